@@ -13,10 +13,12 @@ import (
 	"path/filepath"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/os/series"
+	"github.com/juju/proxy"
 	"github.com/juju/utils"
 	"github.com/juju/utils/shell"
 	"github.com/juju/version"
@@ -77,6 +79,21 @@ func (s APIHostPortsSetter) SetAPIHostPorts(servers []network.HostPorts) error {
 	})
 }
 
+// OverrideProxySettings returns the given proxy settings with any
+// proxy overrides in the agent's config applied.
+func OverrideProxySettings(config Config, settings proxy.Settings) proxy.Settings {
+	if value := config.Value(HTTPProxyOverride); value != "" {
+		settings.Http = value
+	}
+	if value := config.Value(HTTPSProxyOverride); value != "" {
+		settings.Https = value
+	}
+	if value := config.Value(NoProxyOverride); value != "" {
+		settings.NoProxy = value
+	}
+	return settings
+}
+
 // Paths holds the directory paths used by the agent.
 type Paths struct {
 	// DataDir is the data directory where each agent has a subdirectory
@@ -168,6 +185,18 @@ const (
 	// precidence for the agent.
 	LoggingOverride = "LOGGING_OVERRIDE"
 
+	// APIAddressesOverride holds comma separated host:port addresses
+	// which the agent connects to instead of the API addresses
+	// published by the controller.
+	APIAddressesOverride = "API_ADDRESSES_OVERRIDE"
+
+	// HTTPProxyOverride, HTTPSProxyOverride and NoProxyOverride
+	// override the corresponding proxy settings of the model for the
+	// agent's own process.
+	HTTPProxyOverride  = "HTTP_PROXY_OVERRIDE"
+	HTTPSProxyOverride = "HTTPS_PROXY_OVERRIDE"
+	NoProxyOverride    = "NO_PROXY_OVERRIDE"
+
 	LogSinkDBLoggerBufferSize    = "LOGSINK_DBLOGGER_BUFFER_SIZE"
 	LogSinkDBLoggerFlushInterval = "LOGSINK_DBLOGGER_FLUSH_INTERVAL"
 	LogSinkRateLimitBurst        = "LOGSINK_RATELIMIT_BURST"
@@ -700,6 +729,18 @@ func (c *configInternal) APIAddresses() ([]string, error) {
 	return append([]string{}, c.apiDetails.addresses...), nil
 }
 
+// apiAddressesOverride returns the addresses in the API addresses
+// override, if any.
+func (c *configInternal) apiAddressesOverride() []string {
+	var addrs []string
+	for _, addr := range strings.Split(c.values[APIAddressesOverride], ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	return addrs
+}
+
 func (c *configInternal) OldPassword() string {
 	return c.oldPassword
 }
@@ -805,6 +846,9 @@ func (c *configInternal) APIInfo() (*api.Info, bool) {
 	}
 	servingInfo, isController := c.StateServingInfo()
 	addrs := c.apiDetails.addresses
+	if override := c.apiAddressesOverride(); len(override) > 0 {
+		addrs = override
+	}
 	// For controller we return only localhost - we should not connect
 	// to other controllers if we can talk locally.
 	if isController {
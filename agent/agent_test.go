@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"path/filepath"
 
+	"github.com/juju/proxy"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
@@ -512,6 +513,41 @@ func (*suite) TestAPIInfoDoesntAddLocalhostWhenNoServingInfo(c *gc.C) {
 	c.Assert(apiinfo.Addrs, gc.DeepEquals, attrParams.APIAddresses)
 }
 
+func (*suite) TestAPIInfoUsesAPIAddressesOverride(c *gc.C) {
+	attrParams := attributeParams
+	conf, err := agent.NewAgentConfig(attrParams)
+	c.Assert(err, jc.ErrorIsNil)
+	conf.SetValue(agent.APIAddressesOverride, "10.0.0.1:17070, 10.0.0.2:17070")
+	apiinfo, ok := conf.APIInfo()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(apiinfo.Addrs, gc.DeepEquals, []string{"10.0.0.1:17070", "10.0.0.2:17070"})
+
+	// The published addresses are kept, and used again once the
+	// override is removed.
+	addrs, err := conf.APIAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addrs, gc.DeepEquals, attrParams.APIAddresses)
+	conf.SetValue(agent.APIAddressesOverride, "")
+	apiinfo, ok = conf.APIInfo()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(apiinfo.Addrs, gc.DeepEquals, attrParams.APIAddresses)
+}
+
+func (*suite) TestOverrideProxySettings(c *gc.C) {
+	conf, err := agent.NewAgentConfig(attributeParams)
+	c.Assert(err, jc.ErrorIsNil)
+	settings := proxy.Settings{Http: "http://model:3128", NoProxy: "localhost"}
+	c.Assert(agent.OverrideProxySettings(conf, settings), gc.Equals, settings)
+
+	conf.SetValue(agent.HTTPProxyOverride, "http://agent:3128")
+	conf.SetValue(agent.HTTPSProxyOverride, "https://agent:3128")
+	c.Assert(agent.OverrideProxySettings(conf, settings), gc.Equals, proxy.Settings{
+		Http:    "http://agent:3128",
+		Https:   "https://agent:3128",
+		NoProxy: "localhost",
+	})
+}
+
 func (*suite) TestSetPassword(c *gc.C) {
 	attrParams := attributeParams
 	servingInfo := stateServingInfo()
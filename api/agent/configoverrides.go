@@ -0,0 +1,104 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+// ConfigOverrides holds the agent config values overridden by the
+// controller for a machine agent.
+type ConfigOverrides struct {
+	// Values holds the overridden values, keyed by the names defined
+	// in core/agentconfig.
+	Values map[string]string
+
+	// Version is incremented each time the values change.
+	Version int64
+
+	// AppliedVersion is the version of the values which the agent
+	// last reported having applied.
+	AppliedVersion int64
+}
+
+func (st *State) checkConfigOverridesSupported() error {
+	if st.facade.BestAPIVersion() < 3 {
+		return errors.NotSupportedf("agent config overrides by this version of Juju")
+	}
+	return nil
+}
+
+// WatchAgentConfigOverrides returns a watcher which notifies when the
+// agent config values overridden for the given machine change.
+func (st *State) WatchAgentConfigOverrides(tag names.MachineTag) (watcher.NotifyWatcher, error) {
+	if err := st.checkConfigOverridesSupported(); err != nil {
+		return nil, err
+	}
+	var results params.NotifyWatchResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	if err := st.facade.FacadeCall("WatchAgentConfigOverrides", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(st.facade.RawAPICaller(), result), nil
+}
+
+// AgentConfigOverrides returns the agent config values overridden for
+// the given machine.
+func (st *State) AgentConfigOverrides(tag names.MachineTag) (ConfigOverrides, error) {
+	if err := st.checkConfigOverridesSupported(); err != nil {
+		return ConfigOverrides{}, err
+	}
+	var results params.AgentConfigOverridesResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: tag.String()}},
+	}
+	if err := st.facade.FacadeCall("AgentConfigOverrides", args, &results); err != nil {
+		return ConfigOverrides{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return ConfigOverrides{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return ConfigOverrides{}, result.Error
+	}
+	return ConfigOverrides{
+		Values:         result.Values,
+		Version:        result.Version,
+		AppliedVersion: result.AppliedVersion,
+	}, nil
+}
+
+// SetAgentConfigOverridesApplied reports that the given machine's agent
+// has applied the given version of its agent config overrides.
+func (st *State) SetAgentConfigOverridesApplied(tag names.MachineTag, version int64) error {
+	if err := st.checkConfigOverridesSupported(); err != nil {
+		return err
+	}
+	var results params.ErrorResults
+	args := params.AgentConfigOverridesAppliedArgs{
+		Args: []params.AgentConfigOverridesApplied{{
+			Tag:     tag.String(),
+			Version: version,
+		}},
+	}
+	if err := st.facade.FacadeCall("SetAgentConfigOverridesApplied", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
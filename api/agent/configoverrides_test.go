@@ -0,0 +1,92 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/agent"
+	apitesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type ConfigOverridesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ConfigOverridesSuite{})
+
+func (s *ConfigOverridesSuite) newState(c *gc.C, version int, f apitesting.APICallerFunc) *agent.State {
+	st, err := agent.NewState(apitesting.BestVersionCaller{APICallerFunc: f, BestVersion: version})
+	c.Assert(err, jc.ErrorIsNil)
+	return st
+}
+
+func (s *ConfigOverridesSuite) TestAgentConfigOverrides(c *gc.C) {
+	st := s.newState(c, 3, func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Agent")
+		c.Check(request, gc.Equals, "AgentConfigOverrides")
+		c.Check(arg, jc.DeepEquals, params.Entities{Entities: []params.Entity{{Tag: "machine-1"}}})
+		*(result.(*params.AgentConfigOverridesResults)) = params.AgentConfigOverridesResults{
+			Results: []params.AgentConfigOverridesResult{{
+				Values:         map[string]string{"no-proxy": "localhost"},
+				Version:        2,
+				AppliedVersion: 1,
+			}},
+		}
+		return nil
+	})
+	overrides, err := st.AgentConfigOverrides(names.NewMachineTag("1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(overrides, jc.DeepEquals, agent.ConfigOverrides{
+		Values:         map[string]string{"no-proxy": "localhost"},
+		Version:        2,
+		AppliedVersion: 1,
+	})
+}
+
+func (s *ConfigOverridesSuite) TestAgentConfigOverridesError(c *gc.C) {
+	st := s.newState(c, 3, func(_ string, _ int, _, _ string, _, result interface{}) error {
+		*(result.(*params.AgentConfigOverridesResults)) = params.AgentConfigOverridesResults{
+			Results: []params.AgentConfigOverridesResult{{
+				Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+			}},
+		}
+		return nil
+	})
+	_, err := st.AgentConfigOverrides(names.NewMachineTag("1"))
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *ConfigOverridesSuite) TestSetAgentConfigOverridesApplied(c *gc.C) {
+	st := s.newState(c, 3, func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "SetAgentConfigOverridesApplied")
+		c.Check(arg, jc.DeepEquals, params.AgentConfigOverridesAppliedArgs{
+			Args: []params.AgentConfigOverridesApplied{{Tag: "machine-1", Version: 2}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "version 2 not valid"}}},
+		}
+		return nil
+	})
+	err := st.SetAgentConfigOverridesApplied(names.NewMachineTag("1"), 2)
+	c.Assert(err, gc.ErrorMatches, "version 2 not valid")
+}
+
+func (s *ConfigOverridesSuite) TestNotSupported(c *gc.C) {
+	st := s.newState(c, 2, func(string, int, string, string, interface{}, interface{}) error {
+		c.Fatalf("unexpected call")
+		return nil
+	})
+	_, err := st.AgentConfigOverrides(names.NewMachineTag("1"))
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	_, err = st.WatchAgentConfigOverrides(names.NewMachineTag("1"))
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	err = st.SetAgentConfigOverridesApplied(names.NewMachineTag("1"), 1)
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
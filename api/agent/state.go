@@ -12,10 +12,12 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/api/common/cloudspec"
+	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/watcher"
 )
 
 // State provides access to an agent's view of the state.
@@ -60,6 +62,22 @@ func (st *State) getEntity(tag names.Tag) (*params.AgentGetEntitiesResult, error
 	return &results.Entities[0], nil
 }
 
+// WatchControllerConfig returns a watcher which notifies when the
+// controller config changes.
+func (st *State) WatchControllerConfig() (watcher.NotifyWatcher, error) {
+	if st.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("watching controller config by this version of Juju")
+	}
+	var result params.NotifyWatchResult
+	if err := st.facade.FacadeCall("WatchControllerConfig", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return apiwatcher.NewNotifyWatcher(st.facade.RawAPICaller(), result), nil
+}
+
 func (st *State) StateServingInfo() (params.StateServingInfo, error) {
 	var results params.StateServingInfo
 	err := st.facade.FacadeCall("StateServingInfo", nil, &results)
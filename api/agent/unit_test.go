@@ -15,6 +15,7 @@ import (
 	apiagent "github.com/juju/juju/api/agent"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/watcher/watchertest"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 )
@@ -70,3 +71,23 @@ func (s *unitSuite) TestUnitEntity(c *gc.C) {
 	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
 	c.Assert(m, gc.IsNil)
 }
+
+func (s *unitSuite) TestWatchControllerConfig(c *gc.C) {
+	apiSt, err := apiagent.NewState(s.st)
+	c.Assert(err, jc.ErrorIsNil)
+	w, err := apiSt.WatchControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+	wc.AssertOneChange()
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		"features": []interface{}{"new-hotness"},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	config, err := apiSt.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config.Features().Values(), jc.DeepEquals, []string{"new-hotness"})
+}
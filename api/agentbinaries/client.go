@@ -0,0 +1,45 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentbinaries
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the AgentBinaries facade, used to check
+// and repair the replication of agent binaries across the blobstores
+// of the controller nodes.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new AgentBinaries client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "AgentBinaries")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ReplicationStatus returns, for each controller node, the agent
+// binary versions held and missing in the node's blobstore.
+func (c *Client) ReplicationStatus() (params.AgentBinaryReplicationResults, error) {
+	var result params.AgentBinaryReplicationResults
+	if err := c.facade.FacadeCall("ReplicationStatus", nil, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// Repair re-replicates the agent binaries missing from any controller
+// node, returning the outcome for each agent binary version.
+func (c *Client) Repair() ([]params.AgentBinaryRepairResult, error) {
+	var result params.AgentBinaryRepairResults
+	if err := c.facade.FacadeCall("Repair", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Results, nil
+}
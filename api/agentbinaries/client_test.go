@@ -0,0 +1,81 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentbinaries_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/agentbinaries"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type agentBinariesSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&agentBinariesSuite{})
+
+func (s *agentBinariesSuite) TestReplicationStatus(c *gc.C) {
+	status := params.AgentBinaryReplicationResults{
+		Versions: []string{"2.7.0-bionic-amd64"},
+		Results: []params.AgentBinaryReplicationResult{{
+			Node:    "0",
+			Address: "10.0.0.0:37017",
+			Primary: true,
+			Held:    []string{"2.7.0-bionic-amd64"},
+		}},
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "AgentBinaries")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ReplicationStatus")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.AgentBinaryReplicationResults{})
+			*(result.(*params.AgentBinaryReplicationResults)) = status
+			return nil
+		},
+	)
+	client := agentbinaries.NewClient(apiCaller)
+	result, err := client.ReplicationStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, status)
+}
+
+func (s *agentBinariesSuite) TestRepair(c *gc.C) {
+	repairs := []params.AgentBinaryRepairResult{{
+		Version: "2.7.0-bionic-amd64",
+		Nodes:   []string{"2"},
+		Source:  "0",
+	}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "AgentBinaries")
+			c.Check(request, gc.Equals, "Repair")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.AgentBinaryRepairResults{})
+			*(result.(*params.AgentBinaryRepairResults)) = params.AgentBinaryRepairResults{Results: repairs}
+			return nil
+		},
+	)
+	client := agentbinaries.NewClient(apiCaller)
+	result, err := client.Repair()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, repairs)
+}
+
+func (s *agentBinariesSuite) TestReplicationStatusError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			return errors.New("boom")
+		},
+	)
+	client := agentbinaries.NewClient(apiCaller)
+	_, err := client.ReplicationStatus()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,50 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentintrospection
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the AgentIntrospection facade, used to
+// fetch the introspection data of machine and unit agents.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new AgentIntrospection client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "AgentIntrospection")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Introspect fetches a section of the introspection data of the machine
+// or unit agent with the given tag. The result holds either the section
+// itself, or the tag of the action queued to fetch it from the agent,
+// whose output holds the section once it has run.
+func (c *Client) Introspect(agent names.Tag, section string) (params.AgentIntrospectResult, error) {
+	args := params.AgentIntrospectArgs{
+		Args: []params.AgentIntrospectArg{{
+			Tag:     agent.String(),
+			Section: section,
+		}},
+	}
+	var results params.AgentIntrospectResults
+	if err := c.facade.FacadeCall("Introspect", args, &results); err != nil {
+		return params.AgentIntrospectResult{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return params.AgentIntrospectResult{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.AgentIntrospectResult{}, result.Error
+	}
+	return result, nil
+}
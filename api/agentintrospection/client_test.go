@@ -0,0 +1,55 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentintrospection_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/agentintrospection"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type agentIntrospectionSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&agentIntrospectionSuite{})
+
+func (s *agentIntrospectionSuite) TestIntrospect(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "AgentIntrospection")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Introspect")
+			c.Check(a, jc.DeepEquals, params.AgentIntrospectArgs{
+				Args: []params.AgentIntrospectArg{{Tag: "unit-mysql-0", Section: "depengine"}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.AgentIntrospectResults{})
+			*(result.(*params.AgentIntrospectResults)) = params.AgentIntrospectResults{
+				Results: []params.AgentIntrospectResult{{Action: "action-1"}},
+			}
+			return nil
+		})
+	result, err := agentintrospection.NewClient(apiCaller).Introspect(names.NewUnitTag("mysql/0"), "depengine")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AgentIntrospectResult{Action: "action-1"})
+}
+
+func (s *agentIntrospectionSuite) TestIntrospectError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			*(result.(*params.AgentIntrospectResults)) = params.AgentIntrospectResults{
+				Results: []params.AgentIntrospectResult{{
+					Error: &params.Error{Message: `introspection section "secrets" not valid`},
+				}},
+			}
+			return nil
+		})
+	_, err := agentintrospection.NewClient(apiCaller).Introspect(names.NewMachineTag("0"), "secrets")
+	c.Assert(err, gc.ErrorMatches, `introspection section "secrets" not valid`)
+}
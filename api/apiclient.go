@@ -60,6 +60,7 @@ var logger = loggo.GetLogger("juju.api")
 
 type rpcConnection interface {
 	Call(req rpc.Request, params, response interface{}) error
+	CallContext(ctx context.Context, req rpc.Request, params, response interface{}) error
 	Dead() <-chan struct{}
 	Close() error
 }
@@ -138,6 +139,9 @@ type state struct {
 	macaroons []macaroon.Slice
 	nonce     string
 
+	// impersonate holds the user to act as after logging in, if any.
+	impersonate names.UserTag
+
 	// serverRootAddress holds the cached API server address and port used
 	// to login.
 	serverRootAddress string
@@ -252,6 +256,7 @@ func Open(info *Info, opts DialOpts) (Connection, error) {
 		password:     info.Password,
 		macaroons:    info.Macaroons,
 		nonce:        info.Nonce,
+		impersonate:  info.Impersonate,
 		tlsConfig:    dialResult.tlsConfig,
 		bakeryClient: bakeryClient,
 		modelTag:     info.ModelTag,
@@ -1176,8 +1181,14 @@ var apiCallRetryStrategy = retry.LimitTime(10*time.Second,
 // object id, and the specific RPC method. It marshalls the Arguments, and will
 // unmarshall the result into the response object that is supplied.
 func (s *state) APICall(facade string, version int, id, method string, args, response interface{}) error {
-	for a := retry.Start(apiCallRetryStrategy, s.clock); a.Next(); {
-		err := s.client.Call(rpc.Request{
+	return s.APICallContext(context.Background(), facade, version, id, method, args, response)
+}
+
+// APICallContext is like APICall, but stops waiting for the response,
+// and stops retrying, when the given context is done.
+func (s *state) APICallContext(ctx context.Context, facade string, version int, id, method string, args, response interface{}) error {
+	for a := retry.StartWithCancel(apiCallRetryStrategy, s.clock, ctx.Done()); a.Next(); {
+		err := s.client.CallContext(ctx, rpc.Request{
 			Type:    facade,
 			Version: version,
 			Id:      id,
@@ -1190,7 +1201,7 @@ func (s *state) APICall(facade string, version int, id, method string, args, res
 			return errors.Annotatef(err, "too many retries")
 		}
 	}
-	panic("unreachable")
+	return errors.Trace(ctx.Err())
 }
 
 func (s *state) Close() error {
@@ -28,6 +28,7 @@ import (
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/base"
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
@@ -1151,6 +1152,54 @@ func (s *apiclientSuite) TestAPICallRetriesLimit(c *gc.C) {
 	})
 }
 
+func (s *apiclientSuite) TestAPICallContextCancelled(c *gc.C) {
+	rpcConn := newRPCConnection()
+	conn := api.NewTestingState(api.TestingStateParams{
+		RPCConnection: rpcConn,
+		Clock:         &fakeClock{},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := conn.(base.ContextAPICaller).APICallContext(ctx, "facade", 1, "id", "method", nil, nil)
+	c.Check(errors.Cause(err), gc.Equals, context.Canceled)
+	rpcConn.stub.CheckNoCalls(c)
+}
+
+func (s *apiclientSuite) TestAPICallContextStopsRetrying(c *gc.C) {
+	clock := &fakeClock{}
+	retryError := errors.Trace(&rpc.RequestError{Message: "hmm...", Code: params.CodeRetry})
+	rpcConn := newRPCConnection(retryError)
+	conn := api.NewTestingState(api.TestingStateParams{
+		RPCConnection: rpcConn,
+		Clock:         clock,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rpcConn.onCall = cancel
+	err := conn.(base.ContextAPICaller).APICallContext(ctx, "facade", 1, "id", "method", nil, nil)
+	c.Check(errors.Cause(err), gc.Equals, context.Canceled)
+	rpcConn.stub.CheckCallNames(c, "facade.method")
+}
+
+func (s *apiclientSuite) TestWithContext(c *gc.C) {
+	rpcConn := newRPCConnection()
+	conn := api.NewTestingState(api.TestingStateParams{
+		RPCConnection: rpcConn,
+		Clock:         &fakeClock{},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	caller := base.WithContext(ctx, conn)
+	err := caller.APICall("facade", 1, "id", "method", nil, nil)
+	c.Check(err, jc.ErrorIsNil)
+
+	cancel()
+	err = caller.APICall("facade", 1, "id", "method", nil, nil)
+	c.Check(errors.Cause(err), gc.Equals, context.Canceled)
+	rpcConn.stub.CheckCallNames(c, "facade.method")
+}
+
 func (s *apiclientSuite) TestPing(c *gc.C) {
 	clock := &fakeClock{}
 	rpcConn := newRPCConnection()
@@ -1234,6 +1283,65 @@ func (s *apiclientSuite) TestLoginCapturesCLIArgs(c *gc.C) {
 	c.Assert(request.CLIArgs, gc.Equals, `this is "the test" command`)
 }
 
+func (s *apiclientSuite) TestLoginImpersonating(c *gc.C) {
+	info := s.APIInfo(c)
+	conn := newRPCConnection()
+	conn.response = &params.LoginResult{
+		ControllerTag: "controller-" + s.ControllerConfig.ControllerUUID(),
+		ServerVersion: "2.8.0",
+		UserInfo: &params.AuthUserInfo{
+			Identity:         "user-alice",
+			ControllerAccess: "login",
+		},
+	}
+	broken := make(chan struct{})
+	close(broken)
+	testState := api.NewTestingState(api.TestingStateParams{
+		RPCConnection: conn,
+		Clock:         &fakeClock{},
+		Address:       "localhost:1234",
+		Impersonate:   names.NewUserTag("alice"),
+		Broken:        broken,
+		Closed:        make(chan struct{}),
+	})
+	err := testState.Login(info.Tag, info.Password, "", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(testState.AuthTag(), gc.Equals, names.NewUserTag("alice"))
+
+	calls := conn.stub.Calls()
+	c.Assert(calls, gc.HasLen, 1)
+	request := calls[0].Args[1].(*params.LoginRequest)
+	c.Assert(request.ImpersonateTag, gc.Equals, "user-alice")
+}
+
+func (s *apiclientSuite) TestLoginImpersonatingNotSupported(c *gc.C) {
+	info := s.APIInfo(c)
+	conn := newRPCConnection()
+	// Controllers which don't support impersonation log the
+	// caller in as themselves.
+	conn.response = &params.LoginResult{
+		ControllerTag: "controller-" + s.ControllerConfig.ControllerUUID(),
+		ServerVersion: "2.7.0",
+		UserInfo: &params.AuthUserInfo{
+			Identity:         info.Tag.String(),
+			ControllerAccess: "superuser",
+		},
+	}
+	broken := make(chan struct{})
+	close(broken)
+	testState := api.NewTestingState(api.TestingStateParams{
+		RPCConnection: conn,
+		Clock:         &fakeClock{},
+		Address:       "localhost:1234",
+		Impersonate:   names.NewUserTag("alice"),
+		Broken:        broken,
+		Closed:        make(chan struct{}),
+	})
+	err := testState.Login(info.Tag, info.Password, "", nil)
+	c.Assert(err, gc.ErrorMatches, "impersonating users on this controller not supported")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 type clientDNSNameSuite struct {
 	jjtesting.JujuConnSuite
 }
@@ -1296,6 +1404,7 @@ func newRPCConnection(errs ...error) *fakeRPCConnection {
 type fakeRPCConnection struct {
 	stub     testing.Stub
 	response interface{}
+	onCall   func()
 }
 
 func (f *fakeRPCConnection) Dead() <-chan struct{} {
@@ -1306,8 +1415,18 @@ func (f *fakeRPCConnection) Close() error {
 	return nil
 }
 
+func (f *fakeRPCConnection) CallContext(ctx context.Context, req rpc.Request, params, response interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return f.Call(req, params, response)
+}
+
 func (f *fakeRPCConnection) Call(req rpc.Request, params, response interface{}) error {
 	f.stub.AddCall(req.Type+"."+req.Action, req.Version, params)
+	if f.onCall != nil {
+		f.onCall()
+	}
 	if f.response != nil {
 		rv := reflect.ValueOf(response)
 		target := reflect.Indirect(rv)
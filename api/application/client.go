@@ -319,10 +319,18 @@ type SetCharmConfig struct {
 	// EndpointBindings is a map of operator-defined endpoint names to
 	// space names to be merged with any existing endpoint bindings.
 	EndpointBindings map[string]string
+
+	// CanaryUpgrade, if true, upgrades the units of a CAAS application
+	// one at a time, waiting for each one to become ready before the
+	// next is upgraded.
+	CanaryUpgrade bool
 }
 
 // SetCharm sets the charm for a given application.
 func (c *Client) SetCharm(branchName string, cfg SetCharmConfig) error {
+	if cfg.CanaryUpgrade && c.BestAPIVersion() < 18 {
+		return errors.New("this controller does not support canary upgrades")
+	}
 	var storageConstraints map[string]params.StorageConstraints
 	if len(cfg.StorageConstraints) > 0 {
 		storageConstraints = make(map[string]params.StorageConstraints)
@@ -354,6 +362,7 @@ func (c *Client) SetCharm(branchName string, cfg SetCharmConfig) error {
 		ResourceIDs:        cfg.ResourceIDs,
 		StorageConstraints: storageConstraints,
 		EndpointBindings:   cfg.EndpointBindings,
+		CanaryUpgrade:      cfg.CanaryUpgrade,
 		Generation:         branchName,
 	}
 	return c.facade.FacadeCall("SetCharm", args, nil)
@@ -521,6 +530,160 @@ func (c *Client) DestroyUnits(in DestroyUnitsParams) ([]params.DestroyUnitResult
 	return allResults, nil
 }
 
+// CheckUnitsForceRemoval reports, for each of the given units, the
+// status and presence of the unit's agent, and the cleanup steps which
+// will be skipped if the unit is removed with force.
+func (c *Client) CheckUnitsForceRemoval(units []string) ([]params.UnitForceRemovalCheckResult, error) {
+	if c.BestAPIVersion() < 12 {
+		return nil, errors.NotImplementedf("CheckUnitsForceRemoval")
+	}
+	args := params.Entities{
+		Entities: make([]params.Entity, len(units)),
+	}
+	for i, name := range units {
+		if !names.IsValidUnit(name) {
+			return nil, errors.NotValidf("unit ID %q", name)
+		}
+		args.Entities[i].Tag = names.NewUnitTag(name).String()
+	}
+	var result params.UnitForceRemovalCheckResults
+	if err := c.facade.FacadeCall("CheckUnitsForceRemoval", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(result.Results); n != len(units) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(units), n)
+	}
+	return result.Results, nil
+}
+
+// SetUnitsMaintenance puts the given units into maintenance, with a
+// message describing why, or takes them out of maintenance. While a unit
+// is in maintenance its agent runs no hooks, and the unit may not be
+// elected leader of its application.
+func (c *Client) SetUnitsMaintenance(units []string, maintenance bool, message string) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 14 {
+		return nil, errors.NotImplementedf("SetUnitsMaintenance")
+	}
+	args := params.UnitsMaintenance{
+		Units: make([]params.UnitMaintenance, len(units)),
+	}
+	for i, name := range units {
+		if !names.IsValidUnit(name) {
+			return nil, errors.NotValidf("unit ID %q", name)
+		}
+		args.Units[i] = params.UnitMaintenance{
+			Tag:         names.NewUnitTag(name).String(),
+			Maintenance: maintenance,
+			Message:     message,
+		}
+	}
+	var result params.ErrorResults
+	if err := c.facade.FacadeCall("SetUnitsMaintenance", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(result.Results); n != len(units) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(units), n)
+	}
+	return result.Results, nil
+}
+
+// ScalingPolicy returns the scaling policy of the given application.
+func (c *Client) ScalingPolicy(application string) (params.ScalingPolicy, error) {
+	if c.BestAPIVersion() < 15 {
+		return params.ScalingPolicy{}, errors.NotImplementedf("ScalingPolicy")
+	}
+	if !names.IsValidApplication(application) {
+		return params.ScalingPolicy{}, errors.NotValidf("application name %q", application)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.ScalingPolicyResults
+	if err := c.facade.FacadeCall("ScalingPolicies", args, &results); err != nil {
+		return params.ScalingPolicy{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.ScalingPolicy{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.ScalingPolicy{}, err
+	}
+	return *results.Results[0].Result, nil
+}
+
+// SetScalingPolicy sets the scaling policy of the given application.
+// The controller adds units to the application while it has fewer than
+// the policy's minimum units, and replaces units lost along with their
+// force-destroyed machines up to the policy's maximum units.
+func (c *Client) SetScalingPolicy(application string, policy params.ScalingPolicy) error {
+	if c.BestAPIVersion() < 15 {
+		return errors.NotImplementedf("SetScalingPolicy")
+	}
+	if !names.IsValidApplication(application) {
+		return errors.NotValidf("application name %q", application)
+	}
+	args := params.ApplicationScalingPolicies{
+		Policies: []params.ApplicationScalingPolicy{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			Policy:         policy,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetScalingPolicies", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// RelationDetails returns the details of the relation with the given ID.
+// If history is true, the recent changes made to the relation's data bags
+// are returned too, most recent first.
+func (c *Client) RelationDetails(relationId int, history bool) (params.RelationDetails, error) {
+	if c.BestAPIVersion() < 16 {
+		return params.RelationDetails{}, errors.NotImplementedf("RelationDetails")
+	}
+	args := params.RelationDetailsArgs{
+		Args: []params.RelationDetailsArg{{
+			RelationId: relationId,
+			History:    history,
+		}},
+	}
+	var results params.RelationDetailsResults
+	if err := c.facade.FacadeCall("RelationDetails", args, &results); err != nil {
+		return params.RelationDetails{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.RelationDetails{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.RelationDetails{}, err
+	}
+	return *results.Results[0].Result, nil
+}
+
+// DisplayStatus returns the status displayed for the given application,
+// and whether it was derived from the statuses of its units because the
+// application's status has never been set.
+func (c *Client) DisplayStatus(application string) (params.ApplicationDisplayStatus, error) {
+	if c.BestAPIVersion() < 17 {
+		return params.ApplicationDisplayStatus{}, errors.NotImplementedf("DisplayStatus")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.ApplicationDisplayStatusResults
+	if err := c.facade.FacadeCall("DisplayStatus", args, &results); err != nil {
+		return params.ApplicationDisplayStatus{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.ApplicationDisplayStatus{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return params.ApplicationDisplayStatus{}, err
+	}
+	return *results.Results[0].Result, nil
+}
+
 // DestroyDeprecated destroys a given application.
 //
 // NOTE(axw) this exists only for backwards compatibility,
@@ -830,21 +993,32 @@ func (c *Client) AddRelation(endpoints, viaCIDRs []string) (*params.AddRelationR
 }
 
 // DestroyRelation removes the relation between the specified endpoints.
-func (c *Client) DestroyRelation(force *bool, maxWait *time.Duration, endpoints ...string) error {
+// If drainTimeout is set, it overrides the model's relation-drain-timeout.
+func (c *Client) DestroyRelation(force *bool, maxWait, drainTimeout *time.Duration, endpoints ...string) error {
 	args := params.DestroyRelation{
-		Endpoints: endpoints,
-		Force:     force,
-		MaxWait:   maxWait,
+		Endpoints:    endpoints,
+		Force:        force,
+		MaxWait:      maxWait,
+		DrainTimeout: drainTimeout,
 	}
-	return c.facade.FacadeCall("DestroyRelation", args, nil)
+	return c.destroyRelation(args)
 }
 
 // DestroyRelationId removes the relation with the specified id.
-func (c *Client) DestroyRelationId(relationId int, force *bool, maxWait *time.Duration) error {
+// If drainTimeout is set, it overrides the model's relation-drain-timeout.
+func (c *Client) DestroyRelationId(relationId int, force *bool, maxWait, drainTimeout *time.Duration) error {
 	args := params.DestroyRelation{
-		RelationId: relationId,
-		Force:      force,
-		MaxWait:    maxWait,
+		RelationId:   relationId,
+		Force:        force,
+		MaxWait:      maxWait,
+		DrainTimeout: drainTimeout,
+	}
+	return c.destroyRelation(args)
+}
+
+func (c *Client) destroyRelation(args params.DestroyRelation) error {
+	if args.DrainTimeout != nil && c.BestAPIVersion() < 13 {
+		return errors.New("this controller does not support --drain-timeout")
 	}
 	return c.facade.FacadeCall("DestroyRelation", args, nil)
 }
@@ -306,6 +306,46 @@ func (s *applicationSuite) TestSetCharm(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *applicationSuite) TestSetCharmCanaryUpgrade(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Assert(request, gc.Equals, "SetCharm")
+			args, ok := a.(params.ApplicationSetCharm)
+			c.Assert(ok, jc.IsTrue)
+			c.Assert(args.CanaryUpgrade, jc.IsTrue)
+			return nil
+		},
+		BestVersion: 18,
+	}
+	client := application.NewClient(apiCaller)
+	err := client.SetCharm(newBranchName, application.SetCharmConfig{
+		ApplicationName: "application",
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("cs:gitlab-k8s-1"),
+		},
+		CanaryUpgrade: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestSetCharmCanaryUpgradeNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	err := client.SetCharm(newBranchName, application.SetCharmConfig{
+		ApplicationName: "application",
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("cs:gitlab-k8s-1"),
+		},
+		CanaryUpgrade: true,
+	})
+	c.Assert(err, gc.ErrorMatches, "this controller does not support canary upgrades")
+}
+
 func (s *applicationSuite) TestDestroyDeprecated(c *gc.C) {
 	var called bool
 	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
@@ -579,6 +619,248 @@ func (s *applicationSuite) TestDestroyUnits(c *gc.C) {
 	c.Assert(results, jc.DeepEquals, expectedResults)
 }
 
+func (s *applicationSuite) TestCheckUnitsForceRemoval(c *gc.C) {
+	expectedResults := []params.UnitForceRemovalCheckResult{{
+		AgentStatus:  "gone",
+		SkippedSteps: []string{"stop and remove hooks will not run"},
+	}, {
+		Error: &params.Error{Message: "boo"},
+	}}
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "CheckUnitsForceRemoval")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "unit-foo-0"}, {Tag: "unit-bar-1"}},
+			})
+			c.Assert(response, gc.FitsTypeOf, &params.UnitForceRemovalCheckResults{})
+			out := response.(*params.UnitForceRemovalCheckResults)
+			*out = params.UnitForceRemovalCheckResults{Results: expectedResults}
+			return nil
+		},
+		BestVersion: 12,
+	})
+	results, err := client.CheckUnitsForceRemoval([]string{"foo/0", "bar/1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expectedResults)
+}
+
+func (s *applicationSuite) TestCheckUnitsForceRemovalNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	_, err := client.CheckUnitsForceRemoval([]string{"foo/0"})
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *applicationSuite) TestSetUnitsMaintenance(c *gc.C) {
+	expectedResults := []params.ErrorResult{{}, {
+		Error: &params.Error{Message: "boo"},
+	}}
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "SetUnitsMaintenance")
+			c.Assert(a, jc.DeepEquals, params.UnitsMaintenance{
+				Units: []params.UnitMaintenance{
+					{Tag: "unit-foo-0", Maintenance: true, Message: "disk swap"},
+					{Tag: "unit-bar-1", Maintenance: true, Message: "disk swap"},
+				},
+			})
+			c.Assert(response, gc.FitsTypeOf, &params.ErrorResults{})
+			out := response.(*params.ErrorResults)
+			*out = params.ErrorResults{Results: expectedResults}
+			return nil
+		},
+		BestVersion: 14,
+	})
+	results, err := client.SetUnitsMaintenance([]string{"foo/0", "bar/1"}, true, "disk swap")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, expectedResults)
+}
+
+func (s *applicationSuite) TestSetUnitsMaintenanceNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	_, err := client.SetUnitsMaintenance([]string{"foo/0"}, false, "")
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *applicationSuite) TestScalingPolicy(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "ScalingPolicies")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "application-foo"}},
+			})
+			c.Assert(response, gc.FitsTypeOf, &params.ScalingPolicyResults{})
+			out := response.(*params.ScalingPolicyResults)
+			*out = params.ScalingPolicyResults{
+				Results: []params.ScalingPolicyResult{{
+					Result: &params.ScalingPolicy{MinUnits: 1, MaxUnits: 3},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 15,
+	})
+	policy, err := client.ScalingPolicy("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(policy, jc.DeepEquals, params.ScalingPolicy{MinUnits: 1, MaxUnits: 3})
+}
+
+func (s *applicationSuite) TestScalingPolicyError(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			out := response.(*params.ScalingPolicyResults)
+			*out = params.ScalingPolicyResults{
+				Results: []params.ScalingPolicyResult{{
+					Error: &params.Error{Message: "boo"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 15,
+	})
+	_, err := client.ScalingPolicy("foo")
+	c.Assert(err, gc.ErrorMatches, "boo")
+}
+
+func (s *applicationSuite) TestSetScalingPolicy(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "SetScalingPolicies")
+			c.Assert(a, jc.DeepEquals, params.ApplicationScalingPolicies{
+				Policies: []params.ApplicationScalingPolicy{{
+					ApplicationTag: "application-foo",
+					Policy:         params.ScalingPolicy{MinUnits: 1, MaxUnits: 3},
+				}},
+			})
+			c.Assert(response, gc.FitsTypeOf, &params.ErrorResults{})
+			out := response.(*params.ErrorResults)
+			*out = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: &params.Error{Message: "boo"}}},
+			}
+			return nil
+		},
+		BestVersion: 15,
+	})
+	err := client.SetScalingPolicy("foo", params.ScalingPolicy{MinUnits: 1, MaxUnits: 3})
+	c.Assert(err, gc.ErrorMatches, "boo")
+}
+
+func (s *applicationSuite) TestScalingPolicyNotSupported(c *gc.C) {
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	_, err := client.ScalingPolicy("foo")
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+	err = client.SetScalingPolicy("foo", params.ScalingPolicy{MinUnits: 1})
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *applicationSuite) TestRelationDetails(c *gc.C) {
+	details := params.RelationDetails{
+		Id:        3,
+		Key:       "wordpress:db mysql:server",
+		Interface: "mysql",
+		Scope:     "global",
+		Endpoints: []string{"mysql:server", "wordpress:db"},
+		Status:    "joined",
+		History: []params.RelationSettingsChange{{
+			Unit:     "mysql/0",
+			Settings: map[string]string{"host": "10.0.0.1"},
+		}},
+	}
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "RelationDetails")
+			c.Assert(a, jc.DeepEquals, params.RelationDetailsArgs{
+				Args: []params.RelationDetailsArg{{RelationId: 3, History: true}},
+			})
+			c.Assert(response, gc.FitsTypeOf, &params.RelationDetailsResults{})
+			out := response.(*params.RelationDetailsResults)
+			*out = params.RelationDetailsResults{
+				Results: []params.RelationDetailsResult{{Result: &details}},
+			}
+			return nil
+		},
+		BestVersion: 16,
+	})
+	result, err := client.RelationDetails(3, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, details)
+}
+
+func (s *applicationSuite) TestRelationDetailsError(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			out := response.(*params.RelationDetailsResults)
+			*out = params.RelationDetailsResults{
+				Results: []params.RelationDetailsResult{{
+					Error: &params.Error{Message: "boo"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 16,
+	})
+	_, err := client.RelationDetails(3, false)
+	c.Assert(err, gc.ErrorMatches, "boo")
+}
+
+func (s *applicationSuite) TestRelationDetailsNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 15,
+	})
+	_, err := client.RelationDetails(3, false)
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *applicationSuite) TestDisplayStatus(c *gc.C) {
+	displayStatus := params.ApplicationDisplayStatus{
+		Status:  "blocked",
+		Info:    "needs a database",
+		Derived: true,
+	}
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "DisplayStatus")
+			c.Assert(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "application-wordpress"}},
+			})
+			c.Assert(response, gc.FitsTypeOf, &params.ApplicationDisplayStatusResults{})
+			out := response.(*params.ApplicationDisplayStatusResults)
+			*out = params.ApplicationDisplayStatusResults{
+				Results: []params.ApplicationDisplayStatusResult{{Result: &displayStatus}},
+			}
+			return nil
+		},
+		BestVersion: 17,
+	})
+	result, err := client.DisplayStatus("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, displayStatus)
+}
+
+func (s *applicationSuite) TestDisplayStatusNotSupported(c *gc.C) {
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected call to %s", request)
+			return nil
+		},
+		BestVersion: 16,
+	})
+	_, err := client.DisplayStatus("wordpress")
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
 func (s *applicationSuite) TestDestroyUnitsV4(c *gc.C) {
 	expectedResults := []params.DestroyUnitResult{{
 		Error: &params.Error{Message: "boo"},
@@ -724,7 +1006,7 @@ func (s *applicationSuite) TestDestroyRelation(c *gc.C) {
 			return nil
 		})
 
-		err := client.DestroyRelation(t.force, t.maxWait, "ep1", "ep2")
+		err := client.DestroyRelation(t.force, t.maxWait, nil, "ep1", "ep2")
 		c.Assert(err, jc.ErrorIsNil)
 		c.Assert(called, jc.IsTrue)
 	}
@@ -757,12 +1039,42 @@ func (s *applicationSuite) TestDestroyRelationId(c *gc.C) {
 			called = true
 			return nil
 		})
-		err := client.DestroyRelationId(123, t.force, t.maxWait)
+		err := client.DestroyRelationId(123, t.force, t.maxWait, nil)
 		c.Assert(err, jc.ErrorIsNil)
 		c.Assert(called, jc.IsTrue)
 	}
 }
 
+func (s *applicationSuite) TestDestroyRelationDrainTimeout(c *gc.C) {
+	drain := 10 * time.Minute
+	called := false
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Assert(request, gc.Equals, "DestroyRelation")
+			c.Assert(a, jc.DeepEquals, params.DestroyRelation{
+				Endpoints:    []string{"ep1", "ep2"},
+				DrainTimeout: &drain,
+			})
+			called = true
+			return nil
+		},
+		BestVersion: 13,
+	})
+	err := client.DestroyRelation(nil, nil, &drain, "ep1", "ep2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestDestroyRelationDrainTimeoutNotSupported(c *gc.C) {
+	drain := 10 * time.Minute
+	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatalf("unexpected call to %s", request)
+		return nil
+	})
+	err := client.DestroyRelationId(123, nil, nil, &drain)
+	c.Assert(err, gc.ErrorMatches, "this controller does not support --drain-timeout")
+}
+
 func (s *applicationSuite) TestSetRelationSuspended(c *gc.C) {
 	called := false
 	client := newClient(func(objType string, version int, id, request string, a, result interface{}) error {
@@ -0,0 +1,107 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscalinggroups
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+)
+
+const autoscalingGroupsFacade = "AutoscalingGroups"
+
+// Application describes an application backed by a provider
+// autoscaling group.
+type Application struct {
+	Name  string
+	Group string
+
+	// Machines maps the ids of the group's instances for which
+	// machines have been added to the ids of the machines.
+	Machines map[instance.Id]string
+}
+
+// Client provides access to the AutoscalingGroups API facade.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new AutoscalingGroups API client.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{
+		facade: base.NewFacadeCaller(caller, autoscalingGroupsFacade),
+	}
+}
+
+// AutoscaledApplications returns the applications in the model which
+// are backed by provider autoscaling groups.
+func (c *Client) AutoscaledApplications() ([]Application, error) {
+	var result params.AutoscaledApplicationsResult
+	if err := c.facade.FacadeCall("AutoscaledApplications", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	apps := make([]Application, len(result.Results))
+	for i, r := range result.Results {
+		tag, err := names.ParseApplicationTag(r.ApplicationTag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		machines := make(map[instance.Id]string)
+		for _, m := range r.Machines {
+			machineTag, err := names.ParseMachineTag(m.MachineTag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			machines[instance.Id(m.InstanceId)] = machineTag.Id()
+		}
+		apps[i] = Application{
+			Name:     tag.Id(),
+			Group:    r.Group,
+			Machines: machines,
+		}
+	}
+	return apps, nil
+}
+
+// AddMachines adds a machine, hosting a unit of the application, for
+// each of the given instances in the application's autoscaling group.
+func (c *Client) AddMachines(application string, ids []instance.Id) error {
+	instanceIds := make([]string, len(ids))
+	for i, id := range ids {
+		instanceIds[i] = string(id)
+	}
+	args := params.AddAutoscaledMachinesArgs{
+		Args: []params.AddAutoscaledMachines{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			InstanceIds:    instanceIds,
+		}},
+	}
+	var result params.ErrorResults
+	if err := c.facade.FacadeCall("AddAutoscaledMachines", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// RemoveMachines removes the machines with the given ids, which were
+// added for instances that have gone from their autoscaling groups.
+func (c *Client) RemoveMachines(machineIds []string) error {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(machineIds)),
+	}
+	for i, id := range machineIds {
+		args.Entities[i].Tag = names.NewMachineTag(id).String()
+	}
+	var result params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveAutoscaledMachines", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return result.Combine()
+}
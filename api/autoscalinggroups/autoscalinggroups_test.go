@@ -0,0 +1,96 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscalinggroups_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/autoscalinggroups"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+)
+
+type AutoscalingGroupsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&AutoscalingGroupsSuite{})
+
+func (s *AutoscalingGroupsSuite) TestAutoscaledApplications(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "AutoscalingGroups")
+		c.Check(request, gc.Equals, "AutoscaledApplications")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.AutoscaledApplicationsResult{})
+		*(result.(*params.AutoscaledApplicationsResult)) = params.AutoscaledApplicationsResult{
+			Results: []params.AutoscaledApplication{{
+				ApplicationTag: "application-wordpress",
+				Group:          "web",
+				Machines: []params.AutoscaledMachine{{
+					MachineTag: "machine-3",
+					InstanceId: "i-3",
+				}},
+			}},
+		}
+		return nil
+	})
+	client := autoscalinggroups.NewClient(apiCaller)
+	apps, err := client.AutoscaledApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(apps, jc.DeepEquals, []autoscalinggroups.Application{{
+		Name:     "wordpress",
+		Group:    "web",
+		Machines: map[instance.Id]string{"i-3": "3"},
+	}})
+}
+
+func (s *AutoscalingGroupsSuite) TestAutoscaledApplicationsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.AutoscaledApplicationsResult)) = params.AutoscaledApplicationsResult{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+	client := autoscalinggroups.NewClient(apiCaller)
+	_, err := client.AutoscaledApplications()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *AutoscalingGroupsSuite) TestAddMachines(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "AddAutoscaledMachines")
+		c.Check(arg, jc.DeepEquals, params.AddAutoscaledMachinesArgs{
+			Args: []params.AddAutoscaledMachines{{
+				ApplicationTag: "application-wordpress",
+				InstanceIds:    []string{"i-3", "i-4"},
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+	client := autoscalinggroups.NewClient(apiCaller)
+	err := client.AddMachines("wordpress", []instance.Id{"i-3", "i-4"})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *AutoscalingGroupsSuite) TestRemoveMachines(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "RemoveAutoscaledMachines")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "machine-3"}, {Tag: "machine-4"}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}, {}},
+		}
+		return nil
+	})
+	client := autoscalinggroups.NewClient(apiCaller)
+	err := client.RemoveMachines([]string{"3", "4"})
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,64 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package base
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+)
+
+// ContextAPICaller is implemented by APICallers which can make calls
+// that are abandoned when a context is cancelled or its deadline
+// passes. The API connection returned by api.Open implements it.
+type ContextAPICaller interface {
+	APICaller
+
+	// APICallContext is like APICall, but stops waiting for the
+	// response when the given context is done, returning the
+	// context's error.
+	APICallContext(ctx context.Context, objType string, version int, id, request string, params, response interface{}) error
+}
+
+// WithContext returns an APICallCloser which behaves like the given
+// caller, except that every API call it makes is bound to the given
+// context. Facade clients created with the result can be used to make
+// calls which are abandoned when the context is cancelled or its
+// deadline passes:
+//
+//     ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+//     defer cancel()
+//     client := application.NewClient(base.WithContext(ctx, conn))
+//
+// If the caller does not implement ContextAPICaller, the context is
+// only checked before each call is made.
+//
+// Closing the returned value closes the underlying caller.
+func WithContext(ctx context.Context, caller APICallCloser) APICallCloser {
+	return &contextCaller{
+		APICallCloser: caller,
+		ctx:           ctx,
+	}
+}
+
+type contextCaller struct {
+	APICallCloser
+	ctx context.Context
+}
+
+// APICall is part of the APICaller interface.
+func (c *contextCaller) APICall(objType string, version int, id, request string, params, response interface{}) error {
+	return c.APICallContext(c.ctx, objType, version, id, request, params, response)
+}
+
+// APICallContext is part of the ContextAPICaller interface.
+func (c *contextCaller) APICallContext(ctx context.Context, objType string, version int, id, request string, params, response interface{}) error {
+	if caller, ok := c.APICallCloser.(ContextAPICaller); ok {
+		return caller.APICallContext(ctx, objType, version, id, request, params, response)
+	}
+	if err := ctx.Err(); err != nil {
+		return errors.Trace(err)
+	}
+	return c.APICallCloser.APICall(objType, version, id, request, params, response)
+}
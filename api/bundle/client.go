@@ -78,3 +78,55 @@ func (c *Client) ExportBundle() (string, error) {
 
 	return result.Result, nil
 }
+
+// PlanModel returns the changes needed to bring the model to the state
+// described by the given bundle, with a summary of the differences and
+// a fingerprint identifying the plan.
+func (c *Client) PlanModel(args params.ModelPlanArgs) (params.ModelPlanResult, error) {
+	var result params.ModelPlanResult
+	if bestVer := c.BestAPIVersion(); bestVer < 5 {
+		return result, errors.NotSupportedf("planning model changes by this controller")
+	}
+	if err := c.facade.FacadeCall("PlanModel", args, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// ApplyModelPlan applies the changes needed to bring the model to the
+// state described by the given bundle. If a fingerprint is given, the
+// changes are only applied if they match the plan it identifies.
+func (c *Client) ApplyModelPlan(args params.ModelPlanArgs) ([]params.ModelPlanChangeResult, error) {
+	var result params.ApplyModelPlanResult
+	if bestVer := c.BestAPIVersion(); bestVer < 5 {
+		return nil, errors.NotSupportedf("applying model plans by this controller")
+	}
+	if err := c.facade.FacadeCall("ApplyModelPlan", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Results, nil
+}
+
+// AddModelBundle stores a new revision of the bundle which describes
+// the desired state of the model, and returns it.
+func (c *Client) AddModelBundle(bundleDataYAML string) (params.ModelBundle, error) {
+	var result params.ModelBundle
+	if bestVer := c.BestAPIVersion(); bestVer < 6 {
+		return result, errors.NotSupportedf("storing model bundles by this controller")
+	}
+	args := params.AddModelBundleArgs{BundleDataYAML: bundleDataYAML}
+	if err := c.facade.FacadeCall("AddModelBundle", args, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// ApproveModelPlan approves the model plan with the given fingerprint,
+// so that it is applied by the controller.
+func (c *Client) ApproveModelPlan(fingerprint string) error {
+	if bestVer := c.BestAPIVersion(); bestVer < 6 {
+		return errors.NotSupportedf("approving model plans by this controller")
+	}
+	args := params.ApproveModelPlanArgs{Fingerprint: fingerprint}
+	return errors.Trace(c.facade.FacadeCall("ApproveModelPlan", args, nil))
+}
@@ -361,3 +361,187 @@ func (s *bundleMockSuite) TestExportBundleFailNoParamsErrorv2(c *gc.C) {
 	c.Assert(result, jc.DeepEquals, "")
 	c.Check(err.Error(), gc.Matches, "foo")
 }
+
+func (s *bundleMockSuite) TestPlanModel(c *gc.C) {
+	args := params.ModelPlanArgs{
+		BundleDataYAML:      "applications: {}",
+		UseExistingMachines: true,
+	}
+	client := newClient(
+		func(objType string,
+			version int,
+			id,
+			request string,
+			a,
+			response interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Bundle")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "PlanModel")
+			c.Check(a, jc.DeepEquals, args)
+			result := response.(*params.ModelPlanResult)
+			result.Diff = "{}\n"
+			result.Fingerprint = "abc"
+			return nil
+		}, 5,
+	)
+	result, err := client.PlanModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelPlanResult{
+		Diff:        "{}\n",
+		Fingerprint: "abc",
+	})
+}
+
+func (s *bundleMockSuite) TestPlanModelV4(c *gc.C) {
+	client := newClient(
+		func(objType string,
+			version int,
+			id,
+			request string,
+			args,
+			response interface{},
+		) error {
+			c.Fatalf("unexpected call to %q", request)
+			return nil
+		}, 4,
+	)
+	_, err := client.PlanModel(params.ModelPlanArgs{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *bundleMockSuite) TestApplyModelPlan(c *gc.C) {
+	args := params.ModelPlanArgs{
+		BundleDataYAML: "applications: {}",
+		Fingerprint:    "abc",
+	}
+	client := newClient(
+		func(objType string,
+			version int,
+			id,
+			request string,
+			a,
+			response interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Bundle")
+			c.Check(request, gc.Equals, "ApplyModelPlan")
+			c.Check(a, jc.DeepEquals, args)
+			result := response.(*params.ApplyModelPlanResult)
+			result.Results = []params.ModelPlanChangeResult{{
+				Id:     "addMachine-0",
+				Result: "3",
+			}, {
+				Id:    "addUnit-1",
+				Error: &params.Error{Message: "boom"},
+			}}
+			return nil
+		}, 5,
+	)
+	results, err := client.ApplyModelPlan(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ModelPlanChangeResult{{
+		Id:     "addMachine-0",
+		Result: "3",
+	}, {
+		Id:    "addUnit-1",
+		Error: &params.Error{Message: "boom"},
+	}})
+}
+
+func (s *bundleMockSuite) TestApplyModelPlanV4(c *gc.C) {
+	client := newClient(
+		func(objType string,
+			version int,
+			id,
+			request string,
+			args,
+			response interface{},
+		) error {
+			c.Fatalf("unexpected call to %q", request)
+			return nil
+		}, 4,
+	)
+	_, err := client.ApplyModelPlan(params.ModelPlanArgs{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *bundleMockSuite) TestAddModelBundle(c *gc.C) {
+	client := newClient(
+		func(objType string,
+			version int,
+			id,
+			request string,
+			a,
+			response interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Bundle")
+			c.Check(request, gc.Equals, "AddModelBundle")
+			c.Check(a, jc.DeepEquals, params.AddModelBundleArgs{
+				BundleDataYAML: "applications: {}",
+			})
+			result := response.(*params.ModelBundle)
+			result.Revision = 2
+			result.Bundle = "applications: {}"
+			return nil
+		}, 6,
+	)
+	result, err := client.AddModelBundle("applications: {}")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelBundle{
+		Revision: 2,
+		Bundle:   "applications: {}",
+	})
+}
+
+func (s *bundleMockSuite) TestAddModelBundleV5(c *gc.C) {
+	client := newClient(
+		func(objType string,
+			version int,
+			id,
+			request string,
+			args,
+			response interface{},
+		) error {
+			c.Fatalf("unexpected call to %q", request)
+			return nil
+		}, 5,
+	)
+	_, err := client.AddModelBundle("applications: {}")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *bundleMockSuite) TestApproveModelPlan(c *gc.C) {
+	client := newClient(
+		func(objType string,
+			version int,
+			id,
+			request string,
+			a,
+			response interface{},
+		) error {
+			c.Check(objType, gc.Equals, "Bundle")
+			c.Check(request, gc.Equals, "ApproveModelPlan")
+			c.Check(a, jc.DeepEquals, params.ApproveModelPlanArgs{Fingerprint: "abc"})
+			return nil
+		}, 6,
+	)
+	err := client.ApproveModelPlan("abc")
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *bundleMockSuite) TestApproveModelPlanV5(c *gc.C) {
+	client := newClient(
+		func(objType string,
+			version int,
+			id,
+			request string,
+			args,
+			response interface{},
+		) error {
+			c.Fatalf("unexpected call to %q", request)
+			return nil
+		}, 5,
+	)
+	err := client.ApproveModelPlan("abc")
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
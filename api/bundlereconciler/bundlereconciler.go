@@ -0,0 +1,108 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundlereconciler
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
+	apiwatcher "github.com/juju/juju/api/watcher"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+)
+
+const bundleReconcilerFacade = "BundleReconciler"
+
+// Client provides access to the BundleReconciler API facade.
+type Client struct {
+	*common.ModelWatcher
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new BundleReconciler API client.
+func NewClient(caller base.APICaller) *Client {
+	facadeCaller := base.NewFacadeCaller(caller, bundleReconcilerFacade)
+	return &Client{
+		ModelWatcher: common.NewModelWatcher(facadeCaller),
+		facade:       facadeCaller,
+	}
+}
+
+// WatchBundleChanges returns a watcher which triggers whenever a new
+// revision of the model's bundle is stored, or a plan is approved.
+func (c *Client) WatchBundleChanges() (watcher.NotifyWatcher, error) {
+	var result params.NotifyWatchResult
+	if err := c.facade.FacadeCall("WatchBundleChanges", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// DesiredBundle returns the latest revision of the bundle stored in the
+// controller for the model, or a NotFound error if there is none.
+func (c *Client) DesiredBundle() (string, error) {
+	var result params.ModelBundleResult
+	if err := c.facade.FacadeCall("DesiredBundle", nil, &result); err != nil {
+		return "", errors.Trace(err)
+	}
+	if params.IsCodeNotFound(result.Error) {
+		return "", errors.NotFoundf("model bundle")
+	} else if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.Result.Bundle, nil
+}
+
+// ApprovedPlan returns the fingerprint of the most recently approved
+// plan for the model, or an empty string if none has been approved.
+func (c *Client) ApprovedPlan() (string, error) {
+	var result params.StringResult
+	if err := c.facade.FacadeCall("ApprovedPlan", nil, &result); err != nil {
+		return "", errors.Trace(err)
+	}
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
+
+// PlanModel returns the changes needed to bring the model into line
+// with the given bundle.
+func (c *Client) PlanModel(bundle string) (params.ModelPlanResult, error) {
+	var result params.ModelPlanResult
+	args := params.ModelPlanArgs{BundleDataYAML: bundle}
+	if err := c.facade.FacadeCall("PlanModel", args, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// ApplyModelPlan applies the changes needed to bring the model into
+// line with the given bundle, if they match the plan with the given
+// fingerprint.
+func (c *Client) ApplyModelPlan(bundle, fingerprint string) ([]params.ModelPlanChangeResult, error) {
+	var result params.ApplyModelPlanResult
+	args := params.ModelPlanArgs{
+		BundleDataYAML: bundle,
+		Fingerprint:    fingerprint,
+	}
+	if err := c.facade.FacadeCall("ApplyModelPlan", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Results, nil
+}
+
+// SetDrift reports the drift of the model from its bundle in the
+// model's status. An empty message reports that there is no drift.
+func (c *Client) SetDrift(message string, data map[string]interface{}) error {
+	args := params.BundleDrift{
+		Message: message,
+		Data:    data,
+	}
+	return errors.Trace(c.facade.FacadeCall("SetBundleDrift", args, nil))
+}
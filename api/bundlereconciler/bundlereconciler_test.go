@@ -0,0 +1,108 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundlereconciler_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/bundlereconciler"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type BundleReconcilerSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&BundleReconcilerSuite{})
+
+func (s *BundleReconcilerSuite) TestDesiredBundle(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "BundleReconciler")
+		c.Check(request, gc.Equals, "DesiredBundle")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.ModelBundleResult{})
+		*(result.(*params.ModelBundleResult)) = params.ModelBundleResult{
+			Result: &params.ModelBundle{Revision: 2, Bundle: "applications: {}"},
+		}
+		return nil
+	})
+	client := bundlereconciler.NewClient(apiCaller)
+	bundle, err := client.DesiredBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bundle, gc.Equals, "applications: {}")
+}
+
+func (s *BundleReconcilerSuite) TestDesiredBundleNotFound(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ModelBundleResult)) = params.ModelBundleResult{
+			Error: &params.Error{Code: params.CodeNotFound, Message: "model bundle not found"},
+		}
+		return nil
+	})
+	client := bundlereconciler.NewClient(apiCaller)
+	_, err := client.DesiredBundle()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *BundleReconcilerSuite) TestApprovedPlan(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "ApprovedPlan")
+		*(result.(*params.StringResult)) = params.StringResult{Result: "abc"}
+		return nil
+	})
+	client := bundlereconciler.NewClient(apiCaller)
+	fingerprint, err := client.ApprovedPlan()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fingerprint, gc.Equals, "abc")
+}
+
+func (s *BundleReconcilerSuite) TestPlanModel(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "PlanModel")
+		c.Check(arg, jc.DeepEquals, params.ModelPlanArgs{BundleDataYAML: "applications: {}"})
+		*(result.(*params.ModelPlanResult)) = params.ModelPlanResult{Fingerprint: "abc"}
+		return nil
+	})
+	client := bundlereconciler.NewClient(apiCaller)
+	plan, err := client.PlanModel("applications: {}")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(plan.Fingerprint, gc.Equals, "abc")
+}
+
+func (s *BundleReconcilerSuite) TestApplyModelPlan(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "ApplyModelPlan")
+		c.Check(arg, jc.DeepEquals, params.ModelPlanArgs{
+			BundleDataYAML: "applications: {}",
+			Fingerprint:    "abc",
+		})
+		*(result.(*params.ApplyModelPlanResult)) = params.ApplyModelPlanResult{
+			Results: []params.ModelPlanChangeResult{{Id: "addCharm-0", Result: "cs:ubuntu-10"}},
+		}
+		return nil
+	})
+	client := bundlereconciler.NewClient(apiCaller)
+	results, err := client.ApplyModelPlan("applications: {}", "abc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ModelPlanChangeResult{
+		{Id: "addCharm-0", Result: "cs:ubuntu-10"},
+	})
+}
+
+func (s *BundleReconcilerSuite) TestSetDrift(c *gc.C) {
+	data := map[string]interface{}{"bundle-plan": "abc"}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(request, gc.Equals, "SetBundleDrift")
+		c.Check(arg, jc.DeepEquals, params.BundleDrift{Message: "drift", Data: data})
+		c.Check(result, gc.IsNil)
+		return nil
+	})
+	client := bundlereconciler.NewClient(apiCaller)
+	err := client.SetDrift("drift", data)
+	c.Assert(err, jc.ErrorIsNil)
+}
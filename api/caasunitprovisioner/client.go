@@ -158,6 +158,29 @@ type ProvisioningInfo struct {
 	Devices           []devices.KubernetesDeviceParams
 	Tags              map[string]string
 	OperatorImagePath string
+	CanaryUpgrade     bool
+
+	// RegistryCredentials are the model's credentials for the
+	// registries the application's images may be pulled from.
+	RegistryCredentials []RegistryCredential
+
+	// NetworkPolicy, if not nil, determines the traffic allowed to
+	// reach the application's pods.
+	NetworkPolicy *NetworkPolicy
+}
+
+// RegistryCredential holds the credential for an OCI image registry.
+type RegistryCredential struct {
+	Registry string
+	Username string
+	Password string
+}
+
+// NetworkPolicy holds the traffic allowed to reach an application's pods.
+type NetworkPolicy struct {
+	Isolated            bool
+	RelatedApplications []string
+	Exposed             bool
 }
 
 // ProvisioningInfo returns the provisioning info for the specified CAAS
@@ -185,6 +208,7 @@ func (c *Client) ProvisioningInfo(appName string) (*ProvisioningInfo, error) {
 		Constraints:       result.Constraints,
 		Tags:              result.Tags,
 		OperatorImagePath: result.OperatorImagePath,
+		CanaryUpgrade:     result.CanaryUpgrade,
 	}
 	if result.DeploymentInfo != nil {
 		info.DeploymentInfo = DeploymentInfo{
@@ -210,6 +234,22 @@ func (c *Client) ProvisioningInfo(appName string) (*ProvisioningInfo, error) {
 		})
 	}
 	info.Devices = devs
+
+	for _, cred := range result.RegistryCredentials {
+		info.RegistryCredentials = append(info.RegistryCredentials, RegistryCredential{
+			Registry: cred.Registry,
+			Username: cred.Username,
+			Password: cred.Password,
+		})
+	}
+
+	if policy := result.NetworkPolicy; policy != nil {
+		info.NetworkPolicy = &NetworkPolicy{
+			Isolated:            policy.Isolated,
+			RelatedApplications: policy.RelatedApplications,
+			Exposed:             policy.Exposed,
+		}
+	}
 	return info, nil
 }
 
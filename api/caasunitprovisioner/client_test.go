@@ -50,6 +50,7 @@ func (s *unitprovisionerSuite) TestProvisioningInfo(c *gc.C) {
 					Tags:              map[string]string{"foo": "bar"},
 					Constraints:       constraints.MustParse("mem=4G"),
 					OperatorImagePath: "operator/image-path",
+					CanaryUpgrade:     true,
 					DeploymentInfo: &params.KubernetesDeploymentInfo{
 						DeploymentType: "stateful",
 						ServiceType:    "loadbalancer",
@@ -73,6 +74,15 @@ func (s *unitprovisionerSuite) TestProvisioningInfo(c *gc.C) {
 							Attributes: map[string]string{"gpu": "nvidia-tesla-p100"},
 						},
 					},
+					RegistryCredentials: []params.RegistryCredential{{
+						Registry: "registry.example.com",
+						Username: "bob",
+						Password: "secret",
+					}},
+					NetworkPolicy: &params.KubernetesNetworkPolicy{
+						Isolated:            true,
+						RelatedApplications: []string{"mariadb"},
+					},
 				},
 			}},
 		}
@@ -87,6 +97,7 @@ func (s *unitprovisionerSuite) TestProvisioningInfo(c *gc.C) {
 		Tags:              map[string]string{"foo": "bar"},
 		Constraints:       constraints.MustParse("mem=4G"),
 		OperatorImagePath: "operator/image-path",
+		CanaryUpgrade:     true,
 		DeploymentInfo: caasunitprovisioner.DeploymentInfo{
 			DeploymentType: "stateful",
 			ServiceType:    "loadbalancer",
@@ -110,6 +121,15 @@ func (s *unitprovisionerSuite) TestProvisioningInfo(c *gc.C) {
 			Count:      3,
 			Attributes: map[string]string{"gpu": "nvidia-tesla-p100"},
 		}},
+		RegistryCredentials: []caasunitprovisioner.RegistryCredential{{
+			Registry: "registry.example.com",
+			Username: "bob",
+			Password: "secret",
+		}},
+		NetworkPolicy: &caasunitprovisioner.NetworkPolicy{
+			Isolated:            true,
+			RelatedApplications: []string{"mariadb"},
+		},
 	})
 }
 
@@ -64,8 +64,20 @@ func (c *Client) Status(patterns []string) (*params.FullStatus, error) {
 
 // StatusHistory retrieves the last <size> results of
 // <kind:combined|agent|workload|machine|machineinstance|container|containerinstance> status
-// for <name> unit
+// for <name> unit. Given an application tag and a unit kind, it
+// retrieves the interleaved history of all the application's units.
 func (c *Client) StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error) {
+	if c.facade.BestAPIVersion() < 3 {
+		if filter.Until != nil {
+			return status.History{}, errors.NotSupportedf("status history until a time on this controller")
+		}
+		if kind == status.KindApplication {
+			return status.History{}, errors.NotSupportedf("application status history on this controller")
+		}
+	}
+	if c.facade.BestAPIVersion() < 4 && kind != status.KindApplication && tag.Kind() == names.ApplicationTagKind {
+		return status.History{}, errors.NotSupportedf("status history of all of an application's units on this controller")
+	}
 	var results params.StatusHistoryResults
 	args := params.StatusHistoryRequest{
 		Kind: string(kind),
@@ -74,6 +86,7 @@ func (c *Client) StatusHistory(kind status.HistoryKind, tag names.Tag, filter st
 			Date:    filter.FromDate,
 			Delta:   filter.Delta,
 			Exclude: filter.Exclude.Values(),
+			Until:   filter.Until,
 		},
 		Tag: tag.String(),
 	}
@@ -93,23 +106,159 @@ func (c *Client) StatusHistory(kind status.HistoryKind, tag names.Tag, filter st
 		return status.History{}, results.Results[0].History.Error
 	}
 	for i, h := range results.Results[0].History.Statuses {
-		history[i] = status.DetailedStatus{
-			Status:  status.Status(h.Status),
-			Info:    h.Info,
-			Data:    h.Data,
-			Since:   h.Since,
-			Kind:    status.HistoryKind(h.Kind),
-			Version: h.Version,
-			// TODO(perrito666) make sure these are still used.
-			Life: h.Life,
-			Err:  h.Err,
+		history[i] = historyStatus(h)
+	}
+	return history, nil
+}
+
+// historyStatus returns the status history entry for a status returned
+// by the Client facade.
+func historyStatus(h params.DetailedStatus) status.DetailedStatus {
+	result := status.DetailedStatus{
+		Status:  status.Status(h.Status),
+		Info:    h.Info,
+		Data:    h.Data,
+		Since:   h.Since,
+		Kind:    status.HistoryKind(h.Kind),
+		Version: h.Version,
+		// TODO(perrito666) make sure these are still used.
+		Life:       h.Life,
+		Err:        h.Err,
+		Unit:       h.Unit,
+		FirstSince: h.FirstSince,
+		Count:      h.Count,
+	}
+	// TODO(perrito666) https://launchpad.net/bugs/1577589
+	if !result.Kind.Valid() {
+		logger.Errorf("history returned an unknown status kind %q", h.Kind)
+	}
+	return result
+}
+
+// StatusHistoryPage returns up to pageSize entries of the <kind> status
+// history for the entity which match the filter, oldest first, and the
+// cursor with which to read the following page; the cursor is empty if
+// there are no more entries. The cursor is empty to read the first page.
+// The filter must not set Size or Delta.
+func (c *Client) StatusHistoryPage(
+	kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter,
+	pageSize int, cursor string,
+) (status.History, string, error) {
+	if c.facade.BestAPIVersion() < 5 {
+		return nil, "", errors.NotSupportedf("paging through status history on this controller")
+	}
+	args := params.StatusHistoryPageRequest{
+		Tag:  tag.String(),
+		Kind: string(kind),
+		Filter: params.StatusHistoryFilter{
+			Size:    filter.Size,
+			Date:    filter.FromDate,
+			Delta:   filter.Delta,
+			Exclude: filter.Exclude.Values(),
+			Until:   filter.Until,
+		},
+		PageSize: pageSize,
+		Cursor:   cursor,
+	}
+	var result params.StatusHistoryPageResult
+	if err := c.facade.FacadeCall("StatusHistoryPage", args, &result); err != nil {
+		return nil, "", errors.Trace(err)
+	}
+	history := make(status.History, len(result.Statuses))
+	for i, h := range result.Statuses {
+		history[i] = historyStatus(h)
+	}
+	return history, result.Next, nil
+}
+
+// StatusHistoryPages reads the whole of the <kind> status history for
+// the entity which matches the filter, pageSize entries at a time,
+// calling fn with each page in time order. The filter must not set
+// Size, and a Delta is measured from when the first page is read.
+//
+// Controllers with version 5 or later of the Client facade page through
+// the history from its start, so each page is passed to fn as soon as
+// it's read. Older controllers can only page back from its end, so all
+// the pages are read before fn is called.
+func (c *Client) StatusHistoryPages(
+	kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter,
+	pageSize int, fn func(status.History) error,
+) error {
+	if pageSize <= 0 {
+		return errors.NotValidf("page size %d", pageSize)
+	}
+	if filter.Size != 0 {
+		return errors.NotValidf("paging status history with a Size")
+	}
+	if filter.Delta != nil {
+		from := time.Now().Add(-*filter.Delta)
+		filter.FromDate, filter.Delta = &from, nil
+	}
+	if c.facade.BestAPIVersion() < 5 {
+		return c.statusHistoryPagesBackwards(kind, tag, filter, pageSize, fn)
+	}
+	var cursor string
+	for {
+		page, next, err := c.StatusHistoryPage(kind, tag, filter, pageSize, cursor)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(page) > 0 {
+			if err := fn(page); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		if next == "" {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// statusHistoryPagesBackwards implements StatusHistoryPages for
+// controllers without StatusHistoryPage, reading the pages newest first
+// with StatusHistory. Entries set at the same time as the oldest entry
+// in a full page may be missed.
+func (c *Client) statusHistoryPagesBackwards(
+	kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter,
+	pageSize int, fn func(status.History) error,
+) error {
+	// Pages are requested with Size and Until, which can't be combined
+	// with a Date, so entries from before the start of the range are
+	// dropped here.
+	pageFilter := status.StatusHistoryFilter{
+		Size:    pageSize,
+		Until:   filter.Until,
+		Exclude: filter.Exclude,
+	}
+	var pages []status.History
+	for {
+		page, err := c.StatusHistory(kind, tag, pageFilter)
+		if err != nil {
+			return errors.Trace(err)
 		}
-		// TODO(perrito666) https://launchpad.net/bugs/1577589
-		if !history[i].Kind.Valid() {
-			logger.Errorf("history returned an unknown status kind %q", h.Kind)
+		full := len(page) == pageSize
+		if filter.FromDate != nil {
+			for len(page) > 0 && !page[0].Since.After(*filter.FromDate) {
+				page, full = page[1:], false
+			}
+		}
+		if len(page) > 0 {
+			pages = append(pages, page)
+		}
+		if !full {
+			break
 		}
+		// The next page holds the entries from before the oldest one
+		// in this page.
+		pageFilter.Until = page[0].Since
 	}
-	return history, nil
+	for i := len(pages) - 1; i >= 0; i-- {
+		if err := fn(pages[i]); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
 }
 
 // Resolved clears errors on a unit.
@@ -410,6 +559,15 @@ func (c *Client) UploadCharm(curl *charm.URL, content io.ReadSeeker) (*charm.URL
 	if err != nil {
 		return nil, errors.Annotatef(err, "bad charm URL in response")
 	}
+	// The controller rejects charms with errors, so the problems it
+	// reports don't stop the charm being used.
+	for _, f := range resp.LintFindings {
+		if f.Path != "" {
+			logger.Warningf("charm %s: %s: %s", curl, f.Path, f.Message)
+		} else {
+			logger.Warningf("charm %s: %s", curl, f.Message)
+		}
+	}
 	return curl, nil
 }
 
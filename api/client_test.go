@@ -14,6 +14,7 @@ import (
 	"net/url"
 	"os"
 	"path"
+	"strconv"
 	"strings"
 	"time"
 
@@ -34,6 +35,7 @@ import (
 	"github.com/juju/juju/api/common"
 	servercommon "github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
 	jujunames "github.com/juju/juju/juju/names"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/rpc"
@@ -874,3 +876,212 @@ func (s *IsolatedClientSuite) TestFindAllErrorsOnOlderController(c *gc.C) {
 	_, err := client.FindTools(0, 0, "", "", "proposed")
 	c.Assert(err, gc.ErrorMatches, "passing agent-stream not supported by the controller")
 }
+
+func (s *IsolatedClientSuite) TestStatusHistoryUntilErrorsOnOlderController(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 2}
+	client := api.APIClient(apiCaller)
+	until := time.Now()
+	_, err := client.StatusHistory(status.KindUnit, names.NewUnitTag("mysql/0"), status.StatusHistoryFilter{
+		Size:  10,
+		Until: &until,
+	})
+	c.Assert(err, gc.ErrorMatches, "status history until a time on this controller not supported")
+	_, err = client.StatusHistory(status.KindApplication, names.NewApplicationTag("mysql"), status.StatusHistoryFilter{
+		Size: 10,
+	})
+	c.Assert(err, gc.ErrorMatches, "application status history on this controller not supported")
+}
+
+func (s *IsolatedClientSuite) TestStatusHistoryApplicationUnitsErrorsOnOlderController(c *gc.C) {
+	client := api.APIClient(apitesting.BestVersionCaller{BestVersion: 3})
+	_, err := client.StatusHistory(status.KindUnit, names.NewApplicationTag("mysql"), status.StatusHistoryFilter{
+		Size: 10,
+	})
+	c.Assert(err, gc.ErrorMatches, "status history of all of an application's units on this controller not supported")
+}
+
+func (s *IsolatedClientSuite) TestStatusHistoryApplicationUnits(c *gc.C) {
+	since := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 4,
+		APICallerFunc: func(objType string, version int, id, request string, args, response interface{}) error {
+			c.Check(request, gc.Equals, "StatusHistory")
+			c.Check(args.(params.StatusHistoryRequests).Requests[0].Tag, gc.Equals, "application-mysql")
+			*(response.(*params.StatusHistoryResults)) = params.StatusHistoryResults{
+				Results: []params.StatusHistoryResult{{
+					History: params.History{Statuses: []params.DetailedStatus{{
+						Status: "active",
+						Since:  &since,
+						Kind:   "workload",
+						Unit:   "mysql/1",
+					}}},
+				}},
+			}
+			return nil
+		},
+	}
+	client := api.APIClient(apiCaller)
+	history, err := client.StatusHistory(status.KindUnit, names.NewApplicationTag("mysql"), status.StatusHistoryFilter{
+		Size: 10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, status.History{{
+		Status: status.Active,
+		Since:  &since,
+		Kind:   status.KindWorkload,
+		Unit:   "mysql/1",
+	}})
+}
+
+func (s *IsolatedClientSuite) TestStatusHistoryPages(c *gc.C) {
+	base := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	var entries []params.DetailedStatus
+	for i := 1; i <= 5; i++ {
+		since := base.Add(time.Duration(i) * time.Hour)
+		entries = append(entries, params.DetailedStatus{
+			Status: "active",
+			Info:   fmt.Sprintf("entry %d", i),
+			Since:  &since,
+			Kind:   "workload",
+		})
+	}
+	var untils []*time.Time
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: func(objType string, version int, id, request string, args, response interface{}) error {
+			c.Check(objType, gc.Equals, "Client")
+			c.Check(request, gc.Equals, "StatusHistory")
+			filter := args.(params.StatusHistoryRequests).Requests[0].Filter
+			c.Check(filter.Date, gc.IsNil)
+			untils = append(untils, filter.Until)
+			// Return the newest Size entries before Until, in time order.
+			end := len(entries)
+			for end > 0 && filter.Until != nil && !entries[end-1].Since.Before(*filter.Until) {
+				end--
+			}
+			start := end - filter.Size
+			if start < 0 {
+				start = 0
+			}
+			*(response.(*params.StatusHistoryResults)) = params.StatusHistoryResults{
+				Results: []params.StatusHistoryResult{{
+					History: params.History{Statuses: entries[start:end]},
+				}},
+			}
+			return nil
+		},
+	}
+	client := api.APIClient(apiCaller)
+
+	from := base.Add(90 * time.Minute)
+	var pages [][]string
+	err := client.StatusHistoryPages(
+		status.KindWorkload, names.NewUnitTag("mysql/0"),
+		status.StatusHistoryFilter{FromDate: &from}, 2,
+		func(page status.History) error {
+			var infos []string
+			for _, entry := range page {
+				infos = append(infos, entry.Info)
+			}
+			pages = append(pages, infos)
+			return nil
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	// The pages are read newest first, but passed on in time order.
+	c.Assert(pages, jc.DeepEquals, [][]string{
+		{"entry 2", "entry 3"},
+		{"entry 4", "entry 5"},
+	})
+	c.Assert(untils, jc.DeepEquals, []*time.Time{nil, entries[3].Since, entries[1].Since})
+}
+
+func (s *IsolatedClientSuite) TestStatusHistoryPagesWithCursor(c *gc.C) {
+	base := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	var entries []params.DetailedStatus
+	for i := 1; i <= 5; i++ {
+		since := base.Add(time.Duration(i) * time.Hour)
+		entries = append(entries, params.DetailedStatus{
+			Status: "active",
+			Info:   fmt.Sprintf("entry %d", i),
+			Since:  &since,
+			Kind:   "workload",
+		})
+	}
+	var requests []params.StatusHistoryPageRequest
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: func(objType string, version int, id, request string, args, response interface{}) error {
+			c.Check(objType, gc.Equals, "Client")
+			c.Check(request, gc.Equals, "StatusHistoryPage")
+			arg := args.(params.StatusHistoryPageRequest)
+			requests = append(requests, arg)
+			// The cursor is the index of the first entry in the page.
+			start := 0
+			if arg.Cursor != "" {
+				start, _ = strconv.Atoi(arg.Cursor)
+			}
+			end := start + arg.PageSize
+			result := params.StatusHistoryPageResult{}
+			if end < len(entries) {
+				result.Next = strconv.Itoa(end)
+			} else {
+				end = len(entries)
+			}
+			result.Statuses = entries[start:end]
+			*(response.(*params.StatusHistoryPageResult)) = result
+			return nil
+		},
+	}
+	client := api.APIClient(apiCaller)
+
+	var pages [][]string
+	delta := time.Hour
+	err := client.StatusHistoryPages(
+		status.KindWorkload, names.NewUnitTag("mysql/0"),
+		status.StatusHistoryFilter{Delta: &delta}, 2,
+		func(page status.History) error {
+			var infos []string
+			for _, entry := range page {
+				infos = append(infos, entry.Info)
+			}
+			pages = append(pages, infos)
+			return nil
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pages, jc.DeepEquals, [][]string{
+		{"entry 1", "entry 2"},
+		{"entry 3", "entry 4"},
+		{"entry 5"},
+	})
+	c.Assert(requests, gc.HasLen, 3)
+	for i, cursor := range []string{"", "2", "4"} {
+		c.Check(requests[i].Tag, gc.Equals, "unit-mysql-0")
+		c.Check(requests[i].PageSize, gc.Equals, 2)
+		c.Check(requests[i].Cursor, gc.Equals, cursor)
+		// The Delta is turned into a Date once, so that the range
+		// doesn't move on between pages.
+		c.Check(requests[i].Filter.Delta, gc.IsNil)
+		c.Check(requests[i].Filter.Date, gc.NotNil)
+		c.Check(requests[i].Filter.Date, jc.DeepEquals, requests[0].Filter.Date)
+	}
+}
+
+func (s *IsolatedClientSuite) TestStatusHistoryPageErrorsOnOlderController(c *gc.C) {
+	client := api.APIClient(apitesting.BestVersionCaller{BestVersion: 4})
+	_, _, err := client.StatusHistoryPage(
+		status.KindWorkload, names.NewUnitTag("mysql/0"), status.StatusHistoryFilter{}, 10, "",
+	)
+	c.Assert(err, gc.ErrorMatches, "paging through status history on this controller not supported")
+}
+
+func (s *IsolatedClientSuite) TestStatusHistoryPagesRejectsSize(c *gc.C) {
+	client := api.APIClient(apitesting.BestVersionCaller{BestVersion: 3})
+	err := client.StatusHistoryPages(
+		status.KindWorkload, names.NewUnitTag("mysql/0"),
+		status.StatusHistoryFilter{Size: 10}, 2,
+		func(status.History) error { return nil },
+	)
+	c.Assert(err, gc.ErrorMatches, "paging status history with a Size not valid")
+}
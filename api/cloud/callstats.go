@@ -0,0 +1,24 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloud
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// ProviderCallStats returns the statistics for the calls made to cloud
+// providers by the workers on the controller machine serving the API
+// connection.
+func (c *Client) ProviderCallStats() ([]params.ProviderCallStats, error) {
+	if c.BestAPIVersion() < 8 {
+		return nil, errors.NotSupportedf("provider call statistics by this version of Juju")
+	}
+	var result params.ProviderCallStatsResult
+	if err := c.facade.FacadeCall("ProviderCallStats", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Stats, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloud_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	cloudapi "github.com/juju/juju/api/cloud"
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *cloudSuite) TestProviderCallStats(c *gc.C) {
+	stats := []params.ProviderCallStats{{
+		Cloud:     "ec2",
+		Region:    "us-east-1",
+		Caller:    "instance-poller",
+		Method:    "Instances",
+		Calls:     12,
+		Throttled: 1,
+	}}
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				s.called = true
+				c.Check(objType, gc.Equals, "Cloud")
+				c.Check(request, gc.Equals, "ProviderCallStats")
+				c.Check(a, gc.IsNil)
+				*(result.(*params.ProviderCallStatsResult)) = params.ProviderCallStatsResult{Stats: stats}
+				return nil
+			},
+		),
+		BestVersion: 8,
+	}
+	client := cloudapi.NewClient(apiCaller)
+	result, err := client.ProviderCallStats()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, stats)
+	c.Assert(s.called, jc.IsTrue)
+}
+
+func (s *cloudSuite) TestProviderCallStatsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				s.called = true
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := cloudapi.NewClient(apiCaller)
+	_, err := client.ProviderCallStats()
+	c.Assert(err, gc.ErrorMatches, "provider call statistics by this version of Juju not supported")
+	c.Assert(s.called, jc.IsFalse)
+}
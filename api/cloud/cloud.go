@@ -94,17 +94,28 @@ func (c *Client) UserCredentials(user names.UserTag, cloud names.CloudTag) ([]na
 	return tags, nil
 }
 
+// credentialToParams converts a credential to the form
+// used to send it to the controller.
+func credentialToParams(credential jujucloud.Credential) params.CloudCredential {
+	result := params.CloudCredential{
+		AuthType:   string(credential.AuthType()),
+		Attributes: credential.Attributes(),
+	}
+	if !credential.Expiry.IsZero() {
+		expiry := credential.Expiry
+		result.Expiry = &expiry
+	}
+	return result
+}
+
 // UpdateCloudsCredentials updates clouds credentials content on the controller.
 // Passed in credentials are keyed on the credential tag.
 func (c *Client) UpdateCloudsCredentials(cloudCredentials map[string]jujucloud.Credential) ([]params.UpdateCredentialResult, error) {
 	var tagged []params.TaggedCredential
 	for tag, credential := range cloudCredentials {
 		tagged = append(tagged, params.TaggedCredential{
-			Tag: tag,
-			Credential: params.CloudCredential{
-				AuthType:   string(credential.AuthType()),
-				Attributes: credential.Attributes(),
-			},
+			Tag:        tag,
+			Credential: credentialToParams(credential),
 		})
 	}
 	in := params.UpdateCredentialArgs{Credentials: tagged}
@@ -142,6 +153,32 @@ func (c *Client) UpdateCloudsCredentials(cloudCredentials map[string]jujucloud.C
 	return out.Results, nil
 }
 
+// RotateCloudsCredentials replaces the content of cloud credentials stored
+// on the controller, without interrupting the models that use them. The
+// new content must be valid for every model that uses any of the
+// credentials, otherwise none of the credentials are changed.
+func (c *Client) RotateCloudsCredentials(cloudCredentials map[string]jujucloud.Credential) ([]params.UpdateCredentialResult, error) {
+	if c.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("credential rotation by this version of Juju")
+	}
+	var tagged []params.TaggedCredential
+	for tag, credential := range cloudCredentials {
+		tagged = append(tagged, params.TaggedCredential{
+			Tag:        tag,
+			Credential: credentialToParams(credential),
+		})
+	}
+	in := params.TaggedCredentials{Credentials: tagged}
+	var out params.UpdateCredentialResults
+	if err := c.facade.FacadeCall("RotateCredentials", in, &out); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(out.Results) != len(tagged) {
+		return nil, errors.Errorf("expected %d results got %d when rotating credentials", len(tagged), len(out.Results))
+	}
+	return out.Results, nil
+}
+
 // UpdateCredentialsCheckModels updates a cloud credential content
 // stored on the controller. This call validates that the new content works
 // for all models that are using this credential.
@@ -210,14 +247,10 @@ func (c *Client) AddCredential(tag string, credential jujucloud.Credential) erro
 		return errors.NotImplementedf("AddCredential() (need v2+, have v%d)", bestVer)
 	}
 	var results params.ErrorResults
-	cloudCredential := params.CloudCredential{
-		AuthType:   string(credential.AuthType()),
-		Attributes: credential.Attributes(),
-	}
 	args := params.TaggedCredentials{
 		Credentials: []params.TaggedCredential{{
 			Tag:        tag,
-			Credential: cloudCredential,
+			Credential: credentialToParams(credential),
 		},
 		}}
 	if err := c.facade.FacadeCall("AddCredentials", args, &results); err != nil {
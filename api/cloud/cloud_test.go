@@ -5,6 +5,7 @@ package cloud_test
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
@@ -730,6 +731,43 @@ func (s *cloudSuite) TestAddCredentialV2API(c *gc.C) {
 	c.Assert(s.called, jc.IsTrue)
 }
 
+func (s *cloudSuite) TestAddCredentialExpiry(c *gc.C) {
+	expiry := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				s.called = true
+				c.Check(request, gc.Equals, "AddCredentials")
+				c.Check(a, jc.DeepEquals, params.TaggedCredentials{
+					Credentials: []params.TaggedCredential{{
+						Tag: "cloudcred-acloud-user-credname",
+						Credential: params.CloudCredential{
+							AuthType: "userpass",
+							Expiry:   &expiry,
+						},
+					}},
+				})
+				*result.(*params.ErrorResults) = params.ErrorResults{
+					Results: []params.ErrorResult{{}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 2,
+	}
+
+	credential := cloud.NewCredential(cloud.UserPassAuthType, nil)
+	credential.Expiry = expiry
+	client := cloudapi.NewClient(apiCaller)
+	err := client.AddCredential("cloudcred-acloud-user-credname", credential)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.called, jc.IsTrue)
+}
+
 func (s *cloudSuite) TestCredentialContentsArgumentCheck(c *gc.C) {
 	apiCaller := basetesting.BestVersionCaller{BestVersion: 2}
 	client := cloudapi.NewClient(apiCaller)
@@ -1113,6 +1151,69 @@ func (s *cloudSuite) TestUpdateCloudsCredentials(c *gc.C) {
 	c.Assert(s.called, jc.IsTrue)
 }
 
+func (s *cloudSuite) TestRotateCloudsCredentials(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Cloud")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "RotateCredentials")
+				c.Assert(result, gc.FitsTypeOf, &params.UpdateCredentialResults{})
+				c.Assert(a, jc.DeepEquals, params.TaggedCredentials{
+					Credentials: []params.TaggedCredential{{
+						Tag: "cloudcred-foo_bob_bar0",
+						Credential: params.CloudCredential{
+							AuthType: "userpass",
+							Attributes: map[string]string{
+								"username": "admin",
+								"password": "adm1n",
+							},
+						},
+					}}})
+				*result.(*params.UpdateCredentialResults) = params.UpdateCredentialResults{
+					Results: []params.UpdateCredentialResult{{
+						CredentialTag: "cloudcred-foo_bob_bar0",
+					}},
+				}
+				s.called = true
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := cloudapi.NewClient(apiCaller)
+	result, err := client.RotateCloudsCredentials(createCredentials(1))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, []params.UpdateCredentialResult{{
+		CredentialTag: "cloudcred-foo_bob_bar0",
+	}})
+	c.Assert(s.called, jc.IsTrue)
+}
+
+func (s *cloudSuite) TestRotateCloudsCredentialsNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				s.called = true
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := cloudapi.NewClient(apiCaller)
+	_, err := client.RotateCloudsCredentials(createCredentials(1))
+	c.Assert(err, gc.ErrorMatches, "credential rotation by this version of Juju not supported")
+	c.Assert(s.called, jc.IsFalse)
+}
+
 func (s *cloudSuite) TestUpdateCloudsCredentialsErrorV2(c *gc.C) {
 	apiCaller := basetesting.BestVersionCaller{
 		APICallerFunc: basetesting.APICallerFunc(
@@ -0,0 +1,78 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloud
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/cost"
+)
+
+// SetCloudPricing sets the prices of the resources provided by the
+// cloud, which the controller uses to estimate what models cost to run.
+func (c *Client) SetCloudPricing(cloud string, pricing cost.Pricing) error {
+	if c.BestAPIVersion() < 8 {
+		return errors.NotSupportedf("cloud pricing by this version of Juju")
+	}
+	args := params.SetCloudPricingArgs{
+		Args: []params.SetCloudPricingArg{{
+			CloudTag: names.NewCloudTag(cloud).String(),
+			Pricing: params.CloudPricing{
+				Currency:         pricing.Currency,
+				InstanceTypes:    pricing.InstanceTypes,
+				CPUCoreHour:      pricing.CPUCoreHour,
+				MemoryGiBHour:    pricing.MemoryGiBHour,
+				VolumeGiBMonth:   pricing.VolumeGiBMonth,
+				LoadBalancerHour: pricing.LoadBalancerHour,
+			},
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetCloudPricing", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// CloudPricing returns the prices set for the cloud.
+func (c *Client) CloudPricing(cloud string) (cost.Pricing, error) {
+	if c.BestAPIVersion() < 8 {
+		return cost.Pricing{}, errors.NotSupportedf("cloud pricing by this version of Juju")
+	}
+	args := params.Entities{Entities: []params.Entity{{Tag: names.NewCloudTag(cloud).String()}}}
+	var results params.CloudPricingResults
+	if err := c.facade.FacadeCall("CloudPricing", args, &results); err != nil {
+		return cost.Pricing{}, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return cost.Pricing{}, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if err := results.Results[0].Error; err != nil {
+		return cost.Pricing{}, err
+	}
+	p := results.Results[0].Result
+	return cost.Pricing{
+		Currency:         p.Currency,
+		InstanceTypes:    p.InstanceTypes,
+		CPUCoreHour:      p.CPUCoreHour,
+		MemoryGiBHour:    p.MemoryGiBHour,
+		VolumeGiBMonth:   p.VolumeGiBMonth,
+		LoadBalancerHour: p.LoadBalancerHour,
+	}, nil
+}
+
+// RemoveCloudPricing removes the prices set for the cloud.
+func (c *Client) RemoveCloudPricing(cloud string) error {
+	if c.BestAPIVersion() < 8 {
+		return errors.NotSupportedf("cloud pricing by this version of Juju")
+	}
+	args := params.Entities{Entities: []params.Entity{{Tag: names.NewCloudTag(cloud).String()}}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveCloudPricing", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
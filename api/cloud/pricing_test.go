@@ -0,0 +1,140 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloud_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	cloudapi "github.com/juju/juju/api/cloud"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/cost"
+)
+
+func (s *cloudSuite) TestSetCloudPricing(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				s.called = true
+				c.Check(objType, gc.Equals, "Cloud")
+				c.Check(request, gc.Equals, "SetCloudPricing")
+				c.Check(a, jc.DeepEquals, params.SetCloudPricingArgs{
+					Args: []params.SetCloudPricingArg{{
+						CloudTag: "cloud-foo",
+						Pricing: params.CloudPricing{
+							Currency:      "USD",
+							InstanceTypes: map[string]float64{"m5.large": 0.096},
+							CPUCoreHour:   0.03,
+						},
+					}},
+				})
+				*(result.(*params.ErrorResults)) = params.ErrorResults{
+					Results: []params.ErrorResult{{Error: &params.Error{Message: "FAIL"}}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 8,
+	}
+	client := cloudapi.NewClient(apiCaller)
+	err := client.SetCloudPricing("foo", cost.Pricing{
+		Currency:      "USD",
+		InstanceTypes: map[string]float64{"m5.large": 0.096},
+		CPUCoreHour:   0.03,
+	})
+	c.Assert(err, gc.ErrorMatches, "FAIL")
+	c.Assert(s.called, jc.IsTrue)
+}
+
+func (s *cloudSuite) TestCloudPricing(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				s.called = true
+				c.Check(objType, gc.Equals, "Cloud")
+				c.Check(request, gc.Equals, "CloudPricing")
+				c.Check(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "cloud-foo"}},
+				})
+				*(result.(*params.CloudPricingResults)) = params.CloudPricingResults{
+					Results: []params.CloudPricingResult{{
+						Result: &params.CloudPricing{Currency: "EUR", VolumeGiBMonth: 0.1},
+					}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 8,
+	}
+	client := cloudapi.NewClient(apiCaller)
+	pricing, err := client.CloudPricing("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pricing, jc.DeepEquals, cost.Pricing{Currency: "EUR", VolumeGiBMonth: 0.1})
+	c.Assert(s.called, jc.IsTrue)
+}
+
+func (s *cloudSuite) TestCloudPricingError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				*(result.(*params.CloudPricingResults)) = params.CloudPricingResults{
+					Results: []params.CloudPricingResult{{
+						Error: &params.Error{Code: params.CodeNotFound, Message: `pricing for cloud "foo" not found`},
+					}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 8,
+	}
+	client := cloudapi.NewClient(apiCaller)
+	_, err := client.CloudPricing("foo")
+	c.Assert(err, gc.ErrorMatches, `pricing for cloud "foo" not found`)
+	c.Assert(err, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *cloudSuite) TestRemoveCloudPricing(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				s.called = true
+				c.Check(objType, gc.Equals, "Cloud")
+				c.Check(request, gc.Equals, "RemoveCloudPricing")
+				c.Check(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "cloud-foo"}},
+				})
+				*(result.(*params.ErrorResults)) = params.ErrorResults{
+					Results: []params.ErrorResult{{}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 8,
+	}
+	client := cloudapi.NewClient(apiCaller)
+	err := client.RemoveCloudPricing("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.called, jc.IsTrue)
+}
+
+func (s *cloudSuite) TestCloudPricingNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				s.called = true
+				return nil
+			},
+		),
+		BestVersion: 7,
+	}
+	client := cloudapi.NewClient(apiCaller)
+	err := client.SetCloudPricing("foo", cost.Pricing{Currency: "USD"})
+	c.Assert(err, gc.ErrorMatches, "cloud pricing by this version of Juju not supported")
+	_, err = client.CloudPricing("foo")
+	c.Assert(err, gc.ErrorMatches, "cloud pricing by this version of Juju not supported")
+	err = client.RemoveCloudPricing("foo")
+	c.Assert(err, gc.ErrorMatches, "cloud pricing by this version of Juju not supported")
+	c.Assert(s.called, jc.IsFalse)
+}
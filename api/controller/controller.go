@@ -257,6 +257,29 @@ func (c *Client) ConfigSet(values map[string]interface{}) error {
 	)
 }
 
+// FeatureFlagHistory returns the feature flags enabled on the
+// controller, and the changes made to them by users.
+func (c *Client) FeatureFlagHistory() (params.FeatureFlagHistoryResult, error) {
+	var result params.FeatureFlagHistoryResult
+	if c.BestAPIVersion() < 9 {
+		return result, errors.Errorf("this controller version doesn't support feature flag history")
+	}
+	err := c.facade.FacadeCall("FeatureFlagHistory", nil, &result)
+	return result, errors.Trace(err)
+}
+
+// SchemaStatus returns the schema version of the controller's
+// database, and the progress of the online schema migrations run
+// after the controller is upgraded.
+func (c *Client) SchemaStatus() (params.SchemaStatusResult, error) {
+	var result params.SchemaStatusResult
+	if c.BestAPIVersion() < 10 {
+		return result, errors.NotSupportedf("SchemaStatus not supported by this version of Juju")
+	}
+	err := c.facade.FacadeCall("SchemaStatus", nil, &result)
+	return result, errors.Trace(err)
+}
+
 // MigrationSpec holds the details required to start the migration of
 // a single model.
 type MigrationSpec struct {
@@ -5,6 +5,7 @@ package controller_test
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
@@ -383,3 +384,76 @@ func (s *Suite) TestConfigSetAgainstOlderAPIVersion(c *gc.C) {
 	})
 	c.Assert(err, gc.ErrorMatches, "this controller version doesn't support updating controller config")
 }
+
+func (s *Suite) TestFeatureFlagHistory(c *gc.C) {
+	when := time.Date(2020, 1, 3, 14, 0, 0, 0, time.UTC)
+	history := params.FeatureFlagHistoryResult{
+		Enabled: []string{"foo"},
+		Changes: []params.FeatureFlagChange{{
+			Flag:    "foo",
+			Enabled: true,
+			Who:     "bob",
+			Time:    when,
+		}},
+	}
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 9,
+		APICallerFunc: func(objType string, version int, id, request string, args, result interface{}) error {
+			c.Assert(objType, gc.Equals, "Controller")
+			c.Assert(version, gc.Equals, 9)
+			c.Assert(request, gc.Equals, "FeatureFlagHistory")
+			c.Assert(args, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.FeatureFlagHistoryResult{})
+			*(result.(*params.FeatureFlagHistoryResult)) = history
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	result, err := client.FeatureFlagHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, history)
+}
+
+func (s *Suite) TestFeatureFlagHistoryAgainstOlderAPIVersion(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 8}
+	client := controller.NewClient(apiCaller)
+	_, err := client.FeatureFlagHistory()
+	c.Assert(err, gc.ErrorMatches, "this controller version doesn't support feature flag history")
+}
+
+func (s *Suite) TestSchemaStatus(c *gc.C) {
+	started := time.Date(2020, 6, 3, 14, 0, 0, 0, time.UTC)
+	status := params.SchemaStatusResult{
+		Version:       0,
+		LatestVersion: 1,
+		Migrations: []params.SchemaMigration{{
+			Name:      "status-updated-unixnano",
+			Version:   1,
+			Processed: 500,
+			Started:   &started,
+		}},
+	}
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: func(objType string, version int, id, request string, args, result interface{}) error {
+			c.Assert(objType, gc.Equals, "Controller")
+			c.Assert(version, gc.Equals, 10)
+			c.Assert(request, gc.Equals, "SchemaStatus")
+			c.Assert(args, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.SchemaStatusResult{})
+			*(result.(*params.SchemaStatusResult)) = status
+			return nil
+		},
+	}
+	client := controller.NewClient(apiCaller)
+	result, err := client.SchemaStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, status)
+}
+
+func (s *Suite) TestSchemaStatusAgainstOlderAPIVersion(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{BestVersion: 9}
+	client := controller.NewClient(apiCaller)
+	_, err := client.SchemaStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
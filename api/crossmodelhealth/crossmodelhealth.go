@@ -0,0 +1,56 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodelhealth
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const crossModelHealthFacade = "CrossModelHealth"
+
+// Client provides access to the CrossModelHealth API facade.
+type Client struct {
+	*common.ModelWatcher
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new CrossModelHealth API client.
+func NewClient(caller base.APICaller) *Client {
+	facadeCaller := base.NewFacadeCaller(caller, crossModelHealthFacade)
+	return &Client{
+		ModelWatcher: common.NewModelWatcher(facadeCaller),
+		facade:       facadeCaller,
+	}
+}
+
+// OfferConnections returns the connections to offers hosted in the
+// model whose relations are alive and not suspended.
+func (c *Client) OfferConnections() ([]params.OfferConnectionProbe, error) {
+	var result params.OfferConnectionProbesResult
+	if err := c.facade.FacadeCall("OfferConnections", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Connections, nil
+}
+
+// SetOfferConnectionsHealth records the health of each of the given
+// connections.
+func (c *Client) SetOfferConnectionsHealth(health []params.OfferConnectionHealth) error {
+	args := params.OfferConnectionHealthArgs{Connections: health}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetOfferConnectionsHealth", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	if n := len(results.Results); n != len(health) {
+		return errors.Errorf("expected %d results, got %d", len(health), n)
+	}
+	return results.Combine()
+}
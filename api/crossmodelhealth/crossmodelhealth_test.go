@@ -0,0 +1,67 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodelhealth_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/crossmodelhealth"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type CrossModelHealthSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&CrossModelHealthSuite{})
+
+func (s *CrossModelHealthSuite) TestOfferConnections(c *gc.C) {
+	lastContact := time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)
+	probes := []params.OfferConnectionProbe{{
+		RelationTag: "relation-db2.db#django.db",
+		OfferUUID:   "db2-uuid",
+		Username:    "mary",
+		LastContact: &lastContact,
+	}}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CrossModelHealth")
+		c.Check(request, gc.Equals, "OfferConnections")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.OfferConnectionProbesResult{})
+		*(result.(*params.OfferConnectionProbesResult)) = params.OfferConnectionProbesResult{
+			Connections: probes,
+		}
+		return nil
+	})
+	client := crossmodelhealth.NewClient(apiCaller)
+	obtained, err := client.OfferConnections()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(obtained, jc.DeepEquals, probes)
+}
+
+func (s *CrossModelHealthSuite) TestSetOfferConnectionsHealth(c *gc.C) {
+	now := time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)
+	health := []params.OfferConnectionHealth{
+		{RelationTag: "relation-db2.db#django.db", Time: now},
+		{RelationTag: "relation-db2.db#wordpress.db", Message: "no contact", Time: now},
+	}
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "CrossModelHealth")
+		c.Check(request, gc.Equals, "SetOfferConnectionsHealth")
+		c.Check(arg, jc.DeepEquals, params.OfferConnectionHealthArgs{Connections: health})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}, {Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+	client := crossmodelhealth.NewClient(apiCaller)
+	err := client.SetOfferConnectionsHealth(health)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,35 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dashboard
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the Dashboard facade, used to read
+// summaries of many models in a single call.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new Dashboard client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "Dashboard")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Summaries returns the page of model summaries selected by the
+// arguments, together with the total number of models matching the
+// model filters.
+func (c *Client) Summaries(args params.DashboardSummaryArgs) (params.DashboardSummaryResult, error) {
+	var result params.DashboardSummaryResult
+	if err := c.facade.FacadeCall("Summaries", args, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dashboard_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/dashboard"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type dashboardSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&dashboardSuite{})
+
+func (s *dashboardSuite) TestSummaries(c *gc.C) {
+	args := params.DashboardSummaryArgs{
+		ModelFilter: "bob/*",
+		Fields:      []string{params.DashboardApplications},
+		Limit:       10,
+	}
+	summaries := params.DashboardSummaryResult{
+		Models: []params.DashboardModelSummary{{
+			UUID:     coretesting.ModelTag.Id(),
+			Name:     "web",
+			OwnerTag: "user-bob",
+			Applications: []params.DashboardApplicationSummary{{
+				Name:      "wordpress",
+				Charm:     "cs:wordpress-3",
+				UnitCount: 2,
+			}},
+		}},
+		Total: 1,
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "Dashboard")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Summaries")
+			c.Check(a, jc.DeepEquals, args)
+			c.Assert(result, gc.FitsTypeOf, &params.DashboardSummaryResult{})
+			*(result.(*params.DashboardSummaryResult)) = summaries
+			return nil
+		},
+	)
+	client := dashboard.NewClient(apiCaller)
+	result, err := client.Summaries(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, summaries)
+}
+
+func (s *dashboardSuite) TestSummariesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			return errors.New("boom")
+		},
+	)
+	client := dashboard.NewClient(apiCaller)
+	_, err := client.Summaries(params.DashboardSummaryArgs{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
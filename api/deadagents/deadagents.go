@@ -0,0 +1,87 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package deadagents
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+var logger = loggo.GetLogger("juju.api.deadagents")
+
+const deadAgentsFacade = "DeadAgents"
+
+// Client provides access to the DeadAgents API facade.
+type Client struct {
+	*common.ModelWatcher
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new DeadAgents API client.
+func NewClient(caller base.APICaller) *Client {
+	facadeCaller := base.NewFacadeCaller(caller, deadAgentsFacade)
+	return &Client{
+		ModelWatcher: common.NewModelWatcher(facadeCaller),
+		facade:       facadeCaller,
+	}
+}
+
+// MissingUnitAgents returns the units whose agents are lost, and the
+// units which have been marked as having gone agents.
+func (c *Client) MissingUnitAgents() ([]params.MissingUnitAgent, error) {
+	var result params.MissingUnitAgentsResult
+	if err := c.facade.FacadeCall("MissingUnitAgents", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Agents, nil
+}
+
+// MarkUnitAgentsGone marks each of the given units as having a gone
+// agent. It returns the first error it encounters.
+func (c *Client) MarkUnitAgentsGone(units []names.UnitTag) error {
+	return c.bulkCall("MarkUnitAgentsGone", units)
+}
+
+// ClearUnitAgentsGone restores the agent status of each of the given
+// units whose gone agent has returned. It returns the first error it
+// encounters.
+func (c *Client) ClearUnitAgentsGone(units []names.UnitTag) error {
+	return c.bulkCall("ClearUnitAgentsGone", units)
+}
+
+func (c *Client) bulkCall(method string, units []names.UnitTag) error {
+	args := params.Entities{
+		Entities: make([]params.Entity, len(units)),
+	}
+	for i, unit := range units {
+		args.Entities[i].Tag = unit.String()
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall(method, args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	if n := len(results.Results); n != len(units) {
+		return errors.Errorf("expected %d results, got %d", len(units), n)
+	}
+	var err error
+	for i, result := range results.Results {
+		if result.Error == nil {
+			continue
+		}
+		if err == nil {
+			err = errors.Annotatef(result.Error, "unit %q", units[i].Id())
+		} else {
+			logger.Errorf("additional %s error for unit %q: %v", method, units[i].Id(), result.Error)
+		}
+	}
+	return err
+}
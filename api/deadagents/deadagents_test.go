@@ -0,0 +1,77 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package deadagents_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/deadagents"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type DeadAgentsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&DeadAgentsSuite{})
+
+func (s *DeadAgentsSuite) TestMissingUnitAgents(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "DeadAgents")
+		c.Check(request, gc.Equals, "MissingUnitAgents")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.MissingUnitAgentsResult{})
+		*(result.(*params.MissingUnitAgentsResult)) = params.MissingUnitAgentsResult{
+			Agents: []params.MissingUnitAgent{
+				{UnitTag: "unit-mysql-0"},
+				{UnitTag: "unit-mysql-1", Gone: true, Alive: true},
+			},
+		}
+		return nil
+	})
+	client := deadagents.NewClient(apiCaller)
+	agents, err := client.MissingUnitAgents()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(agents, jc.DeepEquals, []params.MissingUnitAgent{
+		{UnitTag: "unit-mysql-0"},
+		{UnitTag: "unit-mysql-1", Gone: true, Alive: true},
+	})
+}
+
+func (s *DeadAgentsSuite) TestMarkUnitAgentsGone(c *gc.C) {
+	s.assertBulkCall(c, "MarkUnitAgentsGone", func(client *deadagents.Client, units []names.UnitTag) error {
+		return client.MarkUnitAgentsGone(units)
+	})
+}
+
+func (s *DeadAgentsSuite) TestClearUnitAgentsGone(c *gc.C) {
+	s.assertBulkCall(c, "ClearUnitAgentsGone", func(client *deadagents.Client, units []names.UnitTag) error {
+		return client.ClearUnitAgentsGone(units)
+	})
+}
+
+func (s *DeadAgentsSuite) assertBulkCall(c *gc.C, method string, call func(*deadagents.Client, []names.UnitTag) error) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "DeadAgents")
+		c.Check(request, gc.Equals, method)
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "unit-mysql-0"}, {Tag: "unit-mysql-1"}},
+		})
+		c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}, {Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+	client := deadagents.NewClient(apiCaller)
+	err := call(client, []names.UnitTag{
+		names.NewUnitTag("mysql/0"),
+		names.NewUnitTag("mysql/1"),
+	})
+	c.Assert(err, gc.ErrorMatches, `unit "mysql/1": boom`)
+}
@@ -72,6 +72,7 @@ type TestingStateParams struct {
 	ServerRoot     string
 	RPCConnection  RPCConnection
 	Clock          clock.Clock
+	Impersonate    names.UserTag
 	Broken, Closed chan struct{}
 }
 
@@ -96,6 +97,7 @@ func NewTestingState(params TestingStateParams) Connection {
 		facadeVersions:    params.FacadeVersions,
 		serverScheme:      params.ServerScheme,
 		serverRootAddress: params.ServerRoot,
+		impersonate:       params.Impersonate,
 		broken:            params.Broken,
 		closed:            params.Closed,
 	}
@@ -13,17 +13,21 @@ package api
 var facadeVersions = map[string]int{
 	"Action":                       5,
 	"ActionPruner":                 1,
-	"Agent":                        2,
+	"Agent":                        4,
+	"AgentBinaries":                1,
+	"AgentIntrospection":           1,
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  11,
+	"Application":                  18,
 	"ApplicationOffers":            2,
 	"ApplicationScaler":            1,
+	"AutoscalingGroups":            1,
 	"Backups":                      2,
 	"Block":                        2,
-	"Bundle":                       4,
+	"Bundle":                       6,
+	"BundleReconciler":             1,
 	"CAASAgent":                    1,
 	"CAASFirewaller":               1,
 	"CAASOperator":                 1,
@@ -33,13 +37,16 @@ var facadeVersions = map[string]int{
 	"CharmRevisionUpdater":         2,
 	"Charms":                       2,
 	"Cleaner":                      2,
-	"Client":                       2,
-	"Cloud":                        6,
-	"Controller":                   8,
+	"Client":                       5,
+	"Cloud":                        8,
+	"Controller":                   10,
 	"CredentialManager":            1,
 	"CredentialValidator":          2,
 	"CrossController":              1,
+	"CrossModelHealth":             1,
 	"CrossModelRelations":          1,
+	"Dashboard":                    1,
+	"DeadAgents":                   1,
 	"Deployer":                     1,
 	"DiskManager":                  2,
 	"EntityWatcher":                2,
@@ -48,23 +55,26 @@ var facadeVersions = map[string]int{
 	"FilesystemAttachmentsWatcher": 2,
 	"Firewaller":                   5,
 	"FirewallRules":                1,
+	"HealthProbes":                 1,
 	"HighAvailability":             2,
 	"HostKeyReporter":              1,
 	"ImageManager":                 2,
 	"ImageMetadata":                3,
 	"ImageMetadataManager":         1,
 	"InstanceMutater":              2,
-	"InstancePoller":               3,
+	"InstancePoller":               5,
 	"KeyManager":                   1,
 	"KeyUpdater":                   1,
+	"LeadershipPinExpiry":          1,
 	"LeadershipService":            2,
+	"Leases":                       3,
 	"LifeFlag":                     1,
 	"LogForwarding":                1,
 	"Logger":                       1,
 	"MachineActions":               1,
-	"MachineManager":               6,
+	"MachineManager":               10,
 	"MachineUndertaker":            1,
-	"Machiner":                     1,
+	"Machiner":                     2,
 	"MeterStatus":                  1,
 	"MetricsAdder":                 2,
 	"MetricsDebug":                 2,
@@ -74,12 +84,16 @@ var facadeVersions = map[string]int{
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
 	"MigrationTarget":              1,
-	"ModelConfig":                  2,
+	"ModelArchive":                 1,
+	"ModelConfig":                  4,
+	"ModelCost":                    1,
 	"ModelGeneration":              4,
-	"ModelManager":                 8,
+	"ModelManager":                 11,
 	"ModelUpgrader":                1,
+	"ModelVerifier":                1,
 	"NotifyWatcher":                1,
 	"OfferStatusWatcher":           1,
+	"OperationsLog":                1,
 	"Payloads":                     1,
 	"PayloadsHookContext":          1,
 	"Pinger":                       1,
@@ -89,27 +103,38 @@ var facadeVersions = map[string]int{
 	"RelationStatusWatcher":        1,
 	"RelationUnitsWatcher":         1,
 	"RemoteRelations":              1,
-	"Resources":                    1,
+	"Resources":                    2,
 	"ResourcesHookContext":         1,
 	"Resumer":                      2,
 	"RetryStrategy":                1,
+	"Search":                       1,
+	"SecurityUpdates":              1,
+	"SecurityUpdatesReporter":      1,
 	"Singular":                     2,
 	"Spaces":                       5,
 	"SSHClient":                    2,
+	"StatusAlerts":                 1,
+	"StatusChanges":                1,
+	"StatusChangesWatcher":         1,
 	"StatusHistory":                2,
+	"StatusSnapshot":               1,
 	"Storage":                      6,
 	"StorageProvisioner":           4,
 	"StringsWatcher":               1,
+	"StuckStatus":                  1,
 	"Subnets":                      3,
-	"Undertaker":                   1,
+	"Undertaker":                   2,
 	"UnitAssigner":                 1,
-	"Uniter":                       13,
+	"Uniter":                       18,
 	"Upgrader":                     1,
 	"UpgradeSeries":                1,
 	"UpgradeSteps":                 1,
 	"UserManager":                  2,
 	"VolumeAttachmentsWatcher":     2,
 	"VolumeAttachmentPlansWatcher": 1,
+	"WorkerFailureReporter":        1,
+	"WorkerFailures":               1,
+	"Zones":                        1,
 }
 
 // bestVersion tries to find the newest version in the version list that we can
@@ -0,0 +1,104 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthprobes
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/healthprobe"
+)
+
+const healthProbesFacade = "HealthProbes"
+
+// Probe describes a health probe to run for a unit.
+type Probe struct {
+	Name     string
+	TCP      string
+	HTTP     string
+	Command  string
+	Interval time.Duration
+	Timeout  time.Duration
+}
+
+// Client provides access to the HealthProbes API facade.
+type Client struct {
+	*common.ModelWatcher
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new HealthProbes API client.
+func NewClient(caller base.APICaller) *Client {
+	facadeCaller := base.NewFacadeCaller(caller, healthProbesFacade)
+	return &Client{
+		ModelWatcher: common.NewModelWatcher(facadeCaller),
+		facade:       facadeCaller,
+	}
+}
+
+// HealthProbes returns the health probes to run for the units on the
+// given machine, keyed by unit name.
+func (c *Client) HealthProbes(machine names.MachineTag) (map[string][]Probe, error) {
+	args := params.Entities{Entities: []params.Entity{{Tag: machine.String()}}}
+	var results params.HealthProbesResults
+	if err := c.facade.FacadeCall("HealthProbes", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	probes := make(map[string][]Probe)
+	for _, unit := range result.Units {
+		tag, err := names.ParseUnitTag(unit.Unit)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		unitProbes := make([]Probe, len(unit.Probes))
+		for i, p := range unit.Probes {
+			unitProbes[i] = Probe{
+				Name:     p.Name,
+				TCP:      p.TCP,
+				HTTP:     p.HTTP,
+				Command:  p.Command,
+				Interval: p.Interval,
+				Timeout:  p.Timeout,
+			}
+		}
+		probes[tag.Id()] = unitProbes
+	}
+	return probes, nil
+}
+
+// SetResults records the latest results of the health probes run on
+// the given machine, keyed by unit name and then probe name.
+func (c *Client) SetResults(machine names.MachineTag, results map[string]map[string]healthprobe.Result) error {
+	arg := params.SetHealthProbeResults{Machine: machine.String()}
+	for unitName, unitResults := range results {
+		units := params.UnitHealthProbeResults{Unit: names.NewUnitTag(unitName).String()}
+		for name, r := range unitResults {
+			units.Results = append(units.Results, params.HealthProbeResult{
+				Name:    name,
+				Healthy: r.Healthy,
+				Message: r.Message,
+				Since:   r.Since,
+			})
+		}
+		arg.Units = append(arg.Units, units)
+	}
+	args := params.SetHealthProbeResultsArgs{Args: []params.SetHealthProbeResults{arg}}
+	var result params.ErrorResults
+	if err := c.facade.FacadeCall("SetHealthProbeResults", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
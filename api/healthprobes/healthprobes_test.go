@@ -0,0 +1,108 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthprobes_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/healthprobes"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/healthprobe"
+)
+
+type HealthProbesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&HealthProbesSuite{})
+
+func (s *HealthProbesSuite) TestHealthProbes(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "HealthProbes")
+		c.Check(request, gc.Equals, "HealthProbes")
+		c.Check(arg, jc.DeepEquals, params.Entities{Entities: []params.Entity{{Tag: "machine-0"}}})
+		c.Assert(result, gc.FitsTypeOf, &params.HealthProbesResults{})
+		*(result.(*params.HealthProbesResults)) = params.HealthProbesResults{
+			Results: []params.HealthProbesResult{{
+				Units: []params.UnitHealthProbes{{
+					Unit: "unit-mysql-0",
+					Probes: []params.HealthProbe{{
+						Name:     "port",
+						TCP:      "localhost:3306",
+						Interval: time.Minute,
+						Timeout:  time.Second,
+					}},
+				}},
+			}},
+		}
+		return nil
+	})
+	client := healthprobes.NewClient(apiCaller)
+	probes, err := client.HealthProbes(names.NewMachineTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(probes, jc.DeepEquals, map[string][]healthprobes.Probe{
+		"mysql/0": {{
+			Name:     "port",
+			TCP:      "localhost:3306",
+			Interval: time.Minute,
+			Timeout:  time.Second,
+		}},
+	})
+}
+
+func (s *HealthProbesSuite) TestHealthProbesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.HealthProbesResults)) = params.HealthProbesResults{
+			Results: []params.HealthProbesResult{{Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+	client := healthprobes.NewClient(apiCaller)
+	_, err := client.HealthProbes(names.NewMachineTag("0"))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *HealthProbesSuite) TestSetResults(c *gc.C) {
+	since := time.Date(2020, 1, 3, 14, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "HealthProbes")
+		c.Check(request, gc.Equals, "SetHealthProbeResults")
+		c.Check(arg, jc.DeepEquals, params.SetHealthProbeResultsArgs{
+			Args: []params.SetHealthProbeResults{{
+				Machine: "machine-0",
+				Units: []params.UnitHealthProbeResults{{
+					Unit: "unit-mysql-0",
+					Results: []params.HealthProbeResult{
+						{Name: "port", Message: "connection refused", Since: since},
+					},
+				}},
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+	client := healthprobes.NewClient(apiCaller)
+	err := client.SetResults(names.NewMachineTag("0"), map[string]map[string]healthprobe.Result{
+		"mysql/0": {"port": {Message: "connection refused", Since: since}},
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *HealthProbesSuite) TestSetResultsCallError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+	client := healthprobes.NewClient(apiCaller)
+	err := client.SetResults(names.NewMachineTag("0"), nil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -60,3 +60,44 @@ func (api *API) WatchModelMachines() (watcher.StringsWatcher, error) {
 	}
 	return newStringsWatcher(api.facade.RawAPICaller(), result), nil
 }
+
+// WatchInstancePollRequests returns a StringsWatcher reporting the ids
+// of the machines whose instances have been asked to be polled without
+// waiting for their next poll.
+func (api *API) WatchInstancePollRequests() (watcher.StringsWatcher, error) {
+	if api.facade.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("instance poll requests by this version of Juju")
+	}
+	var result params.StringsWatchResult
+	err := api.facade.FacadeCall("WatchInstancePollRequests", nil, &result)
+	if err != nil {
+		return nil, err
+	}
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return newStringsWatcher(api.facade.RawAPICaller(), result), nil
+}
+
+// ClearInstancePollRequests removes the requests to poll the instances
+// of the given machines, so that the next request for each is reported
+// again.
+func (api *API) ClearInstancePollRequests(tags []names.MachineTag) error {
+	if api.facade.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("instance poll requests by this version of Juju")
+	}
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var result params.ErrorResult
+	if err := api.facade.FacadeCall("ClearInstancePollRequests", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	if result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
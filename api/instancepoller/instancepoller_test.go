@@ -4,8 +4,7 @@
 package instancepoller_test
 
 import (
-	"errors"
-
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v3"
@@ -175,3 +174,66 @@ func successAPICaller(c *gc.C, method string, expectArgs, useResults interface{}
 		Results:       useResults,
 	})
 }
+
+func (s *InstancePollerSuite) TestWatchInstancePollRequests(c *gc.C) {
+	expectResult := params.StringsWatchResult{
+		StringsWatcherId: "42",
+		Changes:          []string{"1"},
+	}
+	var numWatcherCalls int
+	s.PatchValue(instancepoller.NewStringsWatcher, func(caller base.APICaller, result params.StringsWatchResult) watcher.StringsWatcher {
+		numWatcherCalls++
+		c.Check(result, jc.DeepEquals, expectResult)
+		return nil
+	})
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: func(objType string, _ int, _, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "InstancePoller")
+			c.Check(request, gc.Equals, "WatchInstancePollRequests")
+			c.Check(arg, gc.IsNil)
+			*(result.(*params.StringsWatchResult)) = expectResult
+			return nil
+		},
+	}
+	api := instancepoller.NewAPI(apiCaller)
+	_, err := api.WatchInstancePollRequests()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(numWatcherCalls, gc.Equals, 1)
+}
+
+func (s *InstancePollerSuite) TestClearInstancePollRequests(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 5,
+		APICallerFunc: func(objType string, _ int, _, request string, arg, result interface{}) error {
+			c.Check(request, gc.Equals, "ClearInstancePollRequests")
+			c.Check(arg, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "machine-1"}, {Tag: "machine-2"}},
+			})
+			*(result.(*params.ErrorResult)) = params.ErrorResult{
+				Error: apiservertesting.ServerError("server boom!"),
+			}
+			return nil
+		},
+	}
+	api := instancepoller.NewAPI(apiCaller)
+	err := api.ClearInstancePollRequests([]names.MachineTag{
+		names.NewMachineTag("1"), names.NewMachineTag("2"),
+	})
+	c.Assert(err, gc.ErrorMatches, "server boom!")
+}
+
+func (s *InstancePollerSuite) TestInstancePollRequestsNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 4,
+		APICallerFunc: func(_ string, _ int, _, _ string, _, _ interface{}) error {
+			c.Fatalf("unexpected call")
+			return nil
+		},
+	}
+	api := instancepoller.NewAPI(apiCaller)
+	_, err := api.WatchInstancePollRequests()
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	err = api.ClearInstancePollRequests(nil)
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
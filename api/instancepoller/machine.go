@@ -189,3 +189,22 @@ func (m *Machine) SetProviderAddresses(addrs ...network.ProviderAddress) error {
 	}
 	return result.OneError()
 }
+
+// SetHardwareCharacteristics records the hardware reported by the
+// provider for the machine's instance.
+func (m *Machine) SetHardwareCharacteristics(hc instance.HardwareCharacteristics) error {
+	if m.facade.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("setting hardware characteristics by this version of Juju")
+	}
+	var result params.ErrorResults
+	args := params.SetMachinesHardwareCharacteristics{
+		Machines: []params.MachineHardwareCharacteristics{{
+			Tag:             m.tag.String(),
+			Characteristics: hc,
+		}}}
+	err := m.facade.FacadeCall("SetHardwareCharacteristics", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
@@ -7,6 +7,7 @@ import (
 	"reflect"
 	"time"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v3"
@@ -262,6 +263,49 @@ func (s *MachineSuite) TestSetProviderAddressesSuccess(c *gc.C) {
 	c.Check(apiCaller.CallCount, gc.Equals, 1)
 }
 
+func (s *MachineSuite) TestSetHardwareCharacteristicsSuccess(c *gc.C) {
+	mem := uint64(8192)
+	cores := uint64(4)
+	hc := instance.HardwareCharacteristics{Mem: &mem, CpuCores: &cores}
+	expectArgs := params.SetMachinesHardwareCharacteristics{
+		Machines: []params.MachineHardwareCharacteristics{{
+			Tag:             "machine-42",
+			Characteristics: hc,
+		}}}
+	var called bool
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 4,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "InstancePoller")
+			c.Check(version, gc.Equals, 4)
+			c.Check(request, gc.Equals, "SetHardwareCharacteristics")
+			c.Check(arg, jc.DeepEquals, expectArgs)
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: nil}},
+			}
+			return nil
+		},
+	}
+	machine := instancepoller.NewMachine(apiCaller, s.tag, life.Alive)
+	err := machine.SetHardwareCharacteristics(hc)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(called, jc.IsTrue)
+}
+
+func (s *MachineSuite) TestSetHardwareCharacteristicsNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: func(_ string, _ int, _, _ string, _, _ interface{}) error {
+			c.Fatalf("facade call was not expected")
+			return nil
+		},
+	}
+	machine := instancepoller.NewMachine(apiCaller, s.tag, life.Alive)
+	err := machine.SetHardwareCharacteristics(instance.HardwareCharacteristics{})
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *MachineSuite) CheckClientError(c *gc.C, wf methodWrapper) {
 	apiCaller := clientErrorAPICaller(c, "", nil)
 	machine := instancepoller.NewMachine(apiCaller, s.tag, life.Alive)
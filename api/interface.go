@@ -80,6 +80,12 @@ type Info struct {
 	// Nonce holds the nonce used when provisioning the machine. Used
 	// only by the machine agent.
 	Nonce string `yaml:",omitempty"`
+
+	// Impersonate optionally holds the user that a controller
+	// superuser logging in acts as. The controller records everything
+	// done on the connection in its audit log. HTTP requests made
+	// with the connection are not impersonated.
+	Impersonate names.UserTag `yaml:"-"`
 }
 
 // Ports returns the unique ports for the api addresses.
@@ -0,0 +1,39 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadershippinexpiry
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+const leadershipPinExpiryFacade = "LeadershipPinExpiry"
+
+// Client provides access to the LeadershipPinExpiry API facade.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new LeadershipPinExpiry API client.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{
+		facade: base.NewFacadeCaller(caller, leadershipPinExpiryFacade),
+	}
+}
+
+// UnpinExpiredLeaders lifts the expired pins on the leaders of the
+// model's applications, returning the names of the applications whose
+// leaders were unpinned.
+func (c *Client) UnpinExpiredLeaders() ([]string, error) {
+	var result params.StringsResult
+	if err := c.facade.FacadeCall("UnpinExpiredLeaders", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadershippinexpiry_test
+
+import (
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/leadershippinexpiry"
+	"github.com/juju/juju/apiserver/params"
+)
+
+type LeadershipPinExpirySuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&LeadershipPinExpirySuite{})
+
+func (s *LeadershipPinExpirySuite) TestUnpinExpiredLeaders(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "LeadershipPinExpiry")
+		c.Check(request, gc.Equals, "UnpinExpiredLeaders")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.StringsResult{})
+		*(result.(*params.StringsResult)) = params.StringsResult{
+			Result: []string{"mysql", "redis"},
+		}
+		return nil
+	})
+	client := leadershippinexpiry.NewClient(apiCaller)
+	unpinned, err := client.UnpinExpiredLeaders()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unpinned, jc.DeepEquals, []string{"mysql", "redis"})
+}
+
+func (s *LeadershipPinExpirySuite) TestUnpinExpiredLeadersError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.StringsResult)) = params.StringsResult{
+			Error: &params.Error{Message: "boom"},
+		}
+		return nil
+	})
+	_, err := leadershippinexpiry.NewClient(apiCaller).UnpinExpiredLeaders()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
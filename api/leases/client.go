@@ -0,0 +1,99 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leases
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the Leases facade, used to inspect and
+// revoke the leadership and singular leases of a model, and to compact
+// the lease store.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new Leases client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "Leases")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ShowLeases returns the details of the leases held in the model.
+func (c *Client) ShowLeases() ([]params.LeaseDetails, error) {
+	var result params.LeaseDetailsResult
+	if err := c.facade.FacadeCall("ShowLeases", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Leases, nil
+}
+
+// RevokeLease forcibly vacates the named lease in the given namespace.
+func (c *Client) RevokeLease(namespace, leaseName string) error {
+	args := params.RevokeLeaseArgs{
+		Args: []params.RevokeLeaseArg{{
+			Namespace: namespace,
+			Lease:     leaseName,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RevokeLeases", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// SnapshotLeaseStore asks every controller machine to snapshot the
+// lease store and compact its raft log. The snapshots are taken in the
+// background, after this call returns.
+func (c *Client) SnapshotLeaseStore() error {
+	if bestVer := c.BestAPIVersion(); bestVer < 2 {
+		return errors.NotImplementedf("SnapshotLeaseStore in version %v", bestVer)
+	}
+	return errors.Trace(c.facade.FacadeCall("SnapshotLeaseStore", nil, nil))
+}
+
+// PinLeader pins the leader of the application for the given duration,
+// so that leadership doesn't change during maintenance. The reason is
+// recorded in the application's status data along with the pin.
+func (c *Client) PinLeader(application string, duration time.Duration, reason string) error {
+	if bestVer := c.BestAPIVersion(); bestVer < 3 {
+		return errors.NotImplementedf("PinLeaders in version %v", bestVer)
+	}
+	args := params.PinLeaderArgs{
+		Args: []params.PinLeaderArg{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			Duration:       duration,
+			Reason:         reason,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("PinLeaders", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// UnpinLeader removes the pin placed on the leader of the application
+// by PinLeader before it expires.
+func (c *Client) UnpinLeader(application string) error {
+	if bestVer := c.BestAPIVersion(); bestVer < 3 {
+		return errors.NotImplementedf("UnpinLeaders in version %v", bestVer)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("UnpinLeaders", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
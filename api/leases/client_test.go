@@ -0,0 +1,139 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leases_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/leases"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type leasesSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&leasesSuite{})
+
+func (s *leasesSuite) TestShowLeases(c *gc.C) {
+	expiry := time.Date(2020, 4, 1, 10, 0, 0, 0, time.UTC)
+	details := []params.LeaseDetails{{
+		Namespace: "application-leadership",
+		Lease:     "redis",
+		Holder:    "redis/0",
+		Expiry:    expiry,
+	}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "Leases")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ShowLeases")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.LeaseDetailsResult{})
+			*(result.(*params.LeaseDetailsResult)) = params.LeaseDetailsResult{Leases: details}
+			return nil
+		})
+	result, err := leases.NewClient(apiCaller).ShowLeases()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, details)
+}
+
+func (s *leasesSuite) TestRevokeLease(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "Leases")
+			c.Check(request, gc.Equals, "RevokeLeases")
+			c.Check(a, jc.DeepEquals, params.RevokeLeaseArgs{
+				Args: []params.RevokeLeaseArg{{Namespace: "application-leadership", Lease: "redis"}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+			}
+			return nil
+		})
+	err := leases.NewClient(apiCaller).RevokeLease("application-leadership", "redis")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *leasesSuite) TestSnapshotLeaseStore(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 2,
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Leases")
+				c.Check(request, gc.Equals, "SnapshotLeaseStore")
+				c.Check(a, gc.IsNil)
+				called = true
+				return nil
+			}),
+	}
+	err := leases.NewClient(apiCaller).SnapshotLeaseStore()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *leasesSuite) TestSnapshotLeaseStoreNotImplemented(c *gc.C) {
+	err := leases.NewClient(basetesting.BestVersionCaller{BestVersion: 1}).SnapshotLeaseStore()
+	c.Assert(err, gc.ErrorMatches, "SnapshotLeaseStore in version 1 not implemented")
+}
+
+func (s *leasesSuite) TestPinLeader(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Leases")
+				c.Check(request, gc.Equals, "PinLeaders")
+				c.Check(a, jc.DeepEquals, params.PinLeaderArgs{
+					Args: []params.PinLeaderArg{{
+						ApplicationTag: "application-mysql",
+						Duration:       time.Hour,
+						Reason:         "database migration",
+					}},
+				})
+				c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+				*(result.(*params.ErrorResults)) = params.ErrorResults{
+					Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+				}
+				return nil
+			}),
+	}
+	err := leases.NewClient(apiCaller).PinLeader("mysql", time.Hour, "database migration")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *leasesSuite) TestPinLeaderNotImplemented(c *gc.C) {
+	err := leases.NewClient(basetesting.BestVersionCaller{BestVersion: 2}).PinLeader("mysql", time.Hour, "")
+	c.Assert(err, gc.ErrorMatches, "PinLeaders in version 2 not implemented")
+}
+
+func (s *leasesSuite) TestUnpinLeader(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 3,
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, result interface{}) error {
+				c.Check(objType, gc.Equals, "Leases")
+				c.Check(request, gc.Equals, "UnpinLeaders")
+				c.Check(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "application-mysql"}},
+				})
+				*(result.(*params.ErrorResults)) = params.ErrorResults{
+					Results: []params.ErrorResult{{}},
+				}
+				called = true
+				return nil
+			}),
+	}
+	err := leases.NewClient(apiCaller).UnpinLeader("mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
@@ -133,6 +133,146 @@ func (client *Client) destroyMachines(method string, machines []string) ([]param
 	return allResults, nil
 }
 
+// ProxySettings returns the proxy settings in effect on each of the
+// given machines.
+func (client *Client) ProxySettings(machines ...string) ([]params.ProxyConfigResult, error) {
+	if client.BestAPIVersion() < 7 {
+		return nil, errors.NotSupportedf("querying machine proxy settings by this version of Juju")
+	}
+	args := params.Entities{
+		Entities: make([]params.Entity, 0, len(machines)),
+	}
+	allResults := make([]params.ProxyConfigResult, len(machines))
+	index := make([]int, 0, len(machines))
+	for i, machineId := range machines {
+		if !names.IsValidMachine(machineId) {
+			allResults[i].Error = &params.Error{
+				Message: errors.NotValidf("machine ID %q", machineId).Error(),
+			}
+			continue
+		}
+		index = append(index, i)
+		args.Entities = append(args.Entities, params.Entity{
+			Tag: names.NewMachineTag(machineId).String(),
+		})
+	}
+	if len(args.Entities) > 0 {
+		var result params.ProxyConfigResults
+		if err := client.facade.FacadeCall("ProxySettings", args, &result); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n := len(result.Results); n != len(args.Entities) {
+			return nil, errors.Errorf("expected %d result(s), got %d", len(args.Entities), n)
+		}
+		for i, result := range result.Results {
+			allResults[index[i]] = result
+		}
+	}
+	return allResults, nil
+}
+
+// MachineConsoleLog returns the console output of the given machine's
+// instance, along with the status of cloud-init determined from it.
+func (client *Client) MachineConsoleLog(machineId string) (params.MachineConsoleLogResult, error) {
+	if client.BestAPIVersion() < 8 {
+		return params.MachineConsoleLogResult{}, errors.NotSupportedf("retrieving machine console logs by this version of Juju")
+	}
+	if !names.IsValidMachine(machineId) {
+		return params.MachineConsoleLogResult{}, errors.NotValidf("machine ID %q", machineId)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewMachineTag(machineId).String()}},
+	}
+	var results params.MachineConsoleLogResults
+	if err := client.facade.FacadeCall("MachineConsoleLog", args, &results); err != nil {
+		return params.MachineConsoleLogResult{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.MachineConsoleLogResult{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.MachineConsoleLogResult{}, result.Error
+	}
+	return result, nil
+}
+
+// SetAgentConfigOverrides overrides agent config values of the given
+// machine's agent, which applies them without restarting. Values not
+// given are left as they are; an empty value removes the override.
+func (client *Client) SetAgentConfigOverrides(machineId string, values map[string]string) error {
+	if client.BestAPIVersion() < 9 {
+		return errors.NotSupportedf("overriding agent config by this version of Juju")
+	}
+	if !names.IsValidMachine(machineId) {
+		return errors.NotValidf("machine ID %q", machineId)
+	}
+	args := params.SetAgentConfigOverridesArgs{
+		Args: []params.SetAgentConfigOverridesArg{{
+			Tag:    names.NewMachineTag(machineId).String(),
+			Values: values,
+		}},
+	}
+	var results params.ErrorResults
+	if err := client.facade.FacadeCall("SetAgentConfigOverrides", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// AgentConfigOverrides returns the agent config values overridden for
+// the given machine's agent, and the version of them it has applied.
+func (client *Client) AgentConfigOverrides(machineId string) (params.AgentConfigOverridesResult, error) {
+	if client.BestAPIVersion() < 9 {
+		return params.AgentConfigOverridesResult{}, errors.NotSupportedf("overriding agent config by this version of Juju")
+	}
+	if !names.IsValidMachine(machineId) {
+		return params.AgentConfigOverridesResult{}, errors.NotValidf("machine ID %q", machineId)
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewMachineTag(machineId).String()}},
+	}
+	var results params.AgentConfigOverridesResults
+	if err := client.facade.FacadeCall("AgentConfigOverrides", args, &results); err != nil {
+		return params.AgentConfigOverridesResult{}, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return params.AgentConfigOverridesResult{}, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return params.AgentConfigOverridesResult{}, result.Error
+	}
+	return result, nil
+}
+
+// SyncMachineAddresses asks the controller to refresh the addresses and
+// status of the given machines' instances from the provider now, rather
+// than at their next poll, returning a result for each. If no machine
+// IDs are given, all the machines' instances are refreshed.
+func (client *Client) SyncMachineAddresses(machineIds ...string) ([]params.ErrorResult, error) {
+	if client.BestAPIVersion() < 10 {
+		return nil, errors.NotSupportedf("syncing machine addresses by this version of Juju")
+	}
+	args := params.Entities{
+		Entities: make([]params.Entity, len(machineIds)),
+	}
+	for i, id := range machineIds {
+		if !names.IsValidMachine(id) {
+			return nil, errors.NotValidf("machine ID %q", id)
+		}
+		args.Entities[i].Tag = names.NewMachineTag(id).String()
+	}
+	var results params.ErrorResults
+	if err := client.facade.FacadeCall("SyncMachineAddresses", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != len(machineIds) {
+		return nil, errors.Errorf("expected %d result(s), got %d", len(machineIds), n)
+	}
+	return results.Results, nil
+}
+
 // UpgradeSeriesPrepare notifies the controller that a series upgrade is taking
 // place for a given machine and as such the machine is guarded against
 // operations that would impede, fail, or interfere with the upgrade process.
@@ -248,3 +248,211 @@ func (s *MachinemanagerSuite) TestDestroyMachinesWithParamsNilWait(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, expected)
 }
+
+func (s *MachinemanagerSuite) TestProxySettings(c *gc.C) {
+	settings := params.ProxyConfigResult{
+		JujuProxySettings: params.ProxyConfig{
+			HTTP:    "http://proxy.example.com:3128",
+			NoProxy: "10.0.0.1",
+		},
+	}
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 7,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Check(objType, gc.Equals, "MachineManager")
+				c.Check(request, gc.Equals, "ProxySettings")
+				c.Check(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "machine-0"}, {Tag: "machine-0-lxd-1"}},
+				})
+				out := response.(*params.ProxyConfigResults)
+				*out = params.ProxyConfigResults{Results: []params.ProxyConfigResult{
+					settings,
+					{Error: &params.Error{Message: "machine 0/lxd/1 not found"}},
+				}}
+				return nil
+			})})
+	results, err := client.ProxySettings("0", "!", "0/lxd/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.ProxyConfigResult{
+		settings,
+		{Error: &params.Error{Message: `machine ID "!" not valid`}},
+		{Error: &params.Error{Message: "machine 0/lxd/1 not found"}},
+	})
+}
+
+func (s *MachinemanagerSuite) TestMachineConsoleLog(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 8,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Check(objType, gc.Equals, "MachineManager")
+				c.Check(request, gc.Equals, "MachineConsoleLog")
+				c.Check(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "machine-0"}},
+				})
+				out := response.(*params.MachineConsoleLogResults)
+				*out = params.MachineConsoleLogResults{Results: []params.MachineConsoleLogResult{{
+					InstanceId:      "i-0",
+					Output:          "booting",
+					CloudInitStatus: "unknown",
+				}}}
+				return nil
+			})})
+	result, err := client.MachineConsoleLog("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.MachineConsoleLogResult{
+		InstanceId:      "i-0",
+		Output:          "booting",
+		CloudInitStatus: "unknown",
+	})
+}
+
+func (s *MachinemanagerSuite) TestMachineConsoleLogError(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 8,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				out := response.(*params.MachineConsoleLogResults)
+				*out = params.MachineConsoleLogResults{Results: []params.MachineConsoleLogResult{{
+					Error: &params.Error{Message: "machine 0 not provisioned", Code: params.CodeNotProvisioned},
+				}}}
+				return nil
+			})})
+	_, err := client.MachineConsoleLog("0")
+	c.Assert(err, gc.ErrorMatches, "machine 0 not provisioned")
+	c.Assert(err, jc.Satisfies, params.IsCodeNotProvisioned)
+}
+
+func (s *MachinemanagerSuite) TestMachineConsoleLogNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 7,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			})})
+	_, err := client.MachineConsoleLog("0")
+	c.Assert(err, gc.ErrorMatches, "retrieving machine console logs by this version of Juju not supported")
+}
+
+func (s *MachinemanagerSuite) TestSetAgentConfigOverrides(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 9,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Check(objType, gc.Equals, "MachineManager")
+				c.Check(request, gc.Equals, "SetAgentConfigOverrides")
+				c.Check(a, jc.DeepEquals, params.SetAgentConfigOverridesArgs{
+					Args: []params.SetAgentConfigOverridesArg{{
+						Tag:    "machine-0",
+						Values: map[string]string{"logging-config": "<root>=DEBUG"},
+					}},
+				})
+				out := response.(*params.ErrorResults)
+				*out = params.ErrorResults{Results: []params.ErrorResult{{}}}
+				return nil
+			})})
+	err := client.SetAgentConfigOverrides("0", map[string]string{"logging-config": "<root>=DEBUG"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MachinemanagerSuite) TestAgentConfigOverrides(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 9,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Check(request, gc.Equals, "AgentConfigOverrides")
+				c.Check(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "machine-0"}},
+				})
+				out := response.(*params.AgentConfigOverridesResults)
+				*out = params.AgentConfigOverridesResults{Results: []params.AgentConfigOverridesResult{{
+					Values:         map[string]string{"no-proxy": "localhost"},
+					Version:        2,
+					AppliedVersion: 2,
+				}}}
+				return nil
+			})})
+	result, err := client.AgentConfigOverrides("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AgentConfigOverridesResult{
+		Values:         map[string]string{"no-proxy": "localhost"},
+		Version:        2,
+		AppliedVersion: 2,
+	})
+}
+
+func (s *MachinemanagerSuite) TestAgentConfigOverridesNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 8,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			})})
+	err := client.SetAgentConfigOverrides("0", nil)
+	c.Assert(err, gc.ErrorMatches, "overriding agent config by this version of Juju not supported")
+	_, err = client.AgentConfigOverrides("0")
+	c.Assert(err, gc.ErrorMatches, "overriding agent config by this version of Juju not supported")
+}
+
+func (s *MachinemanagerSuite) TestSyncMachineAddresses(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 10,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Check(objType, gc.Equals, "MachineManager")
+				c.Check(request, gc.Equals, "SyncMachineAddresses")
+				c.Check(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: "machine-0"}, {Tag: "machine-1"}},
+				})
+				out := response.(*params.ErrorResults)
+				*out = params.ErrorResults{Results: []params.ErrorResult{
+					{}, {Error: &params.Error{Message: "machine 1 not found"}},
+				}}
+				return nil
+			})})
+	results, err := client.SyncMachineAddresses("0", "1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Check(results[0].Error, gc.IsNil)
+	c.Check(results[1].Error, gc.ErrorMatches, "machine 1 not found")
+}
+
+func (s *MachinemanagerSuite) TestSyncMachineAddressesAll(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 10,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Check(a, jc.DeepEquals, params.Entities{Entities: []params.Entity{}})
+				return nil
+			})})
+	results, err := client.SyncMachineAddresses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 0)
+}
+
+func (s *MachinemanagerSuite) TestSyncMachineAddressesNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 9,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			})})
+	_, err := client.SyncMachineAddresses("0")
+	c.Assert(err, gc.ErrorMatches, "syncing machine addresses by this version of Juju not supported")
+}
+
+func (s *MachinemanagerSuite) TestProxySettingsNotSupported(c *gc.C) {
+	client := machinemanager.NewClient(
+		basetesting.BestVersionCaller{
+			BestVersion: 6,
+			APICallerFunc: basetesting.APICallerFunc(func(objType string, version int, id, request string, a, response interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			})})
+	_, err := client.ProxySettings("0")
+	c.Assert(err, gc.ErrorMatches, "querying machine proxy settings by this version of Juju not supported")
+}
@@ -4,6 +4,8 @@
 package machiner
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v3"
 
@@ -42,12 +44,14 @@ func (m *Machine) Refresh() error {
 	return nil
 }
 
-// SetStatus sets the status of the machine.
+// SetStatus sets the status of the machine, recording it as having
+// been set now.
 func (m *Machine) SetStatus(status status.Status, info string, data map[string]interface{}) error {
+	now := time.Now()
 	var result params.ErrorResults
 	args := params.SetStatus{
 		Entities: []params.EntityStatusArgs{
-			{Tag: m.tag.String(), Status: status.String(), Info: info, Data: data},
+			{Tag: m.tag.String(), Status: status.String(), Info: info, Data: data, Since: &now},
 		},
 	}
 	err := m.st.facade.FacadeCall("SetStatus", args, &result)
@@ -72,6 +76,25 @@ func (m *Machine) SetMachineAddresses(addresses []network.MachineAddress) error
 	return result.OneError()
 }
 
+// RecordProvisioningPhase records in the machine's provisioning
+// timeline that the machine reached the given phase at the given time.
+func (m *Machine) RecordProvisioningPhase(phase status.ProvisioningPhase, when time.Time) error {
+	if m.st.facade.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("recording provisioning phases by this version of Juju")
+	}
+	var result params.ErrorResults
+	args := params.MachineProvisioningPhases{
+		Phases: []params.MachineProvisioningPhase{
+			{Tag: m.tag.String(), Phase: string(phase), Time: when},
+		},
+	}
+	err := m.st.facade.FacadeCall("RecordProvisioningPhases", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
 // EnsureDead sets the machine lifecycle to Dead if it is Alive or
 // Dying. It does nothing otherwise.
 func (m *Machine) EnsureDead() error {
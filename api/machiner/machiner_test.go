@@ -5,6 +5,7 @@ package machiner_test
 
 import (
 	stdtesting "testing"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -182,6 +183,26 @@ func (s *machinerSuite) TestSetEmptyMachineAddresses(c *gc.C) {
 	c.Assert(s.machine.MachineAddresses(), gc.HasLen, 0)
 }
 
+func (s *machinerSuite) TestRecordProvisioningPhase(c *gc.C) {
+	machine, err := s.machiner.Machine(names.NewMachineTag("1"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	finished := time.Date(2020, 4, 1, 10, 0, 0, 0, time.UTC)
+	err = machine.RecordProvisioningPhase(status.PhaseCloudInitDone, finished)
+	c.Assert(err, jc.ErrorIsNil)
+
+	timeline, err := s.machine.ProvisioningTimeline(status.StatusHistoryFilter{Size: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	var recorded []status.StatusInfo
+	for _, entry := range timeline {
+		if entry.Status == status.Status(status.PhaseCloudInitDone) {
+			recorded = append(recorded, entry)
+		}
+	}
+	c.Assert(recorded, gc.HasLen, 1)
+	c.Assert(recorded[0].Since.Equal(finished), jc.IsTrue)
+}
+
 func (s *machinerSuite) TestWatch(c *gc.C) {
 	machine, err := s.machiner.Machine(names.NewMachineTag("1"))
 	c.Assert(err, jc.ErrorIsNil)
@@ -147,6 +147,7 @@ func (c *Client) ModelInfo() (migration.ModelInfo, error) {
 		Owner:                  owner,
 		AgentVersion:           info.AgentVersion,
 		ControllerAgentVersion: info.ControllerAgentVersion,
+		FormatVersion:          info.FormatVersion,
 	}, nil
 }
 
@@ -183,6 +183,7 @@ func (s *ClientSuite) TestModelInfo(c *gc.C) {
 			OwnerTag:               owner.String(),
 			AgentVersion:           version.MustParse("1.2.3"),
 			ControllerAgentVersion: version.MustParse("1.2.4"),
+			FormatVersion:          1,
 		}
 		return nil
 	})
@@ -198,6 +199,7 @@ func (s *ClientSuite) TestModelInfo(c *gc.C) {
 		Owner:                  owner,
 		AgentVersion:           version.MustParse("1.2.3"),
 		ControllerAgentVersion: version.MustParse("1.2.4"),
+		FormatVersion:          1,
 	})
 }
 
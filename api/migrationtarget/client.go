@@ -49,6 +49,7 @@ func (c *Client) Prechecks(model coremigration.ModelInfo) error {
 		OwnerTag:               model.Owner.String(),
 		AgentVersion:           model.AgentVersion,
 		ControllerAgentVersion: model.ControllerAgentVersion,
+		FormatVersion:          model.FormatVersion,
 	}
 	return c.caller.FacadeCall("Prechecks", args, nil)
 }
@@ -0,0 +1,48 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelarchive
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the ModelArchive facade, used to archive
+// an idle model and to thaw it again.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new ModelArchive client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "ModelArchive")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ArchiveModel archives the model, stopping its workloads. If maxWait
+// is not nil, it is how long to wait for units to go before their
+// machines are forcibly removed.
+func (c *Client) ArchiveModel(maxWait *time.Duration) (params.ModelArchiveResult, error) {
+	var result params.ModelArchiveResult
+	args := params.ArchiveModelArgs{MaxWait: maxWait}
+	if err := c.facade.FacadeCall("ArchiveModel", args, &result); err != nil {
+		return params.ModelArchiveResult{}, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// ThawModel restores the workloads of an archived model, returning the
+// number of units restored for each application.
+func (c *Client) ThawModel() (map[string]int, error) {
+	var result params.ThawModelResult
+	if err := c.facade.FacadeCall("ThawModel", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Applications, nil
+}
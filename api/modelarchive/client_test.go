@@ -0,0 +1,90 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelarchive_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelarchive"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type modelArchiveSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&modelArchiveSuite{})
+
+func (s *modelArchiveSuite) TestArchiveModel(c *gc.C) {
+	maxWait := 5 * time.Minute
+	archive := params.ModelArchiveResult{
+		Size:      1024,
+		SHA256:    "abc123",
+		CreatedBy: "admin",
+		Created:   time.Date(2020, 3, 6, 18, 0, 0, 0, time.UTC),
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelArchive")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ArchiveModel")
+			c.Check(a, jc.DeepEquals, params.ArchiveModelArgs{MaxWait: &maxWait})
+			c.Assert(result, gc.FitsTypeOf, &params.ModelArchiveResult{})
+			*(result.(*params.ModelArchiveResult)) = archive
+			return nil
+		},
+	)
+	client := modelarchive.NewClient(apiCaller)
+	result, err := client.ArchiveModel(&maxWait)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, archive)
+}
+
+func (s *modelArchiveSuite) TestArchiveModelError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			return errors.New("boom")
+		},
+	)
+	client := modelarchive.NewClient(apiCaller)
+	_, err := client.ArchiveModel(nil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *modelArchiveSuite) TestThawModel(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelArchive")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ThawModel")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.ThawModelResult{})
+			*(result.(*params.ThawModelResult)) = params.ThawModelResult{
+				Applications: map[string]int{"wordpress": 2},
+			}
+			return nil
+		},
+	)
+	client := modelarchive.NewClient(apiCaller)
+	restored, err := client.ThawModel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restored, jc.DeepEquals, map[string]int{"wordpress": 2})
+}
+
+func (s *modelArchiveSuite) TestThawModelError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			return errors.New("model is not archived")
+		},
+	)
+	client := modelarchive.NewClient(apiCaller)
+	_, err := client.ThawModel()
+	c.Assert(err, gc.ErrorMatches, "model is not archived")
+}
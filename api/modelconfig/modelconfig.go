@@ -103,3 +103,63 @@ func (c *Client) Sequences() (map[string]int, error) {
 	}
 	return result.Sequences, nil
 }
+
+// ConfigKeys returns a description of all the model and controller
+// config keys known to the controller.
+func (c *Client) ConfigKeys() ([]params.ConfigKey, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("ConfigKeys on v%d facade", c.BestAPIVersion())
+	}
+	var result params.ConfigKeysResult
+	err := c.facade.FacadeCall("ConfigKeys", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Keys, nil
+}
+
+// RegistryCredentials returns the credentials the model uses to pull
+// OCI images from private registries, without their passwords.
+func (c *Client) RegistryCredentials() ([]params.RegistryCredential, error) {
+	if c.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("RegistryCredentials on v%d facade", c.BestAPIVersion())
+	}
+	var result params.RegistryCredentialsResult
+	err := c.facade.FacadeCall("RegistryCredentials", nil, &result)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Credentials, nil
+}
+
+// SetRegistryCredential adds the credential for a registry to the
+// model, or replaces the existing one.
+func (c *Client) SetRegistryCredential(cred params.RegistryCredential) error {
+	if c.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("SetRegistryCredentials on v%d facade", c.BestAPIVersion())
+	}
+	args := params.SetRegistryCredentials{
+		Credentials: []params.RegistryCredential{cred},
+	}
+	var result params.ErrorResults
+	err := c.facade.FacadeCall("SetRegistryCredentials", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
+
+// RemoveRegistryCredentials removes the credentials for the given
+// registries from the model.
+func (c *Client) RemoveRegistryCredentials(registries ...string) error {
+	if c.BestAPIVersion() < 4 {
+		return errors.NotSupportedf("RemoveRegistryCredentials on v%d facade", c.BestAPIVersion())
+	}
+	args := params.RemoveRegistryCredentials{Registries: registries}
+	var result params.ErrorResults
+	err := c.facade.FacadeCall("RemoveRegistryCredentials", args, &result)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return result.Combine()
+}
@@ -4,6 +4,8 @@
 package modelconfig_test
 
 import (
+	"time"
+
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -220,3 +222,172 @@ func (s *modelconfigSuite) TestSequences(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 	c.Assert(sequences, jc.DeepEquals, map[string]int{"foo": 5, "bar": 2})
 }
+
+func (s *modelconfigSuite) TestConfigKeysV2(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(_ string, _ int, _, _ string, _, _ interface{}) error {
+				c.Errorf("shouldn't be called")
+				return nil
+			},
+		), 2}
+	client := modelconfig.NewClient(apiCaller)
+	keys, err := client.ConfigKeys()
+	c.Assert(err, gc.ErrorMatches, "ConfigKeys on v2 facade not supported")
+	c.Assert(keys, gc.IsNil)
+}
+
+func (s *modelconfigSuite) TestConfigKeys(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ModelConfig")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "ConfigKeys")
+				c.Check(a, gc.IsNil)
+				c.Assert(result, gc.FitsTypeOf, &params.ConfigKeysResult{})
+				results := result.(*params.ConfigKeysResult)
+				results.Keys = []params.ConfigKey{{
+					Name:    "foo",
+					Scope:   "model",
+					Type:    "string",
+					Default: "bar",
+					Mutable: true,
+				}}
+				called = true
+				return nil
+			},
+		), 3}
+	client := modelconfig.NewClient(apiCaller)
+	keys, err := client.ConfigKeys()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(keys, jc.DeepEquals, []params.ConfigKey{{
+		Name:    "foo",
+		Scope:   "model",
+		Type:    "string",
+		Default: "bar",
+		Mutable: true,
+	}})
+}
+
+func (s *modelconfigSuite) TestRegistryCredentialsV3(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(_ string, _ int, _, _ string, _, _ interface{}) error {
+				c.Errorf("shouldn't be called")
+				return nil
+			},
+		), 3}
+	client := modelconfig.NewClient(apiCaller)
+	_, err := client.RegistryCredentials()
+	c.Assert(err, gc.ErrorMatches, "RegistryCredentials on v3 facade not supported")
+	err = client.SetRegistryCredential(params.RegistryCredential{})
+	c.Assert(err, gc.ErrorMatches, "SetRegistryCredentials on v3 facade not supported")
+	err = client.RemoveRegistryCredentials("docker.io")
+	c.Assert(err, gc.ErrorMatches, "RemoveRegistryCredentials on v3 facade not supported")
+}
+
+func (s *modelconfigSuite) TestRegistryCredentials(c *gc.C) {
+	expiry := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ModelConfig")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "RegistryCredentials")
+				c.Check(a, gc.IsNil)
+				c.Assert(result, gc.FitsTypeOf, &params.RegistryCredentialsResult{})
+				results := result.(*params.RegistryCredentialsResult)
+				results.Credentials = []params.RegistryCredential{{
+					Registry: "docker.io",
+					Username: "bob",
+					Expiry:   &expiry,
+				}}
+				called = true
+				return nil
+			},
+		), 4}
+	client := modelconfig.NewClient(apiCaller)
+	creds, err := client.RegistryCredentials()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(creds, jc.DeepEquals, []params.RegistryCredential{{
+		Registry: "docker.io",
+		Username: "bob",
+		Expiry:   &expiry,
+	}})
+}
+
+func (s *modelconfigSuite) TestSetRegistryCredential(c *gc.C) {
+	cred := params.RegistryCredential{
+		Registry: "docker.io",
+		Username: "bob",
+		Password: "secret",
+	}
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ModelConfig")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "SetRegistryCredentials")
+				c.Check(a, jc.DeepEquals, params.SetRegistryCredentials{
+					Credentials: []params.RegistryCredential{cred},
+				})
+				c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{
+					Error: &params.Error{Message: "boom"},
+				}}
+				called = true
+				return nil
+			},
+		), 4}
+	client := modelconfig.NewClient(apiCaller)
+	err := client.SetRegistryCredential(cred)
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *modelconfigSuite) TestRemoveRegistryCredentials(c *gc.C) {
+	called := false
+	apiCaller := basetesting.BestVersionCaller{
+		basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ModelConfig")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "RemoveRegistryCredentials")
+				c.Check(a, jc.DeepEquals, params.RemoveRegistryCredentials{
+					Registries: []string{"docker.io", "quay.io"},
+				})
+				c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{{}, {}}
+				called = true
+				return nil
+			},
+		), 4}
+	client := modelconfig.NewClient(apiCaller)
+	err := client.RemoveRegistryCredentials("docker.io", "quay.io")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
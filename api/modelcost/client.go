@@ -0,0 +1,34 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelcost
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the ModelCost facade, used to estimate
+// what a model costs to run.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new ModelCost client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "ModelCost")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// EstimateCost returns the estimated cost of running the model's
+// resources, using the pricing set for the model's cloud.
+func (c *Client) EstimateCost() (params.ModelCostEstimate, error) {
+	var result params.ModelCostEstimate
+	if err := c.facade.FacadeCall("EstimateCost", nil, &result); err != nil {
+		return params.ModelCostEstimate{}, errors.Trace(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelcost_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelcost"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type modelCostSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&modelCostSuite{})
+
+func (s *modelCostSuite) TestEstimateCost(c *gc.C) {
+	estimate := params.ModelCostEstimate{
+		Currency: "USD",
+		Items:    []params.ModelCostItem{{Kind: "machine", Id: "0", Detail: "m5.large", Hourly: 0.5}},
+		Hourly:   0.5,
+		Monthly:  365,
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelCost")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "EstimateCost")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.ModelCostEstimate{})
+			*(result.(*params.ModelCostEstimate)) = estimate
+			return nil
+		},
+	)
+	client := modelcost.NewClient(apiCaller)
+	result, err := client.EstimateCost()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, estimate)
+}
+
+func (s *modelCostSuite) TestEstimateCostError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			return errors.New("boom")
+		},
+	)
+	client := modelcost.NewClient(apiCaller)
+	_, err := client.EstimateCost()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
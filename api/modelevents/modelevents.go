@@ -0,0 +1,83 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelevents provides a client for streaming the lifecycle
+// events of a model, such as units being added and relations being
+// joined, from the controller's /events endpoint.
+package modelevents
+
+import (
+	"io"
+	"sync"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common/stream"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// jsonReadCloser provides the functionality to read JSON-serialized
+// values from a streaming connection.
+type jsonReadCloser interface {
+	io.Closer
+
+	// ReadJSON decodes the next JSON value from the connection and
+	// sets the value at the provided pointer to that newly decoded one.
+	ReadJSON(interface{}) error
+}
+
+// Stream streams the lifecycle events of a model over a websocket
+// connection.
+type Stream struct {
+	mu     sync.Mutex
+	stream jsonReadCloser
+}
+
+// Open opens a websocket to the API's /events endpoint for the model
+// the connection is for, and returns a stream of the events from that
+// connection.
+func Open(conn base.StreamConnector, cfg params.ModelEventsConfig) (*Stream, error) {
+	wsStream, err := stream.Open(conn, "/events", &cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &Stream{stream: wsStream}, nil
+}
+
+// Next returns the next event from the server, blocking until there is
+// one.
+//
+// Each event is delivered at least once, in the order of the events'
+// sequence numbers. A consumer should record the sequence number of
+// each event once it has been handled, and reopen the stream from one
+// after the last of them if Next fails. Events which were sent but not
+// recorded as handled are then sent again.
+func (s *Stream) Next() (params.ModelEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var event params.ModelEvent
+	if s.stream == nil {
+		return event, errors.Errorf("cannot read from closed stream")
+	}
+	if err := s.stream.ReadJSON(&event); err != nil {
+		return event, errors.Trace(err)
+	}
+	return event, nil
+}
+
+// Close closes the stream.
+func (s *Stream) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stream == nil {
+		return nil
+	}
+	if err := s.stream.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	s.stream = nil
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelevents_test
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/base"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelevents"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type StreamSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&StreamSuite{})
+
+func (s *StreamSuite) TestOpen(c *gc.C) {
+	stub := &testing.Stub{}
+	conn := &mockConnector{stub: stub}
+	conn.ReturnConnectStream = mockStream{stub: stub}
+
+	_, err := modelevents.Open(conn, params.ModelEventsConfig{From: 42, NoTail: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	stub.CheckCallNames(c, "ConnectStream")
+	stub.CheckCall(c, 0, "ConnectStream", "/events", url.Values{
+		"from":   []string{"42"},
+		"notail": []string{"true"},
+	})
+}
+
+func (s *StreamSuite) TestOpenError(c *gc.C) {
+	stub := &testing.Stub{}
+	conn := &mockConnector{stub: stub}
+	stub.SetErrors(errors.New("foo"))
+
+	_, err := modelevents.Open(conn, params.ModelEventsConfig{})
+	c.Assert(err, gc.ErrorMatches, "cannot connect to /events: foo")
+}
+
+func (s *StreamSuite) TestNext(c *gc.C) {
+	stub := &testing.Stub{}
+	conn := &mockConnector{stub: stub}
+	events := make(chan params.ModelEvent, 1)
+	conn.ReturnConnectStream = mockStream{stub: stub, ReturnReadJSON: events}
+	stream, err := modelevents.Open(conn, params.ModelEventsConfig{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := params.ModelEvent{
+		Seq:    3,
+		Kind:   "relation-joined",
+		Entity: "unit-wordpress-0",
+		Data:   map[string]string{"relation": "wordpress:db mysql:server"},
+		Time:   time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC),
+	}
+	events <- expected
+	event, err := stream.Next()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(event, jc.DeepEquals, expected)
+	stub.CheckCallNames(c, "ConnectStream", "ReadJSON")
+}
+
+func (s *StreamSuite) TestNextError(c *gc.C) {
+	stub := &testing.Stub{}
+	conn := &mockConnector{stub: stub}
+	conn.ReturnConnectStream = mockStream{stub: stub}
+	failure := errors.New("an error")
+	stub.SetErrors(nil, failure)
+	stream, err := modelevents.Open(conn, params.ModelEventsConfig{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = stream.Next()
+	c.Assert(errors.Cause(err), gc.Equals, failure)
+}
+
+func (s *StreamSuite) TestClose(c *gc.C) {
+	stub := &testing.Stub{}
+	conn := &mockConnector{stub: stub}
+	conn.ReturnConnectStream = mockStream{stub: stub}
+	stream, err := modelevents.Open(conn, params.ModelEventsConfig{})
+	c.Assert(err, jc.ErrorIsNil)
+	stub.ResetCalls()
+
+	err = stream.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	err = stream.Close() // idempotent
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = stream.Next()
+	c.Check(err, gc.ErrorMatches, `cannot read from closed stream`)
+	stub.CheckCallNames(c, "Close")
+}
+
+type mockConnector struct {
+	basetesting.APICallerFunc
+	stub *testing.Stub
+
+	ReturnConnectStream base.Stream
+}
+
+func (c *mockConnector) ConnectStream(path string, values url.Values) (base.Stream, error) {
+	c.stub.AddCall("ConnectStream", path, values)
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return c.ReturnConnectStream, nil
+}
+
+type mockStream struct {
+	base.Stream
+	stub *testing.Stub
+
+	ReturnReadJSON chan params.ModelEvent
+}
+
+func (s mockStream) ReadJSON(v interface{}) error {
+	s.stub.AddCall("ReadJSON", v)
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+
+	switch vt := v.(type) {
+	case *params.ModelEvent:
+		*vt = <-s.ReturnReadJSON
+		return nil
+	default:
+		return errors.Errorf("unexpected output type: %T", v)
+	}
+}
+
+func (s mockStream) Close() error {
+	s.stub.AddCall("Close")
+	if err := s.stub.NextErr(); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelmanager
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// FindAnnotations returns the entities, in the models the user can
+// see, which have all of the given annotations. If any kinds are
+// given, only entities with tags of those kinds are returned.
+func (c *Client) FindAnnotations(annotations map[string]string, kinds ...string) ([]params.AnnotatedEntity, error) {
+	if bestVer := c.BestAPIVersion(); bestVer < 11 {
+		return nil, errors.NotImplementedf("FindAnnotations in version %v", bestVer)
+	}
+	args := params.FindAnnotationsArgs{
+		Annotations: annotations,
+		Kinds:       kinds,
+	}
+	var result params.AnnotatedEntities
+	if err := c.facade.FacadeCall("FindAnnotations", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Entities, nil
+}
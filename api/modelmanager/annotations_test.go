@@ -0,0 +1,49 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelmanager_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *modelmanagerSuite) TestFindAnnotations(c *gc.C) {
+	found := []params.AnnotatedEntity{{
+		ModelTag:      "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		ModelName:     "payments",
+		ModelOwnerTag: "user-bob",
+		EntityTag:     "application-ledger",
+		Annotations:   map[string]string{"team": "payments"},
+	}}
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 11,
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, arg, result interface{}) error {
+				c.Check(objType, gc.Equals, "ModelManager")
+				c.Check(request, gc.Equals, "FindAnnotations")
+				c.Check(arg, jc.DeepEquals, params.FindAnnotationsArgs{
+					Annotations: map[string]string{"team": "payments"},
+					Kinds:       []string{"application"},
+				})
+				c.Assert(result, gc.FitsTypeOf, &params.AnnotatedEntities{})
+				*(result.(*params.AnnotatedEntities)) = params.AnnotatedEntities{Entities: found}
+				return nil
+			},
+		),
+	}
+	client := modelmanager.NewClient(apiCaller)
+	entities, err := client.FindAnnotations(map[string]string{"team": "payments"}, "application")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entities, jc.DeepEquals, found)
+}
+
+func (s *modelmanagerSuite) TestFindAnnotationsNotSupported(c *gc.C) {
+	client := modelmanager.NewClient(basetesting.BestVersionCaller{BestVersion: 10})
+	_, err := client.FindAnnotations(map[string]string{"team": "payments"})
+	c.Assert(err, gc.ErrorMatches, "FindAnnotations in version 10 not implemented")
+}
@@ -13,9 +13,11 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/common"
+	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/rpc"
@@ -48,6 +50,29 @@ func (c *Client) CreateModel(
 	name, owner, cloud, cloudRegion string,
 	cloudCredential names.CloudCredentialTag,
 	config map[string]interface{},
+) (base.ModelInfo, error) {
+	return c.createModel(name, owner, cloud, cloudRegion, cloudCredential, config, "")
+}
+
+// CreateModelFromTemplate creates a new model with the settings of the
+// named model template applied. The model config and credential
+// specified in the args take precedence over those in the template.
+func (c *Client) CreateModelFromTemplate(
+	template, name, owner, cloud, cloudRegion string,
+	cloudCredential names.CloudCredentialTag,
+	config map[string]interface{},
+) (base.ModelInfo, error) {
+	if bestVer := c.BestAPIVersion(); bestVer < 10 {
+		return base.ModelInfo{}, errors.NotImplementedf("CreateModelFromTemplate in version %v", bestVer)
+	}
+	return c.createModel(name, owner, cloud, cloudRegion, cloudCredential, config, template)
+}
+
+func (c *Client) createModel(
+	name, owner, cloud, cloudRegion string,
+	cloudCredential names.CloudCredentialTag,
+	config map[string]interface{},
+	template string,
 ) (base.ModelInfo, error) {
 	var result base.ModelInfo
 	if !names.IsValidUser(owner) {
@@ -71,6 +96,7 @@ func (c *Client) CreateModel(
 		CloudTag:           cloudTag,
 		CloudRegion:        cloudRegion,
 		CloudCredentialTag: cloudCredentialTag,
+		Template:           template,
 	}
 	var modelInfo params.ModelInfo
 	err := c.facade.FacadeCall("CreateModel", createArgs, &modelInfo)
@@ -425,6 +451,51 @@ func (c *Client) DestroyModel(tag names.ModelTag, destroyStorage, force *bool, m
 	return nil
 }
 
+// WatchModelTeardown returns a NotifyWatcher which triggers whenever the
+// progress of tearing down the specified model may have changed. The
+// watcher fails once the model has been removed.
+func (c *Client) WatchModelTeardown(tag names.ModelTag) (watcher.NotifyWatcher, error) {
+	if bestVer := c.BestAPIVersion(); bestVer < 9 {
+		return nil, errors.NotImplementedf("WatchModelTeardown in version %v", bestVer)
+	}
+	args := params.Entities{Entities: []params.Entity{{Tag: tag.String()}}}
+	var results params.NotifyWatchResults
+	if err := c.facade.FacadeCall("WatchModelTeardown", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result), nil
+}
+
+// AbandonedModelResources returns the cloud resources of the specified
+// model which were removed without the provider confirming their
+// release, and which may need to be cleaned up manually. They are
+// available after the model has been removed.
+func (c *Client) AbandonedModelResources(tag names.ModelTag) ([]params.AbandonedResource, error) {
+	if bestVer := c.BestAPIVersion(); bestVer < 9 {
+		return nil, errors.NotImplementedf("AbandonedModelResources in version %v", bestVer)
+	}
+	args := params.Entities{Entities: []params.Entity{{Tag: tag.String()}}}
+	var results params.AbandonedResourcesResults
+	if err := c.facade.FacadeCall("AbandonedModelResources", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Resources, nil
+}
+
 // GrantModel grants a user access to the specified models.
 func (c *Client) GrantModel(user, access string, modelUUIDs ...string) error {
 	return c.modifyModelUser(params.GrantModelAccess, user, access, modelUUIDs)
@@ -862,3 +862,102 @@ func (s *dumpModelSuite) TestDumpModelDBError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "fake error")
 	c.Assert(out, gc.IsNil)
 }
+
+func (s *modelmanagerSuite) TestAbandonedModelResources(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ModelManager")
+				c.Check(request, gc.Equals, "AbandonedModelResources")
+				c.Check(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: coretesting.ModelTag.String()}},
+				})
+				c.Assert(result, gc.FitsTypeOf, &params.AbandonedResourcesResults{})
+				*(result.(*params.AbandonedResourcesResults)) = params.AbandonedResourcesResults{
+					Results: []params.AbandonedResourcesResult{{
+						Resources: []params.AbandonedResource{{
+							Kind:       "machine",
+							Id:         "0",
+							ProviderId: "inst-0",
+							Reason:     "gone",
+						}},
+					}},
+				}
+				return nil
+			},
+		), BestVersion: 9}
+	client := modelmanager.NewClient(apiCaller)
+	resources, err := client.AbandonedModelResources(coretesting.ModelTag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, jc.DeepEquals, []params.AbandonedResource{{
+		Kind:       "machine",
+		Id:         "0",
+		ProviderId: "inst-0",
+		Reason:     "gone",
+	}})
+}
+
+func (s *modelmanagerSuite) TestAbandonedModelResourcesOldVersion(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Fail()
+				return nil
+			},
+		), BestVersion: 8}
+	client := modelmanager.NewClient(apiCaller)
+	_, err := client.AbandonedModelResources(coretesting.ModelTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
+
+func (s *modelmanagerSuite) TestWatchModelTeardownError(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "ModelManager")
+				c.Check(request, gc.Equals, "WatchModelTeardown")
+				c.Check(a, jc.DeepEquals, params.Entities{
+					Entities: []params.Entity{{Tag: coretesting.ModelTag.String()}},
+				})
+				c.Assert(result, gc.FitsTypeOf, &params.NotifyWatchResults{})
+				*(result.(*params.NotifyWatchResults)) = params.NotifyWatchResults{
+					Results: []params.NotifyWatchResult{{
+						Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+					}},
+				}
+				return nil
+			},
+		), BestVersion: 9}
+	client := modelmanager.NewClient(apiCaller)
+	_, err := client.WatchModelTeardown(coretesting.ModelTag)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *modelmanagerSuite) TestWatchModelTeardownOldVersion(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Fail()
+				return nil
+			},
+		), BestVersion: 8}
+	client := modelmanager.NewClient(apiCaller)
+	_, err := client.WatchModelTeardown(coretesting.ModelTag)
+	c.Assert(err, jc.Satisfies, errors.IsNotImplemented)
+}
@@ -0,0 +1,49 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelmanager
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// AddModelTemplate adds a model template to the controller.
+func (c *Client) AddModelTemplate(template params.ModelTemplate) error {
+	if bestVer := c.BestAPIVersion(); bestVer < 10 {
+		return errors.NotImplementedf("AddModelTemplate in version %v", bestVer)
+	}
+	args := params.ModelTemplateArgs{Templates: []params.ModelTemplate{template}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("AddModelTemplates", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ModelTemplates returns the model templates in the controller.
+func (c *Client) ModelTemplates() ([]params.ModelTemplate, error) {
+	if bestVer := c.BestAPIVersion(); bestVer < 10 {
+		return nil, errors.NotImplementedf("ModelTemplates in version %v", bestVer)
+	}
+	var result params.ModelTemplatesResult
+	if err := c.facade.FacadeCall("ModelTemplates", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Templates, nil
+}
+
+// RemoveModelTemplate removes the named model template from the
+// controller.
+func (c *Client) RemoveModelTemplate(name string) error {
+	if bestVer := c.BestAPIVersion(); bestVer < 10 {
+		return errors.NotImplementedf("RemoveModelTemplate in version %v", bestVer)
+	}
+	args := params.ModelTemplateNames{Names: []string{name}}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("RemoveModelTemplates", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
@@ -0,0 +1,123 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelmanager_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
+)
+
+func (s *modelmanagerSuite) TestCreateModelFromTemplate(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, arg, result interface{}) error {
+				c.Check(objType, gc.Equals, "ModelManager")
+				c.Check(request, gc.Equals, "CreateModel")
+				c.Check(arg, jc.DeepEquals, params.ModelCreateArgs{
+					Name:     "new-model",
+					OwnerTag: "user-bob",
+					CloudTag: "cloud-nimbus",
+					Template: "web",
+				})
+				out := result.(*params.ModelInfo)
+				out.Name = "new-model"
+				out.UUID = "youyoueyedee"
+				out.CloudTag = "cloud-nimbus"
+				out.OwnerTag = "user-bob"
+				return nil
+			},
+		),
+	}
+	client := modelmanager.NewClient(apiCaller)
+	info, err := client.CreateModelFromTemplate("web", "new-model", "bob", "nimbus", "", names.CloudCredentialTag{}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.UUID, gc.Equals, "youyoueyedee")
+}
+
+func (s *modelmanagerSuite) TestCreateModelFromTemplateNotSupported(c *gc.C) {
+	client := modelmanager.NewClient(basetesting.BestVersionCaller{BestVersion: 9})
+	_, err := client.CreateModelFromTemplate("web", "new-model", "bob", "", "", names.CloudCredentialTag{}, nil)
+	c.Assert(err, gc.ErrorMatches, "CreateModelFromTemplate in version 9 not implemented")
+}
+
+func (s *modelmanagerSuite) TestAddModelTemplate(c *gc.C) {
+	template := params.ModelTemplate{
+		Name:        "web",
+		Config:      map[string]interface{}{"logging-config": "<root>=DEBUG"},
+		Constraints: constraints.MustParse("mem=4G"),
+	}
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, arg, result interface{}) error {
+				c.Check(objType, gc.Equals, "ModelManager")
+				c.Check(request, gc.Equals, "AddModelTemplates")
+				c.Check(arg, jc.DeepEquals, params.ModelTemplateArgs{
+					Templates: []params.ModelTemplate{template},
+				})
+				*(result.(*params.ErrorResults)) = params.ErrorResults{
+					Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+				}
+				return nil
+			},
+		),
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.AddModelTemplate(template)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *modelmanagerSuite) TestModelTemplates(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, arg, result interface{}) error {
+				c.Check(objType, gc.Equals, "ModelManager")
+				c.Check(request, gc.Equals, "ModelTemplates")
+				c.Check(arg, gc.IsNil)
+				*(result.(*params.ModelTemplatesResult)) = params.ModelTemplatesResult{
+					Templates: []params.ModelTemplate{{Name: "web"}},
+				}
+				return nil
+			},
+		),
+	}
+	client := modelmanager.NewClient(apiCaller)
+	templates, err := client.ModelTemplates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(templates, jc.DeepEquals, []params.ModelTemplate{{Name: "web"}})
+}
+
+func (s *modelmanagerSuite) TestModelTemplatesNotSupported(c *gc.C) {
+	client := modelmanager.NewClient(basetesting.BestVersionCaller{BestVersion: 9})
+	_, err := client.ModelTemplates()
+	c.Assert(err, gc.ErrorMatches, "ModelTemplates in version 9 not implemented")
+}
+
+func (s *modelmanagerSuite) TestRemoveModelTemplate(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		BestVersion: 10,
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, arg, result interface{}) error {
+				c.Check(objType, gc.Equals, "ModelManager")
+				c.Check(request, gc.Equals, "RemoveModelTemplates")
+				c.Check(arg, jc.DeepEquals, params.ModelTemplateNames{Names: []string{"web"}})
+				*(result.(*params.ErrorResults)) = params.ErrorResults{
+					Results: []params.ErrorResult{{}},
+				}
+				return nil
+			},
+		),
+	}
+	client := modelmanager.NewClient(apiCaller)
+	err := client.RemoveModelTemplate("web")
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -0,0 +1,35 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelverifier
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the ModelVerifier facade, used to check
+// the consistency of the documents describing a model.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new ModelVerifier client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "ModelVerifier")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// VerifyModel returns the inconsistencies between the documents
+// describing the model's machines, units, applications, relations and
+// statuses, with suggested repairs.
+func (c *Client) VerifyModel() ([]params.ModelInconsistency, error) {
+	var result params.VerifyModelResult
+	if err := c.facade.FacadeCall("VerifyModel", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Inconsistencies, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelverifier_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/modelverifier"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type modelVerifierSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&modelVerifierSuite{})
+
+func (s *modelVerifierSuite) TestVerifyModel(c *gc.C) {
+	inconsistencies := []params.ModelInconsistency{{
+		Collection: "statuses",
+		Id:         "m#42",
+		Problem:    `machine "42" not found`,
+		Repair:     "remove the status document",
+	}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "ModelVerifier")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "VerifyModel")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.VerifyModelResult{})
+			*(result.(*params.VerifyModelResult)) = params.VerifyModelResult{
+				Inconsistencies: inconsistencies,
+			}
+			return nil
+		},
+	)
+	client := modelverifier.NewClient(apiCaller)
+	result, err := client.VerifyModel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, inconsistencies)
+}
+
+func (s *modelVerifierSuite) TestVerifyModelError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			return errors.New("boom")
+		},
+	)
+	client := modelverifier.NewClient(apiCaller)
+	_, err := client.VerifyModel()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,37 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operationslog
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the OperationsLog facade, used to find
+// out what changed in a model over a period of time.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new OperationsLog client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "OperationsLog")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Operations returns the changes made to the model since the given
+// time, optionally limited to changes of the given kinds.
+func (c *Client) Operations(since time.Time, kinds []string) ([]params.OperationsLogEntry, error) {
+	var result params.OperationsLogResult
+	args := params.OperationsLogArgs{Since: since, Kinds: kinds}
+	if err := c.facade.FacadeCall("Operations", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Operations, nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operationslog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/operationslog"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type operationsLogSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&operationsLogSuite{})
+
+func (s *operationsLogSuite) TestOperations(c *gc.C) {
+	since := time.Date(2020, 1, 3, 14, 0, 0, 0, time.UTC)
+	operations := []params.OperationsLogEntry{{
+		Id:      "1",
+		Kind:    "upgrade",
+		Summary: "upgrade model from 2.7.0 to 2.7.1",
+		Time:    since.Add(time.Minute),
+	}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "OperationsLog")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Operations")
+			c.Check(a, jc.DeepEquals, params.OperationsLogArgs{
+				Since: since,
+				Kinds: []string{"upgrade"},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.OperationsLogResult{})
+			*(result.(*params.OperationsLogResult)) = params.OperationsLogResult{
+				Operations: operations,
+			}
+			return nil
+		},
+	)
+	client := operationslog.NewClient(apiCaller)
+	result, err := client.Operations(since, []string{"upgrade"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, operations)
+}
+
+func (s *operationsLogSuite) TestOperationsError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			return errors.New("boom")
+		},
+	)
+	client := operationslog.NewClient(apiCaller)
+	_, err := client.Operations(time.Now(), nil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
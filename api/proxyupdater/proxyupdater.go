@@ -14,6 +14,7 @@ import (
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs/config"
 )
 
 const proxyUpdaterFacade = "ProxyUpdater"
@@ -83,6 +84,12 @@ type ProxyConfiguration struct {
 	SnapStoreProxyId         string
 	SnapStoreProxyAssertions string
 	SnapStoreProxyURL        string
+
+	AptSources []config.AptSource
+
+	// ContainerRuntimeProxy is true if the proxy settings are to be
+	// applied to the container runtimes on the machines.
+	ContainerRuntimeProxy bool
 }
 
 // ProxyConfig returns the proxy settings for the current model.
@@ -120,9 +127,27 @@ func (api *API) ProxyConfig() (ProxyConfiguration, error) {
 		SnapStoreProxyId:         result.SnapStoreProxyId,
 		SnapStoreProxyAssertions: result.SnapStoreProxyAssertions,
 		SnapStoreProxyURL:        result.SnapStoreProxyURL,
+
+		AptSources: aptSourcesFromParams(result.AptSources),
+
+		ContainerRuntimeProxy: result.ContainerRuntimeProxy,
 	}, nil
 }
 
+func aptSourcesFromParams(sources []params.AptSource) []config.AptSource {
+	if len(sources) == 0 {
+		return nil
+	}
+	result := make([]config.AptSource, len(sources))
+	for i, src := range sources {
+		result[i] = config.AptSource{
+			Source: src.Source,
+			Key:    src.Key,
+		}
+	}
+	return result
+}
+
 func (api *API) proxyConfigV1() (proxySettings, APTProxySettings proxy.Settings, err error) {
 	var results params.ProxyConfigResultsV1
 	args := params.Entities{
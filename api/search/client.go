@@ -0,0 +1,47 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package search
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the Search facade, used to find units and
+// machines across the models in the controller.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new Search client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "Search")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// FindUnits returns the units whose names match the pattern, in all the
+// models the user can see. A pattern without a "/" is matched against
+// the application name.
+func (c *Client) FindUnits(pattern string) ([]params.FoundEntity, error) {
+	var result params.FoundEntities
+	args := params.FindUnitsArgs{Pattern: pattern}
+	if err := c.facade.FacadeCall("FindUnits", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Entities, nil
+}
+
+// FindMachines returns the machines with an address or instance ID
+// equal to the query, in all the models the user can see.
+func (c *Client) FindMachines(query string) ([]params.FoundEntity, error) {
+	var result params.FoundEntities
+	args := params.FindMachinesArgs{Query: query}
+	if err := c.facade.FacadeCall("FindMachines", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Entities, nil
+}
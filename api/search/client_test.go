@@ -0,0 +1,69 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package search_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/search"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type searchSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&searchSuite{})
+
+var foundEntities = []params.FoundEntity{{
+	ModelTag:      coretesting.ModelTag.String(),
+	ModelName:     "web",
+	ModelOwnerTag: "user-bob",
+	EntityTag:     "machine-0",
+	Addresses:     []string{"10.0.0.1"},
+	InstanceId:    "i-0",
+}}
+
+func (s *searchSuite) apiCaller(c *gc.C, request string, args interface{}) basetesting.APICallerFunc {
+	return basetesting.APICallerFunc(
+		func(objType string, version int, id, req string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "Search")
+			c.Check(id, gc.Equals, "")
+			c.Check(req, gc.Equals, request)
+			c.Check(a, jc.DeepEquals, args)
+			c.Assert(result, gc.FitsTypeOf, &params.FoundEntities{})
+			*(result.(*params.FoundEntities)) = params.FoundEntities{Entities: foundEntities}
+			return nil
+		},
+	)
+}
+
+func (s *searchSuite) TestFindUnits(c *gc.C) {
+	client := search.NewClient(s.apiCaller(c, "FindUnits", params.FindUnitsArgs{Pattern: "mysql/*"}))
+	result, err := client.FindUnits("mysql/*")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, foundEntities)
+}
+
+func (s *searchSuite) TestFindMachines(c *gc.C) {
+	client := search.NewClient(s.apiCaller(c, "FindMachines", params.FindMachinesArgs{Query: "10.0.0.1"}))
+	result, err := client.FindMachines("10.0.0.1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, foundEntities)
+}
+
+func (s *searchSuite) TestFindMachinesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			return errors.New("boom")
+		},
+	)
+	client := search.NewClient(apiCaller)
+	_, err := client.FindMachines("10.0.0.1")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,35 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package securityupdates
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the SecurityUpdates facade, used to read
+// the patch posture of the machines in a model.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new SecurityUpdates client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "SecurityUpdates")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Summary returns the latest security updates report of each machine
+// in the model, and the totals across the machines which have
+// reported.
+func (c *Client) Summary() (params.SecurityUpdatesSummary, error) {
+	var result params.SecurityUpdatesSummary
+	if err := c.facade.FacadeCall("Summary", nil, &result); err != nil {
+		return params.SecurityUpdatesSummary{}, errors.Trace(err)
+	}
+	return result, nil
+}
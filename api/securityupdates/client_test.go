@@ -0,0 +1,65 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package securityupdates_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/securityupdates"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type securityUpdatesSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&securityUpdatesSuite{})
+
+func (s *securityUpdatesSuite) TestSummary(c *gc.C) {
+	summary := params.SecurityUpdatesSummary{
+		Machines: []params.MachineSecurityUpdates{{
+			Tag: "machine-0",
+			Report: &params.SecurityUpdatesReport{
+				Pending:  3,
+				Security: 1,
+				Checked:  time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+			},
+		}},
+		Reporting: 1,
+		Pending:   3,
+		Security:  1,
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "SecurityUpdates")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Summary")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.SecurityUpdatesSummary{})
+			*(result.(*params.SecurityUpdatesSummary)) = summary
+			return nil
+		},
+	)
+	client := securityupdates.NewClient(apiCaller)
+	result, err := client.Summary()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, summary)
+}
+
+func (s *securityUpdatesSuite) TestSummaryError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			return errors.New("boom")
+		},
+	)
+	client := securityupdates.NewClient(apiCaller)
+	_, err := client.Summary()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,52 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package securityupdatesreporter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/securityupdates"
+)
+
+const securityUpdatesReporterFacade = "SecurityUpdatesReporter"
+
+// Client provides access to the SecurityUpdatesReporter API facade.
+type Client struct {
+	*common.ModelWatcher
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new SecurityUpdatesReporter API client.
+func NewClient(caller base.APICaller) *Client {
+	facadeCaller := base.NewFacadeCaller(caller, securityUpdatesReporterFacade)
+	return &Client{
+		ModelWatcher: common.NewModelWatcher(facadeCaller),
+		facade:       facadeCaller,
+	}
+}
+
+// SetReport records the latest security updates report of the given
+// machine. A nil report removes the one recorded.
+func (c *Client) SetReport(machine names.MachineTag, report *securityupdates.Report) error {
+	arg := params.SetSecurityUpdates{Tag: machine.String()}
+	if report != nil {
+		arg.Report = &params.SecurityUpdatesReport{
+			Pending:        report.Pending,
+			Security:       report.Security,
+			RebootRequired: report.RebootRequired,
+			RebootPackages: report.RebootPackages,
+			Checked:        report.Checked,
+		}
+	}
+	args := params.SetSecurityUpdatesArgs{Args: []params.SetSecurityUpdates{arg}}
+	var result params.ErrorResults
+	if err := c.facade.FacadeCall("SetSecurityUpdates", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
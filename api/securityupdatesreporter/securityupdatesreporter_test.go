@@ -0,0 +1,80 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package securityupdatesreporter_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/securityupdatesreporter"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/securityupdates"
+)
+
+type SecurityUpdatesReporterSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SecurityUpdatesReporterSuite{})
+
+func (s *SecurityUpdatesReporterSuite) TestSetReport(c *gc.C) {
+	checked := time.Date(2020, 1, 3, 14, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "SecurityUpdatesReporter")
+		c.Check(request, gc.Equals, "SetSecurityUpdates")
+		c.Check(arg, jc.DeepEquals, params.SetSecurityUpdatesArgs{
+			Args: []params.SetSecurityUpdates{{
+				Tag: "machine-0",
+				Report: &params.SecurityUpdatesReport{
+					Pending:        5,
+					Security:       2,
+					RebootRequired: true,
+					Checked:        checked,
+				},
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+	client := securityupdatesreporter.NewClient(apiCaller)
+	err := client.SetReport(names.NewMachineTag("0"), &securityupdates.Report{
+		Pending:        5,
+		Security:       2,
+		RebootRequired: true,
+		Checked:        checked,
+	})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *SecurityUpdatesReporterSuite) TestSetReportRemoves(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(arg, jc.DeepEquals, params.SetSecurityUpdatesArgs{
+			Args: []params.SetSecurityUpdates{{Tag: "machine-0"}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	client := securityupdatesreporter.NewClient(apiCaller)
+	err := client.SetReport(names.NewMachineTag("0"), nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SecurityUpdatesReporterSuite) TestSetReportCallError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+	client := securityupdatesreporter.NewClient(apiCaller)
+	err := client.SetReport(names.NewMachineTag("0"), nil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -44,6 +44,9 @@ func (st *state) Login(tag names.Tag, password, nonce string, macaroons []macaro
 		Macaroons:   macaroons,
 		CLIArgs:     utils.CommandString(os.Args...),
 	}
+	if st.impersonate.Id() != "" {
+		request.ImpersonateTag = st.impersonate.String()
+	}
 	// If we are in developer mode, add the stack location as user data to the
 	// login request. This will allow the apiserver to connect connection ids
 	// to the particular place that initiated the connection.
@@ -138,6 +141,11 @@ func (st *state) Login(tag names.Tag, password, nonce string, macaroons []macaro
 		controllerAccess = result.UserInfo.ControllerAccess
 		modelAccess = result.UserInfo.ModelAccess
 	}
+	if st.impersonate.Id() != "" && tag != st.impersonate {
+		// Controllers which don't know about impersonation
+		// ignore the request, and log in as the superuser.
+		return errors.NotSupportedf("impersonating users on this controller")
+	}
 	servers := params.ToMachineHostsPorts(result.Servers)
 	if err = st.setLoginResult(loginResultParams{
 		tag:              tag,
@@ -0,0 +1,62 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statusalerts
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/statusalert"
+)
+
+const statusAlertsFacade = "StatusAlerts"
+
+// Client provides access to the StatusAlerts API facade.
+type Client struct {
+	*common.ModelWatcher
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new StatusAlerts API client.
+func NewClient(caller base.APICaller) *Client {
+	facadeCaller := base.NewFacadeCaller(caller, statusAlertsFacade)
+	return &Client{
+		ModelWatcher: common.NewModelWatcher(facadeCaller),
+		facade:       facadeCaller,
+	}
+}
+
+// Entities returns the current status of the machines, applications
+// and units in the model, to be matched against the model's status
+// alert rules.
+func (c *Client) Entities() ([]statusalert.Entity, error) {
+	var result params.StatusAlertEntitiesResult
+	if err := c.facade.FacadeCall("StatusAlertEntities", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	entities := make([]statusalert.Entity, len(result.Entities))
+	for i, e := range result.Entities {
+		entities[i] = statusalert.Entity{
+			Kind:        statusalert.Kind(e.Kind),
+			Name:        e.Name,
+			Application: e.Application,
+			Statuses:    make([]status.StatusInfo, len(e.Statuses)),
+		}
+		for j, s := range e.Statuses {
+			entities[i].Statuses[j] = status.StatusInfo{
+				Status:  s.Status,
+				Message: s.Info,
+				Data:    s.Data,
+				Since:   s.Since,
+			}
+		}
+	}
+	return entities, nil
+}
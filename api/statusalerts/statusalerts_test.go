@@ -0,0 +1,76 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statusalerts_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/statusalerts"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/statusalert"
+)
+
+type StatusAlertsSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&StatusAlertsSuite{})
+
+func (s *StatusAlertsSuite) TestEntities(c *gc.C) {
+	since := time.Date(2020, 1, 3, 14, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "StatusAlerts")
+		c.Check(request, gc.Equals, "StatusAlertEntities")
+		c.Check(arg, gc.IsNil)
+		c.Assert(result, gc.FitsTypeOf, &params.StatusAlertEntitiesResult{})
+		*(result.(*params.StatusAlertEntitiesResult)) = params.StatusAlertEntitiesResult{
+			Entities: []params.StatusAlertEntity{{
+				Kind:     "machine",
+				Name:     "0",
+				Statuses: []params.EntityStatus{{Status: status.Down, Since: &since}},
+			}, {
+				Kind:        "unit",
+				Name:        "mysql/0",
+				Application: "mysql",
+				Statuses: []params.EntityStatus{
+					{Status: status.Idle, Since: &since},
+					{Status: status.Error, Info: "hook failed", Since: &since},
+				},
+			}},
+		}
+		return nil
+	})
+	client := statusalerts.NewClient(apiCaller)
+	entities, err := client.Entities()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entities, jc.DeepEquals, []statusalert.Entity{{
+		Kind:     statusalert.KindMachine,
+		Name:     "0",
+		Statuses: []status.StatusInfo{{Status: status.Down, Since: &since}},
+	}, {
+		Kind:        statusalert.KindUnit,
+		Name:        "mysql/0",
+		Application: "mysql",
+		Statuses: []status.StatusInfo{
+			{Status: status.Idle, Since: &since},
+			{Status: status.Error, Message: "hook failed", Since: &since},
+		},
+	}})
+}
+
+func (s *StatusAlertsSuite) TestEntitiesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+	client := statusalerts.NewClient(apiCaller)
+	_, err := client.Entities()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
 )
 
 const apiName = "StatusHistory"
@@ -26,10 +27,23 @@ func NewFacade(caller base.APICaller) *Facade {
 }
 
 // Prune calls "StatusHistory.Prune"
-func (s *Facade) Prune(maxHistoryTime time.Duration, maxHistoryMB int) error {
+func (s *Facade) Prune(maxHistoryTime time.Duration, maxHistoryMB, maxEntriesPerEntity int, perKind map[status.RetentionKind]status.HistoryRetention, compact bool) error {
 	p := params.StatusHistoryPruneArgs{
-		MaxHistoryTime: maxHistoryTime,
-		MaxHistoryMB:   maxHistoryMB,
+		MaxHistoryTime:      maxHistoryTime,
+		MaxHistoryMB:        maxHistoryMB,
+		MaxEntriesPerEntity: maxEntriesPerEntity,
+		Compact:             compact,
+	}
+	for _, kind := range status.AllRetentionKinds() {
+		retention, ok := perKind[kind]
+		if !ok {
+			continue
+		}
+		p.PerKind = append(p.PerKind, params.StatusHistoryKindRetention{
+			Kind:                string(kind),
+			MaxHistoryTime:      retention.MaxAge,
+			MaxEntriesPerEntity: retention.MaxEntries,
+		})
 	}
 	return s.facade.FacadeCall("Prune", p, nil)
 }
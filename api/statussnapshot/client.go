@@ -0,0 +1,37 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statussnapshot
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the StatusSnapshot facade, used to
+// reconstruct the approximate status of a model at a past time.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new StatusSnapshot client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "StatusSnapshot")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// StatusSnapshot returns the approximate status of the model at the
+// given time, and the commands run against the model since then.
+func (c *Client) StatusSnapshot(at time.Time) (params.StatusSnapshotResult, error) {
+	var result params.StatusSnapshotResult
+	args := params.StatusSnapshotArgs{Time: at}
+	if err := c.facade.FacadeCall("StatusSnapshot", args, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
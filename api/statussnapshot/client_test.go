@@ -0,0 +1,63 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statussnapshot_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/statussnapshot"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type statusSnapshotSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&statusSnapshotSuite{})
+
+func (s *statusSnapshotSuite) TestStatusSnapshot(c *gc.C) {
+	at := time.Date(2020, 1, 3, 14, 0, 0, 0, time.UTC)
+	snapshot := params.StatusSnapshotResult{
+		Status: params.FullStatus{
+			Model: params.ModelStatusInfo{Name: "prod"},
+		},
+		Commands: []params.AuditedCommand{{
+			Who:  "bob",
+			What: "juju remove-unit mysql/1",
+			When: at.Add(time.Minute),
+		}},
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "StatusSnapshot")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "StatusSnapshot")
+			c.Check(a, jc.DeepEquals, params.StatusSnapshotArgs{Time: at})
+			c.Assert(result, gc.FitsTypeOf, &params.StatusSnapshotResult{})
+			*(result.(*params.StatusSnapshotResult)) = snapshot
+			return nil
+		},
+	)
+	client := statussnapshot.NewClient(apiCaller)
+	result, err := client.StatusSnapshot(at)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, snapshot)
+}
+
+func (s *statusSnapshotSuite) TestStatusSnapshotError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			return errors.New("boom")
+		},
+	)
+	client := statussnapshot.NewClient(apiCaller)
+	_, err := client.StatusSnapshot(time.Now())
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
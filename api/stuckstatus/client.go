@@ -0,0 +1,39 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package stuckstatus
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the StuckStatus facade, used to find the
+// machines, applications and units in a model which are stuck in
+// error, blocked or waiting.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new StuckStatus client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "StuckStatus")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// StuckStatuses returns the statuses in the model which are error,
+// blocked or waiting and have been for at least minDuration, those
+// stuck longest first.
+func (c *Client) StuckStatuses(minDuration time.Duration) ([]params.StuckStatus, error) {
+	args := params.StuckStatusesArgs{MinDuration: minDuration}
+	var result params.StuckStatusesResult
+	if err := c.facade.FacadeCall("StuckStatuses", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Statuses, nil
+}
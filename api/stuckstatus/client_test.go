@@ -0,0 +1,62 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package stuckstatus_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/stuckstatus"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type stuckStatusSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&stuckStatusSuite{})
+
+func (s *stuckStatusSuite) TestStuckStatuses(c *gc.C) {
+	statuses := []params.StuckStatus{{
+		Tag:      "unit-mysql-0",
+		Kind:     "workload",
+		Status:   "blocked",
+		Info:     "need a database",
+		Since:    time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+		Duration: time.Hour,
+	}}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "StuckStatus")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "StuckStatuses")
+			c.Check(a, jc.DeepEquals, params.StuckStatusesArgs{MinDuration: time.Minute})
+			c.Assert(result, gc.FitsTypeOf, &params.StuckStatusesResult{})
+			*(result.(*params.StuckStatusesResult)) = params.StuckStatusesResult{
+				Statuses: statuses,
+			}
+			return nil
+		},
+	)
+	client := stuckstatus.NewClient(apiCaller)
+	result, err := client.StuckStatuses(time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, statuses)
+}
+
+func (s *stuckStatusSuite) TestStuckStatusesError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			return errors.New("boom")
+		},
+	)
+	client := stuckstatus.NewClient(apiCaller)
+	_, err := client.StuckStatuses(0)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -54,6 +54,13 @@ func (c *Client) RemoveModel() error {
 	return c.entityFacadeCall("RemoveModel", nil)
 }
 
+// AbandonCloudEnvironment records that the cloud environment of the
+// force-destroyed model could not be torn down, for the given reason.
+func (c *Client) AbandonCloudEnvironment(reason string) error {
+	args := params.AbandonCloudEnvironmentArgs{Reason: reason}
+	return errors.Trace(c.caller.FacadeCall("AbandonCloudEnvironment", args, nil))
+}
+
 // SetStatus sets the status of the model.
 func (c *Client) SetStatus(status status.Status, message string, data map[string]interface{}) error {
 	args := params.SetStatus{
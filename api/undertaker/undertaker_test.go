@@ -59,6 +59,29 @@ func (s *UndertakerSuite) TestRemoveModel(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *UndertakerSuite) TestAbandonCloudEnvironment(c *gc.C) {
+	var called bool
+	apiCaller := basetesting.APICallerFunc(func(
+		objType string,
+		version int,
+		id, request string,
+		args, response interface{},
+	) error {
+		called = true
+		c.Check(objType, gc.Equals, "Undertaker")
+		c.Check(request, gc.Equals, "AbandonCloudEnvironment")
+		c.Check(args, jc.DeepEquals, params.AbandonCloudEnvironmentArgs{Reason: "boom"})
+		c.Check(response, gc.IsNil)
+		return nil
+	})
+	client, err := undertaker.NewClient(apiCaller, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = client.AbandonCloudEnvironment("boom")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
 func (s *UndertakerSuite) mockClient(c *gc.C, expectedRequest string, callback func(response interface{})) *undertaker.Client {
 	apiCaller := basetesting.APICallerFunc(func(
 		objType string,
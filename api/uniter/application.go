@@ -4,6 +4,8 @@
 package uniter
 
 import (
+	"time"
+
 	"fmt"
 
 	"github.com/juju/errors"
@@ -119,9 +121,11 @@ func (s *Application) CharmURL() (*charm.URL, bool, error) {
 }
 
 // SetStatus sets the status of the application if the passed unitName,
-// corresponding to the calling unit, is of the leader.
+// corresponding to the calling unit, is of the leader. The status is
+// recorded as having been set now.
 func (s *Application) SetStatus(unitName string, appStatus status.Status, info string, data map[string]interface{}) error {
 	tag := names.NewUnitTag(unitName)
+	now := time.Now()
 	var result params.ErrorResults
 	args := params.SetStatus{
 		Entities: []params.EntityStatusArgs{
@@ -130,6 +134,7 @@ func (s *Application) SetStatus(unitName string, appStatus status.Status, info s
 				Status: appStatus.String(),
 				Info:   info,
 				Data:   data,
+				Since:  &now,
 			},
 		},
 	}
@@ -140,6 +145,71 @@ func (s *Application) SetStatus(unitName string, appStatus status.Status, info s
 	return result.OneError()
 }
 
+// StatusUpdate describes a status to be set by SetStatuses.
+type StatusUpdate struct {
+	// Tag is the tag of the application or of one of its units.
+	Tag    names.Tag
+	Status status.Status
+	Info   string
+	Data   map[string]interface{}
+
+	// Since is when the status was set. If it is nil, the status is
+	// recorded as having been set when SetStatuses is called.
+	Since *time.Time
+}
+
+// SetStatuses sets the statuses of the application and of its units
+// in one call, if the passed unitName, corresponding to the calling
+// unit, is of the leader. The statuses are only set while the unit
+// remains the leader. It returns the error, if any, from setting each
+// of the statuses.
+func (s *Application) SetStatuses(unitName string, updates []StatusUpdate) ([]error, error) {
+	// Just a safety check since controller is always ahead of unit agents.
+	if s.st.facade.BestAPIVersion() < 15 {
+		return nil, errors.NotImplementedf("SetStatuses() (need V15+)")
+	}
+	arg := params.BulkSetStatusArg{
+		Unit:     names.NewUnitTag(unitName).String(),
+		Entities: make([]params.EntityStatusArgs, len(updates)),
+	}
+	now := time.Now()
+	for i, update := range updates {
+		since := update.Since
+		if since == nil {
+			since = &now
+		}
+		arg.Entities[i] = params.EntityStatusArgs{
+			Tag:    update.Tag.String(),
+			Status: update.Status.String(),
+			Info:   update.Info,
+			Data:   update.Data,
+			Since:  since,
+		}
+	}
+	var results params.BulkSetStatusResults
+	args := params.BulkSetStatusArgs{Args: []params.BulkSetStatusArg{arg}}
+	if err := s.st.facade.FacadeCall("BulkSetStatus", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if len(result.Results) != len(updates) {
+		return nil, errors.Errorf("expected %d results, got %d", len(updates), len(result.Results))
+	}
+	errs := make([]error, len(updates))
+	for i, r := range result.Results {
+		if r.Error != nil {
+			errs[i] = r.Error
+		}
+	}
+	return errs, nil
+}
+
 // Status returns the status of the application if the passed unitName,
 // corresponding to the calling unit, is of the leader.
 func (s *Application) Status(unitName string) (params.ApplicationStatusResult, error) {
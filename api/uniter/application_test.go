@@ -115,6 +115,59 @@ func (s *applicationSuite) TestSetApplicationStatus(c *gc.C) {
 	c.Check(stat.Message, gc.Equals, message)
 }
 
+func (s *applicationSuite) TestSetStatuses(c *gc.C) {
+	updates := []uniter.StatusUpdate{{
+		Tag:    s.wordpressApplication.Tag(),
+		Status: status.Active,
+		Info:   "all units ready",
+	}, {
+		Tag:    s.wordpressUnit.Tag(),
+		Status: status.Active,
+		Info:   "ready",
+	}, {
+		Tag:    names.NewApplicationTag("mysql"),
+		Status: status.Active,
+	}}
+	_, err := s.apiApplication.SetStatuses(s.wordpressUnit.Name(), updates)
+	c.Check(err, gc.ErrorMatches, `"wordpress/0" is not leader of "wordpress"`)
+
+	s.claimLeadership(c, s.wordpressUnit, s.wordpressApplication)
+
+	errs, err := s.apiApplication.SetStatuses(s.wordpressUnit.Name(), updates)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 3)
+	c.Check(errs[0], jc.ErrorIsNil)
+	c.Check(errs[1], jc.ErrorIsNil)
+	c.Check(errs[2], gc.ErrorMatches, "permission denied")
+
+	stat, err := s.wordpressApplication.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stat.Status, gc.Equals, status.Active)
+	c.Check(stat.Message, gc.Equals, "all units ready")
+	stat, err = s.wordpressUnit.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stat.Status, gc.Equals, status.Active)
+	c.Check(stat.Message, gc.Equals, "ready")
+}
+
+func (s *applicationSuite) TestSetStatusesSince(c *gc.C) {
+	s.claimLeadership(c, s.wordpressUnit, s.wordpressApplication)
+	since := time.Now().Add(-time.Hour).Round(time.Second)
+	errs, err := s.apiApplication.SetStatuses(s.wordpressUnit.Name(), []uniter.StatusUpdate{{
+		Tag:    s.wordpressUnit.Tag(),
+		Status: status.Active,
+		Since:  &since,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, gc.HasLen, 1)
+	c.Assert(errs[0], jc.ErrorIsNil)
+
+	stat, err := s.wordpressUnit.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stat.Since, gc.NotNil)
+	c.Check(stat.Since.Equal(since), jc.IsTrue)
+}
+
 func (s *applicationSuite) TestApplicationStatus(c *gc.C) {
 	message := "a test message"
 	stat, err := s.wordpressApplication.Status()
@@ -4,6 +4,8 @@
 package uniter
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v3"
@@ -24,6 +26,9 @@ type Unit struct {
 	life         life.Value
 	resolvedMode params.ResolvedMode
 	providerID   string
+
+	maintenance        bool
+	maintenanceMessage string
 }
 
 // Tag returns the unit's tag.
@@ -56,6 +61,12 @@ func (u *Unit) Resolved() params.ResolvedMode {
 	return u.resolvedMode
 }
 
+// Maintenance returns the message given when the unit was put into
+// maintenance, and whether it is in maintenance.
+func (u *Unit) Maintenance() (string, bool) {
+	return u.maintenanceMessage, u.maintenance
+}
+
 // Refresh updates the cached local copy of the unit's data.
 func (u *Unit) Refresh() error {
 	var results params.UnitRefreshResults
@@ -79,18 +90,22 @@ func (u *Unit) Refresh() error {
 	u.life = result.Life
 	u.resolvedMode = result.Resolved
 	u.providerID = result.ProviderID
+	u.maintenance = result.Maintenance
+	u.maintenanceMessage = result.MaintenanceMessage
 	return nil
 }
 
-// SetUnitStatus sets the status of the unit.
+// SetUnitStatus sets the status of the unit, recording it as having
+// been set now.
 func (u *Unit) SetUnitStatus(unitStatus status.Status, info string, data map[string]interface{}) error {
 	if u.st.facade.BestAPIVersion() < 2 {
 		return errors.NotImplementedf("SetUnitStatus")
 	}
+	now := time.Now()
 	var result params.ErrorResults
 	args := params.SetStatus{
 		Entities: []params.EntityStatusArgs{
-			{Tag: u.tag.String(), Status: unitStatus.String(), Info: info, Data: data},
+			{Tag: u.tag.String(), Status: unitStatus.String(), Info: info, Data: data, Since: &now},
 		},
 	}
 	err := u.st.facade.FacadeCall("SetUnitStatus", args, &result)
@@ -122,12 +137,66 @@ func (u *Unit) UnitStatus() (params.StatusResult, error) {
 	return result, nil
 }
 
-// SetAgentStatus sets the status of the unit agent.
+// StatusHistory returns up to size of the unit's most recent workload
+// or juju-unit statuses, newest first.
+func (u *Unit) StatusHistory(kind status.HistoryKind, size int) ([]params.DetailedStatus, error) {
+	if u.st.facade.BestAPIVersion() < 16 {
+		return nil, errors.NotImplementedf("StatusHistory")
+	}
+	var results params.StatusHistoryResults
+	args := params.StatusHistoryRequests{
+		Requests: []params.StatusHistoryRequest{{
+			Tag:    u.tag.String(),
+			Kind:   string(kind),
+			Filter: params.StatusHistoryFilter{Size: size},
+		}},
+	}
+	err := u.st.facade.FacadeCall("UnitStatusHistory", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.History.Statuses, nil
+}
+
+// PeerStatuses returns the workload statuses of the other units of the
+// unit's application.
+func (u *Unit) PeerStatuses() ([]params.StatusResult, error) {
+	if u.st.facade.BestAPIVersion() < 16 {
+		return nil, errors.NotImplementedf("PeerStatuses")
+	}
+	var results params.PeerUnitStatusResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("PeerUnitStatuses", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return result.Units, nil
+}
+
+// SetAgentStatus sets the status of the unit agent, recording it as
+// having been set now.
 func (u *Unit) SetAgentStatus(agentStatus status.Status, info string, data map[string]interface{}) error {
+	now := time.Now()
 	var result params.ErrorResults
 	args := params.SetStatus{
 		Entities: []params.EntityStatusArgs{
-			{Tag: u.tag.String(), Status: agentStatus.String(), Info: info, Data: data},
+			{Tag: u.tag.String(), Status: agentStatus.String(), Info: info, Data: data, Since: &now},
 		},
 	}
 	setStatusFacadeCall := "SetAgentStatus"
@@ -558,6 +627,71 @@ func (u *Unit) ClearResolved() error {
 	return result.OneError()
 }
 
+// StartCharmDeployment reports whether the unit may start installing
+// or upgrading its charm, as limited by the model's max-charm-deployments.
+func (u *Unit) StartCharmDeployment() (bool, error) {
+	// Just a safety check since controller is always ahead of unit agents.
+	if u.st.facade.BestAPIVersion() < 14 {
+		return false, errors.NotImplementedf("StartCharmDeployment() (need V14+)")
+	}
+	var results params.BoolResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("StartCharmDeployment", args, &results)
+	if err != nil {
+		return false, err
+	}
+	if len(results.Results) != 1 {
+		return false, errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.Result, nil
+}
+
+// FinishCharmDeployment records that the unit has finished installing
+// or upgrading its charm, allowing another unit of its application to
+// start.
+func (u *Unit) FinishCharmDeployment() error {
+	// Just a safety check since controller is always ahead of unit agents.
+	if u.st.facade.BestAPIVersion() < 14 {
+		return errors.NotImplementedf("FinishCharmDeployment() (need V14+)")
+	}
+	var result params.ErrorResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: u.tag.String()}},
+	}
+	err := u.st.facade.FacadeCall("FinishCharmDeployment", args, &result)
+	if err != nil {
+		return err
+	}
+	return result.OneError()
+}
+
+// WatchCharmDeployments returns a watcher for observing the units of
+// the unit's application starting and finishing deploying its charm.
+func (u *Unit) WatchCharmDeployments() (watcher.NotifyWatcher, error) {
+	// Just a safety check since controller is always ahead of unit agents.
+	if u.st.facade.BestAPIVersion() < 14 {
+		return nil, errors.NotImplementedf("WatchCharmDeployments() (need V14+)")
+	}
+	return common.Watch(u.st.facade, "WatchCharmDeployments", u.tag)
+}
+
+// WatchKubernetesEvents returns a watcher for observing the Kubernetes
+// events of the unit's pod and of its application's service. It is only
+// supported for units in Kubernetes models.
+func (u *Unit) WatchKubernetesEvents() (watcher.NotifyWatcher, error) {
+	// Just a safety check since controller is always ahead of unit agents.
+	if u.st.facade.BestAPIVersion() < 18 {
+		return nil, errors.NotImplementedf("WatchKubernetesEvents() (need V18+)")
+	}
+	return common.Watch(u.st.facade, "WatchKubernetesEvents", u.tag)
+}
+
 // WatchConfigSettingsHash returns a watcher for observing changes to
 // the unit's charm configuration settings (with a hash of the
 // settings content so we can determine whether it has changed since
@@ -825,6 +959,25 @@ func (u *Unit) NetworkInfo(bindings []string, relationId *int) (map[string]param
 	return results.Results, nil
 }
 
+// RelationsNetworkInfo returns the ingress addresses, egress subnets and
+// bound space of each of the unit's relations on the given endpoints,
+// or on all its endpoints if none are given.
+func (u *Unit) RelationsNetworkInfo(endpoints ...string) ([]params.RelationNetworkInfo, error) {
+	if u.st.facade.BestAPIVersion() < 17 {
+		return nil, errors.NotImplementedf("RelationsNetworkInfo")
+	}
+	var results params.RelationNetworkInfoResults
+	args := params.RelationNetworkInfoParams{
+		Unit:      u.tag.String(),
+		Endpoints: endpoints,
+	}
+	err := u.st.facade.FacadeCall("RelationsNetworkInfo", args, &results)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Results, nil
+}
+
 // UpdateNetworkInfo updates the network settings for the unit's bound
 // endpoints.
 func (u *Unit) UpdateNetworkInfo() error {
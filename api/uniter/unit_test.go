@@ -128,6 +128,59 @@ func (s *unitSuite) TestSetUnitStatus(c *gc.C) {
 	c.Assert(agentStatusInfo.Data, gc.HasLen, 0)
 }
 
+func (s *unitSuite) TestStatusHistory(c *gc.C) {
+	now := time.Now()
+	for i, message := range []string{"one", "two", "three"} {
+		since := now.Add(time.Duration(i) * time.Second)
+		err := s.wordpressUnit.SetStatus(status.StatusInfo{
+			Status:  status.Maintenance,
+			Message: message,
+			Since:   &since,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	history, err := s.apiUnit.StatusHistory(status.KindWorkload, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Assert(history[0].Info, gc.Equals, "three")
+	c.Assert(history[0].Kind, gc.Equals, "workload")
+	c.Assert(history[1].Info, gc.Equals, "two")
+
+	history, err = s.apiUnit.StatusHistory(status.KindUnitAgent, 10)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.Not(gc.HasLen), 0)
+	c.Assert(history[len(history)-1].Status, gc.Equals, "allocating")
+	c.Assert(history[len(history)-1].Kind, gc.Equals, "juju-unit")
+
+	_, err = s.apiUnit.StatusHistory(status.KindMachine, 10)
+	c.Assert(err, gc.ErrorMatches, `unit status history kind "machine" not valid`)
+}
+
+func (s *unitSuite) TestPeerStatuses(c *gc.C) {
+	peer := s.Factory.MakeUnit(c, &jujufactory.UnitParams{Application: s.wordpressApplication})
+	now := time.Now()
+	err := peer.SetStatus(status.StatusInfo{
+		Status:  status.Blocked,
+		Message: "need a database",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	peers, err := s.apiUnit.PeerStatuses()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(peers, gc.HasLen, 1)
+	c.Assert(peers[0].Since, gc.NotNil)
+	peers[0].Since = nil
+	c.Assert(peers[0], jc.DeepEquals, params.StatusResult{
+		Id:     peer.Name(),
+		Life:   "alive",
+		Status: "blocked",
+		Info:   "need a database",
+		Data:   map[string]interface{}{},
+	})
+}
+
 func (s *unitSuite) TestUnitStatus(c *gc.C) {
 	now := time.Now()
 	sInfo := status.StatusInfo{
@@ -281,6 +334,29 @@ func (s *unitSuite) TestRefreshUpdateProviderID(c *gc.C) {
 	c.Assert(s.apiUnit.ProviderID(), gc.Equals, providerID)
 }
 
+func (s *unitSuite) TestRefreshMaintenance(c *gc.C) {
+	_, ok := s.apiUnit.Maintenance()
+	c.Assert(ok, jc.IsFalse)
+
+	err := s.wordpressUnit.SetMaintenance("disk swap")
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.apiUnit.Maintenance()
+	c.Assert(ok, jc.IsFalse)
+
+	err = s.apiUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	message, ok := s.apiUnit.Maintenance()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(message, gc.Equals, "disk swap")
+
+	err = s.wordpressUnit.ClearMaintenance()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.apiUnit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.apiUnit.Maintenance()
+	c.Assert(ok, jc.IsFalse)
+}
+
 func (s *unitSuite) TestWatch(c *gc.C) {
 	c.Assert(s.apiUnit.Life(), gc.Equals, life.Alive)
 
@@ -304,6 +380,34 @@ func (s *unitSuite) TestWatch(c *gc.C) {
 	wc.AssertOneChange()
 }
 
+func (s *unitSuite) TestCharmDeployment(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{"max-charm-deployments": 1}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := s.apiUnit.WatchCharmDeployments()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, nil)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	started, err := s.apiUnit.StartCharmDeployment()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(started, jc.IsTrue)
+	wc.AssertOneChange()
+	deployments, err := s.wordpressApplication.CharmDeployments()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(deployments, jc.DeepEquals, []string{s.wordpressUnit.Name()})
+
+	err = s.apiUnit.FinishCharmDeployment()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	deployments, err = s.wordpressApplication.CharmDeployments()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(deployments, gc.HasLen, 0)
+}
+
 func (s *unitSuite) TestWatchRelations(c *gc.C) {
 	w, err := s.apiUnit.WatchRelations()
 	c.Assert(err, jc.ErrorIsNil)
@@ -539,6 +643,55 @@ func (s *unitSuite) TestNetworkInfo(c *gc.C) {
 	c.Assert(called, gc.Equals, 2)
 }
 
+func (s *unitSuite) TestRelationsNetworkInfo(c *gc.C) {
+	var called int
+	apiCaller := testing.BestVersionCaller{
+		BestVersion: 17,
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			called++
+			if called == 1 {
+				*(result.(*params.UnitRefreshResults)) = params.UnitRefreshResults{
+					Results: []params.UnitRefreshResult{{Life: life.Alive, Resolved: params.ResolvedNone}}}
+				return nil
+			}
+			c.Check(objType, gc.Equals, "Uniter")
+			c.Check(request, gc.Equals, "RelationsNetworkInfo")
+			c.Check(arg, gc.DeepEquals, params.RelationNetworkInfoParams{
+				Unit:      "unit-mysql-0",
+				Endpoints: []string{"server"},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.RelationNetworkInfoResults{})
+			*(result.(*params.RelationNetworkInfoResults)) = params.RelationNetworkInfoResults{
+				Results: []params.RelationNetworkInfo{{
+					RelationId:        2,
+					Endpoint:          "server",
+					RemoteApplication: "wordpress",
+					CrossModel:        true,
+					IngressAddresses:  []string{"4.3.2.1"},
+					EgressSubnets:     []string{"4.3.2.1/32"},
+				}},
+			}
+			return nil
+		},
+	}
+
+	ut := names.NewUnitTag("mysql/0")
+	st := uniter.NewState(apiCaller, ut)
+	unit, err := st.Unit(ut)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := unit.RelationsNetworkInfo("server")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, []params.RelationNetworkInfo{{
+		RelationId:        2,
+		Endpoint:          "server",
+		RemoteApplication: "wordpress",
+		CrossModel:        true,
+		IngressAddresses:  []string{"4.3.2.1"},
+		EgressSubnets:     []string{"4.3.2.1/32"},
+	}})
+	c.Assert(called, gc.Equals, 2)
+}
+
 func (s *unitSuite) TestConfigSettings(c *gc.C) {
 	// Make sure ConfigSettings returns an error when
 	// no charm URL is set, as its state counterpart does.
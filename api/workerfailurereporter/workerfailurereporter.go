@@ -0,0 +1,49 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package workerfailurereporter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+)
+
+const workerFailureReporterFacade = "WorkerFailureReporter"
+
+// Client provides access to the WorkerFailureReporter API facade.
+type Client struct {
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new WorkerFailureReporter API client.
+func NewClient(caller base.APICaller) *Client {
+	return &Client{
+		facade: base.NewFacadeCaller(caller, workerFailureReporterFacade),
+	}
+}
+
+// AddFailures adds the given worker failures to the history kept for
+// the agent of the given machine.
+func (c *Client) AddFailures(machine names.MachineTag, failures []status.WorkerFailure) error {
+	arg := params.AddWorkerFailures{
+		Tag:      machine.String(),
+		Failures: make([]params.WorkerFailure, len(failures)),
+	}
+	for i, f := range failures {
+		arg.Failures[i] = params.WorkerFailure{
+			Worker: f.Worker,
+			Error:  f.Error,
+			Time:   f.Time,
+		}
+	}
+	args := params.AddWorkerFailuresArgs{Args: []params.AddWorkerFailures{arg}}
+	var result params.ErrorResults
+	if err := c.facade.FacadeCall("AddWorkerFailures", args, &result); err != nil {
+		return errors.Trace(err)
+	}
+	return result.OneError()
+}
@@ -0,0 +1,75 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package workerfailurereporter_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/workerfailurereporter"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+)
+
+type WorkerFailureReporterSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&WorkerFailureReporterSuite{})
+
+func (s *WorkerFailureReporterSuite) TestAddFailures(c *gc.C) {
+	failed := time.Date(2020, 1, 3, 14, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "WorkerFailureReporter")
+		c.Check(request, gc.Equals, "AddWorkerFailures")
+		c.Check(arg, jc.DeepEquals, params.AddWorkerFailuresArgs{
+			Args: []params.AddWorkerFailures{{
+				Tag: "machine-0",
+				Failures: []params.WorkerFailure{{
+					Worker: "disk-manager",
+					Error:  "boom",
+					Time:   failed,
+				}},
+			}},
+		})
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	})
+	client := workerfailurereporter.NewClient(apiCaller)
+	err := client.AddFailures(names.NewMachineTag("0"), []status.WorkerFailure{{
+		Worker: "disk-manager",
+		Error:  "boom",
+		Time:   failed,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WorkerFailureReporterSuite) TestAddFailuresResultError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+		}
+		return nil
+	})
+	client := workerfailurereporter.NewClient(apiCaller)
+	err := client.AddFailures(names.NewMachineTag("0"), nil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *WorkerFailureReporterSuite) TestAddFailuresCallError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		return errors.New("boom")
+	})
+	client := workerfailurereporter.NewClient(apiCaller)
+	err := client.AddFailures(names.NewMachineTag("0"), nil)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
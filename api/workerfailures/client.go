@@ -0,0 +1,52 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package workerfailures
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+)
+
+// Client provides access to the WorkerFailures facade, used to read
+// the recent worker failures reported by machine agents.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new WorkerFailures client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "WorkerFailures")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// WorkerFailures returns the recent worker failures reported by the
+// agent of the given machine, oldest first.
+func (c *Client) WorkerFailures(machine names.MachineTag) ([]status.WorkerFailure, error) {
+	args := params.Entities{Entities: []params.Entity{{Tag: machine.String()}}}
+	var results params.WorkerFailuresResults
+	if err := c.facade.FacadeCall("WorkerFailures", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	var failures []status.WorkerFailure
+	for _, f := range result.Failures {
+		failures = append(failures, status.WorkerFailure{
+			Worker: f.Worker,
+			Error:  f.Error,
+			Time:   f.Time,
+		})
+	}
+	return failures, nil
+}
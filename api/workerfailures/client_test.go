@@ -0,0 +1,85 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package workerfailures_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/workerfailures"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type workerFailuresSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&workerFailuresSuite{})
+
+func (s *workerFailuresSuite) TestWorkerFailures(c *gc.C) {
+	failed := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "WorkerFailures")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "WorkerFailures")
+			c.Check(a, jc.DeepEquals, params.Entities{
+				Entities: []params.Entity{{Tag: "machine-0"}},
+			})
+			c.Assert(result, gc.FitsTypeOf, &params.WorkerFailuresResults{})
+			*(result.(*params.WorkerFailuresResults)) = params.WorkerFailuresResults{
+				Results: []params.WorkerFailuresResult{{
+					Failures: []params.WorkerFailure{{
+						Worker: "disk-manager",
+						Error:  "boom",
+						Time:   failed,
+					}},
+				}},
+			}
+			return nil
+		},
+	)
+	client := workerfailures.NewClient(apiCaller)
+	failures, err := client.WorkerFailures(names.NewMachineTag("0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, jc.DeepEquals, []status.WorkerFailure{{
+		Worker: "disk-manager",
+		Error:  "boom",
+		Time:   failed,
+	}})
+}
+
+func (s *workerFailuresSuite) TestWorkerFailuresResultError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			*(result.(*params.WorkerFailuresResults)) = params.WorkerFailuresResults{
+				Results: []params.WorkerFailuresResult{{
+					Error: &params.Error{Message: "machine 0 not found"},
+				}},
+			}
+			return nil
+		},
+	)
+	client := workerfailures.NewClient(apiCaller)
+	_, err := client.WorkerFailures(names.NewMachineTag("0"))
+	c.Assert(err, gc.ErrorMatches, "machine 0 not found")
+}
+
+func (s *workerFailuresSuite) TestWorkerFailuresError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			return errors.New("boom")
+		},
+	)
+	client := workerfailures.NewClient(apiCaller)
+	_, err := client.WorkerFailures(names.NewMachineTag("0"))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,36 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package zones
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client provides access to the Zones facade, used to analyse how
+// the units of a model's applications are spread across availability
+// zones.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient returns a new Zones client.
+func NewClient(caller base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(caller, "Zones")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// ZoneReport returns the availability zones of the model, along with
+// the spread of each application's units across them and the unit
+// moves that would even out any uneven spread.
+func (c *Client) ZoneReport() (params.ZoneReport, error) {
+	var result params.ZoneReport
+	if err := c.facade.FacadeCall("ZoneReport", nil, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package zones_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/zones"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type zonesSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&zonesSuite{})
+
+func (s *zonesSuite) TestZoneReport(c *gc.C) {
+	report := params.ZoneReport{
+		Zones: []params.ZoneResult{{Name: "zone-a", Available: true}},
+		Applications: []params.ApplicationZoneSpread{{
+			Application: "mysql",
+			Units:       map[string][]string{"zone-a": {"mysql/0"}},
+			Balanced:    true,
+		}},
+	}
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			c.Check(objType, gc.Equals, "Zones")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "ZoneReport")
+			c.Check(a, gc.IsNil)
+			c.Assert(result, gc.FitsTypeOf, &params.ZoneReport{})
+			*(result.(*params.ZoneReport)) = report
+			return nil
+		},
+	)
+	client := zones.NewClient(apiCaller)
+	result, err := client.ZoneReport()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, report)
+}
+
+func (s *zonesSuite) TestZoneReportError(c *gc.C) {
+	apiCaller := basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, result interface{}) error {
+			return errors.New("boom")
+		},
+	)
+	client := zones.NewClient(apiCaller)
+	_, err := client.ZoneReport()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
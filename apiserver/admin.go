@@ -176,20 +176,25 @@ func (a *admin) getAuditRecorder(req params.LoginRequest, authResult *authResult
 	if !authResult.userLogin || !cfg.Enabled {
 		return nil, nil
 	}
+	args := auditlog.ConversationArgs{
+		Who:          a.root.entity.Tag().Id(),
+		What:         req.CLIArgs,
+		ModelName:    a.root.model.Name(),
+		ModelUUID:    a.root.model.UUID(),
+		ConnectionID: a.root.connectionID,
+	}
 	// Wrap the audit logger in a filter that prevents us from logging
 	// lots of readonly conversations (like "juju status" requests).
-	filter := observer.MakeInterestingRequestFilter(cfg.ExcludeMethods)
-	result, err := auditlog.NewRecorder(
-		observer.NewAuditLogFilter(cfg.Target, filter),
-		a.srv.clock,
-		auditlog.ConversationArgs{
-			Who:          a.root.entity.Tag().Id(),
-			What:         req.CLIArgs,
-			ModelName:    a.root.model.Name(),
-			ModelUUID:    a.root.model.UUID(),
-			ConnectionID: a.root.connectionID,
-		},
-	)
+	// Everything done while impersonating a user is logged.
+	target := cfg.Target
+	if authResult.impersonator == nil {
+		filter := observer.MakeInterestingRequestFilter(cfg.ExcludeMethods)
+		target = observer.NewAuditLogFilter(cfg.Target, filter)
+	} else {
+		args.Who = authResult.impersonator.Id()
+		args.AsUser = a.root.entity.Tag().Id()
+	}
+	result, err := auditlog.NewRecorder(target, a.srv.clock, args)
 	if err != nil {
 		logger.Errorf("couldn't add login to audit log: %+v", err)
 		return nil, errors.Trace(err)
@@ -199,6 +204,7 @@ func (a *admin) getAuditRecorder(req params.LoginRequest, authResult *authResult
 
 type authResult struct {
 	tag                    names.Tag // nil if external user login
+	impersonator           names.Tag // nil unless impersonating a user
 	anonymousLogin         bool
 	userLogin              bool // false if anonymous user
 	controllerOnlyLogin    bool
@@ -304,9 +310,14 @@ func (a *admin) authenticate(req params.LoginRequest) (*authResult, error) {
 			controllerConn,
 			req.UserData,
 		)
+		if err := a.impersonate(req, result); err != nil {
+			return nil, errors.Trace(err)
+		}
 	} else if a.root.model == nil { // anonymous login to unknown model
 		// Hide the fact that the model does not exist
 		return nil, errors.Unauthorizedf("invalid entity name or password")
+	} else if req.ImpersonateTag != "" {
+		return nil, errors.Trace(common.ErrPerm)
 	}
 	a.loggedIn = true
 
@@ -329,6 +340,50 @@ func (a *admin) authenticate(req params.LoginRequest) (*authResult, error) {
 	return result, nil
 }
 
+// impersonate replaces the authenticated user with the user named in the
+// login request, if any, so that a controller superuser can see what that
+// user sees when debugging their permissions. It is only allowed when the
+// audit log is enabled, so that there is a record of it.
+func (a *admin) impersonate(req params.LoginRequest, result *authResult) error {
+	if req.ImpersonateTag == "" {
+		return nil
+	}
+	if !result.userLogin {
+		return errors.Trace(common.ErrPerm)
+	}
+	userTag := a.root.entity.Tag().(names.UserTag)
+	controllerUser, err := state.ControllerAccess(a.root.state, userTag)
+	if errors.IsNotFound(err) {
+		return errors.Trace(common.ErrPerm)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if controllerUser.Access != permission.SuperuserAccess {
+		return errors.Trace(common.ErrPerm)
+	}
+	if !a.srv.GetAuditConfig().Enabled {
+		return errors.New("cannot impersonate a user when the audit log is disabled")
+	}
+	target, err := names.ParseUserTag(req.ImpersonateTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !target.IsLocal() {
+		return errors.NotSupportedf("impersonating external user %q", target.Id())
+	}
+	user, err := a.root.state.User(target)
+	if err != nil {
+		return errors.Annotatef(err, "cannot impersonate user %q", target.Id())
+	}
+	if user.IsDisabled() {
+		return errors.Errorf("cannot impersonate disabled user %q", target.Id())
+	}
+	logger.Infof("user %q impersonating %q", userTag.Id(), target.Id())
+	result.impersonator = userTag
+	a.root.entity = user
+	return nil
+}
+
 func (a *admin) maybeEmitRedirectError(modelUUID string, authTag names.Tag) error {
 	userTag, ok := authTag.(names.UserTag)
 	if !ok {
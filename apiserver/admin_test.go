@@ -1352,6 +1352,120 @@ func (s *loginSuite) TestAuditLoggingUsesExcludeMethods(c *gc.C) {
 	c.Assert(req2.Method, gc.Equals, "DestroyMachines")
 }
 
+func (s *loginSuite) newImpersonationServer(c *gc.C, log auditlog.AuditLog) (*api.Info, *apiserver.Server, apiserver.ServerConfig) {
+	cfg := testserver.DefaultServerConfig(c)
+	cfg.GetAuditConfig = func() auditlog.Config {
+		return auditlog.Config{
+			Enabled: log != nil,
+			Target:  log,
+		}
+	}
+	cfg.Clock = testclock.NewClock(cfg.Clock.Now())
+	info, srv := s.newServerWithConfig(c, cfg)
+	info.ModelTag = s.Model.Tag().(names.ModelTag)
+	return info, srv, cfg
+}
+
+func (s *loginSuite) TestLoginImpersonating(c *gc.C) {
+	log := &servertesting.FakeAuditLog{}
+	info, srv, cfg := s.newImpersonationServer(c, log)
+	defer assertStop(c, srv)
+
+	user := s.Factory.MakeUser(c, &factory.UserParams{Name: "alice"})
+	conn := s.openAPIWithoutLogin(c, info)
+
+	var result params.LoginResult
+	request := &params.LoginRequest{
+		AuthTag:        s.AdminUserTag(c).String(),
+		Credentials:    "dummy-secret",
+		CLIArgs:        "juju status --as-user alice",
+		ImpersonateTag: user.Tag().String(),
+	}
+	err := conn.APICall("Admin", 3, "", "Login", request, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.UserInfo, gc.NotNil)
+	c.Assert(result.UserInfo.Identity, gc.Equals, "user-alice")
+	c.Assert(result.UserInfo.ControllerAccess, gc.Equals, "login")
+
+	// The conversation is logged straight away, even though there
+	// haven't been any interesting requests.
+	log.CheckCallNames(c, "AddConversation")
+	convo := log.Calls()[0].Args[0].(auditlog.Conversation)
+	convo.ConversationID = "0123456789abcdef"
+	convo.ConnectionID = "something"
+	c.Assert(convo, gc.Equals, auditlog.Conversation{
+		Who:            s.AdminUserTag(c).Id(),
+		What:           "juju status --as-user alice",
+		When:           cfg.Clock.Now().Format(time.RFC3339),
+		ModelName:      s.Model.Name(),
+		ModelUUID:      s.Model.UUID(),
+		ConnectionID:   "something",
+		ConversationID: "0123456789abcdef",
+		AsUser:         "alice",
+	})
+
+	// So are read-only requests.
+	var status params.FullStatus
+	err = conn.APICall("Client", 1, "", "FullStatus", params.StatusParams{}, &status)
+	c.Assert(err, jc.ErrorIsNil)
+	log.CheckCallNames(c, "AddConversation", "AddRequest", "AddResponse")
+}
+
+func (s *loginSuite) TestLoginImpersonatingRequiresSuperuser(c *gc.C) {
+	info, srv, _ := s.newImpersonationServer(c, &servertesting.FakeAuditLog{})
+	defer assertStop(c, srv)
+
+	password := "shhh..."
+	user := s.Factory.MakeUser(c, &factory.UserParams{Password: password})
+	other := s.Factory.MakeUser(c, nil)
+	conn := s.openAPIWithoutLogin(c, info)
+
+	var result params.LoginResult
+	request := &params.LoginRequest{
+		AuthTag:        user.Tag().String(),
+		Credentials:    password,
+		ImpersonateTag: other.Tag().String(),
+	}
+	err := conn.APICall("Admin", 3, "", "Login", request, &result)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(params.ErrCode(err), gc.Equals, params.CodeUnauthorized)
+}
+
+func (s *loginSuite) TestLoginImpersonatingRequiresAuditLog(c *gc.C) {
+	info, srv, _ := s.newImpersonationServer(c, nil)
+	defer assertStop(c, srv)
+
+	user := s.Factory.MakeUser(c, nil)
+	conn := s.openAPIWithoutLogin(c, info)
+
+	var result params.LoginResult
+	request := &params.LoginRequest{
+		AuthTag:        s.AdminUserTag(c).String(),
+		Credentials:    "dummy-secret",
+		ImpersonateTag: user.Tag().String(),
+	}
+	err := conn.APICall("Admin", 3, "", "Login", request, &result)
+	c.Assert(err, gc.ErrorMatches, "cannot impersonate a user when the audit log is disabled")
+}
+
+func (s *loginSuite) TestLoginImpersonatingUnknownUser(c *gc.C) {
+	log := &servertesting.FakeAuditLog{}
+	info, srv, _ := s.newImpersonationServer(c, log)
+	defer assertStop(c, srv)
+
+	conn := s.openAPIWithoutLogin(c, info)
+
+	var result params.LoginResult
+	request := &params.LoginRequest{
+		AuthTag:        s.AdminUserTag(c).String(),
+		Credentials:    "dummy-secret",
+		ImpersonateTag: "user-bob",
+	}
+	err := conn.APICall("Admin", 3, "", "Login", request, &result)
+	c.Assert(err, gc.ErrorMatches, `cannot impersonate user "bob": user "bob" not found`)
+	log.CheckCallNames(c)
+}
+
 var _ = gc.Suite(&macaroonLoginSuite{})
 
 type macaroonLoginSuite struct {
@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/deployer"
 	"github.com/juju/juju/apiserver/facades/agent/diskmanager"
 	"github.com/juju/juju/apiserver/facades/agent/fanconfigurer"
+	"github.com/juju/juju/apiserver/facades/agent/healthprobes"
 	"github.com/juju/juju/apiserver/facades/agent/hostkeyreporter"
 	"github.com/juju/juju/apiserver/facades/agent/instancemutater"
 	"github.com/juju/juju/apiserver/facades/agent/keyupdater"
@@ -36,13 +37,17 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/reboot"
 	"github.com/juju/juju/apiserver/facades/agent/resourceshookcontext"
 	"github.com/juju/juju/apiserver/facades/agent/retrystrategy"
+	"github.com/juju/juju/apiserver/facades/agent/securityupdatesreporter"
 	"github.com/juju/juju/apiserver/facades/agent/storageprovisioner"
 	"github.com/juju/juju/apiserver/facades/agent/unitassigner"
 	"github.com/juju/juju/apiserver/facades/agent/uniter"
 	"github.com/juju/juju/apiserver/facades/agent/upgrader"
 	"github.com/juju/juju/apiserver/facades/agent/upgradeseries"
 	"github.com/juju/juju/apiserver/facades/agent/upgradesteps"
+	"github.com/juju/juju/apiserver/facades/agent/workerfailurereporter"
 	"github.com/juju/juju/apiserver/facades/client/action"
+	"github.com/juju/juju/apiserver/facades/client/agentbinaries"
+	"github.com/juju/juju/apiserver/facades/client/agentintrospection"
 	"github.com/juju/juju/apiserver/facades/client/annotations" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/application" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/applicationoffers"
@@ -54,26 +59,41 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/cloud"      // ModelUser Read
 	"github.com/juju/juju/apiserver/facades/client/controller" // ModelUser Admin (although some methods check for read only)
 	"github.com/juju/juju/apiserver/facades/client/credentialmanager"
+	"github.com/juju/juju/apiserver/facades/client/dashboard"
 	"github.com/juju/juju/apiserver/facades/client/firewallrules"
 	"github.com/juju/juju/apiserver/facades/client/highavailability" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/imagemanager"     // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/imagemetadatamanager"
 	"github.com/juju/juju/apiserver/facades/client/keymanager"     // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/leases"         // Controller superuser or model admin
 	"github.com/juju/juju/apiserver/facades/client/machinemanager" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/metricsdebug"   // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/modelarchive"   // ModelUser Admin
 	"github.com/juju/juju/apiserver/facades/client/modelconfig"    // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/modelcost"      // ModelUser Read
 	"github.com/juju/juju/apiserver/facades/client/modelgeneration"
-	"github.com/juju/juju/apiserver/facades/client/modelmanager" // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/modelmanager"  // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/modelverifier" // ModelUser Admin
+	"github.com/juju/juju/apiserver/facades/client/operationslog" // ModelUser Read
 	"github.com/juju/juju/apiserver/facades/client/payloads"
 	"github.com/juju/juju/apiserver/facades/client/resources"
-	"github.com/juju/juju/apiserver/facades/client/spaces"    // ModelUser Write
-	"github.com/juju/juju/apiserver/facades/client/sshclient" // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/search"
+	"github.com/juju/juju/apiserver/facades/client/securityupdates"
+	"github.com/juju/juju/apiserver/facades/client/spaces"         // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/sshclient"      // ModelUser Write
+	"github.com/juju/juju/apiserver/facades/client/statuschanges"  // ModelUser Read
+	"github.com/juju/juju/apiserver/facades/client/statussnapshot" // ModelUser Read
 	"github.com/juju/juju/apiserver/facades/client/storage"
+	"github.com/juju/juju/apiserver/facades/client/stuckstatus" // ModelUser Read
 	"github.com/juju/juju/apiserver/facades/client/subnets"
 	"github.com/juju/juju/apiserver/facades/client/usermanager"
+	"github.com/juju/juju/apiserver/facades/client/workerfailures"
+	"github.com/juju/juju/apiserver/facades/client/zones" // ModelUser Read
 	"github.com/juju/juju/apiserver/facades/controller/actionpruner"
 	"github.com/juju/juju/apiserver/facades/controller/agenttools"
 	"github.com/juju/juju/apiserver/facades/controller/applicationscaler"
+	"github.com/juju/juju/apiserver/facades/controller/autoscalinggroups"
+	"github.com/juju/juju/apiserver/facades/controller/bundlereconciler"
 	"github.com/juju/juju/apiserver/facades/controller/caasfirewaller"
 	"github.com/juju/juju/apiserver/facades/controller/caasoperatorprovisioner"
 	"github.com/juju/juju/apiserver/facades/controller/caasoperatorupgrader"
@@ -81,11 +101,14 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/charmrevisionupdater"
 	"github.com/juju/juju/apiserver/facades/controller/cleaner"
 	"github.com/juju/juju/apiserver/facades/controller/crosscontroller"
+	"github.com/juju/juju/apiserver/facades/controller/crossmodelhealth"
 	"github.com/juju/juju/apiserver/facades/controller/crossmodelrelations"
+	"github.com/juju/juju/apiserver/facades/controller/deadagents"
 	"github.com/juju/juju/apiserver/facades/controller/externalcontrollerupdater"
 	"github.com/juju/juju/apiserver/facades/controller/firewaller"
 	"github.com/juju/juju/apiserver/facades/controller/imagemetadata"
 	"github.com/juju/juju/apiserver/facades/controller/instancepoller"
+	"github.com/juju/juju/apiserver/facades/controller/leadershippinexpiry"
 	"github.com/juju/juju/apiserver/facades/controller/lifeflag"
 	"github.com/juju/juju/apiserver/facades/controller/logfwd"
 	"github.com/juju/juju/apiserver/facades/controller/machineundertaker"
@@ -96,6 +119,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/remoterelations"
 	"github.com/juju/juju/apiserver/facades/controller/resumer"
 	"github.com/juju/juju/apiserver/facades/controller/singular"
+	"github.com/juju/juju/apiserver/facades/controller/statusalerts"
 	"github.com/juju/juju/apiserver/facades/controller/statushistory"
 	"github.com/juju/juju/apiserver/facades/controller/undertaker"
 	"github.com/juju/juju/feature"
@@ -136,6 +160,10 @@ func AllFacades() *facade.Registry {
 	reg("Action", 5, action.NewActionAPIV5)
 	reg("ActionPruner", 1, actionpruner.NewAPI)
 	reg("Agent", 2, agent.NewAgentAPIV2)
+	reg("Agent", 3, agent.NewAgentAPIV3) // Adds agent config overrides.
+	reg("Agent", 4, agent.NewAgentAPIV4) // Adds WatchControllerConfig.
+	reg("AgentBinaries", 1, agentbinaries.NewFacade)
+	reg("AgentIntrospection", 1, agentintrospection.NewFacade)
 	reg("AgentTools", 1, agenttools.NewFacade)
 	reg("Annotations", 2, annotations.NewAPI)
 
@@ -153,10 +181,18 @@ func AllFacades() *facade.Registry {
 	reg("Application", 9, application.NewFacadeV9)   // ApplicationInfo; generational config; Force on App, Relation and Unit Removal.
 	reg("Application", 10, application.NewFacadeV10) // --force and --no-wait parameters
 	reg("Application", 11, application.NewFacadeV11) // Get call returns the endpoint bindings
+	reg("Application", 12, application.NewFacadeV12) // adds CheckUnitsForceRemoval
+	reg("Application", 13, application.NewFacadeV13) // adds DrainTimeout to DestroyRelation
+	reg("Application", 14, application.NewFacadeV14) // adds SetUnitsMaintenance
+	reg("Application", 15, application.NewFacadeV15) // adds ScalingPolicies and SetScalingPolicies
+	reg("Application", 16, application.NewFacadeV16) // adds RelationDetails
+	reg("Application", 17, application.NewFacadeV17) // adds DisplayStatus
+	reg("Application", 18, application.NewFacadeV18) // adds CanaryUpgrade to SetCharm
 
 	reg("ApplicationOffers", 1, applicationoffers.NewOffersAPI)
 	reg("ApplicationOffers", 2, applicationoffers.NewOffersAPIV2)
 	reg("ApplicationScaler", 1, applicationscaler.NewAPI)
+	reg("AutoscalingGroups", 1, autoscalinggroups.NewFacade)
 	reg("Backups", 1, backups.NewFacade)
 	reg("Backups", 2, backups.NewFacadeV2)
 	reg("Block", 2, block.NewAPI)
@@ -164,17 +200,25 @@ func AllFacades() *facade.Registry {
 	reg("Bundle", 2, bundle.NewFacadeV2)
 	reg("Bundle", 3, bundle.NewFacadeV3)
 	reg("Bundle", 4, bundle.NewFacadeV4)
+	reg("Bundle", 5, bundle.NewFacadeV5) // adds PlanModel and ApplyModelPlan
+	reg("Bundle", 6, bundle.NewFacadeV6) // adds AddModelBundle and ApproveModelPlan
+	reg("BundleReconciler", 1, bundlereconciler.NewFacade)
 	reg("CharmRevisionUpdater", 2, charmrevisionupdater.NewCharmRevisionUpdaterAPI)
 	reg("Charms", 2, charms.NewFacade)
 	reg("Cleaner", 2, cleaner.NewCleanerAPI)
 	reg("Client", 1, client.NewFacadeV1)
-	reg("Client", 2, client.NewFacade)
+	reg("Client", 2, client.NewFacadeV2)
+	reg("Client", 3, client.NewFacadeV3) // adds Until to the StatusHistory filter, and application status history.
+	reg("Client", 4, client.NewFacadeV4) // adds the interleaved status history of an application's units.
+	reg("Client", 5, client.NewFacade)   // adds StatusHistoryPage.
 	reg("Cloud", 1, cloud.NewFacadeV1)
 	reg("Cloud", 2, cloud.NewFacadeV2) // adds AddCloud, AddCredentials, CredentialContents, RemoveClouds
 	reg("Cloud", 3, cloud.NewFacadeV3) // changes signature of UpdateCredentials, adds ModifyCloudAccess
 	reg("Cloud", 4, cloud.NewFacadeV4) // adds UpdateCloud
 	reg("Cloud", 5, cloud.NewFacadeV5) // Removes DefaultCloud, handles config in AddCloud
 	reg("Cloud", 6, cloud.NewFacadeV6) // Adds validity to CredentialContent, force for AddCloud
+	reg("Cloud", 7, cloud.NewFacadeV7) // Adds RotateCredentials
+	reg("Cloud", 8, cloud.NewFacadeV8) // Adds cloud pricing and ProviderCallStats
 
 	// CAAS related facades.
 	// Move these to the correct place above once the feature flag disappears.
@@ -191,6 +235,9 @@ func AllFacades() *facade.Registry {
 	reg("Controller", 6, controller.NewControllerAPIv6)
 	reg("Controller", 7, controller.NewControllerAPIv7)
 	reg("Controller", 8, controller.NewControllerAPIv8)
+	reg("Controller", 9, controller.NewControllerAPIv9)   // adds FeatureFlagHistory
+	reg("Controller", 10, controller.NewControllerAPIv10) // adds SchemaStatus
+	reg("CrossModelHealth", 1, crossmodelhealth.NewFacade)
 	reg("CrossModelRelations", 1, crossmodelrelations.NewStateCrossModelRelationsAPI)
 	reg("CrossController", 1, crosscontroller.NewStateCrossControllerAPI)
 	reg("CredentialManager", 1, credentialmanager.NewCredentialManagerAPI)
@@ -198,6 +245,8 @@ func AllFacades() *facade.Registry {
 	reg("CredentialValidator", 2, credentialvalidator.NewCredentialValidatorAPI) // adds WatchModelCredential
 	reg("ExternalControllerUpdater", 1, externalcontrollerupdater.NewStateAPI)
 
+	reg("Dashboard", 1, dashboard.NewFacade)
+	reg("DeadAgents", 1, deadagents.NewFacade)
 	reg("Deployer", 1, deployer.NewDeployerAPI)
 	reg("DiskManager", 2, diskmanager.NewDiskManagerAPI)
 	reg("FanConfigurer", 1, fanconfigurer.NewFanConfigurerAPI)
@@ -205,6 +254,7 @@ func AllFacades() *facade.Registry {
 	reg("Firewaller", 4, firewaller.NewStateFirewallerAPIV4)
 	reg("Firewaller", 5, firewaller.NewStateFirewallerAPIV5)
 	reg("FirewallRules", 1, firewallrules.NewFacade)
+	reg("HealthProbes", 1, healthprobes.NewFacade)
 	reg("HighAvailability", 2, highavailability.NewHighAvailabilityAPI)
 	reg("HostKeyReporter", 1, hostkeyreporter.NewFacade)
 	reg("ImageManager", 2, imagemanager.NewImageManagerAPI)
@@ -217,11 +267,17 @@ func AllFacades() *facade.Registry {
 	reg("InstanceMutater", 1, instancemutater.NewFacadeV1)
 	reg("InstanceMutater", 2, instancemutater.NewFacadeV2)
 
-	reg("InstancePoller", 3, instancepoller.NewFacade)
+	reg("InstancePoller", 3, instancepoller.NewFacadeV3)
+	reg("InstancePoller", 4, instancepoller.NewFacadeV4) // Adds SetHardwareCharacteristics.
+	reg("InstancePoller", 5, instancepoller.NewFacade)   // Adds WatchInstancePollRequests and ClearInstancePollRequests.
 	reg("KeyManager", 1, keymanager.NewKeyManagerAPI)
 	reg("KeyUpdater", 1, keyupdater.NewKeyUpdaterAPI)
 
+	reg("LeadershipPinExpiry", 1, leadershippinexpiry.NewFacade)
 	reg("LeadershipService", 2, leadership.NewLeadershipServiceFacade)
+	reg("Leases", 1, leases.NewFacadeV1)
+	reg("Leases", 2, leases.NewFacadeV2) // adds SnapshotLeaseStore
+	reg("Leases", 3, leases.NewFacadeV3) // adds PinLeaders and UnpinLeaders
 
 	reg("LifeFlag", 1, lifeflag.NewExternalFacade)
 	reg("Logger", 1, loggerapi.NewLoggerAPI)
@@ -229,13 +285,18 @@ func AllFacades() *facade.Registry {
 	reg("MachineActions", 1, machineactions.NewExternalFacade)
 
 	reg("MachineManager", 2, machinemanager.NewFacade)
-	reg("MachineManager", 3, machinemanager.NewFacade)   // Adds DestroyMachine and ForceDestroyMachine.
-	reg("MachineManager", 4, machinemanager.NewFacadeV4) // Adds DestroyMachineWithParams.
-	reg("MachineManager", 5, machinemanager.NewFacadeV5) // Adds UpgradeSeriesPrepare, removes UpdateMachineSeries.
-	reg("MachineManager", 6, machinemanager.NewFacadeV6) // DestroyMachinesWithParams gains maxWait.
+	reg("MachineManager", 3, machinemanager.NewFacade)     // Adds DestroyMachine and ForceDestroyMachine.
+	reg("MachineManager", 4, machinemanager.NewFacadeV4)   // Adds DestroyMachineWithParams.
+	reg("MachineManager", 5, machinemanager.NewFacadeV5)   // Adds UpgradeSeriesPrepare, removes UpdateMachineSeries.
+	reg("MachineManager", 6, machinemanager.NewFacadeV6)   // DestroyMachinesWithParams gains maxWait.
+	reg("MachineManager", 7, machinemanager.NewFacadeV7)   // Adds ProxySettings.
+	reg("MachineManager", 8, machinemanager.NewFacadeV8)   // Adds MachineConsoleLog.
+	reg("MachineManager", 9, machinemanager.NewFacadeV9)   // Adds SetAgentConfigOverrides and AgentConfigOverrides.
+	reg("MachineManager", 10, machinemanager.NewFacadeV10) // Adds SyncMachineAddresses.
 
 	reg("MachineUndertaker", 1, machineundertaker.NewFacade)
-	reg("Machiner", 1, machine.NewMachinerAPI)
+	reg("Machiner", 1, machine.NewMachinerAPIV1)
+	reg("Machiner", 2, machine.NewMachinerAPI) // Adds RecordProvisioningPhases.
 
 	reg("MeterStatus", 1, meterstatus.NewMeterStatusFacade)
 	reg("MetricsAdder", 2, metricsadder.NewMetricsAdderAPI)
@@ -248,8 +309,12 @@ func AllFacades() *facade.Registry {
 	reg("MigrationMinion", 1, migrationminion.NewFacade)
 	reg("MigrationTarget", 1, migrationtarget.NewFacade)
 
+	reg("ModelArchive", 1, modelarchive.NewFacade)
 	reg("ModelConfig", 1, modelconfig.NewFacadeV1)
 	reg("ModelConfig", 2, modelconfig.NewFacadeV2)
+	reg("ModelConfig", 3, modelconfig.NewFacadeV3) // adds ConfigKeys
+	reg("ModelConfig", 4, modelconfig.NewFacadeV4) // adds registry credentials
+	reg("ModelCost", 1, modelcost.NewFacade)
 	reg("ModelGeneration", 1, modelgeneration.NewModelGenerationFacade)
 	reg("ModelGeneration", 2, modelgeneration.NewModelGenerationFacadeV2)
 	reg("ModelGeneration", 3, modelgeneration.NewModelGenerationFacadeV3)
@@ -257,11 +322,17 @@ func AllFacades() *facade.Registry {
 	reg("ModelManager", 2, modelmanager.NewFacadeV2)
 	reg("ModelManager", 3, modelmanager.NewFacadeV3)
 	reg("ModelManager", 4, modelmanager.NewFacadeV4)
-	reg("ModelManager", 5, modelmanager.NewFacadeV5) // adds ChangeModelCredential
-	reg("ModelManager", 6, modelmanager.NewFacadeV6) // adds cloud specific default config
-	reg("ModelManager", 7, modelmanager.NewFacadeV7) // DestroyModels gains 'force' and max-wait' parameters.
-	reg("ModelManager", 8, modelmanager.NewFacadeV8) // ModelInfo gains credential validity in return.
+	reg("ModelManager", 5, modelmanager.NewFacadeV5)   // adds ChangeModelCredential
+	reg("ModelManager", 6, modelmanager.NewFacadeV6)   // adds cloud specific default config
+	reg("ModelManager", 7, modelmanager.NewFacadeV7)   // DestroyModels gains 'force' and max-wait' parameters.
+	reg("ModelManager", 8, modelmanager.NewFacadeV8)   // ModelInfo gains credential validity in return.
+	reg("ModelManager", 9, modelmanager.NewFacadeV9)   // adds WatchModelTeardown and AbandonedModelResources
+	reg("ModelManager", 10, modelmanager.NewFacadeV10) // adds model templates
+	reg("ModelManager", 11, modelmanager.NewFacadeV11) // adds FindAnnotations
 	reg("ModelUpgrader", 1, modelupgrader.NewStateFacade)
+	reg("ModelVerifier", 1, modelverifier.NewFacade)
+
+	reg("OperationsLog", 1, operationslog.NewFacade)
 
 	reg("Payloads", 1, payloads.NewFacade)
 	regHookContext(
@@ -284,13 +355,18 @@ func AllFacades() *facade.Registry {
 	reg("Reboot", 2, reboot.NewRebootAPI)
 	reg("RemoteRelations", 1, remoterelations.NewStateRemoteRelationsAPI)
 
-	reg("Resources", 1, resources.NewPublicFacade)
+	reg("Resources", 1, resources.NewPublicFacadeV1)
+	reg("Resources", 2, resources.NewPublicFacade) // Adds ResourceHistory and RollbackResources.
 	reg("ResourcesHookContext", 1, resourceshookcontext.NewStateFacade)
 
 	reg("Resumer", 2, resumer.NewResumerAPI)
 	reg("RetryStrategy", 1, retrystrategy.NewRetryStrategyAPI)
 	reg("Singular", 2, singular.NewExternalFacade)
 
+	reg("Search", 1, search.NewFacade)
+	reg("SecurityUpdates", 1, securityupdates.NewFacade)
+	reg("SecurityUpdatesReporter", 1, securityupdatesreporter.NewFacade)
+
 	reg("SSHClient", 1, sshclient.NewFacade)
 	reg("SSHClient", 2, sshclient.NewFacade) // v2 adds AllAddresses() method.
 
@@ -299,8 +375,13 @@ func AllFacades() *facade.Registry {
 	reg("Spaces", 4, spaces.NewAPIv4)
 	reg("Spaces", 5, spaces.NewAPI)
 
+	reg("StatusAlerts", 1, statusalerts.NewFacade)
+
 	reg("StatusHistory", 2, statushistory.NewAPI)
 
+	reg("StatusChanges", 1, statuschanges.NewFacade)
+	reg("StatusSnapshot", 1, statussnapshot.NewFacade)
+
 	reg("Storage", 3, storage.NewStorageAPIV3)
 	reg("Storage", 4, storage.NewStorageAPIV4) // changes Destroy() method signature.
 	reg("Storage", 5, storage.NewStorageAPIV5) // Update and Delete storage pools and CreatePool bulk calls.
@@ -308,9 +389,11 @@ func AllFacades() *facade.Registry {
 
 	reg("StorageProvisioner", 3, storageprovisioner.NewFacadeV3)
 	reg("StorageProvisioner", 4, storageprovisioner.NewFacadeV4)
+	reg("StuckStatus", 1, stuckstatus.NewFacade)
 	reg("Subnets", 2, subnets.NewAPIv2)
 	reg("Subnets", 3, subnets.NewAPI)
-	reg("Undertaker", 1, undertaker.NewUndertakerAPI)
+	reg("Undertaker", 1, undertaker.NewUndertakerAPIV1)
+	reg("Undertaker", 2, undertaker.NewUndertakerAPI) // adds AbandonCloudEnvironment
 	reg("UnitAssigner", 1, unitassigner.New)
 
 	reg("Uniter", 4, uniter.NewUniterAPIV4)
@@ -322,13 +405,21 @@ func AllFacades() *facade.Registry {
 	reg("Uniter", 10, uniter.NewUniterAPIV10)
 	reg("Uniter", 11, uniter.NewUniterAPIV11)
 	reg("Uniter", 12, uniter.NewUniterAPIV12)
-	reg("Uniter", 13, uniter.NewUniterAPI)
+	reg("Uniter", 13, uniter.NewUniterAPIV13)
+	reg("Uniter", 14, uniter.NewUniterAPIV14) // adds StartCharmDeployment, FinishCharmDeployment and WatchCharmDeployments
+	reg("Uniter", 15, uniter.NewUniterAPIV15) // adds BulkSetStatus
+	reg("Uniter", 16, uniter.NewUniterAPIV16) // adds UnitStatusHistory and PeerUnitStatuses
+	reg("Uniter", 17, uniter.NewUniterAPIV17) // adds RelationsNetworkInfo
+	reg("Uniter", 18, uniter.NewUniterAPI)    // adds WatchKubernetesEvents
 
 	reg("Upgrader", 1, upgrader.NewUpgraderFacade)
 	reg("UpgradeSeries", 1, upgradeseries.NewAPI)
 	reg("UpgradeSteps", 1, upgradesteps.NewFacadeV1)
 	reg("UserManager", 1, usermanager.NewUserManagerAPI)
 	reg("UserManager", 2, usermanager.NewUserManagerAPI) // Adds ResetPassword
+	reg("WorkerFailureReporter", 1, workerfailurereporter.NewFacade)
+	reg("WorkerFailures", 1, workerfailures.NewFacade)
+	reg("Zones", 1, zones.NewFacade)
 
 	regRaw("AllWatcher", 1, NewAllWatcher, reflect.TypeOf((*SrvAllWatcher)(nil)))
 	// Note: AllModelWatcher uses the same infrastructure as AllWatcher
@@ -340,6 +431,7 @@ func AllFacades() *facade.Registry {
 	regRaw("StringsWatcher", 1, newStringsWatcher, reflect.TypeOf((*srvStringsWatcher)(nil)))
 	regRaw("OfferStatusWatcher", 1, newOfferStatusWatcher, reflect.TypeOf((*srvOfferStatusWatcher)(nil)))
 	regRaw("RelationStatusWatcher", 1, newRelationStatusWatcher, reflect.TypeOf((*srvRelationStatusWatcher)(nil)))
+	regRaw("StatusChangesWatcher", 1, newStatusChangesWatcher, reflect.TypeOf((*srvStatusChangesWatcher)(nil)))
 	regRaw("RelationUnitsWatcher", 1, newRelationUnitsWatcher, reflect.TypeOf((*srvRelationUnitsWatcher)(nil)))
 	regRaw("VolumeAttachmentsWatcher", 2, newVolumeAttachmentsWatcher, reflect.TypeOf((*srvMachineStorageIdsWatcher)(nil)))
 	regRaw("VolumeAttachmentPlansWatcher", 1, newVolumeAttachmentPlansWatcher, reflect.TypeOf((*srvMachineStorageIdsWatcher)(nil)))
@@ -488,6 +488,7 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		httpCtxt, srv.authenticator,
 		tagKindAuthorizer{names.MachineTagKind, names.ControllerAgentTagKind, names.UserTagKind, names.ApplicationTagKind})
 	pubsubHandler := newPubSubHandler(httpCtxt, srv.shared.centralHub)
+	modelEventsHandler := newModelEventsHandler(httpCtxt, srv.authenticator)
 	logSinkHandler := logsink.NewHTTPHandler(
 		newAgentLogWriteCloserFunc(httpCtxt, srv.logSinkWriter, &srv.dbloggers),
 		httpCtxt.stop(),
@@ -628,6 +629,13 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 		// The authentication is handled within the debugLogHandler in order
 		// for discharge required errors to be handled correctly.
 		unauthenticated: true,
+	}, {
+		pattern: modelRoutePrefix + "/events",
+		handler: modelEventsHandler,
+		tracked: true,
+		// As with debug-log, the authentication is handled within the
+		// handler.
+		unauthenticated: true,
 	}, {
 		pattern:    modelRoutePrefix + "/logsink",
 		handler:    logSinkHandler,
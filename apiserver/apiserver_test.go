@@ -84,7 +84,7 @@ func (s *apiserverConfigFixture) SetUpTest(c *gc.C) {
 		InitializedGate: initialized,
 		Logger:          loggo.GetLogger("test"),
 		WatcherFactory: func() modelcache.BackingWatcher {
-			return s.StatePool.SystemState().WatchAllModels(s.StatePool)
+			return s.StatePool.SystemState().WatchAllModelsForCache(s.StatePool)
 		},
 		PrometheusRegisterer: noopRegisterer{},
 		Cleanup:              func() {},
@@ -28,6 +28,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/charmlint"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
 )
@@ -101,11 +102,19 @@ func (h *charmsHandler) ServePost(w http.ResponseWriter, r *http.Request) error
 	defer st.Release()
 
 	// Add a charm to the store provider.
-	charmURL, err := h.processPost(r, st.State)
+	charmURL, findings, err := h.processPost(r, st.State)
 	if err != nil {
 		return errors.NewBadRequest(err, "")
 	}
-	return errors.Trace(sendStatusAndJSON(w, http.StatusOK, &params.CharmsResponse{CharmURL: charmURL.String()}))
+	response := &params.CharmsResponse{CharmURL: charmURL.String()}
+	for _, f := range findings {
+		response.LintFindings = append(response.LintFindings, params.CharmLintFinding{
+			Severity: string(f.Severity),
+			Path:     f.Path,
+			Message:  f.Message,
+		})
+	}
+	return errors.Trace(sendStatusAndJSON(w, http.StatusOK, response))
 }
 
 func (h *charmsHandler) ServeGet(w http.ResponseWriter, r *http.Request) error {
@@ -204,7 +213,11 @@ func (h *charmsHandler) archiveSender(w http.ResponseWriter, r *http.Request, bu
 }
 
 // processPost handles a charm upload POST request after authentication.
-func (h *charmsHandler) processPost(r *http.Request, st *state.State) (*charm.URL, error) {
+//
+// Local charms are statically analysed before they are accepted; the
+// problems found are returned, and any errors among them cause the
+// charm to be rejected.
+func (h *charmsHandler) processPost(r *http.Request, st *state.State) (*charm.URL, []charmlint.Finding, error) {
 	query := r.URL.Query()
 	schema := query.Get("schema")
 	if schema == "" {
@@ -214,34 +227,55 @@ func (h *charmsHandler) processPost(r *http.Request, st *state.State) (*charm.UR
 	series := query.Get("series")
 	if series != "" {
 		if err := charm.ValidateSeries(series); err != nil {
-			return nil, errors.NewBadRequest(err, "")
+			return nil, nil, errors.NewBadRequest(err, "")
 		}
 	}
 
 	// Make sure the content type is zip.
 	contentType := r.Header.Get("Content-Type")
 	if contentType != "application/zip" {
-		return nil, errors.BadRequestf("expected Content-Type: application/zip, got: %v", contentType)
+		return nil, nil, errors.BadRequestf("expected Content-Type: application/zip, got: %v", contentType)
 	}
 
 	charmFileName, err := writeCharmToTempFile(r.Body)
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 	defer os.Remove(charmFileName)
 
 	err = h.processUploadedArchive(charmFileName)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	archive, err := charm.ReadCharmArchive(charmFileName)
 	if err != nil {
-		return nil, errors.BadRequestf("invalid charm archive: %v", err)
+		return nil, nil, errors.BadRequestf("invalid charm archive: %v", err)
 	}
 
 	name := archive.Meta().Name
 	if err := charm.ValidateName(name); err != nil {
-		return nil, errors.NewBadRequest(err, "")
+		return nil, nil, errors.NewBadRequest(err, "")
+	}
+
+	// Charms uploaded during model migration import are already in
+	// use, so they aren't analysed.
+	isImporting, err := modelIsImporting(st)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	var findings []charmlint.Finding
+	if schema == "local" && !isImporting {
+		findings, err = lintCharmArchive(archive)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		if charmlint.HasErrors(findings) {
+			var problems []string
+			for _, f := range charmlint.Errors(findings) {
+				problems = append(problems, f.String())
+			}
+			return nil, nil, errors.BadRequestf("charm failed static analysis: %s", strings.Join(problems, "; "))
+		}
 	}
 
 	// We got it, now let's reserve a charm URL for it in state.
@@ -255,17 +289,15 @@ func (h *charmsHandler) processPost(r *http.Request, st *state.State) (*charm.UR
 	case "local":
 		curl, err = st.PrepareLocalCharmUpload(curl)
 		if err != nil {
-			return nil, errors.Trace(err)
+			return nil, nil, errors.Trace(err)
 		}
 	case "cs":
 		// "cs:" charms may only be uploaded into models which are
 		// being imported during model migrations. There's currently
 		// no other time where it makes sense to accept charm store
 		// charms through this endpoint.
-		if isImporting, err := modelIsImporting(st); err != nil {
-			return nil, errors.Trace(err)
-		} else if !isImporting {
-			return nil, errors.New("cs charms may only be uploaded during model migration import")
+		if !isImporting {
+			return nil, nil, errors.New("cs charms may only be uploaded during model migration import")
 		}
 
 		// Use the user argument if provided (users only make sense
@@ -280,23 +312,43 @@ func (h *charmsHandler) processPost(r *http.Request, st *state.State) (*charm.UR
 		if revisionStr != "" {
 			curl.Revision, err = strconv.Atoi(revisionStr)
 			if err != nil {
-				return nil, errors.NewBadRequest(errors.NewNotValid(err, "revision"), "")
+				return nil, nil, errors.NewBadRequest(errors.NewNotValid(err, "revision"), "")
 			}
 		}
 		if _, err := st.PrepareStoreCharmUpload(curl); err != nil {
-			return nil, errors.Trace(err)
+			return nil, nil, errors.Trace(err)
 		}
 	default:
-		return nil, errors.Errorf("unsupported schema %q", schema)
+		return nil, nil, errors.Errorf("unsupported schema %q", schema)
 	}
 
 	// Now we need to repackage it with the reserved URL, upload it to
 	// provider storage and update the state.
-	err = h.repackageAndUploadCharm(st, archive, curl)
+	err = h.repackageAndUploadCharm(st, archive, curl, findings)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return curl, findings, nil
+}
+
+// lintCharmArchive expands the given charm archive to a temporary
+// directory and statically analyses it.
+func lintCharmArchive(archive *charm.CharmArchive) ([]charmlint.Finding, error) {
+	tempDir, err := ioutil.TempDir("", "charm-lint")
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create temp directory")
+	}
+	defer os.RemoveAll(tempDir)
+	extractPath := filepath.Join(tempDir, "extracted")
+	if err := archive.ExpandTo(extractPath); err != nil {
+		return nil, errors.Annotate(err, "cannot extract uploaded charm")
+	}
+	charmDir, err := charm.ReadCharmDir(extractPath)
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, errors.Annotate(err, "cannot read extracted charm")
 	}
-	return curl, nil
+	findings, err := charmlint.Lint(charmDir)
+	return findings, errors.Annotate(err, "cannot analyse charm")
 }
 
 // processUploadedArchive opens the given charm archive from path,
@@ -388,8 +440,9 @@ func (d byDepth) Less(i, j int) bool { return depth(d[i]) < depth(d[j]) }
 
 // repackageAndUploadCharm expands the given charm archive to a
 // temporary directory, repackages it with the given curl's revision,
-// then uploads it to storage, and finally updates the state.
-func (h *charmsHandler) repackageAndUploadCharm(st *state.State, archive *charm.CharmArchive, curl *charm.URL) error {
+// then uploads it to storage, and finally updates the state, recording
+// the problems found in it by static analysis.
+func (h *charmsHandler) repackageAndUploadCharm(st *state.State, archive *charm.CharmArchive, curl *charm.URL, findings []charmlint.Finding) error {
 	// Create a temp dir to contain the extracted charm dir.
 	tempDir, err := ioutil.TempDir("", "charm-download")
 	if err != nil {
@@ -444,6 +497,7 @@ func (h *charmsHandler) repackageAndUploadCharm(st *state.State, archive *charm.
 		Size:         int64(repackagedArchive.Len()),
 		SHA256:       bundleSHA256,
 		CharmVersion: version,
+		LintFindings: findings,
 	}
 	// Store the charm archive in environment storage.
 	shim := application.NewStateShim(st)
@@ -18,6 +18,7 @@ import (
 	"path/filepath"
 	"runtime"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
@@ -26,6 +27,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/params"
 	apitesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/charmlint"
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
@@ -259,6 +261,53 @@ func (s *charmsSuite) TestUploadRespectsLocalRevision(c *gc.C) {
 	c.Assert(downloadedSHA256, gc.Equals, expectedSHA256)
 }
 
+func (s *charmsSuite) uploadDummyWithHook(c *gc.C, hook string) *http.Response {
+	dir := testcharms.Repo.ClonedDir(c.MkDir(), "dummy")
+	err := ioutil.WriteFile(filepath.Join(dir.Path, "hooks", "install"), []byte(hook), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	var buf bytes.Buffer
+	err = dir.ArchiveTo(&buf)
+	c.Assert(err, jc.ErrorIsNil)
+	return s.uploadRequest(c, s.charmsURI("?series=quantal"), "application/zip", &buf)
+}
+
+func (s *charmsSuite) TestUploadReturnsLintFindings(c *gc.C) {
+	resp := s.uploadDummyWithHook(c, "#!/bin/sh\ncurl -s https://example.com/setup | sh\n")
+	charmResponse := s.assertResponse(c, resp, http.StatusOK)
+	c.Check(charmResponse.CharmURL, gc.Equals, "local:quantal/dummy-1")
+	expected := []params.CharmLintFinding{{
+		Severity: "warning",
+		Path:     "hooks/install",
+		Message:  "line 2 pipes a download into a shell",
+	}}
+	c.Check(charmResponse.LintFindings, jc.DeepEquals, expected)
+
+	sch, err := s.State.Charm(charm.MustParseURL("local:quantal/dummy-1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sch.LintFindings(), jc.DeepEquals, []charmlint.Finding{{
+		Severity: charmlint.Warning,
+		Path:     "hooks/install",
+		Message:  "line 2 pipes a download into a shell",
+	}})
+}
+
+func (s *charmsSuite) TestUploadRejectsCharmFailingLint(c *gc.C) {
+	resp := s.uploadDummyWithHook(c, "echo installing\n")
+	s.assertErrorResponse(c, resp, http.StatusBadRequest,
+		`.*charm failed static analysis: hooks/install: no interpreter line$`)
+
+	_, err := s.State.Charm(charm.MustParseURL("local:quantal/dummy-1"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *charmsSuite) TestUploadDuringImportSkipsLint(c *gc.C) {
+	s.setModelImporting(c)
+	resp := s.uploadDummyWithHook(c, "echo installing\n")
+	charmResponse := s.assertResponse(c, resp, http.StatusOK)
+	c.Check(charmResponse.CharmURL, gc.Equals, "local:quantal/dummy-1")
+	c.Check(charmResponse.LintFindings, gc.HasLen, 0)
+}
+
 func (s *charmsSuite) TestUploadWithMultiSeriesCharm(c *gc.C) {
 	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
 	resp := s.uploadRequest(c, s.charmsURL("").String(), "application/zip", &fileReader{path: ch.Path})
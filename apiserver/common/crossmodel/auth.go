@@ -127,7 +127,7 @@ func (a *AuthContext) CheckLocalAccessRequest(details *offerPermissionCheck) ([]
 		return nil, errors.Trace(err)
 	}
 	defer releaser()
-	if err := a.checkOfferAccess(st, details.User, details.OfferUUID); err != nil {
+	if err := CheckOfferAccess(st, details.User, details.OfferUUID); err != nil {
 		return nil, errors.Trace(err)
 	}
 
@@ -143,16 +143,19 @@ func (a *AuthContext) CheckLocalAccessRequest(details *offerPermissionCheck) ([]
 	return firstPartyCaveats, nil
 }
 
-func (a *AuthContext) checkOfferAccess(st Backend, username, offerUUID string) error {
+// CheckOfferAccess returns an error satisfying common.ErrPerm unless
+// the user with the given name may consume the offer with the given
+// UUID, and so have discharged the macaroons used to access it.
+func CheckOfferAccess(st Backend, username, offerUUID string) error {
 	userTag := names.NewUserTag(username)
-	isAdmin, err := a.hasControllerAdminAccess(st, userTag)
+	isAdmin, err := hasControllerAdminAccess(st, userTag)
 	if err != nil {
 		return common.ErrPerm
 	}
 	if isAdmin {
 		return nil
 	}
-	isAdmin, err = a.hasModelAdminAccess(st, userTag)
+	isAdmin, err = hasModelAdminAccess(st, userTag)
 	if err != nil {
 		return common.ErrPerm
 	}
@@ -169,7 +172,7 @@ func (a *AuthContext) checkOfferAccess(st Backend, username, offerUUID string) e
 	return nil
 }
 
-func (api *AuthContext) hasControllerAdminAccess(st Backend, userTag names.UserTag) (bool, error) {
+func hasControllerAdminAccess(st Backend, userTag names.UserTag) (bool, error) {
 	isAdmin, err := common.HasPermission(st.UserPermission, userTag, permission.SuperuserAccess, st.ControllerTag())
 	if errors.IsNotFound(err) {
 		return false, nil
@@ -177,7 +180,7 @@ func (api *AuthContext) hasControllerAdminAccess(st Backend, userTag names.UserT
 	return isAdmin, err
 }
 
-func (api *AuthContext) hasModelAdminAccess(st Backend, userTag names.UserTag) (bool, error) {
+func hasModelAdminAccess(st Backend, userTag names.UserTag) (bool, error) {
 	isAdmin, err := common.HasPermission(st.UserPermission, userTag, permission.AdminAccess, st.ModelTag())
 	if errors.IsNotFound(err) {
 		return false, nil
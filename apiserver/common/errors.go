@@ -280,6 +280,29 @@ func ServerError(err error) *params.Error {
 		code = params.CodeForbidden
 	case state.IsIncompatibleSeriesError(err):
 		code = params.CodeIncompatibleSeries
+	case state.IsColocationViolationError(err):
+		violation := errors.Cause(err).(*state.ErrColocationViolation)
+		code = params.CodeColocationViolation
+		info = params.ColocationViolationErrorInfo{
+			Application: violation.Application,
+			Unit:        violation.Unit,
+			Machine:     violation.Machine,
+			Rule:        violation.Rule,
+			Group:       violation.Group,
+			Limit:       violation.Limit,
+			Units:       violation.Units,
+		}.AsMap()
+	case state.IsQuotaExceededError(err):
+		exceeded := errors.Cause(err).(*state.ErrQuotaExceeded)
+		code = params.CodeQuotaLimitExceeded
+		info = params.QuotaLimitExceededErrorInfo{
+			Model:  exceeded.Model,
+			Quota:  exceeded.Quota,
+			Entity: exceeded.Entity,
+			Limit:  exceeded.Limit,
+			Count:  exceeded.Count,
+			Adding: exceeded.Adding,
+		}.AsMap()
 	case IsDischargeRequiredError(err):
 		dischErr := errors.Cause(err).(*DischargeRequiredError)
 		code = params.CodeDischargeRequired
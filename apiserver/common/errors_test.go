@@ -245,6 +245,58 @@ func (err unhashableError) Error() string {
 	return err[0]
 }
 
+func (s *errorsSuite) TestColocationViolationError(c *gc.C) {
+	violation := &state.ErrColocationViolation{
+		Application: "wordpress",
+		Unit:        "wordpress/1",
+		Machine:     "3",
+		Rule:        "anti-colocation",
+		Group:       []string{"mysql", "wordpress"},
+		Units:       []string{"mysql/0"},
+	}
+	err := common.ServerError(errors.Annotate(violation, "cannot assign unit"))
+	c.Assert(err.Code, gc.Equals, params.CodeColocationViolation)
+	c.Assert(err, jc.Satisfies, params.IsCodeColocationViolation)
+	c.Assert(err.Message, gc.Equals, `cannot assign unit: unit "wordpress/1" cannot be placed on machine 3: anti-colocation group "mysql,wordpress" conflicts with mysql/0`)
+
+	var info params.ColocationViolationErrorInfo
+	c.Assert(err.UnmarshalInfo(&info), jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, params.ColocationViolationErrorInfo{
+		Application: "wordpress",
+		Unit:        "wordpress/1",
+		Machine:     "3",
+		Rule:        "anti-colocation",
+		Group:       []string{"mysql", "wordpress"},
+		Units:       []string{"mysql/0"},
+	})
+}
+
+func (s *errorsSuite) TestQuotaExceededError(c *gc.C) {
+	exceeded := &state.ErrQuotaExceeded{
+		Model:  testing.ModelTag.Id(),
+		Quota:  "max-model-units",
+		Entity: "units",
+		Limit:  10,
+		Count:  9,
+		Adding: 2,
+	}
+	err := common.ServerError(errors.Annotate(exceeded, "cannot add units"))
+	c.Assert(err.Code, gc.Equals, params.CodeQuotaLimitExceeded)
+	c.Assert(err, jc.Satisfies, params.IsCodeQuotaLimitExceeded)
+	c.Assert(err.Message, gc.Equals, `cannot add units: cannot add 2 units: model has 9 of 10 allowed by max-model-units`)
+
+	var info params.QuotaLimitExceededErrorInfo
+	c.Assert(err.UnmarshalInfo(&info), jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, params.QuotaLimitExceededErrorInfo{
+		Model:  testing.ModelTag.Id(),
+		Quota:  "max-model-units",
+		Entity: "units",
+		Limit:  10,
+		Count:  9,
+		Adding: 2,
+	})
+}
+
 func (s *errorsSuite) TestErrorTransform(c *gc.C) {
 	for i, t := range errorTransformTests {
 		c.Logf("running test %d: %T{%q}", i, t.err, t.err)
@@ -69,6 +69,13 @@ type ModelManagerBackend interface {
 	ReloadSpaces(environ environs.BootstrapEnviron) error
 	LatestMigration() (state.ModelMigration, error)
 	DumpAll() (map[string]interface{}, error)
+	WatchModelTeardown() state.NotifyWatcher
+	AbandonedResources(modelUUID string) ([]state.AbandonedResource, error)
+	AddModelTemplate(state.ModelTemplate) error
+	ModelTemplate(name string) (state.ModelTemplate, error)
+	ModelTemplates() ([]state.ModelTemplate, error)
+	RemoveModelTemplate(name string) error
+	FindAnnotations(match map[string]string) ([]state.AnnotatedEntity, error)
 	Close() error
 
 	// Methods required by the metricsender package.
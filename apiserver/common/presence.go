@@ -33,7 +33,8 @@ func (c *ModelPresenceContext) machinePresence(machine MachineStatusGetter) (boo
 	return status == presence.Alive, err
 }
 
-func (c *ModelPresenceContext) unitPresence(unit UnitStatusGetter) (bool, error) {
+// UnitPresence returns whether the agent of the given unit is alive.
+func (c *ModelPresenceContext) UnitPresence(unit UnitStatusGetter) (bool, error) {
 	if c.Presence == nil {
 		return unit.AgentPresence()
 	}
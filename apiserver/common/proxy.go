@@ -0,0 +1,95 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"github.com/juju/proxy"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/agentconfig"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/environs/config"
+)
+
+// ProxyConfig returns the proxy settings that the agents of a model
+// with the given config apply, given the API addresses they connect
+// to. The API addresses are added to the no-proxy list of whichever
+// of the juju or legacy proxy settings is in effect, so that agents
+// never talk to the controller through a proxy.
+func ProxyConfig(cfg *config.Config, apiHostPorts []network.SpaceHostPorts) params.ProxyConfigResult {
+	return AgentProxyConfig(cfg, apiHostPorts, nil)
+}
+
+// AgentProxyConfig returns the proxy settings in effect for an agent
+// whose config overrides the given values, keyed as in core/agentconfig.
+// The proxy overrides are applied to whichever of the juju or legacy
+// proxy settings ProxyConfig would return as being in effect.
+func AgentProxyConfig(cfg *config.Config, apiHostPorts []network.SpaceHostPorts, overrides map[string]string) params.ProxyConfigResult {
+	var result params.ProxyConfigResult
+
+	jujuProxySettings := cfg.JujuProxySettings()
+	legacyProxySettings := cfg.LegacyProxySettings()
+
+	if jujuProxySettings.HasProxySet() {
+		jujuProxySettings.AutoNoProxy = network.APIHostPortsToNoProxyString(apiHostPorts)
+		jujuProxySettings = overrideProxySettings(jujuProxySettings, overrides)
+	} else {
+		legacyProxySettings.AutoNoProxy = network.APIHostPortsToNoProxyString(apiHostPorts)
+		legacyProxySettings = overrideProxySettings(legacyProxySettings, overrides)
+	}
+	result.JujuProxySettings = proxyToParams(jujuProxySettings)
+	result.LegacyProxySettings = proxyToParams(legacyProxySettings)
+
+	result.APTProxySettings = proxyToParams(cfg.AptProxySettings())
+	result.AptSources = AptSourcesToParams(cfg.AptSources())
+
+	result.SnapProxySettings = proxyToParams(cfg.SnapProxySettings())
+	result.SnapStoreProxyId = cfg.SnapStoreProxy()
+	result.SnapStoreProxyAssertions = cfg.SnapStoreAssertions()
+	result.SnapStoreProxyURL = cfg.SnapStoreProxyURL()
+
+	result.ContainerRuntimeProxy = cfg.ContainerRuntimeProxy()
+
+	return result
+}
+
+// overrideProxySettings applies the proxy overrides among the given
+// agent config overrides to the settings, as the agent does.
+func overrideProxySettings(settings proxy.Settings, overrides map[string]string) proxy.Settings {
+	if value := overrides[agentconfig.HTTPProxy]; value != "" {
+		settings.Http = value
+	}
+	if value := overrides[agentconfig.HTTPSProxy]; value != "" {
+		settings.Https = value
+	}
+	if value := overrides[agentconfig.NoProxy]; value != "" {
+		settings.NoProxy = value
+	}
+	return settings
+}
+
+// AptSourcesToParams converts the additional apt repositories of a
+// model's config to their API representation.
+func AptSourcesToParams(sources []config.AptSource) []params.AptSource {
+	if len(sources) == 0 {
+		return nil
+	}
+	result := make([]params.AptSource, len(sources))
+	for i, src := range sources {
+		result[i] = params.AptSource{
+			Source: src.Source,
+			Key:    src.Key,
+		}
+	}
+	return result
+}
+
+func proxyToParams(settings proxy.Settings) params.ProxyConfig {
+	return params.ProxyConfig{
+		HTTP:    settings.Http,
+		HTTPS:   settings.Https,
+		FTP:     settings.Ftp,
+		NoProxy: settings.FullNoProxy(),
+	}
+}
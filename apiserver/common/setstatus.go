@@ -104,12 +104,11 @@ func (s *ApplicationStatusSetter) SetStatus(args params.SetStatus) (params.Error
 			continue
 		}
 		// TODO(perrito666) 2016-05-02 lp:1558657
-		now := time.Now()
 		sInfo := status.StatusInfo{
 			Status:  status.Status(arg.Status),
 			Message: arg.Info,
 			Data:    arg.Data,
-			Since:   &now,
+			Since:   statusSince(arg.Since, time.Now()),
 		}
 		if err := service.SetStatus(sInfo); err != nil {
 			result.Results[i].Error = ServerError(err)
@@ -119,6 +118,132 @@ func (s *ApplicationStatusSetter) SetStatus(args params.SetStatus) (params.Error
 	return result, nil
 }
 
+// BulkSetStatus sets, for each of the given units which leads its
+// application, the statuses of the application and of its units. The
+// statuses are set together, and only while the unit remains the
+// leader.
+func (s *ApplicationStatusSetter) BulkSetStatus(args params.BulkSetStatusArgs) (params.BulkSetStatusResults, error) {
+	result := params.BulkSetStatusResults{
+		Results: make([]params.BulkSetStatusResult, len(args.Args)),
+	}
+	if len(args.Args) == 0 {
+		return result, nil
+	}
+	canModify, err := s.getCanModify()
+	if err != nil {
+		return params.BulkSetStatusResults{}, err
+	}
+	for i, arg := range args.Args {
+		result.Results[i] = s.bulkSetStatus(canModify, arg)
+	}
+	return result, nil
+}
+
+func (s *ApplicationStatusSetter) bulkSetStatus(canModify AuthFunc, arg params.BulkSetStatusArg) params.BulkSetStatusResult {
+	var result params.BulkSetStatusResult
+	unitTag, err := names.ParseUnitTag(arg.Unit)
+	if err != nil {
+		result.Error = ServerError(err)
+		return result
+	}
+	if !canModify(unitTag) {
+		result.Error = ServerError(ErrPerm)
+		return result
+	}
+	appName, err := names.UnitApplication(unitTag.Id())
+	if err != nil {
+		result.Error = ServerError(err)
+		return result
+	}
+	// The token is checked here so that a unit which isn't the leader
+	// gets a single error, and again when the statuses are written.
+	token := s.leadershipChecker.LeadershipCheck(appName, unitTag.Id())
+	if err := token.Check(0, nil); err != nil {
+		result.Error = ServerError(err)
+		return result
+	}
+
+	result.Results = make([]params.ErrorResult, len(arg.Entities))
+	// TODO(perrito666) 2016-05-02 lp:1558657
+	now := time.Now()
+	var (
+		updates []state.StatusUpdate
+		indices []int
+	)
+	for i, entity := range arg.Entities {
+		setter, err := s.leaderStatusSetter(appName, entity.Tag)
+		if err != nil {
+			result.Results[i].Error = ServerError(err)
+			continue
+		}
+		updates = append(updates, state.StatusUpdate{
+			Entity: setter,
+			Status: status.StatusInfo{
+				Status:  status.Status(entity.Status),
+				Message: entity.Info,
+				Data:    entity.Data,
+				Since:   statusSince(entity.Since, now),
+			},
+			Token: token,
+		})
+		indices = append(indices, i)
+	}
+	if len(updates) == 0 {
+		return result
+	}
+	for j, err := range s.st.SetStatuses(updates) {
+		result.Results[indices[j]].Error = ServerError(err)
+	}
+	return result
+}
+
+// leaderStatusSetter returns the application or unit with the given
+// tag, if it belongs to the named application.
+func (s *ApplicationStatusSetter) leaderStatusSetter(appName, tagString string) (status.StatusSetter, error) {
+	tag, err := names.ParseTag(tagString)
+	if err != nil {
+		return nil, err
+	}
+	switch tag := tag.(type) {
+	case names.ApplicationTag:
+		if tag.Id() != appName {
+			return nil, ErrPerm
+		}
+		app, err := s.st.Application(appName)
+		if err != nil {
+			return nil, err
+		}
+		return app, nil
+	case names.UnitTag:
+		unitApp, err := names.UnitApplication(tag.Id())
+		if err != nil {
+			return nil, err
+		}
+		if unitApp != appName {
+			return nil, ErrPerm
+		}
+		unit, err := s.st.Unit(tag.Id())
+		if err != nil {
+			return nil, err
+		}
+		return unit, nil
+	}
+	return nil, ErrPerm
+}
+
+// statusSince returns when a status reported at the given time by a
+// caller was set: the time the caller supplied, or now if it didn't
+// supply one. Times in the future, such as from an agent whose clock
+// is ahead of the controller's, are taken as now so that they don't
+// misorder the status history.
+func statusSince(since *time.Time, now time.Time) *time.Time {
+	if since == nil || since.After(now) {
+		return &now
+	}
+	t := *since
+	return &t
+}
+
 // StatusSetter implements a common SetStatus method for use by
 // various facades.
 type StatusSetter struct {
@@ -136,28 +261,49 @@ func NewStatusSetter(st state.EntityFinder, getCanModify GetAuthFunc) *StatusSet
 	}
 }
 
-func (s *StatusSetter) setEntityStatus(tag names.Tag, entityStatus status.Status, info string, data map[string]interface{}, updated *time.Time) error {
+// StatusBatcher is implemented by entity finders which can set the
+// statuses of many entities at once.
+type StatusBatcher interface {
+	SetStatuses([]state.StatusUpdate) []error
+}
+
+// setStatuses sets the given statuses, together if the entity finder
+// supports it.
+func (s *StatusSetter) setStatuses(updates []state.StatusUpdate) []error {
+	if batcher, ok := s.st.(StatusBatcher); ok {
+		return batcher.SetStatuses(updates)
+	}
+	return setStatusesSeparately(updates)
+}
+
+// setStatusesSeparately sets each of the given statuses in turn.
+func setStatusesSeparately(updates []state.StatusUpdate) []error {
+	results := make([]error, len(updates))
+	for i, update := range updates {
+		results[i] = update.Entity.SetStatus(update.Status)
+	}
+	return results
+}
+
+// entityStatusSetter returns the entity with the given tag, if its
+// status can be set.
+func (s *StatusSetter) entityStatusSetter(tag names.Tag) (status.StatusSetter, error) {
 	entity, err := s.st.FindEntity(tag)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	switch entity := entity.(type) {
 	case *state.Application:
-		return ErrPerm
+		return nil, ErrPerm
 	case status.StatusSetter:
-		sInfo := status.StatusInfo{
-			Status:  entityStatus,
-			Message: info,
-			Data:    data,
-			Since:   updated,
-		}
-		return entity.SetStatus(sInfo)
+		return entity, nil
 	default:
-		return NotSupportedError(tag, fmt.Sprintf("setting status, %T", entity))
+		return nil, NotSupportedError(tag, fmt.Sprintf("setting status, %T", entity))
 	}
 }
 
-// SetStatus sets the status of each given entity.
+// SetStatus sets the status of each given entity. When the entity
+// finder is a StatusBatcher, the statuses are set together.
 func (s *StatusSetter) SetStatus(args params.SetStatus) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
@@ -171,17 +317,41 @@ func (s *StatusSetter) SetStatus(args params.SetStatus) (params.ErrorResults, er
 	}
 	// TODO(perrito666) 2016-05-02 lp:1558657
 	now := time.Now()
+	var (
+		updates []state.StatusUpdate
+		indices []int
+	)
 	for i, arg := range args.Entities {
 		tag, err := names.ParseTag(arg.Tag)
 		if err != nil {
 			result.Results[i].Error = ServerError(err)
 			continue
 		}
-		err = ErrPerm
-		if canModify(tag) {
-			err = s.setEntityStatus(tag, status.Status(arg.Status), arg.Info, arg.Data, &now)
+		if !canModify(tag) {
+			result.Results[i].Error = ServerError(ErrPerm)
+			continue
 		}
-		result.Results[i].Error = ServerError(err)
+		entity, err := s.entityStatusSetter(tag)
+		if err != nil {
+			result.Results[i].Error = ServerError(err)
+			continue
+		}
+		updates = append(updates, state.StatusUpdate{
+			Entity: entity,
+			Status: status.StatusInfo{
+				Status:  status.Status(arg.Status),
+				Message: arg.Info,
+				Data:    arg.Data,
+				Since:   statusSince(arg.Since, now),
+			},
+		})
+		indices = append(indices, i)
+	}
+	if len(updates) == 0 {
+		return result, nil
+	}
+	for j, err := range s.setStatuses(updates) {
+		result.Results[indices[j]].Error = ServerError(err)
 	}
 	return result, nil
 }
@@ -275,6 +445,15 @@ func (ua *UnitAgentFinder) FindEntity(tag names.Tag) (state.Entity, error) {
 	return entity.(hasAgent).Agent(), nil
 }
 
+// SetStatuses implements StatusBatcher, setting the statuses together
+// if the wrapped entity finder supports it.
+func (ua *UnitAgentFinder) SetStatuses(updates []state.StatusUpdate) []error {
+	if batcher, ok := ua.EntityFinder.(StatusBatcher); ok {
+		return batcher.SetStatuses(updates)
+	}
+	return setStatusesSeparately(updates)
+}
+
 type hasAgent interface {
 	Agent() *state.UnitAgent
 }
@@ -82,6 +82,42 @@ func (s *statusSetterSuite) TestSetMachineStatus(c *gc.C) {
 	c.Assert(machineStatus.Status, gc.Equals, status.Started)
 }
 
+func (s *statusSetterSuite) TestSetMachineStatusSince(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	since := time.Now().Add(-time.Hour).Round(time.Second)
+	result, err := s.setter.SetStatus(params.SetStatus{[]params.EntityStatusArgs{{
+		Tag:    machine.Tag().String(),
+		Status: status.Started.String(),
+		Since:  &since,
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+
+	machineStatus, err := machine.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineStatus.Since, gc.NotNil)
+	c.Assert(machineStatus.Since.Equal(since), jc.IsTrue)
+}
+
+func (s *statusSetterSuite) TestSetMachineStatusSinceInFuture(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	since := time.Now().Add(time.Hour)
+	result, err := s.setter.SetStatus(params.SetStatus{[]params.EntityStatusArgs{{
+		Tag:    machine.Tag().String(),
+		Status: status.Started.String(),
+		Since:  &since,
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+
+	// A time ahead of the controller's is recorded as the time the
+	// status arrived.
+	machineStatus, err := machine.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machineStatus.Since, gc.NotNil)
+	c.Assert(machineStatus.Since.Before(since), jc.IsTrue)
+}
+
 func (s *statusSetterSuite) TestSetUnitStatus(c *gc.C) {
 	// The status has to be a valid workload status, because get status
 	// on the unit returns the workload status not the agent status as it
@@ -141,6 +177,33 @@ func (s *statusSetterSuite) TestBulk(c *gc.C) {
 	c.Assert(result.Results[1].Error, gc.ErrorMatches, `"bad-tag" is not a valid tag`)
 }
 
+func (s *statusSetterSuite) TestSetUnitStatuses(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	unit0 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	unit1 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	result, err := s.setter.SetStatus(params.SetStatus{[]params.EntityStatusArgs{{
+		Tag:    unit0.Tag().String(),
+		Status: status.Active.String(),
+		Info:   "ready",
+	}, {
+		Tag:    unit1.Tag().String(),
+		Status: "vliegkat",
+	}, {
+		Tag:    names.NewUnitTag("foo/42").String(),
+		Status: status.Active.String(),
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `cannot set invalid status "vliegkat"`)
+	c.Assert(result.Results[2].Error, jc.Satisfies, params.IsCodeNotFound)
+
+	unitStatus, err := unit0.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitStatus.Status, gc.Equals, status.Active)
+	c.Assert(unitStatus.Message, gc.Equals, "ready")
+}
+
 type serviceStatusSetterSuite struct {
 	statusBaseSuite
 	setter *common.ApplicationStatusSetter
@@ -265,6 +328,29 @@ func (s *serviceStatusSetterSuite) TestSetUnitStatusIsLeader(c *gc.C) {
 	c.Assert(unitStatus.Status, gc.Equals, status.Active)
 }
 
+func (s *serviceStatusSetterSuite) TestSetStatusSince(c *gc.C) {
+	service := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: service})
+	err := s.State.LeadershipClaimer().ClaimLeadership(
+		service.Name(),
+		unit.Name(),
+		time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	since := time.Now().Add(-time.Hour).Round(time.Second)
+	result, err := s.setter.SetStatus(params.SetStatus{[]params.EntityStatusArgs{{
+		Tag:    unit.Tag().String(),
+		Status: status.Active.String(),
+		Since:  &since,
+	}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+
+	appStatus, err := service.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(appStatus.Since, gc.NotNil)
+	c.Assert(appStatus.Since.Equal(since), jc.IsTrue)
+}
+
 func (s *serviceStatusSetterSuite) TestBulk(c *gc.C) {
 	s.badTag = names.NewMachineTag("42")
 	machine := s.Factory.MakeMachine(c, nil)
@@ -314,6 +400,33 @@ func (unitAgentFinderSuite) TestFindEntityErr(c *gc.C) {
 	c.Assert(errors.Cause(err), gc.Equals, f.err)
 }
 
+func (unitAgentFinderSuite) TestSetStatusesSeparately(c *gc.C) {
+	ua := &common.UnitAgentFinder{fakeEntityFinder{}}
+	setters := []*fakeStatusSetter{{}, {err: errors.New("boom")}}
+	errs := ua.SetStatuses([]state.StatusUpdate{{
+		Entity: setters[0],
+		Status: status.StatusInfo{Status: status.Idle},
+	}, {
+		Entity: setters[1],
+		Status: status.StatusInfo{Status: status.Executing},
+	}})
+	c.Assert(errs, gc.HasLen, 2)
+	c.Assert(errs[0], jc.ErrorIsNil)
+	c.Assert(errs[1], gc.ErrorMatches, "boom")
+	c.Assert(setters[0].status.Status, gc.Equals, status.Idle)
+	c.Assert(setters[1].status.Status, gc.Equals, status.Executing)
+}
+
+type fakeStatusSetter struct {
+	status status.StatusInfo
+	err    error
+}
+
+func (f *fakeStatusSetter) SetStatus(info status.StatusInfo) error {
+	f.status = info
+	return f.err
+}
+
 type fakeEntityFinder struct {
 	unit fakeUnit
 	err  error
@@ -331,3 +444,91 @@ type fakeUnit struct {
 func (f fakeUnit) Agent() *state.UnitAgent {
 	return f.agent
 }
+
+func (s *serviceStatusSetterSuite) TestBulkSetStatus(c *gc.C) {
+	app := s.Factory.MakeApplication(c, nil)
+	unit0 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	unit1 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	other := s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "other"})
+	result, err := s.setter.BulkSetStatus(params.BulkSetStatusArgs{
+		Args: []params.BulkSetStatusArg{{
+			Unit: unit0.Tag().String(),
+			Entities: []params.EntityStatusArgs{{
+				Tag:    app.Tag().String(),
+				Status: status.Active.String(),
+				Info:   "all ready",
+			}, {
+				Tag:    unit0.Tag().String(),
+				Status: status.Active.String(),
+			}, {
+				Tag:    unit1.Tag().String(),
+				Status: status.Blocked.String(),
+				Info:   "need a relation",
+			}, {
+				Tag:    other.Tag().String(),
+				Status: status.Active.String(),
+			}, {
+				Tag:    names.NewMachineTag("0").String(),
+				Status: status.Active.String(),
+			}},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Results, gc.HasLen, 5)
+	c.Check(result.Results[0].Results[0].Error, gc.IsNil)
+	c.Check(result.Results[0].Results[1].Error, gc.IsNil)
+	c.Check(result.Results[0].Results[2].Error, gc.IsNil)
+	c.Check(result.Results[0].Results[3].Error, jc.Satisfies, params.IsCodeUnauthorized)
+	c.Check(result.Results[0].Results[4].Error, jc.Satisfies, params.IsCodeUnauthorized)
+
+	appStatus, err := app.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(appStatus.Status, gc.Equals, status.Active)
+	c.Check(appStatus.Message, gc.Equals, "all ready")
+	unitStatus, err := unit1.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(unitStatus.Status, gc.Equals, status.Blocked)
+	c.Check(unitStatus.Message, gc.Equals, "need a relation")
+}
+
+func (s *serviceStatusSetterSuite) TestBulkSetStatusNotLeader(c *gc.C) {
+	s.leadershipChecker.isLeader = false
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Status: &status.StatusInfo{
+		Status: status.Maintenance,
+	}})
+	result, err := s.setter.BulkSetStatus(params.BulkSetStatusArgs{
+		Args: []params.BulkSetStatusArg{{
+			Unit: unit.Tag().String(),
+			Entities: []params.EntityStatusArgs{{
+				Tag:    unit.Tag().String(),
+				Status: status.Active.String(),
+			}},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, "not leader")
+	c.Assert(result.Results[0].Results, gc.HasLen, 0)
+
+	unitStatus, err := unit.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unitStatus.Status, gc.Equals, status.Maintenance)
+}
+
+func (s *serviceStatusSetterSuite) TestBulkSetStatusUnauthorized(c *gc.C) {
+	tag := names.NewUnitTag("foo/0")
+	s.badTag = tag
+	result, err := s.setter.BulkSetStatus(params.BulkSetStatusArgs{
+		Args: []params.BulkSetStatusArg{{
+			Unit: tag.String(),
+		}, {
+			Unit: names.NewApplicationTag("foo").String(),
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, jc.Satisfies, params.IsCodeUnauthorized)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `"application-foo" is not a valid unit tag`)
+}
@@ -42,7 +42,7 @@ func (c *ModelPresenceContext) UnitStatus(unit UnitStatusGetter) (agent StatusAn
 		return
 	}
 
-	agentAlive, err := c.unitPresence(unit)
+	agentAlive, err := c.UnitPresence(unit)
 	if err != nil {
 		return
 	}
@@ -54,8 +54,13 @@ func (c *ModelPresenceContext) UnitStatus(unit UnitStatusGetter) (agent StatusAn
 			workload.Status.Status = status.Unknown
 			workload.Status.Message = fmt.Sprintf("agent lost, see 'juju show-status-log %s'", unit.Name())
 		}
-		agent.Status.Status = status.Lost
-		agent.Status.Message = "agent is not communicating with the server"
+		// An agent which has been lost for longer than the model's
+		// dead agent threshold keeps the distinct status it was
+		// given by the controller.
+		if agent.Status.Status != status.Gone {
+			agent.Status.Status = status.Lost
+			agent.Status.Message = "agent is not communicating with the server"
+		}
 	}
 	return
 }
@@ -101,6 +101,34 @@ func (s *UnitStatusSuite) TestCAASLost(c *gc.C) {
 	s.checkLost(c)
 }
 
+func (s *UnitStatusSuite) TestGone(c *gc.C) {
+	s.ctx.Presence = agentDown(s.unit.Tag().String())
+	s.unit.agentStatus = status.StatusInfo{
+		Status:  status.Gone,
+		Message: "agent has not been seen for over 1h0m0s",
+	}
+	agent, workload := s.ctx.UnitStatus(s.unit)
+	// The agent keeps the status it was given by the controller.
+	c.Check(agent.Status, jc.DeepEquals, s.unit.agentStatus)
+	c.Check(agent.Err, jc.ErrorIsNil)
+	c.Check(workload.Status, jc.DeepEquals, status.StatusInfo{
+		Status:  status.Unknown,
+		Message: "agent lost, see 'juju show-status-log foo/2'",
+	})
+	c.Check(workload.Err, jc.ErrorIsNil)
+}
+
+func (s *UnitStatusSuite) TestUnitPresence(c *gc.C) {
+	alive, err := s.ctx.UnitPresence(s.unit)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(alive, jc.IsTrue)
+
+	s.ctx.Presence = agentDown(s.unit.Tag().String())
+	alive, err = s.ctx.UnitPresence(s.unit)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(alive, jc.IsFalse)
+}
+
 func (s *UnitStatusSuite) TestLostAndDeadLegacy(c *gc.C) {
 	s.ctx.Presence = nil
 	s.unit.presence = false
@@ -30,6 +30,9 @@ type Context struct {
 	LeadershipPinner_  leadership.Pinner
 	LeadershipReader_  leadership.Reader
 	SingularClaimer_   lease.Claimer
+	LeaseInspector_    lease.Inspector
+	LeaseRevoker_      lease.Revoker
+	LeaseLister_       lease.Lister
 	// Identity is not part of the facade.Context interface, but is instead
 	// used to make sure that the context objects are the same.
 	Identity string
@@ -120,3 +123,18 @@ func (context Context) LeadershipReader(modelUUID string) (leadership.Reader, er
 func (context Context) SingularClaimer() (lease.Claimer, error) {
 	return context.SingularClaimer_, nil
 }
+
+// LeaseInspector implements facade.Context.
+func (context Context) LeaseInspector(namespace, modelUUID string) (lease.Inspector, error) {
+	return context.LeaseInspector_, nil
+}
+
+// LeaseRevoker implements facade.Context.
+func (context Context) LeaseRevoker(namespace, modelUUID string) (lease.Revoker, error) {
+	return context.LeaseRevoker_, nil
+}
+
+// LeaseLister implements facade.Context.
+func (context Context) LeaseLister(namespace string) (lease.Lister, error) {
+	return context.LeaseLister_, nil
+}
@@ -117,6 +117,18 @@ type Context interface {
 	// SingularClaimer returns a lease.Claimer for singular leases for
 	// this context's model.
 	SingularClaimer() (lease.Claimer, error)
+
+	// LeaseInspector returns a lease.Inspector for the leases in the
+	// given namespace and model.
+	LeaseInspector(namespace, modelUUID string) (lease.Inspector, error)
+
+	// LeaseRevoker returns a lease.Revoker for the leases in the
+	// given namespace and model.
+	LeaseRevoker(namespace, modelUUID string) (lease.Revoker, error)
+
+	// LeaseLister returns a lease.Lister for the leases in the
+	// given namespace, across all models.
+	LeaseLister(namespace string) (lease.Lister, error)
 }
 
 //go:generate mockgen -package mocks -destination mocks/facade_mock.go github.com/juju/juju/apiserver/facade Resources,Authorizer
@@ -66,6 +66,56 @@ func NewAgentAPIV2(st *state.State, resources facade.Resources, auth facade.Auth
 	}, nil
 }
 
+// AgentAPIV3 implements version 3 of the API provided to an agent,
+// which adds the calls with which a machine agent applies the agent
+// config values overridden by the controller.
+type AgentAPIV3 struct {
+	*AgentAPIV2
+}
+
+// NewAgentAPIV3 returns an object implementing version 3 of the Agent
+// API with the given authorizer representing the currently logged in
+// client.
+func NewAgentAPIV3(st *state.State, resources facade.Resources, auth facade.Authorizer) (*AgentAPIV3, error) {
+	api, err := NewAgentAPIV2(st, resources, auth)
+	if err != nil {
+		return nil, err
+	}
+	return &AgentAPIV3{api}, nil
+}
+
+// AgentAPIV4 implements version 4 of the API provided to an agent,
+// which adds WatchControllerConfig so that all agents can apply the
+// feature flags set in the controller config.
+type AgentAPIV4 struct {
+	*AgentAPIV3
+}
+
+// NewAgentAPIV4 returns an object implementing version 4 of the Agent
+// API with the given authorizer representing the currently logged in
+// client.
+func NewAgentAPIV4(st *state.State, resources facade.Resources, auth facade.Authorizer) (*AgentAPIV4, error) {
+	api, err := NewAgentAPIV3(st, resources, auth)
+	if err != nil {
+		return nil, err
+	}
+	return &AgentAPIV4{api}, nil
+}
+
+// WatchControllerConfig returns a watcher which notifies when the
+// controller config changes.
+func (api *AgentAPIV4) WatchControllerConfig() (params.NotifyWatchResult, error) {
+	var result params.NotifyWatchResult
+	watch := api.st.WatchControllerConfig()
+	// Consume the initial event; the agent reads the config when it
+	// starts watching it.
+	if _, ok := <-watch.Changes(); !ok {
+		return result, watcher.EnsureErr(watch)
+	}
+	result.NotifyWatcherId = api.resources.Register(watch)
+	return result, nil
+}
+
 func (api *AgentAPIV2) GetEntities(args params.Entities) params.AgentGetEntitiesResults {
 	results := params.AgentGetEntitiesResults{
 		Entities: make([]params.AgentGetEntitiesResult, len(args.Entities)),
@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/core/agentconfig"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
 	jujutesting "github.com/juju/juju/juju/testing"
@@ -276,3 +277,103 @@ func (s *agentSuite) TestWatchAuthError(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "permission denied")
 	c.Assert(s.resources.Count(), gc.Equals, 0)
 }
+
+func (s *agentSuite) TestAgentConfigOverrides(c *gc.C) {
+	err := s.machine1.SetAgentConfigOverrides(map[string]string{
+		agentconfig.LoggingConfig: "<root>=DEBUG",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := agent.NewAgentAPIV3(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := api.AgentConfigOverrides(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-1"},
+			{Tag: "machine-0"},
+			{Tag: "unit-foo-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.AgentConfigOverridesResults{
+		Results: []params.AgentConfigOverridesResult{
+			{
+				Values:  map[string]string{agentconfig.LoggingConfig: "<root>=DEBUG"},
+				Version: 1,
+			},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *agentSuite) TestSetAgentConfigOverridesApplied(c *gc.C) {
+	err := s.machine1.SetAgentConfigOverrides(map[string]string{
+		agentconfig.NoProxy: "localhost",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	api, err := agent.NewAgentAPIV3(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := api.SetAgentConfigOverridesApplied(params.AgentConfigOverridesAppliedArgs{
+		Args: []params.AgentConfigOverridesApplied{
+			{Tag: "machine-1", Version: 1},
+			{Tag: "machine-0", Version: 1},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	overrides, err := s.machine1.AgentConfigOverrides()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(overrides.AppliedVersion, gc.Equals, int64(1))
+}
+
+func (s *agentSuite) TestWatchControllerConfig(c *gc.C) {
+	api, err := agent.NewAgentAPIV4(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.WatchControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+
+	w := s.resources.Get("1")
+	defer statetesting.AssertStop(c, w)
+
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		"features": []interface{}{"new-hotness"},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *agentSuite) TestWatchAgentConfigOverrides(c *gc.C) {
+	api, err := agent.NewAgentAPIV3(s.State, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := api.WatchAgentConfigOverrides(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}, {Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResults{Results: []params.NotifyWatchResult{
+		{NotifyWatcherId: "1"},
+		{Error: apiservertesting.ErrUnauthorized},
+	}})
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+
+	w := s.resources.Get("1")
+	defer statetesting.AssertStop(c, w)
+
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	err = s.machine1.SetAgentConfigOverrides(map[string]string{agentconfig.NoProxy: "localhost"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agent
+
+import (
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// WatchAgentConfigOverrides returns a watcher for the agent config
+// values overridden for each of the given machines, which must be the
+// authenticated agent's own.
+func (api *AgentAPIV3) WatchAgentConfigOverrides(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		machine, err := api.authMachine(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		watch := machine.WatchAgentConfigOverrides()
+		// Consume the initial event; the agent reads the overrides
+		// when it starts watching them.
+		if _, ok := <-watch.Changes(); ok {
+			results.Results[i].NotifyWatcherId = api.resources.Register(watch)
+		} else {
+			results.Results[i].Error = common.ServerError(watcher.EnsureErr(watch))
+		}
+	}
+	return results, nil
+}
+
+// AgentConfigOverrides returns the agent config values overridden for
+// each of the given machines, which must be the authenticated agent's
+// own.
+func (api *AgentAPIV3) AgentConfigOverrides(args params.Entities) (params.AgentConfigOverridesResults, error) {
+	results := params.AgentConfigOverridesResults{
+		Results: make([]params.AgentConfigOverridesResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		machine, err := api.authMachine(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		overrides, err := machine.AgentConfigOverrides()
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i] = params.AgentConfigOverridesResult{
+			Values:         overrides.Values,
+			Version:        overrides.Version,
+			AppliedVersion: overrides.AppliedVersion,
+		}
+	}
+	return results, nil
+}
+
+// SetAgentConfigOverridesApplied records the version of its agent
+// config overrides which each of the given machines' agents has
+// applied. The machines must be the authenticated agent's own.
+func (api *AgentAPIV3) SetAgentConfigOverridesApplied(args params.AgentConfigOverridesAppliedArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		machine, err := api.authMachine(arg.Tag)
+		if err == nil {
+			err = machine.SetAgentConfigOverridesApplied(arg.Version)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// authMachine returns the machine with the given tag if it is the
+// authenticated agent's own.
+func (api *AgentAPIV3) authMachine(tag string) (*state.Machine, error) {
+	machineTag, err := names.ParseMachineTag(tag)
+	if err != nil {
+		return nil, common.ErrPerm
+	}
+	if !api.auth.AuthOwner(machineTag) {
+		return nil, common.ErrPerm
+	}
+	return api.st.Machine(machineTag.Id())
+}
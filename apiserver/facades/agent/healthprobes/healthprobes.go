@@ -0,0 +1,226 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package healthprobes implements the API used by the health probes
+// worker, which runs the model's health-probes for the units on a
+// machine and records their results in the units' status data.
+package healthprobes
+
+import (
+	"reflect"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/healthprobe"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+// Backend exposes the state functionality required by the facade.
+type Backend interface {
+	state.ModelAccessor
+
+	// Machine returns the machine with the given id.
+	Machine(id string) (Machine, error)
+}
+
+// Machine exposes the machine functionality required by the facade.
+type Machine interface {
+	Units() ([]Unit, error)
+	Status() (status.StatusInfo, error)
+	SetStatus(status.StatusInfo) error
+}
+
+// Unit exposes the unit functionality required by the facade.
+type Unit interface {
+	Name() string
+	ApplicationName() string
+	Status() (status.StatusInfo, error)
+	SetStatus(status.StatusInfo) error
+}
+
+// API implements the HealthProbes facade.
+type API struct {
+	*common.ModelWatcher
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns a new HealthProbes API facade.
+func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		ModelWatcher: common.NewModelWatcher(backend, resources, authorizer),
+		backend:      backend,
+		authorizer:   authorizer,
+	}, nil
+}
+
+func (api *API) machine(tagString string) (Machine, error) {
+	tag, err := names.ParseMachineTag(tagString)
+	if err != nil {
+		return nil, err
+	}
+	if !api.authorizer.AuthOwner(tag) {
+		return nil, common.ErrPerm
+	}
+	return api.backend.Machine(tag.Id())
+}
+
+// HealthProbes returns, for each given machine, the health probes to
+// run for the units on it.
+func (api *API) HealthProbes(args params.Entities) (params.HealthProbesResults, error) {
+	result := params.HealthProbesResults{
+		Results: make([]params.HealthProbesResult, len(args.Entities)),
+	}
+	if len(args.Entities) == 0 {
+		return result, nil
+	}
+	cfg, err := api.backend.ModelConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	probes := cfg.HealthProbes()
+	for i, arg := range args.Entities {
+		units, err := api.healthProbes(arg.Tag, probes)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Units = units
+	}
+	return result, nil
+}
+
+func (api *API) healthProbes(machineTag string, probes map[string][]healthprobe.Probe) ([]params.UnitHealthProbes, error) {
+	machine, err := api.machine(machineTag)
+	if err != nil {
+		return nil, err
+	}
+	units, err := machine.Units()
+	if err != nil {
+		return nil, err
+	}
+	var result []params.UnitHealthProbes
+	for _, unit := range units {
+		appProbes := probes[unit.ApplicationName()]
+		if len(appProbes) == 0 {
+			continue
+		}
+		unitProbes := params.UnitHealthProbes{
+			Unit:   names.NewUnitTag(unit.Name()).String(),
+			Probes: make([]params.HealthProbe, len(appProbes)),
+		}
+		for i, p := range appProbes {
+			unitProbes.Probes[i] = params.HealthProbe{
+				Name:     p.Name,
+				TCP:      p.TCP,
+				HTTP:     p.HTTP,
+				Command:  p.Command,
+				Interval: p.IntervalDuration(),
+				Timeout:  p.TimeoutDuration(),
+			}
+		}
+		result = append(result, unitProbes)
+	}
+	return result, nil
+}
+
+// SetHealthProbeResults records the results of the health probes run
+// by each given machine in the status data of the units they were run
+// for, and all of them in the status data of the machine. Only the
+// statuses whose results have changed are updated.
+func (api *API) SetHealthProbeResults(args params.SetHealthProbeResultsArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.setHealthProbeResults(arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *API) setHealthProbeResults(arg params.SetHealthProbeResults) error {
+	machine, err := api.machine(arg.Machine)
+	if err != nil {
+		return err
+	}
+	units, err := machine.Units()
+	if err != nil {
+		return err
+	}
+	machineUnits := make(map[string]Unit)
+	for _, unit := range units {
+		machineUnits[unit.Name()] = unit
+	}
+
+	machineResults := make(map[string]healthprobe.Result)
+	for _, unitResults := range arg.Units {
+		tag, err := names.ParseUnitTag(unitResults.Unit)
+		if err != nil {
+			return err
+		}
+		unit, ok := machineUnits[tag.Id()]
+		if !ok {
+			return common.ErrPerm
+		}
+		results := make(map[string]healthprobe.Result)
+		for _, r := range unitResults.Results {
+			result := healthprobe.Result{
+				Healthy: r.Healthy,
+				Message: r.Message,
+				Since:   r.Since,
+			}
+			results[r.Name] = result
+			machineResults[unit.Name()+":"+r.Name] = result
+		}
+		if err := updateStatusData(unit, results); err != nil {
+			return errors.Annotatef(err, "updating status of unit %q", unit.Name())
+		}
+	}
+	return errors.Annotate(updateStatusData(machine, machineResults), "updating machine status")
+}
+
+// statusEntity is implemented by machines and units.
+type statusEntity interface {
+	Status() (status.StatusInfo, error)
+	SetStatus(status.StatusInfo) error
+}
+
+// updateStatusData records the probe results in the entity's status
+// data, keeping its status and message, unless they are already
+// recorded there.
+func updateStatusData(entity statusEntity, results map[string]healthprobe.Result) error {
+	info, err := entity.Status()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	probeData := healthprobe.StatusData(results)
+	// Compare the results as they are read back from the status
+	// data, so that the precision of the times doesn't matter.
+	current := healthprobe.ResultsFromStatusData(info.Data)
+	updated := healthprobe.ResultsFromStatusData(map[string]interface{}{
+		healthprobe.DataKey: probeData,
+	})
+	if reflect.DeepEqual(current, updated) {
+		return nil
+	}
+	data := make(map[string]interface{})
+	for k, v := range info.Data {
+		data[k] = v
+	}
+	if len(results) == 0 {
+		delete(data, healthprobe.DataKey)
+	} else {
+		data[healthprobe.DataKey] = probeData
+	}
+	info.Data = data
+	return errors.Trace(entity.SetStatus(info))
+}
@@ -0,0 +1,186 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthprobes_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/agent/healthprobes"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/healthprobe"
+	"github.com/juju/juju/core/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type HealthProbesSuite struct {
+	coretesting.BaseSuite
+
+	backend *mockBackend
+	machine *mockMachine
+	api     *healthprobes.API
+	since   time.Time
+}
+
+var _ = gc.Suite(&HealthProbesSuite{})
+
+const probesConfig = `
+mysql:
+  - name: port
+    tcp: localhost:3306
+  - name: ping
+    command: mysqladmin ping
+    interval: 1m
+    timeout: 5s
+`
+
+func (s *HealthProbesSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.since = time.Date(2020, 1, 3, 14, 0, 0, 0, time.UTC)
+	info := status.StatusInfo{
+		Status:  status.Active,
+		Message: "ready",
+		Data:    map[string]interface{}{"foo": "bar"},
+		Since:   &s.since,
+	}
+	s.machine = &mockMachine{
+		mockStatus: mockStatus{status: status.StatusInfo{Status: status.Started, Since: &s.since}},
+		units: []*mockUnit{
+			{name: "mysql/0", mockStatus: mockStatus{status: info}},
+			{name: "wordpress/0", mockStatus: mockStatus{status: info}},
+		},
+	}
+	s.backend = &mockBackend{
+		probes:   probesConfig,
+		machines: map[string]*mockMachine{"0": s.machine},
+	}
+	var err error
+	s.api, err = healthprobes.NewAPI(
+		s.backend, common.NewResources(),
+		apiservertesting.FakeAuthorizer{Tag: names.NewMachineTag("0")},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *HealthProbesSuite) TestNewAPIRequiresMachineAgent(c *gc.C) {
+	api, err := healthprobes.NewAPI(
+		s.backend, common.NewResources(),
+		apiservertesting.FakeAuthorizer{Tag: names.NewUnitTag("mysql/0")},
+	)
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *HealthProbesSuite) TestHealthProbes(c *gc.C) {
+	result, err := s.api.HealthProbes(params.Entities{Entities: []params.Entity{
+		{Tag: "machine-0"},
+		{Tag: "machine-1"},
+		{Tag: "unit-mysql-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.HealthProbesResults{
+		Results: []params.HealthProbesResult{{
+			Units: []params.UnitHealthProbes{{
+				Unit: "unit-mysql-0",
+				Probes: []params.HealthProbe{{
+					Name:     "port",
+					TCP:      "localhost:3306",
+					Interval: healthprobe.DefaultInterval,
+					Timeout:  healthprobe.DefaultTimeout,
+				}, {
+					Name:     "ping",
+					Command:  "mysqladmin ping",
+					Interval: time.Minute,
+					Timeout:  5 * time.Second,
+				}},
+			}},
+		}, {
+			Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized},
+		}, {
+			Error: &params.Error{Message: `"unit-mysql-0" is not a valid machine tag`},
+		}},
+	})
+	s.backend.CheckCallNames(c, "ModelConfig", "Machine")
+}
+
+func (s *HealthProbesSuite) TestHealthProbesNone(c *gc.C) {
+	s.backend.probes = ""
+	result, err := s.api.HealthProbes(params.Entities{Entities: []params.Entity{{Tag: "machine-0"}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.HealthProbesResults{
+		Results: []params.HealthProbesResult{{}},
+	})
+}
+
+func (s *HealthProbesSuite) setResults(c *gc.C, units ...params.UnitHealthProbeResults) {
+	result, err := s.api.SetHealthProbeResults(params.SetHealthProbeResultsArgs{
+		Args: []params.SetHealthProbeResults{{Machine: "machine-0", Units: units}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{{}}})
+}
+
+func (s *HealthProbesSuite) TestSetHealthProbeResults(c *gc.C) {
+	failing := s.since.Add(time.Minute)
+	s.setResults(c, params.UnitHealthProbeResults{
+		Unit: "unit-mysql-0",
+		Results: []params.HealthProbeResult{
+			{Name: "port", Healthy: true, Since: s.since},
+			{Name: "ping", Healthy: false, Message: "exit status 1", Since: failing},
+		},
+	})
+
+	unit := s.machine.units[0]
+	c.Check(unit.setCall, gc.Equals, 1)
+	c.Check(unit.status.Status, gc.Equals, status.Active)
+	c.Check(unit.status.Message, gc.Equals, "ready")
+	c.Check(unit.status.Since, gc.Equals, &s.since)
+	c.Check(unit.status.Data["foo"], gc.Equals, "bar")
+	c.Check(healthprobe.ResultsFromStatusData(unit.status.Data), jc.DeepEquals, map[string]healthprobe.Result{
+		"port": {Healthy: true, Since: s.since},
+		"ping": {Message: "exit status 1", Since: failing},
+	})
+
+	machine := s.machine
+	c.Check(machine.setCall, gc.Equals, 1)
+	c.Check(machine.status.Status, gc.Equals, status.Started)
+	c.Check(healthprobe.ResultsFromStatusData(machine.status.Data), jc.DeepEquals, map[string]healthprobe.Result{
+		"mysql/0:port": {Healthy: true, Since: s.since},
+		"mysql/0:ping": {Message: "exit status 1", Since: failing},
+	})
+	c.Check(s.machine.units[1].setCall, gc.Equals, 0)
+}
+
+func (s *HealthProbesSuite) TestSetHealthProbeResultsUnchanged(c *gc.C) {
+	results := params.UnitHealthProbeResults{
+		Unit:    "unit-mysql-0",
+		Results: []params.HealthProbeResult{{Name: "port", Healthy: true, Since: s.since}},
+	}
+	s.setResults(c, results)
+	s.setResults(c, results)
+	c.Check(s.machine.units[0].setCall, gc.Equals, 1)
+	c.Check(s.machine.setCall, gc.Equals, 1)
+}
+
+func (s *HealthProbesSuite) TestSetHealthProbeResultsUnitNotOnMachine(c *gc.C) {
+	result, err := s.api.SetHealthProbeResults(params.SetHealthProbeResultsArgs{
+		Args: []params.SetHealthProbeResults{{
+			Machine: "machine-0",
+			Units:   []params.UnitHealthProbeResults{{Unit: "unit-mysql-1"}},
+		}, {
+			Machine: "machine-1",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{
+		{Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}},
+		{Error: &params.Error{Message: "permission denied", Code: params.CodeUnauthorized}},
+	}})
+	c.Check(s.machine.setCall, gc.Equals, 0)
+}
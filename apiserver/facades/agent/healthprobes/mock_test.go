@@ -0,0 +1,92 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthprobes_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/facades/agent/healthprobes"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mockBackend struct {
+	testing.Stub
+	probes   string
+	machines map[string]*mockMachine
+}
+
+func (b *mockBackend) WatchForModelConfigChanges() state.NotifyWatcher {
+	b.MethodCall(b, "WatchForModelConfigChanges")
+	return nil
+}
+
+func (b *mockBackend) ModelConfig() (*config.Config, error) {
+	b.MethodCall(b, "ModelConfig")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	attrs := coretesting.FakeConfig().Merge(coretesting.Attrs{
+		config.HealthProbes: b.probes,
+	})
+	return config.New(config.UseDefaults, attrs)
+}
+
+func (b *mockBackend) Machine(id string) (healthprobes.Machine, error) {
+	b.MethodCall(b, "Machine", id)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	m, ok := b.machines[id]
+	if !ok {
+		return nil, errors.NotFoundf("machine %s", id)
+	}
+	return m, nil
+}
+
+type mockMachine struct {
+	mockStatus
+	units []*mockUnit
+}
+
+func (m *mockMachine) Units() ([]healthprobes.Unit, error) {
+	units := make([]healthprobes.Unit, len(m.units))
+	for i, u := range m.units {
+		units[i] = u
+	}
+	return units, nil
+}
+
+type mockUnit struct {
+	mockStatus
+	name string
+}
+
+func (u *mockUnit) Name() string {
+	return u.name
+}
+
+func (u *mockUnit) ApplicationName() string {
+	app, _ := names.UnitApplication(u.name)
+	return app
+}
+
+type mockStatus struct {
+	status  status.StatusInfo
+	setCall int
+}
+
+func (s *mockStatus) Status() (status.StatusInfo, error) {
+	return s.status, nil
+}
+
+func (s *mockStatus) SetStatus(info status.StatusInfo) error {
+	s.setCall++
+	s.status = info
+	return nil
+}
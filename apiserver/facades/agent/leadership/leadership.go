@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/state"
 )
 
 const (
@@ -38,12 +39,33 @@ func NewLeadershipServiceFacade(context facade.Context) (LeadershipService, erro
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return NewLeadershipService(claimer, context.Auth())
+	return NewLeadershipService(claimer, unitMaintenanceShim{context.State()}, context.Auth())
+}
+
+// UnitMaintenance reports whether units have been put into maintenance.
+type UnitMaintenance interface {
+	// UnitInMaintenance reports whether the named unit has been put
+	// into maintenance by an operator.
+	UnitInMaintenance(unitName string) (bool, error)
+}
+
+type unitMaintenanceShim struct {
+	st *state.State
+}
+
+// UnitInMaintenance is part of the UnitMaintenance interface.
+func (s unitMaintenanceShim) UnitInMaintenance(unitName string) (bool, error) {
+	unit, err := s.st.Unit(unitName)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	_, inMaintenance := unit.Maintenance()
+	return inMaintenance, nil
 }
 
 // NewLeadershipService constructs a new LeadershipService.
 func NewLeadershipService(
-	claimer leadership.Claimer, authorizer facade.Authorizer,
+	claimer leadership.Claimer, units UnitMaintenance, authorizer facade.Authorizer,
 ) (LeadershipService, error) {
 
 	if !authorizer.AuthUnitAgent() && !authorizer.AuthApplicationAgent() {
@@ -52,6 +74,7 @@ func NewLeadershipService(
 
 	return &leadershipService{
 		claimer:    claimer,
+		units:      units,
 		authorizer: authorizer,
 	}, nil
 }
@@ -60,6 +83,7 @@ func NewLeadershipService(
 // is the concrete implementation of the API endpoint.
 type leadershipService struct {
 	claimer    leadership.Claimer
+	units      UnitMaintenance
 	authorizer facade.Authorizer
 }
 
@@ -96,6 +120,19 @@ func (m *leadershipService) ClaimLeadership(args params.ClaimLeadershipBulkParam
 			result.Error = common.ServerError(common.ErrPerm)
 			continue
 		}
+		// Units in maintenance may not lead their application. Denying
+		// the claims of a leader put into maintenance lets its lease
+		// expire, so that another unit can be elected.
+		inMaintenance, err := m.units.UnitInMaintenance(unitTag.Id())
+		if err != nil {
+			result.Error = common.ServerError(err)
+			continue
+		}
+		if inMaintenance {
+			result.Error = common.ServerError(errors.Annotatef(
+				leadership.ErrClaimDenied, "unit %q is in maintenance", unitTag.Id()))
+			continue
+		}
 		if err = m.claimer.ClaimLeadership(applicationTag.Id(), unitTag.Id(), duration); err != nil {
 			result.Error = common.ServerError(err)
 		}
@@ -53,6 +53,14 @@ func (m *stubClaimer) BlockUntilLeadershipReleased(serviceId string, cancel <-ch
 	return nil
 }
 
+type stubUnitMaintenance struct {
+	inMaintenance map[string]bool
+}
+
+func (m *stubUnitMaintenance) UnitInMaintenance(unitName string) (bool, error) {
+	return m.inMaintenance[unitName], nil
+}
+
 type stubAuthorizer struct {
 	facade.Authorizer
 	tag names.Tag
@@ -90,7 +98,7 @@ func newLeadershipService(
 	if authorizer == nil {
 		authorizer = stubAuthorizer{tag: names.NewUnitTag(StubUnitNm)}
 	}
-	result, err := leadership.NewLeadershipService(claimer, authorizer)
+	result, err := leadership.NewLeadershipService(claimer, &stubUnitMaintenance{}, authorizer)
 	c.Assert(err, jc.ErrorIsNil)
 	return result
 }
@@ -179,6 +187,36 @@ func (s *leadershipSuite) TestClaimLeadershipDeniedError(c *gc.C) {
 	c.Check(results.Results[0].Error, jc.Satisfies, params.IsCodeLeadershipClaimDenied)
 }
 
+func (s *leadershipSuite) TestClaimLeadershipInMaintenance(c *gc.C) {
+	claimer := &stubClaimer{
+		ClaimLeadershipFn: func(sid, uid string, duration time.Duration) error {
+			c.Fatalf("unexpected claim for %q", uid)
+			return nil
+		},
+	}
+	units := &stubUnitMaintenance{
+		inMaintenance: map[string]bool{StubUnitNm: true},
+	}
+	authorizer := stubAuthorizer{tag: names.NewUnitTag(StubUnitNm)}
+	ldrSvc, err := leadership.NewLeadershipService(claimer, units, authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := ldrSvc.ClaimLeadership(params.ClaimLeadershipBulkParams{
+		Params: []params.ClaimLeadershipParams{
+			{
+				ApplicationTag:  names.NewApplicationTag(StubAppNm).String(),
+				UnitTag:         names.NewUnitTag(StubUnitNm).String(),
+				DurationSeconds: 30,
+			},
+		},
+	})
+
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].Error, gc.ErrorMatches, `unit "stub-application/0" is in maintenance: leadership claim denied`)
+	c.Check(results.Results[0].Error, jc.Satisfies, params.IsCodeLeadershipClaimDenied)
+}
+
 func (s *leadershipSuite) TestClaimLeadershipBadService(c *gc.C) {
 	ldrSvc := newLeadershipService(c, nil, nil)
 
@@ -331,7 +369,7 @@ func (s *leadershipSuite) TestCreateUnauthorized(c *gc.C) {
 		tag: names.NewMachineTag("123"),
 	}
 
-	ldrSvc, err := leadership.NewLeadershipService(nil, authorizer)
+	ldrSvc, err := leadership.NewLeadershipService(nil, nil, authorizer)
 	c.Check(ldrSvc, gc.IsNil)
 	c.Check(err, gc.ErrorMatches, "permission denied")
 	c.Check(err, jc.Satisfies, errors.IsUnauthorized)
@@ -6,6 +6,7 @@
 package machine
 
 import (
+	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v3"
 
@@ -14,6 +15,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state"
 )
 
@@ -34,6 +36,21 @@ type MachinerAPI struct {
 	getCanRead   common.GetAuthFunc
 }
 
+// MachinerAPIV1 implements the V1 Machiner API, which
+// lacks RecordProvisioningPhases.
+type MachinerAPIV1 struct {
+	*MachinerAPI
+}
+
+// NewMachinerAPIV1 creates a new instance of the V1 Machiner API.
+func NewMachinerAPIV1(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*MachinerAPIV1, error) {
+	api, err := NewMachinerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &MachinerAPIV1{api}, nil
+}
+
 // NewMachinerAPI creates a new instance of the Machiner API.
 func NewMachinerAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*MachinerAPI, error) {
 	if !authorizer.AuthMachineAgent() {
@@ -139,3 +156,40 @@ func (api *MachinerAPI) Jobs(args params.Entities) (params.JobsResults, error) {
 	}
 	return result, nil
 }
+
+// RecordProvisioningPhases records in the machines' provisioning
+// timelines when they reached the given phases. Only the phases that
+// are observed by the machine agent itself may be recorded; the
+// controller records the others.
+func (api *MachinerAPI) RecordProvisioningPhases(args params.MachineProvisioningPhases) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Phases)),
+	}
+	canModify, err := api.getCanModify()
+	if err != nil {
+		return results, err
+	}
+	for i, arg := range args.Phases {
+		tag, err := names.ParseMachineTag(arg.Tag)
+		if err != nil || !canModify(tag) {
+			results.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		phase := status.ProvisioningPhase(arg.Phase)
+		if phase != status.PhaseCloudInitDone {
+			results.Results[i].Error = common.ServerError(errors.NotValidf("provisioning phase %q", arg.Phase))
+			continue
+		}
+		m, err := api.getMachine(tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		err = m.RecordProvisioningPhase(phase, "", arg.Time)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// RecordProvisioningPhases isn't on the V1 API.
+func (*MachinerAPIV1) RecordProvisioningPhases(_, _ struct{}) {}
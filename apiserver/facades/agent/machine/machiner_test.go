@@ -201,6 +201,38 @@ func (s *machinerSuite) TestSetMachineAddresses(c *gc.C) {
 	c.Assert(s.machine0.MachineAddresses(), gc.HasLen, 0)
 }
 
+func (s *machinerSuite) TestRecordProvisioningPhases(c *gc.C) {
+	finished := time.Date(2020, 4, 1, 10, 0, 0, 0, time.UTC)
+	args := params.MachineProvisioningPhases{Phases: []params.MachineProvisioningPhase{
+		{Tag: "machine-1", Phase: "cloud-init-done", Time: finished},
+		{Tag: "machine-1", Phase: "agent-connected", Time: finished},
+		{Tag: "machine-0", Phase: "cloud-init-done", Time: finished},
+		{Tag: "application-foo", Phase: "cloud-init-done", Time: finished},
+	}}
+
+	result, err := s.machiner.RecordProvisioningPhases(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{nil},
+			{&params.Error{Message: `provisioning phase "agent-connected" not valid`}},
+			{apiservertesting.ErrUnauthorized},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	timeline, err := s.machine1.ProvisioningTimeline(status.StatusHistoryFilter{Size: 10})
+	c.Assert(err, jc.ErrorIsNil)
+	var recorded []status.StatusInfo
+	for _, entry := range timeline {
+		if entry.Status == status.Status(status.PhaseCloudInitDone) {
+			recorded = append(recorded, entry)
+		}
+	}
+	c.Assert(recorded, gc.HasLen, 1)
+	c.Assert(recorded[0].Since.Equal(finished), jc.IsTrue)
+}
+
 func (s *machinerSuite) TestSetEmptyMachineAddresses(c *gc.C) {
 	// Set some addresses so we can ensure they are removed.
 	addresses := []network.MachineAddress{
@@ -439,6 +439,7 @@ func (api *ProvisionerAPI) ContainerConfig() (params.ContainerConfig, error) {
 	result.JujuProxy = cfg.JujuProxySettings()
 	result.AptProxy = cfg.AptProxySettings()
 	result.AptMirror = cfg.AptMirror()
+	result.AptSources = common.AptSourcesToParams(cfg.AptSources())
 	result.SnapProxy = cfg.SnapProxySettings()
 	result.SnapStoreAssertions = cfg.SnapStoreAssertions()
 	result.SnapStoreProxyID = cfg.SnapStoreProxy()
@@ -504,7 +505,7 @@ func (api *ProvisionerAPI) MachinesWithTransientErrors() (params.StatusResults,
 			continue
 		}
 		// Transient errors are marked as such in the status data.
-		if transient, ok := result.Data["transient"].(bool); !ok || !transient {
+		if !status.IsTransient(result.Data) {
 			continue
 		}
 		result.Id = machine.Id()
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"github.com/juju/os/series"
+	"github.com/juju/utils/arch"
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/apiserver/common"
@@ -21,6 +22,7 @@ import (
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/environs/tags"
@@ -438,6 +440,24 @@ func (api *ProvisionerAPI) availableImageMetadata(m *state.Machine, env environs
 		return nil, errors.Annotate(err, "could not construct image constraint")
 	}
 
+	// Images pinned in model config take precedence over those found
+	// in the image metadata.
+	cfg := env.Config()
+	if pinned, ok := cfg.PinnedImage(m.Series(), imageConstraint.Region); ok {
+		data, err := pinnedImageMetadata(pinned, imageConstraint)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		logger.Debugf("using pinned image %q for machine %v", pinned.ImageID, m.Id())
+		return data, nil
+	}
+	if cfg.ImagePolicy() == config.ImagePolicyPinned {
+		return nil, errors.Errorf(
+			"no image pinned for series %q in region %q, and image-policy is %q",
+			m.Series(), imageConstraint.Region, config.ImagePolicyPinned,
+		)
+	}
+
 	// Look for image metadata in state.
 	data, err := api.findImageMetadata(imageConstraint, env)
 	if err != nil {
@@ -452,7 +472,7 @@ func (api *ProvisionerAPI) availableImageMetadata(m *state.Machine, env environs
 func (api *ProvisionerAPI) constructImageConstraint(m *state.Machine, env environs.Environ) (*imagemetadata.ImageConstraint, error) {
 	lookup := simplestreams.LookupParams{
 		Series: []string{m.Series()},
-		Stream: env.Config().ImageStream(),
+		Stream: env.Config().ImageStreamFor(m.Series()),
 	}
 
 	mcons, err := m.Constraints()
@@ -479,6 +499,33 @@ func (api *ProvisionerAPI) constructImageConstraint(m *state.Machine, env enviro
 	return imagemetadata.NewImageConstraint(lookup), nil
 }
 
+// pinnedImageMetadata returns the image metadata describing an image
+// pinned in model config, for each of the architectures in the image
+// constraint.
+func pinnedImageMetadata(pinned config.PinnedImage, constraint *imagemetadata.ImageConstraint) ([]params.CloudImageMetadata, error) {
+	version, err := series.SeriesVersion(pinned.Series)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	arches := constraint.Arches
+	if len(arches) == 0 {
+		arches = []string{arch.AMD64}
+	}
+	data := make([]params.CloudImageMetadata, len(arches))
+	for i, a := range arches {
+		data[i] = params.CloudImageMetadata{
+			ImageId: pinned.ImageID,
+			Stream:  constraint.Stream,
+			Region:  constraint.Region,
+			Version: version,
+			Series:  pinned.Series,
+			Arch:    a,
+			Source:  config.ImageIDsKey,
+		}
+	}
+	return data, nil
+}
+
 // findImageMetadata returns all image metadata or an error fetching them.
 // It looks for image metadata in state.
 // If none are found, we fall back on original image search in simple streams.
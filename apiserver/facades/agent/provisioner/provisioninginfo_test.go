@@ -464,6 +464,60 @@ func (s *withoutControllerSuite) TestProviderInfoCloudInitUserData(c *gc.C) {
 		"package_upgrade": false})
 }
 
+func (s *withoutControllerSuite) TestProvisioningInfoPinnedImage(c *gc.C) {
+	attrs := map[string]interface{}{
+		"image-ids":     "quantal=img-golden-quantal",
+		"image-streams": "quantal=hardened",
+	}
+	err := s.Model.UpdateModelConfig(attrs, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	template := state.MachineTemplate{
+		Series:      "quantal",
+		Jobs:        []state.MachineJob{state.JobHostUnits},
+		Constraints: constraints.MustParse("arch=arm64"),
+	}
+	m, err := s.State.AddOneMachine(template)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: m.Tag().String()},
+	}}
+	result, err := s.provisioner.ProvisioningInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	imageMetadata := result.Results[0].Result.ImageMetadata
+	c.Assert(imageMetadata, gc.HasLen, 1)
+	c.Check(imageMetadata[0].ImageId, gc.Equals, "img-golden-quantal")
+	c.Check(imageMetadata[0].Series, gc.Equals, "quantal")
+	c.Check(imageMetadata[0].Version, gc.Equals, "12.10")
+	c.Check(imageMetadata[0].Arch, gc.Equals, "arm64")
+	c.Check(imageMetadata[0].Stream, gc.Equals, "hardened")
+	c.Check(imageMetadata[0].Source, gc.Equals, "image-ids")
+}
+
+func (s *withoutControllerSuite) TestProvisioningInfoImagePolicyPinnedNoImage(c *gc.C) {
+	attrs := map[string]interface{}{
+		"image-ids":    "bionic=img-golden-bionic",
+		"image-policy": "pinned",
+	}
+	err := s.Model.UpdateModelConfig(attrs, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	template := state.MachineTemplate{
+		Series: "quantal",
+		Jobs:   []state.MachineJob{state.JobHostUnits},
+	}
+	m, err := s.State.AddOneMachine(template)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: m.Tag().String()},
+	}}
+	result, err := s.provisioner.ProvisioningInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches,
+		`cannot get available image metadata: no image pinned for series "quantal" in region ".*", and image-policy is "pinned"`)
+}
+
 var validCloudInitUserData = `
 packages:
   - 'python-keystoneclient'
@@ -5,7 +5,6 @@ package proxyupdater
 
 import (
 	"github.com/juju/errors"
-	"github.com/juju/proxy"
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/apiserver/common"
@@ -138,15 +137,6 @@ func (api *APIBase) WatchForProxyConfigAndAPIHostPortChanges(args params.Entitie
 	return results
 }
 
-func toParams(settings proxy.Settings) params.ProxyConfig {
-	return params.ProxyConfig{
-		HTTP:    settings.Http,
-		HTTPS:   settings.Https,
-		FTP:     settings.Ftp,
-		NoProxy: settings.FullNoProxy(),
-	}
-}
-
 func (api *APIBase) authEntities(args params.Entities) (params.ErrorResults, bool) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.Entities)),
@@ -184,25 +174,7 @@ func (api *APIBase) proxyConfig() params.ProxyConfigResult {
 		return result
 	}
 
-	jujuProxySettings := config.JujuProxySettings()
-	legacyProxySettings := config.LegacyProxySettings()
-
-	if jujuProxySettings.HasProxySet() {
-		jujuProxySettings.AutoNoProxy = network.APIHostPortsToNoProxyString(apiHostPorts)
-	} else {
-		legacyProxySettings.AutoNoProxy = network.APIHostPortsToNoProxyString(apiHostPorts)
-	}
-	result.JujuProxySettings = toParams(jujuProxySettings)
-	result.LegacyProxySettings = toParams(legacyProxySettings)
-
-	result.APTProxySettings = toParams(config.AptProxySettings())
-
-	result.SnapProxySettings = toParams(config.SnapProxySettings())
-	result.SnapStoreProxyId = config.SnapStoreProxy()
-	result.SnapStoreProxyAssertions = config.SnapStoreAssertions()
-	result.SnapStoreProxyURL = config.SnapStoreProxyURL()
-
-	return result
+	return common.ProxyConfig(config, apiHostPorts)
 }
 
 // ProxyConfig returns the proxy settings for the current model.
@@ -246,6 +246,42 @@ func (s *ProxyUpdaterSuite) TestSnapProxyConfig(c *gc.C) {
 	})
 }
 
+func (s *ProxyUpdaterSuite) TestAptSourcesConfig(c *gc.C) {
+	s.state.SetModelConfig(coretesting.Attrs{
+		"apt-sources": "- source: ppa:juju/stable",
+	})
+	cfg := s.facade.ProxyConfig(s.oneEntity())
+	s.state.Stub.CheckCallNames(c,
+		"ModelConfig",
+		"APIHostPortsForAgents",
+	)
+
+	expectedNoProxy := "0.1.2.3,0.1.2.4,0.1.2.5"
+
+	c.Assert(cfg.Results[0], jc.DeepEquals, params.ProxyConfigResult{
+		LegacyProxySettings: params.ProxyConfig{NoProxy: expectedNoProxy},
+		AptSources:          []params.AptSource{{Source: "ppa:juju/stable"}},
+	})
+}
+
+func (s *ProxyUpdaterSuite) TestContainerRuntimeProxyConfig(c *gc.C) {
+	s.state.SetModelConfig(coretesting.Attrs{
+		"container-runtime-proxy": true,
+	})
+	cfg := s.facade.ProxyConfig(s.oneEntity())
+	s.state.Stub.CheckCallNames(c,
+		"ModelConfig",
+		"APIHostPortsForAgents",
+	)
+
+	expectedNoProxy := "0.1.2.3,0.1.2.4,0.1.2.5"
+
+	c.Assert(cfg.Results[0], jc.DeepEquals, params.ProxyConfigResult{
+		LegacyProxySettings:   params.ProxyConfig{NoProxy: expectedNoProxy},
+		ContainerRuntimeProxy: true,
+	})
+}
+
 type stubBackend struct {
 	*testing.Stub
 
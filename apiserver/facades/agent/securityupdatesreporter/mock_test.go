@@ -0,0 +1,60 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package securityupdatesreporter_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+
+	"github.com/juju/juju/apiserver/facades/agent/securityupdatesreporter"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mockBackend struct {
+	testing.Stub
+	machines map[string]*mockMachine
+}
+
+func (b *mockBackend) WatchForModelConfigChanges() state.NotifyWatcher {
+	b.MethodCall(b, "WatchForModelConfigChanges")
+	return nil
+}
+
+func (b *mockBackend) ModelConfig() (*config.Config, error) {
+	b.MethodCall(b, "ModelConfig")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return config.New(config.UseDefaults, coretesting.FakeConfig())
+}
+
+func (b *mockBackend) Machine(id string) (securityupdatesreporter.Machine, error) {
+	b.MethodCall(b, "Machine", id)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	m, ok := b.machines[id]
+	if !ok {
+		return nil, errors.NotFoundf("machine %s", id)
+	}
+	return m, nil
+}
+
+type mockMachine struct {
+	status  status.StatusInfo
+	setCall int
+}
+
+func (m *mockMachine) Status() (status.StatusInfo, error) {
+	return m.status, nil
+}
+
+func (m *mockMachine) SetStatus(info status.StatusInfo) error {
+	m.setCall++
+	m.status = info
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package securityupdatesreporter implements the API used by the
+// security updates reporter worker, which records the package updates
+// pending on a machine, and whether it needs rebooting, in the
+// machine's status data.
+package securityupdatesreporter
+
+import (
+	"reflect"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/securityupdates"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+// Backend exposes the state functionality required by the facade.
+type Backend interface {
+	state.ModelAccessor
+
+	// Machine returns the machine with the given id.
+	Machine(id string) (Machine, error)
+}
+
+// Machine exposes the machine functionality required by the facade.
+type Machine interface {
+	Status() (status.StatusInfo, error)
+	SetStatus(status.StatusInfo) error
+}
+
+// API implements the SecurityUpdatesReporter facade.
+type API struct {
+	*common.ModelWatcher
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns a new SecurityUpdatesReporter API facade.
+func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		ModelWatcher: common.NewModelWatcher(backend, resources, authorizer),
+		backend:      backend,
+		authorizer:   authorizer,
+	}, nil
+}
+
+// SetSecurityUpdates records the latest security updates report of
+// each given machine in the machine's status data. A missing report
+// removes the one recorded.
+func (api *API) SetSecurityUpdates(args params.SetSecurityUpdatesArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.setSecurityUpdates(arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *API) setSecurityUpdates(arg params.SetSecurityUpdates) error {
+	tag, err := names.ParseMachineTag(arg.Tag)
+	if err != nil {
+		return err
+	}
+	if !api.authorizer.AuthOwner(tag) {
+		return common.ErrPerm
+	}
+	machine, err := api.backend.Machine(tag.Id())
+	if err != nil {
+		return err
+	}
+	var report *securityupdates.Report
+	if arg.Report != nil {
+		report = &securityupdates.Report{
+			Pending:        arg.Report.Pending,
+			Security:       arg.Report.Security,
+			RebootRequired: arg.Report.RebootRequired,
+			RebootPackages: arg.Report.RebootPackages,
+			Checked:        arg.Report.Checked,
+		}
+	}
+	return errors.Annotate(updateStatusData(machine, report), "updating machine status")
+}
+
+// updateStatusData records the report in the machine's status data,
+// keeping its status and message, unless it is already recorded there.
+func updateStatusData(machine Machine, report *securityupdates.Report) error {
+	info, err := machine.Status()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	current, hasCurrent := securityupdates.FromStatusData(info.Data)
+	var reportData map[string]interface{}
+	if report != nil {
+		reportData = securityupdates.StatusData(*report)
+		// Compare the reports as they are read back from the status
+		// data, so that the precision of the times doesn't matter.
+		updated, _ := securityupdates.FromStatusData(map[string]interface{}{
+			securityupdates.DataKey: reportData,
+		})
+		if hasCurrent && reflect.DeepEqual(current, updated) {
+			return nil
+		}
+	} else if _, ok := info.Data[securityupdates.DataKey]; !ok {
+		return nil
+	}
+	data := make(map[string]interface{})
+	for k, v := range info.Data {
+		data[k] = v
+	}
+	if report == nil {
+		delete(data, securityupdates.DataKey)
+	} else {
+		data[securityupdates.DataKey] = reportData
+	}
+	info.Data = data
+	return errors.Trace(machine.SetStatus(info))
+}
@@ -0,0 +1,140 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package securityupdatesreporter_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/agent/securityupdatesreporter"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/securityupdates"
+	"github.com/juju/juju/core/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SecurityUpdatesReporterSuite struct {
+	coretesting.BaseSuite
+
+	backend *mockBackend
+	machine *mockMachine
+	api     *securityupdatesreporter.API
+	since   time.Time
+	checked time.Time
+}
+
+var _ = gc.Suite(&SecurityUpdatesReporterSuite{})
+
+func (s *SecurityUpdatesReporterSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.since = time.Date(2020, 1, 3, 14, 0, 0, 0, time.UTC)
+	s.checked = time.Date(2020, 1, 4, 6, 0, 0, 0, time.UTC)
+	s.machine = &mockMachine{
+		status: status.StatusInfo{
+			Status:  status.Started,
+			Message: "running",
+			Data:    map[string]interface{}{"foo": "bar"},
+			Since:   &s.since,
+		},
+	}
+	s.backend = &mockBackend{
+		machines: map[string]*mockMachine{"0": s.machine},
+	}
+	var err error
+	s.api, err = securityupdatesreporter.NewAPI(
+		s.backend, common.NewResources(),
+		apiservertesting.FakeAuthorizer{Tag: names.NewMachineTag("0")},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SecurityUpdatesReporterSuite) TestNewAPIRequiresMachineAgent(c *gc.C) {
+	api, err := securityupdatesreporter.NewAPI(
+		s.backend, common.NewResources(),
+		apiservertesting.FakeAuthorizer{Tag: names.NewUnitTag("mysql/0")},
+	)
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *SecurityUpdatesReporterSuite) report() *params.SecurityUpdatesReport {
+	return &params.SecurityUpdatesReport{
+		Pending:        7,
+		Security:       2,
+		RebootRequired: true,
+		RebootPackages: []string{"linux-image-generic"},
+		Checked:        s.checked,
+	}
+}
+
+func (s *SecurityUpdatesReporterSuite) TestSetSecurityUpdates(c *gc.C) {
+	result, err := s.api.SetSecurityUpdates(params.SetSecurityUpdatesArgs{
+		Args: []params.SetSecurityUpdates{
+			{Tag: "machine-0", Report: s.report()},
+			{Tag: "machine-1", Report: s.report()},
+			{Tag: "unit-mysql-0", Report: s.report()},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: &params.Error{Message: `"unit-mysql-0" is not a valid machine tag`}},
+		},
+	})
+
+	// The status and message are kept.
+	c.Assert(s.machine.status.Status, gc.Equals, status.Started)
+	c.Assert(s.machine.status.Message, gc.Equals, "running")
+	c.Assert(s.machine.status.Data["foo"], gc.Equals, "bar")
+	report, ok := securityupdates.FromStatusData(s.machine.status.Data)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(report, jc.DeepEquals, securityupdates.Report{
+		Pending:        7,
+		Security:       2,
+		RebootRequired: true,
+		RebootPackages: []string{"linux-image-generic"},
+		Checked:        s.checked,
+	})
+	c.Assert(s.machine.setCall, gc.Equals, 1)
+}
+
+func (s *SecurityUpdatesReporterSuite) TestSetSecurityUpdatesUnchanged(c *gc.C) {
+	args := params.SetSecurityUpdatesArgs{
+		Args: []params.SetSecurityUpdates{{Tag: "machine-0", Report: s.report()}},
+	}
+	for i := 0; i < 2; i++ {
+		result, err := s.api.SetSecurityUpdates(args)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result.OneError(), jc.ErrorIsNil)
+	}
+	c.Assert(s.machine.setCall, gc.Equals, 1)
+}
+
+func (s *SecurityUpdatesReporterSuite) TestSetSecurityUpdatesRemovesReport(c *gc.C) {
+	result, err := s.api.SetSecurityUpdates(params.SetSecurityUpdatesArgs{
+		Args: []params.SetSecurityUpdates{{Tag: "machine-0", Report: s.report()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+
+	args := params.SetSecurityUpdatesArgs{
+		Args: []params.SetSecurityUpdates{{Tag: "machine-0"}},
+	}
+	for i := 0; i < 2; i++ {
+		result, err = s.api.SetSecurityUpdates(args)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result.OneError(), jc.ErrorIsNil)
+	}
+	_, ok := securityupdates.FromStatusData(s.machine.status.Data)
+	c.Assert(ok, jc.IsFalse)
+	c.Assert(s.machine.status.Data, jc.DeepEquals, map[string]interface{}{"foo": "bar"})
+	c.Assert(s.machine.setCall, gc.Equals, 2)
+}
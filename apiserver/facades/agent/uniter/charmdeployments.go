@@ -0,0 +1,134 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// StartCharmDeployment reports, for each given unit, whether it may
+// start installing or upgrading its charm, as limited by the model's
+// max-charm-deployments.
+func (u *UniterAPI) StartCharmDeployment(args params.Entities) (params.BoolResults, error) {
+	result := params.BoolResults{
+		Results: make([]params.BoolResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.BoolResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			var unit *state.Unit
+			unit, err = u.getUnit(tag)
+			if err == nil {
+				result.Results[i].Result, err = unit.StartCharmDeployment()
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// FinishCharmDeployment records that each given unit has finished
+// installing or upgrading its charm.
+func (u *UniterAPI) FinishCharmDeployment(args params.Entities) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		if canAccess(tag) {
+			var unit *state.Unit
+			unit, err = u.getUnit(tag)
+			if err == nil {
+				err = unit.FinishCharmDeployment()
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// WatchCharmDeployments returns a NotifyWatcher for observing the
+// units of each given unit's application starting and finishing
+// deploying its charm, and the model's config changing.
+func (u *UniterAPI) WatchCharmDeployments(args params.Entities) (params.NotifyWatchResults, error) {
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.NotifyWatchResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		err = common.ErrPerm
+		watcherId := ""
+		if canAccess(tag) {
+			watcherId, err = u.watchOneCharmDeployments(tag)
+		}
+		result.Results[i].NotifyWatcherId = watcherId
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) watchOneCharmDeployments(tag names.UnitTag) (string, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return "", err
+	}
+	app, err := unit.Application()
+	if err != nil {
+		return "", err
+	}
+	watch := app.WatchCharmDeployments()
+	// Consume the initial event. Technically, API
+	// calls to Watch 'transmit' the initial event
+	// in the Watch response. But NotifyWatchers
+	// have no state to transmit.
+	if _, ok := <-watch.Changes(); ok {
+		return u.resources.Register(watch), nil
+	}
+	return "", watcher.EnsureErr(watch)
+}
+
+// Mask the charm deployment methods from the v13 API. The API
+// reflection code in rpc/rpcreflect/type.go:newMethod skips 2-argument
+// methods, so this removes the methods as far as the RPC machinery is
+// concerned.
+
+// StartCharmDeployment isn't on the v13 API.
+func (u *UniterAPIV13) StartCharmDeployment(_, _ struct{}) {}
+
+// FinishCharmDeployment isn't on the v13 API.
+func (u *UniterAPIV13) FinishCharmDeployment(_, _ struct{}) {}
+
+// WatchCharmDeployments isn't on the v13 API.
+func (u *UniterAPIV13) WatchCharmDeployments(_, _ struct{}) {}
@@ -0,0 +1,112 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/caas"
+	corewatcher "github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+)
+
+// WatchKubernetesEvents returns a NotifyWatcher for observing the
+// Kubernetes events of each given unit's pod and of its application's
+// service. It is only supported in Kubernetes models.
+func (u *UniterAPI) WatchKubernetesEvents(args params.Entities) (params.NotifyWatchResults, error) {
+	result := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.NotifyWatchResults{}, err
+	}
+	var broker caas.Broker
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if u.m.Type() != state.ModelTypeCAAS {
+			result.Results[i].Error = common.ServerError(
+				errors.NotSupportedf("watching kubernetes events in a %s model", u.m.Type()),
+			)
+			continue
+		}
+		if broker == nil {
+			if broker, err = u.caasBroker(); err != nil {
+				return params.NotifyWatchResults{}, errors.Trace(err)
+			}
+		}
+		watcherId, err := u.watchOneKubernetesEvents(broker, tag)
+		result.Results[i].NotifyWatcherId = watcherId
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) watchOneKubernetesEvents(broker caas.Broker, tag names.UnitTag) (string, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return "", err
+	}
+	providerID, err := u.getProviderID(unit)
+	if errors.IsNotFound(err) {
+		return "", errors.NotProvisionedf("pod for unit %q", tag.Id())
+	} else if err != nil {
+		return "", err
+	}
+	w, err := broker.WatchUnitEvents(unit.ApplicationName(), providerID)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	watch := kubernetesEventsWatcher{w}
+	// Consume the initial event. Technically, API
+	// calls to Watch 'transmit' the initial event
+	// in the Watch response. But NotifyWatchers
+	// have no state to transmit.
+	if _, ok := <-watch.Changes(); ok {
+		return u.resources.Register(watch), nil
+	}
+	return "", errors.Trace(worker.Stop(watch))
+}
+
+// caasBroker returns a broker for the Kubernetes cluster hosting the
+// uniter's model.
+func (u *UniterAPI) caasBroker() (caas.Broker, error) {
+	newBroker := u.containerBrokerFunc
+	if newBroker == nil {
+		newBroker = caas.New
+	}
+	return stateenvirons.GetNewCAASBrokerFunc(newBroker)(u.st)
+}
+
+// kubernetesEventsWatcher adapts a broker's watcher so that it can be
+// registered as an API resource.
+type kubernetesEventsWatcher struct {
+	corewatcher.NotifyWatcher
+}
+
+// Changes is part of the cache.NotifyWatcher interface.
+func (w kubernetesEventsWatcher) Changes() <-chan struct{} {
+	return w.NotifyWatcher.Changes()
+}
+
+// Stop is part of the cache.NotifyWatcher interface.
+func (w kubernetesEventsWatcher) Stop() error {
+	return worker.Stop(w.NotifyWatcher)
+}
+
+// WatchKubernetesEvents isn't on the v17 API.
+func (u *UniterAPIV17) WatchKubernetesEvents(_, _ struct{}) {}
@@ -5,6 +5,7 @@ package uniter
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/common/networkingcommon"
+	"github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/apiserver/params"
 	corenetwork "github.com/juju/juju/core/network"
 )
@@ -52,12 +54,23 @@ type NetworkInfo struct {
 	defaultEgress []string
 	bindings      map[string]string
 	spaces        []*state.Space
+
+	// addressPreference is the application's preference for the
+	// address advertised to related units.
+	addressPreference corenetwork.UnitAddressPreference
+
+	// machineInfos caches the network info of the unit's machine
+	// for each space it has been read for.
+	machineInfos map[string]state.MachineNetworkInfoResult
 }
 
 // NewNetworkInfo initialises and returns a new NetworkInfo based on the input
 // state and unit tag.
 func NewNetworkInfo(st *state.State, tag names.UnitTag) (*NetworkInfo, error) {
-	netInfo := &NetworkInfo{st: st}
+	netInfo := &NetworkInfo{
+		st:           st,
+		machineInfos: make(map[string]state.MachineNetworkInfoResult),
+	}
 	err := netInfo.init(tag)
 	return netInfo, errors.Trace(err)
 }
@@ -81,6 +94,12 @@ func (n *NetworkInfo) init(tag names.UnitTag) error {
 	}
 	n.bindings = bindings.Map()
 
+	appConfig, err := n.app.ApplicationConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	n.addressPreference = application.PrimaryAddressPreference(appConfig)
+
 	if n.defaultEgress, err = n.getModelEgressSubnets(); err != nil {
 		return errors.Trace(err)
 	}
@@ -161,9 +180,6 @@ func (n *NetworkInfo) ProcessAPIRequest(args params.NetworkInfoParams) (params.N
 
 	if n.unit.ShouldBeAssigned() {
 		var err error
-		// TODO (manadart 2019-09-10): This looks like it might be called
-		// twice in some cases - getRelationNetworkInfo (called above)
-		// calls NetworksForRelation, which also calls this method.
 		if networkInfos, err = n.machineNetworkInfos(spaces.Values()...); err != nil {
 			return params.NetworkInfoResults{}, err
 		}
@@ -218,6 +234,10 @@ func (n *NetworkInfo) ProcessAPIRequest(args params.NetworkInfoParams) (params.N
 		if len(info.IngressAddresses) == 0 {
 			ingress := spaceAddressesFromNetworkInfo(networkInfos[space].NetworkInfos)
 			corenetwork.SortAddresses(ingress)
+			ingress, err := n.preferPrimaryAddress(ingress)
+			if err != nil {
+				return result, errors.Trace(err)
+			}
 			info.IngressAddresses = make([]string, len(ingress))
 			for i, addr := range ingress {
 				info.IngressAddresses[i] = addr.Value
@@ -255,23 +275,98 @@ func (n *NetworkInfo) getRelationNetworkInfo(
 		return "", "", nil, nil, errors.Trace(err)
 	}
 
-	pollPublic := n.unit.ShouldBeAssigned()
+	pollPublic, err := n.pollPublicAddress()
+	if err != nil {
+		return "", "", nil, nil, errors.Trace(err)
+	}
+
+	space, ingress, egress, err := n.NetworksForRelation(endpoint.Name, rel, pollPublic)
+	return endpoint.Name, space, ingress, egress, errors.Trace(err)
+}
+
+// pollPublicAddress returns whether the unit's public address should be
+// waited for if it isn't yet known.
+func (n *NetworkInfo) pollPublicAddress() (bool, error) {
+	if n.unit.ShouldBeAssigned() {
+		return true, nil
+	}
 	// For k8s services which may have a public
 	// address, we want to poll in case it's not ready yet.
-	if !pollPublic {
-		cfg, err := n.app.ApplicationConfig()
+	cfg, err := n.app.ApplicationConfig()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	svcType := cfg.GetString(k8sprovider.ServiceTypeConfigKey, "")
+	switch k8score.ServiceType(svcType) {
+	case k8score.ServiceTypeLoadBalancer, k8score.ServiceTypeExternalName:
+		return true, nil
+	}
+	return false, nil
+}
+
+// RelationsNetworkInfo returns the network information of each of the
+// relations of the unit's application on the given endpoints, or on all
+// its endpoints if none are given, ordered by relation ID. An error
+// resolving the addresses of one relation is returned with it, rather
+// than failing them all.
+func (n *NetworkInfo) RelationsNetworkInfo(endpoints []string) ([]params.RelationNetworkInfo, error) {
+	relations, err := n.app.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	pollPublic, err := n.pollPublicAddress()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	spaceNames := make(map[string]string)
+	for _, space := range n.spaces {
+		spaceNames[space.Id()] = space.Name()
+	}
+
+	wanted := set.NewStrings(endpoints...)
+	var result []params.RelationNetworkInfo
+	for _, rel := range relations {
+		endpoint, err := rel.Endpoint(n.app.Name())
 		if err != nil {
-			return "", "", nil, nil, errors.Trace(err)
+			return nil, errors.Trace(err)
 		}
-		svcType := cfg.GetString(k8sprovider.ServiceTypeConfigKey, "")
-		switch k8score.ServiceType(svcType) {
-		case k8score.ServiceTypeLoadBalancer, k8score.ServiceTypeExternalName:
-			pollPublic = true
+		if !wanted.IsEmpty() && !wanted.Contains(endpoint.Name) {
+			continue
+		}
+		info := params.RelationNetworkInfo{
+			RelationId: rel.Id(),
+			Endpoint:   endpoint.Name,
+		}
+		related, err := rel.RelatedEndpoints(n.app.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(related) > 0 {
+			info.RemoteApplication = related[0].ApplicationName
+		}
+		_, info.CrossModel, err = rel.RemoteApplication()
+		if err != nil {
+			return nil, errors.Trace(err)
 		}
-	}
 
-	space, ingress, egress, err := n.NetworksForRelation(endpoint.Name, rel, pollPublic)
-	return endpoint.Name, space, ingress, egress, errors.Trace(err)
+		space, ingress, egress, err := n.NetworksForRelation(endpoint.Name, rel, pollPublic)
+		if err != nil {
+			info.Error = common.ServerError(err)
+			result = append(result, info)
+			continue
+		}
+		info.Space = spaceNames[space]
+		info.IngressAddresses = make([]string, len(ingress))
+		for i, addr := range ingress {
+			info.IngressAddresses[i] = addr.Value
+		}
+		info.EgressSubnets = egress
+		result = append(result, info)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].RelationId < result[j].RelationId
+	})
+	return result, nil
 }
 
 // NetworksForRelation returns the ingress and egress addresses for
@@ -358,6 +453,9 @@ func (n *NetworkInfo) NetworksForRelation(
 	}
 
 	corenetwork.SortAddresses(ingress)
+	if ingress, err = n.preferPrimaryAddress(ingress); err != nil {
+		return "", nil, nil, errors.Trace(err)
+	}
 
 	// If no egress subnets defined, We default to the ingress address.
 	if len(egress) == 0 && len(ingress) > 0 {
@@ -369,22 +467,69 @@ func (n *NetworkInfo) NetworksForRelation(
 	return boundSpace, ingress, egress, nil
 }
 
+// preferPrimaryAddress moves the unit's primary address, as chosen by
+// the application's primary-address config, to the front of the given
+// ingress addresses so that it is the one advertised to related units.
+// The ingress addresses are already those of the space the endpoint is
+// bound to, so only the public and fqdn preferences change them.
+func (n *NetworkInfo) preferPrimaryAddress(ingress corenetwork.SpaceAddresses) (corenetwork.SpaceAddresses, error) {
+	switch n.addressPreference {
+	case corenetwork.PreferPublicAddress, corenetwork.PreferFQDNAddress:
+	default:
+		return ingress, nil
+	}
+	if !n.unit.ShouldBeAssigned() {
+		return ingress, nil
+	}
+	primary, err := n.unit.PrimaryAddress(n.addressPreference)
+	if network.IsNoAddressError(err) {
+		return ingress, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := corenetwork.SpaceAddresses{primary}
+	for _, addr := range ingress {
+		if addr.Value != primary.Value {
+			result = append(result, addr)
+		}
+	}
+	return result, nil
+}
+
 // machineNetworkInfos returns network info for the unit's machine based on
-// devices with addresses in the input spaces.
+// devices with addresses in the input spaces. The info for each space is
+// only read once, however many relations are bound to it.
 // TODO (manadart 2019-10-10): `GetNetworkInfoForSpaces` is only used here and
 // could be relocated from the state package, reducing cross-cutting concerns
 // there.
 func (n *NetworkInfo) machineNetworkInfos(spaces ...string) (map[string]state.MachineNetworkInfoResult, error) {
-	machineID, err := n.unit.AssignedMachineId()
-	if err != nil {
-		return nil, err
+	unread := set.NewStrings()
+	for _, space := range spaces {
+		if _, ok := n.machineInfos[space]; !ok {
+			unread.Add(space)
+		}
 	}
-	machine, err := n.st.Machine(machineID)
-	if err != nil {
-		return nil, err
+	if !unread.IsEmpty() {
+		machineID, err := n.unit.AssignedMachineId()
+		if err != nil {
+			return nil, err
+		}
+		machine, err := n.st.Machine(machineID)
+		if err != nil {
+			return nil, err
+		}
+		for space, info := range machine.GetNetworkInfoForSpaces(unread) {
+			n.machineInfos[space] = info
+		}
 	}
 
-	return machine.GetNetworkInfoForSpaces(set.NewStrings(spaces...)), nil
+	result := make(map[string]state.MachineNetworkInfoResult)
+	for _, space := range spaces {
+		if info, ok := n.machineInfos[space]; ok {
+			result[space] = info
+		}
+	}
+	return result, nil
 }
 
 // spaceForBinding returns the space id
@@ -17,6 +17,7 @@ import (
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/apiserver/facades/agent/uniter"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/state"
@@ -307,6 +308,64 @@ func (s *networkInfoSuite) TestNetworksForRelationCAASModel(c *gc.C) {
 	c.Assert(egress, gc.DeepEquals, []string{"1.2.3.4/32"})
 }
 
+func (s *networkInfoSuite) TestRelationsNetworkInfo(c *gc.C) {
+	prr := s.newRemoteProReqRelation(c)
+	err := prr.ru0.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := prr.ru0.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(id)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.SetProviderAddresses(
+		network.NewScopedSpaceAddress("1.2.3.4", network.ScopeCloudLocal),
+		network.NewScopedSpaceAddress("4.3.2.1", network.ScopePublic),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = state.NewRelationEgressNetworks(s.State).Save(prr.rel.Tag().Id(), false, []string{"10.0.0.0/16"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	infos, err := s.newNetworkInfo(c, prr.ru0.UnitTag()).RelationsNetworkInfo(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infos, jc.DeepEquals, []params.RelationNetworkInfo{{
+		RelationId:        prr.rel.Id(),
+		Endpoint:          "db",
+		RemoteApplication: "mysql",
+		CrossModel:        true,
+		Space:             network.AlphaSpaceName,
+		IngressAddresses:  []string{"4.3.2.1"},
+		EgressSubnets:     []string{"10.0.0.0/16"},
+	}})
+}
+
+func (s *networkInfoSuite) TestRelationsNetworkInfoEndpoints(c *gc.C) {
+	prr := s.newProReqRelation(c, charm.ScopeGlobal)
+	err := prr.ru0.AssignToNewMachine()
+	c.Assert(err, jc.ErrorIsNil)
+	id, err := prr.ru0.AssignedMachineId()
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.Machine(id)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetProviderAddresses(network.NewScopedSpaceAddress("1.2.3.4", network.ScopeCloudLocal))
+	c.Assert(err, jc.ErrorIsNil)
+
+	netInfo := s.newNetworkInfo(c, prr.ru0.UnitTag())
+	infos, err := netInfo.RelationsNetworkInfo([]string{"db"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infos, jc.DeepEquals, []params.RelationNetworkInfo{{
+		RelationId:        prr.rel.Id(),
+		Endpoint:          "db",
+		RemoteApplication: "mysql",
+		Space:             network.AlphaSpaceName,
+		IngressAddresses:  []string{"1.2.3.4"},
+		EgressSubnets:     []string{"1.2.3.4/32"},
+	}})
+
+	infos, err = netInfo.RelationsNetworkInfo([]string{"cache"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infos, gc.HasLen, 0)
+}
+
 func (s *networkInfoSuite) newNetworkInfo(c *gc.C, tag names.UnitTag) *uniter.NetworkInfo {
 	ni, err := uniter.NewNetworkInfo(s.State, tag)
 	c.Assert(err, jc.ErrorIsNil)
@@ -67,6 +67,14 @@ func (s *StatusAPI) SetApplicationStatus(args params.SetStatus) (params.ErrorRes
 	return s.applicationSetter.SetStatus(args)
 }
 
+// BulkSetStatus sets the statuses of the application and of its units
+// for each of the given units which is the leader of its application,
+// in a single call. The statuses are only set while the unit remains
+// the leader.
+func (s *StatusAPI) BulkSetStatus(args params.BulkSetStatusArgs) (params.BulkSetStatusResults, error) {
+	return s.applicationSetter.BulkSetStatus(args)
+}
+
 // UnitStatus returns the workload status information for the unit.
 func (s *StatusAPI) UnitStatus(args params.Entities) (params.StatusResults, error) {
 	return s.unitGetter.Status(args)
@@ -0,0 +1,155 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/status"
+)
+
+// UnitStatusHistory returns the workload or juju-unit status history
+// of each of the given units, newest first. A unit may only read its
+// own history.
+func (u *UniterAPI) UnitStatusHistory(args params.StatusHistoryRequests) (params.StatusHistoryResults, error) {
+	result := params.StatusHistoryResults{
+		Results: make([]params.StatusHistoryResult, len(args.Requests)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.StatusHistoryResults{}, errors.Trace(err)
+	}
+	for i, request := range args.Requests {
+		statuses, err := u.oneUnitStatusHistory(canAccess, request)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].History.Statuses = statuses
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) oneUnitStatusHistory(canAccess common.AuthFunc, request params.StatusHistoryRequest) ([]params.DetailedStatus, error) {
+	tag, err := names.ParseUnitTag(request.Tag)
+	if err != nil {
+		return nil, common.ErrPerm
+	}
+	if !canAccess(tag) {
+		return nil, common.ErrPerm
+	}
+	filter := status.StatusHistoryFilter{
+		Size:     request.Filter.Size,
+		FromDate: request.Filter.Date,
+		Delta:    request.Filter.Delta,
+		Exclude:  set.NewStrings(request.Filter.Exclude...),
+		Until:    request.Filter.Until,
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, errors.Annotate(err, "cannot validate status history filter")
+	}
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	kind := status.HistoryKind(request.Kind)
+	var history []status.StatusInfo
+	switch kind {
+	case status.KindWorkload:
+		history, err = unit.StatusHistory(filter)
+	case status.KindUnitAgent:
+		history, err = unit.AgentHistory().StatusHistory(filter)
+	default:
+		return nil, errors.NotValidf("unit status history kind %q", kind)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	statuses := make([]params.DetailedStatus, len(history))
+	for i, h := range history {
+		statuses[i] = params.DetailedStatus{
+			Status:     h.Status.String(),
+			Info:       h.Message,
+			Data:       h.Data,
+			Since:      h.Since,
+			Kind:       string(kind),
+			FirstSince: h.FirstSince,
+			Count:      h.Count,
+		}
+	}
+	return statuses, nil
+}
+
+// PeerUnitStatuses returns the workload statuses of the other units of
+// each given unit's application, which any unit of the application may
+// read, unlike the application status.
+func (u *UniterAPI) PeerUnitStatuses(args params.Entities) (params.PeerUnitStatusResults, error) {
+	result := params.PeerUnitStatusResults{
+		Results: make([]params.PeerUnitStatusResult, len(args.Entities)),
+	}
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.PeerUnitStatusResults{}, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseUnitTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		if !canAccess(tag) {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		units, err := u.onePeerUnitStatuses(tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Units = units
+	}
+	return result, nil
+}
+
+func (u *UniterAPI) onePeerUnitStatuses(tag names.UnitTag) ([]params.StatusResult, error) {
+	unit, err := u.getUnit(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	application, err := unit.Application()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	peers, err := application.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results []params.StatusResult
+	for _, peer := range peers {
+		if peer.Name() == unit.Name() {
+			continue
+		}
+		info, err := peer.Status()
+		if errors.IsNotFound(err) {
+			// The unit has been removed.
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		results = append(results, params.StatusResult{
+			Id:     peer.Name(),
+			Life:   life.Value(peer.Life().String()),
+			Status: info.Status.String(),
+			Info:   info.Message,
+			Data:   info.Data,
+			Since:  info.Since,
+		})
+	}
+	return results, nil
+}
@@ -27,7 +27,9 @@ import (
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/life"
 	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
@@ -36,8 +38,8 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.uniter")
 
-// UniterAPI implements the latest version (v13) of the Uniter API,
-// which adds UpdateNetworkInfo.
+// UniterAPI implements the latest version (v18) of the Uniter API,
+// which adds WatchKubernetesEvents.
 type UniterAPI struct {
 	*common.LifeGetter
 	*StatusAPI
@@ -74,13 +76,43 @@ type UniterAPI struct {
 	cloudSpec       cloudspec.CloudSpecAPI
 }
 
+// UniterAPIV17 implements version (v17) of the Uniter API, which adds
+// RelationsNetworkInfo.
+type UniterAPIV17 struct {
+	UniterAPI
+}
+
+// UniterAPIV16 implements version (v16) of the Uniter API, which adds
+// UnitStatusHistory and PeerUnitStatuses.
+type UniterAPIV16 struct {
+	UniterAPIV17
+}
+
+// UniterAPIV15 implements version (v15) of the Uniter API, which adds
+// BulkSetStatus.
+type UniterAPIV15 struct {
+	UniterAPIV16
+}
+
+// UniterAPIV14 implements version (v14) of the Uniter API, which adds
+// StartCharmDeployment, FinishCharmDeployment and WatchCharmDeployments.
+type UniterAPIV14 struct {
+	UniterAPIV15
+}
+
+// UniterAPIV13 implements version (v13) of the Uniter API, which adds
+// UpdateNetworkInfo.
+type UniterAPIV13 struct {
+	UniterAPIV14
+}
+
 // UniterAPIV12 implements version (v12) of the Uniter API,
 // Removes the embedded LXDProfileAPI, which in turn removes the following;
 // RemoveUpgradeCharmProfileData, WatchUnitLXDProfileUpgradeNotifications
 // and WatchLXDProfileUpgradeNotifications
 type UniterAPIV12 struct {
 	*LXDProfileAPI
-	UniterAPI
+	UniterAPIV13
 }
 
 // UniterAPIV11 implements version (v11) of the Uniter API, which adds
@@ -222,9 +254,64 @@ func NewUniterAPI(context facade.Context) (*UniterAPI, error) {
 	}, nil
 }
 
+// NewUniterAPIV17 creates an instance of the V17 uniter API.
+func NewUniterAPIV17(context facade.Context) (*UniterAPIV17, error) {
+	uniterAPI, err := NewUniterAPI(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV17{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV16 creates an instance of the V16 uniter API.
+func NewUniterAPIV16(context facade.Context) (*UniterAPIV16, error) {
+	uniterAPI, err := NewUniterAPIV17(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV16{
+		UniterAPIV17: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV15 creates an instance of the V15 uniter API.
+func NewUniterAPIV15(context facade.Context) (*UniterAPIV15, error) {
+	uniterAPI, err := NewUniterAPIV16(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV15{
+		UniterAPIV16: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV14 creates an instance of the V14 uniter API.
+func NewUniterAPIV14(context facade.Context) (*UniterAPIV14, error) {
+	uniterAPI, err := NewUniterAPIV15(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV14{
+		UniterAPIV15: *uniterAPI,
+	}, nil
+}
+
+// NewUniterAPIV13 creates an instance of the V13 uniter API.
+func NewUniterAPIV13(context facade.Context) (*UniterAPIV13, error) {
+	uniterAPI, err := NewUniterAPIV14(context)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV13{
+		UniterAPIV14: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV12 creates an instance of the V12 uniter API.
 func NewUniterAPIV12(context facade.Context) (*UniterAPIV12, error) {
-	uniterAPI, err := NewUniterAPI(context)
+	uniterAPI, err := NewUniterAPIV13(context)
 	if err != nil {
 		return nil, err
 	}
@@ -234,7 +321,7 @@ func NewUniterAPIV12(context facade.Context) (*UniterAPIV12, error) {
 	accessUnit := unitAccessor(authorizer, st)
 	return &UniterAPIV12{
 		LXDProfileAPI: NewExternalLXDProfileAPI(st, resources, authorizer, accessUnit, logger),
-		UniterAPI:     *uniterAPI,
+		UniterAPIV13:  *uniterAPI,
 	}, nil
 }
 
@@ -1359,6 +1446,7 @@ func (u *UniterAPI) Refresh(args params.Entities) (params.UnitRefreshResults, er
 			if unit, err = u.getUnit(tag); err == nil {
 				result.Results[i].Life = life.Value(unit.Life().String())
 				result.Results[i].Resolved = params.ResolvedMode(unit.Resolved())
+				result.Results[i].MaintenanceMessage, result.Results[i].Maintenance = unit.Maintenance()
 
 				var err1 error
 				result.Results[i].ProviderID, err1 = u.getProviderID(unit)
@@ -1646,6 +1734,10 @@ func (u *UniterAPI) ReadRemoteSettings(args params.RelationUnitPairs) (params.Se
 // UpdateSettings persists all changes made to the local settings of
 // all given pairs of relation and unit. Keys with empty values are
 // considered a signal to delete these values.
+//
+// Changes which would grow a data bag beyond the model's
+// max-relation-data-size, or which don't match the relation data
+// schema declared by the unit's charm, are rejected.
 func (u *UniterAPI) UpdateSettings(args params.RelationUnitsSettings) (params.ErrorResults, error) {
 	result := params.ErrorResults{
 		Results: make([]params.ErrorResult, len(args.RelationUnits)),
@@ -1654,6 +1746,11 @@ func (u *UniterAPI) UpdateSettings(args params.RelationUnitsSettings) (params.Er
 	if err != nil {
 		return params.ErrorResults{}, err
 	}
+	cfg, err := u.m.ModelConfig()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	maxSize := cfg.MaxRelationDataSize()
 
 	updateOne := func(arg params.RelationUnitSettings) error {
 		unitTag, err := names.ParseUnitTag(arg.Unit)
@@ -1668,11 +1765,24 @@ func (u *UniterAPI) UpdateSettings(args params.RelationUnitsSettings) (params.Er
 		if err != nil {
 			return errors.Trace(err)
 		}
-		err = u.updateApplicationSettings(rel, unit, arg.ApplicationSettings)
+		if len(arg.Settings) == 0 && len(arg.ApplicationSettings) == 0 {
+			return nil
+		}
+		schema, err := relationSchema(rel, unit)
 		if err != nil {
 			return errors.Trace(err)
 		}
-		err = u.updateUnitSettings(relUnit, arg.Settings)
+		if err := schema.ValidateApplicationSettings(arg.ApplicationSettings); err != nil {
+			return errors.Trace(err)
+		}
+		if err := schema.ValidateUnitSettings(arg.Settings); err != nil {
+			return errors.Trace(err)
+		}
+		err = u.updateApplicationSettings(rel, unit, arg.ApplicationSettings, maxSize)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		err = u.updateUnitSettings(relUnit, arg.Settings, maxSize)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -1685,7 +1795,7 @@ func (u *UniterAPI) UpdateSettings(args params.RelationUnitsSettings) (params.Er
 	return result, nil
 }
 
-func (u *UniterAPI) updateUnitSettings(relUnit *state.RelationUnit, newSettings params.Settings) error {
+func (u *UniterAPI) updateUnitSettings(relUnit *state.RelationUnit, newSettings params.Settings, maxSize int) error {
 	if len(newSettings) == 0 {
 		return nil
 	}
@@ -1693,6 +1803,7 @@ func (u *UniterAPI) updateUnitSettings(relUnit *state.RelationUnit, newSettings
 	if err != nil {
 		return errors.Trace(err)
 	}
+	oldSize := relation.DataBagSize(settings.Map())
 	for k, v := range newSettings {
 		if v == "" {
 			settings.Delete(k)
@@ -1700,11 +1811,26 @@ func (u *UniterAPI) updateUnitSettings(relUnit *state.RelationUnit, newSettings
 			settings.Set(k, v)
 		}
 	}
-	_, err = settings.Write()
-	return errors.Trace(err)
+	if err := checkRelationDataSize(settings.Map(), oldSize, maxSize); err != nil {
+		return errors.Trace(err)
+	}
+	changes, err := settings.Write()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	changed := make(map[string]string, len(changes))
+	for _, change := range changes {
+		value := ""
+		if !change.IsDeletion() {
+			value = fmt.Sprint(change.NewValue)
+		}
+		changed[change.Key] = value
+	}
+	recordSettingsChange(relUnit.Relation(), relUnit.UnitName(), false, changed)
+	return nil
 }
 
-func (u *UniterAPI) updateApplicationSettings(rel *state.Relation, unit *state.Unit, settings params.Settings) error {
+func (u *UniterAPI) updateApplicationSettings(rel *state.Relation, unit *state.Unit, settings params.Settings, maxSize int) error {
 	if len(settings) == 0 {
 		return nil
 	}
@@ -1717,11 +1843,88 @@ func (u *UniterAPI) updateApplicationSettings(rel *state.Relation, unit *state.U
 	for k, v := range settings {
 		settingsMap[k] = v
 	}
+	current, err := rel.ApplicationSettings(application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	updated := make(map[string]interface{}, len(current))
+	for k, v := range current {
+		updated[k] = v
+	}
+	changed := make(map[string]string)
+	for k, v := range settings {
+		if v == "" {
+			if _, ok := updated[k]; ok {
+				changed[k] = ""
+			}
+			delete(updated, k)
+		} else {
+			if old, ok := updated[k]; !ok || old != v {
+				changed[k] = v
+			}
+			updated[k] = v
+		}
+	}
+	if err := checkRelationDataSize(updated, relation.DataBagSize(current), maxSize); err != nil {
+		return errors.Trace(err)
+	}
 	err = rel.UpdateApplicationSettings(application, token, settingsMap)
 	if leadership.IsNotLeaderError(err) {
 		return common.ErrPerm
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	recordSettingsChange(rel, unit.Name(), true, changed)
+	return nil
+}
+
+// recordSettingsChange records a change made by a unit to the data bags
+// of a relation in the relation's settings history. The history is only
+// kept to help with debugging, so failing to record it is not fatal.
+func recordSettingsChange(rel *state.Relation, unitName string, application bool, changed map[string]string) {
+	if err := rel.RecordSettingsChange(unitName, application, changed); err != nil {
+		logger.Warningf("%v", err)
+	}
+}
+
+// checkRelationDataSize returns an error satisfying errors.IsNotValid if
+// the updated relation data bag is larger than the maximum size. Changes
+// which don't grow a data bag are always allowed, so that charms may
+// shrink data bags which were already larger than the maximum.
+func checkRelationDataSize(updated map[string]interface{}, oldSize, maxSize int) error {
+	if maxSize == 0 {
+		return nil
+	}
+	size := relation.DataBagSize(updated)
+	if size <= maxSize || size <= oldSize {
+		return nil
+	}
+	return errors.NewNotValid(nil, fmt.Sprintf(
+		"relation data of %d bytes exceeds %s of %d bytes",
+		size, config.MaxRelationDataSizeKey, maxSize,
+	))
+}
+
+// relationSchema returns the schema which the unit's charm declares for
+// its endpoint of the relation.
+func relationSchema(rel *state.Relation, unit *state.Unit) (relation.EndpointSchema, error) {
+	ep, err := rel.Endpoint(unit.ApplicationName())
+	if err != nil {
+		return relation.EndpointSchema{}, errors.Trace(err)
+	}
+	application, err := unit.Application()
+	if err != nil {
+		return relation.EndpointSchema{}, errors.Trace(err)
+	}
+	ch, _, err := application.Charm()
+	if err != nil {
+		return relation.EndpointSchema{}, errors.Trace(err)
+	}
+	schemas, err := ch.RelationSchemas()
+	if err != nil {
+		return relation.EndpointSchema{}, errors.Trace(err)
 	}
-	return errors.Trace(err)
+	return schemas[ep.Name], nil
 }
 
 // WatchRelationUnits returns a RelationUnitsWatcher for observing
@@ -2311,6 +2514,36 @@ func (u *UniterAPI) NetworkInfo(args params.NetworkInfoParams) (params.NetworkIn
 	return netInfo.ProcessAPIRequest(args)
 }
 
+// RelationsNetworkInfo returns the ingress addresses, egress subnets
+// and bound space of each of the unit's relations on the given
+// endpoints, or on all its endpoints if none are given.
+func (u *UniterAPI) RelationsNetworkInfo(args params.RelationNetworkInfoParams) (params.RelationNetworkInfoResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.RelationNetworkInfoResults{}, err
+	}
+
+	unitTag, err := names.ParseUnitTag(args.Unit)
+	if err != nil {
+		return params.RelationNetworkInfoResults{}, err
+	}
+
+	if !canAccess(unitTag) {
+		return params.RelationNetworkInfoResults{}, common.ErrPerm
+	}
+
+	netInfo, err := NewNetworkInfo(u.st, unitTag)
+	if err != nil {
+		return params.RelationNetworkInfoResults{}, err
+	}
+
+	infos, err := netInfo.RelationsNetworkInfo(args.Endpoints)
+	if err != nil {
+		return params.RelationNetworkInfoResults{}, err
+	}
+	return params.RelationNetworkInfoResults{Results: infos}, nil
+}
+
 // WatchUnitRelations returns a StringsWatcher, for each given
 // unit, that notifies of changes to the lifecycles of relations
 // relevant to that unit. For principal units, this will be all of the
@@ -3005,6 +3238,18 @@ func (u *UniterAPI) watchOneUnitHashes(tag names.UnitTag, getWatcher func(u *sta
 	return "", nil, watcher.EnsureErr(w)
 }
 
+// BulkSetStatus isn't on the v14 API.
+func (u *UniterAPIV14) BulkSetStatus(_, _ struct{}) {}
+
+// UnitStatusHistory isn't on the v15 API.
+func (u *UniterAPIV15) UnitStatusHistory(_, _ struct{}) {}
+
+// PeerUnitStatuses isn't on the v15 API.
+func (u *UniterAPIV15) PeerUnitStatuses(_, _ struct{}) {}
+
+// RelationsNetworkInfo isn't on the v16 API.
+func (u *UniterAPIV16) RelationsNetworkInfo(_, _ struct{}) {}
+
 // CloudAPIVersion isn't on the v10 API.
 func (u *UniterAPIV10) CloudAPIVersion(_, _ struct{}) {}
 
@@ -5,6 +5,7 @@ package uniter_test
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -33,6 +34,8 @@ import (
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
+	corewatcher "github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
@@ -697,6 +700,90 @@ func (s *uniterSuite) TestClearResolved(c *gc.C) {
 	c.Assert(mode, gc.Equals, state.ResolvedNone)
 }
 
+func (s *uniterSuite) TestStartCharmDeployment(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{"max-charm-deployments": 1}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.StartCharmDeployment(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.BoolResults{
+		Results: []params.BoolResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{Result: true},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	deployments, err := s.wordpress.CharmDeployments()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(deployments, jc.DeepEquals, []string{"wordpress/0"})
+}
+
+func (s *uniterSuite) TestFinishCharmDeployment(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{"max-charm-deployments": 1}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	started, err := s.wordpressUnit.StartCharmDeployment()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(started, jc.IsTrue)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.FinishCharmDeployment(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{apiservertesting.ErrUnauthorized},
+			{nil},
+			{apiservertesting.ErrUnauthorized},
+		},
+	})
+	deployments, err := s.wordpress.CharmDeployments()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(deployments, gc.HasLen, 0)
+}
+
+func (s *uniterSuite) TestWatchCharmDeployments(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-wordpress-0"},
+		{Tag: "unit-foo-42"},
+	}}
+	result, err := s.uniter.WatchCharmDeployments(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{Error: apiservertesting.ErrUnauthorized},
+			{NotifyWatcherId: "1"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resource was registered and stop when done
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event ("returned" in
+	// the Watch call)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	err = s.Model.UpdateModelConfig(map[string]interface{}{"max-charm-deployments": 1}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.wordpressUnit.StartCharmDeployment()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *uniterSuite) TestGetPrincipal(c *gc.C) {
 	// Add a subordinate to wordpressUnit.
 	_, _, subordinate := s.addRelatedApplication(c, "wordpress", "logging", s.wordpressUnit)
@@ -2764,6 +2851,147 @@ func (s *uniterSuite) TestUpdateSettingsWithAppSettingsOnly(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestUpdateSettingsRecordsHistory(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	relUnit, err := rel.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = relUnit.EnterScope(map[string]interface{}{"some": "settings"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.LeadershipClaimer().ClaimLeadership("wordpress", "wordpress/0", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.RelationUnitsSettings{RelationUnits: []params.RelationUnitSettings{{
+		Relation: rel.Tag().String(),
+		Unit:     "unit-wordpress-0",
+		Settings: params.Settings{
+			"some":  "settings",
+			"other": "stuff",
+		},
+		ApplicationSettings: params.Settings{
+			"black midi": "ducter",
+		},
+	}}}
+	result, err := s.uniter.UpdateSettings(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{nil}},
+	})
+
+	// Only the settings which changed are recorded.
+	history, err := rel.SettingsHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Check(history[0].Unit, gc.Equals, "wordpress/0")
+	c.Check(history[0].Settings, jc.DeepEquals, map[string]string{"other": "stuff"})
+	c.Check(history[0].Application, jc.IsFalse)
+	c.Check(history[1].Unit, gc.Equals, "wordpress/0")
+	c.Check(history[1].Settings, jc.DeepEquals, map[string]string{"black midi": "ducter"})
+	c.Check(history[1].Application, jc.IsTrue)
+}
+
+func (s *uniterSuite) TestUpdateSettingsExceedsMaxRelationDataSize(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{"max-relation-data-size": 32}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	rel := s.addRelation(c, "wordpress", "mysql")
+	relUnit, err := rel.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = relUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.RelationUnitsSettings{RelationUnits: []params.RelationUnitSettings{{
+		Relation: rel.Tag().String(),
+		Unit:     "unit-wordpress-0",
+		Settings: params.Settings{"some": strings.Repeat("x", 64)},
+	}}}
+	result, err := s.uniter.UpdateSettings(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, `relation data of \d+ bytes exceeds max-relation-data-size of 32 bytes`)
+
+	readSettings, err := relUnit.ReadSettings(s.wordpressUnit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readSettings["some"], gc.IsNil)
+}
+
+func (s *uniterSuite) TestUpdateSettingsShrinksOversizedRelationData(c *gc.C) {
+	rel := s.addRelation(c, "wordpress", "mysql")
+	relUnit, err := rel.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = relUnit.EnterScope(map[string]interface{}{
+		"some":  strings.Repeat("x", 64),
+		"other": strings.Repeat("y", 64),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.UpdateModelConfig(map[string]interface{}{"max-relation-data-size": 32}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.RelationUnitsSettings{RelationUnits: []params.RelationUnitSettings{{
+		Relation: rel.Tag().String(),
+		Unit:     "unit-wordpress-0",
+		Settings: params.Settings{"other": ""},
+	}}}
+	result, err := s.uniter.UpdateSettings(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{nil}},
+	})
+
+	readSettings, err := relUnit.ReadSettings(s.wordpressUnit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readSettings, gc.DeepEquals, map[string]interface{}{
+		"some": strings.Repeat("x", 64),
+	})
+}
+
+func (s *uniterSuite) TestUpdateSettingsValidatesRelationSchema(c *gc.C) {
+	ch := s.Factory.MakeCharm(c, &factory.CharmParams{
+		Name:            "wordpress",
+		RelationSchemas: "db:\n  unit:\n    port:\n      type: int\n",
+	})
+	err := s.wordpress.SetCharm(state.SetCharmConfig{Charm: ch})
+	c.Assert(err, jc.ErrorIsNil)
+	rel := s.addRelation(c, "wordpress", "mysql")
+	relUnit, err := rel.Unit(s.wordpressUnit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = relUnit.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i, t := range []struct {
+		settings params.Settings
+		err      string
+	}{{
+		settings: params.Settings{"port": "3306", "private-address": "10.0.0.1"},
+	}, {
+		settings: params.Settings{"port": "mysql"},
+		err:      `relation setting "port": expected int, got "mysql"`,
+	}, {
+		settings: params.Settings{"host": "wordpress"},
+		err:      `relation setting "host" not declared in schema`,
+	}} {
+		c.Logf("test %d", i)
+		args := params.RelationUnitsSettings{RelationUnits: []params.RelationUnitSettings{{
+			Relation: rel.Tag().String(),
+			Unit:     "unit-wordpress-0",
+			Settings: t.settings,
+		}}}
+		result, err := s.uniter.UpdateSettings(args)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result.Results, gc.HasLen, 1)
+		if t.err == "" {
+			c.Check(result.Results[0].Error, gc.IsNil)
+		} else {
+			c.Check(result.Results[0].Error, gc.ErrorMatches, t.err)
+		}
+	}
+
+	readSettings, err := relUnit.ReadSettings(s.wordpressUnit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(readSettings, gc.DeepEquals, map[string]interface{}{
+		"port":            "3306",
+		"private-address": "10.0.0.1",
+	})
+}
+
 func (s *uniterSuite) TestWatchRelationUnits(c *gc.C) {
 	// Add a relation between wordpress and mysql and enter scope with
 	// mysqlUnit.
@@ -3132,6 +3360,85 @@ func (s *uniterSuite) TestUnitStatus(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestUnitStatusHistory(c *gc.C) {
+	now := time.Now()
+	for i, message := range []string{"one", "two"} {
+		since := now.Add(time.Duration(i) * time.Second)
+		err := s.wordpressUnit.SetStatus(status.StatusInfo{
+			Status:  status.Maintenance,
+			Message: message,
+			Since:   &since,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	filter := params.StatusHistoryFilter{Size: 2}
+	args := params.StatusHistoryRequests{
+		Requests: []params.StatusHistoryRequest{
+			{Tag: "unit-wordpress-0", Kind: "workload", Filter: filter},
+			{Tag: "unit-wordpress-0", Kind: "machine", Filter: filter},
+			{Tag: "unit-wordpress-0", Kind: "workload"},
+			{Tag: "unit-mysql-0", Kind: "workload", Filter: filter},
+			{Tag: "machine-1", Kind: "workload", Filter: filter},
+		}}
+	result, err := s.uniter.UnitStatusHistory(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 5)
+
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	history := result.Results[0].History.Statuses
+	c.Assert(history, gc.HasLen, 2)
+	c.Check(history[0].Info, gc.Equals, "two")
+	c.Check(history[0].Kind, gc.Equals, "workload")
+	c.Check(history[1].Info, gc.Equals, "one")
+
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `unit status history kind "machine" not valid`)
+	c.Check(result.Results[2].Error, gc.ErrorMatches, "cannot validate status history filter: .*")
+	c.Check(result.Results[3].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Check(result.Results[4].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+}
+
+func (s *uniterSuite) TestPeerUnitStatuses(c *gc.C) {
+	peer := s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: s.wordpress,
+		Machine:     s.machine1,
+	})
+	now := time.Now()
+	err := peer.SetStatus(status.StatusInfo{
+		Status:  status.Blocked,
+		Message: "need a database",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.Entities{
+		Entities: []params.Entity{
+			{Tag: "unit-wordpress-0"},
+			{Tag: "unit-mysql-0"},
+			{Tag: "invalid"},
+		}}
+	result, err := s.uniter.PeerUnitStatuses(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	units := result.Results[0].Units
+	c.Assert(units, gc.HasLen, 1)
+	c.Assert(units[0].Since, gc.NotNil)
+	units[0].Since = nil
+	c.Assert(result, jc.DeepEquals, params.PeerUnitStatusResults{
+		Results: []params.PeerUnitStatusResult{
+			{Units: []params.StatusResult{{
+				Id:     peer.Name(),
+				Life:   life.Alive,
+				Status: status.Blocked.String(),
+				Info:   "need a database",
+				Data:   map[string]interface{}{},
+			}}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
 func (s *uniterSuite) TestAssignedMachine(c *gc.C) {
 	args := params.Entities{Entities: []params.Entity{
 		{Tag: "unit-mysql-0"},
@@ -3487,6 +3794,24 @@ func (s *uniterSuite) TestRefresh(c *gc.C) {
 	c.Assert(results, gc.DeepEquals, expect)
 }
 
+func (s *uniterSuite) TestRefreshMaintenance(c *gc.C) {
+	err := s.wordpressUnit.SetMaintenance("disk swap")
+	c.Assert(err, jc.ErrorIsNil)
+	args := params.Entities{
+		Entities: []params.Entity{{s.wordpressUnit.Tag().String()}},
+	}
+	results, err := s.uniter.Refresh(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.DeepEquals, params.UnitRefreshResults{
+		Results: []params.UnitRefreshResult{{
+			Life:               life.Alive,
+			Resolved:           params.ResolvedNone,
+			Maintenance:        true,
+			MaintenanceMessage: "disk swap",
+		}},
+	})
+}
+
 func (s *uniterSuite) TestRefreshNoArgs(c *gc.C) {
 	results, err := s.uniter.Refresh(params.Entities{Entities: []params.Entity{}})
 	c.Assert(err, jc.ErrorIsNil)
@@ -4677,6 +5002,77 @@ func (s *cloudSpecUniterSuite) TestCloudAPIVersion(c *gc.C) {
 	})
 }
 
+type fakeEventsBroker struct {
+	caas.Broker
+	appName    string
+	providerID string
+}
+
+func (b *fakeEventsBroker) WatchUnitEvents(appName, providerID string) (corewatcher.NotifyWatcher, error) {
+	b.appName = appName
+	b.providerID = providerID
+	ch := make(chan struct{}, 1)
+	ch <- struct{}{}
+	return watchertest.NewMockNotifyWatcher(ch), nil
+}
+
+func (s *cloudSpecUniterSuite) TestWatchKubernetesEvents(c *gc.C) {
+	_, cm, app, unit := s.setupCAASModel(c)
+	providerID := "gitlab-0"
+	err := app.UpdateUnits(&state.UpdateUnitsOperation{
+		Updates: []*state.UpdateUnitOperation{unit.UpdateOperation(state.UnitUpdateProperties{
+			ProviderId: &providerID,
+		})},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	uniterAPI := s.newUniterAPI(c, cm.State(), s.authorizer)
+	broker := &fakeEventsBroker{}
+	uniter.SetNewContainerBrokerFunc(uniterAPI, func(environs.OpenParams) (caas.Broker, error) {
+		return broker, nil
+	})
+
+	result, err := uniterAPI.WatchKubernetesEvents(params.Entities{Entities: []params.Entity{
+		{Tag: unit.Tag().String()},
+		{Tag: "unit-mysql-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{NotifyWatcherId: "1"},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+	c.Assert(broker.appName, gc.Equals, "gitlab")
+	c.Assert(broker.providerID, gc.Equals, "gitlab-0")
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+}
+
+func (s *cloudSpecUniterSuite) TestWatchKubernetesEventsNotProvisioned(c *gc.C) {
+	_, cm, _, unit := s.setupCAASModel(c)
+
+	uniterAPI := s.newUniterAPI(c, cm.State(), s.authorizer)
+	uniter.SetNewContainerBrokerFunc(uniterAPI, func(environs.OpenParams) (caas.Broker, error) {
+		return &fakeEventsBroker{}, nil
+	})
+
+	result, err := uniterAPI.WatchKubernetesEvents(params.Entities{Entities: []params.Entity{
+		{Tag: unit.Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, jc.Satisfies, params.IsCodeNotProvisioned)
+}
+
+func (s *cloudSpecUniterSuite) TestWatchKubernetesEventsIAAS(c *gc.C) {
+	result, err := s.uniter.WatchKubernetesEvents(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-wordpress-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Error, jc.Satisfies, params.IsCodeNotSupported)
+}
+
 type uniterV8Suite struct {
 	uniterSuiteBase
 	uniterV8 *uniter.UniterAPIV8
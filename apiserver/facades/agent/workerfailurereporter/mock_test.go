@@ -0,0 +1,44 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package workerfailurereporter_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+
+	"github.com/juju/juju/apiserver/facades/agent/workerfailurereporter"
+	"github.com/juju/juju/core/status"
+)
+
+type mockBackend struct {
+	testing.Stub
+	machines map[string]*mockMachine
+}
+
+func (b *mockBackend) Machine(id string) (workerfailurereporter.Machine, error) {
+	b.MethodCall(b, "Machine", id)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	m, ok := b.machines[id]
+	if !ok {
+		return nil, errors.NotFoundf("machine %s", id)
+	}
+	return m, nil
+}
+
+type mockMachine struct {
+	status  status.StatusInfo
+	setCall int
+}
+
+func (m *mockMachine) Status() (status.StatusInfo, error) {
+	return m.status, nil
+}
+
+func (m *mockMachine) SetStatus(info status.StatusInfo) error {
+	m.setCall++
+	m.status = info
+	return nil
+}
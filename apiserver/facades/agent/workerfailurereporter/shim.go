@@ -0,0 +1,32 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package workerfailurereporter
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb.
+
+// NewFacade provides the required signature for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(backendShim{st: ctx.State()}, ctx.Auth())
+}
+
+type backendShim struct {
+	st *state.State
+}
+
+// Machine is part of the Backend interface.
+func (shim backendShim) Machine(id string) (Machine, error) {
+	machine, err := shim.st.Machine(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machine, nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package workerfailurereporter implements the API used by machine
+// agents to report the failures of the workers they run. A bounded
+// history of the failures is kept in the status data of the machine's
+// agent, so that intermittent failures can be seen after the agent's
+// logs have rotated.
+package workerfailurereporter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+)
+
+// Backend exposes the state functionality required by the facade.
+type Backend interface {
+	// Machine returns the machine with the given id.
+	Machine(id string) (Machine, error)
+}
+
+// Machine exposes the machine functionality required by the facade.
+type Machine interface {
+	Status() (status.StatusInfo, error)
+	SetStatus(status.StatusInfo) error
+}
+
+// API implements the WorkerFailureReporter facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns a new WorkerFailureReporter API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthMachineAgent() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+// AddWorkerFailures adds the given worker failures to the history kept
+// in the status data of each machine's agent.
+func (api *API) AddWorkerFailures(args params.AddWorkerFailuresArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.addWorkerFailures(arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *API) addWorkerFailures(arg params.AddWorkerFailures) error {
+	tag, err := names.ParseMachineTag(arg.Tag)
+	if err != nil {
+		return err
+	}
+	if !api.authorizer.AuthOwner(tag) {
+		return common.ErrPerm
+	}
+	if len(arg.Failures) == 0 {
+		return nil
+	}
+	machine, err := api.backend.Machine(tag.Id())
+	if err != nil {
+		return err
+	}
+	failures := make([]status.WorkerFailure, len(arg.Failures))
+	for i, f := range arg.Failures {
+		failures[i] = status.WorkerFailure{
+			Worker: f.Worker,
+			Error:  f.Error,
+			Time:   f.Time,
+		}
+	}
+	return errors.Annotate(addToStatusData(machine, failures), "updating machine status")
+}
+
+// addToStatusData adds the failures to the history recorded in the
+// machine's status data, keeping its status and message.
+func addToStatusData(machine Machine, failures []status.WorkerFailure) error {
+	info, err := machine.Status()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	history := status.AppendWorkerFailures(status.WorkerFailures(info.Data), failures...)
+	data := make(map[string]interface{})
+	for k, v := range info.Data {
+		data[k] = v
+	}
+	data[status.WorkerFailuresKey] = status.WorkerFailuresData(history)
+	info.Data = data
+	return errors.Trace(machine.SetStatus(info))
+}
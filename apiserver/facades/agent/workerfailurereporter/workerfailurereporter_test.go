@@ -0,0 +1,127 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package workerfailurereporter_test
+
+import (
+	"fmt"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/facades/agent/workerfailurereporter"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type WorkerFailureReporterSuite struct {
+	coretesting.BaseSuite
+
+	backend *mockBackend
+	machine *mockMachine
+	api     *workerfailurereporter.API
+	since   time.Time
+	failed  time.Time
+}
+
+var _ = gc.Suite(&WorkerFailureReporterSuite{})
+
+func (s *WorkerFailureReporterSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.since = time.Date(2020, 1, 3, 14, 0, 0, 0, time.UTC)
+	s.failed = time.Date(2020, 1, 4, 6, 0, 0, 0, time.UTC)
+	s.machine = &mockMachine{
+		status: status.StatusInfo{
+			Status:  status.Started,
+			Message: "running",
+			Data:    map[string]interface{}{"foo": "bar"},
+			Since:   &s.since,
+		},
+	}
+	s.backend = &mockBackend{
+		machines: map[string]*mockMachine{"0": s.machine},
+	}
+	var err error
+	s.api, err = workerfailurereporter.NewAPI(
+		s.backend, apiservertesting.FakeAuthorizer{Tag: names.NewMachineTag("0")},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *WorkerFailureReporterSuite) TestNewAPIRequiresMachineAgent(c *gc.C) {
+	api, err := workerfailurereporter.NewAPI(
+		s.backend, apiservertesting.FakeAuthorizer{Tag: names.NewUnitTag("mysql/0")},
+	)
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *WorkerFailureReporterSuite) failure(worker string) params.WorkerFailure {
+	return params.WorkerFailure{
+		Worker: worker,
+		Error:  "boom",
+		Time:   s.failed,
+	}
+}
+
+func (s *WorkerFailureReporterSuite) TestAddWorkerFailures(c *gc.C) {
+	failures := []params.WorkerFailure{s.failure("disk-manager")}
+	result, err := s.api.AddWorkerFailures(params.AddWorkerFailuresArgs{
+		Args: []params.AddWorkerFailures{
+			{Tag: "machine-0", Failures: failures},
+			{Tag: "machine-1", Failures: failures},
+			{Tag: "unit-mysql-0", Failures: failures},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: &params.Error{Message: `"unit-mysql-0" is not a valid machine tag`}},
+		},
+	})
+
+	// The status and message are kept.
+	c.Assert(s.machine.status.Status, gc.Equals, status.Started)
+	c.Assert(s.machine.status.Message, gc.Equals, "running")
+	c.Assert(s.machine.status.Since, gc.Equals, &s.since)
+	c.Assert(s.machine.status.Data["foo"], gc.Equals, "bar")
+	c.Assert(status.WorkerFailures(s.machine.status.Data), jc.DeepEquals, []status.WorkerFailure{{
+		Worker: "disk-manager",
+		Error:  "boom",
+		Time:   s.failed,
+	}})
+}
+
+func (s *WorkerFailureReporterSuite) TestAddWorkerFailuresKeepsMostRecent(c *gc.C) {
+	for i := 0; i < status.MaxWorkerFailures+1; i++ {
+		result, err := s.api.AddWorkerFailures(params.AddWorkerFailuresArgs{
+			Args: []params.AddWorkerFailures{{
+				Tag:      "machine-0",
+				Failures: []params.WorkerFailure{s.failure(fmt.Sprintf("worker-%d", i))},
+			}},
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(result.OneError(), jc.ErrorIsNil)
+	}
+	failures := status.WorkerFailures(s.machine.status.Data)
+	c.Assert(failures, gc.HasLen, status.MaxWorkerFailures)
+	c.Assert(failures[0].Worker, gc.Equals, "worker-1")
+	c.Assert(failures[len(failures)-1].Worker, gc.Equals,
+		fmt.Sprintf("worker-%d", status.MaxWorkerFailures))
+}
+
+func (s *WorkerFailureReporterSuite) TestAddNoWorkerFailures(c *gc.C) {
+	result, err := s.api.AddWorkerFailures(params.AddWorkerFailuresArgs{
+		Args: []params.AddWorkerFailures{{Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	c.Assert(s.machine.setCall, gc.Equals, 0)
+	s.backend.CheckNoCalls(c)
+}
@@ -0,0 +1,259 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package agentbinaries provides the API server facade for checking
+// that the agent binaries stored by the controller have been
+// replicated to the blobstore of every controller node, and for
+// re-replicating them where they have not. An agent binary missing
+// from one node's blobstore causes upgrades to fail when the binary
+// is requested from that node.
+package agentbinaries
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state/binarystorage"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.agentbinaries")
+
+// API implements the AgentBinaries facade.
+type API struct {
+	backend Backend
+}
+
+// NewFacade creates a new AgentBinaries API facade.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	dataDir, err := extractResourceValue(ctx.Resources(), "dataDir")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineID, err := extractResourceValue(ctx.Resources(), "machineID")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info, err := mongoInfo(dataDir, machineID)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting mongo info")
+	}
+	return NewAPI(NewStateBackend(st, info), ctx.Auth())
+}
+
+// NewAPI returns a new AgentBinaries API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isControllerAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, errors.Trace(err)
+	}
+	if !isControllerAdmin {
+		return nil, common.ErrPerm
+	}
+	if !backend.IsController() {
+		return nil, errors.New("agent binaries can only be checked from the controller model")
+	}
+	return &API{backend: backend}, nil
+}
+
+func extractResourceValue(resources facade.Resources, key string) (string, error) {
+	res := resources.Get(key)
+	strRes, ok := res.(common.StringResource)
+	if !ok {
+		if res == nil {
+			strRes = ""
+		} else {
+			return "", errors.Errorf("invalid %s resource: %v", key, res)
+		}
+	}
+	return strRes.String(), nil
+}
+
+// ReplicationStatus reports, for each controller node, which of the
+// agent binaries in the controller's catalogue are held complete in
+// the node's blobstore, and which are missing.
+func (api *API) ReplicationStatus() (params.AgentBinaryReplicationResults, error) {
+	var result params.AgentBinaryReplicationResults
+	metadata, members, err := api.catalogue()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, m := range metadata {
+		result.Versions = append(result.Versions, m.Version)
+	}
+	for _, member := range members {
+		result.Results = append(result.Results, api.memberStatus(member, metadata))
+	}
+	return result, nil
+}
+
+// catalogue returns the agent binaries in the controller's catalogue
+// sorted by version, and the replica set members sorted by node.
+func (api *API) catalogue() ([]binarystorage.Metadata, []ReplicaSetMember, error) {
+	metadata, err := api.backend.AgentBinaryMetadata()
+	if err != nil {
+		return nil, nil, errors.Annotate(err, "getting agent binary metadata")
+	}
+	sort.Slice(metadata, func(i, j int) bool {
+		return metadata[i].Version < metadata[j].Version
+	})
+	members, err := api.backend.ReplicaSetMembers()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].Node < members[j].Node
+	})
+	return metadata, members, nil
+}
+
+func (api *API) memberStatus(member ReplicaSetMember, metadata []binarystorage.Metadata) params.AgentBinaryReplicationResult {
+	result := params.AgentBinaryReplicationResult{
+		Node:    member.Node,
+		Address: member.Address,
+		Primary: member.Primary,
+	}
+	blobs, release, err := api.backend.OpenMemberBlobs(member.Address)
+	if err != nil {
+		result.Error = common.ServerError(err)
+		return result
+	}
+	defer release()
+	for _, m := range metadata {
+		if err := blobs.Verify(m); err != nil {
+			logger.Debugf("agent binary %s on node %q: %v", m.Version, member.Node, err)
+			result.Missing = append(result.Missing, m.Version)
+			continue
+		}
+		result.Held = append(result.Held, m.Version)
+	}
+	return result
+}
+
+// Repair re-replicates each agent binary that is missing from any
+// controller node. The binary is copied from a node which holds it
+// complete, and rewritten through the replica set primary so that it
+// is replicated afresh to every node. Nodes that cannot be reached are
+// left alone.
+func (api *API) Repair() (params.AgentBinaryRepairResults, error) {
+	var result params.AgentBinaryRepairResults
+	metadata, members, err := api.catalogue()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	nodes := make([]params.AgentBinaryReplicationResult, len(members))
+	for i, member := range members {
+		nodes[i] = api.memberStatus(member, metadata)
+	}
+
+	for _, m := range metadata {
+		var missing, holders []params.AgentBinaryReplicationResult
+		for _, node := range nodes {
+			if node.Error != nil {
+				continue
+			}
+			if containsVersion(node.Missing, m.Version) {
+				missing = append(missing, node)
+			} else {
+				holders = append(holders, node)
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+		repair := params.AgentBinaryRepairResult{Version: m.Version}
+		for _, node := range missing {
+			repair.Nodes = append(repair.Nodes, node.Node)
+		}
+		source, err := api.rereplicate(m, holders)
+		if err != nil {
+			repair.Error = common.ServerError(err)
+		} else {
+			logger.Infof("re-replicated agent binary %s from node %q to nodes %v", m.Version, source, repair.Nodes)
+		}
+		repair.Source = source
+		result.Results = append(result.Results, repair)
+	}
+	return result, nil
+}
+
+// rereplicate copies the agent binary from the first of the holders
+// that can provide it complete, and rewrites it through the primary.
+// It returns the node the binary was copied from.
+func (api *API) rereplicate(m binarystorage.Metadata, holders []params.AgentBinaryReplicationResult) (string, error) {
+	// Prefer the primary, which is the node the binary is being
+	// written through.
+	sort.SliceStable(holders, func(i, j int) bool {
+		return holders[i].Primary && !holders[j].Primary
+	})
+	for _, holder := range holders {
+		f, err := api.copyFrom(holder.Address, m)
+		if err != nil {
+			logger.Warningf("cannot copy agent binary %s from node %q: %v", m.Version, holder.Node, err)
+			continue
+		}
+		err = api.backend.PrimaryBlobs().Rewrite(f, m)
+		f.Close()
+		os.Remove(f.Name())
+		if err != nil {
+			return holder.Node, errors.Annotatef(err, "rewriting agent binary %s", m.Version)
+		}
+		return holder.Node, nil
+	}
+	return "", errors.NotFoundf("complete copy of agent binary %s", m.Version)
+}
+
+// copyFrom copies the agent binary held by the replica set member with
+// the given address to a temporary file, checking its content, and
+// returns the file positioned at its start.
+func (api *API) copyFrom(address string, m binarystorage.Metadata) (_ *os.File, err error) {
+	blobs, release, err := api.backend.OpenMemberBlobs(address)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer release()
+	r, err := blobs.Open(m)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer r.Close()
+
+	f, err := ioutil.TempFile("", "agent-binary")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+	if err := binarystorage.CheckContent(io.TeeReader(r, f), m); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f, nil
+}
+
+func containsVersion(versions []string, version string) bool {
+	for _, v := range versions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
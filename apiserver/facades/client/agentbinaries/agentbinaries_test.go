@@ -0,0 +1,261 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentbinaries_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/agentbinaries"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state/binarystorage"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type agentBinariesSuite struct {
+	jtesting.IsolationSuite
+
+	backend *fakeBackend
+}
+
+var _ = gc.Suite(&agentBinariesSuite{})
+
+func (s *agentBinariesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &fakeBackend{
+		isController: true,
+		metadata: []binarystorage.Metadata{
+			newMetadata("2.7.1-bionic-amd64"),
+			newMetadata("2.7.0-bionic-amd64"),
+		},
+		members: []agentbinaries.ReplicaSetMember{
+			{Node: "2", Address: "10.0.0.2:37017"},
+			{Node: "0", Address: "10.0.0.0:37017", Primary: true},
+			{Node: "1", Address: "10.0.0.1:37017"},
+		},
+		blobs: map[string]*fakeBlobs{
+			"10.0.0.0:37017": newFakeBlobs("2.7.0-bionic-amd64", "2.7.1-bionic-amd64"),
+			"10.0.0.1:37017": newFakeBlobs("2.7.0-bionic-amd64", "2.7.1-bionic-amd64"),
+			"10.0.0.2:37017": newFakeBlobs("2.7.0-bionic-amd64"),
+		},
+	}
+}
+
+// content returns the fake content of the agent binary with the
+// given version.
+func content(version string) []byte {
+	return []byte("agent binary " + version)
+}
+
+func newMetadata(version string) binarystorage.Metadata {
+	data := content(version)
+	return binarystorage.Metadata{
+		Version: version,
+		Size:    int64(len(data)),
+		SHA256:  fmt.Sprintf("%x", sha256.Sum256(data)),
+	}
+}
+
+func (s *agentBinariesSuite) newAPI(c *gc.C) *agentbinaries.API {
+	api, err := agentbinaries.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("superuser-bob"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *agentBinariesSuite) TestNewAPIRequiresControllerAdmin(c *gc.C) {
+	_, err := agentbinaries.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("bob"),
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *agentBinariesSuite) TestNewAPIRequiresClient(c *gc.C) {
+	_, err := agentbinaries.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *agentBinariesSuite) TestNewAPIRequiresControllerModel(c *gc.C) {
+	s.backend.isController = false
+	_, err := agentbinaries.NewAPI(s.backend, apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("superuser-bob"),
+	})
+	c.Assert(err, gc.ErrorMatches, "agent binaries can only be checked from the controller model")
+}
+
+func (s *agentBinariesSuite) TestReplicationStatus(c *gc.C) {
+	s.backend.dialErrors = map[string]error{
+		"10.0.0.1:37017": errors.New("no route to host"),
+	}
+
+	result, err := s.newAPI(c).ReplicationStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AgentBinaryReplicationResults{
+		Versions: []string{"2.7.0-bionic-amd64", "2.7.1-bionic-amd64"},
+		Results: []params.AgentBinaryReplicationResult{{
+			Node:    "0",
+			Address: "10.0.0.0:37017",
+			Primary: true,
+			Held:    []string{"2.7.0-bionic-amd64", "2.7.1-bionic-amd64"},
+		}, {
+			Node:    "1",
+			Address: "10.0.0.1:37017",
+			Error:   &params.Error{Message: "no route to host"},
+		}, {
+			Node:    "2",
+			Address: "10.0.0.2:37017",
+			Held:    []string{"2.7.0-bionic-amd64"},
+			Missing: []string{"2.7.1-bionic-amd64"},
+		}},
+	})
+}
+
+func (s *agentBinariesSuite) TestReplicationStatusIncompleteBlob(c *gc.C) {
+	s.backend.blobs["10.0.0.1:37017"].content["2.7.0-bionic-amd64"] = []byte("agent")
+
+	result, err := s.newAPI(c).ReplicationStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results[1].Missing, jc.DeepEquals, []string{"2.7.0-bionic-amd64"})
+}
+
+func (s *agentBinariesSuite) TestRepair(c *gc.C) {
+	result, err := s.newAPI(c).Repair()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AgentBinaryRepairResults{
+		Results: []params.AgentBinaryRepairResult{{
+			Version: "2.7.1-bionic-amd64",
+			Nodes:   []string{"2"},
+			Source:  "0",
+		}},
+	})
+	c.Assert(s.backend.primary.rewritten, jc.DeepEquals, map[string][]byte{
+		"2.7.1-bionic-amd64": content("2.7.1-bionic-amd64"),
+	})
+}
+
+func (s *agentBinariesSuite) TestRepairFromSecondary(c *gc.C) {
+	s.backend.blobs["10.0.0.0:37017"] = newFakeBlobs("2.7.0-bionic-amd64")
+
+	result, err := s.newAPI(c).Repair()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AgentBinaryRepairResults{
+		Results: []params.AgentBinaryRepairResult{{
+			Version: "2.7.1-bionic-amd64",
+			Nodes:   []string{"0", "2"},
+			Source:  "1",
+		}},
+	})
+	c.Assert(s.backend.primary.rewritten, gc.HasLen, 1)
+}
+
+func (s *agentBinariesSuite) TestRepairNoCompleteCopy(c *gc.C) {
+	s.backend.blobs["10.0.0.0:37017"] = newFakeBlobs("2.7.0-bionic-amd64")
+	s.backend.blobs["10.0.0.1:37017"] = newFakeBlobs("2.7.0-bionic-amd64")
+
+	result, err := s.newAPI(c).Repair()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Assert(result.Results[0].Nodes, jc.DeepEquals, []string{"0", "1", "2"})
+	c.Assert(result.Results[0].Error, gc.ErrorMatches, "complete copy of agent binary 2.7.1-bionic-amd64 not found")
+	c.Assert(s.backend.primary.rewritten, gc.HasLen, 0)
+}
+
+func (s *agentBinariesSuite) TestRepairNothingMissing(c *gc.C) {
+	s.backend.blobs["10.0.0.2:37017"] = newFakeBlobs("2.7.0-bionic-amd64", "2.7.1-bionic-amd64")
+
+	result, err := s.newAPI(c).Repair()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 0)
+}
+
+type fakeBackend struct {
+	isController bool
+	metadata     []binarystorage.Metadata
+	members      []agentbinaries.ReplicaSetMember
+	blobs        map[string]*fakeBlobs
+	dialErrors   map[string]error
+	primary      fakeBlobs
+}
+
+func (b *fakeBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *fakeBackend) IsController() bool {
+	return b.isController
+}
+
+func (b *fakeBackend) AgentBinaryMetadata() ([]binarystorage.Metadata, error) {
+	return append([]binarystorage.Metadata(nil), b.metadata...), nil
+}
+
+func (b *fakeBackend) ReplicaSetMembers() ([]agentbinaries.ReplicaSetMember, error) {
+	return append([]agentbinaries.ReplicaSetMember(nil), b.members...), nil
+}
+
+func (b *fakeBackend) OpenMemberBlobs(address string) (agentbinaries.Blobs, func(), error) {
+	if err := b.dialErrors[address]; err != nil {
+		return nil, nil, err
+	}
+	return b.blobs[address], func() {}, nil
+}
+
+func (b *fakeBackend) PrimaryBlobs() agentbinaries.Blobs {
+	return &b.primary
+}
+
+type fakeBlobs struct {
+	content   map[string][]byte
+	rewritten map[string][]byte
+}
+
+func newFakeBlobs(versions ...string) *fakeBlobs {
+	blobs := &fakeBlobs{content: make(map[string][]byte)}
+	for _, v := range versions {
+		blobs.content[v] = content(v)
+	}
+	return blobs
+}
+
+func (f *fakeBlobs) Open(m binarystorage.Metadata) (io.ReadCloser, error) {
+	data, ok := f.content[m.Version]
+	if !ok {
+		return nil, errors.NotFoundf("agent binary %s", m.Version)
+	}
+	return ioutil.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (f *fakeBlobs) Verify(m binarystorage.Metadata) error {
+	r, err := f.Open(m)
+	if err != nil {
+		return err
+	}
+	return binarystorage.CheckContent(r, m)
+}
+
+func (f *fakeBlobs) Rewrite(r io.Reader, m binarystorage.Metadata) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	if f.rewritten == nil {
+		f.rewritten = make(map[string][]byte)
+	}
+	f.rewritten[m.Version] = data
+	return nil
+}
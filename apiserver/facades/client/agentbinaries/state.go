@@ -0,0 +1,164 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentbinaries
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/mgo.v2"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/binarystorage"
+)
+
+// jujuMachineKey is the key for the replset member tag where we
+// store the member's corresponding machine id.
+const jujuMachineKey = "juju-machine-id"
+
+// memberDialTimeout is how long to wait when connecting directly to
+// a replica set member.
+const memberDialTimeout = 30 * time.Second
+
+// Backend describes the controller state needed by the AgentBinaries
+// facade.
+type Backend interface {
+	// ControllerTag returns the tag of the controller.
+	ControllerTag() names.ControllerTag
+
+	// IsController reports whether the facade is being served for
+	// the controller model.
+	IsController() bool
+
+	// AgentBinaryMetadata returns the metadata of all agent binaries
+	// in the controller's catalogue.
+	AgentBinaryMetadata() ([]binarystorage.Metadata, error)
+
+	// ReplicaSetMembers returns the members of the controller's mongo
+	// replica set.
+	ReplicaSetMembers() ([]ReplicaSetMember, error)
+
+	// OpenMemberBlobs returns the agent binary files held by the
+	// replica set member with the given address, and a function to
+	// release the connection to the member.
+	OpenMemberBlobs(address string) (Blobs, func(), error)
+
+	// PrimaryBlobs returns the agent binary files as written through
+	// the replica set primary.
+	PrimaryBlobs() Blobs
+}
+
+// ReplicaSetMember describes a member of the controller's mongo
+// replica set.
+type ReplicaSetMember struct {
+	// Node is the ID of the controller machine hosting the member.
+	Node string
+
+	// Address is the address of the member.
+	Address string
+
+	// Primary reports whether the member is the replica set primary.
+	Primary bool
+}
+
+// Blobs describes access to the agent binary files held in a blobstore.
+type Blobs interface {
+	Open(binarystorage.Metadata) (io.ReadCloser, error)
+	Verify(binarystorage.Metadata) error
+	Rewrite(io.Reader, binarystorage.Metadata) error
+}
+
+type stateShim struct {
+	st        *state.State
+	mongoInfo *mongo.MongoInfo
+}
+
+// NewStateBackend returns a Backend backed by the given state, which
+// connects to the replica set members with the given mongo info.
+func NewStateBackend(st *state.State, mongoInfo *mongo.MongoInfo) Backend {
+	return &stateShim{st: st, mongoInfo: mongoInfo}
+}
+
+func (s *stateShim) ControllerTag() names.ControllerTag {
+	return s.st.ControllerTag()
+}
+
+func (s *stateShim) IsController() bool {
+	return s.st.IsController()
+}
+
+func (s *stateShim) AgentBinaryMetadata() ([]binarystorage.Metadata, error) {
+	storage, err := s.st.ToolsStorage()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer storage.Close()
+	return storage.AllMetadata()
+}
+
+func (s *stateShim) ReplicaSetMembers() ([]ReplicaSetMember, error) {
+	session := s.st.MongoSession().Copy()
+	defer session.Close()
+
+	members, err := replicaset.CurrentMembers(session)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get replica set members")
+	}
+	status, err := replicaset.CurrentStatus(session)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get replica set status")
+	}
+	primary := make(map[int]bool)
+	for _, member := range status.Members {
+		primary[member.Id] = member.State == replicaset.PrimaryState
+	}
+	result := make([]ReplicaSetMember, len(members))
+	for i, member := range members {
+		result[i] = ReplicaSetMember{
+			Node:    member.Tags[jujuMachineKey],
+			Address: member.Address,
+			Primary: primary[member.Id],
+		}
+	}
+	return result, nil
+}
+
+func (s *stateShim) OpenMemberBlobs(address string) (Blobs, func(), error) {
+	info := *s.mongoInfo
+	info.Addrs = []string{address}
+	session, err := mongo.DialWithInfo(info, mongo.DialOpts{
+		Timeout: memberDialTimeout,
+		Direct:  true,
+	})
+	if err != nil {
+		return nil, nil, errors.Annotatef(err, "cannot connect to replica set member %q", address)
+	}
+	// Allow reads from the member even if it is a secondary.
+	session.SetMode(mgo.Monotonic, true)
+	return s.st.AgentBinaryBlobs(session), session.Close, nil
+}
+
+func (s *stateShim) PrimaryBlobs() Blobs {
+	return s.st.AgentBinaryBlobs(s.st.MongoSession())
+}
+
+// mongoInfo reads the mongo connection info from the agent config of
+// the controller machine serving the API, as the Backups facade does.
+func mongoInfo(dataDir, machineId string) (*mongo.MongoInfo, error) {
+	path := agent.ConfigPath(dataDir, names.NewMachineTag(machineId))
+	config, err := agent.ReadConfig(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info, ok := config.MongoInfo()
+	if !ok {
+		return nil, errors.Errorf("no mongo info found in %q", path)
+	}
+	return info, nil
+}
@@ -0,0 +1,250 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package agentintrospection provides the API server facade for fetching
+// the introspection data of the machine and unit agents in a model, such
+// as their dependency engine reports and goroutines, without needing
+// access to the hosts they run on.
+package agentintrospection
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// leaseNamespaces holds the lease namespaces reported in the leases
+// section.
+var leaseNamespaces = []string{
+	lease.ApplicationLeadershipNamespace,
+	lease.SingularControllerNamespace,
+}
+
+// Backend defines the state functionality required by the
+// AgentIntrospection facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	ControllerTag() names.ControllerTag
+	ModelType() state.ModelType
+	Machine(id string) (Machine, error)
+	Unit(name string) (Unit, error)
+}
+
+// Machine describes a machine whose agents can be introspected.
+type Machine interface {
+	UnitNames() ([]string, error)
+	AddAction(name string, payload map[string]interface{}) (state.Action, error)
+}
+
+// Unit describes a unit whose agent can be introspected.
+type Unit interface {
+	AssignedMachineId() (string, error)
+}
+
+// LeaseManager describes the access to the lease manager needed by
+// the facade.
+type LeaseManager interface {
+	LeaseInspector(namespace, modelUUID string) (lease.Inspector, error)
+}
+
+// API implements the AgentIntrospection facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	leases     LeaseManager
+}
+
+// NewFacade creates a new AgentIntrospection API facade.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(stateShim{State: st, modelType: model.Type()}, ctx.Auth(), ctx)
+}
+
+// NewAPI returns a new AgentIntrospection API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer, leases LeaseManager) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+		leases:     leases,
+	}, nil
+}
+
+// checkIsAdmin checks that the user is an admin of the model, or a
+// controller superuser. The introspection data can include details of
+// any part of the model, so it isn't shown to users who can only read it.
+func (api *API) checkIsAdmin() error {
+	isSuperuser, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.backend.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if isSuperuser {
+		return nil
+	}
+	isModelAdmin, err := api.authorizer.HasPermission(permission.AdminAccess, api.backend.ModelTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if !isModelAdmin {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// Introspect fetches a section of the introspection data of each of the
+// given machine or unit agents. The leases section is answered by the
+// controller; every other section is fetched from the agent by queuing
+// a juju-introspect action on its machine, whose result holds the
+// section once it has run.
+func (api *API) Introspect(args params.AgentIntrospectArgs) (params.AgentIntrospectResults, error) {
+	result := params.AgentIntrospectResults{
+		Results: make([]params.AgentIntrospectResult, len(args.Args)),
+	}
+	if err := api.checkIsAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		r, err := api.introspect(arg)
+		if err != nil {
+			r.Error = common.ServerError(err)
+		}
+		result.Results[i] = r
+	}
+	return result, nil
+}
+
+func (api *API) introspect(arg params.AgentIntrospectArg) (params.AgentIntrospectResult, error) {
+	var result params.AgentIntrospectResult
+	tag, err := names.ParseTag(arg.Tag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if arg.Section == actions.LeasesIntrospectionSection {
+		result.Output, err = api.leasesReport(tag)
+		return result, errors.Trace(err)
+	}
+	if !isAgentSection(arg.Section) {
+		return result, errors.NotValidf("introspection section %q", arg.Section)
+	}
+	machine, err := api.agentMachine(tag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	action, err := machine.AddAction(actions.JujuIntrospectActionName, map[string]interface{}{
+		"agent":   tag.String(),
+		"section": arg.Section,
+	})
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Action = action.ActionTag().String()
+	return result, nil
+}
+
+// agentMachine returns the machine the agent with the given tag runs on.
+func (api *API) agentMachine(tag names.Tag) (Machine, error) {
+	switch tag := tag.(type) {
+	case names.MachineTag:
+		return api.backend.Machine(tag.Id())
+	case names.UnitTag:
+		if api.backend.ModelType() == state.ModelTypeCAAS {
+			return nil, errors.NotSupportedf("introspecting units in a kubernetes model")
+		}
+		unit, err := api.backend.Unit(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		machineId, err := unit.AssignedMachineId()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return api.backend.Machine(machineId)
+	}
+	return nil, errors.NotValidf("agent tag %q", tag.String())
+}
+
+// leasesReport describes the leases held by the agent with the given
+// tag. A machine agent is reported as holding the leases held by the
+// units on the machine, as well as those it holds itself.
+func (api *API) leasesReport(tag names.Tag) (string, error) {
+	holders := make(map[string]bool)
+	switch tag := tag.(type) {
+	case names.MachineTag:
+		machine, err := api.backend.Machine(tag.Id())
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		unitNames, err := machine.UnitNames()
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		holders[tag.String()] = true
+		for _, name := range unitNames {
+			holders[name] = true
+		}
+	case names.UnitTag:
+		if _, err := api.backend.Unit(tag.Id()); err != nil {
+			return "", errors.Trace(err)
+		}
+		holders[tag.Id()] = true
+	default:
+		return "", errors.NotValidf("agent tag %q", tag.String())
+	}
+
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 1, 1, ' ', 0)
+	fmt.Fprintln(tw, "Namespace\tLease\tHolder\tExpiry")
+	var found bool
+	for _, namespace := range leaseNamespaces {
+		inspector, err := api.leases.LeaseInspector(namespace, api.backend.ModelTag().Id())
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		details := inspector.Details()
+		leaseNames := make([]string, 0, len(details))
+		for name, info := range details {
+			if holders[info.Holder] {
+				leaseNames = append(leaseNames, name)
+			}
+		}
+		sort.Strings(leaseNames)
+		for _, name := range leaseNames {
+			info := details[name]
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", namespace, name, info.Holder, info.Expiry.UTC().Format(time.RFC3339))
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Sprintf("No leases held by %s.\n", names.ReadableString(tag)), nil
+	}
+	tw.Flush()
+	return buf.String(), nil
+}
+
+func isAgentSection(section string) bool {
+	for _, known := range actions.IntrospectionSections {
+		if section == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,258 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentintrospection_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/agentintrospection"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type agentIntrospectionSuite struct {
+	testing.IsolationSuite
+
+	backend *mockBackend
+	leases  *fakeLeaseManager
+}
+
+var _ = gc.Suite(&agentIntrospectionSuite{})
+
+func (s *agentIntrospectionSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		modelType: state.ModelTypeIAAS,
+		machines: map[string]*mockMachine{
+			"0": {unitNames: []string{"mysql/0", "logging/0"}},
+			"1": {unitNames: []string{"mysql/1"}},
+		},
+		units: map[string]string{
+			"mysql/0":   "0",
+			"logging/0": "0",
+			"mysql/1":   "1",
+		},
+	}
+	expiry := time.Date(2020, 4, 1, 10, 0, 0, 0, time.UTC)
+	s.leases = &fakeLeaseManager{
+		details: map[string]map[string]lease.Details{
+			lease.ApplicationLeadershipNamespace: {
+				"mysql":   {Holder: "mysql/1", Expiry: expiry},
+				"logging": {Holder: "logging/0", Expiry: expiry},
+			},
+			lease.SingularControllerNamespace: {
+				coretesting.ModelTag.Id(): {Holder: "machine-0", Expiry: expiry},
+			},
+		},
+	}
+}
+
+func (s *agentIntrospectionSuite) newAPI(c *gc.C, user string) *agentintrospection.API {
+	api, err := agentintrospection.NewAPI(
+		s.backend,
+		apiservertesting.FakeAuthorizer{Tag: names.NewUserTag(user)},
+		s.leases,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *agentIntrospectionSuite) introspect(c *gc.C, tag names.Tag, section string) params.AgentIntrospectResult {
+	results, err := s.newAPI(c, "superuser-bob").Introspect(params.AgentIntrospectArgs{
+		Args: []params.AgentIntrospectArg{{Tag: tag.String(), Section: section}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	return results.Results[0]
+}
+
+func (s *agentIntrospectionSuite) TestNewAPIRequiresClient(c *gc.C) {
+	_, err := agentintrospection.NewAPI(
+		s.backend,
+		apiservertesting.FakeAuthorizer{Tag: names.NewMachineTag("0")},
+		s.leases,
+	)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *agentIntrospectionSuite) TestIntrospectMachine(c *gc.C) {
+	result := s.introspect(c, names.NewMachineTag("1"), "depengine")
+	c.Assert(result, jc.DeepEquals, params.AgentIntrospectResult{
+		Action: "action-1",
+	})
+	c.Assert(s.backend.machines["1"].actions, jc.DeepEquals, []map[string]interface{}{{
+		"agent":   "machine-1",
+		"section": "depengine",
+	}})
+}
+
+func (s *agentIntrospectionSuite) TestIntrospectUnit(c *gc.C) {
+	result := s.introspect(c, names.NewUnitTag("logging/0"), "goroutines")
+	c.Assert(result, jc.DeepEquals, params.AgentIntrospectResult{
+		Action: "action-0",
+	})
+	c.Assert(s.backend.machines["0"].actions, jc.DeepEquals, []map[string]interface{}{{
+		"agent":   "unit-logging-0",
+		"section": "goroutines",
+	}})
+}
+
+func (s *agentIntrospectionSuite) TestIntrospectModelAdmin(c *gc.C) {
+	results, err := s.newAPI(c, "admin-"+coretesting.ModelTag.String()).Introspect(params.AgentIntrospectArgs{
+		Args: []params.AgentIntrospectArg{{Tag: "machine-0", Section: "metrics"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+}
+
+func (s *agentIntrospectionSuite) TestIntrospectPermissionDenied(c *gc.C) {
+	_, err := s.newAPI(c, "read-"+coretesting.ModelTag.String()).Introspect(params.AgentIntrospectArgs{
+		Args: []params.AgentIntrospectArg{{Tag: "machine-0", Section: "metrics"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Assert(s.backend.machines["0"].actions, gc.HasLen, 0)
+}
+
+func (s *agentIntrospectionSuite) TestIntrospectErrors(c *gc.C) {
+	results, err := s.newAPI(c, "superuser-bob").Introspect(params.AgentIntrospectArgs{
+		Args: []params.AgentIntrospectArg{
+			{Tag: "machine-0", Section: "secrets"},
+			{Tag: "application-mysql", Section: "depengine"},
+			{Tag: "unit-mysql-9", Section: "depengine"},
+			{Tag: "machine-9", Section: "leases"},
+			{Tag: "foo", Section: "depengine"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 5)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `introspection section "secrets" not valid`)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `agent tag "application-mysql" not valid`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `unit "mysql/9" not found`)
+	c.Assert(results.Results[3].Error, gc.ErrorMatches, `machine "9" not found`)
+	c.Assert(results.Results[4].Error, gc.ErrorMatches, `"foo" is not a valid tag`)
+}
+
+func (s *agentIntrospectionSuite) TestIntrospectCAASUnit(c *gc.C) {
+	s.backend.modelType = state.ModelTypeCAAS
+	result := s.introspect(c, names.NewUnitTag("mysql/0"), "depengine")
+	c.Assert(result.Error, gc.ErrorMatches, "introspecting units in a kubernetes model not supported")
+}
+
+func (s *agentIntrospectionSuite) TestIntrospectMachineLeases(c *gc.C) {
+	result := s.introspect(c, names.NewMachineTag("0"), "leases")
+	c.Assert(result, jc.DeepEquals, params.AgentIntrospectResult{
+		Output: `
+Namespace              Lease                                Holder    Expiry
+application-leadership logging                              logging/0 2020-04-01T10:00:00Z
+singular-controller    deadbeef-0bad-400d-8000-4b1d0d06f00d machine-0 2020-04-01T10:00:00Z
+`[1:],
+	})
+	c.Assert(s.backend.machines["0"].actions, gc.HasLen, 0)
+}
+
+func (s *agentIntrospectionSuite) TestIntrospectUnitLeases(c *gc.C) {
+	result := s.introspect(c, names.NewUnitTag("mysql/1"), "leases")
+	c.Assert(result, jc.DeepEquals, params.AgentIntrospectResult{
+		Output: `
+Namespace              Lease Holder  Expiry
+application-leadership mysql mysql/1 2020-04-01T10:00:00Z
+`[1:],
+	})
+}
+
+func (s *agentIntrospectionSuite) TestIntrospectNoLeases(c *gc.C) {
+	result := s.introspect(c, names.NewUnitTag("mysql/0"), "leases")
+	c.Assert(result, jc.DeepEquals, params.AgentIntrospectResult{
+		Output: "No leases held by unit mysql/0.\n",
+	})
+}
+
+type mockBackend struct {
+	modelType state.ModelType
+	machines  map[string]*mockMachine
+	units     map[string]string
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *mockBackend) ModelType() state.ModelType {
+	return b.modelType
+}
+
+func (b *mockBackend) Machine(id string) (agentintrospection.Machine, error) {
+	m, ok := b.machines[id]
+	if !ok {
+		return nil, errors.NotFoundf("machine %q", id)
+	}
+	m.id = id
+	return m, nil
+}
+
+func (b *mockBackend) Unit(name string) (agentintrospection.Unit, error) {
+	machineId, ok := b.units[name]
+	if !ok {
+		return nil, errors.NotFoundf("unit %q", name)
+	}
+	return mockUnit(machineId), nil
+}
+
+type mockMachine struct {
+	id        string
+	unitNames []string
+	actions   []map[string]interface{}
+}
+
+func (m *mockMachine) UnitNames() ([]string, error) {
+	return m.unitNames, nil
+}
+
+func (m *mockMachine) AddAction(name string, payload map[string]interface{}) (state.Action, error) {
+	m.actions = append(m.actions, payload)
+	return mockAction{tag: names.NewActionTag(m.id)}, nil
+}
+
+type mockUnit string
+
+func (u mockUnit) AssignedMachineId() (string, error) {
+	return string(u), nil
+}
+
+type mockAction struct {
+	state.Action
+	tag names.ActionTag
+}
+
+func (a mockAction) ActionTag() names.ActionTag {
+	return a.tag
+}
+
+type fakeLeaseManager struct {
+	details map[string]map[string]lease.Details
+}
+
+func (m *fakeLeaseManager) LeaseInspector(namespace, modelUUID string) (lease.Inspector, error) {
+	return fakeInspector(m.details[namespace]), nil
+}
+
+type fakeInspector map[string]lease.Details
+
+func (i fakeInspector) Details() map[string]lease.Details {
+	return i
+}
@@ -0,0 +1,56 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentintrospection
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/state"
+)
+
+type stateShim struct {
+	*state.State
+	modelType state.ModelType
+}
+
+func (s stateShim) ModelTag() names.ModelTag {
+	return names.NewModelTag(s.State.ModelUUID())
+}
+
+func (s stateShim) ModelType() state.ModelType {
+	return s.modelType
+}
+
+func (s stateShim) Machine(id string) (Machine, error) {
+	m, err := s.State.Machine(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machineShim{m}, nil
+}
+
+func (s stateShim) Unit(name string) (Unit, error) {
+	u, err := s.State.Unit(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return u, nil
+}
+
+type machineShim struct {
+	*state.Machine
+}
+
+func (m machineShim) UnitNames() ([]string, error) {
+	units, err := m.Machine.Units()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unitNames := make([]string, len(units))
+	for i, u := range units {
+		unitNames[i] = u.Name()
+	}
+	return unitNames, nil
+}
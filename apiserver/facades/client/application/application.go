@@ -29,6 +29,7 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
 	k8s "github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
@@ -88,6 +89,48 @@ type APIv10 struct {
 // The Get call also returns the current endpoint bindings while the SetCharm
 // call access a map of operator-defined bindings.
 type APIv11 struct {
+	*APIv12
+}
+
+// APIv12 provides the Application API facade for version 12.
+// It adds CheckUnitsForceRemoval.
+type APIv12 struct {
+	*APIv13
+}
+
+// APIv13 provides the Application API facade for version 13.
+// It adds DrainTimeout to DestroyRelation.
+type APIv13 struct {
+	*APIv14
+}
+
+// APIv14 provides the Application API facade for version 14.
+// It adds SetUnitsMaintenance.
+type APIv14 struct {
+	*APIv15
+}
+
+// APIv15 provides the Application API facade for version 15.
+// It adds ScalingPolicies and SetScalingPolicies.
+type APIv15 struct {
+	*APIv16
+}
+
+// APIv16 provides the Application API facade for version 16.
+// It adds RelationDetails.
+type APIv16 struct {
+	*APIv17
+}
+
+// APIv17 provides the Application API facade for version 17.
+// It adds DisplayStatus.
+type APIv17 struct {
+	*APIv18
+}
+
+// APIv18 provides the Application API facade for version 18.
+// It adds CanaryUpgrade to SetCharm.
+type APIv18 struct {
 	*APIBase
 }
 
@@ -186,13 +229,69 @@ func NewFacadeV10(ctx facade.Context) (*APIv10, error) {
 }
 
 func NewFacadeV11(ctx facade.Context) (*APIv11, error) {
-	api, err := newFacadeBase(ctx)
+	api, err := NewFacadeV12(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &APIv11{api}, nil
 }
 
+func NewFacadeV12(ctx facade.Context) (*APIv12, error) {
+	api, err := NewFacadeV13(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv12{api}, nil
+}
+
+func NewFacadeV13(ctx facade.Context) (*APIv13, error) {
+	api, err := NewFacadeV14(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv13{api}, nil
+}
+
+func NewFacadeV14(ctx facade.Context) (*APIv14, error) {
+	api, err := NewFacadeV15(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv14{api}, nil
+}
+
+func NewFacadeV15(ctx facade.Context) (*APIv15, error) {
+	api, err := NewFacadeV16(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv15{api}, nil
+}
+
+func NewFacadeV16(ctx facade.Context) (*APIv16, error) {
+	api, err := NewFacadeV17(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv16{api}, nil
+}
+
+func NewFacadeV17(ctx facade.Context) (*APIv17, error) {
+	api, err := NewFacadeV18(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv17{api}, nil
+}
+
+func NewFacadeV18(ctx facade.Context) (*APIv18, error) {
+	api, err := newFacadeBase(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv18{api}, nil
+}
+
 type caasBrokerInterface interface {
 	ValidateStorageClass(config map[string]interface{}) error
 	Version() (*version.Number, error)
@@ -422,7 +521,13 @@ func (api *APIBase) Deploy(args params.ApplicationsDeploy) (params.ErrorResults,
 
 func applicationConfigSchema(modelType state.ModelType) (environschema.Fields, schema.Defaults, error) {
 	if modelType != state.ModelTypeCAAS {
-		return trustFields, trustDefaults, nil
+		fields := make(environschema.Fields)
+		for _, extra := range []environschema.Fields{primaryAddressFields, autoscalingGroupFields} {
+			for name, field := range extra {
+				fields[name] = field
+			}
+		}
+		return AddTrustSchemaAndDefaults(fields, primaryAddressDefaults)
 	}
 	// TODO(caas) - get the schema from the provider
 	defaults := caas.ConfigDefaults(k8s.ConfigDefaults())
@@ -591,6 +696,9 @@ func deployApplication(
 	if err := checkMachinePlacement(backend, args); err != nil {
 		return errors.Trace(err)
 	}
+	if err := checkModelQuotas(backend, model.Type(), 1, args.NumUnits, args.Placement); err != nil {
+		return errors.Trace(err)
+	}
 
 	// Try to find the charm URL in state first.
 	ch, err := backend.Charm(curl)
@@ -704,6 +812,8 @@ func deployApplication(
 // If the placement scope is for a machine, ensure that the machine exists.
 // If the placement is for a machine or a container on an existing machine,
 // check that the machine is not locked for series upgrade.
+// If the placement is for an existing machine, check that the model's
+// colocation rules allow a unit of the application to be placed on it.
 func checkMachinePlacement(backend Backend, args params.ApplicationDeploy) error {
 	errTemplate := "cannot deploy %q to machine %s"
 	app := args.ApplicationName
@@ -739,11 +849,63 @@ func checkMachinePlacement(backend Backend, args params.ApplicationDeploy) error
 		if err != nil {
 			return errors.Annotatef(err, errTemplate, app, dir)
 		}
+
+		if toProvisionedMachine {
+			if err := m.CheckColocation(app); err != nil {
+				return errors.Annotatef(err, errTemplate, app, dir)
+			}
+		}
 	}
 
 	return nil
 }
 
+// checkModelQuotas returns an error satisfying state.IsQuotaExceededError
+// if adding the given numbers of applications and units would take the
+// model over one of the controller's per-model quotas. In IAAS models
+// each unit which isn't placed on an existing machine is counted as
+// needing a new machine, so the machine quota is checked conservatively.
+func checkModelQuotas(backend Backend, modelType state.ModelType, applications, units int, placement []*instance.Placement) error {
+	if err := backend.CheckModelQuota(controller.MaxModelApplications, applications); err != nil {
+		return errors.Trace(err)
+	}
+	if err := backend.CheckModelQuota(controller.MaxModelUnits, units); err != nil {
+		return errors.Trace(err)
+	}
+	if modelType != state.ModelTypeIAAS {
+		return nil
+	}
+	machines := newMachinesForUnits(units, placement)
+	return errors.Trace(backend.CheckModelQuota(controller.MaxModelMachines, machines))
+}
+
+// newMachinesForUnits returns the most machines, including containers,
+// which may be added to host the given number of units placed with the
+// given directives.
+func newMachinesForUnits(units int, placement []*instance.Placement) int {
+	machines := 0
+	for i := 0; i < units; i++ {
+		if i >= len(placement) || placement[i] == nil {
+			machines++
+			continue
+		}
+		p := placement[i]
+		switch {
+		case p.Scope == instance.MachineScope:
+			// An existing machine.
+		case p.Scope == string(instance.LXD) || p.Scope == string(instance.KVM):
+			// A new container, on a new machine unless one is given.
+			machines++
+			if p.Directive == "" {
+				machines++
+			}
+		default:
+			machines++
+		}
+	}
+	return machines
+}
+
 // applicationSetSettingsStrings updates the settings for the given application,
 // taking the configuration from a map of strings.
 func applicationSetSettingsStrings(
@@ -803,6 +965,7 @@ type setCharmParams struct {
 	ResourceIDs           map[string]string
 	StorageConstraints    map[string]params.StorageConstraints
 	EndpointBindings      map[string]string
+	CanaryUpgrade         bool
 	Force                 forceParams
 }
 
@@ -964,6 +1127,9 @@ func (api *APIBase) SetCharm(args params.ApplicationSetCharm) error {
 			return errors.Trace(err)
 		}
 	}
+	if args.CanaryUpgrade && api.modelType != state.ModelTypeCAAS {
+		return errors.NotSupportedf("canary upgrades on a non-container model")
+	}
 	oneApplication, err := api.backend.Application(args.ApplicationName)
 	if err != nil {
 		return errors.Trace(err)
@@ -979,6 +1145,7 @@ func (api *APIBase) SetCharm(args params.ApplicationSetCharm) error {
 			ResourceIDs:           args.ResourceIDs,
 			StorageConstraints:    args.StorageConstraints,
 			EndpointBindings:      args.EndpointBindings,
+			CanaryUpgrade:         args.CanaryUpgrade,
 			Force: forceParams{
 				ForceSeries: args.ForceSeries,
 				ForceUnits:  args.ForceUnits,
@@ -1082,6 +1249,7 @@ func (api *APIBase) applicationSetCharm(
 		ResourceIDs:        params.ResourceIDs,
 		StorageConstraints: stateStorageConstraints,
 		EndpointBindings:   params.EndpointBindings,
+		CanaryUpgrade:      params.CanaryUpgrade,
 	}
 	return params.Application.SetCharm(cfg)
 }
@@ -1352,6 +1520,9 @@ func addApplicationUnits(backend Backend, modelType state.ModelType, args params
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if err := checkModelQuotas(backend, modelType, 0, args.NumUnits, args.Placement); err != nil {
+		return nil, errors.Trace(err)
+	}
 	return addUnits(
 		oneApplication,
 		args.ApplicationName,
@@ -1437,6 +1608,11 @@ func (api *APIBase) DestroyUnit(args params.DestroyUnitsParams) (params.DestroyU
 		if !unit.IsPrincipal() {
 			return nil, errors.Errorf("unit %q is a subordinate", name)
 		}
+		if !arg.Force {
+			if err := api.checkMinUnits(unit); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
 		var info params.DestroyUnitInfo
 		unitStorage, err := storagecommon.UnitStorage(api.storageAccess, unit.UnitTag())
 		if err != nil {
@@ -1484,6 +1660,91 @@ func (api *APIBase) DestroyUnit(args params.DestroyUnitsParams) (params.DestroyU
 	return params.DestroyUnitResults{results}, nil
 }
 
+// checkMinUnits returns an error if removing the unit would leave its
+// application with fewer alive units than the minimum of its scaling
+// policy.
+func (api *APIBase) checkMinUnits(unit Unit) error {
+	if unit.Life() != state.Alive {
+		return nil
+	}
+	appName, err := names.UnitApplication(unit.Name())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(appName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	minUnits := app.ScalingPolicy().MinUnits
+	if minUnits == 0 {
+		return nil
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	alive := 0
+	for _, u := range units {
+		if u.Life() == state.Alive {
+			alive++
+		}
+	}
+	if alive <= minUnits {
+		return errors.Errorf(
+			"cannot remove unit %q: application %q requires at least %d units",
+			unit.Name(), appName, minUnits,
+		)
+	}
+	return nil
+}
+
+// CheckUnitsForceRemoval isn't on the v11 API.
+func (u *APIv11) CheckUnitsForceRemoval(_, _ struct{}) {}
+
+// CheckUnitsForceRemoval reports, for each of the given units, the
+// status and presence of the unit's agent, and the cleanup steps
+// normally performed by the agent which will be skipped if the unit
+// is removed with force. No units are removed.
+func (api *APIBase) CheckUnitsForceRemoval(args params.Entities) (params.UnitForceRemovalCheckResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.UnitForceRemovalCheckResults{}, errors.Trace(err)
+	}
+	results := make([]params.UnitForceRemovalCheckResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		result, err := api.checkUnitForceRemoval(entity.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i] = result
+	}
+	return params.UnitForceRemovalCheckResults{Results: results}, nil
+}
+
+func (api *APIBase) checkUnitForceRemoval(tag string) (params.UnitForceRemovalCheckResult, error) {
+	var result params.UnitForceRemovalCheckResult
+	unitTag, err := names.ParseUnitTag(tag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	unit, err := api.backend.Unit(unitTag.Id())
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	agentStatus, err := unit.AgentStatus()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.AgentStatus = agentStatus.Status.String()
+	if result.AgentAlive, err = unit.AgentPresence(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if result.SkippedSteps, err = unit.ForceDestroySkippedSteps(); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
 // Destroy destroys a given application, local or remote.
 //
 // NOTE(axw) this exists only for backwards compatibility,
@@ -1795,6 +2056,9 @@ func (api *APIBase) AddRelation(args params.AddRelation) (_ params.AddRelationRe
 	if err != nil {
 		return params.AddRelationResults{}, errors.Trace(err)
 	}
+	if err := api.backend.CheckModelQuota(controller.MaxModelRelations, 1); err != nil {
+		return params.AddRelationResults{}, errors.Trace(err)
+	}
 	if rel, err = api.backend.AddRelation(inEps...); err != nil {
 		return params.AddRelationResults{}, errors.Trace(err)
 	}
@@ -1846,9 +2110,12 @@ func (api *APIBase) DestroyRelation(args params.DestroyRelation) (err error) {
 		return err
 	}
 	force := args.Force != nil && *args.Force
-	errs, err := rel.DestroyWithForce(force, common.MaxWait(args.MaxWait))
-	if len(errs) != 0 {
-		logger.Warningf("operational errors destroying relation %v: %v", rel.Tag().Id(), errs)
+	op := rel.DestroyOperation(force)
+	op.MaxWait = common.MaxWait(args.MaxWait)
+	op.DrainTimeout = args.DrainTimeout
+	err = api.backend.ApplyOperation(op)
+	if len(op.Errors) != 0 {
+		logger.Warningf("operational errors destroying relation %v: %v", rel.Tag().Id(), op.Errors)
 	}
 	return err
 }
@@ -2367,6 +2634,219 @@ func (api *APIBase) ResolveUnitErrors(p params.UnitsResolved) (params.ErrorResul
 	return result, nil
 }
 
+// SetUnitsMaintenance isn't on the v13 API.
+func (u *APIv13) SetUnitsMaintenance(_, _ struct{}) {}
+
+// SetUnitsMaintenance puts units into maintenance, or takes them out
+// of maintenance. While a unit is in maintenance its agent runs no
+// hooks, and the unit may not be elected leader of its application.
+func (api *APIBase) SetUnitsMaintenance(args params.UnitsMaintenance) (params.ErrorResults, error) {
+	var result params.ErrorResults
+	if err := api.checkCanWrite(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	result.Results = make([]params.ErrorResult, len(args.Units))
+	for i, arg := range args.Units {
+		tag, err := names.ParseUnitTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		unit, err := api.backend.Unit(tag.Id())
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if arg.Maintenance {
+			err = unit.SetMaintenance(arg.Message)
+		} else {
+			err = unit.ClearMaintenance()
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// ScalingPolicies isn't on the v14 API.
+func (u *APIv14) ScalingPolicies(_, _ struct{}) {}
+
+// SetScalingPolicies isn't on the v14 API.
+func (u *APIv14) SetScalingPolicies(_, _ struct{}) {}
+
+// ScalingPolicies returns the scaling policies of the given applications.
+func (api *APIBase) ScalingPolicies(args params.Entities) (params.ScalingPolicyResults, error) {
+	var result params.ScalingPolicyResults
+	if err := api.checkCanRead(); err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Results = make([]params.ScalingPolicyResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		tag, err := names.ParseApplicationTag(arg.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		app, err := api.backend.Application(tag.Id())
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		policy := app.ScalingPolicy()
+		result.Results[i].Result = &params.ScalingPolicy{
+			MinUnits: policy.MinUnits,
+			MaxUnits: policy.MaxUnits,
+		}
+	}
+	return result, nil
+}
+
+// SetScalingPolicies sets the scaling policies of the given applications.
+// The controller adds units to an application with fewer alive units than
+// its minimum, and replaces units lost along with their force-destroyed
+// machines up to its maximum. Units may not be removed without force if
+// their application would be left with fewer units than its minimum.
+func (api *APIBase) SetScalingPolicies(args params.ApplicationScalingPolicies) (params.ErrorResults, error) {
+	var result params.ErrorResults
+	if api.modelType == state.ModelTypeCAAS {
+		return result, errors.NotSupportedf("scaling policies on a container model")
+	}
+	if err := api.checkCanWrite(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	result.Results = make([]params.ErrorResult, len(args.Policies))
+	for i, arg := range args.Policies {
+		tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		app, err := api.backend.Application(tag.Id())
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		err = app.SetScalingPolicy(state.ScalingPolicy{
+			MinUnits: arg.Policy.MinUnits,
+			MaxUnits: arg.Policy.MaxUnits,
+		})
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// RelationDetails isn't on the v15 API.
+func (u *APIv15) RelationDetails(_, _ struct{}) {}
+
+// RelationDetails returns the details of the given relations, along with
+// the recent changes made to their data bags if requested.
+func (api *APIBase) RelationDetails(args params.RelationDetailsArgs) (params.RelationDetailsResults, error) {
+	var result params.RelationDetailsResults
+	if err := api.checkCanRead(); err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Results = make([]params.RelationDetailsResult, len(args.Args))
+	for i, arg := range args.Args {
+		details, err := api.relationDetails(arg)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = details
+	}
+	return result, nil
+}
+
+func (api *APIBase) relationDetails(arg params.RelationDetailsArg) (*params.RelationDetails, error) {
+	rel, err := api.backend.Relation(arg.RelationId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	relStatus, err := rel.Status()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	details := &params.RelationDetails{
+		Id:      rel.Id(),
+		Key:     rel.String(),
+		Status:  string(relStatus.Status),
+		Message: relStatus.Message,
+	}
+	for _, ep := range rel.Endpoints() {
+		details.Interface = ep.Interface
+		details.Scope = string(ep.Scope)
+		details.Endpoints = append(details.Endpoints, ep.String())
+	}
+	if !arg.History {
+		return details, nil
+	}
+	history, err := rel.SettingsHistory()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, change := range history {
+		details.History = append(details.History, params.RelationSettingsChange{
+			Unit:        change.Unit,
+			Application: change.Application,
+			Settings:    change.Settings,
+			Time:        change.Time,
+		})
+	}
+	return details, nil
+}
+
+// DisplayStatus isn't on the v16 API.
+func (u *APIv16) DisplayStatus(_, _ struct{}) {}
+
+// DisplayStatus returns the statuses displayed for the given
+// applications, along with whether each was derived from the statuses
+// of the application's units.
+func (api *APIBase) DisplayStatus(args params.Entities) (params.ApplicationDisplayStatusResults, error) {
+	var result params.ApplicationDisplayStatusResults
+	if err := api.checkCanRead(); err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Results = make([]params.ApplicationDisplayStatusResult, len(args.Entities))
+	for i, entity := range args.Entities {
+		displayStatus, err := api.displayStatus(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result.Results[i].Result = displayStatus
+	}
+	return result, nil
+}
+
+func (api *APIBase) displayStatus(tag string) (*params.ApplicationDisplayStatus, error) {
+	appTag, err := names.ParseApplicationTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	app, err := api.backend.Application(appTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info, derived, err := app.DisplayStatus()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.ApplicationDisplayStatus{
+		Status:  info.Status.String(),
+		Info:    info.Message,
+		Data:    info.Data,
+		Since:   info.Since,
+		Derived: derived,
+	}, nil
+}
+
 // ApplicationInfo isn't on the v8 API.
 func (u *APIv8) ApplicationInfo(_, _ struct{}) {}
 
@@ -51,7 +51,7 @@ type applicationSuite struct {
 	apiservertesting.CharmStoreSuite
 	commontesting.BlockHelper
 
-	applicationAPI *application.APIv11
+	applicationAPI *application.APIv18
 	application    *state.Application
 	authorizer     *apiservertesting.FakeAuthorizer
 }
@@ -88,7 +88,7 @@ func (s *applicationSuite) TearDownTest(c *gc.C) {
 	s.JujuConnSuite.TearDownTest(c)
 }
 
-func (s *applicationSuite) makeAPI(c *gc.C) *application.APIv11 {
+func (s *applicationSuite) makeAPI(c *gc.C) *application.APIv18 {
 	resources := common.NewResources()
 	c.Assert(resources.RegisterNamed("dataDir", common.StringResource(c.MkDir())), jc.ErrorIsNil)
 	storageAccess, err := application.GetStorageState(s.State)
@@ -112,7 +112,7 @@ func (s *applicationSuite) makeAPI(c *gc.C) *application.APIv11 {
 		nil, // CAAS Broker not used in this suite.
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	return &application.APIv11{api}
+	return &application.APIv18{api}
 }
 
 func (s *applicationSuite) TestCharmConfig(c *gc.C) {
@@ -136,7 +136,21 @@ func (s *applicationSuite) TestCharmConfigV8(c *gc.C) {
 	api := &application.APIv8{
 		APIv9: &application.APIv9{
 			APIv10: &application.APIv10{
-				APIv11: s.applicationAPI,
+				APIv11: &application.APIv11{
+					APIv12: &application.APIv12{
+						APIv13: &application.APIv13{
+							APIv14: &application.APIv14{
+								APIv15: &application.APIv15{
+									APIv16: &application.APIv16{
+										APIv17: &application.APIv17{
+											APIv18: s.applicationAPI,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
 			},
 		},
 	}
@@ -585,6 +599,35 @@ func (s *applicationSuite) testApplicationDeployWithPlacementLockedError(
 	c.Assert(results.Results[0].Error.Error(), gc.Matches, ".* machine is locked for series upgrade")
 }
 
+func (s *applicationSuite) TestApplicationDeployToMachineBreakingColocationRules(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{"anti-colocation": "application,wordpress"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	m, err := s.BackingState.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	unit, err := wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(unit.AssignToMachine(m), jc.ErrorIsNil)
+
+	curl, _ := s.UploadCharm(c, "precise/dummy-42", "dummy")
+	err = application.AddCharmWithAuthorization(application.NewStateShim(s.State), params.AddCharmWithAuthorization{
+		URL: curl.String(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := s.applicationAPI.Deploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "application",
+			CharmURL:        curl.String(),
+			NumUnits:        1,
+			Placement:       []*instance.Placement{instance.MustParsePlacement(m.Id())},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeColocationViolation)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `cannot deploy "application" to machine [0-9]+: .* anti-colocation group "application,wordpress" conflicts with wordpress/0`)
+}
+
 func (s *applicationSuite) TestApplicationDeploymentRemovesPendingResourcesOnFailure(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy-resource")
 	resources, err := s.State.Resources()
@@ -3217,6 +3260,84 @@ func (s *applicationSuite) setupRemoteApplication(c *gc.C) {
 	c.Assert(results.OneError(), gc.IsNil)
 }
 
+func (s *applicationSuite) TestRelationDetails(c *gc.C) {
+	s.setupRelationScenario(c)
+	rel, err := s.State.KeyRelation("logging:logging-directory wordpress:logging-dir")
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.RecordSettingsChange("wordpress/0", false, map[string]string{"host": "10.0.0.1"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.RecordSettingsChange("wordpress/0", true, map[string]string{"path": ""})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.applicationAPI.RelationDetails(params.RelationDetailsArgs{
+		Args: []params.RelationDetailsArg{
+			{RelationId: rel.Id()},
+			{RelationId: rel.Id(), History: true},
+			{RelationId: 4242},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+
+	details := params.RelationDetails{
+		Id:        rel.Id(),
+		Key:       "logging:logging-directory wordpress:logging-dir",
+		Interface: "logging",
+		Scope:     "container",
+		Endpoints: []string{"logging:logging-directory", "wordpress:logging-dir"},
+		Status:    "joining",
+	}
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(*results.Results[0].Result, jc.DeepEquals, details)
+
+	c.Assert(results.Results[1].Error, gc.IsNil)
+	history := results.Results[1].Result.History
+	c.Assert(history, gc.HasLen, 2)
+	c.Check(history[0].Unit, gc.Equals, "wordpress/0")
+	c.Check(history[0].Application, jc.IsTrue)
+	c.Check(history[0].Settings, jc.DeepEquals, map[string]string{"path": ""})
+	c.Check(history[1].Application, jc.IsFalse)
+	c.Check(history[1].Settings, jc.DeepEquals, map[string]string{"host": "10.0.0.1"})
+
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `relation 4242 not found`)
+}
+
+func (s *applicationSuite) TestDisplayStatus(c *gc.C) {
+	now := time.Now()
+	wordpress := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	unit, err := wordpress.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetStatus(status.StatusInfo{Status: status.Blocked, Message: "needs a database", Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+	mysql := s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	err = mysql.SetStatus(status.StatusInfo{Status: status.Active, Message: "ready", Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.applicationAPI.DisplayStatus(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-wordpress"},
+			{Tag: "application-mysql"},
+			{Tag: "application-foo"},
+			{Tag: "machine-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[0].Result.Status, gc.Equals, "blocked")
+	c.Check(results.Results[0].Result.Info, gc.Equals, "needs a database")
+	c.Check(results.Results[0].Result.Derived, jc.IsTrue)
+
+	c.Assert(results.Results[1].Error, gc.IsNil)
+	c.Check(results.Results[1].Result.Status, gc.Equals, "active")
+	c.Check(results.Results[1].Result.Info, gc.Equals, "ready")
+	c.Check(results.Results[1].Result.Derived, jc.IsFalse)
+
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `application "foo" not found`)
+	c.Assert(results.Results[3].Error, gc.ErrorMatches, `"machine-0" is not a valid application tag`)
+}
+
 func (s *applicationSuite) TestAddRemoteRelation(c *gc.C) {
 	s.setupRemoteApplication(c)
 	// There's already a wordpress in the scenario this assertion sets up.
@@ -25,6 +25,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/caas"
 	k8s "github.com/juju/juju/caas/kubernetes/provider"
+	"github.com/juju/juju/controller"
 	coreapplication "github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
@@ -55,7 +56,7 @@ type ApplicationSuite struct {
 	env          environs.Environ
 	blockChecker mockBlockChecker
 	authorizer   apiservertesting.FakeAuthorizer
-	api          *application.APIv11
+	api          *application.APIv18
 	deployParams map[string]application.DeployApplicationParams
 }
 
@@ -85,7 +86,7 @@ func (s *ApplicationSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		s.caasBroker,
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.api = &application.APIv11{api}
+	s.api = &application.APIv18{api}
 }
 
 func (s *ApplicationSuite) SetUpTest(c *gc.C) {
@@ -329,6 +330,33 @@ func (s *ApplicationSuite) TestSetCharmConfigSettings(c *gc.C) {
 	})
 }
 
+func (s *ApplicationSuite) TestSetCharmCanaryUpgrade(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+		CanaryUpgrade:   true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "Application", "Charm")
+	app := s.backend.applications["postgresql"]
+	app.CheckCallNames(c, "SetCharm")
+	app.CheckCall(c, 0, "SetCharm", state.SetCharmConfig{
+		Charm:         &state.Charm{},
+		CanaryUpgrade: true,
+	})
+}
+
+func (s *ApplicationSuite) TestSetCharmCanaryUpgradeIAASModel(c *gc.C) {
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+		CanaryUpgrade:   true,
+	})
+	c.Assert(err, gc.ErrorMatches, "canary upgrades on a non-container model not supported")
+	s.backend.CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestSetCharmConfigSettingsYAML(c *gc.C) {
 	err := s.api.SetCharm(params.ApplicationSetCharm{
 		ApplicationName: "postgresql",
@@ -407,9 +435,9 @@ func (s *ApplicationSuite) TestDestroyRelation(c *gc.C) {
 	err := s.api.DestroyRelation(params.DestroyRelation{Endpoints: []string{"a", "b"}})
 	c.Assert(err, jc.ErrorIsNil)
 	s.blockChecker.CheckCallNames(c, "RemoveAllowed")
-	s.backend.CheckCallNames(c, "InferEndpoints", "EndpointsRelation")
+	s.backend.CheckCallNames(c, "InferEndpoints", "EndpointsRelation", "ApplyOperation")
 	s.backend.CheckCall(c, 0, "InferEndpoints", []string{"a", "b"})
-	s.relation.CheckCallNames(c, "DestroyWithForce")
+	s.relation.CheckCallNames(c, "DestroyOperation")
 }
 
 func (s *ApplicationSuite) TestDestroyRelationNoRelationsFound(c *gc.C) {
@@ -437,9 +465,27 @@ func (s *ApplicationSuite) TestDestroyRelationId(c *gc.C) {
 	err := s.api.DestroyRelation(params.DestroyRelation{RelationId: 123})
 	c.Assert(err, jc.ErrorIsNil)
 	s.blockChecker.CheckCallNames(c, "RemoveAllowed")
-	s.backend.CheckCallNames(c, "Relation")
+	s.backend.CheckCallNames(c, "Relation", "ApplyOperation")
 	s.backend.CheckCall(c, 0, "Relation", 123)
-	s.relation.CheckCallNames(c, "DestroyWithForce")
+	s.relation.CheckCallNames(c, "DestroyOperation")
+}
+
+func (s *ApplicationSuite) TestDestroyRelationDrainTimeout(c *gc.C) {
+	force := true
+	maxWait := time.Minute
+	drain := 10 * time.Minute
+	err := s.api.DestroyRelation(params.DestroyRelation{
+		RelationId:   123,
+		Force:        &force,
+		MaxWait:      &maxWait,
+		DrainTimeout: &drain,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.relation.CheckCall(c, 0, "DestroyOperation", true)
+	s.backend.CheckCallNames(c, "Relation", "ApplyOperation")
+	op := s.backend.Calls()[1].Args[0].(*state.DestroyRelationOperation)
+	c.Assert(op.MaxWait, gc.Equals, maxWait)
+	c.Assert(op.DrainTimeout, gc.DeepEquals, &drain)
 }
 
 func (s *ApplicationSuite) TestDestroyRelationIdRelationNotFound(c *gc.C) {
@@ -652,8 +698,13 @@ func (s *ApplicationSuite) assertDestroyUnit(c *gc.C, force bool, maxWait *time.
 		Info: &params.DestroyUnitInfo{},
 	}})
 
-	s.backend.CheckCallNames(c,
-		"Unit",
+	// The minimum units of the application are only checked
+	// if the unit is not being removed with force.
+	expectedCalls := []string{"Unit"}
+	if !force {
+		expectedCalls = append(expectedCalls, "Application")
+	}
+	expectedCalls = append(expectedCalls,
 		"UnitStorageAttachments",
 		"StorageInstance",
 		"StorageInstance",
@@ -662,19 +713,128 @@ func (s *ApplicationSuite) assertDestroyUnit(c *gc.C, force bool, maxWait *time.
 		"ApplyOperation",
 
 		"Unit",
+		"Application",
 		"UnitStorageAttachments",
 		"ApplyOperation",
 	)
+	s.backend.CheckCallNames(c, expectedCalls...)
 	expectedOp := &state.DestroyUnitOperation{ForcedOperation: state.ForcedOperation{Force: force}}
 	if force {
 		expectedOp.MaxWait = common.MaxWait(maxWait)
 	}
-	s.backend.CheckCall(c, 6, "ApplyOperation", expectedOp)
-	s.backend.CheckCall(c, 9, "ApplyOperation", &state.DestroyUnitOperation{
+	offset := len(expectedCalls) - 11
+	s.backend.CheckCall(c, 6+offset, "ApplyOperation", expectedOp)
+	s.backend.CheckCall(c, 10+offset, "ApplyOperation", &state.DestroyUnitOperation{
 		DestroyStorage: true,
 	})
 }
 
+func (s *ApplicationSuite) TestDestroyUnitBelowMinUnits(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	app.scalingPolicy = state.ScalingPolicy{MinUnits: 2}
+	results, err := s.api.DestroyUnit(params.DestroyUnitsParams{
+		Units: []params.DestroyUnitParams{{
+			UnitTag: "unit-postgresql-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches,
+		`cannot remove unit "postgresql/0": application "postgresql" requires at least 2 units`)
+	s.backend.CheckCallNames(c, "Unit", "Application")
+	app.CheckCallNames(c, "ScalingPolicy", "AllUnits")
+}
+
+func (s *ApplicationSuite) TestDestroyUnitAboveMinUnits(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	app.scalingPolicy = state.ScalingPolicy{MinUnits: 2}
+	app.units[1].life = state.Dying
+	app.units = append(app.units, &mockUnit{
+		name: "postgresql/2",
+		tag:  names.NewUnitTag("postgresql/2"),
+	}, &mockUnit{
+		name: "postgresql/3",
+		tag:  names.NewUnitTag("postgresql/3"),
+	})
+	results, err := s.api.DestroyUnit(params.DestroyUnitsParams{
+		Units: []params.DestroyUnitParams{{
+			UnitTag: "unit-postgresql-2",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	s.backend.CheckCallNames(c, "Unit", "Application", "UnitStorageAttachments", "ApplyOperation")
+}
+
+func (s *ApplicationSuite) TestForceDestroyUnitBelowMinUnits(c *gc.C) {
+	app := s.backend.applications["postgresql"]
+	app.scalingPolicy = state.ScalingPolicy{MinUnits: 2}
+	results, err := s.api.DestroyUnit(params.DestroyUnitsParams{
+		Units: []params.DestroyUnitParams{{
+			UnitTag: "unit-postgresql-1",
+			Force:   true,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	s.backend.CheckCallNames(c, "Unit", "UnitStorageAttachments", "ApplyOperation")
+}
+
+func (s *ApplicationSuite) TestScalingPolicies(c *gc.C) {
+	s.backend.applications["postgresql"].scalingPolicy = state.ScalingPolicy{MinUnits: 1, MaxUnits: 3}
+	results, err := s.api.ScalingPolicies(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "application-unknown"},
+			{Tag: "unit-postgresql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0], jc.DeepEquals, params.ScalingPolicyResult{
+		Result: &params.ScalingPolicy{MinUnits: 1, MaxUnits: 3},
+	})
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "unknown" not found`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
+}
+
+func (s *ApplicationSuite) TestSetScalingPolicies(c *gc.C) {
+	results, err := s.api.SetScalingPolicies(params.ApplicationScalingPolicies{
+		Policies: []params.ApplicationScalingPolicy{{
+			ApplicationTag: "application-postgresql",
+			Policy:         params.ScalingPolicy{MinUnits: 1, MaxUnits: 3},
+		}, {
+			ApplicationTag: "application-unknown",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `application "unknown" not found`)
+	s.backend.applications["postgresql"].CheckCall(c, 0, "SetScalingPolicy", state.ScalingPolicy{MinUnits: 1, MaxUnits: 3})
+}
+
+func (s *ApplicationSuite) TestBlockSetScalingPolicies(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.SetScalingPolicies(params.ApplicationScalingPolicies{
+		Policies: []params.ApplicationScalingPolicy{{
+			ApplicationTag: "application-postgresql",
+			Policy:         params.ScalingPolicy{MinUnits: 1},
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.backend.applications["postgresql"].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetScalingPoliciesCAASModel(c *gc.C) {
+	application.SetModelType(s.api, state.ModelTypeCAAS)
+	_, err := s.api.SetScalingPolicies(params.ApplicationScalingPolicies{})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func (s *ApplicationSuite) TestDeployAttachStorage(c *gc.C) {
 	args := params.ApplicationsDeploy{
 		Applications: []params.ApplicationDeploy{{
@@ -898,6 +1058,135 @@ func (s *ApplicationSuite) TestAddUnitsCAASModel(c *gc.C) {
 	app.CheckNoCalls(c)
 }
 
+func (s *ApplicationSuite) quotaChecks() []testing.StubCall {
+	var calls []testing.StubCall
+	for _, call := range s.backend.Calls() {
+		if call.FuncName == "CheckModelQuota" {
+			calls = append(calls, call)
+		}
+	}
+	return calls
+}
+
+func (s *ApplicationSuite) TestDeployChecksQuotas(c *gc.C) {
+	args := params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        4,
+			Placement: []*instance.Placement{
+				{Scope: "lxd"},
+				{Scope: "lxd", Directive: "1"},
+				{Scope: "zone", Directive: "a"},
+			},
+		}},
+	}
+	results, err := s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	c.Assert(s.quotaChecks(), jc.DeepEquals, []testing.StubCall{
+		{FuncName: "CheckModelQuota", Args: []interface{}{controller.MaxModelApplications, 1}},
+		{FuncName: "CheckModelQuota", Args: []interface{}{controller.MaxModelUnits, 4}},
+		{FuncName: "CheckModelQuota", Args: []interface{}{controller.MaxModelMachines, 5}},
+	})
+}
+
+func (s *ApplicationSuite) TestDeployQuotaExceeded(c *gc.C) {
+	s.backend.quotaErrs = map[string]error{
+		controller.MaxModelUnits: &state.ErrQuotaExceeded{
+			Quota:  controller.MaxModelUnits,
+			Entity: "units",
+			Limit:  10,
+			Count:  9,
+			Adding: 2,
+		},
+	}
+	args := params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{{
+			ApplicationName: "foo",
+			CharmURL:        "local:foo-0",
+			NumUnits:        2,
+		}},
+	}
+	results, err := s.api.Deploy(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeQuotaLimitExceeded)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `cannot add 2 units: model has 9 of 10 allowed by max-model-units`)
+	c.Assert(s.deployParams, gc.HasLen, 0)
+}
+
+func (s *ApplicationSuite) TestAddUnitsChecksQuotas(c *gc.C) {
+	_, err := s.api.AddUnits(params.AddApplicationUnits{
+		ApplicationName: "postgresql",
+		NumUnits:        2,
+		Placement:       []*instance.Placement{{Scope: instance.MachineScope, Directive: "0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.quotaChecks(), jc.DeepEquals, []testing.StubCall{
+		{FuncName: "CheckModelQuota", Args: []interface{}{controller.MaxModelApplications, 0}},
+		{FuncName: "CheckModelQuota", Args: []interface{}{controller.MaxModelUnits, 2}},
+		{FuncName: "CheckModelQuota", Args: []interface{}{controller.MaxModelMachines, 1}},
+	})
+}
+
+func (s *ApplicationSuite) TestAddUnitsQuotaExceeded(c *gc.C) {
+	s.backend.quotaErrs = map[string]error{
+		controller.MaxModelMachines: &state.ErrQuotaExceeded{
+			Quota:  controller.MaxModelMachines,
+			Entity: "machines",
+			Limit:  3,
+			Count:  3,
+			Adding: 1,
+		},
+	}
+	_, err := s.api.AddUnits(params.AddApplicationUnits{
+		ApplicationName: "postgresql",
+		NumUnits:        1,
+	})
+	c.Assert(err, jc.Satisfies, state.IsQuotaExceededError)
+	app := s.backend.applications["postgresql"]
+	app.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestAddRelationQuotaExceeded(c *gc.C) {
+	s.backend.quotaErrs = map[string]error{
+		controller.MaxModelRelations: &state.ErrQuotaExceeded{
+			Quota:  controller.MaxModelRelations,
+			Entity: "relations",
+			Limit:  1,
+			Count:  1,
+			Adding: 1,
+		},
+	}
+	_, err := s.api.AddRelation(params.AddRelation{Endpoints: []string{"a", "b"}})
+	c.Assert(err, jc.Satisfies, state.IsQuotaExceededError)
+	s.backend.CheckCallNames(c, "InferEndpoints", "CheckModelQuota")
+	s.backend.CheckCall(c, 1, "CheckModelQuota", controller.MaxModelRelations, 1)
+}
+
+func (s *ApplicationSuite) TestCheckUnitsForceRemoval(c *gc.C) {
+	unit := s.backend.applications["postgresql"].units[0]
+	unit.agentStatus = status.Gone
+	unit.skippedSteps = []string{`stop and remove hooks will not run for unit "postgresql/0"`}
+
+	results, err := s.api.CheckUnitsForceRemoval(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "unit-postgresql-0"},
+			{Tag: "application-postgresql"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.UnitForceRemovalCheckResult{{
+		AgentStatus:  "gone",
+		SkippedSteps: []string{`stop and remove hooks will not run for unit "postgresql/0"`},
+	}, {
+		Error: &params.Error{Message: `"application-postgresql" is not a valid unit tag`},
+	}})
+	unit.CheckCallNames(c, "AgentStatus", "AgentPresence", "ForceDestroySkippedSteps")
+	s.backend.CheckCallNames(c, "Unit")
+}
+
 func (s *ApplicationSuite) TestDestroyUnitsCAASModel(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
 	_, err := s.api.DestroyUnit(params.DestroyUnitsParams{
@@ -1686,6 +1975,46 @@ func (s *ApplicationSuite) TestResolveUnitErrorsPermissionDenied(c *gc.C) {
 	s.application.CheckNoCalls(c)
 }
 
+func (s *ApplicationSuite) TestSetUnitsMaintenance(c *gc.C) {
+	result, err := s.api.SetUnitsMaintenance(params.UnitsMaintenance{
+		Units: []params.UnitMaintenance{
+			{Tag: "unit-postgresql-0", Maintenance: true, Message: "disk swap"},
+			{Tag: "unit-postgresql-1"},
+			{Tag: "application-postgresql", Maintenance: true},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ErrorResults{Results: []params.ErrorResult{
+		{},
+		{},
+		{Error: &params.Error{Message: `"application-postgresql" is not a valid unit tag`}},
+	}})
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	units := s.backend.applications["postgresql"].units
+	units[0].CheckCallNames(c, "SetMaintenance")
+	units[0].CheckCall(c, 0, "SetMaintenance", "disk swap")
+	units[1].CheckCallNames(c, "ClearMaintenance")
+}
+
+func (s *ApplicationSuite) TestBlockSetUnitsMaintenance(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.SetUnitsMaintenance(params.UnitsMaintenance{
+		Units: []params.UnitMaintenance{{Tag: "unit-postgresql-0", Maintenance: true}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.backend.applications["postgresql"].units[0].CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestSetUnitsMaintenancePermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.SetUnitsMaintenance(params.UnitsMaintenance{
+		Units: []params.UnitMaintenance{{Tag: "unit-postgresql-0", Maintenance: true}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.applications["postgresql"].units[0].CheckNoCalls(c)
+}
+
 func (s *ApplicationSuite) TestCAASExposeWithoutHostname(c *gc.C) {
 	application.SetModelType(s.api, state.ModelTypeCAAS)
 	err := s.api.Expose(params.ApplicationExpose{
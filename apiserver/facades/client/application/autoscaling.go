@@ -0,0 +1,30 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/application"
+)
+
+// AutoscalingGroupConfigOptionName is the option name used to back an
+// application with a provider autoscaling group. The units of the
+// application are added to and removed from the instances which the
+// cloud starts and stops in the group.
+const AutoscalingGroupConfigOptionName = "autoscaling-group"
+
+var autoscalingGroupFields = environschema.Fields{
+	AutoscalingGroupConfigOptionName: {
+		Description: "The provider autoscaling group whose instances run the units",
+		Type:        environschema.Tstring,
+		Group:       environschema.JujuGroup,
+	},
+}
+
+// AutoscalingGroup returns the name of the provider autoscaling group
+// set in an application's config, or "" if none is set.
+func AutoscalingGroup(config application.ConfigAttributes) string {
+	return config.GetString(AutoscalingGroupConfigOptionName, "")
+}
@@ -49,6 +49,7 @@ type Backend interface {
 	OfferConnectionForRelation(string) (OfferConnection, error)
 	SaveEgressNetworks(relationKey string, cidrs []string) (state.RelationNetworks, error)
 	Branch(string) (Generation, error)
+	CheckModelQuota(quota string, adding int) error
 	state.EndpointBinding
 }
 
@@ -76,6 +77,7 @@ type Application interface {
 	Constraints() (constraints.Value, error)
 	Destroy() error
 	DestroyOperation() *state.DestroyApplicationOperation
+	DisplayStatus() (status.StatusInfo, bool, error)
 	EndpointBindings() (Bindings, error)
 	Endpoints() ([]state.Endpoint, error)
 	IsExposed() bool
@@ -87,6 +89,8 @@ type Application interface {
 	SetExposed() error
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
+	ScalingPolicy() state.ScalingPolicy
+	SetScalingPolicy(state.ScalingPolicy) error
 	UpdateApplicationSeries(string, bool) error
 	UpdateCharmConfig(string, charm.Settings) error
 	UpdateApplicationConfig(application.ConfigAttributes, []string, environschema.Fields, schema.Defaults) error
@@ -120,6 +124,7 @@ type Charm interface {
 type Machine interface {
 	IsLockedForSeriesUpgrade() (bool, error)
 	IsParentLockedForSeriesUpgrade() (bool, error)
+	CheckColocation(application string) error
 }
 
 // Relation defines a subset of the functionality provided by the
@@ -128,10 +133,16 @@ type Machine interface {
 // the same names.
 type Relation interface {
 	status.StatusSetter
+	status.StatusGetter
 	Tag() names.Tag
+	Id() int
+	String() string
 	Destroy() error
 	DestroyWithForce(bool, time.Duration) ([]error, error)
+	DestroyOperation(bool) *state.DestroyRelationOperation
 	Endpoint(string) (state.Endpoint, error)
+	Endpoints() []state.Endpoint
+	SettingsHistory() ([]state.RelationSettingsChange, error)
 	SetSuspended(bool, string) error
 	Suspended() bool
 	SuspendedReason() string
@@ -151,6 +162,11 @@ type Unit interface {
 	Life() state.Life
 	Resolve(retryHooks bool) error
 	AgentTools() (*tools.Tools, error)
+	AgentStatus() (status.StatusInfo, error)
+	AgentPresence() (bool, error)
+	ForceDestroySkippedSteps() ([]string, error)
+	SetMaintenance(message string) error
+	ClearMaintenance() error
 
 	AssignedMachineId() (string, error)
 	AssignWithPolicy(state.AssignmentPolicy) error
@@ -0,0 +1,48 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"os"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/charmrepo.v3"
+
+	"github.com/juju/juju/charmstore"
+)
+
+// mirrorCharmRepo is a charmrepo.Interface which gets charm archives
+// from a mirror of the charm store rather than from the charm store
+// itself. The archive is checked against the SHA256 hash the mirror
+// holds for it.
+type mirrorCharmRepo struct {
+	charmrepo.Interface
+	mirror *charmstore.Mirror
+}
+
+func newMirrorCharmRepo(repo charmrepo.Interface, mirror *charmstore.Mirror) charmrepo.Interface {
+	return &mirrorCharmRepo{
+		Interface: repo,
+		mirror:    mirror,
+	}
+}
+
+// Get implements charmrepo.Interface.
+func (r *mirrorCharmRepo) Get(curl *charm.URL) (charm.Charm, error) {
+	hash, err := r.mirror.Hash256(curl)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	path, err := r.mirror.Archive(curl, hash)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ch, err := charm.ReadCharmArchive(path)
+	if err != nil {
+		os.Remove(path)
+		return nil, errors.Annotatef(err, "reading charm %q downloaded from mirror", curl)
+	}
+	return ch, nil
+}
@@ -0,0 +1,80 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/charmrepo.v3"
+
+	"github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/testcharms"
+)
+
+type CharmMirrorSuite struct {
+	testing.IsolationSuite
+	archive []byte
+	hash    string
+	server  *httptest.Server
+}
+
+var _ = gc.Suite(&CharmMirrorSuite{})
+
+func (s *CharmMirrorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	ch := testcharms.Repo.CharmArchive(c.MkDir(), "dummy")
+	archive, err := ioutil.ReadFile(ch.Path)
+	c.Assert(err, jc.ErrorIsNil)
+	s.archive = archive
+	s.hash = fmt.Sprintf("%x", sha256.Sum256(s.archive))
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bionic/dummy-1/archive":
+			w.Write(s.archive)
+		case "/bionic/dummy-1/meta/hash256":
+			fmt.Fprintf(w, `{"Sum": %q}`, s.hash)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *CharmMirrorSuite) newRepo(c *gc.C) charmrepo.Interface {
+	mirror, err := charmstore.NewMirror(s.server.URL)
+	c.Assert(err, jc.ErrorIsNil)
+	// The charm store itself is never used to get charms.
+	return application.NewMirrorCharmRepo(nil, mirror)
+}
+
+func (s *CharmMirrorSuite) TestGet(c *gc.C) {
+	ch, err := s.newRepo(c).Get(charm.MustParseURL("cs:bionic/dummy-1"))
+	c.Assert(err, jc.ErrorIsNil)
+	archive, ok := ch.(*charm.CharmArchive)
+	c.Assert(ok, jc.IsTrue)
+	defer os.Remove(archive.Path)
+	c.Assert(archive.Meta().Name, gc.Equals, "dummy")
+}
+
+func (s *CharmMirrorSuite) TestGetChecksumMismatch(c *gc.C) {
+	s.hash = "deadbeef"
+
+	_, err := s.newRepo(c).Get(charm.MustParseURL("cs:bionic/dummy-1"))
+	c.Assert(err, gc.ErrorMatches, `downloading charm "cs:bionic/dummy-1" from mirror: checksum of downloaded content \(.*\) does not match metadata \(deadbeef\)`)
+}
+
+func (s *CharmMirrorSuite) TestGetHashNotFound(c *gc.C) {
+	_, err := s.newRepo(c).Get(charm.MustParseURL("cs:bionic/dummy-2"))
+	c.Assert(err, gc.ErrorMatches, `getting hash of charm "cs:bionic/dummy-2" from mirror: "bionic/dummy-2/meta/hash256" on mirror not found`)
+}
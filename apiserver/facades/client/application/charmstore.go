@@ -4,8 +4,10 @@
 package application
 
 import (
+	"archive/zip"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/url"
 	"os"
 	"strings"
@@ -21,8 +23,11 @@ import (
 	"gopkg.in/macaroon.v2-unstable"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/charmlint"
 	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
@@ -211,6 +216,13 @@ func AddCharmWithAuthorization(st State, args params.AddCharmWithAuthorization)
 			return nil, errors.Trace(err)
 		}
 		repo = config.SpecializeCharmRepo(repo, modelConfig).(*charmrepo.CharmStore)
+		if mirrorURL, ok := modelConfig.CharmMirrorURL(); ok {
+			mirror, err := charmstore.NewMirror(mirrorURL)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return newMirrorCharmRepo(repo, mirror), nil
+		}
 		return repo, nil
 	})
 }
@@ -314,10 +326,18 @@ type CharmArchive struct {
 
 	// Charm Version contains semantic version of charm, typically the output of git describe.
 	CharmVersion string
+
+	// LintFindings holds the problems found in the charm by static
+	// analysis.
+	LintFindings []charmlint.Finding
 }
 
 // StoreCharmArchive stores a charm archive in environment storage.
 func StoreCharmArchive(st State, archive CharmArchive) error {
+	relationSchemas, err := readRelationSchemas(archive.Charm)
+	if err != nil {
+		return errors.Annotate(err, "cannot add charm")
+	}
 	storage := newStateStorage(st.ModelUUID(), st.MongoSession())
 	storagePath, err := charmArchiveStoragePath(archive.ID)
 	if err != nil {
@@ -334,6 +354,9 @@ func StoreCharmArchive(st State, archive CharmArchive) error {
 		SHA256:      archive.SHA256,
 		Macaroon:    archive.Macaroon,
 		Version:     archive.CharmVersion,
+
+		RelationSchemas: relationSchemas,
+		LintFindings:    archive.LintFindings,
 	}
 
 	// Now update the charm data in state and mark it as no longer pending.
@@ -359,6 +382,50 @@ func StoreCharmArchive(st State, archive CharmArchive) error {
 	return nil
 }
 
+// readRelationSchemas returns the contents of the relation schemas file
+// of the charm, if it is an archive with one, having checked that the
+// schemas are valid and refer to the charm's relation endpoints.
+func readRelationSchemas(ch charm.Charm) (string, error) {
+	archive, ok := ch.(*charm.CharmArchive)
+	if !ok {
+		return "", nil
+	}
+	zipr, err := zip.OpenReader(archive.Path)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer zipr.Close()
+	for _, f := range zipr.File {
+		if f.Name != relation.SchemasFile {
+			continue
+		}
+		r, err := f.Open()
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		defer r.Close()
+		data, err := ioutil.ReadAll(r)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		schemas, err := relation.ParseSchemas(data)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		meta := ch.Meta()
+		for endpoint := range schemas {
+			_, provided := meta.Provides[endpoint]
+			_, required := meta.Requires[endpoint]
+			_, peer := meta.Peers[endpoint]
+			if !provided && !required && !peer {
+				return "", errors.NotValidf("%s: schema for unknown relation %q", relation.SchemasFile, endpoint)
+			}
+		}
+		return string(data), nil
+	}
+	return "", nil
+}
+
 // charmArchiveStoragePath returns a string that is suitable as a
 // storage path, using a random UUID to avoid colliding with concurrent
 // uploads.
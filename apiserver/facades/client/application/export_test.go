@@ -3,7 +3,12 @@
 
 package application
 
-import "github.com/juju/juju/state"
+import (
+	"gopkg.in/juju/charmrepo.v3"
+
+	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/state"
+)
 
 var (
 	ParseSettingsCompatible = parseSettingsCompatible
@@ -15,6 +20,10 @@ func GetState(st *state.State) Backend {
 	return stateShim{st}
 }
 
-func SetModelType(api *APIv11, modelType state.ModelType) {
+func SetModelType(api *APIv18, modelType state.ModelType) {
 	api.modelType = modelType
 }
+
+func NewMirrorCharmRepo(repo charmrepo.Interface, mirror *charmstore.Mirror) charmrepo.Interface {
+	return newMirrorCharmRepo(repo, mirror)
+}
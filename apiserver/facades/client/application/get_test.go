@@ -29,7 +29,7 @@ import (
 type getSuite struct {
 	jujutesting.JujuConnSuite
 
-	applicationAPI *application.APIv11
+	applicationAPI *application.APIv18
 	authorizer     apiservertesting.FakeAuthorizer
 }
 
@@ -60,12 +60,12 @@ func (s *getSuite) SetUpTest(c *gc.C) {
 		nil, // CAAS Broker not used in this suite.
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	s.applicationAPI = &application.APIv11{api}
+	s.applicationAPI = &application.APIv18{api}
 }
 
 func (s *getSuite) TestClientApplicationGetSmokeTestV4(c *gc.C) {
 	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
-	v4 := &application.APIv4{&application.APIv5{&application.APIv6{&application.APIv7{&application.APIv8{&application.APIv9{&application.APIv10{s.applicationAPI}}}}}}}
+	v4 := &application.APIv4{&application.APIv5{&application.APIv6{&application.APIv7{&application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{&application.APIv12{&application.APIv13{&application.APIv14{&application.APIv15{&application.APIv16{&application.APIv17{s.applicationAPI}}}}}}}}}}}}}}
 	results, err := v4.Get(params.ApplicationGet{ApplicationName: "wordpress"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.DeepEquals, params.ApplicationGetResults{
@@ -85,7 +85,7 @@ func (s *getSuite) TestClientApplicationGetSmokeTestV4(c *gc.C) {
 
 func (s *getSuite) TestClientApplicationGetSmokeTestV5(c *gc.C) {
 	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
-	v5 := &application.APIv5{&application.APIv6{&application.APIv7{&application.APIv8{&application.APIv9{&application.APIv10{s.applicationAPI}}}}}}
+	v5 := &application.APIv5{&application.APIv6{&application.APIv7{&application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{&application.APIv12{&application.APIv13{&application.APIv14{&application.APIv15{&application.APIv16{&application.APIv17{s.applicationAPI}}}}}}}}}}}}}
 	results, err := v5.Get(params.ApplicationGet{ApplicationName: "wordpress"})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, gc.DeepEquals, params.ApplicationGetResults{
@@ -122,6 +122,18 @@ func (s *getSuite) TestClientApplicationGetIAASModelSmokeTest(c *gc.C) {
 			},
 		},
 		ApplicationConfig: map[string]interface{}{
+			"autoscaling-group": map[string]interface{}{
+				"description": "The provider autoscaling group whose instances run the units",
+				"source":      "unset",
+				"type":        environschema.Tstring,
+			},
+			"primary-address": map[string]interface{}{
+				"default":     "auto",
+				"description": "The unit address to advertise (auto, binding, public or fqdn)",
+				"source":      "default",
+				"type":        environschema.Tstring,
+				"value":       "auto",
+			},
 			"trust": map[string]interface{}{
 				"default":     false,
 				"description": "Does this application have access to trusted credentials",
@@ -213,7 +225,7 @@ func (s *getSuite) TestClientApplicationGetCAASModelSmokeTest(c *gc.C) {
 		nil, // CAAS Broker not used in this suite.
 	)
 	c.Assert(err, jc.ErrorIsNil)
-	apiV8 := &application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{api}}}}
+	apiV8 := &application.APIv8{&application.APIv9{&application.APIv10{&application.APIv11{&application.APIv12{&application.APIv13{&application.APIv14{&application.APIv15{&application.APIv16{&application.APIv17{&application.APIv18{api}}}}}}}}}}}
 
 	results, err := apiV8.Get(params.ApplicationGet{ApplicationName: "dashboard4miner"})
 	c.Assert(err, jc.ErrorIsNil)
@@ -290,6 +302,18 @@ var getTests = []struct {
 			},
 		},
 		ApplicationConfig: map[string]interface{}{
+			"autoscaling-group": map[string]interface{}{
+				"description": "The provider autoscaling group whose instances run the units",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"primary-address": map[string]interface{}{
+				"value":       "auto",
+				"default":     "auto",
+				"description": "The unit address to advertise (auto, binding, public or fqdn)",
+				"source":      "default",
+				"type":        "string",
+			},
 			"trust": map[string]interface{}{
 				"value":       false,
 				"default":     false,
@@ -351,6 +375,18 @@ var getTests = []struct {
 			},
 		},
 		ApplicationConfig: map[string]interface{}{
+			"autoscaling-group": map[string]interface{}{
+				"description": "The provider autoscaling group whose instances run the units",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"primary-address": map[string]interface{}{
+				"value":       "auto",
+				"default":     "auto",
+				"description": "The unit address to advertise (auto, binding, public or fqdn)",
+				"source":      "default",
+				"type":        "string",
+			},
 			"trust": map[string]interface{}{
 				"value":       false,
 				"default":     false,
@@ -371,6 +407,18 @@ var getTests = []struct {
 		CharmConfig: map[string]interface{}{},
 		Series:      "quantal",
 		ApplicationConfig: map[string]interface{}{
+			"autoscaling-group": map[string]interface{}{
+				"description": "The provider autoscaling group whose instances run the units",
+				"source":      "unset",
+				"type":        "string",
+			},
+			"primary-address": map[string]interface{}{
+				"value":       "auto",
+				"default":     "auto",
+				"description": "The unit address to advertise (auto, binding, public or fqdn)",
+				"source":      "default",
+				"type":        "string",
+			},
 			"trust": map[string]interface{}{
 				"value":       false,
 				"default":     false,
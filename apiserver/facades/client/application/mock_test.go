@@ -102,6 +102,8 @@ type mockApplication struct {
 	exposed     bool
 	remote      bool
 	agentTools  *tools.Tools
+
+	scalingPolicy state.ScalingPolicy
 }
 
 func (m *mockApplication) Name() string {
@@ -232,6 +234,17 @@ func (a *mockApplication) UpdateCharmConfig(branchName string, settings charm.Se
 	return a.NextErr()
 }
 
+func (a *mockApplication) ScalingPolicy() state.ScalingPolicy {
+	a.MethodCall(a, "ScalingPolicy")
+	a.PopNoErr()
+	return a.scalingPolicy
+}
+
+func (a *mockApplication) SetScalingPolicy(policy state.ScalingPolicy) error {
+	a.MethodCall(a, "SetScalingPolicy", policy)
+	return a.NextErr()
+}
+
 func (a *mockApplication) SetExposed() error {
 	a.MethodCall(a, "SetExposed")
 	return a.NextErr()
@@ -361,6 +374,7 @@ type mockBackend struct {
 	controllers                map[string]crossmodel.ControllerInfo
 	machines                   map[string]*mockMachine
 	generation                 *mockGeneration
+	quotaErrs                  map[string]error
 }
 
 type mockFilesystemAccess struct {
@@ -509,6 +523,11 @@ func (m *mockMachine) IsParentLockedForSeriesUpgrade() (bool, error) {
 	return false, m.NextErr()
 }
 
+func (m *mockMachine) CheckColocation(application string) error {
+	m.MethodCall(m, "CheckColocation", application)
+	return m.NextErr()
+}
+
 func (m *mockMachine) Id() string {
 	m.MethodCall(m, "Id")
 	return m.id
@@ -673,6 +692,11 @@ func (m *mockBackend) SaveController(controllerInfo crossmodel.ControllerInfo, m
 	return &mockExternalController{controllerInfo.ControllerTag.Id(), controllerInfo}, nil
 }
 
+func (m *mockBackend) CheckModelQuota(quota string, adding int) error {
+	m.MethodCall(m, "CheckModelQuota", quota, adding)
+	return m.quotaErrs[quota]
+}
+
 func (m *mockBackend) Branch(branchName string) (application.Generation, error) {
 	if branchName != "new-branch" {
 		return nil, errors.NotFoundf("branch %q", branchName)
@@ -759,6 +783,11 @@ func (r *mockRelation) DestroyWithForce(force bool, maxWait time.Duration) ([]er
 	return nil, r.NextErr()
 }
 
+func (r *mockRelation) DestroyOperation(force bool) *state.DestroyRelationOperation {
+	r.MethodCall(r, "DestroyOperation", force)
+	return &state.DestroyRelationOperation{}
+}
+
 type mockUnit struct {
 	application.Unit
 	jtesting.Stub
@@ -766,6 +795,11 @@ type mockUnit struct {
 	machineId  string
 	name       string
 	agentTools *tools.Tools
+
+	agentStatus  status.Status
+	agentAlive   bool
+	skippedSteps []string
+	life         state.Life
 }
 
 func (u *mockUnit) Tag() names.Tag {
@@ -817,6 +851,35 @@ func (u *mockUnit) AgentTools() (*tools.Tools, error) {
 	return u.agentTools, u.NextErr()
 }
 
+func (u *mockUnit) AgentStatus() (status.StatusInfo, error) {
+	u.MethodCall(u, "AgentStatus")
+	return status.StatusInfo{Status: u.agentStatus}, u.NextErr()
+}
+
+func (u *mockUnit) AgentPresence() (bool, error) {
+	u.MethodCall(u, "AgentPresence")
+	return u.agentAlive, u.NextErr()
+}
+
+func (u *mockUnit) ForceDestroySkippedSteps() ([]string, error) {
+	u.MethodCall(u, "ForceDestroySkippedSteps")
+	return u.skippedSteps, u.NextErr()
+}
+
+func (u *mockUnit) Life() state.Life {
+	return u.life
+}
+
+func (u *mockUnit) SetMaintenance(message string) error {
+	u.MethodCall(u, "SetMaintenance", message)
+	return u.NextErr()
+}
+
+func (u *mockUnit) ClearMaintenance() error {
+	u.MethodCall(u, "ClearMaintenance")
+	return u.NextErr()
+}
+
 type mockStorageAttachment struct {
 	state.StorageAttachment
 	jtesting.Stub
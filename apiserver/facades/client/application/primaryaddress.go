@@ -0,0 +1,42 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/schema"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/network"
+)
+
+// PrimaryAddressConfigOptionName is the option name used to choose which
+// address is advertised as the primary address of an application's units.
+const PrimaryAddressConfigOptionName = "primary-address"
+
+var primaryAddressFields = environschema.Fields{
+	PrimaryAddressConfigOptionName: {
+		Description: "The unit address to advertise (auto, binding, public or fqdn)",
+		Type:        environschema.Tstring,
+		Values: []interface{}{
+			string(network.PreferAutoAddress),
+			string(network.PreferBindingAddress),
+			string(network.PreferPublicAddress),
+			string(network.PreferFQDNAddress),
+		},
+		Group: environschema.JujuGroup,
+	},
+}
+
+var primaryAddressDefaults = schema.Defaults{
+	PrimaryAddressConfigOptionName: string(network.PreferAutoAddress),
+}
+
+// PrimaryAddressPreference returns the preference for the primary
+// address of an application's units set in its application config.
+func PrimaryAddressPreference(config application.ConfigAttributes) network.UnitAddressPreference {
+	return network.UnitAddressPreference(
+		config.GetString(PrimaryAddressConfigOptionName, string(network.PreferAutoAddress)),
+	)
+}
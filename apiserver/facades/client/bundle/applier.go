@@ -0,0 +1,229 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/client/annotations"
+	appFacade "github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/apiserver/facades/client/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// ModelApplier defines the operations needed to apply the changes in a
+// model plan.
+type ModelApplier interface {
+	// AddCharm adds the charm store charm to the model.
+	AddCharm(args params.AddCharmWithAuthorization) error
+
+	// Deploy deploys an application.
+	Deploy(args params.ApplicationDeploy) error
+
+	// AddMachine adds a machine or container and returns its id.
+	AddMachine(args params.AddMachineParams) (string, error)
+
+	// AddUnit adds a single unit and returns its name.
+	AddUnit(args params.AddApplicationUnits) (string, error)
+
+	// AddRelation relates the two endpoints.
+	AddRelation(endpoints []string) error
+
+	// Expose exposes an application.
+	Expose(application string) error
+
+	// SetCharm upgrades the charm of an application.
+	SetCharm(args params.ApplicationSetCharm) error
+
+	// Update updates the charm config of an application.
+	Update(args params.ApplicationUpdate) error
+
+	// SetConstraints sets the constraints of an application.
+	SetConstraints(args params.SetConstraints) error
+
+	// Scale sets the scale of a kubernetes application.
+	Scale(application string, scale int) error
+
+	// SetAnnotations sets annotations on a machine or application.
+	SetAnnotations(tag names.Tag, values map[string]string) error
+}
+
+// stateApplier implements ModelApplier using the client facades, so
+// that plans are applied with the same checks as the equivalent client
+// calls. The facades are created when first needed.
+type stateApplier struct {
+	ctx facade.Context
+
+	application    *appFacade.APIv18
+	machineManager *machinemanager.MachineManagerAPI
+	annotations    *annotations.API
+}
+
+// NewStateApplier returns a ModelApplier that applies changes to the
+// model of the given facade context.
+func NewStateApplier(ctx facade.Context) ModelApplier {
+	return &stateApplier{ctx: ctx}
+}
+
+func (a *stateApplier) applicationAPI() (*appFacade.APIv18, error) {
+	if a.application == nil {
+		api, err := appFacade.NewFacadeV18(a.ctx)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		a.application = api
+	}
+	return a.application, nil
+}
+
+// AddCharm is part of the ModelApplier interface.
+func (a *stateApplier) AddCharm(args params.AddCharmWithAuthorization) error {
+	st := appFacade.NewStateShim(a.ctx.State())
+	return errors.Trace(appFacade.AddCharmWithAuthorization(st, args))
+}
+
+// Deploy is part of the ModelApplier interface.
+func (a *stateApplier) Deploy(args params.ApplicationDeploy) error {
+	api, err := a.applicationAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	results, err := api.Deploy(params.ApplicationsDeploy{
+		Applications: []params.ApplicationDeploy{args},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// AddMachine is part of the ModelApplier interface.
+func (a *stateApplier) AddMachine(args params.AddMachineParams) (string, error) {
+	if a.machineManager == nil {
+		api, err := machinemanager.NewFacade(a.ctx)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		a.machineManager = api
+	}
+	results, err := a.machineManager.AddMachines(params.AddMachines{
+		MachineParams: []params.AddMachineParams{args},
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if n := len(results.Machines); n != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Machines[0].Error; err != nil {
+		return "", err
+	}
+	return results.Machines[0].Machine, nil
+}
+
+// AddUnit is part of the ModelApplier interface.
+func (a *stateApplier) AddUnit(args params.AddApplicationUnits) (string, error) {
+	api, err := a.applicationAPI()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	results, err := api.AddUnits(args)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if n := len(results.Units); n != 1 {
+		return "", errors.Errorf("expected 1 unit, got %d", n)
+	}
+	return results.Units[0], nil
+}
+
+// AddRelation is part of the ModelApplier interface.
+func (a *stateApplier) AddRelation(endpoints []string) error {
+	api, err := a.applicationAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	_, err = api.AddRelation(params.AddRelation{Endpoints: endpoints})
+	return errors.Trace(err)
+}
+
+// Expose is part of the ModelApplier interface.
+func (a *stateApplier) Expose(application string) error {
+	api, err := a.applicationAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(api.Expose(params.ApplicationExpose{ApplicationName: application}))
+}
+
+// SetCharm is part of the ModelApplier interface.
+func (a *stateApplier) SetCharm(args params.ApplicationSetCharm) error {
+	api, err := a.applicationAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(api.SetCharm(args))
+}
+
+// Update is part of the ModelApplier interface.
+func (a *stateApplier) Update(args params.ApplicationUpdate) error {
+	api, err := a.applicationAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(api.Update(args))
+}
+
+// SetConstraints is part of the ModelApplier interface.
+func (a *stateApplier) SetConstraints(args params.SetConstraints) error {
+	api, err := a.applicationAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(api.SetConstraints(args))
+}
+
+// Scale is part of the ModelApplier interface.
+func (a *stateApplier) Scale(application string, scale int) error {
+	api, err := a.applicationAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	results, err := api.ScaleApplications(params.ScaleApplicationsParams{
+		Applications: []params.ScaleApplicationParams{{
+			ApplicationTag: names.NewApplicationTag(application).String(),
+			Scale:          scale,
+		}},
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return errors.Errorf("expected 1 result, got %d", n)
+	}
+	if err := results.Results[0].Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// SetAnnotations is part of the ModelApplier interface.
+func (a *stateApplier) SetAnnotations(tag names.Tag, values map[string]string) error {
+	if a.annotations == nil {
+		api, err := annotations.NewAPI(a.ctx.State(), a.ctx.Resources(), a.ctx.Auth())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		a.annotations = api
+	}
+	results := a.annotations.Set(params.AnnotationsSet{
+		Annotations: []params.EntityAnnotations{{
+			EntityTag:   tag.String(),
+			Annotations: values,
+		}},
+	})
+	return results.Combine()
+}
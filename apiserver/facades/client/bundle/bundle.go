@@ -57,10 +57,25 @@ type APIv4 struct {
 	*BundleAPI
 }
 
+// APIv5 provides the Bundle API facade for version 5. It adds PlanModel
+// and ApplyModelPlan, which compute and apply the changes needed to bring
+// the model to the state described by a bundle.
+type APIv5 struct {
+	*BundleAPI
+}
+
+// APIv6 provides the Bundle API facade for version 6. It adds
+// AddModelBundle and ApproveModelPlan, which store the bundle a model
+// is reconciled with by the controller and approve the plans to do so.
+type APIv6 struct {
+	*BundleAPI
+}
+
 // BundleAPI implements the Bundle interface and is the concrete implementation
 // of the API end point.
 type BundleAPI struct {
 	backend    Backend
+	applier    ModelApplier
 	authorizer facade.Authorizer
 	modelTag   names.ModelTag
 }
@@ -106,6 +121,28 @@ func NewFacadeV4(ctx facade.Context) (*APIv4, error) {
 	return &APIv4{api}, nil
 }
 
+// NewFacadeV5 provides the signature required for facade registration
+// for version 5.
+func NewFacadeV5(ctx facade.Context) (*APIv5, error) {
+	api, err := newFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	api.applier = NewStateApplier(ctx)
+	return &APIv5{api}, nil
+}
+
+// NewFacadeV6 provides the signature required for facade registration
+// for version 6.
+func NewFacadeV6(ctx facade.Context) (*APIv6, error) {
+	api, err := newFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	api.applier = NewStateApplier(ctx)
+	return &APIv6{api}, nil
+}
+
 // NewFacade provides the required signature for facade registration.
 func newFacade(ctx facade.Context) (*BundleAPI, error) {
 	authorizer := ctx.Auth()
@@ -148,6 +185,38 @@ func NewBundleAPIv1(
 	return &APIv1{&APIv2{api}}, nil
 }
 
+// NewBundleAPIv5 returns the new Bundle APIv5 facade, which applies
+// model plans with the given applier.
+func NewBundleAPIv5(
+	st Backend,
+	applier ModelApplier,
+	auth facade.Authorizer,
+	tag names.ModelTag,
+) (*APIv5, error) {
+	api, err := NewBundleAPI(st, auth, tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	api.applier = applier
+	return &APIv5{api}, nil
+}
+
+// NewBundleAPIv6 returns the new Bundle APIv6 facade, which applies
+// model plans with the given applier.
+func NewBundleAPIv6(
+	st Backend,
+	applier ModelApplier,
+	auth facade.Authorizer,
+	tag names.ModelTag,
+) (*APIv6, error) {
+	api, err := NewBundleAPI(st, auth, tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	api.applier = applier
+	return &APIv6{api}, nil
+}
+
 func (b *BundleAPI) checkCanRead() error {
 	canRead, err := b.authorizer.HasPermission(permission.ReadAccess, b.modelTag)
 	if err != nil {
@@ -159,6 +228,17 @@ func (b *BundleAPI) checkCanRead() error {
 	return nil
 }
 
+func (b *BundleAPI) checkCanWrite() error {
+	canWrite, err := b.authorizer.HasPermission(permission.WriteAccess, b.modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canWrite {
+		return common.ErrPerm
+	}
+	return nil
+}
+
 // GetChanges returns the list of changes required to deploy the given bundle
 // data. The changes are sorted by requirements, so that they can be applied in
 // order.
@@ -198,7 +278,25 @@ type validators struct {
 func getBundleChanges(args params.BundleChangesParams,
 	vs validators,
 ) ([]bundlechanges.Change, []error, error) {
-	data, err := charm.ReadBundleData(strings.NewReader(args.BundleDataYAML))
+	data, validationErrors, err := readBundleData(args.BundleDataYAML, vs)
+	if err != nil || len(validationErrors) > 0 {
+		return nil, validationErrors, errors.Trace(err)
+	}
+	changes, err := bundlechanges.FromData(
+		bundlechanges.ChangesConfig{
+			Bundle:    data,
+			BundleURL: args.BundleURL,
+			Logger:    loggo.GetLogger("juju.apiserver.bundlechanges"),
+		})
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return changes, nil, nil
+}
+
+// readBundleData reads and verifies the given bundle YAML.
+func readBundleData(bundleDataYAML string, vs validators) (*charm.BundleData, []error, error) {
+	data, err := charm.ReadBundleData(strings.NewReader(bundleDataYAML))
 	if err != nil {
 		return nil, nil, errors.Annotate(err, "cannot read bundle YAML")
 	}
@@ -213,16 +311,7 @@ func getBundleChanges(args params.BundleChangesParams,
 		// This should never happen as Verify only returns verification errors.
 		return nil, nil, errors.Annotate(err, "cannot verify bundle")
 	}
-	changes, err := bundlechanges.FromData(
-		bundlechanges.ChangesConfig{
-			Bundle:    data,
-			BundleURL: args.BundleURL,
-			Logger:    loggo.GetLogger("juju.apiserver.bundlechanges"),
-		})
-	if err != nil {
-		return nil, nil, errors.Trace(err)
-	}
-	return changes, nil, nil
+	return data, nil, nil
 }
 
 func getChanges(
@@ -292,6 +381,32 @@ func (b *BundleAPI) GetChanges(args params.BundleChangesParams) (params.BundleCh
 // Mask the new method from V3 API or less.
 func (u *APIv3) GetChangesMapArgs() (_, _ struct{}) { return }
 
+// PlanModel is not in V4 API or less.
+// Mask the new method from V4 API or less.
+func (u *APIv2) PlanModel() (_, _ struct{}) { return }
+func (u *APIv3) PlanModel() (_, _ struct{}) { return }
+func (u *APIv4) PlanModel() (_, _ struct{}) { return }
+
+// ApplyModelPlan is not in V4 API or less.
+// Mask the new method from V4 API or less.
+func (u *APIv2) ApplyModelPlan() (_, _ struct{}) { return }
+func (u *APIv3) ApplyModelPlan() (_, _ struct{}) { return }
+func (u *APIv4) ApplyModelPlan() (_, _ struct{}) { return }
+
+// AddModelBundle is not in V5 API or less.
+// Mask the new method from V5 API or less.
+func (u *APIv2) AddModelBundle() (_, _ struct{}) { return }
+func (u *APIv3) AddModelBundle() (_, _ struct{}) { return }
+func (u *APIv4) AddModelBundle() (_, _ struct{}) { return }
+func (u *APIv5) AddModelBundle() (_, _ struct{}) { return }
+
+// ApproveModelPlan is not in V5 API or less.
+// Mask the new method from V5 API or less.
+func (u *APIv2) ApproveModelPlan() (_, _ struct{}) { return }
+func (u *APIv3) ApproveModelPlan() (_, _ struct{}) { return }
+func (u *APIv4) ApproveModelPlan() (_, _ struct{}) { return }
+func (u *APIv5) ApproveModelPlan() (_, _ struct{}) { return }
+
 // GetChangesMapArgs returns the list of changes required to deploy the given
 // bundle data. The changes are sorted by requirements, so that they can be
 // applied in order.
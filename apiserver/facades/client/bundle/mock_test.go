@@ -40,6 +40,23 @@ func (m *mockState) GetExportConfig() state.ExportConfig {
 	}
 }
 
+func (m *mockState) AddModelBundle(bundle, createdBy string) (state.ModelBundle, error) {
+	m.MethodCall(m, "AddModelBundle", bundle, createdBy)
+	if err := m.NextErr(); err != nil {
+		return state.ModelBundle{}, err
+	}
+	return state.ModelBundle{
+		Revision:  1,
+		Bundle:    bundle,
+		CreatedBy: createdBy,
+	}, nil
+}
+
+func (m *mockState) ApproveBundlePlan(fingerprint, approvedBy string) error {
+	m.MethodCall(m, "ApproveBundlePlan", fingerprint, approvedBy)
+	return m.NextErr()
+}
+
 func (m *mockState) AllSpaceInfos() (network.SpaceInfos, error) {
 	result := make(network.SpaceInfos, len(m.Spaces))
 	i := 0
@@ -0,0 +1,56 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// AddModelBundle stores a new revision of the bundle which describes
+// the desired state of the model. When the model's bundle-source is
+// "controller", the bundle reconciler brings the model into line with
+// the latest revision.
+func (b *BundleAPI) AddModelBundle(args params.AddModelBundleArgs) (params.ModelBundle, error) {
+	if err := b.checkCanWrite(); err != nil {
+		return params.ModelBundle{}, common.ServerError(err)
+	}
+	_, validationErrors, err := readBundleData(args.BundleDataYAML, modelValidators())
+	if err != nil {
+		return params.ModelBundle{}, common.ServerError(err)
+	}
+	if len(validationErrors) > 0 {
+		messages := make([]string, len(validationErrors))
+		for i, e := range validationErrors {
+			messages[i] = e.Error()
+		}
+		err := errors.NewNotValid(nil, "invalid bundle: "+strings.Join(messages, "; "))
+		return params.ModelBundle{}, common.ServerError(err)
+	}
+	stored, err := b.backend.AddModelBundle(args.BundleDataYAML, b.authorizer.GetAuthTag().Id())
+	if err != nil {
+		return params.ModelBundle{}, common.ServerError(err)
+	}
+	return params.ModelBundle{
+		Revision:  stored.Revision,
+		Bundle:    stored.Bundle,
+		CreatedBy: stored.CreatedBy,
+		Created:   stored.Created,
+	}, nil
+}
+
+// ApproveModelPlan approves the model plan with the given fingerprint,
+// so that the bundle reconciler applies it when the model's
+// bundle-reconcile-mode is "manual".
+func (b *BundleAPI) ApproveModelPlan(args params.ApproveModelPlanArgs) error {
+	if err := b.checkCanWrite(); err != nil {
+		return common.ServerError(err)
+	}
+	err := b.backend.ApproveBundlePlan(args.Fingerprint, b.authorizer.GetAuthTag().Id())
+	return common.ServerError(err)
+}
@@ -0,0 +1,83 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/facades/client/bundle"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type modelBundleSuite struct {
+	coretesting.BaseSuite
+	auth   *apiservertesting.FakeAuthorizer
+	st     *mockState
+	facade *bundle.APIv6
+}
+
+var _ = gc.Suite(&modelBundleSuite{})
+
+func (s *modelBundleSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.auth = &apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("write"),
+	}
+	s.st = newMockState()
+	api, err := bundle.NewBundleAPIv6(s.st, &fakeApplier{}, s.auth, names.NewModelTag("some-uuid"))
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade = api
+}
+
+func (s *modelBundleSuite) TestAddModelBundle(c *gc.C) {
+	result, err := s.facade.AddModelBundle(params.AddModelBundleArgs{
+		BundleDataYAML: ubuntuBundle,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelBundle{
+		Revision:  1,
+		Bundle:    ubuntuBundle,
+		CreatedBy: "write",
+	})
+	s.st.CheckCall(c, 0, "AddModelBundle", ubuntuBundle, "write")
+}
+
+func (s *modelBundleSuite) TestAddModelBundleInvalid(c *gc.C) {
+	_, err := s.facade.AddModelBundle(params.AddModelBundleArgs{
+		BundleDataYAML: `
+applications:
+  ubuntu:
+    charm: cs:trusty/ubuntu-10
+    num_units: -1
+`,
+	})
+	c.Assert(err, gc.ErrorMatches, `invalid bundle: negative number of units specified on application "ubuntu"`)
+	s.st.CheckNoCalls(c)
+}
+
+func (s *modelBundleSuite) TestAddModelBundlePermissionDenied(c *gc.C) {
+	s.auth.Tag = names.NewUserTag("read")
+	_, err := s.facade.AddModelBundle(params.AddModelBundleArgs{
+		BundleDataYAML: ubuntuBundle,
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.st.CheckNoCalls(c)
+}
+
+func (s *modelBundleSuite) TestApproveModelPlan(c *gc.C) {
+	err := s.facade.ApproveModelPlan(params.ApproveModelPlanArgs{Fingerprint: "abc"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.st.CheckCall(c, 0, "ApproveBundlePlan", "abc", "write")
+}
+
+func (s *modelBundleSuite) TestApproveModelPlanPermissionDenied(c *gc.C) {
+	s.auth.Tag = names.NewUserTag("read")
+	err := s.facade.ApproveModelPlan(params.ApproveModelPlanArgs{Fingerprint: "abc"})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.st.CheckNoCalls(c)
+}
@@ -0,0 +1,704 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/juju/bundlechanges"
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/devices"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/storage"
+)
+
+// modelPlan holds the changes needed to bring a model to the state
+// described by a bundle.
+type modelPlan struct {
+	result  params.ModelPlanResult
+	changes []bundlechanges.Change
+
+	// isCAAS records whether the model is a kubernetes model.
+	isCAAS bool
+
+	// unitMachines maps the units in the model to the machines
+	// they are assigned to.
+	unitMachines map[string]string
+}
+
+// PlanModel returns the changes needed to bring the model to the state
+// described by the given bundle, along with a summary of the
+// differences. The returned fingerprint may be passed to ApplyModelPlan
+// to apply the plan only if the model has not changed in the meantime.
+func (b *BundleAPI) PlanModel(args params.ModelPlanArgs) (params.ModelPlanResult, error) {
+	if err := b.checkCanRead(); err != nil {
+		return params.ModelPlanResult{}, common.ServerError(err)
+	}
+	plan, err := b.planModel(args)
+	if err != nil {
+		return params.ModelPlanResult{}, common.ServerError(err)
+	}
+	return plan.result, nil
+}
+
+// ApplyModelPlan computes the plan for the given bundle, as PlanModel
+// does, and applies its changes in order. If a fingerprint is given and
+// it does not match the computed plan, no changes are made. Changes are
+// applied until the first failure, and the outcome of each attempted
+// change is returned.
+func (b *BundleAPI) ApplyModelPlan(args params.ModelPlanArgs) (params.ApplyModelPlanResult, error) {
+	if err := b.checkCanWrite(); err != nil {
+		return params.ApplyModelPlanResult{}, common.ServerError(err)
+	}
+	result, err := b.applyModelPlan(args)
+	if err != nil {
+		return params.ApplyModelPlanResult{}, common.ServerError(err)
+	}
+	return result, nil
+}
+
+// ModelPlanner computes and applies model plans on behalf of the
+// controller, without checking the permissions of any caller.
+type ModelPlanner struct {
+	api *BundleAPI
+}
+
+// NewModelPlanner returns a ModelPlanner which plans changes to the
+// model of the given backend, and applies them with the given applier.
+func NewModelPlanner(backend Backend, applier ModelApplier) *ModelPlanner {
+	return &ModelPlanner{api: &BundleAPI{
+		backend: backend,
+		applier: applier,
+	}}
+}
+
+// PlanModel returns the changes needed to bring the model to the state
+// described by the given bundle.
+func (p *ModelPlanner) PlanModel(args params.ModelPlanArgs) (params.ModelPlanResult, error) {
+	plan, err := p.api.planModel(args)
+	if err != nil {
+		return params.ModelPlanResult{}, errors.Trace(err)
+	}
+	return plan.result, nil
+}
+
+// ApplyModelPlan computes the plan for the given bundle and applies it,
+// as BundleAPI.ApplyModelPlan does.
+func (p *ModelPlanner) ApplyModelPlan(args params.ModelPlanArgs) (params.ApplyModelPlanResult, error) {
+	result, err := p.api.applyModelPlan(args)
+	return result, errors.Trace(err)
+}
+
+func (b *BundleAPI) applyModelPlan(args params.ModelPlanArgs) (params.ApplyModelPlanResult, error) {
+	var result params.ApplyModelPlanResult
+	plan, err := b.planModel(args)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if len(plan.result.Errors) > 0 {
+		return result, errors.Errorf("cannot apply plan: %s", strings.Join(plan.result.Errors, "; "))
+	}
+	if args.Fingerprint != "" && args.Fingerprint != plan.result.Fingerprint {
+		return result, errors.New("model has changed since the plan was made")
+	}
+
+	logger.Infof("applying model plan %s with %d changes", plan.result.Fingerprint, len(plan.changes))
+	exec := &planExecutor{
+		applier:      b.applier,
+		isCAAS:       plan.isCAAS,
+		unitMachines: plan.unitMachines,
+		channels:     make(map[string]string),
+		results:      make(map[string]string),
+	}
+	result.Results = make([]params.ModelPlanChangeResult, 0, len(plan.changes))
+	for _, change := range plan.changes {
+		value, err := exec.apply(change)
+		changeResult := params.ModelPlanChangeResult{
+			Id:     change.Id(),
+			Result: value,
+		}
+		if err != nil {
+			changeResult.Error = common.ServerError(err)
+			result.Results = append(result.Results, changeResult)
+			break
+		}
+		exec.results[change.Id()] = value
+		result.Results = append(result.Results, changeResult)
+	}
+	return result, nil
+}
+
+// modelValidators returns the validators used to verify bundles which
+// are to be applied to the model.
+func modelValidators() validators {
+	return validators{
+		verifyConstraints: func(s string) error {
+			_, err := constraints.Parse(s)
+			return err
+		},
+		verifyStorage: func(s string) error {
+			_, err := storage.ParseConstraints(s)
+			return err
+		},
+		verifyDevices: func(s string) error {
+			_, err := devices.ParseConstraints(s)
+			return err
+		},
+	}
+}
+
+func (b *BundleAPI) planModel(args params.ModelPlanArgs) (*modelPlan, error) {
+	plan := &modelPlan{}
+	data, validationErrors, err := readBundleData(args.BundleDataYAML, modelValidators())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(validationErrors) > 0 {
+		for _, e := range validationErrors {
+			plan.result.Errors = append(plan.result.Errors, e.Error())
+		}
+		return plan, nil
+	}
+
+	exported, err := b.backend.ExportPartial(b.backend.GetExportConfig())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	current := b.bundleChangesModel(exported, args.UseExistingMachines)
+	plan.isCAAS = exported.Type() == description.CAAS
+	plan.unitMachines = make(map[string]string)
+	for _, application := range exported.Applications() {
+		for _, unit := range application.Units() {
+			plan.unitMachines[unit.Name()] = unit.Machine().Id()
+		}
+	}
+
+	changesLogger := loggo.GetLogger("juju.apiserver.bundlechanges")
+	diff, err := bundlechanges.BuildDiff(bundlechanges.DiffConfig{
+		Bundle:             data,
+		Model:              current,
+		Logger:             changesLogger,
+		IncludeAnnotations: true,
+	})
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot compare bundle with model")
+	}
+	diffYAML, err := yaml.Marshal(diff)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	plan.result.Diff = string(diffYAML)
+
+	plan.changes, err = bundlechanges.FromData(bundlechanges.ChangesConfig{
+		Bundle: data,
+		Model:  current,
+		Logger: changesLogger,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	unplacedUnits := make(map[string]bool)
+	for _, change := range plan.changes {
+		if change, ok := change.(*bundlechanges.AddUnitChange); ok && change.Params.To == "" {
+			unplacedUnits[change.Id()] = true
+		}
+	}
+	plan.result.Changes = make([]*params.BundleChangesMapArgs, len(plan.changes))
+	for i, change := range plan.changes {
+		changeArgs, err := change.Args()
+		if err != nil {
+			return nil, errors.Annotatef(err, "change %q", change.Id())
+		}
+		plan.result.Changes[i] = &params.BundleChangesMapArgs{
+			Id:       change.Id(),
+			Method:   change.Method(),
+			Args:     changeArgs,
+			Requires: change.Requires(),
+		}
+		if err := checkApplicable(change, unplacedUnits); err != nil {
+			plan.result.Errors = append(plan.result.Errors, fmt.Sprintf("change %q: %v", change.Id(), err))
+		}
+	}
+	fingerprint, err := json.Marshal(plan.result.Changes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	plan.result.Fingerprint = fmt.Sprintf("%x", sha256.Sum256(fingerprint))
+	return plan, nil
+}
+
+// bundleChangesModel returns the representation of the exported model
+// used to compute bundle changes. It mirrors the model built by the
+// "juju deploy" and "juju diff-bundle" commands from the model status.
+func (b *BundleAPI) bundleChangesModel(exported description.Model, useExistingMachines bool) *bundlechanges.Model {
+	current := &bundlechanges.Model{
+		Applications: make(map[string]*bundlechanges.Application),
+		Machines:     make(map[string]*bundlechanges.Machine),
+		MachineMap:   make(map[string]string),
+		Sequence:     exported.Sequences(),
+		ConstraintsEqual: func(a, b string) bool {
+			// The bundle has already been verified, and the model
+			// constraints are known to be valid.
+			ac, _ := constraints.Parse(a)
+			bc, _ := constraints.Parse(b)
+			return reflect.DeepEqual(ac, bc)
+		},
+	}
+	for _, machine := range exported.Machines() {
+		id := machine.Id()
+		current.Machines[id] = &bundlechanges.Machine{
+			ID:          id,
+			Series:      machine.Series(),
+			Annotations: machine.Annotations(),
+		}
+		if useExistingMachines {
+			current.MachineMap[id] = id
+		}
+	}
+	isCAAS := exported.Type() == description.CAAS
+	for _, application := range exported.Applications() {
+		app := &bundlechanges.Application{
+			Name:        application.Name(),
+			Charm:       application.CharmURL(),
+			Exposed:     application.Exposed(),
+			Series:      application.Series(),
+			Options:     application.CharmConfig(),
+			Annotations: application.Annotations(),
+		}
+		if isCAAS {
+			app.Scale = application.DesiredScale()
+		}
+		// As in status, subordinate units are not listed against
+		// their application, and subordinates have no constraints.
+		if !application.Subordinate() {
+			app.Constraints = strings.Join(b.constraints(application.Constraints()), " ")
+			for _, unit := range application.Units() {
+				app.Units = append(app.Units, bundlechanges.Unit{
+					Name:    unit.Name(),
+					Machine: unit.Machine().Id(),
+				})
+			}
+		}
+		current.Applications[app.Name] = app
+	}
+	for _, relation := range exported.Relations() {
+		endpoints := relation.Endpoints()
+		// All relations have two endpoints except peers.
+		if len(endpoints) != 2 {
+			continue
+		}
+		current.Relations = append(current.Relations, bundlechanges.Relation{
+			App1:      endpoints[0].ApplicationName(),
+			Endpoint1: endpoints[0].Name(),
+			App2:      endpoints[1].ApplicationName(),
+			Endpoint2: endpoints[1].Name(),
+		})
+	}
+	return current
+}
+
+// checkApplicable returns an error if the change cannot be applied by
+// the controller. Local charms and resources must be uploaded by the
+// client, and offers are not yet supported.
+func checkApplicable(change bundlechanges.Change, unplacedUnits map[string]bool) error {
+	switch change := change.(type) {
+	case *bundlechanges.AddCharmChange:
+		curl, err := charm.ParseURL(change.Params.Charm)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if curl.Schema != "cs" {
+			return errors.NotSupportedf("charm %q which is not from the charm store", change.Params.Charm)
+		}
+		if curl.Revision < 0 {
+			return errors.NotValidf("charm URL %q without a revision", change.Params.Charm)
+		}
+	case *bundlechanges.AddApplicationChange:
+		if len(change.Params.Resources) > 0 || len(change.Params.LocalResources) > 0 {
+			return errors.NotSupportedf("resources")
+		}
+	case *bundlechanges.UpgradeCharmChange:
+		if len(change.Params.Resources) > 0 || len(change.Params.LocalResources) > 0 {
+			return errors.NotSupportedf("resources")
+		}
+	case *bundlechanges.AddMachineChange:
+		if unitPlaceholder(change.Params.ParentId, unplacedUnits) {
+			return errors.NotSupportedf("placement relative to a new unit")
+		}
+	case *bundlechanges.AddUnitChange:
+		if unitPlaceholder(change.Params.To, unplacedUnits) {
+			return errors.NotSupportedf("placement relative to a new unit")
+		}
+	case *bundlechanges.CreateOfferChange,
+		*bundlechanges.ConsumeOfferChange,
+		*bundlechanges.GrantOfferAccessChange:
+		return errors.NotSupportedf("offers")
+	}
+	return nil
+}
+
+// unitPlaceholder reports whether the placement refers to a unit added
+// by the plan without a placement of its own, whose machine cannot be
+// known until the unit is assigned.
+func unitPlaceholder(placement string, unplacedUnits map[string]bool) bool {
+	if i := strings.LastIndex(placement, ":"); i != -1 {
+		placement = placement[i+1:]
+	}
+	return strings.HasPrefix(placement, "$") && unplacedUnits[placement[1:]]
+}
+
+// planExecutor applies the changes of a model plan, keeping track of
+// the entities created by each change so that later changes can refer
+// to them.
+type planExecutor struct {
+	applier      ModelApplier
+	isCAAS       bool
+	unitMachines map[string]string
+
+	// channels maps the added charms to the channels they were
+	// added from.
+	channels map[string]string
+
+	// results maps change ids to the names of the entities they
+	// created.
+	results map[string]string
+}
+
+// apply applies the change and returns the name of the entity it
+// created, if any.
+func (e *planExecutor) apply(change bundlechanges.Change) (string, error) {
+	switch change := change.(type) {
+	case *bundlechanges.AddCharmChange:
+		return e.addCharm(change)
+	case *bundlechanges.AddApplicationChange:
+		return e.addApplication(change)
+	case *bundlechanges.AddMachineChange:
+		return e.addMachine(change)
+	case *bundlechanges.AddUnitChange:
+		return e.addUnit(change)
+	case *bundlechanges.AddRelationChange:
+		return "", e.addRelation(change)
+	case *bundlechanges.ExposeChange:
+		return "", e.expose(change)
+	case *bundlechanges.UpgradeCharmChange:
+		return "", e.upgradeCharm(change)
+	case *bundlechanges.SetOptionsChange:
+		return "", e.setOptions(change)
+	case *bundlechanges.SetConstraintsChange:
+		return "", e.setConstraints(change)
+	case *bundlechanges.ScaleChange:
+		return "", e.scale(change)
+	case *bundlechanges.SetAnnotationsChange:
+		return "", e.setAnnotations(change)
+	}
+	return "", errors.NotSupportedf("change %q", change.Method())
+}
+
+func (e *planExecutor) addCharm(change *bundlechanges.AddCharmChange) (string, error) {
+	p := change.Params
+	curl, err := charm.ParseURL(p.Charm)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if err := e.applier.AddCharm(params.AddCharmWithAuthorization{
+		URL:     curl.String(),
+		Channel: p.Channel,
+	}); err != nil {
+		return "", errors.Annotatef(err, "cannot add charm %q", curl)
+	}
+	e.channels[curl.String()] = p.Channel
+	return curl.String(), nil
+}
+
+func (e *planExecutor) addApplication(change *bundlechanges.AddApplicationChange) (string, error) {
+	p := change.Params
+	curl, err := charm.ParseURL(e.resolve(p.Charm))
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	series := p.Series
+	if series == "" {
+		series = curl.Series
+	}
+	cons, err := constraints.Parse(p.Constraints)
+	if err != nil {
+		return "", errors.Annotate(err, "invalid constraints for application")
+	}
+	var storageConstraints map[string]storage.Constraints
+	if len(p.Storage) > 0 {
+		storageConstraints = make(map[string]storage.Constraints)
+		for name, value := range p.Storage {
+			sc, err := storage.ParseConstraints(value)
+			if err != nil {
+				return "", errors.Annotate(err, "invalid storage constraints")
+			}
+			storageConstraints[name] = sc
+		}
+	}
+	var deviceConstraints map[string]devices.Constraints
+	if len(p.Devices) > 0 {
+		deviceConstraints = make(map[string]devices.Constraints)
+		for name, value := range p.Devices {
+			dc, err := devices.ParseConstraints(value)
+			if err != nil {
+				return "", errors.Annotate(err, "invalid device constraints")
+			}
+			deviceConstraints[name] = dc
+		}
+	}
+	configYAML := ""
+	if len(p.Options) > 0 {
+		config, err := yaml.Marshal(map[string]map[string]interface{}{p.Application: p.Options})
+		if err != nil {
+			return "", errors.Annotatef(err, "cannot marshal options for application %q", p.Application)
+		}
+		configYAML = string(config)
+	}
+	// As with "juju deploy", only kubernetes applications are
+	// deployed with units; units of other applications are added
+	// by separate changes.
+	numUnits := 0
+	if e.isCAAS {
+		numUnits = p.NumUnits
+	}
+	if err := e.applier.Deploy(params.ApplicationDeploy{
+		ApplicationName:  p.Application,
+		Series:           series,
+		CharmURL:         curl.String(),
+		Channel:          e.channels[curl.String()],
+		NumUnits:         numUnits,
+		ConfigYAML:       configYAML,
+		Constraints:      cons,
+		Storage:          storageConstraints,
+		Devices:          deviceConstraints,
+		EndpointBindings: p.EndpointBindings,
+	}); err != nil {
+		return "", errors.Annotatef(err, "cannot deploy application %q", p.Application)
+	}
+	return p.Application, nil
+}
+
+func (e *planExecutor) addMachine(change *bundlechanges.AddMachineChange) (string, error) {
+	p := change.Params
+	cons, err := constraints.Parse(p.Constraints)
+	if err != nil {
+		return "", errors.Annotate(err, "invalid constraints for machine")
+	}
+	machineParams := params.AddMachineParams{
+		Constraints: cons,
+		Series:      p.Series,
+		Jobs:        []model.MachineJob{model.JobHostUnits},
+	}
+	if ct := p.ContainerType; ct != "" {
+		// For backwards compatibility with 1.x bundles, lxc
+		// containers are deployed as lxd containers.
+		if ct == "lxc" {
+			ct = string(instance.LXD)
+		}
+		containerType, err := instance.ParseContainerType(ct)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		machineParams.ContainerType = containerType
+		if p.ParentId != "" {
+			id, err := e.resolveMachine(p.ParentId)
+			if err != nil {
+				return "", errors.Annotate(err, "cannot resolve parent machine")
+			}
+			// Never create nested containers for deployment.
+			if names.IsContainerMachine(id) {
+				id = names.NewMachineTag(id).Parent().Id()
+			}
+			machineParams.ParentId = id
+		}
+	}
+	machine, err := e.applier.AddMachine(machineParams)
+	if err != nil {
+		return "", errors.Annotate(err, "cannot add machine")
+	}
+	return machine, nil
+}
+
+func (e *planExecutor) addUnit(change *bundlechanges.AddUnitChange) (string, error) {
+	p := change.Params
+	applicationName := e.resolve(p.Application)
+	var placement []*instance.Placement
+	targetMachine := ""
+	if p.To != "" {
+		// The placement may be "container:machine".
+		container, target := "", p.To
+		if parts := strings.SplitN(target, ":", 2); len(parts) > 1 {
+			container, target = parts[0], parts[1]
+		}
+		var err error
+		targetMachine, err = e.resolveMachine(target)
+		if err != nil {
+			return "", errors.Annotatef(err, "cannot resolve placement for %q unit", applicationName)
+		}
+		directive := targetMachine
+		if container != "" {
+			directive = container + ":" + directive
+		}
+		directivePlacement, err := instance.ParsePlacement(directive)
+		if err != nil {
+			return "", errors.Annotatef(err, "invalid placement %q", directive)
+		}
+		placement = append(placement, directivePlacement)
+	}
+	unit, err := e.applier.AddUnit(params.AddApplicationUnits{
+		ApplicationName: applicationName,
+		NumUnits:        1,
+		Placement:       placement,
+	})
+	if err != nil {
+		return "", errors.Annotatef(err, "cannot add unit for application %q", applicationName)
+	}
+	if targetMachine != "" {
+		e.unitMachines[unit] = targetMachine
+	}
+	return unit, nil
+}
+
+func (e *planExecutor) addRelation(change *bundlechanges.AddRelationChange) error {
+	ep1 := e.resolveEndpoint(change.Params.Endpoint1)
+	ep2 := e.resolveEndpoint(change.Params.Endpoint2)
+	err := e.applier.AddRelation([]string{ep1, ep2})
+	if err != nil && !errors.IsAlreadyExists(err) {
+		return errors.Annotatef(err, "cannot add relation between %q and %q", ep1, ep2)
+	}
+	return nil
+}
+
+func (e *planExecutor) expose(change *bundlechanges.ExposeChange) error {
+	application := e.resolve(change.Params.Application)
+	if err := e.applier.Expose(application); err != nil {
+		return errors.Annotatef(err, "cannot expose application %q", application)
+	}
+	return nil
+}
+
+func (e *planExecutor) upgradeCharm(change *bundlechanges.UpgradeCharmChange) error {
+	p := change.Params
+	curl, err := charm.ParseURL(e.resolve(p.Charm))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Bundles only ever deal with the current generation.
+	if err := e.applier.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: p.Application,
+		Generation:      model.GenerationMaster,
+		CharmURL:        curl.String(),
+		Channel:         e.channels[curl.String()],
+	}); err != nil {
+		return errors.Annotatef(err, "cannot upgrade charm of application %q", p.Application)
+	}
+	return nil
+}
+
+func (e *planExecutor) setOptions(change *bundlechanges.SetOptionsChange) error {
+	p := change.Params
+	config, err := yaml.Marshal(map[string]map[string]interface{}{p.Application: p.Options})
+	if err != nil {
+		return errors.Annotatef(err, "cannot marshal options for application %q", p.Application)
+	}
+	if err := e.applier.Update(params.ApplicationUpdate{
+		ApplicationName: p.Application,
+		SettingsYAML:    string(config),
+		Generation:      model.GenerationMaster,
+	}); err != nil {
+		return errors.Annotatef(err, "cannot update options for application %q", p.Application)
+	}
+	return nil
+}
+
+func (e *planExecutor) setConstraints(change *bundlechanges.SetConstraintsChange) error {
+	p := change.Params
+	cons, err := constraints.Parse(p.Constraints)
+	if err != nil {
+		return errors.Annotate(err, "invalid constraints for application")
+	}
+	if err := e.applier.SetConstraints(params.SetConstraints{
+		ApplicationName: p.Application,
+		Constraints:     cons,
+	}); err != nil {
+		return errors.Annotatef(err, "cannot update constraints for application %q", p.Application)
+	}
+	return nil
+}
+
+func (e *planExecutor) scale(change *bundlechanges.ScaleChange) error {
+	p := change.Params
+	if err := e.applier.Scale(p.Application, p.Scale); err != nil {
+		return errors.Annotatef(err, "cannot scale application %q", p.Application)
+	}
+	return nil
+}
+
+func (e *planExecutor) setAnnotations(change *bundlechanges.SetAnnotationsChange) error {
+	p := change.Params
+	id := e.resolve(p.Id)
+	var tag names.Tag
+	switch p.EntityType {
+	case bundlechanges.MachineType:
+		tag = names.NewMachineTag(id)
+	case bundlechanges.ApplicationType:
+		tag = names.NewApplicationTag(id)
+	default:
+		return errors.Errorf("unexpected annotation entity type %q", p.EntityType)
+	}
+	if err := e.applier.SetAnnotations(tag, p.Annotations); err != nil {
+		return errors.Annotatef(err, "cannot set annotations for %s %q", p.EntityType, id)
+	}
+	return nil
+}
+
+// resolve returns the name of the entity referred to by the given
+// placeholder. Placeholders such as "$addMachine-3" refer to the
+// results of earlier changes; anything else refers to an existing
+// entity and is returned unchanged.
+func (e *planExecutor) resolve(placeholder string) string {
+	if !strings.HasPrefix(placeholder, "$") {
+		return placeholder
+	}
+	return e.results[placeholder[1:]]
+}
+
+// resolveEndpoint resolves the application placeholder in a relation
+// endpoint.
+func (e *planExecutor) resolveEndpoint(endpoint string) string {
+	parts := strings.SplitN(endpoint, ":", 2)
+	application := e.resolve(parts[0])
+	if len(parts) == 1 {
+		return application
+	}
+	return application + ":" + parts[1]
+}
+
+// resolveMachine returns the id of the machine referred to by the given
+// placeholder, which may refer to a machine or to a unit on it.
+func (e *planExecutor) resolveMachine(placeholder string) (string, error) {
+	machineOrUnit := e.resolve(placeholder)
+	if !names.IsValidUnit(machineOrUnit) {
+		return machineOrUnit, nil
+	}
+	machine := e.unitMachines[machineOrUnit]
+	if machine == "" {
+		return "", errors.Errorf("unit %q is not assigned to a machine", machineOrUnit)
+	}
+	return machine, nil
+}
@@ -0,0 +1,305 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/facades/client/bundle"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type planSuite struct {
+	coretesting.BaseSuite
+	auth    *apiservertesting.FakeAuthorizer
+	st      *mockState
+	applier *fakeApplier
+	facade  *bundle.APIv5
+}
+
+var _ = gc.Suite(&planSuite{})
+
+const ubuntuBundle = `
+series: trusty
+applications:
+  ubuntu:
+    charm: cs:trusty/ubuntu-10
+    num_units: 1
+`
+
+func (s *planSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.auth = &apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("write"),
+	}
+	s.st = newMockState()
+	s.st.model = description.NewModel(description.ModelArgs{
+		Type:  description.IAAS,
+		Owner: names.NewUserTag("magic"),
+		Config: map[string]interface{}{
+			"name": "awesome",
+			"uuid": "some-uuid",
+		},
+		CloudRegion: "some-region",
+	})
+	s.applier = &fakeApplier{}
+	s.facade = s.makeAPI(c)
+}
+
+func (s *planSuite) makeAPI(c *gc.C) *bundle.APIv5 {
+	api, err := bundle.NewBundleAPIv5(s.st, s.applier, s.auth, names.NewModelTag("some-uuid"))
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func changeMethods(changes []*params.BundleChangesMapArgs) []string {
+	var methods []string
+	for _, change := range changes {
+		methods = append(methods, change.Method)
+	}
+	return methods
+}
+
+func (s *planSuite) TestPlanModel(c *gc.C) {
+	result, err := s.facade.PlanModel(params.ModelPlanArgs{
+		BundleDataYAML: ubuntuBundle,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Errors, gc.HasLen, 0)
+	c.Check(changeMethods(result.Changes), jc.DeepEquals, []string{"addCharm", "deploy", "addUnit"})
+	c.Check(result.Fingerprint, gc.Not(gc.Equals), "")
+	c.Check(result.Diff, gc.Matches, `(?s).*ubuntu:\n\s+missing: model.*`)
+	s.st.CheckCallNames(c, "ExportPartial")
+}
+
+func (s *planSuite) TestPlanModelIsStable(c *gc.C) {
+	args := params.ModelPlanArgs{BundleDataYAML: ubuntuBundle}
+	first, err := s.facade.PlanModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+	second, err := s.facade.PlanModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(second.Fingerprint, gc.Equals, first.Fingerprint)
+}
+
+func (s *planSuite) TestPlanModelNoChanges(c *gc.C) {
+	s.st.model.AddMachine(description.MachineArgs{
+		Id:     names.NewMachineTag("0"),
+		Series: "trusty",
+	})
+	app := s.st.model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("ubuntu"),
+		Series:   "trusty",
+		CharmURL: "cs:trusty/ubuntu-10",
+	})
+	app.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("ubuntu/0"),
+		Machine: names.NewMachineTag("0"),
+	})
+
+	result, err := s.facade.PlanModel(params.ModelPlanArgs{
+		BundleDataYAML: `
+series: trusty
+applications:
+  ubuntu:
+    charm: cs:trusty/ubuntu-10
+    num_units: 1
+    to: ["0"]
+machines:
+  "0": {}
+`,
+		UseExistingMachines: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Errors, gc.HasLen, 0)
+	c.Check(result.Changes, gc.HasLen, 0)
+}
+
+func (s *planSuite) TestPlanModelVerificationErrors(c *gc.C) {
+	result, err := s.facade.PlanModel(params.ModelPlanArgs{
+		BundleDataYAML: `
+applications:
+  ubuntu:
+    charm: cs:trusty/ubuntu-10
+    num_units: -1
+`,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Errors, jc.DeepEquals, []string{
+		`negative number of units specified on application "ubuntu"`,
+	})
+	c.Check(result.Changes, gc.HasLen, 0)
+	s.st.CheckNoCalls(c)
+}
+
+func (s *planSuite) TestPlanModelUnrevisionedCharm(c *gc.C) {
+	result, err := s.facade.PlanModel(params.ModelPlanArgs{
+		BundleDataYAML: `
+series: trusty
+applications:
+  ubuntu:
+    charm: cs:trusty/ubuntu
+`,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result.Errors, jc.DeepEquals, []string{
+		`change "addCharm-0": charm URL "cs:trusty/ubuntu" without a revision not valid`,
+	})
+}
+
+func (s *planSuite) TestPlanModelPermissionDenied(c *gc.C) {
+	s.auth.Tag = names.NewUserTag("nobody")
+	_, err := s.facade.PlanModel(params.ModelPlanArgs{BundleDataYAML: ubuntuBundle})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *planSuite) TestApplyModelPlan(c *gc.C) {
+	plan, err := s.facade.PlanModel(params.ModelPlanArgs{BundleDataYAML: ubuntuBundle})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.facade.ApplyModelPlan(params.ModelPlanArgs{
+		BundleDataYAML: ubuntuBundle,
+		Fingerprint:    plan.Fingerprint,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.ModelPlanChangeResult{
+		{Id: "addCharm-0", Result: "cs:trusty/ubuntu-10"},
+		{Id: "deploy-1", Result: "ubuntu"},
+		{Id: "addUnit-2", Result: "ubuntu/0"},
+	})
+	s.applier.CheckCallNames(c, "AddCharm", "Deploy", "AddUnit")
+	s.applier.CheckCall(c, 0, "AddCharm", params.AddCharmWithAuthorization{
+		URL: "cs:trusty/ubuntu-10",
+	})
+	deploy := s.applier.Calls()[1].Args[0].(params.ApplicationDeploy)
+	c.Check(deploy.ApplicationName, gc.Equals, "ubuntu")
+	c.Check(deploy.CharmURL, gc.Equals, "cs:trusty/ubuntu-10")
+	c.Check(deploy.Series, gc.Equals, "trusty")
+	c.Check(deploy.NumUnits, gc.Equals, 0)
+	s.applier.CheckCall(c, 2, "AddUnit", params.AddApplicationUnits{
+		ApplicationName: "ubuntu",
+		NumUnits:        1,
+	})
+}
+
+func (s *planSuite) TestApplyModelPlanModelChanged(c *gc.C) {
+	_, err := s.facade.ApplyModelPlan(params.ModelPlanArgs{
+		BundleDataYAML: ubuntuBundle,
+		Fingerprint:    "stale",
+	})
+	c.Assert(err, gc.ErrorMatches, "model has changed since the plan was made")
+	s.applier.CheckNoCalls(c)
+}
+
+func (s *planSuite) TestApplyModelPlanWithErrors(c *gc.C) {
+	_, err := s.facade.ApplyModelPlan(params.ModelPlanArgs{
+		BundleDataYAML: `
+series: trusty
+applications:
+  ubuntu:
+    charm: cs:trusty/ubuntu
+`,
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot apply plan: change "addCharm-0": .*`)
+	s.applier.CheckNoCalls(c)
+}
+
+func (s *planSuite) TestApplyModelPlanStopsAtFailure(c *gc.C) {
+	s.applier.SetErrors(nil, errors.New("boom"))
+	result, err := s.facade.ApplyModelPlan(params.ModelPlanArgs{BundleDataYAML: ubuntuBundle})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Check(result.Results[0], jc.DeepEquals, params.ModelPlanChangeResult{
+		Id: "addCharm-0", Result: "cs:trusty/ubuntu-10",
+	})
+	c.Check(result.Results[1].Id, gc.Equals, "deploy-1")
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `cannot deploy application "ubuntu": boom`)
+	s.applier.CheckCallNames(c, "AddCharm", "Deploy")
+}
+
+func (s *planSuite) TestApplyModelPlanPermissionDenied(c *gc.C) {
+	s.auth.Tag = names.NewUserTag("read")
+	_, err := s.facade.ApplyModelPlan(params.ModelPlanArgs{BundleDataYAML: ubuntuBundle})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.applier.CheckNoCalls(c)
+}
+
+func (s *planSuite) TestModelPlannerIgnoresPermissions(c *gc.C) {
+	s.auth.Tag = names.NewUserTag("nobody")
+	planner := bundle.NewModelPlanner(s.st, s.applier)
+	plan, err := planner.PlanModel(params.ModelPlanArgs{BundleDataYAML: ubuntuBundle})
+	c.Assert(err, jc.ErrorIsNil)
+	result, err := planner.ApplyModelPlan(params.ModelPlanArgs{
+		BundleDataYAML: ubuntuBundle,
+		Fingerprint:    plan.Fingerprint,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	s.applier.CheckCallNames(c, "AddCharm", "Deploy", "AddUnit")
+}
+
+type fakeApplier struct {
+	testing.Stub
+}
+
+func (f *fakeApplier) AddCharm(args params.AddCharmWithAuthorization) error {
+	f.MethodCall(f, "AddCharm", args)
+	return f.NextErr()
+}
+
+func (f *fakeApplier) Deploy(args params.ApplicationDeploy) error {
+	f.MethodCall(f, "Deploy", args)
+	return f.NextErr()
+}
+
+func (f *fakeApplier) AddMachine(args params.AddMachineParams) (string, error) {
+	f.MethodCall(f, "AddMachine", args)
+	return "0", f.NextErr()
+}
+
+func (f *fakeApplier) AddUnit(args params.AddApplicationUnits) (string, error) {
+	f.MethodCall(f, "AddUnit", args)
+	return args.ApplicationName + "/0", f.NextErr()
+}
+
+func (f *fakeApplier) AddRelation(endpoints []string) error {
+	f.MethodCall(f, "AddRelation", endpoints)
+	return f.NextErr()
+}
+
+func (f *fakeApplier) Expose(application string) error {
+	f.MethodCall(f, "Expose", application)
+	return f.NextErr()
+}
+
+func (f *fakeApplier) SetCharm(args params.ApplicationSetCharm) error {
+	f.MethodCall(f, "SetCharm", args)
+	return f.NextErr()
+}
+
+func (f *fakeApplier) Update(args params.ApplicationUpdate) error {
+	f.MethodCall(f, "Update", args)
+	return f.NextErr()
+}
+
+func (f *fakeApplier) SetConstraints(args params.SetConstraints) error {
+	f.MethodCall(f, "SetConstraints", args)
+	return f.NextErr()
+}
+
+func (f *fakeApplier) Scale(application string, scale int) error {
+	f.MethodCall(f, "Scale", application, scale)
+	return f.NextErr()
+}
+
+func (f *fakeApplier) SetAnnotations(tag names.Tag, values map[string]string) error {
+	f.MethodCall(f, "SetAnnotations", tag, values)
+	return f.NextErr()
+}
@@ -12,6 +12,8 @@ import (
 type Backend interface {
 	ExportPartial(cfg state.ExportConfig) (description.Model, error)
 	GetExportConfig() state.ExportConfig
+	AddModelBundle(bundle, createdBy string) (state.ModelBundle, error)
+	ApproveBundlePlan(fingerprint, approvedBy string) error
 	state.EndpointBinding
 }
 
@@ -51,6 +51,12 @@ func (ctx *charmsSuiteContext) LeadershipPinner(string) (leadership.Pinner, erro
 func (ctx *charmsSuiteContext) LeadershipReader(string) (leadership.Reader, error)   { return nil, nil }
 func (ctx *charmsSuiteContext) SingularClaimer() (lease.Claimer, error)              { return nil, nil }
 
+func (ctx *charmsSuiteContext) LeaseInspector(string, string) (lease.Inspector, error) {
+	return nil, nil
+}
+func (ctx *charmsSuiteContext) LeaseRevoker(string, string) (lease.Revoker, error) { return nil, nil }
+func (ctx *charmsSuiteContext) LeaseLister(string) (lease.Lister, error)           { return nil, nil }
+
 func (s *charmsSuite) SetUpTest(c *gc.C) {
 	s.JujuConnSuite.SetUpTest(c)
 
@@ -46,6 +46,7 @@ type Backend interface {
 	APIHostPortsForClients() ([]network.SpaceHostPorts, error)
 	Application(string) (*state.Application, error)
 	Charm(*charm.URL) (*state.Charm, error)
+	CheckModelQuota(string, int) error
 	ControllerConfig() (controller.Config, error)
 	ControllerNodes() ([]state.ControllerNode, error)
 	ControllerTag() names.ControllerTag
@@ -69,6 +70,7 @@ type Backend interface {
 	SetAnnotations(state.GlobalEntity, map[string]string) error
 	SetModelAgentVersion(version.Number, bool) error
 	SetModelConstraints(constraints.Value) error
+	StatusHistoryPage(names.Tag, status.HistoryKind, status.StatusHistoryFilter, int, string) (state.StatusHistoryPage, error)
 	Unit(string) (Unit, error)
 	UpdateModelConfig(map[string]interface{}, []string, ...state.ValidateConfigFunc) error
 	Watch(params state.WatchParams) *state.Multiwatcher
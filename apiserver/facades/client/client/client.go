@@ -19,11 +19,13 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/modelconfig"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
@@ -73,6 +75,21 @@ type Client struct {
 	callContext context.ProviderCallContext
 }
 
+// ClientV4 serves the (v4) client-specific API methods.
+type ClientV4 struct {
+	*Client
+}
+
+// ClientV3 serves the (v3) client-specific API methods.
+type ClientV3 struct {
+	*Client
+}
+
+// ClientV2 serves the (v2) client-specific API methods.
+type ClientV2 struct {
+	*Client
+}
+
 // ClientV1 serves the (v1) client-specific API methods.
 type ClientV1 struct {
 	*Client
@@ -126,11 +143,38 @@ func (c *Client) checkIsAdmin() error {
 	return nil
 }
 
-// NewFacade creates a version 1 Client facade to handle API requests.
+// NewFacade creates a version 5 Client facade to handle API requests.
 func NewFacade(ctx facade.Context) (*Client, error) {
 	return newFacade(ctx)
 }
 
+// NewFacadeV4 creates a version 4 Client facade to handle API requests.
+func NewFacadeV4(ctx facade.Context) (*ClientV4, error) {
+	client, err := newFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ClientV4{client}, nil
+}
+
+// NewFacadeV3 creates a version 3 Client facade to handle API requests.
+func NewFacadeV3(ctx facade.Context) (*ClientV3, error) {
+	client, err := newFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ClientV3{client}, nil
+}
+
+// NewFacadeV2 creates a version 2 Client facade to handle API requests.
+func NewFacadeV2(ctx facade.Context) (*ClientV2, error) {
+	client, err := newFacade(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ClientV2{client}, nil
+}
+
 // NewFacadeV1 creates a version 1 Client facade to handle API requests.
 func NewFacadeV1(ctx facade.Context) (*ClientV1, error) {
 	client, err := newFacade(ctx)
@@ -190,7 +234,7 @@ func newFacade(ctx facade.Context) (*Client, error) {
 	return NewClient(
 		&stateShim{st, model},
 		&poolShim{ctx.StatePool()},
-		&modelconfig.ModelConfigAPIV1{modelConfigAPI},
+		&modelconfig.ModelConfigAPIV1{&modelconfig.ModelConfigAPIV2{&modelconfig.ModelConfigAPIV3{modelConfigAPI}}},
 		resources,
 		authorizer,
 		presence,
@@ -436,6 +480,15 @@ func (c *Client) addOneMachine(p params.AddMachineParams) (*state.Machine, error
 		p.Addrs = nil
 	}
 
+	// A container without a parent is added inside a new machine.
+	newMachines := 1
+	if p.ContainerType != "" && p.ParentId == "" {
+		newMachines = 2
+	}
+	if err := c.api.stateAccessor.CheckModelQuota(controller.MaxModelMachines, newMachines); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	if p.Series == "" {
 		conf, err := c.api.stateAccessor.ModelConfig()
 		if err != nil {
@@ -763,7 +816,7 @@ func (c *Client) RetryProvisioning(p params.Entities) (params.ErrorResults, erro
 	}
 	entityStatus := make([]params.EntityStatusArgs, len(p.Entities))
 	for i, entity := range p.Entities {
-		entityStatus[i] = params.EntityStatusArgs{Tag: entity.Tag, Data: map[string]interface{}{"transient": true}}
+		entityStatus[i] = params.EntityStatusArgs{Tag: entity.Tag, Data: map[string]interface{}{status.TransientKey: true}}
 	}
 	return c.api.statusSetter.UpdateStatus(params.SetStatus{
 		Entities: entityStatus,
@@ -1221,6 +1221,27 @@ func (s *clientSuite) TestClientAddMachineInsideMachine(c *gc.C) {
 	c.Assert(machines[0].Machine, gc.Equals, "0/lxd/0")
 }
 
+func (s *clientSuite) TestClientAddMachinesQuotaExceeded(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.MaxModelMachines: 2,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	machines, err := s.APIState.Client().AddMachines([]params.AddMachineParams{{
+		Jobs:   []model.MachineJob{model.JobHostUnits},
+		Series: "quantal",
+	}, {
+		Jobs:          []model.MachineJob{model.JobHostUnits},
+		ContainerType: instance.LXD,
+		Series:        "quantal",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(machines, gc.HasLen, 2)
+	c.Assert(machines[0].Error, gc.IsNil)
+	c.Assert(machines[1].Error, jc.Satisfies, params.IsCodeQuotaLimitExceeded)
+	c.Assert(machines[1].Error, gc.ErrorMatches, `cannot add 2 machines: model has 1 of 2 allowed by max-model-machines`)
+}
+
 func (s *clientSuite) TestClientAddMachinesWithConstraints(c *gc.C) {
 	apiParams := make([]params.AddMachineParams, 3)
 	for i := 0; i < 3; i++ {
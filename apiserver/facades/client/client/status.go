@@ -15,6 +15,7 @@ import (
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/application"
 	"github.com/juju/juju/apiserver/params"
 	k8sspecs "github.com/juju/juju/caas/kubernetes/provider/specs"
 	"github.com/juju/juju/core/cache"
@@ -31,11 +32,13 @@ func agentStatusFromStatusInfo(s []status.StatusInfo, kind status.HistoryKind) [
 	result := []params.DetailedStatus{}
 	for _, v := range s {
 		result = append(result, params.DetailedStatus{
-			Status: string(v.Status),
-			Info:   v.Message,
-			Data:   v.Data,
-			Since:  v.Since,
-			Kind:   string(kind),
+			Status:     string(v.Status),
+			Info:       v.Message,
+			Data:       v.Data,
+			Since:      v.Since,
+			Kind:       string(kind),
+			FirstSince: v.FirstSince,
+			Count:      v.Count,
 		})
 	}
 	return result
@@ -94,9 +97,12 @@ func (c *Client) machineStatusHistory(machineTag names.MachineTag, filter status
 		return nil, errors.Trace(err)
 	}
 	var sInfo []status.StatusInfo
-	if kind == status.KindMachineInstance || kind == status.KindContainerInstance {
+	switch kind {
+	case status.KindMachineInstance, status.KindContainerInstance:
 		sInfo, err = machine.InstanceStatusHistory(filter)
-	} else {
+	case status.KindMachineProvisioning:
+		sInfo, err = machine.ProvisioningTimeline(filter)
+	default:
 		sInfo, err = machine.StatusHistory(filter)
 	}
 	if err != nil {
@@ -105,7 +111,51 @@ func (c *Client) machineStatusHistory(machineTag names.MachineTag, filter status
 	return agentStatusFromStatusInfo(sInfo, kind), nil
 }
 
+// applicationStatusHistory returns status history for the given application.
+func (c *Client) applicationStatusHistory(appTag names.ApplicationTag, filter status.StatusHistoryFilter) ([]params.DetailedStatus, error) {
+	app, err := c.api.stateAccessor.Application(appTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sInfo, err := app.StatusHistory(filter)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return agentStatusFromStatusInfo(sInfo, status.KindApplication), nil
+}
+
+// applicationUnitsStatusHistory returns the interleaved status history
+// of all the units of the given application.
+func (c *Client) applicationUnitsStatusHistory(appTag names.ApplicationTag, filter status.StatusHistoryFilter, kind status.HistoryKind) ([]params.DetailedStatus, error) {
+	app, err := c.api.stateAccessor.Application(appTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	history, err := app.UnitsStatusHistory(kind, filter)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]params.DetailedStatus, len(history))
+	for i, h := range history {
+		result[i] = params.DetailedStatus{
+			Status:     string(h.Status),
+			Info:       h.Message,
+			Data:       h.Data,
+			Since:      h.Since,
+			Kind:       string(h.Kind),
+			Unit:       h.Unit,
+			FirstSince: h.FirstSince,
+			Count:      h.Count,
+		}
+	}
+	return result, nil
+}
+
 // StatusHistory returns a slice of past statuses for several entities.
+// Since version 3 of the facade, the filter may bound the history with
+// Until, and application status history may be requested. Since
+// version 4, requesting the unit, workload or unit agent history of an
+// application returns the interleaved history of all its units.
 func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.StatusHistoryResults {
 	results := params.StatusHistoryResults{}
 	// TODO(perrito666) the contents of the loop could be split into
@@ -116,6 +166,7 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 			FromDate: request.Filter.Date,
 			Delta:    request.Filter.Delta,
 			Exclude:  set.NewStrings(request.Filter.Exclude...),
+			Until:    request.Filter.Until,
 		}
 		if err := c.checkCanRead(); err != nil {
 			history := params.StatusHistoryResult{
@@ -141,9 +192,22 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 		kind := status.HistoryKind(request.Kind)
 		switch kind {
 		case status.KindUnit, status.KindWorkload, status.KindUnitAgent:
-			var u names.UnitTag
-			if u, err = names.ParseUnitTag(request.Tag); err == nil {
-				hist, err = c.unitStatusHistory(u, filter, kind)
+			var tag names.Tag
+			if tag, err = names.ParseTag(request.Tag); err != nil {
+				break
+			}
+			switch tag := tag.(type) {
+			case names.UnitTag:
+				hist, err = c.unitStatusHistory(tag, filter, kind)
+			case names.ApplicationTag:
+				hist, err = c.applicationUnitsStatusHistory(tag, filter, kind)
+			default:
+				err = errors.NotValidf("%s status history for %q", kind, request.Tag)
+			}
+		case status.KindApplication:
+			var a names.ApplicationTag
+			if a, err = names.ParseApplicationTag(request.Tag); err == nil {
+				hist, err = c.applicationStatusHistory(a, filter)
 			}
 		default:
 			var m names.MachineTag
@@ -165,6 +229,59 @@ func (c *Client) StatusHistory(request params.StatusHistoryRequests) params.Stat
 	return results
 }
 
+// StatusHistoryPage returns a page of the status history of an entity,
+// oldest first, so that a long history can be read a page at a time.
+// The filter may not have a Size or a Delta.
+func (c *Client) StatusHistoryPage(args params.StatusHistoryPageRequest) (params.StatusHistoryPageResult, error) {
+	if err := c.checkCanRead(); err != nil {
+		return params.StatusHistoryPageResult{}, err
+	}
+	tag, err := names.ParseTag(args.Tag)
+	if err != nil {
+		return params.StatusHistoryPageResult{}, errors.Trace(err)
+	}
+	filter := status.StatusHistoryFilter{
+		Size:     args.Filter.Size,
+		FromDate: args.Filter.Date,
+		Delta:    args.Filter.Delta,
+		Exclude:  set.NewStrings(args.Filter.Exclude...),
+		Until:    args.Filter.Until,
+	}
+	page, err := c.api.stateAccessor.StatusHistoryPage(tag, status.HistoryKind(args.Kind), filter, args.PageSize, args.Cursor)
+	if err != nil {
+		return params.StatusHistoryPageResult{}, errors.Trace(err)
+	}
+	result := params.StatusHistoryPageResult{
+		Statuses: make([]params.DetailedStatus, len(page.Statuses)),
+		Next:     page.Next,
+	}
+	for i, h := range page.Statuses {
+		result.Statuses[i] = params.DetailedStatus{
+			Status:     string(h.Status),
+			Info:       h.Message,
+			Data:       h.Data,
+			Since:      h.Since,
+			Kind:       string(h.Kind),
+			Unit:       h.Unit,
+			FirstSince: h.FirstSince,
+			Count:      h.Count,
+		}
+	}
+	return result, nil
+}
+
+// StatusHistoryPage isn't on the v4 API.
+func (c *ClientV4) StatusHistoryPage(_, _ struct{}) {}
+
+// StatusHistoryPage isn't on the v3 API.
+func (c *ClientV3) StatusHistoryPage(_, _ struct{}) {}
+
+// StatusHistoryPage isn't on the v2 API.
+func (c *ClientV2) StatusHistoryPage(_, _ struct{}) {}
+
+// StatusHistoryPage isn't on the v1 API.
+func (c *ClientV1) StatusHistoryPage(_, _ struct{}) {}
+
 // FullStatus gives the information needed for juju status over the api
 func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error) {
 	if err := c.checkCanRead(); err != nil {
@@ -188,6 +305,9 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	if context.model, err = c.api.stateAccessor.Model(); err != nil {
 		return noStatus, errors.Annotate(err, "could not fetch model")
 	}
+	if c.useCachedStatus(args, &context) {
+		return c.cachedFullStatus(context)
+	}
 	if context.status, err = context.model.LoadModelStatus(); err != nil {
 		return noStatus, errors.Annotate(err, "could not load model status values")
 	}
@@ -202,7 +322,7 @@ func (c *Client) FullStatus(args params.StatusParams) (params.FullStatus, error)
 	// Only admins can see offer details.
 	if err := c.checkIsAdmin(); err == nil {
 		if context.offers, err =
-			fetchOffers(c.api.stateAccessor, context.allAppsUnitsCharmBindings.applications); err != nil {
+			fetchOffers(c.api.stateAccessor, context.allAppsUnitsCharmBindings.charmURLs()); err != nil {
 			return noStatus, errors.Annotate(err, "could not fetch application offers")
 		}
 	}
@@ -488,6 +608,18 @@ type applicationStatusInfo struct {
 	lxdProfiles map[string]*charm.LXDProfile
 }
 
+// charmURLs returns a map from application name to the URL of the
+// application's charm.
+func (i applicationStatusInfo) charmURLs() map[string]string {
+	urls := make(map[string]string, len(i.applications))
+	for name, app := range i.applications {
+		if curl, _ := app.CharmURL(); curl != nil {
+			urls[name] = curl.String()
+		}
+	}
+	return urls
+}
+
 type statusContext struct {
 	providerType string
 	model        *state.Model
@@ -526,6 +658,18 @@ type statusContext struct {
 	latestCharms              map[charm.URL]*state.Charm
 	leaders                   map[string]string
 	branches                  map[string]cache.Branch
+
+	// view is set when machines, applications and units are
+	// served from the model cache rather than from state.
+	view *cache.StatusView
+	// viewSubordinates: principal unit name -> subordinate unit names
+	viewSubordinates map[string][]string
+	// lxdProfiles: lxd profile name -> profile, for charms in the view
+	lxdProfiles map[string]lxdprofile.Profile
+
+	// primaryAddressPreferences: application name -> preference for
+	// the address reported for its units, read as it is first needed.
+	primaryAddressPreferences map[string]network.UnitAddressPreference
 }
 
 // fetchMachines returns a map from top level machine id to machines, where machines[0] is the host
@@ -682,25 +826,10 @@ func fetchAllApplicationsAndUnits(
 		}
 	}
 
-	endpointBindings, err := model.AllEndpointBindings()
+	allBindingsByApp, err := fetchEndpointBindings(model)
 	if err != nil {
 		return applicationStatusInfo{}, err
 	}
-	allBindingsByApp := make(map[string]map[string]string)
-	for _, bindings := range endpointBindings {
-		// If the only binding is the default, and it's set to the
-		// default space, no need to print.
-		bindingMap, err := bindings.Bindings.MapWithSpaceNames()
-		if err != nil {
-			return applicationStatusInfo{}, err
-		}
-		if len(bindingMap) == 1 {
-			if v, ok := bindingMap[""]; ok && v == network.AlphaSpaceName {
-				continue
-			}
-		}
-		allBindingsByApp[bindings.AppName] = bindingMap
-	}
 
 	lxdProfiles := make(map[string]*charm.LXDProfile)
 	for _, app := range applications {
@@ -727,24 +856,58 @@ func fetchAllApplicationsAndUnits(
 		}
 	}
 
+	if err := fetchLatestCharms(st, latestCharms); err != nil {
+		return applicationStatusInfo{}, err
+	}
+
+	return applicationStatusInfo{
+		applications:     appMap,
+		units:            unitMap,
+		latestCharms:     latestCharms,
+		endpointBindings: allBindingsByApp,
+		lxdProfiles:      lxdProfiles,
+	}, nil
+}
+
+// fetchLatestCharms populates the supplied map from base charm URL to the
+// latest known revision of that charm in the store.
+func fetchLatestCharms(st Backend, latestCharms map[charm.URL]*state.Charm) error {
 	for baseURL := range latestCharms {
 		ch, err := st.LatestPlaceholderCharm(&baseURL)
 		if errors.IsNotFound(err) {
 			continue
 		}
 		if err != nil {
-			return applicationStatusInfo{}, err
+			return err
 		}
 		latestCharms[baseURL] = ch
 	}
+	return nil
+}
 
-	return applicationStatusInfo{
-		applications:     appMap,
-		units:            unitMap,
-		latestCharms:     latestCharms,
-		endpointBindings: allBindingsByApp,
-		lxdProfiles:      lxdProfiles,
-	}, nil
+// fetchEndpointBindings returns a map from application name to the
+// application's endpoint bindings, keyed by endpoint name.
+func fetchEndpointBindings(model *state.Model) (map[string]map[string]string, error) {
+	endpointBindings, err := model.AllEndpointBindings()
+	if err != nil {
+		return nil, err
+	}
+	allBindingsByApp := make(map[string]map[string]string)
+	for _, bindings := range endpointBindings {
+		// If the only binding is the default, and it's set to the
+		// default space, no need to print.
+		bindingMap, err := bindings.Bindings.MapWithSpaceNames()
+		if err != nil {
+			return nil, err
+		}
+		if len(bindingMap) == 1 {
+			if v, ok := bindingMap[""]; ok && v == network.AlphaSpaceName {
+				continue
+			}
+		}
+		allBindingsByApp[bindings.AppName] = bindingMap
+	}
+	return allBindingsByApp, nil
 }
 
 // fetchConsumerRemoteApplications returns a map from application name to remote application.
@@ -763,8 +926,10 @@ func fetchConsumerRemoteApplications(st Backend) (map[string]*state.RemoteApplic
 	return appMap, nil
 }
 
-// fetchOfferConnections returns a map from relation id to offer connection.
-func fetchOffers(st Backend, applications map[string]*state.Application) (map[string]offerStatus, error) {
+// fetchOffers returns a map from offer name to offer status. Only offers
+// for applications with a known charm URL, keyed by application name in
+// charmURLs, are included.
+func fetchOffers(st Backend, charmURLs map[string]string) (map[string]offerStatus, error) {
 	offersMap := make(map[string]offerStatus)
 	offers, err := st.AllApplicationOffers()
 	if err != nil {
@@ -779,12 +944,11 @@ func fetchOffers(st Backend, applications map[string]*state.Application) (map[st
 				Endpoints:       offer.Endpoints,
 			},
 		}
-		app, ok := applications[offer.ApplicationName]
+		curl, ok := charmURLs[offer.ApplicationName]
 		if !ok {
 			continue
 		}
-		curl, _ := app.CharmURL()
-		offerInfo.charmURL = curl.String()
+		offerInfo.charmURL = curl
 		rc, err := st.RemoteConnectionStatus(offer.OfferUUID)
 		if err != nil && !errors.IsNotFound(err) {
 			offerInfo.err = err
@@ -885,9 +1049,6 @@ func (c *statusContext) processMachines() map[string]params.MachineStatus {
 
 func (c *statusContext) makeMachineStatus(machine *state.Machine, appStatusInfo applicationStatusInfo) (status params.MachineStatus) {
 	machineID := machine.Id()
-	ipAddresses := c.ipAddresses[machineID]
-	spaces := c.spaces[machineID]
-	linkLayerDevices := c.linkLayerDevices[machineID]
 
 	var err error
 	status.Id = machine.Id()
@@ -938,47 +1099,7 @@ func (c *statusContext) makeMachineStatus(machine *state.Machine, appStatusInfo
 			}
 			status.IPAddresses = append(status.IPAddresses, mAddr.Value)
 		}
-		status.NetworkInterfaces = make(map[string]params.NetworkInterface, len(linkLayerDevices))
-		for _, llDev := range linkLayerDevices {
-			device := llDev.Name()
-			ips := []string{}
-			gw := []string{}
-			ns := []string{}
-			sp := make(set.Strings)
-			for _, ipAddress := range ipAddresses {
-				if ipAddress.DeviceName() != device {
-					continue
-				}
-				ips = append(ips, ipAddress.Value())
-				// We don't expect to find more than one
-				// ipAddress on a device with a list of
-				// nameservers, but append in any case.
-				if len(ipAddress.DNSServers()) > 0 {
-					ns = append(ns, ipAddress.DNSServers()...)
-				}
-				// There should only be one gateway per device
-				// (per machine, in fact, as we don't store
-				// metrics). If we find more than one we should
-				// show them all.
-				if ipAddress.GatewayAddress() != "" {
-					gw = append(gw, ipAddress.GatewayAddress())
-				}
-				// There should only be one space per address,
-				// but it's technically possible to have more
-				// than one address on an interface. If we find
-				// that happens, we need to show all spaces, to
-				// be safe.
-				sp = spaces[device]
-			}
-			status.NetworkInterfaces[device] = params.NetworkInterface{
-				IPAddresses:    ips,
-				MACAddress:     llDev.MACAddress(),
-				Gateway:        strings.Join(gw, " "),
-				DNSNameservers: ns,
-				Space:          strings.Join(sp.Values(), " "),
-				IsUp:           llDev.IsUp(),
-			}
-		}
+		status.NetworkInterfaces = c.machineNetworkInterfaces(machineID)
 		logger.Tracef("NetworkInterfaces: %+v", status.NetworkInterfaces)
 	} else {
 		if errors.IsNotProvisioned(err) {
@@ -1027,6 +1148,57 @@ func (c *statusContext) makeMachineStatus(machine *state.Machine, appStatusInfo
 	return
 }
 
+// machineNetworkInterfaces returns the network interfaces, keyed by
+// device name, of the machine with the given ID.
+func (c *statusContext) machineNetworkInterfaces(machineID string) map[string]params.NetworkInterface {
+	ipAddresses := c.ipAddresses[machineID]
+	spaces := c.spaces[machineID]
+	linkLayerDevices := c.linkLayerDevices[machineID]
+
+	interfaces := make(map[string]params.NetworkInterface, len(linkLayerDevices))
+	for _, llDev := range linkLayerDevices {
+		device := llDev.Name()
+		ips := []string{}
+		gw := []string{}
+		ns := []string{}
+		sp := make(set.Strings)
+		for _, ipAddress := range ipAddresses {
+			if ipAddress.DeviceName() != device {
+				continue
+			}
+			ips = append(ips, ipAddress.Value())
+			// We don't expect to find more than one
+			// ipAddress on a device with a list of
+			// nameservers, but append in any case.
+			if len(ipAddress.DNSServers()) > 0 {
+				ns = append(ns, ipAddress.DNSServers()...)
+			}
+			// There should only be one gateway per device
+			// (per machine, in fact, as we don't store
+			// metrics). If we find more than one we should
+			// show them all.
+			if ipAddress.GatewayAddress() != "" {
+				gw = append(gw, ipAddress.GatewayAddress())
+			}
+			// There should only be one space per address,
+			// but it's technically possible to have more
+			// than one address on an interface. If we find
+			// that happens, we need to show all spaces, to
+			// be safe.
+			sp = spaces[device]
+		}
+		interfaces[device] = params.NetworkInterface{
+			IPAddresses:    ips,
+			MACAddress:     llDev.MACAddress(),
+			Gateway:        strings.Join(gw, " "),
+			DNSNameservers: ns,
+			Space:          strings.Join(sp.Values(), " "),
+			IsUp:           llDev.IsUp(),
+		}
+	}
+	return interfaces
+}
+
 func (context *statusContext) processRelations() []params.RelationStatus {
 	var out []params.RelationStatus
 	relations := context.getAllRelations()
@@ -1076,6 +1248,10 @@ func (context *statusContext) getAllRelations() []*state.Relation {
 }
 
 func (context *statusContext) isSubordinate(ep *state.Endpoint) bool {
+	if context.view != nil {
+		application, ok := context.view.Applications[ep.ApplicationName]
+		return ok && ep.Scope == charm.ScopeContainer && application.Subordinate
+	}
 	application := context.allAppsUnitsCharmBindings.applications[ep.ApplicationName]
 	if application == nil {
 		return false
@@ -1132,7 +1308,7 @@ func (context *statusContext) processApplication(application *state.Application)
 		}
 	}
 
-	processedStatus.Relations, processedStatus.SubordinateTo, err = context.processApplicationRelations(application)
+	processedStatus.Relations, processedStatus.SubordinateTo, err = context.processApplicationRelations(application.Name(), application.IsPrincipal())
 	if err != nil {
 		processedStatus.Err = common.ServerError(err)
 		return processedStatus
@@ -1314,10 +1490,32 @@ func (context *statusContext) processUnits(units map[string]*state.Unit, applica
 	return unitsMap
 }
 
+// unitPrimaryAddress returns the address reported as the unit's public
+// address, as chosen by its application's primary-address config.
+func (context *statusContext) unitPrimaryAddress(unit *state.Unit) (network.SpaceAddress, error) {
+	appName := unit.ApplicationName()
+	pref, ok := context.primaryAddressPreferences[appName]
+	if !ok {
+		pref = network.PreferAutoAddress
+		if app, found := context.allAppsUnitsCharmBindings.applications[appName]; found {
+			cfg, err := app.ApplicationConfig()
+			if err != nil {
+				return network.SpaceAddress{}, errors.Trace(err)
+			}
+			pref = application.PrimaryAddressPreference(cfg)
+		}
+		if context.primaryAddressPreferences == nil {
+			context.primaryAddressPreferences = make(map[string]network.UnitAddressPreference)
+		}
+		context.primaryAddressPreferences[appName] = pref
+	}
+	return unit.PrimaryAddress(pref)
+}
+
 func (context *statusContext) processUnit(unit *state.Unit, applicationCharm string, expectWorkload bool) params.UnitStatus {
 	var result params.UnitStatus
 	if unit.ShouldBeAssigned() {
-		addr, err := unit.PublicAddress()
+		addr, err := context.unitPrimaryAddress(unit)
 		if err != nil {
 			// Usually this indicates that no addresses have been set on the
 			// machine yet.
@@ -1392,22 +1590,22 @@ func (context *statusContext) unitByName(name string) *state.Unit {
 	return context.allAppsUnitsCharmBindings.units[applicationName][name]
 }
 
-func (context *statusContext) processApplicationRelations(application *state.Application) (related map[string][]string, subord []string, err error) {
+func (context *statusContext) processApplicationRelations(appName string, principal bool) (related map[string][]string, subord []string, err error) {
 	subordSet := make(set.Strings)
 	related = make(map[string][]string)
-	relations := context.relations[application.Name()]
+	relations := context.relations[appName]
 	for _, relation := range relations {
-		ep, err := relation.Endpoint(application.Name())
+		ep, err := relation.Endpoint(appName)
 		if err != nil {
 			return nil, nil, err
 		}
 		relationName := ep.Relation.Name
-		eps, err := relation.RelatedEndpoints(application.Name())
+		eps, err := relation.RelatedEndpoints(appName)
 		if err != nil {
 			return nil, nil, err
 		}
 		for _, ep := range eps {
-			if isSubordinate(&ep, application) {
+			if ep.Scope == charm.ScopeContainer && !principal {
 				subordSet.Add(ep.ApplicationName)
 			}
 			related[relationName] = append(related[relationName], ep.ApplicationName)
@@ -1535,11 +1733,19 @@ func (c *statusContext) processMachine(machine *state.Machine) (out params.Detai
 
 // filterStatusData limits what agent StatusData data is passed over
 // the API. This prevents unintended leakage of internal-only data.
+// statusDataWhitelist holds the keys of the status data which are
+// included in the status returned to clients.
+var statusDataWhitelist = set.NewStrings(
+	append(
+		append([]string{status.RelationIdKey, status.WorkerFailuresKey}, status.StartInstanceDataKeys()...),
+		status.LeadershipPinDataKeys()...,
+	)...,
+)
+
 func filterStatusData(status map[string]interface{}) map[string]interface{} {
 	out := make(map[string]interface{})
 	for name, value := range status {
-		// use a set here if we end up with a larger whitelist
-		if name == "relation-id" {
+		if statusDataWhitelist.Contains(name) {
 			out[name] = value
 		}
 	}
@@ -0,0 +1,441 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/feature"
+	"github.com/juju/juju/state"
+)
+
+// useCachedStatus returns true if the status requested by args should be
+// served from the model cache rather than assembled from state.
+// This is only done when the cached-status controller feature is enabled,
+// for unfiltered requests against IAAS models with API server presence.
+func (c *Client) useCachedStatus(args params.StatusParams, context *statusContext) bool {
+	if c.api.modelCache == nil || len(args.Patterns) > 0 {
+		return false
+	}
+	if context.presence.Presence == nil || context.model.Type() != state.ModelTypeIAAS {
+		return false
+	}
+	cfg, err := c.api.stateAccessor.ControllerConfig()
+	if err != nil {
+		logger.Debugf("cannot determine controller features, not using cached status: %v", err)
+		return false
+	}
+	return cfg.Features().Contains(feature.CachedStatus)
+}
+
+// cachedFullStatus returns the full status of the model, with machines,
+// applications and units rendered from the cached model's status view.
+// The view is maintained incrementally by the cache as changes arrive,
+// so the cost of a status call no longer grows with a database round trip
+// per unit and machine. Remote applications, offers and relations are
+// still read from state in bulk.
+//
+// The output is the same legacy FullStatus structure, so existing clients
+// are unaffected. Values not tracked by the cache, which are unit workload
+// versions, machine modification status, display names and constraints,
+// and meter statuses, are omitted.
+func (c *Client) cachedFullStatus(context statusContext) (params.FullStatus, error) {
+	var noStatus params.FullStatus
+	var err error
+
+	context.view = c.api.modelCache.StatusView()
+	if context.consumerRemoteApplications, err =
+		fetchConsumerRemoteApplications(c.api.stateAccessor); err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch remote applications")
+	}
+	// Only admins can see offer details.
+	if err := c.checkIsAdmin(); err == nil {
+		if context.offers, err = fetchOffers(c.api.stateAccessor, context.viewCharmURLs()); err != nil {
+			return noStatus, errors.Annotate(err, "could not fetch application offers")
+		}
+	}
+	// These may be empty when machines have not finished deployment.
+	if context.ipAddresses, context.spaces, context.linkLayerDevices, err =
+		fetchNetworkInterfaces(c.api.stateAccessor); err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch IP addresses and link layer devices")
+	}
+	if context.relations, context.relationsById, err = fetchRelations(c.api.stateAccessor); err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch relations")
+	}
+	if context.allAppsUnitsCharmBindings.endpointBindings, err = fetchEndpointBindings(context.model); err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch endpoint bindings")
+	}
+	context.allAppsUnitsCharmBindings.latestCharms = context.viewLatestCharmURLs()
+	if err := fetchLatestCharms(c.api.stateAccessor, context.allAppsUnitsCharmBindings.latestCharms); err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch latest charms")
+	}
+	if len(context.view.Applications) > 0 {
+		if context.leaders, err = c.api.leadershipReader.Leaders(); err != nil {
+			return noStatus, errors.Annotate(err, "could not fetch leaders")
+		}
+	}
+	if context.controllerTimestamp, err = c.api.stateAccessor.ControllerTimestamp(); err != nil {
+		return noStatus, errors.Annotate(err, "could not fetch controller timestamp")
+	}
+	context.branches = fetchBranches(c.api.modelCache)
+
+	modelStatus, err := c.modelStatus()
+	if err != nil {
+		return noStatus, errors.Annotate(err, "cannot determine model status")
+	}
+	return params.FullStatus{
+		Model:               modelStatus,
+		Machines:            context.processCachedMachines(),
+		Applications:        context.processCachedApplications(),
+		RemoteApplications:  context.processRemoteApplications(),
+		Offers:              context.processOffers(),
+		Relations:           context.processRelations(),
+		ControllerTimestamp: context.controllerTimestamp,
+		Branches:            context.processBranches(),
+	}, nil
+}
+
+// viewCharmURLs returns a map from application name to charm URL for
+// the applications in the status view.
+func (c *statusContext) viewCharmURLs() map[string]string {
+	urls := make(map[string]string, len(c.view.Applications))
+	for name, app := range c.view.Applications {
+		urls[name] = app.CharmURL
+	}
+	return urls
+}
+
+// viewLatestCharmURLs returns the base URLs of the store charms used by
+// applications with units in the status view, ready for the latest
+// revisions to be looked up.
+func (c *statusContext) viewLatestCharmURLs() map[charm.URL]*state.Charm {
+	withUnits := make(map[string]bool)
+	for _, unit := range c.view.Units {
+		withUnits[unit.Application] = true
+	}
+	latestCharms := make(map[charm.URL]*state.Charm)
+	for name, app := range c.view.Applications {
+		if !withUnits[name] {
+			continue
+		}
+		curl, err := charm.ParseURL(app.CharmURL)
+		if err != nil || curl.Schema != "cs" {
+			continue
+		}
+		latestCharms[*curl.WithRevision(-1)] = nil
+	}
+	return latestCharms
+}
+
+// processCachedMachines returns the status of every machine in the view,
+// with containers nested within their hosts.
+func (c *statusContext) processCachedMachines() map[string]params.MachineStatus {
+	// Sort the IDs so that hosts are always processed before the
+	// containers they hold.
+	ids := make([]string, 0, len(c.view.Machines))
+	for id := range c.view.Machines {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		di, dj := strings.Count(ids[i], "/"), strings.Count(ids[j], "/")
+		if di != dj {
+			return di < dj
+		}
+		return ids[i] < ids[j]
+	})
+
+	machinesMap := make(map[string]params.MachineStatus)
+	processed := make(map[string]params.MachineStatus)
+	for _, id := range ids {
+		machineStatus := c.makeCachedMachineStatus(c.view.Machines[id])
+		processed[id] = machineStatus
+		if !strings.Contains(id, "/") {
+			machinesMap[id] = machineStatus
+			continue
+		}
+		parent, ok := processed[state.ParentId(id)]
+		if !ok {
+			logger.Errorf("programmer error, please file a bug, reference this whole log line: container %q has no host", id)
+			continue
+		}
+		parent.Containers[id] = machineStatus
+	}
+	return machinesMap
+}
+
+func (c *statusContext) makeCachedMachineStatus(machine cache.MachineChange) params.MachineStatus {
+	machineStatus := params.MachineStatus{
+		Id:         machine.Id,
+		Series:     machine.Series,
+		Jobs:       machine.Jobs,
+		HasVote:    machine.HasVote,
+		WantsVote:  machine.WantsVote,
+		Containers: make(map[string]params.MachineStatus),
+	}
+
+	agentStatus, err := c.presence.MachineStatus(cachedMachine{machine})
+	populateStatusFromStatusInfoAndErr(&machineStatus.AgentStatus, agentStatus, err)
+	machineStatus.AgentStatus.Life = processCachedLife(machine.Life)
+	machineStatus.AgentStatus.Version = machine.AgentVersion
+	populateStatusFromStatusInfoAndErr(&machineStatus.InstanceStatus, machine.InstanceStatus, nil)
+
+	if machine.InstanceId == "" {
+		machineStatus.InstanceId = "pending"
+	} else {
+		machineStatus.InstanceId = instance.Id(machine.InstanceId)
+		addresses := network.ProviderAddresses(machine.Addresses)
+		if addr, ok := addresses.OneMatchingScope(network.ScopeMatchPublic); ok {
+			machineStatus.DNSName = addr.Value
+		}
+		for _, addr := range addresses {
+			switch addr.Scope {
+			case network.ScopeMachineLocal, network.ScopeLinkLocal:
+				continue
+			}
+			machineStatus.IPAddresses = append(machineStatus.IPAddresses, addr.Value)
+		}
+		machineStatus.NetworkInterfaces = c.machineNetworkInterfaces(machine.Id)
+	}
+	if machine.HardwareCharacteristics != nil {
+		machineStatus.Hardware = machine.HardwareCharacteristics.String()
+	}
+
+	machineStatus.LXDProfiles = make(map[string]params.LXDProfile)
+	profiles := c.viewLXDProfiles()
+	for _, name := range machine.CharmProfiles {
+		if profile, ok := profiles[name]; ok {
+			machineStatus.LXDProfiles[name] = params.LXDProfile{
+				Config:      profile.Config,
+				Description: profile.Description,
+				Devices:     profile.Devices,
+			}
+		}
+	}
+	return machineStatus
+}
+
+// viewLXDProfiles returns the non-empty LXD profiles of the charms used
+// by applications in the view, keyed by profile name.
+func (c *statusContext) viewLXDProfiles() map[string]lxdprofile.Profile {
+	if c.lxdProfiles != nil {
+		return c.lxdProfiles
+	}
+	c.lxdProfiles = make(map[string]lxdprofile.Profile)
+	for name, app := range c.view.Applications {
+		ch, ok := c.view.Charms[app.CharmURL]
+		if !ok || ch.LXDProfile.Empty() {
+			continue
+		}
+		curl, err := charm.ParseURL(app.CharmURL)
+		if err != nil {
+			continue
+		}
+		c.lxdProfiles[lxdprofile.Name(c.model.Name(), name, curl.Revision)] = ch.LXDProfile
+	}
+	return c.lxdProfiles
+}
+
+// processCachedApplications returns the status of every application in
+// the view, along with their units.
+func (c *statusContext) processCachedApplications() map[string]params.ApplicationStatus {
+	unitsByApp := make(map[string][]cache.UnitChange)
+	subordinates := make(map[string][]string)
+	for _, unit := range c.view.Units {
+		unitsByApp[unit.Application] = append(unitsByApp[unit.Application], unit)
+		if unit.Principal != "" {
+			subordinates[unit.Principal] = append(subordinates[unit.Principal], unit.Name)
+		}
+	}
+	c.viewSubordinates = subordinates
+
+	applicationsMap := make(map[string]params.ApplicationStatus)
+	for name, app := range c.view.Applications {
+		applicationsMap[name] = c.processCachedApplication(app, unitsByApp[name])
+	}
+	return applicationsMap
+}
+
+func (c *statusContext) processCachedApplication(app cache.ApplicationChange, units []cache.UnitChange) params.ApplicationStatus {
+	curl, err := charm.ParseURL(app.CharmURL)
+	if err != nil {
+		return params.ApplicationStatus{Err: common.ServerError(err)}
+	}
+
+	processedStatus := params.ApplicationStatus{
+		Charm:           app.CharmURL,
+		Series:          curl.Series,
+		Exposed:         app.Exposed,
+		Life:            processCachedLife(app.Life),
+		WorkloadVersion: app.WorkloadVersion,
+	}
+	if ch, ok := c.view.Charms[app.CharmURL]; ok {
+		processedStatus.CharmVersion = ch.CharmVersion
+		if !ch.LXDProfile.Empty() {
+			processedStatus.CharmProfile = lxdprofile.Name(c.model.Name(), app.Name, curl.Revision)
+		}
+	}
+	if latestCharm, ok := c.allAppsUnitsCharmBindings.latestCharms[*curl.WithRevision(-1)]; ok && latestCharm != nil {
+		if latestCharm.Revision() > curl.Revision {
+			processedStatus.CanUpgradeTo = latestCharm.String()
+		}
+	}
+
+	processedStatus.Relations, processedStatus.SubordinateTo, err = c.processApplicationRelations(app.Name, !app.Subordinate)
+	if err != nil {
+		processedStatus.Err = common.ServerError(err)
+		return processedStatus
+	}
+	if !app.Subordinate {
+		processedStatus.Units = make(map[string]params.UnitStatus)
+		for _, unit := range units {
+			processedStatus.Units[unit.Name] = c.processCachedUnit(unit, app.CharmURL)
+		}
+	}
+
+	unitStatuses := make([]status.StatusInfo, len(units))
+	for i, unit := range units {
+		unitStatuses[i] = unit.WorkloadStatus
+	}
+	applicationStatus, _ := state.ApplicationDisplayStatus(app.Status, app.StatusExplicitlySet, unitStatuses)
+	processedStatus.Status.Status = applicationStatus.Status.String()
+	processedStatus.Status.Info = applicationStatus.Message
+	processedStatus.Status.Data = applicationStatus.Data
+	processedStatus.Status.Since = applicationStatus.Since
+
+	processedStatus.EndpointBindings = c.allAppsUnitsCharmBindings.endpointBindings[app.Name]
+	return processedStatus
+}
+
+func (c *statusContext) processCachedUnit(unit cache.UnitChange, applicationCharm string) params.UnitStatus {
+	result := params.UnitStatus{
+		PublicAddress: unit.PublicAddress,
+	}
+	for _, portRange := range unit.PortRanges {
+		result.OpenedPorts = append(result.OpenedPorts, portRange.String())
+	}
+	if !unit.Subordinate {
+		result.Machine = unit.MachineId
+	}
+	if unit.CharmURL != "" && unit.CharmURL != applicationCharm {
+		result.Charm = unit.CharmURL
+	}
+
+	agent, workload := c.presence.UnitStatus(cachedUnit{unit})
+	populateStatusFromStatusInfoAndErr(&result.AgentStatus, agent.Status, agent.Err)
+	populateStatusFromStatusInfoAndErr(&result.WorkloadStatus, workload.Status, workload.Err)
+	result.AgentStatus.Life = processCachedLife(unit.Life)
+	result.AgentStatus.Version = unit.AgentVersion
+
+	if subUnits := c.viewSubordinates[unit.Name]; len(subUnits) > 0 {
+		result.Subordinates = make(map[string]params.UnitStatus)
+		for _, name := range subUnits {
+			if subUnit, ok := c.view.Units[name]; ok {
+				result.Subordinates[name] = c.processCachedUnit(subUnit, applicationCharm)
+			}
+		}
+	}
+	if leader := c.leaders[unit.Application]; leader == unit.Name {
+		result.Leader = true
+	}
+	return result
+}
+
+func processCachedLife(value life.Value) life.Value {
+	if value == life.Alive {
+		// alive is the usual state so omit it by default.
+		return life.Value("")
+	}
+	return value
+}
+
+// cachedMachine adapts a cached machine for use with the presence
+// context, so that machines with lost agents are reported as down.
+type cachedMachine struct {
+	details cache.MachineChange
+}
+
+// Status implements common.MachineStatusGetter.
+func (m cachedMachine) Status() (status.StatusInfo, error) {
+	return m.details.AgentStatus, nil
+}
+
+// AgentPresence implements common.MachineStatusGetter.
+// It is only called when the presence context has no API server
+// presence, which cached status is never used without.
+func (m cachedMachine) AgentPresence() (bool, error) {
+	return false, errors.NotSupportedf("agent presence for cached machine")
+}
+
+// Id implements common.MachineStatusGetter.
+func (m cachedMachine) Id() string {
+	return m.details.Id
+}
+
+// Life implements common.MachineStatusGetter.
+func (m cachedMachine) Life() state.Life {
+	return stateLife(m.details.Life)
+}
+
+// cachedUnit adapts a cached unit for use with the presence context,
+// so that units with lost agents are reported as such.
+type cachedUnit struct {
+	details cache.UnitChange
+}
+
+// AgentStatus implements common.UnitStatusGetter.
+func (u cachedUnit) AgentStatus() (status.StatusInfo, error) {
+	return u.details.AgentStatus, nil
+}
+
+// Status implements common.UnitStatusGetter.
+func (u cachedUnit) Status() (status.StatusInfo, error) {
+	return u.details.WorkloadStatus, nil
+}
+
+// AgentPresence implements common.UnitStatusGetter.
+// It is only called when the presence context has no API server
+// presence, which cached status is never used without.
+func (u cachedUnit) AgentPresence() (bool, error) {
+	return false, errors.NotSupportedf("agent presence for cached unit")
+}
+
+// ShouldBeAssigned implements common.UnitStatusGetter.
+// Cached status is only used for IAAS models, where all
+// units are assigned to machines.
+func (u cachedUnit) ShouldBeAssigned() bool {
+	return true
+}
+
+// Name implements common.UnitStatusGetter.
+func (u cachedUnit) Name() string {
+	return u.details.Name
+}
+
+// Life implements common.UnitStatusGetter.
+func (u cachedUnit) Life() state.Life {
+	return stateLife(u.details.Life)
+}
+
+func stateLife(value life.Value) state.Life {
+	switch value {
+	case life.Dying:
+		return state.Dying
+	case life.Dead:
+		return state.Dead
+	default:
+		return state.Alive
+	}
+}
@@ -0,0 +1,179 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/presence"
+	"github.com/juju/juju/core/status"
+)
+
+type cachedStatusSuite struct {
+	missing map[string]bool
+}
+
+var _ = gc.Suite(&cachedStatusSuite{})
+
+func (s *cachedStatusSuite) SetUpTest(c *gc.C) {
+	s.missing = make(map[string]bool)
+}
+
+// AgentStatus implements common.ModelPresence.
+func (s *cachedStatusSuite) AgentStatus(agent string) (presence.Status, error) {
+	if s.missing[agent] {
+		return presence.Missing, nil
+	}
+	return presence.Alive, nil
+}
+
+func (s *cachedStatusSuite) context(view *cache.StatusView) *statusContext {
+	return &statusContext{
+		presence: common.ModelPresenceContext{Presence: s},
+		view:     view,
+		leaders:  map[string]string{"mysql": "mysql/0"},
+	}
+}
+
+func (s *cachedStatusSuite) view() *cache.StatusView {
+	return &cache.StatusView{
+		Applications: map[string]cache.ApplicationChange{
+			"mysql": {
+				Name:            "mysql",
+				CharmURL:        "cs:bionic/mysql-3",
+				Life:            life.Alive,
+				Exposed:         true,
+				Status:          status.StatusInfo{Status: status.Waiting, Message: status.MessageWaitForMachine},
+				WorkloadVersion: "5.7",
+			},
+			"logging": {
+				Name:        "logging",
+				CharmURL:    "cs:bionic/logging-1",
+				Life:        life.Alive,
+				Subordinate: true,
+				Status:      status.StatusInfo{Status: status.Active},
+			},
+		},
+		Charms: map[string]cache.CharmChange{
+			"cs:bionic/mysql-3": {CharmURL: "cs:bionic/mysql-3", CharmVersion: "1.2"},
+		},
+		Machines: map[string]cache.MachineChange{
+			"0": {
+				Id:          "0",
+				InstanceId:  "inst-0",
+				Life:        life.Alive,
+				Series:      "bionic",
+				Jobs:        []model.MachineJob{model.JobHostUnits},
+				AgentStatus: status.StatusInfo{Status: status.Started},
+				Addresses: []network.ProviderAddress{
+					network.NewScopedProviderAddress("10.0.0.1", network.ScopeCloudLocal),
+					network.NewScopedProviderAddress("54.0.0.1", network.ScopePublic),
+					network.NewScopedProviderAddress("127.0.0.1", network.ScopeMachineLocal),
+				},
+				AgentVersion: "2.8.0",
+			},
+			"0/lxd/0": {
+				Id:          "0/lxd/0",
+				Life:        life.Dying,
+				Series:      "bionic",
+				AgentStatus: status.StatusInfo{Status: status.Pending},
+			},
+		},
+		Units: map[string]cache.UnitChange{
+			"mysql/0": {
+				Name:           "mysql/0",
+				Application:    "mysql",
+				CharmURL:       "cs:bionic/mysql-3",
+				Life:           life.Alive,
+				MachineId:      "0",
+				PublicAddress:  "54.0.0.1",
+				PortRanges:     []network.PortRange{{FromPort: 3306, ToPort: 3306, Protocol: "tcp"}},
+				WorkloadStatus: status.StatusInfo{Status: status.Blocked, Message: "need db"},
+				AgentStatus:    status.StatusInfo{Status: status.Idle},
+				AgentVersion:   "2.8.0",
+			},
+			"logging/0": {
+				Name:           "logging/0",
+				Application:    "logging",
+				CharmURL:       "cs:bionic/logging-1",
+				Life:           life.Alive,
+				Principal:      "mysql/0",
+				Subordinate:    true,
+				WorkloadStatus: status.StatusInfo{Status: status.Active},
+				AgentStatus:    status.StatusInfo{Status: status.Idle},
+			},
+		},
+	}
+}
+
+func (s *cachedStatusSuite) TestMachines(c *gc.C) {
+	machines := s.context(s.view()).processCachedMachines()
+	c.Assert(machines, gc.HasLen, 1)
+
+	host := machines["0"]
+	c.Check(host.Id, gc.Equals, "0")
+	c.Check(host.InstanceId, gc.Equals, instance.Id("inst-0"))
+	c.Check(host.DNSName, gc.Equals, "54.0.0.1")
+	c.Check(host.IPAddresses, jc.DeepEquals, []string{"10.0.0.1", "54.0.0.1"})
+	c.Check(host.Jobs, jc.DeepEquals, []model.MachineJob{model.JobHostUnits})
+	c.Check(host.AgentStatus.Status, gc.Equals, "started")
+	c.Check(host.AgentStatus.Version, gc.Equals, "2.8.0")
+	c.Check(host.AgentStatus.Life, gc.Equals, life.Value(""))
+
+	c.Assert(host.Containers, gc.HasLen, 1)
+	container := host.Containers["0/lxd/0"]
+	c.Check(container.InstanceId, gc.Equals, instance.Id("pending"))
+	c.Check(container.AgentStatus.Life, gc.Equals, life.Dying)
+}
+
+func (s *cachedStatusSuite) TestMachineAgentDown(c *gc.C) {
+	s.missing[names.NewMachineTag("0").String()] = true
+	machines := s.context(s.view()).processCachedMachines()
+	c.Check(machines["0"].AgentStatus.Status, gc.Equals, "down")
+}
+
+func (s *cachedStatusSuite) TestApplications(c *gc.C) {
+	applications := s.context(s.view()).processCachedApplications()
+	c.Assert(applications, gc.HasLen, 2)
+
+	mysql := applications["mysql"]
+	c.Check(mysql.Charm, gc.Equals, "cs:bionic/mysql-3")
+	c.Check(mysql.Series, gc.Equals, "bionic")
+	c.Check(mysql.Exposed, jc.IsTrue)
+	c.Check(mysql.CharmVersion, gc.Equals, "1.2")
+	c.Check(mysql.WorkloadVersion, gc.Equals, "5.7")
+	// The application status was never set, so it is derived from the units.
+	c.Check(mysql.Status.Status, gc.Equals, "blocked")
+	c.Check(mysql.Status.Info, gc.Equals, "need db")
+
+	c.Assert(mysql.Units, gc.HasLen, 1)
+	unit := mysql.Units["mysql/0"]
+	c.Check(unit.Machine, gc.Equals, "0")
+	c.Check(unit.PublicAddress, gc.Equals, "54.0.0.1")
+	c.Check(unit.OpenedPorts, jc.DeepEquals, []string{"3306/tcp"})
+	c.Check(unit.Leader, jc.IsTrue)
+	c.Check(unit.AgentStatus.Version, gc.Equals, "2.8.0")
+	c.Check(unit.WorkloadStatus.Status, gc.Equals, "blocked")
+	c.Assert(unit.Subordinates, gc.HasLen, 1)
+	c.Check(unit.Subordinates["logging/0"].WorkloadStatus.Status, gc.Equals, "active")
+
+	logging := applications["logging"]
+	c.Check(logging.Units, gc.HasLen, 0)
+	c.Check(logging.Status.Status, gc.Equals, "active")
+}
+
+func (s *cachedStatusSuite) TestUnitAgentLost(c *gc.C) {
+	s.missing[names.NewUnitTag("mysql/0").String()] = true
+	applications := s.context(s.view()).processCachedApplications()
+	unit := applications["mysql"].Units["mysql/0"]
+	c.Check(unit.AgentStatus.Status, gc.Equals, "lost")
+}
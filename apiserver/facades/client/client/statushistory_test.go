@@ -6,6 +6,7 @@ package client_test
 import (
 	"time"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -16,6 +17,7 @@ import (
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/testing"
 )
 
@@ -224,10 +226,129 @@ func (s *statusHistoryTestSuite) TestStatusHistoryCombined(c *gc.C) {
 	checkStatusInfo(c, h.Results[0].History.Statuses, expected)
 }
 
+func (s *statusHistoryTestSuite) TestStatusHistoryUntil(c *gc.C) {
+	s.st.unitHistory = statusInfoWithDates([]status.StatusInfo{
+		{
+			Status:  status.Maintenance,
+			Message: "working",
+		},
+		{
+			Status:  status.Active,
+			Message: "running",
+		},
+		{
+			Status:  status.Blocked,
+			Message: "waiting",
+		},
+	})
+	until := time.Unix(1000, 0)
+	h := s.api.StatusHistory(params.StatusHistoryRequests{
+		Requests: []params.StatusHistoryRequest{{
+			Tag:    "unit-unit-0",
+			Kind:   status.KindWorkload.String(),
+			Filter: params.StatusHistoryFilter{Size: 10, Until: &until},
+		}}})
+	c.Assert(h.Results, gc.HasLen, 1)
+	c.Assert(h.Results[0].Error, gc.IsNil)
+	checkStatusInfo(c, h.Results[0].History.Statuses, reverseStatusInfo(s.st.unitHistory[1:]))
+}
+
+func (s *statusHistoryTestSuite) TestStatusHistoryApplicationInvalidTag(c *gc.C) {
+	h := s.api.StatusHistory(params.StatusHistoryRequests{
+		Requests: []params.StatusHistoryRequest{{
+			Tag:    "unit-unit-0",
+			Kind:   status.KindApplication.String(),
+			Filter: params.StatusHistoryFilter{Size: 10},
+		}}})
+	c.Assert(h.Results, gc.HasLen, 1)
+	c.Assert(h.Results[0].Error, gc.ErrorMatches, `fetching status history for "unit-unit-0": "unit-unit-0" is not a valid application tag`)
+}
+
+func (s *statusHistoryTestSuite) TestStatusHistoryUnitKindInvalidTag(c *gc.C) {
+	h := s.api.StatusHistory(params.StatusHistoryRequests{
+		Requests: []params.StatusHistoryRequest{{
+			Tag:    "machine-0",
+			Kind:   status.KindWorkload.String(),
+			Filter: params.StatusHistoryFilter{Size: 10},
+		}}})
+	c.Assert(h.Results, gc.HasLen, 1)
+	c.Assert(h.Results[0].Error, gc.ErrorMatches, `fetching status history for "machine-0": workload status history for "machine-0" not valid`)
+}
+
+func (s *statusHistoryTestSuite) TestStatusHistoryPage(c *gc.C) {
+	since := time.Unix(1000, 0)
+	s.st.page = state.StatusHistoryPage{
+		Statuses: []state.PagedStatus{{
+			StatusInfo: status.StatusInfo{
+				Status:  status.Maintenance,
+				Message: "installing",
+				Since:   &since,
+			},
+			Kind: status.KindWorkload,
+			Unit: "app/0",
+		}},
+		Next: "next-page",
+	}
+	from := time.Unix(500, 0)
+	result, err := s.api.StatusHistoryPage(params.StatusHistoryPageRequest{
+		Tag:      "application-app",
+		Kind:     status.KindUnit.String(),
+		Filter:   params.StatusHistoryFilter{Date: &from, Exclude: []string{"idle"}},
+		PageSize: 10,
+		Cursor:   "this-page",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StatusHistoryPageResult{
+		Statuses: []params.DetailedStatus{{
+			Status: "maintenance",
+			Info:   "installing",
+			Since:  &since,
+			Kind:   "workload",
+			Unit:   "app/0",
+		}},
+		Next: "next-page",
+	})
+	c.Assert(s.st.pageArgs, jc.DeepEquals, []interface{}{
+		names.NewApplicationTag("app"),
+		status.KindUnit,
+		status.StatusHistoryFilter{FromDate: &from, Exclude: set.NewStrings("idle")},
+		10,
+		"this-page",
+	})
+}
+
+func (s *statusHistoryTestSuite) TestStatusHistoryPageError(c *gc.C) {
+	s.st.pageErr = errors.NotValidf("page size 0")
+	_, err := s.api.StatusHistoryPage(params.StatusHistoryPageRequest{
+		Tag:  "unit-app-0",
+		Kind: status.KindWorkload.String(),
+	})
+	c.Assert(err, gc.ErrorMatches, "page size 0 not valid")
+}
+
+func (s *statusHistoryTestSuite) TestStatusHistoryPageInvalidTag(c *gc.C) {
+	_, err := s.api.StatusHistoryPage(params.StatusHistoryPageRequest{
+		Tag:      "app",
+		Kind:     status.KindWorkload.String(),
+		PageSize: 10,
+	})
+	c.Assert(err, gc.ErrorMatches, `"app" is not a valid tag`)
+}
+
 type mockState struct {
 	client.Backend
 	unitHistory  []status.StatusInfo
 	agentHistory []status.StatusInfo
+	page         state.StatusHistoryPage
+	pageErr      error
+	pageArgs     []interface{}
+}
+
+func (m *mockState) StatusHistoryPage(
+	tag names.Tag, kind status.HistoryKind, filter status.StatusHistoryFilter, pageSize int, cursor string,
+) (state.StatusHistoryPage, error) {
+	m.pageArgs = []interface{}{tag, kind, filter, pageSize, cursor}
+	return m.page, m.pageErr
 }
 
 func (m *mockState) ModelUUID() string {
@@ -273,6 +394,9 @@ type mockUnitAgent struct {
 type statuses []status.StatusInfo
 
 func (s statuses) StatusHistory(filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+	for filter.Until != nil && len(s) > 0 && !s[0].Since.Before(*filter.Until) {
+		s = s[1:]
+	}
 	if filter.Size > len(s) {
 		filter.Size = len(s)
 	}
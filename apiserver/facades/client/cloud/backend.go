@@ -9,6 +9,7 @@ import (
 	"github.com/juju/juju/apiserver/common/credentialcommon"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/cost"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/permission"
@@ -29,6 +30,9 @@ type Backend interface {
 	AddCloud(cloud.Cloud, string) error
 	UpdateCloud(cloud.Cloud) error
 	RemoveCloud(string) error
+	SetCloudPricing(string, cost.Pricing) error
+	CloudPricing(string) (cost.Pricing, error)
+	RemoveCloudPricing(string) error
 	AllCloudCredentials(user names.UserTag) ([]state.Credential, error)
 	CredentialModelsAndOwnerAccess(tag names.CloudCredentialTag) ([]state.CredentialOwnerModelAccess, error)
 	CredentialModels(tag names.CloudCredentialTag) (map[string]string, error)
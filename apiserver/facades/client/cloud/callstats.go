@@ -0,0 +1,51 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloud
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// ProviderCallStats returns the statistics for the calls made to cloud
+// providers by the workers running on the controller machine serving
+// the request, so that operators can see which workers are responsible
+// for being throttled by a cloud. In an HA controller each machine keeps
+// its own statistics. Only controller superusers may see them.
+func (api *CloudAPI) ProviderCallStats() (params.ProviderCallStatsResult, error) {
+	isAdmin, err := api.isControllerAdmin()
+	if err != nil {
+		return params.ProviderCallStatsResult{}, errors.Trace(err)
+	}
+	if !isAdmin {
+		return params.ProviderCallStatsResult{}, common.ErrPerm
+	}
+	stats := api.callStats()
+	result := params.ProviderCallStatsResult{
+		Stats: make([]params.ProviderCallStats, len(stats)),
+	}
+	for i, s := range stats {
+		result.Stats[i] = params.ProviderCallStats{
+			Cloud:         s.Cloud,
+			Region:        s.Region,
+			Caller:        s.Caller,
+			Method:        s.Method,
+			Calls:         s.Calls,
+			Errors:        s.Errors,
+			Throttled:     s.Throttled,
+			TotalDuration: int64(s.TotalDuration),
+			MaxDuration:   int64(s.MaxDuration),
+		}
+		if !s.LastThrottled.IsZero() {
+			lastThrottled := s.LastThrottled
+			result.Stats[i].LastThrottled = &lastThrottled
+		}
+	}
+	return result, nil
+}
+
+// ProviderCallStats isn't on the V7 API.
+func (*CloudAPIV7) ProviderCallStats(_, _ struct{}) {}
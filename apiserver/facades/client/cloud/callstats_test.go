@@ -0,0 +1,68 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloud_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	cloudfacade "github.com/juju/juju/apiserver/facades/client/cloud"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/callaudit"
+)
+
+func (s *cloudSuite) TestProviderCallStats(c *gc.C) {
+	throttledAt := time.Date(2020, 3, 2, 10, 0, 0, 0, time.UTC)
+	cloudfacade.SetProviderCallStats(s.api, func() []callaudit.CallStats {
+		return []callaudit.CallStats{{
+			Labels:        callaudit.Labels{Cloud: "ec2", Region: "us-east-1", Caller: "firewaller"},
+			Method:        "IngressRules",
+			Calls:         10,
+			TotalDuration: 5 * time.Second,
+			MaxDuration:   time.Second,
+		}, {
+			Labels:        callaudit.Labels{Cloud: "ec2", Region: "us-east-1", Caller: "instance-poller"},
+			Method:        "Instances",
+			Calls:         120,
+			Errors:        4,
+			Throttled:     3,
+			TotalDuration: time.Minute,
+			MaxDuration:   2 * time.Second,
+			LastThrottled: throttledAt,
+		}}
+	})
+	result, err := s.api.ProviderCallStats()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ProviderCallStatsResult{
+		Stats: []params.ProviderCallStats{{
+			Cloud:         "ec2",
+			Region:        "us-east-1",
+			Caller:        "firewaller",
+			Method:        "IngressRules",
+			Calls:         10,
+			TotalDuration: int64(5 * time.Second),
+			MaxDuration:   int64(time.Second),
+		}, {
+			Cloud:         "ec2",
+			Region:        "us-east-1",
+			Caller:        "instance-poller",
+			Method:        "Instances",
+			Calls:         120,
+			Errors:        4,
+			Throttled:     3,
+			TotalDuration: int64(time.Minute),
+			MaxDuration:   int64(2 * time.Second),
+			LastThrottled: &throttledAt,
+		}},
+	})
+}
+
+func (s *cloudSuite) TestProviderCallStatsPermissionDenied(c *gc.C) {
+	s.setTestAPIForUser(c, names.NewUserTag("bob"))
+	_, err := s.api.ProviderCallStats()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -22,12 +23,51 @@ import (
 	"github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/callaudit"
 	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 )
 
 var logger = loggo.GetLogger("juju.apiserver.cloud")
 
+// CloudV8 defines the methods on the cloud API facade, version 8.
+type CloudV8 interface {
+	AddCloud(cloudArgs params.AddCloudArgs) error
+	AddCredentials(args params.TaggedCredentials) (params.ErrorResults, error)
+	CheckCredentialsModels(args params.TaggedCredentials) (params.UpdateCredentialResults, error)
+	Cloud(args params.Entities) (params.CloudResults, error)
+	CloudPricing(args params.Entities) (params.CloudPricingResults, error)
+	Clouds() (params.CloudsResult, error)
+	Credential(args params.Entities) (params.CloudCredentialResults, error)
+	CredentialContents(credentialArgs params.CloudCredentialArgs) (params.CredentialContentResults, error)
+	ModifyCloudAccess(args params.ModifyCloudAccessRequest) (params.ErrorResults, error)
+	ProviderCallStats() (params.ProviderCallStatsResult, error)
+	RemoveCloudPricing(args params.Entities) (params.ErrorResults, error)
+	RevokeCredentialsCheckModels(args params.RevokeCredentialArgs) (params.ErrorResults, error)
+	RotateCredentials(args params.TaggedCredentials) (params.UpdateCredentialResults, error)
+	SetCloudPricing(args params.SetCloudPricingArgs) (params.ErrorResults, error)
+	UpdateCredentialsCheckModels(args params.UpdateCredentialArgs) (params.UpdateCredentialResults, error)
+	UserCredentials(args params.UserClouds) (params.StringsResults, error)
+	UpdateCloud(cloudArgs params.UpdateCloudArgs) (params.ErrorResults, error)
+}
+
+// CloudV7 defines the methods on the cloud API facade, version 7.
+type CloudV7 interface {
+	AddCloud(cloudArgs params.AddCloudArgs) error
+	AddCredentials(args params.TaggedCredentials) (params.ErrorResults, error)
+	CheckCredentialsModels(args params.TaggedCredentials) (params.UpdateCredentialResults, error)
+	Cloud(args params.Entities) (params.CloudResults, error)
+	Clouds() (params.CloudsResult, error)
+	Credential(args params.Entities) (params.CloudCredentialResults, error)
+	CredentialContents(credentialArgs params.CloudCredentialArgs) (params.CredentialContentResults, error)
+	ModifyCloudAccess(args params.ModifyCloudAccessRequest) (params.ErrorResults, error)
+	RevokeCredentialsCheckModels(args params.RevokeCredentialArgs) (params.ErrorResults, error)
+	RotateCredentials(args params.TaggedCredentials) (params.UpdateCredentialResults, error)
+	UpdateCredentialsCheckModels(args params.UpdateCredentialArgs) (params.UpdateCredentialResults, error)
+	UserCredentials(args params.UserClouds) (params.StringsResults, error)
+	UpdateCloud(cloudArgs params.UpdateCloudArgs) (params.ErrorResults, error)
+}
+
 // CloudV6 defines the methods on the cloud API facade, version 6.
 type CloudV6 interface {
 	AddCloud(cloudArgs params.AddCloudArgs) error
@@ -128,12 +168,25 @@ type CloudAPI struct {
 	apiUser                names.UserTag
 	getCredentialsAuthFunc common.GetAuthFunc
 	pool                   ModelPoolBackend
+	callStats              func() []callaudit.CallStats
+}
+
+// CloudAPIV7 provides a way to wrap the different calls
+// between version 7 and version 8 of the cloud API.
+type CloudAPIV7 struct {
+	*CloudAPI
+}
+
+// CloudAPIV6 provides a way to wrap the different calls
+// between version 6 and version 7 of the cloud API.
+type CloudAPIV6 struct {
+	*CloudAPIV7
 }
 
 // CloudAPIV5 provides a way to wrap the different calls
 // between version 5 and version 6 of the cloud API.
 type CloudAPIV5 struct {
-	*CloudAPI
+	*CloudAPIV6
 }
 
 // CloudAPIV4 provides a way to wrap the different calls
@@ -161,7 +214,9 @@ type CloudAPIV1 struct {
 }
 
 var (
-	_ CloudV6 = (*CloudAPI)(nil)
+	_ CloudV8 = (*CloudAPI)(nil)
+	_ CloudV7 = (*CloudAPIV7)(nil)
+	_ CloudV6 = (*CloudAPIV6)(nil)
 	_ CloudV5 = (*CloudAPIV5)(nil)
 	_ CloudV4 = (*CloudAPIV4)(nil)
 	_ CloudV3 = (*CloudAPIV3)(nil)
@@ -169,14 +224,32 @@ var (
 	_ CloudV1 = (*CloudAPIV1)(nil)
 )
 
-// NewFacadeV6 is used for API registration.
-func NewFacadeV6(context facade.Context) (*CloudAPI, error) {
+// NewFacadeV8 is used for API registration.
+func NewFacadeV8(context facade.Context) (*CloudAPI, error) {
 	st := NewStateBackend(context.State())
 	pool := NewModelPoolBackend(context.StatePool())
 	ctlrSt := NewStateBackend(pool.SystemState())
 	return NewCloudAPI(st, ctlrSt, pool, context.Auth())
 }
 
+// NewFacadeV7 is used for API registration.
+func NewFacadeV7(context facade.Context) (*CloudAPIV7, error) {
+	v8, err := NewFacadeV8(context)
+	if err != nil {
+		return nil, err
+	}
+	return &CloudAPIV7{v8}, nil
+}
+
+// NewFacadeV6 is used for API registration.
+func NewFacadeV6(context facade.Context) (*CloudAPIV6, error) {
+	v7, err := NewFacadeV7(context)
+	if err != nil {
+		return nil, err
+	}
+	return &CloudAPIV6{v7}, nil
+}
+
 // NewFacadeV5 is used for API registration.
 func NewFacadeV5(context facade.Context) (*CloudAPIV5, error) {
 	v6, err := NewFacadeV6(context)
@@ -250,6 +323,7 @@ func NewCloudAPI(backend, ctlrBackend Backend, pool ModelPoolBackend, authorizer
 		getCredentialsAuthFunc: getUserAuthFunc,
 		apiUser:                authUser,
 		pool:                   pool,
+		callStats:              callaudit.DefaultRecorder.Stats,
 	}, nil
 }
 
@@ -555,10 +629,7 @@ func (api *CloudAPI) AddCredentials(args params.TaggedCredentials) (params.Error
 			continue
 		}
 
-		in := cloud.NewCredential(
-			cloud.AuthType(arg.Credential.AuthType),
-			arg.Credential.Attributes,
-		)
+		in := credentialFromParams(arg.Credential)
 		if err := api.backend.UpdateCloudCredential(tag, in); err != nil {
 			results.Results[i].Error = common.ServerError(err)
 			continue
@@ -617,10 +688,7 @@ func (api *CloudAPI) commonUpdateCredentials(update bool, force bool, args param
 			results[i].Error = common.ServerError(common.ErrPerm)
 			continue
 		}
-		in := cloud.NewCredential(
-			cloud.AuthType(arg.Credential.AuthType),
-			arg.Credential.Attributes,
-		)
+		in := credentialFromParams(arg.Credential)
 
 		models, err := api.credentialModels(tag)
 		if err != nil {
@@ -675,6 +743,26 @@ func (api *CloudAPI) commonUpdateCredentials(update bool, force bool, args param
 	return params.UpdateCredentialResults{results}, nil
 }
 
+// credentialFromParams converts a credential passed to the API
+// to the form stored on the controller.
+func credentialFromParams(in params.CloudCredential) cloud.Credential {
+	credential := cloud.NewCredential(cloud.AuthType(in.AuthType), in.Attributes)
+	if in.Expiry != nil {
+		credential.Expiry = in.Expiry.UTC()
+	}
+	return credential
+}
+
+// expiryToParams returns the credential expiry to report through
+// the API, which is nil if the expiry is not known.
+func expiryToParams(expiry time.Time) *time.Time {
+	if expiry.IsZero() {
+		return nil
+	}
+	expiry = expiry.UTC()
+	return &expiry
+}
+
 func (api *CloudAPI) credentialModels(tag names.CloudCredentialTag) (map[string]string, error) {
 	models, err := api.backend.CredentialModels(tag)
 	if err != nil && !errors.IsNotFound(err) {
@@ -708,12 +796,137 @@ func (api *CloudAPI) validateCredentialForModel(modelUUID string, tag names.Clou
 
 var validateNewCredentialForModelFunc = credentialcommon.ValidateNewModelCredential
 
+// RotateCredentials replaces the content of a set of cloud credentials
+// without interrupting the models that use them.
+//
+// Every credential is first validated against every model that uses it,
+// and no credential is changed unless all of those validations succeed.
+// Each credential is then replaced in a single update, so that all of the
+// workers of the models using it switch to the new content together. If
+// any credential cannot be replaced, those already replaced are restored
+// to their previous content.
+func (api *CloudAPI) RotateCredentials(args params.TaggedCredentials) (params.UpdateCredentialResults, error) {
+	authFunc, err := api.getCredentialsAuthFunc()
+	if err != nil {
+		return params.UpdateCredentialResults{}, err
+	}
+
+	type rotation struct {
+		tag      names.CloudCredentialTag
+		current  cloud.Credential
+		previous cloud.Credential
+	}
+	rotations := make([]rotation, len(args.Credentials))
+	results := make([]params.UpdateCredentialResult, len(args.Credentials))
+	var failed bool
+	for i, arg := range args.Credentials {
+		results[i].CredentialTag = arg.Tag
+		tag, err := names.ParseCloudCredentialTag(arg.Tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			failed = true
+			continue
+		}
+		if !authFunc(tag.Owner()) {
+			results[i].Error = common.ServerError(common.ErrPerm)
+			failed = true
+			continue
+		}
+		existing, err := api.backend.CloudCredential(tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			failed = true
+			continue
+		}
+		previous := cloud.NewCredential(cloud.AuthType(existing.AuthType), existing.Attributes)
+		previous.Expiry = existing.Expiry
+		previous.Invalid = !existing.IsValid()
+		previous.InvalidReason = existing.InvalidReason
+		rotations[i] = rotation{
+			tag:      tag,
+			current:  credentialFromParams(arg.Credential),
+			previous: previous,
+		}
+
+		models, err := api.credentialModels(tag)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			failed = true
+			continue
+		}
+		var modelsResult []params.UpdateCredentialModelResult
+		for uuid, name := range models {
+			model := params.UpdateCredentialModelResult{
+				ModelUUID: uuid,
+				ModelName: name,
+				Errors:    api.validateCredentialForModel(uuid, tag, &rotations[i].current),
+			}
+			modelsResult = append(modelsResult, model)
+		}
+		sort.Slice(modelsResult, func(i, j int) bool {
+			return modelsResult[i].ModelUUID < modelsResult[j].ModelUUID
+		})
+		results[i].Models = modelsResult
+
+		var invalid []string
+		for _, m := range modelsResult {
+			if len(m.Errors) > 0 {
+				invalid = append(invalid, fmt.Sprintf("%q (uuid %v)", m.ModelName, m.ModelUUID))
+			}
+		}
+		if len(invalid) > 0 {
+			results[i].Error = common.ServerError(errors.Errorf(
+				"credential not valid for models %s", strings.Join(invalid, ", "),
+			))
+			failed = true
+		}
+	}
+	if failed {
+		// Rotation is all or nothing, so the credentials that
+		// were valid for their models are not rotated either.
+		for i := range results {
+			if results[i].Error == nil {
+				results[i].Error = common.ServerError(errors.New("credential not rotated: other credentials failed validation"))
+			}
+		}
+		return params.UpdateCredentialResults{results}, nil
+	}
+
+	for i, r := range rotations {
+		err := api.backend.UpdateCloudCredential(r.tag, r.current)
+		if err == nil {
+			continue
+		}
+		results[i].Error = common.ServerError(err)
+		for j := i - 1; j >= 0; j-- {
+			rollbackErr := errors.New("credential rotation rolled back")
+			if err := api.backend.UpdateCloudCredential(rotations[j].tag, rotations[j].previous); err != nil {
+				logger.Errorf("rolling back rotation of credential %q: %v", rotations[j].tag.Id(), err)
+				rollbackErr = errors.Annotate(err, "cannot roll back credential rotation")
+			}
+			results[j].Error = common.ServerError(rollbackErr)
+		}
+		for j := i + 1; j < len(results); j++ {
+			results[j].Error = common.ServerError(errors.New("credential not rotated: rotation of other credentials failed"))
+		}
+		break
+	}
+	return params.UpdateCredentialResults{results}, nil
+}
+
 // Mask out old methods from the new API versions. The API reflection
 // code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
 // so this removes the method as far as the RPC machinery is concerned.
 // UpdateCredentials was dropped in V3, replaced with UpdateCredentialsCheckModels.
 func (*CloudAPI) UpdateCredentials(_, _ struct{}) {}
 
+// Mask out new methods from the old API versions. The API reflection
+// code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
+// so this removes the method as far as the RPC machinery is concerned.
+//
+// RotateCredentials did not exist before V7.
+func (*CloudAPIV6) RotateCredentials(_, _ struct{}) {}
+
 // Mask out old methods from the new API versions. The API reflection
 // code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
 // so this removes the method as far as the RPC machinery is concerned.
@@ -984,6 +1197,7 @@ func (api *CloudAPI) Credential(args params.Entities) (params.CloudCredentialRes
 			AuthType:   cred.AuthType,
 			Attributes: attrs,
 			Redacted:   redacted,
+			Expiry:     expiryToParams(cred.Expiry),
 		}
 	}
 	return results, nil
@@ -1149,6 +1363,7 @@ func (api *CloudAPI) internalCredentialContents(args params.CloudCredentialArgs,
 				AuthType:   credential.AuthType,
 				Attributes: attrs,
 				Cloud:      credential.Cloud,
+				Expiry:     expiryToParams(credential.Expiry),
 			},
 		}
 		if includeValidity {
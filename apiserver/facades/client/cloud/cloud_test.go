@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"regexp"
 	"sort"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/juju/permission"
@@ -23,6 +24,7 @@ import (
 	"github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/cost"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
 	_ "github.com/juju/juju/provider/dummy"
@@ -600,6 +602,25 @@ func (s *cloudSuite) TestUpdateCredentialsAdminAccess(c *gc.C) {
 		Results: []params.UpdateCredentialResult{{CredentialTag: "cloudcred-meep_julia_three"}}})
 }
 
+func (s *cloudSuite) TestUpdateCredentialsExpiry(c *gc.C) {
+	expiry := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	results, err := s.api.UpdateCredentialsCheckModels(params.UpdateCredentialArgs{
+		Credentials: []params.TaggedCredential{{
+			Tag: "cloudcred-meep_julia_three",
+			Credential: params.CloudCredential{
+				AuthType: "userpass",
+				Expiry:   &expiry,
+			},
+		}}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.UpdateCredentialResults{
+		Results: []params.UpdateCredentialResult{{CredentialTag: "cloudcred-meep_julia_three"}}})
+
+	expected := cloud.NewCredential(cloud.UserPassAuthType, nil)
+	expected.Expiry = expiry
+	s.backend.CheckCall(c, 2, "UpdateCloudCredential", names.NewCloudCredentialTag("meep/julia/three"), expected)
+}
+
 func (s *cloudSuite) TestUpdateCredentialsNoModelsFound(c *gc.C) {
 	s.backend.credentialModelsF = func(tag names.CloudCredentialTag) (map[string]string, error) {
 		return nil, errors.NotFoundf("how about it")
@@ -990,6 +1011,152 @@ func (s *cloudSuite) TestUpdateCredentialsAllModelsFailedValidationForce(c *gc.C
 	)
 }
 
+func (s *cloudSuite) TestRotateCredentials(c *gc.C) {
+	s.backend.credentialModelsF = func(tag names.CloudCredentialTag) (map[string]string, error) {
+		return map[string]string{
+			coretesting.ModelTag.Id(): "testModel1",
+		}, nil
+	}
+	s.PatchValue(cloudfacade.ValidateNewCredentialForModelFunc, func(backend credentialcommon.PersistentBackend, callCtx context.ProviderCallContext, credentialTag names.CloudCredentialTag, credential *cloud.Credential) (params.ErrorResults, error) {
+		return params.ErrorResults{}, nil
+	})
+
+	results, err := s.api.RotateCredentials(params.TaggedCredentials{
+		Credentials: []params.TaggedCredential{{
+			Tag: "cloudcred-meep_bruce_one",
+			Credential: params.CloudCredential{
+				AuthType:   "userpass",
+				Attributes: map[string]string{"username": "bruce", "password": "n3w"},
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.UpdateCredentialResults{
+		Results: []params.UpdateCredentialResult{{
+			CredentialTag: "cloudcred-meep_bruce_one",
+			Models: []params.UpdateCredentialModelResult{{
+				ModelUUID: coretesting.ModelTag.Id(),
+				ModelName: "testModel1",
+			}},
+		}},
+	})
+	s.backend.CheckCallNames(c, "ControllerTag", "CloudCredential", "CredentialModels", "UpdateCloudCredential")
+	s.backend.CheckCall(c, 3, "UpdateCloudCredential",
+		names.NewCloudCredentialTag("meep/bruce/one"),
+		cloud.NewCredential(cloud.UserPassAuthType, map[string]string{"username": "bruce", "password": "n3w"}),
+	)
+}
+
+func (s *cloudSuite) TestRotateCredentialsFailedValidation(c *gc.C) {
+	s.backend.credentialModelsF = func(tag names.CloudCredentialTag) (map[string]string, error) {
+		return map[string]string{
+			tag.Name(): "model-" + tag.Name(),
+		}, nil
+	}
+	s.PatchValue(cloudfacade.ValidateNewCredentialForModelFunc, func(backend credentialcommon.PersistentBackend, callCtx context.ProviderCallContext, credentialTag names.CloudCredentialTag, credential *cloud.Credential) (params.ErrorResults, error) {
+		if credentialTag.Name() == "two" {
+			return params.ErrorResults{[]params.ErrorResult{{&params.Error{Message: "not valid for model"}}}}, nil
+		}
+		return params.ErrorResults{}, nil
+	})
+
+	results, err := s.api.RotateCredentials(params.TaggedCredentials{
+		Credentials: []params.TaggedCredential{{
+			Tag:        "cloudcred-meep_bruce_one",
+			Credential: params.CloudCredential{AuthType: "empty"},
+		}, {
+			Tag:        "cloudcred-meep_bruce_two",
+			Credential: params.CloudCredential{AuthType: "empty"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.UpdateCredentialResults{
+		Results: []params.UpdateCredentialResult{{
+			CredentialTag: "cloudcred-meep_bruce_one",
+			Error:         &params.Error{Message: "credential not rotated: other credentials failed validation"},
+			Models: []params.UpdateCredentialModelResult{{
+				ModelUUID: "one",
+				ModelName: "model-one",
+			}},
+		}, {
+			CredentialTag: "cloudcred-meep_bruce_two",
+			Error:         &params.Error{Message: `credential not valid for models "model-two" (uuid two)`},
+			Models: []params.UpdateCredentialModelResult{{
+				ModelUUID: "two",
+				ModelName: "model-two",
+				Errors:    []params.ErrorResult{{Error: &params.Error{Message: "not valid for model"}}},
+			}},
+		}},
+	})
+	s.backend.CheckCallNames(c, "ControllerTag",
+		"CloudCredential", "CredentialModels",
+		"CloudCredential", "CredentialModels",
+	)
+}
+
+func (s *cloudSuite) TestRotateCredentialsNotFound(c *gc.C) {
+	results, err := s.api.RotateCredentials(params.TaggedCredentials{
+		Credentials: []params.TaggedCredential{{
+			Tag:        "cloudcred-meep_julia_three",
+			Credential: params.CloudCredential{AuthType: "empty"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `credential "meep/julia/three" not found`)
+	s.backend.CheckCallNames(c, "ControllerTag", "CloudCredential")
+}
+
+func (s *cloudSuite) TestRotateCredentialsRollback(c *gc.C) {
+	s.PatchValue(cloudfacade.ValidateNewCredentialForModelFunc, func(backend credentialcommon.PersistentBackend, callCtx context.ProviderCallContext, credentialTag names.CloudCredentialTag, credential *cloud.Credential) (params.ErrorResults, error) {
+		return params.ErrorResults{}, nil
+	})
+	s.backend.SetErrors(nil, errors.New("boom"))
+
+	newCredential := cloud.NewCredential(cloud.UserPassAuthType, map[string]string{"username": "bruce", "password": "n3w"})
+	results, err := s.api.RotateCredentials(params.TaggedCredentials{
+		Credentials: []params.TaggedCredential{{
+			Tag: "cloudcred-meep_bruce_two",
+			Credential: params.CloudCredential{
+				AuthType:   "userpass",
+				Attributes: newCredential.Attributes(),
+			},
+		}, {
+			Tag: "cloudcred-meep_bruce_one",
+			Credential: params.CloudCredential{
+				AuthType:   "userpass",
+				Attributes: newCredential.Attributes(),
+			},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.UpdateCredentialResults{
+		Results: []params.UpdateCredentialResult{{
+			CredentialTag: "cloudcred-meep_bruce_two",
+			Error:         &params.Error{Message: "credential rotation rolled back"},
+		}, {
+			CredentialTag: "cloudcred-meep_bruce_one",
+			Error:         &params.Error{Message: "boom"},
+		}},
+	})
+
+	tagTwo := names.NewCloudCredentialTag("meep/bruce/two")
+	tagOne := names.NewCloudCredentialTag("meep/bruce/one")
+	s.backend.CheckCalls(c, []gitjujutesting.StubCall{
+		{"ControllerTag", nil},
+		{"CloudCredential", []interface{}{tagTwo}},
+		{"CredentialModels", []interface{}{tagTwo}},
+		{"CloudCredential", []interface{}{tagOne}},
+		{"CredentialModels", []interface{}{tagOne}},
+		{"UpdateCloudCredential", []interface{}{tagTwo, newCredential}},
+		{"UpdateCloudCredential", []interface{}{tagOne, newCredential}},
+		{"UpdateCloudCredential", []interface{}{tagTwo, cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+			"username": "admin",
+			"password": "adm1n",
+		})}},
+	})
+}
+
 func (s *cloudSuite) TestRevokeCredentials(c *gc.C) {
 	s.setTestAPIForUser(c, names.NewUserTag("bruce"))
 	results, err := s.api.RevokeCredentialsCheckModels(params.RevokeCredentialArgs{
@@ -1355,6 +1522,7 @@ type mockBackend struct {
 	creds         map[string]state.Credential
 	cloudAccess   permission.Access
 	controllerCfg controller.Config
+	pricing       cost.Pricing
 
 	credentialModelsF func(tag names.CloudCredentialTag) (map[string]string, error)
 	credsModels       []state.CredentialOwnerModelAccess
@@ -1403,6 +1571,15 @@ func (st *mockBackend) CloudCredentials(user names.UserTag, cloudName string) (m
 	return st.creds, st.NextErr()
 }
 
+func (st *mockBackend) CloudCredential(tag names.CloudCredentialTag) (state.Credential, error) {
+	st.MethodCall(st, "CloudCredential", tag)
+	cred, ok := st.creds[tag.Id()]
+	if !ok {
+		return state.Credential{}, errors.NotFoundf("credential %q", tag.Id())
+	}
+	return cred, nil
+}
+
 func (st *mockBackend) UpdateCloudCredential(tag names.CloudCredentialTag, cred cloud.Credential) error {
 	st.MethodCall(st, "UpdateCloudCredential", tag, cred)
 	return st.NextErr()
@@ -1434,6 +1611,21 @@ func (st *mockBackend) RemoveCloud(name string) error {
 	return errors.NotImplementedf("RemoveCloud")
 }
 
+func (st *mockBackend) SetCloudPricing(name string, pricing cost.Pricing) error {
+	st.MethodCall(st, "SetCloudPricing", name, pricing)
+	return st.NextErr()
+}
+
+func (st *mockBackend) CloudPricing(name string) (cost.Pricing, error) {
+	st.MethodCall(st, "CloudPricing", name)
+	return st.pricing, st.NextErr()
+}
+
+func (st *mockBackend) RemoveCloudPricing(name string) error {
+	st.MethodCall(st, "RemoveCloudPricing", name)
+	return st.NextErr()
+}
+
 func (st *mockBackend) AllCloudCredentials(user names.UserTag) ([]state.Credential, error) {
 	st.MethodCall(st, "AllCloudCredentials", user)
 	var result []state.Credential
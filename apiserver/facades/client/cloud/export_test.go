@@ -3,7 +3,15 @@
 
 package cloud
 
+import (
+	"github.com/juju/juju/environs/callaudit"
+)
+
 var (
 	InstanceTypes                     = instanceTypes
 	ValidateNewCredentialForModelFunc = &validateNewCredentialForModelFunc
 )
+
+func SetProviderCallStats(api *CloudAPI, stats func() []callaudit.CallStats) {
+	api.callStats = stats
+}
@@ -19,7 +19,6 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/environs/instances"
-	"github.com/juju/juju/state"
 )
 
 type instanceTypesSuite struct{}
@@ -105,10 +104,6 @@ func (*mockBackend) ModelConfig() (*config.Config, error) {
 	return nil, nil
 }
 
-func (b *mockBackend) CloudCredential(tag names.CloudCredentialTag) (state.Credential, error) {
-	return state.Credential{}, nil
-}
-
 type mockEnviron struct {
 	environs.Environ
 	cloud.Backend
@@ -0,0 +1,153 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloud
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/cost"
+	"github.com/juju/juju/permission"
+)
+
+// checkCloudAccess returns an error if the user does not have at least
+// the given access to the cloud, unless they are a controller superuser.
+func (api *CloudAPI) checkCloudAccess(isAdmin bool, tag names.CloudTag, access permission.Access) error {
+	if isAdmin {
+		return nil
+	}
+	canAccess, err := api.canAccessCloud(tag.Id(), api.apiUser, access)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canAccess {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+func (api *CloudAPI) isControllerAdmin() (bool, error) {
+	isAdmin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.ctlrBackend.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return false, errors.Trace(err)
+	}
+	return isAdmin, nil
+}
+
+// SetCloudPricing sets the prices of the resources provided by clouds,
+// used to estimate what models cost to run. Only controller superusers
+// and cloud admins may set a cloud's pricing.
+func (api *CloudAPI) SetCloudPricing(args params.SetCloudPricingArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	isAdmin, err := api.isControllerAdmin()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		tag, err := names.ParseCloudTag(arg.CloudTag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if err := api.checkCloudAccess(isAdmin, tag, permission.AdminAccess); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		err = api.backend.SetCloudPricing(tag.Id(), pricingFromParams(arg.Pricing))
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// CloudPricing returns the prices set for the specified clouds. Users
+// need to be able to add models to a cloud to see its pricing.
+func (api *CloudAPI) CloudPricing(args params.Entities) (params.CloudPricingResults, error) {
+	results := params.CloudPricingResults{
+		Results: make([]params.CloudPricingResult, len(args.Entities)),
+	}
+	isAdmin, err := api.isControllerAdmin()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseCloudTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if err := api.checkCloudAccess(isAdmin, tag, permission.AddModelAccess); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		pricing, err := api.backend.CloudPricing(tag.Id())
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		result := pricingToParams(pricing)
+		results.Results[i].Result = &result
+	}
+	return results, nil
+}
+
+// RemoveCloudPricing removes the prices set for the specified clouds.
+// Only controller superusers and cloud admins may remove a cloud's
+// pricing.
+func (api *CloudAPI) RemoveCloudPricing(args params.Entities) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	isAdmin, err := api.isControllerAdmin()
+	if err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseCloudTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		if err := api.checkCloudAccess(isAdmin, tag, permission.AdminAccess); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Error = common.ServerError(api.backend.RemoveCloudPricing(tag.Id()))
+	}
+	return results, nil
+}
+
+// Mask out new methods from the old API versions. The API reflection
+// code in rpc/rpcreflect/type.go:newMethod skips 2-argument methods,
+// so this removes the method as far as the RPC machinery is concerned.
+//
+// The cloud pricing methods did not exist before V8.
+func (*CloudAPIV7) SetCloudPricing(_, _ struct{})    {}
+func (*CloudAPIV7) CloudPricing(_, _ struct{})       {}
+func (*CloudAPIV7) RemoveCloudPricing(_, _ struct{}) {}
+
+func pricingFromParams(p params.CloudPricing) cost.Pricing {
+	return cost.Pricing{
+		Currency:         p.Currency,
+		InstanceTypes:    p.InstanceTypes,
+		CPUCoreHour:      p.CPUCoreHour,
+		MemoryGiBHour:    p.MemoryGiBHour,
+		VolumeGiBMonth:   p.VolumeGiBMonth,
+		LoadBalancerHour: p.LoadBalancerHour,
+	}
+}
+
+func pricingToParams(p cost.Pricing) params.CloudPricing {
+	return params.CloudPricing{
+		Currency:         p.Currency,
+		InstanceTypes:    p.InstanceTypes,
+		CPUCoreHour:      p.CPUCoreHour,
+		MemoryGiBHour:    p.MemoryGiBHour,
+		VolumeGiBMonth:   p.VolumeGiBMonth,
+		LoadBalancerHour: p.LoadBalancerHour,
+	}
+}
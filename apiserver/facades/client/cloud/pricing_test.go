@@ -0,0 +1,122 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloud_test
+
+import (
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/cost"
+	"github.com/juju/juju/permission"
+)
+
+var (
+	testPricing = cost.Pricing{
+		Currency:         "USD",
+		InstanceTypes:    map[string]float64{"m5.large": 0.096},
+		CPUCoreHour:      0.03,
+		VolumeGiBMonth:   0.1,
+		LoadBalancerHour: 0.025,
+	}
+	testPricingParams = params.CloudPricing{
+		Currency:         "USD",
+		InstanceTypes:    map[string]float64{"m5.large": 0.096},
+		CPUCoreHour:      0.03,
+		VolumeGiBMonth:   0.1,
+		LoadBalancerHour: 0.025,
+	}
+)
+
+func (s *cloudSuite) TestSetCloudPricing(c *gc.C) {
+	results, err := s.api.SetCloudPricing(params.SetCloudPricingArgs{
+		Args: []params.SetCloudPricingArg{
+			{CloudTag: "cloud-dummy", Pricing: testPricingParams},
+			{CloudTag: "machine-0", Pricing: testPricingParams},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: `"machine-0" is not a valid cloud tag`}},
+	})
+	s.backend.CheckCalls(c, []gitjujutesting.StubCall{
+		{"SetCloudPricing", []interface{}{"dummy", testPricing}},
+	})
+}
+
+func (s *cloudSuite) TestSetCloudPricingCloudAdmin(c *gc.C) {
+	s.setTestAPIForUser(c, names.NewUserTag("bob"))
+	s.ctlrBackend.cloudAccess = permission.AdminAccess
+	results, err := s.api.SetCloudPricing(params.SetCloudPricingArgs{
+		Args: []params.SetCloudPricingArg{{CloudTag: "cloud-dummy", Pricing: testPricingParams}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "SetCloudPricing")
+}
+
+func (s *cloudSuite) TestSetCloudPricingPermissionDenied(c *gc.C) {
+	s.setTestAPIForUser(c, names.NewUserTag("bob"))
+	s.ctlrBackend.cloudAccess = permission.AddModelAccess
+	results, err := s.api.SetCloudPricing(params.SetCloudPricingArgs{
+		Args: []params.SetCloudPricingArg{{CloudTag: "cloud-dummy", Pricing: testPricingParams}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *cloudSuite) TestCloudPricing(c *gc.C) {
+	s.setTestAPIForUser(c, names.NewUserTag("bob"))
+	s.ctlrBackend.cloudAccess = permission.AddModelAccess
+	s.backend.pricing = testPricing
+	results, err := s.api.CloudPricing(params.Entities{
+		Entities: []params.Entity{{Tag: "cloud-dummy"}, {Tag: "cloud-your-cloud"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result, jc.DeepEquals, &testPricingParams)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "permission denied")
+	s.backend.CheckCalls(c, []gitjujutesting.StubCall{
+		{"CloudPricing", []interface{}{"dummy"}},
+	})
+}
+
+func (s *cloudSuite) TestCloudPricingNotFound(c *gc.C) {
+	s.backend.SetErrors(errors.NotFoundf(`pricing for cloud "dummy"`))
+	results, err := s.api.CloudPricing(params.Entities{
+		Entities: []params.Entity{{Tag: "cloud-dummy"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Assert(results.Results[0].Result, gc.IsNil)
+}
+
+func (s *cloudSuite) TestRemoveCloudPricing(c *gc.C) {
+	results, err := s.api.RemoveCloudPricing(params.Entities{
+		Entities: []params.Entity{{Tag: "cloud-dummy"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	s.backend.CheckCalls(c, []gitjujutesting.StubCall{
+		{"RemoveCloudPricing", []interface{}{"dummy"}},
+	})
+}
+
+func (s *cloudSuite) TestRemoveCloudPricingPermissionDenied(c *gc.C) {
+	s.setTestAPIForUser(c, names.NewUserTag("bob"))
+	s.ctlrBackend.cloudAccess = permission.AddModelAccess
+	results, err := s.api.RemoveCloudPricing(params.Entities{
+		Entities: []params.Entity{{Tag: "cloud-dummy"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
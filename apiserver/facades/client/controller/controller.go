@@ -51,10 +51,23 @@ type ControllerAPI struct {
 	hub        facade.Hub
 }
 
+// ControllerAPIv9 provides the v9 Controller API. The only difference
+// between this and v10 is that v9 doesn't have the SchemaStatus method.
+type ControllerAPIv9 struct {
+	*ControllerAPI
+}
+
+// ControllerAPIv8 provides the v8 Controller API. The only difference
+// between this and v9 is that v8 doesn't have the FeatureFlagHistory
+// method.
+type ControllerAPIv8 struct {
+	*ControllerAPIv9
+}
+
 // ControllerAPIv7 provides the v7 Controller API. The only difference
 // between this and v8 is that v7 doesn't have the ControllerVersion method.
 type ControllerAPIv7 struct {
-	*ControllerAPI
+	*ControllerAPIv8
 }
 
 // ControllerAPIv6 provides the v6 Controller API. The only difference
@@ -81,8 +94,8 @@ type ControllerAPIv3 struct {
 	*ControllerAPIv4
 }
 
-// NewControllerAPIv8 creates a new ControllerAPIv7.
-func NewControllerAPIv8(ctx facade.Context) (*ControllerAPI, error) {
+// NewControllerAPIv10 creates a new ControllerAPI.
+func NewControllerAPIv10(ctx facade.Context) (*ControllerAPI, error) {
 	st := ctx.State()
 	authorizer := ctx.Auth()
 	pool := ctx.StatePool()
@@ -100,6 +113,24 @@ func NewControllerAPIv8(ctx facade.Context) (*ControllerAPI, error) {
 	)
 }
 
+// NewControllerAPIv9 creates a new ControllerAPIv9.
+func NewControllerAPIv9(ctx facade.Context) (*ControllerAPIv9, error) {
+	v10, err := NewControllerAPIv10(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv9{v10}, nil
+}
+
+// NewControllerAPIv8 creates a new ControllerAPIv8.
+func NewControllerAPIv8(ctx facade.Context) (*ControllerAPIv8, error) {
+	v9, err := NewControllerAPIv9(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ControllerAPIv8{v9}, nil
+}
+
 // NewControllerAPIv7 creates a new ControllerAPIv7.
 func NewControllerAPIv7(ctx facade.Context) (*ControllerAPIv7, error) {
 	v8, err := NewControllerAPIv8(ctx)
@@ -646,7 +677,7 @@ func (c *ControllerAPI) ConfigSet(args params.ControllerConfigSet) error {
 	if err := c.checkHasAdmin(); err != nil {
 		return errors.Trace(err)
 	}
-	if err := c.state.UpdateControllerConfig(args.Config, nil); err != nil {
+	if err := c.state.UpdateControllerConfigBy(c.apiUser.Name(), args.Config, nil); err != nil {
 		return errors.Trace(err)
 	}
 	// TODO(thumper): add a version to controller config to allow for
@@ -664,6 +695,84 @@ func (c *ControllerAPI) ConfigSet(args params.ControllerConfigSet) error {
 	return nil
 }
 
+// FeatureFlagHistory isn't on the v8 API.
+func (c *ControllerAPIv8) FeatureFlagHistory(_, _ struct{}) {}
+
+// FeatureFlagHistory returns the feature flags currently enabled on
+// the controller, along with the changes made to them through the
+// features controller config setting and who made them.
+func (c *ControllerAPI) FeatureFlagHistory() (params.FeatureFlagHistoryResult, error) {
+	result := params.FeatureFlagHistoryResult{
+		Enabled: []string{},
+		Changes: []params.FeatureFlagChange{},
+	}
+	if err := c.checkHasAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	cfg, err := c.state.ControllerConfig()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Enabled = append(result.Enabled, cfg.Features().SortedValues()...)
+	changes, err := c.state.FeatureFlagChanges()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, change := range changes {
+		result.Changes = append(result.Changes, params.FeatureFlagChange{
+			Flag:    change.Flag,
+			Enabled: change.Enabled,
+			Who:     change.Who,
+			Time:    change.Time,
+		})
+	}
+	return result, nil
+}
+
+// SchemaStatus isn't on the v9 API.
+func (c *ControllerAPIv9) SchemaStatus(_, _ struct{}) {}
+
+// SchemaStatus returns the schema version of the controller's
+// database, and the progress of the online schema migrations which
+// bring it up to date after an upgrade.
+func (c *ControllerAPI) SchemaStatus() (params.SchemaStatusResult, error) {
+	result := params.SchemaStatusResult{
+		LatestVersion: state.LatestSchemaVersion(),
+		Migrations:    []params.SchemaMigration{},
+	}
+	if err := c.checkHasAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	version, err := c.state.SchemaVersion()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Version = version
+	migrations, err := c.state.SchemaMigrations()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, migration := range migrations {
+		info := params.SchemaMigration{
+			Name:        migration.Name,
+			Description: migration.Description,
+			Version:     migration.Version,
+			Done:        migration.Done,
+			Processed:   migration.Processed,
+		}
+		if !migration.Started.IsZero() {
+			started := migration.Started
+			info.Started = &started
+		}
+		if !migration.Completed.IsZero() {
+			completed := migration.Completed
+			info.Completed = &completed
+		}
+		result.Migrations = append(result.Migrations, info)
+	}
+	return result, nil
+}
+
 // Mask the ConfigSet method from the v4 API. The API reflection code
 // in rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so
 // this removes the method as far as the RPC machinery is concerned.
@@ -63,7 +63,7 @@ func (s *controllerSuite) SetUpTest(c *gc.C) {
 	}
 	s.hub = pubsub.NewStructuredHub(nil)
 
-	controller, err := controller.NewControllerAPIv8(
+	controller, err := controller.NewControllerAPIv10(
 		facadetest.Context{
 			State_:     s.State,
 			StatePool_: s.StatePool,
@@ -981,6 +981,75 @@ func (s *controllerSuite) TestConfigSetPublishesEvent(c *gc.C) {
 	c.Assert(config.Features().SortedValues(), jc.DeepEquals, []string{"bar", "foo"})
 }
 
+func (s *controllerSuite) TestFeatureFlagHistory(c *gc.C) {
+	err := s.controller.ConfigSet(params.ControllerConfigSet{Config: map[string]interface{}{
+		"features": []string{"foo", "bar"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.controller.FeatureFlagHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Enabled, jc.DeepEquals, []string{"bar", "foo"})
+	c.Assert(result.Changes, gc.HasLen, 2)
+	for i, flag := range []string{"bar", "foo"} {
+		c.Check(result.Changes[i].Flag, gc.Equals, flag)
+		c.Check(result.Changes[i].Enabled, jc.IsTrue)
+		c.Check(result.Changes[i].Who, gc.Equals, s.Owner.Name())
+	}
+}
+
+func (s *controllerSuite) TestFeatureFlagHistoryRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endpoint, err := controller.NewControllerAPIv10(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.FeatureFlagHistory()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *controllerSuite) TestSchemaStatus(c *gc.C) {
+	result, err := s.controller.SchemaStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Version, gc.Equals, state.LatestSchemaVersion())
+	c.Assert(result.LatestVersion, gc.Equals, state.LatestSchemaVersion())
+	c.Assert(result.Migrations, gc.Not(gc.HasLen), 0)
+	for _, migration := range result.Migrations {
+		c.Check(migration.Done, jc.IsTrue)
+		c.Check(migration.Started, gc.IsNil)
+	}
+}
+
+func (s *controllerSuite) TestSchemaStatusRequiresSuperUser(c *gc.C) {
+	user := s.Factory.MakeUser(c, &factory.UserParams{
+		Access: permission.ReadAccess,
+	})
+	anAuthoriser := apiservertesting.FakeAuthorizer{
+		Tag: user.Tag(),
+	}
+	endpoint, err := controller.NewControllerAPIv10(
+		facadetest.Context{
+			State_:     s.State,
+			StatePool_: s.StatePool,
+			Resources_: s.resources,
+			Auth_:      anAuthoriser,
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = endpoint.SchemaStatus()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
 func (s *controllerSuite) TestMongoVersion(c *gc.C) {
 	result, err := s.controller.MongoVersion()
 	c.Assert(err, jc.ErrorIsNil)
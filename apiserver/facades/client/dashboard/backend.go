@@ -0,0 +1,162 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dashboard
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the Dashboard
+// facade.
+type Backend interface {
+	// ModelSummariesForUser returns summaries of the models the user
+	// can see. See state.State.ModelSummariesForUser.
+	ModelSummariesForUser(user names.UserTag, all bool) ([]state.ModelSummary, error)
+
+	// ModelApplications returns the applications in the model with the
+	// given UUID, sorted by name. Their units are only included if
+	// withUnits is true.
+	ModelApplications(modelUUID string, withUnits bool) ([]Application, error)
+}
+
+// Application holds the details of an application shown in the
+// dashboard.
+type Application struct {
+	Name      string
+	Charm     string
+	Exposed   bool
+	Status    status.StatusInfo
+	UnitCount int
+	Units     []Unit
+}
+
+// Unit holds the details of a unit shown in the dashboard.
+type Unit struct {
+	Name           string
+	Machine        string
+	WorkloadStatus status.StatusInfo
+	AgentStatus    status.StatusInfo
+}
+
+type stateShim struct {
+	*state.State
+	pool *state.StatePool
+}
+
+// NewStateBackend returns a Backend which reads the models in the
+// given pool.
+func NewStateBackend(pool *state.StatePool) Backend {
+	return &stateShim{
+		State: pool.SystemState(),
+		pool:  pool,
+	}
+}
+
+// ModelApplications is part of the Backend interface.
+func (s *stateShim) ModelApplications(modelUUID string, withUnits bool) ([]Application, error) {
+	st, err := s.pool.Get(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer st.Release()
+
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The statuses of the whole model are loaded in one query, rather
+	// than one for each entity.
+	modelStatus, err := model.LoadModelStatus()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	apps, err := st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	result := make([]Application, 0, len(apps))
+	for _, app := range apps {
+		units, err := app.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		unitNames := make([]string, len(units))
+		for i, unit := range units {
+			unitNames[i] = unit.Name()
+		}
+		appStatus, err := modelStatus.Application(app.Name(), unitNames)
+		if err != nil {
+			return nil, errors.Annotatef(err, "application %q", app.Name())
+		}
+		curl, _ := app.CharmURL()
+		info := Application{
+			Name:      app.Name(),
+			Charm:     curl.String(),
+			Exposed:   app.IsExposed(),
+			Status:    appStatus,
+			UnitCount: len(units),
+		}
+		if withUnits {
+			expectWorkload, err := expectsWorkload(model, app)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			for _, unit := range units {
+				unitInfo, err := loadUnit(modelStatus, unit, expectWorkload)
+				if err != nil {
+					return nil, errors.Annotatef(err, "unit %q", unit.Name())
+				}
+				info.Units = append(info.Units, unitInfo)
+			}
+		}
+		result = append(result, info)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// expectsWorkload reports whether the units of the application are
+// expected to run a workload; in CAAS models they only do once the
+// application has a pod spec.
+func expectsWorkload(model *state.Model, app *state.Application) (bool, error) {
+	cm, err := model.CAASModel()
+	if err != nil {
+		return true, nil
+	}
+	_, err = cm.PodSpec(app.ApplicationTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return false, errors.Trace(err)
+	}
+	return err == nil, nil
+}
+
+func loadUnit(modelStatus *state.ModelStatus, unit *state.Unit, expectWorkload bool) (Unit, error) {
+	result := Unit{Name: unit.Name()}
+	if unit.ShouldBeAssigned() {
+		machineID, err := unit.AssignedMachineId()
+		if err != nil && !errors.IsNotAssigned(err) {
+			return Unit{}, errors.Trace(err)
+		}
+		result.Machine = machineID
+	}
+	var err error
+	result.AgentStatus, err = modelStatus.UnitAgent(unit.Name())
+	if err != nil {
+		return Unit{}, errors.Trace(err)
+	}
+	result.WorkloadStatus, err = modelStatus.UnitWorkload(unit.Name(), expectWorkload)
+	if err != nil {
+		return Unit{}, errors.Trace(err)
+	}
+	return result, nil
+}
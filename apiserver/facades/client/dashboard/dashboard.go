@@ -0,0 +1,233 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package dashboard provides the API server facade used by the web
+// dashboard to read summaries of many models, and optionally of their
+// applications and units, in a single call.
+package dashboard
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+const (
+	// defaultLimit is the number of models returned when no limit is
+	// given.
+	defaultLimit = 50
+
+	// maxLimit is the largest number of models returned in one call.
+	maxLimit = 200
+)
+
+// API implements the Dashboard facade.
+type API struct {
+	backend Backend
+	apiUser names.UserTag
+}
+
+// NewFacade creates a new Dashboard API facade.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(NewStateBackend(ctx.StatePool()), ctx.Auth())
+}
+
+// NewAPI returns a new Dashboard API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	// Since we know this is a user tag (because AuthClient is true),
+	// we just do the type assertion to the UserTag.
+	apiUser, _ := authorizer.GetAuthTag().(names.UserTag)
+	return &API{
+		backend: backend,
+		apiUser: apiUser,
+	}, nil
+}
+
+// Summaries returns a page of summaries of the models the user can
+// see, optionally with their applications and units. The models are
+// filtered and sorted by owner and name before the page is taken;
+// the application and status filters only affect the details included
+// for the models in the page.
+func (api *API) Summaries(args params.DashboardSummaryArgs) (params.DashboardSummaryResult, error) {
+	result := params.DashboardSummaryResult{Models: []params.DashboardModelSummary{}}
+	withApplications, withUnits, err := parseFields(args.Fields)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if args.Offset < 0 {
+		return result, errors.NotValidf("negative offset")
+	}
+	if args.Limit < 0 {
+		return result, errors.NotValidf("negative limit")
+	}
+	limit := args.Limit
+	if limit == 0 {
+		limit = defaultLimit
+	} else if limit > maxLimit {
+		limit = maxLimit
+	}
+	for _, pattern := range []string{args.ModelFilter, args.ApplicationFilter} {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return result, errors.NewNotValid(err, "invalid filter "+pattern)
+		}
+	}
+
+	summaries, err := api.backend.ModelSummariesForUser(api.apiUser, args.All)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	modelStatuses := set.NewStrings(args.ModelStatus...)
+	var matching []state.ModelSummary
+	for _, summary := range summaries {
+		if !matchModel(summary, args.ModelFilter) {
+			continue
+		}
+		if !modelStatuses.IsEmpty() && !modelStatuses.Contains(string(summary.Status.Status)) {
+			continue
+		}
+		matching = append(matching, summary)
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		if matching[i].Owner != matching[j].Owner {
+			return matching[i].Owner < matching[j].Owner
+		}
+		return matching[i].Name < matching[j].Name
+	})
+	result.Total = len(matching)
+
+	if args.Offset >= len(matching) {
+		return result, nil
+	}
+	matching = matching[args.Offset:]
+	if len(matching) > limit {
+		matching = matching[:limit]
+	}
+
+	statuses := set.NewStrings(args.Status...)
+	for _, summary := range matching {
+		model := modelSummary(summary)
+		if withApplications {
+			apps, err := api.backend.ModelApplications(summary.UUID, withUnits)
+			if err != nil {
+				model.Error = common.ServerError(err)
+			} else {
+				model.Applications = filterApplications(apps, args.ApplicationFilter, statuses)
+			}
+		}
+		result.Models = append(result.Models, model)
+	}
+	return result, nil
+}
+
+func parseFields(fields []string) (withApplications, withUnits bool, err error) {
+	for _, field := range fields {
+		switch field {
+		case params.DashboardApplications:
+			withApplications = true
+		case params.DashboardUnits:
+			withApplications = true
+			withUnits = true
+		default:
+			return false, false, errors.NotValidf("field %q", field)
+		}
+	}
+	return withApplications, withUnits, nil
+}
+
+// matchModel reports whether the model matches the filter, which is
+// matched against the qualified model name if it contains a "/".
+func matchModel(summary state.ModelSummary, filter string) bool {
+	if filter == "" {
+		return true
+	}
+	name := summary.Name
+	if strings.Contains(filter, "/") {
+		name = summary.Owner + "/" + summary.Name
+	}
+	matched, _ := path.Match(filter, name)
+	return matched
+}
+
+func modelSummary(summary state.ModelSummary) params.DashboardModelSummary {
+	model := params.DashboardModelSummary{
+		UUID:         summary.UUID,
+		Name:         summary.Name,
+		OwnerTag:     names.NewUserTag(summary.Owner).String(),
+		Type:         string(summary.Type),
+		CloudTag:     summary.CloudTag,
+		CloudRegion:  summary.CloudRegion,
+		Life:         life.Value(summary.Life.String()),
+		Status:       common.EntityStatusFromState(summary.Status),
+		MachineCount: summary.MachineCount,
+		UnitCount:    summary.UnitCount,
+	}
+	if access, err := common.StateToParamsUserAccessPermission(summary.Access); err == nil {
+		model.UserAccess = access
+	}
+	return model
+}
+
+// filterApplications returns the applications whose names match the
+// filter. If any statuses are given, only applications and units with
+// one of them are included; an application is also included if any of
+// its units are.
+func filterApplications(apps []Application, filter string, statuses set.Strings) []params.DashboardApplicationSummary {
+	matchStatus := func(infos ...status.StatusInfo) bool {
+		if statuses.IsEmpty() {
+			return true
+		}
+		for _, info := range infos {
+			if statuses.Contains(string(info.Status)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	var result []params.DashboardApplicationSummary
+	for _, app := range apps {
+		if filter != "" {
+			if matched, _ := path.Match(filter, app.Name); !matched {
+				continue
+			}
+		}
+		var units []params.DashboardUnitSummary
+		for _, unit := range app.Units {
+			if !matchStatus(unit.WorkloadStatus, unit.AgentStatus) {
+				continue
+			}
+			units = append(units, params.DashboardUnitSummary{
+				Name:           unit.Name,
+				Machine:        unit.Machine,
+				WorkloadStatus: common.EntityStatusFromState(unit.WorkloadStatus),
+				AgentStatus:    common.EntityStatusFromState(unit.AgentStatus),
+			})
+		}
+		if len(units) == 0 && !matchStatus(app.Status) {
+			continue
+		}
+		result = append(result, params.DashboardApplicationSummary{
+			Name:      app.Name,
+			Charm:     app.Charm,
+			Exposed:   app.Exposed,
+			Status:    common.EntityStatusFromState(app.Status),
+			UnitCount: app.UnitCount,
+			Units:     units,
+		})
+	}
+	return result
+}
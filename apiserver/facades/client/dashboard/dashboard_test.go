@@ -0,0 +1,227 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dashboard_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/dashboard"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+type DashboardSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	api        *dashboard.API
+}
+
+var _ = gc.Suite(&DashboardSuite{})
+
+func (s *DashboardSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	model := func(uuid, owner, name string, st status.Status) state.ModelSummary {
+		return state.ModelSummary{
+			UUID:         uuid,
+			Name:         name,
+			Owner:        owner,
+			Type:         state.ModelTypeIAAS,
+			CloudTag:     "cloud-dummy",
+			Life:         state.Alive,
+			Status:       status.StatusInfo{Status: st},
+			Access:       permission.AdminAccess,
+			MachineCount: 1,
+			UnitCount:    2,
+		}
+	}
+	s.backend = &mockBackend{
+		summaries: []state.ModelSummary{
+			model("uuid-3", "fred", "web", status.Available),
+			model("uuid-1", "bob", "web", status.Available),
+			model("uuid-2", "bob", "db", status.Suspended),
+		},
+		applications: map[string][]dashboard.Application{
+			"uuid-1": {{
+				Name:      "haproxy",
+				Charm:     "cs:haproxy-1",
+				Status:    status.StatusInfo{Status: status.Active},
+				UnitCount: 1,
+				Units: []dashboard.Unit{{
+					Name:           "haproxy/0",
+					Machine:        "0",
+					WorkloadStatus: status.StatusInfo{Status: status.Active},
+					AgentStatus:    status.StatusInfo{Status: status.Idle},
+				}},
+			}, {
+				Name:      "wordpress",
+				Charm:     "cs:wordpress-3",
+				Exposed:   true,
+				Status:    status.StatusInfo{Status: status.Error, Message: "hook failed"},
+				UnitCount: 2,
+				Units: []dashboard.Unit{{
+					Name:           "wordpress/0",
+					Machine:        "0",
+					WorkloadStatus: status.StatusInfo{Status: status.Active},
+					AgentStatus:    status.StatusInfo{Status: status.Idle},
+				}, {
+					Name:           "wordpress/1",
+					Machine:        "1",
+					WorkloadStatus: status.StatusInfo{Status: status.Error, Message: "hook failed"},
+					AgentStatus:    status.StatusInfo{Status: status.Idle},
+				}},
+			}},
+		},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("bob"),
+	}
+	api, err := dashboard.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *DashboardSuite) modelNames(result params.DashboardSummaryResult) []string {
+	var names []string
+	for _, model := range result.Models {
+		names = append(names, model.OwnerTag+"/"+model.Name)
+	}
+	return names
+}
+
+func (s *DashboardSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := dashboard.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *DashboardSuite) TestSummaries(c *gc.C) {
+	result, err := s.api.Summaries(params.DashboardSummaryArgs{All: true})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Total, gc.Equals, 3)
+	c.Assert(s.modelNames(result), jc.DeepEquals, []string{
+		"user-bob/db", "user-bob/web", "user-fred/web",
+	})
+	c.Assert(result.Models[1], jc.DeepEquals, params.DashboardModelSummary{
+		UUID:         "uuid-1",
+		Name:         "web",
+		OwnerTag:     "user-bob",
+		Type:         "iaas",
+		CloudTag:     "cloud-dummy",
+		Life:         "alive",
+		Status:       params.EntityStatus{Status: status.Available},
+		UserAccess:   params.ModelAdminAccess,
+		MachineCount: 1,
+		UnitCount:    2,
+	})
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"ModelSummariesForUser", []interface{}{names.NewUserTag("bob"), true}},
+	})
+}
+
+func (s *DashboardSuite) TestSummariesModelFilters(c *gc.C) {
+	result, err := s.api.Summaries(params.DashboardSummaryArgs{ModelFilter: "w*"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Total, gc.Equals, 2)
+	c.Assert(s.modelNames(result), jc.DeepEquals, []string{"user-bob/web", "user-fred/web"})
+
+	result, err = s.api.Summaries(params.DashboardSummaryArgs{ModelFilter: "bob/*"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.modelNames(result), jc.DeepEquals, []string{"user-bob/db", "user-bob/web"})
+
+	result, err = s.api.Summaries(params.DashboardSummaryArgs{ModelStatus: []string{"suspended"}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Total, gc.Equals, 1)
+	c.Assert(s.modelNames(result), jc.DeepEquals, []string{"user-bob/db"})
+}
+
+func (s *DashboardSuite) TestSummariesPaging(c *gc.C) {
+	result, err := s.api.Summaries(params.DashboardSummaryArgs{Offset: 1, Limit: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Total, gc.Equals, 3)
+	c.Assert(s.modelNames(result), jc.DeepEquals, []string{"user-bob/web"})
+
+	result, err = s.api.Summaries(params.DashboardSummaryArgs{Offset: 3})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Total, gc.Equals, 3)
+	c.Assert(result.Models, gc.HasLen, 0)
+}
+
+func (s *DashboardSuite) TestSummariesInvalidArgs(c *gc.C) {
+	_, err := s.api.Summaries(params.DashboardSummaryArgs{Offset: -1})
+	c.Assert(err, gc.ErrorMatches, "negative offset not valid")
+	_, err = s.api.Summaries(params.DashboardSummaryArgs{Limit: -1})
+	c.Assert(err, gc.ErrorMatches, "negative limit not valid")
+	_, err = s.api.Summaries(params.DashboardSummaryArgs{Fields: []string{"machines"}})
+	c.Assert(err, gc.ErrorMatches, `field "machines" not valid`)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *DashboardSuite) TestSummariesApplications(c *gc.C) {
+	result, err := s.api.Summaries(params.DashboardSummaryArgs{
+		ModelFilter: "bob/web",
+		Fields:      []string{"applications"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Models, gc.HasLen, 1)
+	c.Assert(result.Models[0].Applications, jc.DeepEquals, []params.DashboardApplicationSummary{{
+		Name:      "haproxy",
+		Charm:     "cs:haproxy-1",
+		Status:    params.EntityStatus{Status: status.Active},
+		UnitCount: 1,
+	}, {
+		Name:      "wordpress",
+		Charm:     "cs:wordpress-3",
+		Exposed:   true,
+		Status:    params.EntityStatus{Status: status.Error, Info: "hook failed"},
+		UnitCount: 2,
+	}})
+	s.backend.CheckCall(c, 1, "ModelApplications", "uuid-1", false)
+}
+
+func (s *DashboardSuite) TestSummariesUnitsFilteredByStatus(c *gc.C) {
+	result, err := s.api.Summaries(params.DashboardSummaryArgs{
+		ModelFilter:       "bob/web",
+		ApplicationFilter: "word*",
+		Status:            []string{"error"},
+		Fields:            []string{"units"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Models, gc.HasLen, 1)
+	c.Assert(result.Models[0].Applications, jc.DeepEquals, []params.DashboardApplicationSummary{{
+		Name:      "wordpress",
+		Charm:     "cs:wordpress-3",
+		Exposed:   true,
+		Status:    params.EntityStatus{Status: status.Error, Info: "hook failed"},
+		UnitCount: 2,
+		Units: []params.DashboardUnitSummary{{
+			Name:           "wordpress/1",
+			Machine:        "1",
+			WorkloadStatus: params.EntityStatus{Status: status.Error, Info: "hook failed"},
+			AgentStatus:    params.EntityStatus{Status: status.Idle},
+		}},
+	}})
+	s.backend.CheckCall(c, 1, "ModelApplications", "uuid-1", true)
+}
+
+func (s *DashboardSuite) TestSummariesApplicationsError(c *gc.C) {
+	s.backend.SetErrors(nil, errors.New("boom"))
+	result, err := s.api.Summaries(params.DashboardSummaryArgs{
+		ModelFilter: "*/web",
+		Fields:      []string{"applications"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Models, gc.HasLen, 2)
+	c.Assert(result.Models[0].Error, gc.ErrorMatches, "boom")
+	c.Assert(result.Models[1].Error, gc.IsNil)
+}
@@ -0,0 +1,40 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package dashboard_test
+
+import (
+	"github.com/juju/testing"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/facades/client/dashboard"
+	"github.com/juju/juju/state"
+)
+
+type mockBackend struct {
+	testing.Stub
+	summaries    []state.ModelSummary
+	applications map[string][]dashboard.Application
+}
+
+func (b *mockBackend) ModelSummariesForUser(user names.UserTag, all bool) ([]state.ModelSummary, error) {
+	b.MethodCall(b, "ModelSummariesForUser", user, all)
+	return b.summaries, b.NextErr()
+}
+
+func (b *mockBackend) ModelApplications(modelUUID string, withUnits bool) ([]dashboard.Application, error) {
+	b.MethodCall(b, "ModelApplications", modelUUID, withUnits)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	apps := b.applications[modelUUID]
+	if !withUnits {
+		result := make([]dashboard.Application, len(apps))
+		for i, app := range apps {
+			app.Units = nil
+			result[i] = app
+		}
+		return result, nil
+	}
+	return apps, nil
+}
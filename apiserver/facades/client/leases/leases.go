@@ -0,0 +1,369 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package leases provides the API server facade for inspecting the
+// leadership and singular leases of a model, for forcibly revoking
+// a lease whose holder is wedged, for pinning the leaders of
+// applications during maintenance, and for compacting the lease store.
+package leases
+
+import (
+	"sort"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/pubsub/controller"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.leases")
+
+// namespaces holds the lease namespaces that are exposed by the facade.
+var namespaces = []string{
+	lease.ApplicationLeadershipNamespace,
+	lease.SingularControllerNamespace,
+}
+
+// LeaseManager describes the access to the lease manager
+// needed by the facade.
+type LeaseManager interface {
+	LeaseInspector(namespace, modelUUID string) (lease.Inspector, error)
+	LeaseRevoker(namespace, modelUUID string) (lease.Revoker, error)
+	LeadershipPinner(modelUUID string) (leadership.Pinner, error)
+}
+
+// Backend exposes the state functionality required by the facade to
+// record the pinning of application leaders.
+type Backend interface {
+	Application(name string) (Application, error)
+}
+
+// Application exposes the application functionality required by the
+// facade.
+type Application interface {
+	LeadershipPin() (state.LeadershipPin, bool)
+	SetLeadershipPin(state.LeadershipPin) error
+	ClearLeadershipPin() error
+}
+
+// API implements version 3 of the Leases facade.
+type API struct {
+	authorizer    facade.Authorizer
+	leases        LeaseManager
+	backend       Backend
+	hub           facade.Hub
+	clock         clock.Clock
+	modelTag      names.ModelTag
+	controllerTag names.ControllerTag
+}
+
+// APIV2 implements version 2 of the Leases facade, which doesn't have
+// PinLeaders and UnpinLeaders.
+type APIV2 struct {
+	*API
+}
+
+// APIV1 implements version 1 of the Leases facade, which doesn't
+// have SnapshotLeaseStore.
+type APIV1 struct {
+	*APIV2
+}
+
+// NewFacadeV3 creates a new Leases API facade.
+func NewFacadeV3(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	return NewAPI(
+		ctx.Auth(),
+		ctx,
+		backendShim{st},
+		ctx.Hub(),
+		clock.WallClock,
+		names.NewModelTag(st.ModelUUID()),
+		st.ControllerTag(),
+	)
+}
+
+// NewFacadeV2 creates a new version 2 Leases API facade.
+func NewFacadeV2(ctx facade.Context) (*APIV2, error) {
+	api, err := NewFacadeV3(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV2{api}, nil
+}
+
+// NewFacadeV1 creates a new version 1 Leases API facade.
+func NewFacadeV1(ctx facade.Context) (*APIV1, error) {
+	api, err := NewFacadeV2(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIV1{api}, nil
+}
+
+// NewAPI returns a new Leases API facade for the given model.
+func NewAPI(
+	authorizer facade.Authorizer,
+	leases LeaseManager,
+	backend Backend,
+	hub facade.Hub,
+	clock clock.Clock,
+	modelTag names.ModelTag,
+	controllerTag names.ControllerTag,
+) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		authorizer:    authorizer,
+		leases:        leases,
+		backend:       backend,
+		hub:           hub,
+		clock:         clock,
+		modelTag:      modelTag,
+		controllerTag: controllerTag,
+	}, nil
+}
+
+func (api *API) checkIsSuperuser() error {
+	isAdmin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.controllerTag)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if !isAdmin {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+func (api *API) checkIsModelAdmin() error {
+	if err := api.checkIsSuperuser(); err == nil {
+		return nil
+	}
+	isModelAdmin, err := api.authorizer.HasPermission(permission.AdminAccess, api.modelTag)
+	if err != nil && !errors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if !isModelAdmin {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// ShowLeases returns the holder and expiry of every leadership
+// and singular lease in the model.
+func (api *API) ShowLeases() (params.LeaseDetailsResult, error) {
+	result := params.LeaseDetailsResult{}
+	if err := api.checkIsModelAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, namespace := range namespaces {
+		inspector, err := api.leases.LeaseInspector(namespace, api.modelTag.Id())
+		if err != nil {
+			return result, errors.Trace(err)
+		}
+		details := inspector.Details()
+		leaseNames := make([]string, 0, len(details))
+		for name := range details {
+			leaseNames = append(leaseNames, name)
+		}
+		sort.Strings(leaseNames)
+		for _, name := range leaseNames {
+			info := details[name]
+			result.Leases = append(result.Leases, params.LeaseDetails{
+				Namespace: namespace,
+				Lease:     name,
+				Holder:    info.Holder,
+				Expiry:    info.Expiry,
+				PinnedBy:  info.PinnedBy,
+			})
+		}
+	}
+	return result, nil
+}
+
+// RevokeLeases forcibly vacates the supplied leases, regardless of their
+// holders and expiry times. It is intended for recovering from a holder
+// that is wedged and will not give up its lease.
+func (api *API) RevokeLeases(args params.RevokeLeaseArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	if err := api.checkIsSuperuser(); err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		err := api.revokeLease(arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// SnapshotLeaseStore isn't on the v1 API.
+func (*APIV1) SnapshotLeaseStore(_, _ struct{}) {}
+
+// SnapshotLeaseStore asks the raft worker on every controller machine
+// to snapshot the lease store, after which raft compacts its log. The
+// snapshots are taken asynchronously; their progress can be followed
+// in the controller logs and the juju_raft metrics.
+func (api *API) SnapshotLeaseStore() error {
+	if err := api.checkIsSuperuser(); err != nil {
+		return errors.Trace(err)
+	}
+	_, err := api.hub.Publish(controller.RaftSnapshotRequested, controller.RaftSnapshotRequestedMessage{
+		Requester: api.authorizer.GetAuthTag().String(),
+	})
+	return errors.Trace(err)
+}
+
+// PinLeaders isn't on the v2 API.
+func (*APIV2) PinLeaders(_, _ struct{}) {}
+
+// UnpinLeaders isn't on the v2 API.
+func (*APIV2) UnpinLeaders(_, _ struct{}) {}
+
+// PinLeaders pins the leaders of the supplied applications for the
+// durations given, so that leadership doesn't change while an operator
+// carries out maintenance. The pins, along with the reasons for them,
+// are recorded in the status data of the applications, and are removed
+// when they expire. Pinning an application's leader again replaces the
+// earlier pin.
+func (api *API) PinLeaders(args params.PinLeaderArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	if err := api.checkIsModelAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	pinner, err := api.leases.LeadershipPinner(api.modelTag.Id())
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		err := api.pinLeader(pinner, arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *API) pinLeader(pinner leadership.Pinner, arg params.PinLeaderArg) error {
+	appTag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if arg.Duration <= 0 || arg.Duration > state.MaxLeadershipPinDuration {
+		return errors.NotValidf("pin duration %v", arg.Duration)
+	}
+	app, err := api.backend.Application(appTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	entity := api.authorizer.GetAuthTag().String()
+	if err := pinner.PinLeadership(appTag.Id(), entity); err != nil {
+		return errors.Trace(err)
+	}
+	if old, ok := app.LeadershipPin(); ok && old.By != entity {
+		if err := pinner.UnpinLeadership(appTag.Id(), old.By); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	pin := state.LeadershipPin{
+		By:     entity,
+		Reason: arg.Reason,
+		Until:  api.clock.Now().Add(arg.Duration),
+	}
+	if err := app.SetLeadershipPin(pin); err != nil {
+		// Without the record the pin would never expire.
+		if unpinErr := pinner.UnpinLeadership(appTag.Id(), entity); unpinErr != nil {
+			logger.Errorf("cannot unpin leader of %q: %v", appTag.Id(), unpinErr)
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// UnpinLeaders removes the pins placed by PinLeaders on the leaders of
+// the supplied applications before they expire.
+func (api *API) UnpinLeaders(args params.Entities) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	if err := api.checkIsModelAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	pinner, err := api.leases.LeadershipPinner(api.modelTag.Id())
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		err := api.unpinLeader(pinner, entity.Tag)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *API) unpinLeader(pinner leadership.Pinner, tag string) error {
+	appTag, err := names.ParseApplicationTag(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(appTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	pin, ok := app.LeadershipPin()
+	if !ok {
+		return errors.NotFoundf("leadership pin for application %q", appTag.Id())
+	}
+	if err := pinner.UnpinLeadership(appTag.Id(), pin.By); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(app.ClearLeadershipPin())
+}
+
+func (api *API) revokeLease(arg params.RevokeLeaseArg) error {
+	if !isKnownNamespace(arg.Namespace) {
+		return errors.NotValidf("lease namespace %q", arg.Namespace)
+	}
+	revoker, err := api.leases.LeaseRevoker(arg.Namespace, api.modelTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = revoker.Revoke(arg.Lease)
+	if errors.Cause(err) == lease.ErrNotHeld {
+		return errors.NotFoundf("lease %q in namespace %q", arg.Lease, arg.Namespace)
+	}
+	return errors.Trace(err)
+}
+
+func isKnownNamespace(namespace string) bool {
+	for _, known := range namespaces {
+		if namespace == known {
+			return true
+		}
+	}
+	return false
+}
+
+// backendShim wraps state so that it implements Backend.
+type backendShim struct {
+	st *state.State
+}
+
+// Application is part of the Backend interface.
+func (shim backendShim) Application(name string) (Application, error) {
+	app, err := shim.st.Application(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return app, nil
+}
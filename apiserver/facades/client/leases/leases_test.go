@@ -0,0 +1,377 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leases_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/leases"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/pubsub/controller"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type leasesSuite struct {
+	jtesting.IsolationSuite
+
+	manager  *fakeLeaseManager
+	backend  *fakeBackend
+	hub      *fakeHub
+	clock    *testclock.Clock
+	modelTag names.ModelTag
+}
+
+var _ = gc.Suite(&leasesSuite{})
+
+func (s *leasesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.modelTag = coretesting.ModelTag
+	s.hub = &fakeHub{}
+	expiry := time.Date(2020, 4, 1, 10, 0, 0, 0, time.UTC)
+	s.clock = testclock.NewClock(expiry)
+	s.backend = &fakeBackend{applications: map[string]*fakeApplication{
+		"mysql": {},
+		"redis": {},
+	}}
+	s.manager = &fakeLeaseManager{
+		pinned: make(map[string][]string),
+		details: map[string]map[string]lease.Details{
+			lease.ApplicationLeadershipNamespace: {
+				"mysql": {Holder: "mysql/1", Expiry: expiry},
+				"redis": {Holder: "redis/0", Expiry: expiry, PinnedBy: []string{"machine-0"}},
+			},
+			lease.SingularControllerNamespace: {
+				s.modelTag.Id(): {Holder: "machine-0", Expiry: expiry},
+			},
+		},
+	}
+}
+
+func (s *leasesSuite) newAPI(c *gc.C, user string) *leases.API {
+	api, err := leases.NewAPI(
+		apiservertesting.FakeAuthorizer{Tag: names.NewUserTag(user)},
+		s.manager,
+		s.backend,
+		s.hub,
+		s.clock,
+		s.modelTag,
+		coretesting.ControllerTag,
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *leasesSuite) TestNewAPIRequiresClient(c *gc.C) {
+	_, err := leases.NewAPI(
+		apiservertesting.FakeAuthorizer{Tag: names.NewMachineTag("0")},
+		s.manager,
+		s.backend,
+		s.hub,
+		s.clock,
+		s.modelTag,
+		coretesting.ControllerTag,
+	)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *leasesSuite) TestShowLeases(c *gc.C) {
+	result, err := s.newAPI(c, "superuser-bob").ShowLeases()
+	c.Assert(err, jc.ErrorIsNil)
+	expiry := time.Date(2020, 4, 1, 10, 0, 0, 0, time.UTC)
+	c.Assert(result, jc.DeepEquals, params.LeaseDetailsResult{
+		Leases: []params.LeaseDetails{{
+			Namespace: lease.ApplicationLeadershipNamespace,
+			Lease:     "mysql",
+			Holder:    "mysql/1",
+			Expiry:    expiry,
+		}, {
+			Namespace: lease.ApplicationLeadershipNamespace,
+			Lease:     "redis",
+			Holder:    "redis/0",
+			Expiry:    expiry,
+			PinnedBy:  []string{"machine-0"},
+		}, {
+			Namespace: lease.SingularControllerNamespace,
+			Lease:     s.modelTag.Id(),
+			Holder:    "machine-0",
+			Expiry:    expiry,
+		}},
+	})
+}
+
+func (s *leasesSuite) TestShowLeasesModelAdmin(c *gc.C) {
+	result, err := s.newAPI(c, "admin-"+s.modelTag.String()).ShowLeases()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Leases, gc.HasLen, 3)
+}
+
+func (s *leasesSuite) TestShowLeasesPermissionDenied(c *gc.C) {
+	_, err := s.newAPI(c, "bob").ShowLeases()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *leasesSuite) TestShowLeasesNotSupported(c *gc.C) {
+	s.manager.err = errors.NotSupportedf("inspecting leases with the legacy lease manager")
+	_, err := s.newAPI(c, "superuser-bob").ShowLeases()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *leasesSuite) TestRevokeLeases(c *gc.C) {
+	result, err := s.newAPI(c, "superuser-bob").RevokeLeases(params.RevokeLeaseArgs{
+		Args: []params.RevokeLeaseArg{
+			{Namespace: lease.ApplicationLeadershipNamespace, Lease: "redis"},
+			{Namespace: lease.ApplicationLeadershipNamespace, Lease: "postgresql"},
+			{Namespace: "bogus", Lease: "redis"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `lease "postgresql" in namespace "application-leadership" not found`)
+	c.Check(result.Results[2].Error, gc.ErrorMatches, `lease namespace "bogus" not valid`)
+	c.Check(s.manager.revoked, jc.DeepEquals, []string{"application-leadership/redis"})
+}
+
+func (s *leasesSuite) TestRevokeLeasesModelAdminDenied(c *gc.C) {
+	_, err := s.newAPI(c, "admin-"+s.modelTag.String()).RevokeLeases(params.RevokeLeaseArgs{
+		Args: []params.RevokeLeaseArg{
+			{Namespace: lease.ApplicationLeadershipNamespace, Lease: "redis"},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Check(s.manager.revoked, gc.HasLen, 0)
+}
+
+func (s *leasesSuite) TestSnapshotLeaseStore(c *gc.C) {
+	err := s.newAPI(c, "superuser-bob").SnapshotLeaseStore()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.hub.topics, jc.DeepEquals, []string{controller.RaftSnapshotRequested})
+	c.Assert(s.hub.data, jc.DeepEquals, []interface{}{
+		controller.RaftSnapshotRequestedMessage{Requester: "user-superuser-bob"},
+	})
+}
+
+func (s *leasesSuite) TestSnapshotLeaseStoreModelAdminDenied(c *gc.C) {
+	err := s.newAPI(c, "admin-"+s.modelTag.String()).SnapshotLeaseStore()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Check(s.hub.topics, gc.HasLen, 0)
+}
+
+func (s *leasesSuite) TestSnapshotLeaseStorePublishError(c *gc.C) {
+	s.hub.err = errors.New("hub closed")
+	err := s.newAPI(c, "superuser-bob").SnapshotLeaseStore()
+	c.Assert(err, gc.ErrorMatches, "hub closed")
+}
+
+func (s *leasesSuite) TestPinLeaders(c *gc.C) {
+	result, err := s.newAPI(c, "admin-"+s.modelTag.String()).PinLeaders(params.PinLeaderArgs{
+		Args: []params.PinLeaderArg{
+			{ApplicationTag: "application-mysql", Duration: time.Hour, Reason: "database migration"},
+			{ApplicationTag: "application-postgresql", Duration: time.Hour},
+			{ApplicationTag: "application-redis", Duration: 48 * time.Hour},
+			{ApplicationTag: "unit-redis-0", Duration: time.Hour},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 4)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `application "postgresql" not found`)
+	c.Check(result.Results[2].Error, gc.ErrorMatches, `pin duration 48h0m0s not valid`)
+	c.Check(result.Results[3].Error, gc.ErrorMatches, `"unit-redis-0" is not a valid application tag`)
+
+	user := "user-admin-" + s.modelTag.String()
+	c.Check(s.manager.pinned, jc.DeepEquals, map[string][]string{"mysql": {user}})
+	c.Check(s.backend.applications["mysql"].pin, jc.DeepEquals, &state.LeadershipPin{
+		By:     user,
+		Reason: "database migration",
+		Until:  s.clock.Now().Add(time.Hour),
+	})
+	c.Check(s.backend.applications["redis"].pin, gc.IsNil)
+}
+
+func (s *leasesSuite) TestPinLeadersReplacesPin(c *gc.C) {
+	s.manager.pinned["mysql"] = []string{"user-bob"}
+	s.backend.applications["mysql"].pin = &state.LeadershipPin{By: "user-bob", Until: s.clock.Now()}
+
+	result, err := s.newAPI(c, "superuser-alice").PinLeaders(params.PinLeaderArgs{
+		Args: []params.PinLeaderArg{{ApplicationTag: "application-mysql", Duration: time.Hour}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), jc.ErrorIsNil)
+	c.Check(s.manager.pinned, jc.DeepEquals, map[string][]string{"mysql": {"user-superuser-alice"}})
+	c.Check(s.backend.applications["mysql"].pin.By, gc.Equals, "user-superuser-alice")
+}
+
+func (s *leasesSuite) TestPinLeadersUnpinsIfNotRecorded(c *gc.C) {
+	s.backend.applications["mysql"].err = errors.New("application is no longer alive")
+
+	result, err := s.newAPI(c, "superuser-alice").PinLeaders(params.PinLeaderArgs{
+		Args: []params.PinLeaderArg{{ApplicationTag: "application-mysql", Duration: time.Hour}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, "application is no longer alive")
+	c.Check(s.manager.pinned, gc.HasLen, 0)
+}
+
+func (s *leasesSuite) TestPinLeadersPermissionDenied(c *gc.C) {
+	_, err := s.newAPI(c, "bob").PinLeaders(params.PinLeaderArgs{
+		Args: []params.PinLeaderArg{{ApplicationTag: "application-mysql", Duration: time.Hour}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	c.Check(s.manager.pinned, gc.HasLen, 0)
+}
+
+func (s *leasesSuite) TestUnpinLeaders(c *gc.C) {
+	s.manager.pinned["mysql"] = []string{"user-bob"}
+	s.backend.applications["mysql"].pin = &state.LeadershipPin{By: "user-bob", Until: s.clock.Now()}
+
+	result, err := s.newAPI(c, "superuser-alice").UnpinLeaders(params.Entities{
+		Entities: []params.Entity{{Tag: "application-mysql"}, {Tag: "application-redis"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, gc.ErrorMatches, `leadership pin for application "redis" not found`)
+	c.Check(s.manager.pinned, gc.HasLen, 0)
+	c.Check(s.backend.applications["mysql"].pin, gc.IsNil)
+}
+
+type fakeHub struct {
+	topics []string
+	data   []interface{}
+	err    error
+}
+
+func (h *fakeHub) Publish(topic string, data interface{}) (<-chan struct{}, error) {
+	if h.err != nil {
+		return nil, h.err
+	}
+	h.topics = append(h.topics, topic)
+	h.data = append(h.data, data)
+	return nil, nil
+}
+
+type fakeLeaseManager struct {
+	details map[string]map[string]lease.Details
+	revoked []string
+	pinned  map[string][]string
+	err     error
+}
+
+func (m *fakeLeaseManager) LeadershipPinner(modelUUID string) (leadership.Pinner, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return fakePinner{m.pinned}, nil
+}
+
+func (m *fakeLeaseManager) LeaseInspector(namespace, modelUUID string) (lease.Inspector, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return fakeInspector(m.details[namespace]), nil
+}
+
+func (m *fakeLeaseManager) LeaseRevoker(namespace, modelUUID string) (lease.Revoker, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &fakeRevoker{manager: m, namespace: namespace}, nil
+}
+
+type fakeInspector map[string]lease.Details
+
+func (i fakeInspector) Details() map[string]lease.Details {
+	return i
+}
+
+type fakeRevoker struct {
+	manager   *fakeLeaseManager
+	namespace string
+}
+
+func (r *fakeRevoker) Revoke(leaseName string) error {
+	if _, ok := r.manager.details[r.namespace][leaseName]; !ok {
+		return lease.ErrNotHeld
+	}
+	r.manager.revoked = append(r.manager.revoked, r.namespace+"/"+leaseName)
+	return nil
+}
+
+type fakePinner struct {
+	pinned map[string][]string
+}
+
+func (p fakePinner) PinLeadership(applicationId, entity string) error {
+	p.pinned[applicationId] = append(p.pinned[applicationId], entity)
+	return nil
+}
+
+func (p fakePinner) UnpinLeadership(applicationId, entity string) error {
+	var entities []string
+	for _, e := range p.pinned[applicationId] {
+		if e != entity {
+			entities = append(entities, e)
+		}
+	}
+	if len(entities) == 0 {
+		delete(p.pinned, applicationId)
+	} else {
+		p.pinned[applicationId] = entities
+	}
+	return nil
+}
+
+func (p fakePinner) PinnedLeadership() map[string][]string {
+	return p.pinned
+}
+
+type fakeBackend struct {
+	applications map[string]*fakeApplication
+}
+
+func (b *fakeBackend) Application(name string) (leases.Application, error) {
+	app, ok := b.applications[name]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", name)
+	}
+	return app, nil
+}
+
+type fakeApplication struct {
+	pin *state.LeadershipPin
+	err error
+}
+
+func (a *fakeApplication) LeadershipPin() (state.LeadershipPin, bool) {
+	if a.pin == nil {
+		return state.LeadershipPin{}, false
+	}
+	return *a.pin, true
+}
+
+func (a *fakeApplication) SetLeadershipPin(pin state.LeadershipPin) error {
+	if a.err != nil {
+		return a.err
+	}
+	a.pin = &pin
+	return nil
+}
+
+func (a *fakeApplication) ClearLeadershipPin() error {
+	a.pin = nil
+	return nil
+}
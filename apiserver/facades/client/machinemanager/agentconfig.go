@@ -0,0 +1,74 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+)
+
+// SetAgentConfigOverrides overrides agent config values, such as the
+// logging config, API addresses and proxy settings, of the given
+// machines' agents, which apply them without restarting. Values not
+// given are left as they are; an empty value removes the override. Only
+// model admins may override agent config, since it controls where the
+// agents connect to.
+func (mm *MachineManagerAPI) SetAgentConfigOverrides(args params.SetAgentConfigOverridesArgs) (params.ErrorResults, error) {
+	if err := mm.checkAccess(permission.AdminAccess); err != nil {
+		return params.ErrorResults{}, err
+	}
+	if err := mm.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		machine, err := mm.machineFromTag(arg.Tag)
+		if err == nil {
+			err = machine.SetAgentConfigOverrides(arg.Values)
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// AgentConfigOverrides returns the agent config values overridden for
+// each of the given machines' agents, along with the version of them
+// each agent has applied.
+func (mm *MachineManagerAPI) AgentConfigOverrides(args params.Entities) (params.AgentConfigOverridesResults, error) {
+	if err := mm.checkCanRead(); err != nil {
+		return params.AgentConfigOverridesResults{}, err
+	}
+	results := params.AgentConfigOverridesResults{
+		Results: make([]params.AgentConfigOverridesResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		machine, err := mm.machineFromTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		overrides, err := machine.AgentConfigOverrides()
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i] = params.AgentConfigOverridesResult{
+			Values:         overrides.Values,
+			Version:        overrides.Version,
+			AppliedVersion: overrides.AppliedVersion,
+		}
+	}
+	return results, nil
+}
+
+// SetAgentConfigOverrides isn't on the V8 API.
+func (*MachineManagerAPIV8) SetAgentConfigOverrides(_, _ struct{}) {}
+
+// AgentConfigOverrides isn't on the V8 API.
+func (*MachineManagerAPIV8) AgentConfigOverrides(_, _ struct{}) {}
@@ -0,0 +1,62 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+func (s *MachineManagerSuite) TestSetAgentConfigOverrides(c *gc.C) {
+	s.st.machines["0"] = &mockMachine{}
+	s.st.machines["1"] = &mockMachine{}
+	s.st.machines["1"].SetErrors(errors.NotValidf(`agent config override "data-dir"`))
+
+	values := map[string]string{"logging-config": "<root>=DEBUG"}
+	results, err := s.api.SetAgentConfigOverrides(params.SetAgentConfigOverridesArgs{
+		Args: []params.SetAgentConfigOverridesArg{
+			{Tag: "machine-0", Values: values},
+			{Tag: "machine-1", Values: map[string]string{"data-dir": "/tmp"}},
+			{Tag: "machine-2", Values: values},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, `agent config override "data-dir" not valid`)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, "machine 2 not found")
+	s.st.machines["0"].CheckCall(c, 0, "SetAgentConfigOverrides", values)
+}
+
+func (s *MachineManagerSuite) TestSetAgentConfigOverridesPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.SetAgentConfigOverrides(params.SetAgentConfigOverridesArgs{
+		Args: []params.SetAgentConfigOverridesArg{{Tag: "machine-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *MachineManagerSuite) TestAgentConfigOverrides(c *gc.C) {
+	s.st.machines["0"] = &mockMachine{overrides: state.AgentConfigOverrides{
+		Values:         map[string]string{"no-proxy": "localhost"},
+		Version:        2,
+		AppliedVersion: 1,
+	}}
+	results, err := s.api.AgentConfigOverrides(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}, {Tag: "machine-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0], jc.DeepEquals, params.AgentConfigOverridesResult{
+		Values:         map[string]string{"no-proxy": "localhost"},
+		Version:        2,
+		AppliedVersion: 1,
+	})
+	c.Check(results.Results[1].Error, gc.ErrorMatches, "machine 1 not found")
+}
@@ -0,0 +1,83 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cloudconfig/cloudinit"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/permission"
+)
+
+// MachineConsoleLog returns the console output of the instance of each
+// of the given machines, along with the status of cloud-init on the
+// instance as reported in that output. This allows machines which never
+// start their agents to be diagnosed without access to the cloud's
+// console. Only model admins may retrieve console output, since it may
+// include sensitive details of the machine.
+func (mm *MachineManagerAPI) MachineConsoleLog(args params.Entities) (params.MachineConsoleLogResults, error) {
+	return machineConsoleLog(mm, environs.GetEnviron, args)
+}
+
+// MachineConsoleLog isn't on the V7 API.
+func (*MachineManagerAPIV7) MachineConsoleLog(_, _ struct{}) {}
+
+func machineConsoleLog(
+	mm *MachineManagerAPI,
+	getEnviron environGetFunc,
+	args params.Entities,
+) (params.MachineConsoleLogResults, error) {
+	if err := mm.checkAccess(permission.AdminAccess); err != nil {
+		return params.MachineConsoleLogResults{}, err
+	}
+	results := params.MachineConsoleLogResults{
+		Results: make([]params.MachineConsoleLogResult, len(args.Entities)),
+	}
+	if len(args.Entities) == 0 {
+		return results, nil
+	}
+	env, err := mm.environ(getEnviron)
+	if err != nil {
+		return params.MachineConsoleLogResults{}, errors.Trace(err)
+	}
+	getter, ok := env.(environs.InstanceConsoleOutputGetter)
+	if !ok {
+		return params.MachineConsoleLogResults{}, errors.NotSupportedf("retrieving instance console output on this cloud")
+	}
+	for i, entity := range args.Entities {
+		result, err := mm.machineConsoleLog(getter, entity.Tag)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+func (mm *MachineManagerAPI) machineConsoleLog(
+	getter environs.InstanceConsoleOutputGetter,
+	tag string,
+) (params.MachineConsoleLogResult, error) {
+	machine, err := mm.machineFromTag(tag)
+	if err != nil {
+		return params.MachineConsoleLogResult{}, errors.Trace(err)
+	}
+	instId, err := machine.InstanceId()
+	if err != nil {
+		return params.MachineConsoleLogResult{}, errors.Trace(err)
+	}
+	result := params.MachineConsoleLogResult{InstanceId: string(instId)}
+	output, err := getter.InstanceConsoleOutput(mm.callContext, instId)
+	if err != nil {
+		return result, errors.Annotatef(err, "getting console output of instance %q", instId)
+	}
+	status, detail := cloudinit.StatusFromConsoleOutput(output)
+	result.Output = output
+	result.CloudInitStatus = string(status)
+	result.CloudInitDetail = detail
+	return result, nil
+}
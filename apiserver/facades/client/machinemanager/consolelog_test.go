@@ -0,0 +1,98 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/facades/client/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+const consoleOutput = `
+[    5.012345] cloud-init[512]: Cloud-init v. 19.4-33 running 'init-local' at Mon, 02 Mar 2020 10:00:00 +0000. Up 5.01 seconds.
+[   95.123456] cloud-init[980]: Cloud-init v. 19.4-33 finished at Mon, 02 Mar 2020 10:01:30 +0000. Datasource DataSourceEc2Local.  Up 95.12 seconds
+`
+
+func fakeEnvironGetter(env environs.Environ) func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+	return func(environs.EnvironConfigGetter, environs.NewEnvironFunc) (environs.Environ, error) {
+		return env, nil
+	}
+}
+
+func (s *MachineManagerSuite) TestMachineConsoleLog(c *gc.C) {
+	s.st.machines["0"] = &mockMachine{instanceId: "i-0"}
+	s.st.machines["1"] = &mockMachine{}
+	env := &consoleEnviron{outputs: map[instance.Id]string{"i-0": consoleOutput}}
+
+	results, err := machinemanager.MachineConsoleLog(s.api, fakeEnvironGetter(env), params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+			{Tag: "machine-2"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+
+	result := results.Results[0]
+	c.Check(result.Error, gc.IsNil)
+	c.Check(result.InstanceId, gc.Equals, "i-0")
+	c.Check(result.Output, gc.Equals, consoleOutput)
+	c.Check(result.CloudInitStatus, gc.Equals, "done")
+	c.Check(result.CloudInitDetail, gc.Matches, `.*Cloud-init v. 19.4-33 finished at .*`)
+
+	c.Check(results.Results[1].Error, gc.ErrorMatches, "machine not provisioned")
+	c.Check(results.Results[2].Error, gc.ErrorMatches, "machine 2 not found")
+}
+
+func (s *MachineManagerSuite) TestMachineConsoleLogInstanceError(c *gc.C) {
+	s.st.machines["0"] = &mockMachine{instanceId: "i-0"}
+	env := &consoleEnviron{}
+
+	results, err := machinemanager.MachineConsoleLog(s.api, fakeEnvironGetter(env), params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Check(results.Results[0].InstanceId, gc.Equals, "i-0")
+	c.Check(results.Results[0].Error, gc.ErrorMatches, `getting console output of instance "i-0": instance "i-0" not found`)
+}
+
+func (s *MachineManagerSuite) TestMachineConsoleLogNotSupported(c *gc.C) {
+	s.st.machines["0"] = &mockMachine{instanceId: "i-0"}
+
+	_, err := machinemanager.MachineConsoleLog(s.api, fakeEnvironGetter(&mockEnviron{}), params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *MachineManagerSuite) TestMachineConsoleLogPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+
+	_, err := machinemanager.MachineConsoleLog(s.api, fakeEnvironGetter(&consoleEnviron{}), params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type consoleEnviron struct {
+	environs.Environ
+	outputs map[instance.Id]string
+}
+
+func (e *consoleEnviron) InstanceConsoleOutput(ctx context.ProviderCallContext, id instance.Id) (string, error) {
+	output, ok := e.outputs[id]
+	if !ok {
+		return "", errors.NotFoundf("instance %q", id)
+	}
+	return output, nil
+}
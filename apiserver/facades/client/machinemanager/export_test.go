@@ -4,4 +4,5 @@
 package machinemanager
 
 var InstanceTypes = instanceTypes
+var MachineConsoleLog = machineConsoleLog
 var IsSeriesLessThan = isSeriesLessThan
@@ -21,13 +21,11 @@ func (mm *MachineManagerAPI) InstanceTypes(cons params.ModelInstanceTypesConstra
 
 type environGetFunc func(st environs.EnvironConfigGetter, newEnviron environs.NewEnvironFunc) (environs.Environ, error)
 
-func instanceTypes(mm *MachineManagerAPI,
-	getEnviron environGetFunc,
-	cons params.ModelInstanceTypesConstraints,
-) (params.InstanceTypesResults, error) {
+// environ returns the Environ of the current model.
+func (mm *MachineManagerAPI) environ(getEnviron environGetFunc) (environs.Environ, error) {
 	model, err := mm.st.Model()
 	if err != nil {
-		return params.InstanceTypesResults{}, errors.Trace(err)
+		return nil, errors.Trace(err)
 	}
 
 	cloudSpec := func() (environs.CloudSpec, error) {
@@ -40,8 +38,15 @@ func instanceTypes(mm *MachineManagerAPI,
 		CloudSpecFunc:   cloudSpec,
 		ModelConfigFunc: model.Config,
 	}
-
 	env, err := getEnviron(backend, environs.New)
+	return env, errors.Trace(err)
+}
+
+func instanceTypes(mm *MachineManagerAPI,
+	getEnviron environGetFunc,
+	cons params.ModelInstanceTypesConstraints,
+) (params.InstanceTypesResults, error) {
+	env, err := mm.environ(getEnviron)
 	if err != nil {
 		return params.InstanceTypesResults{}, errors.Trace(err)
 	}
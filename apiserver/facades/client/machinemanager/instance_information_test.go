@@ -144,6 +144,7 @@ func (m *mockEnviron) InstanceTypes(ctx context.ProviderCallContext, c constrain
 
 type mockModel struct {
 	machinemanager.Model
+	cfg *config.Config
 }
 
 func (mockModel) CloudCredential() (names.CloudCredentialTag, bool) {
@@ -154,7 +155,10 @@ func (mockModel) ModelTag() names.ModelTag {
 	return names.NewModelTag("beef1beef1-0000-0000-000011112222")
 }
 
-func (*mockModel) Config() (*config.Config, error) {
+func (m *mockModel) Config() (*config.Config, error) {
+	if m.cfg != nil {
+		return m.cfg, nil
+	}
 	return config.New(config.UseDefaults, dummy.SampleConfig())
 }
 
@@ -17,7 +17,9 @@ import (
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
@@ -71,6 +73,30 @@ type MachineManagerAPIV5 struct {
 // Version 6 of Machine Manager API.
 // Changes input parameters to DestroyMachineWithParams and ForceDestroyMachine.
 type MachineManagerAPIV6 struct {
+	*MachineManagerAPIV7
+}
+
+// Version 7 of Machine Manager API.
+// Adds ProxySettings.
+type MachineManagerAPIV7 struct {
+	*MachineManagerAPIV8
+}
+
+// Version 8 of Machine Manager API.
+// Adds MachineConsoleLog.
+type MachineManagerAPIV8 struct {
+	*MachineManagerAPIV9
+}
+
+// Version 9 of Machine Manager API.
+// Adds SetAgentConfigOverrides and AgentConfigOverrides.
+type MachineManagerAPIV9 struct {
+	*MachineManagerAPIV10
+}
+
+// Version 10 of Machine Manager API.
+// Adds SyncMachineAddresses.
+type MachineManagerAPIV10 struct {
 	*MachineManagerAPI
 }
 
@@ -94,11 +120,47 @@ func NewFacadeV5(ctx facade.Context) (*MachineManagerAPIV5, error) {
 
 // NewFacadeV6 creates a new server-side MachineManager API facade.
 func NewFacadeV6(ctx facade.Context) (*MachineManagerAPIV6, error) {
+	machineManagerAPIv7, err := NewFacadeV7(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV6{machineManagerAPIv7}, nil
+}
+
+// NewFacadeV7 creates a new server-side MachineManager API facade.
+func NewFacadeV7(ctx facade.Context) (*MachineManagerAPIV7, error) {
+	machineManagerAPIv8, err := NewFacadeV8(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV7{machineManagerAPIv8}, nil
+}
+
+// NewFacadeV8 creates a new server-side MachineManager API facade.
+func NewFacadeV8(ctx facade.Context) (*MachineManagerAPIV8, error) {
+	machineManagerAPIv9, err := NewFacadeV9(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV8{machineManagerAPIv9}, nil
+}
+
+// NewFacadeV9 creates a new server-side MachineManager API facade.
+func NewFacadeV9(ctx facade.Context) (*MachineManagerAPIV9, error) {
+	machineManagerAPIv10, err := NewFacadeV10(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &MachineManagerAPIV9{machineManagerAPIv10}, nil
+}
+
+// NewFacadeV10 creates a new server-side MachineManager API facade.
+func NewFacadeV10(ctx facade.Context) (*MachineManagerAPIV10, error) {
 	machineManagerAPI, err := NewFacade(ctx)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &MachineManagerAPIV6{machineManagerAPI}, nil
+	return &MachineManagerAPIV10{machineManagerAPI}, nil
 }
 
 // NewMachineManagerAPI creates a new server-side MachineManager API facade.
@@ -193,6 +255,15 @@ func (mm *MachineManagerAPI) addOneMachine(p params.AddMachineParams) (*state.Ma
 		p.Addrs = nil
 	}
 
+	// A container without a parent is added inside a new machine.
+	newMachines := 1
+	if p.ContainerType != "" && p.ParentId == "" {
+		newMachines = 2
+	}
+	if err := mm.st.CheckModelQuota(controller.MaxModelMachines, newMachines); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	if p.Series == "" {
 		model, err := mm.st.Model()
 		if err != nil {
@@ -600,6 +671,61 @@ func (mm *MachineManagerAPI) GetUpgradeSeriesMessages(args params.UpgradeSeriesN
 	return results, nil
 }
 
+// ProxySettings returns the proxy settings in effect on each of the
+// given machines. The settings follow the model's proxy config, with
+// the controller API addresses added to the no-proxy list, which is
+// what the proxy updater on each machine applies. Any proxy overrides
+// set in a machine's agent config are applied to its settings, as its
+// agent does.
+func (mm *MachineManagerAPI) ProxySettings(args params.Entities) (params.ProxyConfigResults, error) {
+	if err := mm.checkCanRead(); err != nil {
+		return params.ProxyConfigResults{}, err
+	}
+	results := params.ProxyConfigResults{
+		Results: make([]params.ProxyConfigResult, len(args.Entities)),
+	}
+	if len(args.Entities) == 0 {
+		return results, nil
+	}
+	cfg, apiHostPorts, err := mm.proxyConfig()
+	if err != nil {
+		return params.ProxyConfigResults{}, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		machine, err := mm.machineFromTag(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		overrides, err := machine.AgentConfigOverrides()
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i] = common.AgentProxyConfig(cfg, apiHostPorts, overrides.Values)
+	}
+	return results, nil
+}
+
+// ProxySettings isn't on the V6 API.
+func (*MachineManagerAPIV6) ProxySettings(_, _ struct{}) {}
+
+func (mm *MachineManagerAPI) proxyConfig() (*config.Config, []network.SpaceHostPorts, error) {
+	model, err := mm.st.Model()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	cfg, err := model.Config()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	apiHostPorts, err := mm.st.APIHostPortsForAgents()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	return cfg, apiHostPorts, nil
+}
+
 func (mm *MachineManagerAPI) machineFromTag(tag string) (Machine, error) {
 	machineTag, err := names.ParseMachineTag(tag)
 	if err != nil {
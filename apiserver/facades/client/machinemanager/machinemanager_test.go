@@ -21,8 +21,12 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/cloud"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
@@ -143,6 +147,31 @@ func (s *MachineManagerSuite) TestAddMachinesStateError(c *gc.C) {
 	c.Assert(s.st.calls, gc.Equals, 1)
 }
 
+func (s *MachineManagerSuite) TestAddMachinesQuotaExceeded(c *gc.C) {
+	s.st.quotaErr = &state.ErrQuotaExceeded{
+		Quota:  controller.MaxModelMachines,
+		Entity: "machines",
+		Limit:  5,
+		Count:  4,
+		Adding: 2,
+	}
+	results, err := s.api.AddMachines(params.AddMachines{
+		MachineParams: []params.AddMachineParams{{
+			Series:        "trusty",
+			ContainerType: instance.LXD,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Machines, gc.HasLen, 1)
+	c.Assert(results.Machines[0].Error, jc.Satisfies, params.IsCodeQuotaLimitExceeded)
+	c.Assert(s.st.calls, gc.Equals, 0)
+	calls := s.st.Calls()
+	c.Assert(calls[len(calls)-1], jc.DeepEquals, jtesting.StubCall{
+		FuncName: "CheckModelQuota",
+		Args:     []interface{}{controller.MaxModelMachines, 2},
+	})
+}
+
 func (s *MachineManagerSuite) TestDestroyMachine(c *gc.C) {
 	s.st.machines["0"] = &mockMachine{}
 	results, err := s.api.DestroyMachine(params.Entities{
@@ -450,7 +479,17 @@ func (s *MachineManagerSuite) setupUpgradeSeries(c *gc.C) {
 }
 
 func (s *MachineManagerSuite) apiV5() machinemanager.MachineManagerAPIV5 {
-	return machinemanager.MachineManagerAPIV5{MachineManagerAPIV6: &machinemanager.MachineManagerAPIV6{s.api}}
+	return machinemanager.MachineManagerAPIV5{
+		MachineManagerAPIV6: &machinemanager.MachineManagerAPIV6{
+			MachineManagerAPIV7: &machinemanager.MachineManagerAPIV7{
+				MachineManagerAPIV8: &machinemanager.MachineManagerAPIV8{
+					MachineManagerAPIV9: &machinemanager.MachineManagerAPIV9{
+						MachineManagerAPIV10: &machinemanager.MachineManagerAPIV10{s.api},
+					},
+				},
+			},
+		},
+	}
 }
 
 func (s *MachineManagerSuite) TestUpgradeSeriesValidateOK(c *gc.C) {
@@ -743,6 +782,84 @@ func (s *MachineManagerSuite) TestUpgradeSeriesComplete(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *MachineManagerSuite) TestProxySettings(c *gc.C) {
+	cfg, err := config.New(config.UseDefaults, coretesting.FakeConfig().Merge(coretesting.Attrs{
+		"juju-http-proxy":  "http://proxy.example.com:3128",
+		"juju-https-proxy": "https://proxy.example.com:3129",
+		"juju-no-proxy":    "internal.example.com",
+		"apt-http-proxy":   "http://apt.example.com:3142",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	s.st.model = &mockModel{cfg: cfg}
+	s.st.machines["0"] = &mockMachine{}
+
+	results, err := s.api.ProxySettings(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+			{Tag: "unit-foo-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+
+	result := results.Results[0]
+	c.Check(result.Error, gc.IsNil)
+	c.Check(result.JujuProxySettings, jc.DeepEquals, params.ProxyConfig{
+		HTTP:    "http://proxy.example.com:3128",
+		HTTPS:   "https://proxy.example.com:3129",
+		NoProxy: "10.0.0.1,internal.example.com",
+	})
+	c.Check(result.LegacyProxySettings, jc.DeepEquals, params.ProxyConfig{
+		NoProxy: "127.0.0.1,::1,localhost",
+	})
+	c.Check(result.APTProxySettings.HTTP, gc.Equals, "http://apt.example.com:3142")
+
+	c.Check(results.Results[1].Error, gc.ErrorMatches, "machine 1 not found")
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `"unit-foo-0" is not a valid machine tag`)
+}
+
+func (s *MachineManagerSuite) TestProxySettingsPermissionDenied(c *gc.C) {
+	user := names.NewUserTag("fred")
+	s.setAPIUser(c, user)
+	_, err := s.api.ProxySettings(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *MachineManagerSuite) TestProxySettingsAgentConfigOverrides(c *gc.C) {
+	cfg, err := config.New(config.UseDefaults, coretesting.FakeConfig().Merge(coretesting.Attrs{
+		"juju-http-proxy": "http://proxy.example.com:3128",
+		"juju-no-proxy":   "internal.example.com",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	s.st.model = &mockModel{cfg: cfg}
+	s.st.machines["0"] = &mockMachine{overrides: state.AgentConfigOverrides{
+		Values: map[string]string{
+			"http-proxy": "http://local-proxy.example.com:3128",
+			"no-proxy":   "local.example.com",
+		},
+	}}
+	s.st.machines["1"] = &mockMachine{}
+
+	results, err := s.api.ProxySettings(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}, {Tag: "machine-1"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[0].JujuProxySettings, jc.DeepEquals, params.ProxyConfig{
+		HTTP:    "http://local-proxy.example.com:3128",
+		NoProxy: "10.0.0.1,local.example.com",
+	})
+	c.Check(results.Results[1].Error, gc.IsNil)
+	c.Check(results.Results[1].JujuProxySettings, jc.DeepEquals, params.ProxyConfig{
+		HTTP:    "http://proxy.example.com:3128",
+		NoProxy: "10.0.0.1,internal.example.com",
+	})
+}
+
 // TestIsSeriesLessThan tests a validation method which is not very complicated
 // but complex enough to warrant being exported from an export test package for
 // testing.
@@ -790,8 +907,10 @@ type mockState struct {
 	err              error
 	blockMsg         string
 	block            state.BlockType
+	quotaErr         error
 
 	unitStorageAttachmentsF func(tag names.UnitTag) ([]state.StorageAttachment, error)
+	model                   *mockModel
 }
 
 type mockVolumeAccess struct {
@@ -840,6 +959,11 @@ func (st *mockState) AddOneMachine(template state.MachineTemplate) (*state.Machi
 	return &m, st.err
 }
 
+func (st *mockState) CheckModelQuota(quota string, adding int) error {
+	st.MethodCall(st, "CheckModelQuota", quota, adding)
+	return st.quotaErr
+}
+
 func (st *mockState) GetBlockForType(t state.BlockType) (state.Block, bool, error) {
 	st.MethodCall(st, "GetBlockForType", t)
 	if st.block == t {
@@ -856,9 +980,19 @@ func (st *mockState) ModelTag() names.ModelTag {
 
 func (st *mockState) Model() (machinemanager.Model, error) {
 	st.MethodCall(st, "Model")
+	if st.model != nil {
+		return st.model, nil
+	}
 	return &mockModel{}, nil
 }
 
+func (st *mockState) APIHostPortsForAgents() ([]network.SpaceHostPorts, error) {
+	st.MethodCall(st, "APIHostPortsForAgents")
+	return []network.SpaceHostPorts{
+		network.NewSpaceHostPorts(17070, "10.0.0.1"),
+	}, st.NextErr()
+}
+
 func (st *mockState) CloudCredential(tag names.CloudCredentialTag) (state.Credential, error) {
 	st.MethodCall(st, "CloudCredential", tag)
 	return state.Credential{}, nil
@@ -878,6 +1012,20 @@ func (st *mockState) Machine(id string) (machinemanager.Machine, error) {
 	}
 }
 
+func (st *mockState) AllMachines() ([]machinemanager.Machine, error) {
+	st.MethodCall(st, "AllMachines")
+	ids := make([]string, 0, len(st.machines))
+	for id := range st.machines {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	machines := make([]machinemanager.Machine, len(ids))
+	for i, id := range ids {
+		machines[i] = st.machines[id]
+	}
+	return machines, st.NextErr()
+}
+
 func (st *mockState) StorageInstance(tag names.StorageTag) (state.StorageInstance, error) {
 	st.MethodCall(st, "StorageInstance", tag)
 	return &mockStorage{
@@ -927,6 +1075,11 @@ type mockMachine struct {
 	unitAgentState status.Status
 	unitState      status.Status
 	isManager      bool
+	instanceId     instance.Id
+	overrides      state.AgentConfigOverrides
+	id             string
+	life           state.Life
+	isContainer    bool
 
 	unitsF func() ([]machinemanager.Unit, error)
 }
@@ -1002,6 +1155,41 @@ func (m *mockMachine) IsManager() bool {
 	return m.isManager
 }
 
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	m.MethodCall(m, "InstanceId")
+	if m.instanceId == "" {
+		return "", errors.NotProvisionedf("machine")
+	}
+	return m.instanceId, nil
+}
+
+func (m *mockMachine) SetAgentConfigOverrides(values map[string]string) error {
+	m.MethodCall(m, "SetAgentConfigOverrides", values)
+	return m.NextErr()
+}
+
+func (m *mockMachine) AgentConfigOverrides() (state.AgentConfigOverrides, error) {
+	m.MethodCall(m, "AgentConfigOverrides")
+	return m.overrides, m.NextErr()
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
+}
+
+func (m *mockMachine) Life() state.Life {
+	return m.life
+}
+
+func (m *mockMachine) IsContainer() bool {
+	return m.isContainer
+}
+
+func (m *mockMachine) RequestInstancePoll() error {
+	m.MethodCall(m, "RequestInstancePoll")
+	return m.NextErr()
+}
+
 type mockUnit struct {
 	tag         names.UnitTag
 	agentStatus status.Status
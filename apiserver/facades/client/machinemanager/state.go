@@ -22,11 +22,14 @@ type Backend interface {
 	network.SpaceLookup
 
 	Machine(string) (Machine, error)
+	AllMachines() ([]Machine, error)
 	Model() (Model, error)
 	GetBlockForType(t state.BlockType) (state.Block, bool, error)
 	AddOneMachine(template state.MachineTemplate) (*state.Machine, error)
 	AddMachineInsideNewMachine(template, parentTemplate state.MachineTemplate, containerType instance.ContainerType) (*state.Machine, error)
 	AddMachineInsideMachine(template state.MachineTemplate, parentId string, containerType instance.ContainerType) (*state.Machine, error)
+	APIHostPortsForAgents() ([]network.SpaceHostPorts, error)
+	CheckModelQuota(quota string, adding int) error
 }
 
 type Pool interface {
@@ -56,6 +59,13 @@ type Machine interface {
 	WatchUpgradeSeriesNotifications() (state.NotifyWatcher, error)
 	GetUpgradeSeriesMessages() ([]string, bool, error)
 	IsManager() bool
+	InstanceId() (instance.Id, error)
+	SetAgentConfigOverrides(map[string]string) error
+	AgentConfigOverrides() (state.AgentConfigOverrides, error)
+	Id() string
+	Life() state.Life
+	IsContainer() bool
+	RequestInstancePoll() error
 }
 
 type stateShim struct {
@@ -70,6 +80,18 @@ func (s stateShim) Machine(name string) (Machine, error) {
 	return machineShim{m}, nil
 }
 
+func (s stateShim) AllMachines() ([]Machine, error) {
+	machines, err := s.State.AllMachines()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Machine, len(machines))
+	for i, m := range machines {
+		out[i] = machineShim{m}
+	}
+	return out, nil
+}
+
 func (s stateShim) Model() (Model, error) {
 	return s.State.Model()
 }
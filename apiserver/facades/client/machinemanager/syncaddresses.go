@@ -0,0 +1,58 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// SyncMachineAddresses asks the instance poller to refresh the addresses
+// and status of the given machines' instances from the provider now,
+// rather than at their next poll. If no machines are given, every
+// machine the instance poller polls is refreshed, and the results are
+// empty.
+func (mm *MachineManagerAPI) SyncMachineAddresses(args params.Entities) (params.ErrorResults, error) {
+	if err := mm.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, err
+	}
+	if len(args.Entities) == 0 {
+		return params.ErrorResults{}, errors.Trace(mm.syncAllMachineAddresses())
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		machine, err := mm.machineFromTag(entity.Tag)
+		if err == nil {
+			err = machine.RequestInstancePoll()
+		}
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// syncAllMachineAddresses requests a poll of the instance of each machine
+// which isn't a container and isn't dead.
+func (mm *MachineManagerAPI) syncAllMachineAddresses() error {
+	machines, err := mm.st.AllMachines()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, machine := range machines {
+		if machine.IsContainer() || machine.Life() == state.Dead {
+			continue
+		}
+		if err := machine.RequestInstancePoll(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// SyncMachineAddresses isn't on the V9 API.
+func (*MachineManagerAPIV9) SyncMachineAddresses(_, _ struct{}) {}
@@ -0,0 +1,55 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machinemanager_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+func (s *MachineManagerSuite) TestSyncMachineAddresses(c *gc.C) {
+	s.st.machines["0"] = &mockMachine{}
+	s.st.machines["1"] = &mockMachine{}
+	s.st.machines["1"].SetErrors(errors.New("machine is dead"))
+
+	results, err := s.api.SyncMachineAddresses(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+			{Tag: "machine-2"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.ErrorMatches, "machine is dead")
+	c.Check(results.Results[2].Error, gc.ErrorMatches, "machine 2 not found")
+	s.st.machines["0"].CheckCallNames(c, "RequestInstancePoll")
+}
+
+func (s *MachineManagerSuite) TestSyncAllMachineAddresses(c *gc.C) {
+	s.st.machines["0"] = &mockMachine{id: "0"}
+	s.st.machines["0/lxd/0"] = &mockMachine{id: "0/lxd/0", isContainer: true}
+	s.st.machines["1"] = &mockMachine{id: "1", life: state.Dead}
+	s.st.machines["2"] = &mockMachine{id: "2", life: state.Dying}
+
+	results, err := s.api.SyncMachineAddresses(params.Entities{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 0)
+	s.st.machines["0"].CheckCallNames(c, "RequestInstancePoll")
+	s.st.machines["0/lxd/0"].CheckNoCalls(c)
+	s.st.machines["1"].CheckNoCalls(c)
+	s.st.machines["2"].CheckCallNames(c, "RequestInstancePoll")
+}
+
+func (s *MachineManagerSuite) TestSyncMachineAddressesPermissionDenied(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("fred"))
+	_, err := s.api.SyncMachineAddresses(params.Entities{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
@@ -0,0 +1,120 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelarchive provides the API server facade for archiving a
+// model, so that the resources it uses in the cloud are released while
+// it is idle, and for later thawing it.
+package modelarchive
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// ModelArchive facade. For details on the methods, see the methods
+// on state.State with the same names.
+type Backend interface {
+	ModelTag() names.ModelTag
+	ArchiveModel(who string, maxWait time.Duration) (state.ModelArchive, error)
+	ThawModel() (map[string]int, error)
+}
+
+// BlockChecker defines the block-checking functionality required by
+// the ModelArchive facade. This is implemented by
+// apiserver/common.BlockChecker.
+type BlockChecker interface {
+	ChangeAllowed() error
+	RemoveAllowed() error
+}
+
+type stateShim struct {
+	*state.State
+}
+
+func (s stateShim) ModelTag() names.ModelTag {
+	return names.NewModelTag(s.State.ModelUUID())
+}
+
+// API implements the ModelArchive facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	check      BlockChecker
+}
+
+// NewFacade creates a new ModelArchive API facade.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	return NewAPI(stateShim{st}, ctx.Auth(), common.NewBlockChecker(st))
+}
+
+// NewAPI returns a new ModelArchive API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer, blockChecker BlockChecker) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+		check:      blockChecker,
+	}, nil
+}
+
+func (api *API) checkAdmin() error {
+	allowed, err := api.authorizer.HasPermission(permission.AdminAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// ArchiveModel exports the model and then stops its workloads,
+// releasing the machines of an IAAS model or scaling the applications
+// of a CAAS model to zero. The model can later be restored with
+// ThawModel.
+func (api *API) ArchiveModel(args params.ArchiveModelArgs) (params.ModelArchiveResult, error) {
+	if err := api.checkAdmin(); err != nil {
+		return params.ModelArchiveResult{}, errors.Trace(err)
+	}
+	if err := api.check.RemoveAllowed(); err != nil {
+		return params.ModelArchiveResult{}, errors.Trace(err)
+	}
+	archive, err := api.backend.ArchiveModel(api.authorizer.GetAuthTag().Id(), common.MaxWait(args.MaxWait))
+	if err != nil {
+		return params.ModelArchiveResult{}, errors.Trace(err)
+	}
+	return params.ModelArchiveResult{
+		Size:      archive.Size,
+		SHA256:    archive.SHA256,
+		CreatedBy: archive.CreatedBy,
+		Created:   archive.Created,
+	}, nil
+}
+
+// ThawModel restores the workloads of an archived model, returning the
+// number of units restored for each application.
+func (api *API) ThawModel() (params.ThawModelResult, error) {
+	if err := api.checkAdmin(); err != nil {
+		return params.ThawModelResult{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ThawModelResult{}, errors.Trace(err)
+	}
+	restored, err := api.backend.ThawModel()
+	if err != nil {
+		return params.ThawModelResult{}, errors.Trace(err)
+	}
+	return params.ThawModelResult{Applications: restored}, nil
+}
@@ -0,0 +1,171 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelarchive_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/modelarchive"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ModelArchiveSuite struct {
+	testing.IsolationSuite
+
+	backend      *mockBackend
+	blockChecker *mockBlockChecker
+	authorizer   apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&ModelArchiveSuite{})
+
+func (s *ModelArchiveSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		archive: state.ModelArchive{
+			Path:      "modelarchives/" + coretesting.ModelTag.Id() + "/model.yaml",
+			Size:      1024,
+			SHA256:    "abc123",
+			CreatedBy: "admin",
+			Created:   time.Date(2020, 3, 6, 18, 0, 0, 0, time.UTC),
+		},
+		restored: map[string]int{"mysql": 1, "wordpress": 2},
+	}
+	s.blockChecker = &mockBlockChecker{}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+}
+
+func (s *ModelArchiveSuite) newAPI(c *gc.C) *modelarchive.API {
+	api, err := modelarchive.NewAPI(s.backend, s.authorizer, s.blockChecker)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *ModelArchiveSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := modelarchive.NewAPI(s.backend, s.authorizer, s.blockChecker)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *ModelArchiveSuite) TestArchiveModel(c *gc.C) {
+	maxWait := 5 * time.Minute
+	result, err := s.newAPI(c).ArchiveModel(params.ArchiveModelArgs{MaxWait: &maxWait})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelArchiveResult{
+		Size:      1024,
+		SHA256:    "abc123",
+		CreatedBy: "admin",
+		Created:   time.Date(2020, 3, 6, 18, 0, 0, 0, time.UTC),
+	})
+	s.blockChecker.CheckCallNames(c, "RemoveAllowed")
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"ModelTag", nil},
+		{"ArchiveModel", []interface{}{"admin", maxWait}},
+	})
+}
+
+func (s *ModelArchiveSuite) TestArchiveModelDefaultMaxWait(c *gc.C) {
+	_, err := s.newAPI(c).ArchiveModel(params.ArchiveModelArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCall(c, 1, "ArchiveModel", "admin", time.Minute)
+}
+
+func (s *ModelArchiveSuite) TestArchiveModelRequiresAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("write")
+	_, err := s.newAPI(c).ArchiveModel(params.ArchiveModelArgs{})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckCallNames(c, "ModelTag")
+	s.blockChecker.CheckNoCalls(c)
+}
+
+func (s *ModelArchiveSuite) TestArchiveModelBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(common.OperationBlockedError("no removals"))
+	_, err := s.newAPI(c).ArchiveModel(params.ArchiveModelArgs{})
+	c.Assert(err, jc.Satisfies, params.IsCodeOperationBlocked)
+	s.backend.CheckCallNames(c, "ModelTag")
+}
+
+func (s *ModelArchiveSuite) TestArchiveModelError(c *gc.C) {
+	s.backend.SetErrors(nil, errors.AlreadyExistsf("model archive"))
+	_, err := s.newAPI(c).ArchiveModel(params.ArchiveModelArgs{})
+	c.Assert(err, gc.ErrorMatches, "model archive already exists")
+}
+
+func (s *ModelArchiveSuite) TestThawModel(c *gc.C) {
+	result, err := s.newAPI(c).ThawModel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ThawModelResult{
+		Applications: map[string]int{"mysql": 1, "wordpress": 2},
+	})
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.backend.CheckCallNames(c, "ModelTag", "ThawModel")
+}
+
+func (s *ModelArchiveSuite) TestThawModelRequiresAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	_, err := s.newAPI(c).ThawModel()
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckCallNames(c, "ModelTag")
+}
+
+func (s *ModelArchiveSuite) TestThawModelBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(common.OperationBlockedError("no changes"))
+	_, err := s.newAPI(c).ThawModel()
+	c.Assert(err, jc.Satisfies, params.IsCodeOperationBlocked)
+	s.backend.CheckCallNames(c, "ModelTag")
+}
+
+func (s *ModelArchiveSuite) TestThawModelError(c *gc.C) {
+	s.backend.SetErrors(nil, errors.New("model is not archived"))
+	_, err := s.newAPI(c).ThawModel()
+	c.Assert(err, gc.ErrorMatches, "model is not archived")
+}
+
+type mockBackend struct {
+	testing.Stub
+	archive  state.ModelArchive
+	restored map[string]int
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	b.MethodCall(b, "ModelTag")
+	b.PopNoErr()
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) ArchiveModel(who string, maxWait time.Duration) (state.ModelArchive, error) {
+	b.MethodCall(b, "ArchiveModel", who, maxWait)
+	return b.archive, b.NextErr()
+}
+
+func (b *mockBackend) ThawModel() (map[string]int, error) {
+	b.MethodCall(b, "ThawModel")
+	return b.restored, b.NextErr()
+}
+
+type mockBlockChecker struct {
+	testing.Stub
+}
+
+func (c *mockBlockChecker) ChangeAllowed() error {
+	c.MethodCall(c, "ChangeAllowed")
+	return c.NextErr()
+}
+
+func (c *mockBlockChecker) RemoveAllowed() error {
+	c.MethodCall(c, "RemoveAllowed")
+	return c.NextErr()
+}
@@ -23,6 +23,9 @@ type Backend interface {
 	SetSLA(level, owner string, credentials []byte) error
 	SLALevel() (string, error)
 	SpaceByName(string) error
+	RegistryCredentials() ([]state.RegistryCredential, error)
+	SetRegistryCredential(state.RegistryCredential, string) error
+	RemoveRegistryCredential(string) error
 }
 
 type stateShim struct {
@@ -0,0 +1,80 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelconfig
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/environs/config"
+)
+
+// ConfigKeys returns a description of every model and controller config
+// key known to the controller, generated from the config schemas. This
+// is intended both for the model-config command's help output and for
+// external tools that wish to validate config before setting it.
+func (c *ModelConfigAPI) ConfigKeys() (params.ConfigKeysResult, error) {
+	result := params.ConfigKeysResult{}
+	if err := c.canReadModel(); err != nil {
+		return result, errors.Trace(err)
+	}
+	modelSchema, err := config.Schema(nil)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Keys = append(
+		schemaConfigKeys(params.ModelConfigScope, modelSchema, config.ConfigDefaults(), func(name string, field environschema.Attr) bool {
+			return !field.Immutable
+		}),
+		schemaConfigKeys(params.ControllerConfigScope, controller.ConfigSchema, controller.ConfigDefaults(), func(name string, _ environschema.Attr) bool {
+			return controller.AllowedUpdateConfigAttributes.Contains(name)
+		})...,
+	)
+	return result, nil
+}
+
+// schemaConfigKeys converts the supplied schema fields into config key
+// descriptions, sorted by name.
+func schemaConfigKeys(
+	scope string,
+	fields environschema.Fields,
+	defaults map[string]interface{},
+	mutable func(string, environschema.Attr) bool,
+) []params.ConfigKey {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	keys := make([]params.ConfigKey, len(names))
+	for i, name := range names {
+		field := fields[name]
+		keys[i] = params.ConfigKey{
+			Name:        name,
+			Scope:       scope,
+			Type:        fmt.Sprintf("%s", field.Type),
+			Description: field.Description,
+			Default:     configKeyDefault(defaults[name]),
+			Mutable:     mutable(name, field),
+			Secret:      field.Secret,
+		}
+	}
+	return keys
+}
+
+// configKeyDefault returns a wire friendly representation of a config
+// default value.
+func configKeyDefault(value interface{}) interface{} {
+	if d, ok := value.(time.Duration); ok {
+		return d.String()
+	}
+	return value
+}
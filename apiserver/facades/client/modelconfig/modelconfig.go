@@ -15,8 +15,8 @@ import (
 	"github.com/juju/juju/state"
 )
 
-// NewFacade is used for API registration.
-func NewFacadeV2(ctx facade.Context) (*ModelConfigAPIV2, error) {
+// NewFacadeV4 is used for API registration.
+func NewFacadeV4(ctx facade.Context) (*ModelConfigAPIV4, error) {
 	auth := ctx.Auth()
 
 	model, err := ctx.State().Model()
@@ -26,6 +26,24 @@ func NewFacadeV2(ctx facade.Context) (*ModelConfigAPIV2, error) {
 	return NewModelConfigAPI(NewStateBackend(model), auth)
 }
 
+// NewFacadeV3 is used for API registration.
+func NewFacadeV3(ctx facade.Context) (*ModelConfigAPIV3, error) {
+	api, err := NewFacadeV4(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ModelConfigAPIV3{api}, nil
+}
+
+// NewFacadeV2 is used for API registration.
+func NewFacadeV2(ctx facade.Context) (*ModelConfigAPIV2, error) {
+	api, err := NewFacadeV3(ctx)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ModelConfigAPIV2{api}, nil
+}
+
 // NewFacadeV1 is used for API registration.
 func NewFacadeV1(ctx facade.Context) (*ModelConfigAPIV1, error) {
 	api, err := NewFacadeV2(ctx)
@@ -36,25 +54,35 @@ func NewFacadeV1(ctx facade.Context) (*ModelConfigAPIV1, error) {
 }
 
 // ModelConfigAPI provides the base implementation of the methods
-// for the V2 and V1 api calls.
+// for the V4, V3, V2 and V1 api calls.
 type ModelConfigAPI struct {
 	backend Backend
 	auth    facade.Authorizer
 	check   *common.BlockChecker
 }
 
-// ModelConfigAPIV2 is currently the latest.
-type ModelConfigAPIV2 struct {
+// ModelConfigAPIV4 is currently the latest.
+type ModelConfigAPIV4 struct {
 	*ModelConfigAPI
 }
 
+// ModelConfigAPIV3 hides V4 functionality.
+type ModelConfigAPIV3 struct {
+	*ModelConfigAPIV4
+}
+
+// ModelConfigAPIV2 hides V3 functionality.
+type ModelConfigAPIV2 struct {
+	*ModelConfigAPIV3
+}
+
 // ModelConfigAPIV1 hides V2 functionality
 type ModelConfigAPIV1 struct {
 	*ModelConfigAPIV2
 }
 
 // NewModelConfigAPI creates a new instance of the ModelConfig Facade.
-func NewModelConfigAPI(backend Backend, authorizer facade.Authorizer) (*ModelConfigAPIV2, error) {
+func NewModelConfigAPI(backend Backend, authorizer facade.Authorizer) (*ModelConfigAPIV4, error) {
 	if !authorizer.AuthClient() {
 		return nil, common.ErrPerm
 	}
@@ -63,7 +91,7 @@ func NewModelConfigAPI(backend Backend, authorizer facade.Authorizer) (*ModelCon
 		auth:    authorizer,
 		check:   common.NewBlockChecker(backend),
 	}
-	return &ModelConfigAPIV2{client}, nil
+	return &ModelConfigAPIV4{client}, nil
 }
 
 func (c *ModelConfigAPI) checkCanWrite() error {
@@ -271,5 +299,17 @@ func (c *ModelConfigAPI) Sequences() (params.ModelSequencesResult, error) {
 // rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so this
 // removes the method as far as the RPC machinery is concerned.
 
+// RegistryCredentials isn't on the V3 API.
+func (a *ModelConfigAPIV3) RegistryCredentials(_, _ struct{}) {}
+
+// SetRegistryCredentials isn't on the V3 API.
+func (a *ModelConfigAPIV3) SetRegistryCredentials(_, _ struct{}) {}
+
+// RemoveRegistryCredentials isn't on the V3 API.
+func (a *ModelConfigAPIV3) RemoveRegistryCredentials(_, _ struct{}) {}
+
+// ConfigKeys isn't on the V2 API.
+func (a *ModelConfigAPIV2) ConfigKeys(_, _ struct{}) {}
+
 // Sequences isn't on the V1 API.
 func (a *ModelConfigAPIV1) Sequences(_, _ struct{}) {}
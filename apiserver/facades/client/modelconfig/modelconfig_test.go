@@ -4,12 +4,16 @@
 package modelconfig_test
 
 import (
+	"strings"
+	"time"
+
 	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v3"
 
+	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facades/client/modelconfig"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
@@ -24,7 +28,7 @@ type modelconfigSuite struct {
 	gitjujutesting.IsolationSuite
 	backend    *mockBackend
 	authorizer apiservertesting.FakeAuthorizer
-	api        *modelconfig.ModelConfigAPIV2
+	api        *modelconfig.ModelConfigAPIV4
 }
 
 var _ = gc.Suite(&modelconfigSuite{})
@@ -207,11 +211,181 @@ func (s *modelconfigSuite) TestSetSupportCredentals(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *modelconfigSuite) TestConfigKeys(c *gc.C) {
+	result, err := s.api.ConfigKeys()
+	c.Assert(err, jc.ErrorIsNil)
+
+	keys := make(map[string]params.ConfigKey)
+	for _, key := range result.Keys {
+		keys[key.Scope+"/"+key.Name] = key
+	}
+	c.Assert(keys["model/agent-version"], jc.DeepEquals, params.ConfigKey{
+		Name:        "agent-version",
+		Scope:       "model",
+		Type:        "string",
+		Description: "The desired Juju agent version to use",
+		Mutable:     false,
+	})
+	c.Assert(keys["model/update-status-hook-interval"].Default, gc.Equals, config.DefaultUpdateStatusHookInterval)
+	c.Assert(keys["model/update-status-hook-interval"].Mutable, jc.IsTrue)
+	c.Assert(keys["controller/max-debug-log-duration"].Default, gc.Equals, "24h0m0s")
+	c.Assert(keys["controller/max-debug-log-duration"].Mutable, jc.IsTrue)
+	c.Assert(keys["controller/api-port"].Mutable, jc.IsFalse)
+}
+
+func (s *modelconfigSuite) TestConfigKeysReadAccess(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+
+	result, err := s.api.ConfigKeys()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Keys, gc.Not(gc.HasLen), 0)
+}
+
+func (s *modelconfigSuite) TestRegistryCredentials(c *gc.C) {
+	expiry := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	updated := time.Date(2020, 4, 1, 0, 0, 0, 0, time.UTC)
+	s.backend.creds = []state.RegistryCredential{{
+		Registry:  "docker.io",
+		Username:  "bob",
+		Password:  "secret",
+		UpdatedBy: "admin",
+		Updated:   updated,
+	}, {
+		Registry:  "registry.example.com",
+		Username:  "mary",
+		Password:  "token",
+		Expiry:    expiry,
+		UpdatedBy: "admin",
+		Updated:   updated,
+	}}
+	s.authorizer.Tag = names.NewUserTag("read")
+
+	result, err := s.api.RegistryCredentials()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Credentials, jc.DeepEquals, []params.RegistryCredential{{
+		Registry:  "docker.io",
+		Username:  "bob",
+		UpdatedBy: "admin",
+		Updated:   &updated,
+	}, {
+		Registry:  "registry.example.com",
+		Username:  "mary",
+		Expiry:    &expiry,
+		UpdatedBy: "admin",
+		Updated:   &updated,
+	}})
+}
+
+func (s *modelconfigSuite) TestSetRegistryCredentials(c *gc.C) {
+	expiry := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	result, err := s.api.SetRegistryCredentials(params.SetRegistryCredentials{
+		Credentials: []params.RegistryCredential{{
+			Registry: "docker.io",
+			Username: "bob",
+			Password: "secret",
+			Expiry:   &expiry,
+		}, {
+			Registry: "bad/registry",
+			Username: "bob",
+			Password: "secret",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `registry "bad/registry" not valid`)
+	c.Assert(s.backend.creds, jc.DeepEquals, []state.RegistryCredential{{
+		Registry:  "docker.io",
+		Username:  "bob",
+		Password:  "secret",
+		Expiry:    expiry,
+		UpdatedBy: "bruce",
+	}})
+}
+
+func (s *modelconfigSuite) TestSetRegistryCredentialsRequiresAdmin(c *gc.C) {
+	apiUser := names.NewUserTag("fred")
+	s.authorizer.Tag = apiUser
+	s.authorizer.HasWriteTag = apiUser
+
+	_, err := s.api.SetRegistryCredentials(params.SetRegistryCredentials{
+		Credentials: []params.RegistryCredential{{
+			Registry: "docker.io",
+			Username: "bob",
+			Password: "secret",
+		}},
+	})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+	c.Assert(s.backend.creds, gc.HasLen, 0)
+}
+
+func (s *modelconfigSuite) TestBlockChangesSetRegistryCredentials(c *gc.C) {
+	s.blockAllChanges(c, "TestBlockChangesSetRegistryCredentials")
+	_, err := s.api.SetRegistryCredentials(params.SetRegistryCredentials{
+		Credentials: []params.RegistryCredential{{
+			Registry: "docker.io",
+			Username: "bob",
+			Password: "secret",
+		}},
+	})
+	s.assertBlocked(c, err, "TestBlockChangesSetRegistryCredentials")
+}
+
+func (s *modelconfigSuite) TestRemoveRegistryCredentials(c *gc.C) {
+	s.backend.creds = []state.RegistryCredential{{
+		Registry: "docker.io",
+		Username: "bob",
+		Password: "secret",
+	}}
+	result, err := s.api.RemoveRegistryCredentials(params.RemoveRegistryCredentials{
+		Registries: []string{"docker.io", "registry.example.com"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `credential for registry "registry.example.com" not found`)
+	c.Assert(result.Results[1].Error, jc.Satisfies, params.IsCodeNotFound)
+	c.Assert(s.backend.creds, gc.HasLen, 0)
+}
+
+func (s *modelconfigSuite) TestRemoveRegistryCredentialsRequiresAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+
+	_, err := s.api.RemoveRegistryCredentials(params.RemoveRegistryCredentials{
+		Registries: []string{"docker.io"},
+	})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+}
+
 type mockBackend struct {
-	cfg config.ConfigValues
-	old *config.Config
-	b   state.BlockType
-	msg string
+	cfg   config.ConfigValues
+	old   *config.Config
+	b     state.BlockType
+	msg   string
+	creds []state.RegistryCredential
+}
+
+func (m *mockBackend) RegistryCredentials() ([]state.RegistryCredential, error) {
+	return m.creds, nil
+}
+
+func (m *mockBackend) SetRegistryCredential(cred state.RegistryCredential, updatedBy string) error {
+	if strings.Contains(cred.Registry, "/") {
+		return errors.NotValidf("registry %q", cred.Registry)
+	}
+	cred.UpdatedBy = updatedBy
+	m.creds = append(m.creds, cred)
+	return nil
+}
+
+func (m *mockBackend) RemoveRegistryCredential(registry string) error {
+	for i, cred := range m.creds {
+		if cred.Registry == registry {
+			m.creds = append(m.creds[:i], m.creds[i+1:]...)
+			return nil
+		}
+	}
+	return errors.NotFoundf("credential for registry %q", registry)
 }
 
 func (m *mockBackend) ModelConfigValues() (config.ConfigValues, error) {
@@ -0,0 +1,109 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelconfig
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// checkCanAdmin checks that the user can manage the model's secrets,
+// which are only visible to model and controller admins.
+func (c *ModelConfigAPI) checkCanAdmin() error {
+	isAdmin, err := c.auth.HasPermission(permission.SuperuserAccess, c.backend.ControllerTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if isAdmin {
+		return nil
+	}
+	isModelAdmin, err := c.auth.HasPermission(permission.AdminAccess, c.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !isModelAdmin {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// RegistryCredentials returns the credentials the model uses to pull
+// OCI images from private registries. The passwords aren't returned.
+func (c *ModelConfigAPI) RegistryCredentials() (params.RegistryCredentialsResult, error) {
+	result := params.RegistryCredentialsResult{}
+	if err := c.canReadModel(); err != nil {
+		return result, errors.Trace(err)
+	}
+	creds, err := c.backend.RegistryCredentials()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Credentials = make([]params.RegistryCredential, len(creds))
+	for i, cred := range creds {
+		updated := cred.Updated
+		result.Credentials[i] = params.RegistryCredential{
+			Registry:  cred.Registry,
+			Username:  cred.Username,
+			UpdatedBy: cred.UpdatedBy,
+			Updated:   &updated,
+		}
+		if !cred.Expiry.IsZero() {
+			expiry := cred.Expiry
+			result.Credentials[i].Expiry = &expiry
+		}
+	}
+	return result, nil
+}
+
+// SetRegistryCredentials adds registry credentials to the model, or
+// replaces the existing credentials for the registries, so that the
+// images of the model's applications are pulled with them.
+func (c *ModelConfigAPI) SetRegistryCredentials(args params.SetRegistryCredentials) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Credentials)),
+	}
+	if err := c.checkCanAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if err := c.check.ChangeAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	updatedBy := c.auth.GetAuthTag().Id()
+	for i, arg := range args.Credentials {
+		cred := state.RegistryCredential{
+			Registry: arg.Registry,
+			Username: arg.Username,
+			Password: arg.Password,
+		}
+		if arg.Expiry != nil {
+			cred.Expiry = *arg.Expiry
+		}
+		err := c.backend.SetRegistryCredential(cred, updatedBy)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// RemoveRegistryCredentials removes the credentials for the given
+// registries from the model.
+func (c *ModelConfigAPI) RemoveRegistryCredentials(args params.RemoveRegistryCredentials) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Registries)),
+	}
+	if err := c.checkCanAdmin(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if err := c.check.RemoveAllowed(); err != nil {
+		return result, errors.Trace(err)
+	}
+	for i, registry := range args.Registries {
+		err := c.backend.RemoveRegistryCredential(registry)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelcost provides the API server facade for estimating what
+// a model costs to run.
+package modelcost
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/cost"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the ModelCost
+// facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+
+	// EstimateCost returns the estimated cost of running the model's
+	// resources. See state.Model.EstimateCost.
+	EstimateCost() (cost.Estimate, error)
+}
+
+type stateShim struct {
+	*state.State
+}
+
+func (s stateShim) ModelTag() names.ModelTag {
+	return names.NewModelTag(s.State.ModelUUID())
+}
+
+func (s stateShim) EstimateCost() (cost.Estimate, error) {
+	model, err := s.State.Model()
+	if err != nil {
+		return cost.Estimate{}, errors.Trace(err)
+	}
+	return model.EstimateCost()
+}
+
+// API implements the ModelCost facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewFacade creates a new ModelCost API facade.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(stateShim{ctx.State()}, ctx.Auth())
+}
+
+// NewAPI returns a new ModelCost API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+// EstimateCost returns the estimated cost of running the model's
+// machines, volumes and load balancers. It returns a NotFound error if
+// no pricing has been set for the model's cloud.
+func (api *API) EstimateCost() (params.ModelCostEstimate, error) {
+	allowed, err := api.authorizer.HasPermission(permission.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return params.ModelCostEstimate{}, errors.Trace(err)
+	}
+	if !allowed {
+		return params.ModelCostEstimate{}, common.ErrPerm
+	}
+	estimate, err := api.backend.EstimateCost()
+	if err != nil {
+		return params.ModelCostEstimate{}, errors.Trace(err)
+	}
+	result := params.ModelCostEstimate{
+		Currency: estimate.Currency,
+		Items:    make([]params.ModelCostItem, len(estimate.Items)),
+		Hourly:   estimate.Hourly,
+		Monthly:  estimate.Monthly(),
+	}
+	for i, item := range estimate.Items {
+		result.Items[i] = params.ModelCostItem{
+			Kind:   string(item.Kind),
+			Id:     item.Id,
+			Detail: item.Detail,
+			Hourly: item.Hourly,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelcost_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/modelcost"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/cost"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ModelCostSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&ModelCostSuite{})
+
+func (s *ModelCostSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		estimate: cost.Estimate{
+			Currency: "USD",
+			Items: []cost.Item{
+				{Kind: cost.KindMachine, Id: "0", Detail: "m5.large", Hourly: 0.5},
+				{Kind: cost.KindVolume, Id: "0/0", Detail: "10GiB", Hourly: 0.25},
+			},
+			Hourly: 0.75,
+		},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	}
+}
+
+func (s *ModelCostSuite) newAPI(c *gc.C) *modelcost.API {
+	api, err := modelcost.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *ModelCostSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := modelcost.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *ModelCostSuite) TestEstimateCost(c *gc.C) {
+	result, err := s.newAPI(c).EstimateCost()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelCostEstimate{
+		Currency: "USD",
+		Items: []params.ModelCostItem{
+			{Kind: "machine", Id: "0", Detail: "m5.large", Hourly: 0.5},
+			{Kind: "volume", Id: "0/0", Detail: "10GiB", Hourly: 0.25},
+		},
+		Hourly:  0.75,
+		Monthly: 547.5,
+	})
+	s.backend.CheckCallNames(c, "ModelTag", "EstimateCost")
+}
+
+func (s *ModelCostSuite) TestEstimateCostNoItems(c *gc.C) {
+	s.backend.estimate = cost.Estimate{Currency: "EUR"}
+	result, err := s.newAPI(c).EstimateCost()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelCostEstimate{
+		Currency: "EUR",
+		Items:    []params.ModelCostItem{},
+	})
+}
+
+func (s *ModelCostSuite) TestEstimateCostRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("nobody")
+	_, err := s.newAPI(c).EstimateCost()
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckCallNames(c, "ModelTag")
+}
+
+func (s *ModelCostSuite) TestEstimateCostNoPricing(c *gc.C) {
+	s.backend.SetErrors(errors.NotFoundf(`pricing for cloud "aws"`))
+	_, err := s.newAPI(c).EstimateCost()
+	c.Assert(err, gc.ErrorMatches, `pricing for cloud "aws" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+type mockBackend struct {
+	testing.Stub
+	estimate cost.Estimate
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	b.MethodCall(b, "ModelTag")
+	b.PopNoErr()
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) EstimateCost() (cost.Estimate, error) {
+	b.MethodCall(b, "EstimateCost")
+	return b.estimate, b.NextErr()
+}
@@ -0,0 +1,60 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelmanager
+
+import (
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// FindAnnotations returns the entities, in the models the user can
+// see, which have all of the given annotations.
+func (m *ModelManagerAPI) FindAnnotations(args params.FindAnnotationsArgs) (params.AnnotatedEntities, error) {
+	result := params.AnnotatedEntities{Entities: []params.AnnotatedEntity{}}
+	if len(args.Annotations) == 0 {
+		return result, errors.NotValidf("empty annotations")
+	}
+	kinds := set.NewStrings(args.Kinds...)
+
+	// Controller superusers see all models.
+	models, err := m.state.ModelBasicInfoForUser(m.apiUser)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	visible := make(map[string]state.ModelAccessInfo)
+	for _, model := range models {
+		visible[model.UUID] = model
+	}
+
+	found, err := m.ctlrState.FindAnnotations(args.Annotations)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, entity := range found {
+		model, ok := visible[entity.ModelUUID]
+		if !ok {
+			continue
+		}
+		tag, err := names.ParseTag(entity.Tag)
+		if err != nil {
+			logger.Warningf("ignoring annotations on %q in model %s: %v", entity.Tag, entity.ModelUUID, err)
+			continue
+		}
+		if !kinds.IsEmpty() && !kinds.Contains(tag.Kind()) {
+			continue
+		}
+		result.Entities = append(result.Entities, params.AnnotatedEntity{
+			ModelTag:      names.NewModelTag(model.UUID).String(),
+			ModelName:     model.Name,
+			ModelOwnerTag: names.NewUserTag(model.Owner).String(),
+			EntityTag:     tag.String(),
+			Annotations:   entity.Annotations,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelmanager_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+const (
+	paymentsModelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+	billingModelUUID  = "deadbeef-0bad-400d-8000-5b1d0d06f00d"
+)
+
+func (s *modelManagerSuite) setUpAnnotated(c *gc.C) {
+	s.st.modelInfos = []state.ModelAccessInfo{{
+		Name:  "payments",
+		UUID:  paymentsModelUUID,
+		Owner: "bob",
+	}}
+	s.ctlrSt.annotated = []state.AnnotatedEntity{{
+		ModelUUID:   paymentsModelUUID,
+		Tag:         names.NewModelTag(paymentsModelUUID).String(),
+		Annotations: map[string]string{"team": "payments"},
+	}, {
+		ModelUUID:   paymentsModelUUID,
+		Tag:         "application-ledger",
+		Annotations: map[string]string{"team": "payments", "tier": "gold"},
+	}, {
+		// The user cannot see the billing model.
+		ModelUUID:   billingModelUUID,
+		Tag:         "application-invoices",
+		Annotations: map[string]string{"team": "payments"},
+	}}
+	s.setAPIUser(c, names.NewUserTag("bob"))
+	s.st.ResetCalls()
+	s.ctlrSt.ResetCalls()
+}
+
+func (s *modelManagerSuite) TestFindAnnotations(c *gc.C) {
+	s.setUpAnnotated(c)
+	result, err := s.api.FindAnnotations(params.FindAnnotationsArgs{
+		Annotations: map[string]string{"team": "payments"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AnnotatedEntities{
+		Entities: []params.AnnotatedEntity{{
+			ModelTag:      names.NewModelTag(paymentsModelUUID).String(),
+			ModelName:     "payments",
+			ModelOwnerTag: "user-bob",
+			EntityTag:     names.NewModelTag(paymentsModelUUID).String(),
+			Annotations:   map[string]string{"team": "payments"},
+		}, {
+			ModelTag:      names.NewModelTag(paymentsModelUUID).String(),
+			ModelName:     "payments",
+			ModelOwnerTag: "user-bob",
+			EntityTag:     "application-ledger",
+			Annotations:   map[string]string{"team": "payments", "tier": "gold"},
+		}},
+	})
+	s.st.CheckCall(c, 0, "ModelBasicInfoForUser", names.NewUserTag("bob"))
+	s.ctlrSt.CheckCall(c, 0, "FindAnnotations", map[string]string{"team": "payments"})
+}
+
+func (s *modelManagerSuite) TestFindAnnotationsKinds(c *gc.C) {
+	s.setUpAnnotated(c)
+	result, err := s.api.FindAnnotations(params.FindAnnotationsArgs{
+		Annotations: map[string]string{"team": "payments"},
+		Kinds:       []string{"application"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Entities, gc.HasLen, 1)
+	c.Assert(result.Entities[0].EntityTag, gc.Equals, "application-ledger")
+}
+
+func (s *modelManagerSuite) TestFindAnnotationsEmpty(c *gc.C) {
+	_, err := s.api.FindAnnotations(params.FindAnnotationsArgs{})
+	c.Assert(err, gc.ErrorMatches, "empty annotations not valid")
+}
@@ -60,7 +60,7 @@ func (s *ListModelsWithInfoSuite) SetUpTest(c *gc.C) {
 	}
 
 	s.callContext = context.NewCloudCallContext()
-	api, err := modelmanager.NewModelManagerAPI(s.st, &mockState{}, nil, nil, s.authoriser, s.st.model, s.callContext)
+	api, err := modelmanager.NewModelManagerAPI(s.st, &mockState{}, nil, nil, s.authoriser, s.st.model, s.callContext, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	s.api = api
 }
@@ -79,7 +79,7 @@ func (s *ListModelsWithInfoSuite) createModel(c *gc.C, user names.UserTag) *mock
 
 func (s *ListModelsWithInfoSuite) setAPIUser(c *gc.C, user names.UserTag) {
 	s.authoriser.Tag = user
-	modelmanager, err := modelmanager.NewModelManagerAPI(s.st, &mockState{}, nil, nil, s.authoriser, s.st.model, s.callContext)
+	modelmanager, err := modelmanager.NewModelManagerAPI(s.st, &mockState{}, nil, nil, s.authoriser, s.st.model, s.callContext, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	s.api = modelmanager
 }
@@ -164,19 +164,19 @@ func (s *modelInfoSuite) SetUpTest(c *gc.C) {
 	s.callContext = context.NewCloudCallContext()
 
 	var err error
-	s.modelmanager, err = modelmanager.NewModelManagerAPI(s.st, s.ctlrSt, nil, nil, &s.authorizer, s.st.model, s.callContext)
+	s.modelmanager, err = modelmanager.NewModelManagerAPI(s.st, s.ctlrSt, nil, nil, &s.authorizer, s.st.model, s.callContext, nil)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
 func (s *modelInfoSuite) setAPIUser(c *gc.C, user names.UserTag) {
 	s.authorizer.Tag = user
 	var err error
-	s.modelmanager, err = modelmanager.NewModelManagerAPI(s.st, s.ctlrSt, nil, nil, s.authorizer, s.st.model, s.callContext)
+	s.modelmanager, err = modelmanager.NewModelManagerAPI(s.st, s.ctlrSt, nil, nil, s.authorizer, s.st.model, s.callContext, nil)
 	c.Assert(err, jc.ErrorIsNil)
 }
 
 func (s *modelInfoSuite) TestModelInfoV7(c *gc.C) {
-	api := &modelmanager.ModelManagerAPIV7{s.modelmanager}
+	api := &modelmanager.ModelManagerAPIV7{&modelmanager.ModelManagerAPIV8{&modelmanager.ModelManagerAPIV9{&modelmanager.ModelManagerAPIV10{s.modelmanager}}}}
 
 	results, err := api.ModelInfo(params.Entities{
 		Entities: []params.Entity{{
@@ -638,6 +638,12 @@ type mockState struct {
 	modelConfig     *config.Config
 
 	modelDetailsForUser func() ([]state.ModelSummary, error)
+
+	teardownWatcher state.NotifyWatcher
+	abandoned       []state.AbandonedResource
+	templates       []state.ModelTemplate
+	annotated       []state.AnnotatedEntity
+	modelInfos      []state.ModelAccessInfo
 }
 
 type fakeModelDescription struct {
@@ -818,6 +824,49 @@ func (st *mockState) CloudCredential(tag names.CloudCredentialTag) (state.Creden
 	return st.cred, st.NextErr()
 }
 
+func (st *mockState) WatchModelTeardown() state.NotifyWatcher {
+	st.MethodCall(st, "WatchModelTeardown")
+	return st.teardownWatcher
+}
+
+func (st *mockState) AbandonedResources(modelUUID string) ([]state.AbandonedResource, error) {
+	st.MethodCall(st, "AbandonedResources", modelUUID)
+	return st.abandoned, st.NextErr()
+}
+
+func (st *mockState) AddModelTemplate(t state.ModelTemplate) error {
+	st.MethodCall(st, "AddModelTemplate", t)
+	return st.NextErr()
+}
+
+func (st *mockState) ModelTemplate(name string) (state.ModelTemplate, error) {
+	st.MethodCall(st, "ModelTemplate", name)
+	if err := st.NextErr(); err != nil {
+		return state.ModelTemplate{}, err
+	}
+	for _, t := range st.templates {
+		if t.Name == name {
+			return t, nil
+		}
+	}
+	return state.ModelTemplate{}, errors.NotFoundf("model template %q", name)
+}
+
+func (st *mockState) ModelTemplates() ([]state.ModelTemplate, error) {
+	st.MethodCall(st, "ModelTemplates")
+	return st.templates, st.NextErr()
+}
+
+func (st *mockState) RemoveModelTemplate(name string) error {
+	st.MethodCall(st, "RemoveModelTemplate", name)
+	return st.NextErr()
+}
+
+func (st *mockState) FindAnnotations(match map[string]string) ([]state.AnnotatedEntity, error) {
+	st.MethodCall(st, "FindAnnotations", match)
+	return st.annotated, st.NextErr()
+}
+
 func (st *mockState) Close() error {
 	st.MethodCall(st, "Close")
 	return st.NextErr()
@@ -850,7 +899,7 @@ func (st *mockState) ModelSummariesForUser(user names.UserTag, all bool) ([]stat
 
 func (st *mockState) ModelBasicInfoForUser(user names.UserTag) ([]state.ModelAccessInfo, error) {
 	st.MethodCall(st, "ModelBasicInfoForUser", user)
-	return []state.ModelAccessInfo{}, st.NextErr()
+	return append([]state.ModelAccessInfo{}, st.modelInfos...), st.NextErr()
 }
 
 func (st *mockState) RemoveUserAccess(subject names.UserTag, target names.Tag) error {
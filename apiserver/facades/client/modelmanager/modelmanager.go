@@ -27,6 +27,7 @@ import (
 	"github.com/juju/juju/caas"
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/controller/modelmanager"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
@@ -40,6 +41,31 @@ import (
 
 var logger = loggo.GetLogger("juju.apiserver.modelmanager")
 
+// ModelManagerV11 defines the methods on the version 11 facade for the
+// modelmanager API endpoint.
+type ModelManagerV11 interface {
+	ModelManagerV10
+	FindAnnotations(args params.FindAnnotationsArgs) (params.AnnotatedEntities, error)
+}
+
+// ModelManagerV10 defines the methods on the version 10 facade for the
+// modelmanager API endpoint.
+type ModelManagerV10 interface {
+	ModelManagerV9
+	AddModelTemplates(args params.ModelTemplateArgs) (params.ErrorResults, error)
+	ModelTemplates() (params.ModelTemplatesResult, error)
+	RemoveModelTemplates(args params.ModelTemplateNames) (params.ErrorResults, error)
+	// CreateModel gains the ability to create a model from a template.
+}
+
+// ModelManagerV9 defines the methods on the version 9 facade for the
+// modelmanager API endpoint.
+type ModelManagerV9 interface {
+	ModelManagerV8
+	WatchModelTeardown(args params.Entities) (params.NotifyWatchResults, error)
+	AbandonedModelResources(args params.Entities) (params.AbandonedResourcesResults, error)
+}
+
 // ModelManagerV8 defines the methods on the version 8 facade for the
 // modelmanager API endpoint.
 type ModelManagerV8 interface {
@@ -121,6 +147,7 @@ type ModelManagerAPI struct {
 	ctlrState   common.ModelManagerBackend
 	check       *common.BlockChecker
 	authorizer  facade.Authorizer
+	resources   facade.Resources
 	toolsFinder *common.ToolsFinder
 	apiUser     names.UserTag
 	isAdmin     bool
@@ -129,10 +156,28 @@ type ModelManagerAPI struct {
 	callContext context.ProviderCallContext
 }
 
+// ModelManagerAPIV10 provides a way to wrap the different calls between
+// version 11 and version 10 of the model manager API
+type ModelManagerAPIV10 struct {
+	*ModelManagerAPI
+}
+
+// ModelManagerAPIV9 provides a way to wrap the different calls between
+// version 10 and version 9 of the model manager API
+type ModelManagerAPIV9 struct {
+	*ModelManagerAPIV10
+}
+
+// ModelManagerAPIV8 provides a way to wrap the different calls between
+// version 9 and version 8 of the model manager API
+type ModelManagerAPIV8 struct {
+	*ModelManagerAPIV9
+}
+
 // ModelManagerAPIV7 provides a way to wrap the different calls between
 // version 8 and version 7 of the model manager API
 type ModelManagerAPIV7 struct {
-	*ModelManagerAPI
+	*ModelManagerAPIV8
 }
 
 // ModelManagerAPIV6 provides a way to wrap the different calls between
@@ -166,17 +211,20 @@ type ModelManagerAPIV2 struct {
 }
 
 var (
-	_ ModelManagerV8 = (*ModelManagerAPI)(nil)
-	_ ModelManagerV7 = (*ModelManagerAPIV7)(nil)
-	_ ModelManagerV6 = (*ModelManagerAPIV6)(nil)
-	_ ModelManagerV5 = (*ModelManagerAPIV5)(nil)
-	_ ModelManagerV4 = (*ModelManagerAPIV4)(nil)
-	_ ModelManagerV3 = (*ModelManagerAPIV3)(nil)
-	_ ModelManagerV2 = (*ModelManagerAPIV2)(nil)
+	_ ModelManagerV11 = (*ModelManagerAPI)(nil)
+	_ ModelManagerV10 = (*ModelManagerAPIV10)(nil)
+	_ ModelManagerV9  = (*ModelManagerAPIV9)(nil)
+	_ ModelManagerV8  = (*ModelManagerAPIV8)(nil)
+	_ ModelManagerV7  = (*ModelManagerAPIV7)(nil)
+	_ ModelManagerV6  = (*ModelManagerAPIV6)(nil)
+	_ ModelManagerV5  = (*ModelManagerAPIV5)(nil)
+	_ ModelManagerV4  = (*ModelManagerAPIV4)(nil)
+	_ ModelManagerV3  = (*ModelManagerAPIV3)(nil)
+	_ ModelManagerV2  = (*ModelManagerAPIV2)(nil)
 )
 
-// NewFacadeV8 is used for API registration.
-func NewFacadeV8(ctx facade.Context) (*ModelManagerAPI, error) {
+// NewFacadeV11 is used for API registration.
+func NewFacadeV11(ctx facade.Context) (*ModelManagerAPI, error) {
 	st := ctx.State()
 	pool := ctx.StatePool()
 	ctlrSt := pool.SystemState()
@@ -210,9 +258,37 @@ func NewFacadeV8(ctx facade.Context) (*ModelManagerAPI, error) {
 		auth,
 		model,
 		state.CallContext(st),
+		ctx.Resources(),
 	)
 }
 
+// NewFacadeV10 is used for API registration.
+func NewFacadeV10(ctx facade.Context) (*ModelManagerAPIV10, error) {
+	v11, err := NewFacadeV11(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelManagerAPIV10{v11}, nil
+}
+
+// NewFacadeV9 is used for API registration.
+func NewFacadeV9(ctx facade.Context) (*ModelManagerAPIV9, error) {
+	v10, err := NewFacadeV10(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelManagerAPIV9{v10}, nil
+}
+
+// NewFacadeV8 is used for API registration.
+func NewFacadeV8(ctx facade.Context) (*ModelManagerAPIV8, error) {
+	v9, err := NewFacadeV9(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ModelManagerAPIV8{v9}, nil
+}
+
 // NewFacadeV7 is used for API registration.
 func NewFacadeV7(ctx facade.Context) (*ModelManagerAPIV7, error) {
 	v8, err := NewFacadeV8(ctx)
@@ -277,6 +353,7 @@ func NewModelManagerAPI(
 	authorizer facade.Authorizer,
 	m common.Model,
 	callCtx context.ProviderCallContext,
+	resources facade.Resources,
 ) (*ModelManagerAPI, error) {
 	if !authorizer.AuthClient() {
 		return nil, common.ErrPerm
@@ -298,6 +375,7 @@ func NewModelManagerAPI(
 		getBroker:      getBroker,
 		check:          common.NewBlockChecker(st),
 		authorizer:     authorizer,
+		resources:      resources,
 		toolsFinder:    common.NewToolsFinder(configGetter, st, urlGetter),
 		apiUser:        apiUser,
 		isAdmin:        isAdmin,
@@ -428,6 +506,15 @@ func (m *ModelManagerAPI) checkAddModelPermission(cloud string, userTag names.Us
 func (m *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.ModelInfo, error) {
 	result := params.ModelInfo{}
 
+	var cons constraints.Value
+	if args.Template != "" {
+		var err error
+		args, cons, err = m.applyModelTemplate(args)
+		if err != nil {
+			return result, errors.Annotate(err, "applying model template")
+		}
+	}
+
 	// Get the controller model first. We need it both for the state
 	// server owner and the ability to get the config.
 	controllerModel, err := m.ctlrState.Model()
@@ -555,7 +642,8 @@ func (m *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Model
 			cloudTag,
 			cloudRegionName,
 			cloudCredentialTag,
-			ownerTag)
+			ownerTag,
+			cons)
 	} else {
 		model, err = m.newModel(
 			cloudSpec,
@@ -564,7 +652,8 @@ func (m *ModelManagerAPI) CreateModel(args params.ModelCreateArgs) (params.Model
 			cloudTag,
 			cloudRegionName,
 			cloudCredentialTag,
-			ownerTag)
+			ownerTag,
+			cons)
 	}
 	if err != nil {
 		return result, errors.Trace(err)
@@ -578,6 +667,7 @@ func (m *ModelManagerAPI) newCAASModel(cloudSpec environs.CloudSpec,
 	cloudRegionName string,
 	cloudCredentialTag names.CloudCredentialTag,
 	ownerTag names.UserTag,
+	cons constraints.Value,
 ) (common.Model, error) {
 	newConfig, err := m.newCAASModelConfig(cloudSpec, createArgs)
 	if err != nil {
@@ -622,6 +712,7 @@ Please choose a different model name.
 		CloudCredential:         cloudCredentialTag,
 		Config:                  newConfig,
 		Owner:                   ownerTag,
+		Constraints:             cons,
 		StorageProviderRegistry: storageProviderRegistry,
 	})
 	if err != nil {
@@ -640,6 +731,7 @@ func (m *ModelManagerAPI) newModel(
 	cloudRegionName string,
 	cloudCredentialTag names.CloudCredentialTag,
 	ownerTag names.UserTag,
+	cons constraints.Value,
 ) (common.Model, error) {
 	newConfig, err := m.newModelConfig(cloudSpec, createArgs, controllerModel)
 	if err != nil {
@@ -682,6 +774,7 @@ func (m *ModelManagerAPI) newModel(
 		CloudCredential:         cloudCredentialTag,
 		Config:                  newConfig,
 		Owner:                   ownerTag,
+		Constraints:             cons,
 		StorageProviderRegistry: storageProviderRegistry,
 		EnvironVersion:          env.Provider().Version(),
 	})
@@ -1634,3 +1727,21 @@ func (*ModelManagerAPIV4) ChangeModelCredential(_, _ struct{}) {}
 
 // ModelDefaultsForClouds did not exist prior to v6.
 func (*ModelManagerAPIV5) ModelDefaultsForClouds(_, _ struct{}) {}
+
+// WatchModelTeardown did not exist prior to v9.
+func (*ModelManagerAPIV8) WatchModelTeardown(_, _ struct{}) {}
+
+// AbandonedModelResources did not exist prior to v9.
+func (*ModelManagerAPIV8) AbandonedModelResources(_, _ struct{}) {}
+
+// AddModelTemplates did not exist prior to v10.
+func (*ModelManagerAPIV9) AddModelTemplates(_, _ struct{}) {}
+
+// ModelTemplates did not exist prior to v10.
+func (*ModelManagerAPIV9) ModelTemplates(_, _ struct{}) {}
+
+// RemoveModelTemplates did not exist prior to v10.
+func (*ModelManagerAPIV9) RemoveModelTemplates(_, _ struct{}) {}
+
+// FindAnnotations did not exist prior to v11.
+func (*ModelManagerAPIV10) FindAnnotations(_, _ struct{}) {}
@@ -67,6 +67,7 @@ type modelManagerSuite struct {
 	caasApi    *modelmanager.ModelManagerAPI
 
 	callContext context.ProviderCallContext
+	resources   *common.Resources
 }
 
 var _ = gc.Suite(&modelManagerSuite{})
@@ -206,16 +207,18 @@ func (s *modelManagerSuite) SetUpTest(c *gc.C) {
 	}
 
 	s.callContext = context.NewCloudCallContext()
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
 
 	newBroker := func(args environs.OpenParams) (caas.Broker, error) {
 		s.caasBroker = &mockCaasBroker{namespace: args.Config.Name()}
 		return s.caasBroker, nil
 	}
 
-	api, err := modelmanager.NewModelManagerAPI(s.st, s.ctlrSt, nil, newBroker, s.authoriser, s.st.model, s.callContext)
+	api, err := modelmanager.NewModelManagerAPI(s.st, s.ctlrSt, nil, newBroker, s.authoriser, s.st.model, s.callContext, s.resources)
 	c.Assert(err, jc.ErrorIsNil)
 	s.api = api
-	caasApi, err := modelmanager.NewModelManagerAPI(s.caasSt, s.ctlrSt, nil, newBroker, s.authoriser, s.st.model, s.callContext)
+	caasApi, err := modelmanager.NewModelManagerAPI(s.caasSt, s.ctlrSt, nil, newBroker, s.authoriser, s.st.model, s.callContext, s.resources)
 	c.Assert(err, jc.ErrorIsNil)
 	s.caasApi = caasApi
 }
@@ -225,7 +228,7 @@ func (s *modelManagerSuite) setAPIUser(c *gc.C, user names.UserTag) {
 	newBroker := func(args environs.OpenParams) (caas.Broker, error) {
 		return s.caasBroker, nil
 	}
-	mm, err := modelmanager.NewModelManagerAPI(s.st, s.ctlrSt, nil, newBroker, s.authoriser, s.st.model, s.callContext)
+	mm, err := modelmanager.NewModelManagerAPI(s.st, s.ctlrSt, nil, newBroker, s.authoriser, s.st.model, s.callContext, s.resources)
 	c.Assert(err, jc.ErrorIsNil)
 	s.api = mm
 }
@@ -680,7 +683,9 @@ func (s *modelManagerSuite) TestDumpModelV2(c *gc.C) {
 				&modelmanager.ModelManagerAPIV5{
 					&modelmanager.ModelManagerAPIV6{
 						&modelmanager.ModelManagerAPIV7{
-							s.api,
+							&modelmanager.ModelManagerAPIV8{
+								&modelmanager.ModelManagerAPIV9{&modelmanager.ModelManagerAPIV10{s.api}},
+							},
 						},
 					},
 				},
@@ -848,7 +853,9 @@ func (s *modelManagerSuite) TestDestroyModelsV3(c *gc.C) {
 			&modelmanager.ModelManagerAPIV5{
 				&modelmanager.ModelManagerAPIV6{
 					&modelmanager.ModelManagerAPIV7{
-						s.api,
+						&modelmanager.ModelManagerAPIV8{
+							&modelmanager.ModelManagerAPIV9{&modelmanager.ModelManagerAPIV10{s.api}},
+						},
 					},
 				},
 			},
@@ -919,6 +926,7 @@ func (s *modelManagerStateSuite) setAPIUser(c *gc.C, user names.UserTag) {
 		s.authoriser,
 		s.Model,
 		s.callContext,
+		nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	s.modelmanager = modelmanager
@@ -933,6 +941,7 @@ func (s *modelManagerStateSuite) TestNewAPIAcceptsClient(c *gc.C) {
 		nil, nil, anAuthoriser,
 		s.Model,
 		s.callContext,
+		nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(endPoint, gc.NotNil)
@@ -946,6 +955,7 @@ func (s *modelManagerStateSuite) TestNewAPIRefusesNonClient(c *gc.C) {
 		common.NewModelManagerBackend(s.Model, s.StatePool),
 		nil, nil, anAuthoriser, s.Model,
 		s.callContext,
+		nil,
 	)
 	c.Assert(endPoint, gc.IsNil)
 	c.Assert(err, gc.ErrorMatches, "permission denied")
@@ -1150,6 +1160,7 @@ func (s *modelManagerStateSuite) TestDestroyOwnModel(c *gc.C) {
 		nil, nil, s.authoriser,
 		s.Model,
 		s.callContext,
+		nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -1188,6 +1199,7 @@ func (s *modelManagerStateSuite) TestAdminDestroysOtherModel(c *gc.C) {
 		nil, nil, s.authoriser,
 		s.Model,
 		s.callContext,
+		nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -1223,6 +1235,7 @@ func (s *modelManagerStateSuite) TestDestroyModelErrors(c *gc.C) {
 		common.NewModelManagerBackend(s.Model, s.StatePool),
 		nil, nil, s.authoriser, s.Model,
 		s.callContext,
+		nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 
@@ -1662,6 +1675,7 @@ func (s *modelManagerStateSuite) TestModelInfoForMigratedModel(c *gc.C) {
 		nil, nil, anAuthoriser,
 		s.Model,
 		s.callContext,
+		nil,
 	)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(endPoint, gc.NotNil)
@@ -1704,7 +1718,9 @@ func (s *modelManagerSuite) TestModelStatusV2(c *gc.C) {
 				&modelmanager.ModelManagerAPIV5{
 					&modelmanager.ModelManagerAPIV6{
 						&modelmanager.ModelManagerAPIV7{
-							s.api,
+							&modelmanager.ModelManagerAPIV8{
+								&modelmanager.ModelManagerAPIV9{&modelmanager.ModelManagerAPIV10{s.api}},
+							},
 						},
 					},
 				},
@@ -1743,7 +1759,9 @@ func (s *modelManagerSuite) TestModelStatusV3(c *gc.C) {
 			&modelmanager.ModelManagerAPIV5{
 				&modelmanager.ModelManagerAPIV6{
 					&modelmanager.ModelManagerAPIV7{
-						s.api,
+						&modelmanager.ModelManagerAPIV8{
+							&modelmanager.ModelManagerAPIV9{&modelmanager.ModelManagerAPIV10{s.api}},
+						},
 					},
 				},
 			},
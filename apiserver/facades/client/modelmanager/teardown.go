@@ -0,0 +1,112 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelmanager
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state/watcher"
+)
+
+// WatchModelTeardown returns a NotifyWatcher for each of the given
+// models, which triggers whenever the progress of tearing down the
+// model may have changed. The model's resources and their status can
+// then be read with ModelStatus. The watcher stops with an error once
+// the model has been removed.
+func (m *ModelManagerAPI) WatchModelTeardown(args params.Entities) (params.NotifyWatchResults, error) {
+	results := params.NotifyWatchResults{
+		Results: make([]params.NotifyWatchResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		id, err := m.watchModelTeardown(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].NotifyWatcherId = id
+	}
+	return results, nil
+}
+
+func (m *ModelManagerAPI) watchModelTeardown(tag string) (string, error) {
+	modelTag, err := names.ParseModelTag(tag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	st := m.state
+	if modelTag != m.state.ModelTag() {
+		otherSt, releaser, err := m.state.GetBackend(modelTag.Id())
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		defer releaser()
+		st = otherSt
+	}
+	model, err := st.Model()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	isAdmin, err := common.HasModelAdmin(m.authorizer, m.apiUser, m.state.ControllerTag(), model)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if !isAdmin {
+		return "", common.ErrPerm
+	}
+
+	w := st.WatchModelTeardown()
+	if _, ok := <-w.Changes(); !ok {
+		return "", watcher.EnsureErr(w)
+	}
+	return m.resources.Register(w), nil
+}
+
+// AbandonedModelResources returns, for each of the given models, the
+// cloud resources which were removed from the model without the
+// provider confirming their release, and which may need to be cleaned
+// up manually. The records are kept after the model has been removed,
+// so only controller superusers and the owner of the model may read
+// them.
+func (m *ModelManagerAPI) AbandonedModelResources(args params.Entities) (params.AbandonedResourcesResults, error) {
+	results := params.AbandonedResourcesResults{
+		Results: make([]params.AbandonedResourcesResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		resources, err := m.abandonedModelResources(arg.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Resources = resources
+	}
+	return results, nil
+}
+
+func (m *ModelManagerAPI) abandonedModelResources(tag string) ([]params.AbandonedResource, error) {
+	modelTag, err := names.ParseModelTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	abandoned, err := m.ctlrState.AbandonedResources(modelTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]params.AbandonedResource, len(abandoned))
+	for i, r := range abandoned {
+		if !m.isAdmin && r.ModelOwner != m.apiUser.Id() {
+			return nil, common.ErrPerm
+		}
+		result[i] = params.AbandonedResource{
+			Kind:       string(r.Kind),
+			Id:         r.Id,
+			ProviderId: r.ProviderId,
+			Reason:     r.Reason,
+			Time:       r.Time,
+		}
+	}
+	return result, nil
+}
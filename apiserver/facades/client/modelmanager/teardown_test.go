@@ -0,0 +1,130 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelmanager_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+func (s *modelManagerSuite) TestWatchModelTeardown(c *gc.C) {
+	ch := make(chan struct{}, 1)
+	ch <- struct{}{}
+	s.st.teardownWatcher = statetesting.NewMockNotifyWatcher(ch)
+
+	results, err := s.api.WatchModelTeardown(params.Entities{
+		Entities: []params.Entity{{Tag: coretesting.ModelTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].NotifyWatcherId, gc.Equals, "1")
+	c.Assert(s.resources.Get("1"), gc.Equals, s.st.teardownWatcher)
+}
+
+func (s *modelManagerSuite) TestWatchModelTeardownNotAdmin(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("bob"))
+	s.st.teardownWatcher = statetesting.NewMockNotifyWatcher(make(chan struct{}))
+
+	results, err := s.api.WatchModelTeardown(params.Entities{
+		Entities: []params.Entity{{Tag: coretesting.ModelTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+}
+
+func (s *modelManagerSuite) TestWatchModelTeardownInvalidTag(c *gc.C) {
+	results, err := s.api.WatchModelTeardown(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `"machine-0" is not a valid model tag`)
+}
+
+func (s *modelManagerSuite) TestAbandonedModelResources(c *gc.C) {
+	now := time.Now()
+	s.ctlrSt.abandoned = []state.AbandonedResource{{
+		ModelUUID:  coretesting.ModelTag.Id(),
+		ModelOwner: "bob",
+		Kind:       state.AbandonedMachine,
+		Id:         "0",
+		ProviderId: "inst-0",
+		Reason:     "machine removed by force before its instance was stopped",
+		Time:       now,
+	}, {
+		ModelUUID:  coretesting.ModelTag.Id(),
+		ModelOwner: "bob",
+		Kind:       state.AbandonedEnviron,
+		Id:         coretesting.ModelTag.Id(),
+		Reason:     "boom",
+		Time:       now,
+	}}
+
+	results, err := s.api.AbandonedModelResources(params.Entities{
+		Entities: []params.Entity{{Tag: coretesting.ModelTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.AbandonedResourcesResults{
+		Results: []params.AbandonedResourcesResult{{
+			Resources: []params.AbandonedResource{{
+				Kind:       "machine",
+				Id:         "0",
+				ProviderId: "inst-0",
+				Reason:     "machine removed by force before its instance was stopped",
+				Time:       now,
+			}, {
+				Kind:   "environ",
+				Id:     coretesting.ModelTag.Id(),
+				Reason: "boom",
+				Time:   now,
+			}},
+		}},
+	})
+}
+
+func (s *modelManagerSuite) TestAbandonedModelResourcesOwner(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("bob"))
+	s.ctlrSt.abandoned = []state.AbandonedResource{{
+		ModelOwner: "bob",
+		Kind:       state.AbandonedNamespace,
+		Id:         "mymodel",
+		Reason:     "boom",
+	}}
+
+	results, err := s.api.AbandonedModelResources(params.Entities{
+		Entities: []params.Entity{{Tag: coretesting.ModelTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Resources, gc.HasLen, 1)
+}
+
+func (s *modelManagerSuite) TestAbandonedModelResourcesNotOwner(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("mary"))
+	s.ctlrSt.abandoned = []state.AbandonedResource{{
+		ModelOwner: "bob",
+		Kind:       state.AbandonedNamespace,
+		Id:         "mymodel",
+		Reason:     "boom",
+	}}
+
+	results, err := s.api.AbandonedModelResources(params.Entities{
+		Entities: []params.Entity{{Tag: coretesting.ModelTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
+}
@@ -0,0 +1,135 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelmanager
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// AddModelTemplates adds the given model templates to the controller.
+// Only controller superusers may add templates.
+func (m *ModelManagerAPI) AddModelTemplates(args params.ModelTemplateArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Templates)),
+	}
+	if !m.isAdmin {
+		return results, common.ErrPerm
+	}
+	if err := m.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Templates {
+		results.Results[i].Error = common.ServerError(m.addModelTemplate(arg))
+	}
+	return results, nil
+}
+
+func (m *ModelManagerAPI) addModelTemplate(arg params.ModelTemplate) error {
+	if _, ok := arg.Config[config.NameKey]; ok {
+		return errors.New("name must not be specified in template config")
+	}
+	if _, ok := arg.Config[config.UUIDKey]; ok {
+		return errors.New("uuid must not be specified in template config")
+	}
+	t := state.ModelTemplate{
+		Name:         arg.Name,
+		Config:       arg.Config,
+		Constraints:  arg.Constraints,
+		DefaultSpace: arg.DefaultSpace,
+		CreatedBy:    m.apiUser.Id(),
+	}
+	if arg.CloudCredentialTag != "" {
+		tag, err := names.ParseCloudCredentialTag(arg.CloudCredentialTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		t.CloudCredential = tag.Id()
+	}
+	return errors.Trace(m.ctlrState.AddModelTemplate(t))
+}
+
+// ModelTemplates returns the model templates in the controller.
+func (m *ModelManagerAPI) ModelTemplates() (params.ModelTemplatesResult, error) {
+	templates, err := m.ctlrState.ModelTemplates()
+	if err != nil {
+		return params.ModelTemplatesResult{}, errors.Trace(err)
+	}
+	result := params.ModelTemplatesResult{
+		Templates: make([]params.ModelTemplate, len(templates)),
+	}
+	for i, t := range templates {
+		result.Templates[i] = params.ModelTemplate{
+			Name:         t.Name,
+			Config:       t.Config,
+			Constraints:  t.Constraints,
+			DefaultSpace: t.DefaultSpace,
+			CreatedBy:    t.CreatedBy,
+			Created:      t.Created,
+		}
+		if t.CloudCredential != "" {
+			result.Templates[i].CloudCredentialTag = names.NewCloudCredentialTag(t.CloudCredential).String()
+		}
+	}
+	return result, nil
+}
+
+// RemoveModelTemplates removes the named model templates from the
+// controller. Models already created from the templates are not
+// affected. Only controller superusers may remove templates.
+func (m *ModelManagerAPI) RemoveModelTemplates(args params.ModelTemplateNames) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Names)),
+	}
+	if !m.isAdmin {
+		return results, common.ErrPerm
+	}
+	if err := m.check.ChangeAllowed(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, name := range args.Names {
+		results.Results[i].Error = common.ServerError(m.ctlrState.RemoveModelTemplate(name))
+	}
+	return results, nil
+}
+
+// applyModelTemplate returns the model creation arguments with the
+// settings of the named template applied, along with the template's
+// constraints. Settings given in the arguments take precedence.
+func (m *ModelManagerAPI) applyModelTemplate(args params.ModelCreateArgs) (params.ModelCreateArgs, constraints.Value, error) {
+	t, err := m.ctlrState.ModelTemplate(args.Template)
+	if err != nil {
+		return args, constraints.Value{}, errors.Trace(err)
+	}
+	attrs := make(map[string]interface{})
+	for key, value := range t.Config {
+		attrs[key] = value
+	}
+	if t.DefaultSpace != "" {
+		attrs[config.DefaultSpace] = t.DefaultSpace
+	}
+	for key, value := range args.Config {
+		attrs[key] = value
+	}
+	args.Config = attrs
+
+	if args.CloudCredentialTag == "" && t.CloudCredential != "" {
+		tag := names.NewCloudCredentialTag(t.CloudCredential)
+		if args.CloudTag == "" {
+			args.CloudTag = tag.Cloud().String()
+		}
+		// Only use the template's credential if it is for the cloud
+		// the model is being created on.
+		if args.CloudTag == tag.Cloud().String() {
+			args.CloudCredentialTag = tag.String()
+		}
+	}
+	return args, t.Constraints, nil
+}
@@ -0,0 +1,167 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelmanager_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/state"
+)
+
+func (s *modelManagerSuite) TestAddModelTemplates(c *gc.C) {
+	results, err := s.api.AddModelTemplates(params.ModelTemplateArgs{
+		Templates: []params.ModelTemplate{{
+			Name:               "web",
+			Config:             map[string]interface{}{"bar": "baz"},
+			Constraints:        constraints.MustParse("mem=4G"),
+			DefaultSpace:       "dmz",
+			CloudCredentialTag: "cloudcred-some-cloud_admin_some-credential",
+		}, {
+			Name:   "bad",
+			Config: map[string]interface{}{"name": "foo"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, "name must not be specified in template config")
+
+	s.ctlrSt.CheckCall(c, 0, "AddModelTemplate", state.ModelTemplate{
+		Name:            "web",
+		Config:          map[string]interface{}{"bar": "baz"},
+		Constraints:     constraints.MustParse("mem=4G"),
+		DefaultSpace:    "dmz",
+		CloudCredential: "some-cloud/admin/some-credential",
+		CreatedBy:       "admin",
+	})
+}
+
+func (s *modelManagerSuite) TestAddModelTemplatesNotAdmin(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("bob"))
+	_, err := s.api.AddModelTemplates(params.ModelTemplateArgs{
+		Templates: []params.ModelTemplate{{Name: "web"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.ctlrSt.CheckNoCalls(c)
+}
+
+func (s *modelManagerSuite) TestAddModelTemplatesBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestAddModelTemplatesBlocked")
+	_, err := s.api.AddModelTemplates(params.ModelTemplateArgs{})
+	s.assertBlocked(c, err, "TestAddModelTemplatesBlocked")
+}
+
+func (s *modelManagerSuite) TestModelTemplates(c *gc.C) {
+	created := time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC)
+	s.ctlrSt.templates = []state.ModelTemplate{{
+		Name:            "web",
+		Config:          map[string]interface{}{"bar": "baz"},
+		Constraints:     constraints.MustParse("mem=4G"),
+		CloudCredential: "some-cloud/admin/some-credential",
+		CreatedBy:       "admin",
+		Created:         created,
+	}}
+	s.setAPIUser(c, names.NewUserTag("bob"))
+	result, err := s.api.ModelTemplates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelTemplatesResult{
+		Templates: []params.ModelTemplate{{
+			Name:               "web",
+			Config:             map[string]interface{}{"bar": "baz"},
+			Constraints:        constraints.MustParse("mem=4G"),
+			CloudCredentialTag: "cloudcred-some-cloud_admin_some-credential",
+			CreatedBy:          "admin",
+			Created:            created,
+		}},
+	})
+}
+
+func (s *modelManagerSuite) TestRemoveModelTemplates(c *gc.C) {
+	s.ctlrSt.SetErrors(nil, errors.NotFoundf(`model template "db"`))
+	results, err := s.api.RemoveModelTemplates(params.ModelTemplateNames{
+		Names: []string{"web", "db"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `model template "db" not found`)
+	s.ctlrSt.CheckCall(c, 0, "RemoveModelTemplate", "web")
+	s.ctlrSt.CheckCall(c, 1, "RemoveModelTemplate", "db")
+}
+
+func (s *modelManagerSuite) TestRemoveModelTemplatesNotAdmin(c *gc.C) {
+	s.setAPIUser(c, names.NewUserTag("bob"))
+	_, err := s.api.RemoveModelTemplates(params.ModelTemplateNames{Names: []string{"web"}})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.ctlrSt.CheckNoCalls(c)
+}
+
+func (s *modelManagerSuite) TestCreateModelFromTemplate(c *gc.C) {
+	s.ctlrSt.templates = []state.ModelTemplate{{
+		Name: "web",
+		Config: map[string]interface{}{
+			"bar":  "template",
+			"quux": "template",
+		},
+		Constraints:     constraints.MustParse("mem=4G"),
+		DefaultSpace:    "dmz",
+		CloudCredential: "some-cloud/admin/some-credential",
+	}}
+	_, err := s.api.CreateModel(params.ModelCreateArgs{
+		Name:     "foo",
+		OwnerTag: "user-admin",
+		Config: map[string]interface{}{
+			"bar": "baz",
+		},
+		Template: "web",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newModelArgs := s.getModelArgs(c)
+	attrs := newModelArgs.Config.AllAttrs()
+	c.Assert(attrs["bar"], gc.Equals, "baz")
+	c.Assert(attrs["quux"], gc.Equals, "template")
+	c.Assert(newModelArgs.Config.DefaultSpace(), gc.Equals, "dmz")
+	c.Assert(newModelArgs.Constraints, jc.DeepEquals, constraints.MustParse("mem=4G"))
+	c.Assert(newModelArgs.CloudName, gc.Equals, "some-cloud")
+	c.Assert(newModelArgs.CloudCredential, gc.Equals, names.NewCloudCredentialTag(
+		"some-cloud/admin/some-credential",
+	))
+}
+
+func (s *modelManagerSuite) TestCreateModelFromTemplateCredentialOverride(c *gc.C) {
+	s.ctlrSt.templates = []state.ModelTemplate{{
+		Name:            "web",
+		CloudCredential: "some-cloud/admin/some-credential",
+	}}
+	_, err := s.api.CreateModel(params.ModelCreateArgs{
+		Name:               "foo",
+		OwnerTag:           "user-admin",
+		CloudCredentialTag: "cloudcred-some-cloud_admin_other",
+		Template:           "web",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	newModelArgs := s.getModelArgs(c)
+	c.Assert(newModelArgs.CloudCredential, gc.Equals, names.NewCloudCredentialTag(
+		"some-cloud/admin/other",
+	))
+}
+
+func (s *modelManagerSuite) TestCreateModelTemplateNotFound(c *gc.C) {
+	_, err := s.api.CreateModel(params.ModelCreateArgs{
+		Name:     "foo",
+		OwnerTag: "user-admin",
+		Template: "missing",
+	})
+	c.Assert(err, gc.ErrorMatches, `applying model template: model template "missing" not found`)
+	c.Assert(errors.IsNotFound(err), jc.IsTrue)
+}
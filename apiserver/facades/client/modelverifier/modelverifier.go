@@ -0,0 +1,88 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelverifier provides the API server facade for checking
+// the consistency of the documents describing a model's machines,
+// units, applications, relations and statuses.
+package modelverifier
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// ModelVerifier facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+
+	// VerifyModel returns the inconsistencies between the model's
+	// documents. See state.State.VerifyModel.
+	VerifyModel() ([]state.ModelInconsistency, error)
+}
+
+type stateShim struct {
+	*state.State
+}
+
+func (s stateShim) ModelTag() names.ModelTag {
+	return names.NewModelTag(s.State.ModelUUID())
+}
+
+// API implements the ModelVerifier facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewFacade creates a new ModelVerifier API facade.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(stateShim{ctx.State()}, ctx.Auth())
+}
+
+// NewAPI returns a new ModelVerifier API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+// VerifyModel checks the references between the documents of the
+// model's machines, units, applications, relations and statuses, and
+// returns the inconsistencies found with suggested repairs. Nothing is
+// changed. Only model admins may verify a model.
+func (api *API) VerifyModel() (params.VerifyModelResult, error) {
+	allowed, err := api.authorizer.HasPermission(permission.AdminAccess, api.backend.ModelTag())
+	if err != nil {
+		return params.VerifyModelResult{}, errors.Trace(err)
+	}
+	if !allowed {
+		return params.VerifyModelResult{}, common.ErrPerm
+	}
+	inconsistencies, err := api.backend.VerifyModel()
+	if err != nil {
+		return params.VerifyModelResult{}, errors.Trace(err)
+	}
+	result := params.VerifyModelResult{
+		Inconsistencies: make([]params.ModelInconsistency, len(inconsistencies)),
+	}
+	for i, inc := range inconsistencies {
+		result.Inconsistencies[i] = params.ModelInconsistency{
+			Collection: inc.Collection,
+			Id:         inc.ID,
+			Problem:    inc.Problem,
+			Repair:     inc.Repair,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,107 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelverifier_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/modelverifier"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ModelVerifierSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&ModelVerifierSuite{})
+
+func (s *ModelVerifierSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		inconsistencies: []state.ModelInconsistency{{
+			Collection: "units",
+			ID:         "mysql/0",
+			Problem:    `machine "3" not found`,
+			Repair:     `run "juju remove-unit --force mysql/0"`,
+		}},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+}
+
+func (s *ModelVerifierSuite) newAPI(c *gc.C) *modelverifier.API {
+	api, err := modelverifier.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *ModelVerifierSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := modelverifier.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *ModelVerifierSuite) TestVerifyModel(c *gc.C) {
+	result, err := s.newAPI(c).VerifyModel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.VerifyModelResult{
+		Inconsistencies: []params.ModelInconsistency{{
+			Collection: "units",
+			Id:         "mysql/0",
+			Problem:    `machine "3" not found`,
+			Repair:     `run "juju remove-unit --force mysql/0"`,
+		}},
+	})
+	s.backend.CheckCallNames(c, "ModelTag", "VerifyModel")
+}
+
+func (s *ModelVerifierSuite) TestVerifyModelConsistent(c *gc.C) {
+	s.backend.inconsistencies = nil
+	result, err := s.newAPI(c).VerifyModel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.VerifyModelResult{
+		Inconsistencies: []params.ModelInconsistency{},
+	})
+}
+
+func (s *ModelVerifierSuite) TestVerifyModelRequiresAdmin(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	_, err := s.newAPI(c).VerifyModel()
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckCallNames(c, "ModelTag")
+}
+
+func (s *ModelVerifierSuite) TestVerifyModelError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	_, err := s.newAPI(c).VerifyModel()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockBackend struct {
+	testing.Stub
+	inconsistencies []state.ModelInconsistency
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	b.MethodCall(b, "ModelTag")
+	b.PopNoErr()
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) VerifyModel() ([]state.ModelInconsistency, error) {
+	b.MethodCall(b, "VerifyModel")
+	return b.inconsistencies, b.NextErr()
+}
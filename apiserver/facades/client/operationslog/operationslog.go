@@ -0,0 +1,93 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package operationslog provides the API server facade for querying
+// the changes recorded in a model's operations log, such as actions,
+// upgrades, migrations and branch commits.
+package operationslog
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// OperationsLog facade. For details on the methods, see the methods
+// on state.State with the same names.
+type Backend interface {
+	ModelTag() names.ModelTag
+	Operations(since time.Time, kinds ...state.OperationKind) ([]state.OperationRecord, error)
+}
+
+type stateShim struct {
+	*state.State
+}
+
+func (s stateShim) ModelTag() names.ModelTag {
+	return names.NewModelTag(s.State.ModelUUID())
+}
+
+// API implements the OperationsLog facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewFacade creates a new OperationsLog API facade.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(stateShim{ctx.State()}, ctx.Auth())
+}
+
+// NewAPI returns a new OperationsLog API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+// Operations returns the changes recorded in the model's operations
+// log since the given time, optionally limited to changes of the given
+// kinds, in the order they were made.
+func (api *API) Operations(args params.OperationsLogArgs) (params.OperationsLogResult, error) {
+	result := params.OperationsLogResult{
+		Operations: []params.OperationsLogEntry{},
+	}
+	allowed, err := api.authorizer.HasPermission(permission.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if !allowed {
+		return result, common.ErrPerm
+	}
+	kinds := make([]state.OperationKind, len(args.Kinds))
+	for i, kind := range args.Kinds {
+		kinds[i] = state.OperationKind(kind)
+	}
+	operations, err := api.backend.Operations(args.Since, kinds...)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, op := range operations {
+		result.Operations = append(result.Operations, params.OperationsLogEntry{
+			Id:      op.Id,
+			Kind:    string(op.Kind),
+			Summary: op.Summary,
+			Who:     op.Who,
+			Ref:     op.Ref,
+			Time:    op.Time,
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package operationslog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/operationslog"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type OperationsLogSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	now        time.Time
+}
+
+var _ = gc.Suite(&OperationsLogSuite{})
+
+func (s *OperationsLogSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.now = time.Date(2020, 1, 3, 14, 0, 0, 0, time.UTC)
+	s.backend = &mockBackend{
+		operations: []state.OperationRecord{{
+			Id:      "1",
+			Kind:    state.OperationUpgrade,
+			Summary: "upgrade model from 2.7.0 to 2.7.1",
+			Time:    s.now,
+		}, {
+			Id:      "2",
+			Kind:    state.OperationBranchCommit,
+			Summary: `commit branch "test"`,
+			Who:     "mary",
+			Ref:     "3",
+			Time:    s.now.Add(time.Minute),
+		}},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	}
+}
+
+func (s *OperationsLogSuite) newAPI(c *gc.C) *operationslog.API {
+	api, err := operationslog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *OperationsLogSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := operationslog.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *OperationsLogSuite) TestOperations(c *gc.C) {
+	since := s.now.Add(-24 * time.Hour)
+	result, err := s.newAPI(c).Operations(params.OperationsLogArgs{
+		Since: since,
+		Kinds: []string{"upgrade", "branch-commit"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCall(c, 0, "Operations", since, []state.OperationKind{
+		state.OperationUpgrade, state.OperationBranchCommit,
+	})
+	c.Assert(result, jc.DeepEquals, params.OperationsLogResult{
+		Operations: []params.OperationsLogEntry{{
+			Id:      "1",
+			Kind:    "upgrade",
+			Summary: "upgrade model from 2.7.0 to 2.7.1",
+			Time:    s.now,
+		}, {
+			Id:      "2",
+			Kind:    "branch-commit",
+			Summary: `commit branch "test"`,
+			Who:     "mary",
+			Ref:     "3",
+			Time:    s.now.Add(time.Minute),
+		}},
+	})
+}
+
+func (s *OperationsLogSuite) TestOperationsPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("someoneelse")
+	_, err := s.newAPI(c).Operations(params.OperationsLogArgs{})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *OperationsLogSuite) TestOperationsError(c *gc.C) {
+	s.backend.SetErrors(errors.NotValidf(`operation kind "deploy"`))
+	_, err := s.newAPI(c).Operations(params.OperationsLogArgs{Kinds: []string{"deploy"}})
+	c.Assert(err, gc.ErrorMatches, `operation kind "deploy" not valid`)
+}
+
+type mockBackend struct {
+	testing.Stub
+	operations []state.OperationRecord
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) Operations(since time.Time, kinds ...state.OperationKind) ([]state.OperationRecord, error) {
+	b.MethodCall(b, "Operations", since, kinds)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return b.operations, nil
+}
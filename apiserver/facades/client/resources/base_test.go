@@ -11,20 +11,25 @@ import (
 	"github.com/juju/testing"
 	gc "gopkg.in/check.v1"
 	charmresource "gopkg.in/juju/charm.v6/resource"
+	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/apiserver/facades/client/resources"
 	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
 	"github.com/juju/juju/charmstore"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/resourcetesting"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type BaseSuite struct {
 	testing.IsolationSuite
 
-	stub     *testing.Stub
-	data     *stubDataStore
-	csClient *stubCSClient
+	stub       *testing.Stub
+	data       *stubDataStore
+	csClient   *stubCSClient
+	authorizer apiservertesting.FakeAuthorizer
+	modelTag   names.ModelTag
 }
 
 func (s *BaseSuite) SetUpTest(c *gc.C) {
@@ -33,6 +38,10 @@ func (s *BaseSuite) SetUpTest(c *gc.C) {
 	s.stub = &testing.Stub{}
 	s.data = &stubDataStore{stub: s.stub}
 	s.csClient = &stubCSClient{Stub: s.stub}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	s.modelTag = coretesting.ModelTag
 }
 
 func (s *BaseSuite) newCSClient() (resources.CharmStore, error) {
@@ -80,6 +89,8 @@ type stubDataStore struct {
 	ReturnGetPendingResource    resource.Resource
 	ReturnSetResource           resource.Resource
 	ReturnUpdatePendingResource resource.Resource
+	ReturnResourceHistory       []resource.AttachedRevision
+	ReturnRollbackResource      resource.Resource
 }
 
 func (s *stubDataStore) OpenResource(application, name string) (resource.Resource, io.ReadCloser, error) {
@@ -144,6 +155,24 @@ func (s *stubDataStore) UpdatePendingResource(applicationID, pendingID, userID s
 	return s.ReturnUpdatePendingResource, nil
 }
 
+func (s *stubDataStore) ResourceHistory(applicationID, name string) ([]resource.AttachedRevision, error) {
+	s.stub.AddCall("ResourceHistory", applicationID, name)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.ReturnResourceHistory, nil
+}
+
+func (s *stubDataStore) RollbackResource(applicationID, name string, revision int) (resource.Resource, error) {
+	s.stub.AddCall("RollbackResource", applicationID, name, revision)
+	if err := s.stub.NextErr(); err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+
+	return s.ReturnRollbackResource, nil
+}
+
 type stubCSClient struct {
 	*testing.Stub
 
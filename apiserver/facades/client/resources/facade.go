@@ -16,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/api"
 	"github.com/juju/juju/state"
@@ -33,6 +34,14 @@ type Backend interface {
 	// it is resolved. The returned ID is used to identify the pending
 	// resources when resolving it.
 	AddPendingResource(applicationID, userID string, chRes charmresource.Resource) (string, error)
+
+	// ResourceHistory returns the revisions of the content attached to
+	// the identified resource, newest first.
+	ResourceHistory(applicationID, name string) ([]resource.AttachedRevision, error)
+
+	// RollbackResource makes the identified previous revision of the
+	// content attached to the resource the one the application uses.
+	RollbackResource(applicationID, name string, revision int) (resource.Resource, error)
 }
 
 // CharmStore exposes the functionality of the charm store as needed here.
@@ -53,6 +62,24 @@ type Facade struct {
 	store Backend
 
 	newCharmstoreClient func() (CharmStore, error)
+
+	authorizer facade.Authorizer
+	modelTag   names.ModelTag
+}
+
+// FacadeV1 is version 1 of the public API facade for resources.
+type FacadeV1 struct {
+	*Facade
+}
+
+// NewPublicFacadeV1 creates version 1 of the public API facade for
+// resources. It is used for API registration.
+func NewPublicFacadeV1(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*FacadeV1, error) {
+	f, err := NewPublicFacade(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &FacadeV1{f}, nil
 }
 
 // NewPublicFacade creates a public API facade for resources. It is
@@ -73,7 +100,7 @@ func NewPublicFacade(st *state.State, _ facade.Resources, authorizer facade.Auth
 	newClient := func() (CharmStore, error) {
 		return charmstore.NewCachingClient(state.MacaroonCache{st}, controllerCfg.CharmStoreURL())
 	}
-	facade, err := NewFacade(rst, newClient)
+	facade, err := NewFacade(rst, newClient, authorizer, names.NewModelTag(st.ModelUUID()))
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -81,10 +108,13 @@ func NewPublicFacade(st *state.State, _ facade.Resources, authorizer facade.Auth
 }
 
 // NewFacade returns a new resoures API facade.
-func NewFacade(store Backend, newClient func() (CharmStore, error)) (*Facade, error) {
+func NewFacade(store Backend, newClient func() (CharmStore, error), authorizer facade.Authorizer, modelTag names.ModelTag) (*Facade, error) {
 	if store == nil {
 		return nil, errors.Errorf("missing data store")
 	}
+	if authorizer == nil {
+		return nil, errors.Errorf("missing authorizer")
+	}
 	if newClient == nil {
 		// Technically this only matters for one code path through
 		// AddPendingResources(). However, that functionality should be
@@ -96,6 +126,8 @@ func NewFacade(store Backend, newClient func() (CharmStore, error)) (*Facade, er
 	f := &Facade{
 		store:               store,
 		newCharmstoreClient: newClient,
+		authorizer:          authorizer,
+		modelTag:            modelTag,
 	}
 	return f, nil
 }
@@ -128,6 +160,73 @@ func (f Facade) ListResources(args params.ListResourcesArgs) (params.ResourcesRe
 	return r, nil
 }
 
+// ResourceHistory returns the revisions of the content attached to each
+// of the given resources, newest first. Previous revisions are kept,
+// until pruned, so that applications can be rolled back to them.
+func (f Facade) ResourceHistory(args params.ResourceRevisionArgs) (params.ResourceHistoryResults, error) {
+	results := params.ResourceHistoryResults{
+		Results: make([]params.ResourceHistoryResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		tag, apiErr := parseApplicationTag(arg.Tag)
+		if apiErr != nil {
+			results.Results[i].Error = apiErr
+			continue
+		}
+		revisions, err := f.store.ResourceHistory(tag.Id(), arg.Name)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		apiRevisions := make([]params.ResourceRevision, len(revisions))
+		for j, rev := range revisions {
+			apiRevisions[j] = api.AttachedRevision2API(rev)
+		}
+		results.Results[i].Revisions = apiRevisions
+	}
+	return results, nil
+}
+
+// RollbackResources makes the given previous revisions of the content
+// attached to resources the ones their applications use.
+func (f Facade) RollbackResources(args params.ResourceRevisionArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	if err := f.checkCanWrite(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		tag, apiErr := parseApplicationTag(arg.Tag)
+		if apiErr != nil {
+			results.Results[i].Error = apiErr
+			continue
+		}
+		logger.Infof("rolling back resource %q of application %q to revision %d", arg.Name, tag.Id(), arg.Revision)
+		if _, err := f.store.RollbackResource(tag.Id(), arg.Name, arg.Revision); err != nil {
+			results.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return results, nil
+}
+
+func (f Facade) checkCanWrite() error {
+	canWrite, err := f.authorizer.HasPermission(permission.WriteAccess, f.modelTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canWrite {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// ResourceHistory isn't on the V1 API.
+func (*FacadeV1) ResourceHistory(_, _ struct{}) {}
+
+// RollbackResources isn't on the V1 API.
+func (*FacadeV1) RollbackResources(_, _ struct{}) {}
+
 // AddPendingResources adds the provided resources (info) to the Juju
 // model in a pending state, meaning they are not available until
 // resolved.
@@ -0,0 +1,108 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resources_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/resources"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/resource"
+)
+
+var _ = gc.Suite(&ResourceHistorySuite{})
+
+type ResourceHistorySuite struct {
+	BaseSuite
+}
+
+func (s *ResourceHistorySuite) TestResourceHistory(c *gc.C) {
+	res1, apiRes1 := newResource(c, "spam", "a-user", "spamspamspam")
+	res2, apiRes2 := newResource(c, "spam", "a-user", "eggs")
+	s.data.ReturnResourceHistory = []resource.AttachedRevision{
+		{Resource: res2, Number: 2},
+		{Resource: res1, Number: 1, Current: true},
+	}
+	facade, err := resources.NewFacade(s.data, s.newCSClient, s.authorizer, s.modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := facade.ResourceHistory(params.ResourceRevisionArgs{
+		Args: []params.ResourceRevisionArg{{
+			Tag:  "application-a-application",
+			Name: "spam",
+		}, {
+			Tag:  "machine-0",
+			Name: "spam",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCall(c, 0, "ResourceHistory", "a-application", "spam")
+	c.Check(results, jc.DeepEquals, params.ResourceHistoryResults{
+		Results: []params.ResourceHistoryResult{{
+			Revisions: []params.ResourceRevision{
+				{Resource: apiRes2, Number: 2},
+				{Resource: apiRes1, Number: 1, Current: true},
+			},
+		}, {
+			ErrorResult: params.ErrorResult{Error: &params.Error{
+				Message: `"machine-0" is not a valid application tag`,
+				Code:    params.CodeBadRequest,
+			}},
+		}},
+	})
+}
+
+func (s *ResourceHistorySuite) TestRollbackResources(c *gc.C) {
+	s.stub.SetErrors(nil, errors.NotFoundf(`revision 7 of resource "a-application/eggs"`))
+	facade, err := resources.NewFacade(s.data, s.newCSClient, s.authorizer, s.modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := facade.RollbackResources(params.ResourceRevisionArgs{
+		Args: []params.ResourceRevisionArg{{
+			Tag:      "application-a-application",
+			Name:     "spam",
+			Revision: 1,
+		}, {
+			Tag:      "application-a-application",
+			Name:     "eggs",
+			Revision: 7,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCalls(c, []testing.StubCall{
+		{"RollbackResource", []interface{}{"a-application", "spam", 1}},
+		{"RollbackResource", []interface{}{"a-application", "eggs", 7}},
+	})
+	c.Check(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}, {
+			Error: &params.Error{
+				Message: `revision 7 of resource "a-application/eggs" not found`,
+				Code:    params.CodeNotFound,
+			},
+		}},
+	})
+}
+
+func (s *ResourceHistorySuite) TestRollbackResourcesPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("read")
+	facade, err := resources.NewFacade(s.data, s.newCSClient, s.authorizer, s.modelTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = facade.RollbackResources(params.ResourceRevisionArgs{
+		Args: []params.ResourceRevisionArg{{
+			Tag:      "application-a-application",
+			Name:     "spam",
+			Revision: 1,
+		}},
+	})
+	c.Assert(errors.Cause(err), gc.Equals, common.ErrPerm)
+	s.stub.CheckNoCalls(c)
+}
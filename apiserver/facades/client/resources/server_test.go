@@ -17,16 +17,21 @@ type FacadeSuite struct {
 }
 
 func (s *FacadeSuite) TestNewFacadeOkay(c *gc.C) {
-	_, err := resources.NewFacade(s.data, s.newCSClient)
+	_, err := resources.NewFacade(s.data, s.newCSClient, s.authorizer, s.modelTag)
 	c.Check(err, jc.ErrorIsNil)
 }
 
 func (s *FacadeSuite) TestNewFacadeMissingDataStore(c *gc.C) {
-	_, err := resources.NewFacade(nil, s.newCSClient)
+	_, err := resources.NewFacade(nil, s.newCSClient, s.authorizer, s.modelTag)
 	c.Check(err, gc.ErrorMatches, `missing data store`)
 }
 
 func (s *FacadeSuite) TestNewFacadeMissingCSClientFactory(c *gc.C) {
-	_, err := resources.NewFacade(s.data, nil)
+	_, err := resources.NewFacade(s.data, nil, s.authorizer, s.modelTag)
 	c.Check(err, gc.ErrorMatches, `missing factory for new charm store clients`)
 }
+
+func (s *FacadeSuite) TestNewFacadeMissingAuthorizer(c *gc.C) {
+	_, err := resources.NewFacade(s.data, s.newCSClient, nil, s.modelTag)
+	c.Check(err, gc.ErrorMatches, `missing authorizer`)
+}
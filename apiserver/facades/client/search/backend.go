@@ -0,0 +1,197 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package search
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the Search
+// facade.
+type Backend interface {
+	// ModelBasicInfoForUser returns the models the user can see. See
+	// state.State.ModelBasicInfoForUser.
+	ModelBasicInfoForUser(user names.UserTag) ([]state.ModelAccessInfo, error)
+
+	// ModelUnits returns the units in the model with the given UUID,
+	// sorted by name.
+	ModelUnits(modelUUID string) ([]Unit, error)
+
+	// ModelMachines returns the machines in the model with the given
+	// UUID, sorted by ID.
+	ModelMachines(modelUUID string) ([]Machine, error)
+}
+
+// Unit holds the details of a unit returned by a search.
+type Unit struct {
+	Name           string
+	Machine        string
+	WorkloadStatus status.StatusInfo
+	Addresses      []string
+}
+
+// Machine holds the details of a machine returned by a search.
+type Machine struct {
+	Id          string
+	InstanceId  string
+	AgentStatus status.StatusInfo
+	Addresses   []string
+}
+
+type stateShim struct {
+	*state.State
+	pool *state.StatePool
+}
+
+// NewStateBackend returns a Backend which reads the models in the
+// given pool.
+func NewStateBackend(pool *state.StatePool) Backend {
+	return &stateShim{
+		State: pool.SystemState(),
+		pool:  pool,
+	}
+}
+
+// ModelUnits is part of the Backend interface.
+func (s *stateShim) ModelUnits(modelUUID string) ([]Unit, error) {
+	st, err := s.pool.Get(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer st.Release()
+
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The statuses of the whole model are loaded in one query, rather
+	// than one for each unit.
+	modelStatus, err := model.LoadModelStatus()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Units on machines have the addresses of their machine, so the
+	// machines are all loaded once up front.
+	machines, err := st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machineAddresses := make(map[string][]string)
+	for _, m := range machines {
+		machineAddresses[m.Id()] = addressValues(m.Addresses())
+	}
+	apps, err := st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var result []Unit
+	for _, app := range apps {
+		units, err := app.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		expectWorkload, err := expectsWorkload(model, app)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, unit := range units {
+			info := Unit{Name: unit.Name()}
+			if unit.ShouldBeAssigned() {
+				machineID, err := unit.AssignedMachineId()
+				if err != nil && !errors.IsNotAssigned(err) {
+					return nil, errors.Annotatef(err, "unit %q", unit.Name())
+				}
+				info.Machine = machineID
+				info.Addresses = machineAddresses[machineID]
+			} else {
+				addresses, err := unit.AllAddresses()
+				if err != nil {
+					return nil, errors.Annotatef(err, "unit %q", unit.Name())
+				}
+				info.Addresses = addressValues(addresses)
+			}
+			info.WorkloadStatus, err = modelStatus.UnitWorkload(unit.Name(), expectWorkload)
+			if err != nil {
+				return nil, errors.Annotatef(err, "unit %q", unit.Name())
+			}
+			result = append(result, info)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// ModelMachines is part of the Backend interface.
+func (s *stateShim) ModelMachines(modelUUID string) ([]Machine, error) {
+	st, err := s.pool.Get(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer st.Release()
+
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	modelStatus, err := model.LoadModelStatus()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machines, err := st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	result := make([]Machine, 0, len(machines))
+	for _, m := range machines {
+		info := Machine{
+			Id:        m.Id(),
+			Addresses: addressValues(m.Addresses()),
+		}
+		instId, err := m.InstanceId()
+		if err != nil && !errors.IsNotProvisioned(err) {
+			return nil, errors.Annotatef(err, "machine %q", m.Id())
+		}
+		info.InstanceId = string(instId)
+		info.AgentStatus, err = modelStatus.MachineAgent(m.Id())
+		if err != nil {
+			return nil, errors.Annotatef(err, "machine %q", m.Id())
+		}
+		result = append(result, info)
+	}
+	return result, nil
+}
+
+// expectsWorkload reports whether the units of the application are
+// expected to run a workload; in CAAS models they only do once the
+// application has a pod spec.
+func expectsWorkload(model *state.Model, app *state.Application) (bool, error) {
+	cm, err := model.CAASModel()
+	if err != nil {
+		return true, nil
+	}
+	_, err = cm.PodSpec(app.ApplicationTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return false, errors.Trace(err)
+	}
+	return err == nil, nil
+}
+
+func addressValues(addresses network.SpaceAddresses) []string {
+	var values []string
+	for _, addr := range addresses {
+		values = append(values, addr.Value)
+	}
+	return values
+}
@@ -0,0 +1,49 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package search_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/facades/client/search"
+	"github.com/juju/juju/state"
+)
+
+type mockBackend struct {
+	testing.Stub
+	models   []state.ModelAccessInfo
+	units    map[string][]search.Unit
+	machines map[string][]search.Machine
+}
+
+func (b *mockBackend) ModelBasicInfoForUser(user names.UserTag) ([]state.ModelAccessInfo, error) {
+	b.MethodCall(b, "ModelBasicInfoForUser", user)
+	return append([]state.ModelAccessInfo{}, b.models...), b.NextErr()
+}
+
+func (b *mockBackend) ModelUnits(modelUUID string) ([]search.Unit, error) {
+	b.MethodCall(b, "ModelUnits", modelUUID)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	units, ok := b.units[modelUUID]
+	if !ok {
+		return nil, errors.NotFoundf("model %q", modelUUID)
+	}
+	return units, nil
+}
+
+func (b *mockBackend) ModelMachines(modelUUID string) ([]search.Machine, error) {
+	b.MethodCall(b, "ModelMachines", modelUUID)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	machines, ok := b.machines[modelUUID]
+	if !ok {
+		return nil, errors.NotFoundf("model %q", modelUUID)
+	}
+	return machines, nil
+}
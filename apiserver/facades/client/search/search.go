@@ -0,0 +1,167 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package search provides the API server facade used to find units and
+// machines across all the models in the controller that a user can
+// see, such as the machine with an IP address given in an alert.
+package search
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.search")
+
+// API implements the Search facade.
+type API struct {
+	backend Backend
+	apiUser names.UserTag
+}
+
+// NewFacade creates a new Search API facade.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(NewStateBackend(ctx.StatePool()), ctx.Auth())
+}
+
+// NewAPI returns a new Search API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	// Since we know this is a user tag (because AuthClient is true),
+	// we just do the type assertion to the UserTag.
+	apiUser, _ := authorizer.GetAuthTag().(names.UserTag)
+	return &API{
+		backend: backend,
+		apiUser: apiUser,
+	}, nil
+}
+
+// FindUnits returns the units, in the models the user can see, whose
+// names match the pattern. A pattern without a "/" is matched against
+// the unit's application name.
+func (api *API) FindUnits(args params.FindUnitsArgs) (params.FoundEntities, error) {
+	result := params.FoundEntities{Entities: []params.FoundEntity{}}
+	if args.Pattern == "" {
+		return result, errors.NotValidf("empty pattern")
+	}
+	if _, err := path.Match(args.Pattern, ""); err != nil {
+		return result, errors.NewNotValid(err, "invalid pattern "+args.Pattern)
+	}
+	models, err := api.models()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, model := range models {
+		units, err := api.backend.ModelUnits(model.UUID)
+		if errors.IsNotFound(err) {
+			// The model was removed after it was listed.
+			logger.Debugf("skipping model %q: %v", model.UUID, err)
+			continue
+		} else if err != nil {
+			return result, errors.Annotatef(err, "model %q", model.Name)
+		}
+		for _, unit := range units {
+			if !matchUnit(args.Pattern, unit.Name) {
+				continue
+			}
+			entity := foundEntity(model, names.NewUnitTag(unit.Name))
+			entity.Status = common.EntityStatusFromState(unit.WorkloadStatus)
+			entity.Addresses = unit.Addresses
+			entity.Machine = unit.Machine
+			result.Entities = append(result.Entities, entity)
+		}
+	}
+	return result, nil
+}
+
+// FindMachines returns the machines, in the models the user can see,
+// which have an address or instance ID equal to the query.
+func (api *API) FindMachines(args params.FindMachinesArgs) (params.FoundEntities, error) {
+	result := params.FoundEntities{Entities: []params.FoundEntity{}}
+	if args.Query == "" {
+		return result, errors.NotValidf("empty query")
+	}
+	models, err := api.models()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, model := range models {
+		machines, err := api.backend.ModelMachines(model.UUID)
+		if errors.IsNotFound(err) {
+			// The model was removed after it was listed.
+			logger.Debugf("skipping model %q: %v", model.UUID, err)
+			continue
+		} else if err != nil {
+			return result, errors.Annotatef(err, "model %q", model.Name)
+		}
+		for _, machine := range machines {
+			if !matchMachine(args.Query, machine) {
+				continue
+			}
+			entity := foundEntity(model, names.NewMachineTag(machine.Id))
+			entity.Status = common.EntityStatusFromState(machine.AgentStatus)
+			entity.Addresses = machine.Addresses
+			entity.InstanceId = machine.InstanceId
+			result.Entities = append(result.Entities, entity)
+		}
+	}
+	return result, nil
+}
+
+// models returns the models the user can see, sorted by owner and
+// name. Controller superusers see all models.
+func (api *API) models() ([]state.ModelAccessInfo, error) {
+	models, err := api.backend.ModelBasicInfoForUser(api.apiUser)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Slice(models, func(i, j int) bool {
+		if models[i].Owner != models[j].Owner {
+			return models[i].Owner < models[j].Owner
+		}
+		return models[i].Name < models[j].Name
+	})
+	return models, nil
+}
+
+func foundEntity(model state.ModelAccessInfo, tag names.Tag) params.FoundEntity {
+	return params.FoundEntity{
+		ModelTag:      names.NewModelTag(model.UUID).String(),
+		ModelName:     model.Name,
+		ModelOwnerTag: names.NewUserTag(model.Owner).String(),
+		EntityTag:     tag.String(),
+	}
+}
+
+func matchUnit(pattern, unitName string) bool {
+	name := unitName
+	if !strings.Contains(pattern, "/") {
+		name = strings.SplitN(unitName, "/", 2)[0]
+	}
+	matched, _ := path.Match(pattern, name)
+	return matched
+}
+
+func matchMachine(query string, machine Machine) bool {
+	if machine.InstanceId != "" && machine.InstanceId == query {
+		return true
+	}
+	for _, addr := range machine.Addresses {
+		if addr == query {
+			return true
+		}
+	}
+	return false
+}
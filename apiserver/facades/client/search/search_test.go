@@ -0,0 +1,176 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package search_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/search"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+type SearchSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	api        *search.API
+}
+
+var _ = gc.Suite(&SearchSuite{})
+
+func (s *SearchSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		models: []state.ModelAccessInfo{
+			{UUID: "uuid-2", Name: "web", Owner: "fred"},
+			{UUID: "uuid-1", Name: "web", Owner: "bob"},
+			{UUID: "uuid-3", Name: "gone", Owner: "bob"},
+		},
+		units: map[string][]search.Unit{
+			"uuid-1": {{
+				Name:           "mysql/0",
+				Machine:        "0",
+				WorkloadStatus: status.StatusInfo{Status: status.Active},
+				Addresses:      []string{"10.0.0.1"},
+			}, {
+				Name:           "wordpress/0",
+				Machine:        "1",
+				WorkloadStatus: status.StatusInfo{Status: status.Error, Message: "hook failed"},
+				Addresses:      []string{"10.0.0.2"},
+			}},
+			"uuid-2": {{
+				Name:           "mysql/3",
+				Machine:        "2",
+				WorkloadStatus: status.StatusInfo{Status: status.Active},
+				Addresses:      []string{"10.0.1.3"},
+			}},
+		},
+		machines: map[string][]search.Machine{
+			"uuid-1": {{
+				Id:          "0",
+				InstanceId:  "i-0",
+				AgentStatus: status.StatusInfo{Status: status.Started},
+				Addresses:   []string{"10.0.0.1", "54.0.0.1"},
+			}, {
+				Id:          "1",
+				AgentStatus: status.StatusInfo{Status: status.Pending},
+			}},
+			"uuid-2": {{
+				Id:          "2",
+				InstanceId:  "i-2",
+				AgentStatus: status.StatusInfo{Status: status.Started},
+				Addresses:   []string{"10.0.1.3"},
+			}},
+		},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("bob"),
+	}
+	api, err := search.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *SearchSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := search.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *SearchSuite) TestFindUnitsByApplication(c *gc.C) {
+	result, err := s.api.FindUnits(params.FindUnitsArgs{Pattern: "mysql"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Entities, jc.DeepEquals, []params.FoundEntity{{
+		ModelTag:      "model-uuid-1",
+		ModelName:     "web",
+		ModelOwnerTag: "user-bob",
+		EntityTag:     "unit-mysql-0",
+		Status:        params.EntityStatus{Status: status.Active},
+		Addresses:     []string{"10.0.0.1"},
+		Machine:       "0",
+	}, {
+		ModelTag:      "model-uuid-2",
+		ModelName:     "web",
+		ModelOwnerTag: "user-fred",
+		EntityTag:     "unit-mysql-3",
+		Status:        params.EntityStatus{Status: status.Active},
+		Addresses:     []string{"10.0.1.3"},
+		Machine:       "2",
+	}})
+	// The missing model is skipped.
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"ModelBasicInfoForUser", []interface{}{names.NewUserTag("bob")}},
+		{"ModelUnits", []interface{}{"uuid-3"}},
+		{"ModelUnits", []interface{}{"uuid-1"}},
+		{"ModelUnits", []interface{}{"uuid-2"}},
+	})
+}
+
+func (s *SearchSuite) TestFindUnitsByName(c *gc.C) {
+	result, err := s.api.FindUnits(params.FindUnitsArgs{Pattern: "word*/0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Entities, gc.HasLen, 1)
+	c.Assert(result.Entities[0].EntityTag, gc.Equals, "unit-wordpress-0")
+	c.Assert(result.Entities[0].Status, jc.DeepEquals, params.EntityStatus{
+		Status: status.Error,
+		Info:   "hook failed",
+	})
+}
+
+func (s *SearchSuite) TestFindUnitsNoMatch(c *gc.C) {
+	result, err := s.api.FindUnits(params.FindUnitsArgs{Pattern: "mysql/1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Entities, gc.HasLen, 0)
+}
+
+func (s *SearchSuite) TestFindUnitsInvalidPattern(c *gc.C) {
+	_, err := s.api.FindUnits(params.FindUnitsArgs{})
+	c.Assert(err, gc.ErrorMatches, "empty pattern not valid")
+	_, err = s.api.FindUnits(params.FindUnitsArgs{Pattern: "mysql["})
+	c.Assert(err, gc.ErrorMatches, `invalid pattern mysql\[: .*`)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *SearchSuite) TestFindUnitsError(c *gc.C) {
+	s.backend.SetErrors(nil, nil, errors.New("boom"))
+	_, err := s.api.FindUnits(params.FindUnitsArgs{Pattern: "mysql"})
+	c.Assert(err, gc.ErrorMatches, `model "web": boom`)
+}
+
+func (s *SearchSuite) TestFindMachinesByAddress(c *gc.C) {
+	result, err := s.api.FindMachines(params.FindMachinesArgs{Query: "54.0.0.1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Entities, jc.DeepEquals, []params.FoundEntity{{
+		ModelTag:      "model-uuid-1",
+		ModelName:     "web",
+		ModelOwnerTag: "user-bob",
+		EntityTag:     "machine-0",
+		Status:        params.EntityStatus{Status: status.Started},
+		Addresses:     []string{"10.0.0.1", "54.0.0.1"},
+		InstanceId:    "i-0",
+	}})
+}
+
+func (s *SearchSuite) TestFindMachinesByInstanceId(c *gc.C) {
+	result, err := s.api.FindMachines(params.FindMachinesArgs{Query: "i-2"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Entities, gc.HasLen, 1)
+	c.Assert(result.Entities[0].ModelOwnerTag, gc.Equals, "user-fred")
+	c.Assert(result.Entities[0].EntityTag, gc.Equals, "machine-2")
+}
+
+func (s *SearchSuite) TestFindMachinesEmptyQuery(c *gc.C) {
+	_, err := s.api.FindMachines(params.FindMachinesArgs{})
+	c.Assert(err, gc.ErrorMatches, "empty query not valid")
+	s.backend.CheckNoCalls(c)
+}
@@ -0,0 +1,33 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package securityupdates
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/state"
+)
+
+type stateShim struct {
+	*state.State
+}
+
+// ModelTag is part of the Backend interface.
+func (s stateShim) ModelTag() names.ModelTag {
+	return names.NewModelTag(s.State.ModelUUID())
+}
+
+// AllMachines is part of the Backend interface.
+func (s stateShim) AllMachines() ([]Machine, error) {
+	machines, err := s.State.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Machine, len(machines))
+	for i, m := range machines {
+		result[i] = m
+	}
+	return result, nil
+}
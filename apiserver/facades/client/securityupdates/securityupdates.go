@@ -0,0 +1,104 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package securityupdates provides the API server facade for reading
+// the patch posture of the machines in a model, as reported by their
+// agents when the model's report-security-updates setting is enabled.
+package securityupdates
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/securityupdates"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/permission"
+)
+
+// Backend defines the state functionality required by the
+// SecurityUpdates facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+
+	// AllMachines returns the machines in the model.
+	AllMachines() ([]Machine, error)
+}
+
+// Machine defines the machine functionality required by the
+// SecurityUpdates facade.
+type Machine interface {
+	Id() string
+	Status() (status.StatusInfo, error)
+}
+
+// API implements the SecurityUpdates facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewFacade creates a new SecurityUpdates API facade.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(stateShim{ctx.State()}, ctx.Auth())
+}
+
+// NewAPI returns a new SecurityUpdates API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+// Summary returns the latest security updates report of each machine
+// in the model, and the totals across the machines which have
+// reported.
+func (api *API) Summary() (params.SecurityUpdatesSummary, error) {
+	allowed, err := api.authorizer.HasPermission(permission.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return params.SecurityUpdatesSummary{}, errors.Trace(err)
+	}
+	if !allowed {
+		return params.SecurityUpdatesSummary{}, common.ErrPerm
+	}
+	machines, err := api.backend.AllMachines()
+	if err != nil {
+		return params.SecurityUpdatesSummary{}, errors.Trace(err)
+	}
+	result := params.SecurityUpdatesSummary{
+		Machines: make([]params.MachineSecurityUpdates, len(machines)),
+	}
+	for i, m := range machines {
+		result.Machines[i].Tag = names.NewMachineTag(m.Id()).String()
+		info, err := m.Status()
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return params.SecurityUpdatesSummary{}, errors.Annotatef(err, "getting status of machine %s", m.Id())
+		}
+		report, ok := securityupdates.FromStatusData(info.Data)
+		if !ok {
+			continue
+		}
+		result.Machines[i].Report = &params.SecurityUpdatesReport{
+			Pending:        report.Pending,
+			Security:       report.Security,
+			RebootRequired: report.RebootRequired,
+			RebootPackages: report.RebootPackages,
+			Checked:        report.Checked,
+		}
+		result.Reporting++
+		result.Pending += report.Pending
+		result.Security += report.Security
+		if report.RebootRequired {
+			result.RebootRequired++
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,149 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package securityupdates_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/securityupdates"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	coresecurityupdates "github.com/juju/juju/core/securityupdates"
+	"github.com/juju/juju/core/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SecurityUpdatesSuite struct {
+	testing.IsolationSuite
+
+	checked    time.Time
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&SecurityUpdatesSuite{})
+
+func (s *SecurityUpdatesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.checked = time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.backend = &mockBackend{
+		machines: []securityupdates.Machine{
+			&mockMachine{id: "0", report: &coresecurityupdates.Report{
+				Pending:  10,
+				Security: 4,
+				Checked:  s.checked,
+			}},
+			&mockMachine{id: "1"},
+			&mockMachine{id: "2", report: &coresecurityupdates.Report{
+				Pending:        3,
+				Security:       1,
+				RebootRequired: true,
+				RebootPackages: []string{"libc6"},
+				Checked:        s.checked,
+			}},
+		},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	}
+}
+
+func (s *SecurityUpdatesSuite) newAPI(c *gc.C) *securityupdates.API {
+	api, err := securityupdates.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *SecurityUpdatesSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := securityupdates.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *SecurityUpdatesSuite) TestSummary(c *gc.C) {
+	result, err := s.newAPI(c).Summary()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.SecurityUpdatesSummary{
+		Machines: []params.MachineSecurityUpdates{{
+			Tag: "machine-0",
+			Report: &params.SecurityUpdatesReport{
+				Pending:  10,
+				Security: 4,
+				Checked:  s.checked,
+			},
+		}, {
+			Tag: "machine-1",
+		}, {
+			Tag: "machine-2",
+			Report: &params.SecurityUpdatesReport{
+				Pending:        3,
+				Security:       1,
+				RebootRequired: true,
+				RebootPackages: []string{"libc6"},
+				Checked:        s.checked,
+			},
+		}},
+		Reporting:      2,
+		Pending:        13,
+		Security:       5,
+		RebootRequired: 1,
+	})
+	s.backend.CheckCallNames(c, "ModelTag", "AllMachines")
+}
+
+func (s *SecurityUpdatesSuite) TestSummaryRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("nobody")
+	_, err := s.newAPI(c).Summary()
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckCallNames(c, "ModelTag")
+}
+
+func (s *SecurityUpdatesSuite) TestSummaryError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	_, err := s.newAPI(c).Summary()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockBackend struct {
+	testing.Stub
+	machines []securityupdates.Machine
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	b.MethodCall(b, "ModelTag")
+	b.PopNoErr()
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) AllMachines() ([]securityupdates.Machine, error) {
+	b.MethodCall(b, "AllMachines")
+	return b.machines, b.NextErr()
+}
+
+type mockMachine struct {
+	id     string
+	report *coresecurityupdates.Report
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
+}
+
+func (m *mockMachine) Status() (status.StatusInfo, error) {
+	info := status.StatusInfo{
+		Status: status.Started,
+		Data:   map[string]interface{}{"foo": "bar"},
+	}
+	if m.report != nil {
+		info.Data[coresecurityupdates.DataKey] = coresecurityupdates.StatusData(*m.report)
+	}
+	return info, nil
+}
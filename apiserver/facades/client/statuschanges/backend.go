@@ -0,0 +1,32 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statuschanges
+
+import (
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// StatusChanges facade. For details on the methods, see the methods
+// on state.State with the same names.
+type Backend interface {
+	ModelTag() names.ModelTag
+	WatchStatusChanges(globalKeyPrefix string) state.StringsWatcher
+	StatusChanges(globalKeys []string) ([]state.StatusChange, error)
+}
+
+type stateShim struct {
+	*state.State
+}
+
+// NewStateBackend converts a state.State into a Backend.
+func NewStateBackend(st *state.State) Backend {
+	return &stateShim{st}
+}
+
+func (s *stateShim) ModelTag() names.ModelTag {
+	return names.NewModelTag(s.State.ModelUUID())
+}
@@ -0,0 +1,42 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statuschanges_test
+
+import (
+	"github.com/juju/testing"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mockBackend struct {
+	testing.Stub
+	keys     []string
+	statuses map[string]state.StatusChange
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) WatchStatusChanges(globalKeyPrefix string) state.StringsWatcher {
+	b.MethodCall(b, "WatchStatusChanges", globalKeyPrefix)
+	ch := make(chan []string, 1)
+	ch <- b.keys
+	return watchertest.NewStringsWatcher(ch)
+}
+
+func (b *mockBackend) StatusChanges(globalKeys []string) ([]state.StatusChange, error) {
+	b.MethodCall(b, "StatusChanges", globalKeys)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	changes := make([]state.StatusChange, len(globalKeys))
+	for i, key := range globalKeys {
+		changes[i] = b.statuses[key]
+	}
+	return changes, nil
+}
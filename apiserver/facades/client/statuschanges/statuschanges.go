@@ -0,0 +1,135 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package statuschanges provides the API server facade for watching
+// changes to the statuses of the entities in a model, so that clients
+// such as the GUI can follow them without repeatedly asking for the
+// full status.
+package statuschanges
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// kindPrefixes maps the kinds of entity whose status changes may be
+// watched to the prefix of the global keys of their statuses.
+var kindPrefixes = map[string]string{
+	"":            "",
+	"model":       "e",
+	"machine":     "m#",
+	"application": "a#",
+	"unit":        "u#",
+}
+
+// API implements the StatusChanges facade.
+type API struct {
+	backend    Backend
+	resources  facade.Resources
+	authorizer facade.Authorizer
+}
+
+// NewFacade creates a new StatusChanges API facade.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(NewStateBackend(ctx.State()), ctx.Resources(), ctx.Auth())
+}
+
+// NewAPI returns a new StatusChanges API facade.
+func NewAPI(backend Backend, resources facade.Resources, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() && !isAgent(authorizer) {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		resources:  resources,
+		authorizer: authorizer,
+	}, nil
+}
+
+func isAgent(authorizer facade.Authorizer) bool {
+	return authorizer.AuthMachineAgent() || authorizer.AuthUnitAgent() || authorizer.AuthApplicationAgent()
+}
+
+func (api *API) checkCanRead() error {
+	if !api.authorizer.AuthClient() {
+		// Agents may watch the statuses in the model they belong to.
+		return nil
+	}
+	allowed, err := api.authorizer.HasPermission(permission.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// WatchStatusChanges starts a StatusChangesWatcher for each of the
+// given kinds of entity, returning the current statuses of those
+// entities as its initial changes. Subsequent calls to the watcher's
+// Next method return the statuses which have changed since.
+func (api *API) WatchStatusChanges(args params.WatchStatusChangesArgs) (params.StatusChangesWatchResults, error) {
+	results := params.StatusChangesWatchResults{
+		Results: make([]params.StatusChangesWatchResult, len(args.Args)),
+	}
+	if err := api.checkCanRead(); err != nil {
+		return results, errors.Trace(err)
+	}
+	for i, arg := range args.Args {
+		result, err := api.watchStatusChanges(arg.Kind)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+func (api *API) watchStatusChanges(kind string) (params.StatusChangesWatchResult, error) {
+	var result params.StatusChangesWatchResult
+	prefix, ok := kindPrefixes[kind]
+	if !ok {
+		return result, errors.NotValidf("entity kind %q", kind)
+	}
+	w := api.backend.WatchStatusChanges(prefix)
+	keys, ok := <-w.Changes()
+	if !ok {
+		return result, watcher.EnsureErr(w)
+	}
+	changes, err := api.backend.StatusChanges(keys)
+	if err != nil {
+		w.Stop()
+		return result, errors.Trace(err)
+	}
+	result.Changes = StatusChangesParams(changes)
+	result.StatusChangesWatcherId = api.resources.Register(w)
+	return result, nil
+}
+
+// StatusChangesParams converts the status changes reported by state
+// into their API representation.
+func StatusChangesParams(changes []state.StatusChange) []params.StatusChange {
+	result := make([]params.StatusChange, len(changes))
+	for i, change := range changes {
+		result[i].Key = change.GlobalKey
+		if change.Entity != nil {
+			result[i].Tag = change.Entity.String()
+		}
+		if change.Status == nil {
+			result[i].Removed = true
+			continue
+		}
+		result[i].Status = change.Status.Status.String()
+		result[i].Info = change.Status.Message
+		result[i].Data = change.Status.Data
+		result[i].Since = change.Status.Since
+	}
+	return result
+}
@@ -0,0 +1,132 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statuschanges_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/statuschanges"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+type StatusChangesSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockBackend
+	resources  *common.Resources
+	authorizer apiservertesting.FakeAuthorizer
+	since      time.Time
+}
+
+var _ = gc.Suite(&StatusChangesSuite{})
+
+func (s *StatusChangesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.since = time.Date(2020, 1, 3, 14, 0, 0, 0, time.UTC)
+	s.backend = &mockBackend{
+		keys: []string{"u#mysql/0#charm", "u#mysql/1#charm"},
+		statuses: map[string]state.StatusChange{
+			"u#mysql/0#charm": {
+				GlobalKey: "u#mysql/0#charm",
+				Entity:    names.NewUnitTag("mysql/0"),
+				Status: &status.StatusInfo{
+					Status:  status.Blocked,
+					Message: "need a relation",
+					Data:    map[string]interface{}{"foo": "bar"},
+					Since:   &s.since,
+				},
+			},
+			"u#mysql/1#charm": {
+				GlobalKey: "u#mysql/1#charm",
+				Entity:    names.NewUnitTag("mysql/1"),
+			},
+		},
+	}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+}
+
+func (s *StatusChangesSuite) newAPI(c *gc.C) *statuschanges.API {
+	api, err := statuschanges.NewAPI(s.backend, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *StatusChangesSuite) TestNewAPIAllowsAgents(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := statuschanges.NewAPI(s.backend, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StatusChangesSuite) TestNewAPIRequiresClientOrAgent(c *gc.C) {
+	s.authorizer.Tag = names.NewModelTag("deadbeef-0bad-400d-8000-4b1d0d06f00d")
+	_, err := statuschanges.NewAPI(s.backend, s.resources, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *StatusChangesSuite) TestWatchStatusChanges(c *gc.C) {
+	results, err := s.newAPI(c).WatchStatusChanges(params.WatchStatusChangesArgs{
+		Args: []params.WatchStatusChangesArg{{Kind: "unit"}, {Kind: ""}, {Kind: "relation"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "WatchStatusChanges", "StatusChanges", "WatchStatusChanges", "StatusChanges")
+	s.backend.CheckCall(c, 0, "WatchStatusChanges", "u#")
+	s.backend.CheckCall(c, 2, "WatchStatusChanges", "")
+	c.Assert(results.Results, gc.HasLen, 3)
+
+	result := results.Results[0]
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.StatusChangesWatcherId, gc.Equals, "1")
+	c.Assert(result.Changes, jc.DeepEquals, []params.StatusChange{{
+		Key:    "u#mysql/0#charm",
+		Tag:    "unit-mysql-0",
+		Status: "blocked",
+		Info:   "need a relation",
+		Data:   map[string]interface{}{"foo": "bar"},
+		Since:  &s.since,
+	}, {
+		Key:     "u#mysql/1#charm",
+		Tag:     "unit-mysql-1",
+		Removed: true,
+	}})
+	c.Assert(s.resources.Get("1"), gc.NotNil)
+
+	c.Assert(results.Results[1].Error, gc.IsNil)
+	c.Assert(results.Results[1].StatusChangesWatcherId, gc.Equals, "2")
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `entity kind "relation" not valid`)
+	c.Assert(s.resources.Count(), gc.Equals, 2)
+}
+
+func (s *StatusChangesSuite) TestWatchStatusChangesError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	results, err := s.newAPI(c).WatchStatusChanges(params.WatchStatusChangesArgs{
+		Args: []params.WatchStatusChangesArg{{Kind: "machine"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "boom")
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+}
+
+func (s *StatusChangesSuite) TestWatchStatusChangesRequiresReadAccess(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("bob")
+	results, err := s.newAPI(c).WatchStatusChanges(params.WatchStatusChangesArgs{
+		Args: []params.WatchStatusChangesArg{{Kind: "unit"}},
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	c.Assert(results.Results[0].StatusChangesWatcherId, gc.Equals, "")
+	s.backend.CheckNoCalls(c)
+}
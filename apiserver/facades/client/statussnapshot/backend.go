@@ -0,0 +1,98 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statussnapshot
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// StatusSnapshot facade. For details on the methods, see the methods
+// on state.State with the same names.
+type Backend interface {
+	ModelTag() names.ModelTag
+	ControllerTag() names.ControllerTag
+	Model() (Model, error)
+	StatusSnapshot(at time.Time) (*state.StatusSnapshot, error)
+	AllApplications() ([]Application, error)
+	AllUnits() ([]Unit, error)
+}
+
+// Model describes the model the snapshot is taken of.
+type Model interface {
+	UUID() string
+	Name() string
+	Type() state.ModelType
+	Cloud() string
+	CloudRegion() string
+}
+
+// Application describes an application which is still in the model.
+type Application interface {
+	Name() string
+	Series() string
+	CharmURL() (*charm.URL, bool)
+}
+
+// Unit describes a unit which is still in the model.
+type Unit interface {
+	Name() string
+	PrincipalName() (string, bool)
+	AssignedMachineId() (string, error)
+}
+
+type stateShim struct {
+	*state.State
+}
+
+// NewStateBackend converts a state.State into a Backend.
+func NewStateBackend(st *state.State) Backend {
+	return &stateShim{st}
+}
+
+func (s *stateShim) ModelTag() names.ModelTag {
+	return names.NewModelTag(s.State.ModelUUID())
+}
+
+func (s *stateShim) Model() (Model, error) {
+	m, err := s.State.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return m, nil
+}
+
+func (s *stateShim) AllApplications() ([]Application, error) {
+	all, err := s.State.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Application, len(all))
+	for i, app := range all {
+		result[i] = app
+	}
+	return result, nil
+}
+
+func (s *stateShim) AllUnits() ([]Unit, error) {
+	m, err := s.State.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	all, err := m.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Unit, len(all))
+	for i, u := range all {
+		result[i] = u
+	}
+	return result, nil
+}
@@ -0,0 +1,117 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statussnapshot_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/facades/client/statussnapshot"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mockBackend struct {
+	testing.Stub
+	snapshot     *state.StatusSnapshot
+	applications []statussnapshot.Application
+	units        []statussnapshot.Unit
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (b *mockBackend) Model() (statussnapshot.Model, error) {
+	b.MethodCall(b, "Model")
+	return &mockModel{}, b.NextErr()
+}
+
+func (b *mockBackend) StatusSnapshot(at time.Time) (*state.StatusSnapshot, error) {
+	b.MethodCall(b, "StatusSnapshot", at)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	b.snapshot.Time = at
+	return b.snapshot, nil
+}
+
+func (b *mockBackend) AllApplications() ([]statussnapshot.Application, error) {
+	b.MethodCall(b, "AllApplications")
+	return b.applications, b.NextErr()
+}
+
+func (b *mockBackend) AllUnits() ([]statussnapshot.Unit, error) {
+	b.MethodCall(b, "AllUnits")
+	return b.units, b.NextErr()
+}
+
+type mockModel struct{}
+
+func (m *mockModel) UUID() string {
+	return coretesting.ModelTag.Id()
+}
+
+func (m *mockModel) Name() string {
+	return "prod"
+}
+
+func (m *mockModel) Type() state.ModelType {
+	return state.ModelTypeIAAS
+}
+
+func (m *mockModel) Cloud() string {
+	return "dummy"
+}
+
+func (m *mockModel) CloudRegion() string {
+	return "east"
+}
+
+type mockApplication struct {
+	name   string
+	series string
+	curl   *charm.URL
+}
+
+func (a *mockApplication) Name() string {
+	return a.name
+}
+
+func (a *mockApplication) Series() string {
+	return a.series
+}
+
+func (a *mockApplication) CharmURL() (*charm.URL, bool) {
+	return a.curl, false
+}
+
+type mockUnit struct {
+	name      string
+	principal string
+	machineId string
+}
+
+func (u *mockUnit) Name() string {
+	return u.name
+}
+
+func (u *mockUnit) PrincipalName() (string, bool) {
+	return u.principal, u.principal != ""
+}
+
+func (u *mockUnit) AssignedMachineId() (string, error) {
+	if u.machineId == "" {
+		return "", errors.NotAssignedf("unit %q", u.name)
+	}
+	return u.machineId, nil
+}
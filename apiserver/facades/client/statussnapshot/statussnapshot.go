@@ -0,0 +1,284 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package statussnapshot provides the API server facade for
+// reconstructing the approximate status of a model at a past time,
+// for analysing what happened during an incident.
+package statussnapshot
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/auditlog"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.statussnapshot")
+
+// commandsWindow bounds how long after the time of a snapshot the
+// commands run against the model are returned for, so that a snapshot
+// taken far in the past doesn't read the whole audit log.
+const commandsWindow = 24 * time.Hour
+
+// API implements the StatusSnapshot facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	logDir     string
+}
+
+// NewFacade creates a new StatusSnapshot API facade.
+func NewFacade(ctx facade.Context) (*API, error) {
+	var logDir string
+	if res, ok := ctx.Resources().Get("logDir").(common.StringResource); ok {
+		logDir = res.String()
+	}
+	return NewAPI(NewStateBackend(ctx.State()), ctx.Auth(), logDir)
+}
+
+// NewAPI returns a new StatusSnapshot API facade, which reads the
+// audit log in the given directory.
+func NewAPI(backend Backend, authorizer facade.Authorizer, logDir string) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+		logDir:     logDir,
+	}, nil
+}
+
+func (api *API) checkCanRead() error {
+	allowed, err := api.authorizer.HasPermission(permission.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+func (api *API) isAdmin() (bool, error) {
+	isAdmin, err := api.authorizer.HasPermission(permission.SuperuserAccess, api.backend.ControllerTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return false, errors.Trace(err)
+	}
+	if isAdmin {
+		return true, nil
+	}
+	isAdmin, err = api.authorizer.HasPermission(permission.AdminAccess, api.backend.ModelTag())
+	if err != nil && !errors.IsNotFound(err) {
+		return false, errors.Trace(err)
+	}
+	return isAdmin, nil
+}
+
+// StatusSnapshot returns the approximate status of the model at the
+// given time. The status of each machine, application and unit is the
+// latest recorded in status history at or before the time; entities
+// which have since been removed are not included. The charm, series
+// and placement of entities are as they are now.
+//
+// For model administrators, the commands run against the model in the
+// day after the given time are also returned, so that changes the
+// snapshot cannot show, such as the removal of units, can be accounted
+// for. They are read from the audit log of the controller node serving
+// the request, so in a highly available controller commands handled by
+// the other nodes are not included.
+func (api *API) StatusSnapshot(args params.StatusSnapshotArgs) (params.StatusSnapshotResult, error) {
+	var result params.StatusSnapshotResult
+	if err := api.checkCanRead(); err != nil {
+		return result, errors.Trace(err)
+	}
+	if args.Time.IsZero() {
+		return result, errors.NotValidf("empty time")
+	}
+	model, err := api.backend.Model()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	snapshot, err := api.backend.StatusSnapshot(args.Time)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	result.Status, err = api.fullStatus(model, snapshot)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+
+	isAdmin, err := api.isAdmin()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	if isAdmin && api.logDir != "" {
+		err := auditlog.Conversations(api.logDir, model.UUID(), args.Time, args.Time.Add(commandsWindow),
+			func(c auditlog.Conversation) error {
+				when, err := time.Parse(time.RFC3339, c.When)
+				if err != nil {
+					return nil
+				}
+				result.Commands = append(result.Commands, params.AuditedCommand{
+					Who:  c.Who,
+					What: c.What,
+					When: when,
+				})
+				return nil
+			})
+		if err != nil {
+			// The snapshot is still useful without the commands.
+			logger.Warningf("cannot read audit log: %v", err)
+		}
+	}
+	return result, nil
+}
+
+func (api *API) fullStatus(model Model, snapshot *state.StatusSnapshot) (params.FullStatus, error) {
+	at := snapshot.Time
+	fullStatus := params.FullStatus{
+		Model: params.ModelStatusInfo{
+			Name:        model.Name(),
+			Type:        string(model.Type()),
+			CloudTag:    names.NewCloudTag(model.Cloud()).String(),
+			CloudRegion: model.CloudRegion(),
+		},
+		Machines:            make(map[string]params.MachineStatus),
+		Applications:        make(map[string]params.ApplicationStatus),
+		RemoteApplications:  make(map[string]params.RemoteApplicationStatus),
+		Offers:              make(map[string]params.ApplicationOfferStatus),
+		ControllerTimestamp: &at,
+	}
+	if snapshot.Model != nil {
+		fullStatus.Model.ModelStatus = detailedStatus(*snapshot.Model)
+	}
+	for id, agentStatus := range snapshot.Machines {
+		fullStatus.Machines[id] = params.MachineStatus{
+			Id:             id,
+			AgentStatus:    detailedStatus(agentStatus),
+			InstanceStatus: detailedStatus(snapshot.Instances[id]),
+		}
+	}
+
+	applications, err := api.backend.AllApplications()
+	if err != nil {
+		return fullStatus, errors.Trace(err)
+	}
+	current := make(map[string]Application)
+	for _, app := range applications {
+		current[app.Name()] = app
+	}
+	application := func(name string) params.ApplicationStatus {
+		appStatus, ok := fullStatus.Applications[name]
+		if ok {
+			return appStatus
+		}
+		appStatus.Units = make(map[string]params.UnitStatus)
+		if info, ok := snapshot.Applications[name]; ok {
+			appStatus.Status = detailedStatus(info)
+		}
+		if app, ok := current[name]; ok {
+			appStatus.Series = app.Series()
+			if curl, _ := app.CharmURL(); curl != nil {
+				appStatus.Charm = curl.String()
+			}
+		}
+		return appStatus
+	}
+	for name := range snapshot.Applications {
+		fullStatus.Applications[name] = application(name)
+	}
+
+	units, err := api.backend.AllUnits()
+	if err != nil {
+		return fullStatus, errors.Trace(err)
+	}
+	principals := make(map[string]string)
+	machines := make(map[string]string)
+	for _, u := range units {
+		if principal, ok := u.PrincipalName(); ok {
+			principals[u.Name()] = principal
+		}
+		if machineId, err := u.AssignedMachineId(); err == nil {
+			machines[u.Name()] = machineId
+		}
+	}
+	unitNames := make(map[string]bool)
+	for name := range snapshot.UnitAgents {
+		unitNames[name] = true
+	}
+	for name := range snapshot.UnitWorkloads {
+		unitNames[name] = true
+	}
+	unitStatus := func(name string) params.UnitStatus {
+		return params.UnitStatus{
+			AgentStatus:    detailedStatus(snapshot.UnitAgents[name]),
+			WorkloadStatus: detailedStatus(snapshot.UnitWorkloads[name]),
+			Machine:        machines[name],
+		}
+	}
+	// Principal units are added first, so that their subordinates
+	// can be added beneath them.
+	for name := range unitNames {
+		if _, ok := principals[name]; ok {
+			continue
+		}
+		appName, err := names.UnitApplication(name)
+		if err != nil {
+			continue
+		}
+		appStatus := application(appName)
+		appStatus.Units[name] = unitStatus(name)
+		fullStatus.Applications[appName] = appStatus
+	}
+	for name := range unitNames {
+		principal, ok := principals[name]
+		if !ok {
+			continue
+		}
+		appName, err := names.UnitApplication(name)
+		if err != nil {
+			continue
+		}
+		// Make sure the subordinate application is shown, even
+		// though its units are shown beneath their principals.
+		fullStatus.Applications[appName] = application(appName)
+
+		principalApp, err := names.UnitApplication(principal)
+		if err != nil {
+			continue
+		}
+		principalStatus, ok := fullStatus.Applications[principalApp].Units[principal]
+		if !ok {
+			// The principal has no status at the time, so show
+			// the subordinate on its own.
+			appStatus := fullStatus.Applications[appName]
+			appStatus.Units[name] = unitStatus(name)
+			continue
+		}
+		if principalStatus.Subordinates == nil {
+			principalStatus.Subordinates = make(map[string]params.UnitStatus)
+		}
+		principalStatus.Subordinates[name] = unitStatus(name)
+		fullStatus.Applications[principalApp].Units[principal] = principalStatus
+	}
+	return fullStatus, nil
+}
+
+func detailedStatus(info status.StatusInfo) params.DetailedStatus {
+	return params.DetailedStatus{
+		Status: info.Status.String(),
+		Info:   info.Message,
+		Data:   info.Data,
+		Since:  info.Since,
+	}
+}
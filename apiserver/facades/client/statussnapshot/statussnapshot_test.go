@@ -0,0 +1,180 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statussnapshot_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/statussnapshot"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type StatusSnapshotSuite struct {
+	testing.IsolationSuite
+
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+	logDir     string
+	at         time.Time
+}
+
+var _ = gc.Suite(&StatusSnapshotSuite{})
+
+func (s *StatusSnapshotSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.at = time.Date(2020, 1, 3, 14, 0, 0, 0, time.UTC)
+	since := s.at.Add(-time.Hour)
+	info := func(st status.Status, message string) status.StatusInfo {
+		return status.StatusInfo{Status: st, Message: message, Since: &since}
+	}
+	s.backend = &mockBackend{
+		snapshot: &state.StatusSnapshot{
+			Machines: map[string]status.StatusInfo{
+				"0": info(status.Started, ""),
+			},
+			Instances: map[string]status.StatusInfo{
+				"0": info(status.Running, "running"),
+			},
+			Applications: map[string]status.StatusInfo{
+				"mysql": info(status.Blocked, "need a relation"),
+			},
+			UnitAgents: map[string]status.StatusInfo{
+				"mysql/0":   info(status.Idle, ""),
+				"logging/0": info(status.Idle, ""),
+			},
+			UnitWorkloads: map[string]status.StatusInfo{
+				"mysql/0":   info(status.Blocked, "need a relation"),
+				"logging/0": info(status.Active, ""),
+			},
+		},
+		applications: []statussnapshot.Application{
+			&mockApplication{
+				name:   "mysql",
+				series: "bionic",
+				curl:   charm.MustParseURL("cs:mysql-42"),
+			},
+		},
+		units: []statussnapshot.Unit{
+			&mockUnit{name: "mysql/0", machineId: "0"},
+			&mockUnit{name: "logging/0", principal: "mysql/0", machineId: "0"},
+		},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	s.logDir = c.MkDir()
+}
+
+func (s *StatusSnapshotSuite) newAPI(c *gc.C) *statussnapshot.API {
+	api, err := statussnapshot.NewAPI(s.backend, s.authorizer, s.logDir)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *StatusSnapshotSuite) writeAuditLog(c *gc.C) {
+	err := ioutil.WriteFile(filepath.Join(s.logDir, "audit.log"), []byte(
+		`{"conversation":{"who":"bob","what":"juju remove-unit mysql/1","when":"2020-01-03T14:30:00Z","model-uuid":"`+
+			coretesting.ModelTag.Id()+`"}}`+"\n"+
+			// Commands from more than a day after the time are not returned.
+			`{"conversation":{"who":"bob","what":"juju remove-unit mysql/2","when":"2020-01-04T15:00:00Z","model-uuid":"`+
+			coretesting.ModelTag.Id()+`"}}`+"\n",
+	), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StatusSnapshotSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := statussnapshot.NewAPI(s.backend, s.authorizer, s.logDir)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *StatusSnapshotSuite) TestStatusSnapshot(c *gc.C) {
+	s.writeAuditLog(c)
+	result, err := s.newAPI(c).StatusSnapshot(params.StatusSnapshotArgs{Time: s.at})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "Model", "StatusSnapshot", "AllApplications", "AllUnits")
+	s.backend.CheckCall(c, 1, "StatusSnapshot", s.at)
+
+	since := s.at.Add(-time.Hour)
+	c.Assert(result.Status.Model.Name, gc.Equals, "prod")
+	c.Assert(result.Status.Model.CloudTag, gc.Equals, "cloud-dummy")
+	c.Assert(*result.Status.ControllerTimestamp, gc.Equals, s.at)
+	c.Assert(result.Status.Machines, jc.DeepEquals, map[string]params.MachineStatus{
+		"0": {
+			Id:             "0",
+			AgentStatus:    params.DetailedStatus{Status: "started", Since: &since},
+			InstanceStatus: params.DetailedStatus{Status: "running", Info: "running", Since: &since},
+		},
+	})
+	c.Assert(result.Status.Applications, jc.DeepEquals, map[string]params.ApplicationStatus{
+		"mysql": {
+			Charm:  "cs:mysql-42",
+			Series: "bionic",
+			Status: params.DetailedStatus{Status: "blocked", Info: "need a relation", Since: &since},
+			Units: map[string]params.UnitStatus{
+				"mysql/0": {
+					AgentStatus:    params.DetailedStatus{Status: "idle", Since: &since},
+					WorkloadStatus: params.DetailedStatus{Status: "blocked", Info: "need a relation", Since: &since},
+					Machine:        "0",
+					Subordinates: map[string]params.UnitStatus{
+						"logging/0": {
+							AgentStatus:    params.DetailedStatus{Status: "idle", Since: &since},
+							WorkloadStatus: params.DetailedStatus{Status: "active", Since: &since},
+							Machine:        "0",
+						},
+					},
+				},
+			},
+		},
+		"logging": {
+			Units: map[string]params.UnitStatus{},
+		},
+	})
+	c.Assert(result.Commands, jc.DeepEquals, []params.AuditedCommand{{
+		Who:  "bob",
+		What: "juju remove-unit mysql/1",
+		When: time.Date(2020, 1, 3, 14, 30, 0, 0, time.UTC),
+	}})
+}
+
+func (s *StatusSnapshotSuite) TestStatusSnapshotCommandsOnlyForAdmins(c *gc.C) {
+	s.writeAuditLog(c)
+	s.authorizer.Tag = names.NewUserTag("read")
+	result, err := s.newAPI(c).StatusSnapshot(params.StatusSnapshotArgs{Time: s.at})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Status.Applications, gc.HasLen, 2)
+	c.Assert(result.Commands, gc.HasLen, 0)
+}
+
+func (s *StatusSnapshotSuite) TestStatusSnapshotPermissionDenied(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("someoneelse")
+	_, err := s.newAPI(c).StatusSnapshot(params.StatusSnapshotArgs{Time: s.at})
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *StatusSnapshotSuite) TestStatusSnapshotEmptyTime(c *gc.C) {
+	_, err := s.newAPI(c).StatusSnapshot(params.StatusSnapshotArgs{})
+	c.Assert(err, gc.ErrorMatches, "empty time not valid")
+}
+
+func (s *StatusSnapshotSuite) TestStatusSnapshotError(c *gc.C) {
+	s.backend.SetErrors(nil, errors.New("boom"))
+	_, err := s.newAPI(c).StatusSnapshot(params.StatusSnapshotArgs{Time: s.at})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,100 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package stuckstatus provides the API server facade for finding the
+// machines, applications and units in a model which have been in
+// error, blocked or waiting, and for how long.
+package stuckstatus
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the StuckStatus
+// facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+
+	// StuckStatuses returns the statuses in the model which are
+	// error, blocked or waiting, and haven't changed since the given
+	// time. See state.State.StuckStatuses.
+	StuckStatuses(notChangedSince time.Time) ([]state.StuckStatus, error)
+}
+
+type stateShim struct {
+	*state.State
+}
+
+func (s stateShim) ModelTag() names.ModelTag {
+	return names.NewModelTag(s.State.ModelUUID())
+}
+
+// API implements the StuckStatus facade.
+type API struct {
+	backend    Backend
+	clock      clock.Clock
+	authorizer facade.Authorizer
+}
+
+// NewFacade creates a new StuckStatus API facade.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(stateShim{ctx.State()}, clock.WallClock, ctx.Auth())
+}
+
+// NewAPI returns a new StuckStatus API facade.
+func NewAPI(backend Backend, clock clock.Clock, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		clock:      clock,
+		authorizer: authorizer,
+	}, nil
+}
+
+// StuckStatuses returns the statuses of the machines, applications and
+// units in the model which are error, blocked or waiting and have been
+// for at least the given time, with how long each has been so, those
+// stuck longest first.
+func (api *API) StuckStatuses(args params.StuckStatusesArgs) (params.StuckStatusesResult, error) {
+	allowed, err := api.authorizer.HasPermission(permission.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return params.StuckStatusesResult{}, errors.Trace(err)
+	}
+	if !allowed {
+		return params.StuckStatusesResult{}, common.ErrPerm
+	}
+	if args.MinDuration < 0 {
+		return params.StuckStatusesResult{}, errors.NotValidf("negative minimum duration")
+	}
+	now := api.clock.Now()
+	stuck, err := api.backend.StuckStatuses(now.Add(-args.MinDuration))
+	if err != nil {
+		return params.StuckStatusesResult{}, errors.Trace(err)
+	}
+	result := params.StuckStatusesResult{
+		Statuses: make([]params.StuckStatus, len(stuck)),
+	}
+	for i, s := range stuck {
+		result.Statuses[i] = params.StuckStatus{
+			Tag:      s.Entity.String(),
+			Kind:     s.Kind.String(),
+			Status:   s.Status.String(),
+			Info:     s.Message,
+			Since:    s.Since,
+			Duration: now.Sub(s.Since),
+		}
+	}
+	return result, nil
+}
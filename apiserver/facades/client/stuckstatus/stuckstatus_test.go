@@ -0,0 +1,139 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package stuckstatus_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/stuckstatus"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type StuckStatusSuite struct {
+	testing.IsolationSuite
+
+	clock      *testclock.Clock
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&StuckStatusSuite{})
+
+func (s *StuckStatusSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.backend = &mockBackend{
+		stuck: []state.StuckStatus{{
+			Entity:  names.NewUnitTag("mysql/0"),
+			Kind:    status.KindUnitAgent,
+			Status:  status.Error,
+			Message: "hook failed",
+			Since:   s.clock.Now().Add(-time.Hour),
+		}, {
+			Entity:  names.NewApplicationTag("wordpress"),
+			Kind:    status.KindApplication,
+			Status:  status.Blocked,
+			Message: "need a database",
+			Since:   s.clock.Now().Add(-time.Minute),
+		}},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	}
+}
+
+func (s *StuckStatusSuite) newAPI(c *gc.C) *stuckstatus.API {
+	api, err := stuckstatus.NewAPI(s.backend, s.clock, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *StuckStatusSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := stuckstatus.NewAPI(s.backend, s.clock, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *StuckStatusSuite) TestStuckStatuses(c *gc.C) {
+	result, err := s.newAPI(c).StuckStatuses(params.StuckStatusesArgs{MinDuration: time.Minute})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StuckStatusesResult{
+		Statuses: []params.StuckStatus{{
+			Tag:      "unit-mysql-0",
+			Kind:     "juju-unit",
+			Status:   "error",
+			Info:     "hook failed",
+			Since:    s.clock.Now().Add(-time.Hour),
+			Duration: time.Hour,
+		}, {
+			Tag:      "application-wordpress",
+			Kind:     "application",
+			Status:   "blocked",
+			Info:     "need a database",
+			Since:    s.clock.Now().Add(-time.Minute),
+			Duration: time.Minute,
+		}},
+	})
+	s.backend.CheckCalls(c, []testing.StubCall{
+		{"ModelTag", nil},
+		{"StuckStatuses", []interface{}{s.clock.Now().Add(-time.Minute)}},
+	})
+}
+
+func (s *StuckStatusSuite) TestStuckStatusesNone(c *gc.C) {
+	s.backend.stuck = nil
+	result, err := s.newAPI(c).StuckStatuses(params.StuckStatusesArgs{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StuckStatusesResult{
+		Statuses: []params.StuckStatus{},
+	})
+	s.backend.CheckCall(c, 1, "StuckStatuses", s.clock.Now())
+}
+
+func (s *StuckStatusSuite) TestStuckStatusesRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("nobody")
+	_, err := s.newAPI(c).StuckStatuses(params.StuckStatusesArgs{})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckCallNames(c, "ModelTag")
+}
+
+func (s *StuckStatusSuite) TestStuckStatusesNegativeDuration(c *gc.C) {
+	_, err := s.newAPI(c).StuckStatuses(params.StuckStatusesArgs{MinDuration: -time.Minute})
+	c.Assert(err, gc.ErrorMatches, "negative minimum duration not valid")
+	s.backend.CheckCallNames(c, "ModelTag")
+}
+
+func (s *StuckStatusSuite) TestStuckStatusesError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	_, err := s.newAPI(c).StuckStatuses(params.StuckStatusesArgs{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type mockBackend struct {
+	testing.Stub
+	stuck []state.StuckStatus
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	b.MethodCall(b, "ModelTag")
+	b.PopNoErr()
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) StuckStatuses(notChangedSince time.Time) ([]state.StuckStatus, error) {
+	b.MethodCall(b, "StuckStatuses", notChangedSince)
+	return b.stuck, b.NextErr()
+}
@@ -0,0 +1,29 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package workerfailures
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/state"
+)
+
+type stateShim struct {
+	*state.State
+}
+
+// ModelTag is part of the Backend interface.
+func (s stateShim) ModelTag() names.ModelTag {
+	return names.NewModelTag(s.State.ModelUUID())
+}
+
+// Machine is part of the Backend interface.
+func (s stateShim) Machine(id string) (Machine, error) {
+	machine, err := s.State.Machine(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machine, nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package workerfailures provides the API server facade for reading
+// the recent worker failures reported by the agents of the machines in
+// a model.
+package workerfailures
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/permission"
+)
+
+// Backend defines the state functionality required by the
+// WorkerFailures facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+
+	// Machine returns the machine with the given id.
+	Machine(id string) (Machine, error)
+}
+
+// Machine defines the machine functionality required by the
+// WorkerFailures facade.
+type Machine interface {
+	Status() (status.StatusInfo, error)
+}
+
+// API implements the WorkerFailures facade.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// NewFacade creates a new WorkerFailures API facade.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(stateShim{ctx.State()}, ctx.Auth())
+}
+
+// NewAPI returns a new WorkerFailures API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+// WorkerFailures returns the recent worker failures reported by the
+// agent of each given machine, oldest first.
+func (api *API) WorkerFailures(args params.Entities) (params.WorkerFailuresResults, error) {
+	allowed, err := api.authorizer.HasPermission(permission.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return params.WorkerFailuresResults{}, errors.Trace(err)
+	}
+	if !allowed {
+		return params.WorkerFailuresResults{}, common.ErrPerm
+	}
+	result := params.WorkerFailuresResults{
+		Results: make([]params.WorkerFailuresResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		failures, err := api.workerFailures(entity.Tag)
+		result.Results[i] = params.WorkerFailuresResult{
+			Failures: failures,
+			Error:    common.ServerError(err),
+		}
+	}
+	return result, nil
+}
+
+func (api *API) workerFailures(tagString string) ([]params.WorkerFailure, error) {
+	tag, err := names.ParseMachineTag(tagString)
+	if err != nil {
+		return nil, err
+	}
+	machine, err := api.backend.Machine(tag.Id())
+	if err != nil {
+		return nil, err
+	}
+	info, err := machine.Status()
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting status of machine %s", tag.Id())
+	}
+	var failures []params.WorkerFailure
+	for _, f := range status.WorkerFailures(info.Data) {
+		failures = append(failures, params.WorkerFailure{
+			Worker: f.Worker,
+			Error:  f.Error,
+			Time:   f.Time,
+		})
+	}
+	return failures, nil
+}
@@ -0,0 +1,142 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package workerfailures_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/workerfailures"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type WorkerFailuresSuite struct {
+	testing.IsolationSuite
+
+	failed     time.Time
+	backend    *mockBackend
+	authorizer apiservertesting.FakeAuthorizer
+}
+
+var _ = gc.Suite(&WorkerFailuresSuite{})
+
+func (s *WorkerFailuresSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.failed = time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.backend = &mockBackend{
+		machines: map[string]*mockMachine{
+			"0": {failures: []status.WorkerFailure{{
+				Worker: "disk-manager",
+				Error:  "boom",
+				Time:   s.failed,
+			}, {
+				Worker: "uniter",
+				Error:  "connection is shut down",
+				Time:   s.failed.Add(time.Minute),
+			}}},
+			"1": {},
+		},
+	}
+	s.authorizer = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	}
+}
+
+func (s *WorkerFailuresSuite) newAPI(c *gc.C) *workerfailures.API {
+	api, err := workerfailures.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *WorkerFailuresSuite) TestNewAPIRequiresClient(c *gc.C) {
+	s.authorizer.Tag = names.NewMachineTag("0")
+	_, err := workerfailures.NewAPI(s.backend, s.authorizer)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *WorkerFailuresSuite) TestWorkerFailures(c *gc.C) {
+	result, err := s.newAPI(c).WorkerFailures(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+			{Tag: "machine-2"},
+			{Tag: "unit-mysql-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.WorkerFailuresResults{
+		Results: []params.WorkerFailuresResult{{
+			Failures: []params.WorkerFailure{{
+				Worker: "disk-manager",
+				Error:  "boom",
+				Time:   s.failed,
+			}, {
+				Worker: "uniter",
+				Error:  "connection is shut down",
+				Time:   s.failed.Add(time.Minute),
+			}},
+		}, {}, {
+			Error: &params.Error{Code: params.CodeNotFound, Message: "machine 2 not found"},
+		}, {
+			Error: &params.Error{Message: `"unit-mysql-0" is not a valid machine tag`},
+		}},
+	})
+	s.backend.CheckCallNames(c, "ModelTag", "Machine", "Machine", "Machine")
+}
+
+func (s *WorkerFailuresSuite) TestWorkerFailuresRequiresRead(c *gc.C) {
+	s.authorizer.Tag = names.NewUserTag("nobody")
+	_, err := s.newAPI(c).WorkerFailures(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-0"}},
+	})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckCallNames(c, "ModelTag")
+}
+
+type mockBackend struct {
+	testing.Stub
+	machines map[string]*mockMachine
+}
+
+func (b *mockBackend) ModelTag() names.ModelTag {
+	b.MethodCall(b, "ModelTag")
+	b.PopNoErr()
+	return coretesting.ModelTag
+}
+
+func (b *mockBackend) Machine(id string) (workerfailures.Machine, error) {
+	b.MethodCall(b, "Machine", id)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	m, ok := b.machines[id]
+	if !ok {
+		return nil, errors.NotFoundf("machine %s", id)
+	}
+	return m, nil
+}
+
+type mockMachine struct {
+	failures []status.WorkerFailure
+}
+
+func (m *mockMachine) Status() (status.StatusInfo, error) {
+	info := status.StatusInfo{
+		Status: status.Started,
+		Data:   map[string]interface{}{"foo": "bar"},
+	}
+	if len(m.failures) > 0 {
+		info.Data[status.WorkerFailuresKey] = status.WorkerFailuresData(m.failures)
+	}
+	return info, nil
+}
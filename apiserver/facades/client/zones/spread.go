@@ -0,0 +1,116 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package zones
+
+import (
+	"sort"
+
+	"github.com/juju/collections/set"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+// analyseSpread reports how the units of an application are spread
+// across zones, given the names of the available zones and the zone
+// hosting each unit. If the spread is uneven, it suggests the unit
+// moves that would even it out: units in zones that are no longer
+// available are always moved, then units are moved from the most to
+// the least populated zones until their unit counts differ by at most
+// one.
+func analyseSpread(application string, available []string, unitZones map[string]string) params.ApplicationZoneSpread {
+	result := params.ApplicationZoneSpread{
+		Application: application,
+		Units:       make(map[string][]string),
+	}
+	for unit, zone := range unitZones {
+		result.Units[zone] = append(result.Units[zone], unit)
+	}
+	for zone := range result.Units {
+		sortUnits(result.Units[zone])
+	}
+
+	// Work on a copy of the unit placement, so the moves can be
+	// simulated without affecting the reported placement.
+	availableSet := set.NewStrings(available...)
+	placed := make(map[string][]string)
+	var stranded []string
+	for zone, units := range result.Units {
+		if availableSet.Contains(zone) {
+			placed[zone] = append([]string(nil), units...)
+		} else {
+			stranded = append(stranded, units...)
+		}
+	}
+	sortUnits(stranded)
+
+	zones := availableSet.SortedValues()
+	if len(zones) == 0 {
+		// There's nowhere to move units to.
+		result.Balanced = len(stranded) == 0
+		return result
+	}
+	fewest := func() string {
+		target := zones[0]
+		for _, zone := range zones[1:] {
+			if len(placed[zone]) < len(placed[target]) {
+				target = zone
+			}
+		}
+		return target
+	}
+	most := func() string {
+		source := zones[0]
+		for _, zone := range zones[1:] {
+			if len(placed[zone]) > len(placed[source]) {
+				source = zone
+			}
+		}
+		return source
+	}
+
+	for _, unit := range stranded {
+		target := fewest()
+		result.Moves = append(result.Moves, params.UnitZoneMove{
+			Unit: unit,
+			From: unitZones[unit],
+			To:   target,
+		})
+		// The replacement unit isn't named until it's added.
+		placed[target] = append(placed[target], "")
+	}
+	for {
+		source, target := most(), fewest()
+		if len(placed[source])-len(placed[target]) <= 1 {
+			break
+		}
+		// Move the most recently added unit that is still in
+		// the source zone, leaving any replacement units alone.
+		units := placed[source]
+		i := len(units) - 1
+		for i > 0 && units[i] == "" {
+			i--
+		}
+		unit := units[i]
+		if unit == "" {
+			break
+		}
+		placed[source] = append(units[:i], units[i+1:]...)
+		placed[target] = append(placed[target], "")
+		result.Moves = append(result.Moves, params.UnitZoneMove{
+			Unit: unit,
+			From: source,
+			To:   target,
+		})
+	}
+	result.Balanced = len(result.Moves) == 0
+	return result
+}
+
+// sortUnits sorts unit names by unit number.
+func sortUnits(units []string) {
+	sort.Slice(units, func(i, j int) bool {
+		return names.NewUnitTag(units[i]).Number() < names.NewUnitTag(units[j]).Number()
+	})
+}
@@ -0,0 +1,116 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package zones
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	providercommon "github.com/juju/juju/provider/common"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/stateenvirons"
+)
+
+// Backend describes the model state and provider access needed by
+// the Zones facade.
+type Backend interface {
+	// ModelTag returns the tag of the model being analysed.
+	ModelTag() names.ModelTag
+
+	// AvailabilityZones returns the availability zones of the
+	// model, as reported by its provider.
+	AvailabilityZones(context.ProviderCallContext) ([]providercommon.AvailabilityZone, error)
+
+	// AllApplications returns all applications in the model.
+	AllApplications() ([]Application, error)
+
+	// Machine returns the machine with the given id.
+	Machine(string) (Machine, error)
+}
+
+// Application describes the application methods used by the facade.
+type Application interface {
+	Name() string
+	IsPrincipal() bool
+	AllUnits() ([]Unit, error)
+}
+
+// Unit describes the unit methods used by the facade.
+type Unit interface {
+	Name() string
+	AssignedMachineId() (string, error)
+}
+
+// Machine describes the machine methods used by the facade.
+type Machine interface {
+	AvailabilityZone() (string, error)
+}
+
+type stateShim struct {
+	configGetter stateenvirons.EnvironConfigGetter
+	st           *state.State
+}
+
+// NewStateBackend returns a Backend backed by the given state.
+func NewStateBackend(st *state.State) (Backend, error) {
+	m, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &stateShim{
+		configGetter: stateenvirons.EnvironConfigGetter{State: st, Model: m},
+		st:           st,
+	}, nil
+}
+
+func (s *stateShim) ModelTag() names.ModelTag {
+	return names.NewModelTag(s.st.ModelUUID())
+}
+
+func (s *stateShim) AvailabilityZones(ctx context.ProviderCallContext) ([]providercommon.AvailabilityZone, error) {
+	env, err := environs.GetEnviron(s.configGetter, environs.New)
+	if err != nil {
+		return nil, errors.Annotate(err, "opening environment")
+	}
+	zoned, ok := env.(providercommon.ZonedEnviron)
+	if !ok {
+		return nil, errors.NotSupportedf("availability zones")
+	}
+	zones, err := zoned.AvailabilityZones(ctx)
+	return zones, errors.Trace(err)
+}
+
+func (s *stateShim) AllApplications() ([]Application, error) {
+	apps, err := s.st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Application, len(apps))
+	for i, app := range apps {
+		result[i] = applicationShim{app}
+	}
+	return result, nil
+}
+
+func (s *stateShim) Machine(id string) (Machine, error) {
+	return s.st.Machine(id)
+}
+
+type applicationShim struct {
+	*state.Application
+}
+
+func (a applicationShim) AllUnits() ([]Unit, error) {
+	units, err := a.Application.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Unit, len(units))
+	for i, unit := range units {
+		result[i] = unit
+	}
+	return result, nil
+}
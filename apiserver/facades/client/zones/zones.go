@@ -0,0 +1,164 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package zones provides the API server facade for analysing how the
+// units of a model's applications are spread across availability
+// zones, so that an uneven spread (for example after recovering from
+// the loss of a zone) can be detected and corrected.
+package zones
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.zones")
+
+// API implements the Zones facade.
+type API struct {
+	backend     Backend
+	authorizer  facade.Authorizer
+	callContext context.ProviderCallContext
+}
+
+// NewFacade creates a new Zones API facade.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	backend, err := NewStateBackend(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(backend, state.CallContext(st), ctx.Auth())
+}
+
+// NewAPI returns a new Zones API facade.
+func NewAPI(backend Backend, callContext context.ProviderCallContext, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:     backend,
+		authorizer:  authorizer,
+		callContext: callContext,
+	}, nil
+}
+
+func (api *API) checkCanRead() error {
+	canRead, err := api.authorizer.HasPermission(permission.ReadAccess, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// ZoneReport returns the availability zones of the model, and for
+// each principal application the zones hosting its units along with
+// the unit moves that would spread them evenly across the available
+// zones. Units whose machines have not been provisioned, or have no
+// zone, are left out of the report.
+func (api *API) ZoneReport() (params.ZoneReport, error) {
+	var result params.ZoneReport
+	if err := api.checkCanRead(); err != nil {
+		return result, errors.Trace(err)
+	}
+
+	zones, err := api.backend.AvailabilityZones(api.callContext)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	var available []string
+	for _, zone := range zones {
+		result.Zones = append(result.Zones, params.ZoneResult{
+			Name:      zone.Name(),
+			Available: zone.Available(),
+		})
+		if zone.Available() {
+			available = append(available, zone.Name())
+		}
+	}
+	sort.Slice(result.Zones, func(i, j int) bool {
+		return result.Zones[i].Name < result.Zones[j].Name
+	})
+
+	apps, err := api.backend.AllApplications()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	sort.Slice(apps, func(i, j int) bool {
+		return apps[i].Name() < apps[j].Name()
+	})
+	machineZones := make(map[string]string)
+	for _, app := range apps {
+		// Subordinate units follow their principals.
+		if !app.IsPrincipal() {
+			continue
+		}
+		unitZones, err := api.unitZones(app, machineZones)
+		if err != nil {
+			return result, errors.Annotatef(err, "application %q", app.Name())
+		}
+		if len(unitZones) == 0 {
+			continue
+		}
+		result.Applications = append(result.Applications, analyseSpread(app.Name(), available, unitZones))
+	}
+	return result, nil
+}
+
+// unitZones returns the zone hosting each of the application's units.
+// Machine zones are cached in machineZones as they are looked up.
+func (api *API) unitZones(app Application, machineZones map[string]string) (map[string]string, error) {
+	units, err := app.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make(map[string]string)
+	for _, unit := range units {
+		machineId, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		// Containers are in the same zone as their host.
+		machineId = state.TopParentId(machineId)
+		zone, ok := machineZones[machineId]
+		if !ok {
+			zone, err = api.machineZone(machineId)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			machineZones[machineId] = zone
+		}
+		if zone == "" {
+			logger.Debugf("skipping unit %q with no known availability zone", unit.Name())
+			continue
+		}
+		result[unit.Name()] = zone
+	}
+	return result, nil
+}
+
+func (api *API) machineZone(id string) (string, error) {
+	machine, err := api.backend.Machine(id)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	zone, err := machine.AvailabilityZone()
+	if errors.IsNotProvisioned(err) {
+		return "", nil
+	}
+	return zone, errors.Trace(err)
+}
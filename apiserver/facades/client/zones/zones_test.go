@@ -0,0 +1,247 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package zones_test
+
+import (
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/zones"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/environs/context"
+	providercommon "github.com/juju/juju/provider/common"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type zonesSuite struct {
+	jtesting.IsolationSuite
+
+	backend *fakeBackend
+}
+
+var _ = gc.Suite(&zonesSuite{})
+
+func (s *zonesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &fakeBackend{
+		zones: []providercommon.AvailabilityZone{
+			fakeZone{"zone-c", true},
+			fakeZone{"zone-a", true},
+			fakeZone{"zone-b", true},
+		},
+		machines: map[string]string{
+			"0": "zone-a",
+			"1": "zone-a",
+			"2": "zone-a",
+			"3": "zone-b",
+			"4": "zone-c",
+		},
+	}
+}
+
+func (s *zonesSuite) newAPI(c *gc.C, user string) *zones.API {
+	api, err := zones.NewAPI(
+		s.backend,
+		context.NewCloudCallContext(),
+		apiservertesting.FakeAuthorizer{Tag: names.NewUserTag(user)},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	return api
+}
+
+func (s *zonesSuite) TestNewAPIRequiresClient(c *gc.C) {
+	_, err := zones.NewAPI(
+		s.backend,
+		context.NewCloudCallContext(),
+		apiservertesting.FakeAuthorizer{Tag: names.NewMachineTag("0")},
+	)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *zonesSuite) TestZoneReportPermissionDenied(c *gc.C) {
+	api := s.newAPI(c, "bob")
+	_, err := api.ZoneReport()
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *zonesSuite) TestZoneReportConcentrated(c *gc.C) {
+	s.backend.apps = []zones.Application{
+		&fakeApplication{name: "mysql", principal: true, units: map[string]string{
+			"mysql/0": "0",
+			"mysql/1": "1",
+			"mysql/2": "2",
+		}},
+		&fakeApplication{name: "redis", principal: true, units: map[string]string{
+			"redis/0": "0/lxd/0",
+			"redis/1": "3",
+			"redis/2": "4",
+		}},
+		&fakeApplication{name: "telegraf", units: map[string]string{
+			"telegraf/0": "0",
+		}},
+	}
+
+	result, err := s.newAPI(c, "admin").ZoneReport()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ZoneReport{
+		Zones: []params.ZoneResult{
+			{Name: "zone-a", Available: true},
+			{Name: "zone-b", Available: true},
+			{Name: "zone-c", Available: true},
+		},
+		Applications: []params.ApplicationZoneSpread{{
+			Application: "mysql",
+			Units: map[string][]string{
+				"zone-a": {"mysql/0", "mysql/1", "mysql/2"},
+			},
+			Moves: []params.UnitZoneMove{
+				{Unit: "mysql/2", From: "zone-a", To: "zone-b"},
+				{Unit: "mysql/1", From: "zone-a", To: "zone-c"},
+			},
+		}, {
+			Application: "redis",
+			Units: map[string][]string{
+				"zone-a": {"redis/0"},
+				"zone-b": {"redis/1"},
+				"zone-c": {"redis/2"},
+			},
+			Balanced: true,
+		}},
+	})
+}
+
+func (s *zonesSuite) TestZoneReportLostZone(c *gc.C) {
+	s.backend.zones[2] = fakeZone{"zone-b", false}
+	s.backend.apps = []zones.Application{
+		&fakeApplication{name: "mysql", principal: true, units: map[string]string{
+			"mysql/0": "0",
+			"mysql/1": "3",
+			"mysql/2": "4",
+		}},
+	}
+
+	result, err := s.newAPI(c, "admin").ZoneReport()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Zones, jc.DeepEquals, []params.ZoneResult{
+		{Name: "zone-a", Available: true},
+		{Name: "zone-b", Available: false},
+		{Name: "zone-c", Available: true},
+	})
+	c.Assert(result.Applications, gc.HasLen, 1)
+	c.Assert(result.Applications[0].Balanced, jc.IsFalse)
+	c.Assert(result.Applications[0].Moves, jc.DeepEquals, []params.UnitZoneMove{
+		{Unit: "mysql/1", From: "zone-b", To: "zone-a"},
+	})
+}
+
+func (s *zonesSuite) TestZoneReportSkipsUnplacedUnits(c *gc.C) {
+	s.backend.machines["5"] = ""
+	s.backend.apps = []zones.Application{
+		&fakeApplication{name: "mysql", principal: true, units: map[string]string{
+			"mysql/0": "0",
+			"mysql/1": "5",
+			"mysql/2": "",
+			"mysql/3": "6",
+		}},
+	}
+
+	result, err := s.newAPI(c, "admin").ZoneReport()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Applications, jc.DeepEquals, []params.ApplicationZoneSpread{{
+		Application: "mysql",
+		Units:       map[string][]string{"zone-a": {"mysql/0"}},
+		Balanced:    true,
+	}})
+}
+
+func (s *zonesSuite) TestZoneReportZonesNotSupported(c *gc.C) {
+	s.backend.zonesErr = errors.NotSupportedf("availability zones")
+	_, err := s.newAPI(c, "admin").ZoneReport()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+type fakeZone struct {
+	name      string
+	available bool
+}
+
+func (z fakeZone) Name() string    { return z.name }
+func (z fakeZone) Available() bool { return z.available }
+
+type fakeBackend struct {
+	zones    []providercommon.AvailabilityZone
+	zonesErr error
+	apps     []zones.Application
+
+	// machines maps the ids of provisioned machines to their zones.
+	machines map[string]string
+}
+
+func (b *fakeBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (b *fakeBackend) AvailabilityZones(context.ProviderCallContext) ([]providercommon.AvailabilityZone, error) {
+	return b.zones, b.zonesErr
+}
+
+func (b *fakeBackend) AllApplications() ([]zones.Application, error) {
+	return b.apps, nil
+}
+
+func (b *fakeBackend) Machine(id string) (zones.Machine, error) {
+	zone, ok := b.machines[id]
+	if !ok {
+		return fakeMachine{id: id, err: errors.NotProvisionedf("machine %v", id)}, nil
+	}
+	return fakeMachine{id: id, zone: zone}, nil
+}
+
+type fakeMachine struct {
+	id   string
+	zone string
+	err  error
+}
+
+func (m fakeMachine) AvailabilityZone() (string, error) {
+	return m.zone, m.err
+}
+
+type fakeApplication struct {
+	name      string
+	principal bool
+
+	// units maps unit names to the ids of their machines.
+	units map[string]string
+}
+
+func (a *fakeApplication) Name() string      { return a.name }
+func (a *fakeApplication) IsPrincipal() bool { return a.principal }
+
+func (a *fakeApplication) AllUnits() ([]zones.Unit, error) {
+	var units []zones.Unit
+	for name, machine := range a.units {
+		units = append(units, fakeUnit{name, machine})
+	}
+	return units, nil
+}
+
+type fakeUnit struct {
+	name    string
+	machine string
+}
+
+func (u fakeUnit) Name() string { return u.name }
+
+func (u fakeUnit) AssignedMachineId() (string, error) {
+	if u.machine == "" {
+		return "", errors.NotAssignedf("unit %q", u.name)
+	}
+	return u.machine, nil
+}
@@ -4,6 +4,8 @@
 package actionpruner
 
 import (
+	"github.com/juju/errors"
+
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
@@ -35,5 +37,23 @@ func (api *API) Prune(p params.ActionPruneArgs) error {
 		return common.ErrPerm
 	}
 
-	return state.PruneActions(api.st, p.MaxHistoryTime, p.MaxHistoryMB)
+	if err := state.PruneActions(api.st, p.MaxHistoryTime, p.MaxHistoryMB); err != nil {
+		return errors.Trace(err)
+	}
+
+	// The operations log, which records actions amongst other
+	// changes, is pruned alongside the actions, as are the model's
+	// lifecycle events which are kept for as long.
+	m, err := api.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	cfg, err := m.ModelConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := state.PruneOperations(api.st, cfg.MaxOperationsAge()); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(state.PruneModelEvents(api.st, cfg.MaxOperationsAge()))
 }
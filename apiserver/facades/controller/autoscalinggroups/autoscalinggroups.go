@@ -0,0 +1,244 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package autoscalinggroups implements the API used by the autoscaling
+// groups worker, which adds and removes the units of applications
+// backed by provider autoscaling groups as the cloud starts and stops
+// the instances in the groups.
+package autoscalinggroups
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/client/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/controller"
+	coreapplication "github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/state"
+)
+
+// placementPrefix prefixes the group name in the placement directive
+// recorded for the machines added for autoscaling group instances. It
+// distinguishes them from the other machines hosting an application's
+// units, which are never removed by the facade.
+const placementPrefix = "autoscaling-group="
+
+// noncePrefix prefixes the instance id in the provisioning nonce of the
+// machines added for autoscaling group instances. The agent on the
+// instance must be started with the same nonce.
+const noncePrefix = "autoscaling-group:"
+
+// Backend exposes the state functionality required by the facade.
+type Backend interface {
+	AllApplications() ([]Application, error)
+	Application(name string) (Application, error)
+	Machine(id string) (Machine, error)
+	AddOneMachine(template state.MachineTemplate) (Machine, error)
+	CheckModelQuota(quota string, adding int) error
+}
+
+// Application exposes the application functionality required by the
+// facade.
+type Application interface {
+	Name() string
+	Life() state.Life
+	Series() string
+	ApplicationConfig() (coreapplication.ConfigAttributes, error)
+
+	// UnitMachineIds returns the ids of the machines to which the
+	// application's units are assigned.
+	UnitMachineIds() ([]string, error)
+
+	// AddUnitToMachine adds a unit of the application and assigns it
+	// to the machine with the given id.
+	AddUnitToMachine(machineId string) error
+}
+
+// Machine exposes the machine functionality required by the facade.
+type Machine interface {
+	Id() string
+	Placement() string
+	InstanceId() (instance.Id, error)
+	ForceDestroy(maxWait time.Duration) error
+}
+
+// API implements the AutoscalingGroups facade.
+type API struct {
+	backend Backend
+}
+
+// NewAPI returns a new AutoscalingGroups API facade.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{backend: backend}, nil
+}
+
+// AutoscaledApplications returns the alive applications which are
+// backed by provider autoscaling groups, along with the machines which
+// have been added for the instances in their groups.
+func (api *API) AutoscaledApplications() (params.AutoscaledApplicationsResult, error) {
+	apps, err := api.backend.AllApplications()
+	if err != nil {
+		return params.AutoscaledApplicationsResult{}, errors.Trace(err)
+	}
+	var result params.AutoscaledApplicationsResult
+	for _, app := range apps {
+		if app.Life() != state.Alive {
+			continue
+		}
+		group, err := autoscalingGroup(app)
+		if err != nil {
+			return params.AutoscaledApplicationsResult{}, errors.Trace(err)
+		}
+		if group == "" {
+			continue
+		}
+		machines, err := api.groupMachines(app, group)
+		if err != nil {
+			return params.AutoscaledApplicationsResult{}, errors.Annotatef(err, "getting machines of %q", app.Name())
+		}
+		result.Results = append(result.Results, params.AutoscaledApplication{
+			ApplicationTag: names.NewApplicationTag(app.Name()).String(),
+			Group:          group,
+			Machines:       machines,
+		})
+	}
+	return result, nil
+}
+
+// groupMachines returns the machines hosting the application's units
+// which were added for instances in the group.
+func (api *API) groupMachines(app Application, group string) ([]params.AutoscaledMachine, error) {
+	ids, err := app.UnitMachineIds()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []params.AutoscaledMachine
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		m, err := api.backend.Machine(id)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if m.Placement() != placementPrefix+group {
+			continue
+		}
+		instId, err := m.InstanceId()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result = append(result, params.AutoscaledMachine{
+			MachineTag: names.NewMachineTag(id).String(),
+			InstanceId: string(instId),
+		})
+	}
+	return result, nil
+}
+
+// AddAutoscaledMachines adds a machine for each of the instances which
+// have appeared in the autoscaling group of an application, and places
+// a unit of the application on it. The machine is recorded as
+// provisioned on the instance, so it is not started by the provisioner.
+// Nothing is added for an application if the machines and units would
+// take the model over its quotas.
+func (api *API) AddAutoscaledMachines(args params.AddAutoscaledMachinesArgs) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.addAutoscaledMachines(arg)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *API) addAutoscaledMachines(arg params.AddAutoscaledMachines) error {
+	tag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := api.backend.Application(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	group, err := autoscalingGroup(app)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if group == "" {
+		return errors.NotValidf("application %q without an autoscaling group", app.Name())
+	}
+	if err := api.backend.CheckModelQuota(controller.MaxModelMachines, len(arg.InstanceIds)); err != nil {
+		return errors.Trace(err)
+	}
+	if err := api.backend.CheckModelQuota(controller.MaxModelUnits, len(arg.InstanceIds)); err != nil {
+		return errors.Trace(err)
+	}
+	for _, instId := range arg.InstanceIds {
+		m, err := api.backend.AddOneMachine(state.MachineTemplate{
+			Series:     app.Series(),
+			Jobs:       []state.MachineJob{state.JobHostUnits},
+			InstanceId: instance.Id(instId),
+			Nonce:      noncePrefix + instId,
+			Placement:  placementPrefix + group,
+		})
+		if err != nil {
+			return errors.Annotatef(err, "adding machine for instance %q", instId)
+		}
+		if err := app.AddUnitToMachine(m.Id()); err != nil {
+			return errors.Annotatef(err, "adding unit on machine %q", m.Id())
+		}
+	}
+	return nil
+}
+
+// RemoveAutoscaledMachines removes the machines, along with the units
+// on them, whose instances have gone from their autoscaling groups.
+func (api *API) RemoveAutoscaledMachines(args params.Entities) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		err := api.removeAutoscaledMachine(entity.Tag)
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (api *API) removeAutoscaledMachine(machineTag string) error {
+	tag, err := names.ParseMachineTag(machineTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	m, err := api.backend.Machine(tag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !strings.HasPrefix(m.Placement(), placementPrefix) {
+		return errors.NotValidf("machine %q not added for an autoscaling group", tag.Id())
+	}
+	return errors.Trace(m.ForceDestroy(common.MaxWait(nil)))
+}
+
+func autoscalingGroup(app Application) (string, error) {
+	config, err := app.ApplicationConfig()
+	if err != nil {
+		return "", errors.Annotatef(err, "getting config of %q", app.Name())
+	}
+	return application.AutoscalingGroup(config), nil
+}
@@ -0,0 +1,173 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscalinggroups_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/controller/autoscalinggroups"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/controller"
+	coreapplication "github.com/juju/juju/core/application"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type AutoscalingGroupsSuite struct {
+	coretesting.BaseSuite
+
+	backend *mockBackend
+	api     *autoscalinggroups.API
+}
+
+var _ = gc.Suite(&AutoscalingGroupsSuite{})
+
+func (s *AutoscalingGroupsSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{}
+	stub := &s.backend.Stub
+	s.backend.applications = map[string]*mockApplication{
+		"wordpress": {
+			Stub:       stub,
+			name:       "wordpress",
+			life:       state.Alive,
+			config:     coreapplication.ConfigAttributes{"autoscaling-group": "web"},
+			machineIds: []string{"0", "1", "0"},
+		},
+		"mysql": {
+			Stub:       stub,
+			name:       "mysql",
+			life:       state.Alive,
+			config:     coreapplication.ConfigAttributes{},
+			machineIds: []string{"2"},
+		},
+	}
+	s.backend.machines = map[string]*mockMachine{
+		"0": {Stub: stub, id: "0", placement: "autoscaling-group=web", instanceId: "i-0"},
+		"1": {Stub: stub, id: "1", instanceId: "i-1"},
+		"2": {Stub: stub, id: "2", instanceId: "i-2"},
+	}
+	var err error
+	s.api, err = autoscalinggroups.NewAPI(s.backend, apiservertesting.FakeAuthorizer{Controller: true})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *AutoscalingGroupsSuite) TestNewAPIRequiresController(c *gc.C) {
+	api, err := autoscalinggroups.NewAPI(s.backend, apiservertesting.FakeAuthorizer{Controller: false})
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *AutoscalingGroupsSuite) TestAutoscaledApplications(c *gc.C) {
+	result, err := s.api.AutoscaledApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AutoscaledApplicationsResult{
+		Results: []params.AutoscaledApplication{{
+			ApplicationTag: "application-wordpress",
+			Group:          "web",
+			Machines: []params.AutoscaledMachine{{
+				MachineTag: "machine-0",
+				InstanceId: "i-0",
+			}},
+		}},
+	})
+	s.backend.CheckCallNames(c,
+		"AllApplications",
+		"ApplicationConfig",
+		"ApplicationConfig", "UnitMachineIds", "Machine", "Machine",
+	)
+}
+
+func (s *AutoscalingGroupsSuite) TestAutoscaledApplicationsSkipsDying(c *gc.C) {
+	s.backend.applications["wordpress"].life = state.Dying
+	result, err := s.api.AutoscaledApplications()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 0)
+}
+
+func (s *AutoscalingGroupsSuite) TestAddAutoscaledMachines(c *gc.C) {
+	result, err := s.api.AddAutoscaledMachines(params.AddAutoscaledMachinesArgs{
+		Args: []params.AddAutoscaledMachines{{
+			ApplicationTag: "application-wordpress",
+			InstanceIds:    []string{"i-3"},
+		}, {
+			ApplicationTag: "application-mysql",
+			InstanceIds:    []string{"i-4"},
+		}, {
+			ApplicationTag: "machine-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `application "mysql" without an autoscaling group not valid`)
+	c.Assert(result.Results[2].Error, gc.ErrorMatches, `"machine-0" is not a valid application tag`)
+
+	s.backend.CheckCall(c, 2, "CheckModelQuota", controller.MaxModelMachines, 1)
+	s.backend.CheckCall(c, 3, "CheckModelQuota", controller.MaxModelUnits, 1)
+	s.backend.CheckCall(c, 4, "AddOneMachine", state.MachineTemplate{
+		Series:     "bionic",
+		Jobs:       []state.MachineJob{state.JobHostUnits},
+		InstanceId: "i-3",
+		Nonce:      "autoscaling-group:i-3",
+		Placement:  "autoscaling-group=web",
+	})
+	s.backend.CheckCall(c, 5, "AddUnitToMachine", "3")
+}
+
+func (s *AutoscalingGroupsSuite) TestAddAutoscaledMachinesQuotaExceeded(c *gc.C) {
+	s.backend.SetErrors(nil, nil, nil, &state.ErrQuotaExceeded{
+		Quota:  controller.MaxModelUnits,
+		Entity: "units",
+		Limit:  3,
+		Count:  3,
+		Adding: 2,
+	})
+	result, err := s.api.AddAutoscaledMachines(params.AddAutoscaledMachinesArgs{
+		Args: []params.AddAutoscaledMachines{{
+			ApplicationTag: "application-wordpress",
+			InstanceIds:    []string{"i-3", "i-4"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `cannot add 2 units: model has 3 of 3 allowed by max-model-units`)
+	c.Assert(result.OneError(), jc.Satisfies, params.IsCodeQuotaLimitExceeded)
+	s.backend.CheckCallNames(c, "Application", "ApplicationConfig", "CheckModelQuota", "CheckModelQuota")
+}
+
+func (s *AutoscalingGroupsSuite) TestAddAutoscaledMachinesError(c *gc.C) {
+	s.backend.SetErrors(nil, nil, nil, nil, errors.New("boom"))
+	result, err := s.api.AddAutoscaledMachines(params.AddAutoscaledMachinesArgs{
+		Args: []params.AddAutoscaledMachines{{
+			ApplicationTag: "application-wordpress",
+			InstanceIds:    []string{"i-3"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.OneError(), gc.ErrorMatches, `adding machine for instance "i-3": boom`)
+	s.backend.CheckCallNames(c, "Application", "ApplicationConfig", "CheckModelQuota", "CheckModelQuota", "AddOneMachine")
+}
+
+func (s *AutoscalingGroupsSuite) TestRemoveAutoscaledMachines(c *gc.C) {
+	result, err := s.api.RemoveAutoscaledMachines(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "machine-0"},
+			{Tag: "machine-1"},
+			{Tag: "machine-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 3)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.ErrorMatches, `machine "1" not added for an autoscaling group not valid`)
+	c.Assert(result.Results[2].Error, jc.Satisfies, params.IsCodeNotFound)
+
+	s.backend.CheckCallNames(c, "Machine", "ForceDestroy", "Machine", "Machine")
+	s.backend.CheckCall(c, 1, "ForceDestroy", time.Minute)
+}
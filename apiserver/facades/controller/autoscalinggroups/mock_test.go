@@ -0,0 +1,144 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscalinggroups_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+
+	"github.com/juju/juju/apiserver/facades/controller/autoscalinggroups"
+	coreapplication "github.com/juju/juju/core/application"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/state"
+)
+
+type mockBackend struct {
+	testing.Stub
+	applications map[string]*mockApplication
+	machines     map[string]*mockMachine
+}
+
+func (b *mockBackend) AllApplications() ([]autoscalinggroups.Application, error) {
+	b.MethodCall(b, "AllApplications")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	var result []autoscalinggroups.Application
+	for _, name := range []string{"mysql", "wordpress"} {
+		if app, ok := b.applications[name]; ok {
+			result = append(result, app)
+		}
+	}
+	return result, nil
+}
+
+func (b *mockBackend) Application(name string) (autoscalinggroups.Application, error) {
+	b.MethodCall(b, "Application", name)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	app, ok := b.applications[name]
+	if !ok {
+		return nil, errors.NotFoundf("application %q", name)
+	}
+	return app, nil
+}
+
+func (b *mockBackend) Machine(id string) (autoscalinggroups.Machine, error) {
+	b.MethodCall(b, "Machine", id)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	m, ok := b.machines[id]
+	if !ok {
+		return nil, errors.NotFoundf("machine %q", id)
+	}
+	return m, nil
+}
+
+func (b *mockBackend) AddOneMachine(template state.MachineTemplate) (autoscalinggroups.Machine, error) {
+	b.MethodCall(b, "AddOneMachine", template)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	m := &mockMachine{
+		Stub:       &b.Stub,
+		id:         fmt.Sprint(len(b.machines)),
+		placement:  template.Placement,
+		instanceId: template.InstanceId,
+	}
+	b.machines[m.id] = m
+	return m, nil
+}
+
+func (b *mockBackend) CheckModelQuota(quota string, adding int) error {
+	b.MethodCall(b, "CheckModelQuota", quota, adding)
+	return b.NextErr()
+}
+
+type mockApplication struct {
+	*testing.Stub
+	name       string
+	life       state.Life
+	config     coreapplication.ConfigAttributes
+	machineIds []string
+}
+
+func (a *mockApplication) Name() string {
+	return a.name
+}
+
+func (a *mockApplication) Life() state.Life {
+	return a.life
+}
+
+func (a *mockApplication) Series() string {
+	return "bionic"
+}
+
+func (a *mockApplication) ApplicationConfig() (coreapplication.ConfigAttributes, error) {
+	a.MethodCall(a, "ApplicationConfig")
+	return a.config, a.NextErr()
+}
+
+func (a *mockApplication) UnitMachineIds() ([]string, error) {
+	a.MethodCall(a, "UnitMachineIds")
+	return a.machineIds, a.NextErr()
+}
+
+func (a *mockApplication) AddUnitToMachine(machineId string) error {
+	a.MethodCall(a, "AddUnitToMachine", machineId)
+	if err := a.NextErr(); err != nil {
+		return err
+	}
+	a.machineIds = append(a.machineIds, machineId)
+	return nil
+}
+
+type mockMachine struct {
+	*testing.Stub
+	id         string
+	placement  string
+	instanceId instance.Id
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
+}
+
+func (m *mockMachine) Placement() string {
+	return m.placement
+}
+
+func (m *mockMachine) InstanceId() (instance.Id, error) {
+	return m.instanceId, nil
+}
+
+func (m *mockMachine) ForceDestroy(maxWait time.Duration) error {
+	m.MethodCall(m, "ForceDestroy", maxWait)
+	return m.NextErr()
+}
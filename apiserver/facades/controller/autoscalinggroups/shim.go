@@ -0,0 +1,105 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscalinggroups
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb.
+
+// NewFacade provides the required signature for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(backendShim{st: ctx.State()}, ctx.Auth())
+}
+
+type backendShim struct {
+	st *state.State
+}
+
+// AllApplications is part of the Backend interface.
+func (shim backendShim) AllApplications() ([]Application, error) {
+	apps, err := shim.st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Application, len(apps))
+	for i, app := range apps {
+		result[i] = applicationShim{Application: app, st: shim.st}
+	}
+	return result, nil
+}
+
+// Application is part of the Backend interface.
+func (shim backendShim) Application(name string) (Application, error) {
+	app, err := shim.st.Application(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return applicationShim{Application: app, st: shim.st}, nil
+}
+
+// Machine is part of the Backend interface.
+func (shim backendShim) Machine(id string) (Machine, error) {
+	m, err := shim.st.Machine(id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return m, nil
+}
+
+// AddOneMachine is part of the Backend interface.
+func (shim backendShim) AddOneMachine(template state.MachineTemplate) (Machine, error) {
+	m, err := shim.st.AddOneMachine(template)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return m, nil
+}
+
+// CheckModelQuota is part of the Backend interface.
+func (shim backendShim) CheckModelQuota(quota string, adding int) error {
+	return shim.st.CheckModelQuota(quota, adding)
+}
+
+type applicationShim struct {
+	*state.Application
+	st *state.State
+}
+
+// UnitMachineIds is part of the Application interface.
+func (shim applicationShim) UnitMachineIds() ([]string, error) {
+	units, err := shim.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var ids []string
+	for _, unit := range units {
+		id, err := unit.AssignedMachineId()
+		if errors.IsNotAssigned(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// AddUnitToMachine is part of the Application interface.
+func (shim applicationShim) AddUnitToMachine(machineId string) error {
+	m, err := shim.st.Machine(machineId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	unit, err := shim.AddUnit(state.AddUnitParams{})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(unit.AssignToMachine(m))
+}
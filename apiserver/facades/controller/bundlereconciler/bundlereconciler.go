@@ -0,0 +1,170 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package bundlereconciler implements the API used by the bundle
+// reconciler worker, which brings a model into line with the bundle
+// named by the model's bundle-source.
+package bundlereconciler
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
+)
+
+// Backend exposes the state functionality required by the facade.
+type Backend interface {
+	state.ModelAccessor
+
+	// WatchModelBundles returns a watcher which triggers when a new
+	// revision of the model's bundle is stored.
+	WatchModelBundles() state.NotifyWatcher
+
+	// WatchBundlePlanApproval returns a watcher which triggers when a
+	// plan is approved for the model.
+	WatchBundlePlanApproval() state.NotifyWatcher
+
+	// LatestModelBundle returns the latest revision of the model's
+	// bundle.
+	LatestModelBundle() (state.ModelBundle, error)
+
+	// BundlePlanApproval returns the most recently approved plan.
+	BundlePlanApproval() (state.BundlePlanApproval, error)
+
+	// ModelStatus returns the status of the model.
+	ModelStatus() (status.StatusInfo, error)
+
+	// SetModelStatus sets the status of the model.
+	SetModelStatus(status.StatusInfo) error
+}
+
+// Planner computes and applies the plans which bring the model into
+// line with a bundle.
+type Planner interface {
+	PlanModel(args params.ModelPlanArgs) (params.ModelPlanResult, error)
+	ApplyModelPlan(args params.ModelPlanArgs) (params.ApplyModelPlanResult, error)
+}
+
+// API implements the BundleReconciler facade.
+type API struct {
+	*common.ModelWatcher
+	backend   Backend
+	planner   Planner
+	resources facade.Resources
+}
+
+// NewAPI returns a new BundleReconciler API facade.
+func NewAPI(
+	backend Backend,
+	planner Planner,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		ModelWatcher: common.NewModelWatcher(backend, resources, authorizer),
+		backend:      backend,
+		planner:      planner,
+		resources:    resources,
+	}, nil
+}
+
+// WatchBundleChanges returns a NotifyWatcher which triggers whenever a
+// new revision of the model's bundle is stored, or a plan is approved.
+func (api *API) WatchBundleChanges() (params.NotifyWatchResult, error) {
+	var result params.NotifyWatchResult
+	w := common.NewMultiNotifyWatcher(
+		api.backend.WatchModelBundles(),
+		api.backend.WatchBundlePlanApproval(),
+	)
+	if _, ok := <-w.Changes(); ok {
+		result.NotifyWatcherId = api.resources.Register(w)
+	} else {
+		result.Error = common.ServerError(watcher.EnsureErr(w))
+	}
+	return result, nil
+}
+
+// DesiredBundle returns the latest revision of the bundle stored for
+// the model, or a not found error if there is none.
+func (api *API) DesiredBundle() (params.ModelBundleResult, error) {
+	bundle, err := api.backend.LatestModelBundle()
+	if err != nil {
+		return params.ModelBundleResult{Error: common.ServerError(err)}, nil
+	}
+	return params.ModelBundleResult{
+		Result: &params.ModelBundle{
+			Revision:  bundle.Revision,
+			Bundle:    bundle.Bundle,
+			CreatedBy: bundle.CreatedBy,
+			Created:   bundle.Created,
+		},
+	}, nil
+}
+
+// ApprovedPlan returns the fingerprint of the most recently approved
+// plan for the model, or an empty string if none has been approved.
+func (api *API) ApprovedPlan() (params.StringResult, error) {
+	approval, err := api.backend.BundlePlanApproval()
+	if errors.IsNotFound(err) {
+		return params.StringResult{}, nil
+	} else if err != nil {
+		return params.StringResult{Error: common.ServerError(err)}, nil
+	}
+	return params.StringResult{Result: approval.Fingerprint}, nil
+}
+
+// PlanModel returns the changes needed to bring the model into line
+// with the given bundle.
+func (api *API) PlanModel(args params.ModelPlanArgs) (params.ModelPlanResult, error) {
+	result, err := api.planner.PlanModel(args)
+	if err != nil {
+		return params.ModelPlanResult{}, common.ServerError(err)
+	}
+	return result, nil
+}
+
+// ApplyModelPlan applies the changes needed to bring the model into
+// line with the given bundle, if they match the given fingerprint.
+func (api *API) ApplyModelPlan(args params.ModelPlanArgs) (params.ApplyModelPlanResult, error) {
+	result, err := api.planner.ApplyModelPlan(args)
+	if err != nil {
+		return params.ApplyModelPlanResult{}, common.ServerError(err)
+	}
+	return result, nil
+}
+
+// bundleSourceKey is the status data key identifying the drift reports
+// of the bundle reconciler.
+const bundleSourceKey = "bundle-source"
+
+// SetBundleDrift reports the drift of the model from its bundle in the
+// model's status message. The status is only changed while the model
+// is available, so that drift does not hide more important states, and
+// an empty message only clears a status message reported by the
+// reconciler.
+func (api *API) SetBundleDrift(args params.BundleDrift) error {
+	current, err := api.backend.ModelStatus()
+	if err != nil {
+		return common.ServerError(err)
+	}
+	if current.Status != status.Available {
+		return nil
+	}
+	if _, ok := current.Data[bundleSourceKey]; args.Message == "" && !ok {
+		return nil
+	}
+	err = api.backend.SetModelStatus(status.StatusInfo{
+		Status:  status.Available,
+		Message: args.Message,
+		Data:    args.Data,
+	})
+	return common.ServerError(err)
+}
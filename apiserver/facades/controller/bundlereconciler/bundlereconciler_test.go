@@ -0,0 +1,166 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundlereconciler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/bundlereconciler"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type BundleReconcilerSuite struct {
+	coretesting.BaseSuite
+
+	backend   *mockBackend
+	planner   *mockPlanner
+	resources *common.Resources
+	api       *bundlereconciler.API
+}
+
+var _ = gc.Suite(&BundleReconcilerSuite{})
+
+func (s *BundleReconcilerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		status: status.StatusInfo{Status: status.Available},
+	}
+	s.planner = &mockPlanner{}
+	s.resources = common.NewResources()
+	s.AddCleanup(func(*gc.C) { s.resources.StopAll() })
+	var err error
+	s.api, err = bundlereconciler.NewAPI(
+		s.backend, s.planner, s.resources,
+		apiservertesting.FakeAuthorizer{Controller: true},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *BundleReconcilerSuite) TestNewAPIRequiresController(c *gc.C) {
+	api, err := bundlereconciler.NewAPI(
+		s.backend, s.planner, s.resources,
+		apiservertesting.FakeAuthorizer{Controller: false},
+	)
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *BundleReconcilerSuite) TestWatchBundleChanges(c *gc.C) {
+	result, err := s.api.WatchBundleChanges()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	c.Assert(result.NotifyWatcherId, gc.Equals, "1")
+	c.Assert(s.resources.Get("1"), gc.NotNil)
+	s.backend.CheckCallNames(c, "WatchModelBundles", "WatchBundlePlanApproval")
+}
+
+func (s *BundleReconcilerSuite) TestDesiredBundle(c *gc.C) {
+	created := time.Date(2020, 5, 1, 9, 0, 0, 0, time.UTC)
+	s.backend.bundle = &state.ModelBundle{
+		Revision:  3,
+		Bundle:    "applications: {}",
+		CreatedBy: "bob",
+		Created:   created,
+	}
+	result, err := s.api.DesiredBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ModelBundleResult{
+		Result: &params.ModelBundle{
+			Revision:  3,
+			Bundle:    "applications: {}",
+			CreatedBy: "bob",
+			Created:   created,
+		},
+	})
+}
+
+func (s *BundleReconcilerSuite) TestDesiredBundleNotFound(c *gc.C) {
+	result, err := s.api.DesiredBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, gc.IsNil)
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotFound)
+}
+
+func (s *BundleReconcilerSuite) TestApprovedPlan(c *gc.C) {
+	s.backend.fingerprint = "abc"
+	result, err := s.api.ApprovedPlan()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringResult{Result: "abc"})
+}
+
+func (s *BundleReconcilerSuite) TestApprovedPlanNone(c *gc.C) {
+	result, err := s.api.ApprovedPlan()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringResult{})
+}
+
+func (s *BundleReconcilerSuite) TestPlanModel(c *gc.C) {
+	args := params.ModelPlanArgs{BundleDataYAML: "applications: {}"}
+	result, err := s.api.PlanModel(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Fingerprint, gc.Equals, "abc")
+	s.planner.CheckCall(c, 0, "PlanModel", args)
+}
+
+func (s *BundleReconcilerSuite) TestApplyModelPlanError(c *gc.C) {
+	s.planner.SetErrors(errors.New("model has changed since the plan was made"))
+	args := params.ModelPlanArgs{BundleDataYAML: "applications: {}", Fingerprint: "abc"}
+	_, err := s.api.ApplyModelPlan(args)
+	c.Assert(err, gc.ErrorMatches, "model has changed since the plan was made")
+	s.planner.CheckCall(c, 0, "ApplyModelPlan", args)
+}
+
+func (s *BundleReconcilerSuite) TestSetBundleDrift(c *gc.C) {
+	data := map[string]interface{}{"bundle-source": "controller", "bundle-plan": "abc"}
+	err := s.api.SetBundleDrift(params.BundleDrift{
+		Message: "bundle drift: 1 change awaiting approval",
+		Data:    data,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "ModelStatus", "SetModelStatus")
+	s.backend.CheckCall(c, 1, "SetModelStatus", status.StatusInfo{
+		Status:  status.Available,
+		Message: "bundle drift: 1 change awaiting approval",
+		Data:    data,
+	})
+}
+
+func (s *BundleReconcilerSuite) TestSetBundleDriftModelNotAvailable(c *gc.C) {
+	s.backend.status = status.StatusInfo{Status: status.Suspended}
+	err := s.api.SetBundleDrift(params.BundleDrift{Message: "bundle drift"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "ModelStatus")
+}
+
+func (s *BundleReconcilerSuite) TestClearBundleDrift(c *gc.C) {
+	s.backend.status = status.StatusInfo{
+		Status:  status.Available,
+		Message: "bundle drift: 1 change awaiting approval",
+		Data:    map[string]interface{}{"bundle-source": "controller"},
+	}
+	err := s.api.SetBundleDrift(params.BundleDrift{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCall(c, 1, "SetModelStatus", status.StatusInfo{
+		Status: status.Available,
+	})
+}
+
+func (s *BundleReconcilerSuite) TestClearBundleDriftKeepsOtherMessages(c *gc.C) {
+	s.backend.status = status.StatusInfo{
+		Status:  status.Available,
+		Message: "some other message",
+	}
+	err := s.api.SetBundleDrift(params.BundleDrift{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "ModelStatus")
+}
@@ -0,0 +1,94 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundlereconciler_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mockBackend struct {
+	testing.Stub
+	bundle      *state.ModelBundle
+	fingerprint string
+	status      status.StatusInfo
+}
+
+func (b *mockBackend) WatchForModelConfigChanges() state.NotifyWatcher {
+	b.MethodCall(b, "WatchForModelConfigChanges")
+	return nil
+}
+
+func (b *mockBackend) ModelConfig() (*config.Config, error) {
+	b.MethodCall(b, "ModelConfig")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return config.New(config.UseDefaults, coretesting.FakeConfig())
+}
+
+func (b *mockBackend) WatchModelBundles() state.NotifyWatcher {
+	b.MethodCall(b, "WatchModelBundles")
+	return apiservertesting.NewFakeNotifyWatcher()
+}
+
+func (b *mockBackend) WatchBundlePlanApproval() state.NotifyWatcher {
+	b.MethodCall(b, "WatchBundlePlanApproval")
+	return apiservertesting.NewFakeNotifyWatcher()
+}
+
+func (b *mockBackend) LatestModelBundle() (state.ModelBundle, error) {
+	b.MethodCall(b, "LatestModelBundle")
+	if err := b.NextErr(); err != nil {
+		return state.ModelBundle{}, err
+	}
+	if b.bundle == nil {
+		return state.ModelBundle{}, errors.NotFoundf("model bundle")
+	}
+	return *b.bundle, nil
+}
+
+func (b *mockBackend) BundlePlanApproval() (state.BundlePlanApproval, error) {
+	b.MethodCall(b, "BundlePlanApproval")
+	if err := b.NextErr(); err != nil {
+		return state.BundlePlanApproval{}, err
+	}
+	if b.fingerprint == "" {
+		return state.BundlePlanApproval{}, errors.NotFoundf("bundle plan approval")
+	}
+	return state.BundlePlanApproval{Fingerprint: b.fingerprint}, nil
+}
+
+func (b *mockBackend) ModelStatus() (status.StatusInfo, error) {
+	b.MethodCall(b, "ModelStatus")
+	return b.status, b.NextErr()
+}
+
+func (b *mockBackend) SetModelStatus(info status.StatusInfo) error {
+	b.MethodCall(b, "SetModelStatus", info)
+	return b.NextErr()
+}
+
+type mockPlanner struct {
+	testing.Stub
+}
+
+func (p *mockPlanner) PlanModel(args params.ModelPlanArgs) (params.ModelPlanResult, error) {
+	p.MethodCall(p, "PlanModel", args)
+	return params.ModelPlanResult{Fingerprint: "abc"}, p.NextErr()
+}
+
+func (p *mockPlanner) ApplyModelPlan(args params.ModelPlanArgs) (params.ApplyModelPlanResult, error) {
+	p.MethodCall(p, "ApplyModelPlan", args)
+	return params.ApplyModelPlanResult{
+		Results: []params.ModelPlanChangeResult{{Id: "addCharm-0"}},
+	}, p.NextErr()
+}
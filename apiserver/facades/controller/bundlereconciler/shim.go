@@ -0,0 +1,121 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundlereconciler
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/client/bundle"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb.
+
+// NewFacade provides the required signature for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Changes are applied through the client facades, acting with
+	// write access to this model only.
+	applierCtx := reconcilerContext{
+		Context: ctx,
+		auth: reconcilerAuthorizer{
+			Authorizer: ctx.Auth(),
+			modelTag:   model.ModelTag(),
+		},
+	}
+	planner := bundle.NewModelPlanner(bundle.NewStateShim(st), bundle.NewStateApplier(applierCtx))
+	return NewAPI(
+		backendShim{st: st, model: model},
+		planner,
+		ctx.Resources(),
+		ctx.Auth(),
+	)
+}
+
+// reconcilerContext is a facade context whose authorizer is that of the
+// reconciler acting on the model.
+type reconcilerContext struct {
+	facade.Context
+	auth facade.Authorizer
+}
+
+// Auth is part of the facade.Context interface.
+func (ctx reconcilerContext) Auth() facade.Authorizer {
+	return ctx.auth
+}
+
+// reconcilerAuthorizer authorizes the controller agent running the
+// bundle reconciler to use the client facades to change its model.
+type reconcilerAuthorizer struct {
+	facade.Authorizer
+	modelTag names.ModelTag
+}
+
+// AuthClient is part of the facade.Authorizer interface.
+func (a reconcilerAuthorizer) AuthClient() bool {
+	return true
+}
+
+// HasPermission is part of the facade.Authorizer interface.
+func (a reconcilerAuthorizer) HasPermission(operation permission.Access, target names.Tag) (bool, error) {
+	if target == a.modelTag && operation != permission.AdminAccess {
+		return true, nil
+	}
+	return a.Authorizer.HasPermission(operation, target)
+}
+
+type backendShim struct {
+	st    *state.State
+	model *state.Model
+}
+
+// WatchForModelConfigChanges is part of the Backend interface.
+func (shim backendShim) WatchForModelConfigChanges() state.NotifyWatcher {
+	return shim.model.WatchForModelConfigChanges()
+}
+
+// ModelConfig is part of the Backend interface.
+func (shim backendShim) ModelConfig() (*config.Config, error) {
+	return shim.model.ModelConfig()
+}
+
+// WatchModelBundles is part of the Backend interface.
+func (shim backendShim) WatchModelBundles() state.NotifyWatcher {
+	return shim.st.WatchModelBundles()
+}
+
+// WatchBundlePlanApproval is part of the Backend interface.
+func (shim backendShim) WatchBundlePlanApproval() state.NotifyWatcher {
+	return shim.st.WatchBundlePlanApproval()
+}
+
+// LatestModelBundle is part of the Backend interface.
+func (shim backendShim) LatestModelBundle() (state.ModelBundle, error) {
+	return shim.st.LatestModelBundle()
+}
+
+// BundlePlanApproval is part of the Backend interface.
+func (shim backendShim) BundlePlanApproval() (state.BundlePlanApproval, error) {
+	return shim.st.BundlePlanApproval()
+}
+
+// ModelStatus is part of the Backend interface.
+func (shim backendShim) ModelStatus() (status.StatusInfo, error) {
+	return shim.model.Status()
+}
+
+// SetModelStatus is part of the Backend interface.
+func (shim backendShim) SetModelStatus(info status.StatusInfo) error {
+	return shim.model.SetStatus(info)
+}
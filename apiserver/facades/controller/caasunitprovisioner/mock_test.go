@@ -30,10 +30,12 @@ import (
 
 type mockState struct {
 	testing.Stub
-	application         mockApplication
-	applicationsWatcher *statetesting.MockStringsWatcher
-	model               mockModel
-	unit                mockUnit
+	application                mockApplication
+	applicationsWatcher        *statetesting.MockStringsWatcher
+	model                      mockModel
+	unit                       mockUnit
+	registryCredentials        []state.RegistryCredential
+	registryCredentialsWatcher *statetesting.MockNotifyWatcher
 }
 
 func (st *mockState) WatchApplications() state.StringsWatcher {
@@ -85,6 +87,19 @@ func (st *mockState) ResolveConstraints(cons constraints.Value) (constraints.Val
 	return cons, nil
 }
 
+func (st *mockState) RegistryCredentials() ([]state.RegistryCredential, error) {
+	st.MethodCall(st, "RegistryCredentials")
+	if err := st.NextErr(); err != nil {
+		return nil, err
+	}
+	return st.registryCredentials, nil
+}
+
+func (st *mockState) WatchRegistryCredentials() state.NotifyWatcher {
+	st.MethodCall(st, "WatchRegistryCredentials")
+	return st.registryCredentialsWatcher
+}
+
 func (m *mockState) AllSpaceInfos() (network.SpaceInfos, error) {
 	m.MethodCall(m, "AllSpaceInfos")
 	return network.SpaceInfos{}, nil
@@ -92,8 +107,10 @@ func (m *mockState) AllSpaceInfos() (network.SpaceInfos, error) {
 
 type mockModel struct {
 	testing.Stub
-	podSpecWatcher *statetesting.MockNotifyWatcher
-	containers     []state.CloudContainer
+	podSpecWatcher     *statetesting.MockNotifyWatcher
+	modelConfigWatcher *statetesting.MockNotifyWatcher
+	containers         []state.CloudContainer
+	isolated           bool
 }
 
 func (m *mockModel) ModelConfig() (*config.Config, error) {
@@ -101,9 +118,15 @@ func (m *mockModel) ModelConfig() (*config.Config, error) {
 	attrs := coretesting.FakeConfig()
 	attrs["workload-storage"] = "k8s-storage"
 	attrs["agent-version"] = jujuversion.Current.String()
+	attrs["network-isolation"] = m.isolated
 	return config.New(config.UseDefaults, attrs)
 }
 
+func (m *mockModel) WatchForModelConfigChanges() state.NotifyWatcher {
+	m.MethodCall(m, "WatchForModelConfigChanges")
+	return m.modelConfigWatcher
+}
+
 func (m *mockModel) PodSpec(tag names.ApplicationTag) (string, error) {
 	m.MethodCall(m, "PodSpec", tag)
 	if err := m.NextErr(); err != nil {
@@ -129,6 +152,7 @@ type mockApplication struct {
 	testing.Stub
 	life         state.Life
 	scaleWatcher *statetesting.MockNotifyWatcher
+	watcher      *statetesting.MockNotifyWatcher
 
 	tag        names.Tag
 	scale      int
@@ -137,12 +161,37 @@ type mockApplication struct {
 	providerId string
 	addresses  []network.SpaceAddress
 	charm      *mockCharm
+	canary     bool
+	exposed    bool
+	related    []string
 }
 
 func (a *mockApplication) Tag() names.Tag {
 	return a.tag
 }
 
+func (a *mockApplication) CanaryUpgrade() bool {
+	return a.canary
+}
+
+func (a *mockApplication) IsExposed() bool {
+	a.MethodCall(a, "IsExposed")
+	return a.exposed
+}
+
+func (a *mockApplication) RelatedApplications() ([]string, error) {
+	a.MethodCall(a, "RelatedApplications")
+	if err := a.NextErr(); err != nil {
+		return nil, err
+	}
+	return a.related, nil
+}
+
+func (a *mockApplication) Watch() state.NotifyWatcher {
+	a.MethodCall(a, "Watch")
+	return a.watcher
+}
+
 func (a *mockApplication) Name() string {
 	a.MethodCall(a, "Name")
 	return a.tag.Id()
@@ -182,10 +182,23 @@ func (f *Facade) watchPodSpec(model Model, tagString string) (string, error) {
 	if err != nil {
 		return "", errors.Trace(err)
 	}
-	w, err := model.WatchPodSpec(tag)
+	app, err := f.state.Application(tag.Id())
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	specWatcher, err := model.WatchPodSpec(tag)
 	if err != nil {
 		return "", errors.Trace(err)
 	}
+	// The pods are also updated when the credentials used to pull
+	// their images change, and their network policy is updated when
+	// the application is related, exposed or isolated.
+	w := common.NewMultiNotifyWatcher(
+		specWatcher,
+		f.state.WatchRegistryCredentials(),
+		app.Watch(),
+		model.WatchForModelConfigChanges(),
+	)
 	if _, ok := <-w.Changes(); ok {
 		return f.resources.Register(w), nil
 	}
@@ -314,6 +327,13 @@ func (f *Facade) provisioningInfo(model Model, tagString string) (*params.Kubern
 		Constraints:       mergedCons,
 		Tags:              resourceTags,
 		OperatorImagePath: operatorImagePath,
+		CanaryUpgrade:     app.CanaryUpgrade(),
+	}
+	if info.RegistryCredentials, err = f.registryCredentials(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if info.NetworkPolicy, err = networkPolicy(app, modelConfig); err != nil {
+		return nil, errors.Trace(err)
 	}
 	deployInfo := ch.Meta().Deployment
 	if deployInfo != nil {
@@ -325,6 +345,47 @@ func (f *Facade) provisioningInfo(model Model, tagString string) (*params.Kubern
 	return info, nil
 }
 
+// registryCredentials returns the model's unexpired registry
+// credentials.
+func (f *Facade) registryCredentials() ([]params.RegistryCredential, error) {
+	creds, err := f.state.RegistryCredentials()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result []params.RegistryCredential
+	now := f.clock.Now()
+	for _, cred := range creds {
+		if cred.Expired(now) {
+			logger.Warningf("not using expired credential for registry %q", cred.Registry)
+			continue
+		}
+		result = append(result, params.RegistryCredential{
+			Registry: cred.Registry,
+			Username: cred.Username,
+			Password: cred.Password,
+		})
+	}
+	return result, nil
+}
+
+// networkPolicy returns the traffic allowed to reach the
+// application's pods.
+func networkPolicy(app Application, modelConfig *config.Config) (*params.KubernetesNetworkPolicy, error) {
+	isolated, _ := modelConfig.AllAttrs()[provider.NetworkIsolationKey].(bool)
+	if !isolated {
+		return &params.KubernetesNetworkPolicy{}, nil
+	}
+	related, err := app.RelatedApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &params.KubernetesNetworkPolicy{
+		Isolated:            true,
+		RelatedApplications: related,
+		Exposed:             app.IsExposed(),
+	}, nil
+}
+
 func filesystemParams(
 	app Application,
 	cons state.StorageConstraints,
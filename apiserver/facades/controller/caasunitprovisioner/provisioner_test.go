@@ -45,6 +45,9 @@ type CAASProvisionerSuite struct {
 	applicationsChanges chan []string
 	podSpecChanges      chan struct{}
 	scaleChanges        chan struct{}
+	credentialChanges   chan struct{}
+	applicationChanges  chan struct{}
+	modelConfigChanges  chan struct{}
 
 	resources  *common.Resources
 	authorizer *apiservertesting.FakeAuthorizer
@@ -57,20 +60,26 @@ func (s *CAASProvisionerSuite) SetUpTest(c *gc.C) {
 	s.applicationsChanges = make(chan []string, 1)
 	s.podSpecChanges = make(chan struct{}, 1)
 	s.scaleChanges = make(chan struct{}, 1)
+	s.credentialChanges = make(chan struct{}, 1)
+	s.applicationChanges = make(chan struct{}, 1)
+	s.modelConfigChanges = make(chan struct{}, 1)
 	s.st = &mockState{
 		application: mockApplication{
 			tag:          names.NewApplicationTag("gitlab"),
 			life:         state.Alive,
 			scaleWatcher: statetesting.NewMockNotifyWatcher(s.scaleChanges),
+			watcher:      statetesting.NewMockNotifyWatcher(s.applicationChanges),
 			scale:        5,
 		},
 		applicationsWatcher: statetesting.NewMockStringsWatcher(s.applicationsChanges),
 		model: mockModel{
-			podSpecWatcher: statetesting.NewMockNotifyWatcher(s.podSpecChanges),
+			podSpecWatcher:     statetesting.NewMockNotifyWatcher(s.podSpecChanges),
+			modelConfigWatcher: statetesting.NewMockNotifyWatcher(s.modelConfigChanges),
 		},
 		unit: mockUnit{
 			life: state.Dying,
 		},
+		registryCredentialsWatcher: statetesting.NewMockNotifyWatcher(s.credentialChanges),
 	}
 	s.storage = &mockStorage{
 		storageFilesystems: make(map[names.StorageTag]names.FilesystemTag),
@@ -83,6 +92,9 @@ func (s *CAASProvisionerSuite) SetUpTest(c *gc.C) {
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.applicationsWatcher) })
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.application.scaleWatcher) })
 	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.model.podSpecWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.registryCredentialsWatcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.application.watcher) })
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, s.st.model.modelConfigWatcher) })
 
 	s.resources = common.NewResources()
 	s.authorizer = &apiservertesting.FakeAuthorizer{
@@ -121,6 +133,9 @@ func (s *CAASProvisionerSuite) TestWatchApplications(c *gc.C) {
 
 func (s *CAASProvisionerSuite) TestWatchPodSpec(c *gc.C) {
 	s.podSpecChanges <- struct{}{}
+	s.credentialChanges <- struct{}{}
+	s.applicationChanges <- struct{}{}
+	s.modelConfigChanges <- struct{}{}
 
 	results, err := s.facade.WatchPodSpec(params.Entities{
 		Entities: []params.Entity{
@@ -137,7 +152,23 @@ func (s *CAASProvisionerSuite) TestWatchPodSpec(c *gc.C) {
 
 	c.Assert(results.Results[0].NotifyWatcherId, gc.Equals, "1")
 	resource := s.resources.Get("1")
-	c.Assert(resource, gc.Equals, s.st.model.podSpecWatcher)
+	c.Assert(resource, gc.FitsTypeOf, &common.MultiNotifyWatcher{})
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, resource.(*common.MultiNotifyWatcher)) })
+	s.st.CheckCallNames(c, "Model", "Application", "WatchRegistryCredentials")
+	s.st.application.CheckCallNames(c, "Watch")
+	s.st.model.CheckCallNames(c, "WatchPodSpec", "WatchForModelConfigChanges")
+
+	// The watcher also triggers when the registry credentials, the
+	// application or the model config change.
+	w := resource.(*common.MultiNotifyWatcher)
+	for _, changes := range []chan struct{}{s.credentialChanges, s.applicationChanges, s.modelConfigChanges} {
+		changes <- struct{}{}
+		select {
+		case <-w.Changes():
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for change")
+		}
+	}
 }
 
 func (s *CAASProvisionerSuite) TestWatchApplicationsScale(c *gc.C) {
@@ -185,6 +216,17 @@ func (s *CAASProvisionerSuite) TestProvisioningInfo(c *gc.C) {
 			},
 		},
 	}
+	s.st.application.canary = true
+	s.st.registryCredentials = []state.RegistryCredential{{
+		Registry: "docker.io",
+		Username: "bob",
+		Password: "secret",
+	}, {
+		Registry: "registry.example.com",
+		Username: "mary",
+		Password: "expired",
+		Expiry:   s.clock.Now().Add(-time.Minute),
+	}}
 
 	results, err := s.facade.ProvisioningInfo(params.Entities{
 		Entities: []params.Entity{
@@ -253,6 +295,7 @@ func (s *CAASProvisionerSuite) TestProvisioningInfo(c *gc.C) {
 	c.Assert(obtained.PodSpec, jc.DeepEquals, expectedResult.PodSpec)
 	c.Assert(obtained.DeploymentInfo, jc.DeepEquals, expectedResult.DeploymentInfo)
 	c.Assert(obtained.OperatorImagePath, gc.Equals, expectedResult.OperatorImagePath)
+	c.Assert(obtained.CanaryUpgrade, jc.IsTrue)
 	c.Assert(len(obtained.Filesystems), gc.Equals, len(expectedFileSystems))
 	for _, fs := range obtained.Filesystems {
 		c.Assert(fs, gc.DeepEquals, expectedFileSystems[fs.StorageName])
@@ -260,16 +303,47 @@ func (s *CAASProvisionerSuite) TestProvisioningInfo(c *gc.C) {
 	c.Assert(obtained.Devices, jc.DeepEquals, expectedResult.Devices)
 	c.Assert(obtained.Constraints, jc.DeepEquals, expectedResult.Constraints)
 	c.Assert(obtained.Tags, jc.DeepEquals, expectedResult.Tags)
+	c.Assert(obtained.RegistryCredentials, jc.DeepEquals, []params.RegistryCredential{{
+		Registry: "docker.io",
+		Username: "bob",
+		Password: "secret",
+	}})
+	c.Assert(obtained.NetworkPolicy, jc.DeepEquals, &params.KubernetesNetworkPolicy{})
 	c.Assert(results.Results[1], jc.DeepEquals, params.KubernetesProvisioningInfoResult{
 		Error: &params.Error{
 			Message: `"unit-gitlab-0" is not a valid application tag`,
 		},
 	})
-	s.st.CheckCallNames(c, "Model", "Application", "ControllerConfig", "ResolveConstraints")
+	s.st.CheckCallNames(c, "Model", "Application", "ControllerConfig", "ResolveConstraints", "RegistryCredentials")
 	s.st.CheckCall(c, 3, "ResolveConstraints", constraints.MustParse("mem=64G"))
 	s.storagePoolManager.CheckCallNames(c, "Get", "Get")
 }
 
+func (s *CAASProvisionerSuite) TestProvisioningInfoNetworkIsolation(c *gc.C) {
+	s.st.application.charm = &mockCharm{
+		meta: charm.Meta{
+			Storage: map[string]charm.Storage{
+				"data": {Name: "data", Type: charm.StorageFilesystem},
+				"logs": {Name: "logs", Type: charm.StorageFilesystem},
+			},
+		},
+	}
+	s.st.application.exposed = true
+	s.st.application.related = []string{"mariadb", "nginx"}
+	s.st.model.isolated = true
+
+	results, err := s.facade.ProvisioningInfo(params.Entities{
+		Entities: []params.Entity{{Tag: "application-gitlab"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[0].Result.NetworkPolicy, jc.DeepEquals, &params.KubernetesNetworkPolicy{
+		Isolated:            true,
+		RelatedApplications: []string{"mariadb", "nginx"},
+		Exposed:             true,
+	})
+}
+
 func (s *CAASProvisionerSuite) TestApplicationScale(c *gc.C) {
 	results, err := s.facade.ApplicationsScale(params.Entities{
 		Entities: []params.Entity{
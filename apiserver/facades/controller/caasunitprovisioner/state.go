@@ -6,6 +6,7 @@ package caasunitprovisioner
 import (
 	"time"
 
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v3"
@@ -30,6 +31,8 @@ type CAASUnitProvisionerState interface {
 	Model() (Model, error)
 	WatchApplications() state.StringsWatcher
 	ResolveConstraints(cons constraints.Value) (constraints.Value, error)
+	RegistryCredentials() ([]state.RegistryCredential, error)
+	WatchRegistryCredentials() state.NotifyWatcher
 }
 
 // StorageBackend provides the subset of backend storage
@@ -65,6 +68,7 @@ type Model interface {
 	ModelConfig() (*config.Config, error)
 	PodSpec(tag names.ApplicationTag) (string, error)
 	WatchPodSpec(tag names.ApplicationTag) (state.NotifyWatcher, error)
+	WatchForModelConfigChanges() state.NotifyWatcher
 	Containers(providerIds ...string) ([]state.CloudContainer, error)
 }
 
@@ -89,6 +93,10 @@ type Application interface {
 	SetOperatorStatus(sInfo status.StatusInfo) error
 	SetStatus(statusInfo status.StatusInfo) error
 	Charm() (Charm, bool, error)
+	CanaryUpgrade() bool
+	IsExposed() bool
+	RelatedApplications() ([]string, error)
+	Watch() state.NotifyWatcher
 }
 
 type stateShim struct {
@@ -131,6 +139,35 @@ func (a applicationShim) Charm() (Charm, bool, error) {
 	return a.Application.Charm()
 }
 
+// RelatedApplications returns the names of the other applications in
+// the model the application is related to.
+func (a applicationShim) RelatedApplications() ([]string, error) {
+	relations, err := a.Application.Relations()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	related := set.NewStrings()
+	for _, rel := range relations {
+		// Applications in other models don't run in the
+		// model's namespace.
+		if _, isRemote, err := rel.RemoteApplication(); err != nil {
+			return nil, errors.Trace(err)
+		} else if isRemote {
+			continue
+		}
+		eps, err := rel.RelatedEndpoints(a.Name())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, ep := range eps {
+			if ep.ApplicationName != a.Name() {
+				related.Add(ep.ApplicationName)
+			}
+		}
+	}
+	return related.SortedValues(), nil
+}
+
 type Charm interface {
 	Meta() *charm.Meta
 }
@@ -0,0 +1,217 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package crossmodelhealth implements the API used by the cross model
+// health prober, which runs on the offering side of cross model
+// relations and reports the relations whose consuming models can no
+// longer use them.
+package crossmodelhealth
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.crossmodelhealth")
+
+// probeStatusDataKey marks a relation status as having been set by the
+// prober, so that it is only cleared by the prober.
+const probeStatusDataKey = "cross-model-probe"
+
+// Backend exposes the state functionality required by the facade.
+type Backend interface {
+	state.ModelAccessor
+
+	// AllOfferConnections returns the connections to all offers
+	// hosted in the model.
+	AllOfferConnections() ([]OfferConnection, error)
+
+	// OfferConnectionForRelation returns the offer connection for
+	// the relation with the given key.
+	OfferConnectionForRelation(key string) (OfferConnection, error)
+
+	// KeyRelation returns the relation with the given key.
+	KeyRelation(key string) (Relation, error)
+
+	// ApplicationOfferForUUID returns the offer with the given UUID.
+	ApplicationOfferForUUID(offerUUID string) (*crossmodel.ApplicationOffer, error)
+
+	// CheckOfferAccess returns an error unless the given user may
+	// consume the offer with the given UUID.
+	CheckOfferAccess(username, offerUUID string) error
+}
+
+// OfferConnection exposes the offer connection functionality required
+// by the facade.
+type OfferConnection interface {
+	OfferUUID() string
+	UserName() string
+	SourceModelUUID() string
+	RelationKey() string
+	LastContact() time.Time
+	LastProbeSuccess() time.Time
+	SetLastProbeSuccess(time.Time) error
+}
+
+// Relation exposes the relation functionality required by the facade.
+type Relation interface {
+	Tag() names.Tag
+	Life() state.Life
+	Suspended() bool
+	Status() (status.StatusInfo, error)
+	SetStatus(status.StatusInfo) error
+}
+
+// API implements the CrossModelHealth facade.
+type API struct {
+	*common.ModelWatcher
+	backend Backend
+}
+
+// NewAPI returns a new CrossModelHealth API facade.
+func NewAPI(
+	backend Backend,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		ModelWatcher: common.NewModelWatcher(backend, resources, authorizer),
+		backend:      backend,
+	}, nil
+}
+
+// OfferConnections returns the connections to offers hosted in the
+// model whose relations are alive and not suspended. Each connection
+// is checked to see whether the consuming model may still discharge
+// the macaroons used to access the relation.
+func (api *API) OfferConnections() (params.OfferConnectionProbesResult, error) {
+	conns, err := api.backend.AllOfferConnections()
+	if err != nil {
+		return params.OfferConnectionProbesResult{}, errors.Trace(err)
+	}
+	result := params.OfferConnectionProbesResult{
+		Connections: []params.OfferConnectionProbe{},
+	}
+	for _, oc := range conns {
+		rel, err := api.backend.KeyRelation(oc.RelationKey())
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return params.OfferConnectionProbesResult{}, errors.Trace(err)
+		}
+		if rel.Life() != state.Alive || rel.Suspended() {
+			continue
+		}
+		probe := params.OfferConnectionProbe{
+			RelationTag:    rel.Tag().String(),
+			OfferUUID:      oc.OfferUUID(),
+			SourceModelTag: names.NewModelTag(oc.SourceModelUUID()).String(),
+			Username:       oc.UserName(),
+		}
+		if t := oc.LastContact(); !t.IsZero() {
+			probe.LastContact = &t
+		}
+		if t := oc.LastProbeSuccess(); !t.IsZero() {
+			probe.LastSuccess = &t
+		}
+		probe.Error = common.ServerError(api.checkAccess(oc))
+		result.Connections = append(result.Connections, probe)
+	}
+	return result, nil
+}
+
+// checkAccess returns an error describing why the consuming model can
+// no longer discharge the macaroons for the connection.
+func (api *API) checkAccess(oc OfferConnection) error {
+	if _, err := api.backend.ApplicationOfferForUUID(oc.OfferUUID()); errors.IsNotFound(err) {
+		return errors.New("offer has been removed")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	err := api.backend.CheckOfferAccess(oc.UserName(), oc.OfferUUID())
+	if errors.Cause(err) == common.ErrPerm {
+		return errors.Errorf("user %q no longer has consume access to the offer", oc.UserName())
+	}
+	return errors.Trace(err)
+}
+
+// SetOfferConnectionsHealth records the health of each of the given
+// connections. Healthy connections have their last probe success
+// recorded; unhealthy ones have the status of their relation set to
+// error, until they are found to be healthy again.
+func (api *API) SetOfferConnectionsHealth(args params.OfferConnectionHealthArgs) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Connections)),
+	}
+	for i, arg := range args.Connections {
+		err := api.setHealth(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) setHealth(arg params.OfferConnectionHealth) error {
+	relTag, err := names.ParseRelationTag(arg.RelationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rel, err := api.backend.KeyRelation(relTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	oc, err := api.backend.OfferConnectionForRelation(relTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	current, err := rel.Status()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	setByProbe := current.Status == status.Error && current.Data[probeStatusDataKey] == true
+
+	if arg.Message == "" {
+		if err := oc.SetLastProbeSuccess(arg.Time); err != nil {
+			return errors.Trace(err)
+		}
+		if !setByProbe {
+			return nil
+		}
+		logger.Infof("cross model relation %q is healthy again", relTag.Id())
+		return rel.SetStatus(status.StatusInfo{
+			Status: status.Joined,
+			Since:  &arg.Time,
+		})
+	}
+
+	// Only report on relations which are otherwise established, so
+	// as not to hide the progress of relations joining or leaving.
+	if current.Status != status.Joined && !setByProbe {
+		return nil
+	}
+	data := map[string]interface{}{probeStatusDataKey: true}
+	if t := oc.LastProbeSuccess(); !t.IsZero() {
+		data["last-success"] = t.Format(time.RFC3339)
+	}
+	if !setByProbe {
+		logger.Warningf("cross model relation %q is unhealthy: %s", relTag.Id(), arg.Message)
+	}
+	return rel.SetStatus(status.StatusInfo{
+		Status:  status.Error,
+		Message: arg.Message,
+		Data:    data,
+		Since:   &arg.Time,
+	})
+}
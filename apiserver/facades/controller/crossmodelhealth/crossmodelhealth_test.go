@@ -0,0 +1,213 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodelhealth_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/crossmodelhealth"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+const (
+	activeKey    = "db2:db django:db"
+	revokedKey   = "db2:db wordpress:db"
+	removedKey   = "mysql:db django:db"
+	suspendedKey = "db2:db mediawiki:db"
+)
+
+type CrossModelHealthSuite struct {
+	coretesting.BaseSuite
+
+	backend *mockBackend
+	api     *crossmodelhealth.API
+}
+
+var _ = gc.Suite(&CrossModelHealthSuite{})
+
+func (s *CrossModelHealthSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	sourceModelUUID := coretesting.ModelTag.Id()
+	s.backend = &mockBackend{
+		conns: []*mockOfferConnection{{
+			offerUUID:       "db2-uuid",
+			username:        "mary",
+			sourceModelUUID: sourceModelUUID,
+			relationKey:     activeKey,
+			lastContact:     time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC),
+		}, {
+			offerUUID:       "db2-uuid",
+			username:        "fred",
+			sourceModelUUID: sourceModelUUID,
+			relationKey:     revokedKey,
+		}, {
+			offerUUID:       "mysql-uuid",
+			username:        "mary",
+			sourceModelUUID: sourceModelUUID,
+			relationKey:     removedKey,
+		}, {
+			offerUUID:       "db2-uuid",
+			username:        "mary",
+			sourceModelUUID: sourceModelUUID,
+			relationKey:     suspendedKey,
+		}},
+		relations: map[string]*mockRelation{
+			activeKey:    {key: activeKey, life: state.Alive, status: status.StatusInfo{Status: status.Joined}},
+			revokedKey:   {key: revokedKey, life: state.Alive, status: status.StatusInfo{Status: status.Joined}},
+			removedKey:   {key: removedKey, life: state.Alive, status: status.StatusInfo{Status: status.Joined}},
+			suspendedKey: {key: suspendedKey, life: state.Alive, suspended: true},
+		},
+		offers:    map[string]bool{"db2-uuid": true},
+		consumers: map[string]bool{"mary": true},
+	}
+	var err error
+	s.api, err = crossmodelhealth.NewAPI(
+		s.backend, common.NewResources(),
+		apiservertesting.FakeAuthorizer{Controller: true},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CrossModelHealthSuite) TestNewAPIRequiresController(c *gc.C) {
+	api, err := crossmodelhealth.NewAPI(
+		s.backend, common.NewResources(),
+		apiservertesting.FakeAuthorizer{Controller: false},
+	)
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *CrossModelHealthSuite) TestOfferConnections(c *gc.C) {
+	result, err := s.api.OfferConnections()
+	c.Assert(err, jc.ErrorIsNil)
+	lastContact := time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)
+	sourceModelTag := coretesting.ModelTag.String()
+	c.Assert(result, jc.DeepEquals, params.OfferConnectionProbesResult{
+		Connections: []params.OfferConnectionProbe{{
+			RelationTag:    "relation-db2.db#django.db",
+			OfferUUID:      "db2-uuid",
+			SourceModelTag: sourceModelTag,
+			Username:       "mary",
+			LastContact:    &lastContact,
+		}, {
+			RelationTag:    "relation-db2.db#wordpress.db",
+			OfferUUID:      "db2-uuid",
+			SourceModelTag: sourceModelTag,
+			Username:       "fred",
+			Error: &params.Error{
+				Message: `user "fred" no longer has consume access to the offer`,
+			},
+		}, {
+			RelationTag:    "relation-mysql.db#django.db",
+			OfferUUID:      "mysql-uuid",
+			SourceModelTag: sourceModelTag,
+			Username:       "mary",
+			Error:          &params.Error{Message: "offer has been removed"},
+		}},
+	})
+}
+
+func (s *CrossModelHealthSuite) TestOfferConnectionsSkipsDyingRelations(c *gc.C) {
+	s.backend.relations[revokedKey].life = state.Dying
+	delete(s.backend.relations, removedKey)
+	result, err := s.api.OfferConnections()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Connections, gc.HasLen, 1)
+	c.Assert(result.Connections[0].RelationTag, gc.Equals, "relation-db2.db#django.db")
+}
+
+func (s *CrossModelHealthSuite) TestSetOfferConnectionsHealth(c *gc.C) {
+	now := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+	lastSuccess := time.Date(2020, 5, 1, 11, 0, 0, 0, time.UTC)
+	s.backend.conns[1].lastProbeSuccess = lastSuccess
+
+	results, err := s.api.SetOfferConnectionsHealth(params.OfferConnectionHealthArgs{
+		Connections: []params.OfferConnectionHealth{{
+			RelationTag: "relation-db2.db#django.db",
+			Time:        now,
+		}, {
+			RelationTag: "relation-db2.db#wordpress.db",
+			Message:     "consume access revoked",
+			Time:        now,
+		}, {
+			RelationTag: "relation-db2.db#unknown.db",
+			Time:        now,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{},
+			{Error: &params.Error{Code: "not found", Message: `relation "db2:db unknown:db" not found`}},
+		},
+	})
+
+	c.Assert(s.backend.conns[0].lastProbeSuccess, gc.Equals, now)
+	c.Assert(s.backend.relations[activeKey].status.Status, gc.Equals, status.Joined)
+
+	c.Assert(s.backend.conns[1].lastProbeSuccess, gc.Equals, lastSuccess)
+	c.Assert(s.backend.relations[revokedKey].status, jc.DeepEquals, status.StatusInfo{
+		Status:  status.Error,
+		Message: "consume access revoked",
+		Data: map[string]interface{}{
+			"cross-model-probe": true,
+			"last-success":      "2020-05-01T11:00:00Z",
+		},
+		Since: &now,
+	})
+}
+
+func (s *CrossModelHealthSuite) TestSetOfferConnectionsHealthRecovers(c *gc.C) {
+	s.backend.relations[revokedKey].status = status.StatusInfo{
+		Status:  status.Error,
+		Message: "consume access revoked",
+		Data:    map[string]interface{}{"cross-model-probe": true},
+	}
+	now := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+	results, err := s.api.SetOfferConnectionsHealth(params.OfferConnectionHealthArgs{
+		Connections: []params.OfferConnectionHealth{{
+			RelationTag: "relation-db2.db#wordpress.db",
+			Time:        now,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.OneError(), jc.ErrorIsNil)
+	c.Assert(s.backend.conns[1].lastProbeSuccess, gc.Equals, now)
+	c.Assert(s.backend.relations[revokedKey].status, jc.DeepEquals, status.StatusInfo{
+		Status: status.Joined,
+		Since:  &now,
+	})
+}
+
+func (s *CrossModelHealthSuite) TestSetOfferConnectionsHealthLeavesOtherStatuses(c *gc.C) {
+	joining := status.StatusInfo{Status: status.Joining}
+	s.backend.relations[revokedKey].status = joining
+	otherError := status.StatusInfo{Status: status.Error, Message: "hook failed"}
+	s.backend.relations[activeKey].status = otherError
+
+	now := time.Date(2020, 5, 1, 12, 0, 0, 0, time.UTC)
+	results, err := s.api.SetOfferConnectionsHealth(params.OfferConnectionHealthArgs{
+		Connections: []params.OfferConnectionHealth{{
+			RelationTag: "relation-db2.db#wordpress.db",
+			Message:     "consume access revoked",
+			Time:        now,
+		}, {
+			RelationTag: "relation-db2.db#django.db",
+			Time:        now,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Combine(), jc.ErrorIsNil)
+	c.Assert(s.backend.relations[revokedKey].status, jc.DeepEquals, joining)
+	c.Assert(s.backend.relations[activeKey].status, jc.DeepEquals, otherError)
+}
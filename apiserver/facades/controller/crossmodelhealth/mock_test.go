@@ -0,0 +1,164 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodelhealth_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/crossmodelhealth"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+type mockBackend struct {
+	testing.Stub
+	conns     []*mockOfferConnection
+	relations map[string]*mockRelation
+	offers    map[string]bool
+	consumers map[string]bool
+}
+
+func (b *mockBackend) WatchForModelConfigChanges() state.NotifyWatcher {
+	b.MethodCall(b, "WatchForModelConfigChanges")
+	return nil
+}
+
+func (b *mockBackend) ModelConfig() (*config.Config, error) {
+	b.MethodCall(b, "ModelConfig")
+	return nil, errors.NotImplementedf("ModelConfig")
+}
+
+func (b *mockBackend) AllOfferConnections() ([]crossmodelhealth.OfferConnection, error) {
+	b.MethodCall(b, "AllOfferConnections")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	conns := make([]crossmodelhealth.OfferConnection, len(b.conns))
+	for i, oc := range b.conns {
+		conns[i] = oc
+	}
+	return conns, nil
+}
+
+func (b *mockBackend) OfferConnectionForRelation(key string) (crossmodelhealth.OfferConnection, error) {
+	b.MethodCall(b, "OfferConnectionForRelation", key)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	for _, oc := range b.conns {
+		if oc.relationKey == key {
+			return oc, nil
+		}
+	}
+	return nil, errors.NotFoundf("offer connection for relation %q", key)
+}
+
+func (b *mockBackend) KeyRelation(key string) (crossmodelhealth.Relation, error) {
+	b.MethodCall(b, "KeyRelation", key)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	rel, ok := b.relations[key]
+	if !ok {
+		return nil, errors.NotFoundf("relation %q", key)
+	}
+	return rel, nil
+}
+
+func (b *mockBackend) ApplicationOfferForUUID(offerUUID string) (*crossmodel.ApplicationOffer, error) {
+	b.MethodCall(b, "ApplicationOfferForUUID", offerUUID)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	if !b.offers[offerUUID] {
+		return nil, errors.NotFoundf("offer %q", offerUUID)
+	}
+	return &crossmodel.ApplicationOffer{OfferUUID: offerUUID}, nil
+}
+
+func (b *mockBackend) CheckOfferAccess(username, offerUUID string) error {
+	b.MethodCall(b, "CheckOfferAccess", username, offerUUID)
+	if err := b.NextErr(); err != nil {
+		return err
+	}
+	if !b.consumers[username] {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+type mockOfferConnection struct {
+	crossmodelhealth.OfferConnection
+	offerUUID        string
+	username         string
+	sourceModelUUID  string
+	relationKey      string
+	lastContact      time.Time
+	lastProbeSuccess time.Time
+}
+
+func (oc *mockOfferConnection) OfferUUID() string {
+	return oc.offerUUID
+}
+
+func (oc *mockOfferConnection) UserName() string {
+	return oc.username
+}
+
+func (oc *mockOfferConnection) SourceModelUUID() string {
+	return oc.sourceModelUUID
+}
+
+func (oc *mockOfferConnection) RelationKey() string {
+	return oc.relationKey
+}
+
+func (oc *mockOfferConnection) LastContact() time.Time {
+	return oc.lastContact
+}
+
+func (oc *mockOfferConnection) LastProbeSuccess() time.Time {
+	return oc.lastProbeSuccess
+}
+
+func (oc *mockOfferConnection) SetLastProbeSuccess(t time.Time) error {
+	oc.lastProbeSuccess = t
+	return nil
+}
+
+type mockRelation struct {
+	crossmodelhealth.Relation
+	key       string
+	life      state.Life
+	suspended bool
+	status    status.StatusInfo
+}
+
+func (r *mockRelation) Tag() names.Tag {
+	return names.NewRelationTag(r.key)
+}
+
+func (r *mockRelation) Life() state.Life {
+	return r.life
+}
+
+func (r *mockRelation) Suspended() bool {
+	return r.suspended
+}
+
+func (r *mockRelation) Status() (status.StatusInfo, error) {
+	return r.status, nil
+}
+
+func (r *mockRelation) SetStatus(info status.StatusInfo) error {
+	r.status = info
+	return nil
+}
@@ -0,0 +1,87 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodelhealth
+
+import (
+	"github.com/juju/errors"
+
+	commoncrossmodel "github.com/juju/juju/apiserver/common/crossmodel"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb.
+
+// NewFacade provides the required signature for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(
+		backendShim{st: st, model: model},
+		ctx.Resources(),
+		ctx.Auth(),
+	)
+}
+
+type backendShim struct {
+	st    *state.State
+	model *state.Model
+}
+
+// WatchForModelConfigChanges is part of the Backend interface.
+func (shim backendShim) WatchForModelConfigChanges() state.NotifyWatcher {
+	return shim.model.WatchForModelConfigChanges()
+}
+
+// ModelConfig is part of the Backend interface.
+func (shim backendShim) ModelConfig() (*config.Config, error) {
+	return shim.model.ModelConfig()
+}
+
+// AllOfferConnections is part of the Backend interface.
+func (shim backendShim) AllOfferConnections() ([]OfferConnection, error) {
+	conns, err := shim.st.AllOfferConnections()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]OfferConnection, len(conns))
+	for i, oc := range conns {
+		result[i] = oc
+	}
+	return result, nil
+}
+
+// OfferConnectionForRelation is part of the Backend interface.
+func (shim backendShim) OfferConnectionForRelation(key string) (OfferConnection, error) {
+	oc, err := shim.st.OfferConnectionForRelation(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return oc, nil
+}
+
+// KeyRelation is part of the Backend interface.
+func (shim backendShim) KeyRelation(key string) (Relation, error) {
+	rel, err := shim.st.KeyRelation(key)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return rel, nil
+}
+
+// ApplicationOfferForUUID is part of the Backend interface.
+func (shim backendShim) ApplicationOfferForUUID(offerUUID string) (*crossmodel.ApplicationOffer, error) {
+	return state.NewApplicationOffers(shim.st).ApplicationOfferForUUID(offerUUID)
+}
+
+// CheckOfferAccess is part of the Backend interface.
+func (shim backendShim) CheckOfferAccess(username, offerUUID string) error {
+	return commoncrossmodel.CheckOfferAccess(commoncrossmodel.GetBackend(shim.st), username, offerUUID)
+}
@@ -0,0 +1,57 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodelrelations
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"gopkg.in/tomb.v2"
+
+	"github.com/juju/juju/state"
+)
+
+// contactRecordInterval is how often contact is recorded for a relation
+// that the consuming model is watching.
+const contactRecordInterval = 5 * time.Minute
+
+// contactRecorder records contact from the consuming model for a
+// relation for as long as the consuming model watches the relation.
+// The consuming controller's watchers are only registered while its
+// API connection is alive, and the connection is closed once the
+// controller stops sending heartbeat pings, so a relation that is
+// healthy but idle is still seen to be in contact.
+type contactRecorder struct {
+	tomb tomb.Tomb
+}
+
+func newContactRecorder(oc OfferConnection, w state.Watcher, clk clock.Clock) *contactRecorder {
+	r := &contactRecorder{}
+	watcherDone := make(chan struct{})
+	go func() {
+		_ = w.Wait()
+		close(watcherDone)
+	}()
+	r.tomb.Go(func() error {
+		for {
+			select {
+			case <-r.tomb.Dying():
+				return tomb.ErrDying
+			case <-watcherDone:
+				return nil
+			case <-clk.After(contactRecordInterval):
+				if err := oc.RecordContact(); err != nil {
+					logger.Warningf("cannot record contact for offer %q: %v", oc.OfferUUID(), err)
+				}
+			}
+		}
+	})
+	return r
+}
+
+// Stop is part of the facade.Resource interface.
+func (r *contactRecorder) Stop() error {
+	r.tomb.Kill(nil)
+	return r.tomb.Wait()
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/charm.v6"
@@ -36,9 +37,11 @@ type CrossModelRelationsAPI struct {
 	resources  facade.Resources
 	authorizer facade.Authorizer
 
-	mu              sync.Mutex
-	authCtxt        *commoncrossmodel.AuthContext
-	relationToOffer map[string]string
+	mu                  sync.Mutex
+	authCtxt            *commoncrossmodel.AuthContext
+	relationToOffer     map[string]string
+	relationConnections map[string]OfferConnection
+	clock               clock.Clock
 
 	egressAddressWatcher  egressAddressWatcherFunc
 	relationStatusWatcher relationStatusWatcherFunc
@@ -89,6 +92,8 @@ func NewCrossModelRelationsAPI(
 		relationStatusWatcher: relationStatusWatcher,
 		offerStatusWatcher:    offerStatusWatcher,
 		relationToOffer:       make(map[string]string),
+		relationConnections:   make(map[string]OfferConnection),
+		clock:                 clock.WallClock,
 	}, nil
 }
 
@@ -98,14 +103,59 @@ func (api *CrossModelRelationsAPI) checkMacaroonsForRelation(relationTag names.T
 
 	offerUUID, ok := api.relationToOffer[relationTag.Id()]
 	if !ok {
-		oc, err := api.st.OfferConnectionForRelation(relationTag.Id())
+		oc, err := api.offerConnection(relationTag)
 		if err != nil {
 			return errors.Trace(err)
 		}
 		offerUUID = oc.OfferUUID()
 	}
 	auth := api.authCtxt.Authenticator(api.st.ModelUUID(), offerUUID)
-	return auth.CheckRelationMacaroons(relationTag, mac)
+	if err := auth.CheckRelationMacaroons(relationTag, mac); err != nil {
+		return err
+	}
+	api.recordContact(relationTag)
+	return nil
+}
+
+// offerConnection returns the offer connection for the relation,
+// which is cached for the life of the facade.
+func (api *CrossModelRelationsAPI) offerConnection(relationTag names.Tag) (OfferConnection, error) {
+	if oc, ok := api.relationConnections[relationTag.Id()]; ok {
+		return oc, nil
+	}
+	oc, err := api.st.OfferConnectionForRelation(relationTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	api.relationConnections[relationTag.Id()] = oc
+	return oc, nil
+}
+
+// recordContact records that the consuming model has made an
+// authenticated request for the relation, so that the health of the
+// connection can be judged on the offering side. Failing to record
+// the contact does not fail the request.
+func (api *CrossModelRelationsAPI) recordContact(relationTag names.Tag) {
+	oc, err := api.offerConnection(relationTag)
+	if err == nil {
+		err = oc.RecordContact()
+	}
+	if err != nil {
+		logger.Warningf("cannot record contact for relation %q: %v", relationTag.Id(), err)
+	}
+}
+
+// keepContact records contact from the consuming model for the
+// relation for as long as the given watcher of it runs.
+func (api *CrossModelRelationsAPI) keepContact(relationTag names.Tag, w state.Watcher) {
+	// The recorder has its own offer connection, as the cached one
+	// is only used with api.mu held.
+	oc, err := api.st.OfferConnectionForRelation(relationTag.Id())
+	if err != nil {
+		logger.Warningf("cannot record contact for relation %q: %v", relationTag.Id(), err)
+		return
+	}
+	api.resources.Register(newContactRecorder(oc, w, api.clock))
 }
 
 // PublishRelationChanges publishes relation changes to the
@@ -137,6 +187,7 @@ func (api *CrossModelRelationsAPI) PublishRelationChanges(
 		}
 		if change.Life != life.Alive {
 			delete(api.relationToOffer, relationTag.Id())
+			delete(api.relationConnections, relationTag.Id())
 		}
 	}
 	return results, nil
@@ -335,6 +386,7 @@ func (api *CrossModelRelationsAPI) WatchRelationUnits(remoteRelationArgs params.
 		}
 		results.Results[i].RelationUnitsWatcherId = api.resources.Register(w)
 		results.Results[i].Changes = changes
+		api.keepContact(relationTag, w)
 	}
 	return results, nil
 }
@@ -419,6 +471,7 @@ func (api *CrossModelRelationsAPI) WatchRelationsSuspendedStatus(
 		}
 		results.Results[i].Changes = changesParams
 		results.Results[i].RelationStatusWatcherId = api.resources.Register(w)
+		api.keepContact(relationTag, w)
 	}
 	return results, nil
 }
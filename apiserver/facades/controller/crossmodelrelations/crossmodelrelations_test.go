@@ -6,6 +6,9 @@ package crossmodelrelations_test
 import (
 	"bytes"
 	"regexp"
+	"time"
+
+	"github.com/juju/clock/testclock"
 
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -42,8 +45,9 @@ type crossmodelRelationsSuite struct {
 	authContext   *commoncrossmodel.AuthContext
 	api           *crossmodelrelations.CrossModelRelationsAPI
 
-	watchedRelations params.Entities
-	watchedOffers    []string
+	watchedRelations      params.Entities
+	watchedOffers         []string
+	relationStatusWatcher *mockRelationStatusWatcher
 }
 
 func (s *crossmodelRelationsSuite) SetUpTest(c *gc.C) {
@@ -69,8 +73,12 @@ func (s *crossmodelRelationsSuite) SetUpTest(c *gc.C) {
 	relationStatusWatcher := func(st crossmodelrelations.CrossModelRelationsState, tag names.RelationTag) (state.StringsWatcher, error) {
 		c.Assert(s.st, gc.Equals, st)
 		s.watchedRelations = params.Entities{Entities: []params.Entity{{Tag: tag.String()}}}
-		w := &mockRelationStatusWatcher{changes: make(chan []string, 1)}
+		w := &mockRelationStatusWatcher{
+			mockWatcher: &mockWatcher{stopped: make(chan struct{})},
+			changes:     make(chan []string, 1),
+		}
 		w.changes <- []string{"db2:db django:db"}
+		s.relationStatusWatcher = w
 		return w, nil
 	}
 	offerStatusWatcher := func(st crossmodelrelations.CrossModelRelationsState, offerUUID string) (crossmodelrelations.OfferWatcher, error) {
@@ -98,12 +106,13 @@ func (s *crossmodelRelationsSuite) assertPublishRelationsChanges(c *gc.C, lifeVa
 	rel.units["db2/1"] = ru1
 	rel.units["db2/2"] = ru2
 	s.st.relations["db2:db django:db"] = rel
-	s.st.offerConnectionsByKey["db2:db django:db"] = &mockOfferConnection{
+	oc := &mockOfferConnection{
 		offerUUID:       "hosted-db2-uuid",
 		sourcemodelUUID: "source-model-uuid",
 		relationKey:     "db2:db django:db",
 		relationId:      1,
 	}
+	s.st.offerConnectionsByKey["db2:db django:db"] = oc
 	s.st.remoteEntities[names.NewRelationTag("db2:db django:db")] = "token-db2:db django:db"
 	mac, err := s.bakery.NewMacaroon(
 		[]checkers.Caveat{
@@ -155,6 +164,9 @@ func (s *crossmodelRelationsSuite) assertPublishRelationsChanges(c *gc.C, lifeVa
 		})
 	}
 	s.st.CheckCalls(c, expected)
+	// The authenticated request is recorded as contact from the
+	// consuming model.
+	c.Assert(oc.contacts, gc.Equals, 1)
 	if forceCleanup {
 		ru1.CheckCalls(c, []testing.StubCall{
 			{"LeaveScope", []interface{}{}},
@@ -489,6 +501,60 @@ func (s *crossmodelRelationsSuite) TestWatchRelationsStatus(c *gc.C) {
 	})
 }
 
+func (s *crossmodelRelationsSuite) TestWatchRelationsStatusRecordsContact(c *gc.C) {
+	clock := testclock.NewClock(time.Now())
+	crossmodelrelations.SetClock(s.api, clock)
+	s.st.remoteEntities[names.NewRelationTag("db2:db django:db")] = "token-db2:db django:db"
+	s.st.relations["db2:db django:db"] = newMockRelation(1)
+	oc := &mockOfferConnection{
+		offerUUID:       "hosted-db2-uuid",
+		sourcemodelUUID: "source-model-uuid",
+		relationKey:     "db2:db django:db",
+		relationId:      1,
+	}
+	s.st.offerConnectionsByKey["db2:db django:db"] = oc
+	mac, err := s.bakery.NewMacaroon(
+		[]checkers.Caveat{
+			checkers.DeclaredCaveat("source-model-uuid", s.st.ModelUUID()),
+			checkers.DeclaredCaveat("relation-key", "db2:db django:db"),
+			checkers.DeclaredCaveat("username", "mary"),
+		})
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.api.WatchRelationsSuspendedStatus(params.RemoteEntityArgs{
+		Args: []params.RemoteEntityArg{{
+			Token:     "token-db2:db django:db",
+			Macaroons: macaroon.Slice{mac},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(oc.Contacts(), gc.Equals, 1)
+
+	// Contact keeps being recorded while the consuming model
+	// watches the relation, even though it makes no requests.
+	for contacts := 2; contacts <= 3; contacts++ {
+		c.Assert(clock.WaitAdvance(crossmodelrelations.ContactRecordInterval, coretesting.LongWait, 1), jc.ErrorIsNil)
+		waitContacts(c, oc, contacts)
+	}
+
+	// Once the watcher is stopped, contact is no longer recorded.
+	s.relationStatusWatcher.Kill()
+	c.Assert(clock.WaitAdvance(crossmodelrelations.ContactRecordInterval, coretesting.LongWait, 1), jc.ErrorIsNil)
+	time.Sleep(coretesting.ShortWait)
+	c.Assert(oc.Contacts(), gc.Equals, 3)
+}
+
+func waitContacts(c *gc.C, oc *mockOfferConnection, expected int) {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		if oc.Contacts() == expected {
+			return
+		}
+	}
+	c.Fatalf("expected %d contacts, got %d", expected, oc.Contacts())
+}
+
 func (s *crossmodelRelationsSuite) TestWatchOfferStatus(c *gc.C) {
 	s.st.offers["mysql-uuid"] = &crossmodel.ApplicationOffer{
 		OfferName: "hosted-mysql", OfferUUID: "mysql-uuid", ApplicationName: "mysql"}
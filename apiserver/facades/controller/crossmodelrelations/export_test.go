@@ -0,0 +1,14 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodelrelations
+
+import (
+	"github.com/juju/clock"
+)
+
+const ContactRecordInterval = contactRecordInterval
+
+func SetClock(api *CrossModelRelationsAPI, clock clock.Clock) {
+	api.clock = clock
+}
@@ -464,12 +464,28 @@ type mockOfferConnection struct {
 	relationKey     string
 	username        string
 	offerUUID       string
+
+	mu       sync.Mutex
+	contacts int
 }
 
 func (m *mockOfferConnection) OfferUUID() string {
 	return m.offerUUID
 }
 
+func (m *mockOfferConnection) RecordContact() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.contacts++
+	return nil
+}
+
+func (m *mockOfferConnection) Contacts() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.contacts
+}
+
 type mockRelationUnit struct {
 	commoncrossmodel.RelationUnit
 	testing.Stub
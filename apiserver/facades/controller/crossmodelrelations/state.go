@@ -58,4 +58,5 @@ func (st stateShim) Model() (Model, error) {
 
 type OfferConnection interface {
 	OfferUUID() string
+	RecordContact() error
 }
@@ -0,0 +1,185 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package deadagents implements the API used by the dead agent marker
+// worker, which marks units whose agents have been lost for longer
+// than the model's dead-agent-threshold.
+package deadagents
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.deadagents")
+
+// Backend exposes the state functionality required by the facade.
+type Backend interface {
+	state.ModelAccessor
+
+	// AllUnits returns all the units in the model.
+	AllUnits() ([]Unit, error)
+
+	// Unit returns the unit with the given name.
+	Unit(name string) (Unit, error)
+}
+
+// Unit exposes the unit functionality required by the facade.
+type Unit interface {
+	common.UnitStatusGetter
+	UnitTag() names.UnitTag
+	SetAgentStatus(status.StatusInfo) error
+}
+
+// API implements the DeadAgents facade.
+type API struct {
+	*common.ModelWatcher
+	backend  Backend
+	presence common.ModelPresenceContext
+}
+
+// NewAPI returns a new DeadAgents API facade.
+func NewAPI(
+	backend Backend,
+	presence common.ModelPresence,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		ModelWatcher: common.NewModelWatcher(backend, resources, authorizer),
+		backend:      backend,
+		presence:     common.ModelPresenceContext{Presence: presence},
+	}, nil
+}
+
+// MissingUnitAgents returns the units whose agents are lost, and the
+// units which have been marked as having gone agents, along with
+// whether those agents have since returned.
+func (api *API) MissingUnitAgents() (params.MissingUnitAgentsResult, error) {
+	units, err := api.backend.AllUnits()
+	if err != nil {
+		return params.MissingUnitAgentsResult{}, errors.Trace(err)
+	}
+	result := params.MissingUnitAgentsResult{
+		Agents: []params.MissingUnitAgent{},
+	}
+	for _, unit := range units {
+		agent, _ := api.presence.UnitStatus(unit)
+		if agent.Err != nil {
+			logger.Warningf("cannot get agent status of unit %q: %v", unit.Name(), agent.Err)
+			continue
+		}
+		missing := params.MissingUnitAgent{UnitTag: unit.UnitTag().String()}
+		switch agent.Status.Status {
+		case status.Lost:
+		case status.Gone:
+			missing.Gone = true
+			missing.Alive, err = api.presence.UnitPresence(unit)
+			if err != nil {
+				logger.Warningf("cannot get agent presence of unit %q: %v", unit.Name(), err)
+				continue
+			}
+		default:
+			continue
+		}
+		result.Agents = append(result.Agents, missing)
+	}
+	return result, nil
+}
+
+// MarkUnitAgentsGone marks each of the given units as having a gone
+// agent. Units whose agents are no longer lost are left alone.
+func (api *API) MarkUnitAgentsGone(args params.Entities) (params.ErrorResults, error) {
+	cfg, err := api.backend.ModelConfig()
+	if err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	message := fmt.Sprintf("agent has not been seen for over %v", cfg.DeadAgentThreshold())
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		err := api.markGone(arg.Tag, message)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) markGone(tag, message string) error {
+	unit, err := api.unit(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The agent may have returned since it was reported lost.
+	agent, _ := api.presence.UnitStatus(unit)
+	if agent.Err != nil {
+		return errors.Trace(agent.Err)
+	}
+	if agent.Status.Status != status.Lost {
+		return nil
+	}
+	logger.Infof("marking agent of unit %q as gone", unit.Name())
+	return unit.SetAgentStatus(status.StatusInfo{
+		Status:  status.Gone,
+		Message: message,
+	})
+}
+
+// ClearUnitAgentsGone restores the agent status of each of the given
+// units whose gone agent has returned. Units whose agents are still
+// missing are left alone.
+func (api *API) ClearUnitAgentsGone(args params.Entities) (params.ErrorResults, error) {
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	for i, arg := range args.Entities {
+		err := api.clearGone(arg.Tag)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) clearGone(tag string) error {
+	unit, err := api.unit(tag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	agentStatus, err := unit.AgentStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if agentStatus.Status != status.Gone {
+		return nil
+	}
+	alive, err := api.presence.UnitPresence(unit)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !alive {
+		return nil
+	}
+	logger.Infof("agent of unit %q has returned", unit.Name())
+	// The agent reports its own status again when it next runs
+	// a hook.
+	return unit.SetAgentStatus(status.StatusInfo{Status: status.Idle})
+}
+
+func (api *API) unit(tag string) (Unit, error) {
+	unitTag, err := names.ParseUnitTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return api.backend.Unit(unitTag.Id())
+}
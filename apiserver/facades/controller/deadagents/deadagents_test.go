@@ -0,0 +1,121 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package deadagents_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/deadagents"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type DeadAgentsSuite struct {
+	coretesting.BaseSuite
+
+	backend *mockBackend
+	api     *deadagents.API
+}
+
+var _ = gc.Suite(&DeadAgentsSuite{})
+
+func (s *DeadAgentsSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &mockBackend{
+		units: []*mockUnit{
+			newMockUnit("mysql/0", status.Idle, true),
+			newMockUnit("mysql/1", status.Idle, false),
+			newMockUnit("mysql/2", status.Gone, false),
+			newMockUnit("mysql/3", status.Gone, true),
+			newMockUnit("mysql/4", status.Allocating, false),
+		},
+	}
+	var err error
+	s.api, err = deadagents.NewAPI(
+		s.backend, nil, common.NewResources(),
+		apiservertesting.FakeAuthorizer{Controller: true},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *DeadAgentsSuite) TestNewAPIRequiresController(c *gc.C) {
+	api, err := deadagents.NewAPI(
+		s.backend, nil, common.NewResources(),
+		apiservertesting.FakeAuthorizer{Controller: false},
+	)
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *DeadAgentsSuite) TestMissingUnitAgents(c *gc.C) {
+	result, err := s.api.MissingUnitAgents()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.MissingUnitAgentsResult{
+		Agents: []params.MissingUnitAgent{
+			{UnitTag: "unit-mysql-1"},
+			{UnitTag: "unit-mysql-2", Gone: true},
+			{UnitTag: "unit-mysql-3", Gone: true, Alive: true},
+		},
+	})
+}
+
+func (s *DeadAgentsSuite) TestMissingUnitAgentsError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	_, err := s.api.MissingUnitAgents()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *DeadAgentsSuite) TestMarkUnitAgentsGone(c *gc.C) {
+	cfg, err := config.New(config.UseDefaults, coretesting.FakeConfig().Merge(coretesting.Attrs{
+		config.DeadAgentThreshold: "24h",
+	}))
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.cfg = cfg
+
+	results, err := s.api.MarkUnitAgentsGone(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-mysql-1"},
+		{Tag: "unit-mysql-9"},
+		{Tag: "machine-0"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 4)
+	c.Check(results.Results[0].Error, gc.IsNil)
+	c.Check(results.Results[1].Error, gc.IsNil)
+	c.Check(results.Results[2].Error, gc.ErrorMatches, `unit "mysql/9" not found`)
+	c.Check(results.Results[3].Error, gc.ErrorMatches, `"machine-0" is not a valid unit tag`)
+
+	// The agent of mysql/0 is alive, so it is left alone.
+	c.Check(s.backend.units[0].setStatus, gc.HasLen, 0)
+	c.Check(s.backend.units[1].setStatus, jc.DeepEquals, []status.StatusInfo{{
+		Status:  status.Gone,
+		Message: "agent has not been seen for over 24h0m0s",
+	}})
+}
+
+func (s *DeadAgentsSuite) TestClearUnitAgentsGone(c *gc.C) {
+	results, err := s.api.ClearUnitAgentsGone(params.Entities{Entities: []params.Entity{
+		{Tag: "unit-mysql-0"},
+		{Tag: "unit-mysql-2"},
+		{Tag: "unit-mysql-3"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}, {}, {}},
+	})
+
+	// mysql/0 is not gone, and the agent of mysql/2 is still
+	// missing; only mysql/3 is restored.
+	c.Check(s.backend.units[0].setStatus, gc.HasLen, 0)
+	c.Check(s.backend.units[2].setStatus, gc.HasLen, 0)
+	c.Check(s.backend.units[3].setStatus, jc.DeepEquals, []status.StatusInfo{{
+		Status: status.Idle,
+	}})
+}
@@ -0,0 +1,112 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package deadagents_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/facades/controller/deadagents"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mockBackend struct {
+	testing.Stub
+	units []*mockUnit
+	cfg   *config.Config
+}
+
+func (b *mockBackend) WatchForModelConfigChanges() state.NotifyWatcher {
+	b.MethodCall(b, "WatchForModelConfigChanges")
+	return nil
+}
+
+func (b *mockBackend) ModelConfig() (*config.Config, error) {
+	b.MethodCall(b, "ModelConfig")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	if b.cfg == nil {
+		return config.New(config.UseDefaults, coretesting.FakeConfig())
+	}
+	return b.cfg, nil
+}
+
+func (b *mockBackend) AllUnits() ([]deadagents.Unit, error) {
+	b.MethodCall(b, "AllUnits")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	units := make([]deadagents.Unit, len(b.units))
+	for i, u := range b.units {
+		units[i] = u
+	}
+	return units, nil
+}
+
+func (b *mockBackend) Unit(name string) (deadagents.Unit, error) {
+	b.MethodCall(b, "Unit", name)
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	for _, u := range b.units {
+		if u.name == name {
+			return u, nil
+		}
+	}
+	return nil, errors.NotFoundf("unit %q", name)
+}
+
+type mockUnit struct {
+	name        string
+	alive       bool
+	agentStatus status.StatusInfo
+	setStatus   []status.StatusInfo
+}
+
+func newMockUnit(name string, agentStatus status.Status, alive bool) *mockUnit {
+	return &mockUnit{
+		name:        name,
+		alive:       alive,
+		agentStatus: status.StatusInfo{Status: agentStatus},
+	}
+}
+
+func (u *mockUnit) Name() string {
+	return u.name
+}
+
+func (u *mockUnit) UnitTag() names.UnitTag {
+	return names.NewUnitTag(u.name)
+}
+
+func (u *mockUnit) AgentStatus() (status.StatusInfo, error) {
+	return u.agentStatus, nil
+}
+
+func (u *mockUnit) Status() (status.StatusInfo, error) {
+	return status.StatusInfo{Status: status.Active}, nil
+}
+
+func (u *mockUnit) AgentPresence() (bool, error) {
+	return u.alive, nil
+}
+
+func (u *mockUnit) ShouldBeAssigned() bool {
+	return true
+}
+
+func (u *mockUnit) Life() state.Life {
+	return state.Alive
+}
+
+func (u *mockUnit) SetAgentStatus(info status.StatusInfo) error {
+	u.setStatus = append(u.setStatus, info)
+	u.agentStatus = info
+	return nil
+}
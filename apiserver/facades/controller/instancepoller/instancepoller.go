@@ -5,18 +5,25 @@ package instancepoller
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/juju/clock"
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/watcher"
 )
 
+var logger = loggo.GetLogger("juju.apiserver.instancepoller")
+
 // InstancePollerAPI provides access to the InstancePoller API facade.
 type InstancePollerAPI struct {
 	*common.LifeGetter
@@ -32,6 +39,42 @@ type InstancePollerAPI struct {
 	clock         clock.Clock
 }
 
+// InstancePollerAPIV4 provides version 4 of the InstancePoller API facade.
+type InstancePollerAPIV4 struct {
+	*InstancePollerAPI
+}
+
+// InstancePollerAPIV3 provides version 3 of the InstancePoller API facade.
+type InstancePollerAPIV3 struct {
+	*InstancePollerAPIV4
+}
+
+// NewFacadeV4 creates a version 4 InstancePoller API facade.
+func NewFacadeV4(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*InstancePollerAPIV4, error) {
+	api, err := NewFacade(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &InstancePollerAPIV4{api}, nil
+}
+
+// NewFacadeV3 creates a version 3 InstancePoller API facade.
+func NewFacadeV3(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*InstancePollerAPIV3, error) {
+	api, err := NewFacadeV4(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &InstancePollerAPIV3{api}, nil
+}
+
 // NewFacade wraps NewInstancePollerAPI for facade registration.
 func NewFacade(
 	st *state.State,
@@ -261,3 +304,138 @@ func (a *InstancePollerAPI) AreManuallyProvisioned(args params.Entities) (params
 	}
 	return result, nil
 }
+
+// SetHardwareCharacteristics records the hardware reported by the
+// provider for each given machine's instance, so that instances resized
+// outside of Juju are reflected in the model. A machine whose instance
+// no longer satisfies its constraints is flagged in its modification
+// status. Only machine tags are accepted.
+func (a *InstancePollerAPI) SetHardwareCharacteristics(args params.SetMachinesHardwareCharacteristics) (params.ErrorResults, error) {
+	result := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Machines)),
+	}
+	canAccess, err := a.accessMachine()
+	if err != nil {
+		return result, err
+	}
+	for i, arg := range args.Machines {
+		machine, err := a.getOneMachine(arg.Tag, canAccess)
+		if err == nil {
+			err = a.setHardwareCharacteristics(machine, arg.Characteristics)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// SetHardwareCharacteristics isn't on the V3 API.
+func (*InstancePollerAPIV3) SetHardwareCharacteristics(_, _ struct{}) {}
+
+func (a *InstancePollerAPI) setHardwareCharacteristics(machine StateMachine, hc instance.HardwareCharacteristics) error {
+	if err := machine.UpdateHardwareCharacteristics(hc); err != nil {
+		return errors.Trace(err)
+	}
+	cons, err := machine.Constraints()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	now := a.clock.Now()
+	// The constraints can't be enforced on an instance resized outside
+	// of Juju, but the operator should see that they no longer hold.
+	if unsatisfied := unsatisfiedConstraints(cons, hc); len(unsatisfied) > 0 {
+		logger.Warningf(
+			"machine %s has been resized and no longer satisfies its constraints: %s",
+			machine.Id(), strings.Join(unsatisfied, ", "),
+		)
+		err := machine.SetModificationStatus(status.StatusInfo{
+			Status:  status.Error,
+			Message: "resized instance does not satisfy constraints: " + strings.Join(unsatisfied, ", "),
+			Data:    map[string]interface{}{unsatisfiedConstraintsKey: unsatisfied},
+			Since:   &now,
+		})
+		return errors.Trace(err)
+	}
+
+	// Clear the flag raised by an earlier resize, but leave any other
+	// modification status alone.
+	current, err := machine.ModificationStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := current.Data[unsatisfiedConstraintsKey]; !ok {
+		return nil
+	}
+	err = machine.SetModificationStatus(status.StatusInfo{
+		Status: status.Idle,
+		Since:  &now,
+	})
+	return errors.Trace(err)
+}
+
+// unsatisfiedConstraintsKey is the modification status data key that
+// marks the status as set because a resized instance doesn't satisfy
+// the machine's constraints.
+const unsatisfiedConstraintsKey = "unsatisfied-constraints"
+
+// unsatisfiedConstraints returns a description of each of the given
+// constraints that the hardware is known not to satisfy.
+func unsatisfiedConstraints(cons constraints.Value, hc instance.HardwareCharacteristics) []string {
+	var unsatisfied []string
+	check := func(name string, want, got *uint64) {
+		if want != nil && got != nil && *got < *want {
+			unsatisfied = append(unsatisfied, fmt.Sprintf("%s=%d (has %d)", name, *want, *got))
+		}
+	}
+	check("mem", cons.Mem, hc.Mem)
+	check("root-disk", cons.RootDisk, hc.RootDisk)
+	check("cores", cons.CpuCores, hc.CpuCores)
+	check("cpu-power", cons.CpuPower, hc.CpuPower)
+	return unsatisfied
+}
+
+// WatchInstancePollRequests returns a StringsWatcher which reports the
+// ids of the machines whose instances have been asked to be polled
+// without waiting for their next poll. Requests are reported until
+// cleared with ClearInstancePollRequests.
+func (a *InstancePollerAPI) WatchInstancePollRequests() (params.StringsWatchResult, error) {
+	result := params.StringsWatchResult{}
+	watch := a.st.WatchInstancePollRequests()
+	// Consume the initial event and forward it to the result.
+	if changes, ok := <-watch.Changes(); ok {
+		result.StringsWatcherId = a.resources.Register(watch)
+		result.Changes = changes
+	} else {
+		err := watcher.EnsureErr(watch)
+		return result, errors.Annotate(err, "cannot obtain initial instance poll requests")
+	}
+	return result, nil
+}
+
+// ClearInstancePollRequests removes the requests to poll the instances
+// of the given machines, once the instance poller has seen them. Only
+// machine tags are accepted.
+func (a *InstancePollerAPI) ClearInstancePollRequests(args params.Entities) (params.ErrorResult, error) {
+	canAccess, err := a.accessMachine()
+	if err != nil {
+		return params.ErrorResult{}, err
+	}
+	machineIds := make([]string, len(args.Entities))
+	for i, entity := range args.Entities {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil {
+			return params.ErrorResult{}, errors.Trace(err)
+		}
+		if !canAccess(tag) {
+			return params.ErrorResult{}, common.ErrPerm
+		}
+		machineIds[i] = tag.Id()
+	}
+	err = a.st.ClearInstancePollRequests(machineIds)
+	return params.ErrorResult{Error: common.ServerError(err)}, nil
+}
+
+// WatchInstancePollRequests isn't on the V4 API.
+func (*InstancePollerAPIV4) WatchInstancePollRequests(_, _ struct{}) {}
+
+// ClearInstancePollRequests isn't on the V4 API.
+func (*InstancePollerAPIV4) ClearInstancePollRequests(_, _ struct{}) {}
@@ -16,6 +16,8 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/instancepoller"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
@@ -536,6 +538,121 @@ func (s *InstancePollerSuite) TestSetProviderAddressesFailure(c *gc.C) {
 	c.Assert(machine.ProviderAddresses(), gc.HasLen, 0)
 }
 
+func (s *InstancePollerSuite) TestSetHardwareCharacteristicsSuccess(c *gc.C) {
+	s.st.SetMachineInfo(c, machineInfo{id: "1"})
+	s.st.SetMachineInfo(c, machineInfo{id: "2", constraints: constraints.MustParse("mem=8G")})
+
+	mem := uint64(4096)
+	cores := uint64(4)
+	hc := instance.HardwareCharacteristics{Mem: &mem, CpuCores: &cores}
+	result, err := s.api.SetHardwareCharacteristics(params.SetMachinesHardwareCharacteristics{
+		Machines: []params.MachineHardwareCharacteristics{
+			{Tag: "machine-1", Characteristics: hc},
+			{Tag: "machine-2", Characteristics: hc},
+			{Tag: "machine-42"},
+			{Tag: "application-unknown"},
+			{Tag: "invalid-tag"},
+			{Tag: "unit-missing-1"},
+			{Tag: ""},
+			{Tag: "42"},
+		}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, s.mixedErrorResults)
+
+	now := s.clock.Now()
+	s.st.CheckFindEntityCall(c, 0, "1")
+	s.st.CheckCall(c, 1, "UpdateHardwareCharacteristics", hc)
+	s.st.CheckCall(c, 2, "Constraints")
+	s.st.CheckCall(c, 3, "ModificationStatus")
+	s.st.CheckFindEntityCall(c, 4, "2")
+	s.st.CheckCall(c, 5, "UpdateHardwareCharacteristics", hc)
+	s.st.CheckCall(c, 6, "Constraints")
+	s.st.CheckCall(c, 7, "SetModificationStatus", status.StatusInfo{
+		Status:  status.Error,
+		Message: "resized instance does not satisfy constraints: mem=8192 (has 4096)",
+		Data:    map[string]interface{}{"unsatisfied-constraints": []string{"mem=8192 (has 4096)"}},
+		Since:   &now,
+	})
+	s.st.CheckFindEntityCall(c, 8, "42")
+
+	// Machine 2 was resized below its constraints, but the hardware
+	// is recorded regardless.
+	c.Check(c.GetTestLog(), jc.Contains, "machine 2 has been resized and no longer satisfies its constraints: mem=8192 (has 4096)")
+	for _, id := range []string{"1", "2"} {
+		machine, err := s.st.Machine(id)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(machine.(*mockMachine).hardware, jc.DeepEquals, hc)
+	}
+}
+
+func (s *InstancePollerSuite) TestSetHardwareCharacteristicsClearsFlag(c *gc.C) {
+	s.st.SetMachineInfo(c, machineInfo{
+		id:          "1",
+		constraints: constraints.MustParse("mem=8G"),
+		modStatus: status.StatusInfo{
+			Status:  status.Error,
+			Message: "resized instance does not satisfy constraints: mem=8192 (has 4096)",
+			Data:    map[string]interface{}{"unsatisfied-constraints": []interface{}{"mem=8192 (has 4096)"}},
+		},
+	})
+	s.st.SetMachineInfo(c, machineInfo{
+		id: "2",
+		modStatus: status.StatusInfo{
+			Status:  status.Applied,
+			Message: "lxd profile applied",
+		},
+	})
+
+	mem := uint64(8192)
+	hc := instance.HardwareCharacteristics{Mem: &mem}
+	result, err := s.api.SetHardwareCharacteristics(params.SetMachinesHardwareCharacteristics{
+		Machines: []params.MachineHardwareCharacteristics{
+			{Tag: "machine-1", Characteristics: hc},
+			{Tag: "machine-2", Characteristics: hc},
+		}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[1].Error, gc.IsNil)
+
+	// The flag raised by the earlier resize is cleared, but other
+	// modification statuses are left alone.
+	now := s.clock.Now()
+	machine, err := s.st.Machine("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(machine.(*mockMachine).modStatus, jc.DeepEquals, status.StatusInfo{
+		Status: status.Idle,
+		Since:  &now,
+	})
+	machine, err = s.st.Machine("2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(machine.(*mockMachine).modStatus.Status, gc.Equals, status.Applied)
+}
+
+func (s *InstancePollerSuite) TestSetHardwareCharacteristicsFailure(c *gc.C) {
+	s.st.SetErrors(
+		errors.New("pow!"),                   // m1 := FindEntity("1")
+		nil,                                  // m2 := FindEntity("2")
+		errors.New("FAIL"),                   // m2.UpdateHardwareCharacteristics()
+		errors.NotProvisionedf("machine 42"), // FindEntity("3") (ensure wrapping is preserved)
+	)
+	s.st.SetMachineInfo(c, machineInfo{id: "1"})
+	s.st.SetMachineInfo(c, machineInfo{id: "2"})
+
+	mem := uint64(4096)
+	result, err := s.api.SetHardwareCharacteristics(params.SetMachinesHardwareCharacteristics{
+		Machines: []params.MachineHardwareCharacteristics{
+			{Tag: "machine-1"},
+			{Tag: "machine-2", Characteristics: instance.HardwareCharacteristics{Mem: &mem}},
+			{Tag: "machine-3"},
+		}},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(result, jc.DeepEquals, s.machineErrorResults)
+}
+
 func toParamAddresses(addrs network.SpaceAddresses) []params.Address {
 	paramAddrs := make([]params.Address, len(addrs))
 	for i, addr := range addrs {
@@ -727,3 +844,46 @@ func (s *InstancePollerSuite) TestAreManuallyProvisionedFailure(c *gc.C) {
 func statusInfo(st string) status.StatusInfo {
 	return status.StatusInfo{Status: status.Status(st)}
 }
+
+func (s *InstancePollerSuite) TestWatchInstancePollRequests(c *gc.C) {
+	s.st.pollRequests = []string{"1", "2"}
+
+	result, err := s.api.WatchInstancePollRequests()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResult{
+		StringsWatcherId: "1",
+		Changes:          []string{"1", "2"},
+	})
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+	s.st.CheckCallNames(c, "WatchInstancePollRequests")
+}
+
+func (s *InstancePollerSuite) TestWatchInstancePollRequestsFailure(c *gc.C) {
+	s.st.SetErrors(errors.Errorf("boom"))
+
+	result, err := s.api.WatchInstancePollRequests()
+	c.Assert(err, gc.ErrorMatches, "cannot obtain initial instance poll requests: boom")
+	c.Assert(result, jc.DeepEquals, params.StringsWatchResult{})
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+}
+
+func (s *InstancePollerSuite) TestClearInstancePollRequests(c *gc.C) {
+	s.st.SetErrors(errors.New("boom"))
+
+	result, err := s.api.ClearInstancePollRequests(params.Entities{
+		Entities: []params.Entity{{Tag: "machine-1"}, {Tag: "machine-2"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "boom")
+	s.st.CheckCall(c, 0, "ClearInstancePollRequests", []string{"1", "2"})
+}
+
+func (s *InstancePollerSuite) TestClearInstancePollRequestsInvalidTag(c *gc.C) {
+	_, err := s.api.ClearInstancePollRequests(params.Entities{
+		Entities: []params.Entity{{Tag: "application-mysql"}},
+	})
+	c.Assert(err, gc.ErrorMatches, `"application-mysql" is not a valid machine tag`)
+	s.st.CheckNoCalls(c)
+}
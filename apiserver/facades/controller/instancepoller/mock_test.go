@@ -13,6 +13,7 @@ import (
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/apiserver/facades/controller/instancepoller"
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
@@ -30,8 +31,9 @@ type mockState struct {
 	configWatchers   []*mockConfigWatcher
 	machinesWatchers []*mockMachinesWatcher
 
-	config   *config.Config
-	machines map[string]*mockMachine
+	config       *config.Config
+	machines     map[string]*mockMachine
+	pollRequests []string
 }
 
 func NewMockState() *mockState {
@@ -117,6 +119,24 @@ func (m *mockState) WatchModelMachines() state.StringsWatcher {
 	return w
 }
 
+// WatchInstancePollRequests implements StateInterface.
+func (m *mockState) WatchInstancePollRequests() state.StringsWatcher {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "WatchInstancePollRequests")
+	return NewMockMachinesWatcher(m.pollRequests, m.NextErr())
+}
+
+// ClearInstancePollRequests implements StateInterface.
+func (m *mockState) ClearInstancePollRequests(machineIds []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "ClearInstancePollRequests", machineIds)
+	return m.NextErr()
+}
+
 // FindEntity implements StateInterface.
 func (m *mockState) FindEntity(tag names.Tag) (state.Entity, error) {
 	m.mu.Lock()
@@ -219,6 +239,9 @@ type machineInfo struct {
 	providerAddresses []network.SpaceAddress
 	life              state.Life
 	isManual          bool
+	constraints       constraints.Value
+	hardware          instance.HardwareCharacteristics
+	modStatus         status.StatusInfo
 }
 
 type mockMachine struct {
@@ -324,6 +347,50 @@ func (m *mockMachine) Status() (status.StatusInfo, error) {
 	return m.status, m.NextErr()
 }
 
+// Constraints implements StateMachine.
+func (m *mockMachine) Constraints() (constraints.Value, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "Constraints")
+	return m.constraints, m.NextErr()
+}
+
+// ModificationStatus implements StateMachine.
+func (m *mockMachine) ModificationStatus() (status.StatusInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "ModificationStatus")
+	return m.modStatus, m.NextErr()
+}
+
+// SetModificationStatus implements StateMachine.
+func (m *mockMachine) SetModificationStatus(modStatus status.StatusInfo) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "SetModificationStatus", modStatus)
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	m.modStatus = modStatus
+	return nil
+}
+
+// UpdateHardwareCharacteristics implements StateMachine.
+func (m *mockMachine) UpdateHardwareCharacteristics(hc instance.HardwareCharacteristics) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.MethodCall(m, "UpdateHardwareCharacteristics", hc)
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	m.hardware = hc
+	return nil
+}
+
 type mockBaseWatcher struct {
 	err error
 
@@ -4,6 +4,7 @@
 package instancepoller
 
 import (
+	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
@@ -26,6 +27,10 @@ type StateMachine interface {
 	Life() state.Life
 	Status() (status.StatusInfo, error)
 	IsManual() (bool, error)
+	Constraints() (constraints.Value, error)
+	UpdateHardwareCharacteristics(instance.HardwareCharacteristics) error
+	ModificationStatus() (status.StatusInfo, error)
+	SetModificationStatus(status.StatusInfo) error
 }
 
 type StateInterface interface {
@@ -35,6 +40,8 @@ type StateInterface interface {
 	network.SpaceLookup
 
 	Machine(id string) (StateMachine, error)
+	WatchInstancePollRequests() state.StringsWatcher
+	ClearInstancePollRequests(machineIds []string) error
 }
 
 // TODO - CAAS(ericclaudejones): This should contain state alone, model will be
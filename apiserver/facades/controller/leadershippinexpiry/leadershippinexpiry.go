@@ -0,0 +1,88 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package leadershippinexpiry implements the API used by the leadership
+// pin expirer worker, which lifts the pins operators place on the
+// leaders of applications once they expire.
+package leadershippinexpiry
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/leadership"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.leadershippinexpiry")
+
+// Backend exposes the state functionality required by the facade.
+type Backend interface {
+	// ApplicationsWithExpiredLeadershipPins returns the applications
+	// whose leaders were pinned until the current time or earlier.
+	ApplicationsWithExpiredLeadershipPins() ([]Application, error)
+}
+
+// Application exposes the application functionality required by the
+// facade.
+type Application interface {
+	Name() string
+	LeadershipPin() (state.LeadershipPin, bool)
+	ClearLeadershipPin() error
+}
+
+// API implements the LeadershipPinExpiry facade.
+type API struct {
+	backend Backend
+	pinner  leadership.Pinner
+}
+
+// NewAPI returns a new LeadershipPinExpiry API facade.
+func NewAPI(
+	backend Backend,
+	pinner leadership.Pinner,
+	authorizer facade.Authorizer,
+) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend: backend,
+		pinner:  pinner,
+	}, nil
+}
+
+// UnpinExpiredLeaders lifts the pins on the leaders of applications
+// which have expired, returning the names of the applications whose
+// leaders were unpinned. Pins which cannot be lifted are logged and
+// left for the next call.
+func (api *API) UnpinExpiredLeaders() (params.StringsResult, error) {
+	applications, err := api.backend.ApplicationsWithExpiredLeadershipPins()
+	if err != nil {
+		return params.StringsResult{}, errors.Trace(err)
+	}
+	result := params.StringsResult{Result: []string{}}
+	for _, app := range applications {
+		if err := api.unpin(app); err != nil {
+			logger.Warningf("cannot unpin leader of application %q: %v", app.Name(), err)
+			continue
+		}
+		result.Result = append(result.Result, app.Name())
+	}
+	return result, nil
+}
+
+func (api *API) unpin(app Application) error {
+	pin, ok := app.LeadershipPin()
+	if !ok {
+		// Unpinned since it was found to have expired.
+		return nil
+	}
+	if err := api.pinner.UnpinLeadership(app.Name(), pin.By); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(app.ClearLeadershipPin())
+}
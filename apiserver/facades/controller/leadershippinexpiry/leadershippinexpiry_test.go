@@ -0,0 +1,154 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadershippinexpiry_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/controller/leadershippinexpiry"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type LeadershipPinExpirySuite struct {
+	coretesting.BaseSuite
+
+	backend *mockBackend
+	pinner  *mockPinner
+	api     *leadershippinexpiry.API
+}
+
+var _ = gc.Suite(&LeadershipPinExpirySuite{})
+
+func (s *LeadershipPinExpirySuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	until := time.Date(2020, 4, 1, 10, 0, 0, 0, time.UTC)
+	s.backend = &mockBackend{
+		applications: []*mockApplication{{
+			name: "mysql",
+			pin:  &state.LeadershipPin{By: "user-admin", Until: until},
+		}, {
+			name: "redis",
+			pin:  &state.LeadershipPin{By: "user-bob", Reason: "upgrade", Until: until},
+		}},
+	}
+	s.pinner = &mockPinner{}
+	var err error
+	s.api, err = leadershippinexpiry.NewAPI(
+		s.backend, s.pinner,
+		apiservertesting.FakeAuthorizer{Controller: true},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *LeadershipPinExpirySuite) TestNewAPIRequiresController(c *gc.C) {
+	api, err := leadershippinexpiry.NewAPI(
+		s.backend, s.pinner,
+		apiservertesting.FakeAuthorizer{Controller: false},
+	)
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *LeadershipPinExpirySuite) TestUnpinExpiredLeaders(c *gc.C) {
+	result, err := s.api.UnpinExpiredLeaders()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.StringsResult{
+		Result: []string{"mysql", "redis"},
+	})
+	s.pinner.CheckCalls(c, []testing.StubCall{
+		{"UnpinLeadership", []interface{}{"mysql", "user-admin"}},
+		{"UnpinLeadership", []interface{}{"redis", "user-bob"}},
+	})
+	for _, app := range s.backend.applications {
+		c.Check(app.pin, gc.IsNil)
+	}
+}
+
+func (s *LeadershipPinExpirySuite) TestUnpinExpiredLeadersSkipsUnpinned(c *gc.C) {
+	s.backend.applications[0].pin = nil
+	result, err := s.api.UnpinExpiredLeaders()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, jc.DeepEquals, []string{"mysql", "redis"})
+	s.pinner.CheckCallNames(c, "UnpinLeadership")
+}
+
+func (s *LeadershipPinExpirySuite) TestUnpinExpiredLeadersUnpinError(c *gc.C) {
+	s.pinner.SetErrors(errors.New("boom"))
+	result, err := s.api.UnpinExpiredLeaders()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Result, jc.DeepEquals, []string{"redis"})
+	// The record is kept, so that unpinning is retried.
+	c.Assert(s.backend.applications[0].pin, gc.NotNil)
+}
+
+func (s *LeadershipPinExpirySuite) TestUnpinExpiredLeadersBackendError(c *gc.C) {
+	s.backend.err = errors.New("boom")
+	_, err := s.api.UnpinExpiredLeaders()
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.pinner.CheckNoCalls(c)
+}
+
+type mockBackend struct {
+	applications []*mockApplication
+	err          error
+}
+
+func (b *mockBackend) ApplicationsWithExpiredLeadershipPins() ([]leadershippinexpiry.Application, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	result := make([]leadershippinexpiry.Application, len(b.applications))
+	for i, app := range b.applications {
+		result[i] = app
+	}
+	return result, nil
+}
+
+type mockApplication struct {
+	name string
+	pin  *state.LeadershipPin
+}
+
+func (a *mockApplication) Name() string {
+	return a.name
+}
+
+func (a *mockApplication) LeadershipPin() (state.LeadershipPin, bool) {
+	if a.pin == nil {
+		return state.LeadershipPin{}, false
+	}
+	return *a.pin, true
+}
+
+func (a *mockApplication) ClearLeadershipPin() error {
+	a.pin = nil
+	return nil
+}
+
+type mockPinner struct {
+	testing.Stub
+}
+
+func (p *mockPinner) PinLeadership(applicationId string, entity string) error {
+	p.AddCall("PinLeadership", applicationId, entity)
+	return p.NextErr()
+}
+
+func (p *mockPinner) UnpinLeadership(applicationId string, entity string) error {
+	p.AddCall("UnpinLeadership", applicationId, entity)
+	return p.NextErr()
+}
+
+func (p *mockPinner) PinnedLeadership() map[string][]string {
+	p.AddCall("PinnedLeadership")
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadershippinexpiry
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb.
+
+// NewFacade provides the required signature for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	pinner, err := ctx.LeadershipPinner(st.ModelUUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(
+		backendShim{st: st, clock: clock.WallClock},
+		pinner,
+		ctx.Auth(),
+	)
+}
+
+type backendShim struct {
+	st    *state.State
+	clock clock.Clock
+}
+
+// ApplicationsWithExpiredLeadershipPins is part of the Backend
+// interface.
+func (shim backendShim) ApplicationsWithExpiredLeadershipPins() ([]Application, error) {
+	applications, err := shim.st.ApplicationsWithExpiredLeadershipPins(shim.clock.Now())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Application, len(applications))
+	for i, app := range applications {
+		result[i] = app
+	}
+	return result, nil
+}
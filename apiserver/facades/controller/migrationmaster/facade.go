@@ -163,10 +163,11 @@ func (api *API) ModelInfo() (params.MigrationModelInfo, error) {
 	}
 
 	return params.MigrationModelInfo{
-		UUID:         api.backend.ModelUUID(),
-		Name:         name,
-		OwnerTag:     owner.String(),
-		AgentVersion: vers,
+		UUID:          api.backend.ModelUUID(),
+		Name:          name,
+		OwnerTag:      owner.String(),
+		AgentVersion:  vers,
+		FormatVersion: migration.FormatVersion,
 	}, nil
 }
 
@@ -125,6 +125,7 @@ func (s *Suite) TestModelInfo(c *gc.C) {
 	c.Assert(model.Name, gc.Equals, "model-name")
 	c.Assert(model.OwnerTag, gc.Equals, names.NewUserTag("owner").String())
 	c.Assert(model.AgentVersion, gc.Equals, version.MustParse("1.2.3"))
+	c.Assert(model.FormatVersion, gc.Equals, migration.FormatVersion)
 }
 
 func (s *Suite) TestSetPhase(c *gc.C) {
@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/controller"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/environs"
@@ -103,6 +104,7 @@ func (api *API) Prechecks(model params.MigrationModelInfo) error {
 			Owner:                  ownerTag,
 			AgentVersion:           model.AgentVersion,
 			ControllerAgentVersion: model.ControllerAgentVersion,
+			FormatVersion:          model.FormatVersion,
 		},
 		api.presence.ModelPresence(controllerState.ModelUUID()),
 	)
@@ -111,8 +113,13 @@ func (api *API) Prechecks(model params.MigrationModelInfo) error {
 // Import takes a serialized Juju model, deserializes it, and
 // recreates it in the receiving controller.
 func (api *API) Import(serialized params.SerializedModel) error {
-	controller := state.NewController(api.pool)
-	_, st, err := migration.ImportModel(controller, api.getClaimer, serialized.Bytes)
+	config, err := api.state.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	strict := config.ModelImportMode() == controller.ModelImportStrict
+	importer := state.NewController(api.pool)
+	_, st, err := migration.ImportModel(importer, api.getClaimer, serialized.Bytes, strict)
 	if err != nil {
 		return err
 	}
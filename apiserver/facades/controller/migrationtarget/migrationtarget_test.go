@@ -152,6 +152,26 @@ func (s *Suite) TestImport(c *gc.C) {
 	c.Assert(model.MigrationMode(), gc.Equals, state.MigrationModeImporting)
 }
 
+func (s *Suite) TestImportUnknownFields(c *gc.C) {
+	_, bytes := s.makeExportedModel(c)
+	bytes = append(bytes, "colour: blue\n"...)
+
+	err := s.State.UpdateControllerConfig(map[string]interface{}{
+		"model-import-mode": "strict",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	api := s.mustNewAPI(c)
+	err = api.Import(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, gc.ErrorMatches, "model description has unknown fields: colour")
+
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		"model-import-mode": "lenient",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = api.Import(params.SerializedModel{Bytes: bytes})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *Suite) TestImportLeadership(c *gc.C) {
 	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{
 		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{
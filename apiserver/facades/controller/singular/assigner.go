@@ -0,0 +1,251 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package singular
+
+import (
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/lease"
+	"github.com/juju/juju/core/presence"
+)
+
+// DefaultDeferralGrace is the longest that a controller agent will
+// defer to the controller agent assigned to a model, before claiming
+// responsibility for the model itself.
+const DefaultDeferralGrace = time.Minute
+
+// Assigner decides which controller agent should be responsible for
+// running the singular workers of a model.
+type Assigner interface {
+	// Deferral returns how long the claimant should defer to another
+	// controller agent before claiming responsibility for the model.
+	// Zero means that the claimant may claim responsibility now.
+	Deferral(modelUUID, claimant string) (time.Duration, error)
+
+	// WaitDeferral blocks until the claimant no longer defers to
+	// another controller agent for the model. If the cancel channel
+	// is closed first, it returns lease.ErrWaitCancelled.
+	WaitDeferral(modelUUID, claimant string, cancel <-chan struct{}) error
+}
+
+// LoadAssignerConfig holds the resources and configuration needed
+// by a LoadAssigner.
+type LoadAssignerConfig struct {
+	// Leases reports the holders of the singular leases for all
+	// models.
+	Leases lease.Lister
+
+	// Candidates returns the tags of the controller agents that are
+	// currently able to run the model's workers.
+	Candidates func() ([]string, error)
+
+	// Clock is used to time deferrals.
+	Clock clock.Clock
+
+	// Grace is the longest that a claimant defers to the assigned
+	// controller agent, so that a model is never left unmanaged by
+	// an assignee that is connected but not claiming.
+	Grace time.Duration
+}
+
+// Validate returns an error if the config cannot be used to create
+// a LoadAssigner.
+func (config LoadAssignerConfig) Validate() error {
+	if config.Leases == nil {
+		return errors.NotValidf("nil Leases")
+	}
+	if config.Candidates == nil {
+		return errors.NotValidf("nil Candidates")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Grace <= 0 {
+		return errors.NotValidf("non-positive Grace")
+	}
+	return nil
+}
+
+// LoadAssigner is an Assigner that spreads responsibility for models
+// across the connected controller agents, in proportion to the number
+// of models each is already responsible for.
+//
+// A controller agent keeps the models it is responsible for unless it
+// is responsible for at least two more models than the least loaded
+// candidate; in that case it gives up its models one at a time, so that
+// responsibility migrates gradually rather than all at once.
+type LoadAssigner struct {
+	config LoadAssignerConfig
+
+	mu            sync.Mutex
+	deferredSince map[string]time.Time
+}
+
+// NewLoadAssigner returns a LoadAssigner with the supplied config.
+func NewLoadAssigner(config LoadAssignerConfig) (*LoadAssigner, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &LoadAssigner{
+		config:        config,
+		deferredSince: make(map[string]time.Time),
+	}, nil
+}
+
+// Deferral is part of the Assigner interface.
+func (a *LoadAssigner) Deferral(modelUUID, claimant string) (time.Duration, error) {
+	holder, assignee, err := a.Assignee(modelUUID, claimant)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	switch {
+	case assignee == claimant:
+		delete(a.deferredSince, modelUUID)
+		return 0, nil
+	case holder == claimant:
+		// The claimant must give up the model, and start deferring
+		// only once its lease has expired.
+		delete(a.deferredSince, modelUUID)
+		return a.config.Grace, nil
+	case holder != "":
+		// Another controller agent is responsible; the claim will
+		// be denied by the lease manager without our help.
+		return 0, nil
+	}
+
+	now := a.config.Clock.Now()
+	since, ok := a.deferredSince[modelUUID]
+	if !ok {
+		since = now
+		a.deferredSince[modelUUID] = since
+	}
+	remaining := since.Add(a.config.Grace).Sub(now)
+	if remaining < 0 {
+		remaining = 0
+	}
+	return remaining, nil
+}
+
+// WaitDeferral is part of the Assigner interface.
+func (a *LoadAssigner) WaitDeferral(modelUUID, claimant string, cancel <-chan struct{}) error {
+	remaining, err := a.Deferral(modelUUID, claimant)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if remaining <= 0 {
+		return nil
+	}
+	select {
+	case <-a.config.Clock.After(remaining):
+		return nil
+	case <-cancel:
+		return lease.ErrWaitCancelled
+	}
+}
+
+// Assignee returns the current holder of the model's singular lease,
+// and the controller agent that should be responsible for the model.
+func (a *LoadAssigner) Assignee(modelUUID, claimant string) (holder, assignee string, _ error) {
+	candidates, err := a.config.Candidates()
+	if err != nil {
+		return "", "", errors.Annotate(err, "getting candidate controller agents")
+	}
+	// The claimant is demonstrably able to run the model's workers,
+	// whether or not it has been reported as connected yet.
+	load := map[string]int{claimant: 0}
+	for _, candidate := range candidates {
+		load[candidate] = 0
+	}
+
+	// lastModel records the model with the greatest UUID held by each
+	// candidate, to choose which model an overloaded candidate gives up.
+	lastModel := make(map[string]string)
+	for key, leaseHolder := range a.config.Leases.Holders() {
+		if key.Lease != key.ModelUUID {
+			// This is the controller's singular lease, which
+			// doesn't carry any model workers.
+			continue
+		}
+		if key.ModelUUID == modelUUID {
+			holder = leaseHolder
+		}
+		if _, ok := load[leaseHolder]; !ok {
+			continue
+		}
+		load[leaseHolder]++
+		if key.ModelUUID > lastModel[leaseHolder] {
+			lastModel[leaseHolder] = key.ModelUUID
+		}
+	}
+
+	if _, ok := load[holder]; ok {
+		least := leastLoaded(modelUUID, load, "")
+		if load[holder] <= load[least]+1 || lastModel[holder] != modelUUID {
+			return holder, holder, nil
+		}
+		return holder, leastLoaded(modelUUID, load, holder), nil
+	}
+	return holder, leastLoaded(modelUUID, load, ""), nil
+}
+
+// leastLoaded returns the candidate responsible for the fewest models,
+// ignoring the excluded candidate. Ties are broken by hashing the model
+// UUID with each candidate, so that models with equally loaded
+// candidates are spread between them consistently.
+func leastLoaded(modelUUID string, load map[string]int, exclude string) string {
+	var (
+		best      string
+		bestScore uint64
+	)
+	for candidate, count := range load {
+		if candidate == exclude {
+			continue
+		}
+		score := rendezvousScore(modelUUID, candidate)
+		if best == "" ||
+			count < load[best] ||
+			(count == load[best] && score > bestScore) {
+			best, bestScore = candidate, score
+		}
+	}
+	return best
+}
+
+func rendezvousScore(modelUUID, candidate string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(modelUUID))
+	h.Write([]byte{0})
+	h.Write([]byte(candidate))
+	return h.Sum64()
+}
+
+// controllerConnections is implemented by the model presence of
+// the API server, and exposes the connections of controller agents
+// as well as those of other agents.
+type controllerConnections interface {
+	Values() []presence.Value
+}
+
+// connectedControllerAgents returns the tags of the controller agents
+// with live connections to the model.
+func connectedControllerAgents(connections controllerConnections) []string {
+	seen := make(map[string]bool)
+	var agents []string
+	for _, value := range connections.Values() {
+		if !value.ControllerAgent || value.Status != presence.Alive || seen[value.Agent] {
+			continue
+		}
+		seen[value.Agent] = true
+		agents = append(agents, value.Agent)
+	}
+	return agents
+}
@@ -0,0 +1,240 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package singular_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/facades/controller/singular"
+	"github.com/juju/juju/core/lease"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type AssignerSuite struct {
+	testing.IsolationSuite
+
+	clock      *testclock.Clock
+	leases     fakeLister
+	candidates []string
+}
+
+var _ = gc.Suite(&AssignerSuite{})
+
+func (s *AssignerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+	s.leases = fakeLister{}
+	s.candidates = []string{"machine-0", "machine-1", "machine-2"}
+}
+
+func (s *AssignerSuite) newAssigner(c *gc.C) *singular.LoadAssigner {
+	assigner, err := singular.NewLoadAssigner(singular.LoadAssignerConfig{
+		Leases: s.leases,
+		Candidates: func() ([]string, error) {
+			return s.candidates, nil
+		},
+		Clock: s.clock,
+		Grace: time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return assigner
+}
+
+func (s *AssignerSuite) hold(holder string, modelUUIDs ...string) {
+	for _, modelUUID := range modelUUIDs {
+		s.leases[lease.Key{
+			Namespace: lease.SingularControllerNamespace,
+			ModelUUID: modelUUID,
+			Lease:     modelUUID,
+		}] = holder
+	}
+}
+
+func (s *AssignerSuite) TestValidate(c *gc.C) {
+	valid := singular.LoadAssignerConfig{
+		Leases:     s.leases,
+		Candidates: func() ([]string, error) { return nil, nil },
+		Clock:      s.clock,
+		Grace:      time.Minute,
+	}
+	breakers := []struct {
+		breaker func(config *singular.LoadAssignerConfig)
+		err     string
+	}{{
+		func(config *singular.LoadAssignerConfig) { config.Leases = nil },
+		"nil Leases not valid",
+	}, {
+		func(config *singular.LoadAssignerConfig) { config.Candidates = nil },
+		"nil Candidates not valid",
+	}, {
+		func(config *singular.LoadAssignerConfig) { config.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(config *singular.LoadAssignerConfig) { config.Grace = 0 },
+		"non-positive Grace not valid",
+	}}
+	for i, test := range breakers {
+		c.Logf("test %d", i)
+		config := valid
+		test.breaker(&config)
+		_, err := singular.NewLoadAssigner(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *AssignerSuite) TestAssignsLeastLoaded(c *gc.C) {
+	s.hold("machine-0", "a", "b")
+	s.hold("machine-1", "c")
+	assigner := s.newAssigner(c)
+
+	holder, assignee, err := assigner.Assignee("d", "machine-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(holder, gc.Equals, "")
+	c.Check(assignee, gc.Equals, "machine-2")
+}
+
+func (s *AssignerSuite) TestAssignmentIgnoresControllerLease(c *gc.C) {
+	s.candidates = []string{"machine-0", "machine-1"}
+	s.hold("machine-0", "a")
+	s.leases[lease.Key{
+		Namespace: lease.SingularControllerNamespace,
+		ModelUUID: "c",
+		Lease:     coretesting.ControllerTag.Id(),
+	}] = "machine-1"
+	s.hold("machine-1", "c")
+	assigner := s.newAssigner(c)
+
+	// Both candidates hold one model, so the tie is broken
+	// consistently whichever candidate claims.
+	_, first, err := assigner.Assignee("d", "machine-0")
+	c.Assert(err, jc.ErrorIsNil)
+	_, second, err := assigner.Assignee("d", "machine-1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(first, gc.Equals, second)
+}
+
+func (s *AssignerSuite) TestAssignmentIgnoresDisconnectedHolders(c *gc.C) {
+	s.candidates = []string{"machine-0"}
+	s.hold("machine-1", "a")
+	assigner := s.newAssigner(c)
+
+	holder, assignee, err := assigner.Assignee("a", "machine-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(holder, gc.Equals, "machine-1")
+	c.Check(assignee, gc.Equals, "machine-0")
+}
+
+func (s *AssignerSuite) TestHolderKeepsModel(c *gc.C) {
+	s.hold("machine-0", "a", "b")
+	s.hold("machine-1", "c")
+	s.hold("machine-2", "d")
+	assigner := s.newAssigner(c)
+
+	deferral, err := assigner.Deferral("b", "machine-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(deferral, gc.Equals, time.Duration(0))
+}
+
+func (s *AssignerSuite) TestOverloadedHolderGivesUpLastModel(c *gc.C) {
+	s.hold("machine-0", "a", "b", "c")
+	s.hold("machine-1", "d")
+	s.hold("machine-2", "e")
+	assigner := s.newAssigner(c)
+
+	deferral, err := assigner.Deferral("a", "machine-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(deferral, gc.Equals, time.Duration(0))
+
+	deferral, err = assigner.Deferral("c", "machine-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(deferral, gc.Equals, time.Minute)
+
+	// Other claimants are denied by the lease manager.
+	deferral, err = assigner.Deferral("c", "machine-1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(deferral, gc.Equals, time.Duration(0))
+}
+
+func (s *AssignerSuite) TestDeferralExpires(c *gc.C) {
+	s.hold("machine-0", "a")
+	s.hold("machine-1", "b")
+	assigner := s.newAssigner(c)
+
+	deferral, err := assigner.Deferral("c", "machine-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(deferral, gc.Equals, time.Minute)
+
+	s.clock.Advance(40 * time.Second)
+	deferral, err = assigner.Deferral("c", "machine-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(deferral, gc.Equals, 20*time.Second)
+
+	s.clock.Advance(20 * time.Second)
+	deferral, err = assigner.Deferral("c", "machine-0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(deferral, gc.Equals, time.Duration(0))
+
+	// The assignee never defers.
+	deferral, err = assigner.Deferral("c", "machine-2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(deferral, gc.Equals, time.Duration(0))
+}
+
+func (s *AssignerSuite) TestWaitDeferral(c *gc.C) {
+	s.hold("machine-0", "a")
+	s.hold("machine-1", "b")
+	assigner := s.newAssigner(c)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- assigner.WaitDeferral("c", "machine-0", nil)
+	}()
+	c.Assert(s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1), jc.ErrorIsNil)
+	select {
+	case err := <-done:
+		c.Check(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for deferral")
+	}
+}
+
+func (s *AssignerSuite) TestWaitDeferralCancelled(c *gc.C) {
+	s.hold("machine-0", "a")
+	s.hold("machine-1", "b")
+	assigner := s.newAssigner(c)
+
+	cancel := make(chan struct{})
+	close(cancel)
+	err := assigner.WaitDeferral("c", "machine-0", cancel)
+	c.Check(err, gc.Equals, lease.ErrWaitCancelled)
+}
+
+func (s *AssignerSuite) TestCandidatesError(c *gc.C) {
+	assigner, err := singular.NewLoadAssigner(singular.LoadAssignerConfig{
+		Leases: s.leases,
+		Candidates: func() ([]string, error) {
+			return nil, errors.New("boom")
+		},
+		Clock: s.clock,
+		Grace: time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = assigner.Deferral("a", "machine-0")
+	c.Check(err, gc.ErrorMatches, "getting candidate controller agents: boom")
+}
+
+// fakeLister implements lease.Lister.
+type fakeLister map[lease.Key]string
+
+// Holders is part of the lease.Lister interface.
+func (l fakeLister) Holders() map[lease.Key]string {
+	return l
+}
@@ -61,3 +61,21 @@ func (mock *mockBackend) WaitUntilExpired(leaseId string, cancel <-chan struct{}
 	}
 	return mock.stub.NextErr()
 }
+
+// mockAssigner implements singular.Assigner.
+type mockAssigner struct {
+	stub     testing.Stub
+	deferral time.Duration
+}
+
+// Deferral is part of the singular.Assigner interface.
+func (mock *mockAssigner) Deferral(modelUUID, claimant string) (time.Duration, error) {
+	mock.stub.AddCall("Deferral", modelUUID, claimant)
+	return mock.deferral, mock.stub.NextErr()
+}
+
+// WaitDeferral is part of the singular.Assigner interface.
+func (mock *mockAssigner) WaitDeferral(modelUUID, claimant string, cancel <-chan struct{}) error {
+	mock.stub.AddCall("WaitDeferral", modelUUID, claimant)
+	return mock.stub.NextErr()
+}
@@ -7,6 +7,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v3"
 
@@ -32,8 +33,41 @@ func NewExternalFacade(context facade.Context) (*Facade, error) {
 		return nil, errors.Trace(err)
 	}
 
+	assigner, err := newAssigner(context, m.UUID())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	backend := getBackend(st, m.ModelTag())
-	return NewFacade(backend, claimer, auth)
+	return NewFacade(backend, claimer, assigner, auth)
+}
+
+// newAssigner returns a LoadAssigner for the model's singular lease,
+// or nil if models are claimed on a first come, first served basis
+// because the API server can't see the load across all models.
+func newAssigner(context facade.Context, modelUUID string) (Assigner, error) {
+	lister, err := context.LeaseLister(lease.SingularControllerNamespace)
+	if errors.IsNotSupported(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	presence := context.Presence()
+	if presence == nil {
+		return nil, nil
+	}
+	connections, ok := presence.ModelPresence(modelUUID).(controllerConnections)
+	if !ok {
+		return nil, nil
+	}
+	return NewLoadAssigner(LoadAssignerConfig{
+		Leases: lister,
+		Candidates: func() ([]string, error) {
+			return connectedControllerAgents(connections), nil
+		},
+		Clock: clock.WallClock,
+		Grace: DefaultDeferralGrace,
+	})
 }
 
 var getBackend = func(st *state.State, modelTag names.ModelTag) Backend {
@@ -61,8 +95,10 @@ type Backend interface {
 }
 
 // NewFacade returns a singular-controller API facade, backed by the supplied
-// state, so long as the authorizer represents a controller machine.
-func NewFacade(backend Backend, claimer lease.Claimer, auth facade.Authorizer) (*Facade, error) {
+// state, so long as the authorizer represents a controller machine. If the
+// assigner is nil, any controller machine may claim responsibility for the
+// model.
+func NewFacade(backend Backend, claimer lease.Claimer, assigner Assigner, auth facade.Authorizer) (*Facade, error) {
 	if !auth.AuthController() {
 		return nil, common.ErrPerm
 	}
@@ -71,6 +107,7 @@ func NewFacade(backend Backend, claimer lease.Claimer, auth facade.Authorizer) (
 		modelTag:        backend.ModelTag(),
 		controllerTag:   backend.ControllerTag(),
 		singularClaimer: claimer,
+		assigner:        assigner,
 	}, nil
 }
 
@@ -81,6 +118,7 @@ type Facade struct {
 	controllerTag   names.ControllerTag
 	modelTag        names.ModelTag
 	singularClaimer lease.Claimer
+	assigner        Assigner
 }
 
 // Wait waits for the singular-controller lease to expire for all supplied
@@ -98,6 +136,10 @@ func (facade *Facade) Wait(ctx context.Context, args params.Entities) (result pa
 		// We should be waiting for the leases in parallel,
 		// so the waits do not affect one another.
 		err = facade.singularClaimer.WaitUntilExpired(leaseId, ctx.Done())
+		if err == nil && facade.assigned(entity.Tag) {
+			holder := facade.auth.GetAuthTag().String()
+			err = facade.assigner.WaitDeferral(leaseId, holder, ctx.Done())
+		}
 		result.Results[i].Error = common.ServerError(err)
 	}
 	return result
@@ -127,9 +169,25 @@ func (facade *Facade) claim(claim params.SingularClaim) error {
 	if claim.ClaimantTag != holder {
 		return common.ErrPerm
 	}
+	if facade.assigned(claim.EntityTag) {
+		deferral, err := facade.assigner.Deferral(leaseId, holder)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if deferral > 0 {
+			return lease.ErrClaimDenied
+		}
+	}
 	return facade.singularClaimer.Claim(leaseId, holder, claim.Duration)
 }
 
+// assigned returns true if responsibility for the entity is subject
+// to the facade's assigner. Only models are assigned; the controller
+// is always claimed on a first come, first served basis.
+func (facade *Facade) assigned(tagString string) bool {
+	return facade.assigner != nil && tagString == facade.modelTag.String()
+}
+
 func (facade *Facade) tagLeaseId(tagString string) (string, error) {
 	tag, err := names.ParseTag(tagString)
 	if err != nil {
@@ -31,14 +31,14 @@ var _ = gc.Suite(&SingularSuite{})
 
 func (s *SingularSuite) TestRequiresController(c *gc.C) {
 	auth := mockAuth{nonController: true}
-	facade, err := singular.NewFacade(nil, nil, auth)
+	facade, err := singular.NewFacade(nil, nil, nil, auth)
 	c.Check(facade, gc.IsNil)
 	c.Check(err, gc.Equals, common.ErrPerm)
 }
 
 func (s *SingularSuite) TestAcceptsController(c *gc.C) {
 	backend := &mockBackend{}
-	facade, err := singular.NewFacade(backend, backend, mockAuth{})
+	facade, err := singular.NewFacade(backend, backend, nil, mockAuth{})
 	c.Check(facade, gc.NotNil)
 	c.Check(err, jc.ErrorIsNil)
 
@@ -69,7 +69,7 @@ func (s *SingularSuite) TestInvalidClaims(c *gc.C) {
 	}
 
 	backend := &mockBackend{}
-	facade, err := singular.NewFacade(backend, backend, mockAuth{})
+	facade, err := singular.NewFacade(backend, backend, nil, mockAuth{})
 	c.Assert(err, jc.ErrorIsNil)
 	result := facade.Claim(claims)
 	c.Assert(result.Results, gc.HasLen, count)
@@ -124,7 +124,7 @@ func (s *SingularSuite) TestValidClaims(c *gc.C) {
 
 	backend := &mockBackend{}
 	backend.stub.SetErrors(errors...)
-	facade, err := singular.NewFacade(backend, backend, mockAuth{})
+	facade, err := singular.NewFacade(backend, backend, nil, mockAuth{})
 	c.Assert(err, jc.ErrorIsNil)
 	result := facade.Claim(claims)
 	c.Assert(result.Results, gc.HasLen, count)
@@ -160,7 +160,7 @@ func (s *SingularSuite) TestWait(c *gc.C) {
 
 	backend := &mockBackend{}
 	backend.stub.SetErrors(errors.New("zap!"), nil)
-	facade, err := singular.NewFacade(backend, backend, mockAuth{})
+	facade, err := singular.NewFacade(backend, backend, nil, mockAuth{})
 	c.Assert(err, jc.ErrorIsNil)
 	result := facade.Wait(context.TODO(), waits)
 	c.Assert(result.Results, gc.HasLen, count)
@@ -192,7 +192,7 @@ func (s *SingularSuite) TestWaitCancelled(c *gc.C) {
 	count := len(waits.Entities)
 
 	backend := &mockBackend{}
-	facade, err := singular.NewFacade(backend, backend, mockAuth{})
+	facade, err := singular.NewFacade(backend, backend, nil, mockAuth{})
 	c.Assert(err, jc.ErrorIsNil)
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -202,6 +202,103 @@ func (s *SingularSuite) TestWaitCancelled(c *gc.C) {
 	c.Check(result.Results[0].Error, gc.ErrorMatches, "waiting for lease cancelled by client")
 }
 
+func (s *SingularSuite) TestClaimDeferred(c *gc.C) {
+	backend := &mockBackend{}
+	assigner := &mockAssigner{deferral: time.Second}
+	facade, err := singular.NewFacade(backend, backend, assigner, mockAuth{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result := facade.Claim(params.SingularClaims{
+		Claims: []params.SingularClaim{{
+			EntityTag:   coretesting.ModelTag.String(),
+			ClaimantTag: "machine-123",
+			Duration:    time.Minute,
+		}, {
+			EntityTag:   coretesting.ControllerTag.String(),
+			ClaimantTag: "machine-123",
+			Duration:    time.Minute,
+		}},
+	})
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Check(result.Results[0].Error, jc.Satisfies, params.IsCodeLeaseClaimDenied)
+	c.Check(result.Results[1].Error, gc.IsNil)
+
+	assigner.stub.CheckCalls(c, []testing.StubCall{{
+		FuncName: "Deferral",
+		Args:     []interface{}{coretesting.ModelTag.Id(), "machine-123"},
+	}})
+	backend.stub.CheckCalls(c, []testing.StubCall{{
+		FuncName: "Claim",
+		Args:     []interface{}{coretesting.ControllerTag.Id(), "machine-123", time.Minute},
+	}})
+}
+
+func (s *SingularSuite) TestClaimAssigned(c *gc.C) {
+	backend := &mockBackend{}
+	assigner := &mockAssigner{}
+	facade, err := singular.NewFacade(backend, backend, assigner, mockAuth{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result := facade.Claim(params.SingularClaims{
+		Claims: []params.SingularClaim{{
+			EntityTag:   coretesting.ModelTag.String(),
+			ClaimantTag: "machine-123",
+			Duration:    time.Minute,
+		}},
+	})
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Check(result.Results[0].Error, gc.IsNil)
+
+	assigner.stub.CheckCallNames(c, "Deferral")
+	backend.stub.CheckCalls(c, []testing.StubCall{{
+		FuncName: "Claim",
+		Args:     []interface{}{coretesting.ModelTag.Id(), "machine-123", time.Minute},
+	}})
+}
+
+func (s *SingularSuite) TestClaimAssignerError(c *gc.C) {
+	backend := &mockBackend{}
+	assigner := &mockAssigner{}
+	assigner.stub.SetErrors(errors.New("boom"))
+	facade, err := singular.NewFacade(backend, backend, assigner, mockAuth{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result := facade.Claim(params.SingularClaims{
+		Claims: []params.SingularClaim{{
+			EntityTag:   coretesting.ModelTag.String(),
+			ClaimantTag: "machine-123",
+			Duration:    time.Minute,
+		}},
+	})
+	c.Assert(result.Results, gc.HasLen, 1)
+	c.Check(result.Results[0].Error, gc.ErrorMatches, "boom")
+	backend.stub.CheckCallNames(c)
+}
+
+func (s *SingularSuite) TestWaitDeferral(c *gc.C) {
+	backend := &mockBackend{}
+	assigner := &mockAssigner{}
+	facade, err := singular.NewFacade(backend, backend, assigner, mockAuth{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result := facade.Wait(context.TODO(), params.Entities{
+		Entities: []params.Entity{{
+			coretesting.ModelTag.String(),
+		}, {
+			coretesting.ControllerTag.String(),
+		}},
+	})
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Check(result.Results[0].Error, gc.IsNil)
+	c.Check(result.Results[1].Error, gc.IsNil)
+
+	assigner.stub.CheckCalls(c, []testing.StubCall{{
+		FuncName: "WaitDeferral",
+		Args:     []interface{}{coretesting.ModelTag.Id(), "machine-123"},
+	}})
+	backend.stub.CheckCallNames(c, "WaitUntilExpired", "WaitUntilExpired")
+}
+
 func checkDenied(c *gc.C, result params.ErrorResult) {
 	if !c.Check(result.Error, gc.NotNil) {
 		return
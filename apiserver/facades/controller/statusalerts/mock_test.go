@@ -0,0 +1,144 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statusalerts_test
+
+import (
+	"github.com/juju/testing"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/facades/controller/statusalerts"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mockBackend struct {
+	testing.Stub
+	machines     []*mockMachine
+	applications []*mockApplication
+	units        []*mockUnit
+}
+
+func (b *mockBackend) WatchForModelConfigChanges() state.NotifyWatcher {
+	b.MethodCall(b, "WatchForModelConfigChanges")
+	return nil
+}
+
+func (b *mockBackend) ModelConfig() (*config.Config, error) {
+	b.MethodCall(b, "ModelConfig")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	return config.New(config.UseDefaults, coretesting.FakeConfig())
+}
+
+func (b *mockBackend) AllMachines() ([]statusalerts.Machine, error) {
+	b.MethodCall(b, "AllMachines")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	machines := make([]statusalerts.Machine, len(b.machines))
+	for i, m := range b.machines {
+		machines[i] = m
+	}
+	return machines, nil
+}
+
+func (b *mockBackend) AllApplications() ([]statusalerts.Application, error) {
+	b.MethodCall(b, "AllApplications")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	applications := make([]statusalerts.Application, len(b.applications))
+	for i, app := range b.applications {
+		applications[i] = app
+	}
+	return applications, nil
+}
+
+func (b *mockBackend) AllUnits() ([]statusalerts.Unit, error) {
+	b.MethodCall(b, "AllUnits")
+	if err := b.NextErr(); err != nil {
+		return nil, err
+	}
+	units := make([]statusalerts.Unit, len(b.units))
+	for i, u := range b.units {
+		units[i] = u
+	}
+	return units, nil
+}
+
+type mockMachine struct {
+	id     string
+	alive  bool
+	status status.StatusInfo
+	err    error
+}
+
+func (m *mockMachine) Id() string {
+	return m.id
+}
+
+func (m *mockMachine) Status() (status.StatusInfo, error) {
+	return m.status, m.err
+}
+
+func (m *mockMachine) AgentPresence() (bool, error) {
+	return m.alive, nil
+}
+
+func (m *mockMachine) Life() state.Life {
+	return state.Alive
+}
+
+type mockApplication struct {
+	name   string
+	status status.StatusInfo
+}
+
+func (a *mockApplication) Name() string {
+	return a.name
+}
+
+func (a *mockApplication) Status() (status.StatusInfo, error) {
+	return a.status, nil
+}
+
+type mockUnit struct {
+	name           string
+	alive          bool
+	agentStatus    status.StatusInfo
+	workloadStatus status.StatusInfo
+	workloadErr    error
+}
+
+func (u *mockUnit) Name() string {
+	return u.name
+}
+
+func (u *mockUnit) ApplicationName() string {
+	app, _ := names.UnitApplication(u.name)
+	return app
+}
+
+func (u *mockUnit) AgentStatus() (status.StatusInfo, error) {
+	return u.agentStatus, nil
+}
+
+func (u *mockUnit) Status() (status.StatusInfo, error) {
+	return u.workloadStatus, u.workloadErr
+}
+
+func (u *mockUnit) AgentPresence() (bool, error) {
+	return u.alive, nil
+}
+
+func (u *mockUnit) ShouldBeAssigned() bool {
+	return true
+}
+
+func (u *mockUnit) Life() state.Life {
+	return state.Alive
+}
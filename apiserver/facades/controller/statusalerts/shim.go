@@ -0,0 +1,84 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statusalerts
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/state"
+)
+
+// This file contains untested shims to let us wrap state in a sensible
+// interface and avoid writing tests that depend on mongodb.
+
+// NewFacade provides the required signature for facade registration.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewAPI(
+		backendShim{st: st, model: model},
+		ctx.Presence().ModelPresence(st.ModelUUID()),
+		ctx.Resources(),
+		ctx.Auth(),
+	)
+}
+
+type backendShim struct {
+	st    *state.State
+	model *state.Model
+}
+
+// WatchForModelConfigChanges is part of the Backend interface.
+func (shim backendShim) WatchForModelConfigChanges() state.NotifyWatcher {
+	return shim.model.WatchForModelConfigChanges()
+}
+
+// ModelConfig is part of the Backend interface.
+func (shim backendShim) ModelConfig() (*config.Config, error) {
+	return shim.model.ModelConfig()
+}
+
+// AllMachines is part of the Backend interface.
+func (shim backendShim) AllMachines() ([]Machine, error) {
+	machines, err := shim.st.AllMachines()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Machine, len(machines))
+	for i, machine := range machines {
+		result[i] = machine
+	}
+	return result, nil
+}
+
+// AllApplications is part of the Backend interface.
+func (shim backendShim) AllApplications() ([]Application, error) {
+	applications, err := shim.st.AllApplications()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Application, len(applications))
+	for i, app := range applications {
+		result[i] = app
+	}
+	return result, nil
+}
+
+// AllUnits is part of the Backend interface.
+func (shim backendShim) AllUnits() ([]Unit, error) {
+	units, err := shim.model.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]Unit, len(units))
+	for i, unit := range units {
+		result[i] = unit
+	}
+	return result, nil
+}
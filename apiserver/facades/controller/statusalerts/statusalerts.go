@@ -0,0 +1,156 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package statusalerts implements the API used by the status alerts
+// worker, which raises alerts for the entities in a model matching the
+// model's status-alert-rules.
+package statusalerts
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/statusalert"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.statusalerts")
+
+// Backend exposes the state functionality required by the facade.
+type Backend interface {
+	state.ModelAccessor
+
+	// AllMachines returns all the machines in the model.
+	AllMachines() ([]Machine, error)
+
+	// AllApplications returns all the applications in the model.
+	AllApplications() ([]Application, error)
+
+	// AllUnits returns all the units in the model.
+	AllUnits() ([]Unit, error)
+}
+
+// Machine exposes the machine functionality required by the facade.
+type Machine interface {
+	common.MachineStatusGetter
+}
+
+// Application exposes the application functionality required by the
+// facade.
+type Application interface {
+	Name() string
+	Status() (status.StatusInfo, error)
+}
+
+// Unit exposes the unit functionality required by the facade.
+type Unit interface {
+	common.UnitStatusGetter
+	ApplicationName() string
+}
+
+// API implements the StatusAlerts facade.
+type API struct {
+	*common.ModelWatcher
+	backend  Backend
+	presence common.ModelPresenceContext
+}
+
+// NewAPI returns a new StatusAlerts API facade.
+func NewAPI(
+	backend Backend,
+	presence common.ModelPresence,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*API, error) {
+	if !authorizer.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		ModelWatcher: common.NewModelWatcher(backend, resources, authorizer),
+		backend:      backend,
+		presence:     common.ModelPresenceContext{Presence: presence},
+	}, nil
+}
+
+// StatusAlertEntities returns the current status of the machines,
+// applications and units in the model, as seen by "juju status".
+// Entities whose status cannot be read are skipped.
+func (api *API) StatusAlertEntities() (params.StatusAlertEntitiesResult, error) {
+	result := params.StatusAlertEntitiesResult{
+		Entities: []params.StatusAlertEntity{},
+	}
+
+	machines, err := api.backend.AllMachines()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, m := range machines {
+		info, err := api.presence.MachineStatus(m)
+		if err != nil {
+			logger.Warningf("cannot get status of machine %q: %v", m.Id(), err)
+			continue
+		}
+		result.Entities = append(result.Entities, params.StatusAlertEntity{
+			Kind:     string(statusalert.KindMachine),
+			Name:     m.Id(),
+			Statuses: []params.EntityStatus{entityStatus(info)},
+		})
+	}
+
+	applications, err := api.backend.AllApplications()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, app := range applications {
+		info, err := app.Status()
+		if err != nil {
+			logger.Warningf("cannot get status of application %q: %v", app.Name(), err)
+			continue
+		}
+		result.Entities = append(result.Entities, params.StatusAlertEntity{
+			Kind:        string(statusalert.KindApplication),
+			Name:        app.Name(),
+			Application: app.Name(),
+			Statuses:    []params.EntityStatus{entityStatus(info)},
+		})
+	}
+
+	units, err := api.backend.AllUnits()
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	for _, u := range units {
+		agent, workload := api.presence.UnitStatus(u)
+		if agent.Err != nil {
+			logger.Warningf("cannot get agent status of unit %q: %v", u.Name(), agent.Err)
+			continue
+		}
+		if workload.Err != nil {
+			logger.Warningf("cannot get workload status of unit %q: %v", u.Name(), workload.Err)
+			continue
+		}
+		result.Entities = append(result.Entities, params.StatusAlertEntity{
+			Kind:        string(statusalert.KindUnit),
+			Name:        u.Name(),
+			Application: u.ApplicationName(),
+			Statuses: []params.EntityStatus{
+				entityStatus(agent.Status),
+				entityStatus(workload.Status),
+			},
+		})
+	}
+	return result, nil
+}
+
+func entityStatus(info status.StatusInfo) params.EntityStatus {
+	return params.EntityStatus{
+		Status: info.Status,
+		Info:   info.Message,
+		Data:   info.Data,
+		Since:  info.Since,
+	}
+}
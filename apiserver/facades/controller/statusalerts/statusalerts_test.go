@@ -0,0 +1,132 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statusalerts_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/controller/statusalerts"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/core/status"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type StatusAlertsSuite struct {
+	coretesting.BaseSuite
+
+	backend *mockBackend
+	api     *statusalerts.API
+	since   time.Time
+}
+
+var _ = gc.Suite(&StatusAlertsSuite{})
+
+func (s *StatusAlertsSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.since = time.Date(2020, 1, 3, 14, 0, 0, 0, time.UTC)
+	info := func(st status.Status, message string) status.StatusInfo {
+		return status.StatusInfo{Status: st, Message: message, Since: &s.since}
+	}
+	s.backend = &mockBackend{
+		machines: []*mockMachine{
+			{id: "0", alive: true, status: info(status.Started, "")},
+			{id: "1", alive: false, status: info(status.Started, "")},
+			{id: "2", status: info(status.Pending, "")},
+			{id: "3", err: errors.New("boom")},
+		},
+		applications: []*mockApplication{
+			{name: "mysql", status: info(status.Blocked, "need a relation")},
+		},
+		units: []*mockUnit{{
+			name:           "mysql/0",
+			alive:          true,
+			agentStatus:    info(status.Idle, ""),
+			workloadStatus: info(status.Error, "hook failed"),
+		}, {
+			name:           "mysql/1",
+			alive:          false,
+			agentStatus:    info(status.Idle, ""),
+			workloadStatus: info(status.Active, ""),
+		}, {
+			name:        "mysql/2",
+			alive:       true,
+			agentStatus: info(status.Idle, ""),
+			workloadErr: errors.New("boom"),
+		}},
+	}
+	var err error
+	s.api, err = statusalerts.NewAPI(
+		s.backend, nil, common.NewResources(),
+		apiservertesting.FakeAuthorizer{Controller: true},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StatusAlertsSuite) TestNewAPIRequiresController(c *gc.C) {
+	api, err := statusalerts.NewAPI(
+		s.backend, nil, common.NewResources(),
+		apiservertesting.FakeAuthorizer{Controller: false},
+	)
+	c.Assert(api, gc.IsNil)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *StatusAlertsSuite) TestStatusAlertEntities(c *gc.C) {
+	result, err := s.api.StatusAlertEntities()
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.CheckCallNames(c, "AllMachines", "AllApplications", "AllUnits")
+	since := &s.since
+	c.Assert(result, jc.DeepEquals, params.StatusAlertEntitiesResult{
+		Entities: []params.StatusAlertEntity{{
+			Kind:     "machine",
+			Name:     "0",
+			Statuses: []params.EntityStatus{{Status: status.Started, Since: since}},
+		}, {
+			Kind: "machine",
+			Name: "1",
+			Statuses: []params.EntityStatus{{
+				Status: status.Down,
+				Info:   "agent is not communicating with the server",
+				Since:  since,
+			}},
+		}, {
+			Kind:     "machine",
+			Name:     "2",
+			Statuses: []params.EntityStatus{{Status: status.Pending, Since: since}},
+		}, {
+			Kind:        "application",
+			Name:        "mysql",
+			Application: "mysql",
+			Statuses:    []params.EntityStatus{{Status: status.Blocked, Info: "need a relation", Since: since}},
+		}, {
+			Kind:        "unit",
+			Name:        "mysql/0",
+			Application: "mysql",
+			Statuses: []params.EntityStatus{
+				{Status: status.Idle, Since: since},
+				{Status: status.Error, Info: "hook failed", Since: since},
+			},
+		}, {
+			Kind:        "unit",
+			Name:        "mysql/1",
+			Application: "mysql",
+			Statuses: []params.EntityStatus{
+				{Status: status.Lost, Info: "agent is not communicating with the server", Since: since},
+				{Status: status.Unknown, Info: "agent lost, see 'juju show-status-log mysql/1'", Since: since},
+			},
+		}},
+	})
+}
+
+func (s *StatusAlertsSuite) TestStatusAlertEntitiesError(c *gc.C) {
+	s.backend.SetErrors(nil, errors.New("boom"))
+	_, err := s.api.StatusAlertEntities()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
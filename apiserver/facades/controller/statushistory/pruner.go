@@ -7,6 +7,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/state"
 )
 
@@ -32,11 +33,31 @@ func NewAPI(st *state.State, r facade.Resources, auth facade.Authorizer) (*API,
 }
 
 // Prune endpoint removes status history entries until
-// only the ones newer than now - p.MaxHistoryTime remain and
-// the history is smaller than p.MaxHistoryMB.
+// only the ones newer than now - p.MaxHistoryTime remain,
+// the history is smaller than p.MaxHistoryMB and no entity
+// has more than p.MaxEntriesPerEntity entries. Zero values
+// are not applied, and p.MaxHistoryMB is only applied on the
+// controller model. The limits in p.PerKind replace those for
+// the history of their kinds of entity. If p.Compact is true, runs of
+// identical entries are first collapsed into one.
 func (api *API) Prune(p params.StatusHistoryPruneArgs) error {
 	if !api.authorizer.AuthController() {
 		return common.ErrPerm
 	}
-	return state.PruneStatusHistory(api.st, p.MaxHistoryTime, p.MaxHistoryMB)
+	policy := state.StatusHistoryPrunePolicy{
+		MaxAge:              p.MaxHistoryTime,
+		MaxSizeMB:           p.MaxHistoryMB,
+		MaxEntriesPerEntity: p.MaxEntriesPerEntity,
+		Compact:             p.Compact,
+	}
+	if len(p.PerKind) > 0 {
+		policy.PerKind = make(map[status.RetentionKind]status.HistoryRetention)
+		for _, r := range p.PerKind {
+			policy.PerKind[status.RetentionKind(r.Kind)] = status.HistoryRetention{
+				MaxAge:     r.MaxHistoryTime,
+				MaxEntries: r.MaxEntriesPerEntity,
+			}
+		}
+	}
+	return state.PruneStatusHistory(api.st, policy)
 }
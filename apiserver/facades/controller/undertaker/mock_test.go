@@ -22,7 +22,8 @@ type mockState struct {
 	removed  bool
 	isSystem bool
 
-	watcher state.NotifyWatcher
+	watcher   state.NotifyWatcher
+	abandoned []state.AbandonedResource
 }
 
 var _ undertaker.State = (*mockState)(nil)
@@ -95,15 +96,28 @@ func (m *mockState) ModelUUID() string {
 	return m.model.UUID()
 }
 
+func (m *mockState) RecordAbandonedResource(kind state.AbandonedResourceKind, id, providerId, reason string) error {
+	m.abandoned = append(m.abandoned, state.AbandonedResource{
+		ModelUUID:  m.model.UUID(),
+		ModelOwner: m.model.owner.Id(),
+		Kind:       kind,
+		Id:         id,
+		ProviderId: providerId,
+		Reason:     reason,
+	})
+	return nil
+}
+
 // mockModel implements Model interface and allows inspection of called
 // methods.
 type mockModel struct {
-	tod    time.Time
-	owner  names.UserTag
-	life   state.Life
-	name   string
-	uuid   string
-	forced bool
+	tod       time.Time
+	owner     names.UserTag
+	life      state.Life
+	name      string
+	uuid      string
+	forced    bool
+	modelType state.ModelType
 
 	status     status.Status
 	statusInfo string
@@ -136,6 +150,13 @@ func (m *mockModel) UUID() string {
 	return m.uuid
 }
 
+func (m *mockModel) Type() state.ModelType {
+	if m.modelType == "" {
+		return state.ModelTypeIAAS
+	}
+	return m.modelType
+}
+
 func (m *mockModel) Destroy() error {
 	m.life = state.Dying
 	return nil
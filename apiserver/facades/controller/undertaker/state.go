@@ -40,6 +40,10 @@ type State interface {
 	// ModelUUID returns the model UUID for the model controlled
 	// by this state instance.
 	ModelUUID() string
+
+	// RecordAbandonedResource records that a cloud resource of the
+	// model was removed without the provider confirming its release.
+	RecordAbandonedResource(kind state.AbandonedResourceKind, id, providerId, reason string) error
 }
 
 // TODO - CAAS(ericclaudejones): This should contain state alone, model will be
@@ -75,6 +79,9 @@ type Model interface {
 	// Name returns the human friendly name of the model.
 	Name() string
 
+	// Type returns the type of the model.
+	Type() state.ModelType
+
 	// UUID returns the universally unique identifier of the model.
 	UUID() string
 }
@@ -22,6 +22,22 @@ type UndertakerAPI struct {
 	*common.StatusSetter
 }
 
+// UndertakerAPIV1 implements version 1 of the Undertaker API, which
+// lacks AbandonCloudEnvironment.
+type UndertakerAPIV1 struct {
+	*UndertakerAPI
+}
+
+// NewUndertakerAPIV1 creates a new instance of version 1 of the
+// undertaker API.
+func NewUndertakerAPIV1(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UndertakerAPIV1, error) {
+	api, err := NewUndertakerAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &UndertakerAPIV1{api}, nil
+}
+
 // NewUndertakerAPI creates a new instance of the undertaker API.
 func NewUndertakerAPI(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UndertakerAPI, error) {
 	m, err := st.Model()
@@ -92,6 +108,28 @@ func (u *UndertakerAPI) RemoveModel() error {
 	return u.st.RemoveDyingModel()
 }
 
+// AbandonCloudEnvironment records that the cloud environment of a
+// force-destroyed model could not be torn down, so that the user can
+// be told to clean it up manually. For a CAAS model the environment is
+// the model's namespace.
+func (u *UndertakerAPI) AbandonCloudEnvironment(args params.AbandonCloudEnvironmentArgs) error {
+	model, err := u.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !model.ForceDestroyed() {
+		return errors.Errorf("model %q was not destroyed with force", model.Name())
+	}
+	kind, id := state.AbandonedEnviron, model.UUID()
+	if model.Type() == state.ModelTypeCAAS {
+		kind, id = state.AbandonedNamespace, model.Name()
+	}
+	return errors.Trace(u.st.RecordAbandonedResource(kind, id, "", args.Reason))
+}
+
+// AbandonCloudEnvironment isn't on the V1 API.
+func (*UndertakerAPIV1) AbandonCloudEnvironment(_, _ struct{}) {}
+
 func (u *UndertakerAPI) modelEntitiesWatcher() params.NotifyWatchResult {
 	var nothing params.NotifyWatchResult
 	watch := u.st.WatchModelEntityReferences(u.st.ModelUUID())
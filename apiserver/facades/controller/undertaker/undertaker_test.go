@@ -183,3 +183,41 @@ func (s *undertakerSuite) TestSetStatusNonControllerPermissions(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results.Results[0].Error, gc.ErrorMatches, "permission denied")
 }
+
+func (s *undertakerSuite) TestAbandonCloudEnvironment(c *gc.C) {
+	mock, hostedAPI := s.setupStateAndAPI(c, false, "hostedmodel")
+	mock.model.life = state.Dying
+	mock.model.forced = true
+
+	err := hostedAPI.AbandonCloudEnvironment(params.AbandonCloudEnvironmentArgs{Reason: "boom"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mock.abandoned, jc.DeepEquals, []state.AbandonedResource{{
+		ModelUUID:  mock.model.uuid,
+		ModelOwner: "admin",
+		Kind:       state.AbandonedEnviron,
+		Id:         mock.model.uuid,
+		Reason:     "boom",
+	}})
+}
+
+func (s *undertakerSuite) TestAbandonCloudEnvironmentCAAS(c *gc.C) {
+	mock, hostedAPI := s.setupStateAndAPI(c, false, "hostedmodel")
+	mock.model.life = state.Dying
+	mock.model.forced = true
+	mock.model.modelType = state.ModelTypeCAAS
+
+	err := hostedAPI.AbandonCloudEnvironment(params.AbandonCloudEnvironmentArgs{Reason: "boom"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(mock.abandoned, gc.HasLen, 1)
+	c.Assert(mock.abandoned[0].Kind, gc.Equals, state.AbandonedNamespace)
+	c.Assert(mock.abandoned[0].Id, gc.Equals, "hostedmodel")
+}
+
+func (s *undertakerSuite) TestAbandonCloudEnvironmentNotForced(c *gc.C) {
+	mock, hostedAPI := s.setupStateAndAPI(c, false, "hostedmodel")
+	mock.model.life = state.Dying
+
+	err := hostedAPI.AbandonCloudEnvironment(params.AbandonCloudEnvironmentArgs{Reason: "boom"})
+	c.Assert(err, gc.ErrorMatches, `model "hostedmodel" was not destroyed with force`)
+	c.Assert(mock.abandoned, gc.HasLen, 0)
+}
@@ -0,0 +1,205 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/schema"
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/httpcontext"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/websocket"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+const (
+	// modelEventsBatchSize is the number of events read from the
+	// database at a time.
+	modelEventsBatchSize = 100
+
+	// modelEventsPollInterval is how often the database is checked
+	// for new events once all of them have been sent.
+	modelEventsPollInterval = time.Second
+
+	// modelEventsGapTimeout is how long to wait for an event to be
+	// committed when a later one already has been. Sequence numbers
+	// are taken before the transactions recording the events are run,
+	// so the events can be committed out of order, and the numbers
+	// taken by transactions which are aborted are never used.
+	modelEventsGapTimeout = 10 * time.Second
+)
+
+// modelEventsSource provides the lifecycle events of a model.
+type modelEventsSource interface {
+	ModelEvents(from, limit int) ([]state.ModelEvent, error)
+}
+
+// modelEventsHandler takes requests to stream the lifecycle events of a
+// model.
+type modelEventsHandler struct {
+	ctxt          httpContext
+	authenticator httpcontext.Authenticator
+}
+
+func newModelEventsHandler(ctxt httpContext, authenticator httpcontext.Authenticator) *modelEventsHandler {
+	return &modelEventsHandler{
+		ctxt:          ctxt,
+		authenticator: authenticator,
+	}
+}
+
+// ServeHTTP will serve up connections as a websocket for the model
+// events API. Each event is sent as a JSON-encoded params.ModelEvent,
+// in the order of their sequence numbers. An event is sent at least
+// once for each time the stream is opened from a position before it.
+//
+// As with debug-log, the authentication and authorization are done
+// after the request has been upgraded to a websocket, so that any
+// discharge required error is returned as the initial error.
+//
+// Args for the HTTP request are as follows:
+//   from -> int - the sequence number of the first event to send
+//   notail -> string - one of [true, false], if true, the events
+//      recorded so far are sent, but the stream does not wait for
+//      new ones.
+func (h *modelEventsHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	handler := func(conn *websocket.Conn) {
+		defer conn.Close()
+		st, err := h.stateForRequest(req)
+		if err != nil {
+			h.sendError(conn, err)
+			return
+		}
+		defer st.Release()
+
+		var cfg params.ModelEventsConfig
+		query := req.URL.Query()
+		query.Del(":modeluuid")
+		if err := schema.NewDecoder().Decode(&cfg, query); err != nil {
+			h.sendError(conn, errors.Annotate(err, "decoding schema"))
+			return
+		}
+		if cfg.From < 0 {
+			h.sendError(conn, errors.NotValidf("negative from %d", cfg.From))
+			return
+		}
+		h.sendError(conn, nil)
+
+		err = streamModelEvents(h.ctxt.srv.clock, st, cfg, conn, h.ctxt.stop())
+		if isBrokenPipe(err) {
+			logger.Tracef("model events handler stopped (client disconnected)")
+		} else if err != nil {
+			logger.Errorf("model events handler error: %v", err)
+		}
+	}
+	websocket.Serve(w, req, handler)
+}
+
+// stateForRequest returns the state of the model named in the request,
+// checking that the request was made by a user who can read the model.
+func (h *modelEventsHandler) stateForRequest(req *http.Request) (_ *state.PooledState, err error) {
+	authInfo, err := h.authenticator.Authenticate(req)
+	if err != nil {
+		return nil, errors.Annotate(err, "authentication failed")
+	}
+	st, err := h.ctxt.stateForRequestUnauthenticated(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			st.Release()
+		}
+	}()
+
+	tag := authInfo.Entity.Tag()
+	if _, ok := tag.(names.UserTag); !ok {
+		return nil, errors.Annotatef(common.ErrPerm, "authorization failed for %s", names.ReadableString(tag))
+	}
+	ok, err := common.HasPermission(st.UserPermission, tag, permission.SuperuserAccess, st.ControllerTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !ok {
+		ok, err = common.HasPermission(st.UserPermission, tag, permission.ReadAccess, names.NewModelTag(st.ModelUUID()))
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if !ok {
+		return nil, errors.Annotatef(common.ErrPerm, "authorization failed for %s", names.ReadableString(tag))
+	}
+	return st, nil
+}
+
+// sendError sends a JSON-encoded error response.
+func (h *modelEventsHandler) sendError(conn *websocket.Conn, err error) {
+	if sendErr := conn.SendInitialErrorV0(err); sendErr != nil {
+		logger.Errorf("closing websocket, %v", err)
+		conn.Close()
+	}
+}
+
+// streamModelEvents writes the model's events to the connection,
+// starting with the one numbered cfg.From, until the stop channel is
+// closed or, if cfg.NoTail is set, the events recorded so far have been
+// written.
+//
+// An event is only skipped over if it hasn't been committed within
+// modelEventsGapTimeout of a later one, so that events aren't missed by
+// consumers resuming from the last event they were sent.
+func streamModelEvents(
+	clock clock.Clock,
+	source modelEventsSource,
+	cfg params.ModelEventsConfig,
+	conn messageWriter,
+	stop <-chan struct{},
+) error {
+	next := cfg.From
+	for {
+		events, err := source.ModelEvents(next, modelEventsBatchSize)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		waiting := false
+		for _, event := range events {
+			if event.Seq > next && clock.Now().Sub(event.Time) < modelEventsGapTimeout {
+				waiting = true
+				break
+			}
+			if err := conn.WriteJSON(modelEventToParams(event)); err != nil {
+				return errors.Trace(err)
+			}
+			next = event.Seq + 1
+		}
+		if !waiting && len(events) == modelEventsBatchSize {
+			continue
+		}
+		if cfg.NoTail {
+			return nil
+		}
+		select {
+		case <-stop:
+			return nil
+		case <-clock.After(modelEventsPollInterval):
+		}
+	}
+}
+
+func modelEventToParams(event state.ModelEvent) params.ModelEvent {
+	return params.ModelEvent{
+		Seq:    event.Seq,
+		Kind:   string(event.Kind),
+		Entity: event.Entity,
+		Data:   event.Data,
+		Time:   event.Time,
+	}
+}
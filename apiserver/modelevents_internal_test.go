@@ -0,0 +1,189 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type modelEventsIntSuite struct {
+	coretesting.BaseSuite
+	clock  *testclock.Clock
+	source *fakeModelEventsSource
+	conn   *fakeModelEventsConn
+}
+
+var _ = gc.Suite(&modelEventsIntSuite{})
+
+func (s *modelEventsIntSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC))
+	s.source = &fakeModelEventsSource{}
+	s.conn = &fakeModelEventsConn{sent: make(chan params.ModelEvent, 10)}
+}
+
+func (s *modelEventsIntSuite) addEvent(seq int, age time.Duration) {
+	s.source.add(state.ModelEvent{
+		Seq:    seq,
+		Kind:   state.ModelEventUnitAdded,
+		Entity: "unit-mysql-0",
+		Data:   map[string]string{"application": "mysql"},
+		Time:   s.clock.Now().Add(-age),
+	})
+}
+
+func (s *modelEventsIntSuite) assertSent(c *gc.C, seqs ...int) {
+	for _, seq := range seqs {
+		select {
+		case event := <-s.conn.sent:
+			c.Assert(event.Seq, gc.Equals, seq)
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for event %d", seq)
+		}
+	}
+	select {
+	case event := <-s.conn.sent:
+		c.Fatalf("unexpected event %d", event.Seq)
+	default:
+	}
+}
+
+func (s *modelEventsIntSuite) TestNoTail(c *gc.C) {
+	s.addEvent(0, time.Minute)
+	s.addEvent(1, time.Minute)
+	s.addEvent(2, time.Minute)
+
+	err := streamModelEvents(s.clock, s.source, params.ModelEventsConfig{NoTail: true}, s.conn, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertSent(c, 0, 1, 2)
+}
+
+func (s *modelEventsIntSuite) TestEventConversion(c *gc.C) {
+	s.addEvent(0, time.Minute)
+
+	err := streamModelEvents(s.clock, s.source, params.ModelEventsConfig{NoTail: true}, s.conn, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(<-s.conn.sent, jc.DeepEquals, params.ModelEvent{
+		Seq:    0,
+		Kind:   "unit-added",
+		Entity: "unit-mysql-0",
+		Data:   map[string]string{"application": "mysql"},
+		Time:   s.clock.Now().Add(-time.Minute),
+	})
+}
+
+func (s *modelEventsIntSuite) TestFrom(c *gc.C) {
+	s.addEvent(0, time.Minute)
+	s.addEvent(1, time.Minute)
+	s.addEvent(2, time.Minute)
+
+	err := streamModelEvents(s.clock, s.source, params.ModelEventsConfig{From: 1, NoTail: true}, s.conn, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertSent(c, 1, 2)
+}
+
+func (s *modelEventsIntSuite) TestWaitsForRecentGap(c *gc.C) {
+	s.addEvent(0, time.Minute)
+	s.addEvent(2, time.Second)
+
+	err := streamModelEvents(s.clock, s.source, params.ModelEventsConfig{NoTail: true}, s.conn, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertSent(c, 0)
+}
+
+func (s *modelEventsIntSuite) TestSkipsOldGap(c *gc.C) {
+	s.addEvent(0, time.Minute)
+	s.addEvent(2, modelEventsGapTimeout)
+	s.addEvent(3, time.Second)
+
+	err := streamModelEvents(s.clock, s.source, params.ModelEventsConfig{NoTail: true}, s.conn, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertSent(c, 0, 2, 3)
+}
+
+func (s *modelEventsIntSuite) TestBatches(c *gc.C) {
+	var seqs []int
+	for i := 0; i < modelEventsBatchSize+5; i++ {
+		s.addEvent(i, time.Minute)
+		seqs = append(seqs, i)
+	}
+	s.conn.sent = make(chan params.ModelEvent, len(seqs))
+
+	err := streamModelEvents(s.clock, s.source, params.ModelEventsConfig{NoTail: true}, s.conn, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertSent(c, seqs...)
+}
+
+func (s *modelEventsIntSuite) TestTail(c *gc.C) {
+	s.addEvent(0, time.Minute)
+	s.addEvent(2, 0)
+
+	stop := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- streamModelEvents(s.clock, s.source, params.ModelEventsConfig{}, s.conn, stop)
+	}()
+	s.assertSent(c, 0)
+
+	// Once event 1 is committed, it is sent ahead of event 2.
+	s.addEvent(1, 0)
+	err := s.clock.WaitAdvance(modelEventsPollInterval, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertSent(c, 1, 2)
+
+	close(stop)
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for the stream to stop")
+	}
+}
+
+type fakeModelEventsSource struct {
+	mu     sync.Mutex
+	events []state.ModelEvent
+}
+
+func (s *fakeModelEventsSource) add(event state.ModelEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, e := range s.events {
+		if e.Seq > event.Seq {
+			s.events = append(s.events[:i], append([]state.ModelEvent{event}, s.events[i:]...)...)
+			return
+		}
+	}
+	s.events = append(s.events, event)
+}
+
+func (s *fakeModelEventsSource) ModelEvents(from, limit int) ([]state.ModelEvent, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var result []state.ModelEvent
+	for _, event := range s.events {
+		if event.Seq >= from && len(result) < limit {
+			result = append(result, event)
+		}
+	}
+	return result, nil
+}
+
+type fakeModelEventsConn struct {
+	sent chan params.ModelEvent
+}
+
+func (c *fakeModelEventsConn) WriteJSON(v interface{}) error {
+	c.sent <- v.(params.ModelEvent)
+	return nil
+}
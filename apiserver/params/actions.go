@@ -164,3 +164,41 @@ type ActionPruneArgs struct {
 type ActionMessageParams struct {
 	Messages []EntityString `json:"messages"`
 }
+
+// AgentIntrospectArgs holds the agents, and the sections of their
+// introspection data, to fetch.
+type AgentIntrospectArgs struct {
+	Args []AgentIntrospectArg `json:"args"`
+}
+
+// AgentIntrospectArg identifies a section of the introspection data of
+// a machine or unit agent.
+type AgentIntrospectArg struct {
+	// Tag is the tag of the machine or unit whose agent is introspected.
+	Tag string `json:"tag"`
+
+	// Section is the section of the introspection data to fetch, such
+	// as depengine, goroutines, machinelock, metrics or leases.
+	Section string `json:"section"`
+}
+
+// AgentIntrospectResults holds the results of an AgentIntrospect call.
+type AgentIntrospectResults struct {
+	Results []AgentIntrospectResult `json:"results"`
+}
+
+// AgentIntrospectResult holds a section of an agent's introspection
+// data, or the tag of the action queued on the agent's machine to
+// fetch it.
+type AgentIntrospectResult struct {
+	// Action is the tag of the action which fetches the section from
+	// the agent. It is empty if the controller answered the request
+	// itself.
+	Action string `json:"action,omitempty"`
+
+	// Output holds the section when the controller answered the
+	// request itself.
+	Output string `json:"output,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
@@ -112,6 +112,39 @@ func (e RedirectErrorInfo) AsMap() map[string]interface{} {
 	return serializeToMap(e)
 }
 
+// ColocationViolationErrorInfo provides the details of the colocation
+// rule which would be broken by placing a unit on a machine.
+type ColocationViolationErrorInfo struct {
+	Application string   `json:"application"`
+	Unit        string   `json:"unit,omitempty"`
+	Machine     string   `json:"machine"`
+	Rule        string   `json:"rule"`
+	Group       []string `json:"group,omitempty"`
+	Limit       int      `json:"limit,omitempty"`
+	Units       []string `json:"units,omitempty"`
+}
+
+// AsMap encodes the error info as a map that can be attached to an Error.
+func (e ColocationViolationErrorInfo) AsMap() map[string]interface{} {
+	return serializeToMap(e)
+}
+
+// QuotaLimitExceededErrorInfo provides the details of the per-model
+// quota which would be exceeded by adding entities to a model.
+type QuotaLimitExceededErrorInfo struct {
+	Model  string `json:"model"`
+	Quota  string `json:"quota"`
+	Entity string `json:"entity"`
+	Limit  int    `json:"limit"`
+	Count  int    `json:"count"`
+	Adding int    `json:"adding"`
+}
+
+// AsMap encodes the error info as a map that can be attached to an Error.
+func (e QuotaLimitExceededErrorInfo) AsMap() map[string]interface{} {
+	return serializeToMap(e)
+}
+
 // serializeToMap is a convenience function for marshaling v into a
 // map[string]interface{}. It works by marshalling v into json and then
 // unmarshaling back to a map.
@@ -174,6 +207,8 @@ const (
 	CodeIncompatibleSeries        = "incompatible series"
 	CodeCloudRegionRequired       = "cloud region required"
 	CodeIncompatibleClouds        = "incompatible clouds"
+	CodeColocationViolation       = "colocation violation"
+	CodeQuotaLimitExceeded        = "quota limit exceeded"
 )
 
 // ErrCode returns the error code associated with
@@ -344,6 +379,14 @@ func IsCodeForbidden(err error) bool {
 	return ErrCode(err) == CodeForbidden
 }
 
+func IsCodeColocationViolation(err error) bool {
+	return ErrCode(err) == CodeColocationViolation
+}
+
+func IsCodeQuotaLimitExceeded(err error) bool {
+	return ErrCode(err) == CodeQuotaLimitExceeded
+}
+
 func IsCodeCloudRegionRequired(err error) bool {
 	return ErrCode(err) == CodeCloudRegionRequired
 }
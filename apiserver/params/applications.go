@@ -149,6 +149,12 @@ type ApplicationSetCharm struct {
 	// space names to be merged with any existing endpoint bindings. This
 	// field is only understood by Application facade version 10 and greater.
 	EndpointBindings map[string]string `json:"endpoint-bindings,omitempty"`
+
+	// CanaryUpgrade, if true, upgrades the units of a CAAS application
+	// one at a time, waiting for each one to become ready before the
+	// next is upgraded. This field is only understood by Application
+	// facade version 18 and greater.
+	CanaryUpgrade bool `json:"canary-upgrade,omitempty"`
 }
 
 // ApplicationExpose holds the parameters for making the application Expose call.
@@ -446,3 +452,125 @@ type ApplicationInfoResult struct {
 type ApplicationInfoResults struct {
 	Results []ApplicationInfoResult `json:"results"`
 }
+
+// ScalingPolicy holds the number of units an application should have.
+type ScalingPolicy struct {
+	// MinUnits is the minimum number of units the application should have.
+	MinUnits int `json:"min-units"`
+
+	// MaxUnits, if non-zero, is the number of units up to which the
+	// application's lost units are replaced.
+	MaxUnits int `json:"max-units"`
+}
+
+// ApplicationScalingPolicy sets the scaling policy of an application.
+type ApplicationScalingPolicy struct {
+	ApplicationTag string        `json:"application-tag"`
+	Policy         ScalingPolicy `json:"policy"`
+}
+
+// ApplicationScalingPolicies holds the parameters for a
+// SetScalingPolicies API request.
+type ApplicationScalingPolicies struct {
+	Policies []ApplicationScalingPolicy `json:"policies"`
+}
+
+// ScalingPolicyResult holds the scaling policy of an application, or
+// an error retrieving it.
+type ScalingPolicyResult struct {
+	Result *ScalingPolicy `json:"result,omitempty"`
+	Error  *Error         `json:"error,omitempty"`
+}
+
+// ScalingPolicyResults holds the results of a ScalingPolicies API request.
+type ScalingPolicyResults struct {
+	Results []ScalingPolicyResult `json:"results"`
+}
+
+// RelationDetailsArg identifies a relation whose details are requested.
+type RelationDetailsArg struct {
+	// RelationId is the ID of the relation.
+	RelationId int `json:"relation-id"`
+
+	// History is true if the settings history of the relation is
+	// requested along with its details.
+	History bool `json:"history,omitempty"`
+}
+
+// RelationDetailsArgs holds the parameters for a RelationDetails API
+// request.
+type RelationDetailsArgs struct {
+	Args []RelationDetailsArg `json:"args"`
+}
+
+// RelationSettingsChange describes a change made by a unit to one of
+// the data bags of a relation.
+type RelationSettingsChange struct {
+	// Unit is the name of the unit which made the change.
+	Unit string `json:"unit"`
+
+	// Application is true if the change was made to the data bag of
+	// the unit's application, rather than to the unit's own data bag.
+	Application bool `json:"application,omitempty"`
+
+	// Settings holds the keys which were changed, along with their
+	// new values. Keys which were deleted have empty values.
+	Settings map[string]string `json:"settings"`
+
+	// Time is when the change was made.
+	Time time.Time `json:"time"`
+}
+
+// RelationDetails holds the details of a relation.
+type RelationDetails struct {
+	Id        int      `json:"id"`
+	Key       string   `json:"key"`
+	Interface string   `json:"interface"`
+	Scope     string   `json:"scope"`
+	Endpoints []string `json:"endpoints"`
+	Status    string   `json:"status"`
+	Message   string   `json:"message,omitempty"`
+
+	// History holds the recent changes made to the relation's data
+	// bags, most recent first, if requested.
+	History []RelationSettingsChange `json:"history,omitempty"`
+}
+
+// RelationDetailsResult holds the details of a relation, or an error
+// retrieving them.
+type RelationDetailsResult struct {
+	Result *RelationDetails `json:"result,omitempty"`
+	Error  *Error           `json:"error,omitempty"`
+}
+
+// RelationDetailsResults holds the results of a RelationDetails API
+// request.
+type RelationDetailsResults struct {
+	Results []RelationDetailsResult `json:"results"`
+}
+
+// ApplicationDisplayStatus holds the status displayed for an
+// application.
+type ApplicationDisplayStatus struct {
+	Status string                 `json:"status"`
+	Info   string                 `json:"info,omitempty"`
+	Data   map[string]interface{} `json:"data,omitempty"`
+	Since  *time.Time             `json:"since,omitempty"`
+
+	// Derived is true if the application's status has never been
+	// set, so the status was aggregated from those of its units.
+	Derived bool `json:"derived,omitempty"`
+}
+
+// ApplicationDisplayStatusResult holds the status displayed for an
+// application, or an error retrieving it.
+type ApplicationDisplayStatusResult struct {
+	Result *ApplicationDisplayStatus `json:"result,omitempty"`
+	Error  *Error                    `json:"error,omitempty"`
+}
+
+// ApplicationDisplayStatusResults holds the results of a DisplayStatus
+// API request.
+type ApplicationDisplayStatusResults struct {
+	Results []ApplicationDisplayStatusResult `json:"results"`
+}
@@ -0,0 +1,46 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+// AutoscaledApplication describes an application backed by a provider
+// autoscaling group, and the machines added for the group's instances.
+type AutoscaledApplication struct {
+	// ApplicationTag is the tag of the application.
+	ApplicationTag string `json:"application-tag"`
+
+	// Group is the name of the provider autoscaling group.
+	Group string `json:"group"`
+
+	// Machines holds the machines which have been added for instances
+	// in the group, and which host the application's units.
+	Machines []AutoscaledMachine `json:"machines,omitempty"`
+}
+
+// AutoscaledMachine describes a machine added for an instance in a
+// provider autoscaling group.
+type AutoscaledMachine struct {
+	MachineTag string `json:"machine-tag"`
+	InstanceId string `json:"instance-id"`
+}
+
+// AutoscaledApplicationsResult holds the applications backed by
+// provider autoscaling groups, or an error.
+type AutoscaledApplicationsResult struct {
+	Results []AutoscaledApplication `json:"results,omitempty"`
+	Error   *Error                  `json:"error,omitempty"`
+}
+
+// AddAutoscaledMachinesArgs holds the arguments for adding machines
+// for the instances which have appeared in autoscaling groups.
+type AddAutoscaledMachinesArgs struct {
+	Args []AddAutoscaledMachines `json:"args"`
+}
+
+// AddAutoscaledMachines holds the ids of the instances which have
+// appeared in the autoscaling group of an application. A machine,
+// hosting a unit of the application, is added for each one.
+type AddAutoscaledMachines struct {
+	ApplicationTag string   `json:"application-tag"`
+	InstanceIds    []string `json:"instance-ids"`
+}
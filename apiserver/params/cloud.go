@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // Cloud holds information about a cloud.
 type Cloud struct {
 	Type             string                            `json:"type"`
@@ -152,6 +154,10 @@ type CloudCredential struct {
 
 	// Redacted is a list of redacted attributes
 	Redacted []string `json:"redacted,omitempty"`
+
+	// Expiry is the time at which the credential expires,
+	// if it is known.
+	Expiry *time.Time `json:"expiry,omitempty"`
 }
 
 // CloudCredentialResult contains a CloudCredential or an error.
@@ -246,6 +252,10 @@ type CredentialContent struct {
 
 	// Attributes contains credential values.
 	Attributes map[string]string `json:"attrs,omitempty"`
+
+	// Expiry is the time at which the credential expires,
+	// if it is known.
+	Expiry *time.Time `json:"expiry,omitempty"`
 }
 
 // ModelAccess contains information about user model access.
@@ -348,3 +358,61 @@ type RevokeCredentialArgs struct {
 	// Credentials holds credentials to revoke.
 	Credentials []RevokeCredentialArg `json:"credentials"`
 }
+
+// CloudPricing holds the prices of the resources provided by a cloud,
+// used to estimate what models cost to run.
+type CloudPricing struct {
+	Currency         string             `json:"currency"`
+	InstanceTypes    map[string]float64 `json:"instance-types,omitempty"`
+	CPUCoreHour      float64            `json:"cpu-core-hour,omitempty"`
+	MemoryGiBHour    float64            `json:"memory-gib-hour,omitempty"`
+	VolumeGiBMonth   float64            `json:"volume-gib-month,omitempty"`
+	LoadBalancerHour float64            `json:"load-balancer-hour,omitempty"`
+}
+
+// SetCloudPricingArg holds the pricing to set for a cloud.
+type SetCloudPricingArg struct {
+	CloudTag string       `json:"cloud-tag"`
+	Pricing  CloudPricing `json:"pricing"`
+}
+
+// SetCloudPricingArgs holds the pricing to set for a set of clouds.
+type SetCloudPricingArgs struct {
+	Args []SetCloudPricingArg `json:"args"`
+}
+
+// CloudPricingResult holds the pricing of a cloud, or an error.
+type CloudPricingResult struct {
+	Result *CloudPricing `json:"result,omitempty"`
+	Error  *Error        `json:"error,omitempty"`
+}
+
+// CloudPricingResults holds the pricing of a set of clouds.
+type CloudPricingResults struct {
+	Results []CloudPricingResult `json:"results"`
+}
+
+// ProviderCallStats holds the statistics for calls of a single cloud
+// provider method, made by a worker on a controller.
+type ProviderCallStats struct {
+	Cloud  string `json:"cloud"`
+	Region string `json:"region,omitempty"`
+	Caller string `json:"caller"`
+	Method string `json:"method"`
+
+	Calls     int64 `json:"calls"`
+	Errors    int64 `json:"errors"`
+	Throttled int64 `json:"throttled"`
+
+	// TotalDuration and MaxDuration are in nanoseconds.
+	TotalDuration int64 `json:"total-duration"`
+	MaxDuration   int64 `json:"max-duration"`
+
+	LastThrottled *time.Time `json:"last-throttled,omitempty"`
+}
+
+// ProviderCallStatsResult holds the provider call statistics recorded
+// by the controller machine answering the request.
+type ProviderCallStatsResult struct {
+	Stats []ProviderCallStats `json:"stats"`
+}
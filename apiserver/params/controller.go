@@ -3,7 +3,11 @@
 
 package params
 
-import "github.com/juju/juju/core/life"
+import (
+	"time"
+
+	"github.com/juju/juju/core/life"
+)
 
 // DestroyControllerArgs holds the arguments for destroying a controller.
 type DestroyControllerArgs struct {
@@ -100,6 +104,41 @@ type ControllerConfigSet struct {
 	Config map[string]interface{} `json:"config"`
 }
 
+// FeatureFlagChange describes a controller feature flag being enabled
+// or disabled.
+type FeatureFlagChange struct {
+	Flag    string    `json:"flag"`
+	Enabled bool      `json:"enabled"`
+	Who     string    `json:"who,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// FeatureFlagHistoryResult holds the result of
+// Controller.FeatureFlagHistory.
+type FeatureFlagHistoryResult struct {
+	Enabled []string            `json:"enabled"`
+	Changes []FeatureFlagChange `json:"changes"`
+}
+
+// SchemaMigration describes the progress of an online schema
+// migration of the controller's database.
+type SchemaMigration struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Version     int        `json:"version"`
+	Done        bool       `json:"done"`
+	Processed   int        `json:"processed"`
+	Started     *time.Time `json:"started,omitempty"`
+	Completed   *time.Time `json:"completed,omitempty"`
+}
+
+// SchemaStatusResult holds the result of Controller.SchemaStatus.
+type SchemaStatusResult struct {
+	Version       int               `json:"version"`
+	LatestVersion int               `json:"latest-version"`
+	Migrations    []SchemaMigration `json:"migrations"`
+}
+
 // ControllerAction is an action that can be performed on a model.
 type ControllerAction string
 
@@ -115,3 +154,61 @@ type ControllerVersionResults struct {
 	Version   string `json:"version"`
 	GitCommit string `json:"git-commit"`
 }
+
+// AgentBinaryReplicationResult holds the agent binary versions held
+// in the blobstore of a single controller node.
+type AgentBinaryReplicationResult struct {
+	// Node is the ID of the controller machine.
+	Node string `json:"node"`
+
+	// Address is the address of the node's mongo replica set member.
+	Address string `json:"address"`
+
+	// Primary reports whether the node is the replica set primary.
+	Primary bool `json:"primary"`
+
+	// Held holds the agent binary versions that are complete in the
+	// node's blobstore.
+	Held []string `json:"held"`
+
+	// Missing holds the agent binary versions that are absent from,
+	// or incomplete in, the node's blobstore.
+	Missing []string `json:"missing"`
+
+	// Error holds any error checking the node's blobstore.
+	Error *Error `json:"error,omitempty"`
+}
+
+// AgentBinaryReplicationResults holds the result of the
+// AgentBinaries.ReplicationStatus API call.
+type AgentBinaryReplicationResults struct {
+	// Versions holds all agent binary versions in the catalogue.
+	Versions []string `json:"versions"`
+
+	// Results holds the agent binaries held by each controller node.
+	Results []AgentBinaryReplicationResult `json:"results"`
+}
+
+// AgentBinaryRepairResult holds the result of re-replicating an agent
+// binary version to the controller nodes missing it.
+type AgentBinaryRepairResult struct {
+	// Version is the agent binary version.
+	Version string `json:"version"`
+
+	// Nodes holds the IDs of the controller nodes that were missing
+	// the agent binary.
+	Nodes []string `json:"nodes"`
+
+	// Source is the ID of the controller node the agent binary was
+	// copied from.
+	Source string `json:"source,omitempty"`
+
+	// Error holds any error re-replicating the agent binary.
+	Error *Error `json:"error,omitempty"`
+}
+
+// AgentBinaryRepairResults holds the result of the
+// AgentBinaries.Repair API call.
+type AgentBinaryRepairResults struct {
+	Results []AgentBinaryRepairResult `json:"results"`
+}
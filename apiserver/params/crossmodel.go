@@ -4,6 +4,8 @@
 package params
 
 import (
+	"time"
+
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/macaroon.v2-unstable"
 
@@ -633,3 +635,49 @@ type ExternalControllerInfo struct {
 	Addrs         []string `json:"addrs"`
 	CACert        string   `json:"ca-cert"`
 }
+
+// OfferConnectionProbe describes a connection to an offer hosted in
+// the model, as seen from the offering side.
+type OfferConnectionProbe struct {
+	RelationTag    string `json:"relation-tag"`
+	OfferUUID      string `json:"offer-uuid"`
+	SourceModelTag string `json:"source-model-tag"`
+	Username       string `json:"username"`
+
+	// LastContact is when the consuming model last made an
+	// authenticated request for the relation.
+	LastContact *time.Time `json:"last-contact,omitempty"`
+
+	// LastSuccess is when the connection was last found to be
+	// healthy.
+	LastSuccess *time.Time `json:"last-success,omitempty"`
+
+	// Error, if set, describes why the consuming model's macaroons
+	// for the relation can no longer be discharged.
+	Error *Error `json:"error,omitempty"`
+}
+
+// OfferConnectionProbesResult holds the result of an
+// OfferConnections API request.
+type OfferConnectionProbesResult struct {
+	Connections []OfferConnectionProbe `json:"connections"`
+	Error       *Error                 `json:"error,omitempty"`
+}
+
+// OfferConnectionHealth records the health of a connection to an
+// offer hosted in the model.
+type OfferConnectionHealth struct {
+	RelationTag string `json:"relation-tag"`
+
+	// Message, if set, describes why the connection is unhealthy.
+	Message string `json:"message,omitempty"`
+
+	// Time is when the connection was probed.
+	Time time.Time `json:"time"`
+}
+
+// OfferConnectionHealthArgs holds the arguments to a
+// SetOfferConnectionsHealth API request.
+type OfferConnectionHealthArgs struct {
+	Connections []OfferConnectionHealth `json:"connections"`
+}
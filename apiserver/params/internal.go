@@ -144,6 +144,39 @@ type ModelCreateArgs struct {
 	// and the owner is the controller owner, the same credential
 	// used for the controller model will be used.
 	CloudCredentialTag string `json:"credential,omitempty"`
+
+	// Template is the name of a model template whose settings are
+	// applied to the new model. Config and a credential given here
+	// take precedence over those in the template.
+	Template string `json:"template,omitempty"`
+}
+
+// ModelTemplate holds the settings applied to models created from a
+// template.
+type ModelTemplate struct {
+	Name               string                 `json:"name"`
+	Config             map[string]interface{} `json:"config,omitempty"`
+	Constraints        constraints.Value      `json:"constraints"`
+	DefaultSpace       string                 `json:"default-space,omitempty"`
+	CloudCredentialTag string                 `json:"credential,omitempty"`
+	CreatedBy          string                 `json:"created-by,omitempty"`
+	Created            time.Time              `json:"created,omitempty"`
+}
+
+// ModelTemplateArgs holds the model templates to add to the
+// controller.
+type ModelTemplateArgs struct {
+	Templates []ModelTemplate `json:"templates"`
+}
+
+// ModelTemplateNames holds the names of model templates.
+type ModelTemplateNames struct {
+	Names []string `json:"names"`
+}
+
+// ModelTemplatesResult holds the model templates in the controller.
+type ModelTemplatesResult struct {
+	Templates []ModelTemplate `json:"templates"`
 }
 
 // Model holds the result of an API call returning a name and UUID
@@ -432,6 +465,33 @@ type InstancesInfo struct {
 	Machines []InstanceInfo `json:"machines"`
 }
 
+// MachineHardwareCharacteristics holds the hardware characteristics
+// reported by the provider for a machine's instance.
+type MachineHardwareCharacteristics struct {
+	Tag             string                           `json:"tag"`
+	Characteristics instance.HardwareCharacteristics `json:"characteristics"`
+}
+
+// SetMachinesHardwareCharacteristics holds the parameters for making
+// a SetHardwareCharacteristics call for multiple machines.
+type SetMachinesHardwareCharacteristics struct {
+	Machines []MachineHardwareCharacteristics `json:"machines"`
+}
+
+// MachineProvisioningPhase records when a machine reached a phase of
+// being brought up.
+type MachineProvisioningPhase struct {
+	Tag   string    `json:"tag"`
+	Phase string    `json:"phase"`
+	Time  time.Time `json:"time"`
+}
+
+// MachineProvisioningPhases holds the parameters for making a
+// RecordProvisioningPhases call.
+type MachineProvisioningPhases struct {
+	Phases []MachineProvisioningPhase `json:"phases"`
+}
+
 // EntityStatus holds the status of an entity.
 type EntityStatus struct {
 	Status status.Status          `json:"status"`
@@ -446,6 +506,10 @@ type EntityStatusArgs struct {
 	Status string                 `json:"status"`
 	Info   string                 `json:"info"`
 	Data   map[string]interface{} `json:"data"`
+
+	// Since, if set, is when the status was set by the caller. The
+	// time it arrives at the controller is recorded otherwise.
+	Since *time.Time `json:"since,omitempty"`
 }
 
 // SetStatus holds the parameters for making a SetStatus/UpdateStatus call.
@@ -453,6 +517,38 @@ type SetStatus struct {
 	Entities []EntityStatusArgs `json:"entities"`
 }
 
+// BulkSetStatusArgs holds the parameters for making a BulkSetStatus
+// call.
+type BulkSetStatusArgs struct {
+	Args []BulkSetStatusArg `json:"args"`
+}
+
+// BulkSetStatusArg holds the statuses a unit sets, as the leader of its
+// application, for the application and its units.
+type BulkSetStatusArg struct {
+	// Unit is the tag of the leader unit setting the statuses.
+	Unit string `json:"unit"`
+
+	// Entities holds the statuses to set, each of them for either the
+	// unit's application or one of its units.
+	Entities []EntityStatusArgs `json:"entities"`
+}
+
+// BulkSetStatusResult holds the result of a BulkSetStatusArg.
+type BulkSetStatusResult struct {
+	// Error is set if none of the statuses could be set, such as when
+	// the unit is not the leader of its application.
+	Error *Error `json:"error,omitempty"`
+
+	// Results holds the error, if any, from setting each status.
+	Results []ErrorResult `json:"results,omitempty"`
+}
+
+// BulkSetStatusResults holds the results of a BulkSetStatus call.
+type BulkSetStatusResults struct {
+	Results []BulkSetStatusResult `json:"results"`
+}
+
 // ConstraintsResult holds machine constraints or an error.
 type ConstraintsResult struct {
 	Error       *Error            `json:"error,omitempty"`
@@ -479,6 +575,47 @@ type AgentGetEntitiesResult struct {
 	Error         *Error                 `json:"error,omitempty"`
 }
 
+// AgentConfigOverridesResult holds the agent config values overridden
+// for a machine agent, and the version of them it has applied.
+type AgentConfigOverridesResult struct {
+	Values         map[string]string `json:"values,omitempty"`
+	Version        int64             `json:"version"`
+	AppliedVersion int64             `json:"applied-version"`
+	Error          *Error            `json:"error,omitempty"`
+}
+
+// AgentConfigOverridesResults holds the results of an
+// AgentConfigOverrides call.
+type AgentConfigOverridesResults struct {
+	Results []AgentConfigOverridesResult `json:"results"`
+}
+
+// SetAgentConfigOverridesArg holds the agent config values to override
+// for a machine agent. An empty value removes an override.
+type SetAgentConfigOverridesArg struct {
+	Tag    string            `json:"tag"`
+	Values map[string]string `json:"values"`
+}
+
+// SetAgentConfigOverridesArgs holds the arguments of a
+// SetAgentConfigOverrides call.
+type SetAgentConfigOverridesArgs struct {
+	Args []SetAgentConfigOverridesArg `json:"args"`
+}
+
+// AgentConfigOverridesApplied records the version of its agent config
+// overrides which a machine agent has applied.
+type AgentConfigOverridesApplied struct {
+	Tag     string `json:"tag"`
+	Version int64  `json:"version"`
+}
+
+// AgentConfigOverridesAppliedArgs holds the arguments of a
+// SetAgentConfigOverridesApplied call.
+type AgentConfigOverridesAppliedArgs struct {
+	Args []AgentConfigOverridesApplied `json:"args"`
+}
+
 // VersionResult holds the version and possibly error for a given
 // DesiredVersion() API call.
 type VersionResult struct {
@@ -679,6 +816,18 @@ type CharmsResponse struct {
 
 	CharmURL string   `json:"charm-url,omitempty"`
 	Files    []string `json:"files,omitempty"`
+
+	// LintFindings holds the problems found in an uploaded charm by
+	// static analysis which didn't stop it being accepted.
+	LintFindings []CharmLintFinding `json:"lint-findings,omitempty"`
+}
+
+// CharmLintFinding describes a problem found in a charm by static
+// analysis.
+type CharmLintFinding struct {
+	Severity string `json:"severity"`
+	Path     string `json:"path,omitempty"`
+	Message  string `json:"message"`
 }
 
 // RunParams is used to provide the parameters to the Run method.
@@ -863,6 +1012,11 @@ type UnitRefreshResult struct {
 	Resolved   ResolvedMode
 	Error      *Error
 	ProviderID string `json:"provider-id,omitempty"`
+
+	// Maintenance reports whether an operator has put the unit
+	// into maintenance, and MaintenanceMessage why.
+	Maintenance        bool   `json:"maintenance,omitempty"`
+	MaintenanceMessage string `json:"maintenance-message,omitempty"`
 }
 
 // UnitRefreshResults holds the results for any API call which ends
@@ -914,3 +1068,164 @@ type ContainerTypeResult struct {
 	Type  instance.ContainerType `json:"container-type"`
 	Error *Error                 `json:"error"`
 }
+
+// MissingUnitAgent describes a unit whose agent is not communicating
+// with the controller, or which has been marked as gone.
+type MissingUnitAgent struct {
+	UnitTag string `json:"unit-tag"`
+
+	// Gone reports whether the unit has been marked as having a gone
+	// agent.
+	Gone bool `json:"gone"`
+
+	// Alive reports whether the agent is communicating with the
+	// controller again.
+	Alive bool `json:"alive"`
+}
+
+// MissingUnitAgentsResult holds the result of a MissingUnitAgents API
+// request.
+type MissingUnitAgentsResult struct {
+	Agents []MissingUnitAgent `json:"agents"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// StatusAlertEntity holds the current status of an entity in a model,
+// as matched against the model's status alert rules.
+type StatusAlertEntity struct {
+	// Kind is the kind of the entity: unit, machine or application.
+	Kind string `json:"kind"`
+
+	// Name is the name of the entity, eg "mysql/0".
+	Name string `json:"name"`
+
+	// Application is the application a unit belongs to, or the name
+	// of an application.
+	Application string `json:"application,omitempty"`
+
+	// Statuses holds the statuses of the entity; for units, the agent
+	// and workload status.
+	Statuses []EntityStatus `json:"statuses"`
+}
+
+// StatusAlertEntitiesResult holds the result of a StatusAlertEntities
+// API request.
+type StatusAlertEntitiesResult struct {
+	Entities []StatusAlertEntity `json:"entities"`
+	Error    *Error              `json:"error,omitempty"`
+}
+
+// HealthProbe describes a health probe which a machine agent runs for
+// a unit. Exactly one of TCP, HTTP and Command is set.
+type HealthProbe struct {
+	Name     string        `json:"name"`
+	TCP      string        `json:"tcp,omitempty"`
+	HTTP     string        `json:"http,omitempty"`
+	Command  string        `json:"command,omitempty"`
+	Interval time.Duration `json:"interval"`
+	Timeout  time.Duration `json:"timeout"`
+}
+
+// UnitHealthProbes holds the health probes to run for a unit.
+type UnitHealthProbes struct {
+	Unit   string        `json:"unit"`
+	Probes []HealthProbe `json:"probes"`
+}
+
+// HealthProbesResult holds the health probes to run for the units on
+// a machine, or an error.
+type HealthProbesResult struct {
+	Units []UnitHealthProbes `json:"units,omitempty"`
+	Error *Error             `json:"error,omitempty"`
+}
+
+// HealthProbesResults holds the results of a HealthProbes API request.
+type HealthProbesResults struct {
+	Results []HealthProbesResult `json:"results"`
+}
+
+// HealthProbeResult holds the outcome of the latest run of a health
+// probe, and when the probe started succeeding or failing.
+type HealthProbeResult struct {
+	Name    string    `json:"name"`
+	Healthy bool      `json:"healthy"`
+	Message string    `json:"message,omitempty"`
+	Since   time.Time `json:"since"`
+}
+
+// UnitHealthProbeResults holds the results of the health probes run
+// for a unit.
+type UnitHealthProbeResults struct {
+	Unit    string              `json:"unit"`
+	Results []HealthProbeResult `json:"results"`
+}
+
+// SetHealthProbeResults holds the results of all the health probes a
+// machine agent runs.
+type SetHealthProbeResults struct {
+	Machine string                   `json:"machine"`
+	Units   []UnitHealthProbeResults `json:"units"`
+}
+
+// SetHealthProbeResultsArgs holds the arguments of a
+// SetHealthProbeResults API request.
+type SetHealthProbeResultsArgs struct {
+	Args []SetHealthProbeResults `json:"args"`
+}
+
+// SecurityUpdatesReport holds a machine's report of the package
+// updates pending on it, and whether it needs rebooting.
+type SecurityUpdatesReport struct {
+	Pending        int       `json:"pending"`
+	Security       int       `json:"security"`
+	RebootRequired bool      `json:"reboot-required"`
+	RebootPackages []string  `json:"reboot-packages,omitempty"`
+	Checked        time.Time `json:"checked"`
+}
+
+// SetSecurityUpdates holds the latest security updates report of a
+// machine. A nil Report removes the machine's report.
+type SetSecurityUpdates struct {
+	Tag    string                 `json:"tag"`
+	Report *SecurityUpdatesReport `json:"report,omitempty"`
+}
+
+// SetSecurityUpdatesArgs holds the arguments of a SetSecurityUpdates
+// API request.
+type SetSecurityUpdatesArgs struct {
+	Args []SetSecurityUpdates `json:"args"`
+}
+
+// WorkerFailure describes a worker run by an agent failing.
+type WorkerFailure struct {
+	Worker string    `json:"worker"`
+	Error  string    `json:"error"`
+	Time   time.Time `json:"time"`
+}
+
+// AddWorkerFailures holds worker failures to add to the history of the
+// agent with the given tag.
+type AddWorkerFailures struct {
+	Tag      string          `json:"tag"`
+	Failures []WorkerFailure `json:"failures"`
+}
+
+// AddWorkerFailuresArgs holds the arguments of an AddWorkerFailures
+// API request.
+type AddWorkerFailuresArgs struct {
+	Args []AddWorkerFailures `json:"args"`
+}
+
+// ModelBundleResult holds the result of a DesiredBundle API request.
+type ModelBundleResult struct {
+	Result *ModelBundle `json:"result,omitempty"`
+	Error  *Error       `json:"error,omitempty"`
+}
+
+// BundleDrift holds the drift of a model from its bundle, as reported
+// by the bundle reconciler. An empty message reports that the model
+// matches its bundle.
+type BundleDrift struct {
+	Message string                 `json:"message"`
+	Data    map[string]interface{} `json:"data,omitempty"`
+}
@@ -25,6 +25,23 @@ type KubernetesProvisioningInfo struct {
 	Volumes           []KubernetesVolumeParams     `json:"volumes,omitempty"`
 	Devices           []KubernetesDeviceParams     `json:"devices,omitempty"`
 	OperatorImagePath string                       `json:"operator-image-path,omitempty"`
+	CanaryUpgrade     bool                         `json:"canary-upgrade,omitempty"`
+
+	// RegistryCredentials are the model's unexpired credentials for
+	// the registries the application's images may be pulled from.
+	RegistryCredentials []RegistryCredential `json:"registry-credentials,omitempty"`
+
+	// NetworkPolicy determines the traffic allowed to reach the
+	// application's pods.
+	NetworkPolicy *KubernetesNetworkPolicy `json:"network-policy,omitempty"`
+}
+
+// KubernetesNetworkPolicy holds the traffic allowed to reach an
+// application's pods.
+type KubernetesNetworkPolicy struct {
+	Isolated            bool     `json:"isolated"`
+	RelatedApplications []string `json:"related-applications,omitempty"`
+	Exposed             bool     `json:"exposed"`
 }
 
 // KubernetesProvisioningInfoResult holds unit provisioning info or an error.
@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // ClaimLeadershipBulkParams is a collection of parameters for making
 // a bulk leadership claim.
 type ClaimLeadershipBulkParams struct {
@@ -94,3 +96,62 @@ type PinnedLeadershipResult struct {
 	//   behaviour for each application.
 	Result map[string][]string `json:"result,omitempty"`
 }
+
+// LeaseDetails describes a single lease, for diagnostic purposes.
+type LeaseDetails struct {
+	// Namespace is the kind of lease; application-leadership or
+	// singular-controller.
+	Namespace string `json:"namespace"`
+
+	// Lease is the name of the lease.
+	Lease string `json:"lease"`
+
+	// Holder is the name of the current leaseholder.
+	Holder string `json:"holder"`
+
+	// Expiry is the latest time at which the lease might still be valid.
+	Expiry time.Time `json:"expiry"`
+
+	// PinnedBy holds the tags of the entities preventing the lease
+	// from expiring.
+	PinnedBy []string `json:"pinned-by,omitempty"`
+}
+
+// LeaseDetailsResult holds the details of the leases for a model.
+type LeaseDetailsResult struct {
+	Leases []LeaseDetails `json:"leases"`
+}
+
+// RevokeLeaseArgs holds the leases to be forcibly vacated.
+type RevokeLeaseArgs struct {
+	Args []RevokeLeaseArg `json:"args"`
+}
+
+// RevokeLeaseArg identifies a single lease to be forcibly vacated.
+type RevokeLeaseArg struct {
+	// Namespace is the kind of lease; application-leadership or
+	// singular-controller.
+	Namespace string `json:"namespace"`
+
+	// Lease is the name of the lease.
+	Lease string `json:"lease"`
+}
+
+// PinLeaderArgs holds the applications whose leaders are to be pinned.
+type PinLeaderArgs struct {
+	Args []PinLeaderArg `json:"args"`
+}
+
+// PinLeaderArg identifies an application whose leader is to be pinned
+// by an operator, so that it doesn't change during maintenance.
+type PinLeaderArg struct {
+	// ApplicationTag is the application whose leader is pinned.
+	ApplicationTag string `json:"application-tag"`
+
+	// Duration is how long the leader is pinned for.
+	Duration time.Duration `json:"duration"`
+
+	// Reason is why the leader is pinned. It is recorded in the
+	// application's status data.
+	Reason string `json:"reason,omitempty"`
+}
@@ -133,6 +133,7 @@ type MigrationModelInfo struct {
 	OwnerTag               string         `json:"owner-tag"`
 	AgentVersion           version.Number `json:"agent-version"`
 	ControllerAgentVersion version.Number `json:"controller-agent-version"`
+	FormatVersion          int            `json:"format-version,omitempty"`
 }
 
 // MigrationStatus reports the current status of a model migration.
@@ -24,6 +24,32 @@ type ModelConfigResults struct {
 	Config map[string]ConfigValue `json:"config"`
 }
 
+const (
+	// ModelConfigScope is the scope of model config keys.
+	ModelConfigScope = "model"
+
+	// ControllerConfigScope is the scope of controller config keys.
+	ControllerConfigScope = "controller"
+)
+
+// ConfigKey describes a single model or controller configuration
+// attribute known to the controller.
+type ConfigKey struct {
+	Name        string      `json:"name"`
+	Scope       string      `json:"scope"`
+	Type        string      `json:"type"`
+	Description string      `json:"description"`
+	Default     interface{} `json:"default,omitempty"`
+	Mutable     bool        `json:"mutable"`
+	Secret      bool        `json:"secret,omitempty"`
+}
+
+// ConfigKeysResult contains the result of client API calls
+// to get the known configuration keys.
+type ConfigKeysResult struct {
+	Keys []ConfigKey `json:"keys"`
+}
+
 // HostedModelConfig contains the model config and the cloud spec
 // for the model, both things that a client needs to talk directly
 // with the provider. This is used to take down mis-behaving models
@@ -335,6 +361,29 @@ type ModelFilesystemInfo struct {
 	Detachable bool   `json:"detachable,omitempty"`
 }
 
+// AbandonedResource holds information about a cloud resource which was
+// removed from a model without the provider confirming its release.
+type AbandonedResource struct {
+	Kind       string    `json:"kind"`
+	Id         string    `json:"id"`
+	ProviderId string    `json:"provider-id,omitempty"`
+	Reason     string    `json:"reason"`
+	Time       time.Time `json:"time"`
+}
+
+// AbandonedResourcesResult holds the abandoned cloud resources of a
+// model, or an error.
+type AbandonedResourcesResult struct {
+	Resources []AbandonedResource `json:"resources,omitempty"`
+	Error     *Error              `json:"error,omitempty"`
+}
+
+// AbandonedResourcesResults holds the abandoned cloud resources of
+// a group of models.
+type AbandonedResourcesResults struct {
+	Results []AbandonedResourcesResult `json:"results"`
+}
+
 // ModelUserInfo holds information on a user who has access to a
 // model. Owners of a model can see this information for all users
 // who have access, so it should not include sensitive information.
@@ -450,3 +499,251 @@ type ChangeModelCredentialParams struct {
 type ChangeModelCredentialsParams struct {
 	Models []ChangeModelCredentialParams `json:"model-credentials"`
 }
+
+// OperationsLogArgs holds the arguments for querying the changes
+// recorded in a model's operations log.
+type OperationsLogArgs struct {
+	// Since limits the results to changes made at or after the time.
+	Since time.Time `json:"since"`
+
+	// Kinds, if not empty, limits the results to changes of the given
+	// kinds: action, upgrade, migration or branch-commit.
+	Kinds []string `json:"kinds,omitempty"`
+}
+
+// OperationsLogEntry describes a change recorded in a model's
+// operations log.
+type OperationsLogEntry struct {
+	Id      string    `json:"id"`
+	Kind    string    `json:"kind"`
+	Summary string    `json:"summary"`
+	Who     string    `json:"who,omitempty"`
+	Ref     string    `json:"ref,omitempty"`
+	Time    time.Time `json:"time"`
+}
+
+// OperationsLogResult holds the changes recorded in a model's
+// operations log, in the order they were made.
+type OperationsLogResult struct {
+	Operations []OperationsLogEntry `json:"operations"`
+}
+
+// ArchiveModelArgs holds the arguments for archiving a model.
+type ArchiveModelArgs struct {
+	// MaxWait is how long to wait for the model's units to go before
+	// their machines are forcibly removed.
+	MaxWait *time.Duration `json:"max-wait,omitempty"`
+}
+
+// ModelArchiveResult describes the export taken when a model was
+// archived.
+type ModelArchiveResult struct {
+	Size      int64     `json:"size"`
+	SHA256    string    `json:"sha256"`
+	CreatedBy string    `json:"created-by"`
+	Created   time.Time `json:"created"`
+}
+
+// ThawModelResult holds the number of units restored to each
+// application when a model was thawed.
+type ThawModelResult struct {
+	Applications map[string]int `json:"applications"`
+}
+
+// ModelCostItem is the estimated cost of a single resource used by a
+// model.
+type ModelCostItem struct {
+	Kind   string  `json:"kind"`
+	Id     string  `json:"id"`
+	Detail string  `json:"detail,omitempty"`
+	Hourly float64 `json:"hourly"`
+}
+
+// ModelCostEstimate is the estimated cost of running a model's
+// resources, using the pricing set for the model's cloud.
+type ModelCostEstimate struct {
+	Currency string          `json:"currency"`
+	Items    []ModelCostItem `json:"items"`
+	Hourly   float64         `json:"hourly"`
+	Monthly  float64         `json:"monthly"`
+}
+
+// Dashboard summary fields which may be requested in addition to the
+// model details.
+const (
+	DashboardApplications = "applications"
+	DashboardUnits        = "units"
+)
+
+// DashboardSummaryArgs holds the arguments for fetching a page of
+// model summaries for the dashboard.
+type DashboardSummaryArgs struct {
+	// All includes every model in the controller, rather than just
+	// those the user has access to, if the user is a controller admin.
+	All bool `json:"all,omitempty"`
+
+	// ModelFilter, if set, is a glob pattern matched against the model
+	// name, or against "<owner>/<name>" if it contains a "/".
+	ModelFilter string `json:"model-filter,omitempty"`
+
+	// ModelStatus, if set, only includes models with one of these
+	// statuses.
+	ModelStatus []string `json:"model-status,omitempty"`
+
+	// ApplicationFilter, if set, is a glob pattern matched against the
+	// names of the applications included in each model.
+	ApplicationFilter string `json:"application-filter,omitempty"`
+
+	// Status, if set, only includes applications and units with one
+	// of these statuses.
+	Status []string `json:"status,omitempty"`
+
+	// Fields names the details to include in addition to those of the
+	// models: "applications", and "units", which implies
+	// "applications".
+	Fields []string `json:"fields,omitempty"`
+
+	// Offset is the number of matching models to skip.
+	Offset int `json:"offset,omitempty"`
+
+	// Limit is the largest number of models to return. If zero, a
+	// default is used.
+	Limit int `json:"limit,omitempty"`
+}
+
+// DashboardSummaryResult holds a page of model summaries for the
+// dashboard.
+type DashboardSummaryResult struct {
+	Models []DashboardModelSummary `json:"models"`
+
+	// Total is the number of models matching the model filters,
+	// across all pages.
+	Total int `json:"total"`
+}
+
+// DashboardModelSummary summarises a model for the dashboard.
+type DashboardModelSummary struct {
+	UUID         string               `json:"uuid"`
+	Name         string               `json:"name"`
+	OwnerTag     string               `json:"owner-tag"`
+	Type         string               `json:"type"`
+	CloudTag     string               `json:"cloud-tag"`
+	CloudRegion  string               `json:"region,omitempty"`
+	Life         life.Value           `json:"life"`
+	Status       EntityStatus         `json:"status"`
+	UserAccess   UserAccessPermission `json:"user-access,omitempty"`
+	MachineCount int64                `json:"machine-count"`
+	UnitCount    int64                `json:"unit-count"`
+
+	// Applications is only set when requested in the Fields.
+	Applications []DashboardApplicationSummary `json:"applications,omitempty"`
+
+	// Error is set if the applications in the model could not be read.
+	Error *Error `json:"error,omitempty"`
+}
+
+// DashboardApplicationSummary summarises an application for the
+// dashboard.
+type DashboardApplicationSummary struct {
+	Name      string       `json:"name"`
+	Charm     string       `json:"charm"`
+	Exposed   bool         `json:"exposed"`
+	Status    EntityStatus `json:"status"`
+	UnitCount int          `json:"unit-count"`
+
+	// Units is only set when requested in the Fields.
+	Units []DashboardUnitSummary `json:"units,omitempty"`
+}
+
+// DashboardUnitSummary summarises a unit for the dashboard.
+type DashboardUnitSummary struct {
+	Name           string       `json:"name"`
+	Machine        string       `json:"machine,omitempty"`
+	WorkloadStatus EntityStatus `json:"workload-status"`
+	AgentStatus    EntityStatus `json:"agent-status"`
+}
+
+// FindAnnotationsArgs holds the arguments for finding entities by
+// their annotations across the models in the controller.
+type FindAnnotationsArgs struct {
+	// Annotations holds the annotations the entities must all have.
+	Annotations map[string]string `json:"annotations"`
+
+	// Kinds, if set, only includes entities with tags of these kinds,
+	// such as "model" or "application".
+	Kinds []string `json:"kinds,omitempty"`
+}
+
+// AnnotatedEntity describes an entity found by its annotations.
+type AnnotatedEntity struct {
+	ModelTag      string            `json:"model-tag"`
+	ModelName     string            `json:"model-name"`
+	ModelOwnerTag string            `json:"model-owner-tag"`
+	EntityTag     string            `json:"entity-tag"`
+	Annotations   map[string]string `json:"annotations"`
+}
+
+// AnnotatedEntities holds the entities found by their annotations.
+type AnnotatedEntities struct {
+	Entities []AnnotatedEntity `json:"entities"`
+}
+
+// FindUnitsArgs holds the arguments for finding units across the
+// models in the controller.
+type FindUnitsArgs struct {
+	// Pattern is a glob matched against unit names, such as
+	// "mysql/*"; a pattern without a "/" is matched against the
+	// application name.
+	Pattern string `json:"pattern"`
+}
+
+// FindMachinesArgs holds the arguments for finding machines across
+// the models in the controller.
+type FindMachinesArgs struct {
+	// Query is an IP address, hostname or instance ID, which must
+	// match one of a machine's addresses or its instance ID exactly.
+	Query string `json:"query"`
+}
+
+// FoundEntity describes a unit or machine found in one of the models
+// in the controller.
+type FoundEntity struct {
+	ModelTag      string       `json:"model-tag"`
+	ModelName     string       `json:"model-name"`
+	ModelOwnerTag string       `json:"model-owner-tag"`
+	EntityTag     string       `json:"entity-tag"`
+	Status        EntityStatus `json:"status"`
+	Addresses     []string     `json:"addresses,omitempty"`
+
+	// Machine holds the ID of the machine a unit is assigned to.
+	Machine string `json:"machine,omitempty"`
+
+	// InstanceId holds the instance ID of a provisioned machine.
+	InstanceId string `json:"instance-id,omitempty"`
+}
+
+// FoundEntities holds the units or machines found across the models in
+// the controller.
+type FoundEntities struct {
+	Entities []FoundEntity `json:"entities"`
+}
+
+// ModelInconsistency describes a document in a model which refers to
+// another that doesn't exist, or which disagrees with the documents it
+// refers to.
+type ModelInconsistency struct {
+	// Collection and Id identify the document with the problem.
+	Collection string `json:"collection"`
+	Id         string `json:"id"`
+
+	Problem string `json:"problem"`
+
+	// Repair suggests how to fix the problem.
+	Repair string `json:"repair"`
+}
+
+// VerifyModelResult holds the inconsistencies found by verifying a
+// model.
+type VerifyModelResult struct {
+	Inconsistencies []ModelInconsistency `json:"inconsistencies"`
+}
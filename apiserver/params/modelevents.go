@@ -0,0 +1,46 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// ModelEventsConfig holds the information necessary to open a streaming
+// connection to the API endpoint for reading a model's lifecycle events.
+//
+// The field tags relate to the following 2 libraries:
+//   github.com/google/go-querystring/query (encoding)
+//   github.com/gorilla/schema (decoding)
+type ModelEventsConfig struct {
+	// From is the sequence number of the first event to stream. A
+	// consumer resumes the stream by passing one more than the
+	// sequence number of the last event it handled.
+	From int `schema:"from" url:"from,omitempty"`
+
+	// NoTail, if true, closes the stream once the events recorded so
+	// far have been sent, rather than waiting for new ones.
+	NoTail bool `schema:"notail" url:"notail,omitempty"`
+}
+
+// ModelEvent describes a single lifecycle event being streamed from a
+// model.
+type ModelEvent struct {
+	// Seq is the sequence number of the event, which is used as the
+	// cursor for resuming the stream.
+	Seq int `json:"seq"`
+
+	// Kind is one of unit-added, unit-removed, status-changed,
+	// relation-joined or upgrade-completed.
+	Kind string `json:"kind"`
+
+	// Entity is the tag of the entity the event is about.
+	Entity string `json:"entity"`
+
+	// Data holds the details of the event.
+	Data map[string]string `json:"data,omitempty"`
+
+	// Time is when the event was recorded.
+	Time time.Time `json:"time"`
+}
@@ -97,6 +97,10 @@ func (d *Delta) UnmarshalJSON(data []byte) error {
 		d.Entity = new(UnitInfo)
 	case "relation":
 		d.Entity = new(RelationInfo)
+	case "applicationOffer":
+		d.Entity = new(ApplicationOfferInfo)
+	case "storageInstance":
+		d.Entity = new(StorageInstanceInfo)
 	case "annotation":
 		d.Entity = new(AnnotationInfo)
 	case "block":
@@ -183,6 +187,11 @@ type ApplicationInfo struct {
 	Subordinate     bool                   `json:"subordinate"`
 	Status          StatusInfo             `json:"status"`
 	WorkloadVersion string                 `json:"workload-version"`
+
+	// StatusExplicitlySet records whether the application status has
+	// been set since the application was created. Until it has, the
+	// status displayed is derived from those of the units.
+	StatusExplicitlySet bool `json:"status-explicitly-set,omitempty"`
 }
 
 // EntityId returns a unique identifier for an application across
@@ -362,6 +371,30 @@ func (i *RelationInfo) EntityId() EntityId {
 	}
 }
 
+// StorageInstanceInfo holds the information about a storage instance
+// that is tracked by multiwatcherStore.
+type StorageInstanceInfo struct {
+	ModelUUID       string     `json:"model-uuid"`
+	Id              string     `json:"id"`
+	Kind            string     `json:"kind"`
+	Life            life.Value `json:"life"`
+	Owner           string     `json:"owner,omitempty"`
+	StorageName     string     `json:"storage-name"`
+	Pool            string     `json:"pool"`
+	Size            uint64     `json:"size"`
+	AttachmentCount int        `json:"attachment-count"`
+}
+
+// EntityId returns a unique identifier for a storage instance across
+// models.
+func (i *StorageInstanceInfo) EntityId() EntityId {
+	return EntityId{
+		Kind:      "storageInstance",
+		ModelUUID: i.ModelUUID,
+		Id:        i.Id,
+	}
+}
+
 // AnnotationInfo holds the information about an annotation that is
 // tracked by multiwatcherStore.
 type AnnotationInfo struct {
@@ -649,6 +649,35 @@ type ZoneResults struct {
 	Results []ZoneResult `json:"results"`
 }
 
+// ZoneReport describes how the units of a model's applications are
+// spread across the model's availability zones.
+type ZoneReport struct {
+	Zones        []ZoneResult            `json:"zones"`
+	Applications []ApplicationZoneSpread `json:"applications"`
+}
+
+// ApplicationZoneSpread holds the zones hosting the units of an
+// application, along with the unit moves that would restore an
+// even spread of units across the available zones.
+type ApplicationZoneSpread struct {
+	Application string `json:"application"`
+
+	// Units maps zone names to the names of the
+	// application's units in that zone.
+	Units map[string][]string `json:"units"`
+
+	Balanced bool           `json:"balanced"`
+	Moves    []UnitZoneMove `json:"moves,omitempty"`
+}
+
+// UnitZoneMove suggests replacing a unit in one availability zone
+// with a new unit in another.
+type UnitZoneMove struct {
+	Unit string `json:"unit"`
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
 // SpaceResult holds a single space tag or an error.
 type SpaceResult struct {
 	Error *Error `json:"error,omitempty"`
@@ -789,9 +818,18 @@ type ProxyConfigResult struct {
 	SnapStoreProxyId         string      `json:"snap-store-id,omitempty"`
 	SnapStoreProxyAssertions string      `json:"snap-store-assertions,omitempty"`
 	SnapStoreProxyURL        string      `json:"snap-store-proxy-url,omitempty"`
+	AptSources               []AptSource `json:"apt-sources,omitempty"`
+	ContainerRuntimeProxy    bool        `json:"container-runtime-proxy,omitempty"`
 	Error                    *Error      `json:"error,omitempty"`
 }
 
+// AptSource describes an additional apt repository, a PPA or a line in
+// sources.list format with an optional signing key, for machines to use.
+type AptSource struct {
+	Source string `json:"source"`
+	Key    string `json:"key,omitempty"`
+}
+
 // ProxyConfigResults contains information needed to configure multiple clients proxy settings
 type ProxyConfigResults struct {
 	Results []ProxyConfigResult `json:"results"`
@@ -869,6 +907,35 @@ type NetworkInfoParams struct {
 	Endpoints []string `json:"bindings"`
 }
 
+// RelationNetworkInfoParams holds the name of a unit and the endpoints
+// whose relations' network information is wanted. If there are no
+// endpoints, that of all the unit's relations is wanted.
+type RelationNetworkInfoParams struct {
+	Unit      string   `json:"unit"`
+	Endpoints []string `json:"endpoints,omitempty"`
+}
+
+// RelationNetworkInfo holds the network information resolved for one of
+// a unit's relations: the addresses the unit should advertise to the
+// other side of the relation, and the subnets its traffic on the
+// relation comes from.
+type RelationNetworkInfo struct {
+	RelationId        int      `json:"relation-id"`
+	Endpoint          string   `json:"endpoint"`
+	RemoteApplication string   `json:"remote-application,omitempty"`
+	CrossModel        bool     `json:"cross-model,omitempty"`
+	Space             string   `json:"space,omitempty"`
+	IngressAddresses  []string `json:"ingress-addresses,omitempty"`
+	EgressSubnets     []string `json:"egress-subnets,omitempty"`
+	Error             *Error   `json:"error,omitempty"`
+}
+
+// RelationNetworkInfoResults holds the network information of a unit's
+// relations.
+type RelationNetworkInfoResults struct {
+	Results []RelationNetworkInfo `json:"results"`
+}
+
 // FanConfigEntry holds configuration for a single fan.
 type FanConfigEntry struct {
 	Underlay string `json:"underlay"`
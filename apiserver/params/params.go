@@ -137,6 +137,11 @@ type DestroyRelation struct {
 	// will wait before forcing the next step to kick-off. This parameter
 	// only makes sense in combination with 'force' set to 'true'.
 	MaxWait *time.Duration `json:"max-wait,omitempty"`
+
+	// DrainTimeout, if set, overrides the model's relation-drain-timeout:
+	// the units in the relation are given this long to run their
+	// departure hooks before they are forced out of the relation.
+	DrainTimeout *time.Duration `json:"drain-timeout,omitempty"`
 }
 
 // RelationStatusArgs holds the parameters for updating the status
@@ -265,6 +270,22 @@ type DestroyMachinesParams struct {
 	MaxWait *time.Duration `json:"max-wait,omitempty"`
 }
 
+// MachineConsoleLogResult holds the console output of a machine's
+// instance, and the status of cloud-init determined from it.
+type MachineConsoleLogResult struct {
+	InstanceId      string `json:"instance-id,omitempty"`
+	Output          string `json:"output,omitempty"`
+	CloudInitStatus string `json:"cloud-init-status,omitempty"`
+	CloudInitDetail string `json:"cloud-init-detail,omitempty"`
+	Error           *Error `json:"error,omitempty"`
+}
+
+// MachineConsoleLogResults holds the results of a MachineConsoleLog
+// call.
+type MachineConsoleLogResults struct {
+	Results []MachineConsoleLogResult `json:"results"`
+}
+
 // UpdateSeriesArg holds the parameters for updating the series for the
 // specified application or machine. For Application, only known by facade
 // version 5 and greater. For MachineManger, only known by facade version
@@ -512,6 +533,11 @@ type LoginRequest struct {
 	Macaroons   []macaroon.Slice `json:"macaroons"`
 	CLIArgs     string           `json:"cli-args,omitempty"`
 	UserData    string           `json:"user-data"`
+
+	// ImpersonateTag, if set, holds the tag of the user that a
+	// controller superuser logging in will act as. Every request
+	// made on the connection is then recorded in the audit log.
+	ImpersonateTag string `json:"impersonate-tag,omitempty"`
 }
 
 // LoginRequestCompat holds credentials for identifying an entity to the Login v1
@@ -645,6 +671,7 @@ type ContainerConfig struct {
 	SnapStoreProxyID           string                 `json:"snap-store-proxy-id"`
 	SnapStoreProxyURL          string                 `json:"snap-store-proxy-url"`
 	AptMirror                  string                 `json:"apt-mirror"`
+	AptSources                 []AptSource            `json:"apt-sources,omitempty"`
 	CloudInitUserData          map[string]interface{} `json:"cloudinit-userdata,omitempty"`
 	ContainerInheritProperties string                 `json:"container-inherit-properties,omitempty"`
 	*UpdateBehavior
@@ -986,6 +1013,86 @@ type BundleChangesMapArgs struct {
 	Requires []string `json:"requires"`
 }
 
+// ModelPlanArgs holds the desired state of a model, expressed as a
+// bundle, for the Bundle.PlanModel and Bundle.ApplyModelPlan calls.
+type ModelPlanArgs struct {
+	// BundleDataYAML is the YAML-encoded description of the desired
+	// applications, machines and relations.
+	BundleDataYAML string `json:"yaml"`
+
+	// UseExistingMachines, if true, maps the machines in the bundle
+	// onto the existing top level machines in the model with the same
+	// ids, as with "juju deploy --map-machines=existing".
+	UseExistingMachines bool `json:"use-existing-machines,omitempty"`
+
+	// Fingerprint, if set, must match the fingerprint of the plan
+	// computed from the current model. It is ignored by PlanModel.
+	Fingerprint string `json:"fingerprint,omitempty"`
+}
+
+// ModelPlanResult holds the result of a Bundle.PlanModel call.
+type ModelPlanResult struct {
+	// Diff holds the YAML-encoded differences between the model and
+	// the desired state.
+	Diff string `json:"diff,omitempty"`
+
+	// Changes holds the ordered list of changes required to bring the
+	// model to the desired state.
+	Changes []*BundleChangesMapArgs `json:"changes,omitempty"`
+
+	// Fingerprint identifies the plan. Passing it to ApplyModelPlan
+	// ensures that the model has not changed since the plan was made.
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// Errors holds bundle verification errors, and the reasons why
+	// any changes cannot be applied by the controller. A plan with
+	// errors cannot be applied.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// ModelPlanChangeResult holds the outcome of applying a single change
+// in a model plan.
+type ModelPlanChangeResult struct {
+	// Id is the id of the change.
+	Id string `json:"id"`
+
+	// Result holds the name of the entity created by the change, if
+	// any; for example the id of an added machine.
+	Result string `json:"result,omitempty"`
+
+	// Error holds the error that caused the change to fail.
+	Error *Error `json:"error,omitempty"`
+}
+
+// ApplyModelPlanResult holds the result of a Bundle.ApplyModelPlan call.
+type ApplyModelPlanResult struct {
+	// Results holds the outcome of each change that was attempted,
+	// in order. Changes are applied until the first failure.
+	Results []ModelPlanChangeResult `json:"results"`
+}
+
+// AddModelBundleArgs holds the bundle to store as the desired state of
+// a model.
+type AddModelBundleArgs struct {
+	// BundleDataYAML is the YAML-encoded bundle data.
+	BundleDataYAML string `json:"yaml"`
+}
+
+// ModelBundle describes a revision of the bundle describing the desired
+// state of a model.
+type ModelBundle struct {
+	Revision  int       `json:"revision"`
+	Bundle    string    `json:"bundle"`
+	CreatedBy string    `json:"created-by"`
+	Created   time.Time `json:"created"`
+}
+
+// ApproveModelPlanArgs holds the fingerprint of a model plan to
+// approve.
+type ApproveModelPlanArgs struct {
+	Fingerprint string `json:"fingerprint"`
+}
+
 type MongoVersion struct {
 	Major         int    `json:"major"`
 	Minor         int    `json:"minor"`
@@ -1072,6 +1179,50 @@ type DestroyUnitInfo struct {
 	DestroyedStorage []Entity `json:"destroyed-storage,omitempty"`
 }
 
+// UnitForceRemovalCheckResults holds the results of a
+// CheckUnitsForceRemoval API request.
+type UnitForceRemovalCheckResults struct {
+	Results []UnitForceRemovalCheckResult `json:"results"`
+}
+
+// UnitForceRemovalCheckResult describes what would happen if a unit
+// were removed with force.
+type UnitForceRemovalCheckResult struct {
+	// AgentStatus is the current status of the unit's agent.
+	AgentStatus string `json:"agent-status"`
+
+	// AgentAlive reports whether the unit's agent is connected to
+	// the controller, and so may yet perform the cleanup steps
+	// itself.
+	AgentAlive bool `json:"agent-alive"`
+
+	// SkippedSteps describes each of the cleanup steps normally
+	// performed by the unit's agent which would be skipped.
+	SkippedSteps []string `json:"skipped-steps,omitempty"`
+
+	Error *Error `json:"error,omitempty"`
+}
+
+// UnitsMaintenance holds the parameters for a SetUnitsMaintenance API
+// request.
+type UnitsMaintenance struct {
+	Units []UnitMaintenance `json:"units"`
+}
+
+// UnitMaintenance puts a unit into maintenance, or takes it out of
+// maintenance.
+type UnitMaintenance struct {
+	// Tag is the tag of the unit.
+	Tag string `json:"tag"`
+
+	// Maintenance is true to put the unit into maintenance, and
+	// false to take it out of maintenance.
+	Maintenance bool `json:"maintenance"`
+
+	// Message describes why the unit is being put into maintenance.
+	Message string `json:"message,omitempty"`
+}
+
 // DumpModelRequest wraps the request for a dump-model call.
 // A simplified dump will not contain a complete export, but instead
 // a reduced set that is determined by the server.
@@ -159,6 +159,36 @@ var marshalTestCases = []struct {
 		},
 	},
 	json: `["relation","change",{"model-uuid": "uuid", "key":"Benji", "id": 4711, "endpoints": [{"application-name":"logging", "relation":{"name":"logging-directory", "role":"requirer", "interface":"logging", "optional":false, "limit":1, "scope":"container"}}, {"application-name":"wordpress", "relation":{"name":"logging-dir", "role":"provider", "interface":"logging", "optional":false, "limit":0, "scope":"container"}}]}]`,
+}, {
+	about: "ApplicationOfferInfo Delta",
+	value: params.Delta{
+		Entity: &params.ApplicationOfferInfo{
+			ModelUUID:            "uuid",
+			OfferName:            "hosted-mysql",
+			OfferUUID:            "offer-uuid",
+			ApplicationName:      "mysql",
+			CharmName:            "mysql",
+			TotalConnectedCount:  2,
+			ActiveConnectedCount: 1,
+		},
+	},
+	json: `["applicationOffer","change",{"model-uuid": "uuid", "offer-name":"hosted-mysql", "offer-uuid":"offer-uuid", "application-name":"mysql", "charm-name":"mysql", "total-connected-count":2, "active-connected-count":1}]`,
+}, {
+	about: "StorageInstanceInfo Delta",
+	value: params.Delta{
+		Entity: &params.StorageInstanceInfo{
+			ModelUUID:       "uuid",
+			Id:              "data/0",
+			Kind:            "block",
+			Life:            life.Alive,
+			Owner:           "unit-postgresql-0",
+			StorageName:     "data",
+			Pool:            "loop",
+			Size:            1024,
+			AttachmentCount: 1,
+		},
+	},
+	json: `["storageInstance","change",{"model-uuid": "uuid", "id":"data/0", "kind":"block", "life":"alive", "owner":"unit-postgresql-0", "storage-name":"data", "pool":"loop", "size":1024, "attachment-count":1}]`,
 }, {
 	about: "AnnotationInfo Delta",
 	value: params.Delta{
@@ -0,0 +1,35 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import "time"
+
+// RegistryCredential holds the credential a model uses to pull OCI
+// images from a private registry. The password is only sent to the
+// controller, and to the agents which pull the images.
+type RegistryCredential struct {
+	Registry  string     `json:"registry"`
+	Username  string     `json:"username"`
+	Password  string     `json:"password,omitempty"`
+	Expiry    *time.Time `json:"expiry,omitempty"`
+	UpdatedBy string     `json:"updated-by,omitempty"`
+	Updated   *time.Time `json:"updated,omitempty"`
+}
+
+// RegistryCredentialsResult holds the registry credentials of a model.
+type RegistryCredentialsResult struct {
+	Credentials []RegistryCredential `json:"credentials"`
+}
+
+// SetRegistryCredentials holds the registry credentials to add to a
+// model, or to replace existing ones with.
+type SetRegistryCredentials struct {
+	Credentials []RegistryCredential `json:"credentials"`
+}
+
+// RemoveRegistryCredentials holds the registries whose credentials are
+// to be removed from a model.
+type RemoveRegistryCredentials struct {
+	Registries []string `json:"registries"`
+}
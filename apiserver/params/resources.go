@@ -65,6 +65,54 @@ type UnitResources struct {
 	DownloadProgress map[string]int64 `json:"download-progress"`
 }
 
+// ResourceRevisionArgs holds the arguments to the ResourceHistory and
+// RollbackResources API endpoints.
+type ResourceRevisionArgs struct {
+	Args []ResourceRevisionArg `json:"args"`
+}
+
+// ResourceRevisionArg identifies a resource of an application and, for
+// RollbackResources, the revision of its content to roll back to.
+type ResourceRevisionArg struct {
+	// Tag is the tag of the application.
+	Tag string `json:"tag"`
+
+	// Name is the name of the resource.
+	Name string `json:"name"`
+
+	// Revision is the number of the revision of the resource's
+	// content.
+	Revision int `json:"revision,omitempty"`
+}
+
+// ResourceHistoryResults holds the results of the ResourceHistory API
+// endpoint.
+type ResourceHistoryResults struct {
+	Results []ResourceHistoryResult `json:"results"`
+}
+
+// ResourceHistoryResult holds the revisions of the content attached to
+// a resource, newest first.
+type ResourceHistoryResult struct {
+	ErrorResult
+
+	Revisions []ResourceRevision `json:"revisions"`
+}
+
+// ResourceRevision describes a revision of the content attached to a
+// resource. Previous revisions are kept, until pruned, so that the
+// application can be rolled back to them.
+type ResourceRevision struct {
+	// Resource describes the resource content of the revision.
+	Resource Resource `json:"resource"`
+
+	// Number identifies the revision among those of the resource.
+	Number int `json:"number"`
+
+	// Current is true for the revision the application is using.
+	Current bool `json:"current"`
+}
+
 // UploadResult is the response from an upload request.
 type UploadResult struct {
 	ErrorResult
@@ -240,6 +240,17 @@ type DetailedStatus struct {
 	Version string                 `json:"version"`
 	Life    life.Value             `json:"life"`
 	Err     *Error                 `json:"err,omitempty"`
+
+	// Unit is the name of the unit whose status this is, when the
+	// status history of all of an application's units is requested.
+	Unit string `json:"unit,omitempty"`
+
+	// FirstSince and Count are set on status history records which
+	// stand for the same status being set several times in a row:
+	// FirstSince holds when it was first set, Count how many times,
+	// and Since when it was last set.
+	FirstSince *time.Time `json:"first-since,omitempty"`
+	Count      int        `json:"count,omitempty"`
 }
 
 // History holds many DetailedStatus.
@@ -254,6 +265,7 @@ type StatusHistoryFilter struct {
 	Date    *time.Time     `json:"date"`
 	Delta   *time.Duration `json:"delta"`
 	Exclude []string       `json:"exclude"`
+	Until   *time.Time     `json:"until,omitempty"`
 }
 
 // StatusHistoryRequest holds the parameters to filter a status history query.
@@ -280,11 +292,50 @@ type StatusHistoryResults struct {
 	Results []StatusHistoryResult `json:"results"`
 }
 
+// StatusHistoryPageRequest holds the parameters to read a page of the
+// status history of an entity.
+type StatusHistoryPageRequest struct {
+	Tag    string              `json:"tag"`
+	Kind   string              `json:"history-kind"`
+	Filter StatusHistoryFilter `json:"filter"`
+
+	// PageSize is the most records to return in the page.
+	PageSize int `json:"page-size"`
+
+	// Cursor is the Next cursor of the previous page, or empty to
+	// read the first page.
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// StatusHistoryPageResult holds a page of status history, oldest first.
+type StatusHistoryPageResult struct {
+	Statuses []DetailedStatus `json:"statuses"`
+
+	// Next is the cursor with which to read the following page, or
+	// empty if there are no more records.
+	Next string `json:"next,omitempty"`
+}
+
 // StatusHistoryPruneArgs holds arguments for status history
 // prunning process.
 type StatusHistoryPruneArgs struct {
-	MaxHistoryTime time.Duration `json:"max-history-time"`
-	MaxHistoryMB   int           `json:"max-history-mb"`
+	MaxHistoryTime      time.Duration                `json:"max-history-time"`
+	MaxHistoryMB        int                          `json:"max-history-mb"`
+	MaxEntriesPerEntity int                          `json:"max-entries-per-entity,omitempty"`
+	PerKind             []StatusHistoryKindRetention `json:"per-kind,omitempty"`
+
+	// Compact, if true, collapses runs of identical status history
+	// records into one before pruning.
+	Compact bool `json:"compact,omitempty"`
+}
+
+// StatusHistoryKindRetention holds the limits applied when pruning the
+// status history of a kind of entity, such as "machine" or "workload",
+// in place of the model-wide ones.
+type StatusHistoryKindRetention struct {
+	Kind                string        `json:"kind"`
+	MaxHistoryTime      time.Duration `json:"max-history-time,omitempty"`
+	MaxEntriesPerEntity int           `json:"max-entries-per-entity,omitempty"`
 }
 
 // StatusResult holds an entity status, extra information, or an
@@ -299,6 +350,18 @@ type StatusResult struct {
 	Since  *time.Time             `json:"since"`
 }
 
+// PeerUnitStatusResult holds the workload statuses of the other units
+// of a unit's application, or an error.
+type PeerUnitStatusResult struct {
+	Units []StatusResult `json:"units,omitempty"`
+	Error *Error         `json:"error,omitempty"`
+}
+
+// PeerUnitStatusResults holds multiple peer unit status results.
+type PeerUnitStatusResults struct {
+	Results []PeerUnitStatusResult `json:"results"`
+}
+
 // StatusResults holds multiple status results.
 type StatusResults struct {
 	Results []StatusResult `json:"results"`
@@ -331,3 +394,123 @@ type BranchStatus struct {
 	Created       int64               `json:"created"`
 	CreatedBy     string              `json:"created-by"`
 }
+
+// StatusSnapshotArgs holds the time for which a snapshot of the status
+// of a model is requested.
+type StatusSnapshotArgs struct {
+	Time time.Time `json:"time"`
+}
+
+// StatusSnapshotResult holds the approximate status of a model at a
+// point in time, reconstructed from status history, and the commands
+// run against the model in the day after then.
+type StatusSnapshotResult struct {
+	Status   FullStatus       `json:"status"`
+	Commands []AuditedCommand `json:"commands,omitempty"`
+}
+
+// AuditedCommand describes a command run against a model, as recorded
+// in the audit log of a controller node.
+type AuditedCommand struct {
+	Who  string    `json:"who"`
+	What string    `json:"what"`
+	When time.Time `json:"when"`
+}
+
+// WatchStatusChangesArgs holds the arguments for watching changes to
+// the statuses of entities in a model.
+type WatchStatusChangesArgs struct {
+	Args []WatchStatusChangesArg `json:"args"`
+}
+
+// WatchStatusChangesArg holds the kind of entity whose status changes
+// are to be watched: "model", "machine", "application" or "unit". All
+// kinds are watched if it's empty.
+type WatchStatusChangesArg struct {
+	Kind string `json:"kind,omitempty"`
+}
+
+// StatusChange describes a change to one of the statuses of an entity.
+type StatusChange struct {
+	// Key identifies the status that changed, as an entity may have
+	// several, such as the agent and workload statuses of a unit.
+	Key string `json:"key"`
+
+	// Tag is the tag of the entity the status belongs to, if any.
+	Tag string `json:"tag,omitempty"`
+
+	// Removed is true if the status was removed along with its entity,
+	// in which case the remaining fields are empty.
+	Removed bool `json:"removed,omitempty"`
+
+	Status string                 `json:"status,omitempty"`
+	Info   string                 `json:"info,omitempty"`
+	Data   map[string]interface{} `json:"data,omitempty"`
+	Since  *time.Time             `json:"since,omitempty"`
+}
+
+// StatusChangesWatchResult holds a StatusChangesWatcher id, the
+// initial statuses (in the Changes field), and an error (if any).
+type StatusChangesWatchResult struct {
+	StatusChangesWatcherId string         `json:"watcher-id"`
+	Changes                []StatusChange `json:"changes"`
+	Error                  *Error         `json:"error,omitempty"`
+}
+
+// StatusChangesWatchResults holds the results of a WatchStatusChanges
+// call.
+type StatusChangesWatchResults struct {
+	Results []StatusChangesWatchResult `json:"results"`
+}
+
+// StuckStatusesArgs holds the arguments for the StuckStatuses call.
+type StuckStatusesArgs struct {
+	// MinDuration, if not zero, leaves out the statuses which have
+	// been stuck for less time.
+	MinDuration time.Duration `json:"min-duration,omitempty"`
+}
+
+// StuckStatus describes a machine, application or unit status which is
+// error, blocked or waiting.
+type StuckStatus struct {
+	Tag      string        `json:"tag"`
+	Kind     string        `json:"kind"`
+	Status   string        `json:"status"`
+	Info     string        `json:"info"`
+	Since    time.Time     `json:"since"`
+	Duration time.Duration `json:"duration"`
+}
+
+// StuckStatusesResult holds the result of the StuckStatuses call.
+type StuckStatusesResult struct {
+	Statuses []StuckStatus `json:"statuses"`
+}
+
+// MachineSecurityUpdates holds the latest security updates report of a
+// machine. Report is nil if the machine hasn't reported.
+type MachineSecurityUpdates struct {
+	Tag    string                 `json:"tag"`
+	Report *SecurityUpdatesReport `json:"report,omitempty"`
+}
+
+// SecurityUpdatesSummary holds the patch posture of the machines in a
+// model, and the totals across the machines which have reported.
+type SecurityUpdatesSummary struct {
+	Machines       []MachineSecurityUpdates `json:"machines"`
+	Reporting      int                      `json:"reporting"`
+	Pending        int                      `json:"pending"`
+	Security       int                      `json:"security"`
+	RebootRequired int                      `json:"reboot-required"`
+}
+
+// WorkerFailuresResult holds the recent worker failures of an agent,
+// oldest first, or an error.
+type WorkerFailuresResult struct {
+	Failures []WorkerFailure `json:"failures,omitempty"`
+	Error    *Error          `json:"error,omitempty"`
+}
+
+// WorkerFailuresResults holds the results of a WorkerFailures call.
+type WorkerFailuresResults struct {
+	Results []WorkerFailuresResult `json:"results"`
+}
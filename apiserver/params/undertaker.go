@@ -21,3 +21,9 @@ type UndertakerModelInfoResult struct {
 	Error  *Error              `json:"error,omitempty"`
 	Result UndertakerModelInfo `json:"result"`
 }
+
+// AbandonCloudEnvironmentArgs holds the reason the cloud environment
+// of a force-destroyed model could not be torn down.
+type AbandonCloudEnvironmentArgs struct {
+	Reason string `json:"reason"`
+}
@@ -17,9 +17,11 @@ var commonModelFacadeNames = set.NewStrings(
 	"ActionPruner",
 	"AllWatcher",
 	"Agent",
+	"AgentIntrospection",
 	"Annotations",
 	"Application",
 	"Block",
+	"BundleReconciler",
 	"CharmRevisionUpdater",
 	"Charms",
 	"Cleaner",
@@ -27,10 +29,14 @@ var commonModelFacadeNames = set.NewStrings(
 	"Cloud",
 	"CredentialValidator",
 	"CrossController",
+	"CrossModelHealth",
 	"CrossModelRelations",
+	"DeadAgents",
 	"ExternalControllerUpdater",
 	"FilesystemAttachmentsWatcher",
+	"LeadershipPinExpiry",
 	"LeadershipService",
+	"Leases",
 	"LifeFlag",
 	"Logger",
 	"MeterStatus",
@@ -39,10 +45,14 @@ var commonModelFacadeNames = set.NewStrings(
 	"MigrationMinion",
 	"MigrationStatusWatcher",
 	"MigrationTarget",
+	"ModelArchive",
 	"ModelConfig",
+	"ModelCost",
 	"ModelUpgrader",
+	"ModelVerifier",
 	"NotifyWatcher",
 	"OfferStatusWatcher",
+	"OperationsLog",
 	"Pinger",
 	"ProxyUpdater",
 	"Resources",
@@ -55,10 +65,15 @@ var commonModelFacadeNames = set.NewStrings(
 	"Resumer",
 	"RetryStrategy",
 	"Singular",
+	"StatusAlerts",
+	"StatusChanges",
+	"StatusChangesWatcher",
 	"StatusHistory",
+	"StatusSnapshot",
 	"Storage",
 	"StorageProvisioner",
 	"StringsWatcher",
+	"StuckStatus",
 	"Undertaker",
 	"Uniter",
 	"Upgrader",
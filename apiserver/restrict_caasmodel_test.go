@@ -28,6 +28,8 @@ func (s *RestrictCAASModelSuite) SetUpSuite(c *gc.C) {
 func (s *RestrictCAASModelSuite) TestAllowed(c *gc.C) {
 	// TODO(caas) - replace with "CAASOperatorProvisioner.WatchApplications" when that bit lands
 	s.assertMethod(c, "CAASOperatorProvisioner", 1, "WatchApplications")
+	s.assertMethod(c, "Leases", 3, "PinLeaders")
+	s.assertMethod(c, "AgentIntrospection", 1, "Introspect")
 }
 
 func (s *RestrictCAASModelSuite) TestNotAllowed(c *gc.C) {
@@ -19,8 +19,10 @@ var controllerFacadeNames = set.NewStrings(
 	"Cloud",
 	"Controller",
 	"CrossController",
+	"Dashboard",
 	"MigrationTarget",
 	"ModelManager",
+	"Search",
 	"UserManager",
 )
 
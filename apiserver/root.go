@@ -573,6 +573,34 @@ func (ctx *facadeContext) SingularClaimer() (lease.Claimer, error) {
 	)
 }
 
+// LeaseInspector is part of the facade.Context interface.
+// Inspecting leases is only available with the Raft leases implementation.
+func (ctx *facadeContext) LeaseInspector(namespace, modelUUID string) (lease.Inspector, error) {
+	if ctx.r.shared.featureEnabled(feature.LegacyLeases) {
+		return nil, errors.NotSupportedf("inspecting leases with the legacy lease manager")
+	}
+	return ctx.r.shared.leaseManager.Inspector(namespace, modelUUID)
+}
+
+// LeaseRevoker is part of the facade.Context interface.
+// Revoking leases is only available with the Raft leases implementation.
+func (ctx *facadeContext) LeaseRevoker(namespace, modelUUID string) (lease.Revoker, error) {
+	if ctx.r.shared.featureEnabled(feature.LegacyLeases) {
+		return nil, errors.NotSupportedf("revoking leases with the legacy lease manager")
+	}
+	return ctx.r.shared.leaseManager.Revoker(namespace, modelUUID)
+}
+
+// LeaseLister is part of the facade.Context interface.
+// Listing leases across models is only available with the Raft
+// leases implementation.
+func (ctx *facadeContext) LeaseLister(namespace string) (lease.Lister, error) {
+	if ctx.r.shared.featureEnabled(feature.LegacyLeases) {
+		return nil, errors.NotSupportedf("listing leases with the legacy lease manager")
+	}
+	return ctx.r.shared.leaseManager.Lister(namespace)
+}
+
 // adminRoot dispatches API calls to those available to an anonymous connection
 // which has not logged in, which here is the admin facade.
 type adminRoot struct {
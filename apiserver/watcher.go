@@ -10,6 +10,7 @@ import (
 	"github.com/juju/juju/apiserver/common/crossmodel"
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/facades/client/statuschanges"
 	"github.com/juju/juju/apiserver/facades/controller/crossmodelrelations"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/controller"
@@ -292,6 +293,55 @@ func (w *srvRelationStatusWatcher) Next() (params.RelationLifeSuspendedStatusWat
 	return params.RelationLifeSuspendedStatusWatchResult{}, err
 }
 
+// srvStatusChangesWatcher defines the API wrapping the
+// state.StringsWatcher returned by state.WatchStatusChanges.
+type srvStatusChangesWatcher struct {
+	watcherCommon
+	st      *state.State
+	watcher state.StringsWatcher
+}
+
+func newStatusChangesWatcher(context facade.Context) (facade.Facade, error) {
+	id := context.ID()
+	auth := context.Auth()
+	resources := context.Resources()
+
+	if !isAgentOrUser(auth) {
+		return nil, common.ErrPerm
+	}
+	watcher, ok := resources.Get(id).(state.StringsWatcher)
+	if !ok {
+		return nil, common.ErrUnknownWatcher
+	}
+	return &srvStatusChangesWatcher{
+		watcherCommon: newWatcherCommon(context),
+		st:            context.State(),
+		watcher:       watcher,
+	}, nil
+}
+
+// Next returns when a status has changed since the most recent
+// call to Next or the WatchStatusChanges call that created the
+// srvStatusChangesWatcher.
+func (w *srvStatusChangesWatcher) Next() (params.StatusChangesWatchResult, error) {
+	if keys, ok := <-w.watcher.Changes(); ok {
+		changes, err := w.st.StatusChanges(keys)
+		if err != nil {
+			return params.StatusChangesWatchResult{
+				Error: common.ServerError(err),
+			}, nil
+		}
+		return params.StatusChangesWatchResult{
+			Changes: statuschanges.StatusChangesParams(changes),
+		}, nil
+	}
+	err := w.watcher.Err()
+	if err == nil {
+		err = common.ErrStoppedWatcher
+	}
+	return params.StatusChangesWatchResult{}, err
+}
+
 // srvOfferStatusWatcher defines the API wrapping a crossmodelrelations.OfferStatusWatcher.
 type srvOfferStatusWatcher struct {
 	watcherCommon
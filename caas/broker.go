@@ -124,6 +124,49 @@ type ServiceParams struct {
 
 	// OperatorImagePath is the path to the OCI image shared by the operator and pod init.
 	OperatorImagePath string
+
+	// PartitionedUpgrade, if true, holds back changes to the pods of a
+	// stateful application so that they can be rolled out one pod at a
+	// time using a PartitionedUpgrader.
+	PartitionedUpgrade bool
+
+	// RegistryCredentials are used to pull the images of containers
+	// whose image details have no password of their own.
+	RegistryCredentials []RegistryCredential
+
+	// NetworkPolicy, if not nil, determines the traffic allowed to
+	// reach the application's pods. If nil, any existing policy is
+	// left as it is.
+	NetworkPolicy *NetworkPolicy
+}
+
+// RegistryCredential is the credential used to pull images from an
+// OCI image registry.
+type RegistryCredential struct {
+	// Registry is the host, and optional port, of the registry as it
+	// appears in image paths.
+	Registry string
+
+	Username string
+	Password string
+}
+
+// NetworkPolicy describes the traffic allowed to reach an
+// application's pods.
+type NetworkPolicy struct {
+	// Isolated is true if the application's pods only accept traffic
+	// from the pods of its own units, its operator and the
+	// applications it is related to. If false, any traffic is
+	// accepted.
+	Isolated bool
+
+	// RelatedApplications are the names of the applications in the
+	// model the application is related to.
+	RelatedApplications []string
+
+	// Exposed is true if the application has been exposed, so its
+	// pods accept traffic from anywhere.
+	Exposed bool
 }
 
 // OperatorState is returned by the OperatorExists call.
@@ -180,6 +223,11 @@ type Broker interface {
 	// are changes to the deployment of the specified application.
 	WatchService(appName string) (watcher.NotifyWatcher, error)
 
+	// WatchUnitEvents returns a watcher which notifies when there are
+	// Kubernetes events for the pod with the given provider id, or for
+	// the service of the specified application.
+	WatchUnitEvents(appName, providerID string) (watcher.NotifyWatcher, error)
+
 	// Operator returns an Operator with current status and life details.
 	Operator(string) (*Operator, error)
 
@@ -221,6 +269,16 @@ type Upgrader interface {
 	Upgrade(appName string, vers version.Number) error
 }
 
+// PartitionedUpgrader is implemented by brokers which can roll out
+// changes to the pods of an application one pod at a time.
+type PartitionedUpgrader interface {
+	// AdvanceUpgrade moves the rollout of a partitioned upgrade of the
+	// specified application on to the next pod, once the pods upgraded
+	// so far are running the new revision and are ready. It returns
+	// true when all of the application's pods have been upgraded.
+	AdvanceUpgrade(appName string) (bool, error)
+}
+
 // StorageValidator provides methods to validate storage.
 type StorageValidator interface {
 	// ValidateStorageClass returns an error if the storage config is not valid.
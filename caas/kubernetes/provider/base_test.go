@@ -48,6 +48,9 @@ type BaseSuite struct {
 	mockSecrets                *mocks.MockSecretInterface
 	mockDeployments            *mocks.MockDeploymentInterface
 	mockStatefulSets           *mocks.MockStatefulSetInterface
+	mockJobs                   *mocks.MockJobInterface
+	mockCronJobs               *mocks.MockCronJobInterface
+	mockNetworkPolicies        *mocks.MockNetworkPolicyInterface
 	mockPods                   *mocks.MockPodInterface
 	mockServices               *mocks.MockServiceInterface
 	mockConfigMaps             *mocks.MockConfigMapInterface
@@ -202,6 +205,20 @@ func (s *BaseSuite) setupK8sRestClient(c *gc.C, ctrl *gomock.Controller, namespa
 	s.mockApps.EXPECT().Deployments(namespace).AnyTimes().Return(s.mockDeployments)
 	s.mockExtensions.EXPECT().Ingresses(namespace).AnyTimes().Return(s.mockIngressInterface)
 
+	mockBatchV1 := mocks.NewMockBatchV1Interface(ctrl)
+	mockBatchV1beta1 := mocks.NewMockBatchV1beta1Interface(ctrl)
+	s.mockJobs = mocks.NewMockJobInterface(ctrl)
+	s.mockCronJobs = mocks.NewMockCronJobInterface(ctrl)
+	s.k8sClient.EXPECT().BatchV1().AnyTimes().Return(mockBatchV1)
+	s.k8sClient.EXPECT().BatchV1beta1().AnyTimes().Return(mockBatchV1beta1)
+	mockBatchV1.EXPECT().Jobs(namespace).AnyTimes().Return(s.mockJobs)
+	mockBatchV1beta1.EXPECT().CronJobs(namespace).AnyTimes().Return(s.mockCronJobs)
+
+	mockNetworkingV1 := mocks.NewMockNetworkingV1Interface(ctrl)
+	s.mockNetworkPolicies = mocks.NewMockNetworkPolicyInterface(ctrl)
+	s.k8sClient.EXPECT().NetworkingV1().AnyTimes().Return(mockNetworkingV1)
+	mockNetworkingV1.EXPECT().NetworkPolicies(namespace).AnyTimes().Return(s.mockNetworkPolicies)
+
 	s.mockStorage = mocks.NewMockStorageV1Interface(ctrl)
 	s.mockStorageClass = mocks.NewMockStorageClassInterface(ctrl)
 	s.k8sClient.EXPECT().StorageV1().AnyTimes().Return(s.mockStorage)
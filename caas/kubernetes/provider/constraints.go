@@ -4,6 +4,11 @@
 package provider
 
 import (
+	"strings"
+
+	"github.com/juju/errors"
+	core "k8s.io/api/core/v1"
+
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/environs/context"
 )
@@ -11,7 +16,6 @@ import (
 const CAASProviderType = "kubernetes"
 
 var unsupportedConstraints = []string{
-	constraints.Cores,
 	constraints.VirtType,
 	constraints.Container,
 	constraints.Arch,
@@ -24,5 +28,101 @@ var unsupportedConstraints = []string{
 func (k *kubernetesClient) ConstraintsValidator(ctx context.ProviderCallContext) (constraints.Validator, error) {
 	validator := constraints.NewValidator()
 	validator.RegisterUnsupported(unsupportedConstraints)
+	// Both cores and cpu-power set the containers' cpu limit.
+	validator.RegisterConflicts([]string{constraints.Cores}, []string{constraints.CpuPower})
 	return validator, nil
 }
+
+// tolerationTagPrefix marks a tags constraint as a taint the
+// application's pods tolerate rather than a node label to select on,
+// eg tags=toleration:dedicated=db.
+const tolerationTagPrefix = "toleration:"
+
+// zoneLabelName is the node label holding a node's availability zone.
+const zoneLabelName = "failure-domain.beta.kubernetes.io/zone"
+
+// nodeTags holds the scheduling rules parsed from a tags constraint.
+type nodeTags struct {
+	// selector holds the labels with a single value, which nodes
+	// must have.
+	selector map[string]string
+
+	// affinity holds the labels with several "|" separated values,
+	// one of which nodes must have.
+	affinity map[string][]string
+
+	// antiAffinity holds the "^" prefixed labels, none of whose
+	// values nodes may have.
+	antiAffinity map[string][]string
+
+	// tolerations holds the taints pods may be scheduled despite.
+	tolerations []core.Toleration
+}
+
+// parseNodeTags parses the value of a tags constraint into node
+// selectors, node affinity and taint tolerations.
+func parseNodeTags(tags []string) (nodeTags, error) {
+	result := nodeTags{
+		selector:     make(map[string]string),
+		affinity:     make(map[string][]string),
+		antiAffinity: make(map[string][]string),
+	}
+	invalid := errors.Errorf("invalid node affinity constraints: %v", strings.Join(tags, ","))
+	for _, tag := range tags {
+		if strings.HasPrefix(tag, tolerationTagPrefix) {
+			toleration, err := parseToleration(strings.TrimPrefix(tag, tolerationTagPrefix))
+			if err != nil {
+				return nodeTags{}, errors.Trace(err)
+			}
+			result.tolerations = append(result.tolerations, toleration)
+			continue
+		}
+		parts := strings.Split(tag, "=")
+		if len(parts) != 2 {
+			return nodeTags{}, invalid
+		}
+		key := strings.Trim(parts[0], " ")
+		values := strings.Split(parts[1], "|")
+		for i, v := range values {
+			values[i] = strings.Trim(v, " ")
+		}
+		switch {
+		case key == "^":
+			return nodeTags{}, invalid
+		case strings.HasPrefix(key, "^"):
+			result.antiAffinity[key[1:]] = values
+		case len(values) == 1:
+			result.selector[key] = values[0]
+		default:
+			result.affinity[key] = values
+		}
+	}
+	return result, nil
+}
+
+// parseToleration parses a taint to tolerate, of the form key,
+// key=value or either of those followed by :effect.
+func parseToleration(value string) (core.Toleration, error) {
+	var toleration core.Toleration
+	if i := strings.LastIndex(value, ":"); i >= 0 {
+		toleration.Effect = core.TaintEffect(value[i+1:])
+		value = value[:i]
+		switch toleration.Effect {
+		case core.TaintEffectNoSchedule, core.TaintEffectPreferNoSchedule, core.TaintEffectNoExecute:
+		default:
+			return core.Toleration{}, errors.NotValidf("taint effect %q", toleration.Effect)
+		}
+	}
+	parts := strings.Split(value, "=")
+	toleration.Key = strings.Trim(parts[0], " ")
+	switch {
+	case len(parts) > 2 || toleration.Key == "":
+		return core.Toleration{}, errors.NotValidf("toleration %q", value)
+	case len(parts) == 2:
+		toleration.Operator = core.TolerationOpEqual
+		toleration.Value = strings.Trim(parts[1], " ")
+	default:
+		toleration.Operator = core.TolerationOpExists
+	}
+	return toleration, nil
+}
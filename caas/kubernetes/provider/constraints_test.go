@@ -65,7 +65,6 @@ func (s *ConstraintsSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 		"tags=foo",
 		"mem=3",
 		"instance-type=some-type",
-		"cores=2",
 		"cpu-power=250",
 		"virt-type=kvm",
 		"root-disk=10M",
@@ -76,7 +75,6 @@ func (s *ConstraintsSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	expected := []string{
-		"cores",
 		"virt-type",
 		"arch",
 		"instance-type",
@@ -85,3 +83,18 @@ func (s *ConstraintsSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 	}
 	c.Check(unsupported, jc.SameContents, expected)
 }
+
+func (s *ConstraintsSuite) TestConstraintsValidatorConflicts(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	validator, err := s.broker.ConstraintsValidator(context.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = validator.Validate(constraints.MustParse("cores=2 cpu-power=250"))
+	c.Assert(err, gc.ErrorMatches, `ambiguous constraints: "cores" overlaps with "cpu-power"`)
+
+	cons, err := validator.Merge(constraints.MustParse("cores=2"), constraints.MustParse("cpu-power=250"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cons, jc.DeepEquals, constraints.MustParse("cpu-power=250"))
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/docker/distribution/reference"
 	"github.com/juju/errors"
 
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/caas/specs"
 )
 
@@ -62,3 +63,29 @@ func extractRegistryURL(imagePath string) (string, error) {
 	}
 	return reference.Domain(imageNamed), nil
 }
+
+// withRegistryCredentials returns the containers with the model's
+// credential for the registry of each image filled in, unless the
+// image details have a password of their own.
+func withRegistryCredentials(containers []specs.ContainerSpec, creds []caas.RegistryCredential) []specs.ContainerSpec {
+	result := make([]specs.ContainerSpec, len(containers))
+	for i, c := range containers {
+		result[i] = c
+		if c.ImageDetails.Password != "" || c.ImageDetails.ImagePath == "" {
+			continue
+		}
+		registryURL, err := extractRegistryURL(c.ImageDetails.ImagePath)
+		if err != nil {
+			logger.Warningf("not using registry credentials for container %q: %v", c.Name, err)
+			continue
+		}
+		for _, cred := range creds {
+			if cred.Registry == registryURL {
+				result[i].ImageDetails.Username = cred.Username
+				result[i].ImageDetails.Password = cred.Password
+				break
+			}
+		}
+	}
+	return result
+}
@@ -9,6 +9,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/caas"
 	"github.com/juju/juju/caas/kubernetes/provider"
 	"github.com/juju/juju/caas/specs"
 	"github.com/juju/juju/testing"
@@ -67,3 +68,52 @@ func (s *DockerConfigSuite) TestCreateDockerConfigJSON(c *gc.C) {
 		},
 	})
 }
+
+func (s *DockerConfigSuite) TestWithRegistryCredentials(c *gc.C) {
+	containers := []specs.ContainerSpec{{
+		Name:         "private",
+		ImageDetails: specs.ImageDetails{ImagePath: "registry.example.com/team/app:1.0"},
+	}, {
+		Name:         "hub",
+		ImageDetails: specs.ImageDetails{ImagePath: "me/mygitlab:latest"},
+	}, {
+		Name: "own-password",
+		ImageDetails: specs.ImageDetails{
+			ImagePath: "registry.example.com/team/other:1.0",
+			Username:  "alice",
+			Password:  "hunter2",
+		},
+	}, {
+		Name:  "deprecated-image",
+		Image: "registry.example.com/team/old:1.0",
+	}}
+	creds := []caas.RegistryCredential{{
+		Registry: "docker.io",
+		Username: "bob",
+		Password: "token",
+	}, {
+		Registry: "registry.example.com",
+		Username: "robot",
+		Password: "secret",
+	}}
+
+	result := provider.WithRegistryCredentials(containers, creds)
+	c.Assert(result, jc.DeepEquals, []specs.ContainerSpec{{
+		Name: "private",
+		ImageDetails: specs.ImageDetails{
+			ImagePath: "registry.example.com/team/app:1.0",
+			Username:  "robot",
+			Password:  "secret",
+		},
+	}, {
+		Name: "hub",
+		ImageDetails: specs.ImageDetails{
+			ImagePath: "me/mygitlab:latest",
+			Username:  "bob",
+			Password:  "token",
+		},
+	}, containers[2], containers[3]})
+
+	// The containers passed in are unchanged.
+	c.Assert(containers[0].ImageDetails.Password, gc.Equals, "")
+}
@@ -4,12 +4,18 @@
 package provider
 
 import (
+	"sort"
+	"time"
+
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
 
 	core "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 )
 
@@ -40,8 +46,63 @@ const (
 	FailedToInspectImage    = "InspectFailed"
 	ErrImageNeverPullPolicy = "ErrImageNeverPull"
 	BackOffPullImage        = "BackOff"
+
+	// Scheduler event reason list, copied from
+	// "k8s.io/kubernetes/pkg/scheduler".
+	FailedScheduling = "FailedScheduling"
+)
+
+// maxPodWarningEvents is how many of a pod's warning events are recorded
+// in its status data.
+const maxPodWarningEvents = 3
+
+// podWarningReasons holds the reasons of the warning events which
+// explain why a pod isn't running: its image failing to pull, its
+// containers backing off or it not fitting on any node.
+var podWarningReasons = set.NewStrings(
+	FailedToPullImage,
+	BackOffPullImage,
+	FailedScheduling,
 )
 
+// podWarningEvents returns the most recent of the given events which
+// explain why a pod isn't running, newest first.
+func podWarningEvents(events []core.Event) []status.KubernetesEvent {
+	var warnings []core.Event
+	for _, evt := range events {
+		if evt.Type == core.EventTypeWarning && podWarningReasons.Contains(evt.Reason) {
+			warnings = append(warnings, evt)
+		}
+	}
+	sort.SliceStable(warnings, func(i, j int) bool {
+		return lastSeen(warnings[i]).After(lastSeen(warnings[j]))
+	})
+	if len(warnings) > maxPodWarningEvents {
+		warnings = warnings[:maxPodWarningEvents]
+	}
+	result := make([]status.KubernetesEvent, len(warnings))
+	for i, evt := range warnings {
+		result[i] = status.KubernetesEvent{
+			Reason:  evt.Reason,
+			Message: evt.Message,
+			Since:   evt.FirstTimestamp.Time,
+		}
+	}
+	return result
+}
+
+// lastSeen returns when the event was last seen, falling back to when
+// it happened for events which don't record that.
+func lastSeen(evt core.Event) time.Time {
+	if !evt.LastTimestamp.IsZero() {
+		return evt.LastTimestamp.Time
+	}
+	if !evt.EventTime.IsZero() {
+		return evt.EventTime.Time
+	}
+	return evt.FirstTimestamp.Time
+}
+
 func (k *kubernetesClient) getEvents(objName string, objKind string) ([]core.Event, error) {
 	selector := fields.AndSelectors(
 		fields.OneTermEqualSelector("involvedObject.name", objName),
@@ -73,3 +134,46 @@ func (k *kubernetesClient) watchEvents(objName string, objKind string) (watcher.
 	}
 	return k.newWatcher(w, objName, k.clock)
 }
+
+// WatchUnitEvents returns a watcher which notifies when there are
+// Kubernetes events for the pod with the given provider id, or for
+// the service of the specified application.
+func (k *kubernetesClient) WatchUnitEvents(appName, providerID string) (watcher.NotifyWatcher, error) {
+	pod, err := k.unitPod(appName, providerID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	podWatcher, err := k.watchEvents(pod.Name, "Pod")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	serviceWatcher, err := k.watchEvents(k.deploymentName(appName), "Service")
+	if err != nil {
+		_ = worker.Stop(podWatcher)
+		return nil, errors.Trace(err)
+	}
+	return watcher.NewMultiNotifyWatcher(podWatcher, serviceWatcher), nil
+}
+
+// unitPod returns the pod of the specified application with the given
+// provider id, which is the pod's name for pods managed by a stateful
+// set and its UID otherwise.
+func (k *kubernetesClient) unitPod(appName, providerID string) (*core.Pod, error) {
+	pod, err := k.getPod(providerID)
+	if err == nil || !errors.IsNotFound(err) {
+		return pod, errors.Trace(err)
+	}
+	pods, err := k.client().CoreV1().Pods(k.namespace).List(v1.ListOptions{
+		LabelSelector:        applicationSelector(appName),
+		IncludeUninitialized: true,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, p := range pods.Items {
+		if string(p.GetUID()) == providerID {
+			return &p, nil
+		}
+	}
+	return nil, errors.NotFoundf("pod %q", providerID)
+}
@@ -26,6 +26,7 @@ var (
 	OperatorPod                = operatorPod
 	ExtractRegistryURL         = extractRegistryURL
 	CreateDockerConfigJSON     = createDockerConfigJSON
+	WithRegistryCredentials    = withRegistryCredentials
 	NewStorageConfig           = newStorageConfig
 	NewKubernetesNotifyWatcher = newKubernetesNotifyWatcher
 	CompileK8sCloudCheckers    = compileK8sCloudCheckers
@@ -37,6 +38,7 @@ var (
 	ToYaml                     = toYaml
 	Indent                     = indent
 	ProcessSecretData          = processSecretData
+	ProcessConstraints         = processConstraints
 )
 
 type (
@@ -114,6 +116,10 @@ func (k *kubernetesClient) GetCRDsForCRs(crs map[string][]unstructured.Unstructu
 	return k.getCRDsForCRs(crs, getter)
 }
 
+func (k *kubernetesClient) EnsureNetworkPolicy(appName, name string, annotations map[string]string, policy caas.NetworkPolicy) error {
+	return k.ensureNetworkPolicy(appName, name, annotations, policy)
+}
+
 func StorageProvider(k8sClient kubernetes.Interface, namespace string) storage.Provider {
 	return &storageProvider{&kubernetesClient{clientUnlocked: k8sClient, namespace: namespace}}
 }
@@ -0,0 +1,287 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	core "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+	"github.com/juju/juju/caas/specs"
+	k8sannotations "github.com/juju/juju/core/annotations"
+	"github.com/juju/juju/core/status"
+)
+
+// jobPodSpec returns the pod spec for the pods of a job. Job pods run
+// to completion, so they can't be restarted unconditionally.
+func jobPodSpec(podSpec core.PodSpec) (core.PodSpec, error) {
+	switch podSpec.RestartPolicy {
+	case "":
+		podSpec.RestartPolicy = core.RestartPolicyOnFailure
+	case core.RestartPolicyAlways:
+		return podSpec, errors.NotValidf("restart policy %q for job", podSpec.RestartPolicy)
+	}
+	return podSpec, nil
+}
+
+func (k *kubernetesClient) jobTemplate(
+	appName, deploymentName string,
+	annotations k8sannotations.Annotation,
+	workloadSpec *workloadSpec,
+	containers []specs.ContainerSpec,
+	parallelism *int32,
+) (*batchv1.JobSpec, error) {
+	// Add the specified file to the pod spec.
+	cfgName := func(fileSetName string) string {
+		return applicationConfigMapName(deploymentName, fileSetName)
+	}
+	podSpec, err := jobPodSpec(workloadSpec.Pod)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := k.configurePodFiles(appName, annotations, &podSpec, containers, cfgName); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	job := workloadSpec.Job
+	return &batchv1.JobSpec{
+		Parallelism:           parallelism,
+		Completions:           job.Completions,
+		BackoffLimit:          job.BackoffLimit,
+		ActiveDeadlineSeconds: job.ActiveDeadlineSeconds,
+		Template: core.PodTemplateSpec{
+			ObjectMeta: v1.ObjectMeta{
+				Labels:      map[string]string{labelApplication: appName},
+				Annotations: podAnnotations(annotations.Copy()).ToMap(),
+			},
+			Spec: podSpec,
+		},
+	}, nil
+}
+
+func (k *kubernetesClient) configureJob(
+	appName, deploymentName string,
+	annotations k8sannotations.Annotation,
+	workloadSpec *workloadSpec,
+	containers []specs.ContainerSpec,
+	parallelism *int32,
+) error {
+	logger.Debugf("creating/updating job for %s", appName)
+
+	jobSpec, err := k.jobTemplate(appName, deploymentName, annotations, workloadSpec, containers, parallelism)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	job := &batchv1.Job{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        deploymentName,
+			Labels:      map[string]string{labelApplication: appName},
+			Annotations: annotations.ToMap(),
+		},
+		Spec: *jobSpec,
+	}
+	return k.ensureJob(job)
+}
+
+func (k *kubernetesClient) ensureJob(spec *batchv1.Job) error {
+	api := k.client().BatchV1().Jobs(k.namespace)
+	existing, err := api.Get(spec.GetName(), v1.GetOptions{IncludeUninitialized: true})
+	if k8serrors.IsNotFound(err) {
+		_, err = api.Create(spec)
+		return errors.Trace(err)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// The pod template of a job can't be changed once it has been
+	// created, so only the number of pods run at a time is updated.
+	existing.Spec.Parallelism = spec.Spec.Parallelism
+	_, err = api.Update(existing)
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteJob(name string) error {
+	jobs := k.client().BatchV1().Jobs(k.namespace)
+	err := jobs.Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) configureCronJob(
+	appName, deploymentName string,
+	annotations k8sannotations.Annotation,
+	workloadSpec *workloadSpec,
+	containers []specs.ContainerSpec,
+	parallelism *int32,
+) error {
+	logger.Debugf("creating/updating cron job for %s", appName)
+
+	jobSpec, err := k.jobTemplate(appName, deploymentName, annotations, workloadSpec, containers, parallelism)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	job := workloadSpec.Job
+	cronJob := &batchv1beta1.CronJob{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        deploymentName,
+			Labels:      map[string]string{labelApplication: appName},
+			Annotations: annotations.ToMap(),
+		},
+		Spec: batchv1beta1.CronJobSpec{
+			Schedule:                   job.Schedule,
+			ConcurrencyPolicy:          job.ConcurrencyPolicy,
+			StartingDeadlineSeconds:    job.StartingDeadlineSeconds,
+			Suspend:                    job.Suspend,
+			SuccessfulJobsHistoryLimit: job.SuccessfulJobsHistoryLimit,
+			FailedJobsHistoryLimit:     job.FailedJobsHistoryLimit,
+			JobTemplate: batchv1beta1.JobTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					Labels: map[string]string{labelApplication: appName},
+				},
+				Spec: *jobSpec,
+			},
+		},
+	}
+	return k.ensureCronJob(cronJob)
+}
+
+func (k *kubernetesClient) ensureCronJob(spec *batchv1beta1.CronJob) error {
+	api := k.client().BatchV1beta1().CronJobs(k.namespace)
+	_, err := api.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = api.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteCronJob(name string) error {
+	cronJobs := k.client().BatchV1beta1().CronJobs(k.namespace)
+	err := cronJobs.Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
+
+// stopJobs stops any job or cron job for the application from running
+// more pods.
+func (k *kubernetesClient) stopJobs(deploymentName string) error {
+	cronJobs := k.client().BatchV1beta1().CronJobs(k.namespace)
+	cronJob, err := cronJobs.Get(deploymentName, v1.GetOptions{IncludeUninitialized: true})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if err == nil {
+		cronJob.Spec.Suspend = boolPtr(true)
+		_, err = cronJobs.Update(cronJob)
+		return errors.Trace(err)
+	}
+
+	jobs := k.client().BatchV1().Jobs(k.namespace)
+	job, err := jobs.Get(deploymentName, v1.GetOptions{IncludeUninitialized: true})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	zero := int32(0)
+	job.Spec.Parallelism = &zero
+	_, err = jobs.Update(job)
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) getJobStatus(job *batchv1.Job) (string, status.Status, error) {
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != core.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobFailed:
+			return cond.Message, status.Error, nil
+		case batchv1.JobComplete:
+			return fmt.Sprintf("completed %d pods", job.Status.Succeeded), status.Active, nil
+		}
+	}
+	jujuStatus := status.Waiting
+	if job.DeletionTimestamp != nil {
+		jujuStatus = status.Terminated
+	} else if job.Status.Active > 0 {
+		jujuStatus = status.Active
+	}
+	return k.getStatusFromEvents(job.Name, "Job", jujuStatus)
+}
+
+func (k *kubernetesClient) getCronJobStatus(cronJob *batchv1beta1.CronJob) (string, status.Status, error) {
+	if cronJob.DeletionTimestamp != nil {
+		return "", status.Terminated, nil
+	}
+	if cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend {
+		return "suspended", status.Waiting, nil
+	}
+	message := "waiting for schedule " + cronJob.Spec.Schedule
+	if cronJob.Status.LastScheduleTime != nil {
+		message = fmt.Sprintf("last run at %s", cronJob.Status.LastScheduleTime.UTC().Format("2006-01-02 15:04:05Z"))
+	}
+	return message, status.Active, nil
+}
+
+// getJobService fills in the scale and status of an application
+// deployed as a job or cron job, if it is.
+func (k *kubernetesClient) getJobService(deploymentName string, result *caas.Service) error {
+	var (
+		parallelism *int32
+		generation  int64
+		message     string
+		jobStatus   status.Status
+	)
+	cronJobs := k.client().BatchV1beta1().CronJobs(k.namespace)
+	cronJob, err := cronJobs.Get(deploymentName, v1.GetOptions{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Trace(err)
+	}
+	if err == nil {
+		parallelism = cronJob.Spec.JobTemplate.Spec.Parallelism
+		generation = cronJob.GetGeneration()
+		if message, jobStatus, err = k.getCronJobStatus(cronJob); err != nil {
+			return errors.Annotatef(err, "getting status for %s", cronJob.Name)
+		}
+	} else {
+		jobs := k.client().BatchV1().Jobs(k.namespace)
+		job, err := jobs.Get(deploymentName, v1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		parallelism = job.Spec.Parallelism
+		generation = job.GetGeneration()
+		if message, jobStatus, err = k.getJobStatus(job); err != nil {
+			return errors.Annotatef(err, "getting status for %s", job.Name)
+		}
+	}
+	if parallelism != nil {
+		scale := int(*parallelism)
+		result.Scale = &scale
+	}
+	result.Generation = &generation
+	result.Status = status.StatusInfo{
+		Status:  jobStatus,
+		Message: message,
+	}
+	return nil
+}
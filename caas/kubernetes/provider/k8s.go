@@ -71,6 +71,10 @@ const (
 
 	gpuAffinityNodeSelectorKey = "gpu"
 
+	// gpuResourceName is the name of the resource the NVIDIA device
+	// plugin advertises nodes' GPUs as.
+	gpuResourceName = "nvidia.com/gpu"
+
 	annotationPrefix = "juju.io"
 
 	operatorContainerName = "juju-operator"
@@ -131,6 +135,9 @@ type kubernetesClient struct {
 //go:generate mockgen -package mocks -destination mocks/k8sclient_mock.go k8s.io/client-go/kubernetes Interface
 //go:generate mockgen -package mocks -destination mocks/appv1_mock.go k8s.io/client-go/kubernetes/typed/apps/v1 AppsV1Interface,DeploymentInterface,StatefulSetInterface
 //go:generate mockgen -package mocks -destination mocks/corev1_mock.go k8s.io/client-go/kubernetes/typed/core/v1 EventInterface,CoreV1Interface,NamespaceInterface,PodInterface,ServiceInterface,ConfigMapInterface,PersistentVolumeInterface,PersistentVolumeClaimInterface,SecretInterface,NodeInterface
+//go:generate mockgen -package mocks -destination mocks/batchv1_mock.go k8s.io/client-go/kubernetes/typed/batch/v1 BatchV1Interface,JobInterface
+//go:generate mockgen -package mocks -destination mocks/batchv1beta1_mock.go k8s.io/client-go/kubernetes/typed/batch/v1beta1 BatchV1beta1Interface,CronJobInterface
+//go:generate mockgen -package mocks -destination mocks/networkingv1_mock.go k8s.io/client-go/kubernetes/typed/networking/v1 NetworkingV1Interface,NetworkPolicyInterface
 //go:generate mockgen -package mocks -destination mocks/extenstionsv1_mock.go k8s.io/client-go/kubernetes/typed/extensions/v1beta1 ExtensionsV1beta1Interface,IngressInterface
 //go:generate mockgen -package mocks -destination mocks/storagev1_mock.go k8s.io/client-go/kubernetes/typed/storage/v1 StorageV1Interface,StorageClassInterface
 //go:generate mockgen -package mocks -destination mocks/rbacv1_mock.go k8s.io/client-go/kubernetes/typed/rbac/v1 RbacV1Interface,ClusterRoleBindingInterface,ClusterRoleInterface,RoleInterface,RoleBindingInterface
@@ -738,24 +745,28 @@ func (k *kubernetesClient) GetService(appName string, includeClusterIP bool) (*c
 
 	deployments := k.client().AppsV1().Deployments(k.namespace)
 	deployment, err := deployments.Get(deploymentName, v1.GetOptions{})
-	if err != nil && !k8serrors.IsNotFound(err) {
+	if k8serrors.IsNotFound(err) {
+		if err := k.getJobService(deploymentName, &result); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &result, nil
+	}
+	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	if err == nil {
-		if deployment.Spec.Replicas != nil {
-			scale := int(*deployment.Spec.Replicas)
-			result.Scale = &scale
-		}
-		gen := deployment.GetGeneration()
-		result.Generation = &gen
-		message, ssStatus, err := k.getDeploymentStatus(deployment)
-		if err != nil {
-			return nil, errors.Annotatef(err, "getting status for %s", ss.Name)
-		}
-		result.Status = status.StatusInfo{
-			Status:  ssStatus,
-			Message: message,
-		}
+	if deployment.Spec.Replicas != nil {
+		scale := int(*deployment.Spec.Replicas)
+		result.Scale = &scale
+	}
+	gen := deployment.GetGeneration()
+	result.Generation = &gen
+	message, ssStatus, err := k.getDeploymentStatus(deployment)
+	if err != nil {
+		return nil, errors.Annotatef(err, "getting status for %s", ss.Name)
+	}
+	result.Status = status.StatusInfo{
+		Status:  ssStatus,
+		Message: message,
 	}
 	return &result, nil
 }
@@ -777,6 +788,15 @@ func (k *kubernetesClient) DeleteService(appName string) (err error) {
 	if err := k.deleteDeployment(deploymentName); err != nil {
 		return errors.Trace(err)
 	}
+	if err := k.deleteJob(deploymentName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.deleteCronJob(deploymentName); err != nil {
+		return errors.Trace(err)
+	}
+	if err := k.deleteNetworkPolicy(deploymentName); err != nil {
+		return errors.Trace(err)
+	}
 	if err := k.deleteSecrets(appName); err != nil {
 		return errors.Trace(err)
 	}
@@ -824,32 +844,49 @@ func processConstraints(pod *core.PodSpec, appName string, cons constraints.Valu
 			return errors.Annotatef(err, "configuring cpu constraint for %s", appName)
 		}
 	}
+	if cores := cons.CpuCores; cores != nil {
+		if err := configureConstraint(pod, "cpu", fmt.Sprintf("%d", *cores)); err != nil {
+			return errors.Annotatef(err, "configuring cores constraint for %s", appName)
+		}
+	}
+	if cons.HasGPUs() {
+		if err := configureConstraint(pod, gpuResourceName, fmt.Sprintf("%d", *cons.GPUs)); err != nil {
+			return errors.Annotatef(err, "configuring gpus constraint for %s", appName)
+		}
+	}
+	if cons.HasGPUType() {
+		// Any device constraints have already selected the node's
+		// accelerator; the two must agree.
+		nodeSelector := buildNodeSelector(*cons.GPUType)
+		for key, value := range nodeSelector {
+			if existing, ok := pod.NodeSelector[key]; ok && existing != value {
+				return errors.NotValidf("gpu-type %q for %s with device constraints for %q", value, appName, existing)
+			}
+			if pod.NodeSelector == nil {
+				pod.NodeSelector = make(map[string]string)
+			}
+			pod.NodeSelector[key] = value
+		}
+	}
 
-	// Translate tags to node affinity.
+	// Translate tags to node selectors, node affinity and tolerations.
 	if cons.Tags != nil {
-		affinityLabels := *cons.Tags
-		var (
-			affinityTags     = make(map[string]string)
-			antiAffinityTags = make(map[string]string)
-		)
-		for _, labelPair := range affinityLabels {
-			parts := strings.Split(labelPair, "=")
-			if len(parts) != 2 {
-				return errors.Errorf("invalid node affinity constraints: %v", affinityLabels)
+		tags, err := parseNodeTags(*cons.Tags)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for key, value := range tags.selector {
+			if existing, ok := pod.NodeSelector[key]; ok && existing != value {
+				return errors.NotValidf("tag %s=%s for %s with node selector %s=%s", key, value, appName, key, existing)
 			}
-			key := strings.Trim(parts[0], " ")
-			value := strings.Trim(parts[1], " ")
-			if strings.HasPrefix(key, "^") {
-				if len(key) == 1 {
-					return errors.Errorf("invalid node affinity constraints: %v", affinityLabels)
-				}
-				antiAffinityTags[key[1:]] = value
-			} else {
-				affinityTags[key] = value
+			if pod.NodeSelector == nil {
+				pod.NodeSelector = make(map[string]string)
 			}
+			pod.NodeSelector[key] = value
 		}
+		pod.Tolerations = append(pod.Tolerations, tags.tolerations...)
 
-		updateSelectorTerms := func(nodeSelectorTerm *core.NodeSelectorTerm, tags map[string]string, op core.NodeSelectorOperator) {
+		updateSelectorTerms := func(nodeSelectorTerm *core.NodeSelectorTerm, tags map[string][]string, op core.NodeSelectorOperator) {
 			// Sort for stable ordering.
 			var keys []string
 			for k := range tags {
@@ -857,48 +894,62 @@ func processConstraints(pod *core.PodSpec, appName string, cons constraints.Valu
 			}
 			sort.Strings(keys)
 			for _, tag := range keys {
-				allValues := strings.Split(tags[tag], "|")
-				for i, v := range allValues {
-					allValues[i] = strings.Trim(v, " ")
-				}
 				nodeSelectorTerm.MatchExpressions = append(nodeSelectorTerm.MatchExpressions, core.NodeSelectorRequirement{
 					Key:      tag,
 					Operator: op,
-					Values:   allValues,
+					Values:   tags[tag],
 				})
 			}
 		}
 		var nodeSelectorTerm core.NodeSelectorTerm
-		updateSelectorTerms(&nodeSelectorTerm, affinityTags, core.NodeSelectorOpIn)
-		updateSelectorTerms(&nodeSelectorTerm, antiAffinityTags, core.NodeSelectorOpNotIn)
-		pod.Affinity = &core.Affinity{
-			NodeAffinity: &core.NodeAffinity{
-				RequiredDuringSchedulingIgnoredDuringExecution: &core.NodeSelector{
-					NodeSelectorTerms: []core.NodeSelectorTerm{nodeSelectorTerm},
+		updateSelectorTerms(&nodeSelectorTerm, tags.affinity, core.NodeSelectorOpIn)
+		updateSelectorTerms(&nodeSelectorTerm, tags.antiAffinity, core.NodeSelectorOpNotIn)
+		if len(nodeSelectorTerm.MatchExpressions) > 0 {
+			pod.Affinity = &core.Affinity{
+				NodeAffinity: &core.NodeAffinity{
+					RequiredDuringSchedulingIgnoredDuringExecution: &core.NodeSelector{
+						NodeSelectorTerms: []core.NodeSelectorTerm{nodeSelectorTerm},
+					},
 				},
-			},
+			}
 		}
 	}
 	if cons.Zones != nil {
 		zones := *cons.Zones
+		if pod.Affinity == nil {
+			pod.Affinity = &core.Affinity{}
+		}
 		affinity := pod.Affinity
-		if affinity == nil {
-			affinity = &core.Affinity{
-				NodeAffinity: &core.NodeAffinity{
-					RequiredDuringSchedulingIgnoredDuringExecution: &core.NodeSelector{
-						NodeSelectorTerms: []core.NodeSelectorTerm{{}},
-					},
+		if affinity.NodeAffinity == nil {
+			affinity.NodeAffinity = &core.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &core.NodeSelector{
+					NodeSelectorTerms: []core.NodeSelectorTerm{{}},
 				},
 			}
-			pod.Affinity = affinity
 		}
 		nodeSelector := &affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms[0]
 		nodeSelector.MatchExpressions = append(nodeSelector.MatchExpressions,
 			core.NodeSelectorRequirement{
-				Key:      "failure-domain.beta.kubernetes.io/zone",
+				Key:      zoneLabelName,
 				Operator: core.NodeSelectorOpIn,
 				Values:   zones,
 			})
+		if len(zones) > 1 {
+			// Spread the application's pods across the zones.
+			// TODO(caas): use a topology spread constraint once
+			// the k8s API we build against supports them.
+			affinity.PodAntiAffinity = &core.PodAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []core.WeightedPodAffinityTerm{{
+					Weight: 100,
+					PodAffinityTerm: core.PodAffinityTerm{
+						LabelSelector: &v1.LabelSelector{
+							MatchLabels: map[string]string{labelApplication: appName},
+						},
+						TopologyKey: zoneLabelName,
+					},
+				}},
+			}
+		}
 	}
 	return nil
 }
@@ -929,6 +980,14 @@ func (k *kubernetesClient) EnsureService(
 	if params == nil || params.PodSpec == nil {
 		return errors.Errorf("missing pod spec")
 	}
+	if len(params.RegistryCredentials) > 0 {
+		// Copy the params so the caller's pod spec isn't changed.
+		withCreds := *params
+		podSpec := *params.PodSpec
+		podSpec.Containers = withRegistryCredentials(podSpec.Containers, params.RegistryCredentials)
+		withCreds.PodSpec = &podSpec
+		params = &withCreds
+	}
 
 	var cleanups []func()
 	defer func() {
@@ -945,6 +1004,9 @@ func (k *kubernetesClient) EnsureService(
 	if err != nil {
 		return errors.Annotatef(err, "parsing unit spec for %s", appName)
 	}
+	if workloadSpec.Job != nil && len(params.Filesystems) > 0 {
+		return errors.NotSupportedf("storage for application %q run as a job", appName)
+	}
 
 	annotations := resourceTagsToAnnotations(params.ResourceTags)
 
@@ -1016,6 +1078,32 @@ func (k *kubernetesClient) EnsureService(
 		cleanups = append(cleanups, func() { k.deleteSecret(imageSecretName, "") })
 	}
 
+	// Restrict the traffic reaching the pods before they are started.
+	if params.NetworkPolicy != nil {
+		if err := k.ensureNetworkPolicy(appName, deploymentName, annotations.Copy(), *params.NetworkPolicy); err != nil {
+			return errors.Annotate(err, "creating or updating network policy")
+		}
+	}
+
+	// Batch workloads run their pods to completion, so they are
+	// deployed as a job, or a cron job if they are scheduled, and have
+	// no service in front of them.
+	if workloadSpec.Job != nil {
+		parallelism := int32(numUnits)
+		if workloadSpec.Job.Scheduled() {
+			if err := k.configureCronJob(appName, deploymentName, annotations.Copy(), workloadSpec, params.PodSpec.Containers, &parallelism); err != nil {
+				return errors.Annotate(err, "creating or updating CronJob")
+			}
+			cleanups = append(cleanups, func() { k.deleteCronJob(deploymentName) })
+		} else {
+			if err := k.configureJob(appName, deploymentName, annotations.Copy(), workloadSpec, params.PodSpec.Containers, &parallelism); err != nil {
+				return errors.Annotate(err, "creating or updating Job")
+			}
+			cleanups = append(cleanups, func() { k.deleteJob(deploymentName) })
+		}
+		return nil
+	}
+
 	// Add a deployment controller or stateful set configured to create the specified number of units/pods.
 	// Defensively check to see if a stateful set is already used.
 	var useStatefulSet bool
@@ -1090,7 +1178,10 @@ func (k *kubernetesClient) EnsureService(
 			return errors.Annotate(err, "creating or updating headless service")
 		}
 		cleanups = append(cleanups, func() { k.deleteService(headlessServiceName(deploymentName)) })
-		if err := k.configureStatefulSet(appName, deploymentName, randPrefix, annotations.Copy(), workloadSpec, params.PodSpec.Containers, &numPods, params.Filesystems); err != nil {
+		if err := k.configureStatefulSet(
+			appName, deploymentName, randPrefix, annotations.Copy(), workloadSpec,
+			params.PodSpec.Containers, &numPods, params.Filesystems, params.PartitionedUpgrade,
+		); err != nil {
 			return errors.Annotate(err, "creating or updating StatefulSet")
 		}
 		cleanups = append(cleanups, func() { k.deleteDeployment(appName) })
@@ -1156,6 +1247,85 @@ func (k *kubernetesClient) Upgrade(appName string, vers version.Number) error {
 	return errors.Trace(err)
 }
 
+// AdvanceUpgrade is part of the caas.PartitionedUpgrader interface.
+func (k *kubernetesClient) AdvanceUpgrade(appName string) (bool, error) {
+	deploymentName := k.deploymentName(appName)
+	statefulsets := k.client().AppsV1().StatefulSets(k.namespace)
+	statefulSet, err := statefulsets.Get(deploymentName, v1.GetOptions{IncludeUninitialized: true})
+	if k8serrors.IsNotFound(err) {
+		// Only stateful sets are upgraded a pod at a time.
+		return true, nil
+	}
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	rollingUpdate := statefulSet.Spec.UpdateStrategy.RollingUpdate
+	if rollingUpdate == nil || rollingUpdate.Partition == nil || *rollingUpdate.Partition <= 0 {
+		return true, nil
+	}
+	if statefulSet.Status.ObservedGeneration < statefulSet.Generation {
+		// The update revision isn't known until the stateful set
+		// controller has seen the change.
+		return false, nil
+	}
+
+	replicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+	partition := *rollingUpdate.Partition
+	if partition > replicas {
+		partition = replicas
+	}
+	// The pods numbered from the partition upwards have been upgraded;
+	// carry on to the next pod once the last of them is ready. Pods
+	// which were already upgraded are passed over without waiting.
+	for partition > 0 {
+		if partition < replicas {
+			ready, err := k.podUpgraded(
+				fmt.Sprintf("%s-%d", deploymentName, partition), statefulSet.Status.UpdateRevision,
+			)
+			if err != nil {
+				return false, errors.Trace(err)
+			}
+			if !ready {
+				break
+			}
+		}
+		partition--
+	}
+	if partition == *rollingUpdate.Partition {
+		return false, nil
+	}
+	logger.Debugf("upgrading pods of %q from %d", appName, partition)
+	rollingUpdate.Partition = &partition
+	if _, err := statefulsets.Update(statefulSet); err != nil {
+		return false, errors.Trace(err)
+	}
+	return partition == 0, nil
+}
+
+// podUpgraded returns whether the named pod is running the given
+// revision of its stateful set and is ready.
+func (k *kubernetesClient) podUpgraded(podName, revision string) (bool, error) {
+	pod, err := k.client().CoreV1().Pods(k.namespace).Get(podName, v1.GetOptions{})
+	if k8serrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if pod.DeletionTimestamp != nil || pod.Labels[apps.StatefulSetRevisionLabel] != revision {
+		return false, nil
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == core.PodReady {
+			return cond.Status == core.ConditionTrue, nil
+		}
+	}
+	return false, nil
+}
+
 func (k *kubernetesClient) deleteAllPods(appName, deploymentName string) error {
 	zero := int32(0)
 	statefulsets := k.client().AppsV1().StatefulSets(k.namespace)
@@ -1172,7 +1342,7 @@ func (k *kubernetesClient) deleteAllPods(appName, deploymentName string) error {
 	deployments := k.client().AppsV1().Deployments(k.namespace)
 	deployment, err := deployments.Get(deploymentName, v1.GetOptions{IncludeUninitialized: true})
 	if k8serrors.IsNotFound(err) {
-		return nil
+		return errors.Trace(k.stopJobs(deploymentName))
 	}
 	if err != nil {
 		return errors.Trace(err)
@@ -1499,6 +1669,7 @@ func getPodManagementPolicy(svc *specs.ServiceSpec) (out apps.PodManagementPolic
 func (k *kubernetesClient) configureStatefulSet(
 	appName, deploymentName, randPrefix string, annotations k8sannotations.Annotation, workloadSpec *workloadSpec,
 	containers []specs.ContainerSpec, replicas *int32, filesystems []storage.KubernetesFilesystemParams,
+	partitioned bool,
 ) error {
 	logger.Debugf("creating/updating stateful set for %s", appName)
 
@@ -1529,6 +1700,17 @@ func (k *kubernetesClient) configureStatefulSet(
 			ServiceName:         headlessServiceName(deploymentName),
 		},
 	}
+	if partitioned {
+		// Hold back all of the existing pods; AdvanceUpgrade lowers
+		// the partition a pod at a time as each one becomes ready.
+		partition := *replicas
+		statefulset.Spec.UpdateStrategy = apps.StatefulSetUpdateStrategy{
+			Type: apps.RollingUpdateStatefulSetStrategyType,
+			RollingUpdate: &apps.RollingUpdateStatefulSetStrategy{
+				Partition: &partition,
+			},
+		}
+	}
 	podSpec := workloadSpec.Pod
 	if err := k.configurePodFiles(appName, annotations, &podSpec, containers, cfgName); err != nil {
 		return errors.Trace(err)
@@ -1567,6 +1749,13 @@ func (k *kubernetesClient) ensureStatefulSet(spec *apps.StatefulSet, existingPod
 	existing.Spec.Template.Spec.Containers = existingPodSpec.Containers
 	existing.Spec.Template.Spec.ServiceAccountName = existingPodSpec.ServiceAccountName
 	existing.Spec.Template.Spec.AutomountServiceAccountToken = existingPodSpec.AutomountServiceAccountToken
+	if spec.Spec.UpdateStrategy.RollingUpdate != nil {
+		existing.Spec.UpdateStrategy = spec.Spec.UpdateStrategy
+	} else if existing.Spec.UpdateStrategy.RollingUpdate != nil {
+		// Any partition left over from an unfinished partitioned
+		// upgrade would hold back this change.
+		existing.Spec.UpdateStrategy.RollingUpdate.Partition = nil
+	}
 	// NB: we can't update the Spec.ServiceName as it is immutable.
 	_, err = api.Update(existing)
 	return errors.Trace(err)
@@ -1983,9 +2172,10 @@ func (k *kubernetesClient) WatchContainerStart(appName string, containerName str
 // WatchService returns a watcher which notifies when there
 // are changes to the deployment of the specified application.
 func (k *kubernetesClient) WatchService(appName string) (watcher.NotifyWatcher, error) {
-	// Application may be a statefulset or deployment. It may not have
-	// been set up when the watcher is started so we don't know which it
-	// is ahead of time. So use a multi-watcher to cover both cases.
+	// Application may be a statefulset, deployment, job or cron job.
+	// It may not have been set up when the watcher is started so we
+	// don't know which it is ahead of time. So use a multi-watcher to
+	// cover all cases.
 	statefulsets := k.client().AppsV1().StatefulSets(k.namespace)
 	sswatcher, err := statefulsets.Watch(v1.ListOptions{
 		LabelSelector: applicationSelector(appName),
@@ -2012,7 +2202,33 @@ func (k *kubernetesClient) WatchService(appName string) (watcher.NotifyWatcher,
 		return nil, errors.Trace(err)
 	}
 
-	return watcher.NewMultiNotifyWatcher(w1, w2), nil
+	jobs := k.client().BatchV1().Jobs(k.namespace)
+	jwatcher, err := jobs.Watch(v1.ListOptions{
+		LabelSelector: applicationSelector(appName),
+		Watch:         true,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w3, err := k.newWatcher(jwatcher, appName, k.clock)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	cronJobs := k.client().BatchV1beta1().CronJobs(k.namespace)
+	cjwatcher, err := cronJobs.Watch(v1.ListOptions{
+		LabelSelector: applicationSelector(appName),
+		Watch:         true,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w4, err := k.newWatcher(cjwatcher, appName, k.clock)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return watcher.NewMultiNotifyWatcher(w1, w2, w3, w4), nil
 }
 
 // legacyJujuPVNameRegexp matches how Juju labels persistent volumes.
@@ -2044,7 +2260,7 @@ func (k *kubernetesClient) Units(appName string) ([]caas.Unit, error) {
 			}
 		}
 		terminated := p.DeletionTimestamp != nil
-		statusMessage, unitStatus, since, err := k.getPODStatus(p, now)
+		unitStatus, err := k.getPODStatus(p, now)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -2055,11 +2271,7 @@ func (k *kubernetesClient) Units(appName string) ([]caas.Unit, error) {
 			Ports:    ports,
 			Dying:    terminated,
 			Stateful: stateful,
-			Status: status.StatusInfo{
-				Status:  unitStatus,
-				Message: statusMessage,
-				Since:   &since,
-			},
+			Status:   unitStatus,
 		}
 
 		volumesByName := make(map[string]core.Volume)
@@ -2234,7 +2446,11 @@ func (k *kubernetesClient) volumeInfoForPVC(vol core.Volume, volMount core.Volum
 	}, nil
 }
 
-func (k *kubernetesClient) getPODStatus(pod core.Pod, now time.Time) (string, status.Status, time.Time, error) {
+// getPODStatus returns the status of the pod. If the pod isn't running
+// and its events explain why, such as its image failing to pull, the
+// most recent of those is used for the message and they are all
+// recorded in the status data.
+func (k *kubernetesClient) getPODStatus(pod core.Pod, now time.Time) (status.StatusInfo, error) {
 	terminated := pod.DeletionTimestamp != nil
 	jujuStatus := k.jujuStatus(pod.Status.Phase, terminated)
 	statusMessage := pod.Status.Message
@@ -2250,20 +2466,47 @@ func (k *kubernetesClient) getPODStatus(pod core.Pod, now time.Time) (string, st
 		}
 	}
 
-	if statusMessage == "" {
-		// If there are any events for this pod we can use the
-		// most recent to set the status.
+	var data map[string]interface{}
+	// A message for the pod itself, such as why it was evicted, is
+	// more telling than its events, but those of its conditions
+	// aren't when it is stuck starting.
+	if pod.Status.Message == "" && (statusMessage == "" || !terminated && !podStarted(pod)) {
 		eventList, err := k.getEvents(pod.Name, "Pod")
 		if err != nil {
-			return "", "", time.Time{}, errors.Trace(err)
+			return status.StatusInfo{}, errors.Trace(err)
 		}
-		// Take the most recent event.
-		if count := len(eventList); count > 0 {
+		if warnings := podWarningEvents(eventList); len(warnings) > 0 {
+			statusMessage = warnings[0].Message
+			data = map[string]interface{}{
+				status.KubernetesEventsKey: status.KubernetesEventsData(warnings),
+			}
+		} else if count := len(eventList); count > 0 && statusMessage == "" {
+			// If there are any events for this pod we can use the
+			// most recent to set the status.
 			statusMessage = eventList[count-1].Message
 		}
 	}
 
-	return statusMessage, jujuStatus, since, nil
+	return status.StatusInfo{
+		Status:  jujuStatus,
+		Message: statusMessage,
+		Data:    data,
+		Since:   &since,
+	}, nil
+}
+
+// podStarted returns whether the pod is running with all its containers
+// ready.
+func podStarted(pod core.Pod) bool {
+	if pod.Status.Phase != core.PodRunning {
+		return false
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if !cs.Ready {
+			return false
+		}
+	}
+	return true
 }
 
 func (k *kubernetesClient) getStatefulSetStatus(ss *apps.StatefulSet) (string, status.Status, error) {
@@ -2380,6 +2623,9 @@ type workloadSpec struct {
 	ServiceAccounts           []serviceAccountSpecGetter
 	CustomResourceDefinitions map[string]apiextensionsv1beta1.CustomResourceDefinitionSpec
 	CustomResources           map[string][]unstructured.Unstructured
+
+	// Job, if set, runs the workload as a job or cron job.
+	Job *k8sspecs.JobSpec
 }
 
 func processContainers(deploymentName string, podSpec *specs.PodSpec, spec *core.PodSpec) error {
@@ -2451,6 +2697,7 @@ func prepareWorkloadSpec(appName, deploymentName string, podSpec *specs.PodSpec,
 			spec.Secrets = k8sResources.Secrets
 			spec.CustomResourceDefinitions = k8sResources.CustomResourceDefinitions
 			spec.CustomResources = k8sResources.CustomResources
+			spec.Job = k8sResources.Job
 			if k8sResources.Pod != nil {
 				spec.Pod.ActiveDeadlineSeconds = k8sResources.Pod.ActiveDeadlineSeconds
 				spec.Pod.TerminationGracePeriodSeconds = k8sResources.Pod.TerminationGracePeriodSeconds
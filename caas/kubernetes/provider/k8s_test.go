@@ -17,7 +17,10 @@ import (
 	"gopkg.in/juju/worker.v1/workertest"
 	apps "k8s.io/api/apps/v1"
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	core "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	k8sstorage "k8s.io/api/storage/v1"
 	storagev1 "k8s.io/api/storage/v1"
@@ -391,6 +394,104 @@ func (s *K8sSuite) TestPrepareWorkloadSpecConfigPairs(c *gc.C) {
 	})
 }
 
+func (s *K8sSuite) TestProcessConstraintsGPUs(c *gc.C) {
+	pod := core.PodSpec{Containers: []core.Container{{Name: "test"}}}
+	err := provider.ProcessConstraints(&pod, "app-name", constraints.MustParse("gpus=2 gpu-type=nvidia-tesla-p100"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pod.Containers[0].Resources.Limits, jc.DeepEquals, core.ResourceList{
+		"nvidia.com/gpu": resource.MustParse("2"),
+	})
+	c.Assert(pod.NodeSelector, jc.DeepEquals, map[string]string{"accelerator": "nvidia-tesla-p100"})
+}
+
+func (s *K8sSuite) TestProcessConstraintsGPUTypeConflictsWithDevices(c *gc.C) {
+	pod := core.PodSpec{
+		Containers:   []core.Container{{Name: "test"}},
+		NodeSelector: map[string]string{"accelerator": "nvidia-tesla-k80"},
+	}
+	err := provider.ProcessConstraints(&pod, "app-name", constraints.MustParse("gpu-type=nvidia-tesla-p100"))
+	c.Assert(err, gc.ErrorMatches, `gpu-type "nvidia-tesla-p100" for app-name with device constraints for "nvidia-tesla-k80" not valid`)
+}
+
+func (s *K8sSuite) TestProcessConstraintsCores(c *gc.C) {
+	pod := core.PodSpec{Containers: []core.Container{{Name: "test"}, {Name: "sidecar"}}}
+	err := provider.ProcessConstraints(&pod, "app-name", constraints.MustParse("cores=2 mem=1G"))
+	c.Assert(err, jc.ErrorIsNil)
+	for _, container := range pod.Containers {
+		c.Check(container.Resources.Limits, jc.DeepEquals, core.ResourceList{
+			"cpu":    resource.MustParse("2"),
+			"memory": resource.MustParse("1024Mi"),
+		})
+	}
+}
+
+func (s *K8sSuite) TestProcessConstraintsTags(c *gc.C) {
+	pod := core.PodSpec{Containers: []core.Container{{Name: "test"}}}
+	err := provider.ProcessConstraints(&pod, "app-name", constraints.MustParse(
+		"tags=disk=ssd,arch=amd64|arm64,^pool=spot,toleration:dedicated=db:NoSchedule,toleration:gpu"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(pod.NodeSelector, jc.DeepEquals, map[string]string{"disk": "ssd"})
+	c.Check(pod.Tolerations, jc.DeepEquals, []core.Toleration{{
+		Key:      "dedicated",
+		Operator: core.TolerationOpEqual,
+		Value:    "db",
+		Effect:   core.TaintEffectNoSchedule,
+	}, {
+		Key:      "gpu",
+		Operator: core.TolerationOpExists,
+	}})
+	c.Check(pod.Affinity, jc.DeepEquals, &core.Affinity{
+		NodeAffinity: &core.NodeAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: &core.NodeSelector{
+				NodeSelectorTerms: []core.NodeSelectorTerm{{
+					MatchExpressions: []core.NodeSelectorRequirement{{
+						Key:      "arch",
+						Operator: core.NodeSelectorOpIn,
+						Values:   []string{"amd64", "arm64"},
+					}, {
+						Key:      "pool",
+						Operator: core.NodeSelectorOpNotIn,
+						Values:   []string{"spot"},
+					}},
+				}},
+			},
+		},
+	})
+}
+
+func (s *K8sSuite) TestProcessConstraintsTagConflictsWithNodeSelector(c *gc.C) {
+	pod := core.PodSpec{
+		Containers:   []core.Container{{Name: "test"}},
+		NodeSelector: map[string]string{"accelerator": "nvidia-tesla-k80"},
+	}
+	err := provider.ProcessConstraints(&pod, "app-name", constraints.MustParse("tags=accelerator=nvidia-tesla-p100"))
+	c.Assert(err, gc.ErrorMatches, `tag accelerator=nvidia-tesla-p100 for app-name with node selector accelerator=nvidia-tesla-k80 not valid`)
+}
+
+func (s *K8sSuite) TestProcessConstraintsInvalidToleration(c *gc.C) {
+	pod := core.PodSpec{Containers: []core.Container{{Name: "test"}}}
+	err := provider.ProcessConstraints(&pod, "app-name", constraints.MustParse("tags=toleration:dedicated=db:Never"))
+	c.Assert(err, gc.ErrorMatches, `taint effect "Never" not valid`)
+	err = provider.ProcessConstraints(&pod, "app-name", constraints.MustParse("tags=toleration:=db"))
+	c.Assert(err, gc.ErrorMatches, `toleration "=db" not valid`)
+}
+
+func (s *K8sSuite) TestProcessConstraintsSingleZone(c *gc.C) {
+	pod := core.PodSpec{Containers: []core.Container{{Name: "test"}}}
+	err := provider.ProcessConstraints(&pod, "app-name", constraints.MustParse("zones=a"))
+	c.Assert(err, jc.ErrorIsNil)
+	// There's nothing to spread pods across.
+	c.Check(pod.Affinity.PodAntiAffinity, gc.IsNil)
+	c.Check(pod.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms, jc.DeepEquals,
+		[]core.NodeSelectorTerm{{
+			MatchExpressions: []core.NodeSelectorRequirement{{
+				Key:      "failure-domain.beta.kubernetes.io/zone",
+				Operator: core.NodeSelectorOpIn,
+				Values:   []string{"a"},
+			}},
+		}})
+}
+
 type K8sBrokerSuite struct {
 	BaseSuite
 }
@@ -822,6 +923,12 @@ func (s *K8sBrokerSuite) TestDeleteServiceForApplication(c *gc.C) {
 			Return(s.k8sNotFoundError()),
 		s.mockDeployments.EXPECT().Delete("test", s.deleteOptions(v1.DeletePropagationForeground, "")).
 			Return(s.k8sNotFoundError()),
+		s.mockJobs.EXPECT().Delete("test", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockCronJobs.EXPECT().Delete("test", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
+		s.mockNetworkPolicies.EXPECT().Delete("test", s.deleteOptions(v1.DeletePropagationForeground, "")).
+			Return(s.k8sNotFoundError()),
 
 		// delete secrets.
 		s.mockSecrets.EXPECT().DeleteCollection(
@@ -1019,6 +1126,305 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorage(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *K8sBrokerSuite) jobPodSpec(c *gc.C, podSpec *specs.PodSpec) core.PodSpec {
+	workloadSpec, err := provider.PrepareWorkloadSpec("app-name", "app-name", podSpec, "operator/image-path")
+	c.Assert(err, jc.ErrorIsNil)
+	spec := provider.PodSpec(workloadSpec)
+	spec.RestartPolicy = core.RestartPolicyOnFailure
+	return spec
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceJob(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	basicPodSpec := getBasicPodspec()
+	basicPodSpec.ProviderPod = &k8sspecs.K8sPodSpec{
+		KubernetesResources: &k8sspecs.KubernetesResources{
+			Job: &k8sspecs.JobSpec{
+				Completions:  int32Ptr(4),
+				BackoffLimit: int32Ptr(2),
+			},
+		},
+	}
+	podSpec := s.jobPodSpec(c, basicPodSpec)
+
+	jobArg := &batchv1.Job{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name",
+			Labels: map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{
+				"fred":               "mary",
+				"juju.io/controller": testing.ControllerTag.Id(),
+			}},
+		Spec: batchv1.JobSpec{
+			Parallelism:  int32Ptr(2),
+			Completions:  int32Ptr(4),
+			BackoffLimit: int32Ptr(2),
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					Labels: map[string]string{"juju-app": "app-name"},
+					Annotations: map[string]string{
+						"apparmor.security.beta.kubernetes.io/pod": "runtime/default",
+						"seccomp.security.beta.kubernetes.io/pod":  "docker/default",
+						"fred":               "mary",
+						"juju.io/controller": testing.ControllerTag.Id(),
+					},
+				},
+				Spec: podSpec,
+			},
+		},
+	}
+
+	ociImageSecret := s.getOCIImageSecret(c, map[string]string{"fred": "mary"})
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Create(ociImageSecret).
+			Return(ociImageSecret, nil),
+		s.mockJobs.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockJobs.EXPECT().Create(jobArg).
+			Return(nil, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec:           basicPodSpec,
+		OperatorImagePath: "operator/image-path",
+		ResourceTags: map[string]string{
+			"juju-controller-uuid": testing.ControllerTag.Id(),
+			"fred":                 "mary",
+		},
+	}
+	err := s.broker.EnsureService("app-name", nil, params, 2, application.ConfigAttributes{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceJobUpdatesParallelism(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	basicPodSpec := getBasicPodspec()
+	basicPodSpec.ProviderPod = &k8sspecs.K8sPodSpec{
+		KubernetesResources: &k8sspecs.KubernetesResources{
+			Job: &k8sspecs.JobSpec{},
+		},
+	}
+
+	existing := &batchv1.Job{
+		ObjectMeta: v1.ObjectMeta{Name: "app-name"},
+		Spec: batchv1.JobSpec{
+			Parallelism: int32Ptr(1),
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					Labels: map[string]string{"juju-app": "app-name", "controller-uid": "uid"},
+				},
+			},
+		},
+	}
+	updated := *existing
+	updated.Spec.Parallelism = int32Ptr(3)
+
+	ociImageSecret := s.getOCIImageSecret(c, nil)
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Create(ociImageSecret).
+			Return(ociImageSecret, nil),
+		s.mockJobs.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(existing, nil),
+		s.mockJobs.EXPECT().Update(&updated).
+			Return(&updated, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec:           basicPodSpec,
+		OperatorImagePath: "operator/image-path",
+		ResourceTags: map[string]string{
+			"juju-controller-uuid": testing.ControllerTag.Id(),
+		},
+	}
+	err := s.broker.EnsureService("app-name", nil, params, 3, application.ConfigAttributes{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceCronJob(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	basicPodSpec := getBasicPodspec()
+	basicPodSpec.ProviderPod = &k8sspecs.K8sPodSpec{
+		KubernetesResources: &k8sspecs.KubernetesResources{
+			Job: &k8sspecs.JobSpec{
+				Schedule:               "@hourly",
+				ConcurrencyPolicy:      batchv1beta1.ForbidConcurrent,
+				FailedJobsHistoryLimit: int32Ptr(1),
+			},
+		},
+	}
+	podSpec := s.jobPodSpec(c, basicPodSpec)
+
+	cronJobArg := &batchv1beta1.CronJob{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   "app-name",
+			Labels: map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{
+				"juju.io/controller": testing.ControllerTag.Id(),
+			}},
+		Spec: batchv1beta1.CronJobSpec{
+			Schedule:               "@hourly",
+			ConcurrencyPolicy:      batchv1beta1.ForbidConcurrent,
+			FailedJobsHistoryLimit: int32Ptr(1),
+			JobTemplate: batchv1beta1.JobTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					Labels: map[string]string{"juju-app": "app-name"},
+				},
+				Spec: batchv1.JobSpec{
+					Parallelism: int32Ptr(1),
+					Template: core.PodTemplateSpec{
+						ObjectMeta: v1.ObjectMeta{
+							Labels: map[string]string{"juju-app": "app-name"},
+							Annotations: map[string]string{
+								"apparmor.security.beta.kubernetes.io/pod": "runtime/default",
+								"seccomp.security.beta.kubernetes.io/pod":  "docker/default",
+								"juju.io/controller":                       testing.ControllerTag.Id(),
+							},
+						},
+						Spec: podSpec,
+					},
+				},
+			},
+		},
+	}
+
+	ociImageSecret := s.getOCIImageSecret(c, nil)
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Create(ociImageSecret).
+			Return(ociImageSecret, nil),
+		s.mockCronJobs.EXPECT().Update(cronJobArg).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockCronJobs.EXPECT().Create(cronJobArg).
+			Return(nil, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec:           basicPodSpec,
+		OperatorImagePath: "operator/image-path",
+		ResourceTags: map[string]string{
+			"juju-controller-uuid": testing.ControllerTag.Id(),
+		},
+	}
+	err := s.broker.EnsureService("app-name", nil, params, 1, application.ConfigAttributes{})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceJobWithStorage(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	basicPodSpec := getBasicPodspec()
+	basicPodSpec.ProviderPod = &k8sspecs.K8sPodSpec{
+		KubernetesResources: &k8sspecs.KubernetesResources{
+			Job: &k8sspecs.JobSpec{},
+		},
+	}
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec:           basicPodSpec,
+		OperatorImagePath: "operator/image-path",
+		Filesystems: []storage.KubernetesFilesystemParams{{
+			StorageName: "database",
+			Size:        100,
+			Provider:    "kubernetes",
+			Attachment: &storage.KubernetesFilesystemAttachmentParams{
+				Path: "path/to/here",
+			},
+		}},
+	}
+	err := s.broker.EnsureService("app-name", func(_ string, _ status.Status, _ string, _ map[string]interface{}) error { return nil }, params, 1, nil)
+	c.Assert(err, gc.ErrorMatches, `storage for application "app-name" run as a job not supported`)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceNoUnitsSuspendsCronJob(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	cronJob := &batchv1beta1.CronJob{
+		ObjectMeta: v1.ObjectMeta{Name: "app-name"},
+		Spec:       batchv1beta1.CronJobSpec{Schedule: "@hourly"},
+	}
+	suspended := *cronJob
+	suspended.Spec.Suspend = boolPtr(true)
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockCronJobs.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(cronJob, nil),
+		s.mockCronJobs.EXPECT().Update(&suspended).
+			Return(&suspended, nil),
+	)
+
+	params := &caas.ServiceParams{}
+	err := s.broker.EnsureService("app-name", nil, params, 0, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestGetServiceJob(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	job := &batchv1.Job{
+		ObjectMeta: v1.ObjectMeta{Name: "app-name", Generation: 1},
+		Spec:       batchv1.JobSpec{Parallelism: int32Ptr(2)},
+		Status: batchv1.JobStatus{
+			Succeeded: 4,
+			Conditions: []batchv1.JobCondition{{
+				Type:   batchv1.JobComplete,
+				Status: core.ConditionTrue,
+			}},
+		},
+	}
+	gomock.InOrder(
+		s.mockServices.EXPECT().List(v1.ListOptions{LabelSelector: "juju-app==app-name", IncludeUninitialized: true}).
+			Return(&core.ServiceList{}, nil),
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockCronJobs.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockJobs.EXPECT().Get("app-name", v1.GetOptions{}).
+			Return(job, nil),
+	)
+
+	svc, err := s.broker.GetService("app-name", false)
+	c.Assert(err, jc.ErrorIsNil)
+	scale := 2
+	gen := int64(1)
+	c.Assert(svc, jc.DeepEquals, &caas.Service{
+		Scale:      &scale,
+		Generation: &gen,
+		Status: status.StatusInfo{
+			Status:  status.Active,
+			Message: "completed 4 pods",
+		},
+	})
+}
+
 func (s *K8sBrokerSuite) TestEnsureServiceWithConfigMapAndSecretsCreate(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
@@ -1409,34 +1815,119 @@ password: shhhh`[1:],
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).
 			Return(nil, s.k8sNotFoundError()),
-		s.mockServices.EXPECT().Update(serviceArg).
+		s.mockServices.EXPECT().Update(serviceArg).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(serviceArg).
+			Return(nil, nil),
+		s.mockDeployments.EXPECT().Update(deploymentArg).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockDeployments.EXPECT().Create(deploymentArg).
+			Return(nil, nil),
+	)
+
+	params := &caas.ServiceParams{
+		PodSpec: basicPodSpec,
+		ResourceTags: map[string]string{
+			"juju-controller-uuid": testing.ControllerTag.Id(),
+			"fred":                 "mary",
+		},
+		OperatorImagePath: "operator/image-path",
+	}
+	err = s.broker.EnsureService("app-name", nil, params, 2, application.ConfigAttributes{
+		"kubernetes-service-type":            "nodeIP",
+		"kubernetes-service-loadbalancer-ip": "10.0.0.1",
+		"kubernetes-service-externalname":    "ext-name",
+		"kubernetes-service-annotations":     map[string]interface{}{"a": "b"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureServiceNoStorageStateful(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	basicPodSpec := getBasicPodspec()
+	basicPodSpec.Service = &specs.ServiceSpec{
+		ScalePolicy: "serial",
+	}
+	workloadSpec, err := provider.PrepareWorkloadSpec("app-name", "app-name", basicPodSpec, "operator/image-path")
+	c.Assert(err, jc.ErrorIsNil)
+	podSpec := provider.PodSpec(workloadSpec)
+
+	numUnits := int32(2)
+	statefulSetArg := &appsv1.StatefulSet{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "app-name",
+			Annotations: map[string]string{
+				"juju-app-uuid":      "appuuid",
+				"juju.io/controller": testing.ControllerTag.Id(),
+			},
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &numUnits,
+			Selector: &v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-app": "app-name"},
+			},
+			Template: core.PodTemplateSpec{
+				ObjectMeta: v1.ObjectMeta{
+					Labels: map[string]string{"juju-app": "app-name"},
+					Annotations: map[string]string{
+						"apparmor.security.beta.kubernetes.io/pod": "runtime/default",
+						"seccomp.security.beta.kubernetes.io/pod":  "docker/default",
+						"juju.io/controller":                       testing.ControllerTag.Id(),
+					},
+				},
+				Spec: podSpec,
+			},
+			PodManagementPolicy: apps.PodManagementPolicyType("OrderedReady"),
+			ServiceName:         "app-name-endpoints",
+		},
+	}
+
+	serviceArg := *basicServiceArg
+	serviceArg.Spec.Type = core.ServiceTypeClusterIP
+	ociImageSecret := s.getOCIImageSecret(c, nil)
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockSecrets.EXPECT().Create(ociImageSecret).
+			Return(ociImageSecret, nil),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Update(&serviceArg).
 			Return(nil, s.k8sNotFoundError()),
-		s.mockServices.EXPECT().Create(serviceArg).
+		s.mockServices.EXPECT().Create(&serviceArg).
 			Return(nil, nil),
-		s.mockDeployments.EXPECT().Update(deploymentArg).
+		s.mockServices.EXPECT().Get("app-name-endpoints", v1.GetOptions{IncludeUninitialized: true}).
 			Return(nil, s.k8sNotFoundError()),
-		s.mockDeployments.EXPECT().Create(deploymentArg).
+		s.mockServices.EXPECT().Update(basicHeadlessServiceArg).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockServices.EXPECT().Create(basicHeadlessServiceArg).
 			Return(nil, nil),
+		s.mockStatefulSets.EXPECT().Update(statefulSetArg).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Create(statefulSetArg).
+			Return(statefulSetArg, nil),
 	)
 
 	params := &caas.ServiceParams{
 		PodSpec: basicPodSpec,
-		ResourceTags: map[string]string{
-			"juju-controller-uuid": testing.ControllerTag.Id(),
-			"fred":                 "mary",
+		Deployment: caas.DeploymentParams{
+			DeploymentType: caas.DeploymentStateful,
 		},
 		OperatorImagePath: "operator/image-path",
+		ResourceTags:      map[string]string{"juju-controller-uuid": testing.ControllerTag.Id()},
 	}
 	err = s.broker.EnsureService("app-name", nil, params, 2, application.ConfigAttributes{
-		"kubernetes-service-type":            "nodeIP",
 		"kubernetes-service-loadbalancer-ip": "10.0.0.1",
 		"kubernetes-service-externalname":    "ext-name",
-		"kubernetes-service-annotations":     map[string]interface{}{"a": "b"},
 	})
 	c.Assert(err, jc.ErrorIsNil)
 }
 
-func (s *K8sBrokerSuite) TestEnsureServiceNoStorageStateful(c *gc.C) {
+func (s *K8sBrokerSuite) TestEnsureServicePartitionedUpgrade(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
 
@@ -1449,6 +1940,13 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorageStateful(c *gc.C) {
 	podSpec := provider.PodSpec(workloadSpec)
 
 	numUnits := int32(2)
+	partition := int32(2)
+	updateStrategy := appsv1.StatefulSetUpdateStrategy{
+		Type: appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{
+			Partition: &partition,
+		},
+	}
 	statefulSetArg := &appsv1.StatefulSet{
 		ObjectMeta: v1.ObjectMeta{
 			Name: "app-name",
@@ -1474,9 +1972,25 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorageStateful(c *gc.C) {
 				Spec: podSpec,
 			},
 			PodManagementPolicy: apps.PodManagementPolicyType("OrderedReady"),
+			UpdateStrategy:      updateStrategy,
 			ServiceName:         "app-name-endpoints",
 		},
 	}
+	existing := &appsv1.StatefulSet{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "app-name",
+			Annotations: map[string]string{"juju-app-uuid": "appuuid"},
+		},
+		Spec: appsv1.StatefulSetSpec{
+			UpdateStrategy: appsv1.StatefulSetUpdateStrategy{
+				Type: appsv1.RollingUpdateStatefulSetStrategyType,
+			},
+		},
+	}
+	updated := *existing
+	updated.Spec.Replicas = &numUnits
+	updated.Spec.Template.Spec.Containers = podSpec.Containers
+	updated.Spec.UpdateStrategy = updateStrategy
 
 	serviceArg := *basicServiceArg
 	serviceArg.Spec.Type = core.ServiceTypeClusterIP
@@ -1487,7 +2001,7 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorageStateful(c *gc.C) {
 		s.mockSecrets.EXPECT().Create(ociImageSecret).
 			Return(ociImageSecret, nil),
 		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).
-			Return(nil, s.k8sNotFoundError()),
+			Return(existing, nil),
 		s.mockServices.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).
 			Return(nil, s.k8sNotFoundError()),
 		s.mockServices.EXPECT().Update(&serviceArg).
@@ -1501,9 +2015,11 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorageStateful(c *gc.C) {
 		s.mockServices.EXPECT().Create(basicHeadlessServiceArg).
 			Return(nil, nil),
 		s.mockStatefulSets.EXPECT().Update(statefulSetArg).
-			Return(nil, s.k8sNotFoundError()),
-		s.mockStatefulSets.EXPECT().Create(statefulSetArg).
 			Return(statefulSetArg, nil),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(existing, nil),
+		s.mockStatefulSets.EXPECT().Update(&updated).
+			Return(&updated, nil),
 	)
 
 	params := &caas.ServiceParams{
@@ -1511,8 +2027,9 @@ func (s *K8sBrokerSuite) TestEnsureServiceNoStorageStateful(c *gc.C) {
 		Deployment: caas.DeploymentParams{
 			DeploymentType: caas.DeploymentStateful,
 		},
-		OperatorImagePath: "operator/image-path",
-		ResourceTags:      map[string]string{"juju-controller-uuid": testing.ControllerTag.Id()},
+		OperatorImagePath:  "operator/image-path",
+		ResourceTags:       map[string]string{"juju-controller-uuid": testing.ControllerTag.Id()},
+		PartitionedUpgrade: true,
 	}
 	err = s.broker.EnsureService("app-name", nil, params, 2, application.ConfigAttributes{
 		"kubernetes-service-loadbalancer-ip": "10.0.0.1",
@@ -3314,6 +3831,17 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithZones(c *gc.C) {
 				}},
 			},
 		},
+		PodAntiAffinity: &core.PodAntiAffinity{
+			PreferredDuringSchedulingIgnoredDuringExecution: []core.WeightedPodAffinityTerm{{
+				Weight: 100,
+				PodAffinityTerm: core.PodAffinityTerm{
+					LabelSelector: &v1.LabelSelector{
+						MatchLabels: map[string]string{"juju-app": "app-name"},
+					},
+					TopologyKey: "failure-domain.beta.kubernetes.io/zone",
+				},
+			}},
+		},
 	}
 	statefulSetArg := unitStatefulSetArg(2, "workload-storage", podSpec)
 	ociImageSecret := s.getOCIImageSecret(c, nil)
@@ -3372,12 +3900,108 @@ func (s *K8sBrokerSuite) TestEnsureServiceWithZones(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *K8sBrokerSuite) TestEnsureNetworkPolicyIsolated(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "app-name",
+			Labels:      map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{"juju.io/controller": testing.ControllerTag.Id()},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-app": "app-name"},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From: []networkingv1.NetworkPolicyPeer{{
+					PodSelector: &v1.LabelSelector{
+						MatchExpressions: []v1.LabelSelectorRequirement{{
+							Key:      "juju-app",
+							Operator: v1.LabelSelectorOpIn,
+							Values:   []string{"app-name", "mariadb", "wordpress"},
+						}},
+					},
+				}, {
+					PodSelector: &v1.LabelSelector{
+						MatchExpressions: []v1.LabelSelectorRequirement{{
+							Key:      "juju-operator",
+							Operator: v1.LabelSelectorOpIn,
+							Values:   []string{"app-name", "mariadb", "wordpress"},
+						}},
+					},
+				}},
+			}},
+		},
+	}
+	gomock.InOrder(
+		s.mockNetworkPolicies.EXPECT().Update(policy).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockNetworkPolicies.EXPECT().Create(policy).
+			Return(policy, nil),
+	)
+
+	err := s.broker.EnsureNetworkPolicy("app-name", "app-name",
+		map[string]string{"juju.io/controller": testing.ControllerTag.Id()},
+		caas.NetworkPolicy{
+			Isolated:            true,
+			RelatedApplications: []string{"wordpress", "mariadb"},
+		})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureNetworkPolicyExposed(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	policy := &networkingv1.NetworkPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        "app-name",
+			Labels:      map[string]string{"juju-app": "app-name"},
+			Annotations: map[string]string{},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: v1.LabelSelector{
+				MatchLabels: map[string]string{"juju-app": "app-name"},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			// A rule with no peers lets in traffic from anywhere.
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{}},
+		},
+	}
+	s.mockNetworkPolicies.EXPECT().Update(policy).Return(policy, nil)
+
+	err := s.broker.EnsureNetworkPolicy("app-name", "app-name", nil, caas.NetworkPolicy{
+		Isolated:            true,
+		RelatedApplications: []string{"wordpress"},
+		Exposed:             true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *K8sBrokerSuite) TestEnsureNetworkPolicyNotIsolated(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	s.mockNetworkPolicies.EXPECT().Delete("app-name", s.deleteOptions(v1.DeletePropagationForeground, "")).
+		Return(s.k8sNotFoundError())
+
+	err := s.broker.EnsureNetworkPolicy("app-name", "app-name", nil, caas.NetworkPolicy{
+		RelatedApplications: []string{"wordpress"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *K8sBrokerSuite) TestWatchService(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
 
 	ssWatcher := watch.NewRaceFreeFake()
 	deployWatcher := watch.NewRaceFreeFake()
+	jobWatcher := watch.NewRaceFreeFake()
+	cronJobWatcher := watch.NewRaceFreeFake()
 
 	gomock.InOrder(
 		s.mockStatefulSets.EXPECT().Watch(v1.ListOptions{
@@ -3388,6 +4012,14 @@ func (s *K8sBrokerSuite) TestWatchService(c *gc.C) {
 			LabelSelector: "juju-app==test",
 			Watch:         true,
 		}).Return(deployWatcher, nil),
+		s.mockJobs.EXPECT().Watch(v1.ListOptions{
+			LabelSelector: "juju-app==test",
+			Watch:         true,
+		}).Return(jobWatcher, nil),
+		s.mockCronJobs.EXPECT().Watch(v1.ListOptions{
+			LabelSelector: "juju-app==test",
+			Watch:         true,
+		}).Return(cronJobWatcher, nil),
 	)
 
 	w, err := s.broker.WatchService("test")
@@ -3410,6 +4042,105 @@ func (s *K8sBrokerSuite) TestWatchService(c *gc.C) {
 	}
 }
 
+func (s *K8sBrokerSuite) TestWatchUnitEvents(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	pod := &core.Pod{ObjectMeta: v1.ObjectMeta{Name: "test-0"}}
+	podEventsWatcher := watch.NewRaceFreeFake()
+	serviceEventsWatcher := watch.NewRaceFreeFake()
+
+	gomock.InOrder(
+		s.mockPods.EXPECT().Get("test-0", v1.GetOptions{
+			IncludeUninitialized: true,
+		}).Return(pod, nil),
+		s.mockEvents.EXPECT().Watch(v1.ListOptions{
+			FieldSelector: "involvedObject.name=test-0,involvedObject.kind=Pod",
+			Watch:         true,
+		}).Return(podEventsWatcher, nil),
+		s.mockStatefulSets.EXPECT().Get("juju-operator-test", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockEvents.EXPECT().Watch(v1.ListOptions{
+			FieldSelector: "involvedObject.name=test,involvedObject.kind=Service",
+			Watch:         true,
+		}).Return(serviceEventsWatcher, nil),
+	)
+
+	w, err := s.broker.WatchUnitEvents("test", "test-0")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Send an event to one of the watchers; multi-watcher should fire.
+	evt := &core.Event{ObjectMeta: v1.ObjectMeta{Name: "test-0.1"}}
+	go func(w *watch.RaceFreeFakeWatcher, clk *testclock.Clock) {
+		if !w.IsStopped() {
+			clk.WaitAdvance(time.Second, testing.ShortWait, 1)
+			w.Add(evt)
+		}
+	}(podEventsWatcher, s.clock)
+
+	select {
+	case _, ok := <-w.Changes():
+		c.Assert(ok, jc.IsTrue)
+	case <-time.After(testing.LongWait):
+		c.Fatal("timed out waiting for event")
+	}
+}
+
+func (s *K8sBrokerSuite) TestWatchUnitEventsByUID(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	podList := &core.PodList{
+		Items: []core.Pod{{ObjectMeta: v1.ObjectMeta{
+			Name: "test-5d8f7c9b6-x2v4q",
+			UID:  types.UID("uuid"),
+		}}},
+	}
+
+	gomock.InOrder(
+		s.mockPods.EXPECT().Get("uuid", v1.GetOptions{
+			IncludeUninitialized: true,
+		}).Return(nil, s.k8sNotFoundError()),
+		s.mockPods.EXPECT().List(v1.ListOptions{
+			LabelSelector:        "juju-app==test",
+			IncludeUninitialized: true,
+		}).Return(podList, nil),
+		s.mockEvents.EXPECT().Watch(v1.ListOptions{
+			FieldSelector: "involvedObject.name=test-5d8f7c9b6-x2v4q,involvedObject.kind=Pod",
+			Watch:         true,
+		}).Return(watch.NewRaceFreeFake(), nil),
+		s.mockStatefulSets.EXPECT().Get("juju-operator-test", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockEvents.EXPECT().Watch(v1.ListOptions{
+			FieldSelector: "involvedObject.name=test,involvedObject.kind=Service",
+			Watch:         true,
+		}).Return(watch.NewRaceFreeFake(), nil),
+	)
+
+	w, err := s.broker.WatchUnitEvents("test", "uuid")
+	c.Assert(err, jc.ErrorIsNil)
+	workertest.CleanKill(c, w)
+}
+
+func (s *K8sBrokerSuite) TestWatchUnitEventsPodNotFound(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockPods.EXPECT().Get("uuid", v1.GetOptions{
+			IncludeUninitialized: true,
+		}).Return(nil, s.k8sNotFoundError()),
+		s.mockPods.EXPECT().List(v1.ListOptions{
+			LabelSelector:        "juju-app==test",
+			IncludeUninitialized: true,
+		}).Return(&core.PodList{}, nil),
+	)
+
+	_, err := s.broker.WatchUnitEvents("test", "uuid")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `pod "uuid" not found`)
+}
+
 func (s *K8sBrokerSuite) TestAnnotateUnit(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
@@ -3775,6 +4506,135 @@ func (s *K8sBrokerSuite) TestUpgradeNotSupported(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
 }
 
+func partitionedStatefulSet(replicas, partition int32) *apps.StatefulSet {
+	return &apps.StatefulSet{
+		ObjectMeta: v1.ObjectMeta{
+			Name:       "app-name",
+			Generation: 2,
+		},
+		Spec: apps.StatefulSetSpec{
+			Replicas: &replicas,
+			UpdateStrategy: apps.StatefulSetUpdateStrategy{
+				Type: apps.RollingUpdateStatefulSetStrategyType,
+				RollingUpdate: &apps.RollingUpdateStatefulSetStrategy{
+					Partition: &partition,
+				},
+			},
+		},
+		Status: apps.StatefulSetStatus{
+			ObservedGeneration: 2,
+			UpdateRevision:     "app-name-new",
+		},
+	}
+}
+
+func upgradedPod(name string, ready core.ConditionStatus) *core.Pod {
+	return &core.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{apps.StatefulSetRevisionLabel: "app-name-new"},
+		},
+		Status: core.PodStatus{
+			Conditions: []core.PodCondition{{
+				Type:   core.PodReady,
+				Status: ready,
+			}},
+		},
+	}
+}
+
+func (s *K8sBrokerSuite) TestAdvanceUpgradeFirstPod(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(partitionedStatefulSet(3, 3), nil),
+		s.mockPods.EXPECT().Get("app-name-2", v1.GetOptions{}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Update(partitionedStatefulSet(3, 2)).
+			Return(nil, nil),
+	)
+
+	done, err := s.broker.AdvanceUpgrade("app-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(done, jc.IsFalse)
+}
+
+func (s *K8sBrokerSuite) TestAdvanceUpgradeNextPod(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(partitionedStatefulSet(3, 1), nil),
+		s.mockPods.EXPECT().Get("app-name-1", v1.GetOptions{}).
+			Return(upgradedPod("app-name-1", core.ConditionTrue), nil),
+		s.mockStatefulSets.EXPECT().Update(partitionedStatefulSet(3, 0)).
+			Return(nil, nil),
+	)
+
+	done, err := s.broker.AdvanceUpgrade("app-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(done, jc.IsTrue)
+}
+
+func (s *K8sBrokerSuite) TestAdvanceUpgradeWaitsForReadyPod(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(partitionedStatefulSet(3, 2), nil),
+		s.mockPods.EXPECT().Get("app-name-2", v1.GetOptions{}).
+			Return(upgradedPod("app-name-2", core.ConditionFalse), nil),
+	)
+
+	done, err := s.broker.AdvanceUpgrade("app-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(done, jc.IsFalse)
+}
+
+func (s *K8sBrokerSuite) TestAdvanceUpgradeWaitsForController(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	ss := partitionedStatefulSet(3, 3)
+	ss.Status.ObservedGeneration = 1
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(ss, nil),
+	)
+
+	done, err := s.broker.AdvanceUpgrade("app-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(done, jc.IsFalse)
+}
+
+func (s *K8sBrokerSuite) TestAdvanceUpgradeNotPartitioned(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("app-name", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+	)
+
+	done, err := s.broker.AdvanceUpgrade("app-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(done, jc.IsTrue)
+}
+
 func initContainers() []core.Container {
 	jujudCmd := "export JUJU_DATA_DIR=/var/lib/juju\nexport JUJU_TOOLS_DIR=$JUJU_DATA_DIR/tools\n\nmkdir -p $JUJU_TOOLS_DIR\ncp /opt/jujud $JUJU_TOOLS_DIR/jujud"
 	jujudCmd += `
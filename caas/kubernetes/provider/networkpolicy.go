@@ -0,0 +1,97 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"sort"
+
+	"github.com/juju/errors"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/juju/juju/caas"
+	k8sannotations "github.com/juju/juju/core/annotations"
+)
+
+// networkPolicySpec returns the network policy which only lets the
+// traffic allowed by policy reach the application's pods.
+func networkPolicySpec(
+	appName, name string,
+	annotations k8sannotations.Annotation,
+	policy caas.NetworkPolicy,
+) *networkingv1.NetworkPolicy {
+	var rule networkingv1.NetworkPolicyIngressRule
+	if !policy.Exposed {
+		// A rule with no peers lets in traffic from anywhere.
+		apps := append([]string{appName}, policy.RelatedApplications...)
+		sort.Strings(apps)
+		// The operators of related applications run their charms'
+		// relation hooks, so they need to reach the pods too.
+		rule.From = []networkingv1.NetworkPolicyPeer{{
+			PodSelector: &v1.LabelSelector{
+				MatchExpressions: []v1.LabelSelectorRequirement{{
+					Key:      labelApplication,
+					Operator: v1.LabelSelectorOpIn,
+					Values:   apps,
+				}},
+			},
+		}, {
+			PodSelector: &v1.LabelSelector{
+				MatchExpressions: []v1.LabelSelectorRequirement{{
+					Key:      labelOperator,
+					Operator: v1.LabelSelectorOpIn,
+					Values:   apps,
+				}},
+			},
+		}}
+	}
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: v1.ObjectMeta{
+			Name:        name,
+			Labels:      map[string]string{labelApplication: appName},
+			Annotations: annotations.ToMap(),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: v1.LabelSelector{
+				MatchLabels: map[string]string{labelApplication: appName},
+			},
+			// Only incoming traffic is restricted; pods can still
+			// reach DNS and anything outside the cluster.
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress:     []networkingv1.NetworkPolicyIngressRule{rule},
+		},
+	}
+}
+
+// ensureNetworkPolicy creates or updates the network policy for the
+// application's pods if it is isolated, and otherwise removes it.
+func (k *kubernetesClient) ensureNetworkPolicy(
+	appName, name string,
+	annotations k8sannotations.Annotation,
+	policy caas.NetworkPolicy,
+) error {
+	if !policy.Isolated {
+		return k.deleteNetworkPolicy(name)
+	}
+	logger.Debugf("creating/updating network policy for %s", appName)
+	spec := networkPolicySpec(appName, name, annotations, policy)
+	api := k.client().NetworkingV1().NetworkPolicies(k.namespace)
+	_, err := api.Update(spec)
+	if k8serrors.IsNotFound(err) {
+		_, err = api.Create(spec)
+	}
+	return errors.Trace(err)
+}
+
+func (k *kubernetesClient) deleteNetworkPolicy(name string) error {
+	policies := k.client().NetworkingV1().NetworkPolicies(k.namespace)
+	err := policies.Delete(name, &v1.DeleteOptions{
+		PropagationPolicy: &defaultPropagationPolicy,
+	})
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return errors.Trace(err)
+}
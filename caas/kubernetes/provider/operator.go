@@ -23,7 +23,6 @@ import (
 	"github.com/juju/juju/caas"
 	k8sannotations "github.com/juju/juju/core/annotations"
 	"github.com/juju/juju/core/paths"
-	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 )
 
@@ -431,7 +430,7 @@ func (k *kubernetesClient) Operator(appName string) (*caas.Operator, error) {
 	opPod := podsList.Items[0]
 	terminated := opPod.DeletionTimestamp != nil
 	now := time.Now()
-	statusMessage, opStatus, since, err := k.getPODStatus(opPod, now)
+	opStatus, err := k.getPODStatus(opPod, now)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -464,13 +463,9 @@ func (k *kubernetesClient) Operator(appName string) (*caas.Operator, error) {
 	}
 
 	return &caas.Operator{
-		Id:    string(opPod.UID),
-		Dying: terminated,
-		Status: status.StatusInfo{
-			Status:  opStatus,
-			Message: statusMessage,
-			Since:   &since,
-		},
+		Id:     string(opPod.UID),
+		Dying:  terminated,
+		Status: opStatus,
 		Config: &cfg,
 	}, nil
 }
@@ -778,6 +778,77 @@ func (s *K8sBrokerSuite) TestOperator(c *gc.C) {
 	c.Assert(operator.Config.OperatorInfo, gc.DeepEquals, []byte("operator-info-data"))
 }
 
+func (s *K8sBrokerSuite) TestOperatorWarningEvents(c *gc.C) {
+	ctrl := s.setupController(c)
+	defer ctrl.Finish()
+
+	opPod := core.Pod{
+		ObjectMeta: v1.ObjectMeta{
+			Name: "test-operator",
+		},
+		Status: core.PodStatus{
+			Phase: core.PodPending,
+			Conditions: []core.PodCondition{{
+				Type:    core.ContainersReady,
+				Message: "containers with unready status: [juju-operator]",
+			}},
+		},
+	}
+	ss := apps.StatefulSet{
+		ObjectMeta: v1.ObjectMeta{
+			Annotations: map[string]string{
+				"juju-version":       "2.99.0",
+				"juju.io/controller": testing.ControllerTag.Id(),
+			},
+		},
+	}
+	first := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	event := func(eventType, reason, message string, seen time.Duration) core.Event {
+		return core.Event{
+			Type:           eventType,
+			Reason:         reason,
+			Message:        message,
+			FirstTimestamp: v1.NewTime(first),
+			LastTimestamp:  v1.NewTime(first.Add(seen)),
+		}
+	}
+	events := &core.EventList{Items: []core.Event{
+		event(core.EventTypeNormal, provider.PullingImage, `Pulling image "test-image"`, 0),
+		event(core.EventTypeWarning, provider.FailedToPullImage, "Error: ErrImagePull", time.Minute),
+		event(core.EventTypeWarning, provider.BackOffPullImage, `Back-off pulling image "test-image"`, 2*time.Minute),
+		event(core.EventTypeWarning, "FailedMount", "Unable to attach or mount volumes", 3*time.Minute),
+	}}
+	gomock.InOrder(
+		s.mockStatefulSets.EXPECT().Get("juju-operator-test", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+		s.mockStatefulSets.EXPECT().Get("test-operator", v1.GetOptions{IncludeUninitialized: true}).
+			Return(&ss, nil),
+		s.mockPods.EXPECT().List(v1.ListOptions{LabelSelector: "juju-operator==test"}).
+			Return(&core.PodList{Items: []core.Pod{opPod}}, nil),
+		s.mockEvents.EXPECT().List(v1.ListOptions{
+			IncludeUninitialized: true,
+			FieldSelector:        "involvedObject.name=test-operator,involvedObject.kind=Pod",
+		}).Return(events, nil),
+		s.mockConfigMaps.EXPECT().Get("test-operator-config", v1.GetOptions{IncludeUninitialized: true}).
+			Return(nil, s.k8sNotFoundError()),
+	)
+
+	operator, err := s.broker.Operator("test")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(operator.Status.Status, gc.Equals, status.Allocating)
+	c.Assert(operator.Status.Message, gc.Equals, `Back-off pulling image "test-image"`)
+	c.Assert(status.KubernetesEvents(operator.Status.Data), jc.DeepEquals, []status.KubernetesEvent{{
+		Reason:  provider.BackOffPullImage,
+		Message: `Back-off pulling image "test-image"`,
+		Since:   first,
+	}, {
+		Reason:  provider.FailedToPullImage,
+		Message: "Error: ErrImagePull",
+		Since:   first,
+	}})
+}
+
 func (s *K8sBrokerSuite) TestOperatorNoPodFound(c *gc.C) {
 	ctrl := s.setupController(c)
 	defer ctrl.Finish()
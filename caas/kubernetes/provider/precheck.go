@@ -47,19 +47,6 @@ func (k *kubernetesClient) PrecheckInstance(ctx context.ProviderCallContext, par
 	if params.Constraints.Tags == nil {
 		return nil
 	}
-	affinityLabels := *params.Constraints.Tags
-	labelsString := strings.Join(affinityLabels, ",")
-	for _, labelPair := range affinityLabels {
-		parts := strings.Split(labelPair, "=")
-		if len(parts) != 2 {
-			return errors.Errorf("invalid node affinity constraints: %v", labelsString)
-		}
-		key := strings.Trim(parts[0], " ")
-		if strings.HasPrefix(key, "^") {
-			if len(key) == 1 {
-				return errors.Errorf("invalid node affinity constraints: %v", labelsString)
-			}
-		}
-	}
-	return nil
+	_, err = parseNodeTags(*params.Constraints.Tags)
+	return errors.Trace(err)
 }
@@ -82,4 +82,9 @@ func (s *PrecheckSuite) TestInvalidConstraints(c *gc.C) {
 		Constraints: constraints.MustParse("tags=^=bar"),
 	})
 	c.Assert(err, gc.ErrorMatches, `invalid node affinity constraints: \^=bar`)
+	err = s.broker.PrecheckInstance(context.NewCloudCallContext(), environs.PrecheckInstanceParams{
+		Series:      "kubernetes",
+		Constraints: constraints.MustParse("tags=toleration:dedicated=db:Sometimes"),
+	})
+	c.Assert(err, gc.ErrorMatches, `taint effect "Sometimes" not valid`)
 }
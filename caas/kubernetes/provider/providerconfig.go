@@ -15,6 +15,11 @@ import (
 const (
 	WorkloadStorageKey = "workload-storage"
 	OperatorStorageKey = "operator-storage"
+
+	// NetworkIsolationKey is the model config key which, when true,
+	// has the pods of each application only accept traffic from the
+	// applications related to it, unless it is exposed.
+	NetworkIsolationKey = "network-isolation"
 )
 
 var configSchema = environschema.Fields{
@@ -29,6 +34,11 @@ var configSchema = environschema.Fields{
 		Group:       environschema.AccountGroup,
 		Immutable:   true,
 	},
+	NetworkIsolationKey: {
+		Description: "Whether an application's pods only accept traffic from related applications, unless it is exposed.",
+		Type:        environschema.Tbool,
+		Group:       environschema.AccountGroup,
+	},
 }
 
 var providerConfigFields = func() schema.Fields {
@@ -40,8 +50,9 @@ var providerConfigFields = func() schema.Fields {
 }()
 
 var providerConfigDefaults = schema.Defaults{
-	WorkloadStorageKey: "",
-	OperatorStorageKey: "",
+	WorkloadStorageKey:  "",
+	OperatorStorageKey:  "",
+	NetworkIsolationKey: false,
 }
 
 type brokerConfig struct {
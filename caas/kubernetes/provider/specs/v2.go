@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/juju/errors"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
@@ -98,6 +99,49 @@ type KubernetesResources struct {
 	CustomResources           map[string][]unstructured.Unstructured                       `json:"customResources,omitempty" yaml:"customResources,omitempty"`
 
 	ServiceAccounts []K8sServiceAccountSpec `json:"serviceAccounts,omitempty" yaml:"serviceAccounts,omitempty"`
+
+	Job *JobSpec `json:"job,omitempty" yaml:"job,omitempty"`
+}
+
+// JobSpec defines a batch workload for charms whose pods run to
+// completion rather than being kept running. The application is
+// deployed as a Job, or as a CronJob if a schedule is specified.
+type JobSpec struct {
+	// Schedule is the cron schedule on which the job is run.
+	Schedule string `json:"schedule,omitempty" yaml:"schedule,omitempty"`
+
+	Completions           *int32 `json:"completions,omitempty" yaml:"completions,omitempty"`
+	BackoffLimit          *int32 `json:"backoffLimit,omitempty" yaml:"backoffLimit,omitempty"`
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty" yaml:"activeDeadlineSeconds,omitempty"`
+
+	// The following only apply to scheduled jobs.
+	ConcurrencyPolicy          batchv1beta1.ConcurrencyPolicy `json:"concurrencyPolicy,omitempty" yaml:"concurrencyPolicy,omitempty"`
+	StartingDeadlineSeconds    *int64                         `json:"startingDeadlineSeconds,omitempty" yaml:"startingDeadlineSeconds,omitempty"`
+	Suspend                    *bool                          `json:"suspend,omitempty" yaml:"suspend,omitempty"`
+	SuccessfulJobsHistoryLimit *int32                         `json:"successfulJobsHistoryLimit,omitempty" yaml:"successfulJobsHistoryLimit,omitempty"`
+	FailedJobsHistoryLimit     *int32                         `json:"failedJobsHistoryLimit,omitempty" yaml:"failedJobsHistoryLimit,omitempty"`
+}
+
+// Scheduled returns true if the job is run on a schedule.
+func (j JobSpec) Scheduled() bool {
+	return j.Schedule != ""
+}
+
+// Validate returns an error if the spec is not valid.
+func (j JobSpec) Validate() error {
+	if j.Scheduled() {
+		switch j.ConcurrencyPolicy {
+		case "", batchv1beta1.AllowConcurrent, batchv1beta1.ForbidConcurrent, batchv1beta1.ReplaceConcurrent:
+		default:
+			return errors.NotSupportedf("job concurrency policy %q", j.ConcurrencyPolicy)
+		}
+		return nil
+	}
+	if j.ConcurrencyPolicy != "" || j.StartingDeadlineSeconds != nil || j.Suspend != nil ||
+		j.SuccessfulJobsHistoryLimit != nil || j.FailedJobsHistoryLimit != nil {
+		return errors.NewNotValid(nil, "job schedule is required for the cron job settings")
+	}
+	return nil
 }
 
 func validateCustomResourceDefinition(name string, crd apiextensionsv1beta1.CustomResourceDefinitionSpec) error {
@@ -129,6 +173,12 @@ func (krs *KubernetesResources) Validate() error {
 			return errors.Trace(err)
 		}
 	}
+
+	if krs.Job != nil {
+		if err := krs.Job.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return nil
 }
 
@@ -6,6 +6,7 @@ package specs_test
 import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	core "k8s.io/api/core/v1"
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -656,6 +657,61 @@ kubernetesResources:
 	c.Assert(err, gc.ErrorMatches, `custom resource definition "tfjobs.kubeflow.org" scope "Cluster" is not supported, please use "Namespaced" scope`)
 }
 
+func (s *v2SpecsSuite) TestParseJob(c *gc.C) {
+	specStr := versionHeader + `
+containers:
+  - name: backup
+    image: backup/latest
+kubernetesResources:
+  job:
+    schedule: "*/30 * * * *"
+    backoffLimit: 3
+    concurrencyPolicy: Forbid
+    successfulJobsHistoryLimit: 1
+`[1:]
+
+	spec, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, jc.ErrorIsNil)
+	job := spec.ProviderPod.(*k8sspecs.K8sPodSpec).KubernetesResources.Job
+	c.Assert(job, jc.DeepEquals, &k8sspecs.JobSpec{
+		Schedule:                   "*/30 * * * *",
+		BackoffLimit:               int32Ptr(3),
+		ConcurrencyPolicy:          batchv1beta1.ForbidConcurrent,
+		SuccessfulJobsHistoryLimit: int32Ptr(1),
+	})
+	c.Assert(job.Scheduled(), jc.IsTrue)
+}
+
+func (s *v2SpecsSuite) TestValidateJobCronSettingsWithoutSchedule(c *gc.C) {
+	specStr := versionHeader + `
+containers:
+  - name: backup
+    image: backup/latest
+kubernetesResources:
+  job:
+    completions: 1
+    suspend: true
+`[1:]
+
+	_, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `job schedule is required for the cron job settings`)
+}
+
+func (s *v2SpecsSuite) TestValidateJobConcurrencyPolicy(c *gc.C) {
+	specStr := versionHeader + `
+containers:
+  - name: backup
+    image: backup/latest
+kubernetesResources:
+  job:
+    schedule: "@hourly"
+    concurrencyPolicy: Sometimes
+`[1:]
+
+	_, err := k8sspecs.ParsePodSpec(specStr)
+	c.Assert(err, gc.ErrorMatches, `job concurrency policy "Sometimes" not supported`)
+}
+
 func (s *v2SpecsSuite) TestUnknownFieldError(c *gc.C) {
 	specStr := versionHeader + `
 containers:
@@ -63,7 +63,7 @@ func newCachingClient(
 		return Client{}, errors.Trace(err)
 	}
 	bakeryClient.Jar = jar
-	return Client{client, jar}, nil
+	return Client{csWrapper: client, jar: jar}, nil
 }
 
 // TODO(natefinch): we really shouldn't let something like a bakeryclient
@@ -104,7 +104,15 @@ func makeWrapper(bakeryClient *httpbakery.Client, server string) (csWrapper, err
 // library) in a higher level API.
 type Client struct {
 	csWrapper
-	jar *macaroonJar
+	jar    *macaroonJar
+	mirror *Mirror
+}
+
+// WithMirror returns a copy of the client which gets resource metadata
+// and content from the given mirror rather than from the charm store.
+func (c Client) WithMirror(mirror *Mirror) Client {
+	c.mirror = mirror
+	return c
 }
 
 // CharmRevision holds the data returned from the charmstore about the latest
@@ -194,6 +202,10 @@ type ResourceData struct {
 
 // GetResource returns the data (bytes) and metadata for a resource from the charmstore.
 func (c Client) GetResource(req ResourceRequest) (data ResourceData, err error) {
+	if c.mirror != nil {
+		data, err := c.mirror.GetResource(req)
+		return data, errors.Trace(err)
+	}
 	if err := c.jar.Activate(req.Charm); err != nil {
 		return ResourceData{}, errors.Trace(err)
 	}
@@ -229,6 +241,10 @@ func (c Client) GetResource(req ResourceRequest) (data ResourceData, err error)
 
 // ResourceInfo returns the metadata for the given resource from the charmstore.
 func (c Client) ResourceInfo(req ResourceRequest) (resource.Resource, error) {
+	if c.mirror != nil {
+		res, err := c.mirror.ResourceMeta(req.Charm, req.Name, req.Revision)
+		return res, errors.Trace(err)
+	}
 	if err := c.jar.Activate(req.Charm); err != nil {
 		return resource.Resource{}, errors.Trace(err)
 	}
@@ -0,0 +1,254 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmstore
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/charm.v6/resource"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
+)
+
+// Mirror downloads charm archives and resources, along with their
+// metadata, from an HTTP mirror of the charm store, so that charms can
+// be deployed where the controller cannot reach the charm store.
+//
+// The mirror is expected to serve a copy of the charm store content at
+// the same paths as the charm store API: the SHA256 hash of a charm's
+// archive at <mirror>/<id>/meta/hash256 and the archive itself at
+// <mirror>/<id>/archive, and the metadata and content of a revision of
+// one of its resources at <mirror>/<id>/meta/resource/<name>/<revision>
+// and <mirror>/<id>/resource/<name>/<revision>. Everything downloaded is checked against the checksum in the
+// metadata before it is used, so that content corrupted or only
+// partially copied to the mirror is never deployed.
+type Mirror struct {
+	baseURL string
+	client  *http.Client
+}
+
+// mirrorHTTPClient is the HTTP client used to talk to mirrors. Its
+// timeouts bound connecting to the mirror and waiting for it to start
+// responding, but not the download itself, as archives and resources
+// may be large.
+var mirrorHTTPClient = &http.Client{
+	Transport: &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ResponseHeaderTimeout: time.Minute,
+		IdleConnTimeout:       90 * time.Second,
+	},
+}
+
+// NewMirror returns a Mirror which downloads content from the mirror
+// at the given URL.
+func NewMirror(mirrorURL string) (*Mirror, error) {
+	return newMirror(mirrorURL, mirrorHTTPClient)
+}
+
+func newMirror(mirrorURL string, client *http.Client) (*Mirror, error) {
+	u, err := url.Parse(mirrorURL)
+	if err != nil {
+		return nil, errors.Annotate(err, "parsing charm mirror URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return nil, errors.NotValidf("charm mirror URL %q", mirrorURL)
+	}
+	return &Mirror{
+		baseURL: strings.TrimSuffix(mirrorURL, "/"),
+		client:  client,
+	}, nil
+}
+
+// Hash256 returns the SHA256 hash of the archive of the charm with the
+// given id.
+func (m *Mirror) Hash256(id *charm.URL) (string, error) {
+	var result csparams.HashResponse
+	if err := m.getJSON(id.Path()+"/meta/hash256", &result); err != nil {
+		return "", errors.Annotatef(err, "getting hash of charm %q from mirror", id)
+	}
+	return result.Sum, nil
+}
+
+// Archive downloads the archive of the charm with the given id to a
+// temporary file, and returns the path to the file. The SHA256 hash of
+// the archive must match expectedSHA256. It is the caller's
+// responsibility to remove the file.
+func (m *Mirror) Archive(id *charm.URL, expectedSHA256 string) (string, error) {
+	f, err := m.download(id.Path()+"/archive", sha256.New(), expectedSHA256)
+	if err != nil {
+		return "", errors.Annotatef(err, "downloading charm %q from mirror", id)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", errors.Trace(err)
+	}
+	return f.Name(), nil
+}
+
+// ResourceMeta returns the metadata for the given revision of the named
+// resource of the charm with the given id. A negative revision refers
+// to the revision the mirror serves as current.
+func (m *Mirror) ResourceMeta(id *charm.URL, name string, revision int) (resource.Resource, error) {
+	path := fmt.Sprintf("%s/meta/resource/%s", id.Path(), url.PathEscape(name))
+	if revision >= 0 {
+		path += fmt.Sprintf("/%d", revision)
+	}
+	var meta csparams.Resource
+	if err := m.getJSON(path, &meta); err != nil {
+		return resource.Resource{}, errors.Annotatef(err, "getting resource %q of charm %q from mirror", name, id)
+	}
+	res, err := csparams.API2Resource(meta)
+	if err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	return res, nil
+}
+
+// GetResource returns the metadata and content of the requested
+// resource from the mirror. The content of file resources must match
+// the fingerprint in the metadata; the content of other resources
+// describes where they are fetched from and has no fingerprint.
+func (m *Mirror) GetResource(req ResourceRequest) (ResourceData, error) {
+	res, err := m.ResourceMeta(req.Charm, req.Name, req.Revision)
+	if err != nil {
+		return ResourceData{}, errors.Trace(err)
+	}
+	var fingerprint *resource.Fingerprint
+	if res.Type == resource.TypeFile {
+		fingerprint = &res.Fingerprint
+	}
+	rc, err := m.resource(req.Charm, req.Name, res.Revision, fingerprint)
+	if err != nil {
+		return ResourceData{}, errors.Trace(err)
+	}
+	return ResourceData{ReadCloser: rc, Resource: res}, nil
+}
+
+// Resource downloads the given revision of the named resource of the
+// charm with the given id. The downloaded content must match the given
+// fingerprint. The temporary file holding the content is removed when
+// the returned reader is closed.
+func (m *Mirror) Resource(id *charm.URL, name string, revision int, fingerprint resource.Fingerprint) (io.ReadCloser, error) {
+	return m.resource(id, name, revision, &fingerprint)
+}
+
+func (m *Mirror) resource(id *charm.URL, name string, revision int, fingerprint *resource.Fingerprint) (io.ReadCloser, error) {
+	path := fmt.Sprintf("%s/resource/%s/%d", id.Path(), url.PathEscape(name), revision)
+	var (
+		h           hash.Hash
+		expectedSum string
+	)
+	if fingerprint != nil {
+		h, expectedSum = sha512.New384(), fingerprint.String()
+	}
+	f, err := m.download(path, h, expectedSum)
+	if err != nil {
+		return nil, errors.Annotatef(err, "downloading resource %q of charm %q from mirror", name, id)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return nil, errors.Trace(err)
+	}
+	return &tempFile{f}, nil
+}
+
+// getJSON fetches the JSON document at the given path of the mirror
+// into result.
+func (m *Mirror) getJSON(path string, result interface{}) error {
+	resp, err := m.get(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+		return errors.Annotatef(err, "decoding %q", path)
+	}
+	return nil
+}
+
+// get requests the content at the given path of the mirror. The
+// caller must close the body of the response returned.
+func (m *Mirror) get(path string) (*http.Response, error) {
+	resp, err := m.client.Get(m.baseURL + "/" + path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, errors.NotFoundf("%q on mirror", path)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, errors.Errorf("unexpected response from mirror: %s", resp.Status)
+	}
+	return resp, nil
+}
+
+// download fetches the content at the given path of the mirror into a
+// temporary file. If h is not nil, the hash of the content must match
+// the expected hex encoded sum.
+func (m *Mirror) download(path string, h hash.Hash, expectedSum string) (_ *os.File, err error) {
+	resp, err := m.get(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer resp.Body.Close()
+
+	f, err := ioutil.TempFile("", "charm-mirror")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(f.Name())
+		}
+	}()
+	var w io.Writer = f
+	if h != nil {
+		w = io.MultiWriter(f, h)
+	}
+	if _, err := io.Copy(w, resp.Body); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if h == nil {
+		return f, nil
+	}
+	if sum := fmt.Sprintf("%x", h.Sum(nil)); sum != expectedSum {
+		return nil, errors.Errorf("checksum of downloaded content (%s) does not match metadata (%s)", sum, expectedSum)
+	}
+	return f, nil
+}
+
+// tempFile is an io.ReadCloser for a temporary file, which removes the
+// file when closed.
+type tempFile struct {
+	*os.File
+}
+
+// Close implements io.Closer.
+func (f *tempFile) Close() error {
+	err := f.File.Close()
+	os.Remove(f.File.Name())
+	return errors.Trace(err)
+}
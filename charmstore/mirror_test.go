@@ -0,0 +1,230 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmstore
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+	"gopkg.in/juju/charm.v6/resource"
+	"gopkg.in/juju/charmrepo.v3/csclient/params"
+)
+
+var _ = gc.Suite(&MirrorSuite{})
+
+type MirrorSuite struct {
+	testing.IsolationSuite
+	server   *httptest.Server
+	content  map[string]string
+	requests []string
+}
+
+func (s *MirrorSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.content = make(map[string]string)
+	s.requests = nil
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.requests = append(s.requests, r.URL.Path)
+		content, ok := s.content[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, content)
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *MirrorSuite) newMirror(c *gc.C) *Mirror {
+	mirror, err := NewMirror(s.server.URL + "/charmstore/")
+	c.Assert(err, jc.ErrorIsNil)
+	return mirror
+}
+
+func sha256Sum(data string) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(data)))
+}
+
+func (s *MirrorSuite) TestNewMirrorInvalidURL(c *gc.C) {
+	_, err := NewMirror("mirror.internal")
+	c.Assert(err, gc.ErrorMatches, `charm mirror URL "mirror.internal" not valid`)
+}
+
+func (s *MirrorSuite) TestArchive(c *gc.C) {
+	s.content["/charmstore/trusty/mysql-23/archive"] = "archive"
+
+	path, err := s.newMirror(c).Archive(charm.MustParseURL("cs:trusty/mysql-23"), sha256Sum("archive"))
+	c.Assert(err, jc.ErrorIsNil)
+	defer os.Remove(path)
+	data, err := ioutil.ReadFile(path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "archive")
+}
+
+func (s *MirrorSuite) TestArchiveChecksumMismatch(c *gc.C) {
+	s.content["/charmstore/trusty/mysql-23/archive"] = "tampered"
+
+	_, err := s.newMirror(c).Archive(charm.MustParseURL("cs:trusty/mysql-23"), sha256Sum("archive"))
+	c.Assert(err, gc.ErrorMatches, `downloading charm "cs:trusty/mysql-23" from mirror: checksum of downloaded content \(.*\) does not match metadata \(.*\)`)
+}
+
+func (s *MirrorSuite) TestArchiveNotFound(c *gc.C) {
+	_, err := s.newMirror(c).Archive(charm.MustParseURL("cs:trusty/mysql-23"), sha256Sum("archive"))
+	c.Assert(err, gc.ErrorMatches, `downloading charm "cs:trusty/mysql-23" from mirror: "trusty/mysql-23/archive" on mirror not found`)
+}
+
+func (s *MirrorSuite) TestResource(c *gc.C) {
+	s.content["/charmstore/mysql/resource/name/5"] = "data"
+	fp, err := resource.GenerateFingerprint(strings.NewReader("data"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	rc, err := s.newMirror(c).Resource(charm.MustParseURL("cs:mysql"), "name", 5, fp)
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(rc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "data")
+	c.Assert(rc.Close(), jc.ErrorIsNil)
+}
+
+func (s *MirrorSuite) TestResourceChecksumMismatch(c *gc.C) {
+	s.content["/charmstore/mysql/resource/name/5"] = "tampered"
+	fp, err := resource.GenerateFingerprint(strings.NewReader("data"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.newMirror(c).Resource(charm.MustParseURL("cs:mysql"), "name", 5, fp)
+	c.Assert(err, gc.ErrorMatches, `downloading resource "name" of charm "cs:mysql" from mirror: checksum .* does not match metadata .*`)
+}
+
+func (s *MirrorSuite) TestHash256(c *gc.C) {
+	s.content["/charmstore/trusty/mysql-23/meta/hash256"] = `{"Sum": "abc123"}`
+
+	hash, err := s.newMirror(c).Hash256(charm.MustParseURL("cs:trusty/mysql-23"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hash, gc.Equals, "abc123")
+}
+
+func (s *MirrorSuite) TestHash256Invalid(c *gc.C) {
+	s.content["/charmstore/trusty/mysql-23/meta/hash256"] = `not json`
+
+	_, err := s.newMirror(c).Hash256(charm.MustParseURL("cs:trusty/mysql-23"))
+	c.Assert(err, gc.ErrorMatches, `getting hash of charm "cs:trusty/mysql-23" from mirror: decoding "trusty/mysql-23/meta/hash256": .*`)
+}
+
+// mirrorResource adds the given resource metadata and content to the
+// mirror, returning the metadata.
+func (s *MirrorSuite) mirrorResource(c *gc.C, revisionPath, resType, data string) params.Resource {
+	fp, err := resource.GenerateFingerprint(strings.NewReader(data))
+	c.Assert(err, jc.ErrorIsNil)
+	apiRes := params.Resource{
+		Name:        "name",
+		Type:        resType,
+		Path:        "foo.zip",
+		Revision:    5,
+		Fingerprint: fp.Bytes(),
+		Size:        int64(len(data)),
+	}
+	meta, err := json.Marshal(apiRes)
+	c.Assert(err, jc.ErrorIsNil)
+	s.content["/charmstore/mysql/meta/resource/name"+revisionPath] = string(meta)
+	s.content["/charmstore/mysql/resource/name/5"] = data
+	return apiRes
+}
+
+func (s *MirrorSuite) TestClientGetResourceFromMirror(c *gc.C) {
+	apiRes := s.mirrorResource(c, "", "file", "data")
+	wrapper := &fakeWrapper{
+		stub:       &testing.Stub{},
+		stableStub: &testing.Stub{},
+		devStub:    &testing.Stub{},
+	}
+	client, err := newCachingClient(&fakeMacCache{stub: &testing.Stub{}}, "", wrapper.makeWrapper)
+	c.Assert(err, jc.ErrorIsNil)
+	client = client.WithMirror(s.newMirror(c))
+
+	req := ResourceRequest{
+		Charm:    charm.MustParseURL("cs:mysql"),
+		Channel:  params.StableChannel,
+		Name:     "name",
+		Revision: -1,
+	}
+	data, err := client.GetResource(req)
+	c.Assert(err, jc.ErrorIsNil)
+	defer data.Close()
+	expected, err := params.API2Resource(apiRes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(data.Resource, gc.DeepEquals, expected)
+	content, err := ioutil.ReadAll(data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Equals, "data")
+
+	// The metadata and content are only fetched from the mirror.
+	wrapper.stub.CheckCallNames(c, "makeWrapper")
+	c.Check(s.requests, jc.DeepEquals, []string{
+		"/charmstore/mysql/meta/resource/name",
+		"/charmstore/mysql/resource/name/5",
+	})
+}
+
+func (s *MirrorSuite) TestClientGetResourceChecksumMismatch(c *gc.C) {
+	s.mirrorResource(c, "/5", "file", "data")
+	s.content["/charmstore/mysql/resource/name/5"] = "tampered"
+
+	_, err := s.newMirror(c).GetResource(ResourceRequest{
+		Charm:    charm.MustParseURL("cs:mysql"),
+		Name:     "name",
+		Revision: 5,
+	})
+	c.Assert(err, gc.ErrorMatches, `downloading resource "name" of charm "cs:mysql" from mirror: checksum .* does not match metadata .*`)
+}
+
+func (s *MirrorSuite) TestClientGetResourceNotFile(c *gc.C) {
+	s.mirrorResource(c, "/5", "oci-image", `{"ImageName": "mysql"}`)
+	// The content of an image resource isn't checked, as it only
+	// describes where the image is fetched from.
+	s.content["/charmstore/mysql/resource/name/5"] = `{"ImageName": "mysql:8"}`
+
+	data, err := s.newMirror(c).GetResource(ResourceRequest{
+		Charm:    charm.MustParseURL("cs:mysql"),
+		Name:     "name",
+		Revision: 5,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer data.Close()
+	content, err := ioutil.ReadAll(data)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(content), gc.Equals, `{"ImageName": "mysql:8"}`)
+}
+
+func (s *MirrorSuite) TestClientResourceInfoFromMirror(c *gc.C) {
+	apiRes := s.mirrorResource(c, "/5", "file", "data")
+	wrapper := &fakeWrapper{
+		stub:       &testing.Stub{},
+		stableStub: &testing.Stub{},
+		devStub:    &testing.Stub{},
+	}
+	client, err := newCachingClient(&fakeMacCache{stub: &testing.Stub{}}, "", wrapper.makeWrapper)
+	c.Assert(err, jc.ErrorIsNil)
+	client = client.WithMirror(s.newMirror(c))
+
+	res, err := client.ResourceInfo(ResourceRequest{
+		Charm:    charm.MustParseURL("cs:mysql"),
+		Name:     "name",
+		Revision: 5,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expected, err := params.API2Resource(apiRes)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, gc.DeepEquals, expected)
+	wrapper.stub.CheckCallNames(c, "makeWrapper")
+}
@@ -0,0 +1,108 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/action"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// ActionResult describes an action and, once it has completed, its
+// results.
+type ActionResult struct {
+	ID     string
+	Unit   string
+	Name   string
+	Params map[string]interface{}
+
+	// Status is one of "pending", "running", "completed", "failed"
+	// or "cancelled".
+	Status  string
+	Message string
+	Output  map[string]interface{}
+
+	Enqueued  time.Time
+	Started   time.Time
+	Completed time.Time
+}
+
+// RunAction queues the named action to be run on the named unit with
+// the given parameters, and returns the id of the action. Use
+// ActionResult to find out when the action completes.
+func (c *Client) RunAction(ctx context.Context, unitName, actionName string, parameters map[string]interface{}) (string, error) {
+	if !names.IsValidUnit(unitName) {
+		return "", errors.NotValidf("unit name %q", unitName)
+	}
+	results, err := action.NewClient(c.callerFor(ctx)).Enqueue(params.Actions{
+		Actions: []params.Action{{
+			Receiver:   names.NewUnitTag(unitName).String(),
+			Name:       actionName,
+			Parameters: parameters,
+		}},
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return "", errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return "", result.Error
+	}
+	if result.Action == nil {
+		return "", errors.New("action not queued")
+	}
+	tag, err := names.ParseActionTag(result.Action.Tag)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return tag.Id(), nil
+}
+
+// ActionResult returns the action with the given id, along with its
+// results if it has completed.
+func (c *Client) ActionResult(ctx context.Context, id string) (*ActionResult, error) {
+	if !names.IsValidAction(id) {
+		return nil, errors.NotValidf("action id %q", id)
+	}
+	results, err := action.NewClient(c.callerFor(ctx)).Actions(params.Entities{
+		Entities: []params.Entity{{Tag: names.NewActionTag(id).String()}},
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.Action == nil {
+		return nil, errors.NotFoundf("action %q", id)
+	}
+	unitTag, err := names.ParseUnitTag(result.Action.Receiver)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &ActionResult{
+		ID:        id,
+		Unit:      unitTag.Id(),
+		Name:      result.Action.Name,
+		Params:    result.Action.Parameters,
+		Status:    result.Status,
+		Message:   result.Message,
+		Output:    result.Output,
+		Enqueued:  result.Enqueued,
+		Started:   result.Started,
+		Completed: result.Completed,
+	}, nil
+}
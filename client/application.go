@@ -0,0 +1,115 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+	csparams "gopkg.in/juju/charmrepo.v3/csclient/params"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/charmstore"
+	"github.com/juju/juju/core/model"
+)
+
+// DeployArgs holds the arguments for deploying an application.
+type DeployArgs struct {
+	// CharmURL is the charm store URL of the charm to deploy. It
+	// must include the charm's revision, for example
+	// "cs:bionic/mysql-58".
+	CharmURL string
+
+	// Channel is the charm store channel to deploy the charm from.
+	// If empty, the stable channel is used.
+	Channel string
+
+	// Application is the name of the application. If empty, the
+	// name of the charm is used.
+	Application string
+
+	// Series is the series to deploy the application on. If empty,
+	// the series in the charm URL is used.
+	Series string
+
+	// NumUnits is the number of units to add to the application.
+	NumUnits int
+
+	// Config holds charm configuration settings for the application.
+	Config map[string]string
+}
+
+// Deploy deploys an application from the charm store.
+func (c *Client) Deploy(ctx context.Context, args DeployArgs) error {
+	curl, err := charm.ParseURL(args.CharmURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if curl.Schema != "cs" {
+		return errors.NotSupportedf("deploying charm %q which is not from the charm store", args.CharmURL)
+	}
+	if curl.Revision < 0 {
+		return errors.NotValidf("charm URL %q without a revision", args.CharmURL)
+	}
+	series := args.Series
+	if series == "" {
+		series = curl.Series
+	}
+	if series == "" {
+		return errors.NotValidf("charm URL %q without a series, when no series is specified", args.CharmURL)
+	}
+	name := args.Application
+	if name == "" {
+		name = curl.Name
+	}
+
+	caller := c.callerFor(ctx)
+	facade := base.NewFacadeCaller(caller, "Client")
+	addCharm := params.AddCharm{
+		URL:     curl.String(),
+		Channel: args.Channel,
+	}
+	if err := facade.FacadeCall("AddCharm", addCharm, nil); err != nil {
+		return errors.Annotatef(err, "adding charm %q", curl)
+	}
+	err = application.NewClient(caller).Deploy(application.DeployArgs{
+		CharmID: charmstore.CharmID{
+			URL:     curl,
+			Channel: csparams.Channel(args.Channel),
+		},
+		ApplicationName: name,
+		Series:          series,
+		NumUnits:        args.NumUnits,
+		Config:          args.Config,
+	})
+	return errors.Annotatef(err, "deploying application %q", name)
+}
+
+// ApplicationConfig returns the charm configuration settings of the
+// named application, including any default values.
+func (c *Client) ApplicationConfig(ctx context.Context, applicationName string) (map[string]interface{}, error) {
+	results, err := application.NewClient(c.callerFor(ctx)).Get(model.GenerationMaster, applicationName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	config := make(map[string]interface{})
+	for key, attrs := range results.CharmConfig {
+		if attrs, ok := attrs.(map[string]interface{}); ok {
+			if value, ok := attrs["value"]; ok {
+				config[key] = value
+			}
+		}
+	}
+	return config, nil
+}
+
+// SetApplicationConfig updates the charm configuration settings of the
+// named application.
+func (c *Client) SetApplicationConfig(ctx context.Context, applicationName string, config map[string]string) error {
+	err := application.NewClient(c.callerFor(ctx)).SetApplicationConfig(model.GenerationMaster, applicationName, config)
+	return errors.Trace(err)
+}
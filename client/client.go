@@ -0,0 +1,96 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api"
+	"github.com/juju/juju/api/base"
+)
+
+// ConnectParams holds the parameters needed to connect to a model.
+type ConnectParams struct {
+	// Addresses holds the host:port addresses of the controller's
+	// API servers.
+	Addresses []string
+
+	// CACert holds the PEM-encoded CA certificate which signed the
+	// controller's certificate.
+	CACert string
+
+	// ModelUUID is the UUID of the model to connect to.
+	ModelUUID string
+
+	// Username and Password hold the credentials of the user to
+	// log in as.
+	Username string
+	Password string
+}
+
+// Client is a connection to a Juju model.
+type Client struct {
+	caller base.APICallCloser
+}
+
+// apiOpen is overridden in tests.
+var apiOpen = api.Open
+
+// Connect connects to the model described by the given parameters,
+// and logs in. The context bounds the time spent connecting; it is not
+// used by subsequent calls on the returned client.
+func Connect(ctx context.Context, p ConnectParams) (*Client, error) {
+	if len(p.Addresses) == 0 {
+		return nil, errors.NotValidf("empty controller addresses")
+	}
+	if !names.IsValidModel(p.ModelUUID) {
+		return nil, errors.NotValidf("model UUID %q", p.ModelUUID)
+	}
+	if !names.IsValidUser(p.Username) {
+		return nil, errors.NotValidf("username %q", p.Username)
+	}
+	info := &api.Info{
+		Addrs:    p.Addresses,
+		CACert:   p.CACert,
+		ModelTag: names.NewModelTag(p.ModelUUID),
+		Tag:      names.NewUserTag(p.Username),
+		Password: p.Password,
+	}
+	// A zero dial timeout means no timeout at all, so a context which
+	// has already expired must not get as far as dialing.
+	if err := ctx.Err(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	opts := api.DefaultDialOpts()
+	if deadline, ok := ctx.Deadline(); ok {
+		opts.Timeout = time.Until(deadline)
+		if opts.Timeout <= 0 {
+			return nil, errors.Trace(context.DeadlineExceeded)
+		}
+	}
+	conn, err := apiOpen(info, opts)
+	if err != nil {
+		return nil, errors.Annotate(err, "connecting to model")
+	}
+	if err := ctx.Err(); err != nil {
+		conn.Close()
+		return nil, errors.Trace(err)
+	}
+	return &Client{caller: conn}, nil
+}
+
+// Close closes the connection to the model.
+func (c *Client) Close() error {
+	return c.caller.Close()
+}
+
+// callerFor returns an API caller whose calls are bound to the given
+// context.
+func (c *Client) callerFor(ctx context.Context) base.APICallCloser {
+	return base.WithContext(ctx, c.caller)
+}
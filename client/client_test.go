@@ -0,0 +1,398 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/client"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type clientSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&clientSuite{})
+
+func (s *clientSuite) TestConnect(c *gc.C) {
+	conn := &fakeConnection{}
+	var info *api.Info
+	s.PatchValue(client.APIOpen, func(i *api.Info, opts api.DialOpts) (api.Connection, error) {
+		info = i
+		return conn, nil
+	})
+	cl, err := client.Connect(context.Background(), client.ConnectParams{
+		Addresses: []string{"10.0.0.1:17070"},
+		CACert:    coretesting.CACert,
+		ModelUUID: coretesting.ModelTag.Id(),
+		Username:  "bob",
+		Password:  "hunter2",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info, jc.DeepEquals, &api.Info{
+		Addrs:    []string{"10.0.0.1:17070"},
+		CACert:   coretesting.CACert,
+		ModelTag: coretesting.ModelTag,
+		Tag:      names.NewUserTag("bob"),
+		Password: "hunter2",
+	})
+	c.Assert(cl.Close(), jc.ErrorIsNil)
+	c.Assert(conn.closed, jc.IsTrue)
+}
+
+func (s *clientSuite) TestConnectValidation(c *gc.C) {
+	s.PatchValue(client.APIOpen, func(*api.Info, api.DialOpts) (api.Connection, error) {
+		c.Fatalf("unexpected connection")
+		return nil, nil
+	})
+	valid := client.ConnectParams{
+		Addresses: []string{"10.0.0.1:17070"},
+		ModelUUID: coretesting.ModelTag.Id(),
+		Username:  "bob",
+	}
+	p := valid
+	p.Addresses = nil
+	_, err := client.Connect(context.Background(), p)
+	c.Check(err, gc.ErrorMatches, "empty controller addresses not valid")
+
+	p = valid
+	p.ModelUUID = "foo"
+	_, err = client.Connect(context.Background(), p)
+	c.Check(err, gc.ErrorMatches, `model UUID "foo" not valid`)
+
+	p = valid
+	p.Username = "bob!"
+	_, err = client.Connect(context.Background(), p)
+	c.Check(err, gc.ErrorMatches, `username "bob!" not valid`)
+}
+
+func (s *clientSuite) TestConnectDeadline(c *gc.C) {
+	conn := &fakeConnection{}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	s.PatchValue(client.APIOpen, func(i *api.Info, opts api.DialOpts) (api.Connection, error) {
+		c.Check(opts.Timeout > 0, jc.IsTrue)
+		c.Check(opts.Timeout <= time.Minute, jc.IsTrue)
+		// The context is cancelled while connecting.
+		cancel()
+		return conn, nil
+	})
+	_, err := client.Connect(ctx, client.ConnectParams{
+		Addresses: []string{"10.0.0.1:17070"},
+		ModelUUID: coretesting.ModelTag.Id(),
+		Username:  "bob",
+	})
+	c.Assert(errors.Cause(err), gc.Equals, context.Canceled)
+	c.Assert(conn.closed, jc.IsTrue)
+}
+
+func (s *clientSuite) TestConnectExpiredContext(c *gc.C) {
+	s.PatchValue(client.APIOpen, func(*api.Info, api.DialOpts) (api.Connection, error) {
+		c.Fatalf("unexpected connection")
+		return nil, nil
+	})
+	p := client.ConnectParams{
+		Addresses: []string{"10.0.0.1:17070"},
+		ModelUUID: coretesting.ModelTag.Id(),
+		Username:  "bob",
+	}
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	_, err := client.Connect(ctx, p)
+	c.Check(errors.Cause(err), gc.Equals, context.DeadlineExceeded)
+
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	_, err = client.Connect(ctx, p)
+	c.Check(errors.Cause(err), gc.Equals, context.Canceled)
+}
+
+func (s *clientSuite) TestStatus(c *gc.C) {
+	caller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Client")
+		c.Check(request, gc.Equals, "FullStatus")
+		c.Check(arg, jc.DeepEquals, params.StatusParams{Patterns: []string{"mysql"}})
+		*(result.(*params.FullStatus)) = params.FullStatus{
+			Model: params.ModelStatusInfo{
+				Name:        "default",
+				CloudTag:    "cloud-aws",
+				CloudRegion: "us-east-1",
+				Version:     "2.8.0",
+				ModelStatus: params.DetailedStatus{Status: "available"},
+			},
+			Applications: map[string]params.ApplicationStatus{
+				"mysql": {
+					Charm:  "cs:bionic/mysql-58",
+					Series: "bionic",
+					Status: params.DetailedStatus{Status: "active", Info: "ready"},
+					Units: map[string]params.UnitStatus{
+						"mysql/0": {
+							WorkloadStatus: params.DetailedStatus{Status: "active", Info: "ready"},
+							AgentStatus:    params.DetailedStatus{Status: "idle"},
+							Machine:        "0",
+							PublicAddress:  "10.0.0.2",
+							Leader:         true,
+						},
+					},
+				},
+			},
+			Machines: map[string]params.MachineStatus{
+				"0": {
+					AgentStatus: params.DetailedStatus{Status: "started"},
+					InstanceId:  "i-123",
+					DNSName:     "10.0.0.2",
+					Series:      "bionic",
+				},
+			},
+		}
+		return nil
+	})
+	status, err := client.NewClientForTest(caller).Status(context.Background(), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, &client.Status{
+		Model: client.ModelStatus{
+			Name:    "default",
+			Cloud:   "aws",
+			Region:  "us-east-1",
+			Version: "2.8.0",
+			Status:  "available",
+		},
+		Applications: map[string]client.ApplicationStatus{
+			"mysql": {
+				Charm:   "cs:bionic/mysql-58",
+				Series:  "bionic",
+				Status:  "active",
+				Message: "ready",
+				Units: map[string]client.UnitStatus{
+					"mysql/0": {
+						WorkloadStatus:  "active",
+						WorkloadMessage: "ready",
+						AgentStatus:     "idle",
+						Machine:         "0",
+						PublicAddress:   "10.0.0.2",
+						Leader:          true,
+					},
+				},
+			},
+		},
+		Machines: map[string]client.MachineStatus{
+			"0": {
+				Status:     "started",
+				InstanceId: "i-123",
+				DNSName:    "10.0.0.2",
+				Series:     "bionic",
+			},
+		},
+	})
+}
+
+func (s *clientSuite) TestDeploy(c *gc.C) {
+	var stub testing.Stub
+	caller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		stub.AddCall(objType+"."+request, arg)
+		if request == "Deploy" {
+			*(result.(*params.ErrorResults)) = params.ErrorResults{Results: []params.ErrorResult{{}}}
+		}
+		return nil
+	})
+	err := client.NewClientForTest(caller).Deploy(context.Background(), client.DeployArgs{
+		CharmURL: "cs:bionic/mysql-58",
+		Channel:  "edge",
+		NumUnits: 2,
+		Config:   map[string]string{"dataset-size": "50%"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	stub.CheckCalls(c, []testing.StubCall{{
+		"Client.AddCharm", []interface{}{params.AddCharm{
+			URL:     "cs:bionic/mysql-58",
+			Channel: "edge",
+		}},
+	}, {
+		"Application.Deploy", []interface{}{params.ApplicationsDeploy{
+			Applications: []params.ApplicationDeploy{{
+				ApplicationName: "mysql",
+				Series:          "bionic",
+				CharmURL:        "cs:bionic/mysql-58",
+				Channel:         "edge",
+				NumUnits:        2,
+				Config:          map[string]string{"dataset-size": "50%"},
+				AttachStorage:   []string{},
+			}},
+		}},
+	}})
+}
+
+func (s *clientSuite) TestDeployInvalidCharmURL(c *gc.C) {
+	caller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s.%s", objType, request)
+		return nil
+	})
+	cl := client.NewClientForTest(caller)
+	err := cl.Deploy(context.Background(), client.DeployArgs{CharmURL: "cs:bionic/mysql"})
+	c.Check(err, gc.ErrorMatches, `charm URL "cs:bionic/mysql" without a revision not valid`)
+	err = cl.Deploy(context.Background(), client.DeployArgs{CharmURL: "local:bionic/mysql-1"})
+	c.Check(err, gc.ErrorMatches, `deploying charm "local:bionic/mysql-1" which is not from the charm store not supported`)
+	err = cl.Deploy(context.Background(), client.DeployArgs{CharmURL: "cs:mysql-58"})
+	c.Check(err, gc.ErrorMatches, `charm URL "cs:mysql-58" without a series, when no series is specified not valid`)
+}
+
+func (s *clientSuite) TestApplicationConfig(c *gc.C) {
+	caller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Application")
+		c.Check(request, gc.Equals, "Get")
+		c.Check(arg, jc.DeepEquals, params.ApplicationGet{
+			ApplicationName: "mysql",
+			BranchName:      "master",
+		})
+		*(result.(*params.ApplicationGetResults)) = params.ApplicationGetResults{
+			CharmConfig: map[string]interface{}{
+				"dataset-size": map[string]interface{}{
+					"value":  "50%",
+					"source": "user",
+				},
+				"port": map[string]interface{}{
+					"value":  3306,
+					"source": "default",
+				},
+				"unset": map[string]interface{}{
+					"source": "unset",
+				},
+			},
+		}
+		return nil
+	})
+	config, err := client.NewClientForTest(caller).ApplicationConfig(context.Background(), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(config, jc.DeepEquals, map[string]interface{}{
+		"dataset-size": "50%",
+		"port":         3306,
+	})
+}
+
+func (s *clientSuite) TestSetApplicationConfig(c *gc.C) {
+	caller := basetesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Application")
+			c.Check(request, gc.Equals, "SetApplicationsConfig")
+			c.Check(arg, jc.DeepEquals, params.ApplicationConfigSetArgs{
+				Args: []params.ApplicationConfigSet{{
+					ApplicationName: "mysql",
+					Generation:      "master",
+					Config:          map[string]string{"dataset-size": "50%"},
+				}},
+			})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{Results: []params.ErrorResult{{}}}
+			return nil
+		},
+		BestVersion: 6,
+	}
+	err := client.NewClientForTest(caller).SetApplicationConfig(
+		context.Background(), "mysql", map[string]string{"dataset-size": "50%"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *clientSuite) TestRunAction(c *gc.C) {
+	caller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Action")
+		c.Check(request, gc.Equals, "Enqueue")
+		c.Check(arg, jc.DeepEquals, params.Actions{
+			Actions: []params.Action{{
+				Receiver:   "unit-mysql-0",
+				Name:       "backup",
+				Parameters: map[string]interface{}{"compress": true},
+			}},
+		})
+		*(result.(*params.ActionResults)) = params.ActionResults{
+			Results: []params.ActionResult{{
+				Action: &params.Action{Tag: "action-42"},
+			}},
+		}
+		return nil
+	})
+	id, err := client.NewClientForTest(caller).RunAction(
+		context.Background(), "mysql/0", "backup", map[string]interface{}{"compress": true},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(id, gc.Equals, "42")
+}
+
+func (s *clientSuite) TestRunActionError(c *gc.C) {
+	caller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		*(result.(*params.ActionResults)) = params.ActionResults{
+			Results: []params.ActionResult{{
+				Error: &params.Error{Message: "no action named backup"},
+			}},
+		}
+		return nil
+	})
+	_, err := client.NewClientForTest(caller).RunAction(context.Background(), "mysql/0", "backup", nil)
+	c.Assert(err, gc.ErrorMatches, "no action named backup")
+}
+
+func (s *clientSuite) TestActionResult(c *gc.C) {
+	completed := time.Date(2020, 4, 1, 10, 0, 0, 0, time.UTC)
+	caller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Check(objType, gc.Equals, "Action")
+		c.Check(request, gc.Equals, "Actions")
+		c.Check(arg, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "action-42"}},
+		})
+		*(result.(*params.ActionResults)) = params.ActionResults{
+			Results: []params.ActionResult{{
+				Action: &params.Action{
+					Tag:      "action-42",
+					Receiver: "unit-mysql-0",
+					Name:     "backup",
+				},
+				Status:    "completed",
+				Output:    map[string]interface{}{"path": "/tmp/backup.tgz"},
+				Completed: completed,
+			}},
+		}
+		return nil
+	})
+	result, err := client.NewClientForTest(caller).ActionResult(context.Background(), "42")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, &client.ActionResult{
+		ID:        "42",
+		Unit:      "mysql/0",
+		Name:      "backup",
+		Status:    "completed",
+		Output:    map[string]interface{}{"path": "/tmp/backup.tgz"},
+		Completed: completed,
+	})
+}
+
+func (s *clientSuite) TestCallsUseContext(c *gc.C) {
+	caller := basetesting.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+		c.Fatalf("unexpected call to %s.%s", objType, request)
+		return nil
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.NewClientForTest(caller).Status(ctx)
+	c.Assert(errors.Cause(err), gc.Equals, context.Canceled)
+}
+
+type fakeConnection struct {
+	api.Connection
+	closed bool
+}
+
+func (c *fakeConnection) Close() error {
+	c.closed = true
+	return nil
+}
@@ -0,0 +1,21 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package client provides a minimal Go client for Juju, intended for
+// tools which integrate with Juju from outside this repository, such as
+// CI systems and infrastructure-as-code providers.
+//
+// The packages under api and apiserver/params change as the controller
+// evolves, and are not meant to be imported by other projects. This
+// package is: it covers connecting to a model, reading its status,
+// deploying and configuring applications and running actions, and its
+// types do not expose types from Juju's internal packages.
+//
+// The package is not a separate Go module; it is versioned and
+// released with Juju itself, so tools should pin the Juju release they
+// import it from. Within a major release of Juju, its exported names
+// are neither removed nor changed incompatibly.
+//
+// Every call takes a context.Context; cancelling the context, or
+// letting its deadline pass, abandons the call.
+package client
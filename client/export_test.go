@@ -0,0 +1,16 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"github.com/juju/juju/api/base"
+)
+
+var APIOpen = &apiOpen
+
+// NewClientForTest returns a client which makes calls using the
+// given caller.
+func NewClientForTest(caller base.APICallCloser) *Client {
+	return &Client{caller: caller}
+}
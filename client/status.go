@@ -0,0 +1,130 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client
+
+import (
+	"context"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Status describes the status of a model.
+type Status struct {
+	Model        ModelStatus
+	Applications map[string]ApplicationStatus
+	Machines     map[string]MachineStatus
+}
+
+// ModelStatus describes the status of the model itself.
+type ModelStatus struct {
+	Name    string
+	Cloud   string
+	Region  string
+	Version string
+	Status  string
+	Message string
+}
+
+// ApplicationStatus describes the status of an application.
+type ApplicationStatus struct {
+	Charm   string
+	Series  string
+	Exposed bool
+	Status  string
+	Message string
+	Units   map[string]UnitStatus
+}
+
+// UnitStatus describes the status of a unit.
+type UnitStatus struct {
+	WorkloadStatus  string
+	WorkloadMessage string
+	AgentStatus     string
+	AgentMessage    string
+	Machine         string
+	PublicAddress   string
+	Leader          bool
+
+	// Subordinates holds the status of the unit's subordinate
+	// units, keyed by unit name.
+	Subordinates map[string]UnitStatus
+}
+
+// MachineStatus describes the status of a machine.
+type MachineStatus struct {
+	Status     string
+	Message    string
+	InstanceId string
+	DNSName    string
+	Series     string
+}
+
+// Status returns the status of the model. If patterns are specified,
+// only the matching machines, applications and units are included, as
+// with "juju status".
+func (c *Client) Status(ctx context.Context, patterns ...string) (*Status, error) {
+	facade := base.NewFacadeCaller(c.callerFor(ctx), "Client")
+	var result params.FullStatus
+	if err := facade.FacadeCall("FullStatus", params.StatusParams{Patterns: patterns}, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	status := &Status{
+		Model: ModelStatus{
+			Name:    result.Model.Name,
+			Region:  result.Model.CloudRegion,
+			Version: result.Model.Version,
+			Status:  result.Model.ModelStatus.Status,
+			Message: result.Model.ModelStatus.Info,
+		},
+		Applications: make(map[string]ApplicationStatus, len(result.Applications)),
+		Machines:     make(map[string]MachineStatus, len(result.Machines)),
+	}
+	if cloudTag, err := names.ParseCloudTag(result.Model.CloudTag); err == nil {
+		status.Model.Cloud = cloudTag.Id()
+	}
+	for name, app := range result.Applications {
+		status.Applications[name] = ApplicationStatus{
+			Charm:   app.Charm,
+			Series:  app.Series,
+			Exposed: app.Exposed,
+			Status:  app.Status.Status,
+			Message: app.Status.Info,
+			Units:   unitStatuses(app.Units),
+		}
+	}
+	for id, machine := range result.Machines {
+		status.Machines[id] = MachineStatus{
+			Status:     machine.AgentStatus.Status,
+			Message:    machine.AgentStatus.Info,
+			InstanceId: string(machine.InstanceId),
+			DNSName:    machine.DNSName,
+			Series:     machine.Series,
+		}
+	}
+	return status, nil
+}
+
+func unitStatuses(units map[string]params.UnitStatus) map[string]UnitStatus {
+	if len(units) == 0 {
+		return nil
+	}
+	result := make(map[string]UnitStatus, len(units))
+	for name, unit := range units {
+		result[name] = UnitStatus{
+			WorkloadStatus:  unit.WorkloadStatus.Status,
+			WorkloadMessage: unit.WorkloadStatus.Info,
+			AgentStatus:     unit.AgentStatus.Status,
+			AgentMessage:    unit.AgentStatus.Info,
+			Machine:         unit.Machine,
+			PublicAddress:   unit.PublicAddress,
+			Leader:          unit.Leader,
+			Subordinates:    unitStatuses(unit.Subordinates),
+		}
+	}
+	return result
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/schema"
@@ -59,6 +60,21 @@ type Credential struct {
 	// InvalidReason contains the reason why a credential was flagged as invalid.
 	// It is expected that this string will be empty when a credential is valid.
 	InvalidReason string
+
+	// Expiry is the time at which the credential will no longer be
+	// accepted by the cloud. It is the zero time if the credential
+	// does not expire, or its expiry is not known.
+	Expiry time.Time
+}
+
+// CredentialExpiryWarningPeriod is how long before a credential
+// expires that users and models are warned of the expiry.
+const CredentialExpiryWarningPeriod = 7 * 24 * time.Hour
+
+// ExpiresBy returns whether the credential has a known expiry
+// at or before the given time.
+func (c Credential) ExpiresBy(t time.Time) bool {
+	return !c.Expiry.IsZero() && !c.Expiry.After(t)
 }
 
 // AuthType returns the authentication type.
@@ -82,14 +98,23 @@ func (c Credential) Attributes() map[string]string {
 	return copyStringMap(c.attributes)
 }
 
+// expiresKey is the key used to record a credential's expiry
+// alongside its attributes.
+const expiresKey = "expires"
+
 type credentialInternal struct {
 	AuthType   AuthType          `yaml:"auth-type"`
+	Expires    string            `yaml:"expires,omitempty"`
 	Attributes map[string]string `yaml:",omitempty,inline"`
 }
 
 // MarshalYAML implements the yaml.Marshaler interface.
 func (c Credential) MarshalYAML() (interface{}, error) {
-	return credentialInternal{c.authType, c.attributes}, nil
+	internal := credentialInternal{AuthType: c.authType, Attributes: c.attributes}
+	if !c.Expiry.IsZero() {
+		internal.Expires = c.Expiry.UTC().Format(time.RFC3339)
+	}
+	return internal, nil
 }
 
 // UnmarshalYAML implements the yaml.Marshaler interface.
@@ -98,10 +123,27 @@ func (c *Credential) UnmarshalYAML(unmarshal func(interface{}) error) error {
 	if err := unmarshal(&internal); err != nil {
 		return err
 	}
-	*c = Credential{authType: internal.AuthType, attributes: internal.Attributes}
+	expiry, err := parseExpiry(internal.Expires)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	*c = Credential{authType: internal.AuthType, attributes: internal.Attributes, Expiry: expiry}
 	return nil
 }
 
+// parseExpiry parses a credential expiry, which must be
+// an RFC3339 timestamp if it is set.
+func parseExpiry(expires string) (time.Time, error) {
+	if expires == "" {
+		return time.Time{}, nil
+	}
+	expiry, err := time.Parse(time.RFC3339, expires)
+	if err != nil {
+		return time.Time{}, errors.NotValidf("credential expiry %q", expires)
+	}
+	return expiry.UTC(), nil
+}
+
 // NewCredential returns a new, immutable, Credential with the supplied
 // auth-type and attributes.
 func NewCredential(authType AuthType, attributes map[string]string) Credential {
@@ -173,7 +215,7 @@ func FinalizeCredential(
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &Credential{authType: credential.authType, attributes: attrs}, nil
+	return &Credential{authType: credential.authType, attributes: attrs, Expiry: credential.Expiry}, nil
 }
 
 // Finalize finalizes the given credential attributes against the credential
@@ -401,15 +443,22 @@ func (c cloudCredentialValueChecker) Coerce(v interface{}, path []string) (inter
 		return nil, errors.Errorf("%v: missing auth-type", strings.Join(path, ""))
 	}
 
+	expires, _ := mapv[expiresKey].(string)
+	expiry, err := parseExpiry(expires)
+	if err != nil {
+		return nil, errors.Annotate(err, strings.Join(path, ""))
+	}
+
 	attrs := make(map[string]string)
 	delete(mapv, "auth-type")
+	delete(mapv, expiresKey)
 	for k, v := range mapv {
 		attrs[k] = v.(string)
 	}
 	if len(attrs) == 0 {
 		attrs = nil
 	}
-	return Credential{authType: AuthType(authType), attributes: attrs}, nil
+	return Credential{authType: AuthType(authType), attributes: attrs, Expiry: expiry}, nil
 }
 
 // ParseCredentials parses the given yaml bytes into Credentials, but does
@@ -446,7 +495,7 @@ func RemoveSecrets(
 			delete(redactedAttrs, attr.Name)
 		}
 	}
-	return &Credential{authType: credential.authType, attributes: redactedAttrs}, nil
+	return &Credential{authType: credential.authType, attributes: redactedAttrs, Expiry: credential.Expiry}, nil
 }
 
 // CredentialCollection holds CloudCredential(s) that are lazily validated.
@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"path/filepath"
 	"regexp"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -56,6 +57,31 @@ credentials:
 `[1:])
 }
 
+func (s *credentialsSuite) TestMarshalExpiry(c *gc.C) {
+	cred := cloud.NewCredential(cloud.AccessKeyAuthType, map[string]string{
+		"access-key": "key",
+		"secret-key": "secret",
+	})
+	cred.Expiry = time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	creds := map[string]cloud.CloudCredential{
+		"aws": {
+			AuthCredentials: map[string]cloud.Credential{"peter": cred},
+		},
+	}
+	out, err := cloud.MarshalCredentials(creds)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(out), gc.Matches, `(?s).*
+    peter:
+      auth-type: access-key
+      expires: "?2020-06-01T12:00:00Z"?
+      access-key: key
+.*`)
+
+	parsed, err := cloud.ParseCredentials(out)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(parsed, jc.DeepEquals, creds)
+}
+
 func (s *credentialsSuite) TestMarshalOpenstackAccessKey(c *gc.C) {
 	creds := map[string]cloud.CloudCredential{
 		"openstack": {
@@ -267,6 +293,50 @@ credentials:
 	})
 }
 
+func (s *credentialsSuite) TestParseCredentialsExpiry(c *gc.C) {
+	expected := cloud.NewCredential(cloud.AccessKeyAuthType, map[string]string{
+		"access-key": "key",
+		"secret-key": "secret",
+	})
+	expected.Expiry = time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC)
+	s.testParseCredentials(c, []byte(`
+credentials:
+  aws:
+    peter:
+      auth-type: access-key
+      expires: 2020-06-01T12:00:00+02:00
+      access-key: key
+      secret-key: secret
+`[1:]), map[string]cloud.CloudCredential{
+		"aws": {
+			AuthCredentials: map[string]cloud.Credential{
+				"peter": expected,
+			},
+		},
+	})
+}
+
+func (s *credentialsSuite) TestParseCredentialsInvalidExpiry(c *gc.C) {
+	s.testParseCredentialsError(c, []byte(`
+credentials:
+  aws:
+    peter:
+      auth-type: access-key
+      expires: next tuesday
+`[1:]), `.*credential expiry "next tuesday" not valid`)
+}
+
+func (s *credentialsSuite) TestExpiresBy(c *gc.C) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	cred := cloud.NewCredential(cloud.AccessKeyAuthType, nil)
+	c.Check(cred.ExpiresBy(now), jc.IsFalse)
+
+	cred.Expiry = now.Add(time.Hour)
+	c.Check(cred.ExpiresBy(now), jc.IsFalse)
+	c.Check(cred.ExpiresBy(now.Add(time.Hour)), jc.IsTrue)
+	c.Check(cred.ExpiresBy(now.Add(cloud.CredentialExpiryWarningPeriod)), jc.IsTrue)
+}
+
 func (s *credentialsSuite) TestParseCredentialsUnknownAuthType(c *gc.C) {
 	// Unknown auth-type is not validated by ParseCredentials.
 	// Validation is deferred to FinalizeCredential.
@@ -648,6 +718,23 @@ func (s *credentialsSuite) TestRemoveSecrets(c *gc.C) {
 	})
 }
 
+func (s *credentialsSuite) TestRemoveSecretsKeepsExpiry(c *gc.C) {
+	cred := cloud.NewCredential(cloud.UserPassAuthType, map[string]string{
+		"username": "user",
+		"password": "secret",
+	})
+	cred.Expiry = time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	sanitisedCred, err := cloud.RemoveSecrets(cred, map[cloud.AuthType]cloud.CredentialSchema{
+		cloud.UserPassAuthType: {{
+			"username", cloud.CredentialAttr{},
+		}, {
+			"password", cloud.CredentialAttr{Hidden: true},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sanitisedCred.Expiry, gc.Equals, cred.Expiry)
+}
+
 func (s *credentialsSuite) TestValidateFileAttrValue(c *gc.C) {
 	_, err := cloud.ValidateFileAttrValue("/xyz/nothing.blah")
 	c.Assert(err, gc.ErrorMatches, "invalid file path: /xyz/nothing.blah")
@@ -124,10 +124,16 @@ func (cfg *ubuntuCloudConfig) AddPackageCommands(
 	addUpdateScripts bool,
 	addUpgradeScripts bool,
 ) error {
+	// The package lists must be updated for packages from any
+	// additional sources to be installable.
+	sources := proxyCfg.AptSources()
+	for _, src := range sources {
+		cfg.AddPackageSource(src)
+	}
 	return addPackageCommandsCommon(
 		cfg,
 		proxyCfg,
-		addUpdateScripts,
+		addUpdateScripts || len(sources) > 0,
 		addUpgradeScripts,
 		cfg.series,
 	)
@@ -4,6 +4,7 @@
 package cloudinit
 
 import (
+	"github.com/juju/packaging"
 	"github.com/juju/proxy"
 	gc "gopkg.in/check.v1"
 )
@@ -91,6 +92,7 @@ func (HelperSuite) TestAddPkgCmdsCommon(c *gc.C) {
 type packageManagerProxySettings struct {
 	aptProxy            proxy.Settings
 	aptMirror           string
+	aptSources          []packaging.PackageSource
 	snapProxy           proxy.Settings
 	snapStoreAssertions string
 	snapStoreProxyID    string
@@ -103,3 +105,7 @@ func (p packageManagerProxySettings) SnapProxy() proxy.Settings   { return p.sna
 func (p packageManagerProxySettings) SnapStoreAssertions() string { return p.snapStoreAssertions }
 func (p packageManagerProxySettings) SnapStoreProxyID() string    { return p.snapStoreProxyID }
 func (p packageManagerProxySettings) SnapStoreProxyURL() string   { return p.snapStoreProxyURL }
+
+func (p packageManagerProxySettings) AptSources() []packaging.PackageSource {
+	return p.aptSources
+}
@@ -342,6 +342,7 @@ type RenderConfig interface {
 type PackageManagerProxyConfig interface {
 	AptProxy() proxy.Settings
 	AptMirror() string
+	AptSources() []packaging.PackageSource
 	SnapProxy() proxy.Settings
 	SnapStoreAssertions() string
 	SnapStoreProxyID() string
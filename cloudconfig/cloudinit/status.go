@@ -0,0 +1,62 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloudinit
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Status describes the progress of cloud-init on a machine, as
+// reported in the machine's console output.
+type Status string
+
+const (
+	// StatusUnknown is reported when the console output holds no
+	// cloud-init output.
+	StatusUnknown Status = "unknown"
+
+	// StatusRunning is reported when cloud-init has started but not
+	// yet finished.
+	StatusRunning Status = "running"
+
+	// StatusDone is reported when cloud-init has finished without
+	// reporting any errors.
+	StatusDone Status = "done"
+
+	// StatusError is reported when cloud-init has reported an error.
+	StatusError Status = "error"
+)
+
+var (
+	cloudInitStageRE    = regexp.MustCompile(`Cloud-init v\. \S+ running '[^']+'`)
+	cloudInitFinishedRE = regexp.MustCompile(`Cloud-init v\. \S+ finished`)
+	cloudInitErrorRE    = regexp.MustCompile(`Failed to run module|Failed running|\[CLOUDINIT\].*\b(ERROR|CRITICAL)\b`)
+)
+
+// StatusFromConsoleOutput returns the status of cloud-init as reported
+// in the console output of a machine, along with the line of output
+// from which the status was determined. The first error reported takes
+// precedence over cloud-init having finished.
+func StatusFromConsoleOutput(output string) (Status, string) {
+	var stage, finished string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case cloudInitErrorRE.MatchString(line):
+			return StatusError, line
+		case cloudInitFinishedRE.MatchString(line):
+			finished = line
+		case cloudInitStageRE.MatchString(line):
+			stage = line
+		}
+	}
+	switch {
+	case finished != "":
+		return StatusDone, finished
+	case stage != "":
+		return StatusRunning, stage
+	}
+	return StatusUnknown, ""
+}
@@ -0,0 +1,55 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloudinit_test
+
+import (
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cloudconfig/cloudinit"
+)
+
+type statusSuite struct{}
+
+var _ = gc.Suite(&statusSuite{})
+
+func (*statusSuite) TestStatusFromConsoleOutput(c *gc.C) {
+	for i, test := range []struct {
+		output string
+		status cloudinit.Status
+		detail string
+	}{{
+		output: "",
+		status: cloudinit.StatusUnknown,
+	}, {
+		output: "[    0.000000] Linux version 4.15.0-1057-aws\n",
+		status: cloudinit.StatusUnknown,
+	}, {
+		output: `
+[    5.012345] cloud-init[512]: Cloud-init v. 19.4-33 running 'init-local' at Mon, 02 Mar 2020 10:00:00 +0000. Up 5.01 seconds.
+[    8.123456] cloud-init[640]: Cloud-init v. 19.4-33 running 'init' at Mon, 02 Mar 2020 10:00:03 +0000. Up 8.12 seconds.
+`[1:],
+		status: cloudinit.StatusRunning,
+		detail: "[    8.123456] cloud-init[640]: Cloud-init v. 19.4-33 running 'init' at Mon, 02 Mar 2020 10:00:03 +0000. Up 8.12 seconds.",
+	}, {
+		output: `
+[    5.012345] cloud-init[512]: Cloud-init v. 19.4-33 running 'init-local' at Mon, 02 Mar 2020 10:00:00 +0000. Up 5.01 seconds.
+[   95.123456] cloud-init[980]: Cloud-init v. 19.4-33 finished at Mon, 02 Mar 2020 10:01:30 +0000. Datasource DataSourceEc2Local.  Up 95.12 seconds
+`[1:],
+		status: cloudinit.StatusDone,
+		detail: "[   95.123456] cloud-init[980]: Cloud-init v. 19.4-33 finished at Mon, 02 Mar 2020 10:01:30 +0000. Datasource DataSourceEc2Local.  Up 95.12 seconds",
+	}, {
+		output: `
+[    5.012345] cloud-init[512]: Cloud-init v. 19.4-33 running 'init-local' at Mon, 02 Mar 2020 10:00:00 +0000. Up 5.01 seconds.
+[   90.000000] cloud-init[980]: 2020-03-02 10:01:25,000 - cc_scripts_user.py[WARNING]: Failed to run module scripts-user (scripts in /var/lib/cloud/instance/scripts)
+[   95.123456] cloud-init[980]: Cloud-init v. 19.4-33 finished at Mon, 02 Mar 2020 10:01:30 +0000. Datasource DataSourceEc2Local.  Up 95.12 seconds
+`[1:],
+		status: cloudinit.StatusError,
+		detail: "[   90.000000] cloud-init[980]: 2020-03-02 10:01:25,000 - cc_scripts_user.py[WARNING]: Failed to run module scripts-user (scripts in /var/lib/cloud/instance/scripts)",
+	}} {
+		c.Logf("test %d", i)
+		status, detail := cloudinit.StatusFromConsoleOutput(test.output)
+		c.Check(status, gc.Equals, test.status)
+		c.Check(detail, gc.Equals, test.detail)
+	}
+}
@@ -148,6 +148,10 @@ type InstanceConfig struct {
 	// override the default APT sources.
 	AptMirror string
 
+	// AptSources holds additional APT repositories, PPAs or lines in
+	// sources.list format, which are added to the instance.
+	AptSources []config.AptSource
+
 	// SnapProxySettings define the http, https and ftp proxy settings to
 	// use for snap, which may or may not be the same as the normal
 	// ProxySettings.
@@ -864,6 +868,9 @@ type ProxyConfiguration struct {
 	// Apt mirror.
 	AptMirror string
 
+	// Additional apt repositories.
+	AptSources []config.AptSource
+
 	// SnapStoreAssertions contains a list of assertions that must be
 	// passed to snapd together with a store proxy ID parameter before it
 	// can connect to a snap store proxy.
@@ -888,6 +895,7 @@ func proxyConfigurationFromEnv(cfg *config.Config) ProxyConfiguration {
 		Juju:                cfg.JujuProxySettings(),
 		Apt:                 cfg.AptProxySettings(),
 		AptMirror:           cfg.AptMirror(),
+		AptSources:          cfg.AptSources(),
 		Snap:                cfg.SnapProxySettings(),
 		SnapStoreAssertions: cfg.SnapStoreAssertions(),
 		SnapStoreProxyID:    cfg.SnapStoreProxy(),
@@ -923,6 +931,7 @@ func PopulateInstanceConfig(icfg *InstanceConfig,
 	// No AutoNoProxy needed as juju no proxy values are CIDR aware.
 	icfg.AptProxySettings = proxyCfg.Apt
 	icfg.AptMirror = proxyCfg.AptMirror
+	icfg.AptSources = proxyCfg.AptSources
 	icfg.SnapProxySettings = proxyCfg.Snap
 	icfg.SnapStoreAssertions = proxyCfg.SnapStoreAssertions
 	icfg.SnapStoreProxyID = proxyCfg.SnapStoreProxyID
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/os"
 	"github.com/juju/os/series"
+	"github.com/juju/packaging"
 	"github.com/juju/proxy"
 	"github.com/juju/utils"
 	"gopkg.in/juju/names.v3"
@@ -17,6 +18,7 @@ import (
 	"github.com/juju/juju/agent"
 	"github.com/juju/juju/cloudconfig/cloudinit"
 	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/environs/config"
 )
 
 const (
@@ -207,6 +209,7 @@ func shquote(p string) string {
 type packageManagerProxySettings struct {
 	aptProxy            proxy.Settings
 	aptMirror           string
+	aptSources          []packaging.PackageSource
 	snapProxy           proxy.Settings
 	snapStoreAssertions string
 	snapStoreProxyID    string
@@ -219,6 +222,9 @@ func (p packageManagerProxySettings) AptProxy() proxy.Settings { return p.aptPro
 // AptMirror implements cloudinit.PackageManagerConfig.
 func (p packageManagerProxySettings) AptMirror() string { return p.aptMirror }
 
+// AptSources implements cloudinit.PackageManagerConfig.
+func (p packageManagerProxySettings) AptSources() []packaging.PackageSource { return p.aptSources }
+
 // SnapProxy implements cloudinit.PackageManagerConfig.
 func (p packageManagerProxySettings) SnapProxy() proxy.Settings { return p.snapProxy }
 
@@ -230,3 +236,19 @@ func (p packageManagerProxySettings) SnapStoreProxyID() string { return p.snapSt
 
 // SnapStoreProxyURL implements cloudinit.PackageManagerConfig.
 func (p packageManagerProxySettings) SnapStoreProxyURL() string { return p.snapStoreProxyURL }
+
+// aptPackageSources returns the package sources which add the given apt
+// sources to an instance.
+func aptPackageSources(sources []config.AptSource) []packaging.PackageSource {
+	if len(sources) == 0 {
+		return nil
+	}
+	result := make([]packaging.PackageSource, len(sources))
+	for i, src := range sources {
+		result[i] = packaging.PackageSource{
+			URL: src.Source,
+			Key: src.Key,
+		}
+	}
+	return result
+}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/juju/collections/set"
 	"github.com/juju/loggo"
+	"github.com/juju/packaging"
 	pacconf "github.com/juju/packaging/config"
 	"github.com/juju/proxy"
 	jc "github.com/juju/testing/checkers"
@@ -1339,6 +1340,28 @@ func (s *cloudinitSuite) testAptMirror(c *gc.C, cfg *config.Config, expect strin
 	//c.Assert(ok, gc.Equals, expect != "")
 }
 
+func (s *cloudinitSuite) TestAptSources(c *gc.C) {
+	environConfig := minimalModelConfig(c)
+	environConfig, err := environConfig.Apply(map[string]interface{}{
+		"apt-sources":              "- source: ppa:juju/stable",
+		"enable-os-refresh-update": false,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	instanceCfg := s.createInstanceConfig(c, environConfig)
+	cloudcfg, err := cloudinit.New("quantal")
+	c.Assert(err, jc.ErrorIsNil)
+	udata, err := cloudconfig.NewUserdataConfig(instanceCfg, cloudcfg)
+	c.Assert(err, jc.ErrorIsNil)
+	err = udata.Configure()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cloudcfg.PackageSources(), jc.DeepEquals, []packaging.PackageSource{{
+		URL: "ppa:juju/stable",
+	}})
+	// The package lists are updated so that packages from the
+	// additional sources can be installed.
+	c.Assert(cloudcfg.SystemUpdate(), jc.IsTrue)
+}
+
 var serverCert = []byte(`
 SERVER CERT
 -----BEGIN CERTIFICATE-----
@@ -287,6 +287,7 @@ func (w *unixConfigure) ConfigureJuju() error {
 		packageManagerProxySettings{
 			aptProxy:            w.icfg.AptProxySettings,
 			aptMirror:           w.icfg.AptMirror,
+			aptSources:          aptPackageSources(w.icfg.AptSources),
 			snapProxy:           w.icfg.SnapProxySettings,
 			snapStoreAssertions: w.icfg.SnapStoreAssertions,
 			snapStoreProxyID:    w.icfg.SnapStoreProxyID,
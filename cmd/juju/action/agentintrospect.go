@@ -0,0 +1,207 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/agentintrospection"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/actions"
+)
+
+// AgentIntrospectAPI defines the API methods used by the
+// agent-introspect command.
+type AgentIntrospectAPI interface {
+	Introspect(agent names.Tag, section string) (params.AgentIntrospectResult, error)
+	Close() error
+}
+
+const agentIntrospectDoc = `
+Show a section of the introspection data of a machine or unit agent,
+without needing to ssh to the machine it runs on. The sections are:
+
+    depengine    the state of the agent's dependency engine workers
+    goroutines   the stacks of the agent's goroutines
+    machinelock  the holder and waiters of the machine lock
+    metrics      the agent's prometheus metrics
+    leases       the leadership and singular leases held by the agent
+
+All but the leases section are fetched from the agent by an action run
+on its machine, which the command waits for; the leases are reported
+by the controller. You must be an admin of the model.
+
+Examples:
+    juju agent-introspect 0 depengine
+    juju agent-introspect mysql/0 goroutines
+    juju agent-introspect mysql/0 leases
+    juju agent-introspect 2 metrics --wait 5m
+
+See also:
+    show-action-output
+    show-leases
+`
+
+// defaultAgentIntrospectWait is how long the command waits for the
+// agent to report by default.
+const defaultAgentIntrospectWait = time.Minute
+
+// NewAgentIntrospectCommand returns a command that shows a section of
+// the introspection data of a machine or unit agent.
+func NewAgentIntrospectCommand() cmd.Command {
+	return modelcmd.Wrap(&agentIntrospectCommand{})
+}
+
+type agentIntrospectCommand struct {
+	ActionCommandBase
+
+	newIntrospectAPIFunc func() (AgentIntrospectAPI, error)
+	agent                names.Tag
+	section              string
+	wait                 time.Duration
+}
+
+// Info implements Command.Info.
+func (c *agentIntrospectCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "agent-introspect",
+		Args:    "<machine>|<unit> <section>",
+		Purpose: "Shows the introspection data of a machine or unit agent.",
+		Doc:     agentIntrospectDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *agentIntrospectCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ActionCommandBase.SetFlags(f)
+	f.DurationVar(&c.wait, "wait", defaultAgentIntrospectWait, "Maximum wait time for the agent to report")
+}
+
+// Init implements Command.Init.
+func (c *agentIntrospectCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no machine or unit specified")
+	case 1:
+		return errors.New("no section specified")
+	}
+	entity, section := args[0], args[1]
+	switch {
+	case names.IsValidMachine(entity):
+		c.agent = names.NewMachineTag(entity)
+	case names.IsValidUnit(entity):
+		c.agent = names.NewUnitTag(entity)
+	default:
+		return errors.NotValidf("machine or unit %q", entity)
+	}
+	if !isIntrospectionSection(section) {
+		return errors.Errorf("unknown section %q, expected one of %s",
+			section, strings.Join(introspectionSections(), ", "))
+	}
+	c.section = section
+	if c.wait <= 0 {
+		return errors.New("wait must be positive")
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+// introspectionSections returns the sections the command can show.
+func introspectionSections() []string {
+	sections := make([]string, 0, len(actions.IntrospectionSections)+1)
+	sections = append(sections, actions.IntrospectionSections...)
+	return append(sections, actions.LeasesIntrospectionSection)
+}
+
+func isIntrospectionSection(section string) bool {
+	for _, known := range introspectionSections() {
+		if section == known {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *agentIntrospectCommand) newIntrospectAPI() (AgentIntrospectAPI, error) {
+	if c.newIntrospectAPIFunc != nil {
+		return c.newIntrospectAPIFunc()
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return agentintrospection.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *agentIntrospectCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newIntrospectAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	result, err := client.Introspect(c.agent, c.section)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if result.Action == "" {
+		fmt.Fprint(ctx.Stdout, result.Output)
+		return nil
+	}
+	actionTag, err := names.ParseActionTag(result.Action)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	output, err := c.waitForOutput(actionTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	fmt.Fprint(ctx.Stdout, output)
+	return nil
+}
+
+// waitForOutput waits for the juju-introspect action with the given tag
+// to finish, and returns the introspection data it fetched.
+func (c *agentIntrospectCommand) waitForOutput(actionTag names.ActionTag) (string, error) {
+	api, err := c.NewActionAPIClient()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer api.Close()
+
+	wait := time.NewTimer(c.wait)
+	defer wait.Stop()
+	result, err := GetActionResult(api, actionTag.Id(), wait, true)
+	if errors.IsTimeout(err) {
+		return "", errors.Errorf("agent did not report within %v; see %q for the result",
+			c.wait, "juju show-action-output "+actionTag.Id())
+	} else if err != nil {
+		return "", errors.Trace(err)
+	}
+	if result.Status != params.ActionCompleted {
+		if result.Message != "" {
+			return "", errors.New(result.Message)
+		}
+		return "", errors.Errorf("action %s %s", actionTag.Id(), result.Status)
+	}
+	output, _ := result.Output["Output"].(string)
+	if encoding, _ := result.Output["OutputEncoding"].(string); encoding == "base64" {
+		decoded, err := base64.StdEncoding.DecodeString(output)
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		output = string(decoded)
+	}
+	return output, nil
+}
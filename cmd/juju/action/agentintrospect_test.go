@@ -0,0 +1,125 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/action"
+)
+
+type AgentIntrospectSuite struct {
+	BaseActionSuite
+	api *fakeIntrospectAPI
+}
+
+var _ = gc.Suite(&AgentIntrospectSuite{})
+
+const introspectActionId = "f47ac10b-58cc-4372-a567-0e02b2c3d479"
+
+func (s *AgentIntrospectSuite) SetUpTest(c *gc.C) {
+	s.BaseActionSuite.SetUpTest(c)
+	s.api = &fakeIntrospectAPI{}
+}
+
+func (s *AgentIntrospectSuite) run(c *gc.C, args ...string) (string, error) {
+	ctx, err := cmdtesting.RunCommand(c, action.NewAgentIntrospectCommandForTest(s.store, s.api), args...)
+	if err != nil {
+		return "", err
+	}
+	return cmdtesting.Stdout(ctx), nil
+}
+
+func (s *AgentIntrospectSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		err: "no machine or unit specified",
+	}, {
+		args: []string{"0"},
+		err:  "no section specified",
+	}, {
+		args: []string{"mysql", "depengine"},
+		err:  `machine or unit "mysql" not valid`,
+	}, {
+		args: []string{"0", "secrets"},
+		err:  `unknown section "secrets", expected one of depengine, goroutines, machinelock, metrics, leases`,
+	}, {
+		args: []string{"0", "depengine", "--wait", "0s"},
+		err:  "wait must be positive",
+	}, {
+		args: []string{"0", "depengine", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := s.run(c, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *AgentIntrospectSuite) TestLeases(c *gc.C) {
+	s.api.result = params.AgentIntrospectResult{
+		Output: "No leases held by unit mysql/0.\n",
+	}
+	out, err := s.run(c, "mysql/0", "leases")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "No leases held by unit mysql/0.\n")
+	c.Assert(s.api.agent, gc.Equals, names.NewUnitTag("mysql/0"))
+	c.Assert(s.api.section, gc.Equals, "leases")
+}
+
+func (s *AgentIntrospectSuite) TestFromAgent(c *gc.C) {
+	s.api.result = params.AgentIntrospectResult{Action: "action-" + introspectActionId}
+	tag := "action-" + introspectActionId
+	client := makeFakeClient(0, 5*time.Second, tagsForIdPrefix(introspectActionId, tag), []params.ActionResult{{
+		Action: &params.Action{Tag: tag},
+		Status: params.ActionCompleted,
+		Output: map[string]interface{}{
+			"Output": "Dependency Engine Report\n\nstate: started\n",
+		},
+	}}, params.ActionsByNames{}, "")
+	defer s.patchAPIClient(client)()
+
+	out, err := s.run(c, "0", "depengine")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "Dependency Engine Report\n\nstate: started\n")
+	c.Assert(s.api.agent, gc.Equals, names.NewMachineTag("0"))
+	c.Assert(s.api.section, gc.Equals, "depengine")
+}
+
+func (s *AgentIntrospectSuite) TestFromAgentFailed(c *gc.C) {
+	s.api.result = params.AgentIntrospectResult{Action: "action-" + introspectActionId}
+	tag := "action-" + introspectActionId
+	client := makeFakeClient(0, 5*time.Second, tagsForIdPrefix(introspectActionId, tag), []params.ActionResult{{
+		Action:  &params.Action{Tag: tag},
+		Status:  params.ActionFailed,
+		Message: `introspecting unit mysql/0: cannot query introspection socket "jujud-unit-mysql-0": connection refused`,
+	}}, params.ActionsByNames{}, "")
+	defer s.patchAPIClient(client)()
+
+	_, err := s.run(c, "mysql/0", "goroutines")
+	c.Assert(err, gc.ErrorMatches, `introspecting unit mysql/0: cannot query .*: connection refused`)
+}
+
+type fakeIntrospectAPI struct {
+	agent   names.Tag
+	section string
+	result  params.AgentIntrospectResult
+}
+
+func (f *fakeIntrospectAPI) Introspect(agent names.Tag, section string) (params.AgentIntrospectResult, error) {
+	f.agent, f.section = agent, section
+	return f.result, nil
+}
+
+func (*fakeIntrospectAPI) Close() error {
+	return nil
+}
@@ -126,6 +126,16 @@ func NewStatusCommandForTest(store jujuclient.ClientStore) (cmd.Command, *Status
 	return modelcmd.Wrap(c), &StatusCommand{c}
 }
 
+func NewAgentIntrospectCommandForTest(store jujuclient.ClientStore, api AgentIntrospectAPI) cmd.Command {
+	c := &agentIntrospectCommand{
+		newIntrospectAPIFunc: func() (AgentIntrospectAPI, error) {
+			return api, nil
+		},
+	}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
 func NewCancelCommandForTest(store jujuclient.ClientStore) (cmd.Command, *CancelCommand) {
 	c := &cancelCommand{}
 	c.SetClientStore(store)
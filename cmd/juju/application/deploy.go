@@ -497,6 +497,17 @@ behaviour use the ` + "`set-constraints`" + ` command to change the application'
 constraints or add a machine (` + "`add-machine`" + `) with a certain constraint and then
 target that machine with ` + "`add-unit`" + ` by using the '--to' option.
 
+In a Kubernetes model, constraints are applied to the application's pods:
+'cores', 'cpu-power' and 'mem' limit the cpu and memory of each container,
+and 'zones' restricts the pods to nodes in those availability zones, spreading
+them across the zones where it can. Each 'tags' value is one of:
+
+  <label>=<value>              schedule on nodes with that label
+  <label>=<value>|<value>...   schedule on nodes with one of those values
+  ^<label>=<value>[|<value>]   avoid nodes with any of those values
+  toleration:<key>[=<value>][:<effect>]
+                               tolerate a taint on the nodes
+
 Use the '--device' option to specify GPU device requirements (with Kubernetes).
 The below format is used for this option's value, where the 'label' is named in
 the charm metadata file:
@@ -638,6 +649,12 @@ Deploy to a machine that is in the 'dmz' network space but not in either the
 
     juju deploy haproxy -n 2 --constraints spaces=dmz,^cms,^database
 
+Deploy a k8s charm whose containers may use 2 cpu cores and 4 GiB of memory,
+on nodes labelled 'disk=ssd' that may be tainted 'dedicated=db':
+
+    juju deploy mariadb-k8s --constraints \
+       "cores=2 mem=4G tags=disk=ssd,toleration:dedicated=db"
+
 Deploy a k8s charm that requires a single Nvidia GPU:
 
     juju deploy mycharm --device miner=1,nvidia.com/gpu
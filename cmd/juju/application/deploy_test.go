@@ -1188,7 +1188,7 @@ func (s *DeploySuite) TestDeployFlags(c *gc.C) {
 	})
 	declaredFlags := append(charmAndBundleFlags, charmOnlyFlags()...)
 	declaredFlags = append(declaredFlags, bundleOnlyFlags...)
-	declaredFlags = append(declaredFlags, "B", "no-browser-login")
+	declaredFlags = append(declaredFlags, "B", "no-browser-login", "as-user")
 	sort.Strings(declaredFlags)
 	c.Assert(declaredFlags, jc.DeepEquals, allFlags)
 }
@@ -255,6 +255,33 @@ func NewSuspendRelationCommandForTest(api SetRelationSuspendedAPI, store jujucli
 	return modelcmd.Wrap(cmd)
 }
 
+// NewSetMaintenanceCommandForTest returns a SetMaintenanceCommand with the api provided as specified.
+func NewSetMaintenanceCommandForTest(api SetUnitsMaintenanceAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &setMaintenanceCommand{newAPIFunc: func() (SetUnitsMaintenanceAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewScalingPolicyCommandForTest returns a ScalingPolicyCommand with the api provided as specified.
+func NewScalingPolicyCommandForTest(api ScalingPolicyAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &scalingPolicyCommand{newAPIFunc: func() (ScalingPolicyAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewShowRelationCommandForTest returns a ShowRelationCommand with the api provided as specified.
+func NewShowRelationCommandForTest(api ShowRelationAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
+	cmd := &showRelationCommand{newAPIFunc: func() (ShowRelationAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 // NewResumeRelationCommandForTest returns a ResumeRelationCommand with the api provided as specified.
 func NewResumeRelationCommandForTest(api SetRelationSuspendedAPI, store jujuclient.ClientStore) modelcmd.ModelCommand {
 	cmd := &resumeRelationCommand{newAPIFunc: func() (SetRelationSuspendedAPI, error) {
@@ -5,6 +5,7 @@ package application
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/juju/errors"
@@ -200,3 +201,26 @@ func (m stringMap) String() string {
 	}
 	return strings.Join(pairs, ";")
 }
+
+// unitCountFlag is an optional, non-negative number of units.
+type unitCountFlag struct {
+	value *int
+}
+
+// Set implements gnuflag.Value.Set.
+func (f *unitCountFlag) Set(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 0 {
+		return errors.Errorf("expected a non-negative number of units, got %q", s)
+	}
+	f.value = &n
+	return nil
+}
+
+// String implements gnuflag.Value.String.
+func (f *unitCountFlag) String() string {
+	if f.value == nil {
+		return ""
+	}
+	return strconv.Itoa(*f.value)
+}
@@ -161,3 +161,7 @@ func (a *testApplicationRemoveUnitAPI) DestroyDeprecated(appName string) error {
 func (a *testApplicationRemoveUnitAPI) DestroyUnitsDeprecated(unitNames ...string) error {
 	panic("DestroyUnitsDeprecated not implemented here")
 }
+
+func (a *testApplicationRemoveUnitAPI) CheckUnitsForceRemoval(units []string) ([]params.UnitForceRemovalCheckResult, error) {
+	panic("CheckUnitsForceRemoval not implemented here")
+}
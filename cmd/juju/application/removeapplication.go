@@ -110,6 +110,7 @@ type RemoveApplicationAPI interface {
 	DestroyDeprecated(appName string) error
 	DestroyUnits(application.DestroyUnitsParams) ([]params.DestroyUnitResult, error)
 	DestroyUnitsDeprecated(unitNames ...string) error
+	CheckUnitsForceRemoval(units []string) ([]params.UnitForceRemovalCheckResult, error)
 	ModelUUID() string
 	BestAPIVersion() int
 }
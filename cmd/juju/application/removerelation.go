@@ -41,10 +41,19 @@ However, at times, there is a need to remove a relation ignoring
 all operational errors. In these rare cases, use --force option but note 
 that --force will remove a relation without giving it the opportunity to be removed cleanly.
 
+Units leaving the relation run their relation-departed and relation-broken
+hooks before the relation is removed. A unit whose hooks never complete
+keeps the relation from being removed. The relation-drain-timeout model
+configuration bounds how long departing units are given; units still in
+the relation once it has elapsed are forced out of it. Use --drain-timeout
+to override the model configuration for this removal, where 0 disables
+the drain window.
+
 Examples:
     juju remove-relation mysql wordpress
     juju remove-relation 4
     juju remove-relation 4 --force
+    juju remove-relation mysql wordpress --drain-timeout 10m
 
 In the case of multiple relations, the relation name should be specified
 at least once - the following examples will all have the same effect:
@@ -74,12 +83,13 @@ func NewRemoveRelationCommand() cmd.Command {
 // removeRelationCommand causes an existing application relation to be shut down.
 type removeRelationCommand struct {
 	modelcmd.ModelCommandBase
-	RelationId int
-	Endpoints  []string
-	newAPIFunc func() (ApplicationDestroyRelationAPI, error)
-	Force      bool
-	NoWait     bool
-	fs         *gnuflag.FlagSet
+	RelationId   int
+	Endpoints    []string
+	newAPIFunc   func() (ApplicationDestroyRelationAPI, error)
+	Force        bool
+	NoWait       bool
+	DrainTimeout time.Duration
+	fs           *gnuflag.FlagSet
 }
 
 func (c *removeRelationCommand) Info() *cmd.Info {
@@ -108,6 +118,7 @@ func (c *removeRelationCommand) Init(args []string) (err error) {
 func (c *removeRelationCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ModelCommandBase.SetFlags(f)
 	f.BoolVar(&c.Force, "force", false, "Force remove a relation")
+	f.DurationVar(&c.DrainTimeout, "drain-timeout", 0, "How long departing units are given before being forced out of the relation")
 	c.fs = f
 }
 
@@ -115,23 +126,34 @@ func (c *removeRelationCommand) SetFlags(f *gnuflag.FlagSet) {
 type ApplicationDestroyRelationAPI interface {
 	Close() error
 	BestAPIVersion() int
-	DestroyRelation(force *bool, maxWait *time.Duration, endpoints ...string) error
-	DestroyRelationId(relationId int, force *bool, maxWait *time.Duration) error
+	DestroyRelation(force *bool, maxWait, drainTimeout *time.Duration, endpoints ...string) error
+	DestroyRelationId(relationId int, force *bool, maxWait, drainTimeout *time.Duration) error
 }
 
 func (c *removeRelationCommand) Run(_ *cmd.Context) error {
 	noWaitSet := false
 	forceSet := false
+	drainTimeoutSet := false
 	c.fs.Visit(func(flag *gnuflag.Flag) {
-		if flag.Name == "no-wait" {
+		switch flag.Name {
+		case "no-wait":
 			noWaitSet = true
-		} else if flag.Name == "force" {
+		case "force":
 			forceSet = true
+		case "drain-timeout":
+			drainTimeoutSet = true
 		}
 	})
 	if !forceSet && noWaitSet {
 		return errors.NotValidf("--no-wait without --force")
 	}
+	if c.DrainTimeout < 0 {
+		return errors.NotValidf("negative --drain-timeout")
+	}
+	var drainTimeout *time.Duration
+	if drainTimeoutSet {
+		drainTimeout = &c.DrainTimeout
+	}
 	var maxWait *time.Duration
 	var force *bool
 	if c.Force {
@@ -151,9 +173,9 @@ func (c *removeRelationCommand) Run(_ *cmd.Context) error {
 		return errors.New("removing a relation using its ID is not supported by this version of Juju")
 	}
 	if len(c.Endpoints) > 0 {
-		err = client.DestroyRelation(force, maxWait, c.Endpoints...)
+		err = client.DestroyRelation(force, maxWait, drainTimeout, c.Endpoints...)
 	} else {
-		err = client.DestroyRelationId(c.RelationId, force, maxWait)
+		err = client.DestroyRelationId(c.RelationId, force, maxWait, drainTimeout)
 	}
 	return block.ProcessBlockedError(err, block.BlockRemove)
 }
@@ -25,7 +25,7 @@ type RemoveRelationSuite struct {
 func (s *RemoveRelationSuite) SetUpTest(c *gc.C) {
 	s.IsolationSuite.SetUpTest(c)
 	s.mockAPI = &mockRemoveAPI{Stub: &testing.Stub{}, version: 6}
-	s.mockAPI.removeRelationFunc = func(force *bool, maxWait *time.Duration, endpoints ...string) error {
+	s.mockAPI.removeRelationFunc = func(force *bool, maxWait, drainTimeout *time.Duration, endpoints ...string) error {
 		return s.mockAPI.NextErr()
 	}
 }
@@ -62,23 +62,45 @@ func (s *RemoveRelationSuite) TestRemoveRelationIdOldServer(c *gc.C) {
 func (s *RemoveRelationSuite) TestRemoveRelationSuccess(c *gc.C) {
 	err := s.runRemoveRelation(c, "application1", "application2")
 	c.Assert(err, jc.ErrorIsNil)
-	s.mockAPI.CheckCall(c, 0, "DestroyRelation", (*bool)(nil), (*time.Duration)(nil), []string{"application1", "application2"})
+	s.mockAPI.CheckCall(c, 0, "DestroyRelation", (*bool)(nil), (*time.Duration)(nil), (*time.Duration)(nil), []string{"application1", "application2"})
 	s.mockAPI.CheckCall(c, 1, "Close")
 }
 
 func (s *RemoveRelationSuite) TestRemoveRelationIdSuccess(c *gc.C) {
 	err := s.runRemoveRelation(c, "123")
 	c.Assert(err, jc.ErrorIsNil)
-	s.mockAPI.CheckCall(c, 0, "DestroyRelationId", 123, (*bool)(nil), (*time.Duration)(nil))
+	s.mockAPI.CheckCall(c, 0, "DestroyRelationId", 123, (*bool)(nil), (*time.Duration)(nil), (*time.Duration)(nil))
 	s.mockAPI.CheckCall(c, 1, "Close")
 }
 
+func (s *RemoveRelationSuite) TestRemoveRelationDrainTimeout(c *gc.C) {
+	err := s.runRemoveRelation(c, "application1", "application2", "--drain-timeout", "10m")
+	c.Assert(err, jc.ErrorIsNil)
+	drainTimeout := 10 * time.Minute
+	s.mockAPI.CheckCall(c, 0, "DestroyRelation", (*bool)(nil), (*time.Duration)(nil), &drainTimeout, []string{"application1", "application2"})
+	s.mockAPI.CheckCall(c, 1, "Close")
+}
+
+func (s *RemoveRelationSuite) TestRemoveRelationIdZeroDrainTimeout(c *gc.C) {
+	err := s.runRemoveRelation(c, "123", "--drain-timeout", "0s")
+	c.Assert(err, jc.ErrorIsNil)
+	drainTimeout := time.Duration(0)
+	s.mockAPI.CheckCall(c, 0, "DestroyRelationId", 123, (*bool)(nil), (*time.Duration)(nil), &drainTimeout)
+	s.mockAPI.CheckCall(c, 1, "Close")
+}
+
+func (s *RemoveRelationSuite) TestRemoveRelationNegativeDrainTimeout(c *gc.C) {
+	err := s.runRemoveRelation(c, "application1", "application2", "--drain-timeout", "-1m")
+	c.Assert(err, gc.ErrorMatches, "negative --drain-timeout not valid")
+	s.mockAPI.CheckNoCalls(c)
+}
+
 func (s *RemoveRelationSuite) TestRemoveRelationFail(c *gc.C) {
 	msg := "fail remove-relation at API"
 	s.mockAPI.SetErrors(errors.New(msg))
 	err := s.runRemoveRelation(c, "application1", "application2")
 	c.Assert(err, gc.ErrorMatches, msg)
-	s.mockAPI.CheckCall(c, 0, "DestroyRelation", (*bool)(nil), (*time.Duration)(nil), []string{"application1", "application2"})
+	s.mockAPI.CheckCall(c, 0, "DestroyRelation", (*bool)(nil), (*time.Duration)(nil), (*time.Duration)(nil), []string{"application1", "application2"})
 	s.mockAPI.CheckCall(c, 1, "Close")
 }
 
@@ -86,14 +108,14 @@ func (s *RemoveRelationSuite) TestRemoveRelationBlocked(c *gc.C) {
 	s.mockAPI.SetErrors(common.OperationBlockedError("TestRemoveRelationBlocked"))
 	err := s.runRemoveRelation(c, "application1", "application2")
 	coretesting.AssertOperationWasBlocked(c, err, ".*TestRemoveRelationBlocked.*")
-	s.mockAPI.CheckCall(c, 0, "DestroyRelation", (*bool)(nil), (*time.Duration)(nil), []string{"application1", "application2"})
+	s.mockAPI.CheckCall(c, 0, "DestroyRelation", (*bool)(nil), (*time.Duration)(nil), (*time.Duration)(nil), []string{"application1", "application2"})
 	s.mockAPI.CheckCall(c, 1, "Close")
 }
 
 type mockRemoveAPI struct {
 	*testing.Stub
 	version            int
-	removeRelationFunc func(force *bool, maxWait *time.Duration, endpoints ...string) error
+	removeRelationFunc func(force *bool, maxWait, drainTimeout *time.Duration, endpoints ...string) error
 }
 
 func (s mockRemoveAPI) Close() error {
@@ -101,13 +123,13 @@ func (s mockRemoveAPI) Close() error {
 	return s.NextErr()
 }
 
-func (s mockRemoveAPI) DestroyRelation(force *bool, maxWait *time.Duration, endpoints ...string) error {
-	s.MethodCall(s, "DestroyRelation", force, maxWait, endpoints)
-	return s.removeRelationFunc(force, maxWait, endpoints...)
+func (s mockRemoveAPI) DestroyRelation(force *bool, maxWait, drainTimeout *time.Duration, endpoints ...string) error {
+	s.MethodCall(s, "DestroyRelation", force, maxWait, drainTimeout, endpoints)
+	return s.removeRelationFunc(force, maxWait, drainTimeout, endpoints...)
 }
 
-func (s mockRemoveAPI) DestroyRelationId(relationId int, force *bool, maxWait *time.Duration) error {
-	s.MethodCall(s, "DestroyRelationId", relationId, force, maxWait)
+func (s mockRemoveAPI) DestroyRelationId(relationId int, force *bool, maxWait, drainTimeout *time.Duration) error {
+	s.MethodCall(s, "DestroyRelationId", relationId, force, maxWait, drainTimeout)
 	return nil
 }
 
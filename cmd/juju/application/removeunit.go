@@ -4,6 +4,7 @@
 package application
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/juju/cmd"
@@ -36,6 +37,7 @@ type removeUnitCommand struct {
 	unknownModel bool
 	Force        bool
 	NoWait       bool
+	assumeYes    bool
 	fs           *gnuflag.FlagSet
 }
 
@@ -74,6 +76,11 @@ all operational errors. In these rare cases, use --force option but note
 that --force will remove a unit and, potentially, its machine without
 given them the opportunity to shutdown cleanly.
 
+Before removing units with --force, Juju lists the cleanup steps normally
+performed by each unit's agent which will be skipped, such as running the
+unit's stop and relation-broken hooks, and asks for confirmation. Use -y to
+skip the prompt.
+
 Unit removal is a multi-step process. Under normal circumstances, Juju will not
 proceed to a next step until the current step has finished.
 However, when using --force, users can also specify --no-wait to progress through steps
@@ -89,6 +96,8 @@ Examples:
 
     juju remove-unit wordpress/2 --force --no-wait
 
+    juju remove-unit wordpress/2 --force -y
+
 See also:
     remove-application
     scale-application
@@ -109,6 +118,8 @@ func (c *removeUnitCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.DestroyStorage, "destroy-storage", false, "Destroy storage attached to the unit")
 	f.BoolVar(&c.Force, "force", false, "Completely remove an application and all its dependencies")
 	f.BoolVar(&c.NoWait, "no-wait", false, "Rush through application removal without waiting for each individual step to complete")
+	f.BoolVar(&c.assumeYes, "y", false, "Do not prompt for confirmation of a forced removal")
+	f.BoolVar(&c.assumeYes, "yes", false, "")
 	c.fs = f
 }
 
@@ -265,9 +276,50 @@ func (c *removeUnitCommand) Run(ctx *cmd.Context) error {
 	if c.DestroyStorage && apiVersion < 5 {
 		return errors.New("--destroy-storage is not supported by this controller")
 	}
+	if c.Force && !c.assumeYes && apiVersion >= 12 {
+		if err := c.confirmForceRemoval(ctx, client); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	return c.removeUnits(ctx, client)
 }
 
+var removeUnitForceMsg = `
+WARNING! Removing units with --force skips the cleanup normally
+performed by their agents:
+%s
+Continue [y/N]? `[1:]
+
+// confirmForceRemoval lists the cleanup steps which will be skipped by
+// removing the units with force, and asks the user to confirm.
+func (c *removeUnitCommand) confirmForceRemoval(ctx *cmd.Context, client RemoveApplicationAPI) error {
+	results, err := client.CheckUnitsForceRemoval(c.EntityNames)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var details string
+	for i, name := range c.EntityNames {
+		result := results[i]
+		if result.Error != nil {
+			details += fmt.Sprintf("unit %s: %v\n", name, result.Error)
+			continue
+		}
+		agent := result.AgentStatus
+		if !result.AgentAlive {
+			agent += ", not running"
+		}
+		details += fmt.Sprintf("unit %s (agent %s):\n", name, agent)
+		for _, step := range result.SkippedSteps {
+			details += fmt.Sprintf("- %s\n", step)
+		}
+	}
+	fmt.Fprintf(ctx.Stdout, removeUnitForceMsg, details)
+	if err := jujucmd.UserConfirmYes(ctx); err != nil {
+		return errors.Annotate(err, "unit removal")
+	}
+	return nil
+}
+
 // TODO(axw) 2017-03-16 #1673323
 // Drop this in Juju 3.0.
 func (c *removeUnitCommand) removeUnitsDeprecated(ctx *cmd.Context, client RemoveApplicationAPI) error {
@@ -4,6 +4,8 @@
 package application_test
 
 import (
+	"bytes"
+	"fmt"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -38,6 +40,8 @@ type fakeApplicationRemoveUnitAPI struct {
 	destroyStorage bool
 	bestAPIVersion int
 	err            error
+
+	checkedUnits []string
 }
 
 func (f *fakeApplicationRemoveUnitAPI) BestAPIVersion() int {
@@ -90,6 +94,34 @@ func (f *fakeApplicationRemoveUnitAPI) DestroyUnits(args apiapplication.DestroyU
 	return result, nil
 }
 
+func (f *fakeApplicationRemoveUnitAPI) CheckUnitsForceRemoval(units []string) ([]params.UnitForceRemovalCheckResult, error) {
+	f.checkedUnits = units
+	var result []params.UnitForceRemovalCheckResult
+	for _, u := range units {
+		switch u {
+		case "unit/0":
+			result = append(result, params.UnitForceRemovalCheckResult{
+				AgentStatus: "gone",
+				SkippedSteps: []string{
+					`stop and remove hooks will not run for unit "unit/0"`,
+					`storage "data/0" will be detached without running storage-detaching hooks`,
+				},
+			})
+		case "unit/1":
+			result = append(result, params.UnitForceRemovalCheckResult{
+				AgentStatus:  "idle",
+				AgentAlive:   true,
+				SkippedSteps: []string{`stop and remove hooks will not run for unit "unit/1"`},
+			})
+		default:
+			result = append(result, params.UnitForceRemovalCheckResult{
+				Error: &params.Error{Code: params.CodeNotFound, Message: fmt.Sprintf("unit %q not found", u)},
+			})
+		}
+	}
+	return result, nil
+}
+
 func (f *fakeApplicationRemoveUnitAPI) ScaleApplication(args apiapplication.ScaleApplicationParams) (params.ScaleApplicationResult, error) {
 	if f.err != nil {
 		return params.ScaleApplicationResult{}, f.err
@@ -147,6 +179,63 @@ removing unit unit/2 failed: unit "unit/2" does not exist
 `[1:])
 }
 
+func (s *RemoveUnitSuite) runForceRemoveUnit(c *gc.C, answer string, args ...string) (*cmd.Context, error) {
+	var stdin, stdout, stderr bytes.Buffer
+	ctx, err := cmd.DefaultContext()
+	c.Assert(err, jc.ErrorIsNil)
+	ctx.Stdout = &stdout
+	ctx.Stderr = &stderr
+	ctx.Stdin = &stdin
+	stdin.WriteString(answer)
+
+	com := application.NewRemoveUnitCommandForTest(s.fake, s.store)
+	err = cmdtesting.InitCommand(com, append(args, "--force"))
+	c.Assert(err, jc.ErrorIsNil)
+	return ctx, com.Run(ctx)
+}
+
+func (s *RemoveUnitSuite) TestRemoveUnitForceConfirmed(c *gc.C) {
+	s.fake.bestAPIVersion = 12
+	ctx, err := s.runForceRemoveUnit(c, "y", "unit/0", "unit/1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.checkedUnits, jc.DeepEquals, []string{"unit/0", "unit/1"})
+	c.Assert(s.fake.units, jc.DeepEquals, []string{"unit/0", "unit/1"})
+
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+WARNING! Removing units with --force skips the cleanup normally
+performed by their agents:
+unit unit/0 (agent gone, not running):
+- stop and remove hooks will not run for unit "unit/0"
+- storage "data/0" will be detached without running storage-detaching hooks
+unit unit/1 (agent idle):
+- stop and remove hooks will not run for unit "unit/1"
+
+Continue [y/N]? `[1:])
+}
+
+func (s *RemoveUnitSuite) TestRemoveUnitForceAborted(c *gc.C) {
+	s.fake.bestAPIVersion = 12
+	_, err := s.runForceRemoveUnit(c, "n", "unit/0")
+	c.Assert(err, gc.ErrorMatches, "unit removal: aborted")
+	c.Assert(s.fake.checkedUnits, jc.DeepEquals, []string{"unit/0"})
+	c.Assert(s.fake.units, gc.IsNil)
+}
+
+func (s *RemoveUnitSuite) TestRemoveUnitForceAssumeYes(c *gc.C) {
+	s.fake.bestAPIVersion = 12
+	_, err := s.runRemoveUnit(c, "unit/0", "--force", "-y")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.checkedUnits, gc.IsNil)
+	c.Assert(s.fake.units, jc.DeepEquals, []string{"unit/0"})
+}
+
+func (s *RemoveUnitSuite) TestRemoveUnitForceOldController(c *gc.C) {
+	_, err := s.runRemoveUnit(c, "unit/0", "--force")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.fake.checkedUnits, gc.IsNil)
+	c.Assert(s.fake.units, jc.DeepEquals, []string{"unit/0"})
+}
+
 func (s *RemoveUnitSuite) TestRemoveUnitNoWaitWithoutForce(c *gc.C) {
 	_, err := s.runRemoveUnit(c, "unit/0", "--no-wait")
 	c.Assert(err, gc.ErrorMatches, `--no-wait without --force not valid`)
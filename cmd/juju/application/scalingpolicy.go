@@ -0,0 +1,158 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var scalingPolicyHelpSummary = `
+Shows or sets the scaling policy of an application.`[1:]
+
+var scalingPolicyHelpDetails = `
+The scaling policy of an application declares how many units it should
+have, so that the controller may bring it back to that number of units
+when units are lost.
+
+While an application has fewer alive units than its minimum, the
+controller adds units to it, each on a new machine. Units may not be
+removed with "juju remove-unit" if the application would be left with
+fewer units than its minimum, unless --force is used; the controller
+then adds units to replace them.
+
+If a maximum is set, units which are removed because their machine was
+removed with "juju remove-machine --force" are replaced by the controller,
+so long as the application would not have more units than its maximum.
+A maximum of 0 means that lost units are only replaced while the
+application has fewer units than its minimum. A model cannot be migrated
+while any of its applications has a maximum set.
+
+With no options, the current scaling policy of the application is shown.
+Options which are not given keep their current values.
+
+Scaling policies are not supported on Kubernetes models, where the scale
+of an application is set with "juju scale-application".
+
+Examples:
+    juju scaling-policy mysql
+    juju scaling-policy mysql --min 3
+    juju scaling-policy mysql --min 3 --max 5
+    juju scaling-policy mysql --min 0 --max 0
+
+See also:
+    add-unit
+    remove-unit
+    remove-machine`
+
+// NewScalingPolicyCommand returns a command to show or set the scaling
+// policy of an application.
+func NewScalingPolicyCommand() cmd.Command {
+	cmd := &scalingPolicyCommand{}
+	cmd.newAPIFunc = func() (ScalingPolicyAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type scalingPolicyCommand struct {
+	modelcmd.ModelCommandBase
+	out             cmd.Output
+	applicationName string
+	minUnits        unitCountFlag
+	maxUnits        unitCountFlag
+	newAPIFunc      func() (ScalingPolicyAPI, error)
+}
+
+// ScalingPolicyAPI defines the API methods that the scaling-policy
+// command uses.
+type ScalingPolicyAPI interface {
+	Close() error
+	BestAPIVersion() int
+	ScalingPolicy(application string) (params.ScalingPolicy, error)
+	SetScalingPolicy(application string, policy params.ScalingPolicy) error
+}
+
+// scalingPolicy is the serialisation format of an application's scaling
+// policy, used by the scaling-policy command.
+type scalingPolicy struct {
+	MinUnits int `yaml:"min-units" json:"min-units"`
+	MaxUnits int `yaml:"max-units" json:"max-units"`
+}
+
+func (c *scalingPolicyCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "scaling-policy",
+		Args:    "<application>",
+		Purpose: scalingPolicyHelpSummary,
+		Doc:     scalingPolicyHelpDetails,
+	})
+}
+
+func (c *scalingPolicyCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.Var(&c.minUnits, "min", "Minimum number of units of the application")
+	f.Var(&c.maxUnits, "max", "Number of units up to which lost units are replaced, or 0 for no replacement beyond the minimum")
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+func (c *scalingPolicyCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no application specified")
+	}
+	if !names.IsValidApplication(args[0]) {
+		return errors.NotValidf("application name %q", args[0])
+	}
+	c.applicationName = args[0]
+	if min, max := c.minUnits.value, c.maxUnits.value; min != nil && max != nil && *max > 0 && *max < *min {
+		return errors.Errorf("--max %d is less than --min %d", *max, *min)
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *scalingPolicyCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 15 {
+		return errors.New("scaling policies are not supported by this version of Juju")
+	}
+	policy, err := client.ScalingPolicy(c.applicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if c.minUnits.value == nil && c.maxUnits.value == nil {
+		return c.out.Write(ctx, scalingPolicy{
+			MinUnits: policy.MinUnits,
+			MaxUnits: policy.MaxUnits,
+		})
+	}
+	if c.minUnits.value != nil {
+		policy.MinUnits = *c.minUnits.value
+	}
+	if c.maxUnits.value != nil {
+		policy.MaxUnits = *c.maxUnits.value
+	}
+	if err := client.SetScalingPolicy(c.applicationName, policy); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type ScalingPolicySuite struct {
+	testing.IsolationSuite
+	mockAPI *mockScalingPolicyAPI
+}
+
+var _ = gc.Suite(&ScalingPolicySuite{})
+
+func (s *ScalingPolicySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockScalingPolicyAPI{
+		Stub:    &testing.Stub{},
+		version: 15,
+		policy:  params.ScalingPolicy{MinUnits: 1, MaxUnits: 3},
+	}
+}
+
+func (s *ScalingPolicySuite) runScalingPolicy(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	return cmdtesting.RunCommand(c, application.NewScalingPolicyCommandForTest(s.mockAPI, store), args...)
+}
+
+func (s *ScalingPolicySuite) TestInvalidArguments(c *gc.C) {
+	_, err := s.runScalingPolicy(c)
+	c.Assert(err, gc.ErrorMatches, "no application specified")
+
+	_, err = s.runScalingPolicy(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, `application name "mysql/0" not valid`)
+
+	_, err = s.runScalingPolicy(c, "mysql", "wordpress")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["wordpress"\]`)
+
+	_, err = s.runScalingPolicy(c, "mysql", "--min", "-1")
+	c.Assert(err, gc.ErrorMatches, `invalid value "-1" for flag --min: expected a non-negative number of units, got "-1"`)
+
+	_, err = s.runScalingPolicy(c, "mysql", "--min", "3", "--max", "2")
+	c.Assert(err, gc.ErrorMatches, "--max 2 is less than --min 3")
+}
+
+func (s *ScalingPolicySuite) TestOldServer(c *gc.C) {
+	s.mockAPI.version = 14
+	_, err := s.runScalingPolicy(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, "scaling policies are not supported by this version of Juju")
+	s.mockAPI.CheckCallNames(c, "Close")
+}
+
+func (s *ScalingPolicySuite) TestShowScalingPolicy(c *gc.C) {
+	ctx, err := s.runScalingPolicy(c, "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "min-units: 1\nmax-units: 3\n")
+	s.mockAPI.CheckCallNames(c, "ScalingPolicy", "Close")
+	s.mockAPI.CheckCall(c, 0, "ScalingPolicy", "mysql")
+}
+
+func (s *ScalingPolicySuite) TestShowScalingPolicyJSON(c *gc.C) {
+	ctx, err := s.runScalingPolicy(c, "mysql", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `{"min-units":1,"max-units":3}`+"\n")
+}
+
+func (s *ScalingPolicySuite) TestSetScalingPolicy(c *gc.C) {
+	_, err := s.runScalingPolicy(c, "mysql", "--min", "2", "--max", "5")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCallNames(c, "ScalingPolicy", "SetScalingPolicy", "Close")
+	s.mockAPI.CheckCall(c, 1, "SetScalingPolicy", "mysql", params.ScalingPolicy{MinUnits: 2, MaxUnits: 5})
+}
+
+func (s *ScalingPolicySuite) TestSetScalingPolicyKeepsUnsetValues(c *gc.C) {
+	_, err := s.runScalingPolicy(c, "mysql", "--min", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCall(c, 1, "SetScalingPolicy", "mysql", params.ScalingPolicy{MinUnits: 2, MaxUnits: 3})
+}
+
+func (s *ScalingPolicySuite) TestAPIFailure(c *gc.C) {
+	s.mockAPI.SetErrors(nil, errors.New("boom"))
+	_, err := s.runScalingPolicy(c, "mysql", "--min", "2")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.mockAPI.CheckCallNames(c, "ScalingPolicy", "SetScalingPolicy", "Close")
+}
+
+func (s *ScalingPolicySuite) TestBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(nil, common.OperationBlockedError("TestBlocked"))
+	_, err := s.runScalingPolicy(c, "mysql", "--min", "2")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestBlocked.*")
+}
+
+type mockScalingPolicyAPI struct {
+	*testing.Stub
+	version int
+	policy  params.ScalingPolicy
+}
+
+func (s *mockScalingPolicyAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return nil
+}
+
+func (s *mockScalingPolicyAPI) BestAPIVersion() int {
+	return s.version
+}
+
+func (s *mockScalingPolicyAPI) ScalingPolicy(application string) (params.ScalingPolicy, error) {
+	s.MethodCall(s, "ScalingPolicy", application)
+	return s.policy, s.NextErr()
+}
+
+func (s *mockScalingPolicyAPI) SetScalingPolicy(application string, policy params.ScalingPolicy) error {
+	s.MethodCall(s, "SetScalingPolicy", application, policy)
+	return s.NextErr()
+}
@@ -0,0 +1,138 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var setMaintenanceHelpSummary = `
+Puts units into maintenance, or takes them out of maintenance.`[1:]
+
+var setMaintenanceHelpDetails = `
+A unit in maintenance runs no hooks until it is taken out of maintenance,
+so that an operator may work on the unit's machine without the charm
+interfering. Any events which occur in the meantime are handled once the
+unit is taken out of maintenance. A unit in maintenance may not be elected
+leader of its application: if it is the leader, another unit is elected
+once its leadership expires.
+
+The agent status of the unit is set to "maintenance", with the given
+message, so that the change is visible in "juju status" and recorded in
+the unit's status history. Taking the unit out of maintenance with --clear
+returns its agent to "idle".
+
+A unit which is removed while in maintenance runs its hooks regardless,
+so that it may be removed.
+
+Examples:
+    juju set-maintenance mysql/0 --message "disk swap"
+    juju set-maintenance mysql/0 mysql/1 --message "kernel upgrade"
+    juju set-maintenance mysql/0 --clear
+
+See also:
+    show-status-log
+    status`
+
+// NewSetMaintenanceCommand returns a command to put units into
+// maintenance, or take them out of maintenance.
+func NewSetMaintenanceCommand() cmd.Command {
+	cmd := &setMaintenanceCommand{}
+	cmd.newAPIFunc = func() (SetUnitsMaintenanceAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type setMaintenanceCommand struct {
+	modelcmd.ModelCommandBase
+	unitNames  []string
+	message    string
+	clear      bool
+	newAPIFunc func() (SetUnitsMaintenanceAPI, error)
+}
+
+// SetUnitsMaintenanceAPI defines the API methods that the set-maintenance
+// command uses.
+type SetUnitsMaintenanceAPI interface {
+	Close() error
+	BestAPIVersion() int
+	SetUnitsMaintenance(units []string, maintenance bool, message string) ([]params.ErrorResult, error)
+}
+
+func (c *setMaintenanceCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "set-maintenance",
+		Args:    "<unit> [<unit> ...]",
+		Purpose: setMaintenanceHelpSummary,
+		Doc:     setMaintenanceHelpDetails,
+	})
+}
+
+func (c *setMaintenanceCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.message, "message", "", "Reason for putting the units into maintenance")
+	f.BoolVar(&c.clear, "clear", false, "Take the units out of maintenance")
+}
+
+func (c *setMaintenanceCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.Errorf("no units specified")
+	}
+	for _, name := range args {
+		if !names.IsValidUnit(name) {
+			return errors.NotValidf("unit name %q", name)
+		}
+	}
+	if c.clear && c.message != "" {
+		return errors.New("cannot specify --message with --clear")
+	}
+	c.unitNames = args
+	return nil
+}
+
+func (c *setMaintenanceCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 14 {
+		return errors.New("unit maintenance is not supported by this version of Juju")
+	}
+	results, err := client.SetUnitsMaintenance(c.unitNames, !c.clear, c.message)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	anyFailed := false
+	for i, name := range c.unitNames {
+		if err := results[i].Error; err != nil {
+			anyFailed = true
+			ctx.Infof("%v", err)
+			continue
+		}
+		if c.clear {
+			ctx.Infof("unit %s is no longer in maintenance", name)
+		} else {
+			ctx.Infof("unit %s is in maintenance", name)
+		}
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type SetMaintenanceSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockSetMaintenanceAPI
+}
+
+var _ = gc.Suite(&SetMaintenanceSuite{})
+
+func (s *SetMaintenanceSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockSetMaintenanceAPI{Stub: &testing.Stub{}, version: 14}
+}
+
+func (s *SetMaintenanceSuite) runSetMaintenance(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	return cmdtesting.RunCommand(c, application.NewSetMaintenanceCommandForTest(s.mockAPI, store), args...)
+}
+
+func (s *SetMaintenanceSuite) TestInvalidArguments(c *gc.C) {
+	_, err := s.runSetMaintenance(c)
+	c.Assert(err, gc.ErrorMatches, "no units specified")
+
+	_, err = s.runSetMaintenance(c, "mysql")
+	c.Assert(err, gc.ErrorMatches, `unit name "mysql" not valid`)
+
+	_, err = s.runSetMaintenance(c, "mysql/0", "--clear", "--message", "disk swap")
+	c.Assert(err, gc.ErrorMatches, "cannot specify --message with --clear")
+}
+
+func (s *SetMaintenanceSuite) TestOldServer(c *gc.C) {
+	s.mockAPI.version = 13
+	_, err := s.runSetMaintenance(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "unit maintenance is not supported by this version of Juju")
+	s.mockAPI.CheckCallNames(c, "Close")
+}
+
+func (s *SetMaintenanceSuite) TestSetMaintenance(c *gc.C) {
+	ctx, err := s.runSetMaintenance(c, "mysql/0", "mysql/1", "--message", "disk swap")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, ""+
+		"unit mysql/0 is in maintenance\n"+
+		"unit mysql/1 is in maintenance\n")
+	s.mockAPI.CheckCallNames(c, "SetUnitsMaintenance", "Close")
+	s.mockAPI.CheckCall(c, 0, "SetUnitsMaintenance", []string{"mysql/0", "mysql/1"}, true, "disk swap")
+}
+
+func (s *SetMaintenanceSuite) TestClearMaintenance(c *gc.C) {
+	ctx, err := s.runSetMaintenance(c, "mysql/0", "--clear")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "unit mysql/0 is no longer in maintenance\n")
+	s.mockAPI.CheckCallNames(c, "SetUnitsMaintenance", "Close")
+	s.mockAPI.CheckCall(c, 0, "SetUnitsMaintenance", []string{"mysql/0"}, false, "")
+}
+
+func (s *SetMaintenanceSuite) TestUnitFailure(c *gc.C) {
+	s.mockAPI.results = []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: `cannot take unit "mysql/1" out of maintenance: unit is not in maintenance`}},
+	}
+	ctx, err := s.runSetMaintenance(c, "mysql/0", "mysql/1", "--clear")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, ""+
+		"unit mysql/0 is no longer in maintenance\n"+
+		"cannot take unit \"mysql/1\" out of maintenance: unit is not in maintenance\n")
+}
+
+func (s *SetMaintenanceSuite) TestAPIFailure(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("boom"))
+	_, err := s.runSetMaintenance(c, "mysql/0")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	s.mockAPI.CheckCallNames(c, "SetUnitsMaintenance", "Close")
+}
+
+func (s *SetMaintenanceSuite) TestBlocked(c *gc.C) {
+	s.mockAPI.SetErrors(common.OperationBlockedError("TestBlocked"))
+	_, err := s.runSetMaintenance(c, "mysql/0")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestBlocked.*")
+}
+
+type mockSetMaintenanceAPI struct {
+	*testing.Stub
+	version int
+	results []params.ErrorResult
+}
+
+func (s *mockSetMaintenanceAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return nil
+}
+
+func (s *mockSetMaintenanceAPI) BestAPIVersion() int {
+	return s.version
+}
+
+func (s *mockSetMaintenanceAPI) SetUnitsMaintenance(units []string, maintenance bool, message string) ([]params.ErrorResult, error) {
+	s.MethodCall(s, "SetUnitsMaintenance", units, maintenance, message)
+	if err := s.NextErr(); err != nil {
+		return nil, err
+	}
+	if s.results != nil {
+		return s.results, nil
+	}
+	return make([]params.ErrorResult, len(units)), nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"strconv"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var showRelationHelpSummary = `
+Shows the details of a relation.`[1:]
+
+var showRelationHelpDetails = `
+The relation is specified using its id, as shown by "juju status --relations".
+
+With --history, the recent changes made by units to the relation's data
+bags are shown too, most recent first. Each change records the unit which
+made it, whether the unit changed its own data bag or the data bag of its
+application, and the keys which were changed along with their new values.
+Keys which were deleted are shown with empty values. Only the most recent
+100 changes are kept for each relation.
+
+Examples:
+    juju show-relation 3
+    juju show-relation 3 --history
+    juju show-relation 3 --history --format json
+
+See also:
+    add-relation
+    remove-relation
+    status`
+
+// NewShowRelationCommand returns a command to show the details of a
+// relation.
+func NewShowRelationCommand() cmd.Command {
+	cmd := &showRelationCommand{}
+	cmd.newAPIFunc = func() (ShowRelationAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type showRelationCommand struct {
+	modelcmd.ModelCommandBase
+	out        cmd.Output
+	relationId int
+	history    bool
+	isoTime    bool
+	newAPIFunc func() (ShowRelationAPI, error)
+}
+
+// ShowRelationAPI defines the API methods that the show-relation command
+// uses.
+type ShowRelationAPI interface {
+	Close() error
+	BestAPIVersion() int
+	RelationDetails(relationId int, history bool) (params.RelationDetails, error)
+}
+
+// relationDetails is the serialisation format of a relation, used by the
+// show-relation command.
+type relationDetails struct {
+	Id        int                      `yaml:"id" json:"id"`
+	Key       string                   `yaml:"key" json:"key"`
+	Interface string                   `yaml:"interface" json:"interface"`
+	Scope     string                   `yaml:"scope" json:"scope"`
+	Endpoints []string                 `yaml:"endpoints" json:"endpoints"`
+	Status    string                   `yaml:"status" json:"status"`
+	Message   string                   `yaml:"message,omitempty" json:"message,omitempty"`
+	History   []relationSettingsChange `yaml:"history,omitempty" json:"history,omitempty"`
+}
+
+// relationSettingsChange is the serialisation format of a change made
+// to a relation's data bags, used by the show-relation command.
+type relationSettingsChange struct {
+	Time     string            `yaml:"time" json:"time"`
+	Unit     string            `yaml:"unit" json:"unit"`
+	DataBag  string            `yaml:"data-bag" json:"data-bag"`
+	Settings map[string]string `yaml:"settings" json:"settings"`
+}
+
+func (c *showRelationCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show-relation",
+		Args:    "<relation-id>",
+		Purpose: showRelationHelpSummary,
+		Doc:     showRelationHelpDetails,
+	})
+}
+
+func (c *showRelationCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.history, "history", false, "Show the recent changes made to the relation's data bags")
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+func (c *showRelationCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("no relation id specified")
+	}
+	if c.relationId, err = strconv.Atoi(args[0]); err != nil || c.relationId < 0 {
+		return errors.NotValidf("relation ID %q", args[0])
+	}
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *showRelationCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+	if client.BestAPIVersion() < 16 {
+		return errors.New("show-relation is not supported by this version of Juju")
+	}
+	details, err := client.RelationDetails(c.relationId, c.history)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := relationDetails{
+		Id:        details.Id,
+		Key:       details.Key,
+		Interface: details.Interface,
+		Scope:     details.Scope,
+		Endpoints: details.Endpoints,
+		Status:    details.Status,
+		Message:   details.Message,
+	}
+	for _, change := range details.History {
+		dataBag := "unit"
+		if change.Application {
+			dataBag = "application"
+		}
+		out.History = append(out.History, relationSettingsChange{
+			Time:     common.FormatTime(&change.Time, c.isoTime),
+			Unit:     change.Unit,
+			DataBag:  dataBag,
+			Settings: change.Settings,
+		})
+	}
+	return c.out.Write(ctx, out)
+}
@@ -0,0 +1,146 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+)
+
+type ShowRelationSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockShowRelationAPI
+}
+
+var _ = gc.Suite(&ShowRelationSuite{})
+
+func (s *ShowRelationSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockShowRelationAPI{
+		Stub:    &testing.Stub{},
+		version: 16,
+		details: params.RelationDetails{
+			Id:        3,
+			Key:       "wordpress:db mysql:server",
+			Interface: "mysql",
+			Scope:     "global",
+			Endpoints: []string{"mysql:server", "wordpress:db"},
+			Status:    "joined",
+		},
+	}
+}
+
+func (s *ShowRelationSuite) runShowRelation(c *gc.C, args ...string) (*cmd.Context, error) {
+	store := jujuclienttesting.MinimalStore()
+	return cmdtesting.RunCommand(c, application.NewShowRelationCommandForTest(s.mockAPI, store), args...)
+}
+
+func (s *ShowRelationSuite) TestInvalidArguments(c *gc.C) {
+	_, err := s.runShowRelation(c)
+	c.Assert(err, gc.ErrorMatches, "no relation id specified")
+
+	_, err = s.runShowRelation(c, "wordpress")
+	c.Assert(err, gc.ErrorMatches, `relation ID "wordpress" not valid`)
+
+	_, err = s.runShowRelation(c, "3", "4")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["4"\]`)
+}
+
+func (s *ShowRelationSuite) TestOldServer(c *gc.C) {
+	s.mockAPI.version = 15
+	_, err := s.runShowRelation(c, "3")
+	c.Assert(err, gc.ErrorMatches, "show-relation is not supported by this version of Juju")
+	s.mockAPI.CheckCallNames(c, "Close")
+}
+
+func (s *ShowRelationSuite) TestShowRelation(c *gc.C) {
+	ctx, err := s.runShowRelation(c, "3")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+id: 3
+key: wordpress:db mysql:server
+interface: mysql
+scope: global
+endpoints:
+- mysql:server
+- wordpress:db
+status: joined
+`[1:])
+	s.mockAPI.CheckCallNames(c, "RelationDetails", "Close")
+	s.mockAPI.CheckCall(c, 0, "RelationDetails", 3, false)
+}
+
+func (s *ShowRelationSuite) TestShowRelationHistory(c *gc.C) {
+	s.mockAPI.details.History = []params.RelationSettingsChange{{
+		Unit:        "mysql/0",
+		Application: true,
+		Settings:    map[string]string{"password": ""},
+		Time:        time.Date(2020, 4, 1, 10, 30, 0, 0, time.UTC),
+	}, {
+		Unit:     "mysql/0",
+		Settings: map[string]string{"host": "10.0.0.1"},
+		Time:     time.Date(2020, 4, 1, 10, 0, 0, 0, time.UTC),
+	}}
+	ctx, err := s.runShowRelation(c, "3", "--history", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+id: 3
+key: wordpress:db mysql:server
+interface: mysql
+scope: global
+endpoints:
+- mysql:server
+- wordpress:db
+status: joined
+history:
+- time: 2020-04-01 10:30:00Z
+  unit: mysql/0
+  data-bag: application
+  settings:
+    password: ""
+- time: 2020-04-01 10:00:00Z
+  unit: mysql/0
+  data-bag: unit
+  settings:
+    host: 10.0.0.1
+`[1:])
+	s.mockAPI.CheckCall(c, 0, "RelationDetails", 3, true)
+}
+
+func (s *ShowRelationSuite) TestAPIFailure(c *gc.C) {
+	s.mockAPI.SetErrors(errors.NotFoundf("relation 3"))
+	_, err := s.runShowRelation(c, "3")
+	c.Assert(err, gc.ErrorMatches, "relation 3 not found")
+	s.mockAPI.CheckCallNames(c, "RelationDetails", "Close")
+}
+
+type mockShowRelationAPI struct {
+	*testing.Stub
+	version int
+	details params.RelationDetails
+}
+
+func (s *mockShowRelationAPI) Close() error {
+	s.MethodCall(s, "Close")
+	return nil
+}
+
+func (s *mockShowRelationAPI) BestAPIVersion() int {
+	return s.version
+}
+
+func (s *mockShowRelationAPI) RelationDetails(relationId int, history bool) (params.RelationDetails, error) {
+	s.MethodCall(s, "RelationDetails", relationId, history)
+	return s.details, s.NextErr()
+}
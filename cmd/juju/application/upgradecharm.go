@@ -125,6 +125,7 @@ type upgradeCharmCommand struct {
 	Force       bool
 	ForceUnits  bool
 	ForceSeries bool
+	Canary      bool
 	SwitchURL   string
 	CharmPath   string
 	Revision    int // defaults to -1 (latest)
@@ -223,6 +224,17 @@ Use of the --force-units option is not generally recommended; units upgraded whi
 error state will not have upgrade-charm hooks executed, and may cause unexpected
 behavior.
 
+If the model's max-charm-deployments is set, no more than that many units of
+the application upgrade their charm at once; the rest wait for a unit to
+finish before starting.
+
+The --canary option, which is only supported for Kubernetes models, upgrades
+the application's units one at a time; each unit must become ready on the
+new charm before the next one is upgraded. A unit which fails to become ready
+halts the rollout, leaving the remaining units on the previous charm.
+
+  juju upgrade-charm gitlab --canary
+
 --force option for LXD Profiles is not generally recommended when upgrading an 
 application; overriding profiles on the container may cause unexpected 
 behavior. 
@@ -243,6 +255,7 @@ func (c *upgradeCharmCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.ForceUnits, "force-units", false, "Upgrade all units immediately, even if in error state")
 	f.StringVar((*string)(&c.Channel), "channel", "", "Channel to use when getting the charm or bundle from the charm store")
 	f.BoolVar(&c.ForceSeries, "force-series", false, "Upgrade even if series of deployed applications are not supported by the new charm")
+	f.BoolVar(&c.Canary, "canary", false, "Upgrade the units of a Kubernetes application one at a time")
 	f.StringVar(&c.SwitchURL, "switch", "", "Crossgrade to a different charm")
 	f.StringVar(&c.CharmPath, "path", "", "Upgrade to a charm located at path")
 	f.IntVar(&c.Revision, "revision", -1, "Explicit revision of current charm")
@@ -296,6 +309,11 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 			return err
 		}
 	}
+	if c.Canary {
+		if err := c.checkApplicationFacadeSupport(apiRoot, "canary upgrades", 18); err != nil {
+			return err
+		}
+	}
 
 	generation, err := c.ActiveBranch()
 	if err != nil {
@@ -427,6 +445,7 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		ResourceIDs:        ids,
 		StorageConstraints: c.Storage,
 		EndpointBindings:   c.Bindings,
+		CanaryUpgrade:      c.Canary,
 	}
 
 	if err := block.ProcessBlockedError(charmUpgradeClient.SetCharm(generation, cfg), block.BlockChange); err != nil {
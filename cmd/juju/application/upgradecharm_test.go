@@ -266,6 +266,28 @@ func (s *UpgradeCharmSuite) TestConfigSettingsMinFacadeVersion(c *gc.C) {
 		"updating config at upgrade-charm time is not supported by server version 1.2.3")
 }
 
+func (s *UpgradeCharmSuite) TestCanary(c *gc.C) {
+	s.apiConnection.bestFacadeVersion = 18
+	_, err := s.runUpgradeCharm(c, "foo", "--canary")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmAPIClient.CheckCallNames(c, "GetCharmURL", "Get", "SetCharm")
+
+	s.charmAPIClient.CheckCall(c, 2, "SetCharm", model.GenerationMaster, application.SetCharmConfig{
+		ApplicationName: "foo",
+		CharmID: jujucharmstore.CharmID{
+			URL:     s.resolvedCharmURL,
+			Channel: csclientparams.StableChannel,
+		},
+		CanaryUpgrade: true,
+	})
+}
+
+func (s *UpgradeCharmSuite) TestCanaryMinFacadeVersion(c *gc.C) {
+	_, err := s.runUpgradeCharm(c, "foo", "--canary")
+	c.Assert(err, gc.ErrorMatches,
+		"canary upgrades at upgrade-charm time is not supported by server version 1.2.3")
+}
+
 type UpgradeCharmErrorsStateSuite struct {
 	jujutesting.RepoSuite
 	handler charmstore.HTTPCloseHandler
@@ -0,0 +1,190 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloud
+
+import (
+	"io"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	cloudapi "github.com/juju/juju/api/cloud"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const usageCloudCallsDetails = `
+Show the calls made to cloud providers by the workers running on the
+current controller, for each cloud, region, worker and provider method,
+along with how many of them failed or were throttled by the cloud. Use
+this to find out which workers are tripping a cloud's rate limits.
+
+The statistics are those of the controller machine serving the request,
+and are reset when it restarts. In a highly available controller each
+machine keeps its own statistics. The same statistics are exported on
+the controller's metrics endpoint as juju_provider_calls_total and
+related metrics.
+
+Use --throttled to only show the calls which have been throttled.
+
+Only controller superusers may see the statistics.
+
+Examples:
+    juju cloud-calls
+    juju cloud-calls --throttled
+    juju cloud-calls --format yaml
+
+See also:
+    clouds
+    regions
+`
+
+// CloudCallsAPI defines the API methods used by the cloud-calls command.
+type CloudCallsAPI interface {
+	ProviderCallStats() ([]params.ProviderCallStats, error)
+	Close() error
+}
+
+// NewCloudCallsCommand returns a command which shows the calls made to
+// cloud providers by a controller.
+func NewCloudCallsCommand() cmd.Command {
+	return modelcmd.WrapController(&cloudCallsCommand{})
+}
+
+type cloudCallsCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	newAPIFunc func() (CloudCallsAPI, error)
+
+	throttledOnly bool
+}
+
+// Info implements Command.Info.
+func (c *cloudCallsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "cloud-calls",
+		Purpose: "Shows the calls made to cloud providers by the controller.",
+		Doc:     usageCloudCallsDetails,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *cloudCallsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.throttledOnly, "throttled", false, "Only show calls which have been throttled")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatCloudCallsTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *cloudCallsCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *cloudCallsCommand) newAPI() (CloudCallsAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cloudapi.NewClient(root), nil
+}
+
+// cloudCall is the serialisation format of the statistics for calls of
+// a provider method, used by the cloud-calls command.
+type cloudCall struct {
+	Cloud         string     `yaml:"cloud" json:"cloud"`
+	Region        string     `yaml:"region,omitempty" json:"region,omitempty"`
+	Caller        string     `yaml:"caller" json:"caller"`
+	Method        string     `yaml:"method" json:"method"`
+	Calls         int64      `yaml:"calls" json:"calls"`
+	Errors        int64      `yaml:"errors" json:"errors"`
+	Throttled     int64      `yaml:"throttled" json:"throttled"`
+	AvgDuration   string     `yaml:"avg-duration" json:"avg-duration"`
+	MaxDuration   string     `yaml:"max-duration" json:"max-duration"`
+	LastThrottled *time.Time `yaml:"last-throttled,omitempty" json:"last-throttled,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *cloudCallsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	stats, err := client.ProviderCallStats()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	calls := []cloudCall{}
+	for _, s := range stats {
+		if c.throttledOnly && s.Throttled == 0 {
+			continue
+		}
+		var avg time.Duration
+		if s.Calls > 0 {
+			avg = time.Duration(s.TotalDuration / s.Calls)
+		}
+		calls = append(calls, cloudCall{
+			Cloud:         s.Cloud,
+			Region:        s.Region,
+			Caller:        s.Caller,
+			Method:        s.Method,
+			Calls:         s.Calls,
+			Errors:        s.Errors,
+			Throttled:     s.Throttled,
+			AvgDuration:   formatCallDuration(avg),
+			MaxDuration:   formatCallDuration(time.Duration(s.MaxDuration)),
+			LastThrottled: s.LastThrottled,
+		})
+	}
+	if len(calls) == 0 && c.out.Name() == "tabular" {
+		if c.throttledOnly {
+			ctx.Infof("No provider calls have been throttled.")
+		} else {
+			ctx.Infof("No provider calls have been recorded.")
+		}
+		return nil
+	}
+	return c.out.Write(ctx, calls)
+}
+
+func formatCallDuration(d time.Duration) string {
+	return d.Round(time.Millisecond).String()
+}
+
+func formatCloudCallsTabular(writer io.Writer, value interface{}) error {
+	calls, ok := value.([]cloudCall)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", calls, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Cloud", "Region", "Caller", "Method", "Calls", "Errors", "Throttled", "Avg", "Max", "Last throttled")
+	for _, call := range calls {
+		lastThrottled := ""
+		if call.LastThrottled != nil {
+			lastThrottled = common.FormatTime(call.LastThrottled, true)
+		}
+		w.Println(
+			call.Cloud, call.Region, call.Caller, call.Method,
+			call.Calls, call.Errors, call.Throttled,
+			call.AvgDuration, call.MaxDuration, lastThrottled,
+		)
+	}
+	tw.Flush()
+	return nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloud_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/cloud"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type cloudCallsSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   *fakeCloudCallsAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&cloudCallsSuite{})
+
+func (s *cloudCallsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	throttledAt := time.Date(2020, 3, 2, 10, 0, 0, 0, time.UTC)
+	s.api = &fakeCloudCallsAPI{
+		stats: []params.ProviderCallStats{{
+			Cloud:         "ec2",
+			Region:        "us-east-1",
+			Caller:        "firewaller",
+			Method:        "IngressRules",
+			Calls:         10,
+			TotalDuration: int64(5 * time.Second),
+			MaxDuration:   int64(1200 * time.Millisecond),
+		}, {
+			Cloud:         "ec2",
+			Region:        "us-east-1",
+			Caller:        "instance-poller",
+			Method:        "Instances",
+			Calls:         120,
+			Errors:        4,
+			Throttled:     3,
+			TotalDuration: int64(time.Minute),
+			MaxDuration:   int64(2 * time.Second),
+			LastThrottled: &throttledAt,
+		}},
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.Controllers["mycontroller"] = jujuclient.ControllerDetails{}
+	s.store.CurrentControllerName = "mycontroller"
+}
+
+func (s *cloudCallsSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	return cmdtesting.RunCommand(c, cloud.NewCloudCallsCommandForTest(s.api, s.store), args...)
+}
+
+func (s *cloudCallsSuite) TestInit(c *gc.C) {
+	_, err := s.run(c, "aws")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["aws"\]`)
+	s.api.CheckNoCalls(c)
+}
+
+func (s *cloudCallsSuite) TestCloudCalls(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Cloud  Region     Caller           Method        Calls  Errors  Throttled  Avg    Max   Last throttled
+ec2    us-east-1  firewaller       IngressRules  10     0       0          500ms  1.2s  
+ec2    us-east-1  instance-poller  Instances     120    4       3          500ms  2s    2020-03-02 10:00:00Z
+`[1:])
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"ProviderCallStats", nil},
+		{"Close", nil},
+	})
+}
+
+func (s *cloudCallsSuite) TestCloudCallsThrottled(c *gc.C) {
+	ctx, err := s.run(c, "--throttled", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- cloud: ec2
+  region: us-east-1
+  caller: instance-poller
+  method: Instances
+  calls: 120
+  errors: 4
+  throttled: 3
+  avg-duration: 500ms
+  max-duration: 2s
+  last-throttled: 2020-03-02T10:00:00Z
+`[1:])
+}
+
+func (s *cloudCallsSuite) TestCloudCallsNoneThrottled(c *gc.C) {
+	s.api.stats = s.api.stats[:1]
+	ctx, err := s.run(c, "--throttled")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No provider calls have been throttled.\n")
+}
+
+func (s *cloudCallsSuite) TestCloudCallsError(c *gc.C) {
+	s.api.SetErrors(&params.Error{Message: "permission denied", Code: params.CodeUnauthorized})
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type fakeCloudCallsAPI struct {
+	jujutesting.Stub
+	stats []params.ProviderCallStats
+}
+
+func (f *fakeCloudCallsAPI) ProviderCallStats() ([]params.ProviderCallStats, error) {
+	f.MethodCall(f, "ProviderCallStats")
+	return f.stats, f.NextErr()
+}
+
+func (f *fakeCloudCallsAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
@@ -207,3 +207,25 @@ func NewListRegionsCommandForTest(store jujuclient.ClientStore, cloudAPI func()
 		cloudAPIFunc:              cloudAPI,
 	}
 }
+
+// NewCloudPricingCommandForTest returns a cloudPricingCommand with the
+// function used to open the API connection mocked out.
+func NewCloudPricingCommandForTest(api CloudPricingAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &cloudPricingCommand{}
+	c.newAPIFunc = func() (CloudPricingAPI, error) {
+		return api, nil
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewCloudCallsCommandForTest returns a cloudCallsCommand with the
+// function used to open the API connection mocked out.
+func NewCloudCallsCommandForTest(api CloudCallsAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &cloudCallsCommand{}
+	c.newAPIFunc = func() (CloudCallsAPI, error) {
+		return api, nil
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -127,6 +128,9 @@ type Credential struct {
 
 	// Label is optionally set to describe the credentials to a user.
 	Label string `json:"label,omitempty" yaml:"label,omitempty"`
+
+	// Expiry is the time at which the credential expires, if known.
+	Expiry *time.Time `json:"expiry,omitempty" yaml:"expiry,omitempty"`
 }
 
 type credentialsMap struct {
@@ -298,7 +302,11 @@ func (c *listCredentialsCommand) remoteCredentials(ctxt *cmd.Context) (map[strin
 		if cloudCredential.Credentials == nil {
 			cloudCredential.Credentials = map[string]Credential{}
 		}
-		cloudCredential.Credentials[remoteCredential.Name] = Credential{AuthType: remoteCredential.AuthType, Attributes: remoteCredential.Attributes}
+		cloudCredential.Credentials[remoteCredential.Name] = Credential{
+			AuthType:   remoteCredential.AuthType,
+			Attributes: remoteCredential.Attributes,
+			Expiry:     remoteCredential.Expiry,
+		}
 		byCloud[remoteCredential.Cloud] = cloudCredential
 	}
 	return byCloud, nil
@@ -336,12 +344,17 @@ func (c *listCredentialsCommand) localCredentials(ctxt *cmd.Context) (map[string
 		if len(cred.AuthCredentials) != 0 {
 			displayCredential.Credentials = make(map[string]Credential, len(cred.AuthCredentials))
 			for credName, credDetails := range cred.AuthCredentials {
-				displayCredential.Credentials[credName] = Credential{
-					string(credDetails.AuthType()),
-					credDetails.Attributes(),
-					credDetails.Revoked,
-					credDetails.Label,
+				display := Credential{
+					AuthType:   string(credDetails.AuthType()),
+					Attributes: credDetails.Attributes(),
+					Revoked:    credDetails.Revoked,
+					Label:      credDetails.Label,
+				}
+				if !credDetails.Expiry.IsZero() {
+					expiry := credDetails.Expiry
+					display.Expiry = &expiry
 				}
+				displayCredential.Credentials[credName] = display
 			}
 		}
 		displayCredentials[cloudName] = displayCredential
@@ -429,6 +442,12 @@ func formatCredentialsTabular(writer io.Writer, value interface{}) error {
 			}
 			w.Println(cloudName, strings.Join(credentialNames, ", "))
 		}
+		if notes := expiryNotes(group, time.Now()); len(notes) > 0 {
+			w.Println()
+			for _, note := range notes {
+				w.Println(note)
+			}
+		}
 	}
 	if len(credentials.Controller) > 0 {
 		w.Println("\nController Credentials:")
@@ -442,3 +461,24 @@ func formatCredentialsTabular(writer io.Writer, value interface{}) error {
 	tw.Flush()
 	return nil
 }
+
+// expiryNotes returns a note for each credential in the group that
+// has expired, or that will expire within the warning period.
+func expiryNotes(group map[string]CloudCredential, now time.Time) []string {
+	var notes []string
+	for cloudName, cloudCredential := range group {
+		for credentialName, credential := range cloudCredential.Credentials {
+			if credential.Expiry == nil || credential.Expiry.After(now.Add(jujucloud.CredentialExpiryWarningPeriod)) {
+				continue
+			}
+			verb := "expires"
+			if !credential.Expiry.After(now) {
+				verb = "expired"
+			}
+			notes = append(notes, fmt.Sprintf("Credential %q for cloud %q %s at %s.",
+				credentialName, cloudName, verb, credential.Expiry.UTC().Format(time.RFC3339)))
+		}
+	}
+	sort.Strings(notes)
+	return notes
+}
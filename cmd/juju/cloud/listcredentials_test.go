@@ -5,6 +5,7 @@ package cloud_test
 
 import (
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -280,6 +281,39 @@ mycloud  me
 `[1:])
 }
 
+func (s *listCredentialsSuite) TestListCredentialsExpiry(c *gc.C) {
+	s.store.Controllers["mycontroller"] = jujuclient.ControllerDetails{}
+	s.store.CurrentControllerName = "mycontroller"
+	expired := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	distant := time.Now().Add(365 * 24 * time.Hour)
+	s.testAPI.credentialContentsF = func(cloud, credential string, withSecrets bool) ([]params.CredentialContentResult, error) {
+		return []params.CredentialContentResult{
+			{Result: &params.ControllerCredentialInfo{Content: params.CredentialContent{
+				Cloud: "remote-cloud", Name: "old", Expiry: &expired,
+			}}},
+			{Result: &params.ControllerCredentialInfo{Content: params.CredentialContent{
+				Cloud: "remote-cloud", Name: "new", Expiry: &distant,
+			}}},
+		}, nil
+	}
+	out := s.listCredentials(c, "-c", "mycontroller")
+	c.Assert(out, gc.Equals, `
+
+Controller Credentials:
+Cloud         Credentials
+remote-cloud  new, old
+
+Credential "old" for cloud "remote-cloud" expired at 2020-06-01T12:00:00Z.
+
+`[1:])
+
+	out = s.listCredentials(c, "-c", "mycontroller", "--format", "yaml")
+	c.Assert(out, gc.Matches, `(?s).*
+    old:
+.*      expiry: "?2020-06-01T12:00:00Z"?
+.*`)
+}
+
 func (s *listCredentialsSuite) TestListCredentialsYAMLWithSecrets(c *gc.C) {
 	s.store.Credentials["missingcloud"] = jujucloud.CloudCredential{
 		AuthCredentials: map[string]jujucloud.Credential{
@@ -0,0 +1,169 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloud
+
+import (
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v3"
+
+	cloudapi "github.com/juju/juju/api/cloud"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/cost"
+)
+
+const usageCloudPricingDetails = `
+Show, set or remove the prices of the resources provided by a cloud on
+the current controller. The controller uses the prices to estimate what
+the models on the cloud cost to run, which is shown by "juju cost" and
+on the controller's metrics endpoint.
+
+With only a cloud name, the cloud's pricing is shown. Given a YAML file,
+the cloud's pricing is replaced by the pricing in the file. Use --reset
+to remove the cloud's pricing.
+
+The pricing file has the following format, where prices not given are
+taken to be zero. Instances are priced by type where the type is listed,
+and otherwise by their cores and memory:
+
+    currency: USD
+    instance-types:
+      m5.large: 0.096
+      m5.xlarge: 0.192
+    cpu-core-hour: 0.03
+    memory-gib-hour: 0.004
+    volume-gib-month: 0.1
+    load-balancer-hour: 0.025
+
+Only controller superusers and cloud admins may set or remove a cloud's
+pricing; users who can add models to the cloud may see it.
+
+Examples:
+    juju cloud-pricing aws
+    juju cloud-pricing aws aws-prices.yaml
+    juju cloud-pricing aws --reset
+
+See also:
+    cost
+    clouds
+`
+
+// CloudPricingAPI defines the API methods used by the cloud-pricing
+// command.
+type CloudPricingAPI interface {
+	SetCloudPricing(cloud string, pricing cost.Pricing) error
+	CloudPricing(cloud string) (cost.Pricing, error)
+	RemoveCloudPricing(cloud string) error
+	Close() error
+}
+
+// NewCloudPricingCommand returns a command which shows, sets or removes
+// a cloud's pricing.
+func NewCloudPricingCommand() cmd.Command {
+	return modelcmd.WrapController(&cloudPricingCommand{})
+}
+
+type cloudPricingCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	newAPIFunc func() (CloudPricingAPI, error)
+
+	cloud    string
+	filename string
+	reset    bool
+}
+
+// Info implements Command.Info.
+func (c *cloudPricingCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "cloud-pricing",
+		Args:    "<cloud name> [<pricing file>]",
+		Purpose: "Shows, sets or removes the pricing of a cloud.",
+		Doc:     usageCloudPricingDetails,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *cloudPricingCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.reset, "reset", false, "Remove the cloud's pricing")
+	c.out.AddFlags(f, "yaml", map[string]cmd.Formatter{
+		"yaml": cmd.FormatYaml,
+		"json": cmd.FormatJson,
+	})
+}
+
+// Init implements Command.Init.
+func (c *cloudPricingCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no cloud specified")
+	}
+	c.cloud, args = args[0], args[1:]
+	if !names.IsValidCloud(c.cloud) {
+		return errors.NotValidf("cloud name %q", c.cloud)
+	}
+	if len(args) > 0 {
+		c.filename, args = args[0], args[1:]
+	}
+	if c.reset && c.filename != "" {
+		return errors.New("cannot specify both a pricing file and --reset")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *cloudPricingCommand) newAPI() (CloudPricingAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return cloudapi.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *cloudPricingCommand) Run(ctx *cmd.Context) error {
+	var pricing cost.Pricing
+	if c.filename != "" {
+		data, err := ioutil.ReadFile(ctx.AbsPath(c.filename))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if pricing, err = cost.ParsePricing(data); err != nil {
+			return errors.Annotatef(err, "reading %q", c.filename)
+		}
+	}
+
+	client, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	switch {
+	case c.reset:
+		if err := client.RemoveCloudPricing(c.cloud); err != nil {
+			return errors.Trace(err)
+		}
+		ctx.Infof("Removed pricing for cloud %q", c.cloud)
+	case c.filename != "":
+		if err := client.SetCloudPricing(c.cloud, pricing); err != nil {
+			return errors.Trace(err)
+		}
+		ctx.Infof("Set pricing for cloud %q", c.cloud)
+	default:
+		pricing, err := client.CloudPricing(c.cloud)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return c.out.Write(ctx, pricing)
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cloud_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/cloud"
+	"github.com/juju/juju/core/cost"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type cloudPricingSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api   *fakeCloudPricingAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&cloudPricingSuite{})
+
+func (s *cloudPricingSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeCloudPricingAPI{}
+	s.store = jujuclient.NewMemStore()
+	s.store.Controllers["mycontroller"] = jujuclient.ControllerDetails{}
+	s.store.CurrentControllerName = "mycontroller"
+}
+
+func (s *cloudPricingSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no cloud specified",
+	}, {
+		args: []string{"Aws!"},
+		err:  `cloud name "Aws!" not valid`,
+	}, {
+		args: []string{"aws", "prices.yaml", "--reset"},
+		err:  "cannot specify both a pricing file and --reset",
+	}, {
+		args: []string{"aws", "prices.yaml", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		command := cloud.NewCloudPricingCommandForTest(s.api, s.store)
+		_, err := cmdtesting.RunCommand(c, command, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *cloudPricingSuite) TestShowPricing(c *gc.C) {
+	s.api.pricing = cost.Pricing{
+		Currency:       "USD",
+		InstanceTypes:  map[string]float64{"m5.large": 0.096},
+		VolumeGiBMonth: 0.1,
+	}
+	command := cloud.NewCloudPricingCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "aws")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+currency: USD
+instance-types:
+  m5.large: 0.096
+volume-gib-month: 0.1
+`[1:])
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"CloudPricing", []interface{}{"aws"}},
+		{"Close", nil},
+	})
+}
+
+func (s *cloudPricingSuite) TestShowPricingNotFound(c *gc.C) {
+	s.api.SetErrors(errors.NotFoundf(`pricing for cloud "aws"`))
+	command := cloud.NewCloudPricingCommandForTest(s.api, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "aws")
+	c.Assert(err, gc.ErrorMatches, `pricing for cloud "aws" not found`)
+}
+
+func (s *cloudPricingSuite) TestSetPricing(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "prices.yaml")
+	err := ioutil.WriteFile(filename, []byte(`
+currency: USD
+cpu-core-hour: 0.03
+load-balancer-hour: 0.025
+`), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	command := cloud.NewCloudPricingCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "aws", filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Set pricing for cloud \"aws\"\n")
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"SetCloudPricing", []interface{}{"aws", cost.Pricing{
+			Currency:         "USD",
+			CPUCoreHour:      0.03,
+			LoadBalancerHour: 0.025,
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *cloudPricingSuite) TestSetPricingInvalidFile(c *gc.C) {
+	filename := filepath.Join(c.MkDir(), "prices.yaml")
+	err := ioutil.WriteFile(filename, []byte("cpu-core-hour: 0.03\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+
+	command := cloud.NewCloudPricingCommandForTest(s.api, s.store)
+	_, err = cmdtesting.RunCommand(c, command, "aws", filename)
+	c.Assert(err, gc.ErrorMatches, `reading ".*prices.yaml": pricing without currency not valid`)
+	s.api.CheckNoCalls(c)
+}
+
+func (s *cloudPricingSuite) TestResetPricing(c *gc.C) {
+	command := cloud.NewCloudPricingCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "aws", "--reset")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Removed pricing for cloud \"aws\"\n")
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"RemoveCloudPricing", []interface{}{"aws"}},
+		{"Close", nil},
+	})
+}
+
+type fakeCloudPricingAPI struct {
+	jujutesting.Stub
+	pricing cost.Pricing
+}
+
+func (f *fakeCloudPricingAPI) SetCloudPricing(cloud string, pricing cost.Pricing) error {
+	f.MethodCall(f, "SetCloudPricing", cloud, pricing)
+	return f.NextErr()
+}
+
+func (f *fakeCloudPricingAPI) CloudPricing(cloud string) (cost.Pricing, error) {
+	f.MethodCall(f, "CloudPricing", cloud)
+	return f.pricing, f.NextErr()
+}
+
+func (f *fakeCloudPricingAPI) RemoveCloudPricing(cloud string) error {
+	f.MethodCall(f, "RemoveCloudPricing", cloud)
+	return f.NextErr()
+}
+
+func (f *fakeCloudPricingAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
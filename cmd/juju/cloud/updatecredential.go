@@ -6,6 +6,7 @@ package cloud
 import (
 	"fmt"
 	"io/ioutil"
+	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -45,12 +46,25 @@ Before credential is updated, the new content is validated. For some providers,
 cloud credentials are region specific. To validate the credential for a non-default region, 
 use --region.
 
+If a credential is known to expire, record its expiry as an RFC3339
+timestamp in the "expires" field of the credential's YAML definition.
+As the expiry approaches, the models using the credential report it in
+their status, and 'juju credentials' lists it, so that the credential can
+be updated before the models are suspended.
+
+Use --rotate to replace a controller credential that is about to be revoked,
+such as when cloud access keys are cycled. The new content is validated
+against every model that uses the credential, and the credential is only
+changed if all of those models accept it. If several credentials are
+rotated together, either all of them are changed or none are.
+
 Examples:
     juju update-credential aws mysecrets
     juju update-credential -f mine.yaml
     juju update-credential -f mine.yaml --client
     juju update-credential aws -f mine.yaml
     juju update-credential azure --region brazilsouth -f mine.yaml
+    juju update-credential aws mysecrets --rotate
 
 See also: 
     add-credential
@@ -70,6 +84,10 @@ type updateCredentialCommand struct {
 
 	// Region is the region that credentials will be validated for before an update.
 	Region string
+
+	// Rotate is true if the controller credentials must only be changed
+	// once all the models using them have accepted the new content.
+	Rotate bool
 }
 
 // NewUpdateCredentialCommand returns a command to update credential details.
@@ -123,11 +141,13 @@ func (c *updateCredentialCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.CredentialsFile, "f", "", "The YAML file containing credential details to update")
 	f.StringVar(&c.CredentialsFile, "file", "", "The YAML file containing credential details to update")
 	f.StringVar(&c.Region, "region", "", "Cloud region that credential is valid for")
+	f.BoolVar(&c.Rotate, "rotate", false, "Only update the controller credential if all models using it accept the new content")
 }
 
 type CredentialAPI interface {
 	Clouds() (map[names.CloudTag]jujucloud.Cloud, error)
 	UpdateCloudsCredentials(cloudCredentials map[string]jujucloud.Credential) ([]params.UpdateCredentialResult, error)
+	RotateCloudsCredentials(cloudCredentials map[string]jujucloud.Credential) ([]params.UpdateCredentialResult, error)
 	BestAPIVersion() int
 	Close() error
 }
@@ -163,6 +183,9 @@ func (c *updateCredentialCommand) Run(ctx *cmd.Context) error {
 	if err := c.MaybePrompt(ctx, fmt.Sprintf("update credential %q on cloud %q on", c.credential, c.cloud)); err != nil {
 		return errors.Trace(err)
 	}
+	if c.Rotate && c.ControllerName == "" {
+		return errors.New("--rotate can only be used to update a controller credential")
+	}
 	var returnErr error
 	if c.Client {
 		if err := c.updateLocalCredentials(ctx, credentials); err != nil {
@@ -368,13 +391,17 @@ func (c *updateCredentialCommand) updateRemoteCredentials(ctx *cmd.Context, upda
 	if len(verified) == 0 {
 		return erred
 	}
-	results, err := client.UpdateCloudsCredentials(verified)
+	op, apiCall := "updated", client.UpdateCloudsCredentials
+	if c.Rotate {
+		op, apiCall = "rotated", client.RotateCloudsCredentials
+	}
+	results, err := apiCall(verified)
 	if err != nil {
 		logger.Errorf("%v", err)
-		ctx.Warningf("Could not update credentials remotely, on controller %q", c.ControllerName)
+		ctx.Warningf("Could not %v credentials remotely, on controller %q", strings.TrimSuffix(op, "d"), c.ControllerName)
 		erred = cmd.ErrSilent
 	}
-	return processUpdateCredentialResult(ctx, accountDetails, "updated", results, c.ControllerName, erred)
+	return processUpdateCredentialResult(ctx, accountDetails, op, results, c.ControllerName, erred)
 }
 
 func verifyCredentialsForUpload(ctx *cmd.Context, accountDetails *jujuclient.AccountDetails, aCloud *jujucloud.Cloud, region string, all map[string]jujucloud.Credential) (map[string]jujucloud.Credential, error) {
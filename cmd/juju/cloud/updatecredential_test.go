@@ -410,6 +410,66 @@ For more information, see ‘juju show-credential aws my-credential’.
 `[1:])
 }
 
+func (s *updateCredentialSuite) TestRotateRemote(c *gc.C) {
+	s.api.updateCloudsCredentials = func(map[string]jujucloud.Credential) ([]params.UpdateCredentialResult, error) {
+		c.Fatalf("unexpected credential update")
+		return nil, nil
+	}
+	s.api.rotateCloudsCredentials = func(cloudCredentials map[string]jujucloud.Credential) ([]params.UpdateCredentialResult, error) {
+		c.Assert(cloudCredentials, gc.HasLen, 1)
+		expectedTag := names.NewCloudCredentialTag("aws/admin@local/my-credential").String()
+		for k, v := range cloudCredentials {
+			c.Assert(k, gc.DeepEquals, expectedTag)
+			c.Assert(v, jc.DeepEquals, jujucloud.NewCredential(jujucloud.AccessKeyAuthType, map[string]string{"access-key": "key", "secret-key": "secret"}))
+		}
+		return []params.UpdateCredentialResult{{CredentialTag: expectedTag}}, nil
+	}
+	s.storeWithCredentials(c)
+	ctx, err := cmdtesting.RunCommand(c, s.testCommand, "aws", "my-credential", "-c", "controller", "--rotate")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, `
+Controller credential "my-credential" for user "admin@local" for cloud "aws" on controller "controller" rotated.
+For more information, see ‘juju show-credential aws my-credential’.
+`[1:])
+}
+
+func (s *updateCredentialSuite) TestRotateRemoteRolledBack(c *gc.C) {
+	s.api.rotateCloudsCredentials = func(cloudCredentials map[string]jujucloud.Credential) ([]params.UpdateCredentialResult, error) {
+		return []params.UpdateCredentialResult{{
+			CredentialTag: names.NewCloudCredentialTag("aws/admin/my-credential").String(),
+			Models: []params.UpdateCredentialModelResult{{
+				ModelUUID: "model-a-uuid",
+				ModelName: "model-a",
+				Errors: []params.ErrorResult{
+					{common.ServerError(errors.New("kaboom"))},
+				},
+			}},
+			Error: common.ServerError(errors.New(`credential not valid for models "model-a" (uuid model-a-uuid)`)),
+		}}, nil
+	}
+	s.storeWithCredentials(c)
+	_, err := cmdtesting.RunCommand(c, s.testCommand, "aws", "my-credential", "-c", "controller", "--rotate")
+	c.Assert(err, gc.DeepEquals, jujucmd.ErrSilent)
+	c.Assert(c.GetTestLog(), jc.Contains, `Controller credential "my-credential" for user "admin@local" for cloud "aws" on controller "controller" not rotated: credential not valid for models "model-a" (uuid model-a-uuid)`)
+}
+
+func (s *updateCredentialSuite) TestRotateRemoteNotSupported(c *gc.C) {
+	s.api.rotateCloudsCredentials = func(cloudCredentials map[string]jujucloud.Credential) ([]params.UpdateCredentialResult, error) {
+		return nil, errors.NotSupportedf("credential rotation by this version of Juju")
+	}
+	s.storeWithCredentials(c)
+	_, err := cmdtesting.RunCommand(c, s.testCommand, "aws", "my-credential", "-c", "controller", "--rotate")
+	c.Assert(err, gc.NotNil)
+	c.Assert(c.GetTestLog(), jc.Contains, `credential rotation by this version of Juju not supported`)
+	c.Assert(c.GetTestLog(), jc.Contains, `Could not rotate credentials remotely, on controller "controller"`)
+}
+
+func (s *updateCredentialSuite) TestRotateClientOnly(c *gc.C) {
+	s.storeWithCredentials(c)
+	_, err := cmdtesting.RunCommand(c, s.testCommand, "aws", "my-credential", "--client", "--rotate")
+	c.Assert(err, gc.ErrorMatches, "--rotate can only be used to update a controller credential")
+}
+
 func (s *updateCredentialSuite) storeWithCredentials(c *gc.C) {
 	authCreds := map[string]string{"access-key": "key", "secret-key": "secret"}
 	s.store.Accounts = map[string]jujuclient.AccountDetails{
@@ -520,6 +580,7 @@ Use ‘juju set-credential’ to change credential for these models before repea
 type fakeUpdateCredentialAPI struct {
 	v                       int
 	updateCloudsCredentials func(cloudCredentials map[string]jujucloud.Credential) ([]params.UpdateCredentialResult, error)
+	rotateCloudsCredentials func(cloudCredentials map[string]jujucloud.Credential) ([]params.UpdateCredentialResult, error)
 	clouds                  func() (map[names.CloudTag]jujucloud.Cloud, error)
 }
 
@@ -535,6 +596,10 @@ func (f *fakeUpdateCredentialAPI) UpdateCloudsCredentials(c map[string]jujucloud
 	return f.updateCloudsCredentials(c)
 }
 
+func (f *fakeUpdateCredentialAPI) RotateCloudsCredentials(c map[string]jujucloud.Credential) ([]params.UpdateCredentialResult, error) {
+	return f.rotateCloudsCredentials(c)
+}
+
 func (f *fakeUpdateCredentialAPI) Clouds() (map[names.CloudTag]jujucloud.Cloud, error) {
 	return f.clouds()
 }
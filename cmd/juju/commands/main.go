@@ -35,6 +35,7 @@ import (
 	"github.com/juju/juju/cmd/juju/crossmodel"
 	"github.com/juju/juju/cmd/juju/firewall"
 	"github.com/juju/juju/cmd/juju/gui"
+	"github.com/juju/juju/cmd/juju/leases"
 	"github.com/juju/juju/cmd/juju/machine"
 	"github.com/juju/juju/cmd/juju/metricsdebug"
 	"github.com/juju/juju/cmd/juju/model"
@@ -46,6 +47,7 @@ import (
 	"github.com/juju/juju/cmd/juju/storage"
 	"github.com/juju/juju/cmd/juju/subnet"
 	"github.com/juju/juju/cmd/juju/user"
+	"github.com/juju/juju/cmd/juju/zones"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/juju"
@@ -293,6 +295,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(status.NewStatusCommand())
 	r.Register(newSwitchCommand())
 	r.Register(status.NewStatusHistoryCommand())
+	r.Register(application.NewShowRelationCommand())
 
 	// Error resolution and debugging commands.
 	if !featureflag.Enabled(feature.JujuV3) {
@@ -302,6 +305,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(newSCPCommand(nil))
 	r.Register(newSSHCommand(nil, nil))
 	r.Register(application.NewResolvedCommand())
+	r.Register(application.NewSetMaintenanceCommand())
+	r.Register(application.NewScalingPolicyCommand())
 	r.Register(newDebugLogCommand(nil))
 	r.Register(newDebugHooksCommand(nil))
 
@@ -358,6 +363,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(machine.NewListMachinesCommand())
 	r.Register(machine.NewShowMachineCommand())
 	r.Register(machine.NewUpgradeSeriesCommand())
+	r.Register(machine.NewMachineConsoleLogCommand())
+	r.Register(machine.NewSyncMachineAddressesCommand())
 
 	// Manage model
 	r.Register(model.NewConfigCommand())
@@ -381,6 +388,16 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 
 	r.Register(newMigrateCommand())
 	r.Register(model.NewExportBundleCommand())
+	r.Register(model.NewOperationsLogCommand())
+	r.Register(model.NewArchiveModelCommand())
+	r.Register(model.NewThawModelCommand())
+	r.Register(model.NewSetModelBundleCommand())
+	r.Register(model.NewApproveModelPlanCommand())
+	r.Register(model.NewCostCommand())
+	r.Register(model.NewVerifyModelCommand())
+	r.Register(model.NewSetRegistryCredentialCommand())
+	r.Register(model.NewRegistryCredentialsCommand())
+	r.Register(model.NewRemoveRegistryCredentialCommand())
 
 	if featureflag.Enabled(feature.DeveloperMode) {
 		r.Register(model.NewDumpCommand())
@@ -391,6 +408,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(action.NewListCommand())
 	r.Register(action.NewShowCommand())
 	r.Register(action.NewCancelCommand())
+	r.Register(action.NewAgentIntrospectCommand())
 	if featureflag.Enabled(feature.JujuV3) {
 		r.Register(action.NewCallCommand())
 		r.Register(action.NewListOperationsCommand())
@@ -451,15 +469,26 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 		r.Register(subnet.NewRemoveCommand())
 	}
 
+	// Availability zone reporting
+	r.Register(zones.NewZonesCommand())
+
 	// Manage controllers
 	r.Register(controller.NewAddModelCommand())
+	r.Register(controller.NewAddModelTemplateCommand())
+	r.Register(controller.NewModelTemplatesCommand())
+	r.Register(controller.NewRemoveModelTemplateCommand())
 	r.Register(controller.NewDestroyCommand())
 	r.Register(controller.NewListModelsCommand())
+	r.Register(controller.NewFindCommand())
+	r.Register(controller.NewFindUnitCommand())
+	r.Register(controller.NewFindMachineCommand())
 	r.Register(controller.NewKillCommand())
 	r.Register(controller.NewListControllersCommand())
 	r.Register(controller.NewRegisterCommand())
 	r.Register(controller.NewUnregisterCommand(jujuclient.NewFileClientStore()))
 	r.Register(controller.NewEnableDestroyControllerCommand())
+	r.Register(controller.NewAgentBinariesCommand())
+	r.Register(controller.NewFeaturesCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewConfigCommand())
 
@@ -484,6 +513,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(cloud.NewRemoveCredentialCommand())
 	r.Register(cloud.NewUpdateCredentialCommand())
 	r.Register(cloud.NewShowCredentialCommand())
+	r.Register(cloud.NewCloudPricingCommand())
+	r.Register(cloud.NewCloudCallsCommand())
 	r.Register(model.NewGrantCloudCommand())
 	r.Register(model.NewRevokeCloudCommand())
 
@@ -499,6 +530,12 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(gui.NewGUICommand())
 	r.Register(gui.NewUpgradeGUICommand())
 
+	// Lease diagnostics and recovery commands.
+	r.Register(leases.NewShowLeasesCommand())
+	r.Register(leases.NewRevokeLeaseCommand())
+	r.Register(leases.NewPinLeaderCommand())
+	r.Register(leases.NewUnpinLeaderCommand())
+
 	// Resource commands
 	r.Register(resource.NewUploadCommand(resource.UploadDeps{
 		NewClient: func(c *resource.UploadCommand) (resource.UploadClient, error) {
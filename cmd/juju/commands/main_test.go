@@ -430,6 +430,7 @@ var commandNames = []string{
 	"add-k8s",
 	"add-machine",
 	"add-model",
+	"add-model-template",
 	"add-relation",
 	"add-space",
 	"add-ssh-key",
@@ -437,8 +438,12 @@ var commandNames = []string{
 	"add-subnet",
 	"add-unit",
 	"add-user",
+	"agent-binaries",
+	"agent-introspect",
 	"agree",
 	"agreements",
+	"approve-model-plan",
+	"archive-model",
 	"attach",
 	"attach-resource",
 	"attach-storage",
@@ -452,12 +457,16 @@ var commandNames = []string{
 	"change-user-password",
 	"charm",
 	"charm-resources",
+	"cloud-calls",
+	"cloud-pricing",
 	"clouds",
 	"collect-metrics",
 	"config",
 	"consume",
 	"controller-config",
+	"controller-features",
 	"controllers",
+	"cost",
 	"create-backup",
 	"create-storage-pool",
 	"create-wallet",
@@ -483,7 +492,10 @@ var commandNames = []string{
 	"exec",
 	"export-bundle",
 	"expose",
+	"find",
+	"find-machine",
 	"find-offers",
+	"find-unit",
 	"firewall-rules",
 	"get-constraints",
 	"get-model-constraints",
@@ -513,6 +525,7 @@ var commandNames = []string{
 	"list-payloads",
 	"list-plans",
 	"list-regions",
+	"list-registry-credentials",
 	"list-resources",
 	"list-spaces",
 	"list-ssh-keys",
@@ -523,19 +536,24 @@ var commandNames = []string{
 	"list-wallets",
 	"login",
 	"logout",
+	"machine-console-log",
 	"machines",
 	"metrics",
 	"migrate",
 	"model-config",
 	"model-default",
 	"model-defaults",
+	"model-templates",
 	"models",
 	"offer",
 	"offers",
+	"operations-log",
 	"payloads",
+	"pin-leader",
 	"plans",
 	"regions",
 	"register",
+	"registry-credentials",
 	"relate", //alias for add-relation
 	"reload-spaces",
 	"remove-application",
@@ -546,7 +564,9 @@ var commandNames = []string{
 	"remove-credential",
 	"remove-k8s",
 	"remove-machine",
+	"remove-model-template",
 	"remove-offer",
+	"remove-registry-credential",
 	"remove-relation",
 	"remove-saas",
 	"remove-ssh-key",
@@ -562,17 +582,22 @@ var commandNames = []string{
 	"retry-provisioning",
 	"revoke",
 	"revoke-cloud",
+	"revoke-lease",
 	"run",
 	"scale-application",
+	"scaling-policy",
 	"scp",
 	"set-credential",
 	"set-constraints",
 	"set-default-credential",
 	"set-default-region",
 	"set-firewall-rule",
+	"set-maintenance",
 	"set-meter-status",
+	"set-model-bundle",
 	"set-model-constraints",
 	"set-plan",
+	"set-registry-credential",
 	"set-series",
 	"set-wallet",
 	"show-action",
@@ -582,9 +607,11 @@ var commandNames = []string{
 	"show-controller",
 	"show-credential",
 	"show-credentials",
+	"show-leases",
 	"show-machine",
 	"show-model",
 	"show-offer",
+	"show-relation",
 	"show-status",
 	"show-status-log",
 	"show-storage",
@@ -601,9 +628,12 @@ var commandNames = []string{
 	"suspend-relation",
 	"switch",
 	"sync-agent-binaries",
+	"sync-machine-addresses",
 	"sync-tools",
+	"thaw-model",
 	"trust",
 	"unexpose",
+	"unpin-leader",
 	"unregister",
 	"update-cloud",
 	"update-public-clouds",
@@ -618,9 +648,11 @@ var commandNames = []string{
 	"upgrade-series",
 	"upload-backup",
 	"users",
+	"verify-model",
 	"version",
 	"wallets",
 	"whoami",
+	"zones",
 }
 
 // devFeatures are feature flags that impact registration of commands.
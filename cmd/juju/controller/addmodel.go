@@ -60,6 +60,7 @@ type addModelCommand struct {
 	CredentialName string
 	CloudRegion    string
 	Config         common.ConfigFlag
+	Template       string
 	noSwitch       bool
 }
 
@@ -96,6 +97,12 @@ the same cloud/region as the controller model. If a region is specified
 without a cloud qualifier, then it is assumed to be in the same cloud
 as the controller model.
 
+A model may be created from a model template stored in the controller,
+using --template. The template's model config, constraints, default
+space and credential are applied to the new model; config and a
+credential given on the command line take precedence. Use
+"juju model-templates" to list the available templates.
+
 Examples:
 
     juju add-model mymodel
@@ -103,6 +110,7 @@ Examples:
     juju add-model mymodel aws/us-east-1
     juju add-model mymodel --config my-config.yaml --config image-stream=daily
     juju add-model mymodel --credential credential_name --config authorized-keys="ssh-rsa ..."
+    juju add-model mymodel --template web --config logging-config="<root>=DEBUG"
 `
 
 func (c *addModelCommand) Info() *cmd.Info {
@@ -119,6 +127,7 @@ func (c *addModelCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.Owner, "owner", "", "The owner of the new model if not the current user")
 	f.StringVar(&c.CredentialName, "credential", "", "Credential used to add the model")
 	f.Var(&c.Config, "config", "Path to YAML model configuration file or individual options (--config config.yaml [--config key=value ...])")
+	f.StringVar(&c.Template, "template", "", "The model template to create the model from")
 	f.BoolVar(&c.noSwitch, "no-switch", false, "Do not switch to the newly created model")
 }
 
@@ -149,6 +158,12 @@ type AddModelAPI interface {
 		cloudCredential names.CloudCredentialTag,
 		config map[string]interface{},
 	) (base.ModelInfo, error)
+	CreateModelFromTemplate(
+		template, name, owner, cloudName, cloudRegion string,
+		cloudCredential names.CloudCredentialTag,
+		config map[string]interface{},
+	) (base.ModelInfo, error)
+	ModelTemplates() ([]params.ModelTemplate, error)
 }
 
 type CloudAPI interface {
@@ -196,6 +211,14 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 		return errors.Trace(err)
 	}
 
+	addModelClient := c.newAddModelAPI(root)
+	var templateCredentialTag names.CloudCredentialTag
+	if c.Template != "" {
+		if templateCredentialTag, err = c.templateCredential(addModelClient); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	cloudClient := c.newCloudAPI(root)
 	var cloudTag names.CloudTag
 	var cloud jujucloud.Cloud
@@ -207,20 +230,33 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 			ctx.Infof("Use 'juju clouds' to see a list of all available clouds or 'juju add-cloud' to a add one.")
 			return cmd.ErrSilent
 		}
+	} else if c.CredentialName == "" && templateCredentialTag != (names.CloudCredentialTag{}) {
+		// The model is created on the cloud of the template's credential.
+		cloudTag = templateCredentialTag.Cloud()
 	} else {
 		if cloudTag, cloud, err = maybeGetControllerCloud(cloudClient); err != nil {
 			return errors.Trace(err)
 		}
 	}
 
-	// Find a local credential to use with the new model.
-	// If credential was found on the controller, it will be nil in return.
-	credential, credentialTag, cloudRegion, err := c.findCredential(ctx, cloudClient, &findCredentialParams{
-		cloudTag:    cloudTag,
-		cloudRegion: cloudRegion,
-		cloud:       cloud,
-		modelOwner:  modelOwner,
-	})
+	var credential *jujucloud.Credential
+	var credentialTag names.CloudCredentialTag
+	useTemplateCredential := c.CredentialName == "" &&
+		templateCredentialTag != (names.CloudCredentialTag{}) &&
+		templateCredentialTag.Cloud() == cloudTag
+	if useTemplateCredential {
+		// The credential in the template is already on the controller.
+		credentialTag = templateCredentialTag
+	} else {
+		// Find a local credential to use with the new model.
+		// If credential was found on the controller, it will be nil in return.
+		credential, credentialTag, cloudRegion, err = c.findCredential(ctx, cloudClient, &findCredentialParams{
+			cloudTag:    cloudTag,
+			cloudRegion: cloudRegion,
+			cloud:       cloud,
+			modelOwner:  modelOwner,
+		})
+	}
 	if err != nil {
 		logger.Errorf("%v", err)
 		ctx.Infof("Use \n* 'juju add-credential -c' to upload a credential to a controller or\n" +
@@ -239,8 +275,12 @@ func (c *addModelCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
-	addModelClient := c.newAddModelAPI(root)
-	model, err := addModelClient.CreateModel(c.Name, modelOwner, cloudTag.Id(), cloudRegion, credentialTag, attrs)
+	var model base.ModelInfo
+	if c.Template != "" {
+		model, err = addModelClient.CreateModelFromTemplate(c.Template, c.Name, modelOwner, cloudTag.Id(), cloudRegion, credentialTag, attrs)
+	} else {
+		model, err = addModelClient.CreateModel(c.Name, modelOwner, cloudTag.Id(), cloudRegion, credentialTag, attrs)
+	}
 	if err != nil {
 		if params.IsCodeUnauthorized(err) {
 			common.PermissionsMessage(ctx.Stderr, "add a model")
@@ -312,6 +352,25 @@ before "juju ssh", "juju scp", or "juju debug-hooks" will work.`)
 	return nil
 }
 
+// templateCredential returns the tag of the credential in the model
+// template named on the command line, if it has one.
+func (c *addModelCommand) templateCredential(client AddModelAPI) (names.CloudCredentialTag, error) {
+	templates, err := client.ModelTemplates()
+	if err != nil {
+		return names.CloudCredentialTag{}, errors.Annotate(err, "getting model templates")
+	}
+	for _, t := range templates {
+		if t.Name != c.Template {
+			continue
+		}
+		if t.CloudCredentialTag == "" {
+			return names.CloudCredentialTag{}, nil
+		}
+		return names.ParseCloudCredentialTag(t.CloudCredentialTag)
+	}
+	return names.CloudCredentialTag{}, errors.NotFoundf("model template %q", c.Template)
+}
+
 func (c *addModelCommand) getCloudRegion(cloudClient CloudAPI) (cloudTag names.CloudTag, cloud jujucloud.Cloud, cloudRegion string, err error) {
 	fail := func(err error) (names.CloudTag, jujucloud.Cloud, string, error) {
 		return names.CloudTag{}, jujucloud.Cloud{}, "", err
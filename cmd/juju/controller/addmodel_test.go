@@ -359,6 +359,47 @@ to the client with:
 and then run the add-model command again with the --credential option.`[1:])
 }
 
+func (s *AddModelSuite) TestTemplatePassedThrough(c *gc.C) {
+	s.fakeAddModelAPI.templates = []params.ModelTemplate{{Name: "web"}}
+	_, err := s.run(c, "test", "--template", "web")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.fakeAddModelAPI.template, gc.Equals, "web")
+	c.Assert(s.fakeAddModelAPI.cloudName, gc.Equals, "aws")
+}
+
+func (s *AddModelSuite) TestTemplateCredentialUsed(c *gc.C) {
+	s.fakeAddModelAPI.templates = []params.ModelTemplate{{
+		Name:               "web",
+		CloudCredentialTag: "cloudcred-aws_admin_shared",
+	}}
+	_, err := s.run(c, "test", "--template", "web")
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The credential is already on the controller, so no
+	// credential is looked for or uploaded.
+	s.fakeCloudAPI.CheckNoCalls(c)
+	c.Assert(s.fakeAddModelAPI.template, gc.Equals, "web")
+	c.Assert(s.fakeAddModelAPI.cloudName, gc.Equals, "aws")
+	c.Assert(s.fakeAddModelAPI.cloudCredential, gc.Equals, names.NewCloudCredentialTag("aws/admin/shared"))
+}
+
+func (s *AddModelSuite) TestTemplateCredentialOverridden(c *gc.C) {
+	s.fakeAddModelAPI.templates = []params.ModelTemplate{{
+		Name:               "web",
+		CloudCredentialTag: "cloudcred-aws_admin_shared",
+	}}
+	_, err := s.run(c, "test", "--template", "web", "--credential", "secrets")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.fakeAddModelAPI.cloudCredential, gc.Equals, names.NewCloudCredentialTag("aws/bob/secrets"))
+}
+
+func (s *AddModelSuite) TestTemplateNotFound(c *gc.C) {
+	_, err := s.run(c, "test", "--template", "missing")
+	c.Assert(err, gc.ErrorMatches, `model template "missing" not found`)
+}
+
 func (s *AddModelSuite) TestCloudRegionPassedThrough(c *gc.C) {
 	_, err := s.run(c, "test", "aws/us-west-1")
 	c.Assert(err, jc.ErrorIsNil)
@@ -662,6 +703,8 @@ type fakeAddClient struct {
 	cloudRegion     string
 	cloudCredential names.CloudCredentialTag
 	config          map[string]interface{}
+	template        string
+	templates       []params.ModelTemplate
 	err             error
 	model           base.ModelInfo
 }
@@ -684,6 +727,15 @@ func (f *fakeAddClient) CreateModel(name, owner, cloudName, cloudRegion string,
 	return f.model, nil
 }
 
+func (f *fakeAddClient) CreateModelFromTemplate(template, name, owner, cloudName, cloudRegion string, cloudCredential names.CloudCredentialTag, config map[string]interface{}) (base.ModelInfo, error) {
+	f.template = template
+	return f.CreateModel(name, owner, cloudName, cloudRegion, cloudCredential, config)
+}
+
+func (f *fakeAddClient) ModelTemplates() ([]params.ModelTemplate, error) {
+	return f.templates, nil
+}
+
 // TODO(wallyworld) - improve this stub and add test asserts
 type fakeCloudAPI struct {
 	clouds map[names.CloudTag]cloud.Cloud
@@ -0,0 +1,229 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/utils/naturalsort"
+
+	"github.com/juju/juju/api/agentbinaries"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/environs/bootstrap"
+)
+
+const agentBinariesDoc = `
+Show which of the agent binaries stored by the controller are held in
+the blobstore of each controller node. The agent binaries are stored in
+the controller's mongo database, and must be replicated to every
+controller node; an upgrade fails if a node asked for an agent binary
+does not hold it.
+
+With --repair, each agent binary missing from any node is copied from a
+node holding it and written again, so that it is replicated afresh to
+every node. Nodes that cannot be reached are not repaired.
+
+Examples:
+    juju agent-binaries
+    juju agent-binaries --format yaml
+    juju agent-binaries --repair
+
+See also:
+    enable-ha
+    upgrade-controller
+`
+
+// AgentBinariesAPI defines the API methods used by the agent-binaries
+// command.
+type AgentBinariesAPI interface {
+	ReplicationStatus() (params.AgentBinaryReplicationResults, error)
+	Repair() ([]params.AgentBinaryRepairResult, error)
+	Close() error
+}
+
+// NewAgentBinariesCommand returns a command that shows and repairs the
+// replication of agent binaries across controller nodes.
+func NewAgentBinariesCommand() cmd.Command {
+	c := &agentBinariesCommand{}
+	c.newAPIFunc = func() (AgentBinariesAPI, error) {
+		root, err := c.NewModelAPIRoot(bootstrap.ControllerModelName)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return agentbinaries.NewClient(root), nil
+	}
+	return modelcmd.WrapController(c)
+}
+
+type agentBinariesCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	repair bool
+
+	newAPIFunc func() (AgentBinariesAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *agentBinariesCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "agent-binaries",
+		Purpose: "Shows and repairs the replication of agent binaries across controller nodes.",
+		Doc:     agentBinariesDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *agentBinariesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.repair, "repair", false, "Re-replicate agent binaries missing from any controller node")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatAgentBinariesTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *agentBinariesCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// agentBinariesOutput is the serialisation format for the
+// agent-binaries command.
+type agentBinariesOutput struct {
+	Versions []string                     `yaml:"versions" json:"versions"`
+	Nodes    map[string]nodeAgentBinaries `yaml:"nodes" json:"nodes"`
+}
+
+type nodeAgentBinaries struct {
+	Address string   `yaml:"address" json:"address"`
+	Primary bool     `yaml:"primary,omitempty" json:"primary,omitempty"`
+	Held    []string `yaml:"held,omitempty" json:"held,omitempty"`
+	Missing []string `yaml:"missing,omitempty" json:"missing,omitempty"`
+	Error   string   `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *agentBinariesCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if c.repair {
+		return errors.Trace(c.runRepair(ctx, client))
+	}
+	status, err := client.ReplicationStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := agentBinariesOutput{
+		Versions: status.Versions,
+		Nodes:    make(map[string]nodeAgentBinaries),
+	}
+	for _, result := range status.Results {
+		node := nodeAgentBinaries{
+			Address: result.Address,
+			Primary: result.Primary,
+			Held:    result.Held,
+			Missing: result.Missing,
+		}
+		if result.Error != nil {
+			node.Error = result.Error.Error()
+		}
+		out.Nodes[result.Node] = node
+	}
+	return c.out.Write(ctx, out)
+}
+
+func (c *agentBinariesCommand) runRepair(ctx *cmd.Context, client AgentBinariesAPI) error {
+	results, err := client.Repair()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results) == 0 {
+		ctx.Infof("All agent binaries are held by every reachable controller node.")
+		return nil
+	}
+	failed := 0
+	for _, result := range results {
+		if result.Error != nil {
+			ctx.Infof("cannot repair agent binary %s: %v", result.Version, result.Error)
+			failed++
+			continue
+		}
+		ctx.Infof("re-replicated agent binary %s from node %s to nodes %s",
+			result.Version, result.Source, strings.Join(result.Nodes, ", "))
+	}
+	if failed > 0 {
+		return errors.Errorf("%d of %d agent binaries could not be repaired", failed, len(results))
+	}
+	return nil
+}
+
+func formatAgentBinariesTabular(writer io.Writer, value interface{}) error {
+	out, ok := value.(agentBinariesOutput)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", out, value)
+	}
+	ids := make([]string, 0, len(out.Nodes))
+	for id := range out.Nodes {
+		ids = append(ids, id)
+	}
+	ids = naturalsort.Sort(ids)
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Print("Version")
+	for _, id := range ids {
+		header := "Node " + id
+		if out.Nodes[id].Primary {
+			header += " (primary)"
+		}
+		w.Print(header)
+	}
+	w.Println()
+	for _, version := range out.Versions {
+		w.Print(version)
+		for _, id := range ids {
+			node := out.Nodes[id]
+			switch {
+			case node.Error != "":
+				w.Print("unknown")
+			case contains(node.Missing, version):
+				w.Print("missing")
+			default:
+				w.Print("held")
+			}
+		}
+		w.Println()
+	}
+	tw.Flush()
+
+	for _, id := range ids {
+		if err := out.Nodes[id].Error; err != "" {
+			fmt.Fprintf(writer, "\nNode %s: %s\n", id, err)
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+)
+
+type agentBinariesSuite struct {
+	baseControllerSuite
+	api   *fakeAgentBinariesAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&agentBinariesSuite{})
+
+func (s *agentBinariesSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	s.api = &fakeAgentBinariesAPI{
+		status: params.AgentBinaryReplicationResults{
+			Versions: []string{"2.7.0-bionic-amd64", "2.7.1-bionic-amd64"},
+			Results: []params.AgentBinaryReplicationResult{{
+				Node:    "0",
+				Address: "10.0.0.1:37017",
+				Primary: true,
+				Held:    []string{"2.7.0-bionic-amd64", "2.7.1-bionic-amd64"},
+			}, {
+				Node:    "1",
+				Address: "10.0.0.2:37017",
+				Held:    []string{"2.7.0-bionic-amd64"},
+				Missing: []string{"2.7.1-bionic-amd64"},
+			}, {
+				Node:    "2",
+				Address: "10.0.0.3:37017",
+				Error:   &params.Error{Message: "cannot connect"},
+			}},
+		},
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+func (s *agentBinariesSuite) newCommand() cmd.Command {
+	return controller.NewAgentBinariesCommandForTest(s.api, s.store)
+}
+
+func (s *agentBinariesSuite) TestStatusTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Version             Node 0 (primary)  Node 1   Node 2
+2.7.0-bionic-amd64  held              held     unknown
+2.7.1-bionic-amd64  held              missing  unknown
+
+Node 2: cannot connect
+`[1:])
+	c.Assert(s.api.closed, jc.IsTrue)
+}
+
+func (s *agentBinariesSuite) TestStatusYAML(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+versions:
+- 2.7.0-bionic-amd64
+- 2.7.1-bionic-amd64
+nodes:
+  "0":
+    address: 10.0.0.1:37017
+    primary: true
+    held:
+    - 2.7.0-bionic-amd64
+    - 2.7.1-bionic-amd64
+  "1":
+    address: 10.0.0.2:37017
+    held:
+    - 2.7.0-bionic-amd64
+    missing:
+    - 2.7.1-bionic-amd64
+  "2":
+    address: 10.0.0.3:37017
+    error: cannot connect
+`[1:])
+}
+
+func (s *agentBinariesSuite) TestStatusError(c *gc.C) {
+	s.api.err = common.ErrPerm
+	_, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *agentBinariesSuite) TestRepair(c *gc.C) {
+	s.api.repair = []params.AgentBinaryRepairResult{{
+		Version: "2.7.1-bionic-amd64",
+		Nodes:   []string{"1"},
+		Source:  "0",
+	}}
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--repair")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "re-replicated agent binary 2.7.1-bionic-amd64 from node 0 to nodes 1\n")
+	c.Assert(s.api.repaired, jc.IsTrue)
+}
+
+func (s *agentBinariesSuite) TestRepairNothingMissing(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--repair")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "All agent binaries are held by every reachable controller node.\n")
+}
+
+func (s *agentBinariesSuite) TestRepairFailure(c *gc.C) {
+	s.api.repair = []params.AgentBinaryRepairResult{{
+		Version: "2.7.1-bionic-amd64",
+		Nodes:   []string{"1", "2"},
+		Error:   &params.Error{Message: "complete copy of agent binary 2.7.1-bionic-amd64 not found"},
+	}}
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--repair")
+	c.Assert(err, gc.ErrorMatches, "1 of 1 agent binaries could not be repaired")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals,
+		"cannot repair agent binary 2.7.1-bionic-amd64: complete copy of agent binary 2.7.1-bionic-amd64 not found\n")
+}
+
+func (s *agentBinariesSuite) TestUnrecognizedArg(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "whoops")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["whoops"\]`)
+}
+
+type fakeAgentBinariesAPI struct {
+	status   params.AgentBinaryReplicationResults
+	repair   []params.AgentBinaryRepairResult
+	err      error
+	repaired bool
+	closed   bool
+}
+
+func (f *fakeAgentBinariesAPI) ReplicationStatus() (params.AgentBinaryReplicationResults, error) {
+	if f.err != nil {
+		return params.AgentBinaryReplicationResults{}, errors.Trace(f.err)
+	}
+	return f.status, nil
+}
+
+func (f *fakeAgentBinariesAPI) Repair() ([]params.AgentBinaryRepairResult, error) {
+	f.repaired = true
+	return f.repair, f.err
+}
+
+func (f *fakeAgentBinariesAPI) Close() error {
+	f.closed = true
+	return nil
+}
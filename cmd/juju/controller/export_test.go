@@ -93,6 +93,18 @@ func NewEnableDestroyControllerCommandForTest(api removeBlocksAPI, store jujucli
 	return modelcmd.WrapController(c)
 }
 
+// NewAgentBinariesCommandForTest returns an agentBinariesCommand with
+// the function used to open the API connection mocked out.
+func NewAgentBinariesCommandForTest(api AgentBinariesAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &agentBinariesCommand{
+		newAPIFunc: func() (AgentBinariesAPI, error) {
+			return api, nil
+		},
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 // NewDestroyCommandForTest returns a DestroyCommand with the controller and
 // client endpoints mocked out.
 func NewDestroyCommandForTest(
@@ -189,3 +201,80 @@ func NewData(api destroyControllerAPI, ctrUUID string) (ctrData, []modelData, er
 var (
 	NoModelsMessage = noModelsMessage
 )
+
+// NewFeaturesCommandForTest returns a featuresCommand with the function
+// used to open the API connection mocked out.
+func NewFeaturesCommandForTest(api FeaturesAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &featuresCommand{
+		newAPIFunc: func() (FeaturesAPI, error) {
+			return api, nil
+		},
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewAddModelTemplateCommandForTest returns an addModelTemplateCommand
+// with the function used to open the API connection mocked out.
+func NewAddModelTemplateCommandForTest(api ModelTemplatesAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &addModelTemplateCommand{}
+	c.newAPIFunc = func() (ModelTemplatesAPI, error) {
+		return api, nil
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewModelTemplatesCommandForTest returns a modelTemplatesCommand with
+// the function used to open the API connection mocked out.
+func NewModelTemplatesCommandForTest(api ModelTemplatesAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &modelTemplatesCommand{}
+	c.newAPIFunc = func() (ModelTemplatesAPI, error) {
+		return api, nil
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewRemoveModelTemplateCommandForTest returns a
+// removeModelTemplateCommand with the function used to open the API
+// connection mocked out.
+func NewRemoveModelTemplateCommandForTest(api ModelTemplatesAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &removeModelTemplateCommand{}
+	c.newAPIFunc = func() (ModelTemplatesAPI, error) {
+		return api, nil
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewFindCommandForTest returns a findCommand with the function used
+// to open the API connection mocked out.
+func NewFindCommandForTest(api FindAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &findCommand{}
+	c.newAPIFunc = func() (FindAPI, error) {
+		return api, nil
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
+// NewFindUnitCommandForTest returns a find-unit command with the
+// function used to open the API connection mocked out.
+func NewFindUnitCommandForTest(api SearchAPI, store jujuclient.ClientStore) cmd.Command {
+	return newSearchCommandForTest(&searchCommand{unit: true}, api, store)
+}
+
+// NewFindMachineCommandForTest returns a find-machine command with the
+// function used to open the API connection mocked out.
+func NewFindMachineCommandForTest(api SearchAPI, store jujuclient.ClientStore) cmd.Command {
+	return newSearchCommandForTest(&searchCommand{}, api, store)
+}
+
+func newSearchCommandForTest(c *searchCommand, api SearchAPI, store jujuclient.ClientStore) cmd.Command {
+	c.newAPIFunc = func() (SearchAPI, error) {
+		return api, nil
+	}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
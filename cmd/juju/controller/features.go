@@ -0,0 +1,165 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	apicontroller "github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const featuresDoc = `
+Show the feature flags enabled on the controller, and the history of
+who enabled or disabled them.
+
+Feature flags are set with the features controller config setting, and
+are applied by all machine and unit agents as soon as they are changed;
+there is no need to restart the agents or to set JUJU_DEV_FEATURE_FLAGS
+in their environment. Flags set in an agent's environment remain
+enabled for that agent.
+
+Examples:
+    juju controller-features
+    juju controller-features --format yaml
+    juju controller-config features="[legacy-leases]"
+
+See also:
+    controller-config
+`
+
+// FeaturesAPI defines the API methods used by the controller-features
+// command.
+type FeaturesAPI interface {
+	FeatureFlagHistory() (params.FeatureFlagHistoryResult, error)
+	Close() error
+}
+
+// NewFeaturesCommand returns a command that shows the controller's
+// feature flags and who changed them.
+func NewFeaturesCommand() cmd.Command {
+	c := &featuresCommand{}
+	c.newAPIFunc = func() (FeaturesAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return apicontroller.NewClient(root), nil
+	}
+	return modelcmd.WrapController(c)
+}
+
+type featuresCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	isoTime bool
+
+	newAPIFunc func() (FeaturesAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *featuresCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "controller-features",
+		Purpose: "Shows the controller's feature flags and who changed them.",
+		Doc:     featuresDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *featuresCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatFeaturesTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *featuresCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// featuresOutput is the serialisation format for the
+// controller-features command.
+type featuresOutput struct {
+	Enabled []string        `yaml:"enabled" json:"enabled"`
+	History []featureChange `yaml:"history,omitempty" json:"history,omitempty"`
+}
+
+type featureChange struct {
+	Time   string `yaml:"time" json:"time"`
+	Flag   string `yaml:"flag" json:"flag"`
+	Change string `yaml:"change" json:"change"`
+	Who    string `yaml:"who,omitempty" json:"who,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *featuresCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	result, err := client.FeatureFlagHistory()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := featuresOutput{
+		Enabled: result.Enabled,
+	}
+	for _, change := range result.Changes {
+		when := change.Time
+		action := "disabled"
+		if change.Enabled {
+			action = "enabled"
+		}
+		out.History = append(out.History, featureChange{
+			Time:   common.FormatTime(&when, c.isoTime),
+			Flag:   change.Flag,
+			Change: action,
+			Who:    change.Who,
+		})
+	}
+	return c.out.Write(ctx, out)
+}
+
+func formatFeaturesTabular(writer io.Writer, value interface{}) error {
+	out, ok := value.(featuresOutput)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", out, value)
+	}
+	enabled := "none"
+	if len(out.Enabled) > 0 {
+		enabled = strings.Join(out.Enabled, ", ")
+	}
+	fmt.Fprintf(writer, "Enabled: %s\n", enabled)
+	if len(out.History) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(writer)
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Time", "Flag", "Change", "By")
+	for _, change := range out.History {
+		w.Println(change.Time, change.Flag, change.Change, change.Who)
+	}
+	tw.Flush()
+	return nil
+}
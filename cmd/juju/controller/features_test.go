@@ -0,0 +1,126 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+)
+
+type featuresSuite struct {
+	baseControllerSuite
+	api   *fakeFeaturesAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&featuresSuite{})
+
+func (s *featuresSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+
+	when := time.Date(2020, 1, 3, 14, 0, 0, 0, time.UTC)
+	s.api = &fakeFeaturesAPI{
+		result: params.FeatureFlagHistoryResult{
+			Enabled: []string{"bar"},
+			Changes: []params.FeatureFlagChange{{
+				Flag:    "bar",
+				Enabled: true,
+				Who:     "bob",
+				Time:    when,
+			}, {
+				Flag:    "foo",
+				Enabled: true,
+				Who:     "bob",
+				Time:    when,
+			}, {
+				Flag: "foo",
+				Time: when.Add(time.Hour),
+			}},
+		},
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+func (s *featuresSuite) newCommand() cmd.Command {
+	return controller.NewFeaturesCommandForTest(s.api, s.store)
+}
+
+func (s *featuresSuite) TestTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Enabled: bar
+
+Time                  Flag  Change    By
+2020-01-03 14:00:00Z  bar   enabled   bob
+2020-01-03 14:00:00Z  foo   enabled   bob
+2020-01-03 15:00:00Z  foo   disabled  
+`[1:])
+	c.Assert(s.api.closed, jc.IsTrue)
+}
+
+func (s *featuresSuite) TestTabularNoFlags(c *gc.C) {
+	s.api.result = params.FeatureFlagHistoryResult{}
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "Enabled: none\n")
+}
+
+func (s *featuresSuite) TestYAML(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--utc", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+enabled:
+- bar
+history:
+- time: 2020-01-03 14:00:00Z
+  flag: bar
+  change: enabled
+  who: bob
+- time: 2020-01-03 14:00:00Z
+  flag: foo
+  change: enabled
+  who: bob
+- time: 2020-01-03 15:00:00Z
+  flag: foo
+  change: disabled
+`[1:])
+}
+
+func (s *featuresSuite) TestError(c *gc.C) {
+	s.api.err = common.ErrPerm
+	_, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+func (s *featuresSuite) TestUnrecognizedArg(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "whoops")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["whoops"\]`)
+}
+
+type fakeFeaturesAPI struct {
+	result params.FeatureFlagHistoryResult
+	err    error
+	closed bool
+}
+
+func (f *fakeFeaturesAPI) FeatureFlagHistory() (params.FeatureFlagHistoryResult, error) {
+	return f.result, f.err
+}
+
+func (f *fakeFeaturesAPI) Close() error {
+	f.closed = true
+	return nil
+}
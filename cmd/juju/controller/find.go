@@ -0,0 +1,216 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/jujuclient"
+)
+
+// FindAPI defines the API methods used by the find command.
+type FindAPI interface {
+	FindAnnotations(annotations map[string]string, kinds ...string) ([]params.AnnotatedEntity, error)
+	Close() error
+}
+
+const findDoc = `
+Find the models, applications, units and machines, in all the models
+you can see in the controller, which have all of the given annotations.
+Annotations are set by bundles, the GUI and other API clients, and are
+often used to record who owns an entity.
+
+The --kind option limits the search to entities of the given kinds,
+such as "model" or "application"; it may be repeated.
+
+Examples:
+    juju find --annotation team=payments
+    juju find --annotation team=payments --annotation tier=gold --kind application
+    juju find --annotation team=payments --format yaml
+
+See also:
+    models
+`
+
+// NewFindCommand returns a command that finds entities by their
+// annotations.
+func NewFindCommand() cmd.Command {
+	return modelcmd.WrapController(&findCommand{})
+}
+
+type findCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	newAPIFunc  func() (FindAPI, error)
+	annotations map[string]string
+	kinds       kindsFlag
+}
+
+// Info implements Command.Info.
+func (c *findCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "find",
+		Purpose: "Finds entities by their annotations across the controller.",
+		Doc:     findDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *findCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.Var(annotationsFlag{&c.annotations}, "annotation", "An annotation, as key=value, the entities must have")
+	f.Var(&c.kinds, "kind", "Only find entities of this kind")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatFoundTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *findCommand) Init(args []string) error {
+	if len(c.annotations) == 0 {
+		return errors.New("no annotations specified")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *findCommand) newAPI() (FindAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelmanager.NewClient(root), nil
+}
+
+// foundEntity is the serialisation format of an entity found by the
+// find command.
+type foundEntity struct {
+	Model       string            `yaml:"model" json:"model"`
+	Kind        string            `yaml:"kind" json:"kind"`
+	Id          string            `yaml:"id" json:"id"`
+	Annotations map[string]string `yaml:"annotations" json:"annotations"`
+}
+
+// Run implements Command.Run.
+func (c *findCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	entities, err := client.FindAnnotations(c.annotations, c.kinds...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	found := make([]foundEntity, 0, len(entities))
+	for _, entity := range entities {
+		owner, err := names.ParseUserTag(entity.ModelOwnerTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		tag, err := names.ParseTag(entity.EntityTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		found = append(found, foundEntity{
+			Model:       jujuclient.JoinOwnerModelName(owner, entity.ModelName),
+			Kind:        tag.Kind(),
+			Id:          tag.Id(),
+			Annotations: entity.Annotations,
+		})
+	}
+	if len(found) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No entities found.")
+		return nil
+	}
+	return c.out.Write(ctx, found)
+}
+
+func formatFoundTabular(writer io.Writer, value interface{}) error {
+	found, ok := value.([]foundEntity)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", found, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Model", "Kind", "Id", "Annotations")
+	for _, entity := range found {
+		pairs := make([]string, 0, len(entity.Annotations))
+		for key, value := range entity.Annotations {
+			pairs = append(pairs, key+"="+value)
+		}
+		sort.Strings(pairs)
+		w.Println(entity.Model, entity.Kind, entity.Id, strings.Join(pairs, ","))
+	}
+	tw.Flush()
+	return nil
+}
+
+// annotationsFlag is a gnuflag.Value which collects key=value pairs
+// from repeated uses of the flag.
+type annotationsFlag struct {
+	annotations *map[string]string
+}
+
+// Set implements gnuflag.Value.Set.
+func (f annotationsFlag) Set(s string) error {
+	key, value := s, ""
+	if i := strings.Index(s, "="); i > 0 {
+		key, value = s[:i], s[i+1:]
+	}
+	if value == "" {
+		return errors.NotValidf("annotation %q, expected key=value", s)
+	}
+	if *f.annotations == nil {
+		*f.annotations = make(map[string]string)
+	}
+	if _, ok := (*f.annotations)[key]; ok {
+		return errors.Errorf("duplicate annotation %q", key)
+	}
+	(*f.annotations)[key] = value
+	return nil
+}
+
+// String implements gnuflag.Value.String.
+func (f annotationsFlag) String() string {
+	pairs := make([]string, 0, len(*f.annotations))
+	for key, value := range *f.annotations {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// kindsFlag is a gnuflag.Value which collects the values of repeated
+// uses of the flag.
+type kindsFlag []string
+
+// Set implements gnuflag.Value.Set.
+func (f *kindsFlag) Set(s string) error {
+	*f = append(*f, s)
+	return nil
+}
+
+// String implements gnuflag.Value.String.
+func (f *kindsFlag) String() string {
+	return strings.Join(*f, ",")
+}
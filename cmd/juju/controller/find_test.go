@@ -0,0 +1,128 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/jujuclient"
+)
+
+type findSuite struct {
+	baseControllerSuite
+	api   *fakeFindAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&findSuite{})
+
+func (s *findSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.api = &fakeFindAPI{
+		entities: []params.AnnotatedEntity{{
+			ModelTag:      "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			ModelName:     "payments",
+			ModelOwnerTag: "user-bob",
+			EntityTag:     "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			Annotations:   map[string]string{"team": "payments"},
+		}, {
+			ModelTag:      "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			ModelName:     "payments",
+			ModelOwnerTag: "user-bob",
+			EntityTag:     "application-ledger",
+			Annotations:   map[string]string{"tier": "gold", "team": "payments"},
+		}},
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+func (s *findSuite) TestFind(c *gc.C) {
+	command := controller.NewFindCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "--annotation", "team=payments")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Model         Kind         Id                                    Annotations
+bob/payments  model        deadbeef-0bad-400d-8000-4b1d0d06f00d  team=payments
+bob/payments  application  ledger                                team=payments,tier=gold
+`[1:])
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"FindAnnotations", []interface{}{map[string]string{"team": "payments"}, []string(nil)}},
+		{"Close", nil},
+	})
+}
+
+func (s *findSuite) TestFindKindsYAML(c *gc.C) {
+	s.api.entities = s.api.entities[1:]
+	command := controller.NewFindCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command,
+		"--annotation", "team=payments", "--annotation", "tier=gold",
+		"--kind", "application", "--format", "yaml",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- model: bob/payments
+  kind: application
+  id: ledger
+  annotations:
+    team: payments
+    tier: gold
+`[1:])
+	s.api.CheckCall(c, 0, "FindAnnotations",
+		map[string]string{"team": "payments", "tier": "gold"}, []string{"application"})
+}
+
+func (s *findSuite) TestFindNothing(c *gc.C) {
+	s.api.entities = nil
+	command := controller.NewFindCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "--annotation", "team=billing")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No entities found.\n")
+}
+
+func (s *findSuite) TestFindInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no annotations specified",
+	}, {
+		args: []string{"--annotation", "team"},
+		err:  `invalid value "team" for flag --annotation: annotation "team", expected key=value not valid`,
+	}, {
+		args: []string{"--annotation", "team=a", "--annotation", "team=b"},
+		err:  `invalid value "team=b" for flag --annotation: duplicate annotation "team"`,
+	}, {
+		args: []string{"--annotation", "team=a", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		command := controller.NewFindCommandForTest(s.api, s.store)
+		err := cmdtesting.InitCommand(command, test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+type fakeFindAPI struct {
+	testing.Stub
+	entities []params.AnnotatedEntity
+}
+
+func (f *fakeFindAPI) FindAnnotations(annotations map[string]string, kinds ...string) ([]params.AnnotatedEntity, error) {
+	f.MethodCall(f, "FindAnnotations", annotations, kinds)
+	return f.entities, f.NextErr()
+}
+
+func (f *fakeFindAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
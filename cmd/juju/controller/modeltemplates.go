@@ -0,0 +1,343 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/modelmanager"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/constraints"
+)
+
+// ModelTemplatesAPI defines the API methods used by the model template
+// commands.
+type ModelTemplatesAPI interface {
+	AddModelTemplate(params.ModelTemplate) error
+	ModelTemplates() ([]params.ModelTemplate, error)
+	RemoveModelTemplate(name string) error
+	Close() error
+}
+
+// modelTemplatesCommandBase is embedded by the model template commands.
+type modelTemplatesCommandBase struct {
+	modelcmd.ControllerCommandBase
+	newAPIFunc func() (ModelTemplatesAPI, error)
+}
+
+func (c *modelTemplatesCommandBase) newAPI() (ModelTemplatesAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return modelmanager.NewClient(root), nil
+}
+
+const addModelTemplateDoc = `
+Add a model template to the controller. Models created with
+"juju add-model --template <name>" have the template's model config,
+constraints and default space applied, and use the template's credential
+unless another is given. Config given to add-model takes precedence
+over the config in the template.
+
+Only controller superusers may add model templates.
+
+Examples:
+    juju add-model-template web --config logging-config="<root>=DEBUG" --constraints mem=4G
+    juju add-model-template web --config web-config.yaml --default-space dmz
+    juju add-model-template web --credential aws/admin/shared
+
+See also:
+    add-model
+    model-templates
+    remove-model-template
+`
+
+// NewAddModelTemplateCommand returns a command that adds a model
+// template to the controller.
+func NewAddModelTemplateCommand() cmd.Command {
+	return modelcmd.WrapController(&addModelTemplateCommand{})
+}
+
+type addModelTemplateCommand struct {
+	modelTemplatesCommandBase
+
+	name         string
+	config       common.ConfigFlag
+	constraints  string
+	defaultSpace string
+	credential   string
+}
+
+// Info implements Command.Info.
+func (c *addModelTemplateCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "add-model-template",
+		Args:    "<template name>",
+		Purpose: "Adds a model template to the controller.",
+		Doc:     addModelTemplateDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *addModelTemplateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.Var(&c.config, "config", "Path to YAML model configuration file or individual options (--config config.yaml [--config key=value ...])")
+	f.StringVar(&c.constraints, "constraints", "", "The constraints of models created from the template")
+	f.StringVar(&c.defaultSpace, "default-space", "", "The space to which application endpoints are bound by default")
+	f.StringVar(&c.credential, "credential", "", "The credential, as <cloud>/<owner>/<name>, used by models created from the template")
+}
+
+// Init implements Command.Init.
+func (c *addModelTemplateCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no model template name specified")
+	}
+	c.name, args = args[0], args[1:]
+	if !names.IsValidModelName(c.name) {
+		return errors.NotValidf("model template name %q", c.name)
+	}
+	if _, err := constraints.Parse(c.constraints); err != nil {
+		return errors.Trace(err)
+	}
+	if c.defaultSpace != "" && !names.IsValidSpace(c.defaultSpace) {
+		return errors.NotValidf("space name %q", c.defaultSpace)
+	}
+	if c.credential != "" && !names.IsValidCloudCredential(c.credential) {
+		return errors.NotValidf("credential %q, expected <cloud>/<owner>/<name>", c.credential)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *addModelTemplateCommand) Run(ctx *cmd.Context) error {
+	attrs, err := c.config.ReadAttrs(ctx)
+	if err != nil {
+		return errors.Annotate(err, "unable to parse config")
+	}
+	coerced, err := common.ConformYAML(attrs)
+	if err != nil {
+		return errors.Annotate(err, "unable to parse config")
+	}
+	template := params.ModelTemplate{
+		Name:         c.name,
+		Config:       coerced.(map[string]interface{}),
+		Constraints:  constraints.MustParse(c.constraints),
+		DefaultSpace: c.defaultSpace,
+	}
+	if c.credential != "" {
+		template.CloudCredentialTag = names.NewCloudCredentialTag(c.credential).String()
+	}
+
+	client, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if err := client.AddModelTemplate(template); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Added model template %q", c.name)
+	return nil
+}
+
+const modelTemplatesDoc = `
+List the model templates in the controller. Use
+"juju add-model --template <name>" to create a model from a template.
+
+Examples:
+    juju model-templates
+    juju model-templates --format yaml
+
+See also:
+    add-model
+    add-model-template
+    remove-model-template
+`
+
+// NewModelTemplatesCommand returns a command that lists the model
+// templates in the controller.
+func NewModelTemplatesCommand() cmd.Command {
+	return modelcmd.WrapController(&modelTemplatesCommand{})
+}
+
+type modelTemplatesCommand struct {
+	modelTemplatesCommandBase
+	out cmd.Output
+
+	isoTime bool
+}
+
+// Info implements Command.Info.
+func (c *modelTemplatesCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "model-templates",
+		Purpose: "Lists the model templates in the controller.",
+		Doc:     modelTemplatesDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *modelTemplatesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatModelTemplatesTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *modelTemplatesCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// modelTemplate is the serialisation format of a model template for
+// the model-templates command.
+type modelTemplate struct {
+	Name         string                 `yaml:"name" json:"name"`
+	Config       map[string]interface{} `yaml:"config,omitempty" json:"config,omitempty"`
+	Constraints  string                 `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+	DefaultSpace string                 `yaml:"default-space,omitempty" json:"default-space,omitempty"`
+	Credential   string                 `yaml:"credential,omitempty" json:"credential,omitempty"`
+	CreatedBy    string                 `yaml:"created-by,omitempty" json:"created-by,omitempty"`
+	Created      string                 `yaml:"created,omitempty" json:"created,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *modelTemplatesCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	templates, err := client.ModelTemplates()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	out := make([]modelTemplate, len(templates))
+	for i, t := range templates {
+		out[i] = modelTemplate{
+			Name:         t.Name,
+			Config:       t.Config,
+			Constraints:  t.Constraints.String(),
+			DefaultSpace: t.DefaultSpace,
+			CreatedBy:    t.CreatedBy,
+		}
+		if t.CloudCredentialTag != "" {
+			tag, err := names.ParseCloudCredentialTag(t.CloudCredentialTag)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			out[i].Credential = tag.Id()
+		}
+		if !t.Created.IsZero() {
+			created := t.Created
+			out[i].Created = common.FormatTime(&created, c.isoTime)
+		}
+	}
+	if len(out) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No model templates to display.")
+		return nil
+	}
+	return c.out.Write(ctx, out)
+}
+
+func formatModelTemplatesTabular(writer io.Writer, value interface{}) error {
+	templates, ok := value.([]modelTemplate)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", templates, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Name", "Config", "Constraints", "Default space", "Credential", "Created by", "Created")
+	for _, t := range templates {
+		keys := make([]string, 0, len(t.Config))
+		for key := range t.Config {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		w.Println(t.Name, strings.Join(keys, ","), t.Constraints, t.DefaultSpace, t.Credential, t.CreatedBy, t.Created)
+	}
+	tw.Flush()
+	return nil
+}
+
+const removeModelTemplateDoc = `
+Remove a model template from the controller. Models already created
+from the template are not changed.
+
+Only controller superusers may remove model templates.
+
+Examples:
+    juju remove-model-template web
+
+See also:
+    add-model-template
+    model-templates
+`
+
+// NewRemoveModelTemplateCommand returns a command that removes a model
+// template from the controller.
+func NewRemoveModelTemplateCommand() cmd.Command {
+	return modelcmd.WrapController(&removeModelTemplateCommand{})
+}
+
+type removeModelTemplateCommand struct {
+	modelTemplatesCommandBase
+
+	name string
+}
+
+// Info implements Command.Info.
+func (c *removeModelTemplateCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "remove-model-template",
+		Args:    "<template name>",
+		Purpose: "Removes a model template from the controller.",
+		Doc:     removeModelTemplateDoc,
+	})
+}
+
+// Init implements Command.Init.
+func (c *removeModelTemplateCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no model template name specified")
+	}
+	c.name, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *removeModelTemplateCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if err := client.RemoveModelTemplate(c.name); err != nil {
+		return block.ProcessBlockedError(err, block.BlockRemove)
+	}
+	ctx.Infof("Removed model template %q", c.name)
+	return nil
+}
@@ -0,0 +1,176 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/jujuclient"
+)
+
+type modelTemplatesSuite struct {
+	baseControllerSuite
+	api   *fakeModelTemplatesAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&modelTemplatesSuite{})
+
+func (s *modelTemplatesSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.api = &fakeModelTemplatesAPI{}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+func (s *modelTemplatesSuite) TestAddModelTemplate(c *gc.C) {
+	command := controller.NewAddModelTemplateCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "web",
+		"--config", "logging-config=<root>=DEBUG",
+		"--constraints", "mem=4G",
+		"--default-space", "dmz",
+		"--credential", "aws/admin/shared",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Added model template \"web\"\n")
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"AddModelTemplate", []interface{}{params.ModelTemplate{
+			Name:               "web",
+			Config:             map[string]interface{}{"logging-config": "<root>=DEBUG"},
+			Constraints:        constraints.MustParse("mem=4G"),
+			DefaultSpace:       "dmz",
+			CloudCredentialTag: "cloudcred-aws_admin_shared",
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *modelTemplatesSuite) TestAddModelTemplateInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no model template name specified",
+	}, {
+		args: []string{"Web"},
+		err:  `model template name "Web" not valid`,
+	}, {
+		args: []string{"web", "--constraints", "mem=lots"},
+		err:  `bad "mem" constraint: .*`,
+	}, {
+		args: []string{"web", "--default-space", "DMZ"},
+		err:  `space name "DMZ" not valid`,
+	}, {
+		args: []string{"web", "--credential", "shared"},
+		err:  `credential "shared", expected <cloud>/<owner>/<name> not valid`,
+	}, {
+		args: []string{"web", "extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		command := controller.NewAddModelTemplateCommandForTest(s.api, s.store)
+		_, err := cmdtesting.RunCommand(c, command, test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *modelTemplatesSuite) TestModelTemplatesTabular(c *gc.C) {
+	s.api.templates = []params.ModelTemplate{{
+		Name:               "web",
+		Config:             map[string]interface{}{"logging-config": "<root>=DEBUG"},
+		Constraints:        constraints.MustParse("mem=4G"),
+		DefaultSpace:       "dmz",
+		CloudCredentialTag: "cloudcred-aws_admin_shared",
+		CreatedBy:          "admin",
+		Created:            time.Date(2020, 3, 1, 10, 0, 0, 0, time.UTC),
+	}}
+	command := controller.NewModelTemplatesCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Name  Config          Constraints  Default space  Credential        Created by  Created
+web   logging-config  mem=4096M    dmz            aws/admin/shared  admin       2020-03-01 10:00:00Z
+`[1:])
+	s.api.CheckCallNames(c, "ModelTemplates", "Close")
+}
+
+func (s *modelTemplatesSuite) TestModelTemplatesYAML(c *gc.C) {
+	s.api.templates = []params.ModelTemplate{{
+		Name:      "web",
+		Config:    map[string]interface{}{"logging-config": "<root>=DEBUG"},
+		CreatedBy: "admin",
+	}}
+	command := controller.NewModelTemplatesCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- name: web
+  config:
+    logging-config: <root>=DEBUG
+  created-by: admin
+`[1:])
+}
+
+func (s *modelTemplatesSuite) TestModelTemplatesNone(c *gc.C) {
+	command := controller.NewModelTemplatesCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No model templates to display.\n")
+}
+
+func (s *modelTemplatesSuite) TestRemoveModelTemplate(c *gc.C) {
+	command := controller.NewRemoveModelTemplateCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "web")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Removed model template \"web\"\n")
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"RemoveModelTemplate", []interface{}{"web"}},
+		{"Close", nil},
+	})
+}
+
+func (s *modelTemplatesSuite) TestRemoveModelTemplateError(c *gc.C) {
+	s.api.SetErrors(errors.NotFoundf(`model template "web"`))
+	command := controller.NewRemoveModelTemplateCommandForTest(s.api, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "web")
+	c.Assert(err, gc.ErrorMatches, `model template "web" not found`)
+}
+
+type fakeModelTemplatesAPI struct {
+	testing.Stub
+	templates []params.ModelTemplate
+}
+
+func (f *fakeModelTemplatesAPI) AddModelTemplate(template params.ModelTemplate) error {
+	f.MethodCall(f, "AddModelTemplate", template)
+	return f.NextErr()
+}
+
+func (f *fakeModelTemplatesAPI) ModelTemplates() ([]params.ModelTemplate, error) {
+	f.MethodCall(f, "ModelTemplates")
+	return f.templates, f.NextErr()
+}
+
+func (f *fakeModelTemplatesAPI) RemoveModelTemplate(name string) error {
+	f.MethodCall(f, "RemoveModelTemplate", name)
+	return f.NextErr()
+}
+
+func (f *fakeModelTemplatesAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
@@ -0,0 +1,222 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/search"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/jujuclient"
+)
+
+// SearchAPI defines the API methods used by the find-unit and
+// find-machine commands.
+type SearchAPI interface {
+	FindUnits(pattern string) ([]params.FoundEntity, error)
+	FindMachines(query string) ([]params.FoundEntity, error)
+	Close() error
+}
+
+const findUnitDoc = `
+Find the units, in all the models you can see in the controller, whose
+names match the pattern. The pattern may contain shell-style wildcards;
+a pattern without a "/" is matched against the unit's application.
+
+Examples:
+    juju find-unit mysql
+    juju find-unit 'mysql/*'
+    juju find-unit 'wordpress/1?' --format yaml
+
+See also:
+    find-machine
+    status
+`
+
+const findMachineDoc = `
+Find the machines, in all the models you can see in the controller,
+which have the given IP address, hostname or instance ID. This is
+useful when an alert only gives the address of a machine.
+
+Examples:
+    juju find-machine 10.0.3.14
+    juju find-machine i-0d6be2ab55a1b2c3d
+
+See also:
+    find-unit
+    status
+`
+
+// NewFindUnitCommand returns a command that finds units across the
+// models in the controller.
+func NewFindUnitCommand() cmd.Command {
+	return modelcmd.WrapController(&searchCommand{unit: true})
+}
+
+// NewFindMachineCommand returns a command that finds machines across
+// the models in the controller.
+func NewFindMachineCommand() cmd.Command {
+	return modelcmd.WrapController(&searchCommand{})
+}
+
+// searchCommand implements both the find-unit and the find-machine
+// commands, which only differ in what they search for.
+type searchCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+
+	newAPIFunc func() (SearchAPI, error)
+	unit       bool
+	query      string
+}
+
+// Info implements Command.Info.
+func (c *searchCommand) Info() *cmd.Info {
+	if c.unit {
+		return jujucmd.Info(&cmd.Info{
+			Name:    "find-unit",
+			Args:    "<pattern>",
+			Purpose: "Finds units across the models in the controller.",
+			Doc:     findUnitDoc,
+		})
+	}
+	return jujucmd.Info(&cmd.Info{
+		Name:    "find-machine",
+		Args:    "<address|instance-id>",
+		Purpose: "Finds machines by address or instance ID across the models in the controller.",
+		Doc:     findMachineDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *searchCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSearchTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *searchCommand) Init(args []string) error {
+	if len(args) == 0 {
+		if c.unit {
+			return errors.New("no unit pattern specified")
+		}
+		return errors.New("no address or instance ID specified")
+	}
+	c.query = args[0]
+	return cmd.CheckEmpty(args[1:])
+}
+
+func (c *searchCommand) newAPI() (SearchAPI, error) {
+	if c.newAPIFunc != nil {
+		return c.newAPIFunc()
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return search.NewClient(root), nil
+}
+
+// searchResult is the serialisation format of a unit or machine found
+// by the find-unit and find-machine commands.
+type searchResult struct {
+	Model      string   `yaml:"model" json:"model"`
+	Unit       string   `yaml:"unit,omitempty" json:"unit,omitempty"`
+	Machine    string   `yaml:"machine,omitempty" json:"machine,omitempty"`
+	InstanceId string   `yaml:"instance-id,omitempty" json:"instance-id,omitempty"`
+	Status     string   `yaml:"status" json:"status"`
+	Message    string   `yaml:"message,omitempty" json:"message,omitempty"`
+	Addresses  []string `yaml:"addresses,omitempty" json:"addresses,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *searchCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	var entities []params.FoundEntity
+	if c.unit {
+		entities, err = client.FindUnits(c.query)
+	} else {
+		entities, err = client.FindMachines(c.query)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	results := make([]searchResult, 0, len(entities))
+	for _, entity := range entities {
+		owner, err := names.ParseUserTag(entity.ModelOwnerTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		tag, err := names.ParseTag(entity.EntityTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		result := searchResult{
+			Model:      jujuclient.JoinOwnerModelName(owner, entity.ModelName),
+			Machine:    entity.Machine,
+			InstanceId: entity.InstanceId,
+			Status:     string(entity.Status.Status),
+			Message:    entity.Status.Info,
+			Addresses:  entity.Addresses,
+		}
+		if tag.Kind() == names.UnitTagKind {
+			result.Unit = tag.Id()
+		} else {
+			result.Machine = tag.Id()
+		}
+		results = append(results, result)
+	}
+	if len(results) == 0 && c.out.Name() == "tabular" {
+		if c.unit {
+			ctx.Infof("No units found.")
+		} else {
+			ctx.Infof("No machines found.")
+		}
+		return nil
+	}
+	return c.out.Write(ctx, results)
+}
+
+func formatSearchTabular(writer io.Writer, value interface{}) error {
+	results, ok := value.([]searchResult)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", results, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	units := len(results) > 0 && results[0].Unit != ""
+	if units {
+		w.Println("Model", "Unit", "Status", "Machine", "Addresses", "Message")
+	} else {
+		w.Println("Model", "Machine", "Status", "Instance ID", "Addresses", "Message")
+	}
+	for _, result := range results {
+		addresses := strings.Join(result.Addresses, ",")
+		if units {
+			w.Println(result.Model, result.Unit, result.Status, result.Machine, addresses, result.Message)
+		} else {
+			w.Println(result.Model, result.Machine, result.Status, result.InstanceId, addresses, result.Message)
+		}
+	}
+	tw.Flush()
+	return nil
+}
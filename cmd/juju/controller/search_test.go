@@ -0,0 +1,129 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/jujuclient"
+)
+
+type searchSuite struct {
+	baseControllerSuite
+	api   *fakeSearchAPI
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&searchSuite{})
+
+func (s *searchSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.api = &fakeSearchAPI{}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "fake"
+	s.store.Controllers["fake"] = jujuclient.ControllerDetails{}
+}
+
+func (s *searchSuite) TestInit(c *gc.C) {
+	err := cmdtesting.InitCommand(controller.NewFindUnitCommandForTest(s.api, s.store), nil)
+	c.Assert(err, gc.ErrorMatches, "no unit pattern specified")
+	err = cmdtesting.InitCommand(controller.NewFindMachineCommandForTest(s.api, s.store), nil)
+	c.Assert(err, gc.ErrorMatches, "no address or instance ID specified")
+	err = cmdtesting.InitCommand(controller.NewFindMachineCommandForTest(s.api, s.store), []string{"10.0.0.1", "foo"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *searchSuite) TestFindUnit(c *gc.C) {
+	s.api.entities = []params.FoundEntity{{
+		ModelTag:      "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		ModelName:     "payments",
+		ModelOwnerTag: "user-bob",
+		EntityTag:     "unit-mysql-0",
+		Status:        params.EntityStatus{Status: status.Active},
+		Addresses:     []string{"10.0.0.1", "54.0.0.1"},
+		Machine:       "0",
+	}, {
+		ModelTag:      "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		ModelName:     "payments",
+		ModelOwnerTag: "user-bob",
+		EntityTag:     "unit-mysql-1",
+		Status:        params.EntityStatus{Status: status.Error, Info: "hook failed"},
+		Machine:       "1",
+	}}
+	ctx, err := cmdtesting.RunCommand(c, controller.NewFindUnitCommandForTest(s.api, s.store), "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Model         Unit     Status  Machine  Addresses          Message
+bob/payments  mysql/0  active  0        10.0.0.1,54.0.0.1  
+bob/payments  mysql/1  error   1                           hook failed
+`[1:])
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"FindUnits", []interface{}{"mysql"}},
+		{"Close", nil},
+	})
+}
+
+func (s *searchSuite) TestFindMachineYAML(c *gc.C) {
+	s.api.entities = []params.FoundEntity{{
+		ModelTag:      "model-deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		ModelName:     "payments",
+		ModelOwnerTag: "user-bob",
+		EntityTag:     "machine-0",
+		Status:        params.EntityStatus{Status: status.Started},
+		Addresses:     []string{"10.0.0.1"},
+		InstanceId:    "i-0",
+	}}
+	ctx, err := cmdtesting.RunCommand(c, controller.NewFindMachineCommandForTest(s.api, s.store),
+		"10.0.0.1", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- model: bob/payments
+  machine: "0"
+  instance-id: i-0
+  status: started
+  addresses:
+  - 10.0.0.1
+`[1:])
+	s.api.CheckCall(c, 0, "FindMachines", "10.0.0.1")
+}
+
+func (s *searchSuite) TestFindMachineNoneFound(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, controller.NewFindMachineCommandForTest(s.api, s.store), "i-1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No machines found.\n")
+}
+
+func (s *searchSuite) TestFindUnitError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := cmdtesting.RunCommand(c, controller.NewFindUnitCommandForTest(s.api, s.store), "mysql")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeSearchAPI struct {
+	testing.Stub
+	entities []params.FoundEntity
+}
+
+func (f *fakeSearchAPI) FindUnits(pattern string) ([]params.FoundEntity, error) {
+	f.MethodCall(f, "FindUnits", pattern)
+	return f.entities, f.NextErr()
+}
+
+func (f *fakeSearchAPI) FindMachines(query string) ([]params.FoundEntity, error) {
+	f.MethodCall(f, "FindMachines", query)
+	return f.entities, f.NextErr()
+}
+
+func (f *fakeSearchAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
@@ -6,6 +6,7 @@ package controller
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
@@ -94,6 +95,7 @@ type ControllerAccessAPI interface {
 	MongoVersion() (string, error)
 	IdentityProviderURL() (string, error)
 	ControllerVersion() (controller.ControllerVersion, error)
+	SchemaStatus() (params.SchemaStatusResult, error)
 	Close() error
 }
 
@@ -141,6 +143,7 @@ func (c *showControllerCommand) Run(ctx *cmd.Context) error {
 			mongoVersion      string
 			controllerVersion string
 			agentGitCommit    string
+			schemaStatus      *params.SchemaStatusResult
 		)
 
 		accountDetails, err := c.store.AccountDetails(controllerName)
@@ -188,6 +191,13 @@ func (c *showControllerCommand) Run(ctx *cmd.Context) error {
 				details.Errors = append(details.Errors, err.Error())
 				mongoVersion = "(error)"
 			}
+			// Fetch the schema status if the apiserver supports it
+			status, err := client.SchemaStatus()
+			if err == nil {
+				schemaStatus = &status
+			} else if !errors.IsNotSupported(err) {
+				details.Errors = append(details.Errors, err.Error())
+			}
 		}
 
 		// Fetch identityURL if the apiserver supports it
@@ -234,6 +244,9 @@ func (c *showControllerCommand) Run(ctx *cmd.Context) error {
 
 		c.convertControllerForShow(&details, controllerName, one, access, allModels,
 			modelStatusResults, mongoVersion, controllerVersion, agentGitCommit, identityURL)
+		if schemaStatus != nil {
+			c.convertSchemaStatusForShow(&details, *schemaStatus)
+		}
 		controllers[controllerName] = details
 	}
 	return c.out.Write(ctx, controllers)
@@ -291,6 +304,10 @@ type ShowControllerDetails struct {
 	// Account is the account details for the user logged into this controller.
 	Account *AccountDetails `yaml:"account,omitempty" json:"account,omitempty"`
 
+	// SchemaMigrations is a collection of the online schema migrations
+	// which have yet to complete.
+	SchemaMigrations map[string]SchemaMigrationDetails `yaml:"schema-migrations,omitempty" json:"schema-migrations,omitempty"`
+
 	// Errors is a collection of errors related to accessing this controller details.
 	Errors []string `yaml:"errors,omitempty" json:"errors,omitempty"`
 }
@@ -333,6 +350,9 @@ type ControllerDetails struct {
 	// if one has been configured for this controller.
 	IdentityURL string `yaml:"identity-url,omitempty" json:"identity-url,omitempty"`
 
+	// SchemaVersion is the schema version of the controller's database.
+	SchemaVersion *int `yaml:"schema-version,omitempty" json:"schema-version,omitempty"`
+
 	// SHA-256 fingerprint of the CA cert
 	CAFingerprint string `yaml:"ca-fingerprint,omitempty" json:"ca-fingerprint,omitempty"`
 
@@ -371,6 +391,28 @@ type ModelDetails struct {
 	UnitCount *int `yaml:"unit-count,omitempty" json:"unit-count,omitempty"`
 }
 
+// SchemaMigrationDetails holds details of an online schema migration
+// to show.
+type SchemaMigrationDetails struct {
+	// Description says what the migration does.
+	Description string `yaml:"description" json:"description"`
+
+	// Version is the schema version of the database once the
+	// migration has completed.
+	Version int `yaml:"version" json:"version"`
+
+	// Status is "pending" if the migration hasn't started, and
+	// "running" otherwise.
+	Status string `yaml:"status" json:"status"`
+
+	// Processed is the number of documents the migration has looked
+	// at so far.
+	Processed int `yaml:"processed" json:"processed"`
+
+	// Started is when the migration started running.
+	Started *time.Time `yaml:"started,omitempty" json:"started,omitempty"`
+}
+
 // AccountDetails holds details of an account to show.
 type AccountDetails struct {
 	// User is the username for the account.
@@ -550,3 +592,27 @@ func haStatus(hasVote bool, wantsVote bool, statusStr string) string {
 	}
 	return "ha-pending"
 }
+
+func (c *showControllerCommand) convertSchemaStatusForShow(controller *ShowControllerDetails, status params.SchemaStatusResult) {
+	version := status.Version
+	controller.Details.SchemaVersion = &version
+	for _, migration := range status.Migrations {
+		if migration.Done {
+			continue
+		}
+		if controller.SchemaMigrations == nil {
+			controller.SchemaMigrations = make(map[string]SchemaMigrationDetails)
+		}
+		details := SchemaMigrationDetails{
+			Description: migration.Description,
+			Version:     migration.Version,
+			Status:      "pending",
+			Processed:   migration.Processed,
+			Started:     migration.Started,
+		}
+		if migration.Started != nil {
+			details.Status = "running"
+		}
+		controller.SchemaMigrations[migration.Name] = details
+	}
+}
@@ -5,6 +5,7 @@ package controller_test
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/juju/juju/api/base"
 	apicontroller "github.com/juju/juju/api/controller"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/jujuclient"
@@ -527,6 +529,53 @@ func (s *ShowControllerSuite) TestShowControllerWithIdentityProvider(c *gc.C) {
 	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, "identity-url: "+expURL)
 }
 
+func (s *ShowControllerSuite) TestShowControllerWithSchemaMigrations(c *gc.C) {
+	_ = s.createTestClientStore(c)
+	ctx, err := s.runShowController(c, "aws-test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Not(jc.Contains), "schema-version")
+
+	started := time.Date(2020, 6, 3, 14, 0, 0, 0, time.UTC)
+	s.fakeController.bestAPIVersion = 10
+	s.fakeController.schemaStatus = params.SchemaStatusResult{
+		Version:       1,
+		LatestVersion: 3,
+		Migrations: []params.SchemaMigration{{
+			Name:    "done",
+			Version: 1,
+			Done:    true,
+		}, {
+			Name:        "running",
+			Description: "does something slowly",
+			Version:     2,
+			Processed:   500,
+			Started:     &started,
+		}, {
+			Name:        "pending",
+			Description: "does something else",
+			Version:     3,
+		}},
+	}
+	ctx, err = s.runShowController(c, "aws-test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, "schema-version: 1\n")
+	c.Assert(cmdtesting.Stdout(ctx), jc.Contains, `
+  schema-migrations:
+    pending:
+      description: does something else
+      version: 3
+      status: pending
+      processed: 0
+    running:
+      description: does something slowly
+      version: 2
+      status: running
+      processed: 500
+      started: 2020-06-03T14:00:00Z
+`[1:])
+	c.Assert(cmdtesting.Stdout(ctx), gc.Not(jc.Contains), "done:")
+}
+
 func (s *ShowControllerSuite) TestShowControllerWithCAFingerprint(c *gc.C) {
 	s.controllersYaml = `controllers:
   mallards:
@@ -624,6 +673,7 @@ type fakeController struct {
 	bestAPIVersion    int
 	identityURL       string
 	controllerVersion apicontroller.ControllerVersion
+	schemaStatus      params.SchemaStatusResult
 }
 
 func (c *fakeController) GetControllerAccess(user string) (permission.Access, error) {
@@ -684,6 +734,13 @@ func (c *fakeController) ControllerVersion() (apicontroller.ControllerVersion, e
 	return c.controllerVersion, nil
 }
 
+func (c *fakeController) SchemaStatus() (params.SchemaStatusResult, error) {
+	if c.bestAPIVersion < 10 {
+		return params.SchemaStatusResult{}, errors.NotSupportedf("SchemaStatus")
+	}
+	return c.schemaStatus, nil
+}
+
 func (*fakeController) Close() error {
 	return nil
 }
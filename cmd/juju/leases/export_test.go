@@ -0,0 +1,51 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leases
+
+import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+func NewShowLeasesCommandForTest(store jujuclient.ClientStore, api ShowLeasesAPI) cmd.Command {
+	c := &showLeasesCommand{
+		newAPIFunc: func() (ShowLeasesAPI, error) {
+			return api, nil
+		},
+	}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewRevokeLeaseCommandForTest(store jujuclient.ClientStore, api RevokeLeaseAPI) cmd.Command {
+	c := &revokeLeaseCommand{
+		newAPIFunc: func() (RevokeLeaseAPI, error) {
+			return api, nil
+		},
+	}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewPinLeaderCommandForTest(store jujuclient.ClientStore, api PinLeaderAPI) cmd.Command {
+	c := &pinLeaderCommand{
+		newAPIFunc: func() (PinLeaderAPI, error) {
+			return api, nil
+		},
+	}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
+
+func NewUnpinLeaderCommandForTest(store jujuclient.ClientStore, api UnpinLeaderAPI) cmd.Command {
+	c := &unpinLeaderCommand{
+		newAPIFunc: func() (UnpinLeaderAPI, error) {
+			return api, nil
+		},
+	}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
@@ -0,0 +1,214 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leases_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/leases"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type leasesSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+
+	api *fakeLeasesAPI
+}
+
+var _ = gc.Suite(&leasesSuite{})
+
+func (s *leasesSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	expiry := time.Date(2020, 4, 1, 10, 0, 0, 0, time.UTC)
+	s.api = &fakeLeasesAPI{
+		details: []params.LeaseDetails{{
+			Namespace: "application-leadership",
+			Lease:     "redis",
+			Holder:    "redis/0",
+			Expiry:    expiry,
+			PinnedBy:  []string{"machine-0"},
+		}, {
+			Namespace: "singular-controller",
+			Lease:     "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+			Holder:    "machine-1",
+			Expiry:    expiry,
+		}},
+	}
+}
+
+func (s *leasesSuite) TestShowLeasesTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, leases.NewShowLeasesCommandForTest(jujuclienttesting.MinimalStore(), s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Namespace               Lease                                 Holder     Expiry                Pinned by\n"+
+		"application-leadership  redis                                 redis/0    2020-04-01T10:00:00Z  machine-0\n"+
+		"singular-controller     deadbeef-0bad-400d-8000-4b1d0d06f00d  machine-1  2020-04-01T10:00:00Z  \n")
+	s.api.CheckCallNames(c, "ShowLeases", "Close")
+}
+
+func (s *leasesSuite) TestShowLeasesYAML(c *gc.C) {
+	s.api.details = s.api.details[:1]
+	ctx, err := cmdtesting.RunCommand(c, leases.NewShowLeasesCommandForTest(jujuclienttesting.MinimalStore(), s.api), "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- namespace: application-leadership
+  lease: redis
+  holder: redis/0
+  expiry: 2020-04-01T10:00:00Z
+  pinned-by:
+  - machine-0
+`[1:])
+}
+
+func (s *leasesSuite) TestShowLeasesNone(c *gc.C) {
+	s.api.details = nil
+	ctx, err := cmdtesting.RunCommand(c, leases.NewShowLeasesCommandForTest(jujuclienttesting.MinimalStore(), s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No leases are held in this model.\n")
+}
+
+func (s *leasesSuite) TestShowLeasesError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := cmdtesting.RunCommand(c, leases.NewShowLeasesCommandForTest(jujuclienttesting.MinimalStore(), s.api))
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *leasesSuite) TestRevokeLease(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, leases.NewRevokeLeaseCommandForTest(jujuclienttesting.MinimalStore(), s.api), "redis")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Lease \"redis\" in namespace \"application-leadership\" revoked.\n")
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"RevokeLease", []interface{}{"application-leadership", "redis"}},
+		{"Close", nil},
+	})
+}
+
+func (s *leasesSuite) TestRevokeLeaseNamespace(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, leases.NewRevokeLeaseCommandForTest(jujuclienttesting.MinimalStore(), s.api),
+		"--namespace", "singular-controller", "deadbeef-0bad-400d-8000-4b1d0d06f00d")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "RevokeLease", "singular-controller", "deadbeef-0bad-400d-8000-4b1d0d06f00d")
+}
+
+func (s *leasesSuite) TestRevokeLeaseInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no lease name specified",
+	}, {
+		args: []string{"redis", "mysql"},
+		err:  `unrecognized args: \["mysql"\]`,
+	}, {
+		args: []string{"--namespace", "bogus", "redis"},
+		err:  `lease namespace "bogus" not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := cmdtesting.RunCommand(c, leases.NewRevokeLeaseCommandForTest(jujuclienttesting.MinimalStore(), s.api), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *leasesSuite) TestPinLeader(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, leases.NewPinLeaderCommandForTest(jujuclienttesting.MinimalStore(), s.api),
+		"redis", "--duration", "2h", "--reason", "restoring backup")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Leader of \"redis\" pinned for 2h0m0s.\n")
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"PinLeader", []interface{}{"redis", 2 * time.Hour, "restoring backup"}},
+		{"Close", nil},
+	})
+}
+
+func (s *leasesSuite) TestPinLeaderDefaultDuration(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, leases.NewPinLeaderCommandForTest(jujuclienttesting.MinimalStore(), s.api), "redis")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "PinLeader", "redis", time.Hour, "")
+}
+
+func (s *leasesSuite) TestPinLeaderInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no application specified",
+	}, {
+		args: []string{"redis/0"},
+		err:  `application name "redis/0" not valid`,
+	}, {
+		args: []string{"redis", "--duration", "0s"},
+		err:  "pin duration 0s not valid",
+	}, {
+		args: []string{"redis", "mysql"},
+		err:  `unrecognized args: \["mysql"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := cmdtesting.RunCommand(c, leases.NewPinLeaderCommandForTest(jujuclienttesting.MinimalStore(), s.api), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *leasesSuite) TestPinLeaderError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := cmdtesting.RunCommand(c, leases.NewPinLeaderCommandForTest(jujuclienttesting.MinimalStore(), s.api), "redis")
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *leasesSuite) TestUnpinLeader(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, leases.NewUnpinLeaderCommandForTest(jujuclienttesting.MinimalStore(), s.api), "redis")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Leader of \"redis\" unpinned.\n")
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"UnpinLeader", []interface{}{"redis"}},
+		{"Close", nil},
+	})
+}
+
+func (s *leasesSuite) TestUnpinLeaderInit(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, leases.NewUnpinLeaderCommandForTest(jujuclienttesting.MinimalStore(), s.api))
+	c.Assert(err, gc.ErrorMatches, "no application specified")
+	s.api.CheckNoCalls(c)
+}
+
+type fakeLeasesAPI struct {
+	jujutesting.Stub
+	details []params.LeaseDetails
+}
+
+func (f *fakeLeasesAPI) ShowLeases() ([]params.LeaseDetails, error) {
+	f.AddCall("ShowLeases")
+	return f.details, f.NextErr()
+}
+
+func (f *fakeLeasesAPI) RevokeLease(namespace, leaseName string) error {
+	f.AddCall("RevokeLease", namespace, leaseName)
+	return f.NextErr()
+}
+
+func (f *fakeLeasesAPI) PinLeader(application string, duration time.Duration, reason string) error {
+	f.AddCall("PinLeader", application, duration, reason)
+	return f.NextErr()
+}
+
+func (f *fakeLeasesAPI) UnpinLeader(application string) error {
+	f.AddCall("UnpinLeader", application)
+	return f.NextErr()
+}
+
+func (f *fakeLeasesAPI) Close() error {
+	f.AddCall("Close")
+	return nil
+}
@@ -0,0 +1,112 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leases
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/leases"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const pinLeaderDoc = `
+Pin the leader of an application for a bounded time, so that leadership
+does not move to another unit while maintenance is carried out, even if
+the leader's agent stops extending its lease. The pin is lifted when
+the duration has passed, or by running unpin-leader.
+
+The duration may be at most 24 hours. The reason given, along with the
+time the pin expires, is shown in the application's status data until
+the pin is lifted.
+
+Examples:
+    juju pin-leader mysql --duration 2h --reason "restoring backup"
+
+See also:
+    unpin-leader
+    show-leases
+`
+
+// PinLeaderAPI defines the API methods used by the pin-leader command.
+type PinLeaderAPI interface {
+	PinLeader(application string, duration time.Duration, reason string) error
+	Close() error
+}
+
+// NewPinLeaderCommand returns a command that pins an application's
+// leader.
+func NewPinLeaderCommand() cmd.Command {
+	c := &pinLeaderCommand{}
+	c.newAPIFunc = func() (PinLeaderAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return leases.NewClient(root), nil
+	}
+	return modelcmd.Wrap(c)
+}
+
+type pinLeaderCommand struct {
+	modelcmd.ModelCommandBase
+
+	application string
+	duration    time.Duration
+	reason      string
+
+	newAPIFunc func() (PinLeaderAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *pinLeaderCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "pin-leader",
+		Args:    "<application>",
+		Purpose: "Prevents an application's leader from changing for a time.",
+		Doc:     pinLeaderDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *pinLeaderCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.DurationVar(&c.duration, "duration", time.Hour, "How long to pin the leader for")
+	f.StringVar(&c.reason, "reason", "", "Why the leader is being pinned")
+}
+
+// Init implements Command.Init.
+func (c *pinLeaderCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application specified")
+	}
+	c.application, args = args[0], args[1:]
+	if !names.IsValidApplication(c.application) {
+		return errors.NotValidf("application name %q", c.application)
+	}
+	if c.duration <= 0 {
+		return errors.NotValidf("pin duration %v", c.duration)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *pinLeaderCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if err := client.PinLeader(c.application, c.duration, c.reason); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Leader of %q pinned for %v.", c.application, c.duration)
+	return nil
+}
@@ -0,0 +1,110 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leases
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/leases"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/core/lease"
+)
+
+const revokeLeaseDoc = `
+Forcibly revoke a lease in the model, regardless of its current holder
+and expiry time. This is intended for recovering from a holder that is
+wedged, and is neither extending nor releasing the lease: once the lease
+is revoked, another party is able to claim it.
+
+Leadership leases are named after their application. The singular lease
+for a model is named after the model's UUID; use show-leases to see the
+leases currently held.
+
+Revoking a lease from a healthy holder is safe, but will cause the lease
+to move, such as a change of application leader.
+
+Examples:
+    juju revoke-lease mysql
+    juju revoke-lease --namespace singular-controller <model-uuid>
+
+See also:
+    show-leases
+`
+
+// RevokeLeaseAPI defines the API methods used by the revoke-lease command.
+type RevokeLeaseAPI interface {
+	RevokeLease(namespace, leaseName string) error
+	Close() error
+}
+
+// NewRevokeLeaseCommand returns a command that forcibly revokes a lease.
+func NewRevokeLeaseCommand() cmd.Command {
+	c := &revokeLeaseCommand{}
+	c.newAPIFunc = func() (RevokeLeaseAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return leases.NewClient(root), nil
+	}
+	return modelcmd.Wrap(c)
+}
+
+type revokeLeaseCommand struct {
+	modelcmd.ModelCommandBase
+
+	namespace string
+	leaseName string
+
+	newAPIFunc func() (RevokeLeaseAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *revokeLeaseCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "revoke-lease",
+		Args:    "<lease name>",
+		Purpose: "Forcibly revokes a leadership or singular lease.",
+		Doc:     revokeLeaseDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *revokeLeaseCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.namespace, "namespace", lease.ApplicationLeadershipNamespace,
+		"The lease namespace; application-leadership or singular-controller")
+}
+
+// Init implements Command.Init.
+func (c *revokeLeaseCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no lease name specified")
+	}
+	c.leaseName, args = args[0], args[1:]
+	switch c.namespace {
+	case lease.ApplicationLeadershipNamespace, lease.SingularControllerNamespace:
+	default:
+		return errors.NotValidf("lease namespace %q", c.namespace)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *revokeLeaseCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if err := client.RevokeLease(c.namespace, c.leaseName); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Lease %q in namespace %q revoked.", c.leaseName, c.namespace)
+	return nil
+}
@@ -0,0 +1,145 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leases
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/leases"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+const showLeasesDoc = `
+Show the leadership and singular leases held in the model, with the
+current holder of each lease and when the lease will expire unless it
+is extended.
+
+A lease that has expired according to its expiry time but is still
+listed is likely to have a wedged holder; see revoke-lease.
+
+Examples:
+    juju show-leases
+    juju show-leases --format yaml
+
+See also:
+    revoke-lease
+`
+
+// ShowLeasesAPI defines the API methods used by the show-leases command.
+type ShowLeasesAPI interface {
+	ShowLeases() ([]params.LeaseDetails, error)
+	Close() error
+}
+
+// NewShowLeasesCommand returns a command that shows the leases in a model.
+func NewShowLeasesCommand() cmd.Command {
+	c := &showLeasesCommand{}
+	c.newAPIFunc = func() (ShowLeasesAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return leases.NewClient(root), nil
+	}
+	return modelcmd.Wrap(c)
+}
+
+type showLeasesCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+
+	newAPIFunc func() (ShowLeasesAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *showLeasesCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "show-leases",
+		Purpose: "Shows the leadership and singular leases held in the model.",
+		Doc:     showLeasesDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *showLeasesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatLeasesTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *showLeasesCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// leaseInfo is the serialisation format for a single lease.
+type leaseInfo struct {
+	Namespace string    `yaml:"namespace" json:"namespace"`
+	Lease     string    `yaml:"lease" json:"lease"`
+	Holder    string    `yaml:"holder" json:"holder"`
+	Expiry    time.Time `yaml:"expiry" json:"expiry"`
+	PinnedBy  []string  `yaml:"pinned-by,omitempty" json:"pinned-by,omitempty"`
+}
+
+// Run implements Command.Run.
+func (c *showLeasesCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	details, err := client.ShowLeases()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(details) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No leases are held in this model.")
+		return nil
+	}
+	infos := make([]leaseInfo, len(details))
+	for i, d := range details {
+		infos[i] = leaseInfo{
+			Namespace: d.Namespace,
+			Lease:     d.Lease,
+			Holder:    d.Holder,
+			Expiry:    d.Expiry,
+			PinnedBy:  d.PinnedBy,
+		}
+	}
+	return c.out.Write(ctx, infos)
+}
+
+func formatLeasesTabular(writer io.Writer, value interface{}) error {
+	infos, ok := value.([]leaseInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", infos, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Namespace", "Lease", "Holder", "Expiry", "Pinned by")
+	for _, info := range infos {
+		w.Println(
+			info.Namespace,
+			info.Lease,
+			info.Holder,
+			info.Expiry.UTC().Format(time.RFC3339),
+			strings.Join(info.PinnedBy, ","),
+		)
+	}
+	tw.Flush()
+	return nil
+}
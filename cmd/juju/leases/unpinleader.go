@@ -0,0 +1,91 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leases
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/leases"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const unpinLeaderDoc = `
+Lift a pin placed on an application's leader by pin-leader, before it
+expires. Leadership is then free to move as usual.
+
+Examples:
+    juju unpin-leader mysql
+
+See also:
+    pin-leader
+`
+
+// UnpinLeaderAPI defines the API methods used by the unpin-leader
+// command.
+type UnpinLeaderAPI interface {
+	UnpinLeader(application string) error
+	Close() error
+}
+
+// NewUnpinLeaderCommand returns a command that lifts the pin on an
+// application's leader.
+func NewUnpinLeaderCommand() cmd.Command {
+	c := &unpinLeaderCommand{}
+	c.newAPIFunc = func() (UnpinLeaderAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return leases.NewClient(root), nil
+	}
+	return modelcmd.Wrap(c)
+}
+
+type unpinLeaderCommand struct {
+	modelcmd.ModelCommandBase
+
+	application string
+
+	newAPIFunc func() (UnpinLeaderAPI, error)
+}
+
+// Info implements Command.Info.
+func (c *unpinLeaderCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "unpin-leader",
+		Args:    "<application>",
+		Purpose: "Lifts the pin on an application's leader.",
+		Doc:     unpinLeaderDoc,
+	})
+}
+
+// Init implements Command.Init.
+func (c *unpinLeaderCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no application specified")
+	}
+	c.application, args = args[0], args[1:]
+	if !names.IsValidApplication(c.application) {
+		return errors.NotValidf("application name %q", c.application)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements Command.Run.
+func (c *unpinLeaderCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	if err := client.UnpinLeader(c.application); err != nil {
+		return errors.Trace(err)
+	}
+	ctx.Infof("Leader of %q unpinned.", c.application)
+	return nil
+}
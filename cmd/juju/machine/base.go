@@ -83,5 +83,11 @@ func (c *baselistMachinesCommand) Run(ctx *cmd.Context) error {
 }
 
 func (c *baselistMachinesCommand) tabular(writer io.Writer, value interface{}) error {
+	if timelines, ok := value.(machineTimelines); ok {
+		return formatTimelineTabular(writer, timelines)
+	}
+	if updates, ok := value.(machinesUpdates); ok {
+		return formatUpdatesTabular(writer, updates)
+	}
 	return status.FormatMachineTabular(writer, c.color, value)
 }
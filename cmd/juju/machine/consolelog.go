@@ -0,0 +1,152 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const machineConsoleLogDoc = `
+Show the console output of a machine's cloud instance, as retrieved
+from the cloud, along with the status of cloud-init on the instance.
+This allows machines which never start their agents, and so remain
+pending, to be diagnosed without logging in to the cloud's console.
+
+By default the console output is written to stdout and the status of
+cloud-init to stderr. The yaml and json formats include both.
+
+Only model admins may retrieve console output, and not all clouds
+support retrieving it.
+
+Examples:
+    juju machine-console-log 3
+    juju machine-console-log 3 --format yaml
+
+See also:
+    show-machine
+`
+
+// MachineConsoleLogAPI defines the API methods used by the
+// machine-console-log command.
+type MachineConsoleLogAPI interface {
+	MachineConsoleLog(machineId string) (params.MachineConsoleLogResult, error)
+	Close() error
+}
+
+// NewMachineConsoleLogCommand returns a command that shows the console
+// output of a machine's instance.
+func NewMachineConsoleLogCommand() cmd.Command {
+	return modelcmd.Wrap(&machineConsoleLogCommand{})
+}
+
+type machineConsoleLogCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+	api MachineConsoleLogAPI
+
+	machineId string
+}
+
+// Info implements Command.Info.
+func (c *machineConsoleLogCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "machine-console-log",
+		Args:    "<machine ID>",
+		Purpose: "Shows the console output of a machine's instance.",
+		Doc:     machineConsoleLogDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *machineConsoleLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "default", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"default": formatConsoleOutput,
+	})
+}
+
+// Init implements Command.Init.
+func (c *machineConsoleLogCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no machine ID specified")
+	}
+	c.machineId, args = args[0], args[1:]
+	if !names.IsValidMachine(c.machineId) {
+		return errors.NotValidf("machine ID %q", c.machineId)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *machineConsoleLogCommand) getAPI() (MachineConsoleLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// consoleLog is the serialisation format of a machine's console output
+// for the machine-console-log command.
+type consoleLog struct {
+	Machine         string `yaml:"machine" json:"machine"`
+	InstanceId      string `yaml:"instance-id" json:"instance-id"`
+	CloudInitStatus string `yaml:"cloud-init-status" json:"cloud-init-status"`
+	CloudInitDetail string `yaml:"cloud-init-detail,omitempty" json:"cloud-init-detail,omitempty"`
+	Output          string `yaml:"output" json:"output"`
+}
+
+// Run implements Command.Run.
+func (c *machineConsoleLogCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	result, err := client.MachineConsoleLog(c.machineId)
+	if params.IsCodeNotProvisioned(err) {
+		return errors.Errorf("machine %s has no instance yet", c.machineId)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	log := consoleLog{
+		Machine:         c.machineId,
+		InstanceId:      result.InstanceId,
+		CloudInitStatus: result.CloudInitStatus,
+		CloudInitDetail: result.CloudInitDetail,
+		Output:          result.Output,
+	}
+	if c.out.Name() == "default" {
+		ctx.Infof("Instance %s, cloud-init %s", log.InstanceId, log.CloudInitStatus)
+		if log.CloudInitDetail != "" {
+			ctx.Infof("  %s", log.CloudInitDetail)
+		}
+	}
+	return c.out.Write(ctx, log)
+}
+
+func formatConsoleOutput(writer io.Writer, value interface{}) error {
+	log, ok := value.(consoleLog)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", log, value)
+	}
+	_, err := fmt.Fprint(writer, log.Output)
+	return errors.Trace(err)
+}
@@ -0,0 +1,104 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type MachineConsoleLogCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *fakeConsoleLogAPI
+}
+
+var _ = gc.Suite(&MachineConsoleLogCommandSuite{})
+
+func (s *MachineConsoleLogCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeConsoleLogAPI{
+		result: params.MachineConsoleLogResult{
+			InstanceId:      "i-0123",
+			Output:          "[    5.0] Cloud-init v. 19.4 running 'init-local'\n",
+			CloudInitStatus: "running",
+			CloudInitDetail: "[    5.0] Cloud-init v. 19.4 running 'init-local'",
+		},
+	}
+}
+
+func (s *MachineConsoleLogCommandSuite) TestInit(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: nil,
+		err:  "no machine ID specified",
+	}, {
+		args: []string{"web"},
+		err:  `machine ID "web" not valid`,
+	}, {
+		args: []string{"0", "1"},
+		err:  `unrecognized args: \["1"\]`,
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		_, err := cmdtesting.RunCommand(c, machine.NewMachineConsoleLogCommandForTest(s.api), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	s.api.CheckNoCalls(c)
+}
+
+func (s *MachineConsoleLogCommandSuite) TestConsoleLog(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, machine.NewMachineConsoleLogCommandForTest(s.api), "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "[    5.0] Cloud-init v. 19.4 running 'init-local'\n")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+Instance i-0123, cloud-init running
+  [    5.0] Cloud-init v. 19.4 running 'init-local'
+`[1:])
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"MachineConsoleLog", []interface{}{"0"}},
+		{"Close", nil},
+	})
+}
+
+func (s *MachineConsoleLogCommandSuite) TestConsoleLogYAML(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, machine.NewMachineConsoleLogCommandForTest(s.api), "0", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+machine: "0"
+instance-id: i-0123
+cloud-init-status: running
+cloud-init-detail: '[    5.0] Cloud-init v. 19.4 running ''init-local'''
+output: |
+  [    5.0] Cloud-init v. 19.4 running 'init-local'
+`[1:])
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "")
+}
+
+func (s *MachineConsoleLogCommandSuite) TestConsoleLogNotProvisioned(c *gc.C) {
+	s.api.SetErrors(&params.Error{Message: "machine 0 not provisioned", Code: params.CodeNotProvisioned})
+	_, err := cmdtesting.RunCommand(c, machine.NewMachineConsoleLogCommandForTest(s.api), "0")
+	c.Assert(err, gc.ErrorMatches, "machine 0 has no instance yet")
+}
+
+type fakeConsoleLogAPI struct {
+	jujutesting.Stub
+	result params.MachineConsoleLogResult
+}
+
+func (f *fakeConsoleLogAPI) MachineConsoleLog(machineId string) (params.MachineConsoleLogResult, error) {
+	f.MethodCall(f, "MachineConsoleLog", machineId)
+	return f.result, f.NextErr()
+}
+
+func (f *fakeConsoleLogAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
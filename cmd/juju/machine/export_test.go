@@ -39,6 +39,15 @@ func NewListCommandForTest(api statusAPI) cmd.Command {
 	return modelcmd.Wrap(command)
 }
 
+// NewListUpdatesCommandForTest returns a listMachinesCommand that reads
+// the machines' security updates from the specified api.
+func NewListUpdatesCommandForTest(api updatesAPI) cmd.Command {
+	command := newListMachinesCommand(nil)
+	command.updatesAPI = api
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(command)
+}
+
 // NewShowCommandForTest returns a showMachineCommand with specified api
 func NewShowCommandForTest(api statusAPI) cmd.Command {
 	command := newShowMachineCommand(api)
@@ -46,6 +55,31 @@ func NewShowCommandForTest(api statusAPI) cmd.Command {
 	return modelcmd.Wrap(command)
 }
 
+// NewShowTimelineCommandForTest returns a showMachineCommand that reads
+// machine timelines from the specified api.
+func NewShowTimelineCommandForTest(api historyAPI) cmd.Command {
+	command := newShowMachineCommand(nil)
+	command.historyAPI = api
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(command)
+}
+
+// NewMachineConsoleLogCommandForTest returns a machineConsoleLogCommand
+// with the api provided as specified.
+func NewMachineConsoleLogCommandForTest(api MachineConsoleLogAPI) cmd.Command {
+	command := &machineConsoleLogCommand{api: api}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(command)
+}
+
+// NewSyncMachineAddressesCommandForTest returns a
+// syncMachineAddressesCommand with the api provided as specified.
+func NewSyncMachineAddressesCommandForTest(api SyncMachineAddressesAPI) cmd.Command {
+	command := &syncMachineAddressesCommand{api: api}
+	command.SetClientStore(jujuclienttesting.MinimalStore())
+	return modelcmd.Wrap(command)
+}
+
 type RemoveCommand struct {
 	*removeCommand
 }
@@ -4,10 +4,21 @@
 package machine
 
 import (
+	"fmt"
+	"io"
+
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/naturalsort"
+	"gopkg.in/juju/names.v3"
 
+	"github.com/juju/juju/api/securityupdates"
+	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
 )
 
 var usageListMachinesSummary = `
@@ -18,8 +29,14 @@ By default, the tabular format is used.
 The following sections are included: ID, STATE, DNS, INS-ID, SERIES, AZ
 Note: AZ above is the cloud region's availability zone.
 
+With --updates, show the package updates pending on each machine, how
+many of them are security updates, and whether the machine needs
+rebooting, with totals across the model. Machines only report these
+when the model's report-security-updates setting is enabled.
+
 Examples:
      juju machines
+     juju machines --updates
 
 See also: 
     status`
@@ -36,9 +53,19 @@ func newListMachinesCommand(api statusAPI) *listMachinesCommand {
 	return listCmd
 }
 
+// updatesAPI defines the API methods used to show the security updates
+// pending on the machines.
+type updatesAPI interface {
+	Summary() (params.SecurityUpdatesSummary, error)
+	Close() error
+}
+
 // listMachineCommand holds information about machines in a model.
 type listMachinesCommand struct {
 	baselistMachinesCommand
+
+	updates    bool
+	updatesAPI updatesAPI
 }
 
 // Info implements Command.Info.
@@ -51,7 +78,123 @@ func (c *listMachinesCommand) Info() *cmd.Info {
 	})
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *listMachinesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.baselistMachinesCommand.SetFlags(f)
+	f.BoolVar(&c.updates, "updates", false, "Show the security updates pending on each machine")
+}
+
 // Init ensures the machines Command does not take arguments.
 func (c *listMachinesCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args)
 }
+
+// Run implements Command.Run.
+func (c *listMachinesCommand) Run(ctx *cmd.Context) error {
+	if !c.updates {
+		return c.baselistMachinesCommand.Run(ctx)
+	}
+	client, err := c.getUpdatesAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	summary, err := client.Summary()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if summary.Reporting == 0 {
+		ctx.Infof("No machines have reported security updates; enable reporting with:\n" +
+			"    juju model-config report-security-updates=true")
+	}
+	return c.out.Write(ctx, c.formatUpdates(summary))
+}
+
+func (c *listMachinesCommand) getUpdatesAPI() (updatesAPI, error) {
+	if c.updatesAPI != nil {
+		return c.updatesAPI, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return securityupdates.NewClient(root), nil
+}
+
+// machinesUpdates is the serialisation format for the security updates
+// pending on the machines in a model.
+type machinesUpdates struct {
+	Machines       map[string]machineUpdates `yaml:"machines" json:"machines"`
+	NotReporting   []string                  `yaml:"not-reporting,omitempty" json:"not-reporting,omitempty"`
+	Pending        int                       `yaml:"pending" json:"pending"`
+	Security       int                       `yaml:"security" json:"security"`
+	RebootRequired int                       `yaml:"reboot-required" json:"reboot-required"`
+}
+
+// machineUpdates is the serialisation format for the security updates
+// pending on a machine.
+type machineUpdates struct {
+	Pending        int      `yaml:"pending" json:"pending"`
+	Security       int      `yaml:"security" json:"security"`
+	RebootRequired bool     `yaml:"reboot-required" json:"reboot-required"`
+	RebootPackages []string `yaml:"reboot-packages,omitempty" json:"reboot-packages,omitempty"`
+	Checked        string   `yaml:"checked" json:"checked"`
+}
+
+func (c *listMachinesCommand) formatUpdates(summary params.SecurityUpdatesSummary) machinesUpdates {
+	result := machinesUpdates{
+		Machines:       make(map[string]machineUpdates),
+		Pending:        summary.Pending,
+		Security:       summary.Security,
+		RebootRequired: summary.RebootRequired,
+	}
+	for _, m := range summary.Machines {
+		tag, err := names.ParseMachineTag(m.Tag)
+		if err != nil {
+			continue
+		}
+		if m.Report == nil {
+			result.NotReporting = append(result.NotReporting, tag.Id())
+			continue
+		}
+		checked := m.Report.Checked
+		result.Machines[tag.Id()] = machineUpdates{
+			Pending:        m.Report.Pending,
+			Security:       m.Report.Security,
+			RebootRequired: m.Report.RebootRequired,
+			RebootPackages: m.Report.RebootPackages,
+			Checked:        common.FormatTime(&checked, c.isoTime),
+		}
+	}
+	result.NotReporting = naturalsort.Sort(result.NotReporting)
+	return result
+}
+
+func formatUpdatesTabular(writer io.Writer, updates machinesUpdates) error {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Machine", "Pending", "Security", "Reboot", "Checked")
+	ids := make([]string, 0, len(updates.Machines)+len(updates.NotReporting))
+	for id := range updates.Machines {
+		ids = append(ids, id)
+	}
+	ids = append(ids, updates.NotReporting...)
+	for _, id := range naturalsort.Sort(ids) {
+		m, ok := updates.Machines[id]
+		if !ok {
+			w.Println(id, "-", "-", "-", "-")
+			continue
+		}
+		reboot := "no"
+		if m.RebootRequired {
+			reboot = "yes"
+		}
+		w.Println(id, m.Pending, m.Security, reboot, m.Checked)
+	}
+	tw.Flush()
+	fmt.Fprintf(writer, "\n%d of %d machines reporting: %d updates pending, %d security, reboot required on %d\n",
+		len(updates.Machines), len(ids), updates.Pending, updates.Security, updates.RebootRequired,
+	)
+	return nil
+}
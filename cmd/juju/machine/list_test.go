@@ -4,6 +4,8 @@
 package machine_test
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	jc "github.com/juju/testing/checkers"
@@ -325,3 +327,99 @@ func (s *MachineListCommandSuite) TestListMachineArgsError(c *gc.C) {
 	_, err := cmdtesting.RunCommand(c, newMachineListCommand(), "0")
 	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["0"\]`)
 }
+
+type fakeUpdatesAPI struct {
+	summary params.SecurityUpdatesSummary
+}
+
+func (f *fakeUpdatesAPI) Summary() (params.SecurityUpdatesSummary, error) {
+	return f.summary, nil
+}
+
+func (*fakeUpdatesAPI) Close() error {
+	return nil
+}
+
+func newUpdatesAPI() *fakeUpdatesAPI {
+	checked := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	return &fakeUpdatesAPI{summary: params.SecurityUpdatesSummary{
+		Machines: []params.MachineSecurityUpdates{{
+			Tag: "machine-10",
+			Report: &params.SecurityUpdatesReport{
+				Pending:        3,
+				Security:       1,
+				RebootRequired: true,
+				RebootPackages: []string{"libc6"},
+				Checked:        checked,
+			},
+		}, {
+			Tag: "machine-1",
+		}, {
+			Tag: "machine-2",
+			Report: &params.SecurityUpdatesReport{
+				Pending:  10,
+				Security: 4,
+				Checked:  checked,
+			},
+		}},
+		Reporting:      2,
+		Pending:        13,
+		Security:       5,
+		RebootRequired: 1,
+	}}
+}
+
+func (s *MachineListCommandSuite) TestListUpdates(c *gc.C) {
+	command := machine.NewListUpdatesCommandForTest(newUpdatesAPI())
+	context, err := cmdtesting.RunCommand(c, command, "--updates", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
+		"Machine  Pending  Security  Reboot  Checked\n"+
+		"1        -        -         -       -\n"+
+		"2        10       4         no      2020-06-01 12:00:00Z\n"+
+		"10       3        1         yes     2020-06-01 12:00:00Z\n"+
+		"\n"+
+		"2 of 3 machines reporting: 13 updates pending, 5 security, reboot required on 1\n")
+	c.Assert(cmdtesting.Stderr(context), gc.Equals, "")
+}
+
+func (s *MachineListCommandSuite) TestListUpdatesYaml(c *gc.C) {
+	command := machine.NewListUpdatesCommandForTest(newUpdatesAPI())
+	context, err := cmdtesting.RunCommand(c, command, "--updates", "--utc", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
+		"machines:\n"+
+		"  \"2\":\n"+
+		"    pending: 10\n"+
+		"    security: 4\n"+
+		"    reboot-required: false\n"+
+		"    checked: 2020-06-01 12:00:00Z\n"+
+		"  \"10\":\n"+
+		"    pending: 3\n"+
+		"    security: 1\n"+
+		"    reboot-required: true\n"+
+		"    reboot-packages:\n"+
+		"    - libc6\n"+
+		"    checked: 2020-06-01 12:00:00Z\n"+
+		"not-reporting:\n"+
+		"- \"1\"\n"+
+		"pending: 13\n"+
+		"security: 5\n"+
+		"reboot-required: 1\n")
+}
+
+func (s *MachineListCommandSuite) TestListUpdatesNoneReporting(c *gc.C) {
+	api := &fakeUpdatesAPI{summary: params.SecurityUpdatesSummary{
+		Machines: []params.MachineSecurityUpdates{{Tag: "machine-0"}},
+	}}
+	context, err := cmdtesting.RunCommand(c, machine.NewListUpdatesCommandForTest(api), "--updates")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
+		"Machine  Pending  Security  Reboot  Checked\n"+
+		"0        -        -         -       -\n"+
+		"\n"+
+		"0 of 1 machines reporting: 0 updates pending, 0 security, reboot required on 0\n")
+	c.Assert(cmdtesting.Stderr(context), gc.Equals, ""+
+		"No machines have reported security updates; enable reporting with:\n"+
+		"    juju model-config report-security-updates=true\n")
+}
@@ -4,10 +4,20 @@
 package machine
 
 import (
+	"io"
+	"time"
+
 	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"github.com/juju/naturalsort"
+	"gopkg.in/juju/names.v3"
 
 	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/status"
 )
 
 const showMachineCommandDoc = `
@@ -15,9 +25,16 @@ Show a specified machine on a model.  Default format is in yaml,
 other formats can be specified with the "--format" option.
 Available formats are yaml, tabular, and json
 
+With --timeline, show when each machine reached each phase of being
+brought up (instance requested, instance running, cloud-init done,
+agent connected and each unit deployed), and the time elapsed since
+the instance was requested. This is useful for diagnosing machines
+that are slow to come up.
+
 Examples:
     juju show-machine 0
     juju show-machine 1 2 3
+    juju show-machine 0 --timeline
 
 `
 
@@ -33,9 +50,18 @@ func newShowMachineCommand(api statusAPI) *showMachineCommand {
 	return showCmd
 }
 
+// historyAPI defines the API methods used to show machine timelines.
+type historyAPI interface {
+	StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error)
+	Close() error
+}
+
 // showMachineCommand struct holds details on the specified machine[s].
 type showMachineCommand struct {
 	baselistMachinesCommand
+
+	timeline   bool
+	historyAPI historyAPI
 }
 
 // Info implements Command.Info.
@@ -48,8 +74,111 @@ func (c *showMachineCommand) Info() *cmd.Info {
 	})
 }
 
+// SetFlags implements Command.SetFlags.
+func (c *showMachineCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.baselistMachinesCommand.SetFlags(f)
+	f.BoolVar(&c.timeline, "timeline", false, "Show when each phase of bringing up the machines was reached")
+}
+
 // Init captures machineId's to show from CL args.
 func (c *showMachineCommand) Init(args []string) error {
 	c.machineIds = args
+	if c.timeline {
+		if len(args) == 0 {
+			return errors.New("--timeline requires at least one machine ID")
+		}
+		for _, id := range args {
+			if !names.IsValidMachine(id) {
+				return errors.NotValidf("machine ID %q", id)
+			}
+		}
+	}
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *showMachineCommand) Run(ctx *cmd.Context) error {
+	if !c.timeline {
+		return c.baselistMachinesCommand.Run(ctx)
+	}
+	client, err := c.getHistoryAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	timelines := make(machineTimelines)
+	for _, id := range c.machineIds {
+		history, err := client.StatusHistory(
+			status.KindMachineProvisioning,
+			names.NewMachineTag(id),
+			// A machine has an entry for each phase and each deployed
+			// unit, so this comfortably covers the whole timeline.
+			status.StatusHistoryFilter{Size: timelineSize},
+		)
+		if err != nil {
+			return errors.Annotatef(err, "getting timeline of machine %s", id)
+		}
+		timelines[id] = c.timelineEntries(history)
+	}
+	return c.out.Write(ctx, timelines)
+}
+
+func (c *showMachineCommand) getHistoryAPI() (historyAPI, error) {
+	if c.historyAPI != nil {
+		return c.historyAPI, nil
+	}
+	return c.NewAPIClient()
+}
+
+const timelineSize = 100
+
+// machineTimelines holds the provisioning timelines of machines,
+// keyed by machine ID.
+type machineTimelines map[string][]timelineEntry
+
+// timelineEntry is the serialisation format for a single phase of
+// a machine's provisioning timeline.
+type timelineEntry struct {
+	Phase   string `yaml:"phase" json:"phase"`
+	Time    string `yaml:"time" json:"time"`
+	Elapsed string `yaml:"elapsed" json:"elapsed"`
+	Message string `yaml:"message,omitempty" json:"message,omitempty"`
+}
+
+func (c *showMachineCommand) timelineEntries(history status.History) []timelineEntry {
+	entries := make([]timelineEntry, 0, len(history))
+	var start *time.Time
+	for _, h := range history {
+		if h.Since == nil {
+			continue
+		}
+		if start == nil {
+			start = h.Since
+		}
+		entries = append(entries, timelineEntry{
+			Phase:   string(h.Status),
+			Time:    common.FormatTime(h.Since, c.isoTime),
+			Elapsed: h.Since.Sub(*start).Round(time.Second).String(),
+			Message: h.Info,
+		})
+	}
+	return entries
+}
+
+func formatTimelineTabular(writer io.Writer, timelines machineTimelines) error {
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Machine", "Phase", "Time", "Elapsed", "Message")
+	ids := make([]string, 0, len(timelines))
+	for id := range timelines {
+		ids = append(ids, id)
+	}
+	for _, id := range naturalsort.Sort(ids) {
+		for _, entry := range timelines[id] {
+			w.Println(id, entry.Phase, entry.Time, entry.Elapsed, entry.Message)
+		}
+	}
+	tw.Flush()
 	return nil
 }
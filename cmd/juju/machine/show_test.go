@@ -4,12 +4,18 @@
 package machine_test
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/testing"
 )
 
@@ -124,6 +130,109 @@ func (s *MachineShowCommandSuite) TestShowSingleMachine(c *gc.C) {
 		"    hardware: availability-zone=us-east-1\n")
 }
 
+type fakeFailedStatusAPI struct{}
+
+func (*fakeFailedStatusAPI) Status(c []string) (*params.FullStatus, error) {
+	return &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			Name:    "dummyenv",
+			Version: "1.2.3",
+		},
+		Machines: map[string]params.MachineStatus{
+			"0": {
+				Id: "0",
+				AgentStatus: params.DetailedStatus{
+					Status: "pending",
+				},
+				InstanceStatus: params.DetailedStatus{
+					Status: string(status.ProvisioningError),
+					Info:   "no capacity",
+					Data: map[string]interface{}{
+						status.StartAttemptsKey:    3,
+						status.StartRetriesLeftKey: 0,
+						status.AvailabilityZoneKey: "us-east-1a",
+						status.InstanceTypeKey:     "m5.large",
+						status.ProviderErrorKey:    "no capacity",
+					},
+				},
+				Series: "bionic",
+			},
+		},
+	}, nil
+}
+
+func (*fakeFailedStatusAPI) Close() error {
+	return nil
+}
+
+func (s *MachineShowCommandSuite) TestShowMachineProvisioningError(c *gc.C) {
+	context, err := cmdtesting.RunCommand(c, machine.NewShowCommandForTest(&fakeFailedStatusAPI{}), "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
+		"model: dummyenv\n"+
+		"machines:\n"+
+		"  \"0\":\n"+
+		"    juju-status:\n"+
+		"      current: pending\n"+
+		"    machine-status:\n"+
+		"      current: provisioning error\n"+
+		"      message: no capacity\n"+
+		"      data:\n"+
+		"        availability-zone: us-east-1a\n"+
+		"        instance-type: m5.large\n"+
+		"        provider-error: no capacity\n"+
+		"        start-attempts: 3\n"+
+		"        start-retries-left: 0\n"+
+		"    series: bionic\n")
+}
+
+type fakeWorkerFailuresStatusAPI struct{}
+
+func (*fakeWorkerFailuresStatusAPI) Status(c []string) (*params.FullStatus, error) {
+	return &params.FullStatus{
+		Model: params.ModelStatusInfo{
+			Name:    "dummyenv",
+			Version: "1.2.3",
+		},
+		Machines: map[string]params.MachineStatus{
+			"0": {
+				Id: "0",
+				AgentStatus: params.DetailedStatus{
+					Status: "started",
+					Data: map[string]interface{}{
+						status.WorkerFailuresKey: status.WorkerFailuresData([]status.WorkerFailure{{
+							Worker: "disk-manager",
+							Error:  "listing block devices: exit status 1",
+							Time:   time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+						}}),
+					},
+				},
+				Series: "bionic",
+			},
+		},
+	}, nil
+}
+
+func (*fakeWorkerFailuresStatusAPI) Close() error {
+	return nil
+}
+
+func (s *MachineShowCommandSuite) TestShowMachineWorkerFailures(c *gc.C) {
+	context, err := cmdtesting.RunCommand(c, machine.NewShowCommandForTest(&fakeWorkerFailuresStatusAPI{}), "--utc", "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
+		"model: dummyenv\n"+
+		"machines:\n"+
+		"  \"0\":\n"+
+		"    juju-status:\n"+
+		"      current: started\n"+
+		"    worker-failures:\n"+
+		"    - worker: disk-manager\n"+
+		"      error: 'listing block devices: exit status 1'\n"+
+		"      time: 2020-06-01 12:00:00Z\n"+
+		"    series: bionic\n")
+}
+
 func (s *MachineShowCommandSuite) TestShowTabularMachine(c *gc.C) {
 	context, err := cmdtesting.RunCommand(c, newMachineShowCommand(), "--format", "tabular", "0", "1")
 	c.Assert(err, jc.ErrorIsNil)
@@ -243,3 +352,83 @@ func (s *MachineShowCommandSuite) TestShowJsonMachine(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(actualJSON, gc.DeepEquals, expectedJSON)
 }
+
+type fakeHistoryAPI struct {
+	calls   []string
+	history status.History
+}
+
+func (f *fakeHistoryAPI) StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error) {
+	f.calls = append(f.calls, fmt.Sprintf("%s %s %d", kind, tag.Id(), filter.Size))
+	return f.history, nil
+}
+
+func (f *fakeHistoryAPI) Close() error {
+	return nil
+}
+
+func (s *MachineShowCommandSuite) newTimelineAPI() *fakeHistoryAPI {
+	start := time.Date(2020, 3, 4, 10, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		t := start.Add(d)
+		return &t
+	}
+	return &fakeHistoryAPI{history: status.History{{
+		Status: status.Status(status.PhaseInstanceRequested),
+		Since:  at(0),
+	}, {
+		Status: status.Status(status.PhaseInstanceRunning),
+		Since:  at(95 * time.Second),
+	}, {
+		Status: status.Status(status.PhaseAgentConnected),
+		Since:  at(3 * time.Minute),
+	}, {
+		Status: status.Status(status.PhaseUnitDeployed),
+		Info:   "mysql/0",
+		Since:  at(4 * time.Minute),
+	}}}
+}
+
+func (s *MachineShowCommandSuite) TestShowTimeline(c *gc.C) {
+	api := s.newTimelineAPI()
+	context, err := cmdtesting.RunCommand(c, machine.NewShowTimelineCommandForTest(api), "--timeline", "--utc", "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api.calls, jc.DeepEquals, []string{"machine-provisioning 0 100"})
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
+		"\"0\":\n"+
+		"- phase: instance-requested\n"+
+		"  time: 2020-03-04 10:00:00Z\n"+
+		"  elapsed: 0s\n"+
+		"- phase: instance-running\n"+
+		"  time: 2020-03-04 10:01:35Z\n"+
+		"  elapsed: 1m35s\n"+
+		"- phase: agent-connected\n"+
+		"  time: 2020-03-04 10:03:00Z\n"+
+		"  elapsed: 3m0s\n"+
+		"- phase: unit-deployed\n"+
+		"  time: 2020-03-04 10:04:00Z\n"+
+		"  elapsed: 4m0s\n"+
+		"  message: mysql/0\n")
+}
+
+func (s *MachineShowCommandSuite) TestShowTimelineTabular(c *gc.C) {
+	api := s.newTimelineAPI()
+	context, err := cmdtesting.RunCommand(c, machine.NewShowTimelineCommandForTest(api), "--timeline", "--utc", "--format", "tabular", "0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Equals, ""+
+		"Machine  Phase               Time                  Elapsed  Message\n"+
+		"0        instance-requested  2020-03-04 10:00:00Z  0s       \n"+
+		"0        instance-running    2020-03-04 10:01:35Z  1m35s    \n"+
+		"0        agent-connected     2020-03-04 10:03:00Z  3m0s     \n"+
+		"0        unit-deployed       2020-03-04 10:04:00Z  4m0s     mysql/0\n")
+}
+
+func (s *MachineShowCommandSuite) TestShowTimelineRequiresMachine(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, machine.NewShowTimelineCommandForTest(&fakeHistoryAPI{}), "--timeline")
+	c.Assert(err, gc.ErrorMatches, "--timeline requires at least one machine ID")
+}
+
+func (s *MachineShowCommandSuite) TestShowTimelineInvalidMachine(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, machine.NewShowTimelineCommandForTest(&fakeHistoryAPI{}), "--timeline", "foo")
+	c.Assert(err, gc.ErrorMatches, `machine ID "foo" not valid`)
+}
@@ -0,0 +1,122 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/api/machinemanager"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const syncMachineAddressesDoc = `
+Refresh the addresses and status of machines' cloud instances from the
+cloud now, rather than waiting for Juju to next check them. Juju checks
+running machines only every 15 minutes, so changes made in the cloud,
+such as an address being added to an instance, may otherwise take a
+while to be seen.
+
+If no machines are specified, all the model's machines are refreshed.
+Containers and manually provisioned machines are not refreshed, since
+their addresses are not retrieved from the cloud.
+
+The refresh happens in the background; use show-machine or status to
+see the results.
+
+Examples:
+    juju sync-machine-addresses 3
+    juju sync-machine-addresses 0 2
+    juju sync-machine-addresses
+
+See also:
+    show-machine
+    status
+`
+
+// SyncMachineAddressesAPI defines the API methods used by the
+// sync-machine-addresses command.
+type SyncMachineAddressesAPI interface {
+	SyncMachineAddresses(machineIds ...string) ([]params.ErrorResult, error)
+	Close() error
+}
+
+// NewSyncMachineAddressesCommand returns a command that refreshes the
+// addresses of machines from the cloud.
+func NewSyncMachineAddressesCommand() cmd.Command {
+	return modelcmd.Wrap(&syncMachineAddressesCommand{})
+}
+
+type syncMachineAddressesCommand struct {
+	modelcmd.ModelCommandBase
+	api SyncMachineAddressesAPI
+
+	machineIds []string
+}
+
+// Info implements Command.Info.
+func (c *syncMachineAddressesCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "sync-machine-addresses",
+		Args:    "[<machine ID> ...]",
+		Purpose: "Refreshes the addresses of machines from the cloud.",
+		Doc:     syncMachineAddressesDoc,
+	})
+}
+
+// Init implements Command.Init.
+func (c *syncMachineAddressesCommand) Init(args []string) error {
+	for _, id := range args {
+		if !names.IsValidMachine(id) {
+			return errors.NotValidf("machine ID %q", id)
+		}
+	}
+	c.machineIds = args
+	return nil
+}
+
+func (c *syncMachineAddressesCommand) getAPI() (SyncMachineAddressesAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return machinemanager.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *syncMachineAddressesCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	results, err := client.SyncMachineAddresses(c.machineIds...)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(c.machineIds) == 0 {
+		ctx.Infof("refreshing all machines")
+		return nil
+	}
+	var failed bool
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.Infof("refreshing machine %s failed: %s", c.machineIds[i], result.Error)
+			failed = true
+			continue
+		}
+		ctx.Infof("refreshing machine %s", c.machineIds[i])
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
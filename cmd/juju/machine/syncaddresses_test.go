@@ -0,0 +1,76 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machine_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/machine"
+	"github.com/juju/juju/testing"
+)
+
+type SyncMachineAddressesCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	api *fakeSyncMachineAddressesAPI
+}
+
+var _ = gc.Suite(&SyncMachineAddressesCommandSuite{})
+
+func (s *SyncMachineAddressesCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeSyncMachineAddressesAPI{}
+}
+
+func (s *SyncMachineAddressesCommandSuite) TestInitInvalidMachine(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, machine.NewSyncMachineAddressesCommandForTest(s.api), "0", "web")
+	c.Assert(err, gc.ErrorMatches, `machine ID "web" not valid`)
+	s.api.CheckNoCalls(c)
+}
+
+func (s *SyncMachineAddressesCommandSuite) TestSyncMachines(c *gc.C) {
+	s.api.results = []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "machine 2 not found"}},
+	}
+	ctx, err := cmdtesting.RunCommand(c, machine.NewSyncMachineAddressesCommandForTest(s.api), "0", "2")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+refreshing machine 0
+refreshing machine 2 failed: machine 2 not found
+`[1:])
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"SyncMachineAddresses", []interface{}{[]string{"0", "2"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *SyncMachineAddressesCommandSuite) TestSyncAllMachines(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, machine.NewSyncMachineAddressesCommandForTest(s.api))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "refreshing all machines\n")
+	s.api.CheckCalls(c, []jujutesting.StubCall{
+		{"SyncMachineAddresses", []interface{}{[]string(nil)}},
+		{"Close", nil},
+	})
+}
+
+type fakeSyncMachineAddressesAPI struct {
+	jujutesting.Stub
+	results []params.ErrorResult
+}
+
+func (f *fakeSyncMachineAddressesAPI) SyncMachineAddresses(machineIds ...string) ([]params.ErrorResult, error) {
+	f.MethodCall(f, "SyncMachineAddresses", machineIds)
+	return f.results, f.NextErr()
+}
+
+func (f *fakeSyncMachineAddressesAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
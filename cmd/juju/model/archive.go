@@ -0,0 +1,230 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/modelarchive"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const (
+	archiveModelSummary = "Archives a model, releasing its cloud resources until it is thawed."
+	archiveModelDoc     = `
+Archives a model which is not in use, such as a development model left
+idle over a weekend, so that it no longer uses resources in the cloud.
+
+The model is first exported and the export kept by the controller. Then
+the units of the model's applications are removed along with the machines
+hosting them; on a Kubernetes model the applications are scaled to zero
+instead. Applications, their config, relations and offers are kept, so
+that "juju thaw-model" can later add new units to replace those removed.
+
+Machines whose units have not gone after the time given by --max-wait
+are forcibly removed. Anything held on the machines' disks is lost.
+
+Models with manually provisioned machines, or with storage attached to
+units, cannot be archived, as they could not be restored.
+
+Due to this the command prompts for confirmation, unless the -y option
+is given.
+
+Archived models cannot be migrated; thaw them first.
+
+Examples:
+    juju archive-model
+    juju archive-model -m dev -y --max-wait 5m
+
+See also:
+    thaw-model
+`
+)
+
+const archiveModelMsg = `WARNING! This command will remove all units and machines of model %q.
+The model can be restored with "juju thaw-model", but data held on the
+machines will be lost.
+
+Continue [y/N]? `
+
+// ModelArchiveAPI defines the API methods used by the archive-model and
+// thaw-model commands.
+type ModelArchiveAPI interface {
+	Close() error
+	ArchiveModel(maxWait *time.Duration) (params.ModelArchiveResult, error)
+	ThawModel() (map[string]int, error)
+}
+
+// modelArchiveCommandBase is embedded by the archive-model and
+// thaw-model commands.
+type modelArchiveCommandBase struct {
+	modelcmd.ModelCommandBase
+
+	api ModelArchiveAPI
+}
+
+func (c *modelArchiveCommandBase) getAPI() (ModelArchiveAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return modelarchive.NewClient(root), nil
+}
+
+// ArchiveModelCommand supplies the "archive-model" CLI command, used to
+// archive an idle model.
+type ArchiveModelCommand struct {
+	modelArchiveCommandBase
+
+	assumeYes bool
+	maxWait   time.Duration
+}
+
+// NewArchiveModelCommand returns a command to archive a model.
+func NewArchiveModelCommand() cmd.Command {
+	return modelcmd.Wrap(&ArchiveModelCommand{})
+}
+
+// Info implements part of the cmd.Command interface.
+func (c *ArchiveModelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "archive-model",
+		Purpose: archiveModelSummary,
+		Doc:     archiveModelDoc,
+	})
+}
+
+// SetFlags implements part of the cmd.Command interface.
+func (c *ArchiveModelCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.assumeYes, "y", false, "Do not prompt for confirmation")
+	f.BoolVar(&c.assumeYes, "yes", false, "")
+	f.DurationVar(&c.maxWait, "max-wait", 0, "How long to wait for units to go before forcibly removing their machines (default 1m)")
+}
+
+// Init implements part of the cmd.Command interface.
+func (c *ArchiveModelCommand) Init(args []string) error {
+	if c.maxWait < 0 {
+		return errors.NotValidf("negative --max-wait")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements part of the cmd.Command interface.
+func (c *ArchiveModelCommand) Run(ctx *cmd.Context) error {
+	modelName, _, err := c.ModelDetails()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !c.assumeYes {
+		fmt.Fprintf(ctx.Stdout, archiveModelMsg, modelName)
+		if err := jujucmd.UserConfirmYes(ctx); err != nil {
+			return errors.Annotate(err, "model archive")
+		}
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	var maxWait *time.Duration
+	if c.maxWait > 0 {
+		maxWait = &c.maxWait
+	}
+	archive, err := client.ArchiveModel(maxWait)
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockRemove)
+	}
+	ctx.Infof("Archived model %q (%d bytes); use \"juju thaw-model\" to restore it", modelName, archive.Size)
+	return nil
+}
+
+const (
+	thawModelSummary = "Restores the workloads of an archived model."
+	thawModelDoc     = `
+Restores a model archived with "juju archive-model". New units are added
+to each application to replace those removed when the model was archived,
+with new machines provisioned for them; on a Kubernetes model the
+applications are scaled back up. Applications removed while the model was
+archived are not restored.
+
+The archive is kept until every application has been restored, so if the
+command fails part way through it may be run again; applications already
+restored are not restored twice.
+
+Examples:
+    juju thaw-model
+    juju thaw-model -m dev
+
+See also:
+    archive-model
+`
+)
+
+// ThawModelCommand supplies the "thaw-model" CLI command, used to
+// restore an archived model.
+type ThawModelCommand struct {
+	modelArchiveCommandBase
+}
+
+// NewThawModelCommand returns a command to restore an archived model.
+func NewThawModelCommand() cmd.Command {
+	return modelcmd.Wrap(&ThawModelCommand{})
+}
+
+// Info implements part of the cmd.Command interface.
+func (c *ThawModelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "thaw-model",
+		Purpose: thawModelSummary,
+		Doc:     thawModelDoc,
+	})
+}
+
+// Init implements part of the cmd.Command interface.
+func (c *ThawModelCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements part of the cmd.Command interface.
+func (c *ThawModelCommand) Run(ctx *cmd.Context) error {
+	modelName, _, err := c.ModelDetails()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	restored, err := client.ThawModel()
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	names := make([]string, 0, len(restored))
+	for name := range restored {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		ctx.Infof("Restored %d unit(s) of %q", restored[name], name)
+	}
+	ctx.Infof("Thawed model %q", modelName)
+	return nil
+}
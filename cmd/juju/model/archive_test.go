@@ -0,0 +1,148 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type archiveModelSuite struct {
+	generationBaseSuite
+
+	api *fakeModelArchiveAPI
+}
+
+var _ = gc.Suite(&archiveModelSuite{})
+
+func (s *archiveModelSuite) SetUpTest(c *gc.C) {
+	s.generationBaseSuite.SetUpTest(c)
+	s.api = &fakeModelArchiveAPI{
+		archive: params.ModelArchiveResult{
+			Size:      1024,
+			SHA256:    "abc123",
+			CreatedBy: "admin",
+			Created:   time.Date(2020, 3, 6, 18, 0, 0, 0, time.UTC),
+		},
+		restored: map[string]int{"wordpress": 2, "mysql": 1},
+	}
+}
+
+func (s *archiveModelSuite) TestArchiveModelInit(c *gc.C) {
+	command := model.NewArchiveModelCommandForTest(s.api, s.store)
+	err := cmdtesting.InitCommand(command, []string{"foo"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+
+	command = model.NewArchiveModelCommandForTest(s.api, s.store)
+	err = cmdtesting.InitCommand(command, []string{"--max-wait", "-1m"})
+	c.Assert(err, gc.ErrorMatches, "negative --max-wait not valid")
+}
+
+func (s *archiveModelSuite) TestArchiveModel(c *gc.C) {
+	command := model.NewArchiveModelCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "-y", "--max-wait", "5m")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals,
+		"Archived model \"admin/mymodel\" (1024 bytes); use \"juju thaw-model\" to restore it\n")
+	maxWait := 5 * time.Minute
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"ArchiveModel", []interface{}{&maxWait}},
+		{"Close", nil},
+	})
+}
+
+func (s *archiveModelSuite) TestArchiveModelDefaultMaxWait(c *gc.C) {
+	command := model.NewArchiveModelCommandForTest(s.api, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "-y")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "ArchiveModel", (*time.Duration)(nil))
+}
+
+func (s *archiveModelSuite) TestArchiveModelConfirmation(c *gc.C) {
+	for _, answer := range []string{"n", ""} {
+		s.api.ResetCalls()
+		command := model.NewArchiveModelCommandForTest(s.api, s.store)
+		c.Assert(cmdtesting.InitCommand(command, nil), jc.ErrorIsNil)
+		ctx := cmdtesting.Context(c)
+		ctx.Stdin = strings.NewReader(answer)
+		err := command.Run(ctx)
+		c.Check(err, gc.ErrorMatches, "model archive: aborted")
+		c.Check(cmdtesting.Stdout(ctx), gc.Matches, `WARNING!.*"admin/mymodel"(.|\n)*`)
+		s.api.CheckNoCalls(c)
+	}
+
+	command := model.NewArchiveModelCommandForTest(s.api, s.store)
+	c.Assert(cmdtesting.InitCommand(command, nil), jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader("y")
+	err := command.Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "ArchiveModel", "Close")
+}
+
+func (s *archiveModelSuite) TestArchiveModelBlocked(c *gc.C) {
+	s.api.SetErrors(common.OperationBlockedError("TestArchiveModelBlocked"))
+	command := model.NewArchiveModelCommandForTest(s.api, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "-y")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestArchiveModelBlocked.*")
+}
+
+func (s *archiveModelSuite) TestArchiveModelError(c *gc.C) {
+	s.api.SetErrors(errors.AlreadyExistsf("model archive"))
+	command := model.NewArchiveModelCommandForTest(s.api, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "-y")
+	c.Assert(err, gc.ErrorMatches, "model archive already exists")
+}
+
+func (s *archiveModelSuite) TestThawModel(c *gc.C) {
+	command := model.NewThawModelCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+Restored 1 unit(s) of "mysql"
+Restored 2 unit(s) of "wordpress"
+Thawed model "admin/mymodel"
+`[1:])
+	s.api.CheckCallNames(c, "ThawModel", "Close")
+}
+
+func (s *archiveModelSuite) TestThawModelError(c *gc.C) {
+	s.api.SetErrors(errors.New("model is not archived"))
+	command := model.NewThawModelCommandForTest(s.api, s.store)
+	_, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, gc.ErrorMatches, "model is not archived")
+}
+
+type fakeModelArchiveAPI struct {
+	testing.Stub
+	archive  params.ModelArchiveResult
+	restored map[string]int
+}
+
+func (f *fakeModelArchiveAPI) ArchiveModel(maxWait *time.Duration) (params.ModelArchiveResult, error) {
+	f.MethodCall(f, "ArchiveModel", maxWait)
+	return f.archive, f.NextErr()
+}
+
+func (f *fakeModelArchiveAPI) ThawModel() (map[string]int, error) {
+	f.MethodCall(f, "ThawModel")
+	return f.restored, f.NextErr()
+}
+
+func (f *fakeModelArchiveAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
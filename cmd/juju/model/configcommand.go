@@ -17,6 +17,7 @@ import (
 	"gopkg.in/juju/environschema.v1"
 
 	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/juju/common"
@@ -34,6 +35,10 @@ are displayed.
 Supplying one key name returns only the value for the key. Supplying key=value
 will set the supplied key to the supplied value, this can be repeated for
 multiple keys. You can also specify a yaml file containing key values.
+
+The --help-keys option asks the controller for a description of every model
+configuration key it knows about, including its type, default value and
+whether it can be changed after the model has been created.
 `
 	modelConfigHelpDocKeys = `
 The following keys are available:
@@ -47,6 +52,7 @@ Examples:
     juju model-config path/to/file.yaml
     juju model-config -m othercontroller:mymodel default-series=yakkety test-mode=false
     juju model-config --reset default-series test-mode
+    juju model-config --help-keys
 
 See also:
     models
@@ -75,6 +81,7 @@ type configCommand struct {
 	reset      []string // Holds the keys to be reset until parsed.
 	resetKeys  []string // Holds the keys to be reset once parsed.
 	setOptions common.ConfigFlag
+	helpKeys   bool
 }
 
 // configCommandAPI defines an API interface to be used during testing.
@@ -84,6 +91,7 @@ type configCommandAPI interface {
 	ModelGetWithMetadata() (config.ConfigValues, error)
 	ModelSet(config map[string]interface{}) error
 	ModelUnset(keys ...string) error
+	ConfigKeys() ([]params.ConfigKey, error)
 }
 
 // Info implements part of the cmd.Command interface.
@@ -119,6 +127,7 @@ func (c *configCommand) SetFlags(f *gnuflag.FlagSet) {
 		"yaml":    cmd.FormatYaml,
 	})
 	f.Var(cmd.NewAppendStringsValue(&c.reset), "reset", "Reset the provided comma delimited keys")
+	f.BoolVar(&c.helpKeys, "help-keys", false, "Describe the model config keys known to the controller")
 }
 
 // Init implements part of the cmd.Command interface.
@@ -131,6 +140,14 @@ func (c *configCommand) Init(args []string) error {
 		return errors.Trace(err)
 	}
 
+	if c.helpKeys {
+		if len(args) > 0 || len(c.resetKeys) > 0 {
+			return errors.New("--help-keys cannot be combined with other arguments")
+		}
+		c.action = c.describeKeys
+		return nil
+	}
+
 	switch len(args) {
 	case 0:
 		return c.handleZeroArgs()
@@ -326,6 +343,43 @@ func (c *configCommand) getConfig(client configCommandAPI, ctx *cmd.Context) err
 	return nil
 }
 
+// configKeyDetails holds the description of a model config key
+// as reported by the controller.
+type configKeyDetails struct {
+	Type        string      `yaml:"type" json:"type"`
+	Default     interface{} `yaml:"default,omitempty" json:"default,omitempty"`
+	Mutable     bool        `yaml:"mutable" json:"mutable"`
+	Description string      `yaml:"description" json:"description"`
+}
+
+// describeKeys writes the descriptions of the model config keys known
+// to the controller to the cmd.Context.
+func (c *configCommand) describeKeys(client configCommandAPI, ctx *cmd.Context) error {
+	keys, err := client.ConfigKeys()
+	if errors.IsNotSupported(err) {
+		return errors.New("describing config keys is not supported by this controller")
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	details := make(map[string]configKeyDetails)
+	for _, key := range keys {
+		if key.Scope != params.ModelConfigScope || key.Secret || isModelAttribute(key.Name) {
+			continue
+		}
+		details[key.Name] = configKeyDetails{
+			Type:        key.Type,
+			Default:     key.Default,
+			Mutable:     key.Mutable,
+			Description: key.Description,
+		}
+	}
+	if c.out.Name() == "tabular" {
+		return c.out.WriteFormatter(ctx, formatConfigKeysTabular, details)
+	}
+	return c.out.Write(ctx, details)
+}
+
 func (c *configCommand) getFilteredModel(client configCommandAPI) (config.ConfigValues, error) {
 	attrs, err := client.ModelGetWithMetadata()
 	if err != nil {
@@ -445,6 +499,38 @@ func formatConfigTabular(writer io.Writer, value interface{}) error {
 	return nil
 }
 
+// formatConfigKeysTabular writes a tabular summary of config key details.
+func formatConfigKeysTabular(writer io.Writer, value interface{}) error {
+	details, ok := value.(map[string]configKeyDetails)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", details, value)
+	}
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+
+	var names []string
+	for name := range details {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	w.Println("Key", "Type", "Default", "Mutable", "Description")
+
+	for _, name := range names {
+		info := details[name]
+		defaultValue := ""
+		if info.Default != nil {
+			defaultValue = fmt.Sprint(info.Default)
+		}
+		// Descriptions may span several lines in the schema.
+		description := strings.Join(strings.Fields(info.Description), " ")
+		w.Println(name, info.Type, defaultValue, info.Mutable, description)
+	}
+
+	tw.Flush()
+	return nil
+}
+
 // ConfigDetails gets ModelDetails when a model is not available
 // to use.
 func ConfigDetails() (map[string]interface{}, error) {
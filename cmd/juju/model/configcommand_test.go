@@ -12,6 +12,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/model"
 	"github.com/juju/juju/testing"
 )
@@ -215,6 +216,63 @@ func (s *ConfigCommandSuite) TestAllValuesTabular(c *gc.C) {
 	c.Assert(output, gc.Equals, expected)
 }
 
+func (s *ConfigCommandSuite) setConfigKeys() {
+	s.fake.configKeys = []params.ConfigKey{{
+		Name:        "special",
+		Scope:       "model",
+		Type:        "string",
+		Description: "A special\nvalue",
+		Default:     "normal",
+		Mutable:     true,
+	}, {
+		Name:        "name",
+		Scope:       "model",
+		Type:        "string",
+		Description: "The name of the current model",
+	}, {
+		Name:        "api-port",
+		Scope:       "controller",
+		Type:        "int",
+		Description: "The port used for api connections",
+		Default:     17070,
+	}}
+}
+
+func (s *ConfigCommandSuite) TestHelpKeysTabular(c *gc.C) {
+	s.setConfigKeys()
+	context, err := s.run(c, "--help-keys")
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := cmdtesting.Stdout(context)
+	expected := "" +
+		"Key      Type    Default  Mutable  Description\n" +
+		"special  string  normal   true     A special value\n" +
+		"\n"
+	c.Assert(output, gc.Equals, expected)
+}
+
+func (s *ConfigCommandSuite) TestHelpKeysYAML(c *gc.C) {
+	s.setConfigKeys()
+	context, err := s.run(c, "--help-keys", "--format=yaml")
+	c.Assert(err, jc.ErrorIsNil)
+
+	output := cmdtesting.Stdout(context)
+	expected := "" +
+		"special:\n" +
+		"  type: string\n" +
+		"  default: normal\n" +
+		"  mutable: true\n" +
+		"  description: |-\n" +
+		"    A special\n" +
+		"    value\n"
+	c.Assert(output, gc.Equals, expected)
+}
+
+func (s *ConfigCommandSuite) TestHelpKeysWithArgs(c *gc.C) {
+	_, err := s.run(c, "--help-keys", "special")
+	c.Assert(err, gc.ErrorMatches, "--help-keys cannot be combined with other arguments")
+}
+
 func (s *ConfigCommandSuite) TestSetAgentVersion(c *gc.C) {
 	_, err := s.run(c, "agent-version=2.0.0")
 	c.Assert(err, gc.ErrorMatches, `"agent-version"" must be set via "upgrade-model"`)
@@ -0,0 +1,166 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/modelcost"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/cost"
+)
+
+const (
+	costSummary = "Estimates what a model costs to run."
+	costDoc     = `
+Shows an estimate of what the model's resources cost to run, using the
+pricing set for the model's cloud with "juju cloud-pricing".
+
+The estimate covers the instances of the model's machines, its volumes,
+and the load balancers of Kubernetes applications whose
+kubernetes-service-type is LoadBalancer. Instances are priced by instance
+type where the cloud's pricing lists it, and otherwise by their cores and
+memory. Containers, and machines not yet provisioned, cost nothing.
+
+Monthly costs assume 730 hours in a month.
+
+Examples:
+    juju cost
+    juju cost -m dev --format yaml
+
+See also:
+    cloud-pricing
+`
+)
+
+// ModelCostAPI defines the API methods used by the cost command.
+type ModelCostAPI interface {
+	Close() error
+	EstimateCost() (params.ModelCostEstimate, error)
+}
+
+// CostCommand supplies the "cost" CLI command, used to estimate what a
+// model costs to run.
+type CostCommand struct {
+	modelcmd.ModelCommandBase
+
+	api ModelCostAPI
+	out cmd.Output
+}
+
+// NewCostCommand returns a command to estimate what a model costs to
+// run.
+func NewCostCommand() cmd.Command {
+	return modelcmd.Wrap(&CostCommand{})
+}
+
+// Info implements part of the cmd.Command interface.
+func (c *CostCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "cost",
+		Purpose: costSummary,
+		Doc:     costDoc,
+	})
+}
+
+// SetFlags implements part of the cmd.Command interface.
+func (c *CostCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatCostTabular,
+	})
+}
+
+// Init implements part of the cmd.Command interface.
+func (c *CostCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+func (c *CostCommand) getAPI() (ModelCostAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return modelcost.NewClient(root), nil
+}
+
+// Run implements part of the cmd.Command interface.
+func (c *CostCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	estimate, err := client.EstimateCost()
+	if params.IsCodeNotFound(err) {
+		return errors.Errorf(`%v; use "juju cloud-pricing" to set it`, err)
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	result := formattedCost{
+		Currency: estimate.Currency,
+		Items:    make([]formattedCostItem, len(estimate.Items)),
+		Hourly:   estimate.Hourly,
+		Monthly:  estimate.Monthly,
+	}
+	for i, item := range estimate.Items {
+		result.Items[i] = formattedCostItem(item)
+	}
+	return errors.Trace(c.out.Write(ctx, result))
+}
+
+type formattedCost struct {
+	Currency string              `yaml:"currency" json:"currency"`
+	Items    []formattedCostItem `yaml:"items" json:"items"`
+	Hourly   float64             `yaml:"hourly" json:"hourly"`
+	Monthly  float64             `yaml:"monthly" json:"monthly"`
+}
+
+type formattedCostItem struct {
+	Kind   string  `yaml:"kind" json:"kind"`
+	Id     string  `yaml:"id" json:"id"`
+	Detail string  `yaml:"detail,omitempty" json:"detail,omitempty"`
+	Hourly float64 `yaml:"hourly" json:"hourly"`
+}
+
+// formatCostTabular prints the estimated cost of each resource, followed
+// by the totals.
+func formatCostTabular(writer io.Writer, value interface{}) error {
+	estimate, ok := value.(formattedCost)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", estimate, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Resource", "Id", "Detail",
+		fmt.Sprintf("Hourly (%s)", estimate.Currency),
+		fmt.Sprintf("Monthly (%s)", estimate.Currency),
+	)
+	for _, item := range estimate.Items {
+		w.Println(item.Kind, item.Id, item.Detail,
+			fmt.Sprintf("%.4f", item.Hourly),
+			fmt.Sprintf("%.2f", item.Hourly*cost.HoursPerMonth),
+		)
+	}
+	w.Println("Total", "", "",
+		fmt.Sprintf("%.4f", estimate.Hourly),
+		fmt.Sprintf("%.2f", estimate.Monthly),
+	)
+	tw.Flush()
+	return nil
+}
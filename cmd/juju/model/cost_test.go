@@ -0,0 +1,110 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+)
+
+type costSuite struct {
+	generationBaseSuite
+
+	api *fakeModelCostAPI
+}
+
+var _ = gc.Suite(&costSuite{})
+
+func (s *costSuite) SetUpTest(c *gc.C) {
+	s.generationBaseSuite.SetUpTest(c)
+	s.api = &fakeModelCostAPI{
+		estimate: params.ModelCostEstimate{
+			Currency: "USD",
+			Items: []params.ModelCostItem{
+				{Kind: "machine", Id: "0", Detail: "m5.large", Hourly: 0.5},
+				{Kind: "volume", Id: "0/0", Detail: "10GiB", Hourly: 0.25},
+			},
+			Hourly:  0.75,
+			Monthly: 547.5,
+		},
+	}
+}
+
+func (s *costSuite) TestInit(c *gc.C) {
+	command := model.NewCostCommandForTest(s.api, s.store)
+	err := cmdtesting.InitCommand(command, []string{"foo"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *costSuite) TestCostTabular(c *gc.C) {
+	command := model.NewCostCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Resource  Id   Detail    Hourly (USD)  Monthly (USD)
+machine   0    m5.large  0.5000        365.00
+volume    0/0  10GiB     0.2500        182.50
+Total                    0.7500        547.50
+`[1:])
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"EstimateCost", nil},
+		{"Close", nil},
+	})
+}
+
+func (s *costSuite) TestCostYAML(c *gc.C) {
+	command := model.NewCostCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+currency: USD
+items:
+- kind: machine
+  id: "0"
+  detail: m5.large
+  hourly: 0.5
+- kind: volume
+  id: 0/0
+  detail: 10GiB
+  hourly: 0.25
+hourly: 0.75
+monthly: 547.5
+`[1:])
+}
+
+func (s *costSuite) TestCostNoPricing(c *gc.C) {
+	s.api.SetErrors(common.ServerError(errors.NotFoundf(`pricing for cloud "aws"`)))
+	command := model.NewCostCommandForTest(s.api, s.store)
+	_, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, gc.ErrorMatches, `pricing for cloud "aws" not found; use "juju cloud-pricing" to set it`)
+}
+
+func (s *costSuite) TestCostError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	command := model.NewCostCommandForTest(s.api, s.store)
+	_, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeModelCostAPI struct {
+	testing.Stub
+	estimate params.ModelCostEstimate
+}
+
+func (f *fakeModelCostAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeModelCostAPI) EstimateCost() (params.ModelCostEstimate, error) {
+	f.MethodCall(f, "EstimateCost")
+	return f.estimate, f.NextErr()
+}
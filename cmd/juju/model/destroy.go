@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"time"
 
 	jujuclock "github.com/juju/clock"
@@ -17,6 +18,7 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/romulus/api/budget"
 	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1"
 	"gopkg.in/macaroon-bakery.v2-unstable/httpbakery"
 
 	"github.com/juju/juju/api/base"
@@ -31,6 +33,7 @@ import (
 	"github.com/juju/juju/cmd/output"
 	"github.com/juju/juju/core/model"
 	corestatus "github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/watcher"
 )
 
 const (
@@ -94,6 +97,14 @@ proceed to a next step until the current step has finished.
 However, when using --force, users can also specify --no-wait to progress through steps 
 without delay waiting for each step to complete.
 
+While the model is being destroyed, the progress of tearing down each of
+its machines, volumes, filesystems and, for a Kubernetes model, its
+namespace is reported as it changes.
+
+When --force is used, resources which Juju could not confirm were released
+by the cloud are recorded as abandoned. Once the model has been removed,
+these are listed so that they can be cleaned up manually.
+
 Examples:
 
     juju destroy-model test
@@ -125,6 +136,8 @@ type DestroyModelAPI interface {
 	BestAPIVersion() int
 	DestroyModel(tag names.ModelTag, destroyStorage, force *bool, maxWait *time.Duration) error
 	ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, error)
+	WatchModelTeardown(tag names.ModelTag) (watcher.NotifyWatcher, error)
+	AbandonedModelResources(tag names.ModelTag) ([]params.AbandonedResource, error)
 }
 
 // ModelConfigAPI defines the methods on the modelconfig
@@ -343,12 +356,17 @@ upgrade the controller to version 2.3 or greater.
 	if err := waitForModelDestroyed(
 		ctx, api,
 		names.NewModelTag(modelDetails.ModelUUID),
+		modelName,
 		c.timeout,
 		c.clock,
 	); err != nil {
 		return err
 	}
 
+	if c.Force {
+		reportAbandonedResources(ctx, api, modelTag)
+	}
+
 	// Check if the model has an sla auth.
 	if slaIsSet {
 		err = c.removeModelBudget(modelDetails.ModelUUID)
@@ -392,6 +410,28 @@ type modelData struct {
 	volumeCount      int
 	filesystemCount  int
 	errorCount       int
+	resources        []teardownResource
+}
+
+// teardownResource holds the teardown progress of one of the
+// resources of a model being destroyed.
+type teardownResource struct {
+	kind       string
+	id         string
+	providerId string
+	status     string
+}
+
+func (r teardownResource) key() string {
+	return r.kind + " " + r.id
+}
+
+func (r teardownResource) String() string {
+	s := r.key()
+	if r.providerId != "" {
+		s += fmt.Sprintf(" (%s)", r.providerId)
+	}
+	return s
 }
 
 func (data *modelData) isEmpty() bool {
@@ -406,6 +446,7 @@ func waitForModelDestroyed(
 	ctx *cmd.Context,
 	api DestroyModelAPI,
 	tag names.ModelTag,
+	modelName string,
 	timeout time.Duration,
 	clock jujuclock.Clock,
 ) error {
@@ -422,12 +463,26 @@ func waitForModelDestroyed(
 		erroredStatuses.PrettyPrint(ctx.Stdout)
 	}
 
+	// Controllers which can report changes to the teardown progress
+	// are watched; older controllers are polled.
+	var changes watcher.NotifyChannel
+	if api.BestAPIVersion() >= 9 {
+		w, err := api.WatchModelTeardown(tag)
+		if err != nil {
+			logger.Debugf("cannot watch model teardown, polling instead: %v", err)
+		} else {
+			defer worker.Stop(w)
+			changes = w.Changes()
+		}
+	}
+
 	// no wait for 1st time.
-	intervalSeconds := 0 * time.Second
+	wait := clock.After(0)
 	timeoutAfter := clock.After(timeout)
 	reported := ""
 	lineLength := 0
 	const perLineLength = 80
+	var previous map[string]teardownResource
 	for {
 		select {
 		case <-interrupted:
@@ -437,31 +492,114 @@ func waitForModelDestroyed(
 		case <-timeoutAfter:
 			printErrors()
 			return errors.Timeoutf("timeout after %v", timeout)
-		case <-clock.After(intervalSeconds):
-			data, erroredStatuses = getModelStatus(ctx, api, tag)
-			if data == nil {
-				// model has been destroyed successfully.
-				return nil
+		case _, ok := <-changes:
+			if !ok {
+				// The watcher stops when the model is removed,
+				// but may also fail; poll from now on.
+				changes = nil
 			}
-			msg := formatDestroyModelInfo(data)
-			if reported == msg {
-				if lineLength == perLineLength {
-					// Time to break to the next line.
-					fmt.Fprintln(ctx.Stderr)
-					lineLength = 0
-				}
-				fmt.Fprint(ctx.Stderr, ".")
-				lineLength++
-			} else {
-				fmt.Fprint(ctx.Stderr, fmt.Sprintf("\n%v...", msg))
-				reported = msg
-				lineLength = len(msg) + 3
+		case <-wait:
+		}
+
+		data, erroredStatuses = getModelStatus(ctx, api, tag, modelName)
+		if data == nil {
+			// model has been destroyed successfully.
+			return nil
+		}
+		if progress := formatTeardownProgress(previous, data.resources); len(progress) > 0 {
+			for _, line := range progress {
+				fmt.Fprintf(ctx.Stderr, "\n%s", line)
+			}
+			// Report the overall progress again below the
+			// progress of the individual resources.
+			reported = ""
+		}
+		previous = make(map[string]teardownResource)
+		for _, r := range data.resources {
+			previous[r.key()] = r
+		}
+		msg := formatDestroyModelInfo(data)
+		if reported == msg {
+			if lineLength == perLineLength {
+				// Time to break to the next line.
+				fmt.Fprintln(ctx.Stderr)
+				lineLength = 0
 			}
-			intervalSeconds = 2 * time.Second
+			fmt.Fprint(ctx.Stderr, ".")
+			lineLength++
+		} else {
+			fmt.Fprint(ctx.Stderr, fmt.Sprintf("\n%v...", msg))
+			reported = msg
+			lineLength = len(msg) + 3
+		}
+		if changes == nil {
+			wait = clock.After(2 * time.Second)
+		} else {
+			wait = nil
 		}
 	}
 }
 
+// formatTeardownProgress returns a line for each resource whose
+// teardown progress has changed since the previous observation, and
+// for each resource that has since been removed. Nothing is reported
+// for the first observation.
+func formatTeardownProgress(previous map[string]teardownResource, current []teardownResource) []string {
+	if previous == nil {
+		return nil
+	}
+	var lines []string
+	seen := make(map[string]bool)
+	for _, r := range current {
+		seen[r.key()] = true
+		if prev, ok := previous[r.key()]; ok && prev.status == r.status {
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", r, r.status))
+	}
+	var removed []teardownResource
+	for key, r := range previous {
+		if !seen[key] {
+			removed = append(removed, r)
+		}
+	}
+	sort.Slice(removed, func(i, j int) bool {
+		return removed[i].key() < removed[j].key()
+	})
+	for _, r := range removed {
+		lines = append(lines, fmt.Sprintf("%s: removed", r))
+	}
+	return lines
+}
+
+// reportAbandonedResources writes a report of the cloud resources
+// which were abandoned while the model was destroyed by force, and
+// which may need to be cleaned up manually.
+func reportAbandonedResources(ctx *cmd.Context, api DestroyModelAPI, tag names.ModelTag) {
+	if api.BestAPIVersion() < 9 {
+		return
+	}
+	abandoned, err := api.AbandonedModelResources(tag)
+	if err != nil {
+		ctx.Warningf("cannot get abandoned cloud resources: %v", err)
+		return
+	}
+	if len(abandoned) == 0 {
+		return
+	}
+	tw := output.TabWriter(ctx.Stdout)
+	w := output.Wrapper{tw}
+	w.Println(`
+The following cloud resources were abandoned while destroying the model,
+and may still exist in the cloud. You should remove them manually.
+`)
+	w.Println("Kind", "Id", "Provider ID", "Reason")
+	for _, r := range abandoned {
+		w.Println(r.Kind, r.Id, r.ProviderId, r.Reason)
+	}
+	tw.Flush()
+}
+
 type modelResourceErrorStatus struct {
 	ID, Message string
 }
@@ -505,7 +643,7 @@ You can fix the problem causing the errors and run destroy-model again.
 	return nil
 }
 
-func getModelStatus(ctx *cmd.Context, api DestroyModelAPI, tag names.ModelTag) (*modelData, modelResourceErrorStatusSummary) {
+func getModelStatus(ctx *cmd.Context, api DestroyModelAPI, tag names.ModelTag, modelName string) (*modelData, modelResourceErrorStatusSummary) {
 	var erroredStatuses modelResourceErrorStatusSummary
 
 	status, err := api.ModelStatus(tag)
@@ -562,9 +700,48 @@ func getModelStatus(ctx *cmd.Context, api DestroyModelAPI, tag names.ModelTag) (
 		volumeCount:      len(status[0].Volumes),
 		filesystemCount:  len(status[0].Filesystems),
 		errorCount:       erroredStatuses.Count(),
+		resources:        teardownResources(status[0], modelName),
 	}, erroredStatuses
 }
 
+func teardownResources(status base.ModelStatus, modelName string) []teardownResource {
+	var resources []teardownResource
+	for _, m := range status.Machines {
+		resources = append(resources, teardownResource{
+			kind:       "machine",
+			id:         m.Id,
+			providerId: m.InstanceId,
+			status:     m.Status,
+		})
+	}
+	for _, v := range status.Volumes {
+		resources = append(resources, teardownResource{
+			kind:       "volume",
+			id:         v.Id,
+			providerId: v.ProviderId,
+			status:     v.Status,
+		})
+	}
+	for _, f := range status.Filesystems {
+		resources = append(resources, teardownResource{
+			kind:       "filesystem",
+			id:         f.Id,
+			providerId: f.ProviderId,
+			status:     f.Status,
+		})
+	}
+	if status.ModelType == model.CAAS {
+		// The namespace of a Kubernetes model is removed once
+		// all of its applications have gone.
+		resources = append(resources, teardownResource{
+			kind:   "namespace",
+			id:     modelName,
+			status: fmt.Sprintf("%d application(s) remaining", status.ApplicationCount),
+		})
+	}
+	return resources
+}
+
 func formatDestroyModelInfo(data *modelData) string {
 	out := "Waiting for model to be removed"
 	if data.errorCount > 0 {
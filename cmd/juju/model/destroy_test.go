@@ -25,6 +25,8 @@ import (
 	rcmd "github.com/juju/juju/cmd/juju/romulus"
 	"github.com/juju/juju/cmd/modelcmd"
 	coremodel "github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
 	"github.com/juju/juju/jujuclient"
 	_ "github.com/juju/juju/provider/dummy"
 	"github.com/juju/juju/testing"
@@ -53,6 +55,12 @@ type fakeAPI struct {
 	bestAPIVersion     int
 	modelInfoErr       []*params.Error
 	modelStatusPayload []base.ModelStatus
+
+	// modelStatusPayloads, if set, holds the payload returned by
+	// each successive call to ModelStatus.
+	modelStatusPayloads [][]base.ModelStatus
+	teardownChanges     chan struct{}
+	abandoned           []params.AbandonedResource
 }
 
 func (f *fakeAPI) Close() error { return nil }
@@ -78,6 +86,9 @@ func (f *fakeAPI) ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, err
 	}
 	f.statusCallCount++
 
+	if n := f.statusCallCount - 1; n < len(f.modelStatusPayloads) {
+		return f.modelStatusPayloads[n], err
+	}
 	if f.modelStatusPayload == nil {
 		f.modelStatusPayload = []base.ModelStatus{{
 			Volumes: []base.Volume{
@@ -90,6 +101,19 @@ func (f *fakeAPI) ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, err
 	return f.modelStatusPayload, err
 }
 
+func (f *fakeAPI) WatchModelTeardown(tag names.ModelTag) (watcher.NotifyWatcher, error) {
+	f.MethodCall(f, "WatchModelTeardown", tag)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return watchertest.NewMockNotifyWatcher(f.teardownChanges), nil
+}
+
+func (f *fakeAPI) AbandonedModelResources(tag names.ModelTag) ([]params.AbandonedResource, error) {
+	f.MethodCall(f, "AbandonedModelResources", tag)
+	return f.abandoned, f.NextErr()
+}
+
 // fakeConfigAPI mocks out the ModelConfigAPI.
 type fakeConfigAPI struct {
 	err      error
@@ -519,6 +543,78 @@ Waiting for model to be removed....`[1:])
 	}
 }
 
+func (s *DestroySuite) TestDestroyReportsTeardownProgress(c *gc.C) {
+	s.api.bestAPIVersion = 9
+	s.api.teardownChanges = make(chan struct{}, 3)
+	for i := 0; i < 3; i++ {
+		s.api.teardownChanges <- struct{}{}
+	}
+	s.api.modelInfoErr = []*params.Error{nil, nil}
+	s.api.modelStatusPayloads = [][]base.ModelStatus{{{
+		HostedMachineCount: 1,
+		Machines:           []base.Machine{{Id: "0", InstanceId: "i-0", Status: "started"}},
+		Volumes:            []base.Volume{{Id: "0", ProviderId: "vol-0", Status: "destroying"}},
+	}}, {{
+		HostedMachineCount: 1,
+		Machines:           []base.Machine{{Id: "0", InstanceId: "i-0", Status: "stopping"}},
+	}}}
+
+	ctx, err := s.runDestroyCommand(c, "test2", "-y")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), jc.Contains, `
+Waiting for model to be removed, 1 machine(s), 1 volume(s)...
+machine 0 (i-0): stopping
+volume 0 (vol-0): removed
+Waiting for model to be removed, 1 machine(s)...`)
+	c.Check(s.api.statusCallCount, gc.Equals, 3)
+	checkModelRemovedFromStore(c, "test1:admin/test2", s.store)
+	s.stub.CheckCallNames(c, "DestroyModel", "WatchModelTeardown")
+}
+
+func (s *DestroySuite) TestDestroyWithForceReportsAbandonedResources(c *gc.C) {
+	s.api.bestAPIVersion = 9
+	s.api.abandoned = []params.AbandonedResource{{
+		Kind:       "machine",
+		Id:         "0",
+		ProviderId: "i-0",
+		Reason:     "machine removed by force before its instance was stopped",
+	}, {
+		Kind:       "environ",
+		Id:         "test2-uuid",
+		ProviderId: "test2-uuid",
+		Reason:     "boom",
+	}}
+
+	ctx, err := s.runDestroyCommand(c, "test2", "-y", "--force")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `
+
+The following cloud resources were abandoned while destroying the model,
+and may still exist in the cloud. You should remove them manually.
+
+Kind     Id          Provider ID  Reason
+machine  0           i-0          machine removed by force before its instance was stopped
+environ  test2-uuid  test2-uuid   boom
+`[1:])
+	checkModelRemovedFromStore(c, "test1:admin/test2", s.store)
+	s.stub.CheckCallNames(c, "DestroyModel", "WatchModelTeardown", "AbandonedModelResources")
+	s.stub.CheckCall(c, 2, "AbandonedModelResources", names.NewModelTag("test2-uuid"))
+}
+
+func (s *DestroySuite) TestDestroyWithoutForceDoesNotReportAbandonedResources(c *gc.C) {
+	s.api.bestAPIVersion = 9
+	ctx, err := s.runDestroyCommand(c, "test2", "-y")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, "")
+	s.stub.CheckCallNames(c, "DestroyModel", "WatchModelTeardown")
+}
+
+func (s *DestroySuite) TestDestroyWithForceOldControllerDoesNotReportAbandonedResources(c *gc.C) {
+	_, err := s.runDestroyCommand(c, "test2", "-y", "--force")
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.CheckCallNames(c, "DestroyModel")
+}
+
 func (s *DestroySuite) TestBlockedDestroy(c *gc.C) {
 	s.stub.SetErrors(common.OperationBlockedError("TestBlockedDestroy"))
 	_, err := s.runDestroyCommand(c, "test2", "-y")
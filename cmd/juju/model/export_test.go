@@ -12,6 +12,7 @@ import (
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/state"
 )
 
 // NewConfigCommandForTest returns a configCommand with the api
@@ -244,3 +245,82 @@ func NewShowCommitCommandForTest(api ShowCommitCommandAPI, store jujuclient.Clie
 	cmd.SetClientStore(store)
 	return modelcmd.Wrap(cmd)
 }
+
+func NewOperationsLogCommandForTest(api OperationsLogAPI, clock jujuclock.Clock, store jujuclient.ClientStore) cmd.Command {
+	cmd := &OperationsLogCommand{
+		api:   api,
+		clock: clock,
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewArchiveModelCommandForTest(api ModelArchiveAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &ArchiveModelCommand{}
+	cmd.api = api
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewThawModelCommandForTest(api ModelArchiveAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &ThawModelCommand{}
+	cmd.api = api
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewSetModelBundleCommandForTest(api ModelBundleAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &SetModelBundleCommand{}
+	cmd.api = api
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewApproveModelPlanCommandForTest(api ModelBundleAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &ApproveModelPlanCommand{}
+	cmd.api = api
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewCostCommandForTest(api ModelCostAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &CostCommand{}
+	cmd.api = api
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewVerifyModelCommandForTest(
+	api ModelVerifierAPI,
+	verifyBackup func(string) ([]state.DumpedModelVerification, error),
+	store jujuclient.ClientStore,
+) cmd.Command {
+	cmd := &VerifyModelCommand{}
+	cmd.api = api
+	cmd.verifyBackup = verifyBackup
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd, modelcmd.WrapSkipModelFlags)
+}
+
+func NewSetRegistryCredentialCommandForTest(api RegistryCredentialsAPI, clock jujuclock.Clock, store jujuclient.ClientStore) cmd.Command {
+	cmd := &SetRegistryCredentialCommand{}
+	cmd.api = api
+	cmd.clock = clock
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewRegistryCredentialsCommandForTest(api RegistryCredentialsAPI, clock jujuclock.Clock, store jujuclient.ClientStore) cmd.Command {
+	cmd := &RegistryCredentialsCommand{}
+	cmd.api = api
+	cmd.clock = clock
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+func NewRemoveRegistryCredentialCommandForTest(api RegistryCredentialsAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &RemoveRegistryCredentialCommand{}
+	cmd.api = api
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
@@ -9,6 +9,7 @@ import (
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/api"
+	"github.com/juju/juju/apiserver/params"
 	jujucloud "github.com/juju/juju/cloud"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/testing"
@@ -44,6 +45,7 @@ type fakeEnvAPI struct {
 	err           error
 	keys          []string
 	resetKeys     []string
+	configKeys    []params.ConfigKey
 }
 
 func (f *fakeEnvAPI) Close() error {
@@ -72,6 +74,10 @@ func (f *fakeEnvAPI) ModelUnset(keys ...string) error {
 	return f.err
 }
 
+func (f *fakeEnvAPI) ConfigKeys() ([]params.ConfigKey, error) {
+	return f.configKeys, f.err
+}
+
 // ModelDefaults related fake environment for testing.
 
 type fakeModelDefaultEnvSuite struct {
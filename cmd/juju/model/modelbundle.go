@@ -0,0 +1,191 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"io/ioutil"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+const (
+	setModelBundleSummary = "Stores the bundle describing the desired state of a model."
+	setModelBundleDoc     = `
+Stores a new revision of the bundle which describes the desired state of
+the model in the controller.
+
+When the model's "bundle-source" config is set to "controller", the
+controller keeps the model in line with the latest revision of the bundle.
+Drift between the model and the bundle is shown in the model's status.
+When "bundle-reconcile-mode" is "manual", the default, the changes needed
+to remove the drift are only made once their plan has been approved with
+"juju approve-model-plan"; when it is "auto", they are made as soon as
+they are seen.
+
+Examples:
+    juju set-model-bundle bundle.yaml
+    juju model-config bundle-source=controller
+
+See also:
+    approve-model-plan
+    export-bundle
+    model-config
+`
+
+	approveModelPlanSummary = "Approves the changes needed to bring a model in line with its bundle."
+	approveModelPlanDoc     = `
+Approves the plan, identified by its fingerprint, which brings the model
+in line with the bundle named by the model's "bundle-source" config. The
+fingerprint of the plan awaiting approval is shown in the model's status.
+
+The plan is only applied if the model and bundle have not changed since
+it was made; otherwise a new plan is made, which must be approved in
+turn.
+
+Examples:
+    juju approve-model-plan 6f2b1c...
+
+See also:
+    set-model-bundle
+`
+)
+
+// ModelBundleAPI defines the API methods used by the set-model-bundle
+// and approve-model-plan commands.
+type ModelBundleAPI interface {
+	Close() error
+	AddModelBundle(bundleDataYAML string) (params.ModelBundle, error)
+	ApproveModelPlan(fingerprint string) error
+}
+
+// modelBundleCommandBase is embedded by the set-model-bundle and
+// approve-model-plan commands.
+type modelBundleCommandBase struct {
+	modelcmd.ModelCommandBase
+
+	api ModelBundleAPI
+}
+
+func (c *modelBundleCommandBase) getAPI() (ModelBundleAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return bundle.NewClient(root), nil
+}
+
+// SetModelBundleCommand supplies the "set-model-bundle" CLI command,
+// used to store the bundle a model is kept in line with.
+type SetModelBundleCommand struct {
+	modelBundleCommandBase
+
+	filename string
+}
+
+// NewSetModelBundleCommand returns a command to store a model's bundle.
+func NewSetModelBundleCommand() cmd.Command {
+	return modelcmd.Wrap(&SetModelBundleCommand{})
+}
+
+// Info implements part of the cmd.Command interface.
+func (c *SetModelBundleCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "set-model-bundle",
+		Args:    "<bundle file>",
+		Purpose: setModelBundleSummary,
+		Doc:     setModelBundleDoc,
+	})
+}
+
+// Init implements part of the cmd.Command interface.
+func (c *SetModelBundleCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no bundle file specified")
+	}
+	c.filename, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements part of the cmd.Command interface.
+func (c *SetModelBundleCommand) Run(ctx *cmd.Context) error {
+	modelName, _, err := c.ModelDetails()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	data, err := ioutil.ReadFile(ctx.AbsPath(c.filename))
+	if err != nil {
+		return errors.Annotate(err, "cannot read bundle")
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	stored, err := client.AddModelBundle(string(data))
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Stored revision %d of the bundle for model %q", stored.Revision, modelName)
+	return nil
+}
+
+// ApproveModelPlanCommand supplies the "approve-model-plan" CLI
+// command, used to approve the changes which bring a model in line with
+// its bundle.
+type ApproveModelPlanCommand struct {
+	modelBundleCommandBase
+
+	fingerprint string
+}
+
+// NewApproveModelPlanCommand returns a command to approve a model plan.
+func NewApproveModelPlanCommand() cmd.Command {
+	return modelcmd.Wrap(&ApproveModelPlanCommand{})
+}
+
+// Info implements part of the cmd.Command interface.
+func (c *ApproveModelPlanCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "approve-model-plan",
+		Args:    "<fingerprint>",
+		Purpose: approveModelPlanSummary,
+		Doc:     approveModelPlanDoc,
+	})
+}
+
+// Init implements part of the cmd.Command interface.
+func (c *ApproveModelPlanCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no plan fingerprint specified")
+	}
+	c.fingerprint, args = args[0], args[1:]
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements part of the cmd.Command interface.
+func (c *ApproveModelPlanCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	if err := client.ApproveModelPlan(c.fingerprint); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	ctx.Infof("Approved plan %s", c.fingerprint)
+	return nil
+}
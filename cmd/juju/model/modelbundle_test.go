@@ -0,0 +1,125 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	coretesting "github.com/juju/juju/testing"
+)
+
+const modelBundle = `
+applications:
+  ubuntu:
+    charm: cs:trusty/ubuntu-10
+    num_units: 1
+`
+
+type modelBundleSuite struct {
+	generationBaseSuite
+
+	api *fakeModelBundleAPI
+}
+
+var _ = gc.Suite(&modelBundleSuite{})
+
+func (s *modelBundleSuite) SetUpTest(c *gc.C) {
+	s.generationBaseSuite.SetUpTest(c)
+	s.api = &fakeModelBundleAPI{}
+}
+
+func (s *modelBundleSuite) writeBundle(c *gc.C) string {
+	path := filepath.Join(c.MkDir(), "bundle.yaml")
+	err := ioutil.WriteFile(path, []byte(modelBundle), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *modelBundleSuite) TestSetModelBundleInit(c *gc.C) {
+	command := model.NewSetModelBundleCommandForTest(s.api, s.store)
+	err := cmdtesting.InitCommand(command, nil)
+	c.Assert(err, gc.ErrorMatches, "no bundle file specified")
+
+	command = model.NewSetModelBundleCommandForTest(s.api, s.store)
+	err = cmdtesting.InitCommand(command, []string{"bundle.yaml", "foo"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+}
+
+func (s *modelBundleSuite) TestSetModelBundle(c *gc.C) {
+	command := model.NewSetModelBundleCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, s.writeBundle(c))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Stored revision 3 of the bundle for model \"admin/mymodel\"\n")
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"AddModelBundle", []interface{}{modelBundle}},
+		{"Close", nil},
+	})
+}
+
+func (s *modelBundleSuite) TestSetModelBundleMissingFile(c *gc.C) {
+	command := model.NewSetModelBundleCommandForTest(s.api, s.store)
+	_, err := cmdtesting.RunCommand(c, command, filepath.Join(c.MkDir(), "missing.yaml"))
+	c.Assert(err, gc.ErrorMatches, "cannot read bundle: .*")
+	s.api.CheckNoCalls(c)
+}
+
+func (s *modelBundleSuite) TestSetModelBundleBlocked(c *gc.C) {
+	s.api.SetErrors(common.OperationBlockedError("TestSetModelBundleBlocked"))
+	command := model.NewSetModelBundleCommandForTest(s.api, s.store)
+	_, err := cmdtesting.RunCommand(c, command, s.writeBundle(c))
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestSetModelBundleBlocked.*")
+}
+
+func (s *modelBundleSuite) TestApproveModelPlanInit(c *gc.C) {
+	command := model.NewApproveModelPlanCommandForTest(s.api, s.store)
+	err := cmdtesting.InitCommand(command, nil)
+	c.Assert(err, gc.ErrorMatches, "no plan fingerprint specified")
+}
+
+func (s *modelBundleSuite) TestApproveModelPlan(c *gc.C) {
+	command := model.NewApproveModelPlanCommandForTest(s.api, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "abc123")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "Approved plan abc123\n")
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"ApproveModelPlan", []interface{}{"abc123"}},
+		{"Close", nil},
+	})
+}
+
+func (s *modelBundleSuite) TestApproveModelPlanError(c *gc.C) {
+	s.api.SetErrors(errors.New("permission denied"))
+	command := model.NewApproveModelPlanCommandForTest(s.api, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "abc123")
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type fakeModelBundleAPI struct {
+	testing.Stub
+}
+
+func (f *fakeModelBundleAPI) AddModelBundle(bundleDataYAML string) (params.ModelBundle, error) {
+	f.MethodCall(f, "AddModelBundle", bundleDataYAML)
+	return params.ModelBundle{Revision: 3, Bundle: bundleDataYAML}, f.NextErr()
+}
+
+func (f *fakeModelBundleAPI) ApproveModelPlan(fingerprint string) error {
+	f.MethodCall(f, "ApproveModelPlan", fingerprint)
+	return f.NextErr()
+}
+
+func (f *fakeModelBundleAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
@@ -0,0 +1,240 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gosuri/uitable"
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/operationslog"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/juju/osenv"
+)
+
+const (
+	operationsLogSummary = "Lists the changes made to a model over a period of time."
+	operationsLogDoc     = `
+Shows the changes made to the model over a period of time, oldest first.
+The log records actions being run, model upgrades, model migrations and
+branch commits, along with who made them where that is known.
+
+The --since option takes either a period of time before now, such as 30m,
+12h or 7d, or an RFC3339 timestamp. It defaults to the last day.
+
+The --type option limits the output to changes of the given types: action,
+upgrade, migration and branch-commit. Multiple types are separated by commas.
+
+Changes are kept for the period set by the max-operations-age model
+config setting.
+
+Examples:
+    juju operations-log
+    juju operations-log --since 7d --type upgrade
+    juju operations-log --since 2020-01-02T15:04:05Z --type action,branch-commit
+
+See also:
+    commits
+    show-action-status
+`
+)
+
+// OperationsLogAPI defines the API methods used by the operations-log
+// command.
+type OperationsLogAPI interface {
+	Close() error
+	Operations(since time.Time, kinds []string) ([]params.OperationsLogEntry, error)
+}
+
+// OperationsLogCommand supplies the "operations-log" CLI command, used
+// to list the changes made to a model over a period of time.
+type OperationsLogCommand struct {
+	modelcmd.ModelCommandBase
+
+	api   OperationsLogAPI
+	clock clock.Clock
+	out   cmd.Output
+
+	sinceArg string
+	typeArg  string
+	isoTime  bool
+
+	since time.Time
+	kinds []string
+}
+
+// NewOperationsLogCommand returns a command to list the changes made to
+// a model over a period of time.
+func NewOperationsLogCommand() cmd.Command {
+	return modelcmd.Wrap(&OperationsLogCommand{clock: clock.WallClock})
+}
+
+// Info implements part of the cmd.Command interface.
+func (c *OperationsLogCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "operations-log",
+		Purpose: operationsLogSummary,
+		Doc:     operationsLogDoc,
+	})
+}
+
+// SetFlags implements part of the cmd.Command interface.
+func (c *OperationsLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.sinceArg, "since", "1d", "Show changes made since this period ago (eg 12h, 7d) or RFC3339 time")
+	f.StringVar(&c.typeArg, "type", "", "Comma separated list of the types of change to show")
+	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": c.printTabular,
+	})
+}
+
+// Init implements part of the cmd.Command interface.
+func (c *OperationsLogCommand) Init(args []string) error {
+	if len(args) > 0 {
+		return errors.Errorf("expected no arguments, but got %v", len(args))
+	}
+	since, err := parseSince(c.sinceArg, c.clock.Now())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	c.since = since
+	c.kinds = nil
+	for _, kind := range strings.Split(c.typeArg, ",") {
+		if kind = strings.TrimSpace(kind); kind != "" {
+			c.kinds = append(c.kinds, kind)
+		}
+	}
+
+	// If use of ISO time not specified on command line, check env var.
+	if !c.isoTime {
+		envVarValue := os.Getenv(osenv.JujuStatusIsoTimeEnvKey)
+		if envVarValue != "" {
+			if c.isoTime, err = strconv.ParseBool(envVarValue); err != nil {
+				return errors.Annotatef(err, "invalid %s env var, expected true|false", osenv.JujuStatusIsoTimeEnvKey)
+			}
+		}
+	}
+	return nil
+}
+
+// parseSince parses the value of the --since option, which is either a
+// period before now, where a "d" suffix gives a number of days, or an
+// RFC3339 timestamp.
+func parseSince(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	var period time.Duration
+	if days := strings.TrimSuffix(value, "d"); days != value {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return time.Time{}, errors.NotValidf("--since value %q", value)
+		}
+		period = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if period, err = time.ParseDuration(value); err != nil {
+			return time.Time{}, errors.NotValidf("--since value %q", value)
+		}
+	}
+	if period <= 0 {
+		return time.Time{}, errors.Errorf("--since period %q must be positive", value)
+	}
+	return now.Add(-period), nil
+}
+
+func (c *OperationsLogCommand) getAPI() (OperationsLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return operationslog.NewClient(root), nil
+}
+
+// Run implements part of the cmd.Command interface.
+func (c *OperationsLogCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	operations, err := client.Operations(c.since, c.kinds)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(operations) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No operations to list")
+		return nil
+	}
+	return errors.Trace(c.out.Write(ctx, c.formatOperations(operations)))
+}
+
+func (c *OperationsLogCommand) formatOperations(operations []params.OperationsLogEntry) formattedOperationsLog {
+	result := formattedOperationsLog{
+		Operations: make([]formattedOperation, len(operations)),
+	}
+	for i, op := range operations {
+		when := op.Time
+		result.Operations[i] = formattedOperation{
+			Id:      op.Id,
+			Type:    op.Kind,
+			Summary: op.Summary,
+			Who:     op.Who,
+			Ref:     op.Ref,
+			Time:    common.FormatTime(&when, c.isoTime),
+		}
+	}
+	return result
+}
+
+// printTabular prints the list of operations in tabular format.
+func (c *OperationsLogCommand) printTabular(writer io.Writer, value interface{}) error {
+	list, ok := value.(formattedOperationsLog)
+	if !ok {
+		return errors.New("unexpected value")
+	}
+
+	table := uitable.New()
+	table.MaxColWidth = 80
+	table.Wrap = true
+
+	table.AddRow("Id", "Time", "Type", "Who", "Summary")
+	for _, op := range list.Operations {
+		table.AddRow(op.Id, op.Time, op.Type, op.Who, op.Summary)
+	}
+	_, _ = fmt.Fprint(writer, table)
+	return nil
+}
+
+type formattedOperation struct {
+	Id      string `json:"id" yaml:"id"`
+	Type    string `json:"type" yaml:"type"`
+	Summary string `json:"summary" yaml:"summary"`
+	Who     string `json:"who,omitempty" yaml:"who,omitempty"`
+	Ref     string `json:"ref,omitempty" yaml:"ref,omitempty"`
+	Time    string `json:"time" yaml:"time"`
+}
+
+type formattedOperationsLog struct {
+	Operations []formattedOperation `json:"operations" yaml:"operations"`
+}
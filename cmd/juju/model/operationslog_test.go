@@ -0,0 +1,149 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+)
+
+type operationsLogSuite struct {
+	generationBaseSuite
+
+	api   *fakeOperationsLogAPI
+	clock *testclock.Clock
+	now   time.Time
+}
+
+var _ = gc.Suite(&operationsLogSuite{})
+
+func (s *operationsLogSuite) SetUpTest(c *gc.C) {
+	s.generationBaseSuite.SetUpTest(c)
+	s.now = time.Date(2020, 1, 3, 14, 0, 0, 0, time.UTC)
+	s.clock = testclock.NewClock(s.now)
+	s.api = &fakeOperationsLogAPI{
+		operations: []params.OperationsLogEntry{{
+			Id:      "1",
+			Kind:    "upgrade",
+			Summary: "upgrade model from 2.7.0 to 2.7.1",
+			Time:    s.now.Add(-2 * time.Hour),
+		}, {
+			Id:      "2",
+			Kind:    "branch-commit",
+			Summary: `commit branch "test"`,
+			Who:     "mary",
+			Ref:     "3",
+			Time:    s.now.Add(-time.Hour),
+		}},
+	}
+}
+
+func (s *operationsLogSuite) runCommand(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := model.NewOperationsLogCommandForTest(s.api, s.clock, s.store)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *operationsLogSuite) TestInitArgs(c *gc.C) {
+	command := model.NewOperationsLogCommandForTest(s.api, s.clock, s.store)
+	err := cmdtesting.InitCommand(command, []string{"foo"})
+	c.Assert(err, gc.ErrorMatches, "expected no arguments, but got 1")
+}
+
+func (s *operationsLogSuite) TestInitInvalidSince(c *gc.C) {
+	for _, since := range []string{"yesterday", "xd", "-1h", "0d"} {
+		command := model.NewOperationsLogCommandForTest(s.api, s.clock, s.store)
+		err := cmdtesting.InitCommand(command, []string{"--since", since})
+		c.Check(err, gc.NotNil, gc.Commentf("--since %s", since))
+	}
+}
+
+func (s *operationsLogSuite) TestRunDefaults(c *gc.C) {
+	_, err := s.runCommand(c)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "Operations", s.now.Add(-24*time.Hour), []string(nil))
+	s.api.CheckCall(c, 1, "Close")
+}
+
+func (s *operationsLogSuite) TestRunSinceDays(c *gc.C) {
+	_, err := s.runCommand(c, "--since", "7d", "--type", "upgrade, branch-commit")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCall(c, 0, "Operations", s.now.Add(-7*24*time.Hour), []string{"upgrade", "branch-commit"})
+}
+
+func (s *operationsLogSuite) TestRunSinceTimestamp(c *gc.C) {
+	_, err := s.runCommand(c, "--since", "2020-01-02T15:04:05Z")
+	c.Assert(err, jc.ErrorIsNil)
+	since := time.Date(2020, 1, 2, 15, 4, 5, 0, time.UTC)
+	s.api.CheckCall(c, 0, "Operations", since, []string(nil))
+}
+
+func (s *operationsLogSuite) TestRunTabular(c *gc.C) {
+	ctx, err := s.runCommand(c, "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Id	Time                	Type         	Who 	Summary                          
+1 	2020-01-03 12:00:00Z	upgrade      	    	upgrade model from 2.7.0 to 2.7.1
+2 	2020-01-03 13:00:00Z	branch-commit	mary	commit branch "test"             
+`[1:])
+}
+
+func (s *operationsLogSuite) TestRunYAML(c *gc.C) {
+	ctx, err := s.runCommand(c, "--utc", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+operations:
+- id: "1"
+  type: upgrade
+  summary: upgrade model from 2.7.0 to 2.7.1
+  time: 2020-01-03 12:00:00Z
+- id: "2"
+  type: branch-commit
+  summary: commit branch "test"
+  who: mary
+  ref: "3"
+  time: 2020-01-03 13:00:00Z
+`[1:])
+}
+
+func (s *operationsLogSuite) TestRunNoOperations(c *gc.C) {
+	s.api.operations = nil
+	ctx, err := s.runCommand(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No operations to list\n")
+}
+
+func (s *operationsLogSuite) TestRunError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := s.runCommand(c)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeOperationsLogAPI struct {
+	testing.Stub
+	operations []params.OperationsLogEntry
+}
+
+func (f *fakeOperationsLogAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeOperationsLogAPI) Operations(since time.Time, kinds []string) ([]params.OperationsLogEntry, error) {
+	f.MethodCall(f, "Operations", since, kinds)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.operations, nil
+}
@@ -0,0 +1,434 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"golang.org/x/crypto/ssh/terminal"
+
+	"github.com/juju/juju/api/modelconfig"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// registryCredentialExpiryWarning is how long before a registry
+// credential expires that the commands start warning about it.
+const registryCredentialExpiryWarning = 7 * 24 * time.Hour
+
+const (
+	setRegistryCredentialSummary = "Sets the credential used to pull images from a registry."
+	setRegistryCredentialDoc     = `
+Sets the username and password the model uses to pull OCI images from a
+private registry, such as the images of Kubernetes charms and their
+image resources. The registry is named as it appears in image paths,
+with "docker.io" for Docker Hub.
+
+The password, which is often an access token, is read from standard
+input, or from the file given with --password-file. Setting the
+credential for a registry again replaces it, which is how credentials
+are rotated; applications pick up the new credential without being
+redeployed.
+
+Use --expires, with a time or a duration from now, to record when the
+password stops being accepted by the registry. Expired credentials are
+no longer used, and "juju registry-credentials" warns about credentials
+which are about to expire.
+
+Examples:
+    aws ecr get-login-password | juju set-registry-credential \
+        123456789012.dkr.ecr.us-east-1.amazonaws.com AWS --expires 12h
+    juju set-registry-credential registry.example.com:5000 bob \
+        --password-file ./token --expires 2020-12-31T00:00:00Z
+
+See also:
+    registry-credentials
+    remove-registry-credential
+`
+
+	registryCredentialsSummary = "Lists the credentials used to pull images from registries."
+	registryCredentialsDoc     = `
+Lists the registries the model has credentials for, with the username
+of each credential and when it expires. Passwords are not shown.
+
+Examples:
+    juju registry-credentials
+    juju registry-credentials --format yaml
+
+See also:
+    set-registry-credential
+    remove-registry-credential
+`
+
+	removeRegistryCredentialSummary = "Removes the credentials used to pull images from registries."
+	removeRegistryCredentialDoc     = `
+Removes the model's credentials for the given registries. Images in the
+registries are pulled without credentials once pods are next created.
+
+Examples:
+    juju remove-registry-credential registry.example.com:5000
+
+See also:
+    set-registry-credential
+    registry-credentials
+`
+)
+
+// RegistryCredentialsAPI defines the API methods used by the registry
+// credential commands.
+type RegistryCredentialsAPI interface {
+	Close() error
+	RegistryCredentials() ([]params.RegistryCredential, error)
+	SetRegistryCredential(params.RegistryCredential) error
+	RemoveRegistryCredentials(registries ...string) error
+}
+
+// registryCredentialsCommandBase holds what is common to the registry
+// credential commands.
+type registryCredentialsCommandBase struct {
+	modelcmd.ModelCommandBase
+
+	api   RegistryCredentialsAPI
+	clock clock.Clock
+}
+
+func (c *registryCredentialsCommandBase) getAPI() (RegistryCredentialsAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return modelconfig.NewClient(root), nil
+}
+
+// expiryWarning returns a warning about the credential for the given
+// registry having expired or expiring soon, or "" if it hasn't.
+func (c *registryCredentialsCommandBase) expiryWarning(registry string, expiry *time.Time) string {
+	if expiry == nil {
+		return ""
+	}
+	remaining := expiry.Sub(c.clock.Now())
+	switch {
+	case remaining <= 0:
+		return "credential for registry " + registry + " has expired"
+	case remaining < registryCredentialExpiryWarning:
+		return "credential for registry " + registry + " expires in " + remaining.Round(time.Minute).String()
+	}
+	return ""
+}
+
+// SetRegistryCredentialCommand supplies the "set-registry-credential"
+// CLI command, used to add or rotate a registry credential.
+type SetRegistryCredentialCommand struct {
+	registryCredentialsCommandBase
+
+	registry     string
+	username     string
+	passwordFile string
+	expires      string
+	expiry       *time.Time
+}
+
+// NewSetRegistryCredentialCommand returns a command to add or rotate a
+// registry credential.
+func NewSetRegistryCredentialCommand() cmd.Command {
+	cmd := &SetRegistryCredentialCommand{}
+	cmd.clock = clock.WallClock
+	return modelcmd.Wrap(cmd)
+}
+
+// Info implements part of the cmd.Command interface.
+func (c *SetRegistryCredentialCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "set-registry-credential",
+		Args:    "<registry> <username>",
+		Purpose: setRegistryCredentialSummary,
+		Doc:     setRegistryCredentialDoc,
+	})
+}
+
+// SetFlags implements part of the cmd.Command interface.
+func (c *SetRegistryCredentialCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.passwordFile, "password-file", "", "Read the password from this file instead of standard input")
+	f.StringVar(&c.expires, "expires", "", "When the password expires, as a time or a duration from now")
+}
+
+// Init implements part of the cmd.Command interface.
+func (c *SetRegistryCredentialCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no registry specified")
+	case 1:
+		return errors.New("no username specified")
+	}
+	c.registry, c.username = args[0], args[1]
+	if c.expires != "" {
+		expiry, err := parseExpiry(c.expires, c.clock.Now())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		c.expiry = &expiry
+	}
+	return cmd.CheckEmpty(args[2:])
+}
+
+// parseExpiry parses an RFC3339 time, or a duration from now.
+func parseExpiry(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		if d <= 0 {
+			return time.Time{}, errors.Errorf("expiry %q is in the past", value)
+		}
+		return now.Add(d).UTC().Round(time.Second), nil
+	}
+	expiry, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.Errorf("invalid expiry %q, expected a time like 2020-12-31T00:00:00Z or a duration like 12h", value)
+	}
+	if !expiry.After(now) {
+		return time.Time{}, errors.Errorf("expiry %q is in the past", value)
+	}
+	return expiry.UTC(), nil
+}
+
+// Run implements part of the cmd.Command interface.
+func (c *SetRegistryCredentialCommand) Run(ctx *cmd.Context) error {
+	password, err := c.readPassword(ctx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	err = client.SetRegistryCredential(params.RegistryCredential{
+		Registry: c.registry,
+		Username: c.username,
+		Password: password,
+		Expiry:   c.expiry,
+	})
+	if err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if warning := c.expiryWarning(c.registry, c.expiry); warning != "" {
+		ctx.Warningf("%s", warning)
+	}
+	return nil
+}
+
+func (c *SetRegistryCredentialCommand) readPassword(ctx *cmd.Context) (string, error) {
+	var data []byte
+	if c.passwordFile != "" {
+		var err error
+		if data, err = ioutil.ReadFile(ctx.AbsPath(c.passwordFile)); err != nil {
+			return "", errors.Annotate(err, "reading password file")
+		}
+	} else if f, ok := ctx.Stdin.(*os.File); ok && terminal.IsTerminal(int(f.Fd())) {
+		_, _ = io.WriteString(ctx.Stderr, "password: ")
+		password, err := terminal.ReadPassword(int(f.Fd()))
+		_, _ = io.WriteString(ctx.Stderr, "\n")
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		data = password
+	} else {
+		var err error
+		if data, err = ioutil.ReadAll(ctx.Stdin); err != nil {
+			return "", errors.Annotate(err, "reading password")
+		}
+	}
+	password := strings.TrimSpace(string(data))
+	if password == "" {
+		return "", errors.New("no password specified")
+	}
+	return password, nil
+}
+
+// RegistryCredentialsCommand supplies the "registry-credentials" CLI
+// command, used to list the registry credentials of a model.
+type RegistryCredentialsCommand struct {
+	registryCredentialsCommandBase
+
+	out cmd.Output
+}
+
+// NewRegistryCredentialsCommand returns a command to list the registry
+// credentials of a model.
+func NewRegistryCredentialsCommand() cmd.Command {
+	cmd := &RegistryCredentialsCommand{}
+	cmd.clock = clock.WallClock
+	return modelcmd.Wrap(cmd)
+}
+
+// Info implements part of the cmd.Command interface.
+func (c *RegistryCredentialsCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "registry-credentials",
+		Purpose: registryCredentialsSummary,
+		Doc:     registryCredentialsDoc,
+		Aliases: []string{"list-registry-credentials"},
+	})
+}
+
+// SetFlags implements part of the cmd.Command interface.
+func (c *RegistryCredentialsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatRegistryCredentialsTabular,
+	})
+}
+
+// Init implements part of the cmd.Command interface.
+func (c *RegistryCredentialsCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements part of the cmd.Command interface.
+func (c *RegistryCredentialsCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	creds, err := client.RegistryCredentials()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(creds) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No registry credentials to display.")
+		return nil
+	}
+	result := make(map[string]formattedRegistryCredential)
+	for _, cred := range creds {
+		status := "active"
+		if warning := c.expiryWarning(cred.Registry, cred.Expiry); warning != "" {
+			ctx.Warningf("%s", warning)
+			status = "expiring"
+			if !cred.Expiry.After(c.clock.Now()) {
+				status = "expired"
+			}
+		}
+		result[cred.Registry] = formattedRegistryCredential{
+			Username:  cred.Username,
+			Expiry:    cred.Expiry,
+			Status:    status,
+			UpdatedBy: cred.UpdatedBy,
+			Updated:   cred.Updated,
+		}
+	}
+	return errors.Trace(c.out.Write(ctx, result))
+}
+
+type formattedRegistryCredential struct {
+	Username  string     `yaml:"username" json:"username"`
+	Expiry    *time.Time `yaml:"expiry,omitempty" json:"expiry,omitempty"`
+	Status    string     `yaml:"status" json:"status"`
+	UpdatedBy string     `yaml:"updated-by,omitempty" json:"updated-by,omitempty"`
+	Updated   *time.Time `yaml:"updated,omitempty" json:"updated,omitempty"`
+}
+
+// formatRegistryCredentialsTabular prints the registry credentials,
+// ordered by registry.
+func formatRegistryCredentialsTabular(writer io.Writer, value interface{}) error {
+	creds, ok := value.(map[string]formattedRegistryCredential)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", creds, value)
+	}
+	registries := make([]string, 0, len(creds))
+	for registry := range creds {
+		registries = append(registries, registry)
+	}
+	sort.Strings(registries)
+
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Registry", "Username", "Expires", "Status", "Updated by")
+	for _, registry := range registries {
+		cred := creds[registry]
+		expires := "never"
+		if cred.Expiry != nil {
+			expires = common.FormatTime(cred.Expiry, true)
+		}
+		w.Print(registry, cred.Username, expires)
+		switch cred.Status {
+		case "expired":
+			w.PrintColor(output.ErrorHighlight, cred.Status)
+		case "expiring":
+			w.PrintColor(output.WarningHighlight, cred.Status)
+		default:
+			w.Print(cred.Status)
+		}
+		w.Println(cred.UpdatedBy)
+	}
+	tw.Flush()
+	return nil
+}
+
+// RemoveRegistryCredentialCommand supplies the
+// "remove-registry-credential" CLI command, used to remove registry
+// credentials from a model.
+type RemoveRegistryCredentialCommand struct {
+	registryCredentialsCommandBase
+
+	registries []string
+}
+
+// NewRemoveRegistryCredentialCommand returns a command to remove
+// registry credentials from a model.
+func NewRemoveRegistryCredentialCommand() cmd.Command {
+	cmd := &RemoveRegistryCredentialCommand{}
+	cmd.clock = clock.WallClock
+	return modelcmd.Wrap(cmd)
+}
+
+// Info implements part of the cmd.Command interface.
+func (c *RemoveRegistryCredentialCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "remove-registry-credential",
+		Args:    "<registry> [<registry> ...]",
+		Purpose: removeRegistryCredentialSummary,
+		Doc:     removeRegistryCredentialDoc,
+	})
+}
+
+// Init implements part of the cmd.Command interface.
+func (c *RemoveRegistryCredentialCommand) Init(args []string) error {
+	if len(args) == 0 {
+		return errors.New("no registry specified")
+	}
+	c.registries = args
+	return nil
+}
+
+// Run implements part of the cmd.Command interface.
+func (c *RemoveRegistryCredentialCommand) Run(ctx *cmd.Context) error {
+	client, err := c.getAPI()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = client.Close() }()
+
+	err = client.RemoveRegistryCredentials(c.registries...)
+	return block.ProcessBlockedError(err, block.BlockRemove)
+}
@@ -0,0 +1,250 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type registryCredentialsSuite struct {
+	generationBaseSuite
+
+	api   *fakeRegistryCredentialsAPI
+	clock *testclock.Clock
+}
+
+var _ = gc.Suite(&registryCredentialsSuite{})
+
+func (s *registryCredentialsSuite) SetUpTest(c *gc.C) {
+	s.generationBaseSuite.SetUpTest(c)
+	s.api = &fakeRegistryCredentialsAPI{}
+	s.clock = testclock.NewClock(time.Date(2020, 4, 1, 12, 0, 0, 0, time.UTC))
+}
+
+func (s *registryCredentialsSuite) runSet(c *gc.C, stdin string, args ...string) (string, error) {
+	command := model.NewSetRegistryCredentialCommandForTest(s.api, s.clock, s.store)
+	if err := cmdtesting.InitCommand(command, args); err != nil {
+		return "", err
+	}
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader(stdin)
+	err := command.Run(ctx)
+	return cmdtesting.Stderr(ctx), err
+}
+
+func (s *registryCredentialsSuite) TestSetInit(c *gc.C) {
+	for _, t := range []struct {
+		args   []string
+		expect string
+	}{{
+		expect: "no registry specified",
+	}, {
+		args:   []string{"docker.io"},
+		expect: "no username specified",
+	}, {
+		args:   []string{"docker.io", "bob", "extra"},
+		expect: `unrecognized args: \["extra"\]`,
+	}, {
+		args:   []string{"docker.io", "bob", "--expires", "tomorrow"},
+		expect: `invalid expiry "tomorrow", expected a time like 2020-12-31T00:00:00Z or a duration like 12h`,
+	}, {
+		args:   []string{"docker.io", "bob", "--expires", "2020-03-01T00:00:00Z"},
+		expect: `expiry "2020-03-01T00:00:00Z" is in the past`,
+	}, {
+		args:   []string{"docker.io", "bob", "--expires", "-1h"},
+		expect: `expiry "-1h" is in the past`,
+	}} {
+		command := model.NewSetRegistryCredentialCommandForTest(s.api, s.clock, s.store)
+		err := cmdtesting.InitCommand(command, t.args)
+		c.Check(err, gc.ErrorMatches, t.expect)
+	}
+}
+
+func (s *registryCredentialsSuite) TestSetFromStdin(c *gc.C) {
+	stderr, err := s.runSet(c, "s3cret\n", "docker.io", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stderr, gc.Equals, "")
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"SetRegistryCredential", []interface{}{params.RegistryCredential{
+			Registry: "docker.io",
+			Username: "bob",
+			Password: "s3cret",
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *registryCredentialsSuite) TestSetFromFile(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "token")
+	err := ioutil.WriteFile(path, []byte("t0ken\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.runSet(c, "", "registry.example.com:5000", "bob", "--password-file", path, "--expires", "2020-06-01T00:00:00Z")
+	c.Assert(err, jc.ErrorIsNil)
+	expiry := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	s.api.CheckCall(c, 0, "SetRegistryCredential", params.RegistryCredential{
+		Registry: "registry.example.com:5000",
+		Username: "bob",
+		Password: "t0ken",
+		Expiry:   &expiry,
+	})
+}
+
+func (s *registryCredentialsSuite) TestSetExpiringSoon(c *gc.C) {
+	stderr, err := s.runSet(c, "s3cret", "docker.io", "bob", "--expires", "12h")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stderr, gc.Matches, "(?s).*credential for registry docker.io expires in 12h0m0s.*")
+	expiry := s.clock.Now().Add(12 * time.Hour)
+	s.api.CheckCall(c, 0, "SetRegistryCredential", params.RegistryCredential{
+		Registry: "docker.io",
+		Username: "bob",
+		Password: "s3cret",
+		Expiry:   &expiry,
+	})
+}
+
+func (s *registryCredentialsSuite) TestSetNoPassword(c *gc.C) {
+	_, err := s.runSet(c, "\n", "docker.io", "bob")
+	c.Assert(err, gc.ErrorMatches, "no password specified")
+	s.api.CheckNoCalls(c)
+}
+
+func (s *registryCredentialsSuite) TestSetBlocked(c *gc.C) {
+	s.api.SetErrors(common.OperationBlockedError("TestSetBlocked"))
+	_, err := s.runSet(c, "s3cret", "docker.io", "bob")
+	coretesting.AssertOperationWasBlocked(c, err, ".*TestSetBlocked.*")
+}
+
+func (s *registryCredentialsSuite) setCredentials() {
+	updated := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	expired := time.Date(2020, 3, 31, 0, 0, 0, 0, time.UTC)
+	expiring := time.Date(2020, 4, 3, 0, 0, 0, 0, time.UTC)
+	s.api.creds = []params.RegistryCredential{{
+		Registry:  "docker.io",
+		Username:  "bob",
+		UpdatedBy: "admin",
+		Updated:   &updated,
+	}, {
+		Registry:  "quay.io",
+		Username:  "mary",
+		Expiry:    &expiring,
+		UpdatedBy: "admin",
+		Updated:   &updated,
+	}, {
+		Registry:  "registry.example.com:5000",
+		Username:  "robot",
+		Expiry:    &expired,
+		UpdatedBy: "mary",
+		Updated:   &updated,
+	}}
+}
+
+func (s *registryCredentialsSuite) TestListTabular(c *gc.C) {
+	s.setCredentials()
+	command := model.NewRegistryCredentialsCommandForTest(s.api, s.clock, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Registry                   Username  Expires               Status    Updated by
+docker.io                  bob       never                 active    admin
+quay.io                    mary      2020-04-03 00:00:00Z  expiring  admin
+registry.example.com:5000  robot     2020-03-31 00:00:00Z  expired   mary
+`[1:])
+	stderr := cmdtesting.Stderr(ctx)
+	c.Assert(stderr, gc.Matches, "(?s).*credential for registry quay.io expires in 36h0m0s.*")
+	c.Assert(stderr, gc.Matches, "(?s).*credential for registry registry.example.com:5000 has expired.*")
+	s.api.CheckCallNames(c, "RegistryCredentials", "Close")
+}
+
+func (s *registryCredentialsSuite) TestListYAML(c *gc.C) {
+	s.setCredentials()
+	s.api.creds = s.api.creds[:2]
+	command := model.NewRegistryCredentialsCommandForTest(s.api, s.clock, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+docker.io:
+  username: bob
+  status: active
+  updated-by: admin
+  updated: 2020-03-01T00:00:00Z
+quay.io:
+  username: mary
+  expiry: 2020-04-03T00:00:00Z
+  status: expiring
+  updated-by: admin
+  updated: 2020-03-01T00:00:00Z
+`[1:])
+}
+
+func (s *registryCredentialsSuite) TestListNone(c *gc.C) {
+	command := model.NewRegistryCredentialsCommandForTest(s.api, s.clock, s.store)
+	ctx, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No registry credentials to display.\n")
+}
+
+func (s *registryCredentialsSuite) TestRemove(c *gc.C) {
+	command := model.NewRemoveRegistryCredentialCommandForTest(s.api, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "docker.io", "quay.io")
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"RemoveRegistryCredentials", []interface{}{[]string{"docker.io", "quay.io"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *registryCredentialsSuite) TestRemoveNoRegistry(c *gc.C) {
+	command := model.NewRemoveRegistryCredentialCommandForTest(s.api, s.store)
+	_, err := cmdtesting.RunCommand(c, command)
+	c.Assert(err, gc.ErrorMatches, "no registry specified")
+}
+
+func (s *registryCredentialsSuite) TestRemoveError(c *gc.C) {
+	s.api.SetErrors(errors.New(`credential for registry "quay.io" not found`))
+	command := model.NewRemoveRegistryCredentialCommandForTest(s.api, s.store)
+	_, err := cmdtesting.RunCommand(c, command, "quay.io")
+	c.Assert(err, gc.ErrorMatches, `credential for registry "quay.io" not found`)
+}
+
+type fakeRegistryCredentialsAPI struct {
+	testing.Stub
+	creds []params.RegistryCredential
+}
+
+func (f *fakeRegistryCredentialsAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeRegistryCredentialsAPI) RegistryCredentials() ([]params.RegistryCredential, error) {
+	f.MethodCall(f, "RegistryCredentials")
+	return f.creds, f.NextErr()
+}
+
+func (f *fakeRegistryCredentialsAPI) SetRegistryCredential(cred params.RegistryCredential) error {
+	f.MethodCall(f, "SetRegistryCredential", cred)
+	return f.NextErr()
+}
+
+func (f *fakeRegistryCredentialsAPI) RemoveRegistryCredentials(registries ...string) error {
+	f.MethodCall(f, "RemoveRegistryCredentials", registries)
+	return f.NextErr()
+}
@@ -0,0 +1,254 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model
+
+import (
+	"io"
+	"os"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/modelverifier"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+const (
+	verifyModelSummary = "Checks the consistency of a model's records."
+	verifyModelDoc     = `
+Checks that the records the controller keeps of a model's machines,
+units, applications, relations and statuses refer to each other
+correctly, and reports those which don't, with a suggested repair for
+each. This finds, for example, units assigned to machines which don't
+exist, statuses left behind by removed units, and unit counts which
+don't match the units found. Nothing is changed.
+
+Without --backup, the current or specified model is checked by its
+controller. Only model admins may check a model.
+
+With --backup, every model in a backup archive downloaded with
+"juju download-backup" is checked, without a controller.
+
+The command exits with an error status if any inconsistencies are
+found.
+
+Examples:
+    juju verify-model
+    juju verify-model mymodel --format yaml
+    juju verify-model --backup juju-backup-20200601-120000.tar.gz
+
+See also:
+    create-backup
+    download-backup
+`
+)
+
+// ModelVerifierAPI defines the API methods used by the verify-model
+// command.
+type ModelVerifierAPI interface {
+	Close() error
+	VerifyModel() ([]params.ModelInconsistency, error)
+}
+
+// VerifyModelCommand supplies the "verify-model" CLI command, used to
+// check the consistency of a model's records.
+type VerifyModelCommand struct {
+	modelcmd.ModelCommandBase
+
+	api          ModelVerifierAPI
+	verifyBackup func(path string) ([]state.DumpedModelVerification, error)
+	out          cmd.Output
+
+	backupFile string
+}
+
+// NewVerifyModelCommand returns a command to check the consistency of
+// a model's records.
+func NewVerifyModelCommand() cmd.Command {
+	return modelcmd.Wrap(&VerifyModelCommand{
+		verifyBackup: verifyBackupArchive,
+	}, modelcmd.WrapSkipModelFlags)
+}
+
+// Info implements part of the cmd.Command interface.
+func (c *VerifyModelCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "verify-model",
+		Args:    "[<model name>]",
+		Purpose: verifyModelSummary,
+		Doc:     verifyModelDoc,
+	})
+}
+
+// SetFlags implements part of the cmd.Command interface.
+func (c *VerifyModelCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.StringVar(&c.backupFile, "backup", "", "Check the models in a backup archive instead")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatVerifiedModelsTabular,
+	})
+}
+
+// Init implements part of the cmd.Command interface.
+func (c *VerifyModelCommand) Init(args []string) error {
+	if c.backupFile != "" {
+		// The models in the backup are checked; no
+		// controller is needed.
+		return cmd.CheckEmpty(args)
+	}
+	modelName := ""
+	if len(args) > 0 {
+		modelName = args[0]
+		args = args[1:]
+	}
+	if err := c.SetModelIdentifier(modelName, true); err != nil {
+		return errors.Trace(err)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func (c *VerifyModelCommand) getAPI() (ModelVerifierAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Annotate(err, "opening API connection")
+	}
+	return modelverifier.NewClient(root), nil
+}
+
+// Run implements part of the cmd.Command interface.
+func (c *VerifyModelCommand) Run(ctx *cmd.Context) error {
+	var models []verifiedModel
+	var err error
+	if c.backupFile != "" {
+		models, err = c.verifyBackupModels()
+	} else {
+		models, err = c.verifyModel()
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	var found bool
+	for _, m := range models {
+		found = found || len(m.Inconsistencies) > 0
+	}
+	if !found && c.out.Name() == "tabular" {
+		ctx.Infof("No inconsistencies found.")
+		return nil
+	}
+	if err := c.out.Write(ctx, models); err != nil {
+		return errors.Trace(err)
+	}
+	if found {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+func (c *VerifyModelCommand) verifyModel() ([]verifiedModel, error) {
+	modelName, _, err := c.ModelDetails()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client, err := c.getAPI()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = client.Close() }()
+
+	inconsistencies, err := client.VerifyModel()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	verified := verifiedModel{
+		Model:           modelName,
+		Inconsistencies: make([]formattedInconsistency, len(inconsistencies)),
+	}
+	for i, inc := range inconsistencies {
+		verified.Inconsistencies[i] = formattedInconsistency(inc)
+	}
+	return []verifiedModel{verified}, nil
+}
+
+func (c *VerifyModelCommand) verifyBackupModels() ([]verifiedModel, error) {
+	verifications, err := c.verifyBackup(c.backupFile)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	models := make([]verifiedModel, len(verifications))
+	for i, v := range verifications {
+		models[i] = verifiedModel{
+			Model:           v.Owner + "/" + v.Name,
+			Inconsistencies: make([]formattedInconsistency, len(v.Inconsistencies)),
+		}
+		for j, inc := range v.Inconsistencies {
+			models[i].Inconsistencies[j] = formattedInconsistency{
+				Collection: inc.Collection,
+				Id:         inc.ID,
+				Problem:    inc.Problem,
+				Repair:     inc.Repair,
+			}
+		}
+	}
+	return models, nil
+}
+
+// verifyBackupArchive unpacks the backup archive at the given path and
+// checks the models in its database dump.
+func verifyBackupArchive(path string) ([]state.DumpedModelVerification, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer f.Close()
+	ws, err := backups.NewArchiveWorkspaceReader(f)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot unpack backup %s", path)
+	}
+	defer ws.Close()
+	result, err := state.VerifyDumpedModels(ws.DBDumpDir)
+	return result, errors.Trace(err)
+}
+
+type verifiedModel struct {
+	Model           string                   `yaml:"model" json:"model"`
+	Inconsistencies []formattedInconsistency `yaml:"inconsistencies" json:"inconsistencies"`
+}
+
+type formattedInconsistency struct {
+	Collection string `yaml:"collection" json:"collection"`
+	Id         string `yaml:"id" json:"id"`
+	Problem    string `yaml:"problem" json:"problem"`
+	Repair     string `yaml:"repair" json:"repair"`
+}
+
+// formatVerifiedModelsTabular prints a row for each inconsistency
+// found, with the model it was found in.
+func formatVerifiedModelsTabular(writer io.Writer, value interface{}) error {
+	models, ok := value.([]verifiedModel)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", models, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Model", "Collection", "Id", "Problem", "Repair")
+	for _, m := range models {
+		for _, inc := range m.Inconsistencies {
+			w.Println(m.Model, inc.Collection, inc.Id, inc.Problem, inc.Repair)
+		}
+	}
+	tw.Flush()
+	return nil
+}
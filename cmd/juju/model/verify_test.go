@@ -0,0 +1,137 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/state"
+)
+
+type verifyModelSuite struct {
+	generationBaseSuite
+
+	api    *fakeModelVerifierAPI
+	backup []state.DumpedModelVerification
+}
+
+var _ = gc.Suite(&verifyModelSuite{})
+
+func (s *verifyModelSuite) SetUpTest(c *gc.C) {
+	s.generationBaseSuite.SetUpTest(c)
+	s.api = &fakeModelVerifierAPI{
+		inconsistencies: []params.ModelInconsistency{{
+			Collection: "units",
+			Id:         "mysql/0",
+			Problem:    `machine "3" not found`,
+			Repair:     `run "juju remove-unit --force mysql/0"`,
+		}},
+	}
+	s.backup = []state.DumpedModelVerification{{
+		UUID:  "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+		Name:  "controller",
+		Owner: "admin",
+	}, {
+		UUID:  "deadbeef-0bad-400d-8000-4b1d0d06f00e",
+		Name:  "dev",
+		Owner: "bob",
+		Inconsistencies: []state.ModelInconsistency{{
+			Collection: "statuses",
+			ID:         "m#42",
+			Problem:    `machine "42" not found`,
+			Repair:     "remove the status document",
+		}},
+	}}
+}
+
+func (s *verifyModelSuite) newCommand() cmd.Command {
+	return model.NewVerifyModelCommandForTest(s.api, s.verifyBackup, s.store)
+}
+
+func (s *verifyModelSuite) verifyBackup(path string) ([]state.DumpedModelVerification, error) {
+	s.api.MethodCall(s, "verifyBackup", path)
+	return s.backup, s.api.NextErr()
+}
+
+func (s *verifyModelSuite) TestInit(c *gc.C) {
+	err := cmdtesting.InitCommand(s.newCommand(), []string{"mymodel", "foo"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["foo"\]`)
+
+	err = cmdtesting.InitCommand(s.newCommand(), []string{"--backup", "backup.tar.gz", "mymodel"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["mymodel"\]`)
+}
+
+func (s *verifyModelSuite) TestVerifyModel(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Model          Collection  Id       Problem                Repair
+admin/mymodel  units       mysql/0  machine "3" not found  run "juju remove-unit --force mysql/0"
+`[1:])
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"VerifyModel", nil},
+		{"Close", nil},
+	})
+}
+
+func (s *verifyModelSuite) TestVerifyModelConsistent(c *gc.C) {
+	s.api.inconsistencies = nil
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No inconsistencies found.\n")
+}
+
+func (s *verifyModelSuite) TestVerifyModelError(c *gc.C) {
+	s.api.SetErrors(errors.New("boom"))
+	_, err := cmdtesting.RunCommand(c, s.newCommand())
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *verifyModelSuite) TestVerifyBackupYAML(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--backup", "backup.tar.gz", "--format", "yaml")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- model: admin/controller
+  inconsistencies: []
+- model: bob/dev
+  inconsistencies:
+  - collection: statuses
+    id: m#42
+    problem: machine "42" not found
+    repair: remove the status document
+`[1:])
+	s.api.CheckCalls(c, []testing.StubCall{
+		{"verifyBackup", []interface{}{"backup.tar.gz"}},
+	})
+}
+
+func (s *verifyModelSuite) TestVerifyBackupConsistent(c *gc.C) {
+	s.backup = s.backup[:1]
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--backup", "backup.tar.gz")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No inconsistencies found.\n")
+}
+
+type fakeModelVerifierAPI struct {
+	testing.Stub
+	inconsistencies []params.ModelInconsistency
+}
+
+func (f *fakeModelVerifierAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeModelVerifierAPI) VerifyModel() ([]params.ModelInconsistency, error) {
+	f.MethodCall(f, "VerifyModel")
+	return f.inconsistencies, f.NextErr()
+}
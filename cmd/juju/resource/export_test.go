@@ -26,6 +26,10 @@ func UploadCommandResourceValue(c *UploadCommand) (application, name, value stri
 		c.resourceValue.value
 }
 
+func UploadCommandRevision(c *UploadCommand) int {
+	return c.revision
+}
+
 func UploadCommandApplication(c *UploadCommand) string {
 	return c.application
 }
@@ -68,3 +68,18 @@ type FormattedApplicationDetails struct {
 // FormattedDetailResource is the data for the tabular output for juju resources
 // <unit> --details.
 type FormattedUnitDetails []FormattedDetailResource
+
+// FormattedResourceRevision holds the formatted representation of a
+// revision of the content attached to an application resource.
+type FormattedResourceRevision struct {
+	Revision    int       `json:"revision" yaml:"revision"`
+	Current     bool      `json:"current" yaml:"current"`
+	Fingerprint string    `json:"fingerprint" yaml:"fingerprint"`
+	Size        int64     `json:"size" yaml:"size"`
+	Timestamp   time.Time `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
+	Username    string    `json:"username,omitempty" yaml:"username,omitempty"`
+}
+
+// FormattedResourceHistory is the data for the output of juju resources
+// --history <application> <resource>.
+type FormattedResourceHistory []FormattedResourceRevision
@@ -88,6 +88,24 @@ func FormatAppResource(res resource.Resource) FormattedAppResource {
 	return result
 }
 
+// FormatResourceHistory converts the revisions of the content attached
+// to an application resource into a formatted value for display on the
+// command line.
+func FormatResourceHistory(revisions []resource.AttachedRevision) FormattedResourceHistory {
+	formatted := make(FormattedResourceHistory, len(revisions))
+	for i, rev := range revisions {
+		formatted[i] = FormattedResourceRevision{
+			Revision:    rev.Number,
+			Current:     rev.Current,
+			Fingerprint: rev.Fingerprint.String(),
+			Size:        rev.Size,
+			Timestamp:   rev.Timestamp,
+			Username:    rev.Username,
+		}
+	}
+	return formatted
+}
+
 func formatApplicationResources(sr resource.ApplicationResources) (FormattedApplicationInfo, error) {
 	var formatted FormattedApplicationInfo
 	updates, err := sr.Updates()
@@ -4,6 +4,7 @@
 package resource
 
 import (
+	"fmt"
 	"sort"
 
 	"github.com/juju/cmd"
@@ -20,6 +21,9 @@ import (
 type ListClient interface {
 	// ListResources returns info about resources for applications in the model.
 	ListResources(applications []string) ([]resource.ApplicationResources, error)
+	// ResourceHistory returns the revisions of the content attached
+	// to an application resource, newest first.
+	ResourceHistory(application, name string) ([]resource.AttachedRevision, error)
 	// Close closes the connection.
 	Close() error
 }
@@ -35,10 +39,12 @@ type ListDeps struct {
 type ListCommand struct {
 	modelcmd.ModelCommandBase
 
-	details bool
-	deps    ListDeps
-	out     cmd.Output
-	target  string
+	details      bool
+	history      bool
+	deps         ListDeps
+	out          cmd.Output
+	target       string
+	resourceName string
 }
 
 // NewListCommand returns a new command that lists resources defined
@@ -47,18 +53,29 @@ func NewListCommand(deps ListDeps) modelcmd.ModelCommand {
 	return modelcmd.Wrap(&ListCommand{deps: deps})
 }
 
+const listDoc = `
+This command shows the resources required by and those in use by an existing
+application or unit in your model.  When run for an application, it will also show any
+updates available for resources from the charmstore.
+
+With --history, the revisions of the content attached to one of an application's
+file resources are shown instead, newest first. The application can be rolled
+back to any of the previous revisions with "juju attach-resource --revision".
+
+Examples:
+    juju resources mysql
+    juju resources mysql/0 --details
+    juju resources --history mysql backup-tool
+`
+
 // Info implements cmd.Command.Info.
 func (c *ListCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:    "resources",
 		Aliases: []string{"list-resources"},
-		Args:    "<application or unit>",
+		Args:    "<application or unit> [<resource name>]",
 		Purpose: "Show the resources for an application or unit.",
-		Doc: `
-This command shows the resources required by and those in use by an existing
-application or unit in your model.  When run for an application, it will also show any
-updates available for resources from the charmstore.
-`,
+		Doc:     listDoc,
 	})
 }
 
@@ -73,6 +90,7 @@ func (c *ListCommand) SetFlags(f *gnuflag.FlagSet) {
 	})
 
 	f.BoolVar(&c.details, "details", false, "show detailed information about resources used by each unit.")
+	f.BoolVar(&c.history, "history", false, "show the revisions of the content attached to an application resource.")
 }
 
 // Init implements cmd.Command.Init. It will return an error satisfying
@@ -82,7 +100,20 @@ func (c *ListCommand) Init(args []string) error {
 		return errors.NewBadRequest(nil, "missing application or unit name")
 	}
 	c.target = args[0]
-	if err := cmd.CheckEmpty(args[1:]); err != nil {
+	args = args[1:]
+	if c.history {
+		if c.details {
+			return errors.NewBadRequest(nil, "--history and --details cannot be used together")
+		}
+		if !names.IsValidApplication(c.target) {
+			return errors.NewBadRequest(nil, fmt.Sprintf("%q is not an application", c.target))
+		}
+		if len(args) == 0 {
+			return errors.NewBadRequest(nil, "missing resource name")
+		}
+		c.resourceName, args = args[0], args[1:]
+	}
+	if err := cmd.CheckEmpty(args); err != nil {
 		return errors.NewBadRequest(err, "")
 	}
 	return nil
@@ -96,6 +127,10 @@ func (c *ListCommand) Run(ctx *cmd.Context) error {
 	}
 	defer apiclient.Close()
 
+	if c.history {
+		return c.formatResourceHistory(ctx, apiclient)
+	}
+
 	var unit string
 	var application string
 	if names.IsValidApplication(c.target) {
@@ -133,6 +168,18 @@ func (c *ListCommand) Run(ctx *cmd.Context) error {
 
 const noResources = "No resources to display."
 
+func (c *ListCommand) formatResourceHistory(ctx *cmd.Context, apiclient ListClient) error {
+	revisions, err := apiclient.ResourceHistory(c.target, c.resourceName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(revisions) == 0 {
+		ctx.Infof("No revisions of resource %q to display.", c.resourceName)
+		return nil
+	}
+	return c.out.Write(ctx, FormatResourceHistory(revisions))
+}
+
 func (c *ListCommand) formatApplicationResources(ctx *cmd.Context, sr resource.ApplicationResources) error {
 	if c.details {
 		formatted, err := FormatApplicationDetails(sr)
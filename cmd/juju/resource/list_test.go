@@ -4,9 +4,11 @@
 package resource_test
 
 import (
+	"strings"
 	"time"
 
 	jujucmd "github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -57,6 +59,36 @@ func (*ShowApplicationSuite) TestInitTooManyArgs(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsBadRequest)
 }
 
+func (*ShowApplicationSuite) TestInitHistory(c *gc.C) {
+	s := resourcecmd.NewListCommandForTest(resourcecmd.ListDeps{})
+
+	err := cmdtesting.InitCommand(s, []string{"--history", "foo", "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resourcecmd.ListCommandTarget(s), gc.Equals, "foo")
+}
+
+func (*ShowApplicationSuite) TestInitHistoryMissingResource(c *gc.C) {
+	s := resourcecmd.NewListCommandForTest(resourcecmd.ListDeps{})
+
+	err := cmdtesting.InitCommand(s, []string{"--history", "foo"})
+	c.Assert(err, jc.Satisfies, errors.IsBadRequest)
+	c.Assert(err, gc.ErrorMatches, "missing resource name")
+}
+
+func (*ShowApplicationSuite) TestInitHistoryUnit(c *gc.C) {
+	s := resourcecmd.NewListCommandForTest(resourcecmd.ListDeps{})
+
+	err := cmdtesting.InitCommand(s, []string{"--history", "foo/0", "bar"})
+	c.Assert(err, jc.Satisfies, errors.IsBadRequest)
+}
+
+func (*ShowApplicationSuite) TestInitHistoryDetails(c *gc.C) {
+	s := resourcecmd.NewListCommandForTest(resourcecmd.ListDeps{})
+
+	err := cmdtesting.InitCommand(s, []string{"--history", "--details", "foo", "bar"})
+	c.Assert(err, jc.Satisfies, errors.IsBadRequest)
+}
+
 func (s *ShowApplicationSuite) TestInfo(c *gc.C) {
 	var command resourcecmd.ListCommand
 	info := command.Info()
@@ -64,12 +96,21 @@ func (s *ShowApplicationSuite) TestInfo(c *gc.C) {
 	c.Check(info, jc.DeepEquals, &jujucmd.Info{
 		Name:    "resources",
 		Aliases: []string{"list-resources"},
-		Args:    "<application or unit>",
+		Args:    "<application or unit> [<resource name>]",
 		Purpose: "Show the resources for an application or unit.",
 		Doc: `
 This command shows the resources required by and those in use by an existing
 application or unit in your model.  When run for an application, it will also show any
 updates available for resources from the charmstore.
+
+With --history, the revisions of the content attached to one of an application's
+file resources are shown instead, newest first. The application can be rolled
+back to any of the previous revisions with "juju attach-resource --revision".
+
+Examples:
+    juju resources mysql
+    juju resources mysql/0 --details
+    juju resources --history mysql backup-tool
 `,
 		FlagKnownAs:    "option",
 		ShowSuperFlags: []string{"show-log", "debug", "logging-config", "verbose", "quiet", "h", "help"},
@@ -656,6 +697,57 @@ charlie   2011-11-11T11:11  2012-12-12T12:12 (fetching: 0%)
 	s.stubDeps.stub.CheckCall(c, 1, "ListResources", []string{"svc"})
 }
 
+func (s *ShowApplicationSuite) TestRunHistory(c *gc.C) {
+	fp, err := charmresource.GenerateFingerprint(strings.NewReader("spamspamspam"))
+	c.Assert(err, jc.ErrorIsNil)
+	res := resource.Resource{
+		Resource: charmresource.Resource{
+			Meta: charmresource.Meta{
+				Name: "website",
+				Type: charmresource.TypeFile,
+			},
+			Origin:      charmresource.OriginUpload,
+			Fingerprint: fp,
+			Size:        12,
+		},
+		Username:  "Bill User",
+		Timestamp: time.Date(2012, 12, 12, 12, 12, 12, 0, time.UTC),
+	}
+	older := res
+	older.Username = "Ted User"
+	older.Timestamp = time.Date(2011, 11, 11, 11, 11, 11, 0, time.UTC)
+	s.stubDeps.client.ReturnHistory = []resource.AttachedRevision{
+		{Resource: res, Number: 2, Current: true},
+		{Resource: older, Number: 1},
+	}
+
+	cmd := resourcecmd.NewListCommandForTest(resourcecmd.ListDeps{
+		NewClient: s.stubDeps.NewClient,
+	})
+
+	code, stdout, stderr := runCmd(c, cmd, "--history", "svc", "website")
+	c.Check(code, gc.Equals, 0)
+	c.Check(stderr, gc.Equals, "")
+	c.Check(stdout, gc.Equals, `
+Revision  Current  Size  Uploaded by  Timestamp             Fingerprint
+2         *        12    Bill User    2012-12-12T12:12:12Z  fac09f7d67d1
+1                  12    Ted User     2011-11-11T11:11:11Z  fac09f7d67d1
+`[1:])
+	s.stubDeps.stub.CheckCallNames(c, "NewClient", "ResourceHistory", "Close")
+	s.stubDeps.stub.CheckCall(c, 1, "ResourceHistory", "svc", "website")
+}
+
+func (s *ShowApplicationSuite) TestRunHistoryNoRevisions(c *gc.C) {
+	cmd := resourcecmd.NewListCommandForTest(resourcecmd.ListDeps{
+		NewClient: s.stubDeps.NewClient,
+	})
+
+	code, stdout, stderr := runCmd(c, cmd, "--history", "svc", "website")
+	c.Check(code, gc.Equals, 0)
+	c.Check(stderr, gc.Equals, "No revisions of resource \"website\" to display.\n")
+	c.Check(stdout, gc.Equals, "")
+}
+
 type stubShowApplicationDeps struct {
 	stub   *testing.Stub
 	client *stubApplicationClient
@@ -673,6 +765,15 @@ func (s *stubShowApplicationDeps) NewClient(c *resourcecmd.ListCommand) (resourc
 type stubApplicationClient struct {
 	stub            *testing.Stub
 	ReturnResources []resource.ApplicationResources
+	ReturnHistory   []resource.AttachedRevision
+}
+
+func (s *stubApplicationClient) ResourceHistory(application, name string) ([]resource.AttachedRevision, error) {
+	s.stub.AddCall("ResourceHistory", application, name)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return s.ReturnHistory, nil
 }
 
 func (s *stubApplicationClient) ListResources(applications []string) ([]resource.ApplicationResources, error) {
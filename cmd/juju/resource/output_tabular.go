@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"sort"
+	"time"
 
 	"github.com/juju/ansiterm"
 	"github.com/juju/errors"
@@ -77,6 +78,9 @@ func FormatAppTabular(writer io.Writer, value interface{}) error {
 	case FormattedUnitDetails:
 		formatUnitDetailTabular(writer, resources)
 		return nil
+	case FormattedResourceHistory:
+		formatResourceHistoryTabular(writer, resources)
+		return nil
 	default:
 		return errors.Errorf("unexpected type for data: %T", resources)
 	}
@@ -211,6 +215,40 @@ func formatUnitDetailTabular(writer io.Writer, resources FormattedUnitDetails) {
 	tw.Flush()
 }
 
+func formatResourceHistoryTabular(writer io.Writer, revisions FormattedResourceHistory) {
+	tw := output.TabWriter(writer)
+
+	// Write the header.
+	fmt.Fprintln(tw, "Revision\tCurrent\tSize\tUploaded by\tTimestamp\tFingerprint")
+
+	for _, r := range revisions {
+		current := ""
+		if r.Current {
+			current = "*"
+		}
+		// the column headers must be kept in sync with these.
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\n",
+			r.Revision,
+			current,
+			r.Size,
+			r.Username,
+			r.Timestamp.UTC().Format(time.RFC3339),
+			shortFingerprint(r.Fingerprint),
+		)
+	}
+	tw.Flush()
+}
+
+// shortFingerprint returns enough of the fingerprint to tell revisions
+// apart in tabular output.
+func shortFingerprint(fp string) string {
+	const shortLen = 12
+	if len(fp) > shortLen {
+		return fp[:shortLen]
+	}
+	return fp
+}
+
 type byUnitID []FormattedDetailResource
 
 func (b byUnitID) Len() int      { return len(b) }
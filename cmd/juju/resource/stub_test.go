@@ -52,6 +52,11 @@ func (s *stubAPIClient) ListResources(applications []string) ([]resource.Applica
 	return []resource.ApplicationResources{s.resources}, nil
 }
 
+func (s *stubAPIClient) RollbackResource(application, name string, revision int) error {
+	s.stub.AddCall("RollbackResource", application, name, revision)
+	return errors.Trace(s.stub.NextErr())
+}
+
 func (s *stubAPIClient) Close() error {
 	s.stub.AddCall("Close")
 	if err := s.stub.NextErr(); err != nil {
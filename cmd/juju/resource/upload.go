@@ -5,9 +5,11 @@ package resource
 
 import (
 	"io"
+	"strings"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	charmresource "gopkg.in/juju/charm.v6/resource"
 	"gopkg.in/juju/names.v3"
 
@@ -25,6 +27,10 @@ type UploadClient interface {
 	// ListResources returns info about resources for applications in the model.
 	ListResources(applications []string) ([]resource.ApplicationResources, error)
 
+	// RollbackResource makes a previous revision of the content
+	// attached to the application resource the current one.
+	RollbackResource(application, name string, revision int) error
+
 	// Close closes the client.
 	Close() error
 }
@@ -51,6 +57,7 @@ type UploadCommand struct {
 	modelcmd.ModelCommandBase
 	application   string
 	resourceValue resourceValue
+	revision      int
 }
 
 // NewUploadCommand returns a new command that lists resources defined
@@ -69,6 +76,14 @@ streamed to the charm when "resource-get" is called by a hook.
 For OCI image resources used by k8s applications, an OCI image or file path is specified.
 A file is specified when a private OCI image is needed and the username/password used to
 access the image is needed along with the image path.
+
+With --revision, the application is rolled back to a previous revision of the
+content attached to a file resource instead, as shown by "juju resources --history".
+The content the application was using is kept as a previous revision in turn.
+
+Examples:
+    juju attach-resource mysql backup-tool=./backup-tool.tgz
+    juju attach-resource mysql backup-tool --revision 2
 `
 )
 
@@ -76,13 +91,19 @@ access the image is needed along with the image path.
 func (c *UploadCommand) Info() *cmd.Info {
 	return jujucmd.Info(&cmd.Info{
 		Name:    "attach-resource",
-		Args:    "application name=file|OCI image",
+		Args:    "application name=file|OCI image | application name --revision <revision>",
 		Purpose: "Update a resource for an application.",
 		Doc:     attachDoc,
 		Aliases: []string{"attach"},
 	})
 }
 
+// SetFlags implements cmd.Command.SetFlags.
+func (c *UploadCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.IntVar(&c.revision, "revision", 0, "roll back to a previous revision of the resource's content")
+}
+
 // Init implements cmd.Command.Init. It will return an error satisfying
 // errors.BadRequest if you give it an incorrect number of arguments.
 func (c *UploadCommand) Init(args []string) error {
@@ -98,6 +119,20 @@ func (c *UploadCommand) Init(args []string) error {
 		return errors.NotValidf("application %q", c.application)
 	}
 
+	if c.revision < 0 {
+		return errors.NotValidf("revision %d", c.revision)
+	}
+	if c.revision > 0 {
+		if strings.Contains(args[1], "=") {
+			return errors.BadRequestf("a resource value cannot be used with --revision")
+		}
+		c.resourceValue = resourceValue{
+			application: c.application,
+			name:        args[1],
+		}
+		return cmd.CheckEmpty(args[2:])
+	}
+
 	if err := c.addResourceValue(args[1]); err != nil {
 		return errors.Trace(err)
 	}
@@ -130,6 +165,14 @@ func (c *UploadCommand) Run(*cmd.Context) error {
 	}
 	defer apiclient.Close()
 
+	if c.revision > 0 {
+		err := apiclient.RollbackResource(c.application, c.resourceValue.name, c.revision)
+		if err := block.ProcessBlockedError(err, block.BlockChange); err != nil {
+			return errors.Annotatef(err, "failed to roll back resource %q", c.resourceValue.name)
+		}
+		return nil
+	}
+
 	result, err := apiclient.ListResources([]string{c.application})
 	if err != nil {
 		return errors.Trace(err)
@@ -7,6 +7,7 @@ import (
 	"bytes"
 
 	jujucmd "github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
 	"github.com/juju/errors"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/testing"
@@ -90,13 +91,38 @@ func (*UploadSuite) TestInitTwoResources(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["fizz=buzz"\]`)
 }
 
+func (*UploadSuite) TestInitRevision(c *gc.C) {
+	u := resourcecmd.NewUploadCommandForTest(resourcecmd.UploadDeps{})
+
+	err := cmdtesting.InitCommand(u, []string{"foo", "bar", "--revision", "2"})
+	c.Assert(err, jc.ErrorIsNil)
+	svc, name, _ := resourcecmd.UploadCommandResourceValue(u)
+	c.Assert(svc, gc.Equals, "foo")
+	c.Assert(name, gc.Equals, "bar")
+	c.Assert(resourcecmd.UploadCommandRevision(u), gc.Equals, 2)
+}
+
+func (*UploadSuite) TestInitRevisionWithValue(c *gc.C) {
+	u := resourcecmd.NewUploadCommandForTest(resourcecmd.UploadDeps{})
+
+	err := cmdtesting.InitCommand(u, []string{"foo", "bar=baz", "--revision", "2"})
+	c.Assert(err, jc.Satisfies, errors.IsBadRequest)
+}
+
+func (*UploadSuite) TestInitBadRevision(c *gc.C) {
+	u := resourcecmd.NewUploadCommandForTest(resourcecmd.UploadDeps{})
+
+	err := cmdtesting.InitCommand(u, []string{"foo", "bar", "--revision", "-1"})
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
 func (s *UploadSuite) TestInfo(c *gc.C) {
 	var command resourcecmd.UploadCommand
 	info := command.Info()
 
 	c.Check(info, jc.DeepEquals, &jujucmd.Info{
 		Name:    "attach-resource",
-		Args:    "application name=file|OCI image",
+		Args:    "application name=file|OCI image | application name --revision <revision>",
 		Purpose: "Update a resource for an application.",
 		Doc: `
 This command updates a resource for an application.
@@ -107,6 +133,14 @@ streamed to the charm when "resource-get" is called by a hook.
 For OCI image resources used by k8s applications, an OCI image or file path is specified.
 A file is specified when a private OCI image is needed and the username/password used to
 access the image is needed along with the image path.
+
+With --revision, the application is rolled back to a previous revision of the
+content attached to a file resource instead, as shown by "juju resources --history".
+The content the application was using is kept as a previous revision in turn.
+
+Examples:
+    juju attach-resource mysql backup-tool=./backup-tool.tgz
+    juju attach-resource mysql backup-tool --revision 2
 `,
 		Aliases:        []string{"attach"},
 		FlagKnownAs:    "option",
@@ -175,6 +209,38 @@ func (s *UploadSuite) TestUploadFileChangeBlocked(c *gc.C) {
 	s.stub.CheckCall(c, 3, "Upload", "svc", "foo", "bar", file)
 }
 
+func (s *UploadSuite) TestRollbackResource(c *gc.C) {
+	u := resourcecmd.NewUploadCommandForTest(resourcecmd.UploadDeps{
+		NewClient:    s.stubDeps.NewClient,
+		OpenResource: s.stubDeps.OpenResource,
+	})
+	err := cmdtesting.InitCommand(u, []string{"svc", "foo", "--revision", "2"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = u.Run(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c,
+		"NewClient",
+		"RollbackResource",
+		"Close",
+	)
+	s.stub.CheckCall(c, 1, "RollbackResource", "svc", "foo", 2)
+}
+
+func (s *UploadSuite) TestRollbackResourceNotFound(c *gc.C) {
+	u := resourcecmd.NewUploadCommandForTest(resourcecmd.UploadDeps{
+		NewClient:    s.stubDeps.NewClient,
+		OpenResource: s.stubDeps.OpenResource,
+	})
+	err := cmdtesting.InitCommand(u, []string{"svc", "foo", "--revision", "7"})
+	c.Assert(err, jc.ErrorIsNil)
+	s.stub.SetErrors(nil, errors.NotFoundf(`revision 7 of resource "svc/foo"`))
+
+	err = u.Run(nil)
+	c.Assert(err, gc.ErrorMatches, `failed to roll back resource "foo": revision 7 of resource "svc/foo" not found`)
+}
+
 type rsc struct {
 	*bytes.Buffer
 }
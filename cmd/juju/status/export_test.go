@@ -18,3 +18,7 @@ func NewTestStatusCommand(statusapi statusAPI, storageapi storage.StorageListAPI
 	return modelcmd.Wrap(
 		&statusCommand{statusAPI: statusapi, storageAPI: storageapi, clock: clock})
 }
+
+func NewTestStatusSnapshotCommand(snapshotapi statusSnapshotAPI) cmd.Command {
+	return modelcmd.Wrap(&statusCommand{snapshotAPI: snapshotapi})
+}
@@ -52,6 +52,13 @@ type controllerStatus struct {
 	Timestamp string `json:"timestamp,omitempty" yaml:"timestamp,omitempty"`
 }
 
+// workerFailure describes a worker run by a machine's agent failing.
+type workerFailure struct {
+	Worker string `json:"worker" yaml:"worker"`
+	Error  string `json:"error" yaml:"error"`
+	Time   string `json:"time" yaml:"time"`
+}
+
 type networkInterface struct {
 	IPAddresses    []string `json:"ip-addresses" yaml:"ip-addresses"`
 	MACAddress     string   `json:"mac-address" yaml:"mac-address"`
@@ -64,6 +71,7 @@ type networkInterface struct {
 type machineStatus struct {
 	Err                error                         `json:"-" yaml:",omitempty"`
 	JujuStatus         statusInfoContents            `json:"juju-status,omitempty" yaml:"juju-status,omitempty"`
+	WorkerFailures     []workerFailure               `json:"worker-failures,omitempty" yaml:"worker-failures,omitempty"`
 	DNSName            string                        `json:"dns-name,omitempty" yaml:"dns-name,omitempty"`
 	IPAddresses        []string                      `json:"ip-addresses,omitempty" yaml:"ip-addresses,omitempty"`
 	InstanceId         instance.Id                   `json:"instance-id,omitempty" yaml:"instance-id,omitempty"`
@@ -275,12 +283,13 @@ func (s *formattedStatus) applicationScale(name string) (string, bool) {
 }
 
 type statusInfoContents struct {
-	Err     error         `json:"-" yaml:",omitempty"`
-	Current status.Status `json:"current,omitempty" yaml:"current,omitempty"`
-	Message string        `json:"message,omitempty" yaml:"message,omitempty"`
-	Since   string        `json:"since,omitempty" yaml:"since,omitempty"`
-	Version string        `json:"version,omitempty" yaml:"version,omitempty"`
-	Life    string        `json:"life,omitempty" yaml:"life,omitempty"`
+	Err     error                  `json:"-" yaml:",omitempty"`
+	Current status.Status          `json:"current,omitempty" yaml:"current,omitempty"`
+	Message string                 `json:"message,omitempty" yaml:"message,omitempty"`
+	Since   string                 `json:"since,omitempty" yaml:"since,omitempty"`
+	Version string                 `json:"version,omitempty" yaml:"version,omitempty"`
+	Life    string                 `json:"life,omitempty" yaml:"life,omitempty"`
+	Data    map[string]interface{} `json:"data,omitempty" yaml:"data,omitempty"`
 }
 
 type statusInfoContentsNoMarshal statusInfoContents
@@ -181,7 +181,7 @@ func (sf *statusFormatter) formatMachine(machine params.MachineStatus) machineSt
 		IPAddresses:        machine.IPAddresses,
 		InstanceId:         machine.InstanceId,
 		DisplayName:        machine.DisplayName,
-		MachineStatus:      sf.getStatusInfoContents(machine.InstanceStatus),
+		MachineStatus:      sf.getInstanceStatusInfoContents(machine.InstanceStatus),
 		ModificationStatus: sf.getStatusInfoContents(machine.ModificationStatus),
 		Series:             machine.Series,
 		Id:                 machine.Id,
@@ -192,6 +192,14 @@ func (sf *statusFormatter) formatMachine(machine params.MachineStatus) machineSt
 		LXDProfiles:        make(map[string]lxdProfileContents),
 	}
 
+	for _, f := range status.WorkerFailures(machine.AgentStatus.Data) {
+		out.WorkerFailures = append(out.WorkerFailures, workerFailure{
+			Worker: f.Worker,
+			Error:  f.Error,
+			Time:   common.FormatTime(&f.Time, sf.isoTime),
+		})
+	}
+
 	for k, d := range machine.NetworkInterfaces {
 		out.NetworkInterfaces[k] = networkInterface{
 			IPAddresses:    d.IPAddresses,
@@ -461,6 +469,17 @@ func (sf *statusFormatter) getStatusInfoContents(inst params.DetailedStatus) sta
 	return info
 }
 
+// getInstanceStatusInfoContents is like getStatusInfoContents, but also
+// includes the status data, which records why the provisioner failed to
+// start the instance.
+func (sf *statusFormatter) getInstanceStatusInfoContents(inst params.DetailedStatus) statusInfoContents {
+	info := sf.getStatusInfoContents(inst)
+	if len(inst.Data) > 0 {
+		info.Data = inst.Data
+	}
+	return info
+}
+
 func (sf *statusFormatter) getWorkloadStatusInfo(unit params.UnitStatus) statusInfoContents {
 	if unit.WorkloadStatus.Status == "" {
 		return statusInfoContents{}
@@ -505,7 +524,7 @@ func (sf *statusFormatter) updateUnitStatusInfo(unit *params.UnitStatus, applica
 }
 func (sf *statusFormatter) formatBranch(ref int, branch params.BranchStatus, isActiveBranch bool) branchStatus {
 	created := time.Unix(branch.Created, 0)
-	if sf.outputName == "tabular" {
+	if isTabularFormat(sf.outputName) {
 		return branchStatus{
 			Ref:       fmt.Sprintf("#%d", ref),
 			Created:   common.UserFriendlyDuration(created, time.Now()),
@@ -525,7 +544,7 @@ func (sf *statusFormatter) branchRefForUnit(unitName string) string {
 		for _, units := range bs.AssignedUnits {
 			unitSet := set.NewStrings(units...)
 			if unitSet.Contains(unitName) {
-				if sf.outputName == "tabular" {
+				if isTabularFormat(sf.outputName) {
 					return sf.formattedBranches[branchName].Ref
 				}
 				return branchName
@@ -551,13 +570,12 @@ func makeHAStatus(hasVote, wantsVote bool) string {
 }
 
 func getRelationIdFromData(unit *params.UnitStatus) int {
-	if relationId_, ok := unit.WorkloadStatus.Data["relation-id"]; ok {
-		if relationId, ok := relationId_.(float64); ok {
-			return int(relationId)
-		} else {
-			logger.Infof("relation-id found status data but was unexpected "+
-				"type: %q. Status output may be lacking some detail.", relationId_)
+	if relationId_, ok := unit.WorkloadStatus.Data[status.RelationIdKey]; ok {
+		if relationId, ok := status.RelationId(unit.WorkloadStatus.Data); ok {
+			return relationId
 		}
+		logger.Infof("relation-id found status data but was unexpected "+
+			"type: %q. Status output may be lacking some detail.", relationId_)
 	}
 	return -1
 }
@@ -4,9 +4,12 @@
 package status
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -16,6 +19,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"gopkg.in/juju/names.v3"
+	"gopkg.in/yaml.v2"
 
 	jujucmd "github.com/juju/juju/cmd"
 	"github.com/juju/juju/cmd/juju/common"
@@ -36,9 +40,14 @@ func NewStatusHistoryCommand() cmd.Command {
 // HistoryAPI is the API surface for the show-status-log command.
 type HistoryAPI interface {
 	StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error)
+	StatusHistoryPages(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter, pageSize int, fn func(status.History) error) error
 	Close() error
 }
 
+// historyPageSize is the number of entries read at a time when the
+// whole of the status history is requested.
+const historyPageSize = 500
+
 type statusHistoryCommand struct {
 	modelcmd.ModelCommandBase
 	api                  HistoryAPI
@@ -47,9 +56,14 @@ type statusHistoryCommand struct {
 	backlogSize          int
 	backlogSizeDays      int
 	backlogDate          string
+	untilDate            string
+	all                  bool
 	isoTime              bool
+	showData             bool
+	application          bool
 	entityName           string
 	date                 time.Time
+	until                time.Time
 	includeStatusUpdates bool
 }
 
@@ -61,6 +75,33 @@ The statuses are available for the following types.
 %v
  and sorted by time of occurrence.
  The default is unit.
+
+The --all option returns every log matching the other options, rather
+than only the last 20; combined with --format and --output, it exports
+the history for analysis elsewhere. The yaml, json and csv formats
+always show times as UTC, and are written as the history is read, so
+that a long history can be exported without holding it all in memory;
+the tabular format waits for the whole history to align its columns.
+
+The --show-data option adds the structured data recorded with each
+status, such as the context of a hook error, to the tabular and csv
+formats. The yaml and json formats always include it.
+
+The --application option takes the name of an application, and reports
+the unit, workload or juju-unit statuses of all its units together,
+each with the name of its unit.
+
+A status set several times in a row may be shown once, with the time
+it was last set. The tabular format adds how many times it was set and
+when it was first set to the message; the yaml and json formats show
+them as count and first-time.
+
+Examples:
+    juju show-status-log mysql/0
+    juju show-status-log --type application mysql --days 7
+    juju show-status-log mysql/0 --all --from-date 2020-03-01 --to-date 2020-04-01 --format csv -o mysql-0.csv
+    juju show-status-log mysql/0 --show-data
+    juju show-status-log --application mysql --type workload
 `, supportedHistoryKindDescs())
 
 func (c *statusHistoryCommand) Info() *cmd.Info {
@@ -99,10 +140,20 @@ func (c *statusHistoryCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.IntVar(&c.backlogSize, "n", 0, "Returns the last N logs (cannot be combined with --days or --date)")
 	f.IntVar(&c.backlogSizeDays, "days", 0, "Returns the logs for the past <days> days (cannot be combined with -n or --date)")
 	f.StringVar(&c.backlogDate, "from-date", "", "Returns logs for any date after the passed one, the expected date format is YYYY-MM-DD (cannot be combined with -n or --days)")
+	f.StringVar(&c.untilDate, "to-date", "", "Returns logs for any date before the passed one, the expected date format is YYYY-MM-DD")
+	f.BoolVar(&c.all, "all", false, "Returns all the logs, rather than the last 20 (cannot be combined with -n)")
 	f.BoolVar(&c.isoTime, "utc", false, "Display time as UTC in RFC3339 format")
+	f.BoolVar(&c.showData, "show-data", false, "Include the data recorded with each status in the tabular and csv formats")
+	f.BoolVar(&c.application, "application", false, "Report the statuses of all the units of the named application")
 	// TODO (anastasiamac 2018-04-11) Remove at the next major release, say Juju 2.5+ or Juju 3.x.
 	// the functionality is no longer there since a fix for lp#1530840
 	f.BoolVar(&c.includeStatusUpdates, "include-status-updates", false, "Deprecated, has no effect for 2.3+ controllers: Include update status hook messages in the returned logs")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    c.formatYAML,
+		"json":    c.formatJSON,
+		"csv":     c.formatCSV,
+		"tabular": c.formatTabular,
+	})
 }
 
 func (c *statusHistoryCommand) Init(args []string) error {
@@ -128,7 +179,10 @@ func (c *statusHistoryCommand) Init(args []string) error {
 	emptyDate := c.backlogDate == ""
 	emptySize := c.backlogSize == 0
 	emptyDays := c.backlogSizeDays == 0
-	if emptyDate && emptySize && emptyDays {
+	if c.all && !emptySize {
+		return errors.Errorf("--all and backlog size cannot be specified together")
+	}
+	if emptyDate && emptySize && emptyDays && !c.all {
 		c.backlogSize = 20
 	}
 	if (!emptyDays && !emptySize) || (!emptyDays && !emptyDate) || (!emptySize && !emptyDate) {
@@ -141,12 +195,29 @@ func (c *statusHistoryCommand) Init(args []string) error {
 			return errors.Annotate(err, "parsing backlog date")
 		}
 	}
+	if c.untilDate != "" {
+		var err error
+		c.until, err = time.Parse("2006-01-02", c.untilDate)
+		if err != nil {
+			return errors.Annotate(err, "parsing until date")
+		}
+		if !c.date.IsZero() && !c.until.After(c.date) {
+			return errors.Errorf("until date must be after backlog date")
+		}
+	}
 
 	kind := status.HistoryKind(c.outputContent)
-	if kind.Valid() {
-		return nil
+	if !kind.Valid() {
+		return errors.Errorf("unexpected status type %q", c.outputContent)
 	}
-	return errors.Errorf("unexpected status type %q", c.outputContent)
+	if c.application {
+		switch kind {
+		case status.KindUnit, status.KindWorkload, status.KindUnitAgent:
+		default:
+			return errors.Errorf("--application cannot be used with status type %q", c.outputContent)
+		}
+	}
+	return nil
 }
 
 const runningHookMSG = "running update-status hook"
@@ -182,19 +253,54 @@ func (c *statusHistoryCommand) Run(ctx *cmd.Context) error {
 	if !c.date.IsZero() {
 		filterArgs.FromDate = &c.date
 	}
+	if !c.until.IsZero() {
+		filterArgs.Until = &c.until
+	}
 	var tag names.Tag
-	switch kind {
-	case status.KindUnit, status.KindWorkload, status.KindUnitAgent:
+	switch {
+	case c.application:
+		if !names.IsValidApplication(c.entityName) {
+			return errors.Errorf("%q is not a valid name for an application", c.entityName)
+		}
+		tag = names.NewApplicationTag(c.entityName)
+	case kind == status.KindUnit, kind == status.KindWorkload, kind == status.KindUnitAgent:
 		if !names.IsValidUnit(c.entityName) {
 			return errors.Errorf("%q is not a valid name for a %s", c.entityName, kind)
 		}
 		tag = names.NewUnitTag(c.entityName)
+	case kind == status.KindApplication:
+		if !names.IsValidApplication(c.entityName) {
+			return errors.Errorf("%q is not a valid name for an %s", c.entityName, kind)
+		}
+		tag = names.NewApplicationTag(c.entityName)
 	default:
 		if !names.IsValidMachine(c.entityName) {
 			return errors.Errorf("%q is not a valid name for a %s", c.entityName, kind)
 		}
 		tag = names.NewMachineTag(c.entityName)
 	}
+	if c.all {
+		// The whole history may be too long to hold at once, so it's
+		// written out as each page is read.
+		history := &historyStream{read: func(fn func(status.History) error) error {
+			return apiclient.StatusHistoryPages(kind, tag, filterArgs, historyPageSize, fn)
+		}}
+		if err := c.out.Write(ctx, history); err != nil {
+			return errors.Trace(err)
+		}
+		if history.count == 0 {
+			if history.err != nil {
+				return errors.Trace(history.err)
+			}
+			return errors.Errorf("no status history available")
+		}
+		if history.err != nil {
+			// Display the error after the status which was read.
+			fmt.Fprintf(ctx.Stderr, "%v\n", history.err)
+		}
+		return nil
+	}
+
 	statuses, err := apiclient.StatusHistory(kind, tag, filterArgs)
 	historyLen := len(statuses)
 	if err != nil {
@@ -209,7 +315,215 @@ func (c *statusHistoryCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("no status history available")
 	}
 
-	c.writeTabular(ctx.Stdout, statuses)
+	if c.out.Name() == "tabular" {
+		return c.out.Write(ctx, statuses)
+	}
+	return c.out.Write(ctx, historyEntries(statuses))
+}
+
+// historyStream is written by the formatters when the whole of the
+// status history is requested, reading it a page at a time.
+type historyStream struct {
+	read func(fn func(status.History) error) error
+
+	// count is the number of entries read, and err the error, if
+	// any, which stopped them being read.
+	count int
+	err   error
+}
+
+// each calls fn with each page of the history in time order. An error
+// reading the history is recorded rather than returned, so that the
+// formatters can complete the output of the entries already read.
+func (h *historyStream) each(fn func(status.History) error) error {
+	var writeErr error
+	h.err = h.read(func(page status.History) error {
+		h.count += len(page)
+		writeErr = fn(page)
+		return writeErr
+	})
+	if writeErr != nil {
+		h.err = nil
+		return errors.Trace(writeErr)
+	}
+	return nil
+}
+
+// historyEntries returns the statuses in the form written by the yaml,
+// json and csv formats.
+func historyEntries(statuses status.History) []historyEntry {
+	entries := make([]historyEntry, len(statuses))
+	for i, s := range statuses {
+		entries[i] = historyEntry{
+			Time:    s.Since.UTC(),
+			Unit:    s.Unit,
+			Type:    string(s.Kind),
+			Status:  string(s.Status),
+			Message: s.Info,
+			Data:    s.Data,
+			Count:   s.Count,
+		}
+		if s.FirstSince != nil {
+			firstTime := s.FirstSince.UTC()
+			entries[i].FirstTime = &firstTime
+		}
+	}
+	return entries
+}
+
+// historyEntry is the serialisation format of a status history entry
+// for the yaml, json and csv formats.
+type historyEntry struct {
+	Time    time.Time              `yaml:"time" json:"time"`
+	Unit    string                 `yaml:"unit,omitempty" json:"unit,omitempty"`
+	Type    string                 `yaml:"type" json:"type"`
+	Status  string                 `yaml:"status" json:"status"`
+	Message string                 `yaml:"message,omitempty" json:"message,omitempty"`
+	Data    map[string]interface{} `yaml:"data,omitempty" json:"data,omitempty"`
+
+	// FirstTime and Count are set when the status was set several
+	// times in a row, in which case Time is when it was last set.
+	FirstTime *time.Time `yaml:"first-time,omitempty" json:"first-time,omitempty"`
+	Count     int        `yaml:"count,omitempty" json:"count,omitempty"`
+}
+
+func (c *statusHistoryCommand) formatYAML(writer io.Writer, value interface{}) error {
+	history, ok := value.(*historyStream)
+	if !ok {
+		return cmd.FormatYaml(writer, value)
+	}
+	return history.each(func(page status.History) error {
+		// Each page's entries carry on the same yaml sequence.
+		data, err := yaml.Marshal(historyEntries(page))
+		if err != nil {
+			return errors.Trace(err)
+		}
+		_, err = writer.Write(data)
+		return errors.Trace(err)
+	})
+}
+
+func (c *statusHistoryCommand) formatJSON(writer io.Writer, value interface{}) error {
+	history, ok := value.(*historyStream)
+	if !ok {
+		return cmd.FormatJson(writer, value)
+	}
+	separator := "["
+	err := history.each(func(page status.History) error {
+		for _, entry := range historyEntries(page) {
+			data, err := json.Marshal(entry)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if _, err := fmt.Fprintf(writer, "%s%s", separator, data); err != nil {
+				return errors.Trace(err)
+			}
+			separator = ","
+		}
+		return nil
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if history.count > 0 {
+		_, err = io.WriteString(writer, "]")
+	}
+	return errors.Trace(err)
+}
+
+func (c *statusHistoryCommand) formatCSV(writer io.Writer, value interface{}) error {
+	w := csv.NewWriter(writer)
+	switch value := value.(type) {
+	case []historyEntry:
+		if err := c.writeCSVHeader(w); err != nil {
+			return errors.Trace(err)
+		}
+		if err := c.writeCSV(w, value); err != nil {
+			return errors.Trace(err)
+		}
+	case *historyStream:
+		header := true
+		err := value.each(func(page status.History) error {
+			if header {
+				if err := c.writeCSVHeader(w); err != nil {
+					return errors.Trace(err)
+				}
+				header = false
+			}
+			if err := c.writeCSV(w, historyEntries(page)); err != nil {
+				return errors.Trace(err)
+			}
+			w.Flush()
+			return errors.Trace(w.Error())
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+	default:
+		return errors.Errorf("expected value of type %T, got %T", []historyEntry{}, value)
+	}
+	w.Flush()
+	return errors.Trace(w.Error())
+}
+
+func (c *statusHistoryCommand) writeCSVHeader(w *csv.Writer) error {
+	header := []string{"time", "type", "status", "message"}
+	if c.application {
+		header = []string{"time", "unit", "type", "status", "message"}
+	}
+	if c.showData {
+		header = append(header, "data")
+	}
+	return errors.Trace(w.Write(header))
+}
+
+func (c *statusHistoryCommand) writeCSV(w *csv.Writer, entries []historyEntry) error {
+	for _, entry := range entries {
+		record := []string{entry.Time.Format(time.RFC3339Nano), entry.Type, entry.Status, entry.Message}
+		if c.application {
+			record = []string{entry.Time.Format(time.RFC3339Nano), entry.Unit, entry.Type, entry.Status, entry.Message}
+		}
+		if c.showData {
+			// The data is written as JSON so that it can be read
+			// back with its structure intact.
+			data := ""
+			if len(entry.Data) > 0 {
+				encoded, err := json.Marshal(entry.Data)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				data = string(encoded)
+			}
+			record = append(record, data)
+		}
+		if err := w.Write(record); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func (c *statusHistoryCommand) formatTabular(writer io.Writer, value interface{}) error {
+	switch value := value.(type) {
+	case status.History:
+		c.writeTabular(writer, value)
+	case *historyStream:
+		// The columns are aligned to fit every entry, so they're all
+		// read before any are written.
+		var statuses status.History
+		err := value.each(func(page status.History) error {
+			statuses = append(statuses, page...)
+			return nil
+		})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(statuses) > 0 {
+			c.writeTabular(writer, statuses)
+		}
+	default:
+		return errors.Errorf("expected value of type %T, got %T", status.History{}, value)
+	}
 	return nil
 }
 
@@ -217,11 +531,57 @@ func (c *statusHistoryCommand) writeTabular(writer io.Writer, statuses status.Hi
 	tw := output.TabWriter(writer)
 	w := output.Wrapper{tw}
 
-	w.Println("Time", "Type", "Status", "Message")
+	header := []interface{}{"Time"}
+	if c.application {
+		header = append(header, "Unit")
+	}
+	header = append(header, "Type", "Status", "Message")
+	if c.showData {
+		header = append(header, "Data")
+	}
+	w.Println(header...)
 	for _, v := range statuses {
-		w.Print(common.FormatTime(v.Since, c.isoTime), v.Kind)
+		w.Print(common.FormatTime(v.Since, c.isoTime))
+		if c.application {
+			w.Print(v.Unit)
+		}
+		w.Print(v.Kind)
 		w.PrintStatus(v.Status)
-		w.Println(v.Info)
+		message := v.Info
+		if v.Count > 1 {
+			message = fmt.Sprintf("%s (%d times", message, v.Count)
+			if v.FirstSince != nil {
+				message += " since " + common.FormatTime(v.FirstSince, c.isoTime)
+			}
+			message += ")"
+		}
+		if c.showData {
+			w.Println(message, formatHistoryData(v.Data))
+		} else {
+			w.Println(message)
+		}
 	}
 	tw.Flush()
 }
+
+// formatHistoryData returns the status data as space separated
+// key=value pairs, sorted by key. Values other than strings are shown
+// as JSON.
+func formatHistoryData(data map[string]interface{}) string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, len(keys))
+	for i, k := range keys {
+		value := data[k]
+		if _, ok := value.(string); !ok {
+			if encoded, err := json.Marshal(value); err == nil {
+				value = string(encoded)
+			}
+		}
+		pairs[i] = fmt.Sprintf("%s=%v", k, value)
+	}
+	return strings.Join(pairs, " ")
+}
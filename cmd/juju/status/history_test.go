@@ -109,9 +109,354 @@ func (s *StatusHistorySuite) TestResults(c *gc.C) {
 	c.Check(cmdtesting.Stdout(ctx), gc.Equals, expected)
 }
 
+func (s *StatusHistorySuite) TestApplicationHistory(c *gc.C) {
+	api := &fakeHistoryAPI{
+		history: status.History{{
+			Kind:   status.KindApplication,
+			Status: status.Active,
+			Info:   "ready",
+			Since:  s.next(),
+		}},
+	}
+	s.api = api
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--type", "application", "mysql", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Time                  Type         Status  Message\n"+
+		"2017-11-28 12:34:56Z  application  active  ready\n")
+	c.Check(api.tag, gc.Equals, names.NewApplicationTag("mysql"))
+}
+
+func (s *StatusHistorySuite) applicationUnitsHistory() *fakeHistoryAPI {
+	return &fakeHistoryAPI{
+		history: status.History{{
+			Kind:   status.KindUnitAgent,
+			Unit:   "mysql/0",
+			Status: status.Executing,
+			Info:   "running install hook",
+			Since:  s.next(),
+		}, {
+			Kind:   status.KindWorkload,
+			Unit:   "mysql/1",
+			Status: status.Active,
+			Info:   "ready",
+			Since:  s.next(),
+		}},
+	}
+}
+
+func (s *StatusHistorySuite) TestApplicationUnitsHistory(c *gc.C) {
+	api := s.applicationUnitsHistory()
+	s.api = api
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--application", "mysql", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Time                  Unit     Type       Status     Message\n"+
+		"2017-11-28 12:34:56Z  mysql/0  juju-unit  executing  running install hook\n"+
+		"2017-11-28 12:35:56Z  mysql/1  workload   active     ready\n")
+	c.Check(api.tag, gc.Equals, names.NewApplicationTag("mysql"))
+}
+
+func (s *StatusHistorySuite) TestApplicationUnitsHistoryCSV(c *gc.C) {
+	s.api = s.applicationUnitsHistory()
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--application", "mysql", "--format", "csv")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"time,unit,type,status,message\n"+
+		"2017-11-28T12:34:56Z,mysql/0,juju-unit,executing,running install hook\n"+
+		"2017-11-28T12:35:56Z,mysql/1,workload,active,ready\n")
+}
+
+func (s *StatusHistorySuite) TestApplicationUnitsHistoryYAML(c *gc.C) {
+	s.api = s.applicationUnitsHistory()
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "--application", "mysql", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"- time: 2017-11-28T12:34:56Z\n"+
+		"  unit: mysql/0\n"+
+		"  type: juju-unit\n"+
+		"  status: executing\n"+
+		"  message: running install hook\n"+
+		"- time: 2017-11-28T12:35:56Z\n"+
+		"  unit: mysql/1\n"+
+		"  type: workload\n"+
+		"  status: active\n"+
+		"  message: ready\n")
+}
+
+func (s *StatusHistorySuite) TestYAML(c *gc.C) {
+	s.api = &fakeHistoryAPI{
+		history: status.History{{
+			Kind:   status.KindWorkload,
+			Status: status.Waiting,
+			Info:   "waiting for machine",
+			Since:  s.next(),
+		}, {
+			Kind:   status.KindWorkload,
+			Status: status.Active,
+			Data:   map[string]interface{}{"port": 3306},
+			Since:  s.next(),
+		}},
+	}
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "mysql/0", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"- time: 2017-11-28T12:34:56Z\n"+
+		"  type: workload\n"+
+		"  status: waiting\n"+
+		"  message: waiting for machine\n"+
+		"- time: 2017-11-28T12:35:56Z\n"+
+		"  type: workload\n"+
+		"  status: active\n"+
+		"  data:\n"+
+		"    port: 3306\n")
+}
+
+func (s *StatusHistorySuite) TestCSV(c *gc.C) {
+	s.api = &fakeHistoryAPI{
+		history: status.History{{
+			Kind:   status.KindUnitAgent,
+			Status: status.Executing,
+			Info:   "running install hook, attempt 2",
+			Since:  s.next(),
+		}, {
+			Kind:   status.KindWorkload,
+			Status: status.Active,
+			Since:  s.next(),
+		}},
+	}
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "mysql/0", "--format", "csv")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"time,type,status,message\n"+
+		"2017-11-28T12:34:56Z,juju-unit,executing,\"running install hook, attempt 2\"\n"+
+		"2017-11-28T12:35:56Z,workload,active,\n")
+}
+
+func (s *StatusHistorySuite) repeatedHistory() *fakeHistoryAPI {
+	first := s.next()
+	last := s.next()
+	return &fakeHistoryAPI{
+		history: status.History{{
+			Kind:       status.KindWorkload,
+			Status:     status.Active,
+			Info:       "ready",
+			Since:      last,
+			FirstSince: first,
+			Count:      5,
+		}},
+	}
+}
+
+func (s *StatusHistorySuite) TestRepeatedStatus(c *gc.C) {
+	s.api = s.repeatedHistory()
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "mysql/0", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Time                  Type      Status  Message\n"+
+		"2017-11-28 12:35:56Z  workload  active  ready (5 times since 2017-11-28 12:34:56Z)\n")
+}
+
+func (s *StatusHistorySuite) TestRepeatedStatusYAML(c *gc.C) {
+	s.api = s.repeatedHistory()
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "mysql/0", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"- time: 2017-11-28T12:35:56Z\n"+
+		"  type: workload\n"+
+		"  status: active\n"+
+		"  message: ready\n"+
+		"  first-time: 2017-11-28T12:34:56Z\n"+
+		"  count: 5\n")
+}
+
+func (s *StatusHistorySuite) dataHistory() *fakeHistoryAPI {
+	return &fakeHistoryAPI{
+		history: status.History{{
+			Kind:   status.KindUnitAgent,
+			Status: status.Error,
+			Info:   "hook failed: \"db-relation-changed\"",
+			Data: map[string]interface{}{
+				"hook":        "db-relation-changed",
+				"relation-id": 3,
+				"remote-unit": "mysql/0",
+			},
+			Since: s.next(),
+		}, {
+			Kind:   status.KindWorkload,
+			Status: status.Active,
+			Since:  s.next(),
+		}},
+	}
+}
+
+func (s *StatusHistorySuite) TestShowData(c *gc.C) {
+	s.api = s.dataHistory()
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "wordpress/0", "--utc", "--show-data")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Time                  Type       Status  Message                             Data\n"+
+		"2017-11-28 12:34:56Z  juju-unit  error   hook failed: \"db-relation-changed\"  hook=db-relation-changed relation-id=3 remote-unit=mysql/0\n"+
+		"2017-11-28 12:35:56Z  workload   active                                      \n")
+}
+
+func (s *StatusHistorySuite) TestShowDataCSV(c *gc.C) {
+	s.api = s.dataHistory()
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "wordpress/0", "--format", "csv", "--show-data")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"time,type,status,message,data\n"+
+		"2017-11-28T12:34:56Z,juju-unit,error,\"hook failed: \"\"db-relation-changed\"\"\",\"{\"\"hook\"\":\"\"db-relation-changed\"\",\"\"relation-id\"\":3,\"\"remote-unit\"\":\"\"mysql/0\"\"}\"\n"+
+		"2017-11-28T12:35:56Z,workload,active,,\n")
+}
+
+func (s *StatusHistorySuite) TestAllReadsPages(c *gc.C) {
+	oldest := status.DetailedStatus{
+		Kind:   status.KindWorkload,
+		Status: status.Maintenance,
+		Info:   "oldest",
+		Since:  s.next(),
+	}
+	middle := status.DetailedStatus{
+		Kind:   status.KindWorkload,
+		Status: status.Waiting,
+		Info:   "middle",
+		Since:  s.next(),
+	}
+	newest := status.DetailedStatus{
+		Kind:   status.KindWorkload,
+		Status: status.Active,
+		Info:   "newest",
+		Since:  s.next(),
+	}
+	api := &fakeHistoryAPI{
+		pages: []status.History{{oldest, middle}, {newest}},
+	}
+	s.api = api
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "mysql/0", "--all", "--from-date", "2017-11-01", "--to-date", "2017-12-01", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Time                  Type      Status       Message\n"+
+		"2017-11-28 12:34:56Z  workload  maintenance  oldest\n"+
+		"2017-11-28 12:35:56Z  workload  waiting      middle\n"+
+		"2017-11-28 12:36:56Z  workload  active       newest\n")
+	from := time.Date(2017, 11, 1, 0, 0, 0, 0, time.UTC)
+	until := time.Date(2017, 12, 1, 0, 0, 0, 0, time.UTC)
+	c.Check(api.filter.Size, gc.Equals, 0)
+	c.Check(api.filter.FromDate, jc.DeepEquals, &from)
+	c.Check(api.filter.Until, jc.DeepEquals, &until)
+}
+
+func (s *StatusHistorySuite) TestInvalidArgs(c *gc.C) {
+	s.api = &fakeHistoryAPI{}
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"mysql/0", "--all", "-n", "10"},
+		err:  "--all and backlog size cannot be specified together",
+	}, {
+		args: []string{"mysql/0", "--to-date", "tomorrow"},
+		err:  `parsing until date: .*`,
+	}, {
+		args: []string{"mysql/0", "--from-date", "2017-11-28", "--to-date", "2017-11-28"},
+		err:  "until date must be after backlog date",
+	}, {
+		args: []string{"mysql", "--application", "--type", "machine"},
+		err:  `--application cannot be used with status type "machine"`,
+	}, {
+		args: []string{"mysql/0", "--application"},
+		err:  `"mysql/0" is not a valid name for an application`,
+	}} {
+		c.Logf("args: %v", test.args)
+		_, err := cmdtesting.RunCommand(c, s.newCommand(), test.args...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *StatusHistorySuite) pagedHistory() *fakeHistoryAPI {
+	return &fakeHistoryAPI{
+		pages: []status.History{{{
+			Kind:   status.KindWorkload,
+			Status: status.Maintenance,
+			Info:   "installing",
+			Since:  s.next(),
+		}}, {{
+			Kind:   status.KindWorkload,
+			Status: status.Active,
+			Info:   "ready",
+			Since:  s.next(),
+		}}},
+	}
+}
+
+func (s *StatusHistorySuite) TestAllCSV(c *gc.C) {
+	s.api = s.pagedHistory()
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "mysql/0", "--all", "--format", "csv")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"time,type,status,message\n"+
+		"2017-11-28T12:34:56Z,workload,maintenance,installing\n"+
+		"2017-11-28T12:35:56Z,workload,active,ready\n")
+}
+
+func (s *StatusHistorySuite) TestAllYAML(c *gc.C) {
+	s.api = s.pagedHistory()
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "mysql/0", "--all", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"- time: 2017-11-28T12:34:56Z\n"+
+		"  type: workload\n"+
+		"  status: maintenance\n"+
+		"  message: installing\n"+
+		"- time: 2017-11-28T12:35:56Z\n"+
+		"  type: workload\n"+
+		"  status: active\n"+
+		"  message: ready\n")
+}
+
+func (s *StatusHistorySuite) TestAllJSON(c *gc.C) {
+	s.api = s.pagedHistory()
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "mysql/0", "--all", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), jc.JSONEquals, []map[string]interface{}{{
+		"time":    "2017-11-28T12:34:56Z",
+		"type":    "workload",
+		"status":  "maintenance",
+		"message": "installing",
+	}, {
+		"time":    "2017-11-28T12:35:56Z",
+		"type":    "workload",
+		"status":  "active",
+		"message": "ready",
+	}})
+}
+
+func (s *StatusHistorySuite) TestAllWritesPagesReadBeforeError(c *gc.C) {
+	api := s.pagedHistory()
+	api.err = errors.New("connection lost")
+	s.api = api
+	ctx, err := cmdtesting.RunCommand(c, s.newCommand(), "mysql/0", "--all", "--format", "csv")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"time,type,status,message\n"+
+		"2017-11-28T12:34:56Z,workload,maintenance,installing\n"+
+		"2017-11-28T12:35:56Z,workload,active,ready\n")
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "connection lost\n")
+}
+
+func (s *StatusHistorySuite) TestAllNoHistory(c *gc.C) {
+	s.api = &fakeHistoryAPI{}
+	_, err := cmdtesting.RunCommand(c, s.newCommand(), "mysql/0", "--all", "--format", "json")
+	c.Assert(err, gc.ErrorMatches, "no status history available")
+}
+
 type fakeHistoryAPI struct {
 	err     error
 	history status.History
+	pages   []status.History
+	tag     names.Tag
+	filter  status.StatusHistoryFilter
 }
 
 func (*fakeHistoryAPI) Close() error {
@@ -119,5 +464,19 @@ func (*fakeHistoryAPI) Close() error {
 }
 
 func (f *fakeHistoryAPI) StatusHistory(kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter) (status.History, error) {
+	f.tag, f.filter = tag, filter
 	return f.history, f.err
 }
+
+func (f *fakeHistoryAPI) StatusHistoryPages(
+	kind status.HistoryKind, tag names.Tag, filter status.StatusHistoryFilter,
+	pageSize int, fn func(status.History) error,
+) error {
+	f.tag, f.filter = tag, filter
+	for _, page := range f.pages {
+		if err := fn(page); err != nil {
+			return err
+		}
+	}
+	return f.err
+}
@@ -0,0 +1,219 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/juju/ansiterm"
+	"github.com/juju/errors"
+	"github.com/juju/utils/naturalsort"
+
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/status"
+)
+
+// The columns of the units table which may be selected with --columns.
+const (
+	unitColumn     = "unit"
+	appColumn      = "app"
+	workloadColumn = "workload"
+	agentColumn    = "agent"
+	machineColumn  = "machine"
+	addressColumn  = "address"
+	portsColumn    = "ports"
+	messageColumn  = "message"
+	sinceColumn    = "since"
+)
+
+// unitColumns holds the names of the unit columns, in the order they
+// are listed to the user.
+var unitColumns = []string{
+	unitColumn,
+	appColumn,
+	workloadColumn,
+	agentColumn,
+	machineColumn,
+	addressColumn,
+	portsColumn,
+	messageColumn,
+	sinceColumn,
+}
+
+// defaultCompactColumns are the unit columns displayed by the compact
+// format when no columns are selected.
+var defaultCompactColumns = []string{
+	unitColumn,
+	workloadColumn,
+	agentColumn,
+	messageColumn,
+}
+
+// defaultTabularColumns returns the unit columns displayed by the
+// tabular format for a model of the given type when no columns are
+// selected.
+func defaultTabularColumns(modelType string) []string {
+	if modelType == caasModelType {
+		return []string{unitColumn, workloadColumn, agentColumn, addressColumn, portsColumn, messageColumn}
+	}
+	return []string{unitColumn, workloadColumn, agentColumn, machineColumn, addressColumn, portsColumn, messageColumn}
+}
+
+// parseColumns parses a comma separated list of unit column names.
+func parseColumns(value string) ([]string, error) {
+	var columns []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		if !isUnitColumn(name) {
+			return nil, errors.Errorf("unknown column %q, expected one of: %s",
+				name, strings.Join(unitColumns, ", "))
+		}
+		columns = append(columns, name)
+	}
+	if len(columns) == 0 {
+		return nil, errors.New("no columns specified")
+	}
+	return columns, nil
+}
+
+func isUnitColumn(name string) bool {
+	for _, column := range unitColumns {
+		if column == name {
+			return true
+		}
+	}
+	return false
+}
+
+func unitColumnHeader(column, modelType string) string {
+	switch column {
+	case addressColumn:
+		if modelType == caasModelType {
+			return "Address"
+		}
+		return "Public address"
+	case appColumn:
+		return "App"
+	}
+	return strings.ToUpper(column[:1]) + column[1:]
+}
+
+// formatCompact writes a table of the units in the model, and their
+// subordinates, showing only the given columns, or the default compact
+// columns if none are given. The other sections of the tabular format
+// are omitted, so that the status of large models fits on a terminal.
+func formatCompact(writer io.Writer, forceColor bool, columns []string, value interface{}) error {
+	fs, valueConverted := value.(formattedStatus)
+	if !valueConverted {
+		return errors.Errorf("expected value of type %T, got %T", fs, value)
+	}
+	if len(columns) == 0 {
+		columns = defaultCompactColumns
+	}
+
+	tw := output.TabWriter(writer)
+	if forceColor {
+		tw.SetColorCapable(forceColor)
+	}
+	units := make(map[string]unitStatus)
+	for _, app := range fs.Applications {
+		for name, u := range app.Units {
+			units[name] = u
+		}
+	}
+	if len(units) > 0 {
+		printUnits(tw, true, fs.Model.Type, units, columns)
+	}
+	return nil
+}
+
+// printUnits writes a section holding the given principal units, with
+// their subordinates indented beneath them, showing the given columns.
+func printUnits(tw *ansiterm.TabWriter, top bool, modelType string, units map[string]unitStatus, columns []string) {
+	headers := make([]interface{}, len(columns))
+	for i, column := range columns {
+		headers[i] = unitColumnHeader(column, modelType)
+	}
+	w := startSection(tw, top, headers...)
+
+	pUnit := func(name string, u unitStatus, level int) {
+		for i, column := range columns {
+			last := i == len(columns)-1
+			var value interface{}
+			switch column {
+			case unitColumn:
+				value = unitDisplayName(name, u, level)
+			case appColumn:
+				value = strings.Split(name, "/")[0]
+			case workloadColumn, agentColumn:
+				current := u.WorkloadStatusInfo.Current
+				if column == agentColumn {
+					current = u.JujuStatusInfo.Current
+				}
+				w.PrintStatus(current)
+				if last {
+					w.Println()
+				}
+				continue
+			case machineColumn:
+				value = u.Machine
+			case addressColumn:
+				value = u.PublicAddress
+				if modelType == caasModelType {
+					value = u.Address
+				}
+			case portsColumn:
+				value = strings.Join(u.OpenedPorts, ",")
+			case messageColumn:
+				value = unitMessage(u)
+			case sinceColumn:
+				value = u.WorkloadStatusInfo.Since
+			}
+			if last {
+				w.Println(value)
+			} else {
+				w.Print(value)
+			}
+		}
+	}
+
+	for _, name := range naturalsort.Sort(stringKeysFromMap(units)) {
+		u := units[name]
+		pUnit(name, u, 0)
+		const indentationLevel = 1
+		recurseUnits(u, indentationLevel, pUnit)
+	}
+	endSection(tw)
+}
+
+// unitDisplayName returns the name of the unit as displayed in the
+// units table, marking the leader and any branch the unit tracks.
+func unitDisplayName(name string, u unitStatus, level int) string {
+	if u.Leader {
+		name += "*"
+	}
+	if u.Branch != "" {
+		name += " " + u.Branch
+	}
+	return indent("", level*2, name)
+}
+
+// unitMessage returns the message displayed for the unit, which is the
+// workload message prefixed with what the agent is doing.
+func unitMessage(u unitStatus) string {
+	message := u.WorkloadStatusInfo.Message
+	// If we're still allocating and there's a message, show that.
+	if u.JujuStatusInfo.Current == status.Allocating && message == "" {
+		message = u.JujuStatusInfo.Message
+	}
+	if agentDoing := agentDoing(u.JujuStatusInfo); agentDoing != "" {
+		message = fmt.Sprintf("(%s) %s", agentDoing, message)
+	}
+	return message
+}
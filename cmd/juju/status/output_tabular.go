@@ -36,6 +36,13 @@ const (
 // units. Any subordinate items are indented by two spaces beneath
 // their superior.
 func FormatTabular(writer io.Writer, forceColor bool, value interface{}) error {
+	return formatTabular(writer, forceColor, nil, value)
+}
+
+// formatTabular writes a tabular summary as FormatTabular does, showing
+// the given columns in the units table, or the default columns for the
+// model type if none are given.
+func formatTabular(writer io.Writer, forceColor bool, columns []string, value interface{}) error {
 	fs, valueConverted := value.(formattedStatus)
 	if !valueConverted {
 		return errors.Errorf("expected value of type %T, got %T", fs, value)
@@ -84,7 +91,7 @@ func FormatTabular(writer io.Writer, forceColor bool, value interface{}) error {
 	}
 
 	if len(fs.Applications) > 0 {
-		printApplications(tw, fs)
+		printApplications(tw, fs, columns)
 	}
 
 	if fs.Model.Type != caasModelType && len(fs.Machines) > 0 {
@@ -120,7 +127,7 @@ func endSection(tw *ansiterm.TabWriter) {
 	tw.Flush()
 }
 
-func printApplications(tw *ansiterm.TabWriter, fs formattedStatus) {
+func printApplications(tw *ansiterm.TabWriter, fs formattedStatus, columns []string) {
 	maxVersionWidth := iaasMaxVersionWidth
 	if fs.Model.Type == caasModelType {
 		maxVersionWidth = caasMaxVersionWidth
@@ -189,54 +196,11 @@ func printApplications(tw *ansiterm.TabWriter, fs formattedStatus) {
 	}
 	endSection(tw)
 
-	pUnit := func(name string, u unitStatus, level int) {
-		message := u.WorkloadStatusInfo.Message
-		// If we're still allocating and there's a message, show that.
-		if u.JujuStatusInfo.Current == status.Allocating && message == "" {
-			message = u.JujuStatusInfo.Message
-		}
-		agentDoing := agentDoing(u.JujuStatusInfo)
-		if agentDoing != "" {
-			message = fmt.Sprintf("(%s) %s", agentDoing, message)
-		}
-		if u.Leader {
-			name += "*"
-		}
-		if u.Branch != "" {
-			name += " " + u.Branch
-		}
-		w.Print(indent("", level*2, name))
-		w.PrintStatus(u.WorkloadStatusInfo.Current)
-		w.PrintStatus(u.JujuStatusInfo.Current)
-		if fs.Model.Type == caasModelType {
-			w.Println(
-				u.Address,
-				strings.Join(u.OpenedPorts, ","),
-				message,
-			)
-			return
-		}
-		w.Println(
-			u.Machine,
-			u.PublicAddress,
-			strings.Join(u.OpenedPorts, ","),
-			message,
-		)
-	}
-
 	if len(units) > 0 {
-		if fs.Model.Type == caasModelType {
-			startSection(tw, false, "Unit", "Workload", "Agent", "Address", "Ports", "Message")
-		} else {
-			startSection(tw, false, "Unit", "Workload", "Agent", "Machine", "Public address", "Ports", "Message")
-		}
-		for _, name := range naturalsort.Sort(stringKeysFromMap(units)) {
-			u := units[name]
-			pUnit(name, u, 0)
-			const indentationLevel = 1
-			recurseUnits(u, indentationLevel, pUnit)
+		if len(columns) == 0 {
+			columns = defaultTabularColumns(fs.Model.Type)
 		}
-		endSection(tw)
+		printUnits(tw, false, fs.Model.Type, units, columns)
 	}
 
 	if !metering {
@@ -18,9 +18,11 @@ import (
 	"github.com/juju/gnuflag"
 	"github.com/juju/loggo"
 
+	"github.com/juju/juju/api/statussnapshot"
 	storageapi "github.com/juju/juju/api/storage"
 	"github.com/juju/juju/apiserver/params"
 	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/juju/storage"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/juju/osenv"
@@ -33,6 +35,11 @@ type statusAPI interface {
 	Close() error
 }
 
+type statusSnapshotAPI interface {
+	StatusSnapshot(at time.Time) (params.StatusSnapshotResult, error)
+	Close() error
+}
+
 // NewStatusCommand returns a new command, which reports on the
 // runtime state of various system entities.
 func NewStatusCommand() cmd.Command {
@@ -55,6 +62,8 @@ type statusCommand struct {
 	storageAPI storage.StorageListAPI
 	clock      Clock
 
+	snapshotAPI statusSnapshotAPI
+
 	retryCount int
 	retryDelay time.Duration
 
@@ -68,6 +77,16 @@ type statusCommand struct {
 
 	// storage indicates if 'storage' section is displayed
 	storage bool
+
+	// columns holds the comma separated unit columns to display,
+	// as given to --columns, and unitColumns holds them parsed.
+	columns     string
+	unitColumns []string
+
+	// at holds the past time to show the status of the model at, as
+	// given to --at, and atTime holds it parsed.
+	at     string
+	atTime time.Time
 }
 
 var usageSummary = `
@@ -94,6 +113,9 @@ The available output formats are:
 	  and units.
       Note: in this format, the AZ column refers to the cloud region's
       availability zone.
+- compact: Displays only a table of units and their subordinates, with the
+      unit, workload, agent and message columns. This is useful for fitting
+      the status of large models on a terminal.
 - {short|line|oneline}: List units and their subordinates. For each unit, the IP
       address and agent status are listed.
 - summary: Displays the subnet(s) and port(s) the model utilises. Also displays
@@ -110,12 +132,29 @@ In tabular format, 'Relations' section is not displayed by default.
 Use --relations option to see this section. This option is ignored in all other
 formats.
 
+With --at, the approximate status of the model at a past time is shown, for
+analysing what happened during an incident. The status of each machine,
+application and unit is the latest recorded in status history at that time.
+Entities removed since then are not shown, and charms and placement are shown
+as they are now. For model administrators, the commands run against the model
+in the day after then are listed after the status. They are read from the
+audit log of the controller node serving the request, so with a highly
+available controller, commands handled by other nodes are not listed. The time may be given as "YYYY-MM-DD HH:MM[:SS]" in local time, or in
+RFC3339 format.
+
+In the tabular and compact formats, the columns of the units table may be
+chosen with the --columns option, which takes a comma separated list of:
+unit, app, workload, agent, machine, address, ports, message and since.
+
 Examples:
     juju show-status
     juju show-status mysql
     juju show-status nova-*
     juju show-status --relations
     juju show-status --storage
+    juju show-status --format compact
+    juju show-status --columns unit,workload,message
+    juju show-status --at "2020-01-03 14:00"
 
 See also:
     machines
@@ -141,6 +180,8 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 
 	f.BoolVar(&c.relations, "relations", false, "Show 'relations' section")
 	f.BoolVar(&c.storage, "storage", false, "Show 'storage' section")
+	f.StringVar(&c.columns, "columns", "", "Comma separated unit columns to show in tabular and compact formats")
+	f.StringVar(&c.at, "at", "", "Show the approximate status of the model at a past time")
 
 	f.IntVar(&c.retryCount, "retry-count", 3, "Number of times to retry API failures")
 	f.DurationVar(&c.retryDelay, "retry-delay", 100*time.Millisecond, "Time to wait between retry attempts")
@@ -169,6 +210,7 @@ func (c *statusCommand) SetFlags(f *gnuflag.FlagSet) {
 		"oneline": FormatOneline,
 		"line":    FormatOneline,
 		"tabular": c.FormatTabular,
+		"compact": c.FormatCompact,
 		"summary": FormatSummary,
 	})
 }
@@ -186,12 +228,64 @@ func (c *statusCommand) Init(args []string) error {
 			}
 		}
 	}
+	if c.columns != "" {
+		if !isTabularFormat(c.out.Name()) {
+			return errors.Errorf("--columns is only supported by the tabular and compact formats")
+		}
+		columns, err := parseColumns(c.columns)
+		if err != nil {
+			return errors.Annotate(err, "invalid --columns")
+		}
+		c.unitColumns = columns
+	}
+	if c.at != "" {
+		if len(c.patterns) > 0 {
+			return errors.New("filter patterns cannot be used with --at")
+		}
+		at, err := parseStatusTime(c.at)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if at.After(time.Now()) {
+			return errors.Errorf("--at time %q is in the future", c.at)
+		}
+		c.atTime = at
+	}
 	if c.clock == nil {
 		c.clock = clock.WallClock
 	}
 	return nil
 }
 
+// statusTimeLayouts are the layouts accepted for the time given to
+// --at, other than RFC3339. Times in these layouts are local.
+var statusTimeLayouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02",
+}
+
+// parseStatusTime parses the time given to --at.
+func parseStatusTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	for _, layout := range statusTimeLayouts {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.Errorf(`invalid --at time %q, expected "YYYY-MM-DD HH:MM[:SS]" or RFC3339`, value)
+}
+
+// isTabularFormat reports whether the output format with the given name
+// is rendered as tables for a terminal.
+func isTabularFormat(name string) bool {
+	return name == "tabular" || name == "compact"
+}
+
 var newAPIClientForStatus = func(c *statusCommand) (statusAPI, error) {
 	if c.statusAPI == nil {
 		api, err := c.NewAPIClient()
@@ -214,6 +308,17 @@ var newAPIClientForStorage = func(c *statusCommand) (storage.StorageListAPI, err
 	return c.storageAPI, nil
 }
 
+var newAPIClientForStatusSnapshot = func(c *statusCommand) (statusSnapshotAPI, error) {
+	if c.snapshotAPI == nil {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		c.snapshotAPI = statussnapshot.NewClient(root)
+	}
+	return c.snapshotAPI, nil
+}
+
 func (c *statusCommand) close() {
 	// We really don't care what the errors are if there are some.
 	// The user can't do anything about it.  Just try.
@@ -223,6 +328,9 @@ func (c *statusCommand) close() {
 	if c.storageAPI != nil {
 		c.storageAPI.Close()
 	}
+	if c.snapshotAPI != nil {
+		c.snapshotAPI.Close()
+	}
 	return
 }
 
@@ -253,6 +361,10 @@ func (c *statusCommand) getStorageInfo(ctx *cmd.Context) (*storage.CombinedStora
 func (c *statusCommand) Run(ctx *cmd.Context) error {
 	defer c.close()
 
+	if !c.atTime.IsZero() {
+		return errors.Trace(c.runSnapshot(ctx))
+	}
+
 	// Always attempt to get the status at least once, and retry if it fails.
 	status, err := c.getStatus()
 	if err != nil && !modelcmd.IsModelMigratedError(err) {
@@ -290,7 +402,11 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 
 	showRelations := c.relations
 	showStorage := c.storage
-	if c.out.Name() != "tabular" {
+	if c.out.Name() == "compact" {
+		// The compact format only displays units.
+		showRelations = false
+		showStorage = false
+	} else if c.out.Name() != "tabular" {
 		showRelations = true
 		showStorage = true
 		providedIgnoredFlags := c.checkProvidedIgnoredFlagF()
@@ -358,5 +474,50 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 }
 
 func (c *statusCommand) FormatTabular(writer io.Writer, value interface{}) error {
-	return FormatTabular(writer, c.color, value)
+	return formatTabular(writer, c.color, c.unitColumns, value)
+}
+
+func (c *statusCommand) FormatCompact(writer io.Writer, value interface{}) error {
+	return formatCompact(writer, c.color, c.unitColumns, value)
+}
+
+// runSnapshot writes the approximate status of the model at the time
+// given to --at, followed by the commands run against the model since.
+func (c *statusCommand) runSnapshot(ctx *cmd.Context) error {
+	api, err := newAPIClientForStatusSnapshot(c)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	result, err := api.StatusSnapshot(c.atTime)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	formatted, err := newStatusFormatter(newStatusFormatterParams{
+		status:         &result.Status,
+		controllerName: controllerName,
+		outputName:     c.out.Name(),
+		isoTime:        c.isoTime,
+	}).format()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	at := common.FormatTime(&c.atTime, c.isoTime)
+	ctx.Infof("Approximate status at %s, reconstructed from status history.", at)
+	if err := c.out.Write(ctx, formatted); err != nil {
+		return err
+	}
+	if len(result.Commands) == 0 {
+		return nil
+	}
+	ctx.Infof("\nCommands run against the model in the day after %s, as recorded by the controller node serving this request:", at)
+	for _, command := range result.Commands {
+		when := command.When
+		ctx.Infof("  %s  %s  %s", common.FormatTime(&when, c.isoTime), command.Who, command.What)
+	}
+	return nil
 }
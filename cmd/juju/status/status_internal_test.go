@@ -5160,6 +5160,141 @@ foo/1  maintenance  executing                                  (backup database)
 `[1:])
 }
 
+func (s *StatusSuite) TestFormatTabularColumns(c *gc.C) {
+	status := formattedStatus{
+		Applications: map[string]applicationStatus{
+			"foo": {
+				Units: map[string]unitStatus{
+					"foo/0": {
+						JujuStatusInfo: statusInfoContents{
+							Current: status.Executing,
+							Message: "running config-changed hook",
+						},
+						WorkloadStatusInfo: statusInfoContents{
+							Current: status.Maintenance,
+							Message: "doing some work",
+						},
+					},
+					"foo/1": {
+						JujuStatusInfo: statusInfoContents{
+							Current: status.Executing,
+							Message: "running action backup database",
+						},
+						WorkloadStatusInfo: statusInfoContents{
+							Current: status.Maintenance,
+							Message: "doing some work",
+						},
+					},
+				},
+			},
+		},
+	}
+	out := &bytes.Buffer{}
+	err := formatTabular(out, false, []string{"unit", "message"}, status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Equals, `
+Model  Controller  Cloud/Region  Version
+                                 
+
+App  Version  Status  Scale  Charm  Store  Rev  OS  Notes
+foo                       2                  0      
+
+Unit   Message
+foo/0  (config-changed) doing some work
+foo/1  (backup database) doing some work
+`[1:])
+}
+
+func (s *StatusSuite) TestFormatCompact(c *gc.C) {
+	status := formattedStatus{
+		Applications: map[string]applicationStatus{
+			"foo": {
+				Units: map[string]unitStatus{
+					"foo/0": {
+						Leader:        true,
+						Machine:       "0",
+						PublicAddress: "10.0.0.1",
+						JujuStatusInfo: statusInfoContents{
+							Current: status.Idle,
+						},
+						WorkloadStatusInfo: statusInfoContents{
+							Current: status.Active,
+							Message: "ready",
+						},
+						Subordinates: map[string]unitStatus{
+							"logging/0": {
+								JujuStatusInfo: statusInfoContents{
+									Current: status.Idle,
+								},
+								WorkloadStatusInfo: statusInfoContents{
+									Current: status.Active,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	out := &bytes.Buffer{}
+	err := formatCompact(out, false, nil, status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Equals, `
+Unit         Workload  Agent  Message
+foo/0*       active    idle   ready
+  logging/0  active    idle   
+`[1:])
+}
+
+func (s *StatusSuite) TestFormatCompactColumnsCAASModel(c *gc.C) {
+	status := formattedStatus{
+		Model: modelStatus{
+			Type: "caas",
+		},
+		Applications: map[string]applicationStatus{
+			"foo": {
+				Units: map[string]unitStatus{
+					"foo/0": {
+						Address: "10.0.0.1",
+					},
+					"foo/1": {},
+				},
+			},
+		},
+	}
+	out := &bytes.Buffer{}
+	err := formatCompact(out, false, []string{"app", "unit", "address"}, status)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.String(), gc.Equals, `
+App  Unit   Address
+foo  foo/0  10.0.0.1
+foo  foo/1  
+`[1:])
+}
+
+func (s *StatusSuite) TestStatusWithFormatCompact(c *gc.C) {
+	ctx := s.prepareTabularData(c)
+	defer s.resetContext(c, ctx)
+	code, stdout, stderr := runStatus(c, "--format", "compact", "--relations")
+	c.Check(code, gc.Equals, 0)
+	c.Check(string(stderr), gc.Equals, "")
+	c.Assert(strings.HasPrefix(string(stdout), "Unit "), jc.IsTrue)
+	c.Assert(string(stdout), gc.Not(jc.Contains), "Relation provider")
+}
+
+func (s *StatusSuite) TestStatusColumnsUnknown(c *gc.C) {
+	code, _, stderr := runStatus(c, "--columns", "unit,bogus")
+	c.Check(code, gc.Equals, 2)
+	c.Check(string(stderr), gc.Equals, `ERROR invalid --columns: unknown column "bogus", expected one of: `+
+		"unit, app, workload, agent, machine, address, ports, message, since\n")
+}
+
+func (s *StatusSuite) TestStatusColumnsUnsupportedFormat(c *gc.C) {
+	code, _, stderr := runStatus(c, "--format", "yaml", "--columns", "unit")
+	c.Check(code, gc.Equals, 2)
+	c.Check(string(stderr), gc.Equals, "ERROR --columns is only supported by the tabular and compact formats\n")
+}
+
 func (s *StatusSuite) TestFormatTabularCAASModel(c *gc.C) {
 	status := formattedStatus{
 		Model: modelStatus{
@@ -130,6 +130,92 @@ func (s *MinimalStatusSuite) TestRetryCountOfZero(c *gc.C) {
 	c.Assert(s.clock.waits, gc.HasLen, 0)
 }
 
+func (s *MinimalStatusSuite) runStatusAt(c *gc.C, api *fakeStatusSnapshotAPI, args ...string) (*cmd.Context, error) {
+	statusCmd := status.NewTestStatusSnapshotCommand(api)
+	return cmdtesting.RunCommand(c, statusCmd, args...)
+}
+
+func (s *MinimalStatusSuite) newSnapshotAPI() *fakeStatusSnapshotAPI {
+	return &fakeStatusSnapshotAPI{
+		result: params.StatusSnapshotResult{
+			Status: params.FullStatus{
+				Model: params.ModelStatusInfo{
+					Name:     "test",
+					CloudTag: "cloud-foo",
+				},
+				Applications: map[string]params.ApplicationStatus{
+					"mysql": {
+						Units: map[string]params.UnitStatus{
+							"mysql/0": {
+								AgentStatus:    params.DetailedStatus{Status: "idle"},
+								WorkloadStatus: params.DetailedStatus{Status: "blocked", Info: "need a relation"},
+							},
+						},
+					},
+				},
+			},
+			Commands: []params.AuditedCommand{{
+				Who:  "bob",
+				What: "juju remove-unit mysql/1",
+				When: time.Date(2020, 1, 3, 14, 30, 0, 0, time.UTC),
+			}},
+		},
+	}
+}
+
+func (s *MinimalStatusSuite) TestStatusAt(c *gc.C) {
+	api := s.newSnapshotAPI()
+	ctx, err := s.runStatusAt(c, api, "--at", "2020-01-03T14:00:00Z", "--format", "compact", "--utc")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api.at, gc.Equals, time.Date(2020, 1, 3, 14, 0, 0, 0, time.UTC))
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Unit     Workload  Agent  Message
+mysql/0  blocked   idle   need a relation
+`[1:])
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+Approximate status at 2020-01-03 14:00:00Z, reconstructed from status history.
+
+Commands run against the model in the day after 2020-01-03 14:00:00Z, as recorded by the controller node serving this request:
+  2020-01-03 14:30:00Z  bob  juju remove-unit mysql/1
+`[1:])
+}
+
+func (s *MinimalStatusSuite) TestStatusAtLocalTime(c *gc.C) {
+	api := s.newSnapshotAPI()
+	_, err := s.runStatusAt(c, api, "--at", "2020-01-03 14:00")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(api.at.Equal(time.Date(2020, 1, 3, 14, 0, 0, 0, time.Local)), jc.IsTrue)
+}
+
+func (s *MinimalStatusSuite) TestStatusAtInvalidTime(c *gc.C) {
+	_, err := s.runStatusAt(c, s.newSnapshotAPI(), "--at", "yesterday")
+	c.Assert(err, gc.ErrorMatches, `invalid --at time "yesterday", expected "YYYY-MM-DD HH:MM\[:SS\]" or RFC3339`)
+}
+
+func (s *MinimalStatusSuite) TestStatusAtFutureTime(c *gc.C) {
+	_, err := s.runStatusAt(c, s.newSnapshotAPI(), "--at", "2999-01-01")
+	c.Assert(err, gc.ErrorMatches, `--at time "2999-01-01" is in the future`)
+}
+
+func (s *MinimalStatusSuite) TestStatusAtWithPatterns(c *gc.C) {
+	_, err := s.runStatusAt(c, s.newSnapshotAPI(), "--at", "2020-01-03 14:00", "mysql")
+	c.Assert(err, gc.ErrorMatches, "filter patterns cannot be used with --at")
+}
+
+type fakeStatusSnapshotAPI struct {
+	at     time.Time
+	result params.StatusSnapshotResult
+}
+
+func (f *fakeStatusSnapshotAPI) StatusSnapshot(at time.Time) (params.StatusSnapshotResult, error) {
+	f.at = at
+	return f.result, nil
+}
+
+func (*fakeStatusSnapshotAPI) Close() error {
+	return nil
+}
+
 type fakeStatusAPI struct {
 	result *params.FullStatus
 	errors []error
@@ -0,0 +1,33 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package zones
+
+import (
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+const UnitPollInterval = unitPollInterval
+
+func NewZonesCommandForTest(
+	store jujuclient.ClientStore, api ZonesAPI, appAPI ApplicationAPI, statusAPI StatusAPI, clock clock.Clock,
+) cmd.Command {
+	c := &zonesCommand{
+		newAPIFunc: func() (ZonesAPI, error) {
+			return api, nil
+		},
+		newApplicationAPIFunc: func() (ApplicationAPI, error) {
+			return appAPI, nil
+		},
+		newStatusAPIFunc: func() (StatusAPI, error) {
+			return statusAPI, nil
+		},
+		clock: clock,
+	}
+	c.SetClientStore(store)
+	return modelcmd.Wrap(c)
+}
@@ -0,0 +1,414 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package zones
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/api/zones"
+	"github.com/juju/juju/apiserver/params"
+	jujucmd "github.com/juju/juju/cmd"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/status"
+)
+
+const zonesDoc = `
+List the availability zones of the model, with whether each zone is
+currently available and how many units it hosts.
+
+With --report, also show how the units of each application are spread
+across the zones. An application whose units are concentrated in too
+few zones (for example after recovering from the loss of a zone) is
+reported as unbalanced, along with the add-unit and remove-unit steps
+that would restore an even spread of its units.
+
+With --apply, perform the suggested steps: for each unit to be moved,
+a replacement unit is added in the target zone, and the unit is only
+removed once its replacement has started and its agent is idle. If the
+replacement fails, or doesn't become idle within --max-wait, the
+rebalance stops and the unit is kept. Units whose machines have not
+been provisioned are not taken into account.
+
+Examples:
+    juju zones
+    juju zones --report
+    juju zones --report --format yaml
+    juju zones --apply
+    juju zones --apply --max-wait 1h
+
+See also:
+    add-unit
+    remove-unit
+`
+
+// ZonesAPI defines the API methods used by the zones command.
+type ZonesAPI interface {
+	ZoneReport() (params.ZoneReport, error)
+	Close() error
+}
+
+// ApplicationAPI defines the API methods used by the zones command
+// to rebalance units.
+type ApplicationAPI interface {
+	ModelUUID() string
+	AddUnits(application.AddUnitsParams) ([]string, error)
+	DestroyUnits(application.DestroyUnitsParams) ([]params.DestroyUnitResult, error)
+	Close() error
+}
+
+// StatusAPI defines the API methods used by the zones command to
+// wait for replacement units to start.
+type StatusAPI interface {
+	Status(patterns []string) (*params.FullStatus, error)
+	Close() error
+}
+
+const (
+	// defaultMaxWait is how long a rebalance waits, by default, for
+	// each replacement unit to start.
+	defaultMaxWait = 30 * time.Minute
+
+	// unitPollInterval is how often a rebalance checks whether a
+	// replacement unit has started.
+	unitPollInterval = 5 * time.Second
+)
+
+// NewZonesCommand returns a command that reports on the spread of
+// units across availability zones.
+func NewZonesCommand() cmd.Command {
+	c := &zonesCommand{}
+	c.newAPIFunc = func() (ZonesAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return zones.NewClient(root), nil
+	}
+	c.newApplicationAPIFunc = func() (ApplicationAPI, error) {
+		root, err := c.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	c.newStatusAPIFunc = func() (StatusAPI, error) {
+		return c.NewAPIClient()
+	}
+	c.clock = clock.WallClock
+	return modelcmd.Wrap(c)
+}
+
+type zonesCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+
+	report  bool
+	apply   bool
+	maxWait time.Duration
+
+	newAPIFunc            func() (ZonesAPI, error)
+	newApplicationAPIFunc func() (ApplicationAPI, error)
+	newStatusAPIFunc      func() (StatusAPI, error)
+	clock                 clock.Clock
+}
+
+// Info implements Command.Info.
+func (c *zonesCommand) Info() *cmd.Info {
+	return jujucmd.Info(&cmd.Info{
+		Name:    "zones",
+		Purpose: "Lists availability zones and reports on the spread of units across them.",
+		Doc:     zonesDoc,
+	})
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *zonesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.report, "report", false, "Report the spread of each application's units across zones")
+	f.BoolVar(&c.apply, "apply", false, "Add and remove units to restore an even spread across zones")
+	f.DurationVar(&c.maxWait, "max-wait", defaultMaxWait, "How long --apply waits for each replacement unit to start")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatZonesTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *zonesCommand) Init(args []string) error {
+	if c.apply {
+		c.report = true
+	}
+	if c.maxWait <= 0 {
+		return errors.NotValidf("non-positive --max-wait")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// zonesOutput is the serialisation format for the zones command.
+type zonesOutput struct {
+	Zones        []zoneInfo          `yaml:"zones" json:"zones"`
+	Applications []applicationSpread `yaml:"applications,omitempty" json:"applications,omitempty"`
+}
+
+type zoneInfo struct {
+	Name      string `yaml:"name" json:"name"`
+	Available bool   `yaml:"available" json:"available"`
+	Units     int    `yaml:"units" json:"units"`
+}
+
+type applicationSpread struct {
+	Application string              `yaml:"application" json:"application"`
+	Units       map[string][]string `yaml:"units" json:"units"`
+	Balanced    bool                `yaml:"balanced" json:"balanced"`
+	Moves       []unitMove          `yaml:"moves,omitempty" json:"moves,omitempty"`
+}
+
+type unitMove struct {
+	Unit string `yaml:"unit" json:"unit"`
+	From string `yaml:"from" json:"from"`
+	To   string `yaml:"to" json:"to"`
+}
+
+// Run implements Command.Run.
+func (c *zonesCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	report, err := client.ZoneReport()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(report.Zones) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No availability zones are known in this model.")
+		return nil
+	}
+
+	out := zonesOutput{}
+	for _, zone := range report.Zones {
+		info := zoneInfo{Name: zone.Name, Available: zone.Available}
+		for _, app := range report.Applications {
+			info.Units += len(app.Units[zone.Name])
+		}
+		out.Zones = append(out.Zones, info)
+	}
+	if c.report {
+		for _, app := range report.Applications {
+			spread := applicationSpread{
+				Application: app.Application,
+				Units:       app.Units,
+				Balanced:    app.Balanced,
+			}
+			for _, move := range app.Moves {
+				spread.Moves = append(spread.Moves, unitMove(move))
+			}
+			out.Applications = append(out.Applications, spread)
+		}
+	}
+	if err := c.out.Write(ctx, out); err != nil {
+		return errors.Trace(err)
+	}
+	if !c.apply {
+		return nil
+	}
+	return errors.Trace(c.rebalance(ctx, report.Applications))
+}
+
+// rebalance performs the suggested unit moves, waiting for each
+// replacement unit to start before removing the unit it replaces.
+func (c *zonesCommand) rebalance(ctx *cmd.Context, apps []params.ApplicationZoneSpread) error {
+	var moves []params.UnitZoneMove
+	for _, app := range apps {
+		moves = append(moves, app.Moves...)
+	}
+	if len(moves) == 0 {
+		ctx.Infof("All applications are evenly spread across the available zones.")
+		return nil
+	}
+
+	client, err := c.newApplicationAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	statusClient, err := c.newStatusAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer statusClient.Close()
+
+	for _, move := range moves {
+		appName := strings.Split(move.Unit, "/")[0]
+		added, err := client.AddUnits(application.AddUnitsParams{
+			ApplicationName: appName,
+			NumUnits:        1,
+			Placement: []*instance.Placement{{
+				Scope:     client.ModelUUID(),
+				Directive: "zone=" + move.To,
+			}},
+		})
+		if err != nil {
+			return block.ProcessBlockedError(
+				errors.Annotatef(err, "adding unit of %q in zone %q", appName, move.To), block.BlockChange,
+			)
+		}
+		ctx.Infof("added unit %s in zone %s", strings.Join(added, ", "), move.To)
+		if err := c.waitForUnits(statusClient, appName, added); err != nil {
+			return errors.Annotatef(err, "not removing unit %q", move.Unit)
+		}
+
+		results, err := client.DestroyUnits(application.DestroyUnitsParams{
+			Units: []string{move.Unit},
+		})
+		if err == nil && len(results) == 1 && results[0].Error != nil {
+			err = results[0].Error
+		}
+		if err != nil {
+			return block.ProcessBlockedError(
+				errors.Annotatef(err, "removing unit %q", move.Unit), block.BlockRemove,
+			)
+		}
+		ctx.Infof("removing unit %s from zone %s", move.Unit, move.From)
+	}
+	return nil
+}
+
+// waitForUnits waits until each of the given units of the application
+// has started and its agent is idle. It fails if any of the units
+// reports an error, or if they aren't all idle within c.maxWait.
+func (c *zonesCommand) waitForUnits(client StatusAPI, appName string, units []string) error {
+	timeout := c.clock.After(c.maxWait)
+	for {
+		pending, err := pendingUnits(client, appName, units)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		select {
+		case <-c.clock.After(unitPollInterval):
+		case <-timeout:
+			return errors.Errorf(
+				"timed out after %v waiting for unit %s to start",
+				c.maxWait, strings.Join(pending, ", "),
+			)
+		}
+	}
+}
+
+// pendingUnits returns those of the given units of the application
+// whose agents are not yet idle, or an error if any of them has failed.
+func pendingUnits(client StatusAPI, appName string, units []string) ([]string, error) {
+	fullStatus, err := client.Status(units)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting unit status")
+	}
+	app := fullStatus.Applications[appName]
+	var pending []string
+	for _, unit := range units {
+		unitStatus, ok := app.Units[unit]
+		if !ok {
+			pending = append(pending, unit)
+			continue
+		}
+		for _, s := range []params.DetailedStatus{unitStatus.AgentStatus, unitStatus.WorkloadStatus} {
+			if status.Status(s.Status) == status.Error {
+				return nil, errors.Errorf("unit %s failed: %s", unit, s.Info)
+			}
+		}
+		if status.Status(unitStatus.AgentStatus.Status) != status.Idle {
+			pending = append(pending, unit)
+		}
+	}
+	return pending, nil
+}
+
+func formatZonesTabular(writer io.Writer, value interface{}) error {
+	out, ok := value.(zonesOutput)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", out, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Zone", "Status", "Units")
+	for _, zone := range out.Zones {
+		status := "available"
+		if !zone.Available {
+			status = "unavailable"
+		}
+		w.Println(zone.Name, status, zone.Units)
+	}
+	if len(out.Applications) == 0 {
+		tw.Flush()
+		return nil
+	}
+
+	w.Println()
+	w.Println("Application", "Spread", "Balanced")
+	var steps []string
+	for _, app := range out.Applications {
+		balanced := "yes"
+		if !app.Balanced {
+			balanced = "no"
+		}
+		w.Println(app.Application, formatSpread(out.Zones, app.Units), balanced)
+		for _, move := range app.Moves {
+			steps = append(steps,
+				fmt.Sprintf("juju add-unit %s --to zone=%s", app.Application, move.To),
+				fmt.Sprintf("juju remove-unit %s", move.Unit),
+			)
+		}
+	}
+	tw.Flush()
+	if len(steps) > 0 {
+		fmt.Fprintln(writer)
+		fmt.Fprintln(writer, "Suggested steps:")
+		for _, step := range steps {
+			fmt.Fprintf(writer, "  %s\n", step)
+		}
+	}
+	return nil
+}
+
+// formatSpread returns the number of units in each available zone,
+// and in any unavailable zone still hosting units.
+func formatSpread(zones []zoneInfo, units map[string][]string) string {
+	var spread []string
+	known := make(map[string]bool)
+	for _, zone := range zones {
+		known[zone.Name] = true
+		if zone.Available || len(units[zone.Name]) > 0 {
+			spread = append(spread, fmt.Sprintf("%s:%d", zone.Name, len(units[zone.Name])))
+		}
+	}
+	var unknown []string
+	for zone := range units {
+		if !known[zone] {
+			unknown = append(unknown, zone)
+		}
+	}
+	sort.Strings(unknown)
+	for _, zone := range unknown {
+		spread = append(spread, fmt.Sprintf("%s:%d", zone, len(units[zone])))
+	}
+	return strings.Join(spread, " ")
+}
@@ -0,0 +1,312 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package zones_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/zones"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/jujuclient/jujuclienttesting"
+	"github.com/juju/juju/testing"
+)
+
+type zonesSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+
+	api       *fakeZonesAPI
+	appAPI    *fakeApplicationAPI
+	statusAPI *fakeStatusAPI
+	clock     *testclock.Clock
+}
+
+var _ = gc.Suite(&zonesSuite{})
+
+func (s *zonesSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.api = &fakeZonesAPI{
+		report: params.ZoneReport{
+			Zones: []params.ZoneResult{
+				{Name: "zone-a", Available: true},
+				{Name: "zone-b", Available: false},
+				{Name: "zone-c", Available: true},
+			},
+			Applications: []params.ApplicationZoneSpread{{
+				Application: "mysql",
+				Units: map[string][]string{
+					"zone-a": {"mysql/0", "mysql/1"},
+					"zone-b": {"mysql/2"},
+				},
+				Moves: []params.UnitZoneMove{
+					{Unit: "mysql/2", From: "zone-b", To: "zone-c"},
+				},
+			}, {
+				Application: "redis",
+				Units: map[string][]string{
+					"zone-a": {"redis/0"},
+					"zone-c": {"redis/1"},
+				},
+				Balanced: true,
+			}},
+		},
+	}
+	s.appAPI = &fakeApplicationAPI{added: []string{"mysql/3"}}
+	s.statusAPI = &fakeStatusAPI{agentStatuses: []string{"idle"}}
+	s.clock = testclock.NewClock(time.Now())
+}
+
+func (s *zonesSuite) runZones(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := zones.NewZonesCommandForTest(jujuclienttesting.MinimalStore(), s.api, s.appAPI, s.statusAPI, s.clock)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+// runZonesAdvancing runs the command, advancing the clock by the given
+// duration once it is waiting on the given number of timers.
+func (s *zonesSuite) runZonesAdvancing(c *gc.C, d time.Duration, waiters int, args ...string) (*cmd.Context, error) {
+	type result struct {
+		ctx *cmd.Context
+		err error
+	}
+	done := make(chan result, 1)
+	go func() {
+		ctx, err := s.runZones(c, args...)
+		done <- result{ctx, err}
+	}()
+	c.Assert(s.clock.WaitAdvance(d, testing.LongWait, waiters), jc.ErrorIsNil)
+	select {
+	case r := <-done:
+		return r.ctx, r.err
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out waiting for command to finish")
+	}
+	panic("unreachable")
+}
+
+func (s *zonesSuite) TestZonesTabular(c *gc.C) {
+	ctx, err := s.runZones(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Zone    Status       Units\n"+
+		"zone-a  available    3\n"+
+		"zone-b  unavailable  1\n"+
+		"zone-c  available    1\n")
+	s.api.CheckCallNames(c, "ZoneReport", "Close")
+	s.appAPI.CheckNoCalls(c)
+}
+
+func (s *zonesSuite) TestZonesReportTabular(c *gc.C) {
+	ctx, err := s.runZones(c, "--report")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		"Zone    Status       Units\n"+
+		"zone-a  available    3\n"+
+		"zone-b  unavailable  1\n"+
+		"zone-c  available    1\n"+
+		"\n"+
+		"Application  Spread                      Balanced\n"+
+		"mysql        zone-a:2 zone-b:1 zone-c:0  no\n"+
+		"redis        zone-a:1 zone-c:1           yes\n"+
+		"\n"+
+		"Suggested steps:\n"+
+		"  juju add-unit mysql --to zone=zone-c\n"+
+		"  juju remove-unit mysql/2\n")
+	s.appAPI.CheckNoCalls(c)
+}
+
+func (s *zonesSuite) TestZonesReportYAML(c *gc.C) {
+	s.api.report.Applications = s.api.report.Applications[:1]
+	ctx, err := s.runZones(c, "--report", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+zones:
+- name: zone-a
+  available: true
+  units: 2
+- name: zone-b
+  available: false
+  units: 1
+- name: zone-c
+  available: true
+  units: 0
+applications:
+- application: mysql
+  units:
+    zone-a:
+    - mysql/0
+    - mysql/1
+    zone-b:
+    - mysql/2
+  balanced: false
+  moves:
+  - unit: mysql/2
+    from: zone-b
+    to: zone-c
+`[1:])
+}
+
+func (s *zonesSuite) TestZonesApply(c *gc.C) {
+	ctx, err := s.runZones(c, "--apply")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, ""+
+		"added unit mysql/3 in zone zone-c\n"+
+		"removing unit mysql/2 from zone zone-b\n")
+	s.appAPI.CheckCalls(c, []jujutesting.StubCall{
+		{"ModelUUID", nil},
+		{"AddUnits", []interface{}{application.AddUnitsParams{
+			ApplicationName: "mysql",
+			NumUnits:        1,
+			Placement: []*instance.Placement{{
+				Scope:     "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+				Directive: "zone=zone-c",
+			}},
+		}}},
+		{"DestroyUnits", []interface{}{application.DestroyUnitsParams{
+			Units: []string{"mysql/2"},
+		}}},
+		{"Close", nil},
+	})
+	s.statusAPI.CheckCalls(c, []jujutesting.StubCall{
+		{"Status", []interface{}{[]string{"mysql/3"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *zonesSuite) TestZonesApplyWaitsForUnit(c *gc.C) {
+	s.statusAPI.agentStatuses = []string{"executing", "idle"}
+	ctx, err := s.runZonesAdvancing(c, zones.UnitPollInterval, 2, "--apply")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, ""+
+		"added unit mysql/3 in zone zone-c\n"+
+		"removing unit mysql/2 from zone zone-b\n")
+	s.statusAPI.CheckCallNames(c, "Status", "Status", "Close")
+	s.appAPI.CheckCallNames(c, "ModelUUID", "AddUnits", "DestroyUnits", "Close")
+}
+
+func (s *zonesSuite) TestZonesApplyUnitFailed(c *gc.C) {
+	s.statusAPI.agentStatuses = []string{"error"}
+	_, err := s.runZones(c, "--apply")
+	c.Assert(err, gc.ErrorMatches, `not removing unit "mysql/2": unit mysql/3 failed: hook failed: "install"`)
+	s.appAPI.CheckCallNames(c, "ModelUUID", "AddUnits", "Close")
+}
+
+func (s *zonesSuite) TestZonesApplyTimeout(c *gc.C) {
+	s.statusAPI.agentStatuses = []string{"executing"}
+	_, err := s.runZonesAdvancing(c, time.Second, 2, "--apply", "--max-wait", "1s")
+	c.Assert(err, gc.ErrorMatches, `not removing unit "mysql/2": timed out after 1s waiting for unit mysql/3 to start`)
+	s.appAPI.CheckCallNames(c, "ModelUUID", "AddUnits", "Close")
+}
+
+func (s *zonesSuite) TestZonesInvalidMaxWait(c *gc.C) {
+	_, err := s.runZones(c, "--apply", "--max-wait", "0s")
+	c.Assert(err, gc.ErrorMatches, "non-positive --max-wait not valid")
+}
+
+func (s *zonesSuite) TestZonesApplyBalanced(c *gc.C) {
+	s.api.report.Applications = s.api.report.Applications[1:]
+	ctx, err := s.runZones(c, "--apply")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "All applications are evenly spread across the available zones.\n")
+	s.appAPI.CheckNoCalls(c)
+}
+
+func (s *zonesSuite) TestZonesApplyRemoveError(c *gc.C) {
+	s.appAPI.destroyErr = &params.Error{Message: "unit is blocked"}
+	_, err := s.runZones(c, "--apply")
+	c.Assert(err, gc.ErrorMatches, `removing unit "mysql/2": unit is blocked`)
+	s.appAPI.CheckCallNames(c, "ModelUUID", "AddUnits", "DestroyUnits", "Close")
+}
+
+func (s *zonesSuite) TestZonesNoZones(c *gc.C) {
+	s.api.report = params.ZoneReport{}
+	ctx, err := s.runZones(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No availability zones are known in this model.\n")
+}
+
+type fakeZonesAPI struct {
+	jujutesting.Stub
+	report params.ZoneReport
+}
+
+func (f *fakeZonesAPI) ZoneReport() (params.ZoneReport, error) {
+	f.MethodCall(f, "ZoneReport")
+	return f.report, f.NextErr()
+}
+
+func (f *fakeZonesAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+type fakeApplicationAPI struct {
+	jujutesting.Stub
+	added      []string
+	destroyErr *params.Error
+}
+
+func (f *fakeApplicationAPI) ModelUUID() string {
+	f.MethodCall(f, "ModelUUID")
+	return "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+}
+
+func (f *fakeApplicationAPI) AddUnits(args application.AddUnitsParams) ([]string, error) {
+	f.MethodCall(f, "AddUnits", args)
+	return f.added, f.NextErr()
+}
+
+func (f *fakeApplicationAPI) DestroyUnits(args application.DestroyUnitsParams) ([]params.DestroyUnitResult, error) {
+	f.MethodCall(f, "DestroyUnits", args)
+	return []params.DestroyUnitResult{{Error: f.destroyErr}}, f.NextErr()
+}
+
+func (f *fakeApplicationAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+// fakeStatusAPI reports the agent of each unit asked about as having
+// each of agentStatuses in turn, staying with the last.
+type fakeStatusAPI struct {
+	jujutesting.Stub
+	agentStatuses []string
+}
+
+func (f *fakeStatusAPI) Status(patterns []string) (*params.FullStatus, error) {
+	f.MethodCall(f, "Status", patterns)
+	agentStatus := f.agentStatuses[0]
+	if len(f.agentStatuses) > 1 {
+		f.agentStatuses = f.agentStatuses[1:]
+	}
+	units := make(map[string]params.UnitStatus)
+	for _, unit := range patterns {
+		unitStatus := params.UnitStatus{
+			AgentStatus:    params.DetailedStatus{Status: agentStatus},
+			WorkloadStatus: params.DetailedStatus{Status: "waiting"},
+		}
+		if agentStatus == "error" {
+			unitStatus.AgentStatus.Info = `hook failed: "install"`
+		}
+		units[unit] = unitStatus
+	}
+	return &params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"mysql": {Units: units},
+		},
+	}, f.NextErr()
+}
+
+func (f *fakeStatusAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
@@ -32,14 +32,19 @@ var (
 		"valid-credential-flag",
 	}
 	requireValidCredentialModelWorkers = []string{
-		"action-pruner",          // tertiary dependency: will be inactive because migration workers will be inactive
-		"application-scaler",     // tertiary dependency: will be inactive because migration workers will be inactive
+		"action-pruner",      // tertiary dependency: will be inactive because migration workers will be inactive
+		"application-scaler", // tertiary dependency: will be inactive because migration workers will be inactive
+		"autoscaling-groups",
+		"bundle-reconciler",      // tertiary dependency: will be inactive because migration workers will be inactive
 		"charm-revision-updater", // tertiary dependency: will be inactive because migration workers will be inactive
 		"compute-provisioner",
+		"cross-model-health", // tertiary dependency: will be inactive because migration workers will be inactive
+		"dead-agent-marker",  // tertiary dependency: will be inactive because migration workers will be inactive
 		"environ-tracker",
 		"firewaller",
 		"instance-mutater",
 		"instance-poller",
+		"leadership-pin-expirer",  // tertiary dependency: will be inactive because migration workers will be inactive
 		"logging-config-updater",  // tertiary dependency: will be inactive because migration workers will be inactive
 		"machine-undertaker",      // tertiary dependency: will be inactive because migration workers will be inactive
 		"metric-worker",           // tertiary dependency: will be inactive because migration workers will be inactive
@@ -49,6 +54,7 @@ var (
 		"model-upgrader",
 		"remote-relations",      // tertiary dependency: will be inactive because migration workers will be inactive
 		"state-cleaner",         // tertiary dependency: will be inactive because migration workers will be inactive
+		"status-alerts",         // tertiary dependency: will be inactive because migration workers will be inactive
 		"status-history-pruner", // tertiary dependency: will be inactive because migration workers will be inactive
 		"storage-provisioner",   // tertiary dependency: will be inactive because migration workers will be inactive
 		"undertaker",
@@ -57,12 +63,17 @@ var (
 	aliveModelWorkers = []string{
 		"action-pruner",
 		"application-scaler",
+		"autoscaling-groups",
+		"bundle-reconciler",
 		"charm-revision-updater",
 		"compute-provisioner",
+		"cross-model-health",
+		"dead-agent-marker",
 		"environ-tracker",
 		"firewaller",
 		"instance-mutater",
 		"instance-poller",
+		"leadership-pin-expirer",
 		"log-forwarder",
 		"logging-config-updater",
 		"machine-undertaker",
@@ -72,6 +83,7 @@ var (
 		"migration-master",
 		"remote-relations",
 		"state-cleaner",
+		"status-alerts",
 		"status-history-pruner",
 		"storage-provisioner",
 		"unit-assigner",
@@ -143,6 +155,7 @@ var (
 		"api-address-updater",
 		"disk-manager",
 		"fan-configurer",
+		"health-prober",
 		// "host-key-reporter", not stable, exits when done
 		"log-sender",
 		"logging-config-updater",
@@ -150,11 +163,13 @@ var (
 		"machiner",
 		"proxy-config-updater",
 		"reboot-executor",
+		"security-updates-reporter",
 		"ssh-authkeys-updater",
 		"storage-provisioner",
 		"upgrade-series",
 		"unconverted-api-workers",
 		"unit-agent-deployer",
+		"worker-failure-reporter",
 	}
 )
 
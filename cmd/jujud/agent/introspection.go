@@ -24,7 +24,7 @@ import (
 // abstract domain socket that the introspection worker serves requests
 // over.
 func DefaultIntrospectionSocketName(entityTag names.Tag) string {
-	return "jujud-" + entityTag.String()
+	return introspection.SocketName(entityTag)
 }
 
 // introspectionConfig defines the various components that the introspection
@@ -34,6 +34,7 @@ type introspectionConfig struct {
 	Engine             *dependency.Engine
 	StatePoolReporter  introspection.IntrospectionReporter
 	PubSubReporter     introspection.IntrospectionReporter
+	InstancePoller     introspection.IntrospectionReporter
 	MachineLock        machinelock.Lock
 	PrometheusGatherer prometheus.Gatherer
 	PresenceRecorder   presence.Recorder
@@ -59,6 +60,7 @@ func startIntrospection(cfg introspectionConfig) error {
 		DepEngine:          cfg.Engine,
 		StatePool:          cfg.StatePoolReporter,
 		PubSub:             cfg.PubSubReporter,
+		InstancePoller:     cfg.InstancePoller,
 		MachineLock:        cfg.MachineLock,
 		PrometheusGatherer: cfg.PrometheusGatherer,
 		Presence:           cfg.PresenceRecorder,
@@ -64,6 +64,7 @@ import (
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/callaudit"
 	jujunames "github.com/juju/juju/juju/names"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/mongo/mongometrics"
@@ -78,6 +79,7 @@ import (
 	"github.com/juju/juju/worker/conv2state"
 	"github.com/juju/juju/worker/deployer"
 	"github.com/juju/juju/worker/gate"
+	"github.com/juju/juju/worker/instancepoller"
 	"github.com/juju/juju/worker/introspection"
 	"github.com/juju/juju/worker/logsender"
 	"github.com/juju/juju/worker/logsender/logsendermetrics"
@@ -87,6 +89,7 @@ import (
 	psworker "github.com/juju/juju/worker/pubsub"
 	"github.com/juju/juju/worker/upgradedatabase"
 	"github.com/juju/juju/worker/upgradesteps"
+	"github.com/juju/juju/worker/workerfailurereporter"
 )
 
 var (
@@ -326,6 +329,8 @@ func NewMachineAgent(
 		loopDeviceManager:           loopDeviceManager,
 		newIntrospectionSocketName:  newIntrospectionSocketName,
 		prometheusRegistry:          prometheusRegistry,
+		instancePollerRegistry:      instancepoller.NewRegistry(),
+		workerFailureRecorder:       workerfailurereporter.NewRecorder(clock.WallClock),
 		mongoTxnCollector:           mongometrics.NewTxnCollector(),
 		mongoDialCollector:          mongometrics.NewDialCollector(),
 		preUpgradeSteps:             preUpgradeSteps,
@@ -356,6 +361,9 @@ func (a *MachineAgent) registerPrometheusCollectors() error {
 	if err := a.prometheusRegistry.Register(a.mongoDialCollector); err != nil {
 		return errors.Annotate(err, "registering mongo dial collector")
 	}
+	if err := a.prometheusRegistry.Register(callaudit.DefaultRecorder); err != nil {
+		return errors.Annotate(err, "registering provider call collector")
+	}
 	return nil
 }
 
@@ -388,6 +396,8 @@ type MachineAgent struct {
 	loopDeviceManager          looputil.LoopDeviceManager
 	newIntrospectionSocketName func(names.Tag) string
 	prometheusRegistry         *prometheus.Registry
+	instancePollerRegistry     *instancepoller.Registry
+	workerFailureRecorder      *workerfailurereporter.Recorder
 	mongoTxnCollector          *mongometrics.TxnCollector
 	mongoDialCollector         *mongometrics.DialCollector
 	preUpgradeSteps            upgrades.PreUpgradeStepsFunc
@@ -567,6 +577,7 @@ func (a *MachineAgent) makeEngineCreator(agentName string, previousAgentVersion
 				DependencyEngine:   engine,
 				StatePool:          &statePoolReporter,
 				PubSub:             pubsubReporter,
+				InstancePoller:     a.instancePollerRegistry,
 				PrometheusGatherer: a.prometheusRegistry,
 			}, handle)
 		}
@@ -609,11 +620,15 @@ func (a *MachineAgent) makeEngineCreator(agentName string, previousAgentVersion
 			NewContainerBrokerFunc:            newCAASBroker,
 			NewBrokerFunc:                     newBroker,
 			IsCaasConfig:                      a.isCaasAgent,
+			WorkerFailureRecorder:             a.workerFailureRecorder,
 		}
 		manifolds := iaasMachineManifolds(manifoldsCfg)
 		if a.isCaasAgent {
 			manifolds = caasMachineManifolds(manifoldsCfg)
 		}
+		// Record the failures of all the agent's workers, so that
+		// they can be reported to the controller.
+		manifolds = a.workerFailureRecorder.Decorate(manifolds)
 		if err := dependency.Install(engine, manifolds); err != nil {
 			if err := worker.Stop(engine); err != nil {
 				logger.Errorf("while stopping engine with bad manifolds: %v", err)
@@ -625,6 +640,7 @@ func (a *MachineAgent) makeEngineCreator(agentName string, previousAgentVersion
 			Engine:             engine,
 			StatePoolReporter:  &statePoolReporter,
 			PubSubReporter:     pubsubReporter,
+			InstancePoller:     a.instancePollerRegistry,
 			MachineLock:        a.machineLock,
 			NewSocketName:      a.newIntrospectionSocketName,
 			PrometheusGatherer: a.prometheusRegistry,
@@ -1089,6 +1105,13 @@ func (a *MachineAgent) startModelWorkers(cfg modelworkermanager.NewModelConfig)
 		logger.Errorf("unable to configure db logging for model: %v", err)
 	}
 
+	// The model's provider-polling workers share one rate limiter for
+	// their calls to the cloud.
+	cloudRateLimiter := workercommon.NewCloudRateLimiter(
+		clock.WallClock,
+		cfg.ControllerConfig.CloudAPIRateLimit(),
+		cfg.ControllerConfig.CloudAPIRateBurst(),
+	)
 	manifoldsCfg := model.ManifoldsConfig{
 		Agent:                       modelAgent,
 		AgentConfigChanged:          a.configChangedVal,
@@ -1097,10 +1120,19 @@ func (a *MachineAgent) startModelWorkers(cfg modelworkermanager.NewModelConfig)
 		RunFlagDuration:             time.Minute,
 		CharmRevisionUpdateInterval: 24 * time.Hour,
 		StatusHistoryPrunerInterval: 5 * time.Minute,
+		DeadAgentCheckInterval:      5 * time.Minute,
+		LeadershipPinExpiryInterval: time.Minute,
+		CrossModelProbeInterval:     5 * time.Minute,
+		StatusAlertCheckInterval:    time.Minute,
+		BundleCheckInterval:         5 * time.Minute,
+		AutoscalingCheckInterval:    time.Minute,
 		ActionPrunerInterval:        24 * time.Hour,
 		NewEnvironFunc:              newEnvirons,
 		NewContainerBrokerFunc:      newCAASBroker,
 		NewMigrationMaster:          migrationmaster.NewWorker,
+		ProviderCallRecorder:        callaudit.DefaultRecorder,
+		CloudRateLimiter:            cloudRateLimiter,
+		InstancePollerRegistry:      a.instancePollerRegistry,
 	}
 	var manifolds dependency.Manifolds
 	if cfg.ModelType == state.ModelTypeIAAS {
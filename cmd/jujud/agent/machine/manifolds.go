@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/api/crosscontroller"
 	apideployer "github.com/juju/juju/api/deployer"
 	"github.com/juju/juju/caas"
+	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/cmd/jujud/agent/engine"
 	containerbroker "github.com/juju/juju/container/broker"
 	"github.com/juju/juju/container/lxd"
@@ -36,6 +37,7 @@ import (
 	proxyconfig "github.com/juju/juju/utils/proxy"
 	jworker "github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/agent"
+	"github.com/juju/juju/worker/agentconfigreloader"
 	"github.com/juju/juju/worker/agentconfigupdater"
 	"github.com/juju/juju/worker/apiaddressupdater"
 	"github.com/juju/juju/worker/apicaller"
@@ -50,6 +52,7 @@ import (
 	"github.com/juju/juju/worker/common"
 	lxdbroker "github.com/juju/juju/worker/containerbroker"
 	"github.com/juju/juju/worker/controllerport"
+	"github.com/juju/juju/worker/credentialexpiry"
 	"github.com/juju/juju/worker/credentialvalidator"
 	"github.com/juju/juju/worker/deployer"
 	"github.com/juju/juju/worker/diskmanager"
@@ -59,6 +62,7 @@ import (
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/globalclockupdater"
+	"github.com/juju/juju/worker/healthprobes"
 	"github.com/juju/juju/worker/hostkeyreporter"
 	"github.com/juju/juju/worker/httpserver"
 	"github.com/juju/juju/worker/httpserverargs"
@@ -72,6 +76,7 @@ import (
 	"github.com/juju/juju/worker/migrationflag"
 	"github.com/juju/juju/worker/migrationminion"
 	"github.com/juju/juju/worker/modelcache"
+	"github.com/juju/juju/worker/modelcost"
 	"github.com/juju/juju/worker/modelworkermanager"
 	"github.com/juju/juju/worker/peergrouper"
 	prworker "github.com/juju/juju/worker/presence"
@@ -86,10 +91,13 @@ import (
 	"github.com/juju/juju/worker/reboot"
 	"github.com/juju/juju/worker/restorewatcher"
 	"github.com/juju/juju/worker/resumer"
+	"github.com/juju/juju/worker/schemamigrator"
+	"github.com/juju/juju/worker/securityupdatesreporter"
 	"github.com/juju/juju/worker/singular"
 	workerstate "github.com/juju/juju/worker/state"
 	"github.com/juju/juju/worker/stateconfigwatcher"
 	"github.com/juju/juju/worker/storageprovisioner"
+	"github.com/juju/juju/worker/stuckstatus"
 	"github.com/juju/juju/worker/terminationworker"
 	"github.com/juju/juju/worker/toolsversionchecker"
 	"github.com/juju/juju/worker/txnpruner"
@@ -97,6 +105,7 @@ import (
 	"github.com/juju/juju/worker/upgrader"
 	"github.com/juju/juju/worker/upgradeseries"
 	"github.com/juju/juju/worker/upgradesteps"
+	"github.com/juju/juju/worker/workerfailurereporter"
 )
 
 const (
@@ -111,6 +120,30 @@ const (
 	// leaseRequestTopic is the pubsub topic that lease FSM updates
 	// will be published on.
 	leaseRequestTopic = "lease.request"
+
+	// credentialExpiryCheckInterval is how often the expiry of
+	// the cloud credentials used by the models is checked.
+	credentialExpiryCheckInterval = time.Hour
+
+	// modelCostEstimateInterval is how often the costs of
+	// the models are estimated for the metrics endpoint.
+	modelCostEstimateInterval = 5 * time.Minute
+
+	// stuckStatusCheckInterval is how often the models are checked
+	// for stuck statuses for the metrics endpoint.
+	stuckStatusCheckInterval = time.Minute
+
+	// schemaMigrationBatchSize is the largest number of documents
+	// migrated at once by the online schema migrations.
+	schemaMigrationBatchSize = 500
+
+	// schemaMigrationInterval is how long to wait between batches
+	// of an online schema migration.
+	schemaMigrationInterval = time.Second
+
+	// schemaMigrationRetryDelay is how long to wait before retrying
+	// a failed batch of an online schema migration.
+	schemaMigrationRetryDelay = time.Minute
 )
 
 // ManifoldsConfig allows specialisation of the result of Manifolds.
@@ -265,6 +298,11 @@ type ManifoldsConfig struct {
 
 	// IsCaasConfig is true if this config is for a caas agent.
 	IsCaasConfig bool
+
+	// WorkerFailureRecorder records the failures of the agent's
+	// workers, for the worker-failure-reporter worker to report to
+	// the controller.
+	WorkerFailureRecorder *workerfailurereporter.Recorder
 }
 
 // commonManifolds returns a set of co-configured manifolds covering the
@@ -299,8 +337,10 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 	}
 
 	var externalUpdateProxyFunc func(proxy.Settings) error
+	var containerRuntimeServices []string
 	if runtime.GOOS == "linux" && !config.IsCaasConfig {
 		externalUpdateProxyFunc = lxd.ConfigureLXDProxies
+		containerRuntimeServices = []string{"containerd", "docker"}
 	}
 
 	agentConfig := config.Agent.CurrentConfig()
@@ -598,6 +638,31 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			UpdateAgentFunc: config.UpdateLoggerConfig,
 		})),
 
+		// The controller features worker keeps the agent's feature
+		// flags in step with the features controller config
+		// setting, in addition to those set in its environment.
+		controllerFeaturesName: ifNotMigrating(featureflag.ApplierManifold(featureflag.ApplierManifoldConfig{
+			APICallerName: apiCallerName,
+			BaseFlags:     featureflag.EnvironmentFlags(),
+			Apply:         featureflag.ApplyToEnvironment,
+			Logger:        loggo.GetLogger("juju.worker.controllerfeatures"),
+			NewSource:     featureflag.NewAPISource,
+			NewWorker:     featureflag.NewApplier,
+		})),
+
+		// The agent config reloader is a leaf worker that applies the
+		// agent config values overridden by the controller, such as the
+		// logging config and proxy settings, without restarting the
+		// agent.
+		agentConfigReloaderName: ifNotMigrating(agentconfigreloader.Manifold(agentconfigreloader.ManifoldConfig{
+			AgentName:            agentName,
+			APICallerName:        apiCallerName,
+			LoggingContext:       loggo.DefaultContext(),
+			InProcessProxyUpdate: proxyconfig.DefaultConfig.Set,
+			Logger:               loggo.GetLogger("juju.worker.agentconfigreloader"),
+			NewWorker:            agentconfigreloader.NewWorker,
+		})),
+
 		// The log sender is a leaf worker that sends log messages to some
 		// API server, when configured so to do. We should only need one of
 		// these in a consolidated agent.
@@ -634,10 +699,60 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 
 		txnPrunerName: ifNotMigrating(ifPrimaryController(txnpruner.Manifold(
 			txnpruner.ManifoldConfig{
+				ClockName:            clockName,
+				StateName:            stateName,
+				PruneInterval:        config.TransactionPruneInterval,
+				PrometheusRegisterer: config.PrometheusRegisterer,
+				NewWorker:            txnpruner.New,
+			},
+		))),
+
+		credentialExpiryName: ifNotMigrating(ifPrimaryController(credentialexpiry.Manifold(
+			credentialexpiry.ManifoldConfig{
 				ClockName:     clockName,
 				StateName:     stateName,
-				PruneInterval: config.TransactionPruneInterval,
-				NewWorker:     txnpruner.New,
+				Logger:        loggo.GetLogger("juju.worker.credentialexpiry"),
+				Interval:      credentialExpiryCheckInterval,
+				WarningPeriod: cloud.CredentialExpiryWarningPeriod,
+				NewBackend:    credentialexpiry.NewBackend,
+				NewWorker:     credentialexpiry.NewWorker,
+			},
+		))),
+
+		modelCostName: ifNotMigrating(ifPrimaryController(modelcost.Manifold(
+			modelcost.ManifoldConfig{
+				ClockName:            clockName,
+				StateName:            stateName,
+				Logger:               loggo.GetLogger("juju.worker.modelcost"),
+				Interval:             modelCostEstimateInterval,
+				PrometheusRegisterer: config.PrometheusRegisterer,
+				NewBackend:           modelcost.NewBackend,
+				NewWorker:            modelcost.NewWorker,
+			},
+		))),
+
+		stuckStatusName: ifNotMigrating(ifPrimaryController(stuckstatus.Manifold(
+			stuckstatus.ManifoldConfig{
+				ClockName:            clockName,
+				StateName:            stateName,
+				Logger:               loggo.GetLogger("juju.worker.stuckstatus"),
+				Interval:             stuckStatusCheckInterval,
+				PrometheusRegisterer: config.PrometheusRegisterer,
+				NewBackend:           stuckstatus.NewBackend,
+				NewWorker:            stuckstatus.NewWorker,
+			},
+		))),
+
+		schemaMigratorName: ifNotMigrating(ifPrimaryController(schemamigrator.Manifold(
+			schemamigrator.ManifoldConfig{
+				ClockName:  clockName,
+				StateName:  stateName,
+				Logger:     loggo.GetLogger("juju.worker.schemamigrator"),
+				BatchSize:  schemaMigrationBatchSize,
+				Interval:   schemaMigrationInterval,
+				RetryDelay: schemaMigrationRetryDelay,
+				NewBackend: schemamigrator.NewBackend,
+				NewWorker:  schemamigrator.NewWorker,
 			},
 		))),
 
@@ -747,9 +862,12 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			ClockName:            clockName,
 			AgentName:            agentName,
 			TransportName:        raftTransportName,
+			HubName:              centralHubName,
+			StateName:            stateName,
 			FSM:                  leaseFSM,
 			Logger:               loggo.GetLogger("juju.worker.raft"),
 			PrometheusRegisterer: config.PrometheusRegisterer,
+			GetControllerConfig:  raft.GetControllerConfig,
 			NewWorker:            raft.NewWorker,
 		})),
 
@@ -815,6 +933,20 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			ExternalUpdate:      externalUpdateProxyFunc,
 			InProcessUpdate:     proxyconfig.DefaultConfig.Set,
 			RunFunc:             proxyupdater.RunWithStdIn,
+
+			ContainerRuntimeServices: containerRuntimeServices,
+		})),
+
+		// The worker failure reporter reports the failures of the
+		// agent's workers to the controller, which keeps a history of
+		// them in the machine's status data.
+		workerFailureReporterName: ifNotMigrating(workerfailurereporter.Manifold(workerfailurereporter.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			Recorder:      config.WorkerFailureRecorder,
+			NewWorker:     workerfailurereporter.NewWorker,
+			NewFacade:     workerfailurereporter.NewFacade,
+			Logger:        loggo.GetLogger("juju.worker.workerfailurereporter"),
 		})),
 
 		// TODO (thumper): It doesn't really make sense in a machine manifold as
@@ -840,6 +972,7 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 		toolsVersionCheckerName: ifNotMigrating(toolsversionchecker.Manifold(toolsversionchecker.ManifoldConfig{
 			AgentName:     agentName,
 			APICallerName: apiCallerName,
+			Clock:         config.Clock,
 		})),
 
 		authenticationWorkerName: ifNotMigrating(authenticationworker.Manifold(authenticationworker.ManifoldConfig{
@@ -855,6 +988,24 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewWorker:     hostkeyreporter.NewWorker,
 		})),
 
+		healthProberName: ifNotMigrating(healthprobes.Manifold(healthprobes.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			NewWorker:     healthprobes.NewWorker,
+			NewFacade:     healthprobes.NewFacade,
+			Logger:        loggo.GetLogger("juju.worker.healthprobes"),
+		})),
+
+		securityUpdatesReporterName: ifNotMigrating(securityupdatesreporter.Manifold(securityupdatesreporter.ManifoldConfig{
+			AgentName:     agentName,
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			NewWorker:     securityupdatesreporter.NewWorker,
+			NewFacade:     securityupdatesreporter.NewFacade,
+			Logger:        loggo.GetLogger("juju.worker.securityupdatesreporter"),
+		})),
+
 		fanConfigurerName: ifNotMigrating(fanconfigurer.Manifold(fanconfigurer.ManifoldConfig{
 			APICallerName: apiCallerName,
 			Clock:         config.Clock,
@@ -883,6 +1034,7 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 		diskManagerName: ifNotMigrating(diskmanager.Manifold(diskmanager.ManifoldConfig{
 			AgentName:     agentName,
 			APICallerName: apiCallerName,
+			Clock:         config.Clock,
 		})),
 
 		// The api address updater is a leaf worker that rewrites agent config
@@ -1115,6 +1267,7 @@ const (
 	apiWorkersName                = "unconverted-api-workers"
 	rebootName                    = "reboot-executor"
 	loggingConfigUpdaterName      = "logging-config-updater"
+	agentConfigReloaderName       = "agent-config-reloader"
 	diskManagerName               = "disk-manager"
 	proxyConfigUpdater            = "proxy-config-updater"
 	apiAddressUpdaterName         = "api-address-updater"
@@ -1128,6 +1281,9 @@ const (
 	toolsVersionCheckerName       = "tools-version-checker"
 	machineActionName             = "machine-action-runner"
 	hostKeyReporterName           = "host-key-reporter"
+	healthProberName              = "health-prober"
+	securityUpdatesReporterName   = "security-updates-reporter"
+	workerFailureReporterName     = "worker-failure-reporter"
 	fanConfigurerName             = "fan-configurer"
 	externalControllerUpdaterName = "external-controller-updater"
 	globalClockUpdaterName        = "global-clock-updater"
@@ -1136,6 +1292,10 @@ const (
 	isControllerFlagName          = "is-controller-flag"
 	instanceMutaterName           = "instance-mutater"
 	txnPrunerName                 = "transaction-pruner"
+	credentialExpiryName          = "credential-expiry"
+	modelCostName                 = "model-cost"
+	stuckStatusName               = "stuck-status"
+	schemaMigratorName            = "schema-migrator"
 	certificateWatcherName        = "certificate-watcher"
 	modelCacheName                = "model-cache"
 	modelCacheInitializedFlagName = "model-cache-initialized-flag"
@@ -1147,6 +1307,7 @@ const (
 	auditConfigUpdaterName        = "audit-config-updater"
 	leaseManagerName              = "lease-manager"
 	legacyLeasesFlagName          = "legacy-leases-flag"
+	controllerFeaturesName        = "controller-features"
 
 	upgradeSeriesWorkerName = "upgrade-series"
 
@@ -59,6 +59,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 		}),
 		[]string{
 			"agent",
+			"agent-config-reloader",
 			"agent-config-updater",
 			"api-address-updater",
 			"api-caller",
@@ -70,11 +71,14 @@ func (ms *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"certificate-updater",
 			"certificate-watcher",
 			"clock",
+			"controller-features",
 			"controller-port",
+			"credential-expiry",
 			"disk-manager",
 			"external-controller-updater",
 			"fan-configurer",
 			"global-clock-updater",
+			"health-prober",
 			"host-key-reporter",
 			"http-server",
 			"http-server-args",
@@ -95,6 +99,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"model-cache",
 			"model-cache-initialized-flag",
 			"model-cache-initialized-gate",
+			"model-cost",
 			"model-worker-manager",
 			"peer-grouper",
 			"presence",
@@ -108,11 +113,14 @@ func (ms *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"raft-transport",
 			"reboot-executor",
 			"restore-watcher",
+			"schema-migrator",
+			"security-updates-reporter",
 			"ssh-authkeys-updater",
 			"ssh-identity-writer",
 			"state",
 			"state-config-watcher",
 			"storage-provisioner",
+			"stuck-status",
 			"termination-signal-handler",
 			"tools-version-checker",
 			"transaction-pruner",
@@ -129,6 +137,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesIAAS(c *gc.C) {
 			"upgrade-steps-runner",
 			"upgrader",
 			"valid-credential-flag",
+			"worker-failure-reporter",
 		},
 	)
 }
@@ -140,6 +149,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesCAAS(c *gc.C) {
 		}),
 		[]string{
 			"agent",
+			"agent-config-reloader",
 			"agent-config-updater",
 			"api-caller",
 			"api-config-watcher",
@@ -148,7 +158,9 @@ func (ms *ManifoldsSuite) TestManifoldNamesCAAS(c *gc.C) {
 			"central-hub",
 			"certificate-watcher",
 			"clock",
+			"controller-features",
 			"controller-port",
+			"credential-expiry",
 			"external-controller-updater",
 			"http-server",
 			"http-server-args",
@@ -165,6 +177,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesCAAS(c *gc.C) {
 			"model-cache",
 			"model-cache-initialized-flag",
 			"model-cache-initialized-gate",
+			"model-cost",
 			"model-worker-manager",
 			"peer-grouper",
 			"presence",
@@ -177,9 +190,11 @@ func (ms *ManifoldsSuite) TestManifoldNamesCAAS(c *gc.C) {
 			"raft-leader-flag",
 			"raft-transport",
 			"restore-watcher",
+			"schema-migrator",
 			"ssh-identity-writer",
 			"state",
 			"state-config-watcher",
+			"stuck-status",
 			"termination-signal-handler",
 			"transaction-pruner",
 			"unconverted-api-workers",
@@ -193,6 +208,7 @@ func (ms *ManifoldsSuite) TestManifoldNamesCAAS(c *gc.C) {
 			"upgrade-steps-runner",
 			"upgrader",
 			"valid-credential-flag",
+			"worker-failure-reporter",
 		},
 	)
 }
@@ -302,7 +318,11 @@ func (*ManifoldsSuite) TestSingularGuardsUsed(c *gc.C) {
 		"upgrade-database-runner",
 	)
 	primaryControllerWorkers := set.NewStrings(
+		"credential-expiry",
 		"external-controller-updater",
+		"model-cost",
+		"schema-migrator",
+		"stuck-status",
 		"transaction-pruner",
 	)
 	for name, manifold := range manifolds {
@@ -406,6 +426,18 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 
 	"agent": {},
 
+	"agent-config-reloader": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"agent-config-updater": {
 		"agent",
 		"api-caller",
@@ -485,6 +517,18 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 
 	"clock": {},
 
+	"controller-features": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"controller-port": {
 		"agent",
 		"central-hub",
@@ -492,6 +536,23 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"state-config-watcher",
 	},
 
+	"credential-expiry": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"clock",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"disk-manager": {
 		"agent",
 		"api-caller",
@@ -552,6 +613,18 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"state-config-watcher",
 	},
 
+	"health-prober": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"host-key-reporter": {
 		"agent",
 		"api-caller",
@@ -766,6 +839,23 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"state-config-watcher",
 	},
 
+	"model-cost": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"clock",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"model-worker-manager": {
 		"agent",
 		"state",
@@ -920,6 +1010,35 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 
 	"restore-watcher": {"agent", "state", "state-config-watcher"},
 
+	"schema-migrator": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"clock",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
+	"security-updates-reporter": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"ssh-authkeys-updater": {
 		"agent",
 		"api-caller",
@@ -961,6 +1080,23 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"valid-credential-flag",
 	},
 
+	"stuck-status": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"clock",
+		"is-controller-flag",
+		"is-primary-controller-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"state",
+		"state-config-watcher",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
+
 	"termination-signal-handler": {},
 
 	"tools-version-checker": {
@@ -1079,6 +1215,18 @@ var expectedMachineManifoldsWithDependencies = map[string][]string{
 		"api-caller",
 		"api-config-watcher",
 	},
+
+	"worker-failure-reporter": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate",
+	},
 }
 
 type mockAgent struct {
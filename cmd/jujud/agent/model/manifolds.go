@@ -22,11 +22,14 @@ import (
 	"github.com/juju/juju/cmd/jujud/agent/engine"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/callaudit"
 	"github.com/juju/juju/worker/actionpruner"
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
 	"github.com/juju/juju/worker/applicationscaler"
+	"github.com/juju/juju/worker/autoscalinggroups"
+	"github.com/juju/juju/worker/bundlereconciler"
 	"github.com/juju/juju/worker/caasbroker"
 	"github.com/juju/juju/worker/caasenvironupgrader"
 	"github.com/juju/juju/worker/caasfirewaller"
@@ -37,12 +40,15 @@ import (
 	"github.com/juju/juju/worker/cleaner"
 	"github.com/juju/juju/worker/common"
 	"github.com/juju/juju/worker/credentialvalidator"
+	"github.com/juju/juju/worker/crossmodelhealth"
+	"github.com/juju/juju/worker/deadagents"
 	"github.com/juju/juju/worker/environ"
 	"github.com/juju/juju/worker/firewaller"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/instancemutater"
 	"github.com/juju/juju/worker/instancepoller"
+	"github.com/juju/juju/worker/leadershippinexpiry"
 	"github.com/juju/juju/worker/lifeflag"
 	"github.com/juju/juju/worker/logforwarder"
 	"github.com/juju/juju/worker/logforwarder/sinks"
@@ -56,6 +62,7 @@ import (
 	"github.com/juju/juju/worker/pruner"
 	"github.com/juju/juju/worker/remoterelations"
 	"github.com/juju/juju/worker/singular"
+	"github.com/juju/juju/worker/statusalerts"
 	"github.com/juju/juju/worker/statushistorypruner"
 	"github.com/juju/juju/worker/storageprovisioner"
 	"github.com/juju/juju/worker/undertaker"
@@ -101,6 +108,32 @@ type ManifoldsConfig struct {
 	// behaviour.
 	StatusHistoryPrunerInterval time.Duration
 
+	// DeadAgentCheckInterval controls how often the dead-agent-marker
+	// worker checks for units whose agents are missing.
+	DeadAgentCheckInterval time.Duration
+
+	// LeadershipPinExpiryInterval controls how often the
+	// leadership-pin-expirer worker lifts expired leadership pins.
+	LeadershipPinExpiryInterval time.Duration
+
+	// CrossModelProbeInterval controls how often the cross-model-health
+	// worker probes the connections to offers hosted in the model.
+	CrossModelProbeInterval time.Duration
+
+	// StatusAlertCheckInterval controls how often the status-alerts
+	// worker matches the status of entities against the model's
+	// status alert rules.
+	StatusAlertCheckInterval time.Duration
+
+	// BundleCheckInterval controls how often the bundle-reconciler
+	// worker compares the model with its bundle-source.
+	BundleCheckInterval time.Duration
+
+	// AutoscalingCheckInterval controls how often the
+	// autoscaling-groups worker compares the instances in the provider
+	// autoscaling groups backing applications with their units.
+	AutoscalingCheckInterval time.Duration
+
 	// ActionPrunerInterval controls the rate at which the action pruner
 	// worker is run.
 	ActionPrunerInterval time.Duration
@@ -115,6 +148,19 @@ type ManifoldsConfig struct {
 	// NewMigrationMaster is called to create a new migrationmaster
 	// worker.
 	NewMigrationMaster func(migrationmaster.Config) (worker.Worker, error)
+
+	// ProviderCallRecorder, if set, records the calls made to the
+	// cloud provider by the workers which support it.
+	ProviderCallRecorder *callaudit.Recorder
+
+	// CloudRateLimiter, if set, is shared by the model's instance
+	// poller, firewaller and provisioner to limit the rate of their
+	// calls to the cloud provider.
+	CloudRateLimiter *common.CloudRateLimiter
+
+	// InstancePollerRegistry, if set, collects the reports of the
+	// instance poller workers for the agent's introspection endpoint.
+	InstancePollerRegistry *instancepoller.Registry
 }
 
 // commonManifolds returns a set of interdependent dependency manifolds that will
@@ -282,6 +328,46 @@ func commonManifolds(config ManifoldsConfig) dependency.Manifolds {
 			PruneInterval: config.StatusHistoryPrunerInterval,
 			Logger:        config.LoggingContext.GetLogger("juju.worker.pruner.statushistory"),
 		})),
+		deadAgentMarkerName: ifNotMigrating(deadagents.Manifold(deadagents.ManifoldConfig{
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			CheckInterval: config.DeadAgentCheckInterval,
+			NewWorker:     deadagents.NewWorker,
+			NewFacade:     deadagents.NewFacade,
+			Logger:        config.LoggingContext.GetLogger("juju.worker.deadagents"),
+		})),
+		leadershipPinExpirerName: ifNotMigrating(leadershippinexpiry.Manifold(leadershippinexpiry.ManifoldConfig{
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			CheckInterval: config.LeadershipPinExpiryInterval,
+			NewWorker:     leadershippinexpiry.NewWorker,
+			NewFacade:     leadershippinexpiry.NewFacade,
+			Logger:        config.LoggingContext.GetLogger("juju.worker.leadershippinexpiry"),
+		})),
+		crossModelHealthName: ifNotMigrating(crossmodelhealth.Manifold(crossmodelhealth.ManifoldConfig{
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			ProbeInterval: config.CrossModelProbeInterval,
+			NewWorker:     crossmodelhealth.NewWorker,
+			NewFacade:     crossmodelhealth.NewFacade,
+			Logger:        config.LoggingContext.GetLogger("juju.worker.crossmodelhealth"),
+		})),
+		statusAlertsName: ifNotMigrating(statusalerts.Manifold(statusalerts.ManifoldConfig{
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			CheckInterval: config.StatusAlertCheckInterval,
+			NewWorker:     statusalerts.NewWorker,
+			NewFacade:     statusalerts.NewFacade,
+			Logger:        config.LoggingContext.GetLogger("juju.worker.statusalerts"),
+		})),
+		bundleReconcilerName: ifNotMigrating(bundlereconciler.Manifold(bundlereconciler.ManifoldConfig{
+			APICallerName: apiCallerName,
+			Clock:         config.Clock,
+			CheckInterval: config.BundleCheckInterval,
+			NewWorker:     bundlereconciler.NewWorker,
+			NewFacade:     bundlereconciler.NewFacade,
+			Logger:        config.LoggingContext.GetLogger("juju.worker.bundlereconciler"),
+		})),
 		actionPrunerName: ifNotMigrating(pruner.Manifold(pruner.ManifoldConfig{
 			APICallerName: apiCallerName,
 			Clock:         config.Clock,
@@ -370,6 +456,7 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 
 			NewProvisionerFunc:           provisioner.NewEnvironProvisioner,
 			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
+			CloudRateLimiter:             config.CloudRateLimiter,
 		}))),
 		storageProvisionerName: ifNotMigrating(ifCredentialValid(storageprovisioner.ModelManifold(storageprovisioner.ModelManifoldConfig{
 			APICallerName:                apiCallerName,
@@ -391,6 +478,8 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFirewallerFacade:          firewaller.NewFirewallerFacade,
 			NewRemoteRelationsFacade:     firewaller.NewRemoteRelationsFacade,
 			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
+			ProviderCallRecorder:         config.ProviderCallRecorder,
+			CloudRateLimiter:             config.CloudRateLimiter,
 		}))),
 		unitAssignerName: ifNotMigrating(unitassigner.Manifold(unitassigner.ManifoldConfig{
 			APICallerName: apiCallerName,
@@ -408,9 +497,23 @@ func IAASManifolds(config ManifoldsConfig) dependency.Manifolds {
 			ClockName:                    clockName,
 			Logger:                       config.LoggingContext.GetLogger("juju.worker.instancepoller"),
 			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
+			ProviderCallRecorder:         config.ProviderCallRecorder,
+			CloudRateLimiter:             config.CloudRateLimiter,
+			Registry:                     config.InstancePollerRegistry,
+		}))),
+		autoscalingGroupsName: ifNotMigrating(ifCredentialValid(autoscalinggroups.Manifold(autoscalinggroups.ManifoldConfig{
+			APICallerName:                apiCallerName,
+			EnvironName:                  environTrackerName,
+			Clock:                        config.Clock,
+			CheckInterval:                config.AutoscalingCheckInterval,
+			NewWorker:                    autoscalinggroups.NewWorker,
+			NewFacade:                    autoscalinggroups.NewFacade,
+			NewCredentialValidatorFacade: common.NewCredentialInvalidatorFacade,
+			Logger:                       config.LoggingContext.GetLogger("juju.worker.autoscalinggroups"),
 		}))),
 		metricWorkerName: ifNotMigrating(metricworker.Manifold(metricworker.ManifoldConfig{
 			APICallerName: apiCallerName,
+			Clock:         config.Clock,
 			Logger:        config.LoggingContext.GetLogger("juju.worker.metricworker"),
 		})),
 		machineUndertakerName: ifNotMigrating(ifCredentialValid(machineundertaker.Manifold(machineundertaker.ManifoldConfig{
@@ -631,13 +734,19 @@ const (
 	unitAssignerName         = "unit-assigner"
 	applicationScalerName    = "application-scaler"
 	instancePollerName       = "instance-poller"
+	autoscalingGroupsName    = "autoscaling-groups"
 	charmRevisionUpdaterName = "charm-revision-updater"
 	metricWorkerName         = "metric-worker"
 	stateCleanerName         = "state-cleaner"
 	statusHistoryPrunerName  = "status-history-pruner"
+	deadAgentMarkerName      = "dead-agent-marker"
+	leadershipPinExpirerName = "leadership-pin-expirer"
 	actionPrunerName         = "action-pruner"
 	machineUndertakerName    = "machine-undertaker"
 	remoteRelationsName      = "remote-relations"
+	crossModelHealthName     = "cross-model-health"
+	statusAlertsName         = "status-alerts"
+	bundleReconcilerName     = "bundle-reconciler"
 	logForwarderName         = "log-forwarder"
 	loggingConfigUpdaterName = "logging-config-updater"
 	instanceMutaterName      = "instance-mutater"
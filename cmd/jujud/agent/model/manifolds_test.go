@@ -39,14 +39,19 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 		"api-caller",
 		"api-config-watcher",
 		"application-scaler",
+		"autoscaling-groups",
+		"bundle-reconciler",
 		"charm-revision-updater",
 		"clock",
 		"compute-provisioner",
+		"cross-model-health",
+		"dead-agent-marker",
 		"environ-tracker",
 		"firewaller",
 		"instance-mutater",
 		"instance-poller",
 		"is-responsible-flag",
+		"leadership-pin-expirer",
 		"log-forwarder",
 		"logging-config-updater",
 		"machine-undertaker",
@@ -61,6 +66,7 @@ func (s *ManifoldsSuite) TestIAASNames(c *gc.C) {
 		"not-dead-flag",
 		"remote-relations",
 		"state-cleaner",
+		"status-alerts",
 		"status-history-pruner",
 		"storage-provisioner",
 		"undertaker",
@@ -85,6 +91,7 @@ func (s *ManifoldsSuite) TestCAASNames(c *gc.C) {
 		"agent",
 		"api-caller",
 		"api-config-watcher",
+		"bundle-reconciler",
 		"caas-broker-tracker",
 		"caas-firewaller",
 		"caas-operator-provisioner",
@@ -92,7 +99,10 @@ func (s *ManifoldsSuite) TestCAASNames(c *gc.C) {
 		"caas-unit-provisioner",
 		"charm-revision-updater",
 		"clock",
+		"cross-model-health",
+		"dead-agent-marker",
 		"is-responsible-flag",
+		"leadership-pin-expirer",
 		"log-forwarder",
 		"logging-config-updater",
 		"migration-fortress",
@@ -105,6 +115,7 @@ func (s *ManifoldsSuite) TestCAASNames(c *gc.C) {
 		"not-dead-flag",
 		"remote-relations",
 		"state-cleaner",
+		"status-alerts",
 		"status-history-pruner",
 		"undertaker",
 		"valid-credential-flag",
@@ -157,6 +168,22 @@ func (s *ManifoldsSuite) TestStateCleanerIgnoresLifeFlags(c *gc.C) {
 	c.Check(inputs.Contains("not-dead-flag"), jc.IsFalse)
 }
 
+func (s *ManifoldsSuite) TestInstancePollerStopsWithInvalidCredential(c *gc.C) {
+	// The instance poller suspends polling when the provider finds the
+	// model's credential invalid, and relies on the credential flag to
+	// stop it and to start a new worker once the credential is valid.
+	manifolds := model.IAASManifolds(model.ManifoldsConfig{
+		Agent:          &mockAgent{},
+		LoggingContext: loggo.DefaultContext(),
+	})
+	for _, name := range []string{"instance-poller", "environ-tracker"} {
+		manifold, found := manifolds[name]
+		c.Assert(found, jc.IsTrue)
+		inputs := set.NewStrings(manifold.Inputs...)
+		c.Check(inputs.Contains("valid-credential-flag"), jc.IsTrue, gc.Commentf("%s", name))
+	}
+}
+
 func (s *ManifoldsSuite) TestClockWrapper(c *gc.C) {
 	expectClock := &fakeClock{}
 	manifolds := model.IAASManifolds(model.ManifoldsConfig{
@@ -215,6 +242,16 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 
 	"api-config-watcher": {"agent"},
 
+	"bundle-reconciler": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"caas-broker-tracker": {"agent", "api-caller", "is-responsible-flag"},
 
 	"caas-firewaller": {
@@ -275,8 +312,38 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 
 	"clock": {},
 
+	"cross-model-health": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
+	"dead-agent-marker": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"is-responsible-flag": {"agent", "api-caller"},
 
+	"leadership-pin-expirer": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"log-forwarder": {
 		"agent",
 		"api-caller",
@@ -349,6 +416,16 @@ var expectedCAASModelManifoldsWithDependencies = map[string][]string{
 		"model-upgraded-flag",
 		"not-dead-flag"},
 
+	"status-alerts": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"status-history-pruner": {
 		"agent",
 		"api-caller",
@@ -401,6 +478,29 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 		"model-upgraded-flag",
 		"not-dead-flag"},
 
+	"autoscaling-groups": {
+		"agent",
+		"api-caller",
+		"environ-tracker",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag",
+		"valid-credential-flag",
+	},
+
+	"bundle-reconciler": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"charm-revision-updater": {
 		"agent",
 		"api-caller",
@@ -426,6 +526,28 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 		"valid-credential-flag",
 	},
 
+	"cross-model-health": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag",
+	},
+
+	"dead-agent-marker": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag",
+	},
+
 	"environ-tracker": {
 		"agent",
 		"api-caller",
@@ -475,6 +597,17 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 
 	"is-responsible-flag": {"agent", "api-caller"},
 
+	"leadership-pin-expirer": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag",
+	},
+
 	"log-forwarder": {
 		"agent",
 		"api-caller",
@@ -579,6 +712,16 @@ var expectedIAASModelManifoldsWithDependencies = map[string][]string{
 		"model-upgraded-flag",
 		"not-dead-flag"},
 
+	"status-alerts": {
+		"agent",
+		"api-caller",
+		"is-responsible-flag",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"model-upgrade-gate",
+		"model-upgraded-flag",
+		"not-dead-flag"},
+
 	"status-history-pruner": {
 		"agent",
 		"api-caller",
@@ -28,6 +28,7 @@ import (
 	"github.com/juju/juju/worker/apiaddressupdater"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
+	"github.com/juju/juju/worker/featureflag"
 	"github.com/juju/juju/worker/fortress"
 	"github.com/juju/juju/worker/gate"
 	"github.com/juju/juju/worker/leadership"
@@ -257,6 +258,18 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			UpdateAgentFunc: config.UpdateLoggerConfig,
 		})),
 
+		// The controller features worker keeps the agent's feature
+		// flags in step with the features controller config
+		// setting, in addition to those set in its environment.
+		controllerFeaturesName: ifNotMigrating(featureflag.ApplierManifold(featureflag.ApplierManifoldConfig{
+			APICallerName: apiCallerName,
+			BaseFlags:     featureflag.EnvironmentFlags(),
+			Apply:         featureflag.ApplyToEnvironment,
+			Logger:        loggo.GetLogger("juju.worker.controllerfeatures"),
+			NewSource:     featureflag.NewAPISource,
+			NewWorker:     featureflag.NewApplier,
+		})),
+
 		// The api address updater is a leaf worker that rewrites agent config
 		// as the controller addresses change. We should only need one of
 		// these in a consolidated agent.
@@ -387,6 +400,7 @@ const (
 	migrationMinionName       = "migration-minion"
 
 	loggingConfigUpdaterName = "logging-config-updater"
+	controllerFeaturesName   = "controller-features"
 	proxyConfigUpdaterName   = "proxy-config-updater"
 	apiAddressUpdaterName    = "api-address-updater"
 
@@ -44,6 +44,7 @@ func (s *ManifoldsSuite) TestManifoldNames(c *gc.C) {
 		"migration-minion",
 		"migration-inactive-flag",
 		"logging-config-updater",
+		"controller-features",
 		"proxy-config-updater",
 		"api-address-updater",
 		"charm-dir",
@@ -182,6 +183,17 @@ var expectedUnitManifoldsWithDependencies = map[string][]string{
 		"upgrade-steps-flag",
 		"upgrade-steps-gate"},
 
+	"controller-features": {
+		"agent",
+		"api-caller",
+		"api-config-watcher",
+		"migration-fortress",
+		"migration-inactive-flag",
+		"upgrade-check-flag",
+		"upgrade-check-gate",
+		"upgrade-steps-flag",
+		"upgrade-steps-gate"},
+
 	"meter-status": {
 		"agent",
 		"api-caller",
@@ -118,6 +118,9 @@ type CommandBase struct {
 	runStarted    bool
 	refreshModels func(jujuclient.ClientStore, string) error
 
+	// asUser holds the name of the user to impersonate, if any.
+	asUser string
+
 	// CanClearCurrentModel indicates that this command can reset current model in local cache, aka client store.
 	CanClearCurrentModel bool
 }
@@ -146,6 +149,7 @@ func (c *CommandBase) closeAPIContexts() {
 // SetFlags implements cmd.Command.SetFlags.
 func (c *CommandBase) SetFlags(f *gnuflag.FlagSet) {
 	c.authOpts.SetFlags(f)
+	f.StringVar(&c.asUser, "as-user", "", "Act as the named user (controller superusers only; recorded in the audit log)")
 }
 
 // SetModelAPI sets the api used to access model information.
@@ -256,6 +260,13 @@ func (c *CommandBase) NewAPIConnectionParams(
 	accountDetails *jujuclient.AccountDetails,
 ) (juju.NewAPIConnectionParams, error) {
 	c.assertRunStarted()
+	var impersonate names.UserTag
+	if c.asUser != "" {
+		if !names.IsValidUser(c.asUser) {
+			return juju.NewAPIConnectionParams{}, errors.NotValidf("user name %q", c.asUser)
+		}
+		impersonate = names.NewUserTag(c.asUser)
+	}
 	bakeryClient, err := c.BakeryClient(store, controllerName)
 	if err != nil {
 		return juju.NewAPIConnectionParams{}, errors.Trace(err)
@@ -273,13 +284,18 @@ func (c *CommandBase) NewAPIConnectionParams(
 		}
 	}
 
-	return newAPIConnectionParams(
+	param, err := newAPIConnectionParams(
 		store, controllerName, modelName,
 		accountDetails,
 		bakeryClient,
 		c.apiOpen,
 		getPassword,
 	)
+	if err != nil {
+		return juju.NewAPIConnectionParams{}, errors.Trace(err)
+	}
+	param.Impersonate = impersonate
+	return param, nil
 }
 
 // HTTPClient returns an http.Client that contains the loaded
@@ -115,5 +115,6 @@ var statusColors = map[status.Status]*ansiterm.Context{
 	status.Down:       ErrorHighlight,
 	status.Error:      ErrorHighlight,
 	status.Failed:     ErrorHighlight,
+	status.Gone:       ErrorHighlight,
 	status.Terminated: ErrorHighlight,
 }
@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/container"
 	"github.com/juju/juju/core/instance"
 	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/network"
 	coretools "github.com/juju/juju/tools"
 )
@@ -255,9 +256,26 @@ func proxyConfigurationFromContainerCfg(cfg params.ContainerConfig) instancecfg.
 		Juju:                cfg.JujuProxy,
 		Apt:                 cfg.AptProxy,
 		AptMirror:           cfg.AptMirror,
+		AptSources:          aptSourcesFromParams(cfg.AptSources),
 		Snap:                cfg.SnapProxy,
 		SnapStoreAssertions: cfg.SnapStoreAssertions,
 		SnapStoreProxyID:    cfg.SnapStoreProxyID,
 		SnapStoreProxyURL:   cfg.SnapStoreProxyURL,
 	}
 }
+
+// aptSourcesFromParams converts the additional apt repositories in a
+// ContainerConfig API response.
+func aptSourcesFromParams(sources []params.AptSource) []config.AptSource {
+	if len(sources) == 0 {
+		return nil
+	}
+	result := make([]config.AptSource, len(sources))
+	for i, src := range sources {
+		result[i] = config.AptSource{
+			Source: src.Source,
+			Key:    src.Key,
+		}
+	}
+	return result
+}
@@ -6,6 +6,7 @@ package controller
 import (
 	"fmt"
 	"net/url"
+	"path/filepath"
 	"regexp"
 	"time"
 
@@ -169,6 +170,102 @@ const (
 	// to not sleep at all.
 	PruneTxnSleepTime = "prune-txn-sleep-time"
 
+	// PruneTxnMinCount is the minimum number of transactions that must have
+	// been added since the last prune before another prune is considered.
+	PruneTxnMinCount = "prune-txn-min-count"
+
+	// PruneTxnMaxCount is the number of transactions added since the last
+	// prune that will always cause a prune, regardless of the growth
+	// percentage.
+	PruneTxnMaxCount = "prune-txn-max-count"
+
+	// PruneTxnGrowthPercent is the percentage by which the txns collection
+	// must have grown since the last prune before another prune is done.
+	PruneTxnGrowthPercent = "prune-txn-growth-percent"
+
+	// PruneTxnMinAge is the minimum age of a completed transaction before
+	// it is eligible for pruning, eg "1h".
+	PruneTxnMinAge = "prune-txn-min-age"
+
+	// PruneTxnMaxPassSize is the maximum number of transactions that will
+	// be evaluated in a single pruning pass. Bounding this causes a large
+	// backlog to be pruned incrementally over several passes, limiting the
+	// load each pass puts on the database. A value <= 0 indicates no limit
+	// beyond that implied by MaxPruneTxnBatchSize and MaxPruneTxnPasses.
+	PruneTxnMaxPassSize = "prune-txn-max-pass-size"
+
+	// RaftSnapshotThreshold is the number of entries that must have been
+	// added to the lease store's raft log since the last snapshot before
+	// another snapshot is taken, compacting the log.
+	RaftSnapshotThreshold = "raft-snapshot-threshold"
+
+	// RaftSnapshotInterval is how often the raft worker checks whether a
+	// snapshot of the lease store should be taken, eg "2m".
+	RaftSnapshotInterval = "raft-snapshot-interval"
+
+	// RaftTrailingLogs is the number of raft log entries kept after a
+	// snapshot, so that a follower which is slightly behind can catch
+	// up without being sent the whole snapshot.
+	RaftTrailingLogs = "raft-trailing-logs"
+
+	// ModelImportMode determines how fields in a model description
+	// which this controller doesn't understand are treated when a model
+	// is migrated into it: "strict" rejects the import, and "lenient"
+	// ignores the fields, logging them.
+	ModelImportMode = "model-import-mode"
+
+	// APIDNSName is the DNS name under which the addresses of the
+	// controllers' API servers are published, as A and AAAA records,
+	// with an SRV record for each controller. Agents and clients in
+	// networks where controller addresses change can then find the
+	// controllers without relying on the addresses they last saw.
+	// Publishing is disabled if it is empty.
+	APIDNSName = "api-dns-name"
+
+	// APIDNSUpdater names the DNS updater used to publish the API
+	// addresses under APIDNSName, eg "nsupdate".
+	APIDNSUpdater = "api-dns-updater"
+
+	// APIDNSTTL is the time to live of the published API address
+	// records, eg "1m".
+	APIDNSTTL = "api-dns-ttl"
+
+	// APIDNSServer is the host[:port] of the DNS server to which the
+	// nsupdate DNS updater sends its updates. If it is empty, nsupdate
+	// finds the primary server of the zone itself.
+	APIDNSServer = "api-dns-server"
+
+	// APIDNSKeyFile is the path, on the controller machines, of the
+	// TSIG key file with which the nsupdate DNS updater signs its
+	// updates.
+	APIDNSKeyFile = "api-dns-key-file"
+
+	// MaxModelApplications is the maximum number of applications a
+	// model on the controller may have. Zero means no limit.
+	MaxModelApplications = "max-model-applications"
+
+	// MaxModelUnits is the maximum number of units a model on the
+	// controller may have. Zero means no limit.
+	MaxModelUnits = "max-model-units"
+
+	// MaxModelMachines is the maximum number of machines a model on
+	// the controller may have. Zero means no limit.
+	MaxModelMachines = "max-model-machines"
+
+	// MaxModelRelations is the maximum number of relations a model on
+	// the controller may have. Zero means no limit.
+	MaxModelRelations = "max-model-relations"
+
+	// CloudAPIRateLimit is the average number of calls per second
+	// which the provider-polling workers of each model, between them,
+	// may make to the cloud API. Zero means no limit.
+	CloudAPIRateLimit = "cloud-api-rate-limit"
+
+	// CloudAPIRateBurst is the number of cloud API calls which the
+	// provider-polling workers of each model may make in a burst,
+	// before CloudAPIRateLimit applies.
+	CloudAPIRateBurst = "cloud-api-rate-burst"
+
 	// Attribute Defaults
 
 	// DefaultAuditingEnabled contains the default value for the
@@ -238,6 +335,59 @@ const (
 	// other systems to operate concurrently.
 	DefaultPruneTxnSleepTime = "10ms"
 
+	// DefaultPruneTxnMinCount is the default minimum number of new
+	// transactions before a prune is considered.
+	DefaultPruneTxnMinCount = 1000
+
+	// DefaultPruneTxnMaxCount is the default number of new transactions
+	// that will always cause a prune.
+	DefaultPruneTxnMaxCount = 100000
+
+	// DefaultPruneTxnGrowthPercent is the default growth of the txns
+	// collection, as a percentage, that causes a prune.
+	DefaultPruneTxnGrowthPercent = 10
+
+	// DefaultPruneTxnMinAge is the default minimum age of a transaction
+	// before it is pruned.
+	DefaultPruneTxnMinAge = "1h"
+
+	// DefaultPruneTxnMaxPassSize is the default maximum number of
+	// transactions evaluated in a single pruning pass (no limit).
+	DefaultPruneTxnMaxPassSize = 0
+
+	// DefaultRaftSnapshotThreshold is the default number of new raft
+	// log entries which causes a snapshot.
+	DefaultRaftSnapshotThreshold = 8192
+
+	// DefaultRaftSnapshotInterval is the default interval between
+	// checks for whether a raft snapshot is needed.
+	DefaultRaftSnapshotInterval = "2m"
+
+	// DefaultRaftTrailingLogs is the default number of raft log entries
+	// kept after a snapshot.
+	DefaultRaftTrailingLogs = 10240
+
+	// DefaultModelImportMode is the default treatment of unknown fields
+	// in an imported model description.
+	DefaultModelImportMode = ModelImportLenient
+
+	// ModelImportStrict and ModelImportLenient are the values of the
+	// ModelImportMode setting.
+	ModelImportStrict  = "strict"
+	ModelImportLenient = "lenient"
+
+	// DefaultAPIDNSUpdater is the DNS updater used to publish the API
+	// addresses when APIDNSUpdater isn't set.
+	DefaultAPIDNSUpdater = "nsupdate"
+
+	// DefaultAPIDNSTTL is the default time to live of the published
+	// API address records.
+	DefaultAPIDNSTTL = "1m"
+
+	// DefaultCloudAPIRateBurst is the default number of cloud API
+	// calls the workers of a model may make in a burst.
+	DefaultCloudAPIRateBurst = 10
+
 	// JujuHASpace is the network space within which the MongoDB replica-set
 	// should communicate.
 	JujuHASpace = "juju-ha-space"
@@ -289,6 +439,26 @@ var (
 		ModelLogsSize,
 		PruneTxnQueryCount,
 		PruneTxnSleepTime,
+		PruneTxnMinCount,
+		PruneTxnMaxCount,
+		PruneTxnGrowthPercent,
+		PruneTxnMinAge,
+		PruneTxnMaxPassSize,
+		RaftSnapshotThreshold,
+		RaftSnapshotInterval,
+		RaftTrailingLogs,
+		ModelImportMode,
+		APIDNSName,
+		APIDNSUpdater,
+		APIDNSTTL,
+		APIDNSServer,
+		APIDNSKeyFile,
+		MaxModelApplications,
+		MaxModelUnits,
+		MaxModelMachines,
+		MaxModelRelations,
+		CloudAPIRateLimit,
+		CloudAPIRateBurst,
 		JujuHASpace,
 		JujuManagementSpace,
 		AuditingEnabled,
@@ -322,6 +492,26 @@ var (
 		MongoMemoryProfile,
 		PruneTxnQueryCount,
 		PruneTxnSleepTime,
+		PruneTxnMinCount,
+		PruneTxnMaxCount,
+		PruneTxnGrowthPercent,
+		PruneTxnMinAge,
+		PruneTxnMaxPassSize,
+		RaftSnapshotThreshold,
+		RaftSnapshotInterval,
+		RaftTrailingLogs,
+		ModelImportMode,
+		APIDNSName,
+		APIDNSUpdater,
+		APIDNSTTL,
+		APIDNSServer,
+		APIDNSKeyFile,
+		MaxModelApplications,
+		MaxModelUnits,
+		MaxModelMachines,
+		MaxModelRelations,
+		CloudAPIRateLimit,
+		CloudAPIRateBurst,
 		JujuHASpace,
 		JujuManagementSpace,
 		CAASOperatorImagePath,
@@ -338,6 +528,12 @@ var (
 	}
 
 	methodNameRE = regexp.MustCompile(`[[:alpha:]][[:alnum:]]*\.[[:alpha:]][[:alnum:]]*`)
+
+	// dnsNameRE matches a DNS name made of letters, digits and hyphens,
+	// optionally fully qualified with a trailing dot.
+	dnsNameRE = regexp.MustCompile(`(?i)^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)*[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.?$`)
+
+	dnsUpdaterRE = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
 )
 
 // ControllerOnlyAttribute returns true if the specified attribute name
@@ -392,6 +588,19 @@ func (c Config) mustInt(name string) int {
 	return value
 }
 
+// zeroableIntOrDefault returns the named attribute as an integer,
+// allowing for zero, or the default value if it is not set.
+func (c Config) zeroableIntOrDefault(name string, defaultVal int) int {
+	switch value := c[name].(type) {
+	case float64:
+		// Values obtained over the api are encoded as float64.
+		return int(value)
+	case int:
+		return value
+	}
+	return defaultVal
+}
+
 func (c Config) intOrDefault(name string, defaultVal int) int {
 	if _, ok := c[name]; ok {
 		return c.mustInt(name)
@@ -663,6 +872,150 @@ func (c Config) PruneTxnSleepTime() time.Duration {
 	return val
 }
 
+// PruneTxnMinCount is the minimum number of new transactions
+// before a prune is considered.
+func (c Config) PruneTxnMinCount() int {
+	return c.zeroableIntOrDefault(PruneTxnMinCount, DefaultPruneTxnMinCount)
+}
+
+// PruneTxnMaxCount is the number of new transactions
+// that will always cause a prune.
+func (c Config) PruneTxnMaxCount() int {
+	return c.zeroableIntOrDefault(PruneTxnMaxCount, DefaultPruneTxnMaxCount)
+}
+
+// PruneTxnGrowthFactor is the factor by which the txns collection must
+// have grown since the last prune before another prune is done.
+func (c Config) PruneTxnGrowthFactor() float64 {
+	percent := c.zeroableIntOrDefault(PruneTxnGrowthPercent, DefaultPruneTxnGrowthPercent)
+	return 1 + float64(percent)/100
+}
+
+// PruneTxnMinAge is the minimum age of a completed transaction
+// before it is pruned.
+func (c Config) PruneTxnMinAge() time.Duration {
+	asStr, ok := c[PruneTxnMinAge].(string)
+	if !ok {
+		asStr = DefaultPruneTxnMinAge
+	}
+	val, _ := time.ParseDuration(asStr)
+	return val
+}
+
+// PruneTxnMaxPassSize is the maximum number of transactions
+// evaluated in a single pruning pass.
+func (c Config) PruneTxnMaxPassSize() int {
+	return c.zeroableIntOrDefault(PruneTxnMaxPassSize, DefaultPruneTxnMaxPassSize)
+}
+
+// RaftSnapshotThreshold is the number of new raft log entries which
+// causes a snapshot of the lease store to be taken.
+func (c Config) RaftSnapshotThreshold() int {
+	return c.zeroableIntOrDefault(RaftSnapshotThreshold, DefaultRaftSnapshotThreshold)
+}
+
+// RaftSnapshotInterval is how often the raft worker checks whether a
+// snapshot of the lease store is needed.
+func (c Config) RaftSnapshotInterval() time.Duration {
+	asStr, ok := c[RaftSnapshotInterval].(string)
+	if !ok {
+		asStr = DefaultRaftSnapshotInterval
+	}
+	val, _ := time.ParseDuration(asStr)
+	return val
+}
+
+// RaftTrailingLogs is the number of raft log entries kept after a
+// snapshot of the lease store.
+func (c Config) RaftTrailingLogs() int {
+	return c.zeroableIntOrDefault(RaftTrailingLogs, DefaultRaftTrailingLogs)
+}
+
+// ModelImportMode returns how unknown fields in an imported model
+// description are treated, either ModelImportStrict or
+// ModelImportLenient.
+func (c Config) ModelImportMode() string {
+	if mode, ok := c[ModelImportMode].(string); ok && mode != "" {
+		return mode
+	}
+	return DefaultModelImportMode
+}
+
+// APIDNSName returns the DNS name under which the API addresses are
+// published, or "" if they aren't. See APIDNSName for more details.
+func (c Config) APIDNSName() string {
+	return c.asString(APIDNSName)
+}
+
+// APIDNSUpdater returns the name of the DNS updater used to publish
+// the API addresses.
+func (c Config) APIDNSUpdater() string {
+	if updater := c.asString(APIDNSUpdater); updater != "" {
+		return updater
+	}
+	return DefaultAPIDNSUpdater
+}
+
+// APIDNSTTL returns the time to live of the published API address
+// records.
+func (c Config) APIDNSTTL() time.Duration {
+	asStr, ok := c[APIDNSTTL].(string)
+	if !ok {
+		asStr = DefaultAPIDNSTTL
+	}
+	val, _ := time.ParseDuration(asStr)
+	return val
+}
+
+// APIDNSServer returns the host[:port] of the DNS server to which the
+// nsupdate DNS updater sends its updates, or "" if it isn't set.
+func (c Config) APIDNSServer() string {
+	return c.asString(APIDNSServer)
+}
+
+// APIDNSKeyFile returns the path of the TSIG key file with which the
+// nsupdate DNS updater signs its updates, or "" if it isn't set.
+func (c Config) APIDNSKeyFile() string {
+	return c.asString(APIDNSKeyFile)
+}
+
+// MaxModelApplications returns the maximum number of applications a
+// model may have, or 0 if there is no limit.
+func (c Config) MaxModelApplications() int {
+	return c.zeroableIntOrDefault(MaxModelApplications, 0)
+}
+
+// MaxModelUnits returns the maximum number of units a model may have,
+// or 0 if there is no limit.
+func (c Config) MaxModelUnits() int {
+	return c.zeroableIntOrDefault(MaxModelUnits, 0)
+}
+
+// MaxModelMachines returns the maximum number of machines a model may
+// have, or 0 if there is no limit.
+func (c Config) MaxModelMachines() int {
+	return c.zeroableIntOrDefault(MaxModelMachines, 0)
+}
+
+// MaxModelRelations returns the maximum number of relations a model
+// may have, or 0 if there is no limit.
+func (c Config) MaxModelRelations() int {
+	return c.zeroableIntOrDefault(MaxModelRelations, 0)
+}
+
+// CloudAPIRateLimit returns the average number of calls per second the
+// provider-polling workers of each model may make to the cloud API, or
+// 0 if there is no limit.
+func (c Config) CloudAPIRateLimit() int {
+	return c.zeroableIntOrDefault(CloudAPIRateLimit, 0)
+}
+
+// CloudAPIRateBurst returns the number of cloud API calls the
+// provider-polling workers of each model may make in a burst.
+func (c Config) CloudAPIRateBurst() int {
+	return c.intOrDefault(CloudAPIRateBurst, DefaultCloudAPIRateBurst)
+}
+
 // JujuHASpace is the network space within which the MongoDB replica-set
 // should communicate.
 func (c Config) JujuHASpace() string {
@@ -780,6 +1133,68 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[PruneTxnMinAge].(string); ok {
+		if _, err := time.ParseDuration(v); err != nil {
+			return errors.Annotatef(err, `%s must be a valid duration (eg "1h")`, PruneTxnMinAge)
+		}
+	}
+
+	for _, name := range []string{
+		PruneTxnMinCount, PruneTxnMaxCount, PruneTxnGrowthPercent,
+		MaxModelApplications, MaxModelUnits, MaxModelMachines, MaxModelRelations,
+		CloudAPIRateLimit,
+	} {
+		if c.zeroableIntOrDefault(name, 0) < 0 {
+			return errors.NotValidf("negative %s", name)
+		}
+	}
+
+	for _, name := range []string{RaftSnapshotThreshold, RaftTrailingLogs, CloudAPIRateBurst} {
+		if c.zeroableIntOrDefault(name, 1) < 1 {
+			return errors.NotValidf("non-positive %s", name)
+		}
+	}
+
+	if v, ok := c[RaftSnapshotInterval].(string); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotatef(err, `%s must be a valid duration (eg "2m")`, RaftSnapshotInterval)
+		}
+		if d <= 0 {
+			return errors.NotValidf("non-positive %s", RaftSnapshotInterval)
+		}
+	}
+
+	if v, ok := c[ModelImportMode].(string); ok {
+		switch v {
+		case ModelImportStrict, ModelImportLenient:
+		default:
+			return errors.NotValidf("%s value %q", ModelImportMode, v)
+		}
+	}
+
+	if v, ok := c[APIDNSName].(string); ok && v != "" && !dnsNameRE.MatchString(v) {
+		return errors.NotValidf("%s value %q", APIDNSName, v)
+	}
+
+	if v, ok := c[APIDNSUpdater].(string); ok && v != "" && !dnsUpdaterRE.MatchString(v) {
+		return errors.NotValidf("%s value %q", APIDNSUpdater, v)
+	}
+
+	if v, ok := c[APIDNSTTL].(string); ok {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return errors.Annotatef(err, `%s must be a valid duration (eg "1m")`, APIDNSTTL)
+		}
+		if d < time.Second {
+			return errors.NotValidf("%s less than 1s", APIDNSTTL)
+		}
+	}
+
+	if v, ok := c[APIDNSKeyFile].(string); ok && v != "" && !filepath.IsAbs(v) {
+		return errors.NotValidf("relative %s %q", APIDNSKeyFile, v)
+	}
+
 	if err := c.validateSpaceConfig(JujuHASpace, "juju HA"); err != nil {
 		return errors.Trace(err)
 	}
@@ -938,6 +1353,26 @@ var configChecker = schema.FieldMap(schema.Fields{
 	ModelLogsSize:           schema.String(),
 	PruneTxnQueryCount:      schema.ForceInt(),
 	PruneTxnSleepTime:       schema.String(),
+	PruneTxnMinCount:        schema.ForceInt(),
+	PruneTxnMaxCount:        schema.ForceInt(),
+	PruneTxnGrowthPercent:   schema.ForceInt(),
+	PruneTxnMinAge:          schema.String(),
+	PruneTxnMaxPassSize:     schema.ForceInt(),
+	RaftSnapshotThreshold:   schema.ForceInt(),
+	RaftSnapshotInterval:    schema.String(),
+	RaftTrailingLogs:        schema.ForceInt(),
+	ModelImportMode:         schema.String(),
+	APIDNSName:              schema.String(),
+	APIDNSUpdater:           schema.String(),
+	APIDNSTTL:               schema.String(),
+	APIDNSServer:            schema.String(),
+	APIDNSKeyFile:           schema.String(),
+	MaxModelApplications:    schema.ForceInt(),
+	MaxModelUnits:           schema.ForceInt(),
+	MaxModelMachines:        schema.ForceInt(),
+	MaxModelRelations:       schema.ForceInt(),
+	CloudAPIRateLimit:       schema.ForceInt(),
+	CloudAPIRateBurst:       schema.ForceInt(),
 	JujuHASpace:             schema.String(),
 	JujuManagementSpace:     schema.String(),
 	CAASOperatorImagePath:   schema.String(),
@@ -945,7 +1380,11 @@ var configChecker = schema.FieldMap(schema.Fields{
 	Features:                schema.List(schema.String()),
 	CharmStoreURL:           schema.String(),
 	MeteringURL:             schema.String(),
-}, schema.Defaults{
+}, configDefaults)
+
+// configDefaults holds the default values for controller config
+// attributes. Attributes without a default are marked with schema.Omit.
+var configDefaults = schema.Defaults{
 	APIPort:                 DefaultAPIPort,
 	APIPortOpenDelay:        DefaultAPIPortOpenDelay,
 	ControllerAPIPort:       schema.Omit,
@@ -971,6 +1410,26 @@ var configChecker = schema.FieldMap(schema.Fields{
 	ModelLogsSize:           fmt.Sprintf("%vM", DefaultModelLogsSizeMB),
 	PruneTxnQueryCount:      DefaultPruneTxnQueryCount,
 	PruneTxnSleepTime:       DefaultPruneTxnSleepTime,
+	PruneTxnMinCount:        DefaultPruneTxnMinCount,
+	PruneTxnMaxCount:        DefaultPruneTxnMaxCount,
+	PruneTxnGrowthPercent:   DefaultPruneTxnGrowthPercent,
+	PruneTxnMinAge:          DefaultPruneTxnMinAge,
+	PruneTxnMaxPassSize:     DefaultPruneTxnMaxPassSize,
+	RaftSnapshotThreshold:   DefaultRaftSnapshotThreshold,
+	RaftSnapshotInterval:    DefaultRaftSnapshotInterval,
+	RaftTrailingLogs:        DefaultRaftTrailingLogs,
+	ModelImportMode:         DefaultModelImportMode,
+	APIDNSName:              schema.Omit,
+	APIDNSUpdater:           schema.Omit,
+	APIDNSTTL:               schema.Omit,
+	APIDNSServer:            schema.Omit,
+	APIDNSKeyFile:           schema.Omit,
+	MaxModelApplications:    schema.Omit,
+	MaxModelUnits:           schema.Omit,
+	MaxModelMachines:        schema.Omit,
+	MaxModelRelations:       schema.Omit,
+	CloudAPIRateLimit:       schema.Omit,
+	CloudAPIRateBurst:       schema.Omit,
 	JujuHASpace:             schema.Omit,
 	JujuManagementSpace:     schema.Omit,
 	CAASOperatorImagePath:   schema.Omit,
@@ -978,7 +1437,20 @@ var configChecker = schema.FieldMap(schema.Fields{
 	Features:                schema.Omit,
 	CharmStoreURL:           csclient.ServerURL,
 	MeteringURL:             romulus.DefaultAPIRoot,
-})
+}
+
+// ConfigDefaults returns the default values for those controller
+// config attributes that have one.
+func ConfigDefaults() map[string]interface{} {
+	defaults := make(map[string]interface{})
+	for attr, val := range configDefaults {
+		if val == schema.Omit {
+			continue
+		}
+		defaults[attr] = val
+	}
+	return defaults
+}
 
 // ConfigSchema holds information on all the fields defined by
 // the config package.
@@ -1092,6 +1564,86 @@ they don't have any access rights to the controller itself`,
 		Type:        environschema.Tstring,
 		Description: `The amount of time to sleep between processing each batch query`,
 	},
+	PruneTxnMinCount: {
+		Type:        environschema.Tint,
+		Description: `The minimum number of new transactions before pruning is considered`,
+	},
+	PruneTxnMaxCount: {
+		Type:        environschema.Tint,
+		Description: `The number of new transactions that always causes pruning`,
+	},
+	PruneTxnGrowthPercent: {
+		Type:        environschema.Tint,
+		Description: `The percentage growth of the txns collection since the last prune that causes pruning`,
+	},
+	PruneTxnMinAge: {
+		Type:        environschema.Tstring,
+		Description: `The minimum age of a completed transaction before it is pruned`,
+	},
+	PruneTxnMaxPassSize: {
+		Type:        environschema.Tint,
+		Description: `The maximum number of transactions evaluated in a single pruning pass (0 for no limit)`,
+	},
+	RaftSnapshotThreshold: {
+		Type:        environschema.Tint,
+		Description: `The number of new lease store raft log entries that causes a snapshot, compacting the log`,
+	},
+	RaftSnapshotInterval: {
+		Type:        environschema.Tstring,
+		Description: `How often to check whether a lease store raft snapshot is needed`,
+	},
+	RaftTrailingLogs: {
+		Type:        environschema.Tint,
+		Description: `The number of lease store raft log entries kept after a snapshot`,
+	},
+	ModelImportMode: {
+		Type:        environschema.Tstring,
+		Description: `How fields not understood by this controller are treated when a model is migrated in: "strict" rejects the migration, "lenient" ignores them`,
+	},
+	APIDNSName: {
+		Type:        environschema.Tstring,
+		Description: `The DNS name under which the controllers' API addresses are published as A, AAAA and SRV records; empty to not publish them`,
+	},
+	APIDNSUpdater: {
+		Type:        environschema.Tstring,
+		Description: `The DNS updater used to publish the API addresses (default "nsupdate")`,
+	},
+	APIDNSTTL: {
+		Type:        environschema.Tstring,
+		Description: `The time to live of the published API address records (default "1m")`,
+	},
+	APIDNSServer: {
+		Type:        environschema.Tstring,
+		Description: `The host[:port] of the DNS server the nsupdate DNS updater sends updates to; empty to use the zone's primary server`,
+	},
+	APIDNSKeyFile: {
+		Type:        environschema.Tstring,
+		Description: `The path on the controller machines of the TSIG key file the nsupdate DNS updater signs updates with`,
+	},
+	MaxModelApplications: {
+		Type:        environschema.Tint,
+		Description: `The maximum number of applications each model may have; 0 for no limit`,
+	},
+	MaxModelUnits: {
+		Type:        environschema.Tint,
+		Description: `The maximum number of units each model may have; 0 for no limit`,
+	},
+	MaxModelMachines: {
+		Type:        environschema.Tint,
+		Description: `The maximum number of machines each model may have; 0 for no limit`,
+	},
+	MaxModelRelations: {
+		Type:        environschema.Tint,
+		Description: `The maximum number of relations each model may have; 0 for no limit`,
+	},
+	CloudAPIRateLimit: {
+		Type:        environschema.Tint,
+		Description: `The average number of calls per second each model's provider-polling workers may make to the cloud API; 0 for no limit`,
+	},
+	CloudAPIRateBurst: {
+		Type:        environschema.Tint,
+		Description: `The number of cloud API calls each model's provider-polling workers may make in a burst (default 10)`,
+	},
 	JujuHASpace: {
 		Type:        environschema.Tstring,
 		Description: `The network space within which the MongoDB replica-set should communicate`,
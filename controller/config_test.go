@@ -282,6 +282,83 @@ var validateTests = []struct {
 		controller.PruneTxnSleepTime: "15",
 	},
 	expectError: `prune-txn-sleep-time must be a valid duration \(eg "10ms"\): time: missing unit in duration 15`,
+}, {
+	about: "prune-txn-min-age not a duration",
+	config: controller.Config{
+		controller.CACertKey:      testing.CACert,
+		controller.PruneTxnMinAge: "15",
+	},
+	expectError: `prune-txn-min-age must be a valid duration \(eg "1h"\): time: missing unit in duration 15`,
+}, {
+	about: "prune-txn-growth-percent negative",
+	config: controller.Config{
+		controller.CACertKey:             testing.CACert,
+		controller.PruneTxnGrowthPercent: -10,
+	},
+	expectError: `negative prune-txn-growth-percent not valid`,
+}, {
+	about: "raft-snapshot-threshold zero",
+	config: controller.Config{
+		controller.CACertKey:             testing.CACert,
+		controller.RaftSnapshotThreshold: 0,
+	},
+	expectError: `non-positive raft-snapshot-threshold not valid`,
+}, {
+	about: "raft-snapshot-interval not a duration",
+	config: controller.Config{
+		controller.CACertKey:            testing.CACert,
+		controller.RaftSnapshotInterval: "15",
+	},
+	expectError: `raft-snapshot-interval must be a valid duration \(eg "2m"\): time: missing unit in duration 15`,
+}, {
+	about: "model-import-mode not valid",
+	config: controller.Config{
+		controller.CACertKey:       testing.CACert,
+		controller.ModelImportMode: "careless",
+	},
+	expectError: `model-import-mode value "careless" not valid`,
+}, {
+	about: "api-dns-name not valid",
+	config: controller.Config{
+		controller.CACertKey:  testing.CACert,
+		controller.APIDNSName: "api_servers.example.com",
+	},
+	expectError: `api-dns-name value "api_servers.example.com" not valid`,
+}, {
+	about: "api-dns-updater not valid",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.APIDNSUpdater: "NS Update",
+	},
+	expectError: `api-dns-updater value "NS Update" not valid`,
+}, {
+	about: "api-dns-ttl too short",
+	config: controller.Config{
+		controller.CACertKey: testing.CACert,
+		controller.APIDNSTTL: "500ms",
+	},
+	expectError: `api-dns-ttl less than 1s not valid`,
+}, {
+	about: "api-dns-key-file relative",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.APIDNSKeyFile: "dns.key",
+	},
+	expectError: `relative api-dns-key-file "dns.key" not valid`,
+}, {
+	about: "max-model-units negative",
+	config: controller.Config{
+		controller.CACertKey:     testing.CACert,
+		controller.MaxModelUnits: -1,
+	},
+	expectError: `negative max-model-units not valid`,
+}, {
+	about: "cloud-api-rate-burst zero",
+	config: controller.Config{
+		controller.CACertKey:         testing.CACert,
+		controller.CloudAPIRateBurst: 0,
+	},
+	expectError: `non-positive cloud-api-rate-burst not valid`,
 }, {
 	about: "mongo-memory-profile not valid",
 	config: controller.Config{
@@ -405,6 +482,144 @@ func (s *ConfigSuite) TestPruneTxnQueryCount(c *gc.C) {
 	c.Check(cfg.PruneTxnSleepTime(), gc.Equals, 5*time.Millisecond)
 }
 
+func (s *ConfigSuite) TestPruneTxnTuningDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.PruneTxnMinCount(), gc.Equals, 1000)
+	c.Check(cfg.PruneTxnMaxCount(), gc.Equals, 100000)
+	c.Check(cfg.PruneTxnGrowthFactor(), gc.Equals, 1.1)
+	c.Check(cfg.PruneTxnMinAge(), gc.Equals, time.Hour)
+	c.Check(cfg.PruneTxnMaxPassSize(), gc.Equals, 0)
+}
+
+func (s *ConfigSuite) TestPruneTxnTuningValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"prune-txn-min-count":      "0",
+			"prune-txn-max-count":      "5000",
+			"prune-txn-growth-percent": "50",
+			"prune-txn-min-age":        "10m",
+			"prune-txn-max-pass-size":  "20000",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.PruneTxnMinCount(), gc.Equals, 0)
+	c.Check(cfg.PruneTxnMaxCount(), gc.Equals, 5000)
+	c.Check(cfg.PruneTxnGrowthFactor(), gc.Equals, 1.5)
+	c.Check(cfg.PruneTxnMinAge(), gc.Equals, 10*time.Minute)
+	c.Check(cfg.PruneTxnMaxPassSize(), gc.Equals, 20000)
+}
+
+func (s *ConfigSuite) TestRaftSnapshotDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.RaftSnapshotThreshold(), gc.Equals, 8192)
+	c.Check(cfg.RaftSnapshotInterval(), gc.Equals, 2*time.Minute)
+	c.Check(cfg.RaftTrailingLogs(), gc.Equals, 10240)
+}
+
+func (s *ConfigSuite) TestRaftSnapshotValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"raft-snapshot-threshold": "1000",
+			"raft-snapshot-interval":  "30s",
+			"raft-trailing-logs":      "500",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.RaftSnapshotThreshold(), gc.Equals, 1000)
+	c.Check(cfg.RaftSnapshotInterval(), gc.Equals, 30*time.Second)
+	c.Check(cfg.RaftTrailingLogs(), gc.Equals, 500)
+}
+
+func (s *ConfigSuite) TestModelImportMode(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.ModelImportMode(), gc.Equals, controller.ModelImportLenient)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{"model-import-mode": "strict"},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.ModelImportMode(), gc.Equals, controller.ModelImportStrict)
+}
+
+func (s *ConfigSuite) TestAPIDNSConfig(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.APIDNSName(), gc.Equals, "")
+	c.Check(cfg.APIDNSUpdater(), gc.Equals, controller.DefaultAPIDNSUpdater)
+	c.Check(cfg.APIDNSTTL(), gc.Equals, time.Minute)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.APIDNSName:    "api.juju.example.com.",
+			controller.APIDNSUpdater: "route53",
+			controller.APIDNSTTL:     "5m",
+			controller.APIDNSServer:  "ns1.example.com:5353",
+			controller.APIDNSKeyFile: "/etc/juju/dns.key",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.APIDNSName(), gc.Equals, "api.juju.example.com.")
+	c.Check(cfg.APIDNSUpdater(), gc.Equals, "route53")
+	c.Check(cfg.APIDNSTTL(), gc.Equals, 5*time.Minute)
+	c.Check(cfg.APIDNSServer(), gc.Equals, "ns1.example.com:5353")
+	c.Check(cfg.APIDNSKeyFile(), gc.Equals, "/etc/juju/dns.key")
+}
+
+func (s *ConfigSuite) TestModelQuotaConfig(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.MaxModelApplications(), gc.Equals, 0)
+	c.Check(cfg.MaxModelUnits(), gc.Equals, 0)
+	c.Check(cfg.MaxModelMachines(), gc.Equals, 0)
+	c.Check(cfg.MaxModelRelations(), gc.Equals, 0)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.MaxModelApplications: 10,
+			controller.MaxModelUnits:        "50",
+			controller.MaxModelMachines:     20,
+			controller.MaxModelRelations:    30,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.MaxModelApplications(), gc.Equals, 10)
+	c.Check(cfg.MaxModelUnits(), gc.Equals, 50)
+	c.Check(cfg.MaxModelMachines(), gc.Equals, 20)
+	c.Check(cfg.MaxModelRelations(), gc.Equals, 30)
+}
+
+func (s *ConfigSuite) TestCloudAPIRateConfig(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.CloudAPIRateLimit(), gc.Equals, 0)
+	c.Check(cfg.CloudAPIRateBurst(), gc.Equals, controller.DefaultCloudAPIRateBurst)
+
+	cfg, err = controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			controller.CloudAPIRateLimit: 5,
+			controller.CloudAPIRateBurst: 20,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cfg.CloudAPIRateLimit(), gc.Equals, 5)
+	c.Check(cfg.CloudAPIRateBurst(), gc.Equals, 20)
+}
+
 func (s *ConfigSuite) TestNetworkSpaceConfigValues(c *gc.C) {
 	haSpace := "space1"
 	managementSpace := "space2"
@@ -662,3 +877,14 @@ func (s *ConfigSuite) TestModelLogfileBackupErr(c *gc.C) {
 	)
 	c.Assert(err.Error(), gc.Equals, `model-logfile-max-backups: expected number, got string("two")`)
 }
+
+func (s *ConfigSuite) TestConfigDefaults(c *gc.C) {
+	defaults := controller.ConfigDefaults()
+	c.Assert(defaults[controller.APIPort], gc.Equals, controller.DefaultAPIPort)
+	c.Assert(defaults[controller.MaxDebugLogDuration], gc.Equals, controller.DefaultMaxDebugLogDuration)
+	// Attributes without a default are not included.
+	_, ok := defaults[controller.IdentityURL]
+	c.Assert(ok, jc.IsFalse)
+	_, ok = defaults[controller.ControllerAPIPort]
+	c.Assert(ok, jc.IsFalse)
+}
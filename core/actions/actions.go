@@ -11,6 +11,19 @@ import (
 // JujuRunActionName defines the action name used by juju-run.
 const JujuRunActionName = "juju-run"
 
+// JujuIntrospectActionName defines the action name used to fetch the
+// introspection data of an agent running on a machine.
+const JujuIntrospectActionName = "juju-introspect"
+
+// IntrospectionSections holds the sections of an agent's introspection
+// data which the juju-introspect action can fetch.
+var IntrospectionSections = []string{"depengine", "goroutines", "machinelock", "metrics"}
+
+// LeasesIntrospectionSection is the introspection section which lists
+// the leases held by an agent. The leases are held by the controller
+// rather than the agent, so it isn't fetched by an action.
+const LeasesIntrospectionSection = "leases"
+
 // PredefinedActionsSpec defines a spec for each predefined action.
 var PredefinedActionsSpec = map[string]charm.ActionSpec{
 	JujuRunActionName: {
@@ -36,4 +49,32 @@ var PredefinedActionsSpec = map[string]charm.ActionSpec{
 			},
 		},
 	},
+	JujuIntrospectActionName: {
+		Description: "predefined juju-introspect action",
+		Params: map[string]interface{}{
+			"type":        "object",
+			"title":       JujuIntrospectActionName,
+			"description": "predefined juju-introspect action params",
+			"required":    []interface{}{"agent", "section"},
+			"properties": map[string]interface{}{
+				"agent": map[string]interface{}{
+					"type":        "string",
+					"description": "tag of the machine or unit agent to introspect",
+				},
+				"section": map[string]interface{}{
+					"type":        "string",
+					"description": "section of the introspection data to fetch",
+					"enum":        introspectionSectionsEnum(),
+				},
+			},
+		},
+	},
+}
+
+func introspectionSectionsEnum() []interface{} {
+	result := make([]interface{}, len(IntrospectionSections))
+	for i, section := range IntrospectionSections {
+		result[i] = section
+	}
+	return result
 }
@@ -0,0 +1,84 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package agentconfig defines the agent config values which the
+// controller may override for a machine agent, and which the agent
+// applies without restarting.
+package agentconfig
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/core/network"
+)
+
+const (
+	// LoggingConfig overrides the logging config of the agent, in the
+	// same form as the logging-config model setting.
+	LoggingConfig = "logging-config"
+
+	// APIAddresses overrides the addresses, as comma separated
+	// host:port pairs, with which the agent connects to the
+	// controller. While it is set, the addresses published by the
+	// controller are not used.
+	APIAddresses = "api-addresses"
+
+	// HTTPProxy overrides the HTTP proxy used by the agent.
+	HTTPProxy = "http-proxy"
+
+	// HTTPSProxy overrides the HTTPS proxy used by the agent.
+	HTTPSProxy = "https-proxy"
+
+	// NoProxy overrides the hosts, as a comma separated list, which
+	// the agent connects to without using a proxy.
+	NoProxy = "no-proxy"
+)
+
+// ValidateOverrides returns an error if any of the given values may not
+// be overridden or is not valid. An empty value removes the override,
+// and is always valid.
+func ValidateOverrides(values map[string]string) error {
+	for key, value := range values {
+		if value == "" {
+			continue
+		}
+		switch key {
+		case LoggingConfig:
+			if _, err := loggo.ParseConfigString(value); err != nil {
+				return errors.Annotatef(err, "invalid %s %q", key, value)
+			}
+		case APIAddresses:
+			if _, err := ParseAPIAddresses(value); err != nil {
+				return errors.Trace(err)
+			}
+		case HTTPProxy, HTTPSProxy, NoProxy:
+		default:
+			return errors.NotValidf("agent config override %q", key)
+		}
+	}
+	return nil
+}
+
+// ParseAPIAddresses parses the value of the api-addresses override,
+// returning each address as a separate server.
+func ParseAPIAddresses(value string) ([]network.HostPorts, error) {
+	var servers []network.HostPorts
+	for _, addr := range strings.Split(value, ",") {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			continue
+		}
+		hp, err := network.ParseMachineHostPort(addr)
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid %s", APIAddresses)
+		}
+		servers = append(servers, network.HostPorts{*hp})
+	}
+	if len(servers) == 0 {
+		return nil, errors.NotValidf("empty %s %q", APIAddresses, value)
+	}
+	return servers, nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentconfig_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/agentconfig"
+	"github.com/juju/juju/core/network"
+)
+
+type overridesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&overridesSuite{})
+
+func (s *overridesSuite) TestValidateOverrides(c *gc.C) {
+	err := agentconfig.ValidateOverrides(map[string]string{
+		agentconfig.LoggingConfig: "<root>=DEBUG;juju.worker=TRACE",
+		agentconfig.APIAddresses:  "10.0.0.1:17070, [2001:db8::1]:17070",
+		agentconfig.HTTPProxy:     "http://proxy.example.com:3128",
+		agentconfig.HTTPSProxy:    "",
+		agentconfig.NoProxy:       "localhost,10.0.0.0/8",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *overridesSuite) TestValidateOverridesErrors(c *gc.C) {
+	for i, test := range []struct {
+		values map[string]string
+		err    string
+	}{{
+		values: map[string]string{"data-dir": "/tmp"},
+		err:    `agent config override "data-dir" not valid`,
+	}, {
+		values: map[string]string{agentconfig.LoggingConfig: "juju=LOUD"},
+		err:    `invalid logging-config "juju=LOUD": .*`,
+	}, {
+		values: map[string]string{agentconfig.APIAddresses: "10.0.0.1"},
+		err:    `invalid api-addresses: cannot parse "10.0.0.1" as address:port: .*`,
+	}, {
+		values: map[string]string{agentconfig.APIAddresses: " , "},
+		err:    `empty api-addresses " , " not valid`,
+	}} {
+		c.Logf("test %d: %v", i, test.values)
+		err := agentconfig.ValidateOverrides(test.values)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *overridesSuite) TestValidateOverridesAllowsRemovingAnything(c *gc.C) {
+	err := agentconfig.ValidateOverrides(map[string]string{"data-dir": ""})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *overridesSuite) TestParseAPIAddresses(c *gc.C) {
+	servers, err := agentconfig.ParseAPIAddresses("10.0.0.1:17070,controller.example.com:443")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(servers, gc.HasLen, 2)
+	c.Check(servers[0], jc.DeepEquals, network.NewMachineHostPorts(17070, "10.0.0.1").HostPorts())
+	c.Check(servers[1], jc.DeepEquals, network.NewMachineHostPorts(443, "controller.example.com").HostPorts())
+
+	_, err = agentconfig.ParseAPIAddresses("")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
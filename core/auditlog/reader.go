@@ -0,0 +1,155 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+)
+
+// backupTimeFormat is the format of the time the audit log was rotated
+// in the names of its backups, audit-<time>.log, as written by
+// lumberjack.
+const backupTimeFormat = "2006-01-02T15-04-05.000"
+
+// errWindowPassed stops reading the audit log once a conversation
+// started after the end of the window being read.
+var errWindowPassed = errors.New("window passed")
+
+// Conversations calls f with each conversation recorded in the audit
+// log in the given directory, including any rotated backups of it, for
+// the model with the given UUID which started at or after since and
+// before until, in the order they were recorded. Backups rotated before
+// since are not read, and reading stops at the first conversation
+// which started at or after until. Records which cannot be parsed are
+// skipped. If f returns an error, reading stops and the error is
+// returned.
+//
+// Each controller node writes its own audit log, so in a controller
+// with several nodes only the conversations held with the node whose
+// log directory is given are seen.
+func Conversations(logDir, modelUUID string, since, until time.Time, f func(Conversation) error) error {
+	paths, err := logPaths(logDir, since)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, path := range paths {
+		err := readConversations(path, func(c Conversation, when time.Time) error {
+			if c.ModelUUID != modelUUID || when.Before(since) {
+				return nil
+			}
+			if !when.Before(until) {
+				return errWindowPassed
+			}
+			return f(c)
+		})
+		if err == errWindowPassed {
+			break
+		}
+		if err != nil {
+			return errors.Annotatef(err, "reading %q", path)
+		}
+	}
+	return nil
+}
+
+// logPaths returns the paths of the audit log in the given directory
+// and of those of its rotated backups which may hold records from at
+// or after since, oldest first.
+func logPaths(logDir string, since time.Time) ([]string, error) {
+	// Rotated backups are named audit-<time>.log, and are compressed
+	// once rotated. The time is when the backup was rotated, in UTC,
+	// so every record in it is older than that.
+	backups, err := filepath.Glob(filepath.Join(logDir, "audit-*.log*"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var paths []string
+	for _, path := range backups {
+		name := strings.TrimPrefix(filepath.Base(path), "audit-")
+		name = strings.TrimSuffix(strings.TrimSuffix(name, ".gz"), ".log")
+		rotated, err := time.Parse(backupTimeFormat, name)
+		if err == nil && rotated.Before(since) {
+			continue
+		}
+		paths = append(paths, path)
+	}
+	// The time format sorts in time order.
+	sort.Strings(paths)
+
+	current := filepath.Join(logDir, "audit.log")
+	if _, err := os.Stat(current); err == nil {
+		paths = append(paths, current)
+	} else if !os.IsNotExist(err) {
+		return nil, errors.Trace(err)
+	}
+	return paths, nil
+}
+
+// readConversations calls f with each conversation recorded in the
+// audit log file at the given path, and when it started, stopping at
+// the first error returned by f.
+func readConversations(path string, f func(Conversation, time.Time) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer file.Close()
+
+	var r io.Reader = file
+	if strings.HasSuffix(path, ".gz") {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	br := bufio.NewReader(r)
+	for {
+		line, err := br.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return errors.Trace(err)
+		}
+		if c, when, ok := parseConversation(line); ok {
+			if err := f(c, when); err != nil {
+				return err
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+	}
+}
+
+// parseConversation returns the conversation held in the audit record,
+// if it is one, and when it started.
+func parseConversation(line []byte) (Conversation, time.Time, bool) {
+	var record Record
+	if err := json.Unmarshal(line, &record); err != nil {
+		return Conversation{}, time.Time{}, false
+	}
+	c := record.Conversation
+	if c == nil {
+		return Conversation{}, time.Time{}, false
+	}
+	when, err := time.Parse(time.RFC3339, c.When)
+	if err != nil {
+		return Conversation{}, time.Time{}, false
+	}
+	// Normalise the time so that conversations recorded in different
+	// zones compare correctly.
+	c.When = when.UTC().Format(time.RFC3339)
+	return *c, when, true
+}
@@ -0,0 +1,134 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/auditlog"
+)
+
+type ReaderSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ReaderSuite{})
+
+const (
+	modelUUID = "deadbeef-0bad-400d-8000-4b1d0d06f00d"
+	otherUUID = "deadbeef-0bad-400d-8000-5b1d0d06f00d"
+)
+
+func writeBackup(c *gc.C, path, content string) {
+	f, err := os.Create(path)
+	c.Assert(err, jc.ErrorIsNil)
+	gz := gzip.NewWriter(f)
+	_, err = gz.Write([]byte(content))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(gz.Close(), jc.ErrorIsNil)
+	c.Assert(f.Close(), jc.ErrorIsNil)
+}
+
+func collect(conversations *[]auditlog.Conversation) func(auditlog.Conversation) error {
+	return func(c auditlog.Conversation) error {
+		*conversations = append(*conversations, c)
+		return nil
+	}
+}
+
+func (s *ReaderSuite) TestConversations(c *gc.C) {
+	dir := c.MkDir()
+
+	// A backup rotated before the window isn't read at all.
+	err := ioutil.WriteFile(filepath.Join(dir, "audit-2020-01-03T12-00-00.000.log.gz"), []byte("not gzip"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	writeBackup(c, filepath.Join(dir, "audit-2020-01-03T14-10-00.000.log.gz"),
+		`{"conversation":{"who":"mary","what":"juju deploy mysql","when":"2020-01-03T13:00:00Z","model-uuid":"`+modelUUID+`"}}`+"\n"+
+			`{"conversation":{"who":"mary","what":"juju config mysql","when":"2020-01-03T14:05:00Z","model-uuid":"`+modelUUID+`"}}`+"\n"+
+			"not json\n")
+
+	logFile := auditlog.NewLogFile(dir, 300, 10)
+	for _, conv := range []auditlog.Conversation{{
+		Who:       "bob",
+		What:      "juju remove-unit mysql/0",
+		When:      "2020-01-03T15:20:00+01:00",
+		ModelUUID: modelUUID,
+	}, {
+		Who:       "bob",
+		What:      "juju remove-application wordpress",
+		When:      "2020-01-03T14:30:00Z",
+		ModelUUID: otherUUID,
+	}, {
+		Who:       "mary",
+		What:      "juju remove-application mysql",
+		When:      "2020-01-03T15:30:00Z",
+		ModelUUID: modelUUID,
+	}, {
+		// Reading stops at the first conversation after the window.
+		Who:       "mary",
+		What:      "juju add-unit mysql",
+		When:      "2020-01-03T14:50:00Z",
+		ModelUUID: modelUUID,
+	}} {
+		err := logFile.AddConversation(conv)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err = logFile.AddRequest(auditlog.Request{
+		When:   "2020-01-03T14:25:00Z",
+		Facade: "Application",
+		Method: "DestroyUnit",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(logFile.Close(), jc.ErrorIsNil)
+
+	since := time.Date(2020, 1, 3, 14, 0, 0, 0, time.UTC)
+	until := since.Add(time.Hour)
+	var conversations []auditlog.Conversation
+	err = auditlog.Conversations(dir, modelUUID, since, until, collect(&conversations))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conversations, jc.DeepEquals, []auditlog.Conversation{{
+		Who:       "mary",
+		What:      "juju config mysql",
+		When:      "2020-01-03T14:05:00Z",
+		ModelUUID: modelUUID,
+	}, {
+		Who:       "bob",
+		What:      "juju remove-unit mysql/0",
+		When:      "2020-01-03T14:20:00Z",
+		ModelUUID: modelUUID,
+	}})
+}
+
+func (s *ReaderSuite) TestConversationsStopsOnError(c *gc.C) {
+	dir := c.MkDir()
+	writeBackup(c, filepath.Join(dir, "audit-2020-01-03T14-10-00.000.log.gz"),
+		`{"conversation":{"who":"mary","what":"juju config mysql","when":"2020-01-03T14:05:00Z","model-uuid":"`+modelUUID+`"}}`+"\n"+
+			`{"conversation":{"who":"mary","what":"juju deploy mysql","when":"2020-01-03T14:06:00Z","model-uuid":"`+modelUUID+`"}}`+"\n")
+
+	since := time.Date(2020, 1, 3, 14, 0, 0, 0, time.UTC)
+	calls := 0
+	err := auditlog.Conversations(dir, modelUUID, since, since.Add(time.Hour), func(auditlog.Conversation) error {
+		calls++
+		return errors.New("boom")
+	})
+	c.Assert(err, gc.ErrorMatches, `reading ".*": boom`)
+	c.Assert(calls, gc.Equals, 1)
+}
+
+func (s *ReaderSuite) TestConversationsNoLog(c *gc.C) {
+	var conversations []auditlog.Conversation
+	now := time.Now()
+	err := auditlog.Conversations(c.MkDir(), modelUUID, now, now.Add(time.Hour), collect(&conversations))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(conversations, gc.HasLen, 0)
+}
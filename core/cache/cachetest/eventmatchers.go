@@ -64,3 +64,33 @@ func BranchEvents(change interface{}) bool {
 	}
 	return false
 }
+
+func RelationEvents(change interface{}) bool {
+	switch change.(type) {
+	case cache.RelationChange:
+		return true
+	case cache.RemoveRelation:
+		return true
+	}
+	return false
+}
+
+func OfferEvents(change interface{}) bool {
+	switch change.(type) {
+	case cache.OfferChange:
+		return true
+	case cache.RemoveOffer:
+		return true
+	}
+	return false
+}
+
+func StorageInstanceEvents(change interface{}) bool {
+	switch change.(type) {
+	case cache.StorageInstanceChange:
+		return true
+	case cache.RemoveStorageInstance:
+		return true
+	}
+	return false
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/life"
@@ -182,3 +183,83 @@ func UnitChange(c *gc.C, modelUUID string, unit *state.Unit) cache.UnitChange {
 		// TODO: Subordinate
 	}
 }
+
+// RelationChange returns a RelationChange representing the input state
+// relation.
+func RelationChange(modelUUID string, rel *state.Relation) cache.RelationChange {
+	eps := rel.Endpoints()
+	endpoints := make([]cache.RelationEndpoint, len(eps))
+	for i, ep := range eps {
+		endpoints[i] = cache.RelationEndpoint{
+			Application: ep.ApplicationName,
+			Name:        ep.Name,
+			Role:        string(ep.Role),
+			Interface:   ep.Interface,
+			Optional:    ep.Optional,
+			Limit:       ep.Limit,
+			Scope:       string(ep.Scope),
+		}
+	}
+
+	return cache.RelationChange{
+		ModelUUID: modelUUID,
+		Key:       rel.String(),
+		Id:        rel.Id(),
+		Endpoints: endpoints,
+	}
+}
+
+// OfferChange returns an OfferChange representing the application offer
+// with the input name.
+func OfferChange(c *gc.C, st *state.State, offerName string) cache.OfferChange {
+	offer, err := state.NewApplicationOffers(st).ApplicationOffer(offerName)
+	c.Assert(err, jc.ErrorIsNil)
+
+	app, err := st.Application(offer.ApplicationName)
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := app.CharmURL()
+
+	conn, err := st.RemoteConnectionStatus(offer.OfferUUID)
+	c.Assert(err, jc.ErrorIsNil)
+
+	return cache.OfferChange{
+		ModelUUID:            st.ModelUUID(),
+		OfferName:            offer.OfferName,
+		OfferUUID:            offer.OfferUUID,
+		ApplicationName:      offer.ApplicationName,
+		CharmName:            curl.Name,
+		TotalConnectedCount:  conn.TotalConnectionCount(),
+		ActiveConnectedCount: conn.ActiveConnectionCount(),
+	}
+}
+
+// StorageInstanceChange returns a StorageInstanceChange representing the
+// storage instance with the input id. The requested size is not exposed
+// by state, so tests that care about it must set it on the result.
+func StorageInstanceChange(c *gc.C, st *state.State, id string) cache.StorageInstanceChange {
+	sb, err := state.NewStorageBackend(st)
+	c.Assert(err, jc.ErrorIsNil)
+
+	tag := names.NewStorageTag(id)
+	si, err := sb.StorageInstance(tag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var owner string
+	if ownerTag, ok := si.Owner(); ok {
+		owner = ownerTag.String()
+	}
+
+	attachments, err := sb.StorageAttachments(tag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	return cache.StorageInstanceChange{
+		ModelUUID:       st.ModelUUID(),
+		Id:              id,
+		Kind:            si.Kind().String(),
+		Life:            life.Value(si.Life().String()),
+		Owner:           owner,
+		StorageName:     si.StorageName(),
+		Pool:            si.Pool(),
+		AttachmentCount: len(attachments),
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/juju/core/instance"
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/lxdprofile"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/settings"
 	"github.com/juju/juju/core/status"
@@ -46,6 +47,10 @@ type ApplicationChange struct {
 	Subordinate     bool
 	Status          status.StatusInfo
 	WorkloadVersion string
+
+	// StatusExplicitlySet records whether the application status has
+	// been set since the application was created.
+	StatusExplicitlySet bool
 }
 
 // copy returns a deep copy of the ApplicationChange.
@@ -134,6 +139,7 @@ type UnitChange struct {
 	Subordinate    bool
 	WorkloadStatus status.StatusInfo
 	AgentStatus    status.StatusInfo
+	AgentVersion   string
 }
 
 // copy returns a deep copy of the UnitChange.
@@ -178,6 +184,7 @@ type MachineChange struct {
 	Id                       string
 	InstanceId               string
 	AgentStatus              status.StatusInfo
+	AgentVersion             string
 	InstanceStatus           status.StatusInfo
 	Life                     life.Value
 	Config                   map[string]interface{}
@@ -187,6 +194,7 @@ type MachineChange struct {
 	SupportedContainersKnown bool
 	HardwareCharacteristics  *instance.HardwareCharacteristics
 	CharmProfiles            []string
+	Jobs                     []model.MachineJob
 	Addresses                []network.ProviderAddress
 	HasVote                  bool
 	WantsVote                bool
@@ -222,6 +230,15 @@ func (m MachineChange) copy() MachineChange {
 	}
 	m.CharmProfiles = cCharmProfiles
 
+	var cJobs []model.MachineJob
+	if m.Jobs != nil {
+		cJobs = make([]model.MachineJob, len(m.Jobs))
+		for i, v := range m.Jobs {
+			cJobs[i] = v
+		}
+	}
+	m.Jobs = cJobs
+
 	var cAddresses []network.ProviderAddress
 	if m.Addresses != nil {
 		cAddresses = make([]network.ProviderAddress, len(m.Addresses))
@@ -305,6 +322,91 @@ type RemoveBranch struct {
 	Id        string
 }
 
+// RelationChange represents either a new relation, or a change
+// to an existing relation in a model.
+type RelationChange struct {
+	ModelUUID string
+	Key       string
+	Id        int
+	Endpoints []RelationEndpoint
+}
+
+// RelationEndpoint describes one side of a cached relation.
+type RelationEndpoint struct {
+	Application string
+	Name        string
+	Role        string
+	Interface   string
+	Optional    bool
+	Limit       int
+	Scope       string
+}
+
+func (r RelationChange) copy() RelationChange {
+	var cEndpoints []RelationEndpoint
+	if r.Endpoints != nil {
+		cEndpoints = make([]RelationEndpoint, len(r.Endpoints))
+		copy(cEndpoints, r.Endpoints)
+	}
+	r.Endpoints = cEndpoints
+	return r
+}
+
+// RemoveRelation represents the situation when a relation
+// is removed from a model in the database.
+type RemoveRelation struct {
+	ModelUUID string
+	Key       string
+}
+
+// OfferChange represents either a new application offer,
+// or a change to an existing offer in a model.
+type OfferChange struct {
+	ModelUUID            string
+	OfferName            string
+	OfferUUID            string
+	ApplicationName      string
+	CharmName            string
+	TotalConnectedCount  int
+	ActiveConnectedCount int
+}
+
+func (o OfferChange) copy() OfferChange {
+	return o
+}
+
+// RemoveOffer represents the situation when an application offer
+// is removed from a model in the database.
+type RemoveOffer struct {
+	ModelUUID string
+	OfferName string
+}
+
+// StorageInstanceChange represents either a new storage instance,
+// or a change to an existing storage instance in a model.
+type StorageInstanceChange struct {
+	ModelUUID       string
+	Id              string
+	Kind            string
+	Life            life.Value
+	Owner           string
+	StorageName     string
+	Pool            string
+	Size            uint64
+	AttachmentCount int
+}
+
+func (s StorageInstanceChange) copy() StorageInstanceChange {
+	return s
+}
+
+// RemoveStorageInstance represents the situation when a storage
+// instance is removed from a model in the database.
+type RemoveStorageInstance struct {
+	ModelUUID string
+	Id        string
+}
+
 func copyStatusInfo(info status.StatusInfo) status.StatusInfo {
 	var cSince *time.Time
 	if info.Since != nil {
@@ -150,6 +150,18 @@ func (c *Controller) loop() error {
 				c.updateBranch(ch)
 			case RemoveBranch:
 				err = c.removeBranch(ch)
+			case RelationChange:
+				c.updateRelation(ch)
+			case RemoveRelation:
+				err = c.removeRelation(ch)
+			case OfferChange:
+				c.updateOffer(ch)
+			case RemoveOffer:
+				err = c.removeOffer(ch)
+			case StorageInstanceChange:
+				c.updateStorageInstance(ch)
+			case RemoveStorageInstance:
+				err = c.removeStorageInstance(ch)
 			}
 			if c.notify != nil {
 				c.notify(change)
@@ -323,6 +335,37 @@ func (c *Controller) removeBranch(ch RemoveBranch) error {
 	return errors.Trace(c.removeResident(ch.ModelUUID, func(m *Model) error { return m.removeBranch(ch) }))
 }
 
+// updateRelation adds or updates the relation in the specified model.
+func (c *Controller) updateRelation(ch RelationChange) {
+	c.ensureModel(ch.ModelUUID).updateRelation(ch, c.manager)
+}
+
+// removeRelation removes the relation from the cached model.
+func (c *Controller) removeRelation(ch RemoveRelation) error {
+	return errors.Trace(c.removeResident(ch.ModelUUID, func(m *Model) error { return m.removeRelation(ch) }))
+}
+
+// updateOffer adds or updates the application offer in the specified model.
+func (c *Controller) updateOffer(ch OfferChange) {
+	c.ensureModel(ch.ModelUUID).updateOffer(ch, c.manager)
+}
+
+// removeOffer removes the application offer from the cached model.
+func (c *Controller) removeOffer(ch RemoveOffer) error {
+	return errors.Trace(c.removeResident(ch.ModelUUID, func(m *Model) error { return m.removeOffer(ch) }))
+}
+
+// updateStorageInstance adds or updates the storage instance
+// in the specified model.
+func (c *Controller) updateStorageInstance(ch StorageInstanceChange) {
+	c.ensureModel(ch.ModelUUID).updateStorageInstance(ch, c.manager)
+}
+
+// removeStorageInstance removes the storage instance from the cached model.
+func (c *Controller) removeStorageInstance(ch RemoveStorageInstance) error {
+	return errors.Trace(c.removeResident(ch.ModelUUID, func(m *Model) error { return m.removeStorageInstance(ch) }))
+}
+
 // removeResident uses the input removal function to remove a cache resident,
 // including cleaning up resources it was responsible for creating.
 // If the cache does not have the model loaded for the resident yet,
@@ -58,6 +58,9 @@ func (s *ControllerSuite) TestAddModel(c *gc.C) {
 			"machine-count":     0,
 			"unit-count":        0,
 			"branch-count":      0,
+			"relation-count":    0,
+			"offer-count":       0,
+			"storage-count":     0,
 		}})
 
 	// The model has the first ID and is registered.
@@ -312,6 +315,72 @@ func (s *ControllerSuite) TestRemoveBranch(c *gc.C) {
 	s.AssertResident(c, branch.CacheId(), false)
 }
 
+func (s *ControllerSuite) TestAddRemoveRelation(c *gc.C) {
+	controller, events := s.new(c)
+	s.processChange(c, relationChange, events)
+
+	mod, err := controller.Model(modelChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mod.Report()["relation-count"], gc.Equals, 1)
+
+	rel, err := mod.Relation(relationChange.Key)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AssertResident(c, rel.CacheId(), true)
+
+	remove := cache.RemoveRelation{
+		ModelUUID: modelChange.ModelUUID,
+		Key:       relationChange.Key,
+	}
+	s.processChange(c, remove, events)
+
+	c.Check(mod.Report()["relation-count"], gc.Equals, 0)
+	s.AssertResident(c, rel.CacheId(), false)
+}
+
+func (s *ControllerSuite) TestAddRemoveOffer(c *gc.C) {
+	controller, events := s.new(c)
+	s.processChange(c, offerChange, events)
+
+	mod, err := controller.Model(modelChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mod.Report()["offer-count"], gc.Equals, 1)
+
+	offer, err := mod.Offer(offerChange.OfferName)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AssertResident(c, offer.CacheId(), true)
+
+	remove := cache.RemoveOffer{
+		ModelUUID: modelChange.ModelUUID,
+		OfferName: offerChange.OfferName,
+	}
+	s.processChange(c, remove, events)
+
+	c.Check(mod.Report()["offer-count"], gc.Equals, 0)
+	s.AssertResident(c, offer.CacheId(), false)
+}
+
+func (s *ControllerSuite) TestAddRemoveStorageInstance(c *gc.C) {
+	controller, events := s.new(c)
+	s.processChange(c, storageChange, events)
+
+	mod, err := controller.Model(modelChange.ModelUUID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(mod.Report()["storage-count"], gc.Equals, 1)
+
+	si, err := mod.StorageInstance(storageChange.Id)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AssertResident(c, si.CacheId(), true)
+
+	remove := cache.RemoveStorageInstance{
+		ModelUUID: modelChange.ModelUUID,
+		Id:        storageChange.Id,
+	}
+	s.processChange(c, remove, events)
+
+	c.Check(mod.Report()["storage-count"], gc.Equals, 0)
+	s.AssertResident(c, si.CacheId(), false)
+}
+
 func (s *ControllerSuite) TestMarkAndSweep(c *gc.C) {
 	controller, events := s.new(c)
 
@@ -37,6 +37,10 @@ func newModel(metrics *ControllerGauges, hub *pubsub.SimpleHub, res *Resident) *
 		machines:     make(map[string]*Machine),
 		units:        make(map[string]*Unit),
 		branches:     make(map[string]*Branch),
+		relations:    make(map[string]*Relation),
+		offers:       make(map[string]*Offer),
+		storage:      make(map[string]*StorageInstance),
+		status:       newStatusView(),
 	}
 	return m
 }
@@ -60,6 +64,16 @@ type Model struct {
 	machines     map[string]*Machine
 	units        map[string]*Unit
 	branches     map[string]*Branch
+	relations    map[string]*Relation
+	offers       map[string]*Offer
+	storage      map[string]*StorageInstance
+
+	// status is the incrementally maintained view of the entities
+	// above used to serve model status. statusShared indicates that
+	// the view has been handed out and must be copied before the
+	// next update.
+	status       *StatusView
+	statusShared bool
 }
 
 // Config returns the current model config.
@@ -106,6 +120,9 @@ func (m *Model) Report() map[string]interface{} {
 		"machine-count":     len(m.machines),
 		"unit-count":        len(m.units),
 		"branch-count":      len(m.branches),
+		"relation-count":    len(m.relations),
+		"offer-count":       len(m.offers),
+		"storage-count":     len(m.storage),
 	}
 }
 
@@ -221,6 +238,84 @@ func (m *Model) Charm(charmURL string) (Charm, error) {
 	return charm.copy(), nil
 }
 
+// Relations makes a copy of the model's relation collection and returns it,
+// keyed by relation key.
+func (m *Model) Relations() map[string]Relation {
+	m.mu.Lock()
+
+	relations := make(map[string]Relation, len(m.relations))
+	for k, v := range m.relations {
+		relations[k] = v.copy()
+	}
+
+	m.mu.Unlock()
+	return relations
+}
+
+// Relation returns the relation with the input key.
+// If the relation is not found, a NotFoundError is returned.
+func (m *Model) Relation(key string) (Relation, error) {
+	defer m.doLocked()()
+
+	relation, found := m.relations[key]
+	if !found {
+		return Relation{}, errors.NotFoundf("relation %q", key)
+	}
+	return relation.copy(), nil
+}
+
+// Offers makes a copy of the model's application offer collection
+// and returns it, keyed by offer name.
+func (m *Model) Offers() map[string]Offer {
+	m.mu.Lock()
+
+	offers := make(map[string]Offer, len(m.offers))
+	for k, v := range m.offers {
+		offers[k] = v.copy()
+	}
+
+	m.mu.Unlock()
+	return offers
+}
+
+// Offer returns the application offer with the input name.
+// If the offer is not found, a NotFoundError is returned.
+func (m *Model) Offer(name string) (Offer, error) {
+	defer m.doLocked()()
+
+	offer, found := m.offers[name]
+	if !found {
+		return Offer{}, errors.NotFoundf("offer %q", name)
+	}
+	return offer.copy(), nil
+}
+
+// StorageInstances makes a copy of the model's storage instance collection
+// and returns it, keyed by storage instance id.
+func (m *Model) StorageInstances() map[string]StorageInstance {
+	m.mu.Lock()
+
+	storage := make(map[string]StorageInstance, len(m.storage))
+	for k, v := range m.storage {
+		storage[k] = v.copy()
+	}
+
+	m.mu.Unlock()
+	return storage
+}
+
+// StorageInstance returns the storage instance with the input id.
+// If the storage instance is not found, a NotFoundError is returned.
+func (m *Model) StorageInstance(id string) (StorageInstance, error) {
+	defer m.doLocked()()
+
+	storage, found := m.storage[id]
+	if !found {
+		return StorageInstance{}, errors.NotFoundf("storage instance %q", id)
+	}
+	return storage.copy(), nil
+}
+
 // WatchMachines returns a PredicateStringsWatcher to notify about
 // added and removed machines in the model.  The initial event contains
 // a slice of the current machine ids.  Containers are excluded.
@@ -266,6 +361,7 @@ func (m *Model) updateApplication(ch ApplicationChange, rm *residentManager) {
 		m.applications[ch.Name] = app
 	}
 	app.setDetails(ch)
+	m.statusForUpdate().Applications[ch.Name] = ch.copy()
 
 	m.mu.Unlock()
 }
@@ -280,6 +376,7 @@ func (m *Model) removeApplication(ch RemoveApplication) error {
 			return errors.Trace(err)
 		}
 		delete(m.applications, ch.Name)
+		delete(m.statusForUpdate().Applications, ch.Name)
 	}
 	return nil
 }
@@ -294,6 +391,7 @@ func (m *Model) updateCharm(ch CharmChange, rm *residentManager) {
 		m.charms[ch.CharmURL] = charm
 	}
 	charm.setDetails(ch)
+	m.statusForUpdate().Charms[ch.CharmURL] = ch.copy()
 
 	m.mu.Unlock()
 }
@@ -308,6 +406,7 @@ func (m *Model) removeCharm(ch RemoveCharm) error {
 			return errors.Trace(err)
 		}
 		delete(m.charms, ch.CharmURL)
+		delete(m.statusForUpdate().Charms, ch.CharmURL)
 	}
 	return nil
 }
@@ -322,6 +421,7 @@ func (m *Model) updateUnit(ch UnitChange, rm *residentManager) {
 		m.units[ch.Name] = unit
 	}
 	unit.setDetails(ch)
+	m.statusForUpdate().Units[ch.Name] = ch.copy()
 
 	m.mu.Unlock()
 }
@@ -337,6 +437,7 @@ func (m *Model) removeUnit(ch RemoveUnit) error {
 			return errors.Trace(err)
 		}
 		delete(m.units, ch.Name)
+		delete(m.statusForUpdate().Units, ch.Name)
 	}
 	return nil
 }
@@ -352,6 +453,7 @@ func (m *Model) updateMachine(ch MachineChange, rm *residentManager) {
 		m.hub.Publish(modelAddRemoveMachine, []string{ch.Id})
 	}
 	machine.setDetails(ch)
+	m.statusForUpdate().Machines[ch.Id] = ch.copy()
 
 	m.mu.Unlock()
 }
@@ -367,6 +469,7 @@ func (m *Model) removeMachine(ch RemoveMachine) error {
 			return errors.Trace(err)
 		}
 		delete(m.machines, ch.Id)
+		delete(m.statusForUpdate().Machines, ch.Id)
 	}
 	return nil
 }
@@ -403,6 +506,90 @@ func (m *Model) removeBranch(ch RemoveBranch) error {
 	return nil
 }
 
+// updateRelation adds or updates the relation in the model.
+func (m *Model) updateRelation(ch RelationChange, rm *residentManager) {
+	m.mu.Lock()
+
+	relation, found := m.relations[ch.Key]
+	if !found {
+		relation = newRelation(m.metrics, m.hub, rm.new())
+		m.relations[ch.Key] = relation
+	}
+	relation.setDetails(ch)
+
+	m.mu.Unlock()
+}
+
+// removeRelation removes the relation from the model.
+func (m *Model) removeRelation(ch RemoveRelation) error {
+	defer m.doLocked()()
+
+	relation, ok := m.relations[ch.Key]
+	if ok {
+		if err := relation.evict(); err != nil {
+			return errors.Trace(err)
+		}
+		delete(m.relations, ch.Key)
+	}
+	return nil
+}
+
+// updateOffer adds or updates the application offer in the model.
+func (m *Model) updateOffer(ch OfferChange, rm *residentManager) {
+	m.mu.Lock()
+
+	offer, found := m.offers[ch.OfferName]
+	if !found {
+		offer = newOffer(m.metrics, m.hub, rm.new())
+		m.offers[ch.OfferName] = offer
+	}
+	offer.setDetails(ch)
+
+	m.mu.Unlock()
+}
+
+// removeOffer removes the application offer from the model.
+func (m *Model) removeOffer(ch RemoveOffer) error {
+	defer m.doLocked()()
+
+	offer, ok := m.offers[ch.OfferName]
+	if ok {
+		if err := offer.evict(); err != nil {
+			return errors.Trace(err)
+		}
+		delete(m.offers, ch.OfferName)
+	}
+	return nil
+}
+
+// updateStorageInstance adds or updates the storage instance in the model.
+func (m *Model) updateStorageInstance(ch StorageInstanceChange, rm *residentManager) {
+	m.mu.Lock()
+
+	storage, found := m.storage[ch.Id]
+	if !found {
+		storage = newStorageInstance(m.metrics, m.hub, rm.new())
+		m.storage[ch.Id] = storage
+	}
+	storage.setDetails(ch)
+
+	m.mu.Unlock()
+}
+
+// removeStorageInstance removes the storage instance from the model.
+func (m *Model) removeStorageInstance(ch RemoveStorageInstance) error {
+	defer m.doLocked()()
+
+	storage, ok := m.storage[ch.Id]
+	if ok {
+		if err := storage.evict(); err != nil {
+			return errors.Trace(err)
+		}
+		delete(m.storage, ch.Id)
+	}
+	return nil
+}
+
 func (m *Model) setDetails(details ModelChange) {
 	m.mu.Lock()
 
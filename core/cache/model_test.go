@@ -35,6 +35,9 @@ func (s *ModelSuite) TestReport(c *gc.C) {
 		"machine-count":     0,
 		"unit-count":        0,
 		"branch-count":      0,
+		"relation-count":    0,
+		"offer-count":       0,
+		"storage-count":     0,
 	})
 }
 
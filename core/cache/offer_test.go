@@ -0,0 +1,58 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/cache"
+)
+
+type OfferSuite struct {
+	cache.EntitySuite
+}
+
+var _ = gc.Suite(&OfferSuite{})
+
+var offerChange = cache.OfferChange{
+	ModelUUID:            "model-uuid",
+	OfferName:            "hosted-mysql",
+	OfferUUID:            "hosted-mysql-uuid",
+	ApplicationName:      "mysql",
+	CharmName:            "mysql",
+	TotalConnectedCount:  2,
+	ActiveConnectedCount: 1,
+}
+
+func (s *OfferSuite) TestOffer(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateOffer(offerChange, s.Manager)
+
+	offer, err := m.Offer(offerChange.OfferName)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(offer.Name(), gc.Equals, "hosted-mysql")
+	c.Check(offer.UUID(), gc.Equals, "hosted-mysql-uuid")
+	c.Check(offer.ApplicationName(), gc.Equals, "mysql")
+	c.Check(offer.CharmName(), gc.Equals, "mysql")
+	c.Check(offer.TotalConnectedCount(), gc.Equals, 2)
+	c.Check(offer.ActiveConnectedCount(), gc.Equals, 1)
+
+	c.Check(m.Offers(), gc.HasLen, 1)
+}
+
+func (s *OfferSuite) TestRemoveOffer(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateOffer(offerChange, s.Manager)
+
+	err := m.RemoveOffer(cache.RemoveOffer{
+		ModelUUID: offerChange.ModelUUID,
+		OfferName: offerChange.OfferName,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = m.Offer(offerChange.OfferName)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -0,0 +1,78 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/cache"
+)
+
+type RelationSuite struct {
+	cache.EntitySuite
+}
+
+var _ = gc.Suite(&RelationSuite{})
+
+var relationChange = cache.RelationChange{
+	ModelUUID: "model-uuid",
+	Key:       "logging:info mysql:juju-info",
+	Id:        1,
+	Endpoints: []cache.RelationEndpoint{{
+		Application: "logging",
+		Name:        "info",
+		Role:        "requirer",
+		Interface:   "juju-info",
+		Scope:       "container",
+	}, {
+		Application: "mysql",
+		Name:        "juju-info",
+		Role:        "provider",
+		Interface:   "juju-info",
+		Scope:       "global",
+	}},
+}
+
+func (s *RelationSuite) TestRelation(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateRelation(relationChange, s.Manager)
+
+	rel, err := m.Relation(relationChange.Key)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rel.Key(), gc.Equals, relationChange.Key)
+	c.Check(rel.Id(), gc.Equals, 1)
+	c.Check(rel.Endpoints(), jc.DeepEquals, relationChange.Endpoints)
+	c.Check(rel.Applications(), jc.DeepEquals, []string{"logging", "mysql"})
+
+	c.Check(m.Relations(), gc.HasLen, 1)
+}
+
+func (s *RelationSuite) TestRelationCopyIsolated(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateRelation(relationChange, s.Manager)
+
+	rel, err := m.Relation(relationChange.Key)
+	c.Assert(err, jc.ErrorIsNil)
+	rel.Endpoints()[0].Name = "changed"
+
+	rel, err = m.Relation(relationChange.Key)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rel.Endpoints()[0].Name, gc.Equals, "info")
+}
+
+func (s *RelationSuite) TestRemoveRelation(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateRelation(relationChange, s.Manager)
+
+	err := m.RemoveRelation(cache.RemoveRelation{
+		ModelUUID: relationChange.ModelUUID,
+		Key:       relationChange.Key,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = m.Relation(relationChange.Key)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
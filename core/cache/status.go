@@ -0,0 +1,79 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache
+
+// StatusView is a point-in-time view of the model entities that make up
+// the model status. The view is maintained incrementally as changes flow
+// into the cache, rather than being assembled on request, and is shared
+// between all callers until the next change arrives. It must be treated
+// as read-only.
+type StatusView struct {
+	// Revision is incremented every time an entity in the view changes.
+	// Two views with the same revision hold the same content.
+	Revision uint64
+
+	Applications map[string]ApplicationChange
+	Charms       map[string]CharmChange
+	Machines     map[string]MachineChange
+	Units        map[string]UnitChange
+}
+
+func newStatusView() *StatusView {
+	return &StatusView{
+		Applications: make(map[string]ApplicationChange),
+		Charms:       make(map[string]CharmChange),
+		Machines:     make(map[string]MachineChange),
+		Units:        make(map[string]UnitChange),
+	}
+}
+
+// clone returns a copy of the view that can be modified without
+// affecting the original. The change values stored in a view are never
+// modified in place, so only the maps themselves need to be copied.
+func (v *StatusView) clone() *StatusView {
+	cv := &StatusView{
+		Revision:     v.Revision,
+		Applications: make(map[string]ApplicationChange, len(v.Applications)),
+		Charms:       make(map[string]CharmChange, len(v.Charms)),
+		Machines:     make(map[string]MachineChange, len(v.Machines)),
+		Units:        make(map[string]UnitChange, len(v.Units)),
+	}
+	for k, a := range v.Applications {
+		cv.Applications[k] = a
+	}
+	for k, c := range v.Charms {
+		cv.Charms[k] = c
+	}
+	for k, m := range v.Machines {
+		cv.Machines[k] = m
+	}
+	for k, u := range v.Units {
+		cv.Units[k] = u
+	}
+	return cv
+}
+
+// StatusView returns the current status view of the model.
+// The same view is returned to every caller until the model changes,
+// so repeated requests for status cost nothing beyond the rendering
+// done by the caller.
+func (m *Model) StatusView() *StatusView {
+	defer m.doLocked()()
+
+	m.statusShared = true
+	return m.status
+}
+
+// statusForUpdate returns the status view ready to be updated with
+// a single change. If the current view has been handed out, it is
+// copied first so that readers never observe a partial update.
+// The model lock must be held by the caller.
+func (m *Model) statusForUpdate() *StatusView {
+	if m.statusShared {
+		m.status = m.status.clone()
+		m.statusShared = false
+	}
+	m.status.Revision++
+	return m.status
+}
@@ -0,0 +1,78 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/status"
+)
+
+type StatusViewSuite struct {
+	cache.EntitySuite
+}
+
+var _ = gc.Suite(&StatusViewSuite{})
+
+func (s *StatusViewSuite) TestEmptyModel(c *gc.C) {
+	m := s.NewModel(modelChange)
+
+	view := m.StatusView()
+	c.Check(view.Revision, gc.Equals, uint64(0))
+	c.Check(view.Applications, gc.HasLen, 0)
+	c.Check(view.Charms, gc.HasLen, 0)
+	c.Check(view.Machines, gc.HasLen, 0)
+	c.Check(view.Units, gc.HasLen, 0)
+}
+
+func (s *StatusViewSuite) TestTracksChanges(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateApplication(appChange, s.Manager)
+	m.UpdateCharm(charmChange, s.Manager)
+	m.UpdateMachine(machineChange, s.Manager)
+	m.UpdateUnit(unitChange, s.Manager)
+
+	view := m.StatusView()
+	c.Check(view.Revision, gc.Equals, uint64(4))
+	c.Check(view.Applications[appChange.Name].CharmURL, gc.Equals, appChange.CharmURL)
+	c.Check(view.Charms[charmChange.CharmURL].DefaultConfig, jc.DeepEquals, charmChange.DefaultConfig)
+	c.Check(view.Machines[machineChange.Id].Series, gc.Equals, machineChange.Series)
+	c.Check(view.Units[unitChange.Name].Application, gc.Equals, unitChange.Application)
+
+	c.Assert(m.RemoveUnit(cache.RemoveUnit{ModelUUID: unitChange.ModelUUID, Name: unitChange.Name}), jc.ErrorIsNil)
+	view = m.StatusView()
+	c.Check(view.Revision, gc.Equals, uint64(5))
+	c.Check(view.Units, gc.HasLen, 0)
+}
+
+func (s *StatusViewSuite) TestSharedUntilChanged(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateUnit(unitChange, s.Manager)
+
+	view1 := m.StatusView()
+	c.Check(m.StatusView(), gc.Equals, view1)
+
+	ch := unitChange
+	ch.WorkloadStatus = status.StatusInfo{Status: status.Blocked}
+	m.UpdateUnit(ch, s.Manager)
+
+	// The view already handed out is not affected by the change.
+	view2 := m.StatusView()
+	c.Check(view2, gc.Not(gc.Equals), view1)
+	c.Check(view2.Revision, gc.Equals, view1.Revision+1)
+	c.Check(view1.Units[unitChange.Name].WorkloadStatus.Status, gc.Equals, unitChange.WorkloadStatus.Status)
+	c.Check(view2.Units[unitChange.Name].WorkloadStatus.Status, gc.Equals, status.Blocked)
+}
+
+func (s *StatusViewSuite) TestDetachedFromChange(c *gc.C) {
+	m := s.NewModel(modelChange)
+	ch := appChange
+	ch.Config = map[string]interface{}{"key": "value"}
+	m.UpdateApplication(ch, s.Manager)
+
+	ch.Config["key"] = "changed"
+	c.Check(m.StatusView().Applications[ch.Name].Config, jc.DeepEquals, map[string]interface{}{"key": "value"})
+}
@@ -0,0 +1,99 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache
+
+import (
+	"github.com/juju/pubsub"
+
+	"github.com/juju/juju/core/life"
+)
+
+func newStorageInstance(metrics *ControllerGauges, hub *pubsub.SimpleHub, res *Resident) *StorageInstance {
+	return &StorageInstance{
+		Resident: res,
+		metrics:  metrics,
+		hub:      hub,
+	}
+}
+
+// StorageInstance represents a storage instance in a cached model.
+type StorageInstance struct {
+	// Resident identifies the storage instance as a type-agnostic cached
+	// entity and tracks resources that it is responsible for cleaning up.
+	*Resident
+
+	metrics *ControllerGauges
+	hub     *pubsub.SimpleHub
+
+	details StorageInstanceChange
+}
+
+// Note that these property accessors are not lock-protected.
+// They are intended for calling from external packages that have retrieved a
+// deep copy from the cache.
+
+// Id returns the storage instance id, for example "data/0".
+func (s *StorageInstance) Id() string {
+	return s.details.Id
+}
+
+// Kind returns the kind of storage, "block" or "filesystem".
+func (s *StorageInstance) Kind() string {
+	return s.details.Kind
+}
+
+// Life returns the life of the storage instance.
+func (s *StorageInstance) Life() life.Value {
+	return s.details.Life
+}
+
+// Owner returns the tag of the entity owning the storage instance,
+// if any.
+func (s *StorageInstance) Owner() string {
+	return s.details.Owner
+}
+
+// StorageName returns the name of the charm storage
+// that the instance was created for.
+func (s *StorageInstance) StorageName() string {
+	return s.details.StorageName
+}
+
+// Pool returns the name of the storage pool the instance
+// was provisioned from.
+func (s *StorageInstance) Pool() string {
+	return s.details.Pool
+}
+
+// Size returns the requested size of the storage instance in MiB.
+func (s *StorageInstance) Size() uint64 {
+	return s.details.Size
+}
+
+// AttachmentCount returns the number of units the storage
+// instance is attached to.
+func (s *StorageInstance) AttachmentCount() int {
+	return s.details.AttachmentCount
+}
+
+func (s *StorageInstance) setDetails(details StorageInstanceChange) {
+	// If this is the first receipt of details, set the removal message.
+	if s.removalMessage == nil {
+		s.removalMessage = RemoveStorageInstance{
+			ModelUUID: details.ModelUUID,
+			Id:        details.Id,
+		}
+	}
+
+	s.setStale(false)
+	s.details = details
+}
+
+// copy returns a copy of the storage instance,
+// ensuring appropriate deep copying.
+func (s *StorageInstance) copy() StorageInstance {
+	cs := *s
+	cs.details = cs.details.copy()
+	return cs
+}
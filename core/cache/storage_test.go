@@ -0,0 +1,63 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cache_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/cache"
+	"github.com/juju/juju/core/life"
+)
+
+type StorageInstanceSuite struct {
+	cache.EntitySuite
+}
+
+var _ = gc.Suite(&StorageInstanceSuite{})
+
+var storageChange = cache.StorageInstanceChange{
+	ModelUUID:       "model-uuid",
+	Id:              "data/0",
+	Kind:            "block",
+	Life:            life.Alive,
+	Owner:           "unit-storage-block-0",
+	StorageName:     "data",
+	Pool:            "loop",
+	Size:            1024,
+	AttachmentCount: 1,
+}
+
+func (s *StorageInstanceSuite) TestStorageInstance(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateStorageInstance(storageChange, s.Manager)
+
+	si, err := m.StorageInstance(storageChange.Id)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(si.Id(), gc.Equals, "data/0")
+	c.Check(si.Kind(), gc.Equals, "block")
+	c.Check(si.Life(), gc.Equals, life.Alive)
+	c.Check(si.Owner(), gc.Equals, "unit-storage-block-0")
+	c.Check(si.StorageName(), gc.Equals, "data")
+	c.Check(si.Pool(), gc.Equals, "loop")
+	c.Check(si.Size(), gc.Equals, uint64(1024))
+	c.Check(si.AttachmentCount(), gc.Equals, 1)
+
+	c.Check(m.StorageInstances(), gc.HasLen, 1)
+}
+
+func (s *StorageInstanceSuite) TestRemoveStorageInstance(c *gc.C) {
+	m := s.NewModel(modelChange)
+	m.UpdateStorageInstance(storageChange, s.Manager)
+
+	err := m.RemoveStorageInstance(cache.RemoveStorageInstance{
+		ModelUUID: storageChange.ModelUUID,
+		Id:        storageChange.Id,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = m.StorageInstance(storageChange.Id)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
@@ -0,0 +1,280 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package charmlint statically analyses charms, reporting the problems
+// which would break them once deployed, such as hooks which cannot be
+// executed, along with lesser issues such as undocumented config
+// options.
+package charmlint
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/charm.v6"
+)
+
+// Severity describes how serious a finding is.
+type Severity string
+
+const (
+	// Error findings describe problems which break the charm; charms
+	// with them are rejected.
+	Error Severity = "error"
+
+	// Warning findings describe problems which don't stop the charm
+	// from working.
+	Warning Severity = "warning"
+)
+
+// Finding describes a problem found in a charm.
+type Finding struct {
+	Severity Severity
+
+	// Path is the path of the file with the problem, relative to the
+	// charm's root directory.
+	Path string
+
+	Message string
+}
+
+// String returns the finding as "path: message".
+func (f Finding) String() string {
+	if f.Path == "" {
+		return f.Message
+	}
+	return f.Path + ": " + f.Message
+}
+
+// HasErrors reports whether any of the findings are errors.
+func HasErrors(findings []Finding) bool {
+	for _, f := range findings {
+		if f.Severity == Error {
+			return true
+		}
+	}
+	return false
+}
+
+// Errors returns the findings which are errors.
+func Errors(findings []Finding) []Finding {
+	var result []Finding
+	for _, f := range findings {
+		if f.Severity == Error {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// forbiddenPattern describes a pattern which may not appear in a
+// charm's hooks and actions.
+type forbiddenPattern struct {
+	re       *regexp.Regexp
+	severity Severity
+	message  string
+}
+
+var forbiddenPatterns = []forbiddenPattern{{
+	re:       regexp.MustCompile(`\brm\s+(-[a-zA-Z]*\s+)*-[a-zA-Z]*[rR][a-zA-Z]*\s+(-[a-zA-Z]*\s+)*/(\*)?(\s|;|$)`),
+	severity: Error,
+	message:  "removes the root directory",
+}, {
+	re:       regexp.MustCompile(`\b(curl|wget)\b[^|\n]*\|\s*(sudo\s+)?(ba|da|z)?sh\b`),
+	severity: Warning,
+	message:  "pipes a download into a shell",
+}}
+
+// Lint statically analyses the charm in the given directory, returning
+// the problems found ordered by path.
+func Lint(dir *charm.CharmDir) ([]Finding, error) {
+	var findings []Finding
+	findings = append(findings, lintMetadata(dir.Meta())...)
+	findings = append(findings, lintConfig(dir.Config())...)
+	findings = append(findings, lintActions(dir.Actions())...)
+
+	executables, err := lintExecutables(dir)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	findings = append(findings, executables...)
+
+	links, err := lintSymlinks(dir.Path)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	findings = append(findings, links...)
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Path < findings[j].Path
+	})
+	return findings, nil
+}
+
+func lintMetadata(meta *charm.Meta) []Finding {
+	var findings []Finding
+	if strings.TrimSpace(meta.Summary) == "" {
+		findings = append(findings, Finding{Warning, "metadata.yaml", "no summary"})
+	}
+	if strings.TrimSpace(meta.Description) == "" {
+		findings = append(findings, Finding{Warning, "metadata.yaml", "no description"})
+	}
+	return findings
+}
+
+func lintConfig(config *charm.Config) []Finding {
+	if config == nil {
+		return nil
+	}
+	var findings []Finding
+	for name, option := range config.Options {
+		if strings.TrimSpace(option.Description) == "" {
+			findings = append(findings, Finding{Warning, "config.yaml", fmt.Sprintf("option %q has no description", name)})
+		}
+	}
+	sortByMessage(findings)
+	return findings
+}
+
+func lintActions(actions *charm.Actions) []Finding {
+	if actions == nil {
+		return nil
+	}
+	var findings []Finding
+	for name, spec := range actions.ActionSpecs {
+		if strings.TrimSpace(spec.Description) == "" {
+			findings = append(findings, Finding{Warning, "actions.yaml", fmt.Sprintf("action %q has no description", name)})
+		}
+	}
+	sortByMessage(findings)
+	return findings
+}
+
+// lintExecutables checks that the charm's hooks, actions and dispatch
+// script can be executed, and don't contain forbidden patterns.
+func lintExecutables(dir *charm.CharmDir) ([]Finding, error) {
+	paths := []string{"dispatch"}
+	for hook := range dir.Meta().Hooks() {
+		paths = append(paths, filepath.Join("hooks", hook))
+	}
+	if actions := dir.Actions(); actions != nil {
+		for action := range actions.ActionSpecs {
+			paths = append(paths, filepath.Join("actions", action))
+		}
+	}
+	sort.Strings(paths)
+
+	var findings []Finding
+	for _, path := range paths {
+		found, err := lintExecutable(dir.Path, path)
+		if err != nil {
+			return nil, errors.Annotatef(err, "checking %s", path)
+		}
+		findings = append(findings, found...)
+	}
+	return findings, nil
+}
+
+func lintExecutable(root, path string) ([]Finding, error) {
+	info, err := os.Stat(filepath.Join(root, path))
+	if os.IsNotExist(err) {
+		// Missing hooks are fine, and dangling links are reported
+		// by lintSymlinks.
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if info.IsDir() {
+		return []Finding{{Error, path, "is a directory"}}, nil
+	}
+	var findings []Finding
+	if info.Mode()&0111 == 0 {
+		findings = append(findings, Finding{Error, path, "not executable"})
+	}
+	data, err := ioutil.ReadFile(filepath.Join(root, path))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	firstLine := data
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		firstLine = data[:i]
+	}
+	switch {
+	case bytes.HasPrefix(data, []byte("\x7fELF")):
+		// Binaries are executed directly.
+		return findings, nil
+	case !bytes.HasPrefix(firstLine, []byte("#!")):
+		findings = append(findings, Finding{Error, path, "no interpreter line"})
+	case bytes.HasSuffix(firstLine, []byte("\r")):
+		findings = append(findings, Finding{Error, path, "interpreter line has Windows line ending"})
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := scanner.Text()
+		if strings.HasPrefix(strings.TrimSpace(text), "#") {
+			continue
+		}
+		for _, p := range forbiddenPatterns {
+			if p.re.MatchString(text) {
+				findings = append(findings, Finding{p.severity, path, fmt.Sprintf("line %d %s", line, p.message)})
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return findings, nil
+}
+
+// lintSymlinks checks that the charm's symlinks refer to files within
+// the charm.
+func lintSymlinks(root string) ([]Finding, error) {
+	var findings []Finding
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		target, err := os.Readlink(path)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if filepath.IsAbs(target) {
+			findings = append(findings, Finding{Error, rel, fmt.Sprintf("absolute symlink to %q", target)})
+			return nil
+		}
+		resolved := filepath.Join(filepath.Dir(rel), target)
+		if resolved == ".." || strings.HasPrefix(resolved, "../") {
+			findings = append(findings, Finding{Error, rel, fmt.Sprintf("symlink to %q outside the charm", target)})
+			return nil
+		}
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			findings = append(findings, Finding{Error, rel, fmt.Sprintf("dangling symlink to %q", target)})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return findings, nil
+}
+
+func sortByMessage(findings []Finding) {
+	sort.Slice(findings, func(i, j int) bool {
+		return findings[i].Message < findings[j].Message
+	})
+}
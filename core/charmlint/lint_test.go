@@ -0,0 +1,131 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package charmlint_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/core/charmlint"
+)
+
+type LintSuite struct {
+	testing.IsolationSuite
+	dir string
+}
+
+var _ = gc.Suite(&LintSuite{})
+
+const goodHook = "#!/bin/sh\necho hello\n"
+
+func (s *LintSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.dir = c.MkDir()
+	s.writeFile(c, "metadata.yaml", `
+name: lint
+summary: A charm to lint.
+description: A charm to lint.
+`, 0644)
+	s.writeFile(c, "hooks/install", goodHook, 0755)
+}
+
+func (s *LintSuite) writeFile(c *gc.C, path, content string, mode os.FileMode) {
+	path = filepath.Join(s.dir, path)
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(path, []byte(content), mode)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *LintSuite) lint(c *gc.C) []charmlint.Finding {
+	dir, err := charm.ReadCharmDir(s.dir)
+	c.Assert(err, jc.ErrorIsNil)
+	findings, err := charmlint.Lint(dir)
+	c.Assert(err, jc.ErrorIsNil)
+	return findings
+}
+
+func (s *LintSuite) TestClean(c *gc.C) {
+	c.Assert(s.lint(c), gc.HasLen, 0)
+}
+
+func (s *LintSuite) TestDocumentation(c *gc.C) {
+	s.writeFile(c, "metadata.yaml", "name: lint\nsummary: ''\ndescription: ''\n", 0644)
+	s.writeFile(c, "config.yaml", `
+options:
+  port:
+    type: int
+    default: 80
+  name:
+    type: string
+    description: The name.
+`, 0644)
+	s.writeFile(c, "actions.yaml", "backup: {}\n", 0644)
+	s.writeFile(c, "actions/backup", goodHook, 0755)
+	c.Assert(s.lint(c), jc.DeepEquals, []charmlint.Finding{
+		{Severity: charmlint.Warning, Path: "actions.yaml", Message: `action "backup" has no description`},
+		{Severity: charmlint.Warning, Path: "config.yaml", Message: `option "port" has no description`},
+		{Severity: charmlint.Warning, Path: "metadata.yaml", Message: "no summary"},
+		{Severity: charmlint.Warning, Path: "metadata.yaml", Message: "no description"},
+	})
+}
+
+func (s *LintSuite) TestExecutables(c *gc.C) {
+	s.writeFile(c, "hooks/install", "#!/bin/sh\r\necho hello\r\n", 0755)
+	s.writeFile(c, "hooks/start", goodHook, 0644)
+	s.writeFile(c, "hooks/stop", "echo goodbye\n", 0755)
+	s.writeFile(c, "hooks/helper", "not a hook", 0644)
+	s.writeFile(c, "dispatch", goodHook, 0755)
+	findings := s.lint(c)
+	c.Assert(findings, jc.DeepEquals, []charmlint.Finding{
+		{Severity: charmlint.Error, Path: "hooks/install", Message: "interpreter line has Windows line ending"},
+		{Severity: charmlint.Error, Path: "hooks/start", Message: "not executable"},
+		{Severity: charmlint.Error, Path: "hooks/stop", Message: "no interpreter line"},
+	})
+	c.Assert(charmlint.HasErrors(findings), jc.IsTrue)
+}
+
+func (s *LintSuite) TestForbiddenPatterns(c *gc.C) {
+	s.writeFile(c, "hooks/install", `#!/bin/sh
+# rm -rf / is fine in a comment
+curl -s https://example.com/setup.sh | sudo bash
+rm -rf /var/lib/lint
+rm -rf /
+`, 0755)
+	findings := s.lint(c)
+	c.Assert(findings, jc.DeepEquals, []charmlint.Finding{
+		{Severity: charmlint.Warning, Path: "hooks/install", Message: "line 3 pipes a download into a shell"},
+		{Severity: charmlint.Error, Path: "hooks/install", Message: "line 5 removes the root directory"},
+	})
+	c.Assert(charmlint.Errors(findings), jc.DeepEquals, findings[1:])
+}
+
+func (s *LintSuite) TestSymlinks(c *gc.C) {
+	err := os.Symlink("install", filepath.Join(s.dir, "hooks", "start"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.Symlink("/etc/passwd", filepath.Join(s.dir, "passwd"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.Symlink("../../secret", filepath.Join(s.dir, "hooks", "secret"))
+	c.Assert(err, jc.ErrorIsNil)
+	err = os.Symlink("missing", filepath.Join(s.dir, "hooks", "stop"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.lint(c), jc.DeepEquals, []charmlint.Finding{
+		{Severity: charmlint.Error, Path: "hooks/secret", Message: `symlink to "../../secret" outside the charm`},
+		{Severity: charmlint.Error, Path: "hooks/stop", Message: `dangling symlink to "missing"`},
+		{Severity: charmlint.Error, Path: "passwd", Message: `absolute symlink to "/etc/passwd"`},
+	})
+}
+
+func (s *LintSuite) TestFindingString(c *gc.C) {
+	f := charmlint.Finding{Severity: charmlint.Error, Path: "hooks/start", Message: "not executable"}
+	c.Assert(f.String(), gc.Equals, "hooks/start: not executable")
+	f.Path = ""
+	c.Assert(f.String(), gc.Equals, "not executable")
+}
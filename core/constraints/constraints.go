@@ -26,6 +26,8 @@ const (
 	cpuCores       = "cpu-cores"
 	Cores          = "cores"
 	CpuPower       = "cpu-power"
+	GPUs           = "gpus"
+	GPUType        = "gpu-type"
 	Mem            = "mem"
 	RootDisk       = "root-disk"
 	RootDiskSource = "root-disk-source"
@@ -58,6 +60,14 @@ type Value struct {
 	// equivalent to 1 Amazon ECU (or, roughly, a single 2007-era Xeon).
 	CpuPower *uint64 `json:"cpu-power,omitempty" yaml:"cpu-power,omitempty"`
 
+	// GPUs, if not nil, indicates that a machine must have at least that
+	// number of GPUs or other accelerators attached.
+	GPUs *uint64 `json:"gpus,omitempty" yaml:"gpus,omitempty"`
+
+	// GPUType, if not nil or empty, indicates that a machine's GPUs must
+	// be of the named model, such as "t4" or "v100".
+	GPUType *string `json:"gpu-type,omitempty" yaml:"gpu-type,omitempty"`
+
 	// Mem, if not nil, indicates that a machine must have at least that many
 	// megabytes of RAM.
 	Mem *uint64 `json:"mem,omitempty" yaml:"mem,omitempty"`
@@ -140,6 +150,17 @@ func (v *Value) HasCpuCores() bool {
 	return v.CpuCores != nil && *v.CpuCores > 0
 }
 
+// HasGPUs returns true if the constraints.Value specifies a minimum number
+// of GPUs.
+func (v *Value) HasGPUs() bool {
+	return v.GPUs != nil && *v.GPUs > 0
+}
+
+// HasGPUType returns true if the constraints.Value specifies a GPU model.
+func (v *Value) HasGPUType() bool {
+	return v.GPUType != nil && *v.GPUType != ""
+}
+
 // HasRootDisk returns true if the contraints.Value specifies a RootDisk size.
 func (v *Value) HasRootDisk() bool {
 	return v.RootDisk != nil && *v.RootDisk > 0
@@ -223,6 +244,12 @@ func (v Value) String() string {
 	if v.CpuPower != nil {
 		strs = append(strs, "cpu-power="+uintStr(*v.CpuPower))
 	}
+	if v.GPUs != nil {
+		strs = append(strs, "gpus="+uintStr(*v.GPUs))
+	}
+	if v.GPUType != nil {
+		strs = append(strs, "gpu-type="+(*v.GPUType))
+	}
 	if v.InstanceType != nil {
 		strs = append(strs, "instance-type="+(*v.InstanceType))
 	}
@@ -275,6 +302,12 @@ func (v Value) GoString() string {
 	if v.CpuPower != nil {
 		values = append(values, fmt.Sprintf("CpuPower: %v", *v.CpuPower))
 	}
+	if v.GPUs != nil {
+		values = append(values, fmt.Sprintf("GPUs: %v", *v.GPUs))
+	}
+	if v.GPUType != nil {
+		values = append(values, fmt.Sprintf("GPUType: %q", *v.GPUType))
+	}
 	if v.Mem != nil {
 		values = append(values, fmt.Sprintf("Mem: %v", *v.Mem))
 	}
@@ -449,6 +482,10 @@ func (v *Value) setRaw(name, str string) error {
 		err = v.setCpuCores(str)
 	case CpuPower:
 		err = v.setCpuPower(str)
+	case GPUs:
+		err = v.setGPUs(str)
+	case GPUType:
+		err = v.setGPUType(str)
 	case Mem:
 		err = v.setMem(str)
 	case RootDisk:
@@ -510,6 +547,10 @@ func (v *Value) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			v.CpuCores, err = parseUint64(vstr)
 		case CpuPower:
 			v.CpuPower, err = parseUint64(vstr)
+		case GPUs:
+			v.GPUs, err = parseUint64(vstr)
+		case GPUType:
+			v.GPUType = &vstr
 		case Mem:
 			v.Mem, err = parseUint64(vstr)
 		case RootDisk:
@@ -591,6 +632,22 @@ func (v *Value) setCpuPower(str string) (err error) {
 	return
 }
 
+func (v *Value) setGPUs(str string) (err error) {
+	if v.GPUs != nil {
+		return errors.Errorf("already set")
+	}
+	v.GPUs, err = parseUint64(str)
+	return
+}
+
+func (v *Value) setGPUType(str string) error {
+	if v.GPUType != nil {
+		return errors.Errorf("already set")
+	}
+	v.GPUType = &str
+	return nil
+}
+
 func (v *Value) setInstanceType(str string) error {
 	if v.InstanceType != nil {
 		return errors.Errorf("already set")
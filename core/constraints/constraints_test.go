@@ -166,6 +166,39 @@ var parseConstraintsTests = []struct {
 		err:     `bad "cpu-power" constraint: already set`,
 	},
 
+	// "gpus" in detail.
+	{
+		summary: "set gpus empty",
+		args:    []string{"gpus="},
+	}, {
+		summary: "set gpus zero",
+		args:    []string{"gpus=0"},
+	}, {
+		summary: "set gpus",
+		args:    []string{"gpus=4"},
+	}, {
+		summary: "set nonsense gpus",
+		args:    []string{"gpus=lots"},
+		err:     `bad "gpus" constraint: must be a non-negative integer`,
+	}, {
+		summary: "double set gpus",
+		args:    []string{"gpus=1", "gpus=2"},
+		err:     `bad "gpus" constraint: already set`,
+	},
+
+	// "gpu-type" in detail.
+	{
+		summary: "set gpu-type empty",
+		args:    []string{"gpu-type="},
+	}, {
+		summary: "set gpu-type",
+		args:    []string{"gpu-type=v100"},
+	}, {
+		summary: "double set gpu-type",
+		args:    []string{"gpu-type=k80", "gpu-type=t4"},
+		err:     `bad "gpu-type" constraint: already set`,
+	},
+
 	// "mem" in detail.
 	{
 		summary: "set mem empty",
@@ -558,6 +591,11 @@ var constraintsRoundtripTests = []roundTrip{
 	{"CpuPower1", constraints.Value{CpuPower: nil}},
 	{"CpuPower2", constraints.Value{CpuPower: uint64p(0)}},
 	{"CpuPower3", constraints.Value{CpuPower: uint64p(250)}},
+	{"GPUs1", constraints.Value{GPUs: nil}},
+	{"GPUs2", constraints.Value{GPUs: uint64p(0)}},
+	{"GPUs3", constraints.Value{GPUs: uint64p(8)}},
+	{"GPUType1", constraints.Value{GPUType: nil}},
+	{"GPUType2", constraints.Value{GPUType: strp("v100")}},
 	{"Mem1", constraints.Value{Mem: nil}},
 	{"Mem2", constraints.Value{Mem: uint64p(0)}},
 	{"Mem3", constraints.Value{Mem: uint64p(98765)}},
@@ -582,6 +620,8 @@ var constraintsRoundtripTests = []roundTrip{
 		Container:      ctypep("lxd"),
 		CpuCores:       uint64p(4096),
 		CpuPower:       uint64p(9001),
+		GPUs:           uint64p(2),
+		GPUType:        strp("t4"),
 		Mem:            uint64p(18000000000),
 		RootDisk:       uint64p(24000000000),
 		RootDiskSource: strp("cave"),
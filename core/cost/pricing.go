@@ -0,0 +1,191 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package cost estimates what the resources used by a model cost to
+// run, from operator supplied pricing for the model's cloud.
+package cost
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// HoursPerMonth is the average number of hours in a month, used to
+// convert between hourly and monthly prices.
+const HoursPerMonth = 730
+
+// Pricing holds the prices of the resources provided by a cloud. Prices
+// which are not set are taken to be zero.
+type Pricing struct {
+	// Currency is the currency the prices are in, eg "USD".
+	Currency string `yaml:"currency" json:"currency"`
+
+	// InstanceTypes holds the hourly price of instances, keyed by
+	// instance type name.
+	InstanceTypes map[string]float64 `yaml:"instance-types,omitempty" json:"instance-types,omitempty"`
+
+	// CPUCoreHour is the hourly price of a CPU core, used for
+	// instances whose type is not known or has no price.
+	CPUCoreHour float64 `yaml:"cpu-core-hour,omitempty" json:"cpu-core-hour,omitempty"`
+
+	// MemoryGiBHour is the hourly price of a GiB of memory, used for
+	// instances whose type is not known or has no price.
+	MemoryGiBHour float64 `yaml:"memory-gib-hour,omitempty" json:"memory-gib-hour,omitempty"`
+
+	// VolumeGiBMonth is the monthly price of a GiB of volume storage.
+	VolumeGiBMonth float64 `yaml:"volume-gib-month,omitempty" json:"volume-gib-month,omitempty"`
+
+	// LoadBalancerHour is the hourly price of a load balancer.
+	LoadBalancerHour float64 `yaml:"load-balancer-hour,omitempty" json:"load-balancer-hour,omitempty"`
+}
+
+// ParsePricing parses pricing in YAML format, returning an error if
+// it is not valid.
+func ParsePricing(data []byte) (Pricing, error) {
+	var pricing Pricing
+	if err := yaml.UnmarshalStrict(data, &pricing); err != nil {
+		return Pricing{}, errors.Annotate(err, "parsing pricing")
+	}
+	if err := pricing.Validate(); err != nil {
+		return Pricing{}, errors.Trace(err)
+	}
+	return pricing, nil
+}
+
+// Validate returns an error if the pricing is not valid.
+func (p Pricing) Validate() error {
+	if p.Currency == "" {
+		return errors.NotValidf("pricing without currency")
+	}
+	for name, price := range p.InstanceTypes {
+		if name == "" {
+			return errors.NotValidf("empty instance type name")
+		}
+		if price < 0 {
+			return errors.NotValidf("negative price for instance type %q", name)
+		}
+	}
+	for name, price := range map[string]float64{
+		"cpu-core-hour":      p.CPUCoreHour,
+		"memory-gib-hour":    p.MemoryGiBHour,
+		"volume-gib-month":   p.VolumeGiBMonth,
+		"load-balancer-hour": p.LoadBalancerHour,
+	} {
+		if price < 0 {
+			return errors.NotValidf("negative %s", name)
+		}
+	}
+	return nil
+}
+
+// Machine describes a provisioned machine.
+type Machine struct {
+	Id           string
+	InstanceType string
+	CPUCores     uint64
+	MemMiB       uint64
+}
+
+// Volume describes a provisioned volume.
+type Volume struct {
+	Id      string
+	SizeMiB uint64
+}
+
+// Resources holds the resources used by a model which have a cost.
+type Resources struct {
+	Machines []Machine
+	Volumes  []Volume
+
+	// LoadBalancers holds the names of the applications which are
+	// exposed through a load balancer.
+	LoadBalancers []string
+}
+
+// Kind identifies the kind of resource an item is for.
+type Kind string
+
+const (
+	// KindMachine items are for the instances of machines.
+	KindMachine Kind = "machine"
+
+	// KindVolume items are for volumes.
+	KindVolume Kind = "volume"
+
+	// KindLoadBalancer items are for the load balancers of
+	// applications.
+	KindLoadBalancer Kind = "load-balancer"
+)
+
+// Item is the estimated cost of a single resource.
+type Item struct {
+	Kind   Kind
+	Id     string
+	Detail string
+	Hourly float64
+}
+
+// Estimate is the estimated cost of running a model's resources.
+type Estimate struct {
+	Currency string
+	Items    []Item
+	Hourly   float64
+}
+
+// Monthly returns the estimated cost of running the resources for a
+// month.
+func (e Estimate) Monthly() float64 {
+	return e.Hourly * HoursPerMonth
+}
+
+// Estimate returns the estimated cost of running the given resources.
+func (p Pricing) Estimate(resources Resources) Estimate {
+	estimate := Estimate{Currency: p.Currency}
+	add := func(item Item) {
+		estimate.Items = append(estimate.Items, item)
+		estimate.Hourly += item.Hourly
+	}
+	for _, m := range resources.Machines {
+		add(p.machineItem(m))
+	}
+	for _, v := range resources.Volumes {
+		sizeGiB := float64(v.SizeMiB) / 1024
+		add(Item{
+			Kind:   KindVolume,
+			Id:     v.Id,
+			Detail: fmt.Sprintf("%.0fGiB", sizeGiB),
+			Hourly: sizeGiB * p.VolumeGiBMonth / HoursPerMonth,
+		})
+	}
+	lbs := append([]string(nil), resources.LoadBalancers...)
+	sort.Strings(lbs)
+	for _, app := range lbs {
+		add(Item{
+			Kind:   KindLoadBalancer,
+			Id:     app,
+			Hourly: p.LoadBalancerHour,
+		})
+	}
+	return estimate
+}
+
+func (p Pricing) machineItem(m Machine) Item {
+	if price, ok := p.InstanceTypes[m.InstanceType]; ok && m.InstanceType != "" {
+		return Item{
+			Kind:   KindMachine,
+			Id:     m.Id,
+			Detail: m.InstanceType,
+			Hourly: price,
+		}
+	}
+	memGiB := float64(m.MemMiB) / 1024
+	return Item{
+		Kind:   KindMachine,
+		Id:     m.Id,
+		Detail: fmt.Sprintf("cores=%d mem=%.0fG", m.CPUCores, memGiB),
+		Hourly: float64(m.CPUCores)*p.CPUCoreHour + memGiB*p.MemoryGiBHour,
+	}
+}
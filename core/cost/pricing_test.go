@@ -0,0 +1,103 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package cost_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/cost"
+)
+
+type pricingSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&pricingSuite{})
+
+func (s *pricingSuite) TestParsePricing(c *gc.C) {
+	pricing, err := cost.ParsePricing([]byte(`
+currency: USD
+instance-types:
+  m5.large: 0.096
+cpu-core-hour: 0.02
+memory-gib-hour: 0.005
+volume-gib-month: 0.1
+load-balancer-hour: 0.025
+`))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pricing, jc.DeepEquals, cost.Pricing{
+		Currency:         "USD",
+		InstanceTypes:    map[string]float64{"m5.large": 0.096},
+		CPUCoreHour:      0.02,
+		MemoryGiBHour:    0.005,
+		VolumeGiBMonth:   0.1,
+		LoadBalancerHour: 0.025,
+	})
+}
+
+func (s *pricingSuite) TestParsePricingErrors(c *gc.C) {
+	for i, test := range []struct {
+		yaml string
+		err  string
+	}{{
+		yaml: "cpu-core-hour: 0.02",
+		err:  "pricing without currency not valid",
+	}, {
+		yaml: "currency: USD\ncpu-core-hour: -1",
+		err:  "negative cpu-core-hour not valid",
+	}, {
+		yaml: "currency: USD\ninstance-types:\n  m5.large: -0.1",
+		err:  `negative price for instance type "m5.large" not valid`,
+	}, {
+		yaml: "currency: USD\ngpu-hour: 1",
+		err:  "parsing pricing: .*field gpu-hour not found.*",
+	}} {
+		c.Logf("test %d: %s", i, test.yaml)
+		_, err := cost.ParsePricing([]byte(test.yaml))
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+	_, err := cost.ParsePricing([]byte("cpu-core-hour: 0.02"))
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *pricingSuite) TestEstimate(c *gc.C) {
+	pricing := cost.Pricing{
+		Currency:         "USD",
+		InstanceTypes:    map[string]float64{"m5.large": 0.5},
+		CPUCoreHour:      0.125,
+		MemoryGiBHour:    0.0625,
+		VolumeGiBMonth:   73,
+		LoadBalancerHour: 0.25,
+	}
+	estimate := pricing.Estimate(cost.Resources{
+		Machines: []cost.Machine{
+			{Id: "0", InstanceType: "m5.large", CPUCores: 2, MemMiB: 8192},
+			{Id: "1", CPUCores: 4, MemMiB: 16384},
+			{Id: "2", InstanceType: "unknown", CPUCores: 1, MemMiB: 2048},
+		},
+		Volumes:       []cost.Volume{{Id: "0", SizeMiB: 10 * 1024}},
+		LoadBalancers: []string{"mariadb", "gitlab"},
+	})
+	c.Assert(estimate, jc.DeepEquals, cost.Estimate{
+		Currency: "USD",
+		Items: []cost.Item{
+			{Kind: cost.KindMachine, Id: "0", Detail: "m5.large", Hourly: 0.5},
+			{Kind: cost.KindMachine, Id: "1", Detail: "cores=4 mem=16G", Hourly: 1.5},
+			{Kind: cost.KindMachine, Id: "2", Detail: "cores=1 mem=2G", Hourly: 0.25},
+			{Kind: cost.KindVolume, Id: "0", Detail: "10GiB", Hourly: 1},
+			{Kind: cost.KindLoadBalancer, Id: "gitlab", Hourly: 0.25},
+			{Kind: cost.KindLoadBalancer, Id: "mariadb", Hourly: 0.25},
+		},
+		Hourly: 3.75,
+	})
+	c.Assert(estimate.Monthly(), gc.Equals, 2737.5)
+}
+
+func (s *pricingSuite) TestEstimateNoResources(c *gc.C) {
+	estimate := cost.Pricing{Currency: "EUR"}.Estimate(cost.Resources{})
+	c.Assert(estimate, jc.DeepEquals, cost.Estimate{Currency: "EUR"})
+}
@@ -0,0 +1,242 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package healthprobe describes the health probes which machine agents
+// run for the units of an application, such as checking that a port
+// accepts connections, and the results they record in the status data
+// of the units.
+package healthprobe
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/yaml.v2"
+)
+
+// Kind identifies how a probe checks health.
+type Kind string
+
+const (
+	// KindTCP probes check that a TCP address accepts connections.
+	KindTCP Kind = "tcp"
+
+	// KindHTTP probes check that an HTTP GET of a URL succeeds with a
+	// 2xx or 3xx response.
+	KindHTTP Kind = "http"
+
+	// KindCommand probes check that a command exits successfully.
+	KindCommand Kind = "command"
+)
+
+const (
+	// DefaultInterval is how often a probe is run if it doesn't say.
+	DefaultInterval = 30 * time.Second
+
+	// DefaultTimeout is how long a probe may take if it doesn't say.
+	DefaultTimeout = 10 * time.Second
+)
+
+// Probe describes a check run on each machine hosting a unit of an
+// application. Exactly one of TCP, HTTP and Command must be set.
+type Probe struct {
+	// Name identifies the probe among those of its application.
+	Name string `yaml:"name"`
+
+	// TCP is the address, as host:port, to connect to.
+	TCP string `yaml:"tcp,omitempty"`
+
+	// HTTP is the URL to get.
+	HTTP string `yaml:"http,omitempty"`
+
+	// Command is the shell command to run.
+	Command string `yaml:"command,omitempty"`
+
+	// Interval is how often to run the probe, eg "1m".
+	Interval string `yaml:"interval,omitempty"`
+
+	// Timeout is how long the probe may take before it fails, eg "5s".
+	Timeout string `yaml:"timeout,omitempty"`
+}
+
+// Kind returns how the probe checks health.
+func (p Probe) Kind() Kind {
+	switch {
+	case p.TCP != "":
+		return KindTCP
+	case p.HTTP != "":
+		return KindHTTP
+	default:
+		return KindCommand
+	}
+}
+
+// Validate returns an error if the probe is not valid.
+func (p Probe) Validate() error {
+	if p.Name == "" {
+		return errors.NotValidf("empty probe name")
+	}
+	if strings.ContainsAny(p.Name, ".$") {
+		return errors.NotValidf("probe name %q", p.Name)
+	}
+	var kinds int
+	for _, v := range []string{p.TCP, p.HTTP, p.Command} {
+		if v != "" {
+			kinds++
+		}
+	}
+	if kinds != 1 {
+		return errors.Errorf("probe %q must have exactly one of tcp, http or command", p.Name)
+	}
+	for _, d := range []struct {
+		name, value string
+	}{{"interval", p.Interval}, {"timeout", p.Timeout}} {
+		if d.value == "" {
+			continue
+		}
+		v, err := time.ParseDuration(d.value)
+		if err != nil {
+			return errors.Annotatef(err, "probe %q %s", p.Name, d.name)
+		}
+		if v <= 0 {
+			return errors.Errorf("probe %q %s %v must be positive", p.Name, d.name, v)
+		}
+	}
+	return nil
+}
+
+// IntervalDuration returns how often the probe is run.
+func (p Probe) IntervalDuration() time.Duration {
+	return durationOr(p.Interval, DefaultInterval)
+}
+
+// TimeoutDuration returns how long the probe may take.
+func (p Probe) TimeoutDuration() time.Duration {
+	return durationOr(p.Timeout, DefaultTimeout)
+}
+
+func durationOr(value string, d time.Duration) time.Duration {
+	// The value has already been validated.
+	if v, err := time.ParseDuration(value); err == nil {
+		return v
+	}
+	return d
+}
+
+// ParseProbes parses and validates the YAML map of application names
+// to the lists of probes run for their units, as held in the
+// health-probes model config setting.
+func ParseProbes(text string) (map[string][]Probe, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	var probes map[string][]Probe
+	if err := yaml.UnmarshalStrict([]byte(text), &probes); err != nil {
+		return nil, errors.Annotate(err, "parsing health probes")
+	}
+	for appName, appProbes := range probes {
+		if !names.IsValidApplication(appName) {
+			return nil, errors.NotValidf("application name %q", appName)
+		}
+		seen := set.NewStrings()
+		for _, p := range appProbes {
+			if err := p.Validate(); err != nil {
+				return nil, errors.Annotatef(err, "application %q", appName)
+			}
+			if seen.Contains(p.Name) {
+				return nil, errors.NotValidf("application %q duplicate probe name %q", appName, p.Name)
+			}
+			seen.Add(p.Name)
+		}
+	}
+	return probes, nil
+}
+
+// DataKey is the key in the status data of a unit under which the
+// results of its health probes are recorded.
+const DataKey = "health-probes"
+
+// Result holds the outcome of the latest run of a probe.
+type Result struct {
+	// Healthy reports whether the probe succeeded.
+	Healthy bool
+
+	// Message describes why the probe failed.
+	Message string
+
+	// Since is when the probe started succeeding or failing.
+	Since time.Time
+}
+
+// StatusData returns the results, keyed by probe name, as they are
+// recorded under DataKey in status data.
+func StatusData(results map[string]Result) map[string]interface{} {
+	data := make(map[string]interface{})
+	for name, r := range results {
+		value := map[string]interface{}{
+			"healthy": r.Healthy,
+			"since":   r.Since.UTC().Format(time.RFC3339),
+		}
+		if r.Message != "" {
+			value["message"] = r.Message
+		}
+		data[name] = value
+	}
+	return data
+}
+
+// ResultsFromStatusData returns the probe results, keyed by probe name,
+// recorded in the given status data. Results which can't be read are
+// ignored.
+func ResultsFromStatusData(data map[string]interface{}) map[string]Result {
+	results := make(map[string]Result)
+	probes, ok := asMap(data[DataKey])
+	if !ok {
+		return results
+	}
+	for name, v := range probes {
+		value, ok := asMap(v)
+		if !ok {
+			continue
+		}
+		healthy, ok := value["healthy"].(bool)
+		if !ok {
+			continue
+		}
+		message, _ := value["message"].(string)
+		since, _ := value["since"].(string)
+		t, _ := time.Parse(time.RFC3339, since)
+		results[name] = Result{
+			Healthy: healthy,
+			Message: message,
+			Since:   t,
+		}
+	}
+	return results
+}
+
+// asMap returns the value as a map with string keys. Maps read from
+// the database or decoded from YAML don't have the same type as those
+// decoded from JSON, so they are converted.
+func asMap(v interface{}) (map[string]interface{}, bool) {
+	if m, ok := v.(map[string]interface{}); ok {
+		return m, true
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Map {
+		return nil, false
+	}
+	m := make(map[string]interface{}, rv.Len())
+	for _, key := range rv.MapKeys() {
+		k, ok := key.Interface().(string)
+		if !ok {
+			return nil, false
+		}
+		m[k] = rv.MapIndex(key).Interface()
+	}
+	return m, true
+}
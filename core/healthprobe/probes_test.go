@@ -0,0 +1,148 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthprobe_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/healthprobe"
+)
+
+type ProbesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ProbesSuite{})
+
+func (s *ProbesSuite) TestParseProbes(c *gc.C) {
+	probes, err := healthprobe.ParseProbes(`
+mysql:
+  - name: port
+    tcp: localhost:3306
+    interval: 1m
+wordpress:
+  - name: front-page
+    http: http://localhost/
+    timeout: 5s
+  - name: php
+    command: pgrep php-fpm
+`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(probes, jc.DeepEquals, map[string][]healthprobe.Probe{
+		"mysql": {{
+			Name:     "port",
+			TCP:      "localhost:3306",
+			Interval: "1m",
+		}},
+		"wordpress": {{
+			Name:    "front-page",
+			HTTP:    "http://localhost/",
+			Timeout: "5s",
+		}, {
+			Name:    "php",
+			Command: "pgrep php-fpm",
+		}},
+	})
+
+	port := probes["mysql"][0]
+	c.Check(port.Kind(), gc.Equals, healthprobe.KindTCP)
+	c.Check(port.IntervalDuration(), gc.Equals, time.Minute)
+	c.Check(port.TimeoutDuration(), gc.Equals, healthprobe.DefaultTimeout)
+	frontPage := probes["wordpress"][0]
+	c.Check(frontPage.Kind(), gc.Equals, healthprobe.KindHTTP)
+	c.Check(frontPage.IntervalDuration(), gc.Equals, healthprobe.DefaultInterval)
+	c.Check(frontPage.TimeoutDuration(), gc.Equals, 5*time.Second)
+	c.Check(probes["wordpress"][1].Kind(), gc.Equals, healthprobe.KindCommand)
+}
+
+func (s *ProbesSuite) TestParseProbesEmpty(c *gc.C) {
+	probes, err := healthprobe.ParseProbes("  \n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(probes, gc.HasLen, 0)
+}
+
+func (s *ProbesSuite) TestParseProbesInvalid(c *gc.C) {
+	for i, test := range []struct {
+		text string
+		err  string
+	}{{
+		text: "mysql:\n  - name: port\n    udp: localhost:53\n",
+		err:  `parsing health probes: .*field udp not found.*`,
+	}, {
+		text: "Not-Valid:\n  - name: port\n    tcp: localhost:3306\n",
+		err:  `application name "Not-Valid" not valid`,
+	}, {
+		text: "mysql:\n  - tcp: localhost:3306\n",
+		err:  `application "mysql": empty probe name not valid`,
+	}, {
+		text: "mysql:\n  - name: a.b\n    tcp: localhost:3306\n",
+		err:  `application "mysql": probe name "a.b" not valid`,
+	}, {
+		text: "mysql:\n  - name: port\n",
+		err:  `application "mysql": probe "port" must have exactly one of tcp, http or command`,
+	}, {
+		text: "mysql:\n  - name: port\n    tcp: localhost:3306\n    command: 'true'\n",
+		err:  `application "mysql": probe "port" must have exactly one of tcp, http or command`,
+	}, {
+		text: "mysql:\n  - name: port\n    tcp: localhost:3306\n    interval: soon\n",
+		err:  `application "mysql": probe "port" interval: time: invalid duration "?soon"?`,
+	}, {
+		text: "mysql:\n  - name: port\n    tcp: localhost:3306\n    timeout: 0s\n",
+		err:  `application "mysql": probe "port" timeout 0s must be positive`,
+	}, {
+		text: "mysql:\n  - name: port\n    tcp: localhost:3306\n  - name: port\n    command: 'true'\n",
+		err:  `application "mysql" duplicate probe name "port" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := healthprobe.ParseProbes(test.text)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ProbesSuite) TestStatusData(c *gc.C) {
+	since := time.Date(2020, 1, 3, 14, 0, 0, 0, time.UTC)
+	results := map[string]healthprobe.Result{
+		"port": {Healthy: true, Since: since},
+		"php":  {Healthy: false, Message: "exit status 1", Since: since},
+	}
+	data := healthprobe.StatusData(results)
+	c.Assert(data, jc.DeepEquals, map[string]interface{}{
+		"port": map[string]interface{}{
+			"healthy": true,
+			"since":   "2020-01-03T14:00:00Z",
+		},
+		"php": map[string]interface{}{
+			"healthy": false,
+			"message": "exit status 1",
+			"since":   "2020-01-03T14:00:00Z",
+		},
+	})
+
+	statusData := map[string]interface{}{
+		"other":             "value",
+		healthprobe.DataKey: data,
+	}
+	c.Assert(healthprobe.ResultsFromStatusData(statusData), jc.DeepEquals, results)
+}
+
+func (s *ProbesSuite) TestResultsFromStatusDataOtherMapTypes(c *gc.C) {
+	type namedMap map[string]interface{}
+	statusData := map[string]interface{}{
+		healthprobe.DataKey: namedMap{
+			"port":    namedMap{"healthy": false, "message": "connection refused"},
+			"garbage": "not a result",
+		},
+	}
+	c.Assert(healthprobe.ResultsFromStatusData(statusData), jc.DeepEquals, map[string]healthprobe.Result{
+		"port": {Healthy: false, Message: "connection refused"},
+	})
+}
+
+func (s *ProbesSuite) TestResultsFromStatusDataMissing(c *gc.C) {
+	c.Assert(healthprobe.ResultsFromStatusData(nil), gc.HasLen, 0)
+}
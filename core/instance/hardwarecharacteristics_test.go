@@ -156,6 +156,39 @@ var parseHardwareTests = []parseHardwareTestSpec{
 		err:     `bad "cpu-power" characteristic: already set`,
 	},
 
+	// "gpus" in detail.
+	{
+		summary: "set gpus empty",
+		args:    []string{"gpus="},
+	}, {
+		summary: "set gpus zero",
+		args:    []string{"gpus=0"},
+	}, {
+		summary: "set gpus",
+		args:    []string{"gpus=4"},
+	}, {
+		summary: "set nonsense gpus",
+		args:    []string{"gpus=lots"},
+		err:     `bad "gpus" characteristic: must be a non-negative integer`,
+	}, {
+		summary: "double set gpus",
+		args:    []string{"gpus=1", "gpus=2"},
+		err:     `bad "gpus" characteristic: already set`,
+	},
+
+	// "gpu-type" in detail.
+	{
+		summary: "set gpu-type empty",
+		args:    []string{"gpu-type="},
+	}, {
+		summary: "set gpu-type",
+		args:    []string{"gpu-type=v100"},
+	}, {
+		summary: "double set gpu-type",
+		args:    []string{"gpu-type=k80", "gpu-type=t4"},
+		err:     `bad "gpu-type" characteristic: already set`,
+	},
+
 	// "mem" in detail.
 	{
 		summary: "set mem empty",
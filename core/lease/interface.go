@@ -106,6 +106,44 @@ type Reader interface {
 	Leases() map[string]string
 }
 
+// Details holds the diagnostic information about a single lease.
+type Details struct {
+	// Holder is the name of the current leaseholder.
+	Holder string
+
+	// Expiry is the latest time at which the lease might still be valid.
+	Expiry time.Time
+
+	// PinnedBy holds the entities requiring the lease not to expire.
+	PinnedBy []string
+}
+
+// Inspector describes retrieval of the details of all leases
+// for a known namespace and model, for diagnostic purposes.
+type Inspector interface {
+	// Details returns the holder, expiry and pins of every lease,
+	// keyed by lease name.
+	Details() map[string]Details
+}
+
+// Revoker describes the forced vacation of leases, for recovering
+// from situations where a holder is wedged and cannot be relied upon
+// to give up the lease.
+type Revoker interface {
+	// Revoke vacates the named lease immediately, regardless of its
+	// holder and expiry. If the lease is not held, it returns
+	// ErrNotHeld.
+	Revoke(leaseName string) error
+}
+
+// Lister describes retrieval of the holders of all leases in a
+// namespace, across every model.
+type Lister interface {
+	// Holders returns the holder of every lease in the namespace,
+	// keyed by lease key.
+	Holders() map[Key]string
+}
+
 // Manager describes methods for acquiring objects that manipulate and query
 // leases for different models.
 type Manager interface {
@@ -113,4 +151,7 @@ type Manager interface {
 	Claimer(namespace string, modelUUID string) (Claimer, error)
 	Pinner(namespace string, modelUUID string) (Pinner, error)
 	Reader(namespace string, modelUUID string) (Reader, error)
+	Inspector(namespace string, modelUUID string) (Inspector, error)
+	Revoker(namespace string, modelUUID string) (Revoker, error)
+	Lister(namespace string) (Lister, error)
 }
@@ -32,6 +32,11 @@ type Store interface {
 	// have passed. If it returns ErrInvalid, check Leases() for updated state.
 	ExpireLease(lease Key) error
 
+	// RevokeLease records the forced vacation of the supplied lease,
+	// regardless of its holder or expiry time. If it returns
+	// ErrInvalid, the lease is not held.
+	RevokeLease(lease Key, stop <-chan struct{}) error
+
 	// Leases returns a recent snapshot of lease state. Expiry times are
 	// expressed according to the Clock the store was configured with.
 	// Supplying any lease keys will filter the return for those requested.
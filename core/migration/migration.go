@@ -68,6 +68,11 @@ type ModelInfo struct {
 	Name                   string
 	AgentVersion           version.Number
 	ControllerAgentVersion version.Number
+
+	// FormatVersion is the version of the model description format the
+	// source controller exports the model in. It is zero if the source
+	// controller doesn't report it.
+	FormatVersion int
 }
 
 func (i *ModelInfo) Validate() error {
@@ -721,3 +721,99 @@ func MergedAddresses(machineAddresses, providerAddresses []SpaceAddress) []Space
 	}
 	return merged
 }
+
+// AddressConflictPolicy determines how the addresses reported for a
+// machine by its provider are reconciled with those reported by the
+// machine agent when choosing the machine's preferred addresses.
+type AddressConflictPolicy string
+
+const (
+	// ProviderWins prefers addresses reported by the provider, only
+	// using those reported by the machine if the provider has none of
+	// the scope wanted. This is the default.
+	ProviderWins AddressConflictPolicy = "provider-wins"
+
+	// MachinerWins prefers addresses reported by the machine, only
+	// using those reported by the provider if the machine has none of
+	// the scope wanted.
+	MachinerWins AddressConflictPolicy = "machiner-wins"
+
+	// MergeWithScopePriority considers the addresses from both sources
+	// together, preferring the address which best matches the scope
+	// wanted wherever it was reported. Where both report the same
+	// address, the provider's details of it are used.
+	MergeWithScopePriority AddressConflictPolicy = "merge-with-scope-priority"
+)
+
+// Validate returns an error if the policy is not one of the known
+// policies.
+func (p AddressConflictPolicy) Validate() error {
+	switch p {
+	case ProviderWins, MachinerWins, MergeWithScopePriority:
+		return nil
+	}
+	return errors.NotValidf("address conflict policy %q", p)
+}
+
+// UnitAddressPreference determines which of the addresses of a unit's
+// machine is advertised as the unit's primary address, both in status
+// and to the units it is related to.
+type UnitAddressPreference string
+
+const (
+	// PreferAutoAddress advertises the machine's preferred public
+	// address in status, and to related units the first address in
+	// the space to which the relation's endpoint is bound. This is
+	// the default.
+	PreferAutoAddress UnitAddressPreference = "auto"
+
+	// PreferBindingAddress advertises an address in the space to
+	// which the application's default endpoint is bound.
+	PreferBindingAddress UnitAddressPreference = "binding"
+
+	// PreferPublicAddress advertises the machine's preferred public
+	// address.
+	PreferPublicAddress UnitAddressPreference = "public"
+
+	// PreferFQDNAddress advertises a host name of the machine, if it
+	// has one.
+	PreferFQDNAddress UnitAddressPreference = "fqdn"
+)
+
+// Validate returns an error if the preference is not one of the known
+// preferences.
+func (p UnitAddressPreference) Validate() error {
+	switch p {
+	case PreferAutoAddress, PreferBindingAddress, PreferPublicAddress, PreferFQDNAddress:
+		return nil
+	}
+	return errors.NotValidf("unit address preference %q", p)
+}
+
+// Primary returns the address to advertise according to the preference,
+// from the given addresses in the order they are otherwise preferred.
+// The spaceID is the space whose addresses PreferBindingAddress picks.
+// If no address matches the preference, the first address is returned.
+// The boolean result is false if there are no addresses.
+func (p UnitAddressPreference) Primary(addrs SpaceAddresses, spaceID string) (SpaceAddress, bool) {
+	if len(addrs) == 0 {
+		return SpaceAddress{}, false
+	}
+	switch p {
+	case PreferBindingAddress:
+		for _, addr := range addrs {
+			if addr.SpaceID == spaceID {
+				return addr, true
+			}
+		}
+	case PreferPublicAddress:
+		return addrs.OneMatchingScope(ScopeMatchPublic)
+	case PreferFQDNAddress:
+		for _, addr := range addrs {
+			if addr.Type == HostName {
+				return addr, true
+			}
+		}
+	}
+	return addrs[0], true
+}
@@ -849,3 +849,55 @@ func (s *AddressSuite) TestSpaceAddressesToProviderAddresses(c *gc.C) {
 	_, err = addrs.ToProviderAddresses(stubLookup{})
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
+
+func (s *AddressSuite) TestAddressConflictPolicyValidate(c *gc.C) {
+	for _, policy := range []network.AddressConflictPolicy{
+		network.ProviderWins,
+		network.MachinerWins,
+		network.MergeWithScopePriority,
+	} {
+		c.Check(policy.Validate(), jc.ErrorIsNil)
+	}
+	err := network.AddressConflictPolicy("agent-wins").Validate()
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+	c.Assert(err, gc.ErrorMatches, `address conflict policy "agent-wins" not valid`)
+}
+
+func (s *AddressSuite) TestUnitAddressPreferencePrimary(c *gc.C) {
+	public := network.NewScopedSpaceAddress("8.8.8.8", network.ScopePublic)
+	local := network.NewScopedSpaceAddress("10.0.0.1", network.ScopeCloudLocal)
+	local.SpaceID = "2"
+	fqdn := network.NewScopedSpaceAddress("node-1.example.com", network.ScopePublic)
+	addrs := network.SpaceAddresses{local, public, fqdn}
+
+	for _, t := range []struct {
+		pref     network.UnitAddressPreference
+		spaceID  string
+		expected network.SpaceAddress
+	}{
+		{network.PreferAutoAddress, "", local},
+		{network.PreferBindingAddress, "2", local},
+		{network.PreferBindingAddress, "3", local},
+		{network.PreferPublicAddress, "", public},
+		{network.PreferFQDNAddress, "", fqdn},
+	} {
+		c.Logf("preference %q, space %q", t.pref, t.spaceID)
+		addr, ok := t.pref.Primary(addrs, t.spaceID)
+		c.Check(ok, jc.IsTrue)
+		c.Check(addr, jc.DeepEquals, t.expected)
+	}
+
+	// Without a matching address the first is used.
+	addr, ok := network.PreferFQDNAddress.Primary(network.SpaceAddresses{public, local}, "")
+	c.Check(ok, jc.IsTrue)
+	c.Check(addr, jc.DeepEquals, public)
+
+	_, ok = network.PreferPublicAddress.Primary(nil, "")
+	c.Check(ok, jc.IsFalse)
+}
+
+func (s *AddressSuite) TestUnitAddressPreferenceValidate(c *gc.C) {
+	c.Check(network.PreferFQDNAddress.Validate(), jc.ErrorIsNil)
+	err := network.UnitAddressPreference("private").Validate()
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
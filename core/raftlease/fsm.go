@@ -45,6 +45,10 @@ const (
 	// OperationUnpin unpins a lease, restoring normal
 	// lease expiry behaviour.
 	OperationUnpin = "unpin"
+
+	// OperationRevoke forcibly removes a lease, regardless of
+	// its holder and expiry.
+	OperationRevoke = "revoke"
 )
 
 // FSMResponse defines what will be available on the return value from
@@ -166,6 +170,21 @@ func (f *FSM) unpin(key lease.Key, entity string) *response {
 	return &response{}
 }
 
+func (f *FSM) revoke(key lease.Key) *response {
+	entries, groupFound := f.getGroup(key)
+	if !groupFound {
+		return invalidResponse()
+	}
+	if _, found := entries[key]; !found {
+		return invalidResponse()
+	}
+	delete(entries, key)
+	if len(entries) == 0 {
+		delete(f.groups, groupKeyFor(key))
+	}
+	return &response{expired: []lease.Key{key}}
+}
+
 func (f *FSM) setTime(oldTime, newTime time.Time) *response {
 	if f.globalTime != oldTime {
 		return &response{err: globalclock.ErrConcurrentUpdate}
@@ -326,7 +345,8 @@ func (r *response) Error() error {
 // Notify is part of FSMResponse.
 func (r *response) Notify(target NotifyTarget) {
 	// This response is either for a claim (in which case claimer will
-	// be set) or a set-time (so it will have zero or more expiries).
+	// be set), a revoke (with a single expiry) or a set-time (so it
+	// will have zero or more expiries).
 	if r.claimer != "" {
 		target.Claimed(r.claimed, r.claimer)
 	}
@@ -362,6 +382,8 @@ func (f *FSM) Apply(log *raft.Log) interface{} {
 		return f.pin(command.LeaseKey(), command.PinEntity)
 	case OperationUnpin:
 		return f.unpin(command.LeaseKey(), command.PinEntity)
+	case OperationRevoke:
+		return f.revoke(command.LeaseKey())
 	case OperationSetTime:
 		return f.setTime(command.OldTime, command.NewTime)
 	default:
@@ -516,7 +538,7 @@ type Command struct {
 	// to handle multiple formats.
 	Version int `yaml:"version"`
 
-	// Operation is one of claim, extend, expire or setTime.
+	// Operation is one of claim, extend, pin, unpin, revoke or setTime.
 	Operation string `yaml:"operation"`
 
 	// Namespace is the kind of lease.
@@ -583,6 +605,22 @@ func (c *Command) Validate() error {
 		if c.PinEntity == "" {
 			return errors.NotValidf("%s with empty pin entity", c.Operation)
 		}
+	case OperationRevoke:
+		if err := c.validateLeaseKey(); err != nil {
+			return err
+		}
+		if err := c.validateNoTime(); err != nil {
+			return err
+		}
+		if c.Holder != "" {
+			return errors.NotValidf("%s with holder", c.Operation)
+		}
+		if c.Duration != 0 {
+			return errors.NotValidf("%s with duration", c.Operation)
+		}
+		if c.PinEntity != "" {
+			return errors.NotValidf("%s with pin entity", c.Operation)
+		}
 	case OperationSetTime:
 		// An old time of 0 is valid when starting up.
 		var zeroTime time.Time
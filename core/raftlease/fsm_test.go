@@ -144,6 +144,38 @@ func (s *fsmSuite) TestExtend(c *gc.C) {
 	assertNoNotifications(c, resp)
 }
 
+func (s *fsmSuite) TestRevoke(c *gc.C) {
+	key := lease.Key{Namespace: "ns", ModelUUID: "model", Lease: "lease"}
+	command := raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationRevoke,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+	}
+	// Can't revoke a lease that isn't held.
+	resp := s.apply(c, command)
+	c.Assert(resp.Error(), jc.Satisfies, lease.IsInvalid)
+	assertNoNotifications(c, resp)
+
+	c.Assert(s.apply(c, raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationClaim,
+		Namespace: "ns",
+		ModelUUID: "model",
+		Lease:     "lease",
+		Holder:    "me",
+		Duration:  time.Minute,
+	}).Error(), jc.ErrorIsNil)
+
+	// The lease is removed well before its expiry.
+	resp = s.apply(c, command)
+	c.Assert(resp.Error(), jc.ErrorIsNil)
+	assertExpired(c, resp, key)
+	c.Assert(s.fsm.Leases(timeDelegate(zero)), gc.HasLen, 0)
+	c.Assert(s.fsm.LeaseGroup(timeDelegate(zero), "ns", "model"), gc.HasLen, 0)
+}
+
 func (s *fsmSuite) TestSetTime(c *gc.C) {
 	// Time always starts at 0.
 	resp := s.apply(c, raftlease.Command{
@@ -845,6 +877,25 @@ func (s *fsmSuite) TestCommandValidationPin(c *gc.C) {
 	c.Assert(command.Validate(), gc.ErrorMatches, "pin with empty pin entity not valid")
 }
 
+func (s *fsmSuite) TestCommandValidationRevoke(c *gc.C) {
+	command := raftlease.Command{
+		Version:   1,
+		Operation: raftlease.OperationRevoke,
+		Namespace: "namespace",
+		ModelUUID: "model",
+		Lease:     "lease",
+	}
+	c.Assert(command.Validate(), gc.Equals, nil)
+	command.Holder = "you"
+	c.Assert(command.Validate(), gc.ErrorMatches, "revoke with holder not valid")
+	command.Holder = ""
+	command.Duration = time.Minute
+	c.Assert(command.Validate(), gc.ErrorMatches, "revoke with duration not valid")
+	command.Duration = 0
+	command.Lease = ""
+	c.Assert(command.Validate(), gc.ErrorMatches, "revoke with empty lease not valid")
+}
+
 func assertClaimed(c *gc.C, resp raftlease.FSMResponse, key lease.Key, holder string) {
 	var target fakeTarget
 	resp.Notify(&target)
@@ -29,7 +29,7 @@ func newMetricsCollector() *metricsCollector {
 				0.99: 0.001,
 			},
 		}, []string{
-			"operation", // claim, extend, pin, unpin or settime
+			"operation", // claim, extend, pin, unpin, revoke or settime
 			"result",    // success, failure, timeout or error
 		}),
 	}
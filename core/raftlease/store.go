@@ -25,7 +25,7 @@ func aborted(command *Command) error {
 	switch command.Operation {
 	case OperationSetTime:
 		return errors.Annotatef(lease.ErrAborted, "setTime")
-	case OperationPin, OperationUnpin:
+	case OperationPin, OperationUnpin, OperationRevoke:
 		leaseId := fmt.Sprintf("%.6s:%s", command.ModelUUID, command.Lease)
 		return errors.Annotatef(lease.ErrAborted, "%q on %q",
 			command.Operation, leaseId)
@@ -140,6 +140,17 @@ func (s *Store) ExpireLease(key lease.Key) error {
 	return lease.ErrInvalid
 }
 
+// RevokeLease is part of lease.Store.
+func (s *Store) RevokeLease(key lease.Key, stop <-chan struct{}) error {
+	return errors.Trace(s.runOnLeader(&Command{
+		Version:   CommandVersion,
+		Operation: OperationRevoke,
+		Namespace: key.Namespace,
+		ModelUUID: key.ModelUUID,
+		Lease:     key.Lease,
+	}, stop))
+}
+
 // Leases is part of lease.Store.
 func (s *Store) Leases(keys ...lease.Key) map[lease.Key]lease.Info {
 	leaseMap := s.fsm.Leases(s.config.Clock.Now, keys...)
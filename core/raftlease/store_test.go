@@ -312,6 +312,62 @@ func (s *storeSuite) TestExpire(c *gc.C) {
 	c.Assert(err, jc.Satisfies, lease.IsInvalid)
 }
 
+func (s *storeSuite) TestRevoke(c *gc.C) {
+	s.handleHubRequest(c,
+		func() {
+			err := s.store.RevokeLease(
+				lease.Key{"warframe", "oberon", "prime"},
+				nil,
+			)
+			c.Assert(err, jc.ErrorIsNil)
+		},
+		raftlease.Command{
+			Version:   1,
+			Operation: raftlease.OperationRevoke,
+			Namespace: "warframe",
+			ModelUUID: "oberon",
+			Lease:     "prime",
+		},
+		func(req raftlease.ForwardRequest) {
+			_, err := s.hub.Publish(
+				req.ResponseTopic,
+				raftlease.ForwardResponse{},
+			)
+			c.Check(err, jc.ErrorIsNil)
+		},
+	)
+}
+
+func (s *storeSuite) TestRevokeInvalid(c *gc.C) {
+	s.handleHubRequest(c,
+		func() {
+			err := s.store.RevokeLease(
+				lease.Key{"warframe", "oberon", "prime"},
+				nil,
+			)
+			c.Assert(err, jc.Satisfies, lease.IsInvalid)
+		},
+		raftlease.Command{
+			Version:   1,
+			Operation: raftlease.OperationRevoke,
+			Namespace: "warframe",
+			ModelUUID: "oberon",
+			Lease:     "prime",
+		},
+		func(req raftlease.ForwardRequest) {
+			_, err := s.hub.Publish(
+				req.ResponseTopic,
+				raftlease.ForwardResponse{
+					Error: &raftlease.ResponseError{
+						Code: "invalid",
+					},
+				},
+			)
+			c.Check(err, jc.ErrorIsNil)
+		},
+	)
+}
+
 func (s *storeSuite) TestLeases(c *gc.C) {
 	in5Seconds := s.clock.Now().Add(5 * time.Second)
 	in10Seconds := s.clock.Now().Add(10 * time.Second)
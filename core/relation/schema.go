@@ -0,0 +1,174 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package relation
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// SchemasFile is the name of the file in a charm archive which declares
+// the schemas of the charm's relation data.
+const SchemasFile = "relation-schemas.yaml"
+
+// The types of value which a relation data schema may declare for a key.
+const (
+	TypeString  = "string"
+	TypeInt     = "int"
+	TypeFloat   = "float"
+	TypeBoolean = "boolean"
+	TypeJSON    = "json"
+)
+
+// unitManagedKeys holds the keys of a unit's relation data which are
+// set by Juju, and which charms may set regardless of their schemas.
+var unitManagedKeys = map[string]bool{
+	"private-address": true,
+	"ingress-address": true,
+	"egress-subnets":  true,
+}
+
+// Schemas holds the relation data schemas declared by a charm, keyed by
+// the name of the relation endpoint.
+type Schemas map[string]EndpointSchema
+
+// EndpointSchema holds the schemas of the data bags which a charm's
+// units may set for a relation endpoint.
+type EndpointSchema struct {
+	// Unit is the schema of each unit's own data bag.
+	Unit DataBagSchema `yaml:"unit,omitempty"`
+
+	// Application is the schema of the application's data bag,
+	// which may only be set by the leader unit.
+	Application DataBagSchema `yaml:"application,omitempty"`
+}
+
+// DataBagSchema holds the schemas of the keys which may be set in a
+// relation data bag. If a data bag has no schema, any keys may be set.
+type DataBagSchema map[string]KeySchema
+
+// KeySchema describes the values which may be set for a key in a
+// relation data bag.
+type KeySchema struct {
+	// Type is the type of the value, which defaults to string.
+	Type string `yaml:"type,omitempty"`
+
+	// MaxSize, if non-zero, is the maximum size of the value in bytes.
+	MaxSize int `yaml:"max-size,omitempty"`
+}
+
+// ParseSchemas parses the contents of a charm's relation schemas file.
+func ParseSchemas(data []byte) (Schemas, error) {
+	var schemas Schemas
+	if err := yaml.UnmarshalStrict(data, &schemas); err != nil {
+		return nil, errors.Annotatef(err, "cannot parse %s", SchemasFile)
+	}
+	for endpoint, schema := range schemas {
+		if err := schema.Unit.validate(); err != nil {
+			return nil, errors.Annotatef(err, "relation %q unit schema", endpoint)
+		}
+		if err := schema.Application.validate(); err != nil {
+			return nil, errors.Annotatef(err, "relation %q application schema", endpoint)
+		}
+	}
+	return schemas, nil
+}
+
+func (s DataBagSchema) validate() error {
+	for key, ks := range s {
+		switch ks.Type {
+		case "", TypeString, TypeInt, TypeFloat, TypeBoolean, TypeJSON:
+		default:
+			return errors.NotValidf("type %q of key %q", ks.Type, key)
+		}
+		if ks.MaxSize < 0 {
+			return errors.NotValidf("negative max-size %d of key %q", ks.MaxSize, key)
+		}
+	}
+	return nil
+}
+
+// ValidateUnitSettings returns an error satisfying errors.IsNotValid if
+// the given changes to a unit's data bag don't match the unit schema.
+// Keys with empty values are deleted, and are always allowed.
+func (s EndpointSchema) ValidateUnitSettings(settings map[string]string) error {
+	return s.Unit.validateSettings(settings, unitManagedKeys)
+}
+
+// ValidateApplicationSettings returns an error satisfying
+// errors.IsNotValid if the given changes to an application's data bag
+// don't match the application schema. Keys with empty values are
+// deleted, and are always allowed.
+func (s EndpointSchema) ValidateApplicationSettings(settings map[string]string) error {
+	return s.Application.validateSettings(settings, nil)
+}
+
+func (s DataBagSchema) validateSettings(settings map[string]string, allowed map[string]bool) error {
+	if s == nil {
+		return nil
+	}
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := settings[key]
+		if value == "" || allowed[key] {
+			continue
+		}
+		ks, ok := s[key]
+		if !ok {
+			return errors.NewNotValid(nil, fmt.Sprintf("relation setting %q not declared in schema", key))
+		}
+		if ks.MaxSize > 0 && len(value) > ks.MaxSize {
+			return errors.NewNotValid(nil, fmt.Sprintf(
+				"relation setting %q is %d bytes, exceeding its max-size of %d", key, len(value), ks.MaxSize))
+		}
+		if !validValue(ks.Type, value) {
+			typ := ks.Type
+			if typ == "" {
+				typ = TypeString
+			}
+			return errors.NewNotValid(nil, fmt.Sprintf("relation setting %q: expected %s, got %q", key, typ, value))
+		}
+	}
+	return nil
+}
+
+func validValue(typ, value string) bool {
+	var err error
+	switch typ {
+	case TypeInt:
+		_, err = strconv.ParseInt(value, 10, 64)
+	case TypeFloat:
+		_, err = strconv.ParseFloat(value, 64)
+	case TypeBoolean:
+		_, err = strconv.ParseBool(value)
+	case TypeJSON:
+		return json.Valid([]byte(value))
+	}
+	return err == nil
+}
+
+// DataBagSize returns the size in bytes of the keys and values of a
+// relation data bag, as limited by the max-relation-data-size model
+// config.
+func DataBagSize(settings map[string]interface{}) int {
+	size := 0
+	for key, value := range settings {
+		size += len(key)
+		if s, ok := value.(string); ok {
+			size += len(s)
+		} else {
+			size += len(fmt.Sprint(value))
+		}
+	}
+	return size
+}
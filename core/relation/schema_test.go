@@ -0,0 +1,143 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package relation_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/relation"
+)
+
+type SchemaSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SchemaSuite{})
+
+const testSchemas = `
+db:
+  unit:
+    database:
+      type: string
+      max-size: 16
+    port:
+      type: int
+  application:
+    options:
+      type: json
+website:
+  unit:
+    secure:
+      type: boolean
+`
+
+func (s *SchemaSuite) TestParseSchemas(c *gc.C) {
+	schemas, err := relation.ParseSchemas([]byte(testSchemas))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schemas, jc.DeepEquals, relation.Schemas{
+		"db": {
+			Unit: relation.DataBagSchema{
+				"database": {Type: relation.TypeString, MaxSize: 16},
+				"port":     {Type: relation.TypeInt},
+			},
+			Application: relation.DataBagSchema{
+				"options": {Type: relation.TypeJSON},
+			},
+		},
+		"website": {
+			Unit: relation.DataBagSchema{
+				"secure": {Type: relation.TypeBoolean},
+			},
+		},
+	})
+}
+
+func (s *SchemaSuite) TestParseSchemasInvalid(c *gc.C) {
+	for i, t := range []struct {
+		data string
+		err  string
+	}{{
+		data: "db: [",
+		err:  "cannot parse relation-schemas.yaml: .*",
+	}, {
+		data: "db:\n  units: {}",
+		err:  "cannot parse relation-schemas.yaml: .*field units not found.*",
+	}, {
+		data: "db:\n  unit:\n    port:\n      type: integer",
+		err:  `relation "db" unit schema: type "integer" of key "port" not valid`,
+	}, {
+		data: "db:\n  application:\n    port:\n      max-size: -1",
+		err:  `relation "db" application schema: negative max-size -1 of key "port" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := relation.ParseSchemas([]byte(t.data))
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
+
+func (s *SchemaSuite) TestValidateUnitSettings(c *gc.C) {
+	schemas, err := relation.ParseSchemas([]byte(testSchemas))
+	c.Assert(err, jc.ErrorIsNil)
+	schema := schemas["db"]
+
+	err = schema.ValidateUnitSettings(map[string]string{
+		"database":        "wordpress",
+		"port":            "3306",
+		"private-address": "10.0.0.1",
+		"unknown":         "",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	for i, t := range []struct {
+		settings map[string]string
+		err      string
+	}{{
+		settings: map[string]string{"unknown": "x"},
+		err:      `relation setting "unknown" not declared in schema`,
+	}, {
+		settings: map[string]string{"port": "three"},
+		err:      `relation setting "port": expected int, got "three"`,
+	}, {
+		settings: map[string]string{"database": "a-very-long-database-name"},
+		err:      `relation setting "database" is 25 bytes, exceeding its max-size of 16`,
+	}} {
+		c.Logf("test %d", i)
+		err := schema.ValidateUnitSettings(t.settings)
+		c.Check(err, gc.ErrorMatches, t.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *SchemaSuite) TestValidateApplicationSettings(c *gc.C) {
+	schemas, err := relation.ParseSchemas([]byte(testSchemas))
+	c.Assert(err, jc.ErrorIsNil)
+	schema := schemas["db"]
+
+	err = schema.ValidateApplicationSettings(map[string]string{"options": `{"ssl": true}`})
+	c.Assert(err, jc.ErrorIsNil)
+	err = schema.ValidateApplicationSettings(map[string]string{"options": `{"ssl": `})
+	c.Assert(err, gc.ErrorMatches, `relation setting "options": expected json, got .*`)
+	// Only units may set the keys managed by Juju.
+	err = schema.ValidateApplicationSettings(map[string]string{"private-address": "10.0.0.1"})
+	c.Assert(err, gc.ErrorMatches, `relation setting "private-address" not declared in schema`)
+}
+
+func (s *SchemaSuite) TestValidateWithoutSchema(c *gc.C) {
+	var schema relation.EndpointSchema
+	err := schema.ValidateUnitSettings(map[string]string{"anything": "goes"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = schema.ValidateApplicationSettings(map[string]string{"anything": "goes"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SchemaSuite) TestDataBagSize(c *gc.C) {
+	size := relation.DataBagSize(map[string]interface{}{
+		"foo": "bar",
+		"baz": "quux",
+	})
+	c.Assert(size, gc.Equals, 13)
+}
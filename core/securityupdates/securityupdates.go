@@ -0,0 +1,128 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package securityupdates describes the reports which machine agents
+// make, when the model's report-security-updates setting is enabled,
+// of the package updates pending on their machines and whether the
+// machines need rebooting, as they are recorded in the status data of
+// the machines.
+package securityupdates
+
+import (
+	"reflect"
+	"sort"
+	"time"
+)
+
+// DataKey is the key in the status data of a machine under which its
+// latest report is recorded.
+const DataKey = "security-updates"
+
+// Report describes the patch posture of a machine.
+type Report struct {
+	// Pending is the number of package updates available.
+	Pending int
+
+	// Security is how many of the pending updates are security
+	// updates.
+	Security int
+
+	// RebootRequired reports whether the machine needs rebooting to
+	// finish applying updates which have already been installed.
+	RebootRequired bool
+
+	// RebootPackages holds the names of the packages which asked for
+	// the reboot, if known.
+	RebootPackages []string
+
+	// Checked is when the machine was last checked.
+	Checked time.Time
+}
+
+// StatusData returns the report as it is recorded under DataKey in
+// status data.
+func StatusData(r Report) map[string]interface{} {
+	data := map[string]interface{}{
+		"pending":         r.Pending,
+		"security":        r.Security,
+		"reboot-required": r.RebootRequired,
+		"checked":         r.Checked.UTC().Format(time.RFC3339),
+	}
+	if len(r.RebootPackages) > 0 {
+		packages := make([]interface{}, len(r.RebootPackages))
+		for i, p := range r.RebootPackages {
+			packages[i] = p
+		}
+		data["reboot-packages"] = packages
+	}
+	return data
+}
+
+// FromStatusData returns the report recorded in the given status data,
+// and whether there was one which could be read.
+func FromStatusData(data map[string]interface{}) (Report, bool) {
+	value, ok := asMap(data[DataKey])
+	if !ok {
+		return Report{}, false
+	}
+	pending, ok := intValue(value["pending"])
+	if !ok {
+		return Report{}, false
+	}
+	security, _ := intValue(value["security"])
+	rebootRequired, _ := value["reboot-required"].(bool)
+	checked, _ := value["checked"].(string)
+	t, _ := time.Parse(time.RFC3339, checked)
+	r := Report{
+		Pending:        pending,
+		Security:       security,
+		RebootRequired: rebootRequired,
+		Checked:        t,
+	}
+	if packages, ok := value["reboot-packages"].([]interface{}); ok {
+		for _, p := range packages {
+			if name, ok := p.(string); ok {
+				r.RebootPackages = append(r.RebootPackages, name)
+			}
+		}
+		sort.Strings(r.RebootPackages)
+	}
+	return r, true
+}
+
+// intValue returns the value as an int. Numbers which have been
+// through JSON or BSON are float64 or int64 rather than int, so all of
+// those are accepted.
+func intValue(v interface{}) (int, bool) {
+	switch value := v.(type) {
+	case int:
+		return value, true
+	case int64:
+		return int(value), true
+	case float64:
+		return int(value), true
+	}
+	return 0, false
+}
+
+// asMap returns the value as a map with string keys. Maps read from
+// the database or decoded from YAML don't have the same type as those
+// decoded from JSON, so they are converted.
+func asMap(v interface{}) (map[string]interface{}, bool) {
+	if m, ok := v.(map[string]interface{}); ok {
+		return m, true
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Map {
+		return nil, false
+	}
+	m := make(map[string]interface{}, rv.Len())
+	for _, key := range rv.MapKeys() {
+		k, ok := key.Interface().(string)
+		if !ok {
+			return nil, false
+		}
+		m[k] = rv.MapIndex(key).Interface()
+	}
+	return m, true
+}
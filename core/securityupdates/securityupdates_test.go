@@ -0,0 +1,62 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package securityupdates_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/securityupdates"
+)
+
+type reportSuite struct{}
+
+var _ = gc.Suite(&reportSuite{})
+
+func (s *reportSuite) TestRoundTrip(c *gc.C) {
+	report := securityupdates.Report{
+		Pending:        12,
+		Security:       3,
+		RebootRequired: true,
+		RebootPackages: []string{"libc6", "linux-image-generic"},
+		Checked:        time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+	}
+	data := map[string]interface{}{
+		securityupdates.DataKey: securityupdates.StatusData(report),
+	}
+	read, ok := securityupdates.FromStatusData(data)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(read, jc.DeepEquals, report)
+}
+
+func (s *reportSuite) TestFromDecodedStatusData(c *gc.C) {
+	// Status data decoded from JSON holds numbers as float64.
+	data := map[string]interface{}{
+		securityupdates.DataKey: map[string]interface{}{
+			"pending":         float64(4),
+			"security":        float64(1),
+			"reboot-required": false,
+			"checked":         "2020-06-01T12:00:00Z",
+		},
+	}
+	read, ok := securityupdates.FromStatusData(data)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(read, jc.DeepEquals, securityupdates.Report{
+		Pending:  4,
+		Security: 1,
+		Checked:  time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+	})
+}
+
+func (s *reportSuite) TestFromStatusDataMissing(c *gc.C) {
+	_, ok := securityupdates.FromStatusData(nil)
+	c.Assert(ok, jc.IsFalse)
+
+	_, ok = securityupdates.FromStatusData(map[string]interface{}{
+		securityupdates.DataKey: map[string]interface{}{"security": 1},
+	})
+	c.Assert(ok, jc.IsFalse)
+}
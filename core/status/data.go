@@ -0,0 +1,160 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"reflect"
+	"time"
+)
+
+// The keys under which the uniter records, in the status data of a
+// unit's agent, which hook failed.
+const (
+	// HookKey holds the name of the hook which failed, including the
+	// relation name for relation hooks (e.g. "db-relation-changed").
+	HookKey = "hook"
+
+	// RelationIdKey holds the id of the relation whose hook failed.
+	// It is only set for relation hooks.
+	RelationIdKey = "relation-id"
+
+	// RemoteUnitKey holds the name of the remote unit whose change
+	// triggered the failed relation hook, if there was one.
+	RemoteUnitKey = "remote-unit"
+)
+
+// TransientKey is the key under which the status data of a machine's
+// instance records that a provisioning error is transient, and so
+// provisioning should be retried.
+const TransientKey = "transient"
+
+// KubernetesEventsKey is the key under which the status data of a
+// unit's cloud container, or of an application's operator, records the
+// most recent warning events of its pod which explain why it isn't
+// running, such as its image failing to pull, newest first.
+const KubernetesEventsKey = "kubernetes-events"
+
+// KubernetesEvent describes a warning event recorded by Kubernetes for
+// a pod.
+type KubernetesEvent struct {
+	// Reason is the reason Kubernetes gave the event, such as
+	// "BackOff" or "FailedScheduling".
+	Reason string
+
+	// Message describes the event.
+	Message string
+
+	// Since is when the event was first seen.
+	Since time.Time
+}
+
+// HookName returns the name of the failed hook recorded in the given
+// status data, and whether there was one.
+func HookName(data map[string]interface{}) (string, bool) {
+	return stringValue(data, HookKey)
+}
+
+// RelationId returns the id of the relation recorded in the given
+// status data, and whether there was one.
+func RelationId(data map[string]interface{}) (int, bool) {
+	return intValue(data, RelationIdKey)
+}
+
+// RemoteUnit returns the name of the remote unit recorded in the given
+// status data, and whether there was one.
+func RemoteUnit(data map[string]interface{}) (string, bool) {
+	return stringValue(data, RemoteUnitKey)
+}
+
+// IsTransient returns whether the given status data records that an
+// error is transient.
+func IsTransient(data map[string]interface{}) bool {
+	transient, ok := data[TransientKey].(bool)
+	return ok && transient
+}
+
+// KubernetesEventsData returns the events as they are recorded under
+// KubernetesEventsKey in status data.
+func KubernetesEventsData(events []KubernetesEvent) []interface{} {
+	data := make([]interface{}, len(events))
+	for i, e := range events {
+		data[i] = map[string]interface{}{
+			"reason":  e.Reason,
+			"message": e.Message,
+			"since":   e.Since.UTC().Format(time.RFC3339),
+		}
+	}
+	return data
+}
+
+// KubernetesEvents returns the Kubernetes events recorded in the given
+// status data. Events which can't be read are ignored.
+func KubernetesEvents(data map[string]interface{}) []KubernetesEvent {
+	values, ok := data[KubernetesEventsKey].([]interface{})
+	if !ok {
+		return nil
+	}
+	var events []KubernetesEvent
+	for _, v := range values {
+		value, ok := mapValue(v)
+		if !ok {
+			continue
+		}
+		reason, ok := stringValue(value, "reason")
+		if !ok {
+			continue
+		}
+		message, _ := stringValue(value, "message")
+		since, _ := stringValue(value, "since")
+		t, _ := time.Parse(time.RFC3339, since)
+		events = append(events, KubernetesEvent{
+			Reason:  reason,
+			Message: message,
+			Since:   t,
+		})
+	}
+	return events
+}
+
+func stringValue(data map[string]interface{}, key string) (string, bool) {
+	value, ok := data[key].(string)
+	return value, ok
+}
+
+// intValue returns the integer held under key in data. Status data
+// which has been through JSON or BSON holds numbers as float64 or
+// int64 rather than int, so all of those are accepted.
+func intValue(data map[string]interface{}, key string) (int, bool) {
+	switch value := data[key].(type) {
+	case int:
+		return value, true
+	case int64:
+		return int(value), true
+	case float64:
+		return int(value), true
+	}
+	return 0, false
+}
+
+// mapValue returns the value as a map with string keys. Maps read from
+// the database don't have the same type as those decoded from JSON, so
+// they are converted.
+func mapValue(v interface{}) (map[string]interface{}, bool) {
+	if m, ok := v.(map[string]interface{}); ok {
+		return m, true
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Map {
+		return nil, false
+	}
+	m := make(map[string]interface{}, rv.Len())
+	for _, key := range rv.MapKeys() {
+		k, ok := key.Interface().(string)
+		if !ok {
+			return nil, false
+		}
+		m[k] = rv.MapIndex(key).Interface()
+	}
+	return m, true
+}
@@ -0,0 +1,125 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+)
+
+type DataSuite struct{}
+
+var _ = gc.Suite(&DataSuite{})
+
+func (s *DataSuite) TestHookError(c *gc.C) {
+	data := map[string]interface{}{
+		status.HookKey:       "db-relation-changed",
+		status.RelationIdKey: 3,
+		status.RemoteUnitKey: "mysql/0",
+	}
+	hook, ok := status.HookName(data)
+	c.Check(ok, jc.IsTrue)
+	c.Check(hook, gc.Equals, "db-relation-changed")
+	relationId, ok := status.RelationId(data)
+	c.Check(ok, jc.IsTrue)
+	c.Check(relationId, gc.Equals, 3)
+	remoteUnit, ok := status.RemoteUnit(data)
+	c.Check(ok, jc.IsTrue)
+	c.Check(remoteUnit, gc.Equals, "mysql/0")
+}
+
+func (s *DataSuite) TestMissingKeys(c *gc.C) {
+	data := map[string]interface{}{}
+	_, ok := status.HookName(data)
+	c.Check(ok, jc.IsFalse)
+	_, ok = status.RelationId(data)
+	c.Check(ok, jc.IsFalse)
+	_, ok = status.RemoteUnit(data)
+	c.Check(ok, jc.IsFalse)
+	_, ok = status.StartAttempts(data)
+	c.Check(ok, jc.IsFalse)
+	_, ok = status.ProviderErrorClassOf(data)
+	c.Check(ok, jc.IsFalse)
+	c.Check(status.IsTransient(data), jc.IsFalse)
+	c.Check(status.IsTransient(nil), jc.IsFalse)
+}
+
+func (s *DataSuite) TestNumbersFromSerialisedData(c *gc.C) {
+	// Status data read back from JSON or BSON holds numbers
+	// as float64 or int64.
+	for _, value := range []interface{}{2, int64(2), float64(2)} {
+		attempts, ok := status.StartAttempts(map[string]interface{}{
+			status.StartAttemptsKey: value,
+		})
+		c.Check(ok, jc.IsTrue)
+		c.Check(attempts, gc.Equals, 2)
+	}
+	_, ok := status.RelationId(map[string]interface{}{status.RelationIdKey: "0"})
+	c.Check(ok, jc.IsFalse)
+}
+
+func (s *DataSuite) TestStartInstanceData(c *gc.C) {
+	data := map[string]interface{}{
+		status.StartAttemptsKey:      3,
+		status.StartRetriesLeftKey:   0,
+		status.ProviderErrorKey:      "no capacity",
+		status.ProviderErrorClassKey: "availability-zone",
+	}
+	attempts, ok := status.StartAttempts(data)
+	c.Check(ok, jc.IsTrue)
+	c.Check(attempts, gc.Equals, 3)
+	retriesLeft, ok := status.StartRetriesLeft(data)
+	c.Check(ok, jc.IsTrue)
+	c.Check(retriesLeft, gc.Equals, 0)
+	providerError, ok := status.ProviderError(data)
+	c.Check(ok, jc.IsTrue)
+	c.Check(providerError, gc.Equals, "no capacity")
+	class, ok := status.ProviderErrorClassOf(data)
+	c.Check(ok, jc.IsTrue)
+	c.Check(class, gc.Equals, status.ProviderErrorAvailabilityZone)
+}
+
+func (s *DataSuite) TestTransient(c *gc.C) {
+	c.Check(status.IsTransient(map[string]interface{}{status.TransientKey: true}), jc.IsTrue)
+	c.Check(status.IsTransient(map[string]interface{}{status.TransientKey: false}), jc.IsFalse)
+	c.Check(status.IsTransient(map[string]interface{}{status.TransientKey: "true"}), jc.IsFalse)
+}
+
+func (s *DataSuite) TestKubernetesEvents(c *gc.C) {
+	events := []status.KubernetesEvent{{
+		Reason:  "BackOff",
+		Message: `Back-off pulling image "mysql:9"`,
+		Since:   time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+	}, {
+		Reason:  "Failed",
+		Message: "ErrImagePull",
+		Since:   time.Date(2020, 6, 1, 11, 59, 0, 0, time.UTC),
+	}}
+	data := map[string]interface{}{
+		status.KubernetesEventsKey: status.KubernetesEventsData(events),
+	}
+	c.Check(status.KubernetesEvents(data), jc.DeepEquals, events)
+	c.Check(status.KubernetesEvents(nil), gc.HasLen, 0)
+}
+
+func (s *DataSuite) TestKubernetesEventsFromDatabase(c *gc.C) {
+	// Maps read from the database have their own named type.
+	type M map[string]interface{}
+	data := map[string]interface{}{
+		status.KubernetesEventsKey: []interface{}{
+			M{"reason": "FailedScheduling", "message": "0/1 nodes are available", "since": "2020-06-01T12:00:00Z"},
+			M{"message": "no reason"},
+			"garbage",
+		},
+	}
+	c.Check(status.KubernetesEvents(data), jc.DeepEquals, []status.KubernetesEvent{{
+		Reason:  "FailedScheduling",
+		Message: "0/1 nodes are available",
+		Since:   time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+	}})
+}
@@ -0,0 +1,30 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+// The keys under which the status data of an application records that
+// an operator has pinned its leader, so that it can't change while
+// maintenance is carried out.
+const (
+	// LeadershipPinnedByKey holds the tag of the user who pinned
+	// the leader.
+	LeadershipPinnedByKey = "leadership-pinned-by"
+
+	// LeadershipPinnedUntilKey holds when the pin expires, in
+	// RFC3339 format.
+	LeadershipPinnedUntilKey = "leadership-pinned-until"
+
+	// LeadershipPinReasonKey holds why the leader was pinned.
+	LeadershipPinReasonKey = "leadership-pin-reason"
+)
+
+// LeadershipPinDataKeys returns the keys under which the status data of
+// an application records that its leader has been pinned.
+func LeadershipPinDataKeys() []string {
+	return []string{
+		LeadershipPinnedByKey,
+		LeadershipPinnedUntilKey,
+		LeadershipPinReasonKey,
+	}
+}
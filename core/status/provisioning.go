@@ -0,0 +1,129 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+// ProvisioningPhase identifies a step in bringing up a machine. The
+// phases a machine has been through are recorded, with the time each
+// was reached, in the machine's provisioning timeline.
+type ProvisioningPhase string
+
+const (
+	// PhaseInstanceRequested is reached when the provisioner
+	// asks the provider to start the machine's instance.
+	PhaseInstanceRequested ProvisioningPhase = "instance-requested"
+
+	// PhaseInstanceRunning is reached when the provider first
+	// reports the machine's instance as running.
+	PhaseInstanceRunning ProvisioningPhase = "instance-running"
+
+	// PhaseCloudInitDone is reached when cloud-init has finished
+	// running on the machine.
+	PhaseCloudInitDone ProvisioningPhase = "cloud-init-done"
+
+	// PhaseAgentConnected is reached when the machine agent
+	// first reports that it has started.
+	PhaseAgentConnected ProvisioningPhase = "agent-connected"
+
+	// PhaseUnitDeployed is reached when the agent of a unit
+	// deployed to the machine first starts. It is recorded
+	// once for each unit.
+	PhaseUnitDeployed ProvisioningPhase = "unit-deployed"
+)
+
+// Valid returns true if the phase is one of the known phases.
+func (p ProvisioningPhase) Valid() bool {
+	switch p {
+	case PhaseInstanceRequested, PhaseInstanceRunning, PhaseCloudInitDone,
+		PhaseAgentConnected, PhaseUnitDeployed:
+		return true
+	}
+	return false
+}
+
+// The keys under which the provisioner records, in the status data of
+// a machine's instance, how its latest attempt to start the instance
+// went, so that failures come with the provider's reasons.
+const (
+	// StartAttemptsKey holds how many times the provisioner has
+	// asked the provider to start the instance.
+	StartAttemptsKey = "start-attempts"
+
+	// StartRetriesLeftKey holds how many more times the provisioner
+	// will ask before giving up.
+	StartRetriesLeftKey = "start-retries-left"
+
+	// AvailabilityZoneKey holds the availability zone in which the
+	// instance was last attempted.
+	AvailabilityZoneKey = "availability-zone"
+
+	// InstanceTypeKey holds the instance type last attempted.
+	// Providers which choose an instance type report it under this
+	// key in the data passed to the StartInstance status callback.
+	InstanceTypeKey = "instance-type"
+
+	// ProviderErrorKey holds the error the provider last returned.
+	ProviderErrorKey = "provider-error"
+
+	// ProviderErrorClassKey holds the ProviderErrorClass of the
+	// error the provider last returned.
+	ProviderErrorClassKey = "provider-error-class"
+)
+
+// ProviderErrorClass classifies an error returned by a provider when
+// starting an instance, so that clients can tell how it might be
+// resolved without parsing the error message.
+type ProviderErrorClass string
+
+const (
+	// ProviderErrorCredential is the class of errors caused by the
+	// model's cloud credential being invalid.
+	ProviderErrorCredential ProviderErrorClass = "credential"
+
+	// ProviderErrorAvailabilityZone is the class of errors which may
+	// be specific to the availability zone attempted.
+	ProviderErrorAvailabilityZone ProviderErrorClass = "availability-zone"
+
+	// ProviderErrorOther is the class of all other provider errors.
+	ProviderErrorOther ProviderErrorClass = "other"
+)
+
+// StartInstanceDataKeys returns the keys under which the provisioner
+// records how the latest attempt to start an instance went.
+func StartInstanceDataKeys() []string {
+	return []string{
+		StartAttemptsKey,
+		StartRetriesLeftKey,
+		AvailabilityZoneKey,
+		InstanceTypeKey,
+		ProviderErrorKey,
+		ProviderErrorClassKey,
+	}
+}
+
+// StartAttempts returns how many times the provisioner has attempted
+// to start an instance, as recorded in the given status data, and
+// whether it was recorded.
+func StartAttempts(data map[string]interface{}) (int, bool) {
+	return intValue(data, StartAttemptsKey)
+}
+
+// StartRetriesLeft returns how many more times the provisioner will
+// attempt to start an instance, as recorded in the given status data,
+// and whether it was recorded.
+func StartRetriesLeft(data map[string]interface{}) (int, bool) {
+	return intValue(data, StartRetriesLeftKey)
+}
+
+// ProviderError returns the provider error recorded in the given
+// status data, and whether there was one.
+func ProviderError(data map[string]interface{}) (string, bool) {
+	return stringValue(data, ProviderErrorKey)
+}
+
+// ProviderErrorClassOf returns the class of the provider error recorded
+// in the given status data, and whether there was one.
+func ProviderErrorClassOf(data map[string]interface{}) (ProviderErrorClass, bool) {
+	class, ok := stringValue(data, ProviderErrorClassKey)
+	return ProviderErrorClass(class), ok
+}
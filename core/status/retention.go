@@ -0,0 +1,130 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// RetentionKind identifies a kind of entity whose status history may
+// be kept for longer or shorter than that of other entities.
+type RetentionKind string
+
+const (
+	// RetentionMachine covers the history of machine agents and of
+	// the instances they run on.
+	RetentionMachine RetentionKind = "machine"
+
+	// RetentionUnitAgent covers the history of unit agents.
+	RetentionUnitAgent RetentionKind = "unit-agent"
+
+	// RetentionWorkload covers the history of unit workloads,
+	// including their workload versions.
+	RetentionWorkload RetentionKind = "workload"
+
+	// RetentionApplication covers the history of applications.
+	RetentionApplication RetentionKind = "application"
+
+	// RetentionFilesystem covers the history of filesystems.
+	RetentionFilesystem RetentionKind = "filesystem"
+
+	// RetentionVolume covers the history of volumes.
+	RetentionVolume RetentionKind = "volume"
+)
+
+// AllRetentionKinds returns all the retention kinds, in a stable order.
+func AllRetentionKinds() []RetentionKind {
+	return []RetentionKind{
+		RetentionMachine,
+		RetentionUnitAgent,
+		RetentionWorkload,
+		RetentionApplication,
+		RetentionFilesystem,
+		RetentionVolume,
+	}
+}
+
+// Validate returns an error if the kind is not known.
+func (k RetentionKind) Validate() error {
+	for _, kind := range AllRetentionKinds() {
+		if k == kind {
+			return nil
+		}
+	}
+	return errors.NotValidf("status history kind %q", string(k))
+}
+
+// HistoryRetention describes how much status history is kept for each
+// entity of a kind. Each limit replaces the model-wide one if it is
+// non-zero.
+type HistoryRetention struct {
+	// MaxAge is the age beyond which records are removed.
+	MaxAge time.Duration
+
+	// MaxEntries is the number of records kept for each entity.
+	MaxEntries int
+}
+
+// retentionYAML is the form in which a HistoryRetention is written in
+// model config.
+type retentionYAML struct {
+	MaxAge     string `yaml:"max-age,omitempty"`
+	MaxEntries int    `yaml:"max-entries,omitempty"`
+}
+
+// ParseHistoryRetention parses and validates the YAML map of entity
+// kinds to the status history kept for them, as held in the
+// status-history-retention model config setting. For example:
+//
+//     machine:
+//       max-age: 2160h
+//     workload:
+//       max-entries: 100
+func ParseHistoryRetention(text string) (map[RetentionKind]HistoryRetention, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	var raw map[RetentionKind]retentionYAML
+	if err := yaml.UnmarshalStrict([]byte(text), &raw); err != nil {
+		return nil, errors.Annotate(err, "parsing status history retention")
+	}
+	result := make(map[RetentionKind]HistoryRetention)
+	for kind, r := range raw {
+		if err := kind.Validate(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		var retention HistoryRetention
+		if r.MaxAge != "" {
+			maxAge, err := time.ParseDuration(r.MaxAge)
+			if err != nil {
+				return nil, errors.Annotatef(err, "%s max-age", kind)
+			}
+			retention.MaxAge = maxAge
+		}
+		retention.MaxEntries = r.MaxEntries
+		if err := retention.Validate(); err != nil {
+			return nil, errors.Annotatef(err, "%s", kind)
+		}
+		result[kind] = retention
+	}
+	return result, nil
+}
+
+// Validate returns an error if the retention is not valid.
+func (r HistoryRetention) Validate() error {
+	if r.MaxAge < 0 {
+		return errors.NotValidf("negative max age")
+	}
+	if r.MaxEntries < 0 {
+		return errors.NotValidf("negative max entries")
+	}
+	if r.MaxAge == 0 && r.MaxEntries == 0 {
+		return errors.NotValidf("status history retention without limits")
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+)
+
+type RetentionSuite struct{}
+
+var _ = gc.Suite(&RetentionSuite{})
+
+func (s *RetentionSuite) TestParseHistoryRetention(c *gc.C) {
+	retention, err := status.ParseHistoryRetention(`
+machine:
+  max-age: 2160h
+workload:
+  max-entries: 100
+volume:
+  max-age: 24h
+  max-entries: 10
+`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(retention, jc.DeepEquals, map[status.RetentionKind]status.HistoryRetention{
+		status.RetentionMachine:  {MaxAge: 2160 * time.Hour},
+		status.RetentionWorkload: {MaxEntries: 100},
+		status.RetentionVolume:   {MaxAge: 24 * time.Hour, MaxEntries: 10},
+	})
+}
+
+func (s *RetentionSuite) TestParseHistoryRetentionEmpty(c *gc.C) {
+	retention, err := status.ParseHistoryRetention(" \n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(retention, gc.HasLen, 0)
+}
+
+func (s *RetentionSuite) TestParseHistoryRetentionInvalid(c *gc.C) {
+	for i, test := range []struct {
+		text string
+		err  string
+	}{{
+		text: "model:\n  max-age: 1h",
+		err:  `status history kind "model" not valid`,
+	}, {
+		text: "machine:\n  max-age: forever",
+		err:  `machine max-age: time: invalid duration "?forever"?`,
+	}, {
+		text: "machine:\n  max-age: -1h",
+		err:  "machine: negative max age not valid",
+	}, {
+		text: "workload:\n  max-entries: -1",
+		err:  "workload: negative max entries not valid",
+	}, {
+		text: "workload: {}",
+		err:  "workload: status history retention without limits not valid",
+	}, {
+		text: "workload:\n  max-size: 1M",
+		err:  "parsing status history retention: .*field max-size not found.*",
+	}} {
+		c.Logf("test %d: %s", i, test.text)
+		_, err := status.ParseHistoryRetention(test.text)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
@@ -26,6 +26,13 @@ type StatusInfo struct {
 	Message string
 	Data    map[string]interface{}
 	Since   *time.Time
+
+	// FirstSince and Count are only set on status history records
+	// which stand for the same status being set several times in a
+	// row: FirstSince holds when it was first set, Count how many
+	// times, and Since when it was last set.
+	FirstSince *time.Time
+	Count      int
 }
 
 // StatusSetter represents a type whose status can be set.
@@ -117,6 +124,12 @@ const (
 	// The juju agent has has not communicated with the juju server for an unexpectedly long time;
 	// the unit agent ought to be signalling activity, but none has been detected.
 	Lost Status = "lost"
+
+	// Gone is set when:
+	// The juju agent has not communicated with the juju server for longer
+	// than the model's dead-agent-threshold. The unit is a candidate for
+	// removal with --force.
+	Gone Status = "gone"
 )
 
 const (
@@ -279,7 +292,9 @@ func (status Status) KnownAgentStatus() bool {
 		Failed,
 		Rebooting,
 		Executing,
-		Idle:
+		Idle,
+		Gone,
+		Maintenance:
 		return true
 	}
 	return false
@@ -346,3 +361,39 @@ func ValidModelStatus(status Status) bool {
 func (status Status) Matches(candidate Status) bool {
 	return status == candidate
 }
+
+// DeriveApplicationStatus returns the most severe of the supplied unit
+// workload statuses, which is what an application reports as its own
+// status when its charm has never set one. From most to least severe,
+// the statuses are:
+//
+//     error, blocked, waiting, maintenance, active, terminated, unknown
+//
+// Where several units share the most severe status, the first of them
+// is used, message, data and all. Other status values are never used.
+func DeriveApplicationStatus(statuses []StatusInfo) StatusInfo {
+	var result StatusInfo
+	for _, unitStatus := range statuses {
+		currentSeverity := statusSeverities[result.Status]
+		unitSeverity := statusSeverities[unitStatus.Status]
+		if unitSeverity > currentSeverity {
+			result.Status = unitStatus.Status
+			result.Message = unitStatus.Message
+			result.Data = unitStatus.Data
+			result.Since = unitStatus.Since
+		}
+	}
+	return result
+}
+
+// statusSeverities holds status values with a severity measure.
+// Status values with higher severity are used in preference to others.
+var statusSeverities = map[Status]int{
+	Error:       100,
+	Blocked:     90,
+	Waiting:     80,
+	Maintenance: 70,
+	Active:      60,
+	Terminated:  50,
+	Unknown:     40,
+}
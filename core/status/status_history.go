@@ -19,6 +19,11 @@ type StatusHistoryFilter struct {
 	FromDate *time.Time
 	// Delta indicates the age of the oldest log expected.
 	Delta *time.Duration
+	// Until, if set, indicates that only logs from before this time
+	// are expected. It may be combined with any of the other
+	// parameters; combined with Size, it lets the history be read a
+	// page at a time, newest first.
+	Until *time.Time
 	// Exclude indicates the status messages that should be excluded
 	// from the returned result.
 	Exclude set.Strings
@@ -39,6 +44,8 @@ func (f *StatusHistoryFilter) Validate() error {
 		return errors.NotValidf("Size and Delta together")
 	case t && d:
 		return errors.NotValidf("Date and Delta together")
+	case t && f.Until != nil && !f.Until.After(*f.FromDate):
+		return errors.NotValidf("Until not after Date")
 	}
 	return nil
 }
@@ -64,6 +71,14 @@ type DetailedStatus struct {
 	Version string
 	Life    life.Value
 	Err     error
+	// Unit is the name of the unit whose status this is, when the
+	// status history of all of an application's units is requested.
+	Unit string
+	// FirstSince and Count are set when the same status was set
+	// several times in a row: FirstSince holds when it was first
+	// set, Count how many times, and Since when it was last set.
+	FirstSince *time.Time
+	Count      int
 }
 
 // History holds many DetailedStatus,
@@ -94,6 +109,11 @@ const (
 	KindContainerInstance HistoryKind = "container"
 	// KindContainer represents an entry for a container agent.
 	KindContainer HistoryKind = "juju-container"
+	// KindMachineProvisioning represents an entry in the timeline of
+	// phases for bringing up a machine.
+	KindMachineProvisioning HistoryKind = "machine-provisioning"
+	// KindApplication represents an entry for an application.
+	KindApplication HistoryKind = "application"
 )
 
 // String returns a string representation of the HistoryKind.
@@ -106,7 +126,8 @@ func (k HistoryKind) Valid() bool {
 	switch k {
 	case KindUnit, KindUnitAgent, KindWorkload,
 		KindMachineInstance, KindMachine,
-		KindContainerInstance, KindContainer:
+		KindContainerInstance, KindContainer,
+		KindMachineProvisioning, KindApplication:
 		return true
 	}
 	return false
@@ -115,12 +136,14 @@ func (k HistoryKind) Valid() bool {
 // AllHistoryKind will return all valid HistoryKinds.
 func AllHistoryKind() map[HistoryKind]string {
 	return map[HistoryKind]string{
-		KindUnit:              "statuses for specified unit and its workload",
-		KindUnitAgent:         "statuses from the agent that is managing a unit",
-		KindWorkload:          "statuses for unit's workload",
-		KindMachineInstance:   "statuses that occur due to provisioning of a machine",
-		KindMachine:           "status of the agent that is managing a machine",
-		KindContainerInstance: "statuses from the agent that is managing containers",
-		KindContainer:         "statuses from the containers only and not their host machines",
+		KindUnit:                "statuses for specified unit and its workload",
+		KindUnitAgent:           "statuses from the agent that is managing a unit",
+		KindWorkload:            "statuses for unit's workload",
+		KindMachineInstance:     "statuses that occur due to provisioning of a machine",
+		KindMachine:             "status of the agent that is managing a machine",
+		KindContainerInstance:   "statuses from the agent that is managing containers",
+		KindContainer:           "statuses from the containers only and not their host machines",
+		KindMachineProvisioning: "timeline of the phases of bringing up a machine",
+		KindApplication:         "statuses for an application",
 	}
 }
@@ -3,6 +3,8 @@
 package status_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
@@ -98,3 +100,35 @@ func (s *StatusSuite) TestInvalidModelStatus(c *gc.C) {
 		c.Assert(status.ValidModelStatus(v), jc.IsFalse, gc.Commentf("status %q is valid for a model", v))
 	}
 }
+
+func (s *StatusSuite) TestDeriveApplicationStatus(c *gc.C) {
+	derived := status.DeriveApplicationStatus([]status.StatusInfo{
+		{Status: status.Active, Message: "ready"},
+		{Status: status.Blocked, Message: "missing relation"},
+		{Status: status.Maintenance, Message: "installing"},
+	})
+	c.Assert(derived, jc.DeepEquals, status.StatusInfo{
+		Status:  status.Blocked,
+		Message: "missing relation",
+	})
+}
+
+func (s *StatusSuite) TestDeriveApplicationStatusNoUnits(c *gc.C) {
+	c.Assert(status.DeriveApplicationStatus(nil), jc.DeepEquals, status.StatusInfo{})
+}
+
+func (s *StatusSuite) TestStatusHistoryFilterUntil(c *gc.C) {
+	from := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	until := from.Add(time.Hour)
+	for _, filter := range []status.StatusHistoryFilter{
+		{Size: 10, Until: &until},
+		{FromDate: &from, Until: &until},
+	} {
+		c.Check(filter.Validate(), jc.ErrorIsNil)
+	}
+
+	filter := status.StatusHistoryFilter{FromDate: &until, Until: &from}
+	c.Assert(filter.Validate(), gc.ErrorMatches, "Until not after Date not valid")
+	filter = status.StatusHistoryFilter{Until: &until}
+	c.Assert(filter.Validate(), gc.ErrorMatches, "missing filter parameters not valid")
+}
@@ -0,0 +1,85 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status
+
+import (
+	"time"
+)
+
+// WorkerFailuresKey is the key under which the status data of a
+// machine's agent records the most recent failures of the workers
+// run by the agent, oldest first.
+const WorkerFailuresKey = "worker-failures"
+
+// MaxWorkerFailures is the number of worker failures kept in an
+// agent's status data. Older failures are dropped.
+const MaxWorkerFailures = 20
+
+// WorkerFailure describes a worker run by an agent failing, either to
+// start or while running.
+type WorkerFailure struct {
+	// Worker is the name of the manifold which runs the worker.
+	Worker string
+
+	// Error is the error the worker failed with.
+	Error string
+
+	// Time is when the worker failed.
+	Time time.Time
+}
+
+// AppendWorkerFailures returns the history of worker failures with the
+// given failures added to it, keeping only the most recent
+// MaxWorkerFailures.
+func AppendWorkerFailures(history []WorkerFailure, failures ...WorkerFailure) []WorkerFailure {
+	all := make([]WorkerFailure, 0, len(history)+len(failures))
+	all = append(append(all, history...), failures...)
+	if len(all) > MaxWorkerFailures {
+		all = all[len(all)-MaxWorkerFailures:]
+	}
+	return all
+}
+
+// WorkerFailuresData returns the failures as they are recorded under
+// WorkerFailuresKey in status data.
+func WorkerFailuresData(failures []WorkerFailure) []interface{} {
+	data := make([]interface{}, len(failures))
+	for i, f := range failures {
+		data[i] = map[string]interface{}{
+			"worker": f.Worker,
+			"error":  f.Error,
+			"time":   f.Time.UTC().Format(time.RFC3339Nano),
+		}
+	}
+	return data
+}
+
+// WorkerFailures returns the worker failures recorded in the given
+// status data. Failures which can't be read are ignored.
+func WorkerFailures(data map[string]interface{}) []WorkerFailure {
+	values, ok := data[WorkerFailuresKey].([]interface{})
+	if !ok {
+		return nil
+	}
+	var failures []WorkerFailure
+	for _, v := range values {
+		value, ok := mapValue(v)
+		if !ok {
+			continue
+		}
+		worker, ok := stringValue(value, "worker")
+		if !ok {
+			continue
+		}
+		message, _ := stringValue(value, "error")
+		when, _ := stringValue(value, "time")
+		t, _ := time.Parse(time.RFC3339Nano, when)
+		failures = append(failures, WorkerFailure{
+			Worker: worker,
+			Error:  message,
+			Time:   t,
+		})
+	}
+	return failures
+}
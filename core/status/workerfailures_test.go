@@ -0,0 +1,65 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package status_test
+
+import (
+	"fmt"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+)
+
+type WorkerFailuresSuite struct{}
+
+var _ = gc.Suite(&WorkerFailuresSuite{})
+
+func (s *WorkerFailuresSuite) TestRoundTrip(c *gc.C) {
+	failures := []status.WorkerFailure{{
+		Worker: "uniter",
+		Error:  "connection is shut down",
+		Time:   time.Date(2020, 6, 1, 12, 0, 0, 500, time.UTC),
+	}, {
+		Worker: "disk-manager",
+		Error:  "listing block devices: exit status 1",
+		Time:   time.Date(2020, 6, 1, 12, 5, 0, 0, time.UTC),
+	}}
+	data := map[string]interface{}{
+		status.WorkerFailuresKey: status.WorkerFailuresData(failures),
+	}
+	c.Check(status.WorkerFailures(data), jc.DeepEquals, failures)
+	c.Check(status.WorkerFailures(nil), gc.HasLen, 0)
+}
+
+func (s *WorkerFailuresSuite) TestFromDatabase(c *gc.C) {
+	// Maps read from the database have their own named type.
+	type M map[string]interface{}
+	data := map[string]interface{}{
+		status.WorkerFailuresKey: []interface{}{
+			M{"worker": "uniter", "error": "boom", "time": "2020-06-01T12:00:00Z"},
+			M{"error": "no worker"},
+			"garbage",
+		},
+	}
+	c.Check(status.WorkerFailures(data), jc.DeepEquals, []status.WorkerFailure{{
+		Worker: "uniter",
+		Error:  "boom",
+		Time:   time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC),
+	}})
+}
+
+func (s *WorkerFailuresSuite) TestAppendKeepsMostRecent(c *gc.C) {
+	var history []status.WorkerFailure
+	for i := 0; i < status.MaxWorkerFailures+3; i++ {
+		history = status.AppendWorkerFailures(history, status.WorkerFailure{
+			Worker: fmt.Sprintf("worker-%d", i),
+		})
+	}
+	c.Assert(history, gc.HasLen, status.MaxWorkerFailures)
+	c.Check(history[0].Worker, gc.Equals, "worker-3")
+	c.Check(history[status.MaxWorkerFailures-1].Worker, gc.Equals,
+		fmt.Sprintf("worker-%d", status.MaxWorkerFailures+2))
+}
@@ -0,0 +1,244 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package statusalert evaluates operator defined alert rules, such as
+// "any unit in error for more than 5 minutes", against the current
+// status of the entities in a model.
+package statusalert
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/core/healthprobe"
+	"github.com/juju/juju/core/status"
+)
+
+// Kind identifies the kind of entity a rule applies to.
+type Kind string
+
+const (
+	// KindUnit rules match the agent or workload status of units.
+	KindUnit Kind = "unit"
+
+	// KindMachine rules match the agent status of machines.
+	KindMachine Kind = "machine"
+
+	// KindApplication rules match the status of applications.
+	KindApplication Kind = "application"
+)
+
+// Rule describes a condition which raises an alert when an entity has
+// been in a status, or a unit's health probe has been failing, for at
+// least a period of time.
+type Rule struct {
+	// Name identifies the rule in the alerts it raises.
+	Name string `yaml:"name"`
+
+	// Entity is the kind of entity the rule applies to.
+	Entity Kind `yaml:"entity"`
+
+	// Status is the status which raises the alert.
+	Status status.Status `yaml:"status,omitempty"`
+
+	// Probe is the name of the health probe which raises the alert
+	// when it is failing, for rules applying to units. A rule has
+	// either a status or a probe.
+	Probe string `yaml:"probe,omitempty"`
+
+	// For is how long an entity must have been in the status, or the
+	// probe failing, before the alert is raised, eg "5m". If empty, the alert is raised as
+	// soon as the entity enters the status.
+	For string `yaml:"for,omitempty"`
+
+	// Applications, if not empty, limits the rule to the named
+	// applications and their units.
+	Applications []string `yaml:"applications,omitempty"`
+}
+
+// Validate returns an error if the rule is not valid.
+func (r Rule) Validate() error {
+	if r.Name == "" {
+		return errors.NotValidf("empty rule name")
+	}
+	switch r.Entity {
+	case KindUnit, KindMachine, KindApplication:
+	default:
+		return errors.NotValidf("rule %q entity %q", r.Name, r.Entity)
+	}
+	switch {
+	case r.Status == "" && r.Probe == "":
+		return errors.NotValidf("rule %q empty status", r.Name)
+	case r.Status != "" && r.Probe != "":
+		return errors.NotValidf("rule %q with both status and probe", r.Name)
+	case r.Probe != "" && r.Entity != KindUnit:
+		return errors.NotValidf("rule %q probe for entity %q", r.Name, r.Entity)
+	}
+	if r.For != "" {
+		d, err := time.ParseDuration(r.For)
+		if err != nil {
+			return errors.Annotatef(err, "rule %q", r.Name)
+		}
+		if d < 0 {
+			return errors.Errorf("rule %q duration %v cannot be negative", r.Name, d)
+		}
+	}
+	return nil
+}
+
+// Duration returns how long an entity must have been in the rule's
+// status, or the rule's probe failing, before the alert is raised.
+func (r Rule) Duration() time.Duration {
+	// The value has already been validated.
+	d, _ := time.ParseDuration(r.For)
+	return d
+}
+
+// ParseRules parses and validates a YAML list of rules, as held in the
+// status-alert-rules model config setting.
+func ParseRules(text string) ([]Rule, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, nil
+	}
+	var rules []Rule
+	if err := yaml.UnmarshalStrict([]byte(text), &rules); err != nil {
+		return nil, errors.Annotate(err, "parsing status alert rules")
+	}
+	names := set.NewStrings()
+	for _, r := range rules {
+		if err := r.Validate(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if names.Contains(r.Name) {
+			return nil, errors.NotValidf("duplicate rule name %q", r.Name)
+		}
+		names.Add(r.Name)
+	}
+	return rules, nil
+}
+
+// Entity holds the current status of an entity in a model.
+type Entity struct {
+	// Kind is the kind of the entity.
+	Kind Kind
+
+	// Name is the name of the entity, eg "mysql/0" or "0".
+	Name string
+
+	// Application is the name of the application a unit belongs to,
+	// or the name of an application.
+	Application string
+
+	// Statuses holds the statuses of the entity which rules are
+	// matched against; for units, the agent and workload status. The
+	// results of a unit's health probes are held in their data.
+	Statuses []status.StatusInfo
+}
+
+// Alert describes an entity which matches a rule.
+type Alert struct {
+	Rule    string
+	Kind    Kind
+	Entity  string
+	Status  status.Status
+	Message string
+	Since   time.Time
+}
+
+// Key uniquely identifies the alert, so that it can be tracked while
+// the entity continues to match the rule.
+func (a Alert) Key() string {
+	return fmt.Sprintf("%s:%s:%s", a.Rule, a.Kind, a.Entity)
+}
+
+// Evaluate returns the alerts raised by the rules for the entities at
+// the given time, ordered by rule and entity. An entity with no time
+// recorded for its status only raises alerts for rules with no
+// duration.
+func Evaluate(rules []Rule, entities []Entity, now time.Time) []Alert {
+	var alerts []Alert
+	for _, r := range rules {
+		applications := set.NewStrings(r.Applications...)
+		for _, e := range entities {
+			if e.Kind != r.Entity {
+				continue
+			}
+			if !applications.IsEmpty() && !applications.Contains(e.Application) {
+				continue
+			}
+			if alert, ok := match(r, e, now); ok {
+				alerts = append(alerts, alert)
+			}
+		}
+	}
+	sort.SliceStable(alerts, func(i, j int) bool {
+		if alerts[i].Rule != alerts[j].Rule {
+			return alerts[i].Rule < alerts[j].Rule
+		}
+		return alerts[i].Entity < alerts[j].Entity
+	})
+	return alerts
+}
+
+func match(r Rule, e Entity, now time.Time) (Alert, bool) {
+	if r.Probe != "" {
+		return matchProbe(r, e, now)
+	}
+	d := r.Duration()
+	for _, info := range e.Statuses {
+		if info.Status != r.Status {
+			continue
+		}
+		var since time.Time
+		if info.Since != nil {
+			since = *info.Since
+		} else if d > 0 {
+			continue
+		}
+		if d > 0 && now.Sub(since) < d {
+			continue
+		}
+		return Alert{
+			Rule:    r.Name,
+			Kind:    e.Kind,
+			Entity:  e.Name,
+			Status:  info.Status,
+			Message: info.Message,
+			Since:   since,
+		}, true
+	}
+	return Alert{}, false
+}
+
+// matchProbe matches a rule against the results of the entity's
+// health probes.
+func matchProbe(r Rule, e Entity, now time.Time) (Alert, bool) {
+	for _, info := range e.Statuses {
+		result, ok := healthprobe.ResultsFromStatusData(info.Data)[r.Probe]
+		if !ok || result.Healthy {
+			continue
+		}
+		if now.Sub(result.Since) < r.Duration() {
+			return Alert{}, false
+		}
+		message := fmt.Sprintf("health probe %q failing", r.Probe)
+		if result.Message != "" {
+			message += ": " + result.Message
+		}
+		return Alert{
+			Rule:    r.Name,
+			Kind:    e.Kind,
+			Entity:  e.Name,
+			Status:  info.Status,
+			Message: message,
+			Since:   result.Since,
+		}, true
+	}
+	return Alert{}, false
+}
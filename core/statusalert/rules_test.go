@@ -0,0 +1,223 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statusalert_test
+
+import (
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/healthprobe"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/statusalert"
+)
+
+type RulesSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&RulesSuite{})
+
+func (s *RulesSuite) TestParseRules(c *gc.C) {
+	rules, err := statusalert.ParseRules(`
+- name: unit-error
+  entity: unit
+  status: error
+  for: 5m
+- name: mysql-blocked
+  entity: application
+  status: blocked
+  applications: [mysql]
+`)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []statusalert.Rule{{
+		Name:   "unit-error",
+		Entity: statusalert.KindUnit,
+		Status: status.Error,
+		For:    "5m",
+	}, {
+		Name:         "mysql-blocked",
+		Entity:       statusalert.KindApplication,
+		Status:       status.Blocked,
+		Applications: []string{"mysql"},
+	}})
+	c.Assert(rules[0].Duration(), gc.Equals, 5*time.Minute)
+}
+
+func (s *RulesSuite) TestParseRulesEmpty(c *gc.C) {
+	rules, err := statusalert.ParseRules("  \n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+}
+
+func (s *RulesSuite) TestParseRulesInvalid(c *gc.C) {
+	for i, test := range []struct {
+		text string
+		err  string
+	}{{
+		text: "- name: x\n  entity: unit\n  status: error\n  when: 5m\n",
+		err:  `parsing status alert rules: .*field when not found.*`,
+	}, {
+		text: "- entity: unit\n  status: error\n",
+		err:  `empty rule name not valid`,
+	}, {
+		text: "- name: x\n  entity: relation\n  status: error\n",
+		err:  `rule "x" entity "relation" not valid`,
+	}, {
+		text: "- name: x\n  entity: machine\n",
+		err:  `rule "x" empty status not valid`,
+	}, {
+		text: "- name: x\n  entity: unit\n  status: error\n  probe: port\n",
+		err:  `rule "x" with both status and probe not valid`,
+	}, {
+		text: "- name: x\n  entity: machine\n  probe: port\n",
+		err:  `rule "x" probe for entity "machine" not valid`,
+	}, {
+		text: "- name: x\n  entity: unit\n  status: error\n  for: soon\n",
+		err:  `rule "x": time: invalid duration .*soon.*`,
+	}, {
+		text: "- name: x\n  entity: unit\n  status: error\n  for: -1m\n",
+		err:  `rule "x" duration -1m0s cannot be negative`,
+	}, {
+		text: "- name: x\n  entity: unit\n  status: error\n- name: x\n  entity: machine\n  status: down\n",
+		err:  `duplicate rule name "x" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := statusalert.ParseRules(test.text)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *RulesSuite) TestEvaluate(c *gc.C) {
+	now := time.Date(2020, 1, 3, 14, 0, 0, 0, time.UTC)
+	longAgo := now.Add(-10 * time.Minute)
+	recently := now.Add(-time.Minute)
+	rules := []statusalert.Rule{{
+		Name:   "unit-error",
+		Entity: statusalert.KindUnit,
+		Status: status.Error,
+		For:    "5m",
+	}, {
+		Name:   "machine-down",
+		Entity: statusalert.KindMachine,
+		Status: status.Down,
+	}, {
+		Name:         "mysql-blocked",
+		Entity:       statusalert.KindApplication,
+		Status:       status.Blocked,
+		Applications: []string{"mysql"},
+	}}
+	entities := []statusalert.Entity{{
+		Kind:        statusalert.KindUnit,
+		Name:        "mysql/1",
+		Application: "mysql",
+		Statuses: []status.StatusInfo{
+			{Status: status.Idle, Since: &longAgo},
+			{Status: status.Error, Message: "hook failed", Since: &longAgo},
+		},
+	}, {
+		Kind:        statusalert.KindUnit,
+		Name:        "mysql/0",
+		Application: "mysql",
+		Statuses: []status.StatusInfo{
+			{Status: status.Error, Message: "hook failed", Since: &recently},
+		},
+	}, {
+		Kind:     statusalert.KindMachine,
+		Name:     "0",
+		Statuses: []status.StatusInfo{{Status: status.Down}},
+	}, {
+		Kind:        statusalert.KindApplication,
+		Name:        "mysql",
+		Application: "mysql",
+		Statuses:    []status.StatusInfo{{Status: status.Blocked, Message: "need a relation", Since: &recently}},
+	}, {
+		Kind:        statusalert.KindApplication,
+		Name:        "wordpress",
+		Application: "wordpress",
+		Statuses:    []status.StatusInfo{{Status: status.Blocked, Since: &recently}},
+	}}
+	alerts := statusalert.Evaluate(rules, entities, now)
+	c.Assert(alerts, jc.DeepEquals, []statusalert.Alert{{
+		Rule:   "machine-down",
+		Kind:   statusalert.KindMachine,
+		Entity: "0",
+		Status: status.Down,
+	}, {
+		Rule:    "mysql-blocked",
+		Kind:    statusalert.KindApplication,
+		Entity:  "mysql",
+		Status:  status.Blocked,
+		Message: "need a relation",
+		Since:   recently,
+	}, {
+		Rule:    "unit-error",
+		Kind:    statusalert.KindUnit,
+		Entity:  "mysql/1",
+		Status:  status.Error,
+		Message: "hook failed",
+		Since:   longAgo,
+	}})
+	c.Assert(alerts[2].Key(), gc.Equals, "unit-error:unit:mysql/1")
+}
+
+func (s *RulesSuite) TestEvaluateDurationWithoutSince(c *gc.C) {
+	rules := []statusalert.Rule{{
+		Name:   "machine-down",
+		Entity: statusalert.KindMachine,
+		Status: status.Down,
+		For:    "1m",
+	}}
+	entities := []statusalert.Entity{{
+		Kind:     statusalert.KindMachine,
+		Name:     "0",
+		Statuses: []status.StatusInfo{{Status: status.Down}},
+	}}
+	c.Assert(statusalert.Evaluate(rules, entities, time.Now()), gc.HasLen, 0)
+}
+
+func (s *RulesSuite) TestEvaluateProbe(c *gc.C) {
+	rules, err := statusalert.ParseRules("- name: port-down\n  entity: unit\n  probe: port\n  for: 5m\n")
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Date(2020, 1, 3, 14, 0, 0, 0, time.UTC)
+	longAgo := now.Add(-10 * time.Minute)
+	unit := func(name string, results map[string]healthprobe.Result) statusalert.Entity {
+		return statusalert.Entity{
+			Kind:        statusalert.KindUnit,
+			Name:        name,
+			Application: "mysql",
+			Statuses: []status.StatusInfo{
+				{Status: status.Idle, Since: &longAgo},
+				{Status: status.Active, Since: &longAgo, Data: map[string]interface{}{
+					healthprobe.DataKey: healthprobe.StatusData(results),
+				}},
+			},
+		}
+	}
+	entities := []statusalert.Entity{
+		unit("mysql/0", map[string]healthprobe.Result{
+			"port": {Message: "connection refused", Since: longAgo},
+		}),
+		unit("mysql/1", map[string]healthprobe.Result{
+			"port": {Message: "connection refused", Since: now.Add(-time.Minute)},
+		}),
+		unit("mysql/2", map[string]healthprobe.Result{
+			"port": {Healthy: true, Since: longAgo},
+		}),
+		unit("mysql/3", map[string]healthprobe.Result{
+			"ping": {Since: longAgo},
+		}),
+	}
+	c.Assert(statusalert.Evaluate(rules, entities, now), jc.DeepEquals, []statusalert.Alert{{
+		Rule:    "port-down",
+		Kind:    statusalert.KindUnit,
+		Entity:  "mysql/0",
+		Status:  status.Active,
+		Message: `health probe "port" failing: connection refused`,
+		Since:   longAgo,
+	}})
+}
@@ -0,0 +1,171 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package callaudit
+
+import (
+	"github.com/juju/version"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/network"
+)
+
+// LabelsFor returns the labels identifying calls made by the named
+// caller to the given environ's cloud and region.
+func LabelsFor(env environs.Environ, caller string) Labels {
+	labels := Labels{Caller: caller}
+	if cfg := env.Config(); cfg != nil {
+		labels.Cloud = cfg.Type()
+	}
+	if hasRegion, ok := env.(simplestreams.HasRegion); ok {
+		if spec, err := hasRegion.Region(); err == nil {
+			labels.Region = spec.Region
+		}
+	}
+	return labels
+}
+
+// WrapEnviron returns an Environ which records the calls made to the
+// instance management methods of the given environ.
+//
+// The returned Environ only implements environs.Environ: any optional
+// interfaces implemented by env, such as environs.Firewaller or
+// environs.Networking, are hidden by it. Callers which need those must
+// check for them on the original environ, and wrap them separately
+// where a wrapper exists.
+func WrapEnviron(env environs.Environ, labels Labels, recorder *Recorder) environs.Environ {
+	return &auditedEnviron{Environ: env, labels: labels, recorder: recorder}
+}
+
+type auditedEnviron struct {
+	environs.Environ
+	labels   Labels
+	recorder *Recorder
+}
+
+func (e *auditedEnviron) observe(method string, f func() error) error {
+	return e.recorder.Observe(e.labels, method, f)
+}
+
+// StartInstance is part of the environs.InstanceBroker interface.
+func (e *auditedEnviron) StartInstance(
+	ctx context.ProviderCallContext, args environs.StartInstanceParams,
+) (result *environs.StartInstanceResult, err error) {
+	err = e.observe("StartInstance", func() error {
+		result, err = e.Environ.StartInstance(ctx, args)
+		return err
+	})
+	return result, err
+}
+
+// StopInstances is part of the environs.InstanceBroker interface.
+func (e *auditedEnviron) StopInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
+	return e.observe("StopInstances", func() error {
+		return e.Environ.StopInstances(ctx, ids...)
+	})
+}
+
+// AllInstances is part of the environs.InstanceBroker interface.
+func (e *auditedEnviron) AllInstances(ctx context.ProviderCallContext) (result []instances.Instance, err error) {
+	err = e.observe("AllInstances", func() error {
+		result, err = e.Environ.AllInstances(ctx)
+		return err
+	})
+	return result, err
+}
+
+// AllRunningInstances is part of the environs.InstanceBroker interface.
+func (e *auditedEnviron) AllRunningInstances(ctx context.ProviderCallContext) (result []instances.Instance, err error) {
+	err = e.observe("AllRunningInstances", func() error {
+		result, err = e.Environ.AllRunningInstances(ctx)
+		return err
+	})
+	return result, err
+}
+
+// Instances is part of the environs.Environ interface.
+func (e *auditedEnviron) Instances(ctx context.ProviderCallContext, ids []instance.Id) (result []instances.Instance, err error) {
+	_ = e.observe("Instances", func() error {
+		result, err = e.Environ.Instances(ctx, ids)
+		// ErrPartialInstances is a normal result, not a failed call.
+		if err == environs.ErrPartialInstances {
+			return nil
+		}
+		return err
+	})
+	return result, err
+}
+
+// ControllerInstances is part of the environs.Environ interface.
+func (e *auditedEnviron) ControllerInstances(ctx context.ProviderCallContext, controllerUUID string) (result []instance.Id, err error) {
+	err = e.observe("ControllerInstances", func() error {
+		result, err = e.Environ.ControllerInstances(ctx, controllerUUID)
+		return err
+	})
+	return result, err
+}
+
+// InstanceTypes is part of the environs.InstanceTypesFetcher interface.
+func (e *auditedEnviron) InstanceTypes(
+	ctx context.ProviderCallContext, cons constraints.Value,
+) (result instances.InstanceTypesWithCostMetadata, err error) {
+	err = e.observe("InstanceTypes", func() error {
+		result, err = e.Environ.InstanceTypes(ctx, cons)
+		return err
+	})
+	return result, err
+}
+
+// AdoptResources is part of the environs.Environ interface.
+func (e *auditedEnviron) AdoptResources(ctx context.ProviderCallContext, controllerUUID string, fromVersion version.Number) error {
+	return e.observe("AdoptResources", func() error {
+		return e.Environ.AdoptResources(ctx, controllerUUID, fromVersion)
+	})
+}
+
+// PrecheckInstance is part of the environs.InstancePrechecker interface.
+func (e *auditedEnviron) PrecheckInstance(ctx context.ProviderCallContext, args environs.PrecheckInstanceParams) error {
+	return e.observe("PrecheckInstance", func() error {
+		return e.Environ.PrecheckInstance(ctx, args)
+	})
+}
+
+// WrapFirewaller returns a Firewaller which records the calls made to
+// the given firewaller.
+func WrapFirewaller(fw environs.Firewaller, labels Labels, recorder *Recorder) environs.Firewaller {
+	return &auditedFirewaller{Firewaller: fw, labels: labels, recorder: recorder}
+}
+
+type auditedFirewaller struct {
+	environs.Firewaller
+	labels   Labels
+	recorder *Recorder
+}
+
+// OpenPorts is part of the environs.Firewaller interface.
+func (f *auditedFirewaller) OpenPorts(ctx context.ProviderCallContext, rules []network.IngressRule) error {
+	return f.recorder.Observe(f.labels, "OpenPorts", func() error {
+		return f.Firewaller.OpenPorts(ctx, rules)
+	})
+}
+
+// ClosePorts is part of the environs.Firewaller interface.
+func (f *auditedFirewaller) ClosePorts(ctx context.ProviderCallContext, rules []network.IngressRule) error {
+	return f.recorder.Observe(f.labels, "ClosePorts", func() error {
+		return f.Firewaller.ClosePorts(ctx, rules)
+	})
+}
+
+// IngressRules is part of the environs.Firewaller interface.
+func (f *auditedFirewaller) IngressRules(ctx context.ProviderCallContext) (result []network.IngressRule, err error) {
+	err = f.recorder.Observe(f.labels, "IngressRules", func() error {
+		result, err = f.Firewaller.IngressRules(ctx)
+		return err
+	})
+	return result, err
+}
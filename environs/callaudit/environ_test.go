@@ -0,0 +1,121 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package callaudit_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/callaudit"
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/environs/simplestreams"
+	"github.com/juju/juju/network"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type environSuite struct {
+	testing.IsolationSuite
+	recorder *callaudit.Recorder
+	env      *fakeEnviron
+}
+
+var _ = gc.Suite(&environSuite{})
+
+func (s *environSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.recorder = callaudit.NewRecorder(testclock.NewClock(time.Time{}))
+	s.env = &fakeEnviron{cfg: coretesting.ModelConfig(c)}
+}
+
+func (s *environSuite) TestLabelsFor(c *gc.C) {
+	c.Assert(callaudit.LabelsFor(s.env, "instance-poller"), jc.DeepEquals, callaudit.Labels{
+		Cloud:  "someprovider",
+		Region: "us-east-1",
+		Caller: "instance-poller",
+	})
+}
+
+func (s *environSuite) TestWrapEnviron(c *gc.C) {
+	labels := callaudit.LabelsFor(s.env, "instance-poller")
+	env := callaudit.WrapEnviron(s.env, labels, s.recorder)
+
+	insts, err := env.Instances(context.NewCloudCallContext(), []instance.Id{"i-0", "i-1"})
+	c.Assert(err, gc.Equals, environs.ErrPartialInstances)
+	c.Assert(insts, gc.HasLen, 2)
+
+	s.env.SetErrors(errors.New("RequestLimitExceeded"))
+	err = env.StopInstances(context.NewCloudCallContext(), "i-0")
+	c.Assert(err, gc.ErrorMatches, "RequestLimitExceeded")
+	s.env.CheckCallNames(c, "Instances", "StopInstances")
+
+	stats := s.recorder.Stats()
+	c.Assert(stats, gc.HasLen, 2)
+	c.Check(stats[0].Labels, jc.DeepEquals, labels)
+	c.Check(stats[0].Method, gc.Equals, "Instances")
+	c.Check(stats[0].Calls, gc.Equals, int64(1))
+	c.Check(stats[0].Errors, gc.Equals, int64(0))
+	c.Check(stats[1].Method, gc.Equals, "StopInstances")
+	c.Check(stats[1].Errors, gc.Equals, int64(1))
+	c.Check(stats[1].Throttled, gc.Equals, int64(1))
+}
+
+func (s *environSuite) TestWrapFirewaller(c *gc.C) {
+	labels := callaudit.Labels{Cloud: "someprovider", Caller: "firewaller"}
+	fw := callaudit.WrapFirewaller(s.env, labels, s.recorder)
+
+	err := fw.OpenPorts(context.NewCloudCallContext(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = fw.IngressRules(context.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+	s.env.CheckCallNames(c, "OpenPorts", "IngressRules")
+
+	stats := s.recorder.Stats()
+	c.Assert(stats, gc.HasLen, 2)
+	c.Check(stats[0].Method, gc.Equals, "IngressRules")
+	c.Check(stats[1].Method, gc.Equals, "OpenPorts")
+}
+
+type fakeEnviron struct {
+	environs.Environ
+	environs.Firewaller
+	testing.Stub
+	cfg *config.Config
+}
+
+func (e *fakeEnviron) Config() *config.Config {
+	return e.cfg
+}
+
+func (e *fakeEnviron) Region() (simplestreams.CloudSpec, error) {
+	return simplestreams.CloudSpec{Region: "us-east-1", Endpoint: "https://ec2.us-east-1.amazonaws.com"}, nil
+}
+
+func (e *fakeEnviron) Instances(ctx context.ProviderCallContext, ids []instance.Id) ([]instances.Instance, error) {
+	e.MethodCall(e, "Instances", ctx, ids)
+	return make([]instances.Instance, len(ids)), environs.ErrPartialInstances
+}
+
+func (e *fakeEnviron) StopInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
+	e.MethodCall(e, "StopInstances", ctx, ids)
+	return e.NextErr()
+}
+
+func (e *fakeEnviron) OpenPorts(ctx context.ProviderCallContext, rules []network.IngressRule) error {
+	e.MethodCall(e, "OpenPorts", ctx, rules)
+	return e.NextErr()
+}
+
+func (e *fakeEnviron) IngressRules(ctx context.ProviderCallContext) ([]network.IngressRule, error) {
+	e.MethodCall(e, "IngressRules", ctx)
+	return nil, e.NextErr()
+}
@@ -0,0 +1,90 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package callaudit
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	callLabelNames = []string{"cloud", "region", "caller", "method"}
+
+	providerCallsTotalDesc = prometheus.NewDesc(
+		"juju_provider_calls_total",
+		"Total number of calls made to the cloud provider.",
+		callLabelNames,
+		prometheus.Labels{},
+	)
+	providerCallErrorsTotalDesc = prometheus.NewDesc(
+		"juju_provider_call_errors_total",
+		"Total number of calls to the cloud provider which failed.",
+		callLabelNames,
+		prometheus.Labels{},
+	)
+	providerCallThrottlesTotalDesc = prometheus.NewDesc(
+		"juju_provider_call_throttles_total",
+		"Total number of calls to the cloud provider which were throttled.",
+		callLabelNames,
+		prometheus.Labels{},
+	)
+	providerCallDurationSecondsDesc = prometheus.NewDesc(
+		"juju_provider_call_duration_seconds",
+		"Time spent in calls to the cloud provider.",
+		callLabelNames,
+		prometheus.Labels{},
+	)
+	providerCallMaxDurationSecondsDesc = prometheus.NewDesc(
+		"juju_provider_call_max_duration_seconds",
+		"Time spent in the longest call to the cloud provider.",
+		callLabelNames,
+		prometheus.Labels{},
+	)
+)
+
+// Describe is part of the prometheus.Collector interface.
+func (r *Recorder) Describe(ch chan<- *prometheus.Desc) {
+	ch <- providerCallsTotalDesc
+	ch <- providerCallErrorsTotalDesc
+	ch <- providerCallThrottlesTotalDesc
+	ch <- providerCallDurationSecondsDesc
+	ch <- providerCallMaxDurationSecondsDesc
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (r *Recorder) Collect(ch chan<- prometheus.Metric) {
+	for _, stats := range r.Stats() {
+		labels := []string{stats.Cloud, stats.Region, stats.Caller, stats.Method}
+		ch <- prometheus.MustNewConstMetric(
+			providerCallsTotalDesc,
+			prometheus.CounterValue,
+			float64(stats.Calls),
+			labels...,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			providerCallErrorsTotalDesc,
+			prometheus.CounterValue,
+			float64(stats.Errors),
+			labels...,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			providerCallThrottlesTotalDesc,
+			prometheus.CounterValue,
+			float64(stats.Throttled),
+			labels...,
+		)
+		ch <- prometheus.MustNewConstSummary(
+			providerCallDurationSecondsDesc,
+			uint64(stats.Calls),
+			stats.TotalDuration.Seconds(),
+			nil,
+			labels...,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			providerCallMaxDurationSecondsDesc,
+			prometheus.GaugeValue,
+			stats.MaxDuration.Seconds(),
+			labels...,
+		)
+	}
+}
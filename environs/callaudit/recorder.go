@@ -0,0 +1,178 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package callaudit records the calls made to cloud providers, so that
+// operators can see which workers are responsible for the load on a
+// cloud's API, and which of them are being throttled by it.
+package callaudit
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+)
+
+// DefaultRecorder is the recorder shared by the workers of a controller
+// agent. Its statistics are exported on the agent's metrics endpoint and
+// reported by "juju cloud-calls".
+var DefaultRecorder = NewRecorder(clock.WallClock)
+
+// Labels identifies the source and destination of provider calls.
+type Labels struct {
+	// Cloud is the type of the cloud the calls are made to.
+	Cloud string
+
+	// Region is the cloud region the calls are made to, if any.
+	Region string
+
+	// Caller is the name of the worker making the calls.
+	Caller string
+}
+
+// CallStats holds the statistics for calls of a single provider method.
+type CallStats struct {
+	Labels
+
+	// Method is the name of the provider method called.
+	Method string
+
+	// Calls is the number of calls made.
+	Calls int64
+
+	// Errors is the number of calls which failed, including those
+	// which were throttled.
+	Errors int64
+
+	// Throttled is the number of calls which were rejected by the
+	// cloud's rate limiting.
+	Throttled int64
+
+	// TotalDuration is the time spent in all of the calls.
+	TotalDuration time.Duration
+
+	// MaxDuration is the time spent in the longest call.
+	MaxDuration time.Duration
+
+	// LastThrottled is when a call was last throttled, or the zero
+	// time if none have been.
+	LastThrottled time.Time
+}
+
+type callKey struct {
+	Labels
+	method string
+}
+
+// Recorder accumulates statistics for provider calls. It is safe for
+// concurrent use.
+type Recorder struct {
+	clock clock.Clock
+
+	mu    sync.Mutex
+	stats map[callKey]*CallStats
+}
+
+// NewRecorder returns a new, empty Recorder which uses the given clock
+// to time calls.
+func NewRecorder(clock clock.Clock) *Recorder {
+	return &Recorder{
+		clock: clock,
+		stats: make(map[callKey]*CallStats),
+	}
+}
+
+// Record records a single call of the given method, which took the
+// given time and returned the given error.
+func (r *Recorder) Record(labels Labels, method string, duration time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := callKey{Labels: labels, method: method}
+	stats, ok := r.stats[key]
+	if !ok {
+		stats = &CallStats{Labels: labels, Method: method}
+		r.stats[key] = stats
+	}
+	stats.Calls++
+	stats.TotalDuration += duration
+	if duration > stats.MaxDuration {
+		stats.MaxDuration = duration
+	}
+	if err == nil {
+		return
+	}
+	stats.Errors++
+	if IsThrottled(err) {
+		stats.Throttled++
+		stats.LastThrottled = r.clock.Now()
+	}
+}
+
+// Observe calls f, recording it as a call of the given method.
+func (r *Recorder) Observe(labels Labels, method string, f func() error) error {
+	start := r.clock.Now()
+	err := f()
+	r.Record(labels, method, r.clock.Now().Sub(start), err)
+	return err
+}
+
+// Stats returns the statistics recorded so far, ordered by cloud,
+// region, caller and method.
+func (r *Recorder) Stats() []CallStats {
+	r.mu.Lock()
+	result := make([]CallStats, 0, len(r.stats))
+	for _, stats := range r.stats {
+		result = append(result, *stats)
+	}
+	r.mu.Unlock()
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if a.Cloud != b.Cloud {
+			return a.Cloud < b.Cloud
+		}
+		if a.Region != b.Region {
+			return a.Region < b.Region
+		}
+		if a.Caller != b.Caller {
+			return a.Caller < b.Caller
+		}
+		return a.Method < b.Method
+	})
+	return result
+}
+
+// throttleMarkers are the fragments of error messages which providers
+// use to report that a request was rejected by rate limiting.
+var throttleMarkers = []string{
+	"requestlimitexceeded",
+	"ratelimitexceeded",
+	"rate limit",
+	"throttl",
+	"too many requests",
+	"toomanyrequests",
+	"slowdown",
+}
+
+// IsThrottled reports whether the error returned by a provider call
+// indicates that the call was throttled by the cloud. Errors may say so
+// explicitly by implementing a Throttled() bool method; otherwise the
+// error message is matched against the messages used by the clouds
+// Juju supports.
+func IsThrottled(err error) bool {
+	if err == nil {
+		return false
+	}
+	if t, ok := errors.Cause(err).(interface{ Throttled() bool }); ok {
+		return t.Throttled()
+	}
+	message := strings.ToLower(err.Error())
+	for _, marker := range throttleMarkers {
+		if strings.Contains(message, marker) {
+			return true
+		}
+	}
+	return false
+}
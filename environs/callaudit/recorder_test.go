@@ -0,0 +1,123 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package callaudit_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/environs/callaudit"
+)
+
+type recorderSuite struct {
+	testing.IsolationSuite
+	clock    *testclock.Clock
+	recorder *callaudit.Recorder
+}
+
+var _ = gc.Suite(&recorderSuite{})
+
+var (
+	ec2Labels = callaudit.Labels{Cloud: "ec2", Region: "us-east-1", Caller: "instance-poller"}
+	gceLabels = callaudit.Labels{Cloud: "gce", Region: "us-east1", Caller: "firewaller"}
+)
+
+func (s *recorderSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2020, 3, 2, 10, 0, 0, 0, time.UTC))
+	s.recorder = callaudit.NewRecorder(s.clock)
+}
+
+func (s *recorderSuite) call(labels callaudit.Labels, method string, d time.Duration, err error) error {
+	return s.recorder.Observe(labels, method, func() error {
+		s.clock.Advance(d)
+		return err
+	})
+}
+
+func (s *recorderSuite) TestObserve(c *gc.C) {
+	throttled := errors.New("RequestLimitExceeded: Request limit exceeded.")
+	c.Assert(s.call(ec2Labels, "Instances", time.Second, nil), jc.ErrorIsNil)
+	c.Assert(s.call(ec2Labels, "Instances", 3*time.Second, throttled), gc.Equals, throttled)
+	c.Assert(s.call(ec2Labels, "Instances", 2*time.Second, errors.New("boom")), gc.ErrorMatches, "boom")
+	c.Assert(s.call(gceLabels, "OpenPorts", time.Second, nil), jc.ErrorIsNil)
+	c.Assert(s.call(ec2Labels, "AllInstances", time.Second, nil), jc.ErrorIsNil)
+
+	c.Assert(s.recorder.Stats(), jc.DeepEquals, []callaudit.CallStats{{
+		Labels:        ec2Labels,
+		Method:        "AllInstances",
+		Calls:         1,
+		TotalDuration: time.Second,
+		MaxDuration:   time.Second,
+	}, {
+		Labels:        ec2Labels,
+		Method:        "Instances",
+		Calls:         3,
+		Errors:        2,
+		Throttled:     1,
+		TotalDuration: 6 * time.Second,
+		MaxDuration:   3 * time.Second,
+		LastThrottled: time.Date(2020, 3, 2, 10, 0, 4, 0, time.UTC),
+	}, {
+		Labels:        gceLabels,
+		Method:        "OpenPorts",
+		Calls:         1,
+		TotalDuration: time.Second,
+		MaxDuration:   time.Second,
+	}})
+}
+
+func (s *recorderSuite) TestCollect(c *gc.C) {
+	c.Assert(s.call(ec2Labels, "Instances", time.Second, errors.New("Throttling: Rate exceeded")), gc.NotNil)
+
+	ch := make(chan prometheus.Metric, 10)
+	s.recorder.Collect(ch)
+	close(ch)
+	var names []string
+	for m := range ch {
+		desc := m.Desc().String()
+		c.Check(desc, jc.Contains, `variableLabels: [cloud region caller method]`)
+		names = append(names, desc)
+	}
+	c.Assert(names, gc.HasLen, 5)
+	c.Check(names[0], jc.Contains, `"juju_provider_calls_total"`)
+	c.Check(names[1], jc.Contains, `"juju_provider_call_errors_total"`)
+	c.Check(names[2], jc.Contains, `"juju_provider_call_throttles_total"`)
+	c.Check(names[3], jc.Contains, `"juju_provider_call_duration_seconds"`)
+	c.Check(names[4], jc.Contains, `"juju_provider_call_max_duration_seconds"`)
+}
+
+type throttledError struct {
+	throttled bool
+}
+
+func (e throttledError) Error() string   { return "rate limit" }
+func (e throttledError) Throttled() bool { return e.throttled }
+
+func (s *recorderSuite) TestIsThrottled(c *gc.C) {
+	for i, test := range []struct {
+		err       error
+		throttled bool
+	}{
+		{nil, false},
+		{errors.New("instance not found"), false},
+		{errors.New("RequestLimitExceeded: Request limit exceeded."), true},
+		{errors.New("Throttling: Rate exceeded"), true},
+		{errors.New("googleapi: Error 403: Rate Limit Exceeded, rateLimitExceeded"), true},
+		{errors.New("Code=\"TooManyRequests\""), true},
+		{errors.New("SlowDown: Please reduce your request rate."), true},
+		{errors.Annotate(errors.New("Too Many Requests"), "listing servers"), true},
+		{throttledError{true}, true},
+		{errors.Trace(throttledError{false}), false},
+	} {
+		c.Logf("test %d: %v", i, test.err)
+		c.Check(callaudit.IsThrottled(test.err), gc.Equals, test.throttled)
+	}
+}
@@ -0,0 +1,96 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package config
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// AptSource describes an additional apt repository that machines in a
+// model are configured to use.
+type AptSource struct {
+	// Source is either a PPA, as "ppa:<owner>/<name>", or a line in
+	// sources.list format, such as
+	// "deb http://example.com/ubuntu bionic main".
+	Source string `yaml:"source"`
+
+	// Key is the ASCII-armored public key the repository is signed
+	// with. The keys of PPAs are fetched from Launchpad, so it need
+	// not be given for them.
+	Key string `yaml:"key,omitempty"`
+}
+
+// IsPPA reports whether the source is a PPA.
+func (s AptSource) IsPPA() bool {
+	return strings.HasPrefix(s.Source, "ppa:")
+}
+
+var (
+	validPPA     = regexp.MustCompile(`^ppa:[a-z0-9][a-z0-9+.-]*/[a-z0-9][a-z0-9+.-]*$`)
+	validAptLine = regexp.MustCompile(`^deb(-src)?\s+(\[[^\]]*\]\s+)?[a-z+]+://\S+\s+\S+(\s+\S+)*$`)
+)
+
+const pgpPublicKeyBlock = "-----BEGIN PGP PUBLIC KEY BLOCK-----"
+
+// ParseAptSources parses the yaml list of apt sources held by the
+// apt-sources model config, for instance:
+//
+//	apt-sources: |
+//	  - source: ppa:juju/stable
+//	  - source: deb http://example.com/ubuntu bionic main
+//	    key: |
+//	      -----BEGIN PGP PUBLIC KEY BLOCK-----
+//	      ...
+func ParseAptSources(value string) ([]AptSource, error) {
+	var sources []AptSource
+	if err := yaml.UnmarshalStrict([]byte(value), &sources); err != nil {
+		return nil, errors.Trace(err)
+	}
+	seen := make(map[string]bool)
+	for i, src := range sources {
+		src.Source = strings.TrimSpace(src.Source)
+		switch {
+		case src.Source == "":
+			return nil, errors.NotValidf("empty source")
+		case src.IsPPA():
+			if !validPPA.MatchString(src.Source) {
+				return nil, errors.NotValidf("PPA %q", src.Source)
+			}
+			if src.Key != "" {
+				return nil, errors.Errorf("key given for PPA %q, PPA keys are fetched from Launchpad", src.Source)
+			}
+		case !validAptLine.MatchString(src.Source):
+			return nil, errors.NotValidf("apt source %q", src.Source)
+		case src.Key != "" && !strings.Contains(src.Key, pgpPublicKeyBlock):
+			return nil, errors.Errorf("key for apt source %q is not an ASCII-armored public key", src.Source)
+		}
+		if seen[src.Source] {
+			return nil, errors.Errorf("apt source %q repeated", src.Source)
+		}
+		seen[src.Source] = true
+		sources[i] = src
+	}
+	return sources, nil
+}
+
+// AptSources returns the additional apt repositories that machines in
+// the model are configured to use.
+func (c *Config) AptSources() []AptSource {
+	value, _ := c.defined[AptSourcesKey].(string)
+	// The value is validated when the config is created.
+	sources, _ := ParseAptSources(value)
+	return sources
+}
+
+func validateAptSourcesConfig(cfg *Config) error {
+	value, _ := cfg.defined[AptSourcesKey].(string)
+	if _, err := ParseAptSources(value); err != nil {
+		return errors.Annotate(err, AptSourcesKey)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package config
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+)
+
+// ParseAntiColocation parses a space separated list of anti-colocation
+// groups, each a comma separated list of application names. Units of
+// different applications in a group may not be placed on the same
+// machine. A group of a single application prevents its units from
+// sharing a machine with each other.
+func ParseAntiColocation(value string) ([][]string, error) {
+	var groups [][]string
+	for _, field := range strings.Fields(value) {
+		var group []string
+		seen := make(map[string]bool)
+		for _, app := range strings.Split(field, ",") {
+			if !names.IsValidApplication(app) {
+				return nil, errors.NotValidf("application name %q in anti-colocation group %q", app, field)
+			}
+			if seen[app] {
+				return nil, errors.Errorf("application %q repeated in anti-colocation group %q", app, field)
+			}
+			seen[app] = true
+			group = append(group, app)
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// AntiColocation returns the groups of applications whose units may
+// not be placed on the same machine.
+func (c *Config) AntiColocation() [][]string {
+	value, _ := c.defined[AntiColocationKey].(string)
+	// The value is validated when the config is created.
+	groups, _ := ParseAntiColocation(value)
+	return groups
+}
+
+// MaxUnitsPerMachine returns the maximum number of principal units
+// which may be placed on a machine, or 0 if there is no limit.
+func (c *Config) MaxUnitsPerMachine() int {
+	value, _ := c.defined[MaxUnitsPerMachineKey].(int)
+	return value
+}
+
+func validateColocationConfig(cfg *Config) error {
+	value, _ := cfg.defined[AntiColocationKey].(string)
+	if _, err := ParseAntiColocation(value); err != nil {
+		return errors.Annotate(err, AntiColocationKey)
+	}
+	if max := cfg.MaxUnitsPerMachine(); max < 0 {
+		return errors.NotValidf("negative %s %d", MaxUnitsPerMachineKey, max)
+	}
+	return nil
+}
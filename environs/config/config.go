@@ -6,6 +6,7 @@ package config
 import (
 	"fmt"
 	"net"
+	"net/url"
 	"os"
 	"strings"
 	"time"
@@ -24,6 +25,10 @@ import (
 	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/healthprobe"
+	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/statusalert"
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/logfwd/syslog"
@@ -88,6 +93,39 @@ const (
 	// of OS image metadata for containers.
 	ContainerImageMetadataURLKey = "container-image-metadata-url"
 
+	// CharmMirrorURLKey is the key used to specify the location of an
+	// HTTP mirror from which charm archives and resources are downloaded.
+	CharmMirrorURLKey = "charm-mirror-url"
+
+	// ImageIDsKey is the key used to pin the images from which machines
+	// of a series are started, optionally per region.
+	ImageIDsKey = "image-ids"
+
+	// ImageStreamsKey is the key used to specify the image stream used
+	// for machines of a series, overriding image-stream.
+	ImageStreamsKey = "image-streams"
+
+	// ImagePolicyKey is the key used to specify whether machines may only
+	// be started from the images pinned with image-ids.
+	ImagePolicyKey = "image-policy"
+
+	// AntiColocationKey is the key used to specify the groups of
+	// applications whose units may not be placed on the same machine.
+	AntiColocationKey = "anti-colocation"
+
+	// MaxUnitsPerMachineKey is the key used to limit the number of
+	// principal units which may be placed on a machine.
+	MaxUnitsPerMachineKey = "max-units-per-machine"
+
+	// MaxRelationDataSizeKey is the key used to limit the size of the
+	// data which units may set in a relation data bag.
+	MaxRelationDataSizeKey = "max-relation-data-size"
+
+	// MaxCharmDeploymentsKey is the key used to limit the number of units
+	// of an application which may be installing or upgrading their charm
+	// at once.
+	MaxCharmDeploymentsKey = "max-charm-deployments"
+
 	// Proxy behaviour has become something of an annoying thing to define
 	// well. These following four proxy variables are being kept to continue
 	// with the existing behaviour for those deployments that specify them.
@@ -142,6 +180,15 @@ const (
 	// AptNoProxyKey stores the key for this setting.
 	AptNoProxyKey = "apt-no-proxy"
 
+	// AptSourcesKey is the key for the additional apt repositories,
+	// PPAs or sources.list lines, that machines in the model use.
+	AptSourcesKey = "apt-sources"
+
+	// ContainerRuntimeProxyKey is the key used to specify whether
+	// machine agents pass the model's proxy settings on to the
+	// container runtimes, such as docker, installed on their machines.
+	ContainerRuntimeProxyKey = "container-runtime-proxy"
+
 	// SnapHTTPProxyKey is used to set the snap core setting proxy.http for deployed machines.
 	SnapHTTPProxyKey = "snap-http-proxy"
 	// SnapHTTPSProxyKey is used to set the snap core setting proxy.https for deployed machines.
@@ -162,6 +209,16 @@ const (
 	// networking method for containers.
 	ContainerNetworkingMethod = "container-networking-method"
 
+	// AddressConflictPolicyKey is the key used to specify how the
+	// addresses reported for a machine by the provider are reconciled
+	// with those reported by the machine itself.
+	AddressConflictPolicyKey = "address-conflict-policy"
+
+	// ReportSecurityUpdatesKey is the key used to specify whether
+	// machine agents report the security updates pending on their
+	// machines, and whether the machines need rebooting.
+	ReportSecurityUpdatesKey = "report-security-updates"
+
 	// The default block storage source.
 	StorageDefaultBlockSourceKey = "storage-default-block-source"
 
@@ -210,6 +267,22 @@ const (
 	// collection can grow to before it is pruned, eg "5M"
 	MaxStatusHistorySize = "max-status-history-size"
 
+	// MaxStatusHistoryEntries is the number of status history values
+	// to keep for each entity when pruning, or 0 for no limit.
+	MaxStatusHistoryEntries = "max-status-history-entries"
+
+	// StatusHistoryRetention is a YAML map of entity kinds, such as
+	// "machine" or "workload", to the maximum age and number of
+	// status history values to keep for each entity of the kind,
+	// replacing the model-wide limits.
+	StatusHistoryRetention = "status-history-retention"
+
+	// CompactStatusHistory determines whether the status history
+	// pruner collapses runs of identical status history values of an
+	// entity into one, which records when the status was first and
+	// last set and how many times.
+	CompactStatusHistory = "compact-status-history"
+
 	// MaxActionResultsAge is the maximum age of actions to keep when pruning, eg
 	// "72h"
 	MaxActionResultsAge = "max-action-results-age"
@@ -218,9 +291,54 @@ const (
 	// grow to before it is pruned, eg "5M"
 	MaxActionResultsSize = "max-action-results-size"
 
+	// MaxOperationsAge is the maximum age of the entries in the model's
+	// operations log to keep when pruning, eg "720h"
+	MaxOperationsAge = "max-operations-age"
+
 	// UpdateStatusHookInterval is how often to run the update-status hook.
 	UpdateStatusHookInterval = "update-status-hook-interval"
 
+	// DeadAgentThreshold is how long a unit agent may go without
+	// communicating with the controller before the unit is marked as
+	// having a gone agent, eg "24h". A value of 0 disables the marking.
+	DeadAgentThreshold = "dead-agent-threshold"
+
+	// RelationDrainTimeout is how long the units of a removed relation
+	// are given to run their departure hooks before they are forced out
+	// of the relation, eg "10m". A value of 0 leaves the units to depart
+	// in their own time, unless the relation is removed with force.
+	RelationDrainTimeout = "relation-drain-timeout"
+
+	// CrossModelContactTimeout is how long a model consuming an offer
+	// hosted in this model may go without contacting it before the
+	// relation is reported as unhealthy, eg "24h". A value of 0 disables
+	// the check.
+	CrossModelContactTimeout = "cross-model-contact-timeout"
+
+	// StatusAlertRules is a YAML list of rules describing the entity
+	// statuses which raise alerts, eg any unit in error for 5 minutes.
+	StatusAlertRules = "status-alert-rules"
+
+	// StatusAlertWebhook is the http or https URL to which alerts raised
+	// by the status alert rules are posted.
+	StatusAlertWebhook = "status-alert-webhook"
+
+	// HealthProbes is a YAML map of application names to the health
+	// probes, such as TCP or HTTP checks, which machine agents run
+	// for the units of the applications.
+	HealthProbes = "health-probes"
+
+	// BundleSourceKey is the key used to specify the bundle which the
+	// model is reconciled with: "controller" for the latest bundle
+	// stored on the controller for the model, or the http or https URL
+	// of a bundle.
+	BundleSourceKey = "bundle-source"
+
+	// BundleReconcileModeKey is the key used to specify whether changes
+	// needed to reconcile the model with its bundle source are applied
+	// once approved ("manual") or as soon as they are seen ("auto").
+	BundleReconcileModeKey = "bundle-reconcile-mode"
+
 	// EgressSubnets are the source addresses from which traffic from this model
 	// originates if the model is deployed such that NAT or similar is in use.
 	EgressSubnets = "egress-subnets"
@@ -287,6 +405,20 @@ const (
 	HarvestAll HarvestMode = HarvestUnknown | HarvestDestroyed
 )
 
+const (
+	// BundleSourceController is the bundle source referring to the
+	// latest bundle stored on the controller for the model.
+	BundleSourceController = "controller"
+
+	// BundleReconcileManual applies the changes needed to reconcile
+	// the model with its bundle source once they have been approved.
+	BundleReconcileManual = "manual"
+
+	// BundleReconcileAuto applies the changes needed to reconcile the
+	// model with its bundle source as soon as they are seen.
+	BundleReconcileAuto = "auto"
+)
+
 // A mapping from method to description. Going this way will be the
 // more common operation, so we want this type of lookup to be O(1).
 var harvestingMethodToFlag = map[HarvestMode]string{
@@ -409,12 +541,29 @@ const (
 	// DefaultStatusHistorySize is the default value for MaxStatusHistorySize.
 	DefaultStatusHistorySize = "5G"
 
+	// DefaultMaxRelationDataSize is the default value for
+	// MaxRelationDataSizeKey, in bytes.
+	DefaultMaxRelationDataSize = 1024 * 1024
+
 	// DefaultUpdateStatusHookInterval is the default value for UpdateStatusHookInterval
 	DefaultUpdateStatusHookInterval = "5m"
 
+	// DefaultDeadAgentThreshold is the default value for DeadAgentThreshold.
+	DefaultDeadAgentThreshold = "1h"
+
+	// DefaultRelationDrainTimeout is the default value for
+	// RelationDrainTimeout.
+	DefaultRelationDrainTimeout = "0s"
+
+	// DefaultCrossModelContactTimeout is the default value for
+	// CrossModelContactTimeout.
+	DefaultCrossModelContactTimeout = "0s"
+
 	DefaultActionResultsAge = "336h" // 2 weeks
 
 	DefaultActionResultsSize = "5G"
+
+	DefaultOperationsAge = "720h" // 30 days
 )
 
 var defaultConfigValues = map[string]interface{}{
@@ -444,6 +593,8 @@ var defaultConfigValues = map[string]interface{}{
 	// $ juju model-config net-bond-reconfigure-delay=30
 	NetBondReconfigureDelayKey: 17,
 	ContainerNetworkingMethod:  "",
+	AddressConflictPolicyKey:   string(corenetwork.ProviderWins),
+	ReportSecurityUpdatesKey:   false,
 
 	"default-series":              series.DefaultSupportedLTS(),
 	ProvisionerHarvestModeKey:     HarvestDestroyed.String(),
@@ -456,6 +607,14 @@ var defaultConfigValues = map[string]interface{}{
 	"test-mode":                   false,
 	TransmitVendorMetricsKey:      true,
 	UpdateStatusHookInterval:      DefaultUpdateStatusHookInterval,
+	DeadAgentThreshold:            DefaultDeadAgentThreshold,
+	RelationDrainTimeout:          DefaultRelationDrainTimeout,
+	CrossModelContactTimeout:      DefaultCrossModelContactTimeout,
+	StatusAlertRules:              "",
+	StatusAlertWebhook:            "",
+	HealthProbes:                  "",
+	BundleSourceKey:               "",
+	BundleReconcileModeKey:        BundleReconcileManual,
 	EgressSubnets:                 "",
 	FanConfig:                     "",
 	CloudInitUserDataKey:          "",
@@ -469,6 +628,20 @@ var defaultConfigValues = map[string]interface{}{
 	AgentMetadataURLKey:          "",
 	ContainerImageStreamKey:      "released",
 	ContainerImageMetadataURLKey: "",
+	CharmMirrorURLKey:            "",
+	ImageIDsKey:                  "",
+	ImageStreamsKey:              "",
+	ImagePolicyKey:               ImagePolicyAny,
+
+	// Workload placement settings.
+	AntiColocationKey:     "",
+	MaxUnitsPerMachineKey: 0,
+
+	// Charm deployment settings.
+	MaxCharmDeploymentsKey: 0,
+
+	// Relation settings.
+	MaxRelationDataSizeKey: DefaultMaxRelationDataSize,
 
 	// Log forward settings.
 	LogForwardEnabled: false,
@@ -488,6 +661,9 @@ var defaultConfigValues = map[string]interface{}{
 	AptFTPProxyKey:   "",
 	AptNoProxyKey:    "",
 	"apt-mirror":     "",
+	AptSourcesKey:    "",
+
+	ContainerRuntimeProxyKey: false,
 
 	SnapHTTPProxyKey:       "",
 	SnapHTTPSProxyKey:      "",
@@ -496,10 +672,14 @@ var defaultConfigValues = map[string]interface{}{
 	SnapStoreProxyURLKey:   "",
 
 	// Status history settings
-	MaxStatusHistoryAge:  DefaultStatusHistoryAge,
-	MaxStatusHistorySize: DefaultStatusHistorySize,
-	MaxActionResultsAge:  DefaultActionResultsAge,
-	MaxActionResultsSize: DefaultActionResultsSize,
+	MaxStatusHistoryAge:     DefaultStatusHistoryAge,
+	MaxStatusHistorySize:    DefaultStatusHistorySize,
+	MaxStatusHistoryEntries: 0,
+	StatusHistoryRetention:  "",
+	CompactStatusHistory:    false,
+	MaxActionResultsAge:     DefaultActionResultsAge,
+	MaxActionResultsSize:    DefaultActionResultsSize,
+	MaxOperationsAge:        DefaultOperationsAge,
 }
 
 // ConfigDefaults returns the config default values
@@ -630,6 +810,16 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[MaxStatusHistoryEntries].(int); ok && v < 0 {
+		return errors.NotValidf("negative %s %d", MaxStatusHistoryEntries, v)
+	}
+
+	if v, ok := cfg.defined[StatusHistoryRetention].(string); ok {
+		if _, err := status.ParseHistoryRetention(v); err != nil {
+			return errors.Annotate(err, "invalid status history retention in model configuration")
+		}
+	}
+
 	if v, ok := cfg.defined[MaxActionResultsAge].(string); ok {
 		if _, err := time.ParseDuration(v); err != nil {
 			return errors.Annotate(err, "invalid max action age in model configuration")
@@ -642,6 +832,14 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[MaxOperationsAge].(string); ok {
+		if d, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid max operations age in model configuration")
+		} else if d <= 0 {
+			return errors.Errorf("max operations age %v must be positive", d)
+		}
+	}
+
 	if v, ok := cfg.defined[UpdateStatusHookInterval].(string); ok {
 		if f, err := time.ParseDuration(v); err != nil {
 			return errors.Annotate(err, "invalid update status hook interval in model configuration")
@@ -655,6 +853,62 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[DeadAgentThreshold].(string); ok {
+		if d, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid dead agent threshold in model configuration")
+		} else if d < 0 {
+			return errors.Errorf("dead agent threshold %v cannot be negative", d)
+		}
+	}
+
+	if v, ok := cfg.defined[RelationDrainTimeout].(string); ok {
+		if d, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid relation drain timeout in model configuration")
+		} else if d < 0 {
+			return errors.Errorf("relation drain timeout %v cannot be negative", d)
+		}
+	}
+
+	if v, ok := cfg.defined[CrossModelContactTimeout].(string); ok {
+		if d, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid cross model contact timeout in model configuration")
+		} else if d < 0 {
+			return errors.Errorf("cross model contact timeout %v cannot be negative", d)
+		}
+	}
+
+	if v, ok := cfg.defined[StatusAlertRules].(string); ok {
+		if _, err := statusalert.ParseRules(v); err != nil {
+			return errors.Annotate(err, "invalid status alert rules in model configuration")
+		}
+	}
+
+	if v, ok := cfg.defined[HealthProbes].(string); ok {
+		if _, err := healthprobe.ParseProbes(v); err != nil {
+			return errors.Annotate(err, "invalid health probes in model configuration")
+		}
+	}
+
+	if v, ok := cfg.defined[StatusAlertWebhook].(string); ok && v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid status alert webhook")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+			return errors.Errorf("status alert webhook %q must be an absolute http or https URL", v)
+		}
+	}
+
+	if v, ok := cfg.defined[BundleSourceKey].(string); ok && v != "" && v != BundleSourceController {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid bundle source")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+			return errors.Errorf("bundle source %q must be %q or an absolute http or https URL", v, BundleSourceController)
+		}
+	}
+
 	if v, ok := cfg.defined[EgressSubnets].(string); ok && v != "" {
 		cidrs := strings.Split(v, ",")
 		for _, cidr := range cidrs {
@@ -734,6 +988,36 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[CharmMirrorURLKey].(string); ok && v != "" {
+		u, err := url.Parse(v)
+		if err != nil {
+			return errors.Annotate(err, "invalid charm mirror URL")
+		}
+		if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+			return errors.Errorf("charm mirror URL %q must be an absolute http or https URL", v)
+		}
+	}
+
+	if err := validateImageConfig(cfg); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := validateColocationConfig(cfg); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := validateAptSourcesConfig(cfg); err != nil {
+		return errors.Trace(err)
+	}
+
+	if v, ok := cfg.defined[MaxRelationDataSizeKey].(int); ok && v < 0 {
+		return errors.NotValidf("negative %s %d", MaxRelationDataSizeKey, v)
+	}
+
+	if v := cfg.MaxCharmDeployments(); v < 0 {
+		return errors.NotValidf("negative %s %d", MaxCharmDeploymentsKey, v)
+	}
+
 	if err := cfg.validateDefaultSpace(); err != nil {
 		return err
 	}
@@ -896,6 +1180,31 @@ func (c *Config) ContainerNetworkingMethod() string {
 	return c.asString(ContainerNetworkingMethod)
 }
 
+// AddressConflictPolicy returns how the addresses reported for a
+// machine by the provider are reconciled with those reported by the
+// machine itself when choosing its preferred addresses.
+func (c *Config) AddressConflictPolicy() corenetwork.AddressConflictPolicy {
+	if policy := c.asString(AddressConflictPolicyKey); policy != "" {
+		return corenetwork.AddressConflictPolicy(policy)
+	}
+	return corenetwork.ProviderWins
+}
+
+// ReportSecurityUpdates returns whether machine agents report the
+// security updates pending on their machines. By default they don't.
+func (c *Config) ReportSecurityUpdates() bool {
+	val, _ := c.defined[ReportSecurityUpdatesKey].(bool)
+	return val
+}
+
+// ContainerRuntimeProxy returns whether machine agents pass the model's
+// proxy settings on to the container runtimes on their machines. By
+// default they don't.
+func (c *Config) ContainerRuntimeProxy() bool {
+	val, _ := c.defined[ContainerRuntimeProxyKey].(bool)
+	return val
+}
+
 // LegacyProxySettings returns all four proxy settings; http, https, ftp, and no
 // proxy. These are considered legacy as using these values will cause the environment
 // to be updated, which has shown to not work in many cases. It is being kept to avoid
@@ -1160,6 +1469,15 @@ func (c *Config) ContainerImageMetadataURL() (string, bool) {
 	return "", false
 }
 
+// CharmMirrorURL returns the URL of the mirror from which charm archives
+// and resources are downloaded, and whether it has been set.
+func (c *Config) CharmMirrorURL() (string, bool) {
+	if url, ok := c.defined[CharmMirrorURLKey]; ok && url != "" {
+		return url.(string), true
+	}
+	return "", false
+}
+
 // Development returns whether the environment is in development mode.
 func (c *Config) Development() bool {
 	value, _ := c.defined["development"].(bool)
@@ -1340,6 +1658,23 @@ func (c *Config) MaxStatusHistoryAge() time.Duration {
 	return val
 }
 
+// MaxRelationDataSize returns the maximum size in bytes of the data which
+// a unit may set in a relation data bag, or 0 if there is no limit.
+func (c *Config) MaxRelationDataSize() int {
+	if value, ok := c.defined[MaxRelationDataSizeKey].(int); ok {
+		return value
+	}
+	return DefaultMaxRelationDataSize
+}
+
+// MaxCharmDeployments returns the maximum number of units of an
+// application which may be installing or upgrading their charm at once,
+// or 0 if there is no limit.
+func (c *Config) MaxCharmDeployments() int {
+	value, _ := c.defined[MaxCharmDeploymentsKey].(int)
+	return value
+}
+
 // MaxStatusHistorySizeMB is the maximum size in MiB which the status history
 // collection can grow to before being pruned.
 func (c *Config) MaxStatusHistorySizeMB() uint {
@@ -1348,6 +1683,28 @@ func (c *Config) MaxStatusHistorySizeMB() uint {
 	return uint(val)
 }
 
+// MaxStatusHistoryEntries is the number of status history entries kept
+// for each entity when pruning, or 0 if there is no limit.
+func (c *Config) MaxStatusHistoryEntries() int {
+	value, _ := c.defined[MaxStatusHistoryEntries].(int)
+	return value
+}
+
+// StatusHistoryRetention returns the status history kept for each
+// entity of the kinds which have their own limits when pruning.
+func (c *Config) StatusHistoryRetention() map[status.RetentionKind]status.HistoryRetention {
+	// Value has already been validated.
+	retention, _ := status.ParseHistoryRetention(c.asString(StatusHistoryRetention))
+	return retention
+}
+
+// CompactStatusHistory returns whether runs of identical status history
+// values are collapsed into one when pruning.
+func (c *Config) CompactStatusHistory() bool {
+	value, _ := c.defined[CompactStatusHistory].(bool)
+	return value
+}
+
 func (c *Config) MaxActionResultsAge() time.Duration {
 	// Value has already been validated.
 	val, _ := time.ParseDuration(c.mustString(MaxActionResultsAge))
@@ -1360,6 +1717,18 @@ func (c *Config) MaxActionResultsSizeMB() uint {
 	return uint(val)
 }
 
+// MaxOperationsAge is the maximum age of the entries in the model's
+// operations log to keep when pruning.
+func (c *Config) MaxOperationsAge() time.Duration {
+	raw := c.asString(MaxOperationsAge)
+	if raw == "" {
+		raw = DefaultOperationsAge
+	}
+	// Value has already been validated.
+	val, _ := time.ParseDuration(raw)
+	return val
+}
+
 // UpdateStatusHookInterval is how often to run the charm
 // update-status hook.
 func (c *Config) UpdateStatusHookInterval() time.Duration {
@@ -1376,6 +1745,93 @@ func (c *Config) UpdateStatusHookInterval() time.Duration {
 	return val
 }
 
+// DeadAgentThreshold is how long a unit agent may go without
+// communicating with the controller before the unit is marked as having
+// a gone agent. Zero means units are never marked.
+func (c *Config) DeadAgentThreshold() time.Duration {
+	// Guard against config from controllers which predate the
+	// setting, as UpdateStatusHookInterval does.
+	raw := c.asString(DeadAgentThreshold)
+	if raw == "" {
+		raw = DefaultDeadAgentThreshold
+	}
+	// Value has already been validated.
+	val, _ := time.ParseDuration(raw)
+	return val
+}
+
+// RelationDrainTimeout is how long the units of a removed relation are
+// given to run their departure hooks before they are forced out of the
+// relation. Zero means units are not forced out.
+func (c *Config) RelationDrainTimeout() time.Duration {
+	raw := c.asString(RelationDrainTimeout)
+	if raw == "" {
+		raw = DefaultRelationDrainTimeout
+	}
+	// Value has already been validated.
+	val, _ := time.ParseDuration(raw)
+	return val
+}
+
+// CrossModelContactTimeout is how long a model consuming an offer
+// hosted in this model may go without contacting it before the relation
+// is reported as unhealthy. Zero means contact is not checked.
+func (c *Config) CrossModelContactTimeout() time.Duration {
+	raw := c.asString(CrossModelContactTimeout)
+	if raw == "" {
+		raw = DefaultCrossModelContactTimeout
+	}
+	// Value has already been validated.
+	val, _ := time.ParseDuration(raw)
+	return val
+}
+
+// StatusAlertRules returns the rules describing the entity statuses
+// which raise alerts.
+func (c *Config) StatusAlertRules() []statusalert.Rule {
+	// Value has already been validated.
+	rules, _ := statusalert.ParseRules(c.asString(StatusAlertRules))
+	return rules
+}
+
+// HealthProbes returns the health probes run for the units of each
+// application, keyed by application name.
+func (c *Config) HealthProbes() map[string][]healthprobe.Probe {
+	// Value has already been validated.
+	probes, _ := healthprobe.ParseProbes(c.asString(HealthProbes))
+	return probes
+}
+
+// StatusAlertWebhook returns the URL to which alerts raised by the
+// status alert rules are posted, and whether it has been set.
+func (c *Config) StatusAlertWebhook() (string, bool) {
+	if url := c.asString(StatusAlertWebhook); url != "" {
+		return url, true
+	}
+	return "", false
+}
+
+// BundleSource returns the source of the bundle which the model is
+// reconciled with, and whether it has been set. The source is either
+// BundleSourceController or the URL of a bundle.
+func (c *Config) BundleSource() (string, bool) {
+	if source := c.asString(BundleSourceKey); source != "" {
+		return source, true
+	}
+	return "", false
+}
+
+// BundleReconcileMode returns whether the changes needed to reconcile
+// the model with its bundle source are applied once approved
+// (BundleReconcileManual) or as soon as they are seen
+// (BundleReconcileAuto).
+func (c *Config) BundleReconcileMode() string {
+	if mode := c.asString(BundleReconcileModeKey); mode != "" {
+		return mode
+	}
+	return BundleReconcileManual
+}
+
 // EgressSubnets are the source addresses from which traffic from this model
 // originates if the model is deployed such that NAT or similar is in use.
 func (c *Config) EgressSubnets() []string {
@@ -1513,6 +1969,8 @@ var alwaysOptional = schema.Defaults{
 	SnapStoreAssertionsKey:        schema.Omit,
 	SnapStoreProxyURLKey:          schema.Omit,
 	"apt-mirror":                  schema.Omit,
+	AptSourcesKey:                 schema.Omit,
+	ContainerRuntimeProxyKey:      schema.Omit,
 	AgentStreamKey:                schema.Omit,
 	ResourceTagsKey:               schema.Omit,
 	"cloudimg-base-url":           schema.Omit,
@@ -1523,6 +1981,14 @@ var alwaysOptional = schema.Defaults{
 	AgentMetadataURLKey:           schema.Omit,
 	ContainerImageStreamKey:       schema.Omit,
 	ContainerImageMetadataURLKey:  schema.Omit,
+	CharmMirrorURLKey:             schema.Omit,
+	ImageIDsKey:                   schema.Omit,
+	ImageStreamsKey:               schema.Omit,
+	ImagePolicyKey:                schema.Omit,
+	AntiColocationKey:             schema.Omit,
+	MaxUnitsPerMachineKey:         schema.Omit,
+	MaxRelationDataSizeKey:        schema.Omit,
+	MaxCharmDeploymentsKey:        schema.Omit,
 	"default-series":              schema.Omit,
 	"development":                 schema.Omit,
 	"ssl-hostname-verification":   schema.Omit,
@@ -1534,11 +2000,25 @@ var alwaysOptional = schema.Defaults{
 	TransmitVendorMetricsKey:      schema.Omit,
 	NetBondReconfigureDelayKey:    schema.Omit,
 	ContainerNetworkingMethod:     schema.Omit,
+	AddressConflictPolicyKey:      schema.Omit,
+	ReportSecurityUpdatesKey:      schema.Omit,
 	MaxStatusHistoryAge:           schema.Omit,
 	MaxStatusHistorySize:          schema.Omit,
+	MaxStatusHistoryEntries:       schema.Omit,
+	StatusHistoryRetention:        schema.Omit,
+	CompactStatusHistory:          schema.Omit,
 	MaxActionResultsAge:           schema.Omit,
 	MaxActionResultsSize:          schema.Omit,
+	MaxOperationsAge:              schema.Omit,
 	UpdateStatusHookInterval:      schema.Omit,
+	DeadAgentThreshold:            schema.Omit,
+	RelationDrainTimeout:          schema.Omit,
+	CrossModelContactTimeout:      schema.Omit,
+	StatusAlertRules:              schema.Omit,
+	StatusAlertWebhook:            schema.Omit,
+	HealthProbes:                  schema.Omit,
+	BundleSourceKey:               schema.Omit,
+	BundleReconcileModeKey:        schema.Omit,
 	EgressSubnets:                 schema.Omit,
 	FanConfig:                     schema.Omit,
 	CloudInitUserDataKey:          schema.Omit,
@@ -1751,6 +2231,16 @@ var configSchema = environschema.Fields{
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	AptSourcesKey: {
+		Description: "Additional APT repositories for machines in the model (in yaml format), a list of PPAs or sources.list lines with optional signing keys",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	ContainerRuntimeProxyKey: {
+		Description: "Determines whether machine agents configure the container runtimes installed on their machines, such as docker and containerd, to use the model's proxy settings",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
 	AuthorizedKeysKey: {
 		Description: "Any authorized SSH public keys for the model, as found in a ~/.ssh/authorized_keys file",
 		Type:        environschema.Tstring,
@@ -1893,6 +2383,47 @@ global or per instance security groups.`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	CharmMirrorURLKey: {
+		Description: `The URL of an HTTP mirror of the charm store from which charm archives and resources are downloaded, instead of downloading them from the charm store directly. The mirror serves a copy of the charm store metadata along with the content, and content is verified against the checksums in that metadata.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	ImageIDsKey: {
+		Description: `A space separated list of images from which machines are started, each of the form <series>[/<region>]=<image id>. An image pinned for a region takes precedence over one pinned for all regions of the series.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	ImageStreamsKey: {
+		Description: `A space separated list of simplestreams streams used to identify which image ids to search when starting an instance of a series, each of the form <series>=<stream>. Series not listed use image-stream.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	ImagePolicyKey: {
+		Description: `Whether machines may be started from any image ("any"), or only from the images pinned with image-ids ("pinned").`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{ImagePolicyAny, ImagePolicyPinned},
+		Group:       environschema.EnvironGroup,
+	},
+	AntiColocationKey: {
+		Description: `A space separated list of groups of applications whose units may not be placed on the same machine, each a comma separated list of application names, e.g. "mysql,wordpress haproxy". A group of a single application keeps its units on separate machines.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxUnitsPerMachineKey: {
+		Description: `The maximum number of principal units which may be placed on a machine. 0 means there is no limit.`,
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxCharmDeploymentsKey: {
+		Description: `The maximum number of units of an application which may be installing or upgrading their charm at once. Other units wait until one of them has finished, so that a charm upgrade rolls through the application. 0 means there is no limit.`,
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxRelationDataSizeKey: {
+		Description: `The maximum size in bytes of the data which a unit may set in a relation data bag, for itself or for its application. 0 means there is no limit.`,
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
 	"logging-config": {
 		Description: `The configuration string to use when configuring Juju agent logging (see http://godoc.org/github.com/juju/loggo#ParseConfigurationString for details)`,
 		Type:        environschema.Tstring,
@@ -2003,6 +2534,21 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	AddressConflictPolicyKey: {
+		Description: `How the addresses reported for a machine by the provider are reconciled with those reported by the machine itself when choosing its preferred public and private addresses: "provider-wins" prefers the provider's addresses, "machiner-wins" prefers the machine's, and "merge-with-scope-priority" picks the address with the best scope from either.`,
+		Type:        environschema.Tstring,
+		Values: []interface{}{
+			string(corenetwork.ProviderWins),
+			string(corenetwork.MachinerWins),
+			string(corenetwork.MergeWithScopePriority),
+		},
+		Group: environschema.EnvironGroup,
+	},
+	ReportSecurityUpdatesKey: {
+		Description: "Determines whether machine agents report the security updates pending on their machines, and whether the machines need rebooting, in the machines' status",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
 	MaxStatusHistoryAge: {
 		Description: "The maximum age for status history entries before they are pruned, in human-readable time format",
 		Type:        environschema.Tstring,
@@ -2013,6 +2559,21 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	MaxStatusHistoryEntries: {
+		Description: "The maximum number of status history entries kept for each entity when pruning. 0 means there is no limit.",
+		Type:        environschema.Tint,
+		Group:       environschema.EnvironGroup,
+	},
+	StatusHistoryRetention: {
+		Description: `A YAML map of entity kinds (machine, unit-agent, workload, application, filesystem or volume) to the status history kept for each entity of the kind, as a "max-age" and/or "max-entries", replacing the model-wide limits`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	CompactStatusHistory: {
+		Description: "Determines whether the status history pruner collapses runs of identical status history entries of an entity into one, recording when the status was first and last set and how many times",
+		Type:        environschema.Tbool,
+		Group:       environschema.EnvironGroup,
+	},
 	MaxActionResultsAge: {
 		Description: "The maximum age for action entries before they are pruned, in human-readable time format",
 		Type:        environschema.Tstring,
@@ -2023,11 +2584,57 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	MaxOperationsAge: {
+		Description: "The maximum age for operations log entries before they are pruned, in human-readable time format",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	UpdateStatusHookInterval: {
 		Description: "How often to run the charm update-status hook, in human-readable time format (default 5m, range 1-60m)",
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	DeadAgentThreshold: {
+		Description: "How long a unit agent may go without communicating with the controller before the unit is marked as having a gone agent, in human-readable time format (0 disables)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	RelationDrainTimeout: {
+		Description: "How long the units of a removed relation are given to run their departure hooks before they are forced out of the relation, in human-readable time format (0 disables)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	CrossModelContactTimeout: {
+		Description: "How long a model consuming an offer hosted in this model may go without contacting it before the relation is reported as unhealthy, in human-readable time format (0 disables)",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	StatusAlertRules: {
+		Description: `A YAML list of rules raising alerts when entities are in a status, or units' health probes are failing, each with a name, an entity kind (unit, machine or application), a status or the name of a probe, an optional duration ("for") and an optional list of applications`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	HealthProbes: {
+		Description: `A YAML map of application names to lists of health probes run by machine agents for the applications' units, each with a name, one of a "tcp" address, an "http" URL or a "command", and an optional interval and timeout`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	StatusAlertWebhook: {
+		Description: "The http or https URL to which alerts raised by the status alert rules are posted",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	BundleSourceKey: {
+		Description: `The bundle which the model is reconciled with: "controller" for the latest bundle stored on the controller with set-model-bundle, or the http or https URL of a bundle, such as the raw URL of a bundle file in a git repository. Empty disables reconciliation.`,
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	BundleReconcileModeKey: {
+		Description: `Whether the changes needed to reconcile the model with its bundle source are applied once approved with approve-model-plan ("manual"), or as soon as they are seen ("auto").`,
+		Type:        environschema.Tstring,
+		Values:      []interface{}{BundleReconcileManual, BundleReconcileAuto},
+		Group:       environschema.EnvironGroup,
+	},
 	EgressSubnets: {
 		Description: "Source address(es) for traffic originating from this model",
 		Type:        environschema.Tstring,
@@ -20,6 +20,10 @@ import (
 	"gopkg.in/juju/charmrepo.v3"
 	"gopkg.in/juju/environschema.v1"
 
+	"github.com/juju/juju/core/healthprobe"
+	corenetwork "github.com/juju/juju/core/network"
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/statusalert"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/juju/osenv"
 	"github.com/juju/juju/testing"
@@ -1327,6 +1331,74 @@ func (s *ConfigSuite) TestUpdateStatusHookIntervalConfigValue(c *gc.C) {
 	c.Assert(cfg.UpdateStatusHookInterval(), gc.Equals, 30*time.Minute)
 }
 
+func (s *ConfigSuite) TestDeadAgentThresholdDefault(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.DeadAgentThreshold(), gc.Equals, time.Hour)
+}
+
+func (s *ConfigSuite) TestDeadAgentThresholdValue(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		config.DeadAgentThreshold: "24h",
+	})
+	c.Assert(cfg.DeadAgentThreshold(), gc.Equals, 24*time.Hour)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.DeadAgentThreshold: "0",
+	})
+	c.Assert(cfg.DeadAgentThreshold(), gc.Equals, time.Duration(0))
+}
+
+func (s *ConfigSuite) TestDeadAgentThresholdInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, minimalConfigAttrs.Merge(testing.Attrs{
+		config.DeadAgentThreshold: "soon",
+	}))
+	c.Assert(err, gc.ErrorMatches, `invalid dead agent threshold in model configuration: time: invalid duration "?soon"?`)
+
+	_, err = config.New(config.UseDefaults, minimalConfigAttrs.Merge(testing.Attrs{
+		config.DeadAgentThreshold: "-1h",
+	}))
+	c.Assert(err, gc.ErrorMatches, `dead agent threshold -1h0m0s cannot be negative`)
+}
+
+func (s *ConfigSuite) TestRelationDrainTimeout(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.RelationDrainTimeout(), gc.Equals, time.Duration(0))
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.RelationDrainTimeout: "10m",
+	})
+	c.Assert(cfg.RelationDrainTimeout(), gc.Equals, 10*time.Minute)
+}
+
+func (s *ConfigSuite) TestRelationDrainTimeoutInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, minimalConfigAttrs.Merge(testing.Attrs{
+		config.RelationDrainTimeout: "soon",
+	}))
+	c.Assert(err, gc.ErrorMatches, `invalid relation drain timeout in model configuration: time: invalid duration "?soon"?`)
+
+	_, err = config.New(config.UseDefaults, minimalConfigAttrs.Merge(testing.Attrs{
+		config.RelationDrainTimeout: "-1m",
+	}))
+	c.Assert(err, gc.ErrorMatches, `relation drain timeout -1m0s cannot be negative`)
+}
+
+func (s *ConfigSuite) TestCrossModelContactTimeout(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.CrossModelContactTimeout(), gc.Equals, time.Duration(0))
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.CrossModelContactTimeout: "24h",
+	})
+	c.Assert(cfg.CrossModelContactTimeout(), gc.Equals, 24*time.Hour)
+}
+
+func (s *ConfigSuite) TestCrossModelContactTimeoutInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, minimalConfigAttrs.Merge(testing.Attrs{
+		config.CrossModelContactTimeout: "-1h",
+	}))
+	c.Assert(err, gc.ErrorMatches, `cross model contact timeout -1h0m0s cannot be negative`)
+}
+
 func (s *ConfigSuite) TestEgressSubnets(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{
 		"egress-subnets": "10.0.0.1/32, 192.168.1.1/16",
@@ -1334,6 +1406,443 @@ func (s *ConfigSuite) TestEgressSubnets(c *gc.C) {
 	c.Assert(cfg.EgressSubnets(), gc.DeepEquals, []string{"10.0.0.1/32", "192.168.1.1/16"})
 }
 
+func (s *ConfigSuite) TestMaxOperationsAge(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.MaxOperationsAge(), gc.Equals, 720*time.Hour)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.MaxOperationsAge: "24h",
+	})
+	c.Assert(cfg.MaxOperationsAge(), gc.Equals, 24*time.Hour)
+}
+
+func (s *ConfigSuite) TestMaxOperationsAgeInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, minimalConfigAttrs.Merge(testing.Attrs{
+		config.MaxOperationsAge: "0s",
+	}))
+	c.Assert(err, gc.ErrorMatches, `max operations age 0s must be positive`)
+}
+
+func (s *ConfigSuite) TestStatusAlertRules(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.StatusAlertRules(), gc.HasLen, 0)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.StatusAlertRules: "- name: unit-error\n  entity: unit\n  status: error\n  for: 5m\n",
+	})
+	c.Assert(cfg.StatusAlertRules(), jc.DeepEquals, []statusalert.Rule{{
+		Name:   "unit-error",
+		Entity: statusalert.KindUnit,
+		Status: status.Error,
+		For:    "5m",
+	}})
+}
+
+func (s *ConfigSuite) TestStatusAlertRulesInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, minimalConfigAttrs.Merge(testing.Attrs{
+		config.StatusAlertRules: "- name: unit-error\n  entity: relation\n  status: error\n",
+	}))
+	c.Assert(err, gc.ErrorMatches, `invalid status alert rules in model configuration: rule "unit-error" entity "relation" not valid`)
+}
+
+func (s *ConfigSuite) TestHealthProbes(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.HealthProbes(), gc.HasLen, 0)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.HealthProbes: "mysql:\n  - name: port\n    tcp: localhost:3306\n",
+	})
+	c.Assert(cfg.HealthProbes(), jc.DeepEquals, map[string][]healthprobe.Probe{
+		"mysql": {{Name: "port", TCP: "localhost:3306"}},
+	})
+}
+
+func (s *ConfigSuite) TestHealthProbesInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, minimalConfigAttrs.Merge(testing.Attrs{
+		config.HealthProbes: "mysql:\n  - name: port\n",
+	}))
+	c.Assert(err, gc.ErrorMatches, `invalid health probes in model configuration: application "mysql": probe "port" must have exactly one of tcp, http or command`)
+}
+
+func (s *ConfigSuite) TestStatusAlertWebhook(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	_, ok := cfg.StatusAlertWebhook()
+	c.Assert(ok, jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.StatusAlertWebhook: "https://alerts.internal/juju",
+	})
+	webhook, ok := cfg.StatusAlertWebhook()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(webhook, gc.Equals, "https://alerts.internal/juju")
+}
+
+func (s *ConfigSuite) TestStatusAlertWebhookInvalid(c *gc.C) {
+	for _, value := range []string{"alerts.internal", "mailto:ops@example.com", "https://"} {
+		c.Logf("status-alert-webhook %q", value)
+		_, err := config.New(config.UseDefaults, minimalConfigAttrs.Merge(testing.Attrs{
+			config.StatusAlertWebhook: value,
+		}))
+		c.Check(err, gc.ErrorMatches, `status alert webhook ".*" must be an absolute http or https URL`)
+	}
+}
+
+func (s *ConfigSuite) TestBundleSource(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	_, ok := cfg.BundleSource()
+	c.Assert(ok, jc.IsFalse)
+	c.Assert(cfg.BundleReconcileMode(), gc.Equals, config.BundleReconcileManual)
+
+	for _, value := range []string{"controller", "https://git.internal/ops/raw/master/bundle.yaml"} {
+		cfg = newTestConfig(c, testing.Attrs{
+			config.BundleSourceKey:        value,
+			config.BundleReconcileModeKey: "auto",
+		})
+		source, ok := cfg.BundleSource()
+		c.Check(ok, jc.IsTrue)
+		c.Check(source, gc.Equals, value)
+		c.Check(cfg.BundleReconcileMode(), gc.Equals, config.BundleReconcileAuto)
+	}
+}
+
+func (s *ConfigSuite) TestBundleSourceInvalid(c *gc.C) {
+	for _, value := range []string{"bundle.yaml", "ssh://git.internal/ops/bundles.git", "https://"} {
+		c.Logf("bundle-source %q", value)
+		_, err := config.New(config.UseDefaults, minimalConfigAttrs.Merge(testing.Attrs{
+			config.BundleSourceKey: value,
+		}))
+		c.Check(err, gc.ErrorMatches, `bundle source ".*" must be "controller" or an absolute http or https URL`)
+	}
+}
+
+func (s *ConfigSuite) TestBundleReconcileModeInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, minimalConfigAttrs.Merge(testing.Attrs{
+		config.BundleReconcileModeKey: "sometimes",
+	}))
+	c.Assert(err, gc.ErrorMatches, `bundle-reconcile-mode: expected one of .*`)
+}
+
+func (s *ConfigSuite) TestAddressConflictPolicy(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.AddressConflictPolicy(), gc.Equals, corenetwork.ProviderWins)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.AddressConflictPolicyKey: "merge-with-scope-priority",
+	})
+	c.Assert(cfg.AddressConflictPolicy(), gc.Equals, corenetwork.MergeWithScopePriority)
+}
+
+func (s *ConfigSuite) TestAddressConflictPolicyInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, minimalConfigAttrs.Merge(testing.Attrs{
+		config.AddressConflictPolicyKey: "agent-wins",
+	}))
+	c.Assert(err, gc.ErrorMatches, `address-conflict-policy: expected one of .*`)
+}
+
+func (s *ConfigSuite) TestReportSecurityUpdates(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.ReportSecurityUpdates(), jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.ReportSecurityUpdatesKey: true,
+	})
+	c.Assert(cfg.ReportSecurityUpdates(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestContainerRuntimeProxy(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.ContainerRuntimeProxy(), jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.ContainerRuntimeProxyKey: true,
+	})
+	c.Assert(cfg.ContainerRuntimeProxy(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestCharmMirrorURL(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	_, ok := cfg.CharmMirrorURL()
+	c.Assert(ok, jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.CharmMirrorURLKey: "https://mirror.internal/charmstore",
+	})
+	mirrorURL, ok := cfg.CharmMirrorURL()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(mirrorURL, gc.Equals, "https://mirror.internal/charmstore")
+}
+
+func (s *ConfigSuite) TestCharmMirrorURLInvalid(c *gc.C) {
+	for _, value := range []string{"mirror.internal", "ftp://mirror.internal", "https://"} {
+		c.Logf("charm-mirror-url %q", value)
+		_, err := config.New(config.UseDefaults, minimalConfigAttrs.Merge(testing.Attrs{
+			config.CharmMirrorURLKey: value,
+		}))
+		c.Check(err, gc.ErrorMatches, `charm mirror URL ".*" must be an absolute http or https URL`)
+	}
+}
+
+func (s *ConfigSuite) TestPinnedImages(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.PinnedImages(), gc.HasLen, 0)
+	c.Assert(cfg.ImagePolicy(), gc.Equals, config.ImagePolicyAny)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.ImageIDsKey:    "xenial=ami-0123abcd bionic/us-east-1=ami-4567ef01 bionic=ami-89abcdef",
+		config.ImagePolicyKey: config.ImagePolicyPinned,
+	})
+	c.Assert(cfg.ImagePolicy(), gc.Equals, config.ImagePolicyPinned)
+	c.Assert(cfg.PinnedImages(), jc.DeepEquals, []config.PinnedImage{
+		{Series: "bionic", ImageID: "ami-89abcdef"},
+		{Series: "bionic", Region: "us-east-1", ImageID: "ami-4567ef01"},
+		{Series: "xenial", ImageID: "ami-0123abcd"},
+	})
+
+	image, ok := cfg.PinnedImage("bionic", "us-east-1")
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(image.ImageID, gc.Equals, "ami-4567ef01")
+	image, ok = cfg.PinnedImage("bionic", "eu-west-1")
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(image.ImageID, gc.Equals, "ami-89abcdef")
+	_, ok = cfg.PinnedImage("trusty", "us-east-1")
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestPinnedImagesInvalid(c *gc.C) {
+	for i, test := range []struct {
+		value string
+		err   string
+	}{{
+		value: "ami-0123abcd",
+		err:   `image-ids: image id "ami-0123abcd", expected <series>\[/<region>\]=<image id> not valid`,
+	}, {
+		value: "bionic/=ami-0123abcd",
+		err:   `image-ids: empty region in image id "bionic/=ami-0123abcd" not valid`,
+	}, {
+		value: "fluffy=ami-0123abcd",
+		err:   `image-ids: series "fluffy" in image id "fluffy=ami-0123abcd" not valid`,
+	}, {
+		value: "bionic=",
+		err:   `image-ids: image id "" for "bionic" not valid`,
+	}, {
+		value: "bionic=ami;rm",
+		err:   `image-ids: image id "ami;rm" for "bionic" not valid`,
+	}, {
+		value: "bionic=ami-0123abcd bionic=ami-4567ef01",
+		err:   `image-ids: more than one image id for "bionic"`,
+	}} {
+		c.Logf("test %d: %q", i, test.value)
+		_, err := config.New(config.UseDefaults, minimalConfigAttrs.Merge(testing.Attrs{
+			config.ImageIDsKey: test.value,
+		}))
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *ConfigSuite) TestImagePolicyPinnedRequiresImageIDs(c *gc.C) {
+	_, err := config.New(config.UseDefaults, minimalConfigAttrs.Merge(testing.Attrs{
+		config.ImagePolicyKey: config.ImagePolicyPinned,
+	}))
+	c.Assert(err, gc.ErrorMatches, `image-policy "pinned" requires image-ids to be set`)
+}
+
+func (s *ConfigSuite) TestImagePolicyInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, minimalConfigAttrs.Merge(testing.Attrs{
+		config.ImagePolicyKey: "golden",
+	}))
+	c.Assert(err, gc.ErrorMatches, `.*image-policy.*"golden".*`)
+}
+
+func (s *ConfigSuite) TestImageStreamFor(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"image-stream":         "daily",
+		config.ImageStreamsKey: "bionic=hardened",
+	})
+	c.Assert(cfg.ImageStreamFor("bionic"), gc.Equals, "hardened")
+	c.Assert(cfg.ImageStreamFor("xenial"), gc.Equals, "daily")
+}
+
+func (s *ConfigSuite) TestImageStreamsInvalid(c *gc.C) {
+	for _, value := range []string{"hardened", "bionic=", "fluffy=hardened", "bionic=a bionic=b"} {
+		c.Logf("image-streams %q", value)
+		_, err := config.New(config.UseDefaults, minimalConfigAttrs.Merge(testing.Attrs{
+			config.ImageStreamsKey: value,
+		}))
+		c.Check(err, gc.ErrorMatches, `image-streams: .*`)
+	}
+}
+
+func (s *ConfigSuite) TestColocation(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		config.AntiColocationKey:     "mysql,wordpress haproxy",
+		config.MaxUnitsPerMachineKey: 3,
+	})
+	c.Assert(cfg.AntiColocation(), jc.DeepEquals, [][]string{{"mysql", "wordpress"}, {"haproxy"}})
+	c.Assert(cfg.MaxUnitsPerMachine(), gc.Equals, 3)
+
+	cfg = newTestConfig(c, nil)
+	c.Assert(cfg.AntiColocation(), gc.HasLen, 0)
+	c.Assert(cfg.MaxUnitsPerMachine(), gc.Equals, 0)
+}
+
+func (s *ConfigSuite) TestAntiColocationInvalid(c *gc.C) {
+	for _, value := range []string{"mysql,", "mysql,Wordpress", "mysql,mysql"} {
+		c.Logf("anti-colocation %q", value)
+		_, err := config.New(config.UseDefaults, minimalConfigAttrs.Merge(testing.Attrs{
+			config.AntiColocationKey: value,
+		}))
+		c.Check(err, gc.ErrorMatches, `anti-colocation: .*`)
+	}
+}
+
+func (s *ConfigSuite) TestAptSources(c *gc.C) {
+	cfg := newTestConfig(c, nil)
+	c.Assert(cfg.AptSources(), gc.HasLen, 0)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.AptSourcesKey: `
+- source: ppa:juju/stable
+- source: deb [arch=amd64] http://example.com/ubuntu bionic main
+  key: |
+    -----BEGIN PGP PUBLIC KEY BLOCK-----
+    mQINBFit
+    -----END PGP PUBLIC KEY BLOCK-----
+`,
+	})
+	c.Assert(cfg.AptSources(), jc.DeepEquals, []config.AptSource{{
+		Source: "ppa:juju/stable",
+	}, {
+		Source: "deb [arch=amd64] http://example.com/ubuntu bionic main",
+		Key:    "-----BEGIN PGP PUBLIC KEY BLOCK-----\nmQINBFit\n-----END PGP PUBLIC KEY BLOCK-----\n",
+	}})
+	c.Assert(cfg.AptSources()[0].IsPPA(), jc.IsTrue)
+	c.Assert(cfg.AptSources()[1].IsPPA(), jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestAptSourcesInvalid(c *gc.C) {
+	for i, test := range []struct {
+		value  string
+		expect string
+	}{{
+		value:  "- ppa:juju/stable",
+		expect: `apt-sources: yaml: unmarshal errors:\n.*`,
+	}, {
+		value:  "- source: ppa:juju/stable\n  url: foo",
+		expect: `apt-sources: yaml: unmarshal errors:\n.*`,
+	}, {
+		value:  "- key: foo",
+		expect: `apt-sources: empty source not valid`,
+	}, {
+		value:  "- source: ppa:juju",
+		expect: `apt-sources: PPA "ppa:juju" not valid`,
+	}, {
+		value:  "- source: ppa:juju/stable\n  key: foo",
+		expect: `apt-sources: key given for PPA "ppa:juju/stable", PPA keys are fetched from Launchpad`,
+	}, {
+		value:  "- source: http://example.com/ubuntu bionic main",
+		expect: `apt-sources: apt source "http://example.com/ubuntu bionic main" not valid`,
+	}, {
+		value:  "- source: deb http://example.com/ubuntu bionic main\n  key: foo",
+		expect: `apt-sources: key for apt source "deb http://example.com/ubuntu bionic main" is not an ASCII-armored public key`,
+	}, {
+		value:  "- source: ppa:juju/stable\n- source: ppa:juju/stable",
+		expect: `apt-sources: apt source "ppa:juju/stable" repeated`,
+	}} {
+		c.Logf("test %d: %q", i, test.value)
+		_, err := config.New(config.UseDefaults, minimalConfigAttrs.Merge(testing.Attrs{
+			config.AptSourcesKey: test.value,
+		}))
+		c.Check(err, gc.ErrorMatches, test.expect)
+	}
+}
+
+func (s *ConfigSuite) TestMaxUnitsPerMachineInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, minimalConfigAttrs.Merge(testing.Attrs{
+		config.MaxUnitsPerMachineKey: -1,
+	}))
+	c.Assert(err, gc.ErrorMatches, `negative max-units-per-machine -1 not valid`)
+}
+
+func (s *ConfigSuite) TestMaxRelationDataSize(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		config.MaxRelationDataSizeKey: 4096,
+	})
+	c.Assert(cfg.MaxRelationDataSize(), gc.Equals, 4096)
+
+	cfg = newTestConfig(c, nil)
+	c.Assert(cfg.MaxRelationDataSize(), gc.Equals, config.DefaultMaxRelationDataSize)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.MaxRelationDataSizeKey: 0,
+	})
+	c.Assert(cfg.MaxRelationDataSize(), gc.Equals, 0)
+}
+
+func (s *ConfigSuite) TestMaxRelationDataSizeInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, minimalConfigAttrs.Merge(testing.Attrs{
+		config.MaxRelationDataSizeKey: -1,
+	}))
+	c.Assert(err, gc.ErrorMatches, `negative max-relation-data-size -1 not valid`)
+}
+
+func (s *ConfigSuite) TestMaxCharmDeployments(c *gc.C) {
+	cfg := newTestConfig(c, nil)
+	c.Assert(cfg.MaxCharmDeployments(), gc.Equals, 0)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.MaxCharmDeploymentsKey: 5,
+	})
+	c.Assert(cfg.MaxCharmDeployments(), gc.Equals, 5)
+}
+
+func (s *ConfigSuite) TestMaxCharmDeploymentsInvalid(c *gc.C) {
+	_, err := config.New(config.UseDefaults, minimalConfigAttrs.Merge(testing.Attrs{
+		config.MaxCharmDeploymentsKey: -1,
+	}))
+	c.Assert(err, gc.ErrorMatches, `negative max-charm-deployments -1 not valid`)
+}
+
+func (s *ConfigSuite) TestMaxStatusHistoryEntries(c *gc.C) {
+	cfg := newTestConfig(c, nil)
+	c.Assert(cfg.MaxStatusHistoryEntries(), gc.Equals, 0)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.MaxStatusHistoryEntries: 500,
+	})
+	c.Assert(cfg.MaxStatusHistoryEntries(), gc.Equals, 500)
+
+	_, err := config.New(config.UseDefaults, minimalConfigAttrs.Merge(testing.Attrs{
+		config.MaxStatusHistoryEntries: -1,
+	}))
+	c.Assert(err, gc.ErrorMatches, `negative max-status-history-entries -1 not valid`)
+}
+
+func (s *ConfigSuite) TestStatusHistoryRetention(c *gc.C) {
+	cfg := newTestConfig(c, nil)
+	c.Assert(cfg.StatusHistoryRetention(), gc.HasLen, 0)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		config.StatusHistoryRetention: "machine:\n  max-age: 2160h\nworkload:\n  max-entries: 100\n",
+	})
+	c.Assert(cfg.StatusHistoryRetention(), jc.DeepEquals, map[status.RetentionKind]status.HistoryRetention{
+		status.RetentionMachine:  {MaxAge: 2160 * time.Hour},
+		status.RetentionWorkload: {MaxEntries: 100},
+	})
+
+	_, err := config.New(config.UseDefaults, minimalConfigAttrs.Merge(testing.Attrs{
+		config.StatusHistoryRetention: "model:\n  max-age: 1h\n",
+	}))
+	c.Assert(err, gc.ErrorMatches, `invalid status history retention in model configuration: status history kind "model" not valid`)
+}
+
+func (s *ConfigSuite) TestCompactStatusHistory(c *gc.C) {
+	cfg := newTestConfig(c, nil)
+	c.Assert(cfg.CompactStatusHistory(), jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{config.CompactStatusHistory: true})
+	c.Assert(cfg.CompactStatusHistory(), jc.IsTrue)
+}
+
 func (s *ConfigSuite) TestCloudInitUserDataFromEnvironment(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{
 		config.CloudInitUserDataKey: validCloudInitUserData,
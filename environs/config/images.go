@@ -0,0 +1,176 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package config
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/os/series"
+)
+
+const (
+	// ImagePolicyAny allows machines to be started from any image found
+	// in the image metadata, with pinned image ids taking precedence.
+	ImagePolicyAny = "any"
+
+	// ImagePolicyPinned requires machines to be started from the images
+	// pinned with image-ids; provisioning machines of a series or in a
+	// region for which no image is pinned fails.
+	ImagePolicyPinned = "pinned"
+)
+
+// validImageID matches the image ids which may be pinned. Providers
+// may further restrict the form of their image ids.
+var validImageID = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._:/-]*$`)
+
+// PinnedImage describes an image which must be used to start machines
+// of a series, optionally in a single region.
+type PinnedImage struct {
+	// Series is the OS series of the image.
+	Series string
+
+	// Region is the cloud region in which the image is used. If empty,
+	// the image is used in all regions.
+	Region string
+
+	// ImageID is the provider specific id of the image.
+	ImageID string
+}
+
+// ParseImageIDs parses a space separated list of pinned images, each
+// of the form <series>[/<region>]=<image id>.
+func ParseImageIDs(value string) ([]PinnedImage, error) {
+	var images []PinnedImage
+	seen := make(map[string]bool)
+	for _, field := range strings.Fields(value) {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 {
+			return nil, errors.NotValidf("image id %q, expected <series>[/<region>]=<image id>", field)
+		}
+		var image PinnedImage
+		image.Series, image.ImageID = parts[0], parts[1]
+		if i := strings.Index(image.Series, "/"); i >= 0 {
+			image.Series, image.Region = image.Series[:i], image.Series[i+1:]
+			if image.Region == "" {
+				return nil, errors.NotValidf("empty region in image id %q", field)
+			}
+		}
+		if _, err := series.SeriesVersion(image.Series); err != nil {
+			return nil, errors.NotValidf("series %q in image id %q", image.Series, field)
+		}
+		if !validImageID.MatchString(image.ImageID) {
+			return nil, errors.NotValidf("image id %q for %q", image.ImageID, parts[0])
+		}
+		if seen[parts[0]] {
+			return nil, errors.Errorf("more than one image id for %q", parts[0])
+		}
+		seen[parts[0]] = true
+		images = append(images, image)
+	}
+	return images, nil
+}
+
+// ParseImageStreams parses a space separated list of per-series image
+// streams, each of the form <series>=<stream>.
+func ParseImageStreams(value string) (map[string]string, error) {
+	streams := make(map[string]string)
+	for _, field := range strings.Fields(value) {
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, errors.NotValidf("image stream %q, expected <series>=<stream>", field)
+		}
+		if _, err := series.SeriesVersion(parts[0]); err != nil {
+			return nil, errors.NotValidf("series %q in image stream %q", parts[0], field)
+		}
+		if _, ok := streams[parts[0]]; ok {
+			return nil, errors.Errorf("more than one image stream for series %q", parts[0])
+		}
+		streams[parts[0]] = parts[1]
+	}
+	return streams, nil
+}
+
+// PinnedImages returns the images pinned with image-ids, sorted by
+// series and region.
+func (c *Config) PinnedImages() []PinnedImage {
+	value, _ := c.defined[ImageIDsKey].(string)
+	// The value has been validated.
+	images, _ := ParseImageIDs(value)
+	sort.Slice(images, func(i, j int) bool {
+		if images[i].Series != images[j].Series {
+			return images[i].Series < images[j].Series
+		}
+		return images[i].Region < images[j].Region
+	})
+	return images
+}
+
+// PinnedImage returns the image pinned for the series in the region,
+// and whether there is one. An image pinned for the region takes
+// precedence over one pinned for all regions.
+func (c *Config) PinnedImage(series, region string) (PinnedImage, bool) {
+	var found *PinnedImage
+	images := c.PinnedImages()
+	for i, image := range images {
+		if image.Series != series {
+			continue
+		}
+		if image.Region == region {
+			return image, true
+		}
+		if image.Region == "" {
+			found = &images[i]
+		}
+	}
+	if found == nil {
+		return PinnedImage{}, false
+	}
+	return *found, true
+}
+
+// ImageStreamFor returns the simplestreams stream used to identify
+// which image ids to search when starting an instance of the series.
+func (c *Config) ImageStreamFor(series string) string {
+	value, _ := c.defined[ImageStreamsKey].(string)
+	// The value has been validated.
+	streams, _ := ParseImageStreams(value)
+	if stream, ok := streams[series]; ok {
+		return stream
+	}
+	return c.ImageStream()
+}
+
+// ImagePolicy returns the policy used to select the images from which
+// machines are started.
+func (c *Config) ImagePolicy() string {
+	if v, _ := c.defined[ImagePolicyKey].(string); v != "" {
+		return v
+	}
+	return ImagePolicyAny
+}
+
+func validateImageConfig(cfg *Config) error {
+	ids, _ := cfg.defined[ImageIDsKey].(string)
+	images, err := ParseImageIDs(ids)
+	if err != nil {
+		return errors.Annotate(err, ImageIDsKey)
+	}
+	streams, _ := cfg.defined[ImageStreamsKey].(string)
+	if _, err := ParseImageStreams(streams); err != nil {
+		return errors.Annotate(err, ImageStreamsKey)
+	}
+	switch policy := cfg.ImagePolicy(); policy {
+	case ImagePolicyAny:
+	case ImagePolicyPinned:
+		if len(images) == 0 {
+			return errors.Errorf("%s %q requires %s to be set", ImagePolicyKey, policy, ImageIDsKey)
+		}
+	default:
+		return errors.NotValidf("%s %q, expected %q or %q", ImagePolicyKey, policy, ImagePolicyAny, ImagePolicyPinned)
+	}
+	return nil
+}
@@ -23,6 +23,17 @@ type Instance interface {
 	Addresses(context.ProviderCallContext) (corenetwork.ProviderAddresses, error)
 }
 
+// HardwareReporter is implemented by instances whose provider can
+// report their current hardware. The hardware may differ from that
+// recorded when the instance was started if it has since been resized
+// outside of Juju. Only LXD instances implement it so far; resizes of
+// other providers' instances aren't detected.
+type HardwareReporter interface {
+	// HardwareCharacteristics returns the current hardware
+	// characteristics of the instance.
+	HardwareCharacteristics(context.ProviderCallContext) (*instance.HardwareCharacteristics, error)
+}
+
 // InstanceFirewaller provides instance-level firewall functionality
 type InstanceFirewaller interface {
 	// OpenPorts opens the given port ranges on the instance, which
@@ -25,6 +25,10 @@ type InstanceType struct {
 	CpuPower   *uint64
 	Tags       []string
 	Deprecated bool
+	// GPUs is the number of GPUs the instance type comes with, or nil
+	// if GPUs are attached separately from choosing the type.
+	GPUs    *uint64
+	GPUType string
 }
 
 // InstanceTypesWithCostMetadata holds an array of InstanceType and metadata
@@ -46,6 +50,10 @@ func CpuPower(power uint64) *uint64 {
 	return &power
 }
 
+func GPUs(count uint64) *uint64 {
+	return &count
+}
+
 // match returns true if itype can satisfy the supplied constraints. If so,
 // it also returns a copy of itype with any arches that do not match the
 // constraints filtered out.
@@ -69,6 +77,12 @@ func (itype InstanceType) match(cons constraints.Value) (InstanceType, bool) {
 	if cons.CpuPower != nil && itype.CpuPower != nil && *itype.CpuPower < *cons.CpuPower {
 		return nothing, false
 	}
+	if cons.HasGPUs() && itype.GPUs != nil && *itype.GPUs < *cons.GPUs {
+		return nothing, false
+	}
+	if cons.HasGPUType() && itype.GPUs != nil && itype.GPUType != *cons.GPUType {
+		return nothing, false
+	}
 	if cons.Mem != nil && itype.Mem < *cons.Mem {
 		return nothing, false
 	}
@@ -318,6 +318,36 @@ func (s *instanceTypeSuite) TestMatch(c *gc.C) {
 	}
 }
 
+func (s *instanceTypeSuite) TestMatchGPUs(c *gc.C) {
+	itypes := []InstanceType{
+		{Name: "plain", Arches: []string{"amd64"}, GPUs: GPUs(0)},
+		{Name: "one-k80", Arches: []string{"amd64"}, GPUs: GPUs(1), GPUType: "k80"},
+		{Name: "four-v100", Arches: []string{"amd64"}, GPUs: GPUs(4), GPUType: "v100"},
+		{Name: "attachable", Arches: []string{"amd64"}},
+	}
+	for i, t := range []struct {
+		cons    string
+		matches []string
+	}{
+		{"", []string{"plain", "one-k80", "four-v100", "attachable"}},
+		{"gpus=0", []string{"plain", "one-k80", "four-v100", "attachable"}},
+		{"gpus=1", []string{"one-k80", "four-v100", "attachable"}},
+		{"gpus=2", []string{"four-v100", "attachable"}},
+		{"gpu-type=k80", []string{"one-k80", "attachable"}},
+		{"gpus=2 gpu-type=k80", []string{"attachable"}},
+	} {
+		c.Logf("test %d: %s", i, t.cons)
+		cons := constraints.MustParse(t.cons)
+		var matches []string
+		for _, itype := range itypes {
+			if _, ok := itype.match(cons); ok {
+				matches = append(matches, itype.Name)
+			}
+		}
+		c.Check(matches, jc.DeepEquals, t.matches)
+	}
+}
+
 var byCostTests = []struct {
 	about          string
 	itypesToUse    []InstanceType
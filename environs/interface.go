@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/cloud"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/environs/instances"
@@ -442,6 +443,53 @@ type InstanceLister interface {
 	Instances(ctx context.ProviderCallContext, ids []instance.Id) ([]instances.Instance, error)
 }
 
+// BulkInstanceQuerier is an interface that an Environ may implement to
+// report that its Instances method looks up any number of instances with
+// a fixed number of provider API calls, typically by listing all of the
+// model's instances, so that callers should ask for as many instances
+// at once as they can.
+type BulkInstanceQuerier interface {
+	// SupportsBulkInstanceQueries reports whether Instances queries
+	// instances in bulk.
+	SupportsBulkInstanceQueries() bool
+}
+
+// SupportsBulkInstanceQueries reports whether the given environ
+// implements BulkInstanceQuerier and queries instances in bulk.
+func SupportsBulkInstanceQueries(env InstanceLister) bool {
+	querier, ok := env.(BulkInstanceQuerier)
+	return ok && querier.SupportsBulkInstanceQueries()
+}
+
+// InstanceNotifier is an interface that an Environ may implement if the
+// provider can push changes to the model's instances, so that callers
+// can watch for changes instead of polling for them.
+type InstanceNotifier interface {
+	// WatchInstances returns a watcher which notifies the ids of
+	// instances whose status, addresses or hardware may have changed.
+	// If the provider can't notify instance changes in this cloud or
+	// with this configuration, an error satisfying
+	// errors.IsNotSupported is returned.
+	WatchInstances(ctx context.ProviderCallContext) (watcher.StringsWatcher, error)
+}
+
+// AutoscalingGroups is an interface that an Environ may implement if
+// the cloud can scale groups of instances itself, such as with an AWS
+// auto scaling group, so that the units of an application can be
+// placed on the instances which the cloud starts in a group.
+type AutoscalingGroups interface {
+	// AutoscalingGroupInstances returns the running instances in the
+	// named autoscaling group, including any which haven't yet been
+	// adopted into the model. No instances are returned for a group
+	// which doesn't exist.
+	AutoscalingGroupInstances(ctx context.ProviderCallContext, group string) ([]instances.Instance, error)
+
+	// AdoptAutoscalingGroupInstances makes the instances with the
+	// given ids, started by the cloud in an autoscaling group, part
+	// of the model, so that they are returned by Instances.
+	AdoptAutoscalingGroupInstances(ctx context.ProviderCallContext, ids []instance.Id) error
+}
+
 // PrecheckInstanceParams contains the parameters for
 // InstancePrechecker.PrecheckInstance.
 type PrecheckInstanceParams struct {
@@ -498,6 +546,16 @@ type InstanceTagger interface {
 	TagInstance(ctx context.ProviderCallContext, id instance.Id, tags map[string]string) error
 }
 
+// InstanceConsoleOutputGetter is an interface that an Environ may
+// implement to allow the console output of its instances to be
+// retrieved, so that machines which never start their agents can be
+// diagnosed.
+type InstanceConsoleOutputGetter interface {
+	// InstanceConsoleOutput returns the output written to the console
+	// of the instance with the given ID.
+	InstanceConsoleOutput(ctx context.ProviderCallContext, id instance.Id) (string, error)
+}
+
 // InstanceTypesFetcher is an interface that allows for instance information from
 // a provider to be obtained.
 type InstanceTypesFetcher interface {
@@ -71,3 +71,9 @@ const JujuV3 = "juju-v3"
 // This feature is disabled during import and export of information, turning
 // this on will allow that to happen.
 const CMRMigrations = "cmr-migrations"
+
+// CachedStatus indicates that the client facade should serve the machines,
+// applications and units in full status from the model cache, rather than
+// reading them from the database on every request.
+// This value is only checked using the controller config "features" attribute.
+const CachedStatus = "cached-status"
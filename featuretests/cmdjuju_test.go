@@ -113,6 +113,16 @@ func (s *cmdJujuSuite) TestApplicationUnset(c *gc.C) {
 func (s *cmdJujuSuite) TestApplicationGetIAASModel(c *gc.C) {
 	expected := `application: dummy-application
 application-config:
+  autoscaling-group:
+    description: The provider autoscaling group whose instances run the units
+    source: unset
+    type: string
+  primary-address:
+    default: auto
+    description: The unit address to advertise (auto, binding, public or fqdn)
+    source: default
+    type: string
+    value: auto
   trust:
     default: false
     description: Does this application have access to trusted credentials
@@ -279,6 +289,16 @@ settings:
 func (s *cmdJujuSuite) TestApplicationGetWeirdYAML(c *gc.C) {
 	expected := `application: yaml-config
 application-config:
+  autoscaling-group:
+    description: The provider autoscaling group whose instances run the units
+    source: unset
+    type: string
+  primary-address:
+    default: auto
+    description: The unit address to advertise (auto, binding, public or fqdn)
+    source: default
+    type: string
+    value: auto
   trust:
     default: false
     description: Does this application have access to trusted credentials
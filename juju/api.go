@@ -44,6 +44,11 @@ type NewAPIConnectionParams struct {
 	// will be scoped to the model with that UUID; otherwise it will be
 	// scoped to the controller.
 	ModelUUID string
+
+	// Impersonate optionally holds the user that the logged in
+	// controller superuser will act as. The account details held in
+	// the store are not updated from an impersonated login.
+	Impersonate names.UserTag
 }
 
 // NewAPIConnection returns an api.Connection to the specified Juju controller,
@@ -126,7 +131,7 @@ func NewAPIConnection(args NewAPIConnectionParams) (_ api.Connection, err error)
 	// Process the account details obtained from login.
 	var accountDetails *jujuclient.AccountDetails
 	user, ok := st.AuthTag().(names.UserTag)
-	if !apiInfo.SkipLogin {
+	if !apiInfo.SkipLogin && apiInfo.Impersonate.Id() == "" {
 		if ok {
 			if accountDetails, err = args.Store.AccountDetails(args.ControllerName); err != nil {
 				if !errors.IsNotFound(err) {
@@ -193,6 +198,7 @@ func connectionInfo(args NewAPIConnectionParams) (*api.Info, *jujuclient.Control
 		// authenticate using macaroons.
 		apiInfo.Password = account.Password
 	}
+	apiInfo.Impersonate = args.Impersonate
 	return apiInfo, controller, nil
 }
 
@@ -0,0 +1,156 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// FormatVersion is the version of the model description format written
+// by ExportModel, and the newest version which DeserializeModel accepts.
+const FormatVersion = 1
+
+// UnknownFieldsError is returned when a model description is
+// deserialized in strict mode and holds fields this controller doesn't
+// understand, which would be lost if it were imported.
+type UnknownFieldsError struct {
+	// Paths holds the location of each unknown field, such as
+	// "applications.applications[0].colour", in sorted order.
+	Paths []string
+}
+
+// Error is part of the error interface.
+func (e *UnknownFieldsError) Error() string {
+	return fmt.Sprintf("model description has unknown fields: %s", strings.Join(e.Paths, ", "))
+}
+
+// IsUnknownFieldsError returns true if the cause of the error is an
+// *UnknownFieldsError.
+func IsUnknownFieldsError(err error) bool {
+	_, ok := errors.Cause(err).(*UnknownFieldsError)
+	return ok
+}
+
+// DeserializeModel deserializes a model description, first checking
+// that it is in a format version this controller understands. Fields
+// in the description which this controller doesn't understand, and so
+// would be dropped, are reported in an *UnknownFieldsError if strict is
+// true; otherwise they are logged and ignored.
+func DeserializeModel(bytes []byte, strict bool) (description.Model, error) {
+	var source map[interface{}]interface{}
+	if err := yaml.Unmarshal(bytes, &source); err != nil {
+		return nil, errors.Trace(err)
+	}
+	version, ok := source["version"].(int)
+	if !ok {
+		return nil, errors.NotValidf("model description without a version")
+	}
+	if version > FormatVersion {
+		return nil, errors.NotSupportedf(
+			"model description format version %d (newest supported is %d)", version, FormatVersion)
+	}
+
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	paths, err := unknownFields(source, model)
+	if err != nil {
+		return nil, errors.Annotate(err, "checking for unknown fields")
+	}
+	if len(paths) == 0 {
+		return model, nil
+	}
+	if strict {
+		return nil, &UnknownFieldsError{Paths: paths}
+	}
+	logger.Warningf("ignoring unknown fields in model description: %s", strings.Join(paths, ", "))
+	return model, nil
+}
+
+// unknownFields returns the paths of the fields in the source which
+// don't survive a round trip through the model description.
+func unknownFields(source map[interface{}]interface{}, model description.Model) ([]string, error) {
+	bytes, err := description.Serialize(model)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var result map[interface{}]interface{}
+	if err := yaml.Unmarshal(bytes, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	paths := missingFields("", source, result)
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// missingFields returns the paths of the non-empty fields in source
+// which are absent from result. Sections of the description written in
+// a different version to the one this controller writes are converted
+// when they're read rather than copied, so they aren't compared, and
+// neither are lists whose lengths differ.
+func missingFields(path string, source, result interface{}) []string {
+	var paths []string
+	switch source := source.(type) {
+	case map[interface{}]interface{}:
+		result, ok := result.(map[interface{}]interface{})
+		if !ok {
+			return nil
+		}
+		if version, ok := source["version"]; ok && version != result["version"] {
+			return nil
+		}
+		for key, value := range source {
+			name := fmt.Sprint(key)
+			if path != "" {
+				name = path + "." + name
+			}
+			resultValue, found := result[key]
+			if !found {
+				if !isEmpty(value) {
+					paths = append(paths, name)
+				}
+				continue
+			}
+			paths = append(paths, missingFields(name, value, resultValue)...)
+		}
+	case []interface{}:
+		result, ok := result.([]interface{})
+		if !ok || len(result) != len(source) {
+			return nil
+		}
+		for i, value := range source {
+			paths = append(paths, missingFields(fmt.Sprintf("%s[%d]", path, i), value, result[i])...)
+		}
+	}
+	return paths
+}
+
+// isEmpty reports whether the value is one which the model description
+// may omit when it is serialized.
+func isEmpty(value interface{}) bool {
+	switch value := value.(type) {
+	case nil:
+		return true
+	case string:
+		return value == ""
+	case bool:
+		return !value
+	case int:
+		return value == 0
+	case float64:
+		return value == 0
+	case []interface{}:
+		return len(value) == 0
+	case map[interface{}]interface{}:
+		return len(value) == 0
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package migration_test
+
+import (
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/migration"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type FormatSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&FormatSuite{})
+
+// exportedModel returns the current model's description, as parsed
+// YAML which can be changed before it is serialized again.
+func (s *FormatSuite) exportedModel(c *gc.C) map[interface{}]interface{} {
+	s.Factory.MakeUnit(c, &factory.UnitParams{})
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	bytes, err := description.Serialize(model)
+	c.Assert(err, jc.ErrorIsNil)
+	var source map[interface{}]interface{}
+	err = yaml.Unmarshal(bytes, &source)
+	c.Assert(err, jc.ErrorIsNil)
+	return source
+}
+
+func (s *FormatSuite) deserialize(c *gc.C, source map[interface{}]interface{}, strict bool) (description.Model, error) {
+	bytes, err := yaml.Marshal(source)
+	c.Assert(err, jc.ErrorIsNil)
+	return migration.DeserializeModel(bytes, strict)
+}
+
+func (s *FormatSuite) TestExportedVersion(c *gc.C) {
+	source := s.exportedModel(c)
+	c.Assert(source["version"], gc.Equals, migration.FormatVersion)
+}
+
+func (s *FormatSuite) TestRoundTripStrict(c *gc.C) {
+	source := s.exportedModel(c)
+	model, err := s.deserialize(c, source, true)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Tag().Id(), gc.Equals, s.State.ModelUUID())
+}
+
+func (s *FormatSuite) TestUnknownFieldsStrict(c *gc.C) {
+	source := s.exportedModel(c)
+	source["colour"] = "blue"
+	source["flavour"] = ""
+	machines := source["machines"].(map[interface{}]interface{})
+	machines["rack"] = 42
+	machineList := machines["machines"].([]interface{})
+	machineList[0].(map[interface{}]interface{})["shelf"] = "top"
+
+	_, err := s.deserialize(c, source, true)
+	c.Assert(err, jc.Satisfies, migration.IsUnknownFieldsError)
+	c.Assert(err.(*migration.UnknownFieldsError).Paths, jc.DeepEquals, []string{
+		"colour", "machines.machines[0].shelf", "machines.rack",
+	})
+	c.Assert(err, gc.ErrorMatches,
+		`model description has unknown fields: colour, machines.machines\[0\].shelf, machines.rack`)
+}
+
+func (s *FormatSuite) TestUnknownFieldsLenient(c *gc.C) {
+	source := s.exportedModel(c)
+	source["colour"] = "blue"
+
+	model, err := s.deserialize(c, source, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Tag().Id(), gc.Equals, s.State.ModelUUID())
+}
+
+func (s *FormatSuite) TestNewerVersion(c *gc.C) {
+	source := s.exportedModel(c)
+	source["version"] = migration.FormatVersion + 1
+
+	_, err := s.deserialize(c, source, false)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+	c.Assert(err, gc.ErrorMatches, `model description format version 2 \(newest supported is 1\) not supported`)
+}
+
+func (s *FormatSuite) TestMissingVersion(c *gc.C) {
+	source := s.exportedModel(c)
+	delete(source, "version")
+
+	_, err := s.deserialize(c, source, false)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
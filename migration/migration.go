@@ -61,9 +61,11 @@ type ClaimerFunc func(string) (leadership.Claimer, error)
 
 // ImportModel deserializes a model description from the bytes, transforms
 // the model config based on information from the controller model, and then
-// imports that as a new database model.
-func ImportModel(importer StateImporter, getClaimer ClaimerFunc, bytes []byte) (*state.Model, *state.State, error) {
-	model, err := description.Deserialize(bytes)
+// imports that as a new database model. If strict is true, a description
+// with fields this controller doesn't understand is rejected; see
+// DeserializeModel.
+func ImportModel(importer StateImporter, getClaimer ClaimerFunc, bytes []byte, strict bool) (*state.Model, *state.State, error) {
+	model, err := DeserializeModel(bytes, strict)
 	if err != nil {
 		return nil, nil, errors.Trace(err)
 	}
@@ -66,7 +66,7 @@ func (s *ImportSuite) SetUpTest(c *gc.C) {
 func (s *ImportSuite) TestBadBytes(c *gc.C) {
 	bytes := []byte("not a model")
 	controller := state.NewController(s.StatePool)
-	model, st, err := migration.ImportModel(controller, fakeGetClaimer, bytes)
+	model, st, err := migration.ImportModel(controller, fakeGetClaimer, bytes, false)
 	c.Check(st, gc.IsNil)
 	c.Check(model, gc.IsNil)
 	c.Assert(err, gc.ErrorMatches, "yaml: unmarshal errors:\n.*")
@@ -89,7 +89,7 @@ func (s *ImportSuite) exportImport(c *gc.C, getClaimer migration.ClaimerFunc) *s
 	c.Check(err, jc.ErrorIsNil)
 
 	controller := state.NewController(s.StatePool)
-	dbModel, dbState, err := migration.ImportModel(controller, getClaimer, bytes)
+	dbModel, dbState, err := migration.ImportModel(controller, getClaimer, bytes, false)
 	c.Assert(err, jc.ErrorIsNil)
 	s.AddCleanup(func(*gc.C) { dbState.Close() })
 
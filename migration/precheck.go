@@ -12,6 +12,8 @@ import (
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/core/status"
@@ -25,7 +27,9 @@ import (
 type PrecheckBackend interface {
 	AgentVersion() (version.Number, error)
 	NeedsCleanup() (bool, error)
+	IsModelArchived() (bool, error)
 	Model() (PrecheckModel, error)
+	ModelConstraints() (constraints.Value, error)
 	AllModelUUIDs() ([]string, error)
 	IsUpgrading() (bool, error)
 	IsMigrationActive(string) (bool, error)
@@ -65,6 +69,8 @@ type PrecheckMachine interface {
 	AgentPresence() (bool, error)
 	InstanceStatus() (status.StatusInfo, error)
 	ShouldRebootOrShutdown() (state.RebootAction, error)
+	Constraints() (constraints.Value, error)
+	HardwareCharacteristics() (*instance.HardwareCharacteristics, error)
 }
 
 // PrecheckApplication describes the state interface for an
@@ -75,6 +81,8 @@ type PrecheckApplication interface {
 	CharmURL() (*charm.URL, bool)
 	AllUnits() ([]PrecheckUnit, error)
 	MinUnits() int
+	ScalingPolicy() state.ScalingPolicy
+	Constraints() (constraints.Value, error)
 }
 
 // PrecheckUnit describes state interface for a unit needed by
@@ -125,6 +133,22 @@ func SourcePrecheck(
 		return errors.Trace(err)
 	}
 
+	// The export of an archived model is held by this controller, so
+	// the model must be thawed before it can be migrated.
+	if archived, err := backend.IsModelArchived(); err != nil {
+		return errors.Annotate(err, "checking model archive")
+	} else if archived {
+		return errors.New("model is archived")
+	}
+
+	// The gpus and gpu-type constraints, and the GPUs of machines,
+	// can't be carried in the model description.
+	if cons, err := backend.ModelConstraints(); err != nil {
+		return errors.Annotate(err, "retrieving model constraints")
+	} else if hasGPUConstraints(cons) {
+		return errors.New("model has gpus or gpu-type constraints; clear them before migrating")
+	}
+
 	if err := ctx.checkMachines(); err != nil {
 		return errors.Trace(err)
 	}
@@ -220,6 +244,11 @@ func TargetPrecheck(backend PrecheckBackend, pool Pool, modelInfo coremigration.
 			modelInfo.ControllerAgentVersion, controllerVersion)
 	}
 
+	if modelInfo.FormatVersion > FormatVersion {
+		return errors.Errorf("source controller exports a newer model description format than target controller (%d > %d)",
+			modelInfo.FormatVersion, FormatVersion)
+	}
+
 	controllerCtx := precheckContext{backend, presence}
 	if err := controllerCtx.checkController(); err != nil {
 		return errors.Trace(err)
@@ -324,10 +353,29 @@ func (ctx *precheckContext) checkMachines() error {
 		if err := checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
 			return errors.Trace(err)
 		}
+
+		if cons, err := machine.Constraints(); err != nil {
+			return errors.Annotatef(err, "retrieving machine %s constraints", machine.Id())
+		} else if hasGPUConstraints(cons) {
+			return errors.Errorf("machine %s has gpus or gpu-type constraints, which can't be migrated", machine.Id())
+		}
+		hc, err := machine.HardwareCharacteristics()
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "retrieving machine %s hardware", machine.Id())
+		}
+		if hc != nil && (hc.GPUs != nil || hc.GPUType != nil) {
+			return errors.Errorf("machine %s has GPUs, which can't be migrated", machine.Id())
+		}
 	}
 	return nil
 }
 
+// hasGPUConstraints reports whether the constraints use gpus or
+// gpu-type.
+func hasGPUConstraints(cons constraints.Value) bool {
+	return cons.GPUs != nil || cons.GPUType != nil
+}
+
 func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, error) {
 	modelVersion, err := ctx.backend.AgentVersion()
 	if err != nil {
@@ -347,6 +395,19 @@ func (ctx *precheckContext) checkApplications() (map[string][]PrecheckUnit, erro
 		if app.Life() != state.Alive {
 			return nil, errors.Errorf("application %s is %s", app.Name(), app.Life())
 		}
+		// The maximum units of a scaling policy can't be carried
+		// in the model description.
+		if app.ScalingPolicy().MaxUnits > 0 {
+			return nil, errors.Errorf("application %s has a maximum units scaling policy; "+
+				`clear it with "juju scaling-policy %s --max 0" and set it again after migrating`,
+				app.Name(), app.Name())
+		}
+		if cons, err := app.Constraints(); err != nil {
+			return nil, errors.Annotatef(err, "retrieving constraints for %s", app.Name())
+		} else if hasGPUConstraints(cons) {
+			return nil, errors.Errorf("application %s has gpus or gpu-type constraints; "+
+				"clear them before migrating", app.Name())
+		}
 		units, err := app.AllUnits()
 		if err != nil {
 			return nil, errors.Annotatef(err, "retrieving units for %s", app.Name())
@@ -11,6 +11,8 @@ import (
 	"gopkg.in/juju/charm.v6"
 	"gopkg.in/juju/names.v3"
 
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/instance"
 	coremigration "github.com/juju/juju/core/migration"
 	"github.com/juju/juju/core/presence"
 	"github.com/juju/juju/core/status"
@@ -93,6 +95,20 @@ func (*SourcePrecheckSuite) TestImportingModel(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "model is being imported as part of another migration")
 }
 
+func (*SourcePrecheckSuite) TestArchivedModel(c *gc.C) {
+	backend := newFakeBackend()
+	backend.archived = true
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model is archived")
+}
+
+func (*SourcePrecheckSuite) TestArchivedModelError(c *gc.C) {
+	backend := newFakeBackend()
+	backend.archivedErr = errors.New("boom")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "checking model archive: boom")
+}
+
 func (*SourcePrecheckSuite) TestCleanupsError(c *gc.C) {
 	backend := newFakeBackend()
 	backend.cleanupErr = errors.New("boom")
@@ -190,6 +206,62 @@ func (s *SourcePrecheckSuite) TestWithPendingMinUnits(c *gc.C) {
 	c.Assert(err.Error(), gc.Equals, "application foo is below its minimum units threshold")
 }
 
+func (s *SourcePrecheckSuite) TestWithMaxUnits(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name:     "foo",
+				maxunits: 3,
+				units:    []migration.PrecheckUnit{&fakeUnit{name: "foo/0"}},
+			},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, `application foo has a maximum units scaling policy; clear it with "juju scaling-policy foo --max 0" and set it again after migrating`)
+}
+
+func (s *SourcePrecheckSuite) TestWithGPUModelConstraints(c *gc.C) {
+	backend := newHappyBackend()
+	backend.modelConstraints = constraints.MustParse("gpus=1")
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model has gpus or gpu-type constraints; clear them before migrating")
+}
+
+func (s *SourcePrecheckSuite) TestWithGPUApplicationConstraints(c *gc.C) {
+	backend := &fakeBackend{
+		apps: []migration.PrecheckApplication{
+			&fakeApp{
+				name:  "foo",
+				cons:  constraints.MustParse("gpu-type=v100"),
+				units: []migration.PrecheckUnit{&fakeUnit{name: "foo/0"}},
+			},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "application foo has gpus or gpu-type constraints; clear them before migrating")
+}
+
+func (s *SourcePrecheckSuite) TestWithGPUMachineConstraints(c *gc.C) {
+	backend := &fakeBackend{
+		machines: []migration.PrecheckMachine{
+			&fakeMachine{id: "0", constraints: constraints.MustParse("gpus=2")},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "machine 0 has gpus or gpu-type constraints, which can't be migrated")
+}
+
+func (s *SourcePrecheckSuite) TestWithGPUMachineHardware(c *gc.C) {
+	gpus := uint64(1)
+	backend := &fakeBackend{
+		machines: []migration.PrecheckMachine{
+			&fakeMachine{id: "0", hardware: &instance.HardwareCharacteristics{GPUs: &gpus}},
+		},
+	}
+	err := sourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "machine 0 has GPUs, which can't be migrated")
+}
+
 func (s *SourcePrecheckSuite) TestUnitVersionsDontMatch(c *gc.C) {
 	backend := &fakeBackend{
 		model: fakeModel{modelType: state.ModelTypeIAAS},
@@ -480,6 +552,19 @@ func (s *TargetPrecheckSuite) TestSourceControllerMinorAhead(c *gc.C) {
 		`source controller has higher version than target controller (1.3.0 > 1.2.3)`)
 }
 
+func (s *TargetPrecheckSuite) TestFormatVersionAhead(c *gc.C) {
+	s.modelInfo.FormatVersion = migration.FormatVersion + 1
+
+	err := s.runPrecheck(newHappyBackend())
+	c.Assert(err.Error(), gc.Equals,
+		`source controller exports a newer model description format than target controller (2 > 1)`)
+}
+
+func (s *TargetPrecheckSuite) TestFormatVersionUnknown(c *gc.C) {
+	s.modelInfo.FormatVersion = 0
+	c.Assert(s.runPrecheck(newHappyBackend()), jc.ErrorIsNil)
+}
+
 func (s *TargetPrecheckSuite) TestSourceControllerPatchAhead(c *gc.C) {
 	backend := newFakeBackend()
 
@@ -763,6 +848,11 @@ type fakeBackend struct {
 	cleanupNeeded bool
 	cleanupErr    error
 
+	archived    bool
+	archivedErr error
+
+	modelConstraints constraints.Value
+
 	isUpgrading    bool
 	isUpgradingErr error
 
@@ -791,6 +881,10 @@ func (b *fakeBackend) Model() (migration.PrecheckModel, error) {
 	return &b.model, nil
 }
 
+func (b *fakeBackend) ModelConstraints() (constraints.Value, error) {
+	return b.modelConstraints, nil
+}
+
 func (b *fakeBackend) AllModelUUIDs() ([]string, error) {
 	return b.models, nil
 }
@@ -799,6 +893,10 @@ func (b *fakeBackend) NeedsCleanup() (bool, error) {
 	return b.cleanupNeeded, b.cleanupErr
 }
 
+func (b *fakeBackend) IsModelArchived() (bool, error) {
+	return b.archived, b.archivedErr
+}
+
 func (b *fakeBackend) AgentVersion() (version.Number, error) {
 	return backendVersion, b.agentVersionErr
 }
@@ -908,6 +1006,8 @@ type fakeMachine struct {
 	instanceStatus status.Status
 	lost           bool
 	rebootAction   state.RebootAction
+	constraints    constraints.Value
+	hardware       *instance.HardwareCharacteristics
 }
 
 func (m *fakeMachine) Id() string {
@@ -959,12 +1059,25 @@ func (m *fakeMachine) ShouldRebootOrShutdown() (state.RebootAction, error) {
 	return m.rebootAction, nil
 }
 
+func (m *fakeMachine) Constraints() (constraints.Value, error) {
+	return m.constraints, nil
+}
+
+func (m *fakeMachine) HardwareCharacteristics() (*instance.HardwareCharacteristics, error) {
+	if m.hardware == nil {
+		return nil, errors.NotFoundf("instance data for machine %s", m.id)
+	}
+	return m.hardware, nil
+}
+
 type fakeApp struct {
 	name     string
 	life     state.Life
 	charmURL string
 	units    []migration.PrecheckUnit
 	minunits int
+	maxunits int
+	cons     constraints.Value
 }
 
 func (a *fakeApp) Name() string {
@@ -991,6 +1104,14 @@ func (a *fakeApp) MinUnits() int {
 	return a.minunits
 }
 
+func (a *fakeApp) ScalingPolicy() state.ScalingPolicy {
+	return state.ScalingPolicy{MinUnits: a.minunits, MaxUnits: a.maxunits}
+}
+
+func (a *fakeApp) Constraints() (constraints.Value, error) {
+	return a.cons, nil
+}
+
 type fakeUnit struct {
 	name        string
 	version     version.Binary
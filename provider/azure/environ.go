@@ -391,6 +391,10 @@ func (env *azureEnviron) ConstraintsValidator(ctx context.ProviderCallContext) (
 		constraints.InstanceType,
 		instTypeNames,
 	)
+	validator.RegisterVocabulary(
+		constraints.GPUType,
+		gpuTypes(),
+	)
 	validator.RegisterConflicts(
 		[]string{constraints.InstanceType},
 		[]string{
@@ -407,14 +411,34 @@ func (env *azureEnviron) PrecheckInstance(ctx context.ProviderCallContext, args
 	if args.Placement != "" {
 		return fmt.Errorf("unknown placement directive: %s", args.Placement)
 	}
-	if !args.Constraints.HasInstanceType() {
+	cons := args.Constraints
+	if !cons.HasInstanceType() && !cons.HasGPUs() && !cons.HasGPUType() {
 		return nil
 	}
-	// Constraint has an instance-type constraint so let's see if it is valid.
 	instanceTypes, err := env.getInstanceTypes(ctx)
 	if err != nil {
 		return err
 	}
+	if cons.HasGPUs() || cons.HasGPUType() {
+		// GPUs come with the VM size, so make sure there's one with
+		// the GPUs asked for.
+		sizes := make([]instances.InstanceType, 0, len(instanceTypes))
+		for _, instanceType := range instanceTypes {
+			sizes = append(sizes, instanceType)
+		}
+		gpuCons := constraints.Value{
+			InstanceType: cons.InstanceType,
+			GPUs:         cons.GPUs,
+			GPUType:      cons.GPUType,
+		}
+		if _, err := instances.MatchingInstanceTypes(sizes, env.location, gpuCons); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if !cons.HasInstanceType() {
+		return nil
+	}
+	// Constraint has an instance-type constraint so let's see if it is valid.
 	for _, instanceType := range instanceTypes {
 		if instanceType.Name == *args.Constraints.InstanceType {
 			return nil
@@ -554,6 +578,10 @@ func (env *azureEnviron) StartInstance(ctx context.ProviderCallContext, args env
 		Mem:      &instanceSpec.InstanceType.Mem,
 		RootDisk: &instanceSpec.InstanceType.RootDisk,
 		CpuCores: &instanceSpec.InstanceType.CpuCores,
+		GPUs:     instanceSpec.InstanceType.GPUs,
+	}
+	if instanceSpec.InstanceType.GPUType != "" {
+		hc.GPUType = &instanceSpec.InstanceType.GPUType
 	}
 	return &environs.StartInstanceResult{
 		Instance: inst,
@@ -1388,6 +1416,12 @@ func (env *azureEnviron) deleteVirtualMachine(
 	return nil
 }
 
+// SupportsBulkInstanceQueries is part of the environs.BulkInstanceQuerier
+// interface.
+func (env *azureEnviron) SupportsBulkInstanceQueries() bool {
+	return true
+}
+
 // Instances is specified in the Environ interface.
 func (env *azureEnviron) Instances(ctx context.ProviderCallContext, ids []instance.Id) ([]instances.Instance, error) {
 	return env.instances(ctx, env.resourceGroup, ids, true /* refresh addresses */)
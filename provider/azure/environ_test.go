@@ -1599,6 +1599,20 @@ func (s *environSuite) TestConstraintsValidatorVocabulary(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches,
 		"invalid constraint value: instance-type=t1.micro\nvalid values are: \\[A1 D1 D2 Standard_A1 Standard_D1 Standard_D2\\]",
 	)
+	_, err = validator.Validate(constraints.MustParse("gpu-type=t4"))
+	c.Assert(err, gc.ErrorMatches,
+		"invalid constraint value: gpu-type=t4\nvalid values are: \\[k80 m60 p100 p40 v100\\]",
+	)
+}
+
+func (s *environSuite) TestPrecheckInstanceGPUs(c *gc.C) {
+	env := s.openEnviron(c)
+	s.sender = azuretesting.Senders{s.resourceSkusSender()}
+	err := env.PrecheckInstance(s.callCtx, environs.PrecheckInstanceParams{
+		Series:      "xenial",
+		Constraints: constraints.MustParse("gpus=1"),
+	})
+	c.Assert(err, gc.ErrorMatches, `no instance types in westus matching constraints "gpus=1"`)
 }
 
 func (s *environSuite) TestConstraintsValidatorMerge(c *gc.C) {
@@ -4,6 +4,8 @@
 package azure
 
 import (
+	"sort"
+
 	"github.com/Azure/azure-sdk-for-go/services/compute/mgmt/2018-10-01/compute"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/juju/errors"
@@ -17,6 +19,53 @@ import (
 
 const defaultMem = 1024 // 1GiB
 
+// vmSizeGPU describes the GPUs a virtual machine size comes with.
+type vmSizeGPU struct {
+	count uint64
+	model string
+}
+
+// vmSizeGPUs holds the GPUs of the GPU optimised virtual machine sizes,
+// which the Azure API doesn't report. Sizes not listed here have no
+// GPUs. See
+// https://docs.microsoft.com/en-us/azure/virtual-machines/sizes-gpu
+var vmSizeGPUs = map[string]vmSizeGPU{
+	"Standard_NC6":  {1, "k80"},
+	"Standard_NC12": {2, "k80"},
+	"Standard_NC24": {4, "k80"},
+
+	"Standard_NC6s_v2":  {1, "p100"},
+	"Standard_NC12s_v2": {2, "p100"},
+	"Standard_NC24s_v2": {4, "p100"},
+
+	"Standard_NC6s_v3":  {1, "v100"},
+	"Standard_NC12s_v3": {2, "v100"},
+	"Standard_NC24s_v3": {4, "v100"},
+
+	"Standard_ND6s":  {1, "p40"},
+	"Standard_ND12s": {2, "p40"},
+	"Standard_ND24s": {4, "p40"},
+
+	"Standard_NV6":  {1, "m60"},
+	"Standard_NV12": {2, "m60"},
+	"Standard_NV24": {4, "m60"},
+}
+
+// gpuTypes returns the models of GPU that virtual machine sizes come
+// with, sorted by name.
+func gpuTypes() []string {
+	seen := make(map[string]bool)
+	var models []string
+	for _, gpu := range vmSizeGPUs {
+		if !seen[gpu.model] {
+			seen[gpu.model] = true
+			models = append(models, gpu.model)
+		}
+	}
+	sort.Strings(models)
+	return models
+}
+
 // newInstanceType creates an InstanceType based on a VirtualMachineSize.
 func newInstanceType(size compute.VirtualMachineSize) instances.InstanceType {
 	// We're not doing real costs for now; just made-up, relative
@@ -102,6 +151,7 @@ func newInstanceType(size compute.VirtualMachineSize) instances.InstanceType {
 	}
 
 	vtype := "Hyper-V"
+	gpu := vmSizeGPUs[sizeName]
 	return instances.InstanceType{
 		Id:       sizeName,
 		Name:     sizeName,
@@ -114,6 +164,8 @@ func newInstanceType(size compute.VirtualMachineSize) instances.InstanceType {
 		RootDisk: mbToMib(uint64(to.Int32(size.OsDiskSizeInMB))),
 		Cost:     uint64(cost),
 		VirtType: &vtype,
+		GPUs:     instances.GPUs(gpu.count),
+		GPUType:  gpu.model,
 		// tags are not currently supported by azure
 	}
 }
@@ -10,6 +10,8 @@ import (
 
 var unsupportedConstraints = []string{
 	constraints.Container,
+	constraints.GPUs,
+	constraints.GPUType,
 	constraints.InstanceType,
 	constraints.Tags,
 	constraints.VirtType,
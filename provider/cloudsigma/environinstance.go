@@ -135,6 +135,12 @@ func (env *environ) instancesForMethod(ctx context.ProviderCallContext, method s
 	return instances, nil
 }
 
+// SupportsBulkInstanceQueries is part of the environs.BulkInstanceQuerier
+// interface.
+func (env *environ) SupportsBulkInstanceQueries() bool {
+	return true
+}
+
 // Instances returns a slice of instances corresponding to the
 // given instance ids.  If no instances were found, but there
 // was no other error, it will return ErrNoInstances.  If
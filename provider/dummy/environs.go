@@ -932,7 +932,7 @@ func (e *environ) Bootstrap(ctx environs.BootstrapContext, callCtx context.Provi
 				InitializedGate: initialized,
 				Logger:          loggo.GetLogger("dummy"),
 				WatcherFactory: func() modelcache.BackingWatcher {
-					return statePool.SystemState().WatchAllModels(statePool)
+					return statePool.SystemState().WatchAllModelsForCache(statePool)
 				},
 				PrometheusRegisterer: noopRegisterer{},
 				Cleanup:              func() {},
@@ -1316,6 +1316,12 @@ func (e *environ) StopInstances(ctx context.ProviderCallContext, ids ...instance
 	return nil
 }
 
+// SupportsBulkInstanceQueries is part of the environs.BulkInstanceQuerier
+// interface.
+func (e *environ) SupportsBulkInstanceQueries() bool {
+	return true
+}
+
 func (e *environ) Instances(ctx context.ProviderCallContext, ids []instance.Id) (insts []instances.Instance, err error) {
 	defer delay()
 	if err := e.checkBroken("Instances"); err != nil {
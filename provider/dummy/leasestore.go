@@ -107,6 +107,18 @@ func (s *leaseStore) ExpireLease(key lease.Key) error {
 	return nil
 }
 
+// RevokeLease is part of lease.Store.
+func (s *leaseStore) RevokeLease(key lease.Key, _ <-chan struct{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, found := s.entries[key]; !found {
+		return lease.ErrInvalid
+	}
+	delete(s.entries, key)
+	s.target.Expired(key)
+	return nil
+}
+
 // Leases is part of lease.Store.
 func (s *leaseStore) Leases(keys ...lease.Key) map[lease.Key]lease.Info {
 	s.mu.Lock()
@@ -0,0 +1,54 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/ec2"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/environs/tags"
+)
+
+var _ environs.AutoscalingGroups = (*environ)(nil)
+
+// autoscalingGroupTag is the tag which AWS gives the instances it
+// starts in an auto scaling group, set to the name of the group.
+const autoscalingGroupTag = "aws:autoscaling:groupName"
+
+// AutoscalingGroupInstances is part of the environs.AutoscalingGroups
+// interface.
+func (e *environ) AutoscalingGroupInstances(ctx context.ProviderCallContext, group string) ([]instances.Instance, error) {
+	filter := ec2.NewFilter()
+	filter.Add("instance-state-name", aliveInstanceStates...)
+	filter.Add("tag:"+autoscalingGroupTag, group)
+	insts, err := e.allInstances(ctx, filter)
+	if err != nil {
+		return nil, errors.Annotatef(err, "listing instances in auto scaling group %q", group)
+	}
+	return insts, nil
+}
+
+// AdoptAutoscalingGroupInstances is part of the
+// environs.AutoscalingGroups interface. The instances are tagged with
+// the model's UUID, but not the controller's, so that they are managed
+// with the model but left to their group if the controller is
+// destroyed.
+func (e *environ) AdoptAutoscalingGroupInstances(ctx context.ProviderCallContext, ids []instance.Id) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	resourceIds := make([]string, len(ids))
+	for i, id := range ids {
+		resourceIds[i] = string(id)
+	}
+	modelTags := map[string]string{tags.JujuModel: e.uuid()}
+	if err := tagResources(e.ec2, ctx, modelTags, resourceIds...); err != nil {
+		return errors.Annotate(maybeConvertCredentialError(err, ctx), "tagging auto scaling group instances")
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ package ec2
 
 import (
 	"fmt"
+	"regexp"
 
 	"github.com/juju/schema"
 	"gopkg.in/juju/environschema.v1"
@@ -41,6 +42,9 @@ var configDefaults = schema.Defaults{
 	"vpc-id-force": false,
 }
 
+// amiIDPattern matches the ids of Amazon Machine Images.
+var amiIDPattern = regexp.MustCompile(`^ami-[0-9a-f]{8,17}$`)
+
 type environConfig struct {
 	*config.Config
 	attrs map[string]interface{}
@@ -100,6 +104,12 @@ func validateConfig(cfg, old *config.Config) (*environConfig, error) {
 		return nil, fmt.Errorf("cannot use vpc-id-force without specifying vpc-id as well")
 	}
 
+	for _, image := range cfg.PinnedImages() {
+		if !amiIDPattern.MatchString(image.ImageID) {
+			return nil, fmt.Errorf("%s: %q is not a valid AMI ID", config.ImageIDsKey, image.ImageID)
+		}
+	}
+
 	if old != nil {
 		attrs := old.UnknownAttrs()
 
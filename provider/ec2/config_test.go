@@ -282,6 +282,15 @@ var configTests = []configTest{
 		expect: attrs{
 			"future": "hammerstein",
 		},
+	}, {
+		config: attrs{
+			"image-ids": "bionic=ami-0123456789abcdef0 xenial/us-east-1=ami-0123abcd",
+		},
+	}, {
+		config: attrs{
+			"image-ids": "bionic=img-0123abcd",
+		},
+		err: `.*image-ids: "img-0123abcd" is not a valid AMI ID`,
 	},
 }
 
@@ -189,6 +189,7 @@ func (e *environ) ConstraintsValidator(ctx context.ProviderCallContext) (constra
 	}
 
 	validator.RegisterVocabulary(constraints.InstanceType, instTypeNames)
+	validator.RegisterVocabulary(constraints.GPUType, ec2instancetypes.GPUTypes())
 	return validator, nil
 }
 
@@ -336,14 +337,31 @@ func (e *environ) PrecheckInstance(ctx context.ProviderCallContext, args environ
 	); err != nil {
 		return errors.Trace(err)
 	}
-	if !args.Constraints.HasInstanceType() {
+	cons := args.Constraints
+	if !cons.HasInstanceType() && !cons.HasGPUs() && !cons.HasGPUType() {
 		return nil
 	}
-	// Constraint has an instance-type constraint so let's see if it is valid.
 	instanceTypes, err := e.supportedInstanceTypes(ctx)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if cons.HasGPUs() || cons.HasGPUType() {
+		// GPUs come with the instance type, so make sure there's one
+		// with the GPUs asked for.
+		gpuCons := constraints.Value{
+			Arch:         cons.Arch,
+			InstanceType: cons.InstanceType,
+			GPUs:         cons.GPUs,
+			GPUType:      cons.GPUType,
+		}
+		if _, err := instances.MatchingInstanceTypes(instanceTypes, e.cloud.Region, gpuCons); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if !cons.HasInstanceType() {
+		return nil
+	}
+	// Constraint has an instance-type constraint so let's see if it is valid.
 	for _, itype := range instanceTypes {
 		if itype.Name != *args.Constraints.InstanceType {
 			continue
@@ -605,7 +623,10 @@ func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 		logger.Debugf("selected subnet %q in zone %q", runArgs.SubnetId, availabilityZone)
 	}
 
-	callback(status.Allocating, fmt.Sprintf("Trying to start instance in availability zone %q", availabilityZone), nil)
+	callback(status.Allocating, fmt.Sprintf("Trying to start instance in availability zone %q", availabilityZone), map[string]interface{}{
+		status.InstanceTypeKey:     spec.InstanceType.Name,
+		status.AvailabilityZoneKey: availabilityZone,
+	})
 	instResp, err = runInstances(e.ec2, ctx, runArgs, callback)
 	if err != nil {
 		if !isZoneOrSubnetConstrainedError(err) {
@@ -659,9 +680,13 @@ func (e *environ) StartInstance(ctx context.ProviderCallContext, args environs.S
 		CpuCores: &spec.InstanceType.CpuCores,
 		CpuPower: spec.InstanceType.CpuPower,
 		RootDisk: &rootDiskSize,
+		GPUs:     spec.InstanceType.GPUs,
 		// Tags currently not supported by EC2
 		AvailabilityZone: &inst.Instance.AvailZone,
 	}
+	if spec.InstanceType.GPUType != "" {
+		hc.GPUType = &spec.InstanceType.GPUType
+	}
 	return &environs.StartInstanceResult{
 		Instance: inst,
 		Hardware: &hc,
@@ -895,6 +920,12 @@ func isNotFoundError(err error) bool {
 	return err != nil && (errors.IsNotFound(err) || ec2ErrCode(err) == "InvalidGroup.NotFound")
 }
 
+// SupportsBulkInstanceQueries is part of the environs.BulkInstanceQuerier
+// interface.
+func (e *environ) SupportsBulkInstanceQueries() bool {
+	return true
+}
+
 // Instances is part of the environs.Environ interface.
 func (e *environ) Instances(ctx context.ProviderCallContext, ids []instance.Id) ([]instances.Instance, error) {
 	if len(ids) == 0 {
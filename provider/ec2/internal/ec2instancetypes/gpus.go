@@ -0,0 +1,73 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2instancetypes
+
+import (
+	"sort"
+
+	"github.com/juju/juju/environs/instances"
+)
+
+// gpu describes the GPUs an instance type comes with.
+type gpu struct {
+	count uint64
+	model string
+}
+
+// instanceTypeGPUs holds the GPUs of the accelerated computing instance
+// types. The pricing data the instance types are generated from doesn't
+// describe them in a form we can use, so they're maintained by hand.
+// Instance types not listed here have no GPUs. See
+// https://aws.amazon.com/ec2/instance-types/#Accelerated_Computing
+var instanceTypeGPUs = map[string]gpu{
+	"g2.2xlarge": {1, "k520"},
+	"g2.8xlarge": {4, "k520"},
+
+	"g3s.xlarge":  {1, "m60"},
+	"g3.4xlarge":  {1, "m60"},
+	"g3.8xlarge":  {2, "m60"},
+	"g3.16xlarge": {4, "m60"},
+
+	"g4dn.xlarge":   {1, "t4"},
+	"g4dn.2xlarge":  {1, "t4"},
+	"g4dn.4xlarge":  {1, "t4"},
+	"g4dn.8xlarge":  {1, "t4"},
+	"g4dn.16xlarge": {1, "t4"},
+	"g4dn.12xlarge": {4, "t4"},
+	"g4dn.metal":    {8, "t4"},
+
+	"p2.xlarge":   {1, "k80"},
+	"p2.8xlarge":  {8, "k80"},
+	"p2.16xlarge": {16, "k80"},
+
+	"p3.2xlarge":    {1, "v100"},
+	"p3.8xlarge":    {4, "v100"},
+	"p3.16xlarge":   {8, "v100"},
+	"p3dn.24xlarge": {8, "v100"},
+}
+
+// GPUTypes returns the models of GPU that instance types come with,
+// sorted by name.
+func GPUTypes() []string {
+	seen := make(map[string]bool)
+	var models []string
+	for _, gpu := range instanceTypeGPUs {
+		if !seen[gpu.model] {
+			seen[gpu.model] = true
+			models = append(models, gpu.model)
+		}
+	}
+	sort.Strings(models)
+	return models
+}
+
+func init() {
+	for _, instanceTypes := range allInstanceTypes {
+		for i := range instanceTypes {
+			gpu := instanceTypeGPUs[instanceTypes[i].Name]
+			instanceTypes[i].GPUs = instances.GPUs(gpu.count)
+			instanceTypes[i].GPUType = gpu.model
+		}
+	}
+}
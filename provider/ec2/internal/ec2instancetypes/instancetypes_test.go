@@ -4,6 +4,8 @@
 package ec2instancetypes_test
 
 import (
+	"fmt"
+
 	"github.com/juju/collections/set"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -90,6 +92,24 @@ func (s *InstanceTypesSuite) TestRegionInstanceTypesUnknownRegion(c *gc.C) {
 	c.Assert(instanceTypes, jc.DeepEquals, ec2instancetypes.RegionInstanceTypes("us-east-1"))
 }
 
+func (s *InstanceTypesSuite) TestRegionInstanceTypesGPUs(c *gc.C) {
+	gpus := make(map[string]string)
+	for _, instanceType := range ec2instancetypes.RegionInstanceTypes("us-east-1") {
+		c.Assert(instanceType.GPUs, gc.NotNil)
+		if *instanceType.GPUs > 0 {
+			gpus[instanceType.Name] = fmt.Sprintf("%d %s", *instanceType.GPUs, instanceType.GPUType)
+		}
+	}
+	c.Assert(gpus["p2.8xlarge"], gc.Equals, "8 k80")
+	c.Assert(gpus["p3.2xlarge"], gc.Equals, "1 v100")
+	c.Assert(gpus["g3.16xlarge"], gc.Equals, "4 m60")
+	c.Assert(gpus["m4.large"], gc.Equals, "")
+}
+
+func (s *InstanceTypesSuite) TestGPUTypes(c *gc.C) {
+	c.Assert(ec2instancetypes.GPUTypes(), jc.DeepEquals, []string{"k520", "k80", "m60", "t4", "v100"})
+}
+
 func (s *InstanceTypesSuite) TestSupportsClassic(c *gc.C) {
 	assertSupportsClassic := func(name string) {
 		c.Assert(ec2instancetypes.SupportsClassic(name), jc.IsTrue)
@@ -1474,6 +1474,36 @@ func (t *localServerSuite) TestPrecheckInstanceUnsupportedArch(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `invalid AWS instance type "cc1.4xlarge" and arch "i386" specified`)
 }
 
+func (t *localServerSuite) TestPrecheckInstanceGPUs(c *gc.C) {
+	env := t.Prepare(c)
+	cons := constraints.MustParse("gpus=4 gpu-type=k520")
+	err := env.PrecheckInstance(t.callCtx, environs.PrecheckInstanceParams{
+		Series:      series.DefaultSupportedLTS(),
+		Constraints: cons,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (t *localServerSuite) TestPrecheckInstanceTooManyGPUs(c *gc.C) {
+	env := t.Prepare(c)
+	cons := constraints.MustParse("gpus=32")
+	err := env.PrecheckInstance(t.callCtx, environs.PrecheckInstanceParams{
+		Series:      series.DefaultSupportedLTS(),
+		Constraints: cons,
+	})
+	c.Assert(err, gc.ErrorMatches, `no instance types in .* matching constraints "gpus=32"`)
+}
+
+func (t *localServerSuite) TestPrecheckInstanceInstanceTypeWithoutGPUs(c *gc.C) {
+	env := t.Prepare(c)
+	cons := constraints.MustParse("instance-type=m1.small gpus=1")
+	err := env.PrecheckInstance(t.callCtx, environs.PrecheckInstanceParams{
+		Series:      series.DefaultSupportedLTS(),
+		Constraints: cons,
+	})
+	c.Assert(err, gc.ErrorMatches, `no instance types in .* matching constraints "gpus=1 instance-type=m1.small"`)
+}
+
 func (t *localServerSuite) TestPrecheckInstanceAvailZone(c *gc.C) {
 	env := t.Prepare(c)
 	placement := "zone=test-available"
@@ -1871,6 +1901,32 @@ func (t *localServerSuite) TestRootDiskTags(c *gc.C) {
 	})
 }
 
+func (t *localServerSuite) TestAutoscalingGroupInstances(c *gc.C) {
+	env := t.Prepare(c)
+	groups, ok := env.(environs.AutoscalingGroups)
+	c.Assert(ok, jc.IsTrue)
+
+	ids := t.srv.ec2srv.NewInstances(2, "m1.small", "ami-a7f539ce", ec2test.Running, nil)
+	_, err := t.client.CreateTags(ids[:1], []amzec2.Tag{{"aws:autoscaling:groupName", "web"}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	insts, err := groups.AutoscalingGroupInstances(t.callCtx, "web")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(insts, gc.HasLen, 1)
+	c.Assert(insts[0].Id(), gc.Equals, instance.Id(ids[0]))
+
+	// The instance is only part of the model once it has been adopted.
+	_, err = env.Instances(t.callCtx, []instance.Id{instance.Id(ids[0])})
+	c.Assert(err, gc.Equals, environs.ErrNoInstances)
+
+	err = groups.AdoptAutoscalingGroupInstances(t.callCtx, []instance.Id{instance.Id(ids[0])})
+	c.Assert(err, jc.ErrorIsNil)
+	insts, err = env.Instances(t.callCtx, []instance.Id{instance.Id(ids[0])})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(insts, gc.HasLen, 1)
+	c.Assert(insts[0].Id(), gc.Equals, instance.Id(ids[0]))
+}
+
 func (s *localServerSuite) TestBootstrapInstanceConstraints(c *gc.C) {
 	env := s.prepareAndBootstrap(c)
 	inst, err := env.AllRunningInstances(s.callCtx)
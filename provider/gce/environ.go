@@ -31,6 +31,9 @@ type gceConnection interface {
 	AddInstance(spec google.InstanceSpec) (*google.Instance, error)
 	RemoveInstances(prefix string, ids ...string) error
 	UpdateMetadata(key, value string, ids ...string) error
+	// InstanceConsoleOutput returns the output written to the serial
+	// console of the given instance.
+	InstanceConsoleOutput(id, zone string) (string, error)
 
 	IngressRules(fwname string) ([]network.IngressRule, error)
 	OpenPorts(fwname string, rules ...network.IngressRule) error
@@ -86,6 +89,7 @@ type environ struct {
 
 var _ environs.Environ = (*environ)(nil)
 var _ environs.NetworkingEnviron = (*environ)(nil)
+var _ environs.InstanceConsoleOutputGetter = (*environ)(nil)
 
 // Function entry points defined as variables so they can be overridden
 // for testing purposes.
@@ -108,7 +108,18 @@ func (env *environ) buildInstanceSpec(args environs.StartInstanceParams) (*insta
 		},
 		args.ImageMetadata,
 	)
-	return spec, errors.Trace(err)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if args.Constraints.HasGPUs() {
+		// GPUs are attached to the instance rather than coming
+		// with its machine type.
+		spec.InstanceType.GPUs = args.Constraints.GPUs
+		if args.Constraints.HasGPUType() {
+			spec.InstanceType.GPUType = *args.Constraints.GPUType
+		}
+	}
+	return spec, nil
 }
 
 var findInstanceSpec = func(
@@ -192,6 +203,17 @@ func (env *environ) newRawInstance(ctx context.ProviderCallContext, args environ
 		return nil, common.ZoneIndependentError(err)
 	}
 
+	var accelerators int64
+	var acceleratorType string
+	if spec.InstanceType.GPUs != nil && *spec.InstanceType.GPUs > 0 {
+		var ok bool
+		acceleratorType, ok = acceleratorTypes[spec.InstanceType.GPUType]
+		if !ok {
+			return nil, common.ZoneIndependentError(errors.NotValidf("GPU type %q", spec.InstanceType.GPUType))
+		}
+		accelerators = int64(*spec.InstanceType.GPUs)
+	}
+
 	// TODO(ericsnow) Use the env ID for the network name (instead of default)?
 	// TODO(ericsnow) Make the network name configurable?
 	// TODO(ericsnow) Support multiple networks?
@@ -204,6 +226,8 @@ func (env *environ) newRawInstance(ctx context.ProviderCallContext, args environ
 		Metadata:          metadata,
 		Tags:              tags,
 		AvailabilityZone:  args.AvailabilityZone,
+		Accelerators:      accelerators,
+		AcceleratorType:   acceleratorType,
 		// Network is omitted (left empty).
 	})
 	if err != nil {
@@ -292,9 +316,13 @@ func (env *environ) getHardwareCharacteristics(spec *instances.InstanceSpec, ins
 		CpuCores:         &spec.InstanceType.CpuCores,
 		CpuPower:         spec.InstanceType.CpuPower,
 		RootDisk:         &rootDiskMB,
+		GPUs:             spec.InstanceType.GPUs,
 		AvailabilityZone: &inst.base.ZoneName,
 		// Tags: not supported in GCE.
 	}
+	if spec.InstanceType.GPUType != "" {
+		hwc.GPUType = &spec.InstanceType.GPUType
+	}
 	return &hwc
 }
 
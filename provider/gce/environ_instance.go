@@ -26,6 +26,12 @@ var instStatuses = []string{
 	google.StatusRunning,
 }
 
+// SupportsBulkInstanceQueries is part of the environs.BulkInstanceQuerier
+// interface.
+func (env *environ) SupportsBulkInstanceQueries() bool {
+	return true
+}
+
 // Instances returns the available instances in the environment that
 // match the provided instance IDs. For IDs that did not match any
 // instances, the result at the corresponding index will be nil. In that
@@ -103,6 +109,22 @@ func (env *environ) instances(ctx context.ProviderCallContext, statusFilters ...
 	return results, err
 }
 
+// InstanceConsoleOutput implements environs.InstanceConsoleOutputGetter.
+func (env *environ) InstanceConsoleOutput(ctx context.ProviderCallContext, id instance.Id) (string, error) {
+	all, err := env.gceInstances(ctx)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	for _, inst := range all {
+		if inst.ID != string(id) {
+			continue
+		}
+		output, err := env.gce.InstanceConsoleOutput(inst.ID, inst.ZoneName)
+		return output, google.HandleCredentialError(errors.Trace(err), ctx)
+	}
+	return "", errors.NotFoundf("instance %q", id)
+}
+
 // ControllerInstances returns the IDs of the instances corresponding
 // to juju controllers.
 func (env *environ) ControllerInstances(ctx context.ProviderCallContext, controllerUUID string) ([]instance.Id, error) {
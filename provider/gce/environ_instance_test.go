@@ -102,6 +102,27 @@ func (s *environInstSuite) TestBasicInstancesAPI(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].Statuses, jc.DeepEquals, []string{google.StatusPending, google.StatusStaging, google.StatusRunning})
 }
 
+func (s *environInstSuite) TestInstanceConsoleOutput(c *gc.C) {
+	s.FakeConn.Insts = []google.Instance{*s.BaseInstance}
+	s.FakeConn.ConsoleOutput = "Cloud-init v. 19.4 finished"
+
+	output, err := s.Env.InstanceConsoleOutput(s.CallCtx, "spam")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(output, gc.Equals, "Cloud-init v. 19.4 finished")
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "InstanceConsoleOutput")
+	c.Check(s.FakeConn.Calls[1].ID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[1].ZoneName, gc.Equals, "home-zone")
+}
+
+func (s *environInstSuite) TestInstanceConsoleOutputNotFound(c *gc.C) {
+	s.FakeConn.Insts = []google.Instance{*s.BaseInstance}
+
+	_, err := s.Env.InstanceConsoleOutput(s.CallCtx, "ham")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func (s *environInstSuite) TestControllerInstances(c *gc.C) {
 	s.FakeConn.Insts = []google.Instance{*s.BaseInstance}
 
@@ -4,6 +4,8 @@
 package gce
 
 import (
+	"sort"
+
 	"github.com/juju/errors"
 
 	"github.com/juju/juju/core/constraints"
@@ -27,6 +29,9 @@ func (env *environ) PrecheckInstance(ctx context.ProviderCallContext, args envir
 			return errors.Errorf("invalid GCE instance type %q", *args.Constraints.InstanceType)
 		}
 	}
+	if args.Constraints.HasGPUs() && !args.Constraints.HasGPUType() {
+		return errors.New("gpu-type must be specified with gpus")
+	}
 
 	return nil
 }
@@ -73,6 +78,13 @@ func (env *environ) ConstraintsValidator(ctx context.ProviderCallContext) (const
 
 	validator.RegisterVocabulary(constraints.Container, []string{vtype})
 
+	gpuTypes := make([]string, 0, len(acceleratorTypes))
+	for gpuType := range acceleratorTypes {
+		gpuTypes = append(gpuTypes, gpuType)
+	}
+	sort.Strings(gpuTypes)
+	validator.RegisterVocabulary(constraints.GPUType, gpuTypes)
+
 	return validator, nil
 }
 
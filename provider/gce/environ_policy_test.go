@@ -58,6 +58,20 @@ func (s *environPolSuite) TestPrecheckInstanceInvalidInstanceType(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `.*invalid GCE instance type.*`)
 }
 
+func (s *environPolSuite) TestPrecheckInstanceGPUs(c *gc.C) {
+	cons := constraints.MustParse("gpus=2 gpu-type=k80")
+	err := s.Env.PrecheckInstance(s.CallCtx, environs.PrecheckInstanceParams{Series: series.DefaultSupportedLTS(), Constraints: cons})
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *environPolSuite) TestPrecheckInstanceGPUsWithoutType(c *gc.C) {
+	cons := constraints.MustParse("gpus=2")
+	err := s.Env.PrecheckInstance(s.CallCtx, environs.PrecheckInstanceParams{Series: series.DefaultSupportedLTS(), Constraints: cons})
+
+	c.Check(err, gc.ErrorMatches, `gpu-type must be specified with gpus`)
+}
+
 func (s *environPolSuite) TestPrecheckInstanceDiskSize(c *gc.C) {
 	cons := constraints.MustParse("instance-type=n1-standard-1 root-disk=1G")
 	placement := ""
@@ -52,6 +52,10 @@ type service interface {
 	// completed or fails.
 	SetMetadata(projectID, zone, instanceID string, metadata *compute.Metadata) error
 
+	// GetSerialPortOutput sends a request to the GCE API for the
+	// output written to the serial console of the specified instance.
+	GetSerialPortOutput(projectID, zone, id string) (string, error)
+
 	// GetFirewalls sends an API request to GCE for the information about
 	// the firewalls with the namePrefix and returns them.
 	// If no firewalls are not found, errors.NotFound is returned.
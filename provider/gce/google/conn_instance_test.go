@@ -104,6 +104,24 @@ func (s *instanceSuite) TestConnectionAddInstanceAPI(c *gc.C) {
 	})
 }
 
+func (s *instanceSuite) TestConnectionAddInstanceAccelerators(c *gc.C) {
+	s.FakeConn.Instance = &s.RawInstanceFull
+	s.InstanceSpec.Accelerators = 2
+	s.InstanceSpec.AcceleratorType = "nvidia-tesla-k80"
+
+	_, err := s.Conn.AddInstance(s.InstanceSpec)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].InstValue.GuestAccelerators, jc.DeepEquals, []*compute.AcceleratorConfig{{
+		AcceleratorCount: 2,
+		AcceleratorType:  "zones/a-zone/acceleratorTypes/nvidia-tesla-k80",
+	}})
+	c.Check(s.FakeConn.Calls[0].InstValue.Scheduling, jc.DeepEquals, &compute.Scheduling{
+		OnHostMaintenance: "TERMINATE",
+	})
+}
+
 func (s *connSuite) TestConnectionAddInstanceFailed(c *gc.C) {
 	s.FakeConn.Instance = &s.RawInstanceFull
 
@@ -155,6 +173,20 @@ func (s *connSuite) TestConnectionInstance(c *gc.C) {
 	c.Check(spec, gc.IsNil)
 }
 
+func (s *connSuite) TestConnectionInstanceConsoleOutput(c *gc.C) {
+	s.FakeConn.ConsoleOutput = "Cloud-init v. 19.4 running 'init-local'"
+
+	output, err := s.Conn.InstanceConsoleOutput("ham", "a-zone")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(output, gc.Equals, "Cloud-init v. 19.4 running 'init-local'")
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetSerialPortOutput")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].ID, gc.Equals, "ham")
+	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "a-zone")
+}
+
 func (s *connSuite) TestConnectionInstanceAPI(c *gc.C) {
 	s.FakeConn.Instance = &s.RawInstanceFull
 
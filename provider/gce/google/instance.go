@@ -52,10 +52,18 @@ type InstanceSpec struct {
 	// AvailabilityZone holds the name of the availability zone in which
 	// to create the instance.
 	AvailabilityZone string
+
+	// Accelerators is the number of GPUs to attach to the instance.
+	Accelerators int64
+
+	// AcceleratorType is the name of the GCE accelerator type of the
+	// GPUs to attach, such as "nvidia-tesla-k80". The value is resolved
+	// relative to an availability zone when the API request is sent.
+	AcceleratorType string
 }
 
 func (is InstanceSpec) raw() *compute.Instance {
-	return &compute.Instance{
+	raw := &compute.Instance{
 		Name:              is.ID,
 		Disks:             is.disks(),
 		NetworkInterfaces: is.networkInterfaces(),
@@ -63,6 +71,17 @@ func (is InstanceSpec) raw() *compute.Instance {
 		Tags:              &compute.Tags{Items: is.Tags},
 		// MachineType is set in the addInstance call.
 	}
+	if is.Accelerators > 0 {
+		raw.GuestAccelerators = []*compute.AcceleratorConfig{{
+			AcceleratorCount: is.Accelerators,
+			AcceleratorType:  formatAcceleratorType(is.AvailabilityZone, is.AcceleratorType),
+		}}
+		// Instances with GPUs can't be live migrated.
+		raw.Scheduling = &compute.Scheduling{
+			OnHostMaintenance: "TERMINATE",
+		}
+	}
+	return raw
 }
 
 // Summary builds an InstanceSummary based on the spec and returns it.
@@ -240,3 +259,7 @@ func unpackMetadata(data *compute.Metadata) map[string]string {
 func formatMachineType(zone, name string) string {
 	return fmt.Sprintf("zones/%s/machineTypes/%s", zone, name)
 }
+
+func formatAcceleratorType(zone, name string) string {
+	return fmt.Sprintf("zones/%s/acceleratorTypes/%s", zone, name)
+}
@@ -54,6 +54,15 @@ func (rc *rawConn) GetInstance(projectID, zone, id string) (*compute.Instance, e
 	return inst, errors.Trace(err)
 }
 
+func (rc *rawConn) GetSerialPortOutput(projectID, zone, id string) (string, error) {
+	call := rc.Instances.GetSerialPortOutput(projectID, zone, id)
+	output, err := call.Do()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return output.Contents, nil
+}
+
 func (rc *rawConn) ListInstances(projectID, prefix string, statuses ...string) ([]*compute.Instance, error) {
 	call := rc.Instances.AggregatedList(projectID)
 	call = call.Filter("name eq " + prefix + ".*")
@@ -14,8 +14,19 @@ var (
 	arches = []string{arch.AMD64}
 )
 
+// acceleratorTypes maps the GPU types which may be attached to GCE
+// instances to the names of the GCE accelerator types.
+var acceleratorTypes = map[string]string{
+	"k80":  "nvidia-tesla-k80",
+	"p100": "nvidia-tesla-p100",
+	"p4":   "nvidia-tesla-p4",
+	"t4":   "nvidia-tesla-t4",
+	"v100": "nvidia-tesla-v100",
+}
+
 // Instance types are not associated with disks in GCE, so we do not
-// set RootDisk.
+// set RootDisk. GPUs are attached to instances of any type, so we do
+// not set GPUs either.
 
 // TODO(axw) 2016-10-03 #1629821
 // Query the machine types dynamically, to avoid hard-coding this
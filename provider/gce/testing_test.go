@@ -518,6 +518,7 @@ type fakeConn struct {
 	GoogleDisk    *google.Disk
 	AttachedDisk  *google.AttachedDisk
 	AttachedDisks []*google.AttachedDisk
+	ConsoleOutput string
 
 	Err        error
 	FailOnCall int
@@ -555,6 +556,15 @@ func (fc *fakeConn) Instances(prefix string, statuses ...string) ([]google.Insta
 	return fc.Insts, fc.err()
 }
 
+func (fc *fakeConn) InstanceConsoleOutput(id, zone string) (string, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "InstanceConsoleOutput",
+		ID:       id,
+		ZoneName: zone,
+	})
+	return fc.ConsoleOutput, fc.err()
+}
+
 func (fc *fakeConn) AddInstance(spec google.InstanceSpec) (*google.Instance, error) {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName:     "AddInstance",
@@ -47,6 +47,8 @@ func newCompute(cloud environs.CloudSpec) (*joyentCompute, error) {
 
 var unsupportedConstraints = []string{
 	constraints.CpuPower,
+	constraints.GPUs,
+	constraints.GPUType,
 	constraints.Tags,
 	constraints.VirtType,
 }
@@ -233,6 +235,12 @@ func (env *joyentEnviron) filteredInstances(ctx context.ProviderCallContext, sta
 	return instances, nil
 }
 
+// SupportsBulkInstanceQueries is part of the environs.BulkInstanceQuerier
+// interface.
+func (env *joyentEnviron) SupportsBulkInstanceQueries() bool {
+	return true
+}
+
 func (env *joyentEnviron) Instances(ctx context.ProviderCallContext, ids []instance.Id) ([]instances.Instance, error) {
 	if len(ids) == 0 {
 		return nil, nil
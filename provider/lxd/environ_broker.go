@@ -8,7 +8,6 @@ import (
 	"strings"
 
 	"github.com/juju/errors"
-	"github.com/juju/utils/arch"
 
 	"github.com/juju/juju/cloudconfig/cloudinit"
 	"github.com/juju/juju/cloudconfig/instancecfg"
@@ -53,7 +52,7 @@ func (env *environ) StartInstance(
 	inst := newInstance(container, env)
 
 	// Build the result.
-	hwc := env.getHardwareCharacteristics(args, inst)
+	hwc := inst.hardwareCharacteristics()
 	result := environs.StartInstanceResult{
 		Instance: inst,
 		Hardware: hwc,
@@ -291,26 +290,6 @@ func (env *environ) parsePlacement(ctx context.ProviderCallContext, placement st
 	return &lxdPlacement{nodeName: node}, nil
 }
 
-// getHardwareCharacteristics compiles hardware-related details about
-// the given instance and relative to the provided spec and returns it.
-func (env *environ) getHardwareCharacteristics(
-	args environs.StartInstanceParams, inst *environInstance,
-) *instance.HardwareCharacteristics {
-	container := inst.container
-
-	archStr := container.Arch()
-	if archStr == "unknown" || !arch.IsSupportedArch(archStr) {
-		archStr = env.server().HostArch()
-	}
-	cores := uint64(container.CPUs())
-	mem := uint64(container.Mem())
-	return &instance.HardwareCharacteristics{
-		Arch:     &archStr,
-		CpuCores: &cores,
-		Mem:      &mem,
-	}
-}
-
 // AllInstances implements environs.InstanceBroker.
 func (env *environ) AllInstances(ctx context.ProviderCallContext) ([]instances.Instance, error) {
 	environInstances, err := env.allInstances()
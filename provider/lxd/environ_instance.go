@@ -16,6 +16,12 @@ import (
 	"github.com/juju/juju/provider/common"
 )
 
+// SupportsBulkInstanceQueries is part of the environs.BulkInstanceQuerier
+// interface.
+func (env *environ) SupportsBulkInstanceQueries() bool {
+	return true
+}
+
 // Instances returns the available instances in the environment that
 // match the provided instance IDs. For IDs that did not match any
 // instances, the result at the corresponding index will be nil. In that
@@ -20,6 +20,8 @@ func (env *environ) PrecheckInstance(ctx context.ProviderCallContext, args envir
 
 var unsupportedConstraints = []string{
 	constraints.CpuPower,
+	constraints.GPUs,
+	constraints.GPUType,
 	constraints.Tags,
 	constraints.VirtType,
 	constraints.Container,
@@ -5,6 +5,7 @@ package lxd
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/utils/arch"
 	"github.com/lxc/lxd/shared/api"
 
 	"github.com/juju/juju/container/lxd"
@@ -20,7 +21,10 @@ type environInstance struct {
 	env       *environ
 }
 
-var _ instances.Instance = (*environInstance)(nil)
+var (
+	_ instances.Instance         = (*environInstance)(nil)
+	_ instances.HardwareReporter = (*environInstance)(nil)
+)
 
 func newInstance(container *lxd.Container, env *environ) *environInstance {
 	return &environInstance{
@@ -60,3 +64,28 @@ func (i *environInstance) Addresses(_ context.ProviderCallContext) (network.Prov
 	addrs, err := i.env.server().ContainerAddresses(i.container.Name)
 	return addrs, errors.Trace(err)
 }
+
+// HardwareCharacteristics implements instances.HardwareReporter.
+// The characteristics reflect the container's current limits, so
+// they change when the container is resized.
+func (i *environInstance) HardwareCharacteristics(_ context.ProviderCallContext) (*instance.HardwareCharacteristics, error) {
+	return i.hardwareCharacteristics(), nil
+}
+
+// hardwareCharacteristics compiles hardware-related details about
+// the instance's container and returns them.
+func (i *environInstance) hardwareCharacteristics() *instance.HardwareCharacteristics {
+	container := i.container
+
+	archStr := container.Arch()
+	if archStr == "unknown" || !arch.IsSupportedArch(archStr) {
+		archStr = i.env.server().HostArch()
+	}
+	cores := uint64(container.CPUs())
+	mem := uint64(container.Mem())
+	return &instance.HardwareCharacteristics{
+		Arch:     &archStr,
+		CpuCores: &cores,
+		Mem:      &mem,
+	}
+}
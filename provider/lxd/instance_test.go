@@ -5,6 +5,7 @@ package lxd_test
 
 import (
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/arch"
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/core/instance"
@@ -46,3 +47,12 @@ func (s *instanceSuite) TestAddresses(c *gc.C) {
 
 	c.Check(addresses, jc.DeepEquals, s.Addresses)
 }
+
+func (s *instanceSuite) TestHardwareCharacteristics(c *gc.C) {
+	s.Client.ServerHostArch = arch.ARM64
+
+	hwc, err := s.Instance.HardwareCharacteristics(context.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(hwc, jc.DeepEquals, s.HWC)
+}
@@ -19,6 +19,8 @@ import (
 
 var unsupportedConstraints = []string{
 	constraints.CpuPower,
+	constraints.GPUs,
+	constraints.GPUType,
 	constraints.InstanceType,
 	constraints.VirtType,
 }
@@ -1518,6 +1518,12 @@ func (env *maasEnviron) StopInstances(ctx context.ProviderCallContext, ids ...in
 
 }
 
+// SupportsBulkInstanceQueries is part of the environs.BulkInstanceQuerier
+// interface.
+func (env *maasEnviron) SupportsBulkInstanceQueries() bool {
+	return true
+}
+
 // Instances returns the instances.Instance objects corresponding to the given
 // slice of instance.Id.  The error is ErrNoInstances if no instances
 // were found.
@@ -328,6 +328,8 @@ func (e *manualEnviron) PrecheckInstance(ctx context.ProviderCallContext, params
 
 var unsupportedConstraints = []string{
 	constraints.CpuPower,
+	constraints.GPUs,
+	constraints.GPUType,
 	constraints.InstanceType,
 	constraints.Tags,
 	constraints.VirtType,
@@ -329,6 +329,8 @@ func (e *Environ) ConstraintsValidator(ctx envcontext.ProviderCallContext) (cons
 	// list of unsupported OCI provider constraints
 	unsupportedConstraints := []string{
 		constraints.Container,
+		constraints.GPUs,
+		constraints.GPUType,
 		constraints.VirtType,
 		constraints.Tags,
 	}
@@ -543,6 +543,8 @@ func (e *Environ) neutron() *neutron.Client {
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.CpuPower,
+	constraints.GPUs,
+	constraints.GPUType,
 }
 
 // ConstraintsValidator is defined on the Environs interface.
@@ -1609,6 +1611,12 @@ func (e *Environ) updateFloatingIPAddresses(ctx context.ProviderCallContext, ins
 	return nil
 }
 
+// SupportsBulkInstanceQueries is part of the environs.BulkInstanceQuerier
+// interface.
+func (e *Environ) SupportsBulkInstanceQueries() bool {
+	return true
+}
+
 func (e *Environ) Instances(ctx context.ProviderCallContext, ids []instance.Id) ([]instances.Instance, error) {
 	if len(ids) == 0 {
 		return nil, nil
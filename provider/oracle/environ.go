@@ -660,6 +660,8 @@ func (o *OracleEnviron) ConstraintsValidator(ctx context.ProviderCallContext) (c
 	unsupportedConstraints := []string{
 		constraints.Container,
 		constraints.CpuPower,
+		constraints.GPUs,
+		constraints.GPUType,
 		constraints.RootDisk,
 		constraints.VirtType,
 	}
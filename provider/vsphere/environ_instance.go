@@ -15,6 +15,12 @@ import (
 	"github.com/juju/juju/environs/tags"
 )
 
+// SupportsBulkInstanceQueries is part of the environs.BulkInstanceQuerier
+// interface.
+func (env *environ) SupportsBulkInstanceQueries() bool {
+	return true
+}
+
 // Instances is part of the environs.Environ interface.
 func (env *environ) Instances(ctx context.ProviderCallContext, ids []instance.Id) (instances []instances.Instance, err error) {
 	if len(ids) == 0 {
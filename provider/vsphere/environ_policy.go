@@ -94,6 +94,8 @@ func (env *sessionEnviron) checkExtendPermissions(ctx context.ProviderCallContex
 }
 
 var unsupportedConstraints = []string{
+	constraints.GPUs,
+	constraints.GPUType,
 	constraints.Tags,
 	constraints.VirtType,
 }
@@ -22,3 +22,15 @@ type ConfigChangedMessage struct {
 	// different machines, and the forwarding of those messages cross each other.
 	// Adding a version could allow subscribers to ignore lower versioned messages.
 }
+
+// RaftSnapshotRequested messages are published by the Leases facade
+// to ask the raft worker on every controller machine to snapshot the
+// lease store, compacting its raft log.
+// data: `RaftSnapshotRequestedMessage`
+const RaftSnapshotRequested = "controller.raft-snapshot-requested"
+
+// RaftSnapshotRequestedMessage identifies the user who asked for the
+// raft snapshot, so the request can be logged.
+type RaftSnapshotRequestedMessage struct {
+	Requester string
+}
@@ -100,8 +100,10 @@ func newResource(c *gc.C, name, username, data string) (resource.Resource, param
 type stubFacade struct {
 	basetesting.StubFacadeCaller
 
-	apiResults map[string]params.ResourcesResult
-	pendingIDs []string
+	apiResults   map[string]params.ResourcesResult
+	pendingIDs   []string
+	apiHistory   params.ResourceHistoryResult
+	rollbackErrs []*params.Error
 }
 
 func newStubFacade(c *gc.C, stub *testing.Stub) *stubFacade {
@@ -134,6 +136,18 @@ func newStubFacade(c *gc.C, stub *testing.Stub) *stubFacade {
 			}
 		case *params.AddPendingResourcesResult:
 			typedResponse.PendingIDs = s.pendingIDs
+		case *params.ResourceHistoryResults:
+			typedResponse.Results = append(typedResponse.Results, s.apiHistory)
+		case *params.ErrorResults:
+			typedArgs, ok := args.(*params.ResourceRevisionArgs)
+			c.Assert(ok, jc.IsTrue)
+			for i := range typedArgs.Args {
+				var result params.ErrorResult
+				if i < len(s.rollbackErrs) {
+					result.Error = s.rollbackErrs[i]
+				}
+				typedResponse.Results = append(typedResponse.Results, result)
+			}
 		default:
 			c.Errorf("bad type %T", response)
 		}
@@ -105,6 +105,85 @@ func newListResourcesArgs(applications []string) (params.ListResourcesArgs, erro
 	return args, nil
 }
 
+// ResourceHistory calls the ResourceHistory API server method for the
+// named resource of the application. The revisions of the content
+// attached to the resource are returned, newest first.
+func (c Client) ResourceHistory(application, name string) ([]resource.AttachedRevision, error) {
+	arg, err := newResourceRevisionArg(application, name, 0)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	args := params.ResourceRevisionArgs{Args: []params.ResourceRevisionArg{arg}}
+
+	var apiResults params.ResourceHistoryResults
+	if err := c.FacadeCall("ResourceHistory", &args, &apiResults); err != nil {
+		if params.IsCodeNotImplemented(err) {
+			return nil, errors.NotSupportedf("resource history on this controller")
+		}
+		return nil, errors.Trace(err)
+	}
+	if len(apiResults.Results) != 1 {
+		return nil, errors.Errorf("got invalid data from server (expected 1 result, got %d)", len(apiResults.Results))
+	}
+	apiResult := apiResults.Results[0]
+	if apiResult.Error != nil {
+		return nil, errors.Trace(common.RestoreError(apiResult.Error))
+	}
+
+	revisions := make([]resource.AttachedRevision, len(apiResult.Revisions))
+	for i, apiRev := range apiResult.Revisions {
+		rev, err := api.API2AttachedRevision(apiRev)
+		if err != nil {
+			return nil, errors.Annotate(err, "got bad data from server")
+		}
+		revisions[i] = rev
+	}
+	return revisions, nil
+}
+
+// RollbackResource calls the RollbackResources API server method to
+// make the identified previous revision of the content attached to the
+// named resource of the application the current one.
+func (c Client) RollbackResource(application, name string, revision int) error {
+	if revision <= 0 {
+		return errors.NotValidf("resource revision %d", revision)
+	}
+	arg, err := newResourceRevisionArg(application, name, revision)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	args := params.ResourceRevisionArgs{Args: []params.ResourceRevisionArg{arg}}
+
+	var apiResults params.ErrorResults
+	if err := c.FacadeCall("RollbackResources", &args, &apiResults); err != nil {
+		if params.IsCodeNotImplemented(err) {
+			return errors.NotSupportedf("resource rollback on this controller")
+		}
+		return errors.Trace(err)
+	}
+	if len(apiResults.Results) != 1 {
+		return errors.Errorf("got invalid data from server (expected 1 result, got %d)", len(apiResults.Results))
+	}
+	if apiErr := apiResults.Results[0].Error; apiErr != nil {
+		return errors.Trace(common.RestoreError(apiErr))
+	}
+	return nil
+}
+
+// newResourceRevisionArg returns the argument identifying a revision of
+// an application resource for the ResourceHistory and RollbackResources
+// endpoints.
+func newResourceRevisionArg(application, name string, revision int) (params.ResourceRevisionArg, error) {
+	if !names.IsValidApplication(application) {
+		return params.ResourceRevisionArg{}, errors.Errorf("invalid application %q", application)
+	}
+	return params.ResourceRevisionArg{
+		Tag:      names.NewApplicationTag(application).String(),
+		Name:     name,
+		Revision: revision,
+	}, nil
+}
+
 // Upload sends the provided resource blob up to Juju.
 func (c Client) Upload(application, name, filename string, reader io.ReadSeeker) error {
 	uReq, err := api.NewUploadRequest(application, name, filename, reader)
@@ -0,0 +1,118 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package client_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/resource/api/client"
+)
+
+var _ = gc.Suite(&ResourceHistorySuite{})
+
+type ResourceHistorySuite struct {
+	BaseSuite
+}
+
+func (s *ResourceHistorySuite) TestResourceHistory(c *gc.C) {
+	res1, apiRes1 := newResource(c, "spam", "a-user", "spamspamspam")
+	res2, apiRes2 := newResource(c, "spam", "a-user", "eggs")
+	s.facade.apiHistory = params.ResourceHistoryResult{
+		Revisions: []params.ResourceRevision{
+			{Resource: apiRes2, Number: 2, Current: true},
+			{Resource: apiRes1, Number: 1},
+		},
+	}
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	revisions, err := cl.ResourceHistory("a-application", "spam")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(revisions, jc.DeepEquals, []resource.AttachedRevision{
+		{Resource: res2, Number: 2, Current: true},
+		{Resource: res1, Number: 1},
+	})
+	s.stub.CheckCallNames(c, "FacadeCall")
+	s.stub.CheckCall(c, 0, "FacadeCall",
+		"ResourceHistory",
+		&params.ResourceRevisionArgs{Args: []params.ResourceRevisionArg{{
+			Tag:  "application-a-application",
+			Name: "spam",
+		}}},
+		&params.ResourceHistoryResults{
+			Results: []params.ResourceHistoryResult{s.facade.apiHistory},
+		},
+	)
+}
+
+func (s *ResourceHistorySuite) TestResourceHistoryError(c *gc.C) {
+	s.facade.apiHistory = params.ResourceHistoryResult{
+		ErrorResult: params.ErrorResult{Error: &params.Error{
+			Message: `resource "a-application/spam" not found`,
+			Code:    params.CodeNotFound,
+		}},
+	}
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	_, err := cl.ResourceHistory("a-application", "spam")
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ResourceHistorySuite) TestResourceHistoryNotImplemented(c *gc.C) {
+	s.stub.SetErrors(&params.Error{Code: params.CodeNotImplemented})
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	_, err := cl.ResourceHistory("a-application", "spam")
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *ResourceHistorySuite) TestResourceHistoryBadApplication(c *gc.C) {
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	_, err := cl.ResourceHistory("???", "spam")
+	c.Check(err, gc.ErrorMatches, `invalid application "\?\?\?"`)
+	s.stub.CheckNoCalls(c)
+}
+
+func (s *ResourceHistorySuite) TestRollbackResource(c *gc.C) {
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.RollbackResource("a-application", "spam", 3)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "FacadeCall")
+	s.stub.CheckCall(c, 0, "FacadeCall",
+		"RollbackResources",
+		&params.ResourceRevisionArgs{Args: []params.ResourceRevisionArg{{
+			Tag:      "application-a-application",
+			Name:     "spam",
+			Revision: 3,
+		}}},
+		&params.ErrorResults{Results: []params.ErrorResult{{}}},
+	)
+}
+
+func (s *ResourceHistorySuite) TestRollbackResourceError(c *gc.C) {
+	s.facade.rollbackErrs = []*params.Error{{
+		Message: `revision 3 of resource "a-application/spam" not found`,
+		Code:    params.CodeNotFound,
+	}}
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.RollbackResource("a-application", "spam", 3)
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+	c.Check(err, gc.ErrorMatches, `revision 3 of resource "a-application/spam" not found`)
+}
+
+func (s *ResourceHistorySuite) TestRollbackResourceBadRevision(c *gc.C) {
+	cl := client.NewClient(s.facade, s, s.facade)
+
+	err := cl.RollbackResource("a-application", "spam", 0)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	s.stub.CheckNoCalls(c)
+}
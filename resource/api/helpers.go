@@ -142,6 +142,30 @@ func API2Resource(apiRes params.Resource) (resource.Resource, error) {
 	return res, nil
 }
 
+// AttachedRevision2API converts a resource.AttachedRevision into
+// a ResourceRevision struct.
+func AttachedRevision2API(rev resource.AttachedRevision) params.ResourceRevision {
+	return params.ResourceRevision{
+		Resource: Resource2API(rev.Resource),
+		Number:   rev.Number,
+		Current:  rev.Current,
+	}
+}
+
+// API2AttachedRevision converts an API ResourceRevision struct into
+// a resource.AttachedRevision.
+func API2AttachedRevision(apiRev params.ResourceRevision) (resource.AttachedRevision, error) {
+	res, err := API2Resource(apiRev.Resource)
+	if err != nil {
+		return resource.AttachedRevision{}, errors.Trace(err)
+	}
+	return resource.AttachedRevision{
+		Resource: res,
+		Number:   apiRev.Number,
+		Current:  apiRev.Current,
+	}, nil
+}
+
 // CharmResource2API converts a charm resource into
 // a CharmResource struct.
 func CharmResource2API(res charmresource.Resource) params.CharmResource {
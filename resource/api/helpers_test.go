@@ -442,6 +442,24 @@ func (HelpersSuite) TestAPI2Resource(c *gc.C) {
 	c.Check(res, jc.DeepEquals, expected)
 }
 
+func (HelpersSuite) TestAttachedRevisionRoundTrip(c *gc.C) {
+	opened := resourcetesting.NewResource(c, nil, "spam", "a-application", "spamspamspam")
+	rev := resource.AttachedRevision{
+		Resource: opened.Resource,
+		Number:   3,
+		Current:  true,
+	}
+
+	apiRev := api.AttachedRevision2API(rev)
+	c.Check(apiRev.Number, gc.Equals, 3)
+	c.Check(apiRev.Current, jc.IsTrue)
+	c.Check(apiRev.Resource, jc.DeepEquals, api.Resource2API(opened.Resource))
+
+	converted, err := api.API2AttachedRevision(apiRev)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(converted, jc.DeepEquals, rev)
+}
+
 func (HelpersSuite) TestCharmResource2API(c *gc.C) {
 	fp, err := charmresource.NewFingerprint([]byte(fingerprint))
 	c.Assert(err, jc.ErrorIsNil)
@@ -0,0 +1,22 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package resource
+
+// AttachedRevision is a revision of the content attached to an
+// application's resource. Revisions are numbered from 1 in the order
+// content is attached to the application. Previous revisions are kept,
+// until pruned, so that the application can be rolled back to them.
+//
+// Only file resources keep previous revisions, as the content of
+// OCI image resources isn't kept by the controller.
+type AttachedRevision struct {
+	Resource
+
+	// Number identifies the revision among those of the resource. It
+	// isn't related to the charm store revision of the resource.
+	Number int
+
+	// Current is true for the revision the application is using.
+	Current bool
+}
@@ -57,7 +57,26 @@ func newCharmStoreClient(st *state.State) (charmstore.Client, error) {
 	if err != nil {
 		return charmstore.Client{}, errors.Trace(err)
 	}
-	return charmstore.NewCachingClient(state.MacaroonCache{st}, controllerCfg.CharmStoreURL())
+	client, err := charmstore.NewCachingClient(state.MacaroonCache{st}, controllerCfg.CharmStoreURL())
+	if err != nil {
+		return charmstore.Client{}, errors.Trace(err)
+	}
+	model, err := st.Model()
+	if err != nil {
+		return charmstore.Client{}, errors.Trace(err)
+	}
+	modelConfig, err := model.ModelConfig()
+	if err != nil {
+		return charmstore.Client{}, errors.Trace(err)
+	}
+	if mirrorURL, ok := modelConfig.CharmMirrorURL(); ok {
+		mirror, err := charmstore.NewMirror(mirrorURL)
+		if err != nil {
+			return charmstore.Client{}, errors.Trace(err)
+		}
+		client = client.WithMirror(mirror)
+	}
+	return client, nil
 }
 
 // NewClient opens a new charm store client.
@@ -4,6 +4,7 @@
 package rpc
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 	"strings"
@@ -24,6 +25,9 @@ type Call struct {
 	Response interface{}
 	Error    error
 	Done     chan *Call
+
+	// reqId holds the id of the request, once it has been sent.
+	reqId uint64
 }
 
 // RequestError represents an error returned from an RPC request.
@@ -86,6 +90,7 @@ func (conn *Conn) send(call *Call) {
 	}
 	conn.reqId++
 	reqId := conn.reqId
+	call.reqId = reqId
 	conn.clientPending[reqId] = call
 	conn.mutex.Unlock()
 
@@ -167,6 +172,17 @@ func (call *Call) done() {
 // The params value may be nil if no parameters are provided; the response value
 // may be nil to indicate that any result should be discarded.
 func (conn *Conn) Call(req Request, params, response interface{}) error {
+	return conn.CallContext(context.Background(), req, params, response)
+}
+
+// CallContext is like Call, but stops waiting for the response if the
+// given context is cancelled or its deadline passes, in which case the
+// context's error is returned. Note that the server may still act on a
+// request which has been abandoned in this way.
+func (conn *Conn) CallContext(ctx context.Context, req Request, params, response interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return errors.Trace(err)
+	}
 	call := &Call{
 		Request:  req,
 		Params:   params,
@@ -174,6 +190,30 @@ func (conn *Conn) Call(req Request, params, response interface{}) error {
 		Done:     make(chan *Call, 1),
 	}
 	conn.send(call)
-	result := <-call.Done
-	return errors.Trace(result.Error)
+	select {
+	case result := <-call.Done:
+		return errors.Trace(result.Error)
+	case <-ctx.Done():
+	}
+	if !conn.abandon(call) {
+		// The response has already been taken by the reader, which may
+		// be decoding it into the response value. Wait for it to finish
+		// so the caller can safely reuse the value.
+		result := <-call.Done
+		return errors.Trace(result.Error)
+	}
+	return errors.Trace(ctx.Err())
+}
+
+// abandon removes the given call from the set of pending calls, so that
+// any response to it will be discarded. It reports whether the call was
+// still pending.
+func (conn *Conn) abandon(call *Call) bool {
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	if conn.clientPending[call.reqId] != call {
+		return false
+	}
+	delete(conn.clientPending, call.reqId)
+	return true
 }
@@ -743,6 +743,55 @@ func (*rpcSuite) TestConcurrentCalls(c *gc.C) {
 	chanRead(c, done2, "method 2 done")
 }
 
+func (*rpcSuite) TestCallContextCancelled(c *gc.C) {
+	ready := make(chan struct{})
+	start := make(chan string)
+	root := &Root{
+		simple: make(map[string]*SimpleMethods),
+		delayed: map[string]*DelayedMethods{
+			"1": {ready: ready, done: start},
+		},
+	}
+	root.simple["a99"] = &SimpleMethods{root: root, id: "a99"}
+	client, _, srvDone, _ := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		var r stringVal
+		err := client.CallContext(ctx, rpc.Request{"DelayedMethods", 0, "1", "Delay"}, nil, &r)
+		c.Check(errors.Cause(err), gc.Equals, context.Canceled)
+		done <- struct{}{}
+	}()
+	chanRead(c, ready, "DelayedMethods.Delay ready")
+	cancel()
+	chanRead(c, done, "call abandoned")
+
+	// The response to the abandoned call is discarded, and the
+	// connection is still usable.
+	start <- "return 1"
+	var r stringVal
+	err := client.Call(rpc.Request{"SimpleMethods", 0, "a99", "Call0r1"}, nil, &r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(r.Val, gc.Equals, "Call0r1 ret")
+}
+
+func (*rpcSuite) TestCallContextAlreadyDone(c *gc.C) {
+	root := &Root{
+		simple: make(map[string]*SimpleMethods),
+	}
+	root.simple["a99"] = &SimpleMethods{root: root, id: "a99"}
+	client, _, srvDone, _ := newRPCClientServer(c, root, nil, false)
+	defer closeClient(c, client, srvDone)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := client.CallContext(ctx, rpc.Request{"SimpleMethods", 0, "a99", "Call0r0"}, nil, nil)
+	c.Assert(errors.Cause(err), gc.Equals, context.Canceled)
+	c.Assert(root.calls, gc.HasLen, 0)
+}
+
 type codedError struct {
 	m    string
 	code string
@@ -0,0 +1,130 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// AbandonedResourceKind identifies the kind of an abandoned cloud
+// resource.
+type AbandonedResourceKind string
+
+const (
+	// AbandonedMachine is an instance of a machine which was removed
+	// by force before the provisioner stopped it.
+	AbandonedMachine AbandonedResourceKind = "machine"
+
+	// AbandonedEnviron is the cloud environment of an IAAS model
+	// which could not be torn down.
+	AbandonedEnviron AbandonedResourceKind = "environ"
+
+	// AbandonedNamespace is the namespace of a CAAS model which
+	// could not be torn down.
+	AbandonedNamespace AbandonedResourceKind = "namespace"
+)
+
+// AbandonedResource records a cloud resource which was removed from a
+// model without the provider confirming that it was released. The
+// resource may still exist in the cloud, and need to be cleaned up
+// manually.
+type AbandonedResource struct {
+	// ModelUUID is the UUID of the model the resource belonged to.
+	ModelUUID string
+
+	// ModelOwner is the owner of the model the resource belonged to.
+	ModelOwner string
+
+	// Kind is the kind of the resource.
+	Kind AbandonedResourceKind
+
+	// Id is the Juju ID of the resource.
+	Id string
+
+	// ProviderId is the ID of the resource in the cloud, if known.
+	ProviderId string
+
+	// Reason describes why the resource was abandoned.
+	Reason string
+
+	// Time is when the resource was abandoned.
+	Time time.Time
+}
+
+type abandonedResourceDoc struct {
+	DocID      string `bson:"_id"`
+	ModelUUID  string `bson:"model-uuid"`
+	ModelOwner string `bson:"model-owner"`
+	Kind       string `bson:"kind"`
+	Id         string `bson:"id"`
+	ProviderId string `bson:"provider-id,omitempty"`
+	Reason     string `bson:"reason"`
+	Time       int64  `bson:"time"`
+}
+
+// RecordAbandonedResource records that the cloud resource of the given
+// kind and ID, belonging to this state's model, was removed from the
+// model without the provider confirming its release. Recording the
+// same resource again replaces the earlier record.
+func (st *State) RecordAbandonedResource(kind AbandonedResourceKind, id, providerId, reason string) error {
+	model, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	coll, closer := st.db().GetCollection(abandonedResourcesC)
+	defer closer()
+
+	docID := st.docID(string(kind) + "#" + id)
+	_, err = coll.Writeable().UpsertId(docID, abandonedResourceDoc{
+		DocID:      docID,
+		ModelUUID:  st.ModelUUID(),
+		ModelOwner: model.Owner().Id(),
+		Kind:       string(kind),
+		Id:         id,
+		ProviderId: providerId,
+		Reason:     reason,
+		Time:       st.clock().Now().UnixNano(),
+	})
+	if err != nil {
+		return errors.Annotatef(err, "cannot record abandoned %s %q", kind, id)
+	}
+	return nil
+}
+
+// AbandonedResources returns the cloud resources recorded as abandoned
+// for the model with the given UUID, in the order they were abandoned.
+// The records are kept after the model is removed.
+func (st *State) AbandonedResources(modelUUID string) ([]AbandonedResource, error) {
+	coll, closer := st.db().GetCollection(abandonedResourcesC)
+	defer closer()
+
+	var docs []abandonedResourceDoc
+	if err := coll.Find(bson.D{{"model-uuid", modelUUID}}).Sort("time").All(&docs); err != nil {
+		return nil, errors.Annotatef(err, "cannot get abandoned resources for model %q", modelUUID)
+	}
+	result := make([]AbandonedResource, len(docs))
+	for i, doc := range docs {
+		result[i] = AbandonedResource{
+			ModelUUID:  doc.ModelUUID,
+			ModelOwner: doc.ModelOwner,
+			Kind:       AbandonedResourceKind(doc.Kind),
+			Id:         doc.Id,
+			ProviderId: doc.ProviderId,
+			Reason:     doc.Reason,
+			Time:       time.Unix(0, doc.Time).UTC(),
+		}
+	}
+	return result, nil
+}
+
+// WatchModelTeardown returns a NotifyWatcher which triggers whenever
+// the progress of tearing down the model may have changed: when the
+// status of any entity in the model changes, or when an entity is
+// removed from the model.
+func (st *State) WatchModelTeardown() NotifyWatcher {
+	return newNotifyCollWatcher(st, statusesC, isLocalID(st))
+}
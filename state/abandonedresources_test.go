@@ -0,0 +1,102 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type AbandonedResourcesSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&AbandonedResourcesSuite{})
+
+func (s *AbandonedResourcesSuite) TestRecordAbandonedResource(c *gc.C) {
+	err := s.State.RecordAbandonedResource(state.AbandonedMachine, "0", "inst-0", "gone")
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Second)
+	err = s.State.RecordAbandonedResource(state.AbandonedEnviron, s.State.ModelUUID(), "", "boom")
+	c.Assert(err, jc.ErrorIsNil)
+
+	owner := s.Model.Owner().Id()
+	resources, err := s.State.AbandonedResources(s.State.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, gc.HasLen, 2)
+	c.Check(resources[0].Time.IsZero(), jc.IsFalse)
+	c.Check(resources[1].Time.After(resources[0].Time), jc.IsTrue)
+	resources[0].Time = time.Time{}
+	resources[1].Time = time.Time{}
+	c.Assert(resources, jc.DeepEquals, []state.AbandonedResource{{
+		ModelUUID:  s.State.ModelUUID(),
+		ModelOwner: owner,
+		Kind:       state.AbandonedMachine,
+		Id:         "0",
+		ProviderId: "inst-0",
+		Reason:     "gone",
+	}, {
+		ModelUUID:  s.State.ModelUUID(),
+		ModelOwner: owner,
+		Kind:       state.AbandonedEnviron,
+		Id:         s.State.ModelUUID(),
+		Reason:     "boom",
+	}})
+}
+
+func (s *AbandonedResourcesSuite) TestRecordAbandonedResourceReplaces(c *gc.C) {
+	err := s.State.RecordAbandonedResource(state.AbandonedMachine, "0", "inst-0", "first")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RecordAbandonedResource(state.AbandonedMachine, "0", "inst-0", "second")
+	c.Assert(err, jc.ErrorIsNil)
+
+	resources, err := s.State.AbandonedResources(s.State.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, gc.HasLen, 1)
+	c.Assert(resources[0].Reason, gc.Equals, "second")
+}
+
+func (s *AbandonedResourcesSuite) TestAbandonedResourcesPerModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	err := st.RecordAbandonedResource(state.AbandonedMachine, "0", "inst-0", "gone")
+	c.Assert(err, jc.ErrorIsNil)
+
+	resources, err := s.State.AbandonedResources(s.State.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, gc.HasLen, 0)
+	resources, err = s.State.AbandonedResources(st.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, gc.HasLen, 1)
+	c.Assert(resources[0].ModelUUID, gc.Equals, st.ModelUUID())
+}
+
+func (s *AbandonedResourcesSuite) TestWatchModelTeardown(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.State.WatchModelTeardown()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	now := time.Now()
+	err = machine.SetStatus(status.StatusInfo{
+		Status:  status.Stopped,
+		Message: "stopping",
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	c.Assert(machine.EnsureDead(), jc.ErrorIsNil)
+	c.Assert(machine.Remove(), jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
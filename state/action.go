@@ -4,6 +4,7 @@
 package state
 
 import (
+	"fmt"
 	"strconv"
 	"time"
 
@@ -492,6 +493,13 @@ func (m *Model) EnqueueAction(receiver names.Tag, actionName string, payload map
 		return nil, errors.Trace(err)
 	}
 
+	actionId := m.st.localID(doc.DocId)
+	operationOp, err := addOperationOp(m.st, OperationAction,
+		fmt.Sprintf("run action %s on %s", actionName, receiver.Id()), "", actionId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	ops := []txn.Op{{
 		C:      receiverCollectionName,
 		Id:     receiverId,
@@ -506,7 +514,7 @@ func (m *Model) EnqueueAction(receiver names.Tag, actionName string, payload map
 		Id:     ndoc.DocId,
 		Assert: txn.DocMissing,
 		Insert: ndoc,
-	}}
+	}, operationOp}
 
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		if notDead, err := isNotDead(m.st, receiverCollectionName, receiverId); err != nil {
@@ -301,6 +301,8 @@ func (st *State) addMachineOps(template MachineTemplate) (*machineDoc, []txn.Op,
 				RootDiskSource: template.HardwareCharacteristics.RootDiskSource,
 				CpuCores:       template.HardwareCharacteristics.CpuCores,
 				CpuPower:       template.HardwareCharacteristics.CpuPower,
+				GPUs:           template.HardwareCharacteristics.GPUs,
+				GPUType:        template.HardwareCharacteristics.GPUType,
 				Tags:           template.HardwareCharacteristics.Tags,
 				AvailZone:      template.HardwareCharacteristics.AvailabilityZone,
 			},
@@ -0,0 +1,201 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/agentconfig"
+)
+
+// agentConfigOverridesDoc records the agent config values which the
+// controller has overridden for a machine agent, and the version of
+// them which the agent has applied.
+type agentConfigOverridesDoc struct {
+	DocID          string            `bson:"_id"`
+	ModelUUID      string            `bson:"model-uuid"`
+	Values         map[string]string `bson:"values"`
+	Version        int64             `bson:"version"`
+	AppliedVersion int64             `bson:"applied-version"`
+}
+
+// AgentConfigOverrides holds the agent config values overridden for a
+// machine agent.
+type AgentConfigOverrides struct {
+	// Values holds the overridden values, keyed by the names defined
+	// in core/agentconfig.
+	Values map[string]string
+
+	// Version is incremented each time the values change.
+	Version int64
+
+	// AppliedVersion is the version of the values which the agent
+	// last reported having applied.
+	AppliedVersion int64
+}
+
+// SetAgentConfigOverrides overrides the given agent config values for
+// the machine's agent, which applies them without restarting. Values
+// not given are left as they are; an empty value removes the override.
+func (m *Machine) SetAgentConfigOverrides(values map[string]string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set agent config overrides for machine %s", m)
+	if err := agentconfig.ValidateOverrides(values); err != nil {
+		return errors.Trace(err)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+	docID := m.st.docID(m.globalKey())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.doc.Life == Dead {
+			return nil, errors.New("machine is dead")
+		}
+		assertNotDead := txn.Op{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: notDeadDoc,
+		}
+		doc, err := m.agentConfigOverrides()
+		if errors.IsNotFound(err) {
+			set := make(map[string]string)
+			for key, value := range values {
+				if value != "" {
+					set[key] = value
+				}
+			}
+			if len(set) == 0 {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return []txn.Op{assertNotDead, {
+				C:      agentConfigOverridesC,
+				Id:     docID,
+				Assert: txn.DocMissing,
+				Insert: &agentConfigOverridesDoc{
+					DocID:     docID,
+					ModelUUID: m.st.ModelUUID(),
+					Values:    set,
+					Version:   1,
+				},
+			}}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var set, unset bson.D
+		for key, value := range values {
+			current, ok := doc.Values[key]
+			switch {
+			case value == "" && ok:
+				unset = append(unset, bson.DocElem{"values." + key, 1})
+			case value != "" && value != current:
+				set = append(set, bson.DocElem{"values." + key, value})
+			}
+		}
+		if len(set) == 0 && len(unset) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		update := bson.D{{"$inc", bson.D{{"version", 1}}}}
+		if len(set) > 0 {
+			update = append(update, bson.DocElem{"$set", set})
+		}
+		if len(unset) > 0 {
+			update = append(update, bson.DocElem{"$unset", unset})
+		}
+		return []txn.Op{assertNotDead, {
+			C:      agentConfigOverridesC,
+			Id:     docID,
+			Assert: bson.D{{"version", doc.Version}},
+			Update: update,
+		}}, nil
+	}
+	return errors.Trace(m.st.db().Run(buildTxn))
+}
+
+// AgentConfigOverrides returns the agent config values overridden for
+// the machine's agent.
+func (m *Machine) AgentConfigOverrides() (AgentConfigOverrides, error) {
+	doc, err := m.agentConfigOverrides()
+	if errors.IsNotFound(err) {
+		return AgentConfigOverrides{Values: map[string]string{}}, nil
+	} else if err != nil {
+		return AgentConfigOverrides{}, errors.Annotatef(err, "cannot get agent config overrides for machine %s", m)
+	}
+	values := doc.Values
+	if values == nil {
+		values = map[string]string{}
+	}
+	return AgentConfigOverrides{
+		Values:         values,
+		Version:        doc.Version,
+		AppliedVersion: doc.AppliedVersion,
+	}, nil
+}
+
+// SetAgentConfigOverridesApplied records that the machine's agent has
+// applied the given version of its agent config overrides.
+func (m *Machine) SetAgentConfigOverridesApplied(version int64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set applied agent config overrides for machine %s", m)
+	buildTxn := func(int) ([]txn.Op, error) {
+		doc, err := m.agentConfigOverrides()
+		if errors.IsNotFound(err) {
+			if version == 0 {
+				return nil, jujutxn.ErrNoOperations
+			}
+			return nil, errors.NotValidf("version %d", version)
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if version > doc.Version {
+			return nil, errors.NotValidf("version %d", version)
+		}
+		if version == doc.AppliedVersion {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      agentConfigOverridesC,
+			Id:     doc.DocID,
+			Assert: bson.D{{"version", bson.D{{"$gte", version}}}},
+			Update: bson.D{{"$set", bson.D{{"applied-version", version}}}},
+		}}, nil
+	}
+	return errors.Trace(m.st.db().Run(buildTxn))
+}
+
+// WatchAgentConfigOverrides returns a watcher which notifies when the
+// agent config values overridden for the machine's agent, or the
+// version of them it has applied, change.
+func (m *Machine) WatchAgentConfigOverrides() NotifyWatcher {
+	return newEntityWatcher(m.st, agentConfigOverridesC, m.st.docID(m.globalKey()))
+}
+
+func (m *Machine) agentConfigOverrides() (*agentConfigOverridesDoc, error) {
+	coll, closer := m.st.db().GetCollection(agentConfigOverridesC)
+	defer closer()
+	var doc agentConfigOverridesDoc
+	err := coll.FindId(m.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("agent config overrides for machine %s", m)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &doc, nil
+}
+
+// removeAgentConfigOverridesOp returns the operation which removes the
+// agent config overrides stored under the given global key.
+func removeAgentConfigOverridesOp(mb modelBackend, globalKey string) txn.Op {
+	return txn.Op{
+		C:      agentConfigOverridesC,
+		Id:     mb.docID(globalKey),
+		Remove: true,
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/agentconfig"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type AgentConfigOverridesSuite struct {
+	ConnSuite
+	machine *state.Machine
+}
+
+var _ = gc.Suite(&AgentConfigOverridesSuite{})
+
+func (s *AgentConfigOverridesSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.machine = s.Factory.MakeMachine(c, &factory.MachineParams{})
+}
+
+func (s *AgentConfigOverridesSuite) assertOverrides(c *gc.C, expect state.AgentConfigOverrides) {
+	overrides, err := s.machine.AgentConfigOverrides()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(overrides, jc.DeepEquals, expect)
+}
+
+func (s *AgentConfigOverridesSuite) TestNoOverrides(c *gc.C) {
+	s.assertOverrides(c, state.AgentConfigOverrides{Values: map[string]string{}})
+}
+
+func (s *AgentConfigOverridesSuite) TestSetAgentConfigOverrides(c *gc.C) {
+	err := s.machine.SetAgentConfigOverrides(map[string]string{
+		agentconfig.LoggingConfig: "<root>=DEBUG",
+		agentconfig.HTTPProxy:     "http://proxy.example.com:3128",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertOverrides(c, state.AgentConfigOverrides{
+		Values: map[string]string{
+			agentconfig.LoggingConfig: "<root>=DEBUG",
+			agentconfig.HTTPProxy:     "http://proxy.example.com:3128",
+		},
+		Version: 1,
+	})
+
+	// Values not given are kept, and empty ones are removed.
+	err = s.machine.SetAgentConfigOverrides(map[string]string{
+		agentconfig.HTTPProxy:    "",
+		agentconfig.APIAddresses: "10.0.0.1:17070",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertOverrides(c, state.AgentConfigOverrides{
+		Values: map[string]string{
+			agentconfig.LoggingConfig: "<root>=DEBUG",
+			agentconfig.APIAddresses:  "10.0.0.1:17070",
+		},
+		Version: 2,
+	})
+
+	// Setting the same values again doesn't change the version.
+	err = s.machine.SetAgentConfigOverrides(map[string]string{
+		agentconfig.LoggingConfig: "<root>=DEBUG",
+		agentconfig.NoProxy:       "",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	overrides, err := s.machine.AgentConfigOverrides()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(overrides.Version, gc.Equals, int64(2))
+}
+
+func (s *AgentConfigOverridesSuite) TestSetAgentConfigOverridesInvalid(c *gc.C) {
+	err := s.machine.SetAgentConfigOverrides(map[string]string{"data-dir": "/tmp"})
+	c.Assert(err, gc.ErrorMatches, `cannot set agent config overrides for machine 0: agent config override "data-dir" not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *AgentConfigOverridesSuite) TestSetAgentConfigOverridesDeadMachine(c *gc.C) {
+	err := s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetAgentConfigOverrides(map[string]string{agentconfig.NoProxy: "localhost"})
+	c.Assert(err, gc.ErrorMatches, `cannot set agent config overrides for machine 0: machine is dead`)
+}
+
+func (s *AgentConfigOverridesSuite) TestSetAgentConfigOverridesApplied(c *gc.C) {
+	err := s.machine.SetAgentConfigOverridesApplied(0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.SetAgentConfigOverrides(map[string]string{agentconfig.NoProxy: "localhost"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetAgentConfigOverridesApplied(1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertOverrides(c, state.AgentConfigOverrides{
+		Values:         map[string]string{agentconfig.NoProxy: "localhost"},
+		Version:        1,
+		AppliedVersion: 1,
+	})
+
+	err = s.machine.SetAgentConfigOverridesApplied(2)
+	c.Assert(err, gc.ErrorMatches, `cannot set applied agent config overrides for machine 0: version 2 not valid`)
+}
+
+func (s *AgentConfigOverridesSuite) TestRemovedWithMachine(c *gc.C) {
+	err := s.machine.SetAgentConfigOverrides(map[string]string{agentconfig.NoProxy: "localhost"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	coll, closer := state.GetCollection(s.State, "agentConfigOverrides")
+	defer closer()
+	count, err := coll.Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+}
+
+func (s *AgentConfigOverridesSuite) TestWatchAgentConfigOverrides(c *gc.C) {
+	w := s.machine.WatchAgentConfigOverrides()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.machine.SetAgentConfigOverrides(map[string]string{agentconfig.NoProxy: "localhost"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.machine.SetAgentConfigOverrides(map[string]string{agentconfig.NoProxy: "localhost"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	err = s.machine.SetAgentConfigOverridesApplied(1)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
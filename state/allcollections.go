@@ -131,6 +131,18 @@ func allCollections() CollectionSchema {
 		// This collection tracks the progress of model migrations.
 		migrationsStatusC: {global: true},
 
+		// This collection records who enabled or disabled controller
+		// feature flags, and when.
+		featureFlagChangesC: {global: true},
+
+		// This collection records the progress of the online schema
+		// migrations run after the controller is upgraded.
+		schemaMigrationsC: {global: true},
+
+		// This collection holds the templates from which models can
+		// be created.
+		modelTemplatesC: {global: true},
+
 		// This collection records the model migrations which
 		// are currently in progress. It is used to ensure that only
 		// one model migration document exists per model.
@@ -166,6 +178,10 @@ func allCollections() CollectionSchema {
 		// This collection holds cloud definitions.
 		cloudsC: {global: true},
 
+		// This collection holds the prices of cloud resources, used to
+		// estimate what models cost to run.
+		cloudPricingC: {global: true},
+
 		// This collection holds users' cloud credentials.
 		cloudCredentialsC: {
 			global: true,
@@ -214,6 +230,17 @@ func allCollections() CollectionSchema {
 			rawAccess: true,
 		},
 
+		// This collection records cloud resources that were removed
+		// from a model without the provider confirming their release.
+		// It is global so that the records outlive the model.
+		abandonedResourcesC: {
+			global:    true,
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid"},
+			}},
+		},
+
 		// This collection tracks who holds which lease when the store
 		// is managed by raft - so that transactions can still make
 		// assertions about holding the lease.
@@ -331,6 +358,14 @@ func allCollections() CollectionSchema {
 		rebootC:      {},
 		sshHostKeysC: {},
 
+		// This collection holds the agent config values overridden by
+		// the controller for machine agents.
+		agentConfigOverridesC: {},
+
+		// This collection holds requests for the instance poller to
+		// poll machines' instances without waiting for their next poll.
+		instancePollRequestsC: {},
+
 		// This collection contains information from removed machines
 		// that needs to be cleaned up in the provider.
 		machineRemovalsC: {},
@@ -440,8 +475,14 @@ func allCollections() CollectionSchema {
 		// named before multi-model support.
 
 		// This collection holds user annotations for various entities. They
-		// shouldn't be written or interpreted by juju.
-		annotationsC: {},
+		// shouldn't be written or interpreted by juju. The pairs are
+		// indexed so that entities can be found by their annotations
+		// across all models.
+		annotationsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"pairs"},
+			}},
+		},
 
 		// This collection in particular holds an astounding number of
 		// different sorts of data: application config settings by charm version,
@@ -456,6 +497,38 @@ func allCollections() CollectionSchema {
 			}},
 		},
 
+		// This collection holds the operations log, which records
+		// changes made to a model such as upgrades and migrations.
+		operationsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "time"},
+			}},
+		},
+
+		// This collection holds the lifecycle events of a model, which
+		// are streamed to external consumers.
+		modelEventsC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "seq"},
+			}, {
+				Key: []string{"model-uuid", "time"},
+			}},
+		},
+
+		// This collection records where the export of an archived
+		// model is held, so that the model can later be thawed.
+		modelArchivesC: {},
+
+		// These collections hold the revisions of the bundle which
+		// describes the desired state of a model, and the approved
+		// plan to bring the model into line with it.
+		modelBundlesC:        {},
+		bundlePlanApprovalsC: {},
+
+		// This collection holds the credentials used to pull OCI
+		// images from private registries for a model's applications.
+		registryCredentialsC: {},
+
 		constraintsC:        {},
 		storageConstraintsC: {},
 		deviceConstraintsC:  {},
@@ -467,7 +540,8 @@ func allCollections() CollectionSchema {
 		statusesHistoryC: {
 			rawAccess: true,
 			indexes: []mgo.Index{{
-				Key: []string{"model-uuid", "globalkey", "updated"},
+				// used to read the history of an entity in order
+				Key: []string{"model-uuid", "globalkey", "updated", "_id"},
 			}, {
 				// used for migration and model-specific pruning
 				Key: []string{"model-uuid", "-updated", "-_id"},
@@ -477,6 +551,19 @@ func allCollections() CollectionSchema {
 			}},
 		},
 
+		// This collection holds the recent changes made to the data
+		// bags of each relation.
+		relationSettingsHistoryC: {
+			rawAccess: true,
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "globalkey", "updated"},
+			}},
+		},
+
+		// This collection holds the units of each application which
+		// are installing or upgrading their charm.
+		charmDeploymentsC: {},
+
 		// This collection holds information about cloud image metadata.
 		cloudimagemetadataC: {
 			global:  true,
@@ -542,19 +629,23 @@ func allCollections() CollectionSchema {
 // it in allCollections, above; and please keep this list sorted for easy
 // inspection.
 const (
+	abandonedResourcesC        = "abandonedResources"
 	actionNotificationsC       = "actionnotifications"
 	actionresultsC             = "actionresults"
 	actionsC                   = "actions"
+	agentConfigOverridesC      = "agentConfigOverrides"
 	annotationsC               = "annotations"
 	autocertCacheC             = "autocertCache"
 	assignUnitC                = "assignUnits"
 	bakeryStorageItemsC        = "bakeryStorageItems"
 	blockDevicesC              = "blockdevices"
 	blocksC                    = "blocks"
+	charmDeploymentsC          = "charmDeployments"
 	charmsC                    = "charms"
 	cleanupsC                  = "cleanups"
 	cloudimagemetadataC        = "cloudimagemetadata"
 	cloudsC                    = "clouds"
+	cloudPricingC              = "cloudPricing"
 	cloudContainersC           = "cloudcontainers"
 	cloudServicesC             = "cloudservices"
 	cloudCredentialsC          = "cloudCredentials"
@@ -564,6 +655,7 @@ const (
 	controllerNodesC           = "controllerNodes"
 	controllerUsersC           = "controllerusers"
 	dockerResourcesC           = "dockerResources"
+	featureFlagChangesC        = "featureFlagChanges"
 	filesystemAttachmentsC     = "filesystemAttachments"
 	filesystemsC               = "filesystems"
 	globalClockC               = "globalclock"
@@ -572,6 +664,7 @@ const (
 	guimetadataC               = "guimetadata"
 	guisettingsC               = "guisettings"
 	instanceDataC              = "instanceData"
+	instancePollRequestsC      = "instancePollRequests"
 	leasesC                    = "leases"
 	leaseHoldersC              = "leaseholders"
 	machinesC                  = "machines"
@@ -588,16 +681,25 @@ const (
 	modelUserLastConnectionC   = "modelUserLastConnection"
 	modelUsersC                = "modelusers"
 	modelsC                    = "models"
+	modelEventsC               = "modelevents"
+	modelArchivesC             = "modelArchives"
+	modelBundlesC              = "modelBundles"
+	bundlePlanApprovalsC       = "bundlePlanApprovals"
+	registryCredentialsC       = "registryCredentials"
+	modelTemplatesC            = "modelTemplates"
 	modelEntityRefsC           = "modelEntityRefs"
 	openedPortsC               = "openedPorts"
+	operationsC                = "operations"
 	payloadsC                  = "payloads"
 	permissionsC               = "permissions"
 	podSpecsC                  = "podSpecs"
 	providerIDsC               = "providerIDs"
 	rebootC                    = "reboot"
 	relationScopesC            = "relationscopes"
+	relationSettingsHistoryC   = "relationsettingshistory"
 	relationsC                 = "relations"
 	restoreInfoC               = "restoreInfo"
+	schemaMigrationsC          = "schemaMigrations"
 	sequenceC                  = "sequence"
 	applicationsC              = "applications"
 	endpointBindingsC          = "endpointbindings"
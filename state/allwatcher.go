@@ -103,6 +103,8 @@ func makeAllWatcherCollectionInfo(collNames ...string) map[string]allWatcherStat
 			collection.docType = reflect.TypeOf(backingApplicationOffer{})
 		case generationsC:
 			collection.docType = reflect.TypeOf(backingGeneration{})
+		case storageInstancesC:
+			collection.docType = reflect.TypeOf(backingStorageInstance{})
 		default:
 			panic(errors.Errorf("unknown collection %q", collName))
 		}
@@ -557,10 +559,11 @@ func (app *backingApplication) updated(st *State, store *multiwatcherStore, id s
 		}
 		info.Constraints = c
 		needConfig = true
-		applicationStatus, err := getStatus(st.db(), key, "application")
+		statusDoc, err := getStatusDoc(st.db(), key, "application")
 		if err != nil {
 			return errors.Annotatef(err, "reading application status for key %s", key)
 		}
+		applicationStatus := statusDoc.asStatusInfo()
 
 		info.Status = params.StatusInfo{
 			Current: applicationStatus.Status,
@@ -568,6 +571,7 @@ func (app *backingApplication) updated(st *State, store *multiwatcherStore, id s
 			Data:    normaliseStatusData(applicationStatus.Data),
 			Since:   applicationStatus.Since,
 		}
+		info.StatusExplicitlySet = statusDoc.ExplicitlySet
 	} else {
 		// The entry already exists, so preserve the current status.
 		appInfo := oldInfo.(*params.ApplicationInfo)
@@ -583,6 +587,7 @@ func (app *backingApplication) updated(st *State, store *multiwatcherStore, id s
 			needConfig = true
 		}
 		info.Status = appInfo.Status
+		info.StatusExplicitlySet = appInfo.StatusExplicitlySet
 	}
 	if needConfig {
 		doc, err := readSettingsDoc(st.db(), settingsC, applicationCharmConfigKey(app.Name, app.CharmURL))
@@ -869,6 +874,37 @@ func (r *backingRelation) mongoId() string {
 	return r.DocID
 }
 
+type backingStorageInstance storageInstanceDoc
+
+func (si *backingStorageInstance) updated(st *State, store *multiwatcherStore, id string) error {
+	info := &params.StorageInstanceInfo{
+		ModelUUID:       st.ModelUUID(),
+		Id:              si.Id,
+		Kind:            si.Kind.String(),
+		Life:            si.Life.Value(),
+		Owner:           si.Owner,
+		StorageName:     si.StorageName,
+		Pool:            si.Constraints.Pool,
+		Size:            si.Constraints.Size,
+		AttachmentCount: si.AttachmentCount,
+	}
+	store.Update(info)
+	return nil
+}
+
+func (si *backingStorageInstance) removed(store *multiwatcherStore, modelUUID, id string, _ *State) error {
+	store.Remove(params.EntityId{
+		Kind:      "storageInstance",
+		ModelUUID: modelUUID,
+		Id:        id,
+	})
+	return nil
+}
+
+func (si *backingStorageInstance) mongoId() string {
+	return si.DocID
+}
+
 type backingAnnotation annotatorDoc
 
 func (a *backingAnnotation) updated(st *State, store *multiwatcherStore, id string) error {
@@ -965,6 +1001,7 @@ func (s *backingStatus) updated(st *State, store *multiwatcherStore, id string)
 	case *params.ApplicationInfo:
 		newInfo := *info
 		newInfo.Status = s.toStatusInfo()
+		newInfo.StatusExplicitlySet = s.ExplicitlySet
 		info0 = &newInfo
 	case *params.RemoteApplicationUpdate:
 		newInfo := *info
@@ -1519,23 +1556,42 @@ func (b *allWatcherStateBacking) Release() error {
 	return nil
 }
 
+// allModelWatcherCollections are the collections watched for the
+// all-model watcher that is served to clients.
+var allModelWatcherCollections = []string{
+	annotationsC,
+	applicationsC,
+	charmsC,
+	constraintsC,
+	generationsC,
+	instanceDataC,
+	modelsC,
+	machinesC,
+	openedPortsC,
+	relationsC,
+	remoteApplicationsC,
+	statusesC,
+	settingsC,
+	unitsC,
+}
+
+// NewAllModelWatcherStateBacking returns a Backing for the entities of
+// all models, as served to clients by the AllModelWatcher facade.
 func NewAllModelWatcherStateBacking(st *State, pool *StatePool) Backing {
-	collections := makeAllWatcherCollectionInfo(
-		annotationsC,
-		applicationsC,
-		charmsC,
-		constraintsC,
-		generationsC,
-		instanceDataC,
-		modelsC,
-		machinesC,
-		openedPortsC,
-		relationsC,
-		remoteApplicationsC,
-		statusesC,
-		settingsC,
-		unitsC,
-	)
+	return newAllModelWatcherStateBacking(st, pool, allModelWatcherCollections...)
+}
+
+// NewModelCacheWatcherStateBacking returns a Backing for the entities
+// of all models which also includes application offers and storage
+// instances. It feeds the controller's model cache; the extra entity
+// kinds are never sent to clients, which would not know them.
+func NewModelCacheWatcherStateBacking(st *State, pool *StatePool) Backing {
+	collNames := append([]string{applicationOffersC, storageInstancesC}, allModelWatcherCollections...)
+	return newAllModelWatcherStateBacking(st, pool, collNames...)
+}
+
+func newAllModelWatcherStateBacking(st *State, pool *StatePool, collNames ...string) Backing {
+	collections := makeAllWatcherCollectionInfo(collNames...)
 	return &allModelWatcherStateBacking{
 		st:               st,
 		watcher:          st.workers.txnLogWatcher(),
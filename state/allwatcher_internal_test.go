@@ -1410,6 +1410,10 @@ func (s *allModelWatcherStateSuite) NewAllModelWatcherStateBacking() Backing {
 	return NewAllModelWatcherStateBacking(s.state, s.pool)
 }
 
+func (s *allModelWatcherStateSuite) NewModelCacheWatcherStateBacking() Backing {
+	return NewModelCacheWatcherStateBacking(s.state, s.pool)
+}
+
 func (s *allModelWatcherStateSuite) TestMissingModelNotError(c *gc.C) {
 	b := s.NewAllModelWatcherStateBacking()
 	defer b.Release()
@@ -1432,6 +1436,16 @@ func (s *allModelWatcherStateSuite) TestMissingModelNotError(c *gc.C) {
 
 // performChangeTestCases runs a passed number of test cases for changes.
 func (s *allModelWatcherStateSuite) performChangeTestCases(c *gc.C, changeTestFuncs []changeTestFunc) {
+	s.performChangeTestCasesWith(c, s.NewAllModelWatcherStateBacking, changeTestFuncs)
+}
+
+// performCacheChangeTestCases runs a passed number of test cases for
+// changes against the model cache's backing.
+func (s *allModelWatcherStateSuite) performCacheChangeTestCases(c *gc.C, changeTestFuncs []changeTestFunc) {
+	s.performChangeTestCasesWith(c, s.NewModelCacheWatcherStateBacking, changeTestFuncs)
+}
+
+func (s *allModelWatcherStateSuite) performChangeTestCasesWith(c *gc.C, newBacking func() Backing, changeTestFuncs []changeTestFunc) {
 	for i, changeTestFunc := range changeTestFuncs {
 		func() { // in aid of per-loop defers
 			defer s.Reset(c)
@@ -1439,7 +1453,7 @@ func (s *allModelWatcherStateSuite) performChangeTestCases(c *gc.C, changeTestFu
 			test0 := changeTestFunc(c, s.state)
 
 			c.Logf("test %d. %s", i, test0.about)
-			b := s.NewAllModelWatcherStateBacking()
+			b := newBacking()
 			defer b.Release()
 			all := newStore()
 
@@ -1506,6 +1520,29 @@ func (s *allModelWatcherStateSuite) TestChangeRemoteApplications(c *gc.C) {
 	testChangeRemoteApplications(c, s.performChangeTestCases)
 }
 
+func (s *allModelWatcherStateSuite) TestChangeApplicationOffers(c *gc.C) {
+	testChangeApplicationOffers(c, s.performCacheChangeTestCases)
+}
+
+func (s *allModelWatcherStateSuite) TestChangeStorageInstances(c *gc.C) {
+	testChangeStorageInstances(c, s.performCacheChangeTestCases)
+}
+
+func (s *allModelWatcherStateSuite) TestCacheOnlyCollections(c *gc.C) {
+	// Clients of the AllModelWatcher facade don't know about offers
+	// or storage instances, so only the model cache watches them.
+	b := s.NewAllModelWatcherStateBacking().(*allModelWatcherStateBacking)
+	c.Check(b.collectionByName, gc.Not(jc.HasKey), applicationOffersC)
+	c.Check(b.collectionByName, gc.Not(jc.HasKey), storageInstancesC)
+
+	b = s.NewModelCacheWatcherStateBacking().(*allModelWatcherStateBacking)
+	c.Check(b.collectionByName, jc.HasKey, applicationOffersC)
+	c.Check(b.collectionByName, jc.HasKey, storageInstancesC)
+	for _, name := range allModelWatcherCollections {
+		c.Check(b.collectionByName, jc.HasKey, name)
+	}
+}
+
 func (s *allModelWatcherStateSuite) TestChangeModels(c *gc.C) {
 	changeTestFuncs := []changeTestFunc{
 		func(c *gc.C, st *State) changeTestCase {
@@ -2615,7 +2652,6 @@ func testChangeRelations(c *gc.C, owner names.UserTag, runChangeTests func(*gc.C
 }
 
 func testChangeApplications(c *gc.C, owner names.UserTag, runChangeTests func(*gc.C, []changeTestFunc)) {
-	// TODO(wallyworld) - add test for changing application status when that is implemented
 	changeTestFuncs := []changeTestFunc{
 		// Applications.
 		func(c *gc.C, st *State) changeTestCase {
@@ -2699,6 +2735,41 @@ func testChangeApplications(c *gc.C, owner names.UserTag, runChangeTests func(*g
 						Config:      charm.Settings{"blog-title": "boring"},
 					}}}
 		},
+		func(c *gc.C, st *State) changeTestCase {
+			app := AddTestingApplication(c, st, "wordpress", AddTestingCharm(c, st, "wordpress"))
+			now := st.clock().Now()
+			err := app.SetStatus(status.StatusInfo{
+				Status:  status.Active,
+				Message: "ready",
+				Data:    map[string]interface{}{"foo": "bar"},
+				Since:   &now,
+			})
+			c.Assert(err, jc.ErrorIsNil)
+			return changeTestCase{
+				about: "application status is changed when it is set",
+				initialContents: []params.EntityInfo{&params.ApplicationInfo{
+					ModelUUID: st.ModelUUID(),
+					Name:      "wordpress",
+					CharmURL:  "local:quantal/quantal-wordpress-3",
+				}},
+				change: watcher.Change{
+					C:  "statuses",
+					Id: st.docID(app.globalKey()),
+				},
+				expectContents: []params.EntityInfo{
+					&params.ApplicationInfo{
+						ModelUUID: st.ModelUUID(),
+						Name:      "wordpress",
+						CharmURL:  "local:quantal/quantal-wordpress-3",
+						Status: params.StatusInfo{
+							Current: "active",
+							Message: "ready",
+							Data:    map[string]interface{}{"foo": "bar"},
+							Since:   &now,
+						},
+						StatusExplicitlySet: true,
+					}}}
+		},
 		func(c *gc.C, st *State) changeTestCase {
 			app := AddTestingApplication(c, st, "wordpress", AddTestingCharm(c, st, "wordpress"))
 			unit, err := app.AddUnit(AddUnitParams{})
@@ -4001,6 +4072,62 @@ func testChangeRemoteApplications(c *gc.C, runChangeTests func(*gc.C, []changeTe
 	runChangeTests(c, changeTestFuncs)
 }
 
+func testChangeStorageInstances(c *gc.C, runChangeTests func(*gc.C, []changeTestFunc)) {
+	changeTestFuncs := []changeTestFunc{
+		func(c *gc.C, st *State) changeTestCase {
+			return changeTestCase{
+				about: "no storage instance in state, no storage instance in store -> do nothing",
+				change: watcher.Change{
+					C:  "storageinstances",
+					Id: st.docID("data/0"),
+				}}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			return changeTestCase{
+				about: "storage instance is removed if it's not in backing",
+				initialContents: []params.EntityInfo{
+					&params.StorageInstanceInfo{
+						ModelUUID: st.ModelUUID(),
+						Id:        "data/0",
+					},
+				},
+				change: watcher.Change{
+					C:  "storageinstances",
+					Id: st.docID("data/0"),
+				}}
+		},
+		func(c *gc.C, st *State) changeTestCase {
+			ch := AddTestingCharm(c, st, "storage-block")
+			app := AddTestingApplicationWithStorage(c, st, "storage-block", ch, map[string]StorageConstraints{
+				"data": {Pool: "loop", Size: 1024, Count: 1},
+			})
+			_, err := app.AddUnit(AddUnitParams{})
+			c.Assert(err, jc.ErrorIsNil)
+
+			return changeTestCase{
+				about: "storage instance is added if it's in backing but not in Store",
+				change: watcher.Change{
+					C:  "storageinstances",
+					Id: st.docID("data/0"),
+				},
+				expectContents: []params.EntityInfo{
+					&params.StorageInstanceInfo{
+						ModelUUID:       st.ModelUUID(),
+						Id:              "data/0",
+						Kind:            "block",
+						Life:            life.Alive,
+						Owner:           "unit-storage-block-0",
+						StorageName:     "data",
+						Pool:            "loop",
+						Size:            1024,
+						AttachmentCount: 1,
+					},
+				}}
+		},
+	}
+	runChangeTests(c, changeTestFuncs)
+}
+
 func testChangeApplicationOffers(c *gc.C, runChangeTests func(*gc.C, []changeTestFunc)) {
 	addOffer := func(c *gc.C, st *State) (params.ApplicationOfferInfo, *User, string) {
 		owner, err := st.AddUser("owner", "owner", "password", "admin")
@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/juju/errors"
@@ -19,11 +20,26 @@ import (
 // due to the fact that it is not accessed directly, but through
 // Annotations/Annotation below.
 // Note also the correspondence with AnnotationInfo in apiserver/params.
+// Pairs holds the annotations as sorted "key=value" strings; it is
+// indexed, so that entities can be found by their annotations across
+// all models.
 type annotatorDoc struct {
 	ModelUUID   string            `bson:"model-uuid"`
 	GlobalKey   string            `bson:"globalkey"`
 	Tag         string            `bson:"tag"`
 	Annotations map[string]string `bson:"annotations"`
+	Pairs       []string          `bson:"pairs"`
+}
+
+// annotationPairs returns the sorted "key=value" strings stored in
+// the pairs field of an annotatorDoc.
+func annotationPairs(annotations map[string]string) []string {
+	pairs := make([]string, 0, len(annotations))
+	for key, value := range annotations {
+		pairs = append(pairs, key+"="+value)
+	}
+	sort.Strings(pairs)
+	return pairs
 }
 
 // SetAnnotations adds key/value pairs to annotations in MongoDB.
@@ -56,16 +72,31 @@ func (m *Model) SetAnnotations(entity GlobalEntity, annotations map[string]strin
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		annotations, closer := m.st.db().GetCollection(annotationsC)
 		defer closer()
-		if count, err := annotations.FindId(entity.globalKey()).Count(); err != nil {
-			return nil, err
-		} else if count == 0 {
+		var doc struct {
+			Annotations map[string]string `bson:"annotations"`
+			TxnRevno    int64             `bson:"txn-revno"`
+		}
+		err := annotations.FindId(entity.globalKey()).One(&doc)
+		if err == mgo.ErrNotFound {
 			// Check that the annotator entity was not previously destroyed.
 			if attempt != 0 {
 				return nil, fmt.Errorf("%s no longer exists", entity.Tag())
 			}
 			return insertAnnotationsOps(m.st, entity, toInsert)
+		} else if err != nil {
+			return nil, err
+		}
+		merged := make(map[string]string)
+		for key, value := range doc.Annotations {
+			merged[key] = value
+		}
+		for key := range toRemove {
+			delete(merged, key)
 		}
-		return updateAnnotations(m.st, entity, toUpdate, toRemove), nil
+		for key, value := range toInsert {
+			merged[key] = value
+		}
+		return updateAnnotations(m.st, entity, toUpdate, toRemove, annotationPairs(merged), doc.TxnRevno), nil
 	}
 	return m.st.db().Run(buildTxn)
 }
@@ -96,6 +127,38 @@ func (m *Model) Annotation(entity GlobalEntity, key string) (string, error) {
 	return ann[key], nil
 }
 
+// AnnotatedEntity describes an entity found by its annotations.
+type AnnotatedEntity struct {
+	ModelUUID   string
+	Tag         string
+	Annotations map[string]string
+}
+
+// FindAnnotations returns the entities, in all the models in the
+// controller, which have all of the given annotations. The entities
+// are sorted by model UUID and tag.
+func (st *State) FindAnnotations(match map[string]string) ([]AnnotatedEntity, error) {
+	if len(match) == 0 {
+		return nil, errors.NotValidf("empty annotation match")
+	}
+	annotations, closer := st.db().GetRawCollection(annotationsC)
+	defer closer()
+	var docs []annotatorDoc
+	query := bson.D{{"pairs", bson.D{{"$all", annotationPairs(match)}}}}
+	if err := annotations.Find(query).Sort("model-uuid", "tag").All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]AnnotatedEntity, len(docs))
+	for i, doc := range docs {
+		result[i] = AnnotatedEntity{
+			ModelUUID:   doc.ModelUUID,
+			Tag:         doc.Tag,
+			Annotations: doc.Annotations,
+		}
+	}
+	return result, nil
+}
+
 // insertAnnotationsOps returns the operations required to insert annotations in MongoDB.
 func insertAnnotationsOps(st *State, entity GlobalEntity, toInsert map[string]string) ([]txn.Op, error) {
 	tag := entity.Tag()
@@ -107,6 +170,7 @@ func insertAnnotationsOps(st *State, entity GlobalEntity, toInsert map[string]st
 			GlobalKey:   entity.globalKey(),
 			Tag:         tag.String(),
 			Annotations: toInsert,
+			Pairs:       annotationPairs(toInsert),
 		},
 	}}
 
@@ -133,13 +197,16 @@ func insertAnnotationsOps(st *State, entity GlobalEntity, toInsert map[string]st
 	}), nil
 }
 
-// updateAnnotations returns the operations required to update or remove annotations in MongoDB.
-func updateAnnotations(mb modelBackend, entity GlobalEntity, toUpdate, toRemove bson.M) []txn.Op {
+// updateAnnotations returns the operations required to update or remove
+// annotations in MongoDB. The pairs are those of the resulting
+// annotations, which were computed from the document with the given
+// txn-revno.
+func updateAnnotations(mb modelBackend, entity GlobalEntity, toUpdate, toRemove bson.M, pairs []string, txnRevno int64) []txn.Op {
 	return []txn.Op{{
 		C:      annotationsC,
 		Id:     mb.docID(entity.globalKey()),
-		Assert: txn.DocExists,
-		Update: setUnsetUpdateAnnotations(toUpdate, toRemove),
+		Assert: bson.D{{"txn-revno", txnRevno}},
+		Update: setUnsetUpdateAnnotations(toUpdate, toRemove, pairs),
 	}}
 }
 
@@ -154,15 +221,13 @@ func annotationRemoveOp(mb modelBackend, id string) txn.Op {
 }
 
 // setUnsetUpdateAnnotations returns a bson.D for use
-// in an annotationsC txn.Op's Update field, containing $set and
-// $unset operators if the corresponding operands
-// are non-empty.
-func setUnsetUpdateAnnotations(set, unset bson.M) bson.D {
-	var update bson.D
-	if len(set) > 0 {
-		set = bson.M(subDocKeys(map[string]interface{}(set), "annotations"))
-		update = append(update, bson.DocElem{Name: "$set", Value: set})
-	}
+// in an annotationsC txn.Op's Update field, containing a $set
+// operator for the annotations and their pairs, and an $unset
+// operator if the corresponding operand is non-empty.
+func setUnsetUpdateAnnotations(set, unset bson.M, pairs []string) bson.D {
+	set = bson.M(subDocKeys(map[string]interface{}(set), "annotations"))
+	set["pairs"] = pairs
+	update := bson.D{{Name: "$set", Value: set}}
 	if len(unset) > 0 {
 		unset = bson.M(subDocKeys(map[string]interface{}(unset), "annotations"))
 		update = append(update, bson.DocElem{Name: "$unset", Value: unset})
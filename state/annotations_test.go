@@ -134,6 +134,57 @@ func (s *AnnotationsSuite) TestSetAnnotationsConcurrently(c *gc.C) {
 	assertAnnotation(c, s.Model, s.testEntity, key, last)
 }
 
+func (s *AnnotationsSuite) TestFindAnnotations(c *gc.C) {
+	err := s.Model.SetAnnotations(s.testEntity, map[string]string{"team": "payments", "tier": "gold"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.SetAnnotations(s.Model, map[string]string{"team": "payments"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	found, err := s.State.FindAnnotations(map[string]string{"team": "payments"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.SameContents, []state.AnnotatedEntity{{
+		ModelUUID:   s.State.ModelUUID(),
+		Tag:         s.testEntity.Tag().String(),
+		Annotations: map[string]string{"team": "payments", "tier": "gold"},
+	}, {
+		ModelUUID:   s.State.ModelUUID(),
+		Tag:         s.Model.Tag().String(),
+		Annotations: map[string]string{"team": "payments"},
+	}})
+
+	found, err = s.State.FindAnnotations(map[string]string{"team": "payments", "tier": "gold"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, gc.HasLen, 1)
+	c.Assert(found[0].Tag, gc.Equals, s.testEntity.Tag().String())
+
+	found, err = s.State.FindAnnotations(map[string]string{"team": "billing"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, gc.HasLen, 0)
+}
+
+func (s *AnnotationsSuite) TestFindAnnotationsAfterUpdate(c *gc.C) {
+	err := s.Model.SetAnnotations(s.testEntity, map[string]string{"team": "payments", "tier": "gold"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.Model.SetAnnotations(s.testEntity, map[string]string{"team": "billing", "tier": ""})
+	c.Assert(err, jc.ErrorIsNil)
+
+	found, err := s.State.FindAnnotations(map[string]string{"team": "payments"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, gc.HasLen, 0)
+	found, err = s.State.FindAnnotations(map[string]string{"team": "billing"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(found, jc.DeepEquals, []state.AnnotatedEntity{{
+		ModelUUID:   s.State.ModelUUID(),
+		Tag:         s.testEntity.Tag().String(),
+		Annotations: map[string]string{"team": "billing"},
+	}})
+}
+
+func (s *AnnotationsSuite) TestFindAnnotationsEmptyMatch(c *gc.C) {
+	_, err := s.State.FindAnnotations(nil)
+	c.Assert(err, gc.ErrorMatches, "empty annotation match not valid")
+}
+
 type AnnotationsModelSuite struct {
 	ConnSuite
 }
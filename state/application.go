@@ -26,6 +26,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/leadership"
@@ -55,11 +56,13 @@ type applicationDoc struct {
 	Channel              string       `bson:"cs-channel"`
 	CharmModifiedVersion int          `bson:"charmmodifiedversion"`
 	ForceCharm           bool         `bson:"forcecharm"`
+	CanaryUpgrade        bool         `bson:"canary-upgrade,omitempty"`
 	Life                 Life         `bson:"life"`
 	UnitCount            int          `bson:"unitcount"`
 	RelationCount        int          `bson:"relationcount"`
 	Exposed              bool         `bson:"exposed"`
 	MinUnits             int          `bson:"minunits"`
+	MaxUnits             int          `bson:"maxunits,omitempty"`
 	Tools                *tools.Tools `bson:",omitempty"`
 	TxnRevno             int64        `bson:"txn-revno"`
 	MetricCredentials    []byte       `bson:"metric-credentials"`
@@ -69,6 +72,10 @@ type applicationDoc struct {
 	PasswordHash string `bson:"passwordhash"`
 	// Placement is the placement directive that should be used allocating units/pods.
 	Placement string `bson:"placement,omitempty"`
+
+	// LeadershipPin records that an operator has pinned the
+	// application's leader.
+	LeadershipPin *leadershipPinDoc `bson:"leadership-pin,omitempty"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -335,7 +342,7 @@ func (op *DestroyApplicationOperation) destroyOps() ([]txn.Op, error) {
 		// relation as well as all operational errors encountered.
 		// If the 'force' is not set and the call came across some errors,
 		// these errors will be fatal and no operations will be returned.
-		relOps, isRemove, err := rel.destroyOps(op.app.doc.Name, &op.ForcedOperation)
+		relOps, isRemove, err := rel.destroyOps(op.app.doc.Name, 0, &op.ForcedOperation)
 		if errors.Cause(err) == errAlreadyDying {
 			relOps = []txn.Op{{
 				C:      relationsC,
@@ -578,6 +585,7 @@ func (a *Application) removeOps(asserts bson.D, op *ForcedOperation) ([]txn.Op,
 		removeSettingsOp(settingsC, a.applicationConfigKey()),
 		removeModelApplicationRefOp(a.st, name),
 		removePodSpecOp(a.ApplicationTag()),
+		removeCharmDeploymentsOp(a.st, name),
 	)
 	return ops, nil
 }
@@ -633,6 +641,12 @@ func (a *Application) IsPrincipal() bool {
 	return !a.doc.Subordinate
 }
 
+// CanaryUpgrade returns whether the last charm upgrade of the application
+// was requested to roll out to its units one at a time.
+func (a *Application) CanaryUpgrade() bool {
+	return a.doc.CanaryUpgrade
+}
+
 // CharmModifiedVersion increases whenever the application's charm is changed in any
 // way.
 func (a *Application) CharmModifiedVersion() int {
@@ -1230,6 +1244,11 @@ type SetCharmConfig struct {
 	// EndpointBindings is an operator-defined map of endpoint names to
 	// space names that should be merged with any existing bindings.
 	EndpointBindings map[string]string
+
+	// CanaryUpgrade, if true, requests that the units of a CAAS
+	// application are upgraded one at a time, with each one checked
+	// for health before the next is upgraded.
+	CanaryUpgrade bool
 }
 
 // SetCharm changes the charm for the application.
@@ -1373,6 +1392,11 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 			ops = append(ops, chng...)
 			newCharmModifiedVersion++
 		}
+		ops = append(ops, txn.Op{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Update: bson.D{{"$set", bson.D{{"canary-upgrade", cfg.CanaryUpgrade}}}},
+		})
 
 		// Always update bindings regardless of whether we upgrade to a
 		// new version or stay at the previous version.
@@ -1403,6 +1427,7 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	a.doc.CharmURL = cfg.Charm.URL()
 	a.doc.Channel = channel
 	a.doc.ForceCharm = cfg.ForceUnits
+	a.doc.CanaryUpgrade = cfg.CanaryUpgrade
 	a.doc.CharmModifiedVersion = newCharmModifiedVersion
 	return nil
 }
@@ -1584,6 +1609,9 @@ func (a *Application) ChangeScale(scaleChange int) (int, error) {
 	if newScale < 0 {
 		return a.doc.DesiredScale, errors.NotValidf("cannot remove more units than currently exist")
 	}
+	if err := a.checkScaleQuota(newScale); err != nil {
+		return a.doc.DesiredScale, errors.Trace(err)
+	}
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		if attempt > 0 {
 			if err := a.Refresh(); err != nil {
@@ -1631,11 +1659,17 @@ func (a *Application) ChangeScale(scaleChange int) (int, error) {
 }
 
 // SetScale sets the application's desired scale value.
-// This is used on CAAS models.
+// This is used on CAAS models. A scale set with force, by a user rather
+// than reported by the cluster, must be within the model's units quota.
 func (a *Application) SetScale(scale int, generation int64, force bool) error {
 	if scale < 0 {
 		return errors.NotValidf("application scale %d", scale)
 	}
+	if force {
+		if err := a.checkScaleQuota(scale); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	svcInfo, err := a.ServiceInfo()
 	if err != nil && !errors.IsNotFound(err) {
 		return errors.Trace(err)
@@ -1887,6 +1921,14 @@ func (a *Application) addUnitOpsWithCons(args applicationAddUnitOpsArgs) (string
 		ops = append(ops, createConstraintsOp(agentGlobalKey, args.cons))
 	}
 
+	eventOp, err := addModelEventOp(a.st, ModelEventUnitAdded, unitTag.String(), map[string]string{
+		"application": a.doc.Name,
+	})
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	ops = append(ops, eventOp)
+
 	// At the last moment we still have the statusDocs in scope, set the initial
 	// history entries. This is risky, and may lead to extra entries, but that's
 	// an intrinsic problem with mixing txn and non-txn ops -- we can't sync
@@ -2062,9 +2104,13 @@ type AddUnitParams struct {
 	Ports *[]string
 }
 
-// AddUnit adds a new principal unit to the application.
+// AddUnit adds a new principal unit to the application, so long as the
+// model has not reached its units quota.
 func (a *Application) AddUnit(args AddUnitParams) (unit *Unit, err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot add unit to application %q", a)
+	if err := a.st.CheckModelQuota(controller.MaxModelUnits, 1); err != nil {
+		return nil, errors.Trace(err)
+	}
 	name, ops, err := a.addUnitOps("", args, nil)
 	if err != nil {
 		return nil, err
@@ -2101,6 +2147,12 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D, op *ForcedOperation
 	if op.FatalError(err) {
 		return nil, errors.Trace(err)
 	}
+	eventOp, err := addModelEventOp(a.st, ModelEventUnitRemoved, u.Tag().String(), map[string]string{
+		"application": a.doc.Name,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	observedFieldsMatch := bson.D{
 		{"charmurl", u.doc.CharmURL},
@@ -2117,9 +2169,11 @@ func (a *Application) removeUnitOps(u *Unit, asserts bson.D, op *ForcedOperation
 		removeStatusOp(a.st, u.globalAgentKey()),
 		removeStatusOp(a.st, u.globalKey()),
 		removeStatusOp(a.st, u.globalCloudContainerKey()),
+		finishCharmDeploymentOp(a.st, a.doc.Name, u.doc.Name),
 		removeConstraintsOp(u.globalAgentKey()),
 		annotationRemoveOp(a.st, u.globalKey()),
 		newCleanupOp(cleanupRemovedUnit, u.doc.Name, op.Force),
+		eventOp,
 	}
 	ops = append(ops, portsOps...)
 	ops = append(ops, resOps...)
@@ -2634,45 +2688,45 @@ func (a *Application) DeviceConstraints() (map[string]DeviceConstraints, error)
 	return cons, nil
 }
 
-// Status returns the status of the application.
-// Only unit leaders are allowed to set the status of the application.
-// If no status is recorded, then there are no unit leaders and the
-// status is derived from the unit status values.
+// Status returns the status of the application, as displayed; see
+// DisplayStatus.
 func (a *Application) Status() (status.StatusInfo, error) {
+	info, _, err := a.DisplayStatus()
+	return info, err
+}
+
+// DisplayStatus returns the status displayed for the application, and
+// whether it was derived from the workload statuses of its units.
+// Only unit leaders are allowed to set the status of the application;
+// until one has, the status is derived as described by
+// ApplicationDisplayStatus.
+func (a *Application) DisplayStatus() (status.StatusInfo, bool, error) {
 	statuses, closer := a.st.db().GetCollection(statusesC)
 	defer closer()
-	query := statuses.Find(bson.D{{"_id", a.globalKey()}, {"neverset", true}})
-	if count, err := query.Count(); err != nil {
-		return status.StatusInfo{}, errors.Trace(err)
-	} else if count != 0 {
-		// This indicates that SetStatus has never been called on this application.
-		// This in turn implies the application status document is likely to be
-		// inaccurate, so we return aggregated unit statuses instead.
-		//
-		// TODO(fwereade): this is completely wrong and will produce bad results
-		// in not-very-challenging scenarios. The leader unit remains responsible
-		// for setting the application status in a timely way, *whether or not the
-		// charm's hooks exists or sets an application status*. This logic should be
-		// removed as soon as possible, and the responsibilities implemented in
-		// the right places rather than being applied at seeming random.
+	var doc statusDocWithID
+	err := statuses.FindId(a.globalKey()).One(&doc)
+	if err == mgo.ErrNotFound {
+		return status.StatusInfo{}, false, errors.Annotate(errors.NotFoundf("application"), "cannot get status")
+	} else if err != nil {
+		return status.StatusInfo{}, false, errors.Annotate(err, "cannot get status")
+	}
+	var unitStatuses []status.StatusInfo
+	if !doc.ExplicitlySet {
 		units, err := a.AllUnits()
 		if err != nil {
-			return status.StatusInfo{}, err
+			return status.StatusInfo{}, false, errors.Trace(err)
 		}
 		logger.Tracef("application %q has %d units", a.Name(), len(units))
-		var unitStatuses []status.StatusInfo
 		for _, unit := range units {
 			unitStatus, err := unit.Status()
 			if err != nil {
-				return status.StatusInfo{}, errors.Annotatef(err, "deriving application status from %q", unit.Name())
+				return status.StatusInfo{}, false, errors.Annotatef(err, "deriving application status from %q", unit.Name())
 			}
 			unitStatuses = append(unitStatuses, unitStatus)
 		}
-		if len(unitStatuses) > 0 {
-			return deriveApplicationStatus(unitStatuses), nil
-		}
 	}
-	return getStatus(a.st.db(), a.globalKey(), "application")
+	info, derived := ApplicationDisplayStatus(doc.asStatusInfo(), doc.ExplicitlySet, unitStatuses)
+	return info, derived, nil
 }
 
 func expectWorkload(st *State, appName string) (bool, error) {
@@ -2694,14 +2748,29 @@ func expectWorkload(st *State, appName string) (bool, error) {
 
 // SetStatus sets the status for the application.
 func (a *Application) SetStatus(statusInfo status.StatusInfo) error {
+	params, err := a.setStatusParams(statusInfo)
+	if err != nil {
+		return err
+	}
+	return setStatus(a.st.db(), params)
+}
+
+// setStatusParams is part of the batchStatusSetter interface.
+func (a *Application) setStatusParams(statusInfo status.StatusInfo) (setStatusParams, error) {
 	if !status.ValidWorkloadStatus(statusInfo.Status) {
-		return errors.Errorf("cannot set invalid status %q", statusInfo.Status)
+		return setStatusParams{}, errors.Errorf("cannot set invalid status %q", statusInfo.Status)
+	}
+
+	if pin, ok := a.LeadershipPin(); ok {
+		// The charm doesn't know the leader has been pinned, so
+		// keep the pin's details in the status it sets.
+		statusInfo.Data = pin.withStatusData(statusInfo.Data)
 	}
 
 	var newHistory *statusDoc
 	m, err := a.st.Model()
 	if err != nil {
-		return errors.Trace(err)
+		return setStatusParams{}, errors.Trace(err)
 	}
 	if m.Type() == ModelTypeCAAS {
 		// Application status for a caas model needs to consider status
@@ -2709,20 +2778,20 @@ func (a *Application) SetStatus(statusInfo status.StatusInfo) error {
 		// override what is set here.
 		expectWorkload, err := expectWorkload(a.st, a.Name())
 		if err != nil {
-			return errors.Trace(err)
+			return setStatusParams{}, errors.Trace(err)
 		}
 		operatorStatus, err := getStatus(a.st.db(), applicationGlobalOperatorKey(a.Name()), "operator")
 		if err == nil {
 			newHistory, err = caasHistoryRewriteDoc(statusInfo, operatorStatus, expectWorkload, caasApplicationDisplayStatus, a.st.clock())
 			if err != nil {
-				return errors.Trace(err)
+				return setStatusParams{}, errors.Trace(err)
 			}
 		} else if !errors.IsNotFound(err) {
-			return errors.Trace(err)
+			return setStatusParams{}, errors.Trace(err)
 		}
 	}
 
-	return setStatus(a.st.db(), setStatusParams{
+	return setStatusParams{
 		badge:            "application",
 		globalKey:        a.globalKey(),
 		status:           statusInfo.Status,
@@ -2730,7 +2799,7 @@ func (a *Application) SetStatus(statusInfo status.StatusInfo) error {
 		rawData:          statusInfo.Data,
 		updated:          timeOrNow(statusInfo.Since, a.st.clock()),
 		historyOverwrite: newHistory,
-	})
+	}, nil
 }
 
 // SetOperatorStatus sets the operator status for an application.
@@ -2789,6 +2858,75 @@ func (a *Application) StatusHistory(filter status.StatusHistoryFilter) ([]status
 	return statusHistory(args)
 }
 
+// UnitStatusHistory is a status history record of one of an
+// application's units.
+type UnitStatusHistory struct {
+	status.StatusInfo
+
+	// Unit is the name of the unit.
+	Unit string
+
+	// Kind is status.KindWorkload for the unit's workload status, or
+	// status.KindUnitAgent for the status of its agent.
+	Kind status.HistoryKind
+}
+
+// UnitsStatusHistory returns the status history of all the
+// application's units, interleaved and newest first, fetched in a
+// single query. The kind is status.KindWorkload or status.KindUnitAgent
+// to fetch only workload or agent statuses, or status.KindUnit for
+// both. The filter's size limits the number of records returned for
+// all the units together.
+func (a *Application) UnitsStatusHistory(kind status.HistoryKind, filter status.StatusHistoryFilter) ([]UnitStatusHistory, error) {
+	var workload, agent bool
+	switch kind {
+	case status.KindUnit:
+		workload, agent = true, true
+	case status.KindWorkload:
+		workload = true
+	case status.KindUnitAgent:
+		agent = true
+	default:
+		return nil, errors.NotValidf("unit status history kind %q", kind)
+	}
+	units, err := a.AllUnits()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	type keyInfo struct {
+		unit string
+		kind status.HistoryKind
+	}
+	keys := make(map[string]keyInfo)
+	var globalKeys []string
+	for _, unit := range units {
+		if workload {
+			key := unit.globalKey()
+			keys[key] = keyInfo{unit.Name(), status.KindWorkload}
+			globalKeys = append(globalKeys, key)
+		}
+		if agent {
+			key := unit.globalAgentKey()
+			keys[key] = keyInfo{unit.Name(), status.KindUnitAgent}
+			globalKeys = append(globalKeys, key)
+		}
+	}
+	history, err := multiStatusHistory(a.st.db(), globalKeys, filter)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get status history of units of application %q", a)
+	}
+	results := make([]UnitStatusHistory, len(history))
+	for i, h := range history {
+		info := keys[h.globalKey]
+		results[i] = UnitStatusHistory{
+			StatusInfo: h.StatusInfo,
+			Unit:       info.unit,
+			Kind:       info.kind,
+		}
+	}
+	return results, nil
+}
+
 // ApplicationAndUnitsStatus returns the status for this application and all its units.
 func (a *Application) ApplicationAndUnitsStatus() (status.StatusInfo, map[string]status.StatusInfo, error) {
 	applicationStatus, err := a.Status()
@@ -2811,33 +2949,6 @@ func (a *Application) ApplicationAndUnitsStatus() (status.StatusInfo, map[string
 
 }
 
-func deriveApplicationStatus(statuses []status.StatusInfo) status.StatusInfo {
-	var result status.StatusInfo
-	for _, unitStatus := range statuses {
-		currentSeverity := statusServerities[result.Status]
-		unitSeverity := statusServerities[unitStatus.Status]
-		if unitSeverity > currentSeverity {
-			result.Status = unitStatus.Status
-			result.Message = unitStatus.Message
-			result.Data = unitStatus.Data
-			result.Since = unitStatus.Since
-		}
-	}
-	return result
-}
-
-// statusSeverities holds status values with a severity measure.
-// Status values with higher severity are used in preference to others.
-var statusServerities = map[status.Status]int{
-	status.Error:       100,
-	status.Blocked:     90,
-	status.Waiting:     80,
-	status.Maintenance: 70,
-	status.Active:      60,
-	status.Terminated:  50,
-	status.Unknown:     40,
-}
-
 type addApplicationOpsArgs struct {
 	applicationDoc    *applicationDoc
 	statusDoc         statusDoc
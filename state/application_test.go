@@ -22,6 +22,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/application"
 	"github.com/juju/juju/core/constraints"
 	"github.com/juju/juju/core/crossmodel"
@@ -107,6 +108,28 @@ func (s *ApplicationSuite) TestSetCharm(c *gc.C) {
 	c.Assert(force, jc.IsTrue)
 }
 
+func (s *ApplicationSuite) TestSetCharmCanaryUpgrade(c *gc.C) {
+	c.Assert(s.mysql.CanaryUpgrade(), jc.IsFalse)
+
+	sch := s.AddMetaCharm(c, "mysql", metaBase, 2)
+	err := s.mysql.SetCharm(state.SetCharmConfig{
+		Charm:         sch,
+		CanaryUpgrade: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.CanaryUpgrade(), jc.IsTrue)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.CanaryUpgrade(), jc.IsTrue)
+
+	// Setting the same charm again without the flag clears it.
+	err = s.mysql.SetCharm(state.SetCharmConfig{Charm: sch})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.CanaryUpgrade(), jc.IsFalse)
+}
+
 func (s *ApplicationSuite) TestLXDProfileSetCharm(c *gc.C) {
 	charm := s.AddTestingCharm(c, "lxd-profile")
 	app := s.AddTestingApplication(c, "lxd-profile", charm)
@@ -3220,6 +3243,54 @@ func (s *ApplicationSuite) TestStatus(c *gc.C) {
 	}
 }
 
+func (s *ApplicationSuite) TestDisplayStatus(c *gc.C) {
+	now := coretesting.ZeroTime()
+	info, derived, err := s.mysql.DisplayStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(derived, jc.IsFalse)
+	c.Check(info.Status, gc.Equals, status.Waiting)
+	c.Check(info.Message, gc.Equals, status.MessageWaitForMachine)
+
+	u, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.SetStatus(status.StatusInfo{Status: status.Blocked, Message: "needs a database", Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+	info, derived, err = s.mysql.DisplayStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(derived, jc.IsTrue)
+	c.Check(info.Status, gc.Equals, status.Blocked)
+	c.Check(info.Message, gc.Equals, "needs a database")
+
+	err = s.mysql.SetStatus(status.StatusInfo{Status: status.Active, Message: "ready", Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+	info, derived, err = s.mysql.DisplayStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(derived, jc.IsFalse)
+	c.Check(info.Status, gc.Equals, status.Active)
+	c.Check(info.Message, gc.Equals, "ready")
+}
+
+func (s *ApplicationSuite) TestApplicationDisplayStatus(c *gc.C) {
+	appStatus := status.StatusInfo{Status: status.Waiting, Message: status.MessageWaitForMachine}
+	unitStatuses := []status.StatusInfo{
+		{Status: status.Active, Message: "ready"},
+		{Status: status.Maintenance, Message: "installing"},
+		{Status: status.Maintenance, Message: "upgrading"},
+	}
+
+	info, derived := state.ApplicationDisplayStatus(appStatus, false, unitStatuses)
+	c.Check(derived, jc.IsTrue)
+	c.Check(info, jc.DeepEquals, status.StatusInfo{Status: status.Maintenance, Message: "installing"})
+
+	info, derived = state.ApplicationDisplayStatus(appStatus, true, unitStatuses)
+	c.Check(derived, jc.IsFalse)
+	c.Check(info, jc.DeepEquals, appStatus)
+
+	info, derived = state.ApplicationDisplayStatus(appStatus, false, nil)
+	c.Check(derived, jc.IsFalse)
+	c.Check(info, jc.DeepEquals, appStatus)
+}
+
 const oneRequiredStorageMeta = `
 storage:
   data0:
@@ -4168,6 +4239,21 @@ func (s *CAASApplicationSuite) TestChangeScale(c *gc.C) {
 	c.Assert(s.app.GetScale(), gc.Equals, 1)
 }
 
+func (s *CAASApplicationSuite) TestChangeScaleQuotaExceeded(c *gc.C) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{controller.MaxModelUnits: 3}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	newScale, err := s.app.ChangeScale(5)
+	c.Assert(err, jc.Satisfies, state.IsQuotaExceededError)
+	c.Assert(newScale, gc.Equals, 0)
+
+	err = s.app.SetScale(5, 0, true)
+	c.Assert(err, jc.Satisfies, state.IsQuotaExceededError)
+	// A scale reported by the cluster isn't checked.
+	err = s.app.SetScale(5, 1, false)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *CAASApplicationSuite) TestWatchScale(c *gc.C) {
 	// Empty initial event.
 	w := s.app.WatchScale()
@@ -288,7 +288,7 @@ func (op *RemoveOfferOperation) internalRemove(offer *crossmodel.ApplicationOffe
 			// When 'force' is set, this call will return needed operations
 			// and accumulate all operational errors encountered in the operation.
 			// If the 'force' is not set, any error will be fatal and no operations will be returned.
-			relOps, _, err := rel.destroyOps("", &op.ForcedOperation)
+			relOps, _, err := rel.destroyOps("", 0, &op.ForcedOperation)
 			if err == errAlreadyDying {
 				continue
 			} else if err != nil {
@@ -914,6 +914,22 @@ var assignUsingConstraintsTests = []struct {
 		unitConstraints:         "cpu-power=50",
 		hardwareCharacteristics: "mem=4G",
 		assignOk:                false,
+	}, {
+		unitConstraints:         "gpus=1",
+		hardwareCharacteristics: "gpus=2 gpu-type=v100",
+		assignOk:                true,
+	}, {
+		unitConstraints:         "gpus=4",
+		hardwareCharacteristics: "gpus=2 gpu-type=v100",
+		assignOk:                false,
+	}, {
+		unitConstraints:         "gpu-type=k80",
+		hardwareCharacteristics: "gpus=2 gpu-type=v100",
+		assignOk:                false,
+	}, {
+		unitConstraints:         "gpus=1",
+		hardwareCharacteristics: "mem=4G",
+		assignOk:                false,
 	}, {
 		unitConstraints:         "root-disk=8192",
 		hardwareCharacteristics: "cpu-power=50",
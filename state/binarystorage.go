@@ -7,6 +7,7 @@ import (
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
 	"gopkg.in/juju/blobstore.v2"
+	"gopkg.in/mgo.v2"
 
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/state/binarystorage"
@@ -73,3 +74,13 @@ func (sc *storageCloser) Close() error {
 	sc.closer()
 	return nil
 }
+
+// AgentBinaryBlobs returns the controller's agent binary files as held
+// in the blobstore reached through the given mongo session. When the
+// session is connected directly to a single replica set member, this
+// shows which binaries have been replicated to that member.
+func (st *State) AgentBinaryBlobs(session *mgo.Session) *binarystorage.Blobs {
+	rs := blobstore.NewGridFS(blobstoreDB, blobstoreDB, session)
+	managedStorage := blobstore.NewManagedStorage(session.DB(jujuDB), rs)
+	return binarystorage.NewBlobs(st.ControllerModelUUID(), managedStorage)
+}
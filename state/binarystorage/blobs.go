@@ -0,0 +1,91 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package binarystorage
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/blobstore.v2"
+)
+
+// blobPath returns the path in managed storage of the binary file
+// described by the metadata.
+func blobPath(metadata Metadata) string {
+	return fmt.Sprintf("tools/%s-%s", metadata.Version, metadata.SHA256)
+}
+
+// Blobs provides access to the binary files held in managed storage,
+// without reference to the metadata catalogue. It is used to check
+// the binary files held by individual mongo replica set members, whose
+// blobstore may be incomplete even though the catalogue is not.
+type Blobs struct {
+	bucketUUID     string
+	managedStorage blobstore.ManagedStorage
+}
+
+// NewBlobs returns a Blobs for the binary files stored in the provided
+// ManagedStorage for the bucket with the given UUID.
+func NewBlobs(bucketUUID string, managedStorage blobstore.ManagedStorage) *Blobs {
+	return &Blobs{
+		bucketUUID:     bucketUUID,
+		managedStorage: managedStorage,
+	}
+}
+
+// Open returns the content of the binary file described by the
+// metadata, or an error satisfying errors.IsNotFound if it is not
+// held in managed storage.
+func (b *Blobs) Open(metadata Metadata) (io.ReadCloser, error) {
+	r, _, err := b.managedStorage.GetForBucket(b.bucketUUID, blobPath(metadata))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return r, nil
+}
+
+// Verify reads the whole of the binary file described by the metadata,
+// checking that it has the expected size and SHA256 hash.
+func (b *Blobs) Verify(metadata Metadata) error {
+	r, err := b.Open(metadata)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer r.Close()
+	return errors.Trace(CheckContent(r, metadata))
+}
+
+// Rewrite replaces the binary file described by the metadata with the
+// content read from r, which must match the metadata. The existing file
+// is removed first, so that the content is written afresh rather than
+// being shared with the existing, possibly incomplete, file.
+func (b *Blobs) Rewrite(r io.Reader, metadata Metadata) error {
+	path := blobPath(metadata)
+	if err := b.managedStorage.RemoveForBucket(b.bucketUUID, path); err != nil && !errors.IsNotFound(err) {
+		return errors.Annotate(err, "cannot remove binary file")
+	}
+	if err := b.managedStorage.PutForBucket(b.bucketUUID, path, r, metadata.Size); err != nil {
+		return errors.Annotate(err, "cannot store binary file")
+	}
+	return nil
+}
+
+// CheckContent reads all of r, checking that its size and SHA256 hash
+// match the metadata.
+func CheckContent(r io.Reader, metadata Metadata) error {
+	h := sha256.New()
+	size, err := io.Copy(h, r)
+	if err != nil {
+		return errors.Annotate(err, "cannot read binary file")
+	}
+	if size != metadata.Size {
+		return errors.Errorf("binary file has size %d, expected %d", size, metadata.Size)
+	}
+	if sum := fmt.Sprintf("%x", h.Sum(nil)); sum != metadata.SHA256 {
+		return errors.Errorf("binary file has SHA256 hash %s, expected %s", sum, metadata.SHA256)
+	}
+	return nil
+}
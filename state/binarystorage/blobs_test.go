@@ -0,0 +1,83 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package binarystorage_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/binarystorage"
+)
+
+type blobsSuite struct {
+	binaryStorageSuite
+	blobs *binarystorage.Blobs
+}
+
+var _ = gc.Suite(&blobsSuite{})
+
+func (s *blobsSuite) SetUpTest(c *gc.C) {
+	s.binaryStorageSuite.SetUpTest(c)
+	s.blobs = binarystorage.NewBlobs("my-uuid", s.managedStorage)
+}
+
+func metadataFor(content string) binarystorage.Metadata {
+	return binarystorage.Metadata{
+		Version: current,
+		Size:    int64(len(content)),
+		SHA256:  fmt.Sprintf("%x", sha256.Sum256([]byte(content))),
+	}
+}
+
+func (s *blobsSuite) TestVerify(c *gc.C) {
+	metadata := metadataFor("some-binary")
+	err := s.storage.Add(strings.NewReader("some-binary"), metadata)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.blobs.Verify(metadata)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *blobsSuite) TestVerifyMissing(c *gc.C) {
+	err := s.blobs.Verify(metadataFor("some-binary"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *blobsSuite) TestVerifyMismatch(c *gc.C) {
+	metadata := metadataFor("some-binary")
+	err := s.managedStorage.PutForBucket("my-uuid", fmt.Sprintf("tools/%s-%s", current, metadata.SHA256), strings.NewReader("other-binary"), 12)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.blobs.Verify(metadata)
+	c.Assert(err, gc.ErrorMatches, "binary file has size 12, expected 11")
+}
+
+func (s *blobsSuite) TestRewrite(c *gc.C) {
+	metadata := metadataFor("some-binary")
+	err := s.storage.Add(strings.NewReader("some-binary"), metadata)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.blobs.Rewrite(bytes.NewReader([]byte("some-binary")), metadata)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, r, err := s.storage.Open(current)
+	c.Assert(err, jc.ErrorIsNil)
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "some-binary")
+}
+
+func (s *blobsSuite) TestCheckContent(c *gc.C) {
+	metadata := metadataFor("some-binary")
+	c.Assert(binarystorage.CheckContent(strings.NewReader("some-binary"), metadata), jc.ErrorIsNil)
+	c.Assert(binarystorage.CheckContent(strings.NewReader("some-binarz"), metadata), gc.ErrorMatches, "binary file has SHA256 hash .*, expected .*")
+}
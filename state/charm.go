@@ -17,7 +17,9 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/core/charmlint"
 	"github.com/juju/juju/core/model"
+	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/mongo"
 	mongoutils "github.com/juju/juju/mongo/utils"
 	"github.com/juju/juju/state/storage"
@@ -86,6 +88,22 @@ type charmDoc struct {
 	Actions    *charm.Actions    `bson:"actions"`
 	Metrics    *charm.Metrics    `bson:"metrics"`
 	LXDProfile *charm.LXDProfile `bson:"lxd-profile"`
+
+	// RelationSchemas holds the contents of the charm's relation
+	// schemas file, if it has one. It is stored as is, rather than
+	// parsed, as relation settings keys may not be valid field names.
+	RelationSchemas string `bson:"relation-schemas,omitempty"`
+
+	// LintFindings holds the problems found in the charm by static
+	// analysis when it was uploaded.
+	LintFindings []lintFindingDoc `bson:"lint-findings,omitempty"`
+}
+
+// lintFindingDoc records a charmlint.Finding.
+type lintFindingDoc struct {
+	Severity string `bson:"severity"`
+	Path     string `bson:"path,omitempty"`
+	Message  string `bson:"message"`
 }
 
 // CharmInfo contains all the data necessary to store a charm's metadata.
@@ -96,6 +114,29 @@ type CharmInfo struct {
 	SHA256      string
 	Macaroon    macaroon.Slice
 	Version     string
+
+	// RelationSchemas holds the contents of the charm's relation
+	// schemas file, if it has one.
+	RelationSchemas string
+
+	// LintFindings holds the problems found in the charm by static
+	// analysis.
+	LintFindings []charmlint.Finding
+}
+
+func lintFindingDocs(findings []charmlint.Finding) []lintFindingDoc {
+	if len(findings) == 0 {
+		return nil
+	}
+	docs := make([]lintFindingDoc, len(findings))
+	for i, f := range findings {
+		docs[i] = lintFindingDoc{
+			Severity: string(f.Severity),
+			Path:     f.Path,
+			Message:  f.Message,
+		}
+	}
+	return docs
 }
 
 // insertCharmOps returns the txn operations necessary to insert the supplied
@@ -106,15 +147,17 @@ func insertCharmOps(mb modelBackend, info CharmInfo) ([]txn.Op, error) {
 	}
 
 	doc := charmDoc{
-		DocID:        info.ID.String(),
-		URL:          info.ID,
-		CharmVersion: info.Version,
-		Meta:         info.Charm.Meta(),
-		Config:       safeConfig(info.Charm),
-		Metrics:      info.Charm.Metrics(),
-		Actions:      info.Charm.Actions(),
-		BundleSha256: info.SHA256,
-		StoragePath:  info.StoragePath,
+		DocID:           info.ID.String(),
+		URL:             info.ID,
+		CharmVersion:    info.Version,
+		Meta:            info.Charm.Meta(),
+		Config:          safeConfig(info.Charm),
+		Metrics:         info.Charm.Metrics(),
+		Actions:         info.Charm.Actions(),
+		BundleSha256:    info.SHA256,
+		StoragePath:     info.StoragePath,
+		RelationSchemas: info.RelationSchemas,
+		LintFindings:    lintFindingDocs(info.LintFindings),
 	}
 	lpc, ok := info.Charm.(charm.LXDProfiler)
 	if !ok {
@@ -226,6 +269,8 @@ func updateCharmOps(mb modelBackend, info CharmInfo, assert bson.D) ([]txn.Op, e
 		{"metrics", info.Charm.Metrics()},
 		{"storagepath", info.StoragePath},
 		{"bundlesha256", info.SHA256},
+		{"relation-schemas", info.RelationSchemas},
+		{"lint-findings", lintFindingDocs(info.LintFindings)},
 		{"pendingupload", false},
 		{"placeholder", false},
 	}
@@ -547,6 +592,29 @@ func (c *Charm) LXDProfile() *charm.LXDProfile {
 	return c.doc.LXDProfile
 }
 
+// RelationSchemas returns the relation data schemas declared by the
+// charm, keyed by relation endpoint name.
+func (c *Charm) RelationSchemas() (relation.Schemas, error) {
+	if c.doc.RelationSchemas == "" {
+		return nil, nil
+	}
+	return relation.ParseSchemas([]byte(c.doc.RelationSchemas))
+}
+
+// LintFindings returns the problems found in the charm by static
+// analysis when it was uploaded.
+func (c *Charm) LintFindings() []charmlint.Finding {
+	var findings []charmlint.Finding
+	for _, doc := range c.doc.LintFindings {
+		findings = append(findings, charmlint.Finding{
+			Severity: charmlint.Severity(doc.Severity),
+			Path:     doc.Path,
+			Message:  doc.Message,
+		})
+	}
+	return findings
+}
+
 // StoragePath returns the storage path of the charm bundle.
 func (c *Charm) StoragePath() string {
 	return c.doc.StoragePath
@@ -18,6 +18,8 @@ import (
 	"gopkg.in/mgo.v2"
 
 	apitesting "github.com/juju/juju/api/testing"
+	"github.com/juju/juju/core/charmlint"
+	"github.com/juju/juju/core/relation"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/storage"
 	"github.com/juju/juju/testcharms"
@@ -287,6 +289,50 @@ func (s *CharmSuite) TestAddCharm(c *gc.C) {
 
 	expVersion := "dummy-146-g725cfd3-dirty"
 	c.Assert(doc.CharmVersion, gc.Equals, expVersion)
+
+	schemas, err := dummy.RelationSchemas()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schemas, gc.IsNil)
+}
+
+func (s *CharmSuite) TestAddCharmWithRelationSchemas(c *gc.C) {
+	info := s.dummyCharm(c, "")
+	info.RelationSchemas = "db:\n  unit:\n    port:\n      type: int\n      max-size: 5\n"
+	_, err := s.State.AddCharm(info)
+	c.Assert(err, jc.ErrorIsNil)
+
+	dummy, err := s.State.Charm(info.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	schemas, err := dummy.RelationSchemas()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schemas, jc.DeepEquals, relation.Schemas{
+		"db": {
+			Unit: relation.DataBagSchema{
+				"port": {Type: relation.TypeInt, MaxSize: 5},
+			},
+		},
+	})
+}
+
+func (s *CharmSuite) TestUpdateUploadedCharmWithLintFindings(c *gc.C) {
+	info := s.dummyCharm(c, "")
+	curl, err := s.State.PrepareLocalCharmUpload(info.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	info.ID = curl
+
+	findings := []charmlint.Finding{{
+		Severity: charmlint.Warning,
+		Path:     "config.yaml",
+		Message:  `option "port" has no description`,
+	}}
+	info.LintFindings = findings
+	sch, err := s.State.UpdateUploadedCharm(info)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sch.LintFindings(), jc.DeepEquals, findings)
+
+	dummy, err := s.State.Charm(info.ID)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(dummy.LintFindings(), jc.DeepEquals, findings)
 }
 
 func (s *CharmSuite) TestAddCharmWithAuth(c *gc.C) {
@@ -0,0 +1,179 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// charmDeploymentsDoc records the units of an application which are
+// installing or upgrading their charm, so that no more than the model's
+// max-charm-deployments of them do so at once.
+type charmDeploymentsDoc struct {
+	DocID       string   `bson:"_id"`
+	ModelUUID   string   `bson:"model-uuid"`
+	Application string   `bson:"application"`
+	Units       []string `bson:"units"`
+	TxnRevno    int64    `bson:"txn-revno"`
+}
+
+// StartCharmDeployment reports whether the unit may start installing or
+// upgrading its charm. If the model limits how many units of an
+// application may do so at once, and as many units as the limit are
+// already deploying the charm, the unit must wait until one of them has
+// called FinishCharmDeployment; WatchCharmDeployments reports when that
+// happens. A unit which has started deploying its charm may start again
+// without finishing.
+func (u *Unit) StartCharmDeployment() (_ bool, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot start charm deployment for unit %q", u)
+	model, err := u.st.Model()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	cfg, err := model.ModelConfig()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	limit := cfg.MaxCharmDeployments()
+	if limit == 0 {
+		return true, nil
+	}
+
+	docID := u.st.docID(u.doc.Application)
+	var started bool
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := u.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if u.doc.Life != Alive {
+			return nil, errors.New("unit is not alive")
+		}
+		doc, err := u.charmDeployments()
+		if errors.IsNotFound(err) {
+			started = true
+			return []txn.Op{{
+				C:      unitsC,
+				Id:     u.doc.DocID,
+				Assert: isAliveDoc,
+			}, {
+				C:      charmDeploymentsC,
+				Id:     docID,
+				Assert: txn.DocMissing,
+				Insert: &charmDeploymentsDoc{
+					DocID:       docID,
+					ModelUUID:   u.st.ModelUUID(),
+					Application: u.doc.Application,
+					Units:       []string{u.doc.Name},
+				},
+			}}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, name := range doc.Units {
+			if name == u.doc.Name {
+				started = true
+				return nil, jujutxn.ErrNoOperations
+			}
+		}
+		if len(doc.Units) >= limit {
+			started = false
+			return nil, jujutxn.ErrNoOperations
+		}
+		started = true
+		return []txn.Op{{
+			C:      unitsC,
+			Id:     u.doc.DocID,
+			Assert: isAliveDoc,
+		}, {
+			C:      charmDeploymentsC,
+			Id:     docID,
+			Assert: bson.D{{"txn-revno", doc.TxnRevno}},
+			Update: bson.D{{"$addToSet", bson.D{{"units", u.doc.Name}}}},
+		}}, nil
+	}
+	if err := u.st.db().Run(buildTxn); err != nil {
+		return false, errors.Trace(err)
+	}
+	return started, nil
+}
+
+// FinishCharmDeployment records that the unit has finished installing
+// or upgrading its charm, allowing another unit of its application to
+// start. It is not an error to finish a deployment which wasn't started.
+func (u *Unit) FinishCharmDeployment() error {
+	err := u.st.db().RunTransaction([]txn.Op{
+		finishCharmDeploymentOp(u.st, u.doc.Application, u.doc.Name),
+	})
+	return errors.Annotatef(err, "cannot finish charm deployment for unit %q", u)
+}
+
+// CharmDeployments returns the names of the units of the application
+// which are installing or upgrading their charm, as limited by the
+// model's max-charm-deployments.
+func (a *Application) CharmDeployments() ([]string, error) {
+	coll, closer := a.st.db().GetCollection(charmDeploymentsC)
+	defer closer()
+	var doc charmDeploymentsDoc
+	err := coll.FindId(a.doc.Name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotatef(err, "cannot get charm deployments for application %q", a.doc.Name)
+	}
+	return doc.Units, nil
+}
+
+// WatchCharmDeployments returns a watcher which notifies when units of
+// the application start or finish deploying its charm. It also notifies
+// when the model's config changes, as raising max-charm-deployments
+// lets waiting units start.
+func (a *Application) WatchCharmDeployments() NotifyWatcher {
+	return newDocWatcher(a.st, []docKey{{
+		charmDeploymentsC,
+		a.st.docID(a.doc.Name),
+	}, {
+		settingsC,
+		a.st.docID(modelGlobalKey),
+	}})
+}
+
+func (u *Unit) charmDeployments() (*charmDeploymentsDoc, error) {
+	coll, closer := u.st.db().GetCollection(charmDeploymentsC)
+	defer closer()
+	var doc charmDeploymentsDoc
+	err := coll.FindId(u.doc.Application).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("charm deployments for application %q", u.doc.Application)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &doc, nil
+}
+
+// finishCharmDeploymentOp returns the operation which removes the unit
+// from the units deploying its application's charm. It does nothing if
+// the unit isn't deploying the charm.
+func finishCharmDeploymentOp(mb modelBackend, application, unit string) txn.Op {
+	return txn.Op{
+		C:      charmDeploymentsC,
+		Id:     mb.docID(application),
+		Update: bson.D{{"$pull", bson.D{{"units", unit}}}},
+	}
+}
+
+// removeCharmDeploymentsOp returns the operation which removes the
+// record of the units deploying the application's charm.
+func removeCharmDeploymentsOp(mb modelBackend, application string) txn.Op {
+	return txn.Op{
+		C:      charmDeploymentsC,
+		Id:     mb.docID(application),
+		Remove: true,
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type CharmDeploymentsSuite struct {
+	ConnSuite
+	application *state.Application
+	units       []*state.Unit
+}
+
+var _ = gc.Suite(&CharmDeploymentsSuite{})
+
+func (s *CharmDeploymentsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.application = s.Factory.MakeApplication(c, nil)
+	s.units = nil
+	for i := 0; i < 3; i++ {
+		unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: s.application})
+		s.units = append(s.units, unit)
+	}
+}
+
+func (s *CharmDeploymentsSuite) setLimit(c *gc.C, limit int) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"max-charm-deployments": limit,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *CharmDeploymentsSuite) assertDeployments(c *gc.C, expect ...string) {
+	deployments, err := s.application.CharmDeployments()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(deployments, jc.SameContents, expect)
+}
+
+func (s *CharmDeploymentsSuite) start(c *gc.C, unit *state.Unit) bool {
+	started, err := unit.StartCharmDeployment()
+	c.Assert(err, jc.ErrorIsNil)
+	return started
+}
+
+func (s *CharmDeploymentsSuite) TestNoLimit(c *gc.C) {
+	for _, unit := range s.units {
+		c.Assert(s.start(c, unit), jc.IsTrue)
+	}
+	s.assertDeployments(c)
+}
+
+func (s *CharmDeploymentsSuite) TestLimit(c *gc.C) {
+	s.setLimit(c, 2)
+	c.Assert(s.start(c, s.units[0]), jc.IsTrue)
+	c.Assert(s.start(c, s.units[1]), jc.IsTrue)
+	c.Assert(s.start(c, s.units[2]), jc.IsFalse)
+	s.assertDeployments(c, s.units[0].Name(), s.units[1].Name())
+
+	// A unit which has already started may start again.
+	c.Assert(s.start(c, s.units[1]), jc.IsTrue)
+	s.assertDeployments(c, s.units[0].Name(), s.units[1].Name())
+
+	err := s.units[0].FinishCharmDeployment()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.start(c, s.units[2]), jc.IsTrue)
+	s.assertDeployments(c, s.units[1].Name(), s.units[2].Name())
+}
+
+func (s *CharmDeploymentsSuite) TestFinishNotStarted(c *gc.C) {
+	err := s.units[0].FinishCharmDeployment()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.setLimit(c, 1)
+	c.Assert(s.start(c, s.units[0]), jc.IsTrue)
+	err = s.units[1].FinishCharmDeployment()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertDeployments(c, s.units[0].Name())
+}
+
+func (s *CharmDeploymentsSuite) TestStartNotAlive(c *gc.C) {
+	s.setLimit(c, 1)
+	err := s.units[0].EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.units[0].StartCharmDeployment()
+	c.Assert(err, gc.ErrorMatches, `cannot start charm deployment for unit ".*": unit is not alive`)
+	s.assertDeployments(c)
+}
+
+func (s *CharmDeploymentsSuite) TestRemoveUnitFinishes(c *gc.C) {
+	s.setLimit(c, 1)
+	c.Assert(s.start(c, s.units[0]), jc.IsTrue)
+	c.Assert(s.start(c, s.units[1]), jc.IsFalse)
+
+	err := s.units[0].EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.units[0].Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertDeployments(c)
+	c.Assert(s.start(c, s.units[1]), jc.IsTrue)
+}
+
+func (s *CharmDeploymentsSuite) TestWatchCharmDeployments(c *gc.C) {
+	s.setLimit(c, 1)
+	w := s.application.WatchCharmDeployments()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	c.Assert(s.start(c, s.units[0]), jc.IsTrue)
+	wc.AssertOneChange()
+
+	c.Assert(s.start(c, s.units[1]), jc.IsFalse)
+	wc.AssertNoChange()
+
+	err := s.units[0].FinishCharmDeployment()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Raising the limit lets waiting units start.
+	s.setLimit(c, 2)
+	wc.AssertOneChange()
+}
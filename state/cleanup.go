@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
@@ -240,7 +241,9 @@ func (st *State) cleanupRelationSettings(prefix string) error {
 	if err := Apply(st.database, change); err != nil {
 		return errors.Trace(err)
 	}
-	return nil
+	// The prefix is the relation's global key followed by "#".
+	err := eraseRelationSettingsHistory(st, strings.TrimSuffix(prefix, "#"))
+	return errors.Trace(err)
 }
 
 func (st *State) cleanupForceDestroyedRelation(prefix string) (err error) {
@@ -1144,6 +1147,9 @@ func (st *State) cleanupForceDestroyedMachineInternal(machineId string, maxWait
 		return errors.Trace(err)
 	}
 	for _, unitName := range machine.doc.Principals {
+		if err := st.recordLostUnit(unitName); err != nil {
+			return errors.Trace(err)
+		}
 		opErrs, err := st.obliterateUnit(unitName, true, maxWait)
 		if len(opErrs) != 0 {
 			logger.Warningf("while obliterating unit %v: %v", unitName, opErrs)
@@ -1214,6 +1220,18 @@ func (st *State) cleanupForceRemoveMachine(machineId string, cleanupArgs []bson.
 	} else if err != nil {
 		return errors.Trace(err)
 	}
+	// The provisioner has not yet stopped the machine's instance, and
+	// will no longer see it once the machine is removed.
+	if instId, err := machine.InstanceId(); err == nil {
+		if err := st.RecordAbandonedResource(
+			AbandonedMachine, machineId, string(instId),
+			"machine removed by force before its instance was stopped",
+		); err != nil {
+			logger.Warningf("%v", err)
+		}
+	} else if !errors.IsNotProvisioned(err) {
+		return errors.Trace(err)
+	}
 	return machine.Remove()
 }
 
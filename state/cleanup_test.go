@@ -594,6 +594,42 @@ func (s *CleanupSuite) TestForceDestroyMachineSchedulesRemove(c *gc.C) {
 	c.Assert(err, jc.Satisfies, errors.IsNotFound)
 }
 
+func (s *CleanupSuite) TestForceRemoveMachineRecordsAbandonedInstance(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = machine.SetProvisioned("inst-id", "", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.ForceDestroy(time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCleanupRuns(c)
+	s.Clock.Advance(time.Minute)
+	s.assertCleanupCount(c, 1)
+
+	resources, err := s.State.AbandonedResources(s.State.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, gc.HasLen, 1)
+	c.Check(resources[0].Kind, gc.Equals, state.AbandonedMachine)
+	c.Check(resources[0].Id, gc.Equals, machine.Id())
+	c.Check(resources[0].ProviderId, gc.Equals, "inst-id")
+}
+
+func (s *CleanupSuite) TestForceRemoveUnprovisionedMachineRecordsNothing(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.ForceDestroy(time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertCleanupRuns(c)
+	s.Clock.Advance(time.Minute)
+	s.assertCleanupCount(c, 1)
+	c.Assert(machine.Refresh(), jc.Satisfies, errors.IsNotFound)
+
+	resources, err := s.State.AbandonedResources(s.State.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources, gc.HasLen, 0)
+}
+
 func (s *CleanupSuite) TestCleanupDyingUnit(c *gc.C) {
 	// Create active unit, in a relation.
 	prr := newProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
@@ -338,6 +338,10 @@ func (st *State) removeCloudOps(name string) ([]txn.Op, error) {
 		C:      cloudsC,
 		Id:     name,
 		Remove: true,
+	}, {
+		C:      cloudPricingC,
+		Id:     name,
+		Remove: true,
 	}, countOp}
 
 	credPattern := bson.M{
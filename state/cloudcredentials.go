@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -60,6 +61,11 @@ type cloudCredentialDoc struct {
 	// This can range from cloud messages such as an expired credential to
 	// commercial reasons set via CLI or api calls.
 	InvalidReason string `bson:"invalid-reason,omitempty"`
+
+	// Expiry is the time at which the credential will no longer be
+	// accepted by the cloud. It is the zero time if the expiry is
+	// not known.
+	Expiry time.Time `bson:"expiry,omitempty"`
 }
 
 // CloudCredential returns the cloud credential for the given tag.
@@ -299,6 +305,7 @@ func createCloudCredentialOp(tag names.CloudCredentialTag, cred cloud.Credential
 			AuthType:   string(cred.AuthType()),
 			Attributes: cred.Attributes(),
 			Revoked:    cred.Revoked,
+			Expiry:     cred.Expiry.UTC(),
 		},
 	}
 }
@@ -316,6 +323,7 @@ func updateCloudCredentialOp(tag names.CloudCredentialTag, cred cloud.Credential
 			{"revoked", cred.Revoked},
 			{"invalid", cred.Invalid},
 			{"invalid-reason", cred.InvalidReason},
+			{"expiry", cred.Expiry.UTC()},
 		}}},
 	}
 }
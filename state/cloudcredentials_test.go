@@ -6,6 +6,7 @@ package state_test
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -55,6 +56,41 @@ func (s *CloudCredentialsSuite) TestUpdateCloudCredentialNew(c *gc.C) {
 	c.Assert(out, jc.DeepEquals, expected)
 }
 
+func (s *CloudCredentialsSuite) TestUpdateCloudCredentialExpiry(c *gc.C) {
+	err := s.State.AddCloud(cloud.Cloud{
+		Name:      "stratus",
+		Type:      "low",
+		AuthTypes: cloud.AuthTypes{cloud.AccessKeyAuthType},
+	}, s.Owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+
+	cred := cloud.NewCredential(cloud.AccessKeyAuthType, map[string]string{
+		"foo": "foo val",
+	})
+	cred.Expiry = time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	tag := names.NewCloudCredentialTag("stratus/bob/foobar")
+	err = s.State.UpdateCloudCredential(tag, cred)
+	c.Assert(err, jc.ErrorIsNil)
+
+	out, err := s.State.CloudCredential(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Expiry.Equal(cred.Expiry), jc.IsTrue)
+
+	cred.Expiry = cred.Expiry.Add(30 * 24 * time.Hour)
+	err = s.State.UpdateCloudCredential(tag, cred)
+	c.Assert(err, jc.ErrorIsNil)
+	out, err = s.State.CloudCredential(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Expiry.Equal(cred.Expiry), jc.IsTrue)
+
+	cred.Expiry = time.Time{}
+	err = s.State.UpdateCloudCredential(tag, cred)
+	c.Assert(err, jc.ErrorIsNil)
+	out, err = s.State.CloudCredential(tag)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Expiry.IsZero(), jc.IsTrue)
+}
+
 func (s *CloudCredentialsSuite) TestCreateInvalidCredential(c *gc.C) {
 	err := s.State.AddCloud(cloud.Cloud{
 		Name:      "stratus",
@@ -0,0 +1,243 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/cost"
+)
+
+// cloudPricingDoc records the prices of a cloud's resources.
+type cloudPricingDoc struct {
+	Cloud            string              `bson:"_id"`
+	Currency         string              `bson:"currency"`
+	InstanceTypes    []instanceTypePrice `bson:"instance-types,omitempty"`
+	CPUCoreHour      float64             `bson:"cpu-core-hour"`
+	MemoryGiBHour    float64             `bson:"memory-gib-hour"`
+	VolumeGiBMonth   float64             `bson:"volume-gib-month"`
+	LoadBalancerHour float64             `bson:"load-balancer-hour"`
+}
+
+// instanceTypePrice is the hourly price of an instance type. Instance
+// type names often contain dots, so they can't be used as keys.
+type instanceTypePrice struct {
+	Name  string  `bson:"name"`
+	Price float64 `bson:"price"`
+}
+
+func newCloudPricingDoc(cloudName string, pricing cost.Pricing) cloudPricingDoc {
+	doc := cloudPricingDoc{
+		Cloud:            cloudName,
+		Currency:         pricing.Currency,
+		CPUCoreHour:      pricing.CPUCoreHour,
+		MemoryGiBHour:    pricing.MemoryGiBHour,
+		VolumeGiBMonth:   pricing.VolumeGiBMonth,
+		LoadBalancerHour: pricing.LoadBalancerHour,
+	}
+	for name, price := range pricing.InstanceTypes {
+		doc.InstanceTypes = append(doc.InstanceTypes, instanceTypePrice{Name: name, Price: price})
+	}
+	sort.Slice(doc.InstanceTypes, func(i, j int) bool {
+		return doc.InstanceTypes[i].Name < doc.InstanceTypes[j].Name
+	})
+	return doc
+}
+
+func (doc cloudPricingDoc) pricing() cost.Pricing {
+	pricing := cost.Pricing{
+		Currency:         doc.Currency,
+		CPUCoreHour:      doc.CPUCoreHour,
+		MemoryGiBHour:    doc.MemoryGiBHour,
+		VolumeGiBMonth:   doc.VolumeGiBMonth,
+		LoadBalancerHour: doc.LoadBalancerHour,
+	}
+	if len(doc.InstanceTypes) > 0 {
+		pricing.InstanceTypes = make(map[string]float64)
+		for _, it := range doc.InstanceTypes {
+			pricing.InstanceTypes[it.Name] = it.Price
+		}
+	}
+	return pricing
+}
+
+// SetCloudPricing sets the prices of the resources provided by the
+// named cloud, replacing any already set.
+func (st *State) SetCloudPricing(cloudName string, pricing cost.Pricing) error {
+	if err := pricing.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	doc := newCloudPricingDoc(cloudName, pricing)
+	buildTxn := func(int) ([]txn.Op, error) {
+		if _, err := st.Cloud(cloudName); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      cloudsC,
+			Id:     cloudName,
+			Assert: txn.DocExists,
+		}}
+		_, err := st.CloudPricing(cloudName)
+		if errors.IsNotFound(err) {
+			return append(ops, txn.Op{
+				C:      cloudPricingC,
+				Id:     cloudName,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			}), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, txn.Op{
+			C:      cloudPricingC,
+			Id:     cloudName,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"currency", doc.Currency},
+				{"instance-types", doc.InstanceTypes},
+				{"cpu-core-hour", doc.CPUCoreHour},
+				{"memory-gib-hour", doc.MemoryGiBHour},
+				{"volume-gib-month", doc.VolumeGiBMonth},
+				{"load-balancer-hour", doc.LoadBalancerHour},
+			}}},
+		}), nil
+	}
+	return errors.Annotatef(st.db().Run(buildTxn), "setting pricing for cloud %q", cloudName)
+}
+
+// CloudPricing returns the prices of the resources provided by the
+// named cloud, or a NotFound error if none have been set.
+func (st *State) CloudPricing(cloudName string) (cost.Pricing, error) {
+	coll, closer := st.db().GetCollection(cloudPricingC)
+	defer closer()
+
+	var doc cloudPricingDoc
+	err := coll.FindId(cloudName).One(&doc)
+	if err == mgo.ErrNotFound {
+		return cost.Pricing{}, errors.NotFoundf("pricing for cloud %q", cloudName)
+	} else if err != nil {
+		return cost.Pricing{}, errors.Annotatef(err, "cannot get pricing for cloud %q", cloudName)
+	}
+	return doc.pricing(), nil
+}
+
+// RemoveCloudPricing removes the prices set for the named cloud.
+func (st *State) RemoveCloudPricing(cloudName string) error {
+	ops := []txn.Op{{
+		C:      cloudPricingC,
+		Id:     cloudName,
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	if err := st.db().RunTransaction(ops); err != nil {
+		if err == txn.ErrAborted {
+			return errors.NotFoundf("pricing for cloud %q", cloudName)
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// EstimateCost returns the estimated cost of running the model's
+// provisioned machines and volumes, and the load balancers of its
+// applications, using the pricing set for the model's cloud. It returns
+// a NotFound error if the cloud has no pricing.
+func (m *Model) EstimateCost() (cost.Estimate, error) {
+	pricing, err := m.st.CloudPricing(m.Cloud())
+	if err != nil {
+		return cost.Estimate{}, errors.Trace(err)
+	}
+	resources, err := m.costResources()
+	if err != nil {
+		return cost.Estimate{}, errors.Trace(err)
+	}
+	return pricing.Estimate(resources), nil
+}
+
+// loadBalancerServiceType is the value of a CAAS application's
+// kubernetes-service-type config which exposes the application through
+// a load balancer.
+const loadBalancerServiceType = "loadbalancer"
+
+func (m *Model) costResources() (cost.Resources, error) {
+	var resources cost.Resources
+	machines, err := m.st.AllMachines()
+	if err != nil {
+		return resources, errors.Trace(err)
+	}
+	for _, machine := range machines {
+		if machine.IsContainer() || machine.Life() == Dead {
+			continue
+		}
+		if _, err := machine.InstanceId(); errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return resources, errors.Trace(err)
+		}
+		costMachine := cost.Machine{Id: machine.Id()}
+		hw, err := machine.HardwareCharacteristics()
+		if err != nil && !errors.IsNotFound(err) {
+			return resources, errors.Trace(err)
+		}
+		if hw != nil && hw.CpuCores != nil {
+			costMachine.CPUCores = *hw.CpuCores
+		}
+		if hw != nil && hw.Mem != nil {
+			costMachine.MemMiB = *hw.Mem
+		}
+		cons, err := machine.Constraints()
+		if err != nil && !errors.IsNotFound(err) {
+			return resources, errors.Trace(err)
+		}
+		if cons.HasInstanceType() {
+			costMachine.InstanceType = *cons.InstanceType
+		}
+		resources.Machines = append(resources.Machines, costMachine)
+	}
+
+	sb, err := NewStorageBackend(m.st)
+	if err != nil {
+		return resources, errors.Trace(err)
+	}
+	volumes, err := sb.AllVolumes()
+	if err != nil {
+		return resources, errors.Trace(err)
+	}
+	for _, v := range volumes {
+		info, err := v.Info()
+		if errors.IsNotProvisioned(err) {
+			continue
+		} else if err != nil {
+			return resources, errors.Trace(err)
+		}
+		resources.Volumes = append(resources.Volumes, cost.Volume{
+			Id:      v.VolumeTag().Id(),
+			SizeMiB: info.Size,
+		})
+	}
+
+	if m.Type() != ModelTypeCAAS {
+		return resources, nil
+	}
+	applications, err := m.st.AllApplications()
+	if err != nil {
+		return resources, errors.Trace(err)
+	}
+	for _, app := range applications {
+		appConfig, err := app.ApplicationConfig()
+		if err != nil {
+			return resources, errors.Trace(err)
+		}
+		if strings.ToLower(appConfig.GetString("kubernetes-service-type", "")) == loadBalancerServiceType {
+			resources.LoadBalancers = append(resources.LoadBalancers, app.Name())
+		}
+	}
+	return resources, nil
+}
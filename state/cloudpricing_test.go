@@ -0,0 +1,167 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/environschema.v1"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/core/cost"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type CloudPricingSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&CloudPricingSuite{})
+
+var dummyPricing = cost.Pricing{
+	Currency:         "USD",
+	InstanceTypes:    map[string]float64{"m5.large": 0.5, "t3.micro": 0.0625},
+	CPUCoreHour:      0.125,
+	MemoryGiBHour:    0.0625,
+	VolumeGiBMonth:   73,
+	LoadBalancerHour: 0.25,
+}
+
+func (s *CloudPricingSuite) TestSetCloudPricing(c *gc.C) {
+	err := s.State.SetCloudPricing("dummy", dummyPricing)
+	c.Assert(err, jc.ErrorIsNil)
+	pricing, err := s.State.CloudPricing("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pricing, jc.DeepEquals, dummyPricing)
+
+	// Setting the pricing again replaces it.
+	err = s.State.SetCloudPricing("dummy", cost.Pricing{Currency: "EUR", CPUCoreHour: 0.5})
+	c.Assert(err, jc.ErrorIsNil)
+	pricing, err = s.State.CloudPricing("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pricing, jc.DeepEquals, cost.Pricing{Currency: "EUR", CPUCoreHour: 0.5})
+}
+
+func (s *CloudPricingSuite) TestSetCloudPricingInvalid(c *gc.C) {
+	err := s.State.SetCloudPricing("dummy", cost.Pricing{CPUCoreHour: 0.5})
+	c.Assert(err, gc.ErrorMatches, "pricing without currency not valid")
+}
+
+func (s *CloudPricingSuite) TestSetCloudPricingCloudNotFound(c *gc.C) {
+	err := s.State.SetCloudPricing("nimbus", dummyPricing)
+	c.Assert(err, gc.ErrorMatches, `setting pricing for cloud "nimbus": cloud "nimbus" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CloudPricingSuite) TestCloudPricingNotFound(c *gc.C) {
+	_, err := s.State.CloudPricing("dummy")
+	c.Assert(err, gc.ErrorMatches, `pricing for cloud "dummy" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CloudPricingSuite) TestRemoveCloudPricing(c *gc.C) {
+	err := s.State.SetCloudPricing("dummy", dummyPricing)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveCloudPricing("dummy")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.CloudPricing("dummy")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.RemoveCloudPricing("dummy")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CloudPricingSuite) TestRemoveCloudRemovesPricing(c *gc.C) {
+	err := s.State.AddCloud(lowCloud, s.Owner.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetCloudPricing(lowCloud.Name, dummyPricing)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveCloud(lowCloud.Name)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.CloudPricing(lowCloud.Name)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CloudPricingSuite) TestEstimateCostNoPricing(c *gc.C) {
+	_, err := s.Model.EstimateCost()
+	c.Assert(err, gc.ErrorMatches, `pricing for cloud "dummy" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *CloudPricingSuite) TestEstimateCost(c *gc.C) {
+	err := s.State.SetCloudPricing("dummy", dummyPricing)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Factory.MakeMachine(c, &factory.MachineParams{
+		Constraints: constraints.MustParse("instance-type=m5.large"),
+	})
+	hw := instance.MustParseHardware("cores=4", "mem=16G")
+	host := s.Factory.MakeMachine(c, &factory.MachineParams{
+		Characteristics: &hw,
+		Volumes: []state.HostVolumeParams{{
+			Volume: state.VolumeParams{Size: 10240},
+		}},
+	})
+	s.Factory.MakeMachineNested(c, host.Id(), nil)
+	s.Factory.MakeUnprovisionedMachineReturningPassword(c, nil)
+
+	sb, err := state.NewStorageBackend(s.State)
+	c.Assert(err, jc.ErrorIsNil)
+	volumes, err := sb.AllVolumes()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(volumes, gc.HasLen, 1)
+	volumeTag := volumes[0].VolumeTag()
+	err = sb.SetVolumeInfo(volumeTag, state.VolumeInfo{
+		VolumeId: "vol-0",
+		Size:     10240,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	estimate, err := s.Model.EstimateCost()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(estimate, jc.DeepEquals, cost.Estimate{
+		Currency: "USD",
+		Items: []cost.Item{
+			{Kind: cost.KindMachine, Id: "0", Detail: "m5.large", Hourly: 0.5},
+			{Kind: cost.KindMachine, Id: "1", Detail: "cores=4 mem=16G", Hourly: 1.5},
+			{Kind: cost.KindVolume, Id: volumeTag.Id(), Detail: "10GiB", Hourly: 1},
+		},
+		Hourly: 3,
+	})
+}
+
+func (s *CloudPricingSuite) TestEstimateCostCAASLoadBalancers(c *gc.C) {
+	st := s.Factory.MakeCAASModel(c, nil)
+	defer st.Close()
+	model, err := st.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	err = st.SetCloudPricing(model.Cloud(), dummyPricing)
+	c.Assert(err, jc.ErrorIsNil)
+
+	f := factory.NewFactory(st, s.StatePool)
+	ch := f.MakeCharm(c, &factory.CharmParams{Name: "gitlab", Series: "kubernetes"})
+	f.MakeApplication(c, &factory.ApplicationParams{
+		Name:              "gitlab",
+		Charm:             ch,
+		ApplicationConfig: map[string]interface{}{"kubernetes-service-type": "LoadBalancer"},
+		ApplicationConfigFields: environschema.Fields{
+			"kubernetes-service-type": environschema.Attr{Type: environschema.Tstring},
+		},
+	})
+	f.MakeApplication(c, &factory.ApplicationParams{Name: "internal", Charm: ch})
+
+	estimate, err := model.EstimateCost()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(estimate, jc.DeepEquals, cost.Estimate{
+		Currency: "USD",
+		Items: []cost.Item{
+			{Kind: cost.KindLoadBalancer, Id: "gitlab", Hourly: 0.25},
+		},
+		Hourly: 0.25,
+	})
+}
@@ -0,0 +1,160 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/environs/config"
+)
+
+// ErrColocationViolation is returned when placing a unit on a machine
+// would break one of the model's colocation rules.
+type ErrColocationViolation struct {
+	// Application is the application of the unit being placed.
+	Application string
+
+	// Unit is the name of the unit being placed, if it exists yet.
+	Unit string
+
+	// Machine is the id of the machine the unit would be placed on.
+	Machine string
+
+	// Rule is the model config key of the rule which would be broken.
+	Rule string
+
+	// Group holds the anti-colocation group which would be broken,
+	// if Rule is anti-colocation.
+	Group []string
+
+	// Limit holds the maximum number of units per machine, if Rule is
+	// max-units-per-machine.
+	Limit int
+
+	// Units holds the units already on the machine which cause the
+	// rule to be broken.
+	Units []string
+}
+
+func (e *ErrColocationViolation) Error() string {
+	what := fmt.Sprintf("unit of application %q", e.Application)
+	if e.Unit != "" {
+		what = fmt.Sprintf("unit %q", e.Unit)
+	}
+	switch e.Rule {
+	case config.AntiColocationKey:
+		return fmt.Sprintf("%s cannot be placed on machine %s: %s group %q conflicts with %s",
+			what, e.Machine, e.Rule, strings.Join(e.Group, ","), strings.Join(e.Units, ", "))
+	default:
+		return fmt.Sprintf("%s cannot be placed on machine %s: machine already hosts %d units (%s is %d)",
+			what, e.Machine, len(e.Units), e.Rule, e.Limit)
+	}
+}
+
+// IsColocationViolationError returns if the given error or its cause is
+// ErrColocationViolation.
+func IsColocationViolationError(err interface{}) bool {
+	if err == nil {
+		return false
+	}
+	// In case of a wrapped error, check the cause first.
+	value := err
+	cause := errors.Cause(err.(error))
+	if cause != nil {
+		value = cause
+	}
+	_, ok := value.(*ErrColocationViolation)
+	return ok
+}
+
+// CheckColocation returns an error satisfying IsColocationViolationError
+// if a new unit of the given application cannot be placed on the machine
+// without breaking the model's colocation rules. Rules only apply to the
+// principal units of the machine itself, not to those of its containers.
+func (m *Machine) CheckColocation(application string) error {
+	_, err := m.checkColocation(application, "")
+	return err
+}
+
+// checkColocation checks the model's colocation rules for placing a unit
+// of the given application on the machine, as CheckColocation does. It
+// also reports whether any rules are in effect, in which case the caller
+// must assert that the machine's principals don't change while placing
+// the unit.
+func (m *Machine) checkColocation(application, unit string) (bool, error) {
+	model, err := m.st.Model()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	cfg, err := model.ModelConfig()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	groups := cfg.AntiColocation()
+	limit := cfg.MaxUnitsPerMachine()
+	if len(groups) == 0 && limit == 0 {
+		return false, nil
+	}
+	violation := &ErrColocationViolation{
+		Application: application,
+		Unit:        unit,
+		Machine:     m.Id(),
+	}
+	if limit > 0 && len(m.doc.Principals) >= limit {
+		violation.Rule = config.MaxUnitsPerMachineKey
+		violation.Limit = limit
+		violation.Units = append([]string(nil), m.doc.Principals...)
+		sort.Strings(violation.Units)
+		return true, violation
+	}
+	for _, group := range groups {
+		if conflicts := antiColocated(group, application, m.doc.Principals); len(conflicts) > 0 {
+			violation.Rule = config.AntiColocationKey
+			violation.Group = group
+			violation.Units = conflicts
+			return true, violation
+		}
+	}
+	return true, nil
+}
+
+// antiColocated returns the units which may not share a machine with
+// a unit of the given application under the anti-colocation group.
+func antiColocated(group []string, application string, units []string) []string {
+	inGroup := false
+	for _, app := range group {
+		if app == application {
+			inGroup = true
+			break
+		}
+	}
+	if !inGroup {
+		return nil
+	}
+	var conflicts []string
+	for _, unit := range units {
+		unitApp, err := names.UnitApplication(unit)
+		if err != nil {
+			continue
+		}
+		// A unit of the same application only conflicts if the group
+		// consists of that application alone.
+		if unitApp == application && len(group) > 1 {
+			continue
+		}
+		for _, app := range group {
+			if app == unitApp {
+				conflicts = append(conflicts, unit)
+				break
+			}
+		}
+	}
+	sort.Strings(conflicts)
+	return conflicts
+}
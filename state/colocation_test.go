@@ -0,0 +1,161 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/state"
+)
+
+type ColocationSuite struct {
+	ConnSuite
+	wordpress *state.Application
+	mysql     *state.Application
+	machine   *state.Machine
+}
+
+var _ = gc.Suite(&ColocationSuite{})
+
+func (s *ColocationSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.wordpress = s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.mysql = s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	var err error
+	s.machine, err = s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ColocationSuite) setRules(c *gc.C, antiColocation string, maxUnits int) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"anti-colocation":       antiColocation,
+		"max-units-per-machine": maxUnits,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ColocationSuite) addUnit(c *gc.C, app *state.Application) *state.Unit {
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	return unit
+}
+
+func (s *ColocationSuite) assertViolation(c *gc.C, err error, expect state.ErrColocationViolation) {
+	c.Assert(err, jc.Satisfies, state.IsColocationViolationError)
+	c.Assert(*(errors.Cause(err).(*state.ErrColocationViolation)), jc.DeepEquals, expect)
+}
+
+func (s *ColocationSuite) TestNoRules(c *gc.C) {
+	for i := 0; i < 3; i++ {
+		err := s.addUnit(c, s.wordpress).AssignToMachine(s.machine)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	err := s.addUnit(c, s.mysql).AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ColocationSuite) TestAntiColocation(c *gc.C) {
+	s.setRules(c, "mysql,wordpress", 0)
+	err := s.addUnit(c, s.wordpress).AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+	// Units of the same application may share the machine.
+	err = s.addUnit(c, s.wordpress).AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.addUnit(c, s.mysql).AssignToMachine(s.machine)
+	c.Assert(err, gc.ErrorMatches, `cannot assign unit "mysql/0" to machine 0: unit "mysql/0" cannot be placed on machine 0: anti-colocation group "mysql,wordpress" conflicts with wordpress/0, wordpress/1`)
+	s.assertViolation(c, err, state.ErrColocationViolation{
+		Application: "mysql",
+		Unit:        "mysql/0",
+		Machine:     "0",
+		Rule:        "anti-colocation",
+		Group:       []string{"mysql", "wordpress"},
+		Units:       []string{"wordpress/0", "wordpress/1"},
+	})
+}
+
+func (s *ColocationSuite) TestAntiColocationSingleApplication(c *gc.C) {
+	s.setRules(c, "wordpress", 0)
+	err := s.addUnit(c, s.wordpress).AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.addUnit(c, s.mysql).AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.addUnit(c, s.wordpress).AssignToMachine(s.machine)
+	s.assertViolation(c, err, state.ErrColocationViolation{
+		Application: "wordpress",
+		Unit:        "wordpress/1",
+		Machine:     "0",
+		Rule:        "anti-colocation",
+		Group:       []string{"wordpress"},
+		Units:       []string{"wordpress/0"},
+	})
+}
+
+func (s *ColocationSuite) TestMaxUnitsPerMachine(c *gc.C) {
+	s.setRules(c, "", 2)
+	err := s.addUnit(c, s.wordpress).AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.addUnit(c, s.mysql).AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.addUnit(c, s.wordpress).AssignToMachine(s.machine)
+	c.Assert(err, gc.ErrorMatches, `.*unit "wordpress/1" cannot be placed on machine 0: machine already hosts 2 units \(max-units-per-machine is 2\)`)
+	s.assertViolation(c, err, state.ErrColocationViolation{
+		Application: "wordpress",
+		Unit:        "wordpress/1",
+		Machine:     "0",
+		Rule:        "max-units-per-machine",
+		Limit:       2,
+		Units:       []string{"mysql/0", "wordpress/0"},
+	})
+}
+
+func (s *ColocationSuite) TestMaxUnitsPerMachineConcurrentAssignment(c *gc.C) {
+	s.setRules(c, "", 1)
+	wordpress := s.addUnit(c, s.wordpress)
+	mysql := s.addUnit(c, s.mysql)
+
+	defer state.SetBeforeHooks(c, s.State, func() {
+		err := mysql.AssignToMachine(s.machine)
+		c.Assert(err, jc.ErrorIsNil)
+	}).Check()
+
+	err := wordpress.AssignToMachine(s.machine)
+	c.Assert(err, jc.Satisfies, state.IsColocationViolationError)
+}
+
+func (s *ColocationSuite) TestAssignUnitWithPlacement(c *gc.C) {
+	s.setRules(c, "mysql,wordpress", 0)
+	err := s.addUnit(c, s.wordpress).AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mysql := s.addUnit(c, s.mysql)
+	err = s.State.AssignUnitWithPlacement(mysql, &instance.Placement{
+		Scope: instance.MachineScope, Directive: s.machine.Id(),
+	})
+	c.Assert(err, jc.Satisfies, state.IsColocationViolationError)
+
+	// Placing the unit in a new container on the machine is allowed.
+	err = s.State.AssignUnitWithPlacement(mysql, &instance.Placement{
+		Scope: string(instance.LXD), Directive: s.machine.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ColocationSuite) TestCheckColocation(c *gc.C) {
+	s.setRules(c, "mysql,wordpress", 0)
+	c.Assert(s.machine.CheckColocation("mysql"), jc.ErrorIsNil)
+	err := s.addUnit(c, s.wordpress).AssignToMachine(s.machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.machine.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.machine.CheckColocation("wordpress"), jc.ErrorIsNil)
+	err = s.machine.CheckColocation("mysql")
+	c.Assert(err, gc.ErrorMatches, `unit of application "mysql" cannot be placed on machine 0: anti-colocation group "mysql,wordpress" conflicts with wordpress/0`)
+}
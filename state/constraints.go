@@ -21,6 +21,8 @@ type constraintsDoc struct {
 	Arch           *string
 	CpuCores       *uint64
 	CpuPower       *uint64
+	GPUs           *uint64
+	GPUType        *string
 	Mem            *uint64
 	RootDisk       *uint64
 	RootDiskSource *string
@@ -37,6 +39,8 @@ func (doc constraintsDoc) value() constraints.Value {
 		Arch:           doc.Arch,
 		CpuCores:       doc.CpuCores,
 		CpuPower:       doc.CpuPower,
+		GPUs:           doc.GPUs,
+		GPUType:        doc.GPUType,
 		Mem:            doc.Mem,
 		RootDisk:       doc.RootDisk,
 		RootDiskSource: doc.RootDiskSource,
@@ -55,6 +59,8 @@ func newConstraintsDoc(cons constraints.Value) constraintsDoc {
 		Arch:           cons.Arch,
 		CpuCores:       cons.CpuCores,
 		CpuPower:       cons.CpuPower,
+		GPUs:           cons.GPUs,
+		GPUType:        cons.GPUType,
 		Mem:            cons.Mem,
 		RootDisk:       cons.RootDisk,
 		RootDiskSource: cons.RootDiskSource,
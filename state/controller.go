@@ -95,6 +95,13 @@ func (st *State) ControllerConfig() (jujucontroller.Config, error) {
 // so revert to their defaults). Only a subset of keys can be changed
 // after bootstrapping.
 func (st *State) UpdateControllerConfig(updateAttrs map[string]interface{}, removeAttrs []string) error {
+	return st.UpdateControllerConfigBy("", updateAttrs, removeAttrs)
+}
+
+// UpdateControllerConfigBy behaves as UpdateControllerConfig, and also
+// records the name of the user making the change against any feature
+// flags it enables or disables.
+func (st *State) UpdateControllerConfigBy(who string, updateAttrs map[string]interface{}, removeAttrs []string) error {
 	if err := st.checkValidControllerConfig(updateAttrs, removeAttrs); err != nil {
 		return errors.Trace(err)
 	}
@@ -103,6 +110,7 @@ func (st *State) UpdateControllerConfig(updateAttrs map[string]interface{}, remo
 	if err != nil {
 		return errors.Annotatef(err, "controller %q", st.ControllerUUID())
 	}
+	oldFlags := jujucontroller.Config(settings.Map()).Features()
 	for _, r := range removeAttrs {
 		settings.Delete(r)
 	}
@@ -110,7 +118,7 @@ func (st *State) UpdateControllerConfig(updateAttrs map[string]interface{}, remo
 
 	// Ensure the resulting config is still valid.
 	newValues := settings.Map()
-	_, err = jujucontroller.NewConfig(
+	newConfig, err := jujucontroller.NewConfig(
 		newValues[jujucontroller.ControllerUUIDKey].(string),
 		newValues[jujucontroller.CACertKey].(string),
 		newValues,
@@ -120,6 +128,7 @@ func (st *State) UpdateControllerConfig(updateAttrs map[string]interface{}, remo
 	}
 
 	_, ops := settings.settingsUpdateOps()
+	ops = append(ops, st.featureFlagChangeOps(oldFlags, newConfig.Features(), who)...)
 	return errors.Trace(settings.write(ops))
 }
 
@@ -4,6 +4,8 @@
 package state_test
 
 import (
+	"time"
+
 	"github.com/juju/clock"
 	"github.com/juju/collections/set"
 	"github.com/juju/errors"
@@ -45,6 +47,26 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 		controller.ModelLogfileMaxSize,
 		controller.PruneTxnQueryCount,
 		controller.PruneTxnSleepTime,
+		controller.PruneTxnMinCount,
+		controller.PruneTxnMaxCount,
+		controller.PruneTxnGrowthPercent,
+		controller.PruneTxnMinAge,
+		controller.PruneTxnMaxPassSize,
+		controller.RaftSnapshotThreshold,
+		controller.RaftSnapshotInterval,
+		controller.RaftTrailingLogs,
+		controller.ModelImportMode,
+		controller.APIDNSName,
+		controller.APIDNSUpdater,
+		controller.APIDNSTTL,
+		controller.APIDNSServer,
+		controller.APIDNSKeyFile,
+		controller.MaxModelApplications,
+		controller.MaxModelUnits,
+		controller.MaxModelMachines,
+		controller.MaxModelRelations,
+		controller.CloudAPIRateLimit,
+		controller.CloudAPIRateBurst,
 		controller.CAASOperatorImagePath,
 		controller.CAASImageRepo,
 		controller.CharmStoreURL,
@@ -121,6 +143,46 @@ func (s *ControllerSuite) TestUpdateControllerConfigRemoveYieldsDefaults(c *gc.C
 	c.Assert(newCfg.AuditLogCaptureArgs(), gc.Equals, false)
 }
 
+func (s *ControllerSuite) TestUpdateControllerConfigRecordsFeatureFlagChanges(c *gc.C) {
+	err := s.State.UpdateControllerConfigBy("bob", map[string]interface{}{
+		controller.Features: []interface{}{"foo", "bar"},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateControllerConfigBy("mary", map[string]interface{}{
+		controller.Features: []interface{}{"bar", "baz"},
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.UpdateControllerConfig(map[string]interface{}{
+		controller.AuditingEnabled: true,
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	changes, err := s.State.FeatureFlagChanges()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes, gc.HasLen, 4)
+	for _, change := range changes {
+		c.Check(change.Time.IsZero(), jc.IsFalse)
+		c.Check(change.Time.Location(), gc.Equals, time.UTC)
+	}
+	type flagChange struct {
+		flag    string
+		enabled bool
+		who     string
+	}
+	var got []flagChange
+	for _, change := range changes {
+		got = append(got, flagChange{change.Flag, change.Enabled, change.Who})
+	}
+	c.Assert(got, jc.SameContents, []flagChange{
+		{"bar", true, "bob"},
+		{"foo", true, "bob"},
+		{"baz", true, "mary"},
+		{"foo", false, "mary"},
+	})
+	c.Assert(changes[0].Who, gc.Equals, "bob")
+	c.Assert(changes[3].Who, gc.Equals, "mary")
+}
+
 func (s *ControllerSuite) TestUpdateControllerConfigRejectsDisallowedUpdates(c *gc.C) {
 	// Sanity check.
 	c.Assert(controller.AllowedUpdateConfigAttributes.Contains(controller.APIPort), jc.IsFalse)
@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/controller"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state/docstore"
 )
 
 var txnLogger = loggo.GetLogger("juju.state.txn")
@@ -103,6 +104,15 @@ type Database interface {
 	Schema() CollectionSchema
 }
 
+// getDocStore returns the named collection as a docstore.Collection,
+// with the same model filtering as GetCollection, and a func that must
+// be called when the collection is no longer needed. Read paths which
+// use it rather than GetCollection don't depend on the mgo driver.
+func getDocStore(db Database, name string) (docstore.Collection, SessionCloser) {
+	coll, closer := db.GetCollection(name)
+	return docstore.NewMgoCollection(coll), closer
+}
+
 // Change represents any mgo/txn-representable change to a Database.
 type Change interface {
 
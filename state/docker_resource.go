@@ -110,12 +110,24 @@ func (dr *dockerMetadataStorage) Get(resourceID string) (io.ReadCloser, int64, e
 	if err != nil {
 		return nil, -1, errors.Trace(err)
 	}
-	data, err := json.Marshal(
-		resources.DockerImageDetails{
-			RegistryPath: doc.RegistryPath,
-			Username:     doc.Username,
-			Password:     doc.Password,
-		})
+	details := resources.DockerImageDetails{
+		RegistryPath: doc.RegistryPath,
+		Username:     doc.Username,
+		Password:     doc.Password,
+	}
+	// Images uploaded without a password are pulled with the model's
+	// credential for their registry, if it has one.
+	if details.Password == "" {
+		cred, ok, err := dr.st.imageCredential(doc.RegistryPath)
+		if err != nil {
+			return nil, -1, errors.Trace(err)
+		}
+		if ok {
+			details.Username = cred.Username
+			details.Password = cred.Password
+		}
+	}
+	data, err := json.Marshal(details)
 	if err != nil {
 		return nil, -1, errors.Trace(err)
 	}
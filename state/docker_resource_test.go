@@ -106,6 +106,37 @@ func (s *dockerMetadataStorageSuite) TestGet(c *gc.C) {
 
 }
 
+func (s *dockerMetadataStorageSuite) TestGetWithRegistryCredential(c *gc.C) {
+	err := s.State.SetRegistryCredential(state.RegistryCredential{
+		Registry: "registry.example.com",
+		Username: "bob",
+		Password: "token",
+	}, "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.metadataStorage.Save("test-123", resources.DockerImageDetails{
+		RegistryPath: "registry.example.com/team/image:1.0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.metadataStorage.Save("test-456", resources.DockerImageDetails{
+		RegistryPath: "docker.io/team/image:1.0",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// An image in the registry is pulled with the model's credential.
+	retrieved, _, err := s.metadataStorage.Get("test-123")
+	c.Assert(err, jc.ErrorIsNil)
+	retrievedInfo := readerToDockerDetails(c, retrieved)
+	c.Assert(retrievedInfo.Username, gc.Equals, "bob")
+	c.Assert(retrievedInfo.Password, gc.Equals, "token")
+
+	// Images in other registries are not.
+	retrieved, _, err = s.metadataStorage.Get("test-456")
+	c.Assert(err, jc.ErrorIsNil)
+	retrievedInfo = readerToDockerDetails(c, retrieved)
+	c.Assert(retrievedInfo.Username, gc.Equals, "")
+	c.Assert(retrievedInfo.Password, gc.Equals, "")
+}
+
 func (s *dockerMetadataStorageSuite) TestRemove(c *gc.C) {
 	id := "test-123"
 	resource := resources.DockerImageDetails{
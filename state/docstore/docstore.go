@@ -0,0 +1,74 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package docstore defines a driver-independent interface for reading
+// documents from the collections in the juju database.
+//
+// The state package has always used gopkg.in/mgo.v2 directly, and its
+// queries are built from mgo's bson types. Code which reads documents
+// through a docstore.Collection instead only depends on the types in
+// this package, so state can be moved to another MongoDB driver one
+// read path at a time: once all the reads of a collection go through
+// this interface, only the implementation of Collection needs to
+// change.
+//
+// Documents are decoded into structs using their bson field tags,
+// which every Go MongoDB driver understands.
+package docstore
+
+// D is an ordered document. It is used for filters in which the order
+// of the elements matters, and for nested operator documents.
+type D []E
+
+// E is an element of a D.
+type E struct {
+	Key   string
+	Value interface{}
+}
+
+// M is an unordered document.
+type M map[string]interface{}
+
+// A is an array of values.
+type A []interface{}
+
+// FindOptions holds the options for FindAll.
+type FindOptions struct {
+	// Sort holds the names of the fields to sort the documents by.
+	// A name prefixed with "-" sorts in descending order.
+	Sort []string
+
+	// Limit is the largest number of documents to return; if it is
+	// zero, all the matching documents are returned.
+	Limit int
+
+	// Fields holds the names of the fields to return. If it is empty,
+	// all fields are returned.
+	Fields []string
+}
+
+// Collection provides read access to the documents in a collection.
+//
+// Filters may be nil, to match all the documents, or a D or M whose
+// values are themselves plain values, D, M or A.
+type Collection interface {
+	// Name returns the name of the collection.
+	Name() string
+
+	// FindId decodes the document with the given id into result, which
+	// must be a pointer to a struct or M. It returns an error
+	// satisfying errors.IsNotFound if there is no such document.
+	FindId(id interface{}, result interface{}) error
+
+	// FindOne decodes the first document matching the filter, in the
+	// given sort order, into result. It returns an error satisfying
+	// errors.IsNotFound if no documents match.
+	FindOne(filter interface{}, sort []string, result interface{}) error
+
+	// FindAll decodes the documents matching the filter into result,
+	// which must be a pointer to a slice.
+	FindAll(filter interface{}, options FindOptions, result interface{}) error
+
+	// Count returns the number of documents matching the filter.
+	Count(filter interface{}) (int, error)
+}
@@ -0,0 +1,175 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package docstore
+
+import (
+	"encoding/binary"
+	"io"
+	"reflect"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// maxDocumentSize is the largest document MongoDB will store.
+const maxDocumentSize = 16 * 1024 * 1024
+
+// ReadDumpCollection returns a Collection holding the documents read
+// from r, which must be in the format mongodump writes a collection's
+// .bson file in: the documents, one after the other.
+//
+// The collection is held in memory and doesn't use indexes, so it's
+// only suited to reading whole collections, such as those in a backup.
+// Its filters may only match top-level fields by equality, and it
+// can't sort documents.
+func ReadDumpCollection(name string, r io.Reader) (Collection, error) {
+	coll := &dumpCollection{name: name}
+	var size [4]byte
+	for {
+		if _, err := io.ReadFull(r, size[:]); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errors.Annotatef(err, "cannot read %s", name)
+		}
+		n := int(binary.LittleEndian.Uint32(size[:]))
+		if n < len(size) || n > maxDocumentSize {
+			return nil, errors.Errorf("cannot read %s: invalid document size %d", name, n)
+		}
+		data := make([]byte, n)
+		copy(data, size[:])
+		if _, err := io.ReadFull(r, data[len(size):]); err != nil {
+			return nil, errors.Annotatef(err, "cannot read %s", name)
+		}
+		var fields bson.M
+		if err := bson.Unmarshal(data, &fields); err != nil {
+			return nil, errors.Annotatef(err, "cannot read %s", name)
+		}
+		coll.docs = append(coll.docs, dumpDoc{raw: data, fields: fields})
+	}
+	return coll, nil
+}
+
+type dumpDoc struct {
+	raw    []byte
+	fields bson.M
+}
+
+type dumpCollection struct {
+	name string
+	docs []dumpDoc
+}
+
+// Name is part of the Collection interface.
+func (c *dumpCollection) Name() string {
+	return c.name
+}
+
+// FindId is part of the Collection interface.
+func (c *dumpCollection) FindId(id interface{}, result interface{}) error {
+	err := c.FindOne(M{"_id": id}, nil, result)
+	if errors.IsNotFound(err) {
+		return errors.NotFoundf("document %v in %s", id, c.name)
+	}
+	return errors.Trace(err)
+}
+
+// FindOne is part of the Collection interface.
+func (c *dumpCollection) FindOne(filter interface{}, sort []string, result interface{}) error {
+	if len(sort) > 0 {
+		return errors.NotSupportedf("sorting dumped documents")
+	}
+	docs, err := c.find(filter, 1)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(docs) == 0 {
+		return errors.NotFoundf("matching document in %s", c.name)
+	}
+	return errors.Trace(bson.Unmarshal(docs[0].raw, result))
+}
+
+// FindAll is part of the Collection interface. The fields to return
+// are ignored; each document is decoded whole.
+func (c *dumpCollection) FindAll(filter interface{}, options FindOptions, result interface{}) error {
+	if len(options.Sort) > 0 {
+		return errors.NotSupportedf("sorting dumped documents")
+	}
+	slice := reflect.ValueOf(result)
+	if slice.Kind() != reflect.Ptr || slice.Elem().Kind() != reflect.Slice {
+		return errors.Errorf("result must be a pointer to a slice, not %T", result)
+	}
+	docs, err := c.find(filter, options.Limit)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	values := reflect.MakeSlice(slice.Elem().Type(), len(docs), len(docs))
+	for i, doc := range docs {
+		if err := bson.Unmarshal(doc.raw, values.Index(i).Addr().Interface()); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	slice.Elem().Set(values)
+	return nil
+}
+
+// Count is part of the Collection interface.
+func (c *dumpCollection) Count(filter interface{}) (int, error) {
+	docs, err := c.find(filter, 0)
+	return len(docs), errors.Trace(err)
+}
+
+// find returns the documents matching the filter, up to limit of them
+// if limit is not zero.
+func (c *dumpCollection) find(filter interface{}, limit int) ([]dumpDoc, error) {
+	var match D
+	switch filter := filter.(type) {
+	case nil:
+	case D:
+		match = filter
+	case M:
+		for key, value := range filter {
+			match = append(match, E{key, value})
+		}
+	default:
+		return nil, errors.NotValidf("filter of type %T", filter)
+	}
+	for _, elem := range match {
+		switch elem.Value.(type) {
+		case D, M, A:
+			return nil, errors.NotSupportedf("matching dumped documents by %q with operators", elem.Key)
+		}
+	}
+	var docs []dumpDoc
+	for _, doc := range c.docs {
+		if limit > 0 && len(docs) == limit {
+			break
+		}
+		if doc.matches(match) {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+func (d dumpDoc) matches(filter D) bool {
+	for _, elem := range filter {
+		value, ok := d.fields[elem.Key]
+		if !ok || !reflect.DeepEqual(normalise(value), normalise(elem.Value)) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalise returns integers as int64s, so that values compare equal
+// however they were decoded.
+func normalise(value interface{}) interface{} {
+	switch value := value.(type) {
+	case int:
+		return int64(value)
+	case int32:
+		return int64(value)
+	}
+	return value
+}
@@ -0,0 +1,85 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package docstore_test
+
+import (
+	"bytes"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state/docstore"
+)
+
+type DumpCollectionSuite struct {
+	coll docstore.Collection
+}
+
+var _ = gc.Suite(&DumpCollectionSuite{})
+
+func (s *DumpCollectionSuite) SetUpTest(c *gc.C) {
+	var dump bytes.Buffer
+	for _, d := range []doc{
+		{Id: "a#1", Status: "active", Rank: 3},
+		{Id: "a#2", Status: "blocked", Rank: 1},
+		{Id: "b#1", Status: "active", Rank: 2},
+	} {
+		data, err := bson.Marshal(d)
+		c.Assert(err, jc.ErrorIsNil)
+		dump.Write(data)
+	}
+	coll, err := docstore.ReadDumpCollection("docs", &dump)
+	c.Assert(err, jc.ErrorIsNil)
+	s.coll = coll
+}
+
+func (s *DumpCollectionSuite) TestName(c *gc.C) {
+	c.Assert(s.coll.Name(), gc.Equals, "docs")
+}
+
+func (s *DumpCollectionSuite) TestFindId(c *gc.C) {
+	var result doc
+	err := s.coll.FindId("a#2", &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, doc{Id: "a#2", Status: "blocked", Rank: 1})
+
+	err = s.coll.FindId("c#1", &result)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, "document c#1 in docs not found")
+}
+
+func (s *DumpCollectionSuite) TestFindAll(c *gc.C) {
+	var result []doc
+	err := s.coll.FindAll(docstore.D{{"status", "active"}, {"rank", 2}}, docstore.FindOptions{}, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, []doc{{Id: "b#1", Status: "active", Rank: 2}})
+
+	err = s.coll.FindAll(nil, docstore.FindOptions{Limit: 2}, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.HasLen, 2)
+}
+
+func (s *DumpCollectionSuite) TestFindAllUnsupported(c *gc.C) {
+	var result []doc
+	err := s.coll.FindAll(docstore.M{"rank": docstore.M{"$gt": 1}}, docstore.FindOptions{}, &result)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+
+	err = s.coll.FindAll(nil, docstore.FindOptions{Sort: []string{"rank"}}, &result)
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *DumpCollectionSuite) TestCount(c *gc.C) {
+	n, err := s.coll.Count(docstore.M{"status": "active"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(n, gc.Equals, 2)
+}
+
+func (s *DumpCollectionSuite) TestReadTruncated(c *gc.C) {
+	data, err := bson.Marshal(doc{Id: "a#1"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = docstore.ReadDumpCollection("docs", bytes.NewReader(data[:len(data)-1]))
+	c.Assert(err, gc.ErrorMatches, "cannot read docs: unexpected EOF")
+}
@@ -0,0 +1,101 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package docstore
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/mongo"
+)
+
+// NewMgoCollection returns a Collection which reads documents through
+// the given mgo-based collection. Any model filtering done by the
+// collection applies to the documents read.
+func NewMgoCollection(coll mongo.Collection) Collection {
+	return mgoCollection{coll}
+}
+
+type mgoCollection struct {
+	coll mongo.Collection
+}
+
+// Name is part of the Collection interface.
+func (c mgoCollection) Name() string {
+	return c.coll.Name()
+}
+
+// FindId is part of the Collection interface.
+func (c mgoCollection) FindId(id interface{}, result interface{}) error {
+	err := c.coll.FindId(id).One(result)
+	if err == mgo.ErrNotFound {
+		return errors.NotFoundf("document %v in %s", id, c.coll.Name())
+	}
+	return errors.Trace(err)
+}
+
+// FindOne is part of the Collection interface.
+func (c mgoCollection) FindOne(filter interface{}, sort []string, result interface{}) error {
+	query := c.coll.Find(toBSON(filter))
+	if len(sort) > 0 {
+		query = query.Sort(sort...)
+	}
+	err := query.One(result)
+	if err == mgo.ErrNotFound {
+		return errors.NotFoundf("matching document in %s", c.coll.Name())
+	}
+	return errors.Trace(err)
+}
+
+// FindAll is part of the Collection interface.
+func (c mgoCollection) FindAll(filter interface{}, options FindOptions, result interface{}) error {
+	query := c.coll.Find(toBSON(filter))
+	if len(options.Fields) > 0 {
+		fields := make(bson.D, len(options.Fields))
+		for i, field := range options.Fields {
+			fields[i] = bson.DocElem{Name: field, Value: 1}
+		}
+		query = query.Select(fields)
+	}
+	if len(options.Sort) > 0 {
+		query = query.Sort(options.Sort...)
+	}
+	if options.Limit > 0 {
+		query = query.Limit(options.Limit)
+	}
+	return errors.Trace(query.All(result))
+}
+
+// Count is part of the Collection interface.
+func (c mgoCollection) Count(filter interface{}) (int, error) {
+	n, err := c.coll.Find(toBSON(filter)).Count()
+	return n, errors.Trace(err)
+}
+
+// toBSON converts the documents in a filter, at any depth, to their
+// mgo bson equivalents. Other values are returned unchanged.
+func toBSON(value interface{}) interface{} {
+	switch value := value.(type) {
+	case D:
+		result := make(bson.D, len(value))
+		for i, elem := range value {
+			result[i] = bson.DocElem{Name: elem.Key, Value: toBSON(elem.Value)}
+		}
+		return result
+	case M:
+		result := make(bson.M, len(value))
+		for key, elem := range value {
+			result[key] = toBSON(elem)
+		}
+		return result
+	case A:
+		result := make([]interface{}, len(value))
+		for i, elem := range value {
+			result[i] = toBSON(elem)
+		}
+		return result
+	}
+	return value
+}
@@ -0,0 +1,115 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package docstore_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2"
+
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state/docstore"
+)
+
+type MgoCollectionSuite struct {
+	testing.MgoSuite
+	session *mgo.Session
+	coll    docstore.Collection
+}
+
+var _ = gc.Suite(&MgoCollectionSuite{})
+
+type doc struct {
+	Id     string `bson:"_id"`
+	Status string `bson:"status"`
+	Rank   int    `bson:"rank"`
+}
+
+func (s *MgoCollectionSuite) SetUpTest(c *gc.C) {
+	s.MgoSuite.SetUpTest(c)
+	session, err := testing.MgoServer.Dial()
+	c.Assert(err, jc.ErrorIsNil)
+	s.session = session
+	raw := s.session.DB("testing").C("docs")
+	for _, d := range []doc{
+		{Id: "a#1", Status: "active", Rank: 3},
+		{Id: "a#2", Status: "blocked", Rank: 1},
+		{Id: "b#1", Status: "active", Rank: 2},
+	} {
+		err = raw.Insert(d)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	s.coll = docstore.NewMgoCollection(mongo.WrapCollection(raw))
+}
+
+func (s *MgoCollectionSuite) TearDownTest(c *gc.C) {
+	s.session.Close()
+	s.MgoSuite.TearDownTest(c)
+}
+
+func (s *MgoCollectionSuite) TestName(c *gc.C) {
+	c.Assert(s.coll.Name(), gc.Equals, "docs")
+}
+
+func (s *MgoCollectionSuite) TestFindId(c *gc.C) {
+	var result doc
+	err := s.coll.FindId("a#2", &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, doc{Id: "a#2", Status: "blocked", Rank: 1})
+}
+
+func (s *MgoCollectionSuite) TestFindIdNotFound(c *gc.C) {
+	var result doc
+	err := s.coll.FindId("c#1", &result)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, "document c#1 in docs not found")
+}
+
+func (s *MgoCollectionSuite) TestFindOne(c *gc.C) {
+	var result doc
+	err := s.coll.FindOne(docstore.M{"status": "active"}, []string{"-rank"}, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Id, gc.Equals, "a#1")
+
+	err = s.coll.FindOne(docstore.M{"status": "error"}, nil, &result)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *MgoCollectionSuite) TestFindAllNestedFilter(c *gc.C) {
+	var result []doc
+	filter := docstore.D{
+		{"_id", docstore.D{{"$regex", "^a#"}}},
+		{"status", docstore.M{"$in": docstore.A{"active", "blocked"}}},
+	}
+	err := s.coll.FindAll(filter, docstore.FindOptions{Sort: []string{"rank"}}, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, []doc{
+		{Id: "a#2", Status: "blocked", Rank: 1},
+		{Id: "a#1", Status: "active", Rank: 3},
+	})
+}
+
+func (s *MgoCollectionSuite) TestFindAllOptions(c *gc.C) {
+	var result []doc
+	options := docstore.FindOptions{
+		Sort:   []string{"-rank"},
+		Limit:  2,
+		Fields: []string{"_id"},
+	}
+	err := s.coll.FindAll(nil, options, &result)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, []doc{{Id: "a#1"}, {Id: "b#1"}})
+}
+
+func (s *MgoCollectionSuite) TestCount(c *gc.C) {
+	n, err := s.coll.Count(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(n, gc.Equals, 3)
+
+	n, err = s.coll.Count(docstore.M{"status": "active"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(n, gc.Equals, 2)
+}
@@ -51,6 +51,10 @@ const (
 	GUISettingsC      = guisettingsC
 	GlobalSettingsC   = globalSettingsC
 	SettingsC         = settingsC
+	StatusesC         = statusesC
+	SchemaVersionKey  = schemaVersionKey
+
+	MaxRelationSettingsHistory = maxRelationSettingsHistory
 )
 
 var (
@@ -327,6 +331,18 @@ func MinUnitsRevno(st *State, applicationname string) (int, error) {
 	return doc.Revno, nil
 }
 
+// LostUnits returns the LostUnits of the minUnits document
+// associated with the given application name.
+func LostUnits(st *State, applicationname string) ([]string, error) {
+	minUnitsCollection, closer := st.db().GetCollection(minUnitsC)
+	defer closer()
+	var doc minUnitsDoc
+	if err := minUnitsCollection.FindId(applicationname).One(&doc); err != nil {
+		return nil, err
+	}
+	return doc.LostUnits, nil
+}
+
 func ConvertTagToCollectionNameAndId(st *State, tag names.Tag) (string, interface{}, error) {
 	return st.tagToCollectionAndId(tag)
 }
@@ -961,3 +977,19 @@ func (st *State) SetClockForTesting(clock clock.Clock) error {
 	}
 	return nil
 }
+
+// SetModelArchiveRestored records that the given applications have
+// been restored by an earlier, failed, thaw of the model.
+func SetModelArchiveRestored(c *gc.C, st *State, restored map[string]int) {
+	var set bson.D
+	for name, count := range restored {
+		set = append(set, bson.DocElem{"restored." + name, count})
+	}
+	err := st.db().RunTransaction([]txn.Op{{
+		C:      modelArchivesC,
+		Id:     st.docID(modelArchiveKey),
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", set}},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+}
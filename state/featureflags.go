@@ -0,0 +1,87 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// featureFlagChangeDoc records a controller feature flag being enabled
+// or disabled through the features controller config setting.
+type featureFlagChangeDoc struct {
+	DocId   string    `bson:"_id"`
+	Flag    string    `bson:"flag"`
+	Enabled bool      `bson:"enabled"`
+	Who     string    `bson:"who,omitempty"`
+	Time    time.Time `bson:"time"`
+}
+
+// FeatureFlagChange describes a controller feature flag being enabled
+// or disabled.
+type FeatureFlagChange struct {
+	// Flag is the name of the feature flag.
+	Flag string
+
+	// Enabled is true if the flag was turned on, and false if it was
+	// turned off.
+	Enabled bool
+
+	// Who is the name of the user who changed the flag, if known.
+	Who string
+
+	// Time is when the flag was changed.
+	Time time.Time
+}
+
+// featureFlagChangeOps returns the txn.Ops which record the differences
+// between the old and new sets of controller feature flags.
+func (st *State) featureFlagChangeOps(oldFlags, newFlags set.Strings, who string) []txn.Op {
+	now := st.nowToTheSecond()
+	var ops []txn.Op
+	record := func(flags set.Strings, enabled bool) {
+		for _, flag := range flags.SortedValues() {
+			ops = append(ops, txn.Op{
+				C:      featureFlagChangesC,
+				Id:     bson.NewObjectId().Hex(),
+				Assert: txn.DocMissing,
+				Insert: &featureFlagChangeDoc{
+					Flag:    flag,
+					Enabled: enabled,
+					Who:     who,
+					Time:    now,
+				},
+			})
+		}
+	}
+	record(newFlags.Difference(oldFlags), true)
+	record(oldFlags.Difference(newFlags), false)
+	return ops
+}
+
+// FeatureFlagChanges returns the changes made to the controller's
+// feature flags, oldest first.
+func (st *State) FeatureFlagChanges() ([]FeatureFlagChange, error) {
+	changes, closer := st.db().GetCollection(featureFlagChangesC)
+	defer closer()
+
+	var docs []featureFlagChangeDoc
+	if err := changes.Find(nil).Sort("time", "_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read feature flag changes")
+	}
+	result := make([]FeatureFlagChange, len(docs))
+	for i, doc := range docs {
+		result[i] = FeatureFlagChange{
+			Flag:    doc.Flag,
+			Enabled: doc.Enabled,
+			Who:     doc.Who,
+			Time:    doc.Time.UTC(),
+		}
+	}
+	return result, nil
+}
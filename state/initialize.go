@@ -125,6 +125,7 @@ func convertCloudCredentialToState(tag names.CloudCredentialTag, cloudCredential
 	credential.DocID = cloudCredentialDocID(tag)
 	credential.Invalid = cloudCredential.Invalid
 	credential.InvalidReason = cloudCredential.InvalidReason
+	credential.Expiry = cloudCredential.Expiry.UTC()
 	return credential
 }
 
@@ -255,6 +256,13 @@ func Initialize(args InitializeParams) (_ *Controller, err error) {
 			Assert: txn.DocMissing,
 			Insert: &hostedModelCountDoc{},
 		},
+		txn.Op{
+			C:      controllersC,
+			Id:     schemaVersionKey,
+			Assert: txn.DocMissing,
+			// A new controller has no data to migrate.
+			Insert: &schemaVersionDoc{Version: LatestSchemaVersion()},
+		},
 		createSettingsOp(controllersC, controllerSettingsGlobalKey, args.ControllerConfig),
 		createSettingsOp(globalSettingsC, cloudGlobalKey(args.Cloud.Name), args.ControllerInheritedConfig),
 	)
@@ -0,0 +1,114 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// instancePollRequestDoc records that the instance of a machine should
+// be polled by the instance poller without waiting for its next poll.
+// Its id is the machine's id.
+type instancePollRequestDoc struct {
+	DocID     string `bson:"_id"`
+	ModelUUID string `bson:"model-uuid"`
+}
+
+// RequestInstancePoll asks the instance poller to refresh the addresses
+// and status of the machine's instance as soon as it can. Requests made
+// before the poller has seen an earlier one are merged with it.
+func (m *Machine) RequestInstancePoll() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot request instance poll for machine %s", m)
+	docID := m.st.docID(m.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := m.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if m.doc.Life == Dead {
+			return nil, errors.New("machine is dead")
+		}
+		coll, closer := m.st.db().GetCollection(instancePollRequestsC)
+		defer closer()
+		n, err := coll.FindId(docID).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n > 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      machinesC,
+			Id:     m.doc.DocID,
+			Assert: notDeadDoc,
+		}, {
+			C:      instancePollRequestsC,
+			Id:     docID,
+			Assert: txn.DocMissing,
+			Insert: &instancePollRequestDoc{
+				DocID:     docID,
+				ModelUUID: m.st.ModelUUID(),
+			},
+		}}, nil
+	}
+	return errors.Trace(m.st.db().Run(buildTxn))
+}
+
+// WatchInstancePollRequests returns a StringsWatcher which reports the
+// ids of the machines whose instances have been asked to be polled.
+// Requests remain until cleared with ClearInstancePollRequests.
+func (st *State) WatchInstancePollRequests() StringsWatcher {
+	return newCollectionWatcher(st, colWCfg{col: instancePollRequestsC})
+}
+
+// ClearInstancePollRequests removes the requests to poll the instances
+// of the machines with the given ids, so that the next request for each
+// is reported again.
+func (st *State) ClearInstancePollRequests(machineIds []string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot clear instance poll requests")
+	if len(machineIds) == 0 {
+		return nil
+	}
+	docIDs := make([]string, len(machineIds))
+	for i, id := range machineIds {
+		docIDs[i] = st.docID(id)
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		coll, closer := st.db().GetCollection(instancePollRequestsC)
+		defer closer()
+		var docs []instancePollRequestDoc
+		query := bson.D{{"_id", bson.D{{"$in", docIDs}}}}
+		if err := coll.Find(query).Select(bson.D{{"_id", 1}}).All(&docs); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(docs) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		ops := make([]txn.Op, len(docs))
+		for i, doc := range docs {
+			ops[i] = txn.Op{
+				C:      instancePollRequestsC,
+				Id:     doc.DocID,
+				Assert: txn.DocExists,
+				Remove: true,
+			}
+		}
+		return ops, nil
+	}
+	return errors.Trace(st.db().Run(buildTxn))
+}
+
+// removeInstancePollRequestOp returns the operation which removes any
+// request to poll the instance of the machine with the given id.
+func removeInstancePollRequestOp(mb modelBackend, machineId string) txn.Op {
+	return txn.Op{
+		C:      instancePollRequestsC,
+		Id:     mb.docID(machineId),
+		Remove: true,
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type InstancePollRequestsSuite struct {
+	ConnSuite
+	machine *state.Machine
+}
+
+var _ = gc.Suite(&InstancePollRequestsSuite{})
+
+func (s *InstancePollRequestsSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.machine = s.Factory.MakeMachine(c, &factory.MachineParams{})
+}
+
+func (s *InstancePollRequestsSuite) TestWatchInstancePollRequests(c *gc.C) {
+	other := s.Factory.MakeMachine(c, &factory.MachineParams{})
+	err := s.machine.RequestInstancePoll()
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.State.WatchInstancePollRequests()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChange(s.machine.Id())
+	wc.AssertNoChange()
+
+	// Pending requests are merged.
+	err = s.machine.RequestInstancePoll()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+
+	err = other.RequestInstancePoll()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(other.Id())
+	wc.AssertNoChange()
+
+	// Once cleared, a request is reported again.
+	err = s.State.ClearInstancePollRequests([]string{s.machine.Id(), other.Id()})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+	err = s.machine.RequestInstancePoll()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange(s.machine.Id())
+	wc.AssertNoChange()
+}
+
+func (s *InstancePollRequestsSuite) TestClearInstancePollRequestsNotRequested(c *gc.C) {
+	err := s.State.ClearInstancePollRequests([]string{s.machine.Id(), "42"})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *InstancePollRequestsSuite) TestRequestInstancePollDeadMachine(c *gc.C) {
+	err := s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.RequestInstancePoll()
+	c.Assert(err, gc.ErrorMatches, "cannot request instance poll for machine 0: machine is dead")
+}
+
+func (s *InstancePollRequestsSuite) TestRemovedWithMachine(c *gc.C) {
+	err := s.machine.RequestInstancePoll()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	coll, closer := state.GetCollection(s.State, "instancePollRequests")
+	defer closer()
+	count, err := coll.Count()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+}
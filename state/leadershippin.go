@@ -0,0 +1,210 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/status"
+	mgoutils "github.com/juju/juju/mongo/utils"
+)
+
+// MaxLeadershipPinDuration is the longest an operator may pin the
+// leader of an application for.
+const MaxLeadershipPinDuration = 24 * time.Hour
+
+// LeadershipPin records that an operator has pinned the leader of an
+// application, so that leadership doesn't change while maintenance is
+// carried out. The pin itself is held by the lease manager; the record
+// lets it be shown in the application's status data, and removed once
+// it expires.
+type LeadershipPin struct {
+	// By is the tag of the user who pinned the leader. The lease is
+	// pinned on behalf of this entity.
+	By string
+
+	// Reason is why the leader was pinned.
+	Reason string
+
+	// Until is when the pin expires.
+	Until time.Time
+}
+
+// Validate returns an error if the pin is not valid.
+func (p LeadershipPin) Validate() error {
+	if p.By == "" {
+		return errors.NotValidf("leadership pin without entity")
+	}
+	if p.Until.IsZero() {
+		return errors.NotValidf("leadership pin without expiry")
+	}
+	return nil
+}
+
+// withStatusData returns a copy of the status data with the pin's
+// details added.
+func (p LeadershipPin) withStatusData(data map[string]interface{}) map[string]interface{} {
+	result := withoutLeadershipPinData(data)
+	result[status.LeadershipPinnedByKey] = p.By
+	result[status.LeadershipPinnedUntilKey] = p.Until.UTC().Format(time.RFC3339)
+	if p.Reason != "" {
+		result[status.LeadershipPinReasonKey] = p.Reason
+	}
+	return result
+}
+
+// withoutLeadershipPinData returns a copy of the status data without
+// the details of any leadership pin.
+func withoutLeadershipPinData(data map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{})
+	for k, v := range data {
+		result[k] = v
+	}
+	for _, k := range status.LeadershipPinDataKeys() {
+		delete(result, k)
+	}
+	return result
+}
+
+// leadershipPinDoc is the form in which a LeadershipPin is held in the
+// application document.
+type leadershipPinDoc struct {
+	By     string `bson:"by"`
+	Reason string `bson:"reason,omitempty"`
+	Until  int64  `bson:"until"`
+}
+
+// LeadershipPin returns the record of the application's leader having
+// been pinned by an operator, and whether there is one.
+func (a *Application) LeadershipPin() (LeadershipPin, bool) {
+	doc := a.doc.LeadershipPin
+	if doc == nil {
+		return LeadershipPin{}, false
+	}
+	return LeadershipPin{
+		By:     doc.By,
+		Reason: doc.Reason,
+		Until:  time.Unix(0, doc.Until).UTC(),
+	}, true
+}
+
+// SetLeadershipPin records that the application's leader has been
+// pinned, replacing any earlier record, and adds the pin's details to
+// the application's status data.
+func (a *Application) SetLeadershipPin(pin LeadershipPin) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot record leadership pin for application %q", a)
+	if err := pin.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	doc := &leadershipPinDoc{
+		By:     pin.By,
+		Reason: pin.Reason,
+		Until:  pin.Until.UnixNano(),
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.Life != Alive {
+			return nil, errors.New("application is no longer alive")
+		}
+		statusOp, err := a.leadershipPinStatusOp(pin.withStatusData)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: isAliveDoc,
+			Update: bson.D{{"$set", bson.D{{"leadership-pin", doc}}}},
+		}, statusOp}, nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	a.doc.LeadershipPin = doc
+	return nil
+}
+
+// ClearLeadershipPin removes the record of the application's leader
+// having been pinned, along with the pin's details in the application's
+// status data.
+func (a *Application) ClearLeadershipPin() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot clear leadership pin for application %q", a)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if a.doc.LeadershipPin == nil {
+			return nil, jujutxn.ErrNoOperations
+		}
+		statusOp, err := a.leadershipPinStatusOp(withoutLeadershipPinData)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$unset", bson.D{{"leadership-pin", nil}}}},
+		}, statusOp}, nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	a.doc.LeadershipPin = nil
+	return nil
+}
+
+// leadershipPinStatusOp returns the operation which replaces the data
+// of the application's status with the result of applying update to
+// it. The rest of the status, including whether it has been set, is
+// left alone.
+func (a *Application) leadershipPinStatusOp(update func(map[string]interface{}) map[string]interface{}) (txn.Op, error) {
+	current, err := getStatus(a.st.db(), a.globalKey(), "application")
+	if err != nil {
+		return txn.Op{}, errors.Trace(err)
+	}
+	txnRevno, err := readTxnRevno(a.st.db(), statusesC, a.globalKey())
+	if err != nil {
+		return txn.Op{}, errors.Trace(err)
+	}
+	return txn.Op{
+		C:      statusesC,
+		Id:     a.st.docID(a.globalKey()),
+		Assert: bson.D{{"txn-revno", txnRevno}},
+		Update: bson.D{{"$set", bson.D{
+			{"statusdata", mgoutils.EscapeKeys(update(current.Data))},
+		}}},
+	}, nil
+}
+
+// ApplicationsWithExpiredLeadershipPins returns the applications whose
+// leaders were pinned by an operator until the given time or earlier.
+func (st *State) ApplicationsWithExpiredLeadershipPins(now time.Time) ([]*Application, error) {
+	applications, closer := st.db().GetCollection(applicationsC)
+	defer closer()
+
+	var docs []applicationDoc
+	err := applications.Find(bson.D{
+		{"leadership-pin.until", bson.D{{"$lte", now.UnixNano()}}},
+	}).All(&docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get applications with expired leadership pins")
+	}
+	result := make([]*Application, len(docs))
+	for i := range docs {
+		result[i] = newApplication(st, &docs[i])
+	}
+	return result, nil
+}
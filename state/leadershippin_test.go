@@ -0,0 +1,133 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type LeadershipPinSuite struct {
+	ConnSuite
+	application *state.Application
+	until       time.Time
+}
+
+var _ = gc.Suite(&LeadershipPinSuite{})
+
+func (s *LeadershipPinSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.application = s.Factory.MakeApplication(c, nil)
+	s.until = time.Date(2020, 4, 1, 10, 0, 0, 0, time.UTC)
+}
+
+func (s *LeadershipPinSuite) pin(c *gc.C) state.LeadershipPin {
+	pin := state.LeadershipPin{
+		By:     "user-admin",
+		Reason: "restoring backup",
+		Until:  s.until,
+	}
+	err := s.application.SetLeadershipPin(pin)
+	c.Assert(err, jc.ErrorIsNil)
+	return pin
+}
+
+func (s *LeadershipPinSuite) TestNoPin(c *gc.C) {
+	_, ok := s.application.LeadershipPin()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *LeadershipPinSuite) TestSetLeadershipPin(c *gc.C) {
+	pin := s.pin(c)
+
+	err := s.application.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	got, ok := s.application.LeadershipPin()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(got, jc.DeepEquals, pin)
+
+	statusInfo, err := s.application.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Data, jc.DeepEquals, map[string]interface{}{
+		status.LeadershipPinnedByKey:    "user-admin",
+		status.LeadershipPinnedUntilKey: "2020-04-01T10:00:00Z",
+		status.LeadershipPinReasonKey:   "restoring backup",
+	})
+}
+
+func (s *LeadershipPinSuite) TestSetLeadershipPinInvalid(c *gc.C) {
+	err := s.application.SetLeadershipPin(state.LeadershipPin{Until: s.until})
+	c.Assert(err, gc.ErrorMatches, `cannot record leadership pin for application ".*": leadership pin without entity not valid`)
+	err = s.application.SetLeadershipPin(state.LeadershipPin{By: "user-admin"})
+	c.Assert(err, gc.ErrorMatches, `cannot record leadership pin for application ".*": leadership pin without expiry not valid`)
+}
+
+func (s *LeadershipPinSuite) TestSetStatusKeepsPin(c *gc.C) {
+	s.pin(c)
+	now := testing.ZeroTime()
+	err := s.application.SetStatus(status.StatusInfo{
+		Status:  status.Active,
+		Message: "ready",
+		Data:    map[string]interface{}{"foo": "bar"},
+		Since:   &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	statusInfo, err := s.application.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, status.Active)
+	c.Assert(statusInfo.Data, jc.DeepEquals, map[string]interface{}{
+		"foo":                           "bar",
+		status.LeadershipPinnedByKey:    "user-admin",
+		status.LeadershipPinnedUntilKey: "2020-04-01T10:00:00Z",
+		status.LeadershipPinReasonKey:   "restoring backup",
+	})
+}
+
+func (s *LeadershipPinSuite) TestClearLeadershipPin(c *gc.C) {
+	s.pin(c)
+	now := testing.ZeroTime()
+	err := s.application.SetStatus(status.StatusInfo{
+		Status: status.Active,
+		Data:   map[string]interface{}{"foo": "bar"},
+		Since:  &now,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.application.ClearLeadershipPin()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := s.application.LeadershipPin()
+	c.Assert(ok, jc.IsFalse)
+
+	statusInfo, err := s.application.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(statusInfo.Status, gc.Equals, status.Active)
+	c.Assert(statusInfo.Data, jc.DeepEquals, map[string]interface{}{"foo": "bar"})
+
+	// Clearing a missing pin is a no-op.
+	err = s.application.ClearLeadershipPin()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *LeadershipPinSuite) TestApplicationsWithExpiredLeadershipPins(c *gc.C) {
+	s.pin(c)
+	// Applications without pins are never returned.
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "unpinned"})
+
+	applications, err := s.State.ApplicationsWithExpiredLeadershipPins(s.until.Add(-time.Second))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(applications, gc.HasLen, 0)
+
+	applications, err = s.State.ApplicationsWithExpiredLeadershipPins(s.until)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(applications, gc.HasLen, 1)
+	c.Assert(applications[0].Name(), gc.Equals, s.application.Name())
+}
@@ -223,6 +223,11 @@ func (store *store) ExpireLease(key lease.Key) error {
 	return nil
 }
 
+// RevokeLease is part of the Store interface.
+func (store *store) RevokeLease(key lease.Key, _ <-chan struct{}) error {
+	return errors.NotImplementedf("revoking legacy leases")
+}
+
 // PinLease is part of the Store interface.
 func (store *store) PinLease(key lease.Key, entity string, _ <-chan struct{}) error {
 	return errors.NotImplementedf("pinning for legacy leases")
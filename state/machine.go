@@ -206,6 +206,18 @@ func (m *Machine) globalModificationKey() string {
 	return machineGlobalModificationKey(m.doc.Id)
 }
 
+// machineGlobalProvisioningKey returns the global database key for the
+// identified machine's provisioning timeline.
+func machineGlobalProvisioningKey(id string) string {
+	return machineGlobalKey(id) + "#provisioning"
+}
+
+// globalProvisioningKey returns the global database key for the
+// machine's provisioning timeline.
+func (m *Machine) globalProvisioningKey() string {
+	return machineGlobalProvisioningKey(m.doc.Id)
+}
+
 // globalKey returns the global database key for the machine.
 func (m *Machine) globalKey() string {
 	return machineGlobalKey(m.doc.Id)
@@ -224,6 +236,8 @@ type instanceData struct {
 	RootDiskSource *string     `bson:"rootdisksource,omitempty"`
 	CpuCores       *uint64     `bson:"cpucores,omitempty"`
 	CpuPower       *uint64     `bson:"cpupower,omitempty"`
+	GPUs           *uint64     `bson:"gpus,omitempty"`
+	GPUType        *string     `bson:"gputype,omitempty"`
 	Tags           *[]string   `bson:"tags,omitempty"`
 	AvailZone      *string     `bson:"availzone,omitempty"`
 
@@ -244,6 +258,8 @@ func hardwareCharacteristics(instData instanceData) *instance.HardwareCharacteri
 		RootDiskSource:   instData.RootDiskSource,
 		CpuCores:         instData.CpuCores,
 		CpuPower:         instData.CpuPower,
+		GPUs:             instData.GPUs,
+		GPUType:          instData.GPUType,
 		Tags:             instData.Tags,
 		AvailabilityZone: instData.AvailZone,
 	}
@@ -258,6 +274,43 @@ func (m *Machine) HardwareCharacteristics() (*instance.HardwareCharacteristics,
 	return hardwareCharacteristics(instData), nil
 }
 
+// UpdateHardwareCharacteristics records the hardware reported by the
+// provider for the machine's instance, which may have been resized
+// since it was provisioned. Only the memory, root disk, CPU cores and
+// CPU power are updated, and only when they are set in hc.
+func (m *Machine) UpdateHardwareCharacteristics(hc instance.HardwareCharacteristics) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		instData, err := getInstanceData(m.st, m.Id())
+		if errors.IsNotFound(err) {
+			return nil, errors.NotProvisionedf("machine %v", m.Id())
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		var updates bson.D
+		maybeUpdate := func(field string, current, value *uint64) {
+			if value != nil && (current == nil || *current != *value) {
+				updates = append(updates, bson.DocElem{Name: field, Value: *value})
+			}
+		}
+		maybeUpdate("mem", instData.Mem, hc.Mem)
+		maybeUpdate("rootdisk", instData.RootDisk, hc.RootDisk)
+		maybeUpdate("cpucores", instData.CpuCores, hc.CpuCores)
+		maybeUpdate("cpupower", instData.CpuPower, hc.CpuPower)
+		if len(updates) == 0 {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      instanceDataC,
+			Id:     m.doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", updates}},
+		}}, nil
+	}
+	err := m.st.db().Run(buildTxn)
+	return errors.Annotatef(err, "cannot update hardware characteristics of machine %v", m)
+}
+
 func getInstanceData(st *State, id string) (instanceData, error) {
 	instanceDataCollection, closer := st.db().GetCollection(instanceDataC)
 	defer closer()
@@ -990,6 +1043,8 @@ func (m *Machine) removeOps() ([]txn.Op, error) {
 		removeConstraintsOp(m.globalKey()),
 		annotationRemoveOp(m.st, m.globalKey()),
 		removeRebootDocOp(m.st, m.globalKey()),
+		removeAgentConfigOverridesOp(m.st, m.globalKey()),
+		removeInstancePollRequestOp(m.st, m.Id()),
 		removeMachineBlockDevicesOp(m.Id()),
 		removeModelMachineRefOp(m.st, m.Id()),
 		removeSSHHostKeyOp(m.globalKey()),
@@ -1167,14 +1222,24 @@ func (m *Machine) InstanceStatus() (status.StatusInfo, error) {
 
 // SetInstanceStatus sets the provider specific instance status for a machine.
 func (m *Machine) SetInstanceStatus(sInfo status.StatusInfo) (err error) {
-	return setStatus(m.st.db(), setStatusParams{
+	updated := timeOrNow(sInfo.Since, m.st.clock())
+	if err := setStatus(m.st.db(), setStatusParams{
 		badge:     "instance",
 		globalKey: m.globalInstanceKey(),
 		status:    sInfo.Status,
 		message:   sInfo.Message,
 		rawData:   sInfo.Data,
-		updated:   timeOrNow(sInfo.Since, m.st.clock()),
-	})
+		updated:   updated,
+	}); err != nil {
+		return err
+	}
+	switch sInfo.Status {
+	case status.Provisioning:
+		m.recordProvisioningPhase(status.PhaseInstanceRequested, "", *updated)
+	case status.Running:
+		m.recordProvisioningPhase(status.PhaseInstanceRunning, "", *updated)
+	}
+	return nil
 }
 
 // InstanceStatusHistory returns a slice of at most filter.Size StatusInfo items
@@ -1325,6 +1390,8 @@ func (m *Machine) SetProvisioned(
 		RootDiskSource: characteristics.RootDiskSource,
 		CpuCores:       characteristics.CpuCores,
 		CpuPower:       characteristics.CpuPower,
+		GPUs:           characteristics.GPUs,
+		GPUType:        characteristics.GPUType,
 		Tags:           characteristics.Tags,
 		AvailZone:      characteristics.AvailabilityZone,
 	}
@@ -1451,28 +1518,39 @@ func (m *Machine) PublicAddress() (corenetwork.SpaceAddress, error) {
 // maybeGetNewAddress determines if the current address is the most appropriate
 // match, and if not it selects the best from the slice of all available
 // addresses. It returns the new address and a bool indicating if a different
-// one was picked.
+// one was picked. The policy determines whether provider or machine
+// addresses are preferred, or whether they are considered together.
 func maybeGetNewAddress(
 	addr address,
 	providerAddresses,
 	machineAddresses []address,
+	policy corenetwork.AddressConflictPolicy,
 	getAddr func([]address) corenetwork.SpaceAddress,
 	checkScope func(address) bool,
 ) (address, bool) {
-	// For picking the best address, try provider addresses first.
+	if policy == corenetwork.MergeWithScopePriority {
+		return maybeGetNewMergedAddress(addr, providerAddresses, machineAddresses, getAddr, checkScope)
+	}
+	preferred, preferredOrigin := providerAddresses, OriginProvider
+	other, otherOrigin := machineAddresses, OriginMachine
+	if policy == corenetwork.MachinerWins {
+		preferred, preferredOrigin, other, otherOrigin = other, otherOrigin, preferred, preferredOrigin
+	}
+
+	// For picking the best address, try the preferred addresses first.
 	var newAddr address
-	netAddr := getAddr(providerAddresses)
+	netAddr := getAddr(preferred)
 	if netAddr.Value == "" {
-		netAddr = getAddr(machineAddresses)
-		newAddr = fromNetworkAddress(netAddr, OriginMachine)
+		netAddr = getAddr(other)
+		newAddr = fromNetworkAddress(netAddr, otherOrigin)
 	} else {
-		newAddr = fromNetworkAddress(netAddr, OriginProvider)
+		newAddr = fromNetworkAddress(netAddr, preferredOrigin)
 	}
 	// The order of these checks is important. If the stored address is
 	// empty we *always* want to check for a new address so we do that
 	// first. If the stored address is unavailable we also *must* check for
-	// a new address so we do that next. If the original is a machine
-	// address and a provider address is available we want to switch to
+	// a new address so we do that next. If the original is not a preferred
+	// address and a preferred address is available we want to switch to
 	// that. Finally we check to see if a better match on scope from the
 	// same origin is available.
 	if addr.Value == "" {
@@ -1481,13 +1559,13 @@ func maybeGetNewAddress(
 	if !containsAddress(providerAddresses, addr) && !containsAddress(machineAddresses, addr) {
 		return newAddr, true
 	}
-	if Origin(addr.Origin) != OriginProvider && Origin(newAddr.Origin) == OriginProvider {
+	if Origin(addr.Origin) != preferredOrigin && Origin(newAddr.Origin) == preferredOrigin {
 		return newAddr, true
 	}
 	if !checkScope(addr) {
-		// If addr.Origin is machine and newAddr.Origin is provider we will
-		// have already caught that, and for the inverse we don't want to
-		// replace the address.
+		// If addr is not preferred and newAddr is we will have already
+		// caught that, and for the inverse we don't want to replace the
+		// address.
 		if addr.Origin == newAddr.Origin {
 			return newAddr, checkScope(newAddr)
 		}
@@ -1495,6 +1573,45 @@ func maybeGetNewAddress(
 	return addr, false
 }
 
+// maybeGetNewMergedAddress is maybeGetNewAddress for the
+// MergeWithScopePriority policy. The best match on scope is picked from
+// the provider and machine addresses together, so the current address
+// is only replaced if it is unavailable or a better match exists,
+// whichever origin either address has.
+func maybeGetNewMergedAddress(
+	addr address,
+	providerAddresses,
+	machineAddresses []address,
+	getAddr func([]address) corenetwork.SpaceAddress,
+	checkScope func(address) bool,
+) (address, bool) {
+	// Provider addresses come first, so that they win ties on scope
+	// and shadow machine addresses with the same value.
+	merged := append([]address(nil), providerAddresses...)
+	for _, machineAddr := range machineAddresses {
+		if !containsAddress(providerAddresses, machineAddr) {
+			merged = append(merged, machineAddr)
+		}
+	}
+	netAddr := getAddr(merged)
+	origin := OriginMachine
+	if containsAddress(providerAddresses, address{Value: netAddr.Value}) {
+		origin = OriginProvider
+	}
+	newAddr := fromNetworkAddress(netAddr, origin)
+
+	if addr.Value == "" {
+		return newAddr, newAddr.Value != ""
+	}
+	if !containsAddress(merged, addr) {
+		return newAddr, true
+	}
+	if !checkScope(addr) {
+		return newAddr, checkScope(newAddr)
+	}
+	return addr, false
+}
+
 // PrivateAddress returns a private address for the machine. If no address is
 // available it returns an error that satisfies network.IsNoAddressError().
 func (m *Machine) PrivateAddress() (corenetwork.SpaceAddress, error) {
@@ -1548,7 +1665,9 @@ func (m *Machine) setPreferredAddressOps(addr address, isPublic bool) []txn.Op {
 	return ops
 }
 
-func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, *address) {
+func (m *Machine) setPublicAddressOps(
+	providerAddresses []address, machineAddresses []address, policy corenetwork.AddressConflictPolicy,
+) ([]txn.Op, *address) {
 	publicAddress := m.doc.PreferredPublicAddress
 	logger.Tracef(
 		"machine %v: current public address: %#v \nprovider addresses: %#v \nmachine addresses: %#v",
@@ -1564,7 +1683,7 @@ func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddres
 		return addr
 	}
 
-	newAddr, changed := maybeGetNewAddress(publicAddress, providerAddresses, machineAddresses, getAddr, checkScope)
+	newAddr, changed := maybeGetNewAddress(publicAddress, providerAddresses, machineAddresses, policy, getAddr, checkScope)
 	if !changed {
 		// No change, so no ops.
 		return []txn.Op{}, nil
@@ -1574,7 +1693,9 @@ func (m *Machine) setPublicAddressOps(providerAddresses []address, machineAddres
 	return ops, &newAddr
 }
 
-func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddresses []address) ([]txn.Op, *address) {
+func (m *Machine) setPrivateAddressOps(
+	providerAddresses []address, machineAddresses []address, policy corenetwork.AddressConflictPolicy,
+) ([]txn.Op, *address) {
 	privateAddress := m.doc.PreferredPrivateAddress
 	// Always prefer an exact match if available.
 	checkScope := func(addr address) bool {
@@ -1587,7 +1708,7 @@ func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddre
 		return addr
 	}
 
-	newAddr, changed := maybeGetNewAddress(privateAddress, providerAddresses, machineAddresses, getAddr, checkScope)
+	newAddr, changed := maybeGetNewAddress(privateAddress, providerAddresses, machineAddresses, policy, getAddr, checkScope)
 	if !changed {
 		// No change, so no ops.
 		return []txn.Op{}, nil
@@ -1597,7 +1718,8 @@ func (m *Machine) setPrivateAddressOps(providerAddresses []address, machineAddre
 }
 
 // SetProviderAddresses records any addresses related to the machine, sourced
-// by asking the provider.
+// by asking the provider. The machine's preferred addresses are then chosen
+// according to the model's address-conflict-policy.
 func (m *Machine) SetProviderAddresses(addresses ...corenetwork.SpaceAddress) error {
 	err := m.setAddresses(nil, &addresses)
 	return errors.Annotatef(err, "cannot set addresses of machine %v", m)
@@ -1710,6 +1832,10 @@ func (m *Machine) setAddressesOps(
 	if m.doc.Life == Dead {
 		return nil, nil, nil, nil, nil, ErrDead
 	}
+	policy, err := m.addressConflictPolicy()
+	if err != nil {
+		return nil, nil, nil, nil, nil, errors.Trace(err)
+	}
 
 	fromNetwork := func(in corenetwork.SpaceAddresses, origin Origin) []address {
 		sorted := make(corenetwork.SpaceAddresses, len(in))
@@ -1737,13 +1863,27 @@ func (m *Machine) setAddressesOps(
 		Update: bson.D{{"$set", set}},
 	}}
 
-	setPrivateAddressOps, newPrivate := m.setPrivateAddressOps(providerStateAddresses, machineStateAddresses)
-	setPublicAddressOps, newPublic := m.setPublicAddressOps(providerStateAddresses, machineStateAddresses)
+	setPrivateAddressOps, newPrivate := m.setPrivateAddressOps(providerStateAddresses, machineStateAddresses, policy)
+	setPublicAddressOps, newPublic := m.setPublicAddressOps(providerStateAddresses, machineStateAddresses, policy)
 	ops = append(ops, setPrivateAddressOps...)
 	ops = append(ops, setPublicAddressOps...)
 	return ops, machineStateAddresses, providerStateAddresses, newPrivate, newPublic, nil
 }
 
+// addressConflictPolicy returns the model's policy for reconciling the
+// machine's provider addresses with its machine addresses.
+func (m *Machine) addressConflictPolicy() (corenetwork.AddressConflictPolicy, error) {
+	model, err := m.st.Model()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	cfg, err := model.ModelConfig()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return cfg.AddressConflictPolicy(), nil
+}
+
 // CheckProvisioned returns true if the machine was provisioned with the given nonce.
 func (m *Machine) CheckProvisioned(nonce string) bool {
 	return nonce == m.doc.Nonce && nonce != ""
@@ -1840,14 +1980,21 @@ func (m *Machine) SetStatus(statusInfo status.StatusInfo) error {
 	default:
 		return errors.Errorf("cannot set invalid status %q", statusInfo.Status)
 	}
-	return setStatus(m.st.db(), setStatusParams{
+	updated := timeOrNow(statusInfo.Since, m.st.clock())
+	if err := setStatus(m.st.db(), setStatusParams{
 		badge:     "machine",
 		globalKey: m.globalKey(),
 		status:    statusInfo.Status,
 		message:   statusInfo.Message,
 		rawData:   statusInfo.Data,
-		updated:   timeOrNow(statusInfo.Since, m.st.clock()),
-	})
+		updated:   updated,
+	}); err != nil {
+		return err
+	}
+	if statusInfo.Status == status.Started {
+		m.recordProvisioningPhase(status.PhaseAgentConnected, "", *updated)
+	}
+	return nil
 }
 
 // StatusHistory returns a slice of at most filter.Size StatusInfo items
@@ -805,6 +805,52 @@ func (s *MachineSuite) TestMachineSetProvisionedUpdatesCharacteristics(c *gc.C)
 	c.Assert(*md, gc.DeepEquals, *expected)
 }
 
+func (s *MachineSuite) TestMachineUpdateHardwareCharacteristics(c *gc.C) {
+	arch := "amd64"
+	mem := uint64(4096)
+	cores := uint64(2)
+	zone := "a_zone"
+	err := s.machine.SetProvisioned("umbrella/0", "", "fake_nonce", &instance.HardwareCharacteristics{
+		Arch:             &arch,
+		Mem:              &mem,
+		CpuCores:         &cores,
+		AvailabilityZone: &zone,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the fields that are set are updated.
+	newMem := uint64(8192)
+	rootDisk := uint64(20480)
+	err = s.machine.UpdateHardwareCharacteristics(instance.HardwareCharacteristics{
+		Mem:      &newMem,
+		RootDisk: &rootDisk,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	md, err := s.machine.HardwareCharacteristics()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(*md, jc.DeepEquals, instance.HardwareCharacteristics{
+		Arch:             &arch,
+		Mem:              &newMem,
+		RootDisk:         &rootDisk,
+		CpuCores:         &cores,
+		AvailabilityZone: &zone,
+	})
+
+	// Updating with the same values is a no-op.
+	err = s.machine.UpdateHardwareCharacteristics(instance.HardwareCharacteristics{
+		Mem: &newMem,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MachineSuite) TestMachineUpdateHardwareCharacteristicsNotProvisioned(c *gc.C) {
+	mem := uint64(4096)
+	err := s.machine.UpdateHardwareCharacteristics(instance.HardwareCharacteristics{
+		Mem: &mem,
+	})
+	c.Assert(err, jc.Satisfies, errors.IsNotProvisioned)
+}
+
 func (s *MachineSuite) TestMachineAvailabilityZone(c *gc.C) {
 	zone := "a_zone"
 	hwc := &instance.HardwareCharacteristics{
@@ -2236,6 +2282,76 @@ func (s *MachineSuite) TestAddressesPrefersProviderBoth(c *gc.C) {
 	c.Assert(addr.Value, gc.Equals, "10.0.0.2")
 }
 
+func (s *MachineSuite) TestAddressesMachinerWins(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{"address-conflict-policy": "machiner-wins"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.SetProviderAddresses(corenetwork.NewSpaceAddress("8.8.4.4"), corenetwork.NewSpaceAddress("10.0.0.2"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	addr, err := machine.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "8.8.4.4")
+	addr, err = machine.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "10.0.0.2")
+
+	// The machine's own addresses replace the provider's.
+	err = machine.SetMachineAddresses(corenetwork.NewSpaceAddress("8.8.8.8"), corenetwork.NewSpaceAddress("10.0.0.1"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	addr, err = machine.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "8.8.8.8")
+	addr, err = machine.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "10.0.0.1")
+
+	// And are kept when the provider reports new ones.
+	err = machine.SetProviderAddresses(corenetwork.NewSpaceAddress("8.8.4.4"), corenetwork.NewSpaceAddress("10.0.0.3"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	addr, err = machine.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "8.8.8.8")
+	addr, err = machine.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "10.0.0.1")
+}
+
+func (s *MachineSuite) TestAddressesMergeWithScopePriority(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{"address-conflict-policy": "merge-with-scope-priority"}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = machine.SetMachineAddresses(corenetwork.NewSpaceAddress("8.8.8.8"), corenetwork.NewSpaceAddress("10.0.0.2"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The provider only knows of a cloud-local address, so the
+	// machine's public address is kept. With provider-wins, the
+	// provider's address would become the public address too.
+	err = machine.SetProviderAddresses(corenetwork.NewSpaceAddress("10.0.0.1"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	addr, err := machine.PublicAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "8.8.8.8")
+	addr, err = machine.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "10.0.0.2")
+
+	// Once the machine's address goes away, the provider's is used.
+	err = machine.SetMachineAddresses(corenetwork.NewSpaceAddress("8.8.8.8"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	addr, err = machine.PrivateAddress()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(addr.Value, gc.Equals, "10.0.0.1")
+}
+
 func (s *MachineSuite) addMachineWithSupportedContainer(c *gc.C, container instance.ContainerType) *state.Machine {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -2467,6 +2583,15 @@ func (s *MachineSuite) TestMachineValidActions(c *gc.C) {
 			givenPayload:    map[string]interface{}{"command": "allyourbasearebelongtous", "timeout": 5.0},
 			expectedPayload: map[string]interface{}{"command": "allyourbasearebelongtous", "timeout": 5.0},
 		},
+		{
+			actionName: "juju-introspect",
+			errString:  `validation failed: (root) : "agent" property is missing and required, given {}; (root) : "section" property is missing and required, given {}`,
+		},
+		{
+			actionName:      "juju-introspect",
+			givenPayload:    map[string]interface{}{"agent": "unit-mysql-0", "section": "depengine"},
+			expectedPayload: map[string]interface{}{"agent": "unit-mysql-0", "section": "depengine"},
+		},
 		{
 			actionName: "baiku",
 			errString:  `cannot add action "baiku" to a machine; only predefined actions allowed`,
@@ -1754,38 +1754,65 @@ func (e *exporter) statusArgs(globalKey string) (description.StatusArgs, error)
 	if !ok {
 		return result, errors.Errorf("expected int64 for updated, got %T", statusDoc["updated"])
 	}
-	neverset, ok := statusDoc["neverset"].(bool)
-	if !ok {
-		return result, errors.Errorf("expected neverset for updated, got %T", statusDoc["neverset"])
+	// explicitly-set is omitted until the status is set.
+	explicitlySet, ok := statusDoc["explicitly-set"].(bool)
+	if _, found := statusDoc["explicitly-set"]; found && !ok {
+		return result, errors.Errorf("expected bool for explicitly-set, got %T", statusDoc["explicitly-set"])
 	}
 
 	result.Value = status
 	result.Message = info
 	result.Data = dataMap
 	result.Updated = time.Unix(0, updated)
-	result.NeverSet = neverset
+	result.NeverSet = !explicitlySet
 	return result, nil
 }
 
+// statusHistoryArgs returns the most recent status history of the
+// entity with the given global key. The description has no notion of a
+// status being set repeatedly, so records which stand for the same
+// status set several times in a row are expanded into one entry for
+// each time it was set.
 func (e *exporter) statusHistoryArgs(globalKey string) []description.StatusArgs {
 	history := e.statusHistory[globalKey]
 	e.logger.Tracef("found %d status history docs for %s", len(history), globalKey)
-	if len(history) > maxStatusHistoryEntries {
-		history = history[:maxStatusHistoryEntries]
-	}
-	result := make([]description.StatusArgs, len(history))
-	for i, doc := range history {
-		result[i] = description.StatusArgs{
-			Value:   string(doc.Status),
-			Message: doc.StatusInfo,
-			Data:    doc.StatusData,
-			Updated: time.Unix(0, doc.Updated),
+	var result []description.StatusArgs
+	for _, doc := range history {
+		for _, updated := range repeatedStatusTimes(doc) {
+			if len(result) == maxStatusHistoryEntries {
+				break
+			}
+			result = append(result, description.StatusArgs{
+				Value:   string(doc.Status),
+				Message: doc.StatusInfo,
+				Data:    doc.StatusData,
+				Updated: time.Unix(0, updated),
+			})
 		}
 	}
 	delete(e.statusHistory, globalKey)
 	return result
 }
 
+// repeatedStatusTimes returns the times at which the status recorded
+// by the history document was set, newest first. Only the first and
+// last times are recorded for a repeated status, so the times of the
+// repeats in between are spread evenly between them.
+func repeatedStatusTimes(doc historicalStatusDoc) []int64 {
+	if doc.Repeats == 0 {
+		return []int64{doc.Updated}
+	}
+	first := doc.FirstUpdated
+	if first == 0 {
+		first = doc.Updated
+	}
+	times := make([]int64, doc.Repeats+1)
+	for i := range times {
+		times[i] = doc.Updated - (doc.Updated-first)*int64(i)/int64(doc.Repeats)
+	}
+	return times
+}
+
 func (e *exporter) constraintsArgs(globalKey string) (description.ConstraintsArgs, error) {
 	doc, found := e.constraints[globalKey]
 	if !found {
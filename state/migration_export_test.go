@@ -2157,6 +2157,30 @@ func (s *MigrationExportSuite) TestTooManyStatusHistories(c *gc.C) {
 	s.checkStatusHistory(c, history, status.Started)
 }
 
+func (s *MigrationExportSuite) TestRepeatedStatusHistory(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	first := time.Now().Truncate(time.Second)
+	for i := 0; i < 3; i++ {
+		since := first.Add(time.Duration(i) * time.Minute)
+		err := machine.SetStatus(status.StatusInfo{
+			Status: status.Started,
+			Since:  &since,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(model.Machines(), gc.HasLen, 1)
+	history := model.Machines()[0].StatusHistory()
+	c.Assert(len(history) > 3, jc.IsTrue)
+	for i, st := range history[:3] {
+		c.Check(st.Value(), gc.Equals, "started")
+		c.Check(st.Updated().Equal(first.Add(time.Duration(2-i)*time.Minute)), jc.IsTrue)
+	}
+}
+
 func (s *MigrationExportSuite) TestRelationWithNoStatus(c *gc.C) {
 	// Importing from a model from before relations had status will
 	// mean that there's no status to export - don't fail to export if
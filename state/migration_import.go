@@ -797,11 +797,11 @@ func (i *importer) loadUnits() error {
 // makeStatusDoc assumes status is non-nil.
 func (i *importer) makeStatusDoc(statusVal description.Status) statusDoc {
 	return statusDoc{
-		Status:     status.Status(statusVal.Value()),
-		StatusInfo: statusVal.Message(),
-		StatusData: statusVal.Data(),
-		Updated:    statusVal.Updated().UnixNano(),
-		NeverSet:   statusVal.NeverSet(),
+		Status:        status.Status(statusVal.Value()),
+		StatusInfo:    statusVal.Message(),
+		StatusData:    statusVal.Data(),
+		Updated:       statusVal.Updated().UnixNano(),
+		ExplicitlySet: !statusVal.NeverSet(),
 	}
 }
 
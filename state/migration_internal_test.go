@@ -103,6 +103,9 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		guimetadataC,
 		// This is controller global, not migrated.
 		guisettingsC,
+		// Abandoned resource records are controller global, and
+		// describe cloud resources of removed models.
+		abandonedResourcesC,
 		// Users aren't migrated.
 		usersC,
 		userLastLoginC,
@@ -115,6 +118,17 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		metricsC,
 		// Backup and restore information is not migrated.
 		restoreInfoC,
+		// Feature flag changes are controller global.
+		featureFlagChangesC,
+		// Schema migrations are controller global, and record the
+		// state of the controller's database rather than a model's.
+		schemaMigrationsC,
+		// Model templates are controller global, and only used when
+		// models are created.
+		modelTemplatesC,
+		// Cloud pricing is controller global, and is set up for each
+		// controller's clouds.
+		cloudPricingC,
 		// reference counts are implementation details that should be
 		// reconstructed on the other side.
 		refcountsC,
@@ -199,6 +213,46 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 
 		// Resources are transferred separately
 		"storedResources",
+
+		// The operations log records the changes made to the model
+		// while it was hosted by this controller.
+		operationsC,
+
+		// The relation settings history is only kept to help debug
+		// the charms of the model while it is hosted by this
+		// controller.
+		relationSettingsHistoryC,
+
+		// Charm deployments in progress are only limited by the
+		// controller hosting the model when they start.
+		charmDeploymentsC,
+
+		// Agent config overrides are made by, and addressed to, the
+		// controller hosting the model; in particular they may name
+		// its API addresses.
+		agentConfigOverridesC,
+
+		// Instance poll requests are addressed to the instance poller
+		// of the controller hosting the model, and are soon cleared.
+		instancePollRequestsC,
+
+		// Archived models cannot be migrated; they must be thawed
+		// first.
+		modelArchivesC,
+
+		// The model bundle is part of how the hosting controller
+		// manages the model, and is not carried with it.
+		modelBundlesC,
+		bundlePlanApprovalsC,
+
+		// Model events are streamed from the controller hosting the
+		// model, whose cursors would mean nothing to another one.
+		modelEventsC,
+
+		// Registry credentials are not yet part of the model
+		// description, so they have to be set again on the target
+		// controller.
+		registryCredentialsC,
 	)
 
 	// THIS SET WILL BE REMOVED WHEN MIGRATIONS ARE COMPLETE
@@ -348,6 +402,11 @@ func (s *MigrationSuite) TestInstanceDataFields(c *gc.C) {
 		// KeepInstance is only set when a machine is
 		// dying/dead (to be removed).
 		"KeepInstance",
+		// GPUs and GPUType are not supported by the description
+		// package; the migration precheck refuses models with
+		// machines which have GPUs.
+		"GPUs",
+		"GPUType",
 	)
 	migrated := set.NewStrings(
 		// DocID is the model + machine id
@@ -388,6 +447,16 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// MaxUnits is not yet supported by the description package;
+		// models with applications which have it set fail the
+		// migration prechecks.
+		"MaxUnits",
+		// LeadershipPin is not migrated, as the lease it records
+		// the pinning of isn't.
+		"LeadershipPin",
+		// CanaryUpgrade only affects an upgrade in progress, which
+		// isn't resumed after the migration.
+		"CanaryUpgrade",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -421,6 +490,9 @@ func (s *MigrationSuite) TestUnitDocFields(c *gc.C) {
 		"Series",
 		"CharmURL",
 		"TxnRevno",
+		// Maintenance is not migrated as units in maintenance fail
+		// the migration prechecks.
+		"Maintenance",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -481,6 +553,9 @@ func (s *MigrationSuite) TestRelationDocFields(c *gc.C) {
 		// UnitCount isn't explicitly exported, but defined by the stored
 		// unit settings data for the relation endpoint.
 		"UnitCount",
+		// DrainDeadline isn't exported, as it is only set once the
+		// relation is dying.
+		"DrainDeadline",
 	)
 	s.AssertExportedFields(c, relationDoc{}, fields)
 	// We also need to check the Endpoint and nested charm.Relation field.
@@ -519,6 +594,9 @@ func (s *MigrationSuite) TestAnnotatorDocFields(c *gc.C) {
 		"GlobalKey",
 		"Tag",
 		"Annotations",
+		// Pairs is derived from the annotations when they are
+		// imported.
+		"Pairs",
 	)
 	s.AssertExportedFields(c, annotatorDoc{}, fields)
 }
@@ -560,6 +638,11 @@ func (s *MigrationSuite) TestConstraintsDocFields(c *gc.C) {
 		// ModelUUID shouldn't be exported, and is inherited
 		// from the model definition.
 		"ModelUUID",
+		// GPUs and GPUType are not supported by the description
+		// package; the migration precheck refuses models which
+		// use them.
+		"GPUs",
+		"GPUType",
 		"Arch",
 		"CpuCores",
 		"CpuPower",
@@ -586,6 +669,10 @@ func (s *MigrationSuite) TestHistoricalStatusDocFields(c *gc.C) {
 		"StatusInfo",
 		"StatusData",
 		"Updated",
+		// Repeated statuses are exported as one entry for each
+		// time they were set.
+		"FirstUpdated",
+		"Repeats",
 	)
 	s.AssertExportedFields(c, historicalStatusDoc{}, fields)
 }
@@ -4,18 +4,21 @@
 package state
 
 import (
+	"github.com/juju/collections/set"
 	"github.com/juju/errors"
 	jujutxn "github.com/juju/txn"
 	"gopkg.in/mgo.v2"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/controller"
 )
 
 // minUnitsDoc keeps track of relevant changes on the application's MinUnits field
 // and on the number of alive units for the application.
-// A new document is created when MinUnits is set to a non zero value.
-// A document is deleted when either the associated application is destroyed
-// or MinUnits is restored to zero. The Revno is increased when either MinUnits
+// A new document is created when MinUnits or MaxUnits is set to a non zero
+// value. A document is deleted when either the associated application is
+// destroyed or both MinUnits and MaxUnits are restored to zero. The Revno is increased when either MinUnits
 // for a application is increased or a unit is destroyed.
 // TODO(frankban): the MinUnitsWatcher reacts to changes by sending events,
 // each one describing one or more application. A worker reacts to those events
@@ -28,6 +31,11 @@ type minUnitsDoc struct {
 	ApplicationName string
 	ModelUUID       string `bson:"model-uuid"`
 	Revno           int
+
+	// LostUnits holds the names of the application's units which were
+	// removed along with their force-destroyed machines, and which have
+	// yet to be replaced according to the application's scaling policy.
+	LostUnits []string `bson:"lostunits,omitempty"`
 }
 
 // SetMinUnits changes the number of minimum units required by the application.
@@ -60,6 +68,9 @@ func (a *Application) SetMinUnits(minUnits int) (err error) {
 		if minUnits == app.doc.MinUnits {
 			return nil, jujutxn.ErrNoOperations
 		}
+		if app.doc.MaxUnits > 0 && minUnits > app.doc.MaxUnits {
+			return nil, errors.Errorf("cannot set minimum units above the maximum of %d", app.doc.MaxUnits)
+		}
 		return setMinUnitsOps(app, minUnits), nil
 	}
 	return a.st.db().Run(buildTxn)
@@ -68,34 +79,10 @@ func (a *Application) SetMinUnits(minUnits int) (err error) {
 // setMinUnitsOps returns the operations required to set MinUnits on the
 // application and to create/update/remove the minUnits document in MongoDB.
 func setMinUnitsOps(app *Application, minUnits int) []txn.Op {
-	state := app.st
-	applicationname := app.Name()
-	ops := []txn.Op{{
-		C:      applicationsC,
-		Id:     state.docID(applicationname),
-		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"minunits", minUnits}}}},
-	}}
-	if app.doc.MinUnits == 0 {
-		return append(ops, txn.Op{
-			C:      minUnitsC,
-			Id:     state.docID(applicationname),
-			Assert: txn.DocMissing,
-			Insert: &minUnitsDoc{
-				ApplicationName: applicationname,
-				ModelUUID:       app.st.ModelUUID(),
-			},
-		})
-	}
-	if minUnits == 0 {
-		return append(ops, minUnitsRemoveOp(state, applicationname))
-	}
-	if minUnits > app.doc.MinUnits {
-		op := minUnitsTriggerOp(state, applicationname)
-		op.Assert = txn.DocExists
-		return append(ops, op)
-	}
-	return ops
+	return setScalingPolicyOps(app, ScalingPolicy{
+		MinUnits: minUnits,
+		MaxUnits: app.doc.MaxUnits,
+	})
 }
 
 // doesMinUnitsExits checks if the minUnits doc exists in the database.
@@ -143,7 +130,12 @@ func (a *Application) MinUnits() int {
 }
 
 // EnsureMinUnits adds new units if the application's MinUnits value is greater
-// than the number of alive units.
+// than the number of alive units. If the application's MaxUnits value is set,
+// it also replaces units which were lost along with their force-destroyed
+// machines, so long as the application would not have more than MaxUnits
+// alive units. Units are not added beyond the model's units and machines
+// quotas; the shortfall is logged rather than returned, as it can only
+// be made up once the quotas allow it.
 func (a *Application) EnsureMinUnits() (err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot ensure minimum units for application %q", a)
 	app := &Application{st: a.st, doc: a.doc}
@@ -152,24 +144,45 @@ func (a *Application) EnsureMinUnits() (err error) {
 		if app.doc.Life != Alive {
 			return errors.New("application is not alive")
 		}
-		// Exit without errors if the MinUnits for the application is not set.
-		if app.doc.MinUnits == 0 {
+		// Exit without errors if the scaling policy for the application is
+		// not set.
+		if app.doc.MinUnits == 0 && app.doc.MaxUnits == 0 {
 			return nil
 		}
-		// Retrieve the number of alive units for the application.
-		aliveUnits, err := aliveUnitsCount(app)
+		// Retrieve the alive units for the application, and those units
+		// which were lost and have yet to be replaced.
+		aliveUnits, err := aliveUnitNames(app)
+		if err != nil {
+			return err
+		}
+		lostUnits, err := lostUnitNames(app, aliveUnits)
 		if err != nil {
 			return err
 		}
 		// Calculate the number of required units to be added.
-		missing := app.doc.MinUnits - aliveUnits
+		missing := app.ScalingPolicy().wantedUnits(len(aliveUnits), len(lostUnits)) - len(aliveUnits)
 		if missing <= 0 {
+			// Any remaining lost units will not be replaced.
+			if len(lostUnits) == 0 {
+				return nil
+			}
+			return a.st.db().RunTransaction([]txn.Op{lostUnitsRemoveOp(a.st, app.doc.Name, lostUnits...)})
+		}
+		// Each unit added is assigned to a new machine.
+		if err := checkMinUnitsQuotas(app.st); IsQuotaExceededError(err) {
+			logger.Warningf("not adding %d units to application %q: %v", missing, app.doc.Name, err)
 			return nil
+		} else if err != nil {
+			return err
 		}
 		name, ops, err := ensureMinUnitsOps(app)
 		if err != nil {
 			return err
 		}
+		if len(lostUnits) > 0 {
+			// The new unit replaces one of the lost units.
+			ops = append(ops, lostUnitsRemoveOp(a.st, app.doc.Name, lostUnits[0]))
+		}
 		// Add missing unit.
 		switch err := a.st.db().RunTransaction(ops); err {
 		case nil:
@@ -197,13 +210,32 @@ func (a *Application) EnsureMinUnits() (err error) {
 	}
 }
 
-// aliveUnitsCount returns the number a alive units for the application.
-func aliveUnitsCount(app *Application) (int, error) {
+// aliveUnitNames returns the names of the alive units for the application.
+func aliveUnitNames(app *Application) (set.Strings, error) {
 	units, closer := app.st.db().GetCollection(unitsC)
 	defer closer()
 
+	var docs []struct {
+		Name string `bson:"name"`
+	}
 	query := bson.D{{"application", app.doc.Name}, {"life", Alive}}
-	return units.Find(query).Count()
+	if err := units.Find(query).Select(bson.D{{"name", 1}}).All(&docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	names := set.NewStrings()
+	for _, doc := range docs {
+		names.Add(doc.Name)
+	}
+	return names, nil
+}
+
+// checkMinUnitsQuotas checks that a unit can be added to the model on a
+// machine of its own.
+func checkMinUnitsQuotas(st *State) error {
+	if err := st.CheckModelQuota(controller.MaxModelUnits, 1); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(st.CheckModelQuota(controller.MaxModelMachines, 1))
 }
 
 // ensureMinUnitsOps returns the operations required to add a unit for the
@@ -435,7 +435,7 @@ func (ctlr *Controller) NewModel(args ModelArgs) (_ *Model, _ *State, err error)
 		return nil, nil, errors.Trace(err)
 	}
 
-	err = newSt.start(st.controllerTag, ctlr.pool.hub)
+	err = newSt.start(st.controllerTag, ctlr.pool.mux)
 	if err != nil {
 		return nil, nil, errors.Annotate(err, "could not start state for new model")
 	}
@@ -640,7 +640,7 @@ func (m *Model) Owner() names.UserTag {
 }
 
 func modelStatusInvalidCredential() status.StatusInfo {
-	return status.StatusInfo{Status: status.Suspended, Message: "suspended since cloud credential is not valid"}
+	return status.StatusInfo{Status: status.Suspended, Message: "suspended: invalid credential"}
 }
 
 // Status returns the status of the model.
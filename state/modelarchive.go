@@ -0,0 +1,443 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"time"
+
+	"github.com/juju/description"
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/state/storage"
+)
+
+// modelArchiveKey is the ID of the single archive document a model
+// may have.
+const modelArchiveKey = "archive"
+
+// ModelArchive describes the export of a model which was taken when
+// the model was archived.
+type ModelArchive struct {
+	// Path is the path of the export in the model's blob storage.
+	Path string
+
+	// Size is the size of the export, in bytes.
+	Size int64
+
+	// SHA256 is the hex-encoded SHA256 hash of the export.
+	SHA256 string
+
+	// CreatedBy is the name of the user who archived the model.
+	CreatedBy string
+
+	// Created is when the model was archived.
+	Created time.Time
+}
+
+// modelArchiveDoc records that a model has been archived.
+type modelArchiveDoc struct {
+	DocID     string    `bson:"_id"`
+	ModelUUID string    `bson:"model-uuid"`
+	Path      string    `bson:"path"`
+	Size      int64     `bson:"size"`
+	SHA256    string    `bson:"sha256"`
+	CreatedBy string    `bson:"created-by"`
+	Created   time.Time `bson:"created"`
+
+	// Restored holds the number of units restored to each
+	// application whose workloads have been restored by a thaw
+	// which has not yet completed.
+	Restored map[string]int `bson:"restored,omitempty"`
+}
+
+// ModelArchive returns the details of the model's archive, or a
+// NotFound error if the model is not archived.
+func (st *State) ModelArchive() (ModelArchive, error) {
+	archives, closer := st.db().GetCollection(modelArchivesC)
+	defer closer()
+
+	var doc modelArchiveDoc
+	if err := archives.FindId(modelArchiveKey).One(&doc); err == mgo.ErrNotFound {
+		return ModelArchive{}, errors.NotFoundf("model archive")
+	} else if err != nil {
+		return ModelArchive{}, errors.Annotate(err, "cannot read model archive")
+	}
+	return ModelArchive{
+		Path:      doc.Path,
+		Size:      doc.Size,
+		SHA256:    doc.SHA256,
+		CreatedBy: doc.CreatedBy,
+		Created:   doc.Created.UTC(),
+	}, nil
+}
+
+// IsModelArchived reports whether the model has been archived and not
+// yet thawed.
+func (st *State) IsModelArchived() (bool, error) {
+	_, err := st.ModelArchive()
+	if errors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
+
+// ArchiveModel exports the model to the model's blob storage and then
+// stops its workloads, so that the resources it uses in the cloud are
+// released until the model is thawed. The units of IAAS applications
+// are destroyed along with the machines which hosted them, with the
+// machines being forcibly removed if the units have not gone after
+// maxWait. CAAS applications are scaled to zero. Applications, their
+// config, relations and offers are left in place. Models with
+// manually provisioned machines, or with storage attached to units,
+// cannot be archived, since those could not be restored.
+func (st *State) ArchiveModel(who string, maxWait time.Duration) (ModelArchive, error) {
+	if st.IsController() {
+		return ModelArchive{}, errors.New("cannot archive the controller model")
+	}
+	model, err := st.Model()
+	if err != nil {
+		return ModelArchive{}, errors.Trace(err)
+	}
+	if model.Life() != Alive {
+		return ModelArchive{}, errors.Errorf("model is %s", model.Life())
+	}
+	if model.MigrationMode() != MigrationModeNone {
+		return ModelArchive{}, errors.New("model is being migrated")
+	}
+	if archived, err := st.IsModelArchived(); err != nil {
+		return ModelArchive{}, errors.Trace(err)
+	} else if archived {
+		return ModelArchive{}, errors.AlreadyExistsf("model archive")
+	}
+	if err := st.checkArchivable(); err != nil {
+		return ModelArchive{}, errors.Trace(err)
+	}
+
+	// The agent binaries and instances are not needed to restore the
+	// workloads, and may be missing for machines not yet provisioned.
+	exported, err := st.ExportPartial(ExportConfig{
+		SkipUnitAgentBinaries:    true,
+		SkipMachineAgentBinaries: true,
+		SkipInstanceData:         true,
+	})
+	if err != nil {
+		return ModelArchive{}, errors.Annotate(err, "exporting model")
+	}
+	data, err := description.Serialize(exported)
+	if err != nil {
+		return ModelArchive{}, errors.Annotate(err, "serializing model")
+	}
+	archive := ModelArchive{
+		Path:      fmt.Sprintf("modelarchives/%s/model.yaml", st.ModelUUID()),
+		Size:      int64(len(data)),
+		SHA256:    fmt.Sprintf("%x", sha256.Sum256(data)),
+		CreatedBy: who,
+		Created:   st.nowToTheSecond(),
+	}
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	if err := stor.Put(archive.Path, bytes.NewReader(data), archive.Size); err != nil {
+		return ModelArchive{}, errors.Annotate(err, "storing model export")
+	}
+
+	doc := modelArchiveDoc{
+		DocID:     st.docID(modelArchiveKey),
+		ModelUUID: st.ModelUUID(),
+		Path:      archive.Path,
+		Size:      archive.Size,
+		SHA256:    archive.SHA256,
+		CreatedBy: archive.CreatedBy,
+		Created:   archive.Created,
+	}
+	ops := []txn.Op{
+		model.assertActiveOp(),
+		{
+			C:      modelArchivesC,
+			Id:     doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		},
+	}
+	if err := st.db().RunTransaction(ops); err != nil {
+		if removeErr := stor.Remove(archive.Path); removeErr != nil {
+			logger.Warningf("cannot remove model export %q: %v", archive.Path, removeErr)
+		}
+		if err == txn.ErrAborted {
+			return ModelArchive{}, errors.New("model has changed while being archived")
+		}
+		return ModelArchive{}, errors.Trace(err)
+	}
+
+	if model.Type() == ModelTypeCAAS {
+		err = st.scaleApplicationsToZero()
+	} else {
+		err = st.releaseMachines(maxWait)
+	}
+	if err != nil {
+		return ModelArchive{}, errors.Annotate(err, "stopping workloads")
+	}
+	return archive, nil
+}
+
+// checkArchivable returns an error if the model has workloads which
+// would be lost by archiving it: those on manually provisioned
+// machines, which cannot be provisioned again when the model is
+// thawed, and those with storage attached, which would be detached or
+// destroyed along with their units.
+func (st *State) checkArchivable() error {
+	machines, err := st.AllMachines()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, m := range machines {
+		manual, err := m.IsManual()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if manual {
+			return errors.Errorf("cannot archive model with manually provisioned machine %q", m.Id())
+		}
+	}
+	sb, err := NewStorageBackend(st)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	applications, err := st.AllApplications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, app := range applications {
+		units, err := app.AllUnits()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, unit := range units {
+			attachments, err := sb.UnitStorageAttachments(unit.UnitTag())
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if len(attachments) > 0 {
+				return errors.Errorf("cannot archive model with storage attached to unit %q", unit.Name())
+			}
+		}
+	}
+	return nil
+}
+
+func (st *State) scaleApplicationsToZero() error {
+	applications, err := st.AllApplications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, app := range applications {
+		if err := app.SetScale(0, 0, true); err != nil {
+			return errors.Annotatef(err, "scaling application %q to zero", app.Name())
+		}
+	}
+	return nil
+}
+
+func (st *State) releaseMachines(maxWait time.Duration) error {
+	applications, err := st.AllApplications()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, app := range applications {
+		if !app.IsPrincipal() {
+			// Subordinate units go with their principals.
+			continue
+		}
+		units, err := app.AllUnits()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		for _, unit := range units {
+			if err := unit.Destroy(); err != nil {
+				return errors.Annotatef(err, "destroying unit %q", unit.Name())
+			}
+		}
+	}
+	machines, err := st.AllMachines()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, m := range machines {
+		if m.IsContainer() {
+			// Containers are removed with their host machines.
+			continue
+		}
+		if err := m.ForceDestroy(maxWait); err != nil {
+			return errors.Annotatef(err, "destroying machine %q", m.Id())
+		}
+	}
+	return nil
+}
+
+// ThawModel restores the workloads of an archived model from the
+// export taken when it was archived, and removes the archive once they
+// have all been restored. New units are added to IAAS applications to
+// replace those which were removed, with new machines provisioned for
+// them; CAAS applications are scaled back up. It returns the number of
+// units restored for each application. Applications removed since the
+// model was archived are not restored.
+//
+// Progress is recorded against the archive as each application is
+// restored, so if thawing fails part way through it may be retried
+// without restoring any application twice.
+func (st *State) ThawModel() (map[string]int, error) {
+	archives, closer := st.db().GetCollection(modelArchivesC)
+	defer closer()
+
+	var doc modelArchiveDoc
+	if err := archives.FindId(modelArchiveKey).One(&doc); err == mgo.ErrNotFound {
+		return nil, errors.NewNotFound(nil, "model is not archived")
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot read model archive")
+	}
+	model, err := st.Model()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if model.Life() != Alive {
+		return nil, errors.Errorf("model is %s", model.Life())
+	}
+
+	stor := storage.NewStorage(st.ModelUUID(), st.MongoSession())
+	exported, err := readModelArchive(stor, ModelArchive{
+		Path:   doc.Path,
+		SHA256: doc.SHA256,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	restored := make(map[string]int)
+	for name, count := range doc.Restored {
+		restored[name] = count
+	}
+	for _, exportedApp := range exported.Applications() {
+		if exportedApp.Subordinate() {
+			continue
+		}
+		if _, ok := restored[exportedApp.Name()]; ok {
+			// Restored by an earlier attempt.
+			continue
+		}
+		app, err := st.Application(exportedApp.Name())
+		if errors.IsNotFound(err) {
+			logger.Infof("not restoring application %q which has been removed", exportedApp.Name())
+			continue
+		} else if err != nil {
+			return restored, errors.Trace(err)
+		}
+		count, err := st.restoreApplication(model.Type(), app, exportedApp)
+		if err != nil {
+			return restored, errors.Trace(err)
+		}
+		ops := []txn.Op{{
+			C:      modelArchivesC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{{"restored." + app.Name(), count}}}},
+		}}
+		if err := st.db().RunTransaction(ops); err == txn.ErrAborted {
+			return restored, errors.New("model has been thawed concurrently")
+		} else if err != nil {
+			return restored, errors.Annotatef(err, "recording application %q restored", app.Name())
+		}
+		restored[app.Name()] = count
+	}
+
+	// Only remove the archive once every application has been
+	// restored, so that it remains available to retry a failed thaw.
+	ops := []txn.Op{{
+		C:      modelArchivesC,
+		Id:     doc.DocID,
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	if err := st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return restored, errors.New("model has already been thawed")
+	} else if err != nil {
+		return restored, errors.Trace(err)
+	}
+	if err := stor.Remove(doc.Path); err != nil {
+		logger.Warningf("cannot remove model export %q: %v", doc.Path, err)
+	}
+	return restored, nil
+}
+
+// restoreApplication restores the units of the application held in
+// the model export, returning how many it has. Units of IAAS
+// applications which are already alive, having been added by an
+// earlier attempt that failed before recording its progress, are
+// counted rather than added again, and assigned to machines if they
+// were not already.
+func (st *State) restoreApplication(modelType ModelType, app *Application, exportedApp description.Application) (int, error) {
+	if modelType == ModelTypeCAAS {
+		scale := exportedApp.DesiredScale()
+		if err := app.SetScale(scale, 0, true); err != nil {
+			return 0, errors.Annotatef(err, "scaling application %q", app.Name())
+		}
+		return scale, nil
+	}
+	units, err := app.AllUnits()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	var alive int
+	for _, unit := range units {
+		if unit.Life() != Alive {
+			continue
+		}
+		alive++
+		if _, err := unit.AssignedMachineId(); errors.IsNotAssigned(err) {
+			if err := st.AssignUnit(unit, AssignCleanEmpty); err != nil {
+				return 0, errors.Annotatef(err, "assigning unit %q", unit.Name())
+			}
+		} else if err != nil {
+			return 0, errors.Trace(err)
+		}
+	}
+	for count := alive; count < len(exportedApp.Units()); count++ {
+		unit, err := app.AddUnit(AddUnitParams{})
+		if err != nil {
+			return 0, errors.Annotatef(err, "adding unit to application %q", app.Name())
+		}
+		if err := st.AssignUnit(unit, AssignCleanEmpty); err != nil {
+			return 0, errors.Annotatef(err, "assigning unit %q", unit.Name())
+		}
+	}
+	return len(exportedApp.Units()), nil
+}
+
+// readModelArchive reads and deserializes the model export held in the
+// archive, checking that it has not been changed.
+func readModelArchive(stor storage.Storage, archive ModelArchive) (description.Model, error) {
+	r, _, err := stor.Get(archive.Path)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading model export")
+	}
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.Annotate(err, "reading model export")
+	}
+	if hash := fmt.Sprintf("%x", sha256.Sum256(data)); hash != archive.SHA256 {
+		return nil, errors.Errorf("model export %q has hash %s, expected %s", archive.Path, hash, archive.SHA256)
+	}
+	exported, err := description.Deserialize(data)
+	if err != nil {
+		return nil, errors.Annotate(err, "deserializing model export")
+	}
+	return exported, nil
+}
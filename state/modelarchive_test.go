@@ -0,0 +1,231 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statestorage "github.com/juju/juju/state/storage"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ModelArchiveSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ModelArchiveSuite{})
+
+func (s *ModelArchiveSuite) TestArchiveControllerModel(c *gc.C) {
+	_, err := s.State.ArchiveModel("admin", time.Minute)
+	c.Assert(err, gc.ErrorMatches, "cannot archive the controller model")
+}
+
+func (s *ModelArchiveSuite) TestModelNotArchived(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	_, err := st.ModelArchive()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	archived, err := st.IsModelArchived()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(archived, jc.IsFalse)
+
+	_, err = st.ThawModel()
+	c.Assert(err, gc.ErrorMatches, "model is not archived")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ModelArchiveSuite) TestArchiveAndThawIAASModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	f := factory.NewFactory(st, s.StatePool)
+	app := f.MakeApplication(c, nil)
+	unit0 := f.MakeUnit(c, &factory.UnitParams{Application: app})
+	unit1 := f.MakeUnit(c, &factory.UnitParams{Application: app})
+
+	archive, err := st.ArchiveModel("bob", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(archive.Path, gc.Equals, "modelarchives/"+st.ModelUUID()+"/model.yaml")
+	c.Check(archive.Size > 0, jc.IsTrue)
+	c.Check(archive.CreatedBy, gc.Equals, "bob")
+
+	stored, err := st.ModelArchive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stored, jc.DeepEquals, archive)
+	archived, err := st.IsModelArchived()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(archived, jc.IsTrue)
+
+	stor := statestorage.NewStorage(st.ModelUUID(), st.MongoSession())
+	r, size, err := stor.Get(archive.Path)
+	c.Assert(err, jc.ErrorIsNil)
+	r.Close()
+	c.Assert(size, gc.Equals, archive.Size)
+
+	// The units are going, and their machines are removed by the
+	// cleanups.
+	for _, u := range []*state.Unit{unit0, unit1} {
+		err := u.Refresh()
+		if !errors.IsNotFound(err) {
+			c.Assert(err, jc.ErrorIsNil)
+			c.Assert(u.Life(), gc.Equals, state.Dying)
+		}
+	}
+	needsCleanup, err := st.NeedsCleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(needsCleanup, jc.IsTrue)
+
+	_, err = st.ArchiveModel("bob", time.Minute)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+
+	restored, err := st.ThawModel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restored, jc.DeepEquals, map[string]int{app.Name(): 2})
+
+	units, err := app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	var alive int
+	for _, u := range units {
+		if u.Life() != state.Alive {
+			continue
+		}
+		alive++
+		_, err := u.AssignedMachineId()
+		c.Check(err, jc.ErrorIsNil)
+	}
+	c.Assert(alive, gc.Equals, 2)
+
+	archived, err = st.IsModelArchived()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(archived, jc.IsFalse)
+	_, _, err = stor.Get(archive.Path)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ModelArchiveSuite) TestArchiveAndThawCAASModel(c *gc.C) {
+	st := s.Factory.MakeCAASModel(c, nil)
+	defer st.Close()
+	f := factory.NewFactory(st, s.StatePool)
+	ch := f.MakeCharm(c, &factory.CharmParams{Name: "gitlab", Series: "kubernetes"})
+	app := f.MakeApplication(c, &factory.ApplicationParams{Name: "gitlab", Charm: ch})
+	err := app.SetScale(3, 0, true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = st.ArchiveModel("bob", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.GetScale(), gc.Equals, 0)
+
+	restored, err := st.ThawModel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restored, jc.DeepEquals, map[string]int{"gitlab": 3})
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.GetScale(), gc.Equals, 3)
+}
+
+func (s *ModelArchiveSuite) TestThawSkipsRemovedApplications(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	f := factory.NewFactory(st, s.StatePool)
+	app := f.MakeApplication(c, nil)
+
+	_, err := st.ArchiveModel("bob", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	restored, err := st.ThawModel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restored, gc.HasLen, 0)
+}
+
+func (s *ModelArchiveSuite) TestArchiveRefusesManualMachines(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	f := factory.NewFactory(st, s.StatePool)
+	m := f.MakeMachine(c, &factory.MachineParams{Nonce: "manual:10.0.0.1"})
+
+	_, err := st.ArchiveModel("bob", time.Minute)
+	c.Assert(err, gc.ErrorMatches, `cannot archive model with manually provisioned machine "`+m.Id()+`"`)
+	archived, err := st.IsModelArchived()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(archived, jc.IsFalse)
+}
+
+func (s *ModelArchiveSuite) TestArchiveRefusesAttachedStorage(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	f := factory.NewFactory(st, s.StatePool)
+	ch := f.MakeCharm(c, &factory.CharmParams{Name: "storage-block"})
+	app := f.MakeApplication(c, &factory.ApplicationParams{
+		Name:  "storage-block",
+		Charm: ch,
+		Storage: map[string]state.StorageConstraints{
+			"data": {Pool: "loop", Size: 1024, Count: 1},
+		},
+	})
+	f.MakeUnit(c, &factory.UnitParams{Application: app})
+
+	_, err := st.ArchiveModel("bob", time.Minute)
+	c.Assert(err, gc.ErrorMatches, `cannot archive model with storage attached to unit "storage-block/0"`)
+}
+
+func (s *ModelArchiveSuite) TestThawResumes(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	f := factory.NewFactory(st, s.StatePool)
+	app0 := f.MakeApplication(c, &factory.ApplicationParams{Name: "app0"})
+	f.MakeUnit(c, &factory.UnitParams{Application: app0})
+	app1 := f.MakeApplication(c, &factory.ApplicationParams{Name: "app1"})
+	f.MakeUnit(c, &factory.UnitParams{Application: app1})
+	f.MakeUnit(c, &factory.UnitParams{Application: app1})
+
+	archive, err := st.ArchiveModel("bob", time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// An earlier thaw restored app0, and added one unit to app1
+	// before failing.
+	state.SetModelArchiveRestored(c, st, map[string]int{"app0": 1})
+	_, err = app1.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The archive is kept until the thaw completes.
+	stor := statestorage.NewStorage(st.ModelUUID(), st.MongoSession())
+	r, _, err := stor.Get(archive.Path)
+	c.Assert(err, jc.ErrorIsNil)
+	r.Close()
+
+	restored, err := st.ThawModel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(restored, jc.DeepEquals, map[string]int{"app0": 1, "app1": 2})
+
+	aliveUnits := func(app *state.Application) int {
+		units, err := app.AllUnits()
+		c.Assert(err, jc.ErrorIsNil)
+		var alive int
+		for _, u := range units {
+			if u.Life() == state.Alive {
+				alive++
+				_, err := u.AssignedMachineId()
+				c.Check(err, jc.ErrorIsNil)
+			}
+		}
+		return alive
+	}
+	c.Assert(aliveUnits(app0), gc.Equals, 0)
+	c.Assert(aliveUnits(app1), gc.Equals, 2)
+
+	archived, err := st.IsModelArchived()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(archived, jc.IsFalse)
+	_, _, err = stor.Get(archive.Path)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
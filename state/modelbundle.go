@@ -0,0 +1,212 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// bundlePlanApprovalKey is the ID of the single plan approval document
+// a model may have.
+const bundlePlanApprovalKey = "approval"
+
+// ModelBundle is a revision of the bundle describing the desired state
+// of a model, as stored in the controller.
+type ModelBundle struct {
+	// Revision is the revision of the bundle. Revisions start at 1.
+	Revision int
+
+	// Bundle is the YAML bundle data.
+	Bundle string
+
+	// CreatedBy is the name of the user who stored the bundle.
+	CreatedBy string
+
+	// Created is when the bundle was stored.
+	Created time.Time
+}
+
+// modelBundleDoc records a revision of a model's bundle.
+type modelBundleDoc struct {
+	DocID     string    `bson:"_id"`
+	ModelUUID string    `bson:"model-uuid"`
+	Revision  int       `bson:"revision"`
+	Bundle    string    `bson:"bundle"`
+	CreatedBy string    `bson:"created-by"`
+	Created   time.Time `bson:"created"`
+}
+
+func (doc modelBundleDoc) modelBundle() ModelBundle {
+	return ModelBundle{
+		Revision:  doc.Revision,
+		Bundle:    doc.Bundle,
+		CreatedBy: doc.CreatedBy,
+		Created:   doc.Created.UTC(),
+	}
+}
+
+// AddModelBundle stores a new revision of the bundle describing the
+// desired state of the model, and returns it.
+func (st *State) AddModelBundle(bundle, createdBy string) (ModelBundle, error) {
+	model, err := st.Model()
+	if err != nil {
+		return ModelBundle{}, errors.Trace(err)
+	}
+	revision, err := sequenceWithMin(st, "modelbundle", 1)
+	if err != nil {
+		return ModelBundle{}, errors.Trace(err)
+	}
+	doc := modelBundleDoc{
+		DocID:     st.docID(strconv.Itoa(revision)),
+		ModelUUID: st.ModelUUID(),
+		Revision:  revision,
+		Bundle:    bundle,
+		CreatedBy: createdBy,
+		Created:   st.nowToTheSecond(),
+	}
+	ops := []txn.Op{
+		model.assertActiveOp(),
+		{
+			C:      modelBundlesC,
+			Id:     doc.DocID,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		},
+	}
+	if err := st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return ModelBundle{}, errors.New("model is no longer alive")
+	} else if err != nil {
+		return ModelBundle{}, errors.Trace(err)
+	}
+	return doc.modelBundle(), nil
+}
+
+// ModelBundle returns the given revision of the model's bundle.
+func (st *State) ModelBundle(revision int) (ModelBundle, error) {
+	bundles, closer := st.db().GetCollection(modelBundlesC)
+	defer closer()
+
+	var doc modelBundleDoc
+	if err := bundles.FindId(strconv.Itoa(revision)).One(&doc); err == mgo.ErrNotFound {
+		return ModelBundle{}, errors.NotFoundf("model bundle revision %d", revision)
+	} else if err != nil {
+		return ModelBundle{}, errors.Annotate(err, "cannot read model bundle")
+	}
+	return doc.modelBundle(), nil
+}
+
+// LatestModelBundle returns the most recent revision of the model's
+// bundle, or a NotFound error if no bundle has been stored.
+func (st *State) LatestModelBundle() (ModelBundle, error) {
+	bundles, closer := st.db().GetCollection(modelBundlesC)
+	defer closer()
+
+	var doc modelBundleDoc
+	if err := bundles.Find(nil).Sort("-revision").One(&doc); err == mgo.ErrNotFound {
+		return ModelBundle{}, errors.NotFoundf("model bundle")
+	} else if err != nil {
+		return ModelBundle{}, errors.Annotate(err, "cannot read model bundle")
+	}
+	return doc.modelBundle(), nil
+}
+
+// WatchModelBundles returns a NotifyWatcher which triggers whenever a
+// new revision of the model's bundle is stored.
+func (st *State) WatchModelBundles() NotifyWatcher {
+	return newNotifyCollWatcher(st, modelBundlesC, isLocalID(st))
+}
+
+// BundlePlanApproval records the approval of a plan to bring the model
+// into line with its bundle.
+type BundlePlanApproval struct {
+	// Fingerprint is the fingerprint of the approved plan.
+	Fingerprint string
+
+	// ApprovedBy is the name of the user who approved the plan.
+	ApprovedBy string
+
+	// Approved is when the plan was approved.
+	Approved time.Time
+}
+
+// bundlePlanApprovalDoc records the most recently approved bundle plan
+// of a model.
+type bundlePlanApprovalDoc struct {
+	DocID       string    `bson:"_id"`
+	ModelUUID   string    `bson:"model-uuid"`
+	Fingerprint string    `bson:"fingerprint"`
+	ApprovedBy  string    `bson:"approved-by"`
+	Approved    time.Time `bson:"approved"`
+}
+
+// ApproveBundlePlan records that the plan with the given fingerprint
+// may be applied to the model. It replaces any earlier approval.
+func (st *State) ApproveBundlePlan(fingerprint, approvedBy string) error {
+	if fingerprint == "" {
+		return errors.NotValidf("empty plan fingerprint")
+	}
+	doc := bundlePlanApprovalDoc{
+		DocID:       st.docID(bundlePlanApprovalKey),
+		ModelUUID:   st.ModelUUID(),
+		Fingerprint: fingerprint,
+		ApprovedBy:  approvedBy,
+		Approved:    st.nowToTheSecond(),
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		_, err := st.BundlePlanApproval()
+		if errors.IsNotFound(err) {
+			return []txn.Op{{
+				C:      bundlePlanApprovalsC,
+				Id:     doc.DocID,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			}}, nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      bundlePlanApprovalsC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", bson.D{
+				{"fingerprint", doc.Fingerprint},
+				{"approved-by", doc.ApprovedBy},
+				{"approved", doc.Approved},
+			}}},
+		}}, nil
+	}
+	return errors.Annotate(st.db().Run(jujutxn.TransactionSource(buildTxn)), "cannot approve bundle plan")
+}
+
+// BundlePlanApproval returns the most recently approved bundle plan of
+// the model, or a NotFound error if no plan has been approved.
+func (st *State) BundlePlanApproval() (BundlePlanApproval, error) {
+	approvals, closer := st.db().GetCollection(bundlePlanApprovalsC)
+	defer closer()
+
+	var doc bundlePlanApprovalDoc
+	if err := approvals.FindId(bundlePlanApprovalKey).One(&doc); err == mgo.ErrNotFound {
+		return BundlePlanApproval{}, errors.NotFoundf("bundle plan approval")
+	} else if err != nil {
+		return BundlePlanApproval{}, errors.Annotate(err, "cannot read bundle plan approval")
+	}
+	return BundlePlanApproval{
+		Fingerprint: doc.Fingerprint,
+		ApprovedBy:  doc.ApprovedBy,
+		Approved:    doc.Approved.UTC(),
+	}, nil
+}
+
+// WatchBundlePlanApproval returns a NotifyWatcher which triggers
+// whenever a bundle plan is approved for the model.
+func (st *State) WatchBundlePlanApproval() NotifyWatcher {
+	return newNotifyCollWatcher(st, bundlePlanApprovalsC, isLocalID(st))
+}
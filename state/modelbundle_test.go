@@ -0,0 +1,104 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type ModelBundleSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ModelBundleSuite{})
+
+func (s *ModelBundleSuite) TestNoModelBundle(c *gc.C) {
+	_, err := s.State.LatestModelBundle()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	_, err = s.State.ModelBundle(1)
+	c.Assert(err, gc.ErrorMatches, "model bundle revision 1 not found")
+}
+
+func (s *ModelBundleSuite) TestAddModelBundle(c *gc.C) {
+	first, err := s.State.AddModelBundle("applications: {}", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(first.Revision, gc.Equals, 1)
+	c.Check(first.Bundle, gc.Equals, "applications: {}")
+	c.Check(first.CreatedBy, gc.Equals, "bob")
+	c.Check(first.Created.IsZero(), jc.IsFalse)
+
+	second, err := s.State.AddModelBundle("machines: {}", "mary")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(second.Revision, gc.Equals, 2)
+
+	latest, err := s.State.LatestModelBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(latest, jc.DeepEquals, second)
+
+	stored, err := s.State.ModelBundle(1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(stored, jc.DeepEquals, first)
+}
+
+func (s *ModelBundleSuite) TestModelBundlesAreLocalToModel(c *gc.C) {
+	_, err := s.State.AddModelBundle("applications: {}", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	_, err = st.LatestModelBundle()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *ModelBundleSuite) TestWatchModelBundles(c *gc.C) {
+	w := s.State.WatchModelBundles()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	_, err := s.State.AddModelBundle("applications: {}", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	wc.AssertNoChange()
+}
+
+func (s *ModelBundleSuite) TestApproveBundlePlan(c *gc.C) {
+	_, err := s.State.BundlePlanApproval()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.ApproveBundlePlan("abc", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	approval, err := s.State.BundlePlanApproval()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(approval.Fingerprint, gc.Equals, "abc")
+	c.Check(approval.ApprovedBy, gc.Equals, "bob")
+
+	err = s.State.ApproveBundlePlan("def", "mary")
+	c.Assert(err, jc.ErrorIsNil)
+	approval, err = s.State.BundlePlanApproval()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(approval.Fingerprint, gc.Equals, "def")
+	c.Check(approval.ApprovedBy, gc.Equals, "mary")
+}
+
+func (s *ModelBundleSuite) TestApproveEmptyBundlePlan(c *gc.C) {
+	err := s.State.ApproveBundlePlan("", "bob")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *ModelBundleSuite) TestWatchBundlePlanApproval(c *gc.C) {
+	w := s.State.WatchBundlePlanApproval()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.State.ApproveBundlePlan("abc", "bob")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	wc.AssertNoChange()
+}
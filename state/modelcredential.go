@@ -31,21 +31,26 @@ func (st *State) InvalidateModelCredential(reason string) error {
 	if err := st.InvalidateCloudCredential(tag, reason); err != nil {
 		return errors.Trace(err)
 	}
-	if err := st.suspendCredentialModels(tag); err != nil {
+	if err := st.suspendCredentialModels(tag, reason); err != nil {
 		// These updates are optimistic. If they fail, it's unfortunate but we are not going to stop the call.
 		logger.Warningf("could not suspend models that use credential %v: %v", tag.Id(), err)
 	}
 	return nil
 }
 
-func (st *State) suspendCredentialModels(tag names.CloudCredentialTag) error {
+// suspendCredentialModels records in the status history of the models
+// using the credential that they are suspended, and why the credential
+// was found invalid.
+func (st *State) suspendCredentialModels(tag names.CloudCredentialTag, reason string) error {
 	models, err := st.modelsWithCredential(tag)
 	if err != nil {
 		return errors.Annotatef(err, "could not determine what models use credential %v", tag.Id())
 	}
+	suspended := modelStatusInvalidCredential()
 	doc := statusDoc{
-		Status:     status.Suspended,
-		StatusInfo: "suspended since cloud credential is not valid",
+		Status:     suspended.Status,
+		StatusInfo: suspended.Message,
+		StatusData: map[string]interface{}{"reason": reason},
 		Updated:    timeOrNow(nil, st.clock()).UnixNano(),
 	}
 	for _, m := range models {
@@ -267,10 +267,14 @@ func (s *ModelCredentialSuite) TestInvalidateModelCredentialTouchesAllCredential
 	defer helper.Release()
 	c.Assert(oneModelState.State().InvalidateModelCredential("testing invalidate for all credential models"), jc.ErrorIsNil)
 
-	// 4. check all models are suspended
+	// 4. check all models are suspended, recording why
 	for _, uuid := range modelUUIDs {
 		assertModelStatus(c, s.StatePool, uuid, status.Suspended)
-		assertModelHistories(c, s.StatePool, uuid, status.Suspended, status.Available)
+		histories := assertModelHistories(c, s.StatePool, uuid, status.Suspended, status.Available)
+		c.Assert(histories[0].Message, gc.Equals, "suspended: invalid credential")
+		c.Assert(histories[0].Data, jc.DeepEquals, map[string]interface{}{
+			"reason": "testing invalidate for all credential models",
+		})
 	}
 }
 
@@ -0,0 +1,133 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ModelEventKind identifies the kind of lifecycle event recorded for a
+// model.
+type ModelEventKind string
+
+const (
+	// ModelEventUnitAdded records a unit being added to an
+	// application.
+	ModelEventUnitAdded ModelEventKind = "unit-added"
+
+	// ModelEventUnitRemoved records a unit being removed from the
+	// model.
+	ModelEventUnitRemoved ModelEventKind = "unit-removed"
+
+	// ModelEventStatusChanged records the workload status of a unit
+	// changing.
+	ModelEventStatusChanged ModelEventKind = "status-changed"
+
+	// ModelEventRelationJoined records a unit entering the scope of a
+	// relation.
+	ModelEventRelationJoined ModelEventKind = "relation-joined"
+
+	// ModelEventUpgradeCompleted records a unit being upgraded to a
+	// new charm.
+	ModelEventUpgradeCompleted ModelEventKind = "upgrade-completed"
+)
+
+// modelEventDoc records a lifecycle event in a model, so that external
+// consumers can follow the changes made to the model.
+type modelEventDoc struct {
+	DocId     string            `bson:"_id"`
+	ModelUUID string            `bson:"model-uuid"`
+	Seq       int               `bson:"seq"`
+	Kind      ModelEventKind    `bson:"kind"`
+	Entity    string            `bson:"entity"`
+	Data      map[string]string `bson:"data,omitempty"`
+	Time      time.Time         `bson:"time"`
+}
+
+// ModelEvent describes a lifecycle event in a model.
+type ModelEvent struct {
+	// Seq is the position of the event in the model's events. Events
+	// are numbered in the order in which they were made, but an event
+	// may be committed after ones which follow it, and some numbers
+	// may never be used.
+	Seq int
+
+	// Kind is the kind of event.
+	Kind ModelEventKind
+
+	// Entity is the tag of the entity the event is about, eg
+	// "unit-mysql-0".
+	Entity string
+
+	// Data holds the details of the event, such as the new status of
+	// a unit or the relation it joined.
+	Data map[string]string
+
+	// Time is when the event was recorded.
+	Time time.Time
+}
+
+// addModelEventOp returns the txn.Op which records a lifecycle event of
+// the given kind in the model's events.
+func addModelEventOp(mb modelBackend, kind ModelEventKind, entity string, data map[string]string) (txn.Op, error) {
+	seq, err := sequence(mb, "modelevent")
+	if err != nil {
+		return txn.Op{}, errors.Trace(err)
+	}
+	doc := modelEventDoc{
+		DocId:     mb.docID(strconv.Itoa(seq)),
+		ModelUUID: mb.modelUUID(),
+		Seq:       seq,
+		Kind:      kind,
+		Entity:    entity,
+		Data:      data,
+		Time:      mb.nowToTheSecond(),
+	}
+	return txn.Op{
+		C:      modelEventsC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}, nil
+}
+
+// ModelEvents returns at most limit of the model's lifecycle events,
+// starting with the event numbered from, ordered by their sequence
+// numbers. If limit is not positive, all of the events are returned.
+func (st *State) ModelEvents(from, limit int) ([]ModelEvent, error) {
+	events, closer := st.db().GetCollection(modelEventsC)
+	defer closer()
+
+	query := events.Find(bson.D{{"seq", bson.D{{"$gte", from}}}}).Sort("seq")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	var docs []modelEventDoc
+	if err := query.All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read model events")
+	}
+	result := make([]ModelEvent, len(docs))
+	for i, doc := range docs {
+		result[i] = ModelEvent{
+			Seq:    doc.Seq,
+			Kind:   doc.Kind,
+			Entity: doc.Entity,
+			Data:   doc.Data,
+			Time:   doc.Time.UTC(),
+		}
+	}
+	return result, nil
+}
+
+// PruneModelEvents removes the model's lifecycle events which are older
+// than the given age.
+func PruneModelEvents(st *State, maxAge time.Duration) error {
+	err := pruneCollection(st, maxAge, 0, modelEventsC, "time", GoTime)
+	return errors.Trace(err)
+}
@@ -0,0 +1,187 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+type ModelEventsSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ModelEventsSuite{})
+
+func (s *ModelEventsSuite) eventsOfKind(c *gc.C, kind state.ModelEventKind) []state.ModelEvent {
+	events, err := s.State.ModelEvents(0, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	var result []state.ModelEvent
+	for _, event := range events {
+		if event.Kind == kind {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+func (s *ModelEventsSuite) TestUnitAddedAndRemoved(c *gc.C) {
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	events := s.eventsOfKind(c, state.ModelEventUnitAdded)
+	c.Assert(events, gc.HasLen, 1)
+	c.Check(events[0], jc.DeepEquals, state.ModelEvent{
+		Seq:    events[0].Seq,
+		Kind:   state.ModelEventUnitAdded,
+		Entity: "unit-wordpress-0",
+		Data:   map[string]string{"application": "wordpress"},
+		Time:   s.Clock.Now().Round(time.Second).UTC(),
+	})
+
+	err = unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	events = s.eventsOfKind(c, state.ModelEventUnitRemoved)
+	c.Assert(events, gc.HasLen, 1)
+	c.Check(events[0].Entity, gc.Equals, "unit-wordpress-0")
+	c.Check(events[0].Data, jc.DeepEquals, map[string]string{"application": "wordpress"})
+}
+
+func (s *ModelEventsSuite) TestStatusChanged(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	now := s.Clock.Now()
+	sInfo := status.StatusInfo{
+		Status:  status.Active,
+		Message: "ready",
+		Since:   &now,
+	}
+	err := unit.SetStatus(sInfo)
+	c.Assert(err, jc.ErrorIsNil)
+
+	events := s.eventsOfKind(c, state.ModelEventStatusChanged)
+	c.Assert(events, gc.HasLen, 1)
+	c.Check(events[0].Entity, gc.Equals, unit.Tag().String())
+	c.Check(events[0].Data, jc.DeepEquals, map[string]string{
+		"status":  "active",
+		"message": "ready",
+	})
+
+	// Setting the same status again isn't a change.
+	err = unit.SetStatus(sInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.eventsOfKind(c, state.ModelEventStatusChanged), gc.HasLen, 1)
+}
+
+func (s *ModelEventsSuite) TestStatusChangedInBatch(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	now := s.Clock.Now()
+	errs := s.State.SetStatuses([]state.StatusUpdate{{
+		Entity: unit,
+		Status: status.StatusInfo{Status: status.Blocked, Message: "waiting for db", Since: &now},
+	}})
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+
+	events := s.eventsOfKind(c, state.ModelEventStatusChanged)
+	c.Assert(events, gc.HasLen, 1)
+	c.Check(events[0].Data["status"], gc.Equals, "blocked")
+}
+
+func (s *ModelEventsSuite) TestRelationJoined(c *gc.C) {
+	prr := newProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	err := prr.pru0.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	events := s.eventsOfKind(c, state.ModelEventRelationJoined)
+	c.Assert(events, gc.HasLen, 1)
+	c.Check(events[0].Entity, gc.Equals, prr.pu0.Tag().String())
+	c.Check(events[0].Data, jc.DeepEquals, map[string]string{
+		"relation": prr.rel.String(),
+	})
+
+	// Entering the scope again isn't a change.
+	err = prr.pru0.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.eventsOfKind(c, state.ModelEventRelationJoined), gc.HasLen, 1)
+}
+
+func (s *ModelEventsSuite) TestUpgradeCompleted(c *gc.C) {
+	ch := s.AddTestingCharm(c, "wordpress")
+	app := s.AddTestingApplication(c, "wordpress", ch)
+	unit, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Setting the unit's first charm isn't an upgrade.
+	err = unit.SetCharmURL(ch.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.eventsOfKind(c, state.ModelEventUpgradeCompleted), gc.HasLen, 0)
+
+	newCharm := s.AddConfigCharm(c, "wordpress", "options: {}", 123)
+	err = app.SetCharm(state.SetCharmConfig{Charm: newCharm})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetCharmURL(newCharm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+
+	events := s.eventsOfKind(c, state.ModelEventUpgradeCompleted)
+	c.Assert(events, gc.HasLen, 1)
+	c.Check(events[0].Entity, gc.Equals, "unit-wordpress-0")
+	c.Check(events[0].Data, jc.DeepEquals, map[string]string{
+		"charm-url":          newCharm.URL().String(),
+		"previous-charm-url": ch.URL().String(),
+	})
+}
+
+func (s *ModelEventsSuite) TestModelEventsFromAndLimit(c *gc.C) {
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	for i := 0; i < 3; i++ {
+		_, err := app.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	all, err := s.State.ModelEvents(0, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 3)
+
+	events, err := s.State.ModelEvents(all[1].Seq, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, jc.DeepEquals, all[1:])
+
+	events, err = s.State.ModelEvents(0, 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, jc.DeepEquals, all[:2])
+}
+
+func (s *ModelEventsSuite) TestOtherModels(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	s.Factory.MakeUnit(c, nil)
+
+	events, err := st.ModelEvents(0, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 0)
+}
+
+func (s *ModelEventsSuite) TestPruneModelEvents(c *gc.C) {
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	_, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(2 * time.Hour)
+	_, err = app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = state.PruneModelEvents(s.State, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	events, err := s.State.ModelEvents(0, 0)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(events, gc.HasLen, 1)
+	c.Check(events[0].Entity, gc.Equals, "unit-wordpress-1")
+}
@@ -413,6 +413,13 @@ func (g *Generation) Commit(userName string) (int, error) {
 				return nil, errors.Trace(err)
 			}
 			newGenId = id
+
+			operationOp, err := addOperationOp(g.st, OperationBranchCommit,
+				fmt.Sprintf("commit branch %q", g.BranchName()), userName, strconv.Itoa(newGenId))
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, operationOp)
 		}
 
 		// As a proxy for checking that the generation has not changed,
@@ -728,7 +728,17 @@ func (st *State) CreateMigration(spec MigrationSpec) (ModelMigration, error) {
 			StatusMessage:    msg,
 		}
 
-		ops := append(ops, []txn.Op{{
+		target := spec.TargetInfo.ControllerAlias
+		if target == "" {
+			target = spec.TargetInfo.ControllerTag.Id()
+		}
+		operationOp, err := addOperationOp(st, OperationMigration,
+			fmt.Sprintf("migrate model to controller %s", target), spec.InitiatedBy.Id(), id)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		ops := append(ops, []txn.Op{operationOp, {
 			C:      migrationsC,
 			Id:     doc.Id,
 			Assert: txn.DocMissing,
@@ -0,0 +1,171 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/constraints"
+)
+
+// ModelTemplate holds the settings applied to a model created from the
+// template, so that teams can create consistently configured models.
+type ModelTemplate struct {
+	// Name identifies the template.
+	Name string
+
+	// Config holds model config attributes. Attributes given when
+	// the model is created take precedence.
+	Config map[string]interface{}
+
+	// Constraints are the initial constraints of the model.
+	Constraints constraints.Value
+
+	// DefaultSpace, if set, is the space to which endpoints of
+	// applications in the model are bound by default.
+	DefaultSpace string
+
+	// CloudCredential, if set, is the ID of the cloud credential to
+	// use when none is given when the model is created.
+	CloudCredential string
+
+	// CreatedBy is the name of the user who added the template.
+	CreatedBy string
+
+	// Created is when the template was added.
+	Created time.Time
+}
+
+// Validate returns an error if the template is not valid.
+func (t ModelTemplate) Validate() error {
+	if !names.IsValidModelName(t.Name) {
+		return errors.NotValidf("model template name %q", t.Name)
+	}
+	if t.DefaultSpace != "" && !names.IsValidSpace(t.DefaultSpace) {
+		return errors.NotValidf("default space %q", t.DefaultSpace)
+	}
+	if t.CloudCredential != "" && !names.IsValidCloudCredential(t.CloudCredential) {
+		return errors.NotValidf("cloud credential ID %q", t.CloudCredential)
+	}
+	return nil
+}
+
+// modelTemplateDoc records a model template in the controller.
+type modelTemplateDoc struct {
+	Name            string                 `bson:"_id"`
+	Config          map[string]interface{} `bson:"config,omitempty"`
+	Constraints     string                 `bson:"constraints,omitempty"`
+	DefaultSpace    string                 `bson:"default-space,omitempty"`
+	CloudCredential string                 `bson:"cloud-credential,omitempty"`
+	CreatedBy       string                 `bson:"created-by"`
+	Created         time.Time              `bson:"created"`
+}
+
+func (doc modelTemplateDoc) template() (ModelTemplate, error) {
+	cons, err := constraints.Parse(doc.Constraints)
+	if err != nil {
+		return ModelTemplate{}, errors.Annotatef(err, "model template %q constraints", doc.Name)
+	}
+	return ModelTemplate{
+		Name:            doc.Name,
+		Config:          doc.Config,
+		Constraints:     cons,
+		DefaultSpace:    doc.DefaultSpace,
+		CloudCredential: doc.CloudCredential,
+		CreatedBy:       doc.CreatedBy,
+		Created:         doc.Created.UTC(),
+	}, nil
+}
+
+// AddModelTemplate records a new model template in the controller. The
+// Created time of the template is set to the current time.
+func (st *State) AddModelTemplate(t ModelTemplate) error {
+	if err := t.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	doc := modelTemplateDoc{
+		Name:            t.Name,
+		Config:          t.Config,
+		DefaultSpace:    t.DefaultSpace,
+		CloudCredential: t.CloudCredential,
+		CreatedBy:       t.CreatedBy,
+		Created:         st.nowToTheSecond(),
+	}
+	if !constraints.IsEmpty(&t.Constraints) {
+		doc.Constraints = t.Constraints.String()
+	}
+	ops := []txn.Op{{
+		C:      modelTemplatesC,
+		Id:     t.Name,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+	if err := st.db().RunTransaction(ops); err != nil {
+		if err == txn.ErrAborted {
+			return errors.AlreadyExistsf("model template %q", t.Name)
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// ModelTemplate returns the model template with the given name.
+func (st *State) ModelTemplate(name string) (ModelTemplate, error) {
+	templates, closer := st.db().GetCollection(modelTemplatesC)
+	defer closer()
+
+	var doc modelTemplateDoc
+	err := templates.FindId(name).One(&doc)
+	if err == mgo.ErrNotFound {
+		return ModelTemplate{}, errors.NotFoundf("model template %q", name)
+	} else if err != nil {
+		return ModelTemplate{}, errors.Annotatef(err, "cannot get model template %q", name)
+	}
+	return doc.template()
+}
+
+// ModelTemplates returns all the model templates in the controller,
+// ordered by name.
+func (st *State) ModelTemplates() ([]ModelTemplate, error) {
+	templates, closer := st.db().GetCollection(modelTemplatesC)
+	defer closer()
+
+	var docs []modelTemplateDoc
+	if err := templates.Find(bson.D{}).Sort("_id").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get model templates")
+	}
+	result := make([]ModelTemplate, len(docs))
+	for i, doc := range docs {
+		t, err := doc.template()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[i] = t
+	}
+	return result, nil
+}
+
+// RemoveModelTemplate removes the model template with the given name.
+// Models already created from the template are not affected.
+func (st *State) RemoveModelTemplate(name string) error {
+	ops := []txn.Op{{
+		C:      modelTemplatesC,
+		Id:     name,
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	if err := st.db().RunTransaction(ops); err != nil {
+		if err == txn.ErrAborted {
+			return errors.NotFoundf("model template %q", name)
+		}
+		return errors.Trace(err)
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/constraints"
+	"github.com/juju/juju/state"
+)
+
+type ModelTemplatesSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&ModelTemplatesSuite{})
+
+func (s *ModelTemplatesSuite) TestAddModelTemplate(c *gc.C) {
+	err := s.State.AddModelTemplate(state.ModelTemplate{
+		Name:            "web",
+		Config:          map[string]interface{}{"image-stream": "daily"},
+		Constraints:     constraints.MustParse("mem=4G"),
+		DefaultSpace:    "public",
+		CloudCredential: "dummy/bob/cred",
+		CreatedBy:       "admin",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	t, err := s.State.ModelTemplate("web")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(t.Created.IsZero(), jc.IsFalse)
+	t.Created = time.Time{}
+	c.Assert(t, jc.DeepEquals, state.ModelTemplate{
+		Name:            "web",
+		Config:          map[string]interface{}{"image-stream": "daily"},
+		Constraints:     constraints.MustParse("mem=4G"),
+		DefaultSpace:    "public",
+		CloudCredential: "dummy/bob/cred",
+		CreatedBy:       "admin",
+	})
+}
+
+func (s *ModelTemplatesSuite) TestAddModelTemplateAlreadyExists(c *gc.C) {
+	err := s.State.AddModelTemplate(state.ModelTemplate{Name: "web", CreatedBy: "admin"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AddModelTemplate(state.ModelTemplate{Name: "web", CreatedBy: "admin"})
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+	c.Assert(err, gc.ErrorMatches, `model template "web" already exists`)
+}
+
+func (s *ModelTemplatesSuite) TestAddModelTemplateInvalid(c *gc.C) {
+	for _, t := range []state.ModelTemplate{
+		{Name: "Web"},
+		{Name: "web", DefaultSpace: "Public!"},
+		{Name: "web", CloudCredential: "cred"},
+	} {
+		err := s.State.AddModelTemplate(t)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *ModelTemplatesSuite) TestModelTemplateNotFound(c *gc.C) {
+	_, err := s.State.ModelTemplate("web")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `model template "web" not found`)
+}
+
+func (s *ModelTemplatesSuite) TestModelTemplates(c *gc.C) {
+	for _, name := range []string{"web", "db"} {
+		err := s.State.AddModelTemplate(state.ModelTemplate{Name: name, CreatedBy: "admin"})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	templates, err := s.State.ModelTemplates()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(templates, gc.HasLen, 2)
+	c.Assert(templates[0].Name, gc.Equals, "db")
+	c.Assert(templates[1].Name, gc.Equals, "web")
+}
+
+func (s *ModelTemplatesSuite) TestRemoveModelTemplate(c *gc.C) {
+	err := s.State.AddModelTemplate(state.ModelTemplate{Name: "web", CreatedBy: "admin"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveModelTemplate("web")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ModelTemplate("web")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.RemoveModelTemplate("web")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
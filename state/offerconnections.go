@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/juju/core/status"
@@ -29,6 +30,14 @@ type offerConnectionDoc struct {
 	OfferUUID       string `bson:"offer-uuid"`
 	UserName        string `bson:"username"`
 	SourceModelUUID string `bson:"source-model-uuid"`
+
+	// LastContact records when the consuming model last made an
+	// authenticated request for the relation.
+	LastContact int64 `bson:"last-contact,omitempty"`
+
+	// LastProbeSuccess records when the connection was last found
+	// to be healthy by the cross model health prober.
+	LastProbeSuccess int64 `bson:"last-probe-success,omitempty"`
 }
 
 func newOfferConnection(st *State, doc *offerConnectionDoc) *OfferConnection {
@@ -64,6 +73,80 @@ func (oc *OfferConnection) RelationKey() string {
 	return oc.doc.RelationKey
 }
 
+// LastContact returns when the consuming model last made an
+// authenticated request for the relation, or the zero time if it
+// never has.
+func (oc *OfferConnection) LastContact() time.Time {
+	if oc.doc.LastContact == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, oc.doc.LastContact).UTC()
+}
+
+// LastProbeSuccess returns when the connection was last found to be
+// healthy, or the zero time if it never has.
+func (oc *OfferConnection) LastProbeSuccess() time.Time {
+	if oc.doc.LastProbeSuccess == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, oc.doc.LastProbeSuccess).UTC()
+}
+
+// offerContactRecordInterval is the interval within which repeated
+// contact from the consuming model is not recorded again.
+const offerContactRecordInterval = time.Minute
+
+// RecordContact records that the consuming model has made an
+// authenticated request for the relation, or is still watching it
+// over a live connection. Contact made within a
+// minute of the last recorded contact is not recorded, so that busy
+// relations do not cause a write for every request.
+func (oc *OfferConnection) RecordContact() error {
+	now := oc.st.clock().Now()
+	cutoff := now.Add(-offerContactRecordInterval).UnixNano()
+	if oc.doc.LastContact > cutoff {
+		return nil
+	}
+	ops := []txn.Op{{
+		C:  offerConnectionsC,
+		Id: oc.doc.DocID,
+		Assert: bson.D{{"$or", []bson.D{
+			{{"last-contact", bson.D{{"$exists", false}}}},
+			{{"last-contact", bson.D{{"$lt", cutoff}}}},
+		}}},
+		Update: bson.D{{"$set", bson.D{{"last-contact", now.UnixNano()}}}},
+	}}
+	err := oc.st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		// Either the contact has been recorded concurrently,
+		// or the connection has been removed.
+		return nil
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot record contact for %v", oc)
+	}
+	oc.doc.LastContact = now.UnixNano()
+	return nil
+}
+
+// SetLastProbeSuccess records when the connection was last found to
+// be healthy.
+func (oc *OfferConnection) SetLastProbeSuccess(t time.Time) error {
+	ops := []txn.Op{{
+		C:      offerConnectionsC,
+		Id:     oc.doc.DocID,
+		Assert: txn.DocExists,
+		Update: bson.D{{"$set", bson.D{{"last-probe-success", t.UnixNano()}}}},
+	}}
+	err := oc.st.db().RunTransaction(ops)
+	if err == txn.ErrAborted {
+		return errors.NotFoundf("offer connection for relation %d", oc.doc.RelationId)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot set last probe success for %v", oc)
+	}
+	oc.doc.LastProbeSuccess = t.UnixNano()
+	return nil
+}
+
 func removeOfferConnectionsForRelationOps(relId int) []txn.Op {
 	op := txn.Op{
 		C:      offerConnectionsC,
@@ -156,7 +239,7 @@ func (st *State) AddOfferConnection(args AddOfferConnectionParams) (_ *OfferConn
 	if err = st.db().Run(buildTxn); err != nil {
 		return nil, errors.Trace(err)
 	}
-	return &OfferConnection{doc: offerConnectionDoc}, nil
+	return newOfferConnection(st, &offerConnectionDoc), nil
 }
 
 // OfferConnections returns the offer connections for an offer.
@@ -191,6 +274,23 @@ func (st *State) OfferConnectionForRelation(relationKey string) (*OfferConnectio
 	return newOfferConnection(st, &connDoc), nil
 }
 
+// AllOfferConnections returns the connections to all offers hosted
+// in the model.
+func (st *State) AllOfferConnections() ([]*OfferConnection, error) {
+	offerConnectionCollection, closer := st.db().GetCollection(offerConnectionsC)
+	defer closer()
+
+	var connDocs []offerConnectionDoc
+	if err := offerConnectionCollection.Find(nil).Sort("relation-id").All(&connDocs); err != nil {
+		return nil, errors.Annotate(err, "cannot get offer connections")
+	}
+	conns := make([]*OfferConnection, len(connDocs))
+	for i := range connDocs {
+		conns[i] = newOfferConnection(st, &connDocs[i])
+	}
+	return conns, nil
+}
+
 // OfferConnectionsForUser returns the offer connections for the specified user.
 func (st *State) OfferConnectionsForUser(username string) ([]*OfferConnection, error) {
 	offerConnectionCollection, closer := st.db().GetCollection(offerConnectionsC)
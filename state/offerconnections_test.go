@@ -5,6 +5,7 @@ package state_test
 
 import (
 	"fmt"
+	"time"
 
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -152,3 +153,74 @@ func (s *offerConnectionsSuite) TestOfferConnectionsForUser(c *gc.C) {
 	c.Assert(obtained[0].OfferUUID(), gc.Equals, oc.OfferUUID())
 	c.Assert(obtained[0].UserName(), gc.Equals, oc.UserName())
 }
+
+func (s *offerConnectionsSuite) TestAllOfferConnections(c *gc.C) {
+	for _, rel := range []*state.Relation{s.activeRel, s.suspendedRel} {
+		_, err := s.State.AddOfferConnection(state.AddOfferConnectionParams{
+			SourceModelUUID: testing.ModelTag.Id(),
+			RelationId:      rel.Id(),
+			RelationKey:     rel.Tag().Id(),
+			Username:        "fred",
+			OfferUUID:       "offer-uuid",
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	all, err := s.State.AllOfferConnections()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 2)
+	c.Assert(all[0].RelationKey(), gc.Equals, s.activeRel.Tag().Id())
+	c.Assert(all[1].RelationKey(), gc.Equals, s.suspendedRel.Tag().Id())
+}
+
+func (s *offerConnectionsSuite) TestRecordContact(c *gc.C) {
+	oc, err := s.State.AddOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID: testing.ModelTag.Id(),
+		RelationId:      s.activeRel.Id(),
+		RelationKey:     s.activeRel.Tag().Id(),
+		Username:        "fred",
+		OfferUUID:       "offer-uuid",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(oc.LastContact().IsZero(), jc.IsTrue)
+
+	first := s.Clock.Now()
+	err = oc.RecordContact()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Contact within a minute of the last is not recorded.
+	s.Clock.Advance(30 * time.Second)
+	obtained, err := s.State.OfferConnectionForRelation(s.activeRel.Tag().Id())
+	c.Assert(err, jc.ErrorIsNil)
+	err = obtained.RecordContact()
+	c.Assert(err, jc.ErrorIsNil)
+	obtained, err = s.State.OfferConnectionForRelation(s.activeRel.Tag().Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(obtained.LastContact().Equal(first), jc.IsTrue)
+
+	s.Clock.Advance(time.Minute)
+	err = obtained.RecordContact()
+	c.Assert(err, jc.ErrorIsNil)
+	obtained, err = s.State.OfferConnectionForRelation(s.activeRel.Tag().Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(obtained.LastContact().Equal(s.Clock.Now()), jc.IsTrue)
+}
+
+func (s *offerConnectionsSuite) TestSetLastProbeSuccess(c *gc.C) {
+	oc, err := s.State.AddOfferConnection(state.AddOfferConnectionParams{
+		SourceModelUUID: testing.ModelTag.Id(),
+		RelationId:      s.activeRel.Id(),
+		RelationKey:     s.activeRel.Tag().Id(),
+		Username:        "fred",
+		OfferUUID:       "offer-uuid",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(oc.LastProbeSuccess().IsZero(), jc.IsTrue)
+
+	now := time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)
+	err = oc.SetLastProbeSuccess(now)
+	c.Assert(err, jc.ErrorIsNil)
+	obtained, err := s.State.OfferConnectionForRelation(s.activeRel.Tag().Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(obtained.LastProbeSuccess(), gc.Equals, now)
+}
@@ -0,0 +1,143 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// OperationKind identifies the kind of change recorded in the
+// operations log.
+type OperationKind string
+
+const (
+	// OperationAction records an action being enqueued on a unit.
+	OperationAction OperationKind = "action"
+
+	// OperationUpgrade records the model agent version being changed.
+	OperationUpgrade OperationKind = "upgrade"
+
+	// OperationMigration records a migration of the model being
+	// started.
+	OperationMigration OperationKind = "migration"
+
+	// OperationBranchCommit records a branch being committed.
+	OperationBranchCommit OperationKind = "branch-commit"
+)
+
+// Validate returns an error if the kind is not known.
+func (k OperationKind) Validate() error {
+	switch k {
+	case OperationAction, OperationUpgrade, OperationMigration, OperationBranchCommit:
+		return nil
+	}
+	return errors.NotValidf("operation kind %q", k)
+}
+
+// operationDoc records a change made to a model, so that operators can
+// find out what changed in the model over a period of time.
+type operationDoc struct {
+	DocId     string        `bson:"_id"`
+	ModelUUID string        `bson:"model-uuid"`
+	Seq       int           `bson:"seq"`
+	Kind      OperationKind `bson:"kind"`
+	Summary   string        `bson:"summary"`
+	Who       string        `bson:"who,omitempty"`
+	Ref       string        `bson:"ref,omitempty"`
+	Time      time.Time     `bson:"time"`
+}
+
+// OperationRecord describes a change made to a model.
+type OperationRecord struct {
+	// Id identifies the record within the model.
+	Id string
+
+	// Kind is the kind of change made.
+	Kind OperationKind
+
+	// Summary describes the change, eg "upgrade model from 2.7.0 to
+	// 2.7.1".
+	Summary string
+
+	// Who is the name of the user who made the change, if known.
+	Who string
+
+	// Ref identifies the entity the change created, such as the ID
+	// of an action or of a migration, if there is one.
+	Ref string
+
+	// Time is when the change was made.
+	Time time.Time
+}
+
+// addOperationOp returns the txn.Op which records a change of the given
+// kind in the model's operations log.
+func addOperationOp(mb modelBackend, kind OperationKind, summary, who, ref string) (txn.Op, error) {
+	id, err := sequence(mb, "operation")
+	if err != nil {
+		return txn.Op{}, errors.Trace(err)
+	}
+	doc := operationDoc{
+		DocId:     mb.docID(strconv.Itoa(id)),
+		ModelUUID: mb.modelUUID(),
+		Seq:       id,
+		Kind:      kind,
+		Summary:   summary,
+		Who:       who,
+		Ref:       ref,
+		Time:      mb.nowToTheSecond(),
+	}
+	return txn.Op{
+		C:      operationsC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}, nil
+}
+
+// Operations returns the changes recorded in the model's operations log
+// at or after the given time, in the order they were made. If any kinds
+// are given, only changes of those kinds are returned.
+func (st *State) Operations(since time.Time, kinds ...OperationKind) ([]OperationRecord, error) {
+	operations, closer := st.db().GetCollection(operationsC)
+	defer closer()
+
+	query := bson.D{{"time", bson.D{{"$gte", since}}}}
+	if len(kinds) > 0 {
+		for _, kind := range kinds {
+			if err := kind.Validate(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		query = append(query, bson.DocElem{"kind", bson.D{{"$in", kinds}}})
+	}
+	var docs []operationDoc
+	if err := operations.Find(query).Sort("seq").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read operations")
+	}
+	result := make([]OperationRecord, len(docs))
+	for i, doc := range docs {
+		result[i] = OperationRecord{
+			Id:      st.localID(doc.DocId),
+			Kind:    doc.Kind,
+			Summary: doc.Summary,
+			Who:     doc.Who,
+			Ref:     doc.Ref,
+			Time:    doc.Time.UTC(),
+		}
+	}
+	return result, nil
+}
+
+// PruneOperations removes the changes recorded in the model's
+// operations log which are older than the given age.
+func PruneOperations(st *State, maxAge time.Duration) error {
+	err := pruneCollection(st, maxAge, 0, operationsC, "time", GoTime)
+	return errors.Trace(err)
+}
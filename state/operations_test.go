@@ -0,0 +1,134 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/core/migration"
+	"github.com/juju/juju/state"
+)
+
+type OperationsSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&OperationsSuite{})
+
+func (s *OperationsSuite) TestOperations(c *gc.C) {
+	start := s.Clock.Now()
+	unit := s.Factory.MakeUnit(c, nil)
+	action, err := s.Model.EnqueueAction(unit.Tag(), "backup", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.Clock.Advance(time.Hour)
+	upgraded := s.Clock.Now()
+	current, err := s.Model.AgentVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetModelAgentVersion(version.MustParse("4.5.6"), true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Times are recorded to the second.
+	ops, err := s.State.Operations(start.Add(-time.Second))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ops, gc.HasLen, 2)
+	c.Check(ops[0], jc.DeepEquals, state.OperationRecord{
+		Id:      ops[0].Id,
+		Kind:    state.OperationAction,
+		Summary: fmt.Sprintf("run action backup on %s", unit.Name()),
+		Ref:     action.Id(),
+		Time:    start.Round(time.Second).UTC(),
+	})
+	c.Check(ops[1], jc.DeepEquals, state.OperationRecord{
+		Id:      ops[1].Id,
+		Kind:    state.OperationUpgrade,
+		Summary: fmt.Sprintf("upgrade model from %s to 4.5.6", current),
+		Time:    upgraded.Round(time.Second).UTC(),
+	})
+
+	ops, err = s.State.Operations(upgraded.Add(-time.Second))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ops, gc.HasLen, 1)
+	c.Check(ops[0].Kind, gc.Equals, state.OperationUpgrade)
+
+	ops, err = s.State.Operations(time.Time{}, state.OperationAction, state.OperationMigration)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ops, gc.HasLen, 1)
+	c.Check(ops[0].Kind, gc.Equals, state.OperationAction)
+}
+
+func (s *OperationsSuite) TestOperationsInvalidKind(c *gc.C) {
+	_, err := s.State.Operations(s.Clock.Now(), "deploy")
+	c.Assert(err, gc.ErrorMatches, `operation kind "deploy" not valid`)
+}
+
+func (s *OperationsSuite) TestBranchCommit(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	c.Assert(s.Model.AddBranch("new-branch", "bob"), jc.ErrorIsNil)
+	branch, err := s.Model.Branch("new-branch")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(branch.AssignUnit(unit.Name()), jc.ErrorIsNil)
+	c.Assert(branch.Refresh(), jc.ErrorIsNil)
+	genId, err := branch.Commit("mary")
+	c.Assert(err, jc.ErrorIsNil)
+
+	ops, err := s.State.Operations(time.Time{}, state.OperationBranchCommit)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ops, gc.HasLen, 1)
+	c.Check(ops[0].Summary, gc.Equals, `commit branch "new-branch"`)
+	c.Check(ops[0].Who, gc.Equals, "mary")
+	c.Check(ops[0].Ref, gc.Equals, fmt.Sprint(genId))
+}
+
+func (s *OperationsSuite) TestMigration(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	mig, err := st.CreateMigration(state.MigrationSpec{
+		InitiatedBy: names.NewUserTag("admin"),
+		TargetInfo: migration.TargetInfo{
+			ControllerTag:   names.NewControllerTag(utils.MustNewUUID().String()),
+			ControllerAlias: "target-controller",
+			Addrs:           []string{"1.2.3.4:5555"},
+			CACert:          "cert",
+			AuthTag:         names.NewUserTag("user"),
+			Password:        "password",
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	ops, err := st.Operations(time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ops, gc.HasLen, 1)
+	c.Check(ops[0].Kind, gc.Equals, state.OperationMigration)
+	c.Check(ops[0].Summary, gc.Equals, "migrate model to controller target-controller")
+	c.Check(ops[0].Who, gc.Equals, "admin")
+	c.Check(ops[0].Ref, gc.Equals, mig.Id())
+
+	// The operations of other models are not included.
+	ops, err = s.State.Operations(time.Time{}, state.OperationMigration)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ops, gc.HasLen, 0)
+}
+
+func (s *OperationsSuite) TestPruneOperations(c *gc.C) {
+	err := s.State.SetModelAgentVersion(version.MustParse("4.5.6"), true)
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(2 * time.Hour)
+	err = s.State.SetModelAgentVersion(version.MustParse("4.5.7"), true)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = state.PruneOperations(s.State, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	ops, err := s.State.Operations(time.Time{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ops, gc.HasLen, 1)
+	c.Check(ops[0].Summary, gc.Equals, "upgrade model from 4.5.6 to 4.5.7")
+}
@@ -114,6 +114,10 @@ type StatePool struct {
 	// by the state pool.
 	hub *pubsub.SimpleHub
 
+	// mux routes the changes published on the hub to only those
+	// HubWatchers watching the changed collections.
+	mux *watcher.Multiplexer
+
 	// watcherRunner makes sure the TxnWatcher stays running.
 	watcherRunner *worker.Runner
 }
@@ -129,6 +133,19 @@ func OpenStatePool(args OpenParams) (*StatePool, error) {
 		pool: make(map[string]*PoolItem),
 		hub:  pubsub.NewSimpleHub(nil),
 	}
+	mux, err := watcher.NewMultiplexer(watcher.MultiplexerConfig{
+		Hub:    pool.hub,
+		Logger: loggo.GetLogger("juju.state.pool.multiplexer"),
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	pool.mux = mux
+	defer func() {
+		if err != nil {
+			_ = mux.Stop()
+		}
+	}()
 
 	session := args.MongoSession.Copy()
 	st, err := open(
@@ -159,7 +176,7 @@ func OpenStatePool(args OpenParams) (*StatePool, error) {
 			return nil, errors.Trace(mongo.MaybeUnauthorizedf(err, "cannot read model %s", args.ControllerModelTag.Id()))
 		}
 	}
-	if err = st.start(args.ControllerTag, pool.hub); err != nil {
+	if err = st.start(args.ControllerTag, pool.mux); err != nil {
 		return nil, errors.Trace(err)
 	}
 	pool.systemState = st
@@ -259,7 +276,7 @@ func (p *StatePool) openState(modelUUID string) (*State, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	if err := newSt.start(p.systemState.controllerTag, p.hub); err != nil {
+	if err := newSt.start(p.systemState.controllerTag, p.mux); err != nil {
 		return nil, errors.Trace(err)
 	}
 	return newSt, nil
@@ -413,6 +430,9 @@ func (p *StatePool) Close() error {
 	if p.watcherRunner != nil {
 		worker.Stop(p.watcherRunner)
 	}
+	if p.mux != nil {
+		worker.Stop(p.mux)
+	}
 	p.mu.Unlock()
 	// As with above and the other watchers. Unlock while releas
 	if err := p.systemState.Close(); err != nil {
@@ -457,6 +477,7 @@ func (p *StatePool) Report() map[string]interface{} {
 	p.mu.Lock()
 	report := make(map[string]interface{})
 	report["txn-watcher"] = p.watcherRunner.Report()
+	report["multiplexer"] = p.mux.Report()
 	report["system"] = p.systemState.Report()
 	report["pool-size"] = len(p.pool)
 	for uuid, item := range p.pool {
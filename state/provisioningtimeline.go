@@ -0,0 +1,68 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/status"
+)
+
+// RecordProvisioningPhase records in the machine's provisioning
+// timeline that the machine reached the given phase at the given time.
+// A phase is only recorded the first time it is reached with a given
+// message, so restarting an agent or retrying provisioning does not
+// obscure how long the machine originally took to come up.
+func (m *Machine) RecordProvisioningPhase(phase status.ProvisioningPhase, message string, when time.Time) error {
+	if !phase.Valid() {
+		return errors.NotValidf("provisioning phase %q", phase)
+	}
+	history, closer := m.st.db().GetCollection(statusesHistoryC)
+	defer closer()
+
+	globalKey := m.globalProvisioningKey()
+	n, err := history.Find(bson.D{
+		{globalKeyField, globalKey},
+		{"status", status.Status(phase)},
+		{"statusinfo", message},
+	}).Count()
+	if err != nil {
+		return errors.Annotatef(err, "cannot read provisioning timeline of machine %v", m.Id())
+	}
+	if n > 0 {
+		return nil
+	}
+	err = history.Writeable().Insert(&historicalStatusDoc{
+		Status:     status.Status(phase),
+		StatusInfo: message,
+		Updated:    when.UnixNano(),
+		GlobalKey:  globalKey,
+	})
+	return errors.Annotatef(err, "cannot record provisioning phase %q of machine %v", phase, m.Id())
+}
+
+// recordProvisioningPhase records the phase in the machine's
+// provisioning timeline, logging rather than returning any failure
+// since the timeline is only informational.
+func (m *Machine) recordProvisioningPhase(phase status.ProvisioningPhase, message string, when time.Time) {
+	if err := m.RecordProvisioningPhase(phase, message, when); err != nil {
+		logger.Warningf("%v", err)
+	}
+}
+
+// ProvisioningTimeline returns a slice of at most filter.Size StatusInfo
+// items, or items as old as filter.Date or newer than now - filter.Delta,
+// describing the phases of bringing up the machine. The status of each
+// item is the phase reached.
+func (m *Machine) ProvisioningTimeline(filter status.StatusHistoryFilter) ([]status.StatusInfo, error) {
+	args := &statusHistoryArgs{
+		db:        m.st.db(),
+		globalKey: m.globalProvisioningKey(),
+		filter:    filter,
+	}
+	return statusHistory(args)
+}
@@ -0,0 +1,89 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+type ProvisioningTimelineSuite struct {
+	ConnSuite
+	machine *state.Machine
+}
+
+var _ = gc.Suite(&ProvisioningTimelineSuite{})
+
+func (s *ProvisioningTimelineSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.machine = s.Factory.MakeMachine(c, nil)
+}
+
+func (s *ProvisioningTimelineSuite) timeline(c *gc.C) []status.StatusInfo {
+	history, err := s.machine.ProvisioningTimeline(status.StatusHistoryFilter{Size: 100})
+	c.Assert(err, jc.ErrorIsNil)
+	return history
+}
+
+func (s *ProvisioningTimelineSuite) TestRecordProvisioningPhase(c *gc.C) {
+	when := time.Date(2020, 3, 4, 10, 0, 0, 0, time.UTC)
+	err := s.machine.RecordProvisioningPhase(status.PhaseCloudInitDone, "", when)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var found []status.StatusInfo
+	for _, info := range s.timeline(c) {
+		if info.Status == status.Status(status.PhaseCloudInitDone) {
+			found = append(found, info)
+		}
+	}
+	c.Assert(found, gc.HasLen, 1)
+	c.Assert(found[0].Since.Equal(when), jc.IsTrue)
+}
+
+func (s *ProvisioningTimelineSuite) TestRecordProvisioningPhaseOnlyOnce(c *gc.C) {
+	first := time.Date(2020, 3, 4, 10, 0, 0, 0, time.UTC)
+	err := s.machine.RecordProvisioningPhase(status.PhaseCloudInitDone, "", first)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.RecordProvisioningPhase(status.PhaseCloudInitDone, "", first.Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+
+	var found []status.StatusInfo
+	for _, info := range s.timeline(c) {
+		if info.Status == status.Status(status.PhaseCloudInitDone) {
+			found = append(found, info)
+		}
+	}
+	c.Assert(found, gc.HasLen, 1)
+	c.Assert(found[0].Since.Equal(first), jc.IsTrue)
+}
+
+func (s *ProvisioningTimelineSuite) TestRecordProvisioningPhaseInvalid(c *gc.C) {
+	err := s.machine.RecordProvisioningPhase("exploded", "", time.Now())
+	c.Assert(err, gc.ErrorMatches, `provisioning phase "exploded" not valid`)
+}
+
+func (s *ProvisioningTimelineSuite) TestSetInstanceStatusRecordsPhases(c *gc.C) {
+	now := time.Now()
+	err := s.machine.SetInstanceStatus(status.StatusInfo{Status: status.Provisioning, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetInstanceStatus(status.StatusInfo{Status: status.Running, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.machine.SetStatus(status.StatusInfo{Status: status.Started, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+
+	phases := make(map[status.Status]bool)
+	for _, info := range s.timeline(c) {
+		phases[info.Status] = true
+	}
+	c.Assert(phases, jc.DeepEquals, map[status.Status]bool{
+		status.Status(status.PhaseInstanceRequested): true,
+		status.Status(status.PhaseInstanceRunning):   true,
+		status.Status(status.PhaseAgentConnected):    true,
+	})
+}
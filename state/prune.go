@@ -43,6 +43,75 @@ func pruneCollection(mb modelBackend, maxHistoryTime time.Duration, maxHistoryMB
 	return errors.Trace(p.pruneBySize())
 }
 
+// pruneCollectionByAge removes the model's collection entries which
+// match filter and are older than maxAge.
+func pruneCollectionByAge(mb modelBackend, maxAge time.Duration, collectionName, ageField string, timeUnit TimeUnit, filter bson.D) error {
+	entries, closer := mb.db().GetRawCollection(collectionName)
+	defer closer()
+
+	p := collectionPruner{
+		st:       mb,
+		coll:     entries,
+		maxAge:   maxAge,
+		ageField: ageField,
+		timeUnit: timeUnit,
+		filter:   filter,
+	}
+	if err := p.validate(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(p.pruneByAge())
+}
+
+// pruneCollectionPerKey removes the model's collection entries which
+// match filter until at most maxEntries remain for each value of
+// keyField, keeping those with the newest ageField.
+func pruneCollectionPerKey(mb modelBackend, maxEntries int, collectionName, keyField, ageField string, filter bson.D) error {
+	if maxEntries <= 0 {
+		return errors.NotValidf("non-positive max entries")
+	}
+	entries, closer := mb.db().GetRawCollection(collectionName)
+	defer closer()
+
+	var counts []struct {
+		Key   string `bson:"_id"`
+		Count int    `bson:"count"`
+	}
+	err := entries.Pipe([]bson.M{
+		{"$match": append(bson.D{{"model-uuid", mb.modelUUID()}}, filter...)},
+		{"$group": bson.M{"_id": "$" + keyField, "count": bson.M{"$sum": 1}}},
+		{"$match": bson.M{"count": bson.M{"$gt": maxEntries}}},
+	}).All(&counts)
+	if err != nil {
+		return errors.Annotatef(err, "counting %s entries", collectionName)
+	}
+
+	modelName, err := mb.modelName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	deleted := 0
+	for _, key := range counts {
+		logTemplate := fmt.Sprintf("%s per-entity pruning (%s, %s): %%d rows deleted", collectionName, modelName, key.Key)
+		n, err := func() (int, error) {
+			iter := entries.Find(bson.D{
+				{"model-uuid", mb.modelUUID()},
+				{keyField, key.Key},
+			}).Sort("-" + ageField).Skip(maxEntries).Select(bson.M{"_id": 1}).Iter()
+			defer iter.Close()
+			return deleteInBatches(entries, iter, logTemplate, loggo.DEBUG, noEarlyFinish)
+		}()
+		if err != nil {
+			return errors.Annotatef(err, "pruning %s entries for %q", collectionName, key.Key)
+		}
+		deleted += n
+	}
+	if deleted > 0 {
+		logger.Infof("%s per-entity pruning (%s): %d rows deleted", collectionName, modelName, deleted)
+	}
+	return nil
+}
+
 const historyPruneBatchSize = 1000
 const historyPruneProgressSeconds = 15
 
@@ -64,6 +133,10 @@ type collectionPruner struct {
 
 	ageField string
 	timeUnit TimeUnit
+
+	// filter, if set, restricts pruning by age to the entries which
+	// match it.
+	filter bson.D
 }
 
 func (p *collectionPruner) validate() error {
@@ -96,10 +169,11 @@ func (p *collectionPruner) pruneByAge() error {
 		notSet = time.Time{}
 	}
 
-	iter := p.coll.Find(bson.D{
+	query := bson.D{
 		{"model-uuid", p.st.modelUUID()},
 		{p.ageField, bson.M{"$gt": notSet, "$lt": age}},
-	}).Select(bson.M{"_id": 1}).Iter()
+	}
+	iter := p.coll.Find(append(query, p.filter...)).Select(bson.M{"_id": 1}).Iter()
 	defer iter.Close()
 
 	modelName, err := p.st.modelName()
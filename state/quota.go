@@ -0,0 +1,137 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/controller"
+)
+
+// modelQuotas maps each per-model quota controller config key to the
+// collection holding the entities it limits, and their name.
+var modelQuotas = map[string]struct {
+	collection string
+	entity     string
+}{
+	controller.MaxModelApplications: {applicationsC, "applications"},
+	controller.MaxModelUnits:        {unitsC, "units"},
+	controller.MaxModelMachines:     {machinesC, "machines"},
+	controller.MaxModelRelations:    {relationsC, "relations"},
+}
+
+// ErrQuotaExceeded is returned when adding entities to a model would
+// take it over one of the controller's per-model quotas.
+type ErrQuotaExceeded struct {
+	// Model is the UUID of the model.
+	Model string
+
+	// Quota is the controller config key of the quota which would be
+	// exceeded, eg "max-model-units".
+	Quota string
+
+	// Entity names the kind of entity being added, eg "units".
+	Entity string
+
+	// Limit is the value of the quota.
+	Limit int
+
+	// Count is the number of entities the model already has.
+	Count int
+
+	// Adding is the number of entities being added.
+	Adding int
+}
+
+func (e *ErrQuotaExceeded) Error() string {
+	return fmt.Sprintf("cannot add %d %s: model has %d of %d allowed by %s",
+		e.Adding, e.Entity, e.Count, e.Limit, e.Quota)
+}
+
+// IsQuotaExceededError returns if the given error or its cause is
+// ErrQuotaExceeded.
+func IsQuotaExceededError(err interface{}) bool {
+	if err == nil {
+		return false
+	}
+	// In case of a wrapped error, check the cause first.
+	value := err
+	cause := errors.Cause(err.(error))
+	if cause != nil {
+		value = cause
+	}
+	_, ok := value.(*ErrQuotaExceeded)
+	return ok
+}
+
+// CheckModelQuota returns an error satisfying IsQuotaExceededError if
+// adding the given number of entities to the model would exceed the
+// controller's per-model quota named by the given controller config
+// key, eg controller.MaxModelUnits. Entities are counted whatever
+// their life, as dying ones still use the controller's resources.
+//
+// Application.AddUnit, EnsureMinUnits, ChangeScale and SetScale check
+// the units quota themselves. The check isn't made in the same
+// transaction as the addition, so concurrent additions may take a model
+// slightly over its quota.
+func (st *State) CheckModelQuota(quota string, adding int) error {
+	q, ok := modelQuotas[quota]
+	if !ok {
+		return errors.NotValidf("model quota %q", quota)
+	}
+	if adding <= 0 {
+		return nil
+	}
+	cfg, err := st.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	limit := controllerQuotaLimit(cfg, quota)
+	if limit <= 0 {
+		return nil
+	}
+
+	coll, closer := st.db().GetCollection(q.collection)
+	defer closer()
+	count, err := coll.Count()
+	if err != nil {
+		return errors.Annotatef(err, "cannot count %s", q.entity)
+	}
+	if count+adding > limit {
+		return &ErrQuotaExceeded{
+			Model:  st.ModelUUID(),
+			Quota:  quota,
+			Entity: q.entity,
+			Limit:  limit,
+			Count:  count,
+			Adding: adding,
+		}
+	}
+	return nil
+}
+
+// checkScaleQuota returns an error satisfying IsQuotaExceededError if
+// scaling the application to the given number of units would take the
+// model over its units quota. Only the units beyond those the
+// application already has are counted.
+func (a *Application) checkScaleQuota(scale int) error {
+	return a.st.CheckModelQuota(controller.MaxModelUnits, scale-a.doc.UnitCount)
+}
+
+// controllerQuotaLimit returns the value of the named per-model quota.
+func controllerQuotaLimit(cfg controller.Config, quota string) int {
+	switch quota {
+	case controller.MaxModelApplications:
+		return cfg.MaxModelApplications()
+	case controller.MaxModelUnits:
+		return cfg.MaxModelUnits()
+	case controller.MaxModelMachines:
+		return cfg.MaxModelMachines()
+	case controller.MaxModelRelations:
+		return cfg.MaxModelRelations()
+	}
+	return 0
+}
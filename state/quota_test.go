@@ -0,0 +1,100 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+)
+
+type QuotaSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&QuotaSuite{})
+
+func (s *QuotaSuite) setQuota(c *gc.C, quota string, limit int) {
+	err := s.State.UpdateControllerConfig(map[string]interface{}{quota: limit}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *QuotaSuite) TestNoQuota(c *gc.C) {
+	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := s.State.CheckModelQuota(controller.MaxModelApplications, 100)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *QuotaSuite) TestUnknownQuota(c *gc.C) {
+	err := s.State.CheckModelQuota("max-model-spaces", 1)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *QuotaSuite) TestWithinQuota(c *gc.C) {
+	s.setQuota(c, controller.MaxModelMachines, 3)
+	_, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.CheckModelQuota(controller.MaxModelMachines, 2)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *QuotaSuite) TestQuotaExceeded(c *gc.C) {
+	s.setQuota(c, controller.MaxModelUnits, 2)
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	_, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.CheckModelQuota(controller.MaxModelUnits, 2)
+	c.Assert(err, gc.ErrorMatches, `cannot add 2 units: model has 1 of 2 allowed by max-model-units`)
+	c.Assert(err, jc.Satisfies, state.IsQuotaExceededError)
+	c.Assert(*(errors.Cause(err).(*state.ErrQuotaExceeded)), jc.DeepEquals, state.ErrQuotaExceeded{
+		Model:  s.State.ModelUUID(),
+		Quota:  controller.MaxModelUnits,
+		Entity: "units",
+		Limit:  2,
+		Count:  1,
+		Adding: 2,
+	})
+}
+
+func (s *QuotaSuite) TestQuotaCountsOnlyThisModel(c *gc.C) {
+	s.setQuota(c, controller.MaxModelApplications, 1)
+	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+
+	otherState := s.Factory.MakeModel(c, nil)
+	defer otherState.Close()
+	err := otherState.CheckModelQuota(controller.MaxModelApplications, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.CheckModelQuota(controller.MaxModelApplications, 1)
+	c.Assert(err, jc.Satisfies, state.IsQuotaExceededError)
+}
+
+func (s *QuotaSuite) TestAddUnitQuotaExceeded(c *gc.C) {
+	s.setQuota(c, controller.MaxModelUnits, 1)
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	_, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, gc.ErrorMatches, `cannot add unit to application "wordpress": cannot add 1 units: model has 1 of 1 allowed by max-model-units`)
+	c.Assert(err, jc.Satisfies, state.IsQuotaExceededError)
+}
+
+func (s *QuotaSuite) TestEnsureMinUnitsStopsAtQuota(c *gc.C) {
+	s.setQuota(c, controller.MaxModelUnits, 2)
+	app := s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	err := app.SetMinUnits(3)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = app.EnsureMinUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	units, err := app.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(units, gc.HasLen, 2)
+}
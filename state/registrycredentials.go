@@ -0,0 +1,243 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/docker/distribution/reference"
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// RegistryCredential is the credential used to pull OCI images from a
+// private registry for the applications in a model.
+type RegistryCredential struct {
+	// Registry is the host, and optional port, of the registry as it
+	// appears in image paths; Docker Hub is "docker.io".
+	Registry string
+
+	// Username and Password authenticate with the registry. The
+	// password is often a token which has to be rotated.
+	Username string
+	Password string
+
+	// Expiry is when the password stops being accepted by the
+	// registry, or zero if it does not expire.
+	Expiry time.Time
+
+	// UpdatedBy is the name of the user who last set the credential.
+	UpdatedBy string
+
+	// Updated is when the credential was last set.
+	Updated time.Time
+}
+
+// Expired reports whether the credential has expired at the given time.
+func (c RegistryCredential) Expired(now time.Time) bool {
+	return !c.Expiry.IsZero() && !now.Before(c.Expiry)
+}
+
+// registryCredentialDoc records the credential for one registry used
+// by a model.
+type registryCredentialDoc struct {
+	DocID     string    `bson:"_id"`
+	ModelUUID string    `bson:"model-uuid"`
+	Registry  string    `bson:"registry"`
+	Username  string    `bson:"username"`
+	Password  string    `bson:"password"`
+	Expiry    time.Time `bson:"expiry,omitempty"`
+	UpdatedBy string    `bson:"updated-by"`
+	Updated   time.Time `bson:"updated"`
+}
+
+func (doc registryCredentialDoc) registryCredential() RegistryCredential {
+	cred := RegistryCredential{
+		Registry:  doc.Registry,
+		Username:  doc.Username,
+		Password:  doc.Password,
+		UpdatedBy: doc.UpdatedBy,
+		Updated:   doc.Updated.UTC(),
+	}
+	if !doc.Expiry.IsZero() {
+		cred.Expiry = doc.Expiry.UTC()
+	}
+	return cred
+}
+
+// ImageRegistry returns the registry, as used to key the registry
+// credentials of a model, which holds the image with the given path.
+func ImageRegistry(imagePath string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(imagePath)
+	if err != nil {
+		return "", errors.NotValidf("image path %q", imagePath)
+	}
+	return reference.Domain(named), nil
+}
+
+func validateRegistry(registry string) error {
+	if registry == "" {
+		return errors.NotValidf("empty registry")
+	}
+	// A registry is the domain of the image paths it holds; names
+	// which would be taken as part of an image's path aren't valid.
+	domain, err := ImageRegistry(registry + "/image")
+	if err != nil || domain != registry {
+		return errors.NotValidf("registry %q", registry)
+	}
+	return nil
+}
+
+// SetRegistryCredential adds the credential for a registry to the
+// model, or replaces the existing one, which is how credentials are
+// rotated.
+func (st *State) SetRegistryCredential(cred RegistryCredential, updatedBy string) error {
+	if err := validateRegistry(cred.Registry); err != nil {
+		return errors.Trace(err)
+	}
+	if cred.Username == "" {
+		return errors.NotValidf("empty username for registry %q", cred.Registry)
+	}
+	if cred.Password == "" {
+		return errors.NotValidf("empty password for registry %q", cred.Registry)
+	}
+	model, err := st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	doc := registryCredentialDoc{
+		DocID:     st.docID(cred.Registry),
+		ModelUUID: st.ModelUUID(),
+		Registry:  cred.Registry,
+		Username:  cred.Username,
+		Password:  cred.Password,
+		Expiry:    cred.Expiry,
+		UpdatedBy: updatedBy,
+		Updated:   st.nowToTheSecond(),
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := model.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if model.Life() != Alive {
+			return nil, errors.New("model is no longer alive")
+		}
+		ops := []txn.Op{model.assertActiveOp()}
+		_, err := st.RegistryCredential(cred.Registry)
+		if errors.IsNotFound(err) {
+			return append(ops, txn.Op{
+				C:      registryCredentialsC,
+				Id:     doc.DocID,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			}), nil
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		set := bson.D{
+			{"username", doc.Username},
+			{"password", doc.Password},
+			{"updated-by", doc.UpdatedBy},
+			{"updated", doc.Updated},
+		}
+		var unset bson.D
+		if doc.Expiry.IsZero() {
+			unset = bson.D{{"expiry", nil}}
+		} else {
+			set = append(set, bson.DocElem{"expiry", doc.Expiry})
+		}
+		update := bson.D{{"$set", set}}
+		if len(unset) > 0 {
+			update = append(update, bson.DocElem{"$unset", unset})
+		}
+		return append(ops, txn.Op{
+			C:      registryCredentialsC,
+			Id:     doc.DocID,
+			Assert: txn.DocExists,
+			Update: update,
+		}), nil
+	}
+	err = st.db().Run(jujutxn.TransactionSource(buildTxn))
+	return errors.Annotatef(err, "cannot set credential for registry %q", cred.Registry)
+}
+
+// RemoveRegistryCredential removes the credential for a registry from
+// the model.
+func (st *State) RemoveRegistryCredential(registry string) error {
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if _, err := st.RegistryCredential(registry); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return []txn.Op{{
+			C:      registryCredentialsC,
+			Id:     st.docID(registry),
+			Assert: txn.DocExists,
+			Remove: true,
+		}}, nil
+	}
+	err := st.db().Run(jujutxn.TransactionSource(buildTxn))
+	return errors.Annotatef(err, "cannot remove credential for registry %q", registry)
+}
+
+// RegistryCredential returns the model's credential for the given
+// registry, or a NotFound error if it has none.
+func (st *State) RegistryCredential(registry string) (RegistryCredential, error) {
+	creds, closer := st.db().GetCollection(registryCredentialsC)
+	defer closer()
+
+	var doc registryCredentialDoc
+	if err := creds.FindId(registry).One(&doc); err == mgo.ErrNotFound {
+		return RegistryCredential{}, errors.NotFoundf("credential for registry %q", registry)
+	} else if err != nil {
+		return RegistryCredential{}, errors.Annotate(err, "cannot read registry credential")
+	}
+	return doc.registryCredential(), nil
+}
+
+// RegistryCredentials returns the model's registry credentials,
+// ordered by registry.
+func (st *State) RegistryCredentials() ([]RegistryCredential, error) {
+	creds, closer := st.db().GetCollection(registryCredentialsC)
+	defer closer()
+
+	var docs []registryCredentialDoc
+	if err := creds.Find(nil).Sort("registry").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read registry credentials")
+	}
+	result := make([]RegistryCredential, len(docs))
+	for i, doc := range docs {
+		result[i] = doc.registryCredential()
+	}
+	return result, nil
+}
+
+// WatchRegistryCredentials returns a NotifyWatcher which triggers
+// whenever a registry credential of the model is set or removed.
+func (st *State) WatchRegistryCredentials() NotifyWatcher {
+	return newNotifyCollWatcher(st, registryCredentialsC, isLocalID(st))
+}
+
+// imageCredential returns the unexpired credential of the model for
+// the registry holding the image with the given path, if there is one.
+func (st *State) imageCredential(imagePath string) (RegistryCredential, bool, error) {
+	registry, err := ImageRegistry(imagePath)
+	if err != nil {
+		return RegistryCredential{}, false, nil
+	}
+	cred, err := st.RegistryCredential(registry)
+	if errors.IsNotFound(err) {
+		return RegistryCredential{}, false, nil
+	} else if err != nil {
+		return RegistryCredential{}, false, errors.Trace(err)
+	}
+	if cred.Expired(st.clock().Now()) {
+		return RegistryCredential{}, false, nil
+	}
+	return cred, true, nil
+}
@@ -0,0 +1,180 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type RegistryCredentialsSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&RegistryCredentialsSuite{})
+
+func (s *RegistryCredentialsSuite) TestNoCredentials(c *gc.C) {
+	creds, err := s.State.RegistryCredentials()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(creds, gc.HasLen, 0)
+	_, err = s.State.RegistryCredential("docker.io")
+	c.Assert(err, gc.ErrorMatches, `credential for registry "docker.io" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RegistryCredentialsSuite) TestSetRegistryCredential(c *gc.C) {
+	expiry := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	err := s.State.SetRegistryCredential(state.RegistryCredential{
+		Registry: "registry.example.com:5000",
+		Username: "bob",
+		Password: "secret",
+		Expiry:   expiry,
+	}, "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetRegistryCredential(state.RegistryCredential{
+		Registry: "docker.io",
+		Username: "mary",
+		Password: "token",
+	}, "admin")
+	c.Assert(err, jc.ErrorIsNil)
+
+	creds, err := s.State.RegistryCredentials()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(creds, gc.HasLen, 2)
+	c.Check(creds[0].Registry, gc.Equals, "docker.io")
+	c.Check(creds[0].Expiry.IsZero(), jc.IsTrue)
+	c.Check(creds[1], jc.DeepEquals, state.RegistryCredential{
+		Registry:  "registry.example.com:5000",
+		Username:  "bob",
+		Password:  "secret",
+		Expiry:    expiry,
+		UpdatedBy: "admin",
+		Updated:   creds[1].Updated,
+	})
+	c.Check(creds[1].Updated.IsZero(), jc.IsFalse)
+}
+
+func (s *RegistryCredentialsSuite) TestRotateRegistryCredential(c *gc.C) {
+	err := s.State.SetRegistryCredential(state.RegistryCredential{
+		Registry: "docker.io",
+		Username: "bob",
+		Password: "old",
+		Expiry:   time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+	}, "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetRegistryCredential(state.RegistryCredential{
+		Registry: "docker.io",
+		Username: "bob",
+		Password: "new",
+	}, "mary")
+	c.Assert(err, jc.ErrorIsNil)
+
+	cred, err := s.State.RegistryCredential("docker.io")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cred.Password, gc.Equals, "new")
+	c.Check(cred.UpdatedBy, gc.Equals, "mary")
+	c.Check(cred.Expiry.IsZero(), jc.IsTrue)
+}
+
+func (s *RegistryCredentialsSuite) TestSetRegistryCredentialInvalid(c *gc.C) {
+	for _, t := range []struct {
+		cred   state.RegistryCredential
+		expect string
+	}{{
+		cred:   state.RegistryCredential{Username: "bob", Password: "secret"},
+		expect: `empty registry not valid`,
+	}, {
+		cred:   state.RegistryCredential{Registry: "example", Username: "bob", Password: "secret"},
+		expect: `registry "example" not valid`,
+	}, {
+		cred:   state.RegistryCredential{Registry: "example.com/team", Username: "bob", Password: "secret"},
+		expect: `registry "example.com/team" not valid`,
+	}, {
+		cred:   state.RegistryCredential{Registry: "example.com", Password: "secret"},
+		expect: `empty username for registry "example.com" not valid`,
+	}, {
+		cred:   state.RegistryCredential{Registry: "example.com", Username: "bob"},
+		expect: `empty password for registry "example.com" not valid`,
+	}} {
+		err := s.State.SetRegistryCredential(t.cred, "admin")
+		c.Check(err, gc.ErrorMatches, t.expect)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *RegistryCredentialsSuite) TestRemoveRegistryCredential(c *gc.C) {
+	err := s.State.SetRegistryCredential(state.RegistryCredential{
+		Registry: "docker.io",
+		Username: "bob",
+		Password: "secret",
+	}, "admin")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveRegistryCredential("docker.io")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.RegistryCredential("docker.io")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.RemoveRegistryCredential("docker.io")
+	c.Assert(err, gc.ErrorMatches, `cannot remove credential for registry "docker.io": credential for registry "docker.io" not found`)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RegistryCredentialsSuite) TestRegistryCredentialsAreLocalToModel(c *gc.C) {
+	err := s.State.SetRegistryCredential(state.RegistryCredential{
+		Registry: "docker.io",
+		Username: "bob",
+		Password: "secret",
+	}, "admin")
+	c.Assert(err, jc.ErrorIsNil)
+
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	creds, err := st.RegistryCredentials()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(creds, gc.HasLen, 0)
+}
+
+func (s *RegistryCredentialsSuite) TestWatchRegistryCredentials(c *gc.C) {
+	w := s.State.WatchRegistryCredentials()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.State.SetRegistryCredential(state.RegistryCredential{
+		Registry: "docker.io",
+		Username: "bob",
+		Password: "secret",
+	}, "admin")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	err = s.State.RemoveRegistryCredential("docker.io")
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	wc.AssertNoChange()
+}
+
+func (s *RegistryCredentialsSuite) TestImageRegistry(c *gc.C) {
+	for path, expect := range map[string]string{
+		"mysql":                                "docker.io",
+		"jujusolutions/jujud-operator:2.8":     "docker.io",
+		"registry.example.com:5000/team/image": "registry.example.com:5000",
+		"localhost/image@sha256:" + sha256Hex:  "localhost",
+	} {
+		registry, err := state.ImageRegistry(path)
+		c.Check(err, jc.ErrorIsNil)
+		c.Check(registry, gc.Equals, expect, gc.Commentf("image %q", path))
+	}
+	_, err := state.ImageRegistry("Not An Image")
+	c.Assert(err, gc.ErrorMatches, `image path "Not An Image" not valid`)
+}
+
+const sha256Hex = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
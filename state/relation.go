@@ -49,6 +49,7 @@ type relationDoc struct {
 	UnitCount       int        `bson:"unitcount"`
 	Suspended       bool       `bson:"suspended"`
 	SuspendedReason string     `bson:"suspended-reason"`
+	DrainDeadline   int64      `bson:"drain-deadline,omitempty"`
 }
 
 // Relation represents a relation between one or two application endpoints.
@@ -108,6 +109,17 @@ func (r *Relation) Refresh() error {
 	return nil
 }
 
+// DrainDeadline returns the time by which the units of the removed
+// relation must have run their departure hooks and left the relation,
+// after which they are forced out. It returns false if the relation is
+// not being drained.
+func (r *Relation) DrainDeadline() (time.Time, bool) {
+	if r.doc.DrainDeadline == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(0, r.doc.DrainDeadline).UTC(), true
+}
+
 // Life returns the relation's current life state.
 func (r *Relation) Life() Life {
 	return r.doc.Life
@@ -266,6 +278,11 @@ type DestroyRelationOperation struct {
 
 	// r holds the relation to destroy.
 	r *Relation
+
+	// DrainTimeout, if set, overrides the model's relation-drain-timeout
+	// for this relation. The units in the relation are given this long
+	// to run their departure hooks before they are forced out of it.
+	DrainTimeout *time.Duration
 }
 
 // Build is part of the ModelOperation interface.
@@ -383,7 +400,11 @@ func (op *DestroyRelationOperation) internalDestroy() (ops []txn.Op, err error)
 	// When 'force' is set, this call will return  needed operations
 	// and accumulate all operational errors encountered in the operation.
 	// If the 'force' is not set, any error will be fatal and no operations will be returned.
-	destroyOps, _, err := rel.destroyOps("", &op.ForcedOperation)
+	drain, err := op.drainTimeout()
+	if op.FatalError(err) {
+		return nil, errors.Trace(err)
+	}
+	destroyOps, _, err := rel.destroyOps("", drain, &op.ForcedOperation)
 	if err == errAlreadyDying {
 		return nil, jujutxn.ErrNoOperations
 	} else if op.FatalError(err) {
@@ -392,15 +413,33 @@ func (op *DestroyRelationOperation) internalDestroy() (ops []txn.Op, err error)
 	return append(ops, destroyOps...), nil
 }
 
+// drainTimeout returns how long the units in the relation are given to
+// depart it before they are forced out.
+func (op *DestroyRelationOperation) drainTimeout() (time.Duration, error) {
+	if op.DrainTimeout != nil {
+		return *op.DrainTimeout, nil
+	}
+	model, err := op.r.st.Model()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	cfg, err := model.ModelConfig()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return cfg.RelationDrainTimeout(), nil
+}
+
 // destroyOps returns the operations necessary to destroy the relation, and
 // whether those operations will lead to the relation's removal. These
 // operations may include changes to the relation's applications; however, if
 // ignoreApplication is not empty, no operations modifying that application will
-// be generated.
+// be generated. If drain is non-zero and the relation has units in scope,
+// they are given that long to leave the relation before being forced out.
 // When 'force' is set, this call will return both operations to remove this
 // relation as well as all operational errors encountered.
 // If the 'force' is not set, any error will be fatal and no operations will be returned.
-func (r *Relation) destroyOps(ignoreApplication string, op *ForcedOperation) (ops []txn.Op, isRemove bool, err error) {
+func (r *Relation) destroyOps(ignoreApplication string, drain time.Duration, op *ForcedOperation) (ops []txn.Op, isRemove bool, err error) {
 	if r.doc.Life != Alive {
 		if !op.Force {
 			return nil, false, errAlreadyDying
@@ -420,19 +459,33 @@ func (r *Relation) destroyOps(ignoreApplication string, op *ForcedOperation) (op
 		return removeOps, true, nil
 	}
 
+	now := r.st.stateClock.Now()
 	lifeAssert := isAliveDoc
+	update := bson.D{{"life", Dying}}
+	var forceAt time.Time
+	if drain > 0 && r.doc.Life == Alive {
+		// The units in scope are given until the drain deadline to
+		// run their departure hooks; any still in scope after that
+		// are forced out, even if the relation was removed with force.
+		forceAt = now.Add(drain)
+		update = append(update, bson.DocElem{"drain-deadline", forceAt.UnixNano()})
+	}
 	if op.Force {
 		// Since we are force destroying, life assert should be current relation's life.
 		lifeAssert = bson.D{{"life", r.doc.Life}}
-		deadline := r.st.stateClock.Now().Add(op.MaxWait)
-		ops = append(ops, newCleanupAtOp(deadline, cleanupForceDestroyedRelation, relationKey(r.Endpoints())))
+		if deadline := now.Add(op.MaxWait); deadline.After(forceAt) {
+			forceAt = deadline
+		}
+	}
+	if !forceAt.IsZero() {
+		ops = append(ops, newCleanupAtOp(forceAt, cleanupForceDestroyedRelation, relationKey(r.Endpoints())))
 	}
 
 	ops = append(ops, txn.Op{
 		C:      relationsC,
 		Id:     r.doc.DocID,
 		Assert: append(bson.D{{"unitcount", bson.D{{"$gt", 0}}}}, lifeAssert...),
-		Update: bson.D{{"$set", bson.D{{"life", Dying}}}},
+		Update: bson.D{{"$set", update}},
 	})
 	return ops, false, nil
 }
@@ -1146,6 +1146,90 @@ func (s *RelationSuite) TestDestroyForceIsFineIfUnitsAlreadyLeft(c *gc.C) {
 	s.assertNoCleanups(c)
 }
 
+func (s *RelationSuite) TestDestroyWithDrainTimeout(c *gc.C) {
+	prr := newProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	prr.allEnterScope(c)
+	rel := prr.rel
+	relUnits := []*state.RelationUnit{
+		prr.pru0, prr.pru1, prr.rru0, prr.rru1,
+	}
+
+	drain := 10 * time.Minute
+	op := rel.DestroyOperation(false)
+	op.DrainTimeout = &drain
+	err := s.State.ApplyOperation(op)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = rel.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rel.Life(), gc.Equals, state.Dying)
+	deadline, ok := rel.DrainDeadline()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(deadline.Equal(s.Clock.Now().Add(drain)), jc.IsTrue)
+
+	// The units are left to depart until the deadline.
+	s.assertNeedsCleanup(c)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, ru := range relUnits {
+		assertJoined(c, ru)
+	}
+
+	s.Clock.Advance(drain)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, ru := range relUnits {
+		assertNotInScope(c, ru)
+	}
+	err = rel.Refresh()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *RelationSuite) TestDestroyForceWaitsForModelDrainTimeout(c *gc.C) {
+	err := s.Model.UpdateModelConfig(map[string]interface{}{
+		"relation-drain-timeout": "5m",
+	}, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	prr := newProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	prr.allEnterScope(c)
+	rel := prr.rel
+	relUnits := []*state.RelationUnit{
+		prr.pru0, prr.pru1, prr.rru0, prr.rru1,
+	}
+
+	opErrs, err := rel.DestroyWithForce(true, time.Minute)
+	c.Assert(opErrs, gc.HasLen, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The max wait has passed, but the drain window has not.
+	s.Clock.Advance(time.Minute)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, ru := range relUnits {
+		assertJoined(c, ru)
+	}
+
+	s.Clock.Advance(4 * time.Minute)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, ru := range relUnits {
+		assertNotInScope(c, ru)
+	}
+}
+
+func (s *RelationSuite) TestDestroyWithoutDrainTimeout(c *gc.C) {
+	prr := newProReqRelation(c, &s.ConnSuite, charm.ScopeGlobal)
+	prr.allEnterScope(c)
+	rel := prr.rel
+
+	err := rel.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = rel.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := rel.DrainDeadline()
+	c.Assert(ok, jc.IsFalse)
+}
+
 func (s *RelationSuite) assertRelationCleanedUp(c *gc.C, rel *state.Relation, relUnits []*state.RelationUnit) {
 	opErrs, err := rel.DestroyWithForce(true, time.Minute)
 	c.Assert(opErrs, gc.HasLen, 0)
@@ -0,0 +1,163 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// maxRelationSettingsHistory is the number of changes recorded in the
+// settings history of each relation. Older changes are removed as new
+// ones are recorded.
+const maxRelationSettingsHistory = 100
+
+// RelationSettingsChange describes a change made by a unit to one of
+// the data bags of a relation.
+type RelationSettingsChange struct {
+	// Unit is the name of the unit which made the change.
+	Unit string
+
+	// Application is true if the change was made to the data bag of the
+	// unit's application, rather than to the unit's own data bag.
+	Application bool
+
+	// Settings holds the keys which were changed, along with their new
+	// values. Keys which were deleted have empty values.
+	Settings map[string]string
+
+	// Time is when the change was made.
+	Time time.Time
+}
+
+// relationSettingsHistoryDoc records a change made to the data bags
+// of a relation.
+type relationSettingsHistoryDoc struct {
+	ModelUUID   string                        `bson:"model-uuid"`
+	GlobalKey   string                        `bson:"globalkey"`
+	Unit        string                        `bson:"unit"`
+	Application bool                          `bson:"application,omitempty"`
+	Settings    []relationSettingsHistoryItem `bson:"settings"`
+	Updated     int64                         `bson:"updated"`
+}
+
+// relationSettingsHistoryItem holds a changed relation setting. The
+// settings are held in a list rather than a map so that their keys
+// needn't be escaped.
+type relationSettingsHistoryItem struct {
+	Key   string `bson:"key"`
+	Value string `bson:"value"`
+}
+
+// RecordSettingsChange records a change made by the named unit to the
+// relation's data bags in the relation's settings history. Only the
+// most recent changes to each relation are kept.
+func (r *Relation) RecordSettingsChange(unitName string, application bool, settings map[string]string) error {
+	if len(settings) == 0 {
+		return nil
+	}
+	items := make([]relationSettingsHistoryItem, 0, len(settings))
+	for key, value := range settings {
+		items = append(items, relationSettingsHistoryItem{Key: key, Value: value})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Key < items[j].Key
+	})
+	doc := &relationSettingsHistoryDoc{
+		GlobalKey:   r.globalScope(),
+		Unit:        unitName,
+		Application: application,
+		Settings:    items,
+		Updated:     r.st.clock().Now().UnixNano(),
+	}
+
+	history, closer := r.st.db().GetCollection(relationSettingsHistoryC)
+	defer closer()
+
+	historyW := history.Writeable()
+	if err := historyW.Insert(doc); err != nil {
+		return errors.Annotatef(err, "cannot record settings history of relation %q", r)
+	}
+
+	var old []struct {
+		ID bson.ObjectId `bson:"_id"`
+	}
+	err := history.Find(bson.D{{globalKeyField, doc.GlobalKey}}).
+		Sort("-updated", "-_id").
+		Skip(maxRelationSettingsHistory).
+		Select(bson.D{{"_id", 1}}).
+		All(&old)
+	if err != nil {
+		return errors.Annotatef(err, "cannot read settings history of relation %q", r)
+	}
+	if len(old) == 0 {
+		return nil
+	}
+	ids := make([]bson.ObjectId, len(old))
+	for i, doc := range old {
+		ids[i] = doc.ID
+	}
+	if _, err := historyW.RemoveAll(bson.D{{"_id", bson.D{{"$in", ids}}}}); err != nil {
+		return errors.Annotatef(err, "cannot prune settings history of relation %q", r)
+	}
+	return nil
+}
+
+// SettingsHistory returns the recorded changes made to the relation's
+// data bags, most recent first.
+func (r *Relation) SettingsHistory() ([]RelationSettingsChange, error) {
+	history, closer := r.st.db().GetCollection(relationSettingsHistoryC)
+	defer closer()
+
+	var docs []relationSettingsHistoryDoc
+	err := history.Find(bson.D{{globalKeyField, r.globalScope()}}).Sort("-updated", "-_id").All(&docs)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot read settings history of relation %q", r)
+	}
+	result := make([]RelationSettingsChange, len(docs))
+	for i, doc := range docs {
+		settings := make(map[string]string, len(doc.Settings))
+		for _, item := range doc.Settings {
+			settings[item.Key] = item.Value
+		}
+		result[i] = RelationSettingsChange{
+			Unit:        doc.Unit,
+			Application: doc.Application,
+			Settings:    settings,
+			Time:        time.Unix(0, doc.Updated).UTC(),
+		}
+	}
+	return result, nil
+}
+
+// eraseRelationSettingsHistory removes all settings history documents
+// for the relation with the given global key.
+func eraseRelationSettingsHistory(mb modelBackend, globalKey string) error {
+	history, closer := mb.db().GetCollection(relationSettingsHistoryC)
+	defer closer()
+
+	iter := history.Find(bson.D{{
+		globalKeyField, globalKey,
+	}}).Select(bson.M{"_id": 1}).Iter()
+	defer iter.Close()
+
+	logFormat := "deleted %d relation settings history documents for " + fmt.Sprintf("%q", globalKey)
+	deleted, err := deleteInBatches(
+		history.Writeable().Underlying(), iter,
+		logFormat, loggo.DEBUG,
+		noEarlyFinish,
+	)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if deleted > 0 {
+		logger.Debugf(logFormat, deleted)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"fmt"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+type RelationSettingsHistorySuite struct {
+	ConnSuite
+	relation *state.Relation
+}
+
+var _ = gc.Suite(&RelationSettingsHistorySuite{})
+
+func (s *RelationSettingsHistorySuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.AddTestingApplication(c, "wordpress", s.AddTestingCharm(c, "wordpress"))
+	s.AddTestingApplication(c, "mysql", s.AddTestingCharm(c, "mysql"))
+	eps, err := s.State.InferEndpoints("wordpress", "mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	s.relation, err = s.State.AddRelation(eps...)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *RelationSettingsHistorySuite) TestSettingsHistoryEmpty(c *gc.C) {
+	history, err := s.relation.SettingsHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
+
+func (s *RelationSettingsHistorySuite) TestRecordSettingsChange(c *gc.C) {
+	start := s.Clock.Now()
+	err := s.relation.RecordSettingsChange("mysql/0", false, map[string]string{
+		"host":     "10.0.0.1",
+		"password": "secret",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Minute)
+	err = s.relation.RecordSettingsChange("mysql/0", true, map[string]string{
+		"password": "",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	// Changes which change nothing aren't recorded.
+	err = s.relation.RecordSettingsChange("wordpress/0", false, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.relation.SettingsHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, jc.DeepEquals, []state.RelationSettingsChange{{
+		Unit:        "mysql/0",
+		Application: true,
+		Settings:    map[string]string{"password": ""},
+		Time:        start.Add(time.Minute).UTC(),
+	}, {
+		Unit:     "mysql/0",
+		Settings: map[string]string{"host": "10.0.0.1", "password": "secret"},
+		Time:     start.UTC(),
+	}})
+}
+
+func (s *RelationSettingsHistorySuite) TestRecordSettingsChangeKeepsMostRecent(c *gc.C) {
+	for i := 0; i < state.MaxRelationSettingsHistory+5; i++ {
+		err := s.relation.RecordSettingsChange("mysql/0", false, map[string]string{
+			"seq": fmt.Sprint(i),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		s.Clock.Advance(time.Second)
+	}
+
+	history, err := s.relation.SettingsHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, state.MaxRelationSettingsHistory)
+	c.Assert(history[0].Settings["seq"], gc.Equals, fmt.Sprint(state.MaxRelationSettingsHistory+4))
+	c.Assert(history[len(history)-1].Settings["seq"], gc.Equals, "5")
+}
+
+func (s *RelationSettingsHistorySuite) TestSettingsHistoryRemovedWithRelation(c *gc.C) {
+	err := s.relation.RecordSettingsChange("mysql/0", false, map[string]string{"host": "10.0.0.1"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.relation.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.relation.SettingsHistory()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 0)
+}
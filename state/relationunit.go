@@ -40,6 +40,11 @@ func (ru *RelationUnit) Relation() *Relation {
 	return ru.relation
 }
 
+// UnitName returns the name of the unit.
+func (ru *RelationUnit) UnitName() string {
+	return ru.unitName
+}
+
 // Endpoint returns the relation endpoint that defines the unit's
 // participation in the relation.
 func (ru *RelationUnit) Endpoint() Endpoint {
@@ -146,6 +151,15 @@ func (ru *RelationUnit) EnterScope(settings map[string]interface{}) error {
 		ops = append(ops, subOps...)
 	}
 
+	// * Record the unit joining the relation in the model's events.
+	eventOp, err := addModelEventOp(ru.st, ModelEventRelationJoined, names.NewUnitTag(ru.unitName).String(), map[string]string{
+		"relation": ru.relation.String(),
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	ops = append(ops, eventOp)
+
 	// Now run the complete transaction, or figure out why we can't.
 	if err := ru.st.db().RunTransaction(ops); err != txn.ErrAborted {
 		return err
@@ -397,7 +397,7 @@ func (op *DestroyRemoteApplicationOperation) destroyOps() (ops []txn.Op, err err
 			// When 'force' is set, this call will return both needed operations
 			// as well as all operational errors encountered.
 			// If the 'force' is not set, any error will be fatal and no operations will be returned.
-			relOps, isRemove, err := rel.destroyOps(op.app.doc.Name, &op.ForcedOperation)
+			relOps, isRemove, err := rel.destroyOps(op.app.doc.Name, 0, &op.ForcedOperation)
 			if err == errAlreadyDying {
 				relOps = []txn.Op{{
 					C:      relationsC,
@@ -58,6 +58,16 @@ type Resources interface {
 	// resources for a failed application deployment.
 	RemovePendingAppResources(applicationID string, pendingIDs map[string]string) error
 
+	// ResourceHistory returns the revisions of the content attached to
+	// the identified resource, newest first. Previous revisions are
+	// kept, until pruned, so that the application can be rolled back to
+	// them.
+	ResourceHistory(applicationID, name string) ([]resource.AttachedRevision, error)
+
+	// RollbackResource makes the identified previous revision of the
+	// content attached to the resource the one the application uses.
+	RollbackResource(applicationID, name string, revision int) (resource.Resource, error)
+
 	// TODO(ericsnow) Move this down to ResourcesPersistence.
 
 	// NewResolvePendingResourcesOps generates mongo transaction operations
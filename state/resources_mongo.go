@@ -5,6 +5,7 @@ package state
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	"github.com/juju/errors"
@@ -47,6 +48,10 @@ func unitResourceID(id, unitID string) string {
 	return resourceID(id, "unit", unitID)
 }
 
+func historyResourceID(id string, revision int) string {
+	return resourceID(id, "history", strconv.Itoa(revision))
+}
+
 // stagedResourceID converts an external resource ID into an internal
 // staged one.
 func stagedResourceID(id string) string {
@@ -59,6 +64,10 @@ type storedResource struct {
 
 	// storagePath is the path to where the resource content is stored.
 	storagePath string
+
+	// historyRevision is the number of the revision of the content
+	// attached to the application resource.
+	historyRevision int
 }
 
 // charmStoreResource holds the info for a resource as provided by the
@@ -136,6 +145,7 @@ func resourceDocToUpdateOp(doc *resourceDoc) bson.M {
 		"storage-path":               doc.StoragePath,
 		"download-progress":          doc.DownloadProgress,
 		"timestamp-when-last-polled": doc.LastPolled,
+		"history-revision":           doc.HistoryRevision,
 	}}
 }
 
@@ -351,6 +361,13 @@ type resourceDoc struct {
 	DownloadProgress *int64 `bson:"download-progress,omitempty"`
 
 	LastPolled time.Time `bson:"timestamp-when-last-polled"`
+
+	// HistoryRevision numbers the revisions of the content attached
+	// to an application resource. History is set on the docs holding
+	// previous revisions, which are kept so that the application can
+	// be rolled back to them.
+	HistoryRevision int  `bson:"history-revision,omitempty"`
+	History         bool `bson:"history,omitempty"`
 }
 
 func charmStoreResource2Doc(id string, res charmStoreResource) *resourceDoc {
@@ -398,6 +415,8 @@ func resource2doc(id string, stored storedResource) *resourceDoc {
 		Timestamp: res.Timestamp,
 
 		StoragePath: stored.storagePath,
+
+		HistoryRevision: stored.historyRevision,
 	}
 }
 
@@ -409,8 +428,9 @@ func doc2resource(doc resourceDoc) (storedResource, error) {
 	}
 
 	stored := storedResource{
-		Resource:    res,
-		storagePath: doc.StoragePath,
+		Resource:        res,
+		storagePath:     doc.StoragePath,
+		historyRevision: doc.HistoryRevision,
 	}
 	return stored, nil
 }
@@ -69,7 +69,7 @@ func (p ResourcePersistence) ListResources(applicationID string) (resource.Appli
 
 	var results resource.ApplicationResources
 	for _, doc := range docs {
-		if doc.PendingID != "" {
+		if doc.PendingID != "" || doc.History {
 			continue
 		}
 
@@ -359,15 +359,20 @@ func (p ResourcePersistence) NewResolvePendingResourceOps(resID, pendingID strin
 		return nil, errors.Trace(err)
 	}
 
-	exists := true
-	if _, err := p.getOne(resID); errors.IsNotFound(err) {
-		exists = false
-	} else if err != nil {
+	var current *resourceDoc
+	if doc, err := p.getOne(resID); err == nil {
+		current = &doc
+	} else if !errors.IsNotFound(err) {
 		return nil, errors.Trace(err)
 	}
+	revision, revisionOps, err := newAttachRevisionOps(p.base, current, pending)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	pending.historyRevision = revision
 
-	ops := newResolvePendingResourceOps(pending, exists)
-	return ops, nil
+	ops := newResolvePendingResourceOps(pending, current != nil)
+	return append(ops, revisionOps...), nil
 }
 
 // NewRemoveUnitResourcesOps returns mgo transaction operations
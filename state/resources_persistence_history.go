@@ -0,0 +1,237 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	charmresource "gopkg.in/juju/charm.v6/resource"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/resource"
+)
+
+// maxResourceHistory is the number of previous revisions of an
+// application resource which are kept so that the application can be
+// rolled back to them. Older revisions are pruned, and their content
+// removed, as new content is attached.
+const maxResourceHistory = 5
+
+// ListResourceHistory returns the revisions of the content attached to
+// the identified application resource, newest first. These are the
+// current revision, if there is any content, and the previous ones the
+// application can be rolled back to.
+func (p ResourcePersistence) ListResourceHistory(id string) ([]resource.AttachedRevision, error) {
+	current, err := p.getOne(id)
+	if errors.IsNotFound(err) {
+		return nil, errors.NotFoundf("resource %q", id)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	history, err := resourceHistoryDocs(p.base, current.ApplicationID, id)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var revisions []resource.AttachedRevision
+	if hasAttachedContent(current) {
+		res, err := doc2basicResource(current)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		revisions = append(revisions, resource.AttachedRevision{
+			Resource: res,
+			Number:   currentRevision(current),
+			Current:  true,
+		})
+	}
+	for _, doc := range history {
+		res, err := doc2basicResource(doc)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		revisions = append(revisions, resource.AttachedRevision{
+			Resource: res,
+			Number:   doc.HistoryRevision,
+		})
+	}
+	// The current revision isn't the newest once the application
+	// has been rolled back.
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Number > revisions[j].Number
+	})
+	return revisions, nil
+}
+
+// RollbackResource makes the identified previous revision of the
+// content attached to the application resource the current one. The
+// current content is kept as a previous revision in its place. The
+// resource is returned as rolled back.
+func (p ResourcePersistence) RollbackResource(id string, revision int) (resource.Resource, error) {
+	var rolledBack resource.Resource
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		current, err := p.getOne(id)
+		if errors.IsNotFound(err) {
+			return nil, errors.NotFoundf("resource %q", id)
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if hasAttachedContent(current) && currentRevision(current) == revision {
+			// The application is already using the revision.
+			rolledBack, err = doc2basicResource(current)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			return nil, jujutxn.ErrNoOperations
+		}
+
+		var previous resourceDoc
+		err = p.base.One(resourcesC, historyResourceID(id, revision), &previous)
+		if errors.IsNotFound(err) {
+			return nil, errors.NotFoundf("revision %d of resource %q", revision, id)
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rolledBack, err = doc2basicResource(previous)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		active := previous
+		active.DocID = current.DocID
+		active.History = false
+		ops := []txn.Op{{
+			C:      resourcesC,
+			Id:     previous.DocID,
+			Assert: txn.DocExists,
+			Remove: true,
+		}, {
+			C:      resourcesC,
+			Id:     current.DocID,
+			Assert: bson.D{{"storage-path", current.StoragePath}},
+			Update: resourceDocToUpdateOp(&active),
+		}}
+		if hasAttachedContent(current) {
+			ops = append(ops, newInsertResourceHistoryOps(current)...)
+		}
+		ops = append(ops, p.base.ApplicationExistsOps(current.ApplicationID)...)
+		// As when new content is attached, units need to know that
+		// the resource has changed.
+		ops = append(ops, p.base.IncCharmModifiedVersionOps(current.ApplicationID)...)
+		return ops, nil
+	}
+	if err := p.base.Run(buildTxn); err != nil {
+		return resource.Resource{}, errors.Trace(err)
+	}
+	return rolledBack, nil
+}
+
+// newAttachRevisionOps returns the operations which keep the content of
+// the current application resource doc, if any, as a previous revision
+// when next takes its place, pruning the oldest previous revisions
+// beyond maxResourceHistory. The number of the revision next is to be
+// recorded as is returned along with the operations.
+func newAttachRevisionOps(base ResourcePersistenceBase, current *resourceDoc, next storedResource) (int, []txn.Op, error) {
+	if current == nil {
+		return 1, nil, nil
+	}
+	revision := currentRevision(*current)
+	if !hasAttachedContent(*current) {
+		// There's nothing to keep, so the next content takes the
+		// place of the current revision.
+		return revision, nil, nil
+	}
+	nextHasContent := next.storagePath != "" && !next.Timestamp.IsZero()
+	if nextHasContent && bytes.Equal(current.Fingerprint, next.Fingerprint.Bytes()) {
+		// The same content has been attached again, so it stays the
+		// current revision; only the new copy of it is needed.
+		var ops []txn.Op
+		if current.StoragePath != next.storagePath {
+			ops = append(ops, newCleanupOp(cleanupResourceBlob, current.StoragePath))
+		}
+		return revision, ops, nil
+	}
+
+	history, err := resourceHistoryDocs(base, current.ApplicationID, current.ID)
+	if err != nil {
+		return 0, nil, errors.Trace(err)
+	}
+	nextRevision := revision + 1
+	for _, doc := range history {
+		if doc.HistoryRevision >= nextRevision {
+			nextRevision = doc.HistoryRevision + 1
+		}
+	}
+	ops := newInsertResourceHistoryOps(*current)
+	if len(history) >= maxResourceHistory {
+		for _, doc := range history[maxResourceHistory-1:] {
+			ops = append(ops, txn.Op{
+				C:      resourcesC,
+				Id:     doc.DocID,
+				Remove: true,
+			}, newCleanupOp(cleanupResourceBlob, doc.StoragePath))
+		}
+	}
+	return nextRevision, ops, nil
+}
+
+// newInsertResourceHistoryOps returns the operations which keep the
+// content of the application resource doc as a previous revision.
+func newInsertResourceHistoryOps(current resourceDoc) []txn.Op {
+	doc := current
+	doc.DocID = historyResourceID(current.ID, currentRevision(current))
+	doc.HistoryRevision = currentRevision(current)
+	doc.History = true
+	return []txn.Op{{
+		C:      resourcesC,
+		Id:     doc.DocID,
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}}
+}
+
+// resourceHistoryDocs returns the docs holding the previous revisions
+// of the identified application resource, newest first.
+func resourceHistoryDocs(base ResourcePersistenceBase, applicationID, id string) ([]resourceDoc, error) {
+	var docs []resourceDoc
+	query := bson.D{
+		{"application-id", applicationID},
+		{"resource-id", id},
+		{"history", true},
+	}
+	if err := base.All(resourcesC, query, &docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		return docs[i].HistoryRevision > docs[j].HistoryRevision
+	})
+	return docs, nil
+}
+
+// currentRevision returns the number of the revision of the content
+// held by the application resource doc. Content attached before
+// revisions were numbered is revision 1.
+func currentRevision(doc resourceDoc) int {
+	if doc.HistoryRevision == 0 {
+		return 1
+	}
+	return doc.HistoryRevision
+}
+
+// hasAttachedContent reports whether the application resource doc
+// holds content which can be kept as a previous revision. Placeholders
+// have no content, and that of OCI image resources isn't kept in the
+// blob store.
+func hasAttachedContent(doc resourceDoc) bool {
+	return doc.Type == charmresource.TypeFile.String() &&
+		doc.StoragePath != "" &&
+		!doc.Timestamp.IsZero()
+}
@@ -0,0 +1,106 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/resource"
+	"github.com/juju/juju/state/statetest"
+)
+
+var _ = gc.Suite(&ResourceHistorySuite{})
+
+type ResourceHistorySuite struct {
+	testing.IsolationSuite
+
+	stub *testing.Stub
+	base *statetest.StubPersistence
+}
+
+func (s *ResourceHistorySuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+
+	s.stub = &testing.Stub{}
+	s.base = statetest.NewStubPersistence(s.stub)
+}
+
+func (s *ResourceHistorySuite) TestListResourceHistory(c *gc.C) {
+	stored, current := newPersistenceResource(c, "a-application", "spam")
+	current.HistoryRevision = 3
+	s.base.ReturnOne = current
+	newer := current
+	newer.DocID = "resource#a-application/spam#history-4"
+	newer.HistoryRevision = 4
+	newer.History = true
+	older := newer
+	older.DocID = "resource#a-application/spam#history-2"
+	older.HistoryRevision = 2
+	s.base.ReturnAll = []resourceDoc{older, newer}
+	p := NewResourcePersistence(s.base)
+
+	revisions, err := p.ListResourceHistory("a-application/spam")
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "One", "All")
+	s.stub.CheckCall(c, 1, "All", "resources", bson.D{
+		{"application-id", "a-application"},
+		{"resource-id", "a-application/spam"},
+		{"history", true},
+	}, &[]resourceDoc{newer, older})
+	c.Check(revisions, jc.DeepEquals, []resource.AttachedRevision{
+		{Resource: stored.Resource, Number: 4},
+		{Resource: stored.Resource, Number: 3, Current: true},
+		{Resource: stored.Resource, Number: 2},
+	})
+}
+
+func (s *ResourceHistorySuite) TestListResourceHistoryPlaceholder(c *gc.C) {
+	_, current := newPersistenceResource(c, "a-application", "spam")
+	current.StoragePath = ""
+	s.base.ReturnOne = current
+	p := NewResourcePersistence(s.base)
+
+	revisions, err := p.ListResourceHistory("a-application/spam")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(revisions, gc.HasLen, 0)
+}
+
+func (s *ResourceHistorySuite) TestListResourceHistoryNotFound(c *gc.C) {
+	p := NewResourcePersistence(s.base)
+
+	_, err := p.ListResourceHistory("a-application/spam")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `resource "a-application/spam" not found`)
+}
+
+func (s *ResourceHistorySuite) TestRollbackResourceInUse(c *gc.C) {
+	stored, current := newPersistenceResource(c, "a-application", "spam")
+	current.HistoryRevision = 2
+	s.base.ReturnOne = current
+	p := NewResourcePersistence(s.base)
+
+	res, err := p.RollbackResource("a-application/spam", 2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res, jc.DeepEquals, stored.Resource)
+	s.stub.CheckCallNames(c, "Run", "One")
+}
+
+func (s *ResourceHistorySuite) TestRollbackResourceRevisionNotFound(c *gc.C) {
+	_, current := newPersistenceResource(c, "a-application", "spam")
+	current.HistoryRevision = 2
+	s.base.ReturnOne = current
+	s.stub.SetErrors(nil, nil, errors.NotFoundf("doc"))
+	p := NewResourcePersistence(s.base)
+
+	_, err := p.RollbackResource("a-application/spam", 1)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `revision 1 of resource "a-application/spam" not found`)
+	s.stub.CheckCallNames(c, "Run", "One", "One")
+	s.stub.CheckCall(c, 2, "One", "resources", "resource#a-application/spam#history-1", &resourceDoc{})
+}
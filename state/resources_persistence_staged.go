@@ -63,39 +63,51 @@ func (staged StagedResource) Unstage() error {
 	return nil
 }
 
-// Activate makes the staged resource the active resource.
+// Activate makes the staged resource the active resource. The content
+// of the resource it replaces is kept as a previous revision, so that
+// the application can be rolled back to it.
 func (staged StagedResource) Activate() error {
 	buildTxn := func(attempt int) ([]txn.Op, error) {
+		stored := staged.stored
+		var current *resourceDoc
+		var revisionOps []txn.Op
+		if stored.PendingID == "" {
+			var err error
+			current, err = staged.current()
+			if err != nil {
+				logger.Errorf("can't read existing resource during activate: %v", errors.Details(err))
+				return nil, errors.Trace(err)
+			}
+			stored.historyRevision, revisionOps, err = newAttachRevisionOps(staged.base, current, stored)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+
 		// This is an "upsert".
 		var ops []txn.Op
 		switch attempt {
 		case 0:
-			ops = newInsertResourceOps(staged.stored)
+			ops = newInsertResourceOps(stored)
 		case 1:
-			ops = newUpdateResourceOps(staged.stored)
+			ops = newUpdateResourceOps(stored)
 		default:
 			return nil, errors.New("setting the resource failed")
 		}
-		if staged.stored.PendingID == "" {
+		if stored.PendingID == "" {
 			// Only non-pending resources must have an existing application.
-			ops = append(ops, staged.base.ApplicationExistsOps(staged.stored.ApplicationID)...)
+			ops = append(ops, staged.base.ApplicationExistsOps(stored.ApplicationID)...)
 		}
 		// No matter what, we always remove any staging.
 		ops = append(ops, newRemoveStagedResourceOps(staged.id)...)
+		ops = append(ops, revisionOps...)
 
 		// If we are changing the bytes for a resource, we increment the
 		// CharmModifiedVersion on the application, since resources are integral to
 		// the high level "version" of the charm.
-		if staged.stored.PendingID == "" {
-			hasNewBytes, err := staged.hasNewBytes()
-			if err != nil {
-				logger.Errorf("can't read existing resource during activate: %v", errors.Details(err))
-				return nil, errors.Trace(err)
-			}
-			if hasNewBytes {
-				incOps := staged.base.IncCharmModifiedVersionOps(staged.stored.ApplicationID)
-				ops = append(ops, incOps...)
-			}
+		if stored.PendingID == "" && hasNewBytes(current, stored) {
+			incOps := staged.base.IncCharmModifiedVersionOps(stored.ApplicationID)
+			ops = append(ops, incOps...)
 		}
 		return ops, nil
 	}
@@ -105,18 +117,25 @@ func (staged StagedResource) Activate() error {
 	return nil
 }
 
-func (staged StagedResource) hasNewBytes() (bool, error) {
+// current returns the doc of the active resource the staged resource
+// will replace, or nil if there isn't one.
+func (staged StagedResource) current() (*resourceDoc, error) {
 	var current resourceDoc
-	err := staged.base.One(resourcesC, staged.stored.ID, &current)
-	switch {
-	case errors.IsNotFound(err):
+	err := staged.base.One(resourcesC, applicationResourceID(staged.stored.ID), &current)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Annotate(err, "couldn't read existing resource")
+	}
+	return &current, nil
+}
+
+func hasNewBytes(current *resourceDoc, stored storedResource) bool {
+	if current == nil {
 		// if there's no current resource stored, then any non-zero bytes will
 		// be new.
-		return !staged.stored.Fingerprint.IsZero(), nil
-	case err != nil:
-		return false, errors.Annotate(err, "couldn't read existing resource")
-	default:
-		diff := !bytes.Equal(staged.stored.Fingerprint.Bytes(), current.Fingerprint)
-		return diff, nil
+		return !stored.Fingerprint.IsZero()
 	}
+	return !bytes.Equal(stored.Fingerprint.Bytes(), current.Fingerprint)
 }
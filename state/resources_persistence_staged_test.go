@@ -4,6 +4,8 @@
 package state
 
 import (
+	"fmt"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -118,13 +120,15 @@ func (s *StagedResourceSuite) TestUnstageOkay(c *gc.C) {
 
 func (s *StagedResourceSuite) TestActivateOkay(c *gc.C) {
 	staged, doc := s.newStagedResource(c, "a-application", "spam")
+	doc.HistoryRevision = 1
 	ignoredErr := errors.New("<never reached>")
 	s.stub.SetErrors(nil, nil, nil, nil, nil, ignoredErr)
 
 	err := staged.Activate()
 	c.Assert(err, jc.ErrorIsNil)
 
-	s.stub.CheckCallNames(c, "Run", "ApplicationExistsOps", "One", "IncCharmModifiedVersionOps", "RunTransaction")
+	s.stub.CheckCallNames(c, "Run", "One", "ApplicationExistsOps", "IncCharmModifiedVersionOps", "RunTransaction")
+	s.stub.CheckCall(c, 1, "One", "resources", "resource#a-application/spam", &resourceDoc{})
 	s.stub.CheckCall(c, 3, "IncCharmModifiedVersionOps", "a-application")
 	s.stub.CheckCall(c, 4, "RunTransaction", []txn.Op{{
 		C:      "resources",
@@ -144,13 +148,14 @@ func (s *StagedResourceSuite) TestActivateOkay(c *gc.C) {
 
 func (s *StagedResourceSuite) TestActivateExists(c *gc.C) {
 	staged, doc := s.newStagedResource(c, "a-application", "spam")
+	doc.HistoryRevision = 1
 	ignoredErr := errors.New("<never reached>")
 	s.stub.SetErrors(nil, nil, nil, nil, txn.ErrAborted, nil, nil, nil, nil, ignoredErr)
 
 	err := staged.Activate()
 	c.Assert(err, jc.ErrorIsNil)
 
-	s.stub.CheckCallNames(c, "Run", "ApplicationExistsOps", "One", "IncCharmModifiedVersionOps", "RunTransaction", "ApplicationExistsOps", "One", "IncCharmModifiedVersionOps", "RunTransaction")
+	s.stub.CheckCallNames(c, "Run", "One", "ApplicationExistsOps", "IncCharmModifiedVersionOps", "RunTransaction", "One", "ApplicationExistsOps", "IncCharmModifiedVersionOps", "RunTransaction")
 	s.stub.CheckCall(c, 3, "IncCharmModifiedVersionOps", "a-application")
 	s.stub.CheckCall(c, 4, "RunTransaction", []txn.Op{{
 		C:      "resources",
@@ -189,6 +194,7 @@ func (s *StagedResourceSuite) TestActivateExists(c *gc.C) {
 			"storage-path":               doc.StoragePath,
 			"download-progress":          doc.DownloadProgress,
 			"timestamp-when-last-polled": doc.LastPolled,
+			"history-revision":           doc.HistoryRevision,
 		}},
 	}, {
 		C:      "application",
@@ -200,3 +206,95 @@ func (s *StagedResourceSuite) TestActivateExists(c *gc.C) {
 		Remove: true,
 	}})
 }
+
+func (s *StagedResourceSuite) TestActivateKeepsPreviousRevision(c *gc.C) {
+	staged, doc := s.newStagedResource(c, "a-application", "spam")
+	staged.stored.storagePath = "application-a-application/resources/spam-7"
+	doc.StoragePath = staged.stored.storagePath
+	doc.HistoryRevision = 7
+
+	_, current := newPersistenceResource(c, "a-application", "spam")
+	_, other := newPersistenceResource(c, "a-application", "eggs")
+	current.Fingerprint = other.Fingerprint
+	current.HistoryRevision = 6
+	s.base.ReturnOne = current
+	var history []resourceDoc
+	for i := 1; i <= 5; i++ {
+		previous := current
+		previous.DocID = fmt.Sprintf("resource#a-application/spam#history-%d", i)
+		previous.StoragePath = fmt.Sprintf("application-a-application/resources/spam-%d", i)
+		previous.HistoryRevision = i
+		previous.History = true
+		history = append(history, previous)
+	}
+	s.base.ReturnAll = history
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, nil, nil, nil, nil, nil, ignoredErr)
+
+	err := staged.Activate()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Run", "One", "All", "ApplicationExistsOps", "IncCharmModifiedVersionOps", "RunTransaction")
+	s.stub.CheckCall(c, 2, "All", "resources", bson.D{
+		{"application-id", "a-application"},
+		{"resource-id", "a-application/spam"},
+		{"history", true},
+	}, &history)
+
+	kept := current
+	kept.DocID = "resource#a-application/spam#history-6"
+	kept.History = true
+	ops := s.stub.Calls()[5].Args[0].([]txn.Op)
+	c.Assert(ops, gc.HasLen, 6)
+	c.Check(ops[:5], jc.DeepEquals, []txn.Op{{
+		C:      "resources",
+		Id:     "resource#a-application/spam",
+		Assert: txn.DocMissing,
+		Insert: &doc,
+	}, {
+		C:      "application",
+		Id:     "a-application",
+		Assert: txn.DocExists,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-application/spam#staged",
+		Remove: true,
+	}, {
+		C:      "resources",
+		Id:     "resource#a-application/spam#history-6",
+		Assert: txn.DocMissing,
+		Insert: &kept,
+	}, {
+		// Only the 4 newest previous revisions are kept along
+		// with the one replaced.
+		C:      "resources",
+		Id:     "resource#a-application/spam#history-1",
+		Remove: true,
+	}})
+	c.Check(ops[5].Insert.(*cleanupDoc).Kind, gc.Equals, cleanupResourceBlob)
+	c.Check(ops[5].Insert.(*cleanupDoc).Prefix, gc.Equals, "application-a-application/resources/spam-1")
+}
+
+func (s *StagedResourceSuite) TestActivateSameContent(c *gc.C) {
+	staged, doc := s.newStagedResource(c, "a-application", "spam")
+	staged.stored.storagePath = "application-a-application/resources/spam-2"
+	doc.StoragePath = staged.stored.storagePath
+	doc.HistoryRevision = 1
+
+	_, current := newPersistenceResource(c, "a-application", "spam")
+	s.base.ReturnOne = current
+	ignoredErr := errors.New("<never reached>")
+	s.stub.SetErrors(nil, nil, nil, nil, ignoredErr)
+
+	err := staged.Activate()
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The content is unchanged, so no revision is kept and the
+	// charm's modified version isn't changed.
+	s.stub.CheckCallNames(c, "Run", "One", "ApplicationExistsOps", "RunTransaction")
+	ops := s.stub.Calls()[3].Args[0].([]txn.Op)
+	c.Assert(ops, gc.HasLen, 4)
+	c.Check(ops[0].Insert, jc.DeepEquals, &doc)
+	c.Check(ops[3].Insert.(*cleanupDoc).Kind, gc.Equals, cleanupResourceBlob)
+	c.Check(ops[3].Insert.(*cleanupDoc).Prefix, gc.Equals, "application-a-application/resources/spam")
+}
@@ -417,6 +417,7 @@ func (s *ResourcePersistenceSuite) TestSetUnitResourceExists(c *gc.C) {
 			"storage-path":               doc.StoragePath,
 			"download-progress":          doc.DownloadProgress,
 			"timestamp-when-last-polled": doc.LastPolled,
+			"history-revision":           doc.HistoryRevision,
 		}},
 	}, {
 		C:      "application",
@@ -528,6 +529,7 @@ func (s *ResourcePersistenceSuite) TestNewResourcePendingResourceOpsExists(c *gc
 			"storage-path":               expected.StoragePath,
 			"download-progress":          expected.DownloadProgress,
 			"timestamp-when-last-polled": expected.LastPolled,
+			"history-revision":           1,
 		}},
 	}, {
 		C:      "resources",
@@ -551,6 +553,7 @@ func (s *ResourcePersistenceSuite) TestNewResourcePendingResourceOpsExists(c *gc
 			"storage-path":               csresourceDoc.StoragePath,
 			"download-progress":          csresourceDoc.DownloadProgress,
 			"timestamp-when-last-polled": csresourceDoc.LastPolled,
+			"history-revision":           csresourceDoc.HistoryRevision,
 		}},
 	},
 	})
@@ -587,6 +590,7 @@ func (s *ResourcePersistenceSuite) TestNewResourcePendingResourceOpsNotFound(c *
 
 	res := ops[2].Insert.(*resourceDoc)
 	res.LastPolled = res.LastPolled.Round(time.Second)
+	expected.HistoryRevision = 1
 
 	c.Check(ops, jc.DeepEquals, []txn.Op{
 		{
@@ -62,6 +62,14 @@ type resourcePersistence interface {
 	// resources for an application. This is typically used in cleanup
 	// for a failed application deployment.
 	RemovePendingAppResources(applicationID string, pendingIDs map[string]string) error
+
+	// ListResourceHistory returns the revisions of the content attached
+	// to the application resource, newest first.
+	ListResourceHistory(id string) ([]resource.AttachedRevision, error)
+
+	// RollbackResource makes the identified previous revision of the
+	// content attached to the application resource the current one.
+	RollbackResource(id string, revision int) (resource.Resource, error)
 }
 
 type resourceStorage interface {
@@ -166,6 +174,34 @@ func (st resourceState) GetPendingResource(applicationID, name, pendingID string
 	return res, errors.NotFoundf("pending resource %q (%s)", name, pendingID)
 }
 
+// ResourceHistory returns the revisions of the content attached to the
+// identified resource, newest first.
+func (st resourceState) ResourceHistory(applicationID, name string) ([]resource.AttachedRevision, error) {
+	id := newResourceID(applicationID, name)
+	revisions, err := st.persist.ListResourceHistory(id)
+	if err != nil {
+		if err := st.raw.VerifyApplication(applicationID); err != nil {
+			return nil, errors.Trace(err)
+		}
+		return nil, errors.Trace(err)
+	}
+	return revisions, nil
+}
+
+// RollbackResource makes the identified previous revision of the
+// content attached to the resource the one the application uses.
+func (st resourceState) RollbackResource(applicationID, name string, revision int) (resource.Resource, error) {
+	id := newResourceID(applicationID, name)
+	res, err := st.persist.RollbackResource(id, revision)
+	if err != nil {
+		if err := st.raw.VerifyApplication(applicationID); err != nil {
+			return resource.Resource{}, errors.Trace(err)
+		}
+		return resource.Resource{}, errors.Trace(err)
+	}
+	return res, nil
+}
+
 // TODO(ericsnow) Separate setting the metadata from storing the blob?
 
 // SetResource stores the resource in the Juju model.
@@ -272,7 +308,10 @@ func (st resourceState) storeResource(res resource.Resource, r io.Reader) error
 	// is stored separately and adding to both should be an atomic
 	// operation.
 
-	storagePath := storagePath(res.Name, res.ApplicationID, res.PendingID)
+	storagePath, err := st.newStoragePath(res)
+	if err != nil {
+		return errors.Trace(err)
+	}
 	staged, err := st.persist.StageResource(res, storagePath)
 	if err != nil {
 		return errors.Trace(err)
@@ -318,6 +357,29 @@ func (st resourceState) storeResource(res resource.Resource, r io.Reader) error
 	return nil
 }
 
+// newStoragePath returns the path to store the content of the resource
+// at. Pending resources have paths of their own. The content of other
+// resources is stored at the resource's path until content is attached
+// again, when a path of its own is needed as the previous content is
+// kept so that the application can be rolled back to it.
+func (st resourceState) newStoragePath(res resource.Resource) (string, error) {
+	if res.PendingID != "" || res.Type != charmresource.TypeFile {
+		return storagePath(res.Name, res.ApplicationID, res.PendingID), nil
+	}
+	revisions, err := st.persist.ListResourceHistory(res.ID)
+	if err != nil && !errors.IsNotFound(err) {
+		return "", errors.Trace(err)
+	}
+	if len(revisions) == 0 {
+		return storagePath(res.Name, res.ApplicationID, ""), nil
+	}
+	uniqueID, err := newPendingID()
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return storagePath(res.Name, res.ApplicationID, uniqueID), nil
+}
+
 // OpenResource returns metadata about the resource, and a reader for
 // the resource.
 func (st resourceState) OpenResource(applicationID, name string) (resource.Resource, io.ReadCloser, error) {
@@ -5,8 +5,10 @@ package state_test
 
 import (
 	"bytes"
+	"io/ioutil"
 	"time" // Only using time func.
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	charmresource "gopkg.in/juju/charm.v6/resource"
@@ -64,6 +66,58 @@ func (s *ResourcesSuite) TestFunctional(c *gc.C) {
 	// TODO(ericsnow) Add more as state.Resources grows more functionality.
 }
 
+func (s *ResourcesSuite) TestRollback(c *gc.C) {
+	ch := s.ConnSuite.AddTestingCharm(c, "wordpress")
+	s.ConnSuite.AddTestingApplication(c, "a-application", ch)
+
+	st, err := s.State.Resources()
+	c.Assert(err, jc.ErrorIsNil)
+
+	for _, data := range []string{"spamspamspam", "eggs"} {
+		res := newResource(c, "spam", data)
+		_, err = st.SetResource("a-application", res.Username, res.Resource, bytes.NewBufferString(data))
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	first := newResource(c, "spam", "spamspamspam")
+
+	revisions, err := st.ResourceHistory("a-application", "spam")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revisions, gc.HasLen, 2)
+	c.Check(revisions[0].Number, gc.Equals, 2)
+	c.Check(revisions[0].Current, jc.IsTrue)
+	c.Check(revisions[1].Number, gc.Equals, 1)
+	c.Check(revisions[1].Current, jc.IsFalse)
+	c.Check(revisions[1].Fingerprint, jc.DeepEquals, first.Fingerprint)
+
+	res, err := st.RollbackResource("a-application", "spam", 1)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(res.Fingerprint, jc.DeepEquals, first.Fingerprint)
+
+	_, reader, err := st.OpenResource("a-application", "spam")
+	c.Assert(err, jc.ErrorIsNil)
+	defer reader.Close()
+	data, err := ioutil.ReadAll(reader)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "spamspamspam")
+
+	revisions, err = st.ResourceHistory("a-application", "spam")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revisions, gc.HasLen, 2)
+	c.Check(revisions[0].Number, gc.Equals, 2)
+	c.Check(revisions[0].Current, jc.IsFalse)
+	c.Check(revisions[1].Number, gc.Equals, 1)
+	c.Check(revisions[1].Current, jc.IsTrue)
+
+	// Previous revisions aren't listed with the application's resources.
+	resources, err := st.ListResources("a-application")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resources.Resources, gc.HasLen, 1)
+	c.Check(resources.Resources[0].Fingerprint, jc.DeepEquals, first.Fingerprint)
+
+	_, err = st.RollbackResource("a-application", "spam", 3)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
 func newResource(c *gc.C, name, data string) resource.Resource {
 	opened := resourcetesting.NewResource(c, nil, name, "a-application", data)
 	res := opened.Resource
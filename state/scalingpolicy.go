@@ -0,0 +1,230 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// ScalingPolicy describes the number of units an application of an IAAS
+// model should have.
+type ScalingPolicy struct {
+	// MinUnits is the minimum number of alive units the application
+	// should have. Missing units are added by the controller, and units
+	// may not be removed without force if the application would be left
+	// with fewer units.
+	MinUnits int
+
+	// MaxUnits, if non-zero, is the maximum number of alive units the
+	// application is brought back up to by the controller when its units
+	// are lost along with their force-destroyed machines. If it is zero,
+	// lost units are only replaced while the application has fewer than
+	// MinUnits units.
+	MaxUnits int
+}
+
+// Validate returns an error if the policy is not valid.
+func (p ScalingPolicy) Validate() error {
+	if p.MinUnits < 0 {
+		return errors.NotValidf("negative minimum units %d", p.MinUnits)
+	}
+	if p.MaxUnits < 0 {
+		return errors.NotValidf("negative maximum units %d", p.MaxUnits)
+	}
+	if p.MaxUnits > 0 && p.MaxUnits < p.MinUnits {
+		return errors.NotValidf("maximum units %d less than minimum units %d", p.MaxUnits, p.MinUnits)
+	}
+	return nil
+}
+
+// isZero returns whether the policy has no effect.
+func (p ScalingPolicy) isZero() bool {
+	return p.MinUnits == 0 && p.MaxUnits == 0
+}
+
+// wantedUnits returns the number of alive units an application with the
+// given number of alive and lost units should have under the policy.
+func (p ScalingPolicy) wantedUnits(alive, lost int) int {
+	wanted := p.MinUnits
+	if p.MaxUnits == 0 {
+		return wanted
+	}
+	replaced := alive + lost
+	if replaced > p.MaxUnits {
+		replaced = p.MaxUnits
+	}
+	if replaced > wanted {
+		wanted = replaced
+	}
+	return wanted
+}
+
+// ScalingPolicy returns the scaling policy of the application.
+func (a *Application) ScalingPolicy() ScalingPolicy {
+	return ScalingPolicy{
+		MinUnits: a.doc.MinUnits,
+		MaxUnits: a.doc.MaxUnits,
+	}
+}
+
+// SetScalingPolicy changes the scaling policy of the application. Scaling
+// policies are only supported for applications of IAAS models; the scale
+// of CAAS applications is managed by their cloud.
+func (a *Application) SetScalingPolicy(policy ScalingPolicy) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set scaling policy for application %q", a)
+	if err := policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	model, err := a.st.Model()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if model.Type() == ModelTypeCAAS {
+		return errors.NotSupportedf("scaling policies on a container model")
+	}
+	app := &Application{st: a.st, doc: a.doc}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := app.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if app.doc.Life != Alive {
+			return nil, errors.New("application is no longer alive")
+		}
+		if policy == app.ScalingPolicy() {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return setScalingPolicyOps(app, policy), nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	a.doc.MinUnits = policy.MinUnits
+	a.doc.MaxUnits = policy.MaxUnits
+	return nil
+}
+
+// setScalingPolicyOps returns the operations required to set the scaling
+// policy of the application and to create/update/remove the minUnits
+// document in MongoDB. The document exists while the policy has any effect,
+// and its Revno is increased when the policy may require more units.
+func setScalingPolicyOps(app *Application, policy ScalingPolicy) []txn.Op {
+	st := app.st
+	applicationname := app.Name()
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     st.docID(applicationname),
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{
+			{"minunits", policy.MinUnits},
+			{"maxunits", policy.MaxUnits},
+		}}},
+	}}
+	current := app.ScalingPolicy()
+	if current.isZero() {
+		return append(ops, txn.Op{
+			C:      minUnitsC,
+			Id:     st.docID(applicationname),
+			Assert: txn.DocMissing,
+			Insert: &minUnitsDoc{
+				ApplicationName: applicationname,
+				ModelUUID:       st.ModelUUID(),
+			},
+		})
+	}
+	if policy.isZero() {
+		return append(ops, minUnitsRemoveOp(st, applicationname))
+	}
+	if policy.MinUnits > current.MinUnits || policy.MaxUnits > current.MaxUnits {
+		op := minUnitsTriggerOp(st, applicationname)
+		op.Assert = txn.DocExists
+		ops = append(ops, op)
+	}
+	if policy.MaxUnits == 0 && current.MaxUnits > 0 {
+		// Lost units are no longer replaced.
+		ops = append(ops, txn.Op{
+			C:      minUnitsC,
+			Id:     st.docID(applicationname),
+			Update: bson.D{{"$unset", bson.D{{"lostunits", nil}}}},
+		})
+	}
+	return ops
+}
+
+// lostUnitNames returns the names of the application's lost units which
+// have yet to be replaced and are no longer alive.
+func lostUnitNames(app *Application, aliveUnits set.Strings) ([]string, error) {
+	minUnits, closer := app.st.db().GetCollection(minUnitsC)
+	defer closer()
+
+	var doc minUnitsDoc
+	err := minUnits.FindId(app.doc.Name).Select(bson.D{{"lostunits", 1}}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var lost []string
+	for _, name := range doc.LostUnits {
+		if !aliveUnits.Contains(name) {
+			lost = append(lost, name)
+		}
+	}
+	return lost, nil
+}
+
+// lostUnitsRemoveOp returns the operation required to forget the given
+// lost units of the application.
+func lostUnitsRemoveOp(st *State, applicationname string, unitNames ...string) txn.Op {
+	return txn.Op{
+		C:      minUnitsC,
+		Id:     st.docID(applicationname),
+		Update: bson.D{{"$pullAll", bson.D{{"lostunits", unitNames}}}},
+	}
+}
+
+// recordLostUnit records that the named unit is being removed along with
+// its force-destroyed machine, so that it may be replaced according to the
+// scaling policy of its application. Units of applications without a
+// maximum number of units, and units which are already being removed,
+// are not recorded.
+func (st *State) recordLostUnit(unitName string) error {
+	applicationname, err := names.UnitApplication(unitName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	app, err := st.Application(applicationname)
+	if errors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	if app.doc.Life != Alive || app.doc.MaxUnits == 0 {
+		return nil
+	}
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     st.docID(unitName),
+		Assert: isAliveDoc,
+	}, {
+		C:      minUnitsC,
+		Id:     st.docID(applicationname),
+		Assert: txn.DocExists,
+		Update: bson.D{
+			{"$addToSet", bson.D{{"lostunits", unitName}}},
+			{"$inc", bson.D{{"revno", 1}}},
+		},
+	}}
+	if err := st.db().RunTransaction(ops); err != nil && err != txn.ErrAborted {
+		return errors.Annotatef(err, "cannot record lost unit %q", unitName)
+	}
+	return nil
+}
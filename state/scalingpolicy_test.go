@@ -0,0 +1,246 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type ScalingPolicySuite struct {
+	ConnSuite
+	application *state.Application
+}
+
+var _ = gc.Suite(&ScalingPolicySuite{})
+
+func (s *ScalingPolicySuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.application = s.AddTestingApplication(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+}
+
+func (s *ScalingPolicySuite) assertRevno(c *gc.C, expectedRevno int, expectedErr error) {
+	revno, err := state.MinUnitsRevno(s.State, s.application.Name())
+	c.Assert(err, gc.Equals, expectedErr)
+	c.Assert(revno, gc.Equals, expectedRevno)
+}
+
+func (s *ScalingPolicySuite) assertAliveUnits(c *gc.C, expected int) {
+	units, err := s.application.AllUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	alive := 0
+	for _, unit := range units {
+		if unit.Life() == state.Alive {
+			alive++
+		}
+	}
+	c.Assert(alive, gc.Equals, expected)
+}
+
+func (s *ScalingPolicySuite) TestScalingPolicyDefault(c *gc.C) {
+	c.Assert(s.application.ScalingPolicy(), gc.Equals, state.ScalingPolicy{})
+	s.assertRevno(c, 0, mgo.ErrNotFound)
+}
+
+func (s *ScalingPolicySuite) TestSetScalingPolicy(c *gc.C) {
+	policy := state.ScalingPolicy{MinUnits: 2, MaxUnits: 5}
+	err := s.application.SetScalingPolicy(policy)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.application.ScalingPolicy(), gc.Equals, policy)
+	c.Assert(s.application.MinUnits(), gc.Equals, 2)
+	s.assertRevno(c, 0, nil)
+
+	application, err := s.State.Application(s.application.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(application.ScalingPolicy(), gc.Equals, policy)
+}
+
+func (s *ScalingPolicySuite) TestSetScalingPolicyMaxOnly(c *gc.C) {
+	err := s.application.SetScalingPolicy(state.ScalingPolicy{MaxUnits: 3})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertRevno(c, 0, nil)
+}
+
+func (s *ScalingPolicySuite) TestSetScalingPolicyTriggers(c *gc.C) {
+	for i, t := range []struct {
+		about   string
+		initial state.ScalingPolicy
+		change  state.ScalingPolicy
+		revno   int
+	}{{
+		about:   "increasing minimum units",
+		initial: state.ScalingPolicy{MinUnits: 1, MaxUnits: 5},
+		change:  state.ScalingPolicy{MinUnits: 2, MaxUnits: 5},
+		revno:   1,
+	}, {
+		about:   "increasing maximum units",
+		initial: state.ScalingPolicy{MinUnits: 1, MaxUnits: 5},
+		change:  state.ScalingPolicy{MinUnits: 1, MaxUnits: 6},
+		revno:   1,
+	}, {
+		about:   "decreasing both",
+		initial: state.ScalingPolicy{MinUnits: 2, MaxUnits: 5},
+		change:  state.ScalingPolicy{MinUnits: 1, MaxUnits: 4},
+	}, {
+		about:   "removing maximum units",
+		initial: state.ScalingPolicy{MinUnits: 2, MaxUnits: 5},
+		change:  state.ScalingPolicy{MinUnits: 2},
+	}} {
+		c.Logf("test %d. %s", i, t.about)
+		err := s.application.SetScalingPolicy(t.initial)
+		c.Assert(err, jc.ErrorIsNil)
+		err = s.application.SetScalingPolicy(t.change)
+		c.Assert(err, jc.ErrorIsNil)
+		s.assertRevno(c, t.revno, nil)
+		// Clean up the minUnits document.
+		err = s.application.SetScalingPolicy(state.ScalingPolicy{})
+		c.Assert(err, jc.ErrorIsNil)
+		s.assertRevno(c, 0, mgo.ErrNotFound)
+	}
+}
+
+func (s *ScalingPolicySuite) TestSetScalingPolicyInvalid(c *gc.C) {
+	for i, t := range []struct {
+		policy state.ScalingPolicy
+		err    string
+	}{{
+		policy: state.ScalingPolicy{MinUnits: -1},
+		err:    "negative minimum units -1 not valid",
+	}, {
+		policy: state.ScalingPolicy{MaxUnits: -1},
+		err:    "negative maximum units -1 not valid",
+	}, {
+		policy: state.ScalingPolicy{MinUnits: 3, MaxUnits: 2},
+		err:    "maximum units 2 less than minimum units 3 not valid",
+	}} {
+		c.Logf("test %d", i)
+		err := s.application.SetScalingPolicy(t.policy)
+		c.Check(err, gc.ErrorMatches, `cannot set scaling policy for application "dummy-application": `+t.err)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+	}
+}
+
+func (s *ScalingPolicySuite) TestSetScalingPolicyCAASModel(c *gc.C) {
+	st := s.Factory.MakeCAASModel(c, nil)
+	defer st.Close()
+	f := factory.NewFactory(st, s.StatePool)
+	ch := f.MakeCharm(c, &factory.CharmParams{Name: "gitlab", Series: "kubernetes"})
+	app := f.MakeApplication(c, &factory.ApplicationParams{Charm: ch})
+
+	err := app.SetScalingPolicy(state.ScalingPolicy{MinUnits: 1})
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *ScalingPolicySuite) TestSetMinUnitsAboveMaxUnits(c *gc.C) {
+	err := s.application.SetScalingPolicy(state.ScalingPolicy{MinUnits: 1, MaxUnits: 3})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.SetMinUnits(4)
+	c.Assert(err, gc.ErrorMatches, `cannot set minimum units for application "dummy-application": cannot set minimum units above the maximum of 3`)
+	err = s.application.SetMinUnits(3)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.application.ScalingPolicy(), gc.Equals, state.ScalingPolicy{MinUnits: 3, MaxUnits: 3})
+}
+
+func (s *ScalingPolicySuite) TestSetMinUnitsKeepsMaxUnitsDocument(c *gc.C) {
+	err := s.application.SetScalingPolicy(state.ScalingPolicy{MinUnits: 1, MaxUnits: 3})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.SetMinUnits(0)
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertRevno(c, 0, nil)
+}
+
+func (s *ScalingPolicySuite) forceDestroyMachineWithUnits(c *gc.C, count int) []string {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	var unitNames []string
+	for i := 0; i < count; i++ {
+		unit, err := s.application.AddUnit(state.AddUnitParams{})
+		c.Assert(err, jc.ErrorIsNil)
+		err = unit.AssignToMachine(machine)
+		c.Assert(err, jc.ErrorIsNil)
+		unitNames = append(unitNames, unit.Name())
+	}
+	err = machine.ForceDestroy(time.Minute)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.Cleanup()
+	c.Assert(err, jc.ErrorIsNil)
+	return unitNames
+}
+
+func (s *ScalingPolicySuite) TestForceDestroyedMachineRecordsLostUnits(c *gc.C) {
+	err := s.application.SetScalingPolicy(state.ScalingPolicy{MinUnits: 1, MaxUnits: 3})
+	c.Assert(err, jc.ErrorIsNil)
+	unitNames := s.forceDestroyMachineWithUnits(c, 2)
+
+	lost, err := state.LostUnits(s.State, s.application.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lost, jc.SameContents, unitNames)
+	revno, err := state.MinUnitsRevno(s.State, s.application.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(revno > 0, jc.IsTrue)
+}
+
+func (s *ScalingPolicySuite) TestForceDestroyedMachineWithoutMaxUnits(c *gc.C) {
+	err := s.application.SetMinUnits(1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.forceDestroyMachineWithUnits(c, 2)
+
+	lost, err := state.LostUnits(s.State, s.application.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lost, gc.HasLen, 0)
+}
+
+func (s *ScalingPolicySuite) TestEnsureMinUnitsReplacesLostUnits(c *gc.C) {
+	err := s.application.SetScalingPolicy(state.ScalingPolicy{MinUnits: 1, MaxUnits: 5})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.forceDestroyMachineWithUnits(c, 2)
+	s.assertAliveUnits(c, 1)
+
+	err = s.application.EnsureMinUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertAliveUnits(c, 3)
+	lost, err := state.LostUnits(s.State, s.application.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lost, gc.HasLen, 0)
+}
+
+func (s *ScalingPolicySuite) TestEnsureMinUnitsReplacesLostUnitsUpToMaxUnits(c *gc.C) {
+	err := s.application.SetScalingPolicy(state.ScalingPolicy{MinUnits: 1, MaxUnits: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.application.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	s.forceDestroyMachineWithUnits(c, 2)
+	s.assertAliveUnits(c, 1)
+
+	err = s.application.EnsureMinUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertAliveUnits(c, 2)
+	lost, err := state.LostUnits(s.State, s.application.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(lost, gc.HasLen, 0)
+
+	// Lost units which were not replaced are forgotten.
+	err = s.application.SetScalingPolicy(state.ScalingPolicy{MinUnits: 1, MaxUnits: 5})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.EnsureMinUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertAliveUnits(c, 2)
+}
+
+func (s *ScalingPolicySuite) TestEnsureMinUnitsMaxUnitsOnly(c *gc.C) {
+	err := s.application.SetScalingPolicy(state.ScalingPolicy{MaxUnits: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.EnsureMinUnits()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertAliveUnits(c, 0)
+}
@@ -0,0 +1,313 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// schemaVersionKey is the key of the document in the controllers
+// collection which records the schema version of the database.
+const schemaVersionKey = "schemaVersion"
+
+// schemaVersionDoc records the schema version of the database. A
+// controller bootstrapped before the document was introduced doesn't
+// have one, and is treated as being at version 0.
+type schemaVersionDoc struct {
+	Version int `bson:"version"`
+}
+
+// schemaMigrationDoc records the progress of an online schema
+// migration, so that it can be resumed if the controller restarts.
+type schemaMigrationDoc struct {
+	Name      string    `bson:"_id"`
+	Version   int       `bson:"version"`
+	ResumeKey string    `bson:"resume-key"`
+	Processed int       `bson:"processed"`
+	Started   time.Time `bson:"started"`
+	Completed time.Time `bson:"completed,omitempty"`
+}
+
+// schemaMigration describes a data migration which is run in the
+// background, a batch at a time, once the controller has been
+// upgraded. Unlike an upgrade step, it doesn't stop the controller
+// from serving the API while it runs, so the code reading the data
+// it migrates must cope with documents in both the old and new forms.
+type schemaMigration struct {
+	// version is the schema version of the database once the
+	// migration, and all those before it, have completed.
+	version int
+
+	// name uniquely identifies the migration.
+	name string
+
+	// description says what the migration does.
+	description string
+
+	// migrate migrates at most batchSize documents ordered after the
+	// one with the given key, which is empty for the first batch. It
+	// returns the key of the last document it looked at, and how many
+	// documents it looked at; the migration is complete once it looks
+	// at fewer than batchSize. A batch may be repeated if the
+	// controller restarts before its progress is recorded, so migrate
+	// must be idempotent.
+	migrate func(st *State, after string, batchSize int) (last string, count int, err error)
+}
+
+// schemaMigrations holds the online schema migrations, in version
+// order. Migrations must never be removed or reordered, since the
+// schema version of a database refers to its position in the list.
+var schemaMigrations = []schemaMigration{{
+	version:     1,
+	name:        "status-updated-unixnano",
+	description: "convert status timestamps stored as dates to nanoseconds since the epoch",
+	migrate:     migrateStatusUpdatedToUnixNano,
+}}
+
+// LatestSchemaVersion returns the schema version of a database once
+// all the known online schema migrations have completed.
+func LatestSchemaVersion() int {
+	return schemaMigrations[len(schemaMigrations)-1].version
+}
+
+// SchemaMigrationStatus describes the progress of an online schema
+// migration.
+type SchemaMigrationStatus struct {
+	// Name uniquely identifies the migration.
+	Name string
+
+	// Description says what the migration does.
+	Description string
+
+	// Version is the schema version of the database once the
+	// migration has completed.
+	Version int
+
+	// Done is true if the database is at or beyond the migration's
+	// schema version.
+	Done bool
+
+	// Processed is the number of documents the migration has looked
+	// at so far.
+	Processed int
+
+	// Started is when the migration ran its first batch, or the zero
+	// time if it hasn't been run on this controller.
+	Started time.Time
+
+	// Completed is when the migration ran its last batch, or the zero
+	// time if it hasn't been run to completion on this controller.
+	Completed time.Time
+}
+
+// SchemaVersion returns the schema version of the database.
+func (st *State) SchemaVersion() (int, error) {
+	version, _, err := st.schemaVersion()
+	return version, errors.Trace(err)
+}
+
+func (st *State) schemaVersion() (version int, found bool, err error) {
+	controllers, closer := st.db().GetCollection(controllersC)
+	defer closer()
+
+	var doc schemaVersionDoc
+	err = controllers.FindId(schemaVersionKey).One(&doc)
+	if err == mgo.ErrNotFound {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, errors.Annotate(err, "cannot read schema version")
+	}
+	return doc.Version, true, nil
+}
+
+// SchemaMigrations returns the status of every known online schema
+// migration, in version order.
+func (st *State) SchemaMigrations() ([]SchemaMigrationStatus, error) {
+	version, err := st.SchemaVersion()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	migrations, closer := st.db().GetCollection(schemaMigrationsC)
+	defer closer()
+
+	var docs []schemaMigrationDoc
+	if err := migrations.Find(nil).All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot read schema migrations")
+	}
+	progress := make(map[string]schemaMigrationDoc)
+	for _, doc := range docs {
+		progress[doc.Name] = doc
+	}
+	result := make([]SchemaMigrationStatus, len(schemaMigrations))
+	for i, migration := range schemaMigrations {
+		doc := progress[migration.name]
+		result[i] = SchemaMigrationStatus{
+			Name:        migration.name,
+			Description: migration.description,
+			Version:     migration.version,
+			Done:        migration.version <= version,
+			Processed:   doc.Processed,
+			Started:     doc.Started.UTC(),
+			Completed:   doc.Completed.UTC(),
+		}
+	}
+	return result, nil
+}
+
+// RunSchemaMigrationBatch runs the next batch of the first incomplete
+// online schema migration, migrating at most batchSize documents and
+// recording its progress. It returns true once the database is at the
+// latest schema version.
+//
+// It must only be called by one agent at a time.
+func (st *State) RunSchemaMigrationBatch(batchSize int) (bool, error) {
+	if batchSize <= 0 {
+		return false, errors.NotValidf("non-positive batch size")
+	}
+	version, versionFound, err := st.schemaVersion()
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	var next schemaMigration
+	for _, migration := range schemaMigrations {
+		if migration.version > version {
+			next = migration
+			break
+		}
+	}
+	if next.name == "" {
+		return true, nil
+	}
+
+	migrations, closer := st.db().GetCollection(schemaMigrationsC)
+	defer closer()
+	var doc schemaMigrationDoc
+	docFound := true
+	if err := migrations.FindId(next.name).One(&doc); err == mgo.ErrNotFound {
+		docFound = false
+	} else if err != nil {
+		return false, errors.Annotatef(err, "cannot read schema migration %q", next.name)
+	}
+
+	last, count, err := next.migrate(st, doc.ResumeKey, batchSize)
+	if err != nil {
+		return false, errors.Annotatef(err, "running schema migration %q", next.name)
+	}
+	if count == 0 {
+		last = doc.ResumeKey
+	}
+	complete := count < batchSize
+	now := st.nowToTheSecond()
+
+	var ops []txn.Op
+	if docFound {
+		set := bson.D{{"resume-key", last}}
+		if complete {
+			set = append(set, bson.DocElem{"completed", now})
+		}
+		ops = append(ops, txn.Op{
+			C:      schemaMigrationsC,
+			Id:     next.name,
+			Assert: bson.D{{"resume-key", doc.ResumeKey}},
+			Update: bson.D{
+				{"$set", set},
+				{"$inc", bson.D{{"processed", count}}},
+			},
+		})
+	} else {
+		doc = schemaMigrationDoc{
+			Name:      next.name,
+			Version:   next.version,
+			ResumeKey: last,
+			Processed: count,
+			Started:   now,
+		}
+		if complete {
+			doc.Completed = now
+		}
+		ops = append(ops, txn.Op{
+			C:      schemaMigrationsC,
+			Id:     next.name,
+			Assert: txn.DocMissing,
+			Insert: &doc,
+		})
+	}
+	if complete {
+		if versionFound {
+			ops = append(ops, txn.Op{
+				C:      controllersC,
+				Id:     schemaVersionKey,
+				Assert: bson.D{{"version", version}},
+				Update: bson.D{{"$set", bson.D{{"version", next.version}}}},
+			})
+		} else {
+			ops = append(ops, txn.Op{
+				C:      controllersC,
+				Id:     schemaVersionKey,
+				Assert: txn.DocMissing,
+				Insert: &schemaVersionDoc{Version: next.version},
+			})
+		}
+	}
+	if err := st.db().RunTransaction(ops); err != nil {
+		return false, errors.Annotatef(err, "cannot record progress of schema migration %q", next.name)
+	}
+	return complete && next.version == LatestSchemaVersion(), nil
+}
+
+// migrateStatusUpdatedToUnixNano converts the updated field of status
+// documents written when it was a *time.Time, and so stored as a date,
+// to the nanoseconds since the epoch used since.
+func migrateStatusUpdatedToUnixNano(st *State, after string, batchSize int) (string, int, error) {
+	statuses, closer := st.db().GetRawCollection(statusesC)
+	defer closer()
+
+	var query bson.D
+	if after != "" {
+		query = bson.D{{"_id", bson.D{{"$gt", after}}}}
+	}
+	iter := statuses.Find(query).Select(bson.D{{"_id", 1}, {"updated", 1}}).Sort("_id").Limit(batchSize).Iter()
+	defer iter.Close()
+
+	var (
+		last  string
+		count int
+		ops   []txn.Op
+	)
+	for {
+		var doc struct {
+			Id      string      `bson:"_id"`
+			Updated interface{} `bson:"updated"`
+		}
+		if !iter.Next(&doc) {
+			break
+		}
+		last = doc.Id
+		count++
+		updated, ok := doc.Updated.(time.Time)
+		if !ok {
+			continue
+		}
+		ops = append(ops, txn.Op{
+			C:      statusesC,
+			Id:     doc.Id,
+			Assert: bson.D{{"updated", updated}},
+			Update: bson.D{{"$set", bson.D{{"updated", updated.UnixNano()}}}},
+		})
+	}
+	if err := iter.Close(); err != nil {
+		return "", 0, errors.Trace(err)
+	}
+	if len(ops) > 0 {
+		if err := st.runRawTransaction(ops); err != nil {
+			return "", 0, errors.Trace(err)
+		}
+	}
+	return last, count, nil
+}
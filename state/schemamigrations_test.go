@@ -0,0 +1,104 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+)
+
+type SchemaMigrationsSuite struct {
+	ConnSuite
+}
+
+var _ = gc.Suite(&SchemaMigrationsSuite{})
+
+func (s *SchemaMigrationsSuite) TestNewControllerAtLatestVersion(c *gc.C) {
+	version, err := s.State.SchemaVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(version, gc.Equals, state.LatestSchemaVersion())
+
+	migrations, err := s.State.SchemaMigrations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(migrations, gc.Not(gc.HasLen), 0)
+	for _, migration := range migrations {
+		c.Check(migration.Done, jc.IsTrue)
+		c.Check(migration.Started.IsZero(), jc.IsTrue)
+	}
+
+	done, err := s.State.RunSchemaMigrationBatch(10)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(done, jc.IsTrue)
+}
+
+func (s *SchemaMigrationsSuite) TestRunSchemaMigrationBatchInvalidBatchSize(c *gc.C) {
+	_, err := s.State.RunSchemaMigrationBatch(0)
+	c.Assert(err, gc.ErrorMatches, "non-positive batch size not valid")
+}
+
+func (s *SchemaMigrationsSuite) TestMigrateFromVersionZero(c *gc.C) {
+	db := s.State.MongoSession().DB("juju")
+	err := db.C(state.ControllersC).RemoveId(state.SchemaVersionKey)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Add some status documents with their updated field stored as a
+	// date, as older versions of juju did.
+	statuses := db.C(state.StatusesC)
+	updated := time.Date(2015, 6, 1, 12, 0, 0, 0, time.UTC)
+	for _, key := range []string{"u#old/0", "u#old/1", "u#old/2"} {
+		err := statuses.Insert(bson.M{
+			"_id":        s.State.ModelUUID() + ":" + key,
+			"model-uuid": s.State.ModelUUID(),
+			"status":     "active",
+			"updated":    updated,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	total, err := statuses.Count()
+	c.Assert(err, jc.ErrorIsNil)
+
+	version, err := s.State.SchemaVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(version, gc.Equals, 0)
+	migrations, err := s.State.SchemaMigrations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(migrations[0].Done, jc.IsFalse)
+
+	// Run the first batch, and check the progress has been recorded.
+	done, err := s.State.RunSchemaMigrationBatch(2)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(done, jc.IsFalse)
+	migrations, err = s.State.SchemaMigrations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(migrations[0].Processed, gc.Equals, 2)
+	c.Check(migrations[0].Started.IsZero(), jc.IsFalse)
+	c.Check(migrations[0].Completed.IsZero(), jc.IsTrue)
+
+	for batches := 1; !done; batches++ {
+		c.Assert(batches, jc.LessThan, total)
+		done, err = s.State.RunSchemaMigrationBatch(2)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	version, err = s.State.SchemaVersion()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(version, gc.Equals, state.LatestSchemaVersion())
+	migrations, err = s.State.SchemaMigrations()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(migrations[0].Done, jc.IsTrue)
+	c.Check(migrations[0].Processed, gc.Equals, total)
+	c.Check(migrations[0].Completed.IsZero(), jc.IsFalse)
+
+	var doc struct {
+		Updated interface{} `bson:"updated"`
+	}
+	err = statuses.FindId(s.State.ModelUUID() + ":u#old/1").One(&doc)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(doc.Updated, gc.Equals, updated.UnixNano())
+}
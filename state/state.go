@@ -21,7 +21,6 @@ import (
 	"github.com/juju/loggo"
 	"github.com/juju/os"
 	"github.com/juju/os/series"
-	"github.com/juju/pubsub"
 	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils"
 	"github.com/juju/version"
@@ -419,7 +418,7 @@ func (st *State) removeInCollectionOps(name string, sel interface{}) ([]txn.Op,
 //   * creating cloud metadata storage
 //
 // start will close the *State if it fails.
-func (st *State) start(controllerTag names.ControllerTag, hub *pubsub.SimpleHub) (err error) {
+func (st *State) start(controllerTag names.ControllerTag, hub watcher.HubSource) (err error) {
 	defer func() {
 		if err == nil {
 			return
@@ -684,6 +683,14 @@ func (st *State) WatchAllModels(pool *StatePool) *Multiwatcher {
 	return NewMultiwatcher(st.workers.allModelManager(pool))
 }
 
+// WatchAllModelsForCache returns a watcher of the entities of all
+// models for the controller's model cache. As well as the entities
+// reported by WatchAllModels, it reports application offers and
+// storage instances.
+func (st *State) WatchAllModelsForCache(pool *StatePool) *Multiwatcher {
+	return NewMultiwatcher(st.workers.modelCacheManager(pool))
+}
+
 // versionInconsistentError indicates one or more agents have a
 // different version from the current one (even empty, when not yet
 // set).
@@ -819,6 +826,12 @@ func (st *State) SetModelAgentVersion(newVersion version.Number, ignoreAgentVers
 			}
 		}
 
+		operationOp, err := addOperationOp(st, OperationUpgrade,
+			fmt.Sprintf("upgrade model from %s to %s", currentVersion, newVersion), "", "")
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+
 		ops := []txn.Op{
 			// Can't set agent-version if there's an active upgradeInfo doc.
 			{
@@ -833,6 +846,7 @@ func (st *State) SetModelAgentVersion(newVersion version.Number, ignoreAgentVers
 					{"$set", bson.D{{"settings.agent-version", newVersion.String()}}},
 				},
 			},
+			operationOp,
 		}
 		return ops, nil
 	}
@@ -1338,10 +1352,6 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 		Status:     status.Waiting,
 		StatusInfo: status.MessageWaitForMachine,
 		Updated:    st.clock().Now().UnixNano(),
-		// This exists to preserve questionable unit-aggregation behaviour
-		// while we work out how to switch to an implementation that makes
-		// sense.
-		NeverSet: true,
 	}
 	if model.Type() == ModelTypeCAAS {
 		statusDoc.StatusInfo = status.MessageWaitForContainer
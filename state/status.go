@@ -6,6 +6,7 @@ package state
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/juju/clock"
@@ -20,6 +21,7 @@ import (
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/mongo"
 	"github.com/juju/juju/mongo/utils"
+	"github.com/juju/juju/state/docstore"
 )
 
 type displayStatusFunc func(unitStatus status.StatusInfo, containerStatus status.StatusInfo, expectWorkload bool) status.StatusInfo
@@ -34,11 +36,11 @@ type ModelStatus struct {
 // LoadModelStatus retrieves all the status documents for the model
 // at once. Used to primarily speed up status.
 func (m *Model) LoadModelStatus() (*ModelStatus, error) {
-	statuses, closer := m.st.db().GetCollection(statusesC)
+	statuses, closer := getDocStore(m.st.db(), statusesC)
 	defer closer()
 
 	var docs []statusDocWithID
-	err := statuses.Find(nil).All(&docs)
+	err := statuses.FindAll(nil, docstore.FindOptions{}, &docs)
 	if err != nil {
 		return nil, errors.Annotate(err, "failed to read status collection")
 	}
@@ -76,24 +78,21 @@ func (m *ModelStatus) Model() (status.StatusInfo, error) {
 	return m.getStatus(m.model.globalKey(), "model")
 }
 
-// Application returns the status of the model.
-// The unitNames are needed due to the current weird implementation of
-// application status.
+// Application returns the status of the application, as displayed; see
+// ApplicationDisplayStatus. The unitNames are needed to aggregate the
+// status of an application whose status has never been set.
 // Considers the operator pods status (for caas models)
 func (m *ModelStatus) Application(appName string, unitNames []string) (status.StatusInfo, error) {
-	// This is kinda terrible, see notes in application.go for *Application.Status().
 	doc, err := m.getDoc(applicationGlobalKey(appName), "application")
 	if err != nil {
 		return status.StatusInfo{}, err
 	}
-	appStatus := doc.asStatusInfo()
 	expectWorkload, err := expectWorkload(m.model.st, appName)
 	if err != nil {
 		return status.StatusInfo{}, errors.Trace(err)
 	}
-	if doc.NeverSet {
-		// Get the status for the agents, and derive a status from that.
-		var unitStatuses []status.StatusInfo
+	var unitStatuses []status.StatusInfo
+	if !doc.ExplicitlySet {
 		for _, name := range unitNames {
 			unitStatus, err := m.UnitWorkload(name, expectWorkload)
 			if err != nil {
@@ -101,11 +100,8 @@ func (m *ModelStatus) Application(appName string, unitNames []string) (status.St
 			}
 			unitStatuses = append(unitStatuses, unitStatus)
 		}
-		if len(unitStatuses) > 0 {
-			appStatus = deriveApplicationStatus(unitStatuses)
-		}
-
 	}
+	appStatus, _ := ApplicationDisplayStatus(doc.asStatusInfo(), doc.ExplicitlySet, unitStatuses)
 	if m.model.Type() == ModelTypeIAAS {
 		return appStatus, nil
 	}
@@ -236,12 +232,13 @@ func caasUnitDisplayStatus(unitStatus status.StatusInfo, containerStatus status.
 	// Charm may think it's active, but as yet there's no way for it to
 	// query the workload state, so we'll ensure that we only say that
 	// it's active if the pod is reported as running. If not, we'll report
-	// any pod error.
+	// any pod error, or the warning events explaining why the pod is
+	// still waiting.
 	switch containerStatus.Status {
 	case status.Error, status.Blocked, status.Allocating:
 		return containerStatus
 	case status.Waiting:
-		if unitStatus.Status == status.Active {
+		if unitStatus.Status == status.Active || len(status.KubernetesEvents(containerStatus.Data)) > 0 {
 			return containerStatus
 		}
 	case status.Running:
@@ -253,6 +250,25 @@ func caasUnitDisplayStatus(unitStatus status.StatusInfo, containerStatus status.
 	return unitStatus
 }
 
+// ApplicationDisplayStatus returns the status displayed for an
+// application, given its own status, whether that has been set since
+// the application was created, and the workload statuses of its units.
+// It also returns whether the status was derived from those of the
+// units.
+//
+// A status set by the application's leader unit is displayed as is.
+// The status an application is created with says nothing about its
+// workload though, so until one is set the status displayed is
+// aggregated from the units by status.DeriveApplicationStatus, which
+// documents the precedence of the unit statuses. An application without
+// units displays the status it was created with.
+func ApplicationDisplayStatus(appStatus status.StatusInfo, explicitlySet bool, unitStatuses []status.StatusInfo) (status.StatusInfo, bool) {
+	if explicitlySet || len(unitStatuses) == 0 {
+		return appStatus, false
+	}
+	return status.DeriveApplicationStatus(unitStatuses), true
+}
+
 // caasApplicationDisplayStatus determines which of the two statuses to use when displaying application status in a CAAS model.
 func caasApplicationDisplayStatus(applicationStatus, operatorStatus status.StatusInfo, expectWorkload bool) status.StatusInfo {
 	if applicationStatus.Status == status.Terminated {
@@ -284,13 +300,13 @@ func caasHistoryRewriteDoc(jujuStatus, caasStatus status.StatusInfo, expectWorkl
 }
 
 type statusDocWithID struct {
-	ID         string                 `bson:"_id"`
-	ModelUUID  string                 `bson:"model-uuid"`
-	Status     status.Status          `bson:"status"`
-	StatusInfo string                 `bson:"statusinfo"`
-	StatusData map[string]interface{} `bson:"statusdata"`
-	Updated    int64                  `bson:"updated"`
-	NeverSet   bool                   `bson:"neverset"`
+	ID            string                 `bson:"_id"`
+	ModelUUID     string                 `bson:"model-uuid"`
+	Status        status.Status          `bson:"status"`
+	StatusInfo    string                 `bson:"statusinfo"`
+	StatusData    map[string]interface{} `bson:"statusdata"`
+	Updated       int64                  `bson:"updated"`
+	ExplicitlySet bool                   `bson:"explicitly-set,omitempty"`
 }
 
 func (doc *statusDocWithID) asStatusInfo() status.StatusInfo {
@@ -316,13 +332,20 @@ type statusDoc struct {
 	// from older versions of juju so this might be 0 for those cases.
 	Updated int64 `bson:"updated"`
 
-	// TODO(fwereade/wallyworld): lp:1479278
-	// NeverSet is a short-term hack to work around a misfeature in application
-	// status. To maintain current behaviour, we create application status docs
-	// (and only application status documents) with NeverSet true; and then, when
-	// reading them, if NeverSet is still true, we aggregate status from the
-	// units instead.
-	NeverSet bool `bson:"neverset"`
+	// ExplicitlySet records whether the status has been set since the
+	// entity was created. Only application status makes use of it: an
+	// application whose status has never been set displays a status
+	// aggregated from those of its units; see ApplicationDisplayStatus.
+	ExplicitlySet bool `bson:"explicitly-set,omitempty"`
+}
+
+func (doc *statusDoc) asStatusInfo() status.StatusInfo {
+	return status.StatusInfo{
+		Status:  doc.Status,
+		Message: doc.StatusInfo,
+		Data:    utils.UnescapeKeys(doc.StatusData),
+		Since:   unixNanoToTime(doc.Updated),
+	}
 }
 
 func unixNanoToTime(i int64) *time.Time {
@@ -333,44 +356,50 @@ func unixNanoToTime(i int64) *time.Time {
 // getStatus retrieves the status document associated with the given
 // globalKey and converts it to a StatusInfo. If the status document
 // is not found, a NotFoundError referencing badge will be returned.
-func getStatus(db Database, globalKey, badge string) (_ status.StatusInfo, err error) {
+func getStatus(db Database, globalKey, badge string) (status.StatusInfo, error) {
+	doc, err := getStatusDoc(db, globalKey, badge)
+	if err != nil {
+		return status.StatusInfo{}, err
+	}
+	return doc.asStatusInfo(), nil
+}
+
+// getStatusDoc returns the status document of the entity with the
+// given global key.
+func getStatusDoc(db Database, globalKey, badge string) (_ statusDoc, err error) {
 	defer errors.DeferredAnnotatef(&err, "cannot get status")
-	statuses, closer := db.GetCollection(statusesC)
+	statuses, closer := getDocStore(db, statusesC)
 	defer closer()
 
 	var doc statusDoc
-	err = statuses.FindId(globalKey).One(&doc)
-	if err == mgo.ErrNotFound {
-		return status.StatusInfo{}, errors.NotFoundf(badge)
+	err = statuses.FindId(globalKey, &doc)
+	if errors.IsNotFound(err) {
+		return statusDoc{}, errors.NotFoundf(badge)
 	} else if err != nil {
-		return status.StatusInfo{}, errors.Trace(err)
+		return statusDoc{}, errors.Trace(err)
 	}
-
-	return status.StatusInfo{
-		Status:  doc.Status,
-		Message: doc.StatusInfo,
-		Data:    utils.UnescapeKeys(doc.StatusData),
-		Since:   unixNanoToTime(doc.Updated),
-	}, nil
+	return doc, nil
 }
 
 func getEntityKeysForStatus(mb modelBackend, keyType string, status status.Status) ([]string, error) {
-	statuses, closer := mb.db().GetCollection(statusesC)
+	statuses, closer := getDocStore(mb.db(), statusesC)
 	defer closer()
 
-	var ids []bson.M
-	query := bson.D{
-		{"_id", bson.D{{"$regex", fmt.Sprintf(".+\\:%s#.+", keyType)}}},
+	var ids []struct {
+		ID string `bson:"_id"`
+	}
+	query := docstore.D{
+		{"_id", docstore.D{{"$regex", fmt.Sprintf(".+\\:%s#.+", keyType)}}},
 		{"status", status},
 	}
-	err := statuses.Find(query).Select(bson.D{{"_id", 1}}).All(&ids)
+	err := statuses.FindAll(query, docstore.FindOptions{Fields: []string{"_id"}}, &ids)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 
 	keys := make([]string, len(ids))
 	for i, id := range ids {
-		keys[i] = mb.localID(id["_id"].(string))
+		keys[i] = mb.localID(id.ID)
 	}
 	return keys, nil
 }
@@ -408,6 +437,14 @@ type setStatusParams struct {
 	// to query its' workload and the cloud container status might contradict
 	// what it thinks it is.
 	historyOverwrite *statusDoc
+
+	// onSet, if not nil, is called once the status has been set.
+	onSet func()
+
+	// eventOp, if not nil, returns the op recording the change of
+	// status in the model's events. It is only called if the status
+	// has changed.
+	eventOp func() (txn.Op, error)
 }
 
 func timeOrNow(t *time.Time, clock clock.Clock) *time.Time {
@@ -424,12 +461,18 @@ func setStatus(db Database, params setStatusParams) (err error) {
 	if params.updated == nil {
 		return errors.NotValidf("nil updated time")
 	}
+	defer func() {
+		if err == nil && params.onSet != nil {
+			params.onSet()
+		}
+	}()
 
 	doc := statusDoc{
-		Status:     params.status,
-		StatusInfo: params.message,
-		StatusData: utils.EscapeKeys(params.rawData),
-		Updated:    params.updated.UnixNano(),
+		Status:        params.status,
+		StatusInfo:    params.message,
+		StatusData:    utils.EscapeKeys(params.rawData),
+		Updated:       params.updated.UnixNano(),
+		ExplicitlySet: true,
 	}
 
 	historyDoc := &doc
@@ -447,7 +490,15 @@ func setStatus(db Database, params setStatusParams) (err error) {
 
 	// Set the authoritative status document, or fail trying.
 	var buildTxn jujutxn.TransactionSource = func(int) ([]txn.Op, error) {
-		return statusSetOps(db, doc, params.globalKey)
+		ops, err := statusSetOps(db, doc, params.globalKey)
+		if err != nil || !newStatus || params.eventOp == nil {
+			return ops, err
+		}
+		eventOp, err := params.eventOp()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return append(ops, eventOp), nil
 	}
 	if params.token != nil {
 		buildTxn = buildTxnWithLeadership(buildTxn, params.token)
@@ -459,6 +510,204 @@ func setStatus(db Database, params setStatusParams) (err error) {
 	return errors.Trace(err)
 }
 
+// setStatuses sets the statuses described by the params together. The
+// status documents which change are updated in a single transaction, and
+// the new statuses are recorded in the history with a single insert.
+// Unlike setStatus, a failure to record the history fails the call. If
+// the transaction fails, such as when one of the entities has been
+// removed or one of the tokens is no longer valid, each status is set
+// in a transaction of its own, and the error from setting each is
+// returned in the results.
+func setStatuses(db Database, params []setStatusParams) (_ []error, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set statuses")
+	history, closer := db.GetCollection(statusesHistoryC)
+	defer closer()
+	historyW := history.Writeable()
+
+	var (
+		changed    []int
+		docs       []statusDoc
+		newStatus  []bool
+		newHistory []interface{}
+	)
+	seen := make(map[string]bool)
+	for i, p := range params {
+		if p.updated == nil {
+			return nil, errors.NotValidf("nil updated time for %s", p.badge)
+		}
+		if seen[p.globalKey] {
+			return nil, errors.NotValidf("more than one status for %s %q", p.badge, p.globalKey)
+		}
+		seen[p.globalKey] = true
+
+		doc := statusDoc{
+			Status:        p.status,
+			StatusInfo:    p.message,
+			StatusData:    utils.EscapeKeys(p.rawData),
+			Updated:       p.updated.UnixNano(),
+			ExplicitlySet: true,
+		}
+		historyDoc := doc
+		if p.historyOverwrite != nil {
+			historyDoc = *p.historyOverwrite
+		}
+		record := &historicalStatusDoc{
+			Status:     historyDoc.Status,
+			StatusInfo: historyDoc.StatusInfo,
+			StatusData: historyDoc.StatusData,
+			Updated:    historyDoc.Updated,
+			GlobalKey:  p.globalKey,
+		}
+		// As in probablyUpdateStatusHistory, a status which is the
+		// same as the last one recorded just updates its time.
+		exists, current := statusHistoryExists(db, record)
+		if exists {
+			if err := repeatStatusHistory(historyW, current, record.Updated); err != nil {
+				return nil, errors.Annotate(err, "cannot update status history")
+			}
+			if p.historyOverwrite == nil {
+				continue
+			}
+		} else {
+			newHistory = append(newHistory, record)
+		}
+		changed = append(changed, i)
+		docs = append(docs, doc)
+		newStatus = append(newStatus, !exists)
+	}
+	if len(newHistory) > 0 {
+		if err := historyW.Insert(newHistory...); err != nil {
+			return nil, errors.Annotate(err, "cannot write status history")
+		}
+	}
+
+	// statusOps returns the operations which set the status of the
+	// j'th changed entity.
+	statusOps := func(j, attempt int) ([]txn.Op, error) {
+		p := params[changed[j]]
+		var ops []txn.Op
+		if p.token != nil {
+			if err := p.token.Check(attempt, &ops); err != nil {
+				return nil, errors.Annotatef(err, "prerequisites failed")
+			}
+		}
+		setOps, err := statusSetOps(db, docs[j], p.globalKey)
+		if errors.Cause(err) == mgo.ErrNotFound {
+			return nil, errors.NotFoundf(p.badge)
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, setOps...)
+		if newStatus[j] && p.eventOp != nil {
+			eventOp, err := p.eventOp()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, eventOp)
+		}
+		return ops, nil
+	}
+
+	results := make([]error, len(params))
+	if len(changed) > 0 {
+		buildTxn := func(attempt int) ([]txn.Op, error) {
+			var ops []txn.Op
+			for j := range changed {
+				entityOps, err := statusOps(j, attempt)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				ops = append(ops, entityOps...)
+			}
+			return ops, nil
+		}
+		if err := db.Run(buildTxn); err != nil {
+			logger.Debugf("cannot set %d statuses together, setting them separately: %v", len(changed), err)
+			for j, i := range changed {
+				j := j
+				results[i] = db.Run(func(attempt int) ([]txn.Op, error) {
+					return statusOps(j, attempt)
+				})
+				if results[i] != nil {
+					results[i] = errors.Annotate(results[i], "cannot set status")
+				}
+			}
+		}
+	}
+	for i, p := range params {
+		if results[i] == nil && p.onSet != nil {
+			p.onSet()
+		}
+	}
+	return results, nil
+}
+
+// batchStatusSetter is implemented by entities whose statuses can be
+// set together by SetStatuses.
+type batchStatusSetter interface {
+	// setStatusParams checks that the entity may be given the status,
+	// and returns the params with which to set it.
+	setStatusParams(status.StatusInfo) (setStatusParams, error)
+}
+
+// StatusUpdate describes a status to be set on an entity by SetStatuses.
+type StatusUpdate struct {
+	Entity status.StatusSetter
+	Status status.StatusInfo
+
+	// Token, if not nil, prevents the status from being set unless
+	// it remains valid, such as when a unit sets statuses as the
+	// leader of its application. Only the statuses of applications,
+	// units and unit agents can be set with a token.
+	Token leadership.Token
+}
+
+// SetStatuses sets the statuses of entities in the model, returning
+// the error, if any, from setting each of them. The statuses of
+// applications, units and unit agents, which are often set many at a
+// time, are written in a single transaction; if it fails, such as when
+// one of their tokens is no longer valid, each of them is written
+// separately so that only those which can't be set get an error. Other
+// entities have their statuses set one at a time.
+func (st *State) SetStatuses(updates []StatusUpdate) []error {
+	results := make([]error, len(updates))
+	var (
+		batch   []setStatusParams
+		indices []int
+	)
+	for i, update := range updates {
+		setter, ok := update.Entity.(batchStatusSetter)
+		if !ok {
+			if update.Token != nil {
+				results[i] = errors.NotSupportedf("setting %T status with a token", update.Entity)
+				continue
+			}
+			results[i] = update.Entity.SetStatus(update.Status)
+			continue
+		}
+		params, err := setter.setStatusParams(update.Status)
+		if err != nil {
+			results[i] = err
+			continue
+		}
+		params.token = update.Token
+		batch = append(batch, params)
+		indices = append(indices, i)
+	}
+	if len(batch) == 0 {
+		return results
+	}
+	batchResults, err := setStatuses(st.db(), batch)
+	for j, i := range indices {
+		if err != nil {
+			results[i] = err
+		} else {
+			results[i] = batchResults[j]
+		}
+	}
+	return results
+}
+
 func statusSetOps(db Database, doc statusDoc, globalKey string) ([]txn.Op, error) {
 	update := bson.D{{"$set", &doc}}
 	txnRevno, err := readTxnRevno(db, statusesC, globalKey)
@@ -499,6 +748,15 @@ func removeStatusOp(mb modelBackend, globalKey string) txn.Op {
 // historicalStatusDoc.GlobalKey.
 const globalKeyField = "globalkey"
 
+// statusHistoryOrder sorts status history documents newest first. Two
+// statuses can be recorded with the same updated time, such as when an
+// agent reports them with the same since time, so the documents' ids
+// break the tie. This keeps the order stable, but it is not insertion
+// order: an ObjectId only records its creation time to the second,
+// followed by values that differ between the controllers which
+// generate them.
+var statusHistoryOrder = []string{"-updated", "-_id"}
+
 type historicalStatusDoc struct {
 	ModelUUID  string                 `bson:"model-uuid"`
 	GlobalKey  string                 `bson:"globalkey"`
@@ -509,13 +767,79 @@ type historicalStatusDoc struct {
 	// Updated might not be present on statuses copied by old
 	// versions of juju from yet older versions of juju.
 	Updated int64 `bson:"updated"`
+
+	// FirstUpdated and Repeats are only present on records which
+	// stand for the same status being set several times in a row.
+	// FirstUpdated holds when it was first set, and Repeats how many
+	// times it was set after that; Updated holds when it was last
+	// set.
+	FirstUpdated int64 `bson:"first-updated,omitempty"`
+	Repeats      int   `bson:"repeats,omitempty"`
+}
+
+// statusInfo returns the status recorded in the document.
+func (doc *historicalStatusDoc) statusInfo() status.StatusInfo {
+	info := status.StatusInfo{
+		Status:  doc.Status,
+		Message: doc.StatusInfo,
+		Data:    utils.UnescapeKeys(doc.StatusData),
+		Since:   unixNanoToTime(doc.Updated),
+	}
+	if doc.Repeats > 0 {
+		if doc.FirstUpdated != 0 {
+			info.FirstSince = unixNanoToTime(doc.FirstUpdated)
+		}
+		info.Count = doc.Repeats + 1
+	}
+	return info
 }
 
 type recordedHistoricalStatusDoc struct {
-	ID         bson.ObjectId          `bson:"_id"`
-	Status     status.Status          `bson:"status"`
-	StatusInfo string                 `bson:"statusinfo"`
-	StatusData map[string]interface{} `bson:"statusdata"`
+	ID           bson.ObjectId          `bson:"_id"`
+	GlobalKey    string                 `bson:"globalkey"`
+	Status       status.Status          `bson:"status"`
+	StatusInfo   string                 `bson:"statusinfo"`
+	StatusData   map[string]interface{} `bson:"statusdata"`
+	Updated      int64                  `bson:"updated"`
+	FirstUpdated int64                  `bson:"first-updated,omitempty"`
+	Repeats      int                    `bson:"repeats,omitempty"`
+}
+
+// sameStatus returns whether the record holds the same status, message
+// and data as other.
+func (doc *recordedHistoricalStatusDoc) sameStatus(other recordedHistoricalStatusDoc) bool {
+	// Check the data last as the short circuit evaluation may mean
+	// we rarely need to drop down into the reflect library.
+	return doc.Status == other.Status &&
+		doc.StatusInfo == other.StatusInfo &&
+		statusDataSame(doc.StatusData, other.StatusData)
+}
+
+// statusDataSame returns whether two sets of status data are the same.
+func statusDataSame(left, right map[string]interface{}) bool {
+	// If they are both empty, then it is the same.
+	if len(left) == 0 && len(right) == 0 {
+		return true
+	}
+	// If either are now empty, they aren't the same.
+	if len(left) == 0 || len(right) == 0 {
+		return false
+	}
+	// Failing that, use reflect.
+	return reflect.DeepEqual(left, right)
+}
+
+// repeatStatusHistory records that the status held in the history
+// record current was set again at the given time, keeping when it was
+// first set and counting how many times.
+func repeatStatusHistory(history mongo.WriteCollection, current recordedHistoricalStatusDoc, updated int64) error {
+	set := bson.D{{"updated", updated}}
+	if current.FirstUpdated == 0 && current.Updated != 0 {
+		set = append(set, bson.DocElem{"first-updated", current.Updated})
+	}
+	return history.Update(
+		bson.D{{"_id", current.ID}},
+		bson.D{{"$set", set}, {"$inc", bson.D{{"repeats", 1}}}})
 }
 
 // probablyUpdateStatusHistory inspects existing status-history
@@ -536,15 +860,12 @@ func probablyUpdateStatusHistory(db Database, globalKey string, doc statusDoc) (
 	history, closer := db.GetCollection(statusesHistoryC)
 	defer closer()
 
-	exists, currentID := statusHistoryExists(db, historyDoc)
+	exists, current := statusHistoryExists(db, historyDoc)
 	if exists {
 		// If the status values have not changed since the last run,
 		// update history record with this timestamp
 		// to keep correct track of when SetStatus ran.
-		historyW := history.Writeable()
-		err := historyW.Update(
-			bson.D{{"_id", currentID}},
-			bson.D{{"$set", bson.D{{"updated", doc.Updated}}}})
+		err := repeatStatusHistory(history.Writeable(), current, doc.Updated)
 		if err != nil {
 			logger.Errorf("failed to update status history: %v", err)
 			return false, err
@@ -561,7 +882,10 @@ func probablyUpdateStatusHistory(db Database, globalKey string, doc statusDoc) (
 	return true, nil
 }
 
-func statusHistoryExists(db Database, historyDoc *historicalStatusDoc) (bool, bson.ObjectId) {
+// statusHistoryExists returns whether the latest status history record
+// of the entity holds the same status as historyDoc and, if so, that
+// record.
+func statusHistoryExists(db Database, historyDoc *historicalStatusDoc) (bool, recordedHistoricalStatusDoc) {
 	// Find the current value to see if it is worthwhile adding the new
 	// status value.
 	history, closer := db.GetCollection(statusesHistoryC)
@@ -569,33 +893,21 @@ func statusHistoryExists(db Database, historyDoc *historicalStatusDoc) (bool, bs
 
 	var latest []recordedHistoricalStatusDoc
 	query := history.Find(bson.D{{globalKeyField, historyDoc.GlobalKey}})
-	query = query.Sort("-updated").Limit(1)
+	query = query.Sort(statusHistoryOrder...).Limit(1)
 	err := query.All(&latest)
 	if err == nil && len(latest) == 1 {
 		current := latest[0]
 		// Short circuit the writing to the DB if the status, message,
 		// and data match.
-		dataSame := func(left, right map[string]interface{}) bool {
-			// If they are both empty, then it is the same.
-			if len(left) == 0 && len(right) == 0 {
-				return true
-			}
-			// If either are now empty, they aren't the same.
-			if len(left) == 0 || len(right) == 0 {
-				return false
-			}
-			// Failing that, use reflect.
-			return reflect.DeepEqual(left, right)
-		}
-		// Check the data last as the short circuit evaluation may mean
-		// we rarely need to drop down into the reflect library.
-		if current.Status == historyDoc.Status &&
-			current.StatusInfo == historyDoc.StatusInfo &&
-			dataSame(current.StatusData, historyDoc.StatusData) {
-			return true, current.ID
+		if current.sameStatus(recordedHistoricalStatusDoc{
+			Status:     historyDoc.Status,
+			StatusInfo: historyDoc.StatusInfo,
+			StatusData: historyDoc.StatusData,
+		}) {
+			return true, current
 		}
 	}
-	return false, ""
+	return false, recordedHistoricalStatusDoc{}
 }
 
 // eraseStatusHistory removes all status history documents for
@@ -639,71 +951,332 @@ type statusHistoryArgs struct {
 	filter    status.StatusHistoryFilter
 }
 
-// fetchNStatusResults will return status for the given key filtered with the
-// given filter or error.
-func fetchNStatusResults(col mongo.Collection, key string,
+// fetchNStatusResults will return status for the given keys filtered with the
+// given filter or error. The statuses of all the keys are interleaved, newest
+// first, and the filter's size applies to them together.
+func fetchNStatusResults(col docstore.Collection, keys []string,
 	filter status.StatusHistoryFilter) ([]historicalStatusDoc, error) {
-	var (
-		docs  []historicalStatusDoc
-		query mongo.Query
-	)
-	baseQuery := bson.M{"globalkey": key}
+	var docs []historicalStatusDoc
+	baseQuery := docstore.M{"globalkey": keys[0]}
+	if len(keys) > 1 {
+		in := make(docstore.A, len(keys))
+		for i, key := range keys {
+			in[i] = key
+		}
+		baseQuery["globalkey"] = docstore.M{"$in": in}
+	}
+	updated := docstore.M{}
 	if filter.Delta != nil {
 		delta := *filter.Delta
 		// TODO(perrito666) 2016-10-06 lp:1558657
-		updated := time.Now().Add(-delta)
-		baseQuery["updated"] = bson.M{"$gt": updated.UnixNano()}
+		from := time.Now().Add(-delta)
+		updated["$gt"] = from.UnixNano()
 	}
 	if filter.FromDate != nil {
-		baseQuery["updated"] = bson.M{"$gt": filter.FromDate.UnixNano()}
+		updated["$gt"] = filter.FromDate.UnixNano()
+	}
+	if filter.Until != nil {
+		updated["$lt"] = filter.Until.UnixNano()
+	}
+	if len(updated) > 0 {
+		baseQuery["updated"] = updated
 	}
 	excludes := []string{}
 	excludes = append(excludes, filter.Exclude.Values()...)
 	if len(excludes) > 0 {
-		baseQuery["statusinfo"] = bson.M{"$nin": excludes}
+		baseQuery["statusinfo"] = docstore.M{"$nin": excludes}
 	}
 
-	query = col.Find(baseQuery).Sort("-updated")
-	if filter.Size > 0 {
-		query = query.Limit(filter.Size)
+	options := docstore.FindOptions{
+		Sort:  statusHistoryOrder,
+		Limit: filter.Size,
 	}
-	err := query.All(&docs)
-
-	if err == mgo.ErrNotFound {
-		return []historicalStatusDoc{}, errors.NotFoundf("status history")
-	} else if err != nil {
+	if err := col.FindAll(baseQuery, options, &docs); err != nil {
 		return []historicalStatusDoc{}, errors.Annotatef(err, "cannot get status history")
 	}
 	return docs, nil
-
 }
 
 func statusHistory(args *statusHistoryArgs) ([]status.StatusInfo, error) {
 	if err := args.filter.Validate(); err != nil {
 		return nil, errors.Annotate(err, "validating arguments")
 	}
-	statusHistory, closer := args.db.GetCollection(statusesHistoryC)
+	statusHistory, closer := getDocStore(args.db, statusesHistoryC)
 	defer closer()
 
 	var results []status.StatusInfo
-	docs, err := fetchNStatusResults(statusHistory, args.globalKey, args.filter)
+	docs, err := fetchNStatusResults(statusHistory, []string{args.globalKey}, args.filter)
 	partial := []status.StatusInfo{}
 	if err != nil {
 		return []status.StatusInfo{}, errors.Trace(err)
 	}
 	for _, doc := range docs {
-		partial = append(partial, status.StatusInfo{
-			Status:  doc.Status,
-			Message: doc.StatusInfo,
-			Data:    utils.UnescapeKeys(doc.StatusData),
-			Since:   unixNanoToTime(doc.Updated),
-		})
+		partial = append(partial, doc.statusInfo())
 	}
 	results = partial
 	return results, nil
 }
 
-func PruneStatusHistory(st *State, maxHistoryTime time.Duration, maxHistoryMB int) error {
-	err := pruneCollection(st, maxHistoryTime, maxHistoryMB, statusesHistoryC, "updated", NanoSeconds)
+// keyedStatusInfo is a status history record of one of several
+// entities whose histories were fetched together.
+type keyedStatusInfo struct {
+	status.StatusInfo
+	globalKey string
+}
+
+// multiStatusHistory returns the interleaved status history of the
+// entities with the given global keys, newest first, in one query.
+func multiStatusHistory(db Database, globalKeys []string, filter status.StatusHistoryFilter) ([]keyedStatusInfo, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, errors.Annotate(err, "validating arguments")
+	}
+	if len(globalKeys) == 0 {
+		return []keyedStatusInfo{}, nil
+	}
+	statusHistory, closer := getDocStore(db, statusesHistoryC)
+	defer closer()
+
+	docs, err := fetchNStatusResults(statusHistory, globalKeys, filter)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]keyedStatusInfo, len(docs))
+	for i, doc := range docs {
+		results[i] = keyedStatusInfo{
+			StatusInfo: doc.statusInfo(),
+			globalKey:  doc.GlobalKey,
+		}
+	}
+	return results, nil
+}
+
+// StatusHistoryPrunePolicy describes which status history records are
+// pruned. Each limit is applied if it is non-zero, and at least one
+// must be set.
+type StatusHistoryPrunePolicy struct {
+	// MaxAge is the age beyond which records are removed.
+	MaxAge time.Duration
+
+	// MaxSizeMB is the size, in MiB, which the status history
+	// collection is pruned down to. The collection is shared by every
+	// model, so this is only applied when pruning the controller model.
+	MaxSizeMB int
+
+	// MaxEntriesPerEntity is the number of records kept for each
+	// entity; older ones are removed.
+	MaxEntriesPerEntity int
+
+	// PerKind holds the limits applied to the history of particular
+	// kinds of entity, replacing MaxAge and MaxEntriesPerEntity for
+	// them where set.
+	PerKind map[status.RetentionKind]status.HistoryRetention
+
+	// Compact, if true, collapses each run of identical records in
+	// an entity's history into one before any are removed.
+	Compact bool
+}
+
+// Validate returns an error if the policy is not valid.
+func (p StatusHistoryPrunePolicy) Validate() error {
+	if p.MaxAge < 0 {
+		return errors.NotValidf("negative max age")
+	}
+	if p.MaxSizeMB < 0 {
+		return errors.NotValidf("negative max size")
+	}
+	if p.MaxEntriesPerEntity < 0 {
+		return errors.NotValidf("negative max entries per entity")
+	}
+	for kind, retention := range p.PerKind {
+		if err := kind.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		if err := retention.Validate(); err != nil {
+			return errors.Annotatef(err, "%s", kind)
+		}
+	}
+	if p.MaxAge == 0 && p.MaxSizeMB == 0 && p.MaxEntriesPerEntity == 0 && len(p.PerKind) == 0 && !p.Compact {
+		return errors.NotValidf("status history prune policy without limits")
+	}
+	return nil
+}
+
+// retentionKindKeyPatterns holds the patterns matching the global keys
+// under which the history of each kind of entity is recorded.
+var retentionKindKeyPatterns = map[status.RetentionKind]string{
+	status.RetentionMachine:     "m#",
+	status.RetentionUnitAgent:   "u#[^#]+$",
+	status.RetentionWorkload:    "u#[^#]+#",
+	status.RetentionApplication: "a#",
+	status.RetentionFilesystem:  "f#",
+	status.RetentionVolume:      "v#",
+}
+
+// historyKeyFilter returns a query matching the history of the given
+// kinds of entity, or of every other kind if exclude is true. No kinds
+// are given when nothing is excluded.
+func historyKeyFilter(kinds []status.RetentionKind, exclude bool) bson.D {
+	if len(kinds) == 0 {
+		return nil
+	}
+	patterns := make([]string, len(kinds))
+	for i, kind := range kinds {
+		patterns[i] = retentionKindKeyPatterns[kind]
+	}
+	re := bson.RegEx{Pattern: "^(?:" + strings.Join(patterns, "|") + ")"}
+	if exclude {
+		return bson.D{{"globalkey", bson.M{"$not": re}}}
+	}
+	return bson.D{{"globalkey", re}}
+}
+
+// PruneStatusHistory removes the status history records of the model
+// which the policy doesn't keep. The size limit is only applied when st
+// is the controller model's state.
+func PruneStatusHistory(st *State, policy StatusHistoryPrunePolicy) error {
+	if err := policy.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if policy.Compact {
+		if err := compactStatusHistory(st); err != nil {
+			return errors.Annotate(err, "compacting status history")
+		}
+	}
+	var ownEntries, ownAge []status.RetentionKind
+	for _, kind := range status.AllRetentionKinds() {
+		retention, ok := policy.PerKind[kind]
+		if !ok {
+			continue
+		}
+		filter := historyKeyFilter([]status.RetentionKind{kind}, false)
+		if retention.MaxEntries > 0 {
+			err := pruneCollectionPerKey(st, retention.MaxEntries, statusesHistoryC, "globalkey", "updated", filter)
+			if err != nil {
+				return errors.Annotatef(err, "pruning %s status history", kind)
+			}
+			ownEntries = append(ownEntries, kind)
+		}
+		if retention.MaxAge > 0 {
+			err := pruneCollectionByAge(st, retention.MaxAge, statusesHistoryC, "updated", NanoSeconds, filter)
+			if err != nil {
+				return errors.Annotatef(err, "pruning %s status history", kind)
+			}
+			ownAge = append(ownAge, kind)
+		}
+	}
+	if policy.MaxEntriesPerEntity > 0 {
+		filter := historyKeyFilter(ownEntries, true)
+		err := pruneCollectionPerKey(st, policy.MaxEntriesPerEntity, statusesHistoryC, "globalkey", "updated", filter)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if policy.MaxAge > 0 {
+		filter := historyKeyFilter(ownAge, true)
+		err := pruneCollectionByAge(st, policy.MaxAge, statusesHistoryC, "updated", NanoSeconds, filter)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if policy.MaxSizeMB == 0 || !st.isController() {
+		// The size limit covers the whole collection, so only the
+		// controller model's pruner applies it.
+		return nil
+	}
+	err := pruneCollection(st, 0, policy.MaxSizeMB, statusesHistoryC, "updated", NanoSeconds)
 	return errors.Trace(err)
 }
+
+// compactStatusHistory collapses each run of identical records in the
+// history of the model's entities into the newest record of the run,
+// as if the status had been set again each time rather than recorded
+// anew. Such runs are left by versions of juju which didn't collapse
+// them, by agents racing to set the same status, and by records added
+// other than by setting a status.
+func compactStatusHistory(mb modelBackend) error {
+	history, closer := mb.db().GetRawCollection(statusesHistoryC)
+	defer closer()
+
+	iter := history.Find(bson.D{
+		{"model-uuid", mb.modelUUID()},
+	}).Sort(globalKeyField, "updated", "_id").Iter()
+	defer iter.Close()
+
+	var (
+		run       []recordedHistoricalStatusDoc
+		bulk      = history.Bulk()
+		batchSize int
+		removed   int
+	)
+	runBulk := func() error {
+		if batchSize == 0 {
+			return nil
+		}
+		// NotFound indicates that records were already removed.
+		if _, err := bulk.Run(); err != nil && err != mgo.ErrNotFound {
+			return errors.Trace(err)
+		}
+		bulk = history.Bulk()
+		batchSize = 0
+		return nil
+	}
+	collapseRun := func() error {
+		if len(run) > 1 {
+			newest := run[len(run)-1]
+			firstUpdated := run[0].FirstUpdated
+			if firstUpdated == 0 {
+				firstUpdated = run[0].Updated
+			}
+			repeats := 0
+			for _, doc := range run[:len(run)-1] {
+				bulk.Remove(bson.D{{"_id", doc.ID}})
+				repeats += doc.Repeats + 1
+			}
+			// The newest record may be set again while it is being
+			// compacted, so its repeats are added to rather than set.
+			update := bson.D{{"$inc", bson.D{{"repeats", repeats}}}}
+			if firstUpdated != 0 {
+				update = append(update, bson.DocElem{"$set", bson.D{{"first-updated", firstUpdated}}})
+			}
+			bulk.Update(bson.D{{"_id", newest.ID}}, update)
+			batchSize += len(run)
+			removed += len(run) - 1
+		}
+		run = run[:0]
+		if batchSize >= historyPruneBatchSize {
+			return runBulk()
+		}
+		return nil
+	}
+
+	var doc recordedHistoricalStatusDoc
+	for iter.Next(&doc) {
+		if len(run) > 0 {
+			last := run[len(run)-1]
+			if last.GlobalKey != doc.GlobalKey || !last.sameStatus(doc) {
+				if err := collapseRun(); err != nil {
+					return errors.Trace(err)
+				}
+			}
+		}
+		run = append(run, doc)
+		doc = recordedHistoricalStatusDoc{}
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Annotate(err, "reading status history")
+	}
+	if err := collapseRun(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := runBulk(); err != nil {
+		return errors.Trace(err)
+	}
+	if removed > 0 {
+		modelName, err := mb.modelName()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		logger.Infof("status history compaction (%s): %d rows collapsed", modelName, removed)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type StatusBatchSuite struct {
+	ConnSuite
+	machine *state.Machine
+	unit0   *state.Unit
+	unit1   *state.Unit
+}
+
+var _ = gc.Suite(&StatusBatchSuite{})
+
+func (s *StatusBatchSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	app := s.Factory.MakeApplication(c, nil)
+	s.machine = s.Factory.MakeMachine(c, nil)
+	s.unit0 = s.Factory.MakeUnit(c, &factory.UnitParams{Application: app, Machine: s.machine})
+	s.unit1 = s.Factory.MakeUnit(c, &factory.UnitParams{Application: app, Machine: s.machine})
+}
+
+func (s *StatusBatchSuite) TestSetStatuses(c *gc.C) {
+	now := testing.ZeroTime()
+	errs := s.State.SetStatuses([]state.StatusUpdate{{
+		Entity: s.unit0,
+		Status: status.StatusInfo{Status: status.Active, Message: "ready", Since: &now},
+	}, {
+		Entity: s.unit0.Agent(),
+		Status: status.StatusInfo{Status: status.Idle, Since: &now},
+	}, {
+		Entity: s.unit1,
+		Status: status.StatusInfo{Status: status.Maintenance, Message: "installing", Since: &now},
+	}, {
+		Entity: s.machine,
+		Status: status.StatusInfo{Status: status.Started, Since: &now},
+	}})
+	c.Assert(errs, jc.DeepEquals, []error{nil, nil, nil, nil})
+
+	s.checkStatus(c, s.unit0, status.Active, "ready")
+	s.checkStatus(c, s.unit0.Agent(), status.Idle, "")
+	s.checkStatus(c, s.unit1, status.Maintenance, "installing")
+	s.checkStatus(c, s.machine, status.Started, "")
+
+	history, err := s.unit0.StatusHistory(status.StatusHistoryFilter{Size: 1})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Check(history[0].Status, gc.Equals, status.Active)
+	c.Check(history[0].Message, gc.Equals, "ready")
+}
+
+func (s *StatusBatchSuite) TestSetStatusesInvalidStatus(c *gc.C) {
+	now := testing.ZeroTime()
+	errs := s.State.SetStatuses([]state.StatusUpdate{{
+		Entity: s.unit0,
+		Status: status.StatusInfo{Status: status.Status("vliegkat"), Since: &now},
+	}, {
+		Entity: s.unit1,
+		Status: status.StatusInfo{Status: status.Active, Since: &now},
+	}})
+	c.Assert(errs, gc.HasLen, 2)
+	c.Check(errs[0], gc.ErrorMatches, `cannot set invalid status "vliegkat"`)
+	c.Check(errs[1], jc.ErrorIsNil)
+	s.checkStatus(c, s.unit1, status.Active, "")
+}
+
+func (s *StatusBatchSuite) TestSetStatusesSameEntity(c *gc.C) {
+	now := testing.ZeroTime()
+	errs := s.State.SetStatuses([]state.StatusUpdate{{
+		Entity: s.unit0,
+		Status: status.StatusInfo{Status: status.Active, Since: &now},
+	}, {
+		Entity: s.unit0,
+		Status: status.StatusInfo{Status: status.Blocked, Since: &now},
+	}})
+	c.Assert(errs, gc.HasLen, 2)
+	for _, err := range errs {
+		c.Check(err, gc.ErrorMatches, `cannot set statuses: more than one status for unit "u#.*" not valid`)
+	}
+}
+
+func (s *StatusBatchSuite) TestSetStatusesRemovedUnit(c *gc.C) {
+	err := s.unit1.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit1.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := testing.ZeroTime()
+	errs := s.State.SetStatuses([]state.StatusUpdate{{
+		Entity: s.unit0,
+		Status: status.StatusInfo{Status: status.Active, Since: &now},
+	}, {
+		Entity: s.unit1,
+		Status: status.StatusInfo{Status: status.Active, Since: &now},
+	}})
+	c.Assert(errs, gc.HasLen, 2)
+	// The statuses are set separately when one of them can't be.
+	c.Check(errs[0], jc.ErrorIsNil)
+	c.Check(errs[1], gc.ErrorMatches, `cannot set status: unit not found`)
+	c.Check(errors.Cause(errs[1]), jc.Satisfies, errors.IsNotFound)
+	s.checkStatus(c, s.unit0, status.Active, "")
+}
+
+func (s *StatusBatchSuite) TestSetStatusesWithToken(c *gc.C) {
+	app, err := s.unit0.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	now := testing.ZeroTime()
+	token := &fakeToken{}
+	errs := s.State.SetStatuses([]state.StatusUpdate{{
+		Entity: app,
+		Status: status.StatusInfo{Status: status.Active, Message: "all good", Since: &now},
+		Token:  token,
+	}, {
+		Entity: s.unit1,
+		Status: status.StatusInfo{Status: status.Active, Since: &now},
+		Token:  token,
+	}})
+	c.Assert(errs, jc.DeepEquals, []error{nil, nil})
+	s.checkStatus(c, app, status.Active, "all good")
+	s.checkStatus(c, s.unit1, status.Active, "")
+}
+
+func (s *StatusBatchSuite) TestSetStatusesWithInvalidToken(c *gc.C) {
+	app, err := s.unit0.Application()
+	c.Assert(err, jc.ErrorIsNil)
+	now := testing.ZeroTime()
+	errs := s.State.SetStatuses([]state.StatusUpdate{{
+		Entity: app,
+		Status: status.StatusInfo{Status: status.Active, Message: "all good", Since: &now},
+		Token:  &failToken{},
+	}, {
+		Entity: s.unit1,
+		Status: status.StatusInfo{Status: status.Active, Since: &now},
+	}})
+	c.Assert(errs, gc.HasLen, 2)
+	c.Check(errs[0], gc.ErrorMatches, `cannot set status: prerequisites failed: something bad happened`)
+	c.Check(errs[1], jc.ErrorIsNil)
+	statusInfo, err := app.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(statusInfo.Status, gc.Not(gc.Equals), status.Active)
+	s.checkStatus(c, s.unit1, status.Active, "")
+}
+
+func (s *StatusBatchSuite) TestSetStatusesWithTokenNotSupported(c *gc.C) {
+	now := testing.ZeroTime()
+	errs := s.State.SetStatuses([]state.StatusUpdate{{
+		Entity: s.machine,
+		Status: status.StatusInfo{Status: status.Started, Since: &now},
+		Token:  &fakeToken{},
+	}})
+	c.Assert(errs, gc.HasLen, 1)
+	c.Check(errs[0], gc.ErrorMatches, `setting \*state.Machine status with a token not supported`)
+	c.Check(errs[0], jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *StatusBatchSuite) checkStatus(c *gc.C, entity status.StatusGetter, expectStatus status.Status, expectMessage string) {
+	statusInfo, err := entity.Status()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(statusInfo.Status, gc.Equals, expectStatus)
+	c.Check(statusInfo.Message, gc.Equals, expectMessage)
+}
@@ -4,6 +4,7 @@
 package state_test
 
 import (
+	"fmt"
 	"regexp"
 	"time"
 
@@ -53,7 +54,7 @@ func (s *StatusHistorySuite) TestPruneStatusHistoryBySize(c *gc.C) {
 	c.Assert(history, gc.HasLen, initialHistory+1)
 
 	// Prune down to 1MB.
-	err = state.PruneStatusHistory(s.State, 0, 1)
+	err = state.PruneStatusHistory(s.State, state.StatusHistoryPrunePolicy{MaxSizeMB: 1})
 	c.Assert(err, jc.ErrorIsNil)
 
 	history, err = unit.StatusHistory(filter)
@@ -80,7 +81,7 @@ func (s *StatusHistorySuite) TestPruneStatusBySizeOnlyForController(c *gc.C) {
 	c.Logf("%d\n", len(history))
 	c.Assert(history, gc.HasLen, 20001)
 
-	err = state.PruneStatusHistory(st, 0, 1)
+	err = state.PruneStatusHistory(st, state.StatusHistoryPrunePolicy{MaxSizeMB: 1})
 	c.Assert(err, jc.ErrorIsNil)
 
 	history, err = unit.StatusHistory(status.StatusHistoryFilter{Size: 25000})
@@ -135,7 +136,10 @@ func (s *StatusHistorySuite) TestPruneStatusHistoryByDate(c *gc.C) {
 		checkPrimedUnitStatus(c, statusInfo, 9-i, 24*time.Hour)
 	}
 
-	err = state.PruneStatusHistory(s.State, 10*time.Hour, 1024)
+	err = state.PruneStatusHistory(s.State, state.StatusHistoryPrunePolicy{
+		MaxAge:    10 * time.Hour,
+		MaxSizeMB: 1024,
+	})
 	c.Assert(err, jc.ErrorIsNil)
 
 	history, err = units[0].StatusHistory(status.StatusHistoryFilter{Size: 50})
@@ -183,6 +187,149 @@ func (s *StatusHistorySuite) TestPruneStatusHistoryByDate(c *gc.C) {
 	}
 }
 
+func (s *StatusHistorySuite) TestPruneStatusHistoryPerEntity(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	unit0 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	unit1 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	primeUnitStatusHistory(c, unit0, 20, 0)
+	primeUnitStatusHistory(c, unit1, 5, 0)
+
+	err := state.PruneStatusHistory(s.State, state.StatusHistoryPrunePolicy{MaxEntriesPerEntity: 10})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The newest entries are kept.
+	history, err := unit0.StatusHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 10)
+	for i, statusInfo := range history {
+		checkPrimedUnitStatus(c, statusInfo, 19-i, 0)
+	}
+
+	// Entities with fewer entries are untouched.
+	history, err = unit1.StatusHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 6)
+}
+
+func (s *StatusHistorySuite) TestPruneStatusHistoryPerEntityOnlyInModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, &factory.ModelParams{})
+	defer st.Close()
+
+	localFactory := factory.NewFactory(st, s.StatePool)
+	unit := localFactory.MakeUnit(c, nil)
+	primeUnitStatusHistory(c, unit, 20, 0)
+
+	err := state.PruneStatusHistory(s.State, state.StatusHistoryPrunePolicy{MaxEntriesPerEntity: 10})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := unit.StatusHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 21)
+}
+
+func (s *StatusHistorySuite) TestPruneStatusHistoryPerKindAge(c *gc.C) {
+	machine := s.Factory.MakeMachine(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Machine: machine})
+	primeUnitStatusHistory(c, unit, 10, 0)
+	primeUnitStatusHistory(c, unit, 10, 24*time.Hour)
+	primeStatusHistory(c, machine, status.Started, 10, func(int) map[string]interface{} {
+		return nil
+	}, 24*time.Hour, "")
+	machineHistory, err := machine.StatusHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = state.PruneStatusHistory(s.State, state.StatusHistoryPrunePolicy{
+		MaxAge: 10 * time.Hour,
+		PerKind: map[status.RetentionKind]status.HistoryRetention{
+			status.RetentionMachine: {MaxAge: 48 * time.Hour},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The workload history is pruned by the model-wide age...
+	history, err := unit.StatusHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 11)
+	for i, statusInfo := range history[:10] {
+		checkPrimedUnitStatus(c, statusInfo, 9-i, 0)
+	}
+
+	// ...but the machine history is kept for longer.
+	history, err = machine.StatusHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, len(machineHistory))
+}
+
+func (s *StatusHistorySuite) TestPruneStatusHistoryPerKindEntries(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	agent := unit.Agent()
+	primeUnitStatusHistory(c, unit, 20, 0)
+	primeUnitAgentStatusHistory(c, agent, 20, 0, "")
+
+	err := state.PruneStatusHistory(s.State, state.StatusHistoryPrunePolicy{
+		MaxEntriesPerEntity: 5,
+		PerKind: map[status.RetentionKind]status.HistoryRetention{
+			status.RetentionWorkload: {MaxEntries: 10},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := unit.StatusHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 10)
+	for i, statusInfo := range history {
+		checkPrimedUnitStatus(c, statusInfo, 19-i, 0)
+	}
+
+	history, err = agent.StatusHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 5)
+	for i, statusInfo := range history {
+		checkPrimedUnitAgentStatus(c, statusInfo, 19-i, 0)
+	}
+}
+
+func (s *StatusHistorySuite) TestPruneStatusHistoryPerKindOnly(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	agent := unit.Agent()
+	primeUnitStatusHistory(c, unit, 20, 0)
+	primeUnitAgentStatusHistory(c, agent, 20, 0, "")
+
+	err := state.PruneStatusHistory(s.State, state.StatusHistoryPrunePolicy{
+		PerKind: map[status.RetentionKind]status.HistoryRetention{
+			status.RetentionUnitAgent: {MaxEntries: 10},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Only the unit agent history is pruned.
+	history, err := unit.StatusHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 21)
+
+	history, err = agent.StatusHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 10)
+}
+
+func (s *StatusHistorySuite) TestPruneStatusHistoryInvalidPolicy(c *gc.C) {
+	err := state.PruneStatusHistory(s.State, state.StatusHistoryPrunePolicy{})
+	c.Assert(err, gc.ErrorMatches, "status history prune policy without limits not valid")
+
+	err = state.PruneStatusHistory(s.State, state.StatusHistoryPrunePolicy{MaxEntriesPerEntity: -1})
+	c.Assert(err, gc.ErrorMatches, "negative max entries per entity not valid")
+
+	err = state.PruneStatusHistory(s.State, state.StatusHistoryPrunePolicy{
+		PerKind: map[status.RetentionKind]status.HistoryRetention{"model": {MaxEntries: 1}},
+	})
+	c.Assert(err, gc.ErrorMatches, `status history kind "model" not valid`)
+
+	err = state.PruneStatusHistory(s.State, state.StatusHistoryPrunePolicy{
+		PerKind: map[status.RetentionKind]status.HistoryRetention{status.RetentionMachine: {}},
+	})
+	c.Assert(err, gc.ErrorMatches, "machine: status history retention without limits not valid")
+}
+
 func (s *StatusHistorySuite) TestStatusHistoryFilterRunningUpdateStatusHook(c *gc.C) {
 
 	application := s.Factory.MakeApplication(c, nil)
@@ -326,4 +473,311 @@ func (s *StatusHistorySuite) TestSameValueNotRepeated(c *gc.C) {
 	c.Assert(history, gc.HasLen, 2)
 	c.Assert(history[0].Message, gc.Equals, "current status")
 	c.Assert(history[1].Message, gc.Equals, "waiting for machine")
+
+	// The repeated status records when it was first and last set,
+	// and how many times.
+	c.Assert(history[0].Count, gc.Equals, 10)
+	c.Assert(history[0].FirstSince, gc.NotNil)
+	c.Assert(history[0].FirstSince.UnixNano(), gc.Equals, now.UnixNano())
+	c.Assert(history[0].Since.UnixNano(), gc.Equals, now.Add(9*time.Second).UnixNano())
+	c.Assert(history[1].Count, gc.Equals, 0)
+	c.Assert(history[1].FirstSince, gc.IsNil)
+}
+
+func (s *StatusHistorySuite) TestPruneStatusHistoryCompacts(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	start := s.Clock.Now()
+	// Record two runs of four identical statuses, one second apart.
+	state.PrimeUnitStatusHistory(c, s.Clock, unit, status.Active, 8, 8, func(i int) map[string]interface{} {
+		return map[string]interface{}{"run": i / 4}
+	})
+	history, err := unit.StatusHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	initial := len(history) - 8
+
+	err = state.PruneStatusHistory(s.State, state.StatusHistoryPrunePolicy{Compact: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err = unit.StatusHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, initial+2)
+	for i, run := range []int{1, 0} {
+		c.Logf("run %d", run)
+		first := start.Add(time.Duration(run*4+1) * time.Second)
+		last := start.Add(time.Duration(run*4+4) * time.Second)
+		c.Check(history[i].Data, jc.DeepEquals, map[string]interface{}{"run": run})
+		c.Check(history[i].Count, gc.Equals, 4)
+		c.Assert(history[i].FirstSince, gc.NotNil)
+		c.Check(history[i].FirstSince.UnixNano(), gc.Equals, first.UnixNano())
+		c.Check(history[i].Since.UnixNano(), gc.Equals, last.UnixNano())
+	}
+
+	// Compacting again changes nothing.
+	err = state.PruneStatusHistory(s.State, state.StatusHistoryPrunePolicy{Compact: true})
+	c.Assert(err, jc.ErrorIsNil)
+	compacted, err := unit.StatusHistory(status.StatusHistoryFilter{Size: 50})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(compacted, jc.DeepEquals, history)
+}
+
+func (s *StatusHistorySuite) TestStatusHistoryUntil(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+
+	now := time.Now()
+	for i := 1; i <= 5; i++ {
+		when := now.Add(-time.Duration(i) * time.Hour)
+		err := unit.SetStatus(status.StatusInfo{
+			Status:  status.Active,
+			Message: fmt.Sprintf("%d hours ago", i),
+			Since:   &when,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// Read the history a page at a time, newest first.
+	until := now.Add(-90 * time.Minute)
+	history, err := unit.StatusHistory(status.StatusHistoryFilter{Size: 2, Until: &until})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Assert(history[0].Message, gc.Equals, "2 hours ago")
+	c.Assert(history[1].Message, gc.Equals, "3 hours ago")
+
+	history, err = unit.StatusHistory(status.StatusHistoryFilter{Size: 2, Until: history[1].Since})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Assert(history[0].Message, gc.Equals, "4 hours ago")
+	c.Assert(history[1].Message, gc.Equals, "5 hours ago")
+
+	// A time range.
+	from := now.Add(-210 * time.Minute)
+	history, err = unit.StatusHistory(status.StatusHistoryFilter{FromDate: &from, Until: &until})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Assert(history[0].Message, gc.Equals, "2 hours ago")
+	c.Assert(history[1].Message, gc.Equals, "3 hours ago")
+}
+
+func (s *StatusHistorySuite) TestStatusHistorySameTimeInSetOrder(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+
+	// Statuses reported with the same since time are returned in the
+	// reverse of the order they were set in, like any others.
+	when := time.Now()
+	for _, message := range []string{"first", "second", "third"} {
+		err := unit.SetStatus(status.StatusInfo{
+			Status:  status.Active,
+			Message: message,
+			Since:   &when,
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	history, err := unit.StatusHistory(status.StatusHistoryFilter{Size: 3})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 3)
+	c.Assert(history[0].Message, gc.Equals, "third")
+	c.Assert(history[1].Message, gc.Equals, "second")
+	c.Assert(history[2].Message, gc.Equals, "first")
+
+	// The latest status is the one compared against when deciding
+	// whether to record a new one, so setting it again doesn't add to
+	// the history.
+	err = unit.SetStatus(status.StatusInfo{
+		Status:  status.Active,
+		Message: "third",
+		Since:   &when,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	history, err = unit.StatusHistory(status.StatusHistoryFilter{Size: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Assert(history[0].Message, gc.Equals, "third")
+	c.Assert(history[1].Message, gc.Equals, "second")
+}
+
+func (s *StatusHistorySuite) TestApplicationUnitsStatusHistory(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	unit0 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	unit1 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+
+	now := time.Now()
+	at := func(minutes int) *time.Time {
+		when := now.Add(time.Duration(minutes) * time.Minute)
+		return &when
+	}
+	err := unit0.SetStatus(status.StatusInfo{Status: status.Maintenance, Message: "unit0 installing", Since: at(1)})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit1.SetStatus(status.StatusInfo{Status: status.Maintenance, Message: "unit1 installing", Since: at(2)})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit0.SetAgentStatus(status.StatusInfo{Status: status.Executing, Message: "unit0 hook", Since: at(3)})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit1.SetStatus(status.StatusInfo{Status: status.Active, Message: "unit1 ready", Since: at(4)})
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := application.UnitsStatusHistory(status.KindUnit, status.StatusHistoryFilter{FromDate: at(0)})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 4)
+	expected := []struct {
+		unit    string
+		kind    status.HistoryKind
+		message string
+	}{
+		{unit1.Name(), status.KindWorkload, "unit1 ready"},
+		{unit0.Name(), status.KindUnitAgent, "unit0 hook"},
+		{unit1.Name(), status.KindWorkload, "unit1 installing"},
+		{unit0.Name(), status.KindWorkload, "unit0 installing"},
+	}
+	for i, h := range history {
+		c.Check(h.Unit, gc.Equals, expected[i].unit)
+		c.Check(h.Kind, gc.Equals, expected[i].kind)
+		c.Check(h.Message, gc.Equals, expected[i].message)
+	}
+
+	// Only the agent statuses.
+	history, err = application.UnitsStatusHistory(status.KindUnitAgent, status.StatusHistoryFilter{FromDate: at(0)})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 1)
+	c.Check(history[0].Message, gc.Equals, "unit0 hook")
+
+	// The size applies to all the units together.
+	history, err = application.UnitsStatusHistory(status.KindWorkload, status.StatusHistoryFilter{Size: 2})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 2)
+	c.Check(history[0].Message, gc.Equals, "unit1 ready")
+	c.Check(history[1].Message, gc.Equals, "unit1 installing")
+}
+
+func (s *StatusHistorySuite) TestApplicationUnitsStatusHistoryInvalidKind(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	_, err := application.UnitsStatusHistory(status.KindMachine, status.StatusHistoryFilter{Size: 1})
+	c.Assert(err, gc.ErrorMatches, `unit status history kind "machine" not valid`)
+}
+
+func (s *StatusHistorySuite) TestStatusHistoryPage(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	now := time.Now()
+	at := func(minutes int) *time.Time {
+		when := now.Add(time.Duration(minutes) * time.Minute)
+		return &when
+	}
+	// Two of the statuses are set at the same time, and must neither
+	// be lost nor repeated at the boundary between pages.
+	for i, minutes := range []int{1, 2, 2, 3, 4} {
+		err := unit.SetStatus(status.StatusInfo{
+			Status:  status.Maintenance,
+			Message: fmt.Sprintf("step %d", i),
+			Since:   at(minutes),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	filter := status.StatusHistoryFilter{FromDate: at(0)}
+	var messages []string
+	var cursor string
+	pages := 0
+	for {
+		page, err := s.State.StatusHistoryPage(unit.Tag(), status.KindWorkload, filter, 2, cursor)
+		c.Assert(err, jc.ErrorIsNil)
+		pages++
+		for _, record := range page.Statuses {
+			c.Check(record.Kind, gc.Equals, status.KindWorkload)
+			c.Check(record.Unit, gc.Equals, "")
+			messages = append(messages, record.Message)
+		}
+		if page.Next == "" {
+			break
+		}
+		cursor = page.Next
+	}
+	c.Check(pages, gc.Equals, 3)
+	c.Check(messages, jc.DeepEquals, []string{"step 0", "step 1", "step 2", "step 3", "step 4"})
+
+	// A page which ends with the last record doesn't have a cursor.
+	page, err := s.State.StatusHistoryPage(unit.Tag(), status.KindWorkload, filter, 5, "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(page.Statuses, gc.HasLen, 5)
+	c.Check(page.Next, gc.Equals, "")
+
+	// The range may be bounded at both ends.
+	filter.Until = at(3)
+	page, err = s.State.StatusHistoryPage(unit.Tag(), status.KindWorkload, filter, 10, "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(page.Statuses, gc.HasLen, 3)
+	c.Check(page.Statuses[0].Message, gc.Equals, "step 0")
+	c.Check(page.Statuses[2].Message, gc.Equals, "step 2")
+}
+
+func (s *StatusHistorySuite) TestStatusHistoryPageApplicationUnits(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	unit0 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	unit1 := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+
+	now := time.Now()
+	at := func(minutes int) *time.Time {
+		when := now.Add(time.Duration(minutes) * time.Minute)
+		return &when
+	}
+	err := unit0.SetStatus(status.StatusInfo{Status: status.Maintenance, Message: "unit0 installing", Since: at(1)})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit1.SetAgentStatus(status.StatusInfo{Status: status.Executing, Message: "unit1 hook", Since: at(2)})
+	c.Assert(err, jc.ErrorIsNil)
+
+	filter := status.StatusHistoryFilter{FromDate: at(0)}
+	page, err := s.State.StatusHistoryPage(application.Tag(), status.KindUnit, filter, 10, "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(page.Statuses, gc.HasLen, 2)
+	c.Check(page.Statuses[0].Unit, gc.Equals, unit0.Name())
+	c.Check(page.Statuses[0].Kind, gc.Equals, status.KindWorkload)
+	c.Check(page.Statuses[0].Message, gc.Equals, "unit0 installing")
+	c.Check(page.Statuses[1].Unit, gc.Equals, unit1.Name())
+	c.Check(page.Statuses[1].Kind, gc.Equals, status.KindUnitAgent)
+	c.Check(page.Statuses[1].Message, gc.Equals, "unit1 hook")
+}
+
+func (s *StatusHistorySuite) TestStatusHistoryPageInvalid(c *gc.C) {
+	unit := s.Factory.MakeUnit(c, nil)
+	now := time.Now()
+	day := 24 * time.Hour
+	for i, test := range []struct {
+		kind     status.HistoryKind
+		filter   status.StatusHistoryFilter
+		pageSize int
+		cursor   string
+		err      string
+	}{{
+		kind:     status.KindWorkload,
+		pageSize: 0,
+		err:      "page size 0 not valid",
+	}, {
+		kind:     status.KindWorkload,
+		filter:   status.StatusHistoryFilter{Size: 10},
+		pageSize: 10,
+		err:      "paging status history with a Size not valid",
+	}, {
+		kind:     status.KindWorkload,
+		filter:   status.StatusHistoryFilter{Delta: &day},
+		pageSize: 10,
+		err:      "paging status history with a Delta not valid",
+	}, {
+		kind:     status.KindWorkload,
+		filter:   status.StatusHistoryFilter{FromDate: &now, Until: &now},
+		pageSize: 10,
+		err:      "Until not after Date not valid",
+	}, {
+		kind:     status.KindWorkload,
+		pageSize: 10,
+		cursor:   "bogus",
+		err:      `status history cursor "bogus" not valid`,
+	}, {
+		kind:     status.KindMachine,
+		pageSize: 10,
+		err:      `juju-machine status history for "unit-.*" not valid`,
+	}} {
+		c.Logf("test %d", i)
+		_, err := s.State.StatusHistoryPage(unit.Tag(), test.kind, test.filter, test.pageSize, test.cursor)
+		c.Check(err, gc.ErrorMatches, "cannot get .* status history page for .*: "+test.err)
+	}
 }
@@ -449,6 +449,36 @@ func (s *UnitCloudStatusSuite) TestContainerOrUnitStatusChoice(c *gc.C) {
 			expectWorkload:       false,
 			messageCheck:         status.MessageInitializingAgent,
 		},
+		{
+			cloudContainerStatus: status.StatusInfo{
+				Status:  status.Waiting,
+				Message: "container",
+			},
+			unitStatus: status.StatusInfo{
+				Status:  status.Waiting,
+				Message: "unit",
+			},
+			expectWorkload: true,
+			messageCheck:   "unit",
+		},
+		{
+			cloudContainerStatus: status.StatusInfo{
+				Status:  status.Waiting,
+				Message: `Back-off pulling image "mysql:9"`,
+				Data: map[string]interface{}{
+					status.KubernetesEventsKey: status.KubernetesEventsData([]status.KubernetesEvent{{
+						Reason:  "BackOff",
+						Message: `Back-off pulling image "mysql:9"`,
+					}}),
+				},
+			},
+			unitStatus: status.StatusInfo{
+				Status:  status.Waiting,
+				Message: "unit",
+			},
+			expectWorkload: true,
+			messageCheck:   `Back-off pulling image "mysql:9"`,
+		},
 	}
 
 	for i, check := range checks {
@@ -114,6 +114,67 @@ func (s *StatusUnitAgentSuite) TestSetStatusRunningNonCAAS(c *gc.C) {
 	s.checkInitialStatus(c)
 }
 
+func (s *StatusUnitAgentSuite) TestSetLostStatus(c *gc.C) {
+	now := testing.ZeroTime()
+	sInfo := status.StatusInfo{
+		Status: status.Lost,
+		Since:  &now,
+	}
+	err := s.agent.SetStatus(sInfo)
+	c.Check(err, gc.ErrorMatches, `cannot set status "lost"`)
+
+	s.checkInitialStatus(c)
+}
+
+func (s *StatusUnitAgentSuite) TestSetGoneStatus(c *gc.C) {
+	now := testing.ZeroTime()
+	sInfo := status.StatusInfo{
+		Status:  status.Gone,
+		Message: "agent has not been seen for over 1h0m0s",
+		Data:    map[string]interface{}{},
+		Since:   &now,
+	}
+	err := s.agent.SetStatus(sInfo)
+	c.Check(err, jc.ErrorIsNil)
+
+	statusInfo, err := s.agent.Status()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(statusInfo.Status, gc.Equals, status.Gone)
+	c.Check(statusInfo.Message, gc.Equals, "agent has not been seen for over 1h0m0s")
+}
+
+func (s *StatusUnitAgentSuite) TestSetMaintenanceStatusNotInMaintenance(c *gc.C) {
+	now := testing.ZeroTime()
+	sInfo := status.StatusInfo{
+		Status:  status.Maintenance,
+		Message: "disk swap",
+		Since:   &now,
+	}
+	err := s.agent.SetStatus(sInfo)
+	c.Check(err, gc.ErrorMatches, `cannot set status "maintenance" as unit is not in maintenance`)
+
+	s.checkInitialStatus(c)
+}
+
+func (s *StatusUnitAgentSuite) TestSetMaintenanceStatus(c *gc.C) {
+	err := s.unit.SetMaintenance("disk swap")
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := testing.ZeroTime()
+	sInfo := status.StatusInfo{
+		Status:  status.Maintenance,
+		Message: "still swapping",
+		Since:   &now,
+	}
+	err = s.agent.SetStatus(sInfo)
+	c.Check(err, jc.ErrorIsNil)
+
+	statusInfo, err := s.agent.Status()
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(statusInfo.Status, gc.Equals, status.Maintenance)
+	c.Check(statusInfo.Message, gc.Equals, "still swapping")
+}
+
 func (s *StatusUnitAgentSuite) TestSetOverwritesData(c *gc.C) {
 	now := testing.ZeroTime()
 	sInfo := status.StatusInfo{
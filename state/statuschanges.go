@@ -0,0 +1,109 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state/docstore"
+)
+
+// StatusChange holds the status stored under a global key, as reported
+// by the watcher returned from WatchStatusChanges.
+type StatusChange struct {
+	// GlobalKey is the global key the status is stored under.
+	GlobalKey string
+
+	// Entity is the tag of the machine, application, unit or model
+	// whose status it is, or nil if the key doesn't belong to one.
+	Entity names.Tag
+
+	// Status holds the status, or nil if it has been removed along
+	// with its entity.
+	Status *status.StatusInfo
+}
+
+// WatchStatusChanges returns a StringsWatcher that notifies of changes
+// to the statuses in the model whose global keys start with the given
+// prefix, such as "u#" for those of units or "m#" for those of
+// machines; an empty prefix watches all of them. The watcher reports
+// the global keys of the changed statuses, starting with all those
+// which exist; StatusChanges returns what they changed to.
+func (st *State) WatchStatusChanges(globalKeyPrefix string) StringsWatcher {
+	prefix := st.docID(globalKeyPrefix)
+	return newCollectionWatcher(st, colWCfg{
+		col: statusesC,
+		filter: func(id interface{}) bool {
+			key, ok := id.(string)
+			return ok && strings.HasPrefix(key, prefix)
+		},
+	})
+}
+
+// StatusChanges returns the statuses stored under the given global
+// keys, in the same order.
+func (st *State) StatusChanges(globalKeys []string) ([]StatusChange, error) {
+	statuses, closer := getDocStore(st.db(), statusesC)
+	defer closer()
+
+	ids := make(docstore.A, len(globalKeys))
+	for i, key := range globalKeys {
+		ids[i] = key
+	}
+	var docs []statusDocWithID
+	err := statuses.FindAll(docstore.D{{"_id", docstore.D{{"$in", ids}}}}, docstore.FindOptions{}, &docs)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get statuses")
+	}
+	found := make(map[string]status.StatusInfo)
+	for _, doc := range docs {
+		found[st.localID(doc.ID)] = doc.asStatusInfo()
+	}
+
+	changes := make([]StatusChange, len(globalKeys))
+	for i, key := range globalKeys {
+		changes[i] = StatusChange{
+			GlobalKey: key,
+			Entity:    st.statusEntity(key),
+		}
+		if info, ok := found[key]; ok {
+			changes[i].Status = &info
+		}
+	}
+	return changes, nil
+}
+
+// statusEntity returns the tag of the entity whose status is stored
+// under the given global key, or nil if there is none.
+func (st *State) statusEntity(globalKey string) names.Tag {
+	if globalKey == modelGlobalKey {
+		return names.NewModelTag(st.ModelUUID())
+	}
+	if len(globalKey) < 3 || globalKey[1] != '#' {
+		return nil
+	}
+	id := globalKey[2:]
+	if i := strings.Index(id, "#"); i >= 0 {
+		id = id[:i]
+	}
+	switch globalKey[0] {
+	case 'm':
+		if names.IsValidMachine(id) {
+			return names.NewMachineTag(id)
+		}
+	case 'a':
+		if names.IsValidApplication(id) {
+			return names.NewApplicationTag(id)
+		}
+	case 'u':
+		if names.IsValidUnit(id) {
+			return names.NewUnitTag(id)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/testing/factory"
+)
+
+type StatusChangesSuite struct {
+	ConnSuite
+	machine *state.Machine
+	unit    *state.Unit
+}
+
+var _ = gc.Suite(&StatusChangesSuite{})
+
+func (s *StatusChangesSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.machine = s.Factory.MakeMachine(c, &factory.MachineParams{})
+	app := s.Factory.MakeApplication(c, nil)
+	s.unit = s.Factory.MakeUnit(c, &factory.UnitParams{Application: app, Machine: s.machine})
+}
+
+func (s *StatusChangesSuite) TestWatchStatusChanges(c *gc.C) {
+	w := s.State.WatchStatusChanges("m#")
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	key := "m#" + s.machine.Id()
+	wc.AssertChangeInSingleEvent(key, key+"#instance", key+"#modification")
+	wc.AssertNoChange()
+
+	now := testing.ZeroTime()
+	err := s.machine.SetStatus(status.StatusInfo{Status: status.Started, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(key)
+
+	// Changes to the statuses of other kinds of entity aren't reported.
+	err = s.unit.SetStatus(status.StatusInfo{Status: status.Active, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
+
+func (s *StatusChangesSuite) TestWatchStatusChangesUnit(c *gc.C) {
+	prefix := "u#" + s.unit.Name() + "#"
+	w := s.State.WatchStatusChanges(prefix)
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent(prefix+"charm", prefix+"charm#sat#workload-version")
+	wc.AssertNoChange()
+
+	now := testing.ZeroTime()
+	err := s.unit.SetStatus(status.StatusInfo{Status: status.Active, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(prefix + "charm")
+
+	// The statuses are removed with the unit.
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent(prefix+"charm", prefix+"charm#sat#workload-version")
+}
+
+func (s *StatusChangesSuite) TestStatusChanges(c *gc.C) {
+	now := testing.ZeroTime()
+	err := s.unit.SetStatus(status.StatusInfo{Status: status.Active, Message: "ready", Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+
+	unitKey := "u#" + s.unit.Name() + "#charm"
+	changes, err := s.State.StatusChanges([]string{unitKey, "e", "m#42", "x#foo"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(changes, gc.HasLen, 4)
+
+	c.Check(changes[0].GlobalKey, gc.Equals, unitKey)
+	c.Check(changes[0].Entity, gc.Equals, s.unit.Tag())
+	c.Assert(changes[0].Status, gc.NotNil)
+	c.Check(changes[0].Status.Status, gc.Equals, status.Active)
+	c.Check(changes[0].Status.Message, gc.Equals, "ready")
+
+	c.Check(changes[1].Entity, gc.Equals, s.Model.ModelTag())
+	c.Check(changes[1].Status, gc.NotNil)
+
+	// There is no machine 42, so it has no status.
+	c.Check(changes[2].Entity, gc.Equals, names.NewMachineTag("42"))
+	c.Check(changes[2].Status, gc.IsNil)
+
+	c.Check(changes[3].Entity, gc.IsNil)
+	c.Check(changes[3].Status, gc.IsNil)
+}
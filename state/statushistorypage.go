@@ -0,0 +1,258 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/status"
+)
+
+// PagedStatus is a record in a page of status history.
+type PagedStatus struct {
+	status.StatusInfo
+
+	// Kind is the kind of status it is. It is only different from
+	// the kind of history requested when that was status.KindUnit,
+	// which covers both status.KindWorkload and status.KindUnitAgent.
+	Kind status.HistoryKind
+
+	// Unit is the name of the unit whose status it is, when the
+	// history of all of an application's units was requested.
+	Unit string
+}
+
+// StatusHistoryPage is a page of status history.
+type StatusHistoryPage struct {
+	// Statuses holds the page's records, oldest first.
+	Statuses []PagedStatus
+
+	// Next is the cursor with which to read the following page, or
+	// empty if there are no more records.
+	Next string
+}
+
+// StatusHistoryPage returns up to pageSize records of the given kind of
+// status history of the entity with the given tag, oldest first. Unlike
+// the StatusHistory methods of the entities, which read all the records
+// at once, it lets the whole of a long history be read a page at a
+// time: the cursor is empty to read the first page, and each page's
+// Next cursor reads the one after it.
+//
+// Given an application tag and status.KindUnit, status.KindWorkload or
+// status.KindUnitAgent, the history of all the application's units is
+// read. The filter may bound the history with FromDate and Until, and
+// exclude messages; it must not set Size or Delta.
+func (st *State) StatusHistoryPage(
+	tag names.Tag, kind status.HistoryKind, filter status.StatusHistoryFilter, pageSize int, cursor string,
+) (_ StatusHistoryPage, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot get %s status history page for %q", kind, tag)
+	if pageSize <= 0 {
+		return StatusHistoryPage{}, errors.NotValidf("page size %d", pageSize)
+	}
+	if filter.Size != 0 {
+		return StatusHistoryPage{}, errors.NotValidf("paging status history with a Size")
+	}
+	if filter.Delta != nil {
+		// The start of the range would move on with each page.
+		return StatusHistoryPage{}, errors.NotValidf("paging status history with a Delta")
+	}
+	if filter.FromDate != nil && filter.Until != nil && !filter.Until.After(*filter.FromDate) {
+		return StatusHistoryPage{}, errors.NotValidf("Until not after Date")
+	}
+	var after *statusHistoryCursor
+	if cursor != "" {
+		c, err := parseStatusHistoryCursor(cursor)
+		if err != nil {
+			return StatusHistoryPage{}, errors.Trace(err)
+		}
+		after = &c
+	}
+	keys, err := st.pagedStatusKeys(tag, kind)
+	if err != nil {
+		return StatusHistoryPage{}, errors.Trace(err)
+	}
+	globalKeys := make([]string, 0, len(keys))
+	for key := range keys {
+		globalKeys = append(globalKeys, key)
+	}
+
+	query := bson.D{{globalKeyField, bson.D{{"$in", globalKeys}}}}
+	var updated bson.D
+	if filter.FromDate != nil {
+		updated = append(updated, bson.DocElem{"$gt", filter.FromDate.UnixNano()})
+	}
+	if filter.Until != nil {
+		updated = append(updated, bson.DocElem{"$lt", filter.Until.UnixNano()})
+	}
+	if len(updated) > 0 {
+		query = append(query, bson.DocElem{"updated", updated})
+	}
+	if excludes := filter.Exclude.Values(); len(excludes) > 0 {
+		query = append(query, bson.DocElem{"statusinfo", bson.D{{"$nin", excludes}}})
+	}
+	if after != nil {
+		query = append(query, bson.DocElem{"$or", []bson.D{
+			{{"updated", bson.D{{"$gt", after.updated}}}},
+			{{"updated", after.updated}, {"_id", bson.D{{"$gt", after.id}}}},
+		}})
+	}
+
+	history, closer := st.db().GetCollection(statusesHistoryC)
+	defer closer()
+	// One more record than is wanted is read to find out whether
+	// there is another page.
+	var docs []recordedHistoricalStatusDoc
+	err = history.Find(query).Sort(pagedStatusHistoryOrder...).Limit(pageSize + 1).All(&docs)
+	if err != nil {
+		return StatusHistoryPage{}, errors.Trace(err)
+	}
+	var page StatusHistoryPage
+	if len(docs) > pageSize {
+		docs = docs[:pageSize]
+		last := docs[len(docs)-1]
+		page.Next = statusHistoryCursor{updated: last.Updated, id: last.ID}.String()
+	}
+	page.Statuses = make([]PagedStatus, len(docs))
+	for i, doc := range docs {
+		record := historicalStatusDoc{
+			Status:       doc.Status,
+			StatusInfo:   doc.StatusInfo,
+			StatusData:   doc.StatusData,
+			Updated:      doc.Updated,
+			FirstUpdated: doc.FirstUpdated,
+			Repeats:      doc.Repeats,
+		}
+		key := keys[doc.GlobalKey]
+		page.Statuses[i] = PagedStatus{
+			StatusInfo: record.statusInfo(),
+			Kind:       key.kind,
+			Unit:       key.unit,
+		}
+	}
+	return page, nil
+}
+
+// pagedStatusHistoryOrder sorts status history documents oldest first,
+// breaking ties between documents with the same updated time as
+// statusHistoryOrder does.
+var pagedStatusHistoryOrder = []string{"updated", "_id"}
+
+// pagedStatusKey describes the status stored under a global key whose
+// history is being paged through.
+type pagedStatusKey struct {
+	kind status.HistoryKind
+	unit string
+}
+
+// pagedStatusKeys returns the global keys under which the given kind of
+// status history of the entity with the given tag is stored.
+func (st *State) pagedStatusKeys(tag names.Tag, kind status.HistoryKind) (map[string]pagedStatusKey, error) {
+	var workload, agent bool
+	switch kind {
+	case status.KindUnit:
+		workload, agent = true, true
+	case status.KindWorkload:
+		workload = true
+	case status.KindUnitAgent:
+		agent = true
+	}
+	keys := make(map[string]pagedStatusKey)
+	addUnit := func(unit *Unit, name string) {
+		if workload {
+			keys[unit.globalKey()] = pagedStatusKey{status.KindWorkload, name}
+		}
+		if agent {
+			keys[unit.globalAgentKey()] = pagedStatusKey{status.KindUnitAgent, name}
+		}
+	}
+	switch tag := tag.(type) {
+	case names.UnitTag:
+		if !workload && !agent {
+			break
+		}
+		unit, err := st.Unit(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		addUnit(unit, "")
+		return keys, nil
+	case names.ApplicationTag:
+		if !workload && !agent && kind != status.KindApplication {
+			break
+		}
+		app, err := st.Application(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if kind == status.KindApplication {
+			keys[app.globalKey()] = pagedStatusKey{kind: kind}
+			return keys, nil
+		}
+		units, err := app.AllUnits()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		for _, unit := range units {
+			addUnit(unit, unit.Name())
+		}
+		return keys, nil
+	case names.MachineTag:
+		machine, err := st.Machine(tag.Id())
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		switch kind {
+		case status.KindMachine, status.KindContainer:
+			keys[machine.globalKey()] = pagedStatusKey{kind: kind}
+			return keys, nil
+		case status.KindMachineInstance, status.KindContainerInstance:
+			keys[machine.globalInstanceKey()] = pagedStatusKey{kind: kind}
+			return keys, nil
+		}
+	}
+	return nil, errors.NotValidf("%s status history for %q", kind, tag)
+}
+
+// statusHistoryCursor identifies the last record in a page of status
+// history; the next page starts after it.
+type statusHistoryCursor struct {
+	updated int64
+	id      bson.ObjectId
+}
+
+// String returns the cursor in the opaque form given to clients.
+func (c statusHistoryCursor) String() string {
+	raw := fmt.Sprintf("%d:%s", c.updated, c.id.Hex())
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// parseStatusHistoryCursor parses a cursor returned by
+// statusHistoryCursor.String.
+func parseStatusHistoryCursor(cursor string) (statusHistoryCursor, error) {
+	notValid := errors.NotValidf("status history cursor %q", cursor)
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return statusHistoryCursor{}, notValid
+	}
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 || !bson.IsObjectIdHex(parts[1]) {
+		return statusHistoryCursor{}, notValid
+	}
+	updated, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return statusHistoryCursor{}, notValid
+	}
+	return statusHistoryCursor{
+		updated: updated,
+		id:      bson.ObjectIdHex(parts[1]),
+	}, nil
+}
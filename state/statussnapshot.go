@@ -0,0 +1,125 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/core/status"
+)
+
+// StatusSnapshot holds the statuses of the entities in a model as they
+// were at a point in time, reconstructed from status history. Entities
+// which have since been removed from the model are not included, since
+// their status history is removed with them, and neither are entities
+// whose status history from that time has been pruned.
+type StatusSnapshot struct {
+	// Time is the time the snapshot was taken for.
+	Time time.Time
+
+	// Model holds the status of the model, if it was recorded.
+	Model *status.StatusInfo
+
+	// Machines holds the status of each machine agent, by machine ID.
+	Machines map[string]status.StatusInfo
+
+	// Instances holds the status of each machine instance, by
+	// machine ID.
+	Instances map[string]status.StatusInfo
+
+	// Applications holds the status of each application, by name.
+	Applications map[string]status.StatusInfo
+
+	// UnitAgents holds the status of each unit agent, by unit name.
+	UnitAgents map[string]status.StatusInfo
+
+	// UnitWorkloads holds the workload status of each unit, by unit
+	// name.
+	UnitWorkloads map[string]status.StatusInfo
+}
+
+// StatusSnapshot returns the statuses the entities of the model had at
+// the given time, being the latest status recorded in status history
+// for each entity at or before that time.
+func (st *State) StatusSnapshot(at time.Time) (*StatusSnapshot, error) {
+	history, closer := st.db().GetRawCollection(statusesHistoryC)
+	defer closer()
+
+	snapshot := &StatusSnapshot{
+		Time:          at,
+		Machines:      make(map[string]status.StatusInfo),
+		Instances:     make(map[string]status.StatusInfo),
+		Applications:  make(map[string]status.StatusInfo),
+		UnitAgents:    make(map[string]status.StatusInfo),
+		UnitWorkloads: make(map[string]status.StatusInfo),
+	}
+	// A record standing for a status set several times in a row is in
+	// effect from when it was first set, so it is included if that was
+	// at or before the time even when it was last set after it. The
+	// sort walks the {model-uuid, globalkey, updated} index backwards,
+	// so the group only has to take the first record for each key.
+	atNano := at.UnixNano()
+	iter := history.Pipe([]bson.M{
+		{"$match": bson.D{
+			{"model-uuid", st.ModelUUID()},
+			{"$or", []bson.D{
+				{{"updated", bson.D{{"$lte", atNano}}}},
+				{{"first-updated", bson.D{{"$lte", atNano}}}},
+			}},
+		}},
+		{"$sort": bson.D{{"model-uuid", -1}, {"globalkey", -1}, {"updated", -1}}},
+		{"$group": bson.M{"_id": "$globalkey", "doc": bson.M{"$first": "$$ROOT"}}},
+	}).Iter()
+	var latest struct {
+		Doc historicalStatusDoc `bson:"doc"`
+	}
+	for iter.Next(&latest) {
+		snapshot.add(latest.Doc, atNano)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, errors.Annotate(err, "cannot read status history")
+	}
+	return snapshot, nil
+}
+
+// add records the status in the history document against the entity
+// identified by its global key, as it was at the given time in Unix
+// nanoseconds. Statuses of keys which do not identify an entity shown
+// in a snapshot are ignored.
+func (s *StatusSnapshot) add(doc historicalStatusDoc, at int64) {
+	since := doc.Updated
+	if since > at {
+		// The status was set again after the time, so report when
+		// it was first set instead.
+		since = doc.FirstUpdated
+	}
+	info := status.StatusInfo{
+		Status:  doc.Status,
+		Message: doc.StatusInfo,
+		Data:    utils.UnescapeKeys(doc.StatusData),
+		Since:   unixNanoToTime(since),
+	}
+	if doc.GlobalKey == modelGlobalKey {
+		s.Model = &info
+		return
+	}
+	parts := strings.Split(doc.GlobalKey, "#")
+	switch {
+	case len(parts) == 2 && parts[0] == "m":
+		s.Machines[parts[1]] = info
+	case len(parts) == 3 && parts[0] == "m" && parts[2] == "instance":
+		s.Instances[parts[1]] = info
+	case len(parts) == 2 && parts[0] == "a":
+		s.Applications[parts[1]] = info
+	case len(parts) == 2 && parts[0] == "u":
+		s.UnitAgents[parts[1]] = info
+	case len(parts) == 3 && parts[0] == "u" && parts[2] == "charm":
+		s.UnitWorkloads[parts[1]] = info
+	}
+}
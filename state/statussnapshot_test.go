@@ -0,0 +1,105 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type StatusSnapshotSuite struct {
+	ConnSuite
+	unit    *state.Unit
+	machine *state.Machine
+	created time.Time
+}
+
+var _ = gc.Suite(&StatusSnapshotSuite{})
+
+func (s *StatusSnapshotSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.created = s.Clock.Now()
+	s.machine = s.Factory.MakeMachine(c, nil)
+	s.unit = s.Factory.MakeUnit(c, &factory.UnitParams{Machine: s.machine})
+}
+
+func (s *StatusSnapshotSuite) setWorkloadStatus(c *gc.C, st status.Status, message string, when time.Time) {
+	err := s.unit.SetStatus(status.StatusInfo{
+		Status:  st,
+		Message: message,
+		Since:   &when,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *StatusSnapshotSuite) TestStatusSnapshot(c *gc.C) {
+	s.setWorkloadStatus(c, status.Maintenance, "installing", s.created.Add(time.Hour))
+	s.setWorkloadStatus(c, status.Active, "ready", s.created.Add(2*time.Hour))
+
+	snapshot, err := s.State.StatusSnapshot(s.created.Add(90 * time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	workload, ok := snapshot.UnitWorkloads[s.unit.Name()]
+	c.Assert(ok, jc.IsTrue)
+	c.Check(workload.Status, gc.Equals, status.Maintenance)
+	c.Check(workload.Message, gc.Equals, "installing")
+	c.Check(workload.Since.Equal(s.created.Add(time.Hour)), jc.IsTrue)
+
+	_, ok = snapshot.UnitAgents[s.unit.Name()]
+	c.Check(ok, jc.IsTrue)
+	_, ok = snapshot.Applications[s.unit.ApplicationName()]
+	c.Check(ok, jc.IsTrue)
+	_, ok = snapshot.Machines[s.machine.Id()]
+	c.Check(ok, jc.IsTrue)
+
+	snapshot, err = s.State.StatusSnapshot(s.created.Add(3 * time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(snapshot.UnitWorkloads[s.unit.Name()].Status, gc.Equals, status.Active)
+}
+
+func (s *StatusSnapshotSuite) TestStatusSnapshotRepeatedStatus(c *gc.C) {
+	s.setWorkloadStatus(c, status.Maintenance, "installing", s.created.Add(time.Hour))
+	s.setWorkloadStatus(c, status.Maintenance, "installing", s.created.Add(2*time.Hour))
+	s.setWorkloadStatus(c, status.Active, "ready", s.created.Add(3*time.Hour))
+
+	// The repeated status is held in one history record last updated
+	// after the time, but it was in effect then.
+	snapshot, err := s.State.StatusSnapshot(s.created.Add(90 * time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	workload := snapshot.UnitWorkloads[s.unit.Name()]
+	c.Check(workload.Status, gc.Equals, status.Maintenance)
+	c.Check(workload.Since.Equal(s.created.Add(time.Hour)), jc.IsTrue)
+
+	snapshot, err = s.State.StatusSnapshot(s.created.Add(150 * time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	workload = snapshot.UnitWorkloads[s.unit.Name()]
+	c.Check(workload.Status, gc.Equals, status.Maintenance)
+	c.Check(workload.Since.Equal(s.created.Add(2*time.Hour)), jc.IsTrue)
+}
+
+func (s *StatusSnapshotSuite) TestStatusSnapshotBeforeEntitiesExisted(c *gc.C) {
+	snapshot, err := s.State.StatusSnapshot(s.created.Add(-time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(snapshot.Machines, gc.HasLen, 0)
+	c.Check(snapshot.Applications, gc.HasLen, 0)
+	c.Check(snapshot.UnitAgents, gc.HasLen, 0)
+	c.Check(snapshot.UnitWorkloads, gc.HasLen, 0)
+}
+
+func (s *StatusSnapshotSuite) TestStatusSnapshotExcludesRemovedEntities(c *gc.C) {
+	err := s.unit.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	snapshot, err := s.State.StatusSnapshot(s.Clock.Now())
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := snapshot.UnitWorkloads[s.unit.Name()]
+	c.Check(ok, jc.IsFalse)
+	_, ok = snapshot.Machines[s.machine.Id()]
+	c.Check(ok, jc.IsTrue)
+}
@@ -0,0 +1,121 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state/docstore"
+)
+
+// StuckStatusValues returns the status values an entity can be stuck
+// in until something changes: it has failed, needs an operator, or is
+// waiting for something else in the model.
+func StuckStatusValues() []status.Status {
+	return []status.Status{status.Error, status.Blocked, status.Waiting}
+}
+
+// StuckStatus describes a status which is one of StuckStatusValues.
+type StuckStatus struct {
+	// Entity is the tag of the machine, application or unit whose
+	// status it is.
+	Entity names.Tag
+
+	// Kind is the kind of status, such as status.KindWorkload for
+	// a unit's workload status or status.KindUnitAgent for that of
+	// its agent.
+	Kind status.HistoryKind
+
+	Status  status.Status
+	Message string
+
+	// Since is when the status was last changed. Setting the same
+	// status and message again doesn't change it.
+	Since time.Time
+}
+
+// StuckStatuses returns the statuses of the machines, applications and
+// units in the model which are error, blocked or waiting, and haven't
+// changed since the given time, those stuck longest first.
+func (st *State) StuckStatuses(notChangedSince time.Time) ([]StuckStatus, error) {
+	statuses, closer := getDocStore(st.db(), statusesC)
+	defer closer()
+
+	values := StuckStatusValues()
+	in := make(docstore.A, len(values))
+	for i, value := range values {
+		in[i] = value
+	}
+	filter := docstore.D{
+		{"status", docstore.D{{"$in", in}}},
+		// Statuses from old versions of juju may not record when
+		// they were set; there's no telling how long they've been
+		// stuck.
+		{"updated", docstore.D{
+			{"$gt", 0},
+			{"$lte", notChangedSince.UnixNano()},
+		}},
+	}
+	var docs []statusDocWithID
+	if err := statuses.FindAll(filter, docstore.FindOptions{Sort: []string{"updated"}}, &docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get stuck statuses")
+	}
+	var result []StuckStatus
+	for _, doc := range docs {
+		key := st.localID(doc.ID)
+		kind := statusKind(key)
+		if kind == "" {
+			continue
+		}
+		entity := st.statusEntity(key)
+		if entity == nil {
+			continue
+		}
+		result = append(result, StuckStatus{
+			Entity:  entity,
+			Kind:    kind,
+			Status:  doc.Status,
+			Message: doc.StatusInfo,
+			Since:   time.Unix(0, doc.Updated).UTC(),
+		})
+	}
+	return result, nil
+}
+
+// statusKind returns the kind of status stored under the given global
+// key, or "" if it isn't one of the statuses of a machine, application
+// or unit.
+func statusKind(globalKey string) status.HistoryKind {
+	parts := strings.SplitN(globalKey, "#", 3)
+	if len(parts) < 2 {
+		return ""
+	}
+	var suffix string
+	if len(parts) == 3 {
+		suffix = parts[2]
+	}
+	container := names.IsContainerMachine(parts[1])
+	switch {
+	case parts[0] == "m" && suffix == "" && container:
+		return status.KindContainer
+	case parts[0] == "m" && suffix == "":
+		return status.KindMachine
+	case parts[0] == "m" && suffix == "instance" && container:
+		return status.KindContainerInstance
+	case parts[0] == "m" && suffix == "instance":
+		return status.KindMachineInstance
+	case parts[0] == "a" && suffix == "":
+		return status.KindApplication
+	case parts[0] == "u" && suffix == "":
+		return status.KindUnitAgent
+	case parts[0] == "u" && suffix == "charm":
+		return status.KindWorkload
+	}
+	return ""
+}
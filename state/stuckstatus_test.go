@@ -0,0 +1,82 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type StuckStatusSuite struct {
+	ConnSuite
+	machine *state.Machine
+	app     *state.Application
+	unit    *state.Unit
+}
+
+var _ = gc.Suite(&StuckStatusSuite{})
+
+func (s *StuckStatusSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.machine = s.Factory.MakeMachine(c, &factory.MachineParams{})
+	s.app = s.Factory.MakeApplication(c, nil)
+	s.unit = s.Factory.MakeUnit(c, &factory.UnitParams{Application: s.app, Machine: s.machine})
+}
+
+func (s *StuckStatusSuite) TestStuckStatuses(c *gc.C) {
+	base := s.Clock.Now().Add(time.Hour)
+	setStatus := func(setter status.StatusSetter, value status.Status, message string, at time.Time) {
+		err := setter.SetStatus(status.StatusInfo{Status: value, Message: message, Since: &at})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	setStatus(s.unit, status.Blocked, "need a database", base)
+	setStatus(s.unit.Agent(), status.Error, "hook failed", base.Add(time.Minute))
+	setStatus(s.machine, status.Started, "", base)
+	setStatus(s.app, status.Waiting, "waiting for peers", base.Add(2*time.Minute))
+	// Setting the same status again doesn't change how long it's
+	// been stuck for.
+	setStatus(s.unit, status.Blocked, "need a database", base.Add(3*time.Minute))
+
+	stuck, err := s.State.StuckStatuses(base.Add(time.Minute))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stuck, jc.DeepEquals, []state.StuckStatus{{
+		Entity:  s.unit.UnitTag(),
+		Kind:    status.KindWorkload,
+		Status:  status.Blocked,
+		Message: "need a database",
+		Since:   base.UTC(),
+	}, {
+		Entity:  s.unit.UnitTag(),
+		Kind:    status.KindUnitAgent,
+		Status:  status.Error,
+		Message: "hook failed",
+		Since:   base.Add(time.Minute).UTC(),
+	}})
+
+	stuck, err = s.State.StuckStatuses(base.Add(time.Hour))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stuck, gc.HasLen, 3)
+	c.Check(stuck[2].Entity, gc.Equals, names.NewApplicationTag(s.app.Name()))
+	c.Check(stuck[2].Kind, gc.Equals, status.KindApplication)
+	c.Check(stuck[2].Status, gc.Equals, status.Waiting)
+}
+
+func (s *StuckStatusSuite) TestStuckStatusesOtherModel(c *gc.C) {
+	now := s.Clock.Now()
+	err := s.unit.SetStatus(status.StatusInfo{Status: status.Blocked, Since: &now})
+	c.Assert(err, jc.ErrorIsNil)
+
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	stuck, err := st.StuckStatuses(now)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stuck, gc.HasLen, 0)
+}
@@ -12,6 +12,8 @@ import (
 	jujutxn "github.com/juju/txn"
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/controller"
 )
 
 func readTxnRevno(db Database, collectionName string, id interface{}) (int64, error) {
@@ -44,17 +46,71 @@ func (st *State) MaybePruneTransactions() error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	// Prune txns when txn count has increased by 10% since last prune.
-	return runner.MaybePruneTransactions(jujutxn.PruneOptions{
-		PruneFactor:                1.1,
-		MinNewTransactions:         1000,
-		MaxNewTransactions:         100000,
-		MaxTime:                    time.Now().Add(-time.Hour),
+	return runner.MaybePruneTransactions(pruneOptions(cfg, st.clock().Now()))
+}
+
+// pruneOptions returns the options used to prune transactions,
+// as tuned by the controller config.
+func pruneOptions(cfg controller.Config, now time.Time) jujutxn.PruneOptions {
+	// Prune txns when the txn count has increased by the configured
+	// growth percentage since the last prune.
+	opts := jujutxn.PruneOptions{
+		PruneFactor:                cfg.PruneTxnGrowthFactor(),
+		MinNewTransactions:         cfg.PruneTxnMinCount(),
+		MaxNewTransactions:         cfg.PruneTxnMaxCount(),
+		MaxTime:                    now.Add(-cfg.PruneTxnMinAge()),
 		MaxBatchTransactions:       cfg.MaxPruneTxnBatchSize(),
 		MaxBatches:                 cfg.MaxPruneTxnPasses(),
 		SmallBatchTransactionCount: cfg.PruneTxnQueryCount(),
 		BatchTransactionSleepTime:  cfg.PruneTxnSleepTime(),
-	})
+	}
+	// If the size of a pass is bounded, evaluate at most that many
+	// transactions in a single batch; anything left over is pruned
+	// by subsequent passes.
+	if passSize := cfg.PruneTxnMaxPassSize(); passSize > 0 {
+		opts.MaxBatchTransactions = passSize
+		opts.MaxBatches = 1
+	}
+	return opts
+}
+
+// TxnCollectionStats holds size information about the collections
+// used for recording transactions.
+type TxnCollectionStats struct {
+	// TxnCount is the number of documents in the txns collection.
+	TxnCount int
+
+	// TxnSizeMB is the size of the txns collection in MiB,
+	// excluding indexes.
+	TxnSizeMB int
+
+	// TxnLogCount is the number of documents in the
+	// capped txns.log collection.
+	TxnLogCount int
+}
+
+// TxnCollectionStats returns size information about the txns
+// and txns.log collections.
+func (st *State) TxnCollectionStats() (TxnCollectionStats, error) {
+	session := st.session.Copy()
+	defer session.Close()
+	db := session.DB(jujuDB)
+
+	var (
+		result TxnCollectionStats
+		err    error
+	)
+	txns := db.C(txnsC)
+	if result.TxnCount, err = txns.Count(); err != nil {
+		return result, errors.Annotate(err, "counting transactions")
+	}
+	if result.TxnSizeMB, err = getCollectionMB(txns); err != nil {
+		return result, errors.Annotate(err, "reading transaction collection size")
+	}
+	if result.TxnLogCount, err = db.C(txnLogC).Count(); err != nil {
+		return result, errors.Annotate(err, "counting transaction log entries")
+	}
+	return result, nil
 }
 
 type multiModelRunner struct {
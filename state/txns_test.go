@@ -5,6 +5,7 @@ package state
 
 import (
 	"errors"
+	"time"
 
 	jc "github.com/juju/testing/checkers"
 	jujutxn "github.com/juju/txn"
@@ -12,6 +13,7 @@ import (
 	"gopkg.in/mgo.v2/bson"
 	"gopkg.in/mgo.v2/txn"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/testing"
 )
 
@@ -470,6 +472,45 @@ func (s *MultiModelRunnerSuite) TestMaybePruneTransactionsWithError(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, "boom")
 }
 
+type PruneOptionsSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&PruneOptionsSuite{})
+
+func (s *PruneOptionsSuite) TestDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Now()
+	c.Check(pruneOptions(cfg, now), jc.DeepEquals, jujutxn.PruneOptions{
+		PruneFactor:                1.1,
+		MinNewTransactions:         1000,
+		MaxNewTransactions:         100000,
+		MaxTime:                    now.Add(-time.Hour),
+		MaxBatchTransactions:       1000000,
+		MaxBatches:                 100,
+		SmallBatchTransactionCount: 1000,
+		BatchTransactionSleepTime:  10 * time.Millisecond,
+	})
+}
+
+func (s *PruneOptionsSuite) TestMaxPassSize(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, map[string]interface{}{
+		controller.PruneTxnMaxPassSize:   50000,
+		controller.PruneTxnGrowthPercent: 25,
+		controller.PruneTxnMinAge:        "30m",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	now := time.Now()
+	opts := pruneOptions(cfg, now)
+	c.Check(opts.PruneFactor, gc.Equals, 1.25)
+	c.Check(opts.MaxTime, gc.Equals, now.Add(-30*time.Minute))
+	c.Check(opts.MaxBatchTransactions, gc.Equals, 50000)
+	c.Check(opts.MaxBatches, gc.Equals, 1)
+}
+
 // recordingRunner is fake transaction running that implements the
 // jujutxn.Runner interface. Instead of doing anything with a database
 // it simply records the transaction operations passed to it for later
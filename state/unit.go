@@ -96,6 +96,7 @@ type unitDoc struct {
 	Life                   Life
 	TxnRevno               int64 `bson:"txn-revno"`
 	PasswordHash           string
+	Maintenance            *unitMaintenanceDoc `bson:"maintenance,omitempty"`
 }
 
 // Unit represents the state of an application unit.
@@ -489,6 +490,40 @@ func (u *Unit) DestroyWithForce(force bool, maxWait time.Duration) (errs []error
 	return op.Errors, err
 }
 
+// ForceDestroySkippedSteps describes each of the cleanup steps normally
+// performed by the unit's agent which will not be performed if the unit
+// is destroyed with force while its agent is not running.
+func (u *Unit) ForceDestroySkippedSteps() ([]string, error) {
+	steps := []string{
+		fmt.Sprintf("stop and remove hooks will not run for unit %q", u.Name()),
+	}
+	relations, err := u.RelationsInScope()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, rel := range relations {
+		steps = append(steps, fmt.Sprintf(
+			"relation-departed and relation-broken hooks will not run for relation %q", rel.String()))
+	}
+	for _, name := range u.SubordinateNames() {
+		steps = append(steps, fmt.Sprintf(
+			"subordinate unit %q will be removed without running its hooks", name))
+	}
+	sb, err := NewStorageBackend(u.st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	attachments, err := sb.UnitStorageAttachments(u.UnitTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, att := range attachments {
+		steps = append(steps, fmt.Sprintf(
+			"storage %q will be detached without running storage-detaching hooks", att.StorageInstance().Id()))
+	}
+	return steps, nil
+}
+
 // DestroyOperation returns a model operation that will destroy the unit.
 func (u *Unit) DestroyOperation() *DestroyUnitOperation {
 	return &DestroyUnitOperation{
@@ -1175,6 +1210,59 @@ func (u *Unit) PrivateAddress() (corenetwork.SpaceAddress, error) {
 	return m.PrivateAddress()
 }
 
+// PrimaryAddress returns the address advertised as the unit's primary
+// address, chosen from the addresses of its machine according to pref.
+// With PreferAutoAddress, and for units which are not assigned to
+// machines, it is the unit's public address. If no address is available
+// it returns an error that satisfies network.IsNoAddressError().
+func (u *Unit) PrimaryAddress(pref corenetwork.UnitAddressPreference) (corenetwork.SpaceAddress, error) {
+	if pref == corenetwork.PreferAutoAddress || !u.ShouldBeAssigned() {
+		return u.PublicAddress()
+	}
+	m, err := u.machine()
+	if err != nil {
+		return corenetwork.SpaceAddress{}, errors.Trace(err)
+	}
+
+	candidates := m.Addresses()
+	var spaceID string
+	if pref == corenetwork.PreferBindingAddress {
+		// Machine addresses don't record their spaces, so look
+		// them up from the subnets of the machine's devices.
+		app, err := u.Application()
+		if err != nil {
+			return corenetwork.SpaceAddress{}, errors.Trace(err)
+		}
+		bindings, err := app.EndpointBindings()
+		if err != nil {
+			return corenetwork.SpaceAddress{}, errors.Trace(err)
+		}
+		spaceID = bindings.Map()[""]
+		bySpace, err := m.AddressesBySpaceID()
+		if err != nil {
+			return corenetwork.SpaceAddress{}, errors.Trace(err)
+		}
+		candidates = nil
+		for id, addrs := range bySpace {
+			for _, addr := range addrs {
+				addr.SpaceID = id
+				candidates = append(candidates, addr)
+			}
+		}
+		corenetwork.SortAddresses(candidates)
+	}
+	// The machine's preferred public address is used if none
+	// of its addresses match the preference.
+	if public, err := m.PublicAddress(); err == nil {
+		candidates = append(corenetwork.SpaceAddresses{public}, candidates...)
+	}
+	addr, ok := pref.Primary(candidates, spaceID)
+	if !ok {
+		return corenetwork.SpaceAddress{}, network.NoAddressError("primary")
+	}
+	return addr, nil
+}
+
 // AllAddresses returns the public and private addresses
 // plus the container address of the unit (if known).
 // Only relevant for CAAS models - will return an empty
@@ -1370,8 +1458,17 @@ func (u *Unit) Status() (status.StatusInfo, error) {
 // the effort to separate Unit from UnitAgent. Now the SetStatus for UnitAgent is in
 // the UnitAgent struct.
 func (u *Unit) SetStatus(unitStatus status.StatusInfo) error {
+	params, err := u.setStatusParams(unitStatus)
+	if err != nil {
+		return err
+	}
+	return setStatus(u.st.db(), params)
+}
+
+// setStatusParams is part of the batchStatusSetter interface.
+func (u *Unit) setStatusParams(unitStatus status.StatusInfo) (setStatusParams, error) {
 	if !status.ValidWorkloadStatus(unitStatus.Status) {
-		return errors.Errorf("cannot set invalid status %q", unitStatus.Status)
+		return setStatusParams{}, errors.Errorf("cannot set invalid status %q", unitStatus.Status)
 	}
 
 	var newHistory *statusDoc
@@ -1382,28 +1479,58 @@ func (u *Unit) SetStatus(unitStatus status.StatusInfo) error {
 		cloudContainerStatus, err := getStatus(u.st.db(), globalCloudContainerKey(u.Name()), "cloud container")
 		if err != nil {
 			if !errors.IsNotFound(err) {
-				return errors.Trace(err)
+				return setStatusParams{}, errors.Trace(err)
 			}
 		}
 		expectWorkload, err := expectWorkload(u.st, u.ApplicationName())
 		if err != nil {
-			return errors.Trace(err)
+			return setStatusParams{}, errors.Trace(err)
 		}
 		newHistory, err = caasHistoryRewriteDoc(unitStatus, cloudContainerStatus, expectWorkload, caasUnitDisplayStatus, u.st.clock())
 		if err != nil {
-			return errors.Trace(err)
+			return setStatusParams{}, errors.Trace(err)
 		}
 	}
 
-	return setStatus(u.st.db(), setStatusParams{
+	updated := timeOrNow(unitStatus.Since, u.st.clock())
+	params := setStatusParams{
 		badge:            "unit",
 		globalKey:        u.globalKey(),
 		status:           unitStatus.Status,
 		message:          unitStatus.Message,
 		rawData:          unitStatus.Data,
-		updated:          timeOrNow(unitStatus.Since, u.st.clock()),
+		updated:          updated,
 		historyOverwrite: newHistory,
-	})
+		eventOp: func() (txn.Op, error) {
+			return addModelEventOp(u.st, ModelEventStatusChanged, u.Tag().String(), map[string]string{
+				"status":  string(unitStatus.Status),
+				"message": unitStatus.Message,
+			})
+		},
+	}
+	// The uniter reports that it is initialising when it first
+	// starts after the unit has been deployed.
+	if u.modelType == ModelTypeIAAS &&
+		unitStatus.Status == status.Waiting && unitStatus.Message == status.MessageInitializingAgent {
+		params.onSet = func() { u.recordDeployed(*updated) }
+	}
+	return params, nil
+}
+
+// recordDeployed records in the provisioning timeline of the unit's
+// machine that the unit has been deployed.
+func (u *Unit) recordDeployed(when time.Time) {
+	machineId, err := u.AssignedMachineId()
+	if err != nil {
+		logger.Warningf("cannot record deployment of unit %q: %v", u.Name(), err)
+		return
+	}
+	m, err := u.st.Machine(machineId)
+	if err != nil {
+		logger.Warningf("cannot record deployment of unit %q: %v", u.Name(), err)
+		return
+	}
+	m.recordProvisioningPhase(status.PhaseUnitDeployed, u.Name(), when)
 }
 
 // OpenPortsOnSubnet opens the given port range and protocol for the unit on the
@@ -1635,6 +1762,15 @@ func (u *Unit) SetCharmURL(curl *charm.URL) error {
 				Update: bson.D{{"$set", bson.D{{"charmurl", curl}}}},
 			})
 		if u.doc.CharmURL != nil {
+			eventOp, err := addModelEventOp(u.st, ModelEventUpgradeCompleted, u.Tag().String(), map[string]string{
+				"charm-url":          curl.String(),
+				"previous-charm-url": u.doc.CharmURL.String(),
+			})
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, eventOp)
+
 			// Drop the reference to the old charm.
 			// Since we can force this now, let's.. There is no point hanging on to the old charm.
 			op := &ForcedOperation{Force: true}
@@ -1869,6 +2005,10 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 	); err != nil {
 		return nil, errors.Trace(err)
 	}
+	colocationRules, err := m.checkColocation(u.doc.Application, u.doc.Name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	storageOps, volumesAttached, filesystemsAttached, err := sb.hostStorageOps(m.doc.Id, storageParams)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -1899,6 +2039,12 @@ func (u *Unit) assignToMachineOps(m *Machine, unused bool) ([]txn.Op, error) {
 	if unused {
 		massert = append(massert, bson.D{{"clean", bson.D{{"$ne", false}}}}...)
 	}
+	if colocationRules {
+		// The colocation rules were checked against the machine's
+		// principals, so they must not change while we're assigning
+		// the unit.
+		massert = append(massert, bson.D{{"principals", m.doc.Principals}}...)
+	}
 	ops := []txn.Op{{
 		C:      unitsC,
 		Id:     u.doc.DocID,
@@ -2681,6 +2827,12 @@ func (u *Unit) findCleanMachineQuery(requireEmpty bool, cons *constraints.Value)
 	if cons.HasCpuPower() {
 		suitableTerms = append(suitableTerms, bson.DocElem{"cpupower", bson.D{{"$gte", *cons.CpuPower}}})
 	}
+	if cons.HasGPUs() {
+		suitableTerms = append(suitableTerms, bson.DocElem{"gpus", bson.D{{"$gte", *cons.GPUs}}})
+	}
+	if cons.HasGPUType() {
+		suitableTerms = append(suitableTerms, bson.DocElem{"gputype", *cons.GPUType})
+	}
 	if cons.Tags != nil && len(*cons.Tags) > 0 {
 		suitableTerms = append(suitableTerms, bson.DocElem{"tags", bson.D{{"$all", *cons.Tags}}})
 	}
@@ -2906,6 +3058,9 @@ func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, e
 	if len(name) == 0 {
 		return nil, errors.New("no action name given")
 	}
+	if name == actions.JujuIntrospectActionName {
+		return nil, errors.Errorf("cannot add action %q to a unit; it is run by the unit's machine", name)
+	}
 
 	// If the action is predefined inside juju, get spec from map
 	spec, ok := actions.PredefinedActionsSpec[name]
@@ -335,6 +335,28 @@ func (s *UnitSuite) addSubordinateUnit(c *gc.C) *state.Unit {
 	return subUnit
 }
 
+func (s *UnitSuite) TestForceDestroySkippedSteps(c *gc.C) {
+	steps, err := s.unit.ForceDestroySkippedSteps()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(steps, jc.DeepEquals, []string{
+		`stop and remove hooks will not run for unit "wordpress/0"`,
+	})
+}
+
+func (s *UnitSuite) TestForceDestroySkippedStepsWithRelationsAndSubordinates(c *gc.C) {
+	s.addSubordinateUnit(c)
+	err := s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+
+	steps, err := s.unit.ForceDestroySkippedSteps()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(steps, jc.DeepEquals, []string{
+		`stop and remove hooks will not run for unit "wordpress/0"`,
+		`relation-departed and relation-broken hooks will not run for relation "logging:info wordpress:juju-info"`,
+		`subordinate unit "logging/0" will be removed without running its hooks`,
+	})
+}
+
 func (s *UnitSuite) setAssignedMachineAddresses(c *gc.C, u *state.Unit) {
 	mid, err := u.AssignedMachineId()
 	if errors.IsNotAssigned(err) {
@@ -393,6 +415,36 @@ func (s *UnitSuite) TestPublicAddress(c *gc.C) {
 	c.Check(address.Value, gc.Equals, "8.8.8.8")
 }
 
+func (s *UnitSuite) TestPrimaryAddress(c *gc.C) {
+	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.AssignToMachine(machine)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.unit.PrimaryAddress(corenetwork.PreferFQDNAddress)
+	c.Assert(err, jc.Satisfies, network.IsNoAddressError)
+
+	public := corenetwork.NewScopedSpaceAddress("8.8.8.8", corenetwork.ScopePublic)
+	private := corenetwork.NewScopedSpaceAddress("10.0.0.1", corenetwork.ScopeCloudLocal)
+	fqdn := corenetwork.NewScopedSpaceAddress("node-0.example.com", corenetwork.ScopePublic)
+	err = machine.SetProviderAddresses(public, private, fqdn)
+	c.Assert(err, jc.ErrorIsNil)
+
+	for pref, expected := range map[corenetwork.UnitAddressPreference]string{
+		corenetwork.PreferAutoAddress:   "8.8.8.8",
+		corenetwork.PreferPublicAddress: "8.8.8.8",
+		corenetwork.PreferFQDNAddress:   "node-0.example.com",
+		// There are no link-layer devices, so no address is known
+		// to be in the default binding's space.
+		corenetwork.PreferBindingAddress: "8.8.8.8",
+	} {
+		c.Logf("preference %q", pref)
+		address, err := s.unit.PrimaryAddress(pref)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(address.Value, gc.Equals, expected)
+	}
+}
+
 func (s *UnitSuite) TestStablePrivateAddress(c *gc.C) {
 	machine, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
@@ -2127,6 +2179,11 @@ snapshot:
 			actionName: "baiku",
 			errString:  `action "baiku" not defined on unit "wordpress-actions/0"`,
 		},
+		{
+			actionName:   "juju-introspect",
+			givenPayload: map[string]interface{}{"agent": "unit-wordpress-actions-0", "section": "depengine"},
+			errString:    `cannot add action "juju-introspect" to a unit; it is run by the unit's machine`,
+		},
 	}
 
 	for i, t := range tests {
@@ -58,12 +58,21 @@ func (u *UnitAgent) Status() (status.StatusInfo, error) {
 // SetStatus sets the status of the unit agent. The optional values
 // allow to pass additional helpful status data.
 func (u *UnitAgent) SetStatus(unitAgentStatus status.StatusInfo) (err error) {
+	params, err := u.setStatusParams(unitAgentStatus)
+	if err != nil {
+		return err
+	}
+	return setStatus(u.st.db(), params)
+}
+
+// setStatusParams is part of the batchStatusSetter interface.
+func (u *UnitAgent) setStatusParams(unitAgentStatus status.StatusInfo) (setStatusParams, error) {
 	unit, err := u.st.Unit(u.name)
 	if errors.IsNotFound(err) {
-		return errors.Annotate(errors.NotFoundf("agent"), "cannot set status")
+		return setStatusParams{}, errors.Annotate(errors.NotFoundf("agent"), "cannot set status")
 	}
 	if err != nil {
-		return errors.Trace(err)
+		return setStatusParams{}, errors.Trace(err)
 	}
 	isAssigned := unit.doc.MachineId != ""
 	shouldBeAssigned := unit.ShouldBeAssigned()
@@ -72,34 +81,41 @@ func (u *UnitAgent) SetStatus(unitAgentStatus status.StatusInfo) (err error) {
 	switch unitAgentStatus.Status {
 	case status.Idle, status.Executing, status.Rebooting, status.Failed:
 		if !isAssigned && isPrincipal && shouldBeAssigned {
-			return errors.Errorf("cannot set status %q until unit is assigned", unitAgentStatus.Status)
+			return setStatusParams{}, errors.Errorf("cannot set status %q until unit is assigned", unitAgentStatus.Status)
 		}
 	case status.Error:
 		if unitAgentStatus.Message == "" {
-			return errors.Errorf("cannot set status %q without info", unitAgentStatus.Status)
+			return setStatusParams{}, errors.Errorf("cannot set status %q without info", unitAgentStatus.Status)
 		}
 	case status.Allocating:
 		if isAssigned {
-			return errors.Errorf("cannot set status %q as unit is already assigned", unitAgentStatus.Status)
+			return setStatusParams{}, errors.Errorf("cannot set status %q as unit is already assigned", unitAgentStatus.Status)
 		}
 	case status.Running:
 		// Only CAAS units (those that require assignment) can have a status of running.
 		if shouldBeAssigned {
-			return errors.Errorf("cannot set invalid status %q", unitAgentStatus.Status)
+			return setStatusParams{}, errors.Errorf("cannot set invalid status %q", unitAgentStatus.Status)
 		}
 	case status.Lost:
-		return errors.Errorf("cannot set status %q", unitAgentStatus.Status)
+		return setStatusParams{}, errors.Errorf("cannot set status %q", unitAgentStatus.Status)
+	case status.Gone:
+		// Set by the controller when the agent has been lost for
+		// longer than the model's dead agent threshold.
+	case status.Maintenance:
+		if unit.doc.Maintenance == nil {
+			return setStatusParams{}, errors.Errorf("cannot set status %q as unit is not in maintenance", unitAgentStatus.Status)
+		}
 	default:
-		return errors.Errorf("cannot set invalid status %q", unitAgentStatus.Status)
+		return setStatusParams{}, errors.Errorf("cannot set invalid status %q", unitAgentStatus.Status)
 	}
-	return setStatus(u.st.db(), setStatusParams{
+	return setStatusParams{
 		badge:     "agent",
 		globalKey: u.globalKey(),
 		status:    unitAgentStatus.Status,
 		message:   unitAgentStatus.Message,
 		rawData:   unitAgentStatus.Data,
 		updated:   timeOrNow(unitAgentStatus.Since, u.st.clock()),
-	})
+	}, nil
 }
 
 // StatusHistory returns a slice of at most filter.Size StatusInfo items
@@ -0,0 +1,85 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+
+	"github.com/juju/juju/core/status"
+)
+
+// unitMaintenanceDoc records that an operator has put a unit into
+// maintenance. It is stored on the unit's document.
+type unitMaintenanceDoc struct {
+	Message string `bson:"message,omitempty"`
+}
+
+// Maintenance returns the message given when the unit was put into
+// maintenance, and whether it is in maintenance.
+func (u *Unit) Maintenance() (string, bool) {
+	if u.doc.Maintenance == nil {
+		return "", false
+	}
+	return u.doc.Maintenance.Message, true
+}
+
+// SetMaintenance puts the unit into maintenance, with the given message
+// describing why. While a unit is in maintenance its agent runs no hooks,
+// and the unit may not be elected leader of its application. The unit's
+// agent status is set to maintenance, so the change is recorded in the
+// unit's status history.
+//
+// Putting a unit which is already in maintenance into maintenance again
+// replaces its message.
+func (u *Unit) SetMaintenance(message string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot put unit %q into maintenance", u)
+	doc := &unitMaintenanceDoc{Message: message}
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: isAliveDoc,
+		Update: bson.D{{"$set", bson.D{{"maintenance", doc}}}},
+	}}
+	if err := u.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.New("unit is not alive")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	u.doc.Maintenance = doc
+	now := u.st.clock().Now()
+	return u.SetAgentStatus(status.StatusInfo{
+		Status:  status.Maintenance,
+		Message: message,
+		Since:   &now,
+	})
+}
+
+// ClearMaintenance takes the unit out of maintenance, allowing its agent
+// to run hooks and the unit to be elected leader once more. The unit's
+// agent status is set to idle.
+func (u *Unit) ClearMaintenance() (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot take unit %q out of maintenance", u)
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: bson.D{{"maintenance", bson.D{{"$exists", true}}}},
+		Update: bson.D{{"$unset", bson.D{{"maintenance", nil}}}},
+	}}
+	if err := u.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		if err := u.Refresh(); err != nil {
+			return errors.Trace(err)
+		}
+		return errors.New("unit is not in maintenance")
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	u.doc.Maintenance = nil
+	now := u.st.clock().Now()
+	return u.SetAgentStatus(status.StatusInfo{
+		Status: status.Idle,
+		Since:  &now,
+	})
+}
@@ -0,0 +1,115 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+)
+
+type UnitMaintenanceSuite struct {
+	ConnSuite
+	unit *state.Unit
+}
+
+var _ = gc.Suite(&UnitMaintenanceSuite{})
+
+func (s *UnitMaintenanceSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.unit = s.Factory.MakeUnit(c, nil)
+	s.Clock.Advance(time.Second)
+	err := s.unit.SetAgentStatus(status.StatusInfo{Status: status.Idle})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *UnitMaintenanceSuite) assertAgentStatus(c *gc.C, expect status.Status, message string) {
+	statusInfo, err := s.unit.AgentStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(statusInfo.Status, gc.Equals, expect)
+	c.Check(statusInfo.Message, gc.Equals, message)
+}
+
+func (s *UnitMaintenanceSuite) TestNotInMaintenance(c *gc.C) {
+	message, ok := s.unit.Maintenance()
+	c.Check(ok, jc.IsFalse)
+	c.Check(message, gc.Equals, "")
+}
+
+func (s *UnitMaintenanceSuite) TestSetMaintenance(c *gc.C) {
+	err := s.unit.SetMaintenance("disk swap")
+	c.Assert(err, jc.ErrorIsNil)
+	message, ok := s.unit.Maintenance()
+	c.Check(ok, jc.IsTrue)
+	c.Check(message, gc.Equals, "disk swap")
+	s.assertAgentStatus(c, status.Maintenance, "disk swap")
+
+	unit, err := s.State.Unit(s.unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	message, ok = unit.Maintenance()
+	c.Check(ok, jc.IsTrue)
+	c.Check(message, gc.Equals, "disk swap")
+}
+
+func (s *UnitMaintenanceSuite) TestSetMaintenanceReplacesMessage(c *gc.C) {
+	err := s.unit.SetMaintenance("disk swap")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetMaintenance("kernel upgrade")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	message, ok := s.unit.Maintenance()
+	c.Check(ok, jc.IsTrue)
+	c.Check(message, gc.Equals, "kernel upgrade")
+	s.assertAgentStatus(c, status.Maintenance, "kernel upgrade")
+}
+
+func (s *UnitMaintenanceSuite) TestSetMaintenanceNotAlive(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.SetMaintenance("disk swap")
+	c.Assert(err, gc.ErrorMatches, `cannot put unit "wordpress/0" into maintenance: unit is not alive`)
+}
+
+func (s *UnitMaintenanceSuite) TestClearMaintenance(c *gc.C) {
+	err := s.unit.SetMaintenance("disk swap")
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.ClearMaintenance()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := s.unit.Maintenance()
+	c.Check(ok, jc.IsFalse)
+	s.assertAgentStatus(c, status.Idle, "")
+
+	err = s.unit.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok = s.unit.Maintenance()
+	c.Check(ok, jc.IsFalse)
+}
+
+func (s *UnitMaintenanceSuite) TestClearMaintenanceNotInMaintenance(c *gc.C) {
+	err := s.unit.ClearMaintenance()
+	c.Assert(err, gc.ErrorMatches, `cannot take unit "wordpress/0" out of maintenance: unit is not in maintenance`)
+}
+
+func (s *UnitMaintenanceSuite) TestMaintenanceRecordedInHistory(c *gc.C) {
+	s.Clock.Advance(time.Second)
+	err := s.unit.SetMaintenance("disk swap")
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Second)
+	err = s.unit.ClearMaintenance()
+	c.Assert(err, jc.ErrorIsNil)
+
+	history, err := s.unit.AgentHistory().StatusHistory(status.StatusHistoryFilter{Size: 3})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(history, gc.HasLen, 3)
+	c.Check(history[0].Status, gc.Equals, status.Idle)
+	c.Check(history[1].Status, gc.Equals, status.Maintenance)
+	c.Check(history[1].Message, gc.Equals, "disk swap")
+	c.Check(history[2].Status, gc.Equals, status.Idle)
+}
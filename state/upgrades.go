@@ -2836,3 +2836,80 @@ func RemoveControllerConfigMaxLogAgeAndSize(pool *StatePool) error {
 	}
 	return nil
 }
+
+// AddAnnotationPairs adds the indexed "key=value" pairs to annotation
+// documents written before entities could be found by their
+// annotations.
+func AddAnnotationPairs(pool *StatePool) error {
+	st := pool.SystemState()
+	coll, closer := st.db().GetRawCollection(annotationsC)
+	defer closer()
+
+	var docs []struct {
+		DocID       string            `bson:"_id"`
+		Annotations map[string]string `bson:"annotations"`
+	}
+	if err := coll.Find(bson.D{{"pairs", bson.D{{"$exists", false}}}}).All(&docs); err != nil {
+		return errors.Trace(err)
+	}
+	var ops []txn.Op
+	for _, doc := range docs {
+		ops = append(ops, txn.Op{
+			C:      annotationsC,
+			Id:     doc.DocID,
+			Assert: bson.D{{"pairs", bson.D{{"$exists", false}}}},
+			Update: bson.D{{"$set", bson.D{{"pairs", annotationPairs(doc.Annotations)}}}},
+		})
+	}
+	if len(ops) > 0 {
+		return errors.Trace(st.runRawTransaction(ops))
+	}
+	return nil
+}
+
+// statusNeverSetBatchSize is the number of status documents
+// ReplaceStatusNeverSet updates in each transaction.
+var statusNeverSetBatchSize = 1000
+
+// ReplaceStatusNeverSet replaces the neverset field of status documents,
+// which was only ever true for applications whose status had not been
+// set, with explicitly-set, which is true for those whose status has.
+// The documents are updated in batches, one transaction per batch, so
+// controllers with many models don't build a single huge transaction.
+func ReplaceStatusNeverSet(pool *StatePool) error {
+	st := pool.SystemState()
+	coll, closer := st.db().GetRawCollection(statusesC)
+	defer closer()
+
+	query := coll.Find(bson.D{{"neverset", bson.D{{"$exists", true}}}}).Sort("_id").Limit(statusNeverSetBatchSize)
+	for {
+		var docs []struct {
+			DocID    string `bson:"_id"`
+			NeverSet bool   `bson:"neverset"`
+		}
+		if err := query.All(&docs); err != nil {
+			return errors.Trace(err)
+		}
+		if len(docs) == 0 {
+			return nil
+		}
+		ops := make([]txn.Op, 0, len(docs))
+		for _, doc := range docs {
+			update := bson.D{{"$unset", bson.D{{"neverset", nil}}}}
+			if !doc.NeverSet {
+				update = append(update, bson.DocElem{"$set", bson.D{{"explicitly-set", true}}})
+			}
+			ops = append(ops, txn.Op{
+				C:      statusesC,
+				Id:     doc.DocID,
+				Assert: bson.D{{"neverset", doc.NeverSet}},
+				Update: update,
+			})
+		}
+		// Each batch removes the neverset field from the documents
+		// it updates, so the query finds the next batch.
+		if err := st.runRawTransaction(ops); err != nil {
+			return errors.Trace(err)
+		}
+	}
+}
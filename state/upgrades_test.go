@@ -2135,7 +2135,6 @@ func (s *upgradesSuite) TestAddRelationStatus(c *gc.C) {
 		"statusdata": bson.M{},
 		"statusinfo": "",
 		"updated":    int64(123),
-		"neverset":   false,
 	}, {
 		"_id":        s.state.ModelUUID() + ":r#1",
 		"model-uuid": s.state.ModelUUID(),
@@ -2143,7 +2142,6 @@ func (s *upgradesSuite) TestAddRelationStatus(c *gc.C) {
 		"statusdata": bson.M{},
 		"statusinfo": "",
 		"updated":    int64(123),
-		"neverset":   false,
 	}, {
 		"_id":        s.state.ModelUUID() + ":r#2",
 		"model-uuid": s.state.ModelUUID(),
@@ -3068,7 +3066,6 @@ func (s *upgradesSuite) TestEnsureDefaultModificationStatus(c *gc.C) {
 			"status":     "idle",
 			"statusinfo": "",
 			"statusdata": bson.M{},
-			"neverset":   false,
 			"updated":    int64(1),
 		}, {
 			"_id":        uuid2 + ":m#1#modification",
@@ -3076,7 +3073,6 @@ func (s *upgradesSuite) TestEnsureDefaultModificationStatus(c *gc.C) {
 			"status":     "idle",
 			"statusinfo": "",
 			"statusdata": bson.M{},
-			"neverset":   false,
 			"updated":    int64(1),
 		},
 	}
@@ -4173,6 +4169,111 @@ func (s *upgradesSuite) TestRemoveControllerConfigMaxLogAgeAndSize(c *gc.C) {
 	s.assertUpgradedData(c, RemoveControllerConfigMaxLogAgeAndSize, upgradedData(settingsColl, expectedSettings))
 }
 
+func (s *upgradesSuite) TestAddAnnotationPairs(c *gc.C) {
+	coll, closer := s.state.db().GetRawCollection(annotationsC)
+	defer closer()
+	_, err := coll.RemoveAll(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	uuid := s.state.ModelUUID()
+	err = coll.Insert(bson.M{
+		"_id":         uuid + ":a#mysql",
+		"model-uuid":  uuid,
+		"globalkey":   "a#mysql",
+		"tag":         "application-mysql",
+		"annotations": bson.M{"team": "payments", "tier": "gold"},
+	}, bson.M{
+		"_id":         uuid + ":e",
+		"model-uuid":  uuid,
+		"globalkey":   "e",
+		"tag":         "model-" + uuid,
+		"annotations": bson.M{"team": "billing"},
+		"pairs":       []string{"team=billing"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := []bson.M{{
+		"_id":         uuid + ":a#mysql",
+		"model-uuid":  uuid,
+		"globalkey":   "a#mysql",
+		"tag":         "application-mysql",
+		"annotations": bson.M{"team": "payments", "tier": "gold"},
+		"pairs":       []interface{}{"team=payments", "tier=gold"},
+	}, {
+		"_id":         uuid + ":e",
+		"model-uuid":  uuid,
+		"globalkey":   "e",
+		"tag":         "model-" + uuid,
+		"annotations": bson.M{"team": "billing"},
+		"pairs":       []interface{}{"team=billing"},
+	}}
+	s.assertUpgradedData(c, AddAnnotationPairs, upgradedData(coll, expected))
+}
+
+func (s *upgradesSuite) TestReplaceStatusNeverSet(c *gc.C) {
+	// Update one document per transaction to check the batching.
+	s.PatchValue(&statusNeverSetBatchSize, 1)
+	coll, closer := s.state.db().GetRawCollection(statusesC)
+	defer closer()
+	_, err := coll.RemoveAll(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	uuid := s.state.ModelUUID()
+	err = coll.Insert(bson.M{
+		"_id":        uuid + ":a#mysql",
+		"model-uuid": uuid,
+		"status":     "waiting",
+		"statusinfo": "waiting for machine",
+		"statusdata": bson.M{},
+		"updated":    int64(1),
+		"neverset":   true,
+	}, bson.M{
+		"_id":        uuid + ":a#wordpress",
+		"model-uuid": uuid,
+		"status":     "active",
+		"statusinfo": "",
+		"statusdata": bson.M{},
+		"updated":    int64(2),
+		"neverset":   false,
+	}, bson.M{
+		"_id":            uuid + ":u#wordpress/0",
+		"model-uuid":     uuid,
+		"status":         "idle",
+		"statusinfo":     "",
+		"statusdata":     bson.M{},
+		"updated":        int64(3),
+		"explicitly-set": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	expected := bsonMById{{
+		"_id":        uuid + ":a#mysql",
+		"model-uuid": uuid,
+		"status":     "waiting",
+		"statusinfo": "waiting for machine",
+		"statusdata": bson.M{},
+		"updated":    int64(1),
+	}, {
+		"_id":            uuid + ":a#wordpress",
+		"model-uuid":     uuid,
+		"status":         "active",
+		"statusinfo":     "",
+		"statusdata":     bson.M{},
+		"updated":        int64(2),
+		"explicitly-set": true,
+	}, {
+		"_id":            uuid + ":u#wordpress/0",
+		"model-uuid":     uuid,
+		"status":         "idle",
+		"statusinfo":     "",
+		"statusdata":     bson.M{},
+		"updated":        int64(3),
+		"explicitly-set": true,
+	}}
+	sort.Sort(expected)
+	s.assertUpgradedData(c, ReplaceStatusNeverSet, upgradedData(coll, expected))
+}
+
 func (s *upgradesSuite) makeMachine(c *gc.C, uuid, id string, life Life) {
 	col, closer := s.state.db().GetRawCollection(machinesC)
 	defer closer()
@@ -0,0 +1,388 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state/docstore"
+)
+
+// ModelInconsistency describes a document in a model which refers to
+// another that doesn't exist, or which disagrees with the documents it
+// refers to.
+type ModelInconsistency struct {
+	// Collection and ID identify the document with the problem. ID is
+	// the document's id within the model, not its _id.
+	Collection string
+	ID         string
+
+	// Problem describes what is wrong.
+	Problem string
+
+	// Repair suggests how to fix it.
+	Repair string
+}
+
+// VerifyModel checks the references between the machines, units,
+// applications, relations and statuses of the model, and returns the
+// inconsistencies found, ordered by collection and id. It doesn't
+// change anything.
+func (st *State) VerifyModel() ([]ModelInconsistency, error) {
+	open := func(name string) (docstore.Collection, SessionCloser, error) {
+		coll, closer := getDocStore(st.db(), name)
+		return coll, closer, nil
+	}
+	result, err := verifyModel(st.ModelUUID(), open)
+	return result, errors.Annotate(err, "cannot verify model")
+}
+
+// DumpedModelVerification holds the inconsistencies found in a model
+// in a database dump.
+type DumpedModelVerification struct {
+	UUID            string
+	Name            string
+	Owner           string
+	Inconsistencies []ModelInconsistency
+}
+
+// VerifyDumpedModels does what State.VerifyModel does for each model
+// in the juju database dumped by mongodump into dumpDir, such as the
+// dump in a backup archive. No database is needed. The models are
+// ordered by owner and name.
+func VerifyDumpedModels(dumpDir string) (_ []DumpedModelVerification, err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot verify models dumped in %s", dumpDir)
+	colls := make(map[string]docstore.Collection)
+	open := func(name string) (docstore.Collection, SessionCloser, error) {
+		if coll, ok := colls[name]; ok {
+			return coll, func() {}, nil
+		}
+		coll, err := readDumpedCollection(dumpDir, name)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		colls[name] = coll
+		return coll, func() {}, nil
+	}
+
+	models, _, err := open(modelsC)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var docs []modelDoc
+	if err := models.FindAll(nil, docstore.FindOptions{}, &docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		if docs[i].Owner != docs[j].Owner {
+			return docs[i].Owner < docs[j].Owner
+		}
+		return docs[i].Name < docs[j].Name
+	})
+	result := make([]DumpedModelVerification, len(docs))
+	for i, doc := range docs {
+		inconsistencies, err := verifyModel(doc.UUID, open)
+		if err != nil {
+			return nil, errors.Annotatef(err, "model %q", doc.Name)
+		}
+		result[i] = DumpedModelVerification{
+			UUID:            doc.UUID,
+			Name:            doc.Name,
+			Owner:           doc.Owner,
+			Inconsistencies: inconsistencies,
+		}
+	}
+	return result, nil
+}
+
+// readDumpedCollection reads the named collection of the juju database
+// from a mongodump output directory. mongodump doesn't write files for
+// collections that don't exist, so a missing file is read as an empty
+// collection.
+func readDumpedCollection(dumpDir, name string) (docstore.Collection, error) {
+	f, err := os.Open(filepath.Join(dumpDir, jujuDB, name+".bson"))
+	if os.IsNotExist(err) {
+		return docstore.ReadDumpCollection(name, strings.NewReader(""))
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer f.Close()
+	coll, err := docstore.ReadDumpCollection(name, f)
+	return coll, errors.Trace(err)
+}
+
+// modelVerifier holds the documents of a model which are checked, and
+// the inconsistencies found between them.
+type modelVerifier struct {
+	modelUUID string
+
+	machines           []machineDoc
+	units              []unitDoc
+	applications       []applicationDoc
+	remoteApplications []remoteApplicationDoc
+	relations          []relationDoc
+	relationScopes     []relationScopeDoc
+	statuses           []statusDocWithID
+
+	machineIds       set.Strings
+	unitNames        set.Strings
+	applicationNames set.Strings
+	remoteAppNames   set.Strings
+	relationIds      map[int]bool
+	statusKeys       set.Strings
+
+	result []ModelInconsistency
+}
+
+func verifyModel(
+	modelUUID string,
+	open func(name string) (docstore.Collection, SessionCloser, error),
+) ([]ModelInconsistency, error) {
+	v := &modelVerifier{modelUUID: modelUUID}
+	load := func(name string, docs interface{}) error {
+		coll, closer, err := open(name)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer closer()
+		filter := docstore.D{{"model-uuid", modelUUID}}
+		return errors.Annotatef(coll.FindAll(filter, docstore.FindOptions{}, docs), "cannot read %s", name)
+	}
+	for _, coll := range []struct {
+		name string
+		docs interface{}
+	}{
+		{machinesC, &v.machines},
+		{unitsC, &v.units},
+		{applicationsC, &v.applications},
+		{remoteApplicationsC, &v.remoteApplications},
+		{relationsC, &v.relations},
+		{relationScopesC, &v.relationScopes},
+		{statusesC, &v.statuses},
+	} {
+		if err := load(coll.name, coll.docs); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	v.index()
+	v.checkUnits()
+	v.checkMachines()
+	v.checkApplications()
+	v.checkRelations()
+	v.checkStatuses()
+	sort.SliceStable(v.result, func(i, j int) bool {
+		if v.result[i].Collection != v.result[j].Collection {
+			return v.result[i].Collection < v.result[j].Collection
+		}
+		return v.result[i].ID < v.result[j].ID
+	})
+	return v.result, nil
+}
+
+func (v *modelVerifier) index() {
+	v.machineIds = set.NewStrings()
+	for _, m := range v.machines {
+		v.machineIds.Add(m.Id)
+	}
+	v.unitNames = set.NewStrings()
+	for _, u := range v.units {
+		v.unitNames.Add(u.Name)
+	}
+	v.applicationNames = set.NewStrings()
+	for _, app := range v.applications {
+		v.applicationNames.Add(app.Name)
+	}
+	v.remoteAppNames = set.NewStrings()
+	for _, app := range v.remoteApplications {
+		v.remoteAppNames.Add(app.Name)
+	}
+	v.relationIds = make(map[int]bool)
+	for _, rel := range v.relations {
+		v.relationIds[rel.Id] = true
+	}
+	v.statusKeys = set.NewStrings()
+	for _, doc := range v.statuses {
+		v.statusKeys.Add(v.localID(doc.ID))
+	}
+}
+
+func (v *modelVerifier) localID(id string) string {
+	return strings.TrimPrefix(id, v.modelUUID+":")
+}
+
+func (v *modelVerifier) report(collection, id, repair, problem string, args ...interface{}) {
+	v.result = append(v.result, ModelInconsistency{
+		Collection: collection,
+		ID:         id,
+		Problem:    fmt.Sprintf(problem, args...),
+		Repair:     repair,
+	})
+}
+
+// reportMissingStatus reports that there is no status with the given
+// key for the entity with the given id.
+func (v *modelVerifier) reportMissingStatus(collection, id, key string) {
+	if !v.statusKeys.Contains(key) {
+		v.report(collection, id,
+			fmt.Sprintf("insert a status document with id %q and status unknown", key),
+			"status %q not found", key)
+	}
+}
+
+func (v *modelVerifier) checkUnits() {
+	principals := make(map[string]set.Strings)
+	for _, m := range v.machines {
+		principals[m.Id] = set.NewStrings(m.Principals...)
+	}
+	for _, u := range v.units {
+		if !v.applicationNames.Contains(u.Application) {
+			v.report(unitsC, u.Name, "remove the unit document",
+				"application %q not found", u.Application)
+		}
+		if u.Principal != "" {
+			if !v.unitNames.Contains(u.Principal) {
+				v.report(unitsC, u.Name, "remove the unit document",
+					"principal unit %q not found", u.Principal)
+			}
+		} else if u.MachineId != "" {
+			if !v.machineIds.Contains(u.MachineId) {
+				v.report(unitsC, u.Name,
+					fmt.Sprintf(`run "juju remove-unit --force %s"`, u.Name),
+					"machine %q not found", u.MachineId)
+			} else if !principals[u.MachineId].Contains(u.Name) {
+				v.report(unitsC, u.Name,
+					fmt.Sprintf("add %q to the principals of machine %q", u.Name, u.MachineId),
+					"not a principal of machine %q", u.MachineId)
+			}
+		}
+		for _, sub := range u.Subordinates {
+			if !v.unitNames.Contains(sub) {
+				v.report(unitsC, u.Name,
+					fmt.Sprintf("remove %q from the unit's subordinates", sub),
+					"subordinate unit %q not found", sub)
+			}
+		}
+		v.reportMissingStatus(unitsC, u.Name, unitAgentGlobalKey(u.Name))
+		v.reportMissingStatus(unitsC, u.Name, unitGlobalKey(u.Name))
+	}
+}
+
+func (v *modelVerifier) checkMachines() {
+	for _, m := range v.machines {
+		for _, name := range m.Principals {
+			if !v.unitNames.Contains(name) {
+				v.report(machinesC, m.Id,
+					fmt.Sprintf("remove %q from the machine's principals", name),
+					"principal unit %q not found", name)
+			}
+		}
+		v.reportMissingStatus(machinesC, m.Id, machineGlobalKey(m.Id))
+		v.reportMissingStatus(machinesC, m.Id, machineGlobalInstanceKey(m.Id))
+	}
+}
+
+func (v *modelVerifier) checkApplications() {
+	unitCounts := make(map[string]int)
+	for _, u := range v.units {
+		unitCounts[u.Application]++
+	}
+	relationCounts := make(map[string]int)
+	for _, rel := range v.relations {
+		for _, ep := range rel.Endpoints {
+			relationCounts[ep.ApplicationName]++
+		}
+	}
+	for _, app := range v.applications {
+		if n := unitCounts[app.Name]; app.UnitCount != n {
+			v.report(applicationsC, app.Name,
+				fmt.Sprintf("set the unit count to %d", n),
+				"unit count is %d, should be %d", app.UnitCount, n)
+		}
+		if n := relationCounts[app.Name]; app.RelationCount != n {
+			v.report(applicationsC, app.Name,
+				fmt.Sprintf("set the relation count to %d", n),
+				"relation count is %d, should be %d", app.RelationCount, n)
+		}
+		v.reportMissingStatus(applicationsC, app.Name, applicationGlobalKey(app.Name))
+	}
+}
+
+func (v *modelVerifier) checkRelations() {
+	unitCounts := make(map[int]int)
+	for _, scope := range v.relationScopes {
+		relScope, _, unitName, err := unpackScopeKey(scope.Key)
+		if err != nil {
+			v.report(relationScopesC, scope.Key, "remove the relation scope document", "%v", err)
+			continue
+		}
+		id, err := strconv.Atoi(strings.SplitN(relScope, "#", 3)[1])
+		if err != nil || !v.relationIds[id] {
+			v.report(relationScopesC, scope.Key, "remove the relation scope document",
+				"relation %q not found", relScope)
+			continue
+		}
+		unitCounts[id]++
+		if !v.unitNames.Contains(unitName) {
+			v.report(relationScopesC, scope.Key,
+				fmt.Sprintf("remove the relation scope document and decrement the unit count of relation %d", id),
+				"unit %q not found", unitName)
+		}
+	}
+	for _, rel := range v.relations {
+		for _, ep := range rel.Endpoints {
+			name := ep.ApplicationName
+			if !v.applicationNames.Contains(name) && !v.remoteAppNames.Contains(name) {
+				v.report(relationsC, rel.Key,
+					fmt.Sprintf(`run "juju remove-relation --force %d"`, rel.Id),
+					"application %q not found", name)
+			}
+		}
+		if n := unitCounts[rel.Id]; rel.UnitCount != n {
+			v.report(relationsC, rel.Key,
+				fmt.Sprintf("set the unit count to %d", n),
+				"unit count is %d, should be %d", rel.UnitCount, n)
+		}
+		v.reportMissingStatus(relationsC, rel.Key, relationGlobalScope(rel.Id))
+	}
+}
+
+// checkStatuses reports the statuses of machines, units, applications
+// and relations which don't exist.
+func (v *modelVerifier) checkStatuses() {
+	for _, doc := range v.statuses {
+		key := v.localID(doc.ID)
+		parts := strings.SplitN(key, "#", 3)
+		if len(parts) < 2 {
+			continue
+		}
+		var kind string
+		var found bool
+		switch parts[0] {
+		case "m":
+			kind, found = "machine", v.machineIds.Contains(parts[1])
+		case "u":
+			kind, found = "unit", v.unitNames.Contains(parts[1])
+		case "a":
+			kind, found = "application", v.applicationNames.Contains(parts[1])
+		case "c":
+			kind, found = "remote application", v.remoteAppNames.Contains(parts[1])
+		case "r":
+			id, err := strconv.Atoi(parts[1])
+			kind, found = "relation", err == nil && v.relationIds[id]
+		default:
+			continue
+		}
+		if !found {
+			v.report(statusesC, key, "remove the status document", "%s %q not found", kind, parts[1])
+		}
+	}
+}
@@ -0,0 +1,171 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/mgo.v2/bson"
+
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/testing/factory"
+)
+
+type VerifyModelSuite struct {
+	ConnSuite
+	app      *state.Application
+	unit     *state.Unit
+	machine  *state.Machine
+	relation *state.Relation
+}
+
+var _ = gc.Suite(&VerifyModelSuite{})
+
+func (s *VerifyModelSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.relation = s.Factory.MakeRelation(c, nil)
+	app, err := s.State.Application("wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	s.app = app
+	s.machine = s.Factory.MakeMachine(c, nil)
+	s.unit = s.Factory.MakeUnit(c, &factory.UnitParams{
+		Application: s.app,
+		Machine:     s.machine,
+	})
+	ru, err := s.relation.Unit(s.unit)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ru.EnterScope(nil)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// corrupt breaks the references between the unit, its machine and
+// application, and the statuses.
+func (s *VerifyModelSuite) corrupt(c *gc.C) {
+	statuses, closer := state.GetRawCollection(s.State, "statuses")
+	defer closer()
+	err := statuses.RemoveId(s.State.ModelUUID() + ":u#" + s.unit.Name() + "#charm")
+	c.Assert(err, jc.ErrorIsNil)
+	err = statuses.Insert(bson.M{
+		"_id":        s.State.ModelUUID() + ":m#42",
+		"model-uuid": s.State.ModelUUID(),
+		"status":     "started",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	machines, closer := state.GetRawCollection(s.State, "machines")
+	defer closer()
+	err = machines.UpdateId(s.State.ModelUUID()+":"+s.machine.Id(), bson.M{
+		"$set": bson.M{"principals": []string{"mysql/7"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	applications, closer := state.GetRawCollection(s.State, "applications")
+	defer closer()
+	err = applications.UpdateId(s.State.ModelUUID()+":wordpress", bson.M{
+		"$set": bson.M{"unitcount": 3},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *VerifyModelSuite) expectedInconsistencies() []state.ModelInconsistency {
+	machineId := s.machine.Id()
+	unitName := s.unit.Name()
+	return []state.ModelInconsistency{{
+		Collection: "applications",
+		ID:         "wordpress",
+		Problem:    "unit count is 3, should be 1",
+		Repair:     "set the unit count to 1",
+	}, {
+		Collection: "machines",
+		ID:         machineId,
+		Problem:    `principal unit "mysql/7" not found`,
+		Repair:     `remove "mysql/7" from the machine's principals`,
+	}, {
+		Collection: "statuses",
+		ID:         "m#42",
+		Problem:    `machine "42" not found`,
+		Repair:     "remove the status document",
+	}, {
+		Collection: "units",
+		ID:         unitName,
+		Problem:    `not a principal of machine "` + machineId + `"`,
+		Repair:     `add "` + unitName + `" to the principals of machine "` + machineId + `"`,
+	}, {
+		Collection: "units",
+		ID:         unitName,
+		Problem:    `status "u#` + unitName + `#charm" not found`,
+		Repair:     `insert a status document with id "u#` + unitName + `#charm" and status unknown`,
+	}}
+}
+
+func (s *VerifyModelSuite) TestVerifyModelConsistent(c *gc.C) {
+	result, err := s.State.VerifyModel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, gc.HasLen, 0)
+}
+
+func (s *VerifyModelSuite) TestVerifyModel(c *gc.C) {
+	s.corrupt(c)
+	result, err := s.State.VerifyModel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, s.expectedInconsistencies())
+}
+
+func (s *VerifyModelSuite) TestVerifyModelRelations(c *gc.C) {
+	relations, closer := state.GetRawCollection(s.State, "relations")
+	defer closer()
+	err := relations.UpdateId(s.State.ModelUUID()+":"+s.relation.String(), bson.M{
+		"$set": bson.M{"unitcount": 0},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	result, err := s.State.VerifyModel()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, []state.ModelInconsistency{{
+		Collection: "relations",
+		ID:         s.relation.String(),
+		Problem:    "unit count is 0, should be 1",
+		Repair:     "set the unit count to 1",
+	}})
+}
+
+func (s *VerifyModelSuite) TestVerifyDumpedModels(c *gc.C) {
+	s.corrupt(c)
+	dumpDir := c.MkDir()
+	err := os.Mkdir(filepath.Join(dumpDir, "juju"), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	// Not all the collections are dumped, as mongodump leaves out
+	// those that don't exist.
+	for _, name := range []string{
+		"models", "machines", "units", "applications",
+		"relations", "relationscopes", "statuses",
+	} {
+		coll, closer := state.GetRawCollection(s.State, name)
+		var docs []bson.Raw
+		err := coll.Find(nil).All(&docs)
+		closer()
+		c.Assert(err, jc.ErrorIsNil)
+		var data []byte
+		for _, doc := range docs {
+			data = append(data, doc.Data...)
+		}
+		err = ioutil.WriteFile(filepath.Join(dumpDir, "juju", name+".bson"), data, 0644)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	result, err := state.VerifyDumpedModels(dumpDir)
+	c.Assert(err, jc.ErrorIsNil)
+	model, err := s.State.Model()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, []state.DumpedModelVerification{{
+		UUID:            model.UUID(),
+		Name:            model.Name(),
+		Owner:           model.Owner().Id(),
+		Inconsistencies: s.expectedInconsistencies(),
+	}})
+}
@@ -52,6 +52,15 @@ type HubWatcher struct {
 	// watches holds the observers managed by Watch/Unwatch.
 	watches map[watchKey][]watchInfo
 
+	// collectionWatches counts the observers in watches for each
+	// collection, so that interest in a collection can be registered
+	// with the event source only while something is watching it.
+	collectionWatches map[string]int
+
+	// interest is the subscription used to restrict the changes
+	// delivered to this watcher to the collections being watched.
+	interest CollectionSubscription
+
 	// syncEvents contain the events to be
 	// dispatched to the watcher channels. They're queued during
 	// processing and flushed at the end to simplify the algorithm.
@@ -93,7 +102,9 @@ type HubWatcher struct {
 // HubWatcherConfig contains the configuration parameters required
 // for a NewHubWatcher.
 type HubWatcherConfig struct {
-	// Hub is the source of the events for the hub watcher.
+	// Hub is the source of the events for the hub watcher. If the hub
+	// is also a CollectionSource, such as a Multiplexer, the watcher
+	// only receives changes for the collections it is watching.
 	Hub HubSource
 	// Clock allows tests to control the advancing of time.
 	Clock Clock
@@ -152,11 +163,22 @@ func newHubWatcher(hub HubSource, clock Clock, modelUUID string, logger Logger)
 		watches:   make(map[watchKey][]watchInfo),
 		request:   make(chan interface{}),
 		changes:   make(chan Change),
+
+		collectionWatches: make(map[string]int),
+	}
+	var unsub func()
+	if source, ok := hub.(CollectionSource); ok {
+		w.interest = source.SubscribeCollections(w.receiveEvent)
+		unsub = w.interest.Unsubscribe
+	} else {
+		w.interest = allCollections{}
 	}
 	w.tomb.Go(func() error {
-		unsub := hub.SubscribeMatch(
-			func(string) bool { return true }, w.receiveEvent,
-		)
+		if unsub == nil {
+			unsub = hub.SubscribeMatch(
+				func(string) bool { return true }, w.receiveEvent,
+			)
+		}
 		defer unsub()
 		close(started)
 		err := w.loop()
@@ -498,6 +520,7 @@ func (w *HubWatcher) handle(req interface{}) {
 			}
 		}
 		w.watches[r.key] = append(w.watches[r.key], r.info)
+		w.addCollectionWatch(r.key.c)
 		if r.registeredCh != nil {
 			select {
 			case r.registeredCh <- nil:
@@ -527,6 +550,7 @@ func (w *HubWatcher) handle(req interface{}) {
 				source: r.source,
 			}
 			w.watches[key] = append(w.watches[key], info)
+			w.addCollectionWatch(r.collection)
 		}
 		select {
 		case r.completedCh <- nil:
@@ -546,6 +570,7 @@ func (w *HubWatcher) handle(req interface{}) {
 		if !removed {
 			panic(fmt.Errorf("tried to remove missing channel %v for %s", r.ch, r.key))
 		}
+		w.removeCollectionWatch(r.key.c)
 		for i := range w.syncEvents {
 			e := &w.syncEvents[i]
 			if r.key.match(e.key) && e.ch == r.ch {
@@ -580,6 +605,33 @@ func (w *HubWatcher) handle(req interface{}) {
 	}
 }
 
+// addCollectionWatch records a new observer of the collection,
+// registering interest in the collection for the first one.
+func (w *HubWatcher) addCollectionWatch(collection string) {
+	w.collectionWatches[collection]++
+	if w.collectionWatches[collection] == 1 {
+		w.interest.Add(collection)
+	}
+}
+
+// removeCollectionWatch records the removal of an observer of the
+// collection, withdrawing interest once there are none left.
+func (w *HubWatcher) removeCollectionWatch(collection string) {
+	w.collectionWatches[collection]--
+	if w.collectionWatches[collection] <= 0 {
+		delete(w.collectionWatches, collection)
+		w.interest.Remove(collection)
+	}
+}
+
+// allCollections is used when the hub watcher's source delivers
+// changes for every collection, so no interest need be registered.
+type allCollections struct{}
+
+func (allCollections) Add(string)    {}
+func (allCollections) Remove(string) {}
+func (allCollections) Unsubscribe()  {}
+
 var (
 	int64Size    = reflect.TypeOf(int64(0)).Size()
 	strSize      = reflect.TypeOf("").Size()
@@ -0,0 +1,346 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package watcher
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/tomb.v2"
+)
+
+// CollectionSource is a HubSource that is able to restrict the changes
+// delivered to a subscriber to those for the collections that the
+// subscriber has expressed an interest in.
+type CollectionSource interface {
+	HubSource
+
+	// SubscribeCollections returns a subscription that delivers
+	// non-collection events, and collection change events for only
+	// those collections added to the subscription, to the handler.
+	SubscribeCollections(handler func(string, interface{})) CollectionSubscription
+}
+
+// CollectionSubscription represents interest in changes for a set
+// of collections.
+type CollectionSubscription interface {
+	// Add starts delivery of changes for the collection.
+	Add(collection string)
+
+	// Remove stops delivery of changes for the collection.
+	Remove(collection string)
+
+	// Unsubscribe stops all delivery to the subscription.
+	Unsubscribe()
+}
+
+// MultiplexerConfig contains the configuration parameters required
+// for a NewMultiplexer.
+type MultiplexerConfig struct {
+	// Hub is the source of the txn watcher events.
+	Hub HubSource
+	// Logger is used to control where the log messages for the
+	// multiplexer go.
+	Logger Logger
+}
+
+// Validate ensures that all the values that have to be set are set.
+func (config MultiplexerConfig) Validate() error {
+	if config.Hub == nil {
+		return errors.NotValidf("missing Hub")
+	}
+	return nil
+}
+
+// Multiplexer shares the single stream of changes published by the
+// TxnWatcher between many HubWatchers. Rather than every HubWatcher
+// receiving, and then discarding, every change made on the controller,
+// changes are routed only to those subscribers watching the collection
+// that was changed.
+type Multiplexer struct {
+	tomb   tomb.Tomb
+	logger Logger
+
+	mu          sync.Mutex
+	subscribers map[*muxSubscription]bool
+	collections map[string]map[*muxSubscription]bool
+
+	// changeCount is the number of collection changes received.
+	changeCount uint64
+
+	// deliveryCount is the number of collection changes
+	// delivered to subscribers.
+	deliveryCount uint64
+}
+
+// NewMultiplexer returns a new Multiplexer distributing the events
+// published to the hub.
+func NewMultiplexer(config MultiplexerConfig) (*Multiplexer, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Annotate(err, "new Multiplexer invalid config")
+	}
+	logger := config.Logger
+	if logger == nil {
+		logger = noOpLogger{}
+	}
+	m := &Multiplexer{
+		logger:      logger,
+		subscribers: make(map[*muxSubscription]bool),
+		collections: make(map[string]map[*muxSubscription]bool),
+	}
+	// Subscribe before returning so that no events published after
+	// construction are missed.
+	unsub := config.Hub.SubscribeMatch(func(string) bool { return true }, m.receiveEvent)
+	m.tomb.Go(func() error {
+		defer unsub()
+		<-m.tomb.Dying()
+		m.mu.Lock()
+		for sub := range m.subscribers {
+			sub.close()
+		}
+		m.subscribers = nil
+		m.collections = nil
+		m.mu.Unlock()
+		return nil
+	})
+	return m, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (m *Multiplexer) Kill() {
+	m.tomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (m *Multiplexer) Wait() error {
+	return m.tomb.Wait()
+}
+
+// Stop stops the multiplexer and all of its subscriptions.
+func (m *Multiplexer) Stop() error {
+	return worker.Stop(m)
+}
+
+// SubscribeMatch is part of the HubSource interface. The handler is
+// called for all events whose topic satisfies the matcher, including
+// changes for every collection.
+func (m *Multiplexer) SubscribeMatch(matcher func(string) bool, handler func(string, interface{})) func() {
+	sub := m.subscribe(handler, matcher)
+	return sub.Unsubscribe
+}
+
+// SubscribeCollections is part of the CollectionSource interface.
+func (m *Multiplexer) SubscribeCollections(handler func(string, interface{})) CollectionSubscription {
+	return m.subscribe(handler, nil)
+}
+
+func (m *Multiplexer) subscribe(handler func(string, interface{}), matcher func(string) bool) *muxSubscription {
+	sub := &muxSubscription{
+		mux:         m,
+		handler:     handler,
+		matcher:     matcher,
+		collections: make(map[string]bool),
+		ready:       make(chan struct{}, 1),
+		done:        make(chan struct{}),
+	}
+	m.mu.Lock()
+	if m.subscribers == nil {
+		// The multiplexer has stopped, so nothing will be delivered.
+		sub.close()
+	} else {
+		m.subscribers[sub] = true
+		go sub.loop()
+	}
+	m.mu.Unlock()
+	return sub
+}
+
+func (m *Multiplexer) receiveEvent(topic string, data interface{}) {
+	m.mu.Lock()
+	var targets []*muxSubscription
+	if topic == txnWatcherCollection {
+		change, ok := data.(Change)
+		if !ok {
+			m.mu.Unlock()
+			m.logger.Warningf("incoming event not a Change")
+			return
+		}
+		m.changeCount++
+		for sub := range m.collections[change.C] {
+			targets = append(targets, sub)
+		}
+		for sub := range m.subscribers {
+			if sub.matcher != nil && sub.matcher(topic) {
+				targets = append(targets, sub)
+			}
+		}
+		m.deliveryCount += uint64(len(targets))
+	} else {
+		for sub := range m.subscribers {
+			if sub.matcher == nil || sub.matcher(topic) {
+				targets = append(targets, sub)
+			}
+		}
+	}
+	m.mu.Unlock()
+
+	// Queueing never blocks, so one slow subscriber
+	// cannot hold up delivery to the others.
+	for _, sub := range targets {
+		sub.queue(muxEvent{topic: topic, data: data})
+	}
+}
+
+// MultiplexerStats defines the metrics that the multiplexer tracks.
+type MultiplexerStats struct {
+	// SubscriberCount is the number of active subscriptions.
+	SubscriberCount int
+	// CollectionCount is the number of collections with at
+	// least one interested subscriber.
+	CollectionCount int
+	// ChangeCount is the number of collection changes received.
+	ChangeCount uint64
+	// DeliveryCount is the number of collection changes delivered
+	// to subscribers.
+	DeliveryCount uint64
+}
+
+// Stats returns the current multiplexer metrics.
+func (m *Multiplexer) Stats() MultiplexerStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return MultiplexerStats{
+		SubscriberCount: len(m.subscribers),
+		CollectionCount: len(m.collections),
+		ChangeCount:     m.changeCount,
+		DeliveryCount:   m.deliveryCount,
+	}
+}
+
+// Report conforms to the worker.Runner.Report interface for returning
+// information about the multiplexer.
+func (m *Multiplexer) Report() map[string]interface{} {
+	stats := m.Stats()
+	return map[string]interface{}{
+		"subscriber-count": stats.SubscriberCount,
+		"collection-count": stats.CollectionCount,
+		"change-count":     stats.ChangeCount,
+		"delivery-count":   stats.DeliveryCount,
+	}
+}
+
+type muxEvent struct {
+	topic string
+	data  interface{}
+}
+
+// muxSubscription queues the events for a single subscriber and
+// delivers them, in order, from its own goroutine.
+type muxSubscription struct {
+	mux     *Multiplexer
+	handler func(string, interface{})
+	matcher func(string) bool
+
+	// collections is guarded by the multiplexer's mutex.
+	collections map[string]bool
+
+	mu      sync.Mutex
+	pending []muxEvent
+	ready   chan struct{}
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// Add is part of the CollectionSubscription interface.
+func (s *muxSubscription) Add(collection string) {
+	m := s.mux
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.subscribers == nil || !m.subscribers[s] || s.collections[collection] {
+		return
+	}
+	s.collections[collection] = true
+	subs, ok := m.collections[collection]
+	if !ok {
+		subs = make(map[*muxSubscription]bool)
+		m.collections[collection] = subs
+	}
+	subs[s] = true
+}
+
+// Remove is part of the CollectionSubscription interface.
+func (s *muxSubscription) Remove(collection string) {
+	m := s.mux
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	s.removeLocked(collection)
+}
+
+func (s *muxSubscription) removeLocked(collection string) {
+	m := s.mux
+	if !s.collections[collection] {
+		return
+	}
+	delete(s.collections, collection)
+	if m.collections == nil {
+		return
+	}
+	subs := m.collections[collection]
+	delete(subs, s)
+	if len(subs) == 0 {
+		delete(m.collections, collection)
+	}
+}
+
+// Unsubscribe is part of the CollectionSubscription interface.
+func (s *muxSubscription) Unsubscribe() {
+	m := s.mux
+	m.mu.Lock()
+	for collection := range s.collections {
+		s.removeLocked(collection)
+	}
+	if m.subscribers != nil {
+		delete(m.subscribers, s)
+	}
+	m.mu.Unlock()
+	s.close()
+}
+
+func (s *muxSubscription) close() {
+	s.closeOnce.Do(func() { close(s.done) })
+}
+
+func (s *muxSubscription) queue(event muxEvent) {
+	s.mu.Lock()
+	s.pending = append(s.pending, event)
+	s.mu.Unlock()
+	select {
+	case s.ready <- struct{}{}:
+	default:
+	}
+}
+
+func (s *muxSubscription) loop() {
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.ready:
+		}
+		s.mu.Lock()
+		events := s.pending
+		s.pending = nil
+		s.mu.Unlock()
+		for _, event := range events {
+			select {
+			case <-s.done:
+				return
+			default:
+			}
+			s.handler(event.topic, event.data)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package watcher_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/loggo"
+	"github.com/juju/pubsub"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/state/watcher"
+	"github.com/juju/juju/testing"
+)
+
+type MultiplexerSuite struct {
+	testing.BaseSuite
+
+	hub *pubsub.SimpleHub
+	mux *watcher.Multiplexer
+}
+
+var _ = gc.Suite(&MultiplexerSuite{})
+
+type muxEvent struct {
+	topic string
+	data  interface{}
+}
+
+func (s *MultiplexerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.hub = pubsub.NewSimpleHub(nil)
+	mux, err := watcher.NewMultiplexer(watcher.MultiplexerConfig{
+		Hub:    s.hub,
+		Logger: loggo.GetLogger("MultiplexerSuite"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.mux = mux
+	s.AddCleanup(func(c *gc.C) {
+		workertest.CleanKill(c, s.mux)
+	})
+}
+
+func (s *MultiplexerSuite) subscribe() (watcher.CollectionSubscription, chan muxEvent) {
+	events := make(chan muxEvent, 10)
+	sub := s.mux.SubscribeCollections(func(topic string, data interface{}) {
+		events <- muxEvent{topic: topic, data: data}
+	})
+	return sub, events
+}
+
+func (s *MultiplexerSuite) publish(c *gc.C, topic string, data interface{}) {
+	select {
+	case <-s.hub.Publish(topic, data):
+	case <-time.After(testing.LongWait):
+		c.Fatalf("event not processed")
+	}
+}
+
+func (s *MultiplexerSuite) assertEvent(c *gc.C, events <-chan muxEvent, topic string, data interface{}) {
+	select {
+	case event := <-events:
+		c.Check(event.topic, gc.Equals, topic)
+		c.Check(event.data, jc.DeepEquals, data)
+	case <-time.After(testing.LongWait):
+		c.Fatalf("event %q not delivered", topic)
+	}
+}
+
+func (s *MultiplexerSuite) assertNoEvent(c *gc.C, events <-chan muxEvent) {
+	select {
+	case event := <-events:
+		c.Fatalf("unexpected event %#v", event)
+	case <-time.After(testing.ShortWait):
+	}
+}
+
+func (s *MultiplexerSuite) TestMissingHub(c *gc.C) {
+	_, err := watcher.NewMultiplexer(watcher.MultiplexerConfig{})
+	c.Assert(err, gc.ErrorMatches, "new Multiplexer invalid config: missing Hub not valid")
+}
+
+func (s *MultiplexerSuite) TestChangesRoutedByCollection(c *gc.C) {
+	subA, eventsA := s.subscribe()
+	subB, eventsB := s.subscribe()
+	subA.Add("testA")
+	subB.Add("testB")
+
+	changeA := watcher.Change{C: "testA", Id: 1, Revno: 3}
+	changeB := watcher.Change{C: "testB", Id: 2, Revno: 5}
+	s.publish(c, watcher.TxnWatcherCollection, changeA)
+	s.publish(c, watcher.TxnWatcherCollection, changeB)
+	s.publish(c, watcher.TxnWatcherCollection, watcher.Change{C: "testC", Id: 1, Revno: 1})
+
+	s.assertEvent(c, eventsA, watcher.TxnWatcherCollection, changeA)
+	s.assertEvent(c, eventsB, watcher.TxnWatcherCollection, changeB)
+	s.assertNoEvent(c, eventsA)
+	s.assertNoEvent(c, eventsB)
+
+	stats := s.mux.Stats()
+	c.Check(stats.SubscriberCount, gc.Equals, 2)
+	c.Check(stats.CollectionCount, gc.Equals, 2)
+	c.Check(stats.ChangeCount, gc.Equals, uint64(3))
+	c.Check(stats.DeliveryCount, gc.Equals, uint64(2))
+}
+
+func (s *MultiplexerSuite) TestControlEventsDeliveredToAll(c *gc.C) {
+	_, eventsA := s.subscribe()
+	_, eventsB := s.subscribe()
+
+	s.publish(c, watcher.TxnWatcherSyncErr, nil)
+
+	s.assertEvent(c, eventsA, watcher.TxnWatcherSyncErr, nil)
+	s.assertEvent(c, eventsB, watcher.TxnWatcherSyncErr, nil)
+}
+
+func (s *MultiplexerSuite) TestRemove(c *gc.C) {
+	sub, events := s.subscribe()
+	sub.Add("testA")
+	sub.Remove("testA")
+
+	s.publish(c, watcher.TxnWatcherCollection, watcher.Change{C: "testA", Id: 1, Revno: 3})
+	s.assertNoEvent(c, events)
+	c.Check(s.mux.Stats().CollectionCount, gc.Equals, 0)
+}
+
+func (s *MultiplexerSuite) TestUnsubscribe(c *gc.C) {
+	sub, events := s.subscribe()
+	sub.Add("testA")
+	sub.Unsubscribe()
+
+	s.publish(c, watcher.TxnWatcherCollection, watcher.Change{C: "testA", Id: 1, Revno: 3})
+	s.publish(c, watcher.TxnWatcherStarting, nil)
+	s.assertNoEvent(c, events)
+	c.Check(s.mux.Stats(), jc.DeepEquals, watcher.MultiplexerStats{ChangeCount: 1})
+}
+
+func (s *MultiplexerSuite) TestSubscribeMatch(c *gc.C) {
+	events := make(chan muxEvent, 10)
+	unsub := s.mux.SubscribeMatch(func(topic string) bool {
+		return topic == watcher.TxnWatcherCollection
+	}, func(topic string, data interface{}) {
+		events <- muxEvent{topic: topic, data: data}
+	})
+	defer unsub()
+
+	change := watcher.Change{C: "testA", Id: 1, Revno: 3}
+	s.publish(c, watcher.TxnWatcherStarting, nil)
+	s.publish(c, watcher.TxnWatcherCollection, change)
+
+	s.assertEvent(c, events, watcher.TxnWatcherCollection, change)
+	s.assertNoEvent(c, events)
+}
+
+func (s *MultiplexerSuite) TestHubWatcher(c *gc.C) {
+	w, started := watcher.NewTestHubWatcher(s.mux, testclock.NewClock(time.Now()), "model-uuid", loggo.GetLogger("MultiplexerSuite"))
+	defer worker.Stop(w)
+	select {
+	case <-started:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("hub watcher worker didn't start")
+	}
+
+	ch := make(chan watcher.Change)
+	w.Watch("testA", 1, ch)
+	c.Check(s.mux.Stats().CollectionCount, gc.Equals, 1)
+
+	s.publish(c, watcher.TxnWatcherCollection, watcher.Change{C: "testB", Id: 1, Revno: 2})
+	s.publish(c, watcher.TxnWatcherCollection, watcher.Change{C: "testA", Id: 1, Revno: 3})
+	assertChange(c, ch, watcher.Change{C: "testA", Id: 1, Revno: 3})
+	c.Check(s.mux.Stats().DeliveryCount, gc.Equals, uint64(1))
+
+	w.Unwatch("testA", 1, ch)
+	for a := testing.LongAttempt.Start(); a.Next(); {
+		if s.mux.Stats().CollectionCount == 0 {
+			return
+		}
+	}
+	c.Fatalf("interest in testA not withdrawn")
+}
@@ -10,7 +10,6 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/os/series"
-	"github.com/juju/pubsub"
 	"gopkg.in/juju/worker.v1"
 
 	corelease "github.com/juju/juju/core/lease"
@@ -22,13 +21,14 @@ import (
 )
 
 const (
-	txnLogWorker          = "txnlog"
-	presenceWorker        = "presence"
-	leadershipWorker      = "leadership"
-	singularWorker        = "singular"
-	allManagerWorker      = "allmanager"
-	allModelManagerWorker = "allmodelmanager"
-	pingBatcherWorker     = "pingbatcher"
+	txnLogWorker            = "txnlog"
+	presenceWorker          = "presence"
+	leadershipWorker        = "leadership"
+	singularWorker          = "singular"
+	allManagerWorker        = "allmanager"
+	allModelManagerWorker   = "allmodelmanager"
+	modelCacheManagerWorker = "modelcachemanager"
+	pingBatcherWorker       = "pingbatcher"
 )
 
 // workers runs the workers that a State instance requires.
@@ -39,12 +39,12 @@ type workers struct {
 	//	model *Model
 	*worker.Runner
 
-	hub *pubsub.SimpleHub
+	hub watcher.HubSource
 }
 
 const pingFlushInterval = time.Second
 
-func newWorkers(st *State, hub *pubsub.SimpleHub) (*workers, error) {
+func newWorkers(st *State, hub watcher.HubSource) (*workers, error) {
 	if hub == nil {
 		return nil, errors.NotValidf("missing hub")
 	}
@@ -198,6 +198,20 @@ func (ws *workers) allModelManager(pool *StatePool) *storeManager {
 	return ws.allModelManager(pool)
 }
 
+func (ws *workers) modelCacheManager(pool *StatePool) *storeManager {
+	w, err := ws.Worker(modelCacheManagerWorker, nil)
+	if err == nil {
+		return w.(*storeManager)
+	}
+	if errors.Cause(err) != worker.ErrNotFound {
+		return newDeadStoreManager(errors.Trace(err))
+	}
+	ws.StartWorker(modelCacheManagerWorker, func() (worker.Worker, error) {
+		return newStoreManager(NewModelCacheWatcherStateBacking(ws.state, pool)), nil
+	})
+	return ws.modelCacheManager(pool)
+}
+
 // lazyLeaseClaimer wraps one of workers.singularManager.Claimer or
 // workers.leadershipManager.Claimer, and calls it in the method
 // calls. This enables the manager to use restarted lease managers.
@@ -0,0 +1,32 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/environs/context"
+)
+
+// ProgressReporter may be implemented by the ProviderCallContext passed
+// to volume and filesystem sources, so that callers are told about the
+// progress of long-running operations such as formatting a filesystem or
+// restoring a volume from a snapshot.
+type ProgressReporter interface {
+	// ReportProgress reports that the operation on the volume or
+	// filesystem with the given tag has reached the stage described
+	// by message. Percent is how much of the operation is complete,
+	// or -1 if that is not known.
+	ReportProgress(tag names.Tag, message string, percent int)
+}
+
+// ReportProgress reports the progress of an operation on the volume or
+// filesystem with the given tag, if the call context implements
+// ProgressReporter. Sources should call ReportProgress before each stage
+// of an operation that may take a long time.
+func ReportProgress(ctx context.ProviderCallContext, tag names.Tag, message string, percent int) {
+	if reporter, ok := ctx.(ProgressReporter); ok {
+		reporter.ReportProgress(tag, message, percent)
+	}
+}
@@ -109,7 +109,7 @@ var _ storage.VolumeSource = (*loopVolumeSource)(nil)
 func (lvs *loopVolumeSource) CreateVolumes(ctx context.ProviderCallContext, args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
 	results := make([]storage.CreateVolumesResult, len(args))
 	for i, arg := range args {
-		volume, err := lvs.createVolume(arg)
+		volume, err := lvs.createVolume(ctx, arg)
 		if err != nil {
 			results[i].Error = errors.Annotate(err, "creating volume")
 		}
@@ -118,12 +118,13 @@ func (lvs *loopVolumeSource) CreateVolumes(ctx context.ProviderCallContext, args
 	return results, nil
 }
 
-func (lvs *loopVolumeSource) createVolume(params storage.VolumeParams) (storage.Volume, error) {
+func (lvs *loopVolumeSource) createVolume(ctx context.ProviderCallContext, params storage.VolumeParams) (storage.Volume, error) {
 	volumeId := params.Tag.String()
 	loopFilePath := lvs.volumeFilePath(params.Tag)
 	if err := ensureDir(lvs.dirFuncs, filepath.Dir(loopFilePath)); err != nil {
 		return storage.Volume{}, errors.Trace(err)
 	}
+	storage.ReportProgress(ctx, params.Tag, "creating block file "+loopFilePath, -1)
 	if err := createBlockFile(lvs.run, loopFilePath, params.Size); err != nil {
 		return storage.Volume{}, errors.Annotate(err, "could not create block file")
 	}
@@ -193,7 +194,7 @@ func (lvs *loopVolumeSource) ValidateVolumeParams(params storage.VolumeParams) e
 func (lvs *loopVolumeSource) AttachVolumes(ctx context.ProviderCallContext, args []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
 	results := make([]storage.AttachVolumesResult, len(args))
 	for i, arg := range args {
-		attachment, err := lvs.attachVolume(arg)
+		attachment, err := lvs.attachVolume(ctx, arg)
 		if err != nil {
 			results[i].Error = errors.Annotatef(err, "attaching volume %v", arg.Volume.Id())
 			continue
@@ -203,8 +204,9 @@ func (lvs *loopVolumeSource) AttachVolumes(ctx context.ProviderCallContext, args
 	return results, nil
 }
 
-func (lvs *loopVolumeSource) attachVolume(arg storage.VolumeAttachmentParams) (*storage.VolumeAttachment, error) {
+func (lvs *loopVolumeSource) attachVolume(ctx context.ProviderCallContext, arg storage.VolumeAttachmentParams) (*storage.VolumeAttachment, error) {
 	loopFilePath := lvs.volumeFilePath(arg.Volume)
+	storage.ReportProgress(ctx, arg.Volume, "attaching loop device for "+loopFilePath, -1)
 	deviceName, err := attachLoopDevice(lvs.run, loopFilePath, arg.ReadOnly)
 	if err != nil {
 		os.Remove(loopFilePath)
@@ -130,6 +130,24 @@ func (s *loopSuite) TestCreateVolumesNoAttachment(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *loopSuite) TestCreateVolumesReportsProgress(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	s.commands.expect("fallocate", "-l", "2MiB", fileName)
+
+	ctx := &progressCallContext{ProviderCallContext: s.callCtx}
+	results, err := source.CreateVolumes(ctx, []storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 2,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(ctx.reports, jc.DeepEquals, []string{
+		"volume-0: creating block file " + fileName + " (-1)",
+	})
+}
+
 func (s *loopSuite) TestDestroyVolumes(c *gc.C) {
 	source, _ := s.loopVolumeSource(c)
 	fileName := filepath.Join(s.storageDir, "volume-0")
@@ -222,6 +240,31 @@ func (s *loopSuite) TestAttachVolumes(c *gc.C) {
 	}})
 }
 
+func (s *loopSuite) TestAttachVolumesReportsProgress(c *gc.C) {
+	source, _ := s.loopVolumeSource(c)
+	fileName := filepath.Join(s.storageDir, "volume-0")
+	cmd := s.commands.expect("losetup", "-j", fileName)
+	cmd.respond("", nil)
+	cmd = s.commands.expect("losetup", "-f", "--show", fileName)
+	cmd.respond("/dev/loop98", nil)
+
+	ctx := &progressCallContext{ProviderCallContext: s.callCtx}
+	results, err := source.AttachVolumes(ctx, []storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "vol-ume0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:    names.NewMachineTag("0"),
+			InstanceId: "inst-ance",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(ctx.reports, jc.DeepEquals, []string{
+		"volume-0: attaching loop device for " + fileName + " (-1)",
+	})
+}
+
 func (s *loopSuite) TestDetachVolumes(c *gc.C) {
 	source, _ := s.loopVolumeSource(c)
 	fileName := filepath.Join(s.storageDir, "volume-0")
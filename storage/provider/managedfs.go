@@ -76,7 +76,7 @@ func (s *managedFilesystemSource) backingVolumeBlockDevice(v names.VolumeTag) (s
 func (s *managedFilesystemSource) CreateFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
 	results := make([]storage.CreateFilesystemsResult, len(args))
 	for i, arg := range args {
-		filesystem, err := s.createFilesystem(arg)
+		filesystem, err := s.createFilesystem(ctx, arg)
 		if err != nil {
 			results[i].Error = err
 			continue
@@ -86,13 +86,14 @@ func (s *managedFilesystemSource) CreateFilesystems(ctx context.ProviderCallCont
 	return results, nil
 }
 
-func (s *managedFilesystemSource) createFilesystem(arg storage.FilesystemParams) (*storage.Filesystem, error) {
+func (s *managedFilesystemSource) createFilesystem(ctx context.ProviderCallContext, arg storage.FilesystemParams) (*storage.Filesystem, error) {
 	blockDevice, err := s.backingVolumeBlockDevice(arg.Volume)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
 	devicePath := devicePath(blockDevice)
 	if isDiskDevice(devicePath) {
+		storage.ReportProgress(ctx, arg.Tag, "partitioning "+devicePath, -1)
 		if err := destroyPartitions(s.run, devicePath); err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -101,6 +102,7 @@ func (s *managedFilesystemSource) createFilesystem(arg storage.FilesystemParams)
 		}
 		devicePath = partitionDevicePath(devicePath)
 	}
+	storage.ReportProgress(ctx, arg.Tag, "creating filesystem on "+devicePath, -1)
 	if err := createFilesystem(s.run, devicePath); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -131,7 +133,7 @@ func (s *managedFilesystemSource) ReleaseFilesystems(ctx context.ProviderCallCon
 func (s *managedFilesystemSource) AttachFilesystems(ctx context.ProviderCallContext, args []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error) {
 	results := make([]storage.AttachFilesystemsResult, len(args))
 	for i, arg := range args {
-		attachment, err := s.attachFilesystem(arg)
+		attachment, err := s.attachFilesystem(ctx, arg)
 		if err != nil {
 			results[i].Error = err
 			continue
@@ -141,7 +143,7 @@ func (s *managedFilesystemSource) AttachFilesystems(ctx context.ProviderCallCont
 	return results, nil
 }
 
-func (s *managedFilesystemSource) attachFilesystem(arg storage.FilesystemAttachmentParams) (*storage.FilesystemAttachment, error) {
+func (s *managedFilesystemSource) attachFilesystem(ctx context.ProviderCallContext, arg storage.FilesystemAttachmentParams) (*storage.FilesystemAttachment, error) {
 	filesystem, ok := s.filesystems[arg.Filesystem]
 	if !ok {
 		return nil, errors.Errorf("filesystem %v is not yet provisioned", arg.Filesystem.Id())
@@ -154,6 +156,7 @@ func (s *managedFilesystemSource) attachFilesystem(arg storage.FilesystemAttachm
 	if isDiskDevice(devicePath) {
 		devicePath = partitionDevicePath(devicePath)
 	}
+	storage.ReportProgress(ctx, arg.Filesystem, "mounting "+devicePath+" at "+arg.Path, -1)
 	if err := mountFilesystem(s.run, s.dirFuncs, devicePath, arg.Path, arg.ReadOnly); err != nil {
 		return nil, errors.Trace(err)
 	}
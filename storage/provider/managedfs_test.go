@@ -110,6 +110,29 @@ func (s *managedfsSuite) TestCreateFilesystems(c *gc.C) {
 	}})
 }
 
+func (s *managedfsSuite) TestCreateFilesystemsReportsProgress(c *gc.C) {
+	source := s.initSource(c)
+	s.commands.expect("sgdisk", "--zap-all", "/dev/sda")
+	s.commands.expect("sgdisk", "-n", "1:0:-1", "/dev/sda")
+	s.commands.expect("mkfs.ext4", "/dev/sda1")
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		Size:       2,
+	}
+	ctx := &progressCallContext{ProviderCallContext: s.callCtx}
+	results, err := source.CreateFilesystems(ctx, []storage.FilesystemParams{{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+		Size:   2,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(ctx.reports, jc.DeepEquals, []string{
+		"filesystem-0-0: partitioning /dev/sda (-1)",
+		"filesystem-0-0: creating filesystem on /dev/sda1 (-1)",
+	})
+}
+
 func (s *managedfsSuite) TestCreateFilesystemsNoBlockDevice(c *gc.C) {
 	source := s.initSource(c)
 	results, err := source.CreateFilesystems(s.callCtx, []storage.FilesystemParams{{
@@ -234,3 +257,14 @@ func (s *managedfsSuite) TestDetachFilesystemsUnattached(c *gc.C) {
 	source := s.initSource(c)
 	testDetachFilesystems(c, s.commands, source, s.callCtx, false, s.fakeEtcDir, "")
 }
+
+// progressCallContext is a ProviderCallContext which records the
+// progress reported to it.
+type progressCallContext struct {
+	context.ProviderCallContext
+	reports []string
+}
+
+func (ctx *progressCallContext) ReportProgress(tag names.Tag, message string, percent int) {
+	ctx.reports = append(ctx.reports, fmt.Sprintf("%s: %s (%d)", tag, message, percent))
+}
@@ -0,0 +1,54 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+)
+
+// SimulatedClock drives a group of workers through simulated time, such
+// as all the workers in a dependency engine when it is passed as the
+// engine's clock and as the clock in each manifold's config.
+//
+// Advancing a test clock before a worker has started its timer would
+// lose the timer's expiry, so each advance first waits until an
+// expected number of timers are waiting. Workers start their timers in
+// no particular order, so rather than waiting for any one of them the
+// clock waits for them all.
+type SimulatedClock struct {
+	*testclock.Clock
+}
+
+// NewSimulatedClock returns a SimulatedClock starting at the given time.
+func NewSimulatedClock(now time.Time) *SimulatedClock {
+	return &SimulatedClock{testclock.NewClock(now)}
+}
+
+// Step waits until at least waiters timers are waiting on the clock,
+// and then advances it by d.
+func (s *SimulatedClock) Step(c *gc.C, d time.Duration, waiters int) {
+	err := s.WaitAdvance(d, LongWait, waiters)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+// Run advances the clock by total, a step at a time, waiting before each
+// step until at least waiters timers are waiting on the clock. The step
+// should be no longer than the shortest period of any of the workers,
+// so that every timer which falls due is fired in turn, and each worker
+// restarts its timer before the next step.
+func (s *SimulatedClock) Run(c *gc.C, total, step time.Duration, waiters int) {
+	c.Assert(step > 0, jc.IsTrue, gc.Commentf("non-positive step %v", step))
+	for total > 0 {
+		d := step
+		if d > total {
+			d = total
+		}
+		s.Step(c, d, waiters)
+		total -= d
+	}
+}
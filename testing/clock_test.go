@@ -0,0 +1,70 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package testing_test
+
+import (
+	"sort"
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+)
+
+type simulatedClockSuite struct{}
+
+var _ = gc.Suite(&simulatedClockSuite{})
+
+type firing struct {
+	name  string
+	after time.Duration
+}
+
+func (s *simulatedClockSuite) TestRunFiresEveryTimer(c *gc.C) {
+	start := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	clock := testing.NewSimulatedClock(start)
+	stop := make(chan struct{})
+	defer close(stop)
+
+	fired := make(chan firing, 10)
+	tick := func(name string, period time.Duration) {
+		for {
+			select {
+			case now := <-clock.After(period):
+				fired <- firing{name, now.Sub(start)}
+			case <-stop:
+				return
+			}
+		}
+	}
+	go tick("short", 2*time.Minute)
+	go tick("long", 3*time.Minute)
+
+	clock.Run(c, 6*time.Minute, time.Minute, 2)
+
+	var firings []firing
+	for len(firings) < 5 {
+		select {
+		case f := <-fired:
+			firings = append(firings, f)
+		case <-time.After(testing.LongWait):
+			c.Fatalf("timed out waiting for timers; got %v", firings)
+		}
+	}
+	sort.Slice(firings, func(i, j int) bool {
+		if firings[i].after != firings[j].after {
+			return firings[i].after < firings[j].after
+		}
+		return firings[i].name < firings[j].name
+	})
+	c.Assert(firings, jc.DeepEquals, []firing{
+		{"short", 2 * time.Minute},
+		{"long", 3 * time.Minute},
+		{"short", 4 * time.Minute},
+		{"long", 6 * time.Minute},
+		{"short", 6 * time.Minute},
+	})
+	c.Assert(clock.Now(), gc.Equals, start.Add(6*time.Minute))
+}
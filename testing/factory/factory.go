@@ -80,6 +80,10 @@ type CharmParams struct {
 	Series   string
 	Revision string
 	URL      string
+
+	// RelationSchemas holds the contents of the charm's relation
+	// schemas file, if it should have one.
+	RelationSchemas string
 }
 
 // Params for creating a machine.
@@ -428,6 +432,8 @@ func (factory *Factory) MakeCharm(c *gc.C, params *CharmParams) *state.Charm {
 		ID:          curl,
 		StoragePath: "fake-storage-path",
 		SHA256:      bundleSHA256,
+
+		RelationSchemas: params.RelationSchemas,
 	}
 	charm, err := factory.st.AddCharm(info)
 	c.Assert(err, jc.ErrorIsNil)
@@ -76,6 +76,8 @@ type StateBackend interface {
 	ReplaceSpaceNameWithIDEndpointBindings() error
 	EnsureDefaultSpaceSetting() error
 	RemoveControllerConfigMaxLogAgeAndSize() error
+	AddAnnotationPairs() error
+	ReplaceStatusNeverSet() error
 }
 
 // Model is an interface providing access to the details of a model within the
@@ -308,3 +310,11 @@ func (s stateBackend) EnsureDefaultSpaceSetting() error {
 func (s stateBackend) RemoveControllerConfigMaxLogAgeAndSize() error {
 	return state.RemoveControllerConfigMaxLogAgeAndSize(s.pool)
 }
+
+func (s stateBackend) AddAnnotationPairs() error {
+	return state.AddAnnotationPairs(s.pool)
+}
+
+func (s stateBackend) ReplaceStatusNeverSet() error {
+	return state.ReplaceStatusNeverSet(s.pool)
+}
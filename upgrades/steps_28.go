@@ -0,0 +1,24 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrades
+
+// stateStepsFor28 returns upgrade steps for Juju 2.8.0.
+func stateStepsFor28() []Step {
+	return []Step{
+		&upgradeStep{
+			description: "add indexed pairs to annotations",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return context.State().AddAnnotationPairs()
+			},
+		},
+		&upgradeStep{
+			description: "record whether statuses have been set",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return context.State().ReplaceStatusNeverSet()
+			},
+		},
+	}
+}
@@ -0,0 +1,33 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrades_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/testing"
+	"github.com/juju/juju/upgrades"
+)
+
+var v28 = version.MustParse("2.8.0")
+
+type steps28Suite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&steps28Suite{})
+
+func (s *steps28Suite) TestAddAnnotationPairs(c *gc.C) {
+	step := findStateStep(c, v28, "add indexed pairs to annotations")
+	// Logic for step itself is tested in state package.
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
+}
+
+func (s *steps28Suite) TestReplaceStatusNeverSet(c *gc.C) {
+	step := findStateStep(c, v28, "record whether statuses have been set")
+	// Logic for step itself is tested in state package.
+	c.Assert(step.Targets(), jc.DeepEquals, []upgrades.Target{upgrades.DatabaseMaster})
+}
@@ -625,6 +625,7 @@ func (s *upgradeSuite) TestStateUpgradeOperationsVersions(c *gc.C) {
 		"2.6.3",
 		"2.6.5",
 		"2.7.0",
+		"2.8.0",
 	})
 }
 
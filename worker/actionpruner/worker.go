@@ -4,8 +4,6 @@
 package actionpruner
 
 import (
-	"time"
-
 	"github.com/juju/errors"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/catacomb"
@@ -26,12 +24,26 @@ type Worker struct {
 }
 
 func NewFacade(caller base.APICaller) pruner.Facade {
-	return action.NewFacade(caller)
+	return facade{action.NewFacade(caller)}
+}
+
+// facade adapts the action pruner client to the pruner.Facade
+// interface. Actions can't be capped per entity.
+type facade struct {
+	*action.Facade
+}
+
+// Prune is part of the pruner.Facade interface.
+func (f facade) Prune(policy pruner.Policy) error {
+	return f.Facade.Prune(policy.MaxAge, int(policy.MaxCollectionMB))
 }
 
 func (w *Worker) loop() error {
-	return w.Work(func(config *config.Config) (time.Duration, uint) {
-		return config.MaxActionResultsAge(), config.MaxActionResultsSizeMB()
+	return w.Work(func(config *config.Config) pruner.Policy {
+		return pruner.Policy{
+			MaxAge:          config.MaxActionResultsAge(),
+			MaxCollectionMB: config.MaxActionResultsSizeMB(),
+		}
 	})
 }
 
@@ -0,0 +1,128 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentconfigreloader
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/proxy"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/agent"
+	apiagent "github.com/juju/juju/api/agent"
+	"github.com/juju/juju/api/base"
+	apilogger "github.com/juju/juju/api/logger"
+	"github.com/juju/juju/api/proxyupdater"
+)
+
+// ManifoldConfig defines the names of the manifolds on which a Manifold
+// will depend, and the other dependencies of the worker.
+type ManifoldConfig struct {
+	AgentName            string
+	APICallerName        string
+	LoggingContext       *loggo.Context
+	InProcessProxyUpdate func(proxy.Settings) error
+	Logger               Logger
+	NewWorker            func(Config) (worker.Worker, error)
+}
+
+// Validate returns an error if the config cannot be used to start a
+// manifold.
+func (config ManifoldConfig) Validate() error {
+	if config.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
+	if config.APICallerName == "" {
+		return errors.NotValidf("empty APICallerName")
+	}
+	if config.LoggingContext == nil {
+		return errors.NotValidf("nil LoggingContext")
+	}
+	if config.InProcessProxyUpdate == nil {
+		return errors.NotValidf("nil InProcessProxyUpdate")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency manifold that runs an agent config
+// reloader worker for a machine agent, using the resource names defined
+// in the supplied config.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.AgentName,
+			config.APICallerName,
+		},
+		Start: func(context dependency.Context) (worker.Worker, error) {
+			if err := config.Validate(); err != nil {
+				return nil, errors.Trace(err)
+			}
+			var a agent.Agent
+			if err := context.Get(config.AgentName, &a); err != nil {
+				return nil, err
+			}
+			tag, ok := a.CurrentConfig().Tag().(names.MachineTag)
+			if !ok {
+				return nil, dependency.ErrUninstall
+			}
+
+			var apiCaller base.APICaller
+			if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+				return nil, err
+			}
+			// Controllers which don't support overriding agent config
+			// will never have any overrides to apply.
+			if apiCaller.BestFacadeVersion("Agent") < 3 {
+				return nil, dependency.ErrUninstall
+			}
+			facade, err := apiagent.NewState(apiCaller)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			proxyAPI, err := proxyupdater.NewAPI(apiCaller, tag)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			loggerAPI := apilogger.NewState(apiCaller)
+
+			w, err := config.NewWorker(Config{
+				Facade:         facade,
+				Agent:          a,
+				Tag:            tag,
+				LoggingContext: config.LoggingContext,
+				ModelLoggingConfig: func() (string, error) {
+					return loggerAPI.LoggingConfig(tag)
+				},
+				ModelProxySettings: func() (proxy.Settings, error) {
+					return modelProxySettings(proxyAPI)
+				},
+				InProcessProxyUpdate: config.InProcessProxyUpdate,
+				Logger:               config.Logger,
+			})
+			return w, errors.Trace(err)
+		},
+	}
+}
+
+// modelProxySettings returns the proxy settings the proxy updater
+// installs for the agent's own process: the juju proxy settings if any
+// are set, otherwise the legacy ones.
+func modelProxySettings(api *proxyupdater.API) (proxy.Settings, error) {
+	config, err := api.ProxyConfig()
+	if err != nil {
+		return proxy.Settings{}, errors.Trace(err)
+	}
+	if config.JujuProxy.HasProxySet() {
+		return config.JujuProxy, nil
+	}
+	return config.LegacyProxy, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentconfigreloader_test
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/proxy"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+	dt "gopkg.in/juju/worker.v1/dependency/testing"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/worker/agentconfigreloader"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+	config agentconfigreloader.ManifoldConfig
+	worker agentconfigreloader.Config
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.worker = agentconfigreloader.Config{}
+	s.config = agentconfigreloader.ManifoldConfig{
+		AgentName:      "agent",
+		APICallerName:  "api-caller",
+		LoggingContext: loggo.NewContext(loggo.WARNING),
+		InProcessProxyUpdate: func(proxy.Settings) error {
+			return nil
+		},
+		Logger: loggo.GetLogger("test"),
+		NewWorker: func(config agentconfigreloader.Config) (worker.Worker, error) {
+			s.worker = config
+			return nil, errors.New("worker started")
+		},
+	}
+}
+
+func (s *ManifoldSuite) apiCaller(version int) basetesting.BestVersionCaller {
+	return basetesting.BestVersionCaller{
+		APICallerFunc: func(string, int, string, string, interface{}, interface{}) error {
+			return errors.New("unexpected call")
+		},
+		BestVersion: version,
+	}
+}
+
+func (s *ManifoldSuite) start(c *gc.C, a *mockAgent, version int) error {
+	manifold := agentconfigreloader.Manifold(s.config)
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent":      a,
+		"api-caller": s.apiCaller(version),
+	})
+	w, err := manifold.Start(context)
+	c.Check(w, gc.IsNil)
+	return err
+}
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := agentconfigreloader.Manifold(s.config)
+	c.Check(manifold.Inputs, jc.DeepEquals, []string{"agent", "api-caller"})
+}
+
+func (s *ManifoldSuite) TestMissingNewWorker(c *gc.C) {
+	s.config.NewWorker = nil
+	err := s.start(c, &mockAgent{}, 3)
+	c.Check(err, gc.ErrorMatches, "nil NewWorker not valid")
+}
+
+func (s *ManifoldSuite) TestMissingAgent(c *gc.C) {
+	manifold := agentconfigreloader.Manifold(s.config)
+	context := dt.StubContext(nil, map[string]interface{}{
+		"agent": dependency.ErrMissing,
+	})
+	_, err := manifold.Start(context)
+	c.Check(err, gc.Equals, dependency.ErrMissing)
+}
+
+func (s *ManifoldSuite) TestNotMachineAgent(c *gc.C) {
+	a := &mockAgent{conf: mockConfig{tag: names.NewUnitTag("mysql/0")}}
+	err := s.start(c, a, 3)
+	c.Check(err, gc.Equals, dependency.ErrUninstall)
+}
+
+func (s *ManifoldSuite) TestControllerNotSupported(c *gc.C) {
+	err := s.start(c, &mockAgent{}, 2)
+	c.Check(err, gc.Equals, dependency.ErrUninstall)
+}
+
+func (s *ManifoldSuite) TestStart(c *gc.C) {
+	a := &mockAgent{}
+	err := s.start(c, a, 3)
+	c.Check(err, gc.ErrorMatches, "worker started")
+
+	c.Check(s.worker.Validate(), jc.ErrorIsNil)
+	c.Check(s.worker.Agent, gc.Equals, a)
+	c.Check(s.worker.Tag, gc.Equals, names.NewMachineTag("1"))
+	c.Check(s.worker.LoggingContext, gc.Equals, s.config.LoggingContext)
+}
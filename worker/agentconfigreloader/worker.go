@@ -0,0 +1,236 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package agentconfigreloader provides a worker which applies the agent
+// config values overridden by the controller for a machine agent, such
+// as its logging config, API addresses and proxy settings, while the
+// agent is running.
+package agentconfigreloader
+
+import (
+	"sort"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/proxy"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1"
+
+	"github.com/juju/juju/agent"
+	apiagent "github.com/juju/juju/api/agent"
+	"github.com/juju/juju/core/agentconfig"
+	"github.com/juju/juju/core/watcher"
+)
+
+// Facade exposes the agent facade calls used by the worker.
+type Facade interface {
+	WatchAgentConfigOverrides(names.MachineTag) (watcher.NotifyWatcher, error)
+	AgentConfigOverrides(names.MachineTag) (apiagent.ConfigOverrides, error)
+	SetAgentConfigOverridesApplied(names.MachineTag, int64) error
+}
+
+// Logger defines the logging methods used by the worker.
+type Logger interface {
+	Warningf(string, ...interface{})
+	Infof(string, ...interface{})
+	Debugf(string, ...interface{})
+}
+
+// Config holds the dependencies of the worker.
+type Config struct {
+	Facade Facade
+	Agent  agent.Agent
+	Tag    names.MachineTag
+
+	// LoggingContext is the logging context to reconfigure when the
+	// logging config is overridden.
+	LoggingContext *loggo.Context
+
+	// ModelLoggingConfig returns the logging config of the model,
+	// which is used again when the logging override is removed.
+	ModelLoggingConfig func() (string, error)
+
+	// ModelProxySettings returns the proxy settings of the model, to
+	// which the proxy overrides are applied.
+	ModelProxySettings func() (proxy.Settings, error)
+
+	// InProcessProxyUpdate installs the given proxy settings for the
+	// agent's own HTTP connections.
+	InProcessProxyUpdate func(proxy.Settings) error
+
+	Logger Logger
+}
+
+// Validate returns an error if the config cannot be used to start a
+// worker.
+func (config Config) Validate() error {
+	if config.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if config.Agent == nil {
+		return errors.NotValidf("nil Agent")
+	}
+	if config.Tag == (names.MachineTag{}) {
+		return errors.NotValidf("empty Tag")
+	}
+	if config.LoggingContext == nil {
+		return errors.NotValidf("nil LoggingContext")
+	}
+	if config.ModelLoggingConfig == nil {
+		return errors.NotValidf("nil ModelLoggingConfig")
+	}
+	if config.ModelProxySettings == nil {
+		return errors.NotValidf("nil ModelProxySettings")
+	}
+	if config.InProcessProxyUpdate == nil {
+		return errors.NotValidf("nil InProcessProxyUpdate")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// overrideKeys maps the names of the agent config values which may be
+// overridden to the agent.conf keys they are stored under.
+var overrideKeys = map[string]string{
+	agentconfig.LoggingConfig: agent.LoggingOverride,
+	agentconfig.APIAddresses:  agent.APIAddressesOverride,
+	agentconfig.HTTPProxy:     agent.HTTPProxyOverride,
+	agentconfig.HTTPSProxy:    agent.HTTPSProxyOverride,
+	agentconfig.NoProxy:       agent.NoProxyOverride,
+}
+
+// NewWorker returns a worker which watches the agent config values
+// overridden for the machine's agent, writes them to agent.conf and
+// applies them, then reports the version applied to the controller.
+//
+// Logging and proxy overrides take effect at once, and the model's
+// settings are restored when they are removed. API addresses are
+// used the next time the agent connects to the controller.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := watcher.NewNotifyWorker(watcher.NotifyConfig{
+		Handler: &reloader{
+			config:  config,
+			applied: -1,
+		},
+	})
+	return w, errors.Trace(err)
+}
+
+type reloader struct {
+	config Config
+
+	// applied is the version of the overrides last applied, or -1
+	// until the first have been.
+	applied int64
+}
+
+// SetUp is part of the watcher.NotifyHandler interface.
+func (r *reloader) SetUp() (watcher.NotifyWatcher, error) {
+	return r.config.Facade.WatchAgentConfigOverrides(r.config.Tag)
+}
+
+// Handle is part of the watcher.NotifyHandler interface.
+func (r *reloader) Handle(_ <-chan struct{}) error {
+	overrides, err := r.config.Facade.AgentConfigOverrides(r.config.Tag)
+	if err != nil {
+		return errors.Annotate(err, "getting agent config overrides")
+	}
+	if overrides.Version == r.applied {
+		return nil
+	}
+	changed, err := r.writeOverrides(overrides.Values)
+	if err != nil {
+		return errors.Annotate(err, "writing agent config overrides")
+	}
+	// If the agent didn't report having applied the overrides before
+	// it last stopped, they may not have been, so they are applied
+	// again whether or not they changed.
+	reapply := r.applied < 0 && overrides.AppliedVersion != overrides.Version
+	if reapply || changed.Contains(agentconfig.LoggingConfig) {
+		if err := r.applyLogging(overrides.Values[agentconfig.LoggingConfig]); err != nil {
+			return errors.Annotate(err, "applying logging config")
+		}
+	}
+	if reapply || changed.Contains(agentconfig.HTTPProxy) ||
+		changed.Contains(agentconfig.HTTPSProxy) || changed.Contains(agentconfig.NoProxy) {
+		if err := r.applyProxySettings(); err != nil {
+			return errors.Annotate(err, "applying proxy settings")
+		}
+	}
+	if err := r.config.Facade.SetAgentConfigOverridesApplied(r.config.Tag, overrides.Version); err != nil {
+		return errors.Annotate(err, "reporting applied agent config overrides")
+	}
+	r.config.Logger.Infof("applied agent config overrides version %d", overrides.Version)
+	r.applied = overrides.Version
+	return nil
+}
+
+// TearDown is part of the watcher.NotifyHandler interface.
+func (r *reloader) TearDown() error {
+	return nil
+}
+
+// writeOverrides writes the given overrides to agent.conf, removing
+// any not given, and returns the names of those which changed.
+func (r *reloader) writeOverrides(values map[string]string) (set.Strings, error) {
+	for _, name := range unknownOverrides(values) {
+		r.config.Logger.Warningf("ignoring unknown agent config override %q", name)
+	}
+	changed := set.NewStrings()
+	err := r.config.Agent.ChangeConfig(func(setter agent.ConfigSetter) error {
+		for name, key := range overrideKeys {
+			value := values[name]
+			if setter.Value(key) == value {
+				continue
+			}
+			r.config.Logger.Debugf("setting agent config override %s to %q", name, value)
+			setter.SetValue(key, value)
+			changed.Add(name)
+		}
+		return nil
+	})
+	return changed, errors.Trace(err)
+}
+
+// applyLogging reconfigures the agent's loggers with the given logging
+// config, or with the model's if it is empty.
+func (r *reloader) applyLogging(loggingConfig string) error {
+	if loggingConfig == "" {
+		var err error
+		if loggingConfig, err = r.config.ModelLoggingConfig(); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	context := r.config.LoggingContext
+	context.ResetLoggerLevels()
+	return errors.Trace(context.ConfigureLoggers(loggingConfig))
+}
+
+// applyProxySettings installs the model's proxy settings, with the
+// overrides in agent.conf applied, for the agent's own process.
+func (r *reloader) applyProxySettings() error {
+	settings, err := r.config.ModelProxySettings()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	settings = agent.OverrideProxySettings(r.config.Agent.CurrentConfig(), settings)
+	settings.SetEnvironmentValues()
+	return errors.Trace(r.config.InProcessProxyUpdate(settings))
+}
+
+func unknownOverrides(values map[string]string) []string {
+	var unknown []string
+	for name := range values {
+		if _, ok := overrideKeys[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
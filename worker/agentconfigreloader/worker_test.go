@@ -0,0 +1,261 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package agentconfigreloader_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/proxy"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/agent"
+	apiagent "github.com/juju/juju/api/agent"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/agentconfigreloader"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	facade  *mockFacade
+	agent   *mockAgent
+	context *loggo.Context
+	proxies []proxy.Settings
+	config  agentconfigreloader.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.facade = &mockFacade{
+		changes: make(chan struct{}, 1),
+		applied: make(chan int64, 1),
+	}
+	s.agent = &mockAgent{conf: mockConfig{values: make(map[string]string)}}
+	s.context = loggo.NewContext(loggo.WARNING)
+	s.proxies = nil
+	s.config = agentconfigreloader.Config{
+		Facade:         s.facade,
+		Agent:          s.agent,
+		Tag:            names.NewMachineTag("1"),
+		LoggingContext: s.context,
+		ModelLoggingConfig: func() (string, error) {
+			return "<root>=INFO", nil
+		},
+		ModelProxySettings: func() (proxy.Settings, error) {
+			return proxy.Settings{Http: "http://model.proxy", NoProxy: "model"}, nil
+		},
+		InProcessProxyUpdate: func(settings proxy.Settings) error {
+			s.proxies = append(s.proxies, settings)
+			return nil
+		},
+		Logger: loggo.GetLogger("test"),
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	s.config.Facade = nil
+	c.Check(s.config.Validate(), gc.ErrorMatches, "nil Facade not valid")
+	s.SetUpTest(c)
+	s.config.Tag = names.MachineTag{}
+	c.Check(s.config.Validate(), gc.ErrorMatches, "empty Tag not valid")
+	s.SetUpTest(c)
+	s.config.InProcessProxyUpdate = nil
+	c.Check(s.config.Validate(), gc.ErrorMatches, "nil InProcessProxyUpdate not valid")
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := agentconfigreloader.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	return w
+}
+
+func (s *WorkerSuite) change(c *gc.C, overrides apiagent.ConfigOverrides) {
+	s.facade.overrides = overrides
+	select {
+	case s.facade.changes <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending change")
+	}
+	s.waitApplied(c, overrides.Version)
+}
+
+func (s *WorkerSuite) waitApplied(c *gc.C, version int64) {
+	select {
+	case applied := <-s.facade.applied:
+		c.Assert(applied, gc.Equals, version)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for version %d to be applied", version)
+	}
+}
+
+func (s *WorkerSuite) TestAppliesOverrides(c *gc.C) {
+	w := s.startWorker(c)
+	s.change(c, apiagent.ConfigOverrides{
+		Values: map[string]string{
+			"logging-config": "<root>=DEBUG",
+			"api-addresses":  "10.0.0.1:17070",
+			"no-proxy":       "localhost",
+		},
+		Version: 1,
+	})
+	workertest.CleanKill(c, w)
+
+	c.Check(s.agent.conf.values, jc.DeepEquals, map[string]string{
+		agent.LoggingOverride:      "<root>=DEBUG",
+		agent.APIAddressesOverride: "10.0.0.1:17070",
+		agent.NoProxyOverride:      "localhost",
+	})
+	c.Check(s.context.Config().String(), gc.Equals, "<root>=DEBUG")
+	c.Check(s.proxies, jc.DeepEquals, []proxy.Settings{{
+		Http:    "http://model.proxy",
+		NoProxy: "localhost",
+	}})
+}
+
+func (s *WorkerSuite) TestRemovedOverridesRestoreModelConfig(c *gc.C) {
+	w := s.startWorker(c)
+	s.change(c, apiagent.ConfigOverrides{
+		Values: map[string]string{
+			"logging-config": "<root>=DEBUG",
+			"http-proxy":     "http://machine.proxy",
+		},
+		Version: 1,
+	})
+	s.change(c, apiagent.ConfigOverrides{Version: 2})
+	workertest.CleanKill(c, w)
+
+	c.Check(s.agent.conf.values, jc.DeepEquals, map[string]string{
+		agent.LoggingOverride:   "",
+		agent.HTTPProxyOverride: "",
+	})
+	c.Check(s.context.Config().String(), gc.Equals, "<root>=INFO")
+	c.Check(s.proxies, jc.DeepEquals, []proxy.Settings{{
+		Http:    "http://machine.proxy",
+		NoProxy: "model",
+	}, {
+		Http:    "http://model.proxy",
+		NoProxy: "model",
+	}})
+}
+
+func (s *WorkerSuite) TestUnchangedOverridesNotReapplied(c *gc.C) {
+	s.agent.conf.values[agent.NoProxyOverride] = "localhost"
+	w := s.startWorker(c)
+	// The agent already reported applying the current version, so
+	// nothing needs to be done.
+	s.change(c, apiagent.ConfigOverrides{
+		Values:         map[string]string{"no-proxy": "localhost"},
+		Version:        3,
+		AppliedVersion: 3,
+	})
+	workertest.CleanKill(c, w)
+
+	c.Check(s.proxies, gc.HasLen, 0)
+	c.Check(s.context.Config().String(), gc.Equals, "<root>=WARNING")
+}
+
+func (s *WorkerSuite) TestUnappliedOverridesReappliedOnStart(c *gc.C) {
+	s.agent.conf.values[agent.NoProxyOverride] = "localhost"
+	w := s.startWorker(c)
+	s.change(c, apiagent.ConfigOverrides{
+		Values:         map[string]string{"no-proxy": "localhost"},
+		Version:        3,
+		AppliedVersion: 2,
+	})
+	workertest.CleanKill(c, w)
+
+	c.Check(s.proxies, jc.DeepEquals, []proxy.Settings{{
+		Http:    "http://model.proxy",
+		NoProxy: "localhost",
+	}})
+	c.Check(s.context.Config().String(), gc.Equals, "<root>=INFO")
+}
+
+func (s *WorkerSuite) TestUnknownOverridesIgnored(c *gc.C) {
+	w := s.startWorker(c)
+	s.change(c, apiagent.ConfigOverrides{
+		Values:  map[string]string{"data-dir": "/tmp"},
+		Version: 1,
+	})
+	workertest.CleanKill(c, w)
+
+	c.Check(s.agent.conf.values, gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) TestFacadeError(c *gc.C) {
+	s.facade.SetErrors(errors.New("boom"))
+	w := s.startWorker(c)
+	s.facade.changes <- struct{}{}
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "getting agent config overrides: boom")
+}
+
+type mockFacade struct {
+	testing.Stub
+	changes   chan struct{}
+	applied   chan int64
+	overrides apiagent.ConfigOverrides
+}
+
+func (f *mockFacade) WatchAgentConfigOverrides(tag names.MachineTag) (watcher.NotifyWatcher, error) {
+	f.MethodCall(f, "WatchAgentConfigOverrides", tag)
+	return watchertest.NewMockNotifyWatcher(f.changes), nil
+}
+
+func (f *mockFacade) AgentConfigOverrides(tag names.MachineTag) (apiagent.ConfigOverrides, error) {
+	f.MethodCall(f, "AgentConfigOverrides", tag)
+	return f.overrides, f.NextErr()
+}
+
+func (f *mockFacade) SetAgentConfigOverridesApplied(tag names.MachineTag, version int64) error {
+	f.MethodCall(f, "SetAgentConfigOverridesApplied", tag, version)
+	f.applied <- version
+	return f.NextErr()
+}
+
+type mockAgent struct {
+	agent.Agent
+	conf mockConfig
+}
+
+func (a *mockAgent) CurrentConfig() agent.Config {
+	return &a.conf
+}
+
+func (a *mockAgent) ChangeConfig(mutate agent.ConfigMutator) error {
+	return mutate(&a.conf)
+}
+
+type mockConfig struct {
+	agent.ConfigSetter
+	tag    names.Tag
+	values map[string]string
+}
+
+func (c *mockConfig) Tag() names.Tag {
+	if c.tag == nil {
+		return names.NewMachineTag("1")
+	}
+	return c.tag
+}
+
+func (c *mockConfig) Value(key string) string {
+	return c.values[key]
+}
+
+func (c *mockConfig) SetValue(key, value string) {
+	c.values[key] = value
+}
@@ -0,0 +1,119 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscalinggroups
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	apiautoscalinggroups "github.com/juju/juju/api/autoscalinggroups"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/worker/common"
+)
+
+// ManifoldConfig describes the resources and configuration on which the
+// autoscaling groups worker depends.
+type ManifoldConfig struct {
+	APICallerName string
+	EnvironName   string
+	Clock         clock.Clock
+	CheckInterval time.Duration
+	NewWorker     func(Config) (worker.Worker, error)
+	NewFacade     func(base.APICaller) Facade
+	Logger        Logger
+
+	NewCredentialValidatorFacade func(base.APICaller) (common.CredentialAPI, error)
+}
+
+// Manifold returns a Manifold that encapsulates the autoscaling groups
+// worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{config.APICallerName, config.EnvironName},
+		Start:  config.start,
+	}
+}
+
+// start is a StartFunc for a Worker manifold.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var environ environs.Environ
+	if err := context.Get(config.EnvironName, &environ); err != nil {
+		return nil, errors.Trace(err)
+	}
+	groups, ok := environ.(environs.AutoscalingGroups)
+	if !ok {
+		// Without autoscaling groups in the cloud, there is no need
+		// to run this worker.
+		config.Logger.Debugf("uninstalling worker because the environ does not support autoscaling groups %T", environ)
+		return nil, dependency.ErrUninstall
+	}
+	var apiCaller base.APICaller
+	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+		return nil, errors.Trace(err)
+	}
+	credentialAPI, err := config.NewCredentialValidatorFacade(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := config.NewWorker(Config{
+		Facade:        config.NewFacade(apiCaller),
+		Environ:       groups,
+		CallContext:   common.NewCloudCallContext(credentialAPI, nil),
+		CheckInterval: config.CheckInterval,
+		Clock:         config.Clock,
+		Logger:        config.Logger,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Validate is called by start to check for bad configuration.
+func (config ManifoldConfig) Validate() error {
+	if config.APICallerName == "" {
+		return errors.NotValidf("empty APICallerName")
+	}
+	if config.EnvironName == "" {
+		return errors.NotValidf("empty EnvironName")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	if config.NewFacade == nil {
+		return errors.NotValidf("nil NewFacade")
+	}
+	if config.NewCredentialValidatorFacade == nil {
+		return errors.NotValidf("nil NewCredentialValidatorFacade")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// NewFacade returns a new autoscaling groups facade.
+func NewFacade(caller base.APICaller) Facade {
+	return apiautoscalinggroups.NewClient(caller)
+}
+
+// NewWorker returns a new autoscaling groups worker.
+func NewWorker(config Config) (worker.Worker, error) {
+	w, err := New(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
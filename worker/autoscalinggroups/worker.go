@@ -0,0 +1,186 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package autoscalinggroups provides a worker which reconciles the
+// units of applications backed by provider autoscaling groups with the
+// instances in the groups. The cloud scales a group, typically driven
+// by its own metrics; as instances appear in the group, the worker
+// adopts them into the model and adds a machine hosting a unit of the
+// application for each, and as instances disappear from the group, it
+// removes their machines and units.
+package autoscalinggroups
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	apiautoscalinggroups "github.com/juju/juju/api/autoscalinggroups"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+)
+
+// logger is here to stop the desire of creating a package level logger.
+// Don't do this, instead pass one through as config to the worker.
+var logger interface{}
+
+// Facade represents the API used by the autoscaling groups worker.
+type Facade interface {
+	AutoscaledApplications() ([]apiautoscalinggroups.Application, error)
+	AddMachines(application string, ids []instance.Id) error
+	RemoveMachines(machineIds []string) error
+}
+
+// Logger defines the methods used by the autoscaling groups worker for
+// logging.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Warningf(string, ...interface{})
+}
+
+// Config holds all necessary attributes to start an autoscaling groups
+// worker.
+type Config struct {
+	Facade        Facade
+	Environ       environs.AutoscalingGroups
+	CallContext   context.ProviderCallContext
+	CheckInterval time.Duration
+	Clock         clock.Clock
+	Logger        Logger
+}
+
+// Validate will err unless basic requirements for a valid
+// config are met.
+func (c Config) Validate() error {
+	if c.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if c.Environ == nil {
+		return errors.NotValidf("nil Environ")
+	}
+	if c.CallContext == nil {
+		return errors.NotValidf("nil CallContext")
+	}
+	if c.CheckInterval <= 0 {
+		return errors.NotValidf("non-positive CheckInterval")
+	}
+	if c.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if c.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// Worker periodically compares the instances in the autoscaling groups
+// backing applications with the machines added for them.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// New returns a worker which reconciles applications with their
+// autoscaling groups.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config: config,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is defined on worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is defined on worker.Worker.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	timer := w.config.Clock.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-timer.Chan():
+			if err := w.reconcile(); err != nil {
+				return errors.Trace(err)
+			}
+			timer.Reset(w.config.CheckInterval)
+		}
+	}
+}
+
+// reconcile adds machines for the instances which have appeared in the
+// applications' autoscaling groups, and removes the machines whose
+// instances have disappeared from them.
+func (w *Worker) reconcile() error {
+	apps, err := w.config.Facade.AutoscaledApplications()
+	if err != nil {
+		return errors.Annotate(err, "getting autoscaled applications")
+	}
+	for _, app := range apps {
+		insts, err := w.config.Environ.AutoscalingGroupInstances(w.config.CallContext, app.Group)
+		if err != nil {
+			return errors.Annotatef(err, "getting instances in autoscaling group of %q", app.Name)
+		}
+		current := make(map[instance.Id]bool)
+		var added []instance.Id
+		for _, inst := range insts {
+			id := inst.Id()
+			current[id] = true
+			if _, ok := app.Machines[id]; !ok {
+				added = append(added, id)
+			}
+		}
+		var removed []string
+		for id, machineId := range app.Machines {
+			if !current[id] {
+				removed = append(removed, machineId)
+			}
+		}
+
+		if len(added) > 0 {
+			sort.Slice(added, func(i, j int) bool { return added[i] < added[j] })
+			w.config.Logger.Infof("adding units of %q on instances %v in autoscaling group %q", app.Name, added, app.Group)
+			if err := w.config.Environ.AdoptAutoscalingGroupInstances(w.config.CallContext, added); err != nil {
+				return errors.Annotatef(err, "adopting instances for %q", app.Name)
+			}
+			if err := w.config.Facade.AddMachines(app.Name, added); params.IsCodeQuotaLimitExceeded(err) {
+				// The units are added at a later check, once
+				// the model's quotas allow it.
+				w.config.Logger.Warningf("not adding units of %q: %v", app.Name, err)
+			} else if err != nil {
+				return errors.Annotatef(err, "adding machines for %q", app.Name)
+			}
+		}
+		if len(removed) > 0 {
+			sort.Strings(removed)
+			w.config.Logger.Infof("removing units of %q on machines %v gone from autoscaling group %q", app.Name, removed, app.Group)
+			if err := w.config.Facade.RemoveMachines(removed); err != nil {
+				return errors.Annotatef(err, "removing machines for %q", app.Name)
+			}
+		}
+	}
+	return nil
+}
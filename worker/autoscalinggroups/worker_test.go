@@ -0,0 +1,221 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package autoscalinggroups_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	apiautoscalinggroups "github.com/juju/juju/api/autoscalinggroups"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/autoscalinggroups"
+)
+
+const checkInterval = time.Minute
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	stub    *testing.Stub
+	facade  *fakeFacade
+	environ *fakeEnviron
+	clock   *testclock.Clock
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.stub = &testing.Stub{}
+	s.facade = &fakeFacade{
+		stub: s.stub,
+		apps: []apiautoscalinggroups.Application{{
+			Name:  "wordpress",
+			Group: "web",
+			Machines: map[instance.Id]string{
+				"i-0": "3",
+				"i-1": "4",
+			},
+		}},
+	}
+	s.environ = &fakeEnviron{
+		stub:   s.stub,
+		groups: map[string][]instance.Id{"web": {"i-0", "i-1"}},
+	}
+	s.clock = testclock.NewClock(time.Date(2020, 6, 1, 10, 0, 0, 0, time.UTC))
+}
+
+func (s *WorkerSuite) config() autoscalinggroups.Config {
+	return autoscalinggroups.Config{
+		Facade:        s.facade,
+		Environ:       s.environ,
+		CallContext:   context.NewCloudCallContext(),
+		CheckInterval: checkInterval,
+		Clock:         s.clock,
+		Logger:        loggo.GetLogger("test"),
+	}
+}
+
+// startWorker starts the worker, and waits for its first check to
+// finish and the timer to be reset.
+func (s *WorkerSuite) startWorker(c *gc.C) {
+	w, err := autoscalinggroups.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	c.Assert(s.clock.WaitAdvance(0, coretesting.LongWait, 1), jc.ErrorIsNil)
+	c.Assert(s.clock.WaitAdvance(0, coretesting.LongWait, 1), jc.ErrorIsNil)
+}
+
+// runCheck fires the check timer, and waits for the check to finish
+// and the timer to be reset.
+func (s *WorkerSuite) runCheck(c *gc.C) {
+	c.Assert(s.clock.WaitAdvance(checkInterval, coretesting.LongWait, 1), jc.ErrorIsNil)
+	c.Assert(s.clock.WaitAdvance(0, coretesting.LongWait, 1), jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	c.Check(config.Validate(), jc.ErrorIsNil)
+	config.CheckInterval = 0
+	c.Check(config.Validate(), gc.ErrorMatches, "non-positive CheckInterval not valid")
+	config.CheckInterval = checkInterval
+	config.CallContext = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil CallContext not valid")
+	config.Environ = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Environ not valid")
+	config.Facade = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Facade not valid")
+}
+
+func (s *WorkerSuite) TestNoChanges(c *gc.C) {
+	s.startWorker(c)
+	s.stub.CheckCallNames(c, "AutoscaledApplications", "AutoscalingGroupInstances")
+	s.stub.CheckCall(c, 1, "AutoscalingGroupInstances", "web")
+}
+
+func (s *WorkerSuite) TestInstancesAdded(c *gc.C) {
+	s.environ.setGroup("web", "i-0", "i-1", "i-6", "i-5")
+	s.startWorker(c)
+	s.stub.CheckCallNames(c,
+		"AutoscaledApplications", "AutoscalingGroupInstances",
+		"AdoptAutoscalingGroupInstances", "AddMachines",
+	)
+	s.stub.CheckCall(c, 2, "AdoptAutoscalingGroupInstances", []instance.Id{"i-5", "i-6"})
+	s.stub.CheckCall(c, 3, "AddMachines", "wordpress", []instance.Id{"i-5", "i-6"})
+}
+
+func (s *WorkerSuite) TestInstancesAddedQuotaExceeded(c *gc.C) {
+	s.environ.setGroup("web", "i-0", "i-5")
+	s.stub.SetErrors(nil, nil, nil, &params.Error{
+		Code:    params.CodeQuotaLimitExceeded,
+		Message: "cannot add 1 units: model has 5 of 5 allowed by max-model-units",
+	})
+	s.startWorker(c)
+	// The instances gone from the group are still removed.
+	s.stub.CheckCallNames(c,
+		"AutoscaledApplications", "AutoscalingGroupInstances",
+		"AdoptAutoscalingGroupInstances", "AddMachines", "RemoveMachines",
+	)
+	s.stub.CheckCall(c, 4, "RemoveMachines", []string{"4"})
+}
+
+func (s *WorkerSuite) TestInstancesRemoved(c *gc.C) {
+	s.startWorker(c)
+	s.stub.ResetCalls()
+
+	s.environ.setGroup("web")
+	s.runCheck(c)
+	s.stub.CheckCallNames(c, "AutoscaledApplications", "AutoscalingGroupInstances", "RemoveMachines")
+	s.stub.CheckCall(c, 2, "RemoveMachines", []string{"3", "4"})
+}
+
+func (s *WorkerSuite) TestAdoptError(c *gc.C) {
+	s.environ.setGroup("web", "i-0", "i-1", "i-5")
+	s.stub.SetErrors(nil, nil, errors.New("boom"))
+	w, err := autoscalinggroups.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	// The worker stops during its first check, so the timer is never
+	// reset; keep firing it until the failing call has been made.
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		s.clock.Advance(0)
+		if len(s.stub.Calls()) == 3 {
+			break
+		}
+	}
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, `adopting instances for "wordpress": boom`)
+	s.stub.CheckCallNames(c, "AutoscaledApplications", "AutoscalingGroupInstances", "AdoptAutoscalingGroupInstances")
+}
+
+type fakeFacade struct {
+	stub *testing.Stub
+	apps []apiautoscalinggroups.Application
+}
+
+func (f *fakeFacade) AutoscaledApplications() ([]apiautoscalinggroups.Application, error) {
+	f.stub.AddCall("AutoscaledApplications")
+	return f.apps, f.stub.NextErr()
+}
+
+func (f *fakeFacade) AddMachines(application string, ids []instance.Id) error {
+	f.stub.AddCall("AddMachines", application, ids)
+	return f.stub.NextErr()
+}
+
+func (f *fakeFacade) RemoveMachines(machineIds []string) error {
+	f.stub.AddCall("RemoveMachines", machineIds)
+	return f.stub.NextErr()
+}
+
+type fakeEnviron struct {
+	stub *testing.Stub
+
+	mu     sync.Mutex
+	groups map[string][]instance.Id
+}
+
+func (e *fakeEnviron) setGroup(group string, ids ...instance.Id) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.groups[group] = ids
+}
+
+func (e *fakeEnviron) AutoscalingGroupInstances(ctx context.ProviderCallContext, group string) ([]instances.Instance, error) {
+	e.stub.AddCall("AutoscalingGroupInstances", group)
+	if err := e.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var result []instances.Instance
+	for _, id := range e.groups[group] {
+		result = append(result, fakeInstance{id: id})
+	}
+	return result, nil
+}
+
+func (e *fakeEnviron) AdoptAutoscalingGroupInstances(ctx context.ProviderCallContext, ids []instance.Id) error {
+	e.stub.AddCall("AdoptAutoscalingGroupInstances", ids)
+	return e.stub.NextErr()
+}
+
+type fakeInstance struct {
+	instances.Instance
+	id instance.Id
+}
+
+func (i fakeInstance) Id() instance.Id {
+	return i.id
+}
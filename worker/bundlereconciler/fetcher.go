@@ -0,0 +1,53 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundlereconciler
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/juju/errors"
+)
+
+// maxBundleSize is the largest bundle which will be read from a
+// bundle-source URL.
+const maxBundleSize = 4 << 20
+
+// Fetcher reads bundles from the URLs named by bundle-source.
+type Fetcher interface {
+	// Fetch returns the bundle at the given URL.
+	Fetch(url string) (string, error)
+}
+
+// NewHTTPFetcher returns a Fetcher which reads bundles from http and
+// https URLs using the given client, such as the raw file URLs served
+// by git hosting services.
+func NewHTTPFetcher(client *http.Client) Fetcher {
+	return httpFetcher{client: client}
+}
+
+type httpFetcher struct {
+	client *http.Client
+}
+
+// Fetch is part of the Fetcher interface.
+func (f httpFetcher) Fetch(url string) (string, error) {
+	resp, err := f.client.Get(url)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", errors.Errorf("%s returned %s", url, resp.Status)
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxBundleSize+1))
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(data) > maxBundleSize {
+		return "", errors.Errorf("bundle at %s is larger than %d bytes", url, maxBundleSize)
+	}
+	return string(data), nil
+}
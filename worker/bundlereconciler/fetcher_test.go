@@ -0,0 +1,58 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundlereconciler_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/bundlereconciler"
+)
+
+type FetcherSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&FetcherSuite{})
+
+func (s *FetcherSuite) TestFetch(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, gc.Equals, "GET")
+		c.Check(r.URL.Path, gc.Equals, "/ops/bundle.yaml")
+		w.Write([]byte(ubuntuBundle))
+	}))
+	defer server.Close()
+
+	fetcher := bundlereconciler.NewHTTPFetcher(server.Client())
+	bundle, err := fetcher.Fetch(server.URL + "/ops/bundle.yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(bundle, gc.Equals, ubuntuBundle)
+}
+
+func (s *FetcherSuite) TestFetchErrorStatus(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	fetcher := bundlereconciler.NewHTTPFetcher(server.Client())
+	_, err := fetcher.Fetch(server.URL)
+	c.Assert(err, gc.ErrorMatches, ".* returned 404 Not Found")
+}
+
+func (s *FetcherSuite) TestFetchTooLarge(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(strings.Repeat("#", 4<<20+1)))
+	}))
+	defer server.Close()
+
+	fetcher := bundlereconciler.NewHTTPFetcher(server.Client())
+	_, err := fetcher.Fetch(server.URL)
+	c.Assert(err, gc.ErrorMatches, "bundle at .* is larger than 4194304 bytes")
+}
@@ -0,0 +1,96 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundlereconciler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/api/base"
+	apibundlereconciler "github.com/juju/juju/api/bundlereconciler"
+)
+
+// fetchTimeout is how long a bundle-source URL is given to return the
+// bundle.
+const fetchTimeout = 30 * time.Second
+
+// ManifoldConfig describes the resources and configuration on which the
+// bundle reconciler worker depends.
+type ManifoldConfig struct {
+	APICallerName string
+	Clock         clock.Clock
+	CheckInterval time.Duration
+	NewWorker     func(Config) (worker.Worker, error)
+	NewFacade     func(base.APICaller) Facade
+	Logger        Logger
+}
+
+// Manifold returns a Manifold that encapsulates the bundle reconciler worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{config.APICallerName},
+		Start:  config.start,
+	}
+}
+
+// start is a StartFunc for a Worker manifold.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var apiCaller base.APICaller
+	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := config.NewWorker(Config{
+		Facade:        config.NewFacade(apiCaller),
+		Fetcher:       NewHTTPFetcher(&http.Client{Timeout: fetchTimeout}),
+		CheckInterval: config.CheckInterval,
+		Clock:         config.Clock,
+		Logger:        config.Logger,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Validate is called by start to check for bad configuration.
+func (config ManifoldConfig) Validate() error {
+	if config.APICallerName == "" {
+		return errors.NotValidf("empty APICallerName")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	if config.NewFacade == nil {
+		return errors.NotValidf("nil NewFacade")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// NewFacade returns a new bundle reconciler facade.
+func NewFacade(caller base.APICaller) Facade {
+	return apibundlereconciler.NewClient(caller)
+}
+
+// NewWorker returns a new bundle reconciler worker.
+func NewWorker(config Config) (worker.Worker, error) {
+	w, err := New(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
@@ -0,0 +1,278 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package bundlereconciler provides a worker which keeps a model in
+// line with the bundle named by the model's bundle-source: either the
+// latest revision of the bundle stored in the controller, or a bundle
+// read from an http or https URL. Drift between the model and the
+// bundle is reported in the model's status. In the "manual"
+// bundle-reconcile-mode the changes needed to remove the drift are only
+// applied once the plan making them has been approved; in the "auto"
+// mode they are applied as soon as they are seen.
+package bundlereconciler
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs/config"
+)
+
+// logger is here to stop the desire of creating a package level logger.
+// Don't do this, instead pass one through as config to the worker.
+var logger interface{}
+
+// Facade represents the API used by the bundle reconciler worker.
+type Facade interface {
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+	ModelConfig() (*config.Config, error)
+	WatchBundleChanges() (watcher.NotifyWatcher, error)
+	DesiredBundle() (string, error)
+	ApprovedPlan() (string, error)
+	PlanModel(bundle string) (params.ModelPlanResult, error)
+	ApplyModelPlan(bundle, fingerprint string) ([]params.ModelPlanChangeResult, error)
+	SetDrift(message string, data map[string]interface{}) error
+}
+
+// Logger defines the methods used by the bundle reconciler worker for
+// logging.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Warningf(string, ...interface{})
+}
+
+// Config holds all necessary attributes to start a bundle reconciler
+// worker.
+type Config struct {
+	Facade        Facade
+	Fetcher       Fetcher
+	CheckInterval time.Duration
+	Clock         clock.Clock
+	Logger        Logger
+}
+
+// Validate will err unless basic requirements for a valid
+// config are met.
+func (c Config) Validate() error {
+	if c.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if c.Fetcher == nil {
+		return errors.NotValidf("nil Fetcher")
+	}
+	if c.CheckInterval <= 0 {
+		return errors.NotValidf("non-positive CheckInterval")
+	}
+	if c.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if c.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// Worker periodically compares the model with its bundle, and reports
+// or removes any drift.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// reported holds the drift message last reported in the model
+	// status, or nil if none has been reported by this worker.
+	reported *string
+}
+
+// New returns a worker which reconciles the model with its bundle.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config: config,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is defined on worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is defined on worker.Worker.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	modelConfigWatcher, err := w.config.Facade.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(modelConfigWatcher); err != nil {
+		return errors.Trace(err)
+	}
+	bundleWatcher, err := w.config.Facade.WatchBundleChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(bundleWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var (
+		modelConfig *config.Config
+		timer       clock.Timer
+		timerCh     <-chan time.Time
+	)
+	// checkNow arranges for the model to be checked straight away, once
+	// the model config has been read.
+	checkNow := func() {
+		if timer == nil {
+			timer = w.config.Clock.NewTimer(0)
+			timerCh = timer.Chan()
+		} else {
+			timer.Reset(0)
+		}
+	}
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+
+		case _, ok := <-modelConfigWatcher.Changes():
+			if !ok {
+				return errors.New("model configuration watcher closed")
+			}
+			modelConfig, err = w.config.Facade.ModelConfig()
+			if err != nil {
+				return errors.Annotate(err, "cannot load model configuration")
+			}
+			source, _ := modelConfig.BundleSource()
+			w.config.Logger.Debugf("bundle source for %s (%s) is %q, reconcile mode %q",
+				modelConfig.Name(), modelConfig.UUID(), source, modelConfig.BundleReconcileMode())
+			checkNow()
+
+		case _, ok := <-bundleWatcher.Changes():
+			if !ok {
+				return errors.New("bundle watcher closed")
+			}
+			if modelConfig != nil {
+				checkNow()
+			}
+
+		case <-timerCh:
+			if err := w.check(modelConfig); err != nil {
+				return errors.Trace(err)
+			}
+			timer.Reset(w.config.CheckInterval)
+		}
+	}
+}
+
+// check compares the model with its bundle, and either reports the
+// drift between them or applies the changes needed to remove it.
+// Problems with the bundle itself are reported rather than returned,
+// so that they do not restart the worker.
+func (w *Worker) check(modelConfig *config.Config) error {
+	source, ok := modelConfig.BundleSource()
+	if !ok {
+		return w.setDrift("", nil)
+	}
+	data := map[string]interface{}{"bundle-source": source}
+
+	var bundle string
+	if source == config.BundleSourceController {
+		var err error
+		bundle, err = w.config.Facade.DesiredBundle()
+		if errors.IsNotFound(err) {
+			return w.setDrift("bundle drift: no bundle stored for the model", data)
+		} else if err != nil {
+			return errors.Annotate(err, "cannot get model bundle")
+		}
+	} else {
+		var err error
+		bundle, err = w.config.Fetcher.Fetch(source)
+		if err != nil {
+			w.config.Logger.Warningf("cannot read bundle from %s: %v", source, err)
+			return w.setDrift(fmt.Sprintf("bundle drift: cannot read bundle: %v", err), data)
+		}
+	}
+
+	plan, err := w.config.Facade.PlanModel(bundle)
+	if err != nil {
+		return errors.Annotate(err, "cannot plan model changes")
+	}
+	if len(plan.Errors) > 0 {
+		return w.setDrift("bundle drift: bundle cannot be applied: "+strings.Join(plan.Errors, "; "), data)
+	}
+	if len(plan.Changes) == 0 {
+		return w.setDrift("", nil)
+	}
+	data["bundle-plan"] = plan.Fingerprint
+	data["bundle-diff"] = plan.Diff
+
+	if modelConfig.BundleReconcileMode() == config.BundleReconcileManual {
+		approved, err := w.config.Facade.ApprovedPlan()
+		if err != nil {
+			return errors.Annotate(err, "cannot get approved plan")
+		}
+		if approved != plan.Fingerprint {
+			message := fmt.Sprintf("bundle drift: %s awaiting approval (plan %s)",
+				pluralChanges(len(plan.Changes)), plan.Fingerprint)
+			return w.setDrift(message, data)
+		}
+	}
+
+	w.config.Logger.Infof("applying plan %s with %s", plan.Fingerprint, pluralChanges(len(plan.Changes)))
+	results, err := w.config.Facade.ApplyModelPlan(bundle, plan.Fingerprint)
+	if err != nil {
+		// The model may have changed since the plan was made; the
+		// next check will make a new one.
+		w.config.Logger.Warningf("cannot apply plan %s: %v", plan.Fingerprint, err)
+		return w.setDrift(fmt.Sprintf("bundle drift: cannot apply plan %s: %v", plan.Fingerprint, err), data)
+	}
+	for _, result := range results {
+		if result.Error != nil {
+			w.config.Logger.Warningf("cannot apply change %q of plan %s: %v", result.Id, plan.Fingerprint, result.Error)
+			message := fmt.Sprintf("bundle drift: change %q of plan %s failed: %v", result.Id, plan.Fingerprint, result.Error)
+			return w.setDrift(message, data)
+		}
+	}
+	return w.setDrift("", nil)
+}
+
+// setDrift reports the drift in the model status, if it differs from
+// that last reported.
+func (w *Worker) setDrift(message string, data map[string]interface{}) error {
+	if w.reported != nil && *w.reported == message {
+		return nil
+	}
+	if err := w.config.Facade.SetDrift(message, data); err != nil {
+		return errors.Annotate(err, "cannot report bundle drift")
+	}
+	w.reported = &message
+	return nil
+}
+
+func pluralChanges(n int) string {
+	if n == 1 {
+		return "1 change"
+	}
+	return fmt.Sprintf("%d changes", n)
+}
@@ -0,0 +1,328 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundlereconciler_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/bundlereconciler"
+)
+
+const (
+	checkInterval = 5 * time.Minute
+	bundleURL     = "https://git.internal/ops/raw/master/bundle.yaml"
+	ubuntuBundle  = "applications:\n  ubuntu:\n    charm: cs:trusty/ubuntu-10\n    num_units: 1\n"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	facade  *fakeFacade
+	fetcher *fakeFetcher
+	clock   *testclock.Clock
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.facade = &fakeFacade{
+		configChanges: make(chan struct{}, 1),
+		bundleChanges: make(chan struct{}, 1),
+		bundle:        ubuntuBundle,
+		plan:          twoChangePlan("abc"),
+	}
+	s.fetcher = &fakeFetcher{}
+	s.clock = testclock.NewClock(time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC))
+}
+
+func twoChangePlan(fingerprint string) params.ModelPlanResult {
+	return params.ModelPlanResult{
+		Diff: "applications:\n  ubuntu:\n    missing: model\n",
+		Changes: []*params.BundleChangesMapArgs{
+			{Id: "addCharm-0", Method: "addCharm"},
+			{Id: "deploy-1", Method: "deploy"},
+		},
+		Fingerprint: fingerprint,
+	}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C, source, mode string) {
+	attrs := coretesting.FakeConfig()
+	attrs["bundle-source"] = source
+	attrs["bundle-reconcile-mode"] = mode
+	cfg, err := config.New(config.UseDefaults, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade.modelConfig = cfg
+
+	w, err := bundlereconciler.New(bundlereconciler.Config{
+		Facade:        s.facade,
+		Fetcher:       s.fetcher,
+		CheckInterval: checkInterval,
+		Clock:         s.clock,
+		Logger:        loggo.GetLogger("test"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	s.facade.configChanges <- struct{}{}
+
+	// The model is checked as soon as the config has been read; wait
+	// for the check to finish and the timer to be reset.
+	c.Assert(s.clock.WaitAdvance(0, coretesting.LongWait, 1), jc.ErrorIsNil)
+	c.Assert(s.clock.WaitAdvance(0, coretesting.LongWait, 1), jc.ErrorIsNil)
+}
+
+// runCheck fires the check timer, and waits for the check to finish
+// and the timer to be reset.
+func (s *WorkerSuite) runCheck(c *gc.C) {
+	c.Assert(s.clock.WaitAdvance(checkInterval, coretesting.LongWait, 1), jc.ErrorIsNil)
+	c.Assert(s.clock.WaitAdvance(0, coretesting.LongWait, 1), jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := bundlereconciler.Config{
+		Facade:        s.facade,
+		Fetcher:       s.fetcher,
+		CheckInterval: checkInterval,
+		Clock:         s.clock,
+		Logger:        loggo.GetLogger("test"),
+	}
+	c.Check(config.Validate(), jc.ErrorIsNil)
+	config.CheckInterval = 0
+	c.Check(config.Validate(), gc.ErrorMatches, "non-positive CheckInterval not valid")
+	config.CheckInterval = checkInterval
+	config.Fetcher = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Fetcher not valid")
+	config.Facade = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Facade not valid")
+}
+
+func (s *WorkerSuite) TestNoSource(c *gc.C) {
+	s.startWorker(c, "", "manual")
+	s.facade.CheckCallNames(c, "WatchForModelConfigChanges", "WatchBundleChanges", "ModelConfig", "SetDrift")
+	s.facade.CheckCall(c, 3, "SetDrift", "", map[string]interface{}(nil))
+
+	// The drift is only reported when it changes.
+	s.runCheck(c)
+	s.facade.CheckCallNames(c, "WatchForModelConfigChanges", "WatchBundleChanges", "ModelConfig", "SetDrift")
+}
+
+func (s *WorkerSuite) TestManualAwaitingApproval(c *gc.C) {
+	s.startWorker(c, "controller", "manual")
+	s.facade.CheckCallNames(c,
+		"WatchForModelConfigChanges", "WatchBundleChanges", "ModelConfig",
+		"DesiredBundle", "PlanModel", "ApprovedPlan", "SetDrift",
+	)
+	s.facade.CheckCall(c, 4, "PlanModel", ubuntuBundle)
+	s.facade.CheckCall(c, 6, "SetDrift", "bundle drift: 2 changes awaiting approval (plan abc)", map[string]interface{}{
+		"bundle-source": "controller",
+		"bundle-plan":   "abc",
+		"bundle-diff":   "applications:\n  ubuntu:\n    missing: model\n",
+	})
+
+	s.runCheck(c)
+	c.Assert(s.facade.callNames(), gc.HasLen, 10)
+	s.facade.CheckCall(c, 9, "ApprovedPlan")
+}
+
+func (s *WorkerSuite) TestManualApproved(c *gc.C) {
+	s.facade.approved = "abc"
+	s.startWorker(c, "controller", "manual")
+	s.facade.CheckCallNames(c,
+		"WatchForModelConfigChanges", "WatchBundleChanges", "ModelConfig",
+		"DesiredBundle", "PlanModel", "ApprovedPlan", "ApplyModelPlan", "SetDrift",
+	)
+	s.facade.CheckCall(c, 6, "ApplyModelPlan", ubuntuBundle, "abc")
+	s.facade.CheckCall(c, 7, "SetDrift", "", map[string]interface{}(nil))
+}
+
+func (s *WorkerSuite) TestApprovalTriggersCheck(c *gc.C) {
+	s.startWorker(c, "controller", "manual")
+	s.facade.setApproved("abc")
+	s.facade.bundleChanges <- struct{}{}
+	s.waitForCall(c, "ApplyModelPlan")
+}
+
+func (s *WorkerSuite) TestAutoApplies(c *gc.C) {
+	s.startWorker(c, bundleURL, "auto")
+	s.fetcher.CheckCall(c, 0, "Fetch", bundleURL)
+	s.facade.CheckCallNames(c,
+		"WatchForModelConfigChanges", "WatchBundleChanges", "ModelConfig",
+		"PlanModel", "ApplyModelPlan", "SetDrift",
+	)
+	s.facade.CheckCall(c, 4, "ApplyModelPlan", ubuntuBundle, "abc")
+}
+
+func (s *WorkerSuite) TestNoChanges(c *gc.C) {
+	s.facade.plan = params.ModelPlanResult{Fingerprint: "empty"}
+	s.startWorker(c, "controller", "auto")
+	s.facade.CheckCallNames(c,
+		"WatchForModelConfigChanges", "WatchBundleChanges", "ModelConfig",
+		"DesiredBundle", "PlanModel", "SetDrift",
+	)
+	s.facade.CheckCall(c, 5, "SetDrift", "", map[string]interface{}(nil))
+}
+
+func (s *WorkerSuite) TestNoBundleStored(c *gc.C) {
+	s.facade.bundle = ""
+	s.startWorker(c, "controller", "auto")
+	s.facade.CheckCallNames(c,
+		"WatchForModelConfigChanges", "WatchBundleChanges", "ModelConfig",
+		"DesiredBundle", "SetDrift",
+	)
+	s.facade.CheckCall(c, 4, "SetDrift", "bundle drift: no bundle stored for the model", map[string]interface{}{
+		"bundle-source": "controller",
+	})
+}
+
+func (s *WorkerSuite) TestFetchError(c *gc.C) {
+	s.fetcher.SetErrors(errors.New("connection refused"))
+	s.startWorker(c, bundleURL, "auto")
+	s.facade.CheckCallNames(c,
+		"WatchForModelConfigChanges", "WatchBundleChanges", "ModelConfig", "SetDrift",
+	)
+	s.facade.CheckCall(c, 3, "SetDrift", "bundle drift: cannot read bundle: connection refused", map[string]interface{}{
+		"bundle-source": bundleURL,
+	})
+}
+
+func (s *WorkerSuite) TestPlanErrors(c *gc.C) {
+	s.facade.plan.Errors = []string{`change "addCharm-0": charm URL "cs:ubuntu" without a revision not valid`}
+	s.startWorker(c, "controller", "auto")
+	s.facade.CheckCallNames(c,
+		"WatchForModelConfigChanges", "WatchBundleChanges", "ModelConfig",
+		"DesiredBundle", "PlanModel", "SetDrift",
+	)
+	s.facade.CheckCall(c, 5, "SetDrift",
+		`bundle drift: bundle cannot be applied: change "addCharm-0": charm URL "cs:ubuntu" without a revision not valid`,
+		map[string]interface{}{"bundle-source": "controller"},
+	)
+}
+
+func (s *WorkerSuite) TestApplyFailure(c *gc.C) {
+	s.facade.applyResults = []params.ModelPlanChangeResult{
+		{Id: "addCharm-0", Result: "cs:trusty/ubuntu-10"},
+		{Id: "deploy-1", Error: &params.Error{Message: "boom"}},
+	}
+	s.startWorker(c, "controller", "auto")
+	s.facade.CheckCall(c, 6, "SetDrift",
+		`bundle drift: change "deploy-1" of plan abc failed: boom`,
+		map[string]interface{}{
+			"bundle-source": "controller",
+			"bundle-plan":   "abc",
+			"bundle-diff":   "applications:\n  ubuntu:\n    missing: model\n",
+		},
+	)
+}
+
+// waitForCall advances the clock until the worker has made the named
+// facade call, for checks triggered by watchers.
+func (s *WorkerSuite) waitForCall(c *gc.C, name string) {
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		s.clock.Advance(0)
+		for _, called := range s.facade.callNames() {
+			if called == name {
+				return
+			}
+		}
+	}
+	c.Fatalf("worker did not call %s", name)
+}
+
+type fakeFacade struct {
+	testing.Stub
+	configChanges chan struct{}
+	bundleChanges chan struct{}
+	modelConfig   *config.Config
+	bundle        string
+	plan          params.ModelPlanResult
+	applyResults  []params.ModelPlanChangeResult
+
+	mu       sync.Mutex
+	approved string
+}
+
+func (f *fakeFacade) callNames() []string {
+	var names []string
+	for _, call := range f.Calls() {
+		names = append(names, call.FuncName)
+	}
+	return names
+}
+
+func (f *fakeFacade) setApproved(fingerprint string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.approved = fingerprint
+}
+
+func (f *fakeFacade) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	f.MethodCall(f, "WatchForModelConfigChanges")
+	return watchertest.NewMockNotifyWatcher(f.configChanges), f.NextErr()
+}
+
+func (f *fakeFacade) ModelConfig() (*config.Config, error) {
+	f.MethodCall(f, "ModelConfig")
+	return f.modelConfig, f.NextErr()
+}
+
+func (f *fakeFacade) WatchBundleChanges() (watcher.NotifyWatcher, error) {
+	f.MethodCall(f, "WatchBundleChanges")
+	return watchertest.NewMockNotifyWatcher(f.bundleChanges), f.NextErr()
+}
+
+func (f *fakeFacade) DesiredBundle() (string, error) {
+	f.MethodCall(f, "DesiredBundle")
+	if f.bundle == "" {
+		return "", errors.NotFoundf("model bundle")
+	}
+	return f.bundle, f.NextErr()
+}
+
+func (f *fakeFacade) ApprovedPlan() (string, error) {
+	f.MethodCall(f, "ApprovedPlan")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.approved, f.NextErr()
+}
+
+func (f *fakeFacade) PlanModel(bundle string) (params.ModelPlanResult, error) {
+	f.MethodCall(f, "PlanModel", bundle)
+	return f.plan, f.NextErr()
+}
+
+func (f *fakeFacade) ApplyModelPlan(bundle, fingerprint string) ([]params.ModelPlanChangeResult, error) {
+	f.MethodCall(f, "ApplyModelPlan", bundle, fingerprint)
+	return f.applyResults, f.NextErr()
+}
+
+func (f *fakeFacade) SetDrift(message string, data map[string]interface{}) error {
+	f.MethodCall(f, "SetDrift", message, data)
+	return f.NextErr()
+}
+
+type fakeFetcher struct {
+	testing.Stub
+}
+
+func (f *fakeFetcher) Fetch(url string) (string, error) {
+	f.MethodCall(f, "Fetch", url)
+	if err := f.NextErr(); err != nil {
+		return "", err
+	}
+	return ubuntuBundle, nil
+}
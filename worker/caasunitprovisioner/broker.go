@@ -28,3 +28,10 @@ type ServiceBroker interface {
 	UnexposeService(appName string) error
 	WatchService(appName string) (watcher.NotifyWatcher, error)
 }
+
+// PartitionedUpgrader is implemented by service brokers which can roll
+// out changes to an application's units one at a time.
+type PartitionedUpgrader interface {
+	caas.PartitionedUpgrader
+	WatchUnits(appName string) (watcher.NotifyWatcher, error)
+}
@@ -4,11 +4,14 @@
 package caasunitprovisioner
 
 import (
+	"reflect"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v3"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/catacomb"
 
+	apicaasunitprovisioner "github.com/juju/juju/api/caasunitprovisioner"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/caas"
 	k8sprovider "github.com/juju/juju/caas/kubernetes/provider"
@@ -77,9 +80,17 @@ func (w *deploymentWorker) loop() error {
 		cw       watcher.NotifyWatcher
 		specChan watcher.NotifyChannel
 
-		currentScale int
-		currentSpec  string
+		// upgradeChan is used to step through a partitioned
+		// upgrade as the application's units change.
+		uw          watcher.NotifyWatcher
+		upgradeChan watcher.NotifyChannel
+
+		currentScale       int
+		currentSpec        string
+		currentCredentials []apicaasunitprovisioner.RegistryCredential
+		currentPolicy      *apicaasunitprovisioner.NetworkPolicy
 	)
+	upgrader, canPartition := w.broker.(PartitionedUpgrader)
 
 	gotSpecNotify := false
 	serviceUpdated := false
@@ -113,6 +124,20 @@ func (w *deploymentWorker) loop() error {
 				return errors.New("watcher closed channel")
 			}
 			gotSpecNotify = true
+		case _, ok := <-upgradeChan:
+			if !ok {
+				return errors.New("watcher closed channel")
+			}
+			done, err := upgrader.AdvanceUpgrade(w.application)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if done {
+				logger.Debugf("finished upgrading units of %v", w.application)
+				worker.Stop(uw)
+				upgradeChan = nil
+			}
+			continue
 		}
 		if desiredScale > 0 && !gotSpecNotify {
 			continue
@@ -140,12 +165,16 @@ func (w *deploymentWorker) loop() error {
 		}
 
 		specStr := info.PodSpec
-		if desiredScale == currentScale && specStr == currentSpec {
+		if desiredScale == currentScale && specStr == currentSpec &&
+			reflect.DeepEqual(info.RegistryCredentials, currentCredentials) &&
+			reflect.DeepEqual(info.NetworkPolicy, currentPolicy) {
 			continue
 		}
 
 		currentScale = desiredScale
 		currentSpec = specStr
+		currentCredentials = info.RegistryCredentials
+		currentPolicy = info.NetworkPolicy
 
 		appConfig, err := w.applicationGetter.ApplicationConfig(w.application)
 		if err != nil {
@@ -167,6 +196,21 @@ func (w *deploymentWorker) loop() error {
 				DeploymentType: caas.DeploymentType(info.DeploymentInfo.DeploymentType),
 				ServiceType:    caas.ServiceType(info.DeploymentInfo.ServiceType),
 			},
+			PartitionedUpgrade: info.CanaryUpgrade && canPartition,
+		}
+		for _, cred := range info.RegistryCredentials {
+			serviceParams.RegistryCredentials = append(serviceParams.RegistryCredentials, caas.RegistryCredential{
+				Registry: cred.Registry,
+				Username: cred.Username,
+				Password: cred.Password,
+			})
+		}
+		if policy := info.NetworkPolicy; policy != nil {
+			serviceParams.NetworkPolicy = &caas.NetworkPolicy{
+				Isolated:            policy.Isolated,
+				RelatedApplications: policy.RelatedApplications,
+				Exposed:             policy.Exposed,
+			}
 		}
 		err = w.broker.EnsureService(w.application, w.provisioningStatusSetter.SetOperatorStatus, serviceParams, desiredScale, appConfig)
 		if err != nil {
@@ -178,6 +222,22 @@ func (w *deploymentWorker) loop() error {
 			return errors.Trace(err)
 		}
 		logger.Debugf("ensured deployment for %s for %v units", w.application, desiredScale)
+		if serviceParams.PartitionedUpgrade {
+			// The units are upgraded one at a time, as each upgraded
+			// unit becomes ready. The broker starts over with the
+			// first unit each time the service is ensured, so a new
+			// watcher is started to have its initial event step
+			// through any units which are already upgraded.
+			if upgradeChan != nil {
+				worker.Stop(uw)
+			}
+			uw, err = upgrader.WatchUnits(w.application)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			w.catacomb.Add(uw)
+			upgradeChan = uw.Changes()
+		}
 		if !serviceUpdated && !spec.OmitServiceFrontend {
 			service, err := w.broker.GetService(w.application, false)
 			if err != nil && !errors.IsNotFound(err) {
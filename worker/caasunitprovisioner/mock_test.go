@@ -44,6 +44,9 @@ type mockServiceBroker struct {
 	deleted        chan<- struct{}
 	serviceStatus  status.StatusInfo
 	serviceWatcher *watchertest.MockNotifyWatcher
+	unitsWatcher   *watchertest.MockNotifyWatcher
+	advanced       chan<- struct{}
+	upgradeDone    bool
 }
 
 func (m *mockServiceBroker) Provider() caas.ContainerEnvironProvider {
@@ -70,6 +73,17 @@ func (m *mockServiceBroker) WatchService(appName string) (watcher.NotifyWatcher,
 	return m.serviceWatcher, m.NextErr()
 }
 
+func (m *mockServiceBroker) WatchUnits(appName string) (watcher.NotifyWatcher, error) {
+	m.MethodCall(m, "WatchUnits", appName)
+	return m.unitsWatcher, m.NextErr()
+}
+
+func (m *mockServiceBroker) AdvanceUpgrade(appName string) (bool, error) {
+	m.MethodCall(m, "AdvanceUpgrade", appName)
+	m.advanced <- struct{}{}
+	return m.upgradeDone, m.NextErr()
+}
+
 func (m *mockServiceBroker) DeleteService(appName string) error {
 	m.MethodCall(m, "DeleteService", appName)
 	m.deleted <- struct{}{}
@@ -50,11 +50,13 @@ type WorkerSuite struct {
 	applicationScaleChanges chan struct{}
 	caasUnitsChanges        chan struct{}
 	caasServiceChanges      chan struct{}
+	caasUpgradeChanges      chan struct{}
 	caasOperatorChanges     chan struct{}
 	containerSpecChanges    chan struct{}
 	serviceDeleted          chan struct{}
 	serviceEnsured          chan struct{}
 	serviceUpdated          chan struct{}
+	upgradeAdvanced         chan struct{}
 	clock                   *testclock.Clock
 }
 
@@ -119,11 +121,13 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 	s.applicationScaleChanges = make(chan struct{})
 	s.caasUnitsChanges = make(chan struct{})
 	s.caasServiceChanges = make(chan struct{})
+	s.caasUpgradeChanges = make(chan struct{})
 	s.caasOperatorChanges = make(chan struct{})
 	s.containerSpecChanges = make(chan struct{}, 1)
 	s.serviceDeleted = make(chan struct{})
 	s.serviceEnsured = make(chan struct{})
 	s.serviceUpdated = make(chan struct{})
+	s.upgradeAdvanced = make(chan struct{})
 
 	s.applicationGetter = mockApplicationGetter{
 		watcher:      watchertest.NewMockStringsWatcher(s.applicationChanges),
@@ -162,6 +166,8 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 		ensured:        s.serviceEnsured,
 		deleted:        s.serviceDeleted,
 		serviceWatcher: watchertest.NewMockNotifyWatcher(s.caasServiceChanges),
+		unitsWatcher:   watchertest.NewMockNotifyWatcher(s.caasUpgradeChanges),
+		advanced:       s.upgradeAdvanced,
 	}
 	s.statusSetter = mockProvisioningStatusSetter{}
 
@@ -429,6 +435,40 @@ func (s *WorkerSuite) TestScaleChangedInCluster(c *gc.C) {
 	})
 }
 
+func (s *WorkerSuite) sendUpgradeUnitsChange(c *gc.C) {
+	select {
+	case s.caasUpgradeChanges <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out sending units change")
+	}
+	select {
+	case <-s.upgradeAdvanced:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for upgrade to be advanced")
+	}
+}
+
+func (s *WorkerSuite) TestCanaryUpgrade(c *gc.C) {
+	s.podSpecGetter.provisioningInfo.CanaryUpgrade = true
+	w := s.setupNewUnitScenario(c)
+	defer workertest.CleanKill(c, w)
+
+	s.serviceBroker.CheckCallNames(c, "EnsureService", "WatchUnits", "GetService")
+	params := s.serviceBroker.Calls()[0].Args[1].(*caas.ServiceParams)
+	c.Assert(params.PartitionedUpgrade, jc.IsTrue)
+	s.serviceBroker.ResetCalls()
+
+	// Each change to the units steps the upgrade on.
+	s.sendUpgradeUnitsChange(c)
+	s.serviceBroker.upgradeDone = true
+	s.sendUpgradeUnitsChange(c)
+	s.serviceBroker.CheckCallNames(c, "AdvanceUpgrade", "AdvanceUpgrade")
+	s.serviceBroker.CheckCall(c, 0, "AdvanceUpgrade", "gitlab")
+
+	// Once all units are upgraded, the units are no longer watched.
+	workertest.CheckKilled(c, s.serviceBroker.unitsWatcher)
+}
+
 func (s *WorkerSuite) TestNewPodSpecChange(c *gc.C) {
 	w := s.setupNewUnitScenario(c)
 	defer workertest.CleanKill(c, w)
@@ -480,6 +520,70 @@ containers:
 		"gitlab", expectedParams, 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
 }
 
+func (s *WorkerSuite) TestRegistryCredentialsChange(c *gc.C) {
+	w := s.setupNewUnitScenario(c)
+	defer workertest.CleanKill(c, w)
+
+	s.serviceBroker.ResetCalls()
+
+	info := s.podSpecGetter.provisioningInfo
+	info.RegistryCredentials = []apicaasunitprovisioner.RegistryCredential{{
+		Registry: "registry.example.com",
+		Username: "bob",
+		Password: "secret",
+	}}
+	s.podSpecGetter.setProvisioningInfo(info)
+	s.sendContainerSpecChange(c)
+	s.podSpecGetter.assertSpecRetrieved(c)
+
+	select {
+	case <-s.serviceEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be ensured")
+	}
+
+	expectedParams := getExpectedServiceParams()
+	expectedParams.RegistryCredentials = []caas.RegistryCredential{{
+		Registry: "registry.example.com",
+		Username: "bob",
+		Password: "secret",
+	}}
+	s.serviceBroker.CheckCallNames(c, "EnsureService")
+	s.serviceBroker.CheckCall(c, 0, "EnsureService",
+		"gitlab", expectedParams, 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
+}
+
+func (s *WorkerSuite) TestNetworkPolicyChange(c *gc.C) {
+	w := s.setupNewUnitScenario(c)
+	defer workertest.CleanKill(c, w)
+
+	s.serviceBroker.ResetCalls()
+
+	info := s.podSpecGetter.provisioningInfo
+	info.NetworkPolicy = &apicaasunitprovisioner.NetworkPolicy{
+		Isolated:            true,
+		RelatedApplications: []string{"mariadb"},
+	}
+	s.podSpecGetter.setProvisioningInfo(info)
+	s.sendContainerSpecChange(c)
+	s.podSpecGetter.assertSpecRetrieved(c)
+
+	select {
+	case <-s.serviceEnsured:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for service to be ensured")
+	}
+
+	expectedParams := getExpectedServiceParams()
+	expectedParams.NetworkPolicy = &caas.NetworkPolicy{
+		Isolated:            true,
+		RelatedApplications: []string{"mariadb"},
+	}
+	s.serviceBroker.CheckCallNames(c, "EnsureService")
+	s.serviceBroker.CheckCall(c, 0, "EnsureService",
+		"gitlab", expectedParams, 1, application.ConfigAttributes{"juju-external-hostname": "exthost"})
+}
+
 func (s *WorkerSuite) TestScaleZero(c *gc.C) {
 	w := s.setupNewUnitScenario(c)
 	defer workertest.CleanKill(c, w)
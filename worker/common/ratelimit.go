@@ -0,0 +1,233 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/juju/ratelimit"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	"github.com/juju/juju/network"
+	providercommon "github.com/juju/juju/provider/common"
+)
+
+// ErrRateLimitAborted is returned by CloudRateLimiter.Wait, and by the
+// calls it limits, if the caller is dying before the call may be made.
+var ErrRateLimitAborted = errors.New("cloud API call aborted while rate limited")
+
+// CloudRateLimiter limits the rate at which a model's workers call the
+// cloud API. A single limiter is shared by all the provider-polling
+// workers of a model (the instance poller, firewaller and provisioner)
+// so that between them they stay within the cloud's own rate limits.
+//
+// A nil *CloudRateLimiter imposes no limit.
+type CloudRateLimiter struct {
+	clock  clock.Clock
+	bucket *ratelimit.Bucket
+}
+
+// NewCloudRateLimiter returns a CloudRateLimiter which allows rate calls
+// per second on average, in bursts of up to burst calls. If rate is not
+// positive it returns nil, which imposes no limit.
+func NewCloudRateLimiter(clk clock.Clock, rate, burst int) *CloudRateLimiter {
+	if rate <= 0 {
+		return nil
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &CloudRateLimiter{
+		clock:  clk,
+		bucket: ratelimit.NewBucketWithRateAndClock(float64(rate), int64(burst), ratelimitClock{clk}),
+	}
+}
+
+// Wait blocks until another cloud API call may be made. If abort is
+// closed first, it returns ErrRateLimitAborted.
+func (l *CloudRateLimiter) Wait(abort <-chan struct{}) error {
+	if l == nil {
+		return nil
+	}
+	d := l.bucket.Take(1)
+	if d <= 0 {
+		return nil
+	}
+	select {
+	case <-l.clock.After(d):
+		return nil
+	case <-abort:
+		return ErrRateLimitAborted
+	}
+}
+
+// ratelimitClock adapts clock.Clock to ratelimit.Clock.
+type ratelimitClock struct {
+	clock.Clock
+}
+
+// Sleep is defined by the ratelimit.Clock interface.
+func (c ratelimitClock) Sleep(d time.Duration) {
+	<-c.Clock.After(d)
+}
+
+// RateLimitEnviron returns an Environ whose instance management calls
+// wait for the given limiter, aborting if the call context is dying.
+// If limiter is nil, env is returned unchanged.
+//
+// If env is a providercommon.ZonedEnviron, so is the returned Environ.
+// Any other optional interfaces implemented by env, such as
+// environs.Firewaller, are hidden by it. Callers which need those must
+// check for them on the original environ, and rate limit them
+// separately where a wrapper exists.
+func RateLimitEnviron(env environs.Environ, limiter *CloudRateLimiter) environs.Environ {
+	if limiter == nil {
+		return env
+	}
+	limited := &rateLimitedEnviron{Environ: env, limiter: limiter}
+	if zoned, ok := env.(providercommon.ZonedEnviron); ok {
+		return &rateLimitedZonedEnviron{rateLimitedEnviron: limited, zoned: zoned}
+	}
+	return limited
+}
+
+type rateLimitedEnviron struct {
+	environs.Environ
+	limiter *CloudRateLimiter
+}
+
+// StartInstance is part of the environs.InstanceBroker interface.
+func (e *rateLimitedEnviron) StartInstance(
+	ctx context.ProviderCallContext, args environs.StartInstanceParams,
+) (*environs.StartInstanceResult, error) {
+	if err := e.limiter.Wait(ctx.Dying()); err != nil {
+		return nil, err
+	}
+	return e.Environ.StartInstance(ctx, args)
+}
+
+// StopInstances is part of the environs.InstanceBroker interface.
+func (e *rateLimitedEnviron) StopInstances(ctx context.ProviderCallContext, ids ...instance.Id) error {
+	if err := e.limiter.Wait(ctx.Dying()); err != nil {
+		return err
+	}
+	return e.Environ.StopInstances(ctx, ids...)
+}
+
+// AllInstances is part of the environs.InstanceBroker interface.
+func (e *rateLimitedEnviron) AllInstances(ctx context.ProviderCallContext) ([]instances.Instance, error) {
+	if err := e.limiter.Wait(ctx.Dying()); err != nil {
+		return nil, err
+	}
+	return e.Environ.AllInstances(ctx)
+}
+
+// AllRunningInstances is part of the environs.InstanceBroker interface.
+func (e *rateLimitedEnviron) AllRunningInstances(ctx context.ProviderCallContext) ([]instances.Instance, error) {
+	if err := e.limiter.Wait(ctx.Dying()); err != nil {
+		return nil, err
+	}
+	return e.Environ.AllRunningInstances(ctx)
+}
+
+// MaintainInstance is part of the environs.InstanceBroker interface.
+func (e *rateLimitedEnviron) MaintainInstance(ctx context.ProviderCallContext, args environs.StartInstanceParams) error {
+	if err := e.limiter.Wait(ctx.Dying()); err != nil {
+		return err
+	}
+	return e.Environ.MaintainInstance(ctx, args)
+}
+
+// Instances is part of the environs.Environ interface.
+func (e *rateLimitedEnviron) Instances(ctx context.ProviderCallContext, ids []instance.Id) ([]instances.Instance, error) {
+	if err := e.limiter.Wait(ctx.Dying()); err != nil {
+		return nil, err
+	}
+	return e.Environ.Instances(ctx, ids)
+}
+
+// ControllerInstances is part of the environs.Environ interface.
+func (e *rateLimitedEnviron) ControllerInstances(ctx context.ProviderCallContext, controllerUUID string) ([]instance.Id, error) {
+	if err := e.limiter.Wait(ctx.Dying()); err != nil {
+		return nil, err
+	}
+	return e.Environ.ControllerInstances(ctx, controllerUUID)
+}
+
+// rateLimitedZonedEnviron is a rateLimitedEnviron which also rate
+// limits the methods of providercommon.ZonedEnviron.
+type rateLimitedZonedEnviron struct {
+	*rateLimitedEnviron
+	zoned providercommon.ZonedEnviron
+}
+
+// AvailabilityZones is part of the providercommon.ZonedEnviron interface.
+func (e *rateLimitedZonedEnviron) AvailabilityZones(ctx context.ProviderCallContext) ([]providercommon.AvailabilityZone, error) {
+	if err := e.limiter.Wait(ctx.Dying()); err != nil {
+		return nil, err
+	}
+	return e.zoned.AvailabilityZones(ctx)
+}
+
+// InstanceAvailabilityZoneNames is part of the providercommon.ZonedEnviron
+// interface.
+func (e *rateLimitedZonedEnviron) InstanceAvailabilityZoneNames(ctx context.ProviderCallContext, ids []instance.Id) ([]string, error) {
+	if err := e.limiter.Wait(ctx.Dying()); err != nil {
+		return nil, err
+	}
+	return e.zoned.InstanceAvailabilityZoneNames(ctx, ids)
+}
+
+// DeriveAvailabilityZones is part of the providercommon.ZonedEnviron
+// interface.
+func (e *rateLimitedZonedEnviron) DeriveAvailabilityZones(ctx context.ProviderCallContext, args environs.StartInstanceParams) ([]string, error) {
+	if err := e.limiter.Wait(ctx.Dying()); err != nil {
+		return nil, err
+	}
+	return e.zoned.DeriveAvailabilityZones(ctx, args)
+}
+
+// RateLimitFirewaller returns a Firewaller whose calls wait for the
+// given limiter, aborting if the call context is dying. If limiter is
+// nil, fw is returned unchanged.
+func RateLimitFirewaller(fw environs.Firewaller, limiter *CloudRateLimiter) environs.Firewaller {
+	if limiter == nil {
+		return fw
+	}
+	return &rateLimitedFirewaller{Firewaller: fw, limiter: limiter}
+}
+
+type rateLimitedFirewaller struct {
+	environs.Firewaller
+	limiter *CloudRateLimiter
+}
+
+// OpenPorts is part of the environs.Firewaller interface.
+func (f *rateLimitedFirewaller) OpenPorts(ctx context.ProviderCallContext, rules []network.IngressRule) error {
+	if err := f.limiter.Wait(ctx.Dying()); err != nil {
+		return err
+	}
+	return f.Firewaller.OpenPorts(ctx, rules)
+}
+
+// ClosePorts is part of the environs.Firewaller interface.
+func (f *rateLimitedFirewaller) ClosePorts(ctx context.ProviderCallContext, rules []network.IngressRule) error {
+	if err := f.limiter.Wait(ctx.Dying()); err != nil {
+		return err
+	}
+	return f.Firewaller.ClosePorts(ctx, rules)
+}
+
+// IngressRules is part of the environs.Firewaller interface.
+func (f *rateLimitedFirewaller) IngressRules(ctx context.ProviderCallContext) ([]network.IngressRule, error) {
+	if err := f.limiter.Wait(ctx.Dying()); err != nil {
+		return nil, err
+	}
+	return f.Firewaller.IngressRules(ctx)
+}
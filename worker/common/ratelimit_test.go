@@ -0,0 +1,129 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package common_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
+	"github.com/juju/juju/environs/instances"
+	providercommon "github.com/juju/juju/provider/common"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/common"
+)
+
+type rateLimitSuite struct {
+	coretesting.BaseSuite
+	clock *testclock.Clock
+}
+
+var _ = gc.Suite(&rateLimitSuite{})
+
+func (s *rateLimitSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Now())
+}
+
+func (s *rateLimitSuite) TestNoLimit(c *gc.C) {
+	limiter := common.NewCloudRateLimiter(s.clock, 0, 10)
+	c.Assert(limiter, gc.IsNil)
+	for i := 0; i < 100; i++ {
+		c.Assert(limiter.Wait(nil), jc.ErrorIsNil)
+	}
+
+	env := &fakeEnviron{}
+	c.Assert(common.RateLimitEnviron(env, nil), gc.Equals, env)
+}
+
+func (s *rateLimitSuite) TestBurstThenWait(c *gc.C) {
+	limiter := common.NewCloudRateLimiter(s.clock, 1, 2)
+	c.Assert(limiter.Wait(nil), jc.ErrorIsNil)
+	c.Assert(limiter.Wait(nil), jc.ErrorIsNil)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- limiter.Wait(nil)
+	}()
+	err := s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case err := <-done:
+		c.Assert(err, jc.ErrorIsNil)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for rate limiter")
+	}
+}
+
+func (s *rateLimitSuite) TestWaitAborted(c *gc.C) {
+	limiter := common.NewCloudRateLimiter(s.clock, 1, 1)
+	c.Assert(limiter.Wait(nil), jc.ErrorIsNil)
+
+	abort := make(chan struct{})
+	close(abort)
+	c.Assert(limiter.Wait(abort), gc.Equals, common.ErrRateLimitAborted)
+}
+
+func (s *rateLimitSuite) TestEnvironSharesLimiter(c *gc.C) {
+	limiter := common.NewCloudRateLimiter(s.clock, 1, 1)
+	fake1, fake2 := &fakeEnviron{}, &fakeEnviron{}
+	env1 := common.RateLimitEnviron(fake1, limiter)
+	env2 := common.RateLimitEnviron(fake2, limiter)
+
+	dying := make(chan struct{})
+	ctx := &context.CloudCallContext{DyingFunc: func() <-chan struct{} { return dying }}
+	_, err := env1.Instances(ctx, []instance.Id{"i-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fake1.instanceCalls, gc.Equals, 1)
+
+	// The second environ has to wait for the first's call.
+	close(dying)
+	_, err = env2.Instances(ctx, []instance.Id{"i-2"})
+	c.Assert(err, gc.Equals, common.ErrRateLimitAborted)
+	c.Assert(fake2.instanceCalls, gc.Equals, 0)
+}
+
+func (s *rateLimitSuite) TestZonedEnviron(c *gc.C) {
+	limiter := common.NewCloudRateLimiter(s.clock, 1, 1)
+	env := common.RateLimitEnviron(&fakeZonedEnviron{}, limiter)
+	zoned, ok := env.(providercommon.ZonedEnviron)
+	c.Assert(ok, jc.IsTrue)
+
+	_, err := zoned.AvailabilityZones(context.NewCloudCallContext())
+	c.Assert(err, jc.ErrorIsNil)
+
+	ctx := &context.CloudCallContext{DyingFunc: func() <-chan struct{} {
+		dying := make(chan struct{})
+		close(dying)
+		return dying
+	}}
+	_, err = zoned.AvailabilityZones(ctx)
+	c.Assert(err, gc.Equals, common.ErrRateLimitAborted)
+
+	_, ok = common.RateLimitEnviron(&fakeEnviron{}, limiter).(providercommon.ZonedEnviron)
+	c.Assert(ok, jc.IsFalse)
+}
+
+type fakeEnviron struct {
+	environs.Environ
+	instanceCalls int
+}
+
+func (e *fakeEnviron) Instances(context.ProviderCallContext, []instance.Id) ([]instances.Instance, error) {
+	e.instanceCalls++
+	return nil, nil
+}
+
+type fakeZonedEnviron struct {
+	providercommon.ZonedEnviron
+}
+
+func (e *fakeZonedEnviron) AvailabilityZones(context.ProviderCallContext) ([]providercommon.AvailabilityZone, error) {
+	return nil, nil
+}
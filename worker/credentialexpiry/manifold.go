@@ -0,0 +1,107 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialexpiry
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/state"
+	workerstate "github.com/juju/juju/worker/state"
+)
+
+// ManifoldConfig holds the information necessary to run a credential
+// expiry worker in a dependency.Engine.
+type ManifoldConfig struct {
+	ClockName string
+	StateName string
+
+	Logger        Logger
+	Interval      time.Duration
+	WarningPeriod time.Duration
+
+	NewBackend func(*state.StatePool) Backend
+	NewWorker  func(Config) (worker.Worker, error)
+}
+
+// Validate returns an error if the config cannot be used
+// to start the worker.
+func (config ManifoldConfig) Validate() error {
+	if config.ClockName == "" {
+		return errors.NotValidf("empty ClockName")
+	}
+	if config.StateName == "" {
+		return errors.NotValidf("empty StateName")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	if config.WarningPeriod <= 0 {
+		return errors.NotValidf("non-positive WarningPeriod")
+	}
+	if config.NewBackend == nil {
+		return errors.NotValidf("nil NewBackend")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold that will run a
+// credential expiry worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.ClockName,
+			config.StateName,
+		},
+		Start: config.start,
+	}
+}
+
+// start is a method on ManifoldConfig because it's more readable than a closure.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var stTracker workerstate.StateTracker
+	if err := context.Get(config.StateName, &stTracker); err != nil {
+		return nil, errors.Trace(err)
+	}
+	statePool, err := stTracker.Use()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	w, err := config.NewWorker(Config{
+		Backend:       config.NewBackend(statePool),
+		Clock:         clock,
+		Logger:        config.Logger,
+		Interval:      config.Interval,
+		WarningPeriod: config.WarningPeriod,
+	})
+	if err != nil {
+		stTracker.Done()
+		return nil, errors.Trace(err)
+	}
+	go func() {
+		w.Wait()
+		stTracker.Done()
+	}()
+	return w, nil
+}
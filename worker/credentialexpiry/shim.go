@@ -0,0 +1,56 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialexpiry
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+
+	"github.com/juju/juju/state"
+)
+
+// NewBackend returns a Backend that reads the models
+// and credentials from the state pool.
+func NewBackend(pool *state.StatePool) Backend {
+	return backendShim{pool}
+}
+
+type backendShim struct {
+	pool *state.StatePool
+}
+
+// AllModelUUIDs is part of the Backend interface.
+func (b backendShim) AllModelUUIDs() ([]string, error) {
+	return b.pool.SystemState().AllModelUUIDs()
+}
+
+// Model is part of the Backend interface.
+func (b backendShim) Model(modelUUID string) (Model, func(), error) {
+	model, helper, err := b.pool.GetModel(modelUUID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	release := func() { helper.Release() }
+	return modelShim{model, b.pool.SystemState()}, release, nil
+}
+
+type modelShim struct {
+	*state.Model
+	st *state.State
+}
+
+// CloudCredential is part of the Model interface.
+func (m modelShim) CloudCredential() (names.CloudCredentialTag, time.Time, error) {
+	tag, ok := m.Model.CloudCredential()
+	if !ok {
+		return names.CloudCredentialTag{}, time.Time{}, nil
+	}
+	credential, err := m.st.CloudCredential(tag)
+	if err != nil {
+		return names.CloudCredentialTag{}, time.Time{}, errors.Trace(err)
+	}
+	return tag, credential.Expiry, nil
+}
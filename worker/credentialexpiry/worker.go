@@ -0,0 +1,218 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package credentialexpiry provides a worker that warns of cloud
+// credentials that are about to expire, by setting the status of the
+// models that use them, so that the credentials can be updated before
+// the models are suspended.
+package credentialexpiry
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/core/status"
+)
+
+// ExpiryDataKey is the key of the model status data entry that
+// records the credential expiry that a model was warned about.
+const ExpiryDataKey = "credential-expiry"
+
+// Logger represents the methods used by the worker to log details.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Warningf(string, ...interface{})
+}
+
+// Backend provides access to the models on the controller.
+type Backend interface {
+	// AllModelUUIDs returns the UUIDs of all the models
+	// on the controller.
+	AllModelUUIDs() ([]string, error)
+
+	// Model returns the model with the given UUID, and a function
+	// that must be called to release it.
+	Model(modelUUID string) (Model, func(), error)
+}
+
+// Model represents a model whose credential expiry is checked.
+type Model interface {
+	// Name returns the name of the model.
+	Name() string
+
+	// CloudCredential returns the tag of the model's cloud credential
+	// and the time at which it expires. The tag is the zero value if
+	// the model has no credential, and the expiry is the zero time if
+	// it is not known.
+	CloudCredential() (names.CloudCredentialTag, time.Time, error)
+
+	// Status returns the status of the model.
+	Status() (status.StatusInfo, error)
+
+	// SetStatus sets the status of the model.
+	SetStatus(status.StatusInfo) error
+}
+
+// Config holds the resources and configuration needed by the worker.
+type Config struct {
+	Backend Backend
+	Clock   clock.Clock
+	Logger  Logger
+
+	// Interval is how often the credentials of all the
+	// models are checked.
+	Interval time.Duration
+
+	// WarningPeriod is how long before a credential expires
+	// that the models using it are warned.
+	WarningPeriod time.Duration
+}
+
+// Validate returns an error if the config cannot be used
+// to start a worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	if config.WarningPeriod <= 0 {
+		return errors.NotValidf("non-positive WarningPeriod")
+	}
+	return nil
+}
+
+// Worker periodically checks the expiry of the cloud credentials used
+// by the models on the controller.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// NewWorker returns a worker that warns of expiring credentials
+// through the status of the models that use them.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	for {
+		// Check straight away, so that a restarted controller
+		// doesn't wait a whole interval to report expiries.
+		if err := w.checkModels(); err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(w.config.Interval):
+		}
+	}
+}
+
+func (w *Worker) checkModels() error {
+	modelUUIDs, err := w.config.Backend.AllModelUUIDs()
+	if err != nil {
+		return errors.Annotate(err, "getting models")
+	}
+	now := w.config.Clock.Now()
+	for _, modelUUID := range modelUUIDs {
+		err := w.checkModel(modelUUID, now)
+		if errors.IsNotFound(err) {
+			// The model has been removed since we listed it.
+			continue
+		}
+		if err != nil {
+			return errors.Annotatef(err, "checking credential expiry for model %q", modelUUID)
+		}
+	}
+	return nil
+}
+
+func (w *Worker) checkModel(modelUUID string, now time.Time) error {
+	model, release, err := w.config.Backend.Model(modelUUID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer release()
+
+	tag, expiry, err := model.CloudCredential()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	current, err := model.Status()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if current.Status != status.Available {
+		// Don't hide anything more important, such as the model
+		// being suspended because the credential is invalid.
+		return nil
+	}
+
+	_, warned := current.Data[ExpiryDataKey]
+	message := expiryMessage(tag, expiry, now, w.config.WarningPeriod)
+	switch {
+	case message == "" && !warned:
+		return nil
+	case message == "":
+		w.config.Logger.Debugf("model %q no longer has an expiring credential", model.Name())
+		return errors.Trace(model.SetStatus(status.StatusInfo{Status: status.Available}))
+	case warned && current.Message == message:
+		return nil
+	}
+	w.config.Logger.Warningf("model %q: %s", model.Name(), message)
+	return errors.Trace(model.SetStatus(status.StatusInfo{
+		Status:  status.Available,
+		Message: message,
+		Data: map[string]interface{}{
+			ExpiryDataKey: expiry.UTC().Format(time.RFC3339),
+		},
+	}))
+}
+
+// expiryMessage returns the warning to report for a credential with
+// the given expiry, or the empty string if no warning is needed.
+func expiryMessage(tag names.CloudCredentialTag, expiry, now time.Time, warningPeriod time.Duration) string {
+	if tag == (names.CloudCredentialTag{}) || expiry.IsZero() || expiry.After(now.Add(warningPeriod)) {
+		return ""
+	}
+	verb := "expires"
+	if !expiry.After(now) {
+		verb = "expired"
+	}
+	return fmt.Sprintf("cloud credential %q %s at %s", tag.Id(), verb, expiry.UTC().Format(time.RFC3339))
+}
@@ -0,0 +1,267 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package credentialexpiry_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/status"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/credentialexpiry"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock   *testclock.Clock
+	backend *fakeBackend
+	config  credentialexpiry.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+var credentialTag = names.NewCloudCredentialTag("aws/bob/secrets")
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.backend = &fakeBackend{
+		models:   make(map[string]*fakeModel),
+		released: make(chan string, 10),
+	}
+	s.config = credentialexpiry.Config{
+		Backend:       s.backend,
+		Clock:         s.clock,
+		Logger:        loggo.GetLogger("test"),
+		Interval:      time.Hour,
+		WarningPeriod: 7 * 24 * time.Hour,
+	}
+}
+
+func (s *WorkerSuite) addModel(uuid string, expiry time.Time) *fakeModel {
+	m := &fakeModel{
+		name:       "model-" + uuid,
+		credential: credentialTag,
+		expiry:     expiry,
+		status:     status.StatusInfo{Status: status.Available},
+	}
+	s.backend.models[uuid] = m
+	return m
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := credentialexpiry.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	return w
+}
+
+// waitChecked waits for the worker to finish checking
+// the given models.
+func (s *WorkerSuite) waitChecked(c *gc.C, uuids ...string) {
+	for range uuids {
+		select {
+		case <-s.backend.released:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for models to be checked")
+		}
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	breakers := []struct {
+		breaker func(config *credentialexpiry.Config)
+		err     string
+	}{{
+		func(config *credentialexpiry.Config) { config.Backend = nil },
+		"nil Backend not valid",
+	}, {
+		func(config *credentialexpiry.Config) { config.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(config *credentialexpiry.Config) { config.Logger = nil },
+		"nil Logger not valid",
+	}, {
+		func(config *credentialexpiry.Config) { config.Interval = 0 },
+		"non-positive Interval not valid",
+	}, {
+		func(config *credentialexpiry.Config) { config.WarningPeriod = 0 },
+		"non-positive WarningPeriod not valid",
+	}}
+	for i, test := range breakers {
+		c.Logf("test %d", i)
+		config := s.config
+		test.breaker(&config)
+		_, err := credentialexpiry.NewWorker(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WorkerSuite) TestWarnsOfExpiringCredential(c *gc.C) {
+	m := s.addModel("a", s.clock.Now().Add(48*time.Hour))
+	w := s.startWorker(c)
+	s.waitChecked(c, "a")
+	workertest.CleanKill(c, w)
+
+	c.Assert(m.status, jc.DeepEquals, status.StatusInfo{
+		Status:  status.Available,
+		Message: `cloud credential "aws/bob/secrets" expires at 2020-06-03T12:00:00Z`,
+		Data: map[string]interface{}{
+			credentialexpiry.ExpiryDataKey: "2020-06-03T12:00:00Z",
+		},
+	})
+}
+
+func (s *WorkerSuite) TestWarnsOfExpiredCredential(c *gc.C) {
+	m := s.addModel("a", s.clock.Now().Add(-time.Minute))
+	w := s.startWorker(c)
+	s.waitChecked(c, "a")
+	workertest.CleanKill(c, w)
+
+	c.Assert(m.status.Message, gc.Equals, `cloud credential "aws/bob/secrets" expired at 2020-06-01T11:59:00Z`)
+}
+
+func (s *WorkerSuite) TestIgnoresDistantExpiry(c *gc.C) {
+	m := s.addModel("a", s.clock.Now().Add(30*24*time.Hour))
+	n := s.addModel("b", time.Time{})
+	w := s.startWorker(c)
+	s.waitChecked(c, "a", "b")
+	workertest.CleanKill(c, w)
+
+	c.Check(m.setCount, gc.Equals, 0)
+	c.Check(n.setCount, gc.Equals, 0)
+}
+
+func (s *WorkerSuite) TestDoesNotHideOtherStatus(c *gc.C) {
+	m := s.addModel("a", s.clock.Now().Add(-time.Minute))
+	m.status = status.StatusInfo{
+		Status:  status.Suspended,
+		Message: "suspended: invalid credential",
+	}
+	w := s.startWorker(c)
+	s.waitChecked(c, "a")
+	workertest.CleanKill(c, w)
+
+	c.Check(m.setCount, gc.Equals, 0)
+}
+
+func (s *WorkerSuite) TestClearsWarningWhenCredentialUpdated(c *gc.C) {
+	m := s.addModel("a", s.clock.Now().Add(30*24*time.Hour))
+	m.status = status.StatusInfo{
+		Status:  status.Available,
+		Message: `cloud credential "aws/bob/secrets" expires at 2020-06-03T12:00:00Z`,
+		Data: map[string]interface{}{
+			credentialexpiry.ExpiryDataKey: "2020-06-03T12:00:00Z",
+		},
+	}
+	w := s.startWorker(c)
+	s.waitChecked(c, "a")
+	workertest.CleanKill(c, w)
+
+	c.Check(m.status, jc.DeepEquals, status.StatusInfo{Status: status.Available})
+}
+
+func (s *WorkerSuite) TestRechecksAfterInterval(c *gc.C) {
+	m := s.addModel("a", s.clock.Now().Add(8*24*time.Hour))
+	w := s.startWorker(c)
+	s.waitChecked(c, "a")
+	c.Check(m.setCount, gc.Equals, 0)
+
+	// The warning is only given once the expiry is within
+	// the warning period, and isn't repeated.
+	for i := 0; i < 25; i++ {
+		err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+		s.waitChecked(c, "a")
+	}
+	workertest.CleanKill(c, w)
+	c.Check(m.setCount, gc.Equals, 1)
+	c.Check(m.status.Message, gc.Equals, `cloud credential "aws/bob/secrets" expires at 2020-06-09T12:00:00Z`)
+}
+
+func (s *WorkerSuite) TestSkipsRemovedModels(c *gc.C) {
+	s.backend.uuids = []string{"gone"}
+	m := s.addModel("a", s.clock.Now().Add(time.Hour))
+	w := s.startWorker(c)
+	s.waitChecked(c, "a")
+	workertest.CleanKill(c, w)
+
+	c.Check(m.setCount, gc.Equals, 1)
+}
+
+func (s *WorkerSuite) TestBackendError(c *gc.C) {
+	s.backend.err = errors.New("boom")
+	w := s.startWorker(c)
+	err := workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "getting models: boom")
+}
+
+type fakeBackend struct {
+	mu       sync.Mutex
+	uuids    []string
+	models   map[string]*fakeModel
+	err      error
+	released chan string
+}
+
+func (b *fakeBackend) AllModelUUIDs() ([]string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err != nil {
+		return nil, b.err
+	}
+	uuids := append([]string(nil), b.uuids...)
+	for uuid := range b.models {
+		uuids = append(uuids, uuid)
+	}
+	return uuids, nil
+}
+
+func (b *fakeBackend) Model(uuid string) (credentialexpiry.Model, func(), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m, ok := b.models[uuid]
+	if !ok {
+		return nil, nil, errors.NotFoundf("model %q", uuid)
+	}
+	return m, func() { b.released <- uuid }, nil
+}
+
+type fakeModel struct {
+	name       string
+	credential names.CloudCredentialTag
+	expiry     time.Time
+	status     status.StatusInfo
+	setCount   int
+}
+
+func (m *fakeModel) Name() string {
+	return m.name
+}
+
+func (m *fakeModel) CloudCredential() (names.CloudCredentialTag, time.Time, error) {
+	return m.credential, m.expiry, nil
+}
+
+func (m *fakeModel) Status() (status.StatusInfo, error) {
+	return m.status, nil
+}
+
+func (m *fakeModel) SetStatus(info status.StatusInfo) error {
+	m.setCount++
+	m.status = info
+	return nil
+}
@@ -0,0 +1,221 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package crossmodelhealth provides a worker which runs on the offering
+// side of cross model relations, and periodically probes each
+// connection to an offer hosted in the model. A connection is unhealthy
+// if the consuming model may no longer discharge the macaroons it uses
+// to access the relation, or if it has not contacted the model for
+// longer than the model's cross-model-contact-timeout. The consuming
+// model is in contact while it makes authenticated requests for the
+// relation, and while its controller stays connected watching the
+// relation, which the offering controller records periodically. The
+// status of the relations of unhealthy connections is set to error, so
+// that dead links between controllers are surfaced before they are
+// next used.
+package crossmodelhealth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs/config"
+)
+
+// logger is here to stop the desire of creating a package level logger.
+// Don't do this, instead pass one through as config to the worker.
+var logger interface{}
+
+// Facade represents the API used by the cross model health worker.
+type Facade interface {
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+	ModelConfig() (*config.Config, error)
+	OfferConnections() ([]params.OfferConnectionProbe, error)
+	SetOfferConnectionsHealth([]params.OfferConnectionHealth) error
+}
+
+// Logger defines the methods used by the cross model health worker for
+// logging.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+}
+
+// Config holds all necessary attributes to start a cross model health
+// worker.
+type Config struct {
+	Facade        Facade
+	ProbeInterval time.Duration
+	Clock         clock.Clock
+	Logger        Logger
+}
+
+// Validate will err unless basic requirements for a valid
+// config are met.
+func (c Config) Validate() error {
+	if c.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if c.ProbeInterval <= 0 {
+		return errors.NotValidf("non-positive ProbeInterval")
+	}
+	if c.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if c.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// Worker periodically probes the connections to offers hosted in the
+// model.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// firstSeen records when each connection was first probed, and
+	// stands in for the last contact of connections which have not
+	// yet been contacted by their consuming models.
+	firstSeen map[string]time.Time
+}
+
+// New returns a worker which probes the connections to offers hosted
+// in the model.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config:    config,
+		firstSeen: make(map[string]time.Time),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is defined on worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is defined on worker.Worker.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	modelConfigWatcher, err := w.config.Facade.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(modelConfigWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var (
+		timeout time.Duration
+		timer   clock.Timer
+		timerCh <-chan time.Time
+	)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+
+		case _, ok := <-modelConfigWatcher.Changes():
+			if !ok {
+				return errors.New("model configuration watcher closed")
+			}
+			modelConfig, err := w.config.Facade.ModelConfig()
+			if err != nil {
+				return errors.Annotate(err, "cannot load model configuration")
+			}
+			if newTimeout := modelConfig.CrossModelContactTimeout(); newTimeout != timeout {
+				w.config.Logger.Infof("cross model contact timeout: %v for %s (%s)",
+					newTimeout, modelConfig.Name(), modelConfig.UUID())
+				timeout = newTimeout
+			}
+			if timer == nil {
+				timer = w.config.Clock.NewTimer(w.config.ProbeInterval)
+				timerCh = timer.Chan()
+			}
+
+		case <-timerCh:
+			if err := w.probe(timeout); err != nil {
+				return errors.Trace(err)
+			}
+			timer.Reset(w.config.ProbeInterval)
+		}
+	}
+}
+
+// probe checks the health of each connection and records it. A zero
+// timeout disables the check of when the consuming model was last in
+// contact.
+func (w *Worker) probe(timeout time.Duration) error {
+	conns, err := w.config.Facade.OfferConnections()
+	if err != nil {
+		return errors.Annotate(err, "cannot get offer connections")
+	}
+	now := w.config.Clock.Now()
+	seen := make(map[string]bool)
+	health := make([]params.OfferConnectionHealth, len(conns))
+	for i, conn := range conns {
+		seen[conn.RelationTag] = true
+		if _, ok := w.firstSeen[conn.RelationTag]; !ok {
+			w.firstSeen[conn.RelationTag] = now
+		}
+		health[i] = params.OfferConnectionHealth{
+			RelationTag: conn.RelationTag,
+			Message:     w.problem(conn, timeout, now),
+			Time:        now,
+		}
+		if health[i].Message != "" {
+			w.config.Logger.Debugf("connection for %s is unhealthy: %s", conn.RelationTag, health[i].Message)
+		}
+	}
+	for tag := range w.firstSeen {
+		if !seen[tag] {
+			delete(w.firstSeen, tag)
+		}
+	}
+	if len(health) == 0 {
+		return nil
+	}
+	if err := w.config.Facade.SetOfferConnectionsHealth(health); err != nil {
+		return errors.Annotate(err, "cannot set offer connections health")
+	}
+	return nil
+}
+
+// problem returns a description of why the connection is unhealthy, or
+// the empty string if it is healthy.
+func (w *Worker) problem(conn params.OfferConnectionProbe, timeout time.Duration, now time.Time) string {
+	if conn.Error != nil {
+		return conn.Error.Message
+	}
+	if timeout == 0 {
+		return ""
+	}
+	lastContact := w.firstSeen[conn.RelationTag]
+	if conn.LastContact != nil {
+		lastContact = *conn.LastContact
+	}
+	if now.Sub(lastContact) < timeout {
+		return ""
+	}
+	return fmt.Sprintf("no contact from consuming model for over %v", timeout)
+}
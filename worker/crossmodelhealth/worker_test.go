@@ -0,0 +1,192 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package crossmodelhealth_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/crossmodelhealth"
+)
+
+const probeInterval = time.Minute
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	facade *fakeFacade
+	clock  *testclock.Clock
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.facade = &fakeFacade{
+		changes: make(chan struct{}, 1),
+	}
+	s.clock = testclock.NewClock(time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC))
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C, timeout string) {
+	attrs := coretesting.FakeConfig()
+	attrs["cross-model-contact-timeout"] = timeout
+	cfg, err := config.New(config.UseDefaults, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade.modelConfig = cfg
+
+	w, err := crossmodelhealth.New(crossmodelhealth.Config{
+		Facade:        s.facade,
+		ProbeInterval: probeInterval,
+		Clock:         s.clock,
+		Logger:        loggo.GetLogger("test"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	s.facade.changes <- struct{}{}
+}
+
+// runProbe fires the probe timer, and waits for the probe to finish
+// and the timer to be reset.
+func (s *WorkerSuite) runProbe(c *gc.C, conns ...params.OfferConnectionProbe) {
+	s.facade.setConnections(conns)
+	c.Assert(s.clock.WaitAdvance(probeInterval, coretesting.LongWait, 1), jc.ErrorIsNil)
+	c.Assert(s.clock.WaitAdvance(0, coretesting.LongWait, 1), jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := crossmodelhealth.Config{
+		Facade:        s.facade,
+		ProbeInterval: probeInterval,
+		Clock:         s.clock,
+		Logger:        loggo.GetLogger("test"),
+	}
+	c.Check(config.Validate(), jc.ErrorIsNil)
+	config.ProbeInterval = 0
+	c.Check(config.Validate(), gc.ErrorMatches, "non-positive ProbeInterval not valid")
+	config.ProbeInterval = probeInterval
+	config.Facade = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Facade not valid")
+}
+
+func (s *WorkerSuite) TestNoConnections(c *gc.C) {
+	s.startWorker(c, "0s")
+	s.runProbe(c)
+	s.facade.CheckCallNames(c, "WatchForModelConfigChanges", "ModelConfig", "OfferConnections")
+}
+
+func (s *WorkerSuite) TestReportsAccessErrors(c *gc.C) {
+	s.startWorker(c, "0s")
+	s.runProbe(c,
+		params.OfferConnectionProbe{RelationTag: "relation-db2.db#django.db"},
+		params.OfferConnectionProbe{
+			RelationTag: "relation-db2.db#wordpress.db",
+			Error:       &params.Error{Message: "offer has been removed"},
+		},
+	)
+	s.facade.CheckCallNames(c,
+		"WatchForModelConfigChanges", "ModelConfig",
+		"OfferConnections", "SetOfferConnectionsHealth",
+	)
+	now := s.clock.Now()
+	s.facade.CheckCall(c, 3, "SetOfferConnectionsHealth", []params.OfferConnectionHealth{
+		{RelationTag: "relation-db2.db#django.db", Time: now},
+		{RelationTag: "relation-db2.db#wordpress.db", Message: "offer has been removed", Time: now},
+	})
+}
+
+func (s *WorkerSuite) TestReportsStaleContact(c *gc.C) {
+	s.startWorker(c, "1h")
+	start := s.clock.Now()
+	recent := start.Add(-time.Minute)
+	stale := start.Add(-2 * time.Hour)
+	s.runProbe(c,
+		params.OfferConnectionProbe{RelationTag: "relation-db2.db#django.db", LastContact: &recent},
+		params.OfferConnectionProbe{RelationTag: "relation-db2.db#wordpress.db", LastContact: &stale},
+	)
+	now := s.clock.Now()
+	s.facade.CheckCall(c, 3, "SetOfferConnectionsHealth", []params.OfferConnectionHealth{
+		{RelationTag: "relation-db2.db#django.db", Time: now},
+		{
+			RelationTag: "relation-db2.db#wordpress.db",
+			Message:     "no contact from consuming model for over 1h0m0s",
+			Time:        now,
+		},
+	})
+}
+
+func (s *WorkerSuite) TestUncontactedConnectionGivenTimeout(c *gc.C) {
+	s.startWorker(c, "2m")
+	conn := params.OfferConnectionProbe{RelationTag: "relation-db2.db#django.db"}
+
+	// The connection has never been contacted, so it is given the
+	// timeout from when it was first seen.
+	s.runProbe(c, conn)
+	s.runProbe(c, conn)
+	s.facade.ResetCalls()
+	s.runProbe(c, conn)
+	s.facade.CheckCall(c, 1, "SetOfferConnectionsHealth", []params.OfferConnectionHealth{{
+		RelationTag: "relation-db2.db#django.db",
+		Message:     "no contact from consuming model for over 2m0s",
+		Time:        s.clock.Now(),
+	}})
+}
+
+func (s *WorkerSuite) TestZeroTimeoutDisablesContactCheck(c *gc.C) {
+	s.startWorker(c, "0s")
+	stale := s.clock.Now().Add(-24 * time.Hour)
+	s.runProbe(c, params.OfferConnectionProbe{RelationTag: "relation-db2.db#django.db", LastContact: &stale})
+	s.facade.CheckCall(c, 3, "SetOfferConnectionsHealth", []params.OfferConnectionHealth{{
+		RelationTag: "relation-db2.db#django.db",
+		Time:        s.clock.Now(),
+	}})
+}
+
+type fakeFacade struct {
+	testing.Stub
+	changes     chan struct{}
+	modelConfig *config.Config
+
+	mu    sync.Mutex
+	conns []params.OfferConnectionProbe
+}
+
+func (f *fakeFacade) setConnections(conns []params.OfferConnectionProbe) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.conns = conns
+}
+
+func (f *fakeFacade) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	f.MethodCall(f, "WatchForModelConfigChanges")
+	return watchertest.NewMockNotifyWatcher(f.changes), f.NextErr()
+}
+
+func (f *fakeFacade) ModelConfig() (*config.Config, error) {
+	f.MethodCall(f, "ModelConfig")
+	return f.modelConfig, f.NextErr()
+}
+
+func (f *fakeFacade) OfferConnections() ([]params.OfferConnectionProbe, error) {
+	f.MethodCall(f, "OfferConnections")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.conns, f.NextErr()
+}
+
+func (f *fakeFacade) SetOfferConnectionsHealth(health []params.OfferConnectionHealth) error {
+	f.MethodCall(f, "SetOfferConnectionsHealth", health)
+	return f.NextErr()
+}
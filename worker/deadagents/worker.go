@@ -0,0 +1,209 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package deadagents provides a worker which marks the units of a
+// model whose agents have been lost for longer than the model's
+// dead-agent-threshold with the "gone" agent status, and restores the
+// status of units whose gone agents return.
+package deadagents
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs/config"
+)
+
+// logger is here to stop the desire of creating a package level logger.
+// Don't do this, instead pass one through as config to the worker.
+var logger interface{}
+
+// Facade represents the API used by the dead agent worker.
+type Facade interface {
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+	ModelConfig() (*config.Config, error)
+	MissingUnitAgents() ([]params.MissingUnitAgent, error)
+	MarkUnitAgentsGone([]names.UnitTag) error
+	ClearUnitAgentsGone([]names.UnitTag) error
+}
+
+// Logger defines the methods used by the dead agent worker for logging.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+}
+
+// Config holds all necessary attributes to start a dead agent worker.
+type Config struct {
+	Facade        Facade
+	CheckInterval time.Duration
+	Clock         clock.Clock
+	Logger        Logger
+}
+
+// Validate will err unless basic requirements for a valid
+// config are met.
+func (c Config) Validate() error {
+	if c.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if c.CheckInterval <= 0 {
+		return errors.NotValidf("non-positive CheckInterval")
+	}
+	if c.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if c.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// Worker periodically checks for units whose agents are missing.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// lostSince records when each unit was first seen with a lost
+	// agent. Units are only marked gone once they have been seen
+	// lost for the whole threshold, so the first check after the
+	// worker starts never marks any.
+	lostSince map[names.UnitTag]time.Time
+}
+
+// New returns a worker which marks units with long-lost agents as gone.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config:    config,
+		lostSince: make(map[names.UnitTag]time.Time),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is defined on worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is defined on worker.Worker.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	modelConfigWatcher, err := w.config.Facade.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(modelConfigWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var (
+		threshold time.Duration
+		timer     clock.Timer
+		timerCh   <-chan time.Time
+	)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+
+		case _, ok := <-modelConfigWatcher.Changes():
+			if !ok {
+				return errors.New("model configuration watcher closed")
+			}
+			modelConfig, err := w.config.Facade.ModelConfig()
+			if err != nil {
+				return errors.Annotate(err, "cannot load model configuration")
+			}
+			if newThreshold := modelConfig.DeadAgentThreshold(); newThreshold != threshold {
+				w.config.Logger.Infof("dead agent threshold: %v for %s (%s)",
+					newThreshold, modelConfig.Name(), modelConfig.UUID())
+				threshold = newThreshold
+			}
+			if timer == nil {
+				timer = w.config.Clock.NewTimer(w.config.CheckInterval)
+				timerCh = timer.Chan()
+			}
+
+		case <-timerCh:
+			if err := w.check(threshold); err != nil {
+				return errors.Trace(err)
+			}
+			timer.Reset(w.config.CheckInterval)
+		}
+	}
+}
+
+// check marks the units whose agents have been lost for at least the
+// threshold as gone, and clears the gone status of units whose agents
+// have returned. A zero threshold disables marking units as gone.
+func (w *Worker) check(threshold time.Duration) error {
+	agents, err := w.config.Facade.MissingUnitAgents()
+	if err != nil {
+		return errors.Annotate(err, "cannot get missing unit agents")
+	}
+	now := w.config.Clock.Now()
+	lost := make(map[names.UnitTag]bool)
+	var gone, returned []names.UnitTag
+	for _, agent := range agents {
+		tag, err := names.ParseUnitTag(agent.UnitTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if agent.Gone {
+			if agent.Alive {
+				returned = append(returned, tag)
+			}
+			continue
+		}
+		lost[tag] = true
+		since, ok := w.lostSince[tag]
+		if !ok {
+			w.lostSince[tag] = now
+			continue
+		}
+		if threshold > 0 && now.Sub(since) >= threshold {
+			gone = append(gone, tag)
+		}
+	}
+	for tag := range w.lostSince {
+		if !lost[tag] {
+			delete(w.lostSince, tag)
+		}
+	}
+
+	if len(gone) > 0 {
+		w.config.Logger.Infof("marking agents of %v as gone", gone)
+		if err := w.config.Facade.MarkUnitAgentsGone(gone); err != nil {
+			return errors.Annotate(err, "cannot mark unit agents gone")
+		}
+		for _, tag := range gone {
+			delete(w.lostSince, tag)
+		}
+	}
+	if len(returned) > 0 {
+		w.config.Logger.Infof("agents of %v have returned", returned)
+		if err := w.config.Facade.ClearUnitAgentsGone(returned); err != nil {
+			return errors.Annotate(err, "cannot clear gone unit agents")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package deadagents_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/deadagents"
+)
+
+const checkInterval = time.Minute
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	facade *fakeFacade
+	clock  *testclock.Clock
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.facade = &fakeFacade{
+		changes: make(chan struct{}, 1),
+	}
+	s.clock = testclock.NewClock(time.Time{})
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C, threshold string) {
+	attrs := coretesting.FakeConfig()
+	attrs["dead-agent-threshold"] = threshold
+	cfg, err := config.New(config.UseDefaults, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade.modelConfig = cfg
+
+	w, err := deadagents.New(deadagents.Config{
+		Facade:        s.facade,
+		CheckInterval: checkInterval,
+		Clock:         s.clock,
+		Logger:        loggo.GetLogger("test"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	s.facade.changes <- struct{}{}
+}
+
+// runCheck fires the check timer, and waits for the check to finish
+// and the timer to be reset.
+func (s *WorkerSuite) runCheck(c *gc.C, agents ...params.MissingUnitAgent) {
+	s.facade.setMissing(agents)
+	c.Assert(s.clock.WaitAdvance(checkInterval, coretesting.LongWait, 1), jc.ErrorIsNil)
+	c.Assert(s.clock.WaitAdvance(0, coretesting.LongWait, 1), jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := deadagents.Config{
+		Facade:        s.facade,
+		CheckInterval: checkInterval,
+		Clock:         s.clock,
+		Logger:        loggo.GetLogger("test"),
+	}
+	c.Check(config.Validate(), jc.ErrorIsNil)
+	config.CheckInterval = 0
+	c.Check(config.Validate(), gc.ErrorMatches, "non-positive CheckInterval not valid")
+	config.CheckInterval = checkInterval
+	config.Facade = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Facade not valid")
+}
+
+func (s *WorkerSuite) TestMarksLostAgentGoneAfterThreshold(c *gc.C) {
+	s.startWorker(c, "2m")
+	lost := params.MissingUnitAgent{UnitTag: "unit-mysql-0"}
+
+	s.runCheck(c, lost)
+	s.runCheck(c, lost)
+	s.facade.CheckCallNames(c,
+		"WatchForModelConfigChanges", "ModelConfig",
+		"MissingUnitAgents", "MissingUnitAgents",
+	)
+
+	s.facade.ResetCalls()
+	s.runCheck(c, lost)
+	s.facade.CheckCallNames(c, "MissingUnitAgents", "MarkUnitAgentsGone")
+	s.facade.CheckCall(c, 1, "MarkUnitAgentsGone", []names.UnitTag{names.NewUnitTag("mysql/0")})
+}
+
+func (s *WorkerSuite) TestReturnedAgentResetsThreshold(c *gc.C) {
+	s.startWorker(c, "2m")
+	lost := params.MissingUnitAgent{UnitTag: "unit-mysql-0"}
+
+	s.runCheck(c, lost)
+	s.runCheck(c)
+	s.runCheck(c, lost)
+	s.runCheck(c, lost)
+	s.facade.CheckCallNames(c,
+		"WatchForModelConfigChanges", "ModelConfig",
+		"MissingUnitAgents", "MissingUnitAgents",
+		"MissingUnitAgents", "MissingUnitAgents",
+	)
+}
+
+func (s *WorkerSuite) TestZeroThresholdDisablesMarking(c *gc.C) {
+	s.startWorker(c, "0s")
+	lost := params.MissingUnitAgent{UnitTag: "unit-mysql-0"}
+
+	s.runCheck(c, lost)
+	s.runCheck(c, lost)
+	s.runCheck(c, lost)
+	s.facade.CheckCallNames(c,
+		"WatchForModelConfigChanges", "ModelConfig",
+		"MissingUnitAgents", "MissingUnitAgents", "MissingUnitAgents",
+	)
+}
+
+func (s *WorkerSuite) TestClearsReturnedGoneAgents(c *gc.C) {
+	s.startWorker(c, "2m")
+
+	s.runCheck(c,
+		params.MissingUnitAgent{UnitTag: "unit-mysql-0", Gone: true},
+		params.MissingUnitAgent{UnitTag: "unit-mysql-1", Gone: true, Alive: true},
+	)
+	s.facade.CheckCallNames(c,
+		"WatchForModelConfigChanges", "ModelConfig",
+		"MissingUnitAgents", "ClearUnitAgentsGone",
+	)
+	s.facade.CheckCall(c, 3, "ClearUnitAgentsGone", []names.UnitTag{names.NewUnitTag("mysql/1")})
+}
+
+type fakeFacade struct {
+	testing.Stub
+	changes     chan struct{}
+	modelConfig *config.Config
+
+	mu      sync.Mutex
+	missing []params.MissingUnitAgent
+}
+
+func (f *fakeFacade) setMissing(agents []params.MissingUnitAgent) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.missing = agents
+}
+
+func (f *fakeFacade) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	f.MethodCall(f, "WatchForModelConfigChanges")
+	return watchertest.NewMockNotifyWatcher(f.changes), f.NextErr()
+}
+
+func (f *fakeFacade) ModelConfig() (*config.Config, error) {
+	f.MethodCall(f, "ModelConfig")
+	return f.modelConfig, f.NextErr()
+}
+
+func (f *fakeFacade) MissingUnitAgents() ([]params.MissingUnitAgent, error) {
+	f.MethodCall(f, "MissingUnitAgents")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.missing, f.NextErr()
+}
+
+func (f *fakeFacade) MarkUnitAgentsGone(units []names.UnitTag) error {
+	f.MethodCall(f, "MarkUnitAgentsGone", units)
+	return f.NextErr()
+}
+
+func (f *fakeFacade) ClearUnitAgentsGone(units []names.UnitTag) error {
+	f.MethodCall(f, "ClearUnitAgentsGone", units)
+	return f.NextErr()
+}
@@ -8,6 +8,7 @@ import (
 	"sort"
 	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/loggo"
 	"gopkg.in/juju/worker.v1"
 
@@ -43,12 +44,12 @@ var DefaultListBlockDevices ListBlockDevicesFunc
 
 // NewWorker returns a worker that lists block devices
 // attached to the machine, and records them in state.
-var NewWorker = func(l ListBlockDevicesFunc, b BlockDeviceSetter) worker.Worker {
+var NewWorker = func(l ListBlockDevicesFunc, b BlockDeviceSetter, clock clock.Clock) worker.Worker {
 	var old []storage.BlockDevice
 	f := func(stop <-chan struct{}) error {
 		return doWork(l, b, &old)
 	}
-	return jworker.NewPeriodicWorker(f, listBlockDevicesPeriod, jworker.NewTimer)
+	return jworker.NewPeriodicWorker(f, listBlockDevicesPeriod, jworker.NewClockTimerFunc(clock))
 }
 
 func doWork(listf ListBlockDevicesFunc, b BlockDeviceSetter, old *[]storage.BlockDevice) error {
@@ -38,9 +38,11 @@ func (s *DiskManagerWorkerSuite) TestWorker(c *gc.C) {
 		return []storage.BlockDevice{{DeviceName: "whatever"}}, nil
 	}
 
-	w := diskmanager.NewWorker(listDevices, setDevices)
+	clock := coretesting.NewSimulatedClock(time.Time{})
+	w := diskmanager.NewWorker(listDevices, setDevices, clock)
 	defer w.Wait()
 	defer w.Kill()
+	clock.Step(c, 0, 1)
 
 	select {
 	case <-done:
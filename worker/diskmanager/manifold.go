@@ -4,6 +4,7 @@
 package diskmanager
 
 import (
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v3"
 	"gopkg.in/juju/worker.v1"
@@ -15,18 +16,29 @@ import (
 	"github.com/juju/juju/cmd/jujud/agent/engine"
 )
 
-// ManifoldConfig defines the names of the manifolds on which a Manifold will depend.
-type ManifoldConfig engine.AgentAPIManifoldConfig
+// ManifoldConfig defines the names of the manifolds on which a Manifold will depend,
+// and the clock used to time the block device listings.
+type ManifoldConfig struct {
+	AgentName     string
+	APICallerName string
+	Clock         clock.Clock
+}
 
 // Manifold returns a dependency manifold that runs a diskmanager worker,
 // using the resource names defined in the supplied config.
 func Manifold(config ManifoldConfig) dependency.Manifold {
-	typedConfig := engine.AgentAPIManifoldConfig(config)
-	return engine.AgentAPIManifold(typedConfig, newWorker)
+	typedConfig := engine.AgentAPIManifoldConfig{
+		AgentName:     config.AgentName,
+		APICallerName: config.APICallerName,
+	}
+	return engine.AgentAPIManifold(typedConfig, config.newWorker)
 }
 
 // newWorker trivially wraps NewWorker for use in a engine.AgentAPIManifold.
-func newWorker(a agent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
+func (config ManifoldConfig) newWorker(a agent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
+	if config.Clock == nil {
+		return nil, errors.NotValidf("nil Clock")
+	}
 	t := a.CurrentConfig().Tag()
 	tag, ok := t.(names.MachineTag)
 	if !ok {
@@ -35,5 +47,5 @@ func newWorker(a agent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
 
 	api := apidiskmanager.NewState(apiCaller, tag)
 
-	return NewWorker(DefaultListBlockDevices, api), nil
+	return NewWorker(DefaultListBlockDevices, api, config.Clock), nil
 }
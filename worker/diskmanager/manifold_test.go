@@ -4,6 +4,7 @@
 package diskmanager_test
 
 import (
+	"github.com/juju/clock"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v3"
@@ -39,8 +40,9 @@ func (s *manifoldSuite) TestMachineDiskmanager(c *gc.C) {
 			return nil
 		})
 
-	s.PatchValue(&diskmanager.NewWorker, func(l diskmanager.ListBlockDevicesFunc, b diskmanager.BlockDeviceSetter) worker.Worker {
+	s.PatchValue(&diskmanager.NewWorker, func(l diskmanager.ListBlockDevicesFunc, b diskmanager.BlockDeviceSetter, clk clock.Clock) worker.Worker {
 		called = true
+		c.Assert(clk, gc.Equals, clock.WallClock)
 
 		c.Assert(l, gc.FitsTypeOf, diskmanager.DefaultListBlockDevices)
 		c.Assert(b, gc.NotNil)
@@ -59,11 +61,18 @@ func (s *manifoldSuite) TestMachineDiskmanager(c *gc.C) {
 		},
 	}
 
-	_, err := diskmanager.NewWorkerFunc(a, apiCaller)
+	config := diskmanager.ManifoldConfig{Clock: clock.WallClock}
+	_, err := diskmanager.NewWorkerFunc(config, a, apiCaller)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *manifoldSuite) TestMissingClock(c *gc.C) {
+	a := &dummyAgent{tag: names.NewMachineTag("1")}
+	_, err := diskmanager.NewWorkerFunc(diskmanager.ManifoldConfig{}, a, nil)
+	c.Assert(err, gc.ErrorMatches, "nil Clock not valid")
+}
+
 type dummyAgent struct {
 	agent.Agent
 	tag  names.Tag
@@ -0,0 +1,163 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package featureflag
+
+import (
+	"os"
+	"strings"
+
+	"github.com/juju/collections/set"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/featureflag"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/juju/osenv"
+)
+
+// The applier worker keeps the feature flags reported by the agent's
+// featureflag package in step with the features controller config
+// setting, so that experimental subsystems can be switched on and off
+// without restarting the agent or editing its environment.
+
+// environFlags holds the feature flags set in the agent's environment
+// when it started. They remain enabled whatever the controller config
+// says, and must be captured before the applier changes the
+// environment.
+var environFlags = os.Getenv(osenv.JujuFeatureFlagEnvKey)
+
+// EnvironmentFlags returns the feature flags set in the agent's
+// environment when it started.
+func EnvironmentFlags() []string {
+	var flags []string
+	for _, flag := range strings.Split(environFlags, ",") {
+		if flag = strings.TrimSpace(flag); flag != "" {
+			flags = append(flags, flag)
+		}
+	}
+	return flags
+}
+
+// ApplyToEnvironment makes the given feature flags, and only those,
+// enabled in the agent process and in the environment passed to the
+// processes it starts.
+func ApplyToEnvironment(flags []string) error {
+	if err := os.Setenv(osenv.JujuFeatureFlagEnvKey, strings.Join(flags, ",")); err != nil {
+		return errors.Trace(err)
+	}
+	featureflag.SetFlagsFromEnvironment(osenv.JujuFeatureFlagEnvKey)
+	return nil
+}
+
+// ApplierSource lets the applier worker get notifications of changes
+// to controller configuration, and then get the changed config.
+// (Primary implementation is the Agent facade client.)
+type ApplierSource interface {
+	WatchControllerConfig() (watcher.NotifyWatcher, error)
+	ControllerConfig() (controller.Config, error)
+}
+
+// ApplierConfig holds the information needed by the applier worker.
+type ApplierConfig struct {
+	Source ApplierSource
+	Logger loggo.Logger
+
+	// BaseFlags are enabled in addition to those set in the
+	// controller config.
+	BaseFlags []string
+
+	// Apply is called with the full, sorted, set of enabled feature
+	// flags when the worker starts and whenever the set changes.
+	Apply func([]string) error
+}
+
+// Validate returns an error if the config cannot be used to start an
+// applier worker.
+func (config ApplierConfig) Validate() error {
+	if config.Source == nil {
+		return errors.NotValidf("nil Source")
+	}
+	if config.Apply == nil {
+		return errors.NotValidf("nil Apply")
+	}
+	return nil
+}
+
+// Applier is a worker which applies the controller's feature flags to
+// the agent.
+type Applier struct {
+	catacomb catacomb.Catacomb
+	config   ApplierConfig
+	current  set.Strings
+}
+
+// NewApplier returns a worker which applies the controller's feature
+// flags to the agent.
+func NewApplier(config ApplierConfig) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	a := &Applier{config: config}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &a.catacomb,
+		Work: a.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return a, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (a *Applier) Kill() {
+	a.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (a *Applier) Wait() error {
+	return a.catacomb.Wait()
+}
+
+func (a *Applier) loop() error {
+	watcher, err := a.config.Source.WatchControllerConfig()
+	if err != nil {
+		return errors.Annotate(err, "watching controller config")
+	}
+	if err := a.catacomb.Add(watcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	for {
+		select {
+		case <-a.catacomb.Dying():
+			return a.catacomb.ErrDying()
+		case _, ok := <-watcher.Changes():
+			if !ok {
+				return errors.Errorf("watcher channel closed")
+			}
+			if err := a.update(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+func (a *Applier) update() error {
+	controllerConfig, err := a.config.Source.ControllerConfig()
+	if err != nil {
+		return errors.Annotate(err, "getting controller config")
+	}
+	flags := controllerConfig.Features().Union(set.NewStrings(a.config.BaseFlags...))
+	if a.current != nil && flags.Difference(a.current).IsEmpty() && a.current.Difference(flags).IsEmpty() {
+		return nil
+	}
+	if err := a.config.Apply(flags.SortedValues()); err != nil {
+		return errors.Annotate(err, "applying feature flags")
+	}
+	a.config.Logger.Infof("feature flags enabled: %v", flags.SortedValues())
+	a.current = flags
+	return nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package featureflag_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/featureflag"
+)
+
+type ApplierSuite struct {
+	testing.IsolationSuite
+	config  featureflag.ApplierConfig
+	source  *applierSource
+	changes chan struct{}
+	applied chan []string
+}
+
+var _ = gc.Suite(&ApplierSuite{})
+
+func (s *ApplierSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.changes = make(chan struct{})
+	s.applied = make(chan []string, 10)
+	s.source = &applierSource{
+		configSource: &configSource{
+			cfg: controller.Config{
+				"features": []interface{}{"new-hotness"},
+			},
+		},
+		watcher: watchertest.NewMockNotifyWatcher(s.changes),
+	}
+	s.config = featureflag.ApplierConfig{
+		Source:    s.source,
+		BaseFlags: []string{"from-env"},
+		Apply: func(flags []string) error {
+			s.applied <- flags
+			return nil
+		},
+	}
+}
+
+func (s *ApplierSuite) sendChange(c *gc.C) {
+	select {
+	case s.changes <- struct{}{}:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out sending config change")
+	}
+}
+
+func (s *ApplierSuite) assertApplied(c *gc.C, expected ...string) {
+	select {
+	case flags := <-s.applied:
+		c.Assert(flags, jc.DeepEquals, expected)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for flags to be applied")
+	}
+}
+
+func (s *ApplierSuite) assertNotApplied(c *gc.C) {
+	select {
+	case flags := <-s.applied:
+		c.Fatalf("unexpected flags applied: %v", flags)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *ApplierSuite) TestValidate(c *gc.C) {
+	config := s.config
+	config.Source = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Source not valid")
+	config = s.config
+	config.Apply = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Apply not valid")
+}
+
+func (s *ApplierSuite) TestAppliesControllerAndBaseFlags(c *gc.C) {
+	w, err := featureflag.NewApplier(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	s.sendChange(c)
+	s.assertApplied(c, "from-env", "new-hotness")
+
+	s.sendChange(c)
+	s.assertNotApplied(c)
+
+	s.source.setConfig(controller.Config{
+		"features": []interface{}{"other-hotness"},
+	})
+	s.sendChange(c)
+	s.assertApplied(c, "from-env", "other-hotness")
+}
+
+func (s *ApplierSuite) TestApplyError(c *gc.C) {
+	s.config.Apply = func([]string) error {
+		return errors.New("boom")
+	}
+	w, err := featureflag.NewApplier(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	s.sendChange(c)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "applying feature flags: boom")
+}
+
+func (s *ApplierSuite) TestWatchError(c *gc.C) {
+	s.source.watchErr = errors.New("boom")
+	w, err := featureflag.NewApplier(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "watching controller config: boom")
+}
+
+type applierSource struct {
+	*configSource
+	watcher  watcher.NotifyWatcher
+	watchErr error
+}
+
+func (s *applierSource) WatchControllerConfig() (watcher.NotifyWatcher, error) {
+	if s.watchErr != nil {
+		return nil, s.watchErr
+	}
+	return s.watcher, nil
+}
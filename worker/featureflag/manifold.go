@@ -9,6 +9,8 @@ import (
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/dependency"
 
+	apiagent "github.com/juju/juju/api/agent"
+	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/cmd/jujud/agent/engine"
 	"github.com/juju/juju/worker/common"
 	"github.com/juju/juju/worker/state"
@@ -88,3 +90,76 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 		},
 	}
 }
+
+// ApplierManifoldConfig holds the information necessary to run an
+// Applier in a dependency.Engine.
+type ApplierManifoldConfig struct {
+	APICallerName string
+	BaseFlags     []string
+	Apply         func([]string) error
+	Logger        loggo.Logger
+	NewSource     func(base.APICaller) (ApplierSource, error)
+	NewWorker     func(ApplierConfig) (worker.Worker, error)
+}
+
+// Validate validates the manifold configuration.
+func (config ApplierManifoldConfig) Validate() error {
+	if config.APICallerName == "" {
+		return errors.NotValidf("empty APICallerName")
+	}
+	if config.Apply == nil {
+		return errors.NotValidf("nil Apply")
+	}
+	if config.NewSource == nil {
+		return errors.NotValidf("nil NewSource")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+func (config ApplierManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var apiCaller base.APICaller
+	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+		return nil, errors.Trace(err)
+	}
+	source, err := config.NewSource(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	w, err := config.NewWorker(ApplierConfig{
+		Source:    source,
+		Logger:    config.Logger,
+		BaseFlags: config.BaseFlags,
+		Apply:     config.Apply,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// ApplierManifold returns a dependency.Manifold that will run an
+// Applier, keeping the agent's feature flags in step with the
+// controller config.
+func ApplierManifold(config ApplierManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.APICallerName,
+		},
+		Start: config.start,
+	}
+}
+
+// NewAPISource returns an ApplierSource backed by the Agent facade.
+func NewAPISource(apiCaller base.APICaller) (ApplierSource, error) {
+	st, err := apiagent.NewState(apiCaller)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return st, nil
+}
@@ -194,8 +194,10 @@ func NewFirewaller(cfg Config) (worker.Worker, error) {
 			// For any failures, try again in 1 minute.
 			RestartDelay: time.Minute,
 		}),
-		cloudCallContext: common.NewCloudCallContext(cfg.CredentialAPI, nil),
 	}
+	// Calls to the provider, including any waiting for them to be
+	// allowed by a rate limiter, are abandoned when the worker dies.
+	fw.cloudCallContext = common.NewCloudCallContext(cfg.CredentialAPI, fw.catacomb.Dying)
 
 	switch cfg.Mode {
 	case config.FwInstance:
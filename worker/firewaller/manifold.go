@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/remoterelations"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/callaudit"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/common"
@@ -42,6 +43,15 @@ type ManifoldConfig struct {
 	NewFirewallerFacade          func(base.APICaller) (FirewallerAPI, error)
 	NewFirewallerWorker          func(Config) (worker.Worker, error)
 	NewCredentialValidatorFacade func(base.APICaller) (common.CredentialAPI, error)
+
+	// ProviderCallRecorder, if set, records the calls the worker
+	// makes to the provider.
+	ProviderCallRecorder *callaudit.Recorder
+
+	// CloudRateLimiter, if set, limits the rate of the worker's
+	// calls to the provider. It is shared with the model's other
+	// provider-polling workers.
+	CloudRateLimiter *common.CloudRateLimiter
 }
 
 // Manifold returns a Manifold that encapsulates the firewaller worker.
@@ -138,12 +148,29 @@ func (cfg ManifoldConfig) start(context dependency.Context) (worker.Worker, erro
 		return nil, errors.Trace(err)
 	}
 
+	// The firewaller check above must be made on the unwrapped environ,
+	// as the wrapper doesn't implement environs.Firewaller.
+	envInstances := environ
+	if cfg.ProviderCallRecorder != nil {
+		labels := callaudit.LabelsFor(environ, "firewaller")
+		envInstances = callaudit.WrapEnviron(environ, labels, cfg.ProviderCallRecorder)
+		if fwEnvOK {
+			fwEnv = callaudit.WrapFirewaller(fwEnv, labels, cfg.ProviderCallRecorder)
+		}
+	}
+	// Rate limit outside the audit wrappers, so that time spent waiting
+	// isn't recorded as call latency.
+	envInstances = common.RateLimitEnviron(envInstances, cfg.CloudRateLimiter)
+	if fwEnvOK {
+		fwEnv = common.RateLimitFirewaller(fwEnv, cfg.CloudRateLimiter)
+	}
+
 	w, err := cfg.NewFirewallerWorker(Config{
 		ModelUUID:               agent.CurrentConfig().Model().Id(),
 		RemoteRelationsApi:      remoteRelationsAPI,
 		FirewallerAPI:           firewallerAPI,
 		EnvironFirewaller:       fwEnv,
-		EnvironInstances:        environ,
+		EnvironInstances:        envInstances,
 		Mode:                    mode,
 		NewCrossModelFacadeFunc: crossmodelFirewallerFacadeFunc(cfg.NewControllerConnection),
 		CredentialAPI:           credentialAPI,
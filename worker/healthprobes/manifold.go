@@ -0,0 +1,116 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthprobes
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/agent"
+	"github.com/juju/juju/api/base"
+	apihealthprobes "github.com/juju/juju/api/healthprobes"
+)
+
+// refreshInterval is how often the worker fetches the probes to run
+// again, so that it picks up units being added to or removed from the
+// machine.
+const refreshInterval = time.Minute
+
+// ManifoldConfig describes the resources and configuration on which the
+// health probes worker depends.
+type ManifoldConfig struct {
+	AgentName     string
+	APICallerName string
+	Clock         clock.Clock
+	NewWorker     func(Config) (worker.Worker, error)
+	NewFacade     func(base.APICaller) Facade
+	Logger        Logger
+}
+
+// Manifold returns a Manifold that encapsulates the health probes
+// worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.AgentName,
+			config.APICallerName,
+		},
+		Start: config.start,
+	}
+}
+
+// start is a StartFunc for a Worker manifold.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var agent agent.Agent
+	if err := context.Get(config.AgentName, &agent); err != nil {
+		return nil, errors.Trace(err)
+	}
+	var apiCaller base.APICaller
+	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	tag, ok := agent.CurrentConfig().Tag().(names.MachineTag)
+	if !ok {
+		return nil, errors.New("health probes may only be run by a machine agent")
+	}
+
+	w, err := config.NewWorker(Config{
+		Facade:          config.NewFacade(apiCaller),
+		MachineTag:      tag,
+		RunProbe:        RunProbe,
+		RefreshInterval: refreshInterval,
+		Clock:           config.Clock,
+		Logger:          config.Logger,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Validate is called by start to check for bad configuration.
+func (config ManifoldConfig) Validate() error {
+	if config.AgentName == "" {
+		return errors.NotValidf("empty AgentName")
+	}
+	if config.APICallerName == "" {
+		return errors.NotValidf("empty APICallerName")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	if config.NewFacade == nil {
+		return errors.NotValidf("nil NewFacade")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// NewFacade returns a new health probes facade.
+func NewFacade(caller base.APICaller) Facade {
+	return apihealthprobes.NewClient(caller)
+}
+
+// NewWorker returns a new health probes worker.
+func NewWorker(config Config) (worker.Worker, error) {
+	w, err := New(config)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthprobes
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os/exec"
+	"strings"
+
+	"github.com/juju/errors"
+
+	apihealthprobes "github.com/juju/juju/api/healthprobes"
+)
+
+// RunProbe runs the given health probe, returning an error describing
+// why it failed if it did:
+//   - a tcp probe fails unless a connection can be made to its address;
+//   - an http probe fails unless a GET of its URL gets a 2xx or 3xx
+//     response;
+//   - a command probe fails unless its command, run with /bin/sh,
+//     exits with status 0.
+//
+// Each probe fails if it doesn't complete within its timeout.
+func RunProbe(probe apihealthprobes.Probe) error {
+	ctx, cancel := context.WithTimeout(context.Background(), probe.Timeout)
+	defer cancel()
+	switch {
+	case probe.TCP != "":
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", probe.TCP)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return conn.Close()
+	case probe.HTTP != "":
+		return errors.Trace(getURL(ctx, probe.HTTP))
+	case probe.Command != "":
+		out, err := exec.CommandContext(ctx, "/bin/sh", "-c", probe.Command).CombinedOutput()
+		if err != nil {
+			if out := strings.TrimSpace(string(out)); out != "" {
+				return errors.Annotate(err, out)
+			}
+			return errors.Trace(err)
+		}
+		return nil
+	}
+	return errors.NotValidf("probe %q with nothing to check", probe.Name)
+}
+
+func getURL(ctx context.Context, url string) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Redirects are treated as healthy responses in their own right.
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return errors.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return nil
+}
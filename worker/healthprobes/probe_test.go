@@ -0,0 +1,78 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthprobes_test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	apihealthprobes "github.com/juju/juju/api/healthprobes"
+	"github.com/juju/juju/worker/healthprobes"
+)
+
+type RunProbeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&RunProbeSuite{})
+
+func probe(p apihealthprobes.Probe) apihealthprobes.Probe {
+	p.Name = "test"
+	p.Interval = time.Minute
+	p.Timeout = 10 * time.Second
+	return p
+}
+
+func (s *RunProbeSuite) TestTCP(c *gc.C) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	c.Assert(err, jc.ErrorIsNil)
+	addr := l.Addr().String()
+
+	err = healthprobes.RunProbe(probe(apihealthprobes.Probe{TCP: addr}))
+	c.Check(err, jc.ErrorIsNil)
+
+	c.Assert(l.Close(), jc.ErrorIsNil)
+	err = healthprobes.RunProbe(probe(apihealthprobes.Probe{TCP: addr}))
+	c.Check(err, gc.ErrorMatches, ".*connection refused")
+}
+
+func (s *RunProbeSuite) TestHTTP(c *gc.C) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+		case "/moved":
+			http.Redirect(w, r, "/missing", http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	err := healthprobes.RunProbe(probe(apihealthprobes.Probe{HTTP: srv.URL + "/ok"}))
+	c.Check(err, jc.ErrorIsNil)
+	err = healthprobes.RunProbe(probe(apihealthprobes.Probe{HTTP: srv.URL + "/moved"}))
+	c.Check(err, jc.ErrorIsNil)
+	err = healthprobes.RunProbe(probe(apihealthprobes.Probe{HTTP: srv.URL + "/missing"}))
+	c.Check(err, gc.ErrorMatches, `GET .*/missing: 404 Not Found`)
+}
+
+func (s *RunProbeSuite) TestCommand(c *gc.C) {
+	err := healthprobes.RunProbe(probe(apihealthprobes.Probe{Command: "true"}))
+	c.Check(err, jc.ErrorIsNil)
+	err = healthprobes.RunProbe(probe(apihealthprobes.Probe{Command: "echo not ready; exit 3"}))
+	c.Check(err, gc.ErrorMatches, "not ready: exit status 3")
+}
+
+func (s *RunProbeSuite) TestTimeout(c *gc.C) {
+	p := probe(apihealthprobes.Probe{Command: "sleep 10"})
+	p.Timeout = 10 * time.Millisecond
+	err := healthprobes.RunProbe(p)
+	c.Check(err, gc.NotNil)
+}
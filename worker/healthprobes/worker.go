@@ -0,0 +1,324 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package healthprobes provides a worker which runs the health probes
+// configured in the model's health-probes for the units on a machine,
+// each at its own interval, and reports their results to the
+// controller whenever any of them change. The results are recorded in
+// the status data of the units and of the machine.
+package healthprobes
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	apihealthprobes "github.com/juju/juju/api/healthprobes"
+	"github.com/juju/juju/core/healthprobe"
+	"github.com/juju/juju/core/watcher"
+)
+
+// logger is here to stop the desire of creating a package level logger.
+// Don't do this, instead pass one through as config to the worker.
+var logger interface{}
+
+// Facade represents the API used by the health probes worker.
+type Facade interface {
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+	HealthProbes(names.MachineTag) (map[string][]apihealthprobes.Probe, error)
+	SetResults(names.MachineTag, map[string]map[string]healthprobe.Result) error
+}
+
+// Logger defines the methods used by the health probes worker for
+// logging.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+}
+
+// Config holds all necessary attributes to start a health probes
+// worker.
+type Config struct {
+	Facade     Facade
+	MachineTag names.MachineTag
+
+	// RunProbe runs a probe, returning why it failed if it did.
+	RunProbe func(apihealthprobes.Probe) error
+
+	// RefreshInterval is how often the probes are fetched again, so
+	// that units which come and go are picked up.
+	RefreshInterval time.Duration
+
+	Clock  clock.Clock
+	Logger Logger
+}
+
+// Validate will err unless basic requirements for a valid
+// config are met.
+func (c Config) Validate() error {
+	if c.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if c.MachineTag.Id() == "" {
+		return errors.NotValidf("empty MachineTag")
+	}
+	if c.RunProbe == nil {
+		return errors.NotValidf("nil RunProbe")
+	}
+	if c.RefreshInterval <= 0 {
+		return errors.NotValidf("non-positive RefreshInterval")
+	}
+	if c.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if c.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// probeState tracks a probe being run for a unit.
+type probeState struct {
+	unit    string
+	probe   apihealthprobes.Probe
+	next    time.Time
+	running bool
+	result  *healthprobe.Result
+}
+
+// probeOutcome is sent by a probe when it has been run.
+type probeOutcome struct {
+	key   string
+	probe apihealthprobes.Probe
+	err   error
+}
+
+// Worker runs the health probes for the units on a machine.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// probes holds the probes being run, keyed by unit and probe name.
+	probes   map[string]*probeState
+	outcomes chan probeOutcome
+
+	// reported holds the units whose results have been reported.
+	reported map[string]bool
+	dirty    bool
+}
+
+// New returns a worker which runs the health probes for the units on
+// the configured machine.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config:   config,
+		probes:   make(map[string]*probeState),
+		outcomes: make(chan probeOutcome),
+		reported: make(map[string]bool),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is defined on worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is defined on worker.Worker.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	modelConfigWatcher, err := w.config.Facade.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(modelConfigWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	refreshTimer := w.config.Clock.NewTimer(w.config.RefreshInterval)
+	defer refreshTimer.Stop()
+	var (
+		probeTimer clock.Timer
+		probeCh    <-chan time.Time
+	)
+	defer func() {
+		if probeTimer != nil {
+			probeTimer.Stop()
+		}
+	}()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+
+		case _, ok := <-modelConfigWatcher.Changes():
+			if !ok {
+				return errors.New("model configuration watcher closed")
+			}
+			if err := w.refresh(); err != nil {
+				return errors.Trace(err)
+			}
+
+		case <-refreshTimer.Chan():
+			if err := w.refresh(); err != nil {
+				return errors.Trace(err)
+			}
+			refreshTimer.Reset(w.config.RefreshInterval)
+
+		case <-probeCh:
+			probeCh = nil
+
+		case outcome := <-w.outcomes:
+			w.record(outcome)
+		}
+
+		if w.dirty {
+			if err := w.report(); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		next, ok := w.runDue()
+		if !ok {
+			probeCh = nil
+			continue
+		}
+		delay := next.Sub(w.config.Clock.Now())
+		if probeTimer == nil {
+			probeTimer = w.config.Clock.NewTimer(delay)
+		} else {
+			probeTimer.Reset(delay)
+		}
+		probeCh = probeTimer.Chan()
+	}
+}
+
+// refresh fetches the probes to run, keeping the schedule and results
+// of those which haven't changed.
+func (w *Worker) refresh() error {
+	unitProbes, err := w.config.Facade.HealthProbes(w.config.MachineTag)
+	if err != nil {
+		return errors.Annotate(err, "cannot get health probes")
+	}
+	now := w.config.Clock.Now()
+	probes := make(map[string]*probeState)
+	for unit, unitProbes := range unitProbes {
+		for _, probe := range unitProbes {
+			key := unit + ":" + probe.Name
+			if state, ok := w.probes[key]; ok && state.probe == probe {
+				probes[key] = state
+				continue
+			}
+			probes[key] = &probeState{unit: unit, probe: probe, next: now}
+		}
+	}
+	for key, state := range w.probes {
+		if _, ok := probes[key]; !ok && state.result != nil {
+			w.dirty = true
+		}
+	}
+	w.config.Logger.Debugf("%d health probes for %s", len(probes), w.config.MachineTag.Id())
+	w.probes = probes
+	return nil
+}
+
+// runDue starts the probes which are due to be run, and returns when
+// the next of the others is due, if any are.
+func (w *Worker) runDue() (time.Time, bool) {
+	now := w.config.Clock.Now()
+	var next time.Time
+	for key, state := range w.probes {
+		if state.running {
+			continue
+		}
+		if !state.next.After(now) {
+			state.running = true
+			go w.run(key, state.probe)
+			continue
+		}
+		if next.IsZero() || state.next.Before(next) {
+			next = state.next
+		}
+	}
+	return next, !next.IsZero()
+}
+
+func (w *Worker) run(key string, probe apihealthprobes.Probe) {
+	err := w.config.RunProbe(probe)
+	select {
+	case w.outcomes <- probeOutcome{key: key, probe: probe, err: err}:
+	case <-w.catacomb.Dying():
+	}
+}
+
+// record updates the result of a probe which has been run, unless the
+// probe has since been changed or removed.
+func (w *Worker) record(outcome probeOutcome) {
+	state, ok := w.probes[outcome.key]
+	if !ok || state.probe != outcome.probe {
+		return
+	}
+	now := w.config.Clock.Now()
+	state.running = false
+	state.next = now.Add(state.probe.Interval)
+
+	result := healthprobe.Result{Healthy: outcome.err == nil, Since: now}
+	if outcome.err != nil {
+		result.Message = outcome.err.Error()
+	}
+	if state.result != nil && state.result.Healthy == result.Healthy {
+		result.Since = state.result.Since
+	}
+	if state.result != nil && *state.result == result {
+		return
+	}
+	if !result.Healthy {
+		w.config.Logger.Infof("health probe %q for %s failing: %s", state.probe.Name, state.unit, result.Message)
+	} else if state.result != nil {
+		w.config.Logger.Infof("health probe %q for %s passing", state.probe.Name, state.unit)
+	}
+	state.result = &result
+	w.dirty = true
+}
+
+// report sends the results of all the probes which have been run,
+// along with empty results for the units whose probes have all been
+// removed since they were last reported.
+func (w *Worker) report() error {
+	results := make(map[string]map[string]healthprobe.Result)
+	for unit := range w.reported {
+		results[unit] = make(map[string]healthprobe.Result)
+	}
+	reported := make(map[string]bool)
+	for _, state := range w.probes {
+		if state.result == nil {
+			continue
+		}
+		if results[state.unit] == nil {
+			results[state.unit] = make(map[string]healthprobe.Result)
+		}
+		results[state.unit][state.probe.Name] = *state.result
+		reported[state.unit] = true
+	}
+	if err := w.config.Facade.SetResults(w.config.MachineTag, results); err != nil {
+		return errors.Annotate(err, "cannot set health probe results")
+	}
+	w.reported = reported
+	w.dirty = false
+	return nil
+}
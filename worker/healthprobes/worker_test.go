@@ -0,0 +1,217 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package healthprobes_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	apihealthprobes "github.com/juju/juju/api/healthprobes"
+	"github.com/juju/juju/core/healthprobe"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/healthprobes"
+)
+
+const refreshInterval = 5 * time.Minute
+
+var portProbe = apihealthprobes.Probe{
+	Name:     "port",
+	TCP:      "localhost:3306",
+	Interval: time.Minute,
+	Timeout:  time.Second,
+}
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	facade *fakeFacade
+	clock  *testclock.Clock
+
+	mu       sync.Mutex
+	probeErr error
+	probed   chan apihealthprobes.Probe
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.facade = &fakeFacade{
+		changes: make(chan struct{}, 1),
+		set:     make(chan map[string]map[string]healthprobe.Result, 10),
+		probes:  map[string][]apihealthprobes.Probe{"mysql/0": {portProbe}},
+	}
+	s.clock = testclock.NewClock(time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC))
+	s.probeErr = nil
+	s.probed = make(chan apihealthprobes.Probe, 10)
+}
+
+func (s *WorkerSuite) config() healthprobes.Config {
+	return healthprobes.Config{
+		Facade:          s.facade,
+		MachineTag:      names.NewMachineTag("0"),
+		RunProbe:        s.runProbe,
+		RefreshInterval: refreshInterval,
+		Clock:           s.clock,
+		Logger:          loggo.GetLogger("test"),
+	}
+}
+
+func (s *WorkerSuite) runProbe(probe apihealthprobes.Probe) error {
+	s.mu.Lock()
+	err := s.probeErr
+	s.mu.Unlock()
+	s.probed <- probe
+	return err
+}
+
+func (s *WorkerSuite) setProbeErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.probeErr = err
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) {
+	w, err := healthprobes.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	s.facade.changes <- struct{}{}
+}
+
+func (s *WorkerSuite) waitProbed(c *gc.C) {
+	select {
+	case probe := <-s.probed:
+		c.Assert(probe, gc.Equals, portProbe)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for probe to run")
+	}
+}
+
+func (s *WorkerSuite) waitSet(c *gc.C) map[string]map[string]healthprobe.Result {
+	select {
+	case results := <-s.facade.set:
+		return results
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for results")
+	}
+	panic("unreachable")
+}
+
+// runProbes fires the probe timer once the probe is waiting on it
+// along with the refresh timer.
+func (s *WorkerSuite) runProbes(c *gc.C) {
+	c.Assert(s.clock.WaitAdvance(portProbe.Interval, coretesting.LongWait, 2), jc.ErrorIsNil)
+	s.waitProbed(c)
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	c.Check(config.Validate(), jc.ErrorIsNil)
+	config.RefreshInterval = 0
+	c.Check(config.Validate(), gc.ErrorMatches, "non-positive RefreshInterval not valid")
+	config.RunProbe = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil RunProbe not valid")
+	config.MachineTag = names.MachineTag{}
+	c.Check(config.Validate(), gc.ErrorMatches, "empty MachineTag not valid")
+	config.Facade = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Facade not valid")
+}
+
+func (s *WorkerSuite) TestReportsChangedResults(c *gc.C) {
+	start := s.clock.Now()
+	s.startWorker(c)
+	s.waitProbed(c)
+	c.Assert(s.waitSet(c), jc.DeepEquals, map[string]map[string]healthprobe.Result{
+		"mysql/0": {"port": {Healthy: true, Since: start}},
+	})
+
+	// Results which haven't changed aren't reported again.
+	s.runProbes(c)
+
+	s.setProbeErr(errors.New("connection refused"))
+	s.runProbes(c)
+	c.Assert(s.waitSet(c), jc.DeepEquals, map[string]map[string]healthprobe.Result{
+		"mysql/0": {"port": {Message: "connection refused", Since: start.Add(2 * time.Minute)}},
+	})
+	s.facade.CheckCallNames(c, "WatchForModelConfigChanges", "HealthProbes", "SetResults", "SetResults")
+}
+
+func (s *WorkerSuite) TestReportsRemovedProbes(c *gc.C) {
+	s.startWorker(c)
+	s.waitProbed(c)
+	s.waitSet(c)
+
+	s.facade.setProbes(nil)
+	s.facade.changes <- struct{}{}
+	c.Assert(s.waitSet(c), jc.DeepEquals, map[string]map[string]healthprobe.Result{
+		"mysql/0": {},
+	})
+}
+
+func (s *WorkerSuite) TestRefreshesProbes(c *gc.C) {
+	s.startWorker(c)
+	s.waitProbed(c)
+	s.waitSet(c)
+
+	s.facade.setProbes(nil)
+	c.Assert(s.clock.WaitAdvance(refreshInterval, coretesting.LongWait, 2), jc.ErrorIsNil)
+	c.Assert(s.waitSet(c), jc.DeepEquals, map[string]map[string]healthprobe.Result{
+		"mysql/0": {},
+	})
+}
+
+func (s *WorkerSuite) TestHealthProbesError(c *gc.C) {
+	s.facade.SetErrors(nil, errors.New("boom"))
+	w, err := healthprobes.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade.changes <- struct{}{}
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "cannot get health probes: boom")
+}
+
+type fakeFacade struct {
+	testing.Stub
+	changes chan struct{}
+	set     chan map[string]map[string]healthprobe.Result
+
+	mu     sync.Mutex
+	probes map[string][]apihealthprobes.Probe
+}
+
+func (f *fakeFacade) setProbes(probes map[string][]apihealthprobes.Probe) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.probes = probes
+}
+
+func (f *fakeFacade) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	f.MethodCall(f, "WatchForModelConfigChanges")
+	return watchertest.NewMockNotifyWatcher(f.changes), f.NextErr()
+}
+
+func (f *fakeFacade) HealthProbes(machine names.MachineTag) (map[string][]apihealthprobes.Probe, error) {
+	f.MethodCall(f, "HealthProbes", machine)
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.probes, f.NextErr()
+}
+
+func (f *fakeFacade) SetResults(machine names.MachineTag, results map[string]map[string]healthprobe.Result) error {
+	f.MethodCall(f, "SetResults", machine, results)
+	if err := f.NextErr(); err != nil {
+		return err
+	}
+	f.set <- results
+	return nil
+}
@@ -12,8 +12,10 @@ import (
 
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/instancepoller"
+	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/callaudit"
 	"github.com/juju/juju/worker/common"
 )
 
@@ -36,6 +38,12 @@ func (s facadeShim) Machine(tag names.MachineTag) (Machine, error) { return s.ap
 func (s facadeShim) WatchModelMachines() (watcher.StringsWatcher, error) {
 	return s.api.WatchModelMachines()
 }
+func (s facadeShim) WatchInstancePollRequests() (watcher.StringsWatcher, error) {
+	return s.api.WatchInstancePollRequests()
+}
+func (s facadeShim) ClearInstancePollRequests(tags []names.MachineTag) error {
+	return s.api.ClearInstancePollRequests(tags)
+}
 
 // ManifoldConfig describes the resources used by the instancepoller worker.
 type ManifoldConfig struct {
@@ -45,6 +53,19 @@ type ManifoldConfig struct {
 	Logger        Logger
 
 	NewCredentialValidatorFacade func(base.APICaller) (common.CredentialAPI, error)
+
+	// ProviderCallRecorder, if set, records the calls the worker
+	// makes to the provider.
+	ProviderCallRecorder *callaudit.Recorder
+
+	// CloudRateLimiter, if set, limits the rate of the worker's
+	// calls to the provider. It is shared with the model's other
+	// provider-polling workers.
+	CloudRateLimiter *common.CloudRateLimiter
+
+	// Registry, if set, collects the worker's report for the agent's
+	// introspection endpoint.
+	Registry *Registry
 }
 
 func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
@@ -56,6 +77,18 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 	if err := context.Get(config.EnvironName, &environ); err != nil {
 		return nil, errors.Trace(err)
 	}
+	// The audit wrapper hides optional interfaces, so check for bulk
+	// instance queries and instance notifications before wrapping.
+	bulkInstanceQueries := environs.SupportsBulkInstanceQueries(environ)
+	instanceNotifier, _ := environ.(environs.InstanceNotifier)
+	modelUUID := environ.Config().UUID()
+	if config.ProviderCallRecorder != nil {
+		labels := callaudit.LabelsFor(environ, "instance-poller")
+		environ = callaudit.WrapEnviron(environ, labels, config.ProviderCallRecorder)
+	}
+	// Rate limit outside the audit wrapper, so that time spent waiting
+	// isn't recorded as call latency.
+	environ = common.RateLimitEnviron(environ, config.CloudRateLimiter)
 
 	var apiCaller base.APICaller
 	if err := context.Get(config.APICallerName, &apiCaller); err != nil {
@@ -72,9 +105,18 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		Facade: facadeShim{
 			api: instancepoller.NewAPI(apiCaller),
 		},
-		Environ:       environ,
-		Logger:        config.Logger,
-		CredentialAPI: credentialAPI,
+		Environ:             environ,
+		Logger:              config.Logger,
+		CredentialAPI:       credentialAPI,
+		BulkInstanceQueries: bulkInstanceQueries,
+		InstanceNotifier:    instanceNotifier,
+		// The environ tracker keeps the environ's config up to date,
+		// so changes to the policy apply without a restart.
+		AddressConflictPolicy: func() network.AddressConflictPolicy {
+			return environ.Config().AddressConflictPolicy()
+		},
+		Registry:  config.Registry,
+		ModelUUID: modelUUID,
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
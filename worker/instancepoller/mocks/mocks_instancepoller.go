@@ -164,6 +164,18 @@ func (mr *MockMachineMockRecorder) Refresh() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockMachine)(nil).Refresh))
 }
 
+// SetHardwareCharacteristics mocks base method
+func (m *MockMachine) SetHardwareCharacteristics(arg0 instance.HardwareCharacteristics) error {
+	ret := m.ctrl.Call(m, "SetHardwareCharacteristics", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHardwareCharacteristics indicates an expected call of SetHardwareCharacteristics
+func (mr *MockMachineMockRecorder) SetHardwareCharacteristics(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHardwareCharacteristics", reflect.TypeOf((*MockMachine)(nil).SetHardwareCharacteristics), arg0)
+}
+
 // SetInstanceStatus mocks base method
 func (m *MockMachine) SetInstanceStatus(arg0 status.Status, arg1 string, arg2 map[string]interface{}) error {
 	ret := m.ctrl.Call(m, "SetInstanceStatus", arg0, arg1, arg2)
@@ -0,0 +1,155 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package instancepoller
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/core/instance"
+	"github.com/juju/juju/core/network"
+)
+
+// The statuses in the instance poller worker's report.
+const (
+	reportStatusPolling   = "polling"
+	reportStatusSuspended = "suspended: invalid credential"
+)
+
+// reporter is implemented by the instance poller worker, whose report
+// shows up in the dependency engine report.
+type reporter interface {
+	Report() map[string]interface{}
+}
+
+// staticReport holds the report a worker made when it stopped.
+type staticReport struct {
+	report map[string]interface{}
+}
+
+func (r *staticReport) Report() map[string]interface{} { return r.report }
+
+// Registry collects the reports of the instance poller workers of an
+// agent's models, so that they can be shown together by the agent's
+// introspection endpoint. It is safe for concurrent use.
+type Registry struct {
+	mu      sync.Mutex
+	workers map[string]reporter
+}
+
+// NewRegistry returns a new, empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{workers: make(map[string]reporter)}
+}
+
+// register records the worker polling the instances of the model with
+// the given UUID, replacing any previous one.
+func (r *Registry) register(modelUUID string, w reporter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.workers[modelUUID] = w
+}
+
+// unregister removes the worker polling the instances of the model
+// with the given UUID, if it hasn't already been replaced. If the
+// worker stopped with an error, or while suspended because the model's
+// cloud credential isn't valid, its last report is kept, along with any
+// error, until a new worker is registered, as the instances of the
+// model aren't polled until then.
+func (r *Registry) unregister(modelUUID string, w reporter, failure error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.workers[modelUUID] != w {
+		return
+	}
+	report := w.Report()
+	if failure == nil && report["status"] != reportStatusSuspended {
+		delete(r.workers, modelUUID)
+		return
+	}
+	if failure != nil {
+		report["error"] = failure.Error()
+	}
+	r.workers[modelUUID] = &staticReport{report}
+}
+
+// IntrospectionReport returns the reports of the instance poller
+// workers, keyed by model UUID, as YAML.
+func (r *Registry) IntrospectionReport() string {
+	r.mu.Lock()
+	reports := make(map[string]interface{}, len(r.workers))
+	for modelUUID, w := range r.workers {
+		reports[modelUUID] = w.Report()
+	}
+	r.mu.Unlock()
+
+	if len(reports) == 0 {
+		return "no instance pollers running\n"
+	}
+	out, err := yaml.Marshal(reports)
+	if err != nil {
+		return fmt.Sprintf("error: %v\n", err)
+	}
+	return string(out)
+}
+
+// machineStats holds what the worker found when it last polled a
+// machine, for its report.
+type machineStats struct {
+	instanceID instance.Id
+	pollGroup  pollGroupType
+	lastPoll   time.Time
+	lastError  string
+
+	// addressesAdded and addressesRemoved hold the difference between
+	// the addresses recorded for the machine and those reported by the
+	// provider when they last changed, at addressesChanged.
+	addressesAdded   []string
+	addressesRemoved []string
+	addressesChanged time.Time
+}
+
+func (s *machineStats) report() map[string]interface{} {
+	report := map[string]interface{}{
+		"instance-id": string(s.instanceID),
+		"poll-group":  s.pollGroup.String(),
+	}
+	if !s.lastPoll.IsZero() {
+		report["last-poll"] = s.lastPoll.Format(time.RFC3339)
+	}
+	if s.lastError != "" {
+		report["last-error"] = s.lastError
+	}
+	if !s.addressesChanged.IsZero() {
+		report["addresses-changed"] = s.addressesChanged.Format(time.RFC3339)
+		report["addresses-added"] = s.addressesAdded
+		report["addresses-removed"] = s.addressesRemoved
+	}
+	return report
+}
+
+// addressDelta returns the values of the addresses in after which
+// aren't in before, and of those in before which aren't in after.
+func addressDelta(before, after network.ProviderAddresses) (added, removed []string) {
+	inBefore := make(map[string]bool)
+	for _, addr := range before {
+		inBefore[addr.Value] = true
+	}
+	inAfter := make(map[string]bool)
+	for _, addr := range after {
+		inAfter[addr.Value] = true
+		if !inBefore[addr.Value] {
+			added = append(added, addr.Value)
+		}
+	}
+	for _, addr := range before {
+		if !inAfter[addr.Value] {
+			removed = append(removed, addr.Value)
+		}
+	}
+	return added, removed
+}
@@ -0,0 +1,107 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package instancepoller
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/core/network"
+)
+
+type registrySuite struct{}
+
+var _ = gc.Suite(&registrySuite{})
+
+type fakeReporter struct {
+	machines int
+}
+
+func (r *fakeReporter) Report() map[string]interface{} {
+	return map[string]interface{}{"machines": r.machines}
+}
+
+func (s *registrySuite) TestIntrospectionReport(c *gc.C) {
+	registry := NewRegistry()
+	c.Assert(registry.IntrospectionReport(), gc.Equals, "no instance pollers running\n")
+
+	registry.register("uuid-2", &fakeReporter{2})
+	registry.register("uuid-1", &fakeReporter{1})
+	c.Assert(registry.IntrospectionReport(), gc.Equals, `
+uuid-1:
+  machines: 1
+uuid-2:
+  machines: 2
+`[1:])
+}
+
+func (s *registrySuite) TestUnregisterStopped(c *gc.C) {
+	registry := NewRegistry()
+	w := &fakeReporter{1}
+	registry.register("uuid-1", w)
+	registry.unregister("uuid-1", w, nil)
+	c.Assert(registry.IntrospectionReport(), gc.Equals, "no instance pollers running\n")
+}
+
+func (s *registrySuite) TestUnregisterFailedKeepsLastReport(c *gc.C) {
+	registry := NewRegistry()
+	w := &fakeReporter{1}
+	registry.register("uuid-1", w)
+	registry.unregister("uuid-1", w, errors.New("boom"))
+	c.Assert(registry.IntrospectionReport(), gc.Equals, `
+uuid-1:
+  error: boom
+  machines: 1
+`[1:])
+
+	// The report is replaced when the worker restarts.
+	registry.register("uuid-1", &fakeReporter{2})
+	c.Assert(registry.IntrospectionReport(), gc.Equals, `
+uuid-1:
+  machines: 2
+`[1:])
+}
+
+type suspendedReporter struct{}
+
+func (*suspendedReporter) Report() map[string]interface{} {
+	return map[string]interface{}{
+		"status":           reportStatusSuspended,
+		"suspended-reason": "unauthorized",
+	}
+}
+
+func (s *registrySuite) TestUnregisterSuspendedKeepsLastReport(c *gc.C) {
+	registry := NewRegistry()
+	w := &suspendedReporter{}
+	registry.register("uuid-1", w)
+	registry.unregister("uuid-1", w, nil)
+	c.Assert(registry.IntrospectionReport(), gc.Equals, `
+uuid-1:
+  status: 'suspended: invalid credential'
+  suspended-reason: unauthorized
+`[1:])
+}
+
+func (s *registrySuite) TestUnregisterReplaced(c *gc.C) {
+	registry := NewRegistry()
+	old := &fakeReporter{1}
+	registry.register("uuid-1", old)
+	registry.register("uuid-1", &fakeReporter{2})
+	registry.unregister("uuid-1", old, nil)
+	c.Assert(registry.IntrospectionReport(), gc.Equals, `
+uuid-1:
+  machines: 2
+`[1:])
+}
+
+func (s *registrySuite) TestAddressDelta(c *gc.C) {
+	added, removed := addressDelta(
+		network.NewProviderAddresses("10.0.0.1", "10.0.0.2"),
+		network.NewProviderAddresses("10.0.0.2", "10.0.0.3"),
+	)
+	c.Assert(added, jc.DeepEquals, []string{"10.0.0.3"})
+	c.Assert(removed, jc.DeepEquals, []string{"10.0.0.1"})
+}
@@ -4,6 +4,8 @@
 package instancepoller
 
 import (
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/juju/clock"
@@ -37,6 +39,9 @@ import (
 //
 // When a machine has an address and is started LongPoll will be used to
 // check that the instance address or status has not changed.
+//
+// Each ShortPoll interval is a poll cycle, in which all the machines due
+// to be polled, from either group, are polled together.
 var (
 	ShortPoll        = 3 * time.Second
 	ShortPollBackoff = 2.0
@@ -59,6 +64,7 @@ type Machine interface {
 	SetProviderAddresses(...network.ProviderAddress) error
 	InstanceStatus() (params.StatusResult, error)
 	SetInstanceStatus(status.Status, string, map[string]interface{}) error
+	SetHardwareCharacteristics(instance.HardwareCharacteristics) error
 	String() string
 	Refresh() error
 	Status() (params.StatusResult, error)
@@ -71,6 +77,8 @@ type Machine interface {
 type FacadeAPI interface {
 	WatchModelMachines() (watcher.StringsWatcher, error)
 	Machine(tag names.MachineTag) (Machine, error)
+	WatchInstancePollRequests() (watcher.StringsWatcher, error)
+	ClearInstancePollRequests([]names.MachineTag) error
 }
 
 // Config encapsulates the configuration options for instantiating a new
@@ -82,6 +90,29 @@ type Config struct {
 	Logger  Logger
 
 	CredentialAPI common.CredentialAPI
+
+	// BulkInstanceQueries is true if the Environ's Instances method
+	// queries instances in bulk (see environs.BulkInstanceQuerier), so
+	// that all the instances polled in a cycle are looked up with a
+	// single call. Otherwise the instances of each poll group are
+	// looked up with a call of their own.
+	BulkInstanceQueries bool
+
+	// InstanceNotifier, if set, is watched for changes to instances
+	// instead of polling started machines every LongPoll. Machines
+	// which are still settling are polled as usual.
+	InstanceNotifier environs.InstanceNotifier
+
+	// AddressConflictPolicy, if set, returns the model's policy for
+	// reconciling the addresses reported by the provider with those
+	// reported by the machines themselves. Otherwise the provider's
+	// addresses are preferred.
+	AddressConflictPolicy func() network.AddressConflictPolicy
+
+	// Registry, if set, collects the worker's report for the agent's
+	// introspection endpoint, under ModelUUID.
+	Registry  *Registry
+	ModelUUID string
 }
 
 // Validate checks whether the worker configuration settings are valid.
@@ -101,6 +132,9 @@ func (config Config) Validate() error {
 	if config.CredentialAPI == nil {
 		return errors.NotValidf("nil CredentialAPI")
 	}
+	if config.Registry != nil && config.ModelUUID == "" {
+		return errors.NotValidf("empty ModelUUID")
+	}
 	return nil
 }
 
@@ -112,6 +146,17 @@ const (
 	invalidPollGroup
 )
 
+// String returns the name of the poll group, for reporting.
+func (t pollGroupType) String() string {
+	switch t {
+	case shortPollGroup:
+		return "short"
+	case longPollGroup:
+		return "long"
+	}
+	return "none"
+}
+
 type pollGroupEntry struct {
 	m          Machine
 	tag        names.MachineTag
@@ -119,6 +164,10 @@ type pollGroupEntry struct {
 
 	shortPollInterval time.Duration
 	shortPollAt       time.Time
+
+	// hardware holds the hardware characteristics last recorded
+	// for the machine's instance.
+	hardware *instance.HardwareCharacteristics
 }
 
 func (e *pollGroupEntry) resetShortPollInterval(clk clock.Clock) {
@@ -142,6 +191,30 @@ type updaterWorker struct {
 	instanceIDToGroupEntry map[instance.Id]*pollGroupEntry
 	callContext            context.ProviderCallContext
 
+	// longPollAt is when the machines in the long poll group are next
+	// polled.
+	longPollAt time.Time
+
+	// hardwareNotSupported is set when the controller can't record
+	// changes to instance hardware.
+	hardwareNotSupported bool
+
+	// instancesNotified is set when the provider notifies changes to
+	// instances, so the long poll group needn't be polled.
+	instancesNotified bool
+
+	// suspendedReason is set, with the reason given by the provider,
+	// when a provider call finds the model's cloud credential invalid.
+	// The machines aren't polled again by this worker: the credential
+	// flag stops it, and a new worker is started once the credential
+	// is valid again.
+	suspendedReason string
+
+	// statsMu guards stats, suspendedReason and, for reporting,
+	// instancesNotified.
+	statsMu sync.Mutex
+	stats   map[names.MachineTag]*machineStats
+
 	// Hook function which tests can use to be notified when the worker
 	// has processed a full loop iteration.
 	loopCompletedHook func()
@@ -161,7 +234,12 @@ func NewWorker(config Config) (worker.Worker, error) {
 			make(map[names.MachineTag]*pollGroupEntry),
 		},
 		instanceIDToGroupEntry: make(map[instance.Id]*pollGroupEntry),
-		callContext:            common.NewCloudCallContext(config.CredentialAPI, nil),
+		stats:                  make(map[names.MachineTag]*machineStats),
+		longPollAt:             config.Clock.Now().Add(LongPoll),
+	}
+	u.callContext = &context.CloudCallContext{
+		InvalidateCredentialFunc: u.invalidateCredential,
+		DyingFunc:                u.catacomb.Dying,
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &u.catacomb,
@@ -183,7 +261,39 @@ func (u *updaterWorker) Wait() error {
 	return u.catacomb.Wait()
 }
 
-func (u *updaterWorker) loop() error {
+// Report shows up in the dependency engine report.
+func (u *updaterWorker) Report() map[string]interface{} {
+	u.statsMu.Lock()
+	defer u.statsMu.Unlock()
+	machines := make(map[string]interface{}, len(u.stats))
+	for tag, stats := range u.stats {
+		machines[tag.Id()] = stats.report()
+	}
+	report := map[string]interface{}{
+		"status":                 reportStatusPolling,
+		"bulk-instance-queries":  u.config.BulkInstanceQueries,
+		"instance-notifications": u.instancesNotified,
+		"machines":               machines,
+	}
+	if u.suspendedReason != "" {
+		report["status"] = reportStatusSuspended
+		report["suspended-reason"] = u.suspendedReason
+	}
+	return report
+}
+
+func (u *updaterWorker) loop() (err error) {
+	if registry := u.config.Registry; registry != nil {
+		registry.register(u.config.ModelUUID, u)
+		defer func() {
+			var failure error
+			if err != u.catacomb.ErrDying() {
+				failure = err
+			}
+			registry.unregister(u.config.ModelUUID, u, failure)
+		}()
+	}
+
 	watcher, err := u.config.Facade.WatchModelMachines()
 	if err != nil {
 		return errors.Trace(err)
@@ -192,11 +302,29 @@ func (u *updaterWorker) loop() error {
 		return errors.Trace(err)
 	}
 
-	shortPollTimer := u.config.Clock.NewTimer(ShortPoll)
-	longPollTimer := u.config.Clock.NewTimer(LongPoll)
+	// Requests to poll machines without waiting for their next poll
+	// are only available from newer controllers.
+	var pollRequests <-chan []string
+	requestsWatcher, err := u.config.Facade.WatchInstancePollRequests()
+	if errors.IsNotSupported(err) {
+		u.config.Logger.Debugf("not watching for instance poll requests: %v", err)
+	} else if err != nil {
+		return errors.Trace(err)
+	} else {
+		if err := u.catacomb.Add(requestsWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		pollRequests = requestsWatcher.Changes()
+	}
+
+	instanceChanges, err := u.watchInstances()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	pollTimer := u.config.Clock.NewTimer(ShortPoll)
 	defer func() {
-		_ = shortPollTimer.Stop()
-		_ = longPollTimer.Stop()
+		_ = pollTimer.Stop()
 	}()
 
 	for {
@@ -214,16 +342,25 @@ func (u *updaterWorker) loop() error {
 					return err
 				}
 			}
-		case <-shortPollTimer.Chan():
-			if err := u.pollGroupMembers(shortPollGroup); err != nil {
+		case ids, ok := <-pollRequests:
+			if !ok {
+				return errors.New("instance poll requests watcher closed")
+			}
+			if err := u.pollRequestedMachines(ids); err != nil {
+				return err
+			}
+		case ids, ok := <-instanceChanges:
+			if !ok {
+				return errors.New("instance changes watcher closed")
+			}
+			if err := u.pollChangedInstances(ids); err != nil {
 				return err
 			}
-			shortPollTimer.Reset(ShortPoll)
-		case <-longPollTimer.Chan():
-			if err := u.pollGroupMembers(longPollGroup); err != nil {
+		case <-pollTimer.Chan():
+			if err := u.pollMachines(); err != nil {
 				return err
 			}
-			longPollTimer.Reset(LongPoll)
+			pollTimer.Reset(ShortPoll)
 		}
 
 		if u.loopCompletedHook != nil {
@@ -232,6 +369,29 @@ func (u *updaterWorker) loop() error {
 	}
 }
 
+// watchInstances starts watching the provider for changes to instances,
+// if it can notify them, and returns the channel they're sent on. If it
+// can't, the channel is nil and the machines are polled instead.
+func (u *updaterWorker) watchInstances() (<-chan []string, error) {
+	if u.config.InstanceNotifier == nil {
+		return nil, nil
+	}
+	w, err := u.config.InstanceNotifier.WatchInstances(u.callContext)
+	if errors.IsNotSupported(err) {
+		u.config.Logger.Debugf("polling instances: %v", err)
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot watch instances")
+	}
+	if err := u.catacomb.Add(w); err != nil {
+		return nil, errors.Trace(err)
+	}
+	u.statsMu.Lock()
+	u.instancesNotified = true
+	u.statsMu.Unlock()
+	return w.Changes(), nil
+}
+
 func (u *updaterWorker) queueMachineForPolling(tag names.MachineTag) error {
 	// If we are already polling this machine, check whether it is still alive
 	// and remove it from its poll group if it now dead.
@@ -243,6 +403,7 @@ func (u *updaterWorker) queueMachineForPolling(tag names.MachineTag) error {
 			u.config.Logger.Debugf("removing dead machine %q (instance ID %q)", entry.m, entry.instanceID)
 			delete(u.pollGroup[groupType], tag)
 			delete(u.instanceIDToGroupEntry, entry.instanceID)
+			u.forgetMachineStats(tag)
 			return nil
 		}
 
@@ -319,62 +480,212 @@ func (u *updaterWorker) lookupPolledMachine(tag names.MachineTag) (*pollGroupEnt
 	return nil, invalidPollGroup
 }
 
-func (u *updaterWorker) pollGroupMembers(groupType pollGroupType) error {
-	// Build a list of instance IDs to pass as a query to the provider.
-	var instList []instance.Id
+// polledEntry is a machine being polled in a poll cycle.
+type polledEntry struct {
+	entry     *pollGroupEntry
+	groupType pollGroupType
+}
+
+// pollMachines polls the machines in the short poll group whose poll
+// interval has elapsed and, once every LongPoll, all the machines in the
+// long poll group. When the provider notifies changes to instances, the
+// long poll group is left to pollChangedInstances.
+func (u *updaterWorker) pollMachines() error {
 	now := u.config.Clock.Now()
-	for _, entry := range u.pollGroup[groupType] {
-		if groupType == shortPollGroup && now.Before(entry.shortPollAt) {
+	var polled []polledEntry
+	for _, entry := range u.pollGroup[shortPollGroup] {
+		if now.Before(entry.shortPollAt) {
 			continue // we shouldn't poll this entry yet
 		}
+		polled = append(polled, polledEntry{entry, shortPollGroup})
+	}
+	if !now.Before(u.longPollAt) {
+		if !u.instancesNotified {
+			for _, entry := range u.pollGroup[longPollGroup] {
+				polled = append(polled, polledEntry{entry, longPollGroup})
+			}
+		}
+		u.longPollAt = now.Add(LongPoll)
+	}
+	return u.pollEntries(polled)
+}
+
+// pollRequestedMachines polls the machines with the given ids at once,
+// whichever poll group they are in, without changing when they are next
+// due to be polled. Machines the worker doesn't poll, such as manually
+// provisioned ones, are ignored.
+func (u *updaterWorker) pollRequestedMachines(ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	tags := make([]names.MachineTag, len(ids))
+	for i, id := range ids {
+		tags[i] = names.NewMachineTag(id)
+	}
+	// Clear the requests before polling, so that any made while the
+	// machines are being polled are reported again.
+	if err := u.config.Facade.ClearInstancePollRequests(tags); err != nil {
+		return errors.Annotate(err, "cannot clear instance poll requests")
+	}
+	var polled []polledEntry
+	for _, tag := range tags {
+		entry, groupType := u.lookupPolledMachine(tag)
+		if entry == nil {
+			u.config.Logger.Debugf("ignoring poll request for machine %q which is not being polled", tag.Id())
+			continue
+		}
+		u.config.Logger.Debugf("polling machine %q (instance ID %q) on request", entry.m, entry.instanceID)
+		polled = append(polled, polledEntry{entry, groupType})
+	}
+	return u.pollEntries(polled)
+}
 
-		if err := u.resolveInstanceID(entry); err != nil {
+// pollChangedInstances polls the machines with the instances the
+// provider notified changes to, whichever poll group they are in.
+// Instances of machines the worker doesn't poll are ignored.
+func (u *updaterWorker) pollChangedInstances(ids []string) error {
+	var polled []polledEntry
+	for _, id := range ids {
+		entry := u.instanceIDToGroupEntry[instance.Id(id)]
+		if entry == nil {
+			u.config.Logger.Debugf("ignoring change to instance %q which is not being polled", id)
+			continue
+		}
+		_, groupType := u.lookupPolledMachine(entry.tag)
+		if groupType == invalidPollGroup {
+			continue
+		}
+		u.config.Logger.Debugf("polling machine %q (instance ID %q) on change", entry.m, entry.instanceID)
+		polled = append(polled, polledEntry{entry, groupType})
+	}
+	return u.pollEntries(polled)
+}
+
+// pollEntries queries the provider for the instances of the given
+// machines and records what it reports. The instances are looked up
+// with a single call to the environ if it queries instances in bulk,
+// and with one call for each poll group otherwise.
+func (u *updaterWorker) pollEntries(polled []polledEntry) error {
+	// Build a list of instance IDs to pass as a query to the provider.
+	var instList []instance.Id
+	groupTypes := make(map[instance.Id]pollGroupType)
+	for _, p := range polled {
+		if err := u.resolveInstanceID(p.entry); err != nil {
 			if params.IsCodeNotProvisioned(err) {
 				// machine not provisioned yet; bump its poll
 				// interval and re-try later (or as soon as we
 				// get a change for the machine)
-				entry.bumpShortPollInterval(u.config.Clock)
+				p.entry.bumpShortPollInterval(u.config.Clock)
+				u.recordPoll(p.entry, err)
 				continue
 			}
+			u.recordPoll(p.entry, err)
 			return errors.Trace(err)
 		}
 
-		instList = append(instList, entry.instanceID)
+		instList = append(instList, p.entry.instanceID)
+		groupTypes[p.entry.instanceID] = p.groupType
 	}
+	sort.Slice(instList, func(i, j int) bool { return instList[i] < instList[j] })
 
-	if len(instList) == 0 {
+	if u.config.BulkInstanceQueries {
+		return u.pollInstances(instList, groupTypes)
+	}
+	for _, groupType := range []pollGroupType{shortPollGroup, longPollGroup} {
+		var groupList []instance.Id
+		for _, instID := range instList {
+			if groupTypes[instID] == groupType {
+				groupList = append(groupList, instID)
+			}
+		}
+		if err := u.pollInstances(groupList, groupTypes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pollInstances queries the provider for the given instances with a
+// single call and records what it reports for each of them.
+func (u *updaterWorker) pollInstances(instList []instance.Id, groupTypes map[instance.Id]pollGroupType) error {
+	if len(instList) == 0 || u.suspended() {
+		// There's nothing to poll, or the provider can't be queried
+		// until the model's cloud credential is valid again.
 		return nil
 	}
 
 	infoList, err := u.config.Environ.Instances(u.callContext, instList)
 	if err != nil && !(err == environs.ErrPartialInstances || err == environs.ErrNoInstances) {
+		for _, instID := range instList {
+			u.recordPoll(u.instanceIDToGroupEntry[instID], err)
+		}
+		if u.suspended() {
+			// The provider found the model's cloud credential
+			// invalid; wait for it to be valid again rather than
+			// bouncing.
+			return nil
+		}
 		return errors.Trace(err)
 	}
+	if err == environs.ErrNoInstances {
+		for _, instID := range instList {
+			u.recordPoll(u.instanceIDToGroupEntry[instID], errors.NotFoundf("instance %q", instID))
+		}
+	}
 	for idx, info := range infoList {
 		// No details found for this instance. This most probably means
 		// that the unit has been killed and we haven't been notified
 		// yet. Log the error and keep going.
+		entry := u.instanceIDToGroupEntry[instList[idx]]
 		if info == nil {
 			u.config.Logger.Warningf("unable to retrieve instance information for instance: %q", instList[idx])
+			u.recordPoll(entry, errors.NotFoundf("instance %q", instList[idx]))
 			continue
 		}
 
-		entry := u.instanceIDToGroupEntry[instList[idx]]
 		providerStatus, err := u.processProviderInfo(entry, info)
 		if err != nil {
+			u.recordPoll(entry, err)
 			return errors.Trace(err)
 		}
 
 		machineStatus, err := entry.m.Status()
 		if err != nil {
+			u.recordPoll(entry, err)
 			return errors.Trace(err)
 		}
-		u.maybeSwitchPollGroup(groupType, entry, providerStatus, status.Status(machineStatus.Status))
+		u.maybeSwitchPollGroup(groupTypes[instList[idx]], entry, providerStatus, status.Status(machineStatus.Status))
+		u.recordPoll(entry, nil)
 	}
 
 	return nil
 }
 
+// invalidateCredential is called by the provider when a call fails
+// because the model's cloud credential isn't valid. The credential is
+// invalidated on the controller, which suspends the model, and the
+// worker stops polling the machines. Rather than bouncing, it waits to
+// be stopped by the model's credential flag, and its report is kept by
+// the registry until a new worker starts.
+func (u *updaterWorker) invalidateCredential(reason string) error {
+	u.statsMu.Lock()
+	suspended := u.suspendedReason != ""
+	u.suspendedReason = reason
+	u.statsMu.Unlock()
+	if !suspended {
+		u.config.Logger.Warningf("suspending instance polling: cloud credential is not valid: %v", reason)
+	}
+	return u.config.CredentialAPI.InvalidateModelCredential(reason)
+}
+
+// suspended reports whether the worker has stopped polling because the
+// model's cloud credential isn't valid.
+func (u *updaterWorker) suspended() bool {
+	u.statsMu.Lock()
+	defer u.statsMu.Unlock()
+	return u.suspendedReason != ""
+}
+
 func (u *updaterWorker) resolveInstanceID(entry *pollGroupEntry) error {
 	if entry.instanceID != "" {
 		return nil // already resolved
@@ -446,11 +757,55 @@ func (u *updaterWorker) processProviderInfo(entry *pollGroupEntry, info instance
 			u.config.Logger.Errorf("cannot set addresses on %q: %v", entry.m, err)
 			return status.Unknown, errors.Trace(err)
 		}
+		u.recordAddressChange(entry, curAddresses, providerAddresses)
+	}
+
+	if err := u.processProviderHardware(entry, info); err != nil {
+		return status.Unknown, errors.Trace(err)
 	}
 
 	return providerStatus.Status, nil
 }
 
+// processProviderHardware records the hardware reported by the provider
+// for the instance, so that instances resized outside of Juju are
+// reflected in the model.
+func (u *updaterWorker) processProviderHardware(entry *pollGroupEntry, info instances.Instance) error {
+	if u.hardwareNotSupported {
+		return nil
+	}
+	reporter, ok := info.(instances.HardwareReporter)
+	if !ok {
+		return nil
+	}
+	hc, err := reporter.HardwareCharacteristics(u.callContext)
+	if err != nil {
+		// The hardware is informational; don't let it prevent
+		// status and address updates.
+		u.config.Logger.Warningf("cannot get hardware characteristics for machine %v (instance ID %q): %v", entry.m.Id(), entry.instanceID, err)
+		return nil
+	}
+	if hc == nil || (entry.hardware != nil && entry.hardware.String() == hc.String()) {
+		return nil
+	}
+
+	err = entry.m.SetHardwareCharacteristics(*hc)
+	if errors.IsNotSupported(err) {
+		u.config.Logger.Debugf("not recording instance hardware: %v", err)
+		u.hardwareNotSupported = true
+		return nil
+	}
+	if err != nil {
+		u.config.Logger.Errorf("cannot set hardware characteristics on %q: %v", entry.m, err)
+		return errors.Trace(err)
+	}
+	if entry.hardware != nil {
+		u.config.Logger.Infof("machine %q (instance ID %q) has been resized to %v", entry.m.Id(), entry.instanceID, hc)
+	}
+	entry.hardware = hc
+	return nil
+}
+
 func (u *updaterWorker) maybeSwitchPollGroup(curGroup pollGroupType, entry *pollGroupEntry, curProviderStatus, curMachineStatus status.Status) {
 	if curProviderStatus == status.Allocating || curProviderStatus == status.Pending {
 		// Keep the machine in the short poll group until it settles
@@ -459,11 +814,15 @@ func (u *updaterWorker) maybeSwitchPollGroup(curGroup pollGroupType, entry *poll
 	}
 
 	machAddrs, _ := entry.m.ProviderAddresses()
+	// When the addresses reported by the machine win over the provider's,
+	// a started machine has the addresses it needs whether or not the
+	// provider reports any.
+	hasAddrs := len(machAddrs) > 0 || u.addressConflictPolicy() == network.MachinerWins
 
 	// If the machine is currently in the long poll group and it has an
 	// unknown status or suddenly has no network addresses, move it back to
 	// the short poll group.
-	if curGroup == longPollGroup && (curProviderStatus == status.Unknown || len(machAddrs) == 0) {
+	if curGroup == longPollGroup && (curProviderStatus == status.Unknown || !hasAddrs) {
 		u.moveEntryToPollGroup(shortPollGroup, entry)
 		u.config.Logger.Debugf("moving machine %q (instance ID %q) back to short poll group", entry.m, entry.instanceID)
 		return
@@ -471,7 +830,7 @@ func (u *updaterWorker) maybeSwitchPollGroup(curGroup pollGroupType, entry *poll
 
 	// The machine has started and we have at least one address; move to
 	// the long poll group
-	if len(machAddrs) > 0 && curMachineStatus == status.Started {
+	if hasAddrs && curMachineStatus == status.Started {
 		u.moveEntryToPollGroup(longPollGroup, entry)
 		if curGroup != longPollGroup {
 			u.config.Logger.Debugf("moving machine %q (instance ID %q) to long poll group", entry.m, entry.instanceID)
@@ -486,6 +845,60 @@ func (u *updaterWorker) maybeSwitchPollGroup(curGroup pollGroupType, entry *poll
 	}
 }
 
+// addressConflictPolicy returns the model's policy for reconciling
+// provider and machine addresses.
+func (u *updaterWorker) addressConflictPolicy() network.AddressConflictPolicy {
+	if u.config.AddressConflictPolicy == nil {
+		return network.ProviderWins
+	}
+	return u.config.AddressConflictPolicy()
+}
+
+// recordPoll records for the worker's report that the entry's machine
+// was polled, and the error polling it, if any.
+func (u *updaterWorker) recordPoll(entry *pollGroupEntry, err error) {
+	_, groupType := u.lookupPolledMachine(entry.tag)
+	u.statsMu.Lock()
+	defer u.statsMu.Unlock()
+	stats := u.machineStats(entry)
+	stats.pollGroup = groupType
+	stats.lastPoll = u.config.Clock.Now()
+	stats.lastError = ""
+	if err != nil {
+		stats.lastError = err.Error()
+	}
+}
+
+// recordAddressChange records for the worker's report how the addresses
+// of the entry's machine changed.
+func (u *updaterWorker) recordAddressChange(entry *pollGroupEntry, before, after network.ProviderAddresses) {
+	added, removed := addressDelta(before, after)
+	u.statsMu.Lock()
+	defer u.statsMu.Unlock()
+	stats := u.machineStats(entry)
+	stats.addressesAdded = added
+	stats.addressesRemoved = removed
+	stats.addressesChanged = u.config.Clock.Now()
+}
+
+// machineStats returns the stats of the entry's machine, adding them if
+// needed. statsMu must be held.
+func (u *updaterWorker) machineStats(entry *pollGroupEntry) *machineStats {
+	stats := u.stats[entry.tag]
+	if stats == nil {
+		stats = &machineStats{}
+		u.stats[entry.tag] = stats
+	}
+	stats.instanceID = entry.instanceID
+	return stats
+}
+
+func (u *updaterWorker) forgetMachineStats(tag names.MachineTag) {
+	u.statsMu.Lock()
+	defer u.statsMu.Unlock()
+	delete(u.stats, tag)
+}
+
 // addressesEqual compares the addresses of the machine and the instance information.
 func addressesEqual(addrs0, addrs1 network.ProviderAddresses) bool {
 	if len(addrs0) != len(addrs1) {
@@ -25,6 +25,7 @@ import (
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/context"
 	"github.com/juju/juju/environs/instances"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/instancepoller/mocks"
@@ -96,6 +97,10 @@ func (s *configSuite) TestConfigValidation(c *gc.C) {
 	testCfg = origCfg
 	testCfg.CredentialAPI = nil
 	c.Assert(testCfg.Validate(), gc.ErrorMatches, "nil CredentialAPI.*")
+
+	testCfg = origCfg
+	testCfg.Registry = NewRegistry()
+	c.Assert(testCfg.Validate(), gc.ErrorMatches, "empty ModelUUID.*")
 }
 
 type pollGroupEntrySuite struct{}
@@ -213,6 +218,105 @@ func (s *workerSuite) TestUpdateOfStatusAndAddressDetails(c *gc.C) {
 	providerStatus, err := updWorker.processProviderInfo(entry, instInfo)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(providerStatus, gc.Equals, status.Running)
+
+	// The change to the addresses is reported.
+	report := updWorker.Report()["machines"].(map[string]interface{})["0"]
+	c.Assert(report, jc.DeepEquals, map[string]interface{}{
+		"instance-id":       "b4dc0ffee",
+		"poll-group":        "none",
+		"addresses-changed": updWorker.config.Clock.Now().Format(time.RFC3339),
+		"addresses-added":   []string{"10.9.9.9"},
+		"addresses-removed": []string{"10.6.6.6"},
+	})
+}
+
+func (s *workerSuite) TestUpdateOfHardwareCharacteristics(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	w, _ := s.startWorker(c, ctrl)
+	defer workertest.CleanKill(c, w)
+	updWorker := w.(*updaterWorker)
+
+	machine, entry := s.runningMachineEntry(ctrl)
+	inst := &hardwareInstance{MockInstance: s.runningInstance(ctrl)}
+
+	// The hardware is recorded when first seen...
+	mem, cores := uint64(4096), uint64(2)
+	inst.hc = &instance.HardwareCharacteristics{Mem: &mem, CpuCores: &cores}
+	machine.EXPECT().SetHardwareCharacteristics(*inst.hc).Return(nil)
+	_, err := updWorker.processProviderInfo(entry, inst)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// ... but not again while it is unchanged ...
+	_, err = updWorker.processProviderInfo(entry, inst)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// ... until the instance is resized.
+	newMem, newCores := uint64(8192), uint64(4)
+	inst.hc = &instance.HardwareCharacteristics{Mem: &newMem, CpuCores: &newCores}
+	machine.EXPECT().SetHardwareCharacteristics(*inst.hc).Return(nil)
+	_, err = updWorker.processProviderInfo(entry, inst)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entry.hardware, jc.DeepEquals, inst.hc)
+}
+
+func (s *workerSuite) TestUpdateOfHardwareCharacteristicsNotSupported(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	w, _ := s.startWorker(c, ctrl)
+	defer workertest.CleanKill(c, w)
+	updWorker := w.(*updaterWorker)
+
+	machine, entry := s.runningMachineEntry(ctrl)
+	mem := uint64(4096)
+	inst := &hardwareInstance{
+		MockInstance: s.runningInstance(ctrl),
+		hc:           &instance.HardwareCharacteristics{Mem: &mem},
+	}
+
+	// An older controller can't record the hardware, so the worker
+	// stops trying.
+	machine.EXPECT().SetHardwareCharacteristics(*inst.hc).Return(errors.NotSupportedf("hardware"))
+	for i := 0; i < 2; i++ {
+		providerStatus, err := updWorker.processProviderInfo(entry, inst)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(providerStatus, gc.Equals, status.Running)
+	}
+}
+
+// runningMachineEntry returns a machine, and its poll group entry,
+// whose status and addresses match those of runningInstance.
+func (s *workerSuite) runningMachineEntry(ctrl *gomock.Controller) (*mocks.MockMachine, *pollGroupEntry) {
+	machine := mocks.NewMockMachine(ctrl)
+	machine.EXPECT().Id().Return("0").AnyTimes()
+	machine.EXPECT().Life().Return(life.Alive).AnyTimes()
+	machine.EXPECT().InstanceStatus().Return(params.StatusResult{Status: string(status.Running)}, nil).AnyTimes()
+	machine.EXPECT().ProviderAddresses().Return(testAddrs, nil).AnyTimes()
+	return machine, &pollGroupEntry{
+		tag:        names.NewMachineTag("0"),
+		m:          machine,
+		instanceID: "b4dc0ffee",
+	}
+}
+
+func (s *workerSuite) runningInstance(ctrl *gomock.Controller) *mocks.MockInstance {
+	inst := mocks.NewMockInstance(ctrl)
+	inst.EXPECT().Status(gomock.Any()).Return(instance.Status{Status: status.Running}).AnyTimes()
+	inst.EXPECT().Addresses(gomock.Any()).Return(testAddrs, nil).AnyTimes()
+	return inst
+}
+
+// hardwareInstance is an instance whose provider
+// reports its hardware characteristics.
+type hardwareInstance struct {
+	*mocks.MockInstance
+	hc *instance.HardwareCharacteristics
+}
+
+func (i *hardwareInstance) HardwareCharacteristics(context.ProviderCallContext) (*instance.HardwareCharacteristics, error) {
+	return i.hc, nil
 }
 
 func (s *workerSuite) TestStartedMachineWithNetAddressesMovesToLongPollGroup(c *gc.C) {
@@ -241,6 +345,37 @@ func (s *workerSuite) TestStartedMachineWithNetAddressesMovesToLongPollGroup(c *
 	c.Assert(updWorker.pollGroup[longPollGroup], gc.HasLen, 1)
 }
 
+func (s *workerSuite) TestStartedMachineWithoutNetAddressesMachinerWins(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	w, _ := s.startWorkerWithConfig(c, ctrl, func(config *Config) {
+		config.AddressConflictPolicy = func() network.AddressConflictPolicy {
+			return network.MachinerWins
+		}
+	})
+	defer workertest.CleanKill(c, w)
+	updWorker := w.(*updaterWorker)
+
+	machineTag := names.NewMachineTag("0")
+	machine := mocks.NewMockMachine(ctrl)
+	updWorker.appendToShortPollGroup(machineTag, machine)
+
+	// The provider reports no addresses, but those reported by the
+	// started machine take precedence, so it is moved to the long poll
+	// group anyway, and kept there.
+	machine.EXPECT().ProviderAddresses().Return(nil, nil).Times(2)
+
+	entry, _ := updWorker.lookupPolledMachine(machineTag)
+	updWorker.maybeSwitchPollGroup(shortPollGroup, entry, status.Running, status.Started)
+	c.Assert(updWorker.pollGroup[shortPollGroup], gc.HasLen, 0)
+	c.Assert(updWorker.pollGroup[longPollGroup], gc.HasLen, 1)
+
+	updWorker.maybeSwitchPollGroup(longPollGroup, entry, status.Running, status.Started)
+	c.Assert(updWorker.pollGroup[shortPollGroup], gc.HasLen, 0)
+	c.Assert(updWorker.pollGroup[longPollGroup], gc.HasLen, 1)
+}
+
 func (s *workerSuite) TestNonStartedMachinesGetBumpedPollInterval(c *gc.C) {
 	ctrl := gomock.NewController(c)
 	defer ctrl.Finish()
@@ -440,9 +575,9 @@ func (s *workerSuite) TestLongPollMachineNotKnownByProvider(c *gc.C) {
 		[]instances.Instance{}, environs.ErrPartialInstances,
 	)
 
-	// Advance the clock to trigger processing of both the short AND long
-	// poll groups. This should trigger to full loop runs.
-	s.assertWorkerCompletesLoops(c, updWorker, 2, func() {
+	// Advance the clock to trigger a poll cycle in which the long poll
+	// group is due.
+	s.assertWorkerCompletesLoop(c, updWorker, func() {
 		mocked.clock.Advance(LongPoll)
 	})
 }
@@ -473,13 +608,336 @@ func (s *workerSuite) TestLongPollNoMachineInGroupKnownByProvider(c *gc.C) {
 		nil, environs.ErrNoInstances,
 	)
 
-	// Advance the clock to trigger processing of both the short AND long
-	// poll groups. This should trigger to full loop runs.
-	s.assertWorkerCompletesLoops(c, updWorker, 2, func() {
+	// Advance the clock to trigger a poll cycle in which the long poll
+	// group is due.
+	s.assertWorkerCompletesLoop(c, updWorker, func() {
+		mocked.clock.Advance(LongPoll)
+	})
+
+	// The missing instance is reported.
+	c.Assert(updWorker.Report()["machines"], jc.DeepEquals, map[string]interface{}{
+		"0": map[string]interface{}{
+			"instance-id": "d3adc0de",
+			"poll-group":  "long",
+			"last-poll":   mocked.clock.Now().Format(time.RFC3339),
+			"last-error":  `instance "d3adc0de" not found`,
+		},
+	})
+}
+
+func (s *workerSuite) TestPollCycleQueriesBothGroupsInOneCall(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	w, mocked := s.startWorkerWithBulkQueries(c, ctrl, true)
+	defer workertest.CleanKill(c, w)
+	updWorker := w.(*updaterWorker)
+
+	shortMachine, shortInfo := s.polledMachine(ctrl, "0", "b4dc0ffee")
+	updWorker.appendToShortPollGroup(names.NewMachineTag("0"), shortMachine)
+	longMachine, longInfo := s.polledMachine(ctrl, "1", "d3adc0de")
+	updWorker.appendToShortPollGroup(names.NewMachineTag("1"), longMachine)
+	entry, _ := updWorker.lookupPolledMachine(names.NewMachineTag("1"))
+	updWorker.moveEntryToPollGroup(longPollGroup, entry)
+
+	// The instances of both groups are looked up with a single call.
+	mocked.environ.EXPECT().Instances(gomock.Any(), []instance.Id{"b4dc0ffee", "d3adc0de"}).Return(
+		[]instances.Instance{shortInfo, longInfo}, nil,
+	)
+	s.assertWorkerCompletesLoop(c, updWorker, func() {
 		mocked.clock.Advance(LongPoll)
 	})
 }
 
+func (s *workerSuite) TestPollCycleQueriesEachGroupWithoutBulkQueries(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	w, mocked := s.startWorker(c, ctrl)
+	defer workertest.CleanKill(c, w)
+	updWorker := w.(*updaterWorker)
+
+	machine0, info0 := s.polledMachine(ctrl, "0", "b4dc0ffee")
+	updWorker.appendToShortPollGroup(names.NewMachineTag("0"), machine0)
+	machine1, info1 := s.polledMachine(ctrl, "1", "d3adc0de")
+	updWorker.appendToShortPollGroup(names.NewMachineTag("1"), machine1)
+	machine2, info2 := s.polledMachine(ctrl, "2", "c0ffee")
+	updWorker.appendToShortPollGroup(names.NewMachineTag("2"), machine2)
+	entry, _ := updWorker.lookupPolledMachine(names.NewMachineTag("2"))
+	updWorker.moveEntryToPollGroup(longPollGroup, entry)
+
+	// The instances of each group are looked up with a call of their
+	// own.
+	gomock.InOrder(
+		mocked.environ.EXPECT().Instances(gomock.Any(), []instance.Id{"b4dc0ffee", "d3adc0de"}).Return(
+			[]instances.Instance{info0, info1}, nil,
+		),
+		mocked.environ.EXPECT().Instances(gomock.Any(), []instance.Id{"c0ffee"}).Return(
+			[]instances.Instance{info2}, nil,
+		),
+	)
+	s.assertWorkerCompletesLoop(c, updWorker, func() {
+		mocked.clock.Advance(LongPoll)
+	})
+}
+
+func (s *workerSuite) TestPollRequestPollsMachinesAtOnce(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	w, mocked := s.startWorker(c, ctrl)
+	defer workertest.CleanKill(c, w)
+	updWorker := w.(*updaterWorker)
+
+	// Machine 1 isn't due to be polled for a while.
+	machine, info := s.polledMachine(ctrl, "1", "d3adc0de")
+	updWorker.appendToShortPollGroup(names.NewMachineTag("1"), machine)
+	entry, _ := updWorker.lookupPolledMachine(names.NewMachineTag("1"))
+	updWorker.moveEntryToPollGroup(longPollGroup, entry)
+
+	// It is polled as soon as it is requested, without advancing the
+	// clock. Machine 2 isn't polled by the worker, so is ignored.
+	mocked.environ.EXPECT().Instances(gomock.Any(), []instance.Id{"d3adc0de"}).Return(
+		[]instances.Instance{info}, nil,
+	)
+	s.assertWorkerCompletesLoop(c, updWorker, func() {
+		mocked.facadeAPI.assertEnqueuePollRequest(c, []string{"1", "2"})
+	})
+	c.Assert(mocked.facadeAPI.cleared, jc.DeepEquals, []names.MachineTag{
+		names.NewMachineTag("1"), names.NewMachineTag("2"),
+	})
+}
+
+func (s *workerSuite) TestInstanceChangesPollChangedMachines(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	notifier := newMockInstanceNotifier(ctrl, nil)
+	w, mocked := s.startWorkerWithConfig(c, ctrl, func(config *Config) {
+		config.InstanceNotifier = notifier
+	})
+	defer workertest.CleanKill(c, w)
+	updWorker := w.(*updaterWorker)
+
+	machine, info := s.polledMachine(ctrl, "1", "d3adc0de")
+	updWorker.appendToShortPollGroup(names.NewMachineTag("1"), machine)
+	entry, _ := updWorker.lookupPolledMachine(names.NewMachineTag("1"))
+	updWorker.moveEntryToPollGroup(longPollGroup, entry)
+	c.Assert(updWorker.resolveInstanceID(entry), jc.ErrorIsNil)
+
+	// The machine is polled as soon as the provider notifies a change
+	// to its instance. Instances the worker doesn't know are ignored.
+	mocked.environ.EXPECT().Instances(gomock.Any(), []instance.Id{"d3adc0de"}).Return(
+		[]instances.Instance{info}, nil,
+	)
+	s.assertWorkerCompletesLoop(c, updWorker, func() {
+		notifier.assertEnqueueChange(c, []string{"d3adc0de", "f00d"})
+	})
+}
+
+func (s *workerSuite) TestInstanceChangesReplaceLongPoll(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	w, mocked := s.startWorkerWithConfig(c, ctrl, func(config *Config) {
+		config.InstanceNotifier = newMockInstanceNotifier(ctrl, nil)
+	})
+	defer workertest.CleanKill(c, w)
+	updWorker := w.(*updaterWorker)
+
+	// The machine isn't expected to be used, as the long poll group
+	// isn't polled when the provider notifies instance changes.
+	machine := mocks.NewMockMachine(ctrl)
+	updWorker.appendToShortPollGroup(names.NewMachineTag("1"), machine)
+	entry, _ := updWorker.lookupPolledMachine(names.NewMachineTag("1"))
+	updWorker.moveEntryToPollGroup(longPollGroup, entry)
+
+	s.assertWorkerCompletesLoop(c, updWorker, func() {
+		mocked.clock.Advance(LongPoll)
+	})
+}
+
+func (s *workerSuite) TestInstanceChangesNotSupportedFallsBackToPolling(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	w, mocked := s.startWorkerWithConfig(c, ctrl, func(config *Config) {
+		config.InstanceNotifier = newMockInstanceNotifier(ctrl, errors.NotSupportedf("instance notifications"))
+	})
+	defer workertest.CleanKill(c, w)
+	updWorker := w.(*updaterWorker)
+
+	machine, info := s.polledMachine(ctrl, "1", "d3adc0de")
+	updWorker.appendToShortPollGroup(names.NewMachineTag("1"), machine)
+	entry, _ := updWorker.lookupPolledMachine(names.NewMachineTag("1"))
+	updWorker.moveEntryToPollGroup(longPollGroup, entry)
+
+	mocked.environ.EXPECT().Instances(gomock.Any(), []instance.Id{"d3adc0de"}).Return(
+		[]instances.Instance{info}, nil,
+	)
+	s.assertWorkerCompletesLoop(c, updWorker, func() {
+		mocked.clock.Advance(LongPoll)
+	})
+}
+
+func (s *workerSuite) TestInstanceChangesWatchError(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	w, err := NewWorker(Config{
+		Clock:         testclock.NewClock(time.Now()),
+		Facade:        newMockFacadeAPI(ctrl, nil),
+		Environ:       mocks.NewMockEnviron(ctrl),
+		CredentialAPI: mocks.NewMockCredentialAPI(ctrl),
+		Logger:        loggo.GetLogger("juju.worker.instancepoller"),
+
+		InstanceNotifier: newMockInstanceNotifier(ctrl, errors.New("boom")),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "cannot watch instances: boom")
+}
+
+func (s *workerSuite) TestReportPolledMachines(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	registry := NewRegistry()
+	w, mocked := s.startWorkerWithConfig(c, ctrl, func(config *Config) {
+		config.Registry = registry
+		config.ModelUUID = coretesting.ModelTag.Id()
+	})
+	updWorker := w.(*updaterWorker)
+
+	machine, info := s.polledMachine(ctrl, "0", "b4dc0ffee")
+	updWorker.appendToShortPollGroup(names.NewMachineTag("0"), machine)
+	mocked.environ.EXPECT().Instances(gomock.Any(), []instance.Id{"b4dc0ffee"}).Return(
+		[]instances.Instance{info}, nil,
+	)
+	s.assertWorkerCompletesLoop(c, updWorker, func() {
+		mocked.clock.Advance(ShortPoll)
+	})
+
+	c.Assert(updWorker.Report(), jc.DeepEquals, map[string]interface{}{
+		"status":                 "polling",
+		"bulk-instance-queries":  false,
+		"instance-notifications": false,
+		"machines": map[string]interface{}{
+			"0": map[string]interface{}{
+				"instance-id": "b4dc0ffee",
+				"poll-group":  "short",
+				"last-poll":   mocked.clock.Now().Format(time.RFC3339),
+			},
+		},
+	})
+	c.Assert(registry.IntrospectionReport(), gc.Equals, coretesting.ModelTag.Id()+`:
+  bulk-instance-queries: false
+  instance-notifications: false
+  machines:
+    "0":
+      instance-id: b4dc0ffee
+      last-poll: `+mocked.clock.Now().Format(time.RFC3339)+`
+      poll-group: short
+  status: polling
+`)
+
+	// The report is removed when the worker stops.
+	workertest.CleanKill(c, w)
+	c.Assert(registry.IntrospectionReport(), gc.Equals, "no instance pollers running\n")
+}
+
+func (s *workerSuite) TestInvalidCredentialSuspendsPolling(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	credentialAPI := mocks.NewMockCredentialAPI(ctrl)
+	w, mocked := s.startWorkerWithConfig(c, ctrl, func(config *Config) {
+		config.CredentialAPI = credentialAPI
+	})
+	defer workertest.CleanKill(c, w)
+	updWorker := w.(*updaterWorker)
+
+	machine := mocks.NewMockMachine(ctrl)
+	machine.EXPECT().InstanceId().Return(instance.Id("b4dc0ffee"), nil)
+	updWorker.appendToShortPollGroup(names.NewMachineTag("0"), machine)
+
+	// The provider finds the model's credential invalid, which is
+	// reported to the controller without the worker bouncing.
+	credentialAPI.EXPECT().InvalidateModelCredential("unauthorized").Return(nil)
+	mocked.environ.EXPECT().Instances(gomock.Any(), []instance.Id{"b4dc0ffee"}).DoAndReturn(
+		func(ctx context.ProviderCallContext, _ []instance.Id) ([]instances.Instance, error) {
+			c.Check(ctx.InvalidateCredential("unauthorized"), jc.ErrorIsNil)
+			return nil, errors.New("unauthorized")
+		},
+	)
+	s.assertWorkerCompletesLoop(c, updWorker, func() {
+		mocked.clock.Advance(ShortPoll)
+	})
+	report := updWorker.Report()
+	c.Check(report["status"], gc.Equals, "suspended: invalid credential")
+	c.Check(report["suspended-reason"], gc.Equals, "unauthorized")
+
+	// The provider isn't queried while the worker is suspended.
+	s.assertWorkerCompletesLoop(c, updWorker, func() {
+		mocked.clock.Advance(ShortPollCap)
+	})
+	c.Check(updWorker.Report()["status"], gc.Equals, "suspended: invalid credential")
+}
+
+func (s *workerSuite) TestInvalidCredentialReportKept(c *gc.C) {
+	ctrl := gomock.NewController(c)
+	defer ctrl.Finish()
+
+	credentialAPI := mocks.NewMockCredentialAPI(ctrl)
+	registry := NewRegistry()
+	w, mocked := s.startWorkerWithConfig(c, ctrl, func(config *Config) {
+		config.CredentialAPI = credentialAPI
+		config.Registry = registry
+		config.ModelUUID = coretesting.ModelTag.Id()
+	})
+	updWorker := w.(*updaterWorker)
+
+	machine := mocks.NewMockMachine(ctrl)
+	machine.EXPECT().InstanceId().Return(instance.Id("b4dc0ffee"), nil)
+	updWorker.appendToShortPollGroup(names.NewMachineTag("0"), machine)
+
+	credentialAPI.EXPECT().InvalidateModelCredential("unauthorized").Return(nil)
+	mocked.environ.EXPECT().Instances(gomock.Any(), []instance.Id{"b4dc0ffee"}).DoAndReturn(
+		func(ctx context.ProviderCallContext, _ []instance.Id) ([]instances.Instance, error) {
+			c.Check(ctx.InvalidateCredential("unauthorized"), jc.ErrorIsNil)
+			return nil, errors.New("unauthorized")
+		},
+	)
+	s.assertWorkerCompletesLoop(c, updWorker, func() {
+		mocked.clock.Advance(ShortPoll)
+	})
+
+	// The worker is stopped once the model's credential is found
+	// invalid, but its report is kept until it is restarted.
+	workertest.CleanKill(c, w)
+	c.Assert(registry.IntrospectionReport(), jc.Contains, "status: 'suspended: invalid credential'\n")
+	c.Assert(registry.IntrospectionReport(), jc.Contains, "suspended-reason: unauthorized\n")
+}
+
+// polledMachine returns a started machine with the given instance ID
+// and the running instance the provider reports for it, both without
+// addresses.
+func (s *workerSuite) polledMachine(ctrl *gomock.Controller, id string, instID instance.Id) (*mocks.MockMachine, *mocks.MockInstance) {
+	machine := mocks.NewMockMachine(ctrl)
+	machine.EXPECT().Id().Return(id).AnyTimes()
+	machine.EXPECT().Life().Return(life.Alive).AnyTimes()
+	machine.EXPECT().InstanceId().Return(instID, nil)
+	machine.EXPECT().InstanceStatus().Return(params.StatusResult{Status: string(status.Running)}, nil)
+	machine.EXPECT().Status().Return(params.StatusResult{Status: string(status.Started)}, nil)
+	machine.EXPECT().ProviderAddresses().Return(nil, nil).AnyTimes()
+	machine.EXPECT().String().Return("machine-" + id).AnyTimes()
+
+	info := mocks.NewMockInstance(ctrl)
+	info.EXPECT().Status(gomock.Any()).Return(instance.Status{Status: status.Running})
+	info.EXPECT().Addresses(gomock.Any()).Return(nil, nil)
+	return machine, info
+}
+
 func (s *workerSuite) assertWorkerCompletesLoop(c *gc.C, w *updaterWorker, triggerFn func()) {
 	s.assertWorkerCompletesLoops(c, w, 1, triggerFn)
 }
@@ -507,6 +965,18 @@ type workerMocks struct {
 }
 
 func (s *workerSuite) startWorker(c *gc.C, ctrl *gomock.Controller) (worker.Worker, workerMocks) {
+	return s.startWorkerWithBulkQueries(c, ctrl, false)
+}
+
+func (s *workerSuite) startWorkerWithBulkQueries(c *gc.C, ctrl *gomock.Controller, bulk bool) (worker.Worker, workerMocks) {
+	return s.startWorkerWithConfig(c, ctrl, func(config *Config) {
+		config.BulkInstanceQueries = bulk
+	})
+}
+
+// startWorkerWithConfig starts a worker with mocked dependencies,
+// letting configFn change its configuration first.
+func (s *workerSuite) startWorkerWithConfig(c *gc.C, ctrl *gomock.Controller, configFn func(*Config)) (worker.Worker, workerMocks) {
 	workerMainLoopEnteredCh := make(chan struct{}, 1)
 	mocked := workerMocks{
 		clock:     testclock.NewClock(time.Now()),
@@ -514,13 +984,15 @@ func (s *workerSuite) startWorker(c *gc.C, ctrl *gomock.Controller) (worker.Work
 		environ:   mocks.NewMockEnviron(ctrl),
 	}
 
-	w, err := NewWorker(Config{
+	config := Config{
 		Clock:         mocked.clock,
 		Facade:        mocked.facadeAPI,
 		Environ:       mocked.environ,
 		CredentialAPI: mocks.NewMockCredentialAPI(ctrl),
 		Logger:        loggo.GetLogger("juju.worker.instancepoller"),
-	})
+	}
+	configFn(&config)
+	w, err := NewWorker(config)
 	c.Assert(err, jc.ErrorIsNil)
 
 	// Wait for worker to reach main loop before we allow tests to
@@ -542,6 +1014,10 @@ type mockFacadeAPI struct {
 
 	sw              *mocks.MockStringsWatcher
 	watcherChangeCh chan []string
+
+	requestsWatcher  *mocks.MockStringsWatcher
+	requestsChangeCh chan []string
+	cleared          []names.MachineTag
 }
 
 func newMockFacadeAPI(ctrl *gomock.Controller, workerGotWatcherCh chan<- struct{}) *mockFacadeAPI {
@@ -560,6 +1036,12 @@ func newMockFacadeAPI(ctrl *gomock.Controller, workerGotWatcherCh chan<- struct{
 	}).AnyTimes()
 	api.sw.EXPECT().Kill().AnyTimes()
 	api.sw.EXPECT().Wait().AnyTimes()
+
+	api.requestsWatcher = mocks.NewMockStringsWatcher(ctrl)
+	api.requestsChangeCh = make(chan []string)
+	api.requestsWatcher.EXPECT().Changes().Return(api.requestsChangeCh).AnyTimes()
+	api.requestsWatcher.EXPECT().Kill().AnyTimes()
+	api.requestsWatcher.EXPECT().Wait().AnyTimes()
 	return api
 }
 
@@ -570,6 +1052,15 @@ func (api *mockFacadeAPI) assertEnqueueChange(c *gc.C, values []string) {
 		c.Fatal("timed out waiting for worker to pick up change")
 	}
 }
+
+func (api *mockFacadeAPI) assertEnqueuePollRequest(c *gc.C, ids []string) {
+	select {
+	case api.requestsChangeCh <- ids:
+	case <-time.After(coretesting.ShortWait):
+		c.Fatal("timed out waiting for worker to pick up poll request")
+	}
+}
+
 func (api *mockFacadeAPI) addMachine(tag names.MachineTag, m Machine) { api.machineMap[tag] = m }
 
 func (api *mockFacadeAPI) WatchModelMachines() (watcher.StringsWatcher, error) { return api.sw, nil }
@@ -579,3 +1070,45 @@ func (api *mockFacadeAPI) Machine(tag names.MachineTag) (Machine, error) {
 	}
 	return nil, errors.NotFoundf(tag.String())
 }
+func (api *mockFacadeAPI) WatchInstancePollRequests() (watcher.StringsWatcher, error) {
+	return api.requestsWatcher, nil
+}
+func (api *mockFacadeAPI) ClearInstancePollRequests(tags []names.MachineTag) error {
+	api.cleared = append(api.cleared, tags...)
+	return nil
+}
+
+// mockInstanceNotifier is an environs.InstanceNotifier whose watcher
+// notifies the instance ids sent with assertEnqueueChange.
+type mockInstanceNotifier struct {
+	err      error
+	sw       *mocks.MockStringsWatcher
+	changeCh chan []string
+}
+
+func newMockInstanceNotifier(ctrl *gomock.Controller, err error) *mockInstanceNotifier {
+	n := &mockInstanceNotifier{
+		err:      err,
+		sw:       mocks.NewMockStringsWatcher(ctrl),
+		changeCh: make(chan []string),
+	}
+	n.sw.EXPECT().Changes().Return(n.changeCh).AnyTimes()
+	n.sw.EXPECT().Kill().AnyTimes()
+	n.sw.EXPECT().Wait().AnyTimes()
+	return n
+}
+
+func (n *mockInstanceNotifier) assertEnqueueChange(c *gc.C, ids []string) {
+	select {
+	case n.changeCh <- ids:
+	case <-time.After(coretesting.ShortWait):
+		c.Fatal("timed out waiting for worker to pick up instance change")
+	}
+}
+
+func (n *mockInstanceNotifier) WatchInstances(context.ProviderCallContext) (watcher.StringsWatcher, error) {
+	if n.err != nil {
+		return nil, n.err
+	}
+	return n.sw, nil
+}
@@ -0,0 +1,48 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package introspection
+
+import (
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+)
+
+// SocketName returns the name of the abstract domain socket over which
+// the introspection worker of the agent with the given tag serves
+// requests.
+func SocketName(tag names.Tag) string {
+	return "jujud-" + tag.String()
+}
+
+// Query fetches the given path, such as "/depengine", from the
+// introspection worker listening on the named socket, and returns the
+// body of the response.
+func Query(socketName, path string) ([]byte, error) {
+	client := http.Client{
+		Transport: &http.Transport{
+			Dial: func(proto, addr string) (net.Conn, error) {
+				return net.Dial("unix", "@"+socketName)
+			},
+		},
+	}
+	resp, err := client.Get("http://unix.socket" + path)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot query introspection socket %q", socketName)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("querying %s: %s: %s", path, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
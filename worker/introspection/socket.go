@@ -45,6 +45,7 @@ type Config struct {
 	DepEngine          DepEngineReporter
 	StatePool          IntrospectionReporter
 	PubSub             IntrospectionReporter
+	InstancePoller     IntrospectionReporter
 	MachineLock        machinelock.Lock
 	PrometheusGatherer prometheus.Gatherer
 	Presence           presence.Recorder
@@ -68,6 +69,7 @@ type socketListener struct {
 	depEngine          DepEngineReporter
 	statePool          IntrospectionReporter
 	pubsub             IntrospectionReporter
+	instancePoller     IntrospectionReporter
 	machineLock        machinelock.Lock
 	prometheusGatherer prometheus.Gatherer
 	presence           presence.Recorder
@@ -101,6 +103,7 @@ func NewWorker(config Config) (worker.Worker, error) {
 		depEngine:          config.DepEngine,
 		statePool:          config.StatePool,
 		pubsub:             config.PubSub,
+		instancePoller:     config.InstancePoller,
 		machineLock:        config.MachineLock,
 		prometheusGatherer: config.PrometheusGatherer,
 		presence:           config.Presence,
@@ -118,6 +121,7 @@ func (w *socketListener) serve() {
 			DependencyEngine:   w.depEngine,
 			StatePool:          w.statePool,
 			PubSub:             w.pubsub,
+			InstancePoller:     w.instancePoller,
 			MachineLock:        w.machineLock,
 			PrometheusGatherer: w.prometheusGatherer,
 			Presence:           w.presence,
@@ -156,6 +160,7 @@ type ReportSources struct {
 	DependencyEngine   DepEngineReporter
 	StatePool          IntrospectionReporter
 	PubSub             IntrospectionReporter
+	InstancePoller     IntrospectionReporter
 	MachineLock        machinelock.Lock
 	PrometheusGatherer prometheus.Gatherer
 	Presence           presence.Recorder
@@ -183,6 +188,10 @@ func RegisterHTTPHandlers(
 		name:     "PubSub Report",
 		reporter: sources.PubSub,
 	})
+	handle("/instancepoller", introspectionReporterHandler{
+		name:     "Instance Poller Report",
+		reporter: sources.InstancePoller,
+	})
 	handle("/metrics/", promhttp.HandlerFor(sources.PrometheusGatherer, promhttp.HandlerOpts{}))
 	// Unit agents don't have a presence recorder to pass in.
 	if sources.Presence != nil {
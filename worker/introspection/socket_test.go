@@ -19,6 +19,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	"github.com/prometheus/client_golang/prometheus"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/workertest"
 
@@ -142,6 +143,12 @@ func (s *introspectionSuite) TestMissingPubSubReporter(c *gc.C) {
 	matches(c, buf, "PubSub Report: missing reporter")
 }
 
+func (s *introspectionSuite) TestMissingInstancePollerReporter(c *gc.C) {
+	buf := s.call(c, "/instancepoller")
+	matches(c, buf, "404 Not Found")
+	matches(c, buf, "Instance Poller Report: missing reporter")
+}
+
 func (s *introspectionSuite) TestMissingMachineLock(c *gc.C) {
 	buf := s.call(c, "/machinelock/")
 	matches(c, buf, "404 Not Found")
@@ -211,6 +218,35 @@ func (s *introspectionSuite) TestPrometheusMetrics(c *gc.C) {
 	matches(c, buf, "tau 6.283185")
 }
 
+func (s *introspectionSuite) TestQuery(c *gc.C) {
+	workertest.CheckKill(c, s.worker)
+	s.reporter = &reporter{
+		values: map[string]interface{}{
+			"working": true,
+		},
+	}
+	s.startWorker(c)
+
+	body, err := introspection.Query(s.name, "/depengine")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(body), gc.Equals, "Dependency Engine Report\n\nworking: true\n")
+}
+
+func (s *introspectionSuite) TestQueryNotFound(c *gc.C) {
+	_, err := introspection.Query(s.name, "/depengine")
+	c.Assert(err, gc.ErrorMatches, "querying /depengine: 404 Not Found: missing dependency engine reporter")
+}
+
+func (s *introspectionSuite) TestQueryNoSocket(c *gc.C) {
+	_, err := introspection.Query(s.name+"-missing", "/depengine")
+	c.Assert(err, gc.ErrorMatches, `cannot query introspection socket "introspection-test-.*-missing": .*`)
+}
+
+func (s *suite) TestSocketName(c *gc.C) {
+	c.Assert(introspection.SocketName(names.NewMachineTag("42")), gc.Equals, "jujud-machine-42")
+	c.Assert(introspection.SocketName(names.NewUnitTag("mysql/0")), gc.Equals, "jujud-unit-mysql-0")
+}
+
 // matches fails if regex is not found in the contents of b.
 // b is expected to be the response from the pprof http server, and will
 // contain some HTTP preamble that should be ignored.
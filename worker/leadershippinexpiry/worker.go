@@ -0,0 +1,115 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package leadershippinexpiry provides a worker which lifts the pins
+// operators place on the leaders of a model's applications once they
+// expire.
+package leadershippinexpiry
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1/catacomb"
+)
+
+// logger is here to stop the desire of creating a package level logger.
+// Don't do this, instead pass one through as config to the worker.
+var logger interface{}
+
+// Facade represents the API used by the leadership pin expiry worker.
+type Facade interface {
+	UnpinExpiredLeaders() ([]string, error)
+}
+
+// Logger defines the methods used by the leadership pin expiry worker
+// for logging.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+}
+
+// Config holds all necessary attributes to start a leadership pin
+// expiry worker.
+type Config struct {
+	Facade        Facade
+	CheckInterval time.Duration
+	Clock         clock.Clock
+	Logger        Logger
+}
+
+// Validate will err unless basic requirements for a valid
+// config are met.
+func (c Config) Validate() error {
+	if c.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if c.CheckInterval <= 0 {
+		return errors.NotValidf("non-positive CheckInterval")
+	}
+	if c.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if c.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// Worker periodically lifts expired leadership pins.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// New returns a worker which lifts expired leadership pins.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is defined on worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is defined on worker.Worker.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	// Check straight away, so that pins which expired while the
+	// worker wasn't running are lifted promptly.
+	timer := w.config.Clock.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+
+		case <-timer.Chan():
+			unpinned, err := w.config.Facade.UnpinExpiredLeaders()
+			if err != nil {
+				return errors.Annotate(err, "cannot unpin expired leaders")
+			}
+			if len(unpinned) > 0 {
+				w.config.Logger.Infof("leadership pins expired for %v", unpinned)
+			} else {
+				w.config.Logger.Debugf("no leadership pins have expired")
+			}
+			timer.Reset(w.config.CheckInterval)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package leadershippinexpiry_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/leadershippinexpiry"
+)
+
+const checkInterval = time.Minute
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	facade *fakeFacade
+	clock  *testclock.Clock
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.facade = &fakeFacade{
+		calls: make(chan struct{}, 10),
+	}
+	s.clock = testclock.NewClock(time.Time{})
+}
+
+func (s *WorkerSuite) config() leadershippinexpiry.Config {
+	return leadershippinexpiry.Config{
+		Facade:        s.facade,
+		CheckInterval: checkInterval,
+		Clock:         s.clock,
+		Logger:        loggo.GetLogger("test"),
+	}
+}
+
+func (s *WorkerSuite) waitForCall(c *gc.C) {
+	select {
+	case <-s.facade.calls:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for UnpinExpiredLeaders")
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	c.Check(config.Validate(), jc.ErrorIsNil)
+	config.CheckInterval = 0
+	c.Check(config.Validate(), gc.ErrorMatches, "non-positive CheckInterval not valid")
+	config.CheckInterval = checkInterval
+	config.Facade = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Facade not valid")
+}
+
+func (s *WorkerSuite) TestUnpinsPeriodically(c *gc.C) {
+	w, err := leadershippinexpiry.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	// The first check is made straight away.
+	c.Assert(s.clock.WaitAdvance(0, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.waitForCall(c)
+
+	c.Assert(s.clock.WaitAdvance(checkInterval, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.waitForCall(c)
+	s.facade.CheckCallNames(c, "UnpinExpiredLeaders", "UnpinExpiredLeaders")
+}
+
+func (s *WorkerSuite) TestUnpinError(c *gc.C) {
+	s.facade.SetErrors(errors.New("boom"))
+	w, err := leadershippinexpiry.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.DirtyKill(c, w)
+
+	c.Assert(s.clock.WaitAdvance(0, coretesting.LongWait, 1), jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "cannot unpin expired leaders: boom")
+}
+
+type fakeFacade struct {
+	testing.Stub
+	calls chan struct{}
+}
+
+func (f *fakeFacade) UnpinExpiredLeaders() ([]string, error) {
+	f.AddCall("UnpinExpiredLeaders")
+	defer func() { f.calls <- struct{}{} }()
+	return []string{"mysql"}, f.NextErr()
+}
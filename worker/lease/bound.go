@@ -17,6 +17,8 @@ type broker interface {
 	lease.Claimer
 	lease.Pinner
 	lease.Reader
+	lease.Inspector
+	lease.Revoker
 }
 
 // boundManager implements the broker interface.
@@ -98,6 +100,38 @@ func (b *boundManager) Leases() map[string]string {
 	return b.manager.leases(b.namespace, b.modelUUID)
 }
 
+// Details (lease.Inspector) returns the holder, expiry and pins of
+// all leases in the bound namespace/model.
+func (b *boundManager) Details() map[string]lease.Details {
+	return b.manager.details(b.namespace, b.modelUUID)
+}
+
+// Revoke (lease.Revoker) sends a revoke message to the worker loop.
+func (b *boundManager) Revoke(leaseName string) error {
+	key := b.leaseKey(leaseName)
+	if err := b.secretary.CheckLease(key); err != nil {
+		return errors.Annotatef(err, "cannot revoke lease %q", leaseName)
+	}
+	return errors.Trace(revoke{
+		leaseKey: key,
+		response: make(chan error),
+		stop:     b.manager.catacomb.Dying(),
+	}.invoke(b.manager.revokes))
+}
+
+// namespaceLister implements lease.Lister for a single namespace,
+// across all models.
+type namespaceLister struct {
+	manager   *Manager
+	namespace string
+}
+
+// Holders (lease.Lister) returns the holders of all leases
+// in the namespace.
+func (l *namespaceLister) Holders() map[lease.Key]string {
+	return l.manager.holders(l.namespace)
+}
+
 // pinOp creates a pin instance from the input lease name,
 // then sends it on the input channel.
 func (b *boundManager) pinOp(leaseName string, entity string, ch chan pin) error {
@@ -60,6 +60,7 @@ func (d dummySecretary) CheckDuration(duration time.Duration) error { return nil
 func NewDeadManager(err error) *Manager {
 	var secretary dummySecretary
 	m := Manager{
+		metrics: newMetricsCollector(),
 		config: ManagerConfig{
 			Secretary: func(_ string) (Secretary, error) {
 				return secretary, nil
@@ -93,7 +94,9 @@ func NewManager(config ManagerConfig) (*Manager, error) {
 		expireDone: make(chan struct{}),
 		pins:       make(chan pin),
 		unpins:     make(chan pin),
+		revokes:    make(chan revoke),
 		logContext: logContext,
+		metrics:    newMetricsCollector(),
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &manager.catacomb,
@@ -146,6 +149,12 @@ type Manager struct {
 	// unpins is used to deliver lease unpin requests to the loop.
 	unpins chan pin
 
+	// revokes is used to deliver lease revoke requests to the loop.
+	revokes chan revoke
+
+	// metrics records the latency of the store operations.
+	metrics *metricsCollector
+
 	// wg is used to ensure that all child goroutines are finished
 	// before we stop.
 	wg sync.WaitGroup
@@ -177,6 +186,10 @@ func (manager *Manager) loop() error {
 		_ = manager.config.PrometheusRegisterer.Register(collector)
 		defer manager.config.PrometheusRegisterer.Unregister(collector)
 	}
+	if manager.config.PrometheusRegisterer != nil {
+		_ = manager.config.PrometheusRegisterer.Register(manager.metrics)
+		defer manager.config.PrometheusRegisterer.Unregister(manager.metrics)
+	}
 
 	defer manager.waitForGoroutines()
 	blocks := make(blocks)
@@ -212,6 +225,8 @@ func (manager *Manager) choose(blocks blocks) error {
 		manager.handlePin(pin)
 	case unpin := <-manager.unpins:
 		manager.handleUnpin(unpin)
+	case revoke := <-manager.revokes:
+		manager.handleRevoke(revoke, blocks)
 	case block := <-manager.blocks:
 		// TODO(raftlease): Include the other key items.
 		manager.config.Logger.Tracef("[%s] adding block for: %s", manager.logContext, block.leaseKey.Lease)
@@ -258,6 +273,27 @@ func (manager *Manager) Reader(namespace, modelUUID string) (lease.Reader, error
 	return manager.bind(namespace, modelUUID)
 }
 
+// Inspector returns a lease.Inspector for the specified namespace and model.
+func (manager *Manager) Inspector(namespace, modelUUID string) (lease.Inspector, error) {
+	return manager.bind(namespace, modelUUID)
+}
+
+// Revoker returns a lease.Revoker for the specified namespace and model.
+func (manager *Manager) Revoker(namespace, modelUUID string) (lease.Revoker, error) {
+	return manager.bind(namespace, modelUUID)
+}
+
+// Lister returns a lease.Lister for the specified namespace.
+func (manager *Manager) Lister(namespace string) (lease.Lister, error) {
+	if _, err := manager.config.Secretary(namespace); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &namespaceLister{
+		manager:   manager,
+		namespace: namespace,
+	}, nil
+}
+
 // retryingClaim handles timeouts when claiming, and responds to the
 // claiming party when it eventually succeeds or fails, or if it times
 // out after a number of retries.
@@ -332,12 +368,16 @@ func (manager *Manager) handleClaim(claim claim) (bool, error) {
 			manager.config.Logger.Tracef("[%s] %s asked for lease %s, no lease found, claiming for %s",
 				manager.logContext, claim.holderName, claim.leaseKey.Lease, claim.duration)
 			action = "claiming"
+			start := manager.config.Clock.Now()
 			err = store.ClaimLease(claim.leaseKey, request, manager.catacomb.Dying())
+			manager.metrics.record("claim", err, start, manager.config.Clock.Now())
 		case info.Holder == claim.holderName:
 			manager.config.Logger.Tracef("[%s] %s extending lease %s for %s",
 				manager.logContext, claim.holderName, claim.leaseKey.Lease, claim.duration)
 			action = "extending"
+			start := manager.config.Clock.Now()
 			err = store.ExtendLease(claim.leaseKey, request, manager.catacomb.Dying())
+			manager.metrics.record("extend", err, start, manager.config.Clock.Now())
 		default:
 			// Note: (jam) 2017-10-31) We don't check here if the lease has
 			// expired for the current holder. Should we?
@@ -612,6 +652,26 @@ func (manager *Manager) handleUnpin(p pin) {
 	p.respond(errors.Trace(manager.config.Store.UnpinLease(p.leaseKey, p.entity, manager.catacomb.Dying())))
 }
 
+// handleRevoke forcibly vacates the lease in the supplied revoke request,
+// and releases anything waiting for it to expire.
+func (manager *Manager) handleRevoke(r revoke, blocks blocks) {
+	start := manager.config.Clock.Now()
+	err := manager.config.Store.RevokeLease(r.leaseKey, manager.catacomb.Dying())
+	manager.metrics.record("revoke", err, start, manager.config.Clock.Now())
+	if lease.IsInvalid(err) {
+		r.respond(lease.ErrNotHeld)
+		return
+	}
+	if err != nil {
+		r.respond(errors.Trace(err))
+		return
+	}
+	manager.config.Logger.Warningf("[%s] lease %q in namespace %q for model %q revoked",
+		manager.logContext, r.leaseKey.Lease, r.leaseKey.Namespace, r.leaseKey.ModelUUID)
+	manager.checkBlocks(blocks)
+	r.respond(nil)
+}
+
 // pinned returns lease names and the entities requiring their pinned
 // behaviour, from the input namespace/model for which leases are pinned.
 func (manager *Manager) pinned(namespace, modelUUID string) map[string][]string {
@@ -632,6 +692,33 @@ func (manager *Manager) leases(namespace, modelUUID string) map[string]string {
 	return leases
 }
 
+// details returns the holder, expiry and pins for each lease in the
+// input namespace/model.
+func (manager *Manager) details(namespace, modelUUID string) map[string]lease.Details {
+	pinned := manager.pinned(namespace, modelUUID)
+	details := make(map[string]lease.Details)
+	for key, info := range manager.config.Store.LeaseGroup(namespace, modelUUID) {
+		details[key.Lease] = lease.Details{
+			Holder:   info.Holder,
+			Expiry:   info.Expiry,
+			PinnedBy: pinned[key.Lease],
+		}
+	}
+	return details
+}
+
+// holders returns the holder of each lease in the input namespace,
+// across all models.
+func (manager *Manager) holders(namespace string) map[lease.Key]string {
+	holders := make(map[lease.Key]string)
+	for key, info := range manager.config.Store.Leases() {
+		if key.Namespace == namespace {
+			holders[key] = info.Holder
+		}
+	}
+	return holders
+}
+
 func keysLess(a, b lease.Key) bool {
 	if a.Namespace == b.Namespace && a.ModelUUID == b.ModelUUID {
 		return a.Lease < b.Lease
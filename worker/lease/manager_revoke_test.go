@@ -0,0 +1,198 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+
+	corelease "github.com/juju/juju/core/lease"
+	"github.com/juju/juju/worker/lease"
+)
+
+type RevokeSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&RevokeSuite{})
+
+func (s *RevokeSuite) TestRevoke(c *gc.C) {
+	fix := &Fixture{
+		leases: map[corelease.Key]corelease.Info{
+			key("redis"): {
+				Holder: "redis/0",
+				Expiry: offset(time.Minute),
+			},
+		},
+		expectCalls: []call{{
+			method: "RevokeLease",
+			args:   []interface{}{key("redis")},
+			callback: func(leases map[corelease.Key]corelease.Info) {
+				delete(leases, key("redis"))
+			},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		blockTest := newBlockTest(c, manager, key("redis"))
+		blockTest.assertBlocked(c)
+
+		err := getRevoker(c, manager).Revoke("redis")
+		c.Assert(err, jc.ErrorIsNil)
+
+		err = blockTest.assertUnblocked(c)
+		c.Check(err, jc.ErrorIsNil)
+	})
+}
+
+func (s *RevokeSuite) TestRevokeNotHeld(c *gc.C) {
+	fix := &Fixture{
+		expectCalls: []call{{
+			method: "RevokeLease",
+			args:   []interface{}{key("redis")},
+			err:    corelease.ErrInvalid,
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getRevoker(c, manager).Revoke("redis")
+		c.Check(errors.Cause(err), gc.Equals, corelease.ErrNotHeld)
+	})
+}
+
+func (s *RevokeSuite) TestRevokeError(c *gc.C) {
+	fix := &Fixture{
+		expectCalls: []call{{
+			method: "RevokeLease",
+			args:   []interface{}{key("redis")},
+			err:    errors.New("boom"),
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getRevoker(c, manager).Revoke("redis")
+		c.Check(err, gc.ErrorMatches, "boom")
+	})
+}
+
+func (s *RevokeSuite) TestRevokeInvalidName(c *gc.C) {
+	fix := &Fixture{}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getRevoker(c, manager).Revoke("INVALID")
+		c.Check(err, gc.ErrorMatches, `cannot revoke lease "INVALID": name not valid`)
+	})
+}
+
+func (s *RevokeSuite) TestDetails(c *gc.C) {
+	fix := &Fixture{
+		leases: map[corelease.Key]corelease.Info{
+			key("redis"): {
+				Holder: "redis/0",
+				Expiry: offset(time.Minute),
+			},
+			key("mysql"): {
+				Holder: "mysql/1",
+				Expiry: offset(time.Second),
+			},
+			key("other", "modelUUID", "redis"): {
+				Holder: "redis/1",
+				Expiry: offset(time.Second),
+			},
+		},
+		expectCalls: []call{{
+			method: "Pinned",
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		inspector, err := manager.Inspector("namespace", "modelUUID")
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(inspector.Details(), jc.DeepEquals, map[string]corelease.Details{
+			"redis": {
+				Holder:   "redis/0",
+				Expiry:   offset(time.Minute),
+				PinnedBy: []string{names.NewMachineTag("0").String()},
+			},
+			"mysql": {
+				Holder: "mysql/1",
+				Expiry: offset(time.Second),
+			},
+		})
+	})
+}
+
+func (s *RevokeSuite) TestLister(c *gc.C) {
+	fix := &Fixture{
+		leases: map[corelease.Key]corelease.Info{
+			key("redis"): {
+				Holder: "redis/0",
+				Expiry: offset(time.Minute),
+			},
+			key("namespace", "otherUUID", "mysql"): {
+				Holder: "mysql/1",
+				Expiry: offset(time.Second),
+			},
+			key("other", "modelUUID", "redis"): {
+				Holder: "redis/1",
+				Expiry: offset(time.Second),
+			},
+		},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		lister, err := manager.Lister("namespace")
+		c.Assert(err, jc.ErrorIsNil)
+		c.Check(lister.Holders(), jc.DeepEquals, map[corelease.Key]string{
+			key("redis"):                           "redis/0",
+			key("namespace", "otherUUID", "mysql"): "mysql/1",
+		})
+	})
+}
+
+func (s *RevokeSuite) TestOperationMetrics(c *gc.C) {
+	registry := prometheus.NewPedanticRegistry()
+	fix := &Fixture{
+		registerer: registry,
+		expectCalls: []call{{
+			method: "ClaimLease",
+			args: []interface{}{
+				key("redis"),
+				corelease.Request{"redis/0", time.Minute},
+			},
+		}},
+	}
+	fix.RunTest(c, func(manager *lease.Manager, _ *testclock.Clock) {
+		err := getClaimer(c, manager).Claim("redis", "redis/0", time.Minute)
+		c.Assert(err, jc.ErrorIsNil)
+
+		families, err := registry.Gather()
+		c.Assert(err, jc.ErrorIsNil)
+		var count uint64
+		for _, family := range families {
+			if family.GetName() != "juju_lease_manager_operation_duration_seconds" {
+				continue
+			}
+			for _, metric := range family.GetMetric() {
+				labels := make(map[string]string)
+				for _, label := range metric.GetLabel() {
+					labels[label.GetName()] = label.GetValue()
+				}
+				c.Check(labels, jc.DeepEquals, map[string]string{
+					"operation": "claim",
+					"result":    "success",
+				})
+				count += metric.GetHistogram().GetSampleCount()
+			}
+		}
+		c.Check(count, gc.Equals, uint64(1))
+	})
+}
+
+func getRevoker(c *gc.C, manager *lease.Manager) corelease.Revoker {
+	revoker, err := manager.Revoker("namespace", "modelUUID")
+	c.Assert(err, jc.ErrorIsNil)
+	return revoker
+}
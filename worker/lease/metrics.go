@@ -0,0 +1,65 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/core/lease"
+)
+
+const (
+	metricsNamespace = "juju_lease_manager"
+)
+
+// metricsCollector is a prometheus.Collector that collects metrics
+// about the store operations made by the lease manager.
+type metricsCollector struct {
+	operations *prometheus.HistogramVec
+}
+
+func newMetricsCollector() *metricsCollector {
+	return &metricsCollector{
+		operations: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "operation_duration_seconds",
+			Help:      "Latency of lease claim, extend and revoke operations in seconds",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 15),
+		}, []string{
+			"operation", // claim, extend or revoke
+			"result",    // success, invalid, timeout or error
+		}),
+	}
+}
+
+// record observes the time taken by a store operation that started
+// at the supplied time.
+func (c *metricsCollector) record(operation string, err error, start, end time.Time) {
+	result := "success"
+	switch {
+	case err == nil:
+	case lease.IsInvalid(err):
+		result = "invalid"
+	case lease.IsTimeout(err):
+		result = "timeout"
+	default:
+		result = "error"
+	}
+	c.operations.With(prometheus.Labels{
+		"operation": operation,
+		"result":    result,
+	}).Observe(end.Sub(start).Seconds())
+}
+
+// Describe is part of prometheus.Collector.
+func (c *metricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.operations.Describe(ch)
+}
+
+// Collect is part of prometheus.Collector.
+func (c *metricsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.operations.Collect(ch)
+}
@@ -0,0 +1,38 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package lease
+
+import (
+	"github.com/juju/juju/core/lease"
+)
+
+// revoke is used to deliver forced lease vacation requests to a
+// manager's worker loop on behalf of Revoke.
+type revoke struct {
+	leaseKey lease.Key
+	response chan error
+	stop     <-chan struct{}
+}
+
+// invoke sends the revoke on the supplied channel and waits for a response.
+func (c revoke) invoke(ch chan<- revoke) error {
+	for {
+		select {
+		case <-c.stop:
+			return errStopped
+		case ch <- c:
+			ch = nil
+		case err := <-c.response:
+			return err
+		}
+	}
+}
+
+// respond causes the supplied error to be sent back to invoke.
+func (c revoke) respond(err error) {
+	select {
+	case <-c.stop:
+	case c.response <- err:
+	}
+}
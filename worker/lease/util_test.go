@@ -207,6 +207,11 @@ func (store *Store) ExpireLease(key lease.Key) error {
 	return store.call("ExpireLease", []interface{}{key})
 }
 
+// RevokeLease is part of the corelease.Store interface.
+func (store *Store) RevokeLease(key lease.Key, stop <-chan struct{}) error {
+	return store.call("RevokeLease", []interface{}{key})
+}
+
 // Refresh is part of the lease.Store interface.
 func (store *Store) Refresh() error {
 	return store.call("Refresh", nil)
@@ -27,6 +27,11 @@ type WorkerConfig struct {
 	Logger   Logger
 	Override string
 
+	// OverrideFunc, if set, returns the logging override each time
+	// the logging config is set, so that an override made while the
+	// worker is running is kept. It takes precedence over Override.
+	OverrideFunc func() string
+
 	Callback func(string) error
 }
 
@@ -76,7 +81,11 @@ func (l *loggerWorker) setLogging() {
 	loggingConfig := ""
 	logger := l.config.Logger
 
-	if override := l.config.Override; override != "" {
+	override := l.config.Override
+	if l.config.OverrideFunc != nil {
+		override = l.config.OverrideFunc()
+	}
+	if override != "" {
 		logger.Debugf("overriding logging config with override from agent.conf %q", override)
 		loggingConfig = override
 	} else {
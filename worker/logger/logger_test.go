@@ -139,6 +139,29 @@ func (s *LoggerSuite) TestConfigOverride(c *gc.C) {
 	s.waitLoggingInfo(c, expected)
 }
 
+func (s *LoggerSuite) TestConfigOverrideFunc(c *gc.C) {
+	var override string
+	s.config.Override = "ignored=TRACE"
+	s.config.OverrideFunc = func() string { return override }
+	s.loggerAPI.config = "<root>=INFO"
+	s.loggerAPI.watcher.changes = make(chan struct{})
+
+	loggingWorker := s.makeLogger(c)
+	defer worker.Stop(loggingWorker)
+	s.waitLoggingInfo(c, "<root>=INFO")
+
+	// An override made while the worker runs is kept when the model's
+	// logging config changes.
+	override = "test=TRACE"
+	s.loggerAPI.config = "<root>=ERROR"
+	select {
+	case s.loggerAPI.watcher.changes <- struct{}{}:
+	case <-time.After(testing.LongWait):
+		c.Fatalf("timed out sending change")
+	}
+	s.waitLoggingInfo(c, "<root>=WARNING;test=TRACE")
+}
+
 type mockNotifyWatcher struct {
 	changes chan struct{}
 }
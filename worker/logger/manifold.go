@@ -46,7 +46,6 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				return nil, err
 			}
 			currentConfig := a.CurrentConfig()
-			loggingOverride := currentConfig.Value(agent.LoggingOverride)
 
 			var apiCaller base.APICaller
 			if err := context.Get(config.APICallerName, &apiCaller); err != nil {
@@ -55,11 +54,13 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 
 			loggerFacade := logger.NewState(apiCaller)
 			workerConfig := WorkerConfig{
-				Context:  config.LoggingContext,
-				API:      loggerFacade,
-				Tag:      currentConfig.Tag(),
-				Logger:   config.Logger,
-				Override: loggingOverride,
+				Context: config.LoggingContext,
+				API:     loggerFacade,
+				Tag:     currentConfig.Tag(),
+				Logger:  config.Logger,
+				OverrideFunc: func() string {
+					return a.CurrentConfig().Value(agent.LoggingOverride)
+				},
 				Callback: config.UpdateAgentFunc,
 			}
 			return NewLogger(workerConfig)
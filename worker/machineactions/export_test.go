@@ -0,0 +1,6 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package machineactions
+
+var QueryIntrospection = &queryIntrospection
@@ -14,13 +14,28 @@ import (
 	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/utils/exec"
+	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/core/actions"
+	"github.com/juju/juju/worker/introspection"
 )
 
 // RunAsUser is the user that the machine juju-run action is executed as.
 var RunAsUser = "ubuntu"
 
+// queryIntrospection fetches a path from the introspection worker of an
+// agent on the machine, listening on the named socket.
+var queryIntrospection = introspection.Query
+
+// introspectionPaths maps the sections fetched by the juju-introspect
+// action to the paths the introspection worker serves them on.
+var introspectionPaths = map[string]string{
+	"depengine":   "/depengine",
+	"goroutines":  "/debug/pprof/goroutine?debug=1",
+	"machinelock": "/machinelock/",
+	"metrics":     "/metrics/",
+}
+
 // HandleAction receives a name and a map of parameters for a given machine action.
 // It will handle that action in a specific way and return a results map suitable for ActionFinish.
 func HandleAction(name string, params map[string]interface{}) (results map[string]interface{}, err error) {
@@ -35,6 +50,8 @@ func HandleAction(name string, params map[string]interface{}) (results map[strin
 	switch name {
 	case actions.JujuRunActionName:
 		return handleJujuRunAction(params)
+	case actions.JujuIntrospectActionName:
+		return handleJujuIntrospectAction(params)
 	default:
 		return nil, errors.Errorf("unexpected action %s", name)
 	}
@@ -62,6 +79,33 @@ func handleJujuRunAction(params map[string]interface{}) (results map[string]inte
 	return actionResults, nil
 }
 
+func handleJujuIntrospectAction(params map[string]interface{}) (results map[string]interface{}, err error) {
+	// The spec checks that the parameters are available, and that the
+	// section is one we know, so we don't need to check again here.
+	agent, _ := params["agent"].(string)
+	section, _ := params["section"].(string)
+	tag, err := names.ParseTag(agent)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if kind := tag.Kind(); kind != names.MachineTagKind && kind != names.UnitTagKind {
+		return nil, errors.NotValidf("agent %q", agent)
+	}
+	path, ok := introspectionPaths[section]
+	if !ok {
+		return nil, errors.NotValidf("introspection section %q", section)
+	}
+	logger.Tracef("juju introspect %s %s", tag, path)
+
+	output, err := queryIntrospection(introspection.SocketName(tag), path)
+	if err != nil {
+		return nil, errors.Annotatef(err, "introspecting %s", names.ReadableString(tag))
+	}
+	actionResults := map[string]interface{}{}
+	storeOutput(actionResults, "Output", output)
+	return actionResults, nil
+}
+
 func runCommandWithTimeout(command string, timeout time.Duration, clock clock.Clock) (*exec.ExecResponse, error) {
 	cmd := exec.RunParams{
 		Commands:    command,
@@ -78,3 +78,59 @@ func (s *HandleSuite) TestErrorRun(c *gc.C) {
 	c.Assert(results["Stdout"], gc.Equals, "")
 	c.Assert(results["Stderr"], gc.Equals, "")
 }
+
+func (s *HandleSuite) TestIntrospect(c *gc.C) {
+	var socket, path string
+	s.PatchValue(machineactions.QueryIntrospection, func(socketName, p string) ([]byte, error) {
+		socket, path = socketName, p
+		return []byte("goroutine profile: total 42\n"), nil
+	})
+	params := map[string]interface{}{
+		"agent":   "unit-mysql-0",
+		"section": "goroutines",
+	}
+
+	results, err := machineactions.HandleAction(actions.JujuIntrospectActionName, params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(socket, gc.Equals, "jujud-unit-mysql-0")
+	c.Assert(path, gc.Equals, "/debug/pprof/goroutine?debug=1")
+	c.Assert(results, jc.DeepEquals, map[string]interface{}{
+		"Output": "goroutine profile: total 42\n",
+	})
+}
+
+func (s *HandleSuite) TestIntrospectUnknownSection(c *gc.C) {
+	params := map[string]interface{}{
+		"agent":   "machine-0",
+		"section": "secrets",
+	}
+
+	results, err := machineactions.HandleAction(actions.JujuIntrospectActionName, params)
+	c.Assert(err, gc.ErrorMatches, "invalid action parameters")
+	c.Assert(results, gc.IsNil)
+}
+
+func (s *HandleSuite) TestIntrospectInvalidAgent(c *gc.C) {
+	params := map[string]interface{}{
+		"agent":   "application-mysql",
+		"section": "depengine",
+	}
+
+	results, err := machineactions.HandleAction(actions.JujuIntrospectActionName, params)
+	c.Assert(err, gc.ErrorMatches, `agent "application-mysql" not valid`)
+	c.Assert(results, gc.IsNil)
+}
+
+func (s *HandleSuite) TestIntrospectError(c *gc.C) {
+	s.PatchValue(machineactions.QueryIntrospection, func(socketName, path string) ([]byte, error) {
+		return nil, errors.New("connection refused")
+	})
+	params := map[string]interface{}{
+		"agent":   "machine-0",
+		"section": "depengine",
+	}
+
+	results, err := machineactions.HandleAction(actions.JujuIntrospectActionName, params)
+	c.Assert(err, gc.ErrorMatches, "introspecting machine 0: connection refused")
+	c.Assert(results, gc.IsNil)
+}
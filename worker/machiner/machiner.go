@@ -4,6 +4,7 @@ package machiner
 
 import (
 	"net"
+	"os"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -102,10 +103,37 @@ func (mr *Machiner) SetUp() (watcher.NotifyWatcher, error) {
 		return nil, errors.Annotatef(err, "%s failed to set status started", mr.config.Tag)
 	}
 	logger.Infof("%q started", mr.config.Tag)
+	recordCloudInitFinished(m)
 
 	return m.Watch()
 }
 
+// cloudInitBootFinished is written by cloud-init when it has
+// finished running on the machine.
+var cloudInitBootFinished = "/var/lib/cloud/instance/boot-finished"
+
+// recordCloudInitFinished records when cloud-init finished running
+// in the machine's provisioning timeline. If cloud-init has not yet
+// finished, or the machine wasn't provisioned with cloud-init, nothing
+// is recorded; the controller only records the first time it is told,
+// so it's fine to record it again each time the agent starts.
+func recordCloudInitFinished(m Machine) {
+	info, err := os.Stat(cloudInitBootFinished)
+	if os.IsNotExist(err) {
+		logger.Debugf("cloud-init has not finished running")
+		return
+	} else if err != nil {
+		logger.Warningf("cannot determine when cloud-init finished: %v", err)
+		return
+	}
+	err = m.RecordProvisioningPhase(status.PhaseCloudInitDone, info.ModTime())
+	if errors.IsNotSupported(err) {
+		logger.Debugf("not recording cloud-init completion: %v", err)
+	} else if err != nil {
+		logger.Warningf("cannot record cloud-init completion: %v", err)
+	}
+}
+
 var interfaceAddrs = net.InterfaceAddrs
 
 // setMachineAddresses sets the addresses for this machine to all of the
@@ -6,8 +6,10 @@ package machiner_test
 import (
 	"io/ioutil"
 	"net"
+	"os"
 	"path/filepath"
 	stdtesting "testing"
+	"time"
 
 	"github.com/juju/errors"
 	gitjujutesting "github.com/juju/testing"
@@ -56,6 +58,7 @@ func (s *MachinerSuite) SetUpTest(c *gc.C) {
 	s.PatchValue(machiner.GetObservedNetworkConfig, func(_ common.NetworkConfigSource) ([]params.NetworkConfig, error) {
 		return nil, nil
 	})
+	s.PatchValue(machiner.CloudInitBootFinished, filepath.Join(c.MkDir(), "boot-finished"))
 }
 
 func (s *MachinerSuite) TestMachinerConfigValidate(c *gc.C) {
@@ -373,6 +376,36 @@ func (s *MachinerSuite) TestSetMachineAddressesEmpty(c *gc.C) {
 	s.accessor.machine.CheckCallNames(c, "SetStatus", "Watch")
 }
 
+func (s *MachinerSuite) TestRecordsCloudInitFinished(c *gc.C) {
+	s.addresses = []net.Addr{}
+	err := ioutil.WriteFile(*machiner.CloudInitBootFinished, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	finished := time.Date(2020, 4, 1, 10, 0, 0, 0, time.UTC)
+	err = os.Chtimes(*machiner.CloudInitBootFinished, finished, finished)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mr := s.makeMachiner(c, false)
+	c.Assert(stopWorker(mr), jc.ErrorIsNil)
+	s.accessor.machine.CheckCallNames(c, "SetStatus", "RecordProvisioningPhase", "Watch")
+	call := s.accessor.machine.Calls()[1]
+	c.Assert(call.Args[0], gc.Equals, status.PhaseCloudInitDone)
+	c.Assert(call.Args[1].(time.Time).Equal(finished), jc.IsTrue)
+}
+
+func (s *MachinerSuite) TestRecordCloudInitFinishedNotSupported(c *gc.C) {
+	s.addresses = []net.Addr{}
+	err := ioutil.WriteFile(*machiner.CloudInitBootFinished, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.accessor.machine.SetErrors(
+		nil, // SetStatus
+		errors.NotSupportedf("recording provisioning phases"),
+	)
+
+	mr := s.makeMachiner(c, false)
+	c.Assert(stopWorker(mr), jc.ErrorIsNil)
+	s.accessor.machine.CheckCallNames(c, "SetStatus", "RecordProvisioningPhase", "Watch")
+}
+
 func (s *MachinerSuite) TestMachineAddressesWithClearFlag(c *gc.C) {
 	mr := s.makeMachiner(c, true)
 	c.Assert(stopWorker(mr), jc.ErrorIsNil)
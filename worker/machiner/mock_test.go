@@ -4,6 +4,8 @@
 package machiner_test
 
 import (
+	"time"
+
 	gitjujutesting "github.com/juju/testing"
 	"gopkg.in/juju/names.v3"
 
@@ -66,6 +68,11 @@ func (m *mockMachine) SetStatus(status status.Status, info string, data map[stri
 	return m.NextErr()
 }
 
+func (m *mockMachine) RecordProvisioningPhase(phase status.ProvisioningPhase, when time.Time) error {
+	m.MethodCall(m, "RecordProvisioningPhase", phase, when)
+	return m.NextErr()
+}
+
 func (m *mockMachine) Watch() (watcher.NotifyWatcher, error) {
 	m.MethodCall(m, "Watch")
 	if err := m.NextErr(); err != nil {
@@ -3,6 +3,8 @@
 package machiner
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v3"
 
@@ -26,6 +28,7 @@ type Machine interface {
 	SetStatus(machineStatus status.Status, info string, data map[string]interface{}) error
 	Watch() (watcher.NotifyWatcher, error)
 	SetObservedNetworkConfig(netConfig []params.NetworkConfig) error
+	RecordProvisioningPhase(phase status.ProvisioningPhase, when time.Time) error
 }
 
 type APIMachineAccessor struct {
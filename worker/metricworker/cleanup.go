@@ -6,6 +6,7 @@ package metricworker
 import (
 	"time"
 
+	"github.com/juju/clock"
 	"gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/api/metricsmanager"
@@ -15,7 +16,7 @@ import (
 const cleanupPeriod = time.Hour
 
 // NewCleanup creates a new periodic worker that calls the CleanupOldMetrics api.
-func newCleanup(client metricsmanager.MetricsManagerClient, notify chan string, logger Logger) worker.Worker {
+func newCleanup(client metricsmanager.MetricsManagerClient, clock clock.Clock, notify chan string, logger Logger) worker.Worker {
 	f := func(stopCh <-chan struct{}) error {
 		err := client.CleanupOldMetrics()
 		if err != nil {
@@ -28,5 +29,5 @@ func newCleanup(client metricsmanager.MetricsManagerClient, notify chan string,
 		}
 		return nil
 	}
-	return jworker.NewPeriodicWorker(f, cleanupPeriod, jworker.NewTimer)
+	return jworker.NewPeriodicWorker(f, cleanupPeriod, jworker.NewClockTimerFunc(clock))
 }
@@ -23,8 +23,10 @@ var _ = gc.Suite(&CleanupSuite{})
 func (s *CleanupSuite) TestCleaner(c *gc.C) {
 	notify := make(chan string, 1)
 	var client mockClient
-	worker := metricworker.NewCleanup(&client, notify, loggo.GetLogger("test"))
+	clock := coretesting.NewSimulatedClock(time.Time{})
+	worker := metricworker.NewCleanup(&client, clock, notify, loggo.GetLogger("test"))
 	defer worker.Kill()
+	clock.Step(c, 0, 1)
 	select {
 	case <-notify:
 	case <-time.After(coretesting.LongWait):
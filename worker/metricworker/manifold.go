@@ -4,6 +4,7 @@
 package metricworker
 
 import (
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/dependency"
@@ -21,6 +22,7 @@ type Logger interface {
 // ManifoldConfig describes the resources used by metrics workers.
 type ManifoldConfig struct {
 	APICallerName string
+	Clock         clock.Clock
 	Logger        Logger
 }
 
@@ -36,11 +38,14 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 
 // start creates a runner for the metrics workers, given a base.APICaller.
 func (c *ManifoldConfig) start(apiCaller base.APICaller) (worker.Worker, error) {
+	if c.Clock == nil {
+		return nil, errors.NotValidf("nil Clock")
+	}
 	client, err := metricsmanager.NewClient(apiCaller)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	w, err := newMetricsManager(client, nil, c.Logger)
+	w, err := newMetricsManager(client, c.Clock, nil, c.Logger)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
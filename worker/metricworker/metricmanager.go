@@ -4,6 +4,7 @@
 package metricworker
 
 import (
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"gopkg.in/juju/worker.v1"
 
@@ -12,7 +13,7 @@ import (
 )
 
 // NewMetricsManager creates a runner that will run the metricsmanagement workers.
-func newMetricsManager(client metricsmanager.MetricsManagerClient, notify chan string, logger Logger) (*worker.Runner, error) {
+func newMetricsManager(client metricsmanager.MetricsManagerClient, clock clock.Clock, notify chan string, logger Logger) (*worker.Runner, error) {
 	// TODO(fwereade): break this out into separate manifolds (with their own facades).
 
 	// Periodic workers automatically retry so none should return an error. If they do
@@ -23,9 +24,10 @@ func newMetricsManager(client metricsmanager.MetricsManagerClient, notify chan s
 	runner := worker.NewRunner(worker.RunnerParams{
 		IsFatal:      isFatal,
 		RestartDelay: jworker.RestartDelay,
+		Clock:        clock,
 	})
 	err := runner.StartWorker("sender", func() (worker.Worker, error) {
-		return newSender(client, notify, logger), nil
+		return newSender(client, clock, notify, logger), nil
 	})
 
 	if err != nil {
@@ -33,7 +35,7 @@ func newMetricsManager(client metricsmanager.MetricsManagerClient, notify chan s
 	}
 
 	err = runner.StartWorker("cleanup", func() (worker.Worker, error) {
-		return newCleanup(client, notify, logger), nil
+		return newCleanup(client, clock, notify, logger), nil
 	})
 	if err != nil {
 		return nil, errors.Trace(err)
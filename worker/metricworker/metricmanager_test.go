@@ -9,6 +9,7 @@ import (
 	"github.com/juju/loggo"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
 
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/metricworker"
@@ -21,8 +22,10 @@ var _ = gc.Suite(&MetricManagerSuite{})
 func (s *MetricManagerSuite) TestRunner(c *gc.C) {
 	notify := make(chan string, 2)
 	var client mockClient
-	_, err := metricworker.NewMetricsManager(&client, notify, loggo.GetLogger("test"))
+	clock := coretesting.NewSimulatedClock(time.Time{})
+	_, err := metricworker.NewMetricsManager(&client, clock, notify, loggo.GetLogger("test"))
 	c.Assert(err, jc.ErrorIsNil)
+	clock.Step(c, 0, 2)
 	expectedCalls := map[string]bool{}
 	for i := 0; i < 2; i++ {
 		select {
@@ -36,3 +39,30 @@ func (s *MetricManagerSuite) TestRunner(c *gc.C) {
 	c.Check(expectedCalls["senderCalled"], jc.IsTrue)
 	c.Check(expectedCalls["cleanupCalled"], jc.IsTrue)
 }
+
+func (s *MetricManagerSuite) TestRunnerPeriods(c *gc.C) {
+	notify := make(chan string, 20)
+	var client mockClient
+	clock := coretesting.NewSimulatedClock(time.Time{})
+	runner, err := metricworker.NewMetricsManager(&client, clock, notify, loggo.GetLogger("test"))
+	c.Assert(err, jc.ErrorIsNil)
+	defer worker.Stop(runner)
+
+	// Both workers run straight away, and then the sender runs every
+	// 5 minutes and the cleanup every hour.
+	clock.Step(c, 0, 2)
+	clock.Run(c, time.Hour, 5*time.Minute, 2)
+	calls := make(map[string]int)
+	for calls["senderCalled"] < 13 || calls["cleanupCalled"] < 2 {
+		select {
+		case call := <-notify:
+			calls[call]++
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for the workers to run; got %v", calls)
+		}
+	}
+	c.Assert(calls, jc.DeepEquals, map[string]int{
+		"senderCalled":  13,
+		"cleanupCalled": 2,
+	})
+}
@@ -6,6 +6,7 @@ package metricworker
 import (
 	"time"
 
+	"github.com/juju/clock"
 	"gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/api/metricsmanager"
@@ -16,7 +17,7 @@ const senderPeriod = 5 * time.Minute
 
 // NewSender creates a new periodic worker that sends metrics
 // to a collection service.
-func newSender(client metricsmanager.MetricsManagerClient, notify chan string, logger Logger) worker.Worker {
+func newSender(client metricsmanager.MetricsManagerClient, clock clock.Clock, notify chan string, logger Logger) worker.Worker {
 	f := func(stopCh <-chan struct{}) error {
 		err := client.SendMetrics()
 		if err != nil {
@@ -29,5 +30,5 @@ func newSender(client metricsmanager.MetricsManagerClient, notify chan string, l
 		}
 		return nil
 	}
-	return jworker.NewPeriodicWorker(f, senderPeriod, jworker.NewTimer)
+	return jworker.NewPeriodicWorker(f, senderPeriod, jworker.NewClockTimerFunc(clock))
 }
@@ -23,7 +23,9 @@ var _ = gc.Suite(&SenderSuite{})
 func (s *SenderSuite) TestSender(c *gc.C) {
 	notify := make(chan string, 1)
 	var client mockClient
-	worker := metricworker.NewSender(&client, notify, loggo.GetLogger("test"))
+	clock := coretesting.NewSimulatedClock(time.Time{})
+	worker := metricworker.NewSender(&client, clock, notify, loggo.GetLogger("test"))
+	clock.Step(c, 0, 1)
 	select {
 	case <-notify:
 	case <-time.After(coretesting.LongWait):
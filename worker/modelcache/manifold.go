@@ -89,7 +89,7 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 	w, err := config.NewWorker(Config{
 		InitializedGate:      unlocker,
 		Logger:               config.Logger,
-		WatcherFactory:       func() BackingWatcher { return pool.SystemState().WatchAllModels(pool) },
+		WatcherFactory:       func() BackingWatcher { return pool.SystemState().WatchAllModelsForCache(pool) },
 		PrometheusRegisterer: config.PrometheusRegisterer,
 		Cleanup:              func() { _ = stTracker.Done() },
 	}.WithDefaultRestartStrategy())
@@ -328,6 +328,12 @@ func (c *cacheWorker) translate(d params.Delta) interface{} {
 		// Generation deltas are processed as cache branch changes,
 		// as only "in-flight" branches should ever be in the cache.
 		return c.translateBranch(d)
+	case "relation":
+		return c.translateRelation(d)
+	case "applicationOffer":
+		return c.translateOffer(d)
+	case "storageInstance":
+		return c.translateStorageInstance(d)
 	default:
 		return nil
 	}
@@ -388,6 +394,8 @@ func (c *cacheWorker) translateApplication(d params.Delta) interface{} {
 		Subordinate:     value.Subordinate,
 		Status:          coreStatus(value.Status),
 		WorkloadVersion: value.WorkloadVersion,
+
+		StatusExplicitlySet: value.StatusExplicitlySet,
 	}
 }
 
@@ -413,6 +421,8 @@ func (c *cacheWorker) translateMachine(d params.Delta) interface{} {
 		Id:                       value.Id,
 		InstanceId:               value.InstanceId,
 		AgentStatus:              coreStatus(value.AgentStatus),
+		AgentVersion:             value.AgentStatus.Version,
+		InstanceStatus:           coreStatus(value.InstanceStatus),
 		Life:                     life.Value(value.Life),
 		Config:                   value.Config,
 		Series:                   value.Series,
@@ -421,6 +431,7 @@ func (c *cacheWorker) translateMachine(d params.Delta) interface{} {
 		SupportedContainersKnown: value.SupportedContainersKnown,
 		HardwareCharacteristics:  value.HardwareCharacteristics,
 		CharmProfiles:            value.CharmProfiles,
+		Jobs:                     value.Jobs,
 		Addresses:                providerAddresses(value.Addresses),
 		HasVote:                  value.HasVote,
 		WantsVote:                value.WantsVote,
@@ -460,6 +471,7 @@ func (c *cacheWorker) translateUnit(d params.Delta) interface{} {
 		Subordinate:    value.Subordinate,
 		WorkloadStatus: coreStatus(value.WorkloadStatus),
 		AgentStatus:    coreStatus(value.AgentStatus),
+		AgentVersion:   value.AgentStatus.Version,
 	}
 }
 
@@ -530,6 +542,102 @@ func (c *cacheWorker) translateBranch(d params.Delta) interface{} {
 	}
 }
 
+func (c *cacheWorker) translateRelation(d params.Delta) interface{} {
+	e := d.Entity
+	id := e.EntityId()
+
+	if d.Removed {
+		return cache.RemoveRelation{
+			ModelUUID: id.ModelUUID,
+			Key:       id.Id,
+		}
+	}
+
+	value, ok := e.(*params.RelationInfo)
+	if !ok {
+		c.config.Logger.Errorf("unexpected type %T", e)
+		return nil
+	}
+
+	endpoints := make([]cache.RelationEndpoint, len(value.Endpoints))
+	for i, ep := range value.Endpoints {
+		endpoints[i] = cache.RelationEndpoint{
+			Application: ep.ApplicationName,
+			Name:        ep.Relation.Name,
+			Role:        ep.Relation.Role,
+			Interface:   ep.Relation.Interface,
+			Optional:    ep.Relation.Optional,
+			Limit:       ep.Relation.Limit,
+			Scope:       ep.Relation.Scope,
+		}
+	}
+
+	return cache.RelationChange{
+		ModelUUID: value.ModelUUID,
+		Key:       value.Key,
+		Id:        value.Id,
+		Endpoints: endpoints,
+	}
+}
+
+func (c *cacheWorker) translateOffer(d params.Delta) interface{} {
+	e := d.Entity
+	id := e.EntityId()
+
+	if d.Removed {
+		return cache.RemoveOffer{
+			ModelUUID: id.ModelUUID,
+			OfferName: id.Id,
+		}
+	}
+
+	value, ok := e.(*params.ApplicationOfferInfo)
+	if !ok {
+		c.config.Logger.Errorf("unexpected type %T", e)
+		return nil
+	}
+
+	return cache.OfferChange{
+		ModelUUID:            value.ModelUUID,
+		OfferName:            value.OfferName,
+		OfferUUID:            value.OfferUUID,
+		ApplicationName:      value.ApplicationName,
+		CharmName:            value.CharmName,
+		TotalConnectedCount:  value.TotalConnectedCount,
+		ActiveConnectedCount: value.ActiveConnectedCount,
+	}
+}
+
+func (c *cacheWorker) translateStorageInstance(d params.Delta) interface{} {
+	e := d.Entity
+	id := e.EntityId()
+
+	if d.Removed {
+		return cache.RemoveStorageInstance{
+			ModelUUID: id.ModelUUID,
+			Id:        id.Id,
+		}
+	}
+
+	value, ok := e.(*params.StorageInstanceInfo)
+	if !ok {
+		c.config.Logger.Errorf("unexpected type %T", e)
+		return nil
+	}
+
+	return cache.StorageInstanceChange{
+		ModelUUID:       value.ModelUUID,
+		Id:              value.Id,
+		Kind:            value.Kind,
+		Life:            value.Life,
+		Owner:           value.Owner,
+		StorageName:     value.StorageName,
+		Pool:            value.Pool,
+		Size:            value.Size,
+		AttachmentCount: value.AttachmentCount,
+	}
+}
+
 // Kill is part of the worker.Worker interface.
 func (c *cacheWorker) Kill() {
 	c.catacomb.Kill(nil)
@@ -20,7 +20,9 @@ import (
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/cache"
 	"github.com/juju/juju/core/cache/cachetest"
+	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
 	"github.com/juju/juju/testing"
@@ -50,7 +52,7 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 		InitializedGate: s.gate,
 		Logger:          s.logger,
 		WatcherFactory: func() modelcache.BackingWatcher {
-			return s.StatePool.SystemState().WatchAllModels(s.StatePool)
+			return s.StatePool.SystemState().WatchAllModelsForCache(s.StatePool)
 		},
 		PrometheusRegisterer:   noopRegisterer{},
 		Cleanup:                func() {},
@@ -323,6 +325,7 @@ func (s *WorkerSuite) TestAddMachine(c *gc.C) {
 	obtained, ok := change.(cache.MachineChange)
 	c.Assert(ok, jc.IsTrue)
 	c.Check(obtained.Id, gc.Equals, machine.Id())
+	c.Check(obtained.Jobs, jc.DeepEquals, []model.MachineJob{model.JobHostUnits})
 
 	controller := s.getController(c, w)
 	modUUIDs := controller.ModelUUIDs()
@@ -542,6 +545,118 @@ func (s *WorkerSuite) TestRemoveBranch(c *gc.C) {
 	}
 }
 
+func (s *WorkerSuite) TestAddRelation(c *gc.C) {
+	changes := s.captureEvents(c, cachetest.RelationEvents)
+	w := s.start(c)
+
+	rel := s.Factory.MakeRelation(c, nil)
+	s.State.StartSync()
+
+	change := s.nextChange(c, changes)
+	obtained, ok := change.(cache.RelationChange)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(obtained, jc.DeepEquals, cachetest.RelationChange(s.State.ModelUUID(), rel))
+
+	controller := s.getController(c, w)
+	mod, err := controller.Model(s.State.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+
+	cachedRel, err := mod.Relation(rel.String())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cachedRel.Id(), gc.Equals, rel.Id())
+}
+
+func (s *WorkerSuite) TestRemoveRelation(c *gc.C) {
+	changes := s.captureEvents(c, cachetest.RelationEvents)
+	w := s.start(c)
+
+	rel := s.Factory.MakeRelation(c, nil)
+	s.State.StartSync()
+	_ = s.nextChange(c, changes)
+
+	controller := s.getController(c, w)
+	modUUID := s.State.ModelUUID()
+
+	// With no units in scope the relation is removed immediately.
+	c.Assert(rel.Destroy(), jc.ErrorIsNil)
+	s.State.StartSync()
+
+	for {
+		change := s.nextChange(c, changes)
+		if _, ok := change.(cache.RemoveRelation); ok {
+			mod, err := controller.Model(modUUID)
+			c.Assert(err, jc.ErrorIsNil)
+
+			_, err = mod.Relation(rel.String())
+			c.Check(errors.IsNotFound(err), jc.IsTrue)
+			return
+		}
+	}
+}
+
+func (s *WorkerSuite) TestAddOffer(c *gc.C) {
+	changes := s.captureEvents(c, cachetest.OfferEvents)
+	w := s.start(c)
+
+	s.Factory.MakeApplication(c, &factory.ApplicationParams{Name: "mysql"})
+	_, err := state.NewApplicationOffers(s.State).AddOffer(crossmodel.AddApplicationOfferArgs{
+		OfferName:       "hosted-mysql",
+		Owner:           s.Owner.Name(),
+		ApplicationName: "mysql",
+		Endpoints:       map[string]string{"server": "server"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.State.StartSync()
+
+	change := s.nextChange(c, changes)
+	obtained, ok := change.(cache.OfferChange)
+	c.Assert(ok, jc.IsTrue)
+	c.Check(obtained, jc.DeepEquals, cachetest.OfferChange(c, s.State, "hosted-mysql"))
+
+	controller := s.getController(c, w)
+	mod, err := controller.Model(s.State.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+
+	offer, err := mod.Offer("hosted-mysql")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(offer.ApplicationName(), gc.Equals, "mysql")
+}
+
+func (s *WorkerSuite) TestAddStorageInstance(c *gc.C) {
+	changes := s.captureEvents(c, cachetest.StorageInstanceEvents)
+	w := s.start(c)
+
+	app := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "storage-block"}),
+		Storage: map[string]state.StorageConstraints{
+			"data": {Pool: "loop", Size: 1024, Count: 1},
+		},
+	})
+	s.Factory.MakeUnit(c, &factory.UnitParams{Application: app})
+	s.State.StartSync()
+
+	// Wait for the change that reflects the attachment to the unit.
+	var obtained cache.StorageInstanceChange
+	for obtained.AttachmentCount == 0 {
+		change := s.nextChange(c, changes)
+		var ok bool
+		obtained, ok = change.(cache.StorageInstanceChange)
+		c.Assert(ok, jc.IsTrue)
+	}
+
+	expected := cachetest.StorageInstanceChange(c, s.State, "data/0")
+	expected.Size = 1024
+	c.Check(obtained, jc.DeepEquals, expected)
+
+	controller := s.getController(c, w)
+	mod, err := controller.Model(s.State.ModelUUID())
+	c.Assert(err, jc.ErrorIsNil)
+
+	si, err := mod.StorageInstance("data/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(si.Pool(), gc.Equals, "loop")
+}
+
 func (s *WorkerSuite) TestWatcherErrorCacheMarkSweep(c *gc.C) {
 	// Some state to close over.
 	fakeModelSent := false
@@ -549,7 +664,7 @@ func (s *WorkerSuite) TestWatcherErrorCacheMarkSweep(c *gc.C) {
 
 	s.config.WatcherFactory = func() modelcache.BackingWatcher {
 		return testingMultiwatcher{
-			Multiwatcher: s.StatePool.SystemState().WatchAllModels(s.StatePool),
+			Multiwatcher: s.StatePool.SystemState().WatchAllModelsForCache(s.StatePool),
 			manipulate: func(deltas []params.Delta) ([]params.Delta, error) {
 				if !fakeModelSent || !errorSent {
 					for _, delta := range deltas {
@@ -629,7 +744,7 @@ func (s *WorkerSuite) TestWatcherErrorRestartBackoff(c *gc.C) {
 	var errCount int
 	s.config.WatcherFactory = func() modelcache.BackingWatcher {
 		return testingMultiwatcher{
-			Multiwatcher: s.StatePool.SystemState().WatchAllModels(s.StatePool),
+			Multiwatcher: s.StatePool.SystemState().WatchAllModelsForCache(s.StatePool),
 			manipulate: func(deltas []params.Delta) ([]params.Delta, error) {
 				if errCount < maxErrors {
 					errCount++
@@ -670,7 +785,7 @@ func (s *WorkerSuite) TestWatcherErrorRestartBackoff(c *gc.C) {
 }
 
 func (s *WorkerSuite) TestWatcherErrorStoppedKillsWorker(c *gc.C) {
-	mw := s.StatePool.SystemState().WatchAllModels(s.StatePool)
+	mw := s.StatePool.SystemState().WatchAllModelsForCache(s.StatePool)
 	s.config.WatcherFactory = func() modelcache.BackingWatcher { return mw }
 
 	config := s.config
@@ -0,0 +1,69 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelcost
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricsNamespace = "juju"
+	metricsSubsystem = "model_cost"
+)
+
+// Collector is a prometheus.Collector that exposes the estimated cost
+// of running each model, for chargeback on shared controllers.
+type Collector struct {
+	hourly         *prometheus.GaugeVec
+	estimateErrors prometheus.Counter
+}
+
+// NewCollector returns a new Collector.
+func NewCollector() *Collector {
+	return &Collector{
+		hourly: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "hourly",
+			Help:      "The estimated hourly cost of running the model's resources, in the currency of its cloud's pricing.",
+		}, []string{"model_uuid", "model_name", "owner", "cloud", "currency"}),
+		estimateErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "estimate_errors_total",
+			Help:      "The number of times a model's cost could not be estimated.",
+		}),
+	}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.hourly.Describe(ch)
+	c.estimateErrors.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.hourly.Collect(ch)
+	c.estimateErrors.Collect(ch)
+}
+
+// modelCost is the estimated cost of running a model.
+type modelCost struct {
+	uuid     string
+	name     string
+	owner    string
+	cloud    string
+	currency string
+	hourly   float64
+}
+
+// setCosts replaces the recorded model costs, so that models which
+// have been removed or whose cloud no longer has pricing are dropped.
+func (c *Collector) setCosts(costs []modelCost) {
+	c.hourly.Reset()
+	for _, m := range costs {
+		c.hourly.WithLabelValues(m.uuid, m.name, m.owner, m.cloud, m.currency).Set(m.hourly)
+	}
+}
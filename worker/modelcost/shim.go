@@ -0,0 +1,35 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelcost
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state"
+)
+
+// NewBackend returns a Backend that reads the models
+// from the state pool.
+func NewBackend(pool *state.StatePool) Backend {
+	return backendShim{pool}
+}
+
+type backendShim struct {
+	pool *state.StatePool
+}
+
+// AllModelUUIDs is part of the Backend interface.
+func (b backendShim) AllModelUUIDs() ([]string, error) {
+	return b.pool.SystemState().AllModelUUIDs()
+}
+
+// Model is part of the Backend interface.
+func (b backendShim) Model(modelUUID string) (Model, func(), error) {
+	model, helper, err := b.pool.GetModel(modelUUID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	release := func() { helper.Release() }
+	return model, release, nil
+}
@@ -0,0 +1,175 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package modelcost provides a worker that periodically estimates what
+// each model on the controller costs to run, and exposes the estimates
+// on the controller's metrics endpoint.
+package modelcost
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/core/cost"
+)
+
+// Logger represents the methods used by the worker to log details.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Warningf(string, ...interface{})
+}
+
+// Backend provides access to the models on the controller.
+type Backend interface {
+	// AllModelUUIDs returns the UUIDs of all the models
+	// on the controller.
+	AllModelUUIDs() ([]string, error)
+
+	// Model returns the model with the given UUID, and a function
+	// that must be called to release it.
+	Model(modelUUID string) (Model, func(), error)
+}
+
+// Model represents a model whose cost is estimated.
+type Model interface {
+	Name() string
+	Owner() names.UserTag
+	Cloud() string
+
+	// EstimateCost returns the estimated cost of running the
+	// model's resources, or a NotFound error if the model's cloud
+	// has no pricing.
+	EstimateCost() (cost.Estimate, error)
+}
+
+// Config holds the resources and configuration needed by the worker.
+type Config struct {
+	Backend   Backend
+	Clock     clock.Clock
+	Logger    Logger
+	Collector *Collector
+
+	// Interval is how often the costs of all the models
+	// are estimated.
+	Interval time.Duration
+}
+
+// Validate returns an error if the config cannot be used
+// to start a worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.Collector == nil {
+		return errors.NotValidf("nil Collector")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// Worker periodically estimates the costs of the models on the
+// controller.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// NewWorker returns a worker that records the estimated costs of the
+// models on the controller with the config's Collector.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	for {
+		if err := w.estimateCosts(); err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(w.config.Interval):
+		}
+	}
+}
+
+func (w *Worker) estimateCosts() error {
+	modelUUIDs, err := w.config.Backend.AllModelUUIDs()
+	if err != nil {
+		return errors.Annotate(err, "getting models")
+	}
+	var costs []modelCost
+	for _, modelUUID := range modelUUIDs {
+		modelCost, err := w.estimateCost(modelUUID)
+		if errors.IsNotFound(err) {
+			// Either the model has been removed since we listed
+			// it, or its cloud has no pricing.
+			continue
+		}
+		if err != nil {
+			// The estimates only feed the metrics, so a model whose
+			// cost can't be estimated doesn't stop the others.
+			w.config.Collector.estimateErrors.Inc()
+			w.config.Logger.Warningf("estimating cost of model %q: %v", modelUUID, err)
+			continue
+		}
+		costs = append(costs, modelCost)
+	}
+	w.config.Logger.Debugf("estimated the costs of %d models", len(costs))
+	w.config.Collector.setCosts(costs)
+	return nil
+}
+
+func (w *Worker) estimateCost(modelUUID string) (modelCost, error) {
+	model, release, err := w.config.Backend.Model(modelUUID)
+	if err != nil {
+		return modelCost{}, errors.Trace(err)
+	}
+	defer release()
+
+	estimate, err := model.EstimateCost()
+	if err != nil {
+		return modelCost{}, errors.Trace(err)
+	}
+	return modelCost{
+		uuid:     modelUUID,
+		name:     model.Name(),
+		owner:    model.Owner().Id(),
+		cloud:    model.Cloud(),
+		currency: estimate.Currency,
+		hourly:   estimate.Hourly,
+	}, nil
+}
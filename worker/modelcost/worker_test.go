@@ -0,0 +1,263 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package modelcost_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/cost"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/modelcost"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock     *testclock.Clock
+	backend   *fakeBackend
+	collector *modelcost.Collector
+	config    modelcost.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.backend = &fakeBackend{
+		models:   make(map[string]*fakeModel),
+		released: make(chan string, 10),
+	}
+	s.collector = modelcost.NewCollector()
+	s.config = modelcost.Config{
+		Backend:   s.backend,
+		Clock:     s.clock,
+		Logger:    loggo.GetLogger("test"),
+		Collector: s.collector,
+		Interval:  time.Hour,
+	}
+}
+
+func (s *WorkerSuite) addModel(uuid string, hourly float64, err error) {
+	m := &fakeModel{
+		name:     "model-" + uuid,
+		owner:    names.NewUserTag("bob"),
+		cloud:    "aws",
+		estimate: cost.Estimate{Currency: "USD", Hourly: hourly},
+		err:      err,
+	}
+	s.backend.mu.Lock()
+	s.backend.models[uuid] = m
+	s.backend.mu.Unlock()
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := modelcost.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	return w
+}
+
+// waitEstimated waits for the worker to finish estimating
+// the costs of the given number of models.
+func (s *WorkerSuite) waitEstimated(c *gc.C, count int) {
+	for i := 0; i < count; i++ {
+		select {
+		case <-s.backend.released:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for model costs to be estimated")
+		}
+	}
+}
+
+// gather returns the hourly cost gauges by model UUID, and the
+// number of estimate errors.
+func (s *WorkerSuite) gather(c *gc.C) (map[string]float64, float64) {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(s.collector)
+	families, err := registry.Gather()
+	c.Assert(err, jc.ErrorIsNil)
+	costs := make(map[string]float64)
+	var errorCount float64
+	for _, family := range families {
+		switch family.GetName() {
+		case "juju_model_cost_hourly":
+			for _, metric := range family.GetMetric() {
+				labels := make(map[string]string)
+				for _, pair := range metric.GetLabel() {
+					labels[pair.GetName()] = pair.GetValue()
+				}
+				c.Check(labels["model_name"], gc.Equals, "model-"+labels["model_uuid"])
+				c.Check(labels["owner"], gc.Equals, "bob")
+				c.Check(labels["cloud"], gc.Equals, "aws")
+				c.Check(labels["currency"], gc.Equals, "USD")
+				costs[labels["model_uuid"]] = metric.GetGauge().GetValue()
+			}
+		case "juju_model_cost_estimate_errors_total":
+			errorCount = family.GetMetric()[0].GetCounter().GetValue()
+		}
+	}
+	return costs, errorCount
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	breakers := []struct {
+		breaker func(config *modelcost.Config)
+		err     string
+	}{{
+		func(config *modelcost.Config) { config.Backend = nil },
+		"nil Backend not valid",
+	}, {
+		func(config *modelcost.Config) { config.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(config *modelcost.Config) { config.Logger = nil },
+		"nil Logger not valid",
+	}, {
+		func(config *modelcost.Config) { config.Collector = nil },
+		"nil Collector not valid",
+	}, {
+		func(config *modelcost.Config) { config.Interval = 0 },
+		"non-positive Interval not valid",
+	}}
+	for i, test := range breakers {
+		c.Logf("test %d", i)
+		config := s.config
+		test.breaker(&config)
+		_, err := modelcost.NewWorker(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WorkerSuite) TestRecordsModelCosts(c *gc.C) {
+	s.addModel("a", 0.5, nil)
+	s.addModel("b", 1.25, nil)
+	w := s.startWorker(c)
+	s.waitEstimated(c, 2)
+	workertest.CleanKill(c, w)
+
+	costs, errorCount := s.gather(c)
+	c.Check(costs, jc.DeepEquals, map[string]float64{"a": 0.5, "b": 1.25})
+	c.Check(errorCount, gc.Equals, float64(0))
+}
+
+func (s *WorkerSuite) TestSkipsModelsWithoutPricing(c *gc.C) {
+	s.backend.uuids = []string{"gone"}
+	s.addModel("a", 0.5, nil)
+	s.addModel("b", 0, errors.NotFoundf(`pricing for cloud "aws"`))
+	w := s.startWorker(c)
+	s.waitEstimated(c, 2)
+	workertest.CleanKill(c, w)
+
+	costs, errorCount := s.gather(c)
+	c.Check(costs, jc.DeepEquals, map[string]float64{"a": 0.5})
+	c.Check(errorCount, gc.Equals, float64(0))
+}
+
+func (s *WorkerSuite) TestCountsEstimateErrors(c *gc.C) {
+	s.addModel("a", 0.5, nil)
+	s.addModel("b", 0, errors.New("boom"))
+	w := s.startWorker(c)
+	s.waitEstimated(c, 2)
+	workertest.CleanKill(c, w)
+
+	costs, errorCount := s.gather(c)
+	c.Check(costs, jc.DeepEquals, map[string]float64{"a": 0.5})
+	c.Check(errorCount, gc.Equals, float64(1))
+}
+
+func (s *WorkerSuite) TestReestimatesAfterInterval(c *gc.C) {
+	s.addModel("a", 0.5, nil)
+	w := s.startWorker(c)
+	s.waitEstimated(c, 1)
+
+	// Models removed since the last pass are dropped
+	// from the metrics.
+	s.backend.mu.Lock()
+	delete(s.backend.models, "a")
+	s.backend.mu.Unlock()
+	s.addModel("c", 0.75, nil)
+	err := s.clock.WaitAdvance(time.Hour, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitEstimated(c, 1)
+	workertest.CleanKill(c, w)
+
+	costs, _ := s.gather(c)
+	c.Check(costs, jc.DeepEquals, map[string]float64{"c": 0.75})
+}
+
+func (s *WorkerSuite) TestBackendError(c *gc.C) {
+	s.backend.err = errors.New("boom")
+	w := s.startWorker(c)
+	err := workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "getting models: boom")
+}
+
+type fakeBackend struct {
+	mu       sync.Mutex
+	uuids    []string
+	models   map[string]*fakeModel
+	err      error
+	released chan string
+}
+
+func (b *fakeBackend) AllModelUUIDs() ([]string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err != nil {
+		return nil, b.err
+	}
+	uuids := append([]string(nil), b.uuids...)
+	for uuid := range b.models {
+		uuids = append(uuids, uuid)
+	}
+	return uuids, nil
+}
+
+func (b *fakeBackend) Model(uuid string) (modelcost.Model, func(), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m, ok := b.models[uuid]
+	if !ok {
+		return nil, nil, errors.NotFoundf("model %q", uuid)
+	}
+	return m, func() { b.released <- uuid }, nil
+}
+
+type fakeModel struct {
+	name     string
+	owner    names.UserTag
+	cloud    string
+	estimate cost.Estimate
+	err      error
+}
+
+func (m *fakeModel) Name() string {
+	return m.name
+}
+
+func (m *fakeModel) Owner() names.UserTag {
+	return m.owner
+}
+
+func (m *fakeModel) Cloud() string {
+	return m.cloud
+}
+
+func (m *fakeModel) EstimateCost() (cost.Estimate, error) {
+	return m.estimate, m.err
+}
@@ -0,0 +1,192 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package peergrouper
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/network"
+)
+
+// APIDNSService is the service label of the SRV records published for
+// the controllers' API servers: clients look up
+// _juju-api._tcp.<api-dns-name> to find them.
+const APIDNSService = "_juju-api._tcp"
+
+// DNSRecordSet holds all the records of one type published under a DNS
+// name.
+type DNSRecordSet struct {
+	// Name is the fully qualified name of the records, ending in a dot.
+	Name string
+
+	// Type is the type of the records: "A", "AAAA" or "SRV".
+	Type string
+
+	// TTL is the time to live of the records.
+	TTL time.Duration
+
+	// Values holds the record data in the zone file presentation
+	// format, sorted. If it is empty, any records of the type under
+	// the name are to be deleted.
+	Values []string
+}
+
+// DNSUpdater publishes DNS records.
+type DNSUpdater interface {
+	// UpdateRecords replaces each of the given record sets in DNS,
+	// deleting those with no values.
+	UpdateRecords([]DNSRecordSet) error
+}
+
+// NewDNSUpdaterFunc returns a DNSUpdater configured by the given
+// controller config.
+type NewDNSUpdaterFunc func(controller.Config) (DNSUpdater, error)
+
+var (
+	dnsUpdatersMu sync.Mutex
+	dnsUpdaters   = map[string]NewDNSUpdaterFunc{
+		NSUpdateDNSUpdater: newNSUpdateDNSUpdater,
+	}
+)
+
+// RegisterDNSUpdater registers a DNS updater which may be chosen, by
+// name, with the api-dns-updater controller config setting.
+func RegisterDNSUpdater(name string, newUpdater NewDNSUpdaterFunc) error {
+	dnsUpdatersMu.Lock()
+	defer dnsUpdatersMu.Unlock()
+	if _, ok := dnsUpdaters[name]; ok {
+		return errors.AlreadyExistsf("DNS updater %q", name)
+	}
+	dnsUpdaters[name] = newUpdater
+	return nil
+}
+
+// NewDNSUpdater returns the DNS updater named by the api-dns-updater
+// controller config setting.
+func NewDNSUpdater(config controller.Config) (DNSUpdater, error) {
+	name := config.APIDNSUpdater()
+	dnsUpdatersMu.Lock()
+	newUpdater, ok := dnsUpdaters[name]
+	dnsUpdatersMu.Unlock()
+	if !ok {
+		return nil, errors.NotFoundf("DNS updater %q", name)
+	}
+	updater, err := newUpdater(config)
+	return updater, errors.Annotatef(err, "creating DNS updater %q", name)
+}
+
+// dnsRecordKey identifies a DNSRecordSet.
+type dnsRecordKey struct {
+	name       string
+	recordType string
+}
+
+// apiDNSRecords returns the record sets which publish the addresses of
+// the given API servers, keyed by controller id, under the given name.
+//
+// The A and AAAA records under the name hold the addresses of all the
+// servers. Each server's addresses are also published under its own
+// name, controller-<id>.<name>, which is the target of the server's
+// SRV record under _juju-api._tcp.<name>. Only usable IP addresses are
+// published.
+func apiDNSRecords(
+	name string, ttl time.Duration, port int, servers map[string]network.SpaceHostPorts,
+) map[dnsRecordKey]DNSRecordSet {
+	records := make(map[dnsRecordKey]DNSRecordSet)
+	if name == "" {
+		return records
+	}
+	name = strings.ToLower(strings.TrimSuffix(name, ".")) + "."
+	add := func(name, recordType, value string) {
+		key := dnsRecordKey{name, recordType}
+		set, ok := records[key]
+		if !ok {
+			set = DNSRecordSet{Name: name, Type: recordType, TTL: ttl}
+		}
+		set.Values = append(set.Values, value)
+		records[key] = set
+	}
+	for id, hostPorts := range servers {
+		serverName := fmt.Sprintf("controller-%s.%s", id, name)
+		var published bool
+		for _, hp := range hostPorts.HostPorts().FilterUnusable().Unique() {
+			ip := net.ParseIP(hp.Host())
+			if ip == nil {
+				continue
+			}
+			recordType := "AAAA"
+			if ip.To4() != nil {
+				recordType = "A"
+			}
+			add(name, recordType, ip.String())
+			add(serverName, recordType, ip.String())
+			published = true
+		}
+		if published {
+			add(APIDNSService+"."+name, "SRV", fmt.Sprintf("0 0 %d %s", port, serverName))
+		}
+	}
+	for key, set := range records {
+		set.Values = uniqueSorted(set.Values)
+		records[key] = set
+	}
+	return records
+}
+
+// changedDNSRecords returns the record sets in records which differ
+// from those in published, with an empty set for each one published
+// which is no longer in records, sorted by name and type.
+func changedDNSRecords(published, records map[dnsRecordKey]DNSRecordSet) []DNSRecordSet {
+	var changed []DNSRecordSet
+	for key, set := range records {
+		old, ok := published[key]
+		if ok && old.TTL == set.TTL && stringsEqual(old.Values, set.Values) {
+			continue
+		}
+		changed = append(changed, set)
+	}
+	for key, set := range published {
+		if _, ok := records[key]; !ok {
+			changed = append(changed, DNSRecordSet{Name: set.Name, Type: set.Type})
+		}
+	}
+	sort.Slice(changed, func(i, j int) bool {
+		if changed[i].Name != changed[j].Name {
+			return changed[i].Name < changed[j].Name
+		}
+		return changed[i].Type < changed[j].Type
+	})
+	return changed
+}
+
+func uniqueSorted(values []string) []string {
+	sort.Strings(values)
+	unique := values[:0]
+	for i, value := range values {
+		if i == 0 || value != values[i-1] {
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
+
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
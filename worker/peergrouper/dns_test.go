@@ -0,0 +1,194 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package peergrouper
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/core/network"
+	"github.com/juju/juju/testing"
+)
+
+type dnsSuite struct {
+	testing.BaseSuite
+}
+
+var _ = gc.Suite(&dnsSuite{})
+
+func (s *dnsSuite) TestAPIDNSRecords(c *gc.C) {
+	servers := map[string]network.SpaceHostPorts{
+		"0": network.NewSpaceHostPorts(17070, "10.0.0.1", "2001:db8::1", "127.0.0.1", "controller.invalid"),
+		"1": network.NewSpaceHostPorts(17070, "10.0.0.2"),
+		// A server without IP addresses gets no SRV record.
+		"2": network.NewSpaceHostPorts(17070, "localhost"),
+	}
+	records := apiDNSRecords("API.Example.com", time.Minute, 17070, servers)
+	c.Assert(changedDNSRecords(nil, records), jc.DeepEquals, []DNSRecordSet{{
+		Name:   "_juju-api._tcp.api.example.com.",
+		Type:   "SRV",
+		TTL:    time.Minute,
+		Values: []string{"0 0 17070 controller-0.api.example.com.", "0 0 17070 controller-1.api.example.com."},
+	}, {
+		Name:   "api.example.com.",
+		Type:   "A",
+		TTL:    time.Minute,
+		Values: []string{"10.0.0.1", "10.0.0.2"},
+	}, {
+		Name:   "api.example.com.",
+		Type:   "AAAA",
+		TTL:    time.Minute,
+		Values: []string{"2001:db8::1"},
+	}, {
+		Name:   "controller-0.api.example.com.",
+		Type:   "A",
+		TTL:    time.Minute,
+		Values: []string{"10.0.0.1"},
+	}, {
+		Name:   "controller-0.api.example.com.",
+		Type:   "AAAA",
+		TTL:    time.Minute,
+		Values: []string{"2001:db8::1"},
+	}, {
+		Name:   "controller-1.api.example.com.",
+		Type:   "A",
+		TTL:    time.Minute,
+		Values: []string{"10.0.0.2"},
+	}})
+}
+
+func (s *dnsSuite) TestAPIDNSRecordsNoName(c *gc.C) {
+	servers := map[string]network.SpaceHostPorts{
+		"0": network.NewSpaceHostPorts(17070, "10.0.0.1"),
+	}
+	c.Assert(apiDNSRecords("", time.Minute, 17070, servers), gc.HasLen, 0)
+}
+
+func (s *dnsSuite) TestChangedDNSRecords(c *gc.C) {
+	published := apiDNSRecords("api.example.com", time.Minute, 17070, map[string]network.SpaceHostPorts{
+		"0": network.NewSpaceHostPorts(17070, "10.0.0.1"),
+		"1": network.NewSpaceHostPorts(17070, "10.0.0.2"),
+	})
+	c.Assert(changedDNSRecords(published, published), gc.HasLen, 0)
+
+	records := apiDNSRecords("api.example.com", time.Minute, 17070, map[string]network.SpaceHostPorts{
+		"0": network.NewSpaceHostPorts(17070, "10.0.0.1"),
+	})
+	c.Assert(changedDNSRecords(published, records), jc.DeepEquals, []DNSRecordSet{{
+		Name:   "_juju-api._tcp.api.example.com.",
+		Type:   "SRV",
+		TTL:    time.Minute,
+		Values: []string{"0 0 17070 controller-0.api.example.com."},
+	}, {
+		Name:   "api.example.com.",
+		Type:   "A",
+		TTL:    time.Minute,
+		Values: []string{"10.0.0.1"},
+	}, {
+		Name: "controller-1.api.example.com.",
+		Type: "A",
+	}})
+
+	c.Assert(changedDNSRecords(records, nil), jc.DeepEquals, []DNSRecordSet{
+		{Name: "_juju-api._tcp.api.example.com.", Type: "SRV"},
+		{Name: "api.example.com.", Type: "A"},
+		{Name: "controller-0.api.example.com.", Type: "A"},
+	})
+}
+
+func (s *dnsSuite) TestNewDNSUpdater(c *gc.C) {
+	updater, err := NewDNSUpdater(controller.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(updater, gc.FitsTypeOf, &nsupdateUpdater{})
+
+	_, err = NewDNSUpdater(controller.Config{controller.APIDNSUpdater: "carrier-pigeon"})
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `DNS updater "carrier-pigeon" not found`)
+}
+
+func (s *dnsSuite) TestRegisterDNSUpdater(c *gc.C) {
+	s.AddCleanup(func(*gc.C) {
+		dnsUpdatersMu.Lock()
+		delete(dnsUpdaters, "test")
+		dnsUpdatersMu.Unlock()
+	})
+	var recorder dnsRecorder
+	err := RegisterDNSUpdater("test", func(controller.Config) (DNSUpdater, error) {
+		return &recorder, nil
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = RegisterDNSUpdater("test", nil)
+	c.Assert(err, jc.Satisfies, errors.IsAlreadyExists)
+
+	updater, err := NewDNSUpdater(controller.Config{controller.APIDNSUpdater: "test"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(updater, gc.Equals, &recorder)
+}
+
+func (s *dnsSuite) TestNSUpdateUpdater(c *gc.C) {
+	var script string
+	var args []string
+	s.PatchValue(&runNSUpdate, func(s string, a ...string) error {
+		script, args = s, a
+		return nil
+	})
+	updater, err := NewDNSUpdater(controller.Config{
+		controller.APIDNSServer:  "ns1.example.com:5353",
+		controller.APIDNSKeyFile: "/etc/juju/dns.key",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = updater.UpdateRecords([]DNSRecordSet{{
+		Name:   "api.example.com.",
+		Type:   "A",
+		TTL:    time.Minute,
+		Values: []string{"10.0.0.1", "10.0.0.2"},
+	}, {
+		Name: "controller-1.api.example.com.",
+		Type: "A",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(args, jc.DeepEquals, []string{"-k", "/etc/juju/dns.key"})
+	c.Check(script, gc.Equals, `
+server ns1.example.com 5353
+update delete api.example.com. A
+update add api.example.com. 60 A 10.0.0.1
+update add api.example.com. 60 A 10.0.0.2
+update delete controller-1.api.example.com. A
+send
+`[1:])
+}
+
+func (s *dnsSuite) TestNSUpdateUpdaterError(c *gc.C) {
+	s.PatchValue(&runNSUpdate, func(string, ...string) error {
+		return errors.New("update failed: REFUSED")
+	})
+	updater, err := NewDNSUpdater(controller.Config{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = updater.UpdateRecords([]DNSRecordSet{{Name: "api.example.com.", Type: "A"}})
+	c.Assert(err, gc.ErrorMatches, "update failed: REFUSED")
+}
+
+func (s *dnsSuite) TestNSUpdateScriptDefaultServer(c *gc.C) {
+	script := nsupdateScript("", []DNSRecordSet{{Name: "api.example.com.", Type: "AAAA"}})
+	c.Assert(script, gc.Equals, "update delete api.example.com. AAAA\nsend\n")
+	script = nsupdateScript("ns1.example.com", nil)
+	c.Assert(script, gc.Equals, "server ns1.example.com\nsend\n")
+}
+
+// dnsRecorder is a DNSUpdater which records the record sets it is
+// asked to update.
+type dnsRecorder struct {
+	updates chan []DNSRecordSet
+}
+
+func (r *dnsRecorder) UpdateRecords(sets []DNSRecordSet) error {
+	if r.updates != nil {
+		r.updates <- sets
+	}
+	return nil
+}
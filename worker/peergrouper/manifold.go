@@ -115,6 +115,7 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		APIPort:            stateServingInfo.APIPort,
 		ControllerAPIPort:  stateServingInfo.ControllerAPIPort,
 		SupportsHA:         supportsHA,
+		NewDNSUpdater:      NewDNSUpdater,
 	})
 	if err != nil {
 		_ = stTracker.Done()
@@ -112,6 +112,10 @@ func (s *ManifoldSuite) TestStart(c *gc.C) {
 	c.Assert(args[0], gc.FitsTypeOf, peergrouper.Config{})
 	config := args[0].(peergrouper.Config)
 
+	// Functions can't be compared.
+	c.Assert(config.NewDNSUpdater, gc.NotNil)
+	config.NewDNSUpdater = nil
+
 	c.Assert(config, jc.DeepEquals, peergrouper.Config{
 		State:        peergrouper.StateShim{s.State},
 		MongoSession: peergrouper.MongoSessionShim{s.State.MongoSession()},
@@ -0,0 +1,83 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package peergrouper
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"os/exec"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/controller"
+)
+
+// NSUpdateDNSUpdater is the name of the DNS updater which sends dynamic
+// updates (RFC 2136) to a DNS server with the nsupdate tool, optionally
+// signed with the key in the api-dns-key-file.
+const NSUpdateDNSUpdater = "nsupdate"
+
+// runNSUpdate runs nsupdate with the given arguments, reading the
+// given script. It is a variable so that tests can replace it.
+var runNSUpdate = func(script string, args ...string) error {
+	cmd := exec.Command("nsupdate", args...)
+	cmd.Stdin = strings.NewReader(script)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	if err := cmd.Run(); err != nil {
+		return errors.Annotatef(err, "running nsupdate: %s", strings.TrimSpace(out.String()))
+	}
+	return nil
+}
+
+type nsupdateUpdater struct {
+	server  string
+	keyFile string
+}
+
+func newNSUpdateDNSUpdater(config controller.Config) (DNSUpdater, error) {
+	return &nsupdateUpdater{
+		server:  config.APIDNSServer(),
+		keyFile: config.APIDNSKeyFile(),
+	}, nil
+}
+
+// UpdateRecords is part of the DNSUpdater interface. All the record
+// sets are replaced in a single update, so that the server applies
+// them all or none of them.
+func (u *nsupdateUpdater) UpdateRecords(sets []DNSRecordSet) error {
+	if len(sets) == 0 {
+		return nil
+	}
+	var args []string
+	if u.keyFile != "" {
+		args = append(args, "-k", u.keyFile)
+	}
+	return errors.Trace(runNSUpdate(nsupdateScript(u.server, sets), args...))
+}
+
+// nsupdateScript returns the nsupdate commands which replace the given
+// record sets.
+func nsupdateScript(server string, sets []DNSRecordSet) string {
+	var script bytes.Buffer
+	if server != "" {
+		if host, port, err := net.SplitHostPort(server); err == nil {
+			fmt.Fprintf(&script, "server %s %s\n", host, port)
+		} else {
+			fmt.Fprintf(&script, "server %s\n", server)
+		}
+	}
+	for _, set := range sets {
+		fmt.Fprintf(&script, "update delete %s %s\n", set.Name, set.Type)
+		ttl := int(set.TTL.Seconds())
+		for _, value := range set.Values {
+			fmt.Fprintf(&script, "update add %s %d %s %s\n", set.Name, ttl, set.Type, value)
+		}
+	}
+	script.WriteString("send\n")
+	return script.String()
+}
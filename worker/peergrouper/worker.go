@@ -134,6 +134,9 @@ type pgWorker struct {
 	// It is used to detect changes since the last publish.
 	serverDetails apiserver.Details
 
+	// dnsRecords holds the API address records last published in DNS.
+	dnsRecords map[dnsRecordKey]DNSRecordSet
+
 	idleFunc func()
 }
 
@@ -155,6 +158,11 @@ type Config struct {
 	// API servers.
 	Hub Hub
 
+	// NewDNSUpdater returns the DNS updater with which the API
+	// addresses are published under the api-dns-name in the
+	// controller config. If it is nil, they aren't published.
+	NewDNSUpdater NewDNSUpdaterFunc
+
 	// UpdateNotify is called when the update channel is signalled.
 	// Used solely for test synchronization.
 	UpdateNotify func()
@@ -310,6 +318,10 @@ func (w *pgWorker) loop() error {
 			logger.Errorf("cannot write API server addresses: %v", err)
 			failed = true
 		}
+		if err := w.publishAPIDNSRecords(servers); err != nil {
+			logger.Errorf("cannot publish API server addresses in DNS: %v", err)
+			failed = true
+		}
 
 		members, err := w.updateReplicaSet()
 		if err != nil {
@@ -559,6 +571,36 @@ func (w *pgWorker) publishAPIServerDetails(
 	}
 }
 
+// publishAPIDNSRecords publishes the addresses of the given API servers
+// in DNS under the api-dns-name in the controller config, updating only
+// the records which have changed since they were last published. The
+// records are deleted when api-dns-name is cleared, or when a server
+// goes away.
+func (w *pgWorker) publishAPIDNSRecords(servers map[string]network.SpaceHostPorts) error {
+	if w.config.NewDNSUpdater == nil {
+		return nil
+	}
+	config, err := w.config.State.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	records := apiDNSRecords(config.APIDNSName(), config.APIDNSTTL(), w.config.APIPort, servers)
+	changed := changedDNSRecords(w.dnsRecords, records)
+	if len(changed) == 0 {
+		return nil
+	}
+	updater, err := w.config.NewDNSUpdater(config)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := updater.UpdateRecords(changed); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("published %d API address DNS record sets", len(changed))
+	w.dnsRecords = records
+	return nil
+}
+
 // replicaSetError holds an error returned as a result
 // of calling replicaset.Set. As this is expected to fail
 // in the normal course of things, it needs special treatment.
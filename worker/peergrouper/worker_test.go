@@ -23,6 +23,7 @@ import (
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/workertest"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/core/network"
 	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/pubsub/apiserver"
@@ -596,6 +597,60 @@ func (s *workerSuite) TestControllersPublishedWithControllerAPIPort(c *gc.C) {
 	}
 }
 
+func (s *workerSuite) TestAPIAddressesPublishedInDNS(c *gc.C) {
+	st := NewFakeState()
+	InitState(c, st, 3, testIPv4)
+	st.controllerConfig.Set(controller.Config{controller.APIDNSName: "api.example.com"})
+
+	recorder := &dnsRecorder{updates: make(chan []DNSRecordSet, 10)}
+	w := s.newWorkerWithConfig(c, Config{
+		State:              st,
+		MongoSession:       st.session,
+		APIHostPortsSetter: nopAPIHostPortsSetter{},
+		MongoPort:          mongoPort,
+		APIPort:            apiPort,
+		Hub:                s.hub,
+		SupportsHA:         true,
+		NewDNSUpdater: func(config controller.Config) (DNSUpdater, error) {
+			c.Check(config.APIDNSName(), gc.Equals, "api.example.com")
+			return recorder, nil
+		},
+	})
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case sets := <-recorder.updates:
+		c.Assert(sets, gc.HasLen, 5)
+		c.Check(sets[0], jc.DeepEquals, DNSRecordSet{
+			Name: "_juju-api._tcp.api.example.com.",
+			Type: "SRV",
+			TTL:  time.Minute,
+			Values: []string{
+				"0 0 5678 controller-10.api.example.com.",
+				"0 0 5678 controller-11.api.example.com.",
+				"0 0 5678 controller-12.api.example.com.",
+			},
+		})
+		c.Check(sets[1], jc.DeepEquals, DNSRecordSet{
+			Name:   "api.example.com.",
+			Type:   "A",
+			TTL:    time.Minute,
+			Values: []string{"0.1.2.10", "0.1.2.11", "0.1.2.12"},
+		})
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for DNS update")
+	}
+
+	// The records are only updated again when they change.
+	err := s.clock.WaitAdvance(pollInterval, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case sets := <-recorder.updates:
+		c.Fatalf("unexpected DNS update %v", sets)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
 func (s *workerSuite) TestControllersArePublishedOverHubWithNewVoters(c *gc.C) {
 	st := NewFakeState()
 	var ids []string
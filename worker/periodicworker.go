@@ -8,6 +8,7 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/juju/clock"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/tomb.v2"
 )
@@ -77,6 +78,30 @@ func NewTimer(d time.Duration) PeriodicTimer {
 	return &Timer{time.NewTimer(d)}
 }
 
+// NewClockTimerFunc returns a NewTimerFunc which makes its timers from
+// the given clock, so that a periodic worker can be driven by a test
+// clock.
+func NewClockTimerFunc(clk clock.Clock) NewTimerFunc {
+	return func(d time.Duration) PeriodicTimer {
+		return &clockTimer{clk.NewTimer(d)}
+	}
+}
+
+// clockTimer implements PeriodicTimer with a clock.Timer.
+type clockTimer struct {
+	timer clock.Timer
+}
+
+// Reset implements PeriodicTimer.
+func (t *clockTimer) Reset(d time.Duration) bool {
+	return t.timer.Reset(d)
+}
+
+// CountDown implements PeriodicTimer.
+func (t *clockTimer) CountDown() <-chan time.Time {
+	return t.timer.Chan()
+}
+
 // NewPeriodicWorker returns a worker that runs the given function continually
 // sleeping for sleepDuration in between each call, until Kill() is called
 // The stopCh argument will be closed when the worker is killed. The error returned
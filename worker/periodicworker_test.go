@@ -210,3 +210,29 @@ func (s *periodicWorkerSuite) TestCallUntilKilled(c *gc.C) {
 	w.Kill()
 	c.Assert(w.Wait(), gc.Equals, nil)
 }
+
+func (s *periodicWorkerSuite) TestClockTimer(c *gc.C) {
+	funcHasRun := make(chan struct{}, 1)
+	doWork := func(_ <-chan struct{}) error {
+		funcHasRun <- struct{}{}
+		return nil
+	}
+	clock := testing.NewSimulatedClock(time.Time{})
+	w := NewPeriodicWorker(doWork, time.Hour, NewClockTimerFunc(clock))
+	defer func() { c.Assert(worker.Stop(w), gc.IsNil) }()
+
+	for i := 0; i < 3; i++ {
+		// The first call is made straight away, and the later
+		// ones once the period has passed.
+		if i == 0 {
+			clock.Step(c, 0, 1)
+		} else {
+			clock.Step(c, time.Hour, 1)
+		}
+		select {
+		case <-funcHasRun:
+		case <-time.After(testing.LongWait):
+			c.Fatalf("The doWork function should have been called by now")
+		}
+	}
+}
@@ -37,6 +37,11 @@ type ManifoldConfig struct {
 
 	NewProvisionerFunc           func(*apiprovisioner.State, agent.Config, Logger, environs.Environ, common.CredentialAPI) (Provisioner, error)
 	NewCredentialValidatorFacade func(base.APICaller) (common.CredentialAPI, error)
+
+	// CloudRateLimiter, if set, limits the rate of the provisioner's
+	// calls to the provider. It is shared with the model's other
+	// provider-polling workers.
+	CloudRateLimiter *common.CloudRateLimiter
 }
 
 // Manifold creates a manifold that runs an environment provisioner. See the
@@ -63,6 +68,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 			if err := context.Get(config.EnvironName, &environ); err != nil {
 				return nil, errors.Trace(err)
 			}
+			environ = common.RateLimitEnviron(environ, config.CloudRateLimiter)
 
 			api := apiprovisioner.NewState(apiCaller)
 			agentConfig := agent.CurrentConfig()
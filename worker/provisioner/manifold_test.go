@@ -4,6 +4,9 @@
 package provisioner_test
 
 import (
+	"time"
+
+	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
 	"github.com/juju/testing"
@@ -23,20 +26,21 @@ import (
 
 type ManifoldSuite struct {
 	testing.IsolationSuite
-	stub testing.Stub
+	stub    testing.Stub
+	limiter *common.CloudRateLimiter
 }
 
 var _ = gc.Suite(&ManifoldSuite{})
 
 func (s *ManifoldSuite) makeManifold() dependency.Manifold {
 	fakeNewProvFunc := func(
-		*apiprovisioner.State,
-		agent.Config,
-		provisioner.Logger,
-		environs.Environ,
-		common.CredentialAPI,
+		_ *apiprovisioner.State,
+		_ agent.Config,
+		_ provisioner.Logger,
+		env environs.Environ,
+		_ common.CredentialAPI,
 	) (provisioner.Provisioner, error) {
-		s.stub.AddCall("NewProvisionerFunc")
+		s.stub.AddCall("NewProvisionerFunc", env)
 		return struct{ provisioner.Provisioner }{}, nil
 	}
 	return provisioner.Manifold(provisioner.ManifoldConfig{
@@ -46,6 +50,7 @@ func (s *ManifoldSuite) makeManifold() dependency.Manifold {
 		EnvironName:                  "environ",
 		NewProvisionerFunc:           fakeNewProvFunc,
 		NewCredentialValidatorFacade: func(base.APICaller) (common.CredentialAPI, error) { return nil, nil },
+		CloudRateLimiter:             s.limiter,
 	})
 }
 
@@ -101,6 +106,21 @@ func (s *ManifoldSuite) TestStarts(c *gc.C) {
 	s.stub.CheckCallNames(c, "NewProvisionerFunc")
 }
 
+func (s *ManifoldSuite) TestStartsRateLimited(c *gc.C) {
+	s.limiter = common.NewCloudRateLimiter(testclock.NewClock(time.Now()), 1, 1)
+	manifold := s.makeManifold()
+	environ := &struct{ environs.Environ }{}
+	w, err := manifold.Start(dt.StubContext(nil, map[string]interface{}{
+		"agent":      new(fakeAgent),
+		"api-caller": apitesting.APICallerFunc(nil),
+		"environ":    environ,
+	}))
+	c.Check(w, gc.NotNil)
+	c.Check(err, jc.ErrorIsNil)
+	s.stub.CheckCallNames(c, "NewProvisionerFunc")
+	c.Check(s.stub.Calls()[0].Args[0], gc.Not(gc.Equals), environ)
+}
+
 type fakeAgent struct {
 	agent.Agent
 }
@@ -983,9 +983,17 @@ func (task *provisionerTask) startMachines(machines []apiprovisioner.MachineProv
 }
 
 func (task *provisionerTask) setErrorStatus(message string, machine apiprovisioner.MachineProvisioner, err error) error {
+	return task.setErrorStatusWithData(message, machine, err, nil)
+}
+
+// setErrorStatusWithData is like setErrorStatus, but also records the
+// given data in the status of the machine's instance.
+func (task *provisionerTask) setErrorStatusWithData(
+	message string, machine apiprovisioner.MachineProvisioner, err error, data map[string]interface{},
+) error {
 	task.logger.Errorf(message, machine, err)
 	errForStatus := errors.Cause(err)
-	if err2 := machine.SetInstanceStatus(status.ProvisioningError, errForStatus.Error(), nil); err2 != nil {
+	if err2 := machine.SetInstanceStatus(status.ProvisioningError, errForStatus.Error(), data); err2 != nil {
 		// Something is wrong with this machine, better report it back.
 		return errors.Annotatef(err2, "cannot set error status for machine %q", machine)
 	}
@@ -1095,6 +1103,18 @@ func (task *provisionerTask) startMachine(
 	// Is rate limiting handled correctly?
 	var result *environs.StartInstanceResult
 
+	// Keep the instance type the provider reports trying, so that it
+	// can be recorded along with the reason the attempt failed.
+	var instanceType string
+	statusCallback := startInstanceParams.StatusCallback
+	startInstanceParams.StatusCallback = func(st status.Status, info string, data map[string]interface{}) error {
+		if v, ok := data[status.InstanceTypeKey].(string); ok {
+			instanceType = v
+		}
+		return statusCallback(st, info, data)
+	}
+	attempts := 0
+
 	// Attempt creating the instance "retryCount" times. If the provider
 	// supports availability zones and we're automatically distributing
 	// across the zones, then we try each zone for every attempt, or until
@@ -1111,15 +1131,22 @@ func (task *provisionerTask) startMachine(
 				machine, startInstanceParams.AvailabilityZone)
 		}
 
+		instanceType = ""
+		if cons := startInstanceParams.Constraints; cons.HasInstanceType() {
+			instanceType = *cons.InstanceType
+		}
+		attempts++
 		attemptResult, err := task.broker.StartInstance(task.cloudCallCtx, startInstanceParams)
 		if err == nil {
 			result = attemptResult
 			break
-		} else if attemptsLeft <= 0 {
+		}
+		attemptData := startAttemptData(attempts, attemptsLeft, startInstanceParams.AvailabilityZone, instanceType, err)
+		if attemptsLeft <= 0 {
 			// Set the state to error, so the machine will be skipped
 			// next time until the error is resolved.
 			task.removeMachineFromAZMap(machine)
-			return task.setErrorStatus("cannot start instance for machine %q: %v", machine, err)
+			return task.setErrorStatusWithData("cannot start instance for machine %q: %v", machine, err, attemptData)
 		}
 
 		retrying := true
@@ -1157,9 +1184,10 @@ func (task *provisionerTask) startMachine(
 			)
 			task.logger.Warningf("%s", retryMsg)
 			attemptsLeft--
+			attemptData[status.StartRetriesLeftKey] = attemptsLeft
 		}
 
-		if err3 := machine.SetInstanceStatus(status.Provisioning, retryMsg, nil); err3 != nil {
+		if err3 := machine.SetInstanceStatus(status.Provisioning, retryMsg, attemptData); err3 != nil {
 			task.logger.Warningf("failed to set instance status: %v", err3)
 		}
 
@@ -1217,6 +1245,36 @@ func (task *provisionerTask) startMachine(
 	return nil
 }
 
+// startAttemptData returns the status data recording how an attempt to
+// start a machine's instance failed.
+func startAttemptData(attempts, retriesLeft int, zone, instanceType string, err error) map[string]interface{} {
+	data := map[string]interface{}{
+		status.StartAttemptsKey:      attempts,
+		status.StartRetriesLeftKey:   retriesLeft,
+		status.ProviderErrorKey:      err.Error(),
+		status.ProviderErrorClassKey: string(providerErrorClass(zone, err)),
+	}
+	if zone != "" {
+		data[status.AvailabilityZoneKey] = zone
+	}
+	if instanceType != "" {
+		data[status.InstanceTypeKey] = instanceType
+	}
+	return data
+}
+
+// providerErrorClass classifies an error returned by the provider when
+// starting an instance in the given zone.
+func providerErrorClass(zone string, err error) status.ProviderErrorClass {
+	switch {
+	case providercommon.IsCredentialNotValid(err):
+		return status.ProviderErrorCredential
+	case zone != "" && !environs.IsAvailabilityZoneIndependent(err):
+		return status.ProviderErrorAvailabilityZone
+	}
+	return status.ProviderErrorOther
+}
+
 // gatherCharmLXDProfiles consumes the charms LXD Profiles from the different
 // sources. This includes getting the information from the broker.
 func (task *provisionerTask) gatherCharmLXDProfiles(instanceId, machineTag string, machineProfiles []string) []string {
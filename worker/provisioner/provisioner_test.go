@@ -743,6 +743,12 @@ func (s *ProvisionerSuite) TestProvisionerFailedStartInstanceWithInjectedCreatio
 	c.Check(agentStatus.Message, gc.Equals, destroyError.Error())
 	c.Check(instanceStatus.Status, gc.Equals, status.ProvisioningError)
 	c.Check(instanceStatus.Message, gc.Equals, destroyError.Error())
+	// The status data records the attempts made and what the
+	// provider said.
+	c.Check(instanceStatus.Data[status.StartAttemptsKey], gc.Equals, 3)
+	c.Check(instanceStatus.Data[status.StartRetriesLeftKey], gc.Equals, 0)
+	c.Check(instanceStatus.Data[status.ProviderErrorKey], gc.Equals, destroyError.Error())
+	c.Check(instanceStatus.Data[status.ProviderErrorClassKey], gc.Equals, string(status.ProviderErrorOther))
 }
 
 func (s *ProvisionerSuite) TestProvisionerSucceedStartInstanceWithInjectedRetryableCreationError(c *gc.C) {
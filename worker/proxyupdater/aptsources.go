@@ -0,0 +1,235 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package proxyupdater
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	stdos "os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/os"
+	"golang.org/x/crypto/openpgp"
+
+	"github.com/juju/juju/environs/config"
+)
+
+const (
+	// aptSourceFilePrefix prefixes the names of the files written
+	// for the apt sources added from the model config, so that they
+	// can be told apart from those added by other means.
+	aptSourceFilePrefix = "juju-"
+
+	// aptSourceMarker prefixes the comment in each apt source file
+	// which records the source, as given in the model config, that
+	// the file was written for.
+	aptSourceMarker = "# juju-apt-source: "
+)
+
+// aptSourceID returns the ID used in the names of the files written for
+// the apt source.
+func aptSourceID(source string) string {
+	sum := sha256.Sum256([]byte(source))
+	return fmt.Sprintf("%x", sum[:8])
+}
+
+func (w *proxyWorker) aptSourcePath(id string) string {
+	return filepath.Join(w.config.AptSourcesDir, aptSourceFilePrefix+id+".list")
+}
+
+func (w *proxyWorker) aptKeyPath(id string) string {
+	return filepath.Join(w.config.AptKeyringDir, aptSourceFilePrefix+id+".gpg")
+}
+
+// handleAptSources brings the apt repositories added to the machine in
+// line with those in the model config. Each repository added is
+// recorded by a file in AptSourcesDir, which for a repository given as
+// a sources.list line is the one apt reads it from, and its key, if
+// given, is written to AptKeyringDir; PPAs are added with
+// add-apt-repository. The files are compared with the model config
+// each time it changes, so repositories which failed to be added or
+// removed are tried again, and those removed from the model config
+// while the agent was not running are removed.
+func (w *proxyWorker) handleAptSources(sources []config.AptSource) {
+	if os.HostOS() != os.Ubuntu {
+		w.config.Logger.Tracef("no apt sources on %v", os.HostOS())
+		return
+	}
+	if w.config.RunFunc == nil || w.config.AptSourcesDir == "" {
+		w.config.Logger.Tracef("apt sources not updated by unit agents")
+		return
+	}
+
+	applied, err := w.appliedAptSources()
+	if err != nil {
+		w.config.Logger.Warningf("unable to read applied apt sources: %v", err)
+		return
+	}
+	wanted := make(map[string]bool)
+	for _, src := range sources {
+		wanted[aptSourceID(src.Source)] = true
+	}
+
+	changed := false
+	ids := make([]string, 0, len(applied))
+	for id := range applied {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if wanted[id] {
+			continue
+		}
+		if err := w.removeAptSource(id, applied[id]); err != nil {
+			w.config.Logger.Warningf("unable to remove apt source %q: %v", applied[id], err)
+			continue
+		}
+		w.config.Logger.Infof("removed apt source %q", applied[id])
+		changed = true
+	}
+	for _, src := range sources {
+		id := aptSourceID(src.Source)
+		_, exists := applied[id]
+		added, err := w.addAptSource(id, src, exists)
+		if err != nil {
+			w.config.Logger.Warningf("unable to add apt source %q: %v", src.Source, err)
+			continue
+		}
+		if added {
+			w.config.Logger.Infof("added apt source %q", src.Source)
+			changed = true
+		}
+	}
+
+	if !changed {
+		return
+	}
+	if output, err := w.config.RunFunc(noStdIn, "apt-get", "update"); err != nil {
+		w.config.Logger.Warningf("unable to update package lists: %v, output: %q", err, output)
+	}
+}
+
+// appliedAptSources returns the apt sources recorded as added to the
+// machine, by ID.
+func (w *proxyWorker) appliedAptSources() (map[string]string, error) {
+	paths, err := filepath.Glob(filepath.Join(w.config.AptSourcesDir, aptSourceFilePrefix+"*.list"))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	applied := make(map[string]string)
+	for _, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			if source := strings.TrimPrefix(scanner.Text(), aptSourceMarker); source != scanner.Text() {
+				id := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), aptSourceFilePrefix), ".list")
+				applied[id] = source
+				break
+			}
+		}
+	}
+	return applied, nil
+}
+
+// addAptSource adds the apt source, with the given ID, to the machine
+// if it has not been already, and updates its file and key if they
+// have changed. It returns whether anything changed.
+func (w *proxyWorker) addAptSource(id string, src config.AptSource, exists bool) (bool, error) {
+	changed := false
+	keyPath := w.aptKeyPath(id)
+	if src.Key != "" {
+		key, err := dearmorKey(src.Key)
+		if err != nil {
+			return false, errors.Annotate(err, "reading key")
+		}
+		written, err := writeFileIfChanged(keyPath, key)
+		if err != nil {
+			return false, errors.Annotate(err, "writing key")
+		}
+		changed = changed || written
+	} else if err := stdos.Remove(keyPath); err == nil {
+		changed = true
+	} else if !stdos.IsNotExist(err) {
+		return false, errors.Annotate(err, "removing key")
+	}
+
+	content := "# Added by Juju from the apt-sources model config.\n" + aptSourceMarker + src.Source + "\n"
+	if src.IsPPA() {
+		// The PPA's own sources.list file and key are added by
+		// add-apt-repository; the file written here just records
+		// that it was.
+		if !exists {
+			if output, err := w.config.RunFunc(noStdIn, "add-apt-repository", "--yes", src.Source); err != nil {
+				return false, errors.Annotatef(err, "output %q", output)
+			}
+			changed = true
+		}
+	} else {
+		content += src.Source + "\n"
+	}
+	written, err := writeFileIfChanged(w.aptSourcePath(id), []byte(content))
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	return changed || written, nil
+}
+
+// removeAptSource removes the apt source with the given ID, and its
+// key, from the machine.
+func (w *proxyWorker) removeAptSource(id, source string) error {
+	if strings.HasPrefix(source, "ppa:") {
+		if output, err := w.config.RunFunc(noStdIn, "add-apt-repository", "--yes", "--remove", source); err != nil {
+			return errors.Annotatef(err, "output %q", output)
+		}
+	}
+	if err := stdos.Remove(w.aptKeyPath(id)); err != nil && !stdos.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+	// The source's file goes last, so that removing it is tried
+	// again if anything else fails.
+	if err := stdos.Remove(w.aptSourcePath(id)); err != nil && !stdos.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// dearmorKey returns the keys in the ASCII-armored key block in the
+// binary format apt reads from its trusted.gpg.d directory.
+func dearmorKey(armored string) ([]byte, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(armored))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var buf bytes.Buffer
+	for _, entity := range entities {
+		if err := entity.Serialize(&buf); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// writeFileIfChanged writes the data to the file at the given path,
+// unless it already holds it, returning whether it wrote the file.
+func writeFileIfChanged(path string, data []byte) (bool, error) {
+	existing, err := ioutil.ReadFile(path)
+	if err == nil && bytes.Equal(existing, data) {
+		return false, nil
+	} else if err != nil && !stdos.IsNotExist(err) {
+		return false, errors.Trace(err)
+	}
+	if err := ioutil.WriteFile(path, data, 0644); err != nil {
+		return false, errors.Trace(err)
+	}
+	return true, nil
+}
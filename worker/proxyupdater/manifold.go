@@ -33,6 +33,10 @@ type ManifoldConfig struct {
 	ExternalUpdate      func(proxy.Settings) error
 	InProcessUpdate     func(proxy.Settings) error
 	RunFunc             func(string, string, ...string) (string, error)
+
+	// ContainerRuntimeServices holds the names of the container
+	// runtime services whose proxy settings are kept up to date.
+	ContainerRuntimeServices []string
 }
 
 // Manifold returns a dependency manifold that runs a proxy updater worker,
@@ -50,8 +54,8 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 			if config.InProcessUpdate == nil {
 				return nil, errors.NotValidf("missing InProcessUpdate")
 			}
-			var agent agent.Agent
-			if err := context.Get(config.AgentName, &agent); err != nil {
+			var a agent.Agent
+			if err := context.Get(config.AgentName, &a); err != nil {
 				return nil, err
 			}
 			var apiCaller base.APICaller
@@ -59,7 +63,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				return nil, err
 			}
 
-			agentConfig := agent.CurrentConfig()
+			agentConfig := a.CurrentConfig()
 			proxyAPI, err := proxyupdater.NewAPI(apiCaller, agentConfig.Tag())
 			if err != nil {
 				return nil, err
@@ -74,6 +78,14 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				InProcessUpdate:     config.InProcessUpdate,
 				Logger:              config.Logger,
 				RunFunc:             config.RunFunc,
+				Overrides: func(settings proxy.Settings) proxy.Settings {
+					return agent.OverrideProxySettings(a.CurrentConfig(), settings)
+				},
+
+				ContainerRuntimeServices: config.ContainerRuntimeServices,
+				SystemdUnitDir:           "/etc/systemd/system",
+				AptSourcesDir:            "/etc/apt/sources.list.d",
+				AptKeyringDir:            "/etc/apt/trusted.gpg.d",
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
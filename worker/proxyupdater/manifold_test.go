@@ -49,6 +49,8 @@ func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 		SupportLegacyValues: true,
 		ExternalUpdate:      MakeUpdateFunc("external"),
 		InProcessUpdate:     MakeUpdateFunc("in-process"),
+
+		ContainerRuntimeServices: []string{"containerd"},
 	}
 }
 
@@ -124,6 +126,9 @@ func (s *ManifoldSuite) TestStartSuccess(c *gc.C) {
 	c.Check(dummy.config.RegistryPath, gc.Equals, `HKCU:\Software\Microsoft\Windows\CurrentVersion\Internet Settings`)
 	c.Check(dummy.config.SupportLegacyValues, jc.IsTrue)
 	c.Check(dummy.config.API, gc.NotNil)
+	c.Check(dummy.config.ContainerRuntimeServices, jc.DeepEquals, []string{"containerd"})
+	c.Check(dummy.config.SystemdUnitDir, gc.Equals, "/etc/systemd/system")
+	c.Check(dummy.config.Overrides, gc.NotNil)
 	// Checking function equality is problematic, use the errors they
 	// return.
 	c.Check(dummy.config.ExternalUpdate(proxy.Settings{}), gc.ErrorMatches, "external")
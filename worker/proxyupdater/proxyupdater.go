@@ -7,13 +7,16 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	stdos "os"
 	stdexec "os/exec"
+	"path/filepath"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/os"
 	"github.com/juju/os/series"
 	"github.com/juju/packaging/commands"
-	"github.com/juju/packaging/config"
+	pacconfig "github.com/juju/packaging/config"
 	"github.com/juju/proxy"
 	"github.com/juju/utils/exec"
 	"gopkg.in/juju/worker.v1"
@@ -33,6 +36,28 @@ type Config struct {
 	InProcessUpdate     func(proxy.Settings) error
 	RunFunc             func(string, string, ...string) (string, error)
 	Logger              Logger
+
+	// Overrides, if set, returns the proxy settings to use in the
+	// agent's own process given those of the model, so that the
+	// agent's proxy settings may be overridden without affecting
+	// those written for the rest of the machine.
+	Overrides func(proxy.Settings) proxy.Settings
+
+	// ContainerRuntimeServices holds the names of the systemd services
+	// of container runtimes, such as containerd and docker, that may be
+	// configured to use the proxy settings. When the model enables
+	// container-runtime-proxy, a drop-in file is written to
+	// SystemdUnitDir for each of them that is installed, and a running
+	// service is restarted when its drop-in changes.
+	ContainerRuntimeServices []string
+	SystemdUnitDir           string
+
+	// AptSourcesDir and AptKeyringDir hold the directories to which
+	// the apt sources added by the apt-sources model config, and
+	// their keys, are written. Apt sources are not updated if
+	// AptSourcesDir is empty.
+	AptSourcesDir string
+	AptKeyringDir string
 }
 
 // Validate ensures that all the required fields have values.
@@ -46,6 +71,12 @@ func (c *Config) Validate() error {
 	if c.Logger == nil {
 		return errors.NotValidf("missing Logger")
 	}
+	if len(c.ContainerRuntimeServices) > 0 && c.SystemdUnitDir == "" {
+		return errors.NotValidf("missing SystemdUnitDir")
+	}
+	if c.AptSourcesDir != "" && c.AptKeyringDir == "" {
+		return errors.NotValidf("missing AptKeyringDir")
+	}
 	return nil
 }
 
@@ -171,8 +202,12 @@ func (w *proxyWorker) handleProxyValues(legacyProxySettings, jujuProxySettings p
 		w.config.Logger.Debugf("applying in-process legacy proxy settings %#v", legacyProxySettings)
 	}
 
-	settings.SetEnvironmentValues()
-	if err := w.config.InProcessUpdate(settings); err != nil {
+	inProcessSettings := settings
+	if w.config.Overrides != nil {
+		inProcessSettings = w.config.Overrides(settings)
+	}
+	inProcessSettings.SetEnvironmentValues()
+	if err := w.config.InProcessUpdate(inProcessSettings); err != nil {
 		w.config.Logger.Errorf("error updating in-process proxy settings: %v", err)
 	}
 
@@ -197,6 +232,120 @@ func (w *proxyWorker) handleProxyValues(legacyProxySettings, jujuProxySettings p
 	}
 }
 
+// containerRuntimeDropIn is the name of the systemd drop-in file
+// written for each container runtime service.
+const containerRuntimeDropIn = "juju-proxy.conf"
+
+// handleContainerRuntimeProxyValues keeps juju's proxy drop-in for each
+// container runtime service up to date. Only juju's own drop-in file is
+// ever written or removed. When the model doesn't enable
+// container-runtime-proxy, any drop-ins written earlier are removed,
+// but the services aren't restarted; they drop the settings when next
+// restarted.
+func (w *proxyWorker) handleContainerRuntimeProxyValues(enabled bool, settings proxy.Settings) {
+	if len(w.config.ContainerRuntimeServices) == 0 || w.config.RunFunc == nil {
+		return
+	}
+	if !enabled {
+		w.removeContainerRuntimeProxyValues()
+		return
+	}
+	content := containerRuntimeProxyContents(settings)
+	var changed []string
+	for _, service := range w.config.ContainerRuntimeServices {
+		dir := filepath.Join(w.config.SystemdUnitDir, service+".service.d")
+		file := filepath.Join(dir, containerRuntimeDropIn)
+		existing, err := ioutil.ReadFile(file)
+		if err == nil && string(existing) == content {
+			continue
+		}
+		if stdos.IsNotExist(err) && len(settings.AsEnvironmentValues()) == 0 {
+			// Nothing to set and nothing to clear.
+			continue
+		}
+		if !w.serviceInstalled(service) {
+			w.config.Logger.Tracef("%s is not installed, not setting its proxy", service)
+			continue
+		}
+		if err := stdos.MkdirAll(dir, 0755); err != nil {
+			w.config.Logger.Errorf("error creating %s proxy drop-in directory: %v", service, err)
+			continue
+		}
+		if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+			w.config.Logger.Errorf("error writing %s proxy drop-in file: %v", service, err)
+			continue
+		}
+		w.config.Logger.Debugf("updated %s proxy settings in %s", service, file)
+		changed = append(changed, service)
+	}
+	if len(changed) == 0 {
+		return
+	}
+
+	// The running services only see the new settings once systemd
+	// has reloaded the units and the services have been restarted.
+	// Services that aren't running pick them up when next started.
+	if output, err := w.config.RunFunc(noStdIn, "systemctl", "daemon-reload"); err != nil {
+		w.config.Logger.Warningf("unable to reload systemd units: %v, output: %q", err, output)
+		return
+	}
+	for _, service := range changed {
+		output, err := w.config.RunFunc(noStdIn, "systemctl", "try-restart", service+".service")
+		if err != nil {
+			w.config.Logger.Warningf("unable to restart %s: %v, output: %q", service, err, output)
+		}
+	}
+}
+
+// removeContainerRuntimeProxyValues removes juju's proxy drop-in from
+// each container runtime service that has one.
+func (w *proxyWorker) removeContainerRuntimeProxyValues() {
+	removed := false
+	for _, service := range w.config.ContainerRuntimeServices {
+		file := filepath.Join(w.config.SystemdUnitDir, service+".service.d", containerRuntimeDropIn)
+		err := stdos.Remove(file)
+		if stdos.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			w.config.Logger.Errorf("error removing %s proxy drop-in file: %v", service, err)
+			continue
+		}
+		w.config.Logger.Infof("removed %s proxy settings; they apply until %s is restarted", service, service)
+		removed = true
+	}
+	if !removed {
+		return
+	}
+	if output, err := w.config.RunFunc(noStdIn, "systemctl", "daemon-reload"); err != nil {
+		w.config.Logger.Warningf("unable to reload systemd units: %v, output: %q", err, output)
+	}
+}
+
+// serviceInstalled reports whether systemd has a unit for the service.
+func (w *proxyWorker) serviceInstalled(service string) bool {
+	output, err := w.config.RunFunc(noStdIn, "systemctl", "show", "--property=LoadState", service+".service")
+	if err != nil {
+		w.config.Logger.Warningf("unable to check whether %s is installed: %v, output: %q", service, err, output)
+		return false
+	}
+	return strings.TrimSpace(output) == "LoadState=loaded"
+}
+
+// containerRuntimeProxyContents returns the contents of a systemd
+// drop-in file that sets the proxy environment of a service.
+func containerRuntimeProxyContents(settings proxy.Settings) string {
+	values := settings.AsEnvironmentValues()
+	if len(values) == 0 {
+		return "[Service]\n"
+	}
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("%q", value)
+	}
+	return fmt.Sprintf("[Service]\nEnvironment=%s\n", strings.Join(quoted, " "))
+}
+
 // getPackageCommander is a helper function which returns the
 // package commands implementation for the current system.
 func getPackageCommander() (commands.PackageCommander, error) {
@@ -294,7 +443,7 @@ func (w *proxyWorker) handleAptProxyValues(aptSettings proxy.Settings) error {
 
 		// Always finish with a new line.
 		content := paccmder.ProxyConfigContents(w.aptProxy) + "\n"
-		err = ioutil.WriteFile(config.AptProxyConfigFile, []byte(content), 0644)
+		err = ioutil.WriteFile(pacconfig.AptProxyConfigFile, []byte(content), 0644)
 		if err != nil {
 			// It isn't really fatal, but we should record it.
 			w.config.Logger.Errorf("error writing apt proxy config file: %v", err)
@@ -310,8 +459,17 @@ func (w *proxyWorker) onChange() error {
 	}
 
 	w.handleProxyValues(config.LegacyProxy, config.JujuProxy)
+	runtimeSettings := config.JujuProxy
+	if !runtimeSettings.HasProxySet() {
+		runtimeSettings = config.LegacyProxy
+	}
+	w.handleContainerRuntimeProxyValues(config.ContainerRuntimeProxy, runtimeSettings)
 	w.handleSnapProxyValues(config.SnapProxy, config.SnapStoreProxyId, config.SnapStoreProxyAssertions, config.SnapStoreProxyURL)
-	return w.handleAptProxyValues(config.APTProxy)
+	if err := w.handleAptProxyValues(config.APTProxy); err != nil {
+		return err
+	}
+	w.handleAptSources(config.AptSources)
+	return nil
 }
 
 // SetUp is defined on the worker.NotifyWatchHandler interface.
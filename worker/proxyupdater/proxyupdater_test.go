@@ -4,6 +4,7 @@
 package proxyupdater_test
 
 import (
+	"bytes"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -16,17 +17,21 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	jujuos "github.com/juju/os"
 	"github.com/juju/os/series"
 	"github.com/juju/packaging/commands"
 	pacconfig "github.com/juju/packaging/config"
 	"github.com/juju/proxy"
 	jc "github.com/juju/testing/checkers"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/workertest"
 
 	proxyupdaterapi "github.com/juju/juju/api/proxyupdater"
 	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs/config"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/proxyupdater"
 )
@@ -270,6 +275,22 @@ func (s *ProxyUpdaterSuite) TestInitialStateJujuProxy(c *gc.C) {
 	s.waitForFile(c, pacconfig.AptProxyConfigFile, paccmder.ProxyConfigContents(aptProxySettings)+"\n")
 }
 
+func (s *ProxyUpdaterSuite) TestInProcessOverrides(c *gc.C) {
+	proxySettings, _ := s.useJujuConfig(c)
+	s.config.Overrides = func(settings proxy.Settings) proxy.Settings {
+		settings.Http = "http agent proxy"
+		return settings
+	}
+
+	updater, err := proxyupdater.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer worker.Stop(updater)
+
+	expected := proxySettings
+	expected.Http = "http agent proxy"
+	s.waitProxySettings(c, expected)
+}
+
 func (s *ProxyUpdaterSuite) TestEnvironmentVariablesLegacyProxy(c *gc.C) {
 	setenv := func(proxy, value string) {
 		os.Setenv(proxy, value)
@@ -360,6 +381,159 @@ func (s *ProxyUpdaterSuite) TestExternalFuncCalled(c *gc.C) {
 	c.Assert(externalSettings, jc.DeepEquals, proxySettings)
 }
 
+func (s *ProxyUpdaterSuite) TestContainerRuntimeProxy(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("container runtime settings not handled on windows")
+	}
+	unitDir := c.MkDir()
+	s.config.ContainerRuntimeServices = []string{"containerd", "docker"}
+	s.config.SystemdUnitDir = unitDir
+	calls := make(chan []string, 10)
+	s.config.RunFunc = fakeSystemctl(calls, "containerd", "docker")
+	proxySettings, _ := s.useJujuConfig(c)
+	s.api.proxies.ContainerRuntimeProxy = true
+
+	updater, err := proxyupdater.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(nextCall(c, calls), jc.DeepEquals, []string{"systemctl", "show", "--property=LoadState", "containerd.service"})
+	c.Assert(nextCall(c, calls), jc.DeepEquals, []string{"systemctl", "show", "--property=LoadState", "docker.service"})
+	c.Assert(nextCall(c, calls), jc.DeepEquals, []string{"systemctl", "daemon-reload"})
+	c.Assert(nextCall(c, calls), jc.DeepEquals, []string{"systemctl", "try-restart", "containerd.service"})
+	c.Assert(nextCall(c, calls), jc.DeepEquals, []string{"systemctl", "try-restart", "docker.service"})
+	workertest.CleanKill(c, updater)
+
+	expected := "[Service]\nEnvironment="
+	for i, value := range proxySettings.AsEnvironmentValues() {
+		if i > 0 {
+			expected += " "
+		}
+		expected += `"` + value + `"`
+	}
+	expected += "\n"
+	s.waitForFile(c, filepath.Join(unitDir, "containerd.service.d", "juju-proxy.conf"), expected)
+	s.waitForFile(c, filepath.Join(unitDir, "docker.service.d", "juju-proxy.conf"), expected)
+
+	// The services aren't restarted when the settings are unchanged,
+	// even when the agent restarts.
+	updater, err = proxyupdater.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitProxySettings(c, proxySettings)
+	workertest.CleanKill(c, updater)
+	assertNoCall(c, calls)
+}
+
+func (s *ProxyUpdaterSuite) TestContainerRuntimeProxyNoneSet(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("container runtime settings not handled on windows")
+	}
+	unitDir := c.MkDir()
+	s.config.ContainerRuntimeServices = []string{"containerd"}
+	s.config.SystemdUnitDir = unitDir
+	calls := make(chan []string, 10)
+	s.config.RunFunc = fakeSystemctl(calls, "containerd")
+	s.api.proxies.ContainerRuntimeProxy = true
+
+	updater, err := proxyupdater.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitProxySettings(c, proxy.Settings{})
+	workertest.CleanKill(c, updater)
+
+	// No drop-in is needed when there is nothing to set or clear.
+	s.assertNoFile(c, filepath.Join(unitDir, "containerd.service.d", "juju-proxy.conf"))
+	assertNoCall(c, calls)
+}
+
+func (s *ProxyUpdaterSuite) TestContainerRuntimeProxyNotInstalled(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("container runtime settings not handled on windows")
+	}
+	unitDir := c.MkDir()
+	s.config.ContainerRuntimeServices = []string{"containerd", "docker"}
+	s.config.SystemdUnitDir = unitDir
+	calls := make(chan []string, 10)
+	s.config.RunFunc = fakeSystemctl(calls, "docker")
+	proxySettings, _ := s.useJujuConfig(c)
+	s.api.proxies.ContainerRuntimeProxy = true
+
+	updater, err := proxyupdater.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(nextCall(c, calls), jc.DeepEquals, []string{"systemctl", "show", "--property=LoadState", "containerd.service"})
+	c.Assert(nextCall(c, calls), jc.DeepEquals, []string{"systemctl", "show", "--property=LoadState", "docker.service"})
+	c.Assert(nextCall(c, calls), jc.DeepEquals, []string{"systemctl", "daemon-reload"})
+	c.Assert(nextCall(c, calls), jc.DeepEquals, []string{"systemctl", "try-restart", "docker.service"})
+	s.waitProxySettings(c, proxySettings)
+	workertest.CleanKill(c, updater)
+
+	s.assertNoFile(c, filepath.Join(unitDir, "containerd.service.d", "juju-proxy.conf"))
+	assertNoCall(c, calls)
+}
+
+func (s *ProxyUpdaterSuite) TestContainerRuntimeProxyDisabled(c *gc.C) {
+	if runtime.GOOS == "windows" {
+		c.Skip("container runtime settings not handled on windows")
+	}
+	unitDir := c.MkDir()
+	dropIn := filepath.Join(unitDir, "docker.service.d", "juju-proxy.conf")
+	err := os.MkdirAll(filepath.Dir(dropIn), 0755)
+	c.Assert(err, jc.ErrorIsNil)
+	err = ioutil.WriteFile(dropIn, []byte("[Service]\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	s.config.ContainerRuntimeServices = []string{"containerd", "docker"}
+	s.config.SystemdUnitDir = unitDir
+	calls := make(chan []string, 10)
+	s.config.RunFunc = fakeSystemctl(calls, "containerd", "docker")
+	proxySettings, _ := s.useJujuConfig(c)
+
+	updater, err := proxyupdater.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Drop-ins written while the model enabled container-runtime-proxy
+	// are removed, without restarting the services.
+	c.Assert(nextCall(c, calls), jc.DeepEquals, []string{"systemctl", "daemon-reload"})
+	s.waitProxySettings(c, proxySettings)
+	workertest.CleanKill(c, updater)
+
+	s.assertNoFile(c, dropIn)
+	s.assertNoFile(c, filepath.Join(unitDir, "containerd.service.d", "juju-proxy.conf"))
+	assertNoCall(c, calls)
+}
+
+// fakeSystemctl returns a RunFunc that records the systemctl calls
+// made, and reports only the given services as installed.
+func fakeSystemctl(calls chan<- []string, installed ...string) func(string, string, ...string) (string, error) {
+	return func(in string, cmd string, args ...string) (string, error) {
+		if cmd != "systemctl" {
+			return "", nil
+		}
+		calls <- append([]string{cmd}, args...)
+		if len(args) == 3 && args[0] == "show" {
+			for _, service := range installed {
+				if args[2] == service+".service" {
+					return "LoadState=loaded\n", nil
+				}
+			}
+			return "LoadState=not-found\n", nil
+		}
+		return "", nil
+	}
+}
+
+func assertNoCall(c *gc.C, calls <-chan []string) {
+	select {
+	case call := <-calls:
+		c.Fatalf("unexpected call %v", call)
+	default:
+	}
+}
+
+func (s *ProxyUpdaterSuite) TestContainerRuntimeProxyMissingUnitDir(c *gc.C) {
+	s.config.ContainerRuntimeServices = []string{"containerd"}
+	_, err := proxyupdater.NewWorker(s.config)
+	c.Assert(err, gc.ErrorMatches, "missing SystemdUnitDir not valid")
+}
+
 func (s *ProxyUpdaterSuite) TestErrorSettingInProcessLogs(c *gc.C) {
 	proxySettings, _ := s.useJujuConfig(c)
 
@@ -609,3 +783,139 @@ DATA...
 		"proxy.store=WhatDoesTheBigRedButtonDo",
 	})
 }
+
+// armoredKey returns a new ASCII-armored public key.
+func armoredKey(c *gc.C) string {
+	entity, err := openpgp.NewEntity("test", "", "test@example.com", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	var buf bytes.Buffer
+	w, err := armor.Encode(&buf, openpgp.PublicKeyType, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entity.Serialize(w), jc.ErrorIsNil)
+	c.Assert(w.Close(), jc.ErrorIsNil)
+	return buf.String()
+}
+
+// setUpAptSources sets up the worker to add apt sources, returning the
+// channel on which the commands it runs are sent, and the command
+// which fails.
+func (s *ProxyUpdaterSuite) setUpAptSources(c *gc.C) (<-chan []string, *string) {
+	s.config.AptSourcesDir = c.MkDir()
+	s.config.AptKeyringDir = c.MkDir()
+	logger := s.config.Logger
+	calls := make(chan []string, 10)
+	var fail string
+	s.config.RunFunc = func(in string, cmd string, args ...string) (string, error) {
+		logger.Debugf("RunFunc(%q, %q, %#v)", in, cmd, args)
+		call := append([]string{in, cmd}, args...)
+		calls <- call
+		if strings.Join(call[1:], " ") == fail {
+			return "failed", errors.New("exit status 1")
+		}
+		return "", nil
+	}
+	return calls, &fail
+}
+
+func (s *ProxyUpdaterSuite) runAptSources(c *gc.C, calls <-chan []string, sources []config.AptSource) {
+	s.api.proxies = proxyupdaterapi.ProxyConfiguration{AptSources: sources}
+	updater, err := proxyupdater.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, updater)
+	c.Assert(nextCall(c, calls), jc.DeepEquals, []string{"", "snap", "set", "core",
+		"proxy.http=",
+		"proxy.https=",
+		"proxy.store=",
+	})
+}
+
+func (s *ProxyUpdaterSuite) aptSourceFiles(c *gc.C) map[string]string {
+	files := make(map[string]string)
+	for _, dir := range []string{s.config.AptSourcesDir, s.config.AptKeyringDir} {
+		infos, err := ioutil.ReadDir(dir)
+		c.Assert(err, jc.ErrorIsNil)
+		for _, info := range infos {
+			data, err := ioutil.ReadFile(filepath.Join(dir, info.Name()))
+			c.Assert(err, jc.ErrorIsNil)
+			files[info.Name()] = string(data)
+		}
+	}
+	return files
+}
+
+func (s *ProxyUpdaterSuite) TestAptSources(c *gc.C) {
+	if jujuos.HostOS() != jujuos.Ubuntu {
+		c.Skip("apt sources only handled on ubuntu")
+	}
+	calls, _ := s.setUpAptSources(c)
+	key := armoredKey(c)
+	s.runAptSources(c, calls, []config.AptSource{{
+		Source: "ppa:juju/stable",
+	}, {
+		Source: "deb http://example.com/ubuntu bionic main",
+		Key:    key,
+	}, {
+		Source: "deb http://example.com/ubuntu bionic universe",
+		Key:    "bad key",
+	}})
+	c.Assert(nextCall(c, calls), jc.DeepEquals, []string{"", "add-apt-repository", "--yes", "ppa:juju/stable"})
+	c.Assert(nextCall(c, calls), jc.DeepEquals, []string{"", "apt-get", "update"})
+
+	// The source whose key can't be read isn't added.
+	files := s.aptSourceFiles(c)
+	c.Assert(files, gc.HasLen, 3)
+	ppa := proxyupdater.AptSourceID("ppa:juju/stable")
+	c.Check(files["juju-"+ppa+".list"], gc.Equals, "# Added by Juju from the apt-sources model config.\n"+
+		"# juju-apt-source: ppa:juju/stable\n")
+	main := proxyupdater.AptSourceID("deb http://example.com/ubuntu bionic main")
+	c.Check(files["juju-"+main+".list"], gc.Equals, "# Added by Juju from the apt-sources model config.\n"+
+		"# juju-apt-source: deb http://example.com/ubuntu bionic main\n"+
+		"deb http://example.com/ubuntu bionic main\n")
+	keyring, err := openpgp.ReadKeyRing(strings.NewReader(files["juju-"+main+".gpg"]))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(keyring, gc.HasLen, 1)
+}
+
+func (s *ProxyUpdaterSuite) TestAptSourcesReconciled(c *gc.C) {
+	if jujuos.HostOS() != jujuos.Ubuntu {
+		c.Skip("apt sources only handled on ubuntu")
+	}
+	calls, fail := s.setUpAptSources(c)
+	*fail = "add-apt-repository --yes --remove ppa:juju/stable"
+	key := armoredKey(c)
+	s.runAptSources(c, calls, []config.AptSource{{
+		Source: "ppa:juju/stable",
+	}, {
+		Source: "deb http://example.com/ubuntu bionic main",
+		Key:    key,
+	}})
+	c.Assert(nextCall(c, calls), jc.DeepEquals, []string{"", "add-apt-repository", "--yes", "ppa:juju/stable"})
+	c.Assert(nextCall(c, calls), jc.DeepEquals, []string{"", "apt-get", "update"})
+
+	// What was applied is read back when the agent restarts, so
+	// nothing is added again.
+	s.runAptSources(c, calls, []config.AptSource{{
+		Source: "ppa:juju/stable",
+	}, {
+		Source: "deb http://example.com/ubuntu bionic main",
+		Key:    key,
+	}})
+	assertNoCall(c, calls)
+
+	// Sources removed from the model config are removed with their
+	// keys; the PPA is kept to try again if removing it fails.
+	s.runAptSources(c, calls, nil)
+	c.Assert(nextCall(c, calls), jc.DeepEquals, []string{"", "add-apt-repository", "--yes", "--remove", "ppa:juju/stable"})
+	c.Assert(nextCall(c, calls), jc.DeepEquals, []string{"", "apt-get", "update"})
+	ppa := proxyupdater.AptSourceID("ppa:juju/stable")
+	c.Assert(s.aptSourceFiles(c), jc.DeepEquals, map[string]string{
+		"juju-" + ppa + ".list": "# Added by Juju from the apt-sources model config.\n" +
+			"# juju-apt-source: ppa:juju/stable\n",
+	})
+
+	*fail = ""
+	s.runAptSources(c, calls, nil)
+	c.Assert(nextCall(c, calls), jc.DeepEquals, []string{"", "add-apt-repository", "--yes", "--remove", "ppa:juju/stable"})
+	c.Assert(nextCall(c, calls), jc.DeepEquals, []string{"", "apt-get", "update"})
+	c.Assert(s.aptSourceFiles(c), gc.HasLen, 0)
+}
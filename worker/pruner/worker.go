@@ -4,12 +4,14 @@
 package pruner
 
 import (
+	"reflect"
 	"time"
 
 	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"gopkg.in/juju/worker.v1/catacomb"
 
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs/config"
 )
@@ -18,9 +20,33 @@ import (
 // Don't do this, instead pass one through as config to the worker.
 var logger interface{}
 
+// Policy describes which records a pruner removes. Each limit is
+// applied if it is non-zero.
+type Policy struct {
+	// MaxAge is the age beyond which records are removed.
+	MaxAge time.Duration
+
+	// MaxCollectionMB is the size, in MiB, the collection is pruned
+	// down to.
+	MaxCollectionMB uint
+
+	// MaxEntriesPerEntity is the number of records kept for each
+	// entity. Not every facade supports it.
+	MaxEntriesPerEntity int
+
+	// PerKind holds the limits which replace MaxAge and
+	// MaxEntriesPerEntity for the status history of particular kinds
+	// of entity. Only the status history facade supports it.
+	PerKind map[status.RetentionKind]status.HistoryRetention
+
+	// Compact, if true, collapses runs of identical records before
+	// pruning. Only the status history facade supports it.
+	Compact bool
+}
+
 // Facade represents an API that implements status history pruning.
 type Facade interface {
-	Prune(time.Duration, int) error
+	Prune(Policy) error
 	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
 	ModelConfig() (*config.Config, error)
 }
@@ -52,7 +78,7 @@ func (w *PrunerWorker) Config() *Config {
 }
 
 // Work is the main body of generic pruner loop.
-func (w *PrunerWorker) Work(getPrunerConfig func(*config.Config) (time.Duration, uint)) error {
+func (w *PrunerWorker) Work(getPolicy func(*config.Config) Policy) error {
 	modelConfigWatcher, err := w.config.Facade.WatchForModelConfigChanges()
 	if err != nil {
 		return errors.Trace(err)
@@ -63,8 +89,7 @@ func (w *PrunerWorker) Work(getPrunerConfig func(*config.Config) (time.Duration,
 	}
 
 	var (
-		maxAge             time.Duration
-		maxCollectionMB    uint
+		policy             Policy
 		modelConfigChanges = modelConfigWatcher.Changes()
 		// We will also get an initial event, but need to ensure that event is
 		// received before doing any pruning.
@@ -86,13 +111,20 @@ func (w *PrunerWorker) Work(getPrunerConfig func(*config.Config) (time.Duration,
 				return errors.Annotate(err, "cannot load model configuration")
 			}
 
-			newMaxAge, newMaxCollectionMB := getPrunerConfig(modelConfig)
-
-			if newMaxAge != maxAge || newMaxCollectionMB != maxCollectionMB {
-				w.config.Logger.Infof("status history config: max age: %v, max collection size %dM for %s (%s)",
-					newMaxAge, newMaxCollectionMB, modelConfig.Name(), modelConfig.UUID())
-				maxAge = newMaxAge
-				maxCollectionMB = newMaxCollectionMB
+			newPolicy := getPolicy(modelConfig)
+
+			if !reflect.DeepEqual(newPolicy, policy) {
+				w.config.Logger.Infof("status history config: max age: %v, max collection size %dM, max entries per entity %d for %s (%s)",
+					newPolicy.MaxAge, newPolicy.MaxCollectionMB, newPolicy.MaxEntriesPerEntity, modelConfig.Name(), modelConfig.UUID())
+				for _, kind := range status.AllRetentionKinds() {
+					if r, ok := newPolicy.PerKind[kind]; ok {
+						w.config.Logger.Infof("status history config for %s: max age: %v, max entries per entity %d", kind, r.MaxAge, r.MaxEntries)
+					}
+				}
+				if newPolicy.Compact {
+					w.config.Logger.Infof("status history compaction enabled for %s (%s)", modelConfig.Name(), modelConfig.UUID())
+				}
+				policy = newPolicy
 			}
 			if timer == nil {
 				timer = w.config.Clock.NewTimer(w.config.PruneInterval)
@@ -100,7 +132,7 @@ func (w *PrunerWorker) Work(getPrunerConfig func(*config.Config) (time.Duration,
 			}
 
 		case <-timerCh:
-			err := w.config.Facade.Prune(maxAge, int(maxCollectionMB))
+			err := w.config.Facade.Prune(policy)
 			if err != nil {
 				return errors.Trace(err)
 			}
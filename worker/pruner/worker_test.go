@@ -14,6 +14,7 @@ import (
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/worker.v1"
 
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
 	"github.com/juju/juju/environs/config"
 	coretesting "github.com/juju/juju/testing"
@@ -72,8 +73,9 @@ func (s *PrunerSuite) assertWorkerCallsPrune(c *gc.C, facade *fakeFacade, testCl
 	}
 	testClock.Advance(time.Nanosecond)
 	select {
-	case args := <-facade.pruned:
-		c.Assert(args.maxHistoryMB, gc.Equals, collectionSize)
+	case policy := <-facade.pruned:
+		c.Assert(policy.MaxAge, gc.Equals, time.Second)
+		c.Assert(policy.MaxCollectionMB, gc.Equals, uint(collectionSize))
 	case <-time.After(coretesting.LongWait):
 		c.Fatal("timed out waiting for call to Prune")
 	}
@@ -106,30 +108,112 @@ func (s *PrunerSuite) TestModelConfigChange(c *gc.C) {
 	s.assertWorkerCallsPrune(c, facade, clock, 4)
 }
 
+func (s *PrunerSuite) TestMaxEntriesPerEntity(c *gc.C) {
+	facade, clock := s.setupPruner(c)
+	s.assertWorkerCallsPrune(c, facade, clock, 3)
+
+	var err error
+	facade.modelConfig, err = facade.modelConfig.Apply(map[string]interface{}{"max-status-history-entries": 100})
+	c.Assert(err, jc.ErrorIsNil)
+	facade.changesWatcher.changes <- struct{}{}
+	select {
+	case <-facade.gotConfig:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for model config")
+	}
+
+	clock.WaitAdvance(coretesting.ShortWait, coretesting.LongWait, 1)
+	select {
+	case policy := <-facade.pruned:
+		c.Assert(policy, jc.DeepEquals, pruner.Policy{
+			MaxAge:              time.Second,
+			MaxCollectionMB:     3,
+			MaxEntriesPerEntity: 100,
+		})
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for call to Prune")
+	}
+}
+
+func (s *PrunerSuite) TestStatusHistoryRetention(c *gc.C) {
+	facade, clock := s.setupPruner(c)
+	s.assertWorkerCallsPrune(c, facade, clock, 3)
+
+	var err error
+	facade.modelConfig, err = facade.modelConfig.Apply(map[string]interface{}{
+		"status-history-retention": "machine:\n  max-age: 2160h\n",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	facade.changesWatcher.changes <- struct{}{}
+	select {
+	case <-facade.gotConfig:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for model config")
+	}
+
+	clock.WaitAdvance(coretesting.ShortWait, coretesting.LongWait, 1)
+	select {
+	case policy := <-facade.pruned:
+		c.Assert(policy, jc.DeepEquals, pruner.Policy{
+			MaxAge:          time.Second,
+			MaxCollectionMB: 3,
+			PerKind: map[status.RetentionKind]status.HistoryRetention{
+				status.RetentionMachine: {MaxAge: 2160 * time.Hour},
+			},
+		})
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for call to Prune")
+	}
+}
+
+func (s *PrunerSuite) TestCompactStatusHistory(c *gc.C) {
+	facade, clock := s.setupPruner(c)
+	s.assertWorkerCallsPrune(c, facade, clock, 3)
+
+	var err error
+	facade.modelConfig, err = facade.modelConfig.Apply(map[string]interface{}{
+		"compact-status-history": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	facade.changesWatcher.changes <- struct{}{}
+	select {
+	case <-facade.gotConfig:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for model config")
+	}
+
+	clock.WaitAdvance(coretesting.ShortWait, coretesting.LongWait, 1)
+	select {
+	case policy := <-facade.pruned:
+		c.Assert(policy, jc.DeepEquals, pruner.Policy{
+			MaxAge:          time.Second,
+			MaxCollectionMB: 3,
+			Compact:         true,
+		})
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for call to Prune")
+	}
+}
+
 type fakeFacade struct {
-	pruned         chan pruneParams
+	pruned         chan pruner.Policy
 	changesWatcher *mockNotifyWatcher
 	modelConfig    *config.Config
 	gotConfig      chan struct{}
 }
 
-type pruneParams struct {
-	maxAge       time.Duration
-	maxHistoryMB int
-}
-
 func newFakeFacade() *fakeFacade {
 	return &fakeFacade{
-		pruned:         make(chan pruneParams, 1),
+		pruned:         make(chan pruner.Policy, 1),
 		gotConfig:      make(chan struct{}, 1),
 		changesWatcher: newMockNotifyWatcher(),
 	}
 }
 
 // Prune implements Facade
-func (f *fakeFacade) Prune(maxAge time.Duration, maxHistoryMB int) error {
+func (f *fakeFacade) Prune(policy pruner.Policy) error {
 	select {
-	case f.pruned <- pruneParams{maxAge, maxHistoryMB}:
+	case f.pruned <- policy:
 	case <-time.After(coretesting.LongWait):
 		return errors.New("timed out waiting for facade call Prune to run")
 	}
@@ -9,11 +9,15 @@ import (
 	"github.com/hashicorp/raft"
 	"github.com/juju/clock"
 	"github.com/juju/errors"
+	"github.com/juju/pubsub"
 	"github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/dependency"
 
 	"github.com/juju/juju/agent"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	workerstate "github.com/juju/juju/worker/state"
 )
 
 // ManifoldConfig holds the information necessary to run a raft
@@ -22,10 +26,13 @@ type ManifoldConfig struct {
 	ClockName     string
 	AgentName     string
 	TransportName string
+	HubName       string
+	StateName     string
 
 	FSM                  raft.FSM
 	Logger               Logger
 	PrometheusRegisterer prometheus.Registerer
+	GetControllerConfig  func(*state.State) (controller.Config, error)
 	NewWorker            func(Config) (worker.Worker, error)
 }
 
@@ -40,12 +47,21 @@ func (config ManifoldConfig) Validate() error {
 	if config.TransportName == "" {
 		return errors.NotValidf("empty TransportName")
 	}
+	if config.HubName == "" {
+		return errors.NotValidf("empty HubName")
+	}
+	if config.StateName == "" {
+		return errors.NotValidf("empty StateName")
+	}
 	if config.FSM == nil {
 		return errors.NotValidf("nil FSM")
 	}
 	if config.Logger == nil {
 		return errors.NotValidf("nil Logger")
 	}
+	if config.GetControllerConfig == nil {
+		return errors.NotValidf("nil GetControllerConfig")
+	}
 	if config.NewWorker == nil {
 		return errors.NotValidf("nil NewWorker")
 	}
@@ -59,6 +75,8 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 			config.ClockName,
 			config.AgentName,
 			config.TransportName,
+			config.HubName,
+			config.StateName,
 		},
 		Start:  config.start,
 		Output: raftOutput,
@@ -84,6 +102,26 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 	if err := context.Get(config.TransportName, &transport); err != nil {
 		return nil, errors.Trace(err)
 	}
+	var hub *pubsub.StructuredHub
+	if err := context.Get(config.HubName, &hub); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var stTracker workerstate.StateTracker
+	if err := context.Get(config.StateName, &stTracker); err != nil {
+		return nil, errors.Trace(err)
+	}
+	statePool, err := stTracker.Use()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The controller config is only read once; the worker is bounced
+	// if the snapshot settings change.
+	controllerConfig, err := config.GetControllerConfig(statePool.SystemState())
+	stTracker.Done()
+	if err != nil {
+		return nil, errors.Annotate(err, "getting controller config")
+	}
 
 	// TODO(axw) make the directory path configurable, so we can
 	// potentially have multiple Rafts. The dqlite raft should go
@@ -98,10 +136,20 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		LocalID:              raft.ServerID(agentConfig.Tag().Id()),
 		Transport:            transport,
 		Clock:                clk,
+		SnapshotThreshold:    uint64(controllerConfig.RaftSnapshotThreshold()),
+		SnapshotInterval:     controllerConfig.RaftSnapshotInterval(),
+		TrailingLogs:         uint64(controllerConfig.RaftTrailingLogs()),
+		Hub:                  hub,
 		PrometheusRegisterer: config.PrometheusRegisterer,
 	})
 }
 
+// GetControllerConfig gets the controller config from the given state
+// - it's a shim so we can test the manifold without a state suite.
+func GetControllerConfig(st *state.State) (controller.Config, error) {
+	return st.ControllerConfig()
+}
+
 func raftOutput(in worker.Worker, out interface{}) error {
 	w, ok := in.(withRaftOutputs)
 	if !ok {
@@ -11,6 +11,7 @@ import (
 	"github.com/juju/clock/testclock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/pubsub"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -19,6 +20,8 @@ import (
 	"gopkg.in/juju/worker.v1/dependency"
 	dt "gopkg.in/juju/worker.v1/dependency/testing"
 
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/worker/raft"
 )
 
@@ -30,6 +33,8 @@ type ManifoldSuite struct {
 	agent     *mockAgent
 	transport *coreraft.InmemTransport
 	clock     *testclock.Clock
+	hub       *pubsub.StructuredHub
+	state     stubStateTracker
 	fsm       *raft.SimpleFSM
 	logger    loggo.Logger
 	worker    *mockRaftWorker
@@ -64,15 +69,20 @@ func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 	})
 
 	s.clock = testclock.NewClock(time.Time{})
+	s.hub = pubsub.NewStructuredHub(nil)
+	s.state = stubStateTracker{}
 
 	s.context = s.newContext(nil)
 	s.manifold = raft.Manifold(raft.ManifoldConfig{
-		ClockName:     "clock",
-		AgentName:     "agent",
-		TransportName: "transport",
-		FSM:           s.fsm,
-		Logger:        s.logger,
-		NewWorker:     s.newWorker,
+		ClockName:           "clock",
+		AgentName:           "agent",
+		TransportName:       "transport",
+		HubName:             "hub",
+		StateName:           "state",
+		FSM:                 s.fsm,
+		Logger:              s.logger,
+		GetControllerConfig: s.getControllerConfig,
+		NewWorker:           s.newWorker,
 	})
 }
 
@@ -81,6 +91,8 @@ func (s *ManifoldSuite) newContext(overlay map[string]interface{}) dependency.Co
 		"agent":     s.agent,
 		"transport": s.transport,
 		"clock":     s.clock,
+		"hub":       s.hub,
+		"state":     &s.state,
 	}
 	for k, v := range overlay {
 		resources[k] = v
@@ -96,8 +108,19 @@ func (s *ManifoldSuite) newWorker(config raft.Config) (worker.Worker, error) {
 	return s.worker, nil
 }
 
+func (s *ManifoldSuite) getControllerConfig(st *state.State) (controller.Config, error) {
+	s.stub.MethodCall(s, "GetControllerConfig", st)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	return controller.Config{
+		controller.RaftSnapshotThreshold: 1000,
+		controller.RaftSnapshotInterval:  "30s",
+	}, nil
+}
+
 var expectedInputs = []string{
-	"clock", "agent", "transport",
+	"clock", "agent", "transport", "hub", "state",
 }
 
 func (s *ManifoldSuite) TestInputs(c *gc.C) {
@@ -117,8 +140,8 @@ func (s *ManifoldSuite) TestMissingInputs(c *gc.C) {
 func (s *ManifoldSuite) TestStart(c *gc.C) {
 	s.startWorkerClean(c)
 
-	s.stub.CheckCallNames(c, "NewWorker")
-	args := s.stub.Calls()[0].Args
+	s.stub.CheckCallNames(c, "GetControllerConfig", "NewWorker")
+	args := s.stub.Calls()[1].Args
 	c.Assert(args, gc.HasLen, 1)
 	c.Assert(args[0], gc.FitsTypeOf, raft.Config{})
 	config := args[0].(raft.Config)
@@ -130,7 +153,20 @@ func (s *ManifoldSuite) TestStart(c *gc.C) {
 		LocalID:    "99",
 		Transport:  s.transport,
 		Clock:      s.clock,
+		// Settings not in the controller config have their defaults.
+		SnapshotThreshold: 1000,
+		SnapshotInterval:  30 * time.Second,
+		TrailingLogs:      10240,
+		Hub:               s.hub,
 	})
+	s.state.CheckCallNames(c, "Use", "Done")
+}
+
+func (s *ManifoldSuite) TestStartControllerConfigError(c *gc.C) {
+	s.stub.SetErrors(errors.New("boom"))
+	_, err := s.manifold.Start(s.context)
+	c.Assert(err, gc.ErrorMatches, "getting controller config: boom")
+	s.state.CheckCallNames(c, "Use", "Done")
 }
 
 func (s *ManifoldSuite) TestOutput(c *gc.C) {
@@ -5,11 +5,12 @@ package raft_test
 
 import (
 	"github.com/hashicorp/raft"
+	"github.com/juju/testing"
 	"gopkg.in/juju/names.v3"
 	"gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/agent"
-	"github.com/juju/testing"
+	"github.com/juju/juju/state"
 )
 
 type mockAgent struct {
@@ -55,3 +56,23 @@ func (r *mockRaftWorker) LogStore() (raft.LogStore, error) {
 type mockLogStore struct {
 	raft.LogStore
 }
+
+type stubStateTracker struct {
+	testing.Stub
+	pool state.StatePool
+}
+
+func (s *stubStateTracker) Use() (*state.StatePool, error) {
+	s.MethodCall(s, "Use")
+	return &s.pool, s.NextErr()
+}
+
+func (s *stubStateTracker) Done() error {
+	s.MethodCall(s, "Done")
+	return s.NextErr()
+}
+
+func (s *stubStateTracker) Report() map[string]interface{} {
+	s.MethodCall(s, "Report")
+	return nil
+}
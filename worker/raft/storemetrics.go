@@ -0,0 +1,155 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package raft
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hashicorp/raft"
+	"github.com/juju/clock"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	storeMetricsNamespace = "juju"
+	storeMetricsSubsystem = "raft"
+)
+
+// storeCollector is a prometheus.Collector that exposes the size of
+// the raft log and the age of the latest snapshot, which are read when
+// the metrics are collected, and how long the FSM takes to apply each
+// log entry. Together they show whether snapshots are keeping the log
+// of the lease store compacted.
+type storeCollector struct {
+	clock      clock.Clock
+	storageDir string
+	logStore   raft.LogStore
+	snapshots  raft.SnapshotStore
+
+	logSize       *prometheus.Desc
+	logEntries    *prometheus.Desc
+	snapshotAge   *prometheus.Desc
+	applyDuration prometheus.Histogram
+}
+
+func newStoreCollector(
+	clock clock.Clock,
+	storageDir string,
+	logStore raft.LogStore,
+	snapshots raft.SnapshotStore,
+) *storeCollector {
+	return &storeCollector{
+		clock:      clock,
+		storageDir: storageDir,
+		logStore:   logStore,
+		snapshots:  snapshots,
+		logSize: prometheus.NewDesc(
+			prometheus.BuildFQName(storeMetricsNamespace, storeMetricsSubsystem, "log_size_bytes"),
+			"The size of the raft log file on disk.",
+			nil, nil,
+		),
+		logEntries: prometheus.NewDesc(
+			prometheus.BuildFQName(storeMetricsNamespace, storeMetricsSubsystem, "log_entries"),
+			"The number of entries in the raft log, which snapshots compact.",
+			nil, nil,
+		),
+		snapshotAge: prometheus.NewDesc(
+			prometheus.BuildFQName(storeMetricsNamespace, storeMetricsSubsystem, "snapshot_age_seconds"),
+			"The time since the latest raft snapshot was taken.",
+			nil, nil,
+		),
+		applyDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: storeMetricsNamespace,
+			Subsystem: storeMetricsSubsystem,
+			Name:      "fsm_apply_duration_seconds",
+			Help:      "The time taken by the FSM to apply each raft log entry.",
+			Buckets:   prometheus.ExponentialBuckets(0.0001, 2, 15),
+		}),
+	}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (c *storeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.logSize
+	ch <- c.logEntries
+	ch <- c.snapshotAge
+	c.applyDuration.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface. Values which
+// can't be read, such as once the worker has closed the log store, are
+// left out.
+func (c *storeCollector) Collect(ch chan<- prometheus.Metric) {
+	if info, err := os.Stat(filepath.Join(c.storageDir, "logs")); err == nil {
+		ch <- prometheus.MustNewConstMetric(c.logSize, prometheus.GaugeValue, float64(info.Size()))
+	}
+	if entries, ok := c.entries(); ok {
+		ch <- prometheus.MustNewConstMetric(c.logEntries, prometheus.GaugeValue, float64(entries))
+	}
+	if taken, ok := c.lastSnapshot(); ok {
+		age := c.clock.Now().Sub(taken)
+		ch <- prometheus.MustNewConstMetric(c.snapshotAge, prometheus.GaugeValue, age.Seconds())
+	}
+	c.applyDuration.Collect(ch)
+}
+
+func (c *storeCollector) entries() (uint64, bool) {
+	first, err := c.logStore.FirstIndex()
+	if err != nil {
+		return 0, false
+	}
+	last, err := c.logStore.LastIndex()
+	if err != nil {
+		return 0, false
+	}
+	if last == 0 {
+		return 0, true
+	}
+	return last - first + 1, true
+}
+
+// lastSnapshot returns the time the latest snapshot was written, from
+// the modification time of its metadata file in the file snapshot
+// store.
+func (c *storeCollector) lastSnapshot() (time.Time, bool) {
+	snapshots, err := c.snapshots.List()
+	if err != nil || len(snapshots) == 0 {
+		return time.Time{}, false
+	}
+	// The snapshots are listed newest first.
+	path := filepath.Join(c.storageDir, "snapshots", snapshots[0].ID, "meta.json")
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return info.ModTime(), true
+}
+
+// timedFSM is a raft.FSM which records how long the wrapped FSM takes
+// to apply each log entry.
+type timedFSM struct {
+	raft.FSM
+	clock    clock.Clock
+	duration prometheus.Histogram
+}
+
+// Apply is part of raft.FSM.
+func (f *timedFSM) Apply(log *raft.Log) interface{} {
+	start := f.clock.Now()
+	defer func() {
+		f.duration.Observe(f.clock.Now().Sub(start).Seconds())
+	}()
+	return f.FSM.Apply(log)
+}
+
+func registerStoreMetrics(registry prometheus.Registerer, collector *storeCollector, logger Logger) {
+	// As with the raft library metrics, any collector left registered
+	// by an earlier run of the worker is replaced; see registerMetrics.
+	registry.Unregister(collector)
+	if err := registry.Register(collector); err != nil {
+		logger.Warningf("registering raft store metrics collector failed: %v", err)
+	}
+}
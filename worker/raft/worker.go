@@ -16,10 +16,14 @@ import (
 	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/pubsub"
 	"github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/catacomb"
+	"gopkg.in/juju/worker.v1/dependency"
 
+	"github.com/juju/juju/controller"
+	controllermsg "github.com/juju/juju/pubsub/controller"
 	"github.com/juju/juju/worker/raft/raftutil"
 )
 
@@ -72,6 +76,7 @@ var (
 
 // Logger represents the logging methods called.
 type Logger interface {
+	Infof(message string, args ...interface{})
 	Warningf(message string, args ...interface{})
 	Errorf(message string, args ...interface{})
 	Tracef(message string, args ...interface{})
@@ -131,6 +136,23 @@ type Config struct {
 	// to retain on disk. If zero, defaults to 2.
 	SnapshotRetention int
 
+	// SnapshotThreshold, if non-zero, will override the default
+	// number of new log entries which causes a snapshot.
+	SnapshotThreshold uint64
+
+	// SnapshotInterval, if non-zero, will override the default
+	// interval between checks for whether a snapshot is needed.
+	SnapshotInterval time.Duration
+
+	// TrailingLogs, if non-zero, will override the default number
+	// of log entries kept after a snapshot.
+	TrailingLogs uint64
+
+	// Hub, if non-nil, is used to receive requests for snapshots,
+	// and changes to the controller config; the worker is bounced
+	// when the snapshot settings change.
+	Hub *pubsub.StructuredHub
+
 	// PrometheusRegisterer is used to register the raft metrics.
 	PrometheusRegisterer prometheus.Registerer
 }
@@ -224,6 +246,7 @@ func newWorker(config Config) (*Worker, error) {
 		config:     config,
 		raftCh:     make(chan *raft.Raft),
 		logStoreCh: make(chan raft.LogStore),
+		snapshotCh: make(chan struct{}, 1),
 	}
 	if err := catacomb.Invoke(catacomb.Plan{
 		Site: &w.catacomb,
@@ -250,6 +273,10 @@ type Worker struct {
 
 	raftCh     chan *raft.Raft
 	logStoreCh chan raft.LogStore
+
+	// snapshotCh receives requests, from the hub, to snapshot the
+	// FSM and so compact the log.
+	snapshotCh chan struct{}
 }
 
 // Raft returns the raft.Raft managed by this worker, or
@@ -318,7 +345,17 @@ func (w *Worker) loop(raftConfig *raft.Config) (loopErr error) {
 		return errors.Trace(err)
 	}
 
-	r, err := raft.NewRaft(raftConfig, w.config.FSM, logStore, rawLogStore, snapshotStore, w.config.Transport)
+	collector := newStoreCollector(w.config.Clock, w.config.StorageDir, logStore, snapshotStore)
+	if w.config.PrometheusRegisterer != nil {
+		registerStoreMetrics(w.config.PrometheusRegisterer, collector, w.config.Logger)
+	}
+	fsm := &timedFSM{
+		FSM:      w.config.FSM,
+		clock:    w.config.Clock,
+		duration: collector.applyDuration,
+	}
+
+	r, err := raft.NewRaft(raftConfig, fsm, logStore, rawLogStore, snapshotStore, w.config.Transport)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -339,6 +376,14 @@ func (w *Worker) loop(raftConfig *raft.Config) (loopErr error) {
 	r.RegisterObserver(observer)
 	defer r.DeregisterObserver(observer)
 
+	if w.config.Hub != nil {
+		unsubscribe, err := w.subscribe()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		defer unsubscribe()
+	}
+
 	// Every 10 seconds we check whether the no-leader timeout should
 	// trip.
 	noLeaderCheck := w.config.Clock.After(noLeaderFrequency)
@@ -372,12 +417,77 @@ func (w *Worker) loop(raftConfig *raft.Config) (loopErr error) {
 					humanize.Time(lastContact), w.config.NoLeaderTimeout)
 				return ErrNoLeaderTimeout
 			}
+		case <-w.snapshotCh:
+			w.snapshot(r)
 		case w.raftCh <- r:
 		case w.logStoreCh <- logStore:
 		}
 	}
 }
 
+// subscribe subscribes to requests for snapshots, and to changes to
+// the controller config, returning a func which unsubscribes from
+// both.
+func (w *Worker) subscribe() (func(), error) {
+	unsubSnapshot, err := w.config.Hub.Subscribe(controllermsg.RaftSnapshotRequested,
+		func(topic string, data controllermsg.RaftSnapshotRequestedMessage, err error) {
+			if err != nil {
+				w.config.Logger.Warningf("bad raft snapshot request: %v", err)
+				return
+			}
+			w.config.Logger.Infof("raft snapshot requested by %s", data.Requester)
+			select {
+			case w.snapshotCh <- struct{}{}:
+			default:
+				// A snapshot is already pending.
+			}
+		})
+	if err != nil {
+		return nil, errors.Annotate(err, "subscribing to raft snapshot requests")
+	}
+	unsubConfig, err := w.config.Hub.Subscribe(controllermsg.ConfigChanged,
+		func(topic string, data controllermsg.ConfigChangedMessage, err error) {
+			if err != nil {
+				w.config.Logger.Warningf("bad controller config change: %v", err)
+				return
+			}
+			if w.snapshotSettingsChanged(data.Config) {
+				w.config.Logger.Infof("raft snapshot settings changed, restarting")
+				w.catacomb.Kill(dependency.ErrBounce)
+			}
+		})
+	if err != nil {
+		unsubSnapshot()
+		return nil, errors.Annotate(err, "subscribing to controller config changes")
+	}
+	return func() {
+		unsubSnapshot()
+		unsubConfig()
+	}, nil
+}
+
+// snapshotSettingsChanged reports whether the raft snapshot settings
+// in the controller config differ from those the worker is using.
+func (w *Worker) snapshotSettingsChanged(cfg controller.Config) bool {
+	return uint64(cfg.RaftSnapshotThreshold()) != w.config.SnapshotThreshold ||
+		cfg.RaftSnapshotInterval() != w.config.SnapshotInterval ||
+		uint64(cfg.RaftTrailingLogs()) != w.config.TrailingLogs
+}
+
+// snapshot snapshots the FSM, after which raft compacts the log,
+// keeping only the trailing logs.
+func (w *Worker) snapshot(r *raft.Raft) {
+	err := r.Snapshot().Error()
+	switch {
+	case err == raft.ErrNothingNewToSnapshot:
+		w.config.Logger.Infof("raft snapshot not needed: %v", err)
+	case err != nil:
+		w.config.Logger.Warningf("raft snapshot failed: %v", err)
+	default:
+		w.config.Logger.Infof("raft snapshot taken")
+	}
+}
+
 // NewRaftConfig makes a raft config struct from the worker config
 // struct passed in.
 func NewRaftConfig(config Config) (*raft.Config, error) {
@@ -398,6 +508,13 @@ func NewRaftConfig(config Config) (*raft.Config, error) {
 	maybeOverrideDuration(config.ElectionTimeout, &raftConfig.ElectionTimeout)
 	maybeOverrideDuration(config.HeartbeatTimeout, &raftConfig.HeartbeatTimeout)
 	maybeOverrideDuration(config.LeaderLeaseTimeout, &raftConfig.LeaderLeaseTimeout)
+	maybeOverrideDuration(config.SnapshotInterval, &raftConfig.SnapshotInterval)
+	if config.SnapshotThreshold != 0 {
+		raftConfig.SnapshotThreshold = config.SnapshotThreshold
+	}
+	if config.TrailingLogs != 0 {
+		raftConfig.TrailingLogs = config.TrailingLogs
+	}
 
 	if err := raft.ValidateConfig(raftConfig); err != nil {
 		return nil, errors.Annotate(err, "validating raft config")
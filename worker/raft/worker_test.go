@@ -4,18 +4,25 @@
 package raft_test
 
 import (
+	"io/ioutil"
 	"log"
+	"path/filepath"
 	"time"
 
 	coreraft "github.com/hashicorp/raft"
 	"github.com/juju/clock"
 	"github.com/juju/clock/testclock"
 	"github.com/juju/loggo"
+	"github.com/juju/pubsub"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/dependency"
 	"gopkg.in/juju/worker.v1/workertest"
 
+	"github.com/juju/juju/controller"
+	controllermsg "github.com/juju/juju/pubsub/controller"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/raft"
 	"github.com/juju/juju/worker/raft/rafttest"
@@ -114,8 +121,10 @@ func (s *WorkerValidationSuite) TestBootstrapTransport(c *gc.C) {
 
 type WorkerSuite struct {
 	workerFixture
-	worker *raft.Worker
-	clock  *testclock.Clock
+	worker   *raft.Worker
+	clock    *testclock.Clock
+	hub      *pubsub.StructuredHub
+	registry *prometheus.Registry
 }
 
 var _ = gc.Suite(&WorkerSuite{})
@@ -143,6 +152,11 @@ func (s *WorkerSuite) SetUpTest(c *gc.C) {
 	s.config.Clock = s.clock
 	s.config.NoLeaderTimeout = 4 * time.Second
 
+	s.hub = pubsub.NewStructuredHub(nil)
+	s.config.Hub = s.hub
+	s.registry = prometheus.NewRegistry()
+	s.config.PrometheusRegisterer = s.registry
+
 	s.config.Transport = transport
 	s.config.FSM = fsm
 	worker, err := raft.NewWorker(s.config)
@@ -249,6 +263,69 @@ func (s *WorkerSuite) TestLogStore(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *WorkerSuite) publish(c *gc.C, topic string, data interface{}) {
+	done, err := s.hub.Publish(topic, data)
+	c.Assert(err, jc.ErrorIsNil)
+	select {
+	case <-done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for %q to be handled", topic)
+	}
+}
+
+func (s *WorkerSuite) TestSnapshotRequested(c *gc.C) {
+	r := s.waitLeader(c)
+	f := r.Apply([]byte("command1"), time.Minute)
+	c.Assert(f.Error(), jc.ErrorIsNil)
+
+	s.publish(c, controllermsg.RaftSnapshotRequested, controllermsg.RaftSnapshotRequestedMessage{
+		Requester: "user-admin",
+	})
+	snapshotDir := filepath.Join(s.config.StorageDir, "snapshots")
+	for a := coretesting.LongAttempt.Start(); a.Next(); {
+		infos, err := ioutil.ReadDir(snapshotDir)
+		c.Assert(err, jc.ErrorIsNil)
+		if len(infos) > 0 {
+			return
+		}
+	}
+	c.Fatal("timed out waiting for snapshot")
+}
+
+func (s *WorkerSuite) TestSnapshotSettingsChangedBounces(c *gc.C) {
+	s.waitLeader(c)
+	s.publish(c, controllermsg.ConfigChanged, controllermsg.ConfigChangedMessage{
+		Config: controller.Config{controller.RaftSnapshotThreshold: 100},
+	})
+	err := workertest.CheckKilled(c, s.worker)
+	c.Assert(err, gc.Equals, dependency.ErrBounce)
+}
+
+func (s *WorkerSuite) TestStoreMetrics(c *gc.C) {
+	r := s.waitLeader(c)
+	f := r.Apply([]byte("command1"), time.Minute)
+	c.Assert(f.Error(), jc.ErrorIsNil)
+
+	families, err := s.registry.Gather()
+	c.Assert(err, jc.ErrorIsNil)
+	values := make(map[string]float64)
+	for _, family := range families {
+		metric := family.GetMetric()[0]
+		switch {
+		case metric.GetGauge() != nil:
+			values[family.GetName()] = metric.GetGauge().GetValue()
+		case metric.GetHistogram() != nil:
+			values[family.GetName()] = float64(metric.GetHistogram().GetSampleCount())
+		}
+	}
+	c.Check(values["juju_raft_log_size_bytes"], jc.GreaterThan, 0.0)
+	c.Check(values["juju_raft_log_entries"], gc.Equals, 3.0)
+	c.Check(values["juju_raft_fsm_apply_duration_seconds"], gc.Equals, 1.0)
+	// No snapshot has been taken yet.
+	_, ok := values["juju_raft_snapshot_age_seconds"]
+	c.Check(ok, jc.IsFalse)
+}
+
 func (s *WorkerSuite) newRaft(c *gc.C, id coreraft.ServerID) (
 	*coreraft.Raft, *coreraft.InmemTransport,
 ) {
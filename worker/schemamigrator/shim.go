@@ -0,0 +1,14 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package schemamigrator
+
+import (
+	"github.com/juju/juju/state"
+)
+
+// NewBackend returns a Backend that migrates the database
+// behind the state pool.
+func NewBackend(pool *state.StatePool) Backend {
+	return pool.SystemState()
+}
@@ -0,0 +1,154 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package schemamigrator provides a worker that runs the online schema
+// migrations of the controller's database in the background, a batch at
+// a time, after the controller has been upgraded.
+package schemamigrator
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+)
+
+// Logger represents the methods used by the worker to log details.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Warningf(string, ...interface{})
+}
+
+// Backend provides access to the controller's database.
+type Backend interface {
+	// SchemaVersion returns the schema version of the database.
+	SchemaVersion() (int, error)
+
+	// RunSchemaMigrationBatch runs the next batch of the first
+	// incomplete schema migration, migrating at most batchSize
+	// documents. It returns true once the database is at the
+	// latest schema version.
+	RunSchemaMigrationBatch(batchSize int) (bool, error)
+}
+
+// Config holds the resources and configuration needed by the worker.
+type Config struct {
+	Backend Backend
+	Clock   clock.Clock
+	Logger  Logger
+
+	// BatchSize is the largest number of documents migrated
+	// at once.
+	BatchSize int
+
+	// Interval is how long the worker waits between batches, so
+	// that the migrations don't starve the controller's other work
+	// of database time.
+	Interval time.Duration
+
+	// RetryDelay is how long the worker waits before retrying a
+	// batch which failed.
+	RetryDelay time.Duration
+}
+
+// Validate returns an error if the config cannot be used
+// to start a worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.BatchSize <= 0 {
+		return errors.NotValidf("non-positive BatchSize")
+	}
+	if config.Interval < 0 {
+		return errors.NotValidf("negative Interval")
+	}
+	if config.RetryDelay <= 0 {
+		return errors.NotValidf("non-positive RetryDelay")
+	}
+	return nil
+}
+
+// Worker runs the online schema migrations of the controller's
+// database until it is at the latest schema version, and then waits
+// to be stopped.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// NewWorker returns a worker that runs the online schema migrations
+// with the config's Backend.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	logger := w.config.Logger
+	batches := 0
+	for {
+		done, err := w.config.Backend.RunSchemaMigrationBatch(w.config.BatchSize)
+		if err != nil {
+			// The migrations can be resumed, so a failed batch is
+			// retried rather than bouncing the worker.
+			logger.Warningf("schema migration failed, retrying in %s: %v", w.config.RetryDelay, err)
+			select {
+			case <-w.catacomb.Dying():
+				return w.catacomb.ErrDying()
+			case <-w.config.Clock.After(w.config.RetryDelay):
+			}
+			continue
+		}
+		if done {
+			break
+		}
+		batches++
+		logger.Debugf("ran schema migration batch %d", batches)
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(w.config.Interval):
+		}
+	}
+
+	version, err := w.config.Backend.SchemaVersion()
+	if err != nil {
+		return errors.Annotate(err, "getting schema version")
+	}
+	if batches > 0 {
+		logger.Infof("schema migrations complete, database is at schema version %d", version)
+	} else {
+		logger.Debugf("database is at schema version %d", version)
+	}
+	<-w.catacomb.Dying()
+	return w.catacomb.ErrDying()
+}
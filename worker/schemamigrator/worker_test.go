@@ -0,0 +1,174 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package schemamigrator_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/schemamigrator"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock   *testclock.Clock
+	backend *fakeBackend
+	config  schemamigrator.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.backend = &fakeBackend{batches: make(chan int, 10)}
+	s.config = schemamigrator.Config{
+		Backend:    s.backend,
+		Clock:      s.clock,
+		Logger:     loggo.GetLogger("test"),
+		BatchSize:  100,
+		Interval:   time.Second,
+		RetryDelay: time.Minute,
+	}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := schemamigrator.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	return w
+}
+
+func (s *WorkerSuite) waitBatch(c *gc.C) {
+	select {
+	case batchSize := <-s.backend.batches:
+		c.Assert(batchSize, gc.Equals, 100)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for schema migration batch")
+	}
+}
+
+func (s *WorkerSuite) assertNoBatch(c *gc.C) {
+	select {
+	case <-s.backend.batches:
+		c.Fatalf("unexpected schema migration batch")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	tests := []struct {
+		mutate func(*schemamigrator.Config)
+		err    string
+	}{
+		{func(cfg *schemamigrator.Config) { cfg.Backend = nil }, "nil Backend not valid"},
+		{func(cfg *schemamigrator.Config) { cfg.Clock = nil }, "nil Clock not valid"},
+		{func(cfg *schemamigrator.Config) { cfg.Logger = nil }, "nil Logger not valid"},
+		{func(cfg *schemamigrator.Config) { cfg.BatchSize = 0 }, "non-positive BatchSize not valid"},
+		{func(cfg *schemamigrator.Config) { cfg.Interval = -1 }, "negative Interval not valid"},
+		{func(cfg *schemamigrator.Config) { cfg.RetryDelay = 0 }, "non-positive RetryDelay not valid"},
+	}
+	for i, test := range tests {
+		c.Logf("test %d: %s", i, test.err)
+		config := s.config
+		test.mutate(&config)
+		c.Check(config.Validate(), gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WorkerSuite) TestRunsBatchesUntilDone(c *gc.C) {
+	s.backend.setResults(false, false, true)
+	w := s.startWorker(c)
+
+	s.waitBatch(c)
+	s.assertNoBatch(c)
+	c.Assert(s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.waitBatch(c)
+	c.Assert(s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.waitBatch(c)
+
+	// Once the migrations are complete the worker runs no more
+	// batches, but keeps running until it's stopped.
+	s.clock.Advance(time.Hour)
+	s.assertNoBatch(c)
+	workertest.CheckAlive(c, w)
+	workertest.CleanKill(c, w)
+}
+
+func (s *WorkerSuite) TestRetriesFailedBatch(c *gc.C) {
+	s.backend.setResults(false, true)
+	s.backend.setErr(errors.New("boom"))
+	w := s.startWorker(c)
+
+	s.waitBatch(c)
+	c.Assert(s.clock.WaitAdvance(time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.assertNoBatch(c)
+	c.Assert(s.clock.WaitAdvance(time.Minute-time.Second, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.waitBatch(c)
+	workertest.CheckAlive(c, w)
+	workertest.CleanKill(c, w)
+}
+
+func (s *WorkerSuite) TestSchemaVersionError(c *gc.C) {
+	s.backend.setResults(true)
+	s.backend.versionErr = errors.New("boom")
+	w := s.startWorker(c)
+
+	s.waitBatch(c)
+	err := workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "getting schema version: boom")
+}
+
+type fakeBackend struct {
+	mu         sync.Mutex
+	results    []bool
+	err        error
+	versionErr error
+	batches    chan int
+}
+
+func (b *fakeBackend) setResults(results ...bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.results = results
+}
+
+// setErr makes the next batch fail with the given error.
+func (b *fakeBackend) setErr(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.err = err
+}
+
+func (b *fakeBackend) SchemaVersion() (int, error) {
+	return 1, b.versionErr
+}
+
+func (b *fakeBackend) RunSchemaMigrationBatch(batchSize int) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.batches <- batchSize
+	if b.err != nil {
+		err := b.err
+		b.err = nil
+		return false, err
+	}
+	if len(b.results) == 0 {
+		return true, nil
+	}
+	done := b.results[0]
+	b.results = b.results[1:]
+	return done, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package securityupdatesreporter
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/securityupdates"
+)
+
+var (
+	// aptCheckPath is the update-notifier helper which counts the
+	// pending package updates, as shown in the login message.
+	aptCheckPath = "/usr/lib/update-notifier/apt-check"
+
+	// rebootRequiredPath exists when an installed package needs the
+	// machine to be rebooted; rebootRequiredPkgsPath lists them.
+	rebootRequiredPath     = "/var/run/reboot-required"
+	rebootRequiredPkgsPath = "/var/run/reboot-required.pkgs"
+)
+
+// CheckUpdates returns the number of package updates pending on the
+// machine, how many of them are security updates, and whether the
+// machine needs rebooting, using the same sources as Ubuntu's login
+// message.
+func CheckUpdates() (securityupdates.Report, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(aptCheckPath)
+	cmd.Stdout = &stdout
+	// apt-check writes its counts to stderr.
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if out := strings.TrimSpace(stdout.String() + stderr.String()); out != "" {
+			return securityupdates.Report{}, errors.Annotate(err, out)
+		}
+		return securityupdates.Report{}, errors.Trace(err)
+	}
+	pending, security, err := ParseAptCheck(stderr.String())
+	if err != nil {
+		return securityupdates.Report{}, errors.Trace(err)
+	}
+	rebootRequired, packages, err := RebootRequired(rebootRequiredPath, rebootRequiredPkgsPath)
+	if err != nil {
+		return securityupdates.Report{}, errors.Trace(err)
+	}
+	return securityupdates.Report{
+		Pending:        pending,
+		Security:       security,
+		RebootRequired: rebootRequired,
+		RebootPackages: packages,
+	}, nil
+}
+
+// ParseAptCheck parses the "<pending>;<security>" output of apt-check.
+func ParseAptCheck(out string) (pending, security int, _ error) {
+	out = strings.TrimSpace(out)
+	if fields := strings.Split(out, ";"); len(fields) == 2 {
+		pending, err1 := strconv.Atoi(fields[0])
+		security, err2 := strconv.Atoi(fields[1])
+		if err1 == nil && err2 == nil {
+			return pending, security, nil
+		}
+	}
+	return 0, 0, errors.Errorf("unexpected apt-check output %q", out)
+}
+
+// RebootRequired returns whether the flag file at path exists, and the
+// sorted, distinct package names listed in the file at pkgsPath if it
+// does.
+func RebootRequired(path, pkgsPath string) (bool, []string, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return false, nil, nil
+	} else if err != nil {
+		return false, nil, errors.Trace(err)
+	}
+	f, err := os.Open(pkgsPath)
+	if os.IsNotExist(err) {
+		return true, nil, nil
+	} else if err != nil {
+		return true, nil, errors.Trace(err)
+	}
+	defer f.Close()
+	seen := make(map[string]bool)
+	var packages []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		name := strings.TrimSpace(scanner.Text())
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		packages = append(packages, name)
+	}
+	if err := scanner.Err(); err != nil {
+		return true, nil, errors.Trace(err)
+	}
+	sort.Strings(packages)
+	return true, packages, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package securityupdatesreporter_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/securityupdatesreporter"
+)
+
+type CheckSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&CheckSuite{})
+
+func (s *CheckSuite) TestParseAptCheck(c *gc.C) {
+	pending, security, err := securityupdatesreporter.ParseAptCheck("12;3\n")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, gc.Equals, 12)
+	c.Assert(security, gc.Equals, 3)
+}
+
+func (s *CheckSuite) TestParseAptCheckInvalid(c *gc.C) {
+	for _, out := range []string{"", "12", "12;x", "E: cannot lock"} {
+		_, _, err := securityupdatesreporter.ParseAptCheck(out)
+		c.Check(err, gc.ErrorMatches, `unexpected apt-check output .*`)
+	}
+}
+
+func (s *CheckSuite) TestRebootRequired(c *gc.C) {
+	dir := c.MkDir()
+	path := filepath.Join(dir, "reboot-required")
+	pkgsPath := filepath.Join(dir, "reboot-required.pkgs")
+
+	required, packages, err := securityupdatesreporter.RebootRequired(path, pkgsPath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(required, jc.IsFalse)
+	c.Assert(packages, gc.HasLen, 0)
+
+	err = ioutil.WriteFile(path, []byte("*** System restart required ***\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	required, packages, err = securityupdatesreporter.RebootRequired(path, pkgsPath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(required, jc.IsTrue)
+	c.Assert(packages, gc.HasLen, 0)
+
+	err = ioutil.WriteFile(pkgsPath, []byte("linux-base\nlibc6\nlinux-base\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	required, packages, err = securityupdatesreporter.RebootRequired(path, pkgsPath)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(required, jc.IsTrue)
+	c.Assert(packages, jc.DeepEquals, []string{"libc6", "linux-base"})
+}
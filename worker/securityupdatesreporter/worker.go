@@ -0,0 +1,192 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package securityupdatesreporter provides a worker which, when the
+// model's report-security-updates setting is enabled, periodically
+// checks the package updates pending on a machine and whether it needs
+// rebooting, and reports them to the controller. The reports are
+// recorded in the status data of the machine.
+package securityupdatesreporter
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/core/securityupdates"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs/config"
+)
+
+// logger is here to stop the desire of creating a package level logger.
+// Don't do this, instead pass one through as config to the worker.
+var logger interface{}
+
+// Facade represents the API used by the security updates reporter
+// worker.
+type Facade interface {
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+	ModelConfig() (*config.Config, error)
+	SetReport(names.MachineTag, *securityupdates.Report) error
+}
+
+// Logger defines the methods used by the security updates reporter
+// worker for logging.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Warningf(string, ...interface{})
+}
+
+// Config holds all necessary attributes to start a security updates
+// reporter worker.
+type Config struct {
+	Facade     Facade
+	MachineTag names.MachineTag
+
+	// Check returns the machine's pending updates and whether it
+	// needs rebooting. The worker sets the time of the check.
+	Check func() (securityupdates.Report, error)
+
+	// CheckInterval is how often the machine is checked while
+	// reporting is enabled.
+	CheckInterval time.Duration
+
+	Clock  clock.Clock
+	Logger Logger
+}
+
+// Validate will err unless basic requirements for a valid
+// config are met.
+func (c Config) Validate() error {
+	if c.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if c.MachineTag.Id() == "" {
+		return errors.NotValidf("empty MachineTag")
+	}
+	if c.Check == nil {
+		return errors.NotValidf("nil Check")
+	}
+	if c.CheckInterval <= 0 {
+		return errors.NotValidf("non-positive CheckInterval")
+	}
+	if c.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if c.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// Worker reports the security updates pending on a machine.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// New returns a worker which reports the security updates pending on
+// the configured machine.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config: config,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is defined on worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is defined on worker.Worker.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	modelConfigWatcher, err := w.config.Facade.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(modelConfigWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var (
+		// enabled is nil until the model config has been read, so
+		// that a report left from when reporting was last enabled
+		// is removed even if it was disabled while the agent was
+		// down.
+		enabled *bool
+		checkCh <-chan time.Time
+	)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+
+		case _, ok := <-modelConfigWatcher.Changes():
+			if !ok {
+				return errors.New("model configuration watcher closed")
+			}
+			cfg, err := w.config.Facade.ModelConfig()
+			if err != nil {
+				return errors.Trace(err)
+			}
+			report := cfg.ReportSecurityUpdates()
+			if enabled != nil && *enabled == report {
+				continue
+			}
+			enabled = &report
+			if !report {
+				w.config.Logger.Debugf("security updates reporting disabled")
+				checkCh = nil
+				if err := w.config.Facade.SetReport(w.config.MachineTag, nil); err != nil {
+					return errors.Trace(err)
+				}
+				continue
+			}
+			if err := w.check(); err != nil {
+				return errors.Trace(err)
+			}
+			checkCh = w.config.Clock.After(w.config.CheckInterval)
+
+		case <-checkCh:
+			if err := w.check(); err != nil {
+				return errors.Trace(err)
+			}
+			checkCh = w.config.Clock.After(w.config.CheckInterval)
+		}
+	}
+}
+
+// check checks the machine and reports the result. A failed check is
+// logged and tried again at the next interval, leaving the previous
+// report in place.
+func (w *Worker) check() error {
+	report, err := w.config.Check()
+	if err != nil {
+		w.config.Logger.Warningf("cannot check for security updates: %v", err)
+		return nil
+	}
+	report.Checked = w.config.Clock.Now()
+	w.config.Logger.Debugf(
+		"%d updates pending, %d security; reboot required: %v",
+		report.Pending, report.Security, report.RebootRequired,
+	)
+	return errors.Trace(w.config.Facade.SetReport(w.config.MachineTag, &report))
+}
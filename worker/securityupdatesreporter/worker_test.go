@@ -0,0 +1,231 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package securityupdatesreporter_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/securityupdates"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/securityupdatesreporter"
+)
+
+const checkInterval = time.Hour
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	facade *fakeFacade
+	clock  *testclock.Clock
+
+	mu       sync.Mutex
+	checkErr error
+	checked  chan struct{}
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.facade = &fakeFacade{
+		changes: make(chan struct{}, 1),
+		set:     make(chan *securityupdates.Report, 10),
+		enabled: true,
+	}
+	s.clock = testclock.NewClock(time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC))
+	s.checkErr = nil
+	s.checked = make(chan struct{}, 10)
+}
+
+func (s *WorkerSuite) config() securityupdatesreporter.Config {
+	return securityupdatesreporter.Config{
+		Facade:        s.facade,
+		MachineTag:    names.NewMachineTag("0"),
+		Check:         s.check,
+		CheckInterval: checkInterval,
+		Clock:         s.clock,
+		Logger:        loggo.GetLogger("test"),
+	}
+}
+
+func (s *WorkerSuite) check() (securityupdates.Report, error) {
+	s.mu.Lock()
+	err := s.checkErr
+	s.mu.Unlock()
+	s.checked <- struct{}{}
+	if err != nil {
+		return securityupdates.Report{}, err
+	}
+	return securityupdates.Report{
+		Pending:        8,
+		Security:       2,
+		RebootRequired: true,
+	}, nil
+}
+
+func (s *WorkerSuite) setCheckErr(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.checkErr = err
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) {
+	w, err := securityupdatesreporter.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	s.facade.changes <- struct{}{}
+}
+
+func (s *WorkerSuite) waitChecked(c *gc.C) {
+	select {
+	case <-s.checked:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for check")
+	}
+}
+
+func (s *WorkerSuite) waitSet(c *gc.C) *securityupdates.Report {
+	select {
+	case report := <-s.facade.set:
+		return report
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for report")
+	}
+	panic("unreachable")
+}
+
+func (s *WorkerSuite) assertNoSet(c *gc.C) {
+	select {
+	case report := <-s.facade.set:
+		c.Fatalf("unexpected report %#v", report)
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	c.Check(config.Validate(), jc.ErrorIsNil)
+	config.CheckInterval = 0
+	c.Check(config.Validate(), gc.ErrorMatches, "non-positive CheckInterval not valid")
+	config.Check = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Check not valid")
+	config.MachineTag = names.MachineTag{}
+	c.Check(config.Validate(), gc.ErrorMatches, "empty MachineTag not valid")
+	config.Facade = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Facade not valid")
+}
+
+func (s *WorkerSuite) TestReportsPeriodically(c *gc.C) {
+	s.startWorker(c)
+	s.waitChecked(c)
+	c.Assert(s.waitSet(c), jc.DeepEquals, &securityupdates.Report{
+		Pending:        8,
+		Security:       2,
+		RebootRequired: true,
+		Checked:        s.clock.Now(),
+	})
+
+	c.Assert(s.clock.WaitAdvance(checkInterval, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.waitChecked(c)
+	report := s.waitSet(c)
+	c.Assert(report.Checked, gc.Equals, s.clock.Now())
+}
+
+func (s *WorkerSuite) TestFailedCheckKeepsReport(c *gc.C) {
+	s.setCheckErr(errors.New("apt-check not found"))
+	s.startWorker(c)
+	s.waitChecked(c)
+	s.assertNoSet(c)
+
+	s.setCheckErr(nil)
+	c.Assert(s.clock.WaitAdvance(checkInterval, coretesting.LongWait, 1), jc.ErrorIsNil)
+	s.waitChecked(c)
+	c.Assert(s.waitSet(c), gc.NotNil)
+}
+
+func (s *WorkerSuite) TestDisabledRemovesReport(c *gc.C) {
+	s.facade.setEnabled(false)
+	s.startWorker(c)
+	c.Assert(s.waitSet(c), gc.IsNil)
+	select {
+	case <-s.checked:
+		c.Fatalf("unexpected check")
+	case <-time.After(coretesting.ShortWait):
+	}
+
+	// Unrelated config changes don't report again.
+	s.facade.changes <- struct{}{}
+	s.assertNoSet(c)
+
+	s.facade.setEnabled(true)
+	s.facade.changes <- struct{}{}
+	s.waitChecked(c)
+	c.Assert(s.waitSet(c), gc.NotNil)
+
+	s.facade.setEnabled(false)
+	s.facade.changes <- struct{}{}
+	c.Assert(s.waitSet(c), gc.IsNil)
+}
+
+func (s *WorkerSuite) TestSetReportError(c *gc.C) {
+	s.facade.SetErrors(nil, nil, errors.New("boom"))
+	w, err := securityupdatesreporter.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade.changes <- struct{}{}
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+type fakeFacade struct {
+	testing.Stub
+	changes chan struct{}
+	set     chan *securityupdates.Report
+
+	mu      sync.Mutex
+	enabled bool
+}
+
+func (f *fakeFacade) setEnabled(enabled bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.enabled = enabled
+}
+
+func (f *fakeFacade) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	f.MethodCall(f, "WatchForModelConfigChanges")
+	return watchertest.NewMockNotifyWatcher(f.changes), f.NextErr()
+}
+
+func (f *fakeFacade) ModelConfig() (*config.Config, error) {
+	f.MethodCall(f, "ModelConfig")
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return config.New(config.UseDefaults, coretesting.FakeConfig().Merge(coretesting.Attrs{
+		config.ReportSecurityUpdatesKey: f.enabled,
+	}))
+}
+
+func (f *fakeFacade) SetReport(machine names.MachineTag, report *securityupdates.Report) error {
+	f.MethodCall(f, "SetReport", machine, report)
+	if err := f.NextErr(); err != nil {
+		return err
+	}
+	f.set <- report
+	return nil
+}
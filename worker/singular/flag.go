@@ -99,6 +99,16 @@ func (flag *FlagWorker) Check() bool {
 	return flag.valid
 }
 
+// Report is part of the worker.Reporter interface. It shows in the
+// dependency engine report whether this controller is responsible for
+// the configured Scope, so that the assignment of singular workers to
+// controllers can be seen through introspection.
+func (flag *FlagWorker) Report() map[string]interface{} {
+	return map[string]interface{}{
+		"responsible": flag.valid,
+	}
+}
+
 // run invokes a suitable runFunc, depending on the value of .valid.
 func (flag *FlagWorker) run() error {
 	runFunc := waitVacant
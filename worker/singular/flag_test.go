@@ -66,6 +66,27 @@ func (s *FlagSuite) TestClaimFailureWaitSuccess(c *gc.C) {
 	fix.CheckClaimWait(c)
 }
 
+func (s *FlagSuite) TestReportNotResponsible(c *gc.C) {
+	fix := newFixture(c, errClaimDenied, nil)
+	fix.Run(c, func(flag *singular.FlagWorker, _ *testclock.Clock, _ func()) {
+		c.Check(flag.Report(), jc.DeepEquals, map[string]interface{}{
+			"responsible": false,
+		})
+	})
+	fix.CheckClaimWait(c)
+}
+
+func (s *FlagSuite) TestReportResponsible(c *gc.C) {
+	fix := newFixture(c, nil, errors.New("should not happen"))
+	fix.Run(c, func(flag *singular.FlagWorker, clock *testclock.Clock, _ func()) {
+		<-clock.Alarms()
+		c.Check(flag.Report(), jc.DeepEquals, map[string]interface{}{
+			"responsible": true,
+		})
+	})
+	fix.CheckClaims(c, 1)
+}
+
 func (s *FlagSuite) TestClaimSuccess(c *gc.C) {
 	fix := newFixture(c, nil, errors.New("should not happen"))
 	fix.Run(c, func(flag *singular.FlagWorker, clock *testclock.Clock, unblock func()) {
@@ -91,6 +91,14 @@ func (w wrappedWorker) Wait() error {
 	return err
 }
 
+// Report is part of the worker.Reporter interface.
+func (w wrappedWorker) Report() map[string]interface{} {
+	if reporter, ok := w.Worker.(worker.Reporter); ok {
+		return reporter.Report()
+	}
+	return nil
+}
+
 // Manifold returns a dependency.Manifold that will run a FlagWorker and
 // expose it to clients as a engine.Flag resource.
 func Manifold(config ManifoldConfig) dependency.Manifold {
@@ -0,0 +1,94 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statusalerts
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/core/statusalert"
+	"github.com/juju/juju/environs/config"
+)
+
+// State describes whether an alert has been raised or resolved.
+type State string
+
+const (
+	// Firing notifications are sent when an entity starts matching
+	// a rule.
+	Firing State = "firing"
+
+	// Resolved notifications are sent when an entity no longer
+	// matches a rule it raised an alert for.
+	Resolved State = "resolved"
+)
+
+// Notification is the JSON document posted to the status alert webhook.
+type Notification struct {
+	State     State      `json:"state"`
+	Model     string     `json:"model"`
+	ModelUUID string     `json:"model-uuid"`
+	Rule      string     `json:"rule"`
+	Kind      string     `json:"kind"`
+	Entity    string     `json:"entity"`
+	Status    string     `json:"status"`
+	Message   string     `json:"message,omitempty"`
+	Since     *time.Time `json:"since,omitempty"`
+	Time      time.Time  `json:"time"`
+}
+
+func newNotification(modelConfig *config.Config, state State, alert statusalert.Alert, now time.Time) Notification {
+	n := Notification{
+		State:     state,
+		Model:     modelConfig.Name(),
+		ModelUUID: modelConfig.UUID(),
+		Rule:      alert.Rule,
+		Kind:      string(alert.Kind),
+		Entity:    alert.Entity,
+		Status:    alert.Status.String(),
+		Message:   alert.Message,
+		Time:      now,
+	}
+	if !alert.Since.IsZero() {
+		n.Since = &alert.Since
+	}
+	return n
+}
+
+// Notifier delivers alert notifications.
+type Notifier interface {
+	// Notify delivers the notification to the given webhook URL.
+	Notify(url string, n Notification) error
+}
+
+// NewWebhookNotifier returns a Notifier which posts notifications as
+// JSON to webhooks using the given client.
+func NewWebhookNotifier(client *http.Client) Notifier {
+	return webhookNotifier{client: client}
+}
+
+type webhookNotifier struct {
+	client *http.Client
+}
+
+// Notify is part of the Notifier interface.
+func (n webhookNotifier) Notify(url string, notification Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	resp, err := n.client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statusalerts_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/statusalerts"
+)
+
+type NotifierSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&NotifierSuite{})
+
+func (s *NotifierSuite) TestNotify(c *gc.C) {
+	var received statusalerts.Notification
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c.Check(r.Method, gc.Equals, "POST")
+		c.Check(r.Header.Get("Content-Type"), gc.Equals, "application/json")
+		c.Check(json.NewDecoder(r.Body).Decode(&received), jc.ErrorIsNil)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	now := time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC)
+	notification := statusalerts.Notification{
+		State:  statusalerts.Firing,
+		Model:  "prod",
+		Rule:   "machine-down",
+		Kind:   "machine",
+		Entity: "0",
+		Status: "down",
+		Time:   now,
+	}
+	notifier := statusalerts.NewWebhookNotifier(server.Client())
+	err := notifier.Notify(server.URL, notification)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(received, jc.DeepEquals, notification)
+}
+
+func (s *NotifierSuite) TestNotifyErrorStatus(c *gc.C) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	notifier := statusalerts.NewWebhookNotifier(server.Client())
+	err := notifier.Notify(server.URL, statusalerts.Notification{})
+	c.Assert(err, gc.ErrorMatches, "webhook returned 503 Service Unavailable")
+}
@@ -0,0 +1,213 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package statusalerts provides a worker which periodically matches the
+// status of the entities in a model against the model's
+// status-alert-rules. When an entity starts matching a rule an alert is
+// raised, and when it stops matching the alert is resolved; both are
+// logged and posted to the model's status-alert-webhook, if one is set.
+// Notifications which cannot be delivered are retried when the rules
+// are next checked.
+package statusalerts
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/core/statusalert"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/environs/config"
+)
+
+// logger is here to stop the desire of creating a package level logger.
+// Don't do this, instead pass one through as config to the worker.
+var logger interface{}
+
+// Facade represents the API used by the status alerts worker.
+type Facade interface {
+	WatchForModelConfigChanges() (watcher.NotifyWatcher, error)
+	ModelConfig() (*config.Config, error)
+	Entities() ([]statusalert.Entity, error)
+}
+
+// Logger defines the methods used by the status alerts worker for
+// logging.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Infof(string, ...interface{})
+	Warningf(string, ...interface{})
+}
+
+// Config holds all necessary attributes to start a status alerts
+// worker.
+type Config struct {
+	Facade        Facade
+	Notifier      Notifier
+	CheckInterval time.Duration
+	Clock         clock.Clock
+	Logger        Logger
+}
+
+// Validate will err unless basic requirements for a valid
+// config are met.
+func (c Config) Validate() error {
+	if c.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if c.Notifier == nil {
+		return errors.NotValidf("nil Notifier")
+	}
+	if c.CheckInterval <= 0 {
+		return errors.NotValidf("non-positive CheckInterval")
+	}
+	if c.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if c.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// Worker periodically matches the status of the entities in the model
+// against the model's status alert rules.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+
+	// firing holds the alerts which have been raised and not yet
+	// resolved, keyed by statusalert.Alert.Key.
+	firing map[string]statusalert.Alert
+}
+
+// New returns a worker which raises alerts for the entities in the
+// model matching the model's status alert rules.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config: config,
+		firing: make(map[string]statusalert.Alert),
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is defined on worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is defined on worker.Worker.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	modelConfigWatcher, err := w.config.Facade.WatchForModelConfigChanges()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := w.catacomb.Add(modelConfigWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	var (
+		modelConfig *config.Config
+		timer       clock.Timer
+		timerCh     <-chan time.Time
+	)
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+
+		case _, ok := <-modelConfigWatcher.Changes():
+			if !ok {
+				return errors.New("model configuration watcher closed")
+			}
+			modelConfig, err = w.config.Facade.ModelConfig()
+			if err != nil {
+				return errors.Annotate(err, "cannot load model configuration")
+			}
+			w.config.Logger.Debugf("%d status alert rules for %s (%s)",
+				len(modelConfig.StatusAlertRules()), modelConfig.Name(), modelConfig.UUID())
+			if timer == nil {
+				timer = w.config.Clock.NewTimer(w.config.CheckInterval)
+				timerCh = timer.Chan()
+			}
+
+		case <-timerCh:
+			if err := w.check(modelConfig); err != nil {
+				return errors.Trace(err)
+			}
+			timer.Reset(w.config.CheckInterval)
+		}
+	}
+}
+
+// check matches the entities in the model against the rules, and
+// notifies of the alerts which have been raised or resolved since the
+// last check.
+func (w *Worker) check(modelConfig *config.Config) error {
+	rules := modelConfig.StatusAlertRules()
+	var alerts []statusalert.Alert
+	now := w.config.Clock.Now()
+	if len(rules) > 0 {
+		entities, err := w.config.Facade.Entities()
+		if err != nil {
+			return errors.Annotate(err, "cannot get entity statuses")
+		}
+		alerts = statusalert.Evaluate(rules, entities, now)
+	} else if len(w.firing) == 0 {
+		return nil
+	}
+
+	webhook, _ := modelConfig.StatusAlertWebhook()
+	notify := func(state State, alert statusalert.Alert) bool {
+		if webhook == "" {
+			return true
+		}
+		err := w.config.Notifier.Notify(webhook, newNotification(modelConfig, state, alert, now))
+		if err != nil {
+			w.config.Logger.Warningf("cannot notify %s of %s alert %q for %s %s, will retry: %v",
+				webhook, state, alert.Rule, alert.Kind, alert.Entity, err)
+			return false
+		}
+		return true
+	}
+
+	raised := make(map[string]bool)
+	for _, alert := range alerts {
+		key := alert.Key()
+		raised[key] = true
+		if _, ok := w.firing[key]; ok {
+			continue
+		}
+		w.config.Logger.Infof("status alert %q firing for %s %s: %s %s",
+			alert.Rule, alert.Kind, alert.Entity, alert.Status, alert.Message)
+		if notify(Firing, alert) {
+			w.firing[key] = alert
+		}
+	}
+	for key, alert := range w.firing {
+		if raised[key] {
+			continue
+		}
+		w.config.Logger.Infof("status alert %q resolved for %s %s", alert.Rule, alert.Kind, alert.Entity)
+		if notify(Resolved, alert) {
+			delete(w.firing, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package statusalerts_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/core/statusalert"
+	"github.com/juju/juju/core/watcher"
+	"github.com/juju/juju/core/watcher/watchertest"
+	"github.com/juju/juju/environs/config"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/statusalerts"
+)
+
+const (
+	checkInterval = time.Minute
+	webhook       = "https://alerts.internal/juju"
+	unitErrorRule = "- name: unit-error\n  entity: unit\n  status: error\n  for: 5m\n"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	facade   *fakeFacade
+	notifier *fakeNotifier
+	clock    *testclock.Clock
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.facade = &fakeFacade{
+		changes: make(chan struct{}, 1),
+	}
+	s.notifier = &fakeNotifier{}
+	s.clock = testclock.NewClock(time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC))
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C, rules, webhook string) {
+	attrs := coretesting.FakeConfig()
+	attrs["status-alert-rules"] = rules
+	attrs["status-alert-webhook"] = webhook
+	cfg, err := config.New(config.UseDefaults, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	s.facade.modelConfig = cfg
+
+	w, err := statusalerts.New(statusalerts.Config{
+		Facade:        s.facade,
+		Notifier:      s.notifier,
+		CheckInterval: checkInterval,
+		Clock:         s.clock,
+		Logger:        loggo.GetLogger("test"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	s.facade.changes <- struct{}{}
+}
+
+// runCheck fires the check timer, and waits for the check to finish
+// and the timer to be reset.
+func (s *WorkerSuite) runCheck(c *gc.C, entities ...statusalert.Entity) {
+	s.facade.setEntities(entities)
+	c.Assert(s.clock.WaitAdvance(checkInterval, coretesting.LongWait, 1), jc.ErrorIsNil)
+	c.Assert(s.clock.WaitAdvance(0, coretesting.LongWait, 1), jc.ErrorIsNil)
+}
+
+func (s *WorkerSuite) unitInError(since time.Time) statusalert.Entity {
+	return statusalert.Entity{
+		Kind:        statusalert.KindUnit,
+		Name:        "mysql/0",
+		Application: "mysql",
+		Statuses: []status.StatusInfo{
+			{Status: status.Idle, Since: &since},
+			{Status: status.Error, Message: "hook failed", Since: &since},
+		},
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := statusalerts.Config{
+		Facade:        s.facade,
+		Notifier:      s.notifier,
+		CheckInterval: checkInterval,
+		Clock:         s.clock,
+		Logger:        loggo.GetLogger("test"),
+	}
+	c.Check(config.Validate(), jc.ErrorIsNil)
+	config.CheckInterval = 0
+	c.Check(config.Validate(), gc.ErrorMatches, "non-positive CheckInterval not valid")
+	config.CheckInterval = checkInterval
+	config.Notifier = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Notifier not valid")
+	config.Facade = nil
+	c.Check(config.Validate(), gc.ErrorMatches, "nil Facade not valid")
+}
+
+func (s *WorkerSuite) TestNoRules(c *gc.C) {
+	s.startWorker(c, "", webhook)
+	s.runCheck(c, s.unitInError(s.clock.Now().Add(-time.Hour)))
+	s.facade.CheckCallNames(c, "WatchForModelConfigChanges", "ModelConfig")
+	c.Assert(s.notifier.sent(), gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) TestFiringAndResolved(c *gc.C) {
+	s.startWorker(c, unitErrorRule, webhook)
+	since := s.clock.Now().Add(-10 * time.Minute)
+	s.runCheck(c, s.unitInError(since))
+	s.facade.CheckCallNames(c, "WatchForModelConfigChanges", "ModelConfig", "Entities")
+	firing := s.clock.Now()
+	c.Assert(s.notifier.sent(), jc.DeepEquals, []sentNotification{{
+		url: webhook,
+		notification: statusalerts.Notification{
+			State:     statusalerts.Firing,
+			Model:     "testmodel",
+			ModelUUID: coretesting.ModelTag.Id(),
+			Rule:      "unit-error",
+			Kind:      "unit",
+			Entity:    "mysql/0",
+			Status:    "error",
+			Message:   "hook failed",
+			Since:     &since,
+			Time:      firing,
+		},
+	}})
+
+	// An alert is only notified once while it is firing.
+	s.runCheck(c, s.unitInError(since))
+	c.Assert(s.notifier.sent(), gc.HasLen, 1)
+
+	s.runCheck(c)
+	sent := s.notifier.sent()
+	c.Assert(sent, gc.HasLen, 2)
+	c.Check(sent[1].notification.State, gc.Equals, statusalerts.Resolved)
+	c.Check(sent[1].notification.Entity, gc.Equals, "mysql/0")
+	c.Check(sent[1].notification.Time, gc.Equals, s.clock.Now())
+}
+
+func (s *WorkerSuite) TestNotFiringUntilDurationPassed(c *gc.C) {
+	s.startWorker(c, unitErrorRule, webhook)
+	since := s.clock.Now()
+	s.runCheck(c, s.unitInError(since))
+	c.Assert(s.notifier.sent(), gc.HasLen, 0)
+	for i := 0; i < 4; i++ {
+		s.runCheck(c, s.unitInError(since))
+	}
+	c.Assert(s.notifier.sent(), gc.HasLen, 1)
+}
+
+func (s *WorkerSuite) TestNoWebhook(c *gc.C) {
+	s.startWorker(c, unitErrorRule, "")
+	s.runCheck(c, s.unitInError(s.clock.Now().Add(-time.Hour)))
+	s.facade.CheckCallNames(c, "WatchForModelConfigChanges", "ModelConfig", "Entities")
+	c.Assert(s.notifier.sent(), gc.HasLen, 0)
+}
+
+func (s *WorkerSuite) TestRetriesFailedNotification(c *gc.C) {
+	s.startWorker(c, unitErrorRule, webhook)
+	since := s.clock.Now().Add(-time.Hour)
+	s.notifier.SetErrors(errors.New("connection refused"))
+	s.runCheck(c, s.unitInError(since))
+	c.Assert(s.notifier.sent(), gc.HasLen, 0)
+
+	s.runCheck(c, s.unitInError(since))
+	sent := s.notifier.sent()
+	c.Assert(sent, gc.HasLen, 1)
+	c.Check(sent[0].notification.State, gc.Equals, statusalerts.Firing)
+}
+
+type fakeFacade struct {
+	testing.Stub
+	changes     chan struct{}
+	modelConfig *config.Config
+
+	mu       sync.Mutex
+	entities []statusalert.Entity
+}
+
+func (f *fakeFacade) setEntities(entities []statusalert.Entity) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.entities = entities
+}
+
+func (f *fakeFacade) WatchForModelConfigChanges() (watcher.NotifyWatcher, error) {
+	f.MethodCall(f, "WatchForModelConfigChanges")
+	return watchertest.NewMockNotifyWatcher(f.changes), f.NextErr()
+}
+
+func (f *fakeFacade) ModelConfig() (*config.Config, error) {
+	f.MethodCall(f, "ModelConfig")
+	return f.modelConfig, f.NextErr()
+}
+
+func (f *fakeFacade) Entities() ([]statusalert.Entity, error) {
+	f.MethodCall(f, "Entities")
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.entities, f.NextErr()
+}
+
+type sentNotification struct {
+	url          string
+	notification statusalerts.Notification
+}
+
+type fakeNotifier struct {
+	testing.Stub
+
+	mu       sync.Mutex
+	notified []sentNotification
+}
+
+func (n *fakeNotifier) sent() []sentNotification {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]sentNotification(nil), n.notified...)
+}
+
+func (n *fakeNotifier) Notify(url string, notification statusalerts.Notification) error {
+	n.MethodCall(n, "Notify", url, notification)
+	if err := n.NextErr(); err != nil {
+		return err
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.notified = append(n.notified, sentNotification{url: url, notification: notification})
+	return nil
+}
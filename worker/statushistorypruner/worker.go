@@ -4,8 +4,6 @@
 package statushistorypruner
 
 import (
-	"time"
-
 	"github.com/juju/errors"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/catacomb"
@@ -27,12 +25,29 @@ type Worker struct {
 
 // NewFacade returns a new status history facade.
 func NewFacade(caller base.APICaller) pruner.Facade {
-	return statushistory.NewFacade(caller)
+	return facade{statushistory.NewFacade(caller)}
+}
+
+// facade adapts the status history client to the pruner.Facade
+// interface.
+type facade struct {
+	*statushistory.Facade
+}
+
+// Prune is part of the pruner.Facade interface.
+func (f facade) Prune(policy pruner.Policy) error {
+	return f.Facade.Prune(policy.MaxAge, int(policy.MaxCollectionMB), policy.MaxEntriesPerEntity, policy.PerKind, policy.Compact)
 }
 
 func (w *Worker) loop() error {
-	return w.Work(func(config *config.Config) (time.Duration, uint) {
-		return config.MaxStatusHistoryAge(), config.MaxStatusHistorySizeMB()
+	return w.Work(func(config *config.Config) pruner.Policy {
+		return pruner.Policy{
+			MaxAge:              config.MaxStatusHistoryAge(),
+			MaxCollectionMB:     config.MaxStatusHistorySizeMB(),
+			MaxEntriesPerEntity: config.MaxStatusHistoryEntries(),
+			PerKind:             config.StatusHistoryRetention(),
+			Compact:             config.CompactStatusHistory(),
+		}
 	})
 }
 
@@ -4,6 +4,7 @@
 package storageprovisioner
 
 import (
+	"fmt"
 	"path/filepath"
 
 	"github.com/juju/errors"
@@ -11,7 +12,9 @@ import (
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/life"
+	"github.com/juju/juju/core/status"
 	"github.com/juju/juju/core/watcher"
+	environscontext "github.com/juju/juju/environs/context"
 	"github.com/juju/juju/storage"
 )
 
@@ -127,6 +130,43 @@ func setStatus(ctx *context, statuses []params.EntityStatusArgs) {
 	}
 }
 
+// progressCallContext wraps the worker's cloud call context, and
+// implements storage.ProgressReporter by recording the progress of
+// long-running operations in the status of the volume or filesystem
+// being operated on.
+type progressCallContext struct {
+	environscontext.ProviderCallContext
+	ctx    *context
+	status status.Status
+}
+
+// newProgressCallContext returns a call context to pass to volume and
+// filesystem sources, which sets the status of entities to the given
+// status value when the sources report progress.
+func newProgressCallContext(ctx *context, value status.Status) environscontext.ProviderCallContext {
+	return &progressCallContext{
+		ProviderCallContext: ctx.config.CloudCallContext,
+		ctx:                 ctx,
+		status:              value,
+	}
+}
+
+// ReportProgress is part of the storage.ProgressReporter interface.
+func (p *progressCallContext) ReportProgress(tag names.Tag, message string, percent int) {
+	info := message
+	data := map[string]interface{}{"progress-message": message}
+	if percent >= 0 {
+		info = fmt.Sprintf("%s (%d%%)", message, percent)
+		data["progress"] = percent
+	}
+	setStatus(p.ctx, []params.EntityStatusArgs{{
+		Tag:    tag.String(),
+		Status: p.status.String(),
+		Info:   info,
+		Data:   data,
+	}})
+}
+
 var errNonDynamic = errors.New("non-dynamic storage provider")
 
 // volumeSource returns a volume source given a name, provider type,
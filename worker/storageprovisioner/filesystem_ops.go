@@ -57,7 +57,7 @@ func createFilesystems(ctx *context, ops map[names.FilesystemTag]*createFilesyst
 		if len(filesystemParams) == 0 {
 			continue
 		}
-		results, err := filesystemSource.CreateFilesystems(ctx.config.CloudCallContext, filesystemParams)
+		results, err := filesystemSource.CreateFilesystems(newProgressCallContext(ctx, status.Pending), filesystemParams)
 		if err != nil {
 			return errors.Annotatef(err, "creating filesystems from source %q", sourceName)
 		}
@@ -141,7 +141,7 @@ func attachFilesystems(ctx *context, ops map[params.MachineStorageId]*attachFile
 	for sourceName, filesystemAttachmentParams := range paramsBySource {
 		ctx.config.Logger.Debugf("attaching filesystems: %+v", filesystemAttachmentParams)
 		filesystemSource := filesystemSources[sourceName]
-		results, err := filesystemSource.AttachFilesystems(ctx.config.CloudCallContext, filesystemAttachmentParams)
+		results, err := filesystemSource.AttachFilesystems(newProgressCallContext(ctx, status.Attaching), filesystemAttachmentParams)
 		if err != nil {
 			return errors.Annotatef(err, "attaching filesystems from source %q", sourceName)
 		}
@@ -557,6 +557,7 @@ type dummyProvider struct {
 	releaseFilesystemsFunc       func([]string) ([]error, error)
 	validateVolumeParamsFunc     func(storage.VolumeParams) error
 	validateFilesystemParamsFunc func(storage.FilesystemParams) error
+	reportProgressFunc           func(context.ProviderCallContext, names.Tag)
 }
 
 type dummyVolumeSource struct {
@@ -681,6 +682,11 @@ func (s *dummyFilesystemSource) ValidateFilesystemParams(params storage.Filesyst
 
 // CreateFilesystems makes some filesystems that we can check later to ensure things went as expected.
 func (s *dummyFilesystemSource) CreateFilesystems(ctx context.ProviderCallContext, params []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
+	if s.provider != nil && s.provider.reportProgressFunc != nil {
+		for _, p := range params {
+			s.provider.reportProgressFunc(ctx, p.Tag)
+		}
+	}
 	if s.provider != nil && s.provider.createFilesystemsFunc != nil {
 		return s.provider.createFilesystemsFunc(params)
 	}
@@ -737,6 +737,41 @@ func (s *storageProvisionerSuite) TestFilesystemAdded(c *gc.C) {
 	waitChannel(c, filesystemInfoSet, "waiting for filesystem info to be set")
 }
 
+func (s *storageProvisionerSuite) TestFilesystemCreationProgress(c *gc.C) {
+	filesystemInfoSet := make(chan interface{})
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.setFilesystemInfo = func(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
+		defer close(filesystemInfoSet)
+		return nil, nil
+	}
+	s.provider.reportProgressFunc = func(ctx context.ProviderCallContext, tag names.Tag) {
+		storage.ReportProgress(ctx, tag, "formatting", 50)
+		storage.ReportProgress(ctx, tag, "restoring", -1)
+	}
+
+	args := &workerArgs{filesystems: filesystemAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	filesystemAccessor.filesystemsWatcher.changes <- []string{"1"}
+	waitChannel(c, filesystemInfoSet, "waiting for filesystem info to be set")
+	c.Assert(args.statusSetter.args, jc.DeepEquals, []params.EntityStatusArgs{{
+		Tag:    "filesystem-1",
+		Status: "pending",
+		Info:   "formatting (50%)",
+		Data:   map[string]interface{}{"progress-message": "formatting", "progress": 50},
+	}, {
+		Tag:    "filesystem-1",
+		Status: "pending",
+		Info:   "restoring",
+		Data:   map[string]interface{}{"progress-message": "restoring"},
+	}, {
+		Tag:    "filesystem-1",
+		Status: "attaching",
+	}})
+}
+
 func (s *storageProvisionerSuite) TestVolumeNeedsInstance(c *gc.C) {
 	volumeInfoSet := make(chan interface{})
 	volumeAccessor := newMockVolumeAccessor()
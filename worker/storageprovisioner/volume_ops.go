@@ -52,7 +52,7 @@ func createVolumes(ctx *context, ops map[names.VolumeTag]*createVolumeOp) error
 		if len(volumeParams) == 0 {
 			continue
 		}
-		results, err := volumeSource.CreateVolumes(ctx.config.CloudCallContext, volumeParams)
+		results, err := volumeSource.CreateVolumes(newProgressCallContext(ctx, status.Pending), volumeParams)
 		if err != nil {
 			return errors.Annotatef(err, "creating volumes from source %q", sourceName)
 		}
@@ -147,7 +147,7 @@ func attachVolumes(ctx *context, ops map[params.MachineStorageId]*attachVolumeOp
 			// to do here.
 			continue
 		}
-		results, err := volumeSource.AttachVolumes(ctx.config.CloudCallContext, volumeAttachmentParams)
+		results, err := volumeSource.AttachVolumes(newProgressCallContext(ctx, status.Attaching), volumeAttachmentParams)
 		if err != nil {
 			return errors.Annotatef(err, "attaching volumes from source %q", sourceName)
 		}
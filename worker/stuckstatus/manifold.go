@@ -0,0 +1,118 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package stuckstatus
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/state"
+	workerstate "github.com/juju/juju/worker/state"
+)
+
+// ManifoldConfig holds the information necessary to run a stuck status
+// worker in a dependency.Engine.
+type ManifoldConfig struct {
+	ClockName string
+	StateName string
+
+	Logger               Logger
+	Interval             time.Duration
+	PrometheusRegisterer prometheus.Registerer
+
+	NewBackend func(*state.StatePool) Backend
+	NewWorker  func(Config) (worker.Worker, error)
+}
+
+// Validate returns an error if the config cannot be used
+// to start the worker.
+func (config ManifoldConfig) Validate() error {
+	if config.ClockName == "" {
+		return errors.NotValidf("empty ClockName")
+	}
+	if config.StateName == "" {
+		return errors.NotValidf("empty StateName")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	if config.PrometheusRegisterer == nil {
+		return errors.NotValidf("nil PrometheusRegisterer")
+	}
+	if config.NewBackend == nil {
+		return errors.NotValidf("nil NewBackend")
+	}
+	if config.NewWorker == nil {
+		return errors.NotValidf("nil NewWorker")
+	}
+	return nil
+}
+
+// Manifold returns a dependency.Manifold that will run a
+// stuck status worker.
+func Manifold(config ManifoldConfig) dependency.Manifold {
+	return dependency.Manifold{
+		Inputs: []string{
+			config.ClockName,
+			config.StateName,
+		},
+		Start: config.start,
+	}
+}
+
+// start is a method on ManifoldConfig because it's more readable than a closure.
+func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var clock clock.Clock
+	if err := context.Get(config.ClockName, &clock); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var stTracker workerstate.StateTracker
+	if err := context.Get(config.StateName, &stTracker); err != nil {
+		return nil, errors.Trace(err)
+	}
+	statePool, err := stTracker.Use()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	// Unregister any collector left behind by a previous worker
+	// before registering the new one.
+	metrics := NewCollector()
+	config.PrometheusRegisterer.Unregister(metrics)
+	if err := config.PrometheusRegisterer.Register(metrics); err != nil {
+		config.Logger.Warningf("registering stuck status metrics collector failed: %v", err)
+	}
+
+	w, err := config.NewWorker(Config{
+		Backend:   config.NewBackend(statePool),
+		Clock:     clock,
+		Logger:    config.Logger,
+		Collector: metrics,
+		Interval:  config.Interval,
+	})
+	if err != nil {
+		config.PrometheusRegisterer.Unregister(metrics)
+		stTracker.Done()
+		return nil, errors.Trace(err)
+	}
+	go func() {
+		w.Wait()
+		config.PrometheusRegisterer.Unregister(metrics)
+		stTracker.Done()
+	}()
+	return w, nil
+}
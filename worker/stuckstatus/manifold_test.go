@@ -0,0 +1,87 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package stuckstatus_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/stuckstatus"
+)
+
+type ManifoldSuite struct {
+	testing.IsolationSuite
+	config stuckstatus.ManifoldConfig
+}
+
+var _ = gc.Suite(&ManifoldSuite{})
+
+func (s *ManifoldSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.config = stuckstatus.ManifoldConfig{
+		ClockName:            "clock",
+		StateName:            "state",
+		Logger:               loggo.GetLogger("test"),
+		Interval:             time.Hour,
+		PrometheusRegisterer: prometheus.NewRegistry(),
+		NewBackend:           stuckstatus.NewBackend,
+		NewWorker:            stuckstatus.NewWorker,
+	}
+}
+
+func (s *ManifoldSuite) TestValid(c *gc.C) {
+	c.Check(s.config.Validate(), jc.ErrorIsNil)
+}
+
+func (s *ManifoldSuite) TestInputs(c *gc.C) {
+	manifold := stuckstatus.Manifold(s.config)
+	c.Check(manifold.Inputs, jc.SameContents, []string{"clock", "state"})
+}
+
+func (s *ManifoldSuite) TestMissingClockName(c *gc.C) {
+	s.config.ClockName = ""
+	s.checkNotValid(c, "empty ClockName not valid")
+}
+
+func (s *ManifoldSuite) TestMissingStateName(c *gc.C) {
+	s.config.StateName = ""
+	s.checkNotValid(c, "empty StateName not valid")
+}
+
+func (s *ManifoldSuite) TestMissingLogger(c *gc.C) {
+	s.config.Logger = nil
+	s.checkNotValid(c, "nil Logger not valid")
+}
+
+func (s *ManifoldSuite) TestZeroInterval(c *gc.C) {
+	s.config.Interval = 0
+	s.checkNotValid(c, "non-positive Interval not valid")
+}
+
+func (s *ManifoldSuite) TestMissingPrometheusRegisterer(c *gc.C) {
+	s.config.PrometheusRegisterer = nil
+	s.checkNotValid(c, "nil PrometheusRegisterer not valid")
+}
+
+func (s *ManifoldSuite) TestMissingNewBackend(c *gc.C) {
+	s.config.NewBackend = nil
+	s.checkNotValid(c, "nil NewBackend not valid")
+}
+
+func (s *ManifoldSuite) TestMissingNewWorker(c *gc.C) {
+	s.config.NewWorker = nil
+	s.checkNotValid(c, "nil NewWorker not valid")
+}
+
+func (s *ManifoldSuite) checkNotValid(c *gc.C, expect string) {
+	err := s.config.Validate()
+	c.Check(err, gc.ErrorMatches, expect)
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+}
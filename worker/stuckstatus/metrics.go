@@ -0,0 +1,83 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package stuckstatus
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricsNamespace = "juju"
+	metricsSubsystem = "stuck_status"
+)
+
+// Collector is a prometheus.Collector that exposes, for each model,
+// how many statuses are error, blocked or waiting, and how long the
+// longest of them has been so.
+type Collector struct {
+	entities    *prometheus.GaugeVec
+	maxDuration *prometheus.GaugeVec
+	checkErrors prometheus.Counter
+}
+
+// NewCollector returns a new Collector.
+func NewCollector() *Collector {
+	labels := []string{"model_uuid", "model_name", "owner", "status"}
+	return &Collector{
+		entities: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "entities",
+			Help:      "The number of machine, application and unit statuses in the model with the given status.",
+		}, labels),
+		maxDuration: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "max_duration_seconds",
+			Help:      "The longest time any machine, application or unit status in the model has had the given status.",
+		}, labels),
+		checkErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "check_errors_total",
+			Help:      "The number of times a model's statuses could not be checked.",
+		}),
+	}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.entities.Describe(ch)
+	c.maxDuration.Describe(ch)
+	c.checkErrors.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.entities.Collect(ch)
+	c.maxDuration.Collect(ch)
+	c.checkErrors.Collect(ch)
+}
+
+// stuckCount is the number of a model's statuses with one of the
+// stuck status values, and the longest time one has had it.
+type stuckCount struct {
+	uuid        string
+	name        string
+	owner       string
+	status      string
+	entities    int
+	maxDuration float64
+}
+
+// setCounts replaces the recorded counts, so that models which have
+// been removed are dropped.
+func (c *Collector) setCounts(counts []stuckCount) {
+	c.entities.Reset()
+	c.maxDuration.Reset()
+	for _, m := range counts {
+		c.entities.WithLabelValues(m.uuid, m.name, m.owner, m.status).Set(float64(m.entities))
+		c.maxDuration.WithLabelValues(m.uuid, m.name, m.owner, m.status).Set(m.maxDuration)
+	}
+}
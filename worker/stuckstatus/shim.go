@@ -0,0 +1,54 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package stuckstatus
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/state"
+)
+
+// NewBackend returns a Backend that reads the models
+// from the state pool.
+func NewBackend(pool *state.StatePool) Backend {
+	return backendShim{pool}
+}
+
+type backendShim struct {
+	pool *state.StatePool
+}
+
+// AllModelUUIDs is part of the Backend interface.
+func (b backendShim) AllModelUUIDs() ([]string, error) {
+	return b.pool.SystemState().AllModelUUIDs()
+}
+
+// Model is part of the Backend interface.
+func (b backendShim) Model(modelUUID string) (Model, func(), error) {
+	st, err := b.pool.Get(modelUUID)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	model, err := st.Model()
+	if err != nil {
+		st.Release()
+		return nil, nil, errors.Trace(err)
+	}
+	release := func() { st.Release() }
+	return modelShim{Model: model, st: st.State}, release, nil
+}
+
+// modelShim adds the model's statuses, which are read through its
+// State, to the Model.
+type modelShim struct {
+	*state.Model
+	st *state.State
+}
+
+// StuckStatuses is part of the Model interface.
+func (m modelShim) StuckStatuses(notChangedSince time.Time) ([]state.StuckStatus, error) {
+	return m.st.StuckStatuses(notChangedSince)
+}
@@ -0,0 +1,194 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package stuckstatus provides a worker that periodically checks how
+// long the machines, applications and units in each model on the
+// controller have been in error, blocked or waiting, and exposes the
+// results on the controller's metrics endpoint, so that operators can
+// be alerted to stuck workloads.
+package stuckstatus
+
+import (
+	"time"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/state"
+)
+
+// Logger represents the methods used by the worker to log details.
+type Logger interface {
+	Debugf(string, ...interface{})
+	Warningf(string, ...interface{})
+}
+
+// Backend provides access to the models on the controller.
+type Backend interface {
+	// AllModelUUIDs returns the UUIDs of all the models
+	// on the controller.
+	AllModelUUIDs() ([]string, error)
+
+	// Model returns the model with the given UUID, and a function
+	// that must be called to release it.
+	Model(modelUUID string) (Model, func(), error)
+}
+
+// Model represents a model whose statuses are checked.
+type Model interface {
+	Name() string
+	Owner() names.UserTag
+
+	// StuckStatuses returns the statuses in the model which are
+	// error, blocked or waiting, and haven't changed since the
+	// given time. See state.State.StuckStatuses.
+	StuckStatuses(notChangedSince time.Time) ([]state.StuckStatus, error)
+}
+
+// Config holds the resources and configuration needed by the worker.
+type Config struct {
+	Backend   Backend
+	Clock     clock.Clock
+	Logger    Logger
+	Collector *Collector
+
+	// Interval is how often the statuses of all the models
+	// are checked.
+	Interval time.Duration
+}
+
+// Validate returns an error if the config cannot be used
+// to start a worker.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	if config.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	if config.Collector == nil {
+		return errors.NotValidf("nil Collector")
+	}
+	if config.Interval <= 0 {
+		return errors.NotValidf("non-positive Interval")
+	}
+	return nil
+}
+
+// Worker periodically checks for stuck statuses in the models on the
+// controller.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// NewWorker returns a worker that records the stuck statuses in the
+// models on the controller with the config's Collector.
+func NewWorker(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{config: config}
+	if err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is part of the worker.Worker interface.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is part of the worker.Worker interface.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	for {
+		if err := w.checkModels(); err != nil {
+			return errors.Trace(err)
+		}
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+		case <-w.config.Clock.After(w.config.Interval):
+		}
+	}
+}
+
+func (w *Worker) checkModels() error {
+	modelUUIDs, err := w.config.Backend.AllModelUUIDs()
+	if err != nil {
+		return errors.Annotate(err, "getting models")
+	}
+	var counts []stuckCount
+	for _, modelUUID := range modelUUIDs {
+		modelCounts, err := w.checkModel(modelUUID)
+		if errors.IsNotFound(err) {
+			// The model has been removed since we listed it.
+			continue
+		}
+		if err != nil {
+			// The counts only feed the metrics, so a model whose
+			// statuses can't be checked doesn't stop the others.
+			w.config.Collector.checkErrors.Inc()
+			w.config.Logger.Warningf("checking statuses of model %q: %v", modelUUID, err)
+			continue
+		}
+		counts = append(counts, modelCounts...)
+	}
+	w.config.Logger.Debugf("checked the statuses of %d models", len(modelUUIDs))
+	w.config.Collector.setCounts(counts)
+	return nil
+}
+
+// checkModel returns the counts of the model's stuck statuses, with
+// one for each of the stuck status values so that a model with none
+// reports zero rather than nothing.
+func (w *Worker) checkModel(modelUUID string) ([]stuckCount, error) {
+	model, release, err := w.config.Backend.Model(modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer release()
+
+	now := w.config.Clock.Now()
+	stuck, err := model.StuckStatuses(now)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	values := state.StuckStatusValues()
+	counts := make([]stuckCount, len(values))
+	index := make(map[string]int)
+	for i, value := range values {
+		counts[i] = stuckCount{
+			uuid:   modelUUID,
+			name:   model.Name(),
+			owner:  model.Owner().Id(),
+			status: value.String(),
+		}
+		index[value.String()] = i
+	}
+	for _, s := range stuck {
+		i, ok := index[s.Status.String()]
+		if !ok {
+			continue
+		}
+		counts[i].entities++
+		if duration := now.Sub(s.Since).Seconds(); duration > counts[i].maxDuration {
+			counts[i].maxDuration = duration
+		}
+	}
+	return counts, nil
+}
@@ -0,0 +1,311 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package stuckstatus_test
+
+import (
+	"sync"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/stuckstatus"
+)
+
+type WorkerSuite struct {
+	testing.IsolationSuite
+
+	clock     *testclock.Clock
+	backend   *fakeBackend
+	collector *stuckstatus.Collector
+	config    stuckstatus.Config
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC))
+	s.backend = &fakeBackend{
+		models:   make(map[string]*fakeModel),
+		released: make(chan string, 10),
+	}
+	s.collector = stuckstatus.NewCollector()
+	s.config = stuckstatus.Config{
+		Backend:   s.backend,
+		Clock:     s.clock,
+		Logger:    loggo.GetLogger("test"),
+		Collector: s.collector,
+		Interval:  time.Minute,
+	}
+}
+
+func (s *WorkerSuite) addModel(uuid string, stuck []state.StuckStatus, err error) {
+	m := &fakeModel{
+		name:  "model-" + uuid,
+		owner: names.NewUserTag("bob"),
+		stuck: stuck,
+		err:   err,
+	}
+	s.backend.mu.Lock()
+	s.backend.models[uuid] = m
+	s.backend.mu.Unlock()
+}
+
+// stuckFor returns a stuck status which has had the given value for
+// the given time.
+func (s *WorkerSuite) stuckFor(unit string, value status.Status, d time.Duration) state.StuckStatus {
+	return state.StuckStatus{
+		Entity: names.NewUnitTag(unit),
+		Kind:   status.KindWorkload,
+		Status: value,
+		Since:  s.clock.Now().Add(-d),
+	}
+}
+
+func (s *WorkerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := stuckstatus.NewWorker(s.config)
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.DirtyKill(c, w) })
+	return w
+}
+
+// waitChecked waits for the worker to finish checking
+// the statuses of the given number of models.
+func (s *WorkerSuite) waitChecked(c *gc.C, count int) {
+	for i := 0; i < count; i++ {
+		select {
+		case <-s.backend.released:
+		case <-time.After(coretesting.LongWait):
+			c.Fatalf("timed out waiting for model statuses to be checked")
+		}
+	}
+}
+
+// gather returns the entity count and maximum duration gauges by
+// model UUID and status, and the number of check errors.
+func (s *WorkerSuite) gather(c *gc.C) (map[string]float64, map[string]float64, float64) {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(s.collector)
+	families, err := registry.Gather()
+	c.Assert(err, jc.ErrorIsNil)
+	entities := make(map[string]float64)
+	durations := make(map[string]float64)
+	var errorCount float64
+	for _, family := range families {
+		var gauges map[string]float64
+		switch family.GetName() {
+		case "juju_stuck_status_entities":
+			gauges = entities
+		case "juju_stuck_status_max_duration_seconds":
+			gauges = durations
+		case "juju_stuck_status_check_errors_total":
+			errorCount = family.GetMetric()[0].GetCounter().GetValue()
+			continue
+		default:
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, pair := range metric.GetLabel() {
+				labels[pair.GetName()] = pair.GetValue()
+			}
+			c.Check(labels["model_name"], gc.Equals, "model-"+labels["model_uuid"])
+			c.Check(labels["owner"], gc.Equals, "bob")
+			gauges[labels["model_uuid"]+"/"+labels["status"]] = metric.GetGauge().GetValue()
+		}
+	}
+	return entities, durations, errorCount
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	breakers := []struct {
+		breaker func(config *stuckstatus.Config)
+		err     string
+	}{{
+		func(config *stuckstatus.Config) { config.Backend = nil },
+		"nil Backend not valid",
+	}, {
+		func(config *stuckstatus.Config) { config.Clock = nil },
+		"nil Clock not valid",
+	}, {
+		func(config *stuckstatus.Config) { config.Logger = nil },
+		"nil Logger not valid",
+	}, {
+		func(config *stuckstatus.Config) { config.Collector = nil },
+		"nil Collector not valid",
+	}, {
+		func(config *stuckstatus.Config) { config.Interval = 0 },
+		"non-positive Interval not valid",
+	}}
+	for i, test := range breakers {
+		c.Logf("test %d", i)
+		config := s.config
+		test.breaker(&config)
+		_, err := stuckstatus.NewWorker(config)
+		c.Check(err, jc.Satisfies, errors.IsNotValid)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *WorkerSuite) TestRecordsStuckStatuses(c *gc.C) {
+	s.addModel("a", []state.StuckStatus{
+		s.stuckFor("mysql/0", status.Error, time.Hour),
+		s.stuckFor("mysql/1", status.Error, time.Minute),
+		s.stuckFor("wordpress/0", status.Blocked, 10*time.Minute),
+	}, nil)
+	s.addModel("b", nil, nil)
+	w := s.startWorker(c)
+	s.waitChecked(c, 2)
+	workertest.CleanKill(c, w)
+
+	entities, durations, errorCount := s.gather(c)
+	c.Check(entities, jc.DeepEquals, map[string]float64{
+		"a/error":   2,
+		"a/blocked": 1,
+		"a/waiting": 0,
+		"b/error":   0,
+		"b/blocked": 0,
+		"b/waiting": 0,
+	})
+	c.Check(durations, jc.DeepEquals, map[string]float64{
+		"a/error":   3600,
+		"a/blocked": 600,
+		"a/waiting": 0,
+		"b/error":   0,
+		"b/blocked": 0,
+		"b/waiting": 0,
+	})
+	c.Check(errorCount, gc.Equals, float64(0))
+	c.Check(s.backend.since(), gc.Equals, s.clock.Now())
+}
+
+func (s *WorkerSuite) TestCountsCheckErrors(c *gc.C) {
+	s.backend.uuids = []string{"gone"}
+	s.addModel("a", []state.StuckStatus{
+		s.stuckFor("mysql/0", status.Waiting, time.Minute),
+	}, nil)
+	s.addModel("b", nil, errors.New("boom"))
+	w := s.startWorker(c)
+	s.waitChecked(c, 2)
+	workertest.CleanKill(c, w)
+
+	entities, _, errorCount := s.gather(c)
+	c.Check(entities, jc.DeepEquals, map[string]float64{
+		"a/error":   0,
+		"a/blocked": 0,
+		"a/waiting": 1,
+	})
+	c.Check(errorCount, gc.Equals, float64(1))
+}
+
+func (s *WorkerSuite) TestRechecksAfterInterval(c *gc.C) {
+	s.addModel("a", nil, nil)
+	w := s.startWorker(c)
+	s.waitChecked(c, 1)
+
+	// Models removed since the last pass are dropped
+	// from the metrics.
+	s.backend.mu.Lock()
+	delete(s.backend.models, "a")
+	s.backend.mu.Unlock()
+	s.addModel("c", []state.StuckStatus{
+		s.stuckFor("mysql/0", status.Blocked, 0),
+	}, nil)
+	err := s.clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	s.waitChecked(c, 1)
+	workertest.CleanKill(c, w)
+
+	entities, durations, _ := s.gather(c)
+	c.Check(entities, jc.DeepEquals, map[string]float64{
+		"c/error":   0,
+		"c/blocked": 1,
+		"c/waiting": 0,
+	})
+	c.Check(durations["c/blocked"], gc.Equals, float64(60))
+}
+
+func (s *WorkerSuite) TestBackendError(c *gc.C) {
+	s.backend.err = errors.New("boom")
+	w := s.startWorker(c)
+	err := workertest.CheckKilled(c, w)
+	c.Check(err, gc.ErrorMatches, "getting models: boom")
+}
+
+type fakeBackend struct {
+	mu       sync.Mutex
+	uuids    []string
+	models   map[string]*fakeModel
+	err      error
+	released chan string
+}
+
+func (b *fakeBackend) AllModelUUIDs() ([]string, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.err != nil {
+		return nil, b.err
+	}
+	uuids := append([]string(nil), b.uuids...)
+	for uuid := range b.models {
+		uuids = append(uuids, uuid)
+	}
+	return uuids, nil
+}
+
+func (b *fakeBackend) Model(uuid string) (stuckstatus.Model, func(), error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	m, ok := b.models[uuid]
+	if !ok {
+		return nil, nil, errors.NotFoundf("model %q", uuid)
+	}
+	return m, func() { b.released <- uuid }, nil
+}
+
+// since returns the latest time passed to any model's StuckStatuses.
+func (b *fakeBackend) since() time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var latest time.Time
+	for _, m := range b.models {
+		if m.since.After(latest) {
+			latest = m.since
+		}
+	}
+	return latest
+}
+
+type fakeModel struct {
+	name  string
+	owner names.UserTag
+	stuck []state.StuckStatus
+	err   error
+	since time.Time
+}
+
+func (m *fakeModel) Name() string {
+	return m.name
+}
+
+func (m *fakeModel) Owner() names.UserTag {
+	return m.owner
+}
+
+func (m *fakeModel) StuckStatuses(notChangedSince time.Time) ([]state.StuckStatus, error) {
+	m.since = notChangedSince
+	return m.stuck, m.err
+}
@@ -17,5 +17,5 @@ func NewPeriodicWorkerForTests(api Facade, params *VersionCheckerParams) worker.
 	periodicCall := func(stop <-chan struct{}) error {
 		return w.doCheck()
 	}
-	return jworker.NewPeriodicWorker(periodicCall, params.CheckInterval, jworker.NewTimer)
+	return jworker.NewPeriodicWorker(periodicCall, params.CheckInterval, jworker.NewClockTimerFunc(params.Clock))
 }
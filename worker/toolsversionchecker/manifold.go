@@ -6,6 +6,7 @@ package toolsversionchecker
 import (
 	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v3"
 	"gopkg.in/juju/worker.v1"
@@ -18,17 +19,28 @@ import (
 	"github.com/juju/juju/cmd/jujud/agent/engine"
 )
 
-// ManifoldConfig defines the names of the manifolds on which a Manifold will depend.
-type ManifoldConfig engine.AgentAPIManifoldConfig
+// ManifoldConfig defines the names of the manifolds on which a Manifold will depend,
+// and the clock used to time the checks.
+type ManifoldConfig struct {
+	AgentName     string
+	APICallerName string
+	Clock         clock.Clock
+}
 
 // Manifold returns a dependency manifold that runs a toolsversionchecker worker,
 // using the api connection resource named in the supplied config.
 func Manifold(config ManifoldConfig) dependency.Manifold {
-	typedConfig := engine.AgentAPIManifoldConfig(config)
-	return engine.AgentAPIManifold(typedConfig, newWorker)
+	typedConfig := engine.AgentAPIManifoldConfig{
+		AgentName:     config.AgentName,
+		APICallerName: config.APICallerName,
+	}
+	return engine.AgentAPIManifold(typedConfig, config.newWorker)
 }
 
-func newWorker(a agent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
+func (config ManifoldConfig) newWorker(a agent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
+	if config.Clock == nil {
+		return nil, errors.NotValidf("nil Clock")
+	}
 	tag := a.CurrentConfig().Tag()
 	if tag.Kind() != names.MachineTagKind {
 		return nil, errors.New("this manifold may only be used inside a machine agent")
@@ -45,6 +57,7 @@ func newWorker(a agent.Agent, apiCaller base.APICaller) (worker.Worker, error) {
 	// 4 times a day seems a decent enough amount of checks.
 	checkerParams := VersionCheckerParams{
 		CheckInterval: time.Hour * 6,
+		Clock:         config.Clock,
 	}
 	return New(agenttools.NewFacade(apiCaller), &checkerParams), nil
 }
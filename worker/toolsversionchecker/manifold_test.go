@@ -4,6 +4,7 @@
 package toolsversionchecker_test
 
 import (
+	"github.com/juju/clock"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -36,8 +37,17 @@ func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 	)
 }
 
+func (s *ManifoldSuite) config() toolsversionchecker.ManifoldConfig {
+	typedConfig := enginetest.AgentAPIManifoldTestConfig()
+	return toolsversionchecker.ManifoldConfig{
+		AgentName:     typedConfig.AgentName,
+		APICallerName: typedConfig.APICallerName,
+		Clock:         clock.WallClock,
+	}
+}
+
 func (s *ManifoldSuite) TestMachine(c *gc.C) {
-	config := toolsversionchecker.ManifoldConfig(enginetest.AgentAPIManifoldTestConfig())
+	config := s.config()
 	_, err := enginetest.RunAgentAPIManifold(
 		toolsversionchecker.Manifold(config),
 		&fakeAgent{tag: names.NewMachineTag("42")},
@@ -46,8 +56,19 @@ func (s *ManifoldSuite) TestMachine(c *gc.C) {
 	c.Assert(s.newCalled, jc.IsTrue)
 }
 
+func (s *ManifoldSuite) TestMissingClock(c *gc.C) {
+	config := s.config()
+	config.Clock = nil
+	_, err := enginetest.RunAgentAPIManifold(
+		toolsversionchecker.Manifold(config),
+		&fakeAgent{tag: names.NewMachineTag("42")},
+		mockAPICaller(model.JobManageModel))
+	c.Assert(err, gc.ErrorMatches, "nil Clock not valid")
+	c.Assert(s.newCalled, jc.IsFalse)
+}
+
 func (s *ManifoldSuite) TestMachineNotModelManagerErrors(c *gc.C) {
-	config := toolsversionchecker.ManifoldConfig(enginetest.AgentAPIManifoldTestConfig())
+	config := s.config()
 	_, err := enginetest.RunAgentAPIManifold(
 		toolsversionchecker.Manifold(config),
 		&fakeAgent{tag: names.NewMachineTag("42")},
@@ -57,7 +78,7 @@ func (s *ManifoldSuite) TestMachineNotModelManagerErrors(c *gc.C) {
 }
 
 func (s *ManifoldSuite) TestNonMachineAgent(c *gc.C) {
-	config := toolsversionchecker.ManifoldConfig(enginetest.AgentAPIManifoldTestConfig())
+	config := s.config()
 	_, err := enginetest.RunAgentAPIManifold(
 		toolsversionchecker.Manifold(config),
 		&fakeAgent{tag: names.NewUnitTag("foo/0")},
@@ -6,6 +6,7 @@ package toolsversionchecker
 import (
 	"time"
 
+	"github.com/juju/clock"
 	"github.com/juju/errors"
 	"gopkg.in/juju/worker.v1"
 
@@ -15,6 +16,8 @@ import (
 // VersionCheckerParams holds params for the version checker worker..
 type VersionCheckerParams struct {
 	CheckInterval time.Duration
+	// Clock is used to time the checks.
+	Clock clock.Clock
 }
 
 type Facade interface {
@@ -33,7 +36,7 @@ var New = func(api Facade, params *VersionCheckerParams) worker.Worker {
 	f := func(stop <-chan struct{}) error {
 		return w.doCheck()
 	}
-	return jworker.NewPeriodicWorker(f, params.CheckInterval, jworker.NewTimer)
+	return jworker.NewPeriodicWorker(f, params.CheckInterval, jworker.NewClockTimerFunc(params.Clock))
 }
 
 type toolsVersionWorker struct {
@@ -54,8 +54,10 @@ func newFacade() *facade {
 
 func (s *ToolsCheckerSuite) TestWorker(c *gc.C) {
 	f := newFacade()
+	clock := coretesting.NewSimulatedClock(time.Time{})
 	params := &toolsversionchecker.VersionCheckerParams{
-		CheckInterval: coretesting.ShortWait,
+		CheckInterval: time.Hour,
+		Clock:         clock,
 	}
 
 	checker := toolsversionchecker.NewPeriodicWorkerForTests(
@@ -67,11 +69,19 @@ func (s *ToolsCheckerSuite) TestWorker(c *gc.C) {
 		c.Assert(checker.Wait(), jc.ErrorIsNil)
 	})
 
+	// The first check is made straight away, and the next once the
+	// interval has passed.
+	clock.Step(c, 0, 1)
+	s.assertChecked(c, f)
+	clock.Step(c, time.Hour, 1)
+	s.assertChecked(c, f)
+}
+
+func (s *ToolsCheckerSuite) assertChecked(c *gc.C, f *facade) {
 	select {
 	case called := <-f.called:
 		c.Assert(called, gc.Equals, "UpdateToolsVersion")
 	case <-time.After(coretesting.LongWait):
 		c.Fatalf("timed out waiting worker to seek new agent binaries versions")
 	}
-
 }
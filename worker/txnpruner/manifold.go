@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/clock"
 	"github.com/juju/errors"
+	"github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/dependency"
 
@@ -20,8 +21,9 @@ type ManifoldConfig struct {
 	ClockName string
 	StateName string
 
-	PruneInterval time.Duration
-	NewWorker     func(TransactionPruner, time.Duration, clock.Clock) worker.Worker
+	PruneInterval        time.Duration
+	PrometheusRegisterer prometheus.Registerer
+	NewWorker            func(TransactionPruner, time.Duration, clock.Clock, *Collector) worker.Worker
 }
 
 func (config ManifoldConfig) Validate() error {
@@ -34,6 +36,9 @@ func (config ManifoldConfig) Validate() error {
 	if config.PruneInterval <= 0 {
 		return errors.NotValidf("non-positive PruneInterval")
 	}
+	if config.PrometheusRegisterer == nil {
+		return errors.NotValidf("nil PrometheusRegisterer")
+	}
 	if config.NewWorker == nil {
 		return errors.NotValidf("nil NewWorker")
 	}
@@ -72,9 +77,18 @@ func (config ManifoldConfig) start(context dependency.Context) (worker.Worker, e
 		return nil, errors.Trace(err)
 	}
 
-	worker := config.NewWorker(statePool.SystemState(), config.PruneInterval, clock)
+	// Unregister any collector left behind by a previous worker
+	// before registering the new one.
+	metrics := NewCollector()
+	config.PrometheusRegisterer.Unregister(metrics)
+	if err := config.PrometheusRegisterer.Register(metrics); err != nil {
+		logger.Warningf("registering txn pruner metrics collector failed: %v", err)
+	}
+
+	worker := config.NewWorker(statePool.SystemState(), config.PruneInterval, clock, metrics)
 	go func() {
 		worker.Wait()
+		config.PrometheusRegisterer.Unregister(metrics)
 		stTracker.Done()
 	}()
 	return worker, nil
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/worker.v1"
 	"gopkg.in/juju/worker.v1/workertest"
@@ -36,11 +37,12 @@ func (s *ManifoldSuite) SetUpTest(c *gc.C) {
 
 func (s *ManifoldSuite) validConfig() txnpruner.ManifoldConfig {
 	return txnpruner.ManifoldConfig{
-		ClockName:     "clock",
-		StateName:     "state",
-		PruneInterval: time.Hour,
-		NewWorker: func(tp txnpruner.TransactionPruner, interval time.Duration, clock clock.Clock) worker.Worker {
-			s.stub.AddCall("NewWorker", tp, interval, clock)
+		ClockName:            "clock",
+		StateName:            "state",
+		PruneInterval:        time.Hour,
+		PrometheusRegisterer: prometheus.NewRegistry(),
+		NewWorker: func(tp txnpruner.TransactionPruner, interval time.Duration, clock clock.Clock, metrics *txnpruner.Collector) worker.Worker {
+			s.stub.AddCall("NewWorker", tp, interval, clock, metrics)
 			return s.worker
 		},
 	}
@@ -65,6 +67,11 @@ func (s *ManifoldSuite) TestZeroPruneInterval(c *gc.C) {
 	s.checkNotValid(c, "non-positive PruneInterval not valid")
 }
 
+func (s *ManifoldSuite) TestMissingPrometheusRegisterer(c *gc.C) {
+	s.config.PrometheusRegisterer = nil
+	s.checkNotValid(c, "nil PrometheusRegisterer not valid")
+}
+
 func (s *ManifoldSuite) TestMissingNewWorker(c *gc.C) {
 	s.config.NewWorker = nil
 	s.checkNotValid(c, "nil NewWorker not valid")
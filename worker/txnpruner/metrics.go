@@ -0,0 +1,115 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package txnpruner
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/state"
+)
+
+const (
+	metricsNamespace = "juju"
+	metricsSubsystem = "txnpruner"
+)
+
+// Collector is a prometheus.Collector that exposes the sizes of the
+// transaction collections, and how long pruning takes, so that operators
+// can see when pruning is falling behind.
+type Collector struct {
+	txnCount       prometheus.Gauge
+	txnSize        prometheus.Gauge
+	txnLogCount    prometheus.Gauge
+	pruneDuration  prometheus.Histogram
+	pruneErrors    prometheus.Counter
+	statsErrors    prometheus.Counter
+	lastPruneStart prometheus.Gauge
+}
+
+// NewCollector returns a new Collector.
+func NewCollector() *Collector {
+	return &Collector{
+		txnCount: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "txns",
+			Help:      "The number of documents in the txns collection.",
+		}),
+		txnSize: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "txns_size_mib",
+			Help:      "The size of the txns collection in MiB, excluding indexes.",
+		}),
+		txnLogCount: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "txns_log",
+			Help:      "The number of documents in the txns.log collection.",
+		}),
+		pruneDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "prune_duration_seconds",
+			Help:      "The time taken by each pruning pass.",
+			Buckets:   prometheus.ExponentialBuckets(0.01, 4, 10),
+		}),
+		pruneErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "prune_errors_total",
+			Help:      "The number of pruning passes that failed.",
+		}),
+		statsErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "stats_errors_total",
+			Help:      "The number of times the txn collection sizes could not be read.",
+		}),
+		lastPruneStart: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: metricsSubsystem,
+			Name:      "last_prune_timestamp_seconds",
+			Help:      "The time at which the most recent pruning pass started.",
+		}),
+	}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.txnCount.Describe(ch)
+	c.txnSize.Describe(ch)
+	c.txnLogCount.Describe(ch)
+	c.pruneDuration.Describe(ch)
+	c.pruneErrors.Describe(ch)
+	c.statsErrors.Describe(ch)
+	c.lastPruneStart.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.txnCount.Collect(ch)
+	c.txnSize.Collect(ch)
+	c.txnLogCount.Collect(ch)
+	c.pruneDuration.Collect(ch)
+	c.pruneErrors.Collect(ch)
+	c.statsErrors.Collect(ch)
+	c.lastPruneStart.Collect(ch)
+}
+
+func (c *Collector) recordPrune(start time.Time, duration time.Duration, err error) {
+	c.lastPruneStart.Set(float64(start.Unix()))
+	c.pruneDuration.Observe(duration.Seconds())
+	if err != nil {
+		c.pruneErrors.Inc()
+	}
+}
+
+func (c *Collector) recordStats(stats state.TxnCollectionStats) {
+	c.txnCount.Set(float64(stats.TxnCount))
+	c.txnSize.Set(float64(stats.TxnSizeMB))
+	c.txnLogCount.Set(float64(stats.TxnLogCount))
+}
@@ -8,28 +8,45 @@ import (
 
 	"github.com/juju/clock"
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"gopkg.in/juju/worker.v1"
 
+	"github.com/juju/juju/state"
 	jworker "github.com/juju/juju/worker"
 )
 
+var logger = loggo.GetLogger("juju.worker.txnpruner")
+
 // TransactionPruner defines the interface for types capable of
 // pruning transactions.
 type TransactionPruner interface {
 	MaybePruneTransactions() error
+	TxnCollectionStats() (state.TxnCollectionStats, error)
 }
 
 // New returns a worker which periodically prunes the data for
-// completed transactions.
-func New(tp TransactionPruner, interval time.Duration, clock clock.Clock) worker.Worker {
+// completed transactions, recording the time taken and the resulting
+// sizes of the transaction collections with the supplied collector.
+func New(tp TransactionPruner, interval time.Duration, clock clock.Clock, metrics *Collector) worker.Worker {
 	return jworker.NewSimpleWorker(func(stopCh <-chan struct{}) error {
 		for {
 			select {
 			case <-clock.After(interval):
+				start := clock.Now()
 				err := tp.MaybePruneTransactions()
+				metrics.recordPrune(start, clock.Now().Sub(start), err)
 				if err != nil {
 					return errors.Annotate(err, "pruning failed, txnpruner stopping")
 				}
+				// Failing to read the collection sizes only affects
+				// the metrics, so it is not fatal to the worker.
+				stats, err := tp.TxnCollectionStats()
+				if err != nil {
+					metrics.statsErrors.Inc()
+					logger.Warningf("reading txn collection sizes: %v", err)
+					continue
+				}
+				metrics.recordStats(stats)
 			case <-stopCh:
 				return nil
 			}
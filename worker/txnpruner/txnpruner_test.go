@@ -9,8 +9,11 @@ import (
 	"github.com/juju/clock"
 	"github.com/juju/clock/testclock"
 	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/workertest"
 
+	"github.com/juju/juju/state"
 	coretesting "github.com/juju/juju/testing"
 	"github.com/juju/juju/worker/txnpruner"
 )
@@ -25,7 +28,7 @@ func (s *TxnPrunerSuite) TestPrunes(c *gc.C) {
 	fakePruner := newFakeTransactionPruner()
 	testClock := testclock.NewClock(time.Now())
 	interval := time.Minute
-	p := txnpruner.New(fakePruner, interval, testClock)
+	p := txnpruner.New(fakePruner, interval, testClock, txnpruner.NewCollector())
 	defer p.Kill()
 
 	select {
@@ -53,10 +56,69 @@ func (s *TxnPrunerSuite) TestPrunes(c *gc.C) {
 	}
 }
 
+func (s *TxnPrunerSuite) TestRecordsMetrics(c *gc.C) {
+	fakePruner := newFakeTransactionPruner()
+	fakePruner.stats = state.TxnCollectionStats{
+		TxnCount:    1234,
+		TxnSizeMB:   56,
+		TxnLogCount: 78,
+	}
+	testClock := testclock.NewClock(time.Now())
+	interval := time.Minute
+	metrics := txnpruner.NewCollector()
+	p := txnpruner.New(fakePruner, interval, testClock, metrics)
+	defer workertest.CleanKill(c, p)
+
+	select {
+	case <-testClock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for worker to start")
+	}
+	testClock.Advance(interval)
+	select {
+	case <-fakePruner.pruneCh:
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for pruning to happen")
+	}
+	// The stats are recorded before the worker waits again.
+	select {
+	case <-testClock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for worker to loop around")
+	}
+
+	registry := prometheus.NewPedanticRegistry()
+	c.Assert(registry.Register(metrics), jc.ErrorIsNil)
+	families, err := registry.Gather()
+	c.Assert(err, jc.ErrorIsNil)
+
+	values := make(map[string]float64)
+	for _, family := range families {
+		metric := family.GetMetric()[0]
+		switch {
+		case metric.GetGauge() != nil:
+			values[family.GetName()] = metric.GetGauge().GetValue()
+		case metric.GetCounter() != nil:
+			values[family.GetName()] = metric.GetCounter().GetValue()
+		case metric.GetHistogram() != nil:
+			values[family.GetName()] = float64(metric.GetHistogram().GetSampleCount())
+		}
+	}
+	c.Check(values, jc.DeepEquals, map[string]float64{
+		"juju_txnpruner_txns":                         1234,
+		"juju_txnpruner_txns_size_mib":                56,
+		"juju_txnpruner_txns_log":                     78,
+		"juju_txnpruner_prune_duration_seconds":       1,
+		"juju_txnpruner_prune_errors_total":           0,
+		"juju_txnpruner_stats_errors_total":           0,
+		"juju_txnpruner_last_prune_timestamp_seconds": float64(testClock.Now().Unix()),
+	})
+}
+
 func (s *TxnPrunerSuite) TestStops(c *gc.C) {
 	success := make(chan bool)
 	check := func() {
-		p := txnpruner.New(newFakeTransactionPruner(), time.Minute, clock.WallClock)
+		p := txnpruner.New(newFakeTransactionPruner(), time.Minute, clock.WallClock, txnpruner.NewCollector())
 		p.Kill()
 		c.Check(p.Wait(), jc.ErrorIsNil)
 		success <- true
@@ -78,6 +140,7 @@ func newFakeTransactionPruner() *fakeTransactionPruner {
 
 type fakeTransactionPruner struct {
 	pruneCh chan bool
+	stats   state.TxnCollectionStats
 }
 
 // MaybePruneTransactions implements the txnpruner.TransactionPruner
@@ -86,3 +149,9 @@ func (p *fakeTransactionPruner) MaybePruneTransactions() error {
 	p.pruneCh <- true
 	return nil
 }
+
+// TxnCollectionStats implements the txnpruner.TransactionPruner
+// interface.
+func (p *fakeTransactionPruner) TxnCollectionStats() (state.TxnCollectionStats, error) {
+	return p.stats, nil
+}
@@ -56,6 +56,11 @@ func (mock *mockFacade) SetStatus(status status.Status, info string, data map[st
 	return mock.stub.NextErr()
 }
 
+func (mock *mockFacade) AbandonCloudEnvironment(reason string) error {
+	mock.stub.MethodCall(mock, "AbandonCloudEnvironment", reason)
+	return mock.stub.NextErr()
+}
+
 func (mock *mockFacade) RemoveModel() error {
 	mock.stub.AddCall("RemoveModel")
 	return mock.stub.NextErr()
@@ -26,6 +26,7 @@ type Facade interface {
 	WatchModelResources() (watcher.NotifyWatcher, error)
 	ProcessDyingModel() error
 	RemoveModel() error
+	AbandonCloudEnvironment(reason string) error
 	SetStatus(status status.Status, message string, data map[string]interface{}) error
 }
 
@@ -169,6 +170,11 @@ func (u *Undertaker) run() error {
 			return errors.Trace(err)
 		}
 		u.config.Logger.Errorf("error tearing down cloud environment for force-destroyed model %q (%s): %v", modelInfo.GlobalName, modelInfo.UUID, err)
+		// Record the abandoned environment so the user can be told
+		// to clean it up, but don't let that block the removal.
+		if err := u.config.Facade.AbandonCloudEnvironment(err.Error()); err != nil {
+			u.config.Logger.Errorf("cannot record abandoned cloud environment for model %q (%s): %v", modelInfo.GlobalName, modelInfo.UUID, err)
+		}
 	}
 	// Finally, the model is going to be dead, and be removed.
 	if err := u.config.Facade.RemoveModel(); err != nil {
@@ -186,12 +186,27 @@ func (s *UndertakerSuite) TestDestroyErrorForced(c *gc.C) {
 		err := workertest.CheckKilled(c, w)
 		c.Assert(err, jc.ErrorIsNil)
 	})
-	// Removal continues despite the error calling destroy.
-	stub.CheckCallNames(c, "ModelInfo", "SetStatus", "Destroy", "RemoveModel")
+	// Removal continues despite the error calling destroy, once the
+	// environment has been recorded as abandoned.
+	stub.CheckCallNames(c, "ModelInfo", "SetStatus", "Destroy", "AbandonCloudEnvironment", "RemoveModel")
+	stub.CheckCall(c, 3, "AbandonCloudEnvironment", "pow")
 	// Logged the failed destroy call.
 	s.fix.logger.stub.CheckCallNames(c, "Errorf")
 }
 
+func (s *UndertakerSuite) TestDestroyErrorForcedAbandonError(c *gc.C) {
+	s.fix.errors = []error{nil, nil, errors.New("pow"), errors.New("splat")}
+	s.fix.info.Result.Life = "dead"
+	s.fix.info.Result.ForceDestroyed = true
+	stub := s.fix.run(c, func(w worker.Worker) {
+		err := workertest.CheckKilled(c, w)
+		c.Assert(err, jc.ErrorIsNil)
+	})
+	// Failing to record the abandoned environment doesn't block removal.
+	stub.CheckCallNames(c, "ModelInfo", "SetStatus", "Destroy", "AbandonCloudEnvironment", "RemoveModel")
+	s.fix.logger.stub.CheckCallNames(c, "Errorf", "Errorf")
+}
+
 func (s *UndertakerSuite) TestRemoveModelErrorFatal(c *gc.C) {
 	s.fix.errors = []error{nil, nil, nil, errors.New("pow")}
 	s.fix.info.Result.Life = "dead"
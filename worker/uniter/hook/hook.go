@@ -18,6 +18,10 @@ const (
 	LeaderSettingsChanged hooks.Kind = "leader-settings-changed"
 )
 
+// KubernetesEvent is run, in Kubernetes models only, when there are new
+// Kubernetes events for the unit's pod or its application's service.
+const KubernetesEvent hooks.Kind = "kubernetes-event"
+
 // Info holds details required to execute a hook. Not all fields are
 // relevant to all Kind values.
 type Info struct {
@@ -76,6 +80,8 @@ func (hi Info) Validate() error {
 	// TODO(fwereade): define these in charm/hooks...
 	case LeaderElected, LeaderDeposed, LeaderSettingsChanged:
 		return nil
+	case KubernetesEvent:
+		return nil
 	}
 	return fmt.Errorf("unknown hook kind %q", hi.Kind)
 }
@@ -60,6 +60,7 @@ var validateTests = []struct {
 	{hook.Info{Kind: hooks.StorageAttached}, `invalid storage ID ""`},
 	{hook.Info{Kind: hooks.StorageAttached, StorageId: "data/0"}, ""},
 	{hook.Info{Kind: hooks.StorageDetaching, StorageId: "data/0"}, ""},
+	{hook.Info{Kind: hook.KubernetesEvent}, ""},
 }
 
 func (s *InfoSuite) TestValidate(c *gc.C) {
@@ -4,13 +4,14 @@
 package operation
 
 import (
+	"fmt"
 	"os"
 
 	"github.com/juju/errors"
-	"github.com/juju/utils"
 	"gopkg.in/juju/charm.v6"
 
 	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/snapshot"
 )
 
 // Kind enumerates the operations the uniter can perform.
@@ -203,24 +204,30 @@ func NewStateFile(path string) *StateFile {
 }
 
 // Read reads a State from the file. If the file does not exist it returns
-// ErrNoStateFile.
+// ErrNoStateFile. If the file is corrupt, the state it held before its
+// last write is read instead; if that is also corrupt, the error satisfies
+// errors.IsNotValid.
 func (f *StateFile) Read() (*State, error) {
 	var st State
-	if err := utils.ReadYaml(f.path, &st); err != nil {
-		if os.IsNotExist(err) {
-			return nil, ErrNoStateFile
-		}
-	}
-	if err := st.validate(); err != nil {
-		return nil, errors.Errorf("cannot read %q: %v", f.path, err)
+	err := snapshot.ReadYaml(f.path, &st, func() error {
+		return st.validate()
+	})
+	switch {
+	case err == nil:
+		return &st, nil
+	case os.IsNotExist(err):
+		return nil, ErrNoStateFile
+	case errors.IsNotValid(err):
+		return nil, errors.NewNotValid(err, fmt.Sprintf("cannot read %q", f.path))
 	}
-	return &st, nil
+	return nil, errors.Annotatef(err, "cannot read %q", f.path)
 }
 
-// Write stores the supplied state to the file.
+// Write stores the supplied state to the file, keeping a snapshot of the
+// state it replaces.
 func (f *StateFile) Write(st *State) error {
 	if err := st.validate(); err != nil {
 		return errors.Trace(err)
 	}
-	return utils.WriteYaml(f.path, st)
+	return snapshot.WriteYaml(f.path, st)
 }
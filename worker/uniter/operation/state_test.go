@@ -4,8 +4,10 @@
 package operation_test
 
 import (
+	"io/ioutil"
 	"path/filepath"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
 	gc "gopkg.in/check.v1"
@@ -259,3 +261,41 @@ func (s *StateFileSuite) TestStates(c *gc.C) {
 		c.Assert(st, jc.DeepEquals, &t.st)
 	}
 }
+
+func (s *StateFileSuite) TestReadCorruptRestoresPreviousState(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "uniter")
+	file := operation.NewStateFile(path)
+	installed := operation.State{
+		Kind:      operation.Continue,
+		Step:      operation.Pending,
+		Installed: true,
+		Started:   true,
+	}
+	err := file.Write(&installed)
+	c.Assert(err, jc.ErrorIsNil)
+	err = file.Write(&operation.State{
+		Kind:      operation.RunHook,
+		Step:      operation.Pending,
+		Installed: true,
+		Started:   true,
+		Hook:      &hook.Info{Kind: hooks.ConfigChanged},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The file is emptied, as happens when the machine loses power
+	// before it is flushed.
+	err = ioutil.WriteFile(path, nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	st, err := file.Read()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(st, jc.DeepEquals, &installed)
+}
+
+func (s *StateFileSuite) TestReadCorruptWithoutSnapshot(c *gc.C) {
+	path := filepath.Join(c.MkDir(), "uniter")
+	err := ioutil.WriteFile(path, []byte("kind: [\n"), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = operation.NewStateFile(path).Read()
+	c.Assert(err, gc.ErrorMatches, `cannot read ".*": yaml: .*`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
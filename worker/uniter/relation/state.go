@@ -14,10 +14,10 @@ import (
 	"strings"
 
 	"github.com/juju/errors"
-	"github.com/juju/utils"
 	"gopkg.in/juju/charm.v6/hooks"
 
 	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/snapshot"
 )
 
 // State describes the state of a relation.
@@ -171,12 +171,20 @@ func ReadStateDir(dirPath string, relationId int) (d *StateDir, err error) {
 			unitOrAppName = svcName + "/" + unitId
 		}
 		var info diskInfo
-		if err = utils.ReadYaml(filepath.Join(d.path, name), &info); err != nil {
+		path := filepath.Join(d.path, name)
+		err = snapshot.ReadYaml(path, &info, info.validate)
+		if errors.IsNotValid(err) {
+			// Neither the file nor its snapshot can be read, so the
+			// unit is forgotten; if it is still in the relation, it
+			// will join again as far as the charm is concerned.
+			logger.Warningf("discarding invalid unit file %q in %q: %v", name, d.path, err)
+			if err := snapshot.Remove(path); err != nil {
+				return nil, errors.Trace(err)
+			}
+			continue
+		} else if err != nil {
 			return nil, fmt.Errorf("invalid unit file %q: %v", name, err)
 		}
-		if info.ChangeVersion == nil {
-			return nil, fmt.Errorf(`invalid unit file %q: "changed-version" not set`, name)
-		}
 		if isApp {
 			d.state.ApplicationMembers[unitOrAppName] = *info.ChangeVersion
 		} else {
@@ -251,7 +259,7 @@ func (d *StateDir) Write(hi hook.Info) (err error) {
 	}
 	path := filepath.Join(d.path, name)
 	if hi.Kind == hooks.RelationDeparted {
-		if err = os.Remove(path); err != nil && !os.IsNotExist(err) {
+		if err = snapshot.Remove(path); err != nil {
 			return err
 		}
 		// If atomic delete succeeded, update own state.
@@ -263,7 +271,7 @@ func (d *StateDir) Write(hi hook.Info) (err error) {
 		return nil
 	}
 	di := diskInfo{&hi.ChangeVersion, hi.Kind == hooks.RelationJoined}
-	if err := utils.WriteYaml(path, &di); err != nil {
+	if err := snapshot.WriteYaml(path, &di); err != nil {
 		return err
 	}
 	// If write was successful, update own state.
@@ -287,7 +295,7 @@ func (d *StateDir) Remove() error {
 	//  delete "foo-app". Instead, during relation-broken, we cleanup all related applications.
 	for appMember := range d.state.ApplicationMembers {
 		path := filepath.Join(d.path, appMember+"-app")
-		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		if err := snapshot.Remove(path); err != nil {
 			return errors.Trace(err)
 		}
 	}
@@ -305,3 +313,11 @@ type diskInfo struct {
 	ChangeVersion  *int64 `yaml:"change-version"`
 	ChangedPending bool   `yaml:"changed-pending,omitempty"`
 }
+
+// validate returns an error if the unit data is not complete.
+func (info *diskInfo) validate() error {
+	if info.ChangeVersion == nil {
+		return errors.New(`"changed-version" not set`)
+	}
+	return nil
+}
@@ -77,12 +77,6 @@ var badRelationsTests = []struct {
 	{
 		nil, []string{"foo-bar-1"},
 		`.* (is a directory|handle is invalid.)`,
-	}, {
-		map[string]string{"foo-1": "'"}, nil,
-		`invalid unit file "foo-1": yaml: found unexpected end of stream`,
-	}, {
-		map[string]string{"foo-1": "blah: blah\n"}, nil,
-		`invalid unit file "foo-1": "changed-version" not set`,
 	}, {
 		map[string]string{
 			"foo-1": "change-version: 123\nchanged-pending: true\n",
@@ -106,6 +100,60 @@ func (s *StateDirSuite) TestBadRelations(c *gc.C) {
 	}
 }
 
+func (s *StateDirSuite) TestReadStateDirRestoresSnapshot(c *gc.C) {
+	basedir := c.MkDir()
+	dir, err := relation.ReadStateDir(basedir, 123)
+	c.Assert(err, jc.ErrorIsNil)
+	err = dir.Ensure()
+	c.Assert(err, jc.ErrorIsNil)
+	for _, hi := range []hook.Info{{
+		Kind:              hooks.RelationJoined,
+		RelationId:        123,
+		RemoteUnit:        "foo/1",
+		RemoteApplication: "foo",
+		ChangeVersion:     1,
+	}, {
+		Kind:              hooks.RelationChanged,
+		RelationId:        123,
+		RemoteUnit:        "foo/1",
+		RemoteApplication: "foo",
+		ChangeVersion:     2,
+	}} {
+		err = dir.Write(hi)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+
+	// The unit file is emptied, as happens when the machine loses
+	// power before it is flushed, so the previous version is used.
+	err = ioutil.WriteFile(filepath.Join(basedir, "123", "foo-1"), nil, 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	dir, err = relation.ReadStateDir(basedir, 123)
+	c.Assert(err, jc.ErrorIsNil)
+	state := dir.State()
+	c.Assert(msi(state.Members), gc.DeepEquals, msi{"foo/1": 1})
+	c.Assert(state.ChangedPending, gc.Equals, "foo/1")
+}
+
+func (s *StateDirSuite) TestReadStateDirDiscardsCorruptFiles(c *gc.C) {
+	basedir := c.MkDir()
+	reldir := setUpDir(c, basedir, "123", map[string]string{
+		"foo-1":   "'",
+		"foo-2":   "blah: blah\n",
+		"foo-3":   "change-version: 3\n",
+		"foo-app": "",
+	})
+
+	dir, err := relation.ReadStateDir(basedir, 123)
+	c.Assert(err, jc.ErrorIsNil)
+	state := dir.State()
+	c.Assert(msi(state.Members), gc.DeepEquals, msi{"foo/3": 3})
+	c.Assert(msi(state.ApplicationMembers), gc.DeepEquals, msi{})
+	for _, name := range []string{"foo-1", "foo-2", "foo-app"} {
+		_, err := os.Stat(filepath.Join(reldir, name))
+		c.Assert(err, jc.Satisfies, os.IsNotExist)
+	}
+}
+
 var defaultMembers = msi{"foo/1": 0, "foo/2": 0}
 var defaultAppMembers = msi{"foo": 0}
 
@@ -400,10 +448,11 @@ func (s *ReadAllStateDirsSuite) TestBadStateDir(c *gc.C) {
 	basedir := c.MkDir()
 	relsdir := setUpDir(c, basedir, "relations", nil)
 	setUpDir(c, relsdir, "123", map[string]string{
-		"bad-0": "blah: blah\n",
+		"bad-0": "change-version: 1\nchanged-pending: true\n",
+		"bad-1": "change-version: 2\nchanged-pending: true\n",
 	})
 	_, err := relation.ReadAllStateDirs(relsdir)
-	c.Assert(err, gc.ErrorMatches, `cannot load relations state from .*: cannot load relation state from .*: invalid unit file "bad-0": "changed-version" not set`)
+	c.Assert(err, gc.ErrorMatches, `cannot load relations state from .*: cannot load relation state from .*: "bad/0" and "bad/1" both have pending changed hooks`)
 }
 
 func (s *ReadAllStateDirsSuite) TestReadAllStateDirs(c *gc.C) {
@@ -201,12 +201,16 @@ type mockUnit struct {
 	life                             life.Value
 	providerID                       string
 	resolved                         params.ResolvedMode
+	maintenance                      bool
+	maintenanceMessage               string
 	application                      mockApplication
 	unitWatcher                      *mockNotifyWatcher
 	addressesWatcher                 *mockStringsWatcher
 	configSettingsWatcher            *mockStringsWatcher
 	applicationConfigSettingsWatcher *mockStringsWatcher
 	upgradeSeriesWatcher             *mockNotifyWatcher
+	charmDeploymentsWatcher          *mockNotifyWatcher
+	kubernetesEventsWatcher          *mockNotifyWatcher
 	storageWatcher                   *mockStringsWatcher
 	actionWatcher                    *mockStringsWatcher
 	relationsWatcher                 *mockStringsWatcher
@@ -228,6 +232,10 @@ func (u *mockUnit) Resolved() params.ResolvedMode {
 	return u.resolved
 }
 
+func (u *mockUnit) Maintenance() (string, bool) {
+	return u.maintenanceMessage, u.maintenance
+}
+
 func (u *mockUnit) Application() (remotestate.Application, error) {
 	return &u.application, nil
 }
@@ -268,6 +276,14 @@ func (u *mockUnit) WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, err
 	return u.upgradeSeriesWatcher, nil
 }
 
+func (u *mockUnit) WatchCharmDeployments() (watcher.NotifyWatcher, error) {
+	return u.charmDeploymentsWatcher, nil
+}
+
+func (u *mockUnit) WatchKubernetesEvents() (watcher.NotifyWatcher, error) {
+	return u.kubernetesEventsWatcher, nil
+}
+
 func (u *mockUnit) UpgradeSeriesStatus() (model.UpgradeSeriesStatus, error) {
 	return model.UpgradeSeriesPrepareStarted, nil
 }
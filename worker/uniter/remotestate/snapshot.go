@@ -34,6 +34,12 @@ type Snapshot struct {
 	// expected to run.
 	CharmURL *charm.URL
 
+	// CharmDeploymentsVersion increments each time units of the
+	// application start or finish deploying its charm, or the model's
+	// config changes, so that a unit waiting for its turn to deploy the
+	// charm tries again.
+	CharmDeploymentsVersion int
+
 	// ForceCharmUpgrade reports whether the unit
 	// should upgrade even in an error state.
 	ForceCharmUpgrade bool
@@ -45,6 +51,13 @@ type Snapshot struct {
 	// ProviderID is the cloud container's provider ID.
 	ProviderID string
 
+	// Maintenance reports whether an operator has put the unit
+	// into maintenance, during which no hooks may be run.
+	Maintenance bool
+
+	// MaintenanceMessage describes why the unit is in maintenance.
+	MaintenanceMessage string
+
 	// RetryHookVersion increments each time a failed
 	// hook is meant to be retried if ResolvedMode is
 	// set to ResolvedNone.
@@ -74,6 +87,11 @@ type Snapshot struct {
 	// update-status hook is supposed to run.
 	UpdateStatusVersion int
 
+	// KubernetesEventsVersion increments each time there are new
+	// Kubernetes events for the unit's pod or its application's
+	// service, so that the kubernetes-event hook runs.
+	KubernetesEventsVersion int
+
 	// Actions is the list of pending actions to
 	// be performed by this unit.
 	Actions []string
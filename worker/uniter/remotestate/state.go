@@ -38,6 +38,7 @@ type Unit interface {
 	Refresh() error
 	ProviderID() string
 	Resolved() params.ResolvedMode
+	Maintenance() (string, bool)
 	Application() (Application, error)
 	Tag() names.UnitTag
 	Watch() (watcher.NotifyWatcher, error)
@@ -45,6 +46,8 @@ type Unit interface {
 	WatchConfigSettingsHash() (watcher.StringsWatcher, error)
 	WatchTrustConfigSettingsHash() (watcher.StringsWatcher, error)
 	WatchUpgradeSeriesNotifications() (watcher.NotifyWatcher, error)
+	WatchCharmDeployments() (watcher.NotifyWatcher, error)
+	WatchKubernetesEvents() (watcher.NotifyWatcher, error)
 	WatchStorage() (watcher.StringsWatcher, error)
 	WatchActionNotifications() (watcher.StringsWatcher, error)
 	// WatchRelation returns a watcher that fires when relations
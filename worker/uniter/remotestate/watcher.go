@@ -286,12 +286,33 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 
 		seenUpgradeSeriesChange bool
 		upgradeSeriesChanges    watcher.NotifyChannel
+
+		seenCharmDeploymentsChange bool
+		charmDeploymentsChanges    watcher.NotifyChannel
+
+		seenKubernetesEventsChange bool
+		kubernetesEventsChanges    watcher.NotifyChannel
 	)
 
 	// CAAS models don't use an application watcher
 	// which fires an initial event.
 	if w.modelType == model.CAAS {
 		seenApplicationChange = true
+
+		// Kubernetes events aren't needed for the initial snapshot, so
+		// the unit isn't held up when they can't be watched: the
+		// controller may be too old or the unit's pod may not exist.
+		kubernetesEventsw, err := w.unit.WatchKubernetesEvents()
+		if errors.IsNotImplemented(err) || params.IsCodeNotProvisioned(err) {
+			logger.Debugf("not watching kubernetes events: %v", err)
+		} else if err != nil {
+			return errors.Trace(err)
+		} else {
+			if err := w.catacomb.Add(kubernetesEventsw); err != nil {
+				return errors.Trace(err)
+			}
+			kubernetesEventsChanges = kubernetesEventsw.Changes()
+		}
 	}
 
 	if w.modelType == model.IAAS {
@@ -318,6 +339,18 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 		}
 		upgradeSeriesChanges = upgradeSeriesw.Changes()
 		requiredEvents++
+
+		// Only the units of IAAS models deploy their own charm, so
+		// only they are limited by max-charm-deployments.
+		charmDeploymentsw, err := w.unit.WatchCharmDeployments()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := w.catacomb.Add(charmDeploymentsw); err != nil {
+			return errors.Trace(err)
+		}
+		charmDeploymentsChanges = charmDeploymentsw.Changes()
+		requiredEvents++
 	}
 
 	var seenStorageChange bool
@@ -477,6 +510,25 @@ func (w *RemoteStateWatcher) loop(unitTag names.UnitTag) (err error) {
 			}
 			observedEvent(&seenUpgradeSeriesChange)
 
+		case _, ok := <-charmDeploymentsChanges:
+			logger.Debugf("got charm deployments change")
+			if !ok {
+				return errors.New("charm deployments watcher closed")
+			}
+			w.charmDeploymentsChanged()
+			observedEvent(&seenCharmDeploymentsChange)
+
+		case _, ok := <-kubernetesEventsChanges:
+			logger.Debugf("got kubernetes events change")
+			if !ok {
+				return errors.New("kubernetes events watcher closed")
+			}
+			// The initial event only reports that the watcher started.
+			if seenKubernetesEventsChange {
+				w.kubernetesEventsChanged()
+			}
+			seenKubernetesEventsChange = true
+
 		case hashes, ok := <-addressesChanges:
 			logger.Debugf("got address change: ok=%t, hashes=%v", ok, hashes)
 			if !ok {
@@ -626,6 +678,22 @@ func (w *RemoteStateWatcher) upgradeSeriesStatus() (model.UpgradeSeriesStatus, e
 	return status, nil
 }
 
+// charmDeploymentsChanged is called when units of the application
+// start or finish deploying its charm, or the model's config changes.
+func (w *RemoteStateWatcher) charmDeploymentsChanged() {
+	w.mu.Lock()
+	w.current.CharmDeploymentsVersion++
+	w.mu.Unlock()
+}
+
+// kubernetesEventsChanged is called when there are new Kubernetes
+// events for the unit's pod or its application's service.
+func (w *RemoteStateWatcher) kubernetesEventsChanged() {
+	w.mu.Lock()
+	w.current.KubernetesEventsVersion++
+	w.mu.Unlock()
+}
+
 // updateStatusChanged is called when the update status timer expires.
 func (w *RemoteStateWatcher) updateStatusChanged() {
 	w.mu.Lock()
@@ -659,6 +727,7 @@ func (w *RemoteStateWatcher) unitChanged() error {
 	// It's ok to sync provider ID by watching unit rather than
 	// cloud container because it will not change once pod created.
 	w.current.ProviderID = w.unit.ProviderID()
+	w.current.MaintenanceMessage, w.current.Maintenance = w.unit.Maintenance()
 	return nil
 }
 
@@ -94,6 +94,7 @@ func (s *WatcherSuiteIAAS) SetUpTest(c *gc.C) {
 	s.st.unit.application.applicationWatcher = newMockNotifyWatcher()
 	s.applicationWatcher = s.st.unit.application.applicationWatcher
 	s.st.unit.upgradeSeriesWatcher = newMockNotifyWatcher()
+	s.st.unit.charmDeploymentsWatcher = newMockNotifyWatcher()
 	w, err := remotestate.NewWatcher(remotestate.WatcherConfig{
 		State:               s.st,
 		ModelType:           s.modelType,
@@ -113,6 +114,7 @@ func (s *WatcherSuiteCAAS) SetUpTest(c *gc.C) {
 
 	s.applicationWatcher = newMockNotifyWatcher()
 	s.runningStatusWatcher = newMockNotifyWatcher()
+	s.st.unit.kubernetesEventsWatcher = newMockNotifyWatcher()
 	w, err := remotestate.NewWatcher(remotestate.WatcherConfig{
 		State:                s.st,
 		ModelType:            s.modelType,
@@ -171,6 +173,9 @@ func (s *WatcherSuite) TestInitialSignal(c *gc.C) {
 	if s.st.unit.upgradeSeriesWatcher != nil {
 		s.st.unit.upgradeSeriesWatcher.changes <- struct{}{}
 	}
+	if s.st.unit.charmDeploymentsWatcher != nil {
+		s.st.unit.charmDeploymentsWatcher.changes <- struct{}{}
+	}
 	s.st.unit.storageWatcher.changes <- []string{}
 	s.st.unit.actionWatcher.changes <- []string{}
 	if s.st.unit.application.applicationWatcher != nil {
@@ -197,6 +202,7 @@ func (s *WatcherSuite) signalAll() {
 	if s.st.modelType == model.IAAS {
 		s.applicationWatcher.changes <- struct{}{}
 		s.st.unit.upgradeSeriesWatcher.changes <- struct{}{}
+		s.st.unit.charmDeploymentsWatcher.changes <- struct{}{}
 	}
 }
 
@@ -206,19 +212,20 @@ func (s *WatcherSuiteIAAS) TestSnapshot(c *gc.C) {
 
 	snap := s.watcher.Snapshot()
 	c.Assert(snap, jc.DeepEquals, remotestate.Snapshot{
-		Life:                  s.st.unit.life,
-		Relations:             map[int]remotestate.RelationSnapshot{},
-		Storage:               map[names.StorageTag]remotestate.StorageSnapshot{},
-		CharmModifiedVersion:  s.st.unit.application.charmModifiedVersion,
-		CharmURL:              s.st.unit.application.curl,
-		ForceCharmUpgrade:     s.st.unit.application.forceUpgrade,
-		ResolvedMode:          s.st.unit.resolved,
-		ConfigHash:            "confighash",
-		TrustHash:             "trusthash",
-		AddressesHash:         "addresseshash",
-		LeaderSettingsVersion: 1,
-		Leader:                true,
-		UpgradeSeriesStatus:   model.UpgradeSeriesPrepareStarted,
+		Life:                    s.st.unit.life,
+		Relations:               map[int]remotestate.RelationSnapshot{},
+		Storage:                 map[names.StorageTag]remotestate.StorageSnapshot{},
+		CharmModifiedVersion:    s.st.unit.application.charmModifiedVersion,
+		CharmURL:                s.st.unit.application.curl,
+		CharmDeploymentsVersion: 1,
+		ForceCharmUpgrade:       s.st.unit.application.forceUpgrade,
+		ResolvedMode:            s.st.unit.resolved,
+		ConfigHash:              "confighash",
+		TrustHash:               "trusthash",
+		AddressesHash:           "addresseshash",
+		LeaderSettingsVersion:   1,
+		Leader:                  true,
+		UpgradeSeriesStatus:     model.UpgradeSeriesPrepareStarted,
 	})
 }
 
@@ -291,6 +298,13 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 	assertOneChange()
 	c.Assert(s.watcher.Snapshot().ResolvedMode, gc.Equals, params.ResolvedRetryHooks)
 
+	s.st.unit.maintenance = true
+	s.st.unit.maintenanceMessage = "disk swap"
+	s.st.unit.unitWatcher.changes <- struct{}{}
+	assertOneChange()
+	c.Assert(s.watcher.Snapshot().Maintenance, jc.IsTrue)
+	c.Assert(s.watcher.Snapshot().MaintenanceMessage, gc.Equals, "disk swap")
+
 	s.st.unit.addressesWatcher.changes <- []string{"addresseshash2"}
 	assertOneChange()
 	c.Assert(s.watcher.Snapshot().AddressesHash, gc.Equals, "addresseshash2")
@@ -316,6 +330,10 @@ func (s *WatcherSuite) TestRemoteStateChanged(c *gc.C) {
 	if s.modelType == model.IAAS {
 		s.st.unit.upgradeSeriesWatcher.changes <- struct{}{}
 		assertOneChange()
+
+		s.st.unit.charmDeploymentsWatcher.changes <- struct{}{}
+		assertOneChange()
+		c.Assert(s.watcher.Snapshot().CharmDeploymentsVersion, gc.Equals, initial.CharmDeploymentsVersion+1)
 	}
 	s.st.unit.application.forceUpgrade = true
 	s.applicationWatcher.changes <- struct{}{}
@@ -779,6 +797,25 @@ func (s *WatcherSuite) waitAlarmsStable(c *gc.C) {
 	}
 }
 
+func (s *WatcherSuiteCAAS) TestKubernetesEventsChanged(c *gc.C) {
+	assertOneChange := func() {
+		assertNotifyEvent(c, s.watcher.RemoteStateChanged(), "waiting for remote state change")
+		assertNoNotifyEvent(c, s.watcher.RemoteStateChanged(), "remote state change")
+	}
+
+	s.signalAll()
+	assertOneChange()
+
+	// The initial event doesn't run the hook.
+	s.st.unit.kubernetesEventsWatcher.changes <- struct{}{}
+	assertOneChange()
+	c.Assert(s.watcher.Snapshot().KubernetesEventsVersion, gc.Equals, 0)
+
+	s.st.unit.kubernetesEventsWatcher.changes <- struct{}{}
+	assertOneChange()
+	c.Assert(s.watcher.Snapshot().KubernetesEventsVersion, gc.Equals, 1)
+}
+
 func (s *WatcherSuiteCAAS) TestWatcherConfig(c *gc.C) {
 	_, err := remotestate.NewWatcher(remotestate.WatcherConfig{
 		ModelType: model.CAAS,
@@ -31,11 +31,32 @@ type ResolverConfig struct {
 	Relations           resolver.Resolver
 	Storage             resolver.Resolver
 	Commands            resolver.Resolver
+
+	// StartCharmDeployment reports whether the unit may start
+	// installing or upgrading its charm, and FinishCharmDeployment
+	// records that it has finished, so that no more of the
+	// application's units deploy the charm at once than the model's
+	// max-charm-deployments allows. They are only used in IAAS models.
+	StartCharmDeployment  func() (bool, error)
+	FinishCharmDeployment func() error
 }
 
 type uniterResolver struct {
 	config                ResolverConfig
 	retryHookTimerStarted bool
+
+	// charmDeploying reports whether the unit may be counted as
+	// deploying its charm, and so must finish the deployment once it
+	// has settled.
+	charmDeploying bool
+
+	// charmDeploymentWaiting is set when the unit was refused a charm
+	// deployment slot, and charmDeploymentsVersion then holds the
+	// remote state's CharmDeploymentsVersion. The unit doesn't ask
+	// again until the version changes, as the slots or the model's
+	// config have changed.
+	charmDeploymentWaiting  bool
+	charmDeploymentsVersion int
 }
 
 // NewUniterResolver returns a new resolver.Resolver for the uniter.
@@ -43,7 +64,70 @@ func NewUniterResolver(cfg ResolverConfig) resolver.Resolver {
 	return &uniterResolver{
 		config:                cfg,
 		retryHookTimerStarted: false,
+		// The uniter may have restarted while deploying the charm,
+		// so the deployment is finished once the unit has settled.
+		charmDeploying: true,
+	}
+}
+
+// startCharmDeployment reports whether the unit may start installing or
+// upgrading its charm.
+func (s *uniterResolver) startCharmDeployment(remoteState remotestate.Snapshot) (bool, error) {
+	if s.config.ModelType != model.IAAS {
+		return true, nil
+	}
+	if s.charmDeploymentWaiting && remoteState.CharmDeploymentsVersion == s.charmDeploymentsVersion {
+		// Nothing has changed since the unit was refused a slot.
+		return false, nil
+	}
+	started, err := s.config.StartCharmDeployment()
+	if err != nil {
+		return false, errors.Trace(err)
 	}
+	if started {
+		s.charmDeploying = true
+		s.charmDeploymentWaiting = false
+	} else {
+		if !s.charmDeploymentWaiting {
+			logger.Infof("waiting for other units to finish deploying the charm")
+		}
+		s.charmDeploymentWaiting = true
+		s.charmDeploymentsVersion = remoteState.CharmDeploymentsVersion
+	}
+	return started, nil
+}
+
+// resumeCharmDeployment reports whether a unit in an error state may
+// retry or skip the failed operation. A unit in an error state gives up
+// its charm deployment slot, so while it is installing or upgrading its
+// charm it must get a slot again first.
+func (s *uniterResolver) resumeCharmDeployment(localState resolver.LocalState, remoteState remotestate.Snapshot) (bool, error) {
+	if localState.Started && localState.Kind == operation.RunHook && !isCharmDeploymentHook(localState.Hook) {
+		return true, nil
+	}
+	return s.startCharmDeployment(remoteState)
+}
+
+// isCharmDeploymentHook reports whether the hook is run as part of
+// installing or upgrading the charm.
+func isCharmDeploymentHook(info *hook.Info) bool {
+	if info == nil {
+		return false
+	}
+	return info.Kind == hooks.Install || info.Kind == hooks.UpgradeCharm
+}
+
+// finishCharmDeployment records that the unit has finished installing or
+// upgrading its charm, if it may have started.
+func (s *uniterResolver) finishCharmDeployment() error {
+	if s.config.ModelType != model.IAAS || !s.charmDeploying {
+		return nil
+	}
+	if err := s.config.FinishCharmDeployment(); err != nil {
+		return errors.Trace(err)
+	}
+	s.charmDeploying = false
+	return nil
 }
 
 func (s *uniterResolver) upgradeOpForModel(opFactory operation.Factory, curl *charm.URL) (operation.Operation, error) {
@@ -65,6 +149,13 @@ func (s *uniterResolver) NextOp(
 		return nil, resolver.ErrTerminate
 	}
 
+	if remoteState.Maintenance && remoteState.Life != life.Dying {
+		// No hooks are run while an operator has put the unit into
+		// maintenance. A dying unit runs its hooks regardless, so
+		// that it may be removed.
+		return nil, resolver.ErrNoOperation
+	}
+
 	// Operations for series-upgrade need to be resolved early,
 	// in particular because no other operations should be run when the unit
 	// has completed preparation and is waiting for upgrade completion.
@@ -161,6 +252,22 @@ func (s *uniterResolver) nextOpConflicted(
 	remoteState remotestate.Snapshot,
 	opFactory operation.Factory,
 ) (operation.Operation, error) {
+	// The conflict lasts until an operator resolves it, so the unit
+	// doesn't hold up the other units deploying the charm meanwhile.
+	if err := s.finishCharmDeployment(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	revert := remoteState.ForceCharmUpgrade && charmModified(localState, remoteState)
+	if remoteState.ResolvedMode != params.ResolvedNone || revert {
+		resumed, err := s.resumeCharmDeployment(localState, remoteState)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !resumed {
+			return nil, resolver.ErrWaiting
+		}
+	}
+
 	// Only IAAS models deal with conflicted upgrades.
 	// TODO(caas) - what to do here.
 	if remoteState.ResolvedMode != params.ResolvedNone {
@@ -172,7 +279,7 @@ func (s *uniterResolver) nextOpConflicted(
 		}
 		return opFactory.NewNoOpUpgrade(localState.CharmURL)
 	}
-	if remoteState.ForceCharmUpgrade && charmModified(localState, remoteState) {
+	if revert {
 		if s.config.ModelType == model.IAAS {
 			return opFactory.NewRevertUpgrade(remoteState.CharmURL)
 		}
@@ -192,8 +299,32 @@ func (s *uniterResolver) nextOpHookError(
 		return nil, errors.Trace(err)
 	}
 
+	// The error may last until an operator resolves it, so the unit
+	// doesn't hold up the other units deploying the charm meanwhile.
+	if err := s.finishCharmDeployment(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	if remoteState.ForceCharmUpgrade && charmModified(localState, remoteState) {
-		return s.upgradeOpForModel(opFactory, remoteState.CharmURL)
+		started, err := s.startCharmDeployment(remoteState)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if started {
+			return s.upgradeOpForModel(opFactory, remoteState.CharmURL)
+		}
+	}
+
+	retrying := remoteState.ResolvedMode != params.ResolvedNone ||
+		remoteState.RetryHookVersion > localState.RetryHookVersion
+	if retrying {
+		resumed, err := s.resumeCharmDeployment(localState, remoteState)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !resumed {
+			return nil, resolver.ErrNoOperation
+		}
 	}
 
 	switch remoteState.ResolvedMode {
@@ -291,11 +422,32 @@ func (s *uniterResolver) nextOp(
 	// TODO(cmars): remove !localState.Started. It's here as a temporary
 	// measure because unit agent upgrades aren't being performed yet.
 	if !localState.Installed && !localState.Started {
+		started, err := s.startCharmDeployment(remoteState)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !started {
+			return nil, resolver.ErrNoOperation
+		}
 		return opFactory.NewRunHook(hook.Info{Kind: hooks.Install})
 	}
 
 	if charmModified(localState, remoteState) {
-		return s.upgradeOpForModel(opFactory, remoteState.CharmURL)
+		// A unit waiting for its turn to upgrade the charm carries
+		// on running the charm it has.
+		started, err := s.startCharmDeployment(remoteState)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if started {
+			return s.upgradeOpForModel(opFactory, remoteState.CharmURL)
+		}
+	} else if localState.Started {
+		// The unit has started and is running the charm it should,
+		// so any deployment of the charm has finished.
+		if err := s.finishCharmDeployment(); err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 
 	configHashChanged := localState.ConfigHash != remoteState.ConfigHash
@@ -310,6 +462,10 @@ func (s *uniterResolver) nextOp(
 		return op, err
 	}
 
+	if localState.KubernetesEventsVersion != remoteState.KubernetesEventsVersion {
+		return opFactory.NewRunHook(hook.Info{Kind: hook.KubernetesEvent})
+	}
+
 	// UpdateStatus hook runs if nothing else needs to.
 	if localState.UpdateStatusVersion != remoteState.UpdateStatusVersion {
 		return opFactory.NewRunHook(hook.Info{Kind: hooks.UpdateStatus})
@@ -87,6 +87,11 @@ type LocalState struct {
 	// been committed.
 	LeaderSettingsVersion int
 
+	// KubernetesEventsVersion is the version of Kubernetes events from
+	// remotestate.Snapshot for which a kubernetes-event hook has been
+	// committed.
+	KubernetesEventsVersion int
+
 	// CompletedActions is the set of actions that have been completed.
 	// This is used to prevent us re running actions requested by the
 	// controller.
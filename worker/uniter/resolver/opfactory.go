@@ -168,6 +168,11 @@ func (s *resolverOpFactory) wrapHookOp(op operation.Operation, info hook.Info) o
 		op = onCommitWrapper{op, func(*operation.State) {
 			s.LocalState.LeaderSettingsVersion = v
 		}}
+	case hook.KubernetesEvent:
+		v := s.RemoteState.KubernetesEventsVersion
+		op = onCommitWrapper{op, func(*operation.State) {
+			s.LocalState.KubernetesEventsVersion = v
+		}}
 	}
 
 	charmModifiedVersion := s.RemoteState.CharmModifiedVersion
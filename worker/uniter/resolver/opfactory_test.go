@@ -158,6 +158,23 @@ func (s *ResolverOpFactorySuite) testLeaderSettingsChanged(
 	c.Assert(f.LocalState.UpdateStatusVersion, gc.Equals, 3)
 }
 
+func (s *ResolverOpFactorySuite) TestKubernetesEvent(c *gc.C) {
+	f := resolver.NewResolverOpFactory(s.opFactory)
+	f.RemoteState.KubernetesEventsVersion = 1
+
+	op, err := f.NewRunHook(hook.Info{Kind: hook.KubernetesEvent})
+	c.Assert(err, jc.ErrorIsNil)
+	f.RemoteState.KubernetesEventsVersion = 2
+
+	_, err = op.Commit(operation.State{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Local state's KubernetesEventsVersion should be set to what
+	// RemoteState's KubernetesEventsVersion was when the operation
+	// was constructed.
+	c.Assert(f.LocalState.KubernetesEventsVersion, gc.Equals, 1)
+}
+
 func (s *ResolverOpFactorySuite) TestUpgrade(c *gc.C) {
 	s.testUpgrade(c, resolver.ResolverOpFactory.NewUpgrade)
 	s.testUpgrade(c, resolver.ResolverOpFactory.NewRevertUpgrade)
@@ -13,6 +13,7 @@ import (
 	"gopkg.in/juju/names.v3"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/core/model"
 	"github.com/juju/juju/worker/uniter"
 	uniteractions "github.com/juju/juju/worker/uniter/actions"
@@ -38,6 +39,9 @@ type resolverSuite struct {
 
 	clearResolved   func() error
 	reportHookError func(hook.Info) error
+
+	charmDeploymentAllowed bool
+	charmDeploymentCalls   []string
 }
 
 type caasResolverSuite struct {
@@ -82,6 +86,9 @@ func (s *resolverSuite) SetUpTest(c *gc.C) {
 		return errors.New("unexpected report hook error")
 	}
 
+	s.charmDeploymentAllowed = true
+	s.charmDeploymentCalls = nil
+
 	s.resolverConfig = uniter.ResolverConfig{
 		ClearResolved:       func() error { return s.clearResolved() },
 		ReportHookError:     func(info hook.Info) error { return s.reportHookError(info) },
@@ -95,6 +102,14 @@ func (s *resolverSuite) SetUpTest(c *gc.C) {
 		Storage:             storage.NewResolver(attachments, s.modelType),
 		Commands:            nopResolver{},
 		ModelType:           s.modelType,
+		StartCharmDeployment: func() (bool, error) {
+			s.charmDeploymentCalls = append(s.charmDeploymentCalls, "start")
+			return s.charmDeploymentAllowed, nil
+		},
+		FinishCharmDeployment: func() error {
+			s.charmDeploymentCalls = append(s.charmDeploymentCalls, "finish")
+			return nil
+		},
 	}
 
 	s.resolver = uniter.NewUniterResolver(s.resolverConfig)
@@ -134,6 +149,170 @@ func (s *resolverSuite) TestNotStartedNotInstalled(c *gc.C) {
 	c.Assert(op.String(), gc.Equals, "run install hook")
 }
 
+func (s *iaasResolverSuite) TestInstallWaitsForCharmDeployment(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind: operation.Continue,
+		},
+	}
+	s.charmDeploymentAllowed = false
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+
+	// The unit doesn't ask again until the charm deployments change.
+	s.charmDeploymentAllowed = true
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	c.Assert(s.charmDeploymentCalls, jc.DeepEquals, []string{"start"})
+
+	s.remoteState.CharmDeploymentsVersion++
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run install hook")
+	c.Assert(s.charmDeploymentCalls, jc.DeepEquals, []string{"start", "start"})
+}
+
+func (s *iaasResolverSuite) TestUpgradeWaitsForCharmDeployment(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	s.remoteState.CharmURL = charm.MustParseURL("cs:precise/mysql-3")
+	s.charmDeploymentAllowed = false
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+
+	// The unit runs the hooks of the charm it has while it waits.
+	s.remoteState.ConfigHash = "version2"
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run config-changed hook")
+
+	s.charmDeploymentAllowed = true
+	s.remoteState.CharmDeploymentsVersion++
+	op, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "upgrade to cs:precise/mysql-3")
+	c.Assert(s.charmDeploymentCalls, jc.DeepEquals, []string{"start", "start"})
+}
+
+func (s *iaasResolverSuite) TestHookErrorFinishesCharmDeployment(c *gc.C) {
+	s.reportHookError = func(hook.Info) error { return nil }
+	s.clearResolved = func() error { return nil }
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind: operation.RunHook,
+			Step: operation.Pending,
+			Hook: &hook.Info{
+				Kind: hooks.Install,
+			},
+		},
+	}
+	// A unit whose install hook failed gives up its slot.
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	c.Assert(s.charmDeploymentCalls, jc.DeepEquals, []string{"finish"})
+
+	// It needs a slot again before retrying the hook.
+	s.charmDeploymentAllowed = false
+	s.remoteState.ResolvedMode = params.ResolvedRetryHooks
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	c.Assert(s.charmDeploymentCalls, jc.DeepEquals, []string{"finish", "start"})
+
+	s.charmDeploymentAllowed = true
+	s.remoteState.CharmDeploymentsVersion++
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run install hook")
+	c.Assert(s.charmDeploymentCalls, jc.DeepEquals, []string{"finish", "start", "start"})
+}
+
+func (s *iaasResolverSuite) TestHookErrorAfterStartRetriesWithoutCharmDeployment(c *gc.C) {
+	s.reportHookError = func(hook.Info) error { return nil }
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.RunHook,
+			Step:      operation.Pending,
+			Installed: true,
+			Started:   true,
+			Hook: &hook.Info{
+				Kind: hooks.ConfigChanged,
+			},
+		},
+	}
+	s.charmDeploymentAllowed = false
+	s.remoteState.RetryHookVersion = 1
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run config-changed hook")
+	c.Assert(s.charmDeploymentCalls, jc.DeepEquals, []string{"finish"})
+}
+
+func (s *iaasResolverSuite) TestFinishCharmDeployment(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	c.Assert(s.charmDeploymentCalls, jc.DeepEquals, []string{"finish"})
+
+	// The deployment is only finished once.
+	_, err = s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	c.Assert(s.charmDeploymentCalls, jc.DeepEquals, []string{"finish"})
+}
+
+func (s *caasResolverSuite) TestCharmDeploymentNotLimited(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind: operation.Continue,
+		},
+	}
+	s.charmDeploymentAllowed = false
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run install hook")
+	c.Assert(s.charmDeploymentCalls, gc.HasLen, 0)
+}
+
+func (s *caasResolverSuite) TestRunsKubernetesEventHook(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion:    s.charmModifiedVersion,
+		CharmURL:                s.charmURL,
+		KubernetesEventsVersion: 1,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	s.remoteState.KubernetesEventsVersion = 2
+
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run kubernetes-event hook")
+}
+
 func (s *iaasResolverSuite) TestUpgradeSeriesPrepareStatusChanged(c *gc.C) {
 	localState := resolver.LocalState{
 		CharmModifiedVersion: s.charmModifiedVersion,
@@ -425,3 +604,39 @@ func (s *resolverSuite) TestNoOperationIfHashesAllMatch(c *gc.C) {
 	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
 	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
 }
+
+func (s *resolverSuite) TestNoOperationInMaintenance(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:       operation.Continue,
+			Installed:  true,
+			Started:    true,
+			ConfigHash: "somehash",
+		},
+	}
+	s.remoteState.ConfigHash = "differenthash"
+	s.remoteState.Maintenance = true
+
+	_, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+}
+
+func (s *resolverSuite) TestDyingInMaintenanceRunsHooks(c *gc.C) {
+	localState := resolver.LocalState{
+		CharmModifiedVersion: s.charmModifiedVersion,
+		CharmURL:             s.charmURL,
+		State: operation.State{
+			Kind:      operation.Continue,
+			Installed: true,
+			Started:   true,
+		},
+	}
+	s.remoteState.Maintenance = true
+	s.remoteState.Life = life.Dying
+
+	op, err := s.resolver.NextOp(localState, s.remoteState, s.opFactory)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(op.String(), gc.Equals, "run stop hook")
+}
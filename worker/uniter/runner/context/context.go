@@ -143,6 +143,14 @@ type HookContext struct {
 	// availabilityzone is the cached value of the unit's availability zone name.
 	availabilityzone string
 
+	// networkInfo holds the network info read for each binding,
+	// keyed by relation ID and then binding name.
+	networkInfo map[int]map[string]params.NetworkInfoResult
+
+	// relationsNetworkInfo holds the network info of the unit's
+	// relations read for each binding.
+	relationsNetworkInfo map[string][]params.RelationNetworkInfo
+
 	// configSettings holds the application configuration.
 	configSettings charm.Settings
 
@@ -397,6 +405,46 @@ func (ctx *HookContext) ApplicationStatus() (jujuc.ApplicationStatusInfo, error)
 	}, nil
 }
 
+// UnitStatusHistory returns up to size of this unit's most recent
+// workload statuses, newest first.
+func (ctx *HookContext) UnitStatusHistory(size int) ([]jujuc.StatusInfo, error) {
+	history, err := ctx.unit.StatusHistory(status.KindWorkload, size)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]jujuc.StatusInfo, len(history))
+	for i, h := range history {
+		result[i] = jujuc.StatusInfo{
+			Tag:    ctx.unit.Tag().String(),
+			Status: h.Status,
+			Info:   h.Info,
+			Data:   h.Data,
+			Since:  h.Since,
+		}
+	}
+	return result, nil
+}
+
+// PeerUnitStatuses returns the workload statuses of the other units of
+// this unit's application.
+func (ctx *HookContext) PeerUnitStatuses() ([]jujuc.StatusInfo, error) {
+	peers, err := ctx.unit.PeerStatuses()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]jujuc.StatusInfo, len(peers))
+	for i, p := range peers {
+		result[i] = jujuc.StatusInfo{
+			Tag:    names.NewUnitTag(p.Id).String(),
+			Status: p.Status,
+			Info:   p.Info,
+			Data:   p.Data,
+			Since:  p.Since,
+		}
+	}
+	return result, nil
+}
+
 // SetUnitStatus will set the given status for this unit.
 func (ctx *HookContext) SetUnitStatus(unitStatus jujuc.StatusInfo) error {
 	ctx.hasRunStatusSet = true
@@ -1008,10 +1056,62 @@ func (ctx *HookContext) SetUnitWorkloadVersion(version string) error {
 }
 
 // NetworkInfo returns the network info for the given bindings on the given relation.
+// The info for each binding and relation is only read once in a hook.
 func (ctx *HookContext) NetworkInfo(bindingNames []string, relationId int) (map[string]params.NetworkInfoResult, error) {
+	cached := ctx.networkInfo[relationId]
+	result := make(map[string]params.NetworkInfoResult)
+	var unread []string
+	for _, name := range bindingNames {
+		if info, ok := cached[name]; ok {
+			result[name] = info
+		} else {
+			unread = append(unread, name)
+		}
+	}
+	if len(unread) == 0 {
+		return result, nil
+	}
+
 	var relId *int
 	if relationId != -1 {
 		relId = &relationId
 	}
-	return ctx.unit.NetworkInfo(bindingNames, relId)
+	infos, err := ctx.unit.NetworkInfo(unread, relId)
+	if err != nil {
+		return nil, err
+	}
+	if cached == nil {
+		if ctx.networkInfo == nil {
+			ctx.networkInfo = make(map[int]map[string]params.NetworkInfoResult)
+		}
+		cached = make(map[string]params.NetworkInfoResult)
+		ctx.networkInfo[relationId] = cached
+	}
+	for name, info := range infos {
+		// Errors aren't cached, so that the info can be asked
+		// for again.
+		if info.Error == nil {
+			cached[name] = info
+		}
+		result[name] = info
+	}
+	return result, nil
+}
+
+// RelationsNetworkInfo returns the network info of each of the unit's
+// relations on the given binding. The info for each binding is only
+// read once in a hook.
+func (ctx *HookContext) RelationsNetworkInfo(bindingName string) ([]params.RelationNetworkInfo, error) {
+	if infos, ok := ctx.relationsNetworkInfo[bindingName]; ok {
+		return infos, nil
+	}
+	infos, err := ctx.unit.RelationsNetworkInfo(bindingName)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if ctx.relationsNetworkInfo == nil {
+		ctx.relationsNetworkInfo = make(map[string][]params.RelationNetworkInfo)
+	}
+	ctx.relationsNetworkInfo[bindingName] = infos
+	return infos, nil
 }
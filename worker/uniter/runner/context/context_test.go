@@ -4,6 +4,7 @@
 package context_test
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/juju/errors"
@@ -117,6 +118,22 @@ func (s *InterfaceSuite) TestUnitNetworkInfo(c *gc.C) {
 	)
 }
 
+func (s *InterfaceSuite) TestRelationsNetworkInfo(c *gc.C) {
+	ctx := s.GetContext(c, -1, "")
+	infos, err := ctx.RelationsNetworkInfo("db")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infos, gc.HasLen, 2)
+	for i, info := range infos {
+		c.Check(info.Endpoint, gc.Equals, "db")
+		c.Check(info.RemoteApplication, gc.Equals, fmt.Sprintf("db%d", i))
+		c.Check(info.CrossModel, jc.IsFalse)
+	}
+
+	infos, err = ctx.RelationsNetworkInfo("cache")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(infos, gc.HasLen, 0)
+}
+
 func (s *InterfaceSuite) TestUnitStatus(c *gc.C) {
 	ctx := s.GetContext(c, -1, "")
 	defer context.PatchCachedStatus(ctx.(runner.Context), "maintenance", "working", map[string]interface{}{"hello": "world"})()
@@ -127,6 +127,14 @@ type ContextStatus interface {
 
 	// SetApplicationStatus updates the status for the unit's application.
 	SetApplicationStatus(StatusInfo) error
+
+	// UnitStatusHistory returns up to size of the executing unit's
+	// most recent workload statuses, newest first.
+	UnitStatusHistory(size int) ([]StatusInfo, error)
+
+	// PeerUnitStatuses returns the workload statuses of the other
+	// units of the executing unit's application.
+	PeerUnitStatuses() ([]StatusInfo, error)
 }
 
 // RebootPriority is the type used for reboot requests.
@@ -169,6 +177,10 @@ type ContextNetworking interface {
 
 	// NetworkInfo returns the network info for the given bindings on the given relation.
 	NetworkInfo(bindingNames []string, relationId int) (map[string]params.NetworkInfoResult, error)
+
+	// RelationsNetworkInfo returns the network info of each of the
+	// unit's relations on the given binding.
+	RelationsNetworkInfo(bindingName string) ([]params.RelationNetworkInfo, error)
 }
 
 // ContextLeadership is the part of a hook context related to the
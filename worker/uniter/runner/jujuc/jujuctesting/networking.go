@@ -18,6 +18,7 @@ type NetworkInterface struct {
 	PrivateAddress     string
 	Ports              []network.PortRange
 	NetworkInfoResults map[string]params.NetworkInfoResult
+	RelationsNetwork   []params.RelationNetworkInfo
 }
 
 // CheckPorts checks the current ports.
@@ -112,3 +113,19 @@ func (c *ContextNetworking) NetworkInfo(bindingNames []string, relationId int) (
 
 	return c.info.NetworkInfoResults, nil
 }
+
+// RelationsNetworkInfo implements jujuc.ContextNetworking.
+func (c *ContextNetworking) RelationsNetworkInfo(bindingName string) ([]params.RelationNetworkInfo, error) {
+	c.stub.AddCall("RelationsNetworkInfo", bindingName)
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var result []params.RelationNetworkInfo
+	for _, info := range c.info.RelationsNetwork {
+		if info.Endpoint == bindingName {
+			result = append(result, info)
+		}
+	}
+	return result, nil
+}
@@ -13,6 +13,8 @@ import (
 type Status struct {
 	UnitStatus        jujuc.StatusInfo
 	ApplicationStatus jujuc.ApplicationStatusInfo
+	History           []jujuc.StatusInfo
+	Peers             []jujuc.StatusInfo
 }
 
 // SetApplicationStatus builds a application status and sets it on the Status.
@@ -70,3 +72,26 @@ func (c *ContextStatus) SetApplicationStatus(status jujuc.StatusInfo) error {
 	c.info.SetApplicationStatus(status, nil)
 	return nil
 }
+
+// UnitStatusHistory implements jujuc.ContextStatus.
+func (c *ContextStatus) UnitStatusHistory(size int) ([]jujuc.StatusInfo, error) {
+	c.stub.AddCall("UnitStatusHistory", size)
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if size < len(c.info.History) {
+		return c.info.History[:size], nil
+	}
+	return c.info.History, nil
+}
+
+// PeerUnitStatuses implements jujuc.ContextStatus.
+func (c *ContextStatus) PeerUnitStatuses() ([]jujuc.StatusInfo, error) {
+	c.stub.AddCall("PeerUnitStatuses")
+	if err := c.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return c.info.Peers, nil
+}
@@ -28,6 +28,7 @@ type NetworkGetCommand struct {
 	bindAddress    bool
 	ingressAddress bool
 	egressSubnets  bool
+	relations      bool
 	keys           []string
 
 	// deprecated
@@ -52,7 +53,7 @@ func NewNetworkGetCommand(ctx Context) (_ cmd.Command, err error) {
 
 // Info is part of the cmd.Command interface.
 func (c *NetworkGetCommand) Info() *cmd.Info {
-	args := "<binding-name> [--ingress-address] [--bind-address] [--egress-subnets]"
+	args := "<binding-name> [--ingress-address] [--bind-address] [--egress-subnets] [--relations]"
 	doc := `
 network-get returns the network config for a given binding name. By default
 it returns the list of interfaces and associated addresses in the space for
@@ -64,6 +65,12 @@ If more than one flag is specified, a map of values is returned.
                     as the address that should be advertised to its peers.
     --ingress-address: the address the local unit should advertise as being used for incoming connections.
     --egress-subnets: subnets (in CIDR notation) from which traffic on this relation will originate.
+
+With --relations, network-get instead returns the ingress addresses and
+egress subnets resolved for each of the relations on the binding, keyed
+by relation id, along with the space the binding is bound to and whether
+the relation is cross-model. It cannot be combined with the flags above,
+and reports every relation on the binding whatever -r is.
 `
 	return jujucmd.Info(&cmd.Info{
 		Name:    "network-get",
@@ -80,6 +87,7 @@ func (c *NetworkGetCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.bindAddress, "bind-address", false, "get the address for the binding on which the unit should listen")
 	f.BoolVar(&c.ingressAddress, "ingress-address", false, "get the ingress address for the binding")
 	f.BoolVar(&c.egressSubnets, "egress-subnets", false, "get the egress subnets for the binding")
+	f.BoolVar(&c.relations, "relations", false, "get the ingress addresses and egress subnets of each relation on the binding")
 	f.Var(c.relationIdProxy, "r", "specify a relation by id")
 	f.Var(c.relationIdProxy, "relation", "")
 }
@@ -108,11 +116,29 @@ func (c *NetworkGetCommand) Init(args []string) error {
 	if c.egressSubnets {
 		c.keys = append(c.keys, egressSubnetsKey)
 	}
+	if c.relations && (c.primaryAddress || len(c.keys) > 0) {
+		return fmt.Errorf("--relations must be the only flag specified")
+	}
 
 	return cmd.CheckEmpty(args[1:])
 }
 
+// relationNetwork is the network config network-get --relations returns
+// for each relation.
+type relationNetwork struct {
+	RemoteApplication string   `yaml:"remote-application,omitempty" json:"remote-application,omitempty"`
+	CrossModel        bool     `yaml:"cross-model" json:"cross-model"`
+	Space             string   `yaml:"space,omitempty" json:"space,omitempty"`
+	IngressAddresses  []string `yaml:"ingress-addresses,omitempty" json:"ingress-addresses,omitempty"`
+	EgressSubnets     []string `yaml:"egress-subnets,omitempty" json:"egress-subnets,omitempty"`
+	Error             string   `yaml:"error,omitempty" json:"error,omitempty"`
+}
+
 func (c *NetworkGetCommand) Run(ctx *cmd.Context) error {
+	if c.relations {
+		return c.writeRelations(ctx)
+	}
+
 	netInfo, err := c.ctx.NetworkInfo([]string{c.bindingName}, c.RelationId)
 	if err != nil {
 		return errors.Trace(err)
@@ -170,6 +196,38 @@ func (c *NetworkGetCommand) Run(ctx *cmd.Context) error {
 	return c.out.Write(ctx, keyValues)
 }
 
+// writeRelations writes the network config of each of the relations on
+// the binding, keyed by relation id.
+func (c *NetworkGetCommand) writeRelations(ctx *cmd.Context) error {
+	infos, err := c.ctx.RelationsNetworkInfo(c.bindingName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	addressForHost := cachingResolver(LookupHost)
+	relations := make(map[string]relationNetwork)
+	for _, info := range infos {
+		relation := relationNetwork{
+			RemoteApplication: info.RemoteApplication,
+			CrossModel:        info.CrossModel,
+			Space:             info.Space,
+			EgressSubnets:     info.EgressSubnets,
+		}
+		if info.Error != nil {
+			relation.Error = info.Error.Error()
+		}
+		for _, addr := range info.IngressAddresses {
+			if ip := net.ParseIP(addr); ip == nil {
+				if resolvedAddr := addressForHost(addr); resolvedAddr != "" {
+					addr = resolvedAddr
+				}
+			}
+			relation.IngressAddresses = append(relation.IngressAddresses, addr)
+		}
+		relations[fmt.Sprintf("%s:%d", info.Endpoint, info.RelationId)] = relation
+	}
+	return c.out.Write(ctx, relations)
+}
+
 // TODO(externalreality) This addresses the immediate problem of
 // https://bugs.launchpad.net/juju/+bug/1721368, but the hostname can populate
 // both the egress subnet CIDR and the ingress addresses. These too should be
@@ -179,19 +237,16 @@ func (c *NetworkGetCommand) Run(ctx *cmd.Context) error {
 func resolveNetworkInfoAddresses(
 	netInfoResult params.NetworkInfoResult, lookupHost resolver,
 ) params.NetworkInfoResult {
-	// Maintain a cache of host-name -> address resolutions.
-	resolved := make(map[string]string)
-	addressForHost := func(hostName string) string {
-		resolvedAddr, ok := resolved[hostName]
-		if !ok {
-			resolvedAddr = resolveHostAddress(hostName, lookupHost)
-			resolved[hostName] = resolvedAddr
-		}
-		return resolvedAddr
-	}
+	addressForHost := cachingResolver(lookupHost)
+
+	// The hook context keeps the result for later calls, so the
+	// slices holding addresses are copied before they're changed.
+	netInfoResult.Info = append([]params.NetworkInfo(nil), netInfoResult.Info...)
+	netInfoResult.IngressAddresses = append([]string(nil), netInfoResult.IngressAddresses...)
 
 	// Resolve addresses in Info.
 	for i, info := range netInfoResult.Info {
+		netInfoResult.Info[i].Addresses = append([]params.InterfaceAddress(nil), info.Addresses...)
 		for j, addr := range info.Addresses {
 			if ip := net.ParseIP(addr.Address); ip == nil {
 				resolvedAddr := addressForHost(addr.Address)
@@ -217,6 +272,20 @@ func resolveNetworkInfoAddresses(
 	return netInfoResult
 }
 
+// cachingResolver returns a function which resolves a host name to an
+// address, or "" if it cannot, remembering each resolution.
+func cachingResolver(lookupHost resolver) func(hostName string) string {
+	resolved := make(map[string]string)
+	return func(hostName string) string {
+		resolvedAddr, ok := resolved[hostName]
+		if !ok {
+			resolvedAddr = resolveHostAddress(hostName, lookupHost)
+			resolved[hostName] = resolvedAddr
+		}
+		return resolvedAddr
+	}
+}
+
 func resolveHostAddress(hostName string, lookupHost resolver) string {
 	resolved, err := lookupHost(hostName)
 	if err != nil {
@@ -138,6 +138,26 @@ func (s *NetworkGetSuite) createCommand(c *gc.C) cmd.Command {
 	}
 
 	hctx.info.NetworkInterface.NetworkInfoResults = presetBindings
+	hctx.info.NetworkInterface.RelationsNetwork = []params.RelationNetworkInfo{{
+		RelationId:        0,
+		Endpoint:          "known-relation",
+		RemoteApplication: "mysql",
+		Space:             "alpha",
+		IngressAddresses:  []string{"10.10.0.23"},
+		EgressSubnets:     []string{"10.10.0.23/32"},
+	}, {
+		RelationId:        3,
+		Endpoint:          "known-relation",
+		RemoteApplication: "remote-mysql",
+		CrossModel:        true,
+		Space:             "alpha",
+		IngressAddresses:  []string{"resolvable-hostname"},
+		EgressSubnets:     []string{"192.168.0.0/16"},
+	}, {
+		RelationId: 4,
+		Endpoint:   "known-extra",
+		Error:      &params.Error{Message: "no public address"},
+	}}
 
 	com, err := jujuc.NewCommand(hctx, cmdString("network-get"))
 	c.Assert(err, jc.ErrorIsNil)
@@ -272,6 +292,38 @@ egress-subnets:
 ingress-addresses:
 - 100.1.2.3
 - 100.4.3.2`[1:],
+	}, {
+		summary: "relations on a binding",
+		args:    []string{"known-relation", "--relations"},
+		out: `
+known-relation:0:
+  remote-application: mysql
+  cross-model: false
+  space: alpha
+  ingress-addresses:
+  - 10.10.0.23
+  egress-subnets:
+  - 10.10.0.23/32
+known-relation:3:
+  remote-application: remote-mysql
+  cross-model: true
+  space: alpha
+  ingress-addresses:
+  - 10.3.3.3
+  egress-subnets:
+  - 192.168.0.0/16`[1:],
+	}, {
+		summary: "relation whose network config could not be resolved",
+		args:    []string{"known-extra", "--relations"},
+		out: `
+known-extra:4:
+  cross-model: false
+  error: no public address`[1:],
+	}, {
+		summary: "relations with another flag",
+		args:    []string{"known-relation", "--relations", "--ingress-address"},
+		code:    2,
+		out:     `--relations must be the only flag specified`,
 	}, {
 		summary: "a resolvable hostname as address, no args",
 		args:    []string{"resolvable-hostname"},
@@ -308,7 +360,7 @@ ingress-addresses:
 
 func (s *NetworkGetSuite) TestHelp(c *gc.C) {
 
-	helpLine := `Usage: network-get [options] <binding-name> [--ingress-address] [--bind-address] [--egress-subnets]`
+	helpLine := `Usage: network-get [options] <binding-name> [--ingress-address] [--bind-address] [--egress-subnets] [--relations]`
 
 	com := s.createCommand(c)
 	ctx := cmdtesting.Context(c)
@@ -58,6 +58,16 @@ func (*RestrictedContext) SetApplicationStatus(StatusInfo) error {
 	return ErrRestrictedContext
 }
 
+// UnitStatusHistory implements hooks.Context.
+func (*RestrictedContext) UnitStatusHistory(int) ([]StatusInfo, error) {
+	return nil, ErrRestrictedContext
+}
+
+// PeerUnitStatuses implements hooks.Context.
+func (*RestrictedContext) PeerUnitStatuses() ([]StatusInfo, error) {
+	return nil, ErrRestrictedContext
+}
+
 // AvailabilityZone implements hooks.Context.
 func (*RestrictedContext) AvailabilityZone() (string, error) { return "", ErrRestrictedContext }
 
@@ -90,6 +100,11 @@ func (*RestrictedContext) NetworkInfo(bindingNames []string, relationId int) (ma
 	return map[string]params.NetworkInfoResult{}, ErrRestrictedContext
 }
 
+// RelationsNetworkInfo implements hooks.Context.
+func (*RestrictedContext) RelationsNetworkInfo(bindingName string) ([]params.RelationNetworkInfo, error) {
+	return nil, ErrRestrictedContext
+}
+
 // IsLeader implements hooks.Context.
 func (*RestrictedContext) IsLeader() (bool, error) { return false, ErrRestrictedContext }
 
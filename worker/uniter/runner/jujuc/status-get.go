@@ -4,6 +4,8 @@
 package jujuc
 
 import (
+	"time"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
@@ -18,6 +20,8 @@ type StatusGetCommand struct {
 	ctx             Context
 	includeData     bool
 	applicationWide bool
+	peers           bool
+	history         int
 	out             cmd.Output
 }
 
@@ -29,10 +33,18 @@ func (c *StatusGetCommand) Info() *cmd.Info {
 	doc := `
 By default, only the status value is printed.
 If the --include-data flag is passed, the associated data are printed also.
+
+The --history option prints up to the given number of the unit's most
+recent workload statuses, newest first, each with its message and the
+time it was set.
+
+The --peers option prints the workload statuses of the other units of
+the unit's application. Unlike --application, it doesn't require the
+unit to be the leader.
 `
 	return jujucmd.Info(&cmd.Info{
 		Name:    "status-get",
-		Args:    "[--include-data] [--application]",
+		Args:    "[--include-data] [--application | --peers | --history <n>]",
 		Purpose: "print status information",
 		Doc:     doc,
 	})
@@ -42,9 +54,23 @@ func (c *StatusGetCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.out.AddFlags(f, "smart", cmd.DefaultFormatters)
 	f.BoolVar(&c.includeData, "include-data", false, "print all status data")
 	f.BoolVar(&c.applicationWide, "application", false, "print status for all units of this application if this unit is the leader")
+	f.BoolVar(&c.peers, "peers", false, "print the workload status of the other units of this application")
+	f.IntVar(&c.history, "history", 0, "print this many of the unit's most recent workload statuses")
 }
 
 func (c *StatusGetCommand) Init(args []string) error {
+	if c.history < 0 {
+		return errors.NotValidf("negative --history %d", c.history)
+	}
+	options := 0
+	for _, set := range []bool{c.applicationWide, c.peers, c.history > 0} {
+		if set {
+			options++
+		}
+	}
+	if options > 1 {
+		return errors.New("only one of --application, --peers and --history may be used")
+	}
 	return cmd.CheckEmpty(args)
 }
 
@@ -54,6 +80,8 @@ type StatusInfo struct {
 	Status string
 	Info   string
 	Data   map[string]interface{}
+	// Since is when the status was set, if known.
+	Since *time.Time
 }
 
 // ApplicationStatusInfo holds StatusInfo for an Application and all its Units.
@@ -102,11 +130,50 @@ func (c *StatusGetCommand) ApplicationStatus(ctx *cmd.Context) error {
 
 }
 
+// StatusHistory writes the unit's most recent workload statuses.
+func (c *StatusGetCommand) StatusHistory(ctx *cmd.Context) error {
+	history, err := c.ctx.UnitStatusHistory(c.history)
+	if err != nil {
+		return errors.Annotatef(err, "finding workload status history")
+	}
+	entries := make([]map[string]interface{}, len(history))
+	for i, info := range history {
+		// The message and time are always wanted when looking
+		// through the history.
+		details := toDetails(info, c.includeData)
+		details["message"] = info.Info
+		if info.Since != nil {
+			details["since"] = info.Since.UTC().Format(time.RFC3339)
+		}
+		entries[i] = details
+	}
+	return c.out.Write(ctx, entries)
+}
+
+// PeerStatuses writes the workload statuses of the other units of the
+// unit's application.
+func (c *StatusGetCommand) PeerStatuses(ctx *cmd.Context) error {
+	peers, err := c.ctx.PeerUnitStatuses()
+	if err != nil {
+		return errors.Annotatef(err, "finding peer unit statuses")
+	}
+	units := make(map[string]interface{}, len(peers))
+	for _, peer := range peers {
+		units[peer.Tag] = toDetails(peer, c.includeData)
+	}
+	return c.out.Write(ctx, units)
+}
+
 func (c *StatusGetCommand) unitOrApplicationStatus(ctx *cmd.Context) error {
 	var err error
 
-	if c.applicationWide {
+	switch {
+	case c.applicationWide:
 		return c.ApplicationStatus(ctx)
+	case c.peers:
+		return c.PeerStatuses(ctx)
+	case c.history > 0:
+		return c.StatusHistory(ctx)
 	}
 
 	unitStatus, err := c.ctx.UnitStatus()
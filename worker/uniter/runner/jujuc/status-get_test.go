@@ -7,6 +7,7 @@ import (
 	"encoding/json"
 	"io/ioutil"
 	"path/filepath"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -103,7 +104,7 @@ func (s *statusGetSuite) TestHelp(c *gc.C) {
 	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{"--help"})
 	c.Assert(code, gc.Equals, 0)
 	expectedHelp := "" +
-		"Usage: status-get [options] [--include-data] [--application]\n" +
+		"Usage: status-get [options] [--include-data] [--application | --peers | --history <n>]\n" +
 		"\n" +
 		"Summary:\n" +
 		"print status information\n" +
@@ -113,14 +114,26 @@ func (s *statusGetSuite) TestHelp(c *gc.C) {
 		"    print status for all units of this application if this unit is the leader\n" +
 		"--format  (= smart)\n" +
 		"    Specify output format (json|smart|yaml)\n" +
+		"--history  (= 0)\n" +
+		"    print this many of the unit's most recent workload statuses\n" +
 		"--include-data  (= false)\n" +
 		"    print all status data\n" +
 		"-o, --output (= \"\")\n" +
 		"    Specify an output file\n" +
+		"--peers  (= false)\n" +
+		"    print the workload status of the other units of this application\n" +
 		"\n" +
 		"Details:\n" +
 		"By default, only the status value is printed.\n" +
-		"If the --include-data flag is passed, the associated data are printed also.\n"
+		"If the --include-data flag is passed, the associated data are printed also.\n" +
+		"\n" +
+		"The --history option prints up to the given number of the unit's most\n" +
+		"recent workload statuses, newest first, each with its message and the\n" +
+		"time it was set.\n" +
+		"\n" +
+		"The --peers option prints the workload statuses of the other units of\n" +
+		"the unit's application. Unlike --application, it doesn't require the\n" +
+		"unit to be the leader.\n"
 
 	c.Assert(bufferString(ctx.Stdout), gc.Equals, expectedHelp)
 	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
@@ -171,3 +184,99 @@ func (s *statusGetSuite) TestApplicationStatus(c *gc.C) {
 	c.Assert(out, gc.DeepEquals, expected)
 
 }
+
+func (s *statusGetSuite) TestStatusHistory(c *gc.C) {
+	hctx := s.GetStatusHookContext(c)
+	since := time.Date(2020, 3, 4, 5, 6, 7, 0, time.UTC)
+	earlier := since.Add(-time.Hour)
+	hctx.info.Status.History = []jujuc.StatusInfo{{
+		Status: "blocked",
+		Info:   "need a database",
+		Data:   map[string]interface{}{"foo": "bar"},
+		Since:  &since,
+	}, {
+		Status: "maintenance",
+		Info:   "installing",
+		Since:  &earlier,
+	}, {
+		Status: "waiting",
+		Info:   "waiting for machine",
+	}}
+	com, err := jujuc.NewCommand(hctx, cmdString("status-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{"--format", "json", "--history", "2"})
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+
+	var out []map[string]interface{}
+	c.Assert(json.Unmarshal(bufferBytes(ctx.Stdout), &out), gc.IsNil)
+	c.Assert(out, gc.DeepEquals, []map[string]interface{}{{
+		"status":  "blocked",
+		"message": "need a database",
+		"since":   "2020-03-04T05:06:07Z",
+	}, {
+		"status":  "maintenance",
+		"message": "installing",
+		"since":   "2020-03-04T04:06:07Z",
+	}})
+	s.Stub.CheckCall(c, 0, "UnitStatusHistory", 2)
+}
+
+func (s *statusGetSuite) TestPeerStatuses(c *gc.C) {
+	hctx := s.GetStatusHookContext(c)
+	hctx.info.Status.Peers = []jujuc.StatusInfo{{
+		Tag:    "unit-wordpress-1",
+		Status: "active",
+		Info:   "serving",
+	}, {
+		Tag:    "unit-wordpress-2",
+		Status: "blocked",
+		Info:   "need a database",
+		Data:   map[string]interface{}{"foo": "bar"},
+	}}
+	com, err := jujuc.NewCommand(hctx, cmdString("status-get"))
+	c.Assert(err, jc.ErrorIsNil)
+	ctx := cmdtesting.Context(c)
+	code := cmd.Main(jujuc.NewJujucCommandWrappedForTest(com), ctx, []string{"--format", "json", "--include-data", "--peers"})
+	c.Assert(code, gc.Equals, 0)
+	c.Assert(bufferString(ctx.Stderr), gc.Equals, "")
+
+	var out map[string]interface{}
+	c.Assert(json.Unmarshal(bufferBytes(ctx.Stdout), &out), gc.IsNil)
+	c.Assert(out, gc.DeepEquals, map[string]interface{}{
+		"unit-wordpress-1": map[string]interface{}{
+			"status":      "active",
+			"message":     "serving",
+			"status-data": map[string]interface{}{},
+		},
+		"unit-wordpress-2": map[string]interface{}{
+			"status":      "blocked",
+			"message":     "need a database",
+			"status-data": map[string]interface{}{"foo": "bar"},
+		},
+	})
+}
+
+func (s *statusGetSuite) TestInitErrors(c *gc.C) {
+	for i, t := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--application", "--peers"},
+		err:  "only one of --application, --peers and --history may be used",
+	}, {
+		args: []string{"--peers", "--history", "3"},
+		err:  "only one of --application, --peers and --history may be used",
+	}, {
+		args: []string{"--history", "-1"},
+		err:  "negative --history -1 not valid",
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		hctx := s.GetStatusHookContext(c)
+		com, err := jujuc.NewCommand(hctx, cmdString("status-get"))
+		c.Assert(err, jc.ErrorIsNil)
+		err = cmdtesting.InitCommand(jujuc.NewJujucCommandWrappedForTest(com), t.args)
+		c.Check(err, gc.ErrorMatches, t.err)
+	}
+}
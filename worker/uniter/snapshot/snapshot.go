@@ -0,0 +1,103 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package snapshot keeps a copy of the previous contents of each of the
+// uniter's local state files, so that a state file found to be corrupt,
+// for example after the machine lost power while it was being written,
+// can be replaced by its last good version.
+package snapshot
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils"
+	"gopkg.in/yaml.v2"
+)
+
+var logger = loggo.GetLogger("juju.worker.uniter.snapshot")
+
+// Path returns the path of the snapshot of the file at path.
+func Path(path string) string {
+	return path + ".snapshot"
+}
+
+// WriteYaml marshals obj as YAML and atomically writes it to the file
+// at path, first copying the file's current contents, if any, to its
+// snapshot.
+func WriteYaml(path string, obj interface{}) error {
+	current, err := ioutil.ReadFile(path)
+	if err == nil {
+		if err := utils.AtomicWriteFile(Path(path), current, 0644); err != nil {
+			return errors.Annotatef(err, "cannot snapshot %q", path)
+		}
+	} else if !os.IsNotExist(err) {
+		return errors.Trace(err)
+	}
+	return utils.WriteYaml(path, obj)
+}
+
+// ReadYaml unmarshals the YAML file at path into obj, which must be a
+// pointer, and checks the result with validate if it is not nil. If the
+// file cannot be parsed or is not valid, its snapshot is read instead
+// and, if that is good, restored to path.
+//
+// If the file does not exist, the error satisfies os.IsNotExist; the
+// snapshot is not used, so that a state file may still be deleted by
+// hand. If neither the file nor its snapshot is good, the file's error
+// satisfies errors.IsNotValid.
+func ReadYaml(path string, obj interface{}, validate func() error) error {
+	err := readYaml(path, obj, validate)
+	if !errors.IsNotValid(err) {
+		return err
+	}
+	snapshotErr := readYaml(Path(path), obj, validate)
+	if snapshotErr != nil {
+		logger.Debugf("cannot read snapshot of %q: %v", path, snapshotErr)
+		return err
+	}
+	logger.Warningf("%q is corrupt (%v); restoring its snapshot", path, err)
+	data, readErr := ioutil.ReadFile(Path(path))
+	if readErr != nil {
+		return errors.Trace(readErr)
+	}
+	if err := utils.AtomicWriteFile(path, data, 0644); err != nil {
+		return errors.Annotatef(err, "cannot restore %q from its snapshot", path)
+	}
+	return nil
+}
+
+// Remove removes the file at path and its snapshot, if they exist.
+func Remove(path string) error {
+	for _, p := range []string{path, Path(path)} {
+		if err := os.Remove(p); err != nil && !os.IsNotExist(err) {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// readYaml unmarshals the YAML file at path into obj after zeroing it,
+// so that nothing is left from an earlier attempt. Errors reading the
+// file are returned unchanged.
+func readYaml(path string, obj interface{}, validate func() error) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	v := reflect.ValueOf(obj).Elem()
+	v.Set(reflect.Zero(v.Type()))
+	if err := yaml.Unmarshal(data, obj); err != nil {
+		return errors.NewNotValid(err, "")
+	}
+	if validate == nil {
+		return nil
+	}
+	if err := validate(); err != nil {
+		return errors.NewNotValid(err, "")
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package snapshot_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter/snapshot"
+)
+
+type SnapshotSuite struct {
+	path string
+}
+
+var _ = gc.Suite(&SnapshotSuite{})
+
+type doc struct {
+	Name  string `yaml:"name"`
+	Count int    `yaml:"count,omitempty"`
+}
+
+func (d *doc) validate() error {
+	if d.Name == "" {
+		return errors.New("name not set")
+	}
+	return nil
+}
+
+func (s *SnapshotSuite) SetUpTest(c *gc.C) {
+	s.path = filepath.Join(c.MkDir(), "state")
+}
+
+func (s *SnapshotSuite) read() (doc, error) {
+	var d doc
+	err := snapshot.ReadYaml(s.path, &d, d.validate)
+	return d, err
+}
+
+func (s *SnapshotSuite) write(c *gc.C, path, content string) {
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *SnapshotSuite) TestWriteKeepsPreviousContents(c *gc.C) {
+	err := snapshot.WriteYaml(s.path, &doc{Name: "first"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = os.Stat(snapshot.Path(s.path))
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+
+	err = snapshot.WriteYaml(s.path, &doc{Name: "second"})
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadFile(snapshot.Path(s.path))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "name: first\n")
+
+	d, err := s.read()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(d, jc.DeepEquals, doc{Name: "second"})
+}
+
+func (s *SnapshotSuite) TestReadMissing(c *gc.C) {
+	// A snapshot without its file is not used, so that state files
+	// may be deleted by hand.
+	s.write(c, snapshot.Path(s.path), "name: old\n")
+	_, err := s.read()
+	c.Assert(err, jc.Satisfies, os.IsNotExist)
+}
+
+func (s *SnapshotSuite) TestReadCorruptRestoresSnapshot(c *gc.C) {
+	for i, content := range []string{"", "name: [", "count: 3\n"} {
+		c.Logf("test %d: %q", i, content)
+		s.write(c, snapshot.Path(s.path), "name: good\n")
+		s.write(c, s.path, content)
+
+		d, err := s.read()
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(d, jc.DeepEquals, doc{Name: "good"})
+		data, err := ioutil.ReadFile(s.path)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(string(data), gc.Equals, "name: good\n")
+	}
+}
+
+func (s *SnapshotSuite) TestReadCorruptWithoutSnapshot(c *gc.C) {
+	s.write(c, s.path, "count: 3\n")
+	_, err := s.read()
+	c.Assert(err, gc.ErrorMatches, "name not set")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	s.write(c, snapshot.Path(s.path), "name: [")
+	_, err = s.read()
+	c.Assert(err, gc.ErrorMatches, "name not set")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *SnapshotSuite) TestRemove(c *gc.C) {
+	err := snapshot.Remove(s.path)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.write(c, s.path, "name: current\n")
+	s.write(c, snapshot.Path(s.path), "name: old\n")
+	err = snapshot.Remove(s.path)
+	c.Assert(err, jc.ErrorIsNil)
+	for _, path := range []string{s.path, snapshot.Path(s.path)} {
+		_, err = os.Stat(path)
+		c.Assert(err, jc.Satisfies, os.IsNotExist)
+	}
+}
@@ -348,6 +348,10 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			// error state.
 			return nil
 		}
+		if remoteState := watcher.Snapshot(); remoteState.Maintenance {
+			// The unit isn't idle, but paused by an operator.
+			return setAgentStatus(u, status.Maintenance, remoteState.MaintenanceMessage, nil)
+		}
 		return setAgentStatus(u, status.Idle, "", nil)
 	}
 
@@ -380,6 +384,8 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 			Commands: runcommands.NewCommandsResolver(
 				u.commands, watcher.CommandCompleted,
 			),
+			StartCharmDeployment:  u.unit.StartCharmDeployment,
+			FinishCharmDeployment: u.unit.FinishCharmDeployment,
 		}
 		uniterResolver := NewUniterResolver(cfg)
 
@@ -637,6 +643,9 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 			return errors.Trace(err)
 		}
 	}
+	if err := u.recoverOperationState(initialState); err != nil {
+		return errors.Trace(err)
+	}
 	operationExecutor, err := u.newOperationExecutor(u.paths.State.OperationsFile, initialState, u.acquireExecutionLock)
 	if err != nil {
 		return errors.Trace(err)
@@ -669,6 +678,36 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	return nil
 }
 
+// recoverOperationState replaces the operation state file, if neither
+// it nor its snapshot can be read, with the state of a newly deployed
+// unit running the charm the controller records for it. This has the
+// same effect as deleting the file by hand: the charm is deployed again
+// and its install and start hooks are run again.
+func (u *Uniter) recoverOperationState(initialState operation.State) error {
+	file := operation.NewStateFile(u.paths.State.OperationsFile)
+	_, err := file.Read()
+	if !errors.IsNotValid(err) {
+		// Any other error is reported by the operation executor.
+		return nil
+	}
+	logger.Warningf("%v; reconstructing operation state from the controller", err)
+	st := initialState
+	if st.Kind == operation.Install {
+		charmURL, err := u.unit.CharmURL()
+		switch errors.Cause(err) {
+		case nil:
+			st.CharmURL = charmURL
+		case uniter.ErrNoCharmURLSet:
+		default:
+			return errors.Trace(err)
+		}
+	}
+	if err := file.Write(&st); err != nil {
+		return errors.Annotate(err, "cannot write reconstructed operation state")
+	}
+	return nil
+}
+
 func (u *Uniter) Kill() {
 	u.catacomb.Kill(nil)
 }
@@ -719,9 +758,9 @@ func (u *Uniter) reportHookError(hookInfo hook.Info) error {
 	hookName := string(hookInfo.Kind)
 	statusData := map[string]interface{}{}
 	if hookInfo.Kind.IsRelation() {
-		statusData["relation-id"] = hookInfo.RelationId
+		statusData[status.RelationIdKey] = hookInfo.RelationId
 		if hookInfo.RemoteUnit != "" {
-			statusData["remote-unit"] = hookInfo.RemoteUnit
+			statusData[status.RemoteUnitKey] = hookInfo.RemoteUnit
 		}
 		relationName, err := u.relations.Name(hookInfo.RelationId)
 		if err != nil {
@@ -729,7 +768,7 @@ func (u *Uniter) reportHookError(hookInfo hook.Info) error {
 		}
 		hookName = fmt.Sprintf("%s-%s", relationName, hookInfo.Kind)
 	}
-	statusData["hook"] = hookName
+	statusData[status.HookKey] = hookName
 	statusMessage := fmt.Sprintf("hook failed: %q", hookName)
 	return setAgentStatus(u, status.Error, statusMessage, statusData)
 }
@@ -172,6 +172,35 @@ func (s *UniterSuite) TestPreviousDownloadsCleared(c *gc.C) {
 	})
 }
 
+func (s *UniterSuite) TestUniterCorruptOperationState(c *gc.C) {
+	s.runUniterTests(c, []uniterTest{
+		ut(
+			"corrupt operation state is restored from its snapshot",
+			quickStart{},
+			stopUniter{},
+			custom{func(c *gc.C, ctx *context) {
+				ft.File{"state/uniter", "", 0644}.Create(c, ctx.path)
+			}},
+			startUniter{},
+			changeConfig{"blog-title": "Goodness Gracious Me"},
+			waitHooks{"config-changed"},
+			verifyRunning{},
+		), ut(
+			"corrupt operation state without a snapshot is reconstructed",
+			quickStart{},
+			stopUniter{},
+			custom{func(c *gc.C, ctx *context) {
+				ft.File{"state/uniter", "kind: [", 0644}.Create(c, ctx.path)
+				ft.File{"state/uniter.snapshot", "", 0644}.Create(c, ctx.path)
+			}},
+			startUniter{},
+			waitUnitAgent{status: status.Idle},
+			waitHooks(startupHooks(false)),
+			verifyCharm{},
+		),
+	})
+}
+
 func (s *UniterSuite) TestUniterBootstrap(c *gc.C) {
 	//TODO(bogdanteleaga): Fix this on windows
 	if runtime.GOOS == "windows" {
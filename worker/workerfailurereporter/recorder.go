@@ -0,0 +1,113 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package workerfailurereporter
+
+import (
+	"sync"
+
+	"github.com/juju/clock"
+	"github.com/juju/errors"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/core/status"
+)
+
+// Recorder records the failures of the workers run by an agent's
+// dependency engine, until the worker reports them to the controller.
+// At most status.MaxWorkerFailures failures are kept; older ones are
+// dropped.
+//
+// A Recorder outlives the engines whose manifolds it decorates, so
+// that failures aren't lost when the engine is restarted.
+type Recorder struct {
+	clock clock.Clock
+
+	mu      sync.Mutex
+	pending []status.WorkerFailure
+	changes chan struct{}
+}
+
+// NewRecorder returns a new Recorder which timestamps failures using
+// the given clock.
+func NewRecorder(clock clock.Clock) *Recorder {
+	return &Recorder{
+		clock:   clock,
+		changes: make(chan struct{}, 1),
+	}
+}
+
+// Decorate returns copies of the given manifolds, whose errors are
+// recorded before being passed to the manifolds' own filters.
+func (r *Recorder) Decorate(manifolds dependency.Manifolds) dependency.Manifolds {
+	result := make(dependency.Manifolds, len(manifolds))
+	for name, manifold := range manifolds {
+		manifold.Filter = r.filter(name, manifold.Filter)
+		result[name] = manifold
+	}
+	return result
+}
+
+func (r *Recorder) filter(name string, filter dependency.FilterFunc) dependency.FilterFunc {
+	return func(err error) error {
+		r.Record(name, err)
+		if filter == nil {
+			return err
+		}
+		return filter(err)
+	}
+}
+
+// Record records that the named worker failed with the given error.
+// Errors which the dependency engine uses to control workers, such as
+// dependency.ErrMissing, aren't failures and are ignored.
+func (r *Recorder) Record(name string, err error) {
+	switch errors.Cause(err) {
+	case nil, dependency.ErrMissing, dependency.ErrBounce, dependency.ErrUninstall:
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pending = status.AppendWorkerFailures(r.pending, status.WorkerFailure{
+		Worker: name,
+		Error:  err.Error(),
+		Time:   r.clock.Now(),
+	})
+	r.notify()
+}
+
+// Changes returns a channel which is signalled when there are failures
+// to take.
+func (r *Recorder) Changes() <-chan struct{} {
+	return r.changes
+}
+
+// Take returns the failures recorded since it was last called, oldest
+// first.
+func (r *Recorder) Take() []status.WorkerFailure {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	failures := r.pending
+	r.pending = nil
+	return failures
+}
+
+// Requeue returns failures which could not be reported, so that they
+// are taken again ahead of any recorded since.
+func (r *Recorder) Requeue(failures []status.WorkerFailure) {
+	if len(failures) == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.pending = status.AppendWorkerFailures(failures, r.pending...)
+	r.notify()
+}
+
+// notify must be called with mu held.
+func (r *Recorder) notify() {
+	select {
+	case r.changes <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package workerfailurereporter_test
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1/dependency"
+
+	"github.com/juju/juju/core/status"
+	"github.com/juju/juju/worker/workerfailurereporter"
+)
+
+type RecorderSuite struct {
+	testing.IsolationSuite
+	clock    *testclock.Clock
+	recorder *workerfailurereporter.Recorder
+}
+
+var _ = gc.Suite(&RecorderSuite{})
+
+func (s *RecorderSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testclock.NewClock(time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC))
+	s.recorder = workerfailurereporter.NewRecorder(s.clock)
+}
+
+func (s *RecorderSuite) TestRecord(c *gc.C) {
+	s.recorder.Record("disk-manager", errors.New("boom"))
+	select {
+	case <-s.recorder.Changes():
+	default:
+		c.Fatalf("recorder not signalled")
+	}
+	c.Assert(s.recorder.Take(), jc.DeepEquals, []status.WorkerFailure{{
+		Worker: "disk-manager",
+		Error:  "boom",
+		Time:   s.clock.Now(),
+	}})
+	c.Assert(s.recorder.Take(), gc.HasLen, 0)
+}
+
+func (s *RecorderSuite) TestRecordIgnoresEngineErrors(c *gc.C) {
+	for _, err := range []error{
+		nil,
+		dependency.ErrMissing,
+		dependency.ErrBounce,
+		errors.Trace(dependency.ErrUninstall),
+	} {
+		s.recorder.Record("disk-manager", err)
+	}
+	c.Assert(s.recorder.Take(), gc.HasLen, 0)
+	select {
+	case <-s.recorder.Changes():
+		c.Fatalf("recorder unexpectedly signalled")
+	default:
+	}
+}
+
+func (s *RecorderSuite) TestRecordKeepsMostRecent(c *gc.C) {
+	for i := 0; i < status.MaxWorkerFailures+1; i++ {
+		s.recorder.Record(fmt.Sprintf("worker-%d", i), errors.New("boom"))
+	}
+	failures := s.recorder.Take()
+	c.Assert(failures, gc.HasLen, status.MaxWorkerFailures)
+	c.Assert(failures[0].Worker, gc.Equals, "worker-1")
+}
+
+func (s *RecorderSuite) TestRequeue(c *gc.C) {
+	s.recorder.Record("first", errors.New("boom"))
+	failures := s.recorder.Take()
+	s.recorder.Record("second", errors.New("boom"))
+	s.recorder.Requeue(failures)
+
+	var names []string
+	for _, f := range s.recorder.Take() {
+		names = append(names, f.Worker)
+	}
+	c.Assert(names, jc.DeepEquals, []string{"first", "second"})
+}
+
+func (s *RecorderSuite) TestDecorate(c *gc.C) {
+	filtered := errors.New("filtered")
+	manifolds := s.recorder.Decorate(dependency.Manifolds{
+		"plain": dependency.Manifold{},
+		"filtering": dependency.Manifold{
+			Filter: func(error) error { return filtered },
+		},
+	})
+
+	err := errors.New("boom")
+	c.Assert(manifolds["plain"].Filter(err), gc.Equals, err)
+	c.Assert(manifolds["filtering"].Filter(err), gc.Equals, filtered)
+
+	var names []string
+	for _, f := range s.recorder.Take() {
+		c.Check(f.Error, gc.Equals, "boom")
+		names = append(names, f.Worker)
+	}
+	c.Assert(names, jc.DeepEquals, []string{"plain", "filtering"})
+}
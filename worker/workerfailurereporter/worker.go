@@ -0,0 +1,117 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package workerfailurereporter provides a worker which reports the
+// failures of the other workers run by a machine agent to the
+// controller, where a bounded history of them is kept in the status
+// data of the machine's agent. The failures are recorded by a Recorder
+// which decorates the agent's manifolds.
+package workerfailurereporter
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1/catacomb"
+
+	"github.com/juju/juju/core/status"
+)
+
+// logger is here to stop the desire of creating a package level logger.
+// Don't do this, instead pass one through as config to the worker.
+var logger interface{}
+
+// Facade represents the API used by the worker failure reporter
+// worker.
+type Facade interface {
+	AddFailures(names.MachineTag, []status.WorkerFailure) error
+}
+
+// Logger defines the methods used by the worker failure reporter
+// worker for logging.
+type Logger interface {
+	Debugf(string, ...interface{})
+}
+
+// Config holds all necessary attributes to start a worker failure
+// reporter worker.
+type Config struct {
+	Facade     Facade
+	MachineTag names.MachineTag
+	Recorder   *Recorder
+	Logger     Logger
+}
+
+// Validate will err unless basic requirements for a valid
+// config are met.
+func (c Config) Validate() error {
+	if c.Facade == nil {
+		return errors.NotValidf("nil Facade")
+	}
+	if c.MachineTag.Id() == "" {
+		return errors.NotValidf("empty MachineTag")
+	}
+	if c.Recorder == nil {
+		return errors.NotValidf("nil Recorder")
+	}
+	if c.Logger == nil {
+		return errors.NotValidf("nil Logger")
+	}
+	return nil
+}
+
+// Worker reports the worker failures recorded for a machine agent.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// New returns a worker which reports the worker failures recorded by
+// the configured recorder.
+func New(config Config) (*Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &Worker{
+		config: config,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return w, nil
+}
+
+// Kill is defined on worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is defined on worker.Worker.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	recorder := w.config.Recorder
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+
+		case <-recorder.Changes():
+			failures := recorder.Take()
+			if len(failures) == 0 {
+				continue
+			}
+			w.config.Logger.Debugf("reporting %d worker failures", len(failures))
+			if err := w.config.Facade.AddFailures(w.config.MachineTag, failures); err != nil {
+				// Keep the failures to report when restarted.
+				recorder.Requeue(failures)
+				return errors.Trace(err)
+			}
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2020 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package workerfailurereporter_test
+
+import (
+	"time"
+
+	"github.com/juju/clock/testclock"
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v3"
+	"gopkg.in/juju/worker.v1/workertest"
+
+	"github.com/juju/juju/core/status"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/workerfailurereporter"
+)
+
+type WorkerSuite struct {
+	coretesting.BaseSuite
+	facade   *fakeFacade
+	clock    *testclock.Clock
+	recorder *workerfailurereporter.Recorder
+}
+
+var _ = gc.Suite(&WorkerSuite{})
+
+func (s *WorkerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.facade = &fakeFacade{
+		added: make(chan []status.WorkerFailure, 10),
+	}
+	s.clock = testclock.NewClock(time.Date(2020, 5, 1, 10, 0, 0, 0, time.UTC))
+	s.recorder = workerfailurereporter.NewRecorder(s.clock)
+}
+
+func (s *WorkerSuite) config() workerfailurereporter.Config {
+	return workerfailurereporter.Config{
+		Facade:     s.facade,
+		MachineTag: names.NewMachineTag("0"),
+		Recorder:   s.recorder,
+		Logger:     loggo.GetLogger("test"),
+	}
+}
+
+func (s *WorkerSuite) TestValidate(c *gc.C) {
+	config := s.config()
+	config.Recorder = nil
+	_, err := workerfailurereporter.New(config)
+	c.Assert(err, gc.ErrorMatches, "nil Recorder not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+}
+
+func (s *WorkerSuite) TestReportsRecordedFailures(c *gc.C) {
+	// Failures recorded before the worker starts are reported too.
+	s.recorder.Record("disk-manager", errors.New("boom"))
+	w, err := workerfailurereporter.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+	defer workertest.CleanKill(c, w)
+
+	c.Assert(s.nextAdded(c), jc.DeepEquals, []status.WorkerFailure{{
+		Worker: "disk-manager",
+		Error:  "boom",
+		Time:   s.clock.Now(),
+	}})
+
+	s.recorder.Record("machiner", errors.New("kaboom"))
+	failures := s.nextAdded(c)
+	c.Assert(failures, gc.HasLen, 1)
+	c.Assert(failures[0].Worker, gc.Equals, "machiner")
+}
+
+func (s *WorkerSuite) TestRequeuesOnError(c *gc.C) {
+	s.facade.err = errors.New("connection is shut down")
+	s.recorder.Record("disk-manager", errors.New("boom"))
+	w, err := workerfailurereporter.New(s.config())
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.nextAdded(c)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "connection is shut down")
+
+	failures := s.recorder.Take()
+	c.Assert(failures, gc.HasLen, 1)
+	c.Assert(failures[0].Worker, gc.Equals, "disk-manager")
+}
+
+func (s *WorkerSuite) nextAdded(c *gc.C) []status.WorkerFailure {
+	select {
+	case failures := <-s.facade.added:
+		return failures
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for failures to be reported")
+	}
+	return nil
+}
+
+type fakeFacade struct {
+	added chan []status.WorkerFailure
+	err   error
+}
+
+func (f *fakeFacade) AddFailures(tag names.MachineTag, failures []status.WorkerFailure) error {
+	if tag.Id() != "0" {
+		return errors.Errorf("unexpected tag %v", tag)
+	}
+	f.added <- failures
+	return f.err
+}